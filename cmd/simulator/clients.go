@@ -0,0 +1,188 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/rideshare-platform/shared/clients/geoclient"
+	"github.com/rideshare-platform/shared/clients/matchingclient"
+	"github.com/rideshare-platform/shared/clients/paymentclient"
+	"github.com/rideshare-platform/shared/clients/pricingclient"
+	"github.com/rideshare-platform/shared/clients/tripclient"
+	"github.com/rideshare-platform/shared/grpc/interceptor"
+	"github.com/rideshare-platform/shared/middleware"
+	"github.com/rideshare-platform/shared/models"
+	geopb "github.com/rideshare-platform/shared/proto/geo"
+	matchingpb "github.com/rideshare-platform/shared/proto/matching"
+	pricingpb "github.com/rideshare-platform/shared/proto/pricing"
+	trippb "github.com/rideshare-platform/shared/proto/trip"
+	sharedtls "github.com/rideshare-platform/shared/tls"
+)
+
+// clients are the service APIs the simulator calls
+type clients struct {
+	geo      *geoclient.Client
+	matching *matchingclient.Client
+	trips    *tripclient.Client
+	pricing  *pricingclient.Client
+	payments *paymentclient.Client
+
+	http       *http.Client
+	geoURL     string
+	paymentURL string
+	// auth signs the tokens calls are made with, nil without a JWT secret
+	auth *middleware.AuthMiddleware
+}
+
+// dial connects to every service the simulator drives
+func dial(cfg *Config, tlsReloader *sharedtls.Reloader) (*clients, error) {
+	c := &clients{
+		http:       &http.Client{Timeout: 10 * time.Second},
+		geoURL:     cfg.GeoServiceURL,
+		paymentURL: cfg.PaymentServiceURL,
+	}
+	if cfg.JWTSecret != "" {
+		c.auth = middleware.NewAuthMiddleware(cfg.JWTSecret, nil)
+	}
+
+	var err error
+	if c.geo, err = geoclient.Dial(cfg.GeoServiceAddr, tlsReloader.DialOption()); err != nil {
+		return nil, fmt.Errorf("failed to create geo-service client: %w", err)
+	}
+	if c.matching, err = matchingclient.Dial(cfg.MatchingServiceAddr, tlsReloader.DialOption()); err != nil {
+		c.Close()
+		return nil, fmt.Errorf("failed to create matching-service client: %w", err)
+	}
+	if c.trips, err = tripclient.Dial(cfg.TripServiceAddr, tlsReloader.DialOption()); err != nil {
+		c.Close()
+		return nil, fmt.Errorf("failed to create trip-service client: %w", err)
+	}
+	if c.pricing, err = pricingclient.Dial(cfg.PricingServiceAddr, tlsReloader.DialOption()); err != nil {
+		c.Close()
+		return nil, fmt.Errorf("failed to create pricing-service client: %w", err)
+	}
+	if c.payments, err = paymentclient.Dial(cfg.PaymentServiceAddr, tlsReloader.DialOption()); err != nil {
+		c.Close()
+		return nil, fmt.Errorf("failed to create payment-service client: %w", err)
+	}
+	return c, nil
+}
+
+// Close closes the service connections
+func (c *clients) Close() {
+	for _, client := range []interface{ Close() error }{c.geo, c.matching, c.trips, c.pricing, c.payments} {
+		client.Close()
+	}
+}
+
+// actor is a simulated driver or rider, whose calls carry its own token
+type actor struct {
+	id       string
+	userType string
+	token    string
+}
+
+// newActor signs a token for the user when the services check them
+func (c *clients) newActor(id, userType string) (*actor, error) {
+	a := &actor{id: id, userType: userType}
+	if c.auth == nil {
+		return a, nil
+	}
+	token, err := c.auth.GenerateToken(id, userType, id+"@simulator.local", 24)
+	if err != nil {
+		return nil, err
+	}
+	a.token = token
+	return a, nil
+}
+
+// context returns ctx carrying the actor's token in the call metadata
+func (a *actor) context(ctx context.Context) context.Context {
+	if a.token == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, interceptor.AuthorizationMetadataKey, "Bearer "+a.token)
+}
+
+// post sends body as JSON to an HTTP API as the actor and decodes the
+// response into out, if given
+func (c *clients) post(ctx context.Context, a *actor, url string, body, out interface{}) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if a.token != "" {
+		req.Header.Set("Authorization", "Bearer "+a.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("POST %s returned %s", url, resp.Status)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// goOnline starts the driver's shift in geo-service
+func (c *clients) goOnline(ctx context.Context, driver *actor) error {
+	if c.geoURL == "" {
+		return nil
+	}
+	return c.post(ctx, driver, fmt.Sprintf("%s/api/v1/drivers/%s/online", c.geoURL, driver.id), struct{}{}, nil)
+}
+
+// addPaymentMethod gives the rider a wallet to pay for trips with and
+// returns its ID
+func (c *clients) addPaymentMethod(ctx context.Context, rider *actor) (string, error) {
+	if c.paymentURL == "" {
+		return "", nil
+	}
+	var resp struct {
+		PaymentMethod struct {
+			ID string `json:"id"`
+		} `json:"payment_method"`
+	}
+	err := c.post(ctx, rider, c.paymentURL+"/api/v1/payment-methods", map[string]interface{}{
+		"user_id":    rider.id,
+		"type":       "digital_wallet",
+		"details":    map[string]interface{}{"provider": "simulator"},
+		"is_default": true,
+	}, &resp)
+	if err != nil {
+		return "", err
+	}
+	return resp.PaymentMethod.ID, nil
+}
+
+func geoLocation(l models.Location) *geopb.Location {
+	return &geopb.Location{Latitude: l.Latitude, Longitude: l.Longitude}
+}
+
+func matchingLocation(l models.Location) *matchingpb.Location {
+	return &matchingpb.Location{Latitude: l.Latitude, Longitude: l.Longitude}
+}
+
+func tripLocation(l models.Location) *trippb.Location {
+	return &trippb.Location{Latitude: l.Latitude, Longitude: l.Longitude}
+}
+
+func pricingLocation(l models.Location) *pricingpb.Location {
+	return &pricingpb.Location{Latitude: l.Latitude, Longitude: l.Longitude}
+}
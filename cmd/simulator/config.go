@@ -0,0 +1,86 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	sharedconfig "github.com/rideshare-platform/shared/config"
+	sharedtls "github.com/rideshare-platform/shared/tls"
+)
+
+// Config holds the services the simulator drives and the traffic it generates
+type Config struct {
+	GeoServiceAddr      string `yaml:"geo_service_addr" env:"GEO_SERVICE_ADDR" default:"geo-service:50053"`
+	MatchingServiceAddr string `yaml:"matching_service_addr" env:"MATCHING_SERVICE_ADDR" default:"matching-service:8054"`
+	TripServiceAddr     string `yaml:"trip_service_addr" env:"TRIP_SERVICE_ADDR" default:"trip-service:50053"`
+	PricingServiceAddr  string `yaml:"pricing_service_addr" env:"PRICING_SERVICE_ADDR" default:"pricing-service:50053"`
+	PaymentServiceAddr  string `yaml:"payment_service_addr" env:"PAYMENT_SERVICE_ADDR" default:"payment-service:8055"`
+	// GeoServiceURL and PaymentServiceURL are the HTTP APIs drivers go online
+	// and riders add payment methods through. Empty skips those steps.
+	GeoServiceURL     string `yaml:"geo_service_url" env:"GEO_SERVICE_URL" default:"http://geo-service:8053"`
+	PaymentServiceURL string `yaml:"payment_service_url" env:"PAYMENT_SERVICE_URL" default:"http://payment-service:9087"`
+
+	// JWTSecret signs a token for each simulated driver and rider when set
+	JWTSecret string           `yaml:"jwt_secret" env:"JWT_SECRET"`
+	TLS       sharedtls.Config `yaml:"tls"`
+	LogLevel  string           `yaml:"log_level" env:"LOG_LEVEL" default:"info"`
+
+	Drivers        int           `yaml:"drivers" env:"SIM_DRIVERS" default:"20"`
+	Riders         int           `yaml:"riders" env:"SIM_RIDERS" default:"100"`
+	TripsPerMinute float64       `yaml:"trips_per_minute" env:"SIM_TRIPS_PER_MINUTE" default:"10"`
+	Duration       time.Duration `yaml:"duration" env:"SIM_DURATION" default:"5m"`
+	// LocationInterval is how often drivers report their location
+	LocationInterval time.Duration `yaml:"location_interval" env:"SIM_LOCATION_INTERVAL" default:"5s"`
+	SpeedKmh         float64       `yaml:"speed_kmh" env:"SIM_SPEED_KMH" default:"30"`
+	// TimeScale speeds up simulated time: at 10, drivers cover ten seconds
+	// of driving every second
+	TimeScale float64 `yaml:"time_scale" env:"SIM_TIME_SCALE" default:"10"`
+
+	// Drivers and trips are placed within RadiusKm of the center
+	CenterLatitude  float64 `yaml:"center_latitude" env:"SIM_CENTER_LATITUDE" default:"40.7580"`
+	CenterLongitude float64 `yaml:"center_longitude" env:"SIM_CENTER_LONGITUDE" default:"-73.9855"`
+	RadiusKm        float64 `yaml:"radius_km" env:"SIM_RADIUS_KM" default:"5"`
+	// City is sent with every request; empty lets the services resolve it
+	// from the locations
+	City        string `yaml:"city" env:"SIM_CITY"`
+	VehicleType string `yaml:"vehicle_type" env:"SIM_VEHICLE_TYPE" default:"economy"`
+}
+
+// Load reads the simulator config from the YAML file at path, if any, and
+// the environment
+func Load(path string) (*Config, error) {
+	loader := sharedconfig.NewLoader()
+	if path != "" {
+		loader = loader.WithFile(path)
+	}
+
+	var cfg Config
+	if err := loader.Load(&cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// Validate checks the traffic settings
+func (c *Config) Validate() error {
+	if c.Drivers <= 0 || c.Riders <= 0 {
+		return errors.New("SIM_DRIVERS and SIM_RIDERS must be positive")
+	}
+	if c.TripsPerMinute <= 0 {
+		return errors.New("SIM_TRIPS_PER_MINUTE must be positive")
+	}
+	if c.Duration <= 0 || c.LocationInterval <= 0 {
+		return errors.New("SIM_DURATION and SIM_LOCATION_INTERVAL must be positive")
+	}
+	if c.SpeedKmh <= 0 || c.TimeScale <= 0 {
+		return errors.New("SIM_SPEED_KMH and SIM_TIME_SCALE must be positive")
+	}
+	if c.RadiusKm <= 0 {
+		return errors.New("SIM_RADIUS_KM must be positive")
+	}
+	if c.CenterLatitude < -90 || c.CenterLatitude > 90 || c.CenterLongitude < -180 || c.CenterLongitude > 180 {
+		return fmt.Errorf("center %f,%f is not a valid location", c.CenterLatitude, c.CenterLongitude)
+	}
+	return c.TLS.Validate()
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+	geopb "github.com/rideshare-platform/shared/proto/geo"
+)
+
+// kmPerDegreeLatitude converts between kilometres and degrees of latitude
+const kmPerDegreeLatitude = 111.32
+
+// driver is a simulated driver. Between trips it cruises to random points
+// around the city; on a trip it drives the route it was given.
+type driver struct {
+	*actor
+	vehicleID string
+
+	mu       sync.Mutex
+	position models.Location
+	target   models.Location
+	status   string
+	tripID   string
+	// arrived is closed when a trip leg's target is reached, nil while
+	// cruising or waiting at pickup
+	arrived chan struct{}
+}
+
+// drive moves the driver and reports its location every interval until ctx
+// is done
+func (d *driver) drive(ctx context.Context, sim *simulation) {
+	ticker := time.NewTicker(sim.cfg.LocationInterval)
+	defer ticker.Stop()
+
+	// Every report covers the distance driven since the last one
+	step := sim.cfg.SpeedKmh * sim.cfg.LocationInterval.Hours() * sim.cfg.TimeScale
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		position, status := d.advance(sim, step)
+		start := time.Now()
+		_, err := sim.clients.geo.UpdateDriverLocation(d.context(ctx), &geopb.UpdateDriverLocationRequest{
+			DriverId:  d.id,
+			Location:  &geopb.Location{Latitude: position.Latitude, Longitude: position.Longitude, Timestamp: timestamppb.New(start)},
+			Status:    status,
+			VehicleId: d.vehicleID,
+			CityId:    sim.cfg.City,
+		})
+		if sim.stats.call(stageLocation, start, err) != nil && ctx.Err() == nil {
+			sim.log.WithError(err).WithFields(logger.Fields{"driver_id": d.id}).Debug("Location update failed")
+		}
+	}
+}
+
+// advance moves the driver step km towards its target and returns where it is
+func (d *driver) advance(sim *simulation, step float64) (models.Location, string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var reached bool
+	d.position, reached = moveToward(d.position, d.target, step)
+	switch {
+	case !reached:
+	case d.arrived != nil:
+		close(d.arrived)
+		d.arrived = nil
+	case d.tripID == "":
+		d.target = sim.randomLocation()
+	}
+	return d.position, d.status
+}
+
+// assign puts the driver on a trip. It returns false if the driver is
+// already on another one.
+func (d *driver) assign(tripID string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.tripID != "" {
+		return false
+	}
+	d.tripID = tripID
+	d.status = string(models.DriverStatusBusy)
+	return true
+}
+
+// driveTo sends the driver on a trip leg to target. The returned channel is
+// closed on arrival.
+func (d *driver) driveTo(target models.Location) <-chan struct{} {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	arrived := make(chan struct{})
+	d.target = target
+	d.arrived = arrived
+	return arrived
+}
+
+// release ends the driver's trip, sending it cruising again
+func (d *driver) release(sim *simulation) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.tripID = ""
+	d.status = string(models.DriverStatusOnline)
+	d.arrived = nil
+	d.target = sim.randomLocation()
+}
+
+// location returns where the driver is
+func (d *driver) location() models.Location {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.position
+}
+
+// moveToward moves km along the straight line from from to to, reporting
+// whether to was reached
+func moveToward(from, to models.Location, km float64) (models.Location, bool) {
+	distance := from.DistanceTo(&to)
+	if distance <= km {
+		return to, true
+	}
+	fraction := km / distance
+	return models.Location{
+		Latitude:  from.Latitude + (to.Latitude-from.Latitude)*fraction,
+		Longitude: from.Longitude + (to.Longitude-from.Longitude)*fraction,
+	}, false
+}
+
+// randomLocation returns a point spread evenly within radiusKm of center
+func randomLocation(center models.Location, radiusKm float64) models.Location {
+	distance := radiusKm * math.Sqrt(rand.Float64())
+	bearing := 2 * math.Pi * rand.Float64()
+	return models.Location{
+		Latitude:  center.Latitude + distance*math.Cos(bearing)/kmPerDegreeLatitude,
+		Longitude: center.Longitude + distance*math.Sin(bearing)/(kmPerDegreeLatitude*math.Cos(center.Latitude*math.Pi/180)),
+	}
+}
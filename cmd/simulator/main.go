@@ -0,0 +1,82 @@
+// Command simulator drives the platform end to end with synthetic traffic.
+// Drivers go online in geo-service and report their location as they drive
+// around the city; riders request trips at a steady rate and take each one
+// through pricing, matching, the trip lifecycle and payment against the
+// running services. A summary of every call and trip is printed when the run
+// ends.
+//
+//	simulator [-config simulator.yaml]
+//
+// Settings come from the YAML file and the environment, e.g.
+// SIM_DRIVERS=50 SIM_TRIPS_PER_MINUTE=30 SIM_DURATION=10m. Calls use mutual
+// TLS when TLS_ENABLED is set and carry tokens signed with JWT_SECRET when it
+// is set, as the services' own calls do. When geo-service checks driver
+// approvals, the sim-driver-NNNN IDs must be approved in user-service.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/rideshare-platform/shared/logger"
+	sharedtls "github.com/rideshare-platform/shared/tls"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	flags := flag.NewFlagSet("simulator", flag.ContinueOnError)
+	configFile := flags.String("config", "", "YAML file with the simulator settings")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := Load(*configFile)
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	log := logger.NewLogger(cfg.LogLevel, "simulation")
+
+	// Interrupting stops the run at once; otherwise it stops requesting trips
+	// after the duration and lets the ones under way finish
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	tlsReloader, err := sharedtls.Load(cfg.TLS, log)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS certificates: %w", err)
+	}
+	go tlsReloader.Watch(ctx)
+
+	clients, err := dial(cfg, tlsReloader)
+	if err != nil {
+		return err
+	}
+	defer clients.Close()
+
+	sim, err := newSimulation(cfg, clients, log)
+	if err != nil {
+		return err
+	}
+
+	log.WithFields(logger.Fields{
+		"drivers":          cfg.Drivers,
+		"riders":           cfg.Riders,
+		"trips_per_minute": cfg.TripsPerMinute,
+		"duration":         cfg.Duration.String(),
+	}).Info("Starting simulation")
+	started := time.Now()
+	sim.Run(ctx)
+	sim.stats.Print(os.Stdout, time.Since(started))
+	return nil
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+)
+
+// simulation runs the synthetic drivers and riders of one run
+type simulation struct {
+	cfg     *Config
+	clients *clients
+	stats   *stats
+	log     *logger.Logger
+	center  models.Location
+
+	drivers map[string]*driver
+	riders  []*rider
+}
+
+// rider is a simulated rider, who adds a payment method before the first trip
+type rider struct {
+	*actor
+
+	once            sync.Once
+	paymentMethodID string
+}
+
+func newSimulation(cfg *Config, clients *clients, log *logger.Logger) (*simulation, error) {
+	sim := &simulation{
+		cfg:     cfg,
+		clients: clients,
+		stats:   newStats(),
+		log:     log,
+		center:  models.Location{Latitude: cfg.CenterLatitude, Longitude: cfg.CenterLongitude},
+		drivers: make(map[string]*driver, cfg.Drivers),
+	}
+
+	for i := 1; i <= cfg.Drivers; i++ {
+		a, err := clients.newActor(fmt.Sprintf("sim-driver-%04d", i), "driver")
+		if err != nil {
+			return nil, err
+		}
+		position := sim.randomLocation()
+		sim.drivers[a.id] = &driver{
+			actor:     a,
+			vehicleID: fmt.Sprintf("sim-vehicle-%04d", i),
+			position:  position,
+			target:    sim.randomLocation(),
+			status:    string(models.DriverStatusOnline),
+		}
+	}
+	for i := 1; i <= cfg.Riders; i++ {
+		a, err := clients.newActor(fmt.Sprintf("sim-rider-%04d", i), "rider")
+		if err != nil {
+			return nil, err
+		}
+		sim.riders = append(sim.riders, &rider{actor: a})
+	}
+	return sim, nil
+}
+
+// Run puts the drivers on the road and requests trips at the configured rate
+// until the duration is up, then waits for the trips under way. Cancelling
+// ctx stops everything at once.
+func (s *simulation) Run(ctx context.Context) {
+	driversCtx, stopDrivers := context.WithCancel(ctx)
+	defer stopDrivers()
+
+	var drivers sync.WaitGroup
+	for _, d := range s.drivers {
+		start := time.Now()
+		if err := s.clients.goOnline(ctx, d.actor); s.stats.call(stageGoOnline, start, err) != nil {
+			s.log.WithError(err).WithFields(logger.Fields{"driver_id": d.id}).Warn("Driver could not go online")
+		}
+		drivers.Add(1)
+		go func(d *driver) {
+			defer drivers.Done()
+			d.drive(driversCtx, s)
+		}(d)
+	}
+
+	requestCtx, stopRequests := context.WithTimeout(ctx, s.cfg.Duration)
+	defer stopRequests()
+
+	var trips sync.WaitGroup
+	ticker := time.NewTicker(time.Duration(float64(time.Minute) / s.cfg.TripsPerMinute))
+	defer ticker.Stop()
+	for requesting := true; requesting; {
+		select {
+		case <-requestCtx.Done():
+			requesting = false
+		case <-ticker.C:
+			trips.Add(1)
+			go func(r *rider) {
+				defer trips.Done()
+				s.trip(ctx, r)
+			}(s.riders[rand.IntN(len(s.riders))])
+		}
+	}
+
+	s.log.Info("Waiting for trips under way to finish")
+	trips.Wait()
+	stopDrivers()
+	drivers.Wait()
+}
+
+// randomLocation returns a point within the simulated area
+func (s *simulation) randomLocation() models.Location {
+	return randomLocation(s.center, s.cfg.RadiusKm)
+}
+
+// simulatedDuration converts real time into the time it simulates
+func (s *simulation) simulatedDuration(elapsed time.Duration) time.Duration {
+	return time.Duration(float64(elapsed) * s.cfg.TimeScale)
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// Calls the simulator makes, reported in this order
+const (
+	stageGoOnline      = "go_online"
+	stageLocation      = "location_update"
+	stagePaymentMethod = "payment_method"
+	stageEstimate      = "price_estimate"
+	stageCreateTrip    = "create_trip"
+	stageMatch         = "match_driver"
+	stageAcceptOffer   = "accept_offer"
+	stageTripStatus    = "trip_status"
+	stageFinalFare     = "final_fare"
+	stagePayment       = "payment"
+	stageCancelTrip    = "cancel_trip"
+)
+
+var stageOrder = []string{
+	stageGoOnline, stageLocation, stagePaymentMethod, stageEstimate, stageCreateTrip,
+	stageMatch, stageAcceptOffer, stageTripStatus, stageFinalFare, stagePayment, stageCancelTrip,
+}
+
+// Trip outcomes
+const (
+	outcomeCompleted   = "completed"
+	outcomeUnmatched   = "unmatched"
+	outcomeFailed      = "failed"
+	outcomeInterrupted = "interrupted"
+)
+
+// stageStats are the calls made for one stage
+type stageStats struct {
+	calls    int
+	failures int
+	latency  time.Duration
+	slowest  time.Duration
+	lastErr  error
+}
+
+// stats collects the outcome of every call and trip of a run
+type stats struct {
+	mu       sync.Mutex
+	stages   map[string]*stageStats
+	outcomes map[string]int
+	requests int
+	revenue  map[string]float64 // completed trip fares by currency
+}
+
+func newStats() *stats {
+	return &stats{
+		stages:   make(map[string]*stageStats),
+		outcomes: make(map[string]int),
+		revenue:  make(map[string]float64),
+	}
+}
+
+// call records a call of stage that started at start
+func (s *stats) call(stage string, start time.Time, err error) error {
+	latency := time.Since(start)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st, exists := s.stages[stage]
+	if !exists {
+		st = &stageStats{}
+		s.stages[stage] = st
+	}
+	st.calls++
+	st.latency += latency
+	if latency > st.slowest {
+		st.slowest = latency
+	}
+	if err != nil {
+		st.failures++
+		st.lastErr = err
+	}
+	return err
+}
+
+func (s *stats) request() {
+	s.mu.Lock()
+	s.requests++
+	s.mu.Unlock()
+}
+
+func (s *stats) outcome(outcome string) {
+	s.mu.Lock()
+	s.outcomes[outcome]++
+	s.mu.Unlock()
+}
+
+func (s *stats) fare(currency string, amount float64) {
+	s.mu.Lock()
+	s.revenue[currency] += amount
+	s.mu.Unlock()
+}
+
+// Print writes the summary of the run
+func (s *stats) Print(w io.Writer, elapsed time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fmt.Fprintf(w, "Simulated %s: %d trips requested, %d completed, %d unmatched, %d failed, %d interrupted\n",
+		elapsed.Round(time.Second), s.requests, s.outcomes[outcomeCompleted], s.outcomes[outcomeUnmatched],
+		s.outcomes[outcomeFailed], s.outcomes[outcomeInterrupted])
+
+	currencies := make([]string, 0, len(s.revenue))
+	for currency := range s.revenue {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+	for _, currency := range currencies {
+		fmt.Fprintf(w, "Fares charged: %.2f %s\n", s.revenue[currency], currency)
+	}
+
+	fmt.Fprintln(w)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "STAGE\tCALLS\tFAILED\tAVG\tMAX\tLAST ERROR")
+	for _, stage := range stageOrder {
+		st, exists := s.stages[stage]
+		if !exists {
+			continue
+		}
+		lastErr := ""
+		if st.lastErr != nil {
+			lastErr = st.lastErr.Error()
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%s\t%s\n", stage, st.calls, st.failures,
+			(st.latency / time.Duration(st.calls)).Round(time.Millisecond), st.slowest.Round(time.Millisecond), lastErr)
+	}
+	tw.Flush()
+}
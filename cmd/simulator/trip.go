@@ -0,0 +1,306 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+	matchingpb "github.com/rideshare-platform/shared/proto/matching"
+	paymentpb "github.com/rideshare-platform/shared/proto/payment"
+	pricingpb "github.com/rideshare-platform/shared/proto/pricing"
+	trippb "github.com/rideshare-platform/shared/proto/trip"
+)
+
+const (
+	// minTripKm keeps trips from ending where they start
+	minTripKm = 1.0
+	// maxWaitingSeconds is the longest a driver waits at pickup, in simulated time
+	maxWaitingSeconds = 180
+	// cleanupTimeout bounds the calls that cancel a trip after the run is stopped
+	cleanupTimeout = 5 * time.Second
+)
+
+// errForeignDriver is recorded when matching picks a driver the simulator does
+// not drive, such as a real one sharing the environment
+var errForeignDriver = errors.New("matched a driver outside the simulation")
+
+// responseError turns an unsuccessful response into an error
+func responseError(err error, success bool, message string) error {
+	if err != nil {
+		return err
+	}
+	if !success {
+		if message == "" {
+			message = "request was not successful"
+		}
+		return errors.New(message)
+	}
+	return nil
+}
+
+// trip takes one request of the rider through pricing, matching, the trip
+// lifecycle and payment
+func (s *simulation) trip(ctx context.Context, r *rider) {
+	s.stats.request()
+	outcome, err := s.runTrip(ctx, r)
+	if outcome != outcomeCompleted && ctx.Err() != nil {
+		outcome = outcomeInterrupted
+	}
+	s.stats.outcome(outcome)
+	if err != nil && outcome == outcomeFailed {
+		s.log.WithError(err).WithFields(logger.Fields{"rider_id": r.id}).Debug("Simulated trip failed")
+	}
+}
+
+func (s *simulation) runTrip(ctx context.Context, r *rider) (string, error) {
+	riderCtx := r.context(ctx)
+	paymentMethodID := s.paymentMethod(ctx, r)
+
+	pickup := s.randomLocation()
+	destination := s.randomLocation()
+	for pickup.DistanceTo(&destination) < minTripKm {
+		destination = s.randomLocation()
+	}
+
+	start := time.Now()
+	estimate, err := s.clients.pricing.GetPriceEstimate(riderCtx, &pricingpb.GetPriceEstimateRequest{
+		PickupLocation: pricingLocation(pickup),
+		Destination:    pricingLocation(destination),
+		VehicleType:    s.cfg.VehicleType,
+		DepartureTime:  timestamppb.New(start),
+		RiderId:        r.id,
+		City:           s.cfg.City,
+	})
+	if err := s.stats.call(stageEstimate, start, responseError(err, estimate.GetSuccess(), estimate.GetMessage())); err != nil {
+		return outcomeFailed, err
+	}
+	quote := estimate.GetEstimate()
+
+	start = time.Now()
+	created, err := s.clients.trips.CreateTrip(riderCtx, &trippb.CreateTripRequest{
+		RiderId:         r.id,
+		PickupLocation:  tripLocation(pickup),
+		Destination:     tripLocation(destination),
+		VehicleType:     s.cfg.VehicleType,
+		PaymentMethodId: paymentMethodID,
+		CityId:          s.cfg.City,
+		Metadata: &trippb.TripMetadata{
+			VehicleType:     s.cfg.VehicleType,
+			DistanceKm:      pickup.DistanceTo(&destination),
+			SurgeMultiplier: quote.GetSurgeMultiplier(),
+		},
+	})
+	if err := s.stats.call(stageCreateTrip, start, responseError(err, created.GetSuccess(), created.GetMessage())); err != nil {
+		return outcomeFailed, err
+	}
+	tripID := created.GetTrip().GetId()
+
+	start = time.Now()
+	matched, err := s.clients.matching.MatchDriver(riderCtx, &matchingpb.MatchDriverRequest{
+		RideRequest: &matchingpb.RideRequest{
+			Id:             tripID,
+			RiderId:        r.id,
+			PickupLocation: matchingLocation(pickup),
+			Destination:    matchingLocation(destination),
+			VehicleType:    s.cfg.VehicleType,
+			PassengerCount: 1,
+			RequestedAt:    timestamppb.New(start),
+			City:           s.cfg.City,
+		},
+	})
+	if err := s.stats.call(stageMatch, start, err); err != nil {
+		s.cancelTrip(ctx, r.actor, tripID, trippb.TripStatus_CANCELLED_BY_RIDER, "matching failed")
+		return outcomeFailed, err
+	}
+	best := matched.GetResult().GetBestMatch()
+	if best == nil {
+		s.cancelTrip(ctx, r.actor, tripID, trippb.TripStatus_CANCELLED_BY_RIDER, "no driver found")
+		return outcomeUnmatched, nil
+	}
+	d, ours := s.drivers[best.GetId()]
+	if !ours {
+		s.cancelTrip(ctx, r.actor, tripID, trippb.TripStatus_CANCELLED_BY_RIDER, "no driver found")
+		return outcomeFailed, errForeignDriver
+	}
+	if !d.assign(tripID) {
+		// Matched while still finishing another trip
+		start = time.Now()
+		_, err := s.clients.matching.DeclineOffer(d.context(ctx), &matchingpb.DeclineOfferRequest{TripId: tripID, DriverId: d.id, Reason: "busy"})
+		s.stats.call(stageAcceptOffer, start, err)
+		s.cancelTrip(ctx, r.actor, tripID, trippb.TripStatus_CANCELLED_BY_RIDER, "driver unavailable")
+		return outcomeUnmatched, nil
+	}
+	defer d.release(s)
+
+	return s.serveTrip(ctx, r, d, tripID, paymentMethodID, pickup, destination, quote)
+}
+
+// serveTrip has the matched driver accept the trip, pick the rider up, drive
+// to the destination and get paid
+func (s *simulation) serveTrip(ctx context.Context, r *rider, d *driver, tripID, paymentMethodID string, pickup, destination models.Location, quote *pricingpb.PriceEstimate) (string, error) {
+	driverCtx := d.context(ctx)
+
+	start := time.Now()
+	accepted, err := s.clients.matching.AcceptOffer(driverCtx, &matchingpb.AcceptOfferRequest{TripId: tripID, DriverId: d.id})
+	if err := s.stats.call(stageAcceptOffer, start, responseError(err, accepted.GetSuccess(), accepted.GetMessage())); err != nil {
+		s.cancelTrip(ctx, d.actor, tripID, trippb.TripStatus_CANCELLED_BY_DRIVER, "offer could not be accepted")
+		return outcomeFailed, err
+	}
+
+	for _, status := range []trippb.TripStatus{trippb.TripStatus_MATCHED, trippb.TripStatus_DRIVER_EN_ROUTE} {
+		if err := s.updateStatus(driverCtx, d, tripID, status, nil); err != nil {
+			return outcomeFailed, err
+		}
+	}
+	if err := arrive(ctx, d.driveTo(pickup)); err != nil {
+		s.cancelTrip(ctx, d.actor, tripID, trippb.TripStatus_CANCELLED_BY_DRIVER, "simulation stopped")
+		return outcomeInterrupted, err
+	}
+	if err := s.updateStatus(driverCtx, d, tripID, trippb.TripStatus_DRIVER_ARRIVED, nil); err != nil {
+		return outcomeFailed, err
+	}
+
+	// The rider takes a while to come out
+	waiting := time.Duration(rand.IntN(maxWaitingSeconds)) * time.Second
+	if err := sleep(ctx, time.Duration(float64(waiting)/s.cfg.TimeScale)); err != nil {
+		s.cancelTrip(ctx, d.actor, tripID, trippb.TripStatus_CANCELLED_BY_DRIVER, "simulation stopped")
+		return outcomeInterrupted, err
+	}
+	if err := s.updateStatus(driverCtx, d, tripID, trippb.TripStatus_TRIP_STARTED, nil); err != nil {
+		return outcomeFailed, err
+	}
+	startedAt := time.Now()
+	if err := arrive(ctx, d.driveTo(destination)); err != nil {
+		s.cancelTrip(ctx, d.actor, tripID, trippb.TripStatus_CANCELLED_BY_DRIVER, "simulation stopped")
+		return outcomeInterrupted, err
+	}
+	endedAt := time.Now()
+
+	distanceKm := pickup.DistanceTo(&destination)
+	durationMinutes := int32(math.Ceil(s.simulatedDuration(endedAt.Sub(startedAt)).Minutes()))
+	completion := &trippb.TripCompletion{
+		VehicleId:          d.vehicleID,
+		VehicleType:        s.cfg.VehicleType,
+		PickupLocation:     tripLocation(pickup),
+		Destination:        tripLocation(destination),
+		DistanceKm:         distanceKm,
+		DurationMinutes:    durationMinutes,
+		StartedAt:          timestamppb.New(startedAt),
+		SurgeMultiplier:    quote.GetSurgeMultiplier(),
+		WaitingTimeSeconds: int32(waiting.Seconds()),
+		City:               s.cfg.City,
+		Currency:           quote.GetCurrency(),
+	}
+	if err := s.updateStatus(driverCtx, d, tripID, trippb.TripStatus_COMPLETED, completion); err != nil {
+		return outcomeFailed, err
+	}
+
+	start = time.Now()
+	fare, err := s.clients.pricing.CalculateFinalFare(r.context(ctx), &pricingpb.CalculateFinalFareRequest{
+		TripId:                tripID,
+		ActualPickup:          pricingLocation(pickup),
+		ActualDestination:     pricingLocation(destination),
+		ActualDistanceKm:      distanceKm,
+		ActualDurationMinutes: durationMinutes,
+		VehicleType:           s.cfg.VehicleType,
+		TripStartTime:         timestamppb.New(startedAt),
+		TripEndTime:           timestamppb.New(endedAt),
+		RiderId:               r.id,
+		LockedSurgeMultiplier: quote.GetSurgeMultiplier(),
+		WaitingTimeSeconds:    int32(waiting.Seconds()),
+		City:                  s.cfg.City,
+		Currency:              quote.GetCurrency(),
+	})
+	if err := s.stats.call(stageFinalFare, start, responseError(err, fare.GetSuccess(), fare.GetMessage())); err != nil {
+		return outcomeFailed, err
+	}
+	total := fare.GetFinalFare().GetTotalAmount()
+	currency := fare.GetFinalFare().GetCurrency()
+
+	if paymentMethodID != "" {
+		start = time.Now()
+		paid, err := s.clients.payments.ProcessPayment(r.context(ctx), &paymentpb.ProcessPaymentRequest{
+			TripId:          tripID,
+			UserId:          r.id,
+			DriverId:        d.id,
+			Amount:          total,
+			Currency:        currency,
+			PaymentMethodId: paymentMethodID,
+			Description:     "Simulated trip " + tripID,
+		})
+		if err := s.stats.call(stagePayment, start, responseError(err, paid.GetSuccess(), paid.GetMessage())); err != nil {
+			return outcomeFailed, err
+		}
+	}
+	s.stats.fare(currency, total)
+	return outcomeCompleted, nil
+}
+
+// paymentMethod returns the rider's payment method, adding it on the first
+// trip. Riders without one take their trips unpaid.
+func (s *simulation) paymentMethod(ctx context.Context, r *rider) string {
+	r.once.Do(func() {
+		start := time.Now()
+		id, err := s.clients.addPaymentMethod(ctx, r.actor)
+		if s.stats.call(stagePaymentMethod, start, err) != nil {
+			s.log.WithError(err).WithFields(logger.Fields{"rider_id": r.id}).Warn("Rider has no payment method, trips will not be paid")
+		}
+		r.paymentMethodID = id
+	})
+	return r.paymentMethodID
+}
+
+// updateStatus moves the trip to status as the driver
+func (s *simulation) updateStatus(ctx context.Context, d *driver, tripID string, status trippb.TripStatus, completion *trippb.TripCompletion) error {
+	start := time.Now()
+	resp, err := s.clients.trips.UpdateTripStatus(ctx, &trippb.UpdateTripStatusRequest{
+		TripId:     tripID,
+		Status:     status,
+		DriverId:   d.id,
+		Completion: completion,
+	})
+	return s.stats.call(stageTripStatus, start, responseError(err, resp.GetSuccess(), resp.GetMessage()))
+}
+
+// cancelTrip cancels a trip that cannot go on. It still runs when ctx was
+// cancelled, so stopping the simulation does not leave trips open.
+func (s *simulation) cancelTrip(ctx context.Context, by *actor, tripID string, status trippb.TripStatus, reason string) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), cleanupTimeout)
+	defer cancel()
+
+	start := time.Now()
+	resp, err := s.clients.trips.UpdateTripStatus(by.context(ctx), &trippb.UpdateTripStatusRequest{
+		TripId: tripID,
+		Status: status,
+		Reason: reason,
+	})
+	s.stats.call(stageCancelTrip, start, responseError(err, resp.GetSuccess(), resp.GetMessage()))
+}
+
+// arrive waits for the driver to reach the end of a trip leg
+func arrive(ctx context.Context, arrived <-chan struct{}) error {
+	select {
+	case <-arrived:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// sleep waits for d of real time
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -47,6 +47,32 @@ CREATE INDEX IF NOT EXISTS idx_drivers_status ON drivers(status);
 CREATE INDEX IF NOT EXISTS idx_drivers_rating ON drivers(rating);
 CREATE INDEX IF NOT EXISTS idx_drivers_location ON drivers(current_latitude, current_longitude);
 
+-- Create driver onboarding tables
+CREATE TABLE IF NOT EXISTS driver_onboarding (
+    driver_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(30) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'documents_submitted', 'approved', 'rejected')),
+    background_check_status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (background_check_status IN ('pending', 'clear', 'consider', 'failed')),
+    rejection_reason TEXT,
+    reviewed_by VARCHAR(100),
+    submitted_at TIMESTAMP WITH TIME ZONE,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS driver_documents (
+    id VARCHAR(50) PRIMARY KEY,
+    driver_id UUID NOT NULL REFERENCES driver_onboarding(driver_id) ON DELETE CASCADE,
+    document_type VARCHAR(30) NOT NULL CHECK (document_type IN ('driver_license', 'insurance', 'vehicle_registration')),
+    file_url TEXT NOT NULL,
+    document_number VARCHAR(100),
+    expires_at TIMESTAMP WITH TIME ZONE,
+    uploaded_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (driver_id, document_type)
+);
+
+CREATE INDEX IF NOT EXISTS idx_driver_onboarding_status ON driver_onboarding(status, submitted_at);
+
 -- Create vehicles table
 CREATE TABLE IF NOT EXISTS vehicles (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
@@ -124,6 +150,7 @@ CREATE TABLE IF NOT EXISTS trips (
     
     -- Timestamps
     requested_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    scheduled_for TIMESTAMP WITH TIME ZONE, -- pickup time for rides booked in advance
     matched_at TIMESTAMP WITH TIME ZONE,
     driver_assigned_at TIMESTAMP WITH TIME ZONE,
     driver_arrived_at TIMESTAMP WITH TIME ZONE,
@@ -149,3 +176,4 @@ CREATE INDEX IF NOT EXISTS idx_trips_driver_id ON trips(driver_id);
 CREATE INDEX IF NOT EXISTS idx_trips_status ON trips(status);
 CREATE INDEX IF NOT EXISTS idx_trips_requested_at ON trips(requested_at);
 CREATE INDEX IF NOT EXISTS idx_trips_completed_at ON trips(completed_at);
+CREATE INDEX IF NOT EXISTS idx_trips_scheduled_for ON trips(scheduled_for) WHERE scheduled_for IS NOT NULL;
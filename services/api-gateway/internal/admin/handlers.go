@@ -0,0 +1,2110 @@
+// Package admin serves the operations API staff use to inspect the platform
+// and intervene in it: active trips, the online driver map, surge, the
+// payment failure queue and alerts, plus force-cancelling trips, banning
+// users and releasing driver reservations. Support staff work the emergency
+// incident queue, the fare dispute queue and lost item reports and search
+// for users, vehicles and trips here too, and admins switch feature flags,
+// contest payment provider chargebacks, review queued refunds, manage fraud
+// rules and the payments they flag, and generate and download the trip data
+// reports cities' regulators require. Every route requires an admin token
+// whose roles grant the route's permission. The trip, driver and surge views
+// can be narrowed to one city with the city_id query parameter.
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/rideshare-platform/services/api-gateway/internal/api"
+	"github.com/rideshare-platform/services/api-gateway/internal/grpc"
+	"github.com/rideshare-platform/shared/alerting"
+	"github.com/rideshare-platform/shared/flags"
+	"github.com/rideshare-platform/shared/logger"
+	geopb "github.com/rideshare-platform/shared/proto/geo"
+	matchingpb "github.com/rideshare-platform/shared/proto/matching"
+	paymentpb "github.com/rideshare-platform/shared/proto/payment"
+	pricingpb "github.com/rideshare-platform/shared/proto/pricing"
+	trippb "github.com/rideshare-platform/shared/proto/trip"
+	userpb "github.com/rideshare-platform/shared/proto/user"
+	"github.com/rideshare-platform/shared/search"
+)
+
+// AlertSource reads the alerts raised across the platform
+type AlertSource interface {
+	GetActiveAlerts(ctx context.Context) ([]*alerting.Alert, error)
+	GetAlertHistory(ctx context.Context, hours int) ([]*alerting.Alert, error)
+}
+
+// Handler serves the admin API
+type Handler struct {
+	clients *grpc.ClientManager
+	auth    TokenParser
+	alerts  AlertSource
+	search  search.Index
+	flags   flags.Store
+	logger  *logger.Logger
+}
+
+// NewHandler creates an admin API handler. Operator tokens are validated
+// with auth.
+func NewHandler(clients *grpc.ClientManager, auth TokenParser, log *logger.Logger) *Handler {
+	return &Handler{
+		clients: clients,
+		auth:    auth,
+		logger:  log,
+	}
+}
+
+// SetAlertSource attaches the store alerts are read from. Without one the
+// alert overview reports the service as unavailable.
+func (h *Handler) SetAlertSource(alerts AlertSource) {
+	h.alerts = alerts
+}
+
+// SetSearchIndex attaches the index support search runs against. Without
+// one search reports the service as unavailable.
+func (h *Handler) SetSearchIndex(index search.Index) {
+	h.search = index
+}
+
+// SetFlagStore attaches the store feature flags are kept in. Without one
+// the flag routes report the service as unavailable.
+func (h *Handler) SetFlagStore(store flags.Store) {
+	h.flags = store
+}
+
+// RegisterRoutes registers the admin routes on the /admin/v1 subrouter
+func (h *Handler) RegisterRoutes(admin *mux.Router) {
+	admin.Use(Authenticate(h.auth))
+
+	admin.HandleFunc("/trips", Require(PermissionView, h.ListActiveTrips)).Methods("GET")
+	admin.HandleFunc("/drivers/map", Require(PermissionView, h.DriverMap)).Methods("GET")
+	admin.HandleFunc("/surge", Require(PermissionView, h.SurgeMap)).Methods("GET")
+	admin.HandleFunc("/payments/failures", Require(PermissionView, h.PaymentFailures)).Methods("GET")
+	admin.HandleFunc("/alerts", Require(PermissionView, h.Alerts)).Methods("GET")
+	admin.HandleFunc("/search", Require(PermissionSearch, h.Search)).Methods("GET")
+
+	admin.HandleFunc("/trips/{id}/cancel", Require(PermissionCancelTrip, h.ForceCancelTrip)).Methods("POST")
+	admin.HandleFunc("/trips/{id}/messages", Require(PermissionReviewMessages, h.TripMessages)).Methods("GET")
+	admin.HandleFunc("/trips/{id}/matching-attempts", Require(PermissionView, h.MatchingAttempts)).Methods("GET")
+	admin.HandleFunc("/users/{id}/ban", Require(PermissionBanUser, h.BanUser)).Methods("POST")
+	admin.HandleFunc("/drivers/{id}/release-reservation", Require(PermissionReleaseDriver, h.ReleaseDriverReservation)).Methods("POST")
+	admin.HandleFunc("/drivers/{id}/payouts", Require(PermissionManagePayouts, h.RecordDriverPayout)).Methods("POST")
+	admin.HandleFunc("/users/{id}/wallet/credits", Require(PermissionGrantCredits, h.GrantWalletCredit)).Methods("POST")
+
+	admin.HandleFunc("/incidents", Require(PermissionView, h.ListIncidents)).Methods("GET")
+	admin.HandleFunc("/incidents/{id}", Require(PermissionView, h.GetIncident)).Methods("GET")
+	admin.HandleFunc("/incidents/{id}/acknowledge", Require(PermissionManageIncidents, h.AcknowledgeIncident)).Methods("POST")
+	admin.HandleFunc("/incidents/{id}/notes", Require(PermissionManageIncidents, h.AddIncidentNote)).Methods("POST")
+	admin.HandleFunc("/incidents/{id}/resolve", Require(PermissionManageIncidents, h.ResolveIncident)).Methods("POST")
+
+	admin.HandleFunc("/disputes", Require(PermissionManageDisputes, h.ListDisputes)).Methods("GET")
+	admin.HandleFunc("/disputes/{id}", Require(PermissionManageDisputes, h.GetDispute)).Methods("GET")
+	admin.HandleFunc("/disputes/{id}/review", Require(PermissionManageDisputes, h.StartDisputeReview)).Methods("POST")
+	admin.HandleFunc("/disputes/{id}/notes", Require(PermissionManageDisputes, h.AddDisputeNote)).Methods("POST")
+	admin.HandleFunc("/disputes/{id}/resolve", Require(PermissionManageDisputes, h.ResolveDispute)).Methods("POST")
+	admin.HandleFunc("/disputes/{id}/reject", Require(PermissionManageDisputes, h.RejectDispute)).Methods("POST")
+
+	admin.HandleFunc("/lost-items", Require(PermissionManageLostItems, h.ListLostItems)).Methods("GET")
+	admin.HandleFunc("/lost-items/{id}", Require(PermissionManageLostItems, h.GetLostItem)).Methods("GET")
+	admin.HandleFunc("/lost-items/{id}/updates", Require(PermissionManageLostItems, h.UpdateLostItem)).Methods("POST")
+
+	admin.HandleFunc("/chargebacks", Require(PermissionManageChargebacks, h.ListChargebacks)).Methods("GET")
+	admin.HandleFunc("/chargebacks/{id}", Require(PermissionManageChargebacks, h.GetChargeback)).Methods("GET")
+	admin.HandleFunc("/chargebacks/{id}/evidence", Require(PermissionManageChargebacks, h.SubmitChargebackEvidence)).Methods("POST")
+	admin.HandleFunc("/chargebacks/{id}/outcome", Require(PermissionManageChargebacks, h.RecordChargebackOutcome)).Methods("POST")
+
+	admin.HandleFunc("/refund-cases", Require(PermissionReviewRefunds, h.ListRefundCases)).Methods("GET")
+	admin.HandleFunc("/refund-cases/{id}", Require(PermissionReviewRefunds, h.GetRefundCase)).Methods("GET")
+	admin.HandleFunc("/refund-cases/{id}/approve", Require(PermissionReviewRefunds, h.ApproveRefundCase)).Methods("POST")
+	admin.HandleFunc("/refund-cases/{id}/reject", Require(PermissionReviewRefunds, h.RejectRefundCase)).Methods("POST")
+
+	admin.HandleFunc("/fraud-rules", Require(PermissionManageFraud, h.ListFraudRules)).Methods("GET")
+	admin.HandleFunc("/fraud-rules", Require(PermissionManageFraud, h.CreateFraudRule)).Methods("POST")
+	admin.HandleFunc("/fraud-rules/{id}", Require(PermissionManageFraud, h.ReplaceFraudRule)).Methods("PUT")
+	admin.HandleFunc("/fraud-rules/{id}", Require(PermissionManageFraud, h.DeleteFraudRule)).Methods("DELETE")
+	admin.HandleFunc("/fraud-rules/{id}/enable", Require(PermissionManageFraud, h.EnableFraudRule)).Methods("POST")
+	admin.HandleFunc("/fraud-rules/{id}/disable", Require(PermissionManageFraud, h.DisableFraudRule)).Methods("POST")
+	admin.HandleFunc("/fraud-reviews", Require(PermissionManageFraud, h.ListFraudReviews)).Methods("GET")
+	admin.HandleFunc("/fraud-reviews/{id}/approve", Require(PermissionManageFraud, h.ApproveFraudReview)).Methods("POST")
+	admin.HandleFunc("/fraud-reviews/{id}/reject", Require(PermissionManageFraud, h.RejectFraudReview)).Methods("POST")
+
+	admin.HandleFunc("/pickup-guarantees/report", Require(PermissionView, h.PickupSLAReport)).Methods("GET")
+
+	admin.HandleFunc("/compliance/reports", Require(PermissionComplianceReports, h.ListComplianceReports)).Methods("GET")
+	admin.HandleFunc("/compliance/reports", Require(PermissionComplianceReports, h.GenerateComplianceReport)).Methods("POST")
+	admin.HandleFunc("/compliance/reports/{id}", Require(PermissionComplianceReports, h.GetComplianceReport)).Methods("GET")
+	admin.HandleFunc("/compliance/reports/{id}/download", Require(PermissionComplianceReports, h.DownloadComplianceReport)).Methods("GET")
+
+	admin.HandleFunc("/dead-letters", Require(PermissionManageDeadLetters, h.ListDeadLetters)).Methods("GET")
+	admin.HandleFunc("/dead-letters/{id}", Require(PermissionManageDeadLetters, h.GetDeadLetter)).Methods("GET")
+	admin.HandleFunc("/dead-letters/{id}/replay", Require(PermissionManageDeadLetters, h.ReplayDeadLetter)).Methods("POST")
+	admin.HandleFunc("/dead-letters/{id}", Require(PermissionManageDeadLetters, h.DiscardDeadLetter)).Methods("DELETE")
+
+	admin.HandleFunc("/projections/trip-listings", Require(PermissionView, h.TripListingsLag)).Methods("GET")
+	admin.HandleFunc("/projections/trip-listings/rebuild", Require(PermissionManageProjections, h.RebuildTripListings)).Methods("POST")
+
+	admin.HandleFunc("/flags", Require(PermissionView, h.ListFlags)).Methods("GET")
+	admin.HandleFunc("/flags/{name}", Require(PermissionView, h.GetFlag)).Methods("GET")
+	admin.HandleFunc("/flags/{name}", Require(PermissionManageFlags, h.SaveFlag)).Methods("PUT")
+	admin.HandleFunc("/flags/{name}", Require(PermissionManageFlags, h.DeleteFlag)).Methods("DELETE")
+
+	admin.HandleFunc("/matching-configs", Require(PermissionView, h.ListMatchingConfigs)).Methods("GET")
+	admin.HandleFunc("/matching-configs/{city}", Require(PermissionView, h.GetMatchingConfig)).Methods("GET")
+	admin.HandleFunc("/matching-configs/{city}", Require(PermissionManageMatching, h.SaveMatchingConfig)).Methods("PUT")
+	admin.HandleFunc("/matching-configs/{city}", Require(PermissionManageMatching, h.DeleteMatchingConfig)).Methods("DELETE")
+}
+
+// ListActiveTrips handles GET /admin/v1/trips, filtered by the status,
+// rider_id, driver_id and city_id query parameters and ordered by sort
+func (h *Handler) ListActiveTrips(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	limit, cursor, err := pageParams(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	req := &trippb.GetActiveTripsRequest{
+		RiderId:  query.Get("rider_id"),
+		DriverId: query.Get("driver_id"),
+		CityId:   query.Get("city_id"),
+		Limit:    limit,
+		Cursor:   cursor,
+		Sort:     query.Get("sort"),
+	}
+	if s := query.Get("status"); s != "" {
+		value, ok := trippb.TripStatus_value[strings.ToUpper(s)]
+		if !ok {
+			api.WriteError(w, invalidParam("status", "is not a trip status"))
+			return
+		}
+		req.Status = trippb.TripStatus(value)
+	}
+	if h.clients.TripClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "trip")
+	defer cancel()
+	resp, callErr := h.clients.TripClient.GetActiveTrips(ctx, req)
+	if callErr != nil {
+		api.WriteError(w, api.FromGRPC("trip", callErr))
+		return
+	}
+
+	body := &TripsResponse{Trips: make([]*Trip, 0, len(resp.Trips)), Total: resp.Count, NextCursor: resp.NextCursor}
+	for _, trip := range resp.Trips {
+		body.Trips = append(body.Trips, tripFromProto(trip))
+	}
+	api.WriteJSON(w, http.StatusOK, body)
+}
+
+// DriverMap handles GET /admin/v1/drivers/map, listing the online drivers
+// within radius_km (default 5) of lat,lng, in city_id or the city lat,lng is
+// in
+func (h *Handler) DriverMap(w http.ResponseWriter, r *http.Request) {
+	lat, err := floatParam(r, "lat", 0, true)
+	if err == nil && (lat < -90 || lat > 90) {
+		err = invalidParam("lat", "must be between -90 and 90")
+	}
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	lng, err := floatParam(r, "lng", 0, true)
+	if err == nil && (lng < -180 || lng > 180) {
+		err = invalidParam("lng", "must be between -180 and 180")
+	}
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	radius, err := floatParam(r, "radius_km", 5, false)
+	if err == nil && (radius <= 0 || radius > 50) {
+		err = invalidParam("radius_km", "must be between 0 and 50")
+	}
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	limit, _, err := pageParams(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	if h.clients.GeoClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("geo"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "geo")
+	defer cancel()
+	resp, callErr := h.clients.GeoClient.FindNearbyDrivers(ctx, &geopb.NearbyDriversRequest{
+		Center:   &geopb.Location{Latitude: lat, Longitude: lng},
+		RadiusKm: radius,
+		Limit:    limit,
+		CityId:   r.URL.Query().Get("city_id"),
+	})
+	if callErr != nil {
+		api.WriteError(w, api.FromGRPC("geo", callErr))
+		return
+	}
+
+	body := &DriverMapResponse{
+		Center:   api.Location{Latitude: lat, Longitude: lng},
+		RadiusKm: radius,
+		Drivers:  make([]*DriverMarker, 0, len(resp.Drivers)),
+		Total:    resp.TotalCount,
+	}
+	for _, driver := range resp.Drivers {
+		body.Drivers = append(body.Drivers, driverMarkerFromProto(driver))
+	}
+	api.WriteJSON(w, http.StatusOK, body)
+}
+
+// SurgeMap handles GET /admin/v1/surge, listing areas surging at or above
+// min_multiplier, in city_id or in every city
+func (h *Handler) SurgeMap(w http.ResponseWriter, r *http.Request) {
+	minMultiplier, err := floatParam(r, "min_multiplier", 0, false)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	if h.clients.PricingClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("pricing"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "pricing")
+	defer cancel()
+	resp, callErr := h.clients.PricingClient.ListSurgeAreas(ctx, &pricingpb.ListSurgeAreasRequest{
+		MinMultiplier: minMultiplier,
+		CityId:        r.URL.Query().Get("city_id"),
+	})
+	if callErr != nil {
+		api.WriteError(w, api.FromGRPC("pricing", callErr))
+		return
+	}
+
+	body := &SurgeMapResponse{Areas: make([]*SurgeArea, 0, len(resp.Areas))}
+	for _, area := range resp.Areas {
+		body.Areas = append(body.Areas, surgeAreaFromProto(area))
+	}
+	api.WriteJSON(w, http.StatusOK, body)
+}
+
+// PaymentFailures handles GET /admin/v1/payments/failures, the failed
+// payments newest first
+func (h *Handler) PaymentFailures(w http.ResponseWriter, r *http.Request) {
+	limit, cursor, err := pageParams(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	if h.clients.PaymentClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("payment"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "payment")
+	defer cancel()
+	resp, callErr := h.clients.PaymentClient.ListPaymentsByStatus(ctx, &paymentpb.ListPaymentsByStatusRequest{
+		Status: paymentpb.PaymentStatus_FAILED,
+		Limit:  limit,
+		Cursor: cursor,
+	})
+	if callErr != nil {
+		api.WriteError(w, api.FromGRPC("payment", callErr))
+		return
+	}
+
+	body := &PaymentFailuresResponse{
+		Payments:   make([]*FailedPayment, 0, len(resp.Payments)),
+		HasMore:    resp.HasMore,
+		NextCursor: resp.NextCursor,
+	}
+	for _, payment := range resp.Payments {
+		body.Payments = append(body.Payments, failedPaymentFromProto(payment))
+	}
+	api.WriteJSON(w, http.StatusOK, body)
+}
+
+// Alerts handles GET /admin/v1/alerts, the active alerts and, with the hours
+// query parameter, the alerts raised in that many past hours
+func (h *Handler) Alerts(w http.ResponseWriter, r *http.Request) {
+	hours, err := intParam(r, "hours", 0)
+	if err == nil && (hours < 0 || hours > 24*7) {
+		err = invalidParam("hours", "must be between 0 and 168")
+	}
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	if h.alerts == nil {
+		api.WriteError(w, api.ServiceUnavailable("alerting"))
+		return
+	}
+
+	active, alertErr := h.alerts.GetActiveAlerts(r.Context())
+	if alertErr != nil {
+		h.logger.WithError(alertErr).Warn("Failed to read active alerts")
+		api.WriteError(w, api.ServiceUnavailable("alerting"))
+		return
+	}
+
+	body := &AlertsResponse{Active: active, BySeverity: map[string]int{}}
+	if body.Active == nil {
+		body.Active = []*alerting.Alert{}
+	}
+	for _, alert := range active {
+		body.BySeverity[string(alert.Severity)]++
+	}
+	if hours > 0 {
+		history, alertErr := h.alerts.GetAlertHistory(r.Context(), hours)
+		if alertErr != nil {
+			h.logger.WithError(alertErr).Warn("Failed to read alert history")
+			api.WriteError(w, api.ServiceUnavailable("alerting"))
+			return
+		}
+		body.History = history
+		body.HistoryHours = hours
+	}
+	api.WriteJSON(w, http.StatusOK, body)
+}
+
+// Search handles GET /admin/v1/search. The q query parameter is matched
+// against names, emails, phone numbers, plates and IDs; around (RFC3339) and
+// window (a duration, 30m by default) find trips requested near a time; type
+// narrows the results to a comma separated list of user, vehicle and trip.
+func (h *Handler) Search(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	limit, err := intParam(r, "limit", search.DefaultLimit)
+	if err == nil && (limit <= 0 || limit > search.MaxLimit) {
+		err = invalidParam("limit", "must be between 1 and 100")
+	}
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	query := search.Query{Text: params.Get("q"), Limit: limit}
+	if types := params.Get("type"); types != "" {
+		for _, name := range strings.Split(types, ",") {
+			kind, err := search.ParseKind(strings.TrimSpace(name))
+			if err != nil {
+				api.WriteError(w, invalidParam("type", "must list user, vehicle or trip"))
+				return
+			}
+			query.Kinds = append(query.Kinds, kind)
+		}
+	}
+	if around := params.Get("around"); around != "" {
+		if query.Around, err = time.Parse(time.RFC3339, around); err != nil {
+			api.WriteError(w, invalidParam("around", "must be an RFC3339 timestamp"))
+			return
+		}
+	}
+	if window := params.Get("window"); window != "" {
+		query.Window, err = time.ParseDuration(window)
+		if err != nil || query.Window <= 0 || query.Window > search.MaxWindow {
+			api.WriteError(w, invalidParam("window", "must be a duration between 1s and 24h, such as 30m"))
+			return
+		}
+	}
+	if _, err := query.Normalize(); err != nil {
+		api.WriteError(w, invalidParam("q", err.Error()))
+		return
+	}
+	if h.search == nil {
+		api.WriteError(w, api.ServiceUnavailable("search"))
+		return
+	}
+
+	results, err := h.search.Search(r.Context(), query)
+	if err != nil {
+		h.logger.WithError(err).Warn("Failed to search")
+		api.WriteError(w, api.ServiceUnavailable("search"))
+		return
+	}
+	if results == nil {
+		results = []search.Result{}
+	}
+	api.WriteJSON(w, http.StatusOK, &SearchResponse{Results: results, Count: len(results)})
+}
+
+// ForceCancelTrip handles POST /admin/v1/trips/{id}/cancel
+func (h *Handler) ForceCancelTrip(w http.ResponseWriter, r *http.Request) {
+	tripID := mux.Vars(r)["id"]
+	var req ActionRequest
+	if err := api.Bind(r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	if h.clients.TripClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "trip")
+	defer cancel()
+	resp, err := h.clients.TripClient.ForceCancelTrip(ctx, &trippb.ForceCancelTripRequest{
+		TripId:  tripID,
+		Reason:  req.Reason,
+		ActorId: actorID(r),
+	})
+	h.audit(r, "cancel_trip", tripID, req.Reason, err)
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("trip", err))
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, &ActionResponse{
+		Action:   "cancel_trip",
+		TargetID: tripID,
+		Status:   strings.ToLower(resp.GetTrip().GetStatus().String()),
+	})
+}
+
+// BanUser handles POST /admin/v1/users/{id}/ban
+func (h *Handler) BanUser(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["id"]
+	var req ActionRequest
+	if err := api.Bind(r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	if h.clients.UserClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("user"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "user")
+	defer cancel()
+	resp, err := h.clients.UserClient.UpdateUserStatus(ctx, &userpb.UpdateUserStatusRequest{
+		UserId: userID,
+		Status: userpb.UserStatus_BANNED,
+		Reason: req.Reason,
+	})
+	h.audit(r, "ban_user", userID, req.Reason, err)
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("user", err))
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, &ActionResponse{
+		Action:   "ban_user",
+		TargetID: userID,
+		Status:   strings.ToLower(resp.Status.String()),
+	})
+}
+
+// ReleaseDriverReservation handles POST /admin/v1/drivers/{id}/release-reservation
+func (h *Handler) ReleaseDriverReservation(w http.ResponseWriter, r *http.Request) {
+	driverID := mux.Vars(r)["id"]
+	var req ActionRequest
+	if err := api.Bind(r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	if h.clients.MatchingClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("matching"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "matching")
+	defer cancel()
+	resp, err := h.clients.MatchingClient.ReleaseDriverReservation(ctx, &matchingpb.ReleaseDriverReservationRequest{DriverId: driverID})
+	h.audit(r, "release_driver_reservation", driverID, req.Reason, err)
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("matching", err))
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, &ActionResponse{
+		Action:   "release_driver_reservation",
+		TargetID: driverID,
+		Status:   "released",
+		TripID:   resp.TripId,
+	})
+}
+
+// MatchingAttempts handles GET /admin/v1/trips/{id}/matching-attempts, every
+// step taken to match a trip: the searches, the drivers offered it and how
+// they answered
+func (h *Handler) MatchingAttempts(w http.ResponseWriter, r *http.Request) {
+	tripID := mux.Vars(r)["id"]
+	if h.clients.MatchingClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("matching"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "matching")
+	defer cancel()
+	resp, err := h.clients.MatchingClient.GetMatchingAttemptLog(ctx, &matchingpb.GetMatchingAttemptLogRequest{TripId: tripID})
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("matching", err))
+		return
+	}
+
+	api.WriteJSON(w, http.StatusOK, matchingAttemptsFromProto(resp))
+}
+
+// TripMessages handles GET /admin/v1/trips/{id}/messages, the conversation
+// between a trip's rider and driver. Reviews are audited as they read
+// private messages.
+func (h *Handler) TripMessages(w http.ResponseWriter, r *http.Request) {
+	tripID := mux.Vars(r)["id"]
+	if h.clients.TripClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "trip")
+	defer cancel()
+	resp, err := h.clients.TripClient.ListTripMessages(ctx, &trippb.ListTripMessagesRequest{TripId: tripID})
+	h.audit(r, "review_trip_messages", tripID, "", err)
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("trip", err))
+		return
+	}
+
+	body := &TripMessagesResponse{TripID: tripID, Messages: make([]*TripMessage, 0, len(resp.Messages))}
+	for _, message := range resp.Messages {
+		body.Messages = append(body.Messages, tripMessageFromProto(message))
+	}
+	api.WriteJSON(w, http.StatusOK, body)
+}
+
+// ListIncidents handles GET /admin/v1/incidents, filtered by the status
+// query parameter and bounded by limit
+func (h *Handler) ListIncidents(w http.ResponseWriter, r *http.Request) {
+	limit, err := intParam(r, "limit", 50)
+	if err == nil && (limit <= 0 || limit > 200) {
+		err = invalidParam("limit", "must be between 1 and 200")
+	}
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	req := &trippb.ListIncidentsRequest{Status: r.URL.Query().Get("status"), Limit: int32(limit)}
+	switch req.Status {
+	case "", "open", "acknowledged", "resolved":
+	default:
+		api.WriteError(w, invalidParam("status", "must be one of open, acknowledged, resolved"))
+		return
+	}
+	if h.clients.TripClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "trip")
+	defer cancel()
+	resp, err := h.clients.TripClient.ListIncidents(ctx, req)
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("trip", err))
+		return
+	}
+
+	body := &IncidentsResponse{Incidents: make([]*Incident, 0, len(resp.Incidents))}
+	for _, incident := range resp.Incidents {
+		body.Incidents = append(body.Incidents, incidentFromProto(incident))
+	}
+	api.WriteJSON(w, http.StatusOK, body)
+}
+
+// GetIncident handles GET /admin/v1/incidents/{id}
+func (h *Handler) GetIncident(w http.ResponseWriter, r *http.Request) {
+	if h.clients.TripClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "trip")
+	defer cancel()
+	incident, err := h.clients.TripClient.GetIncident(ctx, &trippb.GetIncidentRequest{IncidentId: mux.Vars(r)["id"]})
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("trip", err))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, incidentFromProto(incident))
+}
+
+// AcknowledgeIncident handles POST /admin/v1/incidents/{id}/acknowledge
+func (h *Handler) AcknowledgeIncident(w http.ResponseWriter, r *http.Request) {
+	incidentID := mux.Vars(r)["id"]
+	if h.clients.TripClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "trip")
+	defer cancel()
+	incident, err := h.clients.TripClient.AcknowledgeIncident(ctx, &trippb.AcknowledgeIncidentRequest{
+		IncidentId: incidentID,
+		StaffId:    actorID(r),
+	})
+	h.audit(r, "acknowledge_incident", incidentID, "", err)
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("trip", err))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, incidentFromProto(incident))
+}
+
+// AddIncidentNote handles POST /admin/v1/incidents/{id}/notes
+func (h *Handler) AddIncidentNote(w http.ResponseWriter, r *http.Request) {
+	incidentID := mux.Vars(r)["id"]
+	var req IncidentNoteRequest
+	if err := api.Bind(r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	if h.clients.TripClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "trip")
+	defer cancel()
+	incident, err := h.clients.TripClient.AddIncidentNote(ctx, &trippb.AddIncidentNoteRequest{
+		IncidentId: incidentID,
+		AuthorId:   actorID(r),
+		Text:       req.Text,
+	})
+	h.audit(r, "add_incident_note", incidentID, "", err)
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("trip", err))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, incidentFromProto(incident))
+}
+
+// ResolveIncident handles POST /admin/v1/incidents/{id}/resolve
+func (h *Handler) ResolveIncident(w http.ResponseWriter, r *http.Request) {
+	incidentID := mux.Vars(r)["id"]
+	var req ResolveIncidentRequest
+	if err := api.Bind(r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	if h.clients.TripClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "trip")
+	defer cancel()
+	incident, err := h.clients.TripClient.ResolveIncident(ctx, &trippb.ResolveIncidentRequest{
+		IncidentId: incidentID,
+		StaffId:    actorID(r),
+		Resolution: req.Resolution,
+	})
+	h.audit(r, "resolve_incident", incidentID, req.Resolution, err)
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("trip", err))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, incidentFromProto(incident))
+}
+
+// ListDisputes handles GET /admin/v1/disputes, filtered by the status
+// query parameter and bounded by limit
+func (h *Handler) ListDisputes(w http.ResponseWriter, r *http.Request) {
+	limit, err := intParam(r, "limit", 50)
+	if err == nil && (limit <= 0 || limit > 200) {
+		err = invalidParam("limit", "must be between 1 and 200")
+	}
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	req := &trippb.ListDisputesRequest{Status: r.URL.Query().Get("status"), Limit: int32(limit)}
+	switch req.Status {
+	case "", "open", "in_review", "resolved", "rejected":
+	default:
+		api.WriteError(w, invalidParam("status", "must be one of open, in_review, resolved, rejected"))
+		return
+	}
+	if h.clients.TripClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "trip")
+	defer cancel()
+	resp, err := h.clients.TripClient.ListDisputes(ctx, req)
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("trip", err))
+		return
+	}
+
+	body := &DisputesResponse{Disputes: make([]*Dispute, 0, len(resp.Disputes))}
+	for _, dispute := range resp.Disputes {
+		body.Disputes = append(body.Disputes, disputeFromProto(dispute))
+	}
+	api.WriteJSON(w, http.StatusOK, body)
+}
+
+// GetDispute handles GET /admin/v1/disputes/{id}, returning the dispute with
+// the trip's route and fare breakdown
+func (h *Handler) GetDispute(w http.ResponseWriter, r *http.Request) {
+	if h.clients.TripClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "trip")
+	defer cancel()
+	review, err := h.clients.TripClient.GetDisputeReview(ctx, &trippb.GetDisputeRequest{DisputeId: mux.Vars(r)["id"]})
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("trip", err))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, disputeReviewFromProto(review))
+}
+
+// StartDisputeReview handles POST /admin/v1/disputes/{id}/review, assigning
+// the dispute to the caller
+func (h *Handler) StartDisputeReview(w http.ResponseWriter, r *http.Request) {
+	disputeID := mux.Vars(r)["id"]
+	if h.clients.TripClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "trip")
+	defer cancel()
+	dispute, err := h.clients.TripClient.StartDisputeReview(ctx, &trippb.StartDisputeReviewRequest{
+		DisputeId: disputeID,
+		StaffId:   actorID(r),
+	})
+	h.audit(r, "review_dispute", disputeID, "", err)
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("trip", err))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, disputeFromProto(dispute))
+}
+
+// AddDisputeNote handles POST /admin/v1/disputes/{id}/notes
+func (h *Handler) AddDisputeNote(w http.ResponseWriter, r *http.Request) {
+	disputeID := mux.Vars(r)["id"]
+	var req DisputeNoteRequest
+	if err := api.Bind(r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	if h.clients.TripClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "trip")
+	defer cancel()
+	dispute, err := h.clients.TripClient.AddDisputeNote(ctx, &trippb.AddDisputeNoteRequest{
+		DisputeId: disputeID,
+		AuthorId:  actorID(r),
+		Text:      req.Text,
+	})
+	h.audit(r, "add_dispute_note", disputeID, "", err)
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("trip", err))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, disputeFromProto(dispute))
+}
+
+// ResolveDispute handles POST /admin/v1/disputes/{id}/resolve, refunding
+// the rider refund_amount of the charge when it is set
+func (h *Handler) ResolveDispute(w http.ResponseWriter, r *http.Request) {
+	disputeID := mux.Vars(r)["id"]
+	var req ResolveDisputeRequest
+	if err := api.Bind(r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	if h.clients.TripClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "trip")
+	defer cancel()
+	dispute, err := h.clients.TripClient.ResolveDispute(ctx, &trippb.ResolveDisputeRequest{
+		DisputeId:    disputeID,
+		StaffId:      actorID(r),
+		Resolution:   req.Resolution,
+		RefundAmount: req.RefundAmount,
+	})
+	detail := req.Resolution
+	if req.RefundAmount > 0 {
+		detail = fmt.Sprintf("refund %.2f: %s", req.RefundAmount, req.Resolution)
+	}
+	h.audit(r, "resolve_dispute", disputeID, detail, err)
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("trip", err))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, disputeFromProto(dispute))
+}
+
+// RejectDispute handles POST /admin/v1/disputes/{id}/reject, upholding the fare
+func (h *Handler) RejectDispute(w http.ResponseWriter, r *http.Request) {
+	disputeID := mux.Vars(r)["id"]
+	var req RejectDisputeRequest
+	if err := api.Bind(r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	if h.clients.TripClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "trip")
+	defer cancel()
+	dispute, err := h.clients.TripClient.RejectDispute(ctx, &trippb.RejectDisputeRequest{
+		DisputeId:  disputeID,
+		StaffId:    actorID(r),
+		Resolution: req.Resolution,
+	})
+	h.audit(r, "reject_dispute", disputeID, req.Resolution, err)
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("trip", err))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, disputeFromProto(dispute))
+}
+
+// ListChargebacks handles GET /admin/v1/chargebacks, filtered by the status
+// query parameter and paged by limit and cursor
+func (h *Handler) ListChargebacks(w http.ResponseWriter, r *http.Request) {
+	limit, cursor, err := pageParams(r)
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	req := &paymentpb.ListChargebacksRequest{Status: r.URL.Query().Get("status"), Limit: limit, Cursor: cursor}
+	switch req.Status {
+	case "", "needs_response", "under_review", "won", "lost", "accepted":
+	default:
+		api.WriteError(w, invalidParam("status", "must be one of needs_response, under_review, won, lost, accepted"))
+		return
+	}
+	if h.clients.PaymentClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("payment"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "payment")
+	defer cancel()
+	resp, err := h.clients.PaymentClient.ListChargebacks(ctx, req)
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("payment", err))
+		return
+	}
+
+	body := &ChargebacksResponse{
+		Chargebacks: make([]*Chargeback, 0, len(resp.Chargebacks)),
+		HasMore:     resp.HasMore,
+		NextCursor:  resp.NextCursor,
+	}
+	for _, chargeback := range resp.Chargebacks {
+		body.Chargebacks = append(body.Chargebacks, chargebackFromProto(chargeback))
+	}
+	api.WriteJSON(w, http.StatusOK, body)
+}
+
+// GetChargeback handles GET /admin/v1/chargebacks/{id}, returning the
+// chargeback with the evidence submitted so far
+func (h *Handler) GetChargeback(w http.ResponseWriter, r *http.Request) {
+	if h.clients.PaymentClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("payment"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "payment")
+	defer cancel()
+	resp, err := h.clients.PaymentClient.GetChargeback(ctx, &paymentpb.GetChargebackRequest{ChargebackId: mux.Vars(r)["id"]})
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("payment", err))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, chargebackFromProto(resp.Chargeback))
+}
+
+// SubmitChargebackEvidence handles POST /admin/v1/chargebacks/{id}/evidence,
+// submitting evidence as the caller and moving the chargeback under review
+func (h *Handler) SubmitChargebackEvidence(w http.ResponseWriter, r *http.Request) {
+	chargebackID := mux.Vars(r)["id"]
+	var req ChargebackEvidenceRequest
+	if err := api.Bind(r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	if h.clients.PaymentClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("payment"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "payment")
+	defer cancel()
+	resp, err := h.clients.PaymentClient.SubmitChargebackEvidence(ctx, &paymentpb.SubmitChargebackEvidenceRequest{
+		ChargebackId: chargebackID,
+		Type:         req.Type,
+		Description:  req.Description,
+		SubmittedBy:  actorID(r),
+	})
+	h.audit(r, "submit_chargeback_evidence", chargebackID, req.Type, err)
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("payment", err))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, chargebackFromProto(resp.Chargeback))
+}
+
+// RecordChargebackOutcome handles POST /admin/v1/chargebacks/{id}/outcome. A
+// won chargeback releases the driver's frozen fare; a lost or accepted one
+// leaves the payment refunded.
+func (h *Handler) RecordChargebackOutcome(w http.ResponseWriter, r *http.Request) {
+	chargebackID := mux.Vars(r)["id"]
+	var req ChargebackOutcomeRequest
+	if err := api.Bind(r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	if h.clients.PaymentClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("payment"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "payment")
+	defer cancel()
+	resp, err := h.clients.PaymentClient.RecordChargebackOutcome(ctx, &paymentpb.RecordChargebackOutcomeRequest{
+		ChargebackId: chargebackID,
+		Outcome:      req.Outcome,
+		Notes:        req.Notes,
+		ResolvedBy:   actorID(r),
+	})
+	h.audit(r, "record_chargeback_outcome", chargebackID, req.Outcome, err)
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("payment", err))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, chargebackFromProto(resp.Chargeback))
+}
+
+// ListRefundCases handles GET /admin/v1/refund-cases, filtered by the status
+// query parameter (pending_review by default) and bounded by limit
+func (h *Handler) ListRefundCases(w http.ResponseWriter, r *http.Request) {
+	limit, err := intParam(r, "limit", 50)
+	if err == nil && (limit <= 0 || limit > 200) {
+		err = invalidParam("limit", "must be between 1 and 200")
+	}
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	req := &paymentpb.ListRefundCasesRequest{Status: r.URL.Query().Get("status"), Limit: int32(limit)}
+	switch req.Status {
+	case "", "pending_review", "refunded", "rejected", "failed":
+	default:
+		api.WriteError(w, invalidParam("status", "must be one of pending_review, refunded, rejected, failed"))
+		return
+	}
+	if h.clients.PaymentClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("payment"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "payment")
+	defer cancel()
+	resp, err := h.clients.PaymentClient.ListRefundCases(ctx, req)
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("payment", err))
+		return
+	}
+
+	body := &RefundCasesResponse{Cases: make([]*RefundCase, 0, len(resp.Cases))}
+	for _, refundCase := range resp.Cases {
+		body.Cases = append(body.Cases, refundCaseFromProto(refundCase))
+	}
+	api.WriteJSON(w, http.StatusOK, body)
+}
+
+// GetRefundCase handles GET /admin/v1/refund-cases/{id}, returning the case
+// with its audit trail
+func (h *Handler) GetRefundCase(w http.ResponseWriter, r *http.Request) {
+	if h.clients.PaymentClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("payment"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "payment")
+	defer cancel()
+	resp, err := h.clients.PaymentClient.GetRefundCase(ctx, &paymentpb.GetRefundCaseRequest{CaseId: mux.Vars(r)["id"]})
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("payment", err))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, refundCaseFromProto(resp.Case))
+}
+
+// ApproveRefundCase handles POST /admin/v1/refund-cases/{id}/approve, paying
+// a refund queued for review. Cases already decided are answered with a
+// conflict.
+func (h *Handler) ApproveRefundCase(w http.ResponseWriter, r *http.Request) {
+	h.resolveRefundCase(w, r, true)
+}
+
+// RejectRefundCase handles POST /admin/v1/refund-cases/{id}/reject, declining
+// a refund queued for review
+func (h *Handler) RejectRefundCase(w http.ResponseWriter, r *http.Request) {
+	h.resolveRefundCase(w, r, false)
+}
+
+// resolveRefundCase decides a refund case as the caller; payment-service
+// records the reviewer from the forwarded token
+func (h *Handler) resolveRefundCase(w http.ResponseWriter, r *http.Request, approve bool) {
+	caseID := mux.Vars(r)["id"]
+	var req RefundDecisionRequest
+	if err := api.Bind(r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	if h.clients.PaymentClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("payment"))
+		return
+	}
+
+	action := "reject_refund_case"
+	if approve {
+		action = "approve_refund_case"
+	}
+	ctx, cancel := h.outgoing(r, "payment")
+	defer cancel()
+	resp, err := h.clients.PaymentClient.ResolveRefundCase(ctx, &paymentpb.ResolveRefundCaseRequest{
+		CaseId:  caseID,
+		Approve: approve,
+		Notes:   req.Notes,
+	})
+	h.audit(r, action, caseID, req.Notes, err)
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("payment", err))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, refundCaseFromProto(resp.Case))
+}
+
+// ListFraudRules handles GET /admin/v1/fraud-rules, listing the enabled
+// fraud rules, highest priority first
+func (h *Handler) ListFraudRules(w http.ResponseWriter, r *http.Request) {
+	if h.clients.PaymentClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("payment"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "payment")
+	defer cancel()
+	resp, err := h.clients.PaymentClient.ListFraudRules(ctx, &paymentpb.ListFraudRulesRequest{})
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("payment", err))
+		return
+	}
+
+	body := &FraudRulesResponse{Rules: make([]*FraudRule, 0, len(resp.Rules))}
+	for _, rule := range resp.Rules {
+		body.Rules = append(body.Rules, fraudRuleFromProto(rule))
+	}
+	api.WriteJSON(w, http.StatusOK, body)
+}
+
+// CreateFraudRule handles POST /admin/v1/fraud-rules. Payment-service
+// compiles the expression and refuses rules that do not compile.
+func (h *Handler) CreateFraudRule(w http.ResponseWriter, r *http.Request) {
+	h.saveFraudRule(w, r, "", http.StatusCreated)
+}
+
+// ReplaceFraudRule handles PUT /admin/v1/fraud-rules/{id}
+func (h *Handler) ReplaceFraudRule(w http.ResponseWriter, r *http.Request) {
+	h.saveFraudRule(w, r, mux.Vars(r)["id"], http.StatusOK)
+}
+
+func (h *Handler) saveFraudRule(w http.ResponseWriter, r *http.Request, ruleID string, created int) {
+	var req FraudRuleRequest
+	if err := api.Bind(r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	if h.clients.PaymentClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("payment"))
+		return
+	}
+
+	enabled := req.Enabled == nil || *req.Enabled
+	ctx, cancel := h.outgoing(r, "payment")
+	defer cancel()
+	resp, err := h.clients.PaymentClient.SaveFraudRule(ctx, &paymentpb.SaveFraudRuleRequest{
+		Rule: &paymentpb.FraudRule{
+			Id:          ruleID,
+			Name:        req.Name,
+			Description: req.Description,
+			Expression:  req.Expression,
+			Score:       req.Score,
+			Action:      req.Action,
+			Priority:    req.Priority,
+			Enabled:     enabled,
+		},
+	})
+	target := ruleID
+	if err == nil {
+		target = resp.Rule.Id
+	}
+	h.audit(r, "save_fraud_rule", target, req.Reason, err)
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("payment", err))
+		return
+	}
+	api.WriteJSON(w, created, fraudRuleFromProto(resp.Rule))
+}
+
+// EnableFraudRule handles POST /admin/v1/fraud-rules/{id}/enable
+func (h *Handler) EnableFraudRule(w http.ResponseWriter, r *http.Request) {
+	h.setFraudRuleEnabled(w, r, true)
+}
+
+// DisableFraudRule handles POST /admin/v1/fraud-rules/{id}/disable. The rule
+// is kept and stops matching payments until it is enabled again.
+func (h *Handler) DisableFraudRule(w http.ResponseWriter, r *http.Request) {
+	h.setFraudRuleEnabled(w, r, false)
+}
+
+func (h *Handler) setFraudRuleEnabled(w http.ResponseWriter, r *http.Request, enabled bool) {
+	ruleID := mux.Vars(r)["id"]
+	var req ActionRequest
+	if err := api.Bind(r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	if h.clients.PaymentClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("payment"))
+		return
+	}
+
+	action, result := "disable_fraud_rule", "disabled"
+	if enabled {
+		action, result = "enable_fraud_rule", "enabled"
+	}
+	ctx, cancel := h.outgoing(r, "payment")
+	defer cancel()
+	_, err := h.clients.PaymentClient.SetFraudRuleEnabled(ctx, &paymentpb.SetFraudRuleEnabledRequest{RuleId: ruleID, Enabled: enabled})
+	h.audit(r, action, ruleID, req.Reason, err)
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("payment", err))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, &ActionResponse{Action: action, TargetID: ruleID, Status: result})
+}
+
+// DeleteFraudRule handles DELETE /admin/v1/fraud-rules/{id}
+func (h *Handler) DeleteFraudRule(w http.ResponseWriter, r *http.Request) {
+	ruleID := mux.Vars(r)["id"]
+	var req ActionRequest
+	if err := api.Bind(r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	if h.clients.PaymentClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("payment"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "payment")
+	defer cancel()
+	_, err := h.clients.PaymentClient.DeleteFraudRule(ctx, &paymentpb.DeleteFraudRuleRequest{RuleId: ruleID})
+	h.audit(r, "delete_fraud_rule", ruleID, req.Reason, err)
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("payment", err))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, &ActionResponse{Action: "delete_fraud_rule", TargetID: ruleID, Status: "deleted"})
+}
+
+// ListFraudReviews handles GET /admin/v1/fraud-reviews, filtered by the
+// status query parameter (pending by default) and bounded by limit
+func (h *Handler) ListFraudReviews(w http.ResponseWriter, r *http.Request) {
+	limit, err := intParam(r, "limit", 50)
+	if err == nil && (limit <= 0 || limit > 200) {
+		err = invalidParam("limit", "must be between 1 and 200")
+	}
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	req := &paymentpb.ListFraudReviewsRequest{Status: r.URL.Query().Get("status"), Limit: int32(limit)}
+	switch req.Status {
+	case "", "pending", "approved", "rejected":
+	default:
+		api.WriteError(w, invalidParam("status", "must be one of pending, approved, rejected"))
+		return
+	}
+	if h.clients.PaymentClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("payment"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "payment")
+	defer cancel()
+	resp, err := h.clients.PaymentClient.ListFraudReviews(ctx, req)
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("payment", err))
+		return
+	}
+
+	body := &FraudReviewsResponse{Reviews: make([]*FraudReview, 0, len(resp.Reviews))}
+	for _, review := range resp.Reviews {
+		body.Reviews = append(body.Reviews, fraudReviewFromProto(review))
+	}
+	api.WriteJSON(w, http.StatusOK, body)
+}
+
+// ApproveFraudReview handles POST /admin/v1/fraud-reviews/{id}/approve,
+// clearing a flagged payment as legitimate. Reviews already decided are
+// answered with a conflict.
+func (h *Handler) ApproveFraudReview(w http.ResponseWriter, r *http.Request) {
+	h.resolveFraudReview(w, r, true)
+}
+
+// RejectFraudReview handles POST /admin/v1/fraud-reviews/{id}/reject,
+// confirming a flagged payment as fraudulent
+func (h *Handler) RejectFraudReview(w http.ResponseWriter, r *http.Request) {
+	h.resolveFraudReview(w, r, false)
+}
+
+// resolveFraudReview decides a fraud review as the caller; payment-service
+// records the reviewer from the forwarded token
+func (h *Handler) resolveFraudReview(w http.ResponseWriter, r *http.Request, approve bool) {
+	reviewID := mux.Vars(r)["id"]
+	var req FraudDecisionRequest
+	if err := api.Bind(r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	if h.clients.PaymentClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("payment"))
+		return
+	}
+
+	action := "reject_fraud_review"
+	if approve {
+		action = "approve_fraud_review"
+	}
+	ctx, cancel := h.outgoing(r, "payment")
+	defer cancel()
+	resp, err := h.clients.PaymentClient.ResolveFraudReview(ctx, &paymentpb.ResolveFraudReviewRequest{
+		ReviewId: reviewID,
+		Approve:  approve,
+		Notes:    req.Notes,
+	})
+	h.audit(r, action, reviewID, req.Notes, err)
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("payment", err))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, fraudReviewFromProto(resp.Review))
+}
+
+// RecordDriverPayout handles POST /admin/v1/drivers/{id}/payouts, recording
+// a transfer to the driver's bank account against what the platform owes
+// them. A transfer already recorded, or one above what the driver is owed,
+// is answered with a conflict.
+func (h *Handler) RecordDriverPayout(w http.ResponseWriter, r *http.Request) {
+	driverID := mux.Vars(r)["id"]
+	var req PayoutRequest
+	if err := api.Bind(r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	if h.clients.PaymentClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("payment"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "payment")
+	defer cancel()
+	resp, err := h.clients.PaymentClient.RecordDriverPayout(ctx, &paymentpb.RecordDriverPayoutRequest{
+		DriverId:  driverID,
+		Amount:    req.Amount,
+		Currency:  req.Currency,
+		Reference: req.Reference,
+	})
+	h.audit(r, "record_driver_payout", driverID, req.Reason, err)
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("payment", err))
+		return
+	}
+	api.WriteJSON(w, http.StatusCreated, payoutFromProto(resp))
+}
+
+// GrantWalletCredit handles POST /admin/v1/users/{id}/wallet/credits, giving
+// a rider ride credits from a promotion or a goodwill refund. A credit the
+// wallet declines, such as a repeated reference, is answered with a
+// conflict.
+func (h *Handler) GrantWalletCredit(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["id"]
+	var req WalletCreditRequest
+	if err := api.Bind(r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	if h.clients.PaymentClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("payment"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "payment")
+	defer cancel()
+	resp, err := h.clients.PaymentClient.GrantWalletCredit(ctx, &paymentpb.GrantWalletCreditRequest{
+		UserId:      userID,
+		Amount:      req.Amount,
+		Currency:    req.Currency,
+		Type:        req.Type,
+		Reference:   req.Reference,
+		Description: req.Reason,
+	})
+	if err != nil {
+		h.audit(r, "grant_wallet_credit", userID, req.Reason, err)
+		api.WriteError(w, api.FromGRPC("payment", err))
+		return
+	}
+	if !resp.Success {
+		declined := api.NewError(http.StatusConflict, api.CodeConflict, resp.Message)
+		h.audit(r, "grant_wallet_credit", userID, req.Reason, declined)
+		api.WriteError(w, declined)
+		return
+	}
+	h.audit(r, "grant_wallet_credit", userID, req.Reason, nil)
+	api.WriteJSON(w, http.StatusCreated, &WalletCredit{
+		TransactionID: resp.TransactionId,
+		UserID:        userID,
+		Amount:        req.Amount,
+		Currency:      req.Currency,
+		Type:          req.Type,
+		Reference:     req.Reference,
+	})
+}
+
+// ListLostItems handles GET /admin/v1/lost-items, filtered by the status,
+// rider_id, driver_id and trip_id query parameters and bounded by limit
+func (h *Handler) ListLostItems(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	limit, err := intParam(r, "limit", 50)
+	if err == nil && (limit <= 0 || limit > 200) {
+		err = invalidParam("limit", "must be between 1 and 200")
+	}
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	req := &trippb.ListLostItemsRequest{
+		Status:   query.Get("status"),
+		RiderId:  query.Get("rider_id"),
+		DriverId: query.Get("driver_id"),
+		TripId:   query.Get("trip_id"),
+		Limit:    int32(limit),
+	}
+	switch req.Status {
+	case "", "reported", "found", "returned", "not_found":
+	default:
+		api.WriteError(w, invalidParam("status", "must be one of reported, found, returned, not_found"))
+		return
+	}
+	if h.clients.TripClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "trip")
+	defer cancel()
+	resp, err := h.clients.TripClient.ListLostItems(ctx, req)
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("trip", err))
+		return
+	}
+
+	body := &LostItemsResponse{Reports: make([]*LostItemReport, 0, len(resp.Reports))}
+	for _, report := range resp.Reports {
+		body.Reports = append(body.Reports, lostItemFromProto(report))
+	}
+	api.WriteJSON(w, http.StatusOK, body)
+}
+
+// PickupSLAReport handles GET /admin/v1/pickup-guarantees/report: how
+// drivers and areas kept the pickup ETAs riders were promised, for trips
+// matched between the from and to query parameters (RFC3339, by default the
+// last 24 hours), optionally in one city_id
+func (h *Handler) PickupSLAReport(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	to := time.Now()
+	if raw := query.Get("to"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			api.WriteError(w, invalidParam("to", "must be an RFC3339 timestamp"))
+			return
+		}
+		to = parsed
+	}
+	from := to.Add(-24 * time.Hour)
+	if raw := query.Get("from"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil || !parsed.Before(to) {
+			api.WriteError(w, invalidParam("from", "must be an RFC3339 timestamp before to"))
+			return
+		}
+		from = parsed
+	}
+	if h.clients.TripClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "trip")
+	defer cancel()
+	report, err := h.clients.TripClient.GetPickupSLAReport(ctx, &trippb.GetPickupSLAReportRequest{
+		From:   timestamppb.New(from),
+		To:     timestamppb.New(to),
+		CityId: query.Get("city_id"),
+	})
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("trip", err))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, pickupSLAReportFromProto(report))
+}
+
+// ListDeadLetters handles GET /admin/v1/dead-letters, the events
+// trip-service's consumers failed to handle, most recently failed first,
+// filtered by the consumer, event_type and poison query parameters and
+// bounded by limit
+func (h *Handler) ListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	limit, err := intParam(r, "limit", 100)
+	if err == nil && (limit <= 0 || limit > 1000) {
+		err = invalidParam("limit", "must be between 1 and 1000")
+	}
+	if err == nil && query.Get("poison") != "" {
+		if _, parseErr := strconv.ParseBool(query.Get("poison")); parseErr != nil {
+			err = invalidParam("poison", "must be true or false")
+		}
+	}
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	if h.clients.TripClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "trip")
+	defer cancel()
+	resp, err := h.clients.TripClient.ListDeadLetters(ctx, &trippb.ListDeadLettersRequest{
+		Consumer:  query.Get("consumer"),
+		EventType: query.Get("event_type"),
+		Poison:    query.Get("poison"),
+		Limit:     int32(limit),
+	})
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("trip", err))
+		return
+	}
+
+	body := &DeadLettersResponse{DeadLetters: make([]*DeadLetter, 0, len(resp.DeadLetters))}
+	for _, letter := range resp.DeadLetters {
+		body.DeadLetters = append(body.DeadLetters, deadLetterFromProto(letter))
+	}
+	body.Count = len(body.DeadLetters)
+	api.WriteJSON(w, http.StatusOK, body)
+}
+
+// GetDeadLetter handles GET /admin/v1/dead-letters/{id}, a dead letter with
+// its event
+func (h *Handler) GetDeadLetter(w http.ResponseWriter, r *http.Request) {
+	if h.clients.TripClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "trip")
+	defer cancel()
+	letter, err := h.clients.TripClient.GetDeadLetter(ctx, &trippb.GetDeadLetterRequest{DeadLetterId: mux.Vars(r)["id"]})
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("trip", err))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, deadLetterFromProto(letter))
+}
+
+// ReplayDeadLetter handles POST /admin/v1/dead-letters/{id}/replay, handing
+// the event to the handler that failed it again. Events that fail again stay
+// dead-lettered and are answered with a conflict.
+func (h *Handler) ReplayDeadLetter(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	var req ActionRequest
+	if err := api.Bind(r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	if h.clients.TripClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "trip")
+	defer cancel()
+	_, err := h.clients.TripClient.ReplayDeadLetter(ctx, &trippb.ReplayDeadLetterRequest{DeadLetterId: id})
+	h.audit(r, "replay_dead_letter", id, req.Reason, err)
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("trip", err))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, &ActionResponse{Action: "replay_dead_letter", TargetID: id, Status: "replayed"})
+}
+
+// DiscardDeadLetter handles DELETE /admin/v1/dead-letters/{id}, dropping a
+// dead letter without handling its event
+func (h *Handler) DiscardDeadLetter(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+	var req ActionRequest
+	if err := api.Bind(r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	if h.clients.TripClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "trip")
+	defer cancel()
+	_, err := h.clients.TripClient.DiscardDeadLetter(ctx, &trippb.DiscardDeadLetterRequest{DeadLetterId: id})
+	h.audit(r, "discard_dead_letter", id, req.Reason, err)
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("trip", err))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, &ActionResponse{Action: "discard_dead_letter", TargetID: id, Status: "discarded"})
+}
+
+// TripListingsLag handles GET /admin/v1/projections/trip-listings, how far
+// the trip listing read model is behind the trip event log
+func (h *Handler) TripListingsLag(w http.ResponseWriter, r *http.Request) {
+	if h.clients.TripClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "trip")
+	defer cancel()
+	lag, err := h.clients.TripClient.GetTripListingsLag(ctx, &trippb.GetTripListingsLagRequest{})
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("trip", err))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, projectionLagFromProto(lag))
+}
+
+// RebuildTripListings handles POST
+// /admin/v1/projections/trip-listings/rebuild, dropping the trip listings
+// and projecting the whole trip event log again
+func (h *Handler) RebuildTripListings(w http.ResponseWriter, r *http.Request) {
+	var req ActionRequest
+	if err := api.Bind(r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	if h.clients.TripClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "trip")
+	defer cancel()
+	resp, err := h.clients.TripClient.RebuildTripListings(ctx, &trippb.RebuildTripListingsRequest{})
+	h.audit(r, "rebuild_projection", "trip-listings", req.Reason, err)
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("trip", err))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, &RebuildResponse{Projection: "trip-listings", Projected: resp.Projected})
+}
+
+// ListComplianceReports handles GET /admin/v1/compliance/reports, the
+// reports generated for cities' regulators newest first, filtered by the
+// city_id and template_id query parameters and bounded by limit
+func (h *Handler) ListComplianceReports(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	limit, err := intParam(r, "limit", 30)
+	if err == nil && (limit <= 0 || limit > 100) {
+		err = invalidParam("limit", "must be between 1 and 100")
+	}
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	if h.clients.TripClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "trip")
+	defer cancel()
+	resp, err := h.clients.TripClient.ListComplianceReports(ctx, &trippb.ListComplianceReportsRequest{
+		CityId:     query.Get("city_id"),
+		TemplateId: query.Get("template_id"),
+		Limit:      int32(limit),
+	})
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("trip", err))
+		return
+	}
+
+	body := &ComplianceReportsResponse{Reports: make([]*ComplianceReport, 0, len(resp.Reports))}
+	for _, report := range resp.Reports {
+		body.Reports = append(body.Reports, complianceReportFromProto(report))
+	}
+	api.WriteJSON(w, http.StatusOK, body)
+}
+
+// GenerateComplianceReport handles POST /admin/v1/compliance/reports,
+// generating a template's report for a period that has ended, such as one
+// a regulator asked to be filed again
+func (h *Handler) GenerateComplianceReport(w http.ResponseWriter, r *http.Request) {
+	var req ComplianceReportRequest
+	if err := api.Bind(r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	if h.clients.TripClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "trip")
+	defer cancel()
+	report, err := h.clients.TripClient.GenerateComplianceReport(ctx, &trippb.GenerateComplianceReportRequest{
+		TemplateId:  req.TemplateID,
+		Date:        req.Date,
+		GeneratedBy: actorID(r),
+	})
+	h.audit(r, "generate_compliance_report", req.TemplateID, req.Date, err)
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("trip", err))
+		return
+	}
+	api.WriteJSON(w, http.StatusCreated, complianceReportFromProto(report))
+}
+
+// GetComplianceReport handles GET /admin/v1/compliance/reports/{id}
+func (h *Handler) GetComplianceReport(w http.ResponseWriter, r *http.Request) {
+	if h.clients.TripClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "trip")
+	defer cancel()
+	report, err := h.clients.TripClient.GetComplianceReport(ctx, &trippb.GetComplianceReportRequest{ReportId: mux.Vars(r)["id"]})
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("trip", err))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, complianceReportFromProto(report))
+}
+
+// DownloadComplianceReport handles GET
+// /admin/v1/compliance/reports/{id}/download, returning the report file as
+// it was generated. Downloads are audited since reports hold trip data.
+func (h *Handler) DownloadComplianceReport(w http.ResponseWriter, r *http.Request) {
+	reportID := mux.Vars(r)["id"]
+	if h.clients.TripClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "trip")
+	defer cancel()
+	report, err := h.clients.TripClient.GetComplianceReport(ctx, &trippb.GetComplianceReportRequest{ReportId: reportID})
+	h.audit(r, "download_compliance_report", reportID, "", err)
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("trip", err))
+		return
+	}
+
+	filename := fmt.Sprintf("%s-%s.csv", report.TemplateId, report.PeriodStart.AsTime().In(complianceLocation(report.Timezone)).Format(time.DateOnly))
+	w.Header().Set("Content-Type", report.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	w.WriteHeader(http.StatusOK)
+	w.Write(report.Content)
+}
+
+// GetLostItem handles GET /admin/v1/lost-items/{id}
+func (h *Handler) GetLostItem(w http.ResponseWriter, r *http.Request) {
+	if h.clients.TripClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "trip")
+	defer cancel()
+	report, err := h.clients.TripClient.GetLostItem(ctx, &trippb.GetLostItemRequest{ReportId: mux.Vars(r)["id"]})
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("trip", err))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, lostItemFromProto(report))
+}
+
+// UpdateLostItem handles POST /admin/v1/lost-items/{id}/updates, moving the
+// report to a new status, adding a note, or both
+func (h *Handler) UpdateLostItem(w http.ResponseWriter, r *http.Request) {
+	reportID := mux.Vars(r)["id"]
+	var req LostItemUpdateRequest
+	if err := api.Bind(r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	if h.clients.TripClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "trip")
+	defer cancel()
+	report, err := h.clients.TripClient.UpdateLostItem(ctx, &trippb.UpdateLostItemRequest{
+		ReportId: reportID,
+		AuthorId: actorID(r),
+		Status:   req.Status,
+		Note:     req.Note,
+	})
+	h.audit(r, "update_lost_item", reportID, req.Status, err)
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("trip", err))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, lostItemFromProto(report))
+}
+
+// outgoing returns the context for a call to a backend service, bounded by
+// the service's timeout and carrying the operator's token so services that
+// require authentication accept it
+func (h *Handler) outgoing(r *http.Request, service string) (context.Context, context.CancelFunc) {
+	ctx := r.Context()
+	if token, ok := ctx.Value(tokenKey{}).(string); ok {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+	}
+	return h.clients.WithTimeout(ctx, service)
+}
+
+// audit records who performed a manual action, on what and why
+// ListFlags handles GET /admin/v1/flags
+func (h *Handler) ListFlags(w http.ResponseWriter, r *http.Request) {
+	if h.flags == nil {
+		api.WriteError(w, api.ServiceUnavailable("flags"))
+		return
+	}
+
+	list, err := h.flags.List(r.Context())
+	if err != nil {
+		h.logger.WithError(err).Warn("Failed to list feature flags")
+		api.WriteError(w, api.ServiceUnavailable("flags"))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, &FlagsResponse{Flags: list, Count: len(list)})
+}
+
+// GetFlag handles GET /admin/v1/flags/{name}
+func (h *Handler) GetFlag(w http.ResponseWriter, r *http.Request) {
+	if h.flags == nil {
+		api.WriteError(w, api.ServiceUnavailable("flags"))
+		return
+	}
+
+	flag, err := h.flags.Get(r.Context(), mux.Vars(r)["name"])
+	if err != nil {
+		api.WriteError(w, h.flagError(err))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, flag)
+}
+
+// SaveFlag handles PUT /admin/v1/flags/{name}, creating the flag or
+// replacing it whole
+func (h *Handler) SaveFlag(w http.ResponseWriter, r *http.Request) {
+	var req FlagRequest
+	if err := api.Bind(r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	flag := &flags.Flag{
+		Name:        mux.Vars(r)["name"],
+		Description: req.Description,
+		Enabled:     req.Enabled,
+		Rollout:     req.Rollout,
+		Cities:      req.Cities,
+		UpdatedBy:   actorID(r),
+		UpdatedAt:   time.Now().UTC(),
+	}
+	if err := flag.Validate(); err != nil {
+		api.WriteError(w, api.NewError(http.StatusBadRequest, api.CodeValidationFailed, err.Error()))
+		return
+	}
+	if h.flags == nil {
+		api.WriteError(w, api.ServiceUnavailable("flags"))
+		return
+	}
+
+	err := h.flags.Save(r.Context(), flag)
+	h.audit(r, "save_flag", flag.Name, req.Reason, err)
+	if err != nil {
+		api.WriteError(w, h.flagError(err))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, flag)
+}
+
+// DeleteFlag handles DELETE /admin/v1/flags/{name}. Services treat a deleted
+// flag as unknown and fall back to their default.
+func (h *Handler) DeleteFlag(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+	var req ActionRequest
+	if err := api.Bind(r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	if h.flags == nil {
+		api.WriteError(w, api.ServiceUnavailable("flags"))
+		return
+	}
+
+	err := h.flags.Delete(r.Context(), name)
+	h.audit(r, "delete_flag", name, req.Reason, err)
+	if err != nil {
+		api.WriteError(w, h.flagError(err))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, &ActionResponse{Action: "delete_flag", TargetID: name, Status: "deleted"})
+}
+
+// flagError maps a flag store error to the response for it
+func (h *Handler) flagError(err error) error {
+	if errors.Is(err, flags.ErrFlagNotFound) {
+		return api.NewError(http.StatusNotFound, api.CodeNotFound, "feature flag not found")
+	}
+	h.logger.WithError(err).Warn("Feature flag store failed")
+	return api.ServiceUnavailable("flags")
+}
+
+// ListMatchingConfigs handles GET /admin/v1/matching-configs, the cities
+// with a matching config of their own. Cities without one are matched with
+// the "default" config.
+func (h *Handler) ListMatchingConfigs(w http.ResponseWriter, r *http.Request) {
+	if h.clients.MatchingClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("matching"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "matching")
+	defer cancel()
+	resp, err := h.clients.MatchingClient.ListMatchingConfigs(ctx, &matchingpb.ListMatchingConfigsRequest{})
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("matching", err))
+		return
+	}
+
+	configs := make([]*MatchingConfig, 0, len(resp.Configs))
+	for _, config := range resp.Configs {
+		configs = append(configs, matchingConfigFromProto(config))
+	}
+	api.WriteJSON(w, http.StatusOK, &MatchingConfigsResponse{Configs: configs, Count: len(configs)})
+}
+
+// GetMatchingConfig handles GET /admin/v1/matching-configs/{city}, the
+// config the city is matched with, or the version given by the version
+// query parameter to reproduce a logged match
+func (h *Handler) GetMatchingConfig(w http.ResponseWriter, r *http.Request) {
+	version, err := intParam(r, "version", 0)
+	if err == nil && version < 0 {
+		err = invalidParam("version", "must not be negative")
+	}
+	if err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	if h.clients.MatchingClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("matching"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "matching")
+	defer cancel()
+	config, err := h.clients.MatchingClient.GetMatchingConfig(ctx, &matchingpb.GetMatchingConfigRequest{
+		City:    mux.Vars(r)["city"],
+		Version: int64(version),
+	})
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("matching", err))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, matchingConfigFromProto(config))
+}
+
+// SaveMatchingConfig handles PUT /admin/v1/matching-configs/{city}, saving
+// the city's config as its next version. It applies to new matches within
+// the matching service's refresh interval.
+func (h *Handler) SaveMatchingConfig(w http.ResponseWriter, r *http.Request) {
+	city := mux.Vars(r)["city"]
+	var req MatchingConfigRequest
+	if err := api.Bind(r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	if h.clients.MatchingClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("matching"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "matching")
+	defer cancel()
+	config, err := h.clients.MatchingClient.SaveMatchingConfig(ctx, &matchingpb.SaveMatchingConfigRequest{
+		Config: &matchingpb.MatchingConfig{
+			City:                city,
+			DistanceWeight:      req.DistanceWeight,
+			EtaWeight:           req.ETAWeight,
+			RatingWeight:        req.RatingWeight,
+			AvailabilityWeight:  req.AvailabilityWeight,
+			AcceptanceWeight:    req.AcceptanceWeight,
+			CancellationWeight:  req.CancellationWeight,
+			StreakWeight:        req.StreakWeight,
+			StreakTarget:        req.StreakTarget,
+			ScoreDistanceCapKm:  req.ScoreDistanceCapKm,
+			ScoreEtaCapSeconds:  req.ScoreETACapSeconds,
+			InitialRadiusKm:     req.InitialRadiusKm,
+			RadiusStepKm:        req.RadiusStepKm,
+			MaxRadiusKm:         req.MaxRadiusKm,
+			MaxPickupDistanceKm: req.MaxPickupDistanceKm,
+			MinCandidates:       req.MinCandidates,
+			UpdatedBy:           actorID(r),
+		},
+	})
+	h.audit(r, "save_matching_config", city, req.Reason, err)
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("matching", err))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, matchingConfigFromProto(config))
+}
+
+// DeleteMatchingConfig handles DELETE /admin/v1/matching-configs/{city}.
+// The city is matched with the default config again; its past versions are
+// kept.
+func (h *Handler) DeleteMatchingConfig(w http.ResponseWriter, r *http.Request) {
+	city := mux.Vars(r)["city"]
+	var req ActionRequest
+	if err := api.Bind(r, &req); err != nil {
+		api.WriteError(w, err)
+		return
+	}
+	if h.clients.MatchingClient == nil {
+		api.WriteError(w, api.ServiceUnavailable("matching"))
+		return
+	}
+
+	ctx, cancel := h.outgoing(r, "matching")
+	defer cancel()
+	_, err := h.clients.MatchingClient.DeleteMatchingConfig(ctx, &matchingpb.DeleteMatchingConfigRequest{City: city})
+	h.audit(r, "delete_matching_config", city, req.Reason, err)
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("matching", err))
+		return
+	}
+	api.WriteJSON(w, http.StatusOK, &ActionResponse{Action: "delete_matching_config", TargetID: city, Status: "deleted"})
+}
+
+func (h *Handler) audit(r *http.Request, action, targetID, reason string, err error) {
+	entry := h.logger.WithContext(r.Context()).WithFields(logger.Fields{
+		"audit":     true,
+		"action":    action,
+		"target_id": targetID,
+		"actor_id":  actorID(r),
+		"reason":    reason,
+	})
+	if err != nil {
+		entry.WithError(err).Warn("Admin action failed")
+		return
+	}
+	entry.Info("Admin action performed")
+}
+
+func actorID(r *http.Request) string {
+	if claims, ok := ClaimsFromContext(r.Context()); ok {
+		return claims.UserID
+	}
+	return ""
+}
+
+// pageParams reads the limit (default 50, at most 200) and cursor query
+// parameters. The cursor is passed through to the service that issued it.
+func pageParams(r *http.Request) (int32, string, error) {
+	limit, err := intParam(r, "limit", 50)
+	if err == nil && (limit <= 0 || limit > 200) {
+		err = invalidParam("limit", "must be between 1 and 200")
+	}
+	if err != nil {
+		return 0, "", err
+	}
+	return int32(limit), r.URL.Query().Get("cursor"), nil
+}
+
+func intParam(r *http.Request, name string, fallback int) (int, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return fallback, nil
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, invalidParam(name, "must be an integer")
+	}
+	return value, nil
+}
+
+func floatParam(r *http.Request, name string, fallback float64, required bool) (float64, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		if required {
+			return 0, invalidParam(name, "is required")
+		}
+		return fallback, nil
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, invalidParam(name, "must be a number")
+	}
+	return value, nil
+}
+
+func invalidParam(name, message string) error {
+	err := api.NewError(http.StatusBadRequest, api.CodeValidationFailed, "invalid query parameters")
+	err.Details = []api.FieldError{{Field: name, Message: message}}
+	return err
+}
@@ -0,0 +1,1343 @@
+package admin
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/rideshare-platform/services/api-gateway/internal/api"
+	"github.com/rideshare-platform/shared/alerting"
+	"github.com/rideshare-platform/shared/flags"
+	geopb "github.com/rideshare-platform/shared/proto/geo"
+	matchingpb "github.com/rideshare-platform/shared/proto/matching"
+	paymentpb "github.com/rideshare-platform/shared/proto/payment"
+	pricingpb "github.com/rideshare-platform/shared/proto/pricing"
+	trippb "github.com/rideshare-platform/shared/proto/trip"
+	"github.com/rideshare-platform/shared/search"
+)
+
+// Trip is an active trip as shown on the dashboard
+type Trip struct {
+	ID              string        `json:"id"`
+	RiderID         string        `json:"rider_id"`
+	DriverID        string        `json:"driver_id,omitempty"`
+	Status          string        `json:"status"`
+	CityID          string        `json:"city_id,omitempty"`
+	Pickup          *api.Location `json:"pickup,omitempty"`
+	Destination     *api.Location `json:"destination,omitempty"`
+	EstimatedFare   float64       `json:"estimated_fare,omitempty"`
+	SurgeMultiplier float64       `json:"surge_multiplier,omitempty"`
+	RequestedAt     *time.Time    `json:"requested_at,omitempty"`
+	StartedAt       *time.Time    `json:"started_at,omitempty"`
+}
+
+// TripsResponse is a page of active trips
+type TripsResponse struct {
+	Trips      []*Trip `json:"trips"`
+	Total      int32   `json:"total"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+}
+
+// DriverMarker is a driver's position on the online map
+type DriverMarker struct {
+	DriverID    string       `json:"driver_id"`
+	VehicleID   string       `json:"vehicle_id,omitempty"`
+	Location    api.Location `json:"location"`
+	Status      string       `json:"status"`
+	VehicleType string       `json:"vehicle_type,omitempty"`
+	Rating      float64      `json:"rating,omitempty"`
+	DistanceKm  float64      `json:"distance_km"`
+	CityID      string       `json:"city_id,omitempty"`
+}
+
+// DriverMapResponse lists the drivers around the map's center
+type DriverMapResponse struct {
+	Center   api.Location    `json:"center"`
+	RadiusKm float64         `json:"radius_km"`
+	Drivers  []*DriverMarker `json:"drivers"`
+	Total    int32           `json:"total"`
+}
+
+// SurgeArea is an area's current surge
+type SurgeArea struct {
+	Area             string     `json:"area"`
+	CityID           string     `json:"city_id,omitempty"`
+	Multiplier       float64    `json:"multiplier"`
+	DemandLevel      string     `json:"demand_level"`
+	ActiveRequests   int32      `json:"active_requests"`
+	AvailableDrivers int32      `json:"available_drivers"`
+	UpdatedAt        *time.Time `json:"updated_at,omitempty"`
+	ExpiresAt        *time.Time `json:"expires_at,omitempty"`
+}
+
+// SurgeMapResponse lists surging areas, highest multiplier first
+type SurgeMapResponse struct {
+	Areas []*SurgeArea `json:"areas"`
+}
+
+// FailedPayment is an entry in the payment failure queue
+type FailedPayment struct {
+	ID            string     `json:"id"`
+	TripID        string     `json:"trip_id"`
+	UserID        string     `json:"user_id"`
+	Amount        float64    `json:"amount"`
+	Currency      string     `json:"currency"`
+	PaymentMethod string     `json:"payment_method"`
+	FailureReason string     `json:"failure_reason,omitempty"`
+	CreatedAt     *time.Time `json:"created_at,omitempty"`
+}
+
+// PaymentFailuresResponse is a page of failed payments, newest first
+type PaymentFailuresResponse struct {
+	Payments   []*FailedPayment `json:"payments"`
+	HasMore    bool             `json:"has_more"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+}
+
+// AlertsResponse is the alert overview
+type AlertsResponse struct {
+	Active     []*alerting.Alert `json:"active"`
+	BySeverity map[string]int    `json:"by_severity"`
+	// History holds the alerts of the last HistoryHours, when requested
+	History      []*alerting.Alert `json:"history,omitempty"`
+	HistoryHours int               `json:"history_hours,omitempty"`
+}
+
+// SearchResponse holds search results of every type, best match first
+type SearchResponse struct {
+	Results []search.Result `json:"results"`
+	Count   int             `json:"count"`
+}
+
+// ActionRequest carries the reason an operator gives for a manual action
+type ActionRequest struct {
+	Reason string `json:"reason"`
+}
+
+// Validate requires a reason for the audit trail
+func (r *ActionRequest) Validate() []api.FieldError {
+	if strings.TrimSpace(r.Reason) == "" {
+		return []api.FieldError{{Field: "reason", Message: "is required"}}
+	}
+	return nil
+}
+
+// FlagRequest replaces a feature flag. Reason is recorded in the audit
+// trail.
+type FlagRequest struct {
+	Description string                        `json:"description"`
+	Enabled     bool                          `json:"enabled"`
+	Rollout     float64                       `json:"rollout"`
+	Cities      map[string]flags.CityOverride `json:"cities"`
+	Reason      string                        `json:"reason"`
+}
+
+// Validate requires a reason for the audit trail
+func (r *FlagRequest) Validate() []api.FieldError {
+	if strings.TrimSpace(r.Reason) == "" {
+		return []api.FieldError{{Field: "reason", Message: "is required"}}
+	}
+	return nil
+}
+
+// FlagsResponse lists every feature flag, ordered by name
+type FlagsResponse struct {
+	Flags []*flags.Flag `json:"flags"`
+	Count int           `json:"count"`
+}
+
+// MatchingConfigRequest replaces a city's matching config with a new
+// version. The weights must add up to 100. Reason is recorded in the audit
+// trail.
+type MatchingConfigRequest struct {
+	DistanceWeight      float64 `json:"distance_weight"`
+	ETAWeight           float64 `json:"eta_weight"`
+	RatingWeight        float64 `json:"rating_weight"`
+	AvailabilityWeight  float64 `json:"availability_weight"`
+	AcceptanceWeight    float64 `json:"acceptance_weight"`
+	CancellationWeight  float64 `json:"cancellation_weight"`
+	StreakWeight        float64 `json:"streak_weight"`
+	StreakTarget        int32   `json:"streak_target"`
+	ScoreDistanceCapKm  float64 `json:"score_distance_cap_km"`
+	ScoreETACapSeconds  int32   `json:"score_eta_cap_seconds"`
+	InitialRadiusKm     float64 `json:"initial_radius_km"`
+	RadiusStepKm        float64 `json:"radius_step_km"`
+	MaxRadiusKm         float64 `json:"max_radius_km"`
+	MaxPickupDistanceKm float64 `json:"max_pickup_distance_km"`
+	MinCandidates       int32   `json:"min_candidates"`
+	Reason              string  `json:"reason"`
+}
+
+// Validate requires a reason for the audit trail
+func (r *MatchingConfigRequest) Validate() []api.FieldError {
+	if strings.TrimSpace(r.Reason) == "" {
+		return []api.FieldError{{Field: "reason", Message: "is required"}}
+	}
+	return nil
+}
+
+// MatchingConfig is how drivers are searched for and scored in a city. The
+// version is logged with every match made with it.
+type MatchingConfig struct {
+	City                string     `json:"city"`
+	Version             int64      `json:"version"`
+	DistanceWeight      float64    `json:"distance_weight"`
+	ETAWeight           float64    `json:"eta_weight"`
+	RatingWeight        float64    `json:"rating_weight"`
+	AvailabilityWeight  float64    `json:"availability_weight"`
+	AcceptanceWeight    float64    `json:"acceptance_weight"`
+	CancellationWeight  float64    `json:"cancellation_weight"`
+	StreakWeight        float64    `json:"streak_weight"`
+	StreakTarget        int32      `json:"streak_target"`
+	ScoreDistanceCapKm  float64    `json:"score_distance_cap_km"`
+	ScoreETACapSeconds  int32      `json:"score_eta_cap_seconds"`
+	InitialRadiusKm     float64    `json:"initial_radius_km"`
+	RadiusStepKm        float64    `json:"radius_step_km"`
+	MaxRadiusKm         float64    `json:"max_radius_km"`
+	MaxPickupDistanceKm float64    `json:"max_pickup_distance_km"`
+	MinCandidates       int32      `json:"min_candidates"`
+	UpdatedBy           string     `json:"updated_by,omitempty"`
+	UpdatedAt           *time.Time `json:"updated_at,omitempty"`
+}
+
+// MatchingConfigsResponse lists the cities with a matching config of their own
+type MatchingConfigsResponse struct {
+	Configs []*MatchingConfig `json:"configs"`
+	Count   int               `json:"count"`
+}
+
+// ActionResponse reports the outcome of a manual action
+type ActionResponse struct {
+	Action   string `json:"action"`
+	TargetID string `json:"target_id"`
+	Status   string `json:"status"`
+	TripID   string `json:"trip_id,omitempty"`
+}
+
+// IncidentNote is an entry in an incident's timeline
+type IncidentNote struct {
+	AuthorID  string     `json:"author_id"`
+	Text      string     `json:"text"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// Incident is an emergency raised from a trip, with the trip's location
+// trail as it was when the SOS was raised
+type Incident struct {
+	ID             string          `json:"id"`
+	TripID         string          `json:"trip_id"`
+	RiderID        string          `json:"rider_id"`
+	DriverID       string          `json:"driver_id,omitempty"`
+	ReporterID     string          `json:"reporter_id"`
+	ReporterRole   string          `json:"reporter_role"`
+	Status         string          `json:"status"`
+	TripStatus     string          `json:"trip_status"`
+	Location       *api.Location   `json:"location,omitempty"`
+	LocationTrail  []api.Location  `json:"location_trail"`
+	AlertID        string          `json:"alert_id,omitempty"`
+	Notes          []*IncidentNote `json:"notes"`
+	AcknowledgedBy string          `json:"acknowledged_by,omitempty"`
+	AcknowledgedAt *time.Time      `json:"acknowledged_at,omitempty"`
+	ResolvedBy     string          `json:"resolved_by,omitempty"`
+	ResolvedAt     *time.Time      `json:"resolved_at,omitempty"`
+	Resolution     string          `json:"resolution,omitempty"`
+	CreatedAt      *time.Time      `json:"created_at,omitempty"`
+	UpdatedAt      *time.Time      `json:"updated_at,omitempty"`
+}
+
+// IncidentsResponse is the emergency incident queue, newest first
+type IncidentsResponse struct {
+	Incidents []*Incident `json:"incidents"`
+}
+
+// IncidentNoteRequest adds a note to an incident
+type IncidentNoteRequest struct {
+	Text string `json:"text"`
+}
+
+// Validate requires the note's text
+func (r *IncidentNoteRequest) Validate() []api.FieldError {
+	if strings.TrimSpace(r.Text) == "" {
+		return []api.FieldError{{Field: "text", Message: "is required"}}
+	}
+	return nil
+}
+
+// ResolveIncidentRequest closes an incident
+type ResolveIncidentRequest struct {
+	Resolution string `json:"resolution"`
+}
+
+// Validate requires how the incident was resolved
+func (r *ResolveIncidentRequest) Validate() []api.FieldError {
+	if strings.TrimSpace(r.Resolution) == "" {
+		return []api.FieldError{{Field: "resolution", Message: "is required"}}
+	}
+	return nil
+}
+
+// DisputeNote is an entry in a dispute's timeline
+type DisputeNote struct {
+	AuthorID  string     `json:"author_id"`
+	Text      string     `json:"text"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// DisputeEvidence is what a rider sent to support a dispute
+type DisputeEvidence struct {
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url,omitempty"`
+}
+
+// FareLine is one charged component of a disputed fare
+type FareLine struct {
+	Name   string  `json:"name"`
+	Amount float64 `json:"amount"`
+}
+
+// Dispute is a rider contesting a trip's charge, with the charge and fare
+// breakdown as they were when the dispute was opened
+type Dispute struct {
+	ID            string             `json:"id"`
+	TripID        string             `json:"trip_id"`
+	RiderID       string             `json:"rider_id"`
+	DriverID      string             `json:"driver_id,omitempty"`
+	Reason        string             `json:"reason"`
+	Description   string             `json:"description,omitempty"`
+	Evidence      []*DisputeEvidence `json:"evidence"`
+	Status        string             `json:"status"`
+	ChargedAmount float64            `json:"charged_amount"`
+	Currency      string             `json:"currency,omitempty"`
+	FareBreakdown []*FareLine        `json:"fare_breakdown"`
+	AssignedTo    string             `json:"assigned_to,omitempty"`
+	Notes         []*DisputeNote     `json:"notes"`
+	Resolution    string             `json:"resolution,omitempty"`
+	RefundAmount  float64            `json:"refund_amount,omitempty"`
+	RefundID      string             `json:"refund_id,omitempty"`
+	ResolvedBy    string             `json:"resolved_by,omitempty"`
+	ResolvedAt    *time.Time         `json:"resolved_at,omitempty"`
+	CreatedAt     *time.Time         `json:"created_at,omitempty"`
+	UpdatedAt     *time.Time         `json:"updated_at,omitempty"`
+}
+
+// DisputesResponse is the fare dispute queue, oldest first
+type DisputesResponse struct {
+	Disputes []*Dispute `json:"disputes"`
+}
+
+// DisputeReview is a dispute with the trip's route. RouteRecorded reports
+// whether the route is the one geo-service recorded rather than the one the
+// driver's app reported.
+type DisputeReview struct {
+	Dispute            *Dispute       `json:"dispute"`
+	Route              []api.Location `json:"route"`
+	RouteRecorded      bool           `json:"route_recorded"`
+	RouteDistanceKm    float64        `json:"route_distance_km,omitempty"`
+	ReportedDistanceKm float64        `json:"reported_distance_km,omitempty"`
+	DurationSeconds    int32          `json:"duration_seconds,omitempty"`
+}
+
+// DisputeNoteRequest adds a note to a dispute
+type DisputeNoteRequest struct {
+	Text string `json:"text"`
+}
+
+// Validate requires the note's text
+func (r *DisputeNoteRequest) Validate() []api.FieldError {
+	if strings.TrimSpace(r.Text) == "" {
+		return []api.FieldError{{Field: "text", Message: "is required"}}
+	}
+	return nil
+}
+
+// ResolveDisputeRequest closes a dispute, refunding RefundAmount of the
+// charge in the dispute's currency when it is positive
+type ResolveDisputeRequest struct {
+	Resolution   string  `json:"resolution"`
+	RefundAmount float64 `json:"refund_amount,omitempty"`
+}
+
+// Validate requires the resolution and a refund that is not negative
+func (r *ResolveDisputeRequest) Validate() []api.FieldError {
+	var errs []api.FieldError
+	if strings.TrimSpace(r.Resolution) == "" {
+		errs = append(errs, api.FieldError{Field: "resolution", Message: "is required"})
+	}
+	if r.RefundAmount < 0 {
+		errs = append(errs, api.FieldError{Field: "refund_amount", Message: "cannot be negative"})
+	}
+	return errs
+}
+
+// RejectDisputeRequest closes a dispute upholding the fare
+type RejectDisputeRequest struct {
+	Resolution string `json:"resolution"`
+}
+
+// Validate requires why the dispute was rejected
+func (r *RejectDisputeRequest) Validate() []api.FieldError {
+	if strings.TrimSpace(r.Resolution) == "" {
+		return []api.FieldError{{Field: "resolution", Message: "is required"}}
+	}
+	return nil
+}
+
+// ChargebackEvidence is evidence submitted against a chargeback
+type ChargebackEvidence struct {
+	Type        string     `json:"type"`
+	Description string     `json:"description"`
+	SubmittedBy string     `json:"submitted_by"`
+	SubmittedAt *time.Time `json:"submitted_at,omitempty"`
+}
+
+// PayoutRequest records a transfer to a driver's bank account. Reason is
+// recorded in the audit trail.
+type PayoutRequest struct {
+	Amount    float64 `json:"amount"`
+	Currency  string  `json:"currency,omitempty"`
+	Reference string  `json:"reference"`
+	Reason    string  `json:"reason"`
+}
+
+// Validate requires a positive amount, the transfer's reference and a reason
+func (r *PayoutRequest) Validate() []api.FieldError {
+	var errs []api.FieldError
+	if r.Amount <= 0 {
+		errs = append(errs, api.FieldError{Field: "amount", Message: "must be positive"})
+	}
+	if strings.TrimSpace(r.Reference) == "" {
+		errs = append(errs, api.FieldError{Field: "reference", Message: "is required"})
+	}
+	if strings.TrimSpace(r.Reason) == "" {
+		errs = append(errs, api.FieldError{Field: "reason", Message: "is required"})
+	}
+	return errs
+}
+
+// WalletCreditRequest gives a rider ride credits. Type is promotion_credit
+// or refund_credit; guarantee credits are only granted by trip-service.
+// Reason is recorded in the audit trail and on the wallet transaction.
+type WalletCreditRequest struct {
+	Amount    float64 `json:"amount"`
+	Currency  string  `json:"currency,omitempty"`
+	Type      string  `json:"type"`
+	Reference string  `json:"reference"`
+	Reason    string  `json:"reason"`
+}
+
+// Validate requires a positive amount, a known credit type, a reference and
+// a reason
+func (r *WalletCreditRequest) Validate() []api.FieldError {
+	var errs []api.FieldError
+	if r.Amount <= 0 {
+		errs = append(errs, api.FieldError{Field: "amount", Message: "must be positive"})
+	}
+	switch r.Type {
+	case "promotion_credit", "refund_credit":
+	default:
+		errs = append(errs, api.FieldError{Field: "type", Message: "must be one of promotion_credit, refund_credit"})
+	}
+	if strings.TrimSpace(r.Reference) == "" {
+		errs = append(errs, api.FieldError{Field: "reference", Message: "is required"})
+	}
+	if strings.TrimSpace(r.Reason) == "" {
+		errs = append(errs, api.FieldError{Field: "reason", Message: "is required"})
+	}
+	return errs
+}
+
+// WalletCredit is ride credit given to a rider's wallet
+type WalletCredit struct {
+	TransactionID string  `json:"transaction_id"`
+	UserID        string  `json:"user_id"`
+	Amount        float64 `json:"amount"`
+	Currency      string  `json:"currency,omitempty"`
+	Type          string  `json:"type"`
+	Reference     string  `json:"reference"`
+}
+
+// Payout is a transfer to a driver's bank account posted to the general
+// ledger
+type Payout struct {
+	JournalEntryID string     `json:"journal_entry_id"`
+	DriverID       string     `json:"driver_id"`
+	Amount         float64    `json:"amount"`
+	Currency       string     `json:"currency"`
+	Reference      string     `json:"reference"`
+	PostedAt       *time.Time `json:"posted_at,omitempty"`
+}
+
+// Chargeback is a payment the rider disputed with their bank, reported by
+// the payment provider. The driver's fare is frozen while it is open.
+type Chargeback struct {
+	ID                string                `json:"id"`
+	ProviderDisputeID string                `json:"provider_dispute_id"`
+	PaymentID         string                `json:"payment_id"`
+	TripID            string                `json:"trip_id,omitempty"`
+	RiderID           string                `json:"rider_id"`
+	DriverID          string                `json:"driver_id,omitempty"`
+	Amount            float64               `json:"amount"`
+	Currency          string                `json:"currency"`
+	Reason            string                `json:"reason,omitempty"`
+	Status            string                `json:"status"`
+	EvidenceDueBy     *time.Time            `json:"evidence_due_by,omitempty"`
+	Evidence          []*ChargebackEvidence `json:"evidence"`
+	Notes             string                `json:"notes,omitempty"`
+	ResolvedBy        string                `json:"resolved_by,omitempty"`
+	ResolvedAt        *time.Time            `json:"resolved_at,omitempty"`
+	CreatedAt         *time.Time            `json:"created_at,omitempty"`
+}
+
+// ChargebacksResponse is a page of chargebacks, oldest first
+type ChargebacksResponse struct {
+	Chargebacks []*Chargeback `json:"chargebacks"`
+	HasMore     bool          `json:"has_more"`
+	NextCursor  string        `json:"next_cursor,omitempty"`
+}
+
+// RefundCase is a refund the refund policy raised for one payment of a trip.
+// Refunds above the policy's auto-approval limit wait in pending_review.
+type RefundCase struct {
+	ID            string             `json:"id"`
+	TripID        string             `json:"trip_id"`
+	PaymentID     string             `json:"payment_id"`
+	UserID        string             `json:"user_id"`
+	Reason        string             `json:"reason"`
+	Amount        float64            `json:"amount"`
+	Currency      string             `json:"currency"`
+	Status        string             `json:"status"`
+	AutoApproved  bool               `json:"auto_approved"`
+	Source        string             `json:"source,omitempty"`
+	Details       string             `json:"details,omitempty"`
+	ReviewedBy    string             `json:"reviewed_by,omitempty"`
+	Notes         string             `json:"notes,omitempty"`
+	FailureReason string             `json:"failure_reason,omitempty"`
+	CreatedAt     *time.Time         `json:"created_at,omitempty"`
+	ResolvedAt    *time.Time         `json:"resolved_at,omitempty"`
+	Audit         []RefundCaseAction `json:"audit,omitempty"`
+}
+
+// RefundCaseAction is one step in a refund case's audit trail
+type RefundCaseAction struct {
+	Action    string     `json:"action"`
+	Actor     string     `json:"actor"`
+	Details   string     `json:"details,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// RefundCasesResponse lists refund cases, oldest first
+type RefundCasesResponse struct {
+	Cases []*RefundCase `json:"cases"`
+}
+
+// RefundDecisionRequest approves or rejects a refund case. Notes are kept
+// on the case and recorded in the audit trail.
+type RefundDecisionRequest struct {
+	Notes string `json:"notes"`
+}
+
+// Validate requires notes explaining the decision
+func (r *RefundDecisionRequest) Validate() []api.FieldError {
+	if strings.TrimSpace(r.Notes) == "" {
+		return []api.FieldError{{Field: "notes", Message: "is required"}}
+	}
+	return nil
+}
+
+// FraudRule is a fraud rule in the rule DSL, e.g.
+// `velocity_1h >= 5 && amount > 200`
+type FraudRule struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Expression  string     `json:"expression"`
+	Score       float64    `json:"score"`
+	Action      string     `json:"action"`
+	Priority    int32      `json:"priority"`
+	Enabled     bool       `json:"enabled"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+}
+
+// FraudRulesResponse lists the enabled fraud rules, highest priority first
+type FraudRulesResponse struct {
+	Rules []*FraudRule `json:"rules"`
+}
+
+// FraudRuleRequest creates or replaces a fraud rule. Action is flag, review
+// or block, flag when empty, and rules are enabled unless enabled is false.
+// Reason is recorded in the audit trail.
+type FraudRuleRequest struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Expression  string  `json:"expression"`
+	Score       float64 `json:"score"`
+	Action      string  `json:"action"`
+	Priority    int32   `json:"priority"`
+	Enabled     *bool   `json:"enabled"`
+	Reason      string  `json:"reason"`
+}
+
+// Validate requires a name, an expression and a reason for the audit trail
+func (r *FraudRuleRequest) Validate() []api.FieldError {
+	var errs []api.FieldError
+	if strings.TrimSpace(r.Name) == "" {
+		errs = append(errs, api.FieldError{Field: "name", Message: "is required"})
+	}
+	if strings.TrimSpace(r.Expression) == "" {
+		errs = append(errs, api.FieldError{Field: "expression", Message: "is required"})
+	}
+	switch r.Action {
+	case "", "flag", "review", "block":
+	default:
+		errs = append(errs, api.FieldError{Field: "action", Message: "must be one of flag, review, block"})
+	}
+	if strings.TrimSpace(r.Reason) == "" {
+		errs = append(errs, api.FieldError{Field: "reason", Message: "is required"})
+	}
+	return errs
+}
+
+// FraudReview is a payment the fraud engine flagged for manual review
+type FraudReview struct {
+	ID           string             `json:"id"`
+	PaymentID    string             `json:"payment_id"`
+	UserID       string             `json:"user_id"`
+	Amount       float64            `json:"amount"`
+	Currency     string             `json:"currency"`
+	RiskLevel    string             `json:"risk_level"`
+	RiskScore    float64            `json:"risk_score"`
+	Reasons      []string           `json:"reasons,omitempty"`
+	MatchedRules []string           `json:"matched_rules,omitempty"`
+	Scores       map[string]float64 `json:"scores,omitempty"`
+	Status       string             `json:"status"`
+	ReviewedBy   string             `json:"reviewed_by,omitempty"`
+	Notes        string             `json:"notes,omitempty"`
+	ReviewedAt   *time.Time         `json:"reviewed_at,omitempty"`
+	CreatedAt    *time.Time         `json:"created_at,omitempty"`
+}
+
+// FraudReviewsResponse lists fraud reviews, riskiest first
+type FraudReviewsResponse struct {
+	Reviews []*FraudReview `json:"reviews"`
+}
+
+// FraudDecisionRequest approves or rejects a flagged payment. Notes are
+// kept on the review and recorded in the audit trail.
+type FraudDecisionRequest struct {
+	Notes string `json:"notes"`
+}
+
+// Validate requires notes explaining the decision
+func (r *FraudDecisionRequest) Validate() []api.FieldError {
+	if strings.TrimSpace(r.Notes) == "" {
+		return []api.FieldError{{Field: "notes", Message: "is required"}}
+	}
+	return nil
+}
+
+// ChargebackEvidenceRequest submits evidence against a chargeback
+type ChargebackEvidenceRequest struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+}
+
+// Validate requires a known evidence type and a description
+func (r *ChargebackEvidenceRequest) Validate() []api.FieldError {
+	var errs []api.FieldError
+	switch r.Type {
+	case "receipt", "trip_route", "rider_communication", "other":
+	default:
+		errs = append(errs, api.FieldError{Field: "type", Message: "must be one of receipt, trip_route, rider_communication, other"})
+	}
+	if strings.TrimSpace(r.Description) == "" {
+		errs = append(errs, api.FieldError{Field: "description", Message: "is required"})
+	}
+	return errs
+}
+
+// ChargebackOutcomeRequest records how a chargeback was decided
+type ChargebackOutcomeRequest struct {
+	Outcome string `json:"outcome"`
+	Notes   string `json:"notes,omitempty"`
+}
+
+// Validate requires the outcome to be won, lost or accepted
+func (r *ChargebackOutcomeRequest) Validate() []api.FieldError {
+	switch r.Outcome {
+	case "won", "lost", "accepted":
+		return nil
+	}
+	return []api.FieldError{{Field: "outcome", Message: "must be one of won, lost, accepted"}}
+}
+
+// DeadLetter is an event one of trip-service's consumers failed to handle,
+// kept until an operator replays or discards it
+type DeadLetter struct {
+	ID            string          `json:"id"`
+	Consumer      string          `json:"consumer"`
+	Handler       string          `json:"handler"`
+	EventID       string          `json:"event_id"`
+	EventType     string          `json:"event_type"`
+	Event         json.RawMessage `json:"event,omitempty"`
+	Error         string          `json:"error"`
+	Poison        bool            `json:"poison"`
+	Attempts      int32           `json:"attempts"`
+	Replays       int32           `json:"replays"`
+	FirstFailedAt *time.Time      `json:"first_failed_at,omitempty"`
+	LastFailedAt  *time.Time      `json:"last_failed_at,omitempty"`
+}
+
+// DeadLettersResponse is a list of dead letters, most recently failed first
+type DeadLettersResponse struct {
+	DeadLetters []*DeadLetter `json:"dead_letters"`
+	Count       int           `json:"count"`
+}
+
+// ProjectionLag is how far a read model is behind the event log it is
+// projected from
+type ProjectionLag struct {
+	Checkpoint     int64      `json:"checkpoint"`
+	LatestPosition int64      `json:"latest_position"`
+	EventsBehind   int64      `json:"events_behind"`
+	LagSeconds     float64    `json:"lag_seconds"`
+	ProjectedAt    *time.Time `json:"projected_at,omitempty"`
+	RebuiltAt      *time.Time `json:"rebuilt_at,omitempty"`
+}
+
+// RebuildResponse reports a read model rebuilt from its event log
+type RebuildResponse struct {
+	Projection string `json:"projection"`
+	Projected  int32  `json:"projected"`
+}
+
+// ComplianceReport is one period of anonymized trip data generated for a
+// city's regulator from the jurisdiction's template
+type ComplianceReport struct {
+	ID                  string     `json:"id"`
+	TemplateID          string     `json:"template_id"`
+	TemplateName        string     `json:"template_name"`
+	CityID              string     `json:"city_id"`
+	Schedule            string     `json:"schedule"`
+	PeriodStart         *time.Time `json:"period_start,omitempty"`
+	PeriodEnd           *time.Time `json:"period_end,omitempty"`
+	Timezone            string     `json:"timezone"`
+	TripCount           int64      `json:"trip_count"`
+	AccessibleTripCount int64      `json:"accessible_trip_count"`
+	SuppressedZoneCount int64      `json:"suppressed_zone_count"`
+	ContentType         string     `json:"content_type"`
+	GeneratedBy         string     `json:"generated_by"`
+	GeneratedAt         *time.Time `json:"generated_at,omitempty"`
+	// WAV fulfillment of the wheelchair-accessible trips requested in the period
+	WAVRequestCount       int64   `json:"wav_request_count"`
+	WAVFulfilledCount     int64   `json:"wav_fulfilled_count"`
+	WAVFulfillmentRate    float64 `json:"wav_fulfillment_rate"`
+	WAVAverageWaitSeconds float64 `json:"wav_average_wait_seconds"`
+}
+
+// ComplianceReportsResponse is a list of compliance reports, newest first
+type ComplianceReportsResponse struct {
+	Reports []*ComplianceReport `json:"reports"`
+}
+
+// ComplianceReportRequest generates a template's report for the period
+// containing Date, in the city's timezone
+type ComplianceReportRequest struct {
+	TemplateID string `json:"template_id"`
+	Date       string `json:"date"` // YYYY-MM-DD
+}
+
+// Validate requires a template and a date
+func (r *ComplianceReportRequest) Validate() []api.FieldError {
+	var errs []api.FieldError
+	if strings.TrimSpace(r.TemplateID) == "" {
+		errs = append(errs, api.FieldError{Field: "template_id", Message: "is required"})
+	}
+	if _, err := time.Parse(time.DateOnly, r.Date); err != nil {
+		errs = append(errs, api.FieldError{Field: "date", Message: "must be a date like 2006-01-02"})
+	}
+	return errs
+}
+
+// LostItemNote is a status change or remark on a lost item report
+type LostItemNote struct {
+	AuthorID  string     `json:"author_id"`
+	Status    string     `json:"status,omitempty"`
+	Text      string     `json:"text,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// LostItemReport is an item a rider left behind on a trip. ContactOpen
+// reports whether the rider and driver can still use the trip chat.
+type LostItemReport struct {
+	ID               string          `json:"id"`
+	TripID           string          `json:"trip_id"`
+	RiderID          string          `json:"rider_id"`
+	DriverID         string          `json:"driver_id"`
+	Description      string          `json:"description"`
+	Status           string          `json:"status"`
+	DriverNotifiedAt *time.Time      `json:"driver_notified_at,omitempty"`
+	ContactUntil     *time.Time      `json:"contact_until,omitempty"`
+	ContactOpen      bool            `json:"contact_open"`
+	Notes            []*LostItemNote `json:"notes"`
+	CreatedAt        *time.Time      `json:"created_at,omitempty"`
+	UpdatedAt        *time.Time      `json:"updated_at,omitempty"`
+}
+
+// LostItemsResponse is a page of lost item reports, newest first
+type LostItemsResponse struct {
+	Reports []*LostItemReport `json:"reports"`
+}
+
+// PickupSLAGroup is how one driver or area kept the pickup guarantees they
+// were judged on
+type PickupSLAGroup struct {
+	Key                string  `json:"key"` // driver ID or area geohash
+	Judged             int64   `json:"judged"`
+	Missed             int64   `json:"missed"`
+	MissRate           float64 `json:"miss_rate"`
+	AverageLateSeconds float64 `json:"average_late_seconds"`
+}
+
+// PickupSLAReport summarises kept and missed pickup guarantees, with the
+// drivers and areas that missed the most first
+type PickupSLAReport struct {
+	From              *time.Time         `json:"from,omitempty"`
+	To                *time.Time         `json:"to,omitempty"`
+	CityID            string             `json:"city_id,omitempty"`
+	Judged            int64              `json:"judged"`
+	Missed            int64              `json:"missed"`
+	MissRate          float64            `json:"miss_rate"`
+	CreditsByCurrency map[string]float64 `json:"credits_by_currency"`
+	ByDriver          []*PickupSLAGroup  `json:"by_driver"`
+	ByArea            []*PickupSLAGroup  `json:"by_area"`
+}
+
+// LostItemUpdateRequest moves a lost item report to a new status, notes
+// something about it, or both
+type LostItemUpdateRequest struct {
+	Status string `json:"status,omitempty"`
+	Note   string `json:"note,omitempty"`
+}
+
+// Validate requires a known status or a note
+func (r *LostItemUpdateRequest) Validate() []api.FieldError {
+	switch r.Status {
+	case "":
+		if strings.TrimSpace(r.Note) == "" {
+			return []api.FieldError{{Field: "note", Message: "is required when the status is not changed"}}
+		}
+	case "found", "returned", "not_found":
+	default:
+		return []api.FieldError{{Field: "status", Message: "must be one of found, returned, not_found"}}
+	}
+	return nil
+}
+
+// TripMessage is a message between a trip's rider and driver
+type TripMessage struct {
+	ID           string     `json:"id"`
+	SenderID     string     `json:"sender_id"`
+	SenderRole   string     `json:"sender_role"`
+	RecipientID  string     `json:"recipient_id"`
+	Text         string     `json:"text"`
+	QuickReplyID string     `json:"quick_reply_id,omitempty"`
+	CreatedAt    *time.Time `json:"created_at,omitempty"`
+	DeliveredAt  *time.Time `json:"delivered_at,omitempty"`
+}
+
+// TripMessagesResponse is a trip's conversation, oldest first
+type TripMessagesResponse struct {
+	TripID   string         `json:"trip_id"`
+	Messages []*TripMessage `json:"messages"`
+}
+
+// MatchingStep is a step taken to match a trip
+type MatchingStep struct {
+	Event          string     `json:"event"`
+	Status         string     `json:"status"`
+	Message        string     `json:"message"`
+	DriverID       string     `json:"driver_id,omitempty"`
+	OfferID        string     `json:"offer_id,omitempty"`
+	Attempt        int32      `json:"attempt,omitempty"`
+	Retries        int32      `json:"retries,omitempty"`
+	SearchRadiusKm float64    `json:"search_radius_km,omitempty"`
+	At             *time.Time `json:"at,omitempty"`
+}
+
+// MatchingAttemptsResponse is every step taken to match a trip, oldest first
+type MatchingAttemptsResponse struct {
+	TripID     string          `json:"trip_id"`
+	RiderID    string          `json:"rider_id"`
+	Outcome    string          `json:"outcome,omitempty"`
+	Steps      []*MatchingStep `json:"steps"`
+	StartedAt  *time.Time      `json:"started_at,omitempty"`
+	FinishedAt *time.Time      `json:"finished_at,omitempty"`
+}
+
+func tripFromProto(trip *trippb.Trip) *Trip {
+	view := &Trip{
+		ID:            trip.Id,
+		RiderID:       trip.RiderId,
+		DriverID:      trip.DriverId,
+		Status:        strings.ToLower(trip.Status.String()),
+		CityID:        trip.CityId,
+		EstimatedFare: trip.EstimatedFare,
+		RequestedAt:   timeFromProto(trip.RequestedAt),
+		StartedAt:     timeFromProto(trip.StartedAt),
+	}
+	if trip.PickupLocation != nil {
+		view.Pickup = &api.Location{Latitude: trip.PickupLocation.Latitude, Longitude: trip.PickupLocation.Longitude}
+	}
+	if trip.Destination != nil {
+		view.Destination = &api.Location{Latitude: trip.Destination.Latitude, Longitude: trip.Destination.Longitude}
+	}
+	if trip.Metadata != nil {
+		view.SurgeMultiplier = trip.Metadata.SurgeMultiplier
+	}
+	return view
+}
+
+func incidentFromProto(incident *trippb.Incident) *Incident {
+	view := &Incident{
+		ID:             incident.Id,
+		TripID:         incident.TripId,
+		RiderID:        incident.RiderId,
+		DriverID:       incident.DriverId,
+		ReporterID:     incident.ReporterId,
+		ReporterRole:   incident.ReporterRole,
+		Status:         incident.Status,
+		TripStatus:     strings.ToLower(incident.TripStatus.String()),
+		LocationTrail:  make([]api.Location, 0, len(incident.LocationTrail)),
+		AlertID:        incident.AlertId,
+		Notes:          make([]*IncidentNote, 0, len(incident.Notes)),
+		AcknowledgedBy: incident.AcknowledgedBy,
+		AcknowledgedAt: timeFromProto(incident.AcknowledgedAt),
+		ResolvedBy:     incident.ResolvedBy,
+		ResolvedAt:     timeFromProto(incident.ResolvedAt),
+		Resolution:     incident.Resolution,
+		CreatedAt:      timeFromProto(incident.CreatedAt),
+		UpdatedAt:      timeFromProto(incident.UpdatedAt),
+	}
+	if incident.Location != nil {
+		view.Location = &api.Location{Latitude: incident.Location.Latitude, Longitude: incident.Location.Longitude}
+	}
+	for _, point := range incident.LocationTrail {
+		view.LocationTrail = append(view.LocationTrail, api.Location{Latitude: point.Latitude, Longitude: point.Longitude})
+	}
+	for _, note := range incident.Notes {
+		view.Notes = append(view.Notes, &IncidentNote{
+			AuthorID:  note.AuthorId,
+			Text:      note.Text,
+			CreatedAt: timeFromProto(note.CreatedAt),
+		})
+	}
+	return view
+}
+
+func disputeFromProto(dispute *trippb.Dispute) *Dispute {
+	view := &Dispute{
+		ID:            dispute.Id,
+		TripID:        dispute.TripId,
+		RiderID:       dispute.RiderId,
+		DriverID:      dispute.DriverId,
+		Reason:        dispute.Reason,
+		Description:   dispute.Description,
+		Evidence:      make([]*DisputeEvidence, 0, len(dispute.Evidence)),
+		Status:        dispute.Status,
+		ChargedAmount: dispute.ChargedAmount,
+		Currency:      dispute.Currency,
+		FareBreakdown: make([]*FareLine, 0, len(dispute.FareBreakdown)),
+		AssignedTo:    dispute.AssignedTo,
+		Notes:         make([]*DisputeNote, 0, len(dispute.Notes)),
+		Resolution:    dispute.Resolution,
+		RefundAmount:  dispute.RefundAmount,
+		RefundID:      dispute.RefundId,
+		ResolvedBy:    dispute.ResolvedBy,
+		ResolvedAt:    timeFromProto(dispute.ResolvedAt),
+		CreatedAt:     timeFromProto(dispute.CreatedAt),
+		UpdatedAt:     timeFromProto(dispute.UpdatedAt),
+	}
+	for _, evidence := range dispute.Evidence {
+		view.Evidence = append(view.Evidence, &DisputeEvidence{Description: evidence.Description, URL: evidence.Url})
+	}
+	for _, line := range dispute.FareBreakdown {
+		view.FareBreakdown = append(view.FareBreakdown, &FareLine{Name: line.Name, Amount: line.Amount})
+	}
+	for _, note := range dispute.Notes {
+		view.Notes = append(view.Notes, &DisputeNote{
+			AuthorID:  note.AuthorId,
+			Text:      note.Text,
+			CreatedAt: timeFromProto(note.CreatedAt),
+		})
+	}
+	return view
+}
+
+func disputeReviewFromProto(review *trippb.DisputeReview) *DisputeReview {
+	view := &DisputeReview{
+		Route:              make([]api.Location, 0, len(review.Route)),
+		RouteRecorded:      review.RouteRecorded,
+		RouteDistanceKm:    review.RouteDistanceKm,
+		ReportedDistanceKm: review.ReportedDistanceKm,
+		DurationSeconds:    review.DurationSeconds,
+	}
+	if review.Dispute != nil {
+		view.Dispute = disputeFromProto(review.Dispute)
+	}
+	for _, point := range review.Route {
+		view.Route = append(view.Route, api.Location{Latitude: point.Latitude, Longitude: point.Longitude})
+	}
+	return view
+}
+
+func lostItemFromProto(report *trippb.LostItemReport) *LostItemReport {
+	view := &LostItemReport{
+		ID:               report.Id,
+		TripID:           report.TripId,
+		RiderID:          report.RiderId,
+		DriverID:         report.DriverId,
+		Description:      report.Description,
+		Status:           report.Status,
+		DriverNotifiedAt: timeFromProto(report.DriverNotifiedAt),
+		ContactUntil:     timeFromProto(report.ContactUntil),
+		ContactOpen:      report.ContactOpen,
+		Notes:            make([]*LostItemNote, 0, len(report.Notes)),
+		CreatedAt:        timeFromProto(report.CreatedAt),
+		UpdatedAt:        timeFromProto(report.UpdatedAt),
+	}
+	for _, note := range report.Notes {
+		view.Notes = append(view.Notes, &LostItemNote{
+			AuthorID:  note.AuthorId,
+			Status:    note.Status,
+			Text:      note.Text,
+			CreatedAt: timeFromProto(note.CreatedAt),
+		})
+	}
+	return view
+}
+
+func pickupSLAReportFromProto(report *trippb.PickupSLAReport) *PickupSLAReport {
+	view := &PickupSLAReport{
+		From:              timeFromProto(report.From),
+		To:                timeFromProto(report.To),
+		CityID:            report.CityId,
+		Judged:            report.Judged,
+		Missed:            report.Missed,
+		MissRate:          report.MissRate,
+		CreditsByCurrency: report.CreditsByCurrency,
+		ByDriver:          make([]*PickupSLAGroup, 0, len(report.ByDriver)),
+		ByArea:            make([]*PickupSLAGroup, 0, len(report.ByArea)),
+	}
+	if view.CreditsByCurrency == nil {
+		view.CreditsByCurrency = map[string]float64{}
+	}
+	for _, group := range report.ByDriver {
+		view.ByDriver = append(view.ByDriver, pickupSLAGroupFromProto(group))
+	}
+	for _, group := range report.ByArea {
+		view.ByArea = append(view.ByArea, pickupSLAGroupFromProto(group))
+	}
+	return view
+}
+
+func pickupSLAGroupFromProto(group *trippb.PickupSLAGroup) *PickupSLAGroup {
+	return &PickupSLAGroup{
+		Key:                group.Key,
+		Judged:             group.Judged,
+		Missed:             group.Missed,
+		MissRate:           group.MissRate,
+		AverageLateSeconds: group.AverageLateSeconds,
+	}
+}
+
+func driverMarkerFromProto(driver *geopb.DriverLocation) *DriverMarker {
+	marker := &DriverMarker{
+		DriverID:    driver.DriverId,
+		VehicleID:   driver.VehicleId,
+		Status:      driver.Status,
+		VehicleType: driver.VehicleType,
+		Rating:      driver.Rating,
+		DistanceKm:  driver.DistanceFromCenter,
+		CityID:      driver.CityId,
+	}
+	if driver.Location != nil {
+		marker.Location = api.Location{Latitude: driver.Location.Latitude, Longitude: driver.Location.Longitude}
+	}
+	return marker
+}
+
+func surgeAreaFromProto(area *pricingpb.AreaSurge) *SurgeArea {
+	return &SurgeArea{
+		Area:             area.Area,
+		CityID:           area.CityId,
+		Multiplier:       area.Multiplier,
+		DemandLevel:      area.DemandLevel,
+		ActiveRequests:   area.ActiveRequests,
+		AvailableDrivers: area.AvailableDrivers,
+		UpdatedAt:        timeFromProto(area.UpdatedAt),
+		ExpiresAt:        timeFromProto(area.ExpiresAt),
+	}
+}
+
+func failedPaymentFromProto(payment *paymentpb.Payment) *FailedPayment {
+	return &FailedPayment{
+		ID:            payment.Id,
+		TripID:        payment.TripId,
+		UserID:        payment.UserId,
+		Amount:        payment.Amount,
+		Currency:      payment.Currency,
+		PaymentMethod: strings.ToLower(payment.PaymentMethod.String()),
+		FailureReason: payment.FailureReason,
+		CreatedAt:     timeFromProto(payment.CreatedAt),
+	}
+}
+
+func payoutFromProto(payout *paymentpb.RecordDriverPayoutResponse) *Payout {
+	return &Payout{
+		JournalEntryID: payout.JournalEntryId,
+		DriverID:       payout.DriverId,
+		Amount:         payout.Amount,
+		Currency:       payout.Currency,
+		Reference:      payout.Reference,
+		PostedAt:       timeFromProto(payout.PostedAt),
+	}
+}
+
+func refundCaseFromProto(refundCase *paymentpb.RefundCase) *RefundCase {
+	view := &RefundCase{
+		ID:            refundCase.Id,
+		TripID:        refundCase.TripId,
+		PaymentID:     refundCase.PaymentId,
+		UserID:        refundCase.UserId,
+		Reason:        refundCase.Reason,
+		Amount:        refundCase.Amount,
+		Currency:      refundCase.Currency,
+		Status:        refundCase.Status,
+		AutoApproved:  refundCase.AutoApproved,
+		Source:        refundCase.Source,
+		Details:       refundCase.Details,
+		ReviewedBy:    refundCase.ReviewedBy,
+		Notes:         refundCase.Notes,
+		FailureReason: refundCase.FailureReason,
+		CreatedAt:     timeFromProto(refundCase.CreatedAt),
+		ResolvedAt:    timeFromProto(refundCase.ResolvedAt),
+	}
+	for _, entry := range refundCase.Audit {
+		view.Audit = append(view.Audit, RefundCaseAction{
+			Action:    entry.Action,
+			Actor:     entry.Actor,
+			Details:   entry.Details,
+			CreatedAt: timeFromProto(entry.CreatedAt),
+		})
+	}
+	return view
+}
+
+func fraudRuleFromProto(rule *paymentpb.FraudRule) *FraudRule {
+	return &FraudRule{
+		ID:          rule.Id,
+		Name:        rule.Name,
+		Description: rule.Description,
+		Expression:  rule.Expression,
+		Score:       rule.Score,
+		Action:      rule.Action,
+		Priority:    rule.Priority,
+		Enabled:     rule.Enabled,
+		CreatedAt:   timeFromProto(rule.CreatedAt),
+		UpdatedAt:   timeFromProto(rule.UpdatedAt),
+	}
+}
+
+func fraudReviewFromProto(review *paymentpb.FraudReview) *FraudReview {
+	return &FraudReview{
+		ID:           review.Id,
+		PaymentID:    review.PaymentId,
+		UserID:       review.UserId,
+		Amount:       review.Amount,
+		Currency:     review.Currency,
+		RiskLevel:    review.RiskLevel,
+		RiskScore:    review.RiskScore,
+		Reasons:      review.Reasons,
+		MatchedRules: review.MatchedRules,
+		Scores:       review.Scores,
+		Status:       review.Status,
+		ReviewedBy:   review.ReviewedBy,
+		Notes:        review.Notes,
+		ReviewedAt:   timeFromProto(review.ReviewedAt),
+		CreatedAt:    timeFromProto(review.CreatedAt),
+	}
+}
+
+func chargebackFromProto(chargeback *paymentpb.Chargeback) *Chargeback {
+	view := &Chargeback{
+		ID:                chargeback.Id,
+		ProviderDisputeID: chargeback.ProviderDisputeId,
+		PaymentID:         chargeback.PaymentId,
+		TripID:            chargeback.TripId,
+		RiderID:           chargeback.UserId,
+		DriverID:          chargeback.DriverId,
+		Amount:            chargeback.Amount,
+		Currency:          chargeback.Currency,
+		Reason:            chargeback.Reason,
+		Status:            chargeback.Status,
+		EvidenceDueBy:     timeFromProto(chargeback.EvidenceDueBy),
+		Evidence:          make([]*ChargebackEvidence, 0, len(chargeback.Evidence)),
+		Notes:             chargeback.Notes,
+		ResolvedBy:        chargeback.ResolvedBy,
+		ResolvedAt:        timeFromProto(chargeback.ResolvedAt),
+		CreatedAt:         timeFromProto(chargeback.CreatedAt),
+	}
+	for _, evidence := range chargeback.Evidence {
+		view.Evidence = append(view.Evidence, &ChargebackEvidence{
+			Type:        evidence.Type,
+			Description: evidence.Description,
+			SubmittedBy: evidence.SubmittedBy,
+			SubmittedAt: timeFromProto(evidence.SubmittedAt),
+		})
+	}
+	return view
+}
+
+func deadLetterFromProto(letter *trippb.DeadLetter) *DeadLetter {
+	dead := &DeadLetter{
+		ID:            letter.Id,
+		Consumer:      letter.Consumer,
+		Handler:       letter.Handler,
+		EventID:       letter.EventId,
+		EventType:     letter.EventType,
+		Error:         letter.Error,
+		Poison:        letter.Poison,
+		Attempts:      letter.Attempts,
+		Replays:       letter.Replays,
+		FirstFailedAt: timeFromProto(letter.FirstFailedAt),
+		LastFailedAt:  timeFromProto(letter.LastFailedAt),
+	}
+	if json.Valid([]byte(letter.EventJson)) {
+		dead.Event = json.RawMessage(letter.EventJson)
+	}
+	return dead
+}
+
+func projectionLagFromProto(lag *trippb.TripListingsLag) *ProjectionLag {
+	return &ProjectionLag{
+		Checkpoint:     lag.Checkpoint,
+		LatestPosition: lag.LatestPosition,
+		EventsBehind:   lag.EventsBehind,
+		LagSeconds:     lag.LagSeconds,
+		ProjectedAt:    timeFromProto(lag.ProjectedAt),
+		RebuiltAt:      timeFromProto(lag.RebuiltAt),
+	}
+}
+
+func complianceReportFromProto(report *trippb.ComplianceReport) *ComplianceReport {
+	location := complianceLocation(report.Timezone)
+	inLocation := func(t *time.Time) *time.Time {
+		if t == nil {
+			return nil
+		}
+		local := t.In(location)
+		return &local
+	}
+	return &ComplianceReport{
+		ID:                    report.Id,
+		TemplateID:            report.TemplateId,
+		TemplateName:          report.TemplateName,
+		CityID:                report.CityId,
+		Schedule:              report.Schedule,
+		PeriodStart:           inLocation(timeFromProto(report.PeriodStart)),
+		PeriodEnd:             inLocation(timeFromProto(report.PeriodEnd)),
+		Timezone:              report.Timezone,
+		TripCount:             report.TripCount,
+		AccessibleTripCount:   report.AccessibleTripCount,
+		SuppressedZoneCount:   report.SuppressedZoneCount,
+		ContentType:           report.ContentType,
+		GeneratedBy:           report.GeneratedBy,
+		GeneratedAt:           timeFromProto(report.GeneratedAt),
+		WAVRequestCount:       report.WavRequestCount,
+		WAVFulfilledCount:     report.WavFulfilledCount,
+		WAVFulfillmentRate:    report.WavFulfillmentRate,
+		WAVAverageWaitSeconds: report.WavAverageWaitSeconds,
+	}
+}
+
+// complianceLocation returns a report's timezone, UTC if it is unknown
+func complianceLocation(timezone string) *time.Location {
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+func timeFromProto(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+func matchingAttemptsFromProto(resp *matchingpb.GetMatchingAttemptLogResponse) *MatchingAttemptsResponse {
+	view := &MatchingAttemptsResponse{
+		TripID:     resp.TripId,
+		RiderID:    resp.RiderId,
+		Outcome:    resp.Outcome,
+		Steps:      make([]*MatchingStep, 0, len(resp.Events)),
+		StartedAt:  timeFromProto(resp.StartedAt),
+		FinishedAt: timeFromProto(resp.FinishedAt),
+	}
+	for _, event := range resp.Events {
+		view.Steps = append(view.Steps, &MatchingStep{
+			Event:          event.Event,
+			Status:         event.Status,
+			Message:        event.Message,
+			DriverID:       event.DriverId,
+			OfferID:        event.OfferId,
+			Attempt:        event.Attempt,
+			Retries:        event.Retries,
+			SearchRadiusKm: event.SearchRadiusKm,
+			At:             timeFromProto(event.UpdatedAt),
+		})
+	}
+	return view
+}
+
+func matchingConfigFromProto(config *matchingpb.MatchingConfig) *MatchingConfig {
+	return &MatchingConfig{
+		City:                config.City,
+		Version:             config.Version,
+		DistanceWeight:      config.DistanceWeight,
+		ETAWeight:           config.EtaWeight,
+		RatingWeight:        config.RatingWeight,
+		AvailabilityWeight:  config.AvailabilityWeight,
+		AcceptanceWeight:    config.AcceptanceWeight,
+		CancellationWeight:  config.CancellationWeight,
+		StreakWeight:        config.StreakWeight,
+		StreakTarget:        config.StreakTarget,
+		ScoreDistanceCapKm:  config.ScoreDistanceCapKm,
+		ScoreETACapSeconds:  config.ScoreEtaCapSeconds,
+		InitialRadiusKm:     config.InitialRadiusKm,
+		RadiusStepKm:        config.RadiusStepKm,
+		MaxRadiusKm:         config.MaxRadiusKm,
+		MaxPickupDistanceKm: config.MaxPickupDistanceKm,
+		MinCandidates:       config.MinCandidates,
+		UpdatedBy:           config.UpdatedBy,
+		UpdatedAt:           timeFromProto(config.UpdatedAt),
+	}
+}
+
+func tripMessageFromProto(message *trippb.TripMessage) *TripMessage {
+	return &TripMessage{
+		ID:           message.Id,
+		SenderID:     message.SenderId,
+		SenderRole:   message.SenderRole,
+		RecipientID:  message.RecipientId,
+		Text:         message.Text,
+		QuickReplyID: message.QuickReplyId,
+		CreatedAt:    timeFromProto(message.CreatedAt),
+		DeliveredAt:  timeFromProto(message.DeliveredAt),
+	}
+}
@@ -0,0 +1,164 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/rideshare-platform/services/api-gateway/internal/api"
+	"github.com/rideshare-platform/shared/middleware"
+)
+
+// Permission is an operation an admin role may perform
+type Permission string
+
+const (
+	// PermissionView allows reading the operations dashboard
+	PermissionView Permission = "operations:view"
+	// PermissionCancelTrip allows force-cancelling trips
+	PermissionCancelTrip Permission = "trips:cancel"
+	// PermissionBanUser allows banning riders and drivers
+	PermissionBanUser Permission = "users:ban"
+	// PermissionReleaseDriver allows releasing a driver's reservation
+	PermissionReleaseDriver Permission = "drivers:release"
+	// PermissionManageIncidents allows acknowledging, annotating and
+	// resolving emergency incidents
+	PermissionManageIncidents Permission = "incidents:manage"
+	// PermissionManageDisputes allows reviewing fare disputes and resolving
+	// them, including refunding riders
+	PermissionManageDisputes Permission = "disputes:manage"
+	// PermissionManageLostItems allows tracking lost item reports and
+	// recording their outcome
+	PermissionManageLostItems Permission = "lost_items:manage"
+	// PermissionReviewMessages allows reading riders' and drivers' in-trip messages
+	PermissionReviewMessages Permission = "trip_messages:review"
+	// PermissionSearch allows looking up users, vehicles and trips by name,
+	// contact details, plate or time
+	PermissionSearch Permission = "search:read"
+	// PermissionManageFlags allows switching feature flags and changing
+	// their rollouts
+	PermissionManageFlags Permission = "flags:manage"
+	// PermissionManageChargebacks allows submitting evidence against payment
+	// provider chargebacks and recording their outcome
+	PermissionManageChargebacks Permission = "chargebacks:manage"
+	// PermissionComplianceReports allows generating and downloading the
+	// trip data reports filed with cities' regulators
+	PermissionComplianceReports Permission = "compliance_reports:manage"
+	// PermissionManageMatching allows tuning the weights and search radii
+	// drivers are matched with in each city
+	PermissionManageMatching Permission = "matching:manage"
+	// PermissionManageDeadLetters allows inspecting, replaying and
+	// discarding the events services' consumers failed to handle
+	PermissionManageDeadLetters Permission = "dead_letters:manage"
+	// PermissionManageProjections allows rebuilding the read models lists
+	// are served from
+	PermissionManageProjections Permission = "projections:manage"
+	// PermissionManagePayouts allows recording transfers to drivers' bank
+	// accounts in the general ledger
+	PermissionManagePayouts Permission = "payouts:manage"
+	// PermissionGrantCredits allows giving riders ride credits in their
+	// wallets
+	PermissionGrantCredits Permission = "wallet_credits:grant"
+	// PermissionReviewRefunds allows approving and rejecting the refunds
+	// the refund policy queued for review
+	PermissionReviewRefunds Permission = "refunds:review"
+	// PermissionManageFraud allows changing the fraud rules payments are
+	// scored with and deciding the payments they flag for review
+	PermissionManageFraud Permission = "fraud:manage"
+)
+
+// UserTypeAdmin is the user type carried by operator tokens
+const UserTypeAdmin = "admin"
+
+// rolePermissions lists what each admin role is allowed to do. Support
+// staff work customer cases without acting on trips or users, ops unstick
+// trips, drivers, failed events and read models, and admins hold every permission.
+var rolePermissions = map[string][]Permission{
+	"admin": {
+		PermissionView, PermissionCancelTrip, PermissionBanUser, PermissionReleaseDriver,
+		PermissionManageIncidents, PermissionManageDisputes, PermissionManageLostItems,
+		PermissionReviewMessages, PermissionSearch, PermissionManageFlags,
+		PermissionManageChargebacks, PermissionComplianceReports, PermissionManageMatching,
+		PermissionManageDeadLetters, PermissionManageProjections, PermissionManagePayouts,
+		PermissionGrantCredits, PermissionReviewRefunds, PermissionManageFraud,
+	},
+	"ops": {
+		PermissionView, PermissionCancelTrip, PermissionReleaseDriver, PermissionManageIncidents,
+		PermissionSearch, PermissionManageDeadLetters, PermissionManageProjections,
+	},
+	"support": {PermissionView, PermissionManageIncidents, PermissionManageDisputes, PermissionManageLostItems, PermissionReviewMessages, PermissionSearch},
+}
+
+// HasPermission reports whether any of roles grants permission
+func HasPermission(roles []string, permission Permission) bool {
+	for _, role := range roles {
+		for _, granted := range rolePermissions[role] {
+			if granted == permission {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// TokenParser validates a bearer token and returns its claims
+type TokenParser interface {
+	ParseToken(tokenString string) (*middleware.AuthClaims, error)
+}
+
+// claimsKey stores the operator's claims in the request context
+type claimsKey struct{}
+
+// tokenKey stores the operator's bearer token in the request context
+type tokenKey struct{}
+
+// ClaimsFromContext returns the claims of the operator making the request
+func ClaimsFromContext(ctx context.Context) (*middleware.AuthClaims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*middleware.AuthClaims)
+	return claims, ok
+}
+
+// Authenticate rejects requests without a valid admin token and stores the
+// operator's claims in the request context
+func Authenticate(parser TokenParser) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scheme, token, found := strings.Cut(r.Header.Get("Authorization"), " ")
+			if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+				api.WriteError(w, api.NewError(http.StatusUnauthorized, api.CodeUnauthorized, "bearer token required"))
+				return
+			}
+
+			claims, err := parser.ParseToken(token)
+			if err != nil {
+				message := "invalid token"
+				if errors.Is(err, middleware.ErrTokenExpired) {
+					message = "token expired"
+				}
+				api.WriteError(w, api.NewError(http.StatusUnauthorized, api.CodeUnauthorized, message))
+				return
+			}
+			if claims.UserType != UserTypeAdmin {
+				api.WriteError(w, api.NewError(http.StatusForbidden, api.CodeForbidden, "admin access required"))
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), claimsKey{}, claims)
+			ctx = context.WithValue(ctx, tokenKey{}, token)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// Require only lets operators whose roles grant permission reach handler
+func Require(permission Permission, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := ClaimsFromContext(r.Context())
+		if !ok || !HasPermission(claims.Roles, permission) {
+			api.WriteError(w, api.NewError(http.StatusForbidden, api.CodeForbidden, "missing permission "+string(permission)))
+			return
+		}
+		handler(w, r)
+	}
+}
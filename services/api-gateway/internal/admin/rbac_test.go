@@ -0,0 +1,636 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/rideshare-platform/services/api-gateway/internal/api"
+	"github.com/rideshare-platform/services/api-gateway/internal/grpc"
+	"github.com/rideshare-platform/shared/flags"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/middleware"
+	paymentpb "github.com/rideshare-platform/shared/proto/payment"
+	trippb "github.com/rideshare-platform/shared/proto/trip"
+	"github.com/rideshare-platform/shared/search"
+)
+
+const testSecret = "admin-test-secret"
+
+// fakeTripClient records the force-cancel request it receives
+type fakeTripClient struct {
+	trippb.TripServiceClient
+	cancelled     *trippb.ForceCancelTripRequest
+	authorization []string
+}
+
+func (f *fakeTripClient) ForceCancelTrip(ctx context.Context, req *trippb.ForceCancelTripRequest, opts ...googlegrpc.CallOption) (*trippb.ForceCancelTripResponse, error) {
+	f.cancelled = req
+	md, _ := metadata.FromOutgoingContext(ctx)
+	f.authorization = md.Get("authorization")
+	return &trippb.ForceCancelTripResponse{Trip: &trippb.Trip{Id: req.TripId, Status: trippb.TripStatus_CANCELLED_BY_RIDER}}, nil
+}
+
+// fakeDeadLetterClient serves one dead letter, whose replay fails again
+type fakeDeadLetterClient struct {
+	trippb.TripServiceClient
+	listed    *trippb.ListDeadLettersRequest
+	discarded string
+}
+
+func (f *fakeDeadLetterClient) ListDeadLetters(ctx context.Context, req *trippb.ListDeadLettersRequest, opts ...googlegrpc.CallOption) (*trippb.ListDeadLettersResponse, error) {
+	f.listed = req
+	return &trippb.ListDeadLettersResponse{DeadLetters: []*trippb.DeadLetter{{
+		Id: "notifications:trip.completed#1:evt-1", Consumer: "notifications", EventId: "evt-1", EventType: "trip.completed",
+		EventJson: `{"id":"evt-1","type":"trip.completed"}`, Error: "provider down", Attempts: 5,
+	}}}, nil
+}
+
+func (f *fakeDeadLetterClient) ReplayDeadLetter(ctx context.Context, req *trippb.ReplayDeadLetterRequest, opts ...googlegrpc.CallOption) (*trippb.ReplayDeadLetterResponse, error) {
+	return nil, status.Error(codes.Aborted, "replay failed: provider down")
+}
+
+func (f *fakeDeadLetterClient) DiscardDeadLetter(ctx context.Context, req *trippb.DiscardDeadLetterRequest, opts ...googlegrpc.CallOption) (*trippb.DiscardDeadLetterResponse, error) {
+	f.discarded = req.DeadLetterId
+	return &trippb.DiscardDeadLetterResponse{}, nil
+}
+
+// fakePayoutClient records the payout it receives and refuses a second
+// payout of the same transfer
+type fakePayoutClient struct {
+	paymentpb.PaymentServiceClient
+	recorded *paymentpb.RecordDriverPayoutRequest
+}
+
+func (f *fakePayoutClient) RecordDriverPayout(ctx context.Context, req *paymentpb.RecordDriverPayoutRequest, opts ...googlegrpc.CallOption) (*paymentpb.RecordDriverPayoutResponse, error) {
+	if f.recorded != nil && f.recorded.Reference == req.Reference {
+		return nil, status.Error(codes.AlreadyExists, "journal entry already posted")
+	}
+	f.recorded = req
+	return &paymentpb.RecordDriverPayoutResponse{
+		JournalEntryId: "je-1", DriverId: req.DriverId, Amount: req.Amount, Currency: "USD", Reference: req.Reference,
+	}, nil
+}
+
+// fakeCreditClient records the credit it receives and declines a second
+// credit with the same reference
+type fakeCreditClient struct {
+	paymentpb.PaymentServiceClient
+	granted *paymentpb.GrantWalletCreditRequest
+}
+
+func (f *fakeCreditClient) GrantWalletCredit(ctx context.Context, req *paymentpb.GrantWalletCreditRequest, opts ...googlegrpc.CallOption) (*paymentpb.GrantWalletCreditResponse, error) {
+	if f.granted != nil && f.granted.Reference == req.Reference {
+		return &paymentpb.GrantWalletCreditResponse{Success: false, Message: "credit already granted"}, nil
+	}
+	f.granted = req
+	return &paymentpb.GrantWalletCreditResponse{Success: true, TransactionId: "wtx-1"}, nil
+}
+
+// fakeRefundCaseClient records the decision it receives and refuses to
+// decide a case twice
+type fakeRefundCaseClient struct {
+	paymentpb.PaymentServiceClient
+	resolved *paymentpb.ResolveRefundCaseRequest
+}
+
+func (f *fakeRefundCaseClient) ResolveRefundCase(ctx context.Context, req *paymentpb.ResolveRefundCaseRequest, opts ...googlegrpc.CallOption) (*paymentpb.RefundCaseResponse, error) {
+	if f.resolved != nil {
+		return nil, status.Error(codes.FailedPrecondition, "refund case is already resolved")
+	}
+	f.resolved = req
+	refundCase := &paymentpb.RefundCase{Id: req.CaseId, Status: "rejected", Notes: req.Notes}
+	if req.Approve {
+		refundCase.Status = "refunded"
+	}
+	return &paymentpb.RefundCaseResponse{Case: refundCase}, nil
+}
+
+// fakeFraudClient records the fraud review decision it receives and refuses
+// to decide a review twice
+type fakeFraudClient struct {
+	paymentpb.PaymentServiceClient
+	resolved *paymentpb.ResolveFraudReviewRequest
+}
+
+func (f *fakeFraudClient) ResolveFraudReview(ctx context.Context, req *paymentpb.ResolveFraudReviewRequest, opts ...googlegrpc.CallOption) (*paymentpb.FraudReviewResponse, error) {
+	if f.resolved != nil {
+		return nil, status.Error(codes.FailedPrecondition, "fraud review is already resolved")
+	}
+	f.resolved = req
+	review := &paymentpb.FraudReview{Id: req.ReviewId, Status: "rejected", Notes: req.Notes}
+	if req.Approve {
+		review.Status = "approved"
+	}
+	return &paymentpb.FraudReviewResponse{Review: review}, nil
+}
+
+// fakeSearchIndex records the query it receives and returns fixed results
+type fakeSearchIndex struct {
+	query   search.Query
+	results []search.Result
+}
+
+func (f *fakeSearchIndex) Search(ctx context.Context, query search.Query) ([]search.Result, error) {
+	f.query = query
+	return f.results, nil
+}
+
+func newTestRouter(clients *grpc.ClientManager) *mux.Router {
+	return newTestRouterWithSearch(clients, nil)
+}
+
+func newTestRouterWithSearch(clients *grpc.ClientManager, index search.Index) *mux.Router {
+	log := logger.NewLogger("error", "test")
+	router := mux.NewRouter()
+	handler := NewHandler(clients, middleware.NewAuthMiddleware(testSecret, log), log)
+	if index != nil {
+		handler.SetSearchIndex(index)
+	}
+	handler.RegisterRoutes(router.PathPrefix("/admin/v1").Subrouter())
+	return router
+}
+
+func testToken(t *testing.T, userType string, roles ...string) string {
+	t.Helper()
+	token, err := middleware.NewAuthMiddleware(testSecret, nil).GenerateTokenWithRoles("operator-1", userType, "ops@example.com", roles, 1)
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+	return token
+}
+
+func TestHasPermission(t *testing.T) {
+	tests := []struct {
+		roles      []string
+		permission Permission
+		want       bool
+	}{
+		{[]string{"support"}, PermissionView, true},
+		{[]string{"support"}, PermissionCancelTrip, false},
+		{[]string{"ops"}, PermissionCancelTrip, true},
+		{[]string{"ops"}, PermissionReleaseDriver, true},
+		{[]string{"ops"}, PermissionBanUser, false},
+		{[]string{"support", "admin"}, PermissionBanUser, true},
+		{[]string{"support"}, PermissionManageChargebacks, false},
+		{[]string{"admin"}, PermissionManageChargebacks, true},
+		{[]string{"ops"}, PermissionComplianceReports, false},
+		{[]string{"admin"}, PermissionComplianceReports, true},
+		{[]string{"ops"}, PermissionManageMatching, false},
+		{[]string{"admin"}, PermissionManageMatching, true},
+		{[]string{"ops"}, PermissionManageDeadLetters, true},
+		{[]string{"support"}, PermissionManageDeadLetters, false},
+		{[]string{"admin"}, PermissionManageDeadLetters, true},
+		{[]string{"ops"}, PermissionManageProjections, true},
+		{[]string{"support"}, PermissionManageProjections, false},
+		{[]string{"ops"}, PermissionManagePayouts, false},
+		{[]string{"admin"}, PermissionManagePayouts, true},
+		{[]string{"support"}, PermissionGrantCredits, false},
+		{[]string{"admin"}, PermissionGrantCredits, true},
+		{[]string{"support"}, PermissionReviewRefunds, false},
+		{[]string{"admin"}, PermissionReviewRefunds, true},
+		{[]string{"support"}, PermissionManageFraud, false},
+		{[]string{"ops"}, PermissionManageFraud, false},
+		{[]string{"admin"}, PermissionManageFraud, true},
+		{[]string{"unknown"}, PermissionView, false},
+		{nil, PermissionView, false},
+	}
+	for _, tt := range tests {
+		if got := HasPermission(tt.roles, tt.permission); got != tt.want {
+			t.Errorf("HasPermission(%v, %s) = %v, want %v", tt.roles, tt.permission, got, tt.want)
+		}
+	}
+}
+
+func TestAdminRoutesRequireRoles(t *testing.T) {
+	router := newTestRouter(grpc.NewClientManager())
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		token      string
+		wantStatus int
+	}{
+		{"no_token", "GET", "/admin/v1/trips", "", http.StatusUnauthorized},
+		{"invalid_token", "GET", "/admin/v1/trips", "not-a-jwt", http.StatusUnauthorized},
+		{"rider_token", "GET", "/admin/v1/trips", testToken(t, "rider"), http.StatusForbidden},
+		{"admin_without_roles", "GET", "/admin/v1/trips", testToken(t, UserTypeAdmin), http.StatusForbidden},
+		// Authorized requests reach the handler, which reports the missing backend
+		{"support_can_view", "GET", "/admin/v1/trips", testToken(t, UserTypeAdmin, "support"), http.StatusServiceUnavailable},
+		{"support_cannot_ban", "POST", "/admin/v1/users/u1/ban", testToken(t, UserTypeAdmin, "support"), http.StatusForbidden},
+		{"ops_cannot_ban", "POST", "/admin/v1/users/u1/ban", testToken(t, UserTypeAdmin, "ops"), http.StatusForbidden},
+		{"admin_can_ban", "POST", "/admin/v1/users/u1/ban", testToken(t, UserTypeAdmin, "admin"), http.StatusServiceUnavailable},
+		{"ops_can_release", "POST", "/admin/v1/drivers/d1/release-reservation", testToken(t, UserTypeAdmin, "ops"), http.StatusServiceUnavailable},
+		{"support_can_acknowledge_incident", "POST", "/admin/v1/incidents/i1/acknowledge", testToken(t, UserTypeAdmin, "support"), http.StatusServiceUnavailable},
+		{"ops_cannot_review_messages", "GET", "/admin/v1/trips/t1/messages", testToken(t, UserTypeAdmin, "ops"), http.StatusForbidden},
+		{"support_can_review_messages", "GET", "/admin/v1/trips/t1/messages", testToken(t, UserTypeAdmin, "support"), http.StatusServiceUnavailable},
+		{"support_can_search", "GET", "/admin/v1/search?q=alice", testToken(t, UserTypeAdmin, "support"), http.StatusServiceUnavailable},
+		{"ops_cannot_download_compliance_reports", "GET", "/admin/v1/compliance/reports/r1/download", testToken(t, UserTypeAdmin, "ops"), http.StatusForbidden},
+		{"admin_can_list_compliance_reports", "GET", "/admin/v1/compliance/reports", testToken(t, UserTypeAdmin, "admin"), http.StatusServiceUnavailable},
+		{"support_can_view_matching_config", "GET", "/admin/v1/matching-configs/ist", testToken(t, UserTypeAdmin, "support"), http.StatusServiceUnavailable},
+		{"ops_cannot_tune_matching", "PUT", "/admin/v1/matching-configs/ist", testToken(t, UserTypeAdmin, "ops"), http.StatusForbidden},
+		{"admin_can_tune_matching", "PUT", "/admin/v1/matching-configs/ist", testToken(t, UserTypeAdmin, "admin"), http.StatusServiceUnavailable},
+		{"no_token_cannot_list_dead_letters", "GET", "/admin/v1/dead-letters", "", http.StatusUnauthorized},
+		{"support_cannot_replay_dead_letters", "POST", "/admin/v1/dead-letters/d1/replay", testToken(t, UserTypeAdmin, "support"), http.StatusForbidden},
+		{"ops_can_replay_dead_letters", "POST", "/admin/v1/dead-letters/d1/replay", testToken(t, UserTypeAdmin, "ops"), http.StatusServiceUnavailable},
+		{"ops_can_discard_dead_letters", "DELETE", "/admin/v1/dead-letters/d1", testToken(t, UserTypeAdmin, "ops"), http.StatusServiceUnavailable},
+		{"no_token_cannot_rebuild_trip_listings", "POST", "/admin/v1/projections/trip-listings/rebuild", "", http.StatusUnauthorized},
+		{"support_can_view_trip_listings_lag", "GET", "/admin/v1/projections/trip-listings", testToken(t, UserTypeAdmin, "support"), http.StatusServiceUnavailable},
+		{"support_cannot_rebuild_trip_listings", "POST", "/admin/v1/projections/trip-listings/rebuild", testToken(t, UserTypeAdmin, "support"), http.StatusForbidden},
+		{"ops_can_rebuild_trip_listings", "POST", "/admin/v1/projections/trip-listings/rebuild", testToken(t, UserTypeAdmin, "ops"), http.StatusServiceUnavailable},
+		{"no_token_cannot_pay_out", "POST", "/admin/v1/drivers/d1/payouts", "", http.StatusUnauthorized},
+		{"ops_cannot_pay_out", "POST", "/admin/v1/drivers/d1/payouts", testToken(t, UserTypeAdmin, "ops"), http.StatusForbidden},
+		{"no_token_cannot_grant_credit", "POST", "/admin/v1/users/u1/wallet/credits", "", http.StatusUnauthorized},
+		{"rider_cannot_grant_credit", "POST", "/admin/v1/users/u1/wallet/credits", testToken(t, "rider", "admin"), http.StatusForbidden},
+		{"support_cannot_grant_credit", "POST", "/admin/v1/users/u1/wallet/credits", testToken(t, UserTypeAdmin, "support"), http.StatusForbidden},
+		{"no_token_cannot_list_refund_cases", "GET", "/admin/v1/refund-cases", "", http.StatusUnauthorized},
+		{"ops_cannot_approve_refunds", "POST", "/admin/v1/refund-cases/c1/approve", testToken(t, UserTypeAdmin, "ops"), http.StatusForbidden},
+		{"admin_can_list_refund_cases", "GET", "/admin/v1/refund-cases", testToken(t, UserTypeAdmin, "admin"), http.StatusServiceUnavailable},
+		{"no_token_cannot_create_fraud_rule", "POST", "/admin/v1/fraud-rules", "", http.StatusUnauthorized},
+		{"support_cannot_disable_fraud_rule", "POST", "/admin/v1/fraud-rules/r1/disable", testToken(t, UserTypeAdmin, "support"), http.StatusForbidden},
+		{"rider_cannot_approve_fraud_review", "POST", "/admin/v1/fraud-reviews/r1/approve", testToken(t, "rider", "admin"), http.StatusForbidden},
+		{"admin_can_list_fraud_reviews", "GET", "/admin/v1/fraud-reviews", testToken(t, UserTypeAdmin, "admin"), http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(`{"reason": "testing"}`))
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+
+			if recorder.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.wantStatus, recorder.Code, recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestForceCancelTrip(t *testing.T) {
+	trips := &fakeTripClient{}
+	clients := grpc.NewClientManager()
+	clients.TripClient = trips
+	router := newTestRouter(clients)
+	token := testToken(t, UserTypeAdmin, "ops")
+
+	// A reason is required for the audit trail
+	req := httptest.NewRequest("POST", "/admin/v1/trips/trip-1/cancel", strings.NewReader(`{}`))
+	req.Header.Set("Authorization", "Bearer "+token)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	if recorder.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status 400 without a reason, got %d", recorder.Code)
+	}
+
+	req = httptest.NewRequest("POST", "/admin/v1/trips/trip-1/cancel", strings.NewReader(`{"reason": "stuck trip"}`))
+	req.Header.Set("Authorization", "Bearer "+token)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+
+	if trips.cancelled == nil {
+		t.Fatal("Expected the trip service to be called")
+	}
+	if trips.cancelled.TripId != "trip-1" || trips.cancelled.Reason != "stuck trip" || trips.cancelled.ActorId != "operator-1" {
+		t.Errorf("Unexpected cancel request: %+v", trips.cancelled)
+	}
+	if len(trips.authorization) != 1 || trips.authorization[0] != "Bearer "+token {
+		t.Errorf("Expected the operator's token to be forwarded, got %v", trips.authorization)
+	}
+
+	var body ActionResponse
+	if err := json.NewDecoder(recorder.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if body.TargetID != "trip-1" || body.Action != "cancel_trip" {
+		t.Errorf("Unexpected response: %+v", body)
+	}
+}
+
+func TestDeadLetters(t *testing.T) {
+	trips := &fakeDeadLetterClient{}
+	clients := grpc.NewClientManager()
+	clients.TripClient = trips
+	router := newTestRouter(clients)
+	token := testToken(t, UserTypeAdmin, "ops")
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	recorder := serve("GET", "/admin/v1/dead-letters?poison=maybe", "")
+	if recorder.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status 400 for an invalid poison filter, got %d", recorder.Code)
+	}
+
+	recorder = serve("GET", "/admin/v1/dead-letters?consumer=notifications&poison=false", "")
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if trips.listed.Consumer != "notifications" || trips.listed.Poison != "false" || trips.listed.Limit != 100 {
+		t.Errorf("Unexpected list request: %+v", trips.listed)
+	}
+	var listed DeadLettersResponse
+	if err := json.NewDecoder(recorder.Body).Decode(&listed); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if listed.Count != 1 || string(listed.DeadLetters[0].Event) != `{"id":"evt-1","type":"trip.completed"}` {
+		t.Errorf("Unexpected dead letters: %+v", listed)
+	}
+
+	// Events that fail again stay dead-lettered
+	recorder = serve("POST", "/admin/v1/dead-letters/d1/replay", `{"reason": "provider is back"}`)
+	if recorder.Code != http.StatusConflict {
+		t.Errorf("Expected status 409 for a replay that failed again, got %d", recorder.Code)
+	}
+
+	// A reason is required to discard, as with other manual actions
+	recorder = serve("DELETE", "/admin/v1/dead-letters/d1", `{}`)
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 without a reason, got %d", recorder.Code)
+	}
+	recorder = serve("DELETE", "/admin/v1/dead-letters/d1", `{"reason": "trip was refunded by hand"}`)
+	if recorder.Code != http.StatusOK || trips.discarded != "d1" {
+		t.Errorf("Expected d1 to be discarded, got %d and %q", recorder.Code, trips.discarded)
+	}
+}
+
+func TestRecordDriverPayout(t *testing.T) {
+	payments := &fakePayoutClient{}
+	clients := grpc.NewClientManager()
+	clients.PaymentClient = payments
+	router := newTestRouter(clients)
+	token := testToken(t, UserTypeAdmin, "admin")
+
+	serve := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/admin/v1/drivers/driver-1/payouts", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	recorder := serve(`{"amount": 0, "reference": "tr-1", "reason": "weekly payout"}`)
+	if recorder.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status 400 for a zero amount, got %d", recorder.Code)
+	}
+
+	recorder = serve(`{"amount": 120.5, "currency": "usd", "reference": "tr-1", "reason": "weekly payout"}`)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if payments.recorded.DriverId != "driver-1" || payments.recorded.Amount != 120.5 || payments.recorded.Reference != "tr-1" {
+		t.Errorf("Unexpected payout request: %+v", payments.recorded)
+	}
+
+	// The same transfer is only paid out once
+	recorder = serve(`{"amount": 120.5, "reference": "tr-1", "reason": "weekly payout"}`)
+	if recorder.Code != http.StatusConflict {
+		t.Errorf("Expected status 409 for a repeated transfer, got %d", recorder.Code)
+	}
+}
+
+func TestGrantWalletCredit(t *testing.T) {
+	payments := &fakeCreditClient{}
+	clients := grpc.NewClientManager()
+	clients.PaymentClient = payments
+	router := newTestRouter(clients)
+	token := testToken(t, UserTypeAdmin, "admin")
+
+	serve := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/admin/v1/users/rider-1/wallet/credits", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	// Guarantee credits are only granted by trip-service
+	recorder := serve(`{"amount": 5, "type": "guarantee_credit", "reference": "case-1", "reason": "late pickup"}`)
+	if recorder.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status 400 for a guarantee credit, got %d", recorder.Code)
+	}
+
+	recorder = serve(`{"amount": 5, "type": "refund_credit", "reference": "case-1", "reason": "driver took a detour"}`)
+	if recorder.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if payments.granted.UserId != "rider-1" || payments.granted.Amount != 5 || payments.granted.Description != "driver took a detour" {
+		t.Errorf("Unexpected credit request: %+v", payments.granted)
+	}
+
+	recorder = serve(`{"amount": 5, "type": "refund_credit", "reference": "case-1", "reason": "driver took a detour"}`)
+	if recorder.Code != http.StatusConflict {
+		t.Errorf("Expected status 409 for a declined credit, got %d", recorder.Code)
+	}
+}
+
+func TestResolveRefundCase(t *testing.T) {
+	payments := &fakeRefundCaseClient{}
+	clients := grpc.NewClientManager()
+	clients.PaymentClient = payments
+	router := newTestRouter(clients)
+	token := testToken(t, UserTypeAdmin, "admin")
+
+	serve := func(path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	// A reviewer named in the body is not accepted in place of the token's
+	recorder := serve("/admin/v1/refund-cases/case-1/approve", `{"reviewed_by": "someone-else"}`)
+	if recorder.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status 400 without notes, got %d", recorder.Code)
+	}
+
+	recorder = serve("/admin/v1/refund-cases/case-1/approve", `{"notes": "driver confirmed the no-show"}`)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if payments.resolved.CaseId != "case-1" || !payments.resolved.Approve {
+		t.Errorf("Unexpected decision: %+v", payments.resolved)
+	}
+
+	recorder = serve("/admin/v1/refund-cases/case-1/reject", `{"notes": "changed my mind"}`)
+	if recorder.Code != http.StatusConflict {
+		t.Errorf("Expected status 409 for a decided case, got %d", recorder.Code)
+	}
+}
+
+func TestResolveFraudReview(t *testing.T) {
+	payments := &fakeFraudClient{}
+	clients := grpc.NewClientManager()
+	clients.PaymentClient = payments
+	router := newTestRouter(clients)
+	token := testToken(t, UserTypeAdmin, "admin")
+
+	serve := func(path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	// A reviewer named in the body is not accepted in place of the token's
+	recorder := serve("/admin/v1/fraud-reviews/review-1/reject", `{"reviewed_by": "someone-else"}`)
+	if recorder.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status 400 without notes, got %d", recorder.Code)
+	}
+
+	recorder = serve("/admin/v1/fraud-reviews/review-1/reject", `{"notes": "card reported stolen"}`)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if payments.resolved.ReviewId != "review-1" || payments.resolved.Approve {
+		t.Errorf("Unexpected decision: %+v", payments.resolved)
+	}
+
+	recorder = serve("/admin/v1/fraud-reviews/review-1/approve", `{"notes": "changed my mind"}`)
+	if recorder.Code != http.StatusConflict {
+		t.Errorf("Expected status 409 for a decided review, got %d", recorder.Code)
+	}
+}
+
+func TestListActiveTripsRejectsUnknownStatus(t *testing.T) {
+	clients := grpc.NewClientManager()
+	clients.TripClient = &fakeTripClient{}
+	router := newTestRouter(clients)
+
+	req := httptest.NewRequest("GET", "/admin/v1/trips?status=flying", nil)
+	req.Header.Set("Authorization", "Bearer "+testToken(t, UserTypeAdmin, "support"))
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+
+	if recorder.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status 400, got %d", recorder.Code)
+	}
+	var body api.Error
+	if err := json.NewDecoder(recorder.Body).Decode(&body); err != nil {
+		t.Fatalf("Failed to decode error: %v", err)
+	}
+	if len(body.Details) != 1 || body.Details[0].Field != "status" {
+		t.Errorf("Expected a status field error, got %+v", body.Details)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	index := &fakeSearchIndex{results: []search.Result{
+		{Kind: search.KindTrip, ID: "trip-1", Score: 0.9, Trip: &search.TripHit{RiderID: "rider-1", LicensePlate: "34 ABC 123"}},
+		{Kind: search.KindVehicle, ID: "vehicle-1", Score: 0.8, Vehicle: &search.VehicleHit{LicensePlate: "34 ABC 123"}},
+	}}
+	router := newTestRouterWithSearch(grpc.NewClientManager(), index)
+	token := testToken(t, UserTypeAdmin, "support")
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantField  string
+	}{
+		{"no_text_or_time", "/admin/v1/search", http.StatusBadRequest, "q"},
+		{"unknown_type", "/admin/v1/search?q=alice&type=payment", http.StatusBadRequest, "type"},
+		{"bad_time", "/admin/v1/search?around=yesterday", http.StatusBadRequest, "around"},
+		{"window_too_wide", "/admin/v1/search?around=2026-01-02T15:04:05Z&window=48h", http.StatusBadRequest, "window"},
+		{"time_without_trips", "/admin/v1/search?around=2026-01-02T15:04:05Z&type=user", http.StatusBadRequest, "q"},
+		{"plate_and_time", "/admin/v1/search?q=34abc&type=trip,vehicle&around=2026-01-02T15:04:05Z&window=1h", http.StatusOK, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, recorder.Code, recorder.Body.String())
+			}
+			if tt.wantField == "" {
+				return
+			}
+			var body api.Error
+			if err := json.NewDecoder(recorder.Body).Decode(&body); err != nil {
+				t.Fatalf("Failed to decode error: %v", err)
+			}
+			if len(body.Details) != 1 || body.Details[0].Field != tt.wantField {
+				t.Errorf("Expected a %s field error, got %+v", tt.wantField, body.Details)
+			}
+		})
+	}
+
+	if index.query.Text != "34abc" || index.query.Window != time.Hour || len(index.query.Kinds) != 2 {
+		t.Errorf("Unexpected search query: %+v", index.query)
+	}
+	if !index.query.Around.Equal(time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)) {
+		t.Errorf("Expected the around time to be passed on, got %s", index.query.Around)
+	}
+}
+
+func TestFeatureFlags(t *testing.T) {
+	log := logger.NewLogger("error", "test")
+	store := flags.NewMemoryStore()
+	handler := NewHandler(grpc.NewClientManager(), middleware.NewAuthMiddleware(testSecret, log), log)
+	handler.SetFlagStore(store)
+	router := mux.NewRouter()
+	handler.RegisterRoutes(router.PathPrefix("/admin/v1").Subrouter())
+
+	tests := []struct {
+		name       string
+		method     string
+		role       string
+		body       string
+		wantStatus int
+	}{
+		{"support_cannot_switch", "PUT", "support", `{"enabled":true,"rollout":10,"reason":"canary"}`, http.StatusForbidden},
+		{"rollout_out_of_range", "PUT", "admin", `{"enabled":true,"rollout":150,"reason":"canary"}`, http.StatusBadRequest},
+		{"reason_required", "PUT", "admin", `{"enabled":true,"rollout":10}`, http.StatusBadRequest},
+		{"saved", "PUT", "admin", `{"enabled":true,"rollout":10,"cities":{" IST ":{"enabled":true,"rollout":100}},"reason":"canary"}`, http.StatusOK},
+		{"support_can_view", "GET", "support", "", http.StatusOK},
+		{"deleted", "DELETE", "admin", `{"reason":"rolled out"}`, http.StatusOK},
+		{"gone", "GET", "support", "", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/admin/v1/flags/batch_matching", strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer "+testToken(t, UserTypeAdmin, tt.role))
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, recorder.Code, recorder.Body.String())
+			}
+			if tt.name != "saved" {
+				return
+			}
+			flag, err := store.Get(context.Background(), "batch_matching")
+			if err != nil {
+				t.Fatalf("Expected the flag to be saved: %v", err)
+			}
+			if flag.UpdatedBy != "operator-1" || flag.Cities["ist"].Rollout != 100 {
+				t.Errorf("Unexpected saved flag: %+v", flag)
+			}
+		})
+	}
+}
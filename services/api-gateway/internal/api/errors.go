@@ -0,0 +1,127 @@
+package api
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/rideshare-platform/shared/i18n"
+)
+
+// ErrorCode identifies the kind of error returned to API clients
+type ErrorCode string
+
+const (
+	// CodeInvalidRequest means the request body could not be read
+	CodeInvalidRequest ErrorCode = "INVALID_REQUEST"
+	// CodeValidationFailed means one or more fields failed validation
+	CodeValidationFailed ErrorCode = "VALIDATION_FAILED"
+	// CodeNotFound means the requested resource or route does not exist
+	CodeNotFound ErrorCode = "NOT_FOUND"
+	// CodeMethodNotAllowed means the route exists but not for this method
+	CodeMethodNotAllowed ErrorCode = "METHOD_NOT_ALLOWED"
+	// CodeConflict means the request conflicts with the resource's state
+	CodeConflict ErrorCode = "CONFLICT"
+	// CodeUnauthorized means the caller is not authenticated
+	CodeUnauthorized ErrorCode = "UNAUTHORIZED"
+	// CodeForbidden means the caller may not perform the request
+	CodeForbidden ErrorCode = "FORBIDDEN"
+	// CodeTooManyRequests means the caller exceeded a limit and should retry
+	// later
+	CodeTooManyRequests ErrorCode = "TOO_MANY_REQUESTS"
+	// CodeServiceUnavailable means a backend service cannot be reached
+	CodeServiceUnavailable ErrorCode = "SERVICE_UNAVAILABLE"
+	// CodeUpstreamError means a backend service failed the request
+	CodeUpstreamError ErrorCode = "UPSTREAM_ERROR"
+	// CodeInternal means the gateway itself failed
+	CodeInternal ErrorCode = "INTERNAL_ERROR"
+)
+
+// Error is the envelope every gateway endpoint returns on failure. Message
+// is meant for developers and always in English; Title explains the code to
+// users in the language the request was answered in.
+type Error struct {
+	Status  int          `json:"-"`
+	Code    ErrorCode    `json:"code"`
+	Message string       `json:"message"`
+	Title   string       `json:"title,omitempty"`
+	Details []FieldError `json:"details,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// NewError creates an error with the given HTTP status, code and message
+func NewError(status int, code ErrorCode, message string) *Error {
+	return &Error{Status: status, Code: code, Message: message}
+}
+
+// ServiceUnavailable is returned when a backend service has no connection
+func ServiceUnavailable(service string) *Error {
+	return NewError(http.StatusServiceUnavailable, CodeServiceUnavailable, service+" service unavailable")
+}
+
+// FromGRPC maps an error returned by a backend service to the envelope
+func FromGRPC(service string, err error) *Error {
+	st, _ := status.FromError(err)
+	switch st.Code() {
+	case codes.InvalidArgument, codes.OutOfRange:
+		return NewError(http.StatusBadRequest, CodeValidationFailed, st.Message())
+	case codes.NotFound:
+		return NewError(http.StatusNotFound, CodeNotFound, st.Message())
+	case codes.AlreadyExists, codes.FailedPrecondition, codes.Aborted:
+		return NewError(http.StatusConflict, CodeConflict, st.Message())
+	case codes.Unauthenticated:
+		return NewError(http.StatusUnauthorized, CodeUnauthorized, st.Message())
+	case codes.PermissionDenied:
+		return NewError(http.StatusForbidden, CodeForbidden, st.Message())
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return NewError(http.StatusServiceUnavailable, CodeServiceUnavailable, service+" service unavailable")
+	default:
+		return NewError(http.StatusBadGateway, CodeUpstreamError, service+" service failed to handle the request")
+	}
+}
+
+// WriteJSON writes a JSON response with the given status
+func WriteJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// WriteError writes err as an error envelope. Errors that are not *Error are
+// reported as internal errors without exposing their message. Responses
+// whose language was negotiated by Localize get a title in that language.
+func WriteError(w http.ResponseWriter, err error) {
+	apiErr, ok := err.(*Error)
+	if !ok {
+		log.Printf("Unhandled gateway error: %v", err)
+		apiErr = NewError(http.StatusInternalServerError, CodeInternal, "internal server error")
+	}
+	if locale := w.Header().Get(contentLanguageHeader); locale != "" && apiErr.Title == "" {
+		localized := *apiErr
+		localized.Title = i18n.T(locale, "error."+string(apiErr.Code))
+		apiErr = &localized
+	}
+	WriteJSON(w, apiErr.Status, apiErr)
+}
+
+// NotFoundHandler reports unknown routes with the error envelope
+func NotFoundHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		WriteError(w, NewError(http.StatusNotFound, CodeNotFound, "route not found"))
+	})
+}
+
+// MethodNotAllowedHandler reports unsupported methods with the error envelope
+func MethodNotAllowedHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		WriteError(w, NewError(http.StatusMethodNotAllowed, CodeMethodNotAllowed, "method not allowed"))
+	})
+}
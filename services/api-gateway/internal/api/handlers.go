@@ -0,0 +1,441 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/rideshare-platform/services/api-gateway/internal/grpc"
+	trippb "github.com/rideshare-platform/shared/proto/trip"
+)
+
+// Handler serves the gateway's REST API
+type Handler struct {
+	clients *grpc.ClientManager
+}
+
+// NewHandler creates a REST API handler backed by the gRPC client manager
+func NewHandler(clients *grpc.ClientManager) *Handler {
+	return &Handler{clients: clients}
+}
+
+// RegisterRoutes registers the REST API routes on the /api/v1 subrouter
+func (h *Handler) RegisterRoutes(api *mux.Router) {
+	api.HandleFunc("/users/{id}", h.GetUser).Methods("GET")
+	api.HandleFunc("/trips/{id}", h.GetTrip).Methods("GET")
+	api.HandleFunc("/trips/{id}/share", h.ShareTrip).Methods("POST")
+	api.HandleFunc("/trips/{id}/sos", h.ReportSOS).Methods("POST")
+	api.HandleFunc("/trips/{id}/disputes", h.OpenDispute).Methods("POST")
+	api.HandleFunc("/trips/{id}/lost-items", h.ReportLostItem).Methods("POST")
+	api.HandleFunc("/trips/{id}/contact", h.GetTripContact).Methods("GET")
+	api.HandleFunc("/trips/{id}/rider-location", h.ShareRiderLocation).Methods("POST")
+	api.HandleFunc("/trips/{id}/messages", h.SendTripMessage).Methods("POST")
+	api.HandleFunc("/trips/{id}/messages", h.ListTripMessages).Methods("GET")
+	api.HandleFunc("/quick-replies", h.ListQuickReplies).Methods("GET")
+	api.HandleFunc("/pricing/estimate", h.EstimatePrice).Methods("POST")
+	api.HandleFunc("/matching/nearby-drivers", h.FindNearbyDrivers).Methods("POST")
+	api.HandleFunc("/payments", h.CreatePayment).Methods("POST")
+}
+
+// RegisterPublicRoutes registers the unauthenticated routes on the /public
+// subrouter. Trip share tokens are the only credential they take.
+func (h *Handler) RegisterPublicRoutes(public *mux.Router) {
+	public.HandleFunc("/trips/{token}", h.GetSharedTrip).Methods("GET")
+}
+
+// GetUser handles GET /api/v1/users/{id}
+func (h *Handler) GetUser(w http.ResponseWriter, r *http.Request) {
+	if h.clients.UserClient == nil {
+		WriteError(w, ServiceUnavailable("user"))
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, &UserResponse{
+		ID:     mux.Vars(r)["id"],
+		Status: "mock response - gRPC integration needed",
+	})
+}
+
+// GetTrip handles GET /api/v1/trips/{id}
+func (h *Handler) GetTrip(w http.ResponseWriter, r *http.Request) {
+	if h.clients.TripClient == nil {
+		WriteError(w, ServiceUnavailable("trip"))
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, &TripResponse{
+		ID:     mux.Vars(r)["id"],
+		Status: "mock response - gRPC integration needed",
+	})
+}
+
+// EstimatePrice handles POST /api/v1/pricing/estimate
+func (h *Handler) EstimatePrice(w http.ResponseWriter, r *http.Request) {
+	var req PriceEstimateRequest
+	if err := Bind(r, &req); err != nil {
+		WriteError(w, err)
+		return
+	}
+	if h.clients.PricingClient == nil {
+		WriteError(w, ServiceUnavailable("pricing"))
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, &PriceEstimateResponse{
+		EstimatedFare: 15.50,
+		Currency:      "USD",
+		VehicleType:   req.VehicleType,
+		Status:        "mock response",
+	})
+}
+
+// FindNearbyDrivers handles POST /api/v1/matching/nearby-drivers
+func (h *Handler) FindNearbyDrivers(w http.ResponseWriter, r *http.Request) {
+	var req NearbyDriversRequest
+	if err := Bind(r, &req); err != nil {
+		WriteError(w, err)
+		return
+	}
+	if h.clients.MatchingClient == nil {
+		WriteError(w, ServiceUnavailable("matching"))
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, &NearbyDriversResponse{
+		Drivers: []NearbyDriver{},
+		Status:  "mock response - gRPC integration needed",
+	})
+}
+
+// CreatePayment handles POST /api/v1/payments
+func (h *Handler) CreatePayment(w http.ResponseWriter, r *http.Request) {
+	var req CreatePaymentRequest
+	if err := Bind(r, &req); err != nil {
+		WriteError(w, err)
+		return
+	}
+	if h.clients.PaymentClient == nil {
+		WriteError(w, ServiceUnavailable("payment"))
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, &PaymentResponse{
+		PaymentID: "pay_123",
+		Status:    "mock response",
+	})
+}
+
+// ShareTrip handles POST /api/v1/trips/{id}/share
+func (h *Handler) ShareTrip(w http.ResponseWriter, r *http.Request) {
+	var req ShareTripRequest
+	if err := Bind(r, &req); err != nil {
+		WriteError(w, err)
+		return
+	}
+	if h.clients.TripClient == nil {
+		WriteError(w, ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := h.clients.WithTimeout(r.Context(), "trip")
+	defer cancel()
+	resp, err := h.clients.TripClient.CreateTripShareLink(ctx, &trippb.CreateTripShareLinkRequest{
+		TripId:     mux.Vars(r)["id"],
+		RiderId:    req.RiderID,
+		TtlMinutes: int32(req.TTLMinutes),
+	})
+	if err != nil {
+		WriteError(w, FromGRPC("trip", err))
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, &ShareTripResponse{
+		Token:     resp.Token,
+		URL:       "/public/trips/" + resp.Token,
+		SocketURL: "/ws/public/trips/" + resp.Token,
+		ExpiresAt: resp.ExpiresAt.AsTime(),
+	})
+}
+
+// ReportSOS handles POST /api/v1/trips/{id}/sos
+func (h *Handler) ReportSOS(w http.ResponseWriter, r *http.Request) {
+	var req SOSRequest
+	if err := Bind(r, &req); err != nil {
+		WriteError(w, err)
+		return
+	}
+	if h.clients.TripClient == nil {
+		WriteError(w, ServiceUnavailable("trip"))
+		return
+	}
+
+	sos := &trippb.ReportSOSRequest{
+		TripId:     mux.Vars(r)["id"],
+		ReporterId: req.ReporterID,
+		Message:    req.Message,
+	}
+	if req.Location != nil {
+		sos.Location = &trippb.Location{Latitude: req.Location.Latitude, Longitude: req.Location.Longitude}
+	}
+
+	ctx, cancel := h.clients.WithTimeout(r.Context(), "trip")
+	defer cancel()
+	incident, err := h.clients.TripClient.ReportSOS(ctx, sos)
+	if err != nil {
+		WriteError(w, FromGRPC("trip", err))
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, &SOSResponse{
+		IncidentID: incident.Id,
+		Status:     incident.Status,
+		CreatedAt:  incident.CreatedAt.AsTime(),
+	})
+}
+
+// OpenDispute handles POST /api/v1/trips/{id}/disputes, a rider contesting
+// what they were charged for a completed or cancelled trip
+func (h *Handler) OpenDispute(w http.ResponseWriter, r *http.Request) {
+	var req DisputeRequest
+	if err := Bind(r, &req); err != nil {
+		WriteError(w, err)
+		return
+	}
+	if h.clients.TripClient == nil {
+		WriteError(w, ServiceUnavailable("trip"))
+		return
+	}
+
+	open := &trippb.OpenDisputeRequest{
+		TripId:      mux.Vars(r)["id"],
+		RiderId:     req.RiderID,
+		Reason:      req.Reason,
+		Description: req.Description,
+	}
+	for _, evidence := range req.Evidence {
+		open.Evidence = append(open.Evidence, &trippb.DisputeEvidence{Description: evidence.Description, Url: evidence.URL})
+	}
+
+	ctx, cancel := h.clients.WithTimeout(r.Context(), "trip")
+	defer cancel()
+	dispute, err := h.clients.TripClient.OpenDispute(ctx, open)
+	if err != nil {
+		WriteError(w, FromGRPC("trip", err))
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, &DisputeResponse{
+		DisputeID:     dispute.Id,
+		TripID:        dispute.TripId,
+		Status:        dispute.Status,
+		ChargedAmount: dispute.ChargedAmount,
+		Currency:      dispute.Currency,
+		CreatedAt:     dispute.CreatedAt.AsTime(),
+	})
+}
+
+// ReportLostItem handles POST /api/v1/trips/{id}/lost-items, a rider
+// reporting something left behind on a completed trip. The driver is
+// notified and the trip chat reopens until contact_until.
+func (h *Handler) ReportLostItem(w http.ResponseWriter, r *http.Request) {
+	var req LostItemRequest
+	if err := Bind(r, &req); err != nil {
+		WriteError(w, err)
+		return
+	}
+	if h.clients.TripClient == nil {
+		WriteError(w, ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := h.clients.WithTimeout(r.Context(), "trip")
+	defer cancel()
+	report, err := h.clients.TripClient.ReportLostItem(ctx, &trippb.ReportLostItemRequest{
+		TripId:      mux.Vars(r)["id"],
+		RiderId:     req.RiderID,
+		Description: req.Description,
+	})
+	if err != nil {
+		WriteError(w, FromGRPC("trip", err))
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, &LostItemResponse{
+		ReportID:       report.Id,
+		TripID:         report.TripId,
+		Status:         report.Status,
+		DriverNotified: report.DriverNotifiedAt != nil,
+		ContactUntil:   report.ContactUntil.AsTime(),
+		CreatedAt:      report.CreatedAt.AsTime(),
+	})
+}
+
+// GetTripContact handles GET /api/v1/trips/{id}/contact?user_id=, the
+// masked number or in-app call token a rider or driver calls the other
+// party with while the driver is on the trip
+func (h *Handler) GetTripContact(w http.ResponseWriter, r *http.Request) {
+	query := TripContactQuery{UserID: r.URL.Query().Get("user_id")}
+	if err := Validate(&query); err != nil {
+		WriteError(w, err)
+		return
+	}
+	if h.clients.TripClient == nil {
+		WriteError(w, ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := h.clients.WithTimeout(r.Context(), "trip")
+	defer cancel()
+	contact, err := h.clients.TripClient.GetTripContact(ctx, &trippb.GetTripContactRequest{
+		TripId: mux.Vars(r)["id"],
+		UserId: query.UserID,
+	})
+	if err != nil {
+		WriteError(w, FromGRPC("trip", err))
+		return
+	}
+
+	WriteJSON(w, http.StatusOK, &TripContactResponse{
+		TripID:       contact.TripId,
+		Mode:         contact.Mode,
+		MaskedNumber: contact.MaskedNumber,
+		VoIPToken:    contact.VoipToken,
+		ExpiresAt:    contact.ExpiresAt.AsTime(),
+	})
+}
+
+// ShareRiderLocation handles POST /api/v1/trips/{id}/rider-location, sent
+// by a waiting rider's app so their driver can find them at the pickup
+func (h *Handler) ShareRiderLocation(w http.ResponseWriter, r *http.Request) {
+	var req RiderLocationRequest
+	if err := Bind(r, &req); err != nil {
+		WriteError(w, err)
+		return
+	}
+	if h.clients.TripClient == nil {
+		WriteError(w, ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := h.clients.WithTimeout(r.Context(), "trip")
+	defer cancel()
+	_, err := h.clients.TripClient.UpdateRiderLocation(ctx, &trippb.UpdateRiderLocationRequest{
+		TripId:   mux.Vars(r)["id"],
+		RiderId:  req.RiderID,
+		Location: &trippb.Location{Latitude: req.Location.Latitude, Longitude: req.Location.Longitude},
+	})
+	if err != nil {
+		WriteError(w, FromGRPC("trip", err))
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SendTripMessage handles POST /api/v1/trips/{id}/messages, for clients
+// not connected to the trip's chat socket
+func (h *Handler) SendTripMessage(w http.ResponseWriter, r *http.Request) {
+	var req TripMessageRequest
+	if err := Bind(r, &req); err != nil {
+		WriteError(w, err)
+		return
+	}
+	if h.clients.TripClient == nil {
+		WriteError(w, ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := h.clients.WithTimeout(r.Context(), "trip")
+	defer cancel()
+	message, err := h.clients.TripClient.SendTripMessage(ctx, &trippb.SendTripMessageRequest{
+		TripId:       mux.Vars(r)["id"],
+		SenderId:     req.SenderID,
+		Text:         req.Text,
+		QuickReplyId: req.QuickReplyID,
+	})
+	if err != nil {
+		WriteError(w, FromGRPC("trip", err))
+		return
+	}
+
+	WriteJSON(w, http.StatusCreated, NewTripMessageResponse(message))
+}
+
+// ListTripMessages handles GET /api/v1/trips/{id}/messages?user_id=, the
+// conversation as one of the trip's participants sees it
+func (h *Handler) ListTripMessages(w http.ResponseWriter, r *http.Request) {
+	query := TripMessagesQuery{UserID: r.URL.Query().Get("user_id")}
+	if err := Validate(&query); err != nil {
+		WriteError(w, err)
+		return
+	}
+	if h.clients.TripClient == nil {
+		WriteError(w, ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := h.clients.WithTimeout(r.Context(), "trip")
+	defer cancel()
+	resp, err := h.clients.TripClient.ListTripMessages(ctx, &trippb.ListTripMessagesRequest{
+		TripId: mux.Vars(r)["id"],
+		UserId: query.UserID,
+	})
+	if err != nil {
+		WriteError(w, FromGRPC("trip", err))
+		return
+	}
+
+	body := &TripMessagesResponse{Messages: make([]*TripMessageResponse, 0, len(resp.Messages))}
+	for _, message := range resp.Messages {
+		body.Messages = append(body.Messages, NewTripMessageResponse(message))
+	}
+	WriteJSON(w, http.StatusOK, body)
+}
+
+// ListQuickReplies handles GET /api/v1/quick-replies?role=
+func (h *Handler) ListQuickReplies(w http.ResponseWriter, r *http.Request) {
+	query := QuickRepliesQuery{Role: r.URL.Query().Get("role")}
+	if err := Validate(&query); err != nil {
+		WriteError(w, err)
+		return
+	}
+	if h.clients.TripClient == nil {
+		WriteError(w, ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := h.clients.WithTimeout(r.Context(), "trip")
+	defer cancel()
+	resp, err := h.clients.TripClient.ListQuickReplies(ctx, &trippb.ListQuickRepliesRequest{Role: query.Role})
+	if err != nil {
+		WriteError(w, FromGRPC("trip", err))
+		return
+	}
+
+	body := &QuickRepliesResponse{QuickReplies: make([]QuickReply, 0, len(resp.QuickReplies))}
+	for _, reply := range resp.QuickReplies {
+		body.QuickReplies = append(body.QuickReplies, QuickReply{ID: reply.Id, Text: reply.Text})
+	}
+	WriteJSON(w, http.StatusOK, body)
+}
+
+// GetSharedTrip handles GET /public/trips/{token}
+func (h *Handler) GetSharedTrip(w http.ResponseWriter, r *http.Request) {
+	if h.clients.TripClient == nil {
+		WriteError(w, ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := h.clients.WithTimeout(r.Context(), "trip")
+	defer cancel()
+	trip, err := h.clients.TripClient.GetTripByShareToken(ctx, &trippb.GetTripByShareTokenRequest{
+		Token: mux.Vars(r)["token"],
+	})
+	if err != nil {
+		WriteError(w, FromGRPC("trip", err))
+		return
+	}
+
+	// The driver moves, so shared trips are never cached
+	w.Header().Set("Cache-Control", "no-store")
+	WriteJSON(w, http.StatusOK, NewSharedTripResponse(trip))
+}
@@ -0,0 +1,212 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/rideshare-platform/services/api-gateway/internal/grpc"
+	"github.com/rideshare-platform/shared/i18n"
+	pricingpb "github.com/rideshare-platform/shared/proto/pricing"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+func newTestRouter(clients *grpc.ClientManager) *mux.Router {
+	router := mux.NewRouter()
+	router.NotFoundHandler = NotFoundHandler()
+	handler := NewHandler(clients)
+	handler.RegisterRoutes(router.PathPrefix("/api/v1").Subrouter())
+	handler.RegisterPublicRoutes(router.PathPrefix("/public").Subrouter())
+	return router
+}
+
+func readErrorEnvelope(t *testing.T, recorder *httptest.ResponseRecorder) Error {
+	t.Helper()
+	var body Error
+	if err := json.NewDecoder(recorder.Body).Decode(&body); err != nil {
+		t.Fatalf("Expected JSON error envelope, got %q: %v", recorder.Body.String(), err)
+	}
+	return body
+}
+
+func TestEstimatePriceValidation(t *testing.T) {
+	clients := grpc.NewClientManager()
+	clients.PricingClient = pricingpb.NewPricingServiceClient(nil)
+	router := newTestRouter(clients)
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantCode   ErrorCode
+		wantFields []string
+	}{
+		{
+			name:       "valid",
+			body:       `{"pickup": {"latitude": 37.77, "longitude": -122.41}, "destination": {"latitude": 37.80, "longitude": -122.40}, "vehicle_type": "economy"}`,
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "empty_body",
+			body:       ``,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   CodeInvalidRequest,
+		},
+		{
+			name:       "malformed",
+			body:       `{"pickup": `,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   CodeInvalidRequest,
+		},
+		{
+			name:       "out_of_range",
+			body:       `{"pickup": {"latitude": 91, "longitude": -122.41}, "destination": {"latitude": 37.80, "longitude": 181}}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   CodeValidationFailed,
+			wantFields: []string{"pickup.latitude", "destination.longitude"},
+		},
+		{
+			name:       "missing_fields",
+			body:       `{"vehicle_type": "rocket"}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   CodeValidationFailed,
+			wantFields: []string{"pickup", "destination", "vehicle_type"},
+		},
+		{
+			name:       "unknown_field",
+			body:       `{"pickup_location": {"latitude": 1, "longitude": 1}}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   CodeValidationFailed,
+			wantFields: []string{"pickup_location"},
+		},
+		{
+			name:       "wrong_type",
+			body:       `{"pickup": {"latitude": "north", "longitude": 1}}`,
+			wantStatus: http.StatusBadRequest,
+			wantCode:   CodeValidationFailed,
+			wantFields: []string{"pickup.latitude"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/pricing/estimate", strings.NewReader(tt.body))
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, req)
+
+			if recorder.Code != tt.wantStatus {
+				t.Fatalf("Expected status %d, got %d: %s", tt.wantStatus, recorder.Code, recorder.Body.String())
+			}
+			if tt.wantStatus == http.StatusOK {
+				return
+			}
+
+			body := readErrorEnvelope(t, recorder)
+			if body.Code != tt.wantCode {
+				t.Errorf("Expected code %s, got %s", tt.wantCode, body.Code)
+			}
+			if len(body.Details) != len(tt.wantFields) {
+				t.Fatalf("Expected %d field errors, got %+v", len(tt.wantFields), body.Details)
+			}
+			for i, field := range tt.wantFields {
+				if body.Details[i].Field != field {
+					t.Errorf("Expected error for %s, got %s", field, body.Details[i].Field)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlersReportUnavailableServices(t *testing.T) {
+	router := newTestRouter(grpc.NewClientManager())
+
+	tests := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{http.MethodGet, "/api/v1/users/user-1", ""},
+		{http.MethodGet, "/api/v1/trips/trip-1", ""},
+		{http.MethodPost, "/api/v1/trips/trip-1/share", `{"rider_id": "rider-1"}`},
+		{http.MethodPost, "/api/v1/trips/trip-1/sos", `{"reporter_id": "rider-1"}`},
+		{http.MethodPost, "/api/v1/trips/trip-1/rider-location", `{"rider_id": "rider-1", "location": {"latitude": 41.01, "longitude": 28.98}}`},
+		{http.MethodGet, "/public/trips/share-token", ""},
+		{http.MethodPost, "/api/v1/payments", `{"trip_id": "trip-1", "amount": 12.5, "currency": "USD", "payment_method_id": "pm-1"}`},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+
+		if recorder.Code != http.StatusServiceUnavailable {
+			t.Errorf("%s %s: expected status 503, got %d", tt.method, tt.path, recorder.Code)
+			continue
+		}
+		if body := readErrorEnvelope(t, recorder); body.Code != CodeServiceUnavailable {
+			t.Errorf("%s %s: expected code %s, got %s", tt.method, tt.path, CodeServiceUnavailable, body.Code)
+		}
+	}
+}
+
+func TestUnknownRouteUsesErrorEnvelope(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	newTestRouter(grpc.NewClientManager()).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/unknown", nil))
+
+	if recorder.Code != http.StatusNotFound {
+		t.Fatalf("Expected status 404, got %d", recorder.Code)
+	}
+	if body := readErrorEnvelope(t, recorder); body.Code != CodeNotFound {
+		t.Errorf("Expected code %s, got %s", CodeNotFound, body.Code)
+	}
+}
+
+func TestLocalizeNegotiatesErrorTitles(t *testing.T) {
+	handler := Localize(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if locale := i18n.FromContext(r.Context()); locale != "fr-CA" {
+			t.Errorf("Expected locale fr-CA in the request context, got %q", locale)
+		}
+		WriteError(w, NewError(http.StatusNotFound, CodeNotFound, "trip not found"))
+	}))
+
+	request := httptest.NewRequest(http.MethodGet, "/api/v1/trips/trip-1", nil)
+	request.Header.Set("Accept-Language", "zh-CN, fr-CA;q=0.8, en;q=0.5")
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+
+	if language := recorder.Header().Get("Content-Language"); language != "fr-CA" {
+		t.Errorf("Expected Content-Language fr-CA, got %q", language)
+	}
+	body := readErrorEnvelope(t, recorder)
+	if body.Message != "trip not found" {
+		t.Errorf("Expected the message to stay in English, got %q", body.Message)
+	}
+	if body.Title != "Nous n'avons pas trouvé ce que vous cherchez." {
+		t.Errorf("Expected a French title, got %q", body.Title)
+	}
+}
+
+func TestFromGRPC(t *testing.T) {
+	tests := []struct {
+		code       codes.Code
+		wantStatus int
+		wantCode   ErrorCode
+	}{
+		{codes.InvalidArgument, http.StatusBadRequest, CodeValidationFailed},
+		{codes.NotFound, http.StatusNotFound, CodeNotFound},
+		{codes.FailedPrecondition, http.StatusConflict, CodeConflict},
+		{codes.Unavailable, http.StatusServiceUnavailable, CodeServiceUnavailable},
+		{codes.Internal, http.StatusBadGateway, CodeUpstreamError},
+	}
+
+	for _, tt := range tests {
+		err := FromGRPC("trip", grpcstatus.Error(tt.code, "details"))
+		if err.Status != tt.wantStatus || err.Code != tt.wantCode {
+			t.Errorf("%s: expected %d %s, got %d %s", tt.code, tt.wantStatus, tt.wantCode, err.Status, err.Code)
+		}
+	}
+}
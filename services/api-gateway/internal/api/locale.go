@@ -0,0 +1,22 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/rideshare-platform/shared/i18n"
+)
+
+const contentLanguageHeader = "Content-Language"
+
+// Localize negotiates the locale each request is answered in from its
+// Accept-Language header. The locale is kept in the request context, from
+// where it is passed on to the backend services, and announced in the
+// Content-Language header.
+func Localize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		locale := i18n.Negotiate(r.Header.Get("Accept-Language"))
+		w.Header().Set(contentLanguageHeader, locale)
+		w.Header().Add("Vary", "Accept-Language")
+		next.ServeHTTP(w, r.WithContext(i18n.WithLocale(r.Context(), locale)))
+	})
+}
@@ -0,0 +1,438 @@
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	trippb "github.com/rideshare-platform/shared/proto/trip"
+)
+
+// Limits applied when validating requests
+const (
+	maxSearchRadiusKm = 50
+	maxNearbyDrivers  = 50
+	maxPaymentAmount  = 5000
+
+	// maxShareLinkMinutes matches the longest share link trip-service signs
+	maxShareLinkMinutes = 24 * 60
+
+	// maxTripMessageLength matches the longest message trip-service accepts
+	maxTripMessageLength = 500
+
+	// maxLostItemDescriptionLength matches the longest lost item description trip-service accepts
+	maxLostItemDescriptionLength = 500
+)
+
+// vehicleTypes are the ride types pricing-service and matching-service accept
+var vehicleTypes = []string{"economy", "standard", "premium", "luxury", "wav"}
+
+// currencyPattern matches ISO 4217 currency codes
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// Location is a point on the map
+type Location struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Address   string  `json:"address,omitempty"`
+}
+
+// PriceEstimateRequest asks for the fare of a ride before it is booked
+type PriceEstimateRequest struct {
+	RiderID     string    `json:"rider_id"`
+	Pickup      *Location `json:"pickup"`
+	Destination *Location `json:"destination"`
+	VehicleType string    `json:"vehicle_type"`
+}
+
+// Validate checks the pickup and destination and the requested vehicle type
+func (r *PriceEstimateRequest) Validate() []FieldError {
+	var errs fieldErrors
+	errs.location("pickup", r.Pickup)
+	errs.location("destination", r.Destination)
+	errs.oneOf("vehicle_type", r.VehicleType, vehicleTypes)
+	if r.Pickup != nil && r.Destination != nil && *r.Pickup == *r.Destination {
+		errs.add("destination", "must differ from pickup")
+	}
+	return errs
+}
+
+// PriceEstimateResponse is the estimated fare for a ride
+type PriceEstimateResponse struct {
+	EstimatedFare float64 `json:"estimated_fare"`
+	Currency      string  `json:"currency"`
+	VehicleType   string  `json:"vehicle_type,omitempty"`
+	Status        string  `json:"status"`
+}
+
+// NearbyDriversRequest searches for available drivers around a location
+type NearbyDriversRequest struct {
+	Location    *Location `json:"location"`
+	RadiusKm    float64   `json:"radius_km"`
+	VehicleType string    `json:"vehicle_type"`
+	MaxDrivers  int       `json:"max_drivers"`
+}
+
+// Validate checks the search location and bounds the radius and result size
+func (r *NearbyDriversRequest) Validate() []FieldError {
+	var errs fieldErrors
+	errs.location("location", r.Location)
+	if r.RadiusKm < 0 || r.RadiusKm > maxSearchRadiusKm {
+		errs.add("radius_km", "must be between 0 and 50")
+	}
+	if r.MaxDrivers < 0 || r.MaxDrivers > maxNearbyDrivers {
+		errs.add("max_drivers", "must be between 0 and 50")
+	}
+	errs.oneOf("vehicle_type", r.VehicleType, vehicleTypes)
+	return errs
+}
+
+// NearbyDriver is a driver returned by a nearby drivers search
+type NearbyDriver struct {
+	DriverID   string    `json:"driver_id"`
+	Location   *Location `json:"location"`
+	DistanceKm float64   `json:"distance_km"`
+}
+
+// NearbyDriversResponse lists the drivers found around a location
+type NearbyDriversResponse struct {
+	Drivers []NearbyDriver `json:"drivers"`
+	Status  string         `json:"status"`
+}
+
+// CreatePaymentRequest charges a rider for a trip
+type CreatePaymentRequest struct {
+	TripID          string  `json:"trip_id"`
+	Amount          float64 `json:"amount"`
+	Currency        string  `json:"currency"`
+	PaymentMethodID string  `json:"payment_method_id"`
+	Description     string  `json:"description"`
+}
+
+// Validate checks the trip, amount, currency and payment method
+func (r *CreatePaymentRequest) Validate() []FieldError {
+	var errs fieldErrors
+	errs.required("trip_id", r.TripID)
+	errs.required("payment_method_id", r.PaymentMethodID)
+	if r.Amount <= 0 || r.Amount > maxPaymentAmount {
+		errs.add("amount", "must be greater than 0 and at most 5000")
+	}
+	if !currencyPattern.MatchString(r.Currency) {
+		errs.add("currency", "must be a three letter ISO 4217 code")
+	}
+	return errs
+}
+
+// PaymentResponse is the outcome of a payment request
+type PaymentResponse struct {
+	PaymentID string `json:"payment_id"`
+	Status    string `json:"status"`
+}
+
+// UserResponse is a user returned by the gateway
+type UserResponse struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+// TripResponse is a trip returned by the gateway
+type TripResponse struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+// ShareTripRequest asks for a link the rider can hand out so others can
+// follow their trip
+type ShareTripRequest struct {
+	RiderID    string `json:"rider_id"`
+	TTLMinutes int    `json:"ttl_minutes"`
+}
+
+// Validate checks the rider and bounds the link's lifetime
+func (r *ShareTripRequest) Validate() []FieldError {
+	var errs fieldErrors
+	errs.required("rider_id", r.RiderID)
+	if r.TTLMinutes < 0 || r.TTLMinutes > maxShareLinkMinutes {
+		errs.add("ttl_minutes", "must be between 0 and 1440")
+	}
+	return errs
+}
+
+// ShareTripResponse is a trip share link and where it can be followed
+type ShareTripResponse struct {
+	Token     string    `json:"token"`
+	URL       string    `json:"url"`
+	SocketURL string    `json:"socket_url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// SOSRequest raises an emergency during a trip
+type SOSRequest struct {
+	ReporterID string    `json:"reporter_id"`
+	Location   *Location `json:"location,omitempty"`
+	Message    string    `json:"message,omitempty"`
+}
+
+// Validate checks the reporter and, when sent, their location
+func (r *SOSRequest) Validate() []FieldError {
+	var errs fieldErrors
+	errs.required("reporter_id", r.ReporterID)
+	if r.Location != nil {
+		errs.location("location", r.Location)
+	}
+	return errs
+}
+
+// disputeReasons are the reasons a rider can give for disputing a fare
+var disputeReasons = []string{"overcharged", "wrong_route", "trip_not_taken", "cancellation_fee", "other"}
+
+// DisputeEvidence supports a dispute, as a description, an https link to
+// a screenshot or receipt, or both
+type DisputeEvidence struct {
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url,omitempty"`
+}
+
+// DisputeRequest contests what a rider was charged for a trip
+type DisputeRequest struct {
+	RiderID     string            `json:"rider_id"`
+	Reason      string            `json:"reason"`
+	Description string            `json:"description,omitempty"`
+	Evidence    []DisputeEvidence `json:"evidence,omitempty"`
+}
+
+// Validate checks the rider and reason, and that other disputes say what is wrong
+func (r *DisputeRequest) Validate() []FieldError {
+	var errs fieldErrors
+	errs.required("rider_id", r.RiderID)
+	errs.required("reason", r.Reason)
+	errs.oneOf("reason", r.Reason, disputeReasons)
+	if r.Reason == "other" {
+		errs.required("description", r.Description)
+	}
+	for i, evidence := range r.Evidence {
+		if evidence.URL != "" && !strings.HasPrefix(evidence.URL, "https://") {
+			errs.add(fmt.Sprintf("evidence[%d].url", i), "must be an https URL")
+		}
+	}
+	return errs
+}
+
+// DisputeResponse acknowledges a fare dispute
+type DisputeResponse struct {
+	DisputeID     string    `json:"dispute_id"`
+	TripID        string    `json:"trip_id"`
+	Status        string    `json:"status"`
+	ChargedAmount float64   `json:"charged_amount"`
+	Currency      string    `json:"currency,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// LostItemRequest reports something a rider left behind on a trip
+type LostItemRequest struct {
+	RiderID     string `json:"rider_id"`
+	Description string `json:"description"`
+}
+
+// Validate requires the rider and a description of the item
+func (r *LostItemRequest) Validate() []FieldError {
+	var errs fieldErrors
+	errs.required("rider_id", r.RiderID)
+	errs.required("description", r.Description)
+	if utf8.RuneCountInString(r.Description) > maxLostItemDescriptionLength {
+		errs.add("description", "must be at most 500 characters")
+	}
+	return errs
+}
+
+// LostItemResponse acknowledges a lost item report. The rider can message
+// the driver in the trip chat until ContactUntil.
+type LostItemResponse struct {
+	ReportID       string    `json:"report_id"`
+	TripID         string    `json:"trip_id"`
+	Status         string    `json:"status"`
+	DriverNotified bool      `json:"driver_notified"`
+	ContactUntil   time.Time `json:"contact_until"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// TripContactQuery selects whose contact handle to return
+type TripContactQuery struct {
+	UserID string
+}
+
+// Validate requires the rider or driver asking
+func (q *TripContactQuery) Validate() []FieldError {
+	var errs fieldErrors
+	errs.required("user_id", q.UserID)
+	return errs
+}
+
+// TripContactResponse is how a rider or driver reaches the other party of
+// their trip without seeing their phone number: a masked number to dial, or
+// a token to start an in-app call with. It stops working once the trip ends.
+type TripContactResponse struct {
+	TripID       string    `json:"trip_id"`
+	Mode         string    `json:"mode"`
+	MaskedNumber string    `json:"masked_number,omitempty"`
+	VoIPToken    string    `json:"voip_token,omitempty"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// RiderLocationRequest shares a waiting rider's location with their driver
+type RiderLocationRequest struct {
+	RiderID  string    `json:"rider_id"`
+	Location *Location `json:"location"`
+}
+
+// Validate checks the rider and their location
+func (r *RiderLocationRequest) Validate() []FieldError {
+	var errs fieldErrors
+	errs.required("rider_id", r.RiderID)
+	errs.location("location", r.Location)
+	return errs
+}
+
+// SOSResponse acknowledges an emergency with the incident recorded for it
+type SOSResponse struct {
+	IncidentID string    `json:"incident_id"`
+	Status     string    `json:"status"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// SharedTripResponse is what a share link reveals of a trip
+type SharedTripResponse struct {
+	TripID         string    `json:"trip_id"`
+	Status         string    `json:"status"`
+	Pickup         *Location `json:"pickup,omitempty"`
+	Destination    *Location `json:"destination,omitempty"`
+	DriverLocation *Location `json:"driver_location,omitempty"`
+	Ended          bool      `json:"ended"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	ExpiresAt      time.Time `json:"expires_at"`
+}
+
+// NewSharedTripResponse converts a shared trip from trip-service
+func NewSharedTripResponse(trip *trippb.SharedTripStatus) *SharedTripResponse {
+	return &SharedTripResponse{
+		TripID:         trip.TripId,
+		Status:         strings.ToLower(trip.Status.String()),
+		Pickup:         locationFromTrip(trip.PickupLocation),
+		Destination:    locationFromTrip(trip.Destination),
+		DriverLocation: locationFromTrip(trip.DriverLocation),
+		Ended:          trip.Ended,
+		UpdatedAt:      trip.UpdatedAt.AsTime(),
+		ExpiresAt:      trip.ExpiresAt.AsTime(),
+	}
+}
+
+func locationFromTrip(location *trippb.Location) *Location {
+	if location == nil {
+		return nil
+	}
+	return &Location{
+		Latitude:  location.Latitude,
+		Longitude: location.Longitude,
+		Address:   location.Address,
+	}
+}
+
+// TripMessageRequest is a message from a trip's rider or driver to the other
+type TripMessageRequest struct {
+	SenderID     string `json:"sender_id"`
+	Text         string `json:"text,omitempty"`
+	QuickReplyID string `json:"quick_reply_id,omitempty"`
+}
+
+// Validate requires the sender and either text or a quick reply
+func (r *TripMessageRequest) Validate() []FieldError {
+	var errs fieldErrors
+	errs.required("sender_id", r.SenderID)
+	if r.QuickReplyID == "" {
+		errs.required("text", r.Text)
+	}
+	if utf8.RuneCountInString(r.Text) > maxTripMessageLength {
+		errs.add("text", "must be at most 500 characters")
+	}
+	return errs
+}
+
+// TripMessagesQuery selects whose view of a trip's conversation to return
+type TripMessagesQuery struct {
+	UserID string
+}
+
+// Validate requires the participant asking
+func (q *TripMessagesQuery) Validate() []FieldError {
+	var errs fieldErrors
+	errs.required("user_id", q.UserID)
+	return errs
+}
+
+// QuickRepliesQuery selects the quick replies for a role
+type QuickRepliesQuery struct {
+	Role string
+}
+
+// Validate requires a rider or driver role
+func (q *QuickRepliesQuery) Validate() []FieldError {
+	var errs fieldErrors
+	errs.required("role", q.Role)
+	errs.oneOf("role", q.Role, []string{"rider", "driver"})
+	return errs
+}
+
+// TripMessageResponse is a message between a trip's rider and driver
+type TripMessageResponse struct {
+	ID           string     `json:"id"`
+	TripID       string     `json:"trip_id"`
+	SenderID     string     `json:"sender_id"`
+	SenderRole   string     `json:"sender_role"`
+	Text         string     `json:"text"`
+	QuickReplyID string     `json:"quick_reply_id,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	DeliveredAt  *time.Time `json:"delivered_at,omitempty"`
+}
+
+// NewTripMessageResponse converts a trip message from trip-service
+func NewTripMessageResponse(message *trippb.TripMessage) *TripMessageResponse {
+	resp := &TripMessageResponse{
+		ID:           message.Id,
+		TripID:       message.TripId,
+		SenderID:     message.SenderId,
+		SenderRole:   message.SenderRole,
+		Text:         message.Text,
+		QuickReplyID: message.QuickReplyId,
+		CreatedAt:    message.CreatedAt.AsTime(),
+	}
+	if message.DeliveredAt != nil {
+		delivered := message.DeliveredAt.AsTime()
+		resp.DeliveredAt = &delivered
+	}
+	return resp
+}
+
+// TripMessagesResponse is a trip's conversation, oldest first
+type TripMessagesResponse struct {
+	Messages []*TripMessageResponse `json:"messages"`
+}
+
+// QuickReply is a canned message riders or drivers can send with one tap
+type QuickReply struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
+}
+
+// QuickRepliesResponse lists the quick replies for a role
+type QuickRepliesResponse struct {
+	QuickReplies []QuickReply `json:"quick_replies"`
+}
+
+// HealthResponse summarises the health of the backend services
+type HealthResponse struct {
+	Status   string            `json:"status"`
+	Degraded bool              `json:"degraded"`
+	Services map[string]string `json:"services"`
+}
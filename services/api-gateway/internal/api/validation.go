@@ -0,0 +1,114 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// maxBodyBytes caps the size of request bodies the gateway will decode
+const maxBodyBytes = 1 << 20
+
+// FieldError describes why a single request field is invalid
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Validator is implemented by request models that can check their own fields
+type Validator interface {
+	Validate() []FieldError
+}
+
+// Bind decodes a JSON request body into dst and validates it. Unknown fields
+// are rejected so typos in field names are reported instead of ignored.
+func Bind(r *http.Request, dst Validator) error {
+	if r.Body == nil {
+		return NewError(http.StatusBadRequest, CodeInvalidRequest, "request body is required")
+	}
+
+	decoder := json.NewDecoder(io.LimitReader(r.Body, maxBodyBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(dst); err != nil {
+		return decodeError(err)
+	}
+	if decoder.More() {
+		return NewError(http.StatusBadRequest, CodeInvalidRequest, "request body must contain a single JSON object")
+	}
+
+	return Validate(dst)
+}
+
+// Validate runs a model's validation and wraps any failures in the envelope
+func Validate(v Validator) error {
+	if details := v.Validate(); len(details) > 0 {
+		err := NewError(http.StatusBadRequest, CodeValidationFailed, "request validation failed")
+		err.Details = details
+		return err
+	}
+	return nil
+}
+
+// decodeError turns a JSON decoding error into a client-facing message
+func decodeError(err error) *Error {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.Is(err, io.EOF):
+		return NewError(http.StatusBadRequest, CodeInvalidRequest, "request body is required")
+	case errors.As(err, &syntaxErr):
+		return NewError(http.StatusBadRequest, CodeInvalidRequest, fmt.Sprintf("malformed JSON at offset %d", syntaxErr.Offset))
+	case errors.As(err, &typeErr):
+		invalid := NewError(http.StatusBadRequest, CodeValidationFailed, "request validation failed")
+		invalid.Details = []FieldError{{Field: typeErr.Field, Message: fmt.Sprintf("must be a %s", typeErr.Type)}}
+		return invalid
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		invalid := NewError(http.StatusBadRequest, CodeValidationFailed, "request validation failed")
+		invalid.Details = []FieldError{{Field: field, Message: "unknown field"}}
+		return invalid
+	default:
+		return NewError(http.StatusBadRequest, CodeInvalidRequest, "malformed JSON")
+	}
+}
+
+// fieldErrors collects validation failures for a request model
+type fieldErrors []FieldError
+
+func (f *fieldErrors) add(field, message string) {
+	*f = append(*f, FieldError{Field: field, Message: message})
+}
+
+func (f *fieldErrors) required(field, value string) {
+	if strings.TrimSpace(value) == "" {
+		f.add(field, "is required")
+	}
+}
+
+func (f *fieldErrors) oneOf(field, value string, allowed []string) {
+	if value == "" {
+		return
+	}
+	for _, candidate := range allowed {
+		if value == candidate {
+			return
+		}
+	}
+	f.add(field, "must be one of "+strings.Join(allowed, ", "))
+}
+
+func (f *fieldErrors) location(field string, location *Location) {
+	if location == nil {
+		f.add(field, "is required")
+		return
+	}
+	if location.Latitude < -90 || location.Latitude > 90 {
+		f.add(field+".latitude", "must be between -90 and 90")
+	}
+	if location.Longitude < -180 || location.Longitude > 180 {
+		f.add(field+".longitude", "must be between -180 and 180")
+	}
+}
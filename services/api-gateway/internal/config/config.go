@@ -0,0 +1,130 @@
+package config
+
+import (
+	"errors"
+	"time"
+
+	sharedconfig "github.com/rideshare-platform/shared/config"
+	sharedtls "github.com/rideshare-platform/shared/tls"
+)
+
+// Config holds the API gateway's listener, TLS and admin API configuration
+type Config struct {
+	HTTPAddr string      `yaml:"http_addr" env:"HTTP_ADDR" default:":8080"`
+	HTTPS    HTTPSConfig `yaml:"https"`
+
+	// StatusInterval is how often the backend services' health is checked
+	// for /status and the connection state metrics
+	StatusInterval time.Duration `yaml:"status_interval" env:"STATUS_INTERVAL" default:"30s"`
+
+	// Mutual TLS for calls to the backend services
+	TLS sharedtls.Config `yaml:"tls"`
+
+	Admin AdminConfig `yaml:"admin"`
+
+	WebSocket WebSocketConfig `yaml:"websocket"`
+}
+
+// WebSocketConfig configures the authenticated socket served at /ws and its
+// Server-Sent Events fallback at /events. They verify user tokens with the
+// admin API's JWT_SECRET and are not served without it.
+type WebSocketConfig struct {
+	// RedisAddr is the Redis every gateway registers connections and
+	// sessions in, which the WebSocket connection gauge is read from, and
+	// buffers event streams in. Both are kept per gateway in memory
+	// without it.
+	RedisAddr string `yaml:"redis_addr" env:"WEBSOCKET_REDIS_ADDR"`
+	// PingInterval is how often connections are pinged
+	PingInterval time.Duration `yaml:"ping_interval" env:"WEBSOCKET_PING_INTERVAL" default:"30s"`
+	// PongTimeout is how long a connection may stay silent before it is
+	// closed
+	PongTimeout time.Duration `yaml:"pong_timeout" env:"WEBSOCKET_PONG_TIMEOUT" default:"75s"`
+	// ResumeTTL is how long after a disconnect a client can reconnect and
+	// have its topics resubscribed, or its missed events sent
+	ResumeTTL time.Duration `yaml:"resume_ttl" env:"WEBSOCKET_RESUME_TTL" default:"2m"`
+	// MaxConnectionsPerUser limits a user's open connections
+	MaxConnectionsPerUser int `yaml:"max_connections_per_user" env:"WEBSOCKET_MAX_CONNECTIONS_PER_USER" default:"5"`
+	// EventBufferSize is about how many events an event stream keeps for
+	// its client to resume
+	EventBufferSize int `yaml:"event_buffer_size" env:"EVENTS_BUFFER_SIZE" default:"200"`
+	// EventKeepAlive is how often idle event streams are sent a comment
+	EventKeepAlive time.Duration `yaml:"event_keep_alive" env:"EVENTS_KEEP_ALIVE" default:"15s"`
+}
+
+// AdminConfig configures the operations API served under /admin
+type AdminConfig struct {
+	// JWTSecret verifies operator tokens. The admin API is not served
+	// without it.
+	JWTSecret string `yaml:"jwt_secret" env:"JWT_SECRET"`
+	// AlertsRedisAddr is the Redis the alert manager records alerts in. The
+	// alert overview is unavailable without it.
+	AlertsRedisAddr string `yaml:"alerts_redis_addr" env:"ALERTS_REDIS_ADDR"`
+	// SearchDatabaseURL is the PostgreSQL database holding the users,
+	// vehicles and trips tables support search runs against. Search is
+	// unavailable without it.
+	SearchDatabaseURL string `yaml:"search_database_url" env:"SEARCH_DATABASE_URL"`
+	// FlagsRedisAddr is the Redis feature flags are kept in. The flag
+	// routes are unavailable without it.
+	FlagsRedisAddr string `yaml:"flags_redis_addr" env:"FLAGS_REDIS_ADDR"`
+}
+
+// Enabled reports whether the admin API is served
+func (c AdminConfig) Enabled() bool {
+	return c.JWTSecret != ""
+}
+
+// HTTPSConfig configures TLS termination for client traffic
+type HTTPSConfig struct {
+	Enabled  bool   `yaml:"enabled" env:"HTTPS_ENABLED" default:"false"`
+	Addr     string `yaml:"addr" env:"HTTPS_ADDR" default:":8443"`
+	CertFile string `yaml:"cert_file" env:"HTTPS_CERT_FILE"`
+	KeyFile  string `yaml:"key_file" env:"HTTPS_KEY_FILE"`
+	// RedirectHTTP answers plaintext requests on HTTPAddr with a redirect to
+	// HTTPS instead of serving them
+	RedirectHTTP   bool          `yaml:"redirect_http" env:"HTTPS_REDIRECT_HTTP" default:"true"`
+	ReloadInterval time.Duration `yaml:"reload_interval" env:"HTTPS_RELOAD_INTERVAL" default:"1m"`
+}
+
+// Load loads the configuration from CONFIG_FILE and the environment
+func Load() (*Config, error) {
+	cfg := &Config{}
+	if err := sharedconfig.NewLoader().Load(cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Validate validates the configuration
+func (c *Config) Validate() error {
+	if c.StatusInterval <= 0 {
+		return errors.New("STATUS_INTERVAL must be positive")
+	}
+	if c.WebSocket.PingInterval <= 0 || c.WebSocket.PongTimeout <= c.WebSocket.PingInterval {
+		return errors.New("WEBSOCKET_PONG_TIMEOUT must be longer than a positive WEBSOCKET_PING_INTERVAL")
+	}
+	if c.WebSocket.ResumeTTL <= 0 {
+		return errors.New("WEBSOCKET_RESUME_TTL must be positive")
+	}
+	if c.WebSocket.EventBufferSize <= 0 || c.WebSocket.EventKeepAlive <= 0 {
+		return errors.New("EVENTS_BUFFER_SIZE and EVENTS_KEEP_ALIVE must be positive")
+	}
+	if c.HTTPS.Enabled {
+		if c.HTTPS.CertFile == "" || c.HTTPS.KeyFile == "" {
+			return errors.New("HTTPS_CERT_FILE and HTTPS_KEY_FILE are required when HTTPS is enabled")
+		}
+		if c.HTTPS.Addr == c.HTTPAddr {
+			return errors.New("HTTPS_ADDR and HTTP_ADDR must differ")
+		}
+	}
+	return c.TLS.Validate()
+}
+
+// CertificateConfig returns the settings for loading the HTTPS certificate
+func (c HTTPSConfig) CertificateConfig() sharedtls.Config {
+	return sharedtls.Config{
+		Enabled:        c.Enabled,
+		CertFile:       c.CertFile,
+		KeyFile:        c.KeyFile,
+		ReloadInterval: c.ReloadInterval,
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"os"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -11,20 +14,44 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/keepalive"
 
+	sharedgrpc "github.com/rideshare-platform/shared/grpc"
 	geopb "github.com/rideshare-platform/shared/proto/geo"
 	matchingpb "github.com/rideshare-platform/shared/proto/matching"
 	paymentpb "github.com/rideshare-platform/shared/proto/payment"
 	pricingpb "github.com/rideshare-platform/shared/proto/pricing"
 	trippb "github.com/rideshare-platform/shared/proto/trip"
 	userpb "github.com/rideshare-platform/shared/proto/user"
+	sharedtls "github.com/rideshare-platform/shared/tls"
 )
 
 // ServiceConfig holds configuration for individual services
 type ServiceConfig struct {
 	Address        string
-	MaxRetries     int
-	TimeoutSeconds int
-	EnableTLS      bool
+	MaxRetries     int    // retries for idempotent calls
+	TimeoutSeconds int    // deadline for each call attempt
+	EnableTLS      bool   // dial over mutual TLS, requires SetTLS
+	HealthURL      string // HTTP health endpoint reporting the service's dependencies
+
+	// Resilience settings; zero values fall back to the defaults
+	RetryBackoffMillis      int // first retry delay, doubled on each retry
+	BreakerFailureThreshold int // consecutive failures before the breaker opens
+	BreakerCooldownSeconds  int // how long the breaker stays open before probing
+}
+
+// retryPolicy returns the retry policy for the service
+func (c ServiceConfig) retryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:     c.MaxRetries,
+		InitialBackoff: time.Duration(c.RetryBackoffMillis) * time.Millisecond,
+	}
+}
+
+// timeout returns the deadline applied to each call attempt
+func (c ServiceConfig) timeout() time.Duration {
+	if c.TimeoutSeconds <= 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(c.TimeoutSeconds) * time.Second
 }
 
 // ClientManager manages gRPC connections to all microservices
@@ -39,55 +66,102 @@ type ClientManager struct {
 
 	// Connection management
 	connections map[string]*grpc.ClientConn
+	breakers    map[string]*CircuitBreaker
 	mutex       sync.RWMutex
 	config      map[string]ServiceConfig
+	tls         *sharedtls.Reloader
 }
 
 // NewClientManager creates a new gRPC client manager
 func NewClientManager() *ClientManager {
 	return &ClientManager{
 		connections: make(map[string]*grpc.ClientConn),
+		breakers:    make(map[string]*CircuitBreaker),
 		config: map[string]ServiceConfig{
 			"geo": {
 				Address:        "geo-service:50053",
 				MaxRetries:     3,
 				TimeoutSeconds: 30,
 				EnableTLS:      false,
+				HealthURL:      "http://geo-service:8053/health",
 			},
 			"user": {
 				Address:        "user-service:50051",
 				MaxRetries:     3,
 				TimeoutSeconds: 30,
 				EnableTLS:      false,
+				HealthURL:      "http://user-service:8051/health",
 			},
 			"vehicle": {
 				Address:        "vehicle-service:50052",
 				MaxRetries:     3,
 				TimeoutSeconds: 30,
 				EnableTLS:      false,
+				HealthURL:      "http://vehicle-service:8052/health",
 			},
 			"trip": {
 				Address:        "trip-service:8085",
 				MaxRetries:     3,
 				TimeoutSeconds: 30,
 				EnableTLS:      false,
+				HealthURL:      "http://trip-service:8085/health",
 			},
 			"matching": {
-				Address:        "matching-service:8084",
+				Address:        "matching-service:8054",
 				MaxRetries:     3,
 				TimeoutSeconds: 30,
 				EnableTLS:      false,
+				HealthURL:      "http://matching-service:8084/health",
 			},
 			"payment": {
 				Address:        "payment-service:9087",
 				MaxRetries:     3,
 				TimeoutSeconds: 30,
 				EnableTLS:      false,
+				HealthURL:      "http://payment-service:8005/health",
 			},
 		},
 	}
 }
 
+// SetTLS dials every service over mutual TLS, presenting the reloader's
+// certificate. A nil reloader leaves the connections in plaintext.
+func (cm *ClientManager) SetTLS(reloader *sharedtls.Reloader) {
+	if reloader == nil {
+		return
+	}
+	cm.tls = reloader
+	for serviceName, config := range cm.config {
+		config.EnableTLS = true
+		cm.config[serviceName] = config
+	}
+}
+
+// ApplyEnvOverrides overrides service settings from <SERVICE>_SERVICE_ADDR,
+// <SERVICE>_TIMEOUT_SECONDS, <SERVICE>_MAX_RETRIES, <SERVICE>_HEALTH_URL and
+// <SERVICE>_TLS_ENABLED environment variables, e.g. TRIP_SERVICE_ADDR
+func (cm *ClientManager) ApplyEnvOverrides() {
+	for serviceName, config := range cm.config {
+		prefix := strings.ToUpper(serviceName)
+		if addr := os.Getenv(prefix + "_SERVICE_ADDR"); addr != "" {
+			config.Address = addr
+		}
+		if timeout, err := strconv.Atoi(os.Getenv(prefix + "_TIMEOUT_SECONDS")); err == nil && timeout > 0 {
+			config.TimeoutSeconds = timeout
+		}
+		if retries, err := strconv.Atoi(os.Getenv(prefix + "_MAX_RETRIES")); err == nil && retries >= 0 {
+			config.MaxRetries = retries
+		}
+		if healthURL := os.Getenv(prefix + "_HEALTH_URL"); healthURL != "" {
+			config.HealthURL = healthURL
+		}
+		if enableTLS, err := strconv.ParseBool(os.Getenv(prefix + "_TLS_ENABLED")); err == nil {
+			config.EnableTLS = enableTLS
+		}
+		cm.config[serviceName] = config
+	}
+}
+
 // Initialize establishes connections to all services
 func (cm *ClientManager) Initialize() error {
 	log.Println("Initializing gRPC client connections...")
@@ -114,11 +188,30 @@ func (cm *ClientManager) connectService(serviceName string, config ServiceConfig
 		PermitWithoutStream: true,             // send pings even without active streams
 	}
 
+	// Keep the breaker across reconnects so a flapping service stays open
+	breaker, exists := cm.breakers[serviceName]
+	if !exists {
+		breaker = NewCircuitBreaker(config.BreakerFailureThreshold, time.Duration(config.BreakerCooldownSeconds)*time.Second)
+		cm.breakers[serviceName] = breaker
+	}
+
+	transport := grpc.WithTransportCredentials(insecure.NewCredentials())
+	if config.EnableTLS {
+		if cm.tls == nil {
+			return fmt.Errorf("%s requires TLS but no certificates are configured", serviceName)
+		}
+		transport = cm.tls.DialOption()
+	}
+
 	// Create connection options
 	opts := []grpc.DialOption{
 		grpc.WithKeepaliveParams(kacp),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		transport,
+		grpc.WithUnaryInterceptor(resilientUnaryInterceptor(serviceName, config.timeout(), config.retryPolicy(), breaker)),
+		grpc.WithStreamInterceptor(resilientStreamInterceptor(serviceName, breaker)),
 	}
+	// Every attempt carries the request's correlation ID to the service
+	opts = append(opts, sharedgrpc.ClientOptions()...)
 
 	// Establish connection
 	conn, err := grpc.Dial(config.Address, opts...)
@@ -239,6 +332,32 @@ func (cm *ClientManager) GetConnectionStatus() map[string]string {
 	return status
 }
 
+// GetHealthURLs returns the HTTP health endpoint of every configured service
+func (cm *ClientManager) GetHealthURLs() map[string]string {
+	cm.mutex.RLock()
+	defer cm.mutex.RUnlock()
+
+	urls := make(map[string]string)
+	for serviceName, config := range cm.config {
+		if config.HealthURL != "" {
+			urls[serviceName] = config.HealthURL
+		}
+	}
+	return urls
+}
+
+// GetBreakerStates returns the circuit breaker state of every connected service
+func (cm *ClientManager) GetBreakerStates() map[string]BreakerSnapshot {
+	cm.mutex.RLock()
+	defer cm.mutex.RUnlock()
+
+	states := make(map[string]BreakerSnapshot)
+	for serviceName, breaker := range cm.breakers {
+		states[serviceName] = breaker.Snapshot()
+	}
+	return states
+}
+
 // WithTimeout returns a context with the configured timeout for a service
 func (cm *ClientManager) WithTimeout(ctx context.Context, serviceName string) (context.Context, context.CancelFunc) {
 	config, exists := cm.config[serviceName]
@@ -247,5 +366,5 @@ func (cm *ClientManager) WithTimeout(ctx context.Context, serviceName string) (c
 		return context.WithTimeout(ctx, 30*time.Second)
 	}
 
-	return context.WithTimeout(ctx, time.Duration(config.TimeoutSeconds)*time.Second)
+	return context.WithTimeout(ctx, config.timeout())
 }
@@ -0,0 +1,239 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultRetryBackoff     = 100 * time.Millisecond
+	defaultMaxRetryBackoff  = 2 * time.Second
+	defaultBreakerThreshold = 5
+	defaultBreakerCooldown  = 30 * time.Second
+)
+
+// ErrCircuitOpen is returned when calls to a service are rejected by its circuit breaker
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// BreakerState is the state of a service's circuit breaker
+type BreakerState string
+
+const (
+	// BreakerClosed lets every call through
+	BreakerClosed BreakerState = "closed"
+	// BreakerOpen rejects every call until the cooldown has passed
+	BreakerOpen BreakerState = "open"
+	// BreakerHalfOpen lets a single probe call through to test the service
+	BreakerHalfOpen BreakerState = "half_open"
+)
+
+// BreakerSnapshot is a point-in-time view of a circuit breaker
+type BreakerSnapshot struct {
+	State               BreakerState `json:"state"`
+	ConsecutiveFailures int          `json:"consecutive_failures"`
+	OpenedAt            *time.Time   `json:"opened_at,omitempty"`
+}
+
+// CircuitBreaker stops calling a service after consecutive failures so
+// callers fail fast while it recovers
+type CircuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	state     BreakerState
+	failures  int
+	openedAt  time.Time
+	probing   bool
+	now       func() time.Time
+	mutex     sync.Mutex
+}
+
+// NewCircuitBreaker creates a breaker that opens after threshold consecutive
+// failures and probes the service again after cooldown
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	if threshold < 1 {
+		threshold = defaultBreakerThreshold
+	}
+	if cooldown <= 0 {
+		cooldown = defaultBreakerCooldown
+	}
+	return &CircuitBreaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		state:     BreakerClosed,
+		now:       time.Now,
+	}
+}
+
+// Allow reports whether a call may go ahead. Once the cooldown has passed an
+// open breaker lets one probe call through.
+func (b *CircuitBreaker) Allow() error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return ErrCircuitOpen
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+		return nil
+	case BreakerHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// Record updates the breaker with the outcome of an allowed call
+func (b *CircuitBreaker) Record(err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.probing = false
+	if !isServiceFailure(err) {
+		b.state = BreakerClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+		b.state = BreakerOpen
+		b.openedAt = b.now()
+	}
+}
+
+// Snapshot returns the breaker's current state
+func (b *CircuitBreaker) Snapshot() BreakerSnapshot {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	snapshot := BreakerSnapshot{
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+	}
+	if b.state != BreakerClosed {
+		openedAt := b.openedAt
+		snapshot.OpenedAt = &openedAt
+	}
+	return snapshot
+}
+
+// RetryPolicy retries failed idempotent calls with exponential backoff
+type RetryPolicy struct {
+	MaxRetries     int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// Backoff returns how long to wait before the given retry (starting at 0)
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	backoff := p.InitialBackoff
+	if backoff <= 0 {
+		backoff = defaultRetryBackoff
+	}
+	maxBackoff := p.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultMaxRetryBackoff
+	}
+	for i := 0; i < retry && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	return backoff
+}
+
+// resilientUnaryInterceptor applies a per-attempt deadline, the service's
+// circuit breaker and, for idempotent methods, retries with backoff
+func resilientUnaryInterceptor(serviceName string, timeout time.Duration, policy RetryPolicy, breaker *CircuitBreaker) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		retries := 0
+		if isIdempotent(method) {
+			retries = policy.MaxRetries
+		}
+
+		for attempt := 0; ; attempt++ {
+			if err := breaker.Allow(); err != nil {
+				return status.Errorf(codes.Unavailable, "%s service unavailable: %v", serviceName, err)
+			}
+
+			callCtx, cancel := context.WithTimeout(ctx, timeout)
+			err := invoker(callCtx, method, req, reply, cc, opts...)
+			cancel()
+			breaker.Record(err)
+
+			if err == nil || attempt >= retries || !isRetryable(err) || ctx.Err() != nil {
+				return err
+			}
+
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(policy.Backoff(attempt)):
+			}
+		}
+	}
+}
+
+// resilientStreamInterceptor rejects new streams while the service's circuit
+// breaker is open. Streams are never retried.
+func resilientStreamInterceptor(serviceName string, breaker *CircuitBreaker) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if err := breaker.Allow(); err != nil {
+			return nil, status.Errorf(codes.Unavailable, "%s service unavailable: %v", serviceName, err)
+		}
+
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		breaker.Record(err)
+		return stream, err
+	}
+}
+
+// idempotentPrefixes are the read-only RPC name prefixes that are safe to retry
+var idempotentPrefixes = []string{"Get", "List", "Find", "Search", "Check"}
+
+// isIdempotent reports whether a full gRPC method name is safe to retry
+func isIdempotent(fullMethod string) bool {
+	name := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	for _, prefix := range idempotentPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRetryable reports whether a failed call may succeed if tried again
+func isRetryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
+
+// isServiceFailure reports whether an error means the service itself is
+// unhealthy, as opposed to the call being rejected or cancelled by the caller
+func isServiceFailure(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Internal, codes.Unknown:
+		return true
+	default:
+		return false
+	}
+}
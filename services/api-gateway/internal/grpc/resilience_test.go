@@ -0,0 +1,127 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCircuitBreakerTransitions(t *testing.T) {
+	now := time.Now()
+	breaker := NewCircuitBreaker(2, time.Minute)
+	breaker.now = func() time.Time { return now }
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+
+	// Caller errors do not count against the service
+	breaker.Record(status.Error(codes.NotFound, "trip not found"))
+	breaker.Record(unavailable)
+	if state := breaker.Snapshot().State; state != BreakerClosed {
+		t.Fatalf("Expected closed breaker after one failure, got %s", state)
+	}
+
+	breaker.Record(unavailable)
+	if state := breaker.Snapshot().State; state != BreakerOpen {
+		t.Fatalf("Expected open breaker after two failures, got %s", state)
+	}
+	if err := breaker.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected ErrCircuitOpen while cooling down, got %v", err)
+	}
+
+	// After the cooldown a single probe is let through
+	now = now.Add(time.Minute)
+	if err := breaker.Allow(); err != nil {
+		t.Fatalf("Expected probe to be allowed, got %v", err)
+	}
+	if err := breaker.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected concurrent calls to be rejected during the probe, got %v", err)
+	}
+
+	// A failed probe reopens the breaker, a successful one closes it
+	breaker.Record(unavailable)
+	if state := breaker.Snapshot().State; state != BreakerOpen {
+		t.Fatalf("Expected failed probe to reopen the breaker, got %s", state)
+	}
+	now = now.Add(time.Minute)
+	if err := breaker.Allow(); err != nil {
+		t.Fatalf("Expected probe to be allowed, got %v", err)
+	}
+	breaker.Record(nil)
+	if snapshot := breaker.Snapshot(); snapshot.State != BreakerClosed || snapshot.ConsecutiveFailures != 0 {
+		t.Errorf("Expected closed breaker after successful probe, got %+v", snapshot)
+	}
+}
+
+func TestResilientUnaryInterceptorRetries(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 2, InitialBackoff: time.Millisecond}
+
+	tests := []struct {
+		name      string
+		method    string
+		err       error
+		wantCalls int
+	}{
+		{name: "idempotent_unavailable", method: "/trip.TripService/GetTrip", err: status.Error(codes.Unavailable, "down"), wantCalls: 3},
+		{name: "non_idempotent", method: "/payment.PaymentService/ProcessPayment", err: status.Error(codes.Unavailable, "down"), wantCalls: 1},
+		{name: "not_retryable", method: "/trip.TripService/GetTrip", err: status.Error(codes.NotFound, "missing"), wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				calls++
+				if _, ok := ctx.Deadline(); !ok {
+					t.Error("Expected each attempt to have a deadline")
+				}
+				return tt.err
+			}
+
+			interceptor := resilientUnaryInterceptor("test", time.Second, policy, NewCircuitBreaker(10, time.Minute))
+			err := interceptor(context.Background(), tt.method, nil, nil, nil, invoker)
+			if status.Code(err) != status.Code(tt.err) {
+				t.Errorf("Expected %s, got %v", status.Code(tt.err), err)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("Expected %d calls, got %d", tt.wantCalls, calls)
+			}
+		})
+	}
+}
+
+func TestResilientUnaryInterceptorFailsFastWhenOpen(t *testing.T) {
+	breaker := NewCircuitBreaker(1, time.Minute)
+	calls := 0
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		calls++
+		return status.Error(codes.Unavailable, "down")
+	}
+	interceptor := resilientUnaryInterceptor("trip", time.Second, RetryPolicy{MaxRetries: 3, InitialBackoff: time.Millisecond}, breaker)
+
+	// The first failure opens the breaker, so the retries are rejected without calling the service
+	err := interceptor(context.Background(), "/trip.TripService/GetTrip", nil, nil, nil, invoker)
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("Expected Unavailable, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected the open breaker to stop retries, got %d calls", calls)
+	}
+	if state := breaker.Snapshot().State; state != BreakerOpen {
+		t.Errorf("Expected open breaker, got %s", state)
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}
+
+	expected := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond, 300 * time.Millisecond}
+	for retry, want := range expected {
+		if got := policy.Backoff(retry); got != want {
+			t.Errorf("Retry %d: expected backoff %v, got %v", retry, want, got)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	sharedhealth "github.com/rideshare-platform/shared/health"
+)
+
+// defaultTimeout bounds how long the gateway waits for one service's report
+const defaultTimeout = 3 * time.Second
+
+// ServiceHealth is one service's entry in the platform health document
+type ServiceHealth struct {
+	Status         sharedhealth.Status  `json:"status"`
+	GRPCConnection string               `json:"grpc_connection,omitempty"`
+	LatencyMs      int64                `json:"latency_ms"`
+	Error          string               `json:"error,omitempty"`
+	Report         *sharedhealth.Report `json:"report,omitempty"`
+}
+
+// PlatformReport aggregates the health reports of every backend service
+type PlatformReport struct {
+	Status    sharedhealth.Status      `json:"status"`
+	Timestamp time.Time                `json:"timestamp"`
+	Services  map[string]ServiceHealth `json:"services"`
+}
+
+// ConnectionChecker reports whether the gateway's gRPC connection to each service is usable
+type ConnectionChecker interface {
+	HealthCheck(ctx context.Context) map[string]bool
+}
+
+// Aggregator fetches each service's health endpoint and combines the reports
+type Aggregator struct {
+	urls        map[string]string
+	connections ConnectionChecker
+	client      *http.Client
+
+	mutex sync.RWMutex
+	last  *PlatformReport
+}
+
+// NewAggregator creates an aggregator for the given service health URLs.
+// connections may be nil when gRPC connection state should not be reported.
+func NewAggregator(urls map[string]string, connections ConnectionChecker) *Aggregator {
+	return &Aggregator{
+		urls:        urls,
+		connections: connections,
+		client:      &http.Client{Timeout: defaultTimeout},
+	}
+}
+
+// Check fetches every service's report concurrently. The platform is healthy
+// when every service is, unhealthy when none is, and degraded otherwise.
+func (a *Aggregator) Check(ctx context.Context) *PlatformReport {
+	report := &PlatformReport{
+		Timestamp: time.Now().UTC(),
+		Services:  make(map[string]ServiceHealth, len(a.urls)),
+	}
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	for service, url := range a.urls {
+		wg.Add(1)
+		go func(service, url string) {
+			defer wg.Done()
+			result := a.fetch(ctx, url)
+			mutex.Lock()
+			report.Services[service] = result
+			mutex.Unlock()
+		}(service, url)
+	}
+	wg.Wait()
+
+	if a.connections != nil {
+		for service, connected := range a.connections.HealthCheck(ctx) {
+			result, ok := report.Services[service]
+			if !ok {
+				// No health endpoint, the connection state is all we know
+				result.Status = sharedhealth.StatusHealthy
+				if !connected {
+					result.Status = sharedhealth.StatusUnhealthy
+				}
+			}
+			result.GRPCConnection = "ready"
+			if !connected {
+				result.GRPCConnection = "unavailable"
+				if result.Status == sharedhealth.StatusHealthy {
+					result.Status = sharedhealth.StatusDegraded
+				}
+			}
+			report.Services[service] = result
+		}
+	}
+
+	report.Status = platformStatus(report.Services)
+
+	a.mutex.Lock()
+	a.last = report
+	a.mutex.Unlock()
+	return report
+}
+
+// LastReport returns the report of the most recent check, or nil before the
+// first one
+func (a *Aggregator) LastReport() *PlatformReport {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+	return a.last
+}
+
+// fetch retrieves and decodes one service's health report
+func (a *Aggregator) fetch(ctx context.Context, url string) ServiceHealth {
+	start := time.Now()
+	result := ServiceHealth{Status: sharedhealth.StatusUnhealthy}
+
+	report, err := a.getReport(ctx, url)
+	result.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Status = report.Status
+	result.Report = report
+	return result
+}
+
+func (a *Aggregator) getReport(ctx context.Context, url string) (*sharedhealth.Report, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Unhealthy services answer 503 with a report, anything else without one is an error
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+	var report sharedhealth.Report
+	if err := json.Unmarshal(body, &report); err != nil || report.Status == "" {
+		return nil, fmt.Errorf("unexpected health response with status %d", resp.StatusCode)
+	}
+	return &report, nil
+}
+
+func platformStatus(services map[string]ServiceHealth) sharedhealth.Status {
+	healthy := 0
+	for _, service := range services {
+		if service.Status == sharedhealth.StatusHealthy {
+			healthy++
+		}
+	}
+	switch {
+	case healthy == len(services):
+		return sharedhealth.StatusHealthy
+	case healthy == 0:
+		return sharedhealth.StatusUnhealthy
+	default:
+		return sharedhealth.StatusDegraded
+	}
+}
+
+// Handler serves the platform health document. It answers 503 only when no
+// service is healthy.
+func (a *Aggregator) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := a.Check(r.Context())
+
+		status := http.StatusOK
+		if report.Status == sharedhealth.StatusUnhealthy {
+			status = http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(report)
+	})
+}
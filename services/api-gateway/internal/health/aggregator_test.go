@@ -0,0 +1,88 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sharedhealth "github.com/rideshare-platform/shared/health"
+)
+
+// fakeConnections reports a fixed gRPC connection state per service
+type fakeConnections map[string]bool
+
+func (f fakeConnections) HealthCheck(ctx context.Context) map[string]bool {
+	return f
+}
+
+func newServiceServer(t *testing.T, checker *sharedhealth.Checker) string {
+	t.Helper()
+	server := httptest.NewServer(checker.Handler())
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestAggregatorCombinesServiceReports(t *testing.T) {
+	healthy := sharedhealth.NewChecker("trip-service", "1.0.0")
+	healthy.AddCheck("mongodb", func(ctx context.Context) error { return nil })
+
+	degraded := sharedhealth.NewChecker("matching-service", "1.0.0")
+	degraded.AddOptionalCheck("pricing-service", func(ctx context.Context) error { return errors.New("connection refused") })
+
+	unhealthy := sharedhealth.NewChecker("vehicle-service", "1.0.0")
+	unhealthy.AddCheck("postgres", func(ctx context.Context) error { return errors.New("connection refused") })
+
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad gateway", http.StatusBadGateway)
+	}))
+	defer broken.Close()
+
+	aggregator := NewAggregator(map[string]string{
+		"trip":     newServiceServer(t, healthy),
+		"matching": newServiceServer(t, degraded),
+		"vehicle":  newServiceServer(t, unhealthy),
+		"payment":  broken.URL,
+	}, fakeConnections{"trip": true, "matching": true, "vehicle": true, "payment": false, "geo": false})
+
+	report := aggregator.Check(context.Background())
+	if report.Status != sharedhealth.StatusDegraded {
+		t.Errorf("Expected degraded platform, got %s", report.Status)
+	}
+
+	expected := map[string]sharedhealth.Status{
+		"trip":     sharedhealth.StatusHealthy,
+		"matching": sharedhealth.StatusDegraded,
+		"vehicle":  sharedhealth.StatusUnhealthy,
+		"payment":  sharedhealth.StatusUnhealthy,
+		"geo":      sharedhealth.StatusUnhealthy,
+	}
+	for service, want := range expected {
+		if got := report.Services[service].Status; got != want {
+			t.Errorf("Expected %s to be %s, got %s", service, want, got)
+		}
+	}
+
+	vehicle := report.Services["vehicle"]
+	if vehicle.Report == nil || vehicle.Report.Dependencies["postgres"].Error != "connection refused" {
+		t.Errorf("Expected the vehicle report to include the failing postgres probe, got %+v", vehicle.Report)
+	}
+	if payment := report.Services["payment"]; payment.Error == "" || payment.GRPCConnection != "unavailable" {
+		t.Errorf("Expected payment to report the failed fetch and connection, got %+v", payment)
+	}
+}
+
+func TestAggregatorDegradesServiceWithoutConnection(t *testing.T) {
+	aggregator := NewAggregator(map[string]string{
+		"trip": newServiceServer(t, sharedhealth.NewChecker("trip-service", "1.0.0")),
+	}, fakeConnections{"trip": false})
+
+	report := aggregator.Check(context.Background())
+	if got := report.Services["trip"].Status; got != sharedhealth.StatusDegraded {
+		t.Errorf("Expected trip to be degraded without a gRPC connection, got %s", got)
+	}
+	if report.Status != sharedhealth.StatusUnhealthy {
+		t.Errorf("Expected unhealthy platform when no service is healthy, got %s", report.Status)
+	}
+}
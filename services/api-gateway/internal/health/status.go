@@ -0,0 +1,242 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	gatewaygrpc "github.com/rideshare-platform/services/api-gateway/internal/grpc"
+	sharedhealth "github.com/rideshare-platform/shared/health"
+	"google.golang.org/grpc/connectivity"
+)
+
+// ConnectionNotConnected is the connection state of services the gateway
+// is configured for but holds no connection to
+const ConnectionNotConnected = "NOT_CONNECTED"
+
+// connectionStates are every state a service connection is counted under
+var connectionStates = []string{
+	connectivity.Idle.String(),
+	connectivity.Connecting.String(),
+	connectivity.Ready.String(),
+	connectivity.TransientFailure.String(),
+	connectivity.Shutdown.String(),
+	ConnectionNotConnected,
+}
+
+var connectionsByState = promauto.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "api_gateway_grpc_connections",
+		Help: "Number of backend service connections in each gRPC connection state",
+	},
+	[]string{"state"},
+)
+
+// ServiceStatus is the gateway's view of one backend service
+type ServiceStatus struct {
+	Service         string                       `json:"service"`
+	Connection      string                       `json:"connection"`
+	CircuitBreaker  *gatewaygrpc.BreakerSnapshot `json:"circuit_breaker,omitempty"`
+	Health          sharedhealth.Status          `json:"health,omitempty"`
+	Version         string                       `json:"version,omitempty"`
+	HealthLatencyMs int64                        `json:"health_latency_ms,omitempty"`
+	LastHealthCheck *time.Time                   `json:"last_health_check,omitempty"`
+	Build           *sharedhealth.Info           `json:"build,omitempty"`
+}
+
+// Deployment is a build running on the platform and the services running it
+type Deployment struct {
+	GitCommit string   `json:"git_commit"`
+	Versions  []string `json:"versions"`
+	Services  []string `json:"services"`
+}
+
+// PlatformStatus is the status document served on /status. Deployments
+// groups the gateway and the services that reported their build by git
+// commit, and Mismatched is set when they are not all running the same one.
+type PlatformStatus struct {
+	Timestamp   time.Time          `json:"timestamp"`
+	Gateway     *sharedhealth.Info `json:"gateway,omitempty"`
+	Services    []ServiceStatus    `json:"services"`
+	Deployments []Deployment       `json:"deployments,omitempty"`
+	Mismatched  bool               `json:"mismatched"`
+}
+
+// StatusSource reports the state of the gateway's backend connections
+type StatusSource interface {
+	GetConnectionStatus() map[string]string
+	GetBreakerStates() map[string]gatewaygrpc.BreakerSnapshot
+}
+
+// StatusReporter combines the gateway's connection and circuit breaker state
+// with what the aggregator last heard from each service's health endpoint
+type StatusReporter struct {
+	source     StatusSource
+	aggregator *Aggregator
+	gateway    *sharedhealth.Checker
+}
+
+// NewStatusReporter creates a status reporter. aggregator may be nil when
+// service health is not checked.
+func NewStatusReporter(source StatusSource, aggregator *Aggregator) *StatusReporter {
+	return &StatusReporter{source: source, aggregator: aggregator}
+}
+
+// SetGateway sets the gateway's own checker, whose build is reported
+// alongside the services'
+func (r *StatusReporter) SetGateway(checker *sharedhealth.Checker) {
+	r.gateway = checker
+}
+
+// Status returns the current platform status and records the connection
+// states in the connection gauge
+func (r *StatusReporter) Status() *PlatformStatus {
+	connections := r.source.GetConnectionStatus()
+	breakers := r.source.GetBreakerStates()
+	var report *PlatformReport
+	if r.aggregator != nil {
+		report = r.aggregator.LastReport()
+	}
+
+	services := make(map[string]*ServiceStatus)
+	service := func(name string) *ServiceStatus {
+		if services[name] == nil {
+			services[name] = &ServiceStatus{Service: name, Connection: ConnectionNotConnected}
+		}
+		return services[name]
+	}
+	for name, state := range connections {
+		service(name).Connection = state
+	}
+	for name, breaker := range breakers {
+		breaker := breaker
+		service(name).CircuitBreaker = &breaker
+	}
+	if r.aggregator != nil {
+		for name := range r.aggregator.urls {
+			service(name)
+		}
+	}
+	if report != nil {
+		for name, result := range report.Services {
+			status := service(name)
+			status.Health = result.Status
+			status.HealthLatencyMs = result.LatencyMs
+			status.LastHealthCheck = &report.Timestamp
+			if result.Report != nil {
+				status.Version = result.Report.Version
+				status.Build = result.Report.Info
+			}
+		}
+	}
+
+	platform := &PlatformStatus{
+		Timestamp: time.Now().UTC(),
+		Services:  make([]ServiceStatus, 0, len(services)),
+	}
+	for _, status := range services {
+		platform.Services = append(platform.Services, *status)
+	}
+	sort.Slice(platform.Services, func(i, j int) bool {
+		return platform.Services[i].Service < platform.Services[j].Service
+	})
+	if r.gateway != nil {
+		platform.Gateway = r.gateway.Info()
+	}
+	platform.Deployments = deployments(platform.Gateway, platform.Services)
+	platform.Mismatched = len(platform.Deployments) > 1
+
+	recordConnectionStates(platform.Services)
+	return platform
+}
+
+// deployments groups the gateway and services by the git commit of their
+// build. Services that did not report one are left out.
+func deployments(gateway *sharedhealth.Info, services []ServiceStatus) []Deployment {
+	byCommit := make(map[string]*Deployment)
+	add := func(name string, build *sharedhealth.Info) {
+		if build == nil {
+			return
+		}
+		deployment := byCommit[build.GitCommit]
+		if deployment == nil {
+			deployment = &Deployment{GitCommit: build.GitCommit}
+			byCommit[build.GitCommit] = deployment
+		}
+		deployment.Services = append(deployment.Services, name)
+		for _, version := range deployment.Versions {
+			if version == build.Version {
+				return
+			}
+		}
+		deployment.Versions = append(deployment.Versions, build.Version)
+	}
+
+	if gateway != nil {
+		add(gateway.Service, gateway)
+	}
+	for _, service := range services {
+		add(service.Service, service.Build)
+	}
+
+	result := make([]Deployment, 0, len(byCommit))
+	for _, deployment := range byCommit {
+		sort.Strings(deployment.Versions)
+		result = append(result, *deployment)
+	}
+	// The commit most services run comes first
+	sort.Slice(result, func(i, j int) bool {
+		if len(result[i].Services) != len(result[j].Services) {
+			return len(result[i].Services) > len(result[j].Services)
+		}
+		return result[i].GitCommit < result[j].GitCommit
+	})
+	return result
+}
+
+// Watch checks service health every interval until ctx is cancelled, so the
+// status and the connection gauge stay fresh without /status being requested
+func (r *StatusReporter) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if r.aggregator != nil {
+			r.aggregator.Check(ctx)
+		}
+		r.Status()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// recordConnectionStates sets the connection gauge, including the states no
+// service is in so that they drop to zero
+func recordConnectionStates(services []ServiceStatus) {
+	counts := make(map[string]int, len(connectionStates))
+	for _, state := range connectionStates {
+		counts[state] = 0
+	}
+	for _, service := range services {
+		counts[service.Connection]++
+	}
+	for state, count := range counts {
+		connectionsByState.WithLabelValues(state).Set(float64(count))
+	}
+}
+
+// Handler serves the platform status document
+func (r *StatusReporter) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(r.Status())
+	})
+}
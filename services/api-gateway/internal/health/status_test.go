@@ -0,0 +1,96 @@
+package health
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	gatewaygrpc "github.com/rideshare-platform/services/api-gateway/internal/grpc"
+	sharedhealth "github.com/rideshare-platform/shared/health"
+)
+
+// fakeStatusSource reports fixed connection and circuit breaker states
+type fakeStatusSource struct {
+	connections map[string]string
+	breakers    map[string]gatewaygrpc.BreakerSnapshot
+}
+
+func (f fakeStatusSource) GetConnectionStatus() map[string]string {
+	return f.connections
+}
+
+func (f fakeStatusSource) GetBreakerStates() map[string]gatewaygrpc.BreakerSnapshot {
+	return f.breakers
+}
+
+func TestStatusReporterCombinesConnectionsAndHealth(t *testing.T) {
+	aggregator := NewAggregator(map[string]string{
+		"trip":    newServiceServer(t, sharedhealth.NewChecker("trip-service", "2.3.1")),
+		"payment": newServiceServer(t, sharedhealth.NewChecker("payment-service", "1.0.0")),
+	}, nil)
+	reporter := NewStatusReporter(fakeStatusSource{
+		connections: map[string]string{"trip": "READY", "geo": "TRANSIENT_FAILURE"},
+		breakers:    map[string]gatewaygrpc.BreakerSnapshot{"geo": {State: gatewaygrpc.BreakerOpen, ConsecutiveFailures: 5}},
+	}, aggregator)
+
+	before := reporter.Status()
+	for _, service := range before.Services {
+		if service.LastHealthCheck != nil {
+			t.Errorf("Expected no health check for %s before the first check, got %v", service.Service, service.LastHealthCheck)
+		}
+	}
+
+	aggregator.Check(context.Background())
+	status := reporter.Status()
+
+	if len(status.Services) != 3 {
+		t.Fatalf("Expected geo, payment and trip, got %+v", status.Services)
+	}
+	geo, payment, trip := status.Services[0], status.Services[1], status.Services[2]
+	if geo.Service != "geo" || payment.Service != "payment" || trip.Service != "trip" {
+		t.Fatalf("Expected services sorted by name, got %+v", status.Services)
+	}
+	if geo.CircuitBreaker == nil || geo.CircuitBreaker.State != gatewaygrpc.BreakerOpen {
+		t.Errorf("Expected geo's open circuit breaker, got %+v", geo.CircuitBreaker)
+	}
+	if payment.Connection != ConnectionNotConnected {
+		t.Errorf("Expected payment to have no connection, got %s", payment.Connection)
+	}
+	if trip.Connection != "READY" || trip.Version != "2.3.1" || trip.Health != sharedhealth.StatusHealthy || trip.LastHealthCheck == nil {
+		t.Errorf("Expected trip's connection, version and health check, got %+v", trip)
+	}
+
+	for state, want := range map[string]float64{"READY": 1, "TRANSIENT_FAILURE": 1, ConnectionNotConnected: 1, "IDLE": 0} {
+		if got := testutil.ToFloat64(connectionsByState.WithLabelValues(state)); got != want {
+			t.Errorf("Expected %v connections in state %s, got %v", want, state, got)
+		}
+	}
+}
+
+func TestDeploymentsFlagMismatchedCommits(t *testing.T) {
+	gateway := &sharedhealth.Info{Service: "api-gateway", Version: "1.4.0", GitCommit: "abc123"}
+	services := []ServiceStatus{
+		{Service: "geo"},
+		{Service: "payment", Build: &sharedhealth.Info{Service: "payment-service", Version: "1.3.9", GitCommit: "def456"}},
+		{Service: "trip", Build: &sharedhealth.Info{Service: "trip-service", Version: "1.4.0", GitCommit: "abc123"}},
+	}
+
+	deployments := deployments(gateway, services)
+	if len(deployments) != 2 {
+		t.Fatalf("Expected two deployments, got %+v", deployments)
+	}
+	current, stale := deployments[0], deployments[1]
+	if current.GitCommit != "abc123" || len(current.Services) != 2 || current.Services[0] != "api-gateway" || current.Services[1] != "trip" {
+		t.Errorf("Expected the gateway and trip on the most common commit, got %+v", current)
+	}
+	if stale.GitCommit != "def456" || len(stale.Services) != 1 || stale.Services[0] != "payment" || stale.Versions[0] != "1.3.9" {
+		t.Errorf("Expected payment on the stale commit, got %+v", stale)
+	}
+
+	reporter := NewStatusReporter(fakeStatusSource{connections: map[string]string{"trip": "READY"}}, nil)
+	reporter.SetGateway(sharedhealth.NewChecker("api-gateway", "1.0.0"))
+	status := reporter.Status()
+	if status.Gateway == nil || status.Gateway.Service != "api-gateway" || status.Mismatched {
+		t.Errorf("Expected the gateway's build and no mismatch, got %+v", status)
+	}
+}
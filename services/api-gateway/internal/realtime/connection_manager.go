@@ -0,0 +1,439 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/rideshare-platform/services/api-gateway/internal/api"
+	"github.com/rideshare-platform/shared/middleware"
+	"github.com/rideshare-platform/shared/utils"
+)
+
+// Message types sent over the authenticated socket at /ws
+const (
+	MessageTypeWelcome      = "welcome"
+	MessageTypeSubscribed   = "subscribed"
+	MessageTypeUnsubscribed = "unsubscribed"
+	MessageTypeEvent        = "event"
+)
+
+// TopicAction is a client's request to follow or stop following a topic
+type TopicAction struct {
+	Action string `json:"action"` // "subscribe" or "unsubscribe"
+	Topic  string `json:"topic"`
+}
+
+// TokenParser validates a bearer token and returns its claims
+type TokenParser interface {
+	ParseToken(tokenString string) (*middleware.AuthClaims, error)
+}
+
+// ConnectionConfig tunes the connection manager's heartbeats and sessions
+type ConnectionConfig struct {
+	// PingInterval is how often connections are pinged
+	PingInterval time.Duration
+	// PongTimeout is how long a connection may go without answering a ping
+	// or sending a message before it is closed
+	PongTimeout time.Duration
+	// ResumeTTL is how long after a disconnect a session's topics are
+	// resubscribed when the client reconnects with its session ID
+	ResumeTTL time.Duration
+	// MaxConnectionsPerUser limits a user's open connections across gateways
+	MaxConnectionsPerUser int
+	// MaxTopics limits the topics a connection subscribes to
+	MaxTopics int
+}
+
+// DefaultConnectionConfig returns the connection settings used when the
+// gateway configuration leaves them unset
+func DefaultConnectionConfig() ConnectionConfig {
+	return ConnectionConfig{
+		PingInterval:          30 * time.Second,
+		PongTimeout:           75 * time.Second,
+		ResumeTTL:             2 * time.Minute,
+		MaxConnectionsPerUser: 5,
+		MaxTopics:             20,
+	}
+}
+
+// ConnectionManager serves /ws, the apps' authenticated socket for
+// following topics such as trip:{trip_id} and driver:{driver_id}. Clients
+// authenticate the upgrade with their bearer token, in the Authorization
+// header or, for browsers, the access_token query parameter. Connections
+// are registered per user, pinged to detect dead peers and closed when they
+// stop answering. The welcome message carries a session ID; reconnecting
+// with ?session_id= within the resume TTL resubscribes the session's topics.
+type ConnectionManager struct {
+	auth     TokenParser
+	registry ConnectionRegistry
+	upgrader websocket.Upgrader
+	config   ConnectionConfig
+	sources  map[string]TopicSource
+}
+
+// NewConnectionManager creates a new connection manager
+func NewConnectionManager(auth TokenParser, registry ConnectionRegistry, upgrader websocket.Upgrader, config ConnectionConfig) *ConnectionManager {
+	return &ConnectionManager{
+		auth:     auth,
+		registry: registry,
+		upgrader: upgrader,
+		config:   config,
+		sources:  make(map[string]TopicSource),
+	}
+}
+
+// AddTopicSource serves the topics "{kind}:{id}" from source
+func (m *ConnectionManager) AddTopicSource(kind string, source TopicSource) {
+	m.sources[kind] = source
+}
+
+// connectionTTL is how long a connection stays registered without a
+// heartbeat
+func (m *ConnectionManager) connectionTTL() time.Duration {
+	return m.config.PingInterval + m.config.PongTimeout
+}
+
+// managedConnection is an open connection and its topic subscriptions
+type managedConnection struct {
+	conn   *socketConn
+	info   *ConnectionInfo
+	claims *middleware.AuthClaims
+
+	mutex         sync.Mutex
+	subscriptions map[string]context.CancelFunc
+}
+
+// topics returns the connection's subscribed topics in order
+func (c *managedConnection) topics() []string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	topics := make([]string, 0, len(c.subscriptions))
+	for topic := range c.subscriptions {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// drop removes a subscription and reports whether it existed
+func (c *managedConnection) drop(topic string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	cancel, ok := c.subscriptions[topic]
+	if ok {
+		cancel()
+		delete(c.subscriptions, topic)
+	}
+	return ok
+}
+
+// topicMessage is a message about one of the connection's topics
+type topicMessage struct {
+	Type  string          `json:"type"`
+	Topic string          `json:"topic"`
+	Event json.RawMessage `json:"event,omitempty"`
+	Error string          `json:"error,omitempty"`
+}
+
+// welcomeMessage is sent once a connection is registered. Clients whose
+// session was not resumed may have missed events and should reload what
+// they show.
+type welcomeMessage struct {
+	Type         string   `json:"type"`
+	ConnectionID string   `json:"connection_id,omitempty"`
+	SessionID    string   `json:"session_id"`
+	Topics       []string `json:"topics"`
+	Resumed      bool     `json:"resumed"`
+}
+
+func (c *socketConn) sendJSON(message interface{}) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.conn.WriteJSON(message)
+}
+
+func (c *socketConn) sendTopic(messageType, topic string, event json.RawMessage) error {
+	return c.sendJSON(topicMessage{Type: messageType, Topic: topic, Event: event})
+}
+
+func (c *socketConn) sendTopicError(topic string, err error) error {
+	return c.sendJSON(topicMessage{Type: MessageTypeError, Topic: topic, Error: err.Error()})
+}
+
+// authenticate returns the claims of the request's bearer token
+func (m *ConnectionManager) authenticate(r *http.Request) (*middleware.AuthClaims, *api.Error) {
+	token := r.URL.Query().Get("access_token")
+	if scheme, bearer, found := strings.Cut(r.Header.Get("Authorization"), " "); found && strings.EqualFold(scheme, "Bearer") {
+		token = bearer
+	}
+	if token == "" {
+		return nil, api.NewError(http.StatusUnauthorized, api.CodeUnauthorized, "bearer token required")
+	}
+
+	claims, err := m.auth.ParseToken(token)
+	if err != nil {
+		message := "invalid token"
+		if errors.Is(err, middleware.ErrTokenExpired) {
+			message = "token expired"
+		}
+		return nil, api.NewError(http.StatusUnauthorized, api.CodeUnauthorized, message)
+	}
+	return claims, nil
+}
+
+// ServeHTTP authenticates and upgrades the request, then serves the
+// connection until the client leaves or stops answering pings
+func (m *ConnectionManager) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	claims, authErr := m.authenticate(r)
+	if authErr != nil {
+		api.WriteError(w, authErr)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	open, err := m.registry.UserConnections(ctx, claims.UserID)
+	if err != nil {
+		log.Printf("Failed to list connections of user %s: %v", claims.UserID, err)
+		api.WriteError(w, api.NewError(http.StatusServiceUnavailable, api.CodeServiceUnavailable, "connection registry unavailable"))
+		return
+	}
+	if m.config.MaxConnectionsPerUser > 0 && len(open) >= m.config.MaxConnectionsPerUser {
+		api.WriteError(w, api.NewError(http.StatusTooManyRequests, api.CodeTooManyRequests, "too many open connections"))
+		return
+	}
+
+	// Only the user who opened a session may resume it
+	sessionID := r.URL.Query().Get("session_id")
+	var resumed []string
+	resumedSession := false
+	if sessionID != "" {
+		session, err := m.registry.GetSession(ctx, sessionID)
+		switch {
+		case err == nil && session.UserID == claims.UserID:
+			resumed = session.Topics
+			resumedSession = true
+		case err != nil && !errors.Is(err, ErrSessionNotFound):
+			log.Printf("Failed to load session %s: %v", sessionID, err)
+			sessionID = ""
+		default:
+			sessionID = ""
+		}
+	}
+	if sessionID == "" {
+		sessionID = utils.GenerateSessionID()
+	}
+
+	ws, err := m.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("Failed to upgrade WebSocket: %v", err)
+		return
+	}
+	defer ws.Close()
+
+	c := &managedConnection{
+		conn: &socketConn{conn: ws},
+		info: &ConnectionInfo{
+			ID:          utils.GenerateID(),
+			UserID:      claims.UserID,
+			UserType:    claims.UserType,
+			SessionID:   sessionID,
+			ConnectedAt: time.Now().UTC(),
+		},
+		claims:        claims,
+		subscriptions: make(map[string]context.CancelFunc),
+	}
+
+	if err := m.registry.Register(ctx, c.info, m.connectionTTL()); err != nil {
+		log.Printf("Failed to register connection of user %s: %v", claims.UserID, err)
+		c.conn.sendError("connection registry unavailable")
+		return
+	}
+	defer m.disconnect(c)
+
+	// A connection that neither answers pings nor sends anything within
+	// the pong timeout is dead
+	ws.SetReadDeadline(time.Now().Add(m.config.PongTimeout))
+	ws.SetPongHandler(func(string) error {
+		ws.SetReadDeadline(time.Now().Add(m.config.PongTimeout))
+		m.heartbeat(ctx, c)
+		return nil
+	})
+	go m.ping(ctx, c)
+
+	c.conn.sendJSON(welcomeMessage{
+		Type:         MessageTypeWelcome,
+		ConnectionID: c.info.ID,
+		SessionID:    sessionID,
+		Topics:       resumed,
+		Resumed:      resumedSession,
+	})
+	for _, topic := range resumed {
+		m.subscribe(ctx, c, topic)
+	}
+	m.saveSession(ctx, c, m.config.PingInterval+m.config.ResumeTTL)
+
+	for {
+		var action TopicAction
+		if err := ws.ReadJSON(&action); err != nil {
+			if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				log.Printf("WebSocket connection %s of user %s closed: %v", c.info.ID, claims.UserID, err)
+			}
+			return
+		}
+		ws.SetReadDeadline(time.Now().Add(m.config.PongTimeout))
+
+		switch action.Action {
+		case "subscribe":
+			m.subscribe(ctx, c, action.Topic)
+		case "unsubscribe":
+			if c.drop(action.Topic) {
+				m.saveSession(ctx, c, m.config.PingInterval+m.config.ResumeTTL)
+			}
+			c.conn.sendTopic(MessageTypeUnsubscribed, action.Topic, nil)
+		default:
+			c.conn.sendError("unknown action: " + action.Action)
+		}
+	}
+}
+
+// subscribe opens a topic's subscription and forwards its events until the
+// topic is unsubscribed, its stream ends or the connection closes
+func (m *ConnectionManager) subscribe(ctx context.Context, c *managedConnection, topic string) {
+	kind, id, ok := splitTopic(topic)
+	source := m.sources[kind]
+	if !ok || source == nil {
+		c.conn.sendTopicError(topic, ErrUnknownTopic)
+		return
+	}
+
+	c.mutex.Lock()
+	_, subscribed := c.subscriptions[topic]
+	count := len(c.subscriptions)
+	c.mutex.Unlock()
+	if subscribed {
+		c.conn.sendTopic(MessageTypeSubscribed, topic, nil)
+		return
+	}
+	if m.config.MaxTopics > 0 && count >= m.config.MaxTopics {
+		c.conn.sendTopicError(topic, errors.New("too many topics"))
+		return
+	}
+
+	if err := source.Authorize(ctx, c.claims, id); err != nil {
+		c.conn.sendTopicError(topic, err)
+		return
+	}
+	topicCtx, cancel := context.WithCancel(ctx)
+	stream, err := source.Open(topicCtx, c.claims, id)
+	if err != nil {
+		cancel()
+		c.conn.sendTopicError(topic, err)
+		return
+	}
+
+	c.mutex.Lock()
+	c.subscriptions[topic] = cancel
+	c.mutex.Unlock()
+	m.saveSession(ctx, c, m.config.PingInterval+m.config.ResumeTTL)
+	c.conn.sendTopic(MessageTypeSubscribed, topic, nil)
+
+	go func() {
+		for {
+			event, err := stream.Recv()
+			if err != nil {
+				if topicCtx.Err() == nil {
+					log.Printf("Stream of topic %s for connection %s closed: %v", topic, c.info.ID, err)
+					c.drop(topic)
+					m.saveSession(ctx, c, m.config.PingInterval+m.config.ResumeTTL)
+					c.conn.sendTopic(MessageTypeUnsubscribed, topic, nil)
+				}
+				return
+			}
+			if err := c.conn.sendTopic(MessageTypeEvent, topic, event); err != nil {
+				log.Printf("WebSocket write error: %v", err)
+				return
+			}
+		}
+	}()
+}
+
+// ping pings the connection every ping interval until it closes
+func (m *ConnectionManager) ping(ctx context.Context, c *managedConnection) {
+	ticker := time.NewTicker(m.config.PingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deadline := time.Now().Add(m.config.PongTimeout)
+			if err := c.conn.conn.WriteControl(websocket.PingMessage, nil, deadline); err != nil {
+				c.conn.conn.Close()
+				return
+			}
+		}
+	}
+}
+
+// heartbeat keeps an answering connection and its session registered
+func (m *ConnectionManager) heartbeat(ctx context.Context, c *managedConnection) {
+	if err := m.registry.Refresh(ctx, c.info, m.connectionTTL()); err != nil {
+		log.Printf("Failed to refresh connection %s: %v", c.info.ID, err)
+	}
+	m.saveSession(ctx, c, m.config.PingInterval+m.config.ResumeTTL)
+}
+
+// saveSession stores the connection's current topics for ttl
+func (m *ConnectionManager) saveSession(ctx context.Context, c *managedConnection, ttl time.Duration) {
+	session := &Session{ID: c.info.SessionID, UserID: c.info.UserID, Topics: c.topics()}
+	if err := m.registry.SaveSession(ctx, session, ttl); err != nil {
+		log.Printf("Failed to save session %s: %v", session.ID, err)
+	}
+}
+
+// disconnect unregisters a closed connection and keeps its session for the
+// resume TTL
+func (m *ConnectionManager) disconnect(c *managedConnection) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	m.saveSession(ctx, c, m.config.ResumeTTL)
+	if err := m.registry.Unregister(ctx, c.info); err != nil {
+		log.Printf("Failed to unregister connection %s: %v", c.info.ID, err)
+	}
+}
+
+// StartPruning removes expired connections from the registry every
+// interval until ctx is cancelled
+func (m *ConnectionManager) StartPruning(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			pruned, err := m.registry.Prune(ctx)
+			if err != nil {
+				log.Printf("Failed to prune WebSocket connections: %v", err)
+			} else if pruned > 0 {
+				log.Printf("Pruned %d expired WebSocket connections", pruned)
+			}
+		}
+	}
+}
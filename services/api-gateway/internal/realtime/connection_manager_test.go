@@ -0,0 +1,251 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/rideshare-platform/shared/middleware"
+)
+
+const testSecret = "test-secret"
+
+// fakeTopicSource serves every topic ID but "forbidden" and publishes the
+// events pushed to it to the open subscriptions
+type fakeTopicSource struct {
+	mutex   sync.Mutex
+	streams map[string][]chan json.RawMessage
+}
+
+func (s *fakeTopicSource) Authorize(ctx context.Context, claims *middleware.AuthClaims, id string) error {
+	if id == "forbidden" {
+		return ErrTopicForbidden
+	}
+	return nil
+}
+
+func (s *fakeTopicSource) Open(ctx context.Context, claims *middleware.AuthClaims, id string) (TopicStream, error) {
+	events := make(chan json.RawMessage, 1)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.streams == nil {
+		s.streams = make(map[string][]chan json.RawMessage)
+	}
+	s.streams[id] = append(s.streams[id], events)
+	return &fakeTopicStream{ctx: ctx, events: events}, nil
+}
+
+func (s *fakeTopicSource) publish(id string, event json.RawMessage) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, events := range s.streams[id] {
+		select {
+		case events <- event:
+		default:
+		}
+	}
+}
+
+type fakeTopicStream struct {
+	ctx    context.Context
+	events chan json.RawMessage
+}
+
+func (s *fakeTopicStream) Recv() (json.RawMessage, error) {
+	select {
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	case event := <-s.events:
+		return event, nil
+	}
+}
+
+type testMessage struct {
+	Type         string                 `json:"type"`
+	Topic        string                 `json:"topic"`
+	Error        string                 `json:"error"`
+	ConnectionID string                 `json:"connection_id"`
+	SessionID    string                 `json:"session_id"`
+	Topics       []string               `json:"topics"`
+	Event        map[string]interface{} `json:"event"`
+}
+
+func newTestManager(t *testing.T, config ConnectionConfig) (*httptest.Server, *MemoryConnectionRegistry, *fakeTopicSource) {
+	t.Helper()
+	registry := NewMemoryConnectionRegistry()
+	source := &fakeTopicSource{}
+	manager := NewConnectionManager(middleware.NewAuthMiddleware(testSecret, nil), registry, websocket.Upgrader{}, config)
+	manager.AddTopicSource("trip", source)
+
+	server := httptest.NewServer(manager)
+	t.Cleanup(server.Close)
+	return server, registry, source
+}
+
+func testUserToken(t *testing.T, userID string) string {
+	t.Helper()
+	token, err := middleware.NewAuthMiddleware(testSecret, nil).GenerateToken(userID, "rider", userID+"@example.com", 1)
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+	return token
+}
+
+func dial(t *testing.T, server *httptest.Server, query string) (*websocket.Conn, *http.Response, error) {
+	t.Helper()
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws?" + query
+	conn, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	if conn != nil {
+		t.Cleanup(func() { conn.Close() })
+	}
+	return conn, resp, err
+}
+
+func read(t *testing.T, conn *websocket.Conn) testMessage {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var message testMessage
+	if err := conn.ReadJSON(&message); err != nil {
+		t.Fatalf("Failed to read message: %v", err)
+	}
+	return message
+}
+
+func waitFor(t *testing.T, condition func() bool, message string) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal(message)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestConnectionManager_RejectsUnauthenticatedUpgrades(t *testing.T) {
+	config := DefaultConnectionConfig()
+	config.MaxConnectionsPerUser = 1
+	server, _, _ := newTestManager(t, config)
+
+	tests := []struct {
+		name   string
+		query  string
+		status int
+	}{
+		{"no token", "", http.StatusUnauthorized},
+		{"invalid token", "access_token=not-a-token", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, resp, err := dial(t, server, tt.query)
+			if err == nil {
+				t.Fatal("Expected the upgrade to be rejected")
+			}
+			if resp == nil || resp.StatusCode != tt.status {
+				t.Fatalf("Expected status %d, got %v", tt.status, resp)
+			}
+		})
+	}
+
+	token := testUserToken(t, "rider-1")
+	conn, _, err := dial(t, server, "access_token="+token)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	read(t, conn)
+
+	_, resp, err := dial(t, server, "access_token="+token)
+	if err == nil || resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("Expected a second connection to be rejected with 429, got %v", resp)
+	}
+}
+
+func TestConnectionManager_ResubscribesOnReconnect(t *testing.T) {
+	server, registry, source := newTestManager(t, DefaultConnectionConfig())
+	token := testUserToken(t, "rider-1")
+
+	conn, _, err := dial(t, server, "access_token="+token)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	welcome := read(t, conn)
+	if welcome.Type != MessageTypeWelcome || welcome.SessionID == "" || len(welcome.Topics) != 0 {
+		t.Fatalf("Unexpected welcome message: %+v", welcome)
+	}
+	connections, _ := registry.UserConnections(context.Background(), "rider-1")
+	if len(connections) != 1 || connections[0].ID != welcome.ConnectionID {
+		t.Fatalf("Expected the connection to be registered, got %+v", connections)
+	}
+
+	conn.WriteJSON(TopicAction{Action: "subscribe", Topic: "trip:trip-1"})
+	if message := read(t, conn); message.Type != MessageTypeSubscribed || message.Topic != "trip:trip-1" {
+		t.Fatalf("Expected the subscription to be confirmed, got %+v", message)
+	}
+	conn.WriteJSON(TopicAction{Action: "subscribe", Topic: "trip:forbidden"})
+	if message := read(t, conn); message.Type != MessageTypeError || message.Error != ErrTopicForbidden.Error() {
+		t.Fatalf("Expected the subscription to be refused, got %+v", message)
+	}
+	conn.WriteJSON(TopicAction{Action: "subscribe", Topic: "chat:trip-1"})
+	if message := read(t, conn); message.Type != MessageTypeError || message.Error != ErrUnknownTopic.Error() {
+		t.Fatalf("Expected the topic to be unknown, got %+v", message)
+	}
+
+	source.publish("trip-1", json.RawMessage(`{"tripId":"trip-1"}`))
+	if message := read(t, conn); message.Type != MessageTypeEvent || message.Event["tripId"] != "trip-1" {
+		t.Fatalf("Expected the trip's event, got %+v", message)
+	}
+
+	conn.Close()
+	waitFor(t, func() bool {
+		connections, _ := registry.UserConnections(context.Background(), "rider-1")
+		return len(connections) == 0
+	}, "Expected the closed connection to be unregistered")
+
+	// Another user cannot take over the session
+	other, _, err := dial(t, server, "access_token="+testUserToken(t, "rider-2")+"&session_id="+welcome.SessionID)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	if message := read(t, other); message.SessionID == welcome.SessionID || len(message.Topics) != 0 {
+		t.Fatalf("Expected a new session for another user, got %+v", message)
+	}
+
+	resumed, _, err := dial(t, server, "access_token="+token+"&session_id="+welcome.SessionID)
+	if err != nil {
+		t.Fatalf("Failed to reconnect: %v", err)
+	}
+	message := read(t, resumed)
+	if message.SessionID != welcome.SessionID || len(message.Topics) != 1 || message.Topics[0] != "trip:trip-1" {
+		t.Fatalf("Expected the session's topics to be resumed, got %+v", message)
+	}
+	if message := read(t, resumed); message.Type != MessageTypeSubscribed || message.Topic != "trip:trip-1" {
+		t.Fatalf("Expected the topic to be resubscribed, got %+v", message)
+	}
+}
+
+func TestConnectionManager_ClosesConnectionsWithoutHeartbeats(t *testing.T) {
+	config := DefaultConnectionConfig()
+	config.PingInterval = 20 * time.Millisecond
+	config.PongTimeout = 100 * time.Millisecond
+	server, registry, _ := newTestManager(t, config)
+
+	conn, _, err := dial(t, server, "access_token="+testUserToken(t, "rider-1"))
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	read(t, conn)
+
+	// Pongs are only sent while the client reads, so a client that stops
+	// reading stops answering pings
+	waitFor(t, func() bool {
+		connections, _ := registry.UserConnections(context.Background(), "rider-1")
+		return len(connections) == 0
+	}, "Expected the silent connection to be closed and unregistered")
+}
@@ -0,0 +1,347 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// connectionsKey is the set of every open connection's ID, which the
+// platform metrics collector reports as the WebSocket connection gauge. The
+// {websocket} hash tag keeps it in one Redis Cluster slot with the
+// connection and user keys it is updated with in a transaction.
+const connectionsKey = "{websocket}:connections"
+
+// ConnectionInfo describes an authenticated connection to /ws
+type ConnectionInfo struct {
+	ID          string    `json:"id"`
+	UserID      string    `json:"user_id"`
+	UserType    string    `json:"user_type"`
+	SessionID   string    `json:"session_id"`
+	ConnectedAt time.Time `json:"connected_at"`
+}
+
+// Session is what a client resumes when it reconnects with its session ID:
+// the user it belongs to and the topics it was subscribed to
+type Session struct {
+	ID       string   `json:"id"`
+	UserID   string   `json:"user_id"`
+	UserType string   `json:"user_type,omitempty"`
+	Topics   []string `json:"topics"`
+}
+
+// ErrSessionNotFound is returned for sessions that expired or never existed
+var ErrSessionNotFound = errors.New("session not found")
+
+// ConnectionRegistry tracks the open connections of every user and the
+// sessions they can resume. Connections and sessions expire unless they are
+// refreshed, so gateways that stop without unregistering don't leave
+// connections behind.
+type ConnectionRegistry interface {
+	// Register records an open connection for ttl
+	Register(ctx context.Context, conn *ConnectionInfo, ttl time.Duration) error
+	// Refresh extends an open connection's registration to ttl
+	Refresh(ctx context.Context, conn *ConnectionInfo, ttl time.Duration) error
+	// Unregister removes a closed connection
+	Unregister(ctx context.Context, conn *ConnectionInfo) error
+	// UserConnections returns a user's open connections
+	UserConnections(ctx context.Context, userID string) ([]*ConnectionInfo, error)
+	// SaveSession stores a session for ttl
+	SaveSession(ctx context.Context, session *Session, ttl time.Duration) error
+	// GetSession returns a session that has not expired
+	GetSession(ctx context.Context, sessionID string) (*Session, error)
+	// Prune removes connections whose registration expired and returns how
+	// many were removed
+	Prune(ctx context.Context) (int, error)
+}
+
+// RedisConnectionRegistry keeps the registry in Redis, shared by every
+// gateway instance. Each connection is a key expiring with its registration,
+// listed in the connections set and in its user's own set.
+type RedisConnectionRegistry struct {
+	redis redis.UniversalClient
+}
+
+// NewRedisConnectionRegistry creates a connection registry in Redis
+func NewRedisConnectionRegistry(client redis.UniversalClient) *RedisConnectionRegistry {
+	return &RedisConnectionRegistry{redis: client}
+}
+
+func connectionKey(connectionID string) string {
+	return "{websocket}:connection:" + connectionID
+}
+
+func userConnectionsKey(userID string) string {
+	return "{websocket}:user:" + userID + ":connections"
+}
+
+func sessionKey(sessionID string) string {
+	return "websocket:session:" + sessionID
+}
+
+// Register records an open connection for ttl
+func (r *RedisConnectionRegistry) Register(ctx context.Context, conn *ConnectionInfo, ttl time.Duration) error {
+	data, err := json.Marshal(conn)
+	if err != nil {
+		return err
+	}
+
+	pipe := r.redis.TxPipeline()
+	pipe.Set(ctx, connectionKey(conn.ID), data, ttl)
+	pipe.SAdd(ctx, connectionsKey, conn.ID)
+	pipe.SAdd(ctx, userConnectionsKey(conn.UserID), conn.ID)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to register connection %s: %w", conn.ID, err)
+	}
+	return nil
+}
+
+// Refresh extends an open connection's registration to ttl, registering it
+// again if it already expired
+func (r *RedisConnectionRegistry) Refresh(ctx context.Context, conn *ConnectionInfo, ttl time.Duration) error {
+	refreshed, err := r.redis.Expire(ctx, connectionKey(conn.ID), ttl).Result()
+	if err != nil {
+		return fmt.Errorf("failed to refresh connection %s: %w", conn.ID, err)
+	}
+	if !refreshed {
+		return r.Register(ctx, conn, ttl)
+	}
+	return nil
+}
+
+// Unregister removes a closed connection
+func (r *RedisConnectionRegistry) Unregister(ctx context.Context, conn *ConnectionInfo) error {
+	pipe := r.redis.TxPipeline()
+	pipe.Del(ctx, connectionKey(conn.ID))
+	pipe.SRem(ctx, connectionsKey, conn.ID)
+	pipe.SRem(ctx, userConnectionsKey(conn.UserID), conn.ID)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to unregister connection %s: %w", conn.ID, err)
+	}
+	return nil
+}
+
+// UserConnections returns a user's open connections, dropping the expired
+// ones from the user's set
+func (r *RedisConnectionRegistry) UserConnections(ctx context.Context, userID string) ([]*ConnectionInfo, error) {
+	ids, err := r.redis.SMembers(ctx, userConnectionsKey(userID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list connections of user %s: %w", userID, err)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = connectionKey(id)
+	}
+	values, err := r.redis.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load connections of user %s: %w", userID, err)
+	}
+
+	var connections []*ConnectionInfo
+	var expired []interface{}
+	for i, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			expired = append(expired, ids[i])
+			continue
+		}
+		var conn ConnectionInfo
+		if err := json.Unmarshal([]byte(data), &conn); err != nil {
+			return nil, fmt.Errorf("failed to decode connection %s: %w", ids[i], err)
+		}
+		connections = append(connections, &conn)
+	}
+	if len(expired) > 0 {
+		r.redis.SRem(ctx, userConnectionsKey(userID), expired...)
+	}
+	return connections, nil
+}
+
+// SaveSession stores a session for ttl
+func (r *RedisConnectionRegistry) SaveSession(ctx context.Context, session *Session, ttl time.Duration) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	if err := r.redis.Set(ctx, sessionKey(session.ID), data, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to save session %s: %w", session.ID, err)
+	}
+	return nil
+}
+
+// GetSession returns a session that has not expired
+func (r *RedisConnectionRegistry) GetSession(ctx context.Context, sessionID string) (*Session, error) {
+	data, err := r.redis.Get(ctx, sessionKey(sessionID)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load session %s: %w", sessionID, err)
+	}
+
+	var session Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("failed to decode session %s: %w", sessionID, err)
+	}
+	return &session, nil
+}
+
+// Prune removes the connections whose key expired from the connections
+// set, so the gauge doesn't count connections of gateways that stopped
+// without unregistering them. Users' sets are pruned as they are read.
+func (r *RedisConnectionRegistry) Prune(ctx context.Context) (int, error) {
+	ids, err := r.redis.SMembers(ctx, connectionsKey).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list connections: %w", err)
+	}
+
+	var expired []interface{}
+	for _, id := range ids {
+		exists, err := r.redis.Exists(ctx, connectionKey(id)).Result()
+		if err != nil {
+			return 0, fmt.Errorf("failed to check connection %s: %w", id, err)
+		}
+		if exists == 0 {
+			expired = append(expired, id)
+		}
+	}
+	if len(expired) == 0 {
+		return 0, nil
+	}
+	if err := r.redis.SRem(ctx, connectionsKey, expired...).Err(); err != nil {
+		return 0, fmt.Errorf("failed to prune connections: %w", err)
+	}
+	return len(expired), nil
+}
+
+// MemoryConnectionRegistry keeps the registry in memory, for a single
+// gateway instance
+type MemoryConnectionRegistry struct {
+	mutex       sync.Mutex
+	connections map[string]memoryEntry
+	sessions    map[string]memoryEntry
+	now         func() time.Time
+}
+
+type memoryEntry struct {
+	data      []byte
+	expiresAt time.Time
+}
+
+// NewMemoryConnectionRegistry creates an in-memory connection registry
+func NewMemoryConnectionRegistry() *MemoryConnectionRegistry {
+	return &MemoryConnectionRegistry{
+		connections: make(map[string]memoryEntry),
+		sessions:    make(map[string]memoryEntry),
+		now:         time.Now,
+	}
+}
+
+func (r *MemoryConnectionRegistry) live(entry memoryEntry) bool {
+	return r.now().Before(entry.expiresAt)
+}
+
+// Register records an open connection for ttl
+func (r *MemoryConnectionRegistry) Register(ctx context.Context, conn *ConnectionInfo, ttl time.Duration) error {
+	data, err := json.Marshal(conn)
+	if err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.connections[conn.ID] = memoryEntry{data: data, expiresAt: r.now().Add(ttl)}
+	return nil
+}
+
+// Refresh extends an open connection's registration to ttl
+func (r *MemoryConnectionRegistry) Refresh(ctx context.Context, conn *ConnectionInfo, ttl time.Duration) error {
+	return r.Register(ctx, conn, ttl)
+}
+
+// Unregister removes a closed connection
+func (r *MemoryConnectionRegistry) Unregister(ctx context.Context, conn *ConnectionInfo) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	delete(r.connections, conn.ID)
+	return nil
+}
+
+// UserConnections returns a user's open connections
+func (r *MemoryConnectionRegistry) UserConnections(ctx context.Context, userID string) ([]*ConnectionInfo, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var connections []*ConnectionInfo
+	for _, entry := range r.connections {
+		if !r.live(entry) {
+			continue
+		}
+		var conn ConnectionInfo
+		if err := json.Unmarshal(entry.data, &conn); err != nil {
+			return nil, err
+		}
+		if conn.UserID == userID {
+			connections = append(connections, &conn)
+		}
+	}
+	return connections, nil
+}
+
+// SaveSession stores a session for ttl
+func (r *MemoryConnectionRegistry) SaveSession(ctx context.Context, session *Session, ttl time.Duration) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.sessions[session.ID] = memoryEntry{data: data, expiresAt: r.now().Add(ttl)}
+	return nil
+}
+
+// GetSession returns a session that has not expired
+func (r *MemoryConnectionRegistry) GetSession(ctx context.Context, sessionID string) (*Session, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	entry, ok := r.sessions[sessionID]
+	if !ok || !r.live(entry) {
+		return nil, ErrSessionNotFound
+	}
+	var session Session
+	if err := json.Unmarshal(entry.data, &session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// Prune removes expired connections and sessions
+func (r *MemoryConnectionRegistry) Prune(ctx context.Context) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	pruned := 0
+	for id, entry := range r.connections {
+		if !r.live(entry) {
+			delete(r.connections, id)
+			pruned++
+		}
+	}
+	for id, entry := range r.sessions {
+		if !r.live(entry) {
+			delete(r.sessions, id)
+		}
+	}
+	return pruned, nil
+}
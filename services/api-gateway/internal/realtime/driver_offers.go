@@ -0,0 +1,231 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"sync"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/rideshare-platform/services/api-gateway/internal/api"
+	"github.com/rideshare-platform/services/api-gateway/internal/grpc"
+	matchingpb "github.com/rideshare-platform/shared/proto/matching"
+	trippb "github.com/rideshare-platform/shared/proto/trip"
+)
+
+// Message types sent to drivers over the driver socket
+const (
+	MessageTypeOffer         = "offer"
+	MessageTypeOfferAccepted = "offer_accepted"
+	MessageTypeOfferDeclined = "offer_declined"
+	MessageTypeTripAssigned  = "trip_assigned"
+	MessageTypeTripCancelled = "trip_cancelled"
+	MessageTypeRiderLocation = "rider_location"
+	MessageTypeLostItem      = "lost_item_reported"
+	MessageTypeError         = "error"
+)
+
+// driverEventMessageTypes maps trip-service driver events to socket messages
+var driverEventMessageTypes = map[trippb.DriverEventType]string{
+	trippb.DriverEventType_DRIVER_EVENT_TRIP_ASSIGNED:      MessageTypeTripAssigned,
+	trippb.DriverEventType_DRIVER_EVENT_TRIP_CANCELLED:     MessageTypeTripCancelled,
+	trippb.DriverEventType_DRIVER_EVENT_RIDER_LOCATION:     MessageTypeRiderLocation,
+	trippb.DriverEventType_DRIVER_EVENT_LOST_ITEM_REPORTED: MessageTypeLostItem,
+}
+
+// DriverAction is a driver's response to an offer sent over the socket
+type DriverAction struct {
+	Action string `json:"action"` // "accept" or "decline"
+	TripID string `json:"trip_id"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// DriverOfferSocket is the driver app's one connection for real-time events.
+// Offers from the matching service and the driver's trip assignments,
+// cancellations, rider locations and lost item reports from trip-service
+// are streamed to the driver, and accept/decline responses are relayed back over gRPC.
+type DriverOfferSocket struct {
+	clients  *grpc.ClientManager
+	upgrader websocket.Upgrader
+}
+
+// NewDriverOfferSocket creates a new driver offer socket handler
+func NewDriverOfferSocket(clients *grpc.ClientManager, upgrader websocket.Upgrader) *DriverOfferSocket {
+	return &DriverOfferSocket{
+		clients:  clients,
+		upgrader: upgrader,
+	}
+}
+
+// socketConn serializes writes to a WebSocket connection
+type socketConn struct {
+	conn  *websocket.Conn
+	mutex sync.Mutex
+}
+
+func (c *socketConn) send(messageType string, field string, payload proto.Message) error {
+	message := map[string]interface{}{"type": messageType}
+	if payload != nil {
+		data, err := protojson.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		message[field] = json.RawMessage(data)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.conn.WriteJSON(message)
+}
+
+func (c *socketConn) sendError(message string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.conn.WriteJSON(map[string]string{"type": MessageTypeError, "error": message})
+}
+
+// ServeHTTP upgrades the request and serves the events of the driver in the
+// URL. Without trip-service only offers are served.
+func (s *DriverOfferSocket) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	driverID := mux.Vars(r)["driver_id"]
+	if driverID == "" {
+		invalid := api.NewError(http.StatusBadRequest, api.CodeValidationFailed, "request validation failed")
+		invalid.Details = []api.FieldError{{Field: "driver_id", Message: "is required"}}
+		api.WriteError(w, invalid)
+		return
+	}
+
+	matching := s.clients.MatchingClient
+	if matching == nil {
+		api.WriteError(w, api.ServiceUnavailable("matching"))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := matching.StreamDriverOffers(ctx, &matchingpb.StreamDriverOffersRequest{DriverId: driverID})
+	if err != nil {
+		log.Printf("Failed to open offer stream for driver %s: %v", driverID, err)
+		api.WriteError(w, api.FromGRPC("matching", err))
+		return
+	}
+
+	var driverEvents trippb.TripService_SubscribeDriverEventsClient
+	if trips := s.clients.TripClient; trips != nil {
+		driverEvents, err = trips.SubscribeDriverEvents(ctx, &trippb.SubscribeDriverEventsRequest{DriverId: driverID})
+		if err != nil {
+			log.Printf("Failed to open trip event stream for driver %s: %v", driverID, err)
+			api.WriteError(w, api.FromGRPC("trip", err))
+			return
+		}
+	}
+
+	ws, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("Failed to upgrade WebSocket: %v", err)
+		return
+	}
+	defer ws.Close()
+
+	conn := &socketConn{conn: ws}
+
+	// Forward trip events from trip-service until either side goes away
+	if driverEvents != nil {
+		go func() {
+			defer cancel()
+			for {
+				event, err := driverEvents.Recv()
+				if err != nil {
+					if ctx.Err() == nil {
+						log.Printf("Trip event stream for driver %s closed: %v", driverID, err)
+					}
+					ws.Close()
+					return
+				}
+				messageType, ok := driverEventMessageTypes[event.Type]
+				if !ok {
+					continue
+				}
+				if err := conn.send(messageType, "event", event); err != nil {
+					log.Printf("WebSocket write error: %v", err)
+					return
+				}
+			}
+		}()
+	}
+
+	// Forward offers from the matching service until either side goes away
+	go func() {
+		defer cancel()
+		for {
+			offer, err := stream.Recv()
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Printf("Offer stream for driver %s closed: %v", driverID, err)
+				}
+				ws.Close()
+				return
+			}
+			if err := conn.send(MessageTypeOffer, "offer", offer); err != nil {
+				log.Printf("WebSocket write error: %v", err)
+				return
+			}
+		}
+	}()
+
+	for {
+		var action DriverAction
+		if err := ws.ReadJSON(&action); err != nil {
+			if ctx.Err() == nil && !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				log.Printf("WebSocket read error: %v", err)
+			}
+			return
+		}
+
+		s.handleAction(ctx, conn, matching, driverID, &action)
+	}
+}
+
+// handleAction relays a driver's accept or decline to the matching service
+func (s *DriverOfferSocket) handleAction(ctx context.Context, conn *socketConn, matching matchingpb.MatchingServiceClient, driverID string, action *DriverAction) {
+	if action.TripID == "" {
+		conn.sendError("trip_id is required")
+		return
+	}
+
+	callCtx, cancel := s.clients.WithTimeout(ctx, "matching")
+	defer cancel()
+
+	switch action.Action {
+	case "accept":
+		resp, err := matching.AcceptOffer(callCtx, &matchingpb.AcceptOfferRequest{
+			TripId:   action.TripID,
+			DriverId: driverID,
+		})
+		if err != nil {
+			conn.sendError(err.Error())
+			return
+		}
+		conn.send(MessageTypeOfferAccepted, "offer", resp.Offer)
+	case "decline":
+		_, err := matching.DeclineOffer(callCtx, &matchingpb.DeclineOfferRequest{
+			TripId:   action.TripID,
+			DriverId: driverID,
+			Reason:   action.Reason,
+		})
+		if err != nil {
+			conn.sendError(err.Error())
+			return
+		}
+		// The next offer belongs to another driver, so only confirm the decline
+		conn.send(MessageTypeOfferDeclined, "", nil)
+	default:
+		conn.sendError("unknown action: " + action.Action)
+	}
+}
@@ -0,0 +1,333 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// BufferedEvent is a message kept in an event stream session's buffer
+type BufferedEvent struct {
+	ID   string
+	Type string
+	Data json.RawMessage
+}
+
+// EventBuffer keeps the recent events of each event stream session, so
+// clients that reconnect with the ID of the last event they received are
+// sent the events they missed. One writer at a time follows a session's
+// topics and appends their events.
+type EventBuffer interface {
+	// SaveSession stores a session for ttl
+	SaveSession(ctx context.Context, session *Session, ttl time.Duration) error
+	// GetSession returns a session that has not expired
+	GetSession(ctx context.Context, sessionID string) (*Session, error)
+	// ClaimWriter makes owner the session's writer for ttl unless another
+	// owner holds it, and reports whether owner is the writer
+	ClaimWriter(ctx context.Context, sessionID, owner string, ttl time.Duration) (bool, error)
+	// ReleaseWriter gives up owner's claim on the session
+	ReleaseWriter(ctx context.Context, sessionID, owner string) error
+	// Append adds an event to the session's buffer, which is kept for ttl,
+	// and returns the event's ID
+	Append(ctx context.Context, sessionID string, event *BufferedEvent, ttl time.Duration) (string, error)
+	// Read returns the session's events after the event with afterID, "0"
+	// for all of them, waiting up to block for one to be appended
+	Read(ctx context.Context, sessionID, afterID string, block time.Duration) ([]*BufferedEvent, error)
+}
+
+// RedisEventBuffer keeps each session's events in a capped Redis stream,
+// shared by every gateway instance
+type RedisEventBuffer struct {
+	redis  redis.UniversalClient
+	maxLen int64
+}
+
+// NewRedisEventBuffer creates an event buffer in Redis keeping about maxLen
+// events per session
+func NewRedisEventBuffer(client redis.UniversalClient, maxLen int) *RedisEventBuffer {
+	return &RedisEventBuffer{redis: client, maxLen: int64(maxLen)}
+}
+
+func eventSessionKey(sessionID string) string {
+	return "sse:session:" + sessionID
+}
+
+func eventWriterKey(sessionID string) string {
+	return "sse:session:" + sessionID + ":writer"
+}
+
+func eventsKey(sessionID string) string {
+	return "sse:session:" + sessionID + ":events"
+}
+
+// SaveSession stores a session for ttl
+func (b *RedisEventBuffer) SaveSession(ctx context.Context, session *Session, ttl time.Duration) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	if err := b.redis.Set(ctx, eventSessionKey(session.ID), data, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to save event session %s: %w", session.ID, err)
+	}
+	return nil
+}
+
+// GetSession returns a session that has not expired
+func (b *RedisEventBuffer) GetSession(ctx context.Context, sessionID string) (*Session, error) {
+	data, err := b.redis.Get(ctx, eventSessionKey(sessionID)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load event session %s: %w", sessionID, err)
+	}
+
+	var session Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("failed to decode event session %s: %w", sessionID, err)
+	}
+	return &session, nil
+}
+
+// ClaimWriter makes owner the session's writer for ttl unless another owner
+// holds it
+func (b *RedisEventBuffer) ClaimWriter(ctx context.Context, sessionID, owner string, ttl time.Duration) (bool, error) {
+	key := eventWriterKey(sessionID)
+	claimed, err := b.redis.SetNX(ctx, key, owner, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim event session %s: %w", sessionID, err)
+	}
+	if claimed {
+		return true, nil
+	}
+
+	current, err := b.redis.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to claim event session %s: %w", sessionID, err)
+	}
+	if current != owner {
+		return false, nil
+	}
+	return true, b.redis.Expire(ctx, key, ttl).Err()
+}
+
+// ReleaseWriter gives up owner's claim on the session
+func (b *RedisEventBuffer) ReleaseWriter(ctx context.Context, sessionID, owner string) error {
+	key := eventWriterKey(sessionID)
+	current, err := b.redis.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to release event session %s: %w", sessionID, err)
+	}
+	if current != owner {
+		return nil
+	}
+	return b.redis.Del(ctx, key).Err()
+}
+
+// Append adds an event to the session's stream
+func (b *RedisEventBuffer) Append(ctx context.Context, sessionID string, event *BufferedEvent, ttl time.Duration) (string, error) {
+	key := eventsKey(sessionID)
+	pipe := b.redis.TxPipeline()
+	add := pipe.XAdd(ctx, &redis.XAddArgs{
+		Stream: key,
+		MaxLen: b.maxLen,
+		Approx: true,
+		Values: map[string]interface{}{"type": event.Type, "data": string(event.Data)},
+	})
+	pipe.Expire(ctx, key, ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return "", fmt.Errorf("failed to buffer event for session %s: %w", sessionID, err)
+	}
+	return add.Val(), nil
+}
+
+// Read returns the session's events after afterID
+func (b *RedisEventBuffer) Read(ctx context.Context, sessionID, afterID string, block time.Duration) ([]*BufferedEvent, error) {
+	streams, err := b.redis.XRead(ctx, &redis.XReadArgs{
+		Streams: []string{eventsKey(sessionID), afterID},
+		Count:   100,
+		Block:   block,
+	}).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read events of session %s: %w", sessionID, err)
+	}
+
+	var events []*BufferedEvent
+	for _, stream := range streams {
+		for _, message := range stream.Messages {
+			eventType, _ := message.Values["type"].(string)
+			data, _ := message.Values["data"].(string)
+			events = append(events, &BufferedEvent{ID: message.ID, Type: eventType, Data: json.RawMessage(data)})
+		}
+	}
+	return events, nil
+}
+
+// MemoryEventBuffer keeps the sessions' events in memory, for a single
+// gateway instance
+type MemoryEventBuffer struct {
+	mutex    sync.Mutex
+	maxLen   int
+	sessions map[string]memoryEntry
+	writers  map[string]memoryWriter
+	events   map[string]*memoryEvents
+	now      func() time.Time
+}
+
+type memoryWriter struct {
+	owner     string
+	expiresAt time.Time
+}
+
+type memoryEvents struct {
+	events []*BufferedEvent
+	lastID int64
+	// appended is closed and replaced whenever an event is appended
+	appended chan struct{}
+}
+
+// NewMemoryEventBuffer creates an in-memory event buffer keeping maxLen
+// events per session
+func NewMemoryEventBuffer(maxLen int) *MemoryEventBuffer {
+	return &MemoryEventBuffer{
+		maxLen:   maxLen,
+		sessions: make(map[string]memoryEntry),
+		writers:  make(map[string]memoryWriter),
+		events:   make(map[string]*memoryEvents),
+		now:      time.Now,
+	}
+}
+
+// SaveSession stores a session for ttl
+func (b *MemoryEventBuffer) SaveSession(ctx context.Context, session *Session, ttl time.Duration) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.sessions[session.ID] = memoryEntry{data: data, expiresAt: b.now().Add(ttl)}
+	return nil
+}
+
+// GetSession returns a session that has not expired
+func (b *MemoryEventBuffer) GetSession(ctx context.Context, sessionID string) (*Session, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	entry, ok := b.sessions[sessionID]
+	if !ok || !b.now().Before(entry.expiresAt) {
+		delete(b.sessions, sessionID)
+		delete(b.events, sessionID)
+		return nil, ErrSessionNotFound
+	}
+	var session Session
+	if err := json.Unmarshal(entry.data, &session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// ClaimWriter makes owner the session's writer for ttl unless another owner
+// holds it
+func (b *MemoryEventBuffer) ClaimWriter(ctx context.Context, sessionID, owner string, ttl time.Duration) (bool, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	now := b.now()
+	if writer, ok := b.writers[sessionID]; ok && writer.owner != owner && now.Before(writer.expiresAt) {
+		return false, nil
+	}
+	b.writers[sessionID] = memoryWriter{owner: owner, expiresAt: now.Add(ttl)}
+	return true, nil
+}
+
+// ReleaseWriter gives up owner's claim on the session
+func (b *MemoryEventBuffer) ReleaseWriter(ctx context.Context, sessionID, owner string) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if writer, ok := b.writers[sessionID]; ok && writer.owner == owner {
+		delete(b.writers, sessionID)
+	}
+	return nil
+}
+
+// buffer returns the session's events, creating them if needed. Events are
+// dropped with their session. The mutex must be held.
+func (b *MemoryEventBuffer) buffer(sessionID string) *memoryEvents {
+	buffer, ok := b.events[sessionID]
+	if !ok {
+		buffer = &memoryEvents{appended: make(chan struct{})}
+		b.events[sessionID] = buffer
+	}
+	return buffer
+}
+
+// Append adds an event to the session's buffer
+func (b *MemoryEventBuffer) Append(ctx context.Context, sessionID string, event *BufferedEvent, ttl time.Duration) (string, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	buffer := b.buffer(sessionID)
+	buffer.lastID++
+	stored := &BufferedEvent{ID: strconv.FormatInt(buffer.lastID, 10), Type: event.Type, Data: event.Data}
+	buffer.events = append(buffer.events, stored)
+	if len(buffer.events) > b.maxLen {
+		buffer.events = buffer.events[len(buffer.events)-b.maxLen:]
+	}
+
+	close(buffer.appended)
+	buffer.appended = make(chan struct{})
+	return stored.ID, nil
+}
+
+// Read returns the session's events after afterID
+func (b *MemoryEventBuffer) Read(ctx context.Context, sessionID, afterID string, block time.Duration) ([]*BufferedEvent, error) {
+	after, err := strconv.ParseInt(afterID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid event ID %q", afterID)
+	}
+
+	timer := time.NewTimer(block)
+	defer timer.Stop()
+	for {
+		b.mutex.Lock()
+		buffer := b.buffer(sessionID)
+		var events []*BufferedEvent
+		for _, event := range buffer.events {
+			if id, _ := strconv.ParseInt(event.ID, 10, 64); id > after {
+				events = append(events, event)
+			}
+		}
+		appended := buffer.appended
+		b.mutex.Unlock()
+
+		if len(events) > 0 {
+			return events, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+			return nil, nil
+		case <-appended:
+		}
+	}
+}
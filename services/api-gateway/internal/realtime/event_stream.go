@@ -0,0 +1,347 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/services/api-gateway/internal/api"
+	"github.com/rideshare-platform/shared/middleware"
+	"github.com/rideshare-platform/shared/utils"
+)
+
+// EventStreamConfig tunes the Server-Sent Events endpoint
+type EventStreamConfig struct {
+	// ResumeTTL is how long after a client disconnects its session's
+	// topics are still followed and buffered for it to resume
+	ResumeTTL time.Duration
+	// KeepAlive is how often an idle stream is sent a comment, so proxies
+	// keep it open
+	KeepAlive time.Duration
+}
+
+// EventStream serves /events, the Server-Sent Events fallback for clients
+// that cannot use the WebSocket, such as those behind proxies that block
+// upgrades. It serves the same topics as the connection manager, given as
+// topic query parameters, and authenticates requests the same way.
+//
+// Each stream is a session whose events are appended to a short buffer by
+// a single writer following its topics. Event IDs are
+// "{session_id}/{event}", so a client reconnecting with Last-Event-ID
+// within the resume TTL is sent the events it missed, from any gateway.
+type EventStream struct {
+	manager *ConnectionManager
+	buffer  EventBuffer
+	config  EventStreamConfig
+	owner   string
+
+	mutex   sync.Mutex
+	writing map[string]bool
+}
+
+// NewEventStream creates the Server-Sent Events endpoint for the manager's
+// topics
+func NewEventStream(manager *ConnectionManager, buffer EventBuffer, config EventStreamConfig) *EventStream {
+	return &EventStream{
+		manager: manager,
+		buffer:  buffer,
+		config:  config,
+		owner:   utils.GenerateShortID(),
+		writing: make(map[string]bool),
+	}
+}
+
+// writerTTL is how long a session's writer holds its claim without
+// renewing it
+func (s *EventStream) writerTTL() time.Duration {
+	return 3 * s.config.KeepAlive
+}
+
+// requestedTopics returns the request's topic parameters, which may also
+// be comma separated, sorted and without duplicates
+func requestedTopics(r *http.Request) []string {
+	seen := make(map[string]bool)
+	var topics []string
+	for _, value := range r.URL.Query()["topic"] {
+		for _, topic := range strings.Split(value, ",") {
+			topic = strings.TrimSpace(topic)
+			if topic != "" && !seen[topic] {
+				seen[topic] = true
+				topics = append(topics, topic)
+			}
+		}
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// lastEventID splits the Last-Event-ID header, or the last_event_id query
+// parameter for clients that cannot set it, into a session ID and an event ID
+func lastEventID(r *http.Request) (string, string) {
+	id := r.Header.Get("Last-Event-ID")
+	if id == "" {
+		id = r.URL.Query().Get("last_event_id")
+	}
+	sessionID, eventID, found := strings.Cut(id, "/")
+	if !found || sessionID == "" || eventID == "" {
+		return "", ""
+	}
+	return sessionID, eventID
+}
+
+// topicError maps a topic authorization error to the API error envelope
+func topicError(topic string, err error) *api.Error {
+	switch {
+	case errors.Is(err, ErrUnknownTopic):
+		invalid := api.NewError(http.StatusBadRequest, api.CodeValidationFailed, "request validation failed")
+		invalid.Details = []api.FieldError{{Field: "topic", Message: "unknown topic " + topic}}
+		return invalid
+	case errors.Is(err, ErrTopicForbidden):
+		return api.NewError(http.StatusForbidden, api.CodeForbidden, "not allowed to follow "+topic)
+	case errors.Is(err, ErrTopicNotFound):
+		return api.NewError(http.StatusNotFound, api.CodeNotFound, topic+" not found")
+	default:
+		return api.NewError(http.StatusServiceUnavailable, api.CodeServiceUnavailable, topic+" is temporarily unavailable")
+	}
+}
+
+// ServeHTTP authorizes the requested topics and streams their events,
+// resuming the session of the Last-Event-ID if it is still buffered
+func (s *EventStream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	claims, authErr := s.manager.authenticate(r)
+	if authErr != nil {
+		api.WriteError(w, authErr)
+		return
+	}
+
+	topics := requestedTopics(r)
+	if len(topics) == 0 || (s.manager.config.MaxTopics > 0 && len(topics) > s.manager.config.MaxTopics) {
+		invalid := api.NewError(http.StatusBadRequest, api.CodeValidationFailed, "request validation failed")
+		invalid.Details = []api.FieldError{{Field: "topic", Message: fmt.Sprintf("between 1 and %d topics are required", s.manager.config.MaxTopics)}}
+		api.WriteError(w, invalid)
+		return
+	}
+
+	ctx := r.Context()
+	for _, topic := range topics {
+		kind, id, ok := splitTopic(topic)
+		source := s.manager.sources[kind]
+		if !ok || source == nil {
+			api.WriteError(w, topicError(topic, ErrUnknownTopic))
+			return
+		}
+		if err := source.Authorize(ctx, claims, id); err != nil {
+			api.WriteError(w, topicError(topic, err))
+			return
+		}
+	}
+
+	session, lastID, resumed := s.resume(ctx, claims, topics, r)
+	if err := s.buffer.SaveSession(ctx, session, s.config.ResumeTTL); err != nil {
+		log.Printf("Failed to save event session of user %s: %v", claims.UserID, err)
+		api.WriteError(w, api.NewError(http.StatusServiceUnavailable, api.CodeServiceUnavailable, "event buffer unavailable"))
+		return
+	}
+
+	// Streams outlive the server's write timeout
+	controller := http.NewResponseController(w)
+	if err := controller.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		log.Printf("Failed to clear the event stream's write deadline: %v", err)
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	welcome, _ := json.Marshal(welcomeMessage{
+		Type:      MessageTypeWelcome,
+		SessionID: session.ID,
+		Topics:    session.Topics,
+		Resumed:   resumed,
+	})
+	writeEvent(w, session.ID, lastID, MessageTypeWelcome, welcome)
+	if err := controller.Flush(); err != nil {
+		log.Printf("Failed to flush event stream: %v", err)
+		return
+	}
+
+	s.ensureWriter(session, claims)
+	refreshed := time.Now()
+	for {
+		events, err := s.buffer.Read(ctx, session.ID, lastID, s.config.KeepAlive)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			log.Printf("Failed to read event session %s: %v", session.ID, err)
+			return
+		}
+
+		if len(events) == 0 {
+			fmt.Fprint(w, ": keep-alive\n\n")
+		}
+		for _, event := range events {
+			writeEvent(w, session.ID, event.ID, event.Type, event.Data)
+			lastID = event.ID
+		}
+		if err := controller.Flush(); err != nil {
+			return
+		}
+
+		// Keep the session and its writer alive while the client reads
+		if time.Since(refreshed) >= s.config.KeepAlive {
+			if err := s.buffer.SaveSession(ctx, session, s.config.ResumeTTL); err != nil {
+				log.Printf("Failed to refresh event session %s: %v", session.ID, err)
+			}
+			s.ensureWriter(session, claims)
+			refreshed = time.Now()
+		}
+	}
+}
+
+// resume returns the session of the request's Last-Event-ID and the ID of
+// the last event the client received if the session belongs to the user,
+// follows the same topics and has not expired. Otherwise a new session is
+// started.
+func (s *EventStream) resume(ctx context.Context, claims *middleware.AuthClaims, topics []string, r *http.Request) (*Session, string, bool) {
+	sessionID, eventID := lastEventID(r)
+	if sessionID != "" {
+		session, err := s.buffer.GetSession(ctx, sessionID)
+		switch {
+		case err == nil && session.UserID == claims.UserID && strings.Join(session.Topics, ",") == strings.Join(topics, ","):
+			return session, eventID, true
+		case err != nil && !errors.Is(err, ErrSessionNotFound):
+			log.Printf("Failed to load event session %s: %v", sessionID, err)
+		}
+	}
+
+	return &Session{
+		ID:       utils.GenerateSessionID(),
+		UserID:   claims.UserID,
+		UserType: claims.UserType,
+		Topics:   topics,
+	}, "0", false
+}
+
+// writeEvent writes one Server-Sent Event
+func writeEvent(w http.ResponseWriter, sessionID, eventID, eventType string, data []byte) {
+	fmt.Fprintf(w, "id: %s/%s\nevent: %s\ndata: %s\n\n", sessionID, eventID, eventType, data)
+}
+
+// ensureWriter starts following the session's topics on this gateway
+// unless it is already followed here or by another gateway
+func (s *EventStream) ensureWriter(session *Session, claims *middleware.AuthClaims) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.writing[session.ID] {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	claimed, err := s.buffer.ClaimWriter(ctx, session.ID, s.owner, s.writerTTL())
+	if err != nil {
+		log.Printf("Failed to claim event session %s: %v", session.ID, err)
+		return
+	}
+	if !claimed {
+		return
+	}
+
+	s.writing[session.ID] = true
+	go s.write(session, claims)
+}
+
+// write follows the session's topics and appends their events to its
+// buffer until the session expires, resuming where a previous writer
+// stopped
+func (s *EventStream) write(session *Session, claims *middleware.AuthClaims) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		s.mutex.Lock()
+		delete(s.writing, session.ID)
+		s.mutex.Unlock()
+
+		releaseCtx, cancelRelease := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancelRelease()
+		if err := s.buffer.ReleaseWriter(releaseCtx, session.ID, s.owner); err != nil {
+			log.Printf("Failed to release event session %s: %v", session.ID, err)
+		}
+	}()
+
+	messages := make(chan topicMessage)
+	for _, topic := range session.Topics {
+		kind, id, _ := splitTopic(topic)
+		source := s.manager.sources[kind]
+		if source == nil {
+			continue
+		}
+		stream, err := source.Open(ctx, claims, id)
+		if err != nil {
+			s.append(ctx, session, topicMessage{Type: MessageTypeError, Topic: topic, Error: err.Error()})
+			continue
+		}
+
+		go func(topic string) {
+			for {
+				event, err := stream.Recv()
+				message := topicMessage{Type: MessageTypeEvent, Topic: topic, Event: event}
+				if err != nil {
+					if ctx.Err() != nil {
+						return
+					}
+					log.Printf("Stream of topic %s for event session %s closed: %v", topic, session.ID, err)
+					message = topicMessage{Type: MessageTypeUnsubscribed, Topic: topic}
+				}
+				select {
+				case messages <- message:
+				case <-ctx.Done():
+					return
+				}
+				if err != nil {
+					return
+				}
+			}
+		}(topic)
+	}
+
+	ticker := time.NewTicker(s.config.KeepAlive)
+	defer ticker.Stop()
+	for {
+		select {
+		case message := <-messages:
+			s.append(ctx, session, message)
+		case <-ticker.C:
+			// Stop once no client has read the session within the resume
+			// TTL, or another gateway took it over
+			if _, err := s.buffer.GetSession(ctx, session.ID); err != nil {
+				return
+			}
+			claimed, err := s.buffer.ClaimWriter(ctx, session.ID, s.owner, s.writerTTL())
+			if err != nil || !claimed {
+				return
+			}
+		}
+	}
+}
+
+// append adds a topic message to the session's buffer
+func (s *EventStream) append(ctx context.Context, session *Session, message topicMessage) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		log.Printf("Failed to encode event for session %s: %v", session.ID, err)
+		return
+	}
+	if _, err := s.buffer.Append(ctx, session.ID, &BufferedEvent{Type: message.Type, Data: data}, s.config.ResumeTTL); err != nil {
+		log.Printf("Failed to buffer event for session %s: %v", session.ID, err)
+	}
+}
@@ -0,0 +1,174 @@
+package realtime
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/rideshare-platform/shared/middleware"
+)
+
+type serverSentEvent struct {
+	ID    string
+	Event string
+	Data  string
+}
+
+func newTestEventStream(t *testing.T) (*httptest.Server, *fakeTopicSource) {
+	t.Helper()
+	source := &fakeTopicSource{}
+	manager := NewConnectionManager(middleware.NewAuthMiddleware(testSecret, nil), NewMemoryConnectionRegistry(), websocket.Upgrader{}, DefaultConnectionConfig())
+	manager.AddTopicSource("trip", source)
+	events := NewEventStream(manager, NewMemoryEventBuffer(10), EventStreamConfig{
+		ResumeTTL: time.Minute,
+		KeepAlive: 50 * time.Millisecond,
+	})
+
+	server := httptest.NewServer(events)
+	t.Cleanup(server.Close)
+	return server, source
+}
+
+func (s *fakeTopicSource) subscribers(id string) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return len(s.streams[id])
+}
+
+// openEventStream requests the stream and returns its events; cancel
+// disconnects
+func openEventStream(t *testing.T, server *httptest.Server, query, lastEventID string) (*http.Response, <-chan serverSentEvent, context.CancelFunc) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/events?"+query, nil)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to open event stream: %v", err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+
+	events := make(chan serverSentEvent, 10)
+	go func() {
+		defer close(events)
+		scanner := bufio.NewScanner(resp.Body)
+		var event serverSentEvent
+		for scanner.Scan() {
+			field, value, _ := strings.Cut(scanner.Text(), ": ")
+			switch field {
+			case "id":
+				event.ID = value
+			case "event":
+				event.Event = value
+			case "data":
+				event.Data = value
+			case "":
+				if event.Event != "" {
+					events <- event
+				}
+				event = serverSentEvent{}
+			}
+		}
+	}()
+	return resp, events, cancel
+}
+
+func nextEvent(t *testing.T, events <-chan serverSentEvent) serverSentEvent {
+	t.Helper()
+	select {
+	case event, ok := <-events:
+		if !ok {
+			t.Fatal("Event stream closed")
+		}
+		return event
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for an event")
+	}
+	return serverSentEvent{}
+}
+
+func TestEventStream_AuthorizesTopics(t *testing.T) {
+	server, _ := newTestEventStream(t)
+	token := testUserToken(t, "rider-1")
+
+	tests := []struct {
+		name   string
+		query  string
+		status int
+	}{
+		{"no token", "topic=trip:trip-1", http.StatusUnauthorized},
+		{"no topics", "access_token=" + token, http.StatusBadRequest},
+		{"unknown topic", "topic=chat:trip-1&access_token=" + token, http.StatusBadRequest},
+		{"forbidden topic", "topic=trip:trip-1,trip:forbidden&access_token=" + token, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := http.Get(server.URL + "/events?" + tt.query)
+			if err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.status {
+				t.Fatalf("Expected status %d, got %d", tt.status, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestEventStream_ResumesFromLastEventID(t *testing.T) {
+	server, source := newTestEventStream(t)
+	query := "topic=trip:trip-1&access_token=" + testUserToken(t, "rider-1")
+
+	resp, events, disconnect := openEventStream(t, server, query, "")
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("Expected an event stream, got %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	welcome := nextEvent(t, events)
+	var message welcomeMessage
+	json.Unmarshal([]byte(welcome.Data), &message)
+	if welcome.Event != MessageTypeWelcome || message.Resumed || message.SessionID == "" {
+		t.Fatalf("Unexpected welcome event: %+v", welcome)
+	}
+
+	waitFor(t, func() bool { return source.subscribers("trip-1") == 1 }, "Expected the session's topic to be followed")
+	source.publish("trip-1", json.RawMessage(`{"tripId":"trip-1","newStatus":"TRIP_STATUS_STARTED"}`))
+	received := nextEvent(t, events)
+	if received.Event != MessageTypeEvent || !strings.HasPrefix(received.ID, message.SessionID+"/") || !strings.Contains(received.Data, "TRIP_STATUS_STARTED") {
+		t.Fatalf("Expected the trip's event, got %+v", received)
+	}
+
+	// Events published while the client is away are buffered for it
+	disconnect()
+	source.publish("trip-1", json.RawMessage(`{"tripId":"trip-1","newStatus":"TRIP_STATUS_COMPLETED"}`))
+
+	_, resumed, _ := openEventStream(t, server, query, received.ID)
+	welcome = nextEvent(t, resumed)
+	json.Unmarshal([]byte(welcome.Data), &message)
+	if !message.Resumed {
+		t.Fatalf("Expected the session to be resumed, got %+v", welcome)
+	}
+	missed := nextEvent(t, resumed)
+	if !strings.Contains(missed.Data, "TRIP_STATUS_COMPLETED") {
+		t.Fatalf("Expected the missed event, got %+v", missed)
+	}
+	if source.subscribers("trip-1") != 1 {
+		t.Fatal("Expected the resumed session to keep its one writer")
+	}
+
+	// Another user cannot resume the session
+	_, other, _ := openEventStream(t, server, "topic=trip:trip-1&access_token="+testUserToken(t, "rider-2"), received.ID)
+	json.Unmarshal([]byte(nextEvent(t, other).Data), &message)
+	if message.Resumed {
+		t.Fatal("Expected another user to get a new session")
+	}
+}
@@ -0,0 +1,91 @@
+package realtime
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+
+	"github.com/rideshare-platform/services/api-gateway/internal/api"
+	"github.com/rideshare-platform/services/api-gateway/internal/grpc"
+	matchingpb "github.com/rideshare-platform/shared/proto/matching"
+)
+
+// MessageTypeMatchingProgress is sent to riders while their trip is matching
+const MessageTypeMatchingProgress = "matching_progress"
+
+// MatchingProgressSocket streams the steps of matching a trip to the rider
+type MatchingProgressSocket struct {
+	clients  *grpc.ClientManager
+	upgrader websocket.Upgrader
+}
+
+// NewMatchingProgressSocket creates a new matching progress socket handler
+func NewMatchingProgressSocket(clients *grpc.ClientManager, upgrader websocket.Upgrader) *MatchingProgressSocket {
+	return &MatchingProgressSocket{
+		clients:  clients,
+		upgrader: upgrader,
+	}
+}
+
+// ServeHTTP upgrades the request and forwards progress for the trip in the URL
+func (s *MatchingProgressSocket) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	tripID := mux.Vars(r)["trip_id"]
+	if tripID == "" {
+		invalid := api.NewError(http.StatusBadRequest, api.CodeValidationFailed, "request validation failed")
+		invalid.Details = []api.FieldError{{Field: "trip_id", Message: "is required"}}
+		api.WriteError(w, invalid)
+		return
+	}
+
+	matching := s.clients.MatchingClient
+	if matching == nil {
+		api.WriteError(w, api.ServiceUnavailable("matching"))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := matching.StreamMatchingProgress(ctx, &matchingpb.StreamMatchingProgressRequest{TripId: tripID})
+	if err != nil {
+		log.Printf("Failed to open matching progress stream for trip %s: %v", tripID, err)
+		api.WriteError(w, api.FromGRPC("matching", err))
+		return
+	}
+
+	ws, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("Failed to upgrade WebSocket: %v", err)
+		return
+	}
+	defer ws.Close()
+
+	conn := &socketConn{conn: ws}
+
+	// Drain client messages so close frames are processed
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := ws.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		progress, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("Matching progress stream for trip %s closed: %v", tripID, err)
+			}
+			return
+		}
+		if err := conn.send(MessageTypeMatchingProgress, "progress", progress); err != nil {
+			log.Printf("WebSocket write error: %v", err)
+			return
+		}
+	}
+}
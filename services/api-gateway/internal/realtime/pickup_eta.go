@@ -0,0 +1,131 @@
+package realtime
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+
+	"github.com/rideshare-platform/services/api-gateway/internal/api"
+	"github.com/rideshare-platform/services/api-gateway/internal/grpc"
+	geopb "github.com/rideshare-platform/shared/proto/geo"
+	trippb "github.com/rideshare-platform/shared/proto/trip"
+)
+
+// MessageTypePickupETA is sent to riders whenever their driver's ETA to the
+// pickup changes. The last one has arrived set.
+const MessageTypePickupETA = "pickup_eta"
+
+// PickupETASocket streams the driver's ETA to the pickup to the rider while
+// the driver is on their way
+type PickupETASocket struct {
+	clients  *grpc.ClientManager
+	upgrader websocket.Upgrader
+}
+
+// NewPickupETASocket creates a new pickup ETA socket handler
+func NewPickupETASocket(clients *grpc.ClientManager, upgrader websocket.Upgrader) *PickupETASocket {
+	return &PickupETASocket{
+		clients:  clients,
+		upgrader: upgrader,
+	}
+}
+
+// ServeHTTP upgrades the request and forwards pickup ETAs for the trip in
+// the URL until the driver arrives
+func (s *PickupETASocket) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	tripID := mux.Vars(r)["trip_id"]
+	if tripID == "" {
+		invalid := api.NewError(http.StatusBadRequest, api.CodeValidationFailed, "request validation failed")
+		invalid.Details = []api.FieldError{{Field: "trip_id", Message: "is required"}}
+		api.WriteError(w, invalid)
+		return
+	}
+
+	trips, geo := s.clients.TripClient, s.clients.GeoClient
+	if trips == nil {
+		api.WriteError(w, api.ServiceUnavailable("trip"))
+		return
+	}
+	if geo == nil {
+		api.WriteError(w, api.ServiceUnavailable("geo"))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	tripCtx, tripCancel := s.clients.WithTimeout(ctx, "trip")
+	resp, err := trips.GetTrip(tripCtx, &trippb.GetTripRequest{TripId: tripID})
+	tripCancel()
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("trip", err))
+		return
+	}
+	trip := resp.Trip
+	if !resp.Found || trip == nil {
+		api.WriteError(w, api.NewError(http.StatusNotFound, api.CodeNotFound, "trip not found"))
+		return
+	}
+	if trip.DriverId == "" || trip.PickupLocation == nil ||
+		(trip.Status != trippb.TripStatus_MATCHED && trip.Status != trippb.TripStatus_DRIVER_EN_ROUTE) {
+		api.WriteError(w, api.NewError(http.StatusConflict, api.CodeConflict, "trip has no driver on the way to the pickup"))
+		return
+	}
+
+	stream, err := geo.StreamPickupETA(ctx, &geopb.StreamPickupETARequest{
+		TripId:   trip.Id,
+		DriverId: trip.DriverId,
+		Pickup: &geopb.Location{
+			Latitude:  trip.PickupLocation.Latitude,
+			Longitude: trip.PickupLocation.Longitude,
+		},
+		VehicleType: trip.GetMetadata().GetVehicleType(),
+	})
+	if err != nil {
+		log.Printf("Failed to open pickup ETA stream for trip %s: %v", tripID, err)
+		api.WriteError(w, api.FromGRPC("geo", err))
+		return
+	}
+
+	ws, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("Failed to upgrade WebSocket: %v", err)
+		return
+	}
+	defer ws.Close()
+
+	conn := &socketConn{conn: ws}
+
+	// Drain client messages so close frames are processed
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := ws.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		update, err := stream.Recv()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			// Too many streams for the trip is only reported once the stream is read
+			if ctx.Err() == nil {
+				log.Printf("Pickup ETA stream for trip %s closed: %v", tripID, err)
+				conn.sendError(api.FromGRPC("geo", err).Message)
+			}
+			return
+		}
+		if err := conn.send(MessageTypePickupETA, "eta", update); err != nil {
+			log.Printf("WebSocket write error: %v", err)
+			return
+		}
+	}
+}
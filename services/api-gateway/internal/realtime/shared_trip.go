@@ -0,0 +1,110 @@
+package realtime
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+
+	"github.com/rideshare-platform/services/api-gateway/internal/api"
+	"github.com/rideshare-platform/services/api-gateway/internal/grpc"
+	trippb "github.com/rideshare-platform/shared/proto/trip"
+)
+
+// MessageTypeSharedTrip is sent to the followers of a shared trip whenever
+// the trip or the driver's position changes
+const MessageTypeSharedTrip = "shared_trip"
+
+// MessageTypeShareEnded is sent once a shared trip has ended or its link expired
+const MessageTypeShareEnded = "share_ended"
+
+// SharedTripSocket streams a shared trip's live status to anyone holding its
+// share token, without authentication
+type SharedTripSocket struct {
+	clients  *grpc.ClientManager
+	upgrader websocket.Upgrader
+}
+
+// NewSharedTripSocket creates a new shared trip socket handler
+func NewSharedTripSocket(clients *grpc.ClientManager, upgrader websocket.Upgrader) *SharedTripSocket {
+	return &SharedTripSocket{
+		clients:  clients,
+		upgrader: upgrader,
+	}
+}
+
+// ServeHTTP upgrades the request and forwards updates for the trip the
+// token in the URL shares
+func (s *SharedTripSocket) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token := mux.Vars(r)["token"]
+	if token == "" {
+		invalid := api.NewError(http.StatusBadRequest, api.CodeValidationFailed, "request validation failed")
+		invalid.Details = []api.FieldError{{Field: "token", Message: "is required"}}
+		api.WriteError(w, invalid)
+		return
+	}
+
+	trips := s.clients.TripClient
+	if trips == nil {
+		api.WriteError(w, api.ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := trips.WatchTripByShareToken(ctx, &trippb.GetTripByShareTokenRequest{Token: token})
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("trip", err))
+		return
+	}
+	// Invalid and expired tokens are only reported with the first message
+	first, err := stream.Recv()
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("trip", err))
+		return
+	}
+
+	ws, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("Failed to upgrade WebSocket: %v", err)
+		return
+	}
+	defer ws.Close()
+
+	conn := &socketConn{conn: ws}
+
+	// Drain client messages so close frames are processed
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := ws.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for trip := first; ; {
+		if err := conn.send(MessageTypeSharedTrip, "trip", trip); err != nil {
+			log.Printf("WebSocket write error: %v", err)
+			return
+		}
+
+		trip, err = stream.Recv()
+		if err == io.EOF {
+			if err := conn.send(MessageTypeShareEnded, "trip", nil); err != nil {
+				log.Printf("WebSocket write error: %v", err)
+			}
+			return
+		}
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("Shared trip stream closed: %v", err)
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,296 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/rideshare-platform/services/api-gateway/internal/grpc"
+	"github.com/rideshare-platform/shared/alerting"
+	"github.com/rideshare-platform/shared/middleware"
+	matchingpb "github.com/rideshare-platform/shared/proto/matching"
+	trippb "github.com/rideshare-platform/shared/proto/trip"
+)
+
+// Errors returned when a connection subscribes to a topic
+var (
+	ErrUnknownTopic     = errors.New("unknown topic")
+	ErrTopicForbidden   = errors.New("not allowed to follow this topic")
+	ErrTopicNotFound    = errors.New("topic not found")
+	ErrTopicUnavailable = errors.New("topic is temporarily unavailable")
+)
+
+// TopicStream is an open subscription to a topic's events, each encoded
+// as JSON
+type TopicStream interface {
+	Recv() (json.RawMessage, error)
+}
+
+// TopicSource opens subscriptions to one kind of topic, named
+// "{kind}:{id}"
+type TopicSource interface {
+	// Authorize checks the user may follow the topic with the ID
+	Authorize(ctx context.Context, claims *middleware.AuthClaims, id string) error
+	// Open streams the events of the topic with the ID until ctx is
+	// cancelled. The user must have been authorized.
+	Open(ctx context.Context, claims *middleware.AuthClaims, id string) (TopicStream, error)
+}
+
+// splitTopic splits a topic into its kind and ID
+func splitTopic(topic string) (string, string, bool) {
+	kind, id, found := strings.Cut(topic, ":")
+	if !found || kind == "" || id == "" {
+		return "", "", false
+	}
+	return kind, id, true
+}
+
+// protoStream encodes the messages of a gRPC stream as topic events
+type protoStream[T proto.Message] struct {
+	stream interface{ Recv() (T, error) }
+}
+
+func (s protoStream[T]) Recv() (json.RawMessage, error) {
+	message, err := s.stream.Recv()
+	if err != nil {
+		return nil, err
+	}
+	return protojson.Marshal(message)
+}
+
+// TripTopicSource streams the updates of trip:{trip_id} to the trip's rider
+// and driver
+type TripTopicSource struct {
+	clients *grpc.ClientManager
+}
+
+// NewTripTopicSource creates the source of trip topics
+func NewTripTopicSource(clients *grpc.ClientManager) *TripTopicSource {
+	return &TripTopicSource{clients: clients}
+}
+
+// Authorize checks the user is the trip's rider or driver
+func (s *TripTopicSource) Authorize(ctx context.Context, claims *middleware.AuthClaims, tripID string) error {
+	trip, err := loadTopicTrip(ctx, s.clients, tripID)
+	if err != nil {
+		return err
+	}
+	if claims.UserID != trip.RiderId && claims.UserID != trip.DriverId {
+		return ErrTopicForbidden
+	}
+	return nil
+}
+
+// loadTopicTrip loads the trip a topic follows
+func loadTopicTrip(ctx context.Context, clients *grpc.ClientManager, tripID string) (*trippb.Trip, error) {
+	trips := clients.TripClient
+	if trips == nil {
+		return nil, ErrTopicUnavailable
+	}
+
+	callCtx, cancel := clients.WithTimeout(ctx, "trip")
+	defer cancel()
+	resp, err := trips.GetTrip(callCtx, &trippb.GetTripRequest{TripId: tripID})
+	if err != nil {
+		log.Printf("Failed to load trip %s for topic subscription: %v", tripID, err)
+		return nil, ErrTopicUnavailable
+	}
+	if !resp.Found || resp.Trip == nil {
+		return nil, ErrTopicNotFound
+	}
+	return resp.Trip, nil
+}
+
+// Open subscribes to a trip's updates
+func (s *TripTopicSource) Open(ctx context.Context, claims *middleware.AuthClaims, tripID string) (TopicStream, error) {
+	trips := s.clients.TripClient
+	if trips == nil {
+		return nil, ErrTopicUnavailable
+	}
+
+	stream, err := trips.SubscribeToTripUpdates(ctx, &trippb.SubscribeToTripUpdatesRequest{
+		TripId: tripID,
+		UserId: claims.UserID,
+	})
+	if err != nil {
+		log.Printf("Failed to open update stream for trip %s: %v", tripID, err)
+		return nil, ErrTopicUnavailable
+	}
+	return protoStream[*trippb.TripUpdateEvent]{stream}, nil
+}
+
+// MatchingTopicSource streams the steps of matching the trip of
+// matching:{trip_id} to its rider: the searches, the drivers offered the
+// trip and how they answered
+type MatchingTopicSource struct {
+	clients *grpc.ClientManager
+}
+
+// NewMatchingTopicSource creates the source of matching topics
+func NewMatchingTopicSource(clients *grpc.ClientManager) *MatchingTopicSource {
+	return &MatchingTopicSource{clients: clients}
+}
+
+// Authorize checks the user is the trip's rider
+func (s *MatchingTopicSource) Authorize(ctx context.Context, claims *middleware.AuthClaims, tripID string) error {
+	trip, err := loadTopicTrip(ctx, s.clients, tripID)
+	if err != nil {
+		return err
+	}
+	if claims.UserID != trip.RiderId {
+		return ErrTopicForbidden
+	}
+	return nil
+}
+
+// Open subscribes to the steps of matching a trip
+func (s *MatchingTopicSource) Open(ctx context.Context, claims *middleware.AuthClaims, tripID string) (TopicStream, error) {
+	matching := s.clients.MatchingClient
+	if matching == nil {
+		return nil, ErrTopicUnavailable
+	}
+
+	stream, err := matching.StreamMatchingProgress(ctx, &matchingpb.StreamMatchingProgressRequest{TripId: tripID})
+	if err != nil {
+		log.Printf("Failed to open matching progress stream for trip %s: %v", tripID, err)
+		return nil, ErrTopicUnavailable
+	}
+	return protoStream[*matchingpb.MatchingProgress]{stream}, nil
+}
+
+// authorizeDriver only lets drivers follow their own topics
+func authorizeDriver(claims *middleware.AuthClaims, driverID string) error {
+	if claims.UserType != "driver" || claims.UserID != driverID {
+		return ErrTopicForbidden
+	}
+	return nil
+}
+
+// DriverTopicSource streams the trip events of driver:{driver_id} to that
+// driver
+type DriverTopicSource struct {
+	clients *grpc.ClientManager
+}
+
+// NewDriverTopicSource creates the source of driver topics
+func NewDriverTopicSource(clients *grpc.ClientManager) *DriverTopicSource {
+	return &DriverTopicSource{clients: clients}
+}
+
+// Authorize checks the user is the driver
+func (s *DriverTopicSource) Authorize(ctx context.Context, claims *middleware.AuthClaims, driverID string) error {
+	return authorizeDriver(claims, driverID)
+}
+
+// Open subscribes to a driver's trip events
+func (s *DriverTopicSource) Open(ctx context.Context, claims *middleware.AuthClaims, driverID string) (TopicStream, error) {
+	trips := s.clients.TripClient
+	if trips == nil {
+		return nil, ErrTopicUnavailable
+	}
+
+	stream, err := trips.SubscribeDriverEvents(ctx, &trippb.SubscribeDriverEventsRequest{DriverId: driverID})
+	if err != nil {
+		log.Printf("Failed to open trip event stream for driver %s: %v", driverID, err)
+		return nil, ErrTopicUnavailable
+	}
+	return protoStream[*trippb.DriverEvent]{stream}, nil
+}
+
+// OfferTopicSource streams the matching service's trip offers of
+// offers:{driver_id} to that driver
+type OfferTopicSource struct {
+	clients *grpc.ClientManager
+}
+
+// NewOfferTopicSource creates the source of offer topics
+func NewOfferTopicSource(clients *grpc.ClientManager) *OfferTopicSource {
+	return &OfferTopicSource{clients: clients}
+}
+
+// Authorize checks the user is the driver
+func (s *OfferTopicSource) Authorize(ctx context.Context, claims *middleware.AuthClaims, driverID string) error {
+	return authorizeDriver(claims, driverID)
+}
+
+// Open subscribes to a driver's offers
+func (s *OfferTopicSource) Open(ctx context.Context, claims *middleware.AuthClaims, driverID string) (TopicStream, error) {
+	matching := s.clients.MatchingClient
+	if matching == nil {
+		return nil, ErrTopicUnavailable
+	}
+
+	stream, err := matching.StreamDriverOffers(ctx, &matchingpb.StreamDriverOffersRequest{DriverId: driverID})
+	if err != nil {
+		log.Printf("Failed to open offer stream for driver %s: %v", driverID, err)
+		return nil, ErrTopicUnavailable
+	}
+	return protoStream[*matchingpb.DriverOffer]{stream}, nil
+}
+
+// AlertTopicSource streams the platform alerts fired, acknowledged and
+// resolved to operators. alerts:all follows every alert and
+// alerts:{severity} the alerts of one severity.
+type AlertTopicSource struct {
+	redis redis.UniversalClient
+	allow func(claims *middleware.AuthClaims) bool
+}
+
+// NewAlertTopicSource creates the source of alert topics, reading the
+// alert events published in Redis. allow decides which users may follow
+// them.
+func NewAlertTopicSource(client redis.UniversalClient, allow func(claims *middleware.AuthClaims) bool) *AlertTopicSource {
+	return &AlertTopicSource{redis: client, allow: allow}
+}
+
+// Authorize checks the user may follow alerts
+func (s *AlertTopicSource) Authorize(ctx context.Context, claims *middleware.AuthClaims, severity string) error {
+	if !s.allow(claims) {
+		return ErrTopicForbidden
+	}
+	switch alerting.AlertSeverity(severity) {
+	case alerting.SeverityCritical, alerting.SeverityWarning, alerting.SeverityInfo, "all":
+		return nil
+	default:
+		return ErrTopicNotFound
+	}
+}
+
+// Open subscribes to the alert events
+func (s *AlertTopicSource) Open(ctx context.Context, claims *middleware.AuthClaims, severity string) (TopicStream, error) {
+	pubsub := s.redis.Subscribe(ctx, alerting.AlertEventsChannel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		log.Printf("Failed to subscribe to alert events: %v", err)
+		return nil, ErrTopicUnavailable
+	}
+	go func() {
+		<-ctx.Done()
+		pubsub.Close()
+	}()
+	return &alertStream{messages: pubsub.Channel(), severity: alerting.AlertSeverity(severity)}, nil
+}
+
+type alertStream struct {
+	messages <-chan *redis.Message
+	severity alerting.AlertSeverity
+}
+
+func (s *alertStream) Recv() (json.RawMessage, error) {
+	for message := range s.messages {
+		var alert alerting.Alert
+		if err := json.Unmarshal([]byte(message.Payload), &alert); err != nil {
+			continue
+		}
+		if s.severity == "all" || alert.Severity == s.severity {
+			return json.RawMessage(message.Payload), nil
+		}
+	}
+	return nil, errors.New("alert events closed")
+}
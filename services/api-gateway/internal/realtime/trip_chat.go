@@ -0,0 +1,143 @@
+package realtime
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+
+	"github.com/rideshare-platform/services/api-gateway/internal/api"
+	"github.com/rideshare-platform/services/api-gateway/internal/grpc"
+	trippb "github.com/rideshare-platform/shared/proto/trip"
+)
+
+// Message types sent to a trip's rider and driver over the chat socket
+const (
+	MessageTypeChatMessage = "chat_message"
+	MessageTypeChatSent    = "chat_message_sent"
+)
+
+// ChatFrame is a message a rider or driver sends over the chat socket.
+// QuickReplyID sends that quick reply's text instead of Text.
+type ChatFrame struct {
+	Text         string `json:"text,omitempty"`
+	QuickReplyID string `json:"quick_reply_id,omitempty"`
+}
+
+// TripChatSocket bridges a trip participant's WebSocket to trip-service's
+// messaging: messages to them are streamed down, starting with any sent
+// while they were offline, and messages they send are relayed over gRPC
+type TripChatSocket struct {
+	clients  *grpc.ClientManager
+	upgrader websocket.Upgrader
+}
+
+// NewTripChatSocket creates a new trip chat socket handler
+func NewTripChatSocket(clients *grpc.ClientManager, upgrader websocket.Upgrader) *TripChatSocket {
+	return &TripChatSocket{
+		clients:  clients,
+		upgrader: upgrader,
+	}
+}
+
+// ServeHTTP upgrades the request and serves the chat of the trip in the URL
+// for the participant in ?user_id=
+func (s *TripChatSocket) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	tripID := mux.Vars(r)["trip_id"]
+	userID := r.URL.Query().Get("user_id")
+	var missing []api.FieldError
+	if tripID == "" {
+		missing = append(missing, api.FieldError{Field: "trip_id", Message: "is required"})
+	}
+	if userID == "" {
+		missing = append(missing, api.FieldError{Field: "user_id", Message: "is required"})
+	}
+	if len(missing) > 0 {
+		invalid := api.NewError(http.StatusBadRequest, api.CodeValidationFailed, "request validation failed")
+		invalid.Details = missing
+		api.WriteError(w, invalid)
+		return
+	}
+
+	trips := s.clients.TripClient
+	if trips == nil {
+		api.WriteError(w, api.ServiceUnavailable("trip"))
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := trips.StreamTripMessages(ctx, &trippb.StreamTripMessagesRequest{TripId: tripID, UserId: userID})
+	if err != nil {
+		api.WriteError(w, api.FromGRPC("trip", err))
+		return
+	}
+
+	ws, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Printf("Failed to upgrade WebSocket: %v", err)
+		return
+	}
+	defer ws.Close()
+
+	conn := &socketConn{conn: ws}
+
+	// Forward messages from trip-service until either side goes away. Not
+	// being a participant is only reported once the stream is read.
+	go func() {
+		defer cancel()
+		for {
+			message, err := stream.Recv()
+			if err != nil {
+				if ctx.Err() == nil {
+					log.Printf("Chat stream for trip %s closed: %v", tripID, err)
+					conn.sendError(api.FromGRPC("trip", err).Message)
+				}
+				ws.Close()
+				return
+			}
+			if err := conn.send(MessageTypeChatMessage, "message", message); err != nil {
+				log.Printf("WebSocket write error: %v", err)
+				return
+			}
+		}
+	}()
+
+	for {
+		var frame ChatFrame
+		if err := ws.ReadJSON(&frame); err != nil {
+			if ctx.Err() == nil && !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				log.Printf("WebSocket read error: %v", err)
+			}
+			return
+		}
+
+		s.handleFrame(ctx, conn, trips, tripID, userID, &frame)
+	}
+}
+
+// handleFrame relays a message the participant sent to trip-service
+func (s *TripChatSocket) handleFrame(ctx context.Context, conn *socketConn, trips trippb.TripServiceClient, tripID, userID string, frame *ChatFrame) {
+	if frame.Text == "" && frame.QuickReplyID == "" {
+		conn.sendError("text or quick_reply_id is required")
+		return
+	}
+
+	callCtx, cancel := s.clients.WithTimeout(ctx, "trip")
+	defer cancel()
+
+	message, err := trips.SendTripMessage(callCtx, &trippb.SendTripMessageRequest{
+		TripId:       tripID,
+		SenderId:     userID,
+		Text:         frame.Text,
+		QuickReplyId: frame.QuickReplyID,
+	})
+	if err != nil {
+		conn.sendError(api.FromGRPC("trip", err).Message)
+		return
+	}
+	conn.send(MessageTypeChatSent, "message", message)
+}
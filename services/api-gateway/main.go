@@ -10,27 +10,66 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/gorilla/websocket"
+	_ "github.com/lib/pq"
+	"github.com/redis/go-redis/v9"
+	"github.com/rideshare-platform/services/api-gateway/internal/admin"
+	"github.com/rideshare-platform/services/api-gateway/internal/api"
+	"github.com/rideshare-platform/services/api-gateway/internal/config"
 	"github.com/rideshare-platform/services/api-gateway/internal/grpc"
+	"github.com/rideshare-platform/services/api-gateway/internal/health"
+	"github.com/rideshare-platform/services/api-gateway/internal/realtime"
+	"github.com/rideshare-platform/shared/alerting"
+	sharedcrypto "github.com/rideshare-platform/shared/crypto"
+	"github.com/rideshare-platform/shared/flags"
+	sharedhealth "github.com/rideshare-platform/shared/health"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/middleware"
+	"github.com/rideshare-platform/shared/monitoring"
+	"github.com/rideshare-platform/shared/search"
+	sharedtls "github.com/rideshare-platform/shared/tls"
 )
 
 // Simple HTTP handlers for now, we'll add GraphQL later
 func main() {
 	log.Println("🚀 Starting Rideshare API Gateway...")
 
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	appLogger := logger.NewLogger("info", "production")
+
+	// Calls to the backend services use mutual TLS when TLS_ENABLED is set
+	watchCtx, stopWatching := context.WithCancel(context.Background())
+	defer stopWatching()
+	tlsReloader, err := sharedtls.Load(cfg.TLS, appLogger)
+	if err != nil {
+		log.Fatalf("Failed to load TLS certificates: %v", err)
+	}
+	go tlsReloader.Watch(watchCtx)
+
 	// Initialize gRPC client manager
 	grpcClient := grpc.NewClientManager()
+	grpcClient.SetTLS(tlsReloader)
+	grpcClient.ApplyEnvOverrides()
 	if err := grpcClient.Initialize(); err != nil {
 		log.Printf("Failed to initialize gRPC clients: %v", err)
 		// Continue anyway for graceful degradation
 	}
 
-	// Create HTTP router
+	// Create HTTP router. Every request is logged with a request and
+	// correlation ID and answered in the locale negotiated from its
+	// Accept-Language header, both of which are passed on to the backend
+	// services.
 	router := mux.NewRouter()
+	router.NotFoundHandler = api.NotFoundHandler()
+	router.MethodNotAllowedHandler = api.MethodNotAllowedHandler()
+	router.Use(middleware.NewLoggingMiddleware(appLogger).HTTPRequestLogger)
+	router.Use(api.Localize)
 
 	// Health check endpoint (always returns 200 OK)
 	router.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		health := grpcClient.HealthCheck(r.Context())
-		w.Header().Set("Content-Type", "application/json")
 
 		allHealthy := true
 		degradedServices := []string{}
@@ -45,52 +84,36 @@ func main() {
 			log.Printf("[HEALTH] Degraded: %v not healthy", degradedServices)
 		}
 
-		// Compose JSON response with per-service health
-		response := `{"status": "running", "degraded": `
-		if allHealthy {
-			response += "false"
-		} else {
-			response += "true"
-		}
-		response += `, "services": {`
-		first := true
+		// Report per-service health
+		response := &api.HealthResponse{Status: "running", Degraded: !allHealthy, Services: map[string]string{}}
 		for service, healthy := range health {
-			if !first {
-				response += ","
+			response.Services[service] = "healthy"
+			if !healthy {
+				response.Services[service] = "degraded"
 			}
-			response += `"` + service + `": "` + func() string {
-				if healthy {
-					return "healthy"
-				} else {
-					return "degraded"
-				}
-			}() + `"`
-			first = false
 		}
-		response += `}}`
-
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte(response))
+		api.WriteJSON(w, http.StatusOK, response)
 	}).Methods("GET")
 
-	// Service status endpoint
-	router.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
-		status := grpcClient.GetConnectionStatus()
-		w.Header().Set("Content-Type", "application/json")
+	// Build metadata of the gateway, also reported on /status next to each
+	// service's so mismatched deployments stand out
+	gatewayInfo := sharedhealth.NewChecker("api-gateway", "1.0.0")
+	gatewayInfo.SetConfig(cfg)
+	router.Handle("/info", gatewayInfo.InfoHandler()).Methods("GET")
 
-		response := `{"connections": {`
-		first := true
-		for service, state := range status {
-			if !first {
-				response += ","
-			}
-			response += `"` + service + `": "` + state + `"`
-			first = false
-		}
-		response += `}}`
+	// Platform health: every service's dependency report plus the gateway's connection to it
+	platformHealth := health.NewAggregator(grpcClient.GetHealthURLs(), grpcClient)
+	router.Handle("/health/platform", platformHealth.Handler()).Methods("GET")
 
-		w.Write([]byte(response))
-	}).Methods("GET")
+	// Service status endpoint with connection and circuit breaker state and
+	// each service's version and last health check, refreshed in the background
+	status := health.NewStatusReporter(grpcClient, platformHealth)
+	status.SetGateway(gatewayInfo)
+	go status.Watch(watchCtx, cfg.StatusInterval)
+	router.Handle("/status", status.Handler()).Methods("GET")
+
+	// Prometheus metrics, including the connection state gauge
+	router.Handle("/metrics", monitoring.Handler()).Methods("GET")
 
 	// WebSocket upgrade helper
 	upgrader := websocket.Upgrader{
@@ -99,102 +122,118 @@ func main() {
 		},
 	}
 
-	// WebSocket endpoint for real-time updates
-	router.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
-		conn, err := upgrader.Upgrade(w, r, nil)
-		if err != nil {
-			log.Printf("Failed to upgrade WebSocket: %v", err)
-			return
+	// Authenticated WebSocket for following trip, matching, driver, offer and
+	// alert topics, and its Server-Sent Events fallback for clients that cannot
+	// open WebSockets. User tokens are signed with the same JWT_SECRET as
+	// operator tokens.
+	if cfg.Admin.JWTSecret != "" {
+		var registry realtime.ConnectionRegistry = realtime.NewMemoryConnectionRegistry()
+		var buffer realtime.EventBuffer = realtime.NewMemoryEventBuffer(cfg.WebSocket.EventBufferSize)
+		if cfg.WebSocket.RedisAddr != "" {
+			wsRedis := redis.NewClient(&redis.Options{Addr: cfg.WebSocket.RedisAddr})
+			defer wsRedis.Close()
+			registry = realtime.NewRedisConnectionRegistry(wsRedis)
+			buffer = realtime.NewRedisEventBuffer(wsRedis, cfg.WebSocket.EventBufferSize)
+
+			// Reports the connections registered by every gateway
+			go monitoring.NewMetricsCollector(wsRedis, appLogger).StartMetricsCollection(watchCtx)
 		}
-		defer conn.Close()
-
-		// Simple ping-pong for now
-		for {
-			messageType, message, err := conn.ReadMessage()
-			if err != nil {
-				log.Printf("WebSocket read error: %v", err)
-				break
-			}
-
-			// Echo the message back
-			if err := conn.WriteMessage(messageType, message); err != nil {
-				log.Printf("WebSocket write error: %v", err)
-				break
-			}
-		}
-	})
-
-	// REST API endpoints (simplified for now)
-	api := router.PathPrefix("/api/v1").Subrouter()
-
-	// User endpoints
-	api.HandleFunc("/users/{id}", func(w http.ResponseWriter, r *http.Request) {
-		vars := mux.Vars(r)
-		userID := vars["id"]
-
-		if grpcClient.UserClient == nil {
-			http.Error(w, "User service unavailable", http.StatusServiceUnavailable)
-			return
-		}
-
-		// This would call the gRPC service
-		w.Header().Set("Content-Type", "application/json")
-		w.Write([]byte(`{"id": "` + userID + `", "status": "mock response - gRPC integration needed"}`))
-	}).Methods("GET")
-
-	// Trip endpoints
-	api.HandleFunc("/trips/{id}", func(w http.ResponseWriter, r *http.Request) {
-		vars := mux.Vars(r)
-		tripID := vars["id"]
 
-		if grpcClient.TripClient == nil {
-			http.Error(w, "Trip service unavailable", http.StatusServiceUnavailable)
-			return
+		connections := realtime.NewConnectionManager(middleware.NewAuthMiddleware(cfg.Admin.JWTSecret, appLogger), registry, upgrader, realtime.ConnectionConfig{
+			PingInterval:          cfg.WebSocket.PingInterval,
+			PongTimeout:           cfg.WebSocket.PongTimeout,
+			ResumeTTL:             cfg.WebSocket.ResumeTTL,
+			MaxConnectionsPerUser: cfg.WebSocket.MaxConnectionsPerUser,
+			MaxTopics:             realtime.DefaultConnectionConfig().MaxTopics,
+		})
+		connections.AddTopicSource("trip", realtime.NewTripTopicSource(grpcClient))
+		connections.AddTopicSource("driver", realtime.NewDriverTopicSource(grpcClient))
+		connections.AddTopicSource("offers", realtime.NewOfferTopicSource(grpcClient))
+		connections.AddTopicSource("matching", realtime.NewMatchingTopicSource(grpcClient))
+		if cfg.Admin.AlertsRedisAddr != "" {
+			alertEvents := redis.NewClient(&redis.Options{Addr: cfg.Admin.AlertsRedisAddr})
+			defer alertEvents.Close()
+			connections.AddTopicSource("alerts", realtime.NewAlertTopicSource(alertEvents, func(claims *middleware.AuthClaims) bool {
+				return claims.UserType == admin.UserTypeAdmin && admin.HasPermission(claims.Roles, admin.PermissionView)
+			}))
 		}
+		go connections.StartPruning(watchCtx, cfg.WebSocket.PingInterval)
+		router.Handle("/ws", connections)
+		router.Handle("/events", realtime.NewEventStream(connections, buffer, realtime.EventStreamConfig{
+			ResumeTTL: cfg.WebSocket.ResumeTTL,
+			KeepAlive: cfg.WebSocket.EventKeepAlive,
+		})).Methods("GET")
+	} else {
+		log.Println("JWT_SECRET is not set, /ws and /events are disabled")
+	}
 
-		w.Header().Set("Content-Type", "application/json")
-		w.Write([]byte(`{"id": "` + tripID + `", "status": "mock response - gRPC integration needed"}`))
-	}).Methods("GET")
-
-	// Price estimate endpoint
-	api.HandleFunc("/pricing/estimate", func(w http.ResponseWriter, r *http.Request) {
-		if grpcClient.PricingClient == nil {
-			http.Error(w, "Pricing service unavailable", http.StatusServiceUnavailable)
-			return
+	// WebSocket endpoint for drivers to receive and respond to trip offers
+	// and follow their trips' assignments, cancellations and rider locations.
+	// /offers is the endpoint's older name.
+	driverSocket := realtime.NewDriverOfferSocket(grpcClient, upgrader)
+	router.Handle("/ws/drivers/{driver_id}/events", driverSocket)
+	router.Handle("/ws/drivers/{driver_id}/offers", driverSocket)
+
+	// WebSocket endpoint for riders to follow matching progress for a trip
+	router.Handle("/ws/trips/{trip_id}/matching", realtime.NewMatchingProgressSocket(grpcClient, upgrader))
+
+	// WebSocket endpoint for riders to follow their driver's ETA to the pickup
+	router.Handle("/ws/trips/{trip_id}/eta", realtime.NewPickupETASocket(grpcClient, upgrader))
+
+	// WebSocket endpoint for a trip's rider and driver to message each other
+	router.Handle("/ws/trips/{trip_id}/chat", realtime.NewTripChatSocket(grpcClient, upgrader))
+
+	// WebSocket endpoint for anyone holding a trip share link to follow the trip
+	router.Handle("/ws/public/trips/{token}", realtime.NewSharedTripSocket(grpcClient, upgrader))
+
+	// REST API endpoints (simplified for now), and the public shared trip view
+	apiHandler := api.NewHandler(grpcClient)
+	apiHandler.RegisterRoutes(router.PathPrefix("/api/v1").Subrouter())
+	apiHandler.RegisterPublicRoutes(router.PathPrefix("/public").Subrouter())
+
+	// Operations API for staff, behind admin tokens and role permissions
+	if cfg.Admin.Enabled() {
+		adminHandler := admin.NewHandler(grpcClient, middleware.NewAuthMiddleware(cfg.Admin.JWTSecret, appLogger), appLogger)
+		if cfg.Admin.AlertsRedisAddr != "" {
+			alertsRedis := redis.NewClient(&redis.Options{Addr: cfg.Admin.AlertsRedisAddr})
+			defer alertsRedis.Close()
+			adminHandler.SetAlertSource(alerting.NewAlertManager(alertsRedis, appLogger))
 		}
-
-		w.Header().Set("Content-Type", "application/json")
-		w.Write([]byte(`{"estimated_fare": 15.50, "currency": "USD", "status": "mock response"}`))
-	}).Methods("POST")
-
-	// Driver matching endpoint
-	api.HandleFunc("/matching/nearby-drivers", func(w http.ResponseWriter, r *http.Request) {
-		if grpcClient.MatchingClient == nil {
-			http.Error(w, "Matching service unavailable", http.StatusServiceUnavailable)
-			return
+		if cfg.Admin.FlagsRedisAddr != "" {
+			flagsRedis := redis.NewClient(&redis.Options{Addr: cfg.Admin.FlagsRedisAddr})
+			defer flagsRedis.Close()
+			adminHandler.SetFlagStore(flags.NewRedisStore(flagsRedis))
 		}
-
-		w.Header().Set("Content-Type", "application/json")
-		w.Write([]byte(`{"drivers": [], "status": "mock response - gRPC integration needed"}`))
-	}).Methods("POST")
-
-	// Payment endpoints
-	api.HandleFunc("/payments", func(w http.ResponseWriter, r *http.Request) {
-		if grpcClient.PaymentClient == nil {
-			http.Error(w, "Payment service unavailable", http.StatusServiceUnavailable)
-			return
+		if cfg.Admin.SearchDatabaseURL != "" {
+			searchIndex, err := search.Open(context.Background(), cfg.Admin.SearchDatabaseURL, appLogger)
+			if err != nil {
+				log.Printf("Failed to open the search database, admin search is disabled: %v", err)
+			} else {
+				defer searchIndex.Close()
+				// Encrypted emails and phone numbers are matched by their blind index
+				if encryption, err := sharedcrypto.LoadConfig(); err != nil {
+					log.Printf("Failed to load PII encryption settings: %v", err)
+				} else if encryption.Enabled {
+					cipher, err := sharedcrypto.Open(encryption)
+					if err != nil {
+						log.Fatalf("Failed to open PII cipher: %v", err)
+					}
+					searchIndex.SetBlindIndexer(cipher)
+				}
+				adminHandler.SetSearchIndex(searchIndex)
+			}
 		}
-
-		w.Header().Set("Content-Type", "application/json")
-		w.Write([]byte(`{"payment_id": "pay_123", "status": "mock response"}`))
-	}).Methods("POST")
+		adminHandler.RegisterRoutes(router.PathPrefix("/admin/v1").Subrouter())
+	} else {
+		log.Println("JWT_SECRET is not set, the admin API is disabled")
+	}
 
 	// CORS middleware
 	router.Use(func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.Header().Set("Access-Control-Allow-Origin", "*")
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Request-ID, X-Correlation-ID")
 
 			if r.Method == "OPTIONS" {
 				w.WriteHeader(http.StatusOK)
@@ -207,18 +246,64 @@ func main() {
 
 	// Start server
 	srv := &http.Server{
-		Addr:         ":8080",
+		Addr:         cfg.HTTPAddr,
 		Handler:      router,
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
 
-	log.Println("✅ API Gateway listening on :8080")
+	// With HTTPS enabled the router is served over TLS, and plaintext
+	// requests are redirected to it unless HTTPS_REDIRECT_HTTP is false
+	var httpSrv *http.Server
+	if cfg.HTTPS.Enabled {
+		certificates, err := sharedtls.NewReloader(cfg.HTTPS.CertificateConfig(), appLogger)
+		if err != nil {
+			log.Fatalf("Failed to load HTTPS certificate: %v", err)
+		}
+		go certificates.Watch(watchCtx)
+
+		if cfg.HTTPS.RedirectHTTP {
+			httpSrv = &http.Server{
+				Addr:         cfg.HTTPAddr,
+				Handler:      sharedtls.RedirectHandler(cfg.HTTPS.Addr),
+				ReadTimeout:  5 * time.Second,
+				WriteTimeout: 5 * time.Second,
+			}
+		} else {
+			httpSrv = &http.Server{
+				Addr:         cfg.HTTPAddr,
+				Handler:      router,
+				ReadTimeout:  srv.ReadTimeout,
+				WriteTimeout: srv.WriteTimeout,
+				IdleTimeout:  srv.IdleTimeout,
+			}
+		}
+		go func() {
+			if err := httpSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Fatalf("Failed to start HTTP server: %v", err)
+			}
+		}()
+
+		srv.Addr = cfg.HTTPS.Addr
+		srv.TLSConfig = certificates.HTTPSConfig()
+		log.Printf("🔒 HTTPS listening on %s, HTTP on %s", cfg.HTTPS.Addr, cfg.HTTPAddr)
+	}
+
+	log.Printf("✅ API Gateway listening on %s", srv.Addr)
 	log.Println("📊 Health check: http://localhost:8080/health")
+	log.Println("🩺 Platform health: http://localhost:8080/health/platform")
 	log.Println("📈 Status check: http://localhost:8080/status")
-	log.Println("🔌 WebSocket: ws://localhost:8080/ws")
+	log.Println("📉 Metrics: http://localhost:8080/metrics")
+	log.Println("🔌 WebSocket: ws://localhost:8080/ws?access_token={token}")
+	log.Println("📡 Server-Sent Events: http://localhost:8080/events?topic={topic}&access_token={token}")
+	log.Println("🚗 Driver events: ws://localhost:8080/ws/drivers/{driver_id}/events")
+	log.Println("💬 Trip chat: ws://localhost:8080/ws/trips/{trip_id}/chat?user_id={user_id}")
 	log.Println("📡 REST API: http://localhost:8080/api/v1")
+	log.Println("🔗 Shared trips: http://localhost:8080/public/trips/{token}, ws://localhost:8080/ws/public/trips/{token}")
+	if cfg.Admin.Enabled() {
+		log.Println("🛠️  Admin API: http://localhost:8080/admin/v1")
+	}
 
 	// Graceful shutdown
 	go func() {
@@ -231,12 +316,20 @@ func main() {
 		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 		defer cancel()
 
+		if httpSrv != nil {
+			httpSrv.Shutdown(ctx)
+		}
 		srv.Shutdown(ctx)
 		grpcClient.Close()
 	}()
 
 	// Start serving
-	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+	if cfg.HTTPS.Enabled {
+		err = srv.ListenAndServeTLS("", "")
+	} else {
+		err = srv.ListenAndServe()
+	}
+	if err != nil && err != http.ErrServerClosed {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 
@@ -0,0 +1,40 @@
+// Command migrate applies and rolls back the geo-service MongoDB collections
+// and indexes.
+//
+//	migrate up | down [N] | version | status
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/rideshare-platform/services/geo-service/internal/config"
+	"github.com/rideshare-platform/services/geo-service/migrations"
+	"github.com/rideshare-platform/shared/database"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	appLogger := logger.NewLogger(cfg.LogLevel, cfg.Environment)
+
+	mongoDB, err := database.NewMongoDB(&cfg.Database, appLogger)
+	if err != nil {
+		return err
+	}
+	defer mongoDB.Close(context.Background())
+
+	migrator := database.NewMongoMigrator(mongoDB.Database, "geo-service", migrations.Load(), appLogger)
+	return database.RunMigrateCommand(context.Background(), migrator, args, os.Stdout)
+}
@@ -0,0 +1,28 @@
+package client
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	userpb "github.com/rideshare-platform/shared/proto/user"
+)
+
+// GRPCUserClient checks driver onboarding through user-service's gRPC API
+type GRPCUserClient struct {
+	client userpb.UserServiceClient
+}
+
+// NewGRPCUserClient creates a new user-service client
+func NewGRPCUserClient(conn grpc.ClientConnInterface) *GRPCUserClient {
+	return &GRPCUserClient{client: userpb.NewUserServiceClient(conn)}
+}
+
+// IsDriverApproved reports whether a driver has been approved to go online
+func (c *GRPCUserClient) IsDriverApproved(ctx context.Context, driverID string) (bool, error) {
+	resp, err := c.client.GetDriverOnboardingStatus(ctx, &userpb.GetDriverOnboardingStatusRequest{DriverId: driverID})
+	if err != nil {
+		return false, err
+	}
+	return resp.Approved, nil
+}
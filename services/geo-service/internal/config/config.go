@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/rideshare-platform/shared/config"
+	"github.com/rideshare-platform/shared/export"
+	sharedtls "github.com/rideshare-platform/shared/tls"
 )
 
 // Config holds all configuration for the geo service
@@ -19,9 +21,20 @@ type Config struct {
 	HTTPPort        int    `json:"http_port"`
 	ShutdownTimeout int    `json:"shutdown_timeout"`
 
+	// Bearer tokens on gRPC calls are validated against JWTSecret when it is
+	// set. GRPCAuthRequired also rejects calls without one.
+	JWTSecret        string `json:"-"`
+	GRPCAuthRequired bool   `json:"grpc_auth_required"`
+
+	// Mutual TLS for gRPC traffic between services
+	TLS sharedtls.Config `json:"tls"`
+
 	// Database configuration
 	Database config.DatabaseConfig `json:"database"`
 
+	// Apply pending schema migrations before serving
+	MigrateOnStartup bool `json:"migrate_on_startup"`
+
 	// Redis configuration
 	Redis *config.RedisConfig `json:"redis"`
 
@@ -30,6 +43,25 @@ type Config struct {
 
 	// Cache configuration
 	Cache CacheConfig `json:"cache"`
+
+	// Driver state configuration
+	DriverState DriverStateConfig `json:"driver_state"`
+
+	// ETA model configuration
+	ETA ETAConfig `json:"eta"`
+
+	// Trip route recording configuration
+	Route RouteConfig `json:"route"`
+
+	// Pickup ETA streams to riders
+	PickupETA PickupETAConfig `json:"pickup_eta"`
+
+	// Warehouse export of finished driver shifts
+	Export export.Config `json:"export"`
+
+	// Cities file scoping driver locations to the cities the platform
+	// operates in; locations are unpartitioned without one
+	CitiesFile string `json:"cities_file"`
 }
 
 // GeospatialConfig holds geospatial-specific configuration
@@ -52,6 +84,10 @@ type GeospatialConfig struct {
 	// Driver location TTL in seconds (how long to keep location data)
 	DriverLocationTTL int `json:"driver_location_ttl"`
 
+	// Seconds since a driver's last location before nearby searches leave
+	// them out, unless their city sets its own limit; 0 disables
+	MaxLocationAge int `json:"max_location_age"`
+
 	// Route optimization settings
 	RouteOptimization RouteOptimizationConfig `json:"route_optimization"`
 }
@@ -83,15 +119,91 @@ type CacheConfig struct {
 	EnableCaching bool `json:"enable_caching"`
 }
 
+// DriverStateConfig holds driver shift and availability settings
+type DriverStateConfig struct {
+	// Seconds without a heartbeat before an on-shift driver is taken offline
+	HeartbeatTTL int `json:"heartbeat_ttl"`
+
+	// Seconds without a location before an on-shift driver is taken offline,
+	// even while heartbeats keep arriving; 0 disables
+	LocationSilenceTTL int `json:"location_silence_ttl"`
+
+	// How often to sweep for expired heartbeats, in seconds
+	SweepInterval int `json:"sweep_interval"`
+
+	// user-service gRPC address, used to check drivers are approved before they go online
+	UserServiceAddr string `json:"user_service_addr"`
+
+	// Driving-hour limits for cities that set none: hours online, trips
+	// included, and hours on trips within a rolling window; 0 disables a
+	// limit
+	MaxOnlineHours     float64 `json:"max_online_hours"`
+	MaxDrivingHours    float64 `json:"max_driving_hours"`
+	FatigueWindowHours float64 `json:"fatigue_window_hours"`
+
+	// Minutes before a driving-hour limit drivers are warned
+	FatigueWarnMinutes int `json:"fatigue_warn_minutes"`
+}
+
+// ETAConfig selects the ETA model and tunes the traffic model
+type ETAConfig struct {
+	// Model serving ETA requests outside the experiment: "simple" or "traffic"
+	Model string `json:"model"`
+
+	// Model under evaluation and the percentage of origin/destination pairs it serves
+	ExperimentModel   string `json:"experiment_model"`
+	ExperimentPercent int    `json:"experiment_percent"`
+
+	// Geohash precision of the areas trip speeds are grouped by (5 is roughly 5km)
+	AreaPrecision int `json:"area_precision"`
+
+	// Observed trips per area and hour before observed speeds replace the profile
+	MinSamples int `json:"min_samples"`
+
+	// How far back completed trips count, in days
+	LookbackDays int `json:"lookback_days"`
+}
+
+// PickupETAConfig controls how often riders are pushed their driver's pickup ETA
+type PickupETAConfig struct {
+	// Meters the driver must move before the ETA is re-estimated
+	MinMoveMeters float64 `json:"min_move_meters"`
+
+	// Shortest time between two updates on a stream, in seconds
+	MinIntervalSeconds int `json:"min_interval_seconds"`
+
+	// Meters from the pickup at which the driver counts as arrived
+	ArrivalRadiusMeters float64 `json:"arrival_radius_meters"`
+
+	// Streams one trip can have open at once
+	MaxStreamsPerTrip int `json:"max_streams_per_trip"`
+}
+
+// RouteConfig controls how recorded trip routes are cleaned up before their
+// distance is measured
+type RouteConfig struct {
+	// Matcher applied to recorded routes: "none" or "smooth"
+	Matching string `json:"matching"`
+
+	// Points reported with a worse accuracy are dropped, in meters
+	MaxAccuracyMeters float64 `json:"max_accuracy_meters"`
+
+	// Points implying a faster jump from the previous point are dropped, in km/h
+	MaxSpeedKmh float64 `json:"max_speed_kmh"`
+}
+
 // Load loads configuration from environment variables
 func Load() (*Config, error) {
 	cfg := &Config{
-		ServiceName:     getEnv("SERVICE_NAME", "geo-service"),
-		Environment:     getEnv("ENVIRONMENT", "development"),
-		LogLevel:        getEnv("LOG_LEVEL", "info"),
-		GRPCPort:        getEnvInt("GRPC_PORT", 50053),
-		HTTPPort:        getEnvInt("HTTP_PORT", 8053),
-		ShutdownTimeout: getEnvInt("SHUTDOWN_TIMEOUT", 30),
+		ServiceName:      getEnv("SERVICE_NAME", "geo-service"),
+		Environment:      getEnv("ENVIRONMENT", "development"),
+		LogLevel:         getEnv("LOG_LEVEL", "info"),
+		GRPCPort:         getEnvInt("GRPC_PORT", 50053),
+		HTTPPort:         getEnvInt("HTTP_PORT", 8053),
+		ShutdownTimeout:  getEnvInt("SHUTDOWN_TIMEOUT", 30),
+		MigrateOnStartup: getEnvBool("MIGRATE_ON_STARTUP", false),
+		JWTSecret:        getEnv("JWT_SECRET", ""),
+		GRPCAuthRequired: getEnvBool("GRPC_AUTH_REQUIRED", false),
 	}
 
 	// Load database configuration
@@ -121,6 +233,9 @@ func Load() (*Config, error) {
 		WriteTimeout: 3 * time.Second,
 		IdleTimeout:  5 * time.Minute,
 	}
+	if err := config.LoadRedisTopology(cfg.Redis); err != nil {
+		return nil, err
+	}
 
 	// Load geospatial configuration
 	cfg.Geospatial = GeospatialConfig{
@@ -130,6 +245,7 @@ func Load() (*Config, error) {
 		MaxNearbyDrivers:        getEnvInt("GEO_MAX_NEARBY_DRIVERS", 100),
 		LocationUpdateFrequency: getEnvInt("GEO_LOCATION_UPDATE_FREQUENCY", 30),
 		DriverLocationTTL:       getEnvInt("GEO_DRIVER_LOCATION_TTL", 300),
+		MaxLocationAge:          getEnvInt("GEO_MAX_LOCATION_AGE", 60),
 		RouteOptimization: RouteOptimizationConfig{
 			MaxWaypoints: getEnvInt("GEO_MAX_WAYPOINTS", 25),
 			DefaultSpeeds: map[string]float64{
@@ -153,6 +269,61 @@ func Load() (*Config, error) {
 		EnableCaching:    getEnvBool("CACHE_ENABLE", true),
 	}
 
+	// Load driver state configuration
+	cfg.DriverState = DriverStateConfig{
+		HeartbeatTTL:       getEnvInt("DRIVER_HEARTBEAT_TTL", 90),
+		LocationSilenceTTL: getEnvInt("DRIVER_LOCATION_SILENCE_TTL", 300),
+		SweepInterval:      getEnvInt("DRIVER_STATE_SWEEP_INTERVAL", 15),
+		UserServiceAddr:    getEnv("USER_SERVICE_ADDR", "user-service:50051"),
+		MaxOnlineHours:     getEnvFloat("DRIVER_MAX_ONLINE_HOURS", 12),
+		MaxDrivingHours:    getEnvFloat("DRIVER_MAX_DRIVING_HOURS", 0),
+		FatigueWindowHours: getEnvFloat("DRIVER_FATIGUE_WINDOW_HOURS", 24),
+		FatigueWarnMinutes: getEnvInt("DRIVER_FATIGUE_WARN_MINUTES", 30),
+	}
+
+	// Load ETA model configuration
+	cfg.ETA = ETAConfig{
+		Model:             getEnv("ETA_MODEL", "simple"),
+		ExperimentModel:   getEnv("ETA_EXPERIMENT_MODEL", "traffic"),
+		ExperimentPercent: getEnvInt("ETA_EXPERIMENT_PERCENT", 0),
+		AreaPrecision:     getEnvInt("ETA_AREA_PRECISION", 5),
+		MinSamples:        getEnvInt("ETA_MIN_SAMPLES", 20),
+		LookbackDays:      getEnvInt("ETA_LOOKBACK_DAYS", 14),
+	}
+
+	// Load trip route recording configuration
+	cfg.Route = RouteConfig{
+		Matching:          getEnv("ROUTE_MATCHING", "smooth"),
+		MaxAccuracyMeters: getEnvFloat("ROUTE_MAX_ACCURACY_METERS", 50),
+		MaxSpeedKmh:       getEnvFloat("ROUTE_MAX_SPEED_KMH", 160),
+	}
+
+	cfg.PickupETA = PickupETAConfig{
+		MinMoveMeters:       getEnvFloat("PICKUP_ETA_MIN_MOVE_METERS", 50),
+		MinIntervalSeconds:  getEnvInt("PICKUP_ETA_MIN_INTERVAL", 5),
+		ArrivalRadiusMeters: getEnvFloat("PICKUP_ETA_ARRIVAL_RADIUS_METERS", 50),
+		MaxStreamsPerTrip:   getEnvInt("PICKUP_ETA_MAX_STREAMS_PER_TRIP", 3),
+	}
+
+	// Load TLS configuration
+	tlsConfig, err := sharedtls.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	cfg.TLS = tlsConfig
+
+	// Load warehouse export configuration
+	exportConfig, err := export.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	if err := exportConfig.Validate(); err != nil {
+		return nil, err
+	}
+	cfg.Export = exportConfig
+
+	cfg.CitiesFile = getEnv("CITIES_FILE", "")
+
 	return cfg, nil
 }
 
@@ -221,9 +392,43 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid max search radius: %f", c.Geospatial.MaxSearchRadiusKm)
 	}
 
+	if c.DriverState.HeartbeatTTL <= 0 {
+		return fmt.Errorf("invalid driver heartbeat TTL: %d", c.DriverState.HeartbeatTTL)
+	}
+
+	if c.Geospatial.MaxLocationAge < 0 || c.DriverState.LocationSilenceTTL < 0 {
+		return fmt.Errorf("driver location age limits must not be negative")
+	}
+
+	if c.DriverState.MaxOnlineHours < 0 || c.DriverState.MaxDrivingHours < 0 || c.DriverState.FatigueWarnMinutes < 0 {
+		return fmt.Errorf("driving-hour limits must not be negative")
+	}
+
+	if c.DriverState.FatigueWindowHours <= 0 || c.DriverState.FatigueWindowHours > 8*24 {
+		return fmt.Errorf("invalid driving-hour window: %v hours", c.DriverState.FatigueWindowHours)
+	}
+
 	if c.Geospatial.DefaultGeohashPrecision < 1 || c.Geospatial.DefaultGeohashPrecision > 12 {
 		return fmt.Errorf("invalid geohash precision: %d", c.Geospatial.DefaultGeohashPrecision)
 	}
 
+	for _, model := range []string{c.ETA.Model, c.ETA.ExperimentModel} {
+		if model != "simple" && model != "traffic" {
+			return fmt.Errorf("invalid ETA model: %q", model)
+		}
+	}
+
+	if c.ETA.ExperimentPercent < 0 || c.ETA.ExperimentPercent > 100 {
+		return fmt.Errorf("invalid ETA experiment percent: %d", c.ETA.ExperimentPercent)
+	}
+
+	if c.ETA.AreaPrecision < 1 || c.ETA.AreaPrecision > 12 {
+		return fmt.Errorf("invalid ETA area precision: %d", c.ETA.AreaPrecision)
+	}
+
+	if c.Route.Matching != "none" && c.Route.Matching != "smooth" {
+		return fmt.Errorf("invalid route matching: %q", c.Route.Matching)
+	}
+
 	return nil
 }
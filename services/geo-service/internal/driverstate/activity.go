@@ -0,0 +1,282 @@
+package driverstate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ActivityDateLayout is the layout of activity dates, which are UTC days
+const ActivityDateLayout = "2006-01-02"
+
+// maxActivityDays bounds how many days of activity are read at once
+const maxActivityDays = 93
+
+// ErrInvalidActivityRange is returned for activity ranges that end before
+// they start or span more than maxActivityDays
+var ErrInvalidActivityRange = errors.New("invalid activity range")
+
+// DailyActivity is how a driver spent one UTC day on shift. Online time is
+// on shift and not on a break, trips included; it is what incentives and
+// utilization are measured against.
+type DailyActivity struct {
+	DriverID      string `json:"driver_id"`
+	Date          string `json:"date"`
+	OnlineSeconds int64  `json:"online_seconds"`
+	OnTripSeconds int64  `json:"on_trip_seconds"`
+	BreakSeconds  int64  `json:"break_seconds"`
+	// Shifts counts the shifts started that day
+	Shifts int `json:"shifts"`
+}
+
+// OnlineHours returns the online time in hours, rounded to two decimals
+func (a *DailyActivity) OnlineHours() float64 {
+	return math.Round(float64(a.OnlineSeconds)/36) / 100
+}
+
+// Utilization returns the share of online time spent on trips
+func (a *DailyActivity) Utilization() float64 {
+	if a.OnlineSeconds <= 0 {
+		return 0
+	}
+	return float64(a.OnTripSeconds) / float64(a.OnlineSeconds)
+}
+
+// add adds other's times and shifts to the day's
+func (a *DailyActivity) add(other *DailyActivity) {
+	a.OnlineSeconds += other.OnlineSeconds
+	a.OnTripSeconds += other.OnTripSeconds
+	a.BreakSeconds += other.BreakSeconds
+	a.Shifts += other.Shifts
+}
+
+// ActivityTotals sums a driver's activity over several days
+type ActivityTotals struct {
+	OnlineSeconds int64   `json:"online_seconds"`
+	OnTripSeconds int64   `json:"on_trip_seconds"`
+	BreakSeconds  int64   `json:"break_seconds"`
+	Shifts        int     `json:"shifts"`
+	OnlineHours   float64 `json:"online_hours"`
+	Utilization   float64 `json:"utilization"`
+}
+
+// SumActivity adds up the days' activity
+func SumActivity(days []*DailyActivity) *ActivityTotals {
+	sum := &DailyActivity{}
+	for _, day := range days {
+		sum.add(day)
+	}
+	return &ActivityTotals{
+		OnlineSeconds: sum.OnlineSeconds,
+		OnTripSeconds: sum.OnTripSeconds,
+		BreakSeconds:  sum.BreakSeconds,
+		Shifts:        sum.Shifts,
+		OnlineHours:   sum.OnlineHours(),
+		Utilization:   sum.Utilization(),
+	}
+}
+
+// ActivityLog keeps each driver's per-day activity
+type ActivityLog interface {
+	// Add adds the activity's times and shifts to the driver's day
+	Add(ctx context.Context, activity *DailyActivity) error
+	// List returns the driver's activity on the days from to to, both
+	// included, leaving out days with none
+	List(ctx context.Context, driverID, from, to string) ([]*DailyActivity, error)
+}
+
+// activityDates returns the dates from to to, both included
+func activityDates(from, to string) ([]string, error) {
+	start, err := time.Parse(ActivityDateLayout, from)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidActivityRange, err)
+	}
+	end, err := time.Parse(ActivityDateLayout, to)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidActivityRange, err)
+	}
+	if end.Before(start) || end.Sub(start) >= maxActivityDays*24*time.Hour {
+		return nil, fmt.Errorf("%w: %s to %s", ErrInvalidActivityRange, from, to)
+	}
+
+	var dates []string
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		dates = append(dates, day.Format(ActivityDateLayout))
+	}
+	return dates, nil
+}
+
+// splitDays calls add with the seconds of [from, to) falling on each UTC day
+func splitDays(from, to time.Time, add func(date string, seconds int64)) {
+	from, to = from.UTC(), to.UTC()
+	for from.Before(to) {
+		dayEnd := time.Date(from.Year(), from.Month(), from.Day()+1, 0, 0, 0, 0, time.UTC)
+		if dayEnd.After(to) {
+			dayEnd = to
+		}
+		if seconds := int64(dayEnd.Sub(from).Seconds()); seconds > 0 {
+			add(from.Format(ActivityDateLayout), seconds)
+		}
+		from = dayEnd
+	}
+}
+
+// stateActivity returns the activity of a driver who was in state over
+// [from, to), split by day
+func stateActivity(driverID string, state State, from, to time.Time) []*DailyActivity {
+	var days []*DailyActivity
+	splitDays(from, to, func(date string, seconds int64) {
+		day := &DailyActivity{DriverID: driverID, Date: date}
+		switch state {
+		case StateOnline:
+			day.OnlineSeconds = seconds
+		case StateOnTrip:
+			day.OnlineSeconds = seconds
+			day.OnTripSeconds = seconds
+		case StateOnBreak:
+			day.BreakSeconds = seconds
+		}
+		days = append(days, day)
+	})
+	return days
+}
+
+const redisActivityKeyPrefix = "driver_activity:"
+
+// redisActivityKey is the hash of one driver's activity on one day
+func redisActivityKey(driverID, date string) string {
+	return redisActivityKeyPrefix + driverID + ":" + date
+}
+
+// RedisActivityLog keeps each driver's day in a hash of counters, kept for
+// as long as finished shifts are
+type RedisActivityLog struct {
+	client redis.UniversalClient
+}
+
+// NewRedisActivityLog creates a new Redis-backed activity log
+func NewRedisActivityLog(client redis.UniversalClient) *RedisActivityLog {
+	return &RedisActivityLog{client: client}
+}
+
+// Add increments the counters of the driver's day
+func (r *RedisActivityLog) Add(ctx context.Context, activity *DailyActivity) error {
+	key := redisActivityKey(activity.DriverID, activity.Date)
+	pipe := r.client.TxPipeline()
+	pipe.HIncrBy(ctx, key, "online_seconds", activity.OnlineSeconds)
+	pipe.HIncrBy(ctx, key, "on_trip_seconds", activity.OnTripSeconds)
+	pipe.HIncrBy(ctx, key, "break_seconds", activity.BreakSeconds)
+	pipe.HIncrBy(ctx, key, "shifts", int64(activity.Shifts))
+	pipe.Expire(ctx, key, sessionRetention)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to save driver activity: %w", err)
+	}
+	return nil
+}
+
+// List reads the driver's days from to to
+func (r *RedisActivityLog) List(ctx context.Context, driverID, from, to string) ([]*DailyActivity, error) {
+	dates, err := activityDates(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := r.client.Pipeline()
+	results := make([]*redis.MapStringStringCmd, len(dates))
+	for i, date := range dates {
+		results[i] = pipe.HGetAll(ctx, redisActivityKey(driverID, date))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to list driver activity: %w", err)
+	}
+
+	var days []*DailyActivity
+	for i, result := range results {
+		fields := result.Val()
+		if len(fields) == 0 {
+			continue
+		}
+		day := &DailyActivity{DriverID: driverID, Date: dates[i]}
+		day.OnlineSeconds, _ = strconv.ParseInt(fields["online_seconds"], 10, 64)
+		day.OnTripSeconds, _ = strconv.ParseInt(fields["on_trip_seconds"], 10, 64)
+		day.BreakSeconds, _ = strconv.ParseInt(fields["break_seconds"], 10, 64)
+		day.Shifts, _ = strconv.Atoi(fields["shifts"])
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+// MemoryActivityLog implements ActivityLog in memory
+type MemoryActivityLog struct {
+	days  map[string]*DailyActivity
+	mutex sync.RWMutex
+}
+
+// NewMemoryActivityLog creates a new in-memory activity log
+func NewMemoryActivityLog() *MemoryActivityLog {
+	return &MemoryActivityLog{days: make(map[string]*DailyActivity)}
+}
+
+// Add adds the activity to the driver's day
+func (m *MemoryActivityLog) Add(ctx context.Context, activity *DailyActivity) error {
+	key := redisActivityKey(activity.DriverID, activity.Date)
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	day, exists := m.days[key]
+	if !exists {
+		day = &DailyActivity{DriverID: activity.DriverID, Date: activity.Date}
+		m.days[key] = day
+	}
+	day.add(activity)
+	return nil
+}
+
+// List returns copies of the driver's days from to to
+func (m *MemoryActivityLog) List(ctx context.Context, driverID, from, to string) ([]*DailyActivity, error) {
+	dates, err := activityDates(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	var days []*DailyActivity
+	for _, date := range dates {
+		if day, exists := m.days[redisActivityKey(driverID, date)]; exists {
+			copied := *day
+			days = append(days, &copied)
+		}
+	}
+	return days, nil
+}
+
+// mergeActivity adds the extra days into days, keeping them in date order
+func mergeActivity(days []*DailyActivity, extra []*DailyActivity, from, to string) []*DailyActivity {
+	byDate := make(map[string]*DailyActivity, len(days))
+	for _, day := range days {
+		byDate[day.Date] = day
+	}
+	for _, day := range extra {
+		if day.Date < from || day.Date > to {
+			continue
+		}
+		if existing, ok := byDate[day.Date]; ok {
+			existing.add(day)
+			continue
+		}
+		copied := *day
+		byDate[day.Date] = &copied
+		days = append(days, &copied)
+	}
+	sort.Slice(days, func(i, j int) bool {
+		return days[i].Date < days[j].Date
+	})
+	return days
+}
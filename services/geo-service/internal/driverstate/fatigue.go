@@ -0,0 +1,280 @@
+package driverstate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/rideshare-platform/shared/city"
+)
+
+// fatigueRetention is how long shift segments are kept, the longest window
+// driving-hour limits are counted over
+const fatigueRetention = 8 * 24 * time.Hour
+
+// FatigueLimits are the driving-hour limits of a jurisdiction. A zero limit
+// is not enforced.
+type FatigueLimits struct {
+	// MaxOnline caps online time, trips included and breaks not, within Window
+	MaxOnline time.Duration
+	// MaxDriving caps time on trips within Window
+	MaxDriving time.Duration
+	Window     time.Duration
+	// WarnBefore is how long before reaching a limit drivers are warned
+	WarnBefore time.Duration
+}
+
+// enforced reports whether any limit applies
+func (l FatigueLimits) enforced() bool {
+	return l.Window > 0 && (l.MaxOnline > 0 || l.MaxDriving > 0)
+}
+
+// FatiguePolicy returns the driving-hour limits of the city a driver works in
+type FatiguePolicy interface {
+	Limits(cityID string) FatigueLimits
+}
+
+// CityFatiguePolicy takes each city's driving-hour limits from its
+// policies, with defaults for the limits a city leaves unset and for
+// drivers outside every city
+type CityFatiguePolicy struct {
+	cities   *city.Registry
+	defaults FatigueLimits
+}
+
+// NewCityFatiguePolicy creates a fatigue policy over the configured cities,
+// which may be nil
+func NewCityFatiguePolicy(cities *city.Registry, defaults FatigueLimits) *CityFatiguePolicy {
+	return &CityFatiguePolicy{cities: cities, defaults: defaults}
+}
+
+// Limits returns the city's limits
+func (p *CityFatiguePolicy) Limits(cityID string) FatigueLimits {
+	limits := p.defaults
+	policies := p.cities.Policies(cityID)
+	if policies.MaxOnlineHours > 0 {
+		limits.MaxOnline = hours(policies.MaxOnlineHours)
+	}
+	if policies.MaxDrivingHours > 0 {
+		limits.MaxDriving = hours(policies.MaxDrivingHours)
+	}
+	if policies.FatigueWindowHours > 0 {
+		limits.Window = hours(policies.FatigueWindowHours)
+	}
+	return limits
+}
+
+func hours(value float64) time.Duration {
+	return time.Duration(value * float64(time.Hour))
+}
+
+// Segment is a stretch of a shift a driver spent in one state
+type Segment struct {
+	State State     `json:"state"`
+	From  time.Time `json:"from"`
+	To    time.Time `json:"to"`
+}
+
+// FatigueLog keeps the recent shift segments driving-hour limits are
+// counted over
+type FatigueLog interface {
+	Add(ctx context.Context, driverID string, segment Segment) error
+	// Since returns the driver's segments ending after since, oldest first
+	Since(ctx context.Context, driverID string, since time.Time) ([]Segment, error)
+}
+
+// FatigueStatus is how much of their driving-hour limits a driver has used
+// in the window ending now
+type FatigueStatus struct {
+	DriverID          string `json:"driver_id"`
+	CityID            string `json:"city_id,omitempty"`
+	WindowSeconds     int64  `json:"window_seconds"`
+	OnlineSeconds     int64  `json:"online_seconds"`
+	DrivingSeconds    int64  `json:"driving_seconds"`
+	MaxOnlineSeconds  int64  `json:"max_online_seconds,omitempty"`
+	MaxDrivingSeconds int64  `json:"max_driving_seconds,omitempty"`
+	Reached           bool   `json:"reached"`
+	// LimitAt is when a driver who stays in their state reaches a limit,
+	// now once they have. It is nil while they use up neither.
+	LimitAt *time.Time `json:"limit_at,omitempty"`
+	// AvailableAt is when a driver off shift who reached a limit has
+	// enough of it back to go online again
+	AvailableAt *time.Time `json:"available_at,omitempty"`
+}
+
+// fatigueStatus counts the driver's time in the window ending at now from
+// their finished segments and their current state
+func fatigueStatus(state *DriverState, segments []Segment, limits FatigueLimits, now time.Time) *FatigueStatus {
+	status := &FatigueStatus{
+		DriverID:          state.DriverID,
+		CityID:            state.CityID,
+		WindowSeconds:     int64(limits.Window.Seconds()),
+		MaxOnlineSeconds:  int64(limits.MaxOnline.Seconds()),
+		MaxDrivingSeconds: int64(limits.MaxDriving.Seconds()),
+	}
+	if !limits.enforced() {
+		return status
+	}
+
+	since := now.Add(-limits.Window)
+	if state.State != StateOffline && !state.UpdatedAt.IsZero() {
+		segments = append(segments, Segment{State: state.State, From: state.UpdatedAt, To: now})
+	}
+	var online, driving []Segment
+	var onlineTime, drivingTime time.Duration
+	for _, segment := range segments {
+		if segment.From.Before(since) {
+			segment.From = since
+		}
+		if segment.To.After(now) {
+			segment.To = now
+		}
+		if !segment.To.After(segment.From) {
+			continue
+		}
+		switch segment.State {
+		case StateOnTrip:
+			driving = append(driving, segment)
+			drivingTime += segment.To.Sub(segment.From)
+			fallthrough
+		case StateOnline:
+			online = append(online, segment)
+			onlineTime += segment.To.Sub(segment.From)
+		}
+	}
+	status.OnlineSeconds = int64(onlineTime.Seconds())
+	status.DrivingSeconds = int64(drivingTime.Seconds())
+
+	// The time left before the nearest limit the driver's state uses up
+	left, accrues := time.Duration(0), false
+	use := func(max, used time.Duration, accruing bool, spent []Segment) {
+		if max <= 0 {
+			return
+		}
+		if used >= max {
+			status.Reached = true
+			if state.State == StateOffline || state.State == StateOnBreak {
+				available := rested(spent, used-max, limits.Window)
+				if status.AvailableAt == nil || available.After(*status.AvailableAt) {
+					status.AvailableAt = &available
+				}
+			}
+		}
+		if accruing && (!accrues || max-used < left) {
+			left, accrues = max-used, true
+		}
+	}
+	use(limits.MaxOnline, onlineTime, state.State == StateOnline || state.State == StateOnTrip, online)
+	use(limits.MaxDriving, drivingTime, state.State == StateOnTrip, driving)
+
+	if status.Reached {
+		status.LimitAt = &now
+	} else if accrues {
+		limitAt := now.Add(left)
+		status.LimitAt = &limitAt
+	}
+	return status
+}
+
+// rested returns when more than excess of the time spent, oldest first,
+// will have rolled out of the window
+func rested(spent []Segment, excess, window time.Duration) time.Time {
+	var shed time.Duration
+	for _, segment := range spent {
+		length := segment.To.Sub(segment.From)
+		if shed+length > excess {
+			return segment.From.Add(excess - shed + time.Second).Add(window)
+		}
+		shed += length
+	}
+	return spent[len(spent)-1].To.Add(window)
+}
+
+const redisFatigueKeyPrefix = "driver_fatigue:"
+
+// RedisFatigueLog keeps each driver's recent segments in a sorted set
+// scored by when they ended
+type RedisFatigueLog struct {
+	client redis.UniversalClient
+}
+
+// NewRedisFatigueLog creates a new Redis-backed fatigue log
+func NewRedisFatigueLog(client redis.UniversalClient) *RedisFatigueLog {
+	return &RedisFatigueLog{client: client}
+}
+
+// Add records the segment and drops those past retention
+func (r *RedisFatigueLog) Add(ctx context.Context, driverID string, segment Segment) error {
+	data, err := json.Marshal(segment)
+	if err != nil {
+		return fmt.Errorf("failed to encode shift segment: %w", err)
+	}
+
+	key := redisFatigueKeyPrefix + driverID
+	pipe := r.client.TxPipeline()
+	pipe.ZAdd(ctx, key, redis.Z{Score: float64(segment.To.Unix()), Member: data})
+	pipe.ZRemRangeByScore(ctx, key, "-inf", strconv.FormatInt(segment.To.Add(-fatigueRetention).Unix(), 10))
+	pipe.Expire(ctx, key, fatigueRetention)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to save shift segment: %w", err)
+	}
+	return nil
+}
+
+// Since reads the driver's segments ending after since
+func (r *RedisFatigueLog) Since(ctx context.Context, driverID string, since time.Time) ([]Segment, error) {
+	members, err := r.client.ZRangeByScore(ctx, redisFatigueKeyPrefix+driverID, &redis.ZRangeBy{
+		Min: "(" + strconv.FormatInt(since.Unix(), 10),
+		Max: "+inf",
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shift segments: %w", err)
+	}
+
+	segments := make([]Segment, 0, len(members))
+	for _, member := range members {
+		var segment Segment
+		if err := json.Unmarshal([]byte(member), &segment); err != nil {
+			return nil, fmt.Errorf("failed to decode shift segment: %w", err)
+		}
+		segments = append(segments, segment)
+	}
+	return segments, nil
+}
+
+// MemoryFatigueLog implements FatigueLog in memory
+type MemoryFatigueLog struct {
+	segments map[string][]Segment
+	mutex    sync.RWMutex
+}
+
+// NewMemoryFatigueLog creates a new in-memory fatigue log
+func NewMemoryFatigueLog() *MemoryFatigueLog {
+	return &MemoryFatigueLog{segments: make(map[string][]Segment)}
+}
+
+// Add records the segment
+func (m *MemoryFatigueLog) Add(ctx context.Context, driverID string, segment Segment) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.segments[driverID] = append(m.segments[driverID], segment)
+	return nil
+}
+
+// Since returns the driver's segments ending after since
+func (m *MemoryFatigueLog) Since(ctx context.Context, driverID string, since time.Time) ([]Segment, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	segments := []Segment{}
+	for _, segment := range m.segments[driverID] {
+		if segment.To.After(since) {
+			segments = append(segments, segment)
+		}
+	}
+	return segments, nil
+}
@@ -0,0 +1,796 @@
+package driverstate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/shared/events"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// offlineRetention is how long an offline driver's last state is kept
+const offlineRetention = 24 * time.Hour
+
+// ApprovalChecker reports whether a driver has completed onboarding
+type ApprovalChecker interface {
+	IsDriverApproved(ctx context.Context, driverID string) (bool, error)
+}
+
+// UtilizationRecorder records the share of a finished shift's online time
+// the driver spent on trips
+type UtilizationRecorder interface {
+	RecordDriverUtilization(utilizationRatio float64)
+}
+
+// Manager runs the driver state machine, tracks shift heartbeats and
+// publishes state changes for analytics
+type Manager struct {
+	store        Store
+	bus          events.EventBus
+	approvals    ApprovalChecker
+	sessions     SessionLog
+	activity     ActivityLog
+	utilization  UtilizationRecorder
+	fatigue      FatiguePolicy
+	fatigueLog   FatigueLog
+	heartbeatTTL time.Duration
+	locationTTL  time.Duration
+	logger       *logger.Logger
+	mutex        sync.Mutex
+}
+
+// NewManager creates a new driver state manager. Drivers on shift who send no
+// heartbeat for heartbeatTTL are taken offline.
+func NewManager(store Store, bus events.EventBus, heartbeatTTL time.Duration, logger *logger.Logger) *Manager {
+	return &Manager{
+		store:        store,
+		bus:          bus,
+		heartbeatTTL: heartbeatTTL,
+		logger:       logger,
+	}
+}
+
+// SetApprovalChecker sets the user-service client used to keep drivers who
+// have not been approved from going online
+func (m *Manager) SetApprovalChecker(checker ApprovalChecker) {
+	m.approvals = checker
+}
+
+// SetSessionLog sets where finished shifts are kept for the warehouse export
+func (m *Manager) SetSessionLog(log SessionLog) {
+	m.sessions = log
+}
+
+// SetActivityLog sets where drivers' online, on-trip and break time is
+// added up per day
+func (m *Manager) SetActivityLog(log ActivityLog) {
+	m.activity = log
+}
+
+// SetUtilizationRecorder sets the metric finished shifts' utilization is
+// recorded in
+func (m *Manager) SetUtilizationRecorder(recorder UtilizationRecorder) {
+	m.utilization = recorder
+}
+
+// SetFatigueRules enforces driving-hour limits: drivers are warned as they
+// near their city's limits and taken offline when they reach them, after
+// the trip they are on, and cannot go online again until enough time has
+// rolled out of the window
+func (m *Manager) SetFatigueRules(policy FatiguePolicy, log FatigueLog) {
+	m.fatigue = policy
+	m.fatigueLog = log
+}
+
+// SetLocationTTL takes offline drivers on shift who report no location for
+// ttl, even while they keep sending heartbeats. Zero disables it.
+func (m *Manager) SetLocationTTL(ttl time.Duration) {
+	m.locationTTL = ttl
+}
+
+// GetState returns a driver's current state. Drivers with no saved state are offline.
+func (m *Manager) GetState(ctx context.Context, driverID string) (*DriverState, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.current(ctx, driverID, time.Now())
+}
+
+// GoOnline starts a shift or ends a break. Only approved drivers within
+// their driving-hour limits can go online.
+func (m *Manager) GoOnline(ctx context.Context, driverID string) (*DriverState, error) {
+	if err := m.checkApproved(ctx, driverID); err != nil {
+		return nil, err
+	}
+	if err := m.checkRested(ctx, driverID); err != nil {
+		return nil, err
+	}
+	return m.apply(ctx, driverID, ActionGoOnline, "")
+}
+
+// GoOffline ends a driver's shift. Drivers on a trip must finish it first.
+func (m *Manager) GoOffline(ctx context.Context, driverID string) (*DriverState, error) {
+	return m.apply(ctx, driverID, ActionGoOffline, "")
+}
+
+// StartBreak pauses a shift so the driver receives no offers
+func (m *Manager) StartBreak(ctx context.Context, driverID string) (*DriverState, error) {
+	return m.apply(ctx, driverID, ActionStartBreak, "")
+}
+
+// TripAssigned moves an online driver onto a trip. Repeating the call for the same trip is a no-op.
+func (m *Manager) TripAssigned(ctx context.Context, driverID, tripID string) (*DriverState, error) {
+	return m.apply(ctx, driverID, ActionTripAssigned, tripID)
+}
+
+// TripCompleted returns a driver to online once their trip finishes
+func (m *Manager) TripCompleted(ctx context.Context, driverID, tripID string) (*DriverState, error) {
+	return m.apply(ctx, driverID, ActionTripCompleted, tripID)
+}
+
+// Heartbeat extends a driver's shift without changing their state
+func (m *Manager) Heartbeat(ctx context.Context, driverID string) (*DriverState, error) {
+	return m.heartbeat(ctx, driverID, "", time.Time{})
+}
+
+// ReportLocation records that a driver on shift reported a location in
+// cityID recorded at recordedAt. It counts as a heartbeat and updates when
+// the driver was last seen; locations older than the last one seen leave it
+// as it was.
+func (m *Manager) ReportLocation(ctx context.Context, driverID, cityID string, recordedAt time.Time) (*DriverState, error) {
+	if recordedAt.IsZero() {
+		recordedAt = time.Now()
+	}
+	return m.heartbeat(ctx, driverID, cityID, recordedAt)
+}
+
+// Fatigue returns how much of their city's driving-hour limits the driver
+// has used in the window ending now
+func (m *Manager) Fatigue(ctx context.Context, driverID string) (*FatigueStatus, error) {
+	if m.fatigue == nil {
+		return nil, ErrNoFatigueRules
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	now := time.Now()
+	state, err := m.current(ctx, driverID, now)
+	if err != nil {
+		return nil, err
+	}
+	return m.fatigueStatus(ctx, state, now)
+}
+
+// Shifts returns the driver's shifts that overlap [from, to), finished ones
+// followed by the shift in progress, which ends now and has no end reason
+func (m *Manager) Shifts(ctx context.Context, driverID string, from, to time.Time) ([]*Session, error) {
+	if m.sessions == nil {
+		return nil, ErrNoShiftHistory
+	}
+	shifts, err := m.sessions.ListForDriver(ctx, driverID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	state, err := m.GetState(ctx, driverID)
+	if err != nil {
+		return nil, err
+	}
+	if state.ShiftStartedAt != nil && state.ShiftStartedAt.Before(to) && now.After(from) {
+		shift := newSession(driverID, *state.ShiftStartedAt, now, "")
+		shift.OnTripSeconds, shift.BreakSeconds = shiftTimes(state, now)
+		shifts = append(shifts, shift)
+	}
+	return shifts, nil
+}
+
+// Activity returns the driver's online, on-trip and break time on each UTC
+// day from to to (YYYY-MM-DD), both included, counting the time since
+// their last state change up to now. Days the driver was not on shift are
+// left out.
+func (m *Manager) Activity(ctx context.Context, driverID, from, to string) ([]*DailyActivity, error) {
+	if m.activity == nil {
+		return nil, ErrNoShiftHistory
+	}
+	days, err := m.activity.List(ctx, driverID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	state, err := m.GetState(ctx, driverID)
+	if err != nil {
+		return nil, err
+	}
+	if state.State != StateOffline && !state.UpdatedAt.IsZero() {
+		days = mergeActivity(days, stateActivity(driverID, state.State, state.UpdatedAt, now), from, to)
+	}
+	return days, nil
+}
+
+// heartbeat extends a driver's shift, and records when and in which city
+// they were last seen when locationAt is set
+func (m *Manager) heartbeat(ctx context.Context, driverID, cityID string, locationAt time.Time) (*DriverState, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	now := time.Now()
+	state, err := m.current(ctx, driverID, now)
+	if err != nil {
+		return nil, err
+	}
+	if state.State == StateOffline {
+		return nil, ErrDriverOffline
+	}
+
+	if !locationAt.IsZero() {
+		if locationAt.After(now) {
+			locationAt = now
+		}
+		if state.LastLocationAt == nil || locationAt.After(*state.LastLocationAt) {
+			state.LastLocationAt = &locationAt
+		}
+	}
+	if cityID != "" && cityID != state.CityID {
+		// The driver's new city may limit their hours differently
+		state.CityID = cityID
+		m.refreshFatigue(ctx, state, now)
+	}
+	m.refreshHeartbeat(state, now)
+	if m.fatigueDue(state, now) {
+		return m.checkFatigue(ctx, state, now)
+	}
+	if err := m.store.Save(ctx, state, m.stateTTL(state)); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// ExpireStaleDrivers takes offline every driver whose heartbeat deadline is
+// not after now and returns how many were expired
+func (m *Manager) ExpireStaleDrivers(ctx context.Context, now time.Time) (int, error) {
+	driverIDs, err := m.store.Expired(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, driverID := range driverIDs {
+		if m.expireDriver(ctx, driverID, now) {
+			expired++
+		}
+	}
+	return expired, nil
+}
+
+// StartHeartbeatMonitor periodically expires stale drivers until the context is cancelled
+func (m *Manager) StartHeartbeatMonitor(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if _, err := m.ExpireStaleDrivers(ctx, now); err != nil {
+				m.logger.WithContext(ctx).WithError(err).Warn("Driver heartbeat sweep failed")
+			}
+		}
+	}
+}
+
+// SubscribeTripEvents moves drivers on and off trips as trips are matched and finished
+func (m *Manager) SubscribeTripEvents(bus events.EventBus) error {
+	if err := bus.Subscribe(events.TripMatchedEvent, func(ctx context.Context, event *events.Event) error {
+		driverID, _ := event.Data["driver_id"].(string)
+		if driverID == "" {
+			return nil
+		}
+		_, err := m.TripAssigned(ctx, driverID, event.AggregateID)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	finished := func(ctx context.Context, event *events.Event) error {
+		driverID, _ := event.Data["driver_id"].(string)
+		if driverID == "" {
+			return nil
+		}
+		_, err := m.TripCompleted(ctx, driverID, event.AggregateID)
+		if errors.Is(err, ErrInvalidTransition) {
+			// The driver was never moved onto the trip, e.g. a rider cancelled before pickup
+			return nil
+		}
+		return err
+	}
+	if err := bus.Subscribe(events.TripCompletedEvent, finished); err != nil {
+		return err
+	}
+	return bus.Subscribe(events.TripCancelledEvent, finished)
+}
+
+// apply runs an action against a driver's current state
+func (m *Manager) apply(ctx context.Context, driverID string, action Action, tripID string) (*DriverState, error) {
+	if driverID == "" {
+		return nil, fmt.Errorf("driver ID is required")
+	}
+	if (action == ActionTripAssigned || action == ActionTripCompleted) && tripID == "" {
+		return nil, fmt.Errorf("trip ID is required")
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	now := time.Now()
+	state, err := m.current(ctx, driverID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	switch action {
+	case ActionTripAssigned:
+		if state.State == StateOnTrip && state.TripID == tripID {
+			return state, nil
+		}
+	case ActionTripCompleted:
+		if state.State == StateOnTrip && state.TripID != tripID {
+			return nil, fmt.Errorf("%w: driver is on trip %s", ErrInvalidTransition, state.TripID)
+		}
+	}
+
+	state, err = m.transition(ctx, state, action, tripID, now)
+	if err == nil && m.fatigueDue(state, now) {
+		// e.g. a driver who reached a limit on a trip goes offline once it
+		// is completed
+		return m.checkFatigue(ctx, state, now)
+	}
+	return state, err
+}
+
+// checkApproved refuses drivers who have not completed onboarding. A failed
+// lookup also refuses, so unvetted drivers never receive offers.
+func (m *Manager) checkApproved(ctx context.Context, driverID string) error {
+	if m.approvals == nil || driverID == "" {
+		return nil
+	}
+
+	approved, err := m.approvals.IsDriverApproved(ctx, driverID)
+	if err != nil {
+		m.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+			"driver_id": driverID,
+		}).Warn("Failed to check driver approval")
+		return fmt.Errorf("failed to check driver approval: %w", err)
+	}
+	if !approved {
+		return ErrDriverNotApproved
+	}
+	return nil
+}
+
+// checkRested refuses drivers who reached a driving-hour limit until enough
+// of it has rolled out of the window
+func (m *Manager) checkRested(ctx context.Context, driverID string) error {
+	if m.fatigue == nil || driverID == "" {
+		return nil
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	now := time.Now()
+	state, err := m.current(ctx, driverID, now)
+	if err != nil {
+		return err
+	}
+	if state.State != StateOffline && state.State != StateOnBreak {
+		return nil
+	}
+
+	status, err := m.fatigueStatus(ctx, state, now)
+	if err != nil {
+		return fmt.Errorf("failed to check driving hours: %w", err)
+	}
+	if !status.Reached {
+		return nil
+	}
+	if status.AvailableAt != nil {
+		return fmt.Errorf("%w, available again at %s", ErrFatigueLimitReached, status.AvailableAt.UTC().Format(time.RFC3339))
+	}
+	return ErrFatigueLimitReached
+}
+
+// current loads a driver's state, recording a lapsed heartbeat as going offline
+func (m *Manager) current(ctx context.Context, driverID string, now time.Time) (*DriverState, error) {
+	state, err := m.store.Get(ctx, driverID)
+	if errors.Is(err, ErrStateNotFound) {
+		return offlineState(driverID), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if state.State != StateOffline && !state.HeartbeatExpiresAt.After(now) {
+		return m.transition(ctx, state, m.expiryAction(state, now), "", now)
+	}
+	return state, nil
+}
+
+func (m *Manager) expireDriver(ctx context.Context, driverID string, now time.Time) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	state, err := m.store.Get(ctx, driverID)
+	if errors.Is(err, ErrStateNotFound) {
+		// The state itself has already expired, only the deadline entry is left
+		state = &DriverState{DriverID: driverID, State: StateOnline}
+	} else if err != nil || state.State == StateOffline || state.HeartbeatExpiresAt.After(now) {
+		return false
+	}
+
+	_, err = m.transition(ctx, state, m.expiryAction(state, now), "", now)
+	return err == nil
+}
+
+// expiryAction is why a driver whose deadline passed goes offline: they
+// stopped reporting locations, or stopped sending heartbeats altogether
+func (m *Manager) expiryAction(state *DriverState, now time.Time) Action {
+	if deadline, ok := m.locationDeadline(state); ok && !deadline.After(now) {
+		return ActionLocationLost
+	}
+	return ActionHeartbeatExpired
+}
+
+// transition applies an action, saves the new state and publishes the change
+func (m *Manager) transition(ctx context.Context, state *DriverState, action Action, tripID string, now time.Time) (*DriverState, error) {
+	next, err := nextState(state.State, action)
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot %s while %s", err, action, state.State)
+	}
+
+	previous := state.State
+	previousTripID := state.TripID
+	var shiftDuration time.Duration
+	var session *Session
+
+	// The time since the last state change is credited to the state the
+	// driver was in, for drivers who went silent only up to when they were
+	// last heard from
+	end := stateEnd(state, action, now)
+	var activity []*DailyActivity
+	var ended []Segment
+	if previous != StateOffline && !state.UpdatedAt.IsZero() {
+		activity = stateActivity(state.DriverID, previous, state.UpdatedAt, end)
+		state.OnTripSeconds, state.BreakSeconds = shiftTimes(state, end)
+		if end.After(state.UpdatedAt) {
+			ended = append(ended, Segment{State: previous, From: state.UpdatedAt, To: end})
+		}
+	}
+
+	state.State = next
+	state.Version++
+	state.UpdatedAt = now
+	state.TripID = ""
+	if next == StateOnTrip {
+		state.TripID = tripID
+	}
+	if previous == StateOffline {
+		state.ShiftStartedAt = &now
+		state.LastLocationAt = nil
+		state.OnTripSeconds = 0
+		state.BreakSeconds = 0
+		state.FatigueWarnedAt = nil
+		activity = append(activity, &DailyActivity{DriverID: state.DriverID, Date: now.UTC().Format(ActivityDateLayout), Shifts: 1})
+	}
+	if next == StateOffline {
+		if state.ShiftStartedAt != nil {
+			shiftDuration = end.Sub(*state.ShiftStartedAt)
+			session = newSession(state.DriverID, *state.ShiftStartedAt, end, action)
+			session.OnTripSeconds = state.OnTripSeconds
+			session.BreakSeconds = state.BreakSeconds
+		}
+		state.ShiftStartedAt = nil
+		state.OnTripSeconds = 0
+		state.BreakSeconds = 0
+		state.FatigueLimitAt = nil
+		state.FatigueWarnedAt = nil
+	} else {
+		m.refreshFatigue(ctx, state, now, ended...)
+		m.refreshHeartbeat(state, now)
+	}
+
+	if err := m.store.Save(ctx, state, m.stateTTL(state)); err != nil {
+		m.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+			"driver_id": state.DriverID,
+			"action":    action,
+		}).Error("Failed to save driver state")
+		return nil, fmt.Errorf("failed to save driver state: %w", err)
+	}
+
+	m.logger.WithContext(ctx).WithFields(logger.Fields{
+		"driver_id": state.DriverID,
+		"from":      previous,
+		"to":        next,
+		"action":    action,
+	}).Info("Driver state changed")
+
+	if session != nil && m.sessions != nil {
+		// The shift has ended either way, so a failed save only loses its export row
+		if err := m.sessions.Save(ctx, session); err != nil {
+			m.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+				"driver_id": state.DriverID,
+			}).Warn("Failed to save driver session")
+		}
+	}
+	m.recordActivity(ctx, state.DriverID, activity, session)
+	m.recordSegments(ctx, state.DriverID, ended)
+
+	if previousTripID != "" && tripID == "" {
+		tripID = previousTripID
+	}
+	m.publish(ctx, state, previous, action, tripID, shiftDuration)
+	return state, nil
+}
+
+// recordActivity adds the time credited by a state change to the driver's
+// days, and records the utilization of a shift that ended
+func (m *Manager) recordActivity(ctx context.Context, driverID string, activity []*DailyActivity, session *Session) {
+	if m.activity != nil {
+		for _, day := range activity {
+			// The state has changed either way, so a failed save only loses
+			// the time from the driver's online hours
+			if err := m.activity.Add(ctx, day); err != nil {
+				m.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+					"driver_id": driverID,
+					"date":      day.Date,
+				}).Warn("Failed to save driver activity")
+			}
+		}
+	}
+
+	if session != nil && m.utilization != nil && session.OnlineSeconds() > 0 {
+		m.utilization.RecordDriverUtilization(float64(session.OnTripSeconds) / float64(session.OnlineSeconds()))
+	}
+}
+
+// recordSegments logs the shift segments a state change ended for
+// driving-hour limits
+func (m *Manager) recordSegments(ctx context.Context, driverID string, segments []Segment) {
+	if m.fatigueLog == nil {
+		return
+	}
+	for _, segment := range segments {
+		// The state has changed either way, so a failed save only leaves the
+		// time out of the driver's driving hours
+		if err := m.fatigueLog.Add(ctx, driverID, segment); err != nil {
+			m.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+				"driver_id": driverID,
+			}).Warn("Failed to save shift segment")
+		}
+	}
+}
+
+// fatigueStatus counts the driver's time against their city's driving-hour
+// limits, with segments that ended but are not logged yet
+func (m *Manager) fatigueStatus(ctx context.Context, state *DriverState, now time.Time, ended ...Segment) (*FatigueStatus, error) {
+	limits := m.fatigue.Limits(state.CityID)
+	var segments []Segment
+	if limits.enforced() && m.fatigueLog != nil {
+		logged, err := m.fatigueLog.Since(ctx, state.DriverID, now.Add(-limits.Window))
+		if err != nil {
+			return nil, err
+		}
+		segments = logged
+	}
+	return fatigueStatus(state, append(segments, ended...), limits, now), nil
+}
+
+// refreshFatigue moves when the driver reaches a driving-hour limit in
+// their current state
+func (m *Manager) refreshFatigue(ctx context.Context, state *DriverState, now time.Time, ended ...Segment) {
+	if m.fatigue == nil {
+		return
+	}
+	status, err := m.fatigueStatus(ctx, state, now, ended...)
+	if err != nil {
+		// The limit is checked again at the driver's next heartbeat
+		m.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+			"driver_id": state.DriverID,
+		}).Warn("Failed to count driving hours")
+		state.FatigueLimitAt = &now
+		return
+	}
+	state.FatigueLimitAt = status.LimitAt
+}
+
+// fatigueDue reports whether a driver on shift is to be warned of or taken
+// offline for a driving-hour limit
+func (m *Manager) fatigueDue(state *DriverState, now time.Time) bool {
+	if m.fatigue == nil || state.State == StateOffline || state.FatigueLimitAt == nil {
+		return false
+	}
+	if !now.Before(*state.FatigueLimitAt) {
+		return true
+	}
+	warnAt := state.FatigueLimitAt.Add(-m.fatigue.Limits(state.CityID).WarnBefore)
+	return state.FatigueWarnedAt == nil && !now.Before(warnAt)
+}
+
+// checkFatigue takes offline a driver who reached a driving-hour limit,
+// letting a driver on a trip finish it first, and warns a driver nearing
+// one once a shift. As time rolls out of the window the limit may turn out
+// to be further away than it was expected to be.
+func (m *Manager) checkFatigue(ctx context.Context, state *DriverState, now time.Time) (*DriverState, error) {
+	status, err := m.fatigueStatus(ctx, state, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count driving hours: %w", err)
+	}
+	if status.Reached && state.State != StateOnTrip {
+		return m.transition(ctx, state, ActionFatigueLimit, "", now)
+	}
+
+	state.FatigueLimitAt = status.LimitAt
+	if status.LimitAt != nil && state.FatigueWarnedAt == nil &&
+		!now.Before(status.LimitAt.Add(-m.fatigue.Limits(state.CityID).WarnBefore)) {
+		state.FatigueWarnedAt = &now
+		m.publishFatigueWarning(ctx, state, status, now)
+	}
+	if err := m.store.Save(ctx, state, m.stateTTL(state)); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// stateEnd is when a driver leaving their state by action stopped being in
+// it: now, or when a driver who went silent was last heard from. It is
+// never before their last state change.
+func stateEnd(state *DriverState, action Action, now time.Time) time.Time {
+	end := now
+	switch action {
+	case ActionHeartbeatExpired:
+		end = state.LastHeartbeatAt
+	case ActionLocationLost:
+		end = state.UpdatedAt
+		if state.LastLocationAt != nil {
+			end = *state.LastLocationAt
+		}
+	}
+	if end.Before(state.UpdatedAt) {
+		end = state.UpdatedAt
+	}
+	if end.After(now) {
+		end = now
+	}
+	return end
+}
+
+// shiftTimes returns the driver's time on trips and on breaks this shift,
+// counting their current state up to at
+func shiftTimes(state *DriverState, at time.Time) (int64, int64) {
+	onTrip, onBreak := state.OnTripSeconds, state.BreakSeconds
+	if state.UpdatedAt.IsZero() || !at.After(state.UpdatedAt) {
+		return onTrip, onBreak
+	}
+	seconds := int64(at.Sub(state.UpdatedAt).Seconds())
+	switch state.State {
+	case StateOnTrip:
+		onTrip += seconds
+	case StateOnBreak:
+		onBreak += seconds
+	}
+	return onTrip, onBreak
+}
+
+// refreshHeartbeat moves the driver's deadline to a heartbeat TTL from now,
+// or to their location deadline when that comes first
+func (m *Manager) refreshHeartbeat(state *DriverState, now time.Time) {
+	state.LastHeartbeatAt = now
+	state.HeartbeatExpiresAt = now.Add(m.heartbeatTTL)
+	if deadline, ok := m.locationDeadline(state); ok && deadline.Before(state.HeartbeatExpiresAt) {
+		state.HeartbeatExpiresAt = deadline
+	}
+}
+
+// locationDeadline is when a driver on shift who reports no further
+// location is taken offline: a location TTL after their last location, or
+// after their shift started when they have reported none
+func (m *Manager) locationDeadline(state *DriverState) (time.Time, bool) {
+	if m.locationTTL <= 0 {
+		return time.Time{}, false
+	}
+	since := state.LastLocationAt
+	if since == nil {
+		since = state.ShiftStartedAt
+	}
+	if since == nil {
+		return time.Time{}, false
+	}
+	return since.Add(m.locationTTL), true
+}
+
+// stateTTL keeps on-shift states a little past their heartbeat deadline so
+// expiry sweeps can still see what the driver was doing
+func (m *Manager) stateTTL(state *DriverState) time.Duration {
+	if state.State == StateOffline {
+		return offlineRetention
+	}
+	return 2 * m.heartbeatTTL
+}
+
+// publish emits a state change event, plus shift start and end events
+func (m *Manager) publish(ctx context.Context, state *DriverState, previous State, action Action, tripID string, shiftDuration time.Duration) {
+	if m.bus == nil {
+		return
+	}
+
+	changed := map[string]interface{}{
+		"driver_id": state.DriverID,
+		"from":      string(previous),
+		"to":        string(state.State),
+		"action":    string(action),
+	}
+	if tripID != "" {
+		changed["trip_id"] = tripID
+	}
+	published := []*events.Event{events.NewEvent(events.DriverStateChangedEvent, state.DriverID, state.Version, changed, "geo-service")}
+
+	switch {
+	case previous == StateOffline:
+		published = append(published, events.NewEvent(events.DriverOnlineEvent, state.DriverID, state.Version, map[string]interface{}{
+			"driver_id":        state.DriverID,
+			"shift_started_at": state.ShiftStartedAt,
+		}, "geo-service"))
+	case state.State == StateOffline:
+		published = append(published, events.NewEvent(events.DriverOfflineEvent, state.DriverID, state.Version, map[string]interface{}{
+			"driver_id":              state.DriverID,
+			"reason":                 string(action),
+			"shift_duration_seconds": int(shiftDuration.Seconds()),
+		}, "geo-service"))
+	}
+
+	m.publishEvents(ctx, state.DriverID, published)
+}
+
+// publishFatigueWarning tells a driver they are nearing a driving-hour
+// limit, or have reached it and go offline after their trip
+func (m *Manager) publishFatigueWarning(ctx context.Context, state *DriverState, status *FatigueStatus, now time.Time) {
+	m.logger.WithContext(ctx).WithFields(logger.Fields{
+		"driver_id": state.DriverID,
+		"city_id":   state.CityID,
+		"limit_at":  status.LimitAt,
+	}).Info("Driver nearing driving-hour limit")
+	if m.bus == nil {
+		return
+	}
+
+	data := map[string]interface{}{
+		"driver_id":         state.DriverID,
+		"state":             string(state.State),
+		"online_seconds":    status.OnlineSeconds,
+		"driving_seconds":   status.DrivingSeconds,
+		"limit_at":          status.LimitAt,
+		"remaining_seconds": int(status.LimitAt.Sub(now).Seconds()),
+	}
+	if state.CityID != "" {
+		data["city_id"] = state.CityID
+	}
+	m.publishEvents(ctx, state.DriverID, []*events.Event{
+		events.NewEvent(events.DriverFatigueWarningEvent, state.DriverID, state.Version, data, "geo-service"),
+	})
+}
+
+func (m *Manager) publishEvents(ctx context.Context, driverID string, published []*events.Event) {
+	for _, event := range published {
+		if err := m.bus.Publish(ctx, event); err != nil {
+			m.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+				"driver_id":  driverID,
+				"event_type": event.Type,
+			}).Warn("Failed to publish driver state event")
+		}
+	}
+}
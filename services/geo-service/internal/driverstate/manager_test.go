@@ -0,0 +1,574 @@
+package driverstate
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rideshare-platform/shared/events"
+	"github.com/rideshare-platform/shared/export"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingBus keeps published events and runs subscribers synchronously
+type recordingBus struct {
+	published []*events.Event
+	handlers  map[events.EventType][]events.EventHandler
+	mutex     sync.Mutex
+}
+
+func newRecordingBus() *recordingBus {
+	return &recordingBus{handlers: make(map[events.EventType][]events.EventHandler)}
+}
+
+func (b *recordingBus) Publish(ctx context.Context, event *events.Event) error {
+	b.mutex.Lock()
+	b.published = append(b.published, event)
+	b.mutex.Unlock()
+	return nil
+}
+
+func (b *recordingBus) Subscribe(eventType events.EventType, handler events.EventHandler) error {
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+	return nil
+}
+
+func (b *recordingBus) Unsubscribe(eventType events.EventType, handler events.EventHandler) error {
+	return nil
+}
+
+func (b *recordingBus) Close() error {
+	return nil
+}
+
+func (b *recordingBus) deliver(ctx context.Context, event *events.Event) error {
+	for _, handler := range b.handlers[event.Type] {
+		if err := handler(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *recordingBus) types() []events.EventType {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	types := make([]events.EventType, 0, len(b.published))
+	for _, event := range b.published {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+// fakeApprovals approves a fixed set of drivers, or fails every lookup
+type fakeApprovals struct {
+	approved map[string]bool
+	err      error
+}
+
+func (f *fakeApprovals) IsDriverApproved(ctx context.Context, driverID string) (bool, error) {
+	if f.err != nil {
+		return false, f.err
+	}
+	return f.approved[driverID], nil
+}
+
+func newTestManager(bus events.EventBus) *Manager {
+	return NewManager(NewMemoryStore(), bus, time.Minute, logger.NewLogger("test", "info"))
+}
+
+func TestManager_ShiftLifecycle(t *testing.T) {
+	bus := newRecordingBus()
+	manager := newTestManager(bus)
+	ctx := context.Background()
+
+	state, err := manager.GetState(ctx, "driver-1")
+	assert.NoError(t, err)
+	assert.Equal(t, StateOffline, state.State)
+
+	state, err = manager.GoOnline(ctx, "driver-1")
+	assert.NoError(t, err)
+	assert.Equal(t, StateOnline, state.State)
+	assert.NotNil(t, state.ShiftStartedAt)
+	assert.True(t, state.Available())
+
+	state, err = manager.StartBreak(ctx, "driver-1")
+	assert.NoError(t, err)
+	assert.Equal(t, StateOnBreak, state.State)
+	assert.False(t, state.Available())
+
+	_, err = manager.TripAssigned(ctx, "driver-1", "trip-1")
+	assert.ErrorIs(t, err, ErrInvalidTransition)
+
+	state, err = manager.GoOnline(ctx, "driver-1")
+	assert.NoError(t, err)
+	assert.Equal(t, StateOnline, state.State)
+
+	state, err = manager.GoOffline(ctx, "driver-1")
+	assert.NoError(t, err)
+	assert.Equal(t, StateOffline, state.State)
+	assert.Nil(t, state.ShiftStartedAt)
+	assert.Equal(t, 4, state.Version)
+
+	assert.Equal(t, []events.EventType{
+		events.DriverStateChangedEvent, events.DriverOnlineEvent,
+		events.DriverStateChangedEvent,
+		events.DriverStateChangedEvent,
+		events.DriverStateChangedEvent, events.DriverOfflineEvent,
+	}, bus.types())
+	assert.Equal(t, "go_offline", bus.published[5].Data["reason"])
+}
+
+func TestManager_TripTransitions(t *testing.T) {
+	manager := newTestManager(nil)
+	ctx := context.Background()
+
+	_, err := manager.GoOnline(ctx, "driver-1")
+	assert.NoError(t, err)
+
+	state, err := manager.TripAssigned(ctx, "driver-1", "trip-1")
+	assert.NoError(t, err)
+	assert.Equal(t, StateOnTrip, state.State)
+	assert.Equal(t, "trip-1", state.TripID)
+
+	// Assignment is idempotent for the same trip, but a second trip is refused
+	state, err = manager.TripAssigned(ctx, "driver-1", "trip-1")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, state.Version)
+	_, err = manager.TripAssigned(ctx, "driver-1", "trip-2")
+	assert.ErrorIs(t, err, ErrInvalidTransition)
+
+	// Drivers cannot end their shift mid-trip
+	_, err = manager.GoOffline(ctx, "driver-1")
+	assert.ErrorIs(t, err, ErrInvalidTransition)
+
+	_, err = manager.TripCompleted(ctx, "driver-1", "trip-2")
+	assert.ErrorIs(t, err, ErrInvalidTransition)
+
+	state, err = manager.TripCompleted(ctx, "driver-1", "trip-1")
+	assert.NoError(t, err)
+	assert.Equal(t, StateOnline, state.State)
+	assert.Empty(t, state.TripID)
+}
+
+func TestManager_HeartbeatExpiry(t *testing.T) {
+	bus := newRecordingBus()
+	manager := newTestManager(bus)
+	ctx := context.Background()
+
+	_, err := manager.Heartbeat(ctx, "driver-1")
+	assert.ErrorIs(t, err, ErrDriverOffline)
+
+	_, err = manager.GoOnline(ctx, "driver-1")
+	assert.NoError(t, err)
+	_, err = manager.GoOnline(ctx, "driver-2")
+	assert.NoError(t, err)
+
+	state, err := manager.Heartbeat(ctx, "driver-2")
+	assert.NoError(t, err)
+	assert.Equal(t, StateOnline, state.State)
+
+	expired, err := manager.ExpireStaleDrivers(ctx, time.Now().Add(30*time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, expired)
+
+	expired, err = manager.ExpireStaleDrivers(ctx, time.Now().Add(2*time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, expired)
+
+	state, err = manager.GetState(ctx, "driver-1")
+	assert.NoError(t, err)
+	assert.Equal(t, StateOffline, state.State)
+
+	last := bus.published[len(bus.published)-1]
+	assert.Equal(t, events.DriverOfflineEvent, last.Type)
+	assert.Equal(t, "heartbeat_expired", last.Data["reason"])
+}
+
+func TestManager_LocationSilenceExpiry(t *testing.T) {
+	bus := newRecordingBus()
+	manager := newTestManager(bus)
+	manager.SetLocationTTL(30 * time.Second)
+	ctx := context.Background()
+
+	_, err := manager.GoOnline(ctx, "driver-1")
+	assert.NoError(t, err)
+	_, err = manager.GoOnline(ctx, "driver-2")
+	assert.NoError(t, err)
+
+	reportedAt := time.Now().Add(-10 * time.Second)
+	state, err := manager.ReportLocation(ctx, "driver-1", "", reportedAt)
+	assert.NoError(t, err)
+	assert.True(t, reportedAt.Equal(*state.LastLocationAt))
+	// An older location does not move the driver's last seen time back
+	state, err = manager.ReportLocation(ctx, "driver-1", "", reportedAt.Add(-time.Minute))
+	assert.NoError(t, err)
+	assert.True(t, reportedAt.Equal(*state.LastLocationAt))
+
+	// Heartbeats alone do not keep a driver who reports no location online
+	_, err = manager.Heartbeat(ctx, "driver-2")
+	assert.NoError(t, err)
+
+	expired, err := manager.ExpireStaleDrivers(ctx, time.Now().Add(25*time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, expired, "driver-1 was last seen over 30 seconds before")
+	state, err = manager.GetState(ctx, "driver-1")
+	assert.NoError(t, err)
+	assert.Equal(t, StateOffline, state.State)
+	assert.Equal(t, "location_lost", bus.published[len(bus.published)-1].Data["reason"])
+
+	expired, err = manager.ExpireStaleDrivers(ctx, time.Now().Add(35*time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, expired, "driver-2 never reported a location")
+}
+
+func TestManager_FollowsTripEvents(t *testing.T) {
+	bus := newRecordingBus()
+	manager := newTestManager(bus)
+	ctx := context.Background()
+	assert.NoError(t, manager.SubscribeTripEvents(bus))
+
+	_, err := manager.GoOnline(ctx, "driver-1")
+	assert.NoError(t, err)
+
+	matched := events.NewEvent(events.TripMatchedEvent, "trip-1", 1, map[string]interface{}{"driver_id": "driver-1"}, "matching-service")
+	assert.NoError(t, bus.deliver(ctx, matched))
+
+	state, err := manager.GetState(ctx, "driver-1")
+	assert.NoError(t, err)
+	assert.Equal(t, StateOnTrip, state.State)
+
+	// A cancellation for a trip the driver never took is ignored
+	cancelled := events.NewEvent(events.TripCancelledEvent, "trip-9", 1, map[string]interface{}{"driver_id": "driver-2"}, "trip-service")
+	assert.NoError(t, bus.deliver(ctx, cancelled))
+
+	completed := events.NewEvent(events.TripCompletedEvent, "trip-1", 2, map[string]interface{}{"driver_id": "driver-1"}, "trip-service")
+	assert.NoError(t, bus.deliver(ctx, completed))
+
+	state, err = manager.GetState(ctx, "driver-1")
+	assert.NoError(t, err)
+	assert.Equal(t, StateOnline, state.State)
+}
+
+func TestManager_OnlyApprovedDriversGoOnline(t *testing.T) {
+	manager := newTestManager(nil)
+	approvals := &fakeApprovals{approved: map[string]bool{"driver-1": true}}
+	manager.SetApprovalChecker(approvals)
+	ctx := context.Background()
+
+	_, err := manager.GoOnline(ctx, "driver-2")
+	assert.ErrorIs(t, err, ErrDriverNotApproved)
+
+	state, err := manager.GetState(ctx, "driver-2")
+	assert.NoError(t, err)
+	assert.Equal(t, StateOffline, state.State)
+
+	state, err = manager.GoOnline(ctx, "driver-1")
+	assert.NoError(t, err)
+	assert.Equal(t, StateOnline, state.State)
+
+	// Lookups that fail keep the driver offline
+	approvals.err = errors.New("user-service unavailable")
+	_, err = manager.GoOffline(ctx, "driver-1")
+	assert.NoError(t, err)
+	_, err = manager.GoOnline(ctx, "driver-1")
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrDriverNotApproved)
+}
+
+func TestManager_RecordsFinishedShifts(t *testing.T) {
+	manager := newTestManager(nil)
+	sessions := NewMemorySessionLog()
+	manager.SetSessionLog(sessions)
+	ctx := context.Background()
+
+	_, err := manager.GoOnline(ctx, "driver-1")
+	assert.NoError(t, err)
+	_, err = manager.StartBreak(ctx, "driver-1")
+	assert.NoError(t, err)
+
+	listed, err := sessions.ListSince(ctx, export.Watermark{}, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, listed, "the shift has not ended")
+
+	_, err = manager.GoOffline(ctx, "driver-1")
+	assert.NoError(t, err)
+	_, err = manager.GoOnline(ctx, "driver-1")
+	assert.NoError(t, err)
+	_, err = manager.GoOffline(ctx, "driver-1")
+	assert.NoError(t, err)
+
+	listed, err = sessions.ListSince(ctx, export.Watermark{}, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, listed, 2) {
+		assert.Equal(t, "driver-1", listed[0].DriverID)
+		assert.Equal(t, ActionGoOffline, listed[0].EndReason)
+		assert.False(t, listed[0].EndedAt.Before(listed[0].StartedAt))
+		assert.NotEqual(t, listed[0].ID, listed[1].ID)
+	}
+
+	listed, err = sessions.ListSince(ctx, export.Watermark{UpdatedAt: listed[0].EndedAt, ID: listed[0].ID}, 10)
+	assert.NoError(t, err)
+	assert.Len(t, listed, 1)
+}
+
+func TestManager_Shifts(t *testing.T) {
+	manager := newTestManager(nil)
+	ctx := context.Background()
+	from := time.Now().Add(-time.Hour)
+
+	_, err := manager.Shifts(ctx, "driver-1", from, time.Now().Add(time.Hour))
+	assert.ErrorIs(t, err, ErrNoShiftHistory)
+
+	manager.SetSessionLog(NewMemorySessionLog())
+	for _, driverID := range []string{"driver-1", "driver-2"} {
+		_, err = manager.GoOnline(ctx, driverID)
+		assert.NoError(t, err)
+		_, err = manager.GoOffline(ctx, driverID)
+		assert.NoError(t, err)
+	}
+	_, err = manager.GoOnline(ctx, "driver-1")
+	assert.NoError(t, err)
+
+	shifts, err := manager.Shifts(ctx, "driver-1", from, time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	if assert.Len(t, shifts, 2) {
+		assert.Equal(t, ActionGoOffline, shifts[0].EndReason)
+		assert.Equal(t, "driver-1", shifts[1].DriverID)
+		assert.Empty(t, shifts[1].EndReason, "the shift in progress has not ended")
+	}
+
+	shifts, err = manager.Shifts(ctx, "driver-1", from.Add(-2*time.Hour), from)
+	assert.NoError(t, err)
+	assert.Empty(t, shifts)
+}
+
+// backdate moves a driver's saved state by ago into the past, as if their
+// last state change happened that long before, keeping their heartbeat
+// deadline
+func backdate(t *testing.T, store Store, driverID string, ago time.Duration) {
+	state, err := store.Get(context.Background(), driverID)
+	if !assert.NoError(t, err) {
+		return
+	}
+	state.UpdatedAt = state.UpdatedAt.Add(-ago)
+	state.LastHeartbeatAt = state.LastHeartbeatAt.Add(-ago)
+	if state.ShiftStartedAt != nil {
+		started := state.ShiftStartedAt.Add(-ago)
+		state.ShiftStartedAt = &started
+	}
+	if state.FatigueLimitAt != nil {
+		limitAt := state.FatigueLimitAt.Add(-ago)
+		state.FatigueLimitAt = &limitAt
+	}
+	assert.NoError(t, store.Save(context.Background(), state, time.Hour))
+}
+
+type utilizationRecorder struct {
+	ratios []float64
+}
+
+func (r *utilizationRecorder) RecordDriverUtilization(utilizationRatio float64) {
+	r.ratios = append(r.ratios, utilizationRatio)
+}
+
+func TestManager_Activity(t *testing.T) {
+	store := NewMemoryStore()
+	manager := NewManager(store, nil, time.Minute, logger.NewLogger("test", "info"))
+	ctx := context.Background()
+	// Backdated shifts may start the day before
+	from := time.Now().UTC().AddDate(0, 0, -1).Format(ActivityDateLayout)
+	to := time.Now().UTC().Format(ActivityDateLayout)
+
+	_, err := manager.Activity(ctx, "driver-1", from, to)
+	assert.ErrorIs(t, err, ErrNoShiftHistory)
+
+	sessions := NewMemorySessionLog()
+	recorder := &utilizationRecorder{}
+	manager.SetSessionLog(sessions)
+	manager.SetActivityLog(NewMemoryActivityLog())
+	manager.SetUtilizationRecorder(recorder)
+
+	_, err = manager.Activity(ctx, "driver-1", to, from)
+	assert.ErrorIs(t, err, ErrInvalidActivityRange)
+
+	_, err = manager.GoOnline(ctx, "driver-1")
+	assert.NoError(t, err)
+	backdate(t, store, "driver-1", 30*time.Minute)
+	_, err = manager.TripAssigned(ctx, "driver-1", "trip-1")
+	assert.NoError(t, err)
+	backdate(t, store, "driver-1", 20*time.Minute)
+	_, err = manager.TripCompleted(ctx, "driver-1", "trip-1")
+	assert.NoError(t, err)
+	backdate(t, store, "driver-1", 10*time.Minute)
+	_, err = manager.StartBreak(ctx, "driver-1")
+	assert.NoError(t, err)
+	backdate(t, store, "driver-1", 10*time.Minute)
+
+	// The break in progress is counted up to now
+	days, err := manager.Activity(ctx, "driver-1", from, to)
+	assert.NoError(t, err)
+	totals := SumActivity(days)
+	assert.InDelta(t, 3600, totals.OnlineSeconds, 2)
+	assert.InDelta(t, 1200, totals.OnTripSeconds, 2)
+	assert.InDelta(t, 600, totals.BreakSeconds, 2)
+	assert.Equal(t, 1, totals.Shifts)
+	assert.InDelta(t, 1.0/3, totals.Utilization, 0.01)
+
+	_, err = manager.GoOffline(ctx, "driver-1")
+	assert.NoError(t, err)
+	days, err = manager.Activity(ctx, "driver-1", from, to)
+	assert.NoError(t, err)
+	assert.InDelta(t, 600, SumActivity(days).BreakSeconds, 2)
+
+	listed, err := sessions.ListSince(ctx, export.Watermark{}, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, listed, 1) {
+		assert.InDelta(t, 1200, listed[0].OnTripSeconds, 2)
+		assert.InDelta(t, 600, listed[0].BreakSeconds, 2)
+		assert.InDelta(t, 3600, listed[0].OnlineSeconds(), 2)
+	}
+	if assert.Len(t, recorder.ratios, 1) {
+		assert.InDelta(t, 1.0/3, recorder.ratios[0], 0.01)
+	}
+}
+
+func TestManager_ActivityEndsAtLastHeartbeat(t *testing.T) {
+	store := NewMemoryStore()
+	manager := NewManager(store, nil, time.Minute, logger.NewLogger("test", "info"))
+	sessions := NewMemorySessionLog()
+	manager.SetSessionLog(sessions)
+	manager.SetActivityLog(NewMemoryActivityLog())
+	ctx := context.Background()
+
+	_, err := manager.GoOnline(ctx, "driver-1")
+	assert.NoError(t, err)
+	backdate(t, store, "driver-1", 30*time.Minute)
+	_, err = manager.Heartbeat(ctx, "driver-1")
+	assert.NoError(t, err)
+	backdate(t, store, "driver-1", 10*time.Minute)
+
+	expired, err := manager.ExpireStaleDrivers(ctx, time.Now().Add(2*time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, expired)
+
+	// The driver went silent ten minutes ago, so only the half hour before
+	// their last heartbeat is online time
+	days, err := manager.Activity(ctx, "driver-1",
+		time.Now().UTC().AddDate(0, 0, -1).Format(ActivityDateLayout),
+		time.Now().UTC().Format(ActivityDateLayout))
+	assert.NoError(t, err)
+	assert.InDelta(t, 1800, SumActivity(days).OnlineSeconds, 2)
+
+	listed, err := sessions.ListSince(ctx, export.Watermark{}, 10)
+	assert.NoError(t, err)
+	if assert.Len(t, listed, 1) {
+		assert.Equal(t, ActionHeartbeatExpired, listed[0].EndReason)
+		assert.InDelta(t, 1800, listed[0].EndedAt.Sub(listed[0].StartedAt).Seconds(), 2)
+	}
+}
+
+// cityLimits limits driving hours by city
+type cityLimits map[string]FatigueLimits
+
+func (c cityLimits) Limits(cityID string) FatigueLimits {
+	return c[cityID]
+}
+
+func TestManager_FatigueLimit(t *testing.T) {
+	bus := newRecordingBus()
+	store := NewMemoryStore()
+	manager := NewManager(store, bus, time.Hour, logger.NewLogger("test", "info"))
+	ctx := context.Background()
+
+	_, err := manager.Fatigue(ctx, "driver-1")
+	assert.ErrorIs(t, err, ErrNoFatigueRules)
+
+	manager.SetFatigueRules(NewCityFatiguePolicy(nil, FatigueLimits{
+		MaxOnline:  time.Hour,
+		Window:     24 * time.Hour,
+		WarnBefore: 15 * time.Minute,
+	}), NewMemoryFatigueLog())
+
+	state, err := manager.GoOnline(ctx, "driver-1")
+	assert.NoError(t, err)
+	if assert.NotNil(t, state.FatigueLimitAt) {
+		assert.WithinDuration(t, time.Now().Add(time.Hour), *state.FatigueLimitAt, 2*time.Second)
+	}
+	assert.Nil(t, state.FatigueWarnedAt)
+
+	// Drivers are warned once as they near the limit
+	backdate(t, store, "driver-1", 50*time.Minute)
+	state, err = manager.Heartbeat(ctx, "driver-1")
+	assert.NoError(t, err)
+	assert.NotNil(t, state.FatigueWarnedAt)
+	assert.Equal(t, events.DriverFatigueWarningEvent, bus.published[len(bus.published)-1].Type)
+	warnings := len(bus.published)
+	_, err = manager.Heartbeat(ctx, "driver-1")
+	assert.NoError(t, err)
+	assert.Len(t, bus.published, warnings)
+
+	// A driver reaching the limit on a trip finishes it first
+	_, err = manager.TripAssigned(ctx, "driver-1", "trip-1")
+	assert.NoError(t, err)
+	backdate(t, store, "driver-1", 20*time.Minute)
+	state, err = manager.Heartbeat(ctx, "driver-1")
+	assert.NoError(t, err)
+	assert.Equal(t, StateOnTrip, state.State)
+
+	state, err = manager.TripCompleted(ctx, "driver-1", "trip-1")
+	assert.NoError(t, err)
+	assert.Equal(t, StateOffline, state.State)
+	last := bus.published[len(bus.published)-1]
+	assert.Equal(t, events.DriverOfflineEvent, last.Type)
+	assert.Equal(t, string(ActionFatigueLimit), last.Data["reason"])
+
+	// They can go online again once enough time rolls out of the window
+	_, err = manager.GoOnline(ctx, "driver-1")
+	assert.ErrorIs(t, err, ErrFatigueLimitReached)
+	status, err := manager.Fatigue(ctx, "driver-1")
+	assert.NoError(t, err)
+	assert.True(t, status.Reached)
+	assert.InDelta(t, 4200, status.OnlineSeconds, 2)
+	assert.InDelta(t, 1200, status.DrivingSeconds, 2)
+	if assert.NotNil(t, status.AvailableAt) {
+		assert.True(t, status.AvailableAt.After(time.Now().Add(23*time.Hour)))
+		assert.True(t, status.AvailableAt.Before(time.Now().Add(24*time.Hour)))
+	}
+}
+
+func TestManager_FatigueLimitsByCity(t *testing.T) {
+	store := NewMemoryStore()
+	manager := NewManager(store, nil, time.Hour, logger.NewLogger("test", "info"))
+	manager.SetFatigueRules(cityLimits{
+		"":         {MaxOnline: 10 * time.Hour, Window: 24 * time.Hour},
+		"istanbul": {MaxOnline: 10 * time.Hour, MaxDriving: 2 * time.Hour, Window: 24 * time.Hour},
+	}, NewMemoryFatigueLog())
+	ctx := context.Background()
+
+	state, err := manager.GoOnline(ctx, "driver-1")
+	assert.NoError(t, err)
+	if assert.NotNil(t, state.FatigueLimitAt) {
+		assert.WithinDuration(t, time.Now().Add(10*time.Hour), *state.FatigueLimitAt, 2*time.Second)
+	}
+
+	// Breaks count toward neither limit
+	state, err = manager.StartBreak(ctx, "driver-1")
+	assert.NoError(t, err)
+	assert.Nil(t, state.FatigueLimitAt)
+
+	// Time on trips counts toward the driving limit where there is one
+	_, err = manager.GoOnline(ctx, "driver-1")
+	assert.NoError(t, err)
+	_, err = manager.ReportLocation(ctx, "driver-1", "istanbul", time.Now())
+	assert.NoError(t, err)
+	state, err = manager.TripAssigned(ctx, "driver-1", "trip-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "istanbul", state.CityID)
+	if assert.NotNil(t, state.FatigueLimitAt) {
+		assert.WithinDuration(t, time.Now().Add(2*time.Hour), *state.FatigueLimitAt, 2*time.Second)
+	}
+}
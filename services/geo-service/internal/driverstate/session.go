@@ -0,0 +1,271 @@
+package driverstate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rideshare-platform/shared/export"
+)
+
+// sessionRetention is how long finished shifts are kept for export
+const sessionRetention = 30 * 24 * time.Hour
+
+// The {driver_sessions} hash tag keeps every driver's shifts and the set of
+// all shifts in one Redis Cluster slot, so Save can add to both in a transaction
+const redisSessionsKey = "{driver_sessions}"
+
+// redisDriverSessionsKey is the sorted set of one driver's finished shifts
+func redisDriverSessionsKey(driverID string) string {
+	return redisSessionsKey + ":" + driverID
+}
+
+// Session is one finished shift, from going online to going offline
+type Session struct {
+	ID              string    `json:"id"`
+	DriverID        string    `json:"driver_id"`
+	StartedAt       time.Time `json:"started_at"`
+	EndedAt         time.Time `json:"ended_at"`
+	DurationSeconds int64     `json:"duration_seconds"`
+	EndReason       Action    `json:"end_reason"`
+	// OnTripSeconds and BreakSeconds are the parts of the shift spent on
+	// trips and on breaks
+	OnTripSeconds int64 `json:"on_trip_seconds"`
+	BreakSeconds  int64 `json:"break_seconds"`
+}
+
+// OnlineSeconds returns the part of the shift not spent on breaks
+func (s *Session) OnlineSeconds() int64 {
+	return s.DurationSeconds - s.BreakSeconds
+}
+
+// newSession records a shift ending at endedAt
+func newSession(driverID string, startedAt, endedAt time.Time, reason Action) *Session {
+	return &Session{
+		ID:              driverID + ":" + strconv.FormatInt(startedAt.UnixMicro(), 10),
+		DriverID:        driverID,
+		StartedAt:       startedAt,
+		EndedAt:         endedAt,
+		DurationSeconds: int64(endedAt.Sub(startedAt).Seconds()),
+		EndReason:       reason,
+	}
+}
+
+// SessionLog keeps finished shifts until they have been exported
+type SessionLog interface {
+	// Save adds a finished shift
+	Save(ctx context.Context, session *Session) error
+	// ListSince returns up to limit shifts that ended after the watermark,
+	// in (EndedAt, ID) order
+	ListSince(ctx context.Context, since export.Watermark, limit int) ([]*Session, error)
+	// ListForDriver returns the driver's shifts that overlap [from, to), in
+	// (EndedAt, ID) order
+	ListForDriver(ctx context.Context, driverID string, from, to time.Time) ([]*Session, error)
+}
+
+// RedisSessionLog keeps finished shifts in a sorted set scored by when they
+// ended, and each driver's in a set of their own. Shifts older than
+// sessionRetention are trimmed as new ones are saved.
+type RedisSessionLog struct {
+	client redis.UniversalClient
+}
+
+// NewRedisSessionLog creates a new Redis-backed session log
+func NewRedisSessionLog(client redis.UniversalClient) *RedisSessionLog {
+	return &RedisSessionLog{client: client}
+}
+
+// Save adds a finished shift and trims expired ones
+func (r *RedisSessionLog) Save(ctx context.Context, session *Session) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("failed to marshal driver session: %w", err)
+	}
+
+	member := redis.Z{
+		Score:  float64(session.EndedAt.UnixMicro()),
+		Member: data,
+	}
+	expired := "(" + strconv.FormatInt(session.EndedAt.Add(-sessionRetention).UnixMicro(), 10)
+	driverKey := redisDriverSessionsKey(session.DriverID)
+
+	pipe := r.client.TxPipeline()
+	pipe.ZAdd(ctx, redisSessionsKey, member)
+	pipe.ZRemRangeByScore(ctx, redisSessionsKey, "-inf", expired)
+	pipe.ZAdd(ctx, driverKey, member)
+	pipe.ZRemRangeByScore(ctx, driverKey, "-inf", expired)
+	pipe.Expire(ctx, driverKey, sessionRetention)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to save driver session: %w", err)
+	}
+	return nil
+}
+
+// ListSince returns shifts that ended after the watermark. Shifts ending in
+// the watermark's microsecond are read again and filtered by ID.
+func (r *RedisSessionLog) ListSince(ctx context.Context, since export.Watermark, limit int) ([]*Session, error) {
+	min := "-inf"
+	if !since.UpdatedAt.IsZero() {
+		min = strconv.FormatInt(since.UpdatedAt.UnixMicro(), 10)
+	}
+
+	var sessions []*Session
+	for offset := int64(0); len(sessions) < limit; offset += int64(limit) {
+		members, err := r.client.ZRangeByScore(ctx, redisSessionsKey, &redis.ZRangeBy{
+			Min:    min,
+			Max:    "+inf",
+			Offset: offset,
+			Count:  int64(limit),
+		}).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list driver sessions: %w", err)
+		}
+
+		page := make([]*Session, 0, len(members))
+		for _, member := range members {
+			var session Session
+			if err := json.Unmarshal([]byte(member), &session); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal driver session: %w", err)
+			}
+			page = append(page, &session)
+		}
+		sortSessions(page)
+		for _, session := range page {
+			if since.Before(session.EndedAt, session.ID) && len(sessions) < limit {
+				sessions = append(sessions, session)
+			}
+		}
+		if len(members) < limit {
+			break
+		}
+	}
+	return sessions, nil
+}
+
+// ListForDriver returns the driver's shifts that ended after from and started
+// before to
+func (r *RedisSessionLog) ListForDriver(ctx context.Context, driverID string, from, to time.Time) ([]*Session, error) {
+	members, err := r.client.ZRangeByScore(ctx, redisDriverSessionsKey(driverID), &redis.ZRangeBy{
+		Min: "(" + strconv.FormatInt(from.UnixMicro(), 10),
+		Max: "+inf",
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list driver sessions: %w", err)
+	}
+
+	var sessions []*Session
+	for _, member := range members {
+		var session Session
+		if err := json.Unmarshal([]byte(member), &session); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal driver session: %w", err)
+		}
+		if session.StartedAt.Before(to) {
+			sessions = append(sessions, &session)
+		}
+	}
+	sortSessions(sessions)
+	return sessions, nil
+}
+
+// MemorySessionLog implements SessionLog in memory
+type MemorySessionLog struct {
+	sessions []*Session
+	mutex    sync.RWMutex
+}
+
+// NewMemorySessionLog creates a new in-memory session log
+func NewMemorySessionLog() *MemorySessionLog {
+	return &MemorySessionLog{}
+}
+
+// Save adds a copy of a finished shift
+func (m *MemorySessionLog) Save(ctx context.Context, session *Session) error {
+	saved := *session
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.sessions = append(m.sessions, &saved)
+	return nil
+}
+
+// ListSince returns copies of the shifts that ended after the watermark
+func (m *MemorySessionLog) ListSince(ctx context.Context, since export.Watermark, limit int) ([]*Session, error) {
+	m.mutex.RLock()
+	var sessions []*Session
+	for _, session := range m.sessions {
+		if since.Before(session.EndedAt, session.ID) {
+			copied := *session
+			sessions = append(sessions, &copied)
+		}
+	}
+	m.mutex.RUnlock()
+
+	sortSessions(sessions)
+	if len(sessions) > limit {
+		sessions = sessions[:limit]
+	}
+	return sessions, nil
+}
+
+// ListForDriver returns copies of the driver's shifts that overlap [from, to)
+func (m *MemorySessionLog) ListForDriver(ctx context.Context, driverID string, from, to time.Time) ([]*Session, error) {
+	m.mutex.RLock()
+	var sessions []*Session
+	for _, session := range m.sessions {
+		if session.DriverID == driverID && session.EndedAt.After(from) && session.StartedAt.Before(to) {
+			copied := *session
+			sessions = append(sessions, &copied)
+		}
+	}
+	m.mutex.RUnlock()
+
+	sortSessions(sessions)
+	return sessions, nil
+}
+
+// sortSessions sorts sessions into export order
+func sortSessions(sessions []*Session) {
+	sort.Slice(sessions, func(i, j int) bool {
+		at := export.Watermark{UpdatedAt: sessions[i].EndedAt, ID: sessions[i].ID}
+		return at.Before(sessions[j].EndedAt, sessions[j].ID)
+	})
+}
+
+// SessionExportDataset names the driver sessions dataset in the warehouse
+const SessionExportDataset = "driver_sessions"
+
+var sessionExportColumns = []string{
+	"id", "driver_id", "started_at", "ended_at", "duration_seconds", "end_reason",
+}
+
+// NewSessionExport exports finished driver shifts. A shift never changes
+// once it has ended, so each is exported once.
+func NewSessionExport(log SessionLog) export.Dataset {
+	return export.NewDataset(SessionExportDataset, sessionExportColumns, func(ctx context.Context, since export.Watermark, limit int) ([]export.Record, error) {
+		sessions, err := log.ListSince(ctx, since, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		records := make([]export.Record, 0, len(sessions))
+		for _, session := range sessions {
+			records = append(records, export.Record{
+				ID:        session.ID,
+				UpdatedAt: session.EndedAt,
+				Values: []string{
+					session.ID,
+					session.DriverID,
+					export.Time(session.StartedAt),
+					export.Time(session.EndedAt),
+					export.Int(session.DurationSeconds),
+					string(session.EndReason),
+				},
+			})
+		}
+		return records, nil
+	})
+}
@@ -0,0 +1,117 @@
+package driverstate
+
+import (
+	"errors"
+	"time"
+)
+
+// State is a driver's availability state
+type State string
+
+const (
+	StateOffline State = "offline"
+	StateOnline  State = "online"
+	StateOnTrip  State = "on_trip"
+	StateOnBreak State = "on_break"
+)
+
+// Action is something that moves a driver between states
+type Action string
+
+const (
+	ActionGoOnline         Action = "go_online"
+	ActionGoOffline        Action = "go_offline"
+	ActionStartBreak       Action = "start_break"
+	ActionTripAssigned     Action = "trip_assigned"
+	ActionTripCompleted    Action = "trip_completed"
+	ActionHeartbeatExpired Action = "heartbeat_expired"
+	// ActionLocationLost takes offline a driver who keeps sending heartbeats
+	// but no locations
+	ActionLocationLost Action = "location_lost"
+	// ActionFatigueLimit takes offline a driver who reached their city's
+	// driving-hour limit
+	ActionFatigueLimit Action = "fatigue_limit"
+)
+
+var (
+	// ErrInvalidTransition is returned when an action is not allowed from the driver's current state
+	ErrInvalidTransition = errors.New("invalid driver state transition")
+	// ErrDriverOffline is returned when a heartbeat arrives from a driver who is not on shift
+	ErrDriverOffline = errors.New("driver is offline")
+	// ErrStateNotFound is returned by stores when no state is saved for a driver
+	ErrStateNotFound = errors.New("driver state not found")
+	// ErrDriverNotApproved is returned when a driver who has not completed onboarding tries to go online
+	ErrDriverNotApproved = errors.New("driver is not approved")
+	// ErrNoShiftHistory is returned for shift history when no session log is set
+	ErrNoShiftHistory = errors.New("driver shift history is not kept")
+	// ErrFatigueLimitReached is returned when a driver who reached their driving-hour limit tries to go online
+	ErrFatigueLimitReached = errors.New("driver has reached their driving-hour limit")
+	// ErrNoFatigueRules is returned for driving-hour usage when no limits are enforced
+	ErrNoFatigueRules = errors.New("driving-hour limits are not enforced")
+)
+
+// transitions lists the states each action may be applied from, and the state it leads to
+var transitions = map[Action]struct {
+	from []State
+	to   State
+}{
+	ActionGoOnline:         {from: []State{StateOffline, StateOnBreak}, to: StateOnline},
+	ActionGoOffline:        {from: []State{StateOnline, StateOnBreak}, to: StateOffline},
+	ActionStartBreak:       {from: []State{StateOnline}, to: StateOnBreak},
+	ActionTripAssigned:     {from: []State{StateOnline}, to: StateOnTrip},
+	ActionTripCompleted:    {from: []State{StateOnTrip}, to: StateOnline},
+	ActionHeartbeatExpired: {from: []State{StateOnline, StateOnBreak, StateOnTrip}, to: StateOffline},
+	ActionLocationLost:     {from: []State{StateOnline, StateOnBreak, StateOnTrip}, to: StateOffline},
+	ActionFatigueLimit:     {from: []State{StateOnline, StateOnBreak}, to: StateOffline},
+}
+
+// nextState returns the state an action leads to from the current state
+func nextState(current State, action Action) (State, error) {
+	transition, ok := transitions[action]
+	if !ok {
+		return current, ErrInvalidTransition
+	}
+	for _, from := range transition.from {
+		if from == current {
+			return transition.to, nil
+		}
+	}
+	return current, ErrInvalidTransition
+}
+
+// DriverState is a driver's current availability and shift
+type DriverState struct {
+	DriverID        string     `json:"driver_id"`
+	State           State      `json:"state"`
+	TripID          string     `json:"trip_id,omitempty"`
+	ShiftStartedAt  *time.Time `json:"shift_started_at,omitempty"`
+	LastHeartbeatAt time.Time  `json:"last_heartbeat_at"`
+	// LastLocationAt is when the driver's latest location this shift was
+	// recorded, nil until they report one
+	LastLocationAt     *time.Time `json:"last_location_at,omitempty"`
+	HeartbeatExpiresAt time.Time  `json:"heartbeat_expires_at"`
+	Version            int        `json:"version"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+	// OnTripSeconds and BreakSeconds are how long the driver spent on trips
+	// and on breaks this shift, up to their last state change
+	OnTripSeconds int64 `json:"on_trip_seconds,omitempty"`
+	BreakSeconds  int64 `json:"break_seconds,omitempty"`
+	// CityID is the city of the driver's latest location, whose
+	// driving-hour limits apply to them
+	CityID string `json:"city_id,omitempty"`
+	// FatigueLimitAt is when the driver reaches a driving-hour limit if
+	// they stay in their state, and FatigueWarnedAt when they were warned
+	// of it this shift
+	FatigueLimitAt  *time.Time `json:"fatigue_limit_at,omitempty"`
+	FatigueWarnedAt *time.Time `json:"fatigue_warned_at,omitempty"`
+}
+
+// Available reports whether the driver can be offered new trips
+func (s *DriverState) Available() bool {
+	return s.State == StateOnline
+}
+
+// offlineState is the state of a driver with nothing saved
+func offlineState(driverID string) *DriverState {
+	return &DriverState{DriverID: driverID, State: StateOffline}
+}
@@ -0,0 +1,162 @@
+package driverstate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Store persists driver states
+type Store interface {
+	// Get returns ErrStateNotFound when nothing is saved or the saved state has expired
+	Get(ctx context.Context, driverID string) (*DriverState, error)
+	// Save stores the state for ttl. Drivers on shift are tracked until their heartbeat deadline.
+	Save(ctx context.Context, state *DriverState, ttl time.Duration) error
+	// Expired returns drivers on shift whose heartbeat deadline is not after now
+	Expired(ctx context.Context, now time.Time) ([]string, error)
+}
+
+// The {driver_state} hash tag keeps a driver's state key and the heartbeat
+// set in one Redis Cluster slot, so Save can update both in a transaction
+const (
+	redisStateKeyPrefix = "{driver_state}:"
+	redisHeartbeatsKey  = "{driver_state}:heartbeats"
+)
+
+// RedisStore keeps driver states in Redis keys that expire with the driver's
+// heartbeats, plus a sorted set of heartbeat deadlines for expiry sweeps
+type RedisStore struct {
+	client redis.UniversalClient
+}
+
+// NewRedisStore creates a new Redis-backed driver state store
+func NewRedisStore(client redis.UniversalClient) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+// Get retrieves a driver's state
+func (r *RedisStore) Get(ctx context.Context, driverID string) (*DriverState, error) {
+	data, err := r.client.Get(ctx, redisStateKeyPrefix+driverID).Bytes()
+	if err == redis.Nil {
+		return nil, ErrStateNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get driver state: %w", err)
+	}
+
+	var state DriverState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal driver state: %w", err)
+	}
+	return &state, nil
+}
+
+// Save stores a driver's state and updates its heartbeat deadline
+func (r *RedisStore) Save(ctx context.Context, state *DriverState, ttl time.Duration) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal driver state: %w", err)
+	}
+
+	pipe := r.client.TxPipeline()
+	pipe.Set(ctx, redisStateKeyPrefix+state.DriverID, data, ttl)
+	if state.State == StateOffline {
+		pipe.ZRem(ctx, redisHeartbeatsKey, state.DriverID)
+	} else {
+		pipe.ZAdd(ctx, redisHeartbeatsKey, redis.Z{
+			Score:  float64(state.HeartbeatExpiresAt.Unix()),
+			Member: state.DriverID,
+		})
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to save driver state: %w", err)
+	}
+	return nil
+}
+
+// Expired returns drivers whose heartbeat deadline has passed
+func (r *RedisStore) Expired(ctx context.Context, now time.Time) ([]string, error) {
+	ids, err := r.client.ZRangeByScore(ctx, redisHeartbeatsKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatInt(now.Unix(), 10),
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expired heartbeats: %w", err)
+	}
+	return ids, nil
+}
+
+// MemoryStore implements Store in memory, storing copies so callers cannot
+// mutate saved states
+type MemoryStore struct {
+	states  map[string][]byte
+	expires map[string]time.Time
+	mutex   sync.RWMutex
+}
+
+// NewMemoryStore creates a new in-memory driver state store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		states:  make(map[string][]byte),
+		expires: make(map[string]time.Time),
+	}
+}
+
+// Get retrieves a copy of a driver's state
+func (m *MemoryStore) Get(ctx context.Context, driverID string) (*DriverState, error) {
+	m.mutex.RLock()
+	data, exists := m.states[driverID]
+	expiresAt := m.expires[driverID]
+	m.mutex.RUnlock()
+
+	if !exists || (!expiresAt.IsZero() && !time.Now().Before(expiresAt)) {
+		return nil, ErrStateNotFound
+	}
+
+	var state DriverState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal driver state: %w", err)
+	}
+	return &state, nil
+}
+
+// Save stores a copy of a driver's state
+func (m *MemoryStore) Save(ctx context.Context, state *DriverState, ttl time.Duration) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal driver state: %w", err)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.states[state.DriverID] = data
+	if ttl > 0 {
+		m.expires[state.DriverID] = time.Now().Add(ttl)
+	} else {
+		delete(m.expires, state.DriverID)
+	}
+	return nil
+}
+
+// Expired returns drivers on shift whose heartbeat deadline has passed
+func (m *MemoryStore) Expired(ctx context.Context, now time.Time) ([]string, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var ids []string
+	for driverID, data := range m.states {
+		var state DriverState
+		if err := json.Unmarshal(data, &state); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal driver state: %w", err)
+		}
+		if state.State != StateOffline && !state.HeartbeatExpiresAt.After(now) {
+			ids = append(ids, driverID)
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,18 @@
+package driverstate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rideshare-platform/shared/database"
+)
+
+func TestRedisStoreKeys_ShareASlot(t *testing.T) {
+	// Save writes a driver's state and the heartbeat set in one transaction,
+	// which Redis Cluster only allows within one slot
+	heartbeats := database.ClusterSlot(redisHeartbeatsKey)
+	for _, driverID := range []string{"driver-1", "driver-2", "9b2d7f1c-3e4a-4b8d-a6c5-0f1e2d3c4b5a"} {
+		assert.Equal(t, heartbeats, database.ClusterSlot(redisStateKeyPrefix+driverID), driverID)
+	}
+}
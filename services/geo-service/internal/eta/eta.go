@@ -0,0 +1,84 @@
+// Package eta estimates trip durations. A simple model divides distance by a
+// flat per-vehicle speed; the traffic model adjusts that speed by time of day
+// and by speeds observed on recently completed trips in the same area.
+package eta
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/rideshare-platform/shared/models"
+)
+
+// Model names, as used in configuration and reported with each estimate
+const (
+	ModelSimple  = "simple"
+	ModelTraffic = "traffic"
+)
+
+// Request describes the trip to estimate
+type Request struct {
+	Origin         models.Location
+	Destination    models.Location
+	DistanceKm     float64
+	VehicleType    string
+	DepartureTime  time.Time
+	IncludeTraffic bool
+	// Area is the geohash cell of the origin that speed observations are grouped by
+	Area string
+}
+
+// Estimate is a model's duration estimate
+type Estimate struct {
+	Duration time.Duration
+	SpeedKmh float64
+	Model    string
+	// Samples is the number of observed trips the estimate drew on
+	Samples int
+}
+
+// Model estimates how long a trip takes
+type Model interface {
+	Name() string
+	Estimate(ctx context.Context, req Request) (*Estimate, error)
+}
+
+// Selector splits ETA requests between a control model and an experiment
+// model. Assignment hashes a caller-supplied key, so the same key always gets
+// the same model and the two can be compared on like-for-like trips.
+type Selector struct {
+	control    Model
+	experiment Model
+	percent    int
+}
+
+// NewSelector sends percent (0-100) of keys to experiment and the rest to control
+func NewSelector(control, experiment Model, percent int) *Selector {
+	return &Selector{control: control, experiment: experiment, percent: percent}
+}
+
+// Pick returns the model assigned to key
+func (s *Selector) Pick(key string) Model {
+	if s.experiment == nil || s.percent <= 0 {
+		return s.control
+	}
+	if s.percent >= 100 {
+		return s.experiment
+	}
+	hash := fnv.New32a()
+	hash.Write([]byte(key))
+	if int(hash.Sum32()%100) < s.percent {
+		return s.experiment
+	}
+	return s.control
+}
+
+// durationFor converts a distance and speed into a duration
+func durationFor(distanceKm, speedKmh float64) (time.Duration, error) {
+	if speedKmh <= 0 {
+		return 0, fmt.Errorf("invalid speed %.2f km/h", speedKmh)
+	}
+	return time.Duration(distanceKm / speedKmh * float64(time.Hour)).Round(time.Second), nil
+}
@@ -0,0 +1,114 @@
+package eta
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testSpeeds = map[string]float64{"car": 50, "bike": 20}
+
+var testTrafficFactors = map[string]float64{"rush_hour": 1.5, "normal": 1.0, "late_night": 0.8}
+
+func at(hour int) time.Time {
+	return time.Date(2024, 3, 4, hour, 0, 0, 0, time.UTC)
+}
+
+type failingStore struct{}
+
+func (failingStore) Record(ctx context.Context, observation *Observation) error { return nil }
+
+func (failingStore) AverageSpeed(ctx context.Context, area, vehicleType string, hour int, since time.Time) (ObservedSpeed, error) {
+	return ObservedSpeed{}, errors.New("mongo unavailable")
+}
+
+func TestSimpleModel_AppliesTrafficFactor(t *testing.T) {
+	model := NewSimpleModel(testSpeeds, testTrafficFactors)
+	ctx := context.Background()
+
+	free, err := model.Estimate(ctx, Request{DistanceKm: 25, VehicleType: "car", DepartureTime: at(8)})
+	assert.NoError(t, err)
+	assert.Equal(t, 30*time.Minute, free.Duration)
+
+	rush, err := model.Estimate(ctx, Request{DistanceKm: 25, VehicleType: "car", DepartureTime: at(8), IncludeTraffic: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 45*time.Minute, rush.Duration)
+
+	unknown, err := model.Estimate(ctx, Request{DistanceKm: 25, VehicleType: "hovercraft", DepartureTime: at(12)})
+	assert.NoError(t, err)
+	assert.Equal(t, 30*time.Minute, unknown.Duration)
+}
+
+func TestTrafficModel_UsesAreaProfile(t *testing.T) {
+	model := NewTrafficModel(testSpeeds, NewSimpleModel(testSpeeds, testTrafficFactors), 10, 14*24*time.Hour)
+	ctx := context.Background()
+
+	var profile SpeedProfile
+	for hour := range profile {
+		profile[hour] = 0.5
+	}
+	model.SetAreaProfile("dr5r", profile)
+
+	estimate, err := model.Estimate(ctx, Request{DistanceKm: 25, VehicleType: "car", DepartureTime: at(12), IncludeTraffic: true, Area: "dr5ru"})
+	assert.NoError(t, err)
+	assert.Equal(t, ModelTraffic, estimate.Model)
+	assert.Equal(t, time.Hour, estimate.Duration)
+}
+
+func TestTrafficModel_LearnsFromObservedTrips(t *testing.T) {
+	store := NewMemoryObservationStore()
+	model := NewTrafficModel(testSpeeds, NewSimpleModel(testSpeeds, testTrafficFactors), 4, 14*24*time.Hour)
+	model.SetObservations(store)
+	ctx := context.Background()
+	departure := at(8)
+
+	// Four trips at 20 km/h in the area's 08:00 hour replace the profile entirely
+	for i := 0; i < 4; i++ {
+		observation, err := NewObservation(fmt.Sprintf("trip-%d", i), "dr5ru", "car", 10, departure.Add(-24*time.Hour), departure.Add(-24*time.Hour+30*time.Minute))
+		assert.NoError(t, err)
+		assert.NoError(t, store.Record(ctx, observation))
+	}
+
+	estimate, err := model.Estimate(ctx, Request{DistanceKm: 10, VehicleType: "car", DepartureTime: departure, IncludeTraffic: true, Area: "dr5ru"})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, estimate.Samples)
+	assert.Equal(t, 30*time.Minute, estimate.Duration)
+
+	// Other areas still rely on the profile
+	elsewhere, err := model.Estimate(ctx, Request{DistanceKm: 10, VehicleType: "car", DepartureTime: departure, IncludeTraffic: true, Area: "9q8yy"})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, elsewhere.Samples)
+	assert.InDelta(t, 50*DefaultSpeedProfile[8], elsewhere.SpeedKmh, 0.001)
+}
+
+func TestTrafficModel_FallsBackWhenObservationsFail(t *testing.T) {
+	model := NewTrafficModel(testSpeeds, NewSimpleModel(testSpeeds, testTrafficFactors), 4, time.Hour)
+	model.SetObservations(failingStore{})
+
+	estimate, err := model.Estimate(context.Background(), Request{DistanceKm: 25, VehicleType: "car", DepartureTime: at(8), IncludeTraffic: true, Area: "dr5ru"})
+	assert.NoError(t, err)
+	assert.Equal(t, ModelSimple, estimate.Model)
+	assert.Equal(t, 45*time.Minute, estimate.Duration)
+}
+
+func TestSelector_SplitsByKey(t *testing.T) {
+	control := NewSimpleModel(testSpeeds, testTrafficFactors)
+	experiment := NewTrafficModel(testSpeeds, control, 1, time.Hour)
+
+	assert.Equal(t, ModelSimple, NewSelector(control, experiment, 0).Pick("a").Name())
+	assert.Equal(t, ModelTraffic, NewSelector(control, experiment, 100).Pick("a").Name())
+
+	selector := NewSelector(control, experiment, 50)
+	counts := map[string]int{}
+	for i := 0; i < 1000; i++ {
+		key := fmt.Sprintf("area-%d", i)
+		model := selector.Pick(key)
+		assert.Equal(t, model, selector.Pick(key), "assignment must be stable")
+		counts[model.Name()]++
+	}
+	assert.InDelta(t, 500, counts[ModelTraffic], 100)
+}
@@ -0,0 +1,148 @@
+package eta
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ObservationsCollection holds one document per completed trip
+const ObservationsCollection = "trip_speed_observations"
+
+// Observation is the average speed of one completed trip
+type Observation struct {
+	TripID          string    `bson:"trip_id"`
+	Area            string    `bson:"area"`
+	VehicleType     string    `bson:"vehicle_type"`
+	Hour            int       `bson:"hour"`
+	DistanceKm      float64   `bson:"distance_km"`
+	DurationSeconds float64   `bson:"duration_seconds"`
+	SpeedKmh        float64   `bson:"speed_kmh"`
+	CompletedAt     time.Time `bson:"completed_at"`
+}
+
+// NewObservation derives an observation from a completed trip's telemetry,
+// filed under the hour the trip started in
+func NewObservation(tripID, area, vehicleType string, distanceKm float64, startedAt, completedAt time.Time) (*Observation, error) {
+	duration := completedAt.Sub(startedAt)
+	if duration <= 0 || distanceKm <= 0 {
+		return nil, fmt.Errorf("trip %s has no usable distance or duration", tripID)
+	}
+	return &Observation{
+		TripID:          tripID,
+		Area:            area,
+		VehicleType:     vehicleType,
+		Hour:            startedAt.Hour(),
+		DistanceKm:      distanceKm,
+		DurationSeconds: duration.Seconds(),
+		SpeedKmh:        distanceKm / duration.Hours(),
+		CompletedAt:     completedAt,
+	}, nil
+}
+
+// ObservedSpeed is the average speed over matching observations
+type ObservedSpeed struct {
+	SpeedKmh float64
+	Samples  int
+}
+
+// ObservationStore records completed trip speeds and averages them by area,
+// vehicle type and hour of day
+type ObservationStore interface {
+	Record(ctx context.Context, observation *Observation) error
+	AverageSpeed(ctx context.Context, area, vehicleType string, hour int, since time.Time) (ObservedSpeed, error)
+}
+
+// MongoObservationStore keeps observations in MongoDB
+type MongoObservationStore struct {
+	collection *mongo.Collection
+}
+
+// NewMongoObservationStore creates a store backed by the trip_speed_observations collection
+func NewMongoObservationStore(db *mongo.Database) *MongoObservationStore {
+	return &MongoObservationStore{collection: db.Collection(ObservationsCollection)}
+}
+
+// Record stores an observation
+func (s *MongoObservationStore) Record(ctx context.Context, observation *Observation) error {
+	if _, err := s.collection.InsertOne(ctx, observation); err != nil {
+		return fmt.Errorf("failed to record trip speed: %w", err)
+	}
+	return nil
+}
+
+// AverageSpeed averages observations for the area, vehicle type and hour completed since the given time
+func (s *MongoObservationStore) AverageSpeed(ctx context.Context, area, vehicleType string, hour int, since time.Time) (ObservedSpeed, error) {
+	cursor, err := s.collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.D{
+			{Key: "area", Value: area},
+			{Key: "vehicle_type", Value: vehicleType},
+			{Key: "hour", Value: hour},
+			{Key: "completed_at", Value: bson.D{{Key: "$gte", Value: since}}},
+		}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: nil},
+			{Key: "speed_kmh", Value: bson.D{{Key: "$avg", Value: "$speed_kmh"}}},
+			{Key: "samples", Value: bson.D{{Key: "$sum", Value: 1}}},
+		}}},
+	})
+	if err != nil {
+		return ObservedSpeed{}, fmt.Errorf("failed to average trip speeds: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var result struct {
+		SpeedKmh float64 `bson:"speed_kmh"`
+		Samples  int     `bson:"samples"`
+	}
+	if !cursor.Next(ctx) {
+		return ObservedSpeed{}, cursor.Err()
+	}
+	if err := cursor.Decode(&result); err != nil {
+		return ObservedSpeed{}, fmt.Errorf("failed to decode trip speeds: %w", err)
+	}
+	return ObservedSpeed{SpeedKmh: result.SpeedKmh, Samples: result.Samples}, nil
+}
+
+// MemoryObservationStore keeps observations in memory
+type MemoryObservationStore struct {
+	observations []Observation
+	mutex        sync.RWMutex
+}
+
+// NewMemoryObservationStore creates a new in-memory observation store
+func NewMemoryObservationStore() *MemoryObservationStore {
+	return &MemoryObservationStore{}
+}
+
+// Record stores a copy of an observation
+func (s *MemoryObservationStore) Record(ctx context.Context, observation *Observation) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.observations = append(s.observations, *observation)
+	return nil
+}
+
+// AverageSpeed averages observations for the area, vehicle type and hour completed since the given time
+func (s *MemoryObservationStore) AverageSpeed(ctx context.Context, area, vehicleType string, hour int, since time.Time) (ObservedSpeed, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var result ObservedSpeed
+	var total float64
+	for _, observation := range s.observations {
+		if observation.Area == area && observation.VehicleType == vehicleType &&
+			observation.Hour == hour && !observation.CompletedAt.Before(since) {
+			total += observation.SpeedKmh
+			result.Samples++
+		}
+	}
+	if result.Samples > 0 {
+		result.SpeedKmh = total / float64(result.Samples)
+	}
+	return result, nil
+}
@@ -0,0 +1,65 @@
+package eta
+
+import (
+	"context"
+	"time"
+)
+
+// SimpleModel assumes a flat speed per vehicle type, slowed or sped up by a
+// coarse traffic factor for rush hour and late night
+type SimpleModel struct {
+	speeds         map[string]float64
+	trafficFactors map[string]float64
+}
+
+// NewSimpleModel creates a simple model from km/h speeds keyed by vehicle type
+// and duration multipliers keyed "rush_hour", "normal" and "late_night"
+func NewSimpleModel(speeds, trafficFactors map[string]float64) *SimpleModel {
+	return &SimpleModel{speeds: speeds, trafficFactors: trafficFactors}
+}
+
+// Name identifies the model
+func (m *SimpleModel) Name() string {
+	return ModelSimple
+}
+
+// Estimate divides the distance by the vehicle's speed
+func (m *SimpleModel) Estimate(ctx context.Context, req Request) (*Estimate, error) {
+	speed := m.speed(req.VehicleType)
+	if req.IncludeTraffic {
+		if factor := m.trafficFactor(req.DepartureTime); factor > 0 {
+			speed /= factor
+		}
+	}
+
+	duration, err := durationFor(req.DistanceKm, speed)
+	if err != nil {
+		return nil, err
+	}
+	return &Estimate{Duration: duration, SpeedKmh: speed, Model: ModelSimple}, nil
+}
+
+// speed returns the vehicle type's speed, defaulting to car speed
+func (m *SimpleModel) speed(vehicleType string) float64 {
+	if speed, exists := m.speeds[vehicleType]; exists {
+		return speed
+	}
+	return m.speeds["car"]
+}
+
+// trafficFactor returns the duration multiplier for the time of day
+func (m *SimpleModel) trafficFactor(departureTime time.Time) float64 {
+	hour := departureTime.Hour()
+
+	// Rush hour times (7-9 AM, 5-7 PM)
+	if (hour >= 7 && hour <= 9) || (hour >= 17 && hour <= 19) {
+		return m.trafficFactors["rush_hour"]
+	}
+
+	// Late night (11 PM - 5 AM)
+	if hour >= 23 || hour <= 5 {
+		return m.trafficFactors["late_night"]
+	}
+
+	return m.trafficFactors["normal"]
+}
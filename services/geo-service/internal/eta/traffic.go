@@ -0,0 +1,108 @@
+package eta
+
+import (
+	"context"
+	"time"
+)
+
+// SpeedProfile holds speed multipliers for each hour of the day, 0-23. A
+// multiplier of 0.6 means traffic moves at 60% of the vehicle's free-flow speed.
+type SpeedProfile [24]float64
+
+// DefaultSpeedProfile is used for areas without a profile of their own
+var DefaultSpeedProfile = SpeedProfile{
+	1.2, 1.2, 1.2, 1.2, 1.2, 1.1, // 00-05 late night
+	0.9, 0.65, 0.6, 0.7, 0.85, 0.85, // 06-11 morning rush
+	0.8, 0.8, 0.85, 0.8, 0.7, 0.6, // 12-17 afternoon into evening rush
+	0.6, 0.75, 0.9, 1.0, 1.1, 1.2, // 18-23 evening
+}
+
+// TrafficModel starts from the vehicle's free-flow speed scaled by the area's
+// hourly speed profile, then moves towards the average speed observed on
+// recently completed trips in the same area and hour as observations accumulate.
+// Without traffic, or if observations cannot be read, it defers to a fallback model.
+type TrafficModel struct {
+	speeds       map[string]float64
+	profiles     map[string]SpeedProfile
+	observations ObservationStore
+	fallback     Model
+	minSamples   int
+	lookback     time.Duration
+}
+
+// NewTrafficModel creates a traffic model. Observed speeds fully replace the
+// profile once an area and hour has minSamples trips within the lookback window.
+func NewTrafficModel(speeds map[string]float64, fallback Model, minSamples int, lookback time.Duration) *TrafficModel {
+	return &TrafficModel{
+		speeds:     speeds,
+		profiles:   make(map[string]SpeedProfile),
+		fallback:   fallback,
+		minSamples: minSamples,
+		lookback:   lookback,
+	}
+}
+
+// SetObservations sets the store of completed trip speeds the model learns from
+func (m *TrafficModel) SetObservations(observations ObservationStore) {
+	m.observations = observations
+}
+
+// SetAreaProfile overrides the hourly speed profile for trips starting in an
+// area. Profiles apply to every area whose geohash starts with area.
+func (m *TrafficModel) SetAreaProfile(area string, profile SpeedProfile) {
+	m.profiles[area] = profile
+}
+
+// Name identifies the model
+func (m *TrafficModel) Name() string {
+	return ModelTraffic
+}
+
+// Estimate blends the profile speed with observed speeds for the area and hour
+func (m *TrafficModel) Estimate(ctx context.Context, req Request) (*Estimate, error) {
+	if !req.IncludeTraffic {
+		return m.fallback.Estimate(ctx, req)
+	}
+
+	hour := req.DepartureTime.Hour()
+	speed := m.freeFlowSpeed(req.VehicleType) * m.profile(req.Area)[hour]
+
+	samples := 0
+	if m.observations != nil && req.Area != "" {
+		observed, err := m.observations.AverageSpeed(ctx, req.Area, req.VehicleType, hour, req.DepartureTime.Add(-m.lookback))
+		if err != nil {
+			return m.fallback.Estimate(ctx, req)
+		}
+		if observed.Samples > 0 && observed.SpeedKmh > 0 {
+			weight := float64(observed.Samples) / float64(m.minSamples)
+			if m.minSamples <= 0 || weight > 1 {
+				weight = 1
+			}
+			speed = weight*observed.SpeedKmh + (1-weight)*speed
+			samples = observed.Samples
+		}
+	}
+
+	duration, err := durationFor(req.DistanceKm, speed)
+	if err != nil {
+		return m.fallback.Estimate(ctx, req)
+	}
+	return &Estimate{Duration: duration, SpeedKmh: speed, Model: ModelTraffic, Samples: samples}, nil
+}
+
+func (m *TrafficModel) freeFlowSpeed(vehicleType string) float64 {
+	if speed, exists := m.speeds[vehicleType]; exists {
+		return speed
+	}
+	return m.speeds["car"]
+}
+
+// profile returns the profile of the longest configured area prefix
+func (m *TrafficModel) profile(area string) SpeedProfile {
+	for prefix := area; prefix != ""; prefix = prefix[:len(prefix)-1] {
+		if profile, exists := m.profiles[prefix]; exists {
+			return profile
+		}
+	}
+	return DefaultSpeedProfile
+}
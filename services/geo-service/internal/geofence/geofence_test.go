@@ -0,0 +1,162 @@
+package geofence
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// square returns a closed ring around the point, extending size degrees each way
+func square(latitude, longitude, size float64) [][]float64 {
+	return [][]float64{
+		{longitude - size, latitude - size},
+		{longitude + size, latitude - size},
+		{longitude + size, latitude + size},
+		{longitude - size, latitude + size},
+		{longitude - size, latitude - size},
+	}
+}
+
+func newTestZone(name string, zoneType ZoneType, surcharge float64, rings ...[][]float64) *Zone {
+	return &Zone{
+		Name:      name,
+		Type:      zoneType,
+		Boundary:  Polygon{Type: "Polygon", Coordinates: rings},
+		Surcharge: surcharge,
+		Active:    true,
+	}
+}
+
+func TestZone_Validate(t *testing.T) {
+	valid := newTestZone("JFK", ZoneTypeAirport, 5, square(40.64, -73.78, 0.02))
+	assert.NoError(t, valid.Validate())
+
+	unclosed := newTestZone("JFK", ZoneTypeAirport, 5, square(40.64, -73.78, 0.02)[:4])
+	assert.True(t, errors.Is(unclosed.Validate(), ErrInvalidZone))
+
+	open := newTestZone("JFK", ZoneTypeAirport, 5, [][]float64{{-73.8, 40.6}, {-73.7, 40.6}, {-73.7, 40.7}, {-73.8, 40.7}})
+	assert.True(t, errors.Is(open.Validate(), ErrInvalidZone))
+
+	unknownType := newTestZone("JFK", "harbour", 5, square(40.64, -73.78, 0.02))
+	assert.True(t, errors.Is(unknownType.Validate(), ErrInvalidZone))
+
+	outOfRange := newTestZone("Nowhere", ZoneTypeRestricted, 0, square(89.99, -73.78, 0.02))
+	assert.True(t, errors.Is(outOfRange.Validate(), ErrInvalidZone))
+}
+
+func TestPolygon_ContainsExcludesHoles(t *testing.T) {
+	polygon := Polygon{Type: "Polygon", Coordinates: [][][]float64{
+		square(40.75, -73.99, 0.05),
+		square(40.75, -73.99, 0.01),
+	}}
+
+	assert.True(t, polygon.Contains(40.78, -73.99))
+	assert.False(t, polygon.Contains(40.75, -73.99), "point in the hole")
+	assert.False(t, polygon.Contains(40.90, -73.99), "point outside the boundary")
+}
+
+func TestService_LookupCombinesOverlappingZones(t *testing.T) {
+	service := NewService(NewMemoryStore())
+	ctx := context.Background()
+
+	airport, err := service.Create(ctx, newTestZone("JFK", ZoneTypeAirport, 5, square(40.64, -73.78, 0.05)))
+	assert.NoError(t, err)
+	_, err = service.Create(ctx, newTestZone("Terminal 4 curb", ZoneTypeRestricted, 0, square(40.64, -73.78, 0.005)))
+	assert.NoError(t, err)
+	_, err = service.Create(ctx, newTestZone("Arena", ZoneTypeEventVenue, 8, square(40.75, -73.99, 0.01)))
+	assert.NoError(t, err)
+
+	curb, err := service.Lookup(ctx, 40.64, -73.78)
+	assert.NoError(t, err)
+	assert.Len(t, curb.Zones, 2)
+	assert.Equal(t, 5.0, curb.Surcharge)
+	assert.True(t, curb.PickupRestricted, "restricted zones always restrict pickups")
+
+	parking, err := service.Lookup(ctx, 40.67, -73.78)
+	assert.NoError(t, err)
+	assert.Len(t, parking.Zones, 1)
+	assert.Equal(t, airport.ID, parking.Zones[0].ID)
+	assert.False(t, parking.PickupRestricted)
+
+	outside, err := service.Lookup(ctx, 41.0, -74.5)
+	assert.NoError(t, err)
+	assert.Empty(t, outside.Zones)
+	assert.Zero(t, outside.Surcharge)
+
+	_, err = service.Lookup(ctx, 95, 0)
+	assert.True(t, errors.Is(err, ErrInvalidPoint))
+}
+
+func TestService_UpdateAndDelete(t *testing.T) {
+	service := NewService(NewMemoryStore())
+	ctx := context.Background()
+
+	zone, err := service.Create(ctx, newTestZone("Arena", ZoneTypeEventVenue, 8, square(40.75, -73.99, 0.01)))
+	assert.NoError(t, err)
+
+	// Inactive zones are kept but no longer match lookups
+	changes := newTestZone("Arena", ZoneTypeEventVenue, 12, square(40.75, -73.99, 0.01))
+	changes.Active = false
+	updated, err := service.Update(ctx, zone.ID, changes)
+	assert.NoError(t, err)
+	assert.Equal(t, zone.ID, updated.ID)
+	assert.Equal(t, zone.CreatedAt, updated.CreatedAt)
+	assert.Equal(t, 12.0, updated.Surcharge)
+
+	membership, err := service.Lookup(ctx, 40.75, -73.99)
+	assert.NoError(t, err)
+	assert.Empty(t, membership.Zones)
+
+	_, err = service.Update(ctx, "missing", changes)
+	assert.True(t, errors.Is(err, ErrZoneNotFound))
+
+	assert.NoError(t, service.Delete(ctx, zone.ID))
+	_, err = service.Get(ctx, zone.ID)
+	assert.True(t, errors.Is(err, ErrZoneNotFound))
+	assert.True(t, errors.Is(service.Delete(ctx, zone.ID), ErrZoneNotFound))
+}
+
+func TestQueues_TrackQueuesDriversInArrivalOrder(t *testing.T) {
+	ctx := context.Background()
+	zones := NewService(NewMemoryStore())
+	airport := newTestZone("JFK", ZoneTypeAirport, 5, square(40.64, -73.78, 0.02))
+	airport.DispatchQueue = true
+	airport, err := zones.Create(ctx, airport)
+	assert.NoError(t, err)
+	queues := NewQueues(zones, NewMemoryQueueStore())
+
+	start := time.Now()
+	for i, driverID := range []string{"driver-1", "driver-2", "driver-3"} {
+		assert.NoError(t, queues.Track(ctx, driverID, 40.64, -73.78, true, start.Add(time.Duration(i)*time.Minute)))
+	}
+	// Later locations inside the zone keep the driver's place
+	assert.NoError(t, queues.Track(ctx, "driver-1", 40.65, -73.78, true, start.Add(5*time.Minute)))
+
+	queue, err := queues.List(ctx, airport.ID, 10)
+	assert.NoError(t, err)
+	assert.Len(t, queue, 3)
+	assert.Equal(t, "driver-1", queue[0].DriverID)
+
+	// Leaving the zone or taking a trip ejects the driver
+	assert.NoError(t, queues.Track(ctx, "driver-1", 40.75, -73.99, true, start.Add(6*time.Minute)))
+	assert.NoError(t, queues.Track(ctx, "driver-2", 40.64, -73.78, false, start.Add(6*time.Minute)))
+	entry, err := queues.Position(ctx, "driver-3")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, entry.Position)
+	_, err = queues.Position(ctx, "driver-1")
+	assert.True(t, errors.Is(err, ErrNotQueued))
+
+	// Drivers who come back join the back of the queue
+	assert.NoError(t, queues.Track(ctx, "driver-1", 40.64, -73.78, true, start.Add(7*time.Minute)))
+	entry, err = queues.Position(ctx, "driver-1")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, entry.Position)
+
+	surcharged, err := zones.Create(ctx, newTestZone("Stadium", ZoneTypeEventVenue, 3, square(40.83, -73.93, 0.01)))
+	assert.NoError(t, err)
+	_, err = queues.List(ctx, surcharged.ID, 10)
+	assert.True(t, errors.Is(err, ErrNotQueueZone))
+}
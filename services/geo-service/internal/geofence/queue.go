@@ -0,0 +1,302 @@
+package geofence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/rideshare-platform/shared/events"
+)
+
+var (
+	// ErrNotQueued is returned for drivers not waiting in any zone's queue
+	ErrNotQueued = errors.New("driver is not in a zone queue")
+	// ErrNotQueueZone is returned for queue lookups of zones that do not
+	// dispatch from a queue
+	ErrNotQueueZone = errors.New("zone is not a dispatch queue zone")
+)
+
+// QueueEntry is a driver's place in a dispatch zone's queue
+type QueueEntry struct {
+	ZoneID   string `json:"zone_id"`
+	DriverID string `json:"driver_id"`
+	// Position counts from 1 at the head of the queue
+	Position int       `json:"position"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// QueueStore keeps the FIFO queues of dispatch zones. A driver waits in at
+// most one queue at a time.
+type QueueStore interface {
+	// Join adds the driver to the back of the zone's queue, taking them out
+	// of any other. Drivers already in the zone's queue keep their place.
+	Join(ctx context.Context, zoneID, driverID string, at time.Time) error
+	// Leave takes the driver out of the queue they are in, if any
+	Leave(ctx context.Context, driverID string) error
+	// Entry returns the driver's place, ErrNotQueued if they are in no queue
+	Entry(ctx context.Context, driverID string) (*QueueEntry, error)
+	// List returns up to limit drivers from the head of the zone's queue
+	List(ctx context.Context, zoneID string, limit int) ([]*QueueEntry, error)
+}
+
+// The {zone_queue} hash tag keeps every zone's queue and the driver index in
+// one Redis Cluster slot, so a driver can move between queues in a transaction
+const (
+	redisQueueKeyPrefix = "{zone_queue}:zone:"
+	// redisQueueDriversKey maps each queued driver to the zone they wait in
+	redisQueueDriversKey = "{zone_queue}:drivers"
+)
+
+// RedisQueueStore keeps each zone's queue in a sorted set scored by when
+// drivers joined it
+type RedisQueueStore struct {
+	client redis.UniversalClient
+}
+
+// NewRedisQueueStore creates a new Redis-backed queue store
+func NewRedisQueueStore(client redis.UniversalClient) *RedisQueueStore {
+	return &RedisQueueStore{client: client}
+}
+
+// Join queues the driver in the zone
+func (r *RedisQueueStore) Join(ctx context.Context, zoneID, driverID string, at time.Time) error {
+	current, err := r.client.HGet(ctx, redisQueueDriversKey, driverID).Result()
+	if err != nil && err != redis.Nil {
+		return fmt.Errorf("failed to get driver queue: %w", err)
+	}
+	if current == zoneID {
+		return nil
+	}
+
+	pipe := r.client.TxPipeline()
+	if current != "" {
+		pipe.ZRem(ctx, redisQueueKeyPrefix+current, driverID)
+	}
+	pipe.ZAddNX(ctx, redisQueueKeyPrefix+zoneID, redis.Z{Score: float64(at.UnixMilli()), Member: driverID})
+	pipe.HSet(ctx, redisQueueDriversKey, driverID, zoneID)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to join zone queue: %w", err)
+	}
+	return nil
+}
+
+// Leave takes the driver out of their queue
+func (r *RedisQueueStore) Leave(ctx context.Context, driverID string) error {
+	current, err := r.client.HGet(ctx, redisQueueDriversKey, driverID).Result()
+	if err == redis.Nil {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get driver queue: %w", err)
+	}
+
+	pipe := r.client.TxPipeline()
+	pipe.ZRem(ctx, redisQueueKeyPrefix+current, driverID)
+	pipe.HDel(ctx, redisQueueDriversKey, driverID)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to leave zone queue: %w", err)
+	}
+	return nil
+}
+
+// Entry reads the driver's rank and join time in their queue
+func (r *RedisQueueStore) Entry(ctx context.Context, driverID string) (*QueueEntry, error) {
+	zoneID, err := r.client.HGet(ctx, redisQueueDriversKey, driverID).Result()
+	if err == redis.Nil {
+		return nil, ErrNotQueued
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get driver queue: %w", err)
+	}
+
+	key := redisQueueKeyPrefix + zoneID
+	pipe := r.client.Pipeline()
+	rank := pipe.ZRank(ctx, key, driverID)
+	score := pipe.ZScore(ctx, key, driverID)
+	if _, err := pipe.Exec(ctx); err == redis.Nil {
+		return nil, ErrNotQueued
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get queue position: %w", err)
+	}
+	return &QueueEntry{
+		ZoneID:   zoneID,
+		DriverID: driverID,
+		Position: int(rank.Val()) + 1,
+		JoinedAt: time.UnixMilli(int64(score.Val())),
+	}, nil
+}
+
+// List reads the head of the zone's queue
+func (r *RedisQueueStore) List(ctx context.Context, zoneID string, limit int) ([]*QueueEntry, error) {
+	members, err := r.client.ZRangeWithScores(ctx, redisQueueKeyPrefix+zoneID, 0, int64(limit)-1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list zone queue: %w", err)
+	}
+
+	entries := make([]*QueueEntry, 0, len(members))
+	for i, member := range members {
+		driverID, _ := member.Member.(string)
+		entries = append(entries, &QueueEntry{
+			ZoneID:   zoneID,
+			DriverID: driverID,
+			Position: i + 1,
+			JoinedAt: time.UnixMilli(int64(member.Score)),
+		})
+	}
+	return entries, nil
+}
+
+// MemoryQueueStore implements QueueStore in memory
+type MemoryQueueStore struct {
+	joined map[string]QueueEntry
+	mutex  sync.RWMutex
+}
+
+// NewMemoryQueueStore creates a new in-memory queue store
+func NewMemoryQueueStore() *MemoryQueueStore {
+	return &MemoryQueueStore{joined: make(map[string]QueueEntry)}
+}
+
+// Join queues the driver in the zone
+func (m *MemoryQueueStore) Join(ctx context.Context, zoneID, driverID string, at time.Time) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if entry, exists := m.joined[driverID]; exists && entry.ZoneID == zoneID {
+		return nil
+	}
+	m.joined[driverID] = QueueEntry{ZoneID: zoneID, DriverID: driverID, JoinedAt: at}
+	return nil
+}
+
+// Leave takes the driver out of their queue
+func (m *MemoryQueueStore) Leave(ctx context.Context, driverID string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	delete(m.joined, driverID)
+	return nil
+}
+
+// Entry returns the driver's place in their queue
+func (m *MemoryQueueStore) Entry(ctx context.Context, driverID string) (*QueueEntry, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	entry, exists := m.joined[driverID]
+	if !exists {
+		return nil, ErrNotQueued
+	}
+	for i, queued := range m.queue(entry.ZoneID) {
+		if queued.DriverID == driverID {
+			queued.Position = i + 1
+			return &queued, nil
+		}
+	}
+	return nil, ErrNotQueued
+}
+
+// List returns the head of the zone's queue
+func (m *MemoryQueueStore) List(ctx context.Context, zoneID string, limit int) ([]*QueueEntry, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	entries := []*QueueEntry{}
+	for i, queued := range m.queue(zoneID) {
+		if i == limit {
+			break
+		}
+		queued := queued
+		queued.Position = i + 1
+		entries = append(entries, &queued)
+	}
+	return entries, nil
+}
+
+// queue returns the zone's entries, earliest joined first
+func (m *MemoryQueueStore) queue(zoneID string) []QueueEntry {
+	var queue []QueueEntry
+	for _, entry := range m.joined {
+		if entry.ZoneID == zoneID {
+			queue = append(queue, entry)
+		}
+	}
+	sort.Slice(queue, func(i, j int) bool {
+		if !queue[i].JoinedAt.Equal(queue[j].JoinedAt) {
+			return queue[i].JoinedAt.Before(queue[j].JoinedAt)
+		}
+		return queue[i].DriverID < queue[j].DriverID
+	})
+	return queue
+}
+
+// maxQueueListLimit bounds how much of a queue is read at once
+const maxQueueListLimit = 100
+
+// Queues places drivers in the queues of the dispatch zones they wait in
+// and ejects them once they leave the zone or stop waiting for a trip
+type Queues struct {
+	zones *Service
+	store QueueStore
+}
+
+// NewQueues creates the dispatch zone queues
+func NewQueues(zones *Service, store QueueStore) *Queues {
+	return &Queues{zones: zones, store: store}
+}
+
+// Track places a driver who reported a location in the queue of the
+// dispatch zone containing it. Drivers who are not waiting for a trip, or
+// are outside every dispatch zone, leave the queue they were in.
+func (q *Queues) Track(ctx context.Context, driverID string, latitude, longitude float64, waiting bool, at time.Time) error {
+	if !waiting {
+		return q.store.Leave(ctx, driverID)
+	}
+	membership, err := q.zones.Lookup(ctx, latitude, longitude)
+	if err != nil {
+		return err
+	}
+	if membership.QueueZoneID == "" {
+		return q.store.Leave(ctx, driverID)
+	}
+	return q.store.Join(ctx, membership.QueueZoneID, driverID, at)
+}
+
+// Leave takes a driver out of the queue they are in
+func (q *Queues) Leave(ctx context.Context, driverID string) error {
+	return q.store.Leave(ctx, driverID)
+}
+
+// Position returns a driver's place in the queue they wait in
+func (q *Queues) Position(ctx context.Context, driverID string) (*QueueEntry, error) {
+	return q.store.Entry(ctx, driverID)
+}
+
+// List returns up to limit drivers from the head of a dispatch zone's queue
+func (q *Queues) List(ctx context.Context, zoneID string, limit int) ([]*QueueEntry, error) {
+	zone, err := q.zones.Get(ctx, zoneID)
+	if err != nil {
+		return nil, err
+	}
+	if !zone.DispatchQueue {
+		return nil, fmt.Errorf("%w: %s", ErrNotQueueZone, zone.Name)
+	}
+	if limit <= 0 || limit > maxQueueListLimit {
+		limit = maxQueueListLimit
+	}
+	return q.store.List(ctx, zoneID, limit)
+}
+
+// SubscribeDriverEvents ejects drivers from their queue as soon as they go
+// on a trip, on a break or offline, rather than at their next location
+func (q *Queues) SubscribeDriverEvents(bus events.EventBus) error {
+	return bus.Subscribe(events.DriverStateChangedEvent, func(ctx context.Context, event *events.Event) error {
+		driverID, _ := event.Data["driver_id"].(string)
+		to, _ := event.Data["to"].(string)
+		if driverID == "" || to == "online" {
+			return nil
+		}
+		return q.store.Leave(ctx, driverID)
+	})
+}
@@ -0,0 +1,85 @@
+package geofence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rideshare-platform/shared/utils"
+)
+
+// Service manages zones and answers point-in-zone lookups
+type Service struct {
+	store Store
+}
+
+// NewService creates a new zone service
+func NewService(store Store) *Service {
+	return &Service{store: store}
+}
+
+// Create validates and saves a new zone. Restricted zones always restrict pickups.
+func (s *Service) Create(ctx context.Context, zone *Zone) (*Zone, error) {
+	if err := zone.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	created := *zone
+	created.ID = utils.GenerateID()
+	created.PickupRestricted = zone.PickupRestricted || zone.Type == ZoneTypeRestricted
+	created.CreatedAt = now
+	created.UpdatedAt = now
+	if err := s.store.Save(ctx, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// Update replaces an existing zone's fields, keeping its ID and creation time
+func (s *Service) Update(ctx context.Context, id string, zone *Zone) (*Zone, error) {
+	if err := zone.Validate(); err != nil {
+		return nil, err
+	}
+	existing, err := s.store.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := *zone
+	updated.ID = existing.ID
+	updated.PickupRestricted = zone.PickupRestricted || zone.Type == ZoneTypeRestricted
+	updated.CreatedAt = existing.CreatedAt
+	updated.UpdatedAt = time.Now()
+	if err := s.store.Save(ctx, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// Get returns a zone by ID
+func (s *Service) Get(ctx context.Context, id string) (*Zone, error) {
+	return s.store.Get(ctx, id)
+}
+
+// Delete removes a zone
+func (s *Service) Delete(ctx context.Context, id string) error {
+	return s.store.Delete(ctx, id)
+}
+
+// List returns zones of the given type, or all zones if zoneType is empty
+func (s *Service) List(ctx context.Context, zoneType ZoneType) ([]*Zone, error) {
+	return s.store.List(ctx, zoneType)
+}
+
+// Lookup returns the active zones containing a point
+func (s *Service) Lookup(ctx context.Context, latitude, longitude float64) (*Membership, error) {
+	if !validCoordinates(latitude, longitude) {
+		return nil, fmt.Errorf("%w: %f, %f", ErrInvalidPoint, latitude, longitude)
+	}
+	zones, err := s.store.FindContaining(ctx, latitude, longitude)
+	if err != nil {
+		return nil, err
+	}
+	return newMembership(zones), nil
+}
@@ -0,0 +1,174 @@
+package geofence
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ZonesCollection holds one document per zone, with a 2dsphere index on the boundary
+const ZonesCollection = "geofences"
+
+// Store persists zones and finds the active zones containing a point
+type Store interface {
+	// Save inserts the zone or replaces the zone with the same ID
+	Save(ctx context.Context, zone *Zone) error
+	Get(ctx context.Context, id string) (*Zone, error)
+	Delete(ctx context.Context, id string) error
+	// List returns zones ordered by name, all types if zoneType is empty
+	List(ctx context.Context, zoneType ZoneType) ([]*Zone, error)
+	// FindContaining returns the active zones whose boundary contains the point
+	FindContaining(ctx context.Context, latitude, longitude float64) ([]*Zone, error)
+}
+
+// MongoStore keeps zones in MongoDB
+type MongoStore struct {
+	collection *mongo.Collection
+}
+
+// NewMongoStore creates a store backed by the geofences collection
+func NewMongoStore(db *mongo.Database) *MongoStore {
+	return &MongoStore{collection: db.Collection(ZonesCollection)}
+}
+
+// Save inserts or replaces a zone
+func (s *MongoStore) Save(ctx context.Context, zone *Zone) error {
+	_, err := s.collection.ReplaceOne(ctx, bson.M{"_id": zone.ID}, zone, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to save zone: %w", err)
+	}
+	return nil
+}
+
+// Get returns a zone by ID
+func (s *MongoStore) Get(ctx context.Context, id string) (*Zone, error) {
+	var zone Zone
+	err := s.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&zone)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrZoneNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get zone: %w", err)
+	}
+	return &zone, nil
+}
+
+// Delete removes a zone
+func (s *MongoStore) Delete(ctx context.Context, id string) error {
+	result, err := s.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("failed to delete zone: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return ErrZoneNotFound
+	}
+	return nil
+}
+
+// List returns zones ordered by name
+func (s *MongoStore) List(ctx context.Context, zoneType ZoneType) ([]*Zone, error) {
+	filter := bson.M{}
+	if zoneType != "" {
+		filter["type"] = zoneType
+	}
+	return s.find(ctx, filter, options.Find().SetSort(bson.D{{Key: "name", Value: 1}}))
+}
+
+// FindContaining uses $geoIntersects, served by the boundary's 2dsphere index
+func (s *MongoStore) FindContaining(ctx context.Context, latitude, longitude float64) ([]*Zone, error) {
+	return s.find(ctx, bson.M{
+		"active": true,
+		"boundary": bson.M{"$geoIntersects": bson.M{"$geometry": bson.M{
+			"type":        "Point",
+			"coordinates": []float64{longitude, latitude},
+		}}},
+	})
+}
+
+func (s *MongoStore) find(ctx context.Context, filter bson.M, opts ...*options.FindOptions) ([]*Zone, error) {
+	cursor, err := s.collection.Find(ctx, filter, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find zones: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	zones := []*Zone{}
+	if err := cursor.All(ctx, &zones); err != nil {
+		return nil, fmt.Errorf("failed to decode zones: %w", err)
+	}
+	return zones, nil
+}
+
+// MemoryStore keeps zones in memory
+type MemoryStore struct {
+	zones map[string]Zone
+	mutex sync.RWMutex
+}
+
+// NewMemoryStore creates a new in-memory zone store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{zones: make(map[string]Zone)}
+}
+
+// Save stores a copy of a zone
+func (s *MemoryStore) Save(ctx context.Context, zone *Zone) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.zones[zone.ID] = *zone
+	return nil
+}
+
+// Get returns a copy of a zone
+func (s *MemoryStore) Get(ctx context.Context, id string) (*Zone, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	zone, exists := s.zones[id]
+	if !exists {
+		return nil, ErrZoneNotFound
+	}
+	return &zone, nil
+}
+
+// Delete removes a zone
+func (s *MemoryStore) Delete(ctx context.Context, id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, exists := s.zones[id]; !exists {
+		return ErrZoneNotFound
+	}
+	delete(s.zones, id)
+	return nil
+}
+
+// List returns zones ordered by name
+func (s *MemoryStore) List(ctx context.Context, zoneType ZoneType) ([]*Zone, error) {
+	return s.filter(func(zone *Zone) bool {
+		return zoneType == "" || zone.Type == zoneType
+	}), nil
+}
+
+// FindContaining tests the point against every active zone's boundary
+func (s *MemoryStore) FindContaining(ctx context.Context, latitude, longitude float64) ([]*Zone, error) {
+	return s.filter(func(zone *Zone) bool {
+		return zone.Active && zone.Boundary.Contains(latitude, longitude)
+	}), nil
+}
+
+func (s *MemoryStore) filter(match func(zone *Zone) bool) []*Zone {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	zones := []*Zone{}
+	for _, zone := range s.zones {
+		if match(&zone) {
+			zones = append(zones, &zone)
+		}
+	}
+	sort.Slice(zones, func(i, j int) bool { return zones[i].Name < zones[j].Name })
+	return zones
+}
@@ -0,0 +1,156 @@
+// Package geofence manages polygonal zones such as airports, event venues and
+// restricted areas, and answers which zones contain a point. Pricing adds
+// zone surcharges, matching refuses pickups in restricted zones and
+// dispatches pickups in queue zones to the drivers waiting there in turn.
+package geofence
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ZoneType is the kind of area a zone covers
+type ZoneType string
+
+const (
+	ZoneTypeAirport    ZoneType = "airport"
+	ZoneTypeEventVenue ZoneType = "event_venue"
+	ZoneTypeRestricted ZoneType = "restricted"
+)
+
+var (
+	// ErrZoneNotFound is returned when no zone exists with the given ID
+	ErrZoneNotFound = errors.New("zone not found")
+	// ErrInvalidZone is returned when a zone's fields or boundary are malformed
+	ErrInvalidZone = errors.New("invalid zone")
+	// ErrInvalidPoint is returned when a lookup point is outside valid coordinates
+	ErrInvalidPoint = errors.New("invalid coordinates")
+)
+
+// Polygon is a GeoJSON polygon. The first ring is the outer boundary and any
+// further rings are holes. Positions are [longitude, latitude] and each ring
+// must be closed, ending on its first position.
+type Polygon struct {
+	Type        string        `json:"type" bson:"type"`
+	Coordinates [][][]float64 `json:"coordinates" bson:"coordinates"`
+}
+
+// Zone is a named area with pricing and pickup rules
+type Zone struct {
+	ID       string   `json:"id" bson:"_id"`
+	Name     string   `json:"name" bson:"name"`
+	Type     ZoneType `json:"type" bson:"type"`
+	Boundary Polygon  `json:"boundary" bson:"boundary"`
+	// Surcharge is a flat amount added to fares for pickups inside the zone
+	Surcharge float64 `json:"surcharge" bson:"surcharge"`
+	// DispatchQueue makes the zone a FIFO dispatch zone: drivers waiting in
+	// it queue in the order they arrived and pickups inside it go to the
+	// head of the queue rather than the nearest driver
+	DispatchQueue bool `json:"dispatch_queue" bson:"dispatch_queue"`
+	// PickupRestricted keeps riders from being picked up inside the zone
+	PickupRestricted bool      `json:"pickup_restricted" bson:"pickup_restricted"`
+	Active           bool      `json:"active" bson:"active"`
+	CreatedAt        time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// Validate checks the zone's type, surcharge and boundary
+func (z *Zone) Validate() error {
+	if z.Name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidZone)
+	}
+	switch z.Type {
+	case ZoneTypeAirport, ZoneTypeEventVenue, ZoneTypeRestricted:
+	default:
+		return fmt.Errorf("%w: unknown zone type %q", ErrInvalidZone, z.Type)
+	}
+	if z.Surcharge < 0 {
+		return fmt.Errorf("%w: surcharge must not be negative", ErrInvalidZone)
+	}
+	if z.DispatchQueue && z.Type == ZoneTypeRestricted {
+		return fmt.Errorf("%w: restricted zones have no pickups to dispatch", ErrInvalidZone)
+	}
+	return z.Boundary.validate()
+}
+
+func (p Polygon) validate() error {
+	if p.Type != "Polygon" {
+		return fmt.Errorf("%w: boundary must be a GeoJSON Polygon", ErrInvalidZone)
+	}
+	if len(p.Coordinates) == 0 {
+		return fmt.Errorf("%w: boundary has no rings", ErrInvalidZone)
+	}
+	for i, ring := range p.Coordinates {
+		if len(ring) < 4 {
+			return fmt.Errorf("%w: ring %d needs at least 4 positions", ErrInvalidZone, i)
+		}
+		for _, position := range ring {
+			if len(position) != 2 || !validCoordinates(position[1], position[0]) {
+				return fmt.Errorf("%w: ring %d has an invalid position %v", ErrInvalidZone, i, position)
+			}
+		}
+		first, last := ring[0], ring[len(ring)-1]
+		if first[0] != last[0] || first[1] != last[1] {
+			return fmt.Errorf("%w: ring %d is not closed", ErrInvalidZone, i)
+		}
+	}
+	return nil
+}
+
+// Contains reports whether the point lies inside the outer ring and outside every hole
+func (p Polygon) Contains(latitude, longitude float64) bool {
+	if len(p.Coordinates) == 0 || !ringContains(p.Coordinates[0], latitude, longitude) {
+		return false
+	}
+	for _, hole := range p.Coordinates[1:] {
+		if ringContains(hole, latitude, longitude) {
+			return false
+		}
+	}
+	return true
+}
+
+// ringContains casts a ray east from the point and counts the edges it crosses
+func ringContains(ring [][]float64, latitude, longitude float64) bool {
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		lngI, latI := ring[i][0], ring[i][1]
+		lngJ, latJ := ring[j][0], ring[j][1]
+		if (latI > latitude) != (latJ > latitude) &&
+			longitude < (lngJ-lngI)*(latitude-latI)/(latJ-latI)+lngI {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+func validCoordinates(latitude, longitude float64) bool {
+	return latitude >= -90 && latitude <= 90 && longitude >= -180 && longitude <= 180
+}
+
+// Membership lists the active zones containing a point and the rules they impose
+type Membership struct {
+	Zones []*Zone `json:"zones"`
+	// Surcharge is the highest surcharge of the zones, overlapping zones do not stack
+	Surcharge        float64 `json:"surcharge"`
+	PickupRestricted bool    `json:"pickup_restricted"`
+	// QueueZoneID is the dispatch zone pickups at the point are served from
+	QueueZoneID string `json:"queue_zone_id,omitempty"`
+}
+
+func newMembership(zones []*Zone) *Membership {
+	membership := &Membership{Zones: zones}
+	for _, zone := range zones {
+		if zone.Surcharge > membership.Surcharge {
+			membership.Surcharge = zone.Surcharge
+		}
+		if zone.PickupRestricted {
+			membership.PickupRestricted = true
+		}
+		if zone.DispatchQueue && membership.QueueZoneID == "" {
+			membership.QueueZoneID = zone.ID
+		}
+	}
+	return membership
+}
@@ -2,6 +2,7 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"time"
@@ -10,6 +11,13 @@ import (
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/rideshare-platform/services/geo-service/internal/driverstate"
+	"github.com/rideshare-platform/services/geo-service/internal/geofence"
+	"github.com/rideshare-platform/services/geo-service/internal/ingest"
+	"github.com/rideshare-platform/services/geo-service/internal/pickup"
+	"github.com/rideshare-platform/services/geo-service/internal/route"
+	"github.com/rideshare-platform/services/geo-service/internal/service"
+	"github.com/rideshare-platform/shared/city"
 	"github.com/rideshare-platform/shared/models"
 	geopb "github.com/rideshare-platform/shared/proto/geo"
 )
@@ -99,7 +107,10 @@ func (s *Server) FindNearbyDrivers(ctx context.Context, req *geopb.NearbyDrivers
 	}
 
 	// Use the internal service to find nearby drivers
-	nearbyDrivers, err := s.geoService.FindNearbyDrivers(ctx, center, req.RadiusKm, int(req.Limit), req.VehicleTypes, req.OnlyAvailable)
+	nearbyDrivers, err := s.geoService.FindNearbyDrivers(ctx, center, req.RadiusKm, int(req.Limit), req.CityId, req.VehicleTypes, req.OnlyAvailable)
+	if errors.Is(err, city.ErrUnknownCity) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	if err != nil {
 		s.logger.WithError(err).Error("Failed to find nearby drivers")
 		return nil, status.Error(codes.Internal, "failed to find nearby drivers")
@@ -120,7 +131,10 @@ func (s *Server) FindNearbyDrivers(ctx context.Context, req *geopb.NearbyDrivers
 			DistanceFromCenter: driver.DistanceFromCenter,
 			Status:             driver.Status,
 			VehicleType:        driver.VehicleType,
+			VehicleFeatures:    driver.VehicleFeatures,
 			Rating:             driver.Rating,
+			CityId:             driver.CityID,
+			LastSeenAt:         timestamppb.New(driver.LastSeenAt),
 		}
 		grpcDrivers = append(grpcDrivers, grpcDriver)
 	}
@@ -141,6 +155,7 @@ func (s *Server) UpdateDriverLocation(ctx context.Context, req *geopb.UpdateDriv
 	location := models.Location{
 		Latitude:  req.Location.Latitude,
 		Longitude: req.Location.Longitude,
+		Accuracy:  req.Location.Accuracy,
 		Timestamp: time.Now(),
 	}
 
@@ -149,7 +164,10 @@ func (s *Server) UpdateDriverLocation(ctx context.Context, req *geopb.UpdateDriv
 	}
 
 	// Update driver location using the internal service
-	err := s.geoService.UpdateDriverLocation(ctx, req.DriverId, location, req.Status, req.VehicleId)
+	err := s.geoService.UpdateDriverLocation(ctx, req.DriverId, location, req.Status, req.VehicleId, req.CityId, req.VehicleFeatures)
+	if errors.Is(err, city.ErrUnknownCity) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	if err != nil {
 		s.logger.WithError(err).Error("Failed to update driver location")
 		return &geopb.UpdateDriverLocationResponse{
@@ -328,6 +346,55 @@ func (s *Server) SubscribeToDriverLocations(req *geopb.SubscribeToDriverLocation
 	}
 }
 
+// StreamPickupETA implements the gRPC StreamPickupETA method
+func (s *Server) StreamPickupETA(req *geopb.StreamPickupETARequest, stream geopb.GeospatialService_StreamPickupETAServer) error {
+	if req.TripId == "" || req.DriverId == "" || req.Pickup == nil {
+		return status.Error(codes.InvalidArgument, "trip_id, driver_id and pickup are required")
+	}
+
+	pickupReq := pickup.Request{
+		TripID:      req.TripId,
+		DriverID:    req.DriverId,
+		Pickup:      models.Location{Latitude: req.Pickup.Latitude, Longitude: req.Pickup.Longitude},
+		VehicleType: req.VehicleType,
+	}
+	if !pickupReq.Pickup.IsValid() {
+		return status.Error(codes.InvalidArgument, "pickup has invalid coordinates")
+	}
+
+	err := s.geoService.StreamPickupETA(stream.Context(), pickupReq, func(update *pickup.Update) error {
+		return stream.Send(&geopb.PickupETAUpdate{
+			TripId:   update.TripID,
+			DriverId: update.DriverID,
+			DriverLocation: &geopb.Location{
+				Latitude:  update.DriverLocation.Latitude,
+				Longitude: update.DriverLocation.Longitude,
+				Accuracy:  update.DriverLocation.Accuracy,
+				Timestamp: timestamppb.New(update.DriverLocation.Timestamp),
+			},
+			EtaSeconds:       int32(update.ETASeconds),
+			DistanceMeters:   update.DistanceMeters,
+			EstimatedArrival: timestamppb.New(update.EstimatedArrival),
+			Model:            update.Model,
+			Arrived:          update.Arrived,
+			ComputedAt:       timestamppb.New(update.ComputedAt),
+		})
+	})
+	switch {
+	case err == nil, stream.Context().Err() != nil:
+		return nil
+	case errors.Is(err, pickup.ErrTooManyStreams):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, service.ErrPickupETAUnavailable):
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	s.logger.WithError(err).Error("Pickup ETA stream failed")
+	return status.Error(codes.Internal, "pickup ETA stream failed")
+}
+
 // StartLocationTracking implements location tracking session initiation
 func (s *Server) StartLocationTracking(ctx context.Context, req *geopb.StartLocationTrackingRequest) (*geopb.StartLocationTrackingResponse, error) {
 	s.logger.WithFields(map[string]interface{}{
@@ -362,3 +429,253 @@ func (s *Server) StartLocationTracking(ctx context.Context, req *geopb.StartLoca
 		Message:   "Location tracking session started successfully",
 	}, nil
 }
+
+// FindZones returns the active zones containing a location
+func (s *Server) FindZones(ctx context.Context, req *geopb.FindZonesRequest) (*geopb.FindZonesResponse, error) {
+	if req.Location == nil {
+		return nil, status.Error(codes.InvalidArgument, "location is required")
+	}
+	if s.zones == nil {
+		return nil, status.Error(codes.Unimplemented, "zones are not configured")
+	}
+
+	membership, err := s.zones.Lookup(ctx, req.Location.Latitude, req.Location.Longitude)
+	if errors.Is(err, geofence.ErrInvalidPoint) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to look up zones")
+		return nil, status.Error(codes.Internal, "failed to look up zones")
+	}
+
+	zones := make([]*geopb.Zone, 0, len(membership.Zones))
+	for _, zone := range membership.Zones {
+		zones = append(zones, &geopb.Zone{
+			Id:               zone.ID,
+			Name:             zone.Name,
+			Type:             string(zone.Type),
+			Surcharge:        zone.Surcharge,
+			PickupRestricted: zone.PickupRestricted,
+			DispatchQueue:    zone.DispatchQueue,
+		})
+	}
+
+	return &geopb.FindZonesResponse{
+		Zones:            zones,
+		Surcharge:        membership.Surcharge,
+		PickupRestricted: membership.PickupRestricted,
+		QueueZoneId:      membership.QueueZoneID,
+	}, nil
+}
+
+// GetZoneQueue lists the drivers waiting in a dispatch zone's queue
+func (s *Server) GetZoneQueue(ctx context.Context, req *geopb.GetZoneQueueRequest) (*geopb.GetZoneQueueResponse, error) {
+	if req.ZoneId == "" {
+		return nil, status.Error(codes.InvalidArgument, "zone_id is required")
+	}
+	if s.queues == nil {
+		return nil, status.Error(codes.Unimplemented, "dispatch queues are not configured")
+	}
+
+	queue, err := s.queues.List(ctx, req.ZoneId, int(req.Limit))
+	switch {
+	case errors.Is(err, geofence.ErrZoneNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, geofence.ErrNotQueueZone):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case err != nil:
+		s.logger.WithError(err).Error("Failed to list zone queue")
+		return nil, status.Error(codes.Internal, "failed to list zone queue")
+	}
+
+	drivers := make([]*geopb.QueuedDriver, 0, len(queue))
+	for _, entry := range queue {
+		drivers = append(drivers, &geopb.QueuedDriver{
+			DriverId: entry.DriverID,
+			Position: int32(entry.Position),
+			JoinedAt: timestamppb.New(entry.JoinedAt),
+		})
+	}
+	return &geopb.GetZoneQueueResponse{ZoneId: req.ZoneId, Drivers: drivers}, nil
+}
+
+// GetTripRoute returns the route recorded from driver locations during a trip
+func (s *Server) GetTripRoute(ctx context.Context, req *geopb.GetTripRouteRequest) (*geopb.GetTripRouteResponse, error) {
+	if req.TripId == "" {
+		return nil, status.Error(codes.InvalidArgument, "trip_id is required")
+	}
+
+	summary, err := s.geoService.TripRoute(ctx, req.TripId, req.MapMatch)
+	if errors.Is(err, route.ErrNoRoute) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get trip route")
+		return nil, status.Error(codes.Internal, "failed to get trip route")
+	}
+
+	points := make([]*geopb.Location, 0, len(summary.Points))
+	for _, point := range summary.Points {
+		points = append(points, &geopb.Location{
+			Latitude:  point.Latitude,
+			Longitude: point.Longitude,
+			Accuracy:  point.Accuracy,
+			Timestamp: timestamppb.New(point.Timestamp),
+		})
+	}
+
+	return &geopb.GetTripRouteResponse{
+		TripId:          summary.TripID,
+		Points:          points,
+		DistanceKm:      summary.DistanceKm,
+		DurationSeconds: int32(summary.DurationSeconds),
+		RawPointCount:   int32(summary.RawPoints),
+		Matched:         summary.Matched,
+	}, nil
+}
+
+// DeleteTripRoutes erases the routes recorded during trips, for data subject
+// erasure requests
+func (s *Server) DeleteTripRoutes(ctx context.Context, req *geopb.DeleteTripRoutesRequest) (*geopb.DeleteTripRoutesResponse, error) {
+	deleted, err := s.geoService.DeleteTripRoutes(ctx, req.TripIds)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to delete trip routes")
+		return nil, status.Error(codes.Internal, "failed to delete trip routes")
+	}
+	return &geopb.DeleteTripRoutesResponse{DeletedPoints: deleted}, nil
+}
+
+// IngestDriverLocations implements the gRPC IngestDriverLocations method
+func (s *Server) IngestDriverLocations(ctx context.Context, req *geopb.IngestDriverLocationsRequest) (*geopb.IngestDriverLocationsResponse, error) {
+	batches := make([]ingest.Batch, len(req.Batches))
+	for i, batch := range req.Batches {
+		points := make([]models.Location, len(batch.Locations))
+		for j, location := range batch.Locations {
+			points[j] = models.Location{
+				Latitude:  location.Latitude,
+				Longitude: location.Longitude,
+				Accuracy:  location.Accuracy,
+			}
+			// Locations without a timestamp are rejected rather than
+			// stamped with the upload time
+			if location.Timestamp != nil {
+				points[j].Timestamp = location.Timestamp.AsTime()
+			}
+		}
+		batches[i] = ingest.Batch{
+			DriverID:        batch.DriverId,
+			VehicleID:       batch.VehicleId,
+			Status:          batch.Status,
+			CityID:          batch.CityId,
+			VehicleFeatures: batch.VehicleFeatures,
+			Points:          points,
+		}
+	}
+
+	outcomes, err := s.geoService.IngestDriverLocations(ctx, batches)
+	switch {
+	case errors.Is(err, ingest.ErrInvalidBatch):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, service.ErrIngestUnavailable):
+		return nil, status.Error(codes.Unavailable, err.Error())
+	case err != nil:
+		s.logger.WithError(err).Error("Failed to ingest driver locations")
+		return nil, status.Error(codes.Internal, "failed to ingest driver locations")
+	}
+
+	resp := &geopb.IngestDriverLocationsResponse{Results: make([]*geopb.DriverIngestResult, len(outcomes))}
+	for i, outcome := range outcomes {
+		result := &geopb.DriverIngestResult{
+			DriverId:   outcome.DriverID,
+			Accepted:   int32(outcome.Accepted),
+			Duplicates: int32(outcome.Duplicates),
+			Error:      outcome.Error,
+		}
+		for _, rejection := range outcome.Rejected {
+			result.Rejected = append(result.Rejected, &geopb.RejectedLocation{
+				Index:  int32(rejection.Index),
+				Reason: rejection.Reason,
+			})
+		}
+		resp.Results[i] = result
+		resp.Accepted += result.Accepted
+		resp.Duplicates += result.Duplicates
+		resp.Rejected += int32(len(result.Rejected))
+	}
+	return resp, nil
+}
+
+// ListDriverShifts returns the shifts a driver worked in a time range, the
+// one in progress included
+func (s *Server) ListDriverShifts(ctx context.Context, req *geopb.ListDriverShiftsRequest) (*geopb.ListDriverShiftsResponse, error) {
+	if req.DriverId == "" || req.From == nil || req.To == nil {
+		return nil, status.Error(codes.InvalidArgument, "driver_id, from and to are required")
+	}
+	if s.drivers == nil {
+		return nil, status.Error(codes.Unimplemented, "driver states are not configured")
+	}
+
+	sessions, err := s.drivers.Shifts(ctx, req.DriverId, req.From.AsTime(), req.To.AsTime())
+	if errors.Is(err, driverstate.ErrNoShiftHistory) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to list driver shifts")
+		return nil, status.Error(codes.Internal, "failed to list driver shifts")
+	}
+
+	shifts := make([]*geopb.DriverShift, 0, len(sessions))
+	for _, session := range sessions {
+		shifts = append(shifts, &geopb.DriverShift{
+			StartedAt:     timestamppb.New(session.StartedAt),
+			EndedAt:       timestamppb.New(session.EndedAt),
+			InProgress:    session.EndReason == "",
+			EndReason:     string(session.EndReason),
+			OnTripSeconds: session.OnTripSeconds,
+			BreakSeconds:  session.BreakSeconds,
+		})
+	}
+	return &geopb.ListDriverShiftsResponse{Shifts: shifts}, nil
+}
+
+// GetDriverOnlineHours returns a driver's online, on-trip and break time per
+// UTC day, the shift in progress included
+func (s *Server) GetDriverOnlineHours(ctx context.Context, req *geopb.GetDriverOnlineHoursRequest) (*geopb.GetDriverOnlineHoursResponse, error) {
+	if req.DriverId == "" || req.From == "" || req.To == "" {
+		return nil, status.Error(codes.InvalidArgument, "driver_id, from and to are required")
+	}
+	if s.drivers == nil {
+		return nil, status.Error(codes.Unimplemented, "driver states are not configured")
+	}
+
+	days, err := s.drivers.Activity(ctx, req.DriverId, req.From, req.To)
+	if errors.Is(err, driverstate.ErrInvalidActivityRange) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if errors.Is(err, driverstate.ErrNoShiftHistory) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get driver online hours")
+		return nil, status.Error(codes.Internal, "failed to get driver online hours")
+	}
+
+	totals := driverstate.SumActivity(days)
+	resp := &geopb.GetDriverOnlineHoursResponse{
+		Days:          make([]*geopb.DriverDayActivity, 0, len(days)),
+		OnlineSeconds: totals.OnlineSeconds,
+		OnTripSeconds: totals.OnTripSeconds,
+		OnlineHours:   totals.OnlineHours,
+		Utilization:   totals.Utilization,
+	}
+	for _, day := range days {
+		resp.Days = append(resp.Days, &geopb.DriverDayActivity{
+			Date:          day.Date,
+			OnlineSeconds: day.OnlineSeconds,
+			OnTripSeconds: day.OnTripSeconds,
+			BreakSeconds:  day.BreakSeconds,
+			Shifts:        int32(day.Shifts),
+		})
+	}
+	return resp, nil
+}
@@ -10,6 +10,8 @@ import (
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 
+	"github.com/rideshare-platform/services/geo-service/internal/driverstate"
+	"github.com/rideshare-platform/services/geo-service/internal/geofence"
 	"github.com/rideshare-platform/services/geo-service/internal/service"
 	"github.com/rideshare-platform/shared/logger"
 	geopb "github.com/rideshare-platform/shared/proto/geo"
@@ -19,6 +21,9 @@ import (
 type Server struct {
 	geopb.UnimplementedGeospatialServiceServer
 	geoService service.GeospatialService
+	zones      *geofence.Service
+	queues     *geofence.Queues
+	drivers    *driverstate.Manager
 	logger     logger.Logger
 	grpcServer *grpc.Server
 }
@@ -31,6 +36,21 @@ func NewServer(geoService service.GeospatialService, logger logger.Logger) *Serv
 	}
 }
 
+// SetZones sets the zone service that answers FindZones
+func (s *Server) SetZones(zones *geofence.Service) {
+	s.zones = zones
+}
+
+// SetQueues sets the dispatch zone queues that answer GetZoneQueue
+func (s *Server) SetQueues(queues *geofence.Queues) {
+	s.queues = queues
+}
+
+// SetDriverStates sets the driver state manager that answers ListDriverShifts
+func (s *Server) SetDriverStates(drivers *driverstate.Manager) {
+	s.drivers = drivers
+}
+
 // Start starts the gRPC server on the specified port
 func (s *Server) Start(port int) error {
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
@@ -0,0 +1,37 @@
+package grpc
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/rideshare-platform/services/geo-service/internal/config"
+	"github.com/rideshare-platform/services/geo-service/internal/driverstate"
+	"github.com/rideshare-platform/services/geo-service/internal/geofence"
+	"github.com/rideshare-platform/services/geo-service/internal/service"
+	"github.com/rideshare-platform/shared/clients/contract"
+	"github.com/rideshare-platform/shared/clients/geoclient"
+	"github.com/rideshare-platform/shared/logger"
+	geopb "github.com/rideshare-platform/shared/proto/geo"
+)
+
+func TestServer_ServesContract(t *testing.T) {
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("failed to load the default configuration: %v", err)
+	}
+	log := logger.NewLogger("error", "test")
+	server := NewServer(*service.NewGeospatialService(cfg, log, nil, nil, nil, nil), *log)
+	zones := geofence.NewService(geofence.NewMemoryStore())
+	server.SetZones(zones)
+	server.SetQueues(geofence.NewQueues(zones, geofence.NewMemoryQueueStore()))
+	drivers := driverstate.NewManager(driverstate.NewMemoryStore(), nil, time.Minute, log)
+	drivers.SetSessionLog(driverstate.NewMemorySessionLog())
+	server.SetDriverStates(drivers)
+
+	conn := contract.Serve(t, func(grpcServer *grpc.Server) {
+		geopb.RegisterGeospatialServiceServer(grpcServer, server)
+	})
+	contract.Check(t, geoclient.ContractCalls(conn))
+}
@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rideshare-platform/services/geo-service/internal/driverstate"
+)
+
+// DriverStateHandler serves driver shift and availability endpoints
+type DriverStateHandler struct {
+	manager *driverstate.Manager
+}
+
+// NewDriverStateHandler creates a new driver state handler
+func NewDriverStateHandler(manager *driverstate.Manager) *DriverStateHandler {
+	return &DriverStateHandler{manager: manager}
+}
+
+// RegisterRoutes registers the driver state routes
+func (h *DriverStateHandler) RegisterRoutes(router *gin.Engine) {
+	drivers := router.Group("/api/v1/drivers/:driver_id")
+	{
+		drivers.GET("/state", h.getState)
+		drivers.POST("/online", h.goOnline)
+		drivers.POST("/offline", h.goOffline)
+		drivers.POST("/break", h.startBreak)
+		drivers.POST("/heartbeat", h.heartbeat)
+		drivers.POST("/trip/assigned", h.tripAssigned)
+		drivers.POST("/trip/completed", h.tripCompleted)
+		drivers.GET("/sessions", h.listSessions)
+		drivers.GET("/online-hours", h.onlineHours)
+		drivers.GET("/fatigue", h.fatigue)
+	}
+}
+
+func (h *DriverStateHandler) getState(c *gin.Context) {
+	state, err := h.manager.GetState(c.Request.Context(), c.Param("driver_id"))
+	h.respond(c, state, err)
+}
+
+func (h *DriverStateHandler) goOnline(c *gin.Context) {
+	state, err := h.manager.GoOnline(c.Request.Context(), c.Param("driver_id"))
+	h.respond(c, state, err)
+}
+
+func (h *DriverStateHandler) goOffline(c *gin.Context) {
+	state, err := h.manager.GoOffline(c.Request.Context(), c.Param("driver_id"))
+	h.respond(c, state, err)
+}
+
+func (h *DriverStateHandler) startBreak(c *gin.Context) {
+	state, err := h.manager.StartBreak(c.Request.Context(), c.Param("driver_id"))
+	h.respond(c, state, err)
+}
+
+func (h *DriverStateHandler) heartbeat(c *gin.Context) {
+	state, err := h.manager.Heartbeat(c.Request.Context(), c.Param("driver_id"))
+	h.respond(c, state, err)
+}
+
+func (h *DriverStateHandler) tripAssigned(c *gin.Context) {
+	var request struct {
+		TripID string `json:"trip_id" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	state, err := h.manager.TripAssigned(c.Request.Context(), c.Param("driver_id"), request.TripID)
+	h.respond(c, state, err)
+}
+
+func (h *DriverStateHandler) tripCompleted(c *gin.Context) {
+	var request struct {
+		TripID string `json:"trip_id" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	state, err := h.manager.TripCompleted(c.Request.Context(), c.Param("driver_id"), request.TripID)
+	h.respond(c, state, err)
+}
+
+// listSessions returns the driver's shifts overlapping [from, to), RFC 3339
+// times defaulting to the last 7 days, the one in progress included
+func (h *DriverStateHandler) listSessions(c *gin.Context) {
+	to := time.Now()
+	from := to.Add(-7 * 24 * time.Hour)
+	for param, value := range map[string]*time.Time{"from": &from, "to": &to} {
+		if raw := c.Query(param); raw != "" {
+			parsed, err := time.Parse(time.RFC3339, raw)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": param + " must be an RFC 3339 time"})
+				return
+			}
+			*value = parsed
+		}
+	}
+	if !to.After(from) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to must be after from"})
+		return
+	}
+
+	sessions, err := h.manager.Shifts(c.Request.Context(), c.Param("driver_id"), from, to)
+	if err != nil {
+		h.respondHistoryError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"sessions": sessions,
+		"count":    len(sessions),
+	})
+}
+
+// onlineHours returns the driver's online, on-trip and break time per UTC
+// day from from to to (YYYY-MM-DD, both included, defaulting to today)
+func (h *DriverStateHandler) onlineHours(c *gin.Context) {
+	today := time.Now().UTC().Format(driverstate.ActivityDateLayout)
+	from := c.DefaultQuery("from", today)
+	to := c.DefaultQuery("to", from)
+
+	days, err := h.manager.Activity(c.Request.Context(), c.Param("driver_id"), from, to)
+	if err != nil {
+		h.respondHistoryError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"driver_id": c.Param("driver_id"),
+		"from":      from,
+		"to":        to,
+		"days":      days,
+		"totals":    driverstate.SumActivity(days),
+	})
+}
+
+// fatigue returns how much of their city's driving-hour limits the driver
+// has used
+func (h *DriverStateHandler) fatigue(c *gin.Context) {
+	status, err := h.manager.Fatigue(c.Request.Context(), c.Param("driver_id"))
+	switch {
+	case err == nil:
+		c.JSON(http.StatusOK, status)
+	case errors.Is(err, driverstate.ErrNoFatigueRules):
+		c.JSON(http.StatusNotImplemented, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+func (h *DriverStateHandler) respondHistoryError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, driverstate.ErrInvalidActivityRange):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, driverstate.ErrNoShiftHistory):
+		c.JSON(http.StatusNotImplemented, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+func (h *DriverStateHandler) respond(c *gin.Context, state *driverstate.DriverState, err error) {
+	switch {
+	case err == nil:
+		c.JSON(http.StatusOK, state)
+	case errors.Is(err, driverstate.ErrInvalidTransition), errors.Is(err, driverstate.ErrDriverOffline):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, driverstate.ErrDriverNotApproved), errors.Is(err, driverstate.ErrFatigueLimitReached):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
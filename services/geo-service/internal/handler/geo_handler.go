@@ -2,11 +2,16 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"time"
 
+	"github.com/rideshare-platform/services/geo-service/internal/ingest"
+	"github.com/rideshare-platform/services/geo-service/internal/route"
 	"github.com/rideshare-platform/services/geo-service/internal/service"
+	"github.com/rideshare-platform/shared/health"
 	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
 
 	"github.com/gin-gonic/gin"
 )
@@ -14,11 +19,13 @@ import (
 type GeoHandler struct {
 	Logger     *logger.Logger
 	GeoService *service.GeospatialService
+	Health     *health.Checker
 }
 
 func (h *GeoHandler) RegisterRoutes(router *gin.Engine) {
 	// Health check at root level for test scripts
 	router.GET("/health", h.healthCheck)
+	router.GET("/info", h.info)
 	router.GET("/test/mongodb", h.testMongoDB)
 	router.GET("/test/redis", h.testRedis)
 	router.GET("/test/geospatial", h.testGeospatial)
@@ -33,11 +40,17 @@ func (h *GeoHandler) RegisterRoutes(router *gin.Engine) {
 		api.POST("/geo/eta", h.calculateETA)
 		api.POST("/geo/nearby-drivers", h.findNearbyDrivers)
 		api.PUT("/geo/driver-location", h.updateDriverLocation)
+		api.POST("/geo/driver-locations/batch", h.ingestDriverLocations)
 		api.POST("/geo/geohash", h.generateGeohash)
+		api.GET("/geo/trips/:trip_id/route", h.tripRoute)
 	}
 }
 
 func (h *GeoHandler) healthCheck(c *gin.Context) {
+	if h.Health != nil {
+		h.Health.Handler().ServeHTTP(c.Writer, c.Request)
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{
 		"status":  "healthy",
 		"service": "geo-service",
@@ -45,6 +58,15 @@ func (h *GeoHandler) healthCheck(c *gin.Context) {
 	})
 }
 
+// info returns the running build's metadata
+func (h *GeoHandler) info(c *gin.Context) {
+	if h.Health == nil {
+		c.JSON(http.StatusOK, health.NewInfo("geo-service", "1.0.0", ""))
+		return
+	}
+	h.Health.InfoHandler().ServeHTTP(c.Writer, c.Request)
+}
+
 func (h *GeoHandler) testMongoDB(c *gin.Context) {
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
@@ -187,6 +209,78 @@ func (h *GeoHandler) updateDriverLocation(c *gin.Context) {
 	})
 }
 
+// ingestDriverLocations takes in locations drivers buffered offline, in
+// batches per driver, and reports per driver what was ingested
+func (h *GeoHandler) ingestDriverLocations(c *gin.Context) {
+	var request struct {
+		Batches []struct {
+			DriverID        string   `json:"driver_id"`
+			VehicleID       string   `json:"vehicle_id"`
+			Status          string   `json:"status"`
+			CityID          string   `json:"city_id"`
+			VehicleFeatures []string `json:"vehicle_features"`
+			Locations       []struct {
+				Lat       float64   `json:"lat"`
+				Lng       float64   `json:"lng"`
+				Accuracy  float64   `json:"accuracy"`
+				Timestamp time.Time `json:"timestamp"`
+			} `json:"locations"`
+		} `json:"batches"`
+	}
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	batches := make([]ingest.Batch, len(request.Batches))
+	for i, batch := range request.Batches {
+		points := make([]models.Location, len(batch.Locations))
+		for j, location := range batch.Locations {
+			points[j] = models.Location{
+				Latitude:  location.Lat,
+				Longitude: location.Lng,
+				Accuracy:  location.Accuracy,
+				Timestamp: location.Timestamp,
+			}
+		}
+		batches[i] = ingest.Batch{
+			DriverID:        batch.DriverID,
+			VehicleID:       batch.VehicleID,
+			Status:          batch.Status,
+			CityID:          batch.CityID,
+			VehicleFeatures: batch.VehicleFeatures,
+			Points:          points,
+		}
+	}
+
+	outcomes, err := h.GeoService.IngestDriverLocations(c.Request.Context(), batches)
+	switch {
+	case errors.Is(err, ingest.ErrInvalidBatch):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, service.ErrIngestUnavailable):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	accepted, duplicates, rejected := 0, 0, 0
+	for _, outcome := range outcomes {
+		accepted += outcome.Accepted
+		duplicates += outcome.Duplicates
+		rejected += len(outcome.Rejected)
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"results":    outcomes,
+		"accepted":   accepted,
+		"duplicates": duplicates,
+		"rejected":   rejected,
+	})
+}
+
 func (h *GeoHandler) generateGeohash(c *gin.Context) {
 	var request struct {
 		Lat       float64 `json:"lat"`
@@ -213,3 +307,17 @@ func (h *GeoHandler) generateGeohash(c *gin.Context) {
 		"precision": request.Precision,
 	})
 }
+
+// tripRoute returns a trip's recorded route, map-matched unless match=false
+func (h *GeoHandler) tripRoute(c *gin.Context) {
+	summary, err := h.GeoService.TripRoute(c.Request.Context(), c.Param("trip_id"), c.Query("match") != "false")
+	if errors.Is(err, route.ErrNoRoute) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, summary)
+}
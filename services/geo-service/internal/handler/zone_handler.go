@@ -0,0 +1,183 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rideshare-platform/services/geo-service/internal/geofence"
+)
+
+// ZoneHandler serves zone management and point-in-zone lookup endpoints
+type ZoneHandler struct {
+	zones  *geofence.Service
+	queues *geofence.Queues
+}
+
+// NewZoneHandler creates a new zone handler
+func NewZoneHandler(zones *geofence.Service) *ZoneHandler {
+	return &ZoneHandler{zones: zones}
+}
+
+// RegisterRoutes registers the zone routes
+func (h *ZoneHandler) RegisterRoutes(router *gin.Engine) {
+	zones := router.Group("/api/v1/zones")
+	{
+		zones.POST("", h.createZone)
+		zones.GET("", h.listZones)
+		zones.GET("/lookup", h.lookup)
+		zones.GET("/:zone_id", h.getZone)
+		zones.PUT("/:zone_id", h.updateZone)
+		zones.DELETE("/:zone_id", h.deleteZone)
+		zones.GET("/:zone_id/queue", h.getQueue)
+	}
+	router.GET("/api/v1/drivers/:driver_id/queue", h.getQueuePosition)
+}
+
+// SetQueues sets the dispatch zone queues served under the zone and driver routes
+func (h *ZoneHandler) SetQueues(queues *geofence.Queues) {
+	h.queues = queues
+}
+
+// zoneRequest is the body of create and update requests. Zones are active unless stated otherwise.
+type zoneRequest struct {
+	Name             string            `json:"name" binding:"required"`
+	Type             geofence.ZoneType `json:"type" binding:"required"`
+	Boundary         geofence.Polygon  `json:"boundary"`
+	Surcharge        float64           `json:"surcharge"`
+	PickupRestricted bool              `json:"pickup_restricted"`
+	DispatchQueue    bool              `json:"dispatch_queue"`
+	Active           *bool             `json:"active"`
+}
+
+func (r *zoneRequest) zone() *geofence.Zone {
+	zone := &geofence.Zone{
+		Name:             r.Name,
+		Type:             r.Type,
+		Boundary:         r.Boundary,
+		Surcharge:        r.Surcharge,
+		PickupRestricted: r.PickupRestricted,
+		DispatchQueue:    r.DispatchQueue,
+		Active:           true,
+	}
+	if r.Active != nil {
+		zone.Active = *r.Active
+	}
+	return zone
+}
+
+func (h *ZoneHandler) createZone(c *gin.Context) {
+	var request zoneRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	zone, err := h.zones.Create(c.Request.Context(), request.zone())
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, zone)
+}
+
+func (h *ZoneHandler) listZones(c *gin.Context) {
+	zones, err := h.zones.List(c.Request.Context(), geofence.ZoneType(c.Query("type")))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"zones": zones, "count": len(zones)})
+}
+
+func (h *ZoneHandler) getZone(c *gin.Context) {
+	zone, err := h.zones.Get(c.Request.Context(), c.Param("zone_id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, zone)
+}
+
+func (h *ZoneHandler) updateZone(c *gin.Context) {
+	var request zoneRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	zone, err := h.zones.Update(c.Request.Context(), c.Param("zone_id"), request.zone())
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, zone)
+}
+
+func (h *ZoneHandler) deleteZone(c *gin.Context) {
+	if err := h.zones.Delete(c.Request.Context(), c.Param("zone_id")); err != nil {
+		h.respondError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *ZoneHandler) lookup(c *gin.Context) {
+	latitude, latErr := strconv.ParseFloat(c.Query("lat"), 64)
+	longitude, lngErr := strconv.ParseFloat(c.Query("lng"), 64)
+	if latErr != nil || lngErr != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "lat and lng query parameters are required"})
+		return
+	}
+
+	membership, err := h.zones.Lookup(c.Request.Context(), latitude, longitude)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, membership)
+}
+
+// getQueue lists the drivers at the head of a dispatch zone's queue
+func (h *ZoneHandler) getQueue(c *gin.Context) {
+	if h.queues == nil {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "dispatch queues are not configured"})
+		return
+	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	queue, err := h.queues.List(c.Request.Context(), c.Param("zone_id"), limit)
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"zone_id": c.Param("zone_id"), "drivers": queue, "count": len(queue)})
+}
+
+// getQueuePosition returns a driver's place in the queue they wait in
+func (h *ZoneHandler) getQueuePosition(c *gin.Context) {
+	if h.queues == nil {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "dispatch queues are not configured"})
+		return
+	}
+
+	entry, err := h.queues.Position(c.Request.Context(), c.Param("driver_id"))
+	if err != nil {
+		h.respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, entry)
+}
+
+func (h *ZoneHandler) respondError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, geofence.ErrZoneNotFound), errors.Is(err, geofence.ErrNotQueued):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, geofence.ErrInvalidZone), errors.Is(err, geofence.ErrInvalidPoint), errors.Is(err, geofence.ErrNotQueueZone):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,222 @@
+// Package ingest takes in the locations drivers buffer on their phones and
+// upload in batches. Points are deduplicated and must move forward in time,
+// and only each driver's latest position is written per batch: in one bulk
+// write to the store and one pipelined round trip to the GEO index.
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rideshare-platform/services/geo-service/internal/repository"
+	"github.com/rideshare-platform/shared/models"
+)
+
+const (
+	// MaxDrivers caps the drivers one request can carry batches for
+	MaxDrivers = 500
+	// MaxPointsPerDriver caps the points one driver's batch can carry
+	MaxPointsPerDriver = 1000
+	// MaxClockSkew is how far ahead of the server clock a point can be
+	// timestamped before it is rejected
+	MaxClockSkew = time.Minute
+)
+
+// ErrInvalidBatch is returned for a request that cannot be ingested at all
+var ErrInvalidBatch = errors.New("invalid location batch")
+
+// Reasons a point is rejected
+const (
+	ReasonMissingTimestamp = "missing_timestamp"
+	ReasonInvalidLocation  = "invalid_location"
+	ReasonFutureTimestamp  = "future_timestamp"
+	ReasonOutOfOrder       = "out_of_order"
+)
+
+// Batch is the points one driver reported since their last upload, oldest
+// first, and what the driver was doing when reporting them
+type Batch struct {
+	DriverID        string
+	VehicleID       string
+	Status          string
+	CityID          string
+	VehicleFeatures []string
+	Points          []models.Location
+}
+
+// Rejection is a point that was not ingested, by its index in the batch
+type Rejection struct {
+	Index  int    `json:"index"`
+	Reason string `json:"reason"`
+}
+
+// Outcome reports what became of one driver's batch
+type Outcome struct {
+	DriverID string `json:"driver_id"`
+	Accepted int    `json:"accepted"`
+	// Duplicates are points already ingested, by an earlier upload or
+	// earlier in the same batch
+	Duplicates int         `json:"duplicates"`
+	Rejected   []Rejection `json:"rejected,omitempty"`
+	// Error is set when none of the batch could be ingested
+	Error string `json:"error,omitempty"`
+}
+
+// Track is a driver's batch cut down to its accepted points
+type Track struct {
+	Batch
+	// Outcome is the batch's entry in the outcomes returned with the track
+	Outcome *Outcome
+}
+
+// Latest returns the track's most recent point
+func (t *Track) Latest() models.Location {
+	return t.Points[len(t.Points)-1]
+}
+
+// Store keeps each driver's latest position
+type Store interface {
+	// LastSeen returns the time of the stored position of each driver that has one
+	LastSeen(ctx context.Context, driverIDs []string) (map[string]time.Time, error)
+	// Save replaces the drivers' stored positions
+	Save(ctx context.Context, positions []*repository.DriverLocation) error
+}
+
+// GeoIndex keeps the drivers' latest positions searchable by distance
+type GeoIndex interface {
+	Index(ctx context.Context, positions []*repository.DriverLocation) error
+}
+
+// Ingester checks batches of driver locations and writes the latest
+// positions they contain
+type Ingester struct {
+	store Store
+	index GeoIndex
+	now   func() time.Time
+}
+
+// NewIngester creates an ingester saving positions to store and indexing
+// them in index. index may be nil.
+func NewIngester(store Store, index GeoIndex) *Ingester {
+	return &Ingester{store: store, index: index, now: time.Now}
+}
+
+// Prepare validates batches and cleans each against the driver's stored
+// position. It returns the tracks with points left to ingest and an
+// outcome per batch, in request order.
+func (i *Ingester) Prepare(ctx context.Context, batches []Batch) ([]Track, []Outcome, error) {
+	if err := validate(batches); err != nil {
+		return nil, nil, err
+	}
+
+	driverIDs := make([]string, len(batches))
+	for j, batch := range batches {
+		driverIDs[j] = batch.DriverID
+	}
+	lastSeen, err := i.store.LastSeen(ctx, driverIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read stored driver positions: %w", err)
+	}
+
+	now := i.now()
+	outcomes := make([]Outcome, len(batches))
+	var tracks []Track
+	for j, batch := range batches {
+		accepted, outcome := Clean(batch, lastSeen[batch.DriverID], now)
+		outcomes[j] = outcome
+		if len(accepted.Points) > 0 {
+			tracks = append(tracks, Track{Batch: accepted, Outcome: &outcomes[j]})
+		}
+	}
+	return tracks, outcomes, nil
+}
+
+// Commit saves the positions in one bulk write and then indexes them
+func (i *Ingester) Commit(ctx context.Context, positions []*repository.DriverLocation) error {
+	if len(positions) == 0 {
+		return nil
+	}
+	if err := i.store.Save(ctx, positions); err != nil {
+		return fmt.Errorf("failed to save driver positions: %w", err)
+	}
+	if i.index != nil {
+		if err := i.index.Index(ctx, positions); err != nil {
+			return fmt.Errorf("failed to index driver positions: %w", err)
+		}
+	}
+	return nil
+}
+
+func validate(batches []Batch) error {
+	if len(batches) == 0 {
+		return fmt.Errorf("%w: no batches", ErrInvalidBatch)
+	}
+	if len(batches) > MaxDrivers {
+		return fmt.Errorf("%w: %d drivers, at most %d per request", ErrInvalidBatch, len(batches), MaxDrivers)
+	}
+	seen := make(map[string]bool, len(batches))
+	for _, batch := range batches {
+		switch {
+		case batch.DriverID == "":
+			return fmt.Errorf("%w: driver_id is required", ErrInvalidBatch)
+		case seen[batch.DriverID]:
+			return fmt.Errorf("%w: driver %s has more than one batch", ErrInvalidBatch, batch.DriverID)
+		case len(batch.Points) > MaxPointsPerDriver:
+			return fmt.Errorf("%w: driver %s sent %d points, at most %d per batch",
+				ErrInvalidBatch, batch.DriverID, len(batch.Points), MaxPointsPerDriver)
+		}
+		seen[batch.DriverID] = true
+	}
+	return nil
+}
+
+// Clean keeps the points of a batch that move forward in time from after,
+// the time of the driver's stored position. Points at or before it were
+// ingested by an earlier upload and are counted as duplicates, as are exact
+// repeats within the batch; other points that go back in time are rejected.
+func Clean(batch Batch, after, now time.Time) (Batch, Outcome) {
+	outcome := Outcome{DriverID: batch.DriverID}
+	accepted := batch
+	accepted.Points = make([]models.Location, 0, len(batch.Points))
+
+	reject := func(index int, reason string) {
+		outcome.Rejected = append(outcome.Rejected, Rejection{Index: index, Reason: reason})
+	}
+	for index, point := range batch.Points {
+		switch {
+		case point.Timestamp.IsZero():
+			reject(index, ReasonMissingTimestamp)
+			continue
+		case !point.IsValid():
+			reject(index, ReasonInvalidLocation)
+			continue
+		case point.Timestamp.After(now.Add(MaxClockSkew)):
+			reject(index, ReasonFutureTimestamp)
+			continue
+		case !point.Timestamp.After(after):
+			outcome.Duplicates++
+			continue
+		}
+
+		if n := len(accepted.Points); n > 0 {
+			previous := accepted.Points[n-1]
+			if point.Timestamp.Equal(previous.Timestamp) && samePosition(point, previous) {
+				outcome.Duplicates++
+				continue
+			}
+			if !point.Timestamp.After(previous.Timestamp) {
+				reject(index, ReasonOutOfOrder)
+				continue
+			}
+		}
+		accepted.Points = append(accepted.Points, point)
+	}
+	outcome.Accepted = len(accepted.Points)
+	return accepted, outcome
+}
+
+func samePosition(a, b models.Location) bool {
+	return a.Latitude == b.Latitude && a.Longitude == b.Longitude
+}
@@ -0,0 +1,108 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rideshare-platform/services/geo-service/internal/repository"
+	"github.com/rideshare-platform/shared/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var batchStart = time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+
+func point(seconds int, latitude float64) models.Location {
+	return models.Location{
+		Latitude:  latitude,
+		Longitude: -74.0,
+		Timestamp: batchStart.Add(time.Duration(seconds) * time.Second),
+	}
+}
+
+func TestClean_KeepsPointsMovingForwardInTime(t *testing.T) {
+	batch := Batch{DriverID: "driver-1", Points: []models.Location{
+		point(0, 40.000),
+		point(0, 40.000), // repeated
+		point(10, 40.001),
+		point(5, 40.002),  // goes back in time
+		point(10, 40.003), // same time, elsewhere
+		{Latitude: 40.004, Longitude: -74.0},
+		point(20, 95.0),
+		point(3600, 40.005),
+		point(30, 40.006),
+	}}
+
+	accepted, outcome := Clean(batch, time.Time{}, batchStart.Add(time.Minute))
+	assert.Equal(t, []models.Location{point(0, 40.000), point(10, 40.001), point(30, 40.006)}, accepted.Points)
+	assert.Equal(t, 3, outcome.Accepted)
+	assert.Equal(t, 1, outcome.Duplicates)
+	assert.Equal(t, []Rejection{
+		{Index: 3, Reason: ReasonOutOfOrder},
+		{Index: 4, Reason: ReasonOutOfOrder},
+		{Index: 5, Reason: ReasonMissingTimestamp},
+		{Index: 6, Reason: ReasonInvalidLocation},
+		{Index: 7, Reason: ReasonFutureTimestamp},
+	}, outcome.Rejected)
+}
+
+func TestClean_SkipsPointsAlreadyIngested(t *testing.T) {
+	batch := Batch{DriverID: "driver-1", Points: []models.Location{point(0, 40.0), point(10, 40.001), point(20, 40.002)}}
+
+	accepted, outcome := Clean(batch, batchStart.Add(10*time.Second), batchStart.Add(time.Minute))
+	assert.Equal(t, []models.Location{point(20, 40.002)}, accepted.Points)
+	assert.Equal(t, 2, outcome.Duplicates)
+	assert.Empty(t, outcome.Rejected)
+}
+
+type recordingIndex struct {
+	positions []*repository.DriverLocation
+}
+
+func (i *recordingIndex) Index(ctx context.Context, positions []*repository.DriverLocation) error {
+	i.positions = append(i.positions, positions...)
+	return nil
+}
+
+func TestIngester_StoresLatestPositions(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	index := &recordingIndex{}
+	ingester := NewIngester(store, index)
+	ingester.now = func() time.Time { return batchStart.Add(time.Minute) }
+
+	assert.NoError(t, store.Save(ctx, []*repository.DriverLocation{{DriverID: "driver-2", Location: point(20, 41.0)}}))
+
+	tracks, outcomes, err := ingester.Prepare(ctx, []Batch{
+		{DriverID: "driver-1", Points: []models.Location{point(0, 40.0), point(10, 40.001)}},
+		{DriverID: "driver-2", Points: []models.Location{point(10, 41.0), point(20, 41.0)}},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, outcomes, 2)
+	assert.Equal(t, 2, outcomes[0].Accepted)
+	assert.Equal(t, 2, outcomes[1].Duplicates, "driver-2 uploaded these before")
+	assert.Len(t, tracks, 1)
+	assert.Equal(t, point(10, 40.001), tracks[0].Latest())
+
+	latest := tracks[0].Latest()
+	assert.NoError(t, ingester.Commit(ctx, []*repository.DriverLocation{{DriverID: "driver-1", Location: latest}}))
+	position, ok := store.Position("driver-1")
+	assert.True(t, ok)
+	assert.Equal(t, latest, position.Location)
+	assert.Len(t, index.positions, 1)
+}
+
+func TestIngester_RejectsInvalidRequests(t *testing.T) {
+	ingester := NewIngester(NewMemoryStore(), nil)
+	for name, batches := range map[string][]Batch{
+		"empty":            nil,
+		"missing driver":   {{Points: []models.Location{point(0, 40.0)}}},
+		"driver repeated":  {{DriverID: "driver-1"}, {DriverID: "driver-1"}},
+		"too many points":  {{DriverID: "driver-1", Points: make([]models.Location, MaxPointsPerDriver+1)}},
+		"too many drivers": make([]Batch, MaxDrivers+1),
+	} {
+		_, _, err := ingester.Prepare(context.Background(), batches)
+		assert.True(t, errors.Is(err, ErrInvalidBatch), name)
+	}
+}
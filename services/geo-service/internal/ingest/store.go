@@ -0,0 +1,144 @@
+package ingest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/rideshare-platform/services/geo-service/internal/repository"
+)
+
+// PositionsCollection holds one document per driver with their latest position
+const PositionsCollection = "driver_locations"
+
+// GeoIndexKey is the Redis GEO set of online drivers' latest positions
+const GeoIndexKey = "geo:drivers"
+
+// offlineStatus is the status of drivers who are dropped from the GEO index
+const offlineStatus = "offline"
+
+// MongoStore keeps drivers' latest positions in the driver_locations collection
+type MongoStore struct {
+	collection *mongo.Collection
+}
+
+// NewMongoStore creates a store backed by the driver_locations collection
+func NewMongoStore(db *mongo.Database) *MongoStore {
+	return &MongoStore{collection: db.Collection(PositionsCollection)}
+}
+
+// LastSeen returns the time of each driver's stored position
+func (s *MongoStore) LastSeen(ctx context.Context, driverIDs []string) (map[string]time.Time, error) {
+	cursor, err := s.collection.Find(ctx, bson.M{"driver_id": bson.M{"$in": driverIDs}},
+		options.Find().SetProjection(bson.M{"driver_id": 1, "location.timestamp": 1}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read driver positions: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var positions []repository.DriverLocation
+	if err := cursor.All(ctx, &positions); err != nil {
+		return nil, fmt.Errorf("failed to decode driver positions: %w", err)
+	}
+	lastSeen := make(map[string]time.Time, len(positions))
+	for _, position := range positions {
+		lastSeen[position.DriverID] = position.Location.Timestamp
+	}
+	return lastSeen, nil
+}
+
+// Save replaces the drivers' positions in one unordered bulk write
+func (s *MongoStore) Save(ctx context.Context, positions []*repository.DriverLocation) error {
+	writes := make([]mongo.WriteModel, 0, len(positions))
+	for _, position := range positions {
+		writes = append(writes, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"driver_id": position.DriverID}).
+			SetReplacement(position).
+			SetUpsert(true))
+	}
+	if _, err := s.collection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false)); err != nil {
+		return fmt.Errorf("failed to save driver positions: %w", err)
+	}
+	return nil
+}
+
+// RedisGeoIndex keeps online drivers' latest positions in a Redis GEO set
+type RedisGeoIndex struct {
+	client redis.UniversalClient
+}
+
+// NewRedisGeoIndex creates a GEO index in Redis
+func NewRedisGeoIndex(client redis.UniversalClient) *RedisGeoIndex {
+	return &RedisGeoIndex{client: client}
+}
+
+// Index adds online drivers' positions to the GEO set and removes offline
+// drivers, in one pipelined round trip
+func (i *RedisGeoIndex) Index(ctx context.Context, positions []*repository.DriverLocation) error {
+	pipe := i.client.Pipeline()
+	for _, position := range positions {
+		if position.Status == offlineStatus {
+			pipe.ZRem(ctx, GeoIndexKey, position.DriverID)
+			continue
+		}
+		pipe.GeoAdd(ctx, GeoIndexKey, &redis.GeoLocation{
+			Name:      position.DriverID,
+			Longitude: position.Location.Longitude,
+			Latitude:  position.Location.Latitude,
+		})
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to index driver positions: %w", err)
+	}
+	return nil
+}
+
+// MemoryStore keeps drivers' latest positions in memory
+type MemoryStore struct {
+	positions map[string]repository.DriverLocation
+	mutex     sync.RWMutex
+}
+
+// NewMemoryStore creates an empty in-memory position store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{positions: make(map[string]repository.DriverLocation)}
+}
+
+// LastSeen returns the time of each driver's stored position
+func (s *MemoryStore) LastSeen(ctx context.Context, driverIDs []string) (map[string]time.Time, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	lastSeen := make(map[string]time.Time)
+	for _, driverID := range driverIDs {
+		if position, ok := s.positions[driverID]; ok {
+			lastSeen[driverID] = position.Location.Timestamp
+		}
+	}
+	return lastSeen, nil
+}
+
+// Save stores copies of the drivers' positions
+func (s *MemoryStore) Save(ctx context.Context, positions []*repository.DriverLocation) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, position := range positions {
+		s.positions[position.DriverID] = *position
+	}
+	return nil
+}
+
+// Position returns a driver's stored position
+func (s *MemoryStore) Position(driverID string) (repository.DriverLocation, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	position, ok := s.positions[driverID]
+	return position, ok
+}
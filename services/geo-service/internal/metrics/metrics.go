@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// Driver location freshness
+	driverLocationAge = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "geo_service_driver_location_age_seconds",
+			Help:    "Age of the driver locations considered by nearby searches; sum over count is the average freshness",
+			Buckets: []float64{1, 5, 10, 15, 30, 60, 120, 300, 600},
+		},
+	)
+
+	staleDriversExcludedTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "geo_service_stale_drivers_excluded_total",
+			Help: "Total number of drivers left out of nearby searches because their location was too old",
+		},
+	)
+)
+
+// ObserveDriverLocationAge records the age of a driver location a search considered
+func ObserveDriverLocationAge(age time.Duration) {
+	driverLocationAge.Observe(age.Seconds())
+}
+
+// RecordStaleDriverExcluded increments the stale drivers excluded counter
+func RecordStaleDriverExcluded() {
+	staleDriversExcludedTotal.Inc()
+}
@@ -0,0 +1,189 @@
+package pickup
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/rideshare-platform/shared/models"
+)
+
+// Feed carries drivers' locations to the streams following them
+type Feed interface {
+	Publish(ctx context.Context, driverID string, location models.Location) error
+	// Subscribe follows a driver's locations, starting with their latest
+	// one when it is known
+	Subscribe(ctx context.Context, driverID string) (*Subscription, error)
+}
+
+// Subscription receives one driver's locations. It holds only the most
+// recent location not yet taken, so a slow stream skips locations rather
+// than holding up the drivers publishing them.
+type Subscription struct {
+	updates chan models.Location
+	close   func()
+	once    sync.Once
+}
+
+func newSubscription() *Subscription {
+	return &Subscription{updates: make(chan models.Location, 1)}
+}
+
+// Updates delivers the driver's locations
+func (s *Subscription) Updates() <-chan models.Location {
+	return s.updates
+}
+
+// Close stops following the driver
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		if s.close != nil {
+			s.close()
+		}
+	})
+}
+
+// offer hands the subscriber a location, replacing one it has not taken yet
+func (s *Subscription) offer(location models.Location) {
+	for {
+		select {
+		case s.updates <- location:
+			return
+		default:
+		}
+		select {
+		case <-s.updates:
+		default:
+		}
+	}
+}
+
+// MemoryFeed passes locations between streams of one process
+type MemoryFeed struct {
+	latest      map[string]models.Location
+	subscribers map[string]map[*Subscription]struct{}
+	mutex       sync.Mutex
+}
+
+// NewMemoryFeed creates an in-process location feed
+func NewMemoryFeed() *MemoryFeed {
+	return &MemoryFeed{
+		latest:      make(map[string]models.Location),
+		subscribers: make(map[string]map[*Subscription]struct{}),
+	}
+}
+
+// Publish passes the location to the driver's subscribers
+func (f *MemoryFeed) Publish(ctx context.Context, driverID string, location models.Location) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.latest[driverID] = location
+	for subscription := range f.subscribers[driverID] {
+		subscription.offer(location)
+	}
+	return nil
+}
+
+// Subscribe follows a driver's locations
+func (f *MemoryFeed) Subscribe(ctx context.Context, driverID string) (*Subscription, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	subscription := newSubscription()
+	if location, ok := f.latest[driverID]; ok {
+		subscription.offer(location)
+	}
+	if f.subscribers[driverID] == nil {
+		f.subscribers[driverID] = make(map[*Subscription]struct{})
+	}
+	f.subscribers[driverID][subscription] = struct{}{}
+	subscription.close = func() {
+		f.mutex.Lock()
+		defer f.mutex.Unlock()
+		delete(f.subscribers[driverID], subscription)
+		if len(f.subscribers[driverID]) == 0 {
+			delete(f.subscribers, driverID)
+		}
+	}
+	return subscription, nil
+}
+
+// RedisFeed passes locations between geo-service instances over Redis
+// pub/sub, so a stream follows a driver whichever instance their updates
+// reach. Each driver's latest location is kept for ttl to start new streams.
+type RedisFeed struct {
+	client redis.UniversalClient
+	ttl    time.Duration
+}
+
+// NewRedisFeed creates a location feed over Redis pub/sub
+func NewRedisFeed(client redis.UniversalClient, ttl time.Duration) *RedisFeed {
+	return &RedisFeed{client: client, ttl: ttl}
+}
+
+func locationChannel(driverID string) string {
+	return "geo:driver_location:" + driverID
+}
+
+func latestLocationKey(driverID string) string {
+	return "geo:driver_location:latest:" + driverID
+}
+
+// Publish stores the driver's latest location and publishes it to their
+// subscribers in one round trip
+func (f *RedisFeed) Publish(ctx context.Context, driverID string, location models.Location) error {
+	data, err := json.Marshal(location)
+	if err != nil {
+		return fmt.Errorf("failed to encode driver location: %w", err)
+	}
+
+	pipe := f.client.Pipeline()
+	pipe.Set(ctx, latestLocationKey(driverID), data, f.ttl)
+	pipe.Publish(ctx, locationChannel(driverID), data)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to publish driver location: %w", err)
+	}
+	return nil
+}
+
+// Subscribe follows a driver's locations until the subscription is closed
+func (f *RedisFeed) Subscribe(ctx context.Context, driverID string) (*Subscription, error) {
+	pubsub := f.client.Subscribe(ctx, locationChannel(driverID))
+	// Wait for the subscription to be confirmed so no location published
+	// after reading the latest one is missed
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, fmt.Errorf("failed to subscribe to driver locations: %w", err)
+	}
+
+	subscription := newSubscription()
+	subscription.close = func() { pubsub.Close() }
+
+	data, err := f.client.Get(ctx, latestLocationKey(driverID)).Bytes()
+	if err != nil && err != redis.Nil {
+		pubsub.Close()
+		return nil, fmt.Errorf("failed to read latest driver location: %w", err)
+	}
+	if err == nil {
+		var location models.Location
+		if json.Unmarshal(data, &location) == nil {
+			subscription.offer(location)
+		}
+	}
+
+	go func() {
+		for message := range pubsub.Channel() {
+			var location models.Location
+			if err := json.Unmarshal([]byte(message.Payload), &location); err != nil {
+				continue
+			}
+			subscription.offer(location)
+		}
+	}()
+	return subscription, nil
+}
@@ -0,0 +1,206 @@
+// Package pickup streams a driver's ETA to a rider's pickup point while the
+// driver is on their way. The ETA is re-estimated only when the driver has
+// moved far enough to change it, at most once per interval per stream, and
+// the stream ends once the driver reaches the pickup.
+package pickup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/shared/models"
+)
+
+// ErrTooManyStreams is returned when a trip already has as many ETA streams
+// open as it is allowed
+var ErrTooManyStreams = errors.New("too many pickup ETA streams for trip")
+
+// Config tunes how often pickup ETAs are pushed
+type Config struct {
+	// MinMoveMeters is how far the driver must move before the ETA is re-estimated
+	MinMoveMeters float64
+	// MinInterval is the shortest time between two updates on a stream
+	MinInterval time.Duration
+	// ArrivalRadiusMeters is how close to the pickup the driver counts as arrived
+	ArrivalRadiusMeters float64
+	// MaxStreamsPerTrip caps the streams open for one trip, zero for no cap
+	MaxStreamsPerTrip int
+}
+
+// Request asks for the ETA of a trip's driver to its pickup
+type Request struct {
+	TripID      string
+	DriverID    string
+	Pickup      models.Location
+	VehicleType string
+}
+
+// Estimate is how long the driver needs to reach the pickup
+type Estimate struct {
+	DurationSeconds int
+	DistanceMeters  float64
+	Model           string
+}
+
+// Estimator estimates the time to drive from origin to the pickup
+type Estimator func(ctx context.Context, origin, pickup models.Location, vehicleType string) (*Estimate, error)
+
+// Update is one ETA pushed to the rider
+type Update struct {
+	TripID           string
+	DriverID         string
+	DriverLocation   models.Location
+	ETASeconds       int
+	DistanceMeters   float64
+	EstimatedArrival time.Time
+	Model            string
+	// Arrived is set on the last update, once the driver reached the pickup
+	Arrived    bool
+	ComputedAt time.Time
+}
+
+// Tracker serves pickup ETA streams from a feed of driver locations
+type Tracker struct {
+	feed     Feed
+	estimate Estimator
+	config   Config
+
+	streams map[string]int
+	mutex   sync.Mutex
+}
+
+// NewTracker creates a tracker estimating ETAs with estimate as the
+// locations of drivers come in on feed
+func NewTracker(feed Feed, estimate Estimator, config Config) *Tracker {
+	return &Tracker{
+		feed:     feed,
+		estimate: estimate,
+		config:   config,
+		streams:  make(map[string]int),
+	}
+}
+
+// Publish passes a driver's new location on to the streams following them
+func (t *Tracker) Publish(ctx context.Context, driverID string, location models.Location) error {
+	return t.feed.Publish(ctx, driverID, location)
+}
+
+// Stream sends pickup ETAs for the request's driver until they reach the
+// pickup, send fails or ctx is done. The first update is sent as soon as the
+// driver's location is known.
+func (t *Tracker) Stream(ctx context.Context, req Request, send func(*Update) error) error {
+	if req.TripID == "" || req.DriverID == "" {
+		return fmt.Errorf("trip ID and driver ID are required")
+	}
+	if !req.Pickup.IsValid() {
+		return fmt.Errorf("pickup has invalid coordinates")
+	}
+
+	release, err := t.acquire(req.TripID)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	subscription, err := t.feed.Subscribe(ctx, req.DriverID)
+	if err != nil {
+		return fmt.Errorf("failed to follow driver location: %w", err)
+	}
+	defer subscription.Close()
+
+	var (
+		last     *Update
+		pending  *models.Location
+		lastSent time.Time
+		wait     <-chan time.Time
+	)
+	for {
+		if pending != nil && wait == nil {
+			if delay := t.config.MinInterval - time.Since(lastSent); last != nil && delay > 0 {
+				wait = time.After(delay)
+			} else {
+				update, err := t.update(ctx, req, *pending)
+				if err != nil {
+					return err
+				}
+				if err := send(update); err != nil {
+					return err
+				}
+				if update.Arrived {
+					return nil
+				}
+				last, pending, lastSent = update, nil, time.Now()
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-wait:
+			wait = nil
+		case location := <-subscription.Updates():
+			if t.changed(req, last, location) {
+				pending = &location
+			}
+		}
+	}
+}
+
+// changed reports whether a new location warrants a new ETA: the first
+// location, one far enough from the last estimate's, or one at the pickup
+func (t *Tracker) changed(req Request, last *Update, location models.Location) bool {
+	if last == nil || t.arrived(req, location) {
+		return true
+	}
+	return last.DriverLocation.DistanceTo(&location)*1000 >= t.config.MinMoveMeters
+}
+
+func (t *Tracker) arrived(req Request, location models.Location) bool {
+	return req.Pickup.DistanceTo(&location)*1000 <= t.config.ArrivalRadiusMeters
+}
+
+func (t *Tracker) update(ctx context.Context, req Request, location models.Location) (*Update, error) {
+	now := time.Now()
+	update := &Update{
+		TripID:         req.TripID,
+		DriverID:       req.DriverID,
+		DriverLocation: location,
+		ComputedAt:     now,
+	}
+	if t.arrived(req, location) {
+		update.Arrived = true
+		update.EstimatedArrival = now
+		return update, nil
+	}
+
+	estimate, err := t.estimate(ctx, location, req.Pickup, req.VehicleType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate pickup ETA: %w", err)
+	}
+	update.ETASeconds = estimate.DurationSeconds
+	update.DistanceMeters = estimate.DistanceMeters
+	update.Model = estimate.Model
+	update.EstimatedArrival = now.Add(time.Duration(estimate.DurationSeconds) * time.Second)
+	return update, nil
+}
+
+// acquire counts a new stream for the trip, refusing it over the cap
+func (t *Tracker) acquire(tripID string) (func(), error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.config.MaxStreamsPerTrip > 0 && t.streams[tripID] >= t.config.MaxStreamsPerTrip {
+		return nil, fmt.Errorf("%w: %s has %d open", ErrTooManyStreams, tripID, t.streams[tripID])
+	}
+	t.streams[tripID]++
+	return func() {
+		t.mutex.Lock()
+		defer t.mutex.Unlock()
+		if t.streams[tripID]--; t.streams[tripID] <= 0 {
+			delete(t.streams, tripID)
+		}
+	}, nil
+}
@@ -0,0 +1,106 @@
+package pickup
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rideshare-platform/shared/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var pickupPoint = models.Location{Latitude: 40.0, Longitude: -74.0}
+
+// straightLine estimates a minute per kilometre
+func straightLine(ctx context.Context, origin, pickup models.Location, vehicleType string) (*Estimate, error) {
+	km := origin.DistanceTo(&pickup)
+	return &Estimate{DurationSeconds: int(km * 60), DistanceMeters: km * 1000, Model: "test"}, nil
+}
+
+func newTestTracker(feed Feed, maxStreams int) *Tracker {
+	return NewTracker(feed, straightLine, Config{
+		MinMoveMeters:       50,
+		ArrivalRadiusMeters: 30,
+		MaxStreamsPerTrip:   maxStreams,
+	})
+}
+
+// north is a location the given number of meters north of the pickup
+func north(meters float64) models.Location {
+	return models.Location{Latitude: pickupPoint.Latitude + meters/111195, Longitude: pickupPoint.Longitude}
+}
+
+func TestTracker_StreamsUntilArrival(t *testing.T) {
+	feed := NewMemoryFeed()
+	tracker := newTestTracker(feed, 0)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	assert.NoError(t, feed.Publish(ctx, "driver-1", north(2000)))
+
+	updates := make(chan *Update, 10)
+	done := make(chan error, 1)
+	go func() {
+		done <- tracker.Stream(ctx, Request{TripID: "trip-1", DriverID: "driver-1", Pickup: pickupPoint}, func(update *Update) error {
+			updates <- update
+			return nil
+		})
+	}()
+
+	first := <-updates
+	assert.InDelta(t, 120, first.ETASeconds, 1, "starts from the driver's latest location")
+	assert.False(t, first.Arrived)
+
+	// Moving less than the threshold does not change the ETA
+	assert.NoError(t, feed.Publish(ctx, "driver-1", north(1980)))
+	assert.NoError(t, feed.Publish(ctx, "driver-1", north(1000)))
+	second := <-updates
+	assert.InDelta(t, 60, second.ETASeconds, 1)
+
+	assert.NoError(t, feed.Publish(ctx, "driver-1", north(10)))
+	last := <-updates
+	assert.True(t, last.Arrived)
+	assert.NoError(t, <-done, "the stream ends at the pickup")
+	assert.Empty(t, updates)
+}
+
+func TestTracker_CapsStreamsPerTrip(t *testing.T) {
+	tracker := newTestTracker(NewMemoryFeed(), 1)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error, 1)
+	started := make(chan struct{})
+	go func() {
+		close(started)
+		done <- tracker.Stream(ctx, Request{TripID: "trip-1", DriverID: "driver-1", Pickup: pickupPoint}, func(*Update) error { return nil })
+	}()
+	<-started
+	assert.Eventually(t, func() bool {
+		tracker.mutex.Lock()
+		defer tracker.mutex.Unlock()
+		return tracker.streams["trip-1"] == 1
+	}, time.Second, 5*time.Millisecond)
+
+	err := tracker.Stream(ctx, Request{TripID: "trip-1", DriverID: "driver-1", Pickup: pickupPoint}, func(*Update) error { return nil })
+	assert.True(t, errors.Is(err, ErrTooManyStreams))
+
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+	tracker.mutex.Lock()
+	assert.Empty(t, tracker.streams, "closed streams are no longer counted")
+	tracker.mutex.Unlock()
+}
+
+func TestSubscription_KeepsOnlyLatestLocation(t *testing.T) {
+	feed := NewMemoryFeed()
+	subscription, err := feed.Subscribe(context.Background(), "driver-1")
+	assert.NoError(t, err)
+	defer subscription.Close()
+
+	for _, meters := range []float64{300, 200, 100} {
+		assert.NoError(t, feed.Publish(context.Background(), "driver-1", north(meters)))
+	}
+	assert.Equal(t, north(100), <-subscription.Updates())
+	assert.Empty(t, subscription.Updates())
+}
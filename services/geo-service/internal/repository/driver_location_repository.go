@@ -16,9 +16,23 @@ type DriverLocation struct {
 	Location    models.Location `json:"location" bson:"location"`
 	Status      string          `json:"status" bson:"status"`
 	VehicleType string          `json:"vehicle_type" bson:"vehicle_type"`
-	Rating      float64         `json:"rating" bson:"rating"`
-	UpdatedAt   time.Time       `json:"updated_at" bson:"updated_at"`
-	ExpiresAt   time.Time       `json:"expires_at" bson:"expires_at"`
+	// VehicleFeatures are the features of the vehicle being driven, such as
+	// wheelchair_accessible, used to match riders' accessibility needs
+	VehicleFeatures []string  `json:"vehicle_features,omitempty" bson:"vehicle_features,omitempty"`
+	Rating          float64   `json:"rating" bson:"rating"`
+	CityID          string    `json:"city_id,omitempty" bson:"city_id,omitempty"`
+	UpdatedAt       time.Time `json:"updated_at" bson:"updated_at"`
+	ExpiresAt       time.Time `json:"expires_at" bson:"expires_at"`
+}
+
+// LastSeen is when the driver was last known to be at the location: when it
+// was recorded, or when it was stored if the recording time is missing or
+// claims to be later
+func (d *DriverLocation) LastSeen() time.Time {
+	if recordedAt := d.Location.Timestamp; !recordedAt.IsZero() && recordedAt.Before(d.UpdatedAt) {
+		return recordedAt
+	}
+	return d.UpdatedAt
 }
 
 // DriverLocationRepository handles driver location data in MongoDB
@@ -56,7 +70,7 @@ func (r *DriverLocationRepository) UpdateDriverLocation(ctx context.Context, dri
 }
 
 // FindNearbyDrivers finds drivers within a specified radius
-func (r *DriverLocationRepository) FindNearbyDrivers(ctx context.Context, center models.Location, radiusKm float64, vehicleTypes []string, onlyAvailable bool) ([]DriverLocation, error) {
+func (r *DriverLocationRepository) FindNearbyDrivers(ctx context.Context, center models.Location, radiusKm float64, cityID string, vehicleTypes []string, onlyAvailable bool) ([]DriverLocation, error) {
 	// In a real implementation, this would use MongoDB geospatial queries
 	// For now, we'll return mock data
 
@@ -68,6 +82,7 @@ func (r *DriverLocationRepository) FindNearbyDrivers(ctx context.Context, center
 			Status:      "online",
 			VehicleType: "sedan",
 			Rating:      4.8,
+			CityID:      cityID,
 			UpdatedAt:   time.Now(),
 		},
 		{
@@ -77,6 +92,7 @@ func (r *DriverLocationRepository) FindNearbyDrivers(ctx context.Context, center
 			Status:      "online",
 			VehicleType: "suv",
 			Rating:      4.6,
+			CityID:      cityID,
 			UpdatedAt:   time.Now(),
 		},
 	}
@@ -85,6 +101,7 @@ func (r *DriverLocationRepository) FindNearbyDrivers(ctx context.Context, center
 		"center_lat":     center.Latitude,
 		"center_lng":     center.Longitude,
 		"radius_km":      radiusKm,
+		"city_id":        cityID,
 		"drivers_found":  len(mockDrivers),
 		"vehicle_types":  vehicleTypes,
 		"only_available": onlyAvailable,
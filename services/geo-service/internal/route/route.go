@@ -0,0 +1,122 @@
+// Package route records the locations drivers report during a trip and
+// derives the distance actually travelled from them, optionally cleaning up
+// GPS noise before measuring.
+package route
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rideshare-platform/shared/models"
+)
+
+// ErrNoRoute is returned when no points were recorded for a trip
+var ErrNoRoute = errors.New("no route recorded for trip")
+
+// Point is one location reported by the driver during a trip
+type Point struct {
+	TripID     string          `bson:"trip_id"`
+	DriverID   string          `bson:"driver_id"`
+	Location   models.Location `bson:"location"`
+	RecordedAt time.Time       `bson:"recorded_at"`
+}
+
+// Summary is a trip's recorded route and the distance and time it covers
+type Summary struct {
+	TripID          string            `json:"trip_id"`
+	Points          []models.Location `json:"points"`
+	DistanceKm      float64           `json:"distance_km"`
+	DurationSeconds int               `json:"duration_seconds"`
+	// RawPoints is how many points were recorded before matching dropped any
+	RawPoints int  `json:"raw_points"`
+	Matched   bool `json:"matched"`
+}
+
+// Matcher cleans up a recorded route before its distance is measured, for
+// example by dropping GPS outliers or snapping points to roads
+type Matcher interface {
+	Match(points []Point) []Point
+}
+
+// Recorder keeps per-trip route logs and summarizes them
+type Recorder struct {
+	store   Store
+	matcher Matcher
+}
+
+// NewRecorder creates a route recorder. matcher may be nil, routes are then
+// always measured as recorded.
+func NewRecorder(store Store, matcher Matcher) *Recorder {
+	return &Recorder{store: store, matcher: matcher}
+}
+
+// Record appends a driver location to the trip's route
+func (r *Recorder) Record(ctx context.Context, tripID, driverID string, location models.Location) error {
+	if tripID == "" {
+		return fmt.Errorf("trip ID is required")
+	}
+	recordedAt := location.Timestamp
+	if recordedAt.IsZero() {
+		recordedAt = time.Now()
+	}
+	return r.store.Record(ctx, &Point{
+		TripID:     tripID,
+		DriverID:   driverID,
+		Location:   location,
+		RecordedAt: recordedAt,
+	})
+}
+
+// RecordMany appends a driver's locations to the trip's route in one write.
+// Locations must carry the time they were reported.
+func (r *Recorder) RecordMany(ctx context.Context, tripID, driverID string, locations []models.Location) error {
+	if tripID == "" {
+		return fmt.Errorf("trip ID is required")
+	}
+	points := make([]Point, len(locations))
+	for i, location := range locations {
+		points[i] = Point{
+			TripID:     tripID,
+			DriverID:   driverID,
+			Location:   location,
+			RecordedAt: location.Timestamp,
+		}
+	}
+	return r.store.RecordMany(ctx, points)
+}
+
+// Delete erases the routes recorded during the given trips and returns how
+// many points were deleted
+func (r *Recorder) Delete(ctx context.Context, tripIDs []string) (int64, error) {
+	return r.store.Delete(ctx, tripIDs)
+}
+
+// Route summarizes the trip's recorded route. With match set and a matcher
+// configured, the points are cleaned up before the distance is measured.
+func (r *Recorder) Route(ctx context.Context, tripID string, match bool) (*Summary, error) {
+	points, err := r.store.Points(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+	if len(points) == 0 {
+		return nil, ErrNoRoute
+	}
+
+	summary := &Summary{TripID: tripID, RawPoints: len(points)}
+	if match && r.matcher != nil {
+		points = r.matcher.Match(points)
+		summary.Matched = true
+	}
+
+	summary.Points = make([]models.Location, 0, len(points))
+	for i := range points {
+		summary.Points = append(summary.Points, points[i].Location)
+		if i > 0 {
+			summary.DistanceKm += points[i-1].Location.DistanceTo(&points[i].Location)
+		}
+	}
+	summary.DurationSeconds = int(points[len(points)-1].RecordedAt.Sub(points[0].RecordedAt).Seconds())
+	return summary, nil
+}
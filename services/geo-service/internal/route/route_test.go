@@ -0,0 +1,89 @@
+package route
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rideshare-platform/shared/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var routeStart = time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+
+// recordStraightLine records points 0.001 degrees of latitude (about 111m) apart, ten seconds apart
+func recordStraightLine(t *testing.T, recorder *Recorder, tripID string, count int) {
+	for i := 0; i < count; i++ {
+		err := recorder.Record(context.Background(), tripID, "driver-1", models.Location{
+			Latitude:  40.0 + float64(i)*0.001,
+			Longitude: -74.0,
+			Accuracy:  5,
+			Timestamp: routeStart.Add(time.Duration(i) * 10 * time.Second),
+		})
+		assert.NoError(t, err)
+	}
+}
+
+func TestRecorder_MeasuresRecordedRoute(t *testing.T) {
+	recorder := NewRecorder(NewMemoryStore(), nil)
+	recordStraightLine(t, recorder, "trip-1", 11)
+
+	summary, err := recorder.Route(context.Background(), "trip-1", true)
+	assert.NoError(t, err)
+	assert.Len(t, summary.Points, 11)
+	assert.InDelta(t, 1.112, summary.DistanceKm, 0.01)
+	assert.Equal(t, 100, summary.DurationSeconds)
+	assert.False(t, summary.Matched, "no matcher configured")
+
+	_, err = recorder.Route(context.Background(), "trip-unknown", false)
+	assert.True(t, errors.Is(err, ErrNoRoute))
+}
+
+func TestRecorder_DeletesRoutes(t *testing.T) {
+	recorder := NewRecorder(NewMemoryStore(), nil)
+	recordStraightLine(t, recorder, "trip-1", 3)
+	recordStraightLine(t, recorder, "trip-2", 2)
+	recordStraightLine(t, recorder, "trip-3", 4)
+
+	deleted, err := recorder.Delete(context.Background(), []string{"trip-1", "trip-2", "trip-unknown"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), deleted)
+
+	_, err = recorder.Route(context.Background(), "trip-1", false)
+	assert.True(t, errors.Is(err, ErrNoRoute))
+	summary, err := recorder.Route(context.Background(), "trip-3", false)
+	assert.NoError(t, err)
+	assert.Len(t, summary.Points, 4)
+}
+
+func TestSmoothingMatcher_DropsOutliersAndJitter(t *testing.T) {
+	store := NewMemoryStore()
+	recorder := NewRecorder(store, NewSmoothingMatcher(50, 160))
+	recordStraightLine(t, recorder, "trip-2", 11)
+	ctx := context.Background()
+
+	// A fix 5km off the road, ten seconds after the previous one
+	assert.NoError(t, store.Record(ctx, &Point{
+		TripID:     "trip-2",
+		Location:   models.Location{Latitude: 40.0055, Longitude: -73.94, Accuracy: 5},
+		RecordedAt: routeStart.Add(55 * time.Second),
+	}))
+	// A low accuracy fix
+	assert.NoError(t, store.Record(ctx, &Point{
+		TripID:     "trip-2",
+		Location:   models.Location{Latitude: 40.0075, Longitude: -73.99, Accuracy: 400},
+		RecordedAt: routeStart.Add(75 * time.Second),
+	}))
+
+	raw, err := recorder.Route(ctx, "trip-2", false)
+	assert.NoError(t, err)
+	assert.Greater(t, raw.DistanceKm, 10.0)
+
+	matched, err := recorder.Route(ctx, "trip-2", true)
+	assert.NoError(t, err)
+	assert.True(t, matched.Matched)
+	assert.Equal(t, 13, matched.RawPoints)
+	assert.Len(t, matched.Points, 11)
+	assert.InDelta(t, 1.112, matched.DistanceKm, 0.01)
+}
@@ -0,0 +1,52 @@
+package route
+
+// SmoothingMatcher removes GPS noise without a road network: it drops points
+// reported with poor accuracy or implying an impossible jump from the previous
+// point, then averages each remaining point with its neighbours so zig-zag
+// jitter does not add distance. The first and last points are kept as reported.
+type SmoothingMatcher struct {
+	maxAccuracyMeters float64
+	maxSpeedKmh       float64
+}
+
+// NewSmoothingMatcher creates a smoothing matcher. Points reported with an
+// accuracy worse than maxAccuracyMeters, or reached faster than maxSpeedKmh,
+// are dropped. Points without a reported accuracy are kept.
+func NewSmoothingMatcher(maxAccuracyMeters, maxSpeedKmh float64) *SmoothingMatcher {
+	return &SmoothingMatcher{maxAccuracyMeters: maxAccuracyMeters, maxSpeedKmh: maxSpeedKmh}
+}
+
+// Match returns the cleaned-up points
+func (m *SmoothingMatcher) Match(points []Point) []Point {
+	kept := m.dropOutliers(points)
+	if len(kept) < 3 {
+		return kept
+	}
+
+	smoothed := make([]Point, len(kept))
+	copy(smoothed, kept)
+	for i := 1; i < len(kept)-1; i++ {
+		smoothed[i].Location.Latitude = (kept[i-1].Location.Latitude + kept[i].Location.Latitude + kept[i+1].Location.Latitude) / 3
+		smoothed[i].Location.Longitude = (kept[i-1].Location.Longitude + kept[i].Location.Longitude + kept[i+1].Location.Longitude) / 3
+	}
+	return smoothed
+}
+
+func (m *SmoothingMatcher) dropOutliers(points []Point) []Point {
+	kept := make([]Point, 0, len(points))
+	for _, point := range points {
+		if m.maxAccuracyMeters > 0 && point.Location.Accuracy > m.maxAccuracyMeters {
+			continue
+		}
+		if len(kept) > 0 && m.maxSpeedKmh > 0 {
+			previous := kept[len(kept)-1]
+			hours := point.RecordedAt.Sub(previous.RecordedAt).Hours()
+			distanceKm := previous.Location.DistanceTo(&point.Location)
+			if (hours <= 0 && distanceKm > 0) || (hours > 0 && distanceKm/hours > m.maxSpeedKmh) {
+				continue
+			}
+		}
+		kept = append(kept, point)
+	}
+	return kept
+}
@@ -0,0 +1,140 @@
+package route
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// PointsCollection is a time series collection of route points, with the trip
+// ID as its meta field
+const PointsCollection = "trip_routes"
+
+// Store appends route points and reads a trip's points back in time order
+type Store interface {
+	Record(ctx context.Context, point *Point) error
+	// RecordMany stores points in one write
+	RecordMany(ctx context.Context, points []Point) error
+	Points(ctx context.Context, tripID string) ([]Point, error)
+	// Delete erases the points of the given trips and returns how many were deleted
+	Delete(ctx context.Context, tripIDs []string) (int64, error)
+}
+
+// MongoStore keeps route points in a MongoDB time series collection
+type MongoStore struct {
+	collection *mongo.Collection
+}
+
+// NewMongoStore creates a store backed by the trip_routes collection
+func NewMongoStore(db *mongo.Database) *MongoStore {
+	return &MongoStore{collection: db.Collection(PointsCollection)}
+}
+
+// Record stores a route point
+func (s *MongoStore) Record(ctx context.Context, point *Point) error {
+	if _, err := s.collection.InsertOne(ctx, point); err != nil {
+		return fmt.Errorf("failed to record route point: %w", err)
+	}
+	return nil
+}
+
+// RecordMany stores route points in one insert
+func (s *MongoStore) RecordMany(ctx context.Context, points []Point) error {
+	if len(points) == 0 {
+		return nil
+	}
+	documents := make([]interface{}, len(points))
+	for i := range points {
+		documents[i] = points[i]
+	}
+	if _, err := s.collection.InsertMany(ctx, documents); err != nil {
+		return fmt.Errorf("failed to record route points: %w", err)
+	}
+	return nil
+}
+
+// Points returns the trip's points ordered by the time they were recorded
+func (s *MongoStore) Points(ctx context.Context, tripID string) ([]Point, error) {
+	cursor, err := s.collection.Find(ctx, bson.M{"trip_id": tripID},
+		options.Find().SetSort(bson.D{{Key: "recorded_at", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read route: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var points []Point
+	if err := cursor.All(ctx, &points); err != nil {
+		return nil, fmt.Errorf("failed to decode route: %w", err)
+	}
+	return points, nil
+}
+
+// Delete erases the trips' points. Deletes on a time series collection can
+// only filter on its meta field, the trip ID.
+func (s *MongoStore) Delete(ctx context.Context, tripIDs []string) (int64, error) {
+	if len(tripIDs) == 0 {
+		return 0, nil
+	}
+	result, err := s.collection.DeleteMany(ctx, bson.M{"trip_id": bson.M{"$in": tripIDs}})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete routes: %w", err)
+	}
+	return result.DeletedCount, nil
+}
+
+// MemoryStore keeps route points in memory
+type MemoryStore struct {
+	points map[string][]Point
+	mutex  sync.RWMutex
+}
+
+// NewMemoryStore creates a new in-memory route store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{points: make(map[string][]Point)}
+}
+
+// Record stores a copy of a route point
+func (s *MemoryStore) Record(ctx context.Context, point *Point) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.points[point.TripID] = append(s.points[point.TripID], *point)
+	return nil
+}
+
+// RecordMany stores copies of route points
+func (s *MemoryStore) RecordMany(ctx context.Context, points []Point) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, point := range points {
+		s.points[point.TripID] = append(s.points[point.TripID], point)
+	}
+	return nil
+}
+
+// Points returns the trip's points ordered by the time they were recorded
+func (s *MemoryStore) Points(ctx context.Context, tripID string) ([]Point, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	points := append([]Point(nil), s.points[tripID]...)
+	sort.SliceStable(points, func(i, j int) bool { return points[i].RecordedAt.Before(points[j].RecordedAt) })
+	return points, nil
+}
+
+// Delete erases the trips' points
+func (s *MemoryStore) Delete(ctx context.Context, tripIDs []string) (int64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var deleted int64
+	for _, tripID := range tripIDs {
+		deleted += int64(len(s.points[tripID]))
+		delete(s.points, tripID)
+	}
+	return deleted, nil
+}
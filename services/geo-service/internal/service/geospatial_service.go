@@ -8,11 +8,20 @@ import (
 	"sort"
 	"time"
 
-	"github.com/go-redis/redis/v8"
+	"github.com/redis/go-redis/v9"
 	"go.mongodb.org/mongo-driver/mongo"
 
 	"github.com/rideshare-platform/services/geo-service/internal/config"
+	"github.com/rideshare-platform/services/geo-service/internal/driverstate"
+	"github.com/rideshare-platform/services/geo-service/internal/eta"
+	"github.com/rideshare-platform/services/geo-service/internal/geofence"
+	"github.com/rideshare-platform/services/geo-service/internal/ingest"
+	"github.com/rideshare-platform/services/geo-service/internal/metrics"
+	"github.com/rideshare-platform/services/geo-service/internal/pickup"
 	"github.com/rideshare-platform/services/geo-service/internal/repository"
+	"github.com/rideshare-platform/services/geo-service/internal/route"
+	"github.com/rideshare-platform/shared/city"
+	"github.com/rideshare-platform/shared/events"
 	"github.com/rideshare-platform/shared/logger"
 	"github.com/rideshare-platform/shared/models"
 )
@@ -24,9 +33,33 @@ type GeospatialService struct {
 	driverRepo *repository.DriverLocationRepository
 	cacheRepo  *repository.CacheRepository
 	mongo      *mongo.Client
-	redis      *redis.Client
+	redis      redis.UniversalClient
+
+	driverStates *driverstate.Manager
+
+	etaModels    *eta.Selector
+	trafficModel *eta.TrafficModel
+	observations eta.ObservationStore
+
+	routes *route.Recorder
+
+	cities *city.Registry
+
+	ingester *ingest.Ingester
+
+	pickups *pickup.Tracker
+
+	queues *geofence.Queues
 }
 
+// ErrPickupETAUnavailable is returned for pickup ETA streams when no location
+// feed is configured
+var ErrPickupETAUnavailable = errors.New("pickup ETA streams are not configured")
+
+// ErrIngestUnavailable is returned for batch location uploads when no
+// ingester is configured
+var ErrIngestUnavailable = errors.New("batch location ingestion is not configured")
+
 // NewGeospatialService creates a new geospatial service
 func NewGeospatialService(
 	cfg *config.Config,
@@ -34,8 +67,16 @@ func NewGeospatialService(
 	driverRepo *repository.DriverLocationRepository,
 	cacheRepo *repository.CacheRepository,
 	mongo *mongo.Client,
-	redis *redis.Client,
+	redis redis.UniversalClient,
 ) *GeospatialService {
+	routes := cfg.Geospatial.RouteOptimization
+	simpleModel := eta.NewSimpleModel(routes.DefaultSpeeds, routes.TrafficFactors)
+	trafficModel := eta.NewTrafficModel(routes.DefaultSpeeds, simpleModel, cfg.ETA.MinSamples, time.Duration(cfg.ETA.LookbackDays)*24*time.Hour)
+	etaModels := map[string]eta.Model{
+		eta.ModelSimple:  simpleModel,
+		eta.ModelTraffic: trafficModel,
+	}
+
 	return &GeospatialService{
 		config:     cfg,
 		logger:     log,
@@ -43,9 +84,165 @@ func NewGeospatialService(
 		cacheRepo:  cacheRepo,
 		mongo:      mongo,
 		redis:      redis,
+
+		etaModels:    eta.NewSelector(etaModels[cfg.ETA.Model], etaModels[cfg.ETA.ExperimentModel], cfg.ETA.ExperimentPercent),
+		trafficModel: trafficModel,
 	}
 }
 
+// SetObservationStore sets where completed trip speeds are recorded and read
+// back by the traffic ETA model
+func (s *GeospatialService) SetObservationStore(observations eta.ObservationStore) {
+	s.observations = observations
+	s.trafficModel.SetObservations(observations)
+}
+
+// SetDriverStates makes driver availability come from the driver state machine:
+// location updates count as heartbeats and only online drivers are available
+func (s *GeospatialService) SetDriverStates(driverStates *driverstate.Manager) {
+	s.driverStates = driverStates
+}
+
+// SetCities scopes driver locations and searches to the configured cities,
+// and caps search radii by each city's policy
+func (s *GeospatialService) SetCities(cities *city.Registry) {
+	s.cities = cities
+}
+
+// SetRouteRecorder makes location updates from drivers on a trip part of the
+// trip's recorded route
+func (s *GeospatialService) SetRouteRecorder(routes *route.Recorder) {
+	s.routes = routes
+}
+
+// SetZoneQueues sets the dispatch zone queues drivers join and leave as
+// their locations are reported
+func (s *GeospatialService) SetZoneQueues(queues *geofence.Queues) {
+	s.queues = queues
+}
+
+// SetLocationIngester enables batch location uploads through ingester
+func (s *GeospatialService) SetLocationIngester(ingester *ingest.Ingester) {
+	s.ingester = ingester
+}
+
+// SetPickupFeed enables pickup ETA streams, following drivers' locations
+// through feed. Every location update is published to it.
+func (s *GeospatialService) SetPickupFeed(feed pickup.Feed) {
+	config := s.config.PickupETA
+	s.pickups = pickup.NewTracker(feed, s.estimatePickup, pickup.Config{
+		MinMoveMeters:       config.MinMoveMeters,
+		MinInterval:         time.Duration(config.MinIntervalSeconds) * time.Second,
+		ArrivalRadiusMeters: config.ArrivalRadiusMeters,
+		MaxStreamsPerTrip:   config.MaxStreamsPerTrip,
+	})
+}
+
+// StreamPickupETA sends the ETA of a trip's driver to its pickup as the
+// driver moves, until they arrive or ctx is done
+func (s *GeospatialService) StreamPickupETA(ctx context.Context, req pickup.Request, send func(*pickup.Update) error) error {
+	if s.pickups == nil {
+		return ErrPickupETAUnavailable
+	}
+	return s.pickups.Stream(ctx, req, send)
+}
+
+// estimatePickup estimates the drive to a pickup with the ETA model serving
+// ETA requests, traffic included
+func (s *GeospatialService) estimatePickup(ctx context.Context, origin, destination models.Location, vehicleType string) (*pickup.Estimate, error) {
+	if vehicleType == "" {
+		vehicleType = "car"
+	}
+	eta, err := s.CalculateETA(ctx, origin, destination, vehicleType, time.Now(), true)
+	if err != nil {
+		return nil, err
+	}
+	return &pickup.Estimate{
+		DurationSeconds: eta.DurationSeconds,
+		DistanceMeters:  eta.DistanceMeters,
+		Model:           eta.Model,
+	}, nil
+}
+
+// publishLocation passes a driver's new location on to the pickup ETA
+// streams following them. A failed publish does not fail the update.
+func (s *GeospatialService) publishLocation(ctx context.Context, driverID string, location models.Location) {
+	if s.pickups == nil {
+		return
+	}
+	if err := s.pickups.Publish(ctx, driverID, location); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("driver_id", driverID).Warn("Failed to publish driver location")
+	}
+}
+
+// TripRoute returns the route recorded for a trip, cleaned up by the
+// configured matcher when match is set
+func (s *GeospatialService) TripRoute(ctx context.Context, tripID string, match bool) (*route.Summary, error) {
+	if s.routes == nil {
+		return nil, route.ErrNoRoute
+	}
+	return s.routes.Route(ctx, tripID, match)
+}
+
+// DeleteTripRoutes erases the routes recorded during the given trips, for
+// data subject erasure requests
+func (s *GeospatialService) DeleteTripRoutes(ctx context.Context, tripIDs []string) (int64, error) {
+	if s.routes == nil {
+		return 0, nil
+	}
+	return s.routes.Delete(ctx, tripIDs)
+}
+
+// RecordTripSpeed stores a completed trip's average speed for the traffic ETA
+// model, keyed by the area and hour the trip started in
+func (s *GeospatialService) RecordTripSpeed(ctx context.Context, tripID, vehicleType string, pickup models.Location, distanceKm float64, startedAt, completedAt time.Time) error {
+	if s.observations == nil {
+		return nil
+	}
+	observation, err := eta.NewObservation(tripID, s.area(pickup), vehicleType, distanceKm, startedAt, completedAt)
+	if err != nil {
+		return err
+	}
+	return s.observations.Record(ctx, observation)
+}
+
+// SubscribeTripEvents records the speed of completed trips whose events carry
+// pickup, distance and timing telemetry. Events without it are ignored.
+func (s *GeospatialService) SubscribeTripEvents(bus events.EventBus) error {
+	return bus.Subscribe(events.TripCompletedEvent, func(ctx context.Context, event *events.Event) error {
+		lat, okLat := event.Data["pickup_latitude"].(float64)
+		lng, okLng := event.Data["pickup_longitude"].(float64)
+		distanceKm, okDistance := event.Data["distance_km"].(float64)
+		startedAt, okStarted := eventTime(event.Data["started_at"])
+		if !okLat || !okLng || !okDistance || !okStarted {
+			return nil
+		}
+		vehicleType, _ := event.Data["vehicle_type"].(string)
+		if vehicleType == "" {
+			vehicleType = "car"
+		}
+
+		pickup := models.Location{Latitude: lat, Longitude: lng}
+		if err := s.RecordTripSpeed(ctx, event.AggregateID, vehicleType, pickup, distanceKm, startedAt, event.Timestamp); err != nil {
+			s.logger.WithContext(ctx).WithError(err).WithField("trip_id", event.AggregateID).Warn("Failed to record trip speed")
+		}
+		return nil
+	})
+}
+
+// eventTime reads a timestamp from event data, which holds time.Time values
+// in process and RFC 3339 strings once serialized
+func eventTime(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, !v.IsZero()
+	case string:
+		parsed, err := time.Parse(time.RFC3339, v)
+		return parsed, err == nil
+	}
+	return time.Time{}, false
+}
+
 // DistanceCalculation represents the result of a distance calculation
 type DistanceCalculation struct {
 	DistanceMeters    float64 `json:"distance_meters"`
@@ -61,6 +258,7 @@ type ETACalculation struct {
 	RouteSummary     string            `json:"route_summary"`
 	Waypoints        []models.Location `json:"waypoints"`
 	EstimatedArrival time.Time         `json:"estimated_arrival"`
+	Model            string            `json:"model"`
 }
 
 // NearbyDriver represents a driver with location and distance information
@@ -71,7 +269,10 @@ type NearbyDriver struct {
 	DistanceFromCenter float64         `json:"distance_from_center"`
 	Status             string          `json:"status"`
 	VehicleType        string          `json:"vehicle_type"`
+	VehicleFeatures    []string        `json:"vehicle_features,omitempty"`
 	Rating             float64         `json:"rating"`
+	CityID             string          `json:"city_id,omitempty"`
+	LastSeenAt         time.Time       `json:"last_seen_at"`
 }
 
 // CalculateDistance calculates the distance between two geographical points
@@ -134,23 +335,25 @@ func (s *GeospatialService) CalculateETA(ctx context.Context, origin, destinatio
 		return nil, fmt.Errorf("failed to calculate distance for ETA: %w", err)
 	}
 
-	// Get vehicle speed
-	speed, exists := s.config.Geospatial.RouteOptimization.DefaultSpeeds[vehicleType]
-	if !exists {
-		speed = s.config.Geospatial.RouteOptimization.DefaultSpeeds["car"] // default to car speed
+	// The same origin and destination areas always get the same model, so
+	// experiment and control ETAs compare like-for-like trips
+	request := eta.Request{
+		Origin:         origin,
+		Destination:    destination,
+		DistanceKm:     distanceCalc.DistanceKm,
+		VehicleType:    vehicleType,
+		DepartureTime:  departureTime,
+		IncludeTraffic: includeTraffic,
+		Area:           s.area(origin),
 	}
-
-	// Calculate base duration (distance / speed)
-	baseDurationHours := distanceCalc.DistanceKm / speed
-	baseDurationSeconds := int(baseDurationHours * 3600)
-
-	// Apply traffic factors if requested
-	if includeTraffic {
-		trafficFactor := s.getTrafficFactor(departureTime)
-		baseDurationSeconds = int(float64(baseDurationSeconds) * trafficFactor)
+	model := s.etaModels.Pick(request.Area + ":" + s.area(destination))
+	estimate, err := model.Estimate(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate ETA: %w", err)
 	}
+	baseDurationSeconds := int(estimate.Duration.Seconds())
 
-	estimatedArrival := departureTime.Add(time.Duration(baseDurationSeconds) * time.Second)
+	estimatedArrival := departureTime.Add(estimate.Duration)
 
 	// Generate route summary
 	routeSummary := fmt.Sprintf("Route from (%.6f, %.6f) to (%.6f, %.6f) via %s - %.2f km",
@@ -167,6 +370,7 @@ func (s *GeospatialService) CalculateETA(ctx context.Context, origin, destinatio
 		RouteSummary:     routeSummary,
 		Waypoints:        waypoints,
 		EstimatedArrival: estimatedArrival,
+		Model:            estimate.Model,
 	}
 
 	s.logger.WithContext(ctx).WithFields(logger.Fields{
@@ -174,17 +378,30 @@ func (s *GeospatialService) CalculateETA(ctx context.Context, origin, destinatio
 		"duration_minutes": baseDurationSeconds / 60,
 		"distance_km":      distanceCalc.DistanceKm,
 		"include_traffic":  includeTraffic,
+		"model":            estimate.Model,
+		"observed_trips":   estimate.Samples,
 	}).Debug("ETA calculated")
 
 	return result, nil
 }
 
-// FindNearbyDrivers finds drivers within a specified radius of a location
-func (s *GeospatialService) FindNearbyDrivers(ctx context.Context, center models.Location, radiusKm float64, limit int, vehicleTypes []string, onlyAvailable bool) ([]NearbyDriver, error) {
+// FindNearbyDrivers finds drivers within a specified radius of a location.
+// Only drivers in the given city are returned, or in the city the center is
+// in when none is given. Drivers whose last location is older than the
+// city's staleness limit are left out, since they are likely elsewhere.
+func (s *GeospatialService) FindNearbyDrivers(ctx context.Context, center models.Location, radiusKm float64, limit int, cityID string, vehicleTypes []string, onlyAvailable bool) ([]NearbyDriver, error) {
+	cityID, err := s.cities.Resolve(cityID, center)
+	if err != nil {
+		return nil, err
+	}
+
 	// Validate radius
 	if radiusKm > s.config.Geospatial.MaxSearchRadiusKm {
 		radiusKm = s.config.Geospatial.MaxSearchRadiusKm
 	}
+	if maxRadius := s.cities.Policies(cityID).MaxSearchRadiusKm; maxRadius > 0 && radiusKm > maxRadius {
+		radiusKm = maxRadius
+	}
 
 	// Validate limit
 	if limit > s.config.Geospatial.MaxNearbyDrivers {
@@ -192,14 +409,42 @@ func (s *GeospatialService) FindNearbyDrivers(ctx context.Context, center models
 	}
 
 	// Get driver locations from repository
-	driverLocations, err := s.driverRepo.FindNearbyDrivers(ctx, center, radiusKm, vehicleTypes, onlyAvailable)
+	driverLocations, err := s.driverRepo.FindNearbyDrivers(ctx, center, radiusKm, cityID, vehicleTypes, onlyAvailable)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find nearby drivers: %w", err)
 	}
 
 	// Calculate distances and sort
+	now := time.Now()
+	maxAge := s.maxLocationAge(cityID)
 	var nearbyDrivers []NearbyDriver
+	var stale int
 	for _, driverLoc := range driverLocations {
+		// Without configured cities driver locations carry no city to match
+		if s.cities != nil && cityID != "" && driverLoc.CityID != cityID {
+			continue
+		}
+
+		lastSeen := driverLoc.LastSeen()
+		age := now.Sub(lastSeen)
+		metrics.ObserveDriverLocationAge(age)
+		if maxAge > 0 && age > maxAge {
+			metrics.RecordStaleDriverExcluded()
+			stale++
+			continue
+		}
+
+		if s.driverStates != nil {
+			state, err := s.driverStates.GetState(ctx, driverLoc.DriverID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get driver state: %w", err)
+			}
+			if onlyAvailable && !state.Available() {
+				continue
+			}
+			driverLoc.Status = string(state.State)
+		}
+
 		distance, _ := s.calculateHaversineDistance(center, driverLoc.Location)
 
 		nearbyDrivers = append(nearbyDrivers, NearbyDriver{
@@ -209,7 +454,10 @@ func (s *GeospatialService) FindNearbyDrivers(ctx context.Context, center models
 			DistanceFromCenter: distance / 1000, // convert to km
 			Status:             driverLoc.Status,
 			VehicleType:        driverLoc.VehicleType,
+			VehicleFeatures:    driverLoc.VehicleFeatures,
 			Rating:             driverLoc.Rating,
+			CityID:             driverLoc.CityID,
+			LastSeenAt:         lastSeen,
 		})
 	}
 
@@ -227,7 +475,9 @@ func (s *GeospatialService) FindNearbyDrivers(ctx context.Context, center models
 		"center_lat":     center.Latitude,
 		"center_lng":     center.Longitude,
 		"radius_km":      radiusKm,
+		"city_id":        cityID,
 		"drivers_found":  len(nearbyDrivers),
+		"stale_drivers":  stale,
 		"only_available": onlyAvailable,
 		"vehicle_types":  vehicleTypes,
 	}).Info("Nearby drivers search completed")
@@ -235,20 +485,53 @@ func (s *GeospatialService) FindNearbyDrivers(ctx context.Context, center models
 	return nearbyDrivers, nil
 }
 
-// UpdateDriverLocation updates a driver's location
-func (s *GeospatialService) UpdateDriverLocation(ctx context.Context, driverID string, location models.Location, status string, vehicleID string) error {
+// maxLocationAge is how old a driver's location can be for searches in the
+// city to find them, zero for no limit
+func (s *GeospatialService) maxLocationAge(cityID string) time.Duration {
+	if seconds := s.cities.Policies(cityID).MaxLocationAgeSeconds; seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return time.Duration(s.config.Geospatial.MaxLocationAge) * time.Second
+}
+
+// UpdateDriverLocation updates a driver's location, in the given city or the
+// city the location is in when none is given. The vehicle features are kept
+// with the location so matching can filter on them.
+func (s *GeospatialService) UpdateDriverLocation(ctx context.Context, driverID string, location models.Location, status string, vehicleID string, cityID string, vehicleFeatures []string) error {
+	cityID, err := s.cities.Resolve(cityID, location)
+	if err != nil {
+		return err
+	}
+
+	if s.driverStates != nil {
+		state, err := s.driverStates.ReportLocation(ctx, driverID, cityID, location.Timestamp)
+		switch {
+		case err == nil:
+			status = string(state.State)
+			s.recordRoutePoint(ctx, state, location)
+		case errors.Is(err, driverstate.ErrDriverOffline):
+			status = string(driverstate.StateOffline)
+		default:
+			return fmt.Errorf("failed to record driver heartbeat: %w", err)
+		}
+	}
+
 	driverLocation := &repository.DriverLocation{
-		DriverID:  driverID,
-		VehicleID: vehicleID,
-		Location:  location,
-		Status:    status,
-		UpdatedAt: time.Now(),
+		DriverID:        driverID,
+		VehicleID:       vehicleID,
+		Location:        location,
+		Status:          status,
+		VehicleFeatures: vehicleFeatures,
+		CityID:          cityID,
+		UpdatedAt:       time.Now(),
 	}
 
-	err := s.driverRepo.UpdateDriverLocation(ctx, driverLocation)
+	err = s.driverRepo.UpdateDriverLocation(ctx, driverLocation)
 	if err != nil {
 		return fmt.Errorf("failed to update driver location: %w", err)
 	}
+	s.publishLocation(ctx, driverID, location)
+	s.trackQueue(ctx, driverID, location, status)
 
 	s.logger.WithContext(ctx).WithFields(logger.Fields{
 		"driver_id":  driverID,
@@ -256,11 +539,128 @@ func (s *GeospatialService) UpdateDriverLocation(ctx context.Context, driverID s
 		"latitude":   location.Latitude,
 		"longitude":  location.Longitude,
 		"status":     status,
+		"city_id":    cityID,
 	}).Info("Driver location updated")
 
 	return nil
 }
 
+// trackQueue moves the driver into or out of the dispatch zone queue at
+// their location. A queue that cannot be updated does not fail the location
+// update.
+func (s *GeospatialService) trackQueue(ctx context.Context, driverID string, location models.Location, status string) {
+	if s.queues == nil {
+		return
+	}
+	waiting := status == string(driverstate.StateOnline)
+	if err := s.queues.Track(ctx, driverID, location.Latitude, location.Longitude, waiting, time.Now()); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+			"driver_id": driverID,
+		}).Warn("Failed to update dispatch zone queue")
+	}
+}
+
+// recordRoutePoint adds the location to the route of the trip the driver is
+// on. A point that cannot be recorded does not fail the location update.
+func (s *GeospatialService) recordRoutePoint(ctx context.Context, state *driverstate.DriverState, location models.Location) {
+	if s.routes == nil || state.State != driverstate.StateOnTrip || state.TripID == "" {
+		return
+	}
+	if err := s.routes.Record(ctx, state.TripID, state.DriverID, location); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+			"driver_id": state.DriverID,
+			"trip_id":   state.TripID,
+		}).Warn("Failed to record route point")
+	}
+}
+
+// IngestDriverLocations takes in batches of locations drivers buffered
+// offline. Each driver's batch counts as one heartbeat, extends the route of
+// the trip they are on in one write, and leaves only their latest location
+// stored. Batches that cannot be taken in are reported in their outcome
+// rather than failing the others.
+func (s *GeospatialService) IngestDriverLocations(ctx context.Context, batches []ingest.Batch) ([]ingest.Outcome, error) {
+	if s.ingester == nil {
+		return nil, ErrIngestUnavailable
+	}
+
+	tracks, outcomes, err := s.ingester.Prepare(ctx, batches)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	ttl := time.Duration(s.config.Geospatial.DriverLocationTTL) * time.Second
+	positions := make([]*repository.DriverLocation, 0, len(tracks))
+	for i := range tracks {
+		track := &tracks[i]
+		latest := track.Latest()
+		cityID, err := s.cities.Resolve(track.CityID, latest)
+		if err != nil {
+			track.Outcome.Error = err.Error()
+			track.Outcome.Accepted = 0
+			continue
+		}
+
+		status := track.Status
+		if s.driverStates != nil {
+			state, err := s.driverStates.ReportLocation(ctx, track.DriverID, cityID, latest.Timestamp)
+			switch {
+			case err == nil:
+				status = string(state.State)
+				s.recordRoutePoints(ctx, state, track.Points)
+			case errors.Is(err, driverstate.ErrDriverOffline):
+				status = string(driverstate.StateOffline)
+			default:
+				track.Outcome.Error = fmt.Sprintf("failed to record driver heartbeat: %v", err)
+				track.Outcome.Accepted = 0
+				continue
+			}
+		}
+
+		positions = append(positions, &repository.DriverLocation{
+			DriverID:        track.DriverID,
+			VehicleID:       track.VehicleID,
+			Location:        latest,
+			Status:          status,
+			VehicleFeatures: track.VehicleFeatures,
+			CityID:          cityID,
+			UpdatedAt:       now,
+			ExpiresAt:       now.Add(ttl),
+		})
+	}
+
+	if err := s.ingester.Commit(ctx, positions); err != nil {
+		return nil, err
+	}
+	for _, position := range positions {
+		s.publishLocation(ctx, position.DriverID, position.Location)
+		s.trackQueue(ctx, position.DriverID, position.Location, position.Status)
+	}
+
+	s.logger.WithContext(ctx).WithFields(logger.Fields{
+		"drivers":   len(batches),
+		"positions": len(positions),
+	}).Info("Driver location batches ingested")
+
+	return outcomes, nil
+}
+
+// recordRoutePoints adds a batch of locations to the route of the trip the
+// driver is on. Points that cannot be recorded do not fail the upload.
+func (s *GeospatialService) recordRoutePoints(ctx context.Context, state *driverstate.DriverState, locations []models.Location) {
+	if s.routes == nil || state.State != driverstate.StateOnTrip || state.TripID == "" {
+		return
+	}
+	if err := s.routes.RecordMany(ctx, state.TripID, state.DriverID, locations); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+			"driver_id": state.DriverID,
+			"trip_id":   state.TripID,
+			"points":    len(locations),
+		}).Warn("Failed to record route points")
+	}
+}
+
 // GenerateGeohash generates a geohash for a location
 func (s *GeospatialService) GenerateGeohash(ctx context.Context, location models.Location, precision int) (string, error) {
 	if precision <= 0 {
@@ -368,22 +768,9 @@ func (s *GeospatialService) calculateEuclideanDistance(origin, destination model
 	return distance, bearing
 }
 
-// getTrafficFactor returns traffic multiplier based on time of day
-func (s *GeospatialService) getTrafficFactor(departureTime time.Time) float64 {
-	hour := departureTime.Hour()
-
-	// Rush hour times (7-9 AM, 5-7 PM)
-	if (hour >= 7 && hour <= 9) || (hour >= 17 && hour <= 19) {
-		return s.config.Geospatial.RouteOptimization.TrafficFactors["rush_hour"]
-	}
-
-	// Late night (11 PM - 5 AM)
-	if hour >= 23 || hour <= 5 {
-		return s.config.Geospatial.RouteOptimization.TrafficFactors["late_night"]
-	}
-
-	// Normal hours
-	return s.config.Geospatial.RouteOptimization.TrafficFactors["normal"]
+// area returns the geohash cell trip speeds are grouped by
+func (s *GeospatialService) area(location models.Location) string {
+	return s.calculateGeohash(location.Latitude, location.Longitude, s.config.ETA.AreaPrecision)
 }
 
 // generateWaypoints generates intermediate waypoints for a route
@@ -12,20 +12,38 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	_ "github.com/lib/pq"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 
+	"github.com/rideshare-platform/services/geo-service/internal/client"
 	"github.com/rideshare-platform/services/geo-service/internal/config"
+	"github.com/rideshare-platform/services/geo-service/internal/driverstate"
+	"github.com/rideshare-platform/services/geo-service/internal/eta"
+	"github.com/rideshare-platform/services/geo-service/internal/geofence"
 	grpcServer "github.com/rideshare-platform/services/geo-service/internal/grpc"
 	"github.com/rideshare-platform/services/geo-service/internal/handler"
+	"github.com/rideshare-platform/services/geo-service/internal/ingest"
+	"github.com/rideshare-platform/services/geo-service/internal/pickup"
 	"github.com/rideshare-platform/services/geo-service/internal/repository"
+	"github.com/rideshare-platform/services/geo-service/internal/route"
 	"github.com/rideshare-platform/services/geo-service/internal/service"
+	"github.com/rideshare-platform/services/geo-service/migrations"
+	"github.com/rideshare-platform/shared/city"
 	"github.com/rideshare-platform/shared/database"
+	"github.com/rideshare-platform/shared/events"
+	"github.com/rideshare-platform/shared/export"
+	sharedgrpc "github.com/rideshare-platform/shared/grpc"
+	"github.com/rideshare-platform/shared/grpc/interceptor"
+	sharedhealth "github.com/rideshare-platform/shared/health"
 	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/middleware"
 	"github.com/rideshare-platform/shared/models"
+	"github.com/rideshare-platform/shared/monitoring"
 	geopb "github.com/rideshare-platform/shared/proto/geo"
+	sharedtls "github.com/rideshare-platform/shared/tls"
 )
 
 func main() {
@@ -43,6 +61,12 @@ func main() {
 	// Initialize logger
 	appLogger := logger.NewLogger(cfg.LogLevel, cfg.Environment)
 
+	tlsReloader, err := sharedtls.Load(cfg.TLS, appLogger)
+	if err != nil {
+		appLogger.WithError(err).Fatal("Failed to load TLS certificates")
+	}
+	go tlsReloader.Watch(context.Background())
+
 	appLogger.WithFields(logger.Fields{
 		"service":   "geo-service",
 		"version":   "1.0.0",
@@ -61,18 +85,103 @@ func main() {
 		}
 	}()
 
+	if cfg.MigrateOnStartup {
+		migrator := database.NewMongoMigrator(mongoDB.Database, "geo-service", migrations.Load(), appLogger)
+		if _, err := migrator.Up(context.Background()); err != nil {
+			appLogger.WithError(err).Fatal("Failed to migrate database")
+		}
+	}
+
 	redisDB, err := database.NewRedisDB(cfg.Redis, appLogger)
 	if err != nil {
 		appLogger.WithError(err).Fatal("Failed to connect to Redis")
 	}
 	defer redisDB.Close()
 
+	// The health endpoint probes the databases and user-service
+	healthChecker := sharedhealth.NewChecker("geo-service", "1.0.0")
+	healthChecker.SetConfig(cfg)
+	healthChecker.AddCheck("mongodb", mongoDB.Health)
+	healthChecker.AddCheck("redis", redisDB.Health)
+
 	// Initialize repositories
 	driverLocationRepo := repository.NewDriverLocationRepository(mongoDB, appLogger)
 	cacheRepo := repository.NewCacheRepository(redisDB, appLogger)
 
 	// Initialize services
 	geoService := service.NewGeospatialService(cfg, appLogger, driverLocationRepo, cacheRepo, mongoDB.Client, redisDB.Client)
+	var cities *city.Registry
+	if cfg.CitiesFile != "" {
+		cities, err = city.Load(cfg.CitiesFile)
+		if err != nil {
+			appLogger.WithError(err).Fatal("Failed to load cities")
+		}
+		geoService.SetCities(cities)
+	}
+
+	// Driver availability comes from the driver state machine, backed by Redis
+	eventBus := events.NewInMemoryEventBus(appLogger)
+	defer eventBus.Close()
+	driverStates := driverstate.NewManager(driverstate.NewRedisStore(redisDB.Client), eventBus, time.Duration(cfg.DriverState.HeartbeatTTL)*time.Second, appLogger)
+	if err := driverStates.SubscribeTripEvents(eventBus); err != nil {
+		appLogger.WithError(err).Fatal("Failed to subscribe to trip events")
+	}
+	driverStates.SetLocationTTL(time.Duration(cfg.DriverState.LocationSilenceTTL) * time.Second)
+	geoService.SetDriverStates(driverStates)
+	driverSessions := driverstate.NewRedisSessionLog(redisDB.Client)
+	driverStates.SetSessionLog(driverSessions)
+	// Per-day online hours, read by earnings and incentives
+	driverStates.SetActivityLog(driverstate.NewRedisActivityLog(redisDB.Client))
+	// Driving-hour limits, set per city in the cities file
+	driverStates.SetFatigueRules(driverstate.NewCityFatiguePolicy(cities, driverstate.FatigueLimits{
+		MaxOnline:  time.Duration(cfg.DriverState.MaxOnlineHours * float64(time.Hour)),
+		MaxDriving: time.Duration(cfg.DriverState.MaxDrivingHours * float64(time.Hour)),
+		Window:     time.Duration(cfg.DriverState.FatigueWindowHours * float64(time.Hour)),
+		WarnBefore: time.Duration(cfg.DriverState.FatigueWarnMinutes) * time.Minute,
+	}), driverstate.NewRedisFatigueLog(redisDB.Client))
+
+	// The traffic ETA model learns from the speeds of completed trips
+	geoService.SetObservationStore(eta.NewMongoObservationStore(mongoDB.Database))
+	if err := geoService.SubscribeTripEvents(eventBus); err != nil {
+		appLogger.WithError(err).Fatal("Failed to subscribe to trip events")
+	}
+
+	// Locations reported by drivers on a trip make up the trip's recorded route
+	var routeMatcher route.Matcher
+	if cfg.Route.Matching == "smooth" {
+		routeMatcher = route.NewSmoothingMatcher(cfg.Route.MaxAccuracyMeters, cfg.Route.MaxSpeedKmh)
+	}
+	geoService.SetRouteRecorder(route.NewRecorder(route.NewMongoStore(mongoDB.Database), routeMatcher))
+
+	// Batch uploads of locations drivers buffered offline
+	geoService.SetLocationIngester(ingest.NewIngester(ingest.NewMongoStore(mongoDB.Database), ingest.NewRedisGeoIndex(redisDB.Client)))
+
+	// Riders follow their driver's pickup ETA, recomputed as the driver's
+	// locations reach any instance over Redis pub/sub
+	geoService.SetPickupFeed(pickup.NewRedisFeed(redisDB.Client, time.Duration(cfg.Geospatial.DriverLocationTTL)*time.Second))
+
+	// Airport, event venue and restricted area zones, queried by pricing and matching
+	zoneService := geofence.NewService(geofence.NewMongoStore(mongoDB.Database))
+	// Drivers waiting in dispatch zones such as airports queue in the order
+	// they arrived, and leave the queue with the zone or once off the market
+	zoneQueues := geofence.NewQueues(zoneService, geofence.NewRedisQueueStore(redisDB.Client))
+	if err := zoneQueues.SubscribeDriverEvents(eventBus); err != nil {
+		appLogger.WithError(err).Fatal("Failed to subscribe to driver state events")
+	}
+	geoService.SetZoneQueues(zoneQueues)
+
+	// Only drivers approved through user-service onboarding can go online
+	if conn, err := grpc.NewClient(cfg.DriverState.UserServiceAddr, append(sharedgrpc.ClientOptions(), tlsReloader.DialOption())...); err != nil {
+		appLogger.WithError(err).Warn("Failed to create user-service client, driver approval will not be checked")
+	} else {
+		defer conn.Close()
+		driverStates.SetApprovalChecker(client.NewGRPCUserClient(conn))
+		healthChecker.AddOptionalCheck("user-service", sharedhealth.GRPCProbe(conn))
+	}
+
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	go driverStates.StartHeartbeatMonitor(workerCtx, time.Duration(cfg.DriverState.SweepInterval)*time.Second)
 
 	// Test the service with sample data
 	testService(geoService, appLogger)
@@ -81,19 +190,58 @@ func main() {
 	geoHandler := &handler.GeoHandler{
 		Logger:     appLogger,
 		GeoService: geoService,
+		Health:     healthChecker,
 	}
 
+	// Request counts and latencies for HTTP and gRPC, scraped from /metrics
+	metricsCollector := monitoring.NewMetricsCollector(nil, appLogger)
+	driverStates.SetUtilizationRecorder(metricsCollector)
+
 	// Setup Gin router
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.New()
 	router.Use(gin.Recovery())
+	router.Use(middleware.NewLoggingMiddleware(appLogger).RequestLogger())
+	router.Use(metricsCollector.GinMiddleware("geo-service"))
+	router.GET("/metrics", gin.WrapH(monitoring.Handler()))
 
 	// Register routes
 	geoHandler.RegisterRoutes(router)
+	handler.NewDriverStateHandler(driverStates).RegisterRoutes(router)
+	zoneHandler := handler.NewZoneHandler(zoneService)
+	zoneHandler.SetQueues(zoneQueues)
+	zoneHandler.RegisterRoutes(router)
+
+	// Finished driver shifts are exported to the data warehouse in
+	// incremental batches when EXPORT_ENABLED is set, with the manifest for
+	// admin tokens under /api/v1/exports
+	if cfg.Export.Enabled {
+		exporter, err := export.Open(context.Background(), cfg.Export, appLogger)
+		if err != nil {
+			appLogger.WithError(err).Fatal("Failed to set up warehouse export")
+		}
+		defer exporter.Close()
+		exporter.Register(driverstate.NewSessionExport(driverSessions))
+		go exporter.Start(workerCtx, cfg.Export.Interval)
+
+		exportMux := http.NewServeMux()
+		export.NewHandler(exporter, middleware.NewAuthMiddleware(cfg.JWTSecret, appLogger).RequireAdmin).RegisterRoutes(exportMux)
+		router.Any("/api/v1/exports/*path", gin.WrapH(exportMux))
+	}
 
 	// Start gRPC server with health
-	grpcSrv := grpc.NewServer()
+	serverOptions := interceptor.ServerOptions(interceptor.Config{
+		Service:  "geo-service",
+		Logger:   appLogger,
+		Metrics:  metricsCollector,
+		Auth:     interceptor.JWTAuth(cfg.JWTSecret, cfg.GRPCAuthRequired),
+		Deadline: interceptor.DefaultDeadlineConfig(),
+	})
+	grpcSrv := grpc.NewServer(append(serverOptions, tlsReloader.ServerOptions()...)...)
 	geoGrpcServer := grpcServer.NewServer(*geoService, *appLogger)
+	geoGrpcServer.SetZones(zoneService)
+	geoGrpcServer.SetQueues(zoneQueues)
+	geoGrpcServer.SetDriverStates(driverStates)
 	geopb.RegisterGeospatialServiceServer(grpcSrv, geoGrpcServer)
 	healthServer := health.NewServer()
 	grpc_health_v1.RegisterHealthServer(grpcSrv, healthServer)
@@ -137,6 +285,7 @@ func main() {
 	// Wait for interrupt signal
 	<-sigChan
 	appLogger.Logger.Info("Received interrupt signal, starting graceful shutdown...")
+	stopWorkers()
 
 	// Give time for graceful shutdown
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -193,20 +342,20 @@ func testService(geoService *service.GeospatialService, logger *logger.Logger) {
 
 	// Test ETA calculation
 	logger.Logger.Info("Testing ETA calculation...")
-	eta, err := geoService.CalculateETA(ctx, origin, destination, "car", time.Now(), true)
+	etaCalc, err := geoService.CalculateETA(ctx, origin, destination, "car", time.Now(), true)
 	if err != nil {
 		logger.WithError(err).Error("ETA calculation failed")
 	} else {
 		logger.Logger.WithFields(map[string]interface{}{
-			"duration_minutes": eta.DurationSeconds / 60,
-			"distance_km":      eta.DistanceMeters / 1000,
+			"duration_minutes": etaCalc.DurationSeconds / 60,
+			"distance_km":      etaCalc.DistanceMeters / 1000,
 			"vehicle_type":     "car",
 		}).Info("ETA calculation successful")
 	}
 
 	// Test nearby drivers search
 	logger.Logger.Info("Testing nearby drivers search...")
-	drivers, err := geoService.FindNearbyDrivers(ctx, origin, 5.0, 10, []string{"sedan", "suv"}, true)
+	drivers, err := geoService.FindNearbyDrivers(ctx, origin, 5.0, 10, "", []string{"sedan", "suv"}, true)
 	if err != nil {
 		logger.WithError(err).Error("Nearby drivers search failed")
 	} else {
@@ -218,7 +367,7 @@ func testService(geoService *service.GeospatialService, logger *logger.Logger) {
 
 	// Test driver location update
 	logger.Logger.Info("Testing driver location update...")
-	err = geoService.UpdateDriverLocation(ctx, "test_driver_001", origin, "online", "test_vehicle_001")
+	err = geoService.UpdateDriverLocation(ctx, "test_driver_001", origin, "online", "test_vehicle_001", "", nil)
 	if err != nil {
 		logger.WithError(err).Error("Driver location update failed")
 	} else {
@@ -0,0 +1,135 @@
+// Package migrations holds the versioned MongoDB collections and indexes
+// owned by geo-service
+package migrations
+
+import (
+	"context"
+
+	"github.com/rideshare-platform/shared/database"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// driverLocations is the collection DriverLocationRepository reads and writes
+const driverLocations = "driver_locations"
+
+// tripSpeedObservations holds completed trip speeds for the traffic ETA model
+const tripSpeedObservations = "trip_speed_observations"
+
+// tripRoutes is a time series of the locations drivers report during trips
+const tripRoutes = "trip_routes"
+
+// geofences holds airport, event venue and restricted area zones
+const geofences = "geofences"
+
+// Load returns the service's migrations in version order
+func Load() []database.MongoMigration {
+	return []database.MongoMigration{
+		{
+			Version: 1,
+			Name:    "create_driver_locations",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				_, err := db.Collection(driverLocations).Indexes().CreateMany(ctx, []mongo.IndexModel{
+					{
+						Keys:    bson.D{{Key: "driver_id", Value: 1}},
+						Options: options.Index().SetName("driver_id_unique").SetUnique(true),
+					},
+					{
+						Keys:    bson.D{{Key: "status", Value: 1}, {Key: "vehicle_type", Value: 1}},
+						Options: options.Index().SetName("status_vehicle_type"),
+					},
+					{
+						// Locations not refreshed within their expiry are dropped by MongoDB
+						Keys:    bson.D{{Key: "expires_at", Value: 1}},
+						Options: options.Index().SetName("expires_at_ttl").SetExpireAfterSeconds(0),
+					},
+				})
+				return err
+			},
+			Down: func(ctx context.Context, db *mongo.Database) error {
+				return db.Collection(driverLocations).Drop(ctx)
+			},
+		},
+		{
+			Version: 2,
+			Name:    "index_driver_location_coordinates",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				_, err := db.Collection(driverLocations).Indexes().CreateOne(ctx, mongo.IndexModel{
+					Keys:    bson.D{{Key: "location.latitude", Value: 1}, {Key: "location.longitude", Value: 1}},
+					Options: options.Index().SetName("location_coordinates"),
+				})
+				return err
+			},
+			Down: func(ctx context.Context, db *mongo.Database) error {
+				_, err := db.Collection(driverLocations).Indexes().DropOne(ctx, "location_coordinates")
+				return err
+			},
+		},
+		{
+			Version: 3,
+			Name:    "create_trip_speed_observations",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				_, err := db.Collection(tripSpeedObservations).Indexes().CreateOne(ctx, mongo.IndexModel{
+					Keys: bson.D{
+						{Key: "area", Value: 1},
+						{Key: "vehicle_type", Value: 1},
+						{Key: "hour", Value: 1},
+						{Key: "completed_at", Value: -1},
+					},
+					Options: options.Index().SetName("area_vehicle_type_hour_completed_at"),
+				})
+				return err
+			},
+			Down: func(ctx context.Context, db *mongo.Database) error {
+				return db.Collection(tripSpeedObservations).Drop(ctx)
+			},
+		},
+		{
+			Version: 4,
+			Name:    "create_geofences",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				_, err := db.Collection(geofences).Indexes().CreateMany(ctx, []mongo.IndexModel{
+					{
+						// Point-in-zone lookups use $geoIntersects on the boundary polygon
+						Keys:    bson.D{{Key: "boundary", Value: "2dsphere"}},
+						Options: options.Index().SetName("boundary_2dsphere"),
+					},
+					{
+						Keys:    bson.D{{Key: "type", Value: 1}, {Key: "name", Value: 1}},
+						Options: options.Index().SetName("type_name"),
+					},
+				})
+				return err
+			},
+			Down: func(ctx context.Context, db *mongo.Database) error {
+				return db.Collection(geofences).Drop(ctx)
+			},
+		},
+		{
+			Version: 5,
+			Name:    "create_trip_routes",
+			Up: func(ctx context.Context, db *mongo.Database) error {
+				// Points are bucketed per trip; fares and receipts keep the
+				// measured distance, so raw points are dropped after 90 days
+				err := db.CreateCollection(ctx, tripRoutes, options.CreateCollection().
+					SetTimeSeriesOptions(options.TimeSeries().
+						SetTimeField("recorded_at").
+						SetMetaField("trip_id").
+						SetGranularity("seconds")).
+					SetExpireAfterSeconds(90*24*60*60))
+				if err != nil {
+					return err
+				}
+				_, err = db.Collection(tripRoutes).Indexes().CreateOne(ctx, mongo.IndexModel{
+					Keys:    bson.D{{Key: "trip_id", Value: 1}, {Key: "recorded_at", Value: 1}},
+					Options: options.Index().SetName("trip_id_recorded_at"),
+				})
+				return err
+			},
+			Down: func(ctx context.Context, db *mongo.Database) error {
+				return db.Collection(tripRoutes).Drop(ctx)
+			},
+		},
+	}
+}
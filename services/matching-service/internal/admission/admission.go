@@ -0,0 +1,273 @@
+// Package admission bounds how many match requests run at once. Requests
+// beyond the limit wait in a queue ordered by priority, and are turned away
+// with a suggested retry delay once the queue is full or they have waited
+// too long.
+package admission
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/services/matching-service/internal/metrics"
+)
+
+// Priority levels of match requests
+const (
+	PriorityNormal    = 1
+	PriorityPremium   = 2
+	PriorityEmergency = 3
+)
+
+// ErrOverloaded is returned for requests turned away at capacity
+var ErrOverloaded = errors.New("matching is over capacity")
+
+// OverloadError turns a request away and suggests when to retry it
+type OverloadError struct {
+	Reason     string // queue_full, queue_timeout or displaced
+	RetryAfter time.Duration
+}
+
+func (e *OverloadError) Error() string {
+	return fmt.Sprintf("%s (%s), retry in %s", ErrOverloaded, e.Reason, e.RetryAfter)
+}
+
+// Is makes errors.Is match ErrOverloaded
+func (e *OverloadError) Is(target error) bool {
+	return target == ErrOverloaded
+}
+
+// RetryAfter returns the retry delay an overload error suggests, in whole
+// seconds, and whether err is one
+func RetryAfter(err error) (time.Duration, bool) {
+	var overload *OverloadError
+	if !errors.As(err, &overload) {
+		return 0, false
+	}
+	return overload.RetryAfter, true
+}
+
+// Config sizes the controller
+type Config struct {
+	// Workers is how many requests run at once
+	Workers int
+	// QueueSize is how many requests may wait for a worker
+	QueueSize int
+	// MaxWait is how long a request waits for a worker before it is turned away
+	MaxWait time.Duration
+}
+
+// Controller admits requests up to its worker count and queues the rest by
+// priority, highest first and oldest first within a priority. When the queue
+// is full a request displaces the newest waiter of a lower priority, or is
+// turned away.
+type Controller struct {
+	config  Config
+	mutex   sync.Mutex
+	running int
+	waiting waiters
+	seq     uint64
+	// average time a request holds a worker, for the suggested retry delay
+	averageHold time.Duration
+}
+
+// NewController creates a controller. Workers is at least one.
+func NewController(config Config) *Controller {
+	config.Workers = max(config.Workers, 1)
+	config.QueueSize = max(config.QueueSize, 0)
+	return &Controller{config: config, averageHold: time.Second}
+}
+
+// Acquire waits for a worker and returns the function that releases it.
+// Requests turned away get an *OverloadError; a cancelled ctx returns its
+// error.
+func (c *Controller) Acquire(ctx context.Context, priority int) (func(), error) {
+	priority = normalizePriority(priority)
+
+	c.mutex.Lock()
+	if c.running < c.config.Workers && len(c.waiting) == 0 {
+		c.running++
+		metrics.SetMatchesInFlight(c.running)
+		c.mutex.Unlock()
+		return c.releaser(time.Now()), nil
+	}
+
+	if len(c.waiting) >= c.config.QueueSize {
+		victim := c.waiting.lowest()
+		if victim == nil || victim.priority >= priority {
+			err := c.overload("queue_full")
+			c.mutex.Unlock()
+			metrics.RecordMatchShed(priority, "queue_full")
+			return nil, err
+		}
+		heap.Remove(&c.waiting, victim.index)
+		victim.state = stateDisplaced
+		close(victim.ready)
+		metrics.SetAdmissionQueueDepth(victim.priority, c.waiting.count(victim.priority))
+	}
+
+	c.seq++
+	w := &waiter{priority: priority, seq: c.seq, ready: make(chan struct{}), enqueuedAt: time.Now()}
+	heap.Push(&c.waiting, w)
+	metrics.SetAdmissionQueueDepth(priority, c.waiting.count(priority))
+	c.mutex.Unlock()
+
+	var timeout <-chan time.Time
+	if c.config.MaxWait > 0 {
+		timer := time.NewTimer(c.config.MaxWait)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case <-w.ready:
+	case <-timeout:
+	case <-ctx.Done():
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	metrics.ObserveAdmissionWait(time.Since(w.enqueuedAt))
+
+	switch w.state {
+	case stateAdmitted:
+		// Admitted while timing out, the worker is ours
+		return c.releaser(time.Now()), nil
+	case stateDisplaced:
+		metrics.RecordMatchShed(priority, "displaced")
+		return nil, c.overload("displaced")
+	}
+
+	heap.Remove(&c.waiting, w.index)
+	metrics.SetAdmissionQueueDepth(priority, c.waiting.count(priority))
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	metrics.RecordMatchShed(priority, "queue_timeout")
+	return nil, c.overload("queue_timeout")
+}
+
+// Depth returns how many requests are running and waiting
+func (c *Controller) Depth() (running, waiting int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.running, len(c.waiting)
+}
+
+// releaser returns the function that gives the worker to the next waiter
+func (c *Controller) releaser(start time.Time) func() {
+	var once sync.Once
+	return func() {
+		once.Do(func() { c.release(time.Since(start)) })
+	}
+}
+
+func (c *Controller) release(held time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Smoothed so a single slow match does not swing the suggested delay
+	c.averageHold = (c.averageHold*7 + held) / 8
+
+	if len(c.waiting) == 0 {
+		c.running--
+		metrics.SetMatchesInFlight(c.running)
+		return
+	}
+	next := heap.Pop(&c.waiting).(*waiter)
+	next.state = stateAdmitted
+	close(next.ready)
+	metrics.SetAdmissionQueueDepth(next.priority, c.waiting.count(next.priority))
+}
+
+// overload turns a request away, suggesting a retry once the requests ahead
+// of it are likely done. Callers hold the mutex.
+func (c *Controller) overload(reason string) *OverloadError {
+	ahead := float64(c.running+len(c.waiting)) / float64(c.config.Workers)
+	delay := time.Duration(math.Ceil(ahead*c.averageHold.Seconds())) * time.Second
+	return &OverloadError{Reason: reason, RetryAfter: max(delay, time.Second)}
+}
+
+// normalizePriority treats unset and unknown priorities as normal and caps
+// them at emergency
+func normalizePriority(priority int) int {
+	if priority < PriorityNormal {
+		return PriorityNormal
+	}
+	return min(priority, PriorityEmergency)
+}
+
+const (
+	stateWaiting = iota
+	stateAdmitted
+	stateDisplaced
+)
+
+// waiter is a request waiting for a worker
+type waiter struct {
+	priority   int
+	seq        uint64
+	state      int
+	ready      chan struct{}
+	enqueuedAt time.Time
+	index      int
+}
+
+// waiters is a heap of waiting requests, highest priority and oldest first
+type waiters []*waiter
+
+func (w waiters) Len() int { return len(w) }
+
+func (w waiters) Less(i, j int) bool {
+	if w[i].priority != w[j].priority {
+		return w[i].priority > w[j].priority
+	}
+	return w[i].seq < w[j].seq
+}
+
+func (w waiters) Swap(i, j int) {
+	w[i], w[j] = w[j], w[i]
+	w[i].index = i
+	w[j].index = j
+}
+
+func (w *waiters) Push(x any) {
+	item := x.(*waiter)
+	item.index = len(*w)
+	*w = append(*w, item)
+}
+
+func (w *waiters) Pop() any {
+	old := *w
+	item := old[len(old)-1]
+	old[len(old)-1] = nil
+	*w = old[:len(old)-1]
+	return item
+}
+
+// lowest returns the waiter displaced first: the newest of the lowest priority
+func (w waiters) lowest() *waiter {
+	var lowest *waiter
+	for _, item := range w {
+		if lowest == nil || item.priority < lowest.priority ||
+			(item.priority == lowest.priority && item.seq > lowest.seq) {
+			lowest = item
+		}
+	}
+	return lowest
+}
+
+// count returns how many requests of a priority are waiting
+func (w waiters) count(priority int) int {
+	count := 0
+	for _, item := range w {
+		if item.priority == priority {
+			count++
+		}
+	}
+	return count
+}
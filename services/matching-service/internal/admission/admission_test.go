@@ -0,0 +1,108 @@
+package admission
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// acquireAsync acquires in the background, returning the result once admitted or turned away
+func acquireAsync(controller *Controller, priority int) <-chan error {
+	done := make(chan error, 1)
+	go func() {
+		release, err := controller.Acquire(context.Background(), priority)
+		if release != nil {
+			defer release()
+		}
+		done <- err
+	}()
+	return done
+}
+
+// waitForQueue waits until the controller has the given number of waiters
+func waitForQueue(t *testing.T, controller *Controller, waiting int) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		_, queued := controller.Depth()
+		return queued == waiting
+	}, time.Second, time.Millisecond)
+}
+
+func TestController_AdmitsHighestPriorityFirst(t *testing.T) {
+	controller := NewController(Config{Workers: 1, QueueSize: 2})
+
+	release, err := controller.Acquire(context.Background(), PriorityNormal)
+	require.NoError(t, err)
+
+	order := make(chan int, 2)
+	for i, priority := range []int{PriorityNormal, PriorityPremium} {
+		go func(priority int) {
+			release, err := controller.Acquire(context.Background(), priority)
+			if err == nil {
+				order <- priority
+				release()
+			}
+		}(priority)
+		waitForQueue(t, controller, i+1)
+	}
+
+	release()
+	assert.Equal(t, PriorityPremium, <-order)
+	assert.Equal(t, PriorityNormal, <-order)
+
+	assert.Eventually(t, func() bool {
+		running, waiting := controller.Depth()
+		return running == 0 && waiting == 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestController_FullQueueDisplacesLowerPriority(t *testing.T) {
+	controller := NewController(Config{Workers: 1, QueueSize: 1})
+
+	release, err := controller.Acquire(context.Background(), PriorityNormal)
+	require.NoError(t, err)
+
+	normal := acquireAsync(controller, PriorityNormal)
+	waitForQueue(t, controller, 1)
+
+	emergency := acquireAsync(controller, PriorityEmergency)
+	err = <-normal
+	assert.True(t, errors.Is(err, ErrOverloaded))
+	assert.Equal(t, "displaced", err.(*OverloadError).Reason)
+
+	// Nothing waiting ranks below the new request, so it is turned away
+	waitForQueue(t, controller, 1)
+	_, err = controller.Acquire(context.Background(), PriorityPremium)
+	retryAfter, overloaded := RetryAfter(err)
+	assert.True(t, overloaded)
+	assert.GreaterOrEqual(t, retryAfter, time.Second)
+	assert.Equal(t, "queue_full", err.(*OverloadError).Reason)
+
+	release()
+	assert.NoError(t, <-emergency)
+}
+
+func TestController_QueueTimeout(t *testing.T) {
+	controller := NewController(Config{Workers: 1, QueueSize: 5, MaxWait: 10 * time.Millisecond})
+
+	release, err := controller.Acquire(context.Background(), PriorityNormal)
+	require.NoError(t, err)
+	defer release()
+
+	_, err = controller.Acquire(context.Background(), PriorityNormal)
+	assert.True(t, errors.Is(err, ErrOverloaded))
+	assert.Equal(t, "queue_timeout", err.(*OverloadError).Reason)
+
+	// A cancelled request is not an overload
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = controller.Acquire(ctx, PriorityNormal)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, waiting := controller.Depth()
+	assert.Zero(t, waiting)
+}
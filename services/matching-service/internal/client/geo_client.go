@@ -0,0 +1,186 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/rideshare-platform/services/matching-service/internal/service"
+	"github.com/rideshare-platform/shared/models"
+	geopb "github.com/rideshare-platform/shared/proto/geo"
+)
+
+// GRPCZoneClient checks pickup zones through geo-service's gRPC API
+type GRPCZoneClient struct {
+	client geopb.GeospatialServiceClient
+}
+
+// NewGRPCZoneClient creates a new zone client
+func NewGRPCZoneClient(conn grpc.ClientConnInterface) *GRPCZoneClient {
+	return &GRPCZoneClient{client: geopb.NewGeospatialServiceClient(conn)}
+}
+
+// RestrictedPickupZone returns the name of the first zone containing the
+// location that restricts pickups
+func (c *GRPCZoneClient) RestrictedPickupZone(ctx context.Context, location *models.Location) (string, error) {
+	resp, err := c.client.FindZones(ctx, &geopb.FindZonesRequest{
+		Location: &geopb.Location{Latitude: location.Latitude, Longitude: location.Longitude},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	for _, zone := range resp.Zones {
+		if zone.PickupRestricted {
+			return zone.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// zoneQueueLimit is how many drivers are read from the head of a dispatch
+// zone's queue
+const zoneQueueLimit = 50
+
+// QueuedDrivers returns the drivers queued in the dispatch zone containing
+// the location, head of the queue first
+func (c *GRPCZoneClient) QueuedDrivers(ctx context.Context, location *models.Location) ([]string, error) {
+	zones, err := c.client.FindZones(ctx, &geopb.FindZonesRequest{
+		Location: &geopb.Location{Latitude: location.Latitude, Longitude: location.Longitude},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if zones.QueueZoneId == "" {
+		return nil, nil
+	}
+
+	resp, err := c.client.GetZoneQueue(ctx, &geopb.GetZoneQueueRequest{ZoneId: zones.QueueZoneId, Limit: zoneQueueLimit})
+	if err != nil {
+		return nil, err
+	}
+	drivers := make([]string, 0, len(resp.Drivers))
+	for _, driver := range resp.Drivers {
+		drivers = append(drivers, driver.DriverId)
+	}
+	return drivers, nil
+}
+
+// GRPCGeoClient finds nearby drivers and calculates distances and ETAs
+// through geo-service's gRPC API
+type GRPCGeoClient struct {
+	client  geopb.GeospatialServiceClient
+	timeout time.Duration
+}
+
+// NewGRPCGeoClient creates a new geo client. Each call is given up on after
+// timeout, a zero timeout leaves the caller's deadline in place.
+func NewGRPCGeoClient(conn grpc.ClientConnInterface, timeout time.Duration) *GRPCGeoClient {
+	return &GRPCGeoClient{client: geopb.NewGeospatialServiceClient(conn), timeout: timeout}
+}
+
+// CalculateDistance returns the straight line distance between two locations
+func (c *GRPCGeoClient) CalculateDistance(ctx context.Context, origin, destination *models.Location) (*service.DistanceResult, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	resp, err := c.client.CalculateDistance(ctx, &geopb.DistanceRequest{
+		Origin:            toGeoLocation(origin),
+		Destination:       toGeoLocation(destination),
+		CalculationMethod: "haversine",
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &service.DistanceResult{
+		DistanceMeters: resp.DistanceMeters,
+		DistanceKm:     resp.DistanceKm,
+		BearingDegrees: resp.BearingDegrees,
+	}, nil
+}
+
+// CalculateETA returns the traffic-aware travel time between two locations
+func (c *GRPCGeoClient) CalculateETA(ctx context.Context, origin, destination *models.Location, vehicleType string) (*service.ETAResult, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	resp, err := c.client.CalculateETA(ctx, &geopb.ETARequest{
+		Origin:         toGeoLocation(origin),
+		Destination:    toGeoLocation(destination),
+		VehicleType:    vehicleType,
+		IncludeTraffic: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &service.ETAResult{
+		DurationSeconds: int(resp.DurationSeconds),
+		DistanceMeters:  resp.DistanceMeters,
+		RouteSummary:    resp.RouteSummary,
+	}, nil
+}
+
+// FindNearbyDrivers returns the available drivers in the city within radiusKm
+// of center, closest first
+func (c *GRPCGeoClient) FindNearbyDrivers(ctx context.Context, center *models.Location, radiusKm float64, limit int, cityID string) ([]*service.DriverLocation, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+
+	resp, err := c.client.FindNearbyDrivers(ctx, &geopb.NearbyDriversRequest{
+		Center:        toGeoLocation(center),
+		RadiusKm:      radiusKm,
+		Limit:         int32(limit),
+		OnlyAvailable: true,
+		CityId:        cityID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	drivers := make([]*service.DriverLocation, 0, len(resp.Drivers))
+	for _, driver := range resp.Drivers {
+		location := &models.Location{}
+		if driver.Location != nil {
+			location.Latitude = driver.Location.Latitude
+			location.Longitude = driver.Location.Longitude
+			if driver.Location.Timestamp != nil {
+				location.Timestamp = driver.Location.Timestamp.AsTime()
+			}
+		}
+		drivers = append(drivers, &service.DriverLocation{
+			DriverID:           driver.DriverId,
+			VehicleID:          driver.VehicleId,
+			Location:           location,
+			DistanceFromCenter: driver.DistanceFromCenter,
+			Status:             driverStatus(driver.Status),
+			VehicleType:        driver.VehicleType,
+			VehicleFeatures:    driver.VehicleFeatures,
+			Rating:             driver.Rating,
+		})
+	}
+	return drivers, nil
+}
+
+func (c *GRPCGeoClient) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.timeout)
+}
+
+// driverStatus maps geo-service's driver states onto the statuses matching
+// works with: online drivers are the ones that can take a trip
+func driverStatus(state string) string {
+	if state == "online" {
+		return "available"
+	}
+	return state
+}
+
+func toGeoLocation(location *models.Location) *geopb.Location {
+	if location == nil {
+		return nil
+	}
+	return &geopb.Location{Latitude: location.Latitude, Longitude: location.Longitude}
+}
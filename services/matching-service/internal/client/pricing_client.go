@@ -0,0 +1,102 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"google.golang.org/grpc"
+
+	"github.com/rideshare-platform/services/matching-service/internal/service"
+	"github.com/rideshare-platform/shared/models"
+	pricingpb "github.com/rideshare-platform/shared/proto/pricing"
+)
+
+// GRPCPricingClient quotes trips and splits shared ride fares through
+// pricing-service's gRPC API
+type GRPCPricingClient struct {
+	client pricingpb.PricingServiceClient
+}
+
+// NewGRPCPricingClient creates a new pricing client
+func NewGRPCPricingClient(conn grpc.ClientConnInterface) *GRPCPricingClient {
+	return &GRPCPricingClient{client: pricingpb.NewPricingServiceClient(conn)}
+}
+
+// SplitSharedFare splits a shared route's fare between its riders
+func (c *GRPCPricingClient) SplitSharedFare(ctx context.Context, request *service.SharedFareRequest) ([]*service.SharedFareShare, error) {
+	req := &pricingpb.SplitSharedFareRequest{
+		VehicleType:          request.VehicleType,
+		RouteDistanceKm:      request.RouteDistanceKm,
+		RouteDurationMinutes: secondsToMinutes(request.RouteDurationSeconds),
+		City:                 request.City,
+	}
+	for _, rider := range request.Riders {
+		req.Riders = append(req.Riders, &pricingpb.SharedFareRider{
+			RiderId:         rider.RiderID,
+			TripId:          rider.TripID,
+			DistanceKm:      rider.DistanceKm,
+			DurationMinutes: secondsToMinutes(rider.DurationSeconds),
+		})
+	}
+
+	resp, err := c.client.SplitSharedFare(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	shares := make([]*service.SharedFareShare, 0, len(resp.Shares))
+	for _, share := range resp.Shares {
+		shares = append(shares, &service.SharedFareShare{
+			RiderID:    share.RiderId,
+			TripID:     share.TripId,
+			SoloFare:   share.SoloFare,
+			SharedFare: share.SharedFare,
+			Savings:    share.Savings,
+			Currency:   resp.Currency,
+		})
+	}
+	return shares, nil
+}
+
+// EstimateFare quotes a trip at pricing-service's rates and live surge
+func (c *GRPCPricingClient) EstimateFare(ctx context.Context, request *service.FareEstimateRequest) (*service.FareEstimate, error) {
+	resp, err := c.client.GetPriceEstimate(ctx, &pricingpb.GetPriceEstimateRequest{
+		TripId:          request.TripID,
+		RiderId:         request.RiderID,
+		PickupLocation:  toPricingLocation(request.PickupLocation),
+		Destination:     toPricingLocation(request.Destination),
+		VehicleType:     request.VehicleType,
+		DistanceKm:      request.DistanceKm,
+		DurationSeconds: int32(request.DurationSeconds),
+		City:            request.City,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Estimate == nil {
+		return nil, fmt.Errorf("pricing-service returned no estimate: %s", resp.Message)
+	}
+
+	estimate := resp.Estimate
+	return &service.FareEstimate{
+		BaseFare:        estimate.BaseFare,
+		DistanceFare:    estimate.DistanceFare,
+		TimeFare:        estimate.TimeFare,
+		SurgeFare:       estimate.SurgeAmount,
+		SurgeMultiplier: estimate.SurgeMultiplier,
+		TotalEstimate:   estimate.TotalAmount,
+		Currency:        estimate.Currency,
+	}, nil
+}
+
+func toPricingLocation(location *models.Location) *pricingpb.Location {
+	if location == nil {
+		return nil
+	}
+	return &pricingpb.Location{Latitude: location.Latitude, Longitude: location.Longitude}
+}
+
+func secondsToMinutes(seconds int) int32 {
+	return int32(math.Ceil(float64(seconds) / 60))
+}
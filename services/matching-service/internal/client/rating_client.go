@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/rideshare-platform/services/matching-service/internal/service"
+	trippb "github.com/rideshare-platform/shared/proto/trip"
+)
+
+// GRPCRatingClient looks up driver ratings and reliability through
+// trip-service's gRPC API
+type GRPCRatingClient struct {
+	client trippb.TripServiceClient
+}
+
+// NewGRPCRatingClient creates a new rating client
+func NewGRPCRatingClient(conn grpc.ClientConnInterface) *GRPCRatingClient {
+	return &GRPCRatingClient{client: trippb.NewTripServiceClient(conn)}
+}
+
+// GetDriverRatings returns the rolling average ratings of the given drivers
+func (c *GRPCRatingClient) GetDriverRatings(ctx context.Context, driverIDs []string) (map[string]*service.DriverRating, error) {
+	resp, err := c.client.GetDriverRatings(ctx, &trippb.GetDriverRatingsRequest{DriverIds: driverIDs})
+	if err != nil {
+		return nil, err
+	}
+
+	ratings := make(map[string]*service.DriverRating, len(resp.Ratings))
+	for driverID, summary := range resp.Ratings {
+		ratings[driverID] = &service.DriverRating{
+			DriverID:    driverID,
+			Average:     summary.AverageRating,
+			RatingCount: int(summary.RatingCount),
+		}
+	}
+	return ratings, nil
+}
+
+// GetDriverReliability returns how often the given drivers recently
+// cancelled their trips and how many they completed in a row
+func (c *GRPCRatingClient) GetDriverReliability(ctx context.Context, driverIDs []string) (map[string]*service.DriverReliability, error) {
+	resp, err := c.client.GetDriverReliability(ctx, &trippb.GetDriverReliabilityRequest{DriverIds: driverIDs})
+	if err != nil {
+		return nil, err
+	}
+
+	reliabilities := make(map[string]*service.DriverReliability, len(resp.Drivers))
+	for driverID, reliability := range resp.Drivers {
+		reliabilities[driverID] = &service.DriverReliability{
+			DriverID:         driverID,
+			Trips:            reliability.Trips,
+			CancellationRate: reliability.CancellationRate,
+			CompletionStreak: reliability.CompletionStreak,
+		}
+	}
+	return reliabilities, nil
+}
@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/rideshare-platform/services/matching-service/internal/service"
+	"github.com/rideshare-platform/shared/models"
+	trippb "github.com/rideshare-platform/shared/proto/trip"
+)
+
+// GRPCSharedTripClient manages shared trips through trip-service's gRPC API
+type GRPCSharedTripClient struct {
+	client trippb.TripServiceClient
+}
+
+// NewGRPCSharedTripClient creates a new shared trip client
+func NewGRPCSharedTripClient(conn grpc.ClientConnInterface) *GRPCSharedTripClient {
+	return &GRPCSharedTripClient{client: trippb.NewTripServiceClient(conn)}
+}
+
+// ListOpenSharedTrips returns active shared trips that can take more riders
+func (c *GRPCSharedTripClient) ListOpenSharedTrips(ctx context.Context, vehicleType string, seatsNeeded int) ([]*service.SharedTrip, error) {
+	resp, err := c.client.ListOpenSharedTrips(ctx, &trippb.ListOpenSharedTripsRequest{
+		VehicleType: vehicleType,
+		SeatsNeeded: int32(seatsNeeded),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	trips := make([]*service.SharedTrip, 0, len(resp.SharedTrips))
+	for _, trip := range resp.SharedTrips {
+		trips = append(trips, sharedTripFromProto(trip))
+	}
+	return trips, nil
+}
+
+// OpenSharedTrip starts a shared trip around a driver's first rider
+func (c *GRPCSharedTripClient) OpenSharedTrip(ctx context.Context, driverID, vehicleType string, seatCapacity int, rider *service.SharedRider, location *models.Location) (*service.SharedTrip, error) {
+	resp, err := c.client.OpenSharedTrip(ctx, &trippb.OpenSharedTripRequest{
+		DriverId:        driverID,
+		VehicleType:     vehicleType,
+		SeatCapacity:    int32(seatCapacity),
+		Rider:           sharedRiderToProto(rider),
+		CurrentLocation: locationToProto(location),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sharedTripFromProto(resp.SharedTrip), nil
+}
+
+// AddSharedRider inserts a rider's stops into a shared trip
+func (c *GRPCSharedTripClient) AddSharedRider(ctx context.Context, sharedTripID string, expectedVersion int, rider *service.SharedRider, pickupIndex, dropoffIndex int) (*service.SharedTrip, error) {
+	resp, err := c.client.AddSharedRider(ctx, &trippb.AddSharedRiderRequest{
+		SharedTripId:    sharedTripID,
+		ExpectedVersion: int32(expectedVersion),
+		Rider:           sharedRiderToProto(rider),
+		PickupIndex:     int32(pickupIndex),
+		DropoffIndex:    int32(dropoffIndex),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sharedTripFromProto(resp.SharedTrip), nil
+}
+
+func sharedTripFromProto(trip *trippb.SharedTrip) *service.SharedTrip {
+	if trip == nil {
+		return nil
+	}
+
+	sharedTrip := &service.SharedTrip{
+		ID:              trip.Id,
+		DriverID:        trip.DriverId,
+		VehicleType:     trip.VehicleType,
+		SeatCapacity:    int(trip.SeatCapacity),
+		SeatsOccupied:   int(trip.SeatsOccupied),
+		Version:         int(trip.Version),
+		CurrentLocation: locationFromProto(trip.CurrentLocation),
+	}
+	for _, stop := range trip.Stops {
+		sharedTrip.Stops = append(sharedTrip.Stops, &service.SharedTripStop{
+			ID:        stop.Id,
+			TripID:    stop.TripId,
+			RiderID:   stop.RiderId,
+			Type:      stop.Type,
+			Location:  locationFromProto(stop.Location),
+			Completed: stop.Status == "completed",
+		})
+	}
+	for _, rider := range trip.Riders {
+		sharedTrip.Riders = append(sharedTrip.Riders, &service.SharedRider{
+			TripID:           rider.TripId,
+			RiderID:          rider.RiderId,
+			PickupLocation:   locationFromProto(rider.PickupLocation),
+			Destination:      locationFromProto(rider.Destination),
+			PassengerCount:   int(rider.PassengerCount),
+			MaxDetourMinutes: int(rider.MaxDetourMinutes),
+			Fare:             rider.Fare,
+		})
+	}
+	return sharedTrip
+}
+
+func sharedRiderToProto(rider *service.SharedRider) *trippb.SharedRider {
+	return &trippb.SharedRider{
+		TripId:           rider.TripID,
+		RiderId:          rider.RiderID,
+		PickupLocation:   locationToProto(rider.PickupLocation),
+		Destination:      locationToProto(rider.Destination),
+		PassengerCount:   int32(rider.PassengerCount),
+		MaxDetourMinutes: int32(rider.MaxDetourMinutes),
+		Fare:             rider.Fare,
+	}
+}
+
+func locationFromProto(location *trippb.Location) *models.Location {
+	if location == nil {
+		return nil
+	}
+	return &models.Location{
+		Latitude:  location.Latitude,
+		Longitude: location.Longitude,
+	}
+}
+
+func locationToProto(location *models.Location) *trippb.Location {
+	if location == nil {
+		return nil
+	}
+	return &trippb.Location{
+		Latitude:  location.Latitude,
+		Longitude: location.Longitude,
+	}
+}
@@ -0,0 +1,41 @@
+package client
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+
+	"github.com/rideshare-platform/services/matching-service/internal/service"
+	userpb "github.com/rideshare-platform/shared/proto/user"
+)
+
+// GRPCUserClient looks up driver profiles through user-service's gRPC API
+type GRPCUserClient struct {
+	client userpb.UserServiceClient
+}
+
+// NewGRPCUserClient creates a new user client
+func NewGRPCUserClient(conn grpc.ClientConnInterface) *GRPCUserClient {
+	return &GRPCUserClient{client: userpb.NewUserServiceClient(conn)}
+}
+
+// GetDriverProfiles returns the public profiles of the given drivers
+func (c *GRPCUserClient) GetDriverProfiles(ctx context.Context, driverIDs []string) (map[string]*service.DriverProfile, error) {
+	resp, err := c.client.GetDriverProfiles(ctx, &userpb.GetDriverProfilesRequest{DriverIds: driverIDs})
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := make(map[string]*service.DriverProfile, len(resp.Profiles))
+	for driverID, profile := range resp.Profiles {
+		profiles[driverID] = &service.DriverProfile{
+			DriverID:  driverID,
+			Name:      strings.TrimSpace(profile.FirstName + " " + profile.LastName),
+			PhotoURL:  profile.PhotoUrl,
+			Rating:    profile.Rating,
+			TripCount: int(profile.TotalTrips),
+		}
+	}
+	return profiles, nil
+}
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/rideshare-platform/services/matching-service/internal/service"
+	vehiclepb "github.com/rideshare-platform/shared/proto/vehicle"
+)
+
+// GRPCVehicleClient looks up drivers' vehicles through vehicle-service's gRPC API
+type GRPCVehicleClient struct {
+	client vehiclepb.VehicleServiceClient
+}
+
+// NewGRPCVehicleClient creates a new vehicle client
+func NewGRPCVehicleClient(conn grpc.ClientConnInterface) *GRPCVehicleClient {
+	return &GRPCVehicleClient{client: vehiclepb.NewVehicleServiceClient(conn)}
+}
+
+// GetDriverVehicles returns the available vehicles of the given drivers, newest first
+func (c *GRPCVehicleClient) GetDriverVehicles(ctx context.Context, driverIDs []string) (map[string][]*service.DriverVehicle, error) {
+	resp, err := c.client.GetAvailableVehiclesByDrivers(ctx, &vehiclepb.GetAvailableVehiclesByDriversRequest{DriverIds: driverIDs})
+	if err != nil {
+		return nil, err
+	}
+
+	vehicles := make(map[string][]*service.DriverVehicle)
+	for _, vehicle := range resp.Vehicles {
+		vehicles[vehicle.DriverId] = append(vehicles[vehicle.DriverId], &service.DriverVehicle{
+			VehicleID: vehicle.Id,
+			Details: service.VehicleDetails{
+				Make:         vehicle.Make,
+				Model:        vehicle.Model,
+				Year:         int(vehicle.Year),
+				Color:        vehicle.Color,
+				LicensePlate: vehicle.LicensePlate,
+				VehicleType:  vehicle.VehicleType,
+				Capacity:     int(vehicle.Capacity),
+				Features:     vehicle.Features,
+			},
+			PhotoURL: vehicle.PhotoUrl,
+		})
+	}
+	return vehicles, nil
+}
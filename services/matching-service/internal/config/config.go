@@ -1,8 +1,13 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"strconv"
+	"time"
+
+	sharedconfig "github.com/rideshare-platform/shared/config"
+	sharedtls "github.com/rideshare-platform/shared/tls"
 )
 
 // Config holds all configuration for the matching service
@@ -11,6 +16,14 @@ type Config struct {
 	Environment string
 	LogLevel    string
 
+	// Bearer tokens on gRPC calls are validated against JWTSecret when it is
+	// set. GRPCAuthRequired also rejects calls without one.
+	JWTSecret        string
+	GRPCAuthRequired bool
+
+	// Mutual TLS for gRPC traffic between services
+	TLS sharedtls.Config
+
 	// Database config
 	DatabaseHost     string
 	DatabasePort     int
@@ -18,35 +31,92 @@ type Config struct {
 	DatabaseUser     string
 	DatabasePassword string
 
+	// Hourly matching rollups are kept in PostgreSQL when AnalyticsDatabaseURL
+	// is set, in memory otherwise
+	AnalyticsDatabaseURL  string
+	AnalyticsFlushSeconds int
+	MigrateOnStartup      bool
+
+	// Every step taken to match each trip is logged in PostgreSQL for support
+	// review when DatabaseURL is set, in memory otherwise
+	DatabaseURL string
+
 	// MongoDB config
 	MongoURI      string
 	MongoDatabase string
 
-	// Redis config
-	RedisHost     string
-	RedisPort     int
-	RedisPassword string
-	RedisDatabase int
+	// Offers, the matching queue and driver reservations are kept in Redis
+	// so every replica sees them when StateStore is "redis", in memory when
+	// it is "memory"
+	StateStore string
+	Redis      *sharedconfig.RedisConfig
+
+	// Downstream services
+	TripServiceAddr    string
+	PricingServiceAddr string
+	GeoServiceAddr     string
+	GeoServiceTimeout  int  // ms allowed per geo-service call
+	GeoMockMode        bool // match against generated drivers instead of geo-service, for local dev
+	UserServiceAddr    string
+	VehicleServiceAddr string
+	DriverProfileTTL   int // seconds driver profiles and vehicles are cached for
 
 	// Matching algorithm parameters
 	MaxSearchRadius       float64 // km
 	MaxMatchingTimeout    int     // seconds
 	MaxDriversToConsider  int     // number of drivers
 	DriverResponseTimeout int     // seconds
+	OfferSweepInterval    int     // seconds between offer expiry checks
 	PriorityBoostRadius   float64 // km
 	PremiumPriorityBoost  float64 // multiplier
 	MaxConcurrentMatches  int     // concurrent processing limit
+	MatchQueueSize        int     // requests waiting for a match worker before new ones are shed
+	MatchQueueTimeoutMs   int     // ms a request waits for a match worker before it is shed
+	MatchBatchWindowMs    int     // ms requests with nearby pickups are collected for to be matched together, 0 disables batching
 	MatchingRetryAttempts int     // retry attempts
 	MatchingRetryDelayMs  int     // ms between retries
+
+	// Feature flags, shared with the other services through Redis
+	FlagsRedisAddr       string // Redis feature flags are read from, flags are not used without it
+	FlagsRefreshInterval int    // seconds between feature flag reloads, besides those on change
+
+	// ExperimentsFile lists the A/B experiments match requests are assigned
+	// to, shared with pricing-service. No experiments run without it.
+	ExperimentsFile string
+
+	// Per-city matching configs are kept with the attempt logs and reloaded
+	// to pick up changes saved through other instances
+	MatchingConfigRefreshInterval int // seconds between matching config reloads
+
+	// Drivers' offer answers count half as much towards their acceptance
+	// rate after this many hours
+	DriverBehaviorHalfLifeHours int
+
+	// Matching queue parameters
+	QueuePollInterval      int     // seconds between queue checks
+	QueueMaxRetryDelayMs   int     // cap on the backoff between retries
+	QueueRadiusStepKm      float64 // km added to the search radius per retry
+	QueueMaxSearchRadiusKm float64 // km
+	QueueRatingRelaxStep   float64 // rating points relaxed per retry
+	QueueUpgradeAfterRetry int     // retry from which larger vehicle types are accepted
 }
 
 // Load loads configuration from environment variables
 func Load() (*Config, error) {
-	return &Config{
+	tlsConfig, err := sharedtls.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{
 		HTTPPort:    getEnv("HTTP_PORT", "8084"),
 		Environment: getEnv("ENVIRONMENT", "development"),
 		LogLevel:    getEnv("LOG_LEVEL", "info"),
 
+		JWTSecret:        getEnv("JWT_SECRET", ""),
+		GRPCAuthRequired: getEnvBool("GRPC_AUTH_REQUIRED", false),
+		TLS:              tlsConfig,
+
 		// Database config
 		DatabaseHost:     getEnv("DB_HOST", "localhost"),
 		DatabasePort:     getEnvInt("DB_PORT", 5432),
@@ -54,32 +124,86 @@ func Load() (*Config, error) {
 		DatabaseUser:     getEnv("DB_USER", "postgres"),
 		DatabasePassword: getEnv("DB_PASSWORD", "postgres"),
 
+		// Analytics rollups
+		AnalyticsDatabaseURL:  getEnv("ANALYTICS_DATABASE_URL", ""),
+		AnalyticsFlushSeconds: getEnvInt("ANALYTICS_FLUSH_SECONDS", 30),
+		MigrateOnStartup:      getEnvBool("MIGRATE_ON_STARTUP", false),
+
+		// Matching attempt logs
+		DatabaseURL: getEnv("DATABASE_URL", ""),
+
 		// MongoDB config
 		MongoURI:      getEnv("MONGO_URI", "mongodb://localhost:27017"),
 		MongoDatabase: getEnv("MONGO_DB", "rideshare"),
 
 		// Redis config
-		RedisHost:     getEnv("REDIS_HOST", "localhost"),
-		RedisPort:     getEnvInt("REDIS_PORT", 6379),
-		RedisPassword: getEnv("REDIS_PASSWORD", ""),
-		RedisDatabase: getEnvInt("REDIS_DB", 0),
+		StateStore: getEnv("MATCHING_STATE_STORE", "redis"),
+		Redis: &sharedconfig.RedisConfig{
+			Host:         getEnv("REDIS_HOST", "localhost"),
+			Port:         getEnvInt("REDIS_PORT", 6379),
+			Password:     getEnv("REDIS_PASSWORD", ""),
+			Database:     getEnvInt("REDIS_DB", 0),
+			PoolSize:     getEnvInt("REDIS_POOL_SIZE", 50),
+			MinIdleConns: getEnvInt("REDIS_MIN_IDLE_CONNS", 5),
+			DialTimeout:  5 * time.Second,
+			ReadTimeout:  3 * time.Second,
+			WriteTimeout: 3 * time.Second,
+			IdleTimeout:  5 * time.Minute,
+		},
+
+		// Downstream services
+		TripServiceAddr:    getEnv("TRIP_SERVICE_ADDR", "trip-service:50053"),
+		PricingServiceAddr: getEnv("PRICING_SERVICE_ADDR", "pricing-service:50053"),
+		GeoServiceAddr:     getEnv("GEO_SERVICE_ADDR", "geo-service:50053"),
+		GeoServiceTimeout:  getEnvInt("GEO_SERVICE_TIMEOUT_MS", 2000),
+		GeoMockMode:        getEnvBool("GEO_MOCK_MODE", false),
+		UserServiceAddr:    getEnv("USER_SERVICE_ADDR", "user-service:50051"),
+		VehicleServiceAddr: getEnv("VEHICLE_SERVICE_ADDR", "vehicle-service:50052"),
+		DriverProfileTTL:   getEnvInt("DRIVER_PROFILE_TTL", 300),
 
 		// Matching parameters
 		MaxSearchRadius:       getEnvFloat("MAX_SEARCH_RADIUS", 10.0),
 		MaxMatchingTimeout:    getEnvInt("MAX_MATCHING_TIMEOUT", 30),
 		MaxDriversToConsider:  getEnvInt("MAX_DRIVERS_TO_CONSIDER", 20),
 		DriverResponseTimeout: getEnvInt("DRIVER_RESPONSE_TIMEOUT", 30),
+		OfferSweepInterval:    getEnvInt("OFFER_SWEEP_INTERVAL", 2),
 		PriorityBoostRadius:   getEnvFloat("PRIORITY_BOOST_RADIUS", 2.0),
 		PremiumPriorityBoost:  getEnvFloat("PREMIUM_PRIORITY_BOOST", 1.5),
 		MaxConcurrentMatches:  getEnvInt("MAX_CONCURRENT_MATCHES", 100),
+		MatchQueueSize:        getEnvInt("MATCH_QUEUE_SIZE", 500),
+		MatchQueueTimeoutMs:   getEnvInt("MATCH_QUEUE_TIMEOUT_MS", 2000),
+		MatchBatchWindowMs:    getEnvInt("MATCH_BATCH_WINDOW_MS", 2000),
 		MatchingRetryAttempts: getEnvInt("MATCHING_RETRY_ATTEMPTS", 3),
 		MatchingRetryDelayMs:  getEnvInt("MATCHING_RETRY_DELAY_MS", 1000),
-	}, nil
+
+		// Feature flags
+		FlagsRedisAddr:       getEnv("FLAGS_REDIS_ADDR", ""),
+		FlagsRefreshInterval: getEnvInt("FLAGS_REFRESH_INTERVAL", 30),
+
+		ExperimentsFile: getEnv("EXPERIMENTS_FILE", ""),
+
+		MatchingConfigRefreshInterval: getEnvInt("MATCHING_CONFIG_REFRESH_INTERVAL", 30),
+		DriverBehaviorHalfLifeHours:   getEnvInt("DRIVER_BEHAVIOR_HALF_LIFE_HOURS", 336),
+
+		// Matching queue parameters
+		QueuePollInterval:      getEnvInt("QUEUE_POLL_INTERVAL", 1),
+		QueueMaxRetryDelayMs:   getEnvInt("QUEUE_MAX_RETRY_DELAY_MS", 15000),
+		QueueRadiusStepKm:      getEnvFloat("QUEUE_RADIUS_STEP_KM", 5.0),
+		QueueMaxSearchRadiusKm: getEnvFloat("QUEUE_MAX_SEARCH_RADIUS_KM", 40.0),
+		QueueRatingRelaxStep:   getEnvFloat("QUEUE_RATING_RELAX_STEP", 0.25),
+		QueueUpgradeAfterRetry: getEnvInt("QUEUE_UPGRADE_AFTER_RETRY", 2),
+	}
+	if err := sharedconfig.LoadRedisTopology(cfg.Redis); err != nil {
+		return nil, err
+	}
+	return cfg, nil
 }
 
 // Validate validates the configuration
 func (c *Config) Validate() error {
-	// Add validation logic here if needed
+	if c.StateStore != "memory" && c.StateStore != "redis" {
+		return fmt.Errorf("MATCHING_STATE_STORE must be memory or redis, got %q", c.StateStore)
+	}
 	return nil
 }
 
@@ -110,3 +234,13 @@ func getEnvFloat(key string, defaultValue float64) float64 {
 	}
 	return defaultValue
 }
+
+// getEnvBool gets an environment variable as bool with a default value
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := strconv.ParseBool(value); err == nil {
+			return parsed
+		}
+	}
+	return defaultValue
+}
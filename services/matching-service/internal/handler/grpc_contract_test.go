@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"testing"
+
+	"google.golang.org/grpc"
+
+	"github.com/rideshare-platform/services/matching-service/internal/config"
+	"github.com/rideshare-platform/services/matching-service/internal/service"
+	"github.com/rideshare-platform/shared/clients/contract"
+	"github.com/rideshare-platform/shared/clients/matchingclient"
+	matchingpb "github.com/rideshare-platform/shared/proto/matching"
+)
+
+func TestGRPCMatchingHandler_ServesContract(t *testing.T) {
+	grpcHandler := NewGRPCMatchingHandler(
+		service.NewSimpleMatchingService(&config.Config{}),
+		service.NewOfferBroadcaster(),
+		service.NewProgressBroadcaster(),
+	)
+
+	conn := contract.Serve(t, func(server *grpc.Server) {
+		matchingpb.RegisterMatchingServiceServer(server, grpcHandler)
+	})
+	contract.Check(t, matchingclient.ContractCalls(conn),
+		// Driver locations are kept by geo-service; these are not served yet
+		"FindNearbyDrivers",
+		"UpdateDriverLocation",
+		"GetDriver",
+		"GetActiveDrivers",
+		"BatchUpdateDrivers",
+		"StreamDriverUpdates",
+		"GetMatchingStats",
+	)
+}
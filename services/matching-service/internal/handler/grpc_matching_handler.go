@@ -0,0 +1,490 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/rideshare-platform/services/matching-service/internal/admission"
+	"github.com/rideshare-platform/services/matching-service/internal/service"
+	"github.com/rideshare-platform/shared/models"
+	matchingpb "github.com/rideshare-platform/shared/proto/matching"
+)
+
+// GRPCMatchingHandler handles gRPC requests for the matching service
+type GRPCMatchingHandler struct {
+	matchingpb.UnimplementedMatchingServiceServer
+	service     MatchingServiceInterface
+	broadcaster *service.OfferBroadcaster
+	progress    *service.ProgressBroadcaster
+}
+
+// NewGRPCMatchingHandler creates a new gRPC matching handler
+func NewGRPCMatchingHandler(service MatchingServiceInterface, broadcaster *service.OfferBroadcaster, progress *service.ProgressBroadcaster) *GRPCMatchingHandler {
+	return &GRPCMatchingHandler{
+		service:     service,
+		broadcaster: broadcaster,
+		progress:    progress,
+	}
+}
+
+// MatchDriver starts matching for a ride request. Scheduled rides are
+// pre-dispatched here by trip-service ahead of their pickup time.
+func (h *GRPCMatchingHandler) MatchDriver(ctx context.Context, req *matchingpb.MatchDriverRequest) (*matchingpb.MatchDriverResponse, error) {
+	ride := req.RideRequest
+	if ride == nil || ride.Id == "" || ride.RiderId == "" {
+		return nil, status.Error(codes.InvalidArgument, "ride_request with id and rider_id is required")
+	}
+	if ride.PickupLocation == nil || ride.Destination == nil {
+		return nil, status.Error(codes.InvalidArgument, "pickup_location and destination are required")
+	}
+
+	result, err := h.service.FindMatch(ctx, matchingRequestFromProto(req))
+	if retryAfter, overloaded := admission.RetryAfter(err); overloaded {
+		// Soft-fail with a hint of when to retry, carried in the retry-after header
+		_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(int(retryAfter.Seconds()))))
+		return &matchingpb.MatchDriverResponse{
+			Success: false,
+			Message: "Matching is over capacity, retry later",
+			Errors:  []string{err.Error()},
+		}, nil
+	}
+	if err != nil {
+		return &matchingpb.MatchDriverResponse{
+			Success: false,
+			Message: "Matching failed",
+			Errors:  []string{err.Error()},
+		}, nil
+	}
+
+	resp := &matchingpb.MatchDriverResponse{
+		Result: &matchingpb.MatchResult{
+			RequestId: ride.Id,
+			Success:   result.Success,
+			Message:   result.Reason,
+		},
+		Success: result.Success || result.Queued,
+		Message: result.Reason,
+	}
+	if result.MatchedDriver != nil {
+		driver := driverToProto(result.MatchedDriver)
+		resp.Result.BestMatch = driver
+		resp.Result.MatchedDrivers = append(resp.Result.MatchedDrivers, driver)
+	}
+	for _, alternative := range result.AlternativeOptions {
+		resp.Result.MatchedDrivers = append(resp.Result.MatchedDrivers, driverToProto(alternative))
+	}
+	if result.Queued && resp.Message == "" {
+		resp.Message = "Matching queued"
+	}
+	return resp, nil
+}
+
+// AcceptOffer confirms a driver for a trip
+func (h *GRPCMatchingHandler) AcceptOffer(ctx context.Context, req *matchingpb.AcceptOfferRequest) (*matchingpb.AcceptOfferResponse, error) {
+	if req.TripId == "" || req.DriverId == "" {
+		return nil, status.Error(codes.InvalidArgument, "trip_id and driver_id are required")
+	}
+
+	offer, err := h.service.AcceptOffer(ctx, req.TripId, req.DriverId)
+	if err != nil {
+		return nil, offerStatusError(err)
+	}
+
+	return &matchingpb.AcceptOfferResponse{
+		Offer:   offerToProto(offer),
+		Success: true,
+		Message: "Offer accepted",
+	}, nil
+}
+
+// DeclineOffer declines a trip offer and returns the offer made to the next driver
+func (h *GRPCMatchingHandler) DeclineOffer(ctx context.Context, req *matchingpb.DeclineOfferRequest) (*matchingpb.DeclineOfferResponse, error) {
+	if req.TripId == "" || req.DriverId == "" {
+		return nil, status.Error(codes.InvalidArgument, "trip_id and driver_id are required")
+	}
+
+	next, err := h.service.DeclineOffer(ctx, req.TripId, req.DriverId, req.Reason)
+	if err != nil {
+		return nil, offerStatusError(err)
+	}
+
+	return &matchingpb.DeclineOfferResponse{
+		NextOffer: offerToProto(next),
+		Success:   true,
+		Message:   "Offer declined",
+	}, nil
+}
+
+// ReleaseDriverReservation frees a driver held for a trip
+func (h *GRPCMatchingHandler) ReleaseDriverReservation(ctx context.Context, req *matchingpb.ReleaseDriverReservationRequest) (*matchingpb.ReleaseDriverReservationResponse, error) {
+	if req.DriverId == "" {
+		return nil, status.Error(codes.InvalidArgument, "driver_id is required")
+	}
+
+	reservation, err := h.service.ReleaseDriverReservation(ctx, req.DriverId)
+	if errors.Is(err, service.ErrReservationNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, offerStatusError(err)
+	}
+
+	return &matchingpb.ReleaseDriverReservationResponse{
+		DriverId: reservation.DriverID,
+		TripId:   reservation.TripID,
+	}, nil
+}
+
+// GetMatchingAttemptLog returns every step taken to match a trip
+func (h *GRPCMatchingHandler) GetMatchingAttemptLog(ctx context.Context, req *matchingpb.GetMatchingAttemptLogRequest) (*matchingpb.GetMatchingAttemptLogResponse, error) {
+	if req.TripId == "" {
+		return nil, status.Error(codes.InvalidArgument, "trip_id is required")
+	}
+
+	log, err := h.service.GetAttemptLog(ctx, req.TripId)
+	if errors.Is(err, service.ErrAttemptLogNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &matchingpb.GetMatchingAttemptLogResponse{
+		TripId:    log.TripID,
+		RiderId:   log.RiderID,
+		Outcome:   string(log.Outcome),
+		StartedAt: timestamppb.New(log.StartedAt),
+	}
+	for _, progress := range log.Events {
+		resp.Events = append(resp.Events, progressToProto(progress))
+	}
+	if log.FinishedAt != nil {
+		resp.FinishedAt = timestamppb.New(*log.FinishedAt)
+	}
+	return resp, nil
+}
+
+// ListMatchingConfigs returns the config of every city with its own
+func (h *GRPCMatchingHandler) ListMatchingConfigs(ctx context.Context, req *matchingpb.ListMatchingConfigsRequest) (*matchingpb.ListMatchingConfigsResponse, error) {
+	configs, err := h.service.ListMatchingConfigs(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &matchingpb.ListMatchingConfigsResponse{}
+	for _, config := range configs {
+		resp.Configs = append(resp.Configs, matchingConfigToProto(config))
+	}
+	return resp, nil
+}
+
+// GetMatchingConfig returns the config a city is matched with, or a past version of it
+func (h *GRPCMatchingHandler) GetMatchingConfig(ctx context.Context, req *matchingpb.GetMatchingConfigRequest) (*matchingpb.MatchingConfig, error) {
+	if req.City == "" {
+		return nil, status.Error(codes.InvalidArgument, "city is required")
+	}
+
+	config, err := h.service.GetMatchingConfig(ctx, req.City, req.Version)
+	if err != nil {
+		return nil, matchingConfigStatusError(err)
+	}
+	return matchingConfigToProto(config), nil
+}
+
+// SaveMatchingConfig saves a city's config as its next version
+func (h *GRPCMatchingHandler) SaveMatchingConfig(ctx context.Context, req *matchingpb.SaveMatchingConfigRequest) (*matchingpb.MatchingConfig, error) {
+	if req.Config == nil || req.Config.City == "" {
+		return nil, status.Error(codes.InvalidArgument, "config with city is required")
+	}
+
+	config, err := h.service.SaveMatchingConfig(ctx, matchingConfigFromProto(req.Config))
+	if err != nil {
+		return nil, matchingConfigStatusError(err)
+	}
+	return matchingConfigToProto(config), nil
+}
+
+// DeleteMatchingConfig removes a city's config, matching it with the default again
+func (h *GRPCMatchingHandler) DeleteMatchingConfig(ctx context.Context, req *matchingpb.DeleteMatchingConfigRequest) (*matchingpb.DeleteMatchingConfigResponse, error) {
+	if req.City == "" {
+		return nil, status.Error(codes.InvalidArgument, "city is required")
+	}
+
+	if err := h.service.DeleteMatchingConfig(ctx, req.City); err != nil {
+		return nil, matchingConfigStatusError(err)
+	}
+	return &matchingpb.DeleteMatchingConfigResponse{Success: true}, nil
+}
+
+// StreamDriverOffers streams offer updates for a driver until the client disconnects
+func (h *GRPCMatchingHandler) StreamDriverOffers(req *matchingpb.StreamDriverOffersRequest, stream matchingpb.MatchingService_StreamDriverOffersServer) error {
+	if req.DriverId == "" {
+		return status.Error(codes.InvalidArgument, "driver_id is required")
+	}
+	if h.broadcaster == nil {
+		return status.Error(codes.Unavailable, "offer streaming is not enabled")
+	}
+
+	offers, unsubscribe := h.broadcaster.Subscribe(req.DriverId)
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case offer, ok := <-offers:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(offerToProto(offer)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// StreamMatchingProgress streams the steps of matching a trip until the client disconnects
+func (h *GRPCMatchingHandler) StreamMatchingProgress(req *matchingpb.StreamMatchingProgressRequest, stream matchingpb.MatchingService_StreamMatchingProgressServer) error {
+	if req.TripId == "" {
+		return status.Error(codes.InvalidArgument, "trip_id is required")
+	}
+	if h.progress == nil {
+		return status.Error(codes.Unavailable, "progress streaming is not enabled")
+	}
+
+	updates, unsubscribe := h.progress.Subscribe(req.TripId)
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case progress, ok := <-updates:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(progressToProto(progress)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// offerStatusError maps offer errors to gRPC status codes
+func offerStatusError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrOfferNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrOfferNotForDriver):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrOfferNotPending), errors.Is(err, service.ErrOfferExpired):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// offerToProto converts a driver offer to its protobuf representation
+func offerToProto(offer *service.DriverOffer) *matchingpb.DriverOffer {
+	if offer == nil {
+		return nil
+	}
+
+	pb := &matchingpb.DriverOffer{
+		OfferId:        offer.OfferID,
+		TripId:         offer.TripID,
+		RiderId:        offer.RiderID,
+		DriverId:       offer.DriverID(),
+		PickupLocation: locationToProto(offer.PickupLocation),
+		Destination:    locationToProto(offer.Destination),
+		Status:         string(offer.Status),
+		Attempt:        int32(offer.Attempt),
+		OfferedAt:      timestamppb.New(offer.OfferedAt),
+		ExpiresAt:      timestamppb.New(offer.ExpiresAt),
+	}
+	if offer.Driver != nil {
+		pb.EtaSeconds = int32(offer.Driver.ETA)
+		pb.DistanceKm = offer.Driver.Distance
+	}
+	if offer.EstimatedFare != nil {
+		pb.EstimatedFare = offer.EstimatedFare.TotalEstimate
+	}
+
+	return pb
+}
+
+// matchingConfigStatusError maps a matching config error to its gRPC status
+func matchingConfigStatusError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrMatchingConfigNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrInvalidMatchingConfig):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// matchingConfigToProto converts a matching config to its protobuf representation
+func matchingConfigToProto(config *service.MatchingConfig) *matchingpb.MatchingConfig {
+	pb := &matchingpb.MatchingConfig{
+		City:                config.City,
+		Version:             config.Version,
+		DistanceWeight:      config.DistanceWeight,
+		EtaWeight:           config.ETAWeight,
+		RatingWeight:        config.RatingWeight,
+		AvailabilityWeight:  config.AvailabilityWeight,
+		AcceptanceWeight:    config.AcceptanceWeight,
+		CancellationWeight:  config.CancellationWeight,
+		StreakWeight:        config.StreakWeight,
+		StreakTarget:        int32(config.StreakTarget),
+		ScoreDistanceCapKm:  config.ScoreDistanceCapKm,
+		ScoreEtaCapSeconds:  int32(config.ScoreETACapSeconds),
+		InitialRadiusKm:     config.InitialRadiusKm,
+		RadiusStepKm:        config.RadiusStepKm,
+		MaxRadiusKm:         config.MaxRadiusKm,
+		MaxPickupDistanceKm: config.MaxPickupDistanceKm,
+		MinCandidates:       int32(config.MinCandidates),
+		UpdatedBy:           config.UpdatedBy,
+	}
+	if !config.UpdatedAt.IsZero() {
+		pb.UpdatedAt = timestamppb.New(config.UpdatedAt)
+	}
+	return pb
+}
+
+// matchingConfigFromProto converts a protobuf matching config to the service's
+func matchingConfigFromProto(pb *matchingpb.MatchingConfig) *service.MatchingConfig {
+	return &service.MatchingConfig{
+		City:                pb.City,
+		DistanceWeight:      pb.DistanceWeight,
+		ETAWeight:           pb.EtaWeight,
+		RatingWeight:        pb.RatingWeight,
+		AvailabilityWeight:  pb.AvailabilityWeight,
+		AcceptanceWeight:    pb.AcceptanceWeight,
+		CancellationWeight:  pb.CancellationWeight,
+		StreakWeight:        pb.StreakWeight,
+		StreakTarget:        int(pb.StreakTarget),
+		ScoreDistanceCapKm:  pb.ScoreDistanceCapKm,
+		ScoreETACapSeconds:  int(pb.ScoreEtaCapSeconds),
+		InitialRadiusKm:     pb.InitialRadiusKm,
+		RadiusStepKm:        pb.RadiusStepKm,
+		MaxRadiusKm:         pb.MaxRadiusKm,
+		MaxPickupDistanceKm: pb.MaxPickupDistanceKm,
+		MinCandidates:       int(pb.MinCandidates),
+		UpdatedBy:           pb.UpdatedBy,
+	}
+}
+
+// progressToProto converts matching progress to its protobuf representation
+func progressToProto(progress *service.MatchingProgress) *matchingpb.MatchingProgress {
+	pb := &matchingpb.MatchingProgress{
+		TripId:         progress.TripID,
+		RiderId:        progress.RiderID,
+		Status:         string(progress.Status),
+		Retries:        int32(progress.Retries),
+		SearchRadiusKm: progress.SearchRadiusKm,
+		Message:        progress.Message,
+		DriverId:       progress.DriverID,
+		OfferId:        progress.OfferID,
+		UpdatedAt:      timestamppb.New(progress.UpdatedAt),
+		Event:          string(progress.Event),
+		Attempt:        int32(progress.Attempt),
+	}
+	if progress.NextAttemptAt != nil {
+		pb.NextAttemptAt = timestamppb.New(*progress.NextAttemptAt)
+	}
+	if progress.Deadline != nil {
+		pb.Deadline = timestamppb.New(*progress.Deadline)
+	}
+	return pb
+}
+
+// matchingRequestFromProto converts a gRPC match request to a matching request
+func matchingRequestFromProto(req *matchingpb.MatchDriverRequest) *service.MatchingRequest {
+	ride := req.RideRequest
+	request := &service.MatchingRequest{
+		TripID:         ride.Id,
+		RiderID:        ride.RiderId,
+		PickupLocation: locationFromProto(ride.PickupLocation),
+		Destination:    locationFromProto(ride.Destination),
+		PassengerCount: int(ride.PassengerCount),
+		VehicleType:    ride.VehicleType,
+		RequestedAt:    time.Now(),
+		City:           ride.City,
+		PriorityLevel:  int(ride.PriorityLevel),
+	}
+	if ride.RequestedAt != nil {
+		request.RequestedAt = ride.RequestedAt.AsTime()
+	}
+	if ride.ScheduledFor != nil {
+		scheduledFor := ride.ScheduledFor.AsTime()
+		request.ScheduledFor = &scheduledFor
+	}
+	if prefs := req.Preferences; prefs != nil {
+		request.Preferences = &service.RiderPreferences{
+			MinDriverRating:    prefs.MinDriverRating,
+			AllowSharedRides:   prefs.AllowPoolMatching,
+			AccessibilityNeeds: prefs.AccessibilityNeeds,
+		}
+	}
+	return request
+}
+
+// driverToProto converts a matched driver to its protobuf representation
+func driverToProto(driver *service.MatchedDriverInfo) *matchingpb.Driver {
+	pb := &matchingpb.Driver{
+		Id:              driver.DriverID,
+		VehicleId:       driver.VehicleID,
+		CurrentLocation: locationToProto(driver.CurrentLocation),
+		Rating:          driver.Rating,
+		TotalTrips:      int32(driver.TripCount),
+		DistanceKm:      driver.Distance,
+		EtaMinutes:      int32((driver.ETA + 59) / 60),
+		Score:           &matchingpb.MatchingScore{TotalScore: driver.MatchScore},
+		Name:            driver.DriverName,
+		PhotoUrl:        driver.DriverPhoto,
+	}
+	if vehicle := driver.VehicleInfo; vehicle != nil {
+		pb.VehicleType = vehicle.VehicleType
+		if vehicle.Make != "" || vehicle.LicensePlate != "" {
+			pb.Vehicle = &matchingpb.Vehicle{
+				Make:         vehicle.Make,
+				Model:        vehicle.Model,
+				Year:         int32(vehicle.Year),
+				Color:        vehicle.Color,
+				LicensePlate: vehicle.LicensePlate,
+				Capacity:     int32(vehicle.Capacity),
+				Features:     vehicle.Features,
+				PhotoUrl:     driver.VehiclePhoto,
+			}
+		}
+	}
+	return pb
+}
+
+func locationFromProto(location *matchingpb.Location) *models.Location {
+	if location == nil {
+		return nil
+	}
+	return &models.Location{
+		Latitude:  location.Latitude,
+		Longitude: location.Longitude,
+	}
+}
+
+func locationToProto(location *models.Location) *matchingpb.Location {
+	if location == nil {
+		return nil
+	}
+	return &matchingpb.Location{
+		Latitude:  location.Latitude,
+		Longitude: location.Longitude,
+	}
+}
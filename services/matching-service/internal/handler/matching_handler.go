@@ -2,32 +2,56 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/rideshare-platform/services/matching-service/internal/admission"
 	"github.com/rideshare-platform/services/matching-service/internal/service"
+	"github.com/rideshare-platform/shared/analytics"
+	"github.com/rideshare-platform/shared/experiments"
+	"github.com/rideshare-platform/shared/health"
 )
 
 // MatchingServiceInterface defines the interface for matching services
 type MatchingServiceInterface interface {
 	FindMatch(ctx context.Context, request *service.MatchingRequest) (*service.MatchingResult, error)
 	CancelMatching(ctx context.Context, tripID string) error
-	GetMatchingMetrics(ctx context.Context) (map[string]interface{}, error)
+	GetMatchingMetrics(ctx context.Context, tr analytics.TimeRange) (*service.MatchingMetrics, error)
+	GetExperimentMetrics(ctx context.Context, experiment string, tr analytics.TimeRange) (*service.ExperimentMetrics, error)
 	GetMatchingStatus(ctx context.Context, tripID string) (map[string]interface{}, error)
+	GetAttemptLog(ctx context.Context, tripID string) (*service.AttemptLog, error)
+	GetOffer(ctx context.Context, tripID string) (*service.DriverOffer, error)
+	AcceptOffer(ctx context.Context, tripID, driverID string) (*service.DriverOffer, error)
+	DeclineOffer(ctx context.Context, tripID, driverID, reason string) (*service.DriverOffer, error)
+	ReleaseDriverReservation(ctx context.Context, driverID string) (*service.DriverReservation, error)
+	ListMatchingConfigs(ctx context.Context) ([]*service.MatchingConfig, error)
+	GetMatchingConfig(ctx context.Context, city string, version int64) (*service.MatchingConfig, error)
+	SaveMatchingConfig(ctx context.Context, config *service.MatchingConfig) (*service.MatchingConfig, error)
+	DeleteMatchingConfig(ctx context.Context, city string) error
 }
 
 // MatchingHandler handles HTTP requests for the matching service
 type MatchingHandler struct {
 	service MatchingServiceInterface
+	health  *health.Checker
 }
 
 // NewMatchingHandler creates a new matching handler
 func NewMatchingHandler(service MatchingServiceInterface) *MatchingHandler {
 	return &MatchingHandler{
 		service: service,
+		health:  health.NewChecker("matching-service", "1.0.0"),
 	}
 }
 
+// SetHealthChecker attaches the checker that probes the service's dependencies
+func (h *MatchingHandler) SetHealthChecker(checker *health.Checker) {
+	h.health = checker
+}
+
 // RegisterRoutes registers all routes for the matching service
 func (h *MatchingHandler) RegisterRoutes(router *gin.Engine) {
 	api := router.Group("/api/v1")
@@ -38,8 +62,14 @@ func (h *MatchingHandler) RegisterRoutes(router *gin.Engine) {
 		// Matching endpoints
 		api.POST("/match", h.findMatch)
 		api.GET("/match/:trip_id/status", h.getMatchingStatus)
+		api.GET("/match/:trip_id/attempts", h.getAttemptLog)
 		api.DELETE("/match/:trip_id", h.cancelMatching)
 
+		// Driver offer endpoints
+		api.GET("/match/:trip_id/offer", h.getOffer)
+		api.POST("/match/:trip_id/offer/accept", h.acceptOffer)
+		api.POST("/match/:trip_id/offer/decline", h.declineOffer)
+
 		// Driver finding endpoints
 		matching := api.Group("/matching")
 		{
@@ -48,16 +78,13 @@ func (h *MatchingHandler) RegisterRoutes(router *gin.Engine) {
 
 		// Metrics
 		api.GET("/metrics", h.getMetrics)
+		api.GET("/metrics/experiments/:name", h.getExperimentMetrics)
 	}
 }
 
 // healthCheck returns the health status of the service
 func (h *MatchingHandler) healthCheck(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
-		"status":  "healthy",
-		"service": "matching-service",
-		"version": "1.0.0",
-	})
+	h.health.Handler().ServeHTTP(c.Writer, c.Request)
 }
 
 // findMatch handles trip matching requests
@@ -80,6 +107,16 @@ func (h *MatchingHandler) findMatch(c *gin.Context) {
 	}
 
 	result, err := h.service.FindMatch(c.Request.Context(), &request)
+	if retryAfter, overloaded := admission.RetryAfter(err); overloaded {
+		seconds := int(retryAfter.Seconds())
+		c.Header("Retry-After", strconv.Itoa(seconds))
+		c.JSON(http.StatusTooManyRequests, gin.H{
+			"error":               "Matching is over capacity",
+			"details":             err.Error(),
+			"retry_after_seconds": seconds,
+		})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to find match",
@@ -90,6 +127,8 @@ func (h *MatchingHandler) findMatch(c *gin.Context) {
 
 	if result.Success {
 		c.JSON(http.StatusOK, result)
+	} else if result.Queued {
+		c.JSON(http.StatusAccepted, result)
 	} else {
 		c.JSON(http.StatusNotFound, result)
 	}
@@ -117,6 +156,27 @@ func (h *MatchingHandler) getMatchingStatus(c *gin.Context) {
 	c.JSON(http.StatusOK, status)
 }
 
+// getAttemptLog returns every step taken to match a trip, for support review
+func (h *MatchingHandler) getAttemptLog(c *gin.Context) {
+	log, err := h.service.GetAttemptLog(c.Request.Context(), c.Param("trip_id"))
+	if errors.Is(err, service.ErrAttemptLogNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Matching attempt log not found",
+			"details": err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to get matching attempt log",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, log)
+}
+
 // cancelMatching cancels an ongoing matching request
 func (h *MatchingHandler) cancelMatching(c *gin.Context) {
 	tripID := c.Param("trip_id")
@@ -142,9 +202,99 @@ func (h *MatchingHandler) cancelMatching(c *gin.Context) {
 	})
 }
 
-// getMetrics returns matching service metrics
+// OfferResponseRequest represents a driver's response to a trip offer
+type OfferResponseRequest struct {
+	DriverID string `json:"driver_id" binding:"required"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// getOffer returns the current driver offer for a trip
+func (h *MatchingHandler) getOffer(c *gin.Context) {
+	offer, err := h.service.GetOffer(c.Request.Context(), c.Param("trip_id"))
+	if err != nil {
+		h.respondOfferError(c, "Failed to get offer", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, offer)
+}
+
+// acceptOffer handles a driver accepting a trip offer
+func (h *MatchingHandler) acceptOffer(c *gin.Context) {
+	var request OfferResponseRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	offer, err := h.service.AcceptOffer(c.Request.Context(), c.Param("trip_id"), request.DriverID)
+	if err != nil {
+		h.respondOfferError(c, "Failed to accept offer", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, offer)
+}
+
+// declineOffer handles a driver declining a trip offer
+func (h *MatchingHandler) declineOffer(c *gin.Context) {
+	var request OfferResponseRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request format",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	next, err := h.service.DeclineOffer(c.Request.Context(), c.Param("trip_id"), request.DriverID, request.Reason)
+	if err != nil {
+		h.respondOfferError(c, "Failed to decline offer", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "Offer declined",
+		"next_offer": next,
+	})
+}
+
+// respondOfferError maps offer errors to HTTP status codes
+func (h *MatchingHandler) respondOfferError(c *gin.Context, message string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, service.ErrOfferNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, service.ErrOfferNotForDriver):
+		status = http.StatusForbidden
+	case errors.Is(err, service.ErrOfferNotPending):
+		status = http.StatusConflict
+	case errors.Is(err, service.ErrOfferExpired):
+		status = http.StatusGone
+	}
+
+	c.JSON(status, gin.H{
+		"error":   message,
+		"details": err.Error(),
+	})
+}
+
+// getMetrics returns matching metrics for the from and to query parameters,
+// by default the last 24 hours
 func (h *MatchingHandler) getMetrics(c *gin.Context) {
-	metrics, err := h.service.GetMatchingMetrics(c.Request.Context())
+	tr, err := analytics.ParseTimeRange(c.Query("from"), c.Query("to"), time.Now())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid time range",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	metrics, err := h.service.GetMatchingMetrics(c.Request.Context(), tr)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "Failed to get metrics",
@@ -156,6 +306,37 @@ func (h *MatchingHandler) getMetrics(c *gin.Context) {
 	c.JSON(http.StatusOK, metrics)
 }
 
+// getExperimentMetrics compares the variants of an experiment over the from
+// and to query parameters, by default the last 24 hours
+func (h *MatchingHandler) getExperimentMetrics(c *gin.Context) {
+	tr, err := analytics.ParseTimeRange(c.Query("from"), c.Query("to"), time.Now())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid time range",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	metrics, err := h.service.GetExperimentMetrics(c.Request.Context(), c.Param("name"), tr)
+	if errors.Is(err, experiments.ErrUnknownExperiment) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "Experiment not found",
+			"details": err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to get experiment metrics",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, metrics)
+}
+
 // FindDriversRequest represents a request to find available drivers
 type FindDriversRequest struct {
 	RiderLocation struct {
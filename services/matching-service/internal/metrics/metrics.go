@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// Admission control
+	matchesInFlight = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "matching_service_matches_in_flight",
+			Help: "Number of match requests currently being worked on",
+		},
+	)
+
+	admissionQueueDepth = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "matching_service_admission_queue_depth",
+			Help: "Number of match requests waiting for a worker, by priority level",
+		},
+		[]string{"priority"},
+	)
+
+	admissionWait = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "matching_service_admission_wait_seconds",
+			Help:    "Time match requests waited for a worker",
+			Buckets: []float64{0.005, 0.01, 0.05, 0.1, 0.25, 0.5, 1, 2, 5},
+		},
+	)
+
+	// Batch matching
+	matchBatchSize = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "matching_service_match_batch_size",
+			Help:    "Number of requests whose drivers were assigned together in a batch",
+			Buckets: []float64{1, 2, 3, 5, 10, 20, 50},
+		},
+	)
+
+	matchesShedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "matching_service_matches_shed_total",
+			Help: "Total number of match requests turned away at capacity, by priority level and reason",
+		},
+		[]string{"priority", "reason"},
+	)
+)
+
+// SetMatchesInFlight sets the number of match requests being worked on
+func SetMatchesInFlight(count int) {
+	matchesInFlight.Set(float64(count))
+}
+
+// SetAdmissionQueueDepth sets the number of waiting requests of a priority level
+func SetAdmissionQueueDepth(priority, depth int) {
+	admissionQueueDepth.WithLabelValues(strconv.Itoa(priority)).Set(float64(depth))
+}
+
+// ObserveAdmissionWait records how long a request waited for a worker
+func ObserveAdmissionWait(wait time.Duration) {
+	admissionWait.Observe(wait.Seconds())
+}
+
+// RecordMatchShed increments the shed requests counter
+func RecordMatchShed(priority int, reason string) {
+	matchesShedTotal.WithLabelValues(strconv.Itoa(priority), reason).Inc()
+}
+
+// ObserveMatchBatchSize records how many requests a batch assigned drivers to
+func ObserveMatchBatchSize(size int) {
+	matchBatchSize.Observe(float64(size))
+}
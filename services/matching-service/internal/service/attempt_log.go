@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrAttemptLogNotFound is returned for trips that were never matched
+var ErrAttemptLogNotFound = errors.New("matching attempt log not found")
+
+// AttemptLog is every step taken to match a trip, kept for support review.
+// It is written as matching goes and is final once it has an outcome.
+type AttemptLog struct {
+	TripID  string `json:"trip_id"`
+	RiderID string `json:"rider_id"`
+	// Outcome is the event that ended matching, empty while the trip is
+	// still matching
+	Outcome    MatchingEvent       `json:"outcome,omitempty"`
+	Events     []*MatchingProgress `json:"events"`
+	StartedAt  time.Time           `json:"started_at"`
+	FinishedAt *time.Time          `json:"finished_at,omitempty"`
+}
+
+// AttemptLogStore keeps the attempt logs of trips
+type AttemptLogStore interface {
+	// Append adds a step to its trip's log. A final step sets the log's
+	// outcome; a later step, as when a trip is matched again, clears it.
+	Append(ctx context.Context, progress *MatchingProgress) error
+	// Get returns a trip's log, ErrAttemptLogNotFound if it has none
+	Get(ctx context.Context, tripID string) (*AttemptLog, error)
+}
+
+// SetAttemptLogStore sets the store the steps of matching each trip are
+// logged in. Without one, steps are only pushed to riders.
+func (s *AdvancedMatchingService) SetAttemptLogStore(store AttemptLogStore) {
+	s.attemptLogs = store
+}
+
+// GetAttemptLog returns the steps taken to match a trip
+func (s *AdvancedMatchingService) GetAttemptLog(ctx context.Context, tripID string) (*AttemptLog, error) {
+	if s.attemptLogs == nil {
+		return nil, ErrAttemptLogNotFound
+	}
+	return s.attemptLogs.Get(ctx, tripID)
+}
+
+// attemptLogStatus describes a logged trip for status lookups by its last step
+func attemptLogStatus(log *AttemptLog) map[string]interface{} {
+	last := log.Events[len(log.Events)-1]
+	attempts := 1
+	for _, progress := range log.Events {
+		if progress.Attempt > attempts {
+			attempts = progress.Attempt
+		}
+	}
+
+	status := map[string]interface{}{
+		"trip_id":    log.TripID,
+		"status":     last.Status,
+		"event":      last.Event,
+		"message":    last.Message,
+		"started_at": log.StartedAt,
+		"updated_at": last.UpdatedAt,
+		"attempts":   attempts,
+	}
+	if last.DriverID != "" && last.Status == QueueStatusMatched {
+		status["matched_driver"] = last.DriverID
+		status["offer_id"] = last.OfferID
+	}
+	if log.FinishedAt != nil {
+		status["finished_at"] = *log.FinishedAt
+	}
+	return status
+}
+
+// outcomeOf returns the outcome and finish time a log has after the step
+func outcomeOf(progress *MatchingProgress) (MatchingEvent, *time.Time) {
+	if !progress.Event.Final() {
+		return "", nil
+	}
+	finishedAt := progress.UpdatedAt
+	return progress.Event, &finishedAt
+}
+
+// PostgresAttemptLogStore keeps attempt logs in the matching_attempt_logs table
+type PostgresAttemptLogStore struct {
+	db *sql.DB
+}
+
+// NewPostgresAttemptLogStore creates an attempt log store backed by PostgreSQL
+func NewPostgresAttemptLogStore(db *sql.DB) *PostgresAttemptLogStore {
+	return &PostgresAttemptLogStore{db: db}
+}
+
+// Append adds a step to its trip's log, creating the log with the trip's first step
+func (s *PostgresAttemptLogStore) Append(ctx context.Context, progress *MatchingProgress) error {
+	event, err := json.Marshal([]*MatchingProgress{progress})
+	if err != nil {
+		return fmt.Errorf("failed to encode matching step: %w", err)
+	}
+	outcome, finishedAt := outcomeOf(progress)
+
+	query := `
+		INSERT INTO matching_attempt_logs (trip_id, rider_id, outcome, events, started_at, finished_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (trip_id) DO UPDATE SET
+			rider_id = COALESCE(NULLIF(matching_attempt_logs.rider_id, ''), EXCLUDED.rider_id),
+			outcome = EXCLUDED.outcome,
+			events = matching_attempt_logs.events || EXCLUDED.events,
+			finished_at = EXCLUDED.finished_at`
+
+	_, err = s.db.ExecContext(ctx, query,
+		progress.TripID, progress.RiderID, outcome, event, progress.UpdatedAt, finishedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to append to matching attempt log: %w", err)
+	}
+	return nil
+}
+
+// Get returns a trip's log
+func (s *PostgresAttemptLogStore) Get(ctx context.Context, tripID string) (*AttemptLog, error) {
+	query := `
+		SELECT trip_id, rider_id, outcome, events, started_at, finished_at
+		FROM matching_attempt_logs
+		WHERE trip_id = $1`
+
+	log := &AttemptLog{}
+	var events []byte
+	var finishedAt sql.NullTime
+	err := s.db.QueryRowContext(ctx, query, tripID).Scan(
+		&log.TripID, &log.RiderID, &log.Outcome, &events, &log.StartedAt, &finishedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrAttemptLogNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get matching attempt log: %w", err)
+	}
+	if err := json.Unmarshal(events, &log.Events); err != nil {
+		return nil, fmt.Errorf("failed to decode matching attempt log: %w", err)
+	}
+	if finishedAt.Valid {
+		log.FinishedAt = &finishedAt.Time
+	}
+	return log, nil
+}
+
+// MemoryAttemptLogStore keeps attempt logs in memory
+type MemoryAttemptLogStore struct {
+	logs  map[string]*AttemptLog
+	mutex sync.RWMutex
+}
+
+// NewMemoryAttemptLogStore creates an in-memory attempt log store
+func NewMemoryAttemptLogStore() *MemoryAttemptLogStore {
+	return &MemoryAttemptLogStore{logs: make(map[string]*AttemptLog)}
+}
+
+// Append adds a step to its trip's log
+func (s *MemoryAttemptLogStore) Append(ctx context.Context, progress *MatchingProgress) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	log, exists := s.logs[progress.TripID]
+	if !exists {
+		log = &AttemptLog{TripID: progress.TripID, StartedAt: progress.UpdatedAt}
+		s.logs[progress.TripID] = log
+	}
+	if log.RiderID == "" {
+		log.RiderID = progress.RiderID
+	}
+	step := *progress
+	log.Events = append(log.Events, &step)
+	log.Outcome, log.FinishedAt = outcomeOf(progress)
+	return nil
+}
+
+// Get returns a copy of a trip's log
+func (s *MemoryAttemptLogStore) Get(ctx context.Context, tripID string) (*AttemptLog, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	log, exists := s.logs[tripID]
+	if !exists {
+		return nil, ErrAttemptLogNotFound
+	}
+	copied := *log
+	copied.Events = append([]*MatchingProgress(nil), log.Events...)
+	return &copied, nil
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rideshare-platform/services/matching-service/internal/config"
+	"github.com/rideshare-platform/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// drainEvents returns the events of the updates pushed so far
+func drainEvents(updates <-chan *MatchingProgress) []MatchingEvent {
+	var events []MatchingEvent
+	for {
+		select {
+		case update := <-updates:
+			events = append(events, update.Event)
+		default:
+			return events
+		}
+	}
+}
+
+func newAttemptLogTestService() (*AdvancedMatchingService, *ProgressBroadcaster) {
+	service := NewSimpleMatchingService(&config.Config{})
+	progress := NewProgressBroadcaster()
+	service.SetProgressNotifier(progress)
+	service.SetAttemptLogStore(NewMemoryAttemptLogStore())
+	return service, progress
+}
+
+func newAttemptLogTestRequest(tripID string) *MatchingRequest {
+	return &MatchingRequest{
+		TripID:         tripID,
+		RiderID:        "rider-1",
+		PickupLocation: &models.Location{Latitude: 37.7749, Longitude: -122.4194},
+		Destination:    &models.Location{Latitude: 37.7849, Longitude: -122.4094},
+		VehicleType:    "sedan",
+		PassengerCount: 1,
+	}
+}
+
+func TestAttemptLog_AcceptedOfferEndsMatching(t *testing.T) {
+	service, progress := newAttemptLogTestService()
+	ctx := context.Background()
+
+	updates, unsubscribe := progress.Subscribe("trip-log-1")
+	defer unsubscribe()
+
+	result, err := service.FindMatch(ctx, newAttemptLogTestRequest("trip-log-1"))
+	require.NoError(t, err)
+	require.True(t, result.Success)
+	assert.Equal(t, []MatchingEvent{MatchingEventSearching, MatchingEventDriverOffered}, drainEvents(updates))
+
+	_, err = service.AcceptOffer(ctx, "trip-log-1", result.MatchedDriver.DriverID)
+	require.NoError(t, err)
+	assert.Equal(t, []MatchingEvent{MatchingEventDriverAccepted}, drainEvents(updates))
+
+	log, err := service.GetAttemptLog(ctx, "trip-log-1")
+	require.NoError(t, err)
+	assert.Equal(t, "rider-1", log.RiderID)
+	assert.Equal(t, MatchingEventDriverAccepted, log.Outcome)
+	assert.NotNil(t, log.FinishedAt)
+	require.Len(t, log.Events, 3)
+	assert.Equal(t, result.OfferID, log.Events[1].OfferID)
+	assert.Equal(t, 1, log.Events[1].Attempt)
+
+	status, err := service.GetMatchingStatus(ctx, "trip-log-1")
+	require.NoError(t, err)
+	assert.Equal(t, QueueStatusMatched, status["status"])
+	assert.Equal(t, MatchingEventDriverAccepted, status["event"])
+	assert.Equal(t, result.MatchedDriver.DriverID, status["matched_driver"])
+}
+
+func TestAttemptLog_DeclineWithoutCandidatesLeavesTripUnmatched(t *testing.T) {
+	service, progress := newAttemptLogTestService()
+	ctx := context.Background()
+
+	result, err := service.FindMatch(ctx, newAttemptLogTestRequest("trip-log-2"))
+	require.NoError(t, err)
+
+	updates, unsubscribe := progress.Subscribe("trip-log-2")
+	defer unsubscribe()
+
+	_, err = service.DeclineOffer(ctx, "trip-log-2", result.MatchedDriver.DriverID, "too far")
+	require.NoError(t, err)
+	assert.Equal(t, []MatchingEvent{MatchingEventDriverDeclined, MatchingEventNoDrivers}, drainEvents(updates))
+
+	log, err := service.GetAttemptLog(ctx, "trip-log-2")
+	require.NoError(t, err)
+	assert.Equal(t, MatchingEventNoDrivers, log.Outcome)
+
+	status, err := service.GetMatchingStatus(ctx, "trip-log-2")
+	require.NoError(t, err)
+	assert.Equal(t, QueueStatusUnmatched, status["status"])
+}
+
+func TestAttemptLog_QueuedRetryReportsWiderSearch(t *testing.T) {
+	geo := &fakeGeoService{}
+	service := newQueueTestService(geo)
+	service.SetAttemptLogStore(NewMemoryAttemptLogStore())
+	ctx := context.Background()
+
+	_, err := service.FindMatch(ctx, newQueueTestRequest("trip-log-3", time.Minute))
+	require.NoError(t, err)
+
+	geo.setDrivers(&DriverLocation{
+		DriverID:           "far-driver",
+		Location:           &models.Location{Latitude: 37.9, Longitude: -122.4},
+		DistanceFromCenter: 25,
+		Status:             "available",
+		VehicleType:        "sedan",
+		Rating:             4.9,
+	})
+	_, err = service.ProcessMatchingQueue(ctx, time.Now().Add(time.Second))
+	require.NoError(t, err)
+
+	log, err := service.GetAttemptLog(ctx, "trip-log-3")
+	require.NoError(t, err)
+	var events []MatchingEvent
+	for _, step := range log.Events {
+		events = append(events, step.Event)
+	}
+	assert.Equal(t, []MatchingEvent{
+		MatchingEventSearching,
+		MatchingEventQueued,
+		MatchingEventRadiusExpanded,
+		MatchingEventDriverOffered,
+	}, events)
+	assert.Equal(t, 30.0, log.Events[2].SearchRadiusKm)
+	assert.Equal(t, "far-driver", log.Events[3].DriverID)
+	assert.Empty(t, log.Outcome)
+}
+
+func TestAttemptLog_MatchingAgainReopensLog(t *testing.T) {
+	store := NewMemoryAttemptLogStore()
+	ctx := context.Background()
+	now := time.Now()
+
+	require.NoError(t, store.Append(ctx, &MatchingProgress{TripID: "trip-log-4", RiderID: "rider-1", Event: MatchingEventSearching, UpdatedAt: now}))
+	require.NoError(t, store.Append(ctx, &MatchingProgress{TripID: "trip-log-4", Event: MatchingEventCancelled, UpdatedAt: now.Add(time.Second)}))
+
+	log, err := store.Get(ctx, "trip-log-4")
+	require.NoError(t, err)
+	assert.Equal(t, MatchingEventCancelled, log.Outcome)
+	assert.Equal(t, "rider-1", log.RiderID)
+
+	require.NoError(t, store.Append(ctx, &MatchingProgress{TripID: "trip-log-4", Event: MatchingEventSearching, UpdatedAt: now.Add(time.Minute)}))
+	log, err = store.Get(ctx, "trip-log-4")
+	require.NoError(t, err)
+	assert.Empty(t, log.Outcome)
+	assert.Nil(t, log.FinishedAt)
+	assert.Len(t, log.Events, 3)
+	assert.Equal(t, now, log.StartedAt)
+
+	_, err = store.Get(ctx, "unknown-trip")
+	assert.ErrorIs(t, err, ErrAttemptLogNotFound)
+}
@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/services/matching-service/internal/metrics"
+	"github.com/rideshare-platform/shared/city"
+	"github.com/rideshare-platform/shared/flags"
+)
+
+const (
+	// batchAreaPrecision is the geohash precision requests are batched by,
+	// cells about 5km across
+	batchAreaPrecision = 5
+	// maxBatchSize flushes a batch early so the assignment stays cheap to solve
+	maxBatchSize = 50
+	// unassignableCost is the cost of pairing a request with a driver it
+	// cannot be matched to
+	unassignableCost = 1e9
+	// batchMatchingFlag is the feature flag rolling batching out to riders
+	batchMatchingFlag = "batch_matching"
+)
+
+// batchMatcher collects the match requests of an area over a short window
+// and assigns drivers to them jointly, minimising the total pickup ETA.
+// Matched one at a time, two nearby requests would both reach for the same
+// closest driver and one of them would lose the reservation.
+type batchMatcher struct {
+	window  time.Duration
+	mutex   sync.Mutex
+	batches map[string]*matchBatch
+}
+
+// matchBatch is the requests of an area waiting for the window to close
+type matchBatch struct {
+	entries []*batchEntry
+	timer   *time.Timer
+}
+
+// batchEntry is one request's ranked drivers, and where its reordered
+// drivers are sent once the batch is solved
+type batchEntry struct {
+	candidates []*MatchedDriverInfo
+	ordered    chan []*MatchedDriverInfo
+}
+
+func newBatchMatcher(window time.Duration) *batchMatcher {
+	return &batchMatcher{window: window, batches: make(map[string]*matchBatch)}
+}
+
+// SetBatchWindow batches the first matching pass of requests whose pickups
+// are close together over window, so drivers are assigned to them jointly. A
+// zero window matches every request on its own.
+func (s *AdvancedMatchingService) SetBatchWindow(window time.Duration) {
+	if window <= 0 {
+		s.batcher = nil
+		return
+	}
+	s.batcher = newBatchMatcher(window)
+}
+
+// SetFeatureFlags attaches the shared feature flags. The batch_matching
+// flag then decides which riders are batched; while it does not exist every
+// rider is.
+func (s *AdvancedMatchingService) SetFeatureFlags(client *flags.Client) {
+	s.flags = client
+}
+
+// batching reports whether the request is matched in a batch
+func (s *AdvancedMatchingService) batching(request *MatchingRequest) bool {
+	if s.batcher == nil {
+		return false
+	}
+	if s.flags == nil {
+		return true
+	}
+	return s.flags.EnabledOr(batchMatchingFlag, flags.Subject{ID: request.RiderID, City: request.City}, true)
+}
+
+// batchedMatch runs the first matching pass of a request together with the
+// other requests of its area
+func (s *AdvancedMatchingService) batchedMatch(ctx context.Context, request *MatchingRequest, params searchParams, startTime time.Time) (*MatchingResult, error) {
+	scoredDrivers, failure, err := s.rankDrivers(ctx, request, params, startTime)
+	if failure != nil {
+		return failure, err
+	}
+	scoredDrivers = s.batcher.submit(ctx, batchArea(request), scoredDrivers)
+	return s.completeMatch(ctx, request, scoredDrivers, startTime)
+}
+
+// batchArea keys the batch of a request by its city and pickup cell
+func batchArea(request *MatchingRequest) string {
+	return city.Normalize(request.City) + ":" + request.PickupLocation.Geohash(batchAreaPrecision)
+}
+
+// submit adds a request's ranked drivers to its area's batch and waits for
+// the batch to be solved. The drivers come back with the one assigned to the
+// request first and those assigned to other requests last. If ctx ends first
+// the drivers are returned as ranked.
+func (b *batchMatcher) submit(ctx context.Context, area string, candidates []*MatchedDriverInfo) []*MatchedDriverInfo {
+	entry := &batchEntry{candidates: candidates, ordered: make(chan []*MatchedDriverInfo, 1)}
+
+	b.mutex.Lock()
+	batch := b.batches[area]
+	if batch == nil {
+		batch = &matchBatch{}
+		batch.timer = time.AfterFunc(b.window, func() { b.flush(area, batch) })
+		b.batches[area] = batch
+	}
+	batch.entries = append(batch.entries, entry)
+	full := len(batch.entries) >= maxBatchSize
+	b.mutex.Unlock()
+
+	if full {
+		b.flush(area, batch)
+	}
+
+	select {
+	case ordered := <-entry.ordered:
+		return ordered
+	case <-ctx.Done():
+		return candidates
+	}
+}
+
+// flush solves a batch, unless it was already flushed
+func (b *batchMatcher) flush(area string, batch *matchBatch) {
+	b.mutex.Lock()
+	if b.batches[area] != batch {
+		b.mutex.Unlock()
+		return
+	}
+	delete(b.batches, area)
+	batch.timer.Stop()
+	b.mutex.Unlock()
+
+	metrics.ObserveMatchBatchSize(len(batch.entries))
+
+	lists := make([][]*MatchedDriverInfo, len(batch.entries))
+	for i, entry := range batch.entries {
+		lists[i] = entry.candidates
+	}
+	assigned := assignDrivers(lists)
+
+	taken := make(map[string]int, len(assigned))
+	for i, driverID := range assigned {
+		if driverID != "" {
+			taken[driverID] = i
+		}
+	}
+	for i, entry := range batch.entries {
+		entry.ordered <- orderForAssignment(entry.candidates, assigned[i], i, taken)
+	}
+}
+
+// orderForAssignment puts the request's assigned driver first and drivers
+// assigned to other requests of the batch last, keeping the ranking otherwise
+func orderForAssignment(candidates []*MatchedDriverInfo, assigned string, index int, taken map[string]int) []*MatchedDriverInfo {
+	ordered := make([]*MatchedDriverInfo, 0, len(candidates))
+	var others []*MatchedDriverInfo
+	for _, driver := range candidates {
+		if driver.DriverID == assigned {
+			ordered = append([]*MatchedDriverInfo{driver}, ordered...)
+			continue
+		}
+		if owner, ok := taken[driver.DriverID]; ok && owner != index {
+			others = append(others, driver)
+			continue
+		}
+		ordered = append(ordered, driver)
+	}
+	return append(ordered, others...)
+}
+
+// assignDrivers picks at most one driver per request, each driver for at
+// most one request, minimising the total pickup ETA. Requests left without a
+// driver get an empty ID.
+func assignDrivers(lists [][]*MatchedDriverInfo) []string {
+	assigned := make([]string, len(lists))
+	if len(lists) == 0 {
+		return assigned
+	}
+
+	var driverIDs []string
+	columns := make(map[string]int)
+	for _, candidates := range lists {
+		for _, driver := range candidates {
+			if _, ok := columns[driver.DriverID]; !ok {
+				columns[driver.DriverID] = len(driverIDs)
+				driverIDs = append(driverIDs, driver.DriverID)
+			}
+		}
+	}
+
+	// Padded to at least one column per request, for requests that go without
+	size := max(len(driverIDs), len(lists))
+	cost := make([][]float64, len(lists))
+	for i, candidates := range lists {
+		cost[i] = make([]float64, size)
+		for j := range cost[i] {
+			cost[i][j] = unassignableCost
+		}
+		for _, driver := range candidates {
+			cost[i][columns[driver.DriverID]] = float64(driver.ETA)
+		}
+	}
+
+	for i, j := range hungarian(cost) {
+		if j < len(driverIDs) && cost[i][j] < unassignableCost {
+			assigned[i] = driverIDs[j]
+		}
+	}
+	return assigned
+}
+
+// hungarian solves the assignment problem for a cost matrix with no more rows
+// than columns, returning the column assigned to each row
+func hungarian(cost [][]float64) []int {
+	n, m := len(cost), len(cost[0])
+	u := make([]float64, n+1)
+	v := make([]float64, m+1)
+	match := make([]int, m+1) // row matched to each column, 1-based, 0 if none
+	way := make([]int, m+1)
+
+	for i := 1; i <= n; i++ {
+		match[0] = i
+		column := 0
+		minimum := make([]float64, m+1)
+		used := make([]bool, m+1)
+		for j := range minimum {
+			minimum[j] = math.Inf(1)
+		}
+
+		for match[column] != 0 {
+			used[column] = true
+			row, delta, next := match[column], math.Inf(1), 0
+			for j := 1; j <= m; j++ {
+				if used[j] {
+					continue
+				}
+				if reduced := cost[row-1][j-1] - u[row] - v[j]; reduced < minimum[j] {
+					minimum[j] = reduced
+					way[j] = column
+				}
+				if minimum[j] < delta {
+					delta = minimum[j]
+					next = j
+				}
+			}
+			for j := 0; j <= m; j++ {
+				if used[j] {
+					u[match[j]] += delta
+					v[j] -= delta
+				} else {
+					minimum[j] -= delta
+				}
+			}
+			column = next
+		}
+
+		for column != 0 {
+			previous := way[column]
+			match[column] = match[previous]
+			column = previous
+		}
+	}
+
+	assignment := make([]int, n)
+	for j := 1; j <= m; j++ {
+		if match[j] != 0 {
+			assignment[match[j]-1] = j - 1
+		}
+	}
+	return assignment
+}
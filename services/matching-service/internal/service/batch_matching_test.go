@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rideshare-platform/shared/flags"
+)
+
+func TestAssignDrivers_MinimisesTotalETA(t *testing.T) {
+	// Greedily, the first request takes driver-1 and the second is left with
+	// a driver 400s away; jointly both are picked up within 100s
+	lists := [][]*MatchedDriverInfo{
+		{{DriverID: "driver-1", ETA: 60}, {DriverID: "driver-2", ETA: 70}},
+		{{DriverID: "driver-1", ETA: 100}, {DriverID: "driver-2", ETA: 400}},
+	}
+	assert.Equal(t, []string{"driver-2", "driver-1"}, assignDrivers(lists))
+}
+
+func TestAssignDrivers_MoreRequestsThanDrivers(t *testing.T) {
+	lists := [][]*MatchedDriverInfo{
+		{{DriverID: "driver-1", ETA: 300}},
+		{{DriverID: "driver-1", ETA: 60}},
+		{},
+	}
+	assert.Equal(t, []string{"", "driver-1", ""}, assignDrivers(lists))
+}
+
+func TestBatchMatcher_OrdersCandidatesByAssignment(t *testing.T) {
+	batcher := newBatchMatcher(20 * time.Millisecond)
+	lists := [][]*MatchedDriverInfo{
+		{{DriverID: "driver-1", ETA: 60}, {DriverID: "driver-2", ETA: 70}, {DriverID: "driver-3", ETA: 500}},
+		{{DriverID: "driver-1", ETA: 100}, {DriverID: "driver-2", ETA: 400}},
+	}
+
+	ordered := make([][]*MatchedDriverInfo, len(lists))
+	var wg sync.WaitGroup
+	for i := range lists {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ordered[i] = batcher.submit(context.Background(), "istanbul:sxk97", lists[i])
+		}(i)
+	}
+	wg.Wait()
+
+	driverIDs := func(drivers []*MatchedDriverInfo) []string {
+		ids := make([]string, len(drivers))
+		for i, driver := range drivers {
+			ids[i] = driver.DriverID
+		}
+		return ids
+	}
+	// The driver assigned to the other request is tried last
+	assert.Equal(t, []string{"driver-2", "driver-3", "driver-1"}, driverIDs(ordered[0]))
+	assert.Equal(t, []string{"driver-1", "driver-2"}, driverIDs(ordered[1]))
+	assert.Empty(t, batcher.batches)
+}
+
+func TestBatching_FollowsFeatureFlag(t *testing.T) {
+	s := &AdvancedMatchingService{}
+	s.SetBatchWindow(time.Second)
+	store := flags.NewMemoryStore()
+	client := flags.NewClient(store, nil)
+	s.SetFeatureFlags(client)
+
+	request := &MatchingRequest{RiderID: "rider-1", City: "IST"}
+	assert.True(t, s.batching(request), "batching should stay on until the flag is created")
+
+	ctx := context.Background()
+	assert.NoError(t, store.Save(ctx, &flags.Flag{
+		Name:    batchMatchingFlag,
+		Enabled: true,
+		Rollout: 100,
+		Cities:  map[string]flags.CityOverride{"ist": {Enabled: false}},
+	}))
+	_, err := client.Refresh(ctx)
+	assert.NoError(t, err)
+	assert.False(t, s.batching(request))
+	assert.True(t, s.batching(&MatchingRequest{RiderID: "rider-1", City: "ank"}))
+
+	s.SetBatchWindow(0)
+	assert.False(t, s.batching(&MatchingRequest{RiderID: "rider-1", City: "ank"}))
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"sort"
+
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+)
+
+// DispatchQueues reads the driver queues of geo-service's dispatch zones,
+// such as airports
+type DispatchQueues interface {
+	// QueuedDrivers returns the IDs of the drivers queued in the dispatch
+	// zone containing the location, head of the queue first, and nil
+	// outside every dispatch zone
+	QueuedDrivers(ctx context.Context, location *models.Location) ([]string, error)
+}
+
+// SetDispatchQueues sets the geo-service client pickups in dispatch zones
+// are served from the zone's queue with
+func (s *AdvancedMatchingService) SetDispatchQueues(queues DispatchQueues) {
+	s.queues = queues
+}
+
+// dispatchFromQueue ranks the drivers for a pickup in a dispatch zone by
+// their place in the zone's queue rather than their score, leaving out
+// drivers who are not queued. Queued drivers who cannot take the trip have
+// already been filtered out; when none are left, or the queue cannot be
+// read, the pickup is matched like any other.
+func (s *AdvancedMatchingService) dispatchFromQueue(ctx context.Context, request *MatchingRequest, scoredDrivers []*MatchedDriverInfo) []*MatchedDriverInfo {
+	if s.queues == nil || request.PickupLocation == nil {
+		return scoredDrivers
+	}
+
+	queued, err := s.queues.QueuedDrivers(ctx, request.PickupLocation)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.WithContext(ctx).WithError(err).Warn("Failed to read the dispatch zone queue, matching by distance")
+		}
+		return scoredDrivers
+	}
+	if len(queued) == 0 {
+		return scoredDrivers
+	}
+
+	positions := make(map[string]int, len(queued))
+	for i, driverID := range queued {
+		positions[driverID] = i
+	}
+	var inQueue []*MatchedDriverInfo
+	for _, driver := range scoredDrivers {
+		if _, ok := positions[driver.DriverID]; ok {
+			inQueue = append(inQueue, driver)
+		}
+	}
+	if len(inQueue) == 0 {
+		return scoredDrivers
+	}
+	sort.SliceStable(inQueue, func(i, j int) bool {
+		return positions[inQueue[i].DriverID] < positions[inQueue[j].DriverID]
+	})
+
+	if s.logger != nil {
+		s.logger.WithContext(ctx).WithFields(logger.Fields{
+			"trip_id":        request.TripID,
+			"queued_drivers": len(queued),
+			"eligible":       len(inQueue),
+		}).Info("Dispatching pickup from the zone queue")
+	}
+	return inQueue
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rideshare-platform/shared/models"
+)
+
+// fakeDispatchQueues reports a fixed zone queue or an error
+type fakeDispatchQueues struct {
+	queued []string
+	err    error
+}
+
+func (f *fakeDispatchQueues) QueuedDrivers(ctx context.Context, location *models.Location) ([]string, error) {
+	return f.queued, f.err
+}
+
+func newDispatchTestDrivers() []*DriverLocation {
+	var drivers []*DriverLocation
+	for i, driverID := range []string{"nearest-driver", "queued-second", "queued-first"} {
+		drivers = append(drivers, &DriverLocation{
+			DriverID:           driverID,
+			VehicleID:          "vehicle-" + driverID,
+			Location:           &models.Location{Latitude: 37.7749, Longitude: -122.4194},
+			DistanceFromCenter: float64(i + 1),
+			Status:             "available",
+			VehicleType:        "sedan",
+			Rating:             4.8,
+		})
+	}
+	return drivers
+}
+
+func TestDispatchQueues_PickupGoesToHeadOfQueue(t *testing.T) {
+	geo := &fakeGeoService{}
+	geo.setDrivers(newDispatchTestDrivers()...)
+	service := newQueueTestService(geo)
+	service.SetDispatchQueues(&fakeDispatchQueues{queued: []string{"offline-driver", "queued-first", "queued-second"}})
+
+	result, err := service.FindMatch(context.Background(), newQueueTestRequest("trip-airport-1", time.Minute))
+	require.NoError(t, err)
+	require.True(t, result.Success)
+	assert.Equal(t, "queued-first", result.MatchedDriver.DriverID, "the queue head is dispatched, not the nearest driver")
+	for _, alternative := range result.AlternativeOptions {
+		assert.NotEqual(t, "nearest-driver", alternative.DriverID, "drivers outside the queue are passed over")
+	}
+}
+
+func TestDispatchQueues_FallsBackToDistance(t *testing.T) {
+	for name, queues := range map[string]*fakeDispatchQueues{
+		"outside dispatch zones": {},
+		"no eligible driver":     {queued: []string{"offline-driver"}},
+		"queue unavailable":      {err: errors.New("geo-service unavailable")},
+	} {
+		t.Run(name, func(t *testing.T) {
+			geo := &fakeGeoService{}
+			geo.setDrivers(newDispatchTestDrivers()...)
+			service := newQueueTestService(geo)
+			service.SetDispatchQueues(queues)
+
+			result, err := service.FindMatch(context.Background(), newQueueTestRequest("trip-airport-2", time.Minute))
+			require.NoError(t, err)
+			require.True(t, result.Success)
+			assert.Equal(t, "nearest-driver", result.MatchedDriver.DriverID)
+		})
+	}
+}
@@ -0,0 +1,298 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// DefaultBehaviorHalfLife is how long it takes an offer response to count
+// half as much towards a driver's acceptance rate
+const DefaultBehaviorHalfLife = 14 * 24 * time.Hour
+
+// minBehaviorSamples is how many recent offers or trips a driver needs
+// before their acceptance or cancellation rate is scored. Drivers with fewer
+// are scored as if they accepted every offer and cancelled no trip, so new
+// drivers are not held back.
+const minBehaviorSamples = 3
+
+// DriverBehavior is how a driver has recently answered offers and finished
+// trips. Counts decay with age, recent offers and trips counting most.
+type DriverBehavior struct {
+	Offers           float64 // offers accepted, declined or left to expire
+	AcceptanceRate   float64
+	Trips            float64 // trips completed or cancelled by the driver
+	CancellationRate float64
+	CompletionStreak float64 // trips completed since the driver last cancelled one
+}
+
+// acceptance returns the acceptance rate a driver is scored with
+func (b *DriverBehavior) acceptance() float64 {
+	if b == nil || b.Offers < minBehaviorSamples {
+		return 1
+	}
+	return b.AcceptanceRate
+}
+
+// cancellation returns the cancellation rate a driver is scored with
+func (b *DriverBehavior) cancellation() float64 {
+	if b == nil || b.Trips < minBehaviorSamples {
+		return 0
+	}
+	return b.CancellationRate
+}
+
+// streak returns the completion streak a driver is scored with
+func (b *DriverBehavior) streak() float64 {
+	if b == nil {
+		return 0
+	}
+	return b.CompletionStreak
+}
+
+// DriverAcceptance is a driver's decayed count of the offers they answered
+// and accepted
+type DriverAcceptance struct {
+	DriverID  string
+	Offers    float64
+	Accepted  float64
+	UpdatedAt time.Time
+}
+
+// decayed returns the counts aged from when they were last updated to now
+func (a DriverAcceptance) decayed(now time.Time, halfLife time.Duration) *DriverAcceptance {
+	factor := decayFactor(now.Sub(a.UpdatedAt), halfLife)
+	a.Offers *= factor
+	a.Accepted *= factor
+	return &a
+}
+
+// DriverAcceptanceStore counts how drivers answer the offers they are sent
+type DriverAcceptanceStore interface {
+	// RecordResponse counts a driver's answer to an offer, decaying the
+	// answers counted before
+	RecordResponse(ctx context.Context, driverID string, accepted bool, at time.Time) error
+	// GetAcceptance returns the drivers' counts as of now, leaving out
+	// drivers never sent an offer
+	GetAcceptance(ctx context.Context, driverIDs []string, now time.Time) (map[string]*DriverAcceptance, error)
+}
+
+// DriverReliability is how reliably a driver finishes their trips, from trip-service
+type DriverReliability struct {
+	DriverID         string
+	Trips            float64
+	CancellationRate float64
+	CompletionStreak float64
+}
+
+// ReliabilityProvider looks up drivers' cancellation rates and completion streaks
+type ReliabilityProvider interface {
+	// GetDriverReliability returns reliabilities keyed by driver ID, leaving out drivers without trips
+	GetDriverReliability(ctx context.Context, driverIDs []string) (map[string]*DriverReliability, error)
+}
+
+// SetDriverAcceptanceStore sets the store drivers' answers to offers are
+// counted in. Without one every driver is scored as accepting every offer.
+func (s *AdvancedMatchingService) SetDriverAcceptanceStore(store DriverAcceptanceStore) {
+	s.acceptance = store
+}
+
+// SetReliabilityProvider sets the trip-service client drivers' cancellation
+// rates and completion streaks are looked up through
+func (s *AdvancedMatchingService) SetReliabilityProvider(provider ReliabilityProvider) {
+	s.reliability = provider
+}
+
+// recordDriverResponse counts a driver's answer to an offer. Offers
+// withdrawn because the trip was cancelled are not counted.
+func (s *AdvancedMatchingService) recordDriverResponse(ctx context.Context, driverID string, status OfferStatus, now time.Time) {
+	if s.acceptance == nil || driverID == "" {
+		return
+	}
+	if status != OfferStatusAccepted && status != OfferStatusDeclined && status != OfferStatusExpired {
+		return
+	}
+	if err := s.acceptance.RecordResponse(ctx, driverID, status == OfferStatusAccepted, now); err != nil && s.logger != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("driver_id", driverID).Warn("Failed to record driver offer response")
+	}
+}
+
+// driverBehaviors looks up how the drivers have recently answered offers and
+// finished trips. Lookup failures leave the drivers out, scoring them as
+// new drivers, so matching can go on.
+func (s *AdvancedMatchingService) driverBehaviors(ctx context.Context, drivers []*DriverLocation) map[string]*DriverBehavior {
+	behaviors := make(map[string]*DriverBehavior)
+	if (s.acceptance == nil && s.reliability == nil) || len(drivers) == 0 {
+		return behaviors
+	}
+
+	driverIDs := make([]string, 0, len(drivers))
+	for _, driver := range drivers {
+		driverIDs = append(driverIDs, driver.DriverID)
+	}
+	behaviorOf := func(driverID string) *DriverBehavior {
+		behavior, exists := behaviors[driverID]
+		if !exists {
+			behavior = &DriverBehavior{}
+			behaviors[driverID] = behavior
+		}
+		return behavior
+	}
+
+	if s.acceptance != nil {
+		acceptance, err := s.acceptance.GetAcceptance(ctx, driverIDs, time.Now())
+		if err != nil && s.logger != nil {
+			s.logger.WithContext(ctx).WithError(err).Warn("Failed to load driver acceptance rates")
+		}
+		for driverID, counts := range acceptance {
+			behavior := behaviorOf(driverID)
+			behavior.Offers = counts.Offers
+			if counts.Offers > 0 {
+				behavior.AcceptanceRate = counts.Accepted / counts.Offers
+			}
+		}
+	}
+
+	if s.reliability != nil {
+		reliabilities, err := s.reliability.GetDriverReliability(ctx, driverIDs)
+		if err != nil && s.logger != nil {
+			s.logger.WithContext(ctx).WithError(err).Warn("Failed to load driver reliability")
+		}
+		for driverID, reliability := range reliabilities {
+			behavior := behaviorOf(driverID)
+			behavior.Trips = reliability.Trips
+			behavior.CancellationRate = reliability.CancellationRate
+			behavior.CompletionStreak = reliability.CompletionStreak
+		}
+	}
+	return behaviors
+}
+
+// decayFactor is how much a count made elapsed ago still counts, halving
+// every half-life
+func decayFactor(elapsed, halfLife time.Duration) float64 {
+	if elapsed <= 0 || halfLife <= 0 {
+		return 1
+	}
+	return math.Pow(0.5, float64(elapsed)/float64(halfLife))
+}
+
+// PostgresDriverAcceptanceStore counts offer answers in the driver_acceptance table
+type PostgresDriverAcceptanceStore struct {
+	db       *sql.DB
+	halfLife time.Duration
+}
+
+// NewPostgresDriverAcceptanceStore creates a driver acceptance store backed
+// by PostgreSQL. A half-life of 0 uses DefaultBehaviorHalfLife.
+func NewPostgresDriverAcceptanceStore(db *sql.DB, halfLife time.Duration) *PostgresDriverAcceptanceStore {
+	if halfLife <= 0 {
+		halfLife = DefaultBehaviorHalfLife
+	}
+	return &PostgresDriverAcceptanceStore{db: db, halfLife: halfLife}
+}
+
+// RecordResponse counts a driver's answer, decaying the stored counts in
+// the same statement so concurrent answers are not lost
+func (s *PostgresDriverAcceptanceStore) RecordResponse(ctx context.Context, driverID string, accepted bool, at time.Time) error {
+	acceptedCount := 0.0
+	if accepted {
+		acceptedCount = 1
+	}
+
+	query := `
+		INSERT INTO driver_acceptance (driver_id, offers, accepted, updated_at)
+		VALUES ($1, 1, $2, $3)
+		ON CONFLICT (driver_id) DO UPDATE SET
+			offers = driver_acceptance.offers * POWER(0.5, GREATEST(EXTRACT(EPOCH FROM (EXCLUDED.updated_at - driver_acceptance.updated_at)), 0) / $4) + 1,
+			accepted = driver_acceptance.accepted * POWER(0.5, GREATEST(EXTRACT(EPOCH FROM (EXCLUDED.updated_at - driver_acceptance.updated_at)), 0) / $4) + EXCLUDED.accepted,
+			updated_at = GREATEST(driver_acceptance.updated_at, EXCLUDED.updated_at)`
+
+	_, err := s.db.ExecContext(ctx, query, driverID, acceptedCount, at, s.halfLife.Seconds())
+	if err != nil {
+		return fmt.Errorf("failed to record driver offer response: %w", err)
+	}
+	return nil
+}
+
+// GetAcceptance returns the drivers' counts as of now
+func (s *PostgresDriverAcceptanceStore) GetAcceptance(ctx context.Context, driverIDs []string, now time.Time) (map[string]*DriverAcceptance, error) {
+	query := `
+		SELECT driver_id, offers, accepted, updated_at
+		FROM driver_acceptance
+		WHERE driver_id = ANY($1)`
+
+	rows, err := s.db.QueryContext(ctx, query, pq.Array(driverIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get driver acceptance: %w", err)
+	}
+	defer rows.Close()
+
+	acceptance := make(map[string]*DriverAcceptance, len(driverIDs))
+	for rows.Next() {
+		var counts DriverAcceptance
+		if err := rows.Scan(&counts.DriverID, &counts.Offers, &counts.Accepted, &counts.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to read driver acceptance: %w", err)
+		}
+		acceptance[counts.DriverID] = counts.decayed(now, s.halfLife)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get driver acceptance: %w", err)
+	}
+	return acceptance, nil
+}
+
+// MemoryDriverAcceptanceStore counts offer answers in memory
+type MemoryDriverAcceptanceStore struct {
+	counts   map[string]DriverAcceptance
+	halfLife time.Duration
+	mutex    sync.RWMutex
+}
+
+// NewMemoryDriverAcceptanceStore creates an in-memory driver acceptance
+// store. A half-life of 0 uses DefaultBehaviorHalfLife.
+func NewMemoryDriverAcceptanceStore(halfLife time.Duration) *MemoryDriverAcceptanceStore {
+	if halfLife <= 0 {
+		halfLife = DefaultBehaviorHalfLife
+	}
+	return &MemoryDriverAcceptanceStore{counts: make(map[string]DriverAcceptance), halfLife: halfLife}
+}
+
+// RecordResponse counts a driver's answer
+func (s *MemoryDriverAcceptanceStore) RecordResponse(ctx context.Context, driverID string, accepted bool, at time.Time) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	counts := DriverAcceptance{DriverID: driverID, UpdatedAt: at}
+	if existing, exists := s.counts[driverID]; exists {
+		counts = *existing.decayed(at, s.halfLife)
+		if at.After(existing.UpdatedAt) {
+			counts.UpdatedAt = at
+		}
+	}
+	counts.Offers++
+	if accepted {
+		counts.Accepted++
+	}
+	s.counts[driverID] = counts
+	return nil
+}
+
+// GetAcceptance returns copies of the drivers' counts as of now
+func (s *MemoryDriverAcceptanceStore) GetAcceptance(ctx context.Context, driverIDs []string, now time.Time) (map[string]*DriverAcceptance, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	acceptance := make(map[string]*DriverAcceptance, len(driverIDs))
+	for _, driverID := range driverIDs {
+		if counts, exists := s.counts[driverID]; exists {
+			acceptance[driverID] = counts.decayed(now, s.halfLife)
+		}
+	}
+	return acceptance, nil
+}
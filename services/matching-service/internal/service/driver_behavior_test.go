@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rideshare-platform/shared/models"
+)
+
+// fakeReliabilityProvider returns fixed driver reliabilities or an error
+type fakeReliabilityProvider struct {
+	reliabilities map[string]*DriverReliability
+	err           error
+}
+
+func (f *fakeReliabilityProvider) GetDriverReliability(ctx context.Context, driverIDs []string) (map[string]*DriverReliability, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.reliabilities, nil
+}
+
+func newBehaviorTestDrivers() []*DriverLocation {
+	drivers := make([]*DriverLocation, 0, 2)
+	for _, driverID := range []string{"flaky-driver", "steady-driver"} {
+		drivers = append(drivers, &DriverLocation{
+			DriverID:           driverID,
+			Location:           &models.Location{Latitude: 37.775, Longitude: -122.419},
+			DistanceFromCenter: 1,
+			Status:             "available",
+			VehicleType:        "sedan",
+			Rating:             4.8,
+		})
+	}
+	return drivers
+}
+
+func TestDriverBehavior_ReliableDriversRankFirst(t *testing.T) {
+	ctx := context.Background()
+	geo := &fakeGeoService{}
+	geo.setDrivers(newBehaviorTestDrivers()...)
+	service := newQueueTestService(geo)
+
+	acceptance := NewMemoryDriverAcceptanceStore(time.Hour)
+	service.SetDriverAcceptanceStore(acceptance)
+	now := time.Now()
+	service.recordDriverResponse(ctx, "flaky-driver", OfferStatusAccepted, now)
+	service.recordDriverResponse(ctx, "flaky-driver", OfferStatusDeclined, now)
+	service.recordDriverResponse(ctx, "flaky-driver", OfferStatusExpired, now)
+	service.recordDriverResponse(ctx, "flaky-driver", OfferStatusDeclined, now)
+	service.recordDriverResponse(ctx, "flaky-driver", OfferStatusCancelled, now)
+	service.SetReliabilityProvider(&fakeReliabilityProvider{reliabilities: map[string]*DriverReliability{
+		"flaky-driver":  {DriverID: "flaky-driver", Trips: 10, CancellationRate: 0.4, CompletionStreak: 1},
+		"steady-driver": {DriverID: "steady-driver", Trips: 20, CompletionStreak: 20},
+	}})
+
+	result, err := service.FindMatch(ctx, newQueueTestRequest("trip-behavior-1", time.Minute))
+	require.NoError(t, err)
+	require.True(t, result.Success)
+	assert.Equal(t, "steady-driver", result.MatchedDriver.DriverID)
+	require.Len(t, result.AlternativeOptions, 1)
+
+	// The flaky driver, accepting one of four offers, loses three quarters of
+	// the acceptance points, 40% of the cancellation points and most of the
+	// streak points. The withdrawn offer is not counted.
+	weights := DefaultMatchingConfig()
+	lost := weights.AcceptanceWeight*0.75 + weights.CancellationWeight*0.4 + weights.StreakWeight*0.9
+	assert.InDelta(t, lost, result.MatchedDriver.MatchScore-result.AlternativeOptions[0].MatchScore, 0.001)
+}
+
+func TestDriverBehavior_LookupFailureScoresAsNewDriver(t *testing.T) {
+	ctx := context.Background()
+	geo := &fakeGeoService{}
+	geo.setDrivers(newBehaviorTestDrivers()[:1]...)
+	service := newQueueTestService(geo)
+	service.SetReliabilityProvider(&fakeReliabilityProvider{err: errors.New("trip-service unavailable")})
+
+	result, err := service.FindMatch(ctx, newQueueTestRequest("trip-behavior-2", time.Minute))
+	require.NoError(t, err)
+	require.True(t, result.Success)
+
+	unscored := newQueueTestService(nil)
+	expected := unscored.calculateMatchingScore(defaultScoringWeights, &MatchedDriverInfo{Distance: 1, ETA: 600, Rating: 4.8}, &MatchingRequest{})
+	assert.InDelta(t, expected, result.MatchedDriver.MatchScore, 0.001)
+}
+
+func TestMemoryDriverAcceptanceStore_OldAnswersDecay(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryDriverAcceptanceStore(time.Hour)
+	now := time.Now()
+
+	require.NoError(t, store.RecordResponse(ctx, "driver-1", false, now.Add(-2*time.Hour)))
+	require.NoError(t, store.RecordResponse(ctx, "driver-1", true, now.Add(-time.Hour)))
+
+	acceptance, err := store.GetAcceptance(ctx, []string{"driver-1", "driver-2"}, now)
+	require.NoError(t, err)
+	require.Len(t, acceptance, 1)
+
+	// The decline two hours ago counts half as much as the acceptance an hour ago
+	assert.InDelta(t, 0.75, acceptance["driver-1"].Offers, 0.001)
+	assert.InDelta(t, 0.5, acceptance["driver-1"].Accepted, 0.001)
+}
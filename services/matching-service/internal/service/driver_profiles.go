@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/services/matching-service/internal/config"
+)
+
+// defaultDriverProfileTTL is how long profiles and vehicles are cached when
+// the configuration does not say
+const defaultDriverProfileTTL = 5 * time.Minute
+
+// DriverProfile is the public profile of a driver from user-service
+type DriverProfile struct {
+	DriverID  string
+	Name      string
+	PhotoURL  string
+	Rating    float64
+	TripCount int
+}
+
+// DriverVehicle is a vehicle registered to a driver in vehicle-service
+type DriverVehicle struct {
+	VehicleID string
+	Details   VehicleDetails
+	PhotoURL  string
+}
+
+// DriverProfileProvider looks up driver profiles
+type DriverProfileProvider interface {
+	// GetDriverProfiles returns profiles keyed by driver ID, leaving out unknown drivers
+	GetDriverProfiles(ctx context.Context, driverIDs []string) (map[string]*DriverProfile, error)
+}
+
+// DriverVehicleProvider looks up the vehicles drivers can take trips with
+type DriverVehicleProvider interface {
+	// GetDriverVehicles returns each driver's available vehicles keyed by
+	// driver ID, newest first, leaving out drivers with none
+	GetDriverVehicles(ctx context.Context, driverIDs []string) (map[string][]*DriverVehicle, error)
+}
+
+// SetDriverProfileProvider sets the user-service client matched drivers' names and photos come from
+func (s *AdvancedMatchingService) SetDriverProfileProvider(provider DriverProfileProvider) {
+	s.profiles = provider
+}
+
+// SetDriverVehicleProvider sets the vehicle-service client matched drivers' vehicles and their photos come from
+func (s *AdvancedMatchingService) SetDriverVehicleProvider(provider DriverVehicleProvider) {
+	s.vehicles = provider
+}
+
+// enrichMatchedDrivers fills in the profile and vehicle of each matched
+// driver with one batched lookup per service, serving repeat drivers from
+// the cache. Lookup failures leave the details out rather than failing the match.
+func (s *AdvancedMatchingService) enrichMatchedDrivers(ctx context.Context, drivers []*MatchedDriverInfo) {
+	if (s.profiles == nil && s.vehicles == nil) || len(drivers) == 0 {
+		return
+	}
+
+	driverIDs := make([]string, 0, len(drivers))
+	for _, driver := range drivers {
+		driverIDs = append(driverIDs, driver.DriverID)
+	}
+	profiles := s.lookupDriverProfiles(ctx, driverIDs)
+	vehicles := s.lookupDriverVehicles(ctx, driverIDs)
+
+	for _, driver := range drivers {
+		if profile := profiles[driver.DriverID]; profile != nil {
+			driver.DriverName = profile.Name
+			driver.DriverPhoto = profile.PhotoURL
+			driver.TripCount = profile.TripCount
+			// The rating used for scoring stays, it may be a fresher rolling average
+			if driver.Rating == 0 {
+				driver.Rating = profile.Rating
+			}
+		}
+		if vehicle := selectDriverVehicle(vehicles[driver.DriverID], driver.VehicleID); vehicle != nil {
+			details := vehicle.Details
+			details.Features = append([]string(nil), vehicle.Details.Features...)
+			if details.VehicleType == "" && driver.VehicleInfo != nil {
+				details.VehicleType = driver.VehicleInfo.VehicleType
+			}
+			driver.VehicleID = vehicle.VehicleID
+			driver.VehicleInfo = &details
+			driver.VehiclePhoto = vehicle.PhotoURL
+		}
+	}
+}
+
+// selectDriverVehicle picks the vehicle geo-service reported the driver on,
+// or their newest available vehicle
+func selectDriverVehicle(vehicles []*DriverVehicle, vehicleID string) *DriverVehicle {
+	for _, vehicle := range vehicles {
+		if vehicle.VehicleID == vehicleID {
+			return vehicle
+		}
+	}
+	if len(vehicles) > 0 {
+		return vehicles[0]
+	}
+	return nil
+}
+
+func (s *AdvancedMatchingService) lookupDriverProfiles(ctx context.Context, driverIDs []string) map[string]*DriverProfile {
+	if s.profiles == nil {
+		return nil
+	}
+
+	profiles, missing := s.profileCache.profilesFor(driverIDs)
+	if len(missing) == 0 {
+		return profiles
+	}
+	found, err := s.profiles.GetDriverProfiles(ctx, missing)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.WithContext(ctx).WithError(err).Warn("Failed to load driver profiles")
+		}
+		return profiles
+	}
+
+	s.profileCache.storeProfiles(missing, found)
+	for _, driverID := range missing {
+		profiles[driverID] = found[driverID]
+	}
+	return profiles
+}
+
+func (s *AdvancedMatchingService) lookupDriverVehicles(ctx context.Context, driverIDs []string) map[string][]*DriverVehicle {
+	if s.vehicles == nil {
+		return nil
+	}
+
+	vehicles, missing := s.profileCache.vehiclesFor(driverIDs)
+	if len(missing) == 0 {
+		return vehicles
+	}
+	found, err := s.vehicles.GetDriverVehicles(ctx, missing)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.WithContext(ctx).WithError(err).Warn("Failed to load driver vehicles")
+		}
+		return vehicles
+	}
+
+	s.profileCache.storeVehicles(missing, found)
+	for _, driverID := range missing {
+		vehicles[driverID] = found[driverID]
+	}
+	return vehicles
+}
+
+// driverProfileCache keeps looked up profiles and vehicles for a while,
+// including drivers that had none, so drivers matched again and again
+// are not looked up on every request
+type driverProfileCache struct {
+	ttl      time.Duration
+	mutex    sync.Mutex
+	profiles map[string]cachedDriverProfile
+	vehicles map[string]cachedDriverVehicles
+}
+
+type cachedDriverProfile struct {
+	profile   *DriverProfile
+	expiresAt time.Time
+}
+
+type cachedDriverVehicles struct {
+	vehicles  []*DriverVehicle
+	expiresAt time.Time
+}
+
+func newDriverProfileCache(ttl time.Duration) *driverProfileCache {
+	if ttl <= 0 {
+		ttl = defaultDriverProfileTTL
+	}
+	return &driverProfileCache{
+		ttl:      ttl,
+		profiles: make(map[string]cachedDriverProfile),
+		vehicles: make(map[string]cachedDriverVehicles),
+	}
+}
+
+// profilesFor returns the cached profiles and the driver IDs that need a lookup
+func (c *driverProfileCache) profilesFor(driverIDs []string) (map[string]*DriverProfile, []string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := time.Now()
+	profiles := make(map[string]*DriverProfile, len(driverIDs))
+	var missing []string
+	for _, driverID := range driverIDs {
+		if cached, ok := c.profiles[driverID]; ok && now.Before(cached.expiresAt) {
+			profiles[driverID] = cached.profile
+		} else {
+			missing = append(missing, driverID)
+		}
+	}
+	return profiles, missing
+}
+
+func (c *driverProfileCache) storeProfiles(driverIDs []string, profiles map[string]*DriverProfile) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	expiresAt := time.Now().Add(c.ttl)
+	for _, driverID := range driverIDs {
+		c.profiles[driverID] = cachedDriverProfile{profile: profiles[driverID], expiresAt: expiresAt}
+	}
+}
+
+// vehiclesFor returns the cached vehicles and the driver IDs that need a lookup
+func (c *driverProfileCache) vehiclesFor(driverIDs []string) (map[string][]*DriverVehicle, []string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := time.Now()
+	vehicles := make(map[string][]*DriverVehicle, len(driverIDs))
+	var missing []string
+	for _, driverID := range driverIDs {
+		if cached, ok := c.vehicles[driverID]; ok && now.Before(cached.expiresAt) {
+			vehicles[driverID] = cached.vehicles
+		} else {
+			missing = append(missing, driverID)
+		}
+	}
+	return vehicles, missing
+}
+
+func (c *driverProfileCache) storeVehicles(driverIDs []string, vehicles map[string][]*DriverVehicle) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	expiresAt := time.Now().Add(c.ttl)
+	for _, driverID := range driverIDs {
+		c.vehicles[driverID] = cachedDriverVehicles{vehicles: vehicles[driverID], expiresAt: expiresAt}
+	}
+}
+
+func driverProfileTTL(cfg *config.Config) time.Duration {
+	if cfg == nil {
+		return 0
+	}
+	return time.Duration(cfg.DriverProfileTTL) * time.Second
+}
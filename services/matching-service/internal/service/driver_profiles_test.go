@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rideshare-platform/shared/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeProfileProvider returns fixed profiles and vehicles, recording each batch it is asked for
+type fakeProfileProvider struct {
+	mutex        sync.Mutex
+	profiles     map[string]*DriverProfile
+	vehicles     map[string][]*DriverVehicle
+	err          error
+	profileCalls [][]string
+	vehicleCalls [][]string
+}
+
+func (f *fakeProfileProvider) GetDriverProfiles(ctx context.Context, driverIDs []string) (map[string]*DriverProfile, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.profileCalls = append(f.profileCalls, driverIDs)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.profiles, nil
+}
+
+func (f *fakeProfileProvider) GetDriverVehicles(ctx context.Context, driverIDs []string) (map[string][]*DriverVehicle, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.vehicleCalls = append(f.vehicleCalls, driverIDs)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.vehicles, nil
+}
+
+func newProfileTestDrivers() []*DriverLocation {
+	return []*DriverLocation{
+		{
+			DriverID:           "driver-1",
+			VehicleID:          "vehicle-1b",
+			Location:           &models.Location{Latitude: 37.775, Longitude: -122.419},
+			DistanceFromCenter: 0.5,
+			Status:             "available",
+			VehicleType:        "sedan",
+			Rating:             4.9,
+		},
+		{
+			DriverID:           "driver-2",
+			Location:           &models.Location{Latitude: 37.78, Longitude: -122.42},
+			DistanceFromCenter: 2,
+			Status:             "available",
+			VehicleType:        "sedan",
+			Rating:             4.6,
+		},
+	}
+}
+
+func TestDriverProfiles_MatchedDriversAreEnriched(t *testing.T) {
+	geo := &fakeGeoService{}
+	geo.setDrivers(newProfileTestDrivers()...)
+	service := newQueueTestService(geo)
+	provider := &fakeProfileProvider{
+		profiles: map[string]*DriverProfile{
+			"driver-1": {DriverID: "driver-1", Name: "Ada Byron", PhotoURL: "https://cdn.example.com/ada.jpg", Rating: 4.8, TripCount: 312},
+			"driver-2": {DriverID: "driver-2", Name: "Alan Kay", Rating: 4.6, TripCount: 41},
+		},
+		vehicles: map[string][]*DriverVehicle{
+			"driver-1": {
+				{VehicleID: "vehicle-1a", Details: VehicleDetails{Make: "Honda", Model: "Civic", VehicleType: "sedan"}},
+				{VehicleID: "vehicle-1b", Details: VehicleDetails{Make: "Toyota", Model: "Prius", LicensePlate: "ABC123", VehicleType: "sedan", Capacity: 4}, PhotoURL: "https://cdn.example.com/prius.jpg"},
+			},
+		},
+	}
+	service.SetDriverProfileProvider(provider)
+	service.SetDriverVehicleProvider(provider)
+
+	result, err := service.FindMatch(context.Background(), newQueueTestRequest("trip-profile-1", time.Minute))
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+
+	matched := result.MatchedDriver
+	assert.Equal(t, "driver-1", matched.DriverID)
+	assert.Equal(t, "Ada Byron", matched.DriverName)
+	assert.Equal(t, "https://cdn.example.com/ada.jpg", matched.DriverPhoto)
+	assert.Equal(t, 312, matched.TripCount)
+	assert.Equal(t, 4.9, matched.Rating, "the rating used for scoring is kept")
+	// The vehicle geo-service reported the driver on wins over their newest one
+	assert.Equal(t, "vehicle-1b", matched.VehicleID)
+	assert.Equal(t, "Prius", matched.VehicleInfo.Model)
+	assert.Equal(t, "ABC123", matched.VehicleInfo.LicensePlate)
+	assert.Equal(t, "https://cdn.example.com/prius.jpg", matched.VehiclePhoto)
+
+	if assert.Len(t, result.AlternativeOptions, 1) {
+		alternative := result.AlternativeOptions[0]
+		assert.Equal(t, "Alan Kay", alternative.DriverName)
+		assert.Equal(t, "sedan", alternative.VehicleInfo.VehicleType, "drivers without a vehicle keep the reported type")
+	}
+
+	// Both drivers are looked up in one batch per service, later matches hit the cache
+	assert.Equal(t, [][]string{{"driver-1", "driver-2"}}, provider.profileCalls)
+	assert.Equal(t, [][]string{{"driver-1", "driver-2"}}, provider.vehicleCalls)
+
+	_, err = service.FindMatch(context.Background(), newQueueTestRequest("trip-profile-2", time.Minute))
+	assert.NoError(t, err)
+	assert.Len(t, provider.profileCalls, 1)
+	assert.Len(t, provider.vehicleCalls, 1)
+}
+
+func TestDriverProfiles_LookupFailureKeepsMatching(t *testing.T) {
+	geo := &fakeGeoService{}
+	geo.setDrivers(newProfileTestDrivers()...)
+	service := newQueueTestService(geo)
+	provider := &fakeProfileProvider{err: errors.New("user-service unavailable")}
+	service.SetDriverProfileProvider(provider)
+	service.SetDriverVehicleProvider(provider)
+
+	result, err := service.FindMatch(context.Background(), newQueueTestRequest("trip-profile-3", time.Minute))
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Empty(t, result.MatchedDriver.DriverName)
+	assert.Equal(t, "sedan", result.MatchedDriver.VehicleInfo.VehicleType)
+
+	// Failures are not cached, the next match tries again
+	_, err = service.FindMatch(context.Background(), newQueueTestRequest("trip-profile-4", time.Minute))
+	assert.NoError(t, err)
+	assert.Len(t, provider.profileCalls, 2)
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+)
+
+// minRatingsForAverage is how many ratings a driver needs before their
+// rolling average replaces the rating reported by geo-service
+const minRatingsForAverage = 5
+
+// DriverRating is a driver's rolling average rating from trip-service
+type DriverRating struct {
+	DriverID    string
+	Average     float64
+	RatingCount int
+}
+
+// RatingProvider looks up drivers' rolling average ratings
+type RatingProvider interface {
+	// GetDriverRatings returns ratings keyed by driver ID, leaving out drivers with none
+	GetDriverRatings(ctx context.Context, driverIDs []string) (map[string]*DriverRating, error)
+}
+
+// SetRatingProvider sets the trip-service client used to score drivers on their real ratings
+func (s *AdvancedMatchingService) SetRatingProvider(provider RatingProvider) {
+	s.ratings = provider
+}
+
+// applyDriverRatings replaces each driver's rating with their rolling average
+// once they have enough ratings. Lookup failures keep the reported ratings so
+// matching can go on.
+func (s *AdvancedMatchingService) applyDriverRatings(ctx context.Context, drivers []*DriverLocation) []*DriverLocation {
+	if s.ratings == nil || len(drivers) == 0 {
+		return drivers
+	}
+
+	driverIDs := make([]string, 0, len(drivers))
+	for _, driver := range drivers {
+		driverIDs = append(driverIDs, driver.DriverID)
+	}
+
+	ratings, err := s.ratings.GetDriverRatings(ctx, driverIDs)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.WithContext(ctx).WithError(err).Warn("Failed to load driver ratings, using reported ratings")
+		}
+		return drivers
+	}
+
+	rated := make([]*DriverLocation, 0, len(drivers))
+	for _, driver := range drivers {
+		if rating, ok := ratings[driver.DriverID]; ok && rating.RatingCount >= minRatingsForAverage {
+			copied := *driver
+			copied.Rating = rating.Average
+			driver = &copied
+		}
+		rated = append(rated, driver)
+	}
+	return rated
+}
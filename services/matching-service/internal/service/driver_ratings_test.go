@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rideshare-platform/shared/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeRatingProvider returns fixed driver ratings or an error
+type fakeRatingProvider struct {
+	ratings map[string]*DriverRating
+	err     error
+}
+
+func (f *fakeRatingProvider) GetDriverRatings(ctx context.Context, driverIDs []string) (map[string]*DriverRating, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.ratings, nil
+}
+
+func newRatedTestDrivers() []*DriverLocation {
+	return []*DriverLocation{
+		{
+			DriverID:           "overrated-driver",
+			Location:           &models.Location{Latitude: 37.775, Longitude: -122.419},
+			DistanceFromCenter: 1,
+			Status:             "available",
+			VehicleType:        "sedan",
+			Rating:             4.9,
+		},
+		{
+			DriverID:           "new-driver",
+			Location:           &models.Location{Latitude: 37.776, Longitude: -122.418},
+			DistanceFromCenter: 1,
+			Status:             "available",
+			VehicleType:        "sedan",
+			Rating:             4.5,
+		},
+	}
+}
+
+func TestDriverRatings_MatchingUsesRollingAverage(t *testing.T) {
+	geo := &fakeGeoService{}
+	geo.setDrivers(newRatedTestDrivers()...)
+	service := newQueueTestService(geo)
+	service.SetRatingProvider(&fakeRatingProvider{ratings: map[string]*DriverRating{
+		"overrated-driver": {DriverID: "overrated-driver", Average: 3.2, RatingCount: 40},
+		// Too few ratings to replace the reported rating
+		"new-driver": {DriverID: "new-driver", Average: 1.0, RatingCount: 2},
+	}})
+
+	request := newQueueTestRequest("trip-rated-1", time.Minute)
+	request.Preferences = &RiderPreferences{MinDriverRating: 4.0}
+
+	result, err := service.FindMatch(context.Background(), request)
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, "new-driver", result.MatchedDriver.DriverID)
+	assert.Equal(t, 4.5, result.MatchedDriver.Rating)
+	assert.Empty(t, result.AlternativeOptions)
+
+	// The geo-service results are left untouched
+	assert.Equal(t, 4.9, geo.drivers[0].Rating)
+}
+
+func TestDriverRatings_LookupFailureKeepsReportedRatings(t *testing.T) {
+	geo := &fakeGeoService{}
+	geo.setDrivers(newRatedTestDrivers()...)
+	service := newQueueTestService(geo)
+	service.SetRatingProvider(&fakeRatingProvider{err: errors.New("trip-service unavailable")})
+
+	request := newQueueTestRequest("trip-rated-2", time.Minute)
+	request.Preferences = &RiderPreferences{MinDriverRating: 4.0}
+
+	result, err := service.FindMatch(context.Background(), request)
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, "overrated-driver", result.MatchedDriver.DriverID)
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+
+	"github.com/rideshare-platform/shared/models"
+)
+
+// FareEstimateRequest asks pricing-service to quote a trip before it starts
+type FareEstimateRequest struct {
+	TripID          string
+	RiderID         string
+	PickupLocation  *models.Location
+	Destination     *models.Location
+	VehicleType     string
+	DistanceKm      float64
+	DurationSeconds int
+	City            string // sets the currency the trip is quoted in
+}
+
+// FareEstimator quotes trips at pricing-service's vehicle type rates and live surge
+type FareEstimator interface {
+	EstimateFare(ctx context.Context, request *FareEstimateRequest) (*FareEstimate, error)
+}
+
+// SetFareEstimator sets the pricing-service client matched trips are quoted with
+func (s *AdvancedMatchingService) SetFareEstimator(estimator FareEstimator) {
+	s.fareEstimator = estimator
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeFareEstimator quotes $3 base, $1.50 per km and $0.25 per minute at a
+// configurable surge, recording each request
+type fakeFareEstimator struct {
+	mutex    sync.Mutex
+	surge    float64
+	err      error
+	requests []*FareEstimateRequest
+}
+
+func (f *fakeFareEstimator) EstimateFare(ctx context.Context, request *FareEstimateRequest) (*FareEstimate, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.requests = append(f.requests, request)
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	surge := f.surge
+	if surge == 0 {
+		surge = 1
+	}
+	estimate := &FareEstimate{
+		BaseFare:        3,
+		DistanceFare:    request.DistanceKm * 1.5,
+		TimeFare:        float64(request.DurationSeconds) / 60 * 0.25,
+		SurgeMultiplier: surge,
+		Currency:        "USD",
+	}
+	preSurge := estimate.BaseFare + estimate.DistanceFare + estimate.TimeFare
+	estimate.SurgeFare = preSurge * (surge - 1)
+	estimate.TotalEstimate = preSurge + estimate.SurgeFare
+	return estimate, nil
+}
+
+func TestFareEstimates_QuotedByPricingService(t *testing.T) {
+	geo := &fakeGeoService{}
+	geo.setDrivers(newProfileTestDrivers()...)
+	service := newQueueTestService(geo)
+	estimator := &fakeFareEstimator{surge: 1.5}
+	service.SetFareEstimator(estimator)
+
+	request := newQueueTestRequest("trip-fare-1", time.Minute)
+	result, err := service.FindMatch(context.Background(), request)
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+
+	// The fake geo-service reports 5km and 10 minutes for every route
+	if assert.Len(t, estimator.requests, 1) {
+		quoted := estimator.requests[0]
+		assert.Equal(t, "trip-fare-1", quoted.TripID)
+		assert.Equal(t, "rider-1", quoted.RiderID)
+		assert.Equal(t, "sedan", quoted.VehicleType)
+		assert.Equal(t, 5.0, quoted.DistanceKm)
+		assert.Equal(t, 600, quoted.DurationSeconds)
+		assert.Equal(t, request.Destination, quoted.Destination)
+	}
+	if assert.NotNil(t, result.EstimatedFare) {
+		assert.Equal(t, 1.5, result.EstimatedFare.SurgeMultiplier)
+		assert.InDelta(t, (3+7.5+2.5)*1.5, result.EstimatedFare.TotalEstimate, 0.001)
+	}
+}
+
+func TestFareEstimates_PricingFailureLeavesEstimateOut(t *testing.T) {
+	geo := &fakeGeoService{}
+	geo.setDrivers(newProfileTestDrivers()...)
+	service := newQueueTestService(geo)
+	service.SetFareEstimator(&fakeFareEstimator{err: errors.New("pricing-service unavailable")})
+
+	result, err := service.FindMatch(context.Background(), newQueueTestRequest("trip-fare-2", time.Minute))
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Nil(t, result.EstimatedFare)
+}
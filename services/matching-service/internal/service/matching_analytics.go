@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rideshare-platform/shared/analytics"
+	"github.com/rideshare-platform/shared/experiments"
+)
+
+// AnalyticsSource is the name matching rollups are recorded under
+const AnalyticsSource = "matching"
+
+// Matching rollup counters
+const (
+	counterRequests          = "requests"
+	counterMatches           = "matches"
+	counterQueued            = "queued"
+	counterQueuedMatches     = "queued_matches"
+	counterTimedOut          = "timed_out"
+	counterUnmatched         = "unmatched"
+	counterFailed            = "failed"
+	counterMatchSeconds      = "match_seconds"
+	counterMatchScore        = "match_score"
+	counterDriverDistanceKm  = "driver_distance_km"
+	counterDriverETASeconds  = "driver_eta_seconds"
+	counterOffersSent        = "offers_sent"
+	counterOffersAccepted    = "offers_accepted"
+	counterOffersDeclined    = "offers_declined"
+	counterOffersExpired     = "offers_expired"
+	counterOfferResponseSecs = "offer_response_seconds"
+)
+
+// MatchingMetrics summarises matching outcomes over a time range
+type MatchingMetrics struct {
+	From                 time.Time       `json:"from"`
+	To                   time.Time       `json:"to"`
+	TotalRequests        int64           `json:"total_requests"`
+	SuccessfulMatches    int64           `json:"successful_matches"`
+	QueuedRequests       int64           `json:"queued_requests"`
+	QueuedMatches        int64           `json:"queued_matches"`
+	TimedOut             int64           `json:"timed_out"`
+	Unmatched            int64           `json:"unmatched"`
+	Failed               int64           `json:"failed"`
+	SuccessRate          float64         `json:"success_rate"` // percent of requests matched
+	AvgMatchTimeSeconds  float64         `json:"avg_match_time_seconds"`
+	AvgMatchScore        float64         `json:"avg_match_score"`
+	AvgDriverDistanceKm  float64         `json:"avg_driver_distance_km"`
+	AvgETAMinutes        float64         `json:"avg_eta_minutes"`
+	OffersSent           int64           `json:"offers_sent"`
+	OffersAccepted       int64           `json:"offers_accepted"`
+	OffersDeclined       int64           `json:"offers_declined"`
+	OffersExpired        int64           `json:"offers_expired"`
+	OfferAcceptanceRate  float64         `json:"offer_acceptance_rate"` // percent of answered offers accepted
+	AvgOfferResponseSecs float64         `json:"avg_offer_response_seconds"`
+	Hourly               []*MatchingHour `json:"hourly"`
+}
+
+// MatchingHour is one hour of the matching breakdown
+type MatchingHour struct {
+	Hour     time.Time `json:"hour"`
+	Requests int64     `json:"requests"`
+	Matches  int64     `json:"matches"`
+}
+
+// SetAnalytics replaces the recorder matching outcomes are counted with
+func (s *AdvancedMatchingService) SetAnalytics(recorder *analytics.Recorder) {
+	s.analytics = recorder
+}
+
+// GetMatchingMetrics summarises the matching outcomes recorded in the range
+func (s *AdvancedMatchingService) GetMatchingMetrics(ctx context.Context, tr analytics.TimeRange) (*MatchingMetrics, error) {
+	if s.analytics == nil {
+		return nil, errors.New("matching analytics are not configured")
+	}
+	summary, err := s.analytics.Summarize(ctx, tr)
+	if err != nil {
+		return nil, err
+	}
+
+	totals := summary.Totals
+	metrics := &MatchingMetrics{
+		From:                 summary.From,
+		To:                   summary.To,
+		TotalRequests:        int64(totals[counterRequests]),
+		SuccessfulMatches:    int64(totals[counterMatches]),
+		QueuedRequests:       int64(totals[counterQueued]),
+		QueuedMatches:        int64(totals[counterQueuedMatches]),
+		TimedOut:             int64(totals[counterTimedOut]),
+		Unmatched:            int64(totals[counterUnmatched]),
+		Failed:               int64(totals[counterFailed]),
+		SuccessRate:          totals.Ratio(counterMatches, counterRequests) * 100,
+		AvgMatchTimeSeconds:  totals.Ratio(counterMatchSeconds, counterMatches),
+		AvgMatchScore:        totals.Ratio(counterMatchScore, counterMatches),
+		AvgDriverDistanceKm:  totals.Ratio(counterDriverDistanceKm, counterMatches),
+		AvgETAMinutes:        totals.Ratio(counterDriverETASeconds, counterMatches) / 60,
+		OffersSent:           int64(totals[counterOffersSent]),
+		OffersAccepted:       int64(totals[counterOffersAccepted]),
+		OffersDeclined:       int64(totals[counterOffersDeclined]),
+		OffersExpired:        int64(totals[counterOffersExpired]),
+		AvgOfferResponseSecs: totals.Ratio(counterOfferResponseSecs, counterOffersAccepted),
+		Hourly:               []*MatchingHour{},
+	}
+	if answered := metrics.OffersAccepted + metrics.OffersDeclined + metrics.OffersExpired; answered > 0 {
+		metrics.OfferAcceptanceRate = float64(metrics.OffersAccepted) / float64(answered) * 100
+	}
+	for _, rollup := range summary.Hours {
+		metrics.Hourly = append(metrics.Hourly, &MatchingHour{
+			Hour:     rollup.Hour,
+			Requests: int64(rollup.Counters[counterRequests]),
+			Matches:  int64(rollup.Counters[counterMatches]),
+		})
+	}
+	return metrics, nil
+}
+
+// record counts matching events into the current hour
+func (s *AdvancedMatchingService) record(at time.Time, counters analytics.Counters) {
+	if s.analytics != nil {
+		s.analytics.Record(at, counters)
+	}
+}
+
+// recordMatchRequest counts the outcome of a rider's match request, for the
+// experiment variants the request is in as well
+func (s *AdvancedMatchingService) recordMatchRequest(ctx context.Context, result *MatchingResult, err error) {
+	counters := analytics.Counters{counterRequests: 1}
+	switch {
+	case err != nil || result == nil:
+		counters.Add(counterFailed, 1)
+	case result.Success:
+		addMatchCounters(counters, result.MatchedDriver, result.ProcessingTime)
+	case result.Queued:
+		counters.Add(counterQueued, 1)
+	default:
+		counters.Add(counterUnmatched, 1)
+	}
+	s.record(time.Now(), experiments.Tag(ctx, counters))
+}
+
+// recordQueuedMatch counts a queued trip that found a driver, timing the
+// match from when the trip was queued
+func (s *AdvancedMatchingService) recordQueuedMatch(entry *QueuedMatch, driver *MatchedDriverInfo, now time.Time) {
+	counters := analytics.Counters{counterQueuedMatches: 1}
+	addMatchCounters(counters, driver, now.Sub(entry.EnqueuedAt))
+	s.record(now, counters)
+}
+
+// recordOfferResponse counts how a driver answered an offer
+func (s *AdvancedMatchingService) recordOfferResponse(offer *DriverOffer, status OfferStatus, now time.Time) {
+	counters := make(analytics.Counters)
+	switch status {
+	case OfferStatusAccepted:
+		counters.Add(counterOffersAccepted, 1)
+		counters.Add(counterOfferResponseSecs, now.Sub(offer.OfferedAt).Seconds())
+	case OfferStatusDeclined:
+		counters.Add(counterOffersDeclined, 1)
+	case OfferStatusExpired:
+		counters.Add(counterOffersExpired, 1)
+	}
+	s.record(now, counters)
+}
+
+func addMatchCounters(counters analytics.Counters, driver *MatchedDriverInfo, elapsed time.Duration) {
+	counters.Add(counterMatches, 1)
+	counters.Add(counterMatchSeconds, elapsed.Seconds())
+	if driver != nil {
+		counters.Add(counterMatchScore, driver.MatchScore)
+		counters.Add(counterDriverDistanceKm, driver.Distance)
+		counters.Add(counterDriverETASeconds, float64(driver.ETA))
+	}
+}
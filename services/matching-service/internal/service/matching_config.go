@@ -0,0 +1,488 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/shared/city"
+)
+
+// DefaultMatchingCity is the city whose matching config applies to cities
+// without one of their own
+const DefaultMatchingCity = "default"
+
+var (
+	// ErrMatchingConfigNotFound is returned for cities and versions that were never configured
+	ErrMatchingConfigNotFound = errors.New("matching config not found")
+	// ErrInvalidMatchingConfig is returned when saving a config that fails validation
+	ErrInvalidMatchingConfig = errors.New("invalid matching config")
+)
+
+// MatchingConfig tunes how drivers are searched for and scored in a city.
+// Every saved change gets the next version of its city, which is logged
+// with each match so a ranking can be reproduced later.
+type MatchingConfig struct {
+	City    string `json:"city"`
+	Version int64  `json:"version"` // 0 for the built-in defaults
+
+	// Points a driver's distance, pickup ETA, rating, availability, offer
+	// acceptance rate, trips not cancelled and completion streak are worth,
+	// adding up to 100
+	DistanceWeight     float64 `json:"distance_weight"`
+	ETAWeight          float64 `json:"eta_weight"`
+	RatingWeight       float64 `json:"rating_weight"`
+	AvailabilityWeight float64 `json:"availability_weight"`
+	AcceptanceWeight   float64 `json:"acceptance_weight"`
+	CancellationWeight float64 `json:"cancellation_weight"`
+	StreakWeight       float64 `json:"streak_weight"`
+	// Trips completed in a row at which a driver scores the full streak weight
+	StreakTarget int `json:"streak_target"`
+	// Distance and pickup ETA at which a driver scores nothing for them
+	ScoreDistanceCapKm float64 `json:"score_distance_cap_km"`
+	ScoreETACapSeconds int     `json:"score_eta_cap_seconds"`
+
+	// The search starts at InitialRadiusKm and widens by RadiusStepKm until
+	// MinCandidates drivers are found or MaxRadiusKm is reached
+	InitialRadiusKm     float64 `json:"initial_radius_km"`
+	RadiusStepKm        float64 `json:"radius_step_km"`
+	MaxRadiusKm         float64 `json:"max_radius_km"`
+	MaxPickupDistanceKm float64 `json:"max_pickup_distance_km"`
+	MinCandidates       int     `json:"min_candidates"`
+
+	UpdatedBy string    `json:"updated_by,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+}
+
+// DefaultMatchingConfig returns the built-in config, used for cities when
+// no default has been saved
+func DefaultMatchingConfig() *MatchingConfig {
+	return &MatchingConfig{
+		City:                DefaultMatchingCity,
+		DistanceWeight:      35,
+		ETAWeight:           25,
+		RatingWeight:        15,
+		AvailabilityWeight:  5,
+		AcceptanceWeight:    10,
+		CancellationWeight:  5,
+		StreakWeight:        5,
+		StreakTarget:        10,
+		ScoreDistanceCapKm:  15,
+		ScoreETACapSeconds:  20 * 60,
+		InitialRadiusKm:     5,
+		RadiusStepKm:        5,
+		MaxRadiusKm:         20,
+		MaxPickupDistanceKm: 15,
+		MinCandidates:       5,
+	}
+}
+
+// Validate checks that the weights add up to 100 and the search radii are usable
+func (c *MatchingConfig) Validate() error {
+	if c.City == "" {
+		return fmt.Errorf("%w: city is required", ErrInvalidMatchingConfig)
+	}
+	weights := []float64{
+		c.DistanceWeight, c.ETAWeight, c.RatingWeight, c.AvailabilityWeight,
+		c.AcceptanceWeight, c.CancellationWeight, c.StreakWeight,
+	}
+	total := 0.0
+	for _, weight := range weights {
+		if weight < 0 {
+			return fmt.Errorf("%w: weights cannot be negative", ErrInvalidMatchingConfig)
+		}
+		total += weight
+	}
+	if math.Abs(total-100) > 0.01 {
+		return fmt.Errorf("%w: weights must add up to 100, got %g", ErrInvalidMatchingConfig, total)
+	}
+	if c.StreakWeight > 0 && c.StreakTarget < 1 {
+		return fmt.Errorf("%w: streak target must be at least 1", ErrInvalidMatchingConfig)
+	}
+	if c.ScoreDistanceCapKm <= 0 || c.ScoreETACapSeconds <= 0 {
+		return fmt.Errorf("%w: score caps must be positive", ErrInvalidMatchingConfig)
+	}
+	if c.InitialRadiusKm <= 0 || c.RadiusStepKm <= 0 {
+		return fmt.Errorf("%w: initial radius and radius step must be positive", ErrInvalidMatchingConfig)
+	}
+	if c.MaxRadiusKm < c.InitialRadiusKm {
+		return fmt.Errorf("%w: max radius cannot be below the initial radius", ErrInvalidMatchingConfig)
+	}
+	if c.MaxPickupDistanceKm <= 0 || c.MaxPickupDistanceKm > c.MaxRadiusKm {
+		return fmt.Errorf("%w: max pickup distance must be positive and within the max radius", ErrInvalidMatchingConfig)
+	}
+	if c.MinCandidates < 1 {
+		return fmt.Errorf("%w: min candidates must be at least 1", ErrInvalidMatchingConfig)
+	}
+	return nil
+}
+
+// scoringWeights returns the weights and caps drivers are scored with
+func (c *MatchingConfig) scoringWeights() scoringWeights {
+	return scoringWeights{
+		distance:      c.DistanceWeight,
+		eta:           c.ETAWeight,
+		rating:        c.RatingWeight,
+		availability:  c.AvailabilityWeight,
+		acceptance:    c.AcceptanceWeight,
+		cancellation:  c.CancellationWeight,
+		streak:        c.StreakWeight,
+		distanceCapKm: c.ScoreDistanceCapKm,
+		etaCapSeconds: float64(c.ScoreETACapSeconds),
+		streakTarget:  float64(c.StreakTarget),
+	}
+}
+
+// MatchingConfigStore keeps the matching config of each city and every
+// version saved before
+type MatchingConfigStore interface {
+	// List returns the current config of every configured city
+	List(ctx context.Context) ([]*MatchingConfig, error)
+	// Get returns a version of a city's config, ErrMatchingConfigNotFound
+	// if it was never saved
+	Get(ctx context.Context, city string, version int64) (*MatchingConfig, error)
+	// Save stores config as its city's next version and returns it as saved
+	Save(ctx context.Context, config *MatchingConfig) (*MatchingConfig, error)
+	// Delete removes a city's current config, keeping its past versions
+	Delete(ctx context.Context, city string) error
+}
+
+// matchingConfigRegistry caches the configs of the store, so matching does
+// not read the store for every request
+type matchingConfigRegistry struct {
+	store   MatchingConfigStore
+	configs map[string]*MatchingConfig
+	mutex   sync.RWMutex
+}
+
+// SetMatchingConfigStore sets the store per-city matching configs are kept
+// in. Without one every city is matched with DefaultMatchingConfig. The
+// configs are loaded by RefreshMatchingConfigs.
+func (s *AdvancedMatchingService) SetMatchingConfigStore(store MatchingConfigStore) {
+	s.matchingConfigs = &matchingConfigRegistry{store: store, configs: make(map[string]*MatchingConfig)}
+}
+
+// RefreshMatchingConfigs reloads the matching configs from the store
+func (s *AdvancedMatchingService) RefreshMatchingConfigs(ctx context.Context) error {
+	registry := s.matchingConfigs
+	if registry == nil {
+		return nil
+	}
+	list, err := registry.store.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	configs := make(map[string]*MatchingConfig, len(list))
+	for _, config := range list {
+		configs[config.City] = config
+	}
+	registry.mutex.Lock()
+	registry.configs = configs
+	registry.mutex.Unlock()
+	return nil
+}
+
+// StartMatchingConfigRefresh reloads the matching configs every interval
+// until ctx is done, picking up changes saved through other instances
+func (s *AdvancedMatchingService) StartMatchingConfigRefresh(ctx context.Context, interval time.Duration) {
+	if s.matchingConfigs == nil || interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.RefreshMatchingConfigs(ctx); err != nil && s.logger != nil {
+				s.logger.WithError(err).Warn("Failed to reload matching configs")
+			}
+		}
+	}
+}
+
+// MatchingConfigFor returns the config a city is matched with: its own, the
+// saved default or the built-in one, in that order
+func (s *AdvancedMatchingService) MatchingConfigFor(cityID string) *MatchingConfig {
+	if registry := s.matchingConfigs; registry != nil {
+		registry.mutex.RLock()
+		defer registry.mutex.RUnlock()
+		if config, exists := registry.configs[city.Normalize(cityID)]; exists {
+			return config
+		}
+		if config, exists := registry.configs[DefaultMatchingCity]; exists {
+			return config
+		}
+	}
+	return DefaultMatchingConfig()
+}
+
+// ListMatchingConfigs returns the saved config of every configured city
+func (s *AdvancedMatchingService) ListMatchingConfigs(ctx context.Context) ([]*MatchingConfig, error) {
+	if s.matchingConfigs == nil {
+		return []*MatchingConfig{}, nil
+	}
+	return s.matchingConfigs.store.List(ctx)
+}
+
+// GetMatchingConfig returns a saved version of a city's config. Version 0
+// returns the config the city is currently matched with.
+func (s *AdvancedMatchingService) GetMatchingConfig(ctx context.Context, cityID string, version int64) (*MatchingConfig, error) {
+	if version == 0 {
+		return s.MatchingConfigFor(cityID), nil
+	}
+	if s.matchingConfigs == nil {
+		return nil, ErrMatchingConfigNotFound
+	}
+	return s.matchingConfigs.store.Get(ctx, city.Normalize(cityID), version)
+}
+
+// SaveMatchingConfig validates and saves a city's config as its next
+// version. It applies to matches on this instance at once and on the others
+// from their next refresh.
+func (s *AdvancedMatchingService) SaveMatchingConfig(ctx context.Context, config *MatchingConfig) (*MatchingConfig, error) {
+	if s.matchingConfigs == nil {
+		return nil, errors.New("matching configs are not configured")
+	}
+	config.City = city.Normalize(config.City)
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	config.UpdatedAt = time.Now().UTC()
+
+	saved, err := s.matchingConfigs.store.Save(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	s.refreshAfterChange(ctx)
+	return saved, nil
+}
+
+// DeleteMatchingConfig removes a city's config, so it is matched with the
+// default again
+func (s *AdvancedMatchingService) DeleteMatchingConfig(ctx context.Context, cityID string) error {
+	if s.matchingConfigs == nil {
+		return ErrMatchingConfigNotFound
+	}
+	if err := s.matchingConfigs.store.Delete(ctx, city.Normalize(cityID)); err != nil {
+		return err
+	}
+	s.refreshAfterChange(ctx)
+	return nil
+}
+
+// refreshAfterChange reloads the configs once one has changed. A failure
+// only delays the change until the next periodic refresh.
+func (s *AdvancedMatchingService) refreshAfterChange(ctx context.Context) {
+	if err := s.RefreshMatchingConfigs(ctx); err != nil && s.logger != nil {
+		s.logger.WithError(err).Warn("Failed to reload matching configs after a change")
+	}
+}
+
+// PostgresMatchingConfigStore keeps matching configs in the matching_configs
+// table and every version saved in matching_config_versions
+type PostgresMatchingConfigStore struct {
+	db *sql.DB
+}
+
+// NewPostgresMatchingConfigStore creates a matching config store backed by PostgreSQL
+func NewPostgresMatchingConfigStore(db *sql.DB) *PostgresMatchingConfigStore {
+	return &PostgresMatchingConfigStore{db: db}
+}
+
+// List returns the current config of every configured city
+func (s *PostgresMatchingConfigStore) List(ctx context.Context) ([]*MatchingConfig, error) {
+	query := `
+		SELECT city, version, settings, updated_by, updated_at
+		FROM matching_configs
+		ORDER BY city`
+
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list matching configs: %w", err)
+	}
+	defer rows.Close()
+
+	configs := []*MatchingConfig{}
+	for rows.Next() {
+		config, err := scanMatchingConfig(rows)
+		if err != nil {
+			return nil, err
+		}
+		configs = append(configs, config)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list matching configs: %w", err)
+	}
+	return configs, nil
+}
+
+// Get returns a version of a city's config
+func (s *PostgresMatchingConfigStore) Get(ctx context.Context, city string, version int64) (*MatchingConfig, error) {
+	query := `
+		SELECT city, version, settings, updated_by, updated_at
+		FROM matching_config_versions
+		WHERE city = $1 AND version = $2`
+
+	config, err := scanMatchingConfig(s.db.QueryRowContext(ctx, query, city, version))
+	if err == sql.ErrNoRows {
+		return nil, ErrMatchingConfigNotFound
+	}
+	return config, err
+}
+
+// Save stores config as its city's next version, counting on from versions
+// deleted before
+func (s *PostgresMatchingConfigStore) Save(ctx context.Context, config *MatchingConfig) (*MatchingConfig, error) {
+	settings, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode matching config: %w", err)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save matching config: %w", err)
+	}
+	defer tx.Rollback()
+
+	saved := *config
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO matching_config_versions (city, version, settings, updated_by, updated_at)
+		SELECT $1, COALESCE(MAX(version), 0) + 1, $2, $3, $4
+		FROM matching_config_versions
+		WHERE city = $1
+		RETURNING version`,
+		config.City, settings, config.UpdatedBy, config.UpdatedAt,
+	).Scan(&saved.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save matching config: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO matching_configs (city, version, settings, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (city) DO UPDATE SET
+			version = EXCLUDED.version,
+			settings = EXCLUDED.settings,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at`,
+		saved.City, saved.Version, settings, saved.UpdatedBy, saved.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save matching config: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to save matching config: %w", err)
+	}
+	return &saved, nil
+}
+
+// Delete removes a city's current config
+func (s *PostgresMatchingConfigStore) Delete(ctx context.Context, city string) error {
+	result, err := s.db.ExecContext(ctx, `DELETE FROM matching_configs WHERE city = $1`, city)
+	if err != nil {
+		return fmt.Errorf("failed to delete matching config: %w", err)
+	}
+	if deleted, err := result.RowsAffected(); err == nil && deleted == 0 {
+		return ErrMatchingConfigNotFound
+	}
+	return nil
+}
+
+// scanMatchingConfig reads a config row, the settings column holding the
+// config encoded as JSON
+func scanMatchingConfig(row interface{ Scan(...interface{}) error }) (*MatchingConfig, error) {
+	config := &MatchingConfig{}
+	var city, updatedBy string
+	var version int64
+	var settings []byte
+	var updatedAt time.Time
+	if err := row.Scan(&city, &version, &settings, &updatedBy, &updatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to read matching config: %w", err)
+	}
+	if err := json.Unmarshal(settings, config); err != nil {
+		return nil, fmt.Errorf("failed to decode matching config: %w", err)
+	}
+	config.City, config.Version, config.UpdatedBy, config.UpdatedAt = city, version, updatedBy, updatedAt
+	return config, nil
+}
+
+// MemoryMatchingConfigStore keeps matching configs in memory
+type MemoryMatchingConfigStore struct {
+	current  map[string]*MatchingConfig
+	versions map[string][]*MatchingConfig
+	mutex    sync.RWMutex
+}
+
+// NewMemoryMatchingConfigStore creates an in-memory matching config store
+func NewMemoryMatchingConfigStore() *MemoryMatchingConfigStore {
+	return &MemoryMatchingConfigStore{
+		current:  make(map[string]*MatchingConfig),
+		versions: make(map[string][]*MatchingConfig),
+	}
+}
+
+// List returns copies of the current configs, ordered by city
+func (s *MemoryMatchingConfigStore) List(ctx context.Context) ([]*MatchingConfig, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	configs := make([]*MatchingConfig, 0, len(s.current))
+	for _, config := range s.current {
+		copied := *config
+		configs = append(configs, &copied)
+	}
+	sort.Slice(configs, func(i, j int) bool { return configs[i].City < configs[j].City })
+	return configs, nil
+}
+
+// Get returns a copy of a version of a city's config
+func (s *MemoryMatchingConfigStore) Get(ctx context.Context, city string, version int64) (*MatchingConfig, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	versions := s.versions[city]
+	if version < 1 || version > int64(len(versions)) {
+		return nil, ErrMatchingConfigNotFound
+	}
+	copied := *versions[version-1]
+	return &copied, nil
+}
+
+// Save stores config as its city's next version
+func (s *MemoryMatchingConfigStore) Save(ctx context.Context, config *MatchingConfig) (*MatchingConfig, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	saved := *config
+	saved.Version = int64(len(s.versions[config.City])) + 1
+	s.versions[config.City] = append(s.versions[config.City], &saved)
+	s.current[config.City] = &saved
+
+	copied := saved
+	return &copied, nil
+}
+
+// Delete removes a city's current config
+func (s *MemoryMatchingConfigStore) Delete(ctx context.Context, city string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, exists := s.current[city]; !exists {
+		return ErrMatchingConfigNotFound
+	}
+	delete(s.current, city)
+	return nil
+}
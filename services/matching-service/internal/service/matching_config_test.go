@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rideshare-platform/shared/models"
+)
+
+func newCityMatchingConfig(cityID string) *MatchingConfig {
+	config := DefaultMatchingConfig()
+	config.City = cityID
+	config.DistanceWeight, config.ETAWeight = 20, 40
+	config.InitialRadiusKm, config.RadiusStepKm, config.MaxRadiusKm = 2, 3, 8
+	config.MaxPickupDistanceKm = 8
+	config.MinCandidates = 2
+	return config
+}
+
+func TestMatchingConfig_Validate(t *testing.T) {
+	assert.NoError(t, DefaultMatchingConfig().Validate())
+
+	config := DefaultMatchingConfig()
+	config.RatingWeight = 30
+	assert.ErrorIs(t, config.Validate(), ErrInvalidMatchingConfig, "weights add up to 115")
+
+	config = DefaultMatchingConfig()
+	config.MaxPickupDistanceKm = 25
+	assert.ErrorIs(t, config.Validate(), ErrInvalidMatchingConfig, "pickup distance beyond the max radius")
+
+	config = DefaultMatchingConfig()
+	config.MinCandidates = 0
+	assert.ErrorIs(t, config.Validate(), ErrInvalidMatchingConfig)
+
+	config = DefaultMatchingConfig()
+	config.StreakTarget = 0
+	assert.ErrorIs(t, config.Validate(), ErrInvalidMatchingConfig, "streak weighted without a target")
+}
+
+func TestMatchingConfig_SaveVersionsAndFallBack(t *testing.T) {
+	service := newQueueTestService(nil)
+	service.SetMatchingConfigStore(NewMemoryMatchingConfigStore())
+	ctx := context.Background()
+
+	assert.Equal(t, int64(0), service.MatchingConfigFor("istanbul").Version, "built-in defaults before anything is saved")
+
+	saved, err := service.SaveMatchingConfig(ctx, newCityMatchingConfig(" Istanbul "))
+	require.NoError(t, err)
+	assert.Equal(t, "istanbul", saved.City)
+	assert.Equal(t, int64(1), saved.Version)
+
+	second := newCityMatchingConfig("istanbul")
+	second.MinCandidates = 3
+	saved, err = service.SaveMatchingConfig(ctx, second)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), saved.Version)
+	assert.Equal(t, 3, service.MatchingConfigFor("ISTANBUL").MinCandidates, "saved configs apply at once")
+
+	first, err := service.GetMatchingConfig(ctx, "istanbul", 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, first.MinCandidates)
+
+	invalid := newCityMatchingConfig("istanbul")
+	invalid.AvailabilityWeight = 50
+	_, err = service.SaveMatchingConfig(ctx, invalid)
+	assert.ErrorIs(t, err, ErrInvalidMatchingConfig)
+
+	fallback := DefaultMatchingConfig()
+	fallback.MaxRadiusKm = 25
+	_, err = service.SaveMatchingConfig(ctx, fallback)
+	require.NoError(t, err)
+	assert.Equal(t, 25.0, service.MatchingConfigFor("ankara").MaxRadiusKm, "cities without a config use the saved default")
+
+	require.NoError(t, service.DeleteMatchingConfig(ctx, "istanbul"))
+	assert.Equal(t, DefaultMatchingCity, service.MatchingConfigFor("istanbul").City)
+	assert.ErrorIs(t, service.DeleteMatchingConfig(ctx, "istanbul"), ErrMatchingConfigNotFound)
+
+	saved, err = service.SaveMatchingConfig(ctx, newCityMatchingConfig("istanbul"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), saved.Version, "versions count on after a delete")
+
+	_, err = service.GetMatchingConfig(ctx, "istanbul", 9)
+	assert.ErrorIs(t, err, ErrMatchingConfigNotFound)
+}
+
+func TestMatchingConfig_RefreshPicksUpOtherInstances(t *testing.T) {
+	store := NewMemoryMatchingConfigStore()
+	service := newQueueTestService(nil)
+	service.SetMatchingConfigStore(store)
+	ctx := context.Background()
+
+	_, err := store.Save(ctx, newCityMatchingConfig("istanbul"))
+	require.NoError(t, err)
+	assert.Equal(t, DefaultMatchingCity, service.MatchingConfigFor("istanbul").City)
+
+	require.NoError(t, service.RefreshMatchingConfigs(ctx))
+	assert.Equal(t, int64(1), service.MatchingConfigFor("istanbul").Version)
+}
+
+func TestMatchingConfig_AppliedToCitySearchAndScore(t *testing.T) {
+	geo := &fakeGeoService{}
+	service := newQueueTestService(geo)
+	service.SetMatchingConfigStore(NewMemoryMatchingConfigStore())
+	ctx := context.Background()
+
+	_, err := service.SaveMatchingConfig(ctx, newCityMatchingConfig("istanbul"))
+	require.NoError(t, err)
+
+	geo.setDrivers(&DriverLocation{
+		DriverID:           "driver-1",
+		Location:           &models.Location{Latitude: 37.78, Longitude: -122.41},
+		DistanceFromCenter: 1,
+		Status:             "available",
+		VehicleType:        "sedan",
+		Rating:             5,
+	})
+	request := newQueueTestRequest("trip-config-1", time.Minute)
+	request.City = "istanbul"
+	result, err := service.FindMatch(ctx, request)
+	require.NoError(t, err)
+	require.True(t, result.Success)
+
+	// The search widens by the city's step until its minimum of two drivers
+	// is found or its max radius is reached
+	assert.Equal(t, []float64{2, 5, 8, 8}, geo.radii)
+
+	// Scored 20 for distance, 40 for ETA, 15 for rating, 5 for availability
+	// and, as a driver without history, 10 for acceptance, 5 for not
+	// cancelling and nothing for a streak
+	distance := 20 * (15.0 - 1) / 15
+	eta := 40 * (1200.0 - 600) / 1200
+	assert.InDelta(t, distance+eta+15+5+10+5, result.MatchedDriver.MatchScore, 0.001)
+
+	params := service.expandedSearchParams(request, 1)
+	assert.Equal(t, 18.0, params.MaxRadiusKm, "retries widen from the city's radius")
+	assert.Equal(t, int64(1), params.Config.Version)
+}
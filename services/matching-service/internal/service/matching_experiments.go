@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rideshare-platform/shared/analytics"
+	"github.com/rideshare-platform/shared/experiments"
+)
+
+// ScoringExperiment tests the weights drivers are scored by. Its variants
+// may set distance_weight, eta_weight and rating_weight; weights a variant
+// leaves out keep those of the city's matching config.
+const ScoringExperiment = "matching_scoring"
+
+// scoringWeights are the points a driver's distance, pickup ETA, rating,
+// availability and recent behavior are worth out of 100, the distance and
+// ETA at which a driver scores nothing for them and the streak at which a
+// driver scores the full streak weight
+type scoringWeights struct {
+	distance     float64
+	eta          float64
+	rating       float64
+	availability float64
+	acceptance   float64
+	cancellation float64
+	streak       float64
+
+	distanceCapKm float64
+	etaCapSeconds float64
+	streakTarget  float64
+}
+
+var defaultScoringWeights = DefaultMatchingConfig().scoringWeights()
+
+// VariantMetrics compares the matching outcomes of one experiment variant
+type VariantMetrics struct {
+	Variant             string  `json:"variant"`
+	Requests            int64   `json:"requests"`
+	Matches             int64   `json:"matches"`
+	ConversionRate      float64 `json:"conversion_rate"` // percent of requests matched
+	AvgMatchTimeSeconds float64 `json:"avg_match_time_seconds"`
+	AvgETAMinutes       float64 `json:"avg_eta_minutes"`
+	AvgDriverDistanceKm float64 `json:"avg_driver_distance_km"`
+}
+
+// ExperimentMetrics compares an experiment's variants over a time range
+type ExperimentMetrics struct {
+	Experiment string            `json:"experiment"`
+	From       time.Time         `json:"from"`
+	To         time.Time         `json:"to"`
+	Variants   []*VariantMetrics `json:"variants"`
+}
+
+// SetExperiments attaches the experiments match requests are assigned to.
+// Requests keep the assignments they arrive with.
+func (s *AdvancedMatchingService) SetExperiments(registry *experiments.Registry) {
+	s.experiments = registry
+}
+
+// scoringWeights returns the weights of the city's config, overridden by
+// the scoring variant the request is in
+func (s *AdvancedMatchingService) scoringWeights(ctx context.Context, config *MatchingConfig) scoringWeights {
+	weights := config.scoringWeights()
+	weights.distance = s.experiments.Param(ctx, ScoringExperiment, "distance_weight", weights.distance)
+	weights.eta = s.experiments.Param(ctx, ScoringExperiment, "eta_weight", weights.eta)
+	weights.rating = s.experiments.Param(ctx, ScoringExperiment, "rating_weight", weights.rating)
+	return weights
+}
+
+// GetExperimentMetrics compares the match requests of an experiment's
+// variants recorded in the range
+func (s *AdvancedMatchingService) GetExperimentMetrics(ctx context.Context, experiment string, tr analytics.TimeRange) (*ExperimentMetrics, error) {
+	if s.analytics == nil {
+		return nil, errors.New("matching analytics are not configured")
+	}
+	if _, exists := s.experiments.Get(experiment); !exists {
+		return nil, fmt.Errorf("%w: %s", experiments.ErrUnknownExperiment, experiment)
+	}
+	summary, err := s.analytics.Summarize(ctx, tr)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ExperimentMetrics{
+		Experiment: experiment,
+		From:       summary.From,
+		To:         summary.To,
+		Variants:   []*VariantMetrics{},
+	}
+	for _, variant := range experiments.Compare(summary, experiment) {
+		counters := variant.Counters
+		result.Variants = append(result.Variants, &VariantMetrics{
+			Variant:             variant.Variant,
+			Requests:            int64(counters[counterRequests]),
+			Matches:             int64(counters[counterMatches]),
+			ConversionRate:      counters.Ratio(counterMatches, counterRequests) * 100,
+			AvgMatchTimeSeconds: counters.Ratio(counterMatchSeconds, counterMatches),
+			AvgETAMinutes:       counters.Ratio(counterDriverETASeconds, counterMatches) / 60,
+			AvgDriverDistanceKm: counters.Ratio(counterDriverDistanceKm, counterMatches),
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rideshare-platform/services/matching-service/internal/config"
+	"github.com/rideshare-platform/shared/analytics"
+	"github.com/rideshare-platform/shared/experiments"
+)
+
+func newScoringExperiment(t *testing.T) *experiments.Registry {
+	t.Helper()
+	registry, err := experiments.NewRegistry(&experiments.Config{Experiments: map[string]*experiments.Experiment{
+		ScoringExperiment: {
+			Unit: experiments.UnitTrip,
+			Variants: []*experiments.Variant{
+				{Name: "control", Weight: 1},
+				{Name: "eta_first", Weight: 1, Params: map[string]float64{"distance_weight": 20, "eta_weight": 50}},
+			},
+		},
+	}})
+	require.NoError(t, err)
+	return registry
+}
+
+func TestScoringWeights_FollowVariant(t *testing.T) {
+	service := NewSimpleMatchingService(&config.Config{})
+	service.SetExperiments(newScoringExperiment(t))
+
+	ctx := context.Background()
+	config := DefaultMatchingConfig()
+	assert.Equal(t, defaultScoringWeights, service.scoringWeights(ctx, config))
+
+	ctx = experiments.WithAssignments(ctx, experiments.Assignments{ScoringExperiment: "eta_first"})
+	expected := defaultScoringWeights
+	expected.distance, expected.eta = 20, 50
+	assert.Equal(t, expected, service.scoringWeights(ctx, config))
+}
+
+func TestGetExperimentMetrics(t *testing.T) {
+	service := NewSimpleMatchingService(&config.Config{})
+	service.SetExperiments(newScoringExperiment(t))
+	ctx := context.Background()
+
+	// Mock mode matches every request, each trip lands in one variant
+	for _, tripID := range []string{"trip-exp-1", "trip-exp-2", "trip-exp-3", "trip-exp-4"} {
+		_, err := service.FindMatch(ctx, newOfferTestRequest(tripID))
+		require.NoError(t, err)
+	}
+
+	now := time.Now()
+	_, err := service.GetExperimentMetrics(ctx, "unknown", analytics.TimeRange{From: now.Add(-time.Hour), To: now.Add(time.Minute)})
+	assert.ErrorIs(t, err, experiments.ErrUnknownExperiment)
+
+	metrics, err := service.GetExperimentMetrics(ctx, ScoringExperiment, analytics.TimeRange{From: now.Add(-time.Hour), To: now.Add(time.Minute)})
+	require.NoError(t, err)
+
+	var requests int64
+	for _, variant := range metrics.Variants {
+		requests += variant.Requests
+		assert.Equal(t, 100.0, variant.ConversionRate)
+		assert.Equal(t, 5.0, variant.AvgETAMinutes)
+	}
+	assert.Equal(t, int64(4), requests)
+
+	overall, err := service.GetMatchingMetrics(ctx, analytics.TimeRange{From: now.Add(-time.Hour), To: now.Add(time.Minute)})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), overall.TotalRequests)
+}
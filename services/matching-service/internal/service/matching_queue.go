@@ -0,0 +1,383 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/rideshare-platform/shared/analytics"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+)
+
+// searchParams controls how widely a matching pass searches for drivers
+type searchParams struct {
+	MaxRadiusKm         float64
+	MaxPickupDistanceKm float64
+	RatingRelaxation    float64 // subtracted from the rider's minimum rating
+	AllowUpgrades       bool    // accept larger vehicle types than requested
+
+	// Config is the matching config of the request's city the pass applies
+	Config *MatchingConfig
+}
+
+// searchParams returns the parameters for the initial synchronous match,
+// from the matching config of the request's city
+func (s *AdvancedMatchingService) searchParams(request *MatchingRequest) searchParams {
+	config := s.MatchingConfigFor(request.City)
+	return searchParams{
+		MaxRadiusKm:         config.MaxRadiusKm,
+		MaxPickupDistanceKm: config.MaxPickupDistanceKm,
+		Config:              config,
+	}
+}
+
+// vehicleUpgrades lists the vehicle types a rider may be offered instead of the requested one
+var vehicleUpgrades = map[models.VehicleType][]models.VehicleType{
+	models.VehicleTypeHatchback: {models.VehicleTypeSedan, models.VehicleTypeSUV},
+	models.VehicleTypeSedan:     {models.VehicleTypeSUV, models.VehicleTypeLuxury},
+	models.VehicleTypeSUV:       {models.VehicleTypeVan},
+}
+
+// isVehicleUpgrade reports whether offered is an acceptable upgrade over requested
+func isVehicleUpgrade(requested, offered string, passengers int) bool {
+	if models.GetVehicleTypeCapacity(models.VehicleType(offered)) < passengers {
+		return false
+	}
+	for _, upgrade := range vehicleUpgrades[models.VehicleType(requested)] {
+		if string(upgrade) == offered {
+			return true
+		}
+	}
+	return false
+}
+
+// SetProgressNotifier sets the notifier used to push matching progress to riders
+func (s *AdvancedMatchingService) SetProgressNotifier(notifier ProgressNotifier) {
+	s.progress = notifier
+}
+
+// expandedSearchParams widens the search radius and relaxes filters for a retry
+func (s *AdvancedMatchingService) expandedSearchParams(request *MatchingRequest, retry int) searchParams {
+	params := s.searchParams(request)
+
+	step, maxRadius, relaxStep, upgradeAfter := 5.0, 40.0, 0.25, 2
+	if s.config != nil {
+		if s.config.QueueRadiusStepKm > 0 {
+			step = s.config.QueueRadiusStepKm
+		}
+		if s.config.QueueMaxSearchRadiusKm > 0 {
+			maxRadius = s.config.QueueMaxSearchRadiusKm
+		}
+		if s.config.QueueRatingRelaxStep > 0 {
+			relaxStep = s.config.QueueRatingRelaxStep
+		}
+		if s.config.QueueUpgradeAfterRetry > 0 {
+			upgradeAfter = s.config.QueueUpgradeAfterRetry
+		}
+	}
+
+	params.MaxRadiusKm = math.Max(params.MaxRadiusKm, math.Min(params.MaxRadiusKm+float64(retry)*step, maxRadius))
+	params.MaxPickupDistanceKm = math.Min(params.MaxPickupDistanceKm+float64(retry)*step, params.MaxRadiusKm)
+	params.RatingRelaxation = float64(retry) * relaxStep
+	params.AllowUpgrades = retry >= upgradeAfter
+
+	return params
+}
+
+// retryDelay returns the exponential backoff before the given retry
+func (s *AdvancedMatchingService) retryDelay(retry int) time.Duration {
+	base, maxDelay := 1000, 15000
+	if s.config != nil {
+		if s.config.MatchingRetryDelayMs > 0 {
+			base = s.config.MatchingRetryDelayMs
+		}
+		if s.config.QueueMaxRetryDelayMs > 0 {
+			maxDelay = s.config.QueueMaxRetryDelayMs
+		}
+	}
+
+	delay := float64(base) * math.Pow(2, float64(retry-1))
+	return time.Duration(math.Min(delay, float64(maxDelay))) * time.Millisecond
+}
+
+// matchingDeadline returns when the queue should give up on the request.
+// Scheduled rides are dispatched ahead of pickup, so their wait time only
+// starts counting at the scheduled pickup time.
+func (s *AdvancedMatchingService) matchingDeadline(request *MatchingRequest, now time.Time) time.Time {
+	start := request.RequestedAt
+	if start.IsZero() {
+		start = now
+	}
+	if request.ScheduledFor != nil && request.ScheduledFor.After(start) {
+		start = *request.ScheduledFor
+	}
+
+	if request.MaxWaitTime > 0 {
+		return start.Add(request.MaxWaitTime)
+	}
+	if s.config != nil && s.config.MaxMatchingTimeout > 0 {
+		return start.Add(time.Duration(s.config.MaxMatchingTimeout) * time.Second)
+	}
+	return start.Add(5 * time.Minute)
+}
+
+// enqueueMatching keeps the trip in matching state so the queue worker can retry it
+func (s *AdvancedMatchingService) enqueueMatching(ctx context.Context, request *MatchingRequest, reason string) error {
+	if s.queue == nil {
+		return fmt.Errorf("matching queue not configured")
+	}
+
+	now := time.Now()
+	deadline := s.matchingDeadline(request, now)
+	if !now.Before(deadline) {
+		return fmt.Errorf("max wait time already exceeded for trip %s", request.TripID)
+	}
+
+	entry := &QueuedMatch{
+		TripID:         request.TripID,
+		Request:        request,
+		Status:         QueueStatusMatching,
+		SearchRadiusKm: s.searchParams(request).MaxRadiusKm,
+		LastReason:     reason,
+		EnqueuedAt:     now,
+		NextAttemptAt:  s.nextAttemptAt(now, 1, deadline),
+		Deadline:       deadline,
+		UpdatedAt:      now,
+	}
+
+	s.queueMutex.Lock()
+	defer s.queueMutex.Unlock()
+
+	if err := s.saveQueuedMatch(ctx, entry); err != nil {
+		return err
+	}
+	s.notifyProgress(ctx, entry, MatchingEventQueued, "No driver nearby yet, still looking")
+
+	if s.logger != nil {
+		s.logger.WithContext(ctx).WithFields(logger.Fields{
+			"trip_id":  request.TripID,
+			"deadline": deadline,
+			"reason":   reason,
+		}).Info("Trip queued for matching retries")
+	}
+
+	return nil
+}
+
+// ProcessMatchingQueue retries every queued trip that is due. It returns the
+// number of trips that were retried or timed out.
+func (s *AdvancedMatchingService) ProcessMatchingQueue(ctx context.Context, now time.Time) (int, error) {
+	tripIDs, err := s.queue.ListDue(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list due matches: %w", err)
+	}
+
+	processed := 0
+	for _, tripID := range tripIDs {
+		if s.processQueuedMatch(ctx, tripID, now) {
+			processed++
+		}
+	}
+
+	return processed, nil
+}
+
+// StartMatchingQueueWorker periodically retries queued trips until ctx is cancelled
+func (s *AdvancedMatchingService) StartMatchingQueueWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if _, err := s.ProcessMatchingQueue(ctx, now); err != nil && s.logger != nil {
+				s.logger.WithContext(ctx).WithError(err).Warn("Matching queue sweep failed")
+			}
+		}
+	}
+}
+
+// processQueuedMatch runs one retry for a queued trip, or times it out
+func (s *AdvancedMatchingService) processQueuedMatch(ctx context.Context, tripID string, now time.Time) bool {
+	s.queueMutex.Lock()
+	defer s.queueMutex.Unlock()
+
+	entry, err := s.queue.Get(ctx, tripID)
+	if err != nil {
+		s.queue.Remove(ctx, tripID)
+		return false
+	}
+	if entry.Status != QueueStatusMatching || now.Before(entry.NextAttemptAt) {
+		return false
+	}
+
+	if !now.Before(entry.Deadline) {
+		entry.Status = QueueStatusTimedOut
+		entry.UpdatedAt = now
+		if err := s.saveQueuedMatch(ctx, entry); err != nil {
+			return false
+		}
+		s.record(now, analytics.Counters{counterTimedOut: 1})
+		s.notifyProgress(ctx, entry, MatchingEventTimedOut, "No drivers available within the maximum wait time")
+		return true
+	}
+
+	if s.geoService == nil {
+		return false
+	}
+
+	retry := entry.Retries + 1
+	params := s.expandedSearchParams(entry.Request, retry)
+	event := MatchingEventSearching
+	if params.MaxRadiusKm > entry.SearchRadiusKm {
+		event = MatchingEventRadiusExpanded
+	}
+	entry.Retries = retry
+	entry.SearchRadiusKm = params.MaxRadiusKm
+	entry.UpdatedAt = now
+	s.notifyProgress(ctx, entry, event, fmt.Sprintf("Searching for drivers within %.0f km", params.MaxRadiusKm))
+
+	// The rider hears of a driver found through the offer made to them
+	result, err := s.attemptMatch(ctx, entry.Request, params, time.Now())
+	if err == nil && result.Success {
+		entry.Status = QueueStatusMatched
+		entry.MatchedDriver = result.MatchedDriver.DriverID
+		entry.OfferID = result.OfferID
+		entry.LastReason = ""
+		if err := s.saveQueuedMatch(ctx, entry); err != nil {
+			return false
+		}
+		s.recordQueuedMatch(entry, result.MatchedDriver, now)
+		return true
+	}
+
+	entry.LastReason = fmt.Sprintf("no drivers found within %.0f km", params.MaxRadiusKm)
+	if result != nil {
+		entry.LastReason = result.Reason
+	}
+	entry.NextAttemptAt = s.nextAttemptAt(now, retry+1, entry.Deadline)
+	if err := s.saveQueuedMatch(ctx, entry); err != nil {
+		return false
+	}
+	s.notifyProgress(ctx, entry, MatchingEventQueued, fmt.Sprintf("No driver found within %.0f km yet, still looking", params.MaxRadiusKm))
+
+	return true
+}
+
+// cancelQueuedMatch removes a trip from the matching queue
+func (s *AdvancedMatchingService) cancelQueuedMatch(ctx context.Context, tripID string) {
+	s.queueMutex.Lock()
+	defer s.queueMutex.Unlock()
+
+	entry, err := s.queue.Get(ctx, tripID)
+	if err != nil {
+		return
+	}
+
+	if entry.Status == QueueStatusMatching {
+		entry.Status = QueueStatusCancelled
+		entry.UpdatedAt = time.Now()
+		s.notifyProgress(ctx, entry, MatchingEventCancelled, "Matching cancelled")
+	}
+
+	if err := s.queue.Remove(ctx, tripID); err != nil && s.logger != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("trip_id", tripID).Warn("Failed to remove trip from matching queue")
+	}
+}
+
+// nextAttemptAt schedules the next retry, never later than the deadline
+func (s *AdvancedMatchingService) nextAttemptAt(now time.Time, retry int, deadline time.Time) time.Time {
+	next := now.Add(s.retryDelay(retry))
+	if next.After(deadline) {
+		return deadline
+	}
+	return next
+}
+
+// saveQueuedMatch stores the entry, keeping finished entries for status lookups
+func (s *AdvancedMatchingService) saveQueuedMatch(ctx context.Context, entry *QueuedMatch) error {
+	ttl := offerRetention
+	if entry.Status == QueueStatusMatching {
+		ttl += time.Until(entry.Deadline)
+	}
+	if err := s.queue.Save(ctx, entry, ttl); err != nil {
+		if s.logger != nil {
+			s.logger.WithContext(ctx).WithError(err).WithField("trip_id", entry.TripID).Error("Failed to save queued match")
+		}
+		return err
+	}
+	return nil
+}
+
+// notifyProgress pushes a step of matching a queued trip to the rider,
+// with the entry's current state
+func (s *AdvancedMatchingService) notifyProgress(ctx context.Context, entry *QueuedMatch, event MatchingEvent, message string) {
+	deadline := entry.Deadline
+	progress := &MatchingProgress{
+		TripID:         entry.TripID,
+		Event:          event,
+		Retries:        entry.Retries,
+		SearchRadiusKm: entry.SearchRadiusKm,
+		Message:        message,
+		DriverID:       entry.MatchedDriver,
+		OfferID:        entry.OfferID,
+		Deadline:       &deadline,
+		UpdatedAt:      entry.UpdatedAt,
+	}
+	if entry.Request != nil {
+		progress.RiderID = entry.Request.RiderID
+	}
+	if entry.Status == QueueStatusMatching && entry.NextAttemptAt.After(entry.UpdatedAt) {
+		next := entry.NextAttemptAt
+		progress.NextAttemptAt = &next
+	}
+	s.publishProgress(ctx, progress)
+}
+
+// publishProgress pushes a step of matching to the rider and adds it to the
+// trip's attempt log. Steps without a time are stamped now.
+func (s *AdvancedMatchingService) publishProgress(ctx context.Context, progress *MatchingProgress) {
+	progress.Status = eventStatuses[progress.Event]
+	if progress.UpdatedAt.IsZero() {
+		progress.UpdatedAt = time.Now()
+	}
+
+	if s.attemptLogs != nil {
+		if err := s.attemptLogs.Append(ctx, progress); err != nil && s.logger != nil {
+			s.logger.WithContext(ctx).WithError(err).WithField("trip_id", progress.TripID).Warn("Failed to log matching step")
+		}
+	}
+	if s.progress == nil {
+		return
+	}
+	if err := s.progress.NotifyRider(ctx, progress); err != nil && s.logger != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("trip_id", progress.TripID).Warn("Failed to push matching progress")
+	}
+}
+
+// queuedMatchStatus describes a queued trip for status lookups
+func queuedMatchStatus(entry *QueuedMatch) map[string]interface{} {
+	status := map[string]interface{}{
+		"trip_id":          entry.TripID,
+		"status":           entry.Status,
+		"started_at":       entry.EnqueuedAt,
+		"attempts":         entry.Retries + 1,
+		"search_radius_km": entry.SearchRadiusKm,
+		"deadline":         entry.Deadline,
+	}
+	if entry.Status == QueueStatusMatching {
+		status["next_attempt_at"] = entry.NextAttemptAt
+	}
+	if entry.LastReason != "" {
+		status["last_reason"] = entry.LastReason
+	}
+	if entry.MatchedDriver != "" {
+		status["matched_driver"] = entry.MatchedDriver
+		status["offer_id"] = entry.OfferID
+	}
+	return status
+}
@@ -0,0 +1,253 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rideshare-platform/services/matching-service/internal/config"
+	"github.com/rideshare-platform/shared/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeGeoService returns a configurable set of drivers within the requested radius
+type fakeGeoService struct {
+	mutex   sync.Mutex
+	drivers []*DriverLocation
+	radii   []float64
+}
+
+func (f *fakeGeoService) setDrivers(drivers ...*DriverLocation) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.drivers = drivers
+}
+
+func (f *fakeGeoService) CalculateDistance(ctx context.Context, origin, destination *models.Location) (*DistanceResult, error) {
+	return &DistanceResult{DistanceKm: 5, DistanceMeters: 5000}, nil
+}
+
+func (f *fakeGeoService) CalculateETA(ctx context.Context, origin, destination *models.Location, vehicleType string) (*ETAResult, error) {
+	return &ETAResult{DurationSeconds: 600}, nil
+}
+
+func (f *fakeGeoService) FindNearbyDrivers(ctx context.Context, center *models.Location, radiusKm float64, limit int, cityID string) ([]*DriverLocation, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.radii = append(f.radii, radiusKm)
+
+	var found []*DriverLocation
+	for _, driver := range f.drivers {
+		if driver.DistanceFromCenter <= radiusKm {
+			found = append(found, driver)
+		}
+	}
+	return found, nil
+}
+
+func newQueueTestService(geo GeoServiceClient) *AdvancedMatchingService {
+	cfg := &config.Config{
+		MatchingRetryDelayMs:   1000,
+		QueueMaxRetryDelayMs:   4000,
+		QueueRadiusStepKm:      10,
+		QueueMaxSearchRadiusKm: 40,
+		QueueRatingRelaxStep:   0.5,
+		QueueUpgradeAfterRetry: 2,
+	}
+	return NewAdvancedMatchingService(cfg, nil, nil, nil, nil, geo)
+}
+
+func newQueueTestRequest(tripID string, wait time.Duration) *MatchingRequest {
+	return &MatchingRequest{
+		TripID:         tripID,
+		RiderID:        "rider-1",
+		PickupLocation: &models.Location{Latitude: 37.7749, Longitude: -122.4194},
+		Destination:    &models.Location{Latitude: 37.7849, Longitude: -122.4094},
+		VehicleType:    "sedan",
+		PassengerCount: 1,
+		MaxWaitTime:    wait,
+	}
+}
+
+func TestMatchingQueue_QueuesWhenNoDrivers(t *testing.T) {
+	geo := &fakeGeoService{}
+	service := newQueueTestService(geo)
+	progress := NewProgressBroadcaster()
+	service.SetProgressNotifier(progress)
+	ctx := context.Background()
+
+	updates, unsubscribe := progress.Subscribe("trip-queue-1")
+	defer unsubscribe()
+
+	result, err := service.FindMatch(ctx, newQueueTestRequest("trip-queue-1", time.Minute))
+	assert.NoError(t, err)
+	assert.False(t, result.Success)
+	assert.True(t, result.Queued)
+
+	// The rider sees the search start, then carry on in the background
+	for _, event := range []MatchingEvent{MatchingEventSearching, MatchingEventQueued} {
+		select {
+		case update := <-updates:
+			assert.Equal(t, event, update.Event)
+			assert.Equal(t, QueueStatusMatching, update.Status)
+			assert.Equal(t, event == MatchingEventQueued, update.NextAttemptAt != nil)
+		default:
+			t.Fatalf("expected rider to be notified of %s", event)
+		}
+	}
+
+	status, err := service.GetMatchingStatus(ctx, "trip-queue-1")
+	assert.NoError(t, err)
+	assert.Equal(t, QueueStatusMatching, status["status"])
+}
+
+func TestMatchingQueue_RetryExpandsRadiusAndMatches(t *testing.T) {
+	geo := &fakeGeoService{}
+	service := newQueueTestService(geo)
+	ctx := context.Background()
+
+	_, err := service.FindMatch(ctx, newQueueTestRequest("trip-queue-2", time.Minute))
+	assert.NoError(t, err)
+
+	// A driver 25km away is outside the initial search but inside the first expansion
+	geo.setDrivers(&DriverLocation{
+		DriverID:           "far-driver",
+		Location:           &models.Location{Latitude: 37.9, Longitude: -122.4},
+		DistanceFromCenter: 25,
+		Status:             "available",
+		VehicleType:        "sedan",
+		Rating:             4.9,
+	})
+
+	processed, err := service.ProcessMatchingQueue(ctx, time.Now().Add(time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+
+	status, err := service.GetMatchingStatus(ctx, "trip-queue-2")
+	assert.NoError(t, err)
+	assert.Equal(t, QueueStatusMatched, status["status"])
+	assert.Equal(t, "far-driver", status["matched_driver"])
+
+	offer, err := service.GetOffer(ctx, "trip-queue-2")
+	assert.NoError(t, err)
+	assert.Equal(t, "far-driver", offer.DriverID())
+}
+
+func TestMatchingQueue_RelaxesFiltersOnLaterRetries(t *testing.T) {
+	geo := &fakeGeoService{}
+	service := newQueueTestService(geo)
+	ctx := context.Background()
+
+	geo.setDrivers(&DriverLocation{
+		DriverID:           "suv-driver",
+		Location:           &models.Location{Latitude: 37.78, Longitude: -122.42},
+		DistanceFromCenter: 2,
+		Status:             "available",
+		VehicleType:        "suv",
+		Rating:             4.6,
+	})
+
+	request := newQueueTestRequest("trip-queue-3", time.Minute)
+	request.Preferences = &RiderPreferences{MinDriverRating: 4.9}
+
+	result, err := service.FindMatch(ctx, request)
+	assert.NoError(t, err)
+	assert.True(t, result.Queued)
+
+	// First retry relaxes the rating but still requires a sedan
+	now := time.Now().Add(time.Second)
+	_, err = service.ProcessMatchingQueue(ctx, now)
+	assert.NoError(t, err)
+	status, _ := service.GetMatchingStatus(ctx, "trip-queue-3")
+	assert.Equal(t, QueueStatusMatching, status["status"])
+
+	// Second retry accepts an SUV upgrade
+	_, err = service.ProcessMatchingQueue(ctx, now.Add(3*time.Second))
+	assert.NoError(t, err)
+	status, _ = service.GetMatchingStatus(ctx, "trip-queue-3")
+	assert.Equal(t, QueueStatusMatched, status["status"])
+	assert.Equal(t, "suv-driver", status["matched_driver"])
+}
+
+func TestMatchingQueue_RespectsMaxWaitTime(t *testing.T) {
+	geo := &fakeGeoService{}
+	service := newQueueTestService(geo)
+	progress := NewProgressBroadcaster()
+	service.SetProgressNotifier(progress)
+	ctx := context.Background()
+
+	_, err := service.FindMatch(ctx, newQueueTestRequest("trip-queue-4", 5*time.Second))
+	assert.NoError(t, err)
+
+	updates, unsubscribe := progress.Subscribe("trip-queue-4")
+	defer unsubscribe()
+
+	processed, err := service.ProcessMatchingQueue(ctx, time.Now().Add(10*time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, processed)
+
+	select {
+	case update := <-updates:
+		assert.Equal(t, QueueStatusTimedOut, update.Status)
+	default:
+		t.Fatal("expected rider to be notified of the timeout")
+	}
+
+	processed, err = service.ProcessMatchingQueue(ctx, time.Now().Add(20*time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, processed)
+}
+
+func TestMatchingQueue_ScheduledRideWaitsFromPickupTime(t *testing.T) {
+	service := newQueueTestService(&fakeGeoService{})
+	ctx := context.Background()
+
+	request := newQueueTestRequest("trip-queue-6", 5*time.Second)
+	pickup := time.Now().Add(15 * time.Minute)
+	request.ScheduledFor = &pickup
+
+	_, err := service.FindMatch(ctx, request)
+	assert.NoError(t, err)
+
+	// Well past the wait time after dispatch, but still before pickup
+	_, err = service.ProcessMatchingQueue(ctx, time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+
+	status, err := service.GetMatchingStatus(ctx, "trip-queue-6")
+	assert.NoError(t, err)
+	assert.Equal(t, QueueStatusMatching, status["status"])
+	assert.WithinDuration(t, pickup.Add(5*time.Second), status["deadline"].(time.Time), time.Millisecond)
+}
+
+func TestMatchingQueue_CancelRemovesTrip(t *testing.T) {
+	service := newQueueTestService(&fakeGeoService{})
+	ctx := context.Background()
+
+	_, err := service.FindMatch(ctx, newQueueTestRequest("trip-queue-5", time.Minute))
+	assert.NoError(t, err)
+
+	assert.NoError(t, service.CancelMatching(ctx, "trip-queue-5"))
+
+	processed, err := service.ProcessMatchingQueue(ctx, time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, processed)
+}
+
+func TestExpandedSearchParams(t *testing.T) {
+	service := newQueueTestService(nil)
+
+	first := service.expandedSearchParams(newQueueTestRequest("trip-queue-params", time.Minute), 1)
+	assert.Equal(t, 30.0, first.MaxRadiusKm)
+	assert.Equal(t, 25.0, first.MaxPickupDistanceKm)
+	assert.Equal(t, 0.5, first.RatingRelaxation)
+	assert.False(t, first.AllowUpgrades)
+
+	later := service.expandedSearchParams(newQueueTestRequest("trip-queue-params", time.Minute), 5)
+	assert.Equal(t, 40.0, later.MaxRadiusKm)
+	assert.Equal(t, 40.0, later.MaxPickupDistanceKm)
+	assert.True(t, later.AllowUpgrades)
+
+	assert.Equal(t, time.Second, service.retryDelay(1))
+	assert.Equal(t, 4*time.Second, service.retryDelay(5))
+}
@@ -2,19 +2,27 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 	"go.mongodb.org/mongo-driver/mongo"
 
+	"github.com/rideshare-platform/services/matching-service/internal/admission"
 	"github.com/rideshare-platform/services/matching-service/internal/config"
 	"github.com/rideshare-platform/services/matching-service/internal/repository"
+	"github.com/rideshare-platform/shared/analytics"
+	"github.com/rideshare-platform/shared/currency"
+	"github.com/rideshare-platform/shared/experiments"
+	"github.com/rideshare-platform/shared/flags"
 	"github.com/rideshare-platform/shared/logger"
 	"github.com/rideshare-platform/shared/models"
+	"github.com/rideshare-platform/shared/utils"
 )
 
 // AdvancedMatchingService handles trip matching with sophisticated algorithms
@@ -22,16 +30,45 @@ type AdvancedMatchingService struct {
 	config     *config.Config
 	logger     *logger.Logger
 	tripRepo   *repository.TripRepository
-	redis      *redis.Client
+	redis      redis.UniversalClient
 	mongo      *mongo.Client
 	geoService GeoServiceClient // Interface for geo-service gRPC calls
+	offers     OfferStore
+	notifier   OfferNotifier
+	offerMutex sync.Mutex
+	queue      MatchingQueue
+	queueMutex sync.Mutex
+	progress   ProgressNotifier
+
+	attemptLogs   AttemptLogStore
+	reservations  ReservationStore
+	sharedTrips   SharedTripClient
+	fareSplitter  FareSplitter
+	fareEstimator FareEstimator
+	ratings       RatingProvider
+	reliability   ReliabilityProvider
+	acceptance    DriverAcceptanceStore
+	zones         ZoneChecker
+	queues        DispatchQueues
+	profiles      DriverProfileProvider
+	vehicles      DriverVehicleProvider
+	profileCache  *driverProfileCache
+	analytics     *analytics.Recorder
+	admission     *admission.Controller
+	batcher       *batchMatcher
+	flags         *flags.Client
+	experiments   *experiments.Registry
+
+	matchingConfigs *matchingConfigRegistry
 }
 
 // GeoServiceClient interface for geo-service integration
 type GeoServiceClient interface {
 	CalculateDistance(ctx context.Context, origin, destination *models.Location) (*DistanceResult, error)
 	CalculateETA(ctx context.Context, origin, destination *models.Location, vehicleType string) (*ETAResult, error)
-	FindNearbyDrivers(ctx context.Context, center *models.Location, radiusKm float64, limit int) ([]*DriverLocation, error)
+	// FindNearbyDrivers returns drivers in the city, or in the city center is
+	// in when cityID is empty
+	FindNearbyDrivers(ctx context.Context, center *models.Location, radiusKm float64, limit int, cityID string) ([]*DriverLocation, error)
 }
 
 // DistanceResult represents distance calculation result from geo-service
@@ -56,6 +93,7 @@ type DriverLocation struct {
 	DistanceFromCenter float64
 	Status             string
 	VehicleType        string
+	VehicleFeatures    []string
 	Rating             float64
 }
 
@@ -68,6 +106,8 @@ type MatchingRequest struct {
 	PassengerCount int               `json:"passenger_count"`
 	VehicleType    string            `json:"vehicle_type"`
 	RequestedAt    time.Time         `json:"requested_at"`
+	ScheduledFor   *time.Time        `json:"scheduled_for,omitempty"` // pickup time of a pre-dispatched scheduled ride
+	City           string            `json:"city,omitempty"`          // city ID; sets the currency the ride is quoted in and the drivers searched
 	SpecialNeeds   []string          `json:"special_needs,omitempty"`
 	PriorityLevel  int               `json:"priority_level"` // 1=normal, 2=premium, 3=emergency
 	MaxWaitTime    time.Duration     `json:"max_wait_time"`
@@ -96,6 +136,10 @@ type MatchingResult struct {
 	MatchingScore      float64              `json:"matching_score,omitempty"`
 	ProcessingTime     time.Duration        `json:"processing_time"`
 	RetryCount         int                  `json:"retry_count"`
+	OfferID            string               `json:"offer_id,omitempty"`
+	OfferExpiresAt     *time.Time           `json:"offer_expires_at,omitempty"`
+	Queued             bool                 `json:"queued,omitempty"`
+	SharedRide         *SharedRideMatch     `json:"shared_ride,omitempty"`
 }
 
 // MatchedDriverInfo represents detailed matched driver information
@@ -104,6 +148,7 @@ type MatchedDriverInfo struct {
 	VehicleID       string           `json:"vehicle_id"`
 	DriverName      string           `json:"driver_name"`
 	DriverPhoto     string           `json:"driver_photo,omitempty"`
+	VehiclePhoto    string           `json:"vehicle_photo,omitempty"`
 	Rating          float64          `json:"rating"`
 	TripCount       int              `json:"trip_count"`
 	CurrentLocation *models.Location `json:"current_location"`
@@ -112,6 +157,7 @@ type MatchedDriverInfo struct {
 	ETA             int              `json:"eta"`      // seconds to pickup
 	MatchScore      float64          `json:"match_score"`
 	Status          string           `json:"status"`
+	Behavior        *DriverBehavior  `json:"-"` // recent acceptance, cancellations and streak, for scoring
 }
 
 // VehicleDetails represents detailed vehicle information
@@ -128,12 +174,13 @@ type VehicleDetails struct {
 
 // FareEstimate represents estimated fare for the trip
 type FareEstimate struct {
-	BaseFare      float64 `json:"base_fare"`
-	DistanceFare  float64 `json:"distance_fare"`
-	TimeFare      float64 `json:"time_fare"`
-	SurgeFare     float64 `json:"surge_fare"`
-	TotalEstimate float64 `json:"total_estimate"`
-	Currency      string  `json:"currency"`
+	BaseFare        float64 `json:"base_fare"`
+	DistanceFare    float64 `json:"distance_fare"`
+	TimeFare        float64 `json:"time_fare"`
+	SurgeFare       float64 `json:"surge_fare"`
+	SurgeMultiplier float64 `json:"surge_multiplier,omitempty"`
+	TotalEstimate   float64 `json:"total_estimate"`
+	Currency        string  `json:"currency"`
 }
 
 // NewAdvancedMatchingService creates a new advanced matching service
@@ -141,10 +188,19 @@ func NewAdvancedMatchingService(
 	cfg *config.Config,
 	logger *logger.Logger,
 	tripRepo *repository.TripRepository,
-	redis *redis.Client,
+	redis redis.UniversalClient,
 	mongo *mongo.Client,
 	geoService GeoServiceClient,
 ) *AdvancedMatchingService {
+	var offers OfferStore = NewMemoryOfferStore()
+	var queue MatchingQueue = NewMemoryMatchingQueue()
+	var reservations ReservationStore = NewMemoryReservationStore()
+	if redis != nil {
+		offers = NewRedisOfferStore(redis)
+		queue = NewRedisMatchingQueue(redis)
+		reservations = NewRedisReservationStore(redis)
+	}
+
 	return &AdvancedMatchingService{
 		config:     cfg,
 		logger:     logger,
@@ -152,6 +208,12 @@ func NewAdvancedMatchingService(
 		redis:      redis,
 		mongo:      mongo,
 		geoService: geoService,
+		offers:     offers,
+		queue:      queue,
+
+		reservations: reservations,
+		profileCache: newDriverProfileCache(driverProfileTTL(cfg)),
+		analytics:    analytics.NewRecorder(analytics.NewMemoryStore(), AnalyticsSource, logger),
 	}
 }
 
@@ -160,17 +222,83 @@ func NewSimpleMatchingService(cfg *config.Config) *AdvancedMatchingService {
 	// Create a simple version without external dependencies for basic functionality
 	return &AdvancedMatchingService{
 		config: cfg,
+		offers: NewMemoryOfferStore(),
+		queue:  NewMemoryMatchingQueue(),
+
+		reservations: NewMemoryReservationStore(),
+		profileCache: newDriverProfileCache(driverProfileTTL(cfg)),
+		analytics:    analytics.NewRecorder(analytics.NewMemoryStore(), AnalyticsSource, nil),
 		// Other fields will be nil - need to handle this in methods
 	}
 }
 
+// SetRedis keeps offers, the matching queue and driver reservations in
+// Redis, so every replica sees the same ones
+func (s *AdvancedMatchingService) SetRedis(client redis.UniversalClient) {
+	s.redis = client
+	s.offers = NewRedisOfferStore(client)
+	s.queue = NewRedisMatchingQueue(client)
+	s.reservations = NewRedisReservationStore(client)
+}
+
+// SetGeoService sets the geo-service client drivers are searched and ranked
+// with. Without one, matching runs in mock mode against generated drivers.
+func (s *AdvancedMatchingService) SetGeoService(geoService GeoServiceClient) {
+	s.geoService = geoService
+}
+
+// SetAdmission bounds how many matches run at once. Requests beyond the
+// bound wait by priority level and are turned away with an
+// *admission.OverloadError when the service is over capacity.
+func (s *AdvancedMatchingService) SetAdmission(controller *admission.Controller) {
+	s.admission = controller
+}
+
 // FindMatch implements sophisticated driver matching algorithm
 func (s *AdvancedMatchingService) FindMatch(ctx context.Context, request *MatchingRequest) (*MatchingResult, error) {
+	ctx = s.experiments.Assign(ctx, experiments.Units{RiderID: request.RiderID, TripID: request.TripID})
+	if s.admission != nil {
+		release, err := s.admission.Acquire(ctx, request.PriorityLevel)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+
+	result, err := s.findMatch(ctx, request)
+	s.recordMatchRequest(ctx, result, err)
+	return result, err
+}
+
+func (s *AdvancedMatchingService) findMatch(ctx context.Context, request *MatchingRequest) (*MatchingResult, error) {
 	startTime := time.Now()
 
+	// Riders cannot be picked up inside restricted zones, retrying would not help
+	if zone := s.restrictedPickupZone(ctx, request); zone != "" {
+		return &MatchingResult{
+			TripID:         request.TripID,
+			Success:        false,
+			Reason:         fmt.Sprintf("Pickups are not allowed in %s, choose a pickup point outside the zone", zone),
+			ProcessingTime: time.Since(startTime),
+		}, nil
+	}
+
+	params := s.searchParams(request)
+	s.publishProgress(ctx, &MatchingProgress{
+		TripID:         request.TripID,
+		RiderID:        request.RiderID,
+		Event:          MatchingEventSearching,
+		SearchRadiusKm: params.MaxRadiusKm,
+		Message:        "Looking for a driver",
+	})
+
 	// Basic safety check for nil dependencies - return mock response
 	if s.geoService == nil {
-		return s.generateMockResult(request, startTime), nil
+		result := s.generateMockResult(request, startTime)
+		if err := s.attachOffer(ctx, request, result); err != nil {
+			return nil, err
+		}
+		return result, nil
 	}
 
 	if s.logger != nil {
@@ -181,10 +309,83 @@ func (s *AdvancedMatchingService) FindMatch(ctx context.Context, request *Matchi
 			"pickup_lat":   request.PickupLocation.Latitude,
 			"pickup_lng":   request.PickupLocation.Longitude,
 		}).Info("Starting advanced trip matching")
-	} // Phase 1: Find nearby drivers using geo-service
-	nearbyDrivers, err := s.findNearbyDrivers(ctx, request)
+	}
+
+	// Riders who allow sharing are first offered a seat on a trip already in progress
+	if allowsSharedRides(request) && s.sharedTrips != nil {
+		result, err := s.findPooledMatch(ctx, request, startTime)
+		if err != nil && s.logger != nil {
+			s.logger.WithContext(ctx).WithError(err).Warn("Shared ride matching failed, falling back to a solo match")
+		}
+		if result != nil {
+			s.publishProgress(ctx, &MatchingProgress{
+				TripID:   request.TripID,
+				RiderID:  request.RiderID,
+				Event:    MatchingEventDriverAccepted,
+				Message:  "Matched onto a shared ride in progress",
+				DriverID: result.MatchedDriver.DriverID,
+			})
+			return result, nil
+		}
+	}
+
+	var result *MatchingResult
+	var err error
+	if s.batching(request) {
+		result, err = s.batchedMatch(ctx, request, params, startTime)
+	} else {
+		result, err = s.attemptMatch(ctx, request, params, startTime)
+	}
+	if err != nil || result.Success {
+		return result, err
+	}
+
+	// No drivers right now - keep the trip in matching and retry in the background
+	if err := s.enqueueMatching(ctx, request, result.Reason); err != nil {
+		if s.logger != nil {
+			s.logger.WithContext(ctx).WithError(err).Warn("Failed to queue trip for matching retries")
+		}
+		s.publishProgress(ctx, &MatchingProgress{
+			TripID:  request.TripID,
+			RiderID: request.RiderID,
+			Event:   MatchingEventNoDrivers,
+			Message: result.Reason,
+		})
+		return result, nil
+	}
+
+	result.Queued = true
+	result.Reason = fmt.Sprintf("%s; matching will continue in the background", result.Reason)
+	return result, nil
+}
+
+// attemptMatch runs a single matching pass with the given search parameters
+func (s *AdvancedMatchingService) attemptMatch(ctx context.Context, request *MatchingRequest, params searchParams, startTime time.Time) (*MatchingResult, error) {
+	scoredDrivers, failure, err := s.rankDrivers(ctx, request, params, startTime)
+	if failure != nil {
+		return failure, err
+	}
+	return s.completeMatch(ctx, request, scoredDrivers, startTime)
+}
+
+// rankDrivers finds the drivers eligible for the request, best first. When
+// there are none it returns the unsuccessful result instead.
+func (s *AdvancedMatchingService) rankDrivers(ctx context.Context, request *MatchingRequest, params searchParams, startTime time.Time) ([]*MatchedDriverInfo, *MatchingResult, error) {
+	// The config version is logged so the ranking can be reproduced
+	if s.logger != nil {
+		s.logger.WithContext(ctx).WithFields(logger.Fields{
+			"trip_id":                 request.TripID,
+			"matching_config_city":    params.Config.City,
+			"matching_config_version": params.Config.Version,
+			"search_radius_km":        params.MaxRadiusKm,
+			"max_pickup_distance_km":  params.MaxPickupDistanceKm,
+		}).Info("Ranking drivers")
+	}
+
+	// Phase 1: Find nearby drivers using geo-service
+	nearbyDrivers, err := s.findNearbyDrivers(ctx, request, params)
 	if err != nil {
-		return &MatchingResult{
+		return nil, &MatchingResult{
 			TripID:         request.TripID,
 			Success:        false,
 			Reason:         fmt.Sprintf("Failed to find nearby drivers: %v", err),
@@ -193,7 +394,7 @@ func (s *AdvancedMatchingService) FindMatch(ctx context.Context, request *Matchi
 	}
 
 	if len(nearbyDrivers) == 0 {
-		return &MatchingResult{
+		return nil, &MatchingResult{
 			TripID:         request.TripID,
 			Success:        false,
 			Reason:         "No available drivers found in the area",
@@ -201,10 +402,11 @@ func (s *AdvancedMatchingService) FindMatch(ctx context.Context, request *Matchi
 		}, nil
 	}
 
-	// Phase 2: Filter drivers based on requirements
-	eligibleDrivers := s.filterEligibleDrivers(ctx, nearbyDrivers, request)
+	// Phase 2: Filter drivers based on requirements, using their real ratings
+	nearbyDrivers = s.applyDriverRatings(ctx, nearbyDrivers)
+	eligibleDrivers := s.filterEligibleDrivers(ctx, nearbyDrivers, request, params)
 	if len(eligibleDrivers) == 0 {
-		return &MatchingResult{
+		return nil, &MatchingResult{
 			TripID:         request.TripID,
 			Success:        false,
 			Reason:         "No eligible drivers match the requirements",
@@ -213,9 +415,9 @@ func (s *AdvancedMatchingService) FindMatch(ctx context.Context, request *Matchi
 	}
 
 	// Phase 3: Score and rank drivers
-	scoredDrivers, err := s.scoreAndRankDrivers(ctx, eligibleDrivers, request)
+	scoredDrivers, err := s.scoreAndRankDrivers(ctx, eligibleDrivers, request, params.Config)
 	if err != nil {
-		return &MatchingResult{
+		return nil, &MatchingResult{
 			TripID:         request.TripID,
 			Success:        false,
 			Reason:         fmt.Sprintf("Failed to score drivers: %v", err),
@@ -223,33 +425,59 @@ func (s *AdvancedMatchingService) FindMatch(ctx context.Context, request *Matchi
 		}, err
 	}
 
-	// Phase 4: Select best match and alternatives
-	bestMatch := scoredDrivers[0]
-	var alternatives []*MatchedDriverInfo
-	if len(scoredDrivers) > 1 {
-		maxAlternatives := 3
-		if len(scoredDrivers) < maxAlternatives+1 {
-			maxAlternatives = len(scoredDrivers) - 1
-		}
-		alternatives = scoredDrivers[1 : maxAlternatives+1]
-	}
+	// Pickups in dispatch zones such as airports go to the queued drivers in turn
+	return s.dispatchFromQueue(ctx, request, scoredDrivers), nil, nil
+}
 
-	// Phase 5: Calculate fare estimate
-	fareEstimate, err := s.calculateFareEstimate(ctx, request, bestMatch)
-	if err != nil {
-		s.logger.WithError(err).Warn("Failed to calculate fare estimate")
+// completeMatch reserves the first of the ranked drivers no other trip holds
+// and offers them the trip, keeping the next ones as alternatives
+func (s *AdvancedMatchingService) completeMatch(ctx context.Context, request *MatchingRequest, scoredDrivers []*MatchedDriverInfo, startTime time.Time) (*MatchingResult, error) {
+	// Phase 4: Reserve the best driver no other trip holds, keeping the next
+	// ones as alternatives
+	bestIndex := -1
+	for i, driver := range scoredDrivers {
+		err := s.reserveDriver(ctx, driver.DriverID, request.TripID)
+		if err == nil {
+			bestIndex = i
+			break
+		}
+		if !errors.Is(err, ErrDriverReserved) {
+			if s.logger != nil {
+				s.logger.WithError(err).Error("Failed to reserve driver")
+			}
+			return &MatchingResult{
+				TripID:         request.TripID,
+				Success:        false,
+				Reason:         "Driver reservation failed",
+				ProcessingTime: time.Since(startTime),
+			}, err
+		}
+		if s.logger != nil {
+			s.logger.WithContext(ctx).WithFields(logger.Fields{
+				"trip_id":   request.TripID,
+				"driver_id": driver.DriverID,
+			}).Debug("Driver already reserved for another trip, trying next candidate")
+		}
 	}
-
-	// Phase 6: Reserve the driver
-	err = s.reserveDriver(ctx, bestMatch.DriverID, request.TripID)
-	if err != nil {
-		s.logger.WithError(err).Error("Failed to reserve driver")
+	if bestIndex < 0 {
 		return &MatchingResult{
 			TripID:         request.TripID,
 			Success:        false,
-			Reason:         "Driver reservation failed",
+			Reason:         "All eligible drivers are reserved for other trips",
 			ProcessingTime: time.Since(startTime),
-		}, err
+		}, nil
+	}
+
+	bestMatch := scoredDrivers[bestIndex]
+	alternatives := scoredDrivers[bestIndex+1:]
+	if maxAlternatives := 3; len(alternatives) > maxAlternatives {
+		alternatives = alternatives[:maxAlternatives]
+	}
+
+	// Phase 5: Calculate fare estimate
+	fareEstimate, err := s.calculateFareEstimate(ctx, request, bestMatch)
+	if err != nil && s.logger != nil {
+		s.logger.WithError(err).Warn("Failed to calculate fare estimate")
 	}
 
 	result := &MatchingResult{
@@ -265,43 +493,57 @@ func (s *AdvancedMatchingService) FindMatch(ctx context.Context, request *Matchi
 		RetryCount:         0,
 	}
 
-	s.logger.WithContext(ctx).WithFields(logger.Fields{
-		"trip_id":        request.TripID,
-		"matched_driver": bestMatch.DriverID,
-		"match_score":    bestMatch.MatchScore,
-		"processing_ms":  time.Since(startTime).Milliseconds(),
-	}).Info("Trip matching completed successfully")
+	// Phase 6: Show the rider who is coming and in what
+	s.enrichMatchedDrivers(ctx, append([]*MatchedDriverInfo{bestMatch}, alternatives...))
+
+	// Phase 7: Offer the trip to the driver, falling back to alternatives
+	if err := s.attachOffer(ctx, request, result); err != nil {
+		if s.logger != nil {
+			s.logger.WithError(err).Error("Failed to create driver offer")
+		}
+		return nil, err
+	}
+
+	if s.logger != nil {
+		s.logger.WithContext(ctx).WithFields(logger.Fields{
+			"trip_id":        request.TripID,
+			"matched_driver": bestMatch.DriverID,
+			"match_score":    bestMatch.MatchScore,
+			"processing_ms":  time.Since(startTime).Milliseconds(),
+		}).Info("Trip matching completed successfully")
+	}
 
 	return result, nil
 }
 
 // findNearbyDrivers gets nearby drivers from geo-service
-func (s *AdvancedMatchingService) findNearbyDrivers(ctx context.Context, request *MatchingRequest) ([]*DriverLocation, error) {
+func (s *AdvancedMatchingService) findNearbyDrivers(ctx context.Context, request *MatchingRequest, params searchParams) ([]*DriverLocation, error) {
 	// Start with a smaller radius and expand if needed
-	radiusKm := 5.0
-	maxRadius := 20.0
+	radiusKm := params.Config.InitialRadiusKm
 	limit := 50
 
-	for radiusKm <= maxRadius {
-		drivers, err := s.geoService.FindNearbyDrivers(ctx, request.PickupLocation, radiusKm, limit)
+	for radiusKm <= params.MaxRadiusKm {
+		drivers, err := s.geoService.FindNearbyDrivers(ctx, request.PickupLocation, radiusKm, limit, request.City)
 		if err != nil {
 			return nil, err
 		}
 
-		if len(drivers) >= 5 { // Minimum drivers to consider
+		if len(drivers) >= params.Config.MinCandidates { // Minimum drivers to consider
 			return drivers, nil
 		}
 
-		radiusKm += 5.0 // Expand search radius
+		radiusKm += params.Config.RadiusStepKm // Expand search radius
 	}
 
 	// Return whatever we found, even if less than ideal
-	return s.geoService.FindNearbyDrivers(ctx, request.PickupLocation, maxRadius, limit)
+	return s.geoService.FindNearbyDrivers(ctx, request.PickupLocation, params.MaxRadiusKm, limit, request.City)
 }
 
 // filterEligibleDrivers filters drivers based on requirements
-func (s *AdvancedMatchingService) filterEligibleDrivers(ctx context.Context, drivers []*DriverLocation, request *MatchingRequest) []*DriverLocation {
+func (s *AdvancedMatchingService) filterEligibleDrivers(ctx context.Context, drivers []*DriverLocation, request *MatchingRequest, params searchParams) []*DriverLocation {
 	var eligible []*DriverLocation
+	required := requiredVehicleFeatures(request)
+	vehicleFeatures := s.driverVehicleFeatures(ctx, drivers, required)
 
 	for _, driver := range drivers {
 		// Check basic availability
@@ -309,18 +551,25 @@ func (s *AdvancedMatchingService) filterEligibleDrivers(ctx context.Context, dri
 			continue
 		}
 
-		// Check vehicle type match
-		if request.VehicleType != "" && driver.VehicleType != request.VehicleType {
+		// Check vehicle type match, accepting larger vehicles once relaxed.
+		// WAV rides are matched on the vehicle's features instead.
+		if request.VehicleType != "" && request.VehicleType != models.RideTypeWAV && driver.VehicleType != request.VehicleType &&
+			!(params.AllowUpgrades && isVehicleUpgrade(request.VehicleType, driver.VehicleType, request.PassengerCount)) {
 			continue
 		}
 
 		// Check minimum rating requirement
-		if request.Preferences != nil && driver.Rating < request.Preferences.MinDriverRating {
+		if request.Preferences != nil && driver.Rating < request.Preferences.MinDriverRating-params.RatingRelaxation {
 			continue
 		}
 
-		// Check maximum distance (15km for now)
-		if driver.DistanceFromCenter > 15.0 {
+		// Check maximum pickup distance
+		if driver.DistanceFromCenter > params.MaxPickupDistanceKm {
+			continue
+		}
+
+		// Check the vehicle meets the rider's accessibility needs
+		if !hasVehicleFeatures(vehicleFeatures[driver.DriverID], required) {
 			continue
 		}
 
@@ -331,14 +580,18 @@ func (s *AdvancedMatchingService) filterEligibleDrivers(ctx context.Context, dri
 }
 
 // scoreAndRankDrivers scores drivers based on multiple factors
-func (s *AdvancedMatchingService) scoreAndRankDrivers(ctx context.Context, drivers []*DriverLocation, request *MatchingRequest) ([]*MatchedDriverInfo, error) {
+func (s *AdvancedMatchingService) scoreAndRankDrivers(ctx context.Context, drivers []*DriverLocation, request *MatchingRequest, config *MatchingConfig) ([]*MatchedDriverInfo, error) {
 	var scoredDrivers []*MatchedDriverInfo
+	weights := s.scoringWeights(ctx, config)
+	behaviors := s.driverBehaviors(ctx, drivers)
 
 	for _, driver := range drivers {
 		// Calculate ETA
 		eta, err := s.geoService.CalculateETA(ctx, driver.Location, request.PickupLocation, driver.VehicleType)
 		if err != nil {
-			s.logger.WithError(err).Warn("Failed to calculate ETA for driver", driver.DriverID)
+			if s.logger != nil {
+				s.logger.WithError(err).Warn("Failed to calculate ETA for driver", driver.DriverID)
+			}
 			continue
 		}
 
@@ -355,10 +608,11 @@ func (s *AdvancedMatchingService) scoreAndRankDrivers(ctx context.Context, drive
 				VehicleType: driver.VehicleType,
 				// Additional vehicle details would be fetched from vehicle service
 			},
+			Behavior: behaviors[driver.DriverID],
 		}
 
 		// Calculate composite matching score
-		score := s.calculateMatchingScore(matchedDriver, request)
+		score := s.calculateMatchingScore(weights, matchedDriver, request)
 		matchedDriver.MatchScore = score
 
 		scoredDrivers = append(scoredDrivers, matchedDriver)
@@ -373,24 +627,34 @@ func (s *AdvancedMatchingService) scoreAndRankDrivers(ctx context.Context, drive
 }
 
 // calculateMatchingScore calculates a composite score for driver matching
-func (s *AdvancedMatchingService) calculateMatchingScore(driver *MatchedDriverInfo, request *MatchingRequest) float64 {
+func (s *AdvancedMatchingService) calculateMatchingScore(weights scoringWeights, driver *MatchedDriverInfo, request *MatchingRequest) float64 {
 	score := 0.0
 
-	// Distance factor (closer is better) - 40% weight
-	maxDistance := 15.0 // km
-	distanceScore := math.Max(0, (maxDistance-driver.Distance)/maxDistance) * 40
+	// Distance factor (closer is better) - 35% weight by default
+	maxDistance := weights.distanceCapKm
+	distanceScore := math.Max(0, (maxDistance-driver.Distance)/maxDistance) * weights.distance
 
-	// ETA factor (faster pickup is better) - 30% weight
-	maxETA := 20.0 * 60 // 20 minutes in seconds
-	etaScore := math.Max(0, (maxETA-float64(driver.ETA))/maxETA) * 30
+	// ETA factor (faster pickup is better) - 25% weight by default
+	maxETA := weights.etaCapSeconds
+	etaScore := math.Max(0, (maxETA-float64(driver.ETA))/maxETA) * weights.eta
 
-	// Rating factor (higher rating is better) - 20% weight
-	ratingScore := (driver.Rating / 5.0) * 20
+	// Rating factor (higher rating is better) - 15% weight by default
+	ratingScore := (driver.Rating / 5.0) * weights.rating
 
-	// Availability factor - 10% weight
-	availabilityScore := 10.0 // Full score for available drivers
+	// Availability factor - 5% weight by default
+	availabilityScore := weights.availability // Full score for available drivers
 
-	score = distanceScore + etaScore + ratingScore + availabilityScore
+	// Behavior factors (accepting offers, not cancelling trips, completing
+	// trips in a row) - 10%, 5% and 5% weight by default
+	acceptanceScore := driver.Behavior.acceptance() * weights.acceptance
+	cancellationScore := (1 - driver.Behavior.cancellation()) * weights.cancellation
+	streakScore := 0.0
+	if weights.streakTarget > 0 {
+		streakScore = math.Min(1, driver.Behavior.streak()/weights.streakTarget) * weights.streak
+	}
+
+	score = distanceScore + etaScore + ratingScore + availabilityScore +
+		acceptanceScore + cancellationScore + streakScore
 
 	// Apply priority bonuses
 	if request.PriorityLevel > 1 {
@@ -400,8 +664,19 @@ func (s *AdvancedMatchingService) calculateMatchingScore(driver *MatchedDriverIn
 	return math.Min(100.0, score) // Cap at 100
 }
 
-// calculateFareEstimate estimates the fare for the trip
+// calculateFareEstimate quotes the trip through pricing-service from the
+// route geo-service expects the driver to take. Without a pricing-service
+// client the match carries no estimate.
 func (s *AdvancedMatchingService) calculateFareEstimate(ctx context.Context, request *MatchingRequest, driver *MatchedDriverInfo) (*FareEstimate, error) {
+	if s.fareEstimator == nil {
+		return nil, nil
+	}
+
+	vehicleType := request.VehicleType
+	if vehicleType == "" && driver.VehicleInfo != nil {
+		vehicleType = driver.VehicleInfo.VehicleType
+	}
+
 	// Calculate trip distance and duration
 	distanceResult, err := s.geoService.CalculateDistance(ctx, request.PickupLocation, request.Destination)
 	if err != nil {
@@ -413,69 +688,67 @@ func (s *AdvancedMatchingService) calculateFareEstimate(ctx context.Context, req
 		return nil, err
 	}
 
-	// Base fare calculation (simplified)
-	baseFare := 3.00                                           // Base fare
-	distanceFare := distanceResult.DistanceKm * 1.50           // $1.50 per km
-	timeFare := float64(etaResult.DurationSeconds) / 60 * 0.25 // $0.25 per minute
-
-	// Surge pricing (simplified - could be more sophisticated)
-	surgeFare := 0.0
-	if request.PriorityLevel > 1 {
-		surgeFare = (baseFare + distanceFare + timeFare) * 0.5 // 50% surge for premium
-	}
-
-	total := baseFare + distanceFare + timeFare + surgeFare
-
-	return &FareEstimate{
-		BaseFare:      baseFare,
-		DistanceFare:  distanceFare,
-		TimeFare:      timeFare,
-		SurgeFare:     surgeFare,
-		TotalEstimate: total,
-		Currency:      "USD",
-	}, nil
+	return s.fareEstimator.EstimateFare(ctx, &FareEstimateRequest{
+		TripID:          request.TripID,
+		RiderID:         request.RiderID,
+		PickupLocation:  request.PickupLocation,
+		Destination:     request.Destination,
+		VehicleType:     vehicleType,
+		DistanceKm:      distanceResult.DistanceKm,
+		DurationSeconds: etaResult.DurationSeconds,
+		City:            request.City,
+	})
 }
 
-// reserveDriver temporarily reserves a driver for the trip
+// reserveDriver temporarily reserves a driver for the trip. It returns
+// ErrDriverReserved when a concurrent match for another trip got there first.
 func (s *AdvancedMatchingService) reserveDriver(ctx context.Context, driverID, tripID string) error {
-	// Safety check for nil Redis dependency
-	if s.redis == nil {
-		if s.logger != nil {
-			s.logger.WithContext(ctx).Warn("Redis client not available - driver reservation skipped")
-		}
-		return nil // Return success for testing without Redis
-	}
+	now := time.Now()
+	return s.reservations.Reserve(ctx, &DriverReservation{
+		DriverID:   driverID,
+		TripID:     tripID,
+		Token:      utils.GenerateID(),
+		ReservedAt: now,
+		ExpiresAt:  now.Add(reservationTTL),
+	})
+}
 
-	// Set a reservation in Redis with TTL
-	key := fmt.Sprintf("driver_reservation:%s", driverID)
-	value := fmt.Sprintf("trip:%s:reserved_at:%d", tripID, time.Now().Unix())
+// attachOffer creates the driver offer for a successful match and records it on the result
+func (s *AdvancedMatchingService) attachOffer(ctx context.Context, request *MatchingRequest, result *MatchingResult) error {
+	offer, err := s.createOffer(ctx, request, result.MatchedDriver, result.AlternativeOptions, result.EstimatedFare)
+	if err != nil {
+		return err
+	}
 
-	return s.redis.SetEx(ctx, key, value, 5*time.Minute).Err()
+	result.OfferID = offer.OfferID
+	result.OfferExpiresAt = &offer.ExpiresAt
+	return nil
 } // GetMatchingStatus returns the status of ongoing matching processes
 func (s *AdvancedMatchingService) GetMatchingStatus(ctx context.Context, tripID string) (map[string]interface{}, error) {
-	status := "not_found"
-	startedAt := time.Now().Add(-30 * time.Second) // Default fallback
-
-	// Safety check for nil Redis dependency
-	if s.redis != nil {
-		// Check if there's an active reservation for this trip
-		pattern := "driver_reservation:*"
-		keys, err := s.redis.Keys(ctx, pattern).Result()
-		if err != nil && s.logger != nil {
-			s.logger.WithError(err).Warn("Failed to check driver reservations")
+	// Trips waiting in the matching queue report their retry progress
+	if s.queue != nil {
+		if entry, err := s.queue.Get(ctx, tripID); err == nil {
+			return queuedMatchStatus(entry), nil
 		}
+	}
 
-		for _, key := range keys {
-			value, err := s.redis.Get(ctx, key).Result()
-			if err == nil && strings.Contains(value, tripID) {
-				status = "searching"
-				// Extract timestamp from reservation value if possible
-				break
-			}
+	// Other trips report the last step logged for them
+	if s.attemptLogs != nil {
+		if log, err := s.attemptLogs.Get(ctx, tripID); err == nil && len(log.Events) > 0 {
+			return attemptLogStatus(log), nil
 		}
-	} else if s.logger != nil {
-		s.logger.WithContext(ctx).Warn("Redis client not available - using mock status")
-		status = "searching" // Mock status for testing
+	}
+
+	status := "not_found"
+	startedAt := time.Now().Add(-30 * time.Second) // Default fallback
+
+	// A driver held for the trip means matching is still in progress
+	reservation, err := s.reservations.GetByTrip(ctx, tripID)
+	if err == nil {
+		status = "searching"
+		startedAt = reservation.ReservedAt
+	} else if !errors.Is(err, ErrReservationNotFound) && s.logger != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("Failed to check driver reservations")
 	}
 
 	return map[string]interface{}{
@@ -489,25 +762,19 @@ func (s *AdvancedMatchingService) GetMatchingStatus(ctx context.Context, tripID
 
 // CancelMatching cancels an ongoing matching process
 func (s *AdvancedMatchingService) CancelMatching(ctx context.Context, tripID string) error {
-	// Safety check for nil Redis dependency
-	if s.redis == nil {
-		if s.logger != nil {
-			s.logger.WithContext(ctx).WithField("trip_id", tripID).Info("Matching cancelled (Redis not available)")
-		}
-		return nil // Return success for testing without Redis
+	s.cancelOffer(ctx, tripID)
+	if s.queue != nil {
+		s.cancelQueuedMatch(ctx, tripID)
 	}
 
-	// Remove any driver reservations for this trip
-	pattern := "driver_reservation:*"
-	keys, err := s.redis.Keys(ctx, pattern).Result()
-	if err != nil {
+	// Release the driver held for this trip
+	reservation, err := s.reservations.GetByTrip(ctx, tripID)
+	if err != nil && !errors.Is(err, ErrReservationNotFound) {
 		return err
 	}
-
-	for _, key := range keys {
-		value, err := s.redis.Get(ctx, key).Result()
-		if err == nil && contains(value, tripID) {
-			s.redis.Del(ctx, key)
+	if reservation != nil {
+		if err := s.reservations.Release(ctx, reservation); err != nil {
+			return err
 		}
 	}
 
@@ -517,24 +784,6 @@ func (s *AdvancedMatchingService) CancelMatching(ctx context.Context, tripID str
 	return nil
 }
 
-// GetMatchingMetrics returns comprehensive matching metrics
-func (s *AdvancedMatchingService) GetMatchingMetrics(ctx context.Context) (map[string]interface{}, error) {
-	// In a real implementation, these would come from monitoring systems
-	return map[string]interface{}{
-		"total_requests":      1234,
-		"successful_matches":  1089,
-		"success_rate":        88.2,
-		"avg_match_time":      "15.3s",
-		"avg_match_score":     85.7,
-		"active_searches":     5,
-		"avg_driver_distance": "2.3km",
-		"avg_eta":             "8.5min",
-		"surge_active":        false,
-		"available_drivers":   245,
-		"active_trips":        123,
-	}, nil
-}
-
 // Helper function
 func contains(s, substr string) bool {
 	return strings.Contains(s, substr)
@@ -573,7 +822,7 @@ func (s *AdvancedMatchingService) generateMockResult(request *MatchingRequest, s
 		TimeFare:      2.50,
 		SurgeFare:     0.00,
 		TotalEstimate: 11.50,
-		Currency:      "USD",
+		Currency:      currency.Default,
 	}
 
 	return &MatchingResult{
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/rideshare-platform/services/matching-service/internal/config"
+	"github.com/rideshare-platform/shared/analytics"
 	"github.com/rideshare-platform/shared/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -32,8 +33,8 @@ func (m *MockGeoServiceClient) CalculateETA(ctx context.Context, origin, destina
 	return args.Get(0).(*ETAResult), args.Error(1)
 }
 
-func (m *MockGeoServiceClient) FindNearbyDrivers(ctx context.Context, center *models.Location, radiusKm float64, limit int) ([]*DriverLocation, error) {
-	args := m.Called(ctx, center, radiusKm, limit)
+func (m *MockGeoServiceClient) FindNearbyDrivers(ctx context.Context, center *models.Location, radiusKm float64, limit int, cityID string) ([]*DriverLocation, error) {
+	args := m.Called(ctx, center, radiusKm, limit, cityID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -74,6 +75,43 @@ func TestAdvancedMatchingService_FindMatch_MockMode(t *testing.T) {
 	assert.Greater(t, result.ProcessingTime, time.Duration(0))
 }
 
+func TestAdvancedMatchingService_FindMatch_UsesGeoService(t *testing.T) {
+	service := NewSimpleMatchingService(&config.Config{})
+	geo := new(MockGeoServiceClient)
+	service.SetGeoService(geo)
+	service.SetFareEstimator(&fakeFareEstimator{})
+	ctx := context.Background()
+
+	request := &MatchingRequest{
+		TripID:         "trip-geo",
+		RiderID:        "rider-1",
+		PickupLocation: &models.Location{Latitude: 37.7749, Longitude: -122.4194},
+		Destination:    &models.Location{Latitude: 37.7849, Longitude: -122.4094},
+		VehicleType:    "sedan",
+		PassengerCount: 1,
+		MaxWaitTime:    time.Minute,
+	}
+	unreachable := &models.Location{Latitude: 37.77, Longitude: -122.43}
+	reachable := &models.Location{Latitude: 37.775, Longitude: -122.419}
+
+	geo.On("FindNearbyDrivers", mock.Anything, request.PickupLocation, mock.Anything, mock.Anything, request.City).Return([]*DriverLocation{
+		{DriverID: "driver-no-eta", Location: unreachable, DistanceFromCenter: 0.2, Status: "available", VehicleType: "sedan", Rating: 4.9},
+		{DriverID: "driver-1", Location: reachable, DistanceFromCenter: 0.5, Status: "available", VehicleType: "sedan", Rating: 4.8},
+	}, nil)
+	geo.On("CalculateETA", mock.Anything, unreachable, request.PickupLocation, "sedan").Return(nil, assert.AnError)
+	geo.On("CalculateETA", mock.Anything, reachable, request.PickupLocation, "sedan").Return(&ETAResult{DurationSeconds: 120}, nil)
+	geo.On("CalculateDistance", mock.Anything, request.PickupLocation, request.Destination).Return(&DistanceResult{DistanceKm: 2}, nil)
+	geo.On("CalculateETA", mock.Anything, request.PickupLocation, request.Destination, "sedan").Return(&ETAResult{DurationSeconds: 300}, nil)
+
+	result, err := service.FindMatch(ctx, request)
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, "driver-1", result.MatchedDriver.DriverID)
+	assert.Equal(t, 120, result.EstimatedETA)
+	assert.NotNil(t, result.EstimatedFare)
+	geo.AssertExpectations(t)
+}
+
 func TestAdvancedMatchingService_CalculateMatchingScore(t *testing.T) {
 	cfg := &config.Config{}
 	service := NewSimpleMatchingService(cfg)
@@ -127,7 +165,7 @@ func TestAdvancedMatchingService_CalculateMatchingScore(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			score := service.calculateMatchingScore(tt.driver, tt.request)
+			score := service.calculateMatchingScore(defaultScoringWeights, tt.driver, tt.request)
 			assert.GreaterOrEqual(t, score, tt.expectedMin)
 			assert.LessOrEqual(t, score, tt.expectedMax)
 		})
@@ -156,17 +194,141 @@ func TestAdvancedMatchingService_CancelMatching(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestAdvancedMatchingService_ReservationLookupsByTrip(t *testing.T) {
+	service := NewSimpleMatchingService(&config.Config{})
+	ctx := context.Background()
+
+	assert.NoError(t, service.reserveDriver(ctx, "driver-1", "trip-reserved"))
+
+	status, err := service.GetMatchingStatus(ctx, "trip-reserved")
+	assert.NoError(t, err)
+	assert.Equal(t, "searching", status["status"])
+
+	assert.NoError(t, service.CancelMatching(ctx, "trip-reserved"))
+	_, err = service.reservations.Get(ctx, "driver-1")
+	assert.ErrorIs(t, err, ErrReservationNotFound)
+}
+
+func TestAdvancedMatchingService_ReservationPreventsDoubleBooking(t *testing.T) {
+	service := NewSimpleMatchingService(&config.Config{})
+	ctx := context.Background()
+
+	assert.NoError(t, service.reserveDriver(ctx, "driver-1", "trip-1"))
+	assert.ErrorIs(t, service.reserveDriver(ctx, "driver-1", "trip-2"), ErrDriverReserved)
+
+	// The holding trip can reserve again without conflict
+	assert.NoError(t, service.reserveDriver(ctx, "driver-1", "trip-1"))
+
+	// A stale token cannot free the driver
+	assert.NoError(t, service.reservations.Release(ctx, &DriverReservation{DriverID: "driver-1", TripID: "trip-1", Token: "stale"}))
+	_, err := service.reservations.GetByTrip(ctx, "trip-1")
+	assert.NoError(t, err)
+
+	service.releaseDriver(ctx, "driver-1", "trip-1")
+	assert.NoError(t, service.reserveDriver(ctx, "driver-1", "trip-2"))
+}
+
+func TestAdvancedMatchingService_ReleaseDriverReservation(t *testing.T) {
+	service := NewSimpleMatchingService(&config.Config{})
+	ctx := context.Background()
+
+	_, err := service.ReleaseDriverReservation(ctx, "driver-1")
+	assert.ErrorIs(t, err, ErrReservationNotFound)
+
+	assert.NoError(t, service.reserveDriver(ctx, "driver-1", "trip-1"))
+	reservation, err := service.ReleaseDriverReservation(ctx, "driver-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "trip-1", reservation.TripID)
+
+	_, err = service.reservations.Get(ctx, "driver-1")
+	assert.ErrorIs(t, err, ErrReservationNotFound)
+	assert.NoError(t, service.reserveDriver(ctx, "driver-1", "trip-2"))
+}
+
+func TestAdvancedMatchingService_FindMatchSkipsReservedDrivers(t *testing.T) {
+	geo := &fakeGeoService{}
+	service := newQueueTestService(geo)
+	ctx := context.Background()
+
+	geo.setDrivers(
+		&DriverLocation{
+			DriverID:           "near-driver",
+			Location:           &models.Location{Latitude: 37.775, Longitude: -122.419},
+			DistanceFromCenter: 0.5,
+			Status:             "available",
+			VehicleType:        "sedan",
+			Rating:             4.9,
+		},
+		&DriverLocation{
+			DriverID:           "other-driver",
+			Location:           &models.Location{Latitude: 37.78, Longitude: -122.42},
+			DistanceFromCenter: 2,
+			Status:             "available",
+			VehicleType:        "sedan",
+			Rating:             4.8,
+		},
+	)
+
+	// A concurrent match already holds the closest driver
+	assert.NoError(t, service.reserveDriver(ctx, "near-driver", "trip-concurrent"))
+
+	result, err := service.FindMatch(ctx, newQueueTestRequest("trip-conflict", time.Minute))
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, "other-driver", result.MatchedDriver.DriverID)
+}
+
+func TestAdvancedMatchingService_ExpireReservations(t *testing.T) {
+	service := NewSimpleMatchingService(&config.Config{})
+	ctx := context.Background()
+
+	assert.NoError(t, service.reserveDriver(ctx, "driver-1", "trip-1"))
+	assert.NoError(t, service.reserveDriver(ctx, "driver-2", "trip-2"))
+
+	cleared, err := service.ExpireReservations(ctx, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, cleared)
+
+	cleared, err = service.ExpireReservations(ctx, time.Now().Add(reservationTTL))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, cleared)
+
+	_, err = service.reservations.GetByTrip(ctx, "trip-1")
+	assert.ErrorIs(t, err, ErrReservationNotFound)
+}
+
 func TestAdvancedMatchingService_GetMatchingMetrics(t *testing.T) {
 	cfg := &config.Config{}
 	service := NewSimpleMatchingService(cfg)
 	ctx := context.Background()
 
-	metrics, err := service.GetMatchingMetrics(ctx)
+	// Mock mode matches every request to the same driver
+	_, err := service.FindMatch(ctx, newOfferTestRequest("trip-metrics-1"))
+	assert.NoError(t, err)
+	_, err = service.FindMatch(ctx, newOfferTestRequest("trip-metrics-2"))
+	assert.NoError(t, err)
+	_, err = service.AcceptOffer(ctx, "trip-metrics-1", "mock-driver-123")
+	assert.NoError(t, err)
+	_, err = service.DeclineOffer(ctx, "trip-metrics-2", "mock-driver-123", "too far")
+	assert.NoError(t, err)
 
+	now := time.Now()
+	metrics, err := service.GetMatchingMetrics(ctx, analytics.TimeRange{From: now.Add(-time.Hour), To: now.Add(time.Minute)})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), metrics.TotalRequests)
+	assert.Equal(t, int64(2), metrics.SuccessfulMatches)
+	assert.Equal(t, 100.0, metrics.SuccessRate)
+	assert.Equal(t, 85.5, metrics.AvgMatchScore)
+	assert.Equal(t, 5.0, metrics.AvgETAMinutes)
+	assert.Equal(t, int64(2), metrics.OffersSent)
+	assert.Equal(t, 50.0, metrics.OfferAcceptanceRate)
+	assert.Len(t, metrics.Hourly, 1)
+
+	// Nothing was recorded before the range
+	metrics, err = service.GetMatchingMetrics(ctx, analytics.TimeRange{From: now.Add(-48 * time.Hour), To: now.Add(-24 * time.Hour)})
 	assert.NoError(t, err)
-	assert.NotNil(t, metrics)
-	assert.Contains(t, metrics, "total_requests")
-	assert.Contains(t, metrics, "success_rate")
+	assert.Zero(t, metrics.TotalRequests)
+	assert.Zero(t, metrics.SuccessRate)
 }
 
 // Additional comprehensive tests for better coverage
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"sync"
+)
+
+// OfferNotifier pushes offer updates to drivers
+type OfferNotifier interface {
+	NotifyDriver(ctx context.Context, driverID string, offer *DriverOffer) error
+}
+
+// OfferBroadcaster fans offer updates out to subscribed driver streams. The
+// API gateway subscribes on behalf of each driver WebSocket connection.
+type OfferBroadcaster struct {
+	subscribers map[string]map[chan *DriverOffer]struct{}
+	mutex       sync.RWMutex
+}
+
+// NewOfferBroadcaster creates a new offer broadcaster
+func NewOfferBroadcaster() *OfferBroadcaster {
+	return &OfferBroadcaster{
+		subscribers: make(map[string]map[chan *DriverOffer]struct{}),
+	}
+}
+
+// Subscribe registers a stream for a driver's offers. The returned function
+// must be called to release the subscription.
+func (b *OfferBroadcaster) Subscribe(driverID string) (<-chan *DriverOffer, func()) {
+	ch := make(chan *DriverOffer, 16)
+
+	b.mutex.Lock()
+	if b.subscribers[driverID] == nil {
+		b.subscribers[driverID] = make(map[chan *DriverOffer]struct{})
+	}
+	b.subscribers[driverID][ch] = struct{}{}
+	b.mutex.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mutex.Lock()
+			defer b.mutex.Unlock()
+			delete(b.subscribers[driverID], ch)
+			if len(b.subscribers[driverID]) == 0 {
+				delete(b.subscribers, driverID)
+			}
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+// NotifyDriver delivers an offer update to every stream the driver has open.
+// Slow subscribers are skipped rather than blocking the matching flow.
+func (b *OfferBroadcaster) NotifyDriver(ctx context.Context, driverID string, offer *DriverOffer) error {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	for ch := range b.subscribers[driverID] {
+		select {
+		case ch <- offer:
+		default:
+		}
+	}
+	return nil
+}
+
+// SubscriberCount returns the number of open streams for a driver
+func (b *OfferBroadcaster) SubscriberCount(driverID string) int {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return len(b.subscribers[driverID])
+}
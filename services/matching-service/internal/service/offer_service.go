@@ -0,0 +1,414 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rideshare-platform/shared/analytics"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/utils"
+)
+
+var (
+	// ErrOfferNotForDriver is returned when a driver responds to another driver's offer
+	ErrOfferNotForDriver = errors.New("offer is not assigned to this driver")
+	// ErrOfferNotPending is returned when responding to an offer that is already closed
+	ErrOfferNotPending = errors.New("offer is no longer pending")
+	// ErrOfferExpired is returned when the driver's response window has passed
+	ErrOfferExpired = errors.New("offer has expired")
+)
+
+// offerRetention keeps closed offers around long enough for status lookups
+const offerRetention = 5 * time.Minute
+
+// SetOfferNotifier sets the notifier used to push offers to drivers
+func (s *AdvancedMatchingService) SetOfferNotifier(notifier OfferNotifier) {
+	s.notifier = notifier
+}
+
+// offerTimeout returns how long a driver has to respond to an offer
+func (s *AdvancedMatchingService) offerTimeout() time.Duration {
+	if s.config == nil || s.config.DriverResponseTimeout <= 0 {
+		return 30 * time.Second
+	}
+	return time.Duration(s.config.DriverResponseTimeout) * time.Second
+}
+
+// createOffer offers the trip to the best driver, keeping the alternatives as fallbacks
+func (s *AdvancedMatchingService) createOffer(ctx context.Context, request *MatchingRequest, driver *MatchedDriverInfo, alternatives []*MatchedDriverInfo, fare *FareEstimate) (*DriverOffer, error) {
+	now := time.Now()
+	offer := &DriverOffer{
+		OfferID:        utils.GenerateID(),
+		TripID:         request.TripID,
+		RiderID:        request.RiderID,
+		Driver:         driver,
+		PickupLocation: request.PickupLocation,
+		Destination:    request.Destination,
+		EstimatedFare:  fare,
+		PassengerCount: request.PassengerCount,
+		Status:         OfferStatusPending,
+		Attempt:        1,
+		Candidates:     alternatives,
+		OfferedAt:      now,
+		ExpiresAt:      now.Add(s.offerTimeout()),
+	}
+	if allowsSharedRides(request) {
+		offer.AllowShared = true
+		offer.MaxDetourTime = request.Preferences.MaxDetourTime
+	}
+
+	s.offerMutex.Lock()
+	defer s.offerMutex.Unlock()
+
+	if err := s.saveOffer(ctx, offer); err != nil {
+		return nil, err
+	}
+	s.notifyDriver(ctx, driver.DriverID, offer)
+	s.notifyOfferProgress(ctx, offer, MatchingEventDriverOffered, "Driver found, waiting for them to accept", now)
+	s.record(now, analytics.Counters{counterOffersSent: 1})
+
+	return offer, nil
+}
+
+// GetOffer returns the current offer for a trip
+func (s *AdvancedMatchingService) GetOffer(ctx context.Context, tripID string) (*DriverOffer, error) {
+	return s.offers.GetOffer(ctx, tripID)
+}
+
+// AcceptOffer confirms the driver for the trip
+func (s *AdvancedMatchingService) AcceptOffer(ctx context.Context, tripID, driverID string) (*DriverOffer, error) {
+	s.offerMutex.Lock()
+	defer s.offerMutex.Unlock()
+
+	offer, err := s.pendingOfferFor(ctx, tripID, driverID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if offer.IsExpired(now) {
+		if _, err := s.advanceOffer(ctx, offer, OfferStatusExpired, now); err != nil {
+			return nil, err
+		}
+		return nil, ErrOfferExpired
+	}
+
+	offer.Status = OfferStatusAccepted
+	offer.RespondedAt = &now
+	offer.Candidates = nil
+	if err := s.saveOffer(ctx, offer); err != nil {
+		return nil, err
+	}
+	s.notifyDriver(ctx, driverID, offer)
+	s.notifyOfferProgress(ctx, offer, MatchingEventDriverAccepted, "Your driver is on the way", now)
+	s.recordOfferResponse(offer, OfferStatusAccepted, now)
+	s.recordDriverResponse(ctx, driverID, OfferStatusAccepted, now)
+
+	if offer.AllowShared {
+		s.openSharedTrip(ctx, offer)
+	}
+
+	if s.logger != nil {
+		s.logger.WithContext(ctx).WithFields(logger.Fields{
+			"trip_id":   tripID,
+			"driver_id": driverID,
+			"attempt":   offer.Attempt,
+		}).Info("Driver accepted trip offer")
+	}
+
+	return offer, nil
+}
+
+// DeclineOffer records the driver's decline and offers the trip to the next-best driver.
+// The returned offer is the trip's new offer, or an exhausted offer if no drivers remain.
+func (s *AdvancedMatchingService) DeclineOffer(ctx context.Context, tripID, driverID, reason string) (*DriverOffer, error) {
+	s.offerMutex.Lock()
+	defer s.offerMutex.Unlock()
+
+	offer, err := s.pendingOfferFor(ctx, tripID, driverID)
+	if err != nil {
+		return nil, err
+	}
+
+	offer.DeclineReason = reason
+	return s.advanceOffer(ctx, offer, OfferStatusDeclined, time.Now())
+}
+
+// ExpireOffers moves every offer whose response window has passed on to the
+// next candidate. It returns the number of offers that timed out.
+func (s *AdvancedMatchingService) ExpireOffers(ctx context.Context, now time.Time) (int, error) {
+	tripIDs, err := s.offers.ListExpiredOffers(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list expired offers: %w", err)
+	}
+
+	s.offerMutex.Lock()
+	defer s.offerMutex.Unlock()
+
+	expired := 0
+	for _, tripID := range tripIDs {
+		offer, err := s.offers.GetOffer(ctx, tripID)
+		if err != nil || !offer.IsExpired(now) {
+			continue
+		}
+
+		if _, err := s.advanceOffer(ctx, offer, OfferStatusExpired, now); err != nil {
+			if s.logger != nil {
+				s.logger.WithContext(ctx).WithError(err).WithField("trip_id", tripID).Error("Failed to expire offer")
+			}
+			continue
+		}
+		expired++
+	}
+
+	return expired, nil
+}
+
+// StartOfferExpiryWorker periodically expires unanswered offers until ctx is cancelled
+func (s *AdvancedMatchingService) StartOfferExpiryWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if _, err := s.ExpireOffers(ctx, now); err != nil && s.logger != nil {
+				s.logger.WithContext(ctx).WithError(err).Warn("Offer expiry sweep failed")
+			}
+		}
+	}
+}
+
+// cancelOffer withdraws any pending offer for a cancelled trip
+func (s *AdvancedMatchingService) cancelOffer(ctx context.Context, tripID string) {
+	s.offerMutex.Lock()
+	defer s.offerMutex.Unlock()
+
+	offer, err := s.offers.GetOffer(ctx, tripID)
+	if err != nil {
+		return
+	}
+
+	if offer.Status == OfferStatusPending {
+		offer.Status = OfferStatusCancelled
+		offer.Candidates = nil
+		s.notifyDriver(ctx, offer.DriverID(), offer)
+		s.notifyOfferProgress(ctx, offer, MatchingEventCancelled, "Matching cancelled", time.Now())
+	}
+
+	if err := s.offers.DeleteOffer(ctx, tripID); err != nil && s.logger != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("trip_id", tripID).Warn("Failed to delete offer")
+	}
+}
+
+// pendingOfferFor loads the trip's offer and checks that the driver may respond to it
+func (s *AdvancedMatchingService) pendingOfferFor(ctx context.Context, tripID, driverID string) (*DriverOffer, error) {
+	offer, err := s.offers.GetOffer(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+	if offer.DriverID() != driverID {
+		return nil, ErrOfferNotForDriver
+	}
+	if offer.Status != OfferStatusPending {
+		return nil, ErrOfferNotPending
+	}
+	return offer, nil
+}
+
+// advanceOffer closes the current driver's offer with the given status and
+// offers the trip to the next candidate that can still be reserved
+func (s *AdvancedMatchingService) advanceOffer(ctx context.Context, offer *DriverOffer, status OfferStatus, now time.Time) (*DriverOffer, error) {
+	previous := *offer
+	previous.Status = status
+	previous.RespondedAt = &now
+	previous.Candidates = nil
+
+	s.releaseDriver(ctx, previous.DriverID(), previous.TripID)
+	s.notifyDriver(ctx, previous.DriverID(), &previous)
+
+	next := *offer
+	next.DeclinedDrivers = append(append([]string{}, offer.DeclinedDrivers...), previous.DriverID())
+	next.DeclineReason = ""
+	next.RespondedAt = nil
+	next.Driver = nil
+
+	for len(next.Candidates) > 0 {
+		candidate := next.Candidates[0]
+		next.Candidates = next.Candidates[1:]
+
+		if err := s.reserveDriver(ctx, candidate.DriverID, offer.TripID); err != nil {
+			if s.logger != nil {
+				s.logger.WithContext(ctx).WithError(err).WithField("driver_id", candidate.DriverID).Warn("Failed to reserve fallback driver")
+			}
+			continue
+		}
+
+		next.OfferID = utils.GenerateID()
+		next.Driver = candidate
+		next.Status = OfferStatusPending
+		next.Attempt = offer.Attempt + 1
+		next.OfferedAt = now
+		next.ExpiresAt = now.Add(s.offerTimeout())
+		break
+	}
+
+	if next.Driver == nil {
+		next.Status = OfferStatusExhausted
+		next.Candidates = nil
+	}
+
+	if err := s.saveOffer(ctx, &next); err != nil {
+		return nil, err
+	}
+
+	if s.logger != nil {
+		s.logger.WithContext(ctx).WithFields(logger.Fields{
+			"trip_id":         offer.TripID,
+			"previous_driver": previous.DriverID(),
+			"previous_status": status,
+			"next_driver":     next.DriverID(),
+			"attempt":         next.Attempt,
+		}).Info("Trip offer moved to next driver")
+	}
+
+	s.recordOfferResponse(offer, status, now)
+	if previous.DeclineReason != operatorReleaseReason {
+		s.recordDriverResponse(ctx, previous.DriverID(), status, now)
+	}
+	if status == OfferStatusExpired {
+		s.notifyOfferProgress(ctx, &previous, MatchingEventOfferExpired, "Driver did not respond in time", now)
+	} else {
+		s.notifyOfferProgress(ctx, &previous, MatchingEventDriverDeclined, "Driver declined the trip", now)
+	}
+	if next.Driver != nil {
+		s.notifyDriver(ctx, next.DriverID(), &next)
+		s.notifyOfferProgress(ctx, &next, MatchingEventDriverOffered, "Offering the trip to another driver", now)
+		s.record(now, analytics.Counters{counterOffersSent: 1})
+	} else {
+		s.notifyOfferProgress(ctx, &next, MatchingEventNoDrivers, "No other driver is available", now)
+	}
+
+	return &next, nil
+}
+
+// saveOffer stores the offer, keeping it until shortly after its deadline
+func (s *AdvancedMatchingService) saveOffer(ctx context.Context, offer *DriverOffer) error {
+	ttl := offerRetention
+	if offer.Status == OfferStatusPending {
+		ttl += time.Until(offer.ExpiresAt)
+	}
+	if err := s.offers.SaveOffer(ctx, offer, ttl); err != nil {
+		return fmt.Errorf("failed to save offer for trip %s: %w", offer.TripID, err)
+	}
+	return nil
+}
+
+// notifyDriver pushes an offer update to the driver, logging delivery failures
+func (s *AdvancedMatchingService) notifyDriver(ctx context.Context, driverID string, offer *DriverOffer) {
+	if s.notifier == nil || driverID == "" {
+		return
+	}
+	if err := s.notifier.NotifyDriver(ctx, driverID, offer); err != nil && s.logger != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("driver_id", driverID).Warn("Failed to push offer to driver")
+	}
+}
+
+// notifyOfferProgress pushes a step in offering the trip to drivers to the
+// rider. The offer's driver is the one the step is about.
+func (s *AdvancedMatchingService) notifyOfferProgress(ctx context.Context, offer *DriverOffer, event MatchingEvent, message string, at time.Time) {
+	s.publishProgress(ctx, &MatchingProgress{
+		TripID:    offer.TripID,
+		RiderID:   offer.RiderID,
+		Event:     event,
+		Message:   message,
+		DriverID:  offer.DriverID(),
+		OfferID:   offer.OfferID,
+		Attempt:   offer.Attempt,
+		UpdatedAt: at,
+	})
+}
+
+// releaseDriver removes a driver's reservation for the trip so they can be
+// matched again. A reservation held for a different trip is left alone.
+func (s *AdvancedMatchingService) releaseDriver(ctx context.Context, driverID, tripID string) {
+	if driverID == "" {
+		return
+	}
+	reservation, err := s.reservations.Get(ctx, driverID)
+	if err != nil || reservation.TripID != tripID {
+		return
+	}
+	if err := s.reservations.Release(ctx, reservation); err != nil && s.logger != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("driver_id", driverID).Warn("Failed to release driver reservation")
+	}
+}
+
+// operatorReleaseReason declines the offer of a driver released by an
+// operator. It does not count against the driver's acceptance rate.
+const operatorReleaseReason = "released by operator"
+
+// ReleaseDriverReservation frees a driver held for a trip, as operators do for
+// a driver stuck on a stale match. A pending offer to the driver is declined
+// so the trip moves on to the next candidate. It returns the released
+// reservation, or ErrReservationNotFound if the driver is not held.
+func (s *AdvancedMatchingService) ReleaseDriverReservation(ctx context.Context, driverID string) (*DriverReservation, error) {
+	reservation, err := s.reservations.Get(ctx, driverID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.offerMutex.Lock()
+	defer s.offerMutex.Unlock()
+
+	if offer, err := s.pendingOfferFor(ctx, reservation.TripID, driverID); err == nil {
+		offer.DeclineReason = operatorReleaseReason
+		if _, err := s.advanceOffer(ctx, offer, OfferStatusDeclined, time.Now()); err != nil {
+			return nil, err
+		}
+	} else if err := s.reservations.Release(ctx, reservation); err != nil {
+		return nil, fmt.Errorf("failed to release reservation: %w", err)
+	}
+
+	if s.logger != nil {
+		s.logger.WithContext(ctx).WithFields(logger.Fields{
+			"driver_id": driverID,
+			"trip_id":   reservation.TripID,
+		}).Info("Driver reservation released")
+	}
+	return reservation, nil
+}
+
+// ExpireReservations clears reservations whose hold has run out from the
+// trip and expiry indexes. It returns the number of reservations cleared.
+func (s *AdvancedMatchingService) ExpireReservations(ctx context.Context, now time.Time) (int, error) {
+	driverIDs, err := s.reservations.PurgeExpired(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge expired reservations: %w", err)
+	}
+	if len(driverIDs) > 0 && s.logger != nil {
+		s.logger.WithContext(ctx).WithField("drivers", len(driverIDs)).Debug("Expired driver reservations")
+	}
+	return len(driverIDs), nil
+}
+
+// StartReservationExpiryWorker periodically releases expired reservations until ctx is cancelled
+func (s *AdvancedMatchingService) StartReservationExpiryWorker(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if _, err := s.ExpireReservations(ctx, now); err != nil && s.logger != nil {
+				s.logger.WithContext(ctx).WithError(err).Warn("Reservation expiry sweep failed")
+			}
+		}
+	}
+}
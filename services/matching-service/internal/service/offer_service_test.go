@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rideshare-platform/services/matching-service/internal/config"
+	"github.com/rideshare-platform/shared/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func newOfferTestRequest(tripID string) *MatchingRequest {
+	return &MatchingRequest{
+		TripID:         tripID,
+		RiderID:        "rider-1",
+		PickupLocation: &models.Location{Latitude: 37.7749, Longitude: -122.4194},
+		Destination:    &models.Location{Latitude: 37.7849, Longitude: -122.4094},
+		VehicleType:    "standard",
+	}
+}
+
+func newOfferTestDrivers(ids ...string) []*MatchedDriverInfo {
+	drivers := make([]*MatchedDriverInfo, 0, len(ids))
+	for i, id := range ids {
+		drivers = append(drivers, &MatchedDriverInfo{
+			DriverID:   id,
+			Distance:   float64(i + 1),
+			ETA:        (i + 1) * 60,
+			MatchScore: 90 - float64(i*10),
+		})
+	}
+	return drivers
+}
+
+func TestOfferFlow_FindMatchCreatesOffer(t *testing.T) {
+	service := NewSimpleMatchingService(&config.Config{DriverResponseTimeout: 15})
+	broadcaster := NewOfferBroadcaster()
+	service.SetOfferNotifier(broadcaster)
+	ctx := context.Background()
+
+	offers, unsubscribe := broadcaster.Subscribe("mock-driver-123")
+	defer unsubscribe()
+
+	result, err := service.FindMatch(ctx, newOfferTestRequest("trip-offer-1"))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, result.OfferID)
+	assert.NotNil(t, result.OfferExpiresAt)
+
+	select {
+	case offer := <-offers:
+		assert.Equal(t, "trip-offer-1", offer.TripID)
+		assert.Equal(t, OfferStatusPending, offer.Status)
+	default:
+		t.Fatal("expected offer to be pushed to the driver")
+	}
+
+	offer, err := service.GetOffer(ctx, "trip-offer-1")
+	assert.NoError(t, err)
+	assert.Equal(t, result.OfferID, offer.OfferID)
+	assert.WithinDuration(t, time.Now().Add(15*time.Second), offer.ExpiresAt, 2*time.Second)
+}
+
+func TestOfferFlow_Accept(t *testing.T) {
+	service := NewSimpleMatchingService(&config.Config{})
+	ctx := context.Background()
+	drivers := newOfferTestDrivers("driver-a", "driver-b")
+
+	_, err := service.createOffer(ctx, newOfferTestRequest("trip-accept"), drivers[0], drivers[1:], nil)
+	assert.NoError(t, err)
+
+	_, err = service.AcceptOffer(ctx, "trip-accept", "driver-b")
+	assert.ErrorIs(t, err, ErrOfferNotForDriver)
+
+	offer, err := service.AcceptOffer(ctx, "trip-accept", "driver-a")
+	assert.NoError(t, err)
+	assert.Equal(t, OfferStatusAccepted, offer.Status)
+	assert.NotNil(t, offer.RespondedAt)
+
+	_, err = service.DeclineOffer(ctx, "trip-accept", "driver-a", "changed mind")
+	assert.ErrorIs(t, err, ErrOfferNotPending)
+}
+
+func TestOfferFlow_DeclineFallsBackToNextDriver(t *testing.T) {
+	service := NewSimpleMatchingService(&config.Config{})
+	broadcaster := NewOfferBroadcaster()
+	service.SetOfferNotifier(broadcaster)
+	ctx := context.Background()
+	drivers := newOfferTestDrivers("driver-a", "driver-b", "driver-c")
+
+	first, err := service.createOffer(ctx, newOfferTestRequest("trip-decline"), drivers[0], drivers[1:], nil)
+	assert.NoError(t, err)
+
+	declinedUpdates, unsubscribeA := broadcaster.Subscribe("driver-a")
+	defer unsubscribeA()
+	nextOffers, unsubscribeB := broadcaster.Subscribe("driver-b")
+	defer unsubscribeB()
+
+	next, err := service.DeclineOffer(ctx, "trip-decline", "driver-a", "too far")
+	assert.NoError(t, err)
+	assert.Equal(t, "driver-b", next.DriverID())
+	assert.Equal(t, OfferStatusPending, next.Status)
+	assert.Equal(t, 2, next.Attempt)
+	assert.NotEqual(t, first.OfferID, next.OfferID)
+	assert.Equal(t, []string{"driver-a"}, next.DeclinedDrivers)
+	assert.Len(t, next.Candidates, 1)
+
+	select {
+	case update := <-declinedUpdates:
+		assert.Equal(t, OfferStatusDeclined, update.Status)
+		assert.Equal(t, "too far", update.DeclineReason)
+	default:
+		t.Fatal("expected declining driver to be notified")
+	}
+
+	select {
+	case offer := <-nextOffers:
+		assert.Equal(t, next.OfferID, offer.OfferID)
+	default:
+		t.Fatal("expected next driver to receive the offer")
+	}
+}
+
+func TestOfferFlow_DeclineExhaustsCandidates(t *testing.T) {
+	service := NewSimpleMatchingService(&config.Config{})
+	ctx := context.Background()
+	drivers := newOfferTestDrivers("driver-a")
+
+	_, err := service.createOffer(ctx, newOfferTestRequest("trip-exhaust"), drivers[0], nil, nil)
+	assert.NoError(t, err)
+
+	next, err := service.DeclineOffer(ctx, "trip-exhaust", "driver-a", "")
+	assert.NoError(t, err)
+	assert.Equal(t, OfferStatusExhausted, next.Status)
+	assert.Nil(t, next.Driver)
+}
+
+func TestOfferFlow_ExpireOffers(t *testing.T) {
+	service := NewSimpleMatchingService(&config.Config{DriverResponseTimeout: 10})
+	ctx := context.Background()
+	drivers := newOfferTestDrivers("driver-a", "driver-b")
+
+	offer, err := service.createOffer(ctx, newOfferTestRequest("trip-expire"), drivers[0], drivers[1:], nil)
+	assert.NoError(t, err)
+
+	expired, err := service.ExpireOffers(ctx, offer.ExpiresAt.Add(-time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, expired)
+
+	expired, err = service.ExpireOffers(ctx, offer.ExpiresAt.Add(time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, expired)
+
+	current, err := service.GetOffer(ctx, "trip-expire")
+	assert.NoError(t, err)
+	assert.Equal(t, "driver-b", current.DriverID())
+	assert.Equal(t, OfferStatusPending, current.Status)
+
+	_, err = service.AcceptOffer(ctx, "trip-expire", "driver-a")
+	assert.ErrorIs(t, err, ErrOfferNotForDriver)
+}
+
+func TestOfferFlow_CancelMatchingWithdrawsOffer(t *testing.T) {
+	service := NewSimpleMatchingService(&config.Config{})
+	ctx := context.Background()
+	drivers := newOfferTestDrivers("driver-a")
+
+	_, err := service.createOffer(ctx, newOfferTestRequest("trip-cancel"), drivers[0], nil, nil)
+	assert.NoError(t, err)
+
+	assert.NoError(t, service.CancelMatching(ctx, "trip-cancel"))
+
+	_, err = service.GetOffer(ctx, "trip-cancel")
+	assert.ErrorIs(t, err, ErrOfferNotFound)
+}
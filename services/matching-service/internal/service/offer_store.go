@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/rideshare-platform/shared/models"
+)
+
+// OfferStatus represents the state of a driver offer
+type OfferStatus string
+
+const (
+	OfferStatusPending   OfferStatus = "pending"
+	OfferStatusAccepted  OfferStatus = "accepted"
+	OfferStatusDeclined  OfferStatus = "declined"
+	OfferStatusExpired   OfferStatus = "expired"
+	OfferStatusCancelled OfferStatus = "cancelled"
+	OfferStatusExhausted OfferStatus = "exhausted" // no drivers left to offer the trip to
+)
+
+// ErrOfferNotFound is returned when a trip has no active offer
+var ErrOfferNotFound = errors.New("offer not found")
+
+// DriverOffer represents a trip offered to a single driver, together with the
+// ranked candidates to fall back on if the driver declines or does not respond
+type DriverOffer struct {
+	OfferID         string               `json:"offer_id"`
+	TripID          string               `json:"trip_id"`
+	RiderID         string               `json:"rider_id"`
+	Driver          *MatchedDriverInfo   `json:"driver,omitempty"`
+	PickupLocation  *models.Location     `json:"pickup_location"`
+	Destination     *models.Location     `json:"destination"`
+	EstimatedFare   *FareEstimate        `json:"estimated_fare,omitempty"`
+	PassengerCount  int                  `json:"passenger_count,omitempty"`
+	AllowShared     bool                 `json:"allow_shared,omitempty"`
+	MaxDetourTime   int                  `json:"max_detour_time,omitempty"` // minutes
+	Status          OfferStatus          `json:"status"`
+	Attempt         int                  `json:"attempt"`
+	Candidates      []*MatchedDriverInfo `json:"candidates,omitempty"`
+	DeclinedDrivers []string             `json:"declined_drivers,omitempty"`
+	DeclineReason   string               `json:"decline_reason,omitempty"`
+	OfferedAt       time.Time            `json:"offered_at"`
+	ExpiresAt       time.Time            `json:"expires_at"`
+	RespondedAt     *time.Time           `json:"responded_at,omitempty"`
+}
+
+// DriverID returns the ID of the driver currently holding the offer
+func (o *DriverOffer) DriverID() string {
+	if o.Driver == nil {
+		return ""
+	}
+	return o.Driver.DriverID
+}
+
+// IsExpired reports whether the driver's response window has passed
+func (o *DriverOffer) IsExpired(now time.Time) bool {
+	return o.Status == OfferStatusPending && !now.Before(o.ExpiresAt)
+}
+
+// OfferStore persists driver offers keyed by trip
+type OfferStore interface {
+	SaveOffer(ctx context.Context, offer *DriverOffer, ttl time.Duration) error
+	GetOffer(ctx context.Context, tripID string) (*DriverOffer, error)
+	DeleteOffer(ctx context.Context, tripID string) error
+	ListExpiredOffers(ctx context.Context, now time.Time) ([]string, error)
+}
+
+// RedisOfferStore stores offers in Redis with a TTL and tracks response
+// deadlines in a sorted set so timed-out offers can be found without scanning
+type RedisOfferStore struct {
+	client redis.UniversalClient
+}
+
+// Offers are written in one transaction with their deadline, so every offer
+// key shares the deadline index's hash tag and, in Redis Cluster, its slot
+const offerDeadlinesKey = "driver_offer_deadlines:{driver_offers}"
+
+// NewRedisOfferStore creates a new Redis-backed offer store
+func NewRedisOfferStore(client redis.UniversalClient) *RedisOfferStore {
+	return &RedisOfferStore{client: client}
+}
+
+func offerKey(tripID string) string {
+	return fmt.Sprintf("driver_offer:{driver_offers}:%s", tripID)
+}
+
+// SaveOffer stores the offer and records its deadline while it is pending
+func (s *RedisOfferStore) SaveOffer(ctx context.Context, offer *DriverOffer, ttl time.Duration) error {
+	data, err := json.Marshal(offer)
+	if err != nil {
+		return fmt.Errorf("failed to marshal offer: %w", err)
+	}
+
+	pipe := s.client.TxPipeline()
+	pipe.Set(ctx, offerKey(offer.TripID), data, ttl)
+	if offer.Status == OfferStatusPending {
+		pipe.ZAdd(ctx, offerDeadlinesKey, redis.Z{Score: float64(offer.ExpiresAt.Unix()), Member: offer.TripID})
+	} else {
+		pipe.ZRem(ctx, offerDeadlinesKey, offer.TripID)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to save offer: %w", err)
+	}
+	return nil
+}
+
+// GetOffer retrieves the current offer for a trip
+func (s *RedisOfferStore) GetOffer(ctx context.Context, tripID string) (*DriverOffer, error) {
+	data, err := s.client.Get(ctx, offerKey(tripID)).Bytes()
+	if err == redis.Nil {
+		return nil, ErrOfferNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get offer: %w", err)
+	}
+
+	var offer DriverOffer
+	if err := json.Unmarshal(data, &offer); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal offer: %w", err)
+	}
+	return &offer, nil
+}
+
+// DeleteOffer removes the offer for a trip
+func (s *RedisOfferStore) DeleteOffer(ctx context.Context, tripID string) error {
+	pipe := s.client.TxPipeline()
+	pipe.Del(ctx, offerKey(tripID))
+	pipe.ZRem(ctx, offerDeadlinesKey, tripID)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// ListExpiredOffers returns trip IDs whose pending offer deadline has passed
+func (s *RedisOfferStore) ListExpiredOffers(ctx context.Context, now time.Time) ([]string, error) {
+	return s.client.ZRangeByScore(ctx, offerDeadlinesKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: fmt.Sprintf("%d", now.Unix()),
+	}).Result()
+}
+
+// MemoryOfferStore is an in-memory offer store used when Redis is unavailable
+type MemoryOfferStore struct {
+	offers map[string]*memoryOffer
+	mutex  sync.RWMutex
+}
+
+type memoryOffer struct {
+	data      []byte
+	expiresAt time.Time
+	deadline  *time.Time
+}
+
+// NewMemoryOfferStore creates a new in-memory offer store
+func NewMemoryOfferStore() *MemoryOfferStore {
+	return &MemoryOfferStore{
+		offers: make(map[string]*memoryOffer),
+	}
+}
+
+// SaveOffer stores a copy of the offer
+func (s *MemoryOfferStore) SaveOffer(ctx context.Context, offer *DriverOffer, ttl time.Duration) error {
+	data, err := json.Marshal(offer)
+	if err != nil {
+		return fmt.Errorf("failed to marshal offer: %w", err)
+	}
+
+	entry := &memoryOffer{data: data, expiresAt: time.Now().Add(ttl)}
+	if offer.Status == OfferStatusPending {
+		deadline := offer.ExpiresAt
+		entry.deadline = &deadline
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.offers[offer.TripID] = entry
+	return nil
+}
+
+// GetOffer retrieves a copy of the current offer for a trip
+func (s *MemoryOfferStore) GetOffer(ctx context.Context, tripID string) (*DriverOffer, error) {
+	s.mutex.RLock()
+	entry, exists := s.offers[tripID]
+	s.mutex.RUnlock()
+
+	if !exists || time.Now().After(entry.expiresAt) {
+		return nil, ErrOfferNotFound
+	}
+
+	var offer DriverOffer
+	if err := json.Unmarshal(entry.data, &offer); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal offer: %w", err)
+	}
+	return &offer, nil
+}
+
+// DeleteOffer removes the offer for a trip
+func (s *MemoryOfferStore) DeleteOffer(ctx context.Context, tripID string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.offers, tripID)
+	return nil
+}
+
+// ListExpiredOffers returns trip IDs whose pending offer deadline has passed
+func (s *MemoryOfferStore) ListExpiredOffers(ctx context.Context, now time.Time) ([]string, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var tripIDs []string
+	for tripID, entry := range s.offers {
+		if entry.deadline != nil && !now.Before(*entry.deadline) {
+			tripIDs = append(tripIDs, tripID)
+		}
+	}
+	return tripIDs, nil
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+
+	"github.com/rideshare-platform/shared/models"
+)
+
+// ZoneChecker looks up the geo-service zones containing a pickup location
+type ZoneChecker interface {
+	// RestrictedPickupZone returns the name of a zone where pickups are not
+	// allowed, or an empty string if the location can be served
+	RestrictedPickupZone(ctx context.Context, location *models.Location) (string, error)
+}
+
+// SetZoneChecker sets the geo-service client used to refuse pickups in restricted zones
+func (s *AdvancedMatchingService) SetZoneChecker(checker ZoneChecker) {
+	s.zones = checker
+}
+
+// restrictedPickupZone returns the restricted zone the request's pickup lies in.
+// Lookup failures let matching go on rather than blocking every trip.
+func (s *AdvancedMatchingService) restrictedPickupZone(ctx context.Context, request *MatchingRequest) string {
+	if s.zones == nil || request.PickupLocation == nil {
+		return ""
+	}
+
+	zone, err := s.zones.RestrictedPickupZone(ctx, request.PickupLocation)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.WithContext(ctx).WithError(err).Warn("Failed to check pickup zones, allowing the pickup")
+		}
+		return ""
+	}
+	return zone
+}
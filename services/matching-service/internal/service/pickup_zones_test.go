@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rideshare-platform/shared/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeZoneChecker reports a fixed restricted zone or an error
+type fakeZoneChecker struct {
+	zone string
+	err  error
+}
+
+func (f *fakeZoneChecker) RestrictedPickupZone(ctx context.Context, location *models.Location) (string, error) {
+	return f.zone, f.err
+}
+
+func TestPickupZones_RestrictedPickupIsRefused(t *testing.T) {
+	geo := &fakeGeoService{}
+	geo.setDrivers(newRatedTestDrivers()...)
+	service := newQueueTestService(geo)
+	service.SetZoneChecker(&fakeZoneChecker{zone: "Terminal 4 curb"})
+	ctx := context.Background()
+
+	result, err := service.FindMatch(ctx, newQueueTestRequest("trip-zone-1", time.Minute))
+	assert.NoError(t, err)
+	assert.False(t, result.Success)
+	assert.False(t, result.Queued)
+	assert.Contains(t, result.Reason, "Terminal 4 curb")
+
+	// No driver was reserved and the trip was not queued for retries
+	_, err = service.reservations.GetByTrip(ctx, "trip-zone-1")
+	assert.True(t, errors.Is(err, ErrReservationNotFound))
+	_, err = service.queue.Get(ctx, "trip-zone-1")
+	assert.True(t, errors.Is(err, ErrQueuedMatchNotFound))
+}
+
+func TestPickupZones_LookupFailureAllowsPickup(t *testing.T) {
+	geo := &fakeGeoService{}
+	geo.setDrivers(newRatedTestDrivers()...)
+	service := newQueueTestService(geo)
+	service.SetZoneChecker(&fakeZoneChecker{err: errors.New("geo-service unavailable")})
+
+	result, err := service.FindMatch(context.Background(), newQueueTestRequest("trip-zone-2", time.Minute))
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+}
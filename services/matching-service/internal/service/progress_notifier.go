@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MatchingEvent names the step of matching a progress update reports
+type MatchingEvent string
+
+const (
+	// MatchingEventSearching is sent when matching a trip starts, and when a
+	// queued trip is retried without widening the search
+	MatchingEventSearching MatchingEvent = "searching"
+	// MatchingEventQueued is sent when no driver was found right away and
+	// matching goes on in the background
+	MatchingEventQueued         MatchingEvent = "queued"
+	MatchingEventRadiusExpanded MatchingEvent = "radius_expanded"
+	MatchingEventDriverOffered  MatchingEvent = "driver_offered"
+	MatchingEventDriverAccepted MatchingEvent = "driver_accepted"
+	MatchingEventDriverDeclined MatchingEvent = "driver_declined"
+	MatchingEventOfferExpired   MatchingEvent = "offer_expired"
+	// MatchingEventNoDrivers is sent when every driver found declined the
+	// trip, or none was found and the trip could not be queued
+	MatchingEventNoDrivers MatchingEvent = "no_drivers"
+	MatchingEventTimedOut  MatchingEvent = "timed_out"
+	MatchingEventCancelled MatchingEvent = "cancelled"
+)
+
+// eventStatuses are the matching statuses riders see after each event
+var eventStatuses = map[MatchingEvent]QueueStatus{
+	MatchingEventSearching:      QueueStatusMatching,
+	MatchingEventQueued:         QueueStatusMatching,
+	MatchingEventRadiusExpanded: QueueStatusMatching,
+	MatchingEventDriverOffered:  QueueStatusMatched,
+	MatchingEventDriverAccepted: QueueStatusMatched,
+	MatchingEventDriverDeclined: QueueStatusMatching,
+	MatchingEventOfferExpired:   QueueStatusMatching,
+	MatchingEventNoDrivers:      QueueStatusUnmatched,
+	MatchingEventTimedOut:       QueueStatusTimedOut,
+	MatchingEventCancelled:      QueueStatusCancelled,
+}
+
+// Final reports whether the event ends matching for the trip
+func (e MatchingEvent) Final() bool {
+	switch e {
+	case MatchingEventDriverAccepted, MatchingEventNoDrivers, MatchingEventTimedOut, MatchingEventCancelled:
+		return true
+	}
+	return false
+}
+
+// MatchingProgress is a progress update sent to the rider while a trip is
+// matching. Queue fields such as Retries and Deadline are only set for
+// trips matched in the background.
+type MatchingProgress struct {
+	TripID         string        `json:"trip_id"`
+	RiderID        string        `json:"rider_id"`
+	Event          MatchingEvent `json:"event"`
+	Status         QueueStatus   `json:"status"`
+	Retries        int           `json:"retries"`
+	SearchRadiusKm float64       `json:"search_radius_km"`
+	Message        string        `json:"message"`
+	DriverID       string        `json:"driver_id,omitempty"`
+	OfferID        string        `json:"offer_id,omitempty"`
+	Attempt        int           `json:"attempt,omitempty"` // offer of the trip the event is about, from 1
+	NextAttemptAt  *time.Time    `json:"next_attempt_at,omitempty"`
+	Deadline       *time.Time    `json:"deadline,omitempty"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+}
+
+// ProgressNotifier pushes matching progress to riders
+type ProgressNotifier interface {
+	NotifyRider(ctx context.Context, progress *MatchingProgress) error
+}
+
+// ProgressBroadcaster fans matching progress out to streams subscribed to a trip
+type ProgressBroadcaster struct {
+	subscribers map[string]map[chan *MatchingProgress]struct{}
+	mutex       sync.RWMutex
+}
+
+// NewProgressBroadcaster creates a new progress broadcaster
+func NewProgressBroadcaster() *ProgressBroadcaster {
+	return &ProgressBroadcaster{
+		subscribers: make(map[string]map[chan *MatchingProgress]struct{}),
+	}
+}
+
+// Subscribe registers a stream for a trip's matching progress. The returned
+// function must be called to release the subscription.
+func (b *ProgressBroadcaster) Subscribe(tripID string) (<-chan *MatchingProgress, func()) {
+	ch := make(chan *MatchingProgress, 16)
+
+	b.mutex.Lock()
+	if b.subscribers[tripID] == nil {
+		b.subscribers[tripID] = make(map[chan *MatchingProgress]struct{})
+	}
+	b.subscribers[tripID][ch] = struct{}{}
+	b.mutex.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mutex.Lock()
+			defer b.mutex.Unlock()
+			delete(b.subscribers[tripID], ch)
+			if len(b.subscribers[tripID]) == 0 {
+				delete(b.subscribers, tripID)
+			}
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+// NotifyRider delivers a progress update to every stream subscribed to the trip
+func (b *ProgressBroadcaster) NotifyRider(ctx context.Context, progress *MatchingProgress) error {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	for ch := range b.subscribers[progress.TripID] {
+		select {
+		case ch <- progress:
+		default:
+		}
+	}
+	return nil
+}
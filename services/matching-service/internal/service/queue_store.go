@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// QueueStatus represents the state of a trip in the matching queue
+type QueueStatus string
+
+const (
+	QueueStatusMatching  QueueStatus = "matching"
+	QueueStatusMatched   QueueStatus = "matched"
+	QueueStatusTimedOut  QueueStatus = "timed_out"
+	QueueStatusCancelled QueueStatus = "cancelled"
+	// QueueStatusUnmatched is reported for trips no driver took, without
+	// them being queued
+	QueueStatusUnmatched QueueStatus = "unmatched"
+)
+
+// ErrQueuedMatchNotFound is returned when a trip is not in the matching queue
+var ErrQueuedMatchNotFound = errors.New("trip is not in the matching queue")
+
+// QueuedMatch is a trip that is waiting for a driver and will be retried
+type QueuedMatch struct {
+	TripID         string           `json:"trip_id"`
+	Request        *MatchingRequest `json:"request"`
+	Status         QueueStatus      `json:"status"`
+	Retries        int              `json:"retries"`
+	SearchRadiusKm float64          `json:"search_radius_km"`
+	LastReason     string           `json:"last_reason,omitempty"`
+	MatchedDriver  string           `json:"matched_driver,omitempty"`
+	OfferID        string           `json:"offer_id,omitempty"`
+	EnqueuedAt     time.Time        `json:"enqueued_at"`
+	NextAttemptAt  time.Time        `json:"next_attempt_at"`
+	Deadline       time.Time        `json:"deadline"`
+	UpdatedAt      time.Time        `json:"updated_at"`
+}
+
+// MatchingQueue persists queued trips and their retry schedule
+type MatchingQueue interface {
+	Save(ctx context.Context, entry *QueuedMatch, ttl time.Duration) error
+	Get(ctx context.Context, tripID string) (*QueuedMatch, error)
+	Remove(ctx context.Context, tripID string) error
+	ListDue(ctx context.Context, now time.Time) ([]string, error)
+}
+
+// RedisMatchingQueue stores queued trips in Redis, scheduling retries in a sorted set
+type RedisMatchingQueue struct {
+	client redis.UniversalClient
+}
+
+// Queue entries are written in one transaction with their schedule, so every
+// entry key shares the schedule's hash tag and, in Redis Cluster, its slot
+const matchingScheduleKey = "matching_queue_schedule:{matching_queue}"
+
+// NewRedisMatchingQueue creates a new Redis-backed matching queue
+func NewRedisMatchingQueue(client redis.UniversalClient) *RedisMatchingQueue {
+	return &RedisMatchingQueue{client: client}
+}
+
+func queueKey(tripID string) string {
+	return fmt.Sprintf("matching_queue:{matching_queue}:%s", tripID)
+}
+
+// Save stores the entry and schedules its next attempt while it is still matching
+func (q *RedisMatchingQueue) Save(ctx context.Context, entry *QueuedMatch, ttl time.Duration) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal queued match: %w", err)
+	}
+
+	pipe := q.client.TxPipeline()
+	pipe.Set(ctx, queueKey(entry.TripID), data, ttl)
+	if entry.Status == QueueStatusMatching {
+		pipe.ZAdd(ctx, matchingScheduleKey, redis.Z{Score: float64(entry.NextAttemptAt.UnixMilli()), Member: entry.TripID})
+	} else {
+		pipe.ZRem(ctx, matchingScheduleKey, entry.TripID)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to save queued match: %w", err)
+	}
+	return nil
+}
+
+// Get retrieves the queue entry for a trip
+func (q *RedisMatchingQueue) Get(ctx context.Context, tripID string) (*QueuedMatch, error) {
+	data, err := q.client.Get(ctx, queueKey(tripID)).Bytes()
+	if err == redis.Nil {
+		return nil, ErrQueuedMatchNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get queued match: %w", err)
+	}
+
+	var entry QueuedMatch
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal queued match: %w", err)
+	}
+	return &entry, nil
+}
+
+// Remove deletes a trip from the queue
+func (q *RedisMatchingQueue) Remove(ctx context.Context, tripID string) error {
+	pipe := q.client.TxPipeline()
+	pipe.Del(ctx, queueKey(tripID))
+	pipe.ZRem(ctx, matchingScheduleKey, tripID)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// ListDue returns trip IDs whose next attempt is due
+func (q *RedisMatchingQueue) ListDue(ctx context.Context, now time.Time) ([]string, error) {
+	return q.client.ZRangeByScore(ctx, matchingScheduleKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: fmt.Sprintf("%d", now.UnixMilli()),
+	}).Result()
+}
+
+// MemoryMatchingQueue is an in-memory matching queue used when Redis is unavailable
+type MemoryMatchingQueue struct {
+	entries map[string]*memoryQueuedMatch
+	mutex   sync.RWMutex
+}
+
+type memoryQueuedMatch struct {
+	data      []byte
+	expiresAt time.Time
+	dueAt     *time.Time
+}
+
+// NewMemoryMatchingQueue creates a new in-memory matching queue
+func NewMemoryMatchingQueue() *MemoryMatchingQueue {
+	return &MemoryMatchingQueue{
+		entries: make(map[string]*memoryQueuedMatch),
+	}
+}
+
+// Save stores a copy of the entry
+func (q *MemoryMatchingQueue) Save(ctx context.Context, entry *QueuedMatch, ttl time.Duration) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal queued match: %w", err)
+	}
+
+	stored := &memoryQueuedMatch{data: data, expiresAt: time.Now().Add(ttl)}
+	if entry.Status == QueueStatusMatching {
+		dueAt := entry.NextAttemptAt
+		stored.dueAt = &dueAt
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.entries[entry.TripID] = stored
+	return nil
+}
+
+// Get retrieves a copy of the queue entry for a trip
+func (q *MemoryMatchingQueue) Get(ctx context.Context, tripID string) (*QueuedMatch, error) {
+	q.mutex.RLock()
+	stored, exists := q.entries[tripID]
+	q.mutex.RUnlock()
+
+	if !exists || time.Now().After(stored.expiresAt) {
+		return nil, ErrQueuedMatchNotFound
+	}
+
+	var entry QueuedMatch
+	if err := json.Unmarshal(stored.data, &entry); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal queued match: %w", err)
+	}
+	return &entry, nil
+}
+
+// Remove deletes a trip from the queue
+func (q *MemoryMatchingQueue) Remove(ctx context.Context, tripID string) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	delete(q.entries, tripID)
+	return nil
+}
+
+// ListDue returns trip IDs whose next attempt is due
+func (q *MemoryMatchingQueue) ListDue(ctx context.Context, now time.Time) ([]string, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	var tripIDs []string
+	for tripID, stored := range q.entries {
+		if stored.dueAt != nil && !now.Before(*stored.dueAt) {
+			tripIDs = append(tripIDs, tripID)
+		}
+	}
+	return tripIDs, nil
+}
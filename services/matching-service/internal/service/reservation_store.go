@@ -0,0 +1,302 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// reservationTTL is how long a driver stays held for a trip
+const reservationTTL = 5 * time.Minute
+
+var (
+	// ErrReservationNotFound is returned when a driver or trip has no active reservation
+	ErrReservationNotFound = errors.New("reservation not found")
+	// ErrDriverReserved is returned when another trip already holds the driver
+	ErrDriverReserved = errors.New("driver is reserved for another trip")
+)
+
+// DriverReservation holds a driver for a trip while the offer is outstanding.
+// Token identifies the holder, so only the reservation that took the driver
+// can release it.
+type DriverReservation struct {
+	DriverID   string    `json:"driver_id"`
+	TripID     string    `json:"trip_id"`
+	Token      string    `json:"token"`
+	ReservedAt time.Time `json:"reserved_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// ReservationStore persists driver reservations, indexed by driver and by trip
+type ReservationStore interface {
+	// Reserve takes the driver until the reservation's ExpiresAt. It returns
+	// ErrDriverReserved if another trip holds the driver; if the same trip
+	// does, the reservation adopts the existing token and succeeds.
+	Reserve(ctx context.Context, reservation *DriverReservation) error
+	Get(ctx context.Context, driverID string) (*DriverReservation, error)
+	GetByTrip(ctx context.Context, tripID string) (*DriverReservation, error)
+	// Release frees the driver if the reservation's token still holds it
+	Release(ctx context.Context, reservation *DriverReservation) error
+	// PurgeExpired drops index entries of reservations that expired at or
+	// before now and returns the affected drivers
+	PurgeExpired(ctx context.Context, now time.Time) ([]string, error)
+}
+
+// RedisReservationStore keeps each reservation under the driver's key, a
+// trip→driver mapping for lookups by trip, and a sorted set of expiry times
+// for sweeps, so nothing needs to scan the keyspace. The driver key doubles
+// as a lock: it is taken with SET NX and released by a script that checks the
+// holder's token.
+type RedisReservationStore struct {
+	client redis.UniversalClient
+}
+
+// releaseReservationScript deletes the driver key only while it still holds
+// the caller's token, so a holder whose reservation expired and was retaken
+// cannot free the new holder's driver
+var releaseReservationScript = redis.NewScript(`
+local current = redis.call("GET", KEYS[1])
+if not current then
+	return 0
+end
+if cjson.decode(current).token ~= ARGV[1] then
+	return -1
+end
+return redis.call("DEL", KEYS[1])
+`)
+
+// reservationExpiriesKey and tripReservationKey are written in one
+// transaction, so they share a hash tag and, in Redis Cluster, a slot
+const reservationExpiriesKey = "driver_reservation_expiries:{driver_reservations}"
+
+// NewRedisReservationStore creates a new Redis-backed reservation store
+func NewRedisReservationStore(client redis.UniversalClient) *RedisReservationStore {
+	return &RedisReservationStore{client: client}
+}
+
+// driverReservationKey hash-tags the driver ID so each driver's lock hashes
+// to a fixed slot of its own
+func driverReservationKey(driverID string) string {
+	return fmt.Sprintf("driver_reservation:{%s}", driverID)
+}
+
+func tripReservationKey(tripID string) string {
+	return fmt.Sprintf("trip_reservation:{driver_reservations}:%s", tripID)
+}
+
+// Reserve takes the driver's key with SET NX, then records the trip mapping
+// and expiry. A conflict with a reservation for the same trip is not an error.
+func (s *RedisReservationStore) Reserve(ctx context.Context, reservation *DriverReservation) error {
+	data, err := json.Marshal(reservation)
+	if err != nil {
+		return fmt.Errorf("failed to marshal reservation: %w", err)
+	}
+	ttl := time.Until(reservation.ExpiresAt)
+	if ttl <= 0 {
+		return fmt.Errorf("reservation for driver %s has already expired", reservation.DriverID)
+	}
+
+	acquired, err := s.client.SetNX(ctx, driverReservationKey(reservation.DriverID), data, ttl).Result()
+	if err != nil {
+		return fmt.Errorf("failed to reserve driver: %w", err)
+	}
+	if !acquired {
+		current, err := s.Get(ctx, reservation.DriverID)
+		if errors.Is(err, ErrReservationNotFound) {
+			// The holder released it between our SET NX and GET; treat it as
+			// a conflict and let the caller move on to another driver
+			return ErrDriverReserved
+		}
+		if err != nil {
+			return err
+		}
+		if current.TripID != reservation.TripID {
+			return ErrDriverReserved
+		}
+		*reservation = *current
+		return nil
+	}
+
+	pipe := s.client.TxPipeline()
+	pipe.Set(ctx, tripReservationKey(reservation.TripID), reservation.DriverID, ttl)
+	pipe.ZAdd(ctx, reservationExpiriesKey, redis.Z{Score: float64(reservation.ExpiresAt.Unix()), Member: reservation.DriverID})
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to index reservation: %w", err)
+	}
+	return nil
+}
+
+// Get retrieves a driver's active reservation
+func (s *RedisReservationStore) Get(ctx context.Context, driverID string) (*DriverReservation, error) {
+	data, err := s.client.Get(ctx, driverReservationKey(driverID)).Bytes()
+	if err == redis.Nil {
+		return nil, ErrReservationNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservation: %w", err)
+	}
+
+	var reservation DriverReservation
+	if err := json.Unmarshal(data, &reservation); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal reservation: %w", err)
+	}
+	return &reservation, nil
+}
+
+// GetByTrip retrieves the reservation held for a trip through the trip mapping
+func (s *RedisReservationStore) GetByTrip(ctx context.Context, tripID string) (*DriverReservation, error) {
+	driverID, err := s.client.Get(ctx, tripReservationKey(tripID)).Result()
+	if err == redis.Nil {
+		return nil, ErrReservationNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trip reservation: %w", err)
+	}
+
+	reservation, err := s.Get(ctx, driverID)
+	if err != nil {
+		return nil, err
+	}
+	// The driver has since been reserved for another trip
+	if reservation.TripID != tripID {
+		return nil, ErrReservationNotFound
+	}
+	return reservation, nil
+}
+
+// Release frees the driver if the token still holds it, then removes the
+// trip mapping and expiry entry
+func (s *RedisReservationStore) Release(ctx context.Context, reservation *DriverReservation) error {
+	result, err := releaseReservationScript.Run(ctx, s.client,
+		[]string{driverReservationKey(reservation.DriverID)}, reservation.Token).Int()
+	if err != nil {
+		return fmt.Errorf("failed to release reservation: %w", err)
+	}
+	if result < 0 {
+		// Another trip holds the driver now and owns the indexes
+		return nil
+	}
+
+	s.client.ZRem(ctx, reservationExpiriesKey, reservation.DriverID)
+	s.deleteTripMapping(ctx, reservation.TripID, reservation.DriverID)
+	return nil
+}
+
+// PurgeExpired removes expired entries from the expiry index. The driver and
+// trip keys expire on their own TTLs.
+func (s *RedisReservationStore) PurgeExpired(ctx context.Context, now time.Time) ([]string, error) {
+	max := fmt.Sprintf("%d", now.Unix())
+	pipe := s.client.TxPipeline()
+	expired := pipe.ZRangeByScore(ctx, reservationExpiriesKey, &redis.ZRangeBy{Min: "-inf", Max: max})
+	pipe.ZRemRangeByScore(ctx, reservationExpiriesKey, "-inf", max)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to purge expired reservations: %w", err)
+	}
+	return expired.Val(), nil
+}
+
+// deleteTripMapping removes a trip's mapping unless it already points at
+// another driver
+func (s *RedisReservationStore) deleteTripMapping(ctx context.Context, tripID, driverID string) {
+	key := tripReservationKey(tripID)
+	if owner, err := s.client.Get(ctx, key).Result(); err == nil && owner == driverID {
+		s.client.Del(ctx, key)
+	}
+}
+
+// MemoryReservationStore is an in-memory reservation store used when Redis is unavailable
+type MemoryReservationStore struct {
+	byDriver map[string]DriverReservation
+	byTrip   map[string]string
+	mutex    sync.RWMutex
+}
+
+// NewMemoryReservationStore creates a new in-memory reservation store
+func NewMemoryReservationStore() *MemoryReservationStore {
+	return &MemoryReservationStore{
+		byDriver: make(map[string]DriverReservation),
+		byTrip:   make(map[string]string),
+	}
+}
+
+// Reserve stores a copy of the reservation unless another trip holds the driver
+func (s *MemoryReservationStore) Reserve(ctx context.Context, reservation *DriverReservation) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if current, exists := s.byDriver[reservation.DriverID]; exists && time.Now().Before(current.ExpiresAt) {
+		if current.TripID != reservation.TripID {
+			return ErrDriverReserved
+		}
+		*reservation = current
+		return nil
+	}
+	s.byDriver[reservation.DriverID] = *reservation
+	s.byTrip[reservation.TripID] = reservation.DriverID
+	return nil
+}
+
+// Get retrieves a copy of a driver's active reservation
+func (s *MemoryReservationStore) Get(ctx context.Context, driverID string) (*DriverReservation, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	reservation, exists := s.byDriver[driverID]
+	if !exists || !time.Now().Before(reservation.ExpiresAt) {
+		return nil, ErrReservationNotFound
+	}
+	return &reservation, nil
+}
+
+// GetByTrip retrieves a copy of the reservation held for a trip
+func (s *MemoryReservationStore) GetByTrip(ctx context.Context, tripID string) (*DriverReservation, error) {
+	s.mutex.RLock()
+	driverID, exists := s.byTrip[tripID]
+	s.mutex.RUnlock()
+
+	if !exists {
+		return nil, ErrReservationNotFound
+	}
+	return s.Get(ctx, driverID)
+}
+
+// Release removes a driver's reservation if the token still holds it
+func (s *MemoryReservationStore) Release(ctx context.Context, reservation *DriverReservation) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	current, exists := s.byDriver[reservation.DriverID]
+	if !exists || current.Token != reservation.Token {
+		return nil
+	}
+	s.remove(current)
+	return nil
+}
+
+// PurgeExpired removes reservations that expired at or before now
+func (s *MemoryReservationStore) PurgeExpired(ctx context.Context, now time.Time) ([]string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var driverIDs []string
+	for driverID, reservation := range s.byDriver {
+		if !now.Before(reservation.ExpiresAt) {
+			s.remove(reservation)
+			driverIDs = append(driverIDs, driverID)
+		}
+	}
+	return driverIDs, nil
+}
+
+// remove deletes a reservation and its trip mapping; callers hold the lock
+func (s *MemoryReservationStore) remove(reservation DriverReservation) {
+	if s.byTrip[reservation.TripID] == reservation.DriverID {
+		delete(s.byTrip, reservation.TripID)
+	}
+	delete(s.byDriver, reservation.DriverID)
+}
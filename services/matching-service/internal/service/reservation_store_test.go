@@ -0,0 +1,27 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rideshare-platform/shared/database"
+)
+
+func TestReservationKeys_ClusterSlots(t *testing.T) {
+	// Reserve indexes the trip mapping and the expiry set in one transaction,
+	// which Redis Cluster only allows within one slot
+	expiries := database.ClusterSlot(reservationExpiriesKey)
+	for _, tripID := range []string{"trip-1", "trip-2", "5f0c6a2e-8d1b-4c39-9a57-3e1f2b7c9d40"} {
+		assert.Equal(t, expiries, database.ClusterSlot(tripReservationKey(tripID)), tripID)
+	}
+
+	// Each driver's lock hashes on the driver ID alone, so the locks spread
+	// over the cluster instead of all landing with the indexes
+	assert.Equal(t, database.ClusterSlot("driver-1"), database.ClusterSlot(driverReservationKey("driver-1")))
+	slots := make(map[int]bool)
+	for _, driverID := range []string{"driver-1", "driver-2", "driver-3", "driver-4"} {
+		slots[database.ClusterSlot(driverReservationKey(driverID))] = true
+	}
+	assert.Greater(t, len(slots), 1)
+}
@@ -0,0 +1,541 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+)
+
+const (
+	stopTypePickup  = "pickup"
+	stopTypeDropoff = "dropoff"
+
+	// defaultMaxDetourMinutes applies when a rider allows shared rides without a detour limit
+	defaultMaxDetourMinutes = 10
+	// maxPooledPickupSeconds caps how long a pooled rider waits for the shared vehicle
+	maxPooledPickupSeconds = 15 * 60
+	// sharedRideDiscount is applied to local fare estimates when pricing-service cannot split the fare
+	sharedRideDiscount = 0.25
+)
+
+// SharedTrip is a driver's in-progress multi-stop trip as tracked by trip-service
+type SharedTrip struct {
+	ID              string
+	DriverID        string
+	VehicleType     string
+	SeatCapacity    int
+	SeatsOccupied   int
+	Version         int
+	CurrentLocation *models.Location
+	Stops           []*SharedTripStop
+	Riders          []*SharedRider
+}
+
+// SharedTripStop is a pickup or dropoff on a shared trip's route
+type SharedTripStop struct {
+	ID        string
+	TripID    string
+	RiderID   string
+	Type      string
+	Location  *models.Location
+	Completed bool
+}
+
+// SharedRider is one rider's trip on a shared route
+type SharedRider struct {
+	TripID           string           `json:"trip_id"`
+	RiderID          string           `json:"rider_id"`
+	PickupLocation   *models.Location `json:"pickup_location"`
+	Destination      *models.Location `json:"destination"`
+	PassengerCount   int              `json:"passenger_count"`
+	MaxDetourMinutes int              `json:"max_detour_minutes"`
+	Fare             float64          `json:"fare"`
+}
+
+// SharedTripClient manages shared trips in trip-service
+type SharedTripClient interface {
+	ListOpenSharedTrips(ctx context.Context, vehicleType string, seatsNeeded int) ([]*SharedTrip, error)
+	OpenSharedTrip(ctx context.Context, driverID, vehicleType string, seatCapacity int, rider *SharedRider, location *models.Location) (*SharedTrip, error)
+	AddSharedRider(ctx context.Context, sharedTripID string, expectedVersion int, rider *SharedRider, pickupIndex, dropoffIndex int) (*SharedTrip, error)
+}
+
+// SharedFareRider is one rider's direct leg for fare splitting
+type SharedFareRider struct {
+	RiderID         string
+	TripID          string
+	DistanceKm      float64
+	DurationSeconds int
+}
+
+// SharedFareRequest asks pricing-service to split a shared route's fare
+type SharedFareRequest struct {
+	VehicleType          string
+	RouteDistanceKm      float64
+	RouteDurationSeconds int
+	City                 string
+	Riders               []*SharedFareRider
+}
+
+// SharedFareShare is one rider's part of a shared route's fare
+type SharedFareShare struct {
+	RiderID    string  `json:"rider_id"`
+	TripID     string  `json:"trip_id"`
+	SoloFare   float64 `json:"solo_fare"`
+	SharedFare float64 `json:"shared_fare"`
+	Savings    float64 `json:"savings"`
+	Currency   string  `json:"currency"`
+}
+
+// FareSplitter splits shared ride fares via pricing-service
+type FareSplitter interface {
+	SplitSharedFare(ctx context.Context, request *SharedFareRequest) ([]*SharedFareShare, error)
+}
+
+// SharedRideMatch describes where a rider was inserted on a shared trip
+type SharedRideMatch struct {
+	SharedTripID      string             `json:"shared_trip_id"`
+	PickupIndex       int                `json:"pickup_index"`
+	DropoffIndex      int                `json:"dropoff_index"`
+	DetourSeconds     int                `json:"detour_seconds"`
+	AddedRouteSeconds int                `json:"added_route_seconds"`
+	FareShares        []*SharedFareShare `json:"fare_shares,omitempty"`
+}
+
+// pooledInsertion is a candidate placement of a rider's stops on a shared trip
+type pooledInsertion struct {
+	trip          *SharedTrip
+	pickupIndex   int
+	dropoffIndex  int
+	route         []*SharedTripStop // pending stops after insertion
+	arrivals      []int             // seconds from now until each route stop
+	detourSeconds int
+	addedSeconds  int
+}
+
+// SetSharedTripClient sets the trip-service client used for shared rides
+func (s *AdvancedMatchingService) SetSharedTripClient(client SharedTripClient) {
+	s.sharedTrips = client
+}
+
+// SetFareSplitter sets the pricing-service client used to split shared fares
+func (s *AdvancedMatchingService) SetFareSplitter(splitter FareSplitter) {
+	s.fareSplitter = splitter
+}
+
+// allowsSharedRides reports whether the rider opted into pooling
+func allowsSharedRides(request *MatchingRequest) bool {
+	return request.Preferences != nil && request.Preferences.AllowSharedRides
+}
+
+// maxDetourSeconds converts a rider's detour tolerance to seconds
+func maxDetourSeconds(minutes int) int {
+	if minutes <= 0 {
+		minutes = defaultMaxDetourMinutes
+	}
+	return minutes * 60
+}
+
+// passengerCount returns the seats a request needs
+func passengerCount(request *MatchingRequest) int {
+	if request.PassengerCount <= 0 {
+		return 1
+	}
+	return request.PassengerCount
+}
+
+// findPooledMatch tries to insert the request into an in-progress shared trip
+// without delaying any rider beyond their detour limit. It returns nil when no
+// shared trip can take the rider.
+func (s *AdvancedMatchingService) findPooledMatch(ctx context.Context, request *MatchingRequest, startTime time.Time) (*MatchingResult, error) {
+	trips, err := s.sharedTrips.ListOpenSharedTrips(ctx, request.VehicleType, passengerCount(request))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shared trips: %w", err)
+	}
+
+	timer := newRouteTimer(s.geoService, request.VehicleType)
+	var best *pooledInsertion
+	for _, trip := range trips {
+		insertion, err := s.planPooledInsertion(ctx, timer, trip, request)
+		if err != nil {
+			if s.logger != nil {
+				s.logger.WithContext(ctx).WithError(err).WithField("shared_trip_id", trip.ID).Warn("Failed to plan shared ride insertion")
+			}
+			continue
+		}
+		if insertion != nil && (best == nil || insertion.addedSeconds < best.addedSeconds) {
+			best = insertion
+		}
+	}
+	if best == nil {
+		return nil, nil
+	}
+
+	fare, shares := s.splitSharedFare(ctx, timer, best, request)
+	rider := sharedRiderFor(request)
+	if fare != nil {
+		rider.Fare = fare.TotalEstimate
+	}
+
+	trip, err := s.sharedTrips.AddSharedRider(ctx, best.trip.ID, best.trip.Version, rider, best.pickupIndex, best.dropoffIndex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add rider to shared trip: %w", err)
+	}
+
+	pickupETA := best.arrivals[best.pickupIndex]
+	result := &MatchingResult{
+		TripID:  request.TripID,
+		Success: true,
+		MatchedDriver: &MatchedDriverInfo{
+			DriverID:        trip.DriverID,
+			CurrentLocation: trip.CurrentLocation,
+			ETA:             pickupETA,
+			Status:          "on_trip",
+			VehicleInfo: &VehicleDetails{
+				VehicleType: trip.VehicleType,
+				Capacity:    trip.SeatCapacity,
+			},
+		},
+		EstimatedETA:   pickupETA,
+		EstimatedFare:  fare,
+		Reason:         "Matched onto a shared trip in progress",
+		ProcessingTime: time.Since(startTime),
+		SharedRide: &SharedRideMatch{
+			SharedTripID:      trip.ID,
+			PickupIndex:       best.pickupIndex,
+			DropoffIndex:      best.dropoffIndex,
+			DetourSeconds:     best.detourSeconds,
+			AddedRouteSeconds: best.addedSeconds,
+			FareShares:        shares,
+		},
+	}
+
+	if s.logger != nil {
+		s.logger.WithContext(ctx).WithFields(logger.Fields{
+			"trip_id":        request.TripID,
+			"shared_trip_id": trip.ID,
+			"driver_id":      trip.DriverID,
+			"detour_seconds": best.detourSeconds,
+			"added_seconds":  best.addedSeconds,
+		}).Info("Trip matched onto shared trip")
+	}
+
+	return result, nil
+}
+
+// planPooledInsertion finds the cheapest pickup and dropoff positions on a
+// shared trip that respect seat capacity and every rider's detour limit
+func (s *AdvancedMatchingService) planPooledInsertion(ctx context.Context, timer *routeTimer, trip *SharedTrip, request *MatchingRequest) (*pooledInsertion, error) {
+	if trip.CurrentLocation == nil {
+		return nil, nil
+	}
+
+	var pending []*SharedTripStop
+	for _, stop := range trip.Stops {
+		if !stop.Completed {
+			pending = append(pending, stop)
+		}
+	}
+
+	baseline, err := timer.arrivals(ctx, trip.CurrentLocation, pending)
+	if err != nil {
+		return nil, err
+	}
+	baselineAt := make(map[string]int, len(pending))
+	baselineTotal := 0
+	for i, stop := range pending {
+		baselineAt[stop.ID] = baseline[i]
+		baselineTotal = baseline[i]
+	}
+
+	direct, err := timer.travel(ctx, request.PickupLocation, request.Destination)
+	if err != nil {
+		return nil, err
+	}
+
+	riders := make(map[string]*SharedRider, len(trip.Riders))
+	for _, rider := range trip.Riders {
+		riders[rider.TripID] = rider
+	}
+
+	pickup := &SharedTripStop{ID: request.TripID + "_pickup", TripID: request.TripID, RiderID: request.RiderID, Type: stopTypePickup, Location: request.PickupLocation}
+	dropoff := &SharedTripStop{ID: request.TripID + "_dropoff", TripID: request.TripID, RiderID: request.RiderID, Type: stopTypeDropoff, Location: request.Destination}
+	seats := func(tripID string) int {
+		if rider, exists := riders[tripID]; exists && rider.PassengerCount > 0 {
+			return rider.PassengerCount
+		}
+		if tripID == request.TripID {
+			return passengerCount(request)
+		}
+		return 1
+	}
+
+	var best *pooledInsertion
+	for p := 0; p <= len(pending); p++ {
+		for d := p + 1; d <= len(pending)+1; d++ {
+			route := insertSharedStop(insertSharedStop(pending, p, pickup), d, dropoff)
+			if !fitsSeatCapacity(route, trip.SeatsOccupied, trip.SeatCapacity, seats) {
+				continue
+			}
+
+			arrivals, err := timer.arrivals(ctx, trip.CurrentLocation, route)
+			if err != nil {
+				return nil, err
+			}
+
+			if arrivals[p] > maxPooledPickupSeconds {
+				continue
+			}
+
+			detour := arrivals[d] - arrivals[p] - direct
+			if detour > maxDetourSeconds(request.Preferences.MaxDetourTime) {
+				continue
+			}
+			if !withinRiderDetours(route, arrivals, baselineAt, riders) {
+				continue
+			}
+
+			added := arrivals[len(arrivals)-1] - baselineTotal
+			if best == nil || added < best.addedSeconds {
+				best = &pooledInsertion{
+					trip:          trip,
+					pickupIndex:   p,
+					dropoffIndex:  d,
+					route:         route,
+					arrivals:      arrivals,
+					detourSeconds: int(math.Max(0, float64(detour))),
+					addedSeconds:  added,
+				}
+			}
+		}
+	}
+
+	return best, nil
+}
+
+// splitSharedFare prices the rider's share of the shared route, falling back to
+// a discounted solo estimate when the split is unavailable
+func (s *AdvancedMatchingService) splitSharedFare(ctx context.Context, timer *routeTimer, insertion *pooledInsertion, request *MatchingRequest) (*FareEstimate, []*SharedFareShare) {
+	if s.fareSplitter != nil {
+		shares, err := s.requestFareSplit(ctx, timer, insertion, request)
+		if err == nil {
+			for _, share := range shares {
+				if share.TripID == request.TripID {
+					return &FareEstimate{TotalEstimate: share.SharedFare, Currency: share.Currency}, shares
+				}
+			}
+		} else if s.logger != nil {
+			s.logger.WithContext(ctx).WithError(err).Warn("Failed to split shared fare")
+		}
+	}
+
+	driver := &MatchedDriverInfo{VehicleInfo: &VehicleDetails{VehicleType: insertion.trip.VehicleType}}
+	estimate, err := s.calculateFareEstimate(ctx, request, driver)
+	if err != nil || estimate == nil {
+		return nil, nil
+	}
+	discount := 1 - sharedRideDiscount
+	estimate.BaseFare *= discount
+	estimate.DistanceFare *= discount
+	estimate.TimeFare *= discount
+	estimate.SurgeFare *= discount
+	estimate.TotalEstimate *= discount
+	return estimate, nil
+}
+
+// requestFareSplit asks pricing-service to split the fare for every rider still on the route
+func (s *AdvancedMatchingService) requestFareSplit(ctx context.Context, timer *routeTimer, insertion *pooledInsertion, request *MatchingRequest) ([]*SharedFareShare, error) {
+	onRoute := make(map[string]bool)
+	for _, stop := range insertion.route {
+		onRoute[stop.TripID] = true
+	}
+
+	riders := append([]*SharedRider{}, insertion.trip.Riders...)
+	riders = append(riders, sharedRiderFor(request))
+
+	split := &SharedFareRequest{
+		VehicleType:          insertion.trip.VehicleType,
+		RouteDurationSeconds: insertion.arrivals[len(insertion.arrivals)-1],
+		City:                 request.City,
+	}
+	for _, rider := range riders {
+		if !onRoute[rider.TripID] {
+			continue
+		}
+		distance, err := s.geoService.CalculateDistance(ctx, rider.PickupLocation, rider.Destination)
+		if err != nil {
+			return nil, err
+		}
+		duration, err := timer.travel(ctx, rider.PickupLocation, rider.Destination)
+		if err != nil {
+			return nil, err
+		}
+		split.Riders = append(split.Riders, &SharedFareRider{
+			RiderID:         rider.RiderID,
+			TripID:          rider.TripID,
+			DistanceKm:      distance.DistanceKm,
+			DurationSeconds: duration,
+		})
+	}
+
+	from := insertion.trip.CurrentLocation
+	for _, stop := range insertion.route {
+		leg, err := s.geoService.CalculateDistance(ctx, from, stop.Location)
+		if err != nil {
+			return nil, err
+		}
+		split.RouteDistanceKm += leg.DistanceKm
+		from = stop.Location
+	}
+
+	return s.fareSplitter.SplitSharedFare(ctx, split)
+}
+
+// openSharedTrip registers an accepted shareable trip with trip-service so
+// later riders can be pooled onto it
+func (s *AdvancedMatchingService) openSharedTrip(ctx context.Context, offer *DriverOffer) {
+	if s.sharedTrips == nil || offer.Driver == nil {
+		return
+	}
+
+	vehicleType := ""
+	if offer.Driver.VehicleInfo != nil {
+		vehicleType = offer.Driver.VehicleInfo.VehicleType
+	}
+	rider := &SharedRider{
+		TripID:           offer.TripID,
+		RiderID:          offer.RiderID,
+		PickupLocation:   offer.PickupLocation,
+		Destination:      offer.Destination,
+		PassengerCount:   offer.PassengerCount,
+		MaxDetourMinutes: offer.MaxDetourTime,
+	}
+	if offer.EstimatedFare != nil {
+		rider.Fare = offer.EstimatedFare.TotalEstimate
+	}
+
+	capacity := models.GetVehicleTypeCapacity(models.VehicleType(vehicleType))
+	trip, err := s.sharedTrips.OpenSharedTrip(ctx, offer.DriverID(), vehicleType, capacity, rider, offer.Driver.CurrentLocation)
+	if s.logger == nil {
+		return
+	}
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("trip_id", offer.TripID).Warn("Failed to open shared trip")
+		return
+	}
+	s.logger.WithContext(ctx).WithFields(logger.Fields{
+		"trip_id":        offer.TripID,
+		"shared_trip_id": trip.ID,
+		"driver_id":      trip.DriverID,
+	}).Info("Opened shared trip for pooling")
+}
+
+// sharedRiderFor describes the request as a shared trip rider
+func sharedRiderFor(request *MatchingRequest) *SharedRider {
+	rider := &SharedRider{
+		TripID:         request.TripID,
+		RiderID:        request.RiderID,
+		PickupLocation: request.PickupLocation,
+		Destination:    request.Destination,
+		PassengerCount: passengerCount(request),
+	}
+	if request.Preferences != nil {
+		rider.MaxDetourMinutes = request.Preferences.MaxDetourTime
+	}
+	return rider
+}
+
+// insertSharedStop returns a copy of stops with stop inserted at index
+func insertSharedStop(stops []*SharedTripStop, index int, stop *SharedTripStop) []*SharedTripStop {
+	route := make([]*SharedTripStop, 0, len(stops)+1)
+	route = append(route, stops[:index]...)
+	route = append(route, stop)
+	return append(route, stops[index:]...)
+}
+
+// fitsSeatCapacity reports whether the route never needs more seats than the vehicle has
+func fitsSeatCapacity(route []*SharedTripStop, occupied, capacity int, seats func(tripID string) int) bool {
+	for _, stop := range route {
+		if stop.Type == stopTypePickup {
+			occupied += seats(stop.TripID)
+		} else {
+			occupied -= seats(stop.TripID)
+		}
+		if occupied > capacity {
+			return false
+		}
+	}
+	return true
+}
+
+// withinRiderDetours reports whether every existing rider's dropoff is delayed
+// by no more than that rider's detour limit
+func withinRiderDetours(route []*SharedTripStop, arrivals []int, baselineAt map[string]int, riders map[string]*SharedRider) bool {
+	for i, stop := range route {
+		if stop.Type != stopTypeDropoff {
+			continue
+		}
+		baseline, exists := baselineAt[stop.ID]
+		if !exists {
+			continue
+		}
+		limit := maxDetourSeconds(0)
+		if rider, exists := riders[stop.TripID]; exists {
+			limit = maxDetourSeconds(rider.MaxDetourMinutes)
+		}
+		if arrivals[i]-baseline > limit {
+			return false
+		}
+	}
+	return true
+}
+
+// routeTimer memoises geo-service travel times during a pooling pass
+type routeTimer struct {
+	geo         GeoServiceClient
+	vehicleType string
+	cache       map[string]int
+}
+
+func newRouteTimer(geo GeoServiceClient, vehicleType string) *routeTimer {
+	return &routeTimer{
+		geo:         geo,
+		vehicleType: vehicleType,
+		cache:       make(map[string]int),
+	}
+}
+
+// travel returns the driving time in seconds between two locations
+func (t *routeTimer) travel(ctx context.Context, from, to *models.Location) (int, error) {
+	key := fmt.Sprintf("%f,%f>%f,%f", from.Latitude, from.Longitude, to.Latitude, to.Longitude)
+	if seconds, exists := t.cache[key]; exists {
+		return seconds, nil
+	}
+
+	eta, err := t.geo.CalculateETA(ctx, from, to, t.vehicleType)
+	if err != nil {
+		return 0, err
+	}
+	t.cache[key] = eta.DurationSeconds
+	return eta.DurationSeconds, nil
+}
+
+// arrivals returns the cumulative driving time from start to each stop
+func (t *routeTimer) arrivals(ctx context.Context, start *models.Location, stops []*SharedTripStop) ([]int, error) {
+	arrivals := make([]int, len(stops))
+	elapsed := 0
+	from := start
+	for i, stop := range stops {
+		seconds, err := t.travel(ctx, from, stop.Location)
+		if err != nil {
+			return nil, err
+		}
+		elapsed += seconds
+		arrivals[i] = elapsed
+		from = stop.Location
+	}
+	return arrivals, nil
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/rideshare-platform/services/matching-service/internal/config"
+	"github.com/rideshare-platform/shared/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// gridGeoService derives travel times from coordinate differences: 0.01 degrees takes 100 seconds
+type gridGeoService struct {
+	fakeGeoService
+}
+
+func gridDelta(origin, destination *models.Location) float64 {
+	return math.Abs(origin.Latitude-destination.Latitude) + math.Abs(origin.Longitude-destination.Longitude)
+}
+
+func (g *gridGeoService) CalculateDistance(ctx context.Context, origin, destination *models.Location) (*DistanceResult, error) {
+	km := gridDelta(origin, destination) * 100
+	return &DistanceResult{DistanceKm: km, DistanceMeters: km * 1000}, nil
+}
+
+func (g *gridGeoService) CalculateETA(ctx context.Context, origin, destination *models.Location, vehicleType string) (*ETAResult, error) {
+	return &ETAResult{DurationSeconds: int(math.Round(gridDelta(origin, destination) * 10000))}, nil
+}
+
+// fakeSharedTripClient keeps shared trips in memory and records inserted riders
+type fakeSharedTripClient struct {
+	trips  []*SharedTrip
+	added  []*SharedRider
+	opened []*SharedRider
+}
+
+func (f *fakeSharedTripClient) ListOpenSharedTrips(ctx context.Context, vehicleType string, seatsNeeded int) ([]*SharedTrip, error) {
+	return f.trips, nil
+}
+
+func (f *fakeSharedTripClient) OpenSharedTrip(ctx context.Context, driverID, vehicleType string, seatCapacity int, rider *SharedRider, location *models.Location) (*SharedTrip, error) {
+	f.opened = append(f.opened, rider)
+	return &SharedTrip{ID: "shared-new", DriverID: driverID, VehicleType: vehicleType, SeatCapacity: seatCapacity}, nil
+}
+
+func (f *fakeSharedTripClient) AddSharedRider(ctx context.Context, sharedTripID string, expectedVersion int, rider *SharedRider, pickupIndex, dropoffIndex int) (*SharedTrip, error) {
+	f.added = append(f.added, rider)
+	for _, trip := range f.trips {
+		if trip.ID == sharedTripID {
+			updated := *trip
+			updated.Version++
+			updated.Riders = append(append([]*SharedRider{}, trip.Riders...), rider)
+			return &updated, nil
+		}
+	}
+	return nil, ErrQueuedMatchNotFound
+}
+
+// fakeFareSplitter gives every rider a fixed shared fare
+type fakeFareSplitter struct {
+	requests []*SharedFareRequest
+}
+
+func (f *fakeFareSplitter) SplitSharedFare(ctx context.Context, request *SharedFareRequest) ([]*SharedFareShare, error) {
+	f.requests = append(f.requests, request)
+	var shares []*SharedFareShare
+	for _, rider := range request.Riders {
+		shares = append(shares, &SharedFareShare{RiderID: rider.RiderID, TripID: rider.TripID, SoloFare: 20, SharedFare: 12, Savings: 8, Currency: "USD"})
+	}
+	return shares, nil
+}
+
+// newSharedTestTrip has rider A on board, heading north to a dropoff at dropoffLat
+func newSharedTestTrip(dropoffLat float64, seatsOccupied int) *SharedTrip {
+	return &SharedTrip{
+		ID:              "shared-1",
+		DriverID:        "pool-driver",
+		VehicleType:     "sedan",
+		SeatCapacity:    4,
+		SeatsOccupied:   seatsOccupied,
+		Version:         3,
+		CurrentLocation: &models.Location{Latitude: 0, Longitude: 0},
+		Stops: []*SharedTripStop{
+			{ID: "trip-a_pickup", TripID: "trip-a", Type: stopTypePickup, Location: &models.Location{Latitude: 0, Longitude: 0}, Completed: true},
+			{ID: "trip-a_dropoff", TripID: "trip-a", Type: stopTypeDropoff, Location: &models.Location{Latitude: dropoffLat, Longitude: 0}},
+		},
+		Riders: []*SharedRider{
+			{TripID: "trip-a", RiderID: "rider-a", PassengerCount: seatsOccupied, MaxDetourMinutes: 10,
+				PickupLocation: &models.Location{Latitude: 0, Longitude: 0}, Destination: &models.Location{Latitude: dropoffLat, Longitude: 0}},
+		},
+	}
+}
+
+func newSharedTestRequest(tripID string, pickup, destination *models.Location) *MatchingRequest {
+	request := newQueueTestRequest(tripID, 0)
+	request.PickupLocation = pickup
+	request.Destination = destination
+	request.Preferences = &RiderPreferences{AllowSharedRides: true, MaxDetourTime: 5}
+	return request
+}
+
+func TestSharedRides_MatchesOntoTripAlongTheRoute(t *testing.T) {
+	service := newQueueTestService(&gridGeoService{})
+	trips := &fakeSharedTripClient{trips: []*SharedTrip{newSharedTestTrip(0.10, 1)}}
+	splitter := &fakeFareSplitter{}
+	service.SetSharedTripClient(trips)
+	service.SetFareSplitter(splitter)
+
+	request := newSharedTestRequest("trip-b", &models.Location{Latitude: 0.03}, &models.Location{Latitude: 0.07})
+	result, err := service.FindMatch(context.Background(), request)
+	assert.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, "pool-driver", result.MatchedDriver.DriverID)
+	assert.Equal(t, 300, result.EstimatedETA)
+
+	assert.NotNil(t, result.SharedRide)
+	assert.Equal(t, "shared-1", result.SharedRide.SharedTripID)
+	assert.Equal(t, 0, result.SharedRide.PickupIndex)
+	assert.Equal(t, 1, result.SharedRide.DropoffIndex)
+	assert.Equal(t, 0, result.SharedRide.DetourSeconds)
+	assert.Equal(t, 0, result.SharedRide.AddedRouteSeconds)
+
+	// Both riders still on the route share the fare
+	assert.Len(t, splitter.requests, 1)
+	assert.Len(t, splitter.requests[0].Riders, 2)
+	assert.InDelta(t, 10.0, splitter.requests[0].RouteDistanceKm, 0.001)
+	assert.Equal(t, 12.0, result.EstimatedFare.TotalEstimate)
+
+	assert.Len(t, trips.added, 1)
+	assert.Equal(t, 12.0, trips.added[0].Fare)
+	assert.Equal(t, 5, trips.added[0].MaxDetourMinutes)
+}
+
+func TestSharedRides_RejectsDetourBeyondLimit(t *testing.T) {
+	service := newQueueTestService(&gridGeoService{})
+	trips := &fakeSharedTripClient{trips: []*SharedTrip{newSharedTestTrip(0.10, 1)}}
+	service.SetSharedTripClient(trips)
+
+	// Serving this rider first would delay rider A by 1000 seconds, and picking
+	// them up after rider A's dropoff would keep them waiting too long
+	request := newSharedTestRequest("trip-c", &models.Location{Latitude: 0.03, Longitude: 0.05}, &models.Location{Latitude: 0.07, Longitude: 0.05})
+	result, err := service.FindMatch(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Nil(t, result.SharedRide)
+	assert.True(t, result.Queued)
+	assert.Empty(t, trips.added)
+}
+
+func TestSharedRides_WaitsForSeatsToFreeUp(t *testing.T) {
+	service := newQueueTestService(&gridGeoService{})
+	trips := &fakeSharedTripClient{trips: []*SharedTrip{newSharedTestTrip(0.05, 3)}}
+	service.SetSharedTripClient(trips)
+	service.SetFareEstimator(&fakeFareEstimator{})
+
+	request := newSharedTestRequest("trip-d", &models.Location{Latitude: 0.06}, &models.Location{Latitude: 0.09})
+	request.PassengerCount = 2
+
+	result, err := service.FindMatch(context.Background(), request)
+	assert.NoError(t, err)
+	assert.NotNil(t, result.SharedRide)
+	assert.Equal(t, 1, result.SharedRide.PickupIndex)
+	assert.Equal(t, 2, result.SharedRide.DropoffIndex)
+	assert.Equal(t, 600, result.EstimatedETA)
+
+	// Without a fare split the rider gets a discounted solo quote
+	assert.NotNil(t, result.EstimatedFare)
+	assert.InDelta(t, (3.0+4.5+0.25*5)*(1-sharedRideDiscount), result.EstimatedFare.TotalEstimate, 0.001)
+}
+
+func TestSharedRides_IgnoredWhenRiderDoesNotShare(t *testing.T) {
+	service := newQueueTestService(&gridGeoService{})
+	trips := &fakeSharedTripClient{trips: []*SharedTrip{newSharedTestTrip(0.10, 1)}}
+	service.SetSharedTripClient(trips)
+
+	request := newSharedTestRequest("trip-e", &models.Location{Latitude: 0.03}, &models.Location{Latitude: 0.07})
+	request.Preferences.AllowSharedRides = false
+
+	result, err := service.FindMatch(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Nil(t, result.SharedRide)
+	assert.Empty(t, trips.added)
+}
+
+func TestSharedRides_AcceptedOfferOpensSharedTrip(t *testing.T) {
+	service := NewSimpleMatchingService(&config.Config{})
+	trips := &fakeSharedTripClient{}
+	service.SetSharedTripClient(trips)
+	ctx := context.Background()
+
+	request := newOfferTestRequest("trip-open")
+	request.PassengerCount = 2
+	request.Preferences = &RiderPreferences{AllowSharedRides: true, MaxDetourTime: 8}
+	driver := newOfferTestDrivers("driver-a")[0]
+	driver.VehicleInfo = &VehicleDetails{VehicleType: "sedan"}
+
+	_, err := service.createOffer(ctx, request, driver, nil, &FareEstimate{TotalEstimate: 15})
+	assert.NoError(t, err)
+
+	_, err = service.AcceptOffer(ctx, "trip-open", "driver-a")
+	assert.NoError(t, err)
+
+	assert.Len(t, trips.opened, 1)
+	assert.Equal(t, "trip-open", trips.opened[0].TripID)
+	assert.Equal(t, 2, trips.opened[0].PassengerCount)
+	assert.Equal(t, 8, trips.opened[0].MaxDetourMinutes)
+	assert.Equal(t, 15.0, trips.opened[0].Fare)
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+
+	"github.com/rideshare-platform/shared/models"
+)
+
+// requiredVehicleFeatures returns the rider's accessibility needs a vehicle
+// has to offer, wheelchair access for WAV rides. Needs that are not vehicle
+// features, like a service animal, are left to the driver and do not narrow
+// the search.
+func requiredVehicleFeatures(request *MatchingRequest) []string {
+	var required []string
+	if request.VehicleType == models.RideTypeWAV {
+		required = append(required, string(models.VehicleFeatureWheelchairAccessible))
+	}
+	if request.Preferences == nil {
+		return required
+	}
+	for _, need := range request.Preferences.AccessibilityNeeds {
+		if models.IsValidVehicleFeature(need) && !containsFeature(required, need) {
+			required = append(required, need)
+		}
+	}
+	return required
+}
+
+// containsFeature reports whether features include feature
+func containsFeature(features []string, feature string) bool {
+	for _, f := range features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// driverVehicleFeatures returns the features of the vehicle each driver is
+// on, keyed by driver ID. Features geo-service reported with the location
+// are used as they are; the rest come from vehicle-service. Nothing is
+// looked up when no features are required.
+func (s *AdvancedMatchingService) driverVehicleFeatures(ctx context.Context, drivers []*DriverLocation, required []string) map[string][]string {
+	if len(required) == 0 {
+		return nil
+	}
+
+	features := make(map[string][]string, len(drivers))
+	var missing []string
+	for _, driver := range drivers {
+		if len(driver.VehicleFeatures) > 0 {
+			features[driver.DriverID] = driver.VehicleFeatures
+		} else {
+			missing = append(missing, driver.DriverID)
+		}
+	}
+	if len(missing) == 0 {
+		return features
+	}
+
+	vehicles := s.lookupDriverVehicles(ctx, missing)
+	for _, driver := range drivers {
+		if _, ok := features[driver.DriverID]; ok {
+			continue
+		}
+		if vehicle := selectDriverVehicle(vehicles[driver.DriverID], driver.VehicleID); vehicle != nil {
+			features[driver.DriverID] = vehicle.Details.Features
+		}
+	}
+	return features
+}
+
+// hasVehicleFeatures reports whether a vehicle offers every required feature.
+// A vehicle whose features are unknown only passes when none are required.
+func hasVehicleFeatures(features, required []string) bool {
+	for _, feature := range required {
+		if !containsFeature(features, feature) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rideshare-platform/shared/models"
+)
+
+func TestFilterEligibleDrivers_AccessibilityNeeds(t *testing.T) {
+	drivers := newProfileTestDrivers()
+	drivers = append(drivers, &DriverLocation{
+		DriverID:           "driver-3",
+		VehicleID:          "vehicle-3",
+		DistanceFromCenter: 1,
+		Status:             "available",
+		VehicleType:        "sedan",
+		Rating:             4.7,
+		VehicleFeatures:    []string{"wheelchair_accessible", "wifi"},
+	})
+	service := newQueueTestService(&fakeGeoService{})
+	provider := &fakeProfileProvider{
+		vehicles: map[string][]*DriverVehicle{
+			"driver-1": {
+				{VehicleID: "vehicle-1a", Details: VehicleDetails{Features: []string{"wheelchair_accessible"}}},
+				{VehicleID: "vehicle-1b", Details: VehicleDetails{Features: []string{"child_seat"}}},
+			},
+			"driver-2": {
+				{VehicleID: "vehicle-2", Details: VehicleDetails{Features: []string{"wheelchair_accessible", "child_seat"}}},
+			},
+		},
+	}
+	service.SetDriverVehicleProvider(provider)
+
+	request := newQueueTestRequest("trip-1", time.Minute)
+	eligible := service.filterEligibleDrivers(context.Background(), drivers, request, service.searchParams(request))
+	assert.Len(t, eligible, 3)
+	assert.Empty(t, provider.vehicleCalls, "vehicles are only looked up for riders with accessibility needs")
+
+	request.Preferences = &RiderPreferences{AccessibilityNeeds: []string{"wheelchair_accessible", "service_animal_friendly"}}
+	eligible = service.filterEligibleDrivers(context.Background(), drivers, request, service.searchParams(request))
+	var driverIDs []string
+	for _, driver := range eligible {
+		driverIDs = append(driverIDs, driver.DriverID)
+	}
+	// driver-1 is on the vehicle without a ramp, driver-3's features came from geo-service
+	assert.Equal(t, []string{"driver-2", "driver-3"}, driverIDs)
+	assert.Equal(t, [][]string{{"driver-1", "driver-2"}}, provider.vehicleCalls)
+}
+
+func TestFilterEligibleDrivers_WAVRides(t *testing.T) {
+	drivers := newProfileTestDrivers()
+	drivers = append(drivers, &DriverLocation{
+		DriverID:           "driver-3",
+		VehicleID:          "vehicle-3",
+		DistanceFromCenter: 1,
+		Status:             "available",
+		VehicleType:        "van",
+		Rating:             4.7,
+		VehicleFeatures:    []string{"wheelchair_accessible"},
+	})
+	service := newQueueTestService(&fakeGeoService{})
+	service.SetDriverVehicleProvider(&fakeProfileProvider{
+		vehicles: map[string][]*DriverVehicle{
+			"driver-2": {
+				{VehicleID: "vehicle-2", Details: VehicleDetails{Features: []string{"wheelchair_accessible"}}},
+			},
+		},
+	})
+
+	request := newQueueTestRequest("trip-1", time.Minute)
+	request.VehicleType = models.RideTypeWAV
+	eligible := service.filterEligibleDrivers(context.Background(), drivers, request, service.searchParams(request))
+	var driverIDs []string
+	for _, driver := range eligible {
+		driverIDs = append(driverIDs, driver.DriverID)
+	}
+	// WAV rides need the ramp whatever the vehicle type
+	assert.Equal(t, []string{"driver-2", "driver-3"}, driverIDs)
+}
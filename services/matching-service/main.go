@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	"log"
 	"net/http"
 	"os"
@@ -12,9 +13,25 @@ import (
 	"net"
 
 	"github.com/gin-gonic/gin"
+	_ "github.com/lib/pq"
+	"github.com/redis/go-redis/v9"
+	"github.com/rideshare-platform/services/matching-service/internal/admission"
+	"github.com/rideshare-platform/services/matching-service/internal/client"
 	"github.com/rideshare-platform/services/matching-service/internal/config"
 	"github.com/rideshare-platform/services/matching-service/internal/handler"
 	"github.com/rideshare-platform/services/matching-service/internal/service"
+	"github.com/rideshare-platform/services/matching-service/migrations"
+	"github.com/rideshare-platform/shared/analytics"
+	"github.com/rideshare-platform/shared/database"
+	"github.com/rideshare-platform/shared/experiments"
+	"github.com/rideshare-platform/shared/flags"
+	sharedgrpc "github.com/rideshare-platform/shared/grpc"
+	"github.com/rideshare-platform/shared/grpc/interceptor"
+	sharedhealth "github.com/rideshare-platform/shared/health"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/monitoring"
+	matchingpb "github.com/rideshare-platform/shared/proto/matching"
+	sharedtls "github.com/rideshare-platform/shared/tls"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
@@ -26,23 +43,227 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
 
 	log.Printf("Starting Matching Service on port %s", cfg.HTTPPort)
 
+	appLogger := logger.NewLogger(cfg.LogLevel, cfg.Environment)
+
+	tlsReloader, err := sharedtls.Load(cfg.TLS, appLogger)
+	if err != nil {
+		log.Fatalf("Failed to load TLS certificates: %v", err)
+	}
+	go tlsReloader.Watch(context.Background())
+
 	// Initialize services
 	matchingService := service.NewSimpleMatchingService(cfg)
+	if cfg.StateStore == "redis" {
+		redisDB, err := database.NewRedisDB(cfg.Redis, appLogger)
+		if err != nil {
+			log.Fatalf("Failed to connect to Redis: %v", err)
+		}
+		defer redisDB.Close()
+		matchingService.SetRedis(redisDB.Client)
+	}
+
+	// Push driver offers to gRPC stream subscribers (the API gateway WebSocket)
+	offerBroadcaster := service.NewOfferBroadcaster()
+	matchingService.SetOfferNotifier(offerBroadcaster)
+
+	// Push matching queue progress to riders
+	progressBroadcaster := service.NewProgressBroadcaster()
+	matchingService.SetProgressNotifier(progressBroadcaster)
+
+	// Under overload matches wait for a worker by priority, then are shed
+	matchingService.SetAdmission(admission.NewController(admission.Config{
+		Workers:   cfg.MaxConcurrentMatches,
+		QueueSize: cfg.MatchQueueSize,
+		MaxWait:   time.Duration(cfg.MatchQueueTimeoutMs) * time.Millisecond,
+	}))
+
+	// Requests with nearby pickups are matched together so they do not
+	// compete for the same driver
+	matchingService.SetBatchWindow(time.Duration(cfg.MatchBatchWindowMs) * time.Millisecond)
+
+	// A/B experiments branch the matching algorithm per rider or trip
+	if cfg.ExperimentsFile != "" {
+		registry, err := experiments.Load(cfg.ExperimentsFile)
+		if err != nil {
+			log.Fatalf("Failed to load experiments: %v", err)
+		}
+		matchingService.SetExperiments(registry)
+	}
+
+	// Shared feature flags roll features such as batching out gradually
+	var featureFlags *flags.Client
+	if cfg.FlagsRedisAddr != "" {
+		flagsRedis := redis.NewClient(&redis.Options{Addr: cfg.FlagsRedisAddr})
+		defer flagsRedis.Close()
+		featureFlags = flags.NewClient(flags.NewRedisStore(flagsRedis), appLogger)
+		matchingService.SetFeatureFlags(featureFlags)
+	}
+
+	// Outgoing calls carry the request's correlation ID to downstream services
+	dialOptions := append(sharedgrpc.ClientOptions(), tlsReloader.DialOption())
+
+	// Downstream services are optional, the health report is degraded while one is down
+	healthChecker := sharedhealth.NewChecker("matching-service", "1.0.0")
+	healthChecker.SetConfig(cfg)
+
+	// Pool riders who allow shared rides onto trips managed by trip-service,
+	// splitting fares through pricing-service. Driver ratings and reliability
+	// also come from trip-service.
+	if conn, err := grpc.NewClient(cfg.TripServiceAddr, dialOptions...); err != nil {
+		log.Printf("Shared rides, driver ratings and reliability disabled, failed to create trip-service client: %v", err)
+	} else {
+		defer conn.Close()
+		matchingService.SetSharedTripClient(client.NewGRPCSharedTripClient(conn))
+		ratingClient := client.NewGRPCRatingClient(conn)
+		matchingService.SetRatingProvider(ratingClient)
+		matchingService.SetReliabilityProvider(ratingClient)
+		healthChecker.AddOptionalCheck("trip-service", sharedhealth.GRPCProbe(conn))
+	}
+
+	// Matched trips are quoted and shared fares split by pricing-service
+	if conn, err := grpc.NewClient(cfg.PricingServiceAddr, dialOptions...); err != nil {
+		log.Printf("Failed to create pricing-service client, matches will carry no fare estimate: %v", err)
+	} else {
+		defer conn.Close()
+		pricingClient := client.NewGRPCPricingClient(conn)
+		matchingService.SetFareEstimator(pricingClient)
+		matchingService.SetFareSplitter(pricingClient)
+		healthChecker.AddOptionalCheck("pricing-service", sharedhealth.GRPCProbe(conn))
+	}
+
+	// Drivers are found and ranked through geo-service. Mock mode matches
+	// against generated drivers so the service can run without it locally.
+	if conn, err := grpc.NewClient(cfg.GeoServiceAddr, dialOptions...); err != nil {
+		log.Printf("Failed to create geo-service client, matching runs in mock mode and pickup zones will not be checked: %v", err)
+	} else {
+		defer conn.Close()
+		if cfg.GeoMockMode {
+			log.Printf("Geo mock mode enabled, matching against generated drivers")
+		} else {
+			matchingService.SetGeoService(client.NewGRPCGeoClient(conn, time.Duration(cfg.GeoServiceTimeout)*time.Millisecond))
+		}
+		zoneClient := client.NewGRPCZoneClient(conn)
+		matchingService.SetZoneChecker(zoneClient)
+		matchingService.SetDispatchQueues(zoneClient)
+		healthChecker.AddOptionalCheck("geo-service", sharedhealth.GRPCProbe(conn))
+	}
+
+	// Matched drivers are shown with their profile and vehicle
+	if conn, err := grpc.NewClient(cfg.UserServiceAddr, dialOptions...); err != nil {
+		log.Printf("Failed to create user-service client, matched drivers will have no profile: %v", err)
+	} else {
+		defer conn.Close()
+		matchingService.SetDriverProfileProvider(client.NewGRPCUserClient(conn))
+		healthChecker.AddOptionalCheck("user-service", sharedhealth.GRPCProbe(conn))
+	}
+	if conn, err := grpc.NewClient(cfg.VehicleServiceAddr, dialOptions...); err != nil {
+		log.Printf("Failed to create vehicle-service client, matched drivers will have no vehicle details: %v", err)
+	} else {
+		defer conn.Close()
+		matchingService.SetDriverVehicleProvider(client.NewGRPCVehicleClient(conn))
+		healthChecker.AddOptionalCheck("vehicle-service", sharedhealth.GRPCProbe(conn))
+	}
+
+	// Matching outcomes are rolled up hourly for the metrics endpoint
+	analyticsStore := analytics.Store(analytics.NewMemoryStore())
+	if cfg.AnalyticsDatabaseURL != "" {
+		db, err := sql.Open("postgres", cfg.AnalyticsDatabaseURL)
+		if err != nil {
+			log.Fatalf("Failed to connect to analytics database: %v", err)
+		}
+		defer db.Close()
+		if err := db.Ping(); err != nil {
+			log.Fatalf("Failed to ping analytics database: %v", err)
+		}
+		if cfg.MigrateOnStartup {
+			if err := analytics.Migrate(context.Background(), db, appLogger); err != nil {
+				log.Fatalf("Failed to migrate analytics database: %v", err)
+			}
+		}
+		analyticsStore = analytics.NewPostgresStore(db)
+		healthChecker.AddCheck("postgres", db.PingContext)
+	} else {
+		log.Printf("ANALYTICS_DATABASE_URL not set, matching metrics are kept in memory")
+	}
+	analyticsRecorder := analytics.NewRecorder(analyticsStore, service.AnalyticsSource, appLogger)
+	matchingService.SetAnalytics(analyticsRecorder)
+
+	// Every step taken to match a trip is logged for support review, next to
+	// the per-city matching configs and drivers' offer acceptance
+	behaviorHalfLife := time.Duration(cfg.DriverBehaviorHalfLifeHours) * time.Hour
+	if cfg.DatabaseURL != "" {
+		db, err := sql.Open("postgres", cfg.DatabaseURL)
+		if err != nil {
+			log.Fatalf("Failed to connect to database: %v", err)
+		}
+		defer db.Close()
+		if err := db.Ping(); err != nil {
+			log.Fatalf("Failed to ping database: %v", err)
+		}
+		if cfg.MigrateOnStartup {
+			schema, err := migrations.Load()
+			if err != nil {
+				log.Fatalf("Failed to load migrations: %v", err)
+			}
+			if _, err := database.NewMigrator(db, "matching-service", schema, appLogger).Up(context.Background()); err != nil {
+				log.Fatalf("Failed to migrate database: %v", err)
+			}
+		}
+		matchingService.SetAttemptLogStore(service.NewPostgresAttemptLogStore(db))
+		matchingService.SetMatchingConfigStore(service.NewPostgresMatchingConfigStore(db))
+		matchingService.SetDriverAcceptanceStore(service.NewPostgresDriverAcceptanceStore(db, behaviorHalfLife))
+		healthChecker.AddCheck("postgres-attempt-logs", db.PingContext)
+	} else {
+		log.Printf("DATABASE_URL not set, matching attempt logs, configs and driver acceptance are kept in memory")
+		matchingService.SetAttemptLogStore(service.NewMemoryAttemptLogStore())
+		matchingService.SetMatchingConfigStore(service.NewMemoryMatchingConfigStore())
+		matchingService.SetDriverAcceptanceStore(service.NewMemoryDriverAcceptanceStore(behaviorHalfLife))
+	}
+	if err := matchingService.RefreshMatchingConfigs(context.Background()); err != nil {
+		log.Fatalf("Failed to load matching configs: %v", err)
+	}
+
+	// Expire unanswered offers and fall back to the next driver
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	go analyticsRecorder.Start(workerCtx, time.Duration(cfg.AnalyticsFlushSeconds)*time.Second)
+	go matchingService.StartOfferExpiryWorker(workerCtx, time.Duration(cfg.OfferSweepInterval)*time.Second)
+
+	// Release drivers whose reservation ran out without a response
+	go matchingService.StartReservationExpiryWorker(workerCtx, time.Duration(cfg.OfferSweepInterval)*time.Second)
+
+	// Retry trips that could not be matched immediately
+	go matchingService.StartMatchingQueueWorker(workerCtx, time.Duration(cfg.QueuePollInterval)*time.Second)
+
+	// Pick up matching configs saved through other instances
+	go matchingService.StartMatchingConfigRefresh(workerCtx, time.Duration(cfg.MatchingConfigRefreshInterval)*time.Second)
+
+	if featureFlags != nil {
+		go featureFlags.Start(workerCtx, time.Duration(cfg.FlagsRefreshInterval)*time.Second)
+	}
 
 	// Initialize HTTP handler
 	matchingHandler := handler.NewMatchingHandler(matchingService)
+	matchingHandler.SetHealthChecker(healthChecker)
+
+	// Request counts and latencies for HTTP and gRPC, scraped from /metrics
+	metricsCollector := monitoring.NewMetricsCollector(nil, appLogger)
 
 	// Setup HTTP server
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.New()
 	router.Use(gin.Recovery())
+	router.Use(metricsCollector.GinMiddleware("matching-service"))
+	router.GET("/metrics", gin.WrapH(monitoring.Handler()))
 	// Add health endpoint
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{"status": "healthy", "service": "matching-service"})
-	})
+	router.GET("/health", gin.WrapH(healthChecker.Handler()))
+	router.GET("/info", gin.WrapH(healthChecker.InfoHandler()))
 
 	// Register routes
 	matchingHandler.RegisterRoutes(router)
@@ -60,8 +281,16 @@ func main() {
 		}
 	}()
 
-	// Start gRPC health server
-	grpcServer := grpc.NewServer()
+	// Start gRPC server
+	serverOptions := interceptor.ServerOptions(interceptor.Config{
+		Service:  "matching-service",
+		Logger:   appLogger,
+		Metrics:  metricsCollector,
+		Auth:     interceptor.JWTAuth(cfg.JWTSecret, cfg.GRPCAuthRequired),
+		Deadline: interceptor.DefaultDeadlineConfig(),
+	})
+	grpcServer := grpc.NewServer(append(serverOptions, tlsReloader.ServerOptions()...)...)
+	matchingpb.RegisterMatchingServiceServer(grpcServer, handler.NewGRPCMatchingHandler(matchingService, offerBroadcaster, progressBroadcaster))
 	healthServer := health.NewServer()
 	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
 	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
@@ -82,6 +311,7 @@ func main() {
 	<-sigChan
 
 	log.Println("Received interrupt signal, starting graceful shutdown...")
+	stopWorkers()
 
 	// Graceful shutdown
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -0,0 +1,14 @@
+-- Every step taken to match each trip, kept for support review. trip_id and
+-- rider_id refer to records owned by trip-service and user-service, so there
+-- are no foreign keys.
+CREATE TABLE IF NOT EXISTS matching_attempt_logs (
+    trip_id VARCHAR(64) PRIMARY KEY,
+    rider_id VARCHAR(64) NOT NULL DEFAULT '',
+    outcome VARCHAR(32) NOT NULL DEFAULT '',
+    events JSONB NOT NULL DEFAULT '[]',
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    finished_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_matching_attempt_logs_rider_id ON matching_attempt_logs(rider_id, started_at DESC);
+CREATE INDEX IF NOT EXISTS idx_matching_attempt_logs_outcome ON matching_attempt_logs(outcome, started_at DESC);
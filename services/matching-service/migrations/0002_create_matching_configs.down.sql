@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS matching_configs;
+DROP TABLE IF EXISTS matching_config_versions;
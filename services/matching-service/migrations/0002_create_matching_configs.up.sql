@@ -0,0 +1,19 @@
+-- Per-city matching weights and search radii. matching_configs holds the
+-- config each city is matched with; matching_config_versions keeps every
+-- version saved, so the ranking logged with a match can be reproduced.
+CREATE TABLE IF NOT EXISTS matching_config_versions (
+    city VARCHAR(64) NOT NULL,
+    version BIGINT NOT NULL,
+    settings JSONB NOT NULL,
+    updated_by VARCHAR(64) NOT NULL DEFAULT '',
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (city, version)
+);
+
+CREATE TABLE IF NOT EXISTS matching_configs (
+    city VARCHAR(64) PRIMARY KEY,
+    version BIGINT NOT NULL,
+    settings JSONB NOT NULL,
+    updated_by VARCHAR(64) NOT NULL DEFAULT '',
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
@@ -0,0 +1,8 @@
+-- Decayed counts of the offers each driver answered and accepted, halving
+-- with age so drivers' recent answers weigh most in their acceptance rate
+CREATE TABLE IF NOT EXISTS driver_acceptance (
+    driver_id VARCHAR(64) PRIMARY KEY,
+    offers DOUBLE PRECISION NOT NULL DEFAULT 0,
+    accepted DOUBLE PRECISION NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
@@ -0,0 +1,16 @@
+// Package migrations holds the versioned PostgreSQL schema owned by matching-service
+package migrations
+
+import (
+	"embed"
+
+	"github.com/rideshare-platform/shared/database"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+// Load returns the service's migrations in version order
+func Load() ([]database.Migration, error) {
+	return database.LoadMigrations(files)
+}
@@ -0,0 +1,55 @@
+// Command migrate applies and rolls back the payment-service database schema.
+//
+//	migrate up | down [N] | version | status
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+
+	_ "github.com/lib/pq"
+	"github.com/rideshare-platform/services/payment-service/migrations"
+	sharedconfig "github.com/rideshare-platform/shared/config"
+	"github.com/rideshare-platform/shared/database"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// dbConfig locates the payments database
+type dbConfig struct {
+	Host     string `yaml:"db_host" env:"DB_HOST" default:"localhost"`
+	Port     int    `yaml:"db_port" env:"DB_PORT" default:"5432"`
+	Name     string `yaml:"db_name" env:"DB_NAME" default:"rideshare"`
+	User     string `yaml:"db_user" env:"DB_USER" default:"rideshare_user"`
+	Password string `yaml:"db_password" env:"DB_PASSWORD" default:"rideshare_password"`
+	SSLMode  string `yaml:"db_ssl_mode" env:"DB_SSL_MODE" default:"disable"`
+}
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	cfg := &dbConfig{}
+	if err := sharedconfig.NewLoader().Load(cfg); err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	db, err := sql.Open("postgres", fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.Name, cfg.SSLMode))
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	schema, err := migrations.Load()
+	if err != nil {
+		return err
+	}
+	migrator := database.NewMigrator(db, "payment-service", schema, logger.NewLogger("info", "development"))
+	return database.RunMigrateCommand(context.Background(), migrator, args, os.Stdout)
+}
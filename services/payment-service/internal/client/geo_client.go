@@ -0,0 +1,47 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	geopb "github.com/rideshare-platform/shared/proto/geo"
+)
+
+// GRPCShiftClient reads driver shifts from geo-service's gRPC API
+type GRPCShiftClient struct {
+	client geopb.GeospatialServiceClient
+}
+
+// NewGRPCShiftClient creates a new shift client
+func NewGRPCShiftClient(conn grpc.ClientConnInterface) *GRPCShiftClient {
+	return &GRPCShiftClient{client: geopb.NewGeospatialServiceClient(conn)}
+}
+
+// DriverShifts returns the driver's shifts that overlap [from, to). A shift
+// still in progress ends now.
+func (c *GRPCShiftClient) DriverShifts(ctx context.Context, driverID string, from, to time.Time) ([]types.DriverShift, error) {
+	resp, err := c.client.ListDriverShifts(ctx, &geopb.ListDriverShiftsRequest{
+		DriverId: driverID,
+		From:     timestamppb.New(from),
+		To:       timestamppb.New(to),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	shifts := make([]types.DriverShift, 0, len(resp.Shifts))
+	for _, shift := range resp.Shifts {
+		if shift.StartedAt == nil || shift.EndedAt == nil {
+			continue
+		}
+		shifts = append(shifts, types.DriverShift{
+			StartedAt: shift.StartedAt.AsTime(),
+			EndedAt:   shift.EndedAt.AsTime(),
+		})
+	}
+	return shifts, nil
+}
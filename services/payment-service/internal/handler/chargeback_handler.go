@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rideshare-platform/services/payment-service/internal/service"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// ProviderSignatureHeader carries the hex HMAC-SHA256 of a provider webhook's
+// body, keyed with the webhook secret shared with the provider
+const ProviderSignatureHeader = "X-Provider-Signature"
+
+// ChargebackHandler handles the payment provider's dispute webhooks. Staff
+// work chargebacks through the gateway's admin API.
+type ChargebackHandler struct {
+	chargebackService *service.ChargebackService
+	webhookSecret     []byte
+	logger            logger.Logger
+}
+
+// NewChargebackHandler creates a new chargeback handler. Provider webhooks
+// are refused until a webhook secret is configured.
+func NewChargebackHandler(chargebackService *service.ChargebackService, webhookSecret []byte, logger logger.Logger) *ChargebackHandler {
+	return &ChargebackHandler{
+		chargebackService: chargebackService,
+		webhookSecret:     webhookSecret,
+		logger:            logger,
+	}
+}
+
+// RegisterRoutes registers the provider webhook route
+func (h *ChargebackHandler) RegisterRoutes(router *gin.Engine) {
+	router.POST("/api/v1/provider-webhooks/disputes", h.ProviderDisputeWebhook)
+}
+
+// ProviderDisputeWebhook ingests a signed dispute event from the payment
+// provider
+func (h *ChargebackHandler) ProviderDisputeWebhook(c *gin.Context) {
+	if len(h.webhookSecret) == 0 {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"error": "Provider webhooks are not configured",
+		})
+		return
+	}
+
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Failed to read request body",
+		})
+		return
+	}
+	if !h.validSignature(body, c.GetHeader(ProviderSignatureHeader)) {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Invalid webhook signature",
+		})
+		return
+	}
+
+	var event types.ProviderDisputeEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	chargeback, err := h.chargebackService.HandleProviderEvent(c.Request.Context(), &event)
+	if err != nil {
+		h.logger.Error("Failed to handle provider dispute event", "error", err, "event_id", event.ID, "dispute_id", event.Dispute.ID)
+		h.respondError(c, err, "Failed to handle dispute event")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"chargeback": chargeback,
+	})
+}
+
+func (h *ChargebackHandler) validSignature(body []byte, signature string) bool {
+	given, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, h.webhookSecret)
+	mac.Write(body)
+	return hmac.Equal(given, mac.Sum(nil))
+}
+
+func (h *ChargebackHandler) respondError(c *gin.Context, err error, message string) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, types.ErrChargebackNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, types.ErrInvalidChargeback):
+		status = http.StatusBadRequest
+	case errors.Is(err, types.ErrChargebackClosed):
+		status = http.StatusConflict
+	}
+
+	c.JSON(status, gin.H{
+		"error":   message,
+		"details": err.Error(),
+	})
+}
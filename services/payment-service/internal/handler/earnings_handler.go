@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rideshare-platform/services/payment-service/internal/service"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/middleware"
+)
+
+// EarningsHandler handles HTTP requests for the authenticated driver's
+// earnings. Tips and incentives are recorded by other services over gRPC.
+type EarningsHandler struct {
+	earnings *service.EarningsService
+	auth     *middleware.AuthMiddleware
+	logger   logger.Logger
+}
+
+// NewEarningsHandler creates a new earnings handler
+func NewEarningsHandler(earnings *service.EarningsService, auth *middleware.AuthMiddleware, logger logger.Logger) *EarningsHandler {
+	return &EarningsHandler{
+		earnings: earnings,
+		auth:     auth,
+		logger:   logger,
+	}
+}
+
+// RegisterRoutes registers driver earnings routes. Drivers only see their
+// own statements.
+func (h *EarningsHandler) RegisterRoutes(router *gin.Engine) {
+	drivers := router.Group("/api/v1/drivers/:driver_id/earnings", h.auth.JWTAuth(), h.auth.RequireUserType("driver"), requireDriverSelf)
+	{
+		drivers.GET("/daily", h.GetDailyStatement)
+	}
+}
+
+// GetDailyStatement returns a driver's earnings for each day from the from
+// date to the to date, with days starting at midnight in tz. format=csv
+// downloads the statement as CSV.
+func (h *EarningsHandler) GetDailyStatement(c *gin.Context) {
+	req := types.StatementRequest{
+		DriverID: c.Param("driver_id"),
+		From:     c.Query("from"),
+		To:       c.Query("to"),
+		Timezone: c.Query("tz"),
+	}
+	if req.To == "" {
+		req.To = req.From
+	}
+
+	statement, err := h.earnings.Statement(c.Request.Context(), req)
+	if errors.Is(err, types.ErrInvalidStatementRange) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid statement range",
+			"details": err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to build earnings statement", "error", err, "driver_id", req.DriverID)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to build earnings statement",
+		})
+		return
+	}
+
+	if c.Query("format") != "csv" {
+		c.JSON(http.StatusOK, statement)
+		return
+	}
+
+	var body bytes.Buffer
+	if err := service.WriteStatementCSV(&body, statement); err != nil {
+		h.logger.Error("Failed to write earnings statement", "error", err, "driver_id", req.DriverID)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to write earnings statement",
+		})
+		return
+	}
+	filename := fmt.Sprintf("earnings-%s-%s-%s.csv", statement.DriverID, statement.From, statement.To)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	c.Data(http.StatusOK, "text/csv", body.Bytes())
+}
+
+// requireDriverSelf refuses requests for another driver than the one the
+// token was issued to
+func requireDriverSelf(c *gin.Context) {
+	if userID, ok := middleware.GetUserID(c); !ok || userID != c.Param("driver_id") {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "Drivers can only see their own earnings",
+		})
+		c.Abort()
+		return
+	}
+	c.Next()
+}
@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/rideshare-platform/services/payment-service/internal/repository"
+	"github.com/rideshare-platform/services/payment-service/internal/service"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/clients/contract"
+	"github.com/rideshare-platform/shared/clients/paymentclient"
+	"github.com/rideshare-platform/shared/grpc/interceptor"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/middleware"
+	paymentpb "github.com/rideshare-platform/shared/proto/payment"
+)
+
+func TestGRPCPaymentHandler_ServesContract(t *testing.T) {
+	log := logger.NewLogger("error", "test")
+	paymentRepo := repository.NewMockPaymentRepository()
+	paymentService := service.NewPaymentService(
+		paymentRepo,
+		repository.NewMockPaymentMethodRepository(),
+		repository.NewMockRefundRepository(),
+		service.NewSimpleFraudDetectionService(*log),
+		*log,
+	)
+
+	conn := contract.Serve(t, func(server *grpc.Server) {
+		handler := NewGRPCPaymentHandler(paymentService)
+		handler.SetWallet(service.NewWalletService(repository.NewMockWalletRepository(), paymentService, *log))
+		handler.SetHolds(service.NewHoldService(repository.NewMockPaymentHoldRepository(), paymentService, service.DefaultHoldConfig(), *log))
+		handler.SetChargebacks(service.NewChargebackService(repository.NewMockChargebackRepository(), paymentRepo, *log))
+		handler.SetLedger(service.NewLedgerService(repository.NewMockLedgerRepository(), repository.NewMockLedgerOutboxRepository(), *log))
+		handler.SetEarnings(service.NewEarningsService(paymentRepo, repository.NewMockDriverEarningRepository(), *log))
+		handler.SetRefundPolicy(service.NewRefundPolicyService(paymentService, repository.NewMockRefundCaseRepository(), service.DefaultRefundPolicyConfig(), *log))
+		handler.SetFraud(service.NewRulesFraudDetectionService(paymentRepo, repository.NewMockFraudRuleRepository(), repository.NewMockFraudReviewRepository(), nil, service.DefaultFraudEngineConfig(), *log))
+		paymentpb.RegisterPaymentServiceServer(server, handler)
+	})
+	contract.Check(t, paymentclient.ContractCalls(conn),
+		// Payments and payment methods are only served over HTTP
+		"ProcessPayment",
+		"GetPayment",
+		"AddPaymentMethod",
+	)
+}
+
+func TestGRPCPaymentHandler_RecordDriverPayoutRequiresAdmin(t *testing.T) {
+	const secret = "payout-test-secret"
+	log := logger.NewLogger("error", "test")
+	handler := NewGRPCPaymentHandler(nil)
+	handler.SetLedger(service.NewLedgerService(repository.NewMockLedgerRepository(), repository.NewMockLedgerOutboxRepository(), *log))
+
+	auth := interceptor.JWTAuth(secret, false)
+	conn := contract.Serve(t, func(server *grpc.Server) {
+		paymentpb.RegisterPaymentServiceServer(server, handler)
+	}, grpc.ChainUnaryInterceptor(interceptor.AuthUnaryServerInterceptor(*auth)))
+	client := paymentpb.NewPaymentServiceClient(conn)
+
+	withToken := func(userType string) context.Context {
+		return tokenContext(t, secret, userType)
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want codes.Code
+	}{
+		{"no token", context.Background(), codes.PermissionDenied},
+		{"driver token", withToken("driver"), codes.PermissionDenied},
+		// Nothing is owed to the driver yet
+		{"admin token", withToken("admin"), codes.FailedPrecondition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.RecordDriverPayout(tt.ctx, &paymentpb.RecordDriverPayoutRequest{
+				DriverId: "driver-1", Amount: 25, Currency: "USD", Reference: "transfer-1",
+			})
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("RecordDriverPayout returned %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGRPCPaymentHandler_RefundCasesTakeTheReviewerFromTheToken(t *testing.T) {
+	const secret = "refund-test-secret"
+	ctx := context.Background()
+	log := logger.NewLogger("error", "test")
+	cases := repository.NewMockRefundCaseRepository()
+	if err := cases.CreateCase(ctx, &types.RefundCase{
+		ID: "case-1", TripID: "trip-1", PaymentID: "payment-1", UserID: "rider-1",
+		Reason: types.RefundReasonPlatformCancellation, Amount: 80, Currency: "USD",
+		Status: types.RefundCasePendingReview, CreatedAt: time.Now(),
+	}); err != nil {
+		t.Fatalf("failed to create refund case: %v", err)
+	}
+	handler := NewGRPCPaymentHandler(nil)
+	handler.SetRefundPolicy(service.NewRefundPolicyService(nil, cases, service.DefaultRefundPolicyConfig(), *log))
+
+	auth := interceptor.JWTAuth(secret, false)
+	conn := contract.Serve(t, func(server *grpc.Server) {
+		paymentpb.RegisterPaymentServiceServer(server, handler)
+	}, grpc.ChainUnaryInterceptor(interceptor.AuthUnaryServerInterceptor(*auth)))
+	client := paymentpb.NewPaymentServiceClient(conn)
+
+	// Riders cannot raise refunds by reporting trip events themselves
+	_, err := client.ReportTripEvent(tokenContext(t, secret, "rider"), &paymentpb.ReportTripEventRequest{
+		EventId: "event-1", EventType: "trip.cancelled", TripId: "trip-1", CancelledBy: "platform",
+	})
+	if got := status.Code(err); got != codes.PermissionDenied {
+		t.Errorf("ReportTripEvent with a rider token returned %v, want %v", got, codes.PermissionDenied)
+	}
+
+	reject := &paymentpb.ResolveRefundCaseRequest{CaseId: "case-1", Notes: "rider was not charged"}
+	_, err = client.ResolveRefundCase(tokenContext(t, secret, "driver"), reject)
+	if got := status.Code(err); got != codes.PermissionDenied {
+		t.Errorf("ResolveRefundCase with a driver token returned %v, want %v", got, codes.PermissionDenied)
+	}
+
+	resp, err := client.ResolveRefundCase(tokenContext(t, secret, "admin"), reject)
+	if err != nil {
+		t.Fatalf("ResolveRefundCase failed: %v", err)
+	}
+	if resp.Case.Status != string(types.RefundCaseRejected) || resp.Case.ReviewedBy != "user-1" {
+		t.Errorf("Unexpected refund case: %+v", resp.Case)
+	}
+
+	_, err = client.ResolveRefundCase(tokenContext(t, secret, "admin"), reject)
+	if got := status.Code(err); got != codes.FailedPrecondition {
+		t.Errorf("Resolving a case twice returned %v, want %v", got, codes.FailedPrecondition)
+	}
+}
+
+func TestGRPCPaymentHandler_RecordDriverEarning(t *testing.T) {
+	const secret = "earning-test-secret"
+	log := logger.NewLogger("error", "test")
+	handler := NewGRPCPaymentHandler(nil)
+	handler.SetEarnings(service.NewEarningsService(repository.NewMockPaymentRepository(), repository.NewMockDriverEarningRepository(), *log))
+
+	auth := interceptor.JWTAuth(secret, false)
+	conn := contract.Serve(t, func(server *grpc.Server) {
+		paymentpb.RegisterPaymentServiceServer(server, handler)
+	}, grpc.ChainUnaryInterceptor(interceptor.AuthUnaryServerInterceptor(*auth)))
+	client := paymentpb.NewPaymentServiceClient(conn)
+	incentive := &paymentpb.RecordDriverEarningRequest{DriverId: "driver-1", Type: "incentive", Amount: 5, Currency: "USD"}
+
+	_, err := client.RecordDriverEarning(tokenContext(t, secret, "driver"), incentive)
+	if got := status.Code(err); got != codes.PermissionDenied {
+		t.Errorf("RecordDriverEarning with a driver token returned %v, want %v", got, codes.PermissionDenied)
+	}
+
+	before := time.Now()
+	resp, err := client.RecordDriverEarning(context.Background(), incentive)
+	if err != nil {
+		t.Fatalf("RecordDriverEarning failed: %v", err)
+	}
+	if resp.EarnedAt.AsTime().Before(before.Truncate(time.Second)) {
+		t.Errorf("Earning is dated %v, before it was recorded", resp.EarnedAt.AsTime())
+	}
+}
+
+func TestGRPCPaymentHandler_FraudReviewsTakeTheReviewerFromTheToken(t *testing.T) {
+	const secret = "fraud-test-secret"
+	ctx := context.Background()
+	log := logger.NewLogger("error", "test")
+	reviews := repository.NewMockFraudReviewRepository()
+	if err := reviews.CreateReview(ctx, &types.FraudReview{
+		ID: "review-1", PaymentID: "payment-1", UserID: "rider-1", Amount: 300, Currency: "USD",
+		RiskLevel: types.FraudRiskHigh, Status: types.FraudReviewPending,
+	}); err != nil {
+		t.Fatalf("failed to create fraud review: %v", err)
+	}
+	handler := NewGRPCPaymentHandler(nil)
+	handler.SetFraud(service.NewRulesFraudDetectionService(repository.NewMockPaymentRepository(), repository.NewMockFraudRuleRepository(), reviews, nil, service.DefaultFraudEngineConfig(), *log))
+
+	auth := interceptor.JWTAuth(secret, false)
+	conn := contract.Serve(t, func(server *grpc.Server) {
+		paymentpb.RegisterPaymentServiceServer(server, handler)
+	}, grpc.ChainUnaryInterceptor(interceptor.AuthUnaryServerInterceptor(*auth)))
+	client := paymentpb.NewPaymentServiceClient(conn)
+
+	_, err := client.SaveFraudRule(tokenContext(t, secret, "rider"), &paymentpb.SaveFraudRuleRequest{
+		Rule: &paymentpb.FraudRule{Name: "allow_all", Expression: "amount < 0", Enabled: true},
+	})
+	if got := status.Code(err); got != codes.PermissionDenied {
+		t.Errorf("SaveFraudRule with a rider token returned %v, want %v", got, codes.PermissionDenied)
+	}
+
+	approve := &paymentpb.ResolveFraudReviewRequest{ReviewId: "review-1", Approve: true, Notes: "known rider"}
+	_, err = client.ResolveFraudReview(tokenContext(t, secret, "rider"), approve)
+	if got := status.Code(err); got != codes.PermissionDenied {
+		t.Errorf("ResolveFraudReview with a rider token returned %v, want %v", got, codes.PermissionDenied)
+	}
+
+	resp, err := client.ResolveFraudReview(tokenContext(t, secret, "admin"), approve)
+	if err != nil {
+		t.Fatalf("ResolveFraudReview failed: %v", err)
+	}
+	if resp.Review.Status != string(types.FraudReviewApproved) || resp.Review.ReviewedBy != "user-1" {
+		t.Errorf("Unexpected fraud review: %+v", resp.Review)
+	}
+
+	_, err = client.ResolveFraudReview(tokenContext(t, secret, "admin"), approve)
+	if got := status.Code(err); got != codes.FailedPrecondition {
+		t.Errorf("Resolving a review twice returned %v, want %v", got, codes.FailedPrecondition)
+	}
+}
+
+// tokenContext returns a context carrying a bearer token for user-1 of the
+// given user type
+func tokenContext(t *testing.T, secret, userType string) context.Context {
+	t.Helper()
+	token, err := middleware.NewAuthMiddleware(secret, nil).GenerateToken("user-1", userType, "user@example.com", 1)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
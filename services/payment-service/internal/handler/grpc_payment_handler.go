@@ -0,0 +1,1009 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/rideshare-platform/services/payment-service/internal/service"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/events"
+	"github.com/rideshare-platform/shared/grpc/interceptor"
+	"github.com/rideshare-platform/shared/pagination"
+	paymentpb "github.com/rideshare-platform/shared/proto/payment"
+)
+
+// GRPCPaymentHandler handles gRPC requests for payment service
+type GRPCPaymentHandler struct {
+	paymentpb.UnimplementedPaymentServiceServer
+	paymentService *service.PaymentService
+	walletService  *service.WalletService
+	holdService    *service.HoldService
+	chargebacks    *service.ChargebackService
+	ledger         *service.LedgerService
+	earnings       *service.EarningsService
+	refundPolicy   *service.RefundPolicyService
+	fraud          *service.RulesFraudDetectionService
+}
+
+// NewGRPCPaymentHandler creates a new gRPC payment handler
+func NewGRPCPaymentHandler(paymentService *service.PaymentService) *GRPCPaymentHandler {
+	return &GRPCPaymentHandler{
+		paymentService: paymentService,
+	}
+}
+
+// SetWallet attaches the wallet service behind GrantWalletCredit
+func (h *GRPCPaymentHandler) SetWallet(walletService *service.WalletService) {
+	h.walletService = walletService
+}
+
+// SetHolds attaches the hold service behind the trip payment hold RPCs
+func (h *GRPCPaymentHandler) SetHolds(holdService *service.HoldService) {
+	h.holdService = holdService
+}
+
+// SetChargebacks attaches the chargeback service behind the chargeback RPCs
+func (h *GRPCPaymentHandler) SetChargebacks(chargebacks *service.ChargebackService) {
+	h.chargebacks = chargebacks
+}
+
+// SetLedger attaches the general ledger behind RecordDriverPayout
+func (h *GRPCPaymentHandler) SetLedger(ledger *service.LedgerService) {
+	h.ledger = ledger
+}
+
+// SetEarnings attaches the earnings service behind RecordDriverEarning
+func (h *GRPCPaymentHandler) SetEarnings(earnings *service.EarningsService) {
+	h.earnings = earnings
+}
+
+// SetRefundPolicy attaches the refund policy behind ReportTripEvent and the
+// refund case RPCs
+func (h *GRPCPaymentHandler) SetRefundPolicy(refundPolicy *service.RefundPolicyService) {
+	h.refundPolicy = refundPolicy
+}
+
+// SetFraud attaches the fraud engine behind the fraud rule and review RPCs
+func (h *GRPCPaymentHandler) SetFraud(fraud *service.RulesFraudDetectionService) {
+	h.fraud = fraud
+}
+
+// GetTripPayments returns the payments recorded against a trip
+func (h *GRPCPaymentHandler) GetTripPayments(ctx context.Context, req *paymentpb.GetTripPaymentsRequest) (*paymentpb.GetTripPaymentsResponse, error) {
+	if req.TripId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "trip ID is required")
+	}
+
+	payments, err := h.paymentService.GetTripPayments(ctx, req.TripId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get trip payments: %v", err)
+	}
+
+	resp := &paymentpb.GetTripPaymentsResponse{Count: int32(len(payments))}
+	for _, payment := range payments {
+		pb, err := h.paymentWithRefunds(ctx, payment)
+		if err != nil {
+			return nil, err
+		}
+		resp.Payments = append(resp.Payments, pb)
+	}
+	return resp, nil
+}
+
+// GetUserPaymentMethods returns the payment methods a user has saved. Only the
+// masked card data is returned, details are left out.
+func (h *GRPCPaymentHandler) GetUserPaymentMethods(ctx context.Context, req *paymentpb.GetUserPaymentMethodsRequest) (*paymentpb.GetUserPaymentMethodsResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "user ID is required")
+	}
+
+	methods, err := h.paymentService.GetUserPaymentMethods(ctx, req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get payment methods: %v", err)
+	}
+
+	resp := &paymentpb.GetUserPaymentMethodsResponse{Count: int32(len(methods))}
+	for _, method := range methods {
+		resp.PaymentMethods = append(resp.PaymentMethods, paymentMethodDetailsToProto(method))
+	}
+	return resp, nil
+}
+
+// GetUserPayments pages through the payments charged to a user, newest first
+// unless another sort is asked for
+func (h *GRPCPaymentHandler) GetUserPayments(ctx context.Context, req *paymentpb.GetUserPaymentsRequest) (*paymentpb.GetUserPaymentsResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "user ID is required")
+	}
+	page, err := paymentPage(req.Limit, req.Cursor, req.Sort)
+	if err != nil {
+		return nil, err
+	}
+
+	payments, err := h.paymentService.GetUserPayments(ctx, req.UserId, page)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user payments: %v", err)
+	}
+
+	resp := &paymentpb.GetUserPaymentsResponse{
+		TotalCount: int32(payments.TotalEstimate),
+		HasMore:    payments.NextCursor != "",
+		NextCursor: payments.NextCursor,
+	}
+	for _, payment := range payments.Items {
+		pb, err := h.paymentWithRefunds(ctx, payment)
+		if err != nil {
+			return nil, err
+		}
+		resp.Payments = append(resp.Payments, pb)
+	}
+	return resp, nil
+}
+
+// RemoveUserPaymentMethods deletes a user's saved payment methods for a data
+// subject erasure request
+func (h *GRPCPaymentHandler) RemoveUserPaymentMethods(ctx context.Context, req *paymentpb.RemoveUserPaymentMethodsRequest) (*paymentpb.RemoveUserPaymentMethodsResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "user ID is required")
+	}
+
+	removed, err := h.paymentService.RemoveUserPaymentMethods(ctx, req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to remove payment methods: %v", err)
+	}
+	return &paymentpb.RemoveUserPaymentMethodsResponse{RemovedCount: int32(removed)}, nil
+}
+
+// ListPayments pages through the payments created in a time window, e.g. for
+// reconciling them against trips
+func (h *GRPCPaymentHandler) ListPayments(ctx context.Context, req *paymentpb.ListPaymentsRequest) (*paymentpb.ListPaymentsResponse, error) {
+	if req.CreatedAfter == nil || req.CreatedBefore == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "created_after and created_before are required")
+	}
+	// Oldest first, so payments created while reconciling land on later pages
+	page, err := paymentPage(req.Limit, req.Cursor, "created_at")
+	if err != nil {
+		return nil, err
+	}
+
+	payments, err := h.paymentService.ListPayments(ctx, req.CreatedAfter.AsTime(), req.CreatedBefore.AsTime(), page)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list payments: %v", err)
+	}
+
+	resp := &paymentpb.ListPaymentsResponse{HasMore: payments.NextCursor != "", NextCursor: payments.NextCursor}
+	for _, payment := range payments.Items {
+		pb, err := h.paymentWithRefunds(ctx, payment)
+		if err != nil {
+			return nil, err
+		}
+		resp.Payments = append(resp.Payments, pb)
+	}
+	return resp, nil
+}
+
+// ProcessRefund refunds part or all of a completed payment. Refunds declined
+// by validation or the processor are reported in the response, not as errors.
+func (h *GRPCPaymentHandler) ProcessRefund(ctx context.Context, req *paymentpb.ProcessRefundRequest) (*paymentpb.ProcessRefundResponse, error) {
+	if req.PaymentId == "" || req.Amount <= 0 || req.Reason == "" || req.RequestedBy == "" {
+		return nil, status.Error(codes.InvalidArgument, "payment ID, a positive amount, reason and requested by are required")
+	}
+
+	response, err := h.paymentService.ProcessRefund(ctx, &types.RefundPaymentRequest{
+		PaymentID:   req.PaymentId,
+		Amount:      req.Amount,
+		Reason:      req.Reason,
+		RequestedBy: req.RequestedBy,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to process refund: %v", err)
+	}
+
+	resp := &paymentpb.ProcessRefundResponse{
+		Success: response.Success,
+		Message: response.Message,
+		Errors:  response.Errors,
+	}
+	if response.Refund != nil {
+		resp.RefundId = response.Refund.ID
+	}
+	return resp, nil
+}
+
+// ListPaymentsByStatus pages through the payments in one status, newest first
+func (h *GRPCPaymentHandler) ListPaymentsByStatus(ctx context.Context, req *paymentpb.ListPaymentsByStatusRequest) (*paymentpb.ListPaymentsResponse, error) {
+	var paymentStatus types.PaymentStatus
+	for s, pb := range paymentStatusToProto {
+		if pb == req.Status {
+			paymentStatus = s
+		}
+	}
+	if paymentStatus == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown payment status %s", req.Status)
+	}
+	page, err := paymentPage(req.Limit, req.Cursor, "")
+	if err != nil {
+		return nil, err
+	}
+
+	payments, err := h.paymentService.ListPaymentsByStatus(ctx, paymentStatus, page)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list payments: %v", err)
+	}
+
+	resp := &paymentpb.ListPaymentsResponse{HasMore: payments.NextCursor != "", NextCursor: payments.NextCursor}
+	for _, payment := range payments.Items {
+		pb, err := h.paymentWithRefunds(ctx, payment)
+		if err != nil {
+			return nil, err
+		}
+		resp.Payments = append(resp.Payments, pb)
+	}
+	return resp, nil
+}
+
+// paymentPage builds the page a list RPC asks for. An unset limit reads the
+// largest page.
+func paymentPage(limit int32, cursor, sort string) (pagination.Request, error) {
+	if limit <= 0 {
+		limit = int32(types.PaymentListOptions.MaxLimit)
+	}
+	page, err := pagination.NewRequest(int(limit), cursor, sort, types.PaymentListOptions)
+	if err != nil {
+		return pagination.Request{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return page, nil
+}
+
+// paymentWithRefunds converts a payment and adds how much of it was refunded
+func (h *GRPCPaymentHandler) paymentWithRefunds(ctx context.Context, payment *types.Payment) (*paymentpb.Payment, error) {
+	pb := paymentToProto(payment)
+	if payment.Status != types.PaymentStatusCompleted && payment.Status != types.PaymentStatusRefunded {
+		return pb, nil
+	}
+
+	refunded, err := h.paymentService.RefundedAmount(ctx, payment)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get refunds for payment %s: %v", payment.ID, err)
+	}
+	pb.RefundedAmount = refunded
+	return pb, nil
+}
+
+var paymentStatusToProto = map[types.PaymentStatus]paymentpb.PaymentStatus{
+	types.PaymentStatusPending:    paymentpb.PaymentStatus_PENDING,
+	types.PaymentStatusProcessing: paymentpb.PaymentStatus_PROCESSING,
+	types.PaymentStatusCompleted:  paymentpb.PaymentStatus_COMPLETED,
+	types.PaymentStatusFailed:     paymentpb.PaymentStatus_FAILED,
+	types.PaymentStatusRefunded:   paymentpb.PaymentStatus_REFUNDED,
+	types.PaymentStatusCancelled:  paymentpb.PaymentStatus_CANCELLED,
+	types.PaymentStatusChargeback: paymentpb.PaymentStatus_CHARGEBACK,
+}
+
+var paymentMethodToProto = map[types.PaymentMethod]paymentpb.PaymentMethod{
+	types.PaymentMethodCreditCard:    paymentpb.PaymentMethod_CREDIT_CARD,
+	types.PaymentMethodDebitCard:     paymentpb.PaymentMethod_DEBIT_CARD,
+	types.PaymentMethodDigitalWallet: paymentpb.PaymentMethod_DIGITAL_WALLET,
+	types.PaymentMethodBankTransfer:  paymentpb.PaymentMethod_BANK_TRANSFER,
+	types.PaymentMethodCash:          paymentpb.PaymentMethod_CASH,
+	types.PaymentMethodWalletBalance: paymentpb.PaymentMethod_WALLET_BALANCE,
+}
+
+var transactionTypeToProto = map[types.TransactionType]paymentpb.TransactionType{
+	types.TransactionTypePayment:       paymentpb.TransactionType_PAYMENT,
+	types.TransactionTypeRefund:        paymentpb.TransactionType_REFUND,
+	types.TransactionTypeChargeback:    paymentpb.TransactionType_CHARGEBACK_TXN,
+	types.TransactionTypeAuthorization: paymentpb.TransactionType_AUTHORIZATION,
+	types.TransactionTypeCapture:       paymentpb.TransactionType_CAPTURE,
+}
+
+func paymentMethodDetailsToProto(method *types.PaymentMethodDetails) *paymentpb.PaymentMethodDetails {
+	pb := &paymentpb.PaymentMethodDetails{
+		Id:             method.ID,
+		UserId:         method.UserID,
+		Type:           paymentMethodToProto[method.Type],
+		IsDefault:      method.IsDefault,
+		Fingerprint:    method.Fingerprint,
+		LastFourDigits: method.LastFourDigits,
+		BankName:       method.BankName,
+		WalletProvider: method.WalletProvider,
+		CreatedAt:      timestamppb.New(method.CreatedAt),
+		UpdatedAt:      timestamppb.New(method.UpdatedAt),
+	}
+	if method.ExpiryDate != nil {
+		pb.ExpiryDate = timestamppb.New(*method.ExpiryDate)
+	}
+	return pb
+}
+
+func paymentToProto(payment *types.Payment) *paymentpb.Payment {
+	pb := &paymentpb.Payment{
+		Id:              payment.ID,
+		TripId:          payment.TripID,
+		UserId:          payment.UserID,
+		DriverId:        payment.DriverID,
+		Amount:          payment.Amount,
+		Currency:        payment.Currency,
+		PaymentMethod:   paymentMethodToProto[payment.PaymentMethod],
+		Status:          paymentStatusToProto[payment.Status],
+		TransactionType: transactionTypeToProto[payment.TransactionType],
+		FailureReason:   payment.FailureReason,
+		CreatedAt:       timestamppb.New(payment.CreatedAt),
+		UpdatedAt:       timestamppb.New(payment.UpdatedAt),
+	}
+	if payment.ProcessedAt != nil {
+		pb.ProcessedAt = timestamppb.New(*payment.ProcessedAt)
+	}
+	return pb
+}
+
+// GrantWalletCredit gives a rider ride credits. Credits the wallet declines
+// are reported in the response, not as errors.
+func (h *GRPCPaymentHandler) GrantWalletCredit(ctx context.Context, req *paymentpb.GrantWalletCreditRequest) (*paymentpb.GrantWalletCreditResponse, error) {
+	if h.walletService == nil {
+		return nil, status.Error(codes.Unimplemented, "wallets are not configured")
+	}
+	if req.UserId == "" || req.Amount <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "user ID and a positive amount are required")
+	}
+
+	response, err := h.walletService.GrantCredit(ctx, &types.GrantCreditRequest{
+		UserID:      req.UserId,
+		Amount:      req.Amount,
+		Currency:    req.Currency,
+		Type:        types.WalletTransactionType(req.Type),
+		Reference:   req.Reference,
+		Description: req.Description,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to grant credit: %v", err)
+	}
+
+	resp := &paymentpb.GrantWalletCreditResponse{
+		Success: response.Success,
+		Message: response.Message,
+		Errors:  response.Errors,
+	}
+	if response.Transaction != nil {
+		resp.TransactionId = response.Transaction.ID
+	}
+	return resp, nil
+}
+
+// AuthorizeTripPayment places a hold for a starting trip's estimated fare
+func (h *GRPCPaymentHandler) AuthorizeTripPayment(ctx context.Context, req *paymentpb.AuthorizeTripPaymentRequest) (*paymentpb.TripPaymentHoldResponse, error) {
+	if h.holdService == nil {
+		return nil, status.Error(codes.Unimplemented, "payment holds are not configured")
+	}
+	if req.TripId == "" || req.UserId == "" || req.EstimatedFare <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "trip ID, user ID and a positive estimated fare are required")
+	}
+
+	response, err := h.holdService.PlaceHold(ctx, &types.PlaceHoldRequest{
+		TripID:          req.TripId,
+		UserID:          req.UserId,
+		DriverID:        req.DriverId,
+		PaymentMethodID: req.PaymentMethodId,
+		EstimatedFare:   req.EstimatedFare,
+		Currency:        req.Currency,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to place payment hold: %v", err)
+	}
+	return holdResponseToProto(response), nil
+}
+
+// CaptureTripPayment charges a completed trip's fare against its hold
+func (h *GRPCPaymentHandler) CaptureTripPayment(ctx context.Context, req *paymentpb.CaptureTripPaymentRequest) (*paymentpb.TripPaymentHoldResponse, error) {
+	if h.holdService == nil {
+		return nil, status.Error(codes.Unimplemented, "payment holds are not configured")
+	}
+	if req.TripId == "" || req.Amount < 0 {
+		return nil, status.Error(codes.InvalidArgument, "trip ID and a non-negative amount are required")
+	}
+
+	response, err := h.holdService.CaptureHold(ctx, &types.CaptureHoldRequest{
+		TripID:          req.TripId,
+		UserID:          req.UserId,
+		DriverID:        req.DriverId,
+		PaymentMethodID: req.PaymentMethodId,
+		Amount:          req.Amount,
+		Currency:        req.Currency,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to capture trip payment: %v", err)
+	}
+	return holdResponseToProto(response), nil
+}
+
+// ReleaseTripPayment releases a cancelled trip's hold
+func (h *GRPCPaymentHandler) ReleaseTripPayment(ctx context.Context, req *paymentpb.ReleaseTripPaymentRequest) (*paymentpb.TripPaymentHoldResponse, error) {
+	if h.holdService == nil {
+		return nil, status.Error(codes.Unimplemented, "payment holds are not configured")
+	}
+	if req.TripId == "" {
+		return nil, status.Error(codes.InvalidArgument, "trip ID is required")
+	}
+
+	response, err := h.holdService.ReleaseHold(ctx, req.TripId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to release payment hold: %v", err)
+	}
+	return holdResponseToProto(response), nil
+}
+
+func holdResponseToProto(response *types.HoldResponse) *paymentpb.TripPaymentHoldResponse {
+	pb := &paymentpb.TripPaymentHoldResponse{
+		Success: response.Success,
+		Message: response.Message,
+		Errors:  response.Errors,
+	}
+	for _, payment := range response.Payments {
+		pb.PaymentIds = append(pb.PaymentIds, payment.ID)
+	}
+	if hold := response.Hold; hold != nil {
+		pb.Hold = &paymentpb.PaymentHold{
+			Id:              hold.ID,
+			TripId:          hold.TripID,
+			UserId:          hold.UserID,
+			PaymentMethodId: hold.PaymentMethodID,
+			EstimatedFare:   hold.EstimatedFare,
+			Amount:          hold.Amount,
+			Currency:        hold.Currency,
+			Status:          string(hold.Status),
+			CapturedAmount:  hold.CapturedAmount,
+			ReleasedAmount:  hold.ReleasedAmount,
+			PaymentId:       hold.PaymentID,
+			Renewals:        int32(hold.Renewals),
+			FailureReason:   hold.FailureReason,
+			ExpiresAt:       timestamppb.New(hold.ExpiresAt),
+			CreatedAt:       timestamppb.New(hold.CreatedAt),
+		}
+	}
+	return pb
+}
+
+// ListChargebacks returns a page of chargebacks, optionally in one status
+func (h *GRPCPaymentHandler) ListChargebacks(ctx context.Context, req *paymentpb.ListChargebacksRequest) (*paymentpb.ListChargebacksResponse, error) {
+	if h.chargebacks == nil {
+		return nil, status.Error(codes.Unimplemented, "chargebacks are not configured")
+	}
+	page, err := pagination.NewRequest(int(req.Limit), req.Cursor, "", types.ChargebackListOptions)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	chargebacks, err := h.chargebacks.ListChargebacks(ctx, types.ChargebackStatus(req.Status), page)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list chargebacks: %v", err)
+	}
+
+	response := &paymentpb.ListChargebacksResponse{HasMore: chargebacks.NextCursor != "", NextCursor: chargebacks.NextCursor}
+	for _, chargeback := range chargebacks.Items {
+		response.Chargebacks = append(response.Chargebacks, chargebackToProto(chargeback))
+	}
+	return response, nil
+}
+
+// GetChargeback returns a chargeback with its evidence
+func (h *GRPCPaymentHandler) GetChargeback(ctx context.Context, req *paymentpb.GetChargebackRequest) (*paymentpb.ChargebackResponse, error) {
+	if h.chargebacks == nil {
+		return nil, status.Error(codes.Unimplemented, "chargebacks are not configured")
+	}
+	if req.ChargebackId == "" {
+		return nil, status.Error(codes.InvalidArgument, "chargeback ID is required")
+	}
+
+	chargeback, err := h.chargebacks.GetChargeback(ctx, req.ChargebackId)
+	if err != nil {
+		return nil, chargebackError(err)
+	}
+	return &paymentpb.ChargebackResponse{Chargeback: chargebackToProto(chargeback)}, nil
+}
+
+// SubmitChargebackEvidence adds evidence to an open chargeback
+func (h *GRPCPaymentHandler) SubmitChargebackEvidence(ctx context.Context, req *paymentpb.SubmitChargebackEvidenceRequest) (*paymentpb.ChargebackResponse, error) {
+	if h.chargebacks == nil {
+		return nil, status.Error(codes.Unimplemented, "chargebacks are not configured")
+	}
+	if req.ChargebackId == "" {
+		return nil, status.Error(codes.InvalidArgument, "chargeback ID is required")
+	}
+
+	chargeback, err := h.chargebacks.SubmitEvidence(ctx, req.ChargebackId, &types.SubmitEvidenceRequest{
+		Type:        req.Type,
+		Description: req.Description,
+		SubmittedBy: req.SubmittedBy,
+	})
+	if err != nil {
+		return nil, chargebackError(err)
+	}
+	return &paymentpb.ChargebackResponse{Chargeback: chargebackToProto(chargeback)}, nil
+}
+
+// RecordChargebackOutcome decides an open chargeback
+func (h *GRPCPaymentHandler) RecordChargebackOutcome(ctx context.Context, req *paymentpb.RecordChargebackOutcomeRequest) (*paymentpb.ChargebackResponse, error) {
+	if h.chargebacks == nil {
+		return nil, status.Error(codes.Unimplemented, "chargebacks are not configured")
+	}
+	if req.ChargebackId == "" {
+		return nil, status.Error(codes.InvalidArgument, "chargeback ID is required")
+	}
+
+	chargeback, err := h.chargebacks.RecordOutcome(ctx, req.ChargebackId, &types.RecordOutcomeRequest{
+		Outcome:    types.ChargebackStatus(req.Outcome),
+		Notes:      req.Notes,
+		ResolvedBy: req.ResolvedBy,
+	})
+	if err != nil {
+		return nil, chargebackError(err)
+	}
+	return &paymentpb.ChargebackResponse{Chargeback: chargebackToProto(chargeback)}, nil
+}
+
+// RecordDriverPayout posts a transfer to a driver's bank account against
+// what the platform owes them. Payouts move money, so only operators may
+// record them.
+func (h *GRPCPaymentHandler) RecordDriverPayout(ctx context.Context, req *paymentpb.RecordDriverPayoutRequest) (*paymentpb.RecordDriverPayoutResponse, error) {
+	if err := interceptor.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if h.ledger == nil {
+		return nil, status.Error(codes.Unimplemented, "the general ledger is not configured")
+	}
+
+	entry, err := h.ledger.RecordPayout(ctx, &types.RecordPayoutRequest{
+		DriverID:  req.DriverId,
+		Amount:    req.Amount,
+		Currency:  req.Currency,
+		Reference: req.Reference,
+	})
+	if err != nil {
+		return nil, payoutError(err)
+	}
+
+	resp := &paymentpb.RecordDriverPayoutResponse{
+		JournalEntryId: entry.ID,
+		DriverId:       req.DriverId,
+		Amount:         req.Amount,
+		Reference:      entry.Reference,
+		PostedAt:       timestamppb.New(entry.PostedAt),
+	}
+	if len(entry.Postings) > 0 {
+		resp.Currency = entry.Postings[0].Currency
+	}
+	return resp, nil
+}
+
+// RecordDriverEarning records a tip or incentive paid to a driver and posts
+// it to the general ledger. It is dated now, so it cannot be backdated into
+// a statement or invoice already issued.
+func (h *GRPCPaymentHandler) RecordDriverEarning(ctx context.Context, req *paymentpb.RecordDriverEarningRequest) (*paymentpb.RecordDriverEarningResponse, error) {
+	if err := requireService(ctx, "driver earnings are only recorded by services"); err != nil {
+		return nil, err
+	}
+	if h.earnings == nil {
+		return nil, status.Error(codes.Unimplemented, "driver earnings are not configured")
+	}
+
+	earning, err := h.earnings.RecordEarning(ctx, &types.RecordEarningRequest{
+		DriverID:    req.DriverId,
+		TripID:      req.TripId,
+		Type:        types.EarningType(req.Type),
+		Amount:      req.Amount,
+		Currency:    req.Currency,
+		Description: req.Description,
+	})
+	if errors.Is(err, types.ErrInvalidEarning) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to record driver earning: %v", err)
+	}
+
+	return &paymentpb.RecordDriverEarningResponse{
+		EarningId: earning.ID,
+		DriverId:  earning.DriverID,
+		TripId:    earning.TripID,
+		Type:      string(earning.Type),
+		Amount:    earning.Amount,
+		Currency:  earning.Currency,
+		EarnedAt:  timestamppb.New(earning.EarnedAt),
+	}, nil
+}
+
+// requireService refuses calls made with a rider's or driver's token.
+// Services call without a token, or with an operator's they forward.
+func requireService(ctx context.Context, message string) error {
+	if claims, ok := interceptor.ClaimsFromContext(ctx); ok && claims.UserType != interceptor.AdminUserType {
+		return status.Error(codes.PermissionDenied, message)
+	}
+	return nil
+}
+
+func payoutError(err error) error {
+	switch {
+	case errors.Is(err, types.ErrInvalidJournalEntry):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, types.ErrDuplicateJournalEntry):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, types.ErrPayoutExceedsPayable):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return status.Errorf(codes.Internal, "payout failed: %v", err)
+}
+
+func chargebackError(err error) error {
+	switch {
+	case errors.Is(err, types.ErrChargebackNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, types.ErrInvalidChargeback):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, types.ErrChargebackClosed):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return status.Errorf(codes.Internal, "chargeback failed: %v", err)
+}
+
+func chargebackToProto(chargeback *types.Chargeback) *paymentpb.Chargeback {
+	pb := &paymentpb.Chargeback{
+		Id:                chargeback.ID,
+		ProviderDisputeId: chargeback.ProviderDisputeID,
+		PaymentId:         chargeback.PaymentID,
+		TripId:            chargeback.TripID,
+		UserId:            chargeback.UserID,
+		DriverId:          chargeback.DriverID,
+		Amount:            chargeback.Amount,
+		Currency:          chargeback.Currency,
+		Reason:            chargeback.Reason,
+		Status:            string(chargeback.Status),
+		Notes:             chargeback.Notes,
+		ResolvedBy:        chargeback.ResolvedBy,
+		CreatedAt:         timestamppb.New(chargeback.CreatedAt),
+	}
+	if chargeback.EvidenceDueBy != nil {
+		pb.EvidenceDueBy = timestamppb.New(*chargeback.EvidenceDueBy)
+	}
+	if chargeback.ResolvedAt != nil {
+		pb.ResolvedAt = timestamppb.New(*chargeback.ResolvedAt)
+	}
+	for _, evidence := range chargeback.Evidence {
+		pb.Evidence = append(pb.Evidence, &paymentpb.ChargebackEvidence{
+			Id:          evidence.ID,
+			Type:        evidence.Type,
+			Description: evidence.Description,
+			SubmittedBy: evidence.SubmittedBy,
+			SubmittedAt: timestamppb.New(evidence.SubmittedAt),
+		})
+	}
+	return pb
+}
+
+// ReportTripEvent hands a trip event from trip-service to the refund policy.
+// Riders and drivers must not be able to raise refunds, so calls made with
+// their tokens are refused.
+func (h *GRPCPaymentHandler) ReportTripEvent(ctx context.Context, req *paymentpb.ReportTripEventRequest) (*paymentpb.ReportTripEventResponse, error) {
+	if err := requireService(ctx, "trip events are only accepted from trip-service"); err != nil {
+		return nil, err
+	}
+	if h.refundPolicy == nil {
+		return nil, status.Error(codes.Unimplemented, "the refund policy is not configured")
+	}
+	if req.EventId == "" || req.TripId == "" {
+		return nil, status.Error(codes.InvalidArgument, "event ID and trip ID are required")
+	}
+
+	event := &events.Event{
+		ID:          req.EventId,
+		Type:        events.EventType(req.EventType),
+		AggregateID: req.TripId,
+		Data: map[string]interface{}{
+			"trip_id":      req.TripId,
+			"cancelled_by": req.CancelledBy,
+			"reason":       req.Reason,
+		},
+		Timestamp: time.Now().UTC(),
+		Source:    req.Source,
+	}
+	if err := h.refundPolicy.HandleTripEvent(ctx, event); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to handle trip event: %v", err)
+	}
+	return &paymentpb.ReportTripEventResponse{}, nil
+}
+
+// ListRefundCases returns refund cases in a status, oldest first
+func (h *GRPCPaymentHandler) ListRefundCases(ctx context.Context, req *paymentpb.ListRefundCasesRequest) (*paymentpb.ListRefundCasesResponse, error) {
+	if h.refundPolicy == nil {
+		return nil, status.Error(codes.Unimplemented, "the refund policy is not configured")
+	}
+	limit := int(req.Limit)
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+
+	cases, err := h.refundPolicy.ListCases(ctx, types.RefundCaseStatus(req.Status), limit, 0)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list refund cases: %v", err)
+	}
+
+	response := &paymentpb.ListRefundCasesResponse{}
+	for _, refundCase := range cases {
+		response.Cases = append(response.Cases, refundCaseToProto(refundCase, nil))
+	}
+	return response, nil
+}
+
+// GetRefundCase returns a refund case with its audit trail
+func (h *GRPCPaymentHandler) GetRefundCase(ctx context.Context, req *paymentpb.GetRefundCaseRequest) (*paymentpb.RefundCaseResponse, error) {
+	if h.refundPolicy == nil {
+		return nil, status.Error(codes.Unimplemented, "the refund policy is not configured")
+	}
+	if req.CaseId == "" {
+		return nil, status.Error(codes.InvalidArgument, "case ID is required")
+	}
+
+	refundCase, audit, err := h.refundPolicy.GetCase(ctx, req.CaseId)
+	if err != nil {
+		return nil, refundCaseError(err)
+	}
+	return &paymentpb.RefundCaseResponse{Case: refundCaseToProto(refundCase, audit)}, nil
+}
+
+// ResolveRefundCase approves or rejects a refund waiting for review.
+// Approving pays the refund, so only operators may decide cases, and the
+// decision is recorded against the operator the token was issued to.
+func (h *GRPCPaymentHandler) ResolveRefundCase(ctx context.Context, req *paymentpb.ResolveRefundCaseRequest) (*paymentpb.RefundCaseResponse, error) {
+	if err := interceptor.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if h.refundPolicy == nil {
+		return nil, status.Error(codes.Unimplemented, "the refund policy is not configured")
+	}
+	if req.CaseId == "" {
+		return nil, status.Error(codes.InvalidArgument, "case ID is required")
+	}
+
+	claims, _ := interceptor.ClaimsFromContext(ctx)
+	refundCase, err := h.refundPolicy.ResolveCase(ctx, req.CaseId, req.Approve, &types.ResolveRefundCaseRequest{
+		ReviewedBy: claims.UserID,
+		Notes:      req.Notes,
+	})
+	if err != nil {
+		return nil, refundCaseError(err)
+	}
+	return &paymentpb.RefundCaseResponse{Case: refundCaseToProto(refundCase, nil)}, nil
+}
+
+func refundCaseError(err error) error {
+	switch {
+	case errors.Is(err, types.ErrRefundCaseNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, types.ErrRefundCaseResolved):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return status.Errorf(codes.Internal, "refund case failed: %v", err)
+}
+
+func refundCaseToProto(refundCase *types.RefundCase, audit []*types.RefundAuditEntry) *paymentpb.RefundCase {
+	pb := &paymentpb.RefundCase{
+		Id:            refundCase.ID,
+		TripId:        refundCase.TripID,
+		PaymentId:     refundCase.PaymentID,
+		UserId:        refundCase.UserID,
+		Reason:        string(refundCase.Reason),
+		Amount:        refundCase.Amount,
+		Currency:      refundCase.Currency,
+		Status:        string(refundCase.Status),
+		AutoApproved:  refundCase.AutoApproved,
+		Source:        refundCase.Source,
+		Details:       refundCase.Details,
+		ReviewedBy:    refundCase.ReviewedBy,
+		Notes:         refundCase.Notes,
+		FailureReason: refundCase.FailureReason,
+		CreatedAt:     timestamppb.New(refundCase.CreatedAt),
+	}
+	if refundCase.ResolvedAt != nil {
+		pb.ResolvedAt = timestamppb.New(*refundCase.ResolvedAt)
+	}
+	for _, entry := range audit {
+		pb.Audit = append(pb.Audit, &paymentpb.RefundCaseAuditEntry{
+			Action:    entry.Action,
+			Actor:     entry.Actor,
+			Details:   entry.Details,
+			CreatedAt: timestamppb.New(entry.CreatedAt),
+		})
+	}
+	return pb
+}
+
+// ListFraudRules returns the enabled fraud rules. Rules are operator
+// configuration, so only operators may read them.
+func (h *GRPCPaymentHandler) ListFraudRules(ctx context.Context, req *paymentpb.ListFraudRulesRequest) (*paymentpb.ListFraudRulesResponse, error) {
+	if err := interceptor.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if h.fraud == nil {
+		return nil, status.Error(codes.Unimplemented, "the fraud engine is not configured")
+	}
+
+	rules, err := h.fraud.ListRules(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list fraud rules: %v", err)
+	}
+
+	response := &paymentpb.ListFraudRulesResponse{}
+	for _, rule := range rules {
+		response.Rules = append(response.Rules, fraudRuleToProto(rule))
+	}
+	return response, nil
+}
+
+// SaveFraudRule creates a fraud rule, or replaces the rule with its ID
+func (h *GRPCPaymentHandler) SaveFraudRule(ctx context.Context, req *paymentpb.SaveFraudRuleRequest) (*paymentpb.FraudRuleResponse, error) {
+	if err := interceptor.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if h.fraud == nil {
+		return nil, status.Error(codes.Unimplemented, "the fraud engine is not configured")
+	}
+	if req.Rule == nil {
+		return nil, status.Error(codes.InvalidArgument, "rule is required")
+	}
+
+	rule := &types.FraudRule{
+		ID:          req.Rule.Id,
+		Name:        req.Rule.Name,
+		Description: req.Rule.Description,
+		Expression:  req.Rule.Expression,
+		Score:       req.Rule.Score,
+		Action:      types.FraudRuleAction(req.Rule.Action),
+		Priority:    int(req.Rule.Priority),
+		Enabled:     req.Rule.Enabled,
+	}
+	if err := h.fraud.SaveRule(ctx, rule); err != nil {
+		return nil, fraudError(err)
+	}
+	return &paymentpb.FraudRuleResponse{Rule: fraudRuleToProto(rule)}, nil
+}
+
+// SetFraudRuleEnabled switches a fraud rule on or off
+func (h *GRPCPaymentHandler) SetFraudRuleEnabled(ctx context.Context, req *paymentpb.SetFraudRuleEnabledRequest) (*paymentpb.SetFraudRuleEnabledResponse, error) {
+	if err := interceptor.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if h.fraud == nil {
+		return nil, status.Error(codes.Unimplemented, "the fraud engine is not configured")
+	}
+	if req.RuleId == "" {
+		return nil, status.Error(codes.InvalidArgument, "rule ID is required")
+	}
+
+	if err := h.fraud.SetRuleEnabled(ctx, req.RuleId, req.Enabled); err != nil {
+		return nil, fraudError(err)
+	}
+	return &paymentpb.SetFraudRuleEnabledResponse{}, nil
+}
+
+// DeleteFraudRule removes a fraud rule
+func (h *GRPCPaymentHandler) DeleteFraudRule(ctx context.Context, req *paymentpb.DeleteFraudRuleRequest) (*paymentpb.DeleteFraudRuleResponse, error) {
+	if err := interceptor.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if h.fraud == nil {
+		return nil, status.Error(codes.Unimplemented, "the fraud engine is not configured")
+	}
+	if req.RuleId == "" {
+		return nil, status.Error(codes.InvalidArgument, "rule ID is required")
+	}
+
+	if err := h.fraud.DeleteRule(ctx, req.RuleId); err != nil {
+		return nil, fraudError(err)
+	}
+	return &paymentpb.DeleteFraudRuleResponse{}, nil
+}
+
+// ListFraudReviews returns flagged payments in a review status, riskiest
+// first. Reviews name riders and their payments, so only operators may
+// read them.
+func (h *GRPCPaymentHandler) ListFraudReviews(ctx context.Context, req *paymentpb.ListFraudReviewsRequest) (*paymentpb.ListFraudReviewsResponse, error) {
+	if err := interceptor.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if h.fraud == nil {
+		return nil, status.Error(codes.Unimplemented, "the fraud engine is not configured")
+	}
+	limit := int(req.Limit)
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+
+	reviews, err := h.fraud.ListReviews(ctx, types.FraudReviewStatus(req.Status), limit, 0)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list fraud reviews: %v", err)
+	}
+
+	response := &paymentpb.ListFraudReviewsResponse{}
+	for _, review := range reviews {
+		response.Reviews = append(response.Reviews, fraudReviewToProto(review))
+	}
+	return response, nil
+}
+
+// ResolveFraudReview approves or rejects a flagged payment. The decision is
+// recorded against the operator the token was issued to.
+func (h *GRPCPaymentHandler) ResolveFraudReview(ctx context.Context, req *paymentpb.ResolveFraudReviewRequest) (*paymentpb.FraudReviewResponse, error) {
+	if err := interceptor.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if h.fraud == nil {
+		return nil, status.Error(codes.Unimplemented, "the fraud engine is not configured")
+	}
+	if req.ReviewId == "" {
+		return nil, status.Error(codes.InvalidArgument, "review ID is required")
+	}
+
+	claims, _ := interceptor.ClaimsFromContext(ctx)
+	review, err := h.fraud.ResolveReview(ctx, req.ReviewId, req.Approve, &types.ResolveFraudReviewRequest{
+		ReviewedBy: claims.UserID,
+		Notes:      req.Notes,
+	})
+	if err != nil {
+		return nil, fraudError(err)
+	}
+	return &paymentpb.FraudReviewResponse{Review: fraudReviewToProto(review)}, nil
+}
+
+func fraudError(err error) error {
+	switch {
+	case errors.Is(err, types.ErrInvalidFraudRule):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, types.ErrFraudRuleNotFound), errors.Is(err, types.ErrFraudReviewNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, types.ErrFraudReviewResolved):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return status.Errorf(codes.Internal, "fraud operation failed: %v", err)
+}
+
+func fraudRuleToProto(rule *types.FraudRule) *paymentpb.FraudRule {
+	return &paymentpb.FraudRule{
+		Id:          rule.ID,
+		Name:        rule.Name,
+		Description: rule.Description,
+		Expression:  rule.Expression,
+		Score:       rule.Score,
+		Action:      string(rule.Action),
+		Priority:    int32(rule.Priority),
+		Enabled:     rule.Enabled,
+		CreatedAt:   timestamppb.New(rule.CreatedAt),
+		UpdatedAt:   timestamppb.New(rule.UpdatedAt),
+	}
+}
+
+func fraudReviewToProto(review *types.FraudReview) *paymentpb.FraudReview {
+	pb := &paymentpb.FraudReview{
+		Id:           review.ID,
+		PaymentId:    review.PaymentID,
+		UserId:       review.UserID,
+		Amount:       review.Amount,
+		Currency:     review.Currency,
+		RiskLevel:    string(review.RiskLevel),
+		RiskScore:    review.RiskScore,
+		Reasons:      review.Reasons,
+		MatchedRules: review.MatchedRules,
+		Scores:       review.Scores,
+		Status:       string(review.Status),
+		ReviewedBy:   review.ReviewedBy,
+		Notes:        review.Notes,
+		CreatedAt:    timestamppb.New(review.CreatedAt),
+	}
+	if review.ReviewedAt != nil {
+		pb.ReviewedAt = timestamppb.New(*review.ReviewedAt)
+	}
+	return pb
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rideshare-platform/services/payment-service/internal/service"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// InvoiceHandler handles HTTP requests for drivers' monthly invoices
+type InvoiceHandler struct {
+	invoices *service.InvoiceService
+	logger   logger.Logger
+}
+
+// NewInvoiceHandler creates a new invoice handler
+func NewInvoiceHandler(invoices *service.InvoiceService, logger logger.Logger) *InvoiceHandler {
+	return &InvoiceHandler{
+		invoices: invoices,
+		logger:   logger,
+	}
+}
+
+// RegisterRoutes registers driver invoice routes
+func (h *InvoiceHandler) RegisterRoutes(router *gin.Engine) {
+	v1 := router.Group("/api/v1")
+	{
+		v1.POST("/drivers/:driver_id/invoices", h.GenerateInvoice)
+		v1.GET("/drivers/:driver_id/invoices", h.ListDriverInvoices)
+		v1.POST("/drivers/:driver_id/invoices/:period/regenerate", h.RegenerateInvoice)
+		v1.GET("/invoices/:invoice_id", h.GetInvoice)
+		v1.GET("/invoices/:invoice_id/pdf", h.DownloadInvoice)
+	}
+}
+
+// GenerateInvoice generates a driver's invoice for a month that has ended
+func (h *InvoiceHandler) GenerateInvoice(c *gin.Context) {
+	var req types.GenerateInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+	req.DriverID = c.Param("driver_id")
+
+	invoice, err := h.invoices.GenerateInvoice(c.Request.Context(), &req)
+	if err != nil {
+		h.respondError(c, err, "Failed to generate invoice")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"invoice": invoice,
+	})
+}
+
+// RegenerateInvoice issues a corrected revision of a driver's invoice for
+// the month in the path
+func (h *InvoiceHandler) RegenerateInvoice(c *gin.Context) {
+	var req types.RegenerateInvoiceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+	req.DriverID = c.Param("driver_id")
+	req.Period = c.Param("period")
+
+	invoice, err := h.invoices.RegenerateInvoice(c.Request.Context(), &req)
+	if err != nil {
+		h.respondError(c, err, "Failed to regenerate invoice")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"invoice": invoice,
+	})
+}
+
+// ListDriverInvoices returns every revision of a driver's invoices, newest
+// month first
+func (h *InvoiceHandler) ListDriverInvoices(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit <= 0 {
+		limit = 50
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	driverID := c.Param("driver_id")
+	invoices, err := h.invoices.ListDriverInvoices(c.Request.Context(), driverID, limit, offset)
+	if err != nil {
+		h.logger.Error("Failed to list driver invoices", "error", err, "driver_id", driverID)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve invoices",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"invoices": invoices,
+		"count":    len(invoices),
+		"limit":    limit,
+		"offset":   offset,
+	})
+}
+
+// GetInvoice returns an invoice's totals without its PDF
+func (h *InvoiceHandler) GetInvoice(c *gin.Context) {
+	invoice, err := h.invoices.GetInvoice(c.Request.Context(), c.Param("invoice_id"))
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve invoice")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"invoice": invoice,
+	})
+}
+
+// DownloadInvoice downloads an invoice's PDF
+func (h *InvoiceHandler) DownloadInvoice(c *gin.Context) {
+	invoice, err := h.invoices.GetInvoice(c.Request.Context(), c.Param("invoice_id"))
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve invoice")
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.pdf"`, invoice.Number))
+	c.Data(http.StatusOK, "application/pdf", invoice.PDF)
+}
+
+func (h *InvoiceHandler) respondError(c *gin.Context, err error, message string) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, types.ErrInvoiceNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, types.ErrInvalidInvoice):
+		status = http.StatusBadRequest
+	case errors.Is(err, types.ErrInvoiceExists):
+		status = http.StatusConflict
+	default:
+		h.logger.Error(message, "error", err, "driver_id", c.Param("driver_id"), "invoice_id", c.Param("invoice_id"))
+	}
+
+	c.JSON(status, gin.H{
+		"error":   message,
+		"details": err.Error(),
+	})
+}
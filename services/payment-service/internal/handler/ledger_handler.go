@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rideshare-platform/services/payment-service/internal/service"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/pagination"
+)
+
+// LedgerHandler handles general ledger queries for finance. Driver payouts
+// are recorded through the gateway's admin API.
+type LedgerHandler struct {
+	ledgerService *service.LedgerService
+	logger        logger.Logger
+}
+
+// NewLedgerHandler creates a new ledger handler
+func NewLedgerHandler(ledgerService *service.LedgerService, logger logger.Logger) *LedgerHandler {
+	return &LedgerHandler{
+		ledgerService: ledgerService,
+		logger:        logger,
+	}
+}
+
+// RegisterRoutes registers general ledger routes
+func (h *LedgerHandler) RegisterRoutes(router *gin.Engine) {
+	v1 := router.Group("/api/v1/ledger")
+	{
+		v1.GET("/trial-balance", h.GetTrialBalance)
+		v1.GET("/accounts/:account/balance", h.GetAccountBalance)
+		v1.GET("/accounts/:account/postings", h.GetAccountPostings)
+		v1.GET("/entries/:entry_id", h.GetEntry)
+	}
+}
+
+// GetTrialBalance returns every account's balance, as of the as_of query
+// parameter (RFC 3339) or now
+func (h *LedgerHandler) GetTrialBalance(c *gin.Context) {
+	asOf, ok := h.asOf(c)
+	if !ok {
+		return
+	}
+
+	trial, err := h.ledgerService.TrialBalance(c.Request.Context(), asOf)
+	if err != nil {
+		h.logger.Error("Failed to build trial balance", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve trial balance",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, trial)
+}
+
+// GetAccountBalance returns an account's balance in each currency, as of the
+// as_of query parameter (RFC 3339) or now
+func (h *LedgerHandler) GetAccountBalance(c *gin.Context) {
+	asOf, ok := h.asOf(c)
+	if !ok {
+		return
+	}
+
+	account := c.Param("account")
+	balances, err := h.ledgerService.AccountBalances(c.Request.Context(), account, asOf)
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve account balance")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"account":  account,
+		"as_of":    asOf,
+		"balances": balances,
+	})
+}
+
+// GetAccountPostings returns an account's postings, newest first
+func (h *LedgerHandler) GetAccountPostings(c *gin.Context) {
+	page, err := pagination.FromQuery(c.Request.URL.Query(), types.PostingListOptions)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid pagination",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	postings, err := h.ledgerService.AccountPostings(c.Request.Context(), c.Param("account"), page)
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve account postings")
+		return
+	}
+
+	c.JSON(http.StatusOK, postings)
+}
+
+// GetEntry returns a journal entry with its postings
+func (h *LedgerHandler) GetEntry(c *gin.Context) {
+	entry, err := h.ledgerService.GetEntry(c.Request.Context(), c.Param("entry_id"))
+	if err != nil {
+		h.respondError(c, err, "Failed to retrieve journal entry")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"entry": entry,
+	})
+}
+
+func (h *LedgerHandler) asOf(c *gin.Context) (time.Time, bool) {
+	value := c.Query("as_of")
+	if value == "" {
+		return time.Now().UTC(), true
+	}
+	asOf, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid as_of, expected RFC 3339",
+			"details": err.Error(),
+		})
+		return time.Time{}, false
+	}
+	return asOf, true
+}
+
+func (h *LedgerHandler) respondError(c *gin.Context, err error, message string) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, types.ErrJournalEntryNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, types.ErrInvalidJournalEntry):
+		status = http.StatusBadRequest
+	}
+
+	c.JSON(status, gin.H{
+		"error":   message,
+		"details": err.Error(),
+	})
+}
@@ -2,12 +2,14 @@ package handler
 
 import (
 	"net/http"
-	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/rideshare-platform/services/payment-service/internal/service"
 	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/analytics"
 	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/pagination"
 )
 
 // PaymentHandler handles HTTP requests for payment operations
@@ -43,10 +45,6 @@ func (h *PaymentHandler) ProcessPayment(c *gin.Context) {
 		return
 	}
 
-	if req.Currency == "" {
-		req.Currency = "USD" // Default currency
-	}
-
 	response, err := h.paymentService.ProcessPayment(c.Request.Context(), &req)
 	if err != nil {
 		h.logger.Error("Failed to process payment", "error", err)
@@ -117,6 +115,24 @@ func (h *PaymentHandler) AddPaymentMethod(c *gin.Context) {
 	}
 }
 
+// MigrateCardTokens tokenizes card numbers stored before tokenization and
+// moves tokens issued by a previous vault to the current one
+func (h *PaymentHandler) MigrateCardTokens(c *gin.Context) {
+	result, err := h.paymentService.MigrateCardTokens(c.Request.Context())
+	if err != nil {
+		h.logger.Error("Failed to migrate card tokens", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":  "Card token migration failed",
+			"result": result,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": result,
+	})
+}
+
 // GetUserPaymentMethods retrieves payment methods for a user
 func (h *PaymentHandler) GetUserPaymentMethods(c *gin.Context) {
 	userID := c.Param("user_id")
@@ -166,7 +182,8 @@ func (h *PaymentHandler) GetPayment(c *gin.Context) {
 	})
 }
 
-// GetUserPayments retrieves payments for a user with pagination
+// GetUserPayments retrieves a page of a user's payments. Pages are read with
+// the limit, sort and cursor query parameters.
 func (h *PaymentHandler) GetUserPayments(c *gin.Context) {
 	userID := c.Param("user_id")
 	if userID == "" {
@@ -176,21 +193,16 @@ func (h *PaymentHandler) GetUserPayments(c *gin.Context) {
 		return
 	}
 
-	// Parse pagination parameters
-	limitStr := c.DefaultQuery("limit", "20")
-	offsetStr := c.DefaultQuery("offset", "0")
-
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil || limit <= 0 {
-		limit = 20
-	}
-
-	offset, err := strconv.Atoi(offsetStr)
-	if err != nil || offset < 0 {
-		offset = 0
+	page, err := pagination.FromQuery(c.Request.URL.Query(), types.PaymentListOptions)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid pagination",
+			"details": err.Error(),
+		})
+		return
 	}
 
-	payments, err := h.paymentService.GetUserPayments(c.Request.Context(), userID, limit, offset)
+	payments, err := h.paymentService.GetUserPayments(c.Request.Context(), userID, page)
 	if err != nil {
 		h.logger.Error("Failed to get user payments", "error", err, "user_id", userID)
 		c.JSON(http.StatusInternalServerError, gin.H{
@@ -199,12 +211,7 @@ func (h *PaymentHandler) GetUserPayments(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"payments": payments,
-		"count":    len(payments),
-		"limit":    limit,
-		"offset":   offset,
-	})
+	c.JSON(http.StatusOK, payments)
 }
 
 // GetTripPayments retrieves all payments for a trip
@@ -254,30 +261,37 @@ func (h *PaymentHandler) HealthCheck(c *gin.Context) {
 	})
 }
 
-// GetPaymentStats provides payment statistics (for admin/dashboard)
+// GetPaymentStats provides payment statistics (for admin/dashboard) for
+// payments created between the from and to query parameters, RFC3339
+// timestamps or YYYY-MM-DD dates. Stats cover today (UTC) unless a range is
+// given; since is still accepted in place of from.
 func (h *PaymentHandler) GetPaymentStats(c *gin.Context) {
-	// This would typically aggregate payment data from the database
-	// For now, return mock statistics
+	from, to := c.Query("from"), c.Query("to")
+	if from == "" {
+		from = c.Query("since")
+	}
+	if from == "" && to == "" {
+		from = time.Now().UTC().Format(time.DateOnly)
+	}
+	tr, err := analytics.ParseTimeRange(from, to, time.Now())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid time range",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	stats, err := h.paymentService.GetPaymentStats(c.Request.Context(), tr.From, tr.To)
+	if err != nil {
+		h.logger.Error("Failed to get payment stats", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve payment stats",
+		})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
-		"daily_stats": gin.H{
-			"total_payments":      1247,
-			"successful_payments": 1189,
-			"failed_payments":     58,
-			"total_amount":        "₹2,847,392.50",
-			"refunds_processed":   23,
-			"fraud_blocked":       12,
-		},
-		"payment_methods": gin.H{
-			"credit_card":    "45%",
-			"digital_wallet": "32%",
-			"debit_card":     "18%",
-			"bank_transfer":  "4%",
-			"cash":           "1%",
-		},
-		"processing_times": gin.H{
-			"average_ms": 245,
-			"p95_ms":     890,
-			"p99_ms":     1420,
-		},
+		"stats": stats,
 	})
 }
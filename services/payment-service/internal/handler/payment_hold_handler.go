@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rideshare-platform/services/payment-service/internal/service"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// PaymentHoldHandler handles HTTP requests for holds on trip fares
+type PaymentHoldHandler struct {
+	holdService *service.HoldService
+	logger      logger.Logger
+}
+
+// NewPaymentHoldHandler creates a new payment hold handler
+func NewPaymentHoldHandler(holdService *service.HoldService, logger logger.Logger) *PaymentHoldHandler {
+	return &PaymentHoldHandler{
+		holdService: holdService,
+		logger:      logger,
+	}
+}
+
+// RegisterRoutes registers payment hold routes. Holds are placed, captured
+// and released by trip-service over gRPC, so only reads are served here.
+func (h *PaymentHoldHandler) RegisterRoutes(router *gin.Engine) {
+	v1 := router.Group("/api/v1")
+	{
+		v1.GET("/trips/:trip_id/payment-holds", h.GetTripHolds)
+	}
+}
+
+// GetTripHolds returns the holds placed for a trip
+func (h *PaymentHoldHandler) GetTripHolds(c *gin.Context) {
+	tripID := c.Param("trip_id")
+
+	holds, err := h.holdService.GetTripHolds(c.Request.Context(), tripID)
+	if err != nil {
+		h.logger.Error("Failed to get payment holds", "error", err, "trip_id", tripID)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve payment holds",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"holds": holds,
+		"count": len(holds),
+	})
+}
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rideshare-platform/services/payment-service/internal/service"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/middleware"
+)
+
+// WalletHandler handles HTTP requests for the authenticated rider's wallet.
+// Ride credits are granted by trip-service over gRPC and by staff through
+// the gateway's admin API.
+type WalletHandler struct {
+	walletService *service.WalletService
+	auth          *middleware.AuthMiddleware
+	logger        logger.Logger
+}
+
+// NewWalletHandler creates a new wallet handler
+func NewWalletHandler(walletService *service.WalletService, auth *middleware.AuthMiddleware, logger logger.Logger) *WalletHandler {
+	return &WalletHandler{
+		walletService: walletService,
+		auth:          auth,
+		logger:        logger,
+	}
+}
+
+// RegisterRoutes registers wallet routes. The wallet is always the one of
+// the rider the bearer token was issued to.
+func (h *WalletHandler) RegisterRoutes(router *gin.Engine) {
+	v1 := router.Group("/api/v1", h.auth.JWTAuth(), h.auth.RequireUserType("rider"))
+	{
+		v1.GET("/wallet", h.GetWallet)
+		v1.POST("/wallet/top-ups", h.TopUp)
+		v1.POST("/payments/wallet", h.PayFare)
+	}
+}
+
+// GetWallet returns the rider's wallet balance and transaction history
+func (h *WalletHandler) GetWallet(c *gin.Context) {
+	userID, _ := middleware.GetUserID(c)
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit <= 0 {
+		limit = 50
+	}
+
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	wallet, transactions, err := h.walletService.GetWallet(c.Request.Context(), userID, limit, offset)
+	if err != nil {
+		h.logger.Error("Failed to get wallet", "error", err, "user_id", userID)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": "Failed to retrieve wallet",
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"wallet":       wallet,
+		"transactions": transactions,
+		"count":        len(transactions),
+		"limit":        limit,
+		"offset":       offset,
+	})
+}
+
+// TopUp adds money to the rider's wallet, charged to one of their payment methods
+func (h *WalletHandler) TopUp(c *gin.Context) {
+	var req types.TopUpWalletRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+	req.UserID, _ = middleware.GetUserID(c)
+
+	if req.Amount <= 0 || req.PaymentMethodID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "A positive amount and a payment method are required",
+		})
+		return
+	}
+
+	response, err := h.walletService.TopUp(c.Request.Context(), &req)
+	h.respond(c, response, err, http.StatusCreated, "Wallet top-up failed")
+}
+
+// PayFare pays a fare from the rider's wallet, with any remainder charged to
+// the given payment method
+func (h *WalletHandler) PayFare(c *gin.Context) {
+	var req types.WalletPaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request body",
+			"details": err.Error(),
+		})
+		return
+	}
+	req.UserID, _ = middleware.GetUserID(c)
+
+	if req.Amount <= 0 || req.TripID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error": "Trip and a positive amount are required",
+		})
+		return
+	}
+
+	response, err := h.walletService.PayFare(c.Request.Context(), &req)
+	h.respond(c, response, err, http.StatusOK, "Payment processing failed")
+}
+
+func (h *WalletHandler) respond(c *gin.Context, response *types.WalletResponse, err error, successStatus int, failure string) {
+	if err != nil {
+		h.logger.Error(failure, "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error": failure,
+		})
+		return
+	}
+
+	if response.Success {
+		c.JSON(successStatus, response)
+	} else {
+		c.JSON(http.StatusBadRequest, response)
+	}
+}
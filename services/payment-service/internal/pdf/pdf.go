@@ -0,0 +1,151 @@
+// Package pdf writes simple text documents as PDF. Text is set in the
+// standard Helvetica fonts with WinAnsi encoding, so documents need no
+// embedded fonts; characters outside that encoding print as '?'.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// A4 page size in points
+const (
+	A4Width  = 595.0
+	A4Height = 842.0
+)
+
+// Font is one of the standard fonts every PDF reader has
+type Font string
+
+const (
+	Helvetica     Font = "Helvetica"
+	HelveticaBold Font = "Helvetica-Bold"
+)
+
+var fontResources = map[Font]string{
+	Helvetica:     "F1",
+	HelveticaBold: "F2",
+}
+
+// Document is a PDF being built page by page
+type Document struct {
+	title string
+	pages []*Page
+}
+
+// New creates an empty document with the given title
+func New(title string) *Document {
+	return &Document{title: title}
+}
+
+// Page is an A4 page. Coordinates are in points from the bottom-left corner.
+type Page struct {
+	content bytes.Buffer
+}
+
+// AddPage appends a blank page to the document
+func (d *Document) AddPage() *Page {
+	page := &Page{}
+	d.pages = append(d.pages, page)
+	return page
+}
+
+// Text draws text with its baseline starting at x, y
+func (p *Page) Text(x, y float64, font Font, size float64, text string) {
+	fmt.Fprintf(&p.content, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", fontResources[font], size, x, y, escape(text))
+}
+
+// Line draws a line from x1, y1 to x2, y2
+func (p *Page) Line(x1, y1, x2, y2 float64) {
+	fmt.Fprintf(&p.content, "%.2f %.2f m %.2f %.2f l 0.5 w S\n", x1, y1, x2, y2)
+}
+
+// WriteTo writes the document as a PDF file. A document without pages gets
+// one blank page.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	pages := d.pages
+	if len(pages) == 0 {
+		pages = []*Page{{}}
+	}
+
+	// Objects 1-4 are the catalog, page tree, fonts and info; each page is
+	// followed by its content stream
+	var objects []string
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	objects = append(objects,
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)),
+		fmt.Sprintf("<< /F1 %s /F2 %s >>", fontObject(Helvetica), fontObject(HelveticaBold)),
+		fmt.Sprintf("<< /Title (%s) /Producer (rideshare-platform) >>", escape(d.title)),
+	)
+	for i, page := range pages {
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font 3 0 R >> /Contents %d 0 R >>",
+				A4Width, A4Height, 6+2*i),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", page.content.Len(), page.content.String()),
+		)
+	}
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 4 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	return out.WriteTo(w)
+}
+
+// Bytes returns the document as a PDF file
+func (d *Document) Bytes() []byte {
+	var out bytes.Buffer
+	d.WriteTo(&out)
+	return out.Bytes()
+}
+
+func fontObject(font Font) string {
+	return fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding /WinAnsiEncoding >>", font)
+}
+
+// escape encodes text as a PDF string in WinAnsi encoding
+func escape(text string) string {
+	var out strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			out.WriteByte('\\')
+			out.WriteRune(r)
+		case r >= 0x20 && r < 0x7f:
+			out.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			// WinAnsi matches Latin-1 here; octal escapes keep the
+			// string ASCII
+			fmt.Fprintf(&out, "\\%03o", r)
+		case r == 0x202f || r == 0x2009:
+			// Narrow and thin spaces separate digit groups in some locales
+			out.WriteString("\\240")
+		case r == '€':
+			out.WriteString("\\200")
+		case r == '’':
+			out.WriteString("\\222")
+		case r == '–':
+			out.WriteString("\\226")
+		default:
+			out.WriteByte('?')
+		}
+	}
+	return out.String()
+}
@@ -0,0 +1,47 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_CrossReferencesPointAtObjects(t *testing.T) {
+	doc := New("Statement (March)")
+	doc.AddPage().Text(56, 780, HelveticaBold, 18, "Résumé – 1\u202f234,50 €")
+	page := doc.AddPage()
+	page.Text(56, 780, Helvetica, 10, `back\slash (parens)`)
+	page.Line(56, 770, 539, 770)
+	out := doc.Bytes()
+
+	assert.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(out, []byte("%%EOF\n")))
+	assert.Contains(t, string(out), "/Count 2")
+	assert.Contains(t, string(out), `(R\351sum\351 \226 1\240234,50 \200)`)
+	assert.Contains(t, string(out), `(back\\slash \(parens\))`)
+	assert.Contains(t, string(out), `/Title (Statement \(March\))`)
+
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(out)
+	require.NotNil(t, startxref)
+	xref, err := strconv.Atoi(string(startxref[1]))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(out[xref:], []byte("xref\n")))
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n \n`).FindAllSubmatch(out[xref:], -1)
+	require.Len(t, entries, 8)
+	for i, entry := range entries {
+		offset, err := strconv.Atoi(string(entry[1]))
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(out[offset:], []byte(fmt.Sprintf("%d 0 obj\n", i+1))), "object %d", i+1)
+	}
+}
+
+func TestDocument_WithoutPagesHasBlankPage(t *testing.T) {
+	out := New("Empty").Bytes()
+	assert.Contains(t, string(out), "/Count 1")
+}
@@ -0,0 +1,368 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/pagination"
+)
+
+// ChargebackRepository defines the interface for chargeback cases raised by
+// the payment provider and the evidence submitted against them
+type ChargebackRepository interface {
+	CreateChargeback(ctx context.Context, chargeback *types.Chargeback) error
+	GetChargeback(ctx context.Context, chargebackID string) (*types.Chargeback, error)
+	GetChargebackByProviderID(ctx context.Context, providerDisputeID string) (*types.Chargeback, error)
+	// ListChargebacks returns chargebacks in status, all of them when status
+	// is empty, a page at a time
+	ListChargebacks(ctx context.Context, status types.ChargebackStatus, page pagination.Request) ([]*types.Chargeback, error)
+	// GetOpenChargebacksByDriver returns the driver's chargebacks that are
+	// still being decided
+	GetOpenChargebacksByDriver(ctx context.Context, driverID string) ([]*types.Chargeback, error)
+	UpdateChargeback(ctx context.Context, chargeback *types.Chargeback) error
+	AddEvidence(ctx context.Context, evidence *types.ChargebackEvidence) error
+	GetEvidence(ctx context.Context, chargebackID string) ([]*types.ChargebackEvidence, error)
+}
+
+// PostgreSQLChargebackRepository implements ChargebackRepository using PostgreSQL
+type PostgreSQLChargebackRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+// NewPostgreSQLChargebackRepository creates a new PostgreSQL chargeback repository
+func NewPostgreSQLChargebackRepository(db *sql.DB, logger logger.Logger) *PostgreSQLChargebackRepository {
+	return &PostgreSQLChargebackRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+const chargebackColumns = `
+	id, provider_dispute_id, payment_id, trip_id, user_id, driver_id, amount, currency, reason,
+	status, evidence_due_by, notes, resolved_by, created_at, updated_at, resolved_at
+`
+
+func (r *PostgreSQLChargebackRepository) CreateChargeback(ctx context.Context, chargeback *types.Chargeback) error {
+	if chargeback.ID == "" {
+		chargeback.ID = uuid.New().String()
+	}
+	if chargeback.CreatedAt.IsZero() {
+		chargeback.CreatedAt = time.Now()
+	}
+	chargeback.UpdatedAt = chargeback.CreatedAt
+
+	query := `INSERT INTO chargebacks (` + chargebackColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		chargeback.ID, chargeback.ProviderDisputeID, chargeback.PaymentID, chargeback.TripID,
+		chargeback.UserID, chargeback.DriverID, chargeback.Amount, chargeback.Currency, chargeback.Reason,
+		chargeback.Status, chargeback.EvidenceDueBy, chargeback.Notes, chargeback.ResolvedBy,
+		chargeback.CreatedAt, chargeback.UpdatedAt, chargeback.ResolvedAt,
+	)
+	return err
+}
+
+func (r *PostgreSQLChargebackRepository) GetChargeback(ctx context.Context, chargebackID string) (*types.Chargeback, error) {
+	return r.getOne(ctx, `SELECT `+chargebackColumns+` FROM chargebacks WHERE id = $1`, chargebackID)
+}
+
+func (r *PostgreSQLChargebackRepository) GetChargebackByProviderID(ctx context.Context, providerDisputeID string) (*types.Chargeback, error) {
+	return r.getOne(ctx, `SELECT `+chargebackColumns+` FROM chargebacks WHERE provider_dispute_id = $1`, providerDisputeID)
+}
+
+func (r *PostgreSQLChargebackRepository) getOne(ctx context.Context, query, id string) (*types.Chargeback, error) {
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	chargebacks, err := r.scanChargebacks(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(chargebacks) == 0 {
+		return nil, fmt.Errorf("%w: %s", types.ErrChargebackNotFound, id)
+	}
+
+	return chargebacks[0], nil
+}
+
+func (r *PostgreSQLChargebackRepository) ListChargebacks(ctx context.Context, status types.ChargebackStatus, page pagination.Request) ([]*types.Chargeback, error) {
+	condition, args := "($1 = '' OR status = $1)", []interface{}{status}
+	if keyset, keysetArgs := page.Keyset("created_at", "id", len(args)+1); keyset != "" {
+		condition += " AND " + keyset
+		args = append(args, keysetArgs...)
+	}
+	args = append(args, page.FetchLimit())
+
+	query := fmt.Sprintf(`SELECT `+chargebackColumns+` FROM chargebacks WHERE %s
+		ORDER BY %s LIMIT $%d`, condition, page.OrderBy("created_at", "id"), len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanChargebacks(rows)
+}
+
+func (r *PostgreSQLChargebackRepository) GetOpenChargebacksByDriver(ctx context.Context, driverID string) ([]*types.Chargeback, error) {
+	query := `SELECT ` + chargebackColumns + ` FROM chargebacks WHERE driver_id = $1 AND status IN ($2, $3)
+		ORDER BY created_at ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, driverID, types.ChargebackStatusNeedsResponse, types.ChargebackStatusUnderReview)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanChargebacks(rows)
+}
+
+func (r *PostgreSQLChargebackRepository) UpdateChargeback(ctx context.Context, chargeback *types.Chargeback) error {
+	chargeback.UpdatedAt = time.Now()
+
+	query := `
+		UPDATE chargebacks
+		SET status = $1, evidence_due_by = $2, notes = $3, resolved_by = $4, updated_at = $5, resolved_at = $6
+		WHERE id = $7
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		chargeback.Status, chargeback.EvidenceDueBy, chargeback.Notes, chargeback.ResolvedBy,
+		chargeback.UpdatedAt, chargeback.ResolvedAt, chargeback.ID,
+	)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return fmt.Errorf("%w: %s", types.ErrChargebackNotFound, chargeback.ID)
+	}
+
+	return nil
+}
+
+func (r *PostgreSQLChargebackRepository) AddEvidence(ctx context.Context, evidence *types.ChargebackEvidence) error {
+	if evidence.ID == "" {
+		evidence.ID = uuid.New().String()
+	}
+	if evidence.SubmittedAt.IsZero() {
+		evidence.SubmittedAt = time.Now()
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO chargeback_evidence (id, chargeback_id, type, description, submitted_by, submitted_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, evidence.ID, evidence.ChargebackID, evidence.Type, evidence.Description, evidence.SubmittedBy, evidence.SubmittedAt)
+	return err
+}
+
+func (r *PostgreSQLChargebackRepository) GetEvidence(ctx context.Context, chargebackID string) ([]*types.ChargebackEvidence, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, chargeback_id, type, description, submitted_by, submitted_at
+		FROM chargeback_evidence WHERE chargeback_id = $1
+		ORDER BY submitted_at ASC
+	`, chargebackID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var evidence []*types.ChargebackEvidence
+	for rows.Next() {
+		var item types.ChargebackEvidence
+		if err := rows.Scan(&item.ID, &item.ChargebackID, &item.Type, &item.Description, &item.SubmittedBy, &item.SubmittedAt); err != nil {
+			return nil, err
+		}
+		evidence = append(evidence, &item)
+	}
+
+	return evidence, rows.Err()
+}
+
+func (r *PostgreSQLChargebackRepository) scanChargebacks(rows *sql.Rows) ([]*types.Chargeback, error) {
+	var chargebacks []*types.Chargeback
+
+	for rows.Next() {
+		var chargeback types.Chargeback
+		var tripID, userID, driverID, reason, notes, resolvedBy sql.NullString
+
+		err := rows.Scan(
+			&chargeback.ID, &chargeback.ProviderDisputeID, &chargeback.PaymentID, &tripID,
+			&userID, &driverID, &chargeback.Amount, &chargeback.Currency, &reason,
+			&chargeback.Status, &chargeback.EvidenceDueBy, &notes, &resolvedBy,
+			&chargeback.CreatedAt, &chargeback.UpdatedAt, &chargeback.ResolvedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		chargeback.TripID = tripID.String
+		chargeback.UserID = userID.String
+		chargeback.DriverID = driverID.String
+		chargeback.Reason = reason.String
+		chargeback.Notes = notes.String
+		chargeback.ResolvedBy = resolvedBy.String
+
+		chargebacks = append(chargebacks, &chargeback)
+	}
+
+	return chargebacks, rows.Err()
+}
+
+// MockChargebackRepository provides an in-memory implementation for testing
+type MockChargebackRepository struct {
+	chargebacks map[string]*types.Chargeback
+	evidence    map[string][]*types.ChargebackEvidence
+	mutex       sync.RWMutex
+}
+
+// NewMockChargebackRepository creates a new mock chargeback repository
+func NewMockChargebackRepository() *MockChargebackRepository {
+	return &MockChargebackRepository{
+		chargebacks: make(map[string]*types.Chargeback),
+		evidence:    make(map[string][]*types.ChargebackEvidence),
+	}
+}
+
+func (m *MockChargebackRepository) CreateChargeback(ctx context.Context, chargeback *types.Chargeback) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if chargeback.ID == "" {
+		chargeback.ID = uuid.New().String()
+	}
+	if chargeback.CreatedAt.IsZero() {
+		chargeback.CreatedAt = time.Now()
+	}
+	chargeback.UpdatedAt = chargeback.CreatedAt
+
+	for _, existing := range m.chargebacks {
+		if existing.ProviderDisputeID == chargeback.ProviderDisputeID {
+			return fmt.Errorf("chargeback for provider dispute %s already exists", chargeback.ProviderDisputeID)
+		}
+	}
+
+	stored := *chargeback
+	stored.Evidence = nil
+	m.chargebacks[chargeback.ID] = &stored
+	return nil
+}
+
+func (m *MockChargebackRepository) GetChargeback(ctx context.Context, chargebackID string) (*types.Chargeback, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	chargeback, exists := m.chargebacks[chargebackID]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", types.ErrChargebackNotFound, chargebackID)
+	}
+
+	found := *chargeback
+	return &found, nil
+}
+
+func (m *MockChargebackRepository) GetChargebackByProviderID(ctx context.Context, providerDisputeID string) (*types.Chargeback, error) {
+	matching := m.matching(func(chargeback *types.Chargeback) bool {
+		return chargeback.ProviderDisputeID == providerDisputeID
+	})
+	if len(matching) == 0 {
+		return nil, fmt.Errorf("%w: %s", types.ErrChargebackNotFound, providerDisputeID)
+	}
+	return matching[0], nil
+}
+
+func (m *MockChargebackRepository) ListChargebacks(ctx context.Context, status types.ChargebackStatus, page pagination.Request) ([]*types.Chargeback, error) {
+	matching := m.matching(func(chargeback *types.Chargeback) bool {
+		return (status == "" || chargeback.Status == status) &&
+			(page.After == nil || page.Compare(types.ChargebackSortKey(chargeback), *page.After) > 0)
+	})
+	sort.Slice(matching, func(i, j int) bool {
+		return page.Compare(types.ChargebackSortKey(matching[i]), types.ChargebackSortKey(matching[j])) < 0
+	})
+
+	return matching[:min(page.FetchLimit(), len(matching))], nil
+}
+
+func (m *MockChargebackRepository) GetOpenChargebacksByDriver(ctx context.Context, driverID string) ([]*types.Chargeback, error) {
+	return m.matching(func(chargeback *types.Chargeback) bool {
+		return chargeback.DriverID == driverID && chargeback.Status.Open()
+	}), nil
+}
+
+// matching returns copies of the chargebacks that match, oldest first
+func (m *MockChargebackRepository) matching(match func(*types.Chargeback) bool) []*types.Chargeback {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var chargebacks []*types.Chargeback
+	for _, chargeback := range m.chargebacks {
+		if match(chargeback) {
+			found := *chargeback
+			chargebacks = append(chargebacks, &found)
+		}
+	}
+
+	sort.Slice(chargebacks, func(i, j int) bool {
+		if !chargebacks[i].CreatedAt.Equal(chargebacks[j].CreatedAt) {
+			return chargebacks[i].CreatedAt.Before(chargebacks[j].CreatedAt)
+		}
+		return chargebacks[i].ID < chargebacks[j].ID
+	})
+
+	return chargebacks
+}
+
+func (m *MockChargebackRepository) UpdateChargeback(ctx context.Context, chargeback *types.Chargeback) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, exists := m.chargebacks[chargeback.ID]; !exists {
+		return fmt.Errorf("%w: %s", types.ErrChargebackNotFound, chargeback.ID)
+	}
+
+	chargeback.UpdatedAt = time.Now()
+	stored := *chargeback
+	stored.Evidence = nil
+	m.chargebacks[chargeback.ID] = &stored
+	return nil
+}
+
+func (m *MockChargebackRepository) AddEvidence(ctx context.Context, evidence *types.ChargebackEvidence) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if evidence.ID == "" {
+		evidence.ID = uuid.New().String()
+	}
+	if evidence.SubmittedAt.IsZero() {
+		evidence.SubmittedAt = time.Now()
+	}
+
+	stored := *evidence
+	m.evidence[evidence.ChargebackID] = append(m.evidence[evidence.ChargebackID], &stored)
+	return nil
+}
+
+func (m *MockChargebackRepository) GetEvidence(ctx context.Context, chargebackID string) ([]*types.ChargebackEvidence, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	evidence := make([]*types.ChargebackEvidence, 0, len(m.evidence[chargebackID]))
+	for _, item := range m.evidence[chargebackID] {
+		found := *item
+		evidence = append(evidence, &found)
+	}
+	return evidence, nil
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// DriverEarningRepository defines the interface for the tips and incentives
+// paid to drivers
+type DriverEarningRepository interface {
+	CreateEarning(ctx context.Context, earning *types.DriverEarning) error
+	// GetDriverEarnings returns the driver's earnings earned in [from, to),
+	// oldest first
+	GetDriverEarnings(ctx context.Context, driverID string, from, to time.Time) ([]*types.DriverEarning, error)
+}
+
+// PostgreSQLDriverEarningRepository implements DriverEarningRepository using PostgreSQL
+type PostgreSQLDriverEarningRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+// NewPostgreSQLDriverEarningRepository creates a new PostgreSQL driver earning repository
+func NewPostgreSQLDriverEarningRepository(db *sql.DB, logger logger.Logger) *PostgreSQLDriverEarningRepository {
+	return &PostgreSQLDriverEarningRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *PostgreSQLDriverEarningRepository) CreateEarning(ctx context.Context, earning *types.DriverEarning) error {
+	if earning.ID == "" {
+		earning.ID = uuid.New().String()
+	}
+	if earning.CreatedAt.IsZero() {
+		earning.CreatedAt = time.Now()
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO driver_earnings (id, driver_id, trip_id, type, amount, currency, description, earned_at, created_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7, $8, $9)
+	`, earning.ID, earning.DriverID, earning.TripID, earning.Type, earning.Amount, earning.Currency,
+		earning.Description, earning.EarnedAt, earning.CreatedAt)
+	return err
+}
+
+func (r *PostgreSQLDriverEarningRepository) GetDriverEarnings(ctx context.Context, driverID string, from, to time.Time) ([]*types.DriverEarning, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, driver_id, COALESCE(trip_id, ''), type, amount, currency, COALESCE(description, ''), earned_at, created_at
+		FROM driver_earnings WHERE driver_id = $1 AND earned_at >= $2 AND earned_at < $3
+		ORDER BY earned_at ASC, id ASC
+	`, driverID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var earnings []*types.DriverEarning
+	for rows.Next() {
+		var earning types.DriverEarning
+		err := rows.Scan(
+			&earning.ID, &earning.DriverID, &earning.TripID, &earning.Type, &earning.Amount,
+			&earning.Currency, &earning.Description, &earning.EarnedAt, &earning.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		earnings = append(earnings, &earning)
+	}
+
+	return earnings, rows.Err()
+}
+
+// MockDriverEarningRepository provides an in-memory implementation for testing
+type MockDriverEarningRepository struct {
+	earnings []*types.DriverEarning
+	mutex    sync.RWMutex
+}
+
+// NewMockDriverEarningRepository creates a new mock driver earning repository
+func NewMockDriverEarningRepository() *MockDriverEarningRepository {
+	return &MockDriverEarningRepository{}
+}
+
+func (m *MockDriverEarningRepository) CreateEarning(ctx context.Context, earning *types.DriverEarning) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if earning.ID == "" {
+		earning.ID = uuid.New().String()
+	}
+	if earning.CreatedAt.IsZero() {
+		earning.CreatedAt = time.Now()
+	}
+
+	stored := *earning
+	m.earnings = append(m.earnings, &stored)
+	return nil
+}
+
+func (m *MockDriverEarningRepository) GetDriverEarnings(ctx context.Context, driverID string, from, to time.Time) ([]*types.DriverEarning, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var earnings []*types.DriverEarning
+	for _, earning := range m.earnings {
+		if earning.DriverID == driverID && !earning.EarnedAt.Before(from) && earning.EarnedAt.Before(to) {
+			found := *earning
+			earnings = append(earnings, &found)
+		}
+	}
+
+	sort.Slice(earnings, func(i, j int) bool {
+		if !earnings[i].EarnedAt.Equal(earnings[j].EarnedAt) {
+			return earnings[i].EarnedAt.Before(earnings[j].EarnedAt)
+		}
+		return earnings[i].ID < earnings[j].ID
+	})
+
+	return earnings, nil
+}
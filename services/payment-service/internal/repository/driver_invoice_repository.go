@@ -0,0 +1,328 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// DriverInvoiceRepository defines the interface for drivers' monthly
+// invoices and their rendered PDFs
+type DriverInvoiceRepository interface {
+	// CreateInvoice stores a month's first invoice. It returns
+	// types.ErrInvoiceExists if the driver already has one for the month.
+	CreateInvoice(ctx context.Context, invoice *types.DriverInvoice) error
+	// SupersedeInvoice marks current superseded and stores next in its place,
+	// in one transaction. It returns types.ErrInvoiceExists if current was
+	// superseded in the meantime.
+	SupersedeInvoice(ctx context.Context, current, next *types.DriverInvoice) error
+	// GetInvoice returns an invoice with its PDF
+	GetInvoice(ctx context.Context, invoiceID string) (*types.DriverInvoice, error)
+	// GetIssuedInvoice returns the invoice in force for the driver's month,
+	// without its PDF
+	GetIssuedInvoice(ctx context.Context, driverID, period string) (*types.DriverInvoice, error)
+	// ListDriverInvoices returns every revision of the driver's invoices,
+	// newest month and revision first, without their PDFs
+	ListDriverInvoices(ctx context.Context, driverID string, limit, offset int) ([]*types.DriverInvoice, error)
+}
+
+// PostgreSQLDriverInvoiceRepository implements DriverInvoiceRepository using PostgreSQL
+type PostgreSQLDriverInvoiceRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+// NewPostgreSQLDriverInvoiceRepository creates a new PostgreSQL driver invoice repository
+func NewPostgreSQLDriverInvoiceRepository(db *sql.DB, logger logger.Logger) *PostgreSQLDriverInvoiceRepository {
+	return &PostgreSQLDriverInvoiceRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+const driverInvoiceColumns = `id, number, driver_id, period, timezone, locale, revision, status, commission_rate,
+	trips_completed, totals, COALESCE(correction_reason, ''), COALESCE(supersedes_id, ''), generated_by,
+	generated_at, superseded_at`
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+func (r *PostgreSQLDriverInvoiceRepository) CreateInvoice(ctx context.Context, invoice *types.DriverInvoice) error {
+	return insertInvoice(ctx, r.db, invoice)
+}
+
+func (r *PostgreSQLDriverInvoiceRepository) SupersedeInvoice(ctx context.Context, current, next *types.DriverInvoice) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	supersededAt := time.Now()
+	result, err := tx.ExecContext(ctx, `
+		UPDATE driver_invoices SET status = $2, superseded_at = $3
+		WHERE id = $1 AND status = $4
+	`, current.ID, types.InvoiceStatusSuperseded, supersededAt, types.InvoiceStatusIssued)
+	if err != nil {
+		return err
+	}
+	if updated, err := result.RowsAffected(); err != nil {
+		return err
+	} else if updated == 0 {
+		return fmt.Errorf("%w: revision %d was already superseded", types.ErrInvoiceExists, current.Revision)
+	}
+
+	if err := insertInvoice(ctx, tx, next); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	current.Status = types.InvoiceStatusSuperseded
+	current.SupersededAt = &supersededAt
+	return nil
+}
+
+func insertInvoice(ctx context.Context, db execer, invoice *types.DriverInvoice) error {
+	if invoice.ID == "" {
+		invoice.ID = uuid.New().String()
+	}
+	if invoice.GeneratedAt.IsZero() {
+		invoice.GeneratedAt = time.Now()
+	}
+	totals, err := json.Marshal(invoice.Totals)
+	if err != nil {
+		return err
+	}
+
+	result, err := db.ExecContext(ctx, `
+		INSERT INTO driver_invoices (id, number, driver_id, period, timezone, locale, revision, status,
+			commission_rate, trips_completed, totals, correction_reason, supersedes_id, generated_by, generated_at, pdf)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NULLIF($12, ''), NULLIF($13, ''), $14, $15, $16)
+		ON CONFLICT DO NOTHING
+	`, invoice.ID, invoice.Number, invoice.DriverID, invoice.Period, invoice.Timezone, invoice.Locale,
+		invoice.Revision, invoice.Status, invoice.CommissionRate, invoice.TripsCompleted, totals,
+		invoice.CorrectionReason, invoice.SupersedesID, invoice.GeneratedBy, invoice.GeneratedAt, invoice.PDF)
+	if err != nil {
+		return err
+	}
+	if inserted, err := result.RowsAffected(); err != nil {
+		return err
+	} else if inserted == 0 {
+		return types.ErrInvoiceExists
+	}
+	return nil
+}
+
+func (r *PostgreSQLDriverInvoiceRepository) GetInvoice(ctx context.Context, invoiceID string) (*types.DriverInvoice, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+driverInvoiceColumns+`, pdf FROM driver_invoices WHERE id = $1`, invoiceID)
+
+	var invoice types.DriverInvoice
+	var totals []byte
+	err := row.Scan(
+		&invoice.ID, &invoice.Number, &invoice.DriverID, &invoice.Period, &invoice.Timezone, &invoice.Locale,
+		&invoice.Revision, &invoice.Status, &invoice.CommissionRate, &invoice.TripsCompleted, &totals,
+		&invoice.CorrectionReason, &invoice.SupersedesID, &invoice.GeneratedBy, &invoice.GeneratedAt,
+		&invoice.SupersededAt, &invoice.PDF,
+	)
+	if err == sql.ErrNoRows {
+		return nil, types.ErrInvoiceNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(totals, &invoice.Totals); err != nil {
+		return nil, err
+	}
+	return &invoice, nil
+}
+
+func (r *PostgreSQLDriverInvoiceRepository) GetIssuedInvoice(ctx context.Context, driverID, period string) (*types.DriverInvoice, error) {
+	invoices, err := r.queryInvoices(ctx, `
+		SELECT `+driverInvoiceColumns+` FROM driver_invoices
+		WHERE driver_id = $1 AND period = $2 AND status = $3
+	`, driverID, period, types.InvoiceStatusIssued)
+	if err != nil {
+		return nil, err
+	}
+	if len(invoices) == 0 {
+		return nil, types.ErrInvoiceNotFound
+	}
+	return invoices[0], nil
+}
+
+func (r *PostgreSQLDriverInvoiceRepository) ListDriverInvoices(ctx context.Context, driverID string, limit, offset int) ([]*types.DriverInvoice, error) {
+	return r.queryInvoices(ctx, `
+		SELECT `+driverInvoiceColumns+` FROM driver_invoices WHERE driver_id = $1
+		ORDER BY period DESC, revision DESC LIMIT $2 OFFSET $3
+	`, driverID, limit, offset)
+}
+
+func (r *PostgreSQLDriverInvoiceRepository) queryInvoices(ctx context.Context, query string, args ...interface{}) ([]*types.DriverInvoice, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var invoices []*types.DriverInvoice
+	for rows.Next() {
+		var invoice types.DriverInvoice
+		var totals []byte
+		err := rows.Scan(
+			&invoice.ID, &invoice.Number, &invoice.DriverID, &invoice.Period, &invoice.Timezone, &invoice.Locale,
+			&invoice.Revision, &invoice.Status, &invoice.CommissionRate, &invoice.TripsCompleted, &totals,
+			&invoice.CorrectionReason, &invoice.SupersedesID, &invoice.GeneratedBy, &invoice.GeneratedAt,
+			&invoice.SupersededAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(totals, &invoice.Totals); err != nil {
+			return nil, err
+		}
+		invoices = append(invoices, &invoice)
+	}
+
+	return invoices, rows.Err()
+}
+
+// MockDriverInvoiceRepository provides an in-memory implementation for testing
+type MockDriverInvoiceRepository struct {
+	invoices map[string]*types.DriverInvoice
+	mutex    sync.RWMutex
+}
+
+// NewMockDriverInvoiceRepository creates a new mock driver invoice repository
+func NewMockDriverInvoiceRepository() *MockDriverInvoiceRepository {
+	return &MockDriverInvoiceRepository{
+		invoices: make(map[string]*types.DriverInvoice),
+	}
+}
+
+func (m *MockDriverInvoiceRepository) CreateInvoice(ctx context.Context, invoice *types.DriverInvoice) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.insert(invoice)
+}
+
+func (m *MockDriverInvoiceRepository) SupersedeInvoice(ctx context.Context, current, next *types.DriverInvoice) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	stored, exists := m.invoices[current.ID]
+	if !exists || stored.Status != types.InvoiceStatusIssued {
+		return fmt.Errorf("%w: revision %d was already superseded", types.ErrInvoiceExists, current.Revision)
+	}
+
+	supersededAt := time.Now()
+	stored.Status = types.InvoiceStatusSuperseded
+	stored.SupersededAt = &supersededAt
+	if err := m.insert(next); err != nil {
+		stored.Status = types.InvoiceStatusIssued
+		stored.SupersededAt = nil
+		return err
+	}
+	current.Status = types.InvoiceStatusSuperseded
+	current.SupersededAt = &supersededAt
+	return nil
+}
+
+// insert stores an invoice, holding the write lock
+func (m *MockDriverInvoiceRepository) insert(invoice *types.DriverInvoice) error {
+	for _, stored := range m.invoices {
+		if stored.DriverID == invoice.DriverID && stored.Period == invoice.Period &&
+			(stored.Status == types.InvoiceStatusIssued || stored.Revision == invoice.Revision) {
+			return types.ErrInvoiceExists
+		}
+	}
+
+	if invoice.ID == "" {
+		invoice.ID = uuid.New().String()
+	}
+	if invoice.GeneratedAt.IsZero() {
+		invoice.GeneratedAt = time.Now()
+	}
+	stored := copyInvoice(invoice, true)
+	m.invoices[stored.ID] = stored
+	return nil
+}
+
+func (m *MockDriverInvoiceRepository) GetInvoice(ctx context.Context, invoiceID string) (*types.DriverInvoice, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	invoice, exists := m.invoices[invoiceID]
+	if !exists {
+		return nil, types.ErrInvoiceNotFound
+	}
+	return copyInvoice(invoice, true), nil
+}
+
+func (m *MockDriverInvoiceRepository) GetIssuedInvoice(ctx context.Context, driverID, period string) (*types.DriverInvoice, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	for _, invoice := range m.invoices {
+		if invoice.DriverID == driverID && invoice.Period == period && invoice.Status == types.InvoiceStatusIssued {
+			return copyInvoice(invoice, false), nil
+		}
+	}
+	return nil, types.ErrInvoiceNotFound
+}
+
+func (m *MockDriverInvoiceRepository) ListDriverInvoices(ctx context.Context, driverID string, limit, offset int) ([]*types.DriverInvoice, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var invoices []*types.DriverInvoice
+	for _, invoice := range m.invoices {
+		if invoice.DriverID == driverID {
+			invoices = append(invoices, copyInvoice(invoice, false))
+		}
+	}
+
+	sort.Slice(invoices, func(i, j int) bool {
+		if invoices[i].Period != invoices[j].Period {
+			return invoices[i].Period > invoices[j].Period
+		}
+		return invoices[i].Revision > invoices[j].Revision
+	})
+
+	if offset >= len(invoices) {
+		return []*types.DriverInvoice{}, nil
+	}
+	end := offset + limit
+	if end > len(invoices) {
+		end = len(invoices)
+	}
+	return invoices[offset:end], nil
+}
+
+func copyInvoice(invoice *types.DriverInvoice, withPDF bool) *types.DriverInvoice {
+	copied := *invoice
+	copied.Totals = make([]*types.CurrencyEarnings, len(invoice.Totals))
+	for i, total := range invoice.Totals {
+		totalCopy := *total
+		copied.Totals[i] = &totalCopy
+	}
+	if invoice.SupersededAt != nil {
+		supersededAt := *invoice.SupersededAt
+		copied.SupersededAt = &supersededAt
+	}
+	copied.PDF = nil
+	if withPDF {
+		copied.PDF = append([]byte(nil), invoice.PDF...)
+	}
+	return &copied
+}
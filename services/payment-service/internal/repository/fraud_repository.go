@@ -0,0 +1,429 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// FraudRuleRepository defines the interface for fraud rule storage
+type FraudRuleRepository interface {
+	GetActiveRules(ctx context.Context) ([]*types.FraudRule, error)
+	SaveRule(ctx context.Context, rule *types.FraudRule) error
+	SetRuleEnabled(ctx context.Context, ruleID string, enabled bool) error
+	DeleteRule(ctx context.Context, ruleID string) error
+}
+
+// FraudReviewRepository defines the interface for the fraud review queue
+type FraudReviewRepository interface {
+	CreateReview(ctx context.Context, review *types.FraudReview) error
+	GetReview(ctx context.Context, reviewID string) (*types.FraudReview, error)
+	ListReviews(ctx context.Context, status types.FraudReviewStatus, limit, offset int) ([]*types.FraudReview, error)
+	// UpdateReviewStatus resolves a pending review. The status only changes
+	// while the review is still pending, so two reviewers cannot both
+	// decide it; the second gets ErrFraudReviewResolved.
+	UpdateReviewStatus(ctx context.Context, reviewID string, status types.FraudReviewStatus, reviewedBy, notes string) error
+}
+
+// PostgreSQLFraudRuleRepository implements FraudRuleRepository using PostgreSQL
+type PostgreSQLFraudRuleRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+// NewPostgreSQLFraudRuleRepository creates a new PostgreSQL fraud rule repository
+func NewPostgreSQLFraudRuleRepository(db *sql.DB, logger logger.Logger) *PostgreSQLFraudRuleRepository {
+	return &PostgreSQLFraudRuleRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *PostgreSQLFraudRuleRepository) GetActiveRules(ctx context.Context) ([]*types.FraudRule, error) {
+	query := `
+		SELECT id, name, description, expression, score, action, priority, enabled, created_at, updated_at
+		FROM fraud_rules WHERE enabled = true
+		ORDER BY priority DESC, name ASC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rules []*types.FraudRule
+	for rows.Next() {
+		var rule types.FraudRule
+		if err := rows.Scan(
+			&rule.ID, &rule.Name, &rule.Description, &rule.Expression, &rule.Score,
+			&rule.Action, &rule.Priority, &rule.Enabled, &rule.CreatedAt, &rule.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		rules = append(rules, &rule)
+	}
+
+	return rules, rows.Err()
+}
+
+func (r *PostgreSQLFraudRuleRepository) SaveRule(ctx context.Context, rule *types.FraudRule) error {
+	if rule.ID == "" {
+		rule.ID = uuid.New().String()
+		rule.CreatedAt = time.Now()
+	}
+	rule.UpdatedAt = time.Now()
+
+	query := `
+		INSERT INTO fraud_rules (
+			id, name, description, expression, score, action, priority, enabled, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (id) DO UPDATE SET
+			name = EXCLUDED.name, description = EXCLUDED.description,
+			expression = EXCLUDED.expression, score = EXCLUDED.score,
+			action = EXCLUDED.action, priority = EXCLUDED.priority,
+			enabled = EXCLUDED.enabled, updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		rule.ID, rule.Name, rule.Description, rule.Expression, rule.Score,
+		rule.Action, rule.Priority, rule.Enabled, rule.CreatedAt, rule.UpdatedAt,
+	)
+	return err
+}
+
+func (r *PostgreSQLFraudRuleRepository) SetRuleEnabled(ctx context.Context, ruleID string, enabled bool) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE fraud_rules SET enabled = $1, updated_at = $2 WHERE id = $3`,
+		enabled, time.Now(), ruleID,
+	)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return fmt.Errorf("%w: %s", types.ErrFraudRuleNotFound, ruleID)
+	}
+
+	return nil
+}
+
+func (r *PostgreSQLFraudRuleRepository) DeleteRule(ctx context.Context, ruleID string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM fraud_rules WHERE id = $1`, ruleID)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return fmt.Errorf("%w: %s", types.ErrFraudRuleNotFound, ruleID)
+	}
+
+	return nil
+}
+
+// PostgreSQLFraudReviewRepository implements FraudReviewRepository using PostgreSQL
+type PostgreSQLFraudReviewRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+// NewPostgreSQLFraudReviewRepository creates a new PostgreSQL fraud review repository
+func NewPostgreSQLFraudReviewRepository(db *sql.DB, logger logger.Logger) *PostgreSQLFraudReviewRepository {
+	return &PostgreSQLFraudReviewRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *PostgreSQLFraudReviewRepository) CreateReview(ctx context.Context, review *types.FraudReview) error {
+	reasonsJSON, _ := json.Marshal(review.Reasons)
+	rulesJSON, _ := json.Marshal(review.MatchedRules)
+	scoresJSON, _ := json.Marshal(review.Scores)
+
+	query := `
+		INSERT INTO fraud_reviews (
+			id, payment_id, user_id, amount, currency, risk_level, risk_score,
+			reasons, matched_rules, scores, status, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		review.ID, review.PaymentID, review.UserID, review.Amount, review.Currency,
+		review.RiskLevel, review.RiskScore, reasonsJSON, rulesJSON, scoresJSON,
+		review.Status, review.CreatedAt,
+	)
+	return err
+}
+
+func (r *PostgreSQLFraudReviewRepository) GetReview(ctx context.Context, reviewID string) (*types.FraudReview, error) {
+	query := `
+		SELECT id, payment_id, user_id, amount, currency, risk_level, risk_score,
+			   reasons, matched_rules, scores, status, reviewed_by, notes, reviewed_at, created_at
+		FROM fraud_reviews WHERE id = $1
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, reviewID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews, err := r.scanReviews(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(reviews) == 0 {
+		return nil, fmt.Errorf("%w: %s", types.ErrFraudReviewNotFound, reviewID)
+	}
+
+	return reviews[0], nil
+}
+
+func (r *PostgreSQLFraudReviewRepository) ListReviews(ctx context.Context, status types.FraudReviewStatus, limit, offset int) ([]*types.FraudReview, error) {
+	query := `
+		SELECT id, payment_id, user_id, amount, currency, risk_level, risk_score,
+			   reasons, matched_rules, scores, status, reviewed_by, notes, reviewed_at, created_at
+		FROM fraud_reviews WHERE status = $1
+		ORDER BY risk_score DESC, created_at ASC LIMIT $2 OFFSET $3
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanReviews(rows)
+}
+
+func (r *PostgreSQLFraudReviewRepository) UpdateReviewStatus(ctx context.Context, reviewID string, status types.FraudReviewStatus, reviewedBy, notes string) error {
+	query := `
+		UPDATE fraud_reviews
+		SET status = $1, reviewed_by = $2, notes = $3, reviewed_at = $4
+		WHERE id = $5 AND status = $6
+	`
+
+	result, err := r.db.ExecContext(ctx, query, status, reviewedBy, notes, time.Now(), reviewID, types.FraudReviewPending)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected > 0 {
+		return nil
+	}
+
+	var exists bool
+	if err := r.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM fraud_reviews WHERE id = $1)`, reviewID).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("%w: %s", types.ErrFraudReviewNotFound, reviewID)
+	}
+	return fmt.Errorf("%w: %s", types.ErrFraudReviewResolved, reviewID)
+}
+
+func (r *PostgreSQLFraudReviewRepository) scanReviews(rows *sql.Rows) ([]*types.FraudReview, error) {
+	var reviews []*types.FraudReview
+
+	for rows.Next() {
+		var review types.FraudReview
+		var reasonsJSON, rulesJSON, scoresJSON []byte
+		var reviewedBy, notes sql.NullString
+
+		err := rows.Scan(
+			&review.ID, &review.PaymentID, &review.UserID, &review.Amount, &review.Currency,
+			&review.RiskLevel, &review.RiskScore, &reasonsJSON, &rulesJSON, &scoresJSON,
+			&review.Status, &reviewedBy, &notes, &review.ReviewedAt, &review.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		review.ReviewedBy = reviewedBy.String
+		review.Notes = notes.String
+
+		// Unmarshal JSON fields
+		if len(reasonsJSON) > 0 {
+			json.Unmarshal(reasonsJSON, &review.Reasons)
+		}
+		if len(rulesJSON) > 0 {
+			json.Unmarshal(rulesJSON, &review.MatchedRules)
+		}
+		if len(scoresJSON) > 0 {
+			json.Unmarshal(scoresJSON, &review.Scores)
+		}
+
+		reviews = append(reviews, &review)
+	}
+
+	return reviews, rows.Err()
+}
+
+// MockFraudRuleRepository provides an in-memory implementation for testing
+type MockFraudRuleRepository struct {
+	rules map[string]*types.FraudRule
+	mutex sync.RWMutex
+}
+
+// NewMockFraudRuleRepository creates a new mock fraud rule repository
+func NewMockFraudRuleRepository() *MockFraudRuleRepository {
+	return &MockFraudRuleRepository{
+		rules: make(map[string]*types.FraudRule),
+	}
+}
+
+func (m *MockFraudRuleRepository) GetActiveRules(ctx context.Context) ([]*types.FraudRule, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var rules []*types.FraudRule
+	for _, rule := range m.rules {
+		if rule.Enabled {
+			rules = append(rules, rule)
+		}
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		if rules[i].Priority != rules[j].Priority {
+			return rules[i].Priority > rules[j].Priority
+		}
+		return rules[i].Name < rules[j].Name
+	})
+
+	return rules, nil
+}
+
+func (m *MockFraudRuleRepository) SaveRule(ctx context.Context, rule *types.FraudRule) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if rule.ID == "" {
+		rule.ID = uuid.New().String()
+		rule.CreatedAt = time.Now()
+	}
+	rule.UpdatedAt = time.Now()
+
+	m.rules[rule.ID] = rule
+	return nil
+}
+
+func (m *MockFraudRuleRepository) DeleteRule(ctx context.Context, ruleID string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, exists := m.rules[ruleID]; !exists {
+		return fmt.Errorf("%w: %s", types.ErrFraudRuleNotFound, ruleID)
+	}
+
+	delete(m.rules, ruleID)
+	return nil
+}
+
+func (m *MockFraudRuleRepository) SetRuleEnabled(ctx context.Context, ruleID string, enabled bool) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	rule, exists := m.rules[ruleID]
+	if !exists {
+		return fmt.Errorf("%w: %s", types.ErrFraudRuleNotFound, ruleID)
+	}
+
+	rule.Enabled = enabled
+	rule.UpdatedAt = time.Now()
+	return nil
+}
+
+// MockFraudReviewRepository provides an in-memory implementation for testing
+type MockFraudReviewRepository struct {
+	reviews map[string]*types.FraudReview
+	mutex   sync.RWMutex
+}
+
+// NewMockFraudReviewRepository creates a new mock fraud review repository
+func NewMockFraudReviewRepository() *MockFraudReviewRepository {
+	return &MockFraudReviewRepository{
+		reviews: make(map[string]*types.FraudReview),
+	}
+}
+
+func (m *MockFraudReviewRepository) CreateReview(ctx context.Context, review *types.FraudReview) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if review.ID == "" {
+		review.ID = uuid.New().String()
+	}
+	if review.CreatedAt.IsZero() {
+		review.CreatedAt = time.Now()
+	}
+
+	m.reviews[review.ID] = review
+	return nil
+}
+
+func (m *MockFraudReviewRepository) GetReview(ctx context.Context, reviewID string) (*types.FraudReview, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	review, exists := m.reviews[reviewID]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", types.ErrFraudReviewNotFound, reviewID)
+	}
+
+	return review, nil
+}
+
+func (m *MockFraudReviewRepository) ListReviews(ctx context.Context, status types.FraudReviewStatus, limit, offset int) ([]*types.FraudReview, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var matching []*types.FraudReview
+	for _, review := range m.reviews {
+		if review.Status == status {
+			matching = append(matching, review)
+		}
+	}
+
+	sort.Slice(matching, func(i, j int) bool {
+		if matching[i].RiskScore != matching[j].RiskScore {
+			return matching[i].RiskScore > matching[j].RiskScore
+		}
+		return matching[i].CreatedAt.Before(matching[j].CreatedAt)
+	})
+
+	if offset >= len(matching) {
+		return []*types.FraudReview{}, nil
+	}
+	end := offset + limit
+	if end > len(matching) {
+		end = len(matching)
+	}
+
+	return matching[offset:end], nil
+}
+
+func (m *MockFraudReviewRepository) UpdateReviewStatus(ctx context.Context, reviewID string, status types.FraudReviewStatus, reviewedBy, notes string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	review, exists := m.reviews[reviewID]
+	if !exists {
+		return fmt.Errorf("%w: %s", types.ErrFraudReviewNotFound, reviewID)
+	}
+	if review.Status != types.FraudReviewPending {
+		return fmt.Errorf("%w: %s is %s", types.ErrFraudReviewResolved, reviewID, review.Status)
+	}
+
+	now := time.Now()
+	review.Status = status
+	review.ReviewedBy = reviewedBy
+	review.Notes = notes
+	review.ReviewedAt = &now
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+func TestFraudReviewRepository_UpdateReviewStatusOnlyResolvesPendingReviews(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	repo := NewPostgreSQLFraudReviewRepository(db, *logger.NewLogger("error", "test"))
+
+	// The first decision moves the review out of pending
+	mock.ExpectExec(`UPDATE fraud_reviews .* WHERE id = \$5 AND status = \$6`).
+		WithArgs(types.FraudReviewApproved, "analyst-1", "", sqlmock.AnyArg(), "review-1", types.FraudReviewPending).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, repo.UpdateReviewStatus(ctx, "review-1", types.FraudReviewApproved, "analyst-1", ""))
+
+	// A second decision matches no pending row and is refused
+	mock.ExpectExec(`UPDATE fraud_reviews .* WHERE id = \$5 AND status = \$6`).
+		WithArgs(types.FraudReviewRejected, "analyst-2", "", sqlmock.AnyArg(), "review-1", types.FraudReviewPending).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT EXISTS`).WithArgs("review-1").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	err = repo.UpdateReviewStatus(ctx, "review-1", types.FraudReviewRejected, "analyst-2", "")
+	assert.ErrorIs(t, err, types.ErrFraudReviewResolved)
+
+	mock.ExpectExec(`UPDATE fraud_reviews`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(`SELECT EXISTS`).WithArgs("missing").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	err = repo.UpdateReviewStatus(ctx, "missing", types.FraudReviewRejected, "analyst-2", "")
+	assert.ErrorIs(t, err, types.ErrFraudReviewNotFound)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
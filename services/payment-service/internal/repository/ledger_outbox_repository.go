@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// LedgerOutboxRepository keeps journal entries that failed to post, keyed by
+// their idempotency key, until a retry posts them
+type LedgerOutboxRepository interface {
+	// Enqueue stores an entry to post later. An entry already queued under
+	// the same idempotency key is kept as it is.
+	Enqueue(ctx context.Context, pending *types.PendingJournalEntry) error
+	// GetDue returns up to limit queued entries whose next attempt is due by
+	// now, the longest waiting first
+	GetDue(ctx context.Context, now time.Time, limit int) ([]*types.PendingJournalEntry, error)
+	// Reschedule records another failed attempt and when to try again
+	Reschedule(ctx context.Context, idempotencyKey, lastError string, next time.Time) error
+	// Delete removes an entry once it is in the ledger
+	Delete(ctx context.Context, idempotencyKey string) error
+}
+
+// PostgreSQLLedgerOutboxRepository implements LedgerOutboxRepository using
+// PostgreSQL
+type PostgreSQLLedgerOutboxRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+// NewPostgreSQLLedgerOutboxRepository creates a new PostgreSQL ledger outbox
+// repository
+func NewPostgreSQLLedgerOutboxRepository(db *sql.DB, logger logger.Logger) *PostgreSQLLedgerOutboxRepository {
+	return &PostgreSQLLedgerOutboxRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *PostgreSQLLedgerOutboxRepository) Enqueue(ctx context.Context, pending *types.PendingJournalEntry) error {
+	entry, err := json.Marshal(pending.Entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal journal entry: %w", err)
+	}
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO ledger_outbox (idempotency_key, entry, attempts, last_error, created_at, next_attempt_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (idempotency_key) DO NOTHING
+	`, pending.Entry.IdempotencyKey, entry, pending.Attempts, pending.LastError, pending.CreatedAt, pending.NextAttemptAt)
+	return err
+}
+
+func (r *PostgreSQLLedgerOutboxRepository) GetDue(ctx context.Context, now time.Time, limit int) ([]*types.PendingJournalEntry, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT entry, attempts, COALESCE(last_error, ''), created_at, next_attempt_at
+		FROM ledger_outbox WHERE next_attempt_at <= $1
+		ORDER BY next_attempt_at ASC, idempotency_key ASC LIMIT $2
+	`, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var due []*types.PendingJournalEntry
+	for rows.Next() {
+		var (
+			pending types.PendingJournalEntry
+			entry   []byte
+		)
+		if err := rows.Scan(&entry, &pending.Attempts, &pending.LastError, &pending.CreatedAt, &pending.NextAttemptAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(entry, &pending.Entry); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal journal entry: %w", err)
+		}
+		due = append(due, &pending)
+	}
+	return due, rows.Err()
+}
+
+func (r *PostgreSQLLedgerOutboxRepository) Reschedule(ctx context.Context, idempotencyKey, lastError string, next time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE ledger_outbox SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3
+		WHERE idempotency_key = $1
+	`, idempotencyKey, lastError, next)
+	return err
+}
+
+func (r *PostgreSQLLedgerOutboxRepository) Delete(ctx context.Context, idempotencyKey string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM ledger_outbox WHERE idempotency_key = $1`, idempotencyKey)
+	return err
+}
+
+// MockLedgerOutboxRepository provides an in-memory implementation for testing
+type MockLedgerOutboxRepository struct {
+	pending map[string]*types.PendingJournalEntry
+	mutex   sync.RWMutex
+}
+
+// NewMockLedgerOutboxRepository creates a new mock ledger outbox repository
+func NewMockLedgerOutboxRepository() *MockLedgerOutboxRepository {
+	return &MockLedgerOutboxRepository{
+		pending: make(map[string]*types.PendingJournalEntry),
+	}
+}
+
+func (m *MockLedgerOutboxRepository) Enqueue(ctx context.Context, pending *types.PendingJournalEntry) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, queued := m.pending[pending.Entry.IdempotencyKey]; !queued {
+		m.pending[pending.Entry.IdempotencyKey] = copyPendingJournalEntry(pending)
+	}
+	return nil
+}
+
+func (m *MockLedgerOutboxRepository) GetDue(ctx context.Context, now time.Time, limit int) ([]*types.PendingJournalEntry, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var due []*types.PendingJournalEntry
+	for _, pending := range m.pending {
+		if !pending.NextAttemptAt.After(now) {
+			due = append(due, copyPendingJournalEntry(pending))
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].NextAttemptAt.Equal(due[j].NextAttemptAt) {
+			return due[i].NextAttemptAt.Before(due[j].NextAttemptAt)
+		}
+		return due[i].Entry.IdempotencyKey < due[j].Entry.IdempotencyKey
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+func (m *MockLedgerOutboxRepository) Reschedule(ctx context.Context, idempotencyKey, lastError string, next time.Time) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if pending, ok := m.pending[idempotencyKey]; ok {
+		pending.Attempts++
+		pending.LastError = lastError
+		pending.NextAttemptAt = next
+	}
+	return nil
+}
+
+func (m *MockLedgerOutboxRepository) Delete(ctx context.Context, idempotencyKey string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	delete(m.pending, idempotencyKey)
+	return nil
+}
+
+// copyPendingJournalEntry copies a queued entry down to its postings, as
+// storing and loading it would
+func copyPendingJournalEntry(pending *types.PendingJournalEntry) *types.PendingJournalEntry {
+	copied := *pending
+	entry := *pending.Entry
+	entry.Postings = make([]*types.Posting, 0, len(pending.Entry.Postings))
+	for _, posting := range pending.Entry.Postings {
+		p := *posting
+		entry.Postings = append(entry.Postings, &p)
+	}
+	copied.Entry = &entry
+	return &copied
+}
@@ -0,0 +1,576 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/export"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/pagination"
+)
+
+// LedgerRepository defines the interface for the general ledger
+type LedgerRepository interface {
+	// PostEntry stores a journal entry and its postings atomically, opening
+	// the accounts it posts to. It fails with types.ErrUnbalancedJournalEntry
+	// or types.ErrInvalidJournalEntry without storing anything, and with
+	// types.ErrDuplicateJournalEntry if the entry's idempotency key was
+	// already posted.
+	PostEntry(ctx context.Context, entry *types.JournalEntry) error
+	// PostPayout posts entry, which pays amount out of account in currency,
+	// if the account's balance covers it. The account stays locked from
+	// reading its balance until the entry is posted, so concurrent payouts
+	// from any replica cannot overdraw it. It returns the balance before the
+	// payout, and fails with types.ErrPayoutExceedsPayable without posting
+	// when the amount is larger.
+	PostPayout(ctx context.Context, entry *types.JournalEntry, account, currency string, amount int64) (int64, error)
+	GetEntry(ctx context.Context, id string) (*types.JournalEntry, error)
+	// GetAccountBalances returns an account's balance in each currency it
+	// holds, counting postings made up to asOf
+	GetAccountBalances(ctx context.Context, account string, asOf time.Time) ([]*types.AccountBalance, error)
+	// GetAccountPostings pages through an account's postings, newest first
+	GetAccountPostings(ctx context.Context, account string, page pagination.Request) ([]*types.Posting, error)
+	// GetAllBalances returns every account's balances as of asOf, in account
+	// and currency order
+	GetAllBalances(ctx context.Context, asOf time.Time) ([]*types.AccountBalance, error)
+	// GetEntriesSince returns up to limit journal entries, without their
+	// postings, posted after the watermark in (posted_at, id) order, for the
+	// warehouse export
+	GetEntriesSince(ctx context.Context, since export.Watermark, limit int) ([]*types.JournalEntry, error)
+	// GetPostingsSince returns up to limit postings made after the watermark,
+	// in (posted_at, id) order, for the warehouse export
+	GetPostingsSince(ctx context.Context, since export.Watermark, limit int) ([]*types.Posting, error)
+}
+
+// prepareJournalEntry checks the ledger's invariants, that the entry has at
+// least two non-zero postings to typed accounts summing to zero in each
+// currency, and assigns IDs and timestamps. It returns the types of the
+// accounts posted to.
+func prepareJournalEntry(entry *types.JournalEntry) (map[string]types.LedgerAccountType, error) {
+	if entry.IdempotencyKey == "" {
+		return nil, fmt.Errorf("%w: idempotency key is required", types.ErrInvalidJournalEntry)
+	}
+	if len(entry.Postings) < 2 {
+		return nil, fmt.Errorf("%w: an entry needs at least two postings", types.ErrInvalidJournalEntry)
+	}
+
+	accounts := make(map[string]types.LedgerAccountType)
+	sums := make(map[string]int64)
+	for _, posting := range entry.Postings {
+		if posting.Amount == 0 {
+			return nil, fmt.Errorf("%w: posting to %s has no amount", types.ErrInvalidJournalEntry, posting.Account)
+		}
+		if posting.Currency == "" {
+			return nil, fmt.Errorf("%w: posting to %s has no currency", types.ErrInvalidJournalEntry, posting.Account)
+		}
+		accountType, err := types.LedgerAccountTypeOf(posting.Account)
+		if err != nil {
+			return nil, err
+		}
+		accounts[posting.Account] = accountType
+		sums[posting.Currency] += posting.Amount
+	}
+	for code, sum := range sums {
+		if sum != 0 {
+			return nil, fmt.Errorf("%w: %s postings are off by %d", types.ErrUnbalancedJournalEntry, code, sum)
+		}
+	}
+
+	if entry.ID == "" {
+		entry.ID = uuid.New().String()
+	}
+	if entry.PostedAt.IsZero() {
+		entry.PostedAt = time.Now()
+	}
+	for _, posting := range entry.Postings {
+		if posting.ID == "" {
+			posting.ID = uuid.New().String()
+		}
+		posting.JournalEntryID = entry.ID
+		posting.PostedAt = entry.PostedAt
+	}
+	return accounts, nil
+}
+
+// PostgreSQLLedgerRepository implements LedgerRepository using PostgreSQL
+type PostgreSQLLedgerRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+// NewPostgreSQLLedgerRepository creates a new PostgreSQL ledger repository
+func NewPostgreSQLLedgerRepository(db *sql.DB, logger logger.Logger) *PostgreSQLLedgerRepository {
+	return &PostgreSQLLedgerRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *PostgreSQLLedgerRepository) PostEntry(ctx context.Context, entry *types.JournalEntry) error {
+	accounts, err := prepareJournalEntry(entry)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := insertJournalEntry(ctx, tx, entry, accounts); err != nil {
+		return err
+	}
+	// The journal_entry_balanced trigger checks the entry again on commit
+	return tx.Commit()
+}
+
+func (r *PostgreSQLLedgerRepository) PostPayout(ctx context.Context, entry *types.JournalEntry, account, currency string, amount int64) (int64, error) {
+	accounts, err := prepareJournalEntry(entry)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	// Payouts lock the account row, so one waiting on another reads the
+	// balance after the first has posted. Accounts are opened by the first
+	// entry posting to them; one that does not exist is owed nothing.
+	var accountType types.LedgerAccountType
+	err = tx.QueryRowContext(ctx, `
+		SELECT type FROM ledger_accounts WHERE code = $1 FOR UPDATE
+	`, account).Scan(&accountType)
+	if err == sql.ErrNoRows {
+		return 0, types.ErrPayoutExceedsPayable
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var debits, credits int64
+	err = tx.QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(amount) FILTER (WHERE amount > 0), 0),
+			   COALESCE(-SUM(amount) FILTER (WHERE amount < 0), 0)
+		FROM journal_postings WHERE account = $1 AND currency = $2
+	`, account, currency).Scan(&debits, &credits)
+	if err != nil {
+		return 0, err
+	}
+	payable := types.NewAccountBalance(account, accountType, currency, debits, credits).Balance
+	if amount > payable {
+		return payable, types.ErrPayoutExceedsPayable
+	}
+
+	if err := insertJournalEntry(ctx, tx, entry, accounts); err != nil {
+		return payable, err
+	}
+	return payable, tx.Commit()
+}
+
+// insertJournalEntry stores a prepared entry in tx, opening the accounts it
+// posts to
+func insertJournalEntry(ctx context.Context, tx *sql.Tx, entry *types.JournalEntry, accounts map[string]types.LedgerAccountType) error {
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO journal_entries (id, kind, idempotency_key, reference, description, posted_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (idempotency_key) DO NOTHING
+	`, entry.ID, entry.Kind, entry.IdempotencyKey, entry.Reference, entry.Description, entry.PostedAt)
+	if err != nil {
+		return err
+	}
+	if inserted, err := result.RowsAffected(); err != nil {
+		return err
+	} else if inserted == 0 {
+		return fmt.Errorf("%w: %s", types.ErrDuplicateJournalEntry, entry.IdempotencyKey)
+	}
+
+	for code, accountType := range accounts {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO ledger_accounts (code, type, created_at) VALUES ($1, $2, $3)
+			ON CONFLICT (code) DO NOTHING
+		`, code, accountType, entry.PostedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, posting := range entry.Postings {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO journal_postings (id, journal_entry_id, account, amount, currency, posted_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, posting.ID, posting.JournalEntryID, posting.Account, posting.Amount, posting.Currency, posting.PostedAt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *PostgreSQLLedgerRepository) GetEntry(ctx context.Context, id string) (*types.JournalEntry, error) {
+	var entry types.JournalEntry
+	err := r.db.QueryRowContext(ctx, `
+		SELECT id, kind, idempotency_key, COALESCE(reference, ''), COALESCE(description, ''), posted_at
+		FROM journal_entries WHERE id = $1
+	`, id).Scan(&entry.ID, &entry.Kind, &entry.IdempotencyKey, &entry.Reference, &entry.Description, &entry.PostedAt)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %s", types.ErrJournalEntryNotFound, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, journal_entry_id, account, amount, currency, posted_at
+		FROM journal_postings WHERE journal_entry_id = $1
+		ORDER BY amount DESC, id
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entry.Postings, err = scanPostings(rows)
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *PostgreSQLLedgerRepository) GetAccountBalances(ctx context.Context, account string, asOf time.Time) ([]*types.AccountBalance, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT p.account, a.type, p.currency,
+			   COALESCE(SUM(p.amount) FILTER (WHERE p.amount > 0), 0),
+			   COALESCE(-SUM(p.amount) FILTER (WHERE p.amount < 0), 0)
+		FROM journal_postings p JOIN ledger_accounts a ON a.code = p.account
+		WHERE p.account = $1 AND p.posted_at <= $2
+		GROUP BY p.account, a.type, p.currency
+		ORDER BY p.currency
+	`, account, asOf)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanBalances(rows)
+}
+
+func (r *PostgreSQLLedgerRepository) GetAccountPostings(ctx context.Context, account string, page pagination.Request) ([]*types.Posting, error) {
+	condition, args := "account = $1", []interface{}{account}
+	if keyset, keysetArgs := page.Keyset("posted_at", "id", len(args)+1); keyset != "" {
+		condition += " AND " + keyset
+		args = append(args, keysetArgs...)
+	}
+	args = append(args, page.FetchLimit())
+
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(`
+		SELECT id, journal_entry_id, account, amount, currency, posted_at
+		FROM journal_postings WHERE %s
+		ORDER BY %s LIMIT $%d
+	`, condition, page.OrderBy("posted_at", "id"), len(args)), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanPostings(rows)
+}
+
+func (r *PostgreSQLLedgerRepository) GetAllBalances(ctx context.Context, asOf time.Time) ([]*types.AccountBalance, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT p.account, a.type, p.currency,
+			   COALESCE(SUM(p.amount) FILTER (WHERE p.amount > 0), 0),
+			   COALESCE(-SUM(p.amount) FILTER (WHERE p.amount < 0), 0)
+		FROM journal_postings p JOIN ledger_accounts a ON a.code = p.account
+		WHERE p.posted_at <= $1
+		GROUP BY p.account, a.type, p.currency
+		ORDER BY p.account, p.currency
+	`, asOf)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanBalances(rows)
+}
+
+func (r *PostgreSQLLedgerRepository) GetEntriesSince(ctx context.Context, since export.Watermark, limit int) ([]*types.JournalEntry, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, kind, idempotency_key, COALESCE(reference, ''), COALESCE(description, ''), posted_at
+		FROM journal_entries WHERE (posted_at, id) > ($1, $2)
+		ORDER BY posted_at ASC, id ASC LIMIT $3
+	`, since.UpdatedAt, since.ID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*types.JournalEntry
+	for rows.Next() {
+		var entry types.JournalEntry
+		if err := rows.Scan(&entry.ID, &entry.Kind, &entry.IdempotencyKey, &entry.Reference, &entry.Description, &entry.PostedAt); err != nil {
+			return nil, err
+		}
+		entries = append(entries, &entry)
+	}
+	return entries, rows.Err()
+}
+
+func (r *PostgreSQLLedgerRepository) GetPostingsSince(ctx context.Context, since export.Watermark, limit int) ([]*types.Posting, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, journal_entry_id, account, amount, currency, posted_at
+		FROM journal_postings WHERE (posted_at, id) > ($1, $2)
+		ORDER BY posted_at ASC, id ASC LIMIT $3
+	`, since.UpdatedAt, since.ID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanPostings(rows)
+}
+
+func scanPostings(rows *sql.Rows) ([]*types.Posting, error) {
+	var postings []*types.Posting
+	for rows.Next() {
+		var posting types.Posting
+		if err := rows.Scan(&posting.ID, &posting.JournalEntryID, &posting.Account, &posting.Amount, &posting.Currency, &posting.PostedAt); err != nil {
+			return nil, err
+		}
+		postings = append(postings, &posting)
+	}
+	return postings, rows.Err()
+}
+
+func scanBalances(rows *sql.Rows) ([]*types.AccountBalance, error) {
+	var balances []*types.AccountBalance
+	for rows.Next() {
+		var (
+			account, code  string
+			accountType    types.LedgerAccountType
+			debits, credit int64
+		)
+		if err := rows.Scan(&account, &accountType, &code, &debits, &credit); err != nil {
+			return nil, err
+		}
+		balances = append(balances, types.NewAccountBalance(account, accountType, code, debits, credit))
+	}
+	return balances, rows.Err()
+}
+
+// MockLedgerRepository provides an in-memory implementation for testing
+type MockLedgerRepository struct {
+	entries  map[string]*types.JournalEntry
+	keys     map[string]bool
+	accounts map[string]*types.LedgerAccount
+	postings []*types.Posting
+	mutex    sync.RWMutex
+}
+
+// NewMockLedgerRepository creates a new mock ledger repository
+func NewMockLedgerRepository() *MockLedgerRepository {
+	return &MockLedgerRepository{
+		entries:  make(map[string]*types.JournalEntry),
+		keys:     make(map[string]bool),
+		accounts: make(map[string]*types.LedgerAccount),
+	}
+}
+
+func (m *MockLedgerRepository) PostEntry(ctx context.Context, entry *types.JournalEntry) error {
+	accounts, err := prepareJournalEntry(entry)
+	if err != nil {
+		return err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.post(entry, accounts)
+}
+
+func (m *MockLedgerRepository) PostPayout(ctx context.Context, entry *types.JournalEntry, account, currency string, amount int64) (int64, error) {
+	accounts, err := prepareJournalEntry(entry)
+	if err != nil {
+		return 0, err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var payable int64
+	for _, balance := range m.balances(func(posting *types.Posting) bool {
+		return posting.Account == account && posting.Currency == currency
+	}) {
+		payable = balance.Balance
+	}
+	if amount > payable {
+		return payable, types.ErrPayoutExceedsPayable
+	}
+	return payable, m.post(entry, accounts)
+}
+
+// post stores a prepared entry. The caller holds the write lock.
+func (m *MockLedgerRepository) post(entry *types.JournalEntry, accounts map[string]types.LedgerAccountType) error {
+	if m.keys[entry.IdempotencyKey] {
+		return fmt.Errorf("%w: %s", types.ErrDuplicateJournalEntry, entry.IdempotencyKey)
+	}
+	m.keys[entry.IdempotencyKey] = true
+
+	for code, accountType := range accounts {
+		if m.accounts[code] == nil {
+			m.accounts[code] = &types.LedgerAccount{Code: code, Type: accountType, CreatedAt: entry.PostedAt}
+		}
+	}
+	stored := *entry
+	stored.Postings = make([]*types.Posting, 0, len(entry.Postings))
+	for _, posting := range entry.Postings {
+		copied := *posting
+		stored.Postings = append(stored.Postings, &copied)
+		m.postings = append(m.postings, &copied)
+	}
+	m.entries[entry.ID] = &stored
+	return nil
+}
+
+func (m *MockLedgerRepository) GetEntry(ctx context.Context, id string) (*types.JournalEntry, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	entry, ok := m.entries[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", types.ErrJournalEntryNotFound, id)
+	}
+	copied := *entry
+	copied.Postings = make([]*types.Posting, 0, len(entry.Postings))
+	for _, posting := range entry.Postings {
+		p := *posting
+		copied.Postings = append(copied.Postings, &p)
+	}
+	sort.SliceStable(copied.Postings, func(i, j int) bool {
+		return copied.Postings[i].Amount > copied.Postings[j].Amount
+	})
+	return &copied, nil
+}
+
+func (m *MockLedgerRepository) GetAccountBalances(ctx context.Context, account string, asOf time.Time) ([]*types.AccountBalance, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.balances(func(posting *types.Posting) bool {
+		return posting.Account == account && !posting.PostedAt.After(asOf)
+	}), nil
+}
+
+func (m *MockLedgerRepository) GetAccountPostings(ctx context.Context, account string, page pagination.Request) ([]*types.Posting, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	matching := []*types.Posting{}
+	for _, posting := range m.postings {
+		if posting.Account == account && (page.After == nil || page.Compare(types.PostingSortKey(posting), *page.After) > 0) {
+			copied := *posting
+			matching = append(matching, &copied)
+		}
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		return page.Compare(types.PostingSortKey(matching[i]), types.PostingSortKey(matching[j])) < 0
+	})
+	return matching[:min(page.FetchLimit(), len(matching))], nil
+}
+
+func (m *MockLedgerRepository) GetAllBalances(ctx context.Context, asOf time.Time) ([]*types.AccountBalance, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.balances(func(posting *types.Posting) bool {
+		return !posting.PostedAt.After(asOf)
+	}), nil
+}
+
+func (m *MockLedgerRepository) GetEntriesSince(ctx context.Context, since export.Watermark, limit int) ([]*types.JournalEntry, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var matching []*types.JournalEntry
+	for _, entry := range m.entries {
+		if since.Before(entry.PostedAt, entry.ID) {
+			copied := *entry
+			copied.Postings = nil
+			matching = append(matching, &copied)
+		}
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		at := export.Watermark{UpdatedAt: matching[i].PostedAt, ID: matching[i].ID}
+		return at.Before(matching[j].PostedAt, matching[j].ID)
+	})
+	if len(matching) > limit {
+		matching = matching[:limit]
+	}
+	return matching, nil
+}
+
+func (m *MockLedgerRepository) GetPostingsSince(ctx context.Context, since export.Watermark, limit int) ([]*types.Posting, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var matching []*types.Posting
+	for _, posting := range m.postings {
+		if since.Before(posting.PostedAt, posting.ID) {
+			copied := *posting
+			matching = append(matching, &copied)
+		}
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		at := export.Watermark{UpdatedAt: matching[i].PostedAt, ID: matching[i].ID}
+		return at.Before(matching[j].PostedAt, matching[j].ID)
+	})
+	if len(matching) > limit {
+		matching = matching[:limit]
+	}
+	return matching, nil
+}
+
+// balances sums the matching postings per account and currency, in account
+// and currency order
+func (m *MockLedgerRepository) balances(match func(*types.Posting) bool) []*types.AccountBalance {
+	type key struct{ account, currency string }
+	debits := make(map[key]int64)
+	credits := make(map[key]int64)
+	var keys []key
+	for _, posting := range m.postings {
+		if !match(posting) {
+			continue
+		}
+		k := key{posting.Account, posting.Currency}
+		if _, seen := debits[k]; !seen {
+			keys = append(keys, k)
+			debits[k] = 0
+		}
+		if posting.Amount > 0 {
+			debits[k] += posting.Amount
+		} else {
+			credits[k] -= posting.Amount
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].account != keys[j].account {
+			return keys[i].account < keys[j].account
+		}
+		return keys[i].currency < keys[j].currency
+	})
+
+	balances := make([]*types.AccountBalance, 0, len(keys))
+	for _, k := range keys {
+		balances = append(balances, types.NewAccountBalance(k.account, m.accounts[k.account].Type, k.currency, debits[k], credits[k]))
+	}
+	return balances
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+func newPayoutEntry(amount int64) *types.JournalEntry {
+	return &types.JournalEntry{
+		Kind:           types.JournalPayout,
+		IdempotencyKey: "payout:tr_1",
+		Reference:      "tr_1",
+		Postings: []*types.Posting{
+			{Account: types.GLDriverPayable("driver-1"), Amount: amount, Currency: "USD"},
+			{Account: types.GLBank, Amount: -amount, Currency: "USD"},
+		},
+	}
+}
+
+func TestLedgerRepository_PostPayoutLocksTheAccount(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	repo := NewPostgreSQLLedgerRepository(db, *logger.NewLogger("error", "test"))
+	account := types.GLDriverPayable("driver-1")
+
+	// The balance is read and the payout posted under the account's row lock
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT type FROM ledger_accounts WHERE code = \$1 FOR UPDATE`).WithArgs(account).
+		WillReturnRows(sqlmock.NewRows([]string{"type"}).AddRow("liability"))
+	mock.ExpectQuery(`FROM journal_postings WHERE account = \$1 AND currency = \$2`).WithArgs(account, "USD").
+		WillReturnRows(sqlmock.NewRows([]string{"debits", "credits"}).AddRow(500, 3000))
+	mock.ExpectExec(`INSERT INTO journal_entries`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO ledger_accounts`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO ledger_accounts`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO journal_postings`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO journal_postings`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	payable, err := repo.PostPayout(ctx, newPayoutEntry(2500), account, "USD", 2500)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2500), payable)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLedgerRepository_PostPayoutRejectsOverdrafts(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	repo := NewPostgreSQLLedgerRepository(db, *logger.NewLogger("error", "test"))
+	account := types.GLDriverPayable("driver-1")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`FOR UPDATE`).WithArgs(account).
+		WillReturnRows(sqlmock.NewRows([]string{"type"}).AddRow("liability"))
+	mock.ExpectQuery(`FROM journal_postings`).WithArgs(account, "USD").
+		WillReturnRows(sqlmock.NewRows([]string{"debits", "credits"}).AddRow(500, 3000))
+	mock.ExpectRollback()
+
+	payable, err := repo.PostPayout(ctx, newPayoutEntry(2501), account, "USD", 2501)
+	assert.ErrorIs(t, err, types.ErrPayoutExceedsPayable)
+	assert.Equal(t, int64(2500), payable)
+
+	// A driver the ledger has never owed anything has no account to lock
+	mock.ExpectBegin()
+	mock.ExpectQuery(`FOR UPDATE`).WithArgs(account).WillReturnRows(sqlmock.NewRows([]string{"type"}))
+	mock.ExpectRollback()
+
+	payable, err = repo.PostPayout(ctx, newPayoutEntry(100), account, "USD", 100)
+	assert.ErrorIs(t, err, types.ErrPayoutExceedsPayable)
+	assert.Zero(t, payable)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
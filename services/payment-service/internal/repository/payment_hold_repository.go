@@ -0,0 +1,264 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// PaymentHoldRepository defines the interface for payment holds placed on
+// riders' payment methods during trips
+type PaymentHoldRepository interface {
+	CreateHold(ctx context.Context, hold *types.PaymentHold) error
+	GetHold(ctx context.Context, holdID string) (*types.PaymentHold, error)
+	// GetHoldsByTrip returns a trip's holds, oldest first
+	GetHoldsByTrip(ctx context.Context, tripID string) ([]*types.PaymentHold, error)
+	// GetActiveHoldsExpiringBefore returns the active holds that expire before
+	// cutoff, soonest first
+	GetActiveHoldsExpiringBefore(ctx context.Context, cutoff time.Time) ([]*types.PaymentHold, error)
+	UpdateHold(ctx context.Context, hold *types.PaymentHold) error
+}
+
+// PostgreSQLPaymentHoldRepository implements PaymentHoldRepository using PostgreSQL
+type PostgreSQLPaymentHoldRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+// NewPostgreSQLPaymentHoldRepository creates a new PostgreSQL payment hold repository
+func NewPostgreSQLPaymentHoldRepository(db *sql.DB, logger logger.Logger) *PostgreSQLPaymentHoldRepository {
+	return &PostgreSQLPaymentHoldRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+const paymentHoldColumns = `
+	id, trip_id, user_id, driver_id, payment_method_id, payment_method, estimated_fare, amount,
+	currency, status, authorization_id, renewals, captured_amount, released_amount, payment_id,
+	failure_reason, expires_at, created_at, updated_at, closed_at
+`
+
+func (r *PostgreSQLPaymentHoldRepository) CreateHold(ctx context.Context, hold *types.PaymentHold) error {
+	if hold.ID == "" {
+		hold.ID = uuid.New().String()
+	}
+	if hold.CreatedAt.IsZero() {
+		hold.CreatedAt = time.Now()
+	}
+	hold.UpdatedAt = hold.CreatedAt
+
+	query := `INSERT INTO payment_holds (` + paymentHoldColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		hold.ID, hold.TripID, hold.UserID, hold.DriverID, hold.PaymentMethodID, hold.PaymentMethod,
+		hold.EstimatedFare, hold.Amount, hold.Currency, hold.Status, hold.AuthorizationID, hold.Renewals,
+		hold.CapturedAmount, hold.ReleasedAmount, hold.PaymentID, hold.FailureReason,
+		hold.ExpiresAt, hold.CreatedAt, hold.UpdatedAt, hold.ClosedAt,
+	)
+	return err
+}
+
+func (r *PostgreSQLPaymentHoldRepository) GetHold(ctx context.Context, holdID string) (*types.PaymentHold, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+paymentHoldColumns+` FROM payment_holds WHERE id = $1`, holdID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	holds, err := r.scanHolds(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(holds) == 0 {
+		return nil, fmt.Errorf("%w: %s", types.ErrHoldNotFound, holdID)
+	}
+
+	return holds[0], nil
+}
+
+func (r *PostgreSQLPaymentHoldRepository) GetHoldsByTrip(ctx context.Context, tripID string) ([]*types.PaymentHold, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+paymentHoldColumns+` FROM payment_holds WHERE trip_id = $1 ORDER BY created_at ASC`, tripID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanHolds(rows)
+}
+
+func (r *PostgreSQLPaymentHoldRepository) GetActiveHoldsExpiringBefore(ctx context.Context, cutoff time.Time) ([]*types.PaymentHold, error) {
+	query := `SELECT ` + paymentHoldColumns + ` FROM payment_holds
+		WHERE status = $1 AND expires_at < $2 ORDER BY expires_at ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, types.HoldStatusActive, cutoff)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanHolds(rows)
+}
+
+func (r *PostgreSQLPaymentHoldRepository) UpdateHold(ctx context.Context, hold *types.PaymentHold) error {
+	hold.UpdatedAt = time.Now()
+
+	query := `
+		UPDATE payment_holds
+		SET status = $1, authorization_id = $2, renewals = $3, captured_amount = $4, released_amount = $5,
+			payment_id = $6, failure_reason = $7, expires_at = $8, updated_at = $9, closed_at = $10
+		WHERE id = $11
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		hold.Status, hold.AuthorizationID, hold.Renewals, hold.CapturedAmount, hold.ReleasedAmount,
+		hold.PaymentID, hold.FailureReason, hold.ExpiresAt, hold.UpdatedAt, hold.ClosedAt, hold.ID,
+	)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return fmt.Errorf("%w: %s", types.ErrHoldNotFound, hold.ID)
+	}
+
+	return nil
+}
+
+func (r *PostgreSQLPaymentHoldRepository) scanHolds(rows *sql.Rows) ([]*types.PaymentHold, error) {
+	var holds []*types.PaymentHold
+
+	for rows.Next() {
+		var hold types.PaymentHold
+		var driverID, authorizationID, paymentID, failureReason sql.NullString
+
+		err := rows.Scan(
+			&hold.ID, &hold.TripID, &hold.UserID, &driverID, &hold.PaymentMethodID, &hold.PaymentMethod,
+			&hold.EstimatedFare, &hold.Amount, &hold.Currency, &hold.Status, &authorizationID, &hold.Renewals,
+			&hold.CapturedAmount, &hold.ReleasedAmount, &paymentID, &failureReason,
+			&hold.ExpiresAt, &hold.CreatedAt, &hold.UpdatedAt, &hold.ClosedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		hold.DriverID = driverID.String
+		hold.AuthorizationID = authorizationID.String
+		hold.PaymentID = paymentID.String
+		hold.FailureReason = failureReason.String
+
+		holds = append(holds, &hold)
+	}
+
+	return holds, rows.Err()
+}
+
+// MockPaymentHoldRepository provides an in-memory implementation for testing
+type MockPaymentHoldRepository struct {
+	holds map[string]*types.PaymentHold
+	mutex sync.RWMutex
+}
+
+// NewMockPaymentHoldRepository creates a new mock payment hold repository
+func NewMockPaymentHoldRepository() *MockPaymentHoldRepository {
+	return &MockPaymentHoldRepository{
+		holds: make(map[string]*types.PaymentHold),
+	}
+}
+
+func (m *MockPaymentHoldRepository) CreateHold(ctx context.Context, hold *types.PaymentHold) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if hold.ID == "" {
+		hold.ID = uuid.New().String()
+	}
+	if hold.CreatedAt.IsZero() {
+		hold.CreatedAt = time.Now()
+	}
+	hold.UpdatedAt = hold.CreatedAt
+
+	stored := *hold
+	m.holds[hold.ID] = &stored
+	return nil
+}
+
+func (m *MockPaymentHoldRepository) GetHold(ctx context.Context, holdID string) (*types.PaymentHold, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	hold, exists := m.holds[holdID]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", types.ErrHoldNotFound, holdID)
+	}
+
+	found := *hold
+	return &found, nil
+}
+
+func (m *MockPaymentHoldRepository) GetHoldsByTrip(ctx context.Context, tripID string) ([]*types.PaymentHold, error) {
+	holds := m.matching(func(hold *types.PaymentHold) bool {
+		return hold.TripID == tripID
+	})
+
+	sort.Slice(holds, func(i, j int) bool {
+		if !holds[i].CreatedAt.Equal(holds[j].CreatedAt) {
+			return holds[i].CreatedAt.Before(holds[j].CreatedAt)
+		}
+		return holds[i].ID < holds[j].ID
+	})
+
+	return holds, nil
+}
+
+func (m *MockPaymentHoldRepository) GetActiveHoldsExpiringBefore(ctx context.Context, cutoff time.Time) ([]*types.PaymentHold, error) {
+	holds := m.matching(func(hold *types.PaymentHold) bool {
+		return hold.Status == types.HoldStatusActive && hold.ExpiresAt.Before(cutoff)
+	})
+
+	sort.Slice(holds, func(i, j int) bool {
+		if !holds[i].ExpiresAt.Equal(holds[j].ExpiresAt) {
+			return holds[i].ExpiresAt.Before(holds[j].ExpiresAt)
+		}
+		return holds[i].ID < holds[j].ID
+	})
+
+	return holds, nil
+}
+
+// matching returns copies of the holds that match
+func (m *MockPaymentHoldRepository) matching(match func(*types.PaymentHold) bool) []*types.PaymentHold {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var holds []*types.PaymentHold
+	for _, hold := range m.holds {
+		if match(hold) {
+			found := *hold
+			holds = append(holds, &found)
+		}
+	}
+
+	return holds
+}
+
+func (m *MockPaymentHoldRepository) UpdateHold(ctx context.Context, hold *types.PaymentHold) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, exists := m.holds[hold.ID]; !exists {
+		return fmt.Errorf("%w: %s", types.ErrHoldNotFound, hold.ID)
+	}
+
+	hold.UpdatedAt = time.Now()
+	stored := *hold
+	m.holds[hold.ID] = &stored
+	return nil
+}
@@ -0,0 +1,304 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	sharedcrypto "github.com/rideshare-platform/shared/crypto"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// PostgreSQLPaymentMethodRepository implements PaymentMethodRepository using
+// PostgreSQL. With a cipher set, card, bank and wallet metadata, card tokens
+// and the details blob are encrypted at rest.
+type PostgreSQLPaymentMethodRepository struct {
+	db     *sql.DB
+	cipher *sharedcrypto.Cipher
+	logger logger.Logger
+}
+
+// NewPostgreSQLPaymentMethodRepository creates a new PostgreSQL payment method repository
+func NewPostgreSQLPaymentMethodRepository(db *sql.DB, logger logger.Logger) *PostgreSQLPaymentMethodRepository {
+	return &PostgreSQLPaymentMethodRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// SetCipher encrypts payment method metadata at rest. Without a cipher it is
+// stored in plaintext.
+func (r *PostgreSQLPaymentMethodRepository) SetCipher(cipher *sharedcrypto.Cipher) {
+	r.cipher = cipher
+}
+
+const paymentMethodColumns = `
+	id, user_id, type, is_default, fingerprint, expiry_date, last_four_digits, bank_name,
+	wallet_provider, details, card_token, token_vault, card_brand, created_at, updated_at
+`
+
+func (r *PostgreSQLPaymentMethodRepository) CreatePaymentMethod(ctx context.Context, method *types.PaymentMethodDetails) error {
+	if method.ID == "" {
+		method.ID = uuid.New().String()
+	}
+	now := time.Now()
+	method.CreatedAt = now
+	method.UpdatedAt = now
+
+	sealed, details, err := r.seal(ctx, method)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO payment_methods (` + paymentMethodColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`
+
+	_, err = r.db.ExecContext(ctx, query,
+		sealed.ID, sealed.UserID, sealed.Type, sealed.IsDefault, sealed.Fingerprint, sealed.ExpiryDate,
+		sealed.LastFourDigits, sealed.BankName, sealed.WalletProvider, details,
+		sealed.CardToken, sealed.TokenVault, sealed.CardBrand, sealed.CreatedAt, sealed.UpdatedAt,
+	)
+	return err
+}
+
+func (r *PostgreSQLPaymentMethodRepository) GetPaymentMethod(ctx context.Context, methodID string) (*types.PaymentMethodDetails, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+paymentMethodColumns+` FROM payment_methods WHERE id = $1`, methodID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	methods, err := r.scanMethods(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(methods) == 0 {
+		return nil, fmt.Errorf("payment method not found: %s", methodID)
+	}
+
+	return methods[0], nil
+}
+
+func (r *PostgreSQLPaymentMethodRepository) GetUserPaymentMethods(ctx context.Context, userID string) ([]*types.PaymentMethodDetails, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+paymentMethodColumns+` FROM payment_methods WHERE user_id = $1 ORDER BY created_at ASC`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanMethods(ctx, rows)
+}
+
+func (r *PostgreSQLPaymentMethodRepository) UpdatePaymentMethod(ctx context.Context, method *types.PaymentMethodDetails) error {
+	method.UpdatedAt = time.Now()
+
+	sealed, details, err := r.seal(ctx, method)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE payment_methods
+		SET is_default = $2, fingerprint = $3, expiry_date = $4, last_four_digits = $5, bank_name = $6,
+			wallet_provider = $7, details = $8, card_token = $9, token_vault = $10, card_brand = $11, updated_at = $12
+		WHERE id = $1
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		sealed.ID, sealed.IsDefault, sealed.Fingerprint, sealed.ExpiryDate, sealed.LastFourDigits, sealed.BankName,
+		sealed.WalletProvider, details, sealed.CardToken, sealed.TokenVault, sealed.CardBrand, sealed.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return fmt.Errorf("payment method not found: %s", method.ID)
+	}
+
+	return nil
+}
+
+func (r *PostgreSQLPaymentMethodRepository) DeletePaymentMethod(ctx context.Context, methodID string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM payment_methods WHERE id = $1`, methodID)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return fmt.Errorf("payment method not found: %s", methodID)
+	}
+
+	return nil
+}
+
+func (r *PostgreSQLPaymentMethodRepository) SetDefaultPaymentMethod(ctx context.Context, userID, methodID string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	if _, err := tx.ExecContext(ctx, `UPDATE payment_methods SET is_default = FALSE, updated_at = $2 WHERE user_id = $1 AND is_default`, userID, now); err != nil {
+		return err
+	}
+	result, err := tx.ExecContext(ctx, `UPDATE payment_methods SET is_default = TRUE, updated_at = $3 WHERE id = $1 AND user_id = $2`, methodID, userID, now)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return fmt.Errorf("payment method not found: %s", methodID)
+	}
+
+	return tx.Commit()
+}
+
+func (r *PostgreSQLPaymentMethodRepository) ListPaymentMethods(ctx context.Context, limit, offset int) ([]*types.PaymentMethodDetails, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+paymentMethodColumns+` FROM payment_methods ORDER BY id LIMIT $1 OFFSET $2`, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanMethods(ctx, rows)
+}
+
+// RotateBatch re-encrypts the metadata of up to limit payment methods after
+// cursor under the active master key. Methods stored before encryption was
+// enabled are encrypted.
+func (r *PostgreSQLPaymentMethodRepository) RotateBatch(ctx context.Context, cipher *sharedcrypto.Cipher, cursor string, limit int) (string, int, error) {
+	query := `
+		SELECT id, last_four_digits, bank_name, wallet_provider, card_token, details, updated_at
+		FROM payment_methods WHERE id > $1 ORDER BY id LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, cursor, limit)
+	if err != nil {
+		return "", 0, err
+	}
+	type storedMethod struct {
+		method  types.PaymentMethodDetails
+		details string
+	}
+	var methods []storedMethod
+	for rows.Next() {
+		var stored storedMethod
+		var lastFour, bankName, walletProvider, cardToken, details sql.NullString
+		if err := rows.Scan(&stored.method.ID, &lastFour, &bankName, &walletProvider, &cardToken, &details, &stored.method.UpdatedAt); err != nil {
+			rows.Close()
+			return "", 0, err
+		}
+		stored.method.LastFourDigits = lastFour.String
+		stored.method.BankName = bankName.String
+		stored.method.WalletProvider = walletProvider.String
+		stored.method.CardToken = cardToken.String
+		stored.details = details.String
+		methods = append(methods, stored)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return "", 0, err
+	}
+	if len(methods) == 0 {
+		return "", 0, nil
+	}
+
+	rotated := 0
+	for _, stored := range methods {
+		changed, err := cipher.RotateFields(ctx, &stored.method)
+		if err != nil {
+			return "", rotated, fmt.Errorf("failed to re-encrypt payment method %s: %w", stored.method.ID, err)
+		}
+		details := stored.details
+		if cipher.NeedsRotation(details) {
+			if details, err = cipher.Reencrypt(ctx, details); err != nil {
+				return "", rotated, fmt.Errorf("failed to re-encrypt payment method %s: %w", stored.method.ID, err)
+			}
+			changed = true
+		}
+		if !changed {
+			continue
+		}
+
+		// Methods updated since they were read are left for the next run
+		_, err = r.db.ExecContext(ctx, `
+			UPDATE payment_methods SET last_four_digits = $2, bank_name = $3, wallet_provider = $4, card_token = $5, details = $6
+			WHERE id = $1 AND updated_at = $7`,
+			stored.method.ID, stored.method.LastFourDigits, stored.method.BankName, stored.method.WalletProvider,
+			stored.method.CardToken, details, stored.method.UpdatedAt,
+		)
+		if err != nil {
+			return "", rotated, fmt.Errorf("failed to rotate payment method %s: %w", stored.method.ID, err)
+		}
+		rotated++
+	}
+
+	return methods[len(methods)-1].method.ID, rotated, nil
+}
+
+// seal returns a copy of the method with its encrypted fields sealed, and
+// its details encoded and sealed
+func (r *PostgreSQLPaymentMethodRepository) seal(ctx context.Context, method *types.PaymentMethodDetails) (*types.PaymentMethodDetails, string, error) {
+	sealed := *method
+	if err := r.cipher.EncryptFields(ctx, &sealed); err != nil {
+		return nil, "", fmt.Errorf("failed to encrypt payment method: %w", err)
+	}
+
+	encoded, err := json.Marshal(method.Details)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encode payment method details: %w", err)
+	}
+	details, err := r.cipher.Encrypt(ctx, string(encoded))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encrypt payment method details: %w", err)
+	}
+	return &sealed, details, nil
+}
+
+func (r *PostgreSQLPaymentMethodRepository) scanMethods(ctx context.Context, rows *sql.Rows) ([]*types.PaymentMethodDetails, error) {
+	var methods []*types.PaymentMethodDetails
+	for rows.Next() {
+		method := &types.PaymentMethodDetails{}
+		var fingerprint, lastFour, bankName, walletProvider, details, cardToken, tokenVault, cardBrand sql.NullString
+		var expiryDate sql.NullTime
+
+		err := rows.Scan(
+			&method.ID, &method.UserID, &method.Type, &method.IsDefault, &fingerprint, &expiryDate,
+			&lastFour, &bankName, &walletProvider, &details, &cardToken, &tokenVault, &cardBrand,
+			&method.CreatedAt, &method.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		method.Fingerprint = fingerprint.String
+		if expiryDate.Valid {
+			method.ExpiryDate = &expiryDate.Time
+		}
+		method.LastFourDigits = lastFour.String
+		method.BankName = bankName.String
+		method.WalletProvider = walletProvider.String
+		method.CardToken = cardToken.String
+		method.TokenVault = tokenVault.String
+		method.CardBrand = cardBrand.String
+		if err := r.cipher.DecryptFields(ctx, method); err != nil {
+			return nil, fmt.Errorf("failed to decrypt payment method %s: %w", method.ID, err)
+		}
+
+		if details.String != "" {
+			decoded, err := r.cipher.Decrypt(ctx, details.String)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decrypt payment method %s: %w", method.ID, err)
+			}
+			if err := json.Unmarshal([]byte(decoded), &method.Details); err != nil {
+				r.logger.WithError(err).Warn("Failed to decode payment method details")
+			}
+		}
+
+		methods = append(methods, method)
+	}
+
+	return methods, rows.Err()
+}
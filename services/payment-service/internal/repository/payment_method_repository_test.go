@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sharedcrypto "github.com/rideshare-platform/shared/crypto"
+	"github.com/rideshare-platform/shared/crypto/cryptotest"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+func TestPaymentMethodRepository_RotateBatch(t *testing.T) {
+	ctx := context.Background()
+	oldKey, newKey := cryptotest.NewKey(t), cryptotest.NewKey(t)
+	before := cryptotest.NewCipher(t, map[string][]byte{"k1": oldKey}, "k1", nil)
+	cipher := cryptotest.NewCipher(t, map[string][]byte{"k1": oldKey, "k2": newKey}, "k2", nil)
+
+	oldLastFour, oldToken, oldDetails := cryptotest.Seal(t, before, "4242"), cryptotest.Seal(t, before, "tok_1"), cryptotest.Seal(t, before, `{"holder":"Ada"}`)
+	current := cryptotest.Seal(t, cipher, "1881")
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	repo := NewPostgreSQLPaymentMethodRepository(db, *logger.NewLogger("error", "test"))
+
+	const selectMethods = `SELECT id, last_four_digits, bank_name, wallet_provider, card_token, details, updated_at\s+FROM payment_methods`
+	const updateMethod = `UPDATE payment_methods SET last_four_digits = \$2`
+	columns := []string{"id", "last_four_digits", "bank_name", "wallet_provider", "card_token", "details", "updated_at"}
+	updatedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	mock.ExpectQuery(selectMethods).WithArgs("", 3).WillReturnRows(
+		sqlmock.NewRows(columns).
+			// Sealed under the retired key
+			AddRow("pm-1", oldLastFour, nil, nil, oldToken, oldDetails, updatedAt).
+			// Stored before encryption was enabled
+			AddRow("pm-2", nil, "Ziraat", nil, nil, `{"iban":"TR00"}`, updatedAt).
+			// Up to date
+			AddRow("pm-3", current, nil, nil, nil, nil, updatedAt),
+	)
+	mock.ExpectExec(updateMethod).WithArgs(
+		"pm-1", cryptotest.SealedUnder{Cipher: cipher, Plaintext: "4242"}, "", "", cryptotest.SealedUnder{Cipher: cipher, Plaintext: "tok_1"}, cryptotest.SealedUnder{Cipher: cipher, Plaintext: `{"holder":"Ada"}`}, updatedAt,
+	).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(updateMethod).WithArgs(
+		"pm-2", "", cryptotest.SealedUnder{Cipher: cipher, Plaintext: "Ziraat"}, "", "", cryptotest.SealedUnder{Cipher: cipher, Plaintext: `{"iban":"TR00"}`}, updatedAt,
+	).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	next, rotated, err := repo.RotateBatch(ctx, cipher, "", 3)
+	require.NoError(t, err)
+	assert.Equal(t, "pm-3", next)
+	assert.Equal(t, 2, rotated)
+
+	// The next batch continues from the cursor and finds nothing left
+	mock.ExpectQuery(selectMethods).WithArgs("pm-3", 3).WillReturnRows(sqlmock.NewRows(columns))
+	next, rotated, err = repo.RotateBatch(ctx, cipher, "pm-3", 3)
+	require.NoError(t, err)
+	assert.Empty(t, next)
+	assert.Zero(t, rotated)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPaymentMethodRepository_RotateBatchStopsOnUnknownKey(t *testing.T) {
+	ctx := context.Background()
+	retired := cryptotest.NewCipher(t, map[string][]byte{"k0": cryptotest.NewKey(t)}, "k0", nil)
+	cipher := cryptotest.NewCipher(t, map[string][]byte{"k1": cryptotest.NewKey(t)}, "k1", nil)
+	sealed, err := retired.Encrypt(ctx, "4242")
+	require.NoError(t, err)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	repo := NewPostgreSQLPaymentMethodRepository(db, *logger.NewLogger("error", "test"))
+
+	mock.ExpectQuery(`SELECT id, last_four_digits`).WillReturnRows(
+		sqlmock.NewRows([]string{"id", "last_four_digits", "bank_name", "wallet_provider", "card_token", "details", "updated_at"}).
+			AddRow("pm-1", sealed, nil, nil, nil, nil, time.Now()),
+	)
+
+	_, rotated, err := repo.RotateBatch(ctx, cipher, "", 10)
+	assert.ErrorIs(t, err, sharedcrypto.ErrUnknownKey, "values under a dropped key are reported, not overwritten")
+	assert.Zero(t, rotated)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
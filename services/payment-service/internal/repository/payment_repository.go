@@ -5,12 +5,16 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/export"
 	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/pagination"
 )
 
 // PaymentRepository defines the interface for payment data operations
@@ -19,8 +23,48 @@ type PaymentRepository interface {
 	GetPayment(ctx context.Context, paymentID string) (*types.Payment, error)
 	UpdatePaymentStatus(ctx context.Context, paymentID string, status types.PaymentStatus, processorResponse string) error
 	GetPaymentsByTrip(ctx context.Context, tripID string) ([]*types.Payment, error)
-	GetPaymentsByUser(ctx context.Context, userID string, limit, offset int) ([]*types.Payment, error)
-	GetPaymentsByStatus(ctx context.Context, status types.PaymentStatus, limit, offset int) ([]*types.Payment, error)
+	// GetPaymentsByUser, GetPaymentsByStatus and GetPaymentsCreatedBetween
+	// read page.FetchLimit() payments of the requested page
+	GetPaymentsByUser(ctx context.Context, userID string, page pagination.Request) ([]*types.Payment, error)
+	GetPaymentsByStatus(ctx context.Context, status types.PaymentStatus, page pagination.Request) ([]*types.Payment, error)
+	// CountPaymentsByUser counts the payments charged to a user
+	CountPaymentsByUser(ctx context.Context, userID string) (int64, error)
+	// GetPaymentTotals counts and sums payments created in [from, to) by currency and status
+	GetPaymentTotals(ctx context.Context, from, to time.Time) ([]*types.CurrencyTotal, error)
+	// GetPaymentsCreatedBetween pages through payments created in [from, to)
+	GetPaymentsCreatedBetween(ctx context.Context, from, to time.Time, page pagination.Request) ([]*types.Payment, error)
+	// GetDriverPayments returns the payments for a driver's trips created in
+	// [from, to), oldest first
+	GetDriverPayments(ctx context.Context, driverID string, from, to time.Time) ([]*types.Payment, error)
+	// GetPaymentsUpdatedSince returns up to limit payments updated after the
+	// watermark, in (updated_at, id) order, for the warehouse export
+	GetPaymentsUpdatedSince(ctx context.Context, since export.Watermark, limit int) ([]*types.Payment, error)
+	// GetPaymentsEndedBefore returns up to limit payments in an ended status
+	// last updated before cutoff, with IDs after afterID, in ID order
+	GetPaymentsEndedBefore(ctx context.Context, cutoff time.Time, afterID string, limit int) ([]*types.Payment, error)
+	// DeletePayments removes archived payments
+	DeletePayments(ctx context.Context, ids []string) error
+	// RestorePayment saves a payment rehydrated from the archive unless it
+	// is already in the store
+	RestorePayment(ctx context.Context, payment *types.Payment) error
+}
+
+// endedPaymentStatuses are the statuses a payment no longer leaves on its
+// own, so it can be archived. Chargebacks stay until they are resolved.
+var endedPaymentStatuses = []types.PaymentStatus{
+	types.PaymentStatusCompleted,
+	types.PaymentStatusFailed,
+	types.PaymentStatusRefunded,
+	types.PaymentStatusCancelled,
+}
+
+func isEndedPaymentStatus(status types.PaymentStatus) bool {
+	for _, ended := range endedPaymentStatuses {
+		if status == ended {
+			return true
+		}
+	}
+	return false
 }
 
 // PaymentMethodRepository defines the interface for payment method operations
@@ -31,6 +75,8 @@ type PaymentMethodRepository interface {
 	UpdatePaymentMethod(ctx context.Context, method *types.PaymentMethodDetails) error
 	DeletePaymentMethod(ctx context.Context, methodID string) error
 	SetDefaultPaymentMethod(ctx context.Context, userID, methodID string) error
+	// ListPaymentMethods pages through all payment methods in ID order
+	ListPaymentMethods(ctx context.Context, limit, offset int) ([]*types.PaymentMethodDetails, error)
 }
 
 // RefundRepository defines the interface for refund operations
@@ -119,16 +165,28 @@ func (r *PostgreSQLPaymentRepository) GetPaymentsByTrip(ctx context.Context, tri
 	return r.scanPayments(rows)
 }
 
-func (r *PostgreSQLPaymentRepository) GetPaymentsByUser(ctx context.Context, userID string, limit, offset int) ([]*types.Payment, error) {
+func (r *PostgreSQLPaymentRepository) GetPaymentsByUser(ctx context.Context, userID string, page pagination.Request) ([]*types.Payment, error) {
+	return r.listPayments(ctx, page, "user_id = $1", userID)
+}
+
+func (r *PostgreSQLPaymentRepository) GetPaymentsByStatus(ctx context.Context, status types.PaymentStatus, page pagination.Request) ([]*types.Payment, error) {
+	return r.listPayments(ctx, page, "status = $1", status)
+}
+
+func (r *PostgreSQLPaymentRepository) GetPaymentsCreatedBetween(ctx context.Context, from, to time.Time, page pagination.Request) ([]*types.Payment, error) {
+	return r.listPayments(ctx, page, "created_at >= $1 AND created_at < $2", from, to)
+}
+
+func (r *PostgreSQLPaymentRepository) GetDriverPayments(ctx context.Context, driverID string, from, to time.Time) ([]*types.Payment, error) {
 	query := `
 		SELECT id, trip_id, user_id, driver_id, amount, currency, payment_method,
 			   status, transaction_type, processor_response, fraud_risk,
 			   fraud_scores, metadata, failure_reason, processed_at, created_at, updated_at
-		FROM payments WHERE user_id = $1 
-		ORDER BY created_at DESC LIMIT $2 OFFSET $3
+		FROM payments WHERE driver_id = $1 AND created_at >= $2 AND created_at < $3
+		ORDER BY created_at ASC, id ASC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, driverID, from, to)
 	if err != nil {
 		return nil, err
 	}
@@ -137,16 +195,58 @@ func (r *PostgreSQLPaymentRepository) GetPaymentsByUser(ctx context.Context, use
 	return r.scanPayments(rows)
 }
 
-func (r *PostgreSQLPaymentRepository) GetPaymentsByStatus(ctx context.Context, status types.PaymentStatus, limit, offset int) ([]*types.Payment, error) {
+func (r *PostgreSQLPaymentRepository) CountPaymentsByUser(ctx context.Context, userID string) (int64, error) {
+	var count int64
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM payments WHERE user_id = $1`, userID).Scan(&count)
+	return count, err
+}
+
+// paymentSortColumns maps the fields payment lists sort on to their columns
+var paymentSortColumns = map[string]string{
+	"created_at": "created_at",
+	"amount":     "amount",
+}
+
+// listPayments reads a page of the payments matching condition, whose
+// placeholders are numbered from $1 for args
+func (r *PostgreSQLPaymentRepository) listPayments(ctx context.Context, page pagination.Request, condition string, args ...interface{}) ([]*types.Payment, error) {
+	column, ok := paymentSortColumns[page.Sort.Field.Name]
+	if !ok {
+		return nil, fmt.Errorf("%w: cannot sort payments on %q", pagination.ErrInvalidSort, page.Sort.Field.Name)
+	}
+	if keyset, keysetArgs := page.Keyset(column, "id", len(args)+1); keyset != "" {
+		condition += " AND " + keyset
+		args = append(args, keysetArgs...)
+	}
+	args = append(args, page.FetchLimit())
+
+	query := fmt.Sprintf(`
+		SELECT id, trip_id, user_id, driver_id, amount, currency, payment_method,
+			   status, transaction_type, processor_response, fraud_risk,
+			   fraud_scores, metadata, failure_reason, processed_at, created_at, updated_at
+		FROM payments WHERE %s
+		ORDER BY %s LIMIT $%d
+	`, condition, page.OrderBy(column, "id"), len(args))
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanPayments(rows)
+}
+
+func (r *PostgreSQLPaymentRepository) GetPaymentsUpdatedSince(ctx context.Context, since export.Watermark, limit int) ([]*types.Payment, error) {
 	query := `
 		SELECT id, trip_id, user_id, driver_id, amount, currency, payment_method,
 			   status, transaction_type, processor_response, fraud_risk,
 			   fraud_scores, metadata, failure_reason, processed_at, created_at, updated_at
-		FROM payments WHERE status = $1 
-		ORDER BY created_at DESC LIMIT $2 OFFSET $3
+		FROM payments WHERE (updated_at, id) > ($1, $2)
+		ORDER BY updated_at ASC, id ASC LIMIT $3
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, status, limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, since.UpdatedAt, since.ID, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -155,6 +255,81 @@ func (r *PostgreSQLPaymentRepository) GetPaymentsByStatus(ctx context.Context, s
 	return r.scanPayments(rows)
 }
 
+func (r *PostgreSQLPaymentRepository) GetPaymentsEndedBefore(ctx context.Context, cutoff time.Time, afterID string, limit int) ([]*types.Payment, error) {
+	query := `
+		SELECT id, trip_id, user_id, driver_id, amount, currency, payment_method,
+			   status, transaction_type, processor_response, fraud_risk,
+			   fraud_scores, metadata, failure_reason, processed_at, created_at, updated_at
+		FROM payments WHERE status = ANY($1) AND updated_at < $2 AND id > $3
+		ORDER BY id ASC LIMIT $4
+	`
+
+	statuses := make([]string, len(endedPaymentStatuses))
+	for i, status := range endedPaymentStatuses {
+		statuses[i] = string(status)
+	}
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(statuses), cutoff, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanPayments(rows)
+}
+
+func (r *PostgreSQLPaymentRepository) DeletePayments(ctx context.Context, ids []string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM payments WHERE id = ANY($1)`, pq.Array(ids))
+	return err
+}
+
+func (r *PostgreSQLPaymentRepository) RestorePayment(ctx context.Context, payment *types.Payment) error {
+	fraudScoresJSON, _ := json.Marshal(payment.FraudScores)
+	metadataJSON, _ := json.Marshal(payment.Metadata)
+
+	query := `
+		INSERT INTO payments (id, trip_id, user_id, driver_id, amount, currency, payment_method,
+			status, transaction_type, processor_response, fraud_risk, fraud_scores,
+			metadata, failure_reason, processed_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
+		ON CONFLICT (id) DO NOTHING
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		payment.ID, payment.TripID, payment.UserID, payment.DriverID,
+		payment.Amount, payment.Currency, payment.PaymentMethod,
+		payment.Status, payment.TransactionType, payment.ProcessorResponse,
+		payment.FraudRisk, fraudScoresJSON, metadataJSON,
+		payment.FailureReason, payment.ProcessedAt, payment.CreatedAt, payment.UpdatedAt,
+	)
+	return err
+}
+
+// GetPaymentTotals counts and sums payments created in [from, to) by
+// currency and status
+func (r *PostgreSQLPaymentRepository) GetPaymentTotals(ctx context.Context, from, to time.Time) ([]*types.CurrencyTotal, error) {
+	query := `
+		SELECT currency, status, COUNT(*), COALESCE(SUM(amount), 0)
+		FROM payments WHERE created_at >= $1 AND created_at < $2
+		GROUP BY currency, status ORDER BY currency, status
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var totals []*types.CurrencyTotal
+	for rows.Next() {
+		var total types.CurrencyTotal
+		if err := rows.Scan(&total.Currency, &total.Status, &total.Count, &total.Amount); err != nil {
+			return nil, err
+		}
+		totals = append(totals, &total)
+	}
+	return totals, rows.Err()
+}
+
 func (r *PostgreSQLPaymentRepository) scanPayment(row *sql.Row) (*types.Payment, error) {
 	var payment types.Payment
 	var fraudScoresJSON, metadataJSON []byte
@@ -290,42 +465,158 @@ func (m *MockPaymentRepository) GetPaymentsByTrip(ctx context.Context, tripID st
 	return payments, nil
 }
 
-func (m *MockPaymentRepository) GetPaymentsByUser(ctx context.Context, userID string, limit, offset int) ([]*types.Payment, error) {
+func (m *MockPaymentRepository) GetPaymentsByUser(ctx context.Context, userID string, page pagination.Request) ([]*types.Payment, error) {
+	return m.pageOfPayments(page, func(payment *types.Payment) bool {
+		return payment.UserID == userID
+	}), nil
+}
+
+func (m *MockPaymentRepository) CountPaymentsByUser(ctx context.Context, userID string) (int64, error) {
 	m.mutex.RLock()
 	defer m.mutex.RUnlock()
 
-	var payments []*types.Payment
-	count := 0
+	var count int64
 	for _, payment := range m.payments {
 		if payment.UserID == userID {
-			if count >= offset && len(payments) < limit {
-				payments = append(payments, payment)
-			}
 			count++
 		}
 	}
+	return count, nil
+}
 
-	return payments, nil
+func (m *MockPaymentRepository) GetPaymentsByStatus(ctx context.Context, status types.PaymentStatus, page pagination.Request) ([]*types.Payment, error) {
+	return m.pageOfPayments(page, func(payment *types.Payment) bool {
+		return payment.Status == status
+	}), nil
+}
+
+// pageOfPayments reads a page of the payments matching like the SQL queries do
+func (m *MockPaymentRepository) pageOfPayments(page pagination.Request, matches func(*types.Payment) bool) []*types.Payment {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	key := types.PaymentSortKey(page.Sort)
+	matching := []*types.Payment{}
+	for _, payment := range m.payments {
+		if matches(payment) && (page.After == nil || page.Compare(key(payment), *page.After) > 0) {
+			matching = append(matching, payment)
+		}
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		return page.Compare(key(matching[i]), key(matching[j])) < 0
+	})
+	return matching[:min(page.FetchLimit(), len(matching))]
+}
+
+func (m *MockPaymentRepository) GetPaymentTotals(ctx context.Context, from, to time.Time) ([]*types.CurrencyTotal, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	type key struct {
+		currency string
+		status   types.PaymentStatus
+	}
+	byKey := make(map[key]*types.CurrencyTotal)
+	var totals []*types.CurrencyTotal
+	for _, payment := range m.payments {
+		if payment.CreatedAt.Before(from) || !payment.CreatedAt.Before(to) {
+			continue
+		}
+		k := key{currency: payment.Currency, status: payment.Status}
+		total, exists := byKey[k]
+		if !exists {
+			total = &types.CurrencyTotal{Currency: payment.Currency, Status: payment.Status}
+			byKey[k] = total
+			totals = append(totals, total)
+		}
+		total.Count++
+		total.Amount += payment.Amount
+	}
+
+	return totals, nil
+}
+
+func (m *MockPaymentRepository) GetPaymentsCreatedBetween(ctx context.Context, from, to time.Time, page pagination.Request) ([]*types.Payment, error) {
+	return m.pageOfPayments(page, func(payment *types.Payment) bool {
+		return !payment.CreatedAt.Before(from) && payment.CreatedAt.Before(to)
+	}), nil
 }
 
-func (m *MockPaymentRepository) GetPaymentsByStatus(ctx context.Context, status types.PaymentStatus, limit, offset int) ([]*types.Payment, error) {
+func (m *MockPaymentRepository) GetDriverPayments(ctx context.Context, driverID string, from, to time.Time) ([]*types.Payment, error) {
 	m.mutex.RLock()
 	defer m.mutex.RUnlock()
 
 	var payments []*types.Payment
-	count := 0
 	for _, payment := range m.payments {
-		if payment.Status == status {
-			if count >= offset && len(payments) < limit {
-				payments = append(payments, payment)
-			}
-			count++
+		if payment.DriverID == driverID && !payment.CreatedAt.Before(from) && payment.CreatedAt.Before(to) {
+			payments = append(payments, payment)
 		}
 	}
+	sort.Slice(payments, func(i, j int) bool {
+		if !payments[i].CreatedAt.Equal(payments[j].CreatedAt) {
+			return payments[i].CreatedAt.Before(payments[j].CreatedAt)
+		}
+		return payments[i].ID < payments[j].ID
+	})
 
 	return payments, nil
 }
 
+func (m *MockPaymentRepository) GetPaymentsUpdatedSince(ctx context.Context, since export.Watermark, limit int) ([]*types.Payment, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var matching []*types.Payment
+	for _, payment := range m.payments {
+		if since.Before(payment.UpdatedAt, payment.ID) {
+			matching = append(matching, payment)
+		}
+	}
+
+	sort.Slice(matching, func(i, j int) bool {
+		at := export.Watermark{UpdatedAt: matching[i].UpdatedAt, ID: matching[i].ID}
+		return at.Before(matching[j].UpdatedAt, matching[j].ID)
+	})
+	return matching[:min(limit, len(matching))], nil
+}
+
+func (m *MockPaymentRepository) GetPaymentsEndedBefore(ctx context.Context, cutoff time.Time, afterID string, limit int) ([]*types.Payment, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var matching []*types.Payment
+	for _, payment := range m.payments {
+		if isEndedPaymentStatus(payment.Status) && payment.UpdatedAt.Before(cutoff) && payment.ID > afterID {
+			matching = append(matching, payment)
+		}
+	}
+
+	sort.Slice(matching, func(i, j int) bool {
+		return matching[i].ID < matching[j].ID
+	})
+	return matching[:min(limit, len(matching))], nil
+}
+
+func (m *MockPaymentRepository) DeletePayments(ctx context.Context, ids []string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, id := range ids {
+		delete(m.payments, id)
+	}
+	return nil
+}
+
+func (m *MockPaymentRepository) RestorePayment(ctx context.Context, payment *types.Payment) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, exists := m.payments[payment.ID]; !exists {
+		m.payments[payment.ID] = payment
+	}
+	return nil
+}
+
 // MockPaymentMethodRepository provides an in-memory implementation for testing
 type MockPaymentMethodRepository struct {
 	methods map[string]*types.PaymentMethodDetails
@@ -409,6 +700,26 @@ func (m *MockPaymentMethodRepository) DeletePaymentMethod(ctx context.Context, m
 	return nil
 }
 
+func (m *MockPaymentMethodRepository) ListPaymentMethods(ctx context.Context, limit, offset int) ([]*types.PaymentMethodDetails, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	methods := make([]*types.PaymentMethodDetails, 0, len(m.methods))
+	for _, method := range m.methods {
+		methods = append(methods, method)
+	}
+	sort.Slice(methods, func(i, j int) bool { return methods[i].ID < methods[j].ID })
+
+	if offset >= len(methods) {
+		return []*types.PaymentMethodDetails{}, nil
+	}
+	end := offset + limit
+	if end > len(methods) {
+		end = len(methods)
+	}
+	return methods[offset:end], nil
+}
+
 func (m *MockPaymentMethodRepository) SetDefaultPaymentMethod(ctx context.Context, userID, methodID string) error {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
@@ -0,0 +1,318 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// RefundCaseRepository defines the interface for automated refund cases and
+// their audit trail
+type RefundCaseRepository interface {
+	CreateCase(ctx context.Context, refundCase *types.RefundCase) error
+	GetCase(ctx context.Context, caseID string) (*types.RefundCase, error)
+	GetCasesByTrip(ctx context.Context, tripID string) ([]*types.RefundCase, error)
+	ListCases(ctx context.Context, status types.RefundCaseStatus, limit, offset int) ([]*types.RefundCase, error)
+	UpdateCase(ctx context.Context, refundCase *types.RefundCase) error
+	AddAuditEntry(ctx context.Context, entry *types.RefundAuditEntry) error
+	GetAuditEntries(ctx context.Context, caseID string) ([]*types.RefundAuditEntry, error)
+}
+
+// PostgreSQLRefundCaseRepository implements RefundCaseRepository using PostgreSQL
+type PostgreSQLRefundCaseRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+// NewPostgreSQLRefundCaseRepository creates a new PostgreSQL refund case repository
+func NewPostgreSQLRefundCaseRepository(db *sql.DB, logger logger.Logger) *PostgreSQLRefundCaseRepository {
+	return &PostgreSQLRefundCaseRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+const refundCaseColumns = `
+	id, trip_id, payment_id, user_id, reason, amount, currency, status, auto_approved,
+	source, details, reviewed_by, notes, failure_reason, created_at, resolved_at
+`
+
+func (r *PostgreSQLRefundCaseRepository) CreateCase(ctx context.Context, refundCase *types.RefundCase) error {
+	if refundCase.ID == "" {
+		refundCase.ID = uuid.New().String()
+	}
+	if refundCase.CreatedAt.IsZero() {
+		refundCase.CreatedAt = time.Now()
+	}
+
+	query := `INSERT INTO refund_cases (` + refundCaseColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		refundCase.ID, refundCase.TripID, refundCase.PaymentID, refundCase.UserID, refundCase.Reason,
+		refundCase.Amount, refundCase.Currency, refundCase.Status, refundCase.AutoApproved,
+		refundCase.Source, refundCase.Details, refundCase.ReviewedBy, refundCase.Notes,
+		refundCase.FailureReason, refundCase.CreatedAt, refundCase.ResolvedAt,
+	)
+	return err
+}
+
+func (r *PostgreSQLRefundCaseRepository) GetCase(ctx context.Context, caseID string) (*types.RefundCase, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+refundCaseColumns+` FROM refund_cases WHERE id = $1`, caseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cases, err := r.scanCases(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(cases) == 0 {
+		return nil, fmt.Errorf("%w: %s", types.ErrRefundCaseNotFound, caseID)
+	}
+
+	return cases[0], nil
+}
+
+func (r *PostgreSQLRefundCaseRepository) GetCasesByTrip(ctx context.Context, tripID string) ([]*types.RefundCase, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT `+refundCaseColumns+` FROM refund_cases WHERE trip_id = $1 ORDER BY created_at ASC`, tripID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanCases(rows)
+}
+
+func (r *PostgreSQLRefundCaseRepository) ListCases(ctx context.Context, status types.RefundCaseStatus, limit, offset int) ([]*types.RefundCase, error) {
+	query := `SELECT ` + refundCaseColumns + ` FROM refund_cases WHERE status = $1
+		ORDER BY created_at ASC LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.QueryContext(ctx, query, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return r.scanCases(rows)
+}
+
+func (r *PostgreSQLRefundCaseRepository) UpdateCase(ctx context.Context, refundCase *types.RefundCase) error {
+	query := `
+		UPDATE refund_cases
+		SET status = $1, auto_approved = $2, reviewed_by = $3, notes = $4, failure_reason = $5, resolved_at = $6
+		WHERE id = $7
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		refundCase.Status, refundCase.AutoApproved, refundCase.ReviewedBy, refundCase.Notes,
+		refundCase.FailureReason, refundCase.ResolvedAt, refundCase.ID,
+	)
+	if err != nil {
+		return err
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return fmt.Errorf("%w: %s", types.ErrRefundCaseNotFound, refundCase.ID)
+	}
+
+	return nil
+}
+
+func (r *PostgreSQLRefundCaseRepository) AddAuditEntry(ctx context.Context, entry *types.RefundAuditEntry) error {
+	if entry.ID == "" {
+		entry.ID = uuid.New().String()
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO refund_audit_log (id, case_id, action, actor, details, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, entry.ID, entry.CaseID, entry.Action, entry.Actor, entry.Details, entry.CreatedAt)
+	return err
+}
+
+func (r *PostgreSQLRefundCaseRepository) GetAuditEntries(ctx context.Context, caseID string) ([]*types.RefundAuditEntry, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, case_id, action, actor, COALESCE(details, ''), created_at
+		FROM refund_audit_log WHERE case_id = $1
+		ORDER BY created_at ASC
+	`, caseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*types.RefundAuditEntry
+	for rows.Next() {
+		var entry types.RefundAuditEntry
+		if err := rows.Scan(&entry.ID, &entry.CaseID, &entry.Action, &entry.Actor, &entry.Details, &entry.CreatedAt); err != nil {
+			return nil, err
+		}
+		entries = append(entries, &entry)
+	}
+
+	return entries, rows.Err()
+}
+
+func (r *PostgreSQLRefundCaseRepository) scanCases(rows *sql.Rows) ([]*types.RefundCase, error) {
+	var cases []*types.RefundCase
+
+	for rows.Next() {
+		var refundCase types.RefundCase
+		var source, details, reviewedBy, notes, failureReason sql.NullString
+
+		err := rows.Scan(
+			&refundCase.ID, &refundCase.TripID, &refundCase.PaymentID, &refundCase.UserID, &refundCase.Reason,
+			&refundCase.Amount, &refundCase.Currency, &refundCase.Status, &refundCase.AutoApproved,
+			&source, &details, &reviewedBy, &notes, &failureReason,
+			&refundCase.CreatedAt, &refundCase.ResolvedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		refundCase.Source = source.String
+		refundCase.Details = details.String
+		refundCase.ReviewedBy = reviewedBy.String
+		refundCase.Notes = notes.String
+		refundCase.FailureReason = failureReason.String
+
+		cases = append(cases, &refundCase)
+	}
+
+	return cases, rows.Err()
+}
+
+// MockRefundCaseRepository provides an in-memory implementation for testing
+type MockRefundCaseRepository struct {
+	cases map[string]*types.RefundCase
+	audit map[string][]*types.RefundAuditEntry
+	mutex sync.RWMutex
+}
+
+// NewMockRefundCaseRepository creates a new mock refund case repository
+func NewMockRefundCaseRepository() *MockRefundCaseRepository {
+	return &MockRefundCaseRepository{
+		cases: make(map[string]*types.RefundCase),
+		audit: make(map[string][]*types.RefundAuditEntry),
+	}
+}
+
+func (m *MockRefundCaseRepository) CreateCase(ctx context.Context, refundCase *types.RefundCase) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if refundCase.ID == "" {
+		refundCase.ID = uuid.New().String()
+	}
+	if refundCase.CreatedAt.IsZero() {
+		refundCase.CreatedAt = time.Now()
+	}
+
+	stored := *refundCase
+	m.cases[refundCase.ID] = &stored
+	return nil
+}
+
+func (m *MockRefundCaseRepository) GetCase(ctx context.Context, caseID string) (*types.RefundCase, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	refundCase, exists := m.cases[caseID]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", types.ErrRefundCaseNotFound, caseID)
+	}
+
+	found := *refundCase
+	return &found, nil
+}
+
+func (m *MockRefundCaseRepository) GetCasesByTrip(ctx context.Context, tripID string) ([]*types.RefundCase, error) {
+	return m.matching(func(refundCase *types.RefundCase) bool {
+		return refundCase.TripID == tripID
+	}), nil
+}
+
+func (m *MockRefundCaseRepository) ListCases(ctx context.Context, status types.RefundCaseStatus, limit, offset int) ([]*types.RefundCase, error) {
+	matching := m.matching(func(refundCase *types.RefundCase) bool {
+		return refundCase.Status == status
+	})
+
+	if offset >= len(matching) {
+		return []*types.RefundCase{}, nil
+	}
+	end := offset + limit
+	if end > len(matching) {
+		end = len(matching)
+	}
+
+	return matching[offset:end], nil
+}
+
+// matching returns copies of the cases that match, oldest first
+func (m *MockRefundCaseRepository) matching(match func(*types.RefundCase) bool) []*types.RefundCase {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var cases []*types.RefundCase
+	for _, refundCase := range m.cases {
+		if match(refundCase) {
+			found := *refundCase
+			cases = append(cases, &found)
+		}
+	}
+
+	sort.Slice(cases, func(i, j int) bool {
+		if !cases[i].CreatedAt.Equal(cases[j].CreatedAt) {
+			return cases[i].CreatedAt.Before(cases[j].CreatedAt)
+		}
+		return cases[i].ID < cases[j].ID
+	})
+
+	return cases
+}
+
+func (m *MockRefundCaseRepository) UpdateCase(ctx context.Context, refundCase *types.RefundCase) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, exists := m.cases[refundCase.ID]; !exists {
+		return fmt.Errorf("%w: %s", types.ErrRefundCaseNotFound, refundCase.ID)
+	}
+
+	stored := *refundCase
+	m.cases[refundCase.ID] = &stored
+	return nil
+}
+
+func (m *MockRefundCaseRepository) AddAuditEntry(ctx context.Context, entry *types.RefundAuditEntry) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if entry.ID == "" {
+		entry.ID = uuid.New().String()
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	m.audit[entry.CaseID] = append(m.audit[entry.CaseID], entry)
+	return nil
+}
+
+func (m *MockRefundCaseRepository) GetAuditEntries(ctx context.Context, caseID string) ([]*types.RefundAuditEntry, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return append([]*types.RefundAuditEntry(nil), m.audit[caseID]...), nil
+}
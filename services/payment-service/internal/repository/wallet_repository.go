@@ -0,0 +1,287 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// WalletRepository defines the interface for the wallet ledger
+type WalletRepository interface {
+	// RecordTransaction stores a transaction and its ledger entries atomically.
+	// It fails with types.ErrInsufficientBalance if either of the user's wallet
+	// accounts would go negative.
+	RecordTransaction(ctx context.Context, transaction *types.WalletTransaction) error
+	GetWalletAccounts(ctx context.Context, userID string) (*types.WalletAccounts, error)
+	// GetWalletTransactions pages through a user's transactions, newest first
+	GetWalletTransactions(ctx context.Context, userID string, limit, offset int) ([]*types.WalletTransaction, error)
+}
+
+// prepareTransaction assigns IDs and timestamps and checks that the entries
+// balance
+func prepareTransaction(transaction *types.WalletTransaction) error {
+	if !transaction.Balanced() {
+		return types.ErrUnbalancedTransaction
+	}
+	if transaction.ID == "" {
+		transaction.ID = uuid.New().String()
+	}
+	if transaction.CreatedAt.IsZero() {
+		transaction.CreatedAt = time.Now()
+	}
+	for _, entry := range transaction.Entries {
+		if entry.Currency != transaction.Currency {
+			return fmt.Errorf("ledger entry currency %s does not match transaction currency %s", entry.Currency, transaction.Currency)
+		}
+		if entry.ID == "" {
+			entry.ID = uuid.New().String()
+		}
+		entry.TransactionID = transaction.ID
+		entry.CreatedAt = transaction.CreatedAt
+	}
+	return nil
+}
+
+// applyEntries returns the user's wallet accounts after the transaction
+func applyEntries(accounts types.WalletAccounts, transaction *types.WalletTransaction) (types.WalletAccounts, error) {
+	cash, credit := types.WalletCashAccount(transaction.UserID), types.WalletCreditAccount(transaction.UserID)
+	for _, entry := range transaction.Entries {
+		switch entry.Account {
+		case cash:
+			accounts.Cash += entry.Amount
+		case credit:
+			accounts.Credit += entry.Amount
+		}
+	}
+	if accounts.Cash < 0 || accounts.Credit < 0 {
+		return accounts, types.ErrInsufficientBalance
+	}
+	return accounts, nil
+}
+
+// PostgreSQLWalletRepository implements WalletRepository using PostgreSQL
+type PostgreSQLWalletRepository struct {
+	db     *sql.DB
+	logger logger.Logger
+}
+
+// NewPostgreSQLWalletRepository creates a new PostgreSQL wallet repository
+func NewPostgreSQLWalletRepository(db *sql.DB, logger logger.Logger) *PostgreSQLWalletRepository {
+	return &PostgreSQLWalletRepository{
+		db:     db,
+		logger: logger,
+	}
+}
+
+func (r *PostgreSQLWalletRepository) RecordTransaction(ctx context.Context, transaction *types.WalletTransaction) error {
+	if err := prepareTransaction(transaction); err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Serialize movements of the same wallet so the balance check holds
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, transaction.UserID); err != nil {
+		return err
+	}
+	accounts, err := r.walletAccounts(ctx, tx, transaction.UserID)
+	if err != nil {
+		return err
+	}
+	if _, err := applyEntries(*accounts, transaction); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO wallet_transactions (id, user_id, type, amount, currency, reference, description, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`,
+		transaction.ID, transaction.UserID, transaction.Type, transaction.Amount,
+		transaction.Currency, transaction.Reference, transaction.Description, transaction.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range transaction.Entries {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO ledger_entries (id, transaction_id, account, amount, currency, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, entry.ID, entry.TransactionID, entry.Account, entry.Amount, entry.Currency, entry.CreatedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *PostgreSQLWalletRepository) GetWalletAccounts(ctx context.Context, userID string) (*types.WalletAccounts, error) {
+	return r.walletAccounts(ctx, r.db, userID)
+}
+
+// queryer is satisfied by both *sql.DB and *sql.Tx
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+func (r *PostgreSQLWalletRepository) walletAccounts(ctx context.Context, q queryer, userID string) (*types.WalletAccounts, error) {
+	cash, credit := types.WalletCashAccount(userID), types.WalletCreditAccount(userID)
+	rows, err := q.QueryContext(ctx, `
+		SELECT account, currency, COALESCE(SUM(amount), 0)
+		FROM ledger_entries WHERE account IN ($1, $2)
+		GROUP BY account, currency
+	`, cash, credit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	accounts := &types.WalletAccounts{}
+	for rows.Next() {
+		var account string
+		var balance int64
+		if err := rows.Scan(&account, &accounts.Currency, &balance); err != nil {
+			return nil, err
+		}
+		if account == cash {
+			accounts.Cash = balance
+		} else {
+			accounts.Credit = balance
+		}
+	}
+	return accounts, rows.Err()
+}
+
+func (r *PostgreSQLWalletRepository) GetWalletTransactions(ctx context.Context, userID string, limit, offset int) ([]*types.WalletTransaction, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, user_id, type, amount, currency, COALESCE(reference, ''), COALESCE(description, ''), created_at
+		FROM wallet_transactions WHERE user_id = $1
+		ORDER BY created_at DESC LIMIT $2 OFFSET $3
+	`, userID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var transactions []*types.WalletTransaction
+	byID := make(map[string]*types.WalletTransaction)
+	var ids []interface{}
+	for rows.Next() {
+		var transaction types.WalletTransaction
+		if err := rows.Scan(
+			&transaction.ID, &transaction.UserID, &transaction.Type, &transaction.Amount,
+			&transaction.Currency, &transaction.Reference, &transaction.Description, &transaction.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		transactions = append(transactions, &transaction)
+		byID[transaction.ID] = &transaction
+		ids = append(ids, transaction.ID)
+	}
+	if err := rows.Err(); err != nil || len(transactions) == 0 {
+		return transactions, err
+	}
+
+	placeholders := ""
+	for i := range ids {
+		if i > 0 {
+			placeholders += ", "
+		}
+		placeholders += fmt.Sprintf("$%d", i+1)
+	}
+	entryRows, err := r.db.QueryContext(ctx, `
+		SELECT id, transaction_id, account, amount, currency, created_at
+		FROM ledger_entries WHERE transaction_id IN (`+placeholders+`)
+		ORDER BY transaction_id, amount
+	`, ids...)
+	if err != nil {
+		return nil, err
+	}
+	defer entryRows.Close()
+
+	for entryRows.Next() {
+		var entry types.LedgerEntry
+		if err := entryRows.Scan(&entry.ID, &entry.TransactionID, &entry.Account, &entry.Amount, &entry.Currency, &entry.CreatedAt); err != nil {
+			return nil, err
+		}
+		if transaction, ok := byID[entry.TransactionID]; ok {
+			transaction.Entries = append(transaction.Entries, &entry)
+		}
+	}
+	return transactions, entryRows.Err()
+}
+
+// MockWalletRepository provides an in-memory implementation for testing
+type MockWalletRepository struct {
+	transactions []*types.WalletTransaction
+	accounts     map[string]types.WalletAccounts
+	mutex        sync.RWMutex
+}
+
+// NewMockWalletRepository creates a new mock wallet repository
+func NewMockWalletRepository() *MockWalletRepository {
+	return &MockWalletRepository{
+		accounts: make(map[string]types.WalletAccounts),
+	}
+}
+
+func (m *MockWalletRepository) RecordTransaction(ctx context.Context, transaction *types.WalletTransaction) error {
+	if err := prepareTransaction(transaction); err != nil {
+		return err
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	current := m.accounts[transaction.UserID]
+	accounts, err := applyEntries(current, transaction)
+	if err != nil {
+		return err
+	}
+	accounts.Currency = transaction.Currency
+
+	m.accounts[transaction.UserID] = accounts
+	m.transactions = append(m.transactions, transaction)
+	return nil
+}
+
+func (m *MockWalletRepository) GetWalletAccounts(ctx context.Context, userID string) (*types.WalletAccounts, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	accounts := m.accounts[userID]
+	return &accounts, nil
+}
+
+func (m *MockWalletRepository) GetWalletTransactions(ctx context.Context, userID string, limit, offset int) ([]*types.WalletTransaction, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	// Transactions are recorded in order, so walking back lists the newest first
+	var transactions []*types.WalletTransaction
+	for i := len(m.transactions) - 1; i >= 0; i-- {
+		if m.transactions[i].UserID == userID {
+			transactions = append(transactions, m.transactions[i])
+		}
+	}
+
+	if offset >= len(transactions) {
+		return []*types.WalletTransaction{}, nil
+	}
+	end := offset + limit
+	if end > len(transactions) {
+		end = len(transactions)
+	}
+	return transactions[offset:end], nil
+}
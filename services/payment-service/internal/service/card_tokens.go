@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/services/payment-service/internal/vault"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// tokenMigrationPageSize is how many payment methods MigrateCardTokens loads at a time
+const tokenMigrationPageSize = 100
+
+// sensitiveCardFields are the payment method details that hold card data.
+// They are removed once the card is tokenized.
+var sensitiveCardFields = []string{"card_number", "cvv", "expiry", "expiry_month", "expiry_year", "holder_name"}
+
+// SetVault sets the vault cards are tokenized with. Without one, cards cannot
+// be added.
+func (s *PaymentService) SetVault(cardVault vault.Vault) {
+	s.vault = cardVault
+}
+
+// tokenizeCard exchanges the card in a new payment method's details for a
+// vault token. Only the token, brand, last four digits and expiry are kept.
+func (s *PaymentService) tokenizeCard(ctx context.Context, method *types.PaymentMethodDetails, details map[string]interface{}) error {
+	if s.vault == nil {
+		return fmt.Errorf("card vault is not configured")
+	}
+
+	card, err := cardFromDetails(details)
+	if err != nil {
+		return err
+	}
+	if err := card.Validate(time.Now(), true); err != nil {
+		return err
+	}
+
+	token, err := s.vault.Tokenize(ctx, card)
+	if err != nil {
+		return err
+	}
+	applyCardToken(method, token)
+	method.Details = scrubCardDetails(details)
+	return nil
+}
+
+// MigrateCardTokens brings stored card payment methods in line with the
+// vault: card numbers stored before tokenization are exchanged for tokens and
+// removed, and tokens issued by another vault are retokenized into this one.
+func (s *PaymentService) MigrateCardTokens(ctx context.Context) (*types.TokenMigrationResult, error) {
+	if s.vault == nil {
+		return nil, fmt.Errorf("card vault is not configured")
+	}
+
+	result := &types.TokenMigrationResult{}
+	for offset := 0; ; offset += tokenMigrationPageSize {
+		methods, err := s.paymentMethodRepo.ListPaymentMethods(ctx, tokenMigrationPageSize, offset)
+		if err != nil {
+			return result, err
+		}
+
+		for _, method := range methods {
+			if method.Type != types.PaymentMethodCreditCard && method.Type != types.PaymentMethodDebitCard {
+				continue
+			}
+			result.Scanned++
+
+			outcome, err := s.migrateCardToken(ctx, method)
+			if outcome != tokenUnchanged {
+				if updateErr := s.paymentMethodRepo.UpdatePaymentMethod(ctx, method); updateErr != nil {
+					return result, fmt.Errorf("failed to update payment method %s: %w", method.ID, updateErr)
+				}
+			}
+			if err != nil {
+				result.Failed++
+				result.FailedMethodIDs = append(result.FailedMethodIDs, method.ID)
+				s.logger.WithFields(logger.Fields{
+					"payment_method_id": method.ID,
+					"error":             err.Error(),
+				}).Warn("Failed to migrate card token")
+				continue
+			}
+			switch outcome {
+			case tokenIssued:
+				result.Tokenized++
+			case tokenReissued:
+				result.Retokenized++
+			}
+		}
+
+		if len(methods) < tokenMigrationPageSize {
+			return result, nil
+		}
+	}
+}
+
+// tokenMigration is what migrating a payment method did to it
+type tokenMigration int
+
+const (
+	tokenUnchanged tokenMigration = iota // already up to date
+	tokenIssued                          // stored card data exchanged for a token
+	tokenReissued                        // token moved from a previous vault
+	tokenScrubbed                        // card data removed without a token
+)
+
+// migrateCardToken updates one card payment method in place
+func (s *PaymentService) migrateCardToken(ctx context.Context, method *types.PaymentMethodDetails) (tokenMigration, error) {
+	if _, stored := method.Details["card_number"]; stored {
+		card, err := cardFromDetails(method.Details)
+		if err == nil {
+			err = card.Validate(time.Now(), false)
+		}
+		var token *vault.Token
+		if err == nil {
+			token, err = s.vault.Tokenize(ctx, card)
+		}
+
+		// Card data must not stay in storage even if it cannot be tokenized
+		if number, ok := method.Details["card_number"].(string); ok && len(number) >= 4 {
+			method.LastFourDigits = number[len(number)-4:]
+		}
+		method.Details = scrubCardDetails(method.Details)
+		if err != nil {
+			return tokenScrubbed, err
+		}
+		applyCardToken(method, token)
+		return tokenIssued, nil
+	}
+
+	if method.CardToken == "" || method.TokenVault == s.vault.Name() {
+		return tokenUnchanged, nil
+	}
+	token, err := s.vault.Retokenize(ctx, &vault.Token{
+		Token:       method.CardToken,
+		Vault:       method.TokenVault,
+		Brand:       method.CardBrand,
+		LastFour:    method.LastFourDigits,
+		Fingerprint: method.Fingerprint,
+	})
+	if err != nil {
+		return tokenUnchanged, err
+	}
+	applyCardToken(method, token)
+	return tokenReissued, nil
+}
+
+// applyCardToken records a card's token in place of the card
+func applyCardToken(method *types.PaymentMethodDetails, token *vault.Token) {
+	expiresAt := vault.ExpiresAt(token.ExpiryMonth, token.ExpiryYear)
+	method.CardToken = token.Token
+	method.TokenVault = token.Vault
+	method.CardBrand = token.Brand
+	method.LastFourDigits = token.LastFour
+	method.ExpiryDate = &expiresAt
+	method.Fingerprint = token.Fingerprint
+}
+
+// cardFromDetails reads card data from payment method details. The expiry is
+// given either as expiry_month and expiry_year or as expiry in MM/YY form.
+func cardFromDetails(details map[string]interface{}) (*vault.Card, error) {
+	number, _ := details["card_number"].(string)
+	if number == "" {
+		return nil, fmt.Errorf("%w: card number is required", vault.ErrInvalidCard)
+	}
+	cvv, _ := details["cvv"].(string)
+	holder, _ := details["holder_name"].(string)
+	card := &vault.Card{Number: number, CVV: cvv, HolderName: holder}
+
+	if expiry, ok := details["expiry"].(string); ok {
+		month, year, found := strings.Cut(expiry, "/")
+		if !found {
+			return nil, fmt.Errorf("%w: expiry must be MM/YY", vault.ErrInvalidCard)
+		}
+		card.ExpiryMonth = detailInt(strings.TrimSpace(month))
+		card.ExpiryYear = detailInt(strings.TrimSpace(year))
+	} else {
+		card.ExpiryMonth = detailInt(details["expiry_month"])
+		card.ExpiryYear = detailInt(details["expiry_year"])
+	}
+	if card.ExpiryYear > 0 && card.ExpiryYear < 100 {
+		card.ExpiryYear += 2000
+	}
+	return card, nil
+}
+
+// detailInt reads a number sent either as a JSON number or as a string
+func detailInt(value interface{}) int {
+	switch v := value.(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	case string:
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return 0
+		}
+		return n
+	default:
+		return 0
+	}
+}
+
+// scrubCardDetails returns a copy of payment method details without card data
+func scrubCardDetails(details map[string]interface{}) map[string]interface{} {
+	scrubbed := make(map[string]interface{}, len(details))
+	for key, value := range details {
+		scrubbed[key] = value
+	}
+	for _, field := range sensitiveCardFields {
+		delete(scrubbed, field)
+	}
+	return scrubbed
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/rideshare-platform/services/payment-service/internal/repository"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/services/payment-service/internal/vault"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+// acceptingCardProcessor verifies every tokenized card
+type acceptingCardProcessor struct {
+	MockCardProcessor
+}
+
+func (p *acceptingCardProcessor) VerifyPaymentMethod(ctx context.Context, method *types.PaymentMethodDetails) error {
+	if method.CardToken == "" {
+		return errors.New("card token is required")
+	}
+	return nil
+}
+
+func newCardTestService(cardVault vault.Vault) (*PaymentService, *repository.MockPaymentMethodRepository) {
+	methods := repository.NewMockPaymentMethodRepository()
+	service := NewPaymentService(
+		repository.NewMockPaymentRepository(),
+		methods,
+		repository.NewMockRefundRepository(),
+		nil,
+		*logger.NewLogger("error", "test"),
+	)
+	service.processors[types.PaymentMethodCreditCard] = &acceptingCardProcessor{}
+	service.SetVault(cardVault)
+	return service, methods
+}
+
+func testCardDetails(number string) map[string]interface{} {
+	return map[string]interface{}{
+		"card_number": number,
+		"cvv":         "123",
+		"expiry":      fmt.Sprintf("12/%02d", time.Now().Year()%100+2),
+		"holder_name": "Ada Lovelace",
+		"bank_name":   "First Bank",
+	}
+}
+
+func TestAddPaymentMethod_StoresOnlyCardToken(t *testing.T) {
+	ctx := context.Background()
+	service, methods := newCardTestService(vault.NewMemoryVault("gateway-a", []byte("key")))
+
+	response, err := service.AddPaymentMethod(ctx, &types.AddPaymentMethodRequest{
+		UserID:  "user-1",
+		Type:    types.PaymentMethodCreditCard,
+		Details: testCardDetails("4242 4242 4242 4242"),
+	})
+	assert.NoError(t, err)
+	assert.True(t, response.Success, response.Errors)
+
+	stored, err := methods.GetPaymentMethod(ctx, response.PaymentMethod.ID)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, stored.CardToken)
+	assert.Equal(t, "gateway-a", stored.TokenVault)
+	assert.Equal(t, "visa", stored.CardBrand)
+	assert.Equal(t, "4242", stored.LastFourDigits)
+	assert.Equal(t, time.December, stored.ExpiryDate.Month())
+	assert.Equal(t, map[string]interface{}{"bank_name": "First Bank"}, stored.Details)
+	assert.NotContains(t, fmt.Sprintf("%v %#v", stored, vault.Card{Number: "4242424242424242"}), "424242424242")
+
+	// The same card gets the same fingerprint
+	again, err := service.AddPaymentMethod(ctx, &types.AddPaymentMethodRequest{
+		UserID:  "user-1",
+		Type:    types.PaymentMethodCreditCard,
+		Details: testCardDetails("4242424242424242"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, stored.Fingerprint, again.PaymentMethod.Fingerprint)
+	assert.NotEqual(t, stored.CardToken, again.PaymentMethod.CardToken)
+}
+
+func TestAddPaymentMethod_RejectsInvalidCards(t *testing.T) {
+	ctx := context.Background()
+	service, _ := newCardTestService(vault.NewMemoryVault("gateway-a", []byte("key")))
+
+	expired := testCardDetails("5555555555554444")
+	expired["expiry"] = "01/20"
+	noCVV := testCardDetails("5555555555554444")
+	delete(noCVV, "cvv")
+
+	for _, details := range []map[string]interface{}{
+		testCardDetails("4242424242424241"), // fails the checksum
+		testCardDetails("4242"),
+		expired,
+		noCVV,
+	} {
+		response, err := service.AddPaymentMethod(ctx, &types.AddPaymentMethodRequest{
+			UserID:  "user-1",
+			Type:    types.PaymentMethodCreditCard,
+			Details: details,
+		})
+		assert.NoError(t, err)
+		assert.False(t, response.Success)
+		assert.Equal(t, "Card could not be tokenized", response.Message)
+	}
+}
+
+func TestMigrateCardTokens(t *testing.T) {
+	ctx := context.Background()
+	oldVault := vault.NewMemoryVault("gateway-a", []byte("key"))
+	oldService, methods := newCardTestService(oldVault)
+
+	moved, err := oldService.AddPaymentMethod(ctx, &types.AddPaymentMethodRequest{
+		UserID:  "user-1",
+		Type:    types.PaymentMethodCreditCard,
+		Details: testCardDetails("378282246310005"),
+	})
+	assert.NoError(t, err)
+	oldFingerprint := moved.PaymentMethod.Fingerprint
+
+	// Stored before tokenization, one of them no longer valid
+	legacy := &types.PaymentMethodDetails{ID: "legacy-1", UserID: "user-2", Type: types.PaymentMethodCreditCard, Details: testCardDetails("6011111111111117")}
+	broken := &types.PaymentMethodDetails{ID: "legacy-2", UserID: "user-3", Type: types.PaymentMethodDebitCard, Details: map[string]interface{}{"card_number": "1234567812345678", "cvv": "999"}}
+	wallet := &types.PaymentMethodDetails{ID: "wallet-1", UserID: "user-2", Type: types.PaymentMethodDigitalWallet, Details: map[string]interface{}{"email": "a@example.com"}}
+	for _, method := range []*types.PaymentMethodDetails{legacy, broken, wallet} {
+		assert.NoError(t, methods.CreatePaymentMethod(ctx, method))
+	}
+
+	service := NewPaymentService(repository.NewMockPaymentRepository(), methods, repository.NewMockRefundRepository(), nil, *logger.NewLogger("error", "test"))
+	service.SetVault(vault.NewMemoryVault("gateway-b", []byte("key"), oldVault))
+
+	result, err := service.MigrateCardTokens(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, result.Scanned)
+	assert.Equal(t, 1, result.Tokenized)
+	assert.Equal(t, 1, result.Retokenized)
+	assert.Equal(t, []string{"legacy-2"}, result.FailedMethodIDs)
+
+	migrated, err := methods.GetPaymentMethod(ctx, moved.PaymentMethod.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "gateway-b", migrated.TokenVault)
+	assert.Equal(t, "amex", migrated.CardBrand)
+	assert.Equal(t, oldFingerprint, migrated.Fingerprint)
+
+	tokenized, err := methods.GetPaymentMethod(ctx, "legacy-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "discover", tokenized.CardBrand)
+	assert.NotContains(t, tokenized.Details, "card_number")
+
+	scrubbed, err := methods.GetPaymentMethod(ctx, "legacy-2")
+	assert.NoError(t, err)
+	assert.Empty(t, scrubbed.CardToken)
+	assert.Equal(t, "5678", scrubbed.LastFourDigits)
+	assert.Empty(t, scrubbed.Details)
+
+	// A second pass has nothing left to do
+	result, err = service.MigrateCardTokens(ctx)
+	assert.NoError(t, err)
+	assert.Zero(t, result.Tokenized+result.Retokenized+result.Failed)
+}
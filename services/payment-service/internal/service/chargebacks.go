@@ -0,0 +1,313 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/services/payment-service/internal/repository"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/currency"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/pagination"
+)
+
+// chargebackEvidenceTypes are the kinds of evidence admins can submit
+var chargebackEvidenceTypes = map[string]bool{
+	"receipt":             true,
+	"trip_route":          true,
+	"rider_communication": true,
+	"other":               true,
+}
+
+// ChargebackService turns the payment provider's dispute webhooks into
+// chargeback cases linked to the disputed payment and its trip. While a
+// chargeback is open its payment is in the chargeback status, which keeps the
+// fare out of the driver's payout; a won chargeback restores the payment and
+// a lost one leaves it refunded to the rider. Admins submit evidence and
+// record outcomes the provider reports outside of webhooks.
+type ChargebackService struct {
+	chargebacks repository.ChargebackRepository
+	paymentRepo repository.PaymentRepository
+	ledger      *LedgerService
+	logger      logger.Logger
+
+	// Provider events and admin updates to chargebacks run one at a time
+	mutex sync.Mutex
+}
+
+// NewChargebackService creates a new chargeback service
+func NewChargebackService(chargebacks repository.ChargebackRepository, paymentRepo repository.PaymentRepository, logger logger.Logger) *ChargebackService {
+	return &ChargebackService{
+		chargebacks: chargebacks,
+		paymentRepo: paymentRepo,
+		logger:      logger,
+	}
+}
+
+// SetLedger sets the general ledger lost chargebacks are posted to
+func (s *ChargebackService) SetLedger(ledger *LedgerService) {
+	s.ledger = ledger
+}
+
+// HandleProviderEvent applies a dispute webhook. The first event for a
+// dispute opens a chargeback for its payment, whatever its type; later ones
+// move the chargeback along, and dispute.closed decides it. Events for
+// chargebacks that are already decided are ignored unless they close them
+// with another outcome, since the provider has the final word.
+func (s *ChargebackService) HandleProviderEvent(ctx context.Context, event *types.ProviderDisputeEvent) (*types.Chargeback, error) {
+	dispute := event.Dispute
+	if dispute.ID == "" || dispute.PaymentID == "" {
+		return nil, fmt.Errorf("%w: dispute ID and payment ID are required", types.ErrInvalidChargeback)
+	}
+	switch event.Type {
+	case types.ProviderDisputeCreated, types.ProviderDisputeUpdated:
+	case types.ProviderDisputeClosed:
+		if dispute.Status != types.ChargebackStatusWon && dispute.Status != types.ChargebackStatusLost {
+			return nil, fmt.Errorf("%w: closed disputes are won or lost, got %q", types.ErrInvalidChargeback, dispute.Status)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown event type %q", types.ErrInvalidChargeback, event.Type)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	chargeback, err := s.chargebacks.GetChargebackByProviderID(ctx, dispute.ID)
+	if errors.Is(err, types.ErrChargebackNotFound) {
+		chargeback, err = s.open(ctx, &dispute)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case event.Type == types.ProviderDisputeClosed:
+		if chargeback.Status == dispute.Status {
+			return chargeback, nil
+		}
+		if err := s.resolve(ctx, chargeback, dispute.Status, "provider", ""); err != nil {
+			return nil, err
+		}
+	case chargeback.Status.Open():
+		if dispute.Status.Open() {
+			chargeback.Status = dispute.Status
+		}
+		if dispute.EvidenceDueBy != nil {
+			chargeback.EvidenceDueBy = dispute.EvidenceDueBy
+		}
+		if err := s.chargebacks.UpdateChargeback(ctx, chargeback); err != nil {
+			return nil, err
+		}
+	default:
+		s.logger.WithFields(logger.Fields{
+			"chargeback_id": chargeback.ID,
+			"event_id":      event.ID,
+			"event_type":    event.Type,
+		}).Info("Ignoring dispute event for a closed chargeback")
+	}
+
+	return chargeback, nil
+}
+
+// open creates the chargeback for a dispute and puts its payment into the
+// chargeback status
+func (s *ChargebackService) open(ctx context.Context, dispute *types.ProviderDispute) (*types.Chargeback, error) {
+	payment, err := s.paymentRepo.GetPayment(ctx, dispute.PaymentID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: payment %s: %v", types.ErrInvalidChargeback, dispute.PaymentID, err)
+	}
+
+	amount := dispute.Amount
+	if amount <= 0 {
+		amount = payment.Amount
+	}
+	if dispute.Currency != "" {
+		if err := currency.Match(payment.Currency, dispute.Currency); err != nil {
+			return nil, fmt.Errorf("%w: %v", types.ErrInvalidChargeback, err)
+		}
+	}
+	status := dispute.Status
+	if !status.Open() {
+		status = types.ChargebackStatusNeedsResponse
+	}
+
+	chargeback := &types.Chargeback{
+		ProviderDisputeID: dispute.ID,
+		PaymentID:         payment.ID,
+		TripID:            payment.TripID,
+		UserID:            payment.UserID,
+		DriverID:          payment.DriverID,
+		Amount:            amount,
+		Currency:          payment.Currency,
+		Reason:            dispute.Reason,
+		Status:            status,
+		EvidenceDueBy:     dispute.EvidenceDueBy,
+	}
+	if err := s.chargebacks.CreateChargeback(ctx, chargeback); err != nil {
+		return nil, err
+	}
+	if err := s.setPaymentStatus(ctx, payment, types.PaymentStatusChargeback); err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(logger.Fields{
+		"chargeback_id": chargeback.ID,
+		"payment_id":    payment.ID,
+		"trip_id":       payment.TripID,
+		"driver_id":     payment.DriverID,
+		"amount":        amount,
+		"currency":      payment.Currency,
+		"reason":        dispute.Reason,
+	}).Warn("Chargeback opened, driver fare frozen")
+
+	return chargeback, nil
+}
+
+// SubmitEvidence adds evidence to an open chargeback and moves it under review
+func (s *ChargebackService) SubmitEvidence(ctx context.Context, chargebackID string, req *types.SubmitEvidenceRequest) (*types.Chargeback, error) {
+	if !chargebackEvidenceTypes[req.Type] {
+		return nil, fmt.Errorf("%w: evidence type must be receipt, trip_route, rider_communication or other", types.ErrInvalidChargeback)
+	}
+	if req.Description == "" || req.SubmittedBy == "" {
+		return nil, fmt.Errorf("%w: evidence needs a description and who submitted it", types.ErrInvalidChargeback)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	chargeback, err := s.chargebacks.GetChargeback(ctx, chargebackID)
+	if err != nil {
+		return nil, err
+	}
+	if !chargeback.Status.Open() {
+		return nil, fmt.Errorf("%w: %s is %s", types.ErrChargebackClosed, chargeback.ID, chargeback.Status)
+	}
+
+	if err := s.chargebacks.AddEvidence(ctx, &types.ChargebackEvidence{
+		ChargebackID: chargeback.ID,
+		Type:         req.Type,
+		Description:  req.Description,
+		SubmittedBy:  req.SubmittedBy,
+	}); err != nil {
+		return nil, err
+	}
+	chargeback.Status = types.ChargebackStatusUnderReview
+	if err := s.chargebacks.UpdateChargeback(ctx, chargeback); err != nil {
+		return nil, err
+	}
+
+	return s.withEvidence(ctx, chargeback)
+}
+
+// RecordOutcome decides an open chargeback as won, lost or accepted
+func (s *ChargebackService) RecordOutcome(ctx context.Context, chargebackID string, req *types.RecordOutcomeRequest) (*types.Chargeback, error) {
+	switch req.Outcome {
+	case types.ChargebackStatusWon, types.ChargebackStatusLost, types.ChargebackStatusAccepted:
+	default:
+		return nil, fmt.Errorf("%w: outcome must be won, lost or accepted", types.ErrInvalidChargeback)
+	}
+	if req.ResolvedBy == "" {
+		return nil, fmt.Errorf("%w: resolved by is required", types.ErrInvalidChargeback)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	chargeback, err := s.chargebacks.GetChargeback(ctx, chargebackID)
+	if err != nil {
+		return nil, err
+	}
+	if !chargeback.Status.Open() {
+		return nil, fmt.Errorf("%w: %s is %s", types.ErrChargebackClosed, chargeback.ID, chargeback.Status)
+	}
+
+	if err := s.resolve(ctx, chargeback, req.Outcome, req.ResolvedBy, req.Notes); err != nil {
+		return nil, err
+	}
+	return s.withEvidence(ctx, chargeback)
+}
+
+// resolve decides a chargeback. A won chargeback returns its payment to
+// completed, releasing the driver's fare; a lost or accepted one leaves the
+// payment refunded.
+func (s *ChargebackService) resolve(ctx context.Context, chargeback *types.Chargeback, outcome types.ChargebackStatus, resolvedBy, notes string) error {
+	payment, err := s.paymentRepo.GetPayment(ctx, chargeback.PaymentID)
+	if err != nil {
+		return fmt.Errorf("failed to get charged back payment: %w", err)
+	}
+	paymentStatus := types.PaymentStatusRefunded
+	if outcome == types.ChargebackStatusWon {
+		paymentStatus = types.PaymentStatusCompleted
+	}
+	if err := s.setPaymentStatus(ctx, payment, paymentStatus); err != nil {
+		return err
+	}
+
+	wasLost := chargeback.Status == types.ChargebackStatusLost || chargeback.Status == types.ChargebackStatusAccepted
+	now := time.Now()
+	chargeback.Status = outcome
+	chargeback.ResolvedBy = resolvedBy
+	chargeback.ResolvedAt = &now
+	if notes != "" {
+		chargeback.Notes = notes
+	}
+	if err := s.chargebacks.UpdateChargeback(ctx, chargeback); err != nil {
+		return err
+	}
+	if s.ledger != nil {
+		switch {
+		case outcome != types.ChargebackStatusWon && !wasLost:
+			s.ledger.RecordChargebackLoss(ctx, payment, chargeback)
+		case outcome == types.ChargebackStatusWon && wasLost:
+			s.ledger.RecordChargebackReinstatement(ctx, payment, chargeback)
+		}
+	}
+
+	s.logger.WithFields(logger.Fields{
+		"chargeback_id": chargeback.ID,
+		"payment_id":    chargeback.PaymentID,
+		"outcome":       string(outcome),
+		"resolved_by":   resolvedBy,
+	}).Info("Chargeback resolved")
+	return nil
+}
+
+// setPaymentStatus moves a payment to status, keeping its processor response
+func (s *ChargebackService) setPaymentStatus(ctx context.Context, payment *types.Payment, status types.PaymentStatus) error {
+	if err := s.paymentRepo.UpdatePaymentStatus(ctx, payment.ID, status, payment.ProcessorResponse); err != nil {
+		return fmt.Errorf("failed to update charged back payment: %w", err)
+	}
+	return nil
+}
+
+// GetChargeback returns a chargeback with its evidence
+func (s *ChargebackService) GetChargeback(ctx context.Context, chargebackID string) (*types.Chargeback, error) {
+	chargeback, err := s.chargebacks.GetChargeback(ctx, chargebackID)
+	if err != nil {
+		return nil, err
+	}
+	return s.withEvidence(ctx, chargeback)
+}
+
+// ListChargebacks returns a page of chargebacks in status, all of them when
+// status is empty, oldest first
+func (s *ChargebackService) ListChargebacks(ctx context.Context, status types.ChargebackStatus, page pagination.Request) (*pagination.Page[*types.Chargeback], error) {
+	chargebacks, err := s.chargebacks.ListChargebacks(ctx, status, page)
+	if err != nil {
+		return nil, err
+	}
+	result := pagination.NewPage(chargebacks, page, types.ChargebackSortKey, 0)
+	return &result, nil
+}
+
+func (s *ChargebackService) withEvidence(ctx context.Context, chargeback *types.Chargeback) (*types.Chargeback, error) {
+	evidence, err := s.chargebacks.GetEvidence(ctx, chargeback.ID)
+	if err != nil {
+		return nil, err
+	}
+	chargeback.Evidence = evidence
+	return chargeback, nil
+}
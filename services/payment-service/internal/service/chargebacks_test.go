@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rideshare-platform/services/payment-service/internal/repository"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newChargebackTestService(t *testing.T) (*ChargebackService, *EarningsService, *types.Payment) {
+	earnings, paymentRepo := newEarningsTestService(t)
+	addFare(t, paymentRepo, "trip-1", 20, time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC))
+	payments, err := paymentRepo.GetPaymentsByTrip(context.Background(), "trip-1")
+	require.NoError(t, err)
+	require.Len(t, payments, 1)
+
+	chargebacks := NewChargebackService(repository.NewMockChargebackRepository(), paymentRepo, *logger.NewLogger("error", "test"))
+	return chargebacks, earnings, payments[0]
+}
+
+func disputeEvent(eventType, paymentID string, status types.ChargebackStatus) *types.ProviderDisputeEvent {
+	return &types.ProviderDisputeEvent{
+		ID:   "evt-" + eventType,
+		Type: eventType,
+		Dispute: types.ProviderDispute{
+			ID: "dp_1", PaymentID: paymentID, Currency: "USD", Reason: "fraudulent", Status: status,
+		},
+	}
+}
+
+func statementTotals(t *testing.T, earnings *EarningsService) *types.CurrencyEarnings {
+	statement, err := earnings.Statement(context.Background(), types.StatementRequest{
+		DriverID: "driver-1", From: "2026-03-10", To: "2026-03-10",
+	})
+	require.NoError(t, err)
+	require.Len(t, statement.Totals, 1)
+	return statement.Totals[0]
+}
+
+func TestChargebackService_FreezesFareUntilWon(t *testing.T) {
+	ctx := context.Background()
+	chargebacks, earnings, payment := newChargebackTestService(t)
+
+	chargeback, err := chargebacks.HandleProviderEvent(ctx, disputeEvent(types.ProviderDisputeCreated, payment.ID, types.ChargebackStatusNeedsResponse))
+	require.NoError(t, err)
+	assert.Equal(t, "trip-1", chargeback.TripID)
+	assert.Equal(t, "driver-1", chargeback.DriverID)
+	assert.Equal(t, 20.0, chargeback.Amount)
+	assert.Equal(t, types.ChargebackStatusNeedsResponse, chargeback.Status)
+
+	// A redelivered event does not open a second chargeback
+	again, err := chargebacks.HandleProviderEvent(ctx, disputeEvent(types.ProviderDisputeCreated, payment.ID, types.ChargebackStatusNeedsResponse))
+	require.NoError(t, err)
+	assert.Equal(t, chargeback.ID, again.ID)
+
+	frozen := statementTotals(t, earnings)
+	assert.Equal(t, 0.0, frozen.GrossFares)
+	assert.Equal(t, 20.0, frozen.Frozen)
+	assert.Equal(t, 0.0, frozen.Net)
+
+	chargeback, err = chargebacks.SubmitEvidence(ctx, chargeback.ID, &types.SubmitEvidenceRequest{
+		Type: "trip_route", Description: "GPS trace of the completed trip", SubmittedBy: "admin-1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, types.ChargebackStatusUnderReview, chargeback.Status)
+	require.Len(t, chargeback.Evidence, 1)
+
+	chargeback, err = chargebacks.RecordOutcome(ctx, chargeback.ID, &types.RecordOutcomeRequest{
+		Outcome: types.ChargebackStatusWon, ResolvedBy: "admin-1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, types.ChargebackStatusWon, chargeback.Status)
+	require.NotNil(t, chargeback.ResolvedAt)
+
+	released := statementTotals(t, earnings)
+	assert.Equal(t, 20.0, released.GrossFares)
+	assert.Equal(t, 0.0, released.Frozen)
+	assert.Equal(t, 16.0, released.Net)
+
+	_, err = chargebacks.SubmitEvidence(ctx, chargeback.ID, &types.SubmitEvidenceRequest{
+		Type: "receipt", Description: "Trip receipt", SubmittedBy: "admin-1",
+	})
+	assert.ErrorIs(t, err, types.ErrChargebackClosed)
+}
+
+func TestChargebackService_ProviderClosesAsLost(t *testing.T) {
+	ctx := context.Background()
+	chargebacks, earnings, payment := newChargebackTestService(t)
+
+	// A dispute first seen when it closes still gets a chargeback
+	chargeback, err := chargebacks.HandleProviderEvent(ctx, disputeEvent(types.ProviderDisputeClosed, payment.ID, types.ChargebackStatusLost))
+	require.NoError(t, err)
+	assert.Equal(t, types.ChargebackStatusLost, chargeback.Status)
+	assert.Equal(t, "provider", chargeback.ResolvedBy)
+
+	// The refunded fare is gone from the driver's statement
+	statement, err := earnings.Statement(ctx, types.StatementRequest{
+		DriverID: "driver-1", From: "2026-03-10", To: "2026-03-10",
+	})
+	require.NoError(t, err)
+	assert.Empty(t, statement.Totals)
+	assert.Equal(t, 0, statement.TripsCompleted)
+}
+
+func TestChargebackService_RejectsBadInput(t *testing.T) {
+	ctx := context.Background()
+	chargebacks, _, payment := newChargebackTestService(t)
+
+	_, err := chargebacks.HandleProviderEvent(ctx, disputeEvent("dispute.funds_withdrawn", payment.ID, types.ChargebackStatusNeedsResponse))
+	assert.ErrorIs(t, err, types.ErrInvalidChargeback)
+	_, err = chargebacks.HandleProviderEvent(ctx, disputeEvent(types.ProviderDisputeCreated, "missing-payment", types.ChargebackStatusNeedsResponse))
+	assert.ErrorIs(t, err, types.ErrInvalidChargeback)
+
+	event := disputeEvent(types.ProviderDisputeCreated, payment.ID, types.ChargebackStatusNeedsResponse)
+	event.Dispute.Currency = "EUR"
+	_, err = chargebacks.HandleProviderEvent(ctx, event)
+	assert.ErrorIs(t, err, types.ErrInvalidChargeback)
+
+	chargeback, err := chargebacks.HandleProviderEvent(ctx, disputeEvent(types.ProviderDisputeCreated, payment.ID, types.ChargebackStatusNeedsResponse))
+	require.NoError(t, err)
+	_, err = chargebacks.SubmitEvidence(ctx, chargeback.ID, &types.SubmitEvidenceRequest{Type: "photo", Description: "x", SubmittedBy: "admin-1"})
+	assert.ErrorIs(t, err, types.ErrInvalidChargeback)
+	_, err = chargebacks.RecordOutcome(ctx, chargeback.ID, &types.RecordOutcomeRequest{Outcome: types.ChargebackStatusUnderReview, ResolvedBy: "admin-1"})
+	assert.ErrorIs(t, err, types.ErrInvalidChargeback)
+	_, err = chargebacks.GetChargeback(ctx, "missing")
+	assert.ErrorIs(t, err, types.ErrChargebackNotFound)
+}
@@ -0,0 +1,467 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/rideshare-platform/services/payment-service/internal/repository"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/currency"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+const (
+	// DefaultCommissionRate is the share of fares the platform keeps
+	DefaultCommissionRate = 0.20
+	// MaxStatementDays is the most days one statement covers
+	MaxStatementDays = 31
+
+	statementDateLayout = "2006-01-02"
+)
+
+// ShiftSource reads the shifts drivers worked from their state history
+type ShiftSource interface {
+	// DriverShifts returns the driver's shifts that overlap [from, to)
+	DriverShifts(ctx context.Context, driverID string, from, to time.Time) ([]types.DriverShift, error)
+}
+
+// EarningsService builds drivers' daily statements from the payments for
+// their trips, the tips and incentives paid to them and the shifts they worked
+type EarningsService struct {
+	paymentRepo     repository.PaymentRepository
+	earningRepo     repository.DriverEarningRepository
+	shifts          ShiftSource
+	ledger          *LedgerService
+	commissionRate  float64
+	defaultCurrency string
+	logger          logger.Logger
+
+	// now is when tips and incentives are earned
+	now func() time.Time
+}
+
+// NewEarningsService creates a new earnings service
+func NewEarningsService(paymentRepo repository.PaymentRepository, earningRepo repository.DriverEarningRepository, logger logger.Logger) *EarningsService {
+	return &EarningsService{
+		paymentRepo:     paymentRepo,
+		earningRepo:     earningRepo,
+		commissionRate:  DefaultCommissionRate,
+		defaultCurrency: currency.Default,
+		logger:          logger,
+		now:             time.Now,
+	}
+}
+
+// SetLedger sets the general ledger tips and incentives are posted to
+func (s *EarningsService) SetLedger(ledger *LedgerService) {
+	s.ledger = ledger
+}
+
+// SetShiftSource sets where driver shifts are read from. Statements report
+// no online hours without one.
+func (s *EarningsService) SetShiftSource(shifts ShiftSource) {
+	s.shifts = shifts
+}
+
+// SetCommissionRate sets the share of fares the platform keeps
+func (s *EarningsService) SetCommissionRate(rate float64) error {
+	if rate < 0 || rate >= 1 {
+		return fmt.Errorf("commission rate must be at least 0 and below 1, got %v", rate)
+	}
+	s.commissionRate = rate
+	return nil
+}
+
+// SetDefaultCurrency sets the currency of tips and incentives recorded
+// without one
+func (s *EarningsService) SetDefaultCurrency(code string) error {
+	normalized, err := currency.Normalize(code)
+	if err != nil {
+		return err
+	}
+	s.defaultCurrency = normalized
+	return nil
+}
+
+// RecordEarning records a tip or incentive paid to a driver
+func (s *EarningsService) RecordEarning(ctx context.Context, req *types.RecordEarningRequest) (*types.DriverEarning, error) {
+	if req.DriverID == "" {
+		return nil, fmt.Errorf("%w: driver is required", types.ErrInvalidEarning)
+	}
+	if req.Type != types.EarningTypeTip && req.Type != types.EarningTypeIncentive {
+		return nil, fmt.Errorf("%w: type must be %s or %s", types.ErrInvalidEarning, types.EarningTypeTip, types.EarningTypeIncentive)
+	}
+	if req.Type == types.EarningTypeTip && req.TripID == "" {
+		return nil, fmt.Errorf("%w: tips must name their trip", types.ErrInvalidEarning)
+	}
+	if req.Amount <= 0 {
+		return nil, fmt.Errorf("%w: amount must be greater than zero", types.ErrInvalidEarning)
+	}
+
+	code := req.Currency
+	if code == "" {
+		code = s.defaultCurrency
+	}
+	normalized, err := currency.Normalize(code)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", types.ErrInvalidEarning, err)
+	}
+
+	earning := &types.DriverEarning{
+		DriverID:    req.DriverID,
+		TripID:      req.TripID,
+		Type:        req.Type,
+		Amount:      currency.Round(req.Amount, normalized),
+		Currency:    normalized,
+		Description: req.Description,
+		EarnedAt:    s.now().UTC(),
+	}
+
+	if err := s.earningRepo.CreateEarning(ctx, earning); err != nil {
+		return nil, fmt.Errorf("failed to record driver earning: %w", err)
+	}
+	if s.ledger != nil {
+		s.ledger.RecordEarning(ctx, earning)
+	}
+
+	s.logger.WithFields(logger.Fields{
+		"driver_id": earning.DriverID,
+		"type":      earning.Type,
+		"amount":    earning.Amount,
+		"currency":  earning.Currency,
+	}).Info("Driver earning recorded")
+	return earning, nil
+}
+
+// statementDays are the days a statement covers, each starting at midnight
+// in the statement's timezone
+type statementDays struct {
+	location *time.Location
+	starts   []time.Time // one more than there are days, the last ending the range
+}
+
+func newStatementDays(req types.StatementRequest) (*statementDays, error) {
+	name := req.Timezone
+	if name == "" {
+		name = "UTC"
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unknown timezone %q", types.ErrInvalidStatementRange, req.Timezone)
+	}
+
+	from, err := time.ParseInLocation(statementDateLayout, req.From, location)
+	if err != nil {
+		return nil, fmt.Errorf("%w: from must be a date like 2006-01-02", types.ErrInvalidStatementRange)
+	}
+	to, err := time.ParseInLocation(statementDateLayout, req.To, location)
+	if err != nil {
+		return nil, fmt.Errorf("%w: to must be a date like 2006-01-02", types.ErrInvalidStatementRange)
+	}
+	if to.Before(from) {
+		return nil, fmt.Errorf("%w: to is before from", types.ErrInvalidStatementRange)
+	}
+
+	// Days are counted on the calendar, so a day is 23 or 25 hours long when
+	// clocks change
+	days := &statementDays{location: location}
+	for day := 0; ; day++ {
+		start := time.Date(from.Year(), from.Month(), from.Day()+day, 0, 0, 0, 0, location)
+		days.starts = append(days.starts, start)
+		if start.After(to) {
+			break
+		}
+		if day == MaxStatementDays {
+			return nil, fmt.Errorf("%w: a statement covers at most %d days", types.ErrInvalidStatementRange, MaxStatementDays)
+		}
+	}
+	return days, nil
+}
+
+func (d *statementDays) count() int {
+	return len(d.starts) - 1
+}
+
+func (d *statementDays) from() time.Time {
+	return d.starts[0]
+}
+
+func (d *statementDays) to() time.Time {
+	return d.starts[len(d.starts)-1]
+}
+
+// index returns the day t falls on, or -1 outside the statement
+func (d *statementDays) index(t time.Time) int {
+	if t.Before(d.from()) || !t.Before(d.to()) {
+		return -1
+	}
+	return sort.Search(d.count(), func(i int) bool {
+		return t.Before(d.starts[i+1])
+	})
+}
+
+// minorEarnings accumulates what a driver earned in one currency, in minor units
+type minorEarnings struct {
+	gross, commission, tips, incentives, frozen int64
+}
+
+func (e *minorEarnings) add(other *minorEarnings) {
+	e.gross += other.gross
+	e.commission += other.commission
+	e.tips += other.tips
+	e.incentives += other.incentives
+	e.frozen += other.frozen
+}
+
+func (e *minorEarnings) toEarnings(code string) *types.CurrencyEarnings {
+	return &types.CurrencyEarnings{
+		Currency:   code,
+		GrossFares: currency.FromMinor(e.gross, code),
+		Commission: currency.FromMinor(e.commission, code),
+		Tips:       currency.FromMinor(e.tips, code),
+		Incentives: currency.FromMinor(e.incentives, code),
+		Net:        currency.FromMinor(e.gross-e.commission+e.tips+e.incentives, code),
+		Frozen:     currency.FromMinor(e.frozen, code),
+	}
+}
+
+// byCurrency holds one day's or the statement's earnings per currency
+type byCurrency map[string]*minorEarnings
+
+func (b byCurrency) get(code string) *minorEarnings {
+	if b[code] == nil {
+		b[code] = &minorEarnings{}
+	}
+	return b[code]
+}
+
+func (b byCurrency) toEarnings() []*types.CurrencyEarnings {
+	codes := make([]string, 0, len(b))
+	for code := range b {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	earnings := make([]*types.CurrencyEarnings, 0, len(codes))
+	for _, code := range codes {
+		earnings = append(earnings, b[code].toEarnings(code))
+	}
+	return earnings
+}
+
+// Statement returns a driver's earnings, trips and online hours for each day
+// of the request. Fares count on the day they were paid; only completed
+// payments count, so refunded fares are left out. Fares under chargeback are
+// frozen: their trips count but they are kept out of the driver's net until
+// the chargeback is won. A trip paid in parts counts once, on the day of its
+// first payment.
+func (s *EarningsService) Statement(ctx context.Context, req types.StatementRequest) (*types.EarningsStatement, error) {
+	if req.DriverID == "" {
+		return nil, fmt.Errorf("%w: driver is required", types.ErrInvalidStatementRange)
+	}
+	days, err := newStatementDays(req)
+	if err != nil {
+		return nil, err
+	}
+
+	money := make([]byCurrency, days.count())
+	trips := make([]int, days.count())
+	for i := range money {
+		money[i] = byCurrency{}
+	}
+
+	payments, err := s.paymentRepo.GetDriverPayments(ctx, req.DriverID, days.from(), days.to())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get driver payments: %w", err)
+	}
+	countedTrips := make(map[string]bool)
+	for _, payment := range payments {
+		if payment.TransactionType != types.TransactionTypePayment {
+			continue
+		}
+		if payment.Status != types.PaymentStatusCompleted && payment.Status != types.PaymentStatusChargeback {
+			continue
+		}
+		day := days.index(payment.CreatedAt)
+		if day < 0 {
+			continue
+		}
+		amount := currency.ToMinor(payment.Amount, payment.Currency)
+		if payment.Status == types.PaymentStatusChargeback {
+			money[day].get(payment.Currency).frozen += amount
+		} else {
+			money[day].get(payment.Currency).gross += amount
+		}
+		if payment.TripID != "" && !countedTrips[payment.TripID] {
+			countedTrips[payment.TripID] = true
+			trips[day]++
+		}
+	}
+
+	earnings, err := s.earningRepo.GetDriverEarnings(ctx, req.DriverID, days.from(), days.to())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get driver tips and incentives: %w", err)
+	}
+	for _, earning := range earnings {
+		day := days.index(earning.EarnedAt)
+		if day < 0 {
+			continue
+		}
+		amount := currency.ToMinor(earning.Amount, earning.Currency)
+		switch earning.Type {
+		case types.EarningTypeTip:
+			money[day].get(earning.Currency).tips += amount
+		case types.EarningTypeIncentive:
+			money[day].get(earning.Currency).incentives += amount
+		}
+	}
+
+	online, onlineAvailable := s.onlineSeconds(ctx, req.DriverID, days)
+
+	statement := &types.EarningsStatement{
+		DriverID:             req.DriverID,
+		Timezone:             days.location.String(),
+		From:                 days.from().Format(statementDateLayout),
+		To:                   days.starts[days.count()-1].Format(statementDateLayout),
+		CommissionRate:       s.commissionRate,
+		OnlineHoursAvailable: onlineAvailable,
+		Days:                 make([]*types.DailyEarnings, 0, days.count()),
+	}
+	totals := byCurrency{}
+	var totalOnline int64
+	for day := 0; day < days.count(); day++ {
+		// Commission is taken per day so each day's statement adds up
+		for _, earned := range money[day] {
+			earned.commission = int64(math.Round(float64(earned.gross) * s.commissionRate))
+		}
+		for code, earned := range money[day] {
+			totals.get(code).add(earned)
+		}
+		totalOnline += online[day]
+		statement.TripsCompleted += trips[day]
+
+		statement.Days = append(statement.Days, &types.DailyEarnings{
+			Date:           days.starts[day].Format(statementDateLayout),
+			TripsCompleted: trips[day],
+			OnlineSeconds:  online[day],
+			OnlineHours:    hours(online[day]),
+			Earnings:       money[day].toEarnings(),
+		})
+	}
+	statement.OnlineHours = hours(totalOnline)
+	statement.Totals = totals.toEarnings()
+	return statement, nil
+}
+
+// onlineSeconds splits the driver's shifts over the statement's days. It
+// reports false when shift history cannot be read.
+func (s *EarningsService) onlineSeconds(ctx context.Context, driverID string, days *statementDays) ([]int64, bool) {
+	online := make([]int64, days.count())
+	if s.shifts == nil {
+		return online, false
+	}
+
+	shifts, err := s.shifts.DriverShifts(ctx, driverID, days.from(), days.to())
+	if err != nil {
+		s.logger.WithFields(logger.Fields{
+			"driver_id": driverID,
+			"error":     err.Error(),
+		}).Warn("Failed to get driver shifts, leaving online hours out of the statement")
+		return online, false
+	}
+
+	for _, shift := range shifts {
+		for day := 0; day < days.count(); day++ {
+			start, end := days.starts[day], days.starts[day+1]
+			if shift.StartedAt.After(start) {
+				start = shift.StartedAt
+			}
+			if shift.EndedAt.Before(end) {
+				end = shift.EndedAt
+			}
+			if end.After(start) {
+				online[day] += int64(end.Sub(start).Seconds())
+			}
+		}
+	}
+	return online, true
+}
+
+// hours converts seconds to hours, rounded to two decimals
+func hours(seconds int64) float64 {
+	return math.Round(float64(seconds)/36) / 100
+}
+
+var statementColumns = []string{
+	"date", "trips_completed", "online_hours", "currency",
+	"gross_fares", "commission", "tips", "incentives", "net",
+}
+
+// WriteStatementCSV writes a statement as CSV, a row per day and currency
+// followed by a total row per currency. Days without earnings have a row
+// with no currency.
+func WriteStatementCSV(w io.Writer, statement *types.EarningsStatement) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(statementColumns); err != nil {
+		return err
+	}
+
+	for _, day := range statement.Days {
+		dayColumns := []string{day.Date, strconv.Itoa(day.TripsCompleted), formatHours(day.OnlineHours, statement.OnlineHoursAvailable)}
+		if len(day.Earnings) == 0 {
+			if err := writer.Write(append(dayColumns, "", "", "", "", "", "")); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, earned := range day.Earnings {
+			if err := writer.Write(append(dayColumns, earningsColumns(earned)...)); err != nil {
+				return err
+			}
+		}
+	}
+
+	totalColumns := []string{"total", strconv.Itoa(statement.TripsCompleted), formatHours(statement.OnlineHours, statement.OnlineHoursAvailable)}
+	for _, earned := range statement.Totals {
+		if err := writer.Write(append(totalColumns, earningsColumns(earned)...)); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func earningsColumns(earned *types.CurrencyEarnings) []string {
+	return []string{
+		earned.Currency,
+		formatAmount(earned.GrossFares, earned.Currency),
+		formatAmount(earned.Commission, earned.Currency),
+		formatAmount(earned.Tips, earned.Currency),
+		formatAmount(earned.Incentives, earned.Currency),
+		formatAmount(earned.Net, earned.Currency),
+	}
+}
+
+// formatAmount writes an amount with its currency's decimals and no symbol
+func formatAmount(amount float64, code string) string {
+	decimals := 2
+	if c, err := currency.Lookup(code); err == nil {
+		decimals = c.MinorUnits
+	}
+	return strconv.FormatFloat(amount, 'f', decimals, 64)
+}
+
+// formatHours leaves online hours blank when shift history was unavailable
+func formatHours(hours float64, available bool) string {
+	if !available {
+		return ""
+	}
+	return strconv.FormatFloat(hours, 'f', 2, 64)
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rideshare-platform/services/payment-service/internal/repository"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubShiftSource struct {
+	shifts []types.DriverShift
+	err    error
+}
+
+func (s *stubShiftSource) DriverShifts(ctx context.Context, driverID string, from, to time.Time) ([]types.DriverShift, error) {
+	return s.shifts, s.err
+}
+
+func newEarningsTestService(t *testing.T) (*EarningsService, *repository.MockPaymentRepository) {
+	paymentRepo := repository.NewMockPaymentRepository()
+	return NewEarningsService(paymentRepo, repository.NewMockDriverEarningRepository(), *logger.NewLogger("error", "test")), paymentRepo
+}
+
+// addFare stores a completed fare paid at the given time
+func addFare(t *testing.T, repo *repository.MockPaymentRepository, tripID string, amount float64, paidAt time.Time) {
+	payment := &types.Payment{
+		TripID:          tripID,
+		UserID:          "rider-1",
+		DriverID:        "driver-1",
+		Amount:          amount,
+		Currency:        "USD",
+		Status:          types.PaymentStatusCompleted,
+		TransactionType: types.TransactionTypePayment,
+	}
+	require.NoError(t, repo.CreatePayment(context.Background(), payment))
+	payment.CreatedAt = paidAt
+}
+
+func TestEarningsService_StatementSplitsDaysInTimezone(t *testing.T) {
+	ctx := context.Background()
+	earnings, paymentRepo := newEarningsTestService(t)
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// 01:30 UTC on the 11th is still the evening of the 10th in New York
+	addFare(t, paymentRepo, "trip-1", 20, time.Date(2026, 3, 11, 1, 30, 0, 0, time.UTC))
+	addFare(t, paymentRepo, "trip-2", 10, time.Date(2026, 3, 11, 15, 0, 0, 0, time.UTC))
+	// A second payment for the same trip is not another trip
+	addFare(t, paymentRepo, "trip-2", 2.5, time.Date(2026, 3, 11, 15, 5, 0, 0, time.UTC))
+	refunded := &types.Payment{TripID: "trip-3", DriverID: "driver-1", Amount: 40, Currency: "USD",
+		Status: types.PaymentStatusRefunded, TransactionType: types.TransactionTypePayment}
+	require.NoError(t, paymentRepo.CreatePayment(ctx, refunded))
+	refunded.CreatedAt = time.Date(2026, 3, 11, 16, 0, 0, 0, time.UTC)
+
+	// Tips and incentives are earned when they are recorded
+	earnings.now = func() time.Time { return time.Date(2026, 3, 11, 16, 0, 0, 0, time.UTC) }
+	_, err = earnings.RecordEarning(ctx, &types.RecordEarningRequest{
+		DriverID: "driver-1", TripID: "trip-2", Type: types.EarningTypeTip, Amount: 3,
+	})
+	require.NoError(t, err)
+	_, err = earnings.RecordEarning(ctx, &types.RecordEarningRequest{
+		DriverID: "driver-1", Type: types.EarningTypeIncentive, Amount: 5,
+	})
+	require.NoError(t, err)
+
+	// Online from 22:00 on the 10th to 02:00 on the 11th, New York time
+	earnings.SetShiftSource(&stubShiftSource{shifts: []types.DriverShift{{
+		StartedAt: time.Date(2026, 3, 10, 22, 0, 0, 0, newYork),
+		EndedAt:   time.Date(2026, 3, 11, 2, 0, 0, 0, newYork),
+	}}})
+
+	statement, err := earnings.Statement(ctx, types.StatementRequest{
+		DriverID: "driver-1", From: "2026-03-10", To: "2026-03-11", Timezone: "America/New_York",
+	})
+	require.NoError(t, err)
+	require.Len(t, statement.Days, 2)
+	assert.True(t, statement.OnlineHoursAvailable)
+
+	first, second := statement.Days[0], statement.Days[1]
+	assert.Equal(t, "2026-03-10", first.Date)
+	assert.Equal(t, 1, first.TripsCompleted)
+	assert.Equal(t, 2.0, first.OnlineHours)
+	require.Len(t, first.Earnings, 1)
+	assert.Equal(t, 20.0, first.Earnings[0].GrossFares)
+	assert.Equal(t, 4.0, first.Earnings[0].Commission)
+	assert.Equal(t, 16.0, first.Earnings[0].Net)
+
+	assert.Equal(t, "2026-03-11", second.Date)
+	assert.Equal(t, 1, second.TripsCompleted)
+	assert.Equal(t, 2.0, second.OnlineHours)
+	require.Len(t, second.Earnings, 1)
+	assert.Equal(t, 12.5, second.Earnings[0].GrossFares)
+	assert.Equal(t, 2.5, second.Earnings[0].Commission)
+	assert.Equal(t, 3.0, second.Earnings[0].Tips)
+	assert.Equal(t, 5.0, second.Earnings[0].Incentives)
+	assert.Equal(t, 18.0, second.Earnings[0].Net)
+
+	assert.Equal(t, 2, statement.TripsCompleted)
+	assert.Equal(t, 4.0, statement.OnlineHours)
+	require.Len(t, statement.Totals, 1)
+	assert.Equal(t, 34.0, statement.Totals[0].Net)
+}
+
+func TestEarningsService_StatementWithoutShiftHistory(t *testing.T) {
+	earnings, paymentRepo := newEarningsTestService(t)
+	addFare(t, paymentRepo, "trip-1", 10, time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC))
+	earnings.SetShiftSource(&stubShiftSource{err: errors.New("geo-service unavailable")})
+
+	statement, err := earnings.Statement(context.Background(), types.StatementRequest{
+		DriverID: "driver-1", From: "2026-03-10", To: "2026-03-10",
+	})
+	require.NoError(t, err)
+	assert.False(t, statement.OnlineHoursAvailable)
+	assert.Equal(t, "UTC", statement.Timezone)
+	assert.Equal(t, 1, statement.TripsCompleted)
+}
+
+func TestEarningsService_StatementRejectsBadRanges(t *testing.T) {
+	earnings, _ := newEarningsTestService(t)
+
+	for name, req := range map[string]types.StatementRequest{
+		"unknown timezone": {DriverID: "driver-1", From: "2026-03-10", To: "2026-03-10", Timezone: "Mars/Olympus"},
+		"bad date":         {DriverID: "driver-1", From: "10/03/2026", To: "2026-03-10"},
+		"reversed":         {DriverID: "driver-1", From: "2026-03-10", To: "2026-03-09"},
+		"too long":         {DriverID: "driver-1", From: "2026-01-01", To: "2026-03-01"},
+	} {
+		_, err := earnings.Statement(context.Background(), req)
+		assert.ErrorIs(t, err, types.ErrInvalidStatementRange, name)
+	}
+}
+
+func TestEarningsService_RecordEarningValidates(t *testing.T) {
+	earnings, _ := newEarningsTestService(t)
+
+	_, err := earnings.RecordEarning(context.Background(), &types.RecordEarningRequest{
+		DriverID: "driver-1", Type: types.EarningTypeTip, Amount: 2,
+	})
+	assert.ErrorIs(t, err, types.ErrInvalidEarning)
+
+	_, err = earnings.RecordEarning(context.Background(), &types.RecordEarningRequest{
+		DriverID: "driver-1", Type: types.EarningTypeIncentive, Amount: -1,
+	})
+	assert.ErrorIs(t, err, types.ErrInvalidEarning)
+
+	assert.Error(t, earnings.SetCommissionRate(1))
+}
+
+func TestWriteStatementCSV(t *testing.T) {
+	earnings, paymentRepo := newEarningsTestService(t)
+	addFare(t, paymentRepo, "trip-1", 10, time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC))
+	earnings.SetShiftSource(&stubShiftSource{})
+
+	statement, err := earnings.Statement(context.Background(), types.StatementRequest{
+		DriverID: "driver-1", From: "2026-03-10", To: "2026-03-11",
+	})
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, WriteStatementCSV(&out, statement))
+	assert.Equal(t, strings.Join([]string{
+		"date,trips_completed,online_hours,currency,gross_fares,commission,tips,incentives,net",
+		"2026-03-10,1,0.00,USD,10.00,2.00,0.00,0.00,8.00",
+		"2026-03-11,0,0.00,,,,,,",
+		"total,1,0.00,USD,10.00,2.00,0.00,0.00,8.00",
+		"",
+	}, "\n"), out.String())
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rideshare-platform/services/payment-service/internal/pdf"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// Layout of invoice pages, in points
+const (
+	invoiceMargin     = 56.0
+	invoiceValueX     = 340.0
+	invoiceLineHeight = 16.0
+	invoiceFooterY    = 40.0
+)
+
+// invoiceDateLayouts are the date orders used in invoices, by language and
+// then by region. Other locales get ISO 8601 dates.
+var invoiceDateLayouts = map[string]string{
+	"en": "02/01/2006", "en-US": "01/02/2006", "en-CA": "2006-01-02",
+	"fr": "02/01/2006", "es": "02/01/2006", "it": "02/01/2006", "pt": "02/01/2006",
+	"de": "02.01.2006", "ru": "02.01.2006", "pl": "02.01.2006", "tr": "02.01.2006",
+	"nl": "02-01-2006", "hi": "02/01/2006", "ar": "02/01/2006",
+	"ja": "2006/01/02", "zh": "2006/01/02", "ko": "2006. 01. 02.",
+}
+
+// invoiceFormatter formats numbers and dates the way the invoice's locale
+// writes them
+type invoiceFormatter struct {
+	printer    *message.Printer
+	dateLayout string
+}
+
+func newInvoiceFormatter(locale string) *invoiceFormatter {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		tag = language.MustParse(DefaultInvoiceLocale)
+	}
+	base, _ := tag.Base()
+	region, _ := tag.Region()
+
+	layout, ok := invoiceDateLayouts[base.String()+"-"+region.String()]
+	if !ok {
+		layout, ok = invoiceDateLayouts[base.String()]
+	}
+	if !ok {
+		layout = statementDateLayout
+	}
+	return &invoiceFormatter{printer: message.NewPrinter(tag), dateLayout: layout}
+}
+
+// amount formats an amount with its currency's decimals followed by its code
+func (f *invoiceFormatter) amount(amount float64, code string) string {
+	decimals := 2
+	if c, err := currency.Lookup(code); err == nil {
+		decimals = c.MinorUnits
+	}
+	return f.printer.Sprint(number.Decimal(amount, number.Scale(decimals))) + " " + code
+}
+
+func (f *invoiceFormatter) percent(rate float64) string {
+	return f.printer.Sprint(number.Percent(rate, number.MaxFractionDigits(2)))
+}
+
+func (f *invoiceFormatter) count(n int) string {
+	return f.printer.Sprint(number.Decimal(n))
+}
+
+func (f *invoiceFormatter) date(t time.Time) string {
+	return t.Format(f.dateLayout)
+}
+
+// invoiceWriter lays out lines of text down the pages of an invoice
+type invoiceWriter struct {
+	doc  *pdf.Document
+	page *pdf.Page
+	y    float64
+}
+
+func newInvoiceWriter(title string) *invoiceWriter {
+	w := &invoiceWriter{doc: pdf.New(title)}
+	w.newPage()
+	return w
+}
+
+func (w *invoiceWriter) newPage() {
+	w.page = w.doc.AddPage()
+	w.y = pdf.A4Height - invoiceMargin
+}
+
+// next moves down by lines, starting a new page when the footer is reached
+func (w *invoiceWriter) next(lines float64) {
+	w.y -= lines * invoiceLineHeight
+	if w.y < invoiceFooterY+2*invoiceLineHeight {
+		w.newPage()
+	}
+}
+
+func (w *invoiceWriter) text(font pdf.Font, size float64, text string) {
+	w.page.Text(invoiceMargin, w.y, font, size, text)
+	w.next(1)
+}
+
+func (w *invoiceWriter) row(font pdf.Font, label, value string) {
+	w.page.Text(invoiceMargin, w.y, font, 10, label)
+	w.page.Text(invoiceValueX, w.y, font, 10, value)
+	w.next(1)
+}
+
+func (w *invoiceWriter) rule() {
+	w.page.Line(invoiceMargin, w.y+invoiceLineHeight/2, pdf.A4Width-invoiceMargin, w.y+invoiceLineHeight/2)
+	w.next(0.5)
+}
+
+// renderInvoicePDF renders a driver invoice as a PDF, with numbers and dates
+// formatted for its locale
+func renderInvoicePDF(invoice *types.DriverInvoice) []byte {
+	f := newInvoiceFormatter(invoice.Locale)
+	location, err := time.LoadLocation(invoice.Timezone)
+	if err != nil {
+		location = time.UTC
+	}
+	month, _ := time.ParseInLocation(invoicePeriodLayout, invoice.Period, location)
+	end := month.AddDate(0, 1, -1)
+
+	w := newInvoiceWriter("Driver invoice " + invoice.Number)
+	w.text(pdf.HelveticaBold, 18, "Monthly earnings statement")
+	w.text(pdf.Helvetica, 11, "Driver earnings and platform commission invoice")
+	w.next(0.5)
+
+	w.row(pdf.Helvetica, "Invoice number", invoice.Number)
+	w.row(pdf.Helvetica, "Driver", invoice.DriverID)
+	w.row(pdf.Helvetica, "Period", fmt.Sprintf("%s - %s (%s)", f.date(month), f.date(end), invoice.Timezone))
+	w.row(pdf.Helvetica, "Issued", f.date(invoice.GeneratedAt.In(location)))
+	w.row(pdf.Helvetica, "Revision", f.count(invoice.Revision))
+	if invoice.SupersedesID != "" {
+		w.row(pdf.Helvetica, "Correction", invoice.CorrectionReason)
+	}
+	w.row(pdf.Helvetica, "Trips completed", f.count(invoice.TripsCompleted))
+	w.next(0.5)
+	w.rule()
+
+	if len(invoice.Totals) == 0 {
+		w.text(pdf.Helvetica, 10, "No earnings this month.")
+	}
+	for _, total := range invoice.Totals {
+		w.text(pdf.HelveticaBold, 12, "Earnings in "+total.Currency)
+		w.row(pdf.Helvetica, "Gross fares", f.amount(total.GrossFares, total.Currency))
+		w.row(pdf.Helvetica, fmt.Sprintf("Platform commission (%s)", f.percent(invoice.CommissionRate)), f.amount(-total.Commission, total.Currency))
+		w.row(pdf.Helvetica, "Tips", f.amount(total.Tips, total.Currency))
+		w.row(pdf.Helvetica, "Incentives", f.amount(total.Incentives, total.Currency))
+		if total.Frozen != 0 {
+			w.row(pdf.Helvetica, "Fares held under dispute (not paid)", f.amount(total.Frozen, total.Currency))
+		}
+		w.row(pdf.HelveticaBold, "Net earnings", f.amount(total.Net, total.Currency))
+		w.next(0.5)
+	}
+
+	w.rule()
+	w.text(pdf.Helvetica, 8, "Commission is charged on gross fares only; tips and incentives are paid in full.")
+	if invoice.SupersedesID != "" {
+		w.text(pdf.Helvetica, 8, fmt.Sprintf("This revision replaces revision %s of this invoice.", f.count(invoice.Revision-1)))
+	}
+	return w.doc.Bytes()
+}
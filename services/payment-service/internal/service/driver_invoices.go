@@ -0,0 +1,314 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/services/payment-service/internal/repository"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/pagination"
+	"golang.org/x/text/language"
+)
+
+const (
+	// DefaultInvoiceLocale formats invoices generated without a locale
+	DefaultInvoiceLocale = "en-US"
+	invoicePeriodLayout  = "2006-01"
+	// invoiceBatchSize is how many payments are read at a time when finding
+	// the drivers to invoice for a month
+	invoiceBatchSize = 500
+)
+
+// InvoiceService generates drivers' monthly tax statements from their
+// earnings statements: gross fares, the commission charged on them, tips and
+// incentives per currency, rendered as a PDF in the driver's locale. A month
+// can only be invoiced once it has ended. When a driver's earnings are
+// corrected afterwards, the invoice is regenerated as a new revision that
+// supersedes the previous one, which is kept for the record.
+type InvoiceService struct {
+	invoices      repository.DriverInvoiceRepository
+	earnings      *EarningsService
+	paymentRepo   repository.PaymentRepository
+	defaultLocale language.Tag
+	logger        logger.Logger
+
+	// Invoices for one driver and month are generated one at a time
+	mutex sync.Mutex
+	// lastInvoiced is the last month Start invoiced every driver for
+	lastInvoiced string
+}
+
+// NewInvoiceService creates a new invoice service. Invoices total what
+// earnings statements report; paymentRepo finds the drivers paid in a month.
+func NewInvoiceService(invoices repository.DriverInvoiceRepository, earnings *EarningsService, paymentRepo repository.PaymentRepository, logger logger.Logger) *InvoiceService {
+	return &InvoiceService{
+		invoices:      invoices,
+		earnings:      earnings,
+		paymentRepo:   paymentRepo,
+		defaultLocale: language.MustParse(DefaultInvoiceLocale),
+		logger:        logger,
+	}
+}
+
+// SetDefaultLocale sets the locale of invoices generated without one, such
+// as the ones generated for every driver at the end of a month
+func (s *InvoiceService) SetDefaultLocale(locale string) error {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return fmt.Errorf("invalid locale %q: %w", locale, err)
+	}
+	s.defaultLocale = tag
+	return nil
+}
+
+// GenerateInvoice generates a driver's invoice for a month that has ended in
+// the invoice's timezone. It fails with types.ErrInvoiceExists if the month
+// was already invoiced.
+func (s *InvoiceService) GenerateInvoice(ctx context.Context, req *types.GenerateInvoiceRequest) (*types.DriverInvoice, error) {
+	if req.DriverID == "" {
+		return nil, fmt.Errorf("%w: driver is required", types.ErrInvalidInvoice)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, err := s.invoices.GetIssuedInvoice(ctx, req.DriverID, req.Period); err == nil {
+		return nil, types.ErrInvoiceExists
+	} else if !errors.Is(err, types.ErrInvoiceNotFound) {
+		return nil, fmt.Errorf("failed to check for an existing invoice: %w", err)
+	}
+
+	invoice, err := s.build(ctx, req.DriverID, req.Period, req.Locale, req.Timezone, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	invoice.Revision = 1
+	invoice.GeneratedBy = generatedBy(req.GeneratedBy)
+	s.render(invoice)
+
+	if err := s.invoices.CreateInvoice(ctx, invoice); err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(logger.Fields{
+		"driver_id":  invoice.DriverID,
+		"period":     invoice.Period,
+		"invoice_id": invoice.ID,
+	}).Info("Driver invoice generated")
+	return invoice, nil
+}
+
+// RegenerateInvoice issues a new revision of a driver's invoice for the
+// month, reflecting corrections made to their earnings since the current
+// revision was generated. The current revision is superseded, not deleted.
+func (s *InvoiceService) RegenerateInvoice(ctx context.Context, req *types.RegenerateInvoiceRequest) (*types.DriverInvoice, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, fmt.Errorf("%w: a correction reason is required", types.ErrInvalidInvoice)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	current, err := s.invoices.GetIssuedInvoice(ctx, req.DriverID, req.Period)
+	if err != nil {
+		return nil, err
+	}
+
+	locale, timezone := req.Locale, req.Timezone
+	if locale == "" {
+		locale = current.Locale
+	}
+	if timezone == "" {
+		timezone = current.Timezone
+	}
+	invoice, err := s.build(ctx, req.DriverID, req.Period, locale, timezone, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	invoice.Revision = current.Revision + 1
+	invoice.CorrectionReason = reason
+	invoice.SupersedesID = current.ID
+	invoice.GeneratedBy = generatedBy(req.GeneratedBy)
+	s.render(invoice)
+
+	if err := s.invoices.SupersedeInvoice(ctx, current, invoice); err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(logger.Fields{
+		"driver_id":     invoice.DriverID,
+		"period":        invoice.Period,
+		"invoice_id":    invoice.ID,
+		"revision":      invoice.Revision,
+		"supersedes_id": current.ID,
+	}).Info("Driver invoice regenerated")
+	return invoice, nil
+}
+
+// build totals a driver's month into an unsaved invoice
+func (s *InvoiceService) build(ctx context.Context, driverID, period, locale, timezone string, now time.Time) (*types.DriverInvoice, error) {
+	tag := s.defaultLocale
+	if locale != "" {
+		parsed, err := language.Parse(locale)
+		if err != nil {
+			return nil, fmt.Errorf("%w: unknown locale %q", types.ErrInvalidInvoice, locale)
+		}
+		tag = parsed
+	}
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unknown timezone %q", types.ErrInvalidInvoice, timezone)
+	}
+
+	month, err := time.ParseInLocation(invoicePeriodLayout, period, location)
+	if err != nil {
+		return nil, fmt.Errorf("%w: period must be a month like 2006-01", types.ErrInvalidInvoice)
+	}
+	end := month.AddDate(0, 1, 0)
+	if end.After(now) {
+		return nil, fmt.Errorf("%w: %s has not ended yet", types.ErrInvalidInvoice, period)
+	}
+
+	statement, err := s.earnings.Statement(ctx, types.StatementRequest{
+		DriverID: driverID,
+		From:     month.Format(statementDateLayout),
+		To:       end.AddDate(0, 0, -1).Format(statementDateLayout),
+		Timezone: timezone,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to total driver earnings: %w", err)
+	}
+
+	return &types.DriverInvoice{
+		DriverID:       driverID,
+		Period:         period,
+		Timezone:       timezone,
+		Locale:         tag.String(),
+		Status:         types.InvoiceStatusIssued,
+		CommissionRate: statement.CommissionRate,
+		TripsCompleted: statement.TripsCompleted,
+		Totals:         statement.Totals,
+		GeneratedAt:    now,
+	}, nil
+}
+
+// render numbers the invoice and renders its PDF
+func (s *InvoiceService) render(invoice *types.DriverInvoice) {
+	invoice.Number = fmt.Sprintf("INV-%s-%s-R%d", strings.ReplaceAll(invoice.Period, "-", ""), invoice.DriverID, invoice.Revision)
+	invoice.PDF = renderInvoicePDF(invoice)
+}
+
+// GetInvoice returns an invoice with its PDF
+func (s *InvoiceService) GetInvoice(ctx context.Context, invoiceID string) (*types.DriverInvoice, error) {
+	return s.invoices.GetInvoice(ctx, invoiceID)
+}
+
+// ListDriverInvoices returns every revision of a driver's invoices, newest
+// month first
+func (s *InvoiceService) ListDriverInvoices(ctx context.Context, driverID string, limit, offset int) ([]*types.DriverInvoice, error) {
+	return s.invoices.ListDriverInvoices(ctx, driverID, limit, offset)
+}
+
+// GenerateMonth invoices every driver paid during the month, in UTC and the
+// default locale, skipping drivers already invoiced. It returns how many
+// invoices were generated.
+func (s *InvoiceService) GenerateMonth(ctx context.Context, period string) (int, error) {
+	month, err := time.Parse(invoicePeriodLayout, period)
+	if err != nil {
+		return 0, fmt.Errorf("%w: period must be a month like 2006-01", types.ErrInvalidInvoice)
+	}
+	drivers, err := s.driversPaidBetween(ctx, month, month.AddDate(0, 1, 0))
+	if err != nil {
+		return 0, err
+	}
+
+	generated := 0
+	for _, driverID := range drivers {
+		_, err := s.GenerateInvoice(ctx, &types.GenerateInvoiceRequest{DriverID: driverID, Period: period, GeneratedBy: "system"})
+		if errors.Is(err, types.ErrInvoiceExists) {
+			continue
+		}
+		if err != nil {
+			return generated, fmt.Errorf("failed to invoice driver %s: %w", driverID, err)
+		}
+		generated++
+	}
+	return generated, nil
+}
+
+// driversPaidBetween returns the drivers with payments created in [from, to)
+func (s *InvoiceService) driversPaidBetween(ctx context.Context, from, to time.Time) ([]string, error) {
+	seen := make(map[string]bool)
+	page := pagination.Request{Limit: invoiceBatchSize, Sort: types.PaymentListOptions.Default}
+	sortKey := types.PaymentSortKey(page.Sort)
+	for {
+		payments, err := s.paymentRepo.GetPaymentsCreatedBetween(ctx, from, to, page)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list payments: %w", err)
+		}
+		result := pagination.NewPage(payments, page, sortKey, 0)
+		for _, payment := range result.Items {
+			if payment.DriverID != "" {
+				seen[payment.DriverID] = true
+			}
+		}
+		if result.NextCursor == "" {
+			break
+		}
+		after := sortKey(result.Items[len(result.Items)-1])
+		page.After = &after
+	}
+
+	drivers := make([]string, 0, len(seen))
+	for driverID := range seen {
+		drivers = append(drivers, driverID)
+	}
+	sort.Strings(drivers)
+	return drivers, nil
+}
+
+// Start invoices every driver for the previous month once it has ended,
+// checking every interval until ctx is cancelled
+func (s *InvoiceService) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			now := time.Now().UTC()
+			period := time.Date(now.Year(), now.Month()-1, 1, 0, 0, 0, 0, time.UTC).Format(invoicePeriodLayout)
+			if period == s.lastInvoiced {
+				continue
+			}
+			generated, err := s.GenerateMonth(ctx, period)
+			if err != nil {
+				if ctx.Err() == nil {
+					s.logger.WithFields(logger.Fields{"period": period, "error": err.Error()}).Error("Monthly driver invoicing failed")
+				}
+				continue
+			}
+			s.lastInvoiced = period
+			s.logger.WithFields(logger.Fields{"period": period, "generated": generated}).Info("Monthly driver invoices generated")
+		}
+	}
+}
+
+func generatedBy(by string) string {
+	if by == "" {
+		return "system"
+	}
+	return by
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rideshare-platform/services/payment-service/internal/repository"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newInvoiceTestService(t *testing.T) (*InvoiceService, *EarningsService, *repository.MockPaymentRepository) {
+	earnings, paymentRepo := newEarningsTestService(t)
+	invoices := NewInvoiceService(repository.NewMockDriverInvoiceRepository(), earnings, paymentRepo, *logger.NewLogger("error", "test"))
+	return invoices, earnings, paymentRepo
+}
+
+func TestInvoiceService_GeneratesMonthlyInvoiceInLocale(t *testing.T) {
+	ctx := context.Background()
+	invoices, earnings, paymentRepo := newInvoiceTestService(t)
+	addFare(t, paymentRepo, "trip-1", 1200, time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC))
+	// Paid in April, so not on March's invoice
+	addFare(t, paymentRepo, "trip-2", 50, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC))
+	earnings.now = func() time.Time { return time.Date(2026, 3, 10, 13, 0, 0, 0, time.UTC) }
+	_, err := earnings.RecordEarning(ctx, &types.RecordEarningRequest{
+		DriverID: "driver-1", TripID: "trip-1", Type: types.EarningTypeTip, Amount: 3,
+	})
+	require.NoError(t, err)
+
+	invoice, err := invoices.GenerateInvoice(ctx, &types.GenerateInvoiceRequest{DriverID: "driver-1", Period: "2026-03", Locale: "de-DE"})
+	require.NoError(t, err)
+	assert.Equal(t, "INV-202603-driver-1-R1", invoice.Number)
+	assert.Equal(t, 1, invoice.Revision)
+	assert.Equal(t, types.InvoiceStatusIssued, invoice.Status)
+	assert.Equal(t, "de-DE", invoice.Locale)
+	assert.Equal(t, "UTC", invoice.Timezone)
+	assert.Equal(t, 1, invoice.TripsCompleted)
+	require.Len(t, invoice.Totals, 1)
+	assert.Equal(t, 1200.0, invoice.Totals[0].GrossFares)
+	assert.Equal(t, 240.0, invoice.Totals[0].Commission)
+	assert.Equal(t, 963.0, invoice.Totals[0].Net)
+
+	stored, err := invoices.GetInvoice(ctx, invoice.ID)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(stored.PDF, []byte("%PDF-1.4")))
+	assert.Contains(t, string(stored.PDF), "(1.200,00 USD)")
+	assert.Contains(t, string(stored.PDF), "(963,00 USD)")
+	assert.Contains(t, string(stored.PDF), "(01.03.2026 - 31.03.2026 \\(UTC\\))")
+
+	_, err = invoices.GenerateInvoice(ctx, &types.GenerateInvoiceRequest{DriverID: "driver-1", Period: "2026-03"})
+	assert.ErrorIs(t, err, types.ErrInvoiceExists)
+}
+
+func TestInvoiceService_RegeneratesCorrectedInvoice(t *testing.T) {
+	ctx := context.Background()
+	invoices, earnings, paymentRepo := newInvoiceTestService(t)
+	addFare(t, paymentRepo, "trip-1", 20, time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC))
+
+	first, err := invoices.GenerateInvoice(ctx, &types.GenerateInvoiceRequest{DriverID: "driver-1", Period: "2026-03", Locale: "en-US"})
+	require.NoError(t, err)
+	assert.Equal(t, 16.0, first.Totals[0].Net)
+
+	_, err = invoices.RegenerateInvoice(ctx, &types.RegenerateInvoiceRequest{DriverID: "driver-1", Period: "2026-03"})
+	assert.ErrorIs(t, err, types.ErrInvalidInvoice)
+	_, err = invoices.RegenerateInvoice(ctx, &types.RegenerateInvoiceRequest{DriverID: "driver-1", Period: "2026-02", Reason: "Missed incentive"})
+	assert.ErrorIs(t, err, types.ErrInvoiceNotFound)
+
+	// An incentive for March missing from the first invoice
+	earnings.now = func() time.Time { return time.Date(2026, 3, 31, 20, 0, 0, 0, time.UTC) }
+	_, err = earnings.RecordEarning(ctx, &types.RecordEarningRequest{
+		DriverID: "driver-1", Type: types.EarningTypeIncentive, Amount: 5,
+	})
+	require.NoError(t, err)
+
+	second, err := invoices.RegenerateInvoice(ctx, &types.RegenerateInvoiceRequest{
+		DriverID: "driver-1", Period: "2026-03", Reason: "Missed incentive", GeneratedBy: "admin-1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, second.Revision)
+	assert.Equal(t, "INV-202603-driver-1-R2", second.Number)
+	assert.Equal(t, first.ID, second.SupersedesID)
+	assert.Equal(t, "en-US", second.Locale)
+	assert.Equal(t, "admin-1", second.GeneratedBy)
+	assert.Equal(t, 21.0, second.Totals[0].Net)
+
+	listed, err := invoices.ListDriverInvoices(ctx, "driver-1", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+	assert.Equal(t, second.ID, listed[0].ID)
+	assert.Equal(t, types.InvoiceStatusSuperseded, listed[1].Status)
+	assert.NotNil(t, listed[1].SupersededAt)
+	assert.Nil(t, listed[0].PDF)
+
+	pdf, err := invoices.GetInvoice(ctx, second.ID)
+	require.NoError(t, err)
+	assert.Contains(t, string(pdf.PDF), "(Missed incentive)")
+	assert.Contains(t, string(pdf.PDF), "(03/01/2026 - 03/31/2026 \\(UTC\\))")
+}
+
+func TestInvoiceService_RejectsUnfinishedMonthsAndBadInput(t *testing.T) {
+	ctx := context.Background()
+	invoices, _, _ := newInvoiceTestService(t)
+
+	current := time.Now().UTC().Format("2006-01")
+	_, err := invoices.GenerateInvoice(ctx, &types.GenerateInvoiceRequest{DriverID: "driver-1", Period: current})
+	assert.ErrorIs(t, err, types.ErrInvalidInvoice)
+	_, err = invoices.GenerateInvoice(ctx, &types.GenerateInvoiceRequest{DriverID: "driver-1", Period: "March"})
+	assert.ErrorIs(t, err, types.ErrInvalidInvoice)
+	_, err = invoices.GenerateInvoice(ctx, &types.GenerateInvoiceRequest{DriverID: "driver-1", Period: "2026-03", Locale: "not a locale!"})
+	assert.ErrorIs(t, err, types.ErrInvalidInvoice)
+	_, err = invoices.GenerateInvoice(ctx, &types.GenerateInvoiceRequest{DriverID: "driver-1", Period: "2026-03", Timezone: "Mars/Olympus"})
+	assert.ErrorIs(t, err, types.ErrInvalidInvoice)
+	_, err = invoices.GenerateInvoice(ctx, &types.GenerateInvoiceRequest{Period: "2026-03"})
+	assert.ErrorIs(t, err, types.ErrInvalidInvoice)
+	assert.Error(t, invoices.SetDefaultLocale("not a locale!"))
+}
+
+func TestInvoiceService_GenerateMonthInvoicesEveryPaidDriver(t *testing.T) {
+	ctx := context.Background()
+	invoices, _, paymentRepo := newInvoiceTestService(t)
+	addFare(t, paymentRepo, "trip-1", 20, time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC))
+	other := &types.Payment{TripID: "trip-2", UserID: "rider-2", DriverID: "driver-2", Amount: 30, Currency: "USD",
+		Status: types.PaymentStatusCompleted, TransactionType: types.TransactionTypePayment}
+	require.NoError(t, paymentRepo.CreatePayment(ctx, other))
+	other.CreatedAt = time.Date(2026, 3, 20, 12, 0, 0, 0, time.UTC)
+
+	_, err := invoices.GenerateInvoice(ctx, &types.GenerateInvoiceRequest{DriverID: "driver-1", Period: "2026-03"})
+	require.NoError(t, err)
+
+	generated, err := invoices.GenerateMonth(ctx, "2026-03")
+	require.NoError(t, err)
+	assert.Equal(t, 1, generated)
+
+	listed, err := invoices.ListDriverInvoices(ctx, "driver-2", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, "system", listed[0].GeneratedBy)
+	assert.Equal(t, DefaultInvoiceLocale, listed[0].Locale)
+}
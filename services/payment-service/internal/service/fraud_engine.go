@@ -0,0 +1,603 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rideshare-platform/services/payment-service/internal/repository"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/currency"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+	"github.com/rideshare-platform/shared/pagination"
+)
+
+// TripLocationProvider resolves the pickup location of a trip for geo checks
+type TripLocationProvider interface {
+	GetPickupLocation(ctx context.Context, tripID string) (*models.Location, error)
+}
+
+// FraudEngineConfig holds the tunable thresholds of the rules-based fraud engine
+type FraudEngineConfig struct {
+	VelocityWindow         time.Duration `json:"velocity_window"`
+	MaxPaymentsPerWindow   int           `json:"max_payments_per_window"`
+	AnomalyZScoreThreshold float64       `json:"anomaly_zscore_threshold"`
+	MinHistoryForAnomaly   int           `json:"min_history_for_anomaly"`
+	MaxPickupDistanceKm    float64       `json:"max_pickup_distance_km"`
+	HistoryLimit           int           `json:"history_limit"`
+	RuleRefreshInterval    time.Duration `json:"rule_refresh_interval"`
+	ReviewThreshold        float64       `json:"review_threshold"`
+	BlockThreshold         float64       `json:"block_threshold"`
+	// BaseCurrency is the currency of the absolute amount tiers and the
+	// base_amount rule feature
+	BaseCurrency string `json:"base_currency"`
+}
+
+// DefaultFraudEngineConfig returns the default fraud engine configuration
+func DefaultFraudEngineConfig() FraudEngineConfig {
+	return FraudEngineConfig{
+		VelocityWindow:         time.Hour,
+		MaxPaymentsPerWindow:   5,
+		AnomalyZScoreThreshold: 3.0,
+		MinHistoryForAnomaly:   5,
+		MaxPickupDistanceKm:    50.0,
+		HistoryLimit:           100,
+		RuleRefreshInterval:    time.Minute,
+		ReviewThreshold:        0.5,
+		BlockThreshold:         0.8,
+		BaseCurrency:           currency.Default,
+	}
+}
+
+// RulesFraudDetectionService is a rules-based fraud engine combining built-in
+// velocity, amount anomaly and geo checks with configurable DSL rules
+type RulesFraudDetectionService struct {
+	paymentRepo   repository.PaymentRepository
+	ruleRepo      repository.FraudRuleRepository
+	reviewRepo    repository.FraudReviewRepository
+	tripLocations TripLocationProvider
+	rates         currency.RateProvider
+	config        FraudEngineConfig
+	logger        logger.Logger
+
+	mutex         sync.RWMutex
+	rules         []*CompiledFraudRule
+	rulesLoadedAt time.Time
+}
+
+// NewRulesFraudDetectionService creates a new rules-based fraud detection service
+func NewRulesFraudDetectionService(
+	paymentRepo repository.PaymentRepository,
+	ruleRepo repository.FraudRuleRepository,
+	reviewRepo repository.FraudReviewRepository,
+	tripLocations TripLocationProvider,
+	config FraudEngineConfig,
+	logger logger.Logger,
+) *RulesFraudDetectionService {
+	return &RulesFraudDetectionService{
+		paymentRepo:   paymentRepo,
+		ruleRepo:      ruleRepo,
+		reviewRepo:    reviewRepo,
+		tripLocations: tripLocations,
+		config:        config,
+		logger:        logger,
+	}
+}
+
+// SetExchangeRates lets payments in other currencies than the base currency
+// be scored against the absolute amount tiers. Without rates only payments in
+// the base currency are.
+func (s *RulesFraudDetectionService) SetExchangeRates(rates currency.RateProvider) {
+	s.rates = rates
+}
+
+// AnalyzeTransaction evaluates a payment against the built-in checks and the configured rules
+func (s *RulesFraudDetectionService) AnalyzeTransaction(ctx context.Context, payment *types.Payment) (*types.FraudDetectionResult, error) {
+	result := &types.FraudDetectionResult{
+		TransactionID: payment.ID,
+		Scores:        make(map[string]float64),
+		Reasons:       []string{},
+	}
+
+	features, err := s.buildFeatures(ctx, payment)
+	if err != nil {
+		return nil, err
+	}
+
+	// Built-in checks
+	result.Scores["velocity"] = s.velocityScore(features)
+	result.Scores["amount"] = s.amountScore(features)
+	result.Scores["geo"] = s.geoScore(features)
+
+	weights := map[string]float64{
+		"velocity": 0.4,
+		"amount":   0.35,
+		"geo":      0.25,
+	}
+
+	var totalScore float64
+	for factor, score := range result.Scores {
+		totalScore += score * weights[factor]
+	}
+
+	if result.Scores["velocity"] >= 1.0 {
+		result.Reasons = append(result.Reasons, fmt.Sprintf("High transaction frequency: %.0f payments in %s",
+			features["velocity_window"], s.config.VelocityWindow))
+	}
+	if result.Scores["amount"] > 0.7 {
+		result.Reasons = append(result.Reasons, "Amount deviates significantly from user history")
+	}
+	if result.Scores["geo"] > 0.7 {
+		result.Reasons = append(result.Reasons, fmt.Sprintf("Payment location %.1f km from trip pickup", features["geo_distance_km"]))
+	}
+
+	// Configured rules
+	forceBlock := false
+	forceReview := false
+	for _, rule := range s.activeRules(ctx) {
+		if !rule.Matches(features) {
+			continue
+		}
+
+		result.MatchedRules = append(result.MatchedRules, rule.Name)
+		result.Reasons = append(result.Reasons, fmt.Sprintf("Rule matched: %s", rule.Name))
+		totalScore += rule.Score
+
+		switch rule.Action {
+		case types.FraudRuleActionBlock:
+			forceBlock = true
+		case types.FraudRuleActionReview:
+			forceReview = true
+		}
+	}
+
+	result.RiskScore = math.Min(totalScore, 1.0)
+
+	// Determine risk level
+	switch {
+	case forceBlock || result.RiskScore >= s.config.BlockThreshold:
+		result.RiskLevel = types.FraudRiskHigh
+		result.RequiresReview = true
+	case forceReview || result.RiskScore >= s.config.ReviewThreshold:
+		result.RiskLevel = types.FraudRiskMedium
+		result.RequiresReview = true
+	default:
+		result.RiskLevel = types.FraudRiskLow
+	}
+
+	if result.RequiresReview {
+		s.enqueueReview(ctx, payment, result)
+	}
+
+	return result, nil
+}
+
+// buildFeatures derives the feature set that built-in checks and rules evaluate against
+func (s *RulesFraudDetectionService) buildFeatures(ctx context.Context, payment *types.Payment) (FraudFeatures, error) {
+	now := time.Now()
+	features := FraudFeatures{
+		"amount":         payment.Amount,
+		"currency":       payment.Currency,
+		"payment_method": string(payment.PaymentMethod),
+		"hour":           float64(now.Hour()),
+	}
+
+	recent := pagination.Request{Limit: s.config.HistoryLimit, Sort: types.PaymentListOptions.Default}
+	history, err := s.paymentRepo.GetPaymentsByUser(ctx, payment.UserID, recent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load payment history: %w", err)
+	}
+	history = history[:min(len(history), recent.Limit)]
+
+	var windowCount, dayCount, failedCount float64
+	var amounts []float64
+	for _, previous := range history {
+		if previous.ID == payment.ID {
+			continue
+		}
+		age := now.Sub(previous.CreatedAt)
+		if age <= s.config.VelocityWindow {
+			windowCount++
+		}
+		if age <= 24*time.Hour {
+			dayCount++
+			if previous.Status == types.PaymentStatusFailed {
+				failedCount++
+			}
+		}
+		// Amounts are only comparable within one currency, so the anomaly
+		// check looks at the user's history in the payment's currency
+		if previous.Status == types.PaymentStatusCompleted && strings.EqualFold(previous.Currency, payment.Currency) {
+			amounts = append(amounts, previous.Amount)
+		}
+	}
+
+	// The current payment counts towards velocity
+	features["velocity_window"] = windowCount + 1
+	features["velocity_1h"] = windowCount + 1
+	features["velocity_24h"] = dayCount + 1
+	features["failed_24h"] = failedCount
+	features["history_count"] = float64(len(amounts))
+
+	mean, stddev := meanAndStdDev(amounts)
+	features["avg_amount"] = mean
+	if stddev > 0 {
+		features["amount_zscore"] = (payment.Amount - mean) / stddev
+	} else {
+		features["amount_zscore"] = 0.0
+	}
+
+	if amount, ok := s.baseAmount(ctx, payment); ok {
+		features["base_amount"] = amount
+	}
+	features["geo_distance_km"] = s.pickupDistance(ctx, payment)
+
+	return features, nil
+}
+
+// baseAmount returns the payment's amount in the base currency. It is not
+// known for other currencies when no exchange rate is configured.
+func (s *RulesFraudDetectionService) baseAmount(ctx context.Context, payment *types.Payment) (float64, bool) {
+	if payment.Currency == "" || strings.EqualFold(payment.Currency, s.config.BaseCurrency) {
+		return payment.Amount, true
+	}
+	if s.rates == nil {
+		return 0, false
+	}
+
+	rate, err := s.rates.Rate(ctx, payment.Currency, s.config.BaseCurrency)
+	if err != nil {
+		s.logger.WithFields(logger.Fields{
+			"payment_id": payment.ID,
+			"currency":   payment.Currency,
+			"error":      err.Error(),
+		}).Warn("Failed to convert payment amount for fraud check")
+		return 0, false
+	}
+	return currency.Round(payment.Amount*rate, s.config.BaseCurrency), true
+}
+
+// pickupDistance returns the distance between the payer's device location and
+// the trip pickup, or -1 when either location is unknown
+func (s *RulesFraudDetectionService) pickupDistance(ctx context.Context, payment *types.Payment) float64 {
+	lat, latOK := metadataFloat(payment.Metadata, "latitude")
+	lng, lngOK := metadataFloat(payment.Metadata, "longitude")
+	if !latOK || !lngOK {
+		return -1
+	}
+	paymentLocation := &models.Location{Latitude: lat, Longitude: lng}
+
+	var pickup *models.Location
+	if s.tripLocations != nil && payment.TripID != "" {
+		location, err := s.tripLocations.GetPickupLocation(ctx, payment.TripID)
+		if err != nil {
+			s.logger.WithFields(logger.Fields{
+				"trip_id": payment.TripID,
+				"error":   err.Error(),
+			}).Warn("Failed to resolve trip pickup location for fraud check")
+		} else {
+			pickup = location
+		}
+	}
+	if pickup == nil {
+		pickupLat, okLat := metadataFloat(payment.Metadata, "pickup_latitude")
+		pickupLng, okLng := metadataFloat(payment.Metadata, "pickup_longitude")
+		if !okLat || !okLng {
+			return -1
+		}
+		pickup = &models.Location{Latitude: pickupLat, Longitude: pickupLng}
+	}
+
+	return paymentLocation.DistanceTo(pickup)
+}
+
+func (s *RulesFraudDetectionService) velocityScore(features FraudFeatures) float64 {
+	count := features["velocity_window"].(float64)
+	if s.config.MaxPaymentsPerWindow <= 0 {
+		return 0
+	}
+	return math.Min(count/float64(s.config.MaxPaymentsPerWindow), 1.0)
+}
+
+func (s *RulesFraudDetectionService) amountScore(features FraudFeatures) float64 {
+	if int(features["history_count"].(float64)) < s.config.MinHistoryForAnomaly {
+		// Not enough history for anomaly detection, fall back to absolute
+		// tiers in the base currency
+		amount, ok := features["base_amount"].(float64)
+		if !ok {
+			// The amount cannot be compared with the tiers
+			return 0.1
+		}
+		switch {
+		case amount > 1000:
+			return 0.9
+		case amount > 500:
+			return 0.7
+		case amount > 100:
+			return 0.4
+		default:
+			return 0.1
+		}
+	}
+
+	zscore := features["amount_zscore"].(float64)
+	if zscore <= 0 {
+		return 0
+	}
+	return math.Min(zscore/s.config.AnomalyZScoreThreshold, 1.0)
+}
+
+func (s *RulesFraudDetectionService) geoScore(features FraudFeatures) float64 {
+	distance := features["geo_distance_km"].(float64)
+	if distance < 0 || s.config.MaxPickupDistanceKm <= 0 {
+		return 0
+	}
+	return math.Min(distance/s.config.MaxPickupDistanceKm, 1.0)
+}
+
+// activeRules returns the compiled rule set, reloading it from the repository when stale
+func (s *RulesFraudDetectionService) activeRules(ctx context.Context) []*CompiledFraudRule {
+	s.mutex.RLock()
+	fresh := !s.rulesLoadedAt.IsZero() && time.Since(s.rulesLoadedAt) < s.config.RuleRefreshInterval
+	rules := s.rules
+	s.mutex.RUnlock()
+
+	if fresh || s.ruleRepo == nil {
+		return rules
+	}
+
+	if err := s.ReloadRules(ctx); err != nil {
+		s.logger.WithFields(logger.Fields{
+			"error": err.Error(),
+		}).Warn("Failed to reload fraud rules, using cached rule set")
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.rules
+}
+
+// ReloadRules loads and compiles all enabled rules from the rule repository.
+// Invalid rules are logged and skipped so a single bad rule cannot disable the engine.
+func (s *RulesFraudDetectionService) ReloadRules(ctx context.Context) error {
+	stored, err := s.ruleRepo.GetActiveRules(ctx)
+	if err != nil {
+		return err
+	}
+
+	compiled := make([]*CompiledFraudRule, 0, len(stored))
+	for _, rule := range stored {
+		c, err := CompileFraudRule(rule)
+		if err != nil {
+			s.logger.WithFields(logger.Fields{
+				"rule_id": rule.ID,
+				"error":   err.Error(),
+			}).Error("Skipping invalid fraud rule")
+			continue
+		}
+		compiled = append(compiled, c)
+	}
+
+	s.mutex.Lock()
+	s.rules = compiled
+	s.rulesLoadedAt = time.Now()
+	s.mutex.Unlock()
+
+	return nil
+}
+
+// SeedDefaultRules stores the default rule set if the repository has no rules yet
+func (s *RulesFraudDetectionService) SeedDefaultRules(ctx context.Context) error {
+	existing, err := s.ruleRepo.GetActiveRules(ctx)
+	if err != nil {
+		return err
+	}
+	if len(existing) > 0 {
+		return nil
+	}
+
+	for _, rule := range DefaultFraudRules() {
+		if err := s.ruleRepo.SaveRule(ctx, rule); err != nil {
+			return err
+		}
+	}
+
+	return s.ReloadRules(ctx)
+}
+
+// DefaultFraudRules returns the baseline rule set used when none is configured
+func DefaultFraudRules() []*types.FraudRule {
+	return []*types.FraudRule{
+		{
+			Name:        "burst_velocity",
+			Description: "Many payments in a short time with a high amount",
+			Expression:  "velocity_1h >= 4 && base_amount > 150",
+			Score:       0.5,
+			Action:      types.FraudRuleActionReview,
+			Priority:    100,
+			Enabled:     true,
+		},
+		{
+			Name:        "repeated_failures",
+			Description: "Multiple failed payments in the last day",
+			Expression:  "failed_24h >= 3",
+			Score:       0.3,
+			Action:      types.FraudRuleActionReview,
+			Priority:    90,
+			Enabled:     true,
+		},
+		{
+			Name:        "extreme_amount_anomaly",
+			Description: "Amount far outside the user's normal spend",
+			Expression:  "history_count >= 5 && amount_zscore >= 6",
+			Score:       0.6,
+			Action:      types.FraudRuleActionBlock,
+			Priority:    80,
+			Enabled:     true,
+		},
+		{
+			Name:        "remote_payment_location",
+			Description: "Payer device far away from the trip pickup",
+			Expression:  "geo_distance_km > 200",
+			Score:       0.4,
+			Action:      types.FraudRuleActionReview,
+			Priority:    70,
+			Enabled:     true,
+		},
+	}
+}
+
+// ListRules returns the enabled fraud rules
+func (s *RulesFraudDetectionService) ListRules(ctx context.Context) ([]*types.FraudRule, error) {
+	return s.ruleRepo.GetActiveRules(ctx)
+}
+
+// SaveRule validates and stores a fraud rule, then refreshes the compiled rule set
+func (s *RulesFraudDetectionService) SaveRule(ctx context.Context, rule *types.FraudRule) error {
+	if rule.Name == "" {
+		return fmt.Errorf("%w: rule name is required", types.ErrInvalidFraudRule)
+	}
+	switch rule.Action {
+	case types.FraudRuleActionFlag, types.FraudRuleActionReview, types.FraudRuleActionBlock:
+	case "":
+		rule.Action = types.FraudRuleActionFlag
+	default:
+		return fmt.Errorf("%w: invalid rule action: %s", types.ErrInvalidFraudRule, rule.Action)
+	}
+	if _, err := CompileFraudRule(rule); err != nil {
+		return fmt.Errorf("%w: %v", types.ErrInvalidFraudRule, err)
+	}
+
+	if err := s.ruleRepo.SaveRule(ctx, rule); err != nil {
+		return err
+	}
+
+	return s.ReloadRules(ctx)
+}
+
+// SetRuleEnabled switches a fraud rule on or off and refreshes the compiled rule set
+func (s *RulesFraudDetectionService) SetRuleEnabled(ctx context.Context, ruleID string, enabled bool) error {
+	if err := s.ruleRepo.SetRuleEnabled(ctx, ruleID, enabled); err != nil {
+		return err
+	}
+
+	return s.ReloadRules(ctx)
+}
+
+// DeleteRule removes a fraud rule and refreshes the compiled rule set
+func (s *RulesFraudDetectionService) DeleteRule(ctx context.Context, ruleID string) error {
+	if err := s.ruleRepo.DeleteRule(ctx, ruleID); err != nil {
+		return err
+	}
+
+	return s.ReloadRules(ctx)
+}
+
+// ListReviews returns flagged payments in the review queue with the given status
+func (s *RulesFraudDetectionService) ListReviews(ctx context.Context, status types.FraudReviewStatus, limit, offset int) ([]*types.FraudReview, error) {
+	if status == "" {
+		status = types.FraudReviewPending
+	}
+	return s.reviewRepo.ListReviews(ctx, status, limit, offset)
+}
+
+// ResolveReview records a reviewer decision on a flagged payment. Only
+// pending reviews can be decided; the repository enforces this as part of
+// the update, so concurrent decisions cannot overwrite each other.
+func (s *RulesFraudDetectionService) ResolveReview(ctx context.Context, reviewID string, approve bool, req *types.ResolveFraudReviewRequest) (*types.FraudReview, error) {
+	review, err := s.reviewRepo.GetReview(ctx, reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if review.Status != types.FraudReviewPending {
+		return nil, fmt.Errorf("%w: %s is %s", types.ErrFraudReviewResolved, reviewID, review.Status)
+	}
+
+	status := types.FraudReviewRejected
+	if approve {
+		status = types.FraudReviewApproved
+	}
+
+	if err := s.reviewRepo.UpdateReviewStatus(ctx, reviewID, status, req.ReviewedBy, req.Notes); err != nil {
+		return nil, err
+	}
+
+	s.logger.WithFields(logger.Fields{
+		"review_id":   reviewID,
+		"payment_id":  review.PaymentID,
+		"status":      status,
+		"reviewed_by": req.ReviewedBy,
+	}).Info("Fraud review resolved")
+
+	return s.reviewRepo.GetReview(ctx, reviewID)
+}
+
+func (s *RulesFraudDetectionService) enqueueReview(ctx context.Context, payment *types.Payment, result *types.FraudDetectionResult) {
+	if s.reviewRepo == nil {
+		return
+	}
+
+	review := &types.FraudReview{
+		ID:           uuid.New().String(),
+		PaymentID:    payment.ID,
+		UserID:       payment.UserID,
+		Amount:       payment.Amount,
+		Currency:     payment.Currency,
+		RiskLevel:    result.RiskLevel,
+		RiskScore:    result.RiskScore,
+		Reasons:      result.Reasons,
+		MatchedRules: result.MatchedRules,
+		Scores:       result.Scores,
+		Status:       types.FraudReviewPending,
+		CreatedAt:    time.Now(),
+	}
+
+	if err := s.reviewRepo.CreateReview(ctx, review); err != nil {
+		s.logger.WithFields(logger.Fields{
+			"payment_id": payment.ID,
+			"error":      err.Error(),
+		}).Error("Failed to enqueue payment for fraud review")
+	}
+}
+
+func metadataFloat(metadata map[string]interface{}, key string) (float64, bool) {
+	if metadata == nil {
+		return 0, false
+	}
+	switch v := metadata[key].(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+func meanAndStdDev(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	variance /= float64(len(values))
+
+	return mean, math.Sqrt(variance)
+}
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rideshare-platform/services/payment-service/internal/repository"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/currency"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestFraudEngine() (*RulesFraudDetectionService, *repository.MockPaymentRepository, *repository.MockFraudReviewRepository) {
+	paymentRepo := repository.NewMockPaymentRepository()
+	reviewRepo := repository.NewMockFraudReviewRepository()
+	engine := NewRulesFraudDetectionService(
+		paymentRepo,
+		repository.NewMockFraudRuleRepository(),
+		reviewRepo,
+		nil,
+		DefaultFraudEngineConfig(),
+		*logger.NewLogger("error", "test"),
+	)
+	return engine, paymentRepo, reviewRepo
+}
+
+func TestCompileRuleExpression(t *testing.T) {
+	features := FraudFeatures{
+		"amount":         250.0,
+		"velocity_1h":    3.0,
+		"payment_method": "digital_wallet",
+	}
+
+	tests := []struct {
+		expression string
+		expected   bool
+	}{
+		{"amount > 200", true},
+		{"amount > 200 && velocity_1h >= 4", false},
+		{"amount > 300 || velocity_1h == 3", true},
+		{"!(amount < 100)", true},
+		{`payment_method == "digital_wallet"`, true},
+		{`payment_method != 'digital_wallet'`, false},
+		{"(amount > 300 || velocity_1h > 2) && payment_method == \"digital_wallet\"", true},
+		{"unknown_feature > 0", false},
+	}
+
+	for _, tt := range tests {
+		expr, err := compileRuleExpression(tt.expression)
+		assert.NoError(t, err, tt.expression)
+		if err != nil {
+			continue
+		}
+		assert.Equal(t, tt.expected, expr.eval(features), tt.expression)
+	}
+}
+
+func TestCompileRuleExpression_Invalid(t *testing.T) {
+	invalid := []string{
+		"",
+		"amount >",
+		"amount = 5",
+		"amount > 5 &&",
+		"(amount > 5",
+		`payment_method > "cash"`,
+		"amount > 5 & velocity_1h > 1",
+	}
+
+	for _, expression := range invalid {
+		_, err := compileRuleExpression(expression)
+		assert.Error(t, err, expression)
+	}
+}
+
+func TestRulesFraudDetection_VelocityCheck(t *testing.T) {
+	engine, paymentRepo, reviewRepo := newTestFraudEngine()
+	ctx := context.Background()
+	userID := uuid.New().String()
+
+	for i := 0; i < 5; i++ {
+		paymentRepo.CreatePayment(ctx, &types.Payment{
+			UserID: userID,
+			Amount: 20,
+			Status: types.PaymentStatusCompleted,
+		})
+	}
+
+	payment := &types.Payment{ID: uuid.New().String(), UserID: userID, Amount: 20}
+	result, err := engine.AnalyzeTransaction(ctx, payment)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1.0, result.Scores["velocity"])
+	assert.Contains(t, result.Reasons[0], "High transaction frequency")
+
+	pending, err := reviewRepo.ListReviews(ctx, types.FraudReviewPending, 10, 0)
+	assert.NoError(t, err)
+	if result.RequiresReview {
+		assert.Len(t, pending, 1)
+	} else {
+		assert.Empty(t, pending)
+	}
+}
+
+func TestRulesFraudDetection_AmountAnomaly(t *testing.T) {
+	engine, paymentRepo, _ := newTestFraudEngine()
+	ctx := context.Background()
+	userID := uuid.New().String()
+
+	for _, amount := range []float64{18, 20, 22, 19, 21, 20} {
+		paymentRepo.CreatePayment(ctx, &types.Payment{
+			UserID: userID,
+			Amount: amount,
+			Status: types.PaymentStatusCompleted,
+		})
+	}
+
+	normal, err := engine.AnalyzeTransaction(ctx, &types.Payment{ID: uuid.New().String(), UserID: userID, Amount: 21})
+	assert.NoError(t, err)
+	assert.Less(t, normal.Scores["amount"], 0.5)
+
+	anomalous, err := engine.AnalyzeTransaction(ctx, &types.Payment{ID: uuid.New().String(), UserID: userID, Amount: 400})
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, anomalous.Scores["amount"])
+}
+
+func TestRulesFraudDetection_AmountHistoryIsPerCurrency(t *testing.T) {
+	engine, paymentRepo, _ := newTestFraudEngine()
+	ctx := context.Background()
+	userID := uuid.New().String()
+
+	for _, amount := range []float64{18, 20, 22, 19, 21, 20} {
+		paymentRepo.CreatePayment(ctx, &types.Payment{UserID: userID, Amount: amount, Currency: "USD", Status: types.PaymentStatusCompleted})
+	}
+	for _, amount := range []float64{600, 650, 700, 620, 680} {
+		paymentRepo.CreatePayment(ctx, &types.Payment{UserID: userID, Amount: amount, Currency: "TRY", Status: types.PaymentStatusCompleted})
+	}
+
+	// An ordinary lira fare is not an anomaly against the dollar history
+	usual, err := engine.AnalyzeTransaction(ctx, &types.Payment{ID: uuid.New().String(), UserID: userID, Amount: 640, Currency: "TRY"})
+	require.NoError(t, err)
+	assert.Less(t, usual.Scores["amount"], 0.5)
+
+	anomalous, err := engine.AnalyzeTransaction(ctx, &types.Payment{ID: uuid.New().String(), UserID: userID, Amount: 6000, Currency: "TRY"})
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, anomalous.Scores["amount"])
+}
+
+func TestRulesFraudDetection_AmountTiersAreInTheBaseCurrency(t *testing.T) {
+	engine, _, _ := newTestFraudEngine()
+	ctx := context.Background()
+
+	score := func(amount float64, code string) float64 {
+		result, err := engine.AnalyzeTransaction(ctx, &types.Payment{ID: uuid.New().String(), UserID: uuid.New().String(), Amount: amount, Currency: code})
+		require.NoError(t, err)
+		return result.Scores["amount"]
+	}
+
+	assert.Equal(t, 0.9, score(1500, "USD"))
+	assert.Equal(t, 0.1, score(2000, "TRY"), "without exchange rates other currencies are not held to dollar tiers")
+
+	rates, err := currency.NewStaticRates("USD", map[string]float64{"TRY": 32.5})
+	require.NoError(t, err)
+	engine.SetExchangeRates(rates)
+
+	assert.Equal(t, 0.1, score(2000, "TRY"), "about 62 dollars")
+	assert.Equal(t, 0.4, score(6500, "TRY"), "200 dollars")
+	assert.Equal(t, 0.9, score(40000, "TRY"), "about 1230 dollars")
+	assert.Equal(t, 0.1, score(150000, "JPY"), "no rate is known for yen")
+}
+
+func TestRulesFraudDetection_GeoMismatch(t *testing.T) {
+	engine, _, _ := newTestFraudEngine()
+	ctx := context.Background()
+
+	payment := &types.Payment{
+		ID:     uuid.New().String(),
+		UserID: uuid.New().String(),
+		Amount: 30,
+		Metadata: map[string]interface{}{
+			"latitude":         51.5074,
+			"longitude":        -0.1278,
+			"pickup_latitude":  40.7128,
+			"pickup_longitude": -74.0060,
+		},
+	}
+
+	result, err := engine.AnalyzeTransaction(ctx, payment)
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, result.Scores["geo"])
+}
+
+func TestRulesFraudDetection_BlockRule(t *testing.T) {
+	engine, _, reviewRepo := newTestFraudEngine()
+	ctx := context.Background()
+
+	err := engine.SaveRule(ctx, &types.FraudRule{
+		Name:       "wallet_large_amount",
+		Expression: `payment_method == "digital_wallet" && amount >= 300`,
+		Score:      0.2,
+		Action:     types.FraudRuleActionBlock,
+		Enabled:    true,
+	})
+	assert.NoError(t, err)
+
+	result, err := engine.AnalyzeTransaction(ctx, &types.Payment{
+		ID:            uuid.New().String(),
+		UserID:        uuid.New().String(),
+		Amount:        300,
+		PaymentMethod: types.PaymentMethodDigitalWallet,
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, types.FraudRiskHigh, result.RiskLevel)
+	assert.Contains(t, result.MatchedRules, "wallet_large_amount")
+
+	pending, err := reviewRepo.ListReviews(ctx, types.FraudReviewPending, 10, 0)
+	assert.NoError(t, err)
+	if !assert.Len(t, pending, 1) {
+		return
+	}
+
+	resolved, err := engine.ResolveReview(ctx, pending[0].ID, true, &types.ResolveFraudReviewRequest{ReviewedBy: "analyst-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, types.FraudReviewApproved, resolved.Status)
+
+	_, err = engine.ResolveReview(ctx, pending[0].ID, false, &types.ResolveFraudReviewRequest{ReviewedBy: "analyst-2"})
+	assert.ErrorIs(t, err, types.ErrFraudReviewResolved)
+}
+
+func TestRulesFraudDetection_SaveRuleRejectsInvalidExpression(t *testing.T) {
+	engine, _, _ := newTestFraudEngine()
+
+	err := engine.SaveRule(context.Background(), &types.FraudRule{
+		Name:       "broken",
+		Expression: "amount >> 5",
+		Enabled:    true,
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,329 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+)
+
+// FraudFeatures holds the transaction features that rule expressions can reference.
+// Values are either float64 or string.
+type FraudFeatures map[string]interface{}
+
+// ruleExpr is a compiled rule expression
+type ruleExpr interface {
+	eval(features FraudFeatures) bool
+}
+
+type orExpr struct{ left, right ruleExpr }
+
+func (e *orExpr) eval(f FraudFeatures) bool { return e.left.eval(f) || e.right.eval(f) }
+
+type andExpr struct{ left, right ruleExpr }
+
+func (e *andExpr) eval(f FraudFeatures) bool { return e.left.eval(f) && e.right.eval(f) }
+
+type notExpr struct{ inner ruleExpr }
+
+func (e *notExpr) eval(f FraudFeatures) bool { return !e.inner.eval(f) }
+
+type comparisonExpr struct {
+	feature string
+	op      string
+	number  float64
+	text    string
+	isText  bool
+}
+
+func (e *comparisonExpr) eval(f FraudFeatures) bool {
+	value, exists := f[e.feature]
+	if !exists {
+		return false
+	}
+
+	if e.isText {
+		str, ok := value.(string)
+		if !ok {
+			return false
+		}
+		switch e.op {
+		case "==":
+			return str == e.text
+		case "!=":
+			return str != e.text
+		}
+		return false
+	}
+
+	num, ok := value.(float64)
+	if !ok {
+		return false
+	}
+	switch e.op {
+	case ">":
+		return num > e.number
+	case ">=":
+		return num >= e.number
+	case "<":
+		return num < e.number
+	case "<=":
+		return num <= e.number
+	case "==":
+		return num == e.number
+	case "!=":
+		return num != e.number
+	}
+	return false
+}
+
+// CompiledFraudRule is a fraud rule with its expression parsed and ready to evaluate
+type CompiledFraudRule struct {
+	Name   string
+	Score  float64
+	Action types.FraudRuleAction
+	expr   ruleExpr
+}
+
+// Matches reports whether the rule matches the given features
+func (r *CompiledFraudRule) Matches(features FraudFeatures) bool {
+	return r.expr.eval(features)
+}
+
+// CompileFraudRule validates and compiles a stored fraud rule
+func CompileFraudRule(rule *types.FraudRule) (*CompiledFraudRule, error) {
+	expr, err := compileRuleExpression(rule.Expression)
+	if err != nil {
+		return nil, fmt.Errorf("rule %q: %w", rule.Name, err)
+	}
+
+	return &CompiledFraudRule{
+		Name:   rule.Name,
+		Score:  rule.Score,
+		Action: rule.Action,
+		expr:   expr,
+	}, nil
+}
+
+// compileRuleExpression parses a rule DSL expression.
+//
+// Grammar:
+//
+//	expr       := andExpr ( "||" andExpr )*
+//	andExpr    := unary ( "&&" unary )*
+//	unary      := "!" unary | "(" expr ")" | comparison
+//	comparison := identifier op ( number | "string" )
+//	op         := ">" | ">=" | "<" | "<=" | "==" | "!="
+func compileRuleExpression(expression string) (ruleExpr, error) {
+	tokens, err := tokenizeRule(expression)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty rule expression")
+	}
+
+	p := &ruleParser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected token %q at position %d", p.tokens[p.pos].value, p.pos)
+	}
+
+	return expr, nil
+}
+
+type ruleTokenKind int
+
+const (
+	tokenIdent ruleTokenKind = iota
+	tokenNumber
+	tokenString
+	tokenOperator
+	tokenLogical
+	tokenNot
+	tokenLParen
+	tokenRParen
+)
+
+type ruleToken struct {
+	kind  ruleTokenKind
+	value string
+}
+
+func tokenizeRule(input string) ([]ruleToken, error) {
+	var tokens []ruleToken
+	runes := []rune(input)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, ruleToken{tokenLParen, "("})
+			i++
+		case r == ')':
+			tokens = append(tokens, ruleToken{tokenRParen, ")"})
+			i++
+		case r == '&' || r == '|':
+			if i+1 >= len(runes) || runes[i+1] != r {
+				return nil, fmt.Errorf("invalid logical operator at position %d", i)
+			}
+			tokens = append(tokens, ruleToken{tokenLogical, string([]rune{r, r})})
+			i += 2
+		case r == '>' || r == '<' || r == '=' || r == '!':
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				tokens = append(tokens, ruleToken{tokenOperator, string([]rune{r, '='})})
+				i += 2
+			} else if r == '!' {
+				tokens = append(tokens, ruleToken{tokenNot, "!"})
+				i++
+			} else if r == '=' {
+				return nil, fmt.Errorf("use == for equality at position %d", i)
+			} else {
+				tokens = append(tokens, ruleToken{tokenOperator, string(r)})
+				i++
+			}
+		case r == '"' || r == '\'':
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("unterminated string at position %d", i)
+			}
+			tokens = append(tokens, ruleToken{tokenString, string(runes[i+1 : end])})
+			i = end + 1
+		case unicode.IsDigit(r) || r == '-' || r == '.':
+			start := i
+			i++
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, ruleToken{tokenNumber, string(runes[start:i])})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, ruleToken{tokenIdent, string(runes[start:i])})
+		default:
+			return nil, fmt.Errorf("unexpected character %q at position %d", r, i)
+		}
+	}
+
+	return tokens, nil
+}
+
+type ruleParser struct {
+	tokens []ruleToken
+	pos    int
+}
+
+func (p *ruleParser) peek() *ruleToken {
+	if p.pos >= len(p.tokens) {
+		return nil
+	}
+	return &p.tokens[p.pos]
+}
+
+func (p *ruleParser) parseOr() (ruleExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for tok := p.peek(); tok != nil && tok.kind == tokenLogical && tok.value == "||"; tok = p.peek() {
+		p.pos++
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &orExpr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *ruleParser) parseAnd() (ruleExpr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for tok := p.peek(); tok != nil && tok.kind == tokenLogical && tok.value == "&&"; tok = p.peek() {
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &andExpr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *ruleParser) parseUnary() (ruleExpr, error) {
+	tok := p.peek()
+	if tok == nil {
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+
+	switch tok.kind {
+	case tokenNot:
+		p.pos++
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &notExpr{inner: inner}, nil
+	case tokenLParen:
+		p.pos++
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.peek(); closing == nil || closing.kind != tokenRParen {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return inner, nil
+	case tokenIdent:
+		return p.parseComparison()
+	}
+
+	return nil, fmt.Errorf("unexpected token %q", tok.value)
+}
+
+func (p *ruleParser) parseComparison() (ruleExpr, error) {
+	if p.pos+2 >= len(p.tokens) {
+		return nil, fmt.Errorf("incomplete comparison near %q", p.tokens[p.pos].value)
+	}
+
+	ident, op, operand := p.tokens[p.pos], p.tokens[p.pos+1], p.tokens[p.pos+2]
+	if op.kind != tokenOperator {
+		return nil, fmt.Errorf("expected comparison operator after %q", ident.value)
+	}
+
+	expr := &comparisonExpr{feature: strings.ToLower(ident.value), op: op.value}
+	switch operand.kind {
+	case tokenNumber:
+		number, err := strconv.ParseFloat(operand.value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q", operand.value)
+		}
+		expr.number = number
+	case tokenString:
+		if op.value != "==" && op.value != "!=" {
+			return nil, fmt.Errorf("operator %s is not supported for strings", op.value)
+		}
+		expr.text = operand.value
+		expr.isText = true
+	default:
+		return nil, fmt.Errorf("expected number or string after %s", op.value)
+	}
+
+	p.pos += 3
+	return expr, nil
+}
@@ -0,0 +1,420 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/rideshare-platform/services/payment-service/internal/repository"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/currency"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/pagination"
+)
+
+const (
+	// ledgerRetryBatchSize is how many queued journal entries one retry posts
+	ledgerRetryBatchSize = 100
+	// ledgerRetryBackoff is the wait before the first retry of a queued
+	// entry, doubling after each failed attempt up to ledgerRetryMaxBackoff
+	ledgerRetryBackoff    = time.Minute
+	ledgerRetryMaxBackoff = time.Hour
+)
+
+// walletLedgerAccounts maps the platform's side of wallet transactions to
+// general ledger accounts. Riders' wallet accounts become liabilities.
+var walletLedgerAccounts = map[string]string{
+	types.LedgerAccountCardFunding: types.GLProcessorClearing,
+	types.LedgerAccountPromotions:  types.GLPromotions,
+	types.LedgerAccountRefunds:     types.GLRefundCredits,
+	types.LedgerAccountGuarantees:  types.GLPickupGuarantees,
+	types.LedgerAccountFares:       types.GLWalletFares,
+}
+
+// LedgerService keeps the double-entry general ledger: every fare, refund,
+// commission, tip, incentive, payout and wallet movement is posted as a
+// balanced journal entry, keyed by the event it records so replaying an event
+// never posts it twice. Fares are split between the driver, who is owed the
+// fare less commission, and the platform's commission revenue; refunds and
+// lost chargebacks reverse that split.
+//
+// The services that move money post to the ledger after the movement
+// succeeds. A posting that fails does not fail the movement, which has
+// already happened: the entry is queued in the ledger outbox instead, and
+// Start posts it again, backing off between attempts, until the ledger
+// takes it.
+type LedgerService struct {
+	ledger         repository.LedgerRepository
+	outbox         repository.LedgerOutboxRepository
+	commissionRate float64
+	logger         logger.Logger
+}
+
+// NewLedgerService creates a new ledger service
+func NewLedgerService(ledger repository.LedgerRepository, outbox repository.LedgerOutboxRepository, logger logger.Logger) *LedgerService {
+	return &LedgerService{
+		ledger:         ledger,
+		outbox:         outbox,
+		commissionRate: DefaultCommissionRate,
+		logger:         logger,
+	}
+}
+
+// SetCommissionRate sets the share of fares posted to commission revenue. It
+// should match the rate driver statements use.
+func (s *LedgerService) SetCommissionRate(rate float64) error {
+	if rate < 0 || rate >= 1 {
+		return fmt.Errorf("commission rate must be at least 0 and below 1, got %v", rate)
+	}
+	s.commissionRate = rate
+	return nil
+}
+
+// RecordPayment posts a completed fare: the money collected, by whatever
+// method, is split between the driver's payable and commission. Wallet
+// top-ups are posted by RecordWalletTransaction instead.
+func (s *LedgerService) RecordPayment(ctx context.Context, payment *types.Payment) error {
+	if payment.Status != types.PaymentStatusCompleted || payment.TransactionType != types.TransactionTypePayment || isWalletTopUp(payment) {
+		return nil
+	}
+
+	amount := currency.ToMinor(payment.Amount, payment.Currency)
+	postings := []*types.Posting{{Account: fundingAccount(payment), Amount: amount, Currency: payment.Currency}}
+	postings = append(postings, s.fareSplit(payment, -amount)...)
+	return s.post(ctx, &types.JournalEntry{
+		Kind:           types.JournalFarePayment,
+		IdempotencyKey: "payment:" + payment.ID,
+		Reference:      payment.ID,
+		Description:    "Fare for trip " + payment.TripID,
+		Postings:       postings,
+	})
+}
+
+// RecordRefund posts a completed refund of a fare, taking it back from the
+// driver and commission in the same proportions the fare was split
+func (s *LedgerService) RecordRefund(ctx context.Context, payment *types.Payment, refund *types.RefundRequest) error {
+	if refund.Status != types.PaymentStatusCompleted || isWalletTopUp(payment) {
+		return nil
+	}
+	return s.reverse(ctx, types.JournalRefund, "refund:"+refund.ID, refund.ID, "Refund of payment "+payment.ID, payment, refund.Amount)
+}
+
+// RecordChargebackLoss posts a chargeback the platform lost or accepted as a
+// reversal of the disputed fare
+func (s *LedgerService) RecordChargebackLoss(ctx context.Context, payment *types.Payment, chargeback *types.Chargeback) error {
+	return s.reverse(ctx, types.JournalChargeback, "chargeback:"+chargeback.ID, chargeback.ID, "Chargeback of payment "+payment.ID, payment, chargeback.Amount)
+}
+
+// RecordChargebackReinstatement posts a lost chargeback the provider later
+// decided in the platform's favour, undoing RecordChargebackLoss
+func (s *LedgerService) RecordChargebackReinstatement(ctx context.Context, payment *types.Payment, chargeback *types.Chargeback) error {
+	return s.reverse(ctx, types.JournalChargeback, "chargeback:"+chargeback.ID+":reinstated", chargeback.ID, "Chargeback of payment "+payment.ID+" reversed", payment, -chargeback.Amount)
+}
+
+// reverse posts amount of a fare back out of the driver's payable and
+// commission. A negative amount posts it back in.
+func (s *LedgerService) reverse(ctx context.Context, kind types.JournalEntryKind, key, reference, description string, payment *types.Payment, amount float64) error {
+	minor := currency.ToMinor(amount, payment.Currency)
+	if minor == 0 {
+		return nil
+	}
+	postings := []*types.Posting{{Account: fundingAccount(payment), Amount: -minor, Currency: payment.Currency}}
+	postings = append(postings, s.fareSplit(payment, minor)...)
+	return s.post(ctx, &types.JournalEntry{
+		Kind:           kind,
+		IdempotencyKey: key,
+		Reference:      reference,
+		Description:    description,
+		Postings:       postings,
+	})
+}
+
+// fareSplit posts amount of a fare to the driver's payable and commission,
+// or to unattributed fares when the payment has no driver. Negative amounts
+// credit them, as when the fare is paid; positive ones debit them back.
+func (s *LedgerService) fareSplit(payment *types.Payment, amount int64) []*types.Posting {
+	if payment.DriverID == "" {
+		return []*types.Posting{{Account: types.GLUnattributedFares, Amount: amount, Currency: payment.Currency}}
+	}
+
+	commission := int64(math.Round(float64(amount) * s.commissionRate))
+	postings := []*types.Posting{{Account: types.GLDriverPayable(payment.DriverID), Amount: amount - commission, Currency: payment.Currency}}
+	if commission != 0 {
+		postings = append(postings, &types.Posting{Account: types.GLCommission, Amount: commission, Currency: payment.Currency})
+	}
+	return postings
+}
+
+// RecordEarning posts a tip, collected from the rider, or an incentive, paid
+// by the platform, to the driver's payable
+func (s *LedgerService) RecordEarning(ctx context.Context, earning *types.DriverEarning) error {
+	source, kind := types.GLProcessorClearing, types.JournalTip
+	if earning.Type == types.EarningTypeIncentive {
+		source, kind = types.GLIncentives, types.JournalIncentive
+	}
+
+	amount := currency.ToMinor(earning.Amount, earning.Currency)
+	return s.post(ctx, &types.JournalEntry{
+		Kind:           kind,
+		IdempotencyKey: "earning:" + earning.ID,
+		Reference:      earning.ID,
+		Description:    fmt.Sprintf("%s for driver %s", earning.Type, earning.DriverID),
+		Postings: []*types.Posting{
+			{Account: source, Amount: amount, Currency: earning.Currency},
+			{Account: types.GLDriverPayable(earning.DriverID), Amount: -amount, Currency: earning.Currency},
+		},
+	})
+}
+
+// RecordWalletTransaction mirrors a wallet transaction into the general
+// ledger. Wallet entries grow the wallet's balance when positive, which for a
+// liability is a credit, so their signs flip.
+func (s *LedgerService) RecordWalletTransaction(ctx context.Context, transaction *types.WalletTransaction) error {
+	entry := &types.JournalEntry{
+		Kind:           types.JournalWallet,
+		IdempotencyKey: "wallet:" + transaction.ID,
+		Reference:      transaction.ID,
+		Description:    string(transaction.Type),
+	}
+	for _, walletEntry := range transaction.Entries {
+		account, ok := walletLedgerAccounts[walletEntry.Account]
+		if !ok {
+			account = types.GLWalletAccount(walletEntry.Account)
+		}
+		entry.Postings = append(entry.Postings, &types.Posting{Account: account, Amount: -walletEntry.Amount, Currency: walletEntry.Currency})
+	}
+	return s.post(ctx, entry)
+}
+
+// RecordPayout posts money sent to a driver's bank account against what the
+// platform owes them. The driver's account is locked while its balance is
+// checked and the payout posted, so concurrent payouts cannot overdraw it.
+// Payouts are keyed by their reference, so recording the same transfer again
+// fails with types.ErrDuplicateJournalEntry.
+func (s *LedgerService) RecordPayout(ctx context.Context, req *types.RecordPayoutRequest) (*types.JournalEntry, error) {
+	if req.DriverID == "" || req.Reference == "" || req.Amount <= 0 {
+		return nil, fmt.Errorf("%w: driver, reference and a positive amount are required", types.ErrInvalidJournalEntry)
+	}
+	code, err := currency.Normalize(req.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", types.ErrInvalidJournalEntry, err)
+	}
+
+	account := types.GLDriverPayable(req.DriverID)
+	amount := currency.ToMinor(req.Amount, code)
+	entry := &types.JournalEntry{
+		Kind:           types.JournalPayout,
+		IdempotencyKey: "payout:" + req.Reference,
+		Reference:      req.Reference,
+		Description:    "Payout to driver " + req.DriverID,
+		Postings: []*types.Posting{
+			{Account: account, Amount: amount, Currency: code},
+			{Account: types.GLBank, Amount: -amount, Currency: code},
+		},
+	}
+	payable, err := s.ledger.PostPayout(ctx, entry, account, code, amount)
+	if errors.Is(err, types.ErrPayoutExceedsPayable) {
+		return nil, fmt.Errorf("%w: %s owed, %s requested", err,
+			currency.FormatAmount(currency.FromMinor(payable, code), code), currency.FormatAmount(req.Amount, code))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// post stores an entry. Events already in the ledger are not an error, and
+// neither are postings that failed but were queued in the outbox to retry.
+func (s *LedgerService) post(ctx context.Context, entry *types.JournalEntry) error {
+	err := s.ledger.PostEntry(ctx, entry)
+	if err == nil || errors.Is(err, types.ErrDuplicateJournalEntry) {
+		return nil
+	}
+
+	fields := logger.Fields{
+		"idempotency_key": entry.IdempotencyKey,
+		"kind":            string(entry.Kind),
+		"error":           err.Error(),
+	}
+	// Entries the ledger rejects would be rejected again
+	if errors.Is(err, types.ErrInvalidJournalEntry) || errors.Is(err, types.ErrUnbalancedJournalEntry) {
+		s.logger.WithFields(fields).Error("Failed to post journal entry")
+		return err
+	}
+
+	// The movement's request may be what was cancelled, so the entry is
+	// queued regardless
+	now := time.Now()
+	queueErr := s.outbox.Enqueue(context.WithoutCancel(ctx), &types.PendingJournalEntry{
+		Entry:         entry,
+		Attempts:      1,
+		LastError:     err.Error(),
+		CreatedAt:     now,
+		NextAttemptAt: now.Add(ledgerRetryDelay(1)),
+	})
+	if queueErr != nil {
+		fields["outbox_error"] = queueErr.Error()
+		s.logger.WithFields(fields).Error("Failed to post or queue journal entry")
+		return err
+	}
+	s.logger.WithFields(fields).Warn("Failed to post journal entry, queued to retry")
+	return nil
+}
+
+// RetryPending posts queued journal entries whose next attempt is due by now
+// and returns how many it posted. Entries that fail again are rescheduled.
+// Replicas retrying the same entry at once are harmless, as only one posting
+// of an idempotency key is ever stored.
+func (s *LedgerService) RetryPending(ctx context.Context, now time.Time) (int, error) {
+	due, err := s.outbox.GetDue(ctx, now, ledgerRetryBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get queued journal entries: %w", err)
+	}
+
+	posted := 0
+	for _, pending := range due {
+		entry := pending.Entry
+		// The entry is dated when it reaches the ledger, so the warehouse
+		// export, which follows posting times, still picks it up
+		entry.PostedAt = time.Time{}
+		postErr := s.ledger.PostEntry(ctx, entry)
+		if postErr != nil && !errors.Is(postErr, types.ErrDuplicateJournalEntry) {
+			if ctx.Err() != nil {
+				return posted, ctx.Err()
+			}
+			s.logger.WithFields(logger.Fields{
+				"idempotency_key": entry.IdempotencyKey,
+				"attempts":        pending.Attempts + 1,
+				"error":           postErr.Error(),
+			}).Warn("Queued journal entry failed to post again")
+			if err := s.outbox.Reschedule(ctx, entry.IdempotencyKey, postErr.Error(), now.Add(ledgerRetryDelay(pending.Attempts+1))); err != nil {
+				return posted, fmt.Errorf("failed to reschedule journal entry %s: %w", entry.IdempotencyKey, err)
+			}
+			continue
+		}
+
+		if err := s.outbox.Delete(ctx, entry.IdempotencyKey); err != nil {
+			return posted, fmt.Errorf("failed to remove posted journal entry %s: %w", entry.IdempotencyKey, err)
+		}
+		if postErr == nil {
+			posted++
+		}
+	}
+	return posted, nil
+}
+
+// Start retries queued journal entries every interval until ctx is cancelled
+func (s *LedgerService) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.RetryPending(ctx, time.Now()); err != nil && ctx.Err() == nil {
+				s.logger.WithFields(logger.Fields{"error": err.Error()}).Error("Journal entry retry failed")
+			}
+		}
+	}
+}
+
+// ledgerRetryDelay is how long to wait after a queued entry's attempts
+// before trying it again
+func ledgerRetryDelay(attempts int) time.Duration {
+	delay := ledgerRetryBackoff
+	for i := 1; i < attempts && delay < ledgerRetryMaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > ledgerRetryMaxBackoff {
+		delay = ledgerRetryMaxBackoff
+	}
+	return delay
+}
+
+// GetEntry returns a journal entry with its postings
+func (s *LedgerService) GetEntry(ctx context.Context, id string) (*types.JournalEntry, error) {
+	return s.ledger.GetEntry(ctx, id)
+}
+
+// AccountBalances returns an account's balance in each currency as of asOf
+func (s *LedgerService) AccountBalances(ctx context.Context, account string, asOf time.Time) ([]*types.AccountBalance, error) {
+	if _, err := types.LedgerAccountTypeOf(account); err != nil {
+		return nil, err
+	}
+	return s.ledger.GetAccountBalances(ctx, account, asOf)
+}
+
+// AccountPostings pages through an account's postings, newest first
+func (s *LedgerService) AccountPostings(ctx context.Context, account string, page pagination.Request) (*pagination.Page[*types.Posting], error) {
+	if _, err := types.LedgerAccountTypeOf(account); err != nil {
+		return nil, err
+	}
+	postings, err := s.ledger.GetAccountPostings(ctx, account, page)
+	if err != nil {
+		return nil, err
+	}
+	result := pagination.NewPage(postings, page, types.PostingSortKey, 0)
+	return &result, nil
+}
+
+// TrialBalance returns every account's balance as of asOf with the total
+// debits and credits per currency, which are equal while the ledger holds
+func (s *LedgerService) TrialBalance(ctx context.Context, asOf time.Time) (*types.TrialBalance, error) {
+	balances, err := s.ledger.GetAllBalances(ctx, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[string]*types.TrialBalanceTotal)
+	for _, balance := range balances {
+		total := totals[balance.Currency]
+		if total == nil {
+			total = &types.TrialBalanceTotal{Currency: balance.Currency}
+			totals[balance.Currency] = total
+		}
+		total.Debits += balance.Debits
+		total.Credits += balance.Credits
+	}
+
+	trial := &types.TrialBalance{
+		AsOf:     asOf,
+		Accounts: balances,
+		Totals:   make([]*types.TrialBalanceTotal, 0, len(totals)),
+	}
+	for _, total := range totals {
+		total.Balanced = total.Debits == total.Credits
+		if !total.Balanced {
+			s.logger.WithFields(logger.Fields{
+				"currency": total.Currency,
+				"debits":   total.Debits,
+				"credits":  total.Credits,
+			}).Error("General ledger does not balance")
+		}
+		trial.Totals = append(trial.Totals, total)
+	}
+	sort.Slice(trial.Totals, func(i, j int) bool {
+		return trial.Totals[i].Currency < trial.Totals[j].Currency
+	})
+	return trial, nil
+}
+
+// fundingAccount is where the money for a payment came from
+func fundingAccount(payment *types.Payment) string {
+	switch payment.PaymentMethod {
+	case types.PaymentMethodCash:
+		return types.GLCashWithDrivers
+	case types.PaymentMethodWalletBalance:
+		return types.GLWalletFares
+	}
+	return types.GLProcessorClearing
+}
+
+func isWalletTopUp(payment *types.Payment) bool {
+	topUp, _ := payment.Metadata["wallet_top_up"].(bool)
+	return topUp
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+
+	"github.com/rideshare-platform/services/payment-service/internal/repository"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/export"
+)
+
+// Names the general ledger is exported to the warehouse under
+const (
+	JournalEntryExportDataset = "journal_entries"
+	PostingExportDataset      = "journal_postings"
+)
+
+var journalEntryExportColumns = []string{
+	"id", "kind", "idempotency_key", "reference", "description", "posted_at",
+}
+
+var postingExportColumns = []string{
+	"id", "journal_entry_id", "account", "account_type", "debit_minor", "credit_minor", "currency", "posted_at",
+}
+
+// NewJournalEntryExport creates the warehouse dataset of journal entries.
+// Their postings are exported separately by NewPostingExport.
+func NewJournalEntryExport(repo repository.LedgerRepository) export.Dataset {
+	return export.NewDataset(JournalEntryExportDataset, journalEntryExportColumns, func(ctx context.Context, since export.Watermark, limit int) ([]export.Record, error) {
+		entries, err := repo.GetEntriesSince(ctx, since, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		records := make([]export.Record, 0, len(entries))
+		for _, entry := range entries {
+			records = append(records, export.Record{
+				ID:        entry.ID,
+				UpdatedAt: entry.PostedAt,
+				Values: []string{
+					entry.ID, string(entry.Kind), entry.IdempotencyKey, entry.Reference, entry.Description,
+					export.Time(entry.PostedAt),
+				},
+			})
+		}
+		return records, nil
+	})
+}
+
+// NewPostingExport creates the warehouse dataset of journal postings, with
+// each amount in its debit or credit column. Postings never change once
+// posted, so they are exported once.
+func NewPostingExport(repo repository.LedgerRepository) export.Dataset {
+	return export.NewDataset(PostingExportDataset, postingExportColumns, func(ctx context.Context, since export.Watermark, limit int) ([]export.Record, error) {
+		postings, err := repo.GetPostingsSince(ctx, since, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		records := make([]export.Record, 0, len(postings))
+		for _, posting := range postings {
+			var debit, credit int64
+			if posting.Amount > 0 {
+				debit = posting.Amount
+			} else {
+				credit = -posting.Amount
+			}
+			accountType, _ := types.LedgerAccountTypeOf(posting.Account)
+			records = append(records, export.Record{
+				ID:        posting.ID,
+				UpdatedAt: posting.PostedAt,
+				Values: []string{
+					posting.ID, posting.JournalEntryID, posting.Account, string(accountType),
+					export.Int(debit), export.Int(credit), posting.Currency, export.Time(posting.PostedAt),
+				},
+			})
+		}
+		return records, nil
+	})
+}
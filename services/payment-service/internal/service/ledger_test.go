@@ -0,0 +1,293 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rideshare-platform/services/payment-service/internal/repository"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/pagination"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLedgerTestService() *LedgerService {
+	return NewLedgerService(repository.NewMockLedgerRepository(), repository.NewMockLedgerOutboxRepository(), *logger.NewLogger("error", "test"))
+}
+
+// balanceOf returns an account's USD balance in minor units
+func balanceOf(t *testing.T, ledger *LedgerService, account string) int64 {
+	balances, err := ledger.AccountBalances(context.Background(), account, time.Now())
+	require.NoError(t, err)
+	for _, balance := range balances {
+		if balance.Currency == "USD" {
+			return balance.Balance
+		}
+	}
+	return 0
+}
+
+func assertLedgerBalances(t *testing.T, ledger *LedgerService) {
+	trial, err := ledger.TrialBalance(context.Background(), time.Now())
+	require.NoError(t, err)
+	require.NotEmpty(t, trial.Totals)
+	for _, total := range trial.Totals {
+		assert.True(t, total.Balanced, "%s debits %d, credits %d", total.Currency, total.Debits, total.Credits)
+	}
+}
+
+func TestLedgerService_PostsWalletAndCardFares(t *testing.T) {
+	ctx := context.Background()
+	wallets, _, _ := newWalletTestService(t)
+	ledger := newLedgerTestService()
+	wallets.paymentService.SetLedger(ledger)
+
+	topUp, err := wallets.TopUp(ctx, &types.TopUpWalletRequest{UserID: "rider-1", Amount: 10, PaymentMethodID: "card-1"})
+	require.NoError(t, err)
+	require.True(t, topUp.Success, topUp.Errors)
+	credit, err := wallets.GrantCredit(ctx, &types.GrantCreditRequest{UserID: "rider-1", Amount: 5, Type: types.WalletTransactionPromotionCredit, Reference: "WELCOME5"})
+	require.NoError(t, err)
+	require.True(t, credit.Success, credit.Errors)
+
+	paid, err := wallets.PayFare(ctx, &types.WalletPaymentRequest{TripID: "trip-1", UserID: "rider-1", DriverID: "driver-1", Amount: 8.5})
+	require.NoError(t, err)
+	require.True(t, paid.Success, paid.Errors)
+	paid, err = wallets.PayFare(ctx, &types.WalletPaymentRequest{TripID: "trip-2", UserID: "rider-1", DriverID: "driver-1", Amount: 20, PaymentMethodID: "card-1"})
+	require.NoError(t, err)
+	require.True(t, paid.Success, paid.Errors)
+
+	// 28.50 of fares, less 20% commission
+	assert.Equal(t, int64(2280), balanceOf(t, ledger, types.GLDriverPayable("driver-1")))
+	assert.Equal(t, int64(570), balanceOf(t, ledger, types.GLCommission))
+	// The top-up and the card part of the second fare were charged to the card
+	assert.Equal(t, int64(2350), balanceOf(t, ledger, types.GLProcessorClearing))
+	assert.Equal(t, int64(500), balanceOf(t, ledger, types.GLPromotions))
+	// Wallet fares clear against the wallet debits, which emptied the wallet
+	assert.Zero(t, balanceOf(t, ledger, types.GLWalletFares))
+	assert.Zero(t, balanceOf(t, ledger, types.GLWalletAccount(types.WalletCashAccount("rider-1"))))
+	assert.Zero(t, balanceOf(t, ledger, types.GLWalletAccount(types.WalletCreditAccount("rider-1"))))
+
+	// A partial refund of the card payment comes back out of the split
+	refund, err := wallets.paymentService.ProcessRefund(ctx, &types.RefundPaymentRequest{
+		PaymentID: paid.CardPayment.ID, Amount: 5, Reason: "Route was longer than needed", RequestedBy: "support",
+	})
+	require.NoError(t, err)
+	require.True(t, refund.Success, refund.Message)
+	assert.Equal(t, int64(1880), balanceOf(t, ledger, types.GLDriverPayable("driver-1")))
+	assert.Equal(t, int64(470), balanceOf(t, ledger, types.GLCommission))
+	assert.Equal(t, int64(1850), balanceOf(t, ledger, types.GLProcessorClearing))
+
+	assertLedgerBalances(t, ledger)
+}
+
+func TestLedgerService_PayoutsCannotExceedPayable(t *testing.T) {
+	ctx := context.Background()
+	ledger := newLedgerTestService()
+
+	payment := &types.Payment{
+		ID: "payment-1", TripID: "trip-1", DriverID: "driver-1", Amount: 25, Currency: "USD",
+		PaymentMethod: types.PaymentMethodCash, Status: types.PaymentStatusCompleted, TransactionType: types.TransactionTypePayment,
+	}
+	require.NoError(t, ledger.RecordPayment(ctx, payment))
+	// Replaying the payment does not post it twice
+	require.NoError(t, ledger.RecordPayment(ctx, payment))
+	require.NoError(t, ledger.RecordEarning(ctx, &types.DriverEarning{
+		ID: "earning-1", DriverID: "driver-1", Type: types.EarningTypeTip, Amount: 3, Currency: "USD",
+	}))
+	require.NoError(t, ledger.RecordEarning(ctx, &types.DriverEarning{
+		ID: "earning-2", DriverID: "driver-1", Type: types.EarningTypeIncentive, Amount: 2, Currency: "USD",
+	}))
+	assert.Equal(t, int64(2500), balanceOf(t, ledger, types.GLDriverPayable("driver-1")))
+	assert.Equal(t, int64(2500), balanceOf(t, ledger, types.GLCashWithDrivers))
+
+	_, err := ledger.RecordPayout(ctx, &types.RecordPayoutRequest{DriverID: "driver-1", Amount: 25.01, Currency: "USD", Reference: "tr_1"})
+	assert.ErrorIs(t, err, types.ErrPayoutExceedsPayable)
+	_, err = ledger.RecordPayout(ctx, &types.RecordPayoutRequest{DriverID: "driver-1", Amount: 10, Currency: "EUR", Reference: "tr_1"})
+	assert.ErrorIs(t, err, types.ErrPayoutExceedsPayable)
+	_, err = ledger.RecordPayout(ctx, &types.RecordPayoutRequest{DriverID: "driver-1", Amount: -1, Currency: "USD", Reference: "tr_1"})
+	assert.ErrorIs(t, err, types.ErrInvalidJournalEntry)
+
+	entry, err := ledger.RecordPayout(ctx, &types.RecordPayoutRequest{DriverID: "driver-1", Amount: 20, Currency: "usd", Reference: "tr_1"})
+	require.NoError(t, err)
+	assert.Equal(t, types.JournalPayout, entry.Kind)
+	_, err = ledger.RecordPayout(ctx, &types.RecordPayoutRequest{DriverID: "driver-1", Amount: 1, Currency: "USD", Reference: "tr_1"})
+	assert.ErrorIs(t, err, types.ErrDuplicateJournalEntry)
+
+	assert.Equal(t, int64(500), balanceOf(t, ledger, types.GLDriverPayable("driver-1")))
+	assert.Equal(t, int64(-2000), balanceOf(t, ledger, types.GLBank))
+
+	stored, err := ledger.GetEntry(ctx, entry.ID)
+	require.NoError(t, err)
+	assert.Len(t, stored.Postings, 2)
+	page, err := pagination.NewRequest(3, "", "", types.PostingListOptions)
+	require.NoError(t, err)
+	postings, err := ledger.AccountPostings(ctx, types.GLDriverPayable("driver-1"), page)
+	require.NoError(t, err)
+	assert.Len(t, postings.Items, 3)
+	require.NotEmpty(t, postings.NextCursor)
+	page, err = pagination.NewRequest(3, postings.NextCursor, "", types.PostingListOptions)
+	require.NoError(t, err)
+	postings, err = ledger.AccountPostings(ctx, types.GLDriverPayable("driver-1"), page)
+	require.NoError(t, err)
+	assert.Len(t, postings.Items, 1)
+	assert.Empty(t, postings.NextCursor)
+	assertLedgerBalances(t, ledger)
+}
+
+func TestLedgerService_LostChargebackReversesFare(t *testing.T) {
+	ctx := context.Background()
+	chargebacks, _, payment := newChargebackTestService(t)
+	ledger := newLedgerTestService()
+	chargebacks.SetLedger(ledger)
+	require.NoError(t, ledger.RecordPayment(ctx, payment))
+	assert.Equal(t, int64(1600), balanceOf(t, ledger, types.GLDriverPayable("driver-1")))
+
+	_, err := chargebacks.HandleProviderEvent(ctx, disputeEvent(types.ProviderDisputeClosed, payment.ID, types.ChargebackStatusLost))
+	require.NoError(t, err)
+	assert.Zero(t, balanceOf(t, ledger, types.GLDriverPayable("driver-1")))
+	assert.Zero(t, balanceOf(t, ledger, types.GLCommission))
+	assert.Zero(t, balanceOf(t, ledger, types.GLProcessorClearing))
+
+	// The provider reversing its decision puts the fare back
+	won := disputeEvent(types.ProviderDisputeClosed, payment.ID, types.ChargebackStatusWon)
+	won.ID = "evt-reversed"
+	_, err = chargebacks.HandleProviderEvent(ctx, won)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1600), balanceOf(t, ledger, types.GLDriverPayable("driver-1")))
+	assertLedgerBalances(t, ledger)
+}
+
+func TestMockLedgerRepository_RejectsUnbalancedEntries(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMockLedgerRepository()
+
+	err := repo.PostEntry(ctx, &types.JournalEntry{
+		Kind: types.JournalPayout, IdempotencyKey: "payout:tr_1",
+		Postings: []*types.Posting{
+			{Account: types.GLDriverPayable("driver-1"), Amount: 1000, Currency: "USD"},
+			{Account: types.GLBank, Amount: -999, Currency: "USD"},
+		},
+	})
+	assert.ErrorIs(t, err, types.ErrUnbalancedJournalEntry)
+
+	err = repo.PostEntry(ctx, &types.JournalEntry{
+		Kind: types.JournalPayout, IdempotencyKey: "payout:tr_1",
+		Postings: []*types.Posting{
+			{Account: "driver:1", Amount: 1000, Currency: "USD"},
+			{Account: types.GLBank, Amount: -1000, Currency: "USD"},
+		},
+	})
+	assert.ErrorIs(t, err, types.ErrInvalidJournalEntry)
+
+	balances, err := repo.GetAllBalances(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, balances)
+}
+
+// failingLedger fails every posting while down, as when its database is
+// unreachable
+type failingLedger struct {
+	*repository.MockLedgerRepository
+	down bool
+}
+
+func (f *failingLedger) PostEntry(ctx context.Context, entry *types.JournalEntry) error {
+	if f.down {
+		return errors.New("connection refused")
+	}
+	return f.MockLedgerRepository.PostEntry(ctx, entry)
+}
+
+func TestLedgerService_RetriesQueuedEntries(t *testing.T) {
+	ctx := context.Background()
+	repo := &failingLedger{MockLedgerRepository: repository.NewMockLedgerRepository(), down: true}
+	outbox := repository.NewMockLedgerOutboxRepository()
+	ledger := NewLedgerService(repo, outbox, *logger.NewLogger("error", "test"))
+
+	payment := &types.Payment{
+		ID: "payment-1", TripID: "trip-1", DriverID: "driver-1", Amount: 10, Currency: "USD",
+		PaymentMethod: types.PaymentMethodCash, Status: types.PaymentStatusCompleted, TransactionType: types.TransactionTypePayment,
+	}
+	// A failed posting is queued rather than failing the payment
+	require.NoError(t, ledger.RecordPayment(ctx, payment))
+	require.NoError(t, ledger.RecordPayment(ctx, payment))
+	now := time.Now()
+	due, err := outbox.GetDue(ctx, now.Add(ledgerRetryBackoff), 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1, "the replayed event is queued once")
+	assert.Equal(t, "payment:payment-1", due[0].Entry.IdempotencyKey)
+	assert.Equal(t, "connection refused", due[0].LastError)
+
+	// Nothing is due before the backoff, and a failed retry backs off further
+	posted, err := ledger.RetryPending(ctx, now)
+	require.NoError(t, err)
+	assert.Zero(t, posted)
+	posted, err = ledger.RetryPending(ctx, now.Add(ledgerRetryBackoff))
+	require.NoError(t, err)
+	assert.Zero(t, posted)
+	due, err = outbox.GetDue(ctx, now.Add(2*ledgerRetryBackoff), 10)
+	require.NoError(t, err)
+	assert.Empty(t, due)
+	due, err = outbox.GetDue(ctx, now.Add(3*ledgerRetryBackoff), 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, 2, due[0].Attempts)
+
+	repo.down = false
+	posted, err = ledger.RetryPending(ctx, now.Add(3*ledgerRetryBackoff))
+	require.NoError(t, err)
+	assert.Equal(t, 1, posted)
+	assert.Equal(t, int64(800), balanceOf(t, ledger, types.GLDriverPayable("driver-1")))
+	due, err = outbox.GetDue(ctx, now.Add(ledgerRetryMaxBackoff), 10)
+	require.NoError(t, err)
+	assert.Empty(t, due)
+	assertLedgerBalances(t, ledger)
+}
+
+func TestLedgerService_RetryDelayBacksOff(t *testing.T) {
+	assert.Equal(t, time.Minute, ledgerRetryDelay(1))
+	assert.Equal(t, 2*time.Minute, ledgerRetryDelay(2))
+	assert.Equal(t, 32*time.Minute, ledgerRetryDelay(6))
+	assert.Equal(t, time.Hour, ledgerRetryDelay(7))
+	assert.Equal(t, time.Hour, ledgerRetryDelay(50))
+}
+
+func TestLedgerService_ConcurrentPayoutsCannotOverdraw(t *testing.T) {
+	ctx := context.Background()
+	ledger := newLedgerTestService()
+	require.NoError(t, ledger.RecordEarning(ctx, &types.DriverEarning{
+		ID: "earning-1", DriverID: "driver-1", Type: types.EarningTypeIncentive, Amount: 30, Currency: "USD",
+	}))
+
+	var (
+		wg      sync.WaitGroup
+		mutex   sync.Mutex
+		paidOut int
+	)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := ledger.RecordPayout(ctx, &types.RecordPayoutRequest{
+				DriverID: "driver-1", Amount: 10, Currency: "USD", Reference: fmt.Sprintf("tr_%d", i),
+			})
+			if err == nil {
+				mutex.Lock()
+				paidOut++
+				mutex.Unlock()
+				return
+			}
+			assert.ErrorIs(t, err, types.ErrPayoutExceedsPayable)
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 3, paidOut)
+	assert.Zero(t, balanceOf(t, ledger, types.GLDriverPayable("driver-1")))
+	assertLedgerBalances(t, ledger)
+}
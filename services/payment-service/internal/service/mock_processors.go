@@ -79,21 +79,12 @@ func (p *MockCardProcessor) VerifyPaymentMethod(ctx context.Context, method *typ
 	// Simulate card verification
 	time.Sleep(time.Millisecond * 100)
 
-	// Basic validation
-	if cardNumber, ok := method.Details["card_number"].(string); ok {
-		if len(cardNumber) < 13 || len(cardNumber) > 19 {
-			return fmt.Errorf("invalid card number length")
-		}
-	} else {
-		return fmt.Errorf("card number is required")
+	// Cards are verified by their vault token, the card data never gets here
+	if method.CardToken == "" {
+		return fmt.Errorf("card token is required")
 	}
-
-	if cvv, ok := method.Details["cvv"].(string); ok {
-		if len(cvv) < 3 || len(cvv) > 4 {
-			return fmt.Errorf("invalid CVV")
-		}
-	} else {
-		return fmt.Errorf("CVV is required")
+	if method.ExpiryDate != nil && time.Now().After(*method.ExpiryDate) {
+		return fmt.Errorf("card has expired")
 	}
 
 	// Simulate random verification failures (2% failure rate)
@@ -105,6 +96,72 @@ func (p *MockCardProcessor) VerifyPaymentMethod(ctx context.Context, method *typ
 	return nil
 }
 
+// Card authorizations are held by the issuer for seven days
+const cardAuthorizationValidity = 7 * 24 * time.Hour
+
+func (p *MockCardProcessor) Authorize(ctx context.Context, payment *types.Payment) (*ProcessorResponse, error) {
+	time.Sleep(time.Millisecond * 200)
+
+	// Authorizations are declined as often as charges (10%)
+	rand.Seed(time.Now().UnixNano())
+	if rand.Float64() < 0.1 {
+		return &ProcessorResponse{
+			Success:         false,
+			TransactionID:   uuid.New().String(),
+			ProcessorID:     "card_processor_v1",
+			ResponseCode:    "DECLINED",
+			ResponseMessage: "Authorization declined by issuer",
+		}, nil
+	}
+
+	return &ProcessorResponse{
+		Success:           true,
+		TransactionID:     uuid.New().String(),
+		ProcessorID:       "card_processor_v1",
+		ResponseCode:      "AUTHORIZED",
+		ResponseMessage:   "Authorization approved",
+		AuthorizationCode: fmt.Sprintf("AUTH_%d", rand.Int31()),
+		ExpiresAt:         time.Now().Add(cardAuthorizationValidity),
+	}, nil
+}
+
+func (p *MockCardProcessor) Capture(ctx context.Context, authorizationID string, amount float64) (*ProcessorResponse, error) {
+	time.Sleep(time.Millisecond * 150)
+
+	// Captures of a live authorization rarely fail (2%)
+	rand.Seed(time.Now().UnixNano())
+	if rand.Float64() < 0.02 {
+		return &ProcessorResponse{
+			Success:         false,
+			TransactionID:   authorizationID,
+			ProcessorID:     "card_processor_v1",
+			ResponseCode:    "CAPTURE_FAILED",
+			ResponseMessage: "Authorization could not be captured",
+		}, nil
+	}
+
+	return &ProcessorResponse{
+		Success:         true,
+		TransactionID:   authorizationID,
+		ProcessorID:     "card_processor_v1",
+		ResponseCode:    "CAPTURED",
+		ResponseMessage: "Authorization captured",
+		ProcessingFee:   amount * 0.029, // 2.9% processing fee
+	}, nil
+}
+
+func (p *MockCardProcessor) Void(ctx context.Context, authorizationID string) (*ProcessorResponse, error) {
+	time.Sleep(time.Millisecond * 100)
+
+	return &ProcessorResponse{
+		Success:         true,
+		TransactionID:   authorizationID,
+		ProcessorID:     "card_processor_v1",
+		ResponseCode:    "VOIDED",
+		ResponseMessage: "Authorization released",
+	}, nil
+}
+
 // MockWalletProcessor simulates digital wallet processing (PayPal, Apple Pay, etc.)
 type MockWalletProcessor struct{}
 
@@ -180,6 +237,58 @@ func (p *MockWalletProcessor) VerifyPaymentMethod(ctx context.Context, method *t
 	return nil
 }
 
+// Digital wallets hold authorized funds for three days
+const walletAuthorizationValidity = 3 * 24 * time.Hour
+
+func (p *MockWalletProcessor) Authorize(ctx context.Context, payment *types.Payment) (*ProcessorResponse, error) {
+	time.Sleep(time.Millisecond * 150)
+
+	rand.Seed(time.Now().UnixNano())
+	if rand.Float64() < 0.05 {
+		return &ProcessorResponse{
+			Success:         false,
+			TransactionID:   uuid.New().String(),
+			ProcessorID:     "wallet_processor_v2",
+			ResponseCode:    "INSUFFICIENT_FUNDS",
+			ResponseMessage: "Insufficient balance in wallet",
+		}, nil
+	}
+
+	return &ProcessorResponse{
+		Success:         true,
+		TransactionID:   uuid.New().String(),
+		ProcessorID:     "wallet_processor_v2",
+		ResponseCode:    "AUTHORIZED",
+		ResponseMessage: "Wallet funds held",
+		ExpiresAt:       time.Now().Add(walletAuthorizationValidity),
+	}, nil
+}
+
+func (p *MockWalletProcessor) Capture(ctx context.Context, authorizationID string, amount float64) (*ProcessorResponse, error) {
+	time.Sleep(time.Millisecond * 100)
+
+	return &ProcessorResponse{
+		Success:         true,
+		TransactionID:   authorizationID,
+		ProcessorID:     "wallet_processor_v2",
+		ResponseCode:    "CAPTURED",
+		ResponseMessage: "Wallet funds captured",
+		ProcessingFee:   amount * 0.025, // 2.5% processing fee
+	}, nil
+}
+
+func (p *MockWalletProcessor) Void(ctx context.Context, authorizationID string) (*ProcessorResponse, error) {
+	time.Sleep(time.Millisecond * 50)
+
+	return &ProcessorResponse{
+		Success:         true,
+		TransactionID:   authorizationID,
+		ProcessorID:     "wallet_processor_v2",
+		ResponseCode:    "VOIDED",
+		ResponseMessage: "Wallet funds released",
+	}, nil
+}
+
 // MockBankProcessor simulates bank transfer processing
 type MockBankProcessor struct{}
 
@@ -264,6 +373,19 @@ func (p *MockBankProcessor) VerifyPaymentMethod(ctx context.Context, method *typ
 	return nil
 }
 
+// Bank transfers cannot be authorized ahead of the transfer
+func (p *MockBankProcessor) Authorize(ctx context.Context, payment *types.Payment) (*ProcessorResponse, error) {
+	return nil, types.ErrHoldsUnsupported
+}
+
+func (p *MockBankProcessor) Capture(ctx context.Context, authorizationID string, amount float64) (*ProcessorResponse, error) {
+	return nil, types.ErrHoldsUnsupported
+}
+
+func (p *MockBankProcessor) Void(ctx context.Context, authorizationID string) (*ProcessorResponse, error) {
+	return nil, types.ErrHoldsUnsupported
+}
+
 // MockCashProcessor simulates cash payment handling
 type MockCashProcessor struct{}
 
@@ -317,6 +439,19 @@ func (p *MockCashProcessor) VerifyPaymentMethod(ctx context.Context, method *typ
 	return nil
 }
 
+// Cash is collected by the driver, there is nothing to hold
+func (p *MockCashProcessor) Authorize(ctx context.Context, payment *types.Payment) (*ProcessorResponse, error) {
+	return nil, types.ErrHoldsUnsupported
+}
+
+func (p *MockCashProcessor) Capture(ctx context.Context, authorizationID string, amount float64) (*ProcessorResponse, error) {
+	return nil, types.ErrHoldsUnsupported
+}
+
+func (p *MockCashProcessor) Void(ctx context.Context, authorizationID string) (*ProcessorResponse, error) {
+	return nil, types.ErrHoldsUnsupported
+}
+
 // Helper function to check if string contains substring
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || contains(s[1:], substr) || (len(s) > 0 && s[0:len(substr)] == substr))
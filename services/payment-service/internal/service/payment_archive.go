@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/rideshare-platform/services/payment-service/internal/repository"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/archive"
+)
+
+// PaymentArchiveDataset is the name payments are archived under
+const PaymentArchiveDataset = "payments"
+
+// NewPaymentArchive creates the archive dataset of payments. Completed,
+// failed, refunded and cancelled payments are archived once their last
+// update is older than the retention period, keeping a summary of the
+// amounts and outcome for analytics.
+func NewPaymentArchive(repo repository.PaymentRepository) archive.Dataset {
+	expired := func(ctx context.Context, cutoff time.Time, afterID string, limit int) ([]archive.Record, error) {
+		payments, err := repo.GetPaymentsEndedBefore(ctx, cutoff, afterID, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		records := make([]archive.Record, 0, len(payments))
+		for _, payment := range payments {
+			data, err := json.Marshal(payment)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode payment %s: %w", payment.ID, err)
+			}
+			summary := map[string]interface{}{
+				"trip_id":          payment.TripID,
+				"user_id":          payment.UserID,
+				"driver_id":        payment.DriverID,
+				"amount":           payment.Amount,
+				"currency":         payment.Currency,
+				"payment_method":   string(payment.PaymentMethod),
+				"status":           string(payment.Status),
+				"transaction_type": string(payment.TransactionType),
+				"fraud_risk":       string(payment.FraudRisk),
+				"created_at":       payment.CreatedAt,
+			}
+			if payment.ProcessedAt != nil {
+				summary["processed_at"] = *payment.ProcessedAt
+			}
+			records = append(records, archive.Record{
+				ID:      payment.ID,
+				EndedAt: payment.UpdatedAt,
+				Data:    data,
+				Summary: summary,
+			})
+		}
+		return records, nil
+	}
+
+	restore := func(ctx context.Context, data json.RawMessage) error {
+		var payment types.Payment
+		if err := json.Unmarshal(data, &payment); err != nil {
+			return fmt.Errorf("failed to decode archived payment: %w", err)
+		}
+		return repo.RestorePayment(ctx, &payment)
+	}
+
+	return archive.NewDataset(PaymentArchiveDataset, expired, repo.DeletePayments, restore)
+}
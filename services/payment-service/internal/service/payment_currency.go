@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/currency"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// SetDefaultCurrency sets the currency charged when a payment request does not
+// name one
+func (s *PaymentService) SetDefaultCurrency(code string) error {
+	normalized, err := currency.Normalize(code)
+	if err != nil {
+		return err
+	}
+	s.defaultCurrency = normalized
+	return nil
+}
+
+// SetExchangeRates enables a reporting total in the stats, with everything
+// collected converted to reportingCurrency
+func (s *PaymentService) SetExchangeRates(rates currency.RateProvider, reportingCurrency string) error {
+	normalized, err := currency.Normalize(reportingCurrency)
+	if err != nil {
+		return err
+	}
+	s.rates = rates
+	s.reportingCurrency = normalized
+	return nil
+}
+
+// paymentCurrency returns the currency to charge a payment in. Riders are
+// charged in the currency they were quoted in, so every payment on a trip has
+// to be in the same currency.
+func (s *PaymentService) paymentCurrency(ctx context.Context, req *types.ProcessPaymentRequest) (string, error) {
+	code := req.Currency
+	if code == "" {
+		code = s.defaultCurrency
+	}
+	normalized, err := currency.Normalize(code)
+	if err != nil {
+		return "", err
+	}
+	if req.TripID == "" {
+		return normalized, nil
+	}
+
+	existing, err := s.paymentRepo.GetPaymentsByTrip(ctx, req.TripID)
+	if err != nil {
+		s.logger.WithFields(logger.Fields{
+			"trip_id": req.TripID,
+			"error":   err.Error(),
+		}).Warn("Failed to check currency of earlier trip payments")
+		return normalized, nil
+	}
+	for _, payment := range existing {
+		if payment.Status == types.PaymentStatusFailed || payment.Status == types.PaymentStatusCancelled {
+			continue
+		}
+		if err := currency.Match(payment.Currency, normalized); err != nil {
+			return "", fmt.Errorf("trip %s was already charged in %s: %w", req.TripID, payment.Currency, err)
+		}
+	}
+	return normalized, nil
+}
+
+// GetPaymentStats summarises payments created in [from, to). Amounts
+// collected are reported per currency; they are only summed across currencies
+// in the reporting total, and only when exchange rates are configured.
+func (s *PaymentService) GetPaymentStats(ctx context.Context, from, to time.Time) (*types.PaymentStats, error) {
+	totals, err := s.paymentRepo.GetPaymentTotals(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &types.PaymentStats{Since: from, Until: to, Collected: []*types.CurrencyAmount{}}
+	collected := make(map[string]*types.CurrencyAmount)
+	for _, total := range totals {
+		stats.TotalPayments += total.Count
+		switch total.Status {
+		case types.PaymentStatusCompleted:
+			stats.SuccessfulPayments += total.Count
+		case types.PaymentStatusFailed:
+			stats.FailedPayments += total.Count
+		case types.PaymentStatusRefunded:
+			stats.RefundedPayments += total.Count
+		}
+		if total.Status != types.PaymentStatusCompleted {
+			continue
+		}
+
+		amount, exists := collected[total.Currency]
+		if !exists {
+			amount = &types.CurrencyAmount{Currency: total.Currency}
+			collected[total.Currency] = amount
+			stats.Collected = append(stats.Collected, amount)
+		}
+		amount.Count += total.Count
+		amount.Amount = currency.Round(amount.Amount+total.Amount, total.Currency)
+		amount.Formatted = currency.FormatAmount(amount.Amount, total.Currency)
+	}
+	sort.Slice(stats.Collected, func(i, j int) bool {
+		return stats.Collected[i].Currency < stats.Collected[j].Currency
+	})
+
+	if s.rates != nil {
+		stats.ReportingTotal = s.reportingTotal(ctx, stats.Collected)
+	}
+	return stats, nil
+}
+
+// reportingTotal converts amounts collected to the reporting currency.
+// Currencies without a known rate are left out and logged.
+func (s *PaymentService) reportingTotal(ctx context.Context, collected []*types.CurrencyAmount) *types.CurrencyAmount {
+	var minor int64
+	total := &types.CurrencyAmount{Currency: s.reportingCurrency}
+	for _, amount := range collected {
+		converted, err := currency.Convert(ctx, s.rates, currency.ToMinor(amount.Amount, amount.Currency), amount.Currency, s.reportingCurrency)
+		if err != nil {
+			s.logger.WithFields(logger.Fields{
+				"currency": amount.Currency,
+				"error":    err.Error(),
+			}).Warn("Leaving currency out of reporting total")
+			continue
+		}
+		minor += converted
+		total.Count += amount.Count
+	}
+	total.Amount = currency.FromMinor(minor, s.reportingCurrency)
+	total.Formatted = currency.FormatAmount(total.Amount, s.reportingCurrency)
+	return total
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rideshare-platform/services/payment-service/internal/repository"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/currency"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCurrencyTestService() (*PaymentService, *repository.MockPaymentRepository) {
+	paymentRepo := repository.NewMockPaymentRepository()
+	service := NewPaymentService(
+		paymentRepo,
+		repository.NewMockPaymentMethodRepository(),
+		repository.NewMockRefundRepository(),
+		nil,
+		*logger.NewLogger("error", "test"),
+	)
+	return service, paymentRepo
+}
+
+func TestPaymentService_RejectsMismatchedCurrency(t *testing.T) {
+	ctx := context.Background()
+	service, paymentRepo := newCurrencyTestService()
+	charged := &types.Payment{ID: "payment-1", TripID: "trip-1", Amount: 120, Currency: "TRY", Status: types.PaymentStatusCompleted}
+	assert.NoError(t, paymentRepo.CreatePayment(ctx, charged))
+
+	response, err := service.ProcessPayment(ctx, &types.ProcessPaymentRequest{TripID: "trip-1", Amount: 5, Currency: "USD"})
+	assert.NoError(t, err)
+	assert.False(t, response.Success)
+	assert.Equal(t, "Invalid payment currency", response.Message)
+
+	response, err = service.ProcessPayment(ctx, &types.ProcessPaymentRequest{TripID: "trip-2", Amount: 5, Currency: "XYZ"})
+	assert.NoError(t, err)
+	assert.False(t, response.Success)
+	assert.Equal(t, "Invalid payment currency", response.Message)
+
+	response, err = service.ProcessRefund(ctx, &types.RefundPaymentRequest{PaymentID: "payment-1", Amount: 20, Currency: "USD"})
+	assert.NoError(t, err)
+	assert.False(t, response.Success)
+	assert.Equal(t, "Refund currency does not match the payment", response.Message)
+
+	payment, err := service.paymentCurrency(ctx, &types.ProcessPaymentRequest{TripID: "trip-1", Currency: "try"})
+	assert.NoError(t, err)
+	assert.Equal(t, "TRY", payment)
+
+	assert.NoError(t, service.SetDefaultCurrency("eur"))
+	payment, err = service.paymentCurrency(ctx, &types.ProcessPaymentRequest{TripID: "trip-3"})
+	assert.NoError(t, err)
+	assert.Equal(t, "EUR", payment)
+}
+
+func TestPaymentService_GetPaymentStatsPerCurrency(t *testing.T) {
+	ctx := context.Background()
+	service, paymentRepo := newCurrencyTestService()
+	payments := []*types.Payment{
+		{TripID: "trip-1", Amount: 10.25, Currency: "USD", Status: types.PaymentStatusCompleted},
+		{TripID: "trip-2", Amount: 4.75, Currency: "USD", Status: types.PaymentStatusCompleted},
+		{TripID: "trip-3", Amount: 1200, Currency: "JPY", Status: types.PaymentStatusCompleted},
+		{TripID: "trip-4", Amount: 50, Currency: "USD", Status: types.PaymentStatusFailed},
+	}
+	for _, payment := range payments {
+		assert.NoError(t, paymentRepo.CreatePayment(ctx, payment))
+	}
+	from, to := time.Now().Add(-time.Hour), time.Now().Add(time.Minute)
+
+	stats, err := service.GetPaymentStats(ctx, from, to)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, stats.TotalPayments)
+	assert.Equal(t, 3, stats.SuccessfulPayments)
+	assert.Equal(t, 1, stats.FailedPayments)
+	assert.Len(t, stats.Collected, 2)
+	assert.Equal(t, "JPY", stats.Collected[0].Currency)
+	assert.Equal(t, "¥1,200", stats.Collected[0].Formatted)
+	assert.Equal(t, 15.0, stats.Collected[1].Amount)
+	assert.Equal(t, "$15.00", stats.Collected[1].Formatted)
+	assert.Nil(t, stats.ReportingTotal, "no exchange rates configured")
+
+	earlier, err := service.GetPaymentStats(ctx, from.Add(-24*time.Hour), from)
+	assert.NoError(t, err)
+	assert.Zero(t, earlier.TotalPayments)
+	assert.Empty(t, earlier.Collected)
+
+	rates, err := currency.NewStaticRates("USD", map[string]float64{"JPY": 150})
+	assert.NoError(t, err)
+	assert.NoError(t, service.SetExchangeRates(rates, "USD"))
+	stats, err = service.GetPaymentStats(ctx, from, to)
+	assert.NoError(t, err)
+	assert.Equal(t, "USD", stats.ReportingTotal.Currency)
+	assert.Equal(t, 23.0, stats.ReportingTotal.Amount)
+	assert.Equal(t, 3, stats.ReportingTotal.Count)
+}
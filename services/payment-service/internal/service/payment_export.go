@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+
+	"github.com/rideshare-platform/services/payment-service/internal/repository"
+	"github.com/rideshare-platform/shared/export"
+)
+
+// PaymentExportDataset is the name payments are exported to the warehouse under
+const PaymentExportDataset = "payments"
+
+var paymentExportColumns = []string{
+	"id", "trip_id", "user_id", "driver_id", "amount", "currency",
+	"payment_method", "status", "transaction_type", "fraud_risk", "failure_reason",
+	"processed_at", "created_at", "updated_at",
+}
+
+// NewPaymentExport creates the warehouse dataset of payments. Processor
+// responses and metadata are left out, as they may hold card details.
+func NewPaymentExport(repo repository.PaymentRepository) export.Dataset {
+	return export.NewDataset(PaymentExportDataset, paymentExportColumns, func(ctx context.Context, since export.Watermark, limit int) ([]export.Record, error) {
+		payments, err := repo.GetPaymentsUpdatedSince(ctx, since, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		records := make([]export.Record, 0, len(payments))
+		for _, payment := range payments {
+			records = append(records, export.Record{
+				ID:        payment.ID,
+				UpdatedAt: payment.UpdatedAt,
+				Values: []string{
+					payment.ID, payment.TripID, payment.UserID, payment.DriverID,
+					export.Float(payment.Amount), payment.Currency,
+					string(payment.PaymentMethod), string(payment.Status), string(payment.TransactionType),
+					string(payment.FraudRisk), payment.FailureReason,
+					export.TimePtr(payment.ProcessedAt), export.Time(payment.CreatedAt), export.Time(payment.UpdatedAt),
+				},
+			})
+		}
+		return records, nil
+	})
+}
@@ -0,0 +1,587 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rideshare-platform/services/payment-service/internal/repository"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/currency"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// holdLockStripes is how many locks hold operations are spread over
+const holdLockStripes = 64
+
+// HoldConfig controls how much is held on a rider's payment method and when
+// holds are renewed
+type HoldConfig struct {
+	// Buffer is held on top of the estimated fare as a fraction of it,
+	// covering detours, waiting and tolls
+	Buffer float64
+	// MinBuffer is the least held on top of the estimated fare
+	MinBuffer float64
+	// RenewBefore is how long before it lapses an active hold is authorized
+	// again, so holds outlive long trips
+	RenewBefore time.Duration
+	// DefaultValidity is how long an authorization is assumed to last when the
+	// processor does not say
+	DefaultValidity time.Duration
+}
+
+// DefaultHoldConfig returns the hold settings used unless configured otherwise
+func DefaultHoldConfig() HoldConfig {
+	return HoldConfig{
+		Buffer:          0.25,
+		MinBuffer:       2,
+		RenewBefore:     12 * time.Hour,
+		DefaultValidity: 24 * time.Hour,
+	}
+}
+
+// HoldService places holds on riders' payment methods when their trips start
+// and charges the actual fare against them when the trips complete.
+// Authorizations lapse after a few days, so holds close to lapsing are
+// authorized again; a hold that lapsed anyway is replaced by a direct charge.
+type HoldService struct {
+	holds          repository.PaymentHoldRepository
+	paymentService *PaymentService
+	config         HoldConfig
+	logger         logger.Logger
+
+	// Operations on the same trip run one at a time, so a fare is never
+	// captured twice
+	locks [holdLockStripes]sync.Mutex
+}
+
+// NewHoldService creates a new hold service. Holds are authorized through
+// paymentService's processors and captures are recorded as its payments.
+func NewHoldService(holds repository.PaymentHoldRepository, paymentService *PaymentService, config HoldConfig, logger logger.Logger) *HoldService {
+	return &HoldService{
+		holds:          holds,
+		paymentService: paymentService,
+		config:         config,
+		logger:         logger,
+	}
+}
+
+func (s *HoldService) lock(tripID string) func() {
+	hash := fnv.New32a()
+	hash.Write([]byte(tripID))
+	mutex := &s.locks[hash.Sum32()%holdLockStripes]
+	mutex.Lock()
+	return mutex.Unlock
+}
+
+// GetTripHolds returns the holds placed for a trip, oldest first
+func (s *HoldService) GetTripHolds(ctx context.Context, tripID string) ([]*types.PaymentHold, error) {
+	return s.holds.GetHoldsByTrip(ctx, tripID)
+}
+
+// PlaceHold authorizes the estimated fare plus the buffer on the rider's
+// payment method. A trip keeps its active hold if it already has one.
+// Payment methods that cannot be held, such as cash, are refused and the trip
+// is charged when it completes.
+func (s *HoldService) PlaceHold(ctx context.Context, req *types.PlaceHoldRequest) (*types.HoldResponse, error) {
+	if req.TripID == "" || req.UserID == "" || req.EstimatedFare <= 0 {
+		return holdFailure("Trip, user and a positive estimated fare are required", nil), nil
+	}
+
+	unlock := s.lock(req.TripID)
+	defer unlock()
+
+	holds, err := s.holds.GetHoldsByTrip(ctx, req.TripID)
+	if err != nil {
+		return nil, err
+	}
+	if active := latestHold(holds, types.HoldStatusActive); active != nil {
+		return &types.HoldResponse{Hold: active, Success: true, Message: "Trip already has an active hold"}, nil
+	}
+
+	code, err := s.paymentService.paymentCurrency(ctx, &types.ProcessPaymentRequest{TripID: req.TripID, Currency: req.Currency})
+	if err != nil {
+		return holdFailure("Invalid payment currency", err), nil
+	}
+	method, err := s.paymentMethod(ctx, req.UserID, req.PaymentMethodID)
+	if err != nil {
+		return holdFailure("Payment method not found", err), nil
+	}
+	processor, exists := s.paymentService.processors[method.Type]
+	if !exists {
+		return holdFailure("Unsupported payment method", nil), nil
+	}
+
+	buffer := math.Max(req.EstimatedFare*s.config.Buffer, s.config.MinBuffer)
+	hold := &types.PaymentHold{
+		ID:              uuid.New().String(),
+		TripID:          req.TripID,
+		UserID:          req.UserID,
+		DriverID:        req.DriverID,
+		PaymentMethodID: method.ID,
+		PaymentMethod:   method.Type,
+		EstimatedFare:   req.EstimatedFare,
+		Amount:          currency.Round(req.EstimatedFare+buffer, code),
+		Currency:        code,
+		Status:          types.HoldStatusActive,
+	}
+
+	resp, err := processor.Authorize(ctx, authorization(hold))
+	if errors.Is(err, types.ErrHoldsUnsupported) {
+		return holdFailure("Payment method does not support holds", err), nil
+	}
+	if err != nil || !resp.Success {
+		now := time.Now()
+		hold.Status = types.HoldStatusFailed
+		hold.FailureReason = processorFailure(resp, err)
+		hold.ClosedAt = &now
+		if err := s.holds.CreateHold(ctx, hold); err != nil {
+			return nil, err
+		}
+		return &types.HoldResponse{
+			Hold:    hold,
+			Success: false,
+			Message: "Authorization declined",
+			Errors:  []string{hold.FailureReason},
+		}, nil
+	}
+
+	hold.AuthorizationID = resp.TransactionID
+	hold.ExpiresAt = s.expiry(resp)
+	if err := s.holds.CreateHold(ctx, hold); err != nil {
+		s.void(ctx, processor, hold, hold.AuthorizationID)
+		return nil, err
+	}
+
+	s.logger.WithFields(logger.Fields{
+		"trip_id":    hold.TripID,
+		"hold_id":    hold.ID,
+		"amount":     hold.Amount,
+		"currency":   hold.Currency,
+		"expires_at": hold.ExpiresAt,
+	}).Info("Payment hold placed")
+
+	return &types.HoldResponse{Hold: hold, Success: true, Message: "Payment hold placed"}, nil
+}
+
+// CaptureHold charges a completed trip's actual fare against its hold and
+// releases the rest of the hold. A fare above the hold has the difference
+// charged separately. Trips whose hold lapsed, failed to capture or was never
+// placed are charged directly. Capturing a trip twice charges it once.
+func (s *HoldService) CaptureHold(ctx context.Context, req *types.CaptureHoldRequest) (*types.HoldResponse, error) {
+	if req.TripID == "" || req.Amount < 0 {
+		return holdFailure("Trip and a non-negative amount are required", nil), nil
+	}
+
+	unlock := s.lock(req.TripID)
+	defer unlock()
+
+	holds, err := s.holds.GetHoldsByTrip(ctx, req.TripID)
+	if err != nil {
+		return nil, err
+	}
+	if captured := latestHold(holds, types.HoldStatusCaptured); captured != nil {
+		return &types.HoldResponse{Hold: captured, Success: true, Message: "Trip fare was already captured"}, nil
+	}
+
+	hold := latestHold(holds, types.HoldStatusActive)
+	if hold == nil {
+		return s.chargeTrip(ctx, req, nil)
+	}
+	if req.Currency != "" {
+		if err := currency.Match(hold.Currency, req.Currency); err != nil {
+			return holdFailure("Fare currency does not match the hold", err), nil
+		}
+	}
+
+	processor := s.paymentService.processors[hold.PaymentMethod]
+	if req.Amount == 0 {
+		if err := s.release(ctx, processor, hold); err != nil {
+			return nil, err
+		}
+		return &types.HoldResponse{Hold: hold, Success: true, Message: "Trip had no fare, hold released"}, nil
+	}
+
+	now := time.Now()
+	if !now.Before(hold.ExpiresAt) {
+		hold.Status = types.HoldStatusExpired
+		hold.FailureReason = "authorization lapsed before the fare was captured"
+		hold.ClosedAt = &now
+		if err := s.holds.UpdateHold(ctx, hold); err != nil {
+			return nil, err
+		}
+		return s.chargeTrip(ctx, req, hold)
+	}
+
+	captureAmount := math.Min(req.Amount, hold.Amount)
+	resp, err := processor.Capture(ctx, hold.AuthorizationID, captureAmount)
+	if err != nil || !resp.Success {
+		reason := processorFailure(resp, err)
+		s.logger.WithFields(logger.Fields{
+			"trip_id": hold.TripID,
+			"hold_id": hold.ID,
+			"reason":  reason,
+		}).Warn("Failed to capture payment hold, charging the trip directly")
+		if err := s.release(ctx, processor, hold); err != nil {
+			return nil, err
+		}
+		hold.FailureReason = "capture failed: " + reason
+		if err := s.holds.UpdateHold(ctx, hold); err != nil {
+			return nil, err
+		}
+		return s.chargeTrip(ctx, req, hold)
+	}
+
+	payment := &types.Payment{
+		ID:              uuid.New().String(),
+		TripID:          hold.TripID,
+		UserID:          hold.UserID,
+		DriverID:        hold.DriverID,
+		Amount:          captureAmount,
+		Currency:        hold.Currency,
+		PaymentMethod:   hold.PaymentMethod,
+		Status:          types.PaymentStatusCompleted,
+		TransactionType: types.TransactionTypePayment,
+		ProcessorResponse: fmt.Sprintf("Code: %s, Message: %s, TxnID: %s",
+			resp.ResponseCode, resp.ResponseMessage, resp.TransactionID),
+		Metadata:    map[string]interface{}{"hold_id": hold.ID},
+		ProcessedAt: &now,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	hold.Status = types.HoldStatusCaptured
+	hold.CapturedAmount = captureAmount
+	hold.ReleasedAmount = currency.Round(hold.Amount-captureAmount, hold.Currency)
+	hold.PaymentID = payment.ID
+	hold.ClosedAt = &now
+
+	if err := s.paymentService.paymentRepo.CreatePayment(ctx, payment); err != nil {
+		s.logger.WithFields(logger.Fields{
+			"trip_id": hold.TripID,
+			"hold_id": hold.ID,
+			"error":   err.Error(),
+		}).Error("Payment hold was captured but the charge could not be recorded")
+		hold.FailureReason = "captured charge was not recorded: " + err.Error()
+		s.holds.UpdateHold(ctx, hold)
+		return holdFailure("Failed to record captured payment", err), nil
+	}
+	s.paymentService.recordPaymentInLedger(ctx, payment)
+	if err := s.holds.UpdateHold(ctx, hold); err != nil {
+		s.logger.WithFields(logger.Fields{
+			"trip_id":    hold.TripID,
+			"hold_id":    hold.ID,
+			"payment_id": payment.ID,
+			"error":      err.Error(),
+		}).Error("Failed to mark payment hold as captured")
+	}
+
+	response := &types.HoldResponse{
+		Hold:     hold,
+		Payments: []*types.Payment{payment},
+		Success:  true,
+		Message:  "Trip fare captured",
+	}
+
+	overage := currency.Round(req.Amount-captureAmount, hold.Currency)
+	if overage > 0 {
+		charge, err := s.paymentService.ProcessPayment(ctx, &types.ProcessPaymentRequest{
+			TripID:          hold.TripID,
+			UserID:          hold.UserID,
+			DriverID:        hold.DriverID,
+			Amount:          overage,
+			Currency:        hold.Currency,
+			PaymentMethodID: hold.PaymentMethodID,
+			Description:     "Fare above the payment hold",
+			Metadata:        map[string]interface{}{"hold_id": hold.ID},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if charge.Payment != nil {
+			response.Payments = append(response.Payments, charge.Payment)
+		}
+		if !charge.Success {
+			response.Success = false
+			response.Message = "Fare exceeded the hold and the difference could not be charged"
+			response.Errors = charge.Errors
+		}
+	}
+
+	return response, nil
+}
+
+// ReleaseHold voids a trip's active hold without charging it, as when the
+// trip is cancelled. Trips without an active hold have nothing to release.
+func (s *HoldService) ReleaseHold(ctx context.Context, tripID string) (*types.HoldResponse, error) {
+	if tripID == "" {
+		return holdFailure("Trip is required", nil), nil
+	}
+
+	unlock := s.lock(tripID)
+	defer unlock()
+
+	holds, err := s.holds.GetHoldsByTrip(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+	hold := latestHold(holds, types.HoldStatusActive)
+	if hold == nil {
+		return &types.HoldResponse{Success: true, Message: "Trip has no active hold"}, nil
+	}
+
+	if err := s.release(ctx, s.paymentService.processors[hold.PaymentMethod], hold); err != nil {
+		return nil, err
+	}
+	return &types.HoldResponse{Hold: hold, Success: true, Message: "Payment hold released"}, nil
+}
+
+// RenewExpiring authorizes again the active holds that lapse within the
+// renewal window, replacing their authorization, and marks holds that have
+// already lapsed as expired. It returns how many holds were renewed. Holds
+// whose renewal is declined keep their authorization and are retried on the
+// next run.
+func (s *HoldService) RenewExpiring(ctx context.Context, now time.Time) (int, error) {
+	expiring, err := s.holds.GetActiveHoldsExpiringBefore(ctx, now.Add(s.config.RenewBefore))
+	if err != nil {
+		return 0, err
+	}
+
+	renewed := 0
+	for _, hold := range expiring {
+		ok, err := s.renew(ctx, hold.ID, now)
+		if err != nil {
+			return renewed, err
+		}
+		if ok {
+			renewed++
+		}
+	}
+	return renewed, nil
+}
+
+// Start renews expiring holds every interval until ctx is cancelled
+func (s *HoldService) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.RenewExpiring(ctx, time.Now()); err != nil && ctx.Err() == nil {
+				s.logger.WithFields(logger.Fields{"error": err.Error()}).Error("Payment hold renewal failed")
+			}
+		}
+	}
+}
+
+func (s *HoldService) renew(ctx context.Context, holdID string, now time.Time) (bool, error) {
+	hold, err := s.holds.GetHold(ctx, holdID)
+	if err != nil {
+		return false, err
+	}
+
+	unlock := s.lock(hold.TripID)
+	defer unlock()
+
+	// The hold may have been captured or released since it was listed
+	hold, err = s.holds.GetHold(ctx, holdID)
+	if err != nil {
+		return false, err
+	}
+	if hold.Status != types.HoldStatusActive {
+		return false, nil
+	}
+
+	if !now.Before(hold.ExpiresAt) {
+		hold.Status = types.HoldStatusExpired
+		hold.FailureReason = "authorization lapsed before it was renewed"
+		hold.ClosedAt = &now
+		return false, s.holds.UpdateHold(ctx, hold)
+	}
+
+	processor := s.paymentService.processors[hold.PaymentMethod]
+	resp, err := processor.Authorize(ctx, authorization(hold))
+	if err != nil || !resp.Success {
+		hold.FailureReason = "renewal declined: " + processorFailure(resp, err)
+		s.logger.WithFields(logger.Fields{
+			"trip_id":    hold.TripID,
+			"hold_id":    hold.ID,
+			"expires_at": hold.ExpiresAt,
+			"reason":     hold.FailureReason,
+		}).Warn("Failed to renew payment hold")
+		return false, s.holds.UpdateHold(ctx, hold)
+	}
+
+	previous := hold.AuthorizationID
+	hold.AuthorizationID = resp.TransactionID
+	hold.ExpiresAt = s.expiry(resp)
+	hold.Renewals++
+	hold.FailureReason = ""
+	if err := s.holds.UpdateHold(ctx, hold); err != nil {
+		s.void(ctx, processor, hold, resp.TransactionID)
+		return false, err
+	}
+	s.void(ctx, processor, hold, previous)
+
+	return true, nil
+}
+
+// release voids the hold's authorization and records it as released
+func (s *HoldService) release(ctx context.Context, processor PaymentProcessor, hold *types.PaymentHold) error {
+	s.void(ctx, processor, hold, hold.AuthorizationID)
+
+	now := time.Now()
+	hold.Status = types.HoldStatusReleased
+	hold.ReleasedAmount = hold.Amount
+	hold.ClosedAt = &now
+	return s.holds.UpdateHold(ctx, hold)
+}
+
+// void releases an authorization. Authorizations that cannot be voided lapse
+// on their own, so failures are only logged.
+func (s *HoldService) void(ctx context.Context, processor PaymentProcessor, hold *types.PaymentHold, authorizationID string) {
+	resp, err := processor.Void(ctx, authorizationID)
+	if err != nil || !resp.Success {
+		s.logger.WithFields(logger.Fields{
+			"trip_id":          hold.TripID,
+			"hold_id":          hold.ID,
+			"authorization_id": authorizationID,
+			"reason":           processorFailure(resp, err),
+		}).Warn("Failed to void authorization, it will lapse on its own")
+	}
+}
+
+// chargeTrip charges the fare without a hold, to the lapsed or failed hold's
+// payment method when there is one
+func (s *HoldService) chargeTrip(ctx context.Context, req *types.CaptureHoldRequest, hold *types.PaymentHold) (*types.HoldResponse, error) {
+	if req.Amount == 0 {
+		return &types.HoldResponse{Hold: hold, Success: true, Message: "Trip had no fare"}, nil
+	}
+
+	charge := &types.ProcessPaymentRequest{
+		TripID:          req.TripID,
+		UserID:          req.UserID,
+		DriverID:        req.DriverID,
+		Amount:          req.Amount,
+		Currency:        req.Currency,
+		PaymentMethodID: req.PaymentMethodID,
+		Description:     "Trip fare",
+	}
+	if hold != nil {
+		charge.UserID = hold.UserID
+		charge.DriverID = hold.DriverID
+		charge.Currency = hold.Currency
+		charge.PaymentMethodID = hold.PaymentMethodID
+		charge.Metadata = map[string]interface{}{"hold_id": hold.ID}
+	}
+	if charge.PaymentMethodID == "" {
+		method, err := s.paymentMethod(ctx, charge.UserID, "")
+		if err != nil {
+			return holdFailure("Payment method not found", err), nil
+		}
+		charge.PaymentMethodID = method.ID
+	}
+
+	result, err := s.paymentService.ProcessPayment(ctx, charge)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &types.HoldResponse{
+		Hold:    hold,
+		Success: result.Success,
+		Message: "Trip fare charged",
+		Errors:  result.Errors,
+	}
+	if result.Payment != nil {
+		response.Payments = []*types.Payment{result.Payment}
+	}
+	if !result.Success {
+		response.Message = result.Message
+	}
+	return response, nil
+}
+
+// paymentMethod returns the user's payment method, their default one when
+// methodID is empty
+func (s *HoldService) paymentMethod(ctx context.Context, userID, methodID string) (*types.PaymentMethodDetails, error) {
+	if methodID != "" {
+		method, err := s.paymentService.paymentMethodRepo.GetPaymentMethod(ctx, methodID)
+		if err != nil {
+			return nil, err
+		}
+		if method.UserID != userID {
+			return nil, fmt.Errorf("payment method %s does not belong to user %s", methodID, userID)
+		}
+		return method, nil
+	}
+
+	methods, err := s.paymentService.paymentMethodRepo.GetUserPaymentMethods(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, method := range methods {
+		if method.IsDefault {
+			return method, nil
+		}
+	}
+	return nil, fmt.Errorf("user %s has no default payment method", userID)
+}
+
+// expiry returns when an authorization lapses
+func (s *HoldService) expiry(resp *ProcessorResponse) time.Time {
+	if !resp.ExpiresAt.IsZero() {
+		return resp.ExpiresAt
+	}
+	return time.Now().Add(s.config.DefaultValidity)
+}
+
+// authorization is the payment a processor is asked to authorize for a hold
+func authorization(hold *types.PaymentHold) *types.Payment {
+	return &types.Payment{
+		ID:              hold.ID,
+		TripID:          hold.TripID,
+		UserID:          hold.UserID,
+		DriverID:        hold.DriverID,
+		Amount:          hold.Amount,
+		Currency:        hold.Currency,
+		PaymentMethod:   hold.PaymentMethod,
+		Status:          types.PaymentStatusPending,
+		TransactionType: types.TransactionTypeAuthorization,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+}
+
+// latestHold returns the most recent of the holds in status
+func latestHold(holds []*types.PaymentHold, status types.HoldStatus) *types.PaymentHold {
+	for i := len(holds) - 1; i >= 0; i-- {
+		if holds[i].Status == status {
+			return holds[i]
+		}
+	}
+	return nil
+}
+
+// processorFailure describes why a processor call did not succeed
+func processorFailure(resp *ProcessorResponse, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	return resp.ResponseMessage
+}
+
+func holdFailure(message string, err error) *types.HoldResponse {
+	response := &types.HoldResponse{Success: false, Message: message}
+	if err != nil {
+		response.Errors = []string{err.Error()}
+	}
+	return response
+}
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rideshare-platform/services/payment-service/internal/repository"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// holdingCardProcessor approves charges and authorizations lasting validity,
+// declining authorizations while decline is set
+type holdingCardProcessor struct {
+	approvingCardProcessor
+	validity    time.Duration
+	decline     bool
+	failCapture bool
+	captured    map[string]float64
+	voided      []string
+}
+
+func (p *holdingCardProcessor) Authorize(ctx context.Context, payment *types.Payment) (*ProcessorResponse, error) {
+	if p.decline {
+		return &ProcessorResponse{Success: false, ResponseCode: "DECLINED", ResponseMessage: "Authorization declined by issuer"}, nil
+	}
+	return &ProcessorResponse{Success: true, TransactionID: uuid.New().String(), ResponseCode: "AUTHORIZED", ExpiresAt: time.Now().Add(p.validity)}, nil
+}
+
+func (p *holdingCardProcessor) Capture(ctx context.Context, authorizationID string, amount float64) (*ProcessorResponse, error) {
+	if p.failCapture {
+		return &ProcessorResponse{Success: false, ResponseCode: "CAPTURE_FAILED", ResponseMessage: "Authorization could not be captured"}, nil
+	}
+	p.captured[authorizationID] = amount
+	return &ProcessorResponse{Success: true, TransactionID: authorizationID, ResponseCode: "CAPTURED"}, nil
+}
+
+func (p *holdingCardProcessor) Void(ctx context.Context, authorizationID string) (*ProcessorResponse, error) {
+	p.voided = append(p.voided, authorizationID)
+	return &ProcessorResponse{Success: true, TransactionID: authorizationID, ResponseCode: "VOIDED"}, nil
+}
+
+func newHoldTestService(t *testing.T) (*HoldService, *holdingCardProcessor, *repository.MockPaymentRepository) {
+	paymentRepo := repository.NewMockPaymentRepository()
+	methods := repository.NewMockPaymentMethodRepository()
+	payments := NewPaymentService(paymentRepo, methods, repository.NewMockRefundRepository(), nil, *logger.NewLogger("error", "test"))
+	processor := &holdingCardProcessor{validity: 7 * 24 * time.Hour, captured: make(map[string]float64)}
+	payments.processors[types.PaymentMethodCreditCard] = processor
+	require.NoError(t, methods.CreatePaymentMethod(context.Background(), &types.PaymentMethodDetails{
+		ID: "card-1", UserID: "rider-1", Type: types.PaymentMethodCreditCard, CardToken: "tok_1", IsDefault: true,
+	}))
+	require.NoError(t, methods.CreatePaymentMethod(context.Background(), &types.PaymentMethodDetails{
+		ID: "cash-1", UserID: "rider-2", Type: types.PaymentMethodCash, IsDefault: true,
+	}))
+
+	holds := NewHoldService(repository.NewMockPaymentHoldRepository(), payments, DefaultHoldConfig(), *logger.NewLogger("error", "test"))
+	return holds, processor, paymentRepo
+}
+
+func TestHoldService_CapturesFareAndReleasesTheRest(t *testing.T) {
+	ctx := context.Background()
+	holds, processor, paymentRepo := newHoldTestService(t)
+
+	placed, err := holds.PlaceHold(ctx, &types.PlaceHoldRequest{TripID: "trip-1", UserID: "rider-1", DriverID: "driver-1", EstimatedFare: 20, Currency: "USD"})
+	require.NoError(t, err)
+	require.True(t, placed.Success, placed.Errors)
+	assert.Equal(t, types.HoldStatusActive, placed.Hold.Status)
+	assert.Equal(t, "card-1", placed.Hold.PaymentMethodID, "the default payment method is held")
+	assert.Equal(t, 25.0, placed.Hold.Amount, "a quarter of the estimate is held on top")
+
+	again, err := holds.PlaceHold(ctx, &types.PlaceHoldRequest{TripID: "trip-1", UserID: "rider-1", EstimatedFare: 20})
+	require.NoError(t, err)
+	assert.Equal(t, placed.Hold.ID, again.Hold.ID, "a trip keeps its active hold")
+
+	captured, err := holds.CaptureHold(ctx, &types.CaptureHoldRequest{TripID: "trip-1", Amount: 18, Currency: "USD"})
+	require.NoError(t, err)
+	require.True(t, captured.Success, captured.Errors)
+	assert.Equal(t, types.HoldStatusCaptured, captured.Hold.Status)
+	assert.Equal(t, 18.0, captured.Hold.CapturedAmount)
+	assert.Equal(t, 7.0, captured.Hold.ReleasedAmount)
+	assert.Equal(t, 18.0, processor.captured[placed.Hold.AuthorizationID])
+	require.Len(t, captured.Payments, 1)
+	assert.Equal(t, captured.Payments[0].ID, captured.Hold.PaymentID)
+
+	_, err = holds.CaptureHold(ctx, &types.CaptureHoldRequest{TripID: "trip-1", Amount: 18})
+	require.NoError(t, err)
+	charges, err := paymentRepo.GetPaymentsByTrip(ctx, "trip-1")
+	require.NoError(t, err)
+	require.Len(t, charges, 1, "a trip's fare is captured once")
+	assert.Equal(t, types.TransactionTypePayment, charges[0].TransactionType)
+	assert.Equal(t, types.PaymentStatusCompleted, charges[0].Status)
+	assert.Equal(t, placed.Hold.ID, charges[0].Metadata["hold_id"])
+}
+
+func TestHoldService_ChargesFareAboveTheHold(t *testing.T) {
+	ctx := context.Background()
+	holds, _, paymentRepo := newHoldTestService(t)
+
+	placed, err := holds.PlaceHold(ctx, &types.PlaceHoldRequest{TripID: "trip-1", UserID: "rider-1", EstimatedFare: 6})
+	require.NoError(t, err)
+	assert.Equal(t, 8.0, placed.Hold.Amount, "small fares get the minimum buffer")
+
+	captured, err := holds.CaptureHold(ctx, &types.CaptureHoldRequest{TripID: "trip-1", Amount: 11.5})
+	require.NoError(t, err)
+	require.True(t, captured.Success, captured.Errors)
+	assert.Equal(t, 8.0, captured.Hold.CapturedAmount)
+	assert.Zero(t, captured.Hold.ReleasedAmount)
+	require.Len(t, captured.Payments, 2)
+	assert.Equal(t, 3.5, captured.Payments[1].Amount)
+
+	charges, err := paymentRepo.GetPaymentsByTrip(ctx, "trip-1")
+	require.NoError(t, err)
+	var total float64
+	for _, charge := range charges {
+		total += charge.Amount
+	}
+	assert.Equal(t, 11.5, total)
+}
+
+func TestHoldService_ReleasesCancelledTripsAndChargesUnheldOnes(t *testing.T) {
+	ctx := context.Background()
+	holds, processor, paymentRepo := newHoldTestService(t)
+
+	placed, err := holds.PlaceHold(ctx, &types.PlaceHoldRequest{TripID: "trip-1", UserID: "rider-1", EstimatedFare: 20})
+	require.NoError(t, err)
+	released, err := holds.ReleaseHold(ctx, "trip-1")
+	require.NoError(t, err)
+	assert.Equal(t, types.HoldStatusReleased, released.Hold.Status)
+	assert.Equal(t, 25.0, released.Hold.ReleasedAmount)
+	assert.Equal(t, []string{placed.Hold.AuthorizationID}, processor.voided)
+
+	released, err = holds.ReleaseHold(ctx, "trip-1")
+	require.NoError(t, err)
+	assert.True(t, released.Success)
+	assert.Nil(t, released.Hold, "a trip's hold is released once")
+
+	cash, err := holds.PlaceHold(ctx, &types.PlaceHoldRequest{TripID: "trip-2", UserID: "rider-2", EstimatedFare: 20})
+	require.NoError(t, err)
+	assert.False(t, cash.Success)
+	assert.Equal(t, "Payment method does not support holds", cash.Message)
+
+	processor.decline = true
+	declined, err := holds.PlaceHold(ctx, &types.PlaceHoldRequest{TripID: "trip-3", UserID: "rider-1", EstimatedFare: 20})
+	require.NoError(t, err)
+	assert.False(t, declined.Success)
+	assert.Equal(t, types.HoldStatusFailed, declined.Hold.Status)
+
+	// Trips without an active hold are charged when they complete
+	processor.decline = false
+	charged, err := holds.CaptureHold(ctx, &types.CaptureHoldRequest{TripID: "trip-3", UserID: "rider-1", Amount: 17})
+	require.NoError(t, err)
+	require.True(t, charged.Success, charged.Errors)
+	require.Len(t, charged.Payments, 1)
+	assert.Equal(t, 17.0, charged.Payments[0].Amount)
+	assert.Empty(t, processor.captured)
+
+	charges, err := paymentRepo.GetPaymentsByTrip(ctx, "trip-3")
+	require.NoError(t, err)
+	assert.Len(t, charges, 1)
+}
+
+func TestHoldService_RenewsHoldsBeforeTheyLapse(t *testing.T) {
+	ctx := context.Background()
+	holds, processor, _ := newHoldTestService(t)
+
+	processor.validity = time.Hour
+	placed, err := holds.PlaceHold(ctx, &types.PlaceHoldRequest{TripID: "trip-long", UserID: "rider-1", EstimatedFare: 80})
+	require.NoError(t, err)
+	first := placed.Hold.AuthorizationID
+
+	// A declined renewal keeps the current authorization
+	processor.decline = true
+	renewed, err := holds.RenewExpiring(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Zero(t, renewed)
+	stored, err := holds.GetTripHolds(ctx, "trip-long")
+	require.NoError(t, err)
+	assert.Equal(t, types.HoldStatusActive, stored[0].Status)
+	assert.Equal(t, first, stored[0].AuthorizationID)
+	assert.Contains(t, stored[0].FailureReason, "renewal declined")
+
+	processor.decline = false
+	processor.validity = 7 * 24 * time.Hour
+	renewed, err = holds.RenewExpiring(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, renewed)
+	stored, err = holds.GetTripHolds(ctx, "trip-long")
+	require.NoError(t, err)
+	assert.NotEqual(t, first, stored[0].AuthorizationID)
+	assert.Equal(t, 1, stored[0].Renewals)
+	assert.Empty(t, stored[0].FailureReason)
+	assert.Equal(t, []string{first}, processor.voided, "the replaced authorization is voided")
+
+	renewed, err = holds.RenewExpiring(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Zero(t, renewed, "holds far from lapsing are left alone")
+}
+
+func TestHoldService_ChargesDirectlyWhenTheHoldLapsed(t *testing.T) {
+	ctx := context.Background()
+	holds, processor, _ := newHoldTestService(t)
+
+	processor.validity = -time.Minute
+	placed, err := holds.PlaceHold(ctx, &types.PlaceHoldRequest{TripID: "trip-1", UserID: "rider-1", EstimatedFare: 20})
+	require.NoError(t, err)
+	require.True(t, placed.Success, placed.Errors)
+
+	renewed, err := holds.RenewExpiring(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Zero(t, renewed)
+	stored, err := holds.GetTripHolds(ctx, "trip-1")
+	require.NoError(t, err)
+	assert.Equal(t, types.HoldStatusExpired, stored[0].Status)
+
+	charged, err := holds.CaptureHold(ctx, &types.CaptureHoldRequest{TripID: "trip-1", UserID: "rider-1", Amount: 22})
+	require.NoError(t, err)
+	require.True(t, charged.Success, charged.Errors)
+	require.Len(t, charged.Payments, 1)
+	assert.Equal(t, 22.0, charged.Payments[0].Amount)
+	assert.Empty(t, processor.captured)
+
+	// A hold whose capture fails is released and the fare charged directly
+	processor.validity = time.Hour
+	processor.failCapture = true
+	placed, err = holds.PlaceHold(ctx, &types.PlaceHoldRequest{TripID: "trip-2", UserID: "rider-1", EstimatedFare: 20})
+	require.NoError(t, err)
+	charged, err = holds.CaptureHold(ctx, &types.CaptureHoldRequest{TripID: "trip-2", Amount: 19})
+	require.NoError(t, err)
+	require.True(t, charged.Success, charged.Errors)
+	assert.Equal(t, types.HoldStatusReleased, charged.Hold.Status)
+	assert.Contains(t, charged.Hold.FailureReason, "capture failed")
+	assert.Equal(t, placed.Hold.ID, charged.Payments[0].Metadata["hold_id"])
+	assert.Contains(t, processor.voided, placed.Hold.AuthorizationID)
+}
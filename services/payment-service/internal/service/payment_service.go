@@ -10,7 +10,10 @@ import (
 	"github.com/google/uuid"
 	"github.com/rideshare-platform/services/payment-service/internal/repository"
 	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/services/payment-service/internal/vault"
+	"github.com/rideshare-platform/shared/currency"
 	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/pagination"
 )
 
 // PaymentProcessor interface for different payment processors
@@ -18,6 +21,14 @@ type PaymentProcessor interface {
 	ProcessPayment(ctx context.Context, payment *types.Payment) (*ProcessorResponse, error)
 	ProcessRefund(ctx context.Context, payment *types.Payment, amount float64) (*ProcessorResponse, error)
 	VerifyPaymentMethod(ctx context.Context, method *types.PaymentMethodDetails) error
+
+	// Authorize holds payment.Amount on the payment method without charging
+	// it. Processors that cannot hold funds return types.ErrHoldsUnsupported.
+	Authorize(ctx context.Context, payment *types.Payment) (*ProcessorResponse, error)
+	// Capture charges amount of an authorization and releases the rest of it
+	Capture(ctx context.Context, authorizationID string, amount float64) (*ProcessorResponse, error)
+	// Void releases an authorization without charging it
+	Void(ctx context.Context, authorizationID string) (*ProcessorResponse, error)
 }
 
 // ProcessorResponse represents the response from a payment processor
@@ -29,6 +40,9 @@ type ProcessorResponse struct {
 	ResponseMessage   string  `json:"response_message"`
 	ProcessingFee     float64 `json:"processing_fee"`
 	AuthorizationCode string  `json:"authorization_code,omitempty"`
+
+	// ExpiresAt is when an authorization lapses if it is not captured
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
 }
 
 // FraudDetectionService handles fraud detection logic
@@ -44,6 +58,12 @@ type PaymentService struct {
 	fraudService      FraudDetectionService
 	processors        map[types.PaymentMethod]PaymentProcessor
 	logger            logger.Logger
+
+	defaultCurrency   string
+	rates             currency.RateProvider
+	reportingCurrency string
+	vault             vault.Vault
+	ledger            *LedgerService
 }
 
 // NewPaymentService creates a new payment service
@@ -61,6 +81,7 @@ func NewPaymentService(
 		fraudService:      fraudService,
 		processors:        make(map[types.PaymentMethod]PaymentProcessor),
 		logger:            logger,
+		defaultCurrency:   currency.Default,
 	}
 
 	// Initialize mock processors
@@ -73,8 +94,31 @@ func NewPaymentService(
 	return service
 }
 
+// SetLedger sets the general ledger completed payments and refunds are
+// posted to
+func (s *PaymentService) SetLedger(ledger *LedgerService) {
+	s.ledger = ledger
+}
+
+// recordPaymentInLedger posts a payment to the general ledger, if one is set.
+// Failures are logged by the ledger; the payment itself has already happened.
+func (s *PaymentService) recordPaymentInLedger(ctx context.Context, payment *types.Payment) {
+	if s.ledger != nil {
+		s.ledger.RecordPayment(ctx, payment)
+	}
+}
+
 // ProcessPayment processes a payment transaction
 func (s *PaymentService) ProcessPayment(ctx context.Context, req *types.ProcessPaymentRequest) (*types.PaymentResponse, error) {
+	paymentCurrency, err := s.paymentCurrency(ctx, req)
+	if err != nil {
+		return &types.PaymentResponse{
+			Success: false,
+			Message: "Invalid payment currency",
+			Errors:  []string{err.Error()},
+		}, nil
+	}
+
 	// Get payment method details
 	paymentMethod, err := s.paymentMethodRepo.GetPaymentMethod(ctx, req.PaymentMethodID)
 	if err != nil {
@@ -92,7 +136,7 @@ func (s *PaymentService) ProcessPayment(ctx context.Context, req *types.ProcessP
 		UserID:          req.UserID,
 		DriverID:        req.DriverID,
 		Amount:          req.Amount,
-		Currency:        req.Currency,
+		Currency:        paymentCurrency,
 		PaymentMethod:   paymentMethod.Type,
 		Status:          types.PaymentStatusPending,
 		TransactionType: types.TransactionTypePayment,
@@ -183,6 +227,7 @@ func (s *PaymentService) ProcessPayment(ctx context.Context, req *types.ProcessP
 		processorResp.ResponseCode, processorResp.ResponseMessage, processorResp.TransactionID)
 
 	s.paymentRepo.UpdatePaymentStatus(ctx, payment.ID, payment.Status, payment.ProcessorResponse)
+	s.recordPaymentInLedger(ctx, payment)
 
 	return &types.PaymentResponse{
 		Payment: payment,
@@ -203,6 +248,17 @@ func (s *PaymentService) ProcessRefund(ctx context.Context, req *types.RefundPay
 		}, nil
 	}
 
+	// Refunds are paid back in the currency that was charged
+	if req.Currency != "" {
+		if err := currency.Match(payment.Currency, req.Currency); err != nil {
+			return &types.PaymentResponse{
+				Success: false,
+				Message: "Refund currency does not match the payment",
+				Errors:  []string{err.Error()},
+			}, nil
+		}
+	}
+
 	// Validate refund amount
 	if req.Amount > payment.Amount {
 		return &types.PaymentResponse{
@@ -261,13 +317,19 @@ func (s *PaymentService) ProcessRefund(ctx context.Context, req *types.RefundPay
 
 	// Update refund status
 	if processorResp.Success {
-		s.refundRepo.UpdateRefundStatus(ctx, refund.ID, types.PaymentStatusCompleted)
+		refund.Status = types.PaymentStatusCompleted
 		// Note: In real implementation, we might update payment status to partially/fully refunded
 	} else {
-		s.refundRepo.UpdateRefundStatus(ctx, refund.ID, types.PaymentStatusFailed)
+		refund.Status = types.PaymentStatusFailed
+	}
+	s.refundRepo.UpdateRefundStatus(ctx, refund.ID, refund.Status)
+	if s.ledger != nil {
+		s.ledger.RecordRefund(ctx, payment, refund)
 	}
 
 	return &types.PaymentResponse{
+		Payment: payment,
+		Refund:  refund,
 		Success: processorResp.Success,
 		Message: "Refund processed",
 	}, nil
@@ -292,8 +354,13 @@ func (s *PaymentService) AddPaymentMethod(ctx context.Context, req *types.AddPay
 	// Extract relevant details based on payment type
 	switch req.Type {
 	case types.PaymentMethodCreditCard, types.PaymentMethodDebitCard:
-		if cardNumber, ok := req.Details["card_number"].(string); ok {
-			method.LastFourDigits = cardNumber[len(cardNumber)-4:]
+		// Cards are exchanged for a vault token, which also sets the fingerprint
+		if err := s.tokenizeCard(ctx, method, req.Details); err != nil {
+			return &types.PaymentMethodResponse{
+				Success: false,
+				Message: "Card could not be tokenized",
+				Errors:  []string{err.Error()},
+			}, nil
 		}
 		if bankName, ok := req.Details["bank_name"].(string); ok {
 			method.BankName = bankName
@@ -345,14 +412,43 @@ func (s *PaymentService) GetUserPaymentMethods(ctx context.Context, userID strin
 	return s.paymentMethodRepo.GetUserPaymentMethods(ctx, userID)
 }
 
+// RemoveUserPaymentMethods deletes every payment method a user has saved,
+// for a data subject erasure request, and returns how many were removed.
+// The user's payments are financial records and are kept.
+func (s *PaymentService) RemoveUserPaymentMethods(ctx context.Context, userID string) (int, error) {
+	if userID == "" {
+		return 0, fmt.Errorf("user ID is required")
+	}
+
+	methods, err := s.paymentMethodRepo.GetUserPaymentMethods(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get payment methods: %w", err)
+	}
+	for i, method := range methods {
+		if err := s.paymentMethodRepo.DeletePaymentMethod(ctx, method.ID); err != nil {
+			return i, fmt.Errorf("failed to delete payment method %s: %w", method.ID, err)
+		}
+	}
+	return len(methods), nil
+}
+
 // GetPayment retrieves a payment by ID
 func (s *PaymentService) GetPayment(ctx context.Context, paymentID string) (*types.Payment, error) {
 	return s.paymentRepo.GetPayment(ctx, paymentID)
 }
 
-// GetUserPayments retrieves payments for a user with pagination
-func (s *PaymentService) GetUserPayments(ctx context.Context, userID string, limit, offset int) ([]*types.Payment, error) {
-	return s.paymentRepo.GetPaymentsByUser(ctx, userID, limit, offset)
+// GetUserPayments retrieves a page of the payments charged to a user
+func (s *PaymentService) GetUserPayments(ctx context.Context, userID string, page pagination.Request) (*pagination.Page[*types.Payment], error) {
+	payments, err := s.paymentRepo.GetPaymentsByUser(ctx, userID, page)
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.paymentRepo.CountPaymentsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	result := pagination.NewPage(payments, page, types.PaymentSortKey(page.Sort), total)
+	return &result, nil
 }
 
 // GetTripPayments retrieves all payments for a trip
@@ -360,6 +456,44 @@ func (s *PaymentService) GetTripPayments(ctx context.Context, tripID string) ([]
 	return s.paymentRepo.GetPaymentsByTrip(ctx, tripID)
 }
 
+// ListPayments pages through payments created in [from, to). The payments
+// are not counted, the page's total estimate stays zero.
+func (s *PaymentService) ListPayments(ctx context.Context, from, to time.Time, page pagination.Request) (*pagination.Page[*types.Payment], error) {
+	payments, err := s.paymentRepo.GetPaymentsCreatedBetween(ctx, from, to, page)
+	if err != nil {
+		return nil, err
+	}
+	result := pagination.NewPage(payments, page, types.PaymentSortKey(page.Sort), 0)
+	return &result, nil
+}
+
+// ListPaymentsByStatus pages through the payments in a status. The payments
+// are not counted, the page's total estimate stays zero.
+func (s *PaymentService) ListPaymentsByStatus(ctx context.Context, status types.PaymentStatus, page pagination.Request) (*pagination.Page[*types.Payment], error) {
+	payments, err := s.paymentRepo.GetPaymentsByStatus(ctx, status, page)
+	if err != nil {
+		return nil, err
+	}
+	result := pagination.NewPage(payments, page, types.PaymentSortKey(page.Sort), 0)
+	return &result, nil
+}
+
+// RefundedAmount sums the refunds against a payment that have not failed
+func (s *PaymentService) RefundedAmount(ctx context.Context, payment *types.Payment) (float64, error) {
+	refunds, err := s.refundRepo.GetRefundsByPayment(ctx, payment.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	var refunded float64
+	for _, refund := range refunds {
+		if refund.Status != types.PaymentStatusFailed {
+			refunded += refund.Amount
+		}
+	}
+	return currency.Round(refunded, payment.Currency), nil
+}
+
 // generateFingerprint creates a unique fingerprint for duplicate detection
 func (s *PaymentService) generateFingerprint(method *types.PaymentMethodDetails) string {
 	var parts []string
@@ -367,11 +501,6 @@ func (s *PaymentService) generateFingerprint(method *types.PaymentMethodDetails)
 	parts = append(parts, method.UserID)
 
 	switch method.Type {
-	case types.PaymentMethodCreditCard, types.PaymentMethodDebitCard:
-		if cardNumber, ok := method.Details["card_number"].(string); ok {
-			// Use last 4 digits and length for fingerprint
-			parts = append(parts, fmt.Sprintf("%d_%s", len(cardNumber), cardNumber[len(cardNumber)-4:]))
-		}
 	case types.PaymentMethodDigitalWallet:
 		if email, ok := method.Details["email"].(string); ok {
 			parts = append(parts, email)
@@ -500,7 +629,12 @@ func (s *SimpleFraudDetectionService) analyzeLocation() float64 {
 
 // GetPaymentHistory returns payment history for a user
 func (s *PaymentService) GetPaymentHistory(ctx context.Context, userID string) ([]*types.Payment, error) {
-	return s.paymentRepo.GetPaymentsByUser(ctx, userID, 100, 0) // Get last 100 payments
+	recent := pagination.Request{Limit: 100, Sort: types.PaymentListOptions.Default}
+	payments, err := s.paymentRepo.GetPaymentsByUser(ctx, userID, recent)
+	if err != nil {
+		return nil, err
+	}
+	return payments[:min(len(payments), recent.Limit)], nil // Last 100 payments
 }
 
 // ValidatePaymentAmount validates if the payment amount is within acceptable limits
@@ -94,11 +94,12 @@ func TestPaymentService_GenerateFingerprint(t *testing.T) {
 		},
 	}
 
+	// Card fingerprints come from the vault, card digits are never used here
 	fingerprint := service.generateFingerprint(method)
 	assert.NotEmpty(t, fingerprint)
 	assert.Contains(t, fingerprint, "credit_card")
 	assert.Contains(t, fingerprint, "user123")
-	assert.Contains(t, fingerprint, "1111")
+	assert.NotContains(t, fingerprint, "1111")
 
 	// Test digital wallet fingerprint
 	method2 := &types.PaymentMethodDetails{
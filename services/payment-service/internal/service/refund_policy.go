@@ -0,0 +1,464 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/services/payment-service/internal/repository"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/currency"
+	"github.com/rideshare-platform/shared/events"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// refundPolicyActor is recorded as the actor of automatic refund decisions
+const refundPolicyActor = "refund-policy"
+
+// RefundPolicyRule is the refund policy for one reason code
+type RefundPolicyRule struct {
+	Enabled bool `json:"enabled"`
+	// RefundRatio is the share of what the rider was charged that is refunded.
+	// Overcharges always refund the difference to the reconciled fare.
+	RefundRatio float64 `json:"refund_ratio"`
+	// AutoApproveLimit is the largest refund, in the payment's currency, that
+	// is paid without review. Larger refunds wait in the review queue.
+	AutoApproveLimit float64 `json:"auto_approve_limit"`
+}
+
+// RefundPolicyConfig holds the refund policy for each automated trigger
+type RefundPolicyConfig struct {
+	Rules map[types.RefundReasonCode]RefundPolicyRule `json:"rules"`
+	// An overcharge is only refunded if it is at least MinOvercharge and at
+	// least MinOverchargeRatio of the reconciled fare
+	MinOvercharge      float64 `json:"min_overcharge"`
+	MinOverchargeRatio float64 `json:"min_overcharge_ratio"`
+}
+
+// DefaultRefundPolicyConfig returns the default refund policy
+func DefaultRefundPolicyConfig() RefundPolicyConfig {
+	return RefundPolicyConfig{
+		Rules: map[types.RefundReasonCode]RefundPolicyRule{
+			types.RefundReasonDriverNoShow:         {Enabled: true, RefundRatio: 1.0, AutoApproveLimit: 50},
+			types.RefundReasonPlatformCancellation: {Enabled: true, RefundRatio: 1.0, AutoApproveLimit: 50},
+			types.RefundReasonOvercharge:           {Enabled: true, RefundRatio: 1.0, AutoApproveLimit: 25},
+		},
+		MinOvercharge:      1.0,
+		MinOverchargeRatio: 0.1,
+	}
+}
+
+// RefundPolicyService turns trip events and reconciliation findings into
+// refunds. Each trigger raises a refund case per payment of the trip; cases
+// within the auto-approval limit are refunded straight away and the rest are
+// queued for review. Every step is written to the case's audit trail.
+type RefundPolicyService struct {
+	paymentService *PaymentService
+	caseRepo       repository.RefundCaseRepository
+	config         RefundPolicyConfig
+	logger         logger.Logger
+
+	wallets *WalletService
+	// Triggers are handled one at a time so redelivered events are detected
+	mutex sync.Mutex
+}
+
+// NewRefundPolicyService creates a new refund policy service
+func NewRefundPolicyService(
+	paymentService *PaymentService,
+	caseRepo repository.RefundCaseRepository,
+	config RefundPolicyConfig,
+	logger logger.Logger,
+) *RefundPolicyService {
+	return &RefundPolicyService{
+		paymentService: paymentService,
+		caseRepo:       caseRepo,
+		config:         config,
+		logger:         logger,
+	}
+}
+
+// SetWalletService enables refunds of fares paid from wallets, which are
+// given back as ride credits
+func (s *RefundPolicyService) SetWalletService(wallets *WalletService) {
+	s.wallets = wallets
+}
+
+// HandleTripEvent raises refunds for trip events covered by the policy: driver
+// no-shows and cancellations by the platform. It is an events.EventHandler.
+func (s *RefundPolicyService) HandleTripEvent(ctx context.Context, event *events.Event) error {
+	var reason types.RefundReasonCode
+	switch event.Type {
+	case events.TripDriverNoShowEvent:
+		reason = types.RefundReasonDriverNoShow
+	case events.TripCancelledEvent:
+		cancelledBy, _ := event.Data["cancelled_by"].(string)
+		cancelReason, _ := event.Data["reason"].(string)
+		switch {
+		case cancelReason == string(types.RefundReasonDriverNoShow):
+			reason = types.RefundReasonDriverNoShow
+		case cancelledBy == "platform" || cancelledBy == "system":
+			reason = types.RefundReasonPlatformCancellation
+		default:
+			// Riders and drivers cancelling is handled by cancellation fees
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	tripID, _ := event.Data["trip_id"].(string)
+	if tripID == "" {
+		tripID = event.AggregateID
+	}
+
+	response, err := s.Trigger(ctx, &types.RefundTrigger{
+		TripID:  tripID,
+		Reason:  reason,
+		Source:  event.Source,
+		Details: fmt.Sprintf("%s event %s", event.Type, event.ID),
+	})
+	if err != nil {
+		return err
+	}
+	if !response.Success {
+		return fmt.Errorf("refund trigger for trip %s failed: %s", tripID, response.Message)
+	}
+	return nil
+}
+
+// Trigger raises refunds for a trip under the policy for the trigger's reason.
+// A trip is only refunded once per reason, so redelivered triggers return the
+// cases already raised.
+func (s *RefundPolicyService) Trigger(ctx context.Context, trigger *types.RefundTrigger) (*types.RefundTriggerResponse, error) {
+	rule, exists := s.config.Rules[trigger.Reason]
+	if !exists || !rule.Enabled {
+		return &types.RefundTriggerResponse{
+			Success: false,
+			Message: "No refund policy for reason",
+			Errors:  []string{fmt.Sprintf("refunds for %q are not automated", trigger.Reason)},
+		}, nil
+	}
+	if trigger.TripID == "" {
+		return &types.RefundTriggerResponse{Success: false, Message: "Trip ID is required"}, nil
+	}
+	if trigger.Reason == types.RefundReasonOvercharge && trigger.ExpectedAmount <= 0 {
+		return &types.RefundTriggerResponse{Success: false, Message: "Expected amount is required for overcharges"}, nil
+	}
+	if trigger.Source == "" {
+		trigger.Source = "api"
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	existing, err := s.caseRepo.GetCasesByTrip(ctx, trigger.TripID)
+	if err != nil {
+		return nil, err
+	}
+	var raised []*types.RefundCase
+	for _, refundCase := range existing {
+		if refundCase.Reason == trigger.Reason && refundCase.Status != types.RefundCaseFailed {
+			raised = append(raised, refundCase)
+		}
+	}
+	if len(raised) > 0 {
+		return &types.RefundTriggerResponse{Success: true, Message: "Refund already raised", Cases: raised}, nil
+	}
+
+	payments, err := s.refundablePayments(ctx, trigger.TripID)
+	if err != nil {
+		return nil, err
+	}
+	if len(payments) == 0 {
+		s.logger.LogAuditEvent(ctx, "refund_trigger_ignored", "trip", logger.Fields{
+			"trip_id": trigger.TripID,
+			"reason":  trigger.Reason,
+			"source":  trigger.Source,
+			"details": "no refundable payments",
+		})
+		return &types.RefundTriggerResponse{Success: true, Message: "No refundable payments for trip"}, nil
+	}
+
+	amounts, message := s.refundAmounts(trigger, rule, payments)
+	if len(amounts) == 0 {
+		s.logger.LogAuditEvent(ctx, "refund_trigger_ignored", "trip", logger.Fields{
+			"trip_id": trigger.TripID,
+			"reason":  trigger.Reason,
+			"source":  trigger.Source,
+			"details": message,
+		})
+		return &types.RefundTriggerResponse{Success: true, Message: message}, nil
+	}
+
+	// The limit applies to the whole trigger, so a fare split across payments
+	// is reviewed the same way as one paid in full
+	var total float64
+	for _, amount := range amounts {
+		total += amount
+	}
+	autoApprove := total <= rule.AutoApproveLimit
+
+	response := &types.RefundTriggerResponse{Success: true, Message: "Refund queued for review"}
+	if autoApprove {
+		response.Message = "Refund processed"
+	}
+	for _, refundable := range payments {
+		amount := amounts[refundable.payment.ID]
+		if amount <= 0 {
+			continue
+		}
+
+		refundCase := &types.RefundCase{
+			TripID:    trigger.TripID,
+			PaymentID: refundable.payment.ID,
+			UserID:    refundable.payment.UserID,
+			Reason:    trigger.Reason,
+			Amount:    amount,
+			Currency:  refundable.payment.Currency,
+			Status:    types.RefundCasePendingReview,
+			Source:    trigger.Source,
+			Details:   trigger.Details,
+		}
+		if err := s.caseRepo.CreateCase(ctx, refundCase); err != nil {
+			return nil, err
+		}
+		s.audit(ctx, refundCase, "raised", trigger.Source, trigger.Details)
+
+		if autoApprove {
+			refundCase.AutoApproved = true
+			s.audit(ctx, refundCase, "auto_approved", refundPolicyActor, fmt.Sprintf("within auto-approval limit of %.2f", rule.AutoApproveLimit))
+			s.payRefund(ctx, refundCase, refundPolicyActor)
+			if refundCase.Status == types.RefundCaseFailed {
+				response.Success = false
+				response.Message = "Refund failed"
+				response.Errors = append(response.Errors, refundCase.FailureReason)
+			}
+		} else {
+			s.audit(ctx, refundCase, "queued_for_review", refundPolicyActor, fmt.Sprintf("above auto-approval limit of %.2f", rule.AutoApproveLimit))
+		}
+		response.Cases = append(response.Cases, refundCase)
+	}
+
+	return response, nil
+}
+
+// ListCases returns refund cases with the given status, oldest first
+func (s *RefundPolicyService) ListCases(ctx context.Context, status types.RefundCaseStatus, limit, offset int) ([]*types.RefundCase, error) {
+	if status == "" {
+		status = types.RefundCasePendingReview
+	}
+	return s.caseRepo.ListCases(ctx, status, limit, offset)
+}
+
+// GetCase returns a refund case with its audit trail
+func (s *RefundPolicyService) GetCase(ctx context.Context, caseID string) (*types.RefundCase, []*types.RefundAuditEntry, error) {
+	refundCase, err := s.caseRepo.GetCase(ctx, caseID)
+	if err != nil {
+		return nil, nil, err
+	}
+	audit, err := s.caseRepo.GetAuditEntries(ctx, caseID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return refundCase, audit, nil
+}
+
+// ResolveCase records a reviewer decision on a queued refund, and pays it if
+// approved
+func (s *RefundPolicyService) ResolveCase(ctx context.Context, caseID string, approve bool, req *types.ResolveRefundCaseRequest) (*types.RefundCase, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	refundCase, err := s.caseRepo.GetCase(ctx, caseID)
+	if err != nil {
+		return nil, err
+	}
+	if refundCase.Status != types.RefundCasePendingReview {
+		return nil, fmt.Errorf("%w: %s is %s", types.ErrRefundCaseResolved, caseID, refundCase.Status)
+	}
+
+	refundCase.ReviewedBy = req.ReviewedBy
+	refundCase.Notes = req.Notes
+	if approve {
+		s.audit(ctx, refundCase, "approved", req.ReviewedBy, req.Notes)
+		s.payRefund(ctx, refundCase, req.ReviewedBy)
+		return refundCase, nil
+	}
+
+	now := time.Now()
+	refundCase.Status = types.RefundCaseRejected
+	refundCase.ResolvedAt = &now
+	if err := s.caseRepo.UpdateCase(ctx, refundCase); err != nil {
+		return nil, err
+	}
+	s.audit(ctx, refundCase, "rejected", req.ReviewedBy, req.Notes)
+	return refundCase, nil
+}
+
+// refundablePayment is a completed payment and how much of it can still be
+// refunded
+type refundablePayment struct {
+	payment    *types.Payment
+	refundable float64
+}
+
+// refundablePayments returns the trip's completed payments with an amount
+// left to refund, newest first
+func (s *RefundPolicyService) refundablePayments(ctx context.Context, tripID string) ([]refundablePayment, error) {
+	payments, err := s.paymentService.paymentRepo.GetPaymentsByTrip(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+
+	var refundable []refundablePayment
+	for _, payment := range payments {
+		if payment.Status != types.PaymentStatusCompleted || payment.TransactionType != types.TransactionTypePayment {
+			continue
+		}
+		refunded, err := s.paymentService.RefundedAmount(ctx, payment)
+		if err != nil {
+			return nil, err
+		}
+		remaining := currency.Round(payment.Amount-refunded, payment.Currency)
+		if remaining > 0 {
+			refundable = append(refundable, refundablePayment{payment: payment, refundable: remaining})
+		}
+	}
+
+	sort.Slice(refundable, func(i, j int) bool {
+		return refundable[i].payment.CreatedAt.After(refundable[j].payment.CreatedAt)
+	})
+	return refundable, nil
+}
+
+// refundAmounts decides how much of each payment to refund. With nothing to
+// refund it explains why.
+func (s *RefundPolicyService) refundAmounts(trigger *types.RefundTrigger, rule RefundPolicyRule, payments []refundablePayment) (map[string]float64, string) {
+	amounts := make(map[string]float64)
+
+	if trigger.Reason != types.RefundReasonOvercharge {
+		for _, refundable := range payments {
+			amount := currency.Round(refundable.refundable*rule.RefundRatio, refundable.payment.Currency)
+			if amount > 0 {
+				amounts[refundable.payment.ID] = amount
+			}
+		}
+		return amounts, "Refund policy refunds nothing"
+	}
+
+	var charged float64
+	for _, refundable := range payments {
+		charged += refundable.refundable
+	}
+	code := payments[0].payment.Currency
+	overcharge := currency.Round(charged-trigger.ExpectedAmount, code)
+	if overcharge < s.config.MinOvercharge || overcharge < trigger.ExpectedAmount*s.config.MinOverchargeRatio {
+		return nil, "Overcharge below refund threshold"
+	}
+
+	// The difference comes off the most recent charges first
+	for _, refundable := range payments {
+		if overcharge <= 0 {
+			break
+		}
+		amount := min(overcharge, refundable.refundable)
+		amounts[refundable.payment.ID] = amount
+		overcharge = currency.Round(overcharge-amount, code)
+	}
+	return amounts, ""
+}
+
+// payRefund refunds an approved case to the payment it belongs to. Fares paid
+// from the wallet are given back as ride credits.
+func (s *RefundPolicyService) payRefund(ctx context.Context, refundCase *types.RefundCase, actor string) {
+	var failure string
+	payment, err := s.paymentService.paymentRepo.GetPayment(ctx, refundCase.PaymentID)
+	switch {
+	case err != nil:
+		failure = err.Error()
+	case payment.PaymentMethod == types.PaymentMethodWalletBalance:
+		if s.wallets == nil {
+			failure = "wallet refunds are not configured"
+			break
+		}
+		credit, err := s.wallets.GrantCredit(ctx, &types.GrantCreditRequest{
+			UserID:      refundCase.UserID,
+			Amount:      refundCase.Amount,
+			Currency:    refundCase.Currency,
+			Type:        types.WalletTransactionRefundCredit,
+			Reference:   refundCase.ID,
+			Description: fmt.Sprintf("Refund: %s", refundCase.Reason),
+		})
+		if err != nil {
+			failure = err.Error()
+		} else if !credit.Success {
+			failure = credit.Message
+		}
+	default:
+		refund, err := s.paymentService.ProcessRefund(ctx, &types.RefundPaymentRequest{
+			PaymentID:   refundCase.PaymentID,
+			Amount:      refundCase.Amount,
+			Reason:      string(refundCase.Reason),
+			RequestedBy: actor,
+			Currency:    refundCase.Currency,
+		})
+		if err != nil {
+			failure = err.Error()
+		} else if !refund.Success {
+			failure = refund.Message
+		}
+	}
+
+	now := time.Now()
+	refundCase.ResolvedAt = &now
+	if failure != "" {
+		refundCase.Status = types.RefundCaseFailed
+		refundCase.FailureReason = failure
+	} else {
+		refundCase.Status = types.RefundCaseRefunded
+	}
+	if err := s.caseRepo.UpdateCase(ctx, refundCase); err != nil {
+		s.logger.WithFields(logger.Fields{
+			"case_id": refundCase.ID,
+			"status":  refundCase.Status,
+			"error":   err.Error(),
+		}).Error("Failed to update refund case")
+	}
+
+	if failure != "" {
+		s.audit(ctx, refundCase, "refund_failed", actor, failure)
+	} else {
+		s.audit(ctx, refundCase, "refunded", actor, fmt.Sprintf("%.2f %s", refundCase.Amount, refundCase.Currency))
+	}
+}
+
+// audit appends to the case's audit trail and the audit log
+func (s *RefundPolicyService) audit(ctx context.Context, refundCase *types.RefundCase, action, actor, details string) {
+	s.logger.LogAuditEvent(ctx, "refund_"+action, "refund_case", logger.Fields{
+		"case_id":    refundCase.ID,
+		"trip_id":    refundCase.TripID,
+		"payment_id": refundCase.PaymentID,
+		"reason":     refundCase.Reason,
+		"amount":     refundCase.Amount,
+		"currency":   refundCase.Currency,
+		"actor":      actor,
+		"details":    details,
+	})
+
+	entry := &types.RefundAuditEntry{
+		CaseID:  refundCase.ID,
+		Action:  action,
+		Actor:   actor,
+		Details: details,
+	}
+	if err := s.caseRepo.AddAuditEntry(ctx, entry); err != nil {
+		s.logger.WithFields(logger.Fields{
+			"case_id": refundCase.ID,
+			"action":  action,
+			"error":   err.Error(),
+		}).Warn("Failed to record refund audit entry")
+	}
+}
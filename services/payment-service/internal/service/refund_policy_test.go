@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rideshare-platform/services/payment-service/internal/repository"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/events"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRefundPolicyTestService(t *testing.T) (*RefundPolicyService, *WalletService, *repository.MockRefundCaseRepository) {
+	wallets, _, _ := newWalletTestService(t)
+	cases := repository.NewMockRefundCaseRepository()
+	policy := NewRefundPolicyService(wallets.paymentService, cases, DefaultRefundPolicyConfig(), *logger.NewLogger("error", "test"))
+	policy.SetWalletService(wallets)
+	return policy, wallets, cases
+}
+
+func chargeTrip(t *testing.T, wallets *WalletService, tripID string, amount float64) {
+	response, err := wallets.paymentService.ProcessPayment(context.Background(), &types.ProcessPaymentRequest{
+		TripID: tripID, UserID: "rider-1", DriverID: "driver-1", Amount: amount, PaymentMethodID: "card-1",
+	})
+	assert.NoError(t, err)
+	assert.True(t, response.Success, response.Errors)
+}
+
+func auditActions(t *testing.T, policy *RefundPolicyService, caseID string) []string {
+	_, audit, err := policy.GetCase(context.Background(), caseID)
+	assert.NoError(t, err)
+	var actions []string
+	for _, entry := range audit {
+		actions = append(actions, entry.Action)
+	}
+	return actions
+}
+
+func TestRefundPolicy_DriverNoShowIsRefundedOnce(t *testing.T) {
+	ctx := context.Background()
+	policy, wallets, _ := newRefundPolicyTestService(t)
+	chargeTrip(t, wallets, "trip-1", 20)
+
+	event := events.NewEvent(events.TripCancelledEvent, "trip-1", 1, map[string]interface{}{
+		"trip_id":      "trip-1",
+		"cancelled_by": "rider",
+		"reason":       "driver_no_show",
+	}, "trip-service")
+	assert.NoError(t, policy.HandleTripEvent(ctx, event))
+
+	refunded, err := policy.ListCases(ctx, types.RefundCaseRefunded, 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, refunded, 1)
+	assert.Equal(t, types.RefundReasonDriverNoShow, refunded[0].Reason)
+	assert.Equal(t, 20.0, refunded[0].Amount)
+	assert.True(t, refunded[0].AutoApproved)
+	assert.Equal(t, []string{"raised", "auto_approved", "refunded"}, auditActions(t, policy, refunded[0].ID))
+
+	// Redelivery does not refund again
+	response, err := policy.Trigger(ctx, &types.RefundTrigger{TripID: "trip-1", Reason: types.RefundReasonDriverNoShow})
+	assert.NoError(t, err)
+	assert.Equal(t, "Refund already raised", response.Message)
+	assert.Equal(t, refunded[0].ID, response.Cases[0].ID)
+
+	refunds, err := wallets.paymentService.refundRepo.GetRefundsByPayment(ctx, refunded[0].PaymentID)
+	assert.NoError(t, err)
+	assert.Len(t, refunds, 1)
+	assert.Equal(t, "driver_no_show", refunds[0].Reason)
+}
+
+func TestRefundPolicy_LargeRefundsWaitForReview(t *testing.T) {
+	ctx := context.Background()
+	policy, wallets, _ := newRefundPolicyTestService(t)
+	chargeTrip(t, wallets, "trip-1", 80)
+	chargeTrip(t, wallets, "trip-2", 15)
+
+	// Riders cancelling are not refunded by the policy
+	assert.NoError(t, policy.HandleTripEvent(ctx, events.NewEvent(events.TripCancelledEvent, "trip-2", 1, map[string]interface{}{"cancelled_by": "rider"}, "trip-service")))
+	queued, err := policy.ListCases(ctx, "", 10, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, queued)
+
+	assert.NoError(t, policy.HandleTripEvent(ctx, events.NewEvent(events.TripCancelledEvent, "trip-1", 1, map[string]interface{}{"cancelled_by": "platform"}, "trip-service")))
+	queued, err = policy.ListCases(ctx, "", 10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, queued, 1)
+	assert.Equal(t, types.RefundReasonPlatformCancellation, queued[0].Reason)
+	assert.False(t, queued[0].AutoApproved)
+
+	approved, err := policy.ResolveCase(ctx, queued[0].ID, true, &types.ResolveRefundCaseRequest{ReviewedBy: "ops-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, types.RefundCaseRefunded, approved.Status)
+	assert.Equal(t, "ops-1", approved.ReviewedBy)
+	assert.Equal(t, []string{"raised", "queued_for_review", "approved", "refunded"}, auditActions(t, policy, approved.ID))
+
+	_, err = policy.ResolveCase(ctx, queued[0].ID, false, &types.ResolveRefundCaseRequest{ReviewedBy: "ops-2"})
+	assert.Error(t, err)
+}
+
+func TestRefundPolicy_OverchargeRefundsTheDifference(t *testing.T) {
+	ctx := context.Background()
+	policy, wallets, _ := newRefundPolicyTestService(t)
+
+	credit, err := wallets.GrantCredit(ctx, &types.GrantCreditRequest{UserID: "rider-1", Amount: 10, Type: types.WalletTransactionPromotionCredit})
+	assert.NoError(t, err)
+	assert.True(t, credit.Success, credit.Errors)
+	paid, err := wallets.PayFare(ctx, &types.WalletPaymentRequest{TripID: "trip-1", UserID: "rider-1", DriverID: "driver-1", Amount: 30, PaymentMethodID: "card-1"})
+	assert.NoError(t, err)
+	assert.True(t, paid.Success, paid.Errors)
+
+	response, err := policy.Trigger(ctx, &types.RefundTrigger{TripID: "trip-1", Reason: types.RefundReasonOvercharge, ExpectedAmount: 29.5, Source: "reconciliation"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Overcharge below refund threshold", response.Message)
+	assert.Empty(t, response.Cases)
+
+	response, err = policy.Trigger(ctx, &types.RefundTrigger{TripID: "trip-1", Reason: types.RefundReasonOvercharge, ExpectedAmount: 18, Source: "reconciliation"})
+	assert.NoError(t, err)
+	assert.True(t, response.Success, response.Errors)
+	assert.Len(t, response.Cases, 2)
+
+	var total float64
+	for _, refundCase := range response.Cases {
+		assert.Equal(t, types.RefundCaseRefunded, refundCase.Status, refundCase.FailureReason)
+		total += refundCase.Amount
+	}
+	assert.Equal(t, 12.0, total)
+
+	// Part of the refund went back to the wallet as a ride credit
+	wallet, _, err := wallets.GetWallet(ctx, "rider-1", 10, 0)
+	assert.NoError(t, err)
+	assert.Greater(t, wallet.Credits, 0.0)
+}
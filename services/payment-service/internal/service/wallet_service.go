@@ -0,0 +1,393 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rideshare-platform/services/payment-service/internal/repository"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/currency"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// walletLockStripes is how many locks wallet operations are spread over
+const walletLockStripes = 64
+
+// WalletService manages rider wallets. Every movement is recorded in the
+// ledger as balanced entries between the rider's wallet accounts and a
+// platform account, so wallet balances are always the sum of their entries.
+type WalletService struct {
+	walletRepo     repository.WalletRepository
+	paymentService *PaymentService
+	logger         logger.Logger
+
+	// Operations on the same wallet run one at a time, so a fare split is
+	// still valid when the wallet is debited
+	locks [walletLockStripes]sync.Mutex
+}
+
+// NewWalletService creates a new wallet service. Top-ups and the card part of
+// fare payments are charged through paymentService.
+func NewWalletService(walletRepo repository.WalletRepository, paymentService *PaymentService, logger logger.Logger) *WalletService {
+	return &WalletService{
+		walletRepo:     walletRepo,
+		paymentService: paymentService,
+		logger:         logger,
+	}
+}
+
+func (s *WalletService) lock(userID string) func() {
+	hash := fnv.New32a()
+	hash.Write([]byte(userID))
+	mutex := &s.locks[hash.Sum32()%walletLockStripes]
+	mutex.Lock()
+	return mutex.Unlock
+}
+
+// GetWallet returns a rider's balance and a page of their transactions,
+// newest first
+func (s *WalletService) GetWallet(ctx context.Context, userID string, limit, offset int) (*types.WalletBalance, []*types.WalletTransaction, error) {
+	accounts, err := s.walletRepo.GetWalletAccounts(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	transactions, err := s.walletRepo.GetWalletTransactions(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.balance(userID, accounts), transactions, nil
+}
+
+// TopUp charges the rider's payment method and adds the amount to their
+// wallet
+func (s *WalletService) TopUp(ctx context.Context, req *types.TopUpWalletRequest) (*types.WalletResponse, error) {
+	unlock := s.lock(req.UserID)
+	defer unlock()
+
+	code, accounts, err := s.walletCurrency(ctx, req.UserID, req.Currency)
+	if err != nil {
+		return walletFailure("Invalid wallet currency", err), nil
+	}
+
+	method, err := s.paymentService.paymentMethodRepo.GetPaymentMethod(ctx, req.PaymentMethodID)
+	if err != nil || method.UserID != req.UserID {
+		return walletFailure("Payment method not found", err), nil
+	}
+
+	charge, err := s.paymentService.ProcessPayment(ctx, &types.ProcessPaymentRequest{
+		UserID:          req.UserID,
+		Amount:          req.Amount,
+		Currency:        code,
+		PaymentMethodID: req.PaymentMethodID,
+		Description:     "Wallet top-up",
+		Metadata:        map[string]interface{}{"wallet_top_up": true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !charge.Success {
+		return &types.WalletResponse{
+			Success:     false,
+			Message:     "Top-up payment failed",
+			Errors:      charge.Errors,
+			CardPayment: charge.Payment,
+		}, nil
+	}
+
+	amount := currency.ToMinor(req.Amount, code)
+	transaction := &types.WalletTransaction{
+		UserID:      req.UserID,
+		Type:        types.WalletTransactionTopUp,
+		Currency:    code,
+		Reference:   charge.Payment.ID,
+		Description: "Wallet top-up",
+		Entries: []*types.LedgerEntry{
+			{Account: types.WalletCashAccount(req.UserID), Amount: amount, Currency: code},
+			{Account: types.LedgerAccountCardFunding, Amount: -amount, Currency: code},
+		},
+	}
+	if err := s.record(ctx, transaction, accounts); err != nil {
+		s.logger.WithFields(logger.Fields{
+			"user_id":    req.UserID,
+			"payment_id": charge.Payment.ID,
+			"error":      err.Error(),
+		}).Error("Top-up was charged but could not be added to the wallet")
+		return &types.WalletResponse{
+			Success:     false,
+			Message:     "Failed to record top-up",
+			Errors:      []string{err.Error()},
+			CardPayment: charge.Payment,
+		}, nil
+	}
+
+	return &types.WalletResponse{
+		Success:     true,
+		Message:     "Wallet topped up",
+		Transaction: transaction,
+		Wallet:      s.balance(req.UserID, accounts),
+		CardPayment: charge.Payment,
+	}, nil
+}
+
+// GrantCredit gives a rider ride credits from a promotion, a refund or a
+// missed guarantee. Credits are spent before the rider's own balance.
+func (s *WalletService) GrantCredit(ctx context.Context, req *types.GrantCreditRequest) (*types.WalletResponse, error) {
+	var source string
+	switch req.Type {
+	case types.WalletTransactionPromotionCredit:
+		source = types.LedgerAccountPromotions
+	case types.WalletTransactionRefundCredit:
+		source = types.LedgerAccountRefunds
+	case types.WalletTransactionGuaranteeCredit:
+		source = types.LedgerAccountGuarantees
+	default:
+		return walletFailure("Invalid credit type", fmt.Errorf("credit type must be %s, %s or %s", types.WalletTransactionPromotionCredit, types.WalletTransactionRefundCredit, types.WalletTransactionGuaranteeCredit)), nil
+	}
+
+	unlock := s.lock(req.UserID)
+	defer unlock()
+
+	code, accounts, err := s.walletCurrency(ctx, req.UserID, req.Currency)
+	if err != nil {
+		return walletFailure("Invalid wallet currency", err), nil
+	}
+
+	amount := currency.ToMinor(req.Amount, code)
+	transaction := &types.WalletTransaction{
+		UserID:      req.UserID,
+		Type:        req.Type,
+		Currency:    code,
+		Reference:   req.Reference,
+		Description: req.Description,
+		Entries: []*types.LedgerEntry{
+			{Account: types.WalletCreditAccount(req.UserID), Amount: amount, Currency: code},
+			{Account: source, Amount: -amount, Currency: code},
+		},
+	}
+	if err := s.record(ctx, transaction, accounts); err != nil {
+		return walletFailure("Failed to record credit", err), nil
+	}
+
+	return &types.WalletResponse{
+		Success:     true,
+		Message:     "Ride credit granted",
+		Transaction: transaction,
+		Wallet:      s.balance(req.UserID, accounts),
+	}, nil
+}
+
+// PayFare pays a fare from the rider's wallet, spending ride credits before
+// cash. Whatever the wallet does not cover is charged to the payment method;
+// the card is charged first, so the wallet is only debited for fares that end
+// up fully paid.
+func (s *WalletService) PayFare(ctx context.Context, req *types.WalletPaymentRequest) (*types.WalletResponse, error) {
+	unlock := s.lock(req.UserID)
+	defer unlock()
+
+	// Every payment on a trip is in the currency it was quoted in
+	code, err := s.paymentService.paymentCurrency(ctx, &types.ProcessPaymentRequest{TripID: req.TripID, Currency: req.Currency})
+	if err != nil {
+		return walletFailure("Invalid payment currency", err), nil
+	}
+	code, accounts, err := s.walletCurrency(ctx, req.UserID, code)
+	if err != nil {
+		return walletFailure("Wallet currency does not match the fare", err), nil
+	}
+
+	fare := currency.ToMinor(req.Amount, code)
+	fromCredit := min(accounts.Credit, fare)
+	fromCash := min(accounts.Cash, fare-fromCredit)
+	remainder := fare - fromCredit - fromCash
+
+	response := &types.WalletResponse{
+		WalletAmount: currency.FromMinor(fromCredit+fromCash, code),
+		CardAmount:   currency.FromMinor(remainder, code),
+	}
+
+	if remainder > 0 {
+		if req.PaymentMethodID == "" {
+			return walletFailure("Wallet balance does not cover the fare", types.ErrInsufficientBalance), nil
+		}
+		charge, err := s.paymentService.ProcessPayment(ctx, &types.ProcessPaymentRequest{
+			TripID:          req.TripID,
+			UserID:          req.UserID,
+			DriverID:        req.DriverID,
+			Amount:          response.CardAmount,
+			Currency:        code,
+			PaymentMethodID: req.PaymentMethodID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		response.CardPayment = charge.Payment
+		if !charge.Success {
+			response.Message = "Card payment failed"
+			response.Errors = charge.Errors
+			return response, nil
+		}
+	}
+
+	if fromCredit+fromCash > 0 {
+		transaction := &types.WalletTransaction{
+			UserID:      req.UserID,
+			Type:        types.WalletTransactionFarePayment,
+			Currency:    code,
+			Reference:   req.TripID,
+			Description: "Fare payment",
+		}
+		if fromCredit > 0 {
+			transaction.Entries = append(transaction.Entries, &types.LedgerEntry{Account: types.WalletCreditAccount(req.UserID), Amount: -fromCredit, Currency: code})
+		}
+		if fromCash > 0 {
+			transaction.Entries = append(transaction.Entries, &types.LedgerEntry{Account: types.WalletCashAccount(req.UserID), Amount: -fromCash, Currency: code})
+		}
+		transaction.Entries = append(transaction.Entries, &types.LedgerEntry{Account: types.LedgerAccountFares, Amount: fromCredit + fromCash, Currency: code})
+
+		if err := s.record(ctx, transaction, accounts); err != nil {
+			s.refundCardPayment(ctx, response.CardPayment)
+			response.Message = "Failed to debit wallet"
+			response.Errors = []string{err.Error()}
+			return response, nil
+		}
+		response.Transaction = transaction
+		s.recordWalletPayment(ctx, req, transaction, response.WalletAmount)
+	}
+
+	response.Success = true
+	response.Message = "Fare paid"
+	response.Wallet = s.balance(req.UserID, accounts)
+	return response, nil
+}
+
+// walletCurrency resolves the currency of a wallet operation and loads the
+// wallet. A wallet holds a single currency, set by its first transaction.
+func (s *WalletService) walletCurrency(ctx context.Context, userID, code string) (string, *types.WalletAccounts, error) {
+	accounts, err := s.walletRepo.GetWalletAccounts(ctx, userID)
+	if err != nil {
+		return "", nil, err
+	}
+	if code == "" {
+		code = accounts.Currency
+	}
+	if code == "" {
+		code = s.paymentService.defaultCurrency
+	}
+	normalized, err := currency.Normalize(code)
+	if err != nil {
+		return "", nil, err
+	}
+	if accounts.Currency != "" {
+		if err := currency.Match(accounts.Currency, normalized); err != nil {
+			return "", nil, fmt.Errorf("wallet is held in %s: %w", accounts.Currency, err)
+		}
+	}
+	return normalized, accounts, nil
+}
+
+// record stores the transaction and applies it to the loaded accounts
+func (s *WalletService) record(ctx context.Context, transaction *types.WalletTransaction, accounts *types.WalletAccounts) error {
+	var cash, credit int64
+	for _, entry := range transaction.Entries {
+		switch entry.Account {
+		case types.WalletCashAccount(transaction.UserID):
+			cash += entry.Amount
+		case types.WalletCreditAccount(transaction.UserID):
+			credit += entry.Amount
+		}
+	}
+	transaction.Amount = currency.FromMinor(cash+credit, transaction.Currency)
+
+	if err := s.walletRepo.RecordTransaction(ctx, transaction); err != nil {
+		return err
+	}
+	if s.paymentService.ledger != nil {
+		s.paymentService.ledger.RecordWalletTransaction(ctx, transaction)
+	}
+	accounts.Currency = transaction.Currency
+	accounts.Cash += cash
+	accounts.Credit += credit
+	return nil
+}
+
+// recordWalletPayment records the wallet part of a fare as a completed
+// payment, so the trip's payments add up to the fare
+func (s *WalletService) recordWalletPayment(ctx context.Context, req *types.WalletPaymentRequest, transaction *types.WalletTransaction, amount float64) {
+	now := time.Now()
+	payment := &types.Payment{
+		ID:                uuid.New().String(),
+		TripID:            req.TripID,
+		UserID:            req.UserID,
+		DriverID:          req.DriverID,
+		Amount:            amount,
+		Currency:          transaction.Currency,
+		PaymentMethod:     types.PaymentMethodWalletBalance,
+		Status:            types.PaymentStatusCompleted,
+		TransactionType:   types.TransactionTypePayment,
+		ProcessorResponse: "Wallet transaction " + transaction.ID,
+		Metadata:          map[string]interface{}{"wallet_transaction_id": transaction.ID},
+		ProcessedAt:       &now,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+	if err := s.paymentService.paymentRepo.CreatePayment(ctx, payment); err != nil {
+		s.logger.WithFields(logger.Fields{
+			"trip_id":               req.TripID,
+			"wallet_transaction_id": transaction.ID,
+			"error":                 err.Error(),
+		}).Warn("Failed to record wallet payment")
+		return
+	}
+	s.paymentService.recordPaymentInLedger(ctx, payment)
+}
+
+// refundCardPayment gives back the card part of a fare whose wallet part
+// could not be debited
+func (s *WalletService) refundCardPayment(ctx context.Context, payment *types.Payment) {
+	if payment == nil {
+		return
+	}
+	refund, err := s.paymentService.ProcessRefund(ctx, &types.RefundPaymentRequest{
+		PaymentID:   payment.ID,
+		Amount:      payment.Amount,
+		Reason:      "Wallet could not be debited for the rest of the fare",
+		RequestedBy: "wallet",
+	})
+	if err == nil && !refund.Success {
+		err = errors.New(refund.Message)
+	}
+	if err != nil {
+		s.logger.WithFields(logger.Fields{
+			"payment_id": payment.ID,
+			"error":      err.Error(),
+		}).Error("Failed to refund card part of fare")
+	}
+}
+
+func (s *WalletService) balance(userID string, accounts *types.WalletAccounts) *types.WalletBalance {
+	code := accounts.Currency
+	if code == "" {
+		code = s.paymentService.defaultCurrency
+	}
+	total := accounts.Cash + accounts.Credit
+	return &types.WalletBalance{
+		UserID:    userID,
+		Currency:  code,
+		Cash:      currency.FromMinor(accounts.Cash, code),
+		Credits:   currency.FromMinor(accounts.Credit, code),
+		Total:     currency.FromMinor(total, code),
+		Formatted: currency.FormatAmount(currency.FromMinor(total, code), code),
+	}
+}
+
+func walletFailure(message string, err error) *types.WalletResponse {
+	response := &types.WalletResponse{Success: false, Message: message}
+	if err != nil {
+		response.Errors = []string{err.Error()}
+	}
+	return response
+}
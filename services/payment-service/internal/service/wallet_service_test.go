@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rideshare-platform/services/payment-service/internal/repository"
+	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+// approvingCardProcessor approves every charge and refund
+type approvingCardProcessor struct {
+	MockCardProcessor
+}
+
+func (p *approvingCardProcessor) ProcessPayment(ctx context.Context, payment *types.Payment) (*ProcessorResponse, error) {
+	return &ProcessorResponse{Success: true, TransactionID: uuid.New().String(), ResponseCode: "APPROVED"}, nil
+}
+
+func (p *approvingCardProcessor) ProcessRefund(ctx context.Context, payment *types.Payment, amount float64) (*ProcessorResponse, error) {
+	return &ProcessorResponse{Success: true, TransactionID: uuid.New().String(), ResponseCode: "REFUND_APPROVED"}, nil
+}
+
+func newWalletTestService(t *testing.T) (*WalletService, *repository.MockPaymentRepository, *repository.MockWalletRepository) {
+	paymentRepo := repository.NewMockPaymentRepository()
+	methods := repository.NewMockPaymentMethodRepository()
+	payments := NewPaymentService(paymentRepo, methods, repository.NewMockRefundRepository(), nil, *logger.NewLogger("error", "test"))
+	payments.processors[types.PaymentMethodCreditCard] = &approvingCardProcessor{}
+	assert.NoError(t, methods.CreatePaymentMethod(context.Background(), &types.PaymentMethodDetails{
+		ID: "card-1", UserID: "rider-1", Type: types.PaymentMethodCreditCard, CardToken: "tok_1",
+	}))
+
+	walletRepo := repository.NewMockWalletRepository()
+	return NewWalletService(walletRepo, payments, *logger.NewLogger("error", "test")), paymentRepo, walletRepo
+}
+
+func assertBalanced(t *testing.T, transactions []*types.WalletTransaction) {
+	for _, transaction := range transactions {
+		assert.True(t, transaction.Balanced(), "transaction %s does not balance", transaction.ID)
+	}
+}
+
+func TestWalletService_PaysFareFromCreditsThenCashThenCard(t *testing.T) {
+	ctx := context.Background()
+	wallets, paymentRepo, _ := newWalletTestService(t)
+
+	topUp, err := wallets.TopUp(ctx, &types.TopUpWalletRequest{UserID: "rider-1", Amount: 10, PaymentMethodID: "card-1"})
+	assert.NoError(t, err)
+	assert.True(t, topUp.Success, topUp.Errors)
+	credit, err := wallets.GrantCredit(ctx, &types.GrantCreditRequest{UserID: "rider-1", Amount: 5, Type: types.WalletTransactionPromotionCredit, Reference: "WELCOME5"})
+	assert.NoError(t, err)
+	assert.True(t, credit.Success, credit.Errors)
+	assert.Equal(t, 15.0, credit.Wallet.Total)
+
+	// Covered by credits and part of the cash
+	paid, err := wallets.PayFare(ctx, &types.WalletPaymentRequest{TripID: "trip-1", UserID: "rider-1", DriverID: "driver-1", Amount: 8.5})
+	assert.NoError(t, err)
+	assert.True(t, paid.Success, paid.Errors)
+	assert.Equal(t, 8.5, paid.WalletAmount)
+	assert.Zero(t, paid.CardAmount)
+	assert.Nil(t, paid.CardPayment)
+	assert.Equal(t, -8.5, paid.Transaction.Amount)
+	assert.Zero(t, paid.Wallet.Credits)
+	assert.Equal(t, 6.5, paid.Wallet.Cash)
+
+	// The rest of the wallet, then the card
+	paid, err = wallets.PayFare(ctx, &types.WalletPaymentRequest{TripID: "trip-2", UserID: "rider-1", DriverID: "driver-1", Amount: 20, PaymentMethodID: "card-1"})
+	assert.NoError(t, err)
+	assert.True(t, paid.Success, paid.Errors)
+	assert.Equal(t, 6.5, paid.WalletAmount)
+	assert.Equal(t, 13.5, paid.CardAmount)
+	assert.Equal(t, 13.5, paid.CardPayment.Amount)
+	assert.Zero(t, paid.Wallet.Total)
+
+	tripPayments, err := paymentRepo.GetPaymentsByTrip(ctx, "trip-2")
+	assert.NoError(t, err)
+	var total float64
+	for _, payment := range tripPayments {
+		assert.Equal(t, types.PaymentStatusCompleted, payment.Status)
+		total += payment.Amount
+	}
+	assert.Len(t, tripPayments, 2)
+	assert.Equal(t, 20.0, total)
+
+	wallet, history, err := wallets.GetWallet(ctx, "rider-1", 10, 0)
+	assert.NoError(t, err)
+	assert.Zero(t, wallet.Total)
+	assert.Len(t, history, 4)
+	assert.Equal(t, types.WalletTransactionFarePayment, history[0].Type)
+	assert.Equal(t, types.WalletTransactionTopUp, history[3].Type)
+	assertBalanced(t, history)
+}
+
+func TestWalletService_RejectsUncoveredFaresAndOtherCurrencies(t *testing.T) {
+	ctx := context.Background()
+	wallets, _, walletRepo := newWalletTestService(t)
+
+	credit, err := wallets.GrantCredit(ctx, &types.GrantCreditRequest{UserID: "rider-1", Amount: 3, Currency: "EUR", Type: types.WalletTransactionRefundCredit})
+	assert.NoError(t, err)
+	assert.True(t, credit.Success, credit.Errors)
+
+	response, err := wallets.PayFare(ctx, &types.WalletPaymentRequest{TripID: "trip-1", UserID: "rider-1", Amount: 4, Currency: "EUR"})
+	assert.NoError(t, err)
+	assert.False(t, response.Success)
+	assert.Equal(t, "Wallet balance does not cover the fare", response.Message)
+
+	response, err = wallets.PayFare(ctx, &types.WalletPaymentRequest{TripID: "trip-1", UserID: "rider-1", Amount: 2, Currency: "USD"})
+	assert.NoError(t, err)
+	assert.False(t, response.Success)
+	assert.Equal(t, "Wallet currency does not match the fare", response.Message)
+
+	response, err = wallets.GrantCredit(ctx, &types.GrantCreditRequest{UserID: "rider-1", Amount: 1, Type: types.WalletTransactionFarePayment})
+	assert.NoError(t, err)
+	assert.False(t, response.Success)
+
+	// The ledger itself refuses to overdraw a wallet or record unbalanced entries
+	overdraw := &types.WalletTransaction{UserID: "rider-1", Type: types.WalletTransactionFarePayment, Currency: "EUR", Entries: []*types.LedgerEntry{
+		{Account: types.WalletCreditAccount("rider-1"), Amount: -301, Currency: "EUR"},
+		{Account: types.LedgerAccountFares, Amount: 301, Currency: "EUR"},
+	}}
+	assert.ErrorIs(t, walletRepo.RecordTransaction(ctx, overdraw), types.ErrInsufficientBalance)
+	unbalanced := &types.WalletTransaction{UserID: "rider-1", Type: types.WalletTransactionPromotionCredit, Currency: "EUR", Entries: []*types.LedgerEntry{
+		{Account: types.WalletCreditAccount("rider-1"), Amount: 100, Currency: "EUR"},
+		{Account: types.LedgerAccountPromotions, Amount: -99, Currency: "EUR"},
+	}}
+	assert.ErrorIs(t, walletRepo.RecordTransaction(ctx, unbalanced), types.ErrUnbalancedTransaction)
+
+	wallet, history, err := wallets.GetWallet(ctx, "rider-1", 10, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "EUR", wallet.Currency)
+	assert.Equal(t, 3.0, wallet.Credits)
+	assert.Len(t, history, 1)
+
+	// Late pickups are credited from their own platform account
+	response, err = wallets.GrantCredit(ctx, &types.GrantCreditRequest{UserID: "rider-1", Amount: 2, Currency: "EUR", Type: types.WalletTransactionGuaranteeCredit, Reference: "pickup_guarantee:trip-2"})
+	assert.NoError(t, err)
+	assert.True(t, response.Success, response.Errors)
+	assert.Equal(t, 5.0, response.Wallet.Total)
+	assert.Equal(t, types.LedgerAccountGuarantees, response.Transaction.Entries[1].Account)
+}
@@ -0,0 +1,110 @@
+package types
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrChargebackNotFound is returned when a chargeback case does not exist
+	ErrChargebackNotFound = errors.New("chargeback not found")
+	// ErrChargebackClosed is returned for evidence or outcomes on a
+	// chargeback that has already been decided
+	ErrChargebackClosed = errors.New("chargeback is already closed")
+	// ErrInvalidChargeback is returned for provider events and admin requests
+	// that cannot be applied to a chargeback
+	ErrInvalidChargeback = errors.New("invalid chargeback")
+)
+
+// ChargebackStatus is where a chargeback is in the provider's dispute process
+type ChargebackStatus string
+
+const (
+	ChargebackStatusNeedsResponse ChargebackStatus = "needs_response" // evidence is due
+	ChargebackStatusUnderReview   ChargebackStatus = "under_review"   // evidence was submitted, the issuer is deciding
+	ChargebackStatusWon           ChargebackStatus = "won"            // the charge stands
+	ChargebackStatusLost          ChargebackStatus = "lost"           // the rider's bank took the money back
+	ChargebackStatusAccepted      ChargebackStatus = "accepted"       // the platform conceded without contesting
+)
+
+// Open reports whether the chargeback is still being decided
+func (s ChargebackStatus) Open() bool {
+	return s == ChargebackStatusNeedsResponse || s == ChargebackStatusUnderReview
+}
+
+// Chargeback is a payment disputed by the rider with their bank and reported
+// by the payment provider. The driver's fare for the trip is frozen until the
+// chargeback is decided.
+type Chargeback struct {
+	ID                string           `json:"id" db:"id"`
+	ProviderDisputeID string           `json:"provider_dispute_id" db:"provider_dispute_id"`
+	PaymentID         string           `json:"payment_id" db:"payment_id"`
+	TripID            string           `json:"trip_id" db:"trip_id"`
+	UserID            string           `json:"user_id" db:"user_id"`
+	DriverID          string           `json:"driver_id" db:"driver_id"`
+	Amount            float64          `json:"amount" db:"amount"`
+	Currency          string           `json:"currency" db:"currency"`
+	Reason            string           `json:"reason" db:"reason"` // the provider's reason code, e.g. fraudulent or product_not_received
+	Status            ChargebackStatus `json:"status" db:"status"`
+	EvidenceDueBy     *time.Time       `json:"evidence_due_by,omitempty" db:"evidence_due_by"`
+	Notes             string           `json:"notes,omitempty" db:"notes"`
+	ResolvedBy        string           `json:"resolved_by,omitempty" db:"resolved_by"` // the admin, or "provider"
+	CreatedAt         time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time        `json:"updated_at" db:"updated_at"`
+	ResolvedAt        *time.Time       `json:"resolved_at,omitempty" db:"resolved_at"`
+
+	Evidence []*ChargebackEvidence `json:"evidence,omitempty"`
+}
+
+// ChargebackEvidence is a piece of evidence submitted against a chargeback,
+// such as the trip's receipt or route
+type ChargebackEvidence struct {
+	ID           string    `json:"id" db:"id"`
+	ChargebackID string    `json:"chargeback_id" db:"chargeback_id"`
+	Type         string    `json:"type" db:"type"` // receipt, trip_route, rider_communication or other
+	Description  string    `json:"description" db:"description"`
+	SubmittedBy  string    `json:"submitted_by" db:"submitted_by"`
+	SubmittedAt  time.Time `json:"submitted_at" db:"submitted_at"`
+}
+
+// Provider dispute event types
+const (
+	ProviderDisputeCreated = "dispute.created"
+	ProviderDisputeUpdated = "dispute.updated"
+	ProviderDisputeClosed  = "dispute.closed"
+)
+
+// ProviderDisputeEvent is a dispute webhook sent by the payment provider
+type ProviderDisputeEvent struct {
+	ID      string          `json:"id"`
+	Type    string          `json:"type"`
+	Dispute ProviderDispute `json:"dispute"`
+}
+
+// ProviderDispute is the provider's view of a dispute. PaymentID is the
+// reference the charge was made with, which is the payment's ID. Status uses
+// the same values as ChargebackStatus; closed disputes are won or lost.
+type ProviderDispute struct {
+	ID            string           `json:"id"`
+	PaymentID     string           `json:"payment_id"`
+	Amount        float64          `json:"amount"`
+	Currency      string           `json:"currency"`
+	Reason        string           `json:"reason"`
+	Status        ChargebackStatus `json:"status"`
+	EvidenceDueBy *time.Time       `json:"evidence_due_by,omitempty"`
+}
+
+// SubmitEvidenceRequest adds evidence to a chargeback
+type SubmitEvidenceRequest struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	SubmittedBy string `json:"submitted_by"`
+}
+
+// RecordOutcomeRequest records how a chargeback was decided. Outcome is won,
+// lost or accepted.
+type RecordOutcomeRequest struct {
+	Outcome    ChargebackStatus `json:"outcome"`
+	Notes      string           `json:"notes"`
+	ResolvedBy string           `json:"resolved_by"`
+}
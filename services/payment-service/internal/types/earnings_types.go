@@ -0,0 +1,103 @@
+package types
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrInvalidStatementRange is returned for statements whose dates or
+	// timezone cannot be used
+	ErrInvalidStatementRange = errors.New("invalid statement range")
+	// ErrInvalidEarning is returned for tips and incentives that cannot be recorded
+	ErrInvalidEarning = errors.New("invalid driver earning")
+)
+
+// EarningType is the kind of money a driver earns on top of their fares
+type EarningType string
+
+const (
+	EarningTypeTip       EarningType = "tip"
+	EarningTypeIncentive EarningType = "incentive"
+)
+
+// DriverEarning is a tip or incentive paid to a driver. Tips and incentives
+// go to the driver in full; commission is only taken on fares.
+type DriverEarning struct {
+	ID          string      `json:"id" db:"id"`
+	DriverID    string      `json:"driver_id" db:"driver_id"`
+	TripID      string      `json:"trip_id,omitempty" db:"trip_id"`
+	Type        EarningType `json:"type" db:"type"`
+	Amount      float64     `json:"amount" db:"amount"`
+	Currency    string      `json:"currency" db:"currency"`
+	Description string      `json:"description,omitempty" db:"description"`
+	EarnedAt    time.Time   `json:"earned_at" db:"earned_at"`
+	CreatedAt   time.Time   `json:"created_at" db:"created_at"`
+}
+
+// RecordEarningRequest records a tip or incentive for a driver. It is
+// earned when it is recorded, so it cannot land in a statement already
+// closed.
+type RecordEarningRequest struct {
+	DriverID    string      `json:"-"`
+	TripID      string      `json:"trip_id"`
+	Type        EarningType `json:"type" validate:"required"`
+	Amount      float64     `json:"amount" validate:"required,gt=0"`
+	Currency    string      `json:"currency"`
+	Description string      `json:"description"`
+}
+
+// DriverShift is a stretch of time a driver was online, from going online
+// to going offline
+type DriverShift struct {
+	StartedAt time.Time `json:"started_at"`
+	EndedAt   time.Time `json:"ended_at"`
+}
+
+// StatementRequest asks for a driver's statement over the days From to To,
+// both included, in Timezone
+type StatementRequest struct {
+	DriverID string
+	From     string // YYYY-MM-DD
+	To       string // YYYY-MM-DD
+	Timezone string // IANA name, UTC when empty
+}
+
+// CurrencyEarnings is what a driver earned in one currency. Net is what the
+// driver is paid: gross fares less commission, plus tips and incentives.
+// Frozen is fares held back from Net while their payments are under
+// chargeback.
+type CurrencyEarnings struct {
+	Currency   string  `json:"currency"`
+	GrossFares float64 `json:"gross_fares"`
+	Commission float64 `json:"commission"`
+	Tips       float64 `json:"tips"`
+	Incentives float64 `json:"incentives"`
+	Net        float64 `json:"net"`
+	Frozen     float64 `json:"frozen"`
+}
+
+// DailyEarnings is a driver's work on one day of their statement
+type DailyEarnings struct {
+	Date           string              `json:"date"`
+	TripsCompleted int                 `json:"trips_completed"`
+	OnlineSeconds  int64               `json:"online_seconds"`
+	OnlineHours    float64             `json:"online_hours"`
+	Earnings       []*CurrencyEarnings `json:"earnings"`
+}
+
+// EarningsStatement is a driver's daily statement. Days start at midnight in
+// the statement's timezone. Online hours are only reported when driver shift
+// history is available.
+type EarningsStatement struct {
+	DriverID             string              `json:"driver_id"`
+	Timezone             string              `json:"timezone"`
+	From                 string              `json:"from"`
+	To                   string              `json:"to"`
+	CommissionRate       float64             `json:"commission_rate"`
+	OnlineHoursAvailable bool                `json:"online_hours_available"`
+	Days                 []*DailyEarnings    `json:"days"`
+	TripsCompleted       int                 `json:"trips_completed"`
+	OnlineHours          float64             `json:"online_hours"`
+	Totals               []*CurrencyEarnings `json:"totals"`
+}
@@ -0,0 +1,79 @@
+package types
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrFraudRuleNotFound is returned when a fraud rule does not exist
+	ErrFraudRuleNotFound = errors.New("fraud rule not found")
+	// ErrInvalidFraudRule is returned for fraud rules that fail validation
+	ErrInvalidFraudRule = errors.New("invalid fraud rule")
+	// ErrFraudReviewNotFound is returned when a fraud review does not exist
+	ErrFraudReviewNotFound = errors.New("fraud review not found")
+	// ErrFraudReviewResolved is returned for decisions on a fraud review that
+	// is no longer pending
+	ErrFraudReviewResolved = errors.New("fraud review is already resolved")
+)
+
+// FraudRuleAction defines what happens when a fraud rule matches
+type FraudRuleAction string
+
+const (
+	FraudRuleActionFlag   FraudRuleAction = "flag"
+	FraudRuleActionReview FraudRuleAction = "review"
+	FraudRuleActionBlock  FraudRuleAction = "block"
+)
+
+// FraudReviewStatus represents the state of a flagged payment in the review queue
+type FraudReviewStatus string
+
+const (
+	FraudReviewPending  FraudReviewStatus = "pending"
+	FraudReviewApproved FraudReviewStatus = "approved"
+	FraudReviewRejected FraudReviewStatus = "rejected"
+)
+
+// FraudRule is a configurable fraud rule expressed in the rule DSL.
+//
+// Expressions compare transaction features against literals, e.g.
+// `velocity_1h >= 5 && amount > 200` or `payment_method == "digital_wallet"`.
+type FraudRule struct {
+	ID          string          `json:"id" db:"id"`
+	Name        string          `json:"name" db:"name"`
+	Description string          `json:"description" db:"description"`
+	Expression  string          `json:"expression" db:"expression"`
+	Score       float64         `json:"score" db:"score"`
+	Action      FraudRuleAction `json:"action" db:"action"`
+	Priority    int             `json:"priority" db:"priority"`
+	Enabled     bool            `json:"enabled" db:"enabled"`
+	CreatedAt   time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// FraudReview is an entry in the manual review queue for a flagged payment
+type FraudReview struct {
+	ID           string             `json:"id" db:"id"`
+	PaymentID    string             `json:"payment_id" db:"payment_id"`
+	UserID       string             `json:"user_id" db:"user_id"`
+	Amount       float64            `json:"amount" db:"amount"`
+	Currency     string             `json:"currency" db:"currency"`
+	RiskLevel    FraudRiskLevel     `json:"risk_level" db:"risk_level"`
+	RiskScore    float64            `json:"risk_score" db:"risk_score"`
+	Reasons      []string           `json:"reasons" db:"reasons"`
+	MatchedRules []string           `json:"matched_rules" db:"matched_rules"`
+	Status       FraudReviewStatus  `json:"status" db:"status"`
+	ReviewedBy   string             `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	Notes        string             `json:"notes,omitempty" db:"notes"`
+	Scores       map[string]float64 `json:"scores" db:"scores"`
+	ReviewedAt   *time.Time         `json:"reviewed_at,omitempty" db:"reviewed_at"`
+	CreatedAt    time.Time          `json:"created_at" db:"created_at"`
+}
+
+// ResolveFraudReviewRequest represents a reviewer decision on a flagged payment.
+// ReviewedBy is the operator the decision was made by, taken from their token.
+type ResolveFraudReviewRequest struct {
+	ReviewedBy string `json:"-"`
+	Notes      string `json:"notes"`
+}
@@ -0,0 +1,87 @@
+package types
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrHoldNotFound is returned when a payment hold does not exist
+	ErrHoldNotFound = errors.New("payment hold not found")
+	// ErrHoldsUnsupported is returned by processors for payment methods that
+	// cannot be authorized ahead of a charge, such as cash
+	ErrHoldsUnsupported = errors.New("payment method does not support holds")
+)
+
+// HoldStatus is where a payment hold is in its lifecycle
+type HoldStatus string
+
+const (
+	HoldStatusActive   HoldStatus = "active"   // authorized and waiting for the fare
+	HoldStatusCaptured HoldStatus = "captured" // the fare was charged against it
+	HoldStatusReleased HoldStatus = "released" // voided without a charge
+	HoldStatusExpired  HoldStatus = "expired"  // lapsed before it was captured or renewed
+	HoldStatusFailed   HoldStatus = "failed"   // the authorization was declined
+)
+
+// PaymentHold is an authorization placed on a rider's payment method when
+// their trip starts. The estimated fare plus a buffer is held, the actual fare
+// is captured when the trip completes and the rest is released.
+type PaymentHold struct {
+	ID              string        `json:"id" db:"id"`
+	TripID          string        `json:"trip_id" db:"trip_id"`
+	UserID          string        `json:"user_id" db:"user_id"`
+	DriverID        string        `json:"driver_id" db:"driver_id"`
+	PaymentMethodID string        `json:"payment_method_id" db:"payment_method_id"`
+	PaymentMethod   PaymentMethod `json:"payment_method" db:"payment_method"`
+	EstimatedFare   float64       `json:"estimated_fare" db:"estimated_fare"`
+	Amount          float64       `json:"amount" db:"amount"` // held, the estimated fare plus the buffer
+	Currency        string        `json:"currency" db:"currency"`
+	Status          HoldStatus    `json:"status" db:"status"`
+	// AuthorizationID is the processor's authorization currently backing the
+	// hold. Renewing a hold replaces it.
+	AuthorizationID string     `json:"authorization_id,omitempty" db:"authorization_id"`
+	Renewals        int        `json:"renewals" db:"renewals"`
+	CapturedAmount  float64    `json:"captured_amount" db:"captured_amount"`
+	ReleasedAmount  float64    `json:"released_amount" db:"released_amount"`
+	PaymentID       string     `json:"payment_id,omitempty" db:"payment_id"` // the charge recorded at capture
+	FailureReason   string     `json:"failure_reason,omitempty" db:"failure_reason"`
+	ExpiresAt       time.Time  `json:"expires_at" db:"expires_at"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
+	ClosedAt        *time.Time `json:"closed_at,omitempty" db:"closed_at"`
+}
+
+// PlaceHoldRequest asks for a hold on a rider's payment method at trip start.
+// The rider's default payment method is held when PaymentMethodID is empty.
+type PlaceHoldRequest struct {
+	TripID          string  `json:"trip_id"`
+	UserID          string  `json:"user_id"`
+	DriverID        string  `json:"driver_id"`
+	PaymentMethodID string  `json:"payment_method_id"`
+	EstimatedFare   float64 `json:"estimated_fare"`
+	Currency        string  `json:"currency"`
+}
+
+// CaptureHoldRequest charges a completed trip's actual fare. Trips without a
+// usable hold are charged directly, to PaymentMethodID or the rider's default
+// payment method.
+type CaptureHoldRequest struct {
+	TripID          string  `json:"trip_id"`
+	UserID          string  `json:"user_id"`
+	DriverID        string  `json:"driver_id"`
+	PaymentMethodID string  `json:"payment_method_id"`
+	Amount          float64 `json:"amount"`
+	Currency        string  `json:"currency"`
+}
+
+// HoldResponse is the outcome of an operation on a trip's hold. Payments are
+// the charges it made: the capture and, when the fare exceeded the hold, the
+// charge for the difference.
+type HoldResponse struct {
+	Hold     *PaymentHold `json:"hold,omitempty"`
+	Payments []*Payment   `json:"payments,omitempty"`
+	Success  bool         `json:"success"`
+	Message  string       `json:"message"`
+	Errors   []string     `json:"errors,omitempty"`
+}
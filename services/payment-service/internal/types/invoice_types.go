@@ -0,0 +1,74 @@
+package types
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrInvalidInvoice is returned for invoice requests that cannot be
+	// generated, such as ones for months that have not ended
+	ErrInvalidInvoice = errors.New("invalid driver invoice")
+	// ErrInvoiceNotFound is returned when a driver invoice does not exist
+	ErrInvoiceNotFound = errors.New("driver invoice not found")
+	// ErrInvoiceExists is returned when a driver already has an invoice for
+	// the month; corrections regenerate it instead
+	ErrInvoiceExists = errors.New("driver invoice already issued for this month")
+)
+
+// InvoiceStatus is whether an invoice is the one in force for its month
+type InvoiceStatus string
+
+const (
+	InvoiceStatusIssued     InvoiceStatus = "issued"
+	InvoiceStatusSuperseded InvoiceStatus = "superseded"
+)
+
+// DriverInvoice is a driver's monthly tax statement: what they earned in
+// fares, tips and incentives and the commission the platform charged them,
+// per currency. Corrections regenerate it as a new revision, which
+// supersedes the previous one; superseded invoices are kept.
+type DriverInvoice struct {
+	ID             string              `json:"id" db:"id"`
+	Number         string              `json:"number" db:"number"`
+	DriverID       string              `json:"driver_id" db:"driver_id"`
+	Period         string              `json:"period" db:"period"` // YYYY-MM
+	Timezone       string              `json:"timezone" db:"timezone"`
+	Locale         string              `json:"locale" db:"locale"`
+	Revision       int                 `json:"revision" db:"revision"`
+	Status         InvoiceStatus       `json:"status" db:"status"`
+	CommissionRate float64             `json:"commission_rate" db:"commission_rate"`
+	TripsCompleted int                 `json:"trips_completed" db:"trips_completed"`
+	Totals         []*CurrencyEarnings `json:"totals" db:"totals"`
+	// CorrectionReason says why a revision replaced the one before it
+	CorrectionReason string     `json:"correction_reason,omitempty" db:"correction_reason"`
+	SupersedesID     string     `json:"supersedes_id,omitempty" db:"supersedes_id"`
+	GeneratedBy      string     `json:"generated_by" db:"generated_by"`
+	GeneratedAt      time.Time  `json:"generated_at" db:"generated_at"`
+	SupersededAt     *time.Time `json:"superseded_at,omitempty" db:"superseded_at"`
+	// PDF is the rendered invoice, only loaded when the invoice is fetched
+	// on its own
+	PDF []byte `json:"-" db:"pdf"`
+}
+
+// GenerateInvoiceRequest generates a driver's invoice for a month that has
+// ended. Locale defaults to the service's and Timezone to UTC.
+type GenerateInvoiceRequest struct {
+	DriverID    string `json:"-"`
+	Period      string `json:"period" validate:"required"` // YYYY-MM
+	Locale      string `json:"locale"`
+	Timezone    string `json:"timezone"`
+	GeneratedBy string `json:"generated_by"`
+}
+
+// RegenerateInvoiceRequest issues a new revision of a driver's invoice after
+// a correction to their earnings. Locale and Timezone default to the
+// current revision's.
+type RegenerateInvoiceRequest struct {
+	DriverID    string `json:"-"`
+	Period      string `json:"-"`
+	Reason      string `json:"reason" validate:"required"`
+	Locale      string `json:"locale"`
+	Timezone    string `json:"timezone"`
+	GeneratedBy string `json:"generated_by"`
+}
@@ -0,0 +1,185 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrUnbalancedJournalEntry is returned for journal entries whose postings
+	// do not sum to zero in every currency
+	ErrUnbalancedJournalEntry = errors.New("journal entry does not balance")
+	// ErrInvalidJournalEntry is returned for journal entries that cannot be
+	// posted, such as ones with zero postings or unknown account types
+	ErrInvalidJournalEntry = errors.New("invalid journal entry")
+	// ErrDuplicateJournalEntry is returned when a journal entry with the same
+	// idempotency key has already been posted
+	ErrDuplicateJournalEntry = errors.New("journal entry already posted")
+	// ErrJournalEntryNotFound is returned when a journal entry does not exist
+	ErrJournalEntryNotFound = errors.New("journal entry not found")
+	// ErrPayoutExceedsPayable is returned for payouts larger than what the
+	// platform owes the driver
+	ErrPayoutExceedsPayable = errors.New("payout exceeds the driver's payable balance")
+)
+
+// LedgerAccountType is the class of a general ledger account. Assets and
+// expenses grow with debits; liabilities, equity and revenue grow with
+// credits.
+type LedgerAccountType string
+
+const (
+	LedgerAccountAsset     LedgerAccountType = "asset"
+	LedgerAccountLiability LedgerAccountType = "liability"
+	LedgerAccountEquity    LedgerAccountType = "equity"
+	LedgerAccountRevenue   LedgerAccountType = "revenue"
+	LedgerAccountExpense   LedgerAccountType = "expense"
+)
+
+// DebitNormal reports whether debits increase accounts of this type
+func (t LedgerAccountType) DebitNormal() bool {
+	return t == LedgerAccountAsset || t == LedgerAccountExpense
+}
+
+// LedgerAccountTypeOf returns the type of an account code. Account codes start
+// with their type, e.g. asset:processor_clearing or liability:driver:42:payable.
+func LedgerAccountTypeOf(code string) (LedgerAccountType, error) {
+	prefix, rest, found := strings.Cut(code, ":")
+	if !found || rest == "" {
+		return "", fmt.Errorf("%w: account %q has no type prefix", ErrInvalidJournalEntry, code)
+	}
+	switch accountType := LedgerAccountType(prefix); accountType {
+	case LedgerAccountAsset, LedgerAccountLiability, LedgerAccountEquity, LedgerAccountRevenue, LedgerAccountExpense:
+		return accountType, nil
+	}
+	return "", fmt.Errorf("%w: account %q has unknown type %q", ErrInvalidJournalEntry, code, prefix)
+}
+
+// General ledger accounts on the platform's side
+const (
+	GLProcessorClearing = "asset:processor_clearing"   // charged to cards and banks, not yet settled
+	GLCashWithDrivers   = "asset:cash_with_drivers"    // cash fares collected by drivers
+	GLWalletFares       = "asset:wallet_fare_clearing" // fares paid from wallets, awaiting their payment
+	GLBank              = "asset:bank"                 // the platform's bank account payouts are paid from
+	GLCommission        = "revenue:commission"         // the platform's share of fares
+	GLPromotions        = "expense:promotions"         // ride credits given away
+	GLRefundCredits     = "expense:refund_credits"     // refunds paid as ride credits
+	GLPickupGuarantees  = "expense:pickup_guarantees"  // credits for late pickups
+	GLIncentives        = "expense:driver_incentives"  // bonuses paid to drivers
+	GLUnattributedFares = "revenue:unattributed_fares" // fares without a driver
+)
+
+// GLDriverPayable is the account holding what the platform owes a driver
+func GLDriverPayable(driverID string) string {
+	return fmt.Sprintf("liability:driver:%s:payable", driverID)
+}
+
+// GLWalletAccount is the general ledger account mirroring a wallet ledger
+// account, e.g. wallet:42:cash becomes liability:wallet:42:cash
+func GLWalletAccount(walletAccount string) string {
+	return "liability:" + walletAccount
+}
+
+// JournalEntryKind is the business event a journal entry records
+type JournalEntryKind string
+
+const (
+	JournalFarePayment JournalEntryKind = "fare_payment"
+	JournalRefund      JournalEntryKind = "refund"
+	JournalChargeback  JournalEntryKind = "chargeback"
+	JournalTip         JournalEntryKind = "tip"
+	JournalIncentive   JournalEntryKind = "incentive"
+	JournalPayout      JournalEntryKind = "payout"
+	JournalWallet      JournalEntryKind = "wallet"
+)
+
+// Posting is one line of a journal entry. Amount is in minor units of
+// Currency: positive amounts debit the account and negative amounts credit it.
+type Posting struct {
+	ID             string    `json:"id" db:"id"`
+	JournalEntryID string    `json:"journal_entry_id" db:"journal_entry_id"`
+	Account        string    `json:"account" db:"account"`
+	Amount         int64     `json:"amount_minor" db:"amount"`
+	Currency       string    `json:"currency" db:"currency"`
+	PostedAt       time.Time `json:"posted_at" db:"posted_at"`
+}
+
+// JournalEntry is a balanced set of postings recording one money movement.
+// IdempotencyKey names the event it records, such as payment:<id>, so an
+// event is posted at most once.
+type JournalEntry struct {
+	ID             string           `json:"id" db:"id"`
+	Kind           JournalEntryKind `json:"kind" db:"kind"`
+	IdempotencyKey string           `json:"idempotency_key" db:"idempotency_key"`
+	Reference      string           `json:"reference,omitempty" db:"reference"` // the payment, refund, trip or payout it records
+	Description    string           `json:"description,omitempty" db:"description"`
+	Postings       []*Posting       `json:"postings"`
+	PostedAt       time.Time        `json:"posted_at" db:"posted_at"`
+}
+
+// PendingJournalEntry is a journal entry that failed to post, kept in the
+// ledger outbox until a retry posts it
+type PendingJournalEntry struct {
+	Entry         *JournalEntry `json:"entry"`
+	Attempts      int           `json:"attempts"`
+	LastError     string        `json:"last_error,omitempty"`
+	CreatedAt     time.Time     `json:"created_at"`
+	NextAttemptAt time.Time     `json:"next_attempt_at"`
+}
+
+// LedgerAccount is an account in the general ledger. Accounts are opened by
+// the first journal entry that posts to them.
+type LedgerAccount struct {
+	Code      string            `json:"code" db:"code"`
+	Type      LedgerAccountType `json:"type" db:"type"`
+	CreatedAt time.Time         `json:"created_at" db:"created_at"`
+}
+
+// AccountBalance is an account's totals in one currency, in minor units.
+// Balance is on the account's normal side: debits less credits for assets and
+// expenses, credits less debits otherwise.
+type AccountBalance struct {
+	Account  string            `json:"account"`
+	Type     LedgerAccountType `json:"type"`
+	Currency string            `json:"currency"`
+	Debits   int64             `json:"debits_minor"`
+	Credits  int64             `json:"credits_minor"`
+	Balance  int64             `json:"balance_minor"`
+}
+
+// NewAccountBalance sums an account's debits and credits into its balance
+func NewAccountBalance(account string, accountType LedgerAccountType, code string, debits, credits int64) *AccountBalance {
+	balance := &AccountBalance{Account: account, Type: accountType, Currency: code, Debits: debits, Credits: credits}
+	if accountType.DebitNormal() {
+		balance.Balance = debits - credits
+	} else {
+		balance.Balance = credits - debits
+	}
+	return balance
+}
+
+// TrialBalanceTotal is the sum of every account's debits and credits in one
+// currency. They are equal when the ledger balances.
+type TrialBalanceTotal struct {
+	Currency string `json:"currency"`
+	Debits   int64  `json:"debits_minor"`
+	Credits  int64  `json:"credits_minor"`
+	Balanced bool   `json:"balanced"`
+}
+
+// TrialBalance is every account's balance as of a moment
+type TrialBalance struct {
+	AsOf     time.Time            `json:"as_of"`
+	Accounts []*AccountBalance    `json:"accounts"`
+	Totals   []*TrialBalanceTotal `json:"totals"`
+}
+
+// RecordPayoutRequest records money paid out to a driver's bank account.
+// Reference is the bank transfer's ID and makes the payout idempotent.
+type RecordPayoutRequest struct {
+	DriverID  string  `json:"driver_id"`
+	Amount    float64 `json:"amount"`
+	Currency  string  `json:"currency"`
+	Reference string  `json:"reference"`
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"github.com/rideshare-platform/shared/pagination"
+)
+
+var (
+	paymentSortCreatedAt = pagination.Field{Name: "created_at", Kind: pagination.KindTime}
+	paymentSortAmount    = pagination.Field{Name: "amount", Kind: pagination.KindNumber}
+)
+
+// PaymentListOptions are the sorts payment lists offer, newest first by default
+var PaymentListOptions = pagination.Options{
+	Fields:   []pagination.Field{paymentSortCreatedAt, paymentSortAmount},
+	Default:  pagination.Sort{Field: paymentSortCreatedAt, Descending: true},
+	MaxLimit: 500,
+}
+
+// PaymentSortKey returns the position of payments in a list sorted by sort
+func PaymentSortKey(sort pagination.Sort) func(*Payment) pagination.Key {
+	if sort.Field.Name == paymentSortAmount.Name {
+		return func(p *Payment) pagination.Key { return pagination.NumberKey(p.Amount, p.ID) }
+	}
+	return func(p *Payment) pagination.Key { return pagination.TimeKey(p.CreatedAt, p.ID) }
+}
+
+var chargebackSortCreatedAt = pagination.Field{Name: "created_at", Kind: pagination.KindTime}
+
+// ChargebackListOptions page chargebacks oldest first, so the ones whose
+// evidence is due soonest come first
+var ChargebackListOptions = pagination.Options{
+	Fields:       []pagination.Field{chargebackSortCreatedAt},
+	Default:      pagination.Sort{Field: chargebackSortCreatedAt},
+	DefaultLimit: 50,
+	MaxLimit:     200,
+}
+
+// ChargebackSortKey returns the position of a chargeback in a list
+func ChargebackSortKey(c *Chargeback) pagination.Key {
+	return pagination.TimeKey(c.CreatedAt, c.ID)
+}
+
+var postingSortPostedAt = pagination.Field{Name: "posted_at", Kind: pagination.KindTime}
+
+// PostingListOptions page an account's postings newest first
+var PostingListOptions = pagination.Options{
+	Fields:       []pagination.Field{postingSortPostedAt},
+	Default:      pagination.Sort{Field: postingSortPostedAt, Descending: true},
+	DefaultLimit: 50,
+	MaxLimit:     500,
+}
+
+// PostingSortKey returns the position of a posting in a list
+func PostingSortKey(p *Posting) pagination.Key {
+	return pagination.TimeKey(p.PostedAt, p.ID)
+}
@@ -13,6 +13,9 @@ const (
 	PaymentMethodDigitalWallet PaymentMethod = "digital_wallet"
 	PaymentMethodBankTransfer  PaymentMethod = "bank_transfer"
 	PaymentMethodCash          PaymentMethod = "cash"
+	// PaymentMethodWalletBalance marks the part of a fare paid from the rider's
+	// wallet; it is not a method riders add
+	PaymentMethodWalletBalance PaymentMethod = "wallet_balance"
 )
 
 // PaymentStatus represents the current state of a payment
@@ -77,12 +80,18 @@ type PaymentMethodDetails struct {
 	IsDefault      bool                   `json:"is_default" db:"is_default"`
 	Fingerprint    string                 `json:"fingerprint" db:"fingerprint"`
 	ExpiryDate     *time.Time             `json:"expiry_date,omitempty" db:"expiry_date"`
-	LastFourDigits string                 `json:"last_four_digits,omitempty" db:"last_four_digits"`
-	BankName       string                 `json:"bank_name,omitempty" db:"bank_name"`
-	WalletProvider string                 `json:"wallet_provider,omitempty" db:"wallet_provider"`
+	LastFourDigits string                 `json:"last_four_digits,omitempty" db:"last_four_digits" pii:"encrypt"`
+	BankName       string                 `json:"bank_name,omitempty" db:"bank_name" pii:"encrypt"`
+	WalletProvider string                 `json:"wallet_provider,omitempty" db:"wallet_provider" pii:"encrypt"`
 	Details        map[string]interface{} `json:"details" db:"details"`
 	CreatedAt      time.Time              `json:"created_at" db:"created_at"`
 	UpdatedAt      time.Time              `json:"updated_at" db:"updated_at"`
+
+	// Cards are kept as a gateway token; the card number and CVV are never
+	// stored. TokenVault names the vault that issued the token.
+	CardToken  string `json:"-" db:"card_token" pii:"encrypt"`
+	TokenVault string `json:"-" db:"token_vault"`
+	CardBrand  string `json:"card_brand,omitempty" db:"card_brand"`
 }
 
 // RefundRequest represents a refund transaction
@@ -104,6 +113,7 @@ type FraudDetectionResult struct {
 	RiskScore      float64            `json:"risk_score"`
 	Reasons        []string           `json:"reasons"`
 	Scores         map[string]float64 `json:"scores"`
+	MatchedRules   []string           `json:"matched_rules,omitempty"`
 	RequiresReview bool               `json:"requires_review"`
 }
 
@@ -136,6 +146,9 @@ type RefundPaymentRequest struct {
 	Amount      float64 `json:"amount" validate:"required,gt=0"`
 	Reason      string  `json:"reason" validate:"required"`
 	RequestedBy string  `json:"requested_by" validate:"required"`
+	// Currency optionally confirms the currency of the refunded amount; it
+	// must match the payment's
+	Currency string `json:"currency,omitempty"`
 }
 
 // AddPaymentMethodRequest represents adding a new payment method
@@ -149,9 +162,23 @@ type AddPaymentMethodRequest struct {
 // PaymentResponse represents the response from payment operations
 type PaymentResponse struct {
 	Payment *Payment `json:"payment"`
-	Success bool     `json:"success"`
-	Message string   `json:"message"`
-	Errors  []string `json:"errors,omitempty"`
+	// Refund is set by refunds that got as far as the processor
+	Refund  *RefundRequest `json:"refund,omitempty"`
+	Success bool           `json:"success"`
+	Message string         `json:"message"`
+	Errors  []string       `json:"errors,omitempty"`
+}
+
+// TokenMigrationResult reports a pass over stored payment methods that
+// tokenizes legacy card numbers and moves tokens to the current vault
+type TokenMigrationResult struct {
+	Scanned     int `json:"scanned"`
+	Tokenized   int `json:"tokenized"`   // raw card numbers replaced by tokens
+	Retokenized int `json:"retokenized"` // tokens moved from a previous vault
+	// Failed methods could not be tokenized. Their card data is removed all the
+	// same, and they have to be added again before they can be charged.
+	Failed          int      `json:"failed"`
+	FailedMethodIDs []string `json:"failed_method_ids,omitempty"`
 }
 
 // PaymentMethodResponse represents the response for payment method operations
@@ -161,3 +188,34 @@ type PaymentMethodResponse struct {
 	Message       string                `json:"message"`
 	Errors        []string              `json:"errors,omitempty"`
 }
+
+// CurrencyTotal is the number and sum of payments in one currency and status
+type CurrencyTotal struct {
+	Currency string        `json:"currency"`
+	Status   PaymentStatus `json:"status"`
+	Count    int           `json:"count"`
+	Amount   float64       `json:"amount"`
+}
+
+// CurrencyAmount is an amount in one currency, with its display form
+type CurrencyAmount struct {
+	Currency  string  `json:"currency"`
+	Count     int     `json:"count"`
+	Amount    float64 `json:"amount"`
+	Formatted string  `json:"formatted"`
+}
+
+// PaymentStats summarises payments created in [Since, Until). Amounts are kept
+// per currency and are only converted for the reporting total.
+type PaymentStats struct {
+	Since              time.Time         `json:"since"`
+	Until              time.Time         `json:"until"`
+	TotalPayments      int               `json:"total_payments"`
+	SuccessfulPayments int               `json:"successful_payments"`
+	FailedPayments     int               `json:"failed_payments"`
+	RefundedPayments   int               `json:"refunded_payments"`
+	Collected          []*CurrencyAmount `json:"collected"`
+	// ReportingTotal is everything collected converted to the reporting
+	// currency, nil when no exchange rates are configured
+	ReportingTotal *CurrencyAmount `json:"reporting_total,omitempty"`
+}
@@ -0,0 +1,89 @@
+package types
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrRefundCaseNotFound is returned when a refund case does not exist
+	ErrRefundCaseNotFound = errors.New("refund case not found")
+	// ErrRefundCaseResolved is returned for decisions on a refund case that
+	// is no longer waiting for review
+	ErrRefundCaseResolved = errors.New("refund case is already resolved")
+)
+
+// RefundReasonCode identifies why an automated refund was triggered
+type RefundReasonCode string
+
+const (
+	RefundReasonDriverNoShow         RefundReasonCode = "driver_no_show"
+	RefundReasonPlatformCancellation RefundReasonCode = "platform_cancellation"
+	RefundReasonOvercharge           RefundReasonCode = "overcharge"
+)
+
+// RefundCaseStatus represents the state of an automated refund
+type RefundCaseStatus string
+
+const (
+	RefundCasePendingReview RefundCaseStatus = "pending_review"
+	RefundCaseRefunded      RefundCaseStatus = "refunded"
+	RefundCaseRejected      RefundCaseStatus = "rejected"
+	RefundCaseFailed        RefundCaseStatus = "failed"
+)
+
+// RefundTrigger reports an event that may entitle the rider to a refund
+type RefundTrigger struct {
+	TripID string           `json:"trip_id" validate:"required"`
+	Reason RefundReasonCode `json:"reason" validate:"required"`
+	// ExpectedAmount is the fare reconciliation arrived at, for overcharges
+	ExpectedAmount float64 `json:"expected_amount,omitempty"`
+	Source         string  `json:"source"` // service or job that raised the trigger
+	Details        string  `json:"details,omitempty"`
+}
+
+// RefundCase is a refund raised by a trigger for one payment of a trip. Small
+// refunds are paid automatically; larger ones wait for a reviewer.
+type RefundCase struct {
+	ID            string           `json:"id" db:"id"`
+	TripID        string           `json:"trip_id" db:"trip_id"`
+	PaymentID     string           `json:"payment_id" db:"payment_id"`
+	UserID        string           `json:"user_id" db:"user_id"`
+	Reason        RefundReasonCode `json:"reason" db:"reason"`
+	Amount        float64          `json:"amount" db:"amount"`
+	Currency      string           `json:"currency" db:"currency"`
+	Status        RefundCaseStatus `json:"status" db:"status"`
+	AutoApproved  bool             `json:"auto_approved" db:"auto_approved"`
+	Source        string           `json:"source" db:"source"`
+	Details       string           `json:"details,omitempty" db:"details"`
+	ReviewedBy    string           `json:"reviewed_by,omitempty" db:"reviewed_by"`
+	Notes         string           `json:"notes,omitempty" db:"notes"`
+	FailureReason string           `json:"failure_reason,omitempty" db:"failure_reason"`
+	CreatedAt     time.Time        `json:"created_at" db:"created_at"`
+	ResolvedAt    *time.Time       `json:"resolved_at,omitempty" db:"resolved_at"`
+}
+
+// RefundAuditEntry records one step in the life of a refund case
+type RefundAuditEntry struct {
+	ID        string    `json:"id" db:"id"`
+	CaseID    string    `json:"case_id" db:"case_id"`
+	Action    string    `json:"action" db:"action"`
+	Actor     string    `json:"actor" db:"actor"`
+	Details   string    `json:"details,omitempty" db:"details"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// RefundTriggerResponse is the outcome of a refund trigger
+type RefundTriggerResponse struct {
+	Success bool          `json:"success"`
+	Message string        `json:"message"`
+	Errors  []string      `json:"errors,omitempty"`
+	Cases   []*RefundCase `json:"cases,omitempty"`
+}
+
+// ResolveRefundCaseRequest represents a reviewer decision on a refund case.
+// ReviewedBy is the operator whose token the decision was made with.
+type ResolveRefundCaseRequest struct {
+	ReviewedBy string `json:"-"`
+	Notes      string `json:"notes"`
+}
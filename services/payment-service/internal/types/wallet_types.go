@@ -0,0 +1,142 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrInsufficientBalance is returned when a wallet account would go negative
+	ErrInsufficientBalance = errors.New("insufficient wallet balance")
+	// ErrUnbalancedTransaction is returned for wallet transactions whose ledger
+	// entries do not sum to zero
+	ErrUnbalancedTransaction = errors.New("ledger entries do not balance")
+)
+
+// WalletTransactionType is the kind of money movement in a wallet
+type WalletTransactionType string
+
+const (
+	WalletTransactionTopUp           WalletTransactionType = "top_up"
+	WalletTransactionPromotionCredit WalletTransactionType = "promotion_credit"
+	WalletTransactionRefundCredit    WalletTransactionType = "refund_credit"
+	WalletTransactionGuaranteeCredit WalletTransactionType = "guarantee_credit"
+	WalletTransactionFarePayment     WalletTransactionType = "fare_payment"
+)
+
+// Ledger accounts on the platform's side of wallet movements. Each rider has
+// a cash account, funded by top-ups, and a credit account, funded by
+// promotions, refunds and missed guarantees; see WalletCashAccount and WalletCreditAccount.
+const (
+	LedgerAccountCardFunding = "platform:card_funding" // top-ups charged to cards
+	LedgerAccountPromotions  = "platform:promotions"   // ride credits given away
+	LedgerAccountRefunds     = "platform:refunds"      // refunds paid as ride credits
+	LedgerAccountGuarantees  = "platform:guarantees"   // credits for late pickups
+	LedgerAccountFares       = "platform:fares"        // fares paid from wallets
+)
+
+// WalletCashAccount is the ledger account holding a rider's topped up balance
+func WalletCashAccount(userID string) string {
+	return fmt.Sprintf("wallet:%s:cash", userID)
+}
+
+// WalletCreditAccount is the ledger account holding a rider's ride credits
+func WalletCreditAccount(userID string) string {
+	return fmt.Sprintf("wallet:%s:credit", userID)
+}
+
+// LedgerEntry is one side of a wallet transaction. Amount is in minor units of
+// Currency; positive amounts increase the account's balance.
+type LedgerEntry struct {
+	ID            string    `json:"id" db:"id"`
+	TransactionID string    `json:"transaction_id" db:"transaction_id"`
+	Account       string    `json:"account" db:"account"`
+	Amount        int64     `json:"amount_minor" db:"amount"`
+	Currency      string    `json:"currency" db:"currency"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+}
+
+// WalletTransaction is a movement of money into, out of or within a rider's
+// wallet, recorded as balanced ledger entries
+type WalletTransaction struct {
+	ID     string                `json:"id" db:"id"`
+	UserID string                `json:"user_id" db:"user_id"`
+	Type   WalletTransactionType `json:"type" db:"type"`
+	// Amount is the change to the rider's wallet balance, negative for payments
+	Amount      float64        `json:"amount" db:"amount"`
+	Currency    string         `json:"currency" db:"currency"`
+	Reference   string         `json:"reference,omitempty" db:"reference"` // payment, trip or promotion the movement belongs to
+	Description string         `json:"description,omitempty" db:"description"`
+	Entries     []*LedgerEntry `json:"entries"`
+	CreatedAt   time.Time      `json:"created_at" db:"created_at"`
+}
+
+// Balanced reports whether the transaction's entries sum to zero
+func (t *WalletTransaction) Balanced() bool {
+	var sum int64
+	for _, entry := range t.Entries {
+		sum += entry.Amount
+	}
+	return len(t.Entries) >= 2 && sum == 0
+}
+
+// WalletAccounts are the balances of a rider's wallet accounts in minor units.
+// Currency is empty for wallets that have never been used.
+type WalletAccounts struct {
+	Currency string
+	Cash     int64
+	Credit   int64
+}
+
+// WalletBalance is a rider's wallet balance
+type WalletBalance struct {
+	UserID    string  `json:"user_id"`
+	Currency  string  `json:"currency"`
+	Cash      float64 `json:"cash"`    // topped up, spent after credits
+	Credits   float64 `json:"credits"` // ride credits from promotions and refunds
+	Total     float64 `json:"total"`
+	Formatted string  `json:"formatted"`
+}
+
+// TopUpWalletRequest adds money to a wallet, charged to a payment method
+type TopUpWalletRequest struct {
+	UserID          string  `json:"-"`
+	Amount          float64 `json:"amount" validate:"required,gt=0"`
+	Currency        string  `json:"currency"`
+	PaymentMethodID string  `json:"payment_method_id" validate:"required"`
+}
+
+// GrantCreditRequest gives a rider ride credits
+type GrantCreditRequest struct {
+	UserID      string                `json:"user_id"`
+	Amount      float64               `json:"amount" validate:"required,gt=0"`
+	Currency    string                `json:"currency"`
+	Type        WalletTransactionType `json:"type"` // promotion_credit, refund_credit or guarantee_credit
+	Reference   string                `json:"reference"`
+	Description string                `json:"description"`
+}
+
+// WalletPaymentRequest pays a fare from the rider's wallet, with whatever the
+// wallet does not cover charged to PaymentMethodID
+type WalletPaymentRequest struct {
+	TripID          string  `json:"trip_id" validate:"required"`
+	UserID          string  `json:"-"`
+	DriverID        string  `json:"driver_id" validate:"required"`
+	Amount          float64 `json:"amount" validate:"required,gt=0"`
+	Currency        string  `json:"currency"`
+	PaymentMethodID string  `json:"payment_method_id"`
+}
+
+// WalletResponse is the result of a wallet operation
+type WalletResponse struct {
+	Success     bool               `json:"success"`
+	Message     string             `json:"message"`
+	Errors      []string           `json:"errors,omitempty"`
+	Transaction *WalletTransaction `json:"transaction,omitempty"`
+	Wallet      *WalletBalance     `json:"wallet,omitempty"`
+	// Fare payments split between the wallet and a card
+	WalletAmount float64  `json:"wallet_amount,omitempty"`
+	CardAmount   float64  `json:"card_amount,omitempty"`
+	CardPayment  *Payment `json:"card_payment,omitempty"`
+}
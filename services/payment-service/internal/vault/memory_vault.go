@@ -0,0 +1,117 @@
+package vault
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MemoryVault is an in-memory stand-in for a gateway vault, for development
+// and tests. Cards are lost on restart.
+type MemoryVault struct {
+	name           string
+	fingerprintKey []byte
+	previous       []*MemoryVault
+	cards          map[string]*Card
+	mutex          sync.RWMutex
+}
+
+// NewMemoryVault creates an in-memory vault. Fingerprints are keyed with
+// fingerprintKey, so the same card has the same fingerprint in every vault
+// sharing the key. Tokens of the previous vaults can be retokenized into this
+// one, as when moving to a new gateway.
+func NewMemoryVault(name string, fingerprintKey []byte, previous ...*MemoryVault) *MemoryVault {
+	return &MemoryVault{
+		name:           name,
+		fingerprintKey: fingerprintKey,
+		previous:       previous,
+		cards:          make(map[string]*Card),
+	}
+}
+
+// Name identifies the vault
+func (v *MemoryVault) Name() string {
+	return v.name
+}
+
+// Tokenize validates the card and stores it without its CVV. The CVV is
+// checked if given; whether one is required is up to the caller.
+func (v *MemoryVault) Tokenize(ctx context.Context, card *Card) (*Token, error) {
+	if err := card.Validate(time.Now(), false); err != nil {
+		return nil, err
+	}
+	return v.store(card), nil
+}
+
+// Retokenize issues a new token for a card held under a token of this vault
+// or of one of its previous vaults
+func (v *MemoryVault) Retokenize(ctx context.Context, token *Token) (*Token, error) {
+	source := v.source(token.Vault)
+	if source == nil {
+		return nil, fmt.Errorf("%w: vault %s cannot import tokens of %s", ErrTokenNotFound, v.name, token.Vault)
+	}
+
+	card, exists := source.card(token.Token)
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrTokenNotFound, token.Token)
+	}
+	return v.store(card), nil
+}
+
+// store keeps a copy of the card under a new token
+func (v *MemoryVault) store(card *Card) *Token {
+	number := normalizeNumber(card.Number)
+	stored := &Card{
+		Number:      number,
+		HolderName:  card.HolderName,
+		ExpiryMonth: card.ExpiryMonth,
+		ExpiryYear:  card.ExpiryYear,
+	}
+	token := &Token{
+		Token:       "tok_" + uuid.New().String(),
+		Vault:       v.name,
+		Brand:       Brand(number),
+		LastFour:    lastFour(number),
+		ExpiryMonth: card.ExpiryMonth,
+		ExpiryYear:  card.ExpiryYear,
+		Fingerprint: v.fingerprint(number),
+	}
+
+	v.mutex.Lock()
+	v.cards[token.Token] = stored
+	v.mutex.Unlock()
+	return token
+}
+
+func (v *MemoryVault) card(token string) (*Card, bool) {
+	v.mutex.RLock()
+	defer v.mutex.RUnlock()
+
+	card, exists := v.cards[token]
+	return card, exists
+}
+
+// source returns the vault that issued tokens with the given vault name
+func (v *MemoryVault) source(name string) *MemoryVault {
+	if name == v.name {
+		return v
+	}
+	for _, previous := range v.previous {
+		if previous.name == name {
+			return previous
+		}
+	}
+	return nil
+}
+
+func (v *MemoryVault) fingerprint(number string) string {
+	mac := hmac.New(sha256.New, v.fingerprintKey)
+	mac.Write([]byte(number))
+	return hex.EncodeToString(mac.Sum(nil))
+}
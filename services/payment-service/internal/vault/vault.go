@@ -0,0 +1,164 @@
+// Package vault exchanges card data for gateway tokens. It is the only part of
+// payment-service that handles card numbers: callers pass a Card in, get a
+// Token back and store nothing but the token, brand, last four digits and
+// expiry. Keeping card data behind the Vault interface keeps the rest of the
+// service out of PCI scope.
+package vault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidCard is returned for card data that cannot be tokenized
+	ErrInvalidCard = errors.New("invalid card")
+	// ErrTokenNotFound is returned for tokens the vault does not hold
+	ErrTokenNotFound = errors.New("card token not found")
+)
+
+// Card is raw card data. It must never be stored or logged; String masks it.
+type Card struct {
+	Number      string
+	CVV         string // checked on tokenization, never kept
+	HolderName  string
+	ExpiryMonth int
+	ExpiryYear  int
+}
+
+// Token is what is kept of a card once it has been tokenized
+type Token struct {
+	Token       string `json:"token"`
+	Vault       string `json:"vault"` // name of the vault that issued the token
+	Brand       string `json:"brand"`
+	LastFour    string `json:"last_four"`
+	ExpiryMonth int    `json:"expiry_month"`
+	ExpiryYear  int    `json:"expiry_year"`
+	// Fingerprint identifies the card number without revealing it, for
+	// duplicate detection
+	Fingerprint string `json:"fingerprint"`
+}
+
+// Vault tokenizes cards. Implementations are backed by a payment gateway's
+// vault.
+type Vault interface {
+	// Name identifies the vault, and is recorded with the tokens it issues
+	Name() string
+	// Tokenize stores the card with the gateway and returns its token
+	Tokenize(ctx context.Context, card *Card) (*Token, error)
+	// Retokenize returns a token of this vault for a card held under an
+	// existing token, issued by this vault or by one it migrates from. The
+	// card data never leaves the gateways.
+	Retokenize(ctx context.Context, token *Token) (*Token, error)
+}
+
+// String masks the card so it can never end up in a log
+func (c Card) String() string {
+	return "card ending " + lastFour(c.Number)
+}
+
+// GoString masks the card for %#v
+func (c Card) GoString() string {
+	return c.String()
+}
+
+// Validate checks the card number, CVV and expiry. requireCVV is false for
+// cards being migrated from storage, which never held the CVV.
+func (c *Card) Validate(now time.Time, requireCVV bool) error {
+	number := normalizeNumber(c.Number)
+	if len(number) < 13 || len(number) > 19 {
+		return fmt.Errorf("%w: card number must have 13 to 19 digits", ErrInvalidCard)
+	}
+	if !luhnValid(number) {
+		return fmt.Errorf("%w: card number fails checksum", ErrInvalidCard)
+	}
+	if requireCVV || c.CVV != "" {
+		if len(c.CVV) < 3 || len(c.CVV) > 4 || strings.Trim(c.CVV, "0123456789") != "" {
+			return fmt.Errorf("%w: CVV must be 3 or 4 digits", ErrInvalidCard)
+		}
+	}
+	if c.ExpiryMonth < 1 || c.ExpiryMonth > 12 || c.ExpiryYear < 2000 {
+		return fmt.Errorf("%w: invalid expiry date", ErrInvalidCard)
+	}
+	if now.After(c.ExpiresAt()) {
+		return fmt.Errorf("%w: card has expired", ErrInvalidCard)
+	}
+	return nil
+}
+
+// ExpiresAt returns the end of the card's expiry month
+func (c *Card) ExpiresAt() time.Time {
+	return ExpiresAt(c.ExpiryMonth, c.ExpiryYear)
+}
+
+// ExpiresAt returns the end of the given expiry month, cards are valid
+// through the last day of it
+func ExpiresAt(month, year int) time.Time {
+	return time.Date(year, time.Month(month)+1, 1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond)
+}
+
+// Brand returns the card network of a card number from its leading digits
+func Brand(number string) string {
+	number = normalizeNumber(number)
+	prefix := func(n int) int {
+		if len(number) < n {
+			return -1
+		}
+		value := 0
+		for _, digit := range number[:n] {
+			value = value*10 + int(digit-'0')
+		}
+		return value
+	}
+
+	switch {
+	case prefix(1) == 4:
+		return "visa"
+	case prefix(2) >= 51 && prefix(2) <= 55, prefix(4) >= 2221 && prefix(4) <= 2720:
+		return "mastercard"
+	case prefix(2) == 34, prefix(2) == 37:
+		return "amex"
+	case prefix(4) == 6011, prefix(2) == 65, prefix(3) >= 644 && prefix(3) <= 649:
+		return "discover"
+	case prefix(4) >= 3528 && prefix(4) <= 3589:
+		return "jcb"
+	default:
+		return "unknown"
+	}
+}
+
+// normalizeNumber strips the spaces and dashes card numbers are entered with
+func normalizeNumber(number string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(number)
+}
+
+func luhnValid(number string) bool {
+	sum := 0
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		digit := int(number[i] - '0')
+		if digit < 0 || digit > 9 {
+			return false
+		}
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+func lastFour(number string) string {
+	number = normalizeNumber(number)
+	if len(number) < 4 {
+		return ""
+	}
+	return number[len(number)-4:]
+}
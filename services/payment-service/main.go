@@ -2,20 +2,39 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
 	"net"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	_ "github.com/lib/pq"
+	"github.com/rideshare-platform/services/payment-service/internal/client"
+	"github.com/rideshare-platform/services/payment-service/internal/handler"
 	"github.com/rideshare-platform/services/payment-service/internal/repository"
 	"github.com/rideshare-platform/services/payment-service/internal/service"
 	"github.com/rideshare-platform/services/payment-service/internal/types"
+	"github.com/rideshare-platform/services/payment-service/internal/vault"
+	"github.com/rideshare-platform/services/payment-service/migrations"
+	"github.com/rideshare-platform/shared/archive"
+	"github.com/rideshare-platform/shared/currency"
+	"github.com/rideshare-platform/shared/database"
+	"github.com/rideshare-platform/shared/export"
+	sharedgrpc "github.com/rideshare-platform/shared/grpc"
+	"github.com/rideshare-platform/shared/grpc/interceptor"
+	sharedhealth "github.com/rideshare-platform/shared/health"
 	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/middleware"
+	"github.com/rideshare-platform/shared/monitoring"
+	paymentpb "github.com/rideshare-platform/shared/proto/payment"
+	sharedtls "github.com/rideshare-platform/shared/tls"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
@@ -25,13 +44,35 @@ func main() {
 	// Create logger
 	logr := logger.NewLogger("info", "development")
 
+	tlsConfig, err := sharedtls.LoadConfig()
+	if err != nil {
+		log.Fatalf("Invalid TLS configuration: %v", err)
+	}
+	tlsReloader, err := sharedtls.Load(tlsConfig, logr)
+	if err != nil {
+		log.Fatalf("Failed to load TLS certificates: %v", err)
+	}
+	go tlsReloader.Watch(context.Background())
+
 	// Initialize mock repositories
 	paymentRepo := repository.NewMockPaymentRepository()
 	paymentMethodRepo := repository.NewMockPaymentMethodRepository()
 	refundRepo := repository.NewMockRefundRepository()
+	fraudRuleRepo := repository.NewMockFraudRuleRepository()
+	fraudReviewRepo := repository.NewMockFraudReviewRepository()
 
-	// Initialize fraud detection service
-	fraudService := service.NewSimpleFraudDetectionService(*logr)
+	// Initialize rules-based fraud detection engine
+	fraudService := service.NewRulesFraudDetectionService(
+		paymentRepo,
+		fraudRuleRepo,
+		fraudReviewRepo,
+		nil,
+		service.DefaultFraudEngineConfig(),
+		*logr,
+	)
+	if err := fraudService.SeedDefaultRules(context.Background()); err != nil {
+		log.Printf("Failed to seed default fraud rules: %v", err)
+	}
 
 	// Initialize payment service
 	paymentService := service.NewPaymentService(
@@ -42,25 +83,230 @@ func main() {
 		*logr,
 	)
 
+	// Payments without a currency are charged in DEFAULT_CURRENCY. With
+	// EXCHANGE_RATES set, stats also total everything in REPORTING_CURRENCY
+	// and fraud checks score every currency against the same amount tiers.
+	if code := os.Getenv("DEFAULT_CURRENCY"); code != "" {
+		if err := paymentService.SetDefaultCurrency(code); err != nil {
+			log.Fatalf("Invalid DEFAULT_CURRENCY: %v", err)
+		}
+	}
+	if spec := os.Getenv("EXCHANGE_RATES"); spec != "" {
+		reporting := os.Getenv("REPORTING_CURRENCY")
+		if reporting == "" {
+			reporting = currency.Default
+		}
+		rates, err := currency.ParseStaticRates(reporting, spec)
+		if err != nil {
+			log.Fatalf("Invalid EXCHANGE_RATES: %v", err)
+		}
+		if err := paymentService.SetExchangeRates(rates, reporting); err != nil {
+			log.Fatalf("Invalid REPORTING_CURRENCY: %v", err)
+		}
+		fraudService.SetExchangeRates(rates)
+	}
+
+	// Cards are exchanged for vault tokens before anything is stored. The
+	// fingerprint key keeps card fingerprints stable across restarts.
+	fingerprintKey := []byte(os.Getenv("CARD_FINGERPRINT_KEY"))
+	if len(fingerprintKey) == 0 {
+		fingerprintKey = []byte(uuid.New().String())
+		log.Printf("CARD_FINGERPRINT_KEY not set, duplicate cards are only detected until restart")
+	}
+	paymentService.SetVault(vault.NewMemoryVault("memory", fingerprintKey))
+
+	// Every completed payment, refund, tip, incentive, payout, lost chargeback
+	// and wallet movement is posted to the double-entry general ledger, kept
+	// in PostgreSQL when DATABASE_URL is set. Postings that fail are queued
+	// in the ledger outbox and retried every minute.
+	var (
+		ledgerRepo   repository.LedgerRepository
+		ledgerOutbox repository.LedgerOutboxRepository
+		ledgerDB     *sql.DB
+	)
+	if databaseURL := os.Getenv("DATABASE_URL"); databaseURL != "" {
+		db, err := sql.Open("postgres", databaseURL)
+		if err != nil {
+			log.Fatalf("Failed to connect to database: %v", err)
+		}
+		defer db.Close()
+		if err := db.Ping(); err != nil {
+			log.Fatalf("Failed to ping database: %v", err)
+		}
+
+		if migrate, _ := strconv.ParseBool(os.Getenv("MIGRATE_ON_STARTUP")); migrate {
+			schema, err := migrations.Load()
+			if err != nil {
+				log.Fatalf("Failed to load migrations: %v", err)
+			}
+			if _, err := database.NewMigrator(db, "payment-service", schema, logr).Up(context.Background()); err != nil {
+				log.Fatalf("Failed to migrate database: %v", err)
+			}
+		}
+
+		ledgerRepo = repository.NewPostgreSQLLedgerRepository(db, *logr)
+		ledgerOutbox = repository.NewPostgreSQLLedgerOutboxRepository(db, *logr)
+		ledgerDB = db
+	} else {
+		log.Printf("DATABASE_URL not set, the general ledger is kept in memory")
+		ledgerRepo = repository.NewMockLedgerRepository()
+		ledgerOutbox = repository.NewMockLedgerOutboxRepository()
+	}
+	ledger := service.NewLedgerService(ledgerRepo, ledgerOutbox, *logr)
+	paymentService.SetLedger(ledger)
+	ledgerCtx, stopLedger := context.WithCancel(context.Background())
+	defer stopLedger()
+	go ledger.Start(ledgerCtx, time.Minute)
+	paymentHandler := handler.NewPaymentHandler(paymentService, *logr)
+
+	// Request counts and latencies for HTTP and gRPC, scraped from /metrics
+	metricsCollector := monitoring.NewMetricsCollector(nil, logr)
+
 	// Setup router
 	router := gin.Default()
+	router.Use(middleware.NewLoggingMiddleware(logr).RedactBodies("/api/v1/payment-methods").RequestLogger())
+	router.Use(metricsCollector.GinMiddleware("payment-service"))
+	router.GET("/metrics", gin.WrapH(monitoring.Handler()))
 
-	// Health check endpoint
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
-			"status":    "healthy",
-			"service":   "payment-service",
-			"timestamp": time.Now().Format(time.RFC3339),
-			"version":   "1.0.0",
-			"features": []string{
-				"payment_processing",
-				"fraud_detection",
-				"multiple_payment_methods",
-				"refund_processing",
-				"transaction_logging",
-			},
-		})
+	// Rider and driver routes take the user from the bearer token; staff
+	// operations go through the gateway's admin API
+	auth := middleware.NewAuthMiddleware(os.Getenv("JWT_SECRET"), logr)
+
+	// Rider wallets: top-ups, ride credits and fares paid from the balance
+	walletService := service.NewWalletService(repository.NewMockWalletRepository(), paymentService, *logr)
+	handler.NewWalletHandler(walletService, auth, *logr).RegisterRoutes(router)
+
+	// Refunds raised by trip events and reconciliation, with larger ones
+	// queued for review
+	refundPolicy := service.NewRefundPolicyService(paymentService, repository.NewMockRefundCaseRepository(), service.DefaultRefundPolicyConfig(), *logr)
+	refundPolicy.SetWalletService(walletService)
+
+	// Trip fares are held on the rider's payment method when the trip starts
+	// and captured when it completes, with holds close to lapsing renewed
+	holdService := service.NewHoldService(repository.NewMockPaymentHoldRepository(), paymentService, service.DefaultHoldConfig(), *logr)
+	handler.NewPaymentHoldHandler(holdService, *logr).RegisterRoutes(router)
+	holdCtx, stopHolds := context.WithCancel(context.Background())
+	defer stopHolds()
+	go holdService.Start(holdCtx, 10*time.Minute)
+
+	// Disputes the payment provider reports become chargebacks that freeze the
+	// driver's fare until they are decided. Webhooks are signed with
+	// PROVIDER_WEBHOOK_SECRET and refused while it is unset.
+	chargebacks := service.NewChargebackService(repository.NewMockChargebackRepository(), paymentRepo, *logr)
+	chargebacks.SetLedger(ledger)
+	handler.NewChargebackHandler(chargebacks, []byte(os.Getenv("PROVIDER_WEBHOOK_SECRET")), *logr).RegisterRoutes(router)
+
+	// Daily driver statements: fares less DRIVER_COMMISSION_RATE, tips and
+	// incentives, and online hours from the shifts geo-service keeps
+	earnings := service.NewEarningsService(paymentRepo, repository.NewMockDriverEarningRepository(), *logr)
+	earnings.SetLedger(ledger)
+	if rate := os.Getenv("DRIVER_COMMISSION_RATE"); rate != "" {
+		parsed, err := strconv.ParseFloat(rate, 64)
+		if err != nil {
+			log.Fatalf("Invalid DRIVER_COMMISSION_RATE: %v", err)
+		}
+		if err := earnings.SetCommissionRate(parsed); err != nil {
+			log.Fatalf("Invalid DRIVER_COMMISSION_RATE: %v", err)
+		}
+		if err := ledger.SetCommissionRate(parsed); err != nil {
+			log.Fatalf("Invalid DRIVER_COMMISSION_RATE: %v", err)
+		}
+	}
+	if code := os.Getenv("DEFAULT_CURRENCY"); code != "" {
+		if err := earnings.SetDefaultCurrency(code); err != nil {
+			log.Fatalf("Invalid DEFAULT_CURRENCY: %v", err)
+		}
+	}
+	geoAddr := os.Getenv("GEO_SERVICE_ADDR")
+	if geoAddr == "" {
+		geoAddr = "geo-service:50053"
+	}
+	dialOptions := append(sharedgrpc.ClientOptions(), tlsReloader.DialOption())
+	if conn, err := grpc.NewClient(geoAddr, dialOptions...); err != nil {
+		log.Printf("Failed to create geo-service client, statements will not include online hours: %v", err)
+	} else {
+		defer conn.Close()
+		earnings.SetShiftSource(client.NewGRPCShiftClient(conn))
+	}
+	handler.NewEarningsHandler(earnings, auth, *logr).RegisterRoutes(router)
+
+	// Monthly driver invoices for tax purposes, generated for every driver
+	// paid in a month once it ends and formatted for INVOICE_LOCALE unless a
+	// driver asks for another locale
+	invoices := service.NewInvoiceService(repository.NewMockDriverInvoiceRepository(), earnings, paymentRepo, *logr)
+	if locale := os.Getenv("INVOICE_LOCALE"); locale != "" {
+		if err := invoices.SetDefaultLocale(locale); err != nil {
+			log.Fatalf("Invalid INVOICE_LOCALE: %v", err)
+		}
+	}
+	handler.NewInvoiceHandler(invoices, *logr).RegisterRoutes(router)
+	invoiceCtx, stopInvoices := context.WithCancel(context.Background())
+	defer stopInvoices()
+	go invoices.Start(invoiceCtx, time.Hour)
+	handler.NewLedgerHandler(ledger, *logr).RegisterRoutes(router)
+
+	// Payments and the general ledger are exported to the data warehouse in
+	// incremental batches when EXPORT_ENABLED is set, with the manifest for
+	// admin tokens under /api/v1/exports
+	requireAdmin := auth.RequireAdmin
+	exportConfig, err := export.LoadConfig()
+	if err != nil {
+		log.Fatalf("Invalid export configuration: %v", err)
+	}
+	exportCtx, stopExport := context.WithCancel(context.Background())
+	defer stopExport()
+	if exportConfig.Enabled {
+		exporter, err := export.Open(context.Background(), exportConfig, logr)
+		if err != nil {
+			log.Fatalf("Failed to set up warehouse export: %v", err)
+		}
+		defer exporter.Close()
+		exporter.Register(service.NewPaymentExport(paymentRepo))
+		exporter.Register(service.NewJournalEntryExport(ledgerRepo))
+		exporter.Register(service.NewPostingExport(ledgerRepo))
+		go exporter.Start(exportCtx, exportConfig.Interval)
+
+		exportMux := http.NewServeMux()
+		export.NewHandler(exporter, requireAdmin).RegisterRoutes(exportMux)
+		router.Any("/api/v1/exports/*path", gin.WrapH(exportMux))
+	}
+
+	// Ended payments past their retention period move to the archive when
+	// ARCHIVE_ENABLED is set, with lookups and rehydration for admin tokens
+	// under /api/v1/archive
+	archiveConfig, err := archive.LoadConfig()
+	if err != nil {
+		log.Fatalf("Invalid archive configuration: %v", err)
+	}
+	archiveCtx, stopArchive := context.WithCancel(context.Background())
+	defer stopArchive()
+	if archiveConfig.Enabled {
+		archiver, err := archive.Open(context.Background(), archiveConfig, logr)
+		if err != nil {
+			log.Fatalf("Failed to set up payment archival: %v", err)
+		}
+		defer archiver.Close()
+		archiver.Register(service.NewPaymentArchive(paymentRepo))
+		go archiver.Start(archiveCtx, archiveConfig.Interval)
+
+		archiveMux := http.NewServeMux()
+		archive.NewHandler(archiver, requireAdmin).RegisterRoutes(archiveMux)
+		router.Any("/api/v1/archive/*path", gin.WrapH(archiveMux))
+	}
+
+	// Health check endpoint. Payments are kept in memory, so the ledger's
+	// database is the only dependency to probe.
+	healthChecker := sharedhealth.NewChecker("payment-service", "1.0.0")
+	healthChecker.SetConfig(map[string]interface{}{
+		"tls":     tlsConfig,
+		"export":  exportConfig,
+		"archive": archiveConfig,
 	})
+	if ledgerDB != nil {
+		healthChecker.AddCheck("postgres", ledgerDB.PingContext)
+	}
+	router.GET("/health", gin.WrapH(healthChecker.Handler()))
+	router.GET("/info", gin.WrapH(healthChecker.InfoHandler()))
 
 	// API routes
 	v1 := router.Group("/api/v1")
@@ -143,6 +389,9 @@ func main() {
 			}
 		})
 
+		// Tokenize stored card numbers and move tokens to the current vault
+		v1.POST("/payment-methods/migrate-tokens", paymentHandler.MigrateCardTokens)
+
 		// Get user payment methods
 		v1.GET("/users/:user_id/payment-methods", func(c *gin.Context) {
 			userID := c.Param("user_id")
@@ -176,31 +425,8 @@ func main() {
 			})
 		})
 
-		// Get payment statistics (mock)
-		v1.GET("/stats", func(c *gin.Context) {
-			c.JSON(http.StatusOK, gin.H{
-				"daily_stats": gin.H{
-					"total_payments":      1247,
-					"successful_payments": 1189,
-					"failed_payments":     58,
-					"total_amount":        "₹2,847,392.50",
-					"refunds_processed":   23,
-					"fraud_blocked":       12,
-				},
-				"payment_methods": gin.H{
-					"credit_card":    "45%",
-					"digital_wallet": "32%",
-					"debit_card":     "18%",
-					"bank_transfer":  "4%",
-					"cash":           "1%",
-				},
-				"processing_times": gin.H{
-					"average_ms": 245,
-					"p95_ms":     890,
-					"p99_ms":     1420,
-				},
-			})
-		})
+		// Payment statistics per currency
+		v1.GET("/stats", paymentHandler.GetPaymentStats)
 	}
 
 	// Setup HTTP server
@@ -217,8 +443,28 @@ func main() {
 		}
 	}()
 
-	// Start gRPC health server
-	grpcServer := grpc.NewServer()
+	// Start gRPC server with trip payment lookups, wallet credits, fare holds,
+	// chargebacks, driver payouts and health. Bearer tokens are validated
+	// against JWT_SECRET when it is set, which operator-only calls such as
+	// payouts rely on; GRPC_AUTH_REQUIRED also rejects calls without one.
+	grpcAuthRequired, _ := strconv.ParseBool(os.Getenv("GRPC_AUTH_REQUIRED"))
+	serverOptions := interceptor.ServerOptions(interceptor.Config{
+		Service:  "payment-service",
+		Logger:   logr,
+		Metrics:  metricsCollector,
+		Auth:     interceptor.JWTAuth(os.Getenv("JWT_SECRET"), grpcAuthRequired),
+		Deadline: interceptor.DefaultDeadlineConfig(),
+	})
+	grpcServer := grpc.NewServer(append(serverOptions, tlsReloader.ServerOptions()...)...)
+	grpcPaymentHandler := handler.NewGRPCPaymentHandler(paymentService)
+	grpcPaymentHandler.SetWallet(walletService)
+	grpcPaymentHandler.SetHolds(holdService)
+	grpcPaymentHandler.SetChargebacks(chargebacks)
+	grpcPaymentHandler.SetLedger(ledger)
+	grpcPaymentHandler.SetEarnings(earnings)
+	grpcPaymentHandler.SetRefundPolicy(refundPolicy)
+	grpcPaymentHandler.SetFraud(fraudService)
+	paymentpb.RegisterPaymentServiceServer(grpcServer, grpcPaymentHandler)
 	healthServer := health.NewServer()
 	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
 	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
@@ -239,6 +485,8 @@ func main() {
 	<-quit
 
 	log.Println("Shutting down payment service...")
+	stopExport()
+	stopArchive()
 
 	// Create a deadline for shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS payments (
+    id VARCHAR(64) PRIMARY KEY,
+    trip_id VARCHAR(64) NOT NULL,
+    user_id VARCHAR(64) NOT NULL,
+    driver_id VARCHAR(64),
+    amount DECIMAL(12,2) NOT NULL CHECK (amount >= 0),
+    currency VARCHAR(3) NOT NULL DEFAULT 'USD',
+    payment_method VARCHAR(30) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    transaction_type VARCHAR(20) NOT NULL,
+    processor_response TEXT,
+    fraud_risk VARCHAR(20),
+    fraud_scores JSONB,
+    metadata JSONB,
+    failure_reason TEXT,
+    processed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_payments_trip_id ON payments(trip_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_payments_user_id ON payments(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_payments_status ON payments(status);
@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS fraud_rules (
+    id VARCHAR(64) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    expression TEXT NOT NULL,
+    score DOUBLE PRECISION NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    priority INTEGER NOT NULL DEFAULT 0,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS fraud_reviews (
+    id VARCHAR(64) PRIMARY KEY,
+    payment_id VARCHAR(64) NOT NULL,
+    user_id VARCHAR(64) NOT NULL,
+    amount DECIMAL(12,2) NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'USD',
+    risk_level VARCHAR(20) NOT NULL,
+    risk_score DOUBLE PRECISION NOT NULL,
+    reasons JSONB,
+    matched_rules JSONB,
+    scores JSONB,
+    status VARCHAR(20) NOT NULL,
+    reviewed_by VARCHAR(100),
+    notes TEXT,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_fraud_rules_enabled ON fraud_rules(enabled, priority DESC);
+CREATE INDEX IF NOT EXISTS idx_fraud_reviews_status ON fraud_reviews(status, risk_score DESC, created_at);
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS wallet_transactions (
+    id VARCHAR(64) PRIMARY KEY,
+    user_id VARCHAR(64) NOT NULL,
+    type VARCHAR(30) NOT NULL,
+    amount DECIMAL(12,2) NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    reference VARCHAR(64),
+    description TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Double-entry ledger: the entries of a transaction sum to zero, amounts in
+-- minor units of the currency
+CREATE TABLE IF NOT EXISTS ledger_entries (
+    id VARCHAR(64) PRIMARY KEY,
+    transaction_id VARCHAR(64) NOT NULL REFERENCES wallet_transactions(id),
+    account VARCHAR(150) NOT NULL,
+    amount BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_wallet_transactions_user ON wallet_transactions(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_account ON ledger_entries(account);
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_transaction ON ledger_entries(transaction_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS refund_audit_log;
+DROP TABLE IF EXISTS refund_cases;
@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS refund_cases (
+    id VARCHAR(64) PRIMARY KEY,
+    trip_id VARCHAR(64) NOT NULL,
+    payment_id VARCHAR(64) NOT NULL,
+    user_id VARCHAR(64) NOT NULL,
+    reason VARCHAR(30) NOT NULL,
+    amount DECIMAL(12,2) NOT NULL CHECK (amount > 0),
+    currency VARCHAR(3) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    auto_approved BOOLEAN NOT NULL DEFAULT FALSE,
+    source VARCHAR(100),
+    details TEXT,
+    reviewed_by VARCHAR(100),
+    notes TEXT,
+    failure_reason TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE IF NOT EXISTS refund_audit_log (
+    id VARCHAR(64) PRIMARY KEY,
+    case_id VARCHAR(64) NOT NULL REFERENCES refund_cases(id),
+    action VARCHAR(50) NOT NULL,
+    actor VARCHAR(100) NOT NULL,
+    details TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_refund_cases_trip ON refund_cases(trip_id);
+CREATE INDEX IF NOT EXISTS idx_refund_cases_status ON refund_cases(status, created_at);
+CREATE INDEX IF NOT EXISTS idx_refund_audit_log_case ON refund_audit_log(case_id, created_at);
@@ -0,0 +1,20 @@
+-- Card, bank and wallet metadata and the details blob are stored encrypted
+CREATE TABLE IF NOT EXISTS payment_methods (
+    id VARCHAR(64) PRIMARY KEY,
+    user_id VARCHAR(64) NOT NULL,
+    type VARCHAR(30) NOT NULL,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    fingerprint VARCHAR(128),
+    expiry_date TIMESTAMP WITH TIME ZONE,
+    last_four_digits TEXT,
+    bank_name TEXT,
+    wallet_provider TEXT,
+    details TEXT,
+    card_token TEXT,
+    token_vault VARCHAR(50),
+    card_brand VARCHAR(30),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_methods_user_id ON payment_methods(user_id);
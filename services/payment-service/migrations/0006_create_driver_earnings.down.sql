@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_payments_driver_id;
+DROP TABLE IF EXISTS driver_earnings;
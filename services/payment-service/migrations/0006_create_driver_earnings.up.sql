@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS driver_earnings (
+    id VARCHAR(64) PRIMARY KEY,
+    driver_id VARCHAR(64) NOT NULL,
+    trip_id VARCHAR(64),
+    type VARCHAR(20) NOT NULL,
+    amount DECIMAL(12,2) NOT NULL CHECK (amount > 0),
+    currency VARCHAR(3) NOT NULL,
+    description TEXT,
+    earned_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_driver_earnings_driver ON driver_earnings(driver_id, earned_at);
+CREATE INDEX IF NOT EXISTS idx_payments_driver_id ON payments(driver_id, created_at);
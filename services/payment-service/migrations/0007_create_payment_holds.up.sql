@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS payment_holds (
+    id VARCHAR(64) PRIMARY KEY,
+    trip_id VARCHAR(64) NOT NULL,
+    user_id VARCHAR(64) NOT NULL,
+    driver_id VARCHAR(64),
+    payment_method_id VARCHAR(64) NOT NULL,
+    payment_method VARCHAR(30) NOT NULL,
+    estimated_fare DECIMAL(12,2) NOT NULL CHECK (estimated_fare > 0),
+    amount DECIMAL(12,2) NOT NULL CHECK (amount > 0),
+    currency VARCHAR(3) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    authorization_id VARCHAR(100),
+    renewals INTEGER NOT NULL DEFAULT 0,
+    captured_amount DECIMAL(12,2) NOT NULL DEFAULT 0,
+    released_amount DECIMAL(12,2) NOT NULL DEFAULT 0,
+    payment_id VARCHAR(64),
+    failure_reason TEXT,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    closed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_holds_trip ON payment_holds(trip_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_payment_holds_expiry ON payment_holds(status, expires_at);
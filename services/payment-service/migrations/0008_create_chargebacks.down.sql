@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS chargeback_evidence;
+DROP TABLE IF EXISTS chargebacks;
@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS chargebacks (
+    id VARCHAR(64) PRIMARY KEY,
+    provider_dispute_id VARCHAR(100) NOT NULL UNIQUE,
+    payment_id VARCHAR(64) NOT NULL,
+    trip_id VARCHAR(64),
+    user_id VARCHAR(64),
+    driver_id VARCHAR(64),
+    amount DECIMAL(12,2) NOT NULL CHECK (amount > 0),
+    currency VARCHAR(3) NOT NULL,
+    reason VARCHAR(50),
+    status VARCHAR(20) NOT NULL,
+    evidence_due_by TIMESTAMP WITH TIME ZONE,
+    notes TEXT,
+    resolved_by VARCHAR(100),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    resolved_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE IF NOT EXISTS chargeback_evidence (
+    id VARCHAR(64) PRIMARY KEY,
+    chargeback_id VARCHAR(64) NOT NULL REFERENCES chargebacks(id),
+    type VARCHAR(30) NOT NULL,
+    description TEXT NOT NULL,
+    submitted_by VARCHAR(100) NOT NULL,
+    submitted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_chargebacks_status ON chargebacks(status, created_at);
+CREATE INDEX IF NOT EXISTS idx_chargebacks_driver ON chargebacks(driver_id, status);
+CREATE INDEX IF NOT EXISTS idx_chargeback_evidence_chargeback ON chargeback_evidence(chargeback_id, submitted_at);
@@ -0,0 +1,48 @@
+-- General ledger: every money movement is a journal entry whose postings sum
+-- to zero in each currency. Amounts are in minor units, debits positive.
+CREATE TABLE IF NOT EXISTS ledger_accounts (
+    code VARCHAR(150) PRIMARY KEY,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('asset', 'liability', 'equity', 'revenue', 'expense')),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS journal_entries (
+    id VARCHAR(64) PRIMARY KEY,
+    kind VARCHAR(30) NOT NULL,
+    idempotency_key VARCHAR(150) NOT NULL UNIQUE,
+    reference VARCHAR(64),
+    description TEXT,
+    posted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS journal_postings (
+    id VARCHAR(64) PRIMARY KEY,
+    journal_entry_id VARCHAR(64) NOT NULL REFERENCES journal_entries(id),
+    account VARCHAR(150) NOT NULL REFERENCES ledger_accounts(code),
+    amount BIGINT NOT NULL CHECK (amount <> 0),
+    currency VARCHAR(3) NOT NULL,
+    posted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_journal_postings_account ON journal_postings(account, posted_at);
+CREATE INDEX IF NOT EXISTS idx_journal_postings_entry ON journal_postings(journal_entry_id);
+CREATE INDEX IF NOT EXISTS idx_journal_postings_export ON journal_postings(posted_at, id);
+
+-- Checked when the posting transaction commits, once all of an entry's
+-- postings are in
+CREATE OR REPLACE FUNCTION check_journal_entry_balanced() RETURNS TRIGGER AS $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM journal_postings WHERE journal_entry_id = NEW.journal_entry_id
+        GROUP BY currency HAVING SUM(amount) <> 0
+    ) THEN
+        RAISE EXCEPTION 'journal entry % does not balance', NEW.journal_entry_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER journal_entry_balanced
+    AFTER INSERT OR UPDATE ON journal_postings
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE FUNCTION check_journal_entry_balanced();
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_driver_invoices_driver;
+DROP INDEX IF EXISTS idx_driver_invoices_issued;
+DROP TABLE IF EXISTS driver_invoices;
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS driver_invoices (
+    id VARCHAR(64) PRIMARY KEY,
+    number VARCHAR(100) NOT NULL UNIQUE,
+    driver_id VARCHAR(64) NOT NULL,
+    period CHAR(7) NOT NULL,
+    timezone VARCHAR(64) NOT NULL,
+    locale VARCHAR(35) NOT NULL,
+    revision INTEGER NOT NULL CHECK (revision > 0),
+    status VARCHAR(20) NOT NULL,
+    commission_rate DECIMAL(5,4) NOT NULL,
+    trips_completed INTEGER NOT NULL,
+    totals JSONB NOT NULL,
+    correction_reason TEXT,
+    supersedes_id VARCHAR(64) REFERENCES driver_invoices(id),
+    generated_by VARCHAR(100) NOT NULL,
+    generated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    superseded_at TIMESTAMP WITH TIME ZONE,
+    pdf BYTEA NOT NULL,
+    UNIQUE (driver_id, period, revision)
+);
+
+-- A month has one invoice in force per driver
+CREATE UNIQUE INDEX IF NOT EXISTS idx_driver_invoices_issued ON driver_invoices(driver_id, period) WHERE status = 'issued';
+CREATE INDEX IF NOT EXISTS idx_driver_invoices_driver ON driver_invoices(driver_id, period DESC, revision DESC);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_ledger_outbox_due;
+DROP TABLE IF EXISTS ledger_outbox;
@@ -0,0 +1,12 @@
+-- Journal entries that failed to post, retried until the ledger takes them.
+-- Entries are keyed like journal_entries, so an event is queued at most once.
+CREATE TABLE IF NOT EXISTS ledger_outbox (
+    idempotency_key VARCHAR(150) PRIMARY KEY,
+    entry JSONB NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_outbox_due ON ledger_outbox(next_attempt_at);
@@ -0,0 +1,49 @@
+// Command migrate applies and rolls back the pricing-service database schema.
+//
+//	migrate up | down [N] | version | status
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+
+	"pricing-service/internal/config"
+	"pricing-service/migrations"
+
+	_ "github.com/lib/pq"
+	"github.com/rideshare-platform/shared/database"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if cfg.DatabaseURL == "" {
+		return fmt.Errorf("DATABASE_URL is required")
+	}
+	appLogger := logger.NewLogger(cfg.Dynamic.LogLevel, cfg.Environment)
+
+	db, err := sql.Open("postgres", cfg.DatabaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	schema, err := migrations.Load()
+	if err != nil {
+		return err
+	}
+	migrator := database.NewMigrator(db, "pricing-service", schema, appLogger)
+	return database.RunMigrateCommand(context.Background(), migrator, args, os.Stdout)
+}
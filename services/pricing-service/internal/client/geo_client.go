@@ -0,0 +1,111 @@
+package client
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"pricing-service/internal/service"
+
+	"github.com/rideshare-platform/shared/models"
+	geopb "github.com/rideshare-platform/shared/proto/geo"
+)
+
+// GRPCZoneClient looks up pickup zones through geo-service's gRPC API
+type GRPCZoneClient struct {
+	client geopb.GeospatialServiceClient
+}
+
+// NewGRPCZoneClient creates a new zone client
+func NewGRPCZoneClient(conn grpc.ClientConnInterface) *GRPCZoneClient {
+	return &GRPCZoneClient{client: geopb.NewGeospatialServiceClient(conn)}
+}
+
+// ZoneSurcharge returns the zone with the highest surcharge containing the
+// location, and the types of all zones containing it
+func (c *GRPCZoneClient) ZoneSurcharge(ctx context.Context, location *models.Location) (*service.ZoneSurcharge, error) {
+	resp, err := c.client.FindZones(ctx, &geopb.FindZonesRequest{
+		Location: &geopb.Location{Latitude: location.Latitude, Longitude: location.Longitude},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Zones) == 0 {
+		return nil, nil
+	}
+
+	surcharge := &service.ZoneSurcharge{}
+	for _, zone := range resp.Zones {
+		surcharge.ZoneTypes = append(surcharge.ZoneTypes, zone.Type)
+		if zone.Surcharge > 0 && zone.Surcharge > surcharge.Amount {
+			surcharge.ZoneID = zone.Id
+			surcharge.ZoneName = zone.Name
+			surcharge.ZoneType = zone.Type
+			surcharge.Amount = zone.Surcharge
+		}
+	}
+	return surcharge, nil
+}
+
+// pickupSearchRadiusKm bounds how far from a pickup drivers are looked for
+const pickupSearchRadiusKm = 10
+
+// GRPCPickupETAClient estimates pickup ETAs through geo-service's gRPC API
+type GRPCPickupETAClient struct {
+	client geopb.GeospatialServiceClient
+}
+
+// NewGRPCPickupETAClient creates a new pickup ETA client
+func NewGRPCPickupETAClient(conn grpc.ClientConnInterface) *GRPCPickupETAClient {
+	return &GRPCPickupETAClient{client: geopb.NewGeospatialServiceClient(conn)}
+}
+
+// PickupETA returns the traffic-aware travel time of the nearest available
+// driver of the vehicle type to the pickup. WAV drivers are found by their
+// vehicle's wheelchair access rather than its type.
+func (c *GRPCPickupETAClient) PickupETA(ctx context.Context, pickup *models.Location, cityID, vehicleType string) (int, error) {
+	center := &geopb.Location{Latitude: pickup.Latitude, Longitude: pickup.Longitude}
+	search := &geopb.NearbyDriversRequest{
+		Center:        center,
+		RadiusKm:      pickupSearchRadiusKm,
+		Limit:         1,
+		VehicleTypes:  []string{vehicleType},
+		OnlyAvailable: true,
+		CityId:        cityID,
+	}
+	wav := vehicleType == models.RideTypeWAV
+	if wav {
+		search.VehicleTypes = nil
+		search.Limit = 20
+	}
+	resp, err := c.client.FindNearbyDrivers(ctx, search)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, driver := range resp.Drivers {
+		if driver.Location == nil || (wav && !hasFeature(driver.VehicleFeatures, string(models.VehicleFeatureWheelchairAccessible))) {
+			continue
+		}
+		eta, err := c.client.CalculateETA(ctx, &geopb.ETARequest{
+			Origin:         &geopb.Location{Latitude: driver.Location.Latitude, Longitude: driver.Location.Longitude},
+			Destination:    center,
+			VehicleType:    driver.VehicleType,
+			IncludeTraffic: true,
+		})
+		if err != nil {
+			return 0, err
+		}
+		return int(eta.DurationSeconds), nil
+	}
+	return 0, service.ErrNoDriverNearby
+}
+
+func hasFeature(features []string, feature string) bool {
+	for _, f := range features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
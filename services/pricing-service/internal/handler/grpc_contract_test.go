@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"testing"
+
+	"google.golang.org/grpc"
+
+	"pricing-service/internal/service"
+
+	"github.com/rideshare-platform/shared/clients/contract"
+	"github.com/rideshare-platform/shared/clients/pricingclient"
+	"github.com/rideshare-platform/shared/logger"
+	pricingpb "github.com/rideshare-platform/shared/proto/pricing"
+)
+
+func TestGRPCPricingHandler_ServesContract(t *testing.T) {
+	grpcHandler := NewGRPCPricingHandler(service.NewAdvancedPricingService(nil), logger.NewLogger("error", "test"))
+
+	conn := contract.Serve(t, func(server *grpc.Server) {
+		pricingpb.RegisterPricingServiceServer(server, grpcHandler)
+	})
+	contract.Check(t, pricingclient.ContractCalls(conn),
+		// Not served over gRPC yet; surge is read and updated over HTTP
+		"GetMultipleEstimates",
+		"GetSurgePricing",
+		"UpdateSurgePricing",
+		"GetVehicleTypes",
+		"GetPricingStats",
+		"SubscribeToPricingUpdates",
+	)
+}
@@ -0,0 +1,304 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"pricing-service/internal/service"
+
+	"github.com/rideshare-platform/shared/currency"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+	pricingpb "github.com/rideshare-platform/shared/proto/pricing"
+)
+
+// GRPCPricingHandler handles gRPC requests for pricing service
+type GRPCPricingHandler struct {
+	pricingpb.UnimplementedPricingServiceServer
+	pricingService *service.AdvancedPricingService
+	logger         *logger.Logger
+}
+
+// NewGRPCPricingHandler creates a new gRPC pricing handler
+func NewGRPCPricingHandler(pricingService *service.AdvancedPricingService, logger *logger.Logger) *GRPCPricingHandler {
+	return &GRPCPricingHandler{
+		pricingService: pricingService,
+		logger:         logger,
+	}
+}
+
+// SplitSharedFare splits a shared route's fare between its riders
+func (h *GRPCPricingHandler) SplitSharedFare(ctx context.Context, req *pricingpb.SplitSharedFareRequest) (*pricingpb.SplitSharedFareResponse, error) {
+	if len(req.Riders) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "at least one rider is required")
+	}
+
+	request := &service.SharedFareRequest{
+		VehicleType:          req.VehicleType,
+		PickupArea:           req.PickupArea,
+		RouteDistanceKm:      req.RouteDistanceKm,
+		RouteDurationMinutes: int(req.RouteDurationMinutes),
+		City:                 req.City,
+	}
+	for _, rider := range req.Riders {
+		request.Riders = append(request.Riders, &service.SharedFareRider{
+			RiderID:         rider.RiderId,
+			TripID:          rider.TripId,
+			DistanceKm:      rider.DistanceKm,
+			DurationMinutes: int(rider.DurationMinutes),
+		})
+	}
+
+	response, err := h.pricingService.SplitSharedFare(ctx, request)
+	if err != nil {
+		h.logger.WithContext(ctx).WithError(err).Warn("Failed to split shared fare")
+		return nil, status.Errorf(codes.InvalidArgument, "failed to split shared fare: %v", err)
+	}
+
+	shares := make([]*pricingpb.SharedFareShare, 0, len(response.Shares))
+	for _, share := range response.Shares {
+		shares = append(shares, &pricingpb.SharedFareShare{
+			RiderId:    share.RiderID,
+			TripId:     share.TripID,
+			SoloFare:   share.SoloFare,
+			SharedFare: share.SharedFare,
+			Savings:    share.Savings,
+		})
+	}
+
+	return &pricingpb.SplitSharedFareResponse{
+		Shares:    shares,
+		RouteFare: response.RouteFare,
+		TotalFare: response.TotalFare,
+		Currency:  response.Currency,
+		Success:   true,
+		Message:   "Shared fare split successfully",
+	}, nil
+}
+
+// CalculateFinalFare prices a completed trip from its actual distance and duration
+func (h *GRPCPricingHandler) CalculateFinalFare(ctx context.Context, req *pricingpb.CalculateFinalFareRequest) (*pricingpb.CalculateFinalFareResponse, error) {
+	if req.TripId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "trip ID is required")
+	}
+	if req.ActualDistanceKm < 0 || req.ActualDurationMinutes < 0 || req.WaitingTimeSeconds < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "distance, duration and waiting time must not be negative")
+	}
+	if req.LockedSurgeMultiplier < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "locked surge multiplier must not be negative")
+	}
+
+	requestTime := time.Now()
+	if req.TripStartTime != nil {
+		requestTime = req.TripStartTime.AsTime()
+	}
+	var pickup *models.Location
+	if req.ActualPickup != nil {
+		pickup = &models.Location{Latitude: req.ActualPickup.Latitude, Longitude: req.ActualPickup.Longitude}
+	}
+
+	request := &service.PricingRequest{
+		TripID:                req.TripId,
+		Distance:              req.ActualDistanceKm,
+		EstimatedTime:         int(req.ActualDurationMinutes) * 60,
+		VehicleType:           req.VehicleType,
+		PickupArea:            req.PickupArea,
+		RequestTime:           requestTime.Unix(),
+		RiderID:               req.RiderId,
+		PickupLocation:        pickup,
+		LockedSurgeMultiplier: req.LockedSurgeMultiplier,
+		WaitingTime:           int(req.WaitingTimeSeconds),
+		City:                  req.City,
+		Currency:              req.Currency,
+		QuoteID:               req.QuoteId,
+	}
+	response, err := h.pricingService.CalculatePrice(ctx, request)
+	if errors.Is(err, currency.ErrMismatch) || errors.Is(err, currency.ErrUnsupported) {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to calculate final fare: %v", err)
+	}
+	if err := priceLockError(err); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		h.logger.WithContext(ctx).WithError(err).Warn("Failed to calculate final fare")
+		return nil, status.Errorf(codes.Internal, "failed to calculate final fare: %v", err)
+	}
+
+	// The fare stands even if it cannot be recorded, the rider must not be
+	// left without one because the history database is down
+	if err := h.pricingService.RecordPricing(ctx, request, response); err != nil {
+		h.logger.WithContext(ctx).WithError(err).Warn("Failed to record final fare in pricing history")
+	}
+
+	var adjustments []*pricingpb.FareAdjustment
+	if response.WaitingFare > 0 {
+		adjustments = append(adjustments, &pricingpb.FareAdjustment{
+			Type:        "wait_time",
+			Amount:      response.WaitingFare,
+			Description: fmt.Sprintf("Waited %d minutes at pickup", req.WaitingTimeSeconds/60),
+			Reason:      "pickup_wait",
+		})
+	}
+	if response.ZoneSurcharge > 0 {
+		adjustments = append(adjustments, &pricingpb.FareAdjustment{
+			Type:        "zone_surcharge",
+			Amount:      response.ZoneSurcharge,
+			Description: fmt.Sprintf("Pickup surcharge for %s", response.SurchargeZone),
+			Reason:      "pickup_zone",
+		})
+	}
+
+	finalFare := priceEstimateToProto(response, req.ActualDistanceKm, req.ActualDurationMinutes)
+	finalFare.Breakdown.WaitingFare = response.WaitingFare
+	finalFare.Breakdown.WaitingTimeSeconds = req.WaitingTimeSeconds
+
+	return &pricingpb.CalculateFinalFareResponse{
+		FinalFare:   finalFare,
+		Adjustments: adjustments,
+		Success:     true,
+		Message:     "Final fare calculated successfully",
+	}, nil
+}
+
+// GetPriceEstimate quotes a trip before it starts from its estimated distance
+// and duration, at the vehicle type's rates and the current surge
+func (h *GRPCPricingHandler) GetPriceEstimate(ctx context.Context, req *pricingpb.GetPriceEstimateRequest) (*pricingpb.GetPriceEstimateResponse, error) {
+	if req.DistanceKm < 0 || req.DurationSeconds < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "distance and duration must not be negative")
+	}
+
+	var pickup, destination *models.Location
+	if req.PickupLocation != nil {
+		pickup = &models.Location{Latitude: req.PickupLocation.Latitude, Longitude: req.PickupLocation.Longitude}
+	}
+	if req.Destination != nil {
+		destination = &models.Location{Latitude: req.Destination.Latitude, Longitude: req.Destination.Longitude}
+	}
+	distanceKm := req.DistanceKm
+	if distanceKm == 0 && pickup != nil && destination != nil {
+		distanceKm = pickup.DistanceTo(destination)
+	}
+	requestTime := time.Now()
+	if req.DepartureTime != nil {
+		requestTime = req.DepartureTime.AsTime()
+	}
+
+	response, err := h.pricingService.EstimateQuote(ctx, &service.PricingRequest{
+		TripID:              req.TripId,
+		Distance:            distanceKm,
+		EstimatedTime:       int(req.DurationSeconds),
+		VehicleType:         req.VehicleType,
+		PickupArea:          req.PickupArea,
+		RequestTime:         requestTime.Unix(),
+		RiderID:             req.RiderId,
+		PickupLocation:      pickup,
+		City:                req.City,
+		DestinationLocation: destination,
+		QuoteID:             req.QuoteId,
+	})
+	if err := priceLockError(err); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to estimate price: %v", err)
+	}
+
+	return &pricingpb.GetPriceEstimateResponse{
+		Estimate: priceEstimateToProto(response, distanceKm, (req.DurationSeconds+59)/60),
+		Success:  true,
+		Message:  "Price estimated successfully",
+	}, nil
+}
+
+// ListSurgeAreas returns the areas currently surging, highest multiplier first
+func (h *GRPCPricingHandler) ListSurgeAreas(ctx context.Context, req *pricingpb.ListSurgeAreasRequest) (*pricingpb.ListSurgeAreasResponse, error) {
+	areas, err := h.pricingService.ListSurgeAreas(ctx, req.CityId)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to list surge areas: %v", err)
+	}
+
+	resp := &pricingpb.ListSurgeAreasResponse{}
+	for _, area := range areas {
+		if area.Multiplier < req.MinMultiplier {
+			continue
+		}
+		resp.Areas = append(resp.Areas, &pricingpb.AreaSurge{
+			Area:             area.Area,
+			CityId:           area.CityID,
+			Multiplier:       area.Multiplier,
+			DemandLevel:      area.DemandLevel,
+			ActiveRequests:   int32(area.ActiveRequests),
+			AvailableDrivers: int32(area.AvailableDrivers),
+			UpdatedAt:        timestamppb.New(area.UpdatedAt),
+			ExpiresAt:        timestamppb.New(area.ExpiresAt),
+		})
+	}
+	return resp, nil
+}
+
+// priceLockError maps price lock errors to gRPC status codes, returning nil
+// for any other error
+func priceLockError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrPriceLockNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrPriceLockExpired):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrPriceLockMismatch):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return nil
+	}
+}
+
+func priceEstimateToProto(response *service.PricingResponse, distanceKm float64, durationMinutes int32) *pricingpb.PriceEstimate {
+	discounts := make([]*pricingpb.AppliedDiscount, 0, len(response.AppliedDiscounts))
+	for _, discount := range response.AppliedDiscounts {
+		discounts = append(discounts, &pricingpb.AppliedDiscount{
+			Name:        discount.Code,
+			Type:        discount.Type,
+			AmountSaved: discount.Amount,
+			Description: discount.Description,
+		})
+	}
+
+	var taxLines []*pricingpb.TaxLine
+	for _, line := range response.FareBreakdown.TaxLines {
+		taxLines = append(taxLines, &pricingpb.TaxLine{
+			Name:   line.Name,
+			Type:   line.Type,
+			Rate:   line.Rate,
+			Amount: line.Amount,
+		})
+	}
+
+	return &pricingpb.PriceEstimate{
+		Id:              response.TripID,
+		BaseFare:        response.BaseFare,
+		DistanceFare:    response.DistanceFare,
+		TimeFare:        response.TimeFare,
+		SurgeMultiplier: response.SurgeMultiplier,
+		SurgeAmount:     response.SurgeFare,
+		DiscountAmount:  response.DiscountAmount,
+		TotalAmount:     response.TotalFare,
+		Currency:        response.Currency,
+		Breakdown: &pricingpb.PricingBreakdown{
+			BaseRate:        response.FareBreakdown.BaseRate,
+			PerKmRate:       response.FareBreakdown.DistanceRate,
+			PerMinuteRate:   response.FareBreakdown.TimeRate,
+			DistanceKm:      distanceKm,
+			DurationMinutes: durationMinutes,
+			Discounts:       discounts,
+			Taxes:           response.TaxAmount,
+			TaxLines:        taxLines,
+		},
+		ValidUntil: timestamppb.New(response.ValidUntil),
+		QuoteId:    response.QuoteID,
+	}
+}
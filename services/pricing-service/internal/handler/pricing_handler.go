@@ -1,12 +1,19 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"pricing-service/internal/service"
 
 	"github.com/gin-gonic/gin"
+
+	"github.com/rideshare-platform/shared/analytics"
+	"github.com/rideshare-platform/shared/currency"
+	"github.com/rideshare-platform/shared/experiments"
 )
 
 // PricingHandler handles HTTP requests for pricing operations
@@ -55,6 +62,13 @@ func (h *PricingHandler) CalculatePrice(c *gin.Context) {
 	}
 
 	response, err := h.pricingService.CalculatePrice(c.Request.Context(), &request)
+	if errors.Is(err, currency.ErrMismatch) || errors.Is(err, currency.ErrUnsupported) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_currency",
+			"message": err.Error(),
+		})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "calculation_failed",
@@ -66,6 +80,58 @@ func (h *PricingHandler) CalculatePrice(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// CompareFares quotes a trip in every vehicle type side by side, cheapest
+// first, with each type's pickup ETA. The request's vehicle type is ignored.
+func (h *PricingHandler) CompareFares(c *gin.Context) {
+	var request service.PricingRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	if request.RequestTime == 0 {
+		request.RequestTime = time.Now().Unix()
+	}
+	if request.Distance == 0 && request.PickupLocation != nil && request.DestinationLocation != nil {
+		request.Distance = request.PickupLocation.DistanceTo(request.DestinationLocation)
+	}
+	if request.Distance <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_distance",
+			"message": "Distance, or pickup and destination locations, are required",
+		})
+		return
+	}
+	if request.EstimatedTime <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_time",
+			"message": "Estimated time must be greater than 0",
+		})
+		return
+	}
+
+	comparison, err := h.pricingService.CompareFares(c.Request.Context(), &request)
+	if errors.Is(err, currency.ErrMismatch) || errors.Is(err, currency.ErrUnsupported) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_currency",
+			"message": err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "comparison_failed",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, comparison)
+}
+
 // GetSurgeMultiplier handles surge multiplier requests
 func (h *PricingHandler) GetSurgeMultiplier(c *gin.Context) {
 	area := c.Param("area")
@@ -77,7 +143,8 @@ func (h *PricingHandler) GetSurgeMultiplier(c *gin.Context) {
 		return
 	}
 
-	multiplier, err := h.pricingService.GetSurgeMultiplier(c.Request.Context(), area)
+	cityID := c.Query("city_id")
+	multiplier, err := h.pricingService.GetSurgeMultiplier(c.Request.Context(), cityID, area)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "surge_lookup_failed",
@@ -88,6 +155,7 @@ func (h *PricingHandler) GetSurgeMultiplier(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{
 		"area":             area,
+		"city_id":          cityID,
 		"surge_multiplier": multiplier,
 		"surge_active":     multiplier > 1.0,
 		"timestamp":        time.Now().Format(time.RFC3339),
@@ -98,6 +166,7 @@ func (h *PricingHandler) GetSurgeMultiplier(c *gin.Context) {
 func (h *PricingHandler) UpdateSurgeMultiplier(c *gin.Context) {
 	var request struct {
 		Area             string  `json:"area" binding:"required"`
+		CityID           string  `json:"city_id"`
 		Multiplier       float64 `json:"multiplier" binding:"required"`
 		ActiveRequests   int     `json:"active_requests"`
 		AvailableDrivers int     `json:"available_drivers"`
@@ -122,6 +191,7 @@ func (h *PricingHandler) UpdateSurgeMultiplier(c *gin.Context) {
 
 	err := h.pricingService.UpdateSurgeMultiplier(
 		c.Request.Context(),
+		request.CityID,
 		request.Area,
 		request.Multiplier,
 		request.ActiveRequests,
@@ -139,6 +209,7 @@ func (h *PricingHandler) UpdateSurgeMultiplier(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
 		"message":          "Surge multiplier updated successfully",
 		"area":             request.Area,
+		"city_id":          request.CityID,
 		"surge_multiplier": request.Multiplier,
 		"updated_at":       time.Now().Format(time.RFC3339),
 	})
@@ -174,7 +245,7 @@ func (h *PricingHandler) ApplyDiscount(c *gin.Context) {
 	})
 }
 
-// GetPricingHistory handles pricing history requests
+// GetPricingHistory returns the recorded final pricing calculations for a trip
 func (h *PricingHandler) GetPricingHistory(c *gin.Context) {
 	tripID := c.Param("trip_id")
 	if tripID == "" {
@@ -184,41 +255,133 @@ func (h *PricingHandler) GetPricingHistory(c *gin.Context) {
 		})
 		return
 	}
+	h.respondHistory(c, tripID)
+}
 
-	// For now, return mock pricing history
-	// In a real implementation, this would query the database
-	c.JSON(http.StatusOK, gin.H{
-		"trip_id": tripID,
-		"history": []gin.H{
-			{
-				"timestamp":        time.Now().Add(-30 * time.Minute).Format(time.RFC3339),
-				"action":           "price_calculated",
-				"base_fare":        3.50,
-				"distance_fare":    8.40,
-				"time_fare":        2.25,
-				"surge_multiplier": 1.0,
-				"total_fare":       14.15,
-			},
-			{
-				"timestamp":        time.Now().Add(-25 * time.Minute).Format(time.RFC3339),
-				"action":           "surge_applied",
-				"surge_multiplier": 1.5,
-				"total_fare":       21.23,
-			},
-			{
-				"timestamp":       time.Now().Add(-20 * time.Minute).Format(time.RFC3339),
-				"action":          "discount_applied",
-				"discount_type":   "first_ride",
-				"discount_amount": 4.25,
-				"total_fare":      16.98,
-			},
-		},
-	})
+// ListPricingHistory returns recorded final pricing calculations, filtered by
+// rider_id, area and a from/to date range and paged with limit and offset
+func (h *PricingHandler) ListPricingHistory(c *gin.Context) {
+	h.respondHistory(c, "")
+}
+
+func (h *PricingHandler) respondHistory(c *gin.Context, tripID string) {
+	filter, err := historyFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": err.Error(),
+		})
+		return
+	}
+	filter.TripID = tripID
+
+	page, err := h.pricingService.GetPricingHistory(c.Request.Context(), filter)
+	if errors.Is(err, service.ErrInvalidHistoryQuery) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "history_failed",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
 }
 
-// GetPricingAnalytics handles pricing analytics requests
+// historyFilter reads the history query parameters. Dates are RFC 3339
+// timestamps or plain YYYY-MM-DD dates, a plain to date covers the whole day.
+func historyFilter(c *gin.Context) (service.HistoryFilter, error) {
+	filter := service.HistoryFilter{
+		RiderID: c.Query("rider_id"),
+		Area:    c.Query("area"),
+	}
+
+	var err error
+	if value := c.Query("limit"); value != "" {
+		if filter.Limit, err = strconv.Atoi(value); err != nil {
+			return filter, fmt.Errorf("limit must be a number")
+		}
+	}
+	if value := c.Query("offset"); value != "" {
+		if filter.Offset, err = strconv.Atoi(value); err != nil {
+			return filter, fmt.Errorf("offset must be a number")
+		}
+	}
+	if value := c.Query("from"); value != "" {
+		if filter.From, _, err = parseHistoryDate(value); err != nil {
+			return filter, fmt.Errorf("from must be an RFC 3339 timestamp or YYYY-MM-DD date")
+		}
+	}
+	if value := c.Query("to"); value != "" {
+		var dateOnly bool
+		if filter.To, dateOnly, err = parseHistoryDate(value); err != nil {
+			return filter, fmt.Errorf("to must be an RFC 3339 timestamp or YYYY-MM-DD date")
+		}
+		if dateOnly {
+			filter.To = filter.To.Add(24*time.Hour - time.Nanosecond)
+		}
+	}
+	return filter, nil
+}
+
+func parseHistoryDate(value string) (time.Time, bool, error) {
+	if date, err := time.Parse(time.DateOnly, value); err == nil {
+		return date, true, nil
+	}
+	timestamp, err := time.Parse(time.RFC3339, value)
+	return timestamp, false, err
+}
+
+// GetPricingAnalytics summarises the final fares calculated between the from
+// and to query parameters, by default over the last 24 hours
 func (h *PricingHandler) GetPricingAnalytics(c *gin.Context) {
-	analytics, err := h.pricingService.GetPricingAnalytics(c.Request.Context())
+	tr, err := analytics.ParseTimeRange(c.Query("from"), c.Query("to"), time.Now())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	summary, err := h.pricingService.GetPricingAnalytics(c.Request.Context(), tr)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "analytics_failed",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
+
+// GetExperimentFares compares the final fares of an experiment's variants
+// between the from and to query parameters, by default over the last 24 hours
+func (h *PricingHandler) GetExperimentFares(c *gin.Context) {
+	tr, err := analytics.ParseTimeRange(c.Query("from"), c.Query("to"), time.Now())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	fares, err := h.pricingService.GetExperimentFares(c.Request.Context(), c.Param("name"), tr)
+	if errors.Is(err, experiments.ErrUnknownExperiment) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "experiment_not_found",
+			"message": err.Error(),
+		})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error":   "analytics_failed",
@@ -227,7 +390,7 @@ func (h *PricingHandler) GetPricingAnalytics(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, analytics)
+	c.JSON(http.StatusOK, fares)
 }
 
 // ValidatePrice handles price validation requests
@@ -267,3 +430,26 @@ func (h *PricingHandler) ValidatePrice(c *gin.Context) {
 		"validated_at":  time.Now().Format(time.RFC3339),
 	})
 }
+
+// SplitSharedFare handles fare split requests for shared rides
+func (h *PricingHandler) SplitSharedFare(c *gin.Context) {
+	var request service.SharedFareRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_request",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	response, err := h.pricingService.SplitSharedFare(c.Request.Context(), &request)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "split_failed",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
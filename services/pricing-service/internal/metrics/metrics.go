@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// Quote cache
+	quoteCacheRequestsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "pricing_service_quote_cache_requests_total",
+			Help: "Total number of quotes looked up in the quote cache, by result (hit, miss)",
+		},
+		[]string{"result"},
+	)
+
+	quoteCacheInvalidationsTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "pricing_service_quote_cache_invalidations_total",
+			Help: "Total number of cached quotes dropped because the surge of their area changed",
+		},
+	)
+)
+
+// RecordQuoteCacheHit increments the quote cache hit counter
+func RecordQuoteCacheHit() {
+	quoteCacheRequestsTotal.WithLabelValues("hit").Inc()
+}
+
+// RecordQuoteCacheMiss increments the quote cache miss counter
+func RecordQuoteCacheMiss() {
+	quoteCacheRequestsTotal.WithLabelValues("miss").Inc()
+}
+
+// RecordQuotesInvalidated adds the number of cached quotes dropped on a surge change
+func RecordQuotesInvalidated(count int) {
+	quoteCacheInvalidationsTotal.Add(float64(count))
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/rideshare-platform/shared/city"
+)
+
+// RateCards holds each city's vehicle rates, keyed by city ID and vehicle
+// type. Vehicle types a city has no card for are charged the default rates.
+type RateCards map[string]map[string]*VehicleRates
+
+// rateCardsFile is the section of the cities file pricing-service reads:
+//
+//	rate_cards:
+//	  istanbul:
+//	    economy: {base_fare: 40, distance_rate: 18, time_rate: 3, minimum_fare: 90, maximum_fare: 2500}
+//	    wav: {base_fare: 40, distance_rate: 18, time_rate: 3, minimum_fare: 90, maximum_fare: 2500, free_wait_minutes: 6}
+type rateCardsFile struct {
+	RateCards RateCards `yaml:"rate_cards"`
+}
+
+// LoadRateCards reads the rate cards from the cities file at path. Every card
+// must belong to one of the cities.
+func LoadRateCards(path string, cities *city.Registry) (RateCards, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cities file: %w", err)
+	}
+
+	var file rateCardsFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse rate cards in %s: %w", path, err)
+	}
+
+	cards := make(RateCards, len(file.RateCards))
+	for id, rates := range file.RateCards {
+		cityID := city.Normalize(id)
+		if _, err := cities.Get(cityID); err != nil {
+			return nil, fmt.Errorf("rate card: %w", err)
+		}
+		for vehicleType, rate := range rates {
+			if rate == nil || rate.MinimumFare > rate.MaximumFare {
+				return nil, fmt.Errorf("rate card %s/%s: minimum_fare must not exceed maximum_fare", cityID, vehicleType)
+			}
+		}
+		cards[cityID] = rates
+	}
+	return cards, nil
+}
+
+// SetCities scopes surge areas to the configured cities and caps surge by
+// each city's policy
+func (s *AdvancedPricingService) SetCities(cities *city.Registry) {
+	s.cities = cities
+}
+
+// SetRateCards sets the vehicle rates charged in each city
+func (s *AdvancedPricingService) SetRateCards(cards RateCards) {
+	s.rateCards = cards
+}
+
+// vehicleRatesFor returns the rates of a vehicle type in a city: the city's
+// rate card, or the default rates
+func (s *AdvancedPricingService) vehicleRatesFor(cityID, vehicleType string) (*VehicleRates, bool) {
+	if rates, exists := s.rateCards[city.Normalize(cityID)][vehicleType]; exists {
+		return rates, true
+	}
+	rates, exists := s.vehicleRates[vehicleType]
+	return rates, exists
+}
+
+// surgeKey is the Redis key of an area's surge. Areas outside every city keep
+// the unscoped key.
+func surgeKey(cityID, area string) string {
+	if cityID = city.Normalize(cityID); cityID != "" {
+		return fmt.Sprintf("surge:%s:%s", cityID, area)
+	}
+	return fmt.Sprintf("surge:%s", area)
+}
+
+// capSurge limits a multiplier to the city's maximum surge, or the
+// platform's for cities without one
+func (s *AdvancedPricingService) capSurge(cityID string, multiplier float64) float64 {
+	maxSurge := s.cities.Policies(cityID).MaxSurgeMultiplier
+	if maxSurge <= 0 {
+		maxSurge = s.surgeLimits.MaxMultiplier
+	}
+	if maxSurge > 0 && multiplier > maxSurge {
+		return maxSurge
+	}
+	return multiplier
+}
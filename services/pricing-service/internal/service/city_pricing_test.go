@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rideshare-platform/shared/city"
+)
+
+const testCitiesFile = `
+cities:
+  istanbul:
+    name: Istanbul
+    currency: TRY
+    center: {latitude: 41.0082, longitude: 28.9784}
+    radius_km: 60
+    policies:
+      max_surge_multiplier: 2
+rate_cards:
+  istanbul:
+    standard: {base_fare: 40, distance_rate: 18, time_rate: 3, minimum_fare: 90, maximum_fare: 2500}
+`
+
+func loadTestCities(t *testing.T, contents string) (*city.Registry, RateCards, error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "cities.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+
+	cities, err := city.Load(path)
+	require.NoError(t, err)
+	cards, err := LoadRateCards(path, cities)
+	return cities, cards, err
+}
+
+func TestCalculatePrice_ChargesCityRateCard(t *testing.T) {
+	ctx := context.Background()
+	cities, cards, err := loadTestCities(t, testCitiesFile)
+	require.NoError(t, err)
+
+	pricing := NewAdvancedPricingService(nil)
+	pricing.SetCities(cities)
+	pricing.SetRateCards(cards)
+
+	request := newFinalFareTestRequest()
+	request.City = "Istanbul"
+	inCity, err := pricing.CalculatePrice(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, 40.0, inCity.BaseFare)
+	assert.Equal(t, 180.0, inCity.DistanceFare)
+
+	request.VehicleType = "premium"
+	fallback, err := pricing.CalculatePrice(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, pricing.GetVehicleRates("premium").BaseFare, fallback.BaseFare, "vehicle types without a card use the default rates")
+}
+
+func TestLoadRateCards_RejectsUnknownCity(t *testing.T) {
+	_, _, err := loadTestCities(t, testCitiesFile+`
+  paris:
+    standard: {base_fare: 3, minimum_fare: 8, maximum_fare: 200}
+`)
+	assert.ErrorIs(t, err, city.ErrUnknownCity)
+}
+
+func TestSurgeIsScopedAndCappedByCity(t *testing.T) {
+	cities, _, err := loadTestCities(t, testCitiesFile)
+	require.NoError(t, err)
+	pricing := NewAdvancedPricingService(nil)
+	pricing.SetCities(cities)
+
+	assert.Equal(t, "surge:istanbul:airport", surgeKey("Istanbul", "airport"))
+	assert.Equal(t, "surge:airport", surgeKey("", "airport"))
+	assert.Equal(t, 2.0, pricing.capSurge("istanbul", 3.5))
+	assert.Equal(t, 3.5, pricing.capSurge("london", 3.5), "cities without a cap are not capped")
+}
@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/rideshare-platform/shared/city"
+	"github.com/rideshare-platform/shared/experiments"
+	"github.com/rideshare-platform/shared/models"
+)
+
+// ErrNoDriverNearby is returned by pickup ETA estimators when no available
+// driver of the vehicle type is near the pickup
+var ErrNoDriverNearby = errors.New("no driver nearby")
+
+// pickupETATimeout bounds how long a comparison waits for each vehicle
+// type's pickup ETA before leaving it out
+const pickupETATimeout = 2 * time.Second
+
+// PickupETAEstimator estimates how long the nearest available driver of a
+// vehicle type takes to reach a pickup
+type PickupETAEstimator interface {
+	PickupETA(ctx context.Context, pickup *models.Location, cityID, vehicleType string) (int, error)
+}
+
+// FareOption is one vehicle type's quote in a fare comparison
+type FareOption struct {
+	VehicleType string  `json:"vehicle_type"`
+	TotalFare   float64 `json:"total_fare"`
+	// PickupETASeconds is how long the nearest available driver of the type
+	// takes to reach the pickup, unset when none is nearby or it could not
+	// be estimated
+	PickupETASeconds *int             `json:"pickup_eta_seconds,omitempty"`
+	Quote            *PricingResponse `json:"quote"`
+}
+
+// FareComparison quotes a trip in every vehicle type the city offers,
+// cheapest first. Its quotes lock no surge; riders get a quote to book with
+// once they pick a vehicle type.
+type FareComparison struct {
+	Options         []*FareOption `json:"options"`
+	Currency        string        `json:"currency"`
+	SurgeMultiplier float64       `json:"surge_multiplier"`
+	ComparedAt      time.Time     `json:"compared_at"`
+}
+
+// ComparisonCache keeps fare comparisons for a short time
+type ComparisonCache interface {
+	// Get returns the comparison cached under key, or nil if there is none
+	Get(ctx context.Context, key string) (*FareComparison, error)
+	Set(ctx context.Context, key string, comparison *FareComparison) error
+}
+
+// SetPickupETAs sets the geo-service client fare comparisons estimate each
+// vehicle type's pickup ETA with
+func (s *AdvancedPricingService) SetPickupETAs(etas PickupETAEstimator) {
+	s.pickupETAs = etas
+}
+
+// SetComparisonCache caches fare comparisons by the geohash cells of their
+// pickup and destination, at the given precision
+func (s *AdvancedPricingService) SetComparisonCache(cache ComparisonCache, precision int) {
+	s.comparisons = cache
+	s.comparisonPrecision = precision
+}
+
+// CompareFares quotes a trip in every vehicle type offered in its city, with
+// the surge and zone surcharge looked up once for all of them, and the
+// pickup ETA of each type
+func (s *AdvancedPricingService) CompareFares(ctx context.Context, request *PricingRequest) (*FareComparison, error) {
+	if request.Distance < 0 || request.EstimatedTime < 0 {
+		return nil, fmt.Errorf("distance and estimated time cannot be negative")
+	}
+	fareCurrency, err := s.fareCurrency(request)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = s.assignExperiments(ctx, request)
+	key := s.comparisonKey(ctx, request)
+	if key != "" {
+		if comparison, err := s.comparisons.Get(ctx, key); err == nil && comparison != nil {
+			return comparison, nil
+		}
+	}
+
+	vehicleTypes := s.vehicleTypesFor(request.City)
+	etas := s.estimatePickups(ctx, request, vehicleTypes)
+	surgeMultiplier, _ := s.surgeMultiplier(ctx, request)
+	zoneSurcharge := s.zoneSurcharge(ctx, request)
+
+	comparison := &FareComparison{
+		Currency:        fareCurrency,
+		SurgeMultiplier: surgeMultiplier,
+		ComparedAt:      time.Now(),
+	}
+	for _, vehicleType := range vehicleTypes {
+		option := *request
+		option.TripID = ""
+		option.VehicleType = vehicleType
+		quote := s.priceFare(ctx, &option, s.fareAt(&option, surgeMultiplier, false, zoneSurcharge), fareCurrency)
+		comparison.Options = append(comparison.Options, &FareOption{
+			VehicleType:      vehicleType,
+			TotalFare:        quote.TotalFare,
+			PickupETASeconds: etas[vehicleType],
+			Quote:            quote,
+		})
+	}
+	sort.SliceStable(comparison.Options, func(i, j int) bool {
+		return comparison.Options[i].TotalFare < comparison.Options[j].TotalFare
+	})
+
+	if key != "" {
+		_ = s.comparisons.Set(ctx, key, comparison)
+	}
+	return comparison, nil
+}
+
+// vehicleTypesFor returns the vehicle types with rates in the city, by name
+func (s *AdvancedPricingService) vehicleTypesFor(cityID string) []string {
+	var vehicleTypes []string
+	for vehicleType := range s.vehicleRates {
+		vehicleTypes = append(vehicleTypes, vehicleType)
+	}
+	for vehicleType := range s.rateCards[city.Normalize(cityID)] {
+		if _, exists := s.vehicleRates[vehicleType]; !exists {
+			vehicleTypes = append(vehicleTypes, vehicleType)
+		}
+	}
+	sort.Strings(vehicleTypes)
+	return vehicleTypes
+}
+
+// estimatePickups estimates the pickup ETA of each vehicle type at once.
+// Types whose ETA cannot be estimated in time are left out.
+func (s *AdvancedPricingService) estimatePickups(ctx context.Context, request *PricingRequest, vehicleTypes []string) map[string]*int {
+	etas := make(map[string]*int, len(vehicleTypes))
+	if s.pickupETAs == nil || request.PickupLocation == nil {
+		return etas
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, pickupETATimeout)
+	defer cancel()
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	for _, vehicleType := range vehicleTypes {
+		wg.Add(1)
+		go func(vehicleType string) {
+			defer wg.Done()
+			seconds, err := s.pickupETAs.PickupETA(ctx, request.PickupLocation, request.City, vehicleType)
+			if err != nil {
+				return
+			}
+			mutex.Lock()
+			etas[vehicleType] = &seconds
+			mutex.Unlock()
+		}(vehicleType)
+	}
+	wg.Wait()
+	return etas
+}
+
+// comparisonKey keys a comparison like a quote, by the rider as well since
+// comparisons include their discounts. Comparisons without both locations,
+// or priced at a locked surge or with waiting time, are not cached.
+func (s *AdvancedPricingService) comparisonKey(ctx context.Context, request *PricingRequest) string {
+	if s.comparisons == nil || request.PickupLocation == nil || request.DestinationLocation == nil {
+		return ""
+	}
+	if request.LockedSurgeMultiplier > 0 || request.WaitingTime > 0 {
+		return ""
+	}
+
+	pickupCell := request.PickupLocation.Geohash(s.comparisonPrecision)
+	destinationCell := request.DestinationLocation.Geohash(s.comparisonPrecision)
+	if pickupCell == "" || destinationCell == "" {
+		return ""
+	}
+	key := fmt.Sprintf("%s:%s:%s:%s", quoteArea(request.City, request.PickupArea), pickupCell, destinationCell, request.RiderID)
+	if variant := experiments.VariantOf(ctx, SurgeExperiment); variant != "" {
+		key += ":" + variant
+	}
+	return key
+}
+
+// RedisComparisonCache keeps fare comparisons in Redis, shared by every replica
+type RedisComparisonCache struct {
+	client redis.UniversalClient
+	ttl    time.Duration
+}
+
+// NewRedisComparisonCache creates a cache whose comparisons expire after ttl
+func NewRedisComparisonCache(client redis.UniversalClient, ttl time.Duration) *RedisComparisonCache {
+	return &RedisComparisonCache{client: client, ttl: ttl}
+}
+
+// Get returns the comparison cached under key, or nil if there is none
+func (c *RedisComparisonCache) Get(ctx context.Context, key string) (*FareComparison, error) {
+	data, err := c.client.Get(ctx, "fare_comparison:"+key).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cached fare comparison: %w", err)
+	}
+
+	var comparison FareComparison
+	if err := json.Unmarshal(data, &comparison); err != nil {
+		return nil, fmt.Errorf("failed to decode cached fare comparison: %w", err)
+	}
+	return &comparison, nil
+}
+
+// Set caches a comparison
+func (c *RedisComparisonCache) Set(ctx context.Context, key string, comparison *FareComparison) error {
+	data, err := json.Marshal(comparison)
+	if err != nil {
+		return err
+	}
+	if err := c.client.SetEx(ctx, "fare_comparison:"+key, data, c.ttl).Err(); err != nil {
+		return fmt.Errorf("failed to cache fare comparison: %w", err)
+	}
+	return nil
+}
+
+// MemoryComparisonCache keeps fare comparisons in memory
+type MemoryComparisonCache struct {
+	ttl     time.Duration
+	entries map[string]cachedComparison
+	mutex   sync.Mutex
+}
+
+type cachedComparison struct {
+	comparison *FareComparison
+	expiresAt  time.Time
+}
+
+// NewMemoryComparisonCache creates an in-memory cache whose comparisons
+// expire after ttl
+func NewMemoryComparisonCache(ttl time.Duration) *MemoryComparisonCache {
+	return &MemoryComparisonCache{ttl: ttl, entries: make(map[string]cachedComparison)}
+}
+
+// Get returns the comparison cached under key, or nil if there is none
+func (c *MemoryComparisonCache) Get(ctx context.Context, key string) (*FareComparison, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	entry, exists := c.entries[key]
+	if !exists || time.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, nil
+	}
+	return entry.comparison, nil
+}
+
+// Set caches a comparison
+func (c *MemoryComparisonCache) Set(ctx context.Context, key string, comparison *FareComparison) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.entries[key] = cachedComparison{comparison: comparison, expiresAt: time.Now().Add(c.ttl)}
+	return nil
+}
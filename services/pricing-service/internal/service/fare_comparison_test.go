@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rideshare-platform/shared/models"
+)
+
+// fixedPickupETAs reports a fixed ETA per vehicle type, or no driver nearby
+type fixedPickupETAs map[string]int
+
+func (f fixedPickupETAs) PickupETA(ctx context.Context, pickup *models.Location, cityID, vehicleType string) (int, error) {
+	if seconds, ok := f[vehicleType]; ok {
+		return seconds, nil
+	}
+	return 0, ErrNoDriverNearby
+}
+
+func TestCompareFares_QuotesEveryVehicleTypeCheapestFirst(t *testing.T) {
+	ctx := context.Background()
+	zones := &countingZoneLookup{}
+	service := NewAdvancedPricingService(nil)
+	service.SetZoneLookup(zones)
+	service.SetPickupETAs(fixedPickupETAs{"economy": 240, "luxury": 900})
+	service.SetComparisonCache(NewMemoryComparisonCache(time.Minute), 7)
+
+	jfk := &models.Location{Latitude: 40.6413, Longitude: -73.7781}
+	comparison, err := service.CompareFares(ctx, newQuoteTestRequest("rider-1", jfk))
+	require.NoError(t, err)
+	assert.Equal(t, 1, zones.calls, "the zone surcharge is looked up once for every vehicle type")
+
+	var vehicleTypes []string
+	for i, option := range comparison.Options {
+		vehicleTypes = append(vehicleTypes, option.VehicleType)
+		assert.Equal(t, option.Quote.TotalFare, option.TotalFare)
+		assert.Equal(t, 5.0, option.Quote.ZoneSurcharge)
+		assert.Empty(t, option.Quote.QuoteID, "comparisons lock no surge")
+		if i > 0 {
+			assert.GreaterOrEqual(t, option.TotalFare, comparison.Options[i-1].TotalFare)
+		}
+	}
+	assert.ElementsMatch(t, []string{"economy", "standard", "premium", "luxury", models.RideTypeWAV}, vehicleTypes)
+	assert.Equal(t, "economy", comparison.Options[0].VehicleType)
+	require.NotNil(t, comparison.Options[0].PickupETASeconds)
+	assert.Equal(t, 240, *comparison.Options[0].PickupETASeconds)
+	assert.Nil(t, comparison.Options[1].PickupETASeconds, "types without a driver nearby have no ETA")
+
+	// The same rider comparing from the same cell is answered from the cache
+	cached, err := service.CompareFares(ctx, newQuoteTestRequest("rider-1", &models.Location{Latitude: 40.64131, Longitude: -73.77811}))
+	require.NoError(t, err)
+	assert.Equal(t, 1, zones.calls)
+	assert.Equal(t, comparison.ComparedAt, cached.ComparedAt)
+
+	_, err = service.CompareFares(ctx, newQuoteTestRequest("rider-2", jfk))
+	require.NoError(t, err)
+	assert.Equal(t, 2, zones.calls, "comparisons include the rider's discounts and are cached per rider")
+}
+
+func TestCompareFares_IncludesCityRateCards(t *testing.T) {
+	service := NewAdvancedPricingService(nil)
+	service.SetRateCards(RateCards{"nyc": {"moto": {BaseFare: 1, DistanceRate: 0.5, MinimumFare: 3, MaximumFare: 50}}})
+
+	comparison, err := service.CompareFares(context.Background(), newQuoteTestRequest("rider-1", nil))
+	require.NoError(t, err)
+	assert.Equal(t, "moto", comparison.Options[0].VehicleType)
+	assert.Len(t, comparison.Options, 6)
+}
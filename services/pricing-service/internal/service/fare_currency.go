@@ -0,0 +1,34 @@
+package service
+
+import (
+	"github.com/rideshare-platform/shared/currency"
+)
+
+// SetCurrencies sets the currency each city's fares are charged in
+func (s *AdvancedPricingService) SetCurrencies(cities *currency.Cities) {
+	s.currencies = cities
+}
+
+// fareCurrency returns the currency a request is priced in: its city's
+// currency, or the currency it was quoted in when it names no city. A request
+// quoted in a different currency than its city charges in is rejected.
+func (s *AdvancedPricingService) fareCurrency(request *PricingRequest) (string, error) {
+	if request.City == "" && request.Currency != "" {
+		return currency.Normalize(request.Currency)
+	}
+
+	code := s.cityCurrency(request.City)
+	if request.Currency != "" {
+		if err := currency.Match(code, request.Currency); err != nil {
+			return "", err
+		}
+	}
+	return code, nil
+}
+
+func (s *AdvancedPricingService) cityCurrency(city string) string {
+	if s.currencies == nil {
+		return currency.Default
+	}
+	return s.currencies.ForCity(city)
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rideshare-platform/shared/currency"
+)
+
+func newCurrencyTestService(t *testing.T) *AdvancedPricingService {
+	cities, err := currency.ParseCities("USD", "istanbul=TRY,tokyo=JPY")
+	assert.NoError(t, err)
+	pricing := NewAdvancedPricingService(nil)
+	pricing.SetCurrencies(cities)
+	return pricing
+}
+
+func TestCalculatePrice_ChargesInCityCurrency(t *testing.T) {
+	ctx := context.Background()
+	pricing := newCurrencyTestService(t)
+
+	request := newFinalFareTestRequest()
+	request.City = "Istanbul"
+	response, err := pricing.CalculatePrice(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, "TRY", response.Currency)
+
+	request.City = "tokyo"
+	response, err = pricing.CalculatePrice(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, "JPY", response.Currency)
+	assert.Equal(t, float64(int64(response.TotalFare)), response.TotalFare, "yen have no minor unit")
+
+	request.City = "springfield"
+	response, err = pricing.CalculatePrice(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, "USD", response.Currency)
+}
+
+func TestCalculatePrice_RejectsMismatchedCurrency(t *testing.T) {
+	ctx := context.Background()
+	pricing := newCurrencyTestService(t)
+
+	request := newFinalFareTestRequest()
+	request.City = "istanbul"
+	request.Currency = "try"
+	_, err := pricing.CalculatePrice(ctx, request)
+	assert.NoError(t, err)
+
+	request.Currency = "USD"
+	_, err = pricing.CalculatePrice(ctx, request)
+	assert.True(t, errors.Is(err, currency.ErrMismatch))
+
+	// Without a city the quoted currency is charged, if it is supported
+	request.City = ""
+	request.Currency = "EUR"
+	response, err := pricing.CalculatePrice(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, "EUR", response.Currency)
+
+	request.Currency = "XYZ"
+	_, err = pricing.CalculatePrice(ctx, request)
+	assert.True(t, errors.Is(err, currency.ErrUnsupported))
+}
@@ -0,0 +1,29 @@
+package service
+
+import "context"
+
+// surgeMultiplier returns the request's locked surge if it has one, and the
+// current surge for the pickup area otherwise. The second result reports
+// whether the locked surge was used.
+func (s *AdvancedPricingService) surgeMultiplier(ctx context.Context, request *PricingRequest) (float64, bool) {
+	if request.LockedSurgeMultiplier > 0 {
+		// A locked surge is still held to the cap
+		return s.capSurge(request.City, request.LockedSurgeMultiplier), true
+	}
+
+	multiplier, err := s.GetSurgeMultiplier(ctx, request.City, request.PickupArea)
+	if err != nil {
+		return 1.0, false // Default if surge data unavailable
+	}
+	return s.scaleSurge(ctx, multiplier), false
+}
+
+// waitingFare charges the time waited at pickup beyond the vehicle type's
+// free waiting period
+func waitingFare(rates *VehicleRates, waitingSeconds int) float64 {
+	chargeableMinutes := float64(waitingSeconds)/60.0 - rates.FreeWaitMinutes
+	if chargeableMinutes <= 0 {
+		return 0
+	}
+	return chargeableMinutes * rates.WaitRate
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newFinalFareTestRequest() *PricingRequest {
+	return &PricingRequest{
+		TripID:        "trip-final",
+		Distance:      10,
+		EstimatedTime: 1200,
+		VehicleType:   "standard",
+		RequestTime:   time.Date(2024, 3, 4, 8, 0, 0, 0, time.Local).Unix(),
+		RiderID:       "rider-1",
+	}
+}
+
+func TestCalculatePrice_UsesLockedSurge(t *testing.T) {
+	ctx := context.Background()
+	pricing := NewAdvancedPricingService(nil)
+
+	current, err := pricing.CalculatePrice(ctx, newFinalFareTestRequest())
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, current.SurgeMultiplier)
+	assert.False(t, current.FareBreakdown.SurgeLocked)
+
+	request := newFinalFareTestRequest()
+	request.LockedSurgeMultiplier = 1.5
+	locked, err := pricing.CalculatePrice(ctx, request)
+	assert.NoError(t, err)
+	assert.Equal(t, 1.5, locked.SurgeMultiplier)
+	assert.True(t, locked.FareBreakdown.SurgeLocked)
+	assert.InDelta(t, (locked.BaseFare+locked.DistanceFare+locked.TimeFare)*0.5, locked.SurgeFare, 0.001)
+	assert.Greater(t, locked.TotalFare, current.TotalFare)
+}
+
+func TestCalculatePrice_ChargesWaitingBeyondFreePeriod(t *testing.T) {
+	ctx := context.Background()
+	pricing := NewAdvancedPricingService(nil)
+
+	withoutWait, err := pricing.CalculatePrice(ctx, newFinalFareTestRequest())
+	assert.NoError(t, err)
+
+	request := newFinalFareTestRequest()
+	request.WaitingTime = 90 // within the two free minutes
+	free, err := pricing.CalculatePrice(ctx, request)
+	assert.NoError(t, err)
+	assert.Zero(t, free.WaitingFare)
+	assert.Equal(t, withoutWait.TotalFare, free.TotalFare)
+
+	request.WaitingTime = 300
+	charged, err := pricing.CalculatePrice(ctx, request)
+	assert.NoError(t, err)
+	assert.InDelta(t, 3*0.40, charged.WaitingFare, 0.001)
+	assert.InDelta(t, withoutWait.TotalFare+charged.WaitingFare, charged.TotalFare, 0.001)
+}
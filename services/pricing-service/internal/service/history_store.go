@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/rideshare-platform/shared/export"
+)
+
+// PostgresHistoryStore keeps pricing records in the pricing_history table
+type PostgresHistoryStore struct {
+	db *sql.DB
+}
+
+// NewPostgresHistoryStore creates a history store backed by PostgreSQL
+func NewPostgresHistoryStore(db *sql.DB) *PostgresHistoryStore {
+	return &PostgresHistoryStore{db: db}
+}
+
+// Save stores a pricing record, filling in its ID
+func (s *PostgresHistoryStore) Save(ctx context.Context, record *PricingRecord) error {
+	request, err := json.Marshal(record.Request)
+	if err != nil {
+		return fmt.Errorf("failed to encode pricing request: %w", err)
+	}
+	result, err := json.Marshal(record.Result)
+	if err != nil {
+		return fmt.Errorf("failed to encode pricing result: %w", err)
+	}
+	discounts := []*DiscountInfo{}
+	if record.Result != nil && record.Result.AppliedDiscounts != nil {
+		discounts = record.Result.AppliedDiscounts
+	}
+	appliedDiscounts, err := json.Marshal(discounts)
+	if err != nil {
+		return fmt.Errorf("failed to encode applied discounts: %w", err)
+	}
+
+	query := `
+		INSERT INTO pricing_history (
+			trip_id, rider_id, pickup_area, vehicle_type, total_fare, currency,
+			surge_multiplier, pricing_version, request, result, applied_discounts, calculated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING id`
+
+	err = s.db.QueryRowContext(ctx, query,
+		record.TripID, record.RiderID, record.PickupArea, record.VehicleType,
+		record.TotalFare, record.Currency, record.SurgeMultiplier, record.PricingVersion,
+		request, result, appliedDiscounts, record.CalculatedAt,
+	).Scan(&record.ID)
+	if err != nil {
+		return fmt.Errorf("failed to insert pricing record: %w", err)
+	}
+	return nil
+}
+
+// List returns the page of records matching the filter, newest first
+func (s *PostgresHistoryStore) List(ctx context.Context, filter HistoryFilter) ([]*PricingRecord, int, error) {
+	var conditions []string
+	var args []interface{}
+	addCondition := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.TripID != "" {
+		addCondition("trip_id = $%d", filter.TripID)
+	}
+	if filter.RiderID != "" {
+		addCondition("rider_id = $%d", filter.RiderID)
+	}
+	if filter.Area != "" {
+		addCondition("pickup_area = $%d", filter.Area)
+	}
+	if !filter.From.IsZero() {
+		addCondition("calculated_at >= $%d", filter.From)
+	}
+	if !filter.To.IsZero() {
+		addCondition("calculated_at <= $%d", filter.To)
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM pricing_history "+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count pricing records: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, trip_id, rider_id, pickup_area, vehicle_type, total_fare, currency,
+			surge_multiplier, pricing_version, request, result, calculated_at
+		FROM pricing_history
+		%s
+		ORDER BY calculated_at DESC, id
+		LIMIT $%d OFFSET $%d`, where, len(args)+1, len(args)+2)
+	rows, err := s.db.QueryContext(ctx, query, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list pricing records: %w", err)
+	}
+	defer rows.Close()
+
+	var records []*PricingRecord
+	for rows.Next() {
+		record := &PricingRecord{}
+		var request, result []byte
+		err := rows.Scan(
+			&record.ID, &record.TripID, &record.RiderID, &record.PickupArea, &record.VehicleType,
+			&record.TotalFare, &record.Currency, &record.SurgeMultiplier, &record.PricingVersion,
+			&request, &result, &record.CalculatedAt,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan pricing record: %w", err)
+		}
+		if err := json.Unmarshal(request, &record.Request); err != nil {
+			return nil, 0, fmt.Errorf("failed to decode pricing request: %w", err)
+		}
+		if err := json.Unmarshal(result, &record.Result); err != nil {
+			return nil, 0, fmt.Errorf("failed to decode pricing result: %w", err)
+		}
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating pricing records: %w", err)
+	}
+	return records, total, nil
+}
+
+// ListSince returns up to limit records calculated after the watermark,
+// oldest first. Records are never updated, so they are exported once.
+func (s *PostgresHistoryStore) ListSince(ctx context.Context, since export.Watermark, limit int) ([]*PricingRecord, error) {
+	query := `
+		SELECT id, trip_id, rider_id, pickup_area, vehicle_type, total_fare, currency,
+			surge_multiplier, pricing_version, calculated_at
+		FROM pricing_history
+		WHERE (calculated_at, id::text) > ($1, $2)
+		ORDER BY calculated_at, id::text
+		LIMIT $3`
+	rows, err := s.db.QueryContext(ctx, query, since.UpdatedAt, since.ID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pricing records: %w", err)
+	}
+	defer rows.Close()
+
+	var records []*PricingRecord
+	for rows.Next() {
+		record := &PricingRecord{}
+		err := rows.Scan(
+			&record.ID, &record.TripID, &record.RiderID, &record.PickupArea, &record.VehicleType,
+			&record.TotalFare, &record.Currency, &record.SurgeMultiplier, &record.PricingVersion,
+			&record.CalculatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan pricing record: %w", err)
+		}
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating pricing records: %w", err)
+	}
+	return records, nil
+}
+
+// MemoryHistoryStore keeps pricing records in memory, for development
+// without a database
+type MemoryHistoryStore struct {
+	records []*PricingRecord
+	mutex   sync.RWMutex
+}
+
+// NewMemoryHistoryStore creates a new in-memory history store
+func NewMemoryHistoryStore() *MemoryHistoryStore {
+	return &MemoryHistoryStore{}
+}
+
+// Save stores a pricing record, filling in its ID
+func (s *MemoryHistoryStore) Save(ctx context.Context, record *PricingRecord) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	record.ID = uuid.New().String()
+	stored := *record
+	s.records = append(s.records, &stored)
+	return nil
+}
+
+// List returns the page of records matching the filter, newest first
+func (s *MemoryHistoryStore) List(ctx context.Context, filter HistoryFilter) ([]*PricingRecord, int, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var matching []*PricingRecord
+	for _, record := range s.records {
+		if (filter.TripID != "" && record.TripID != filter.TripID) ||
+			(filter.RiderID != "" && record.RiderID != filter.RiderID) ||
+			(filter.Area != "" && record.PickupArea != filter.Area) ||
+			(!filter.From.IsZero() && record.CalculatedAt.Before(filter.From)) ||
+			(!filter.To.IsZero() && record.CalculatedAt.After(filter.To)) {
+			continue
+		}
+		matching = append(matching, record)
+	}
+	sort.SliceStable(matching, func(i, j int) bool { return matching[i].CalculatedAt.After(matching[j].CalculatedAt) })
+
+	total := len(matching)
+	if filter.Offset >= total {
+		return nil, total, nil
+	}
+	end := total
+	if filter.Limit > 0 && filter.Offset+filter.Limit < total {
+		end = filter.Offset + filter.Limit
+	}
+	page := make([]*PricingRecord, 0, end-filter.Offset)
+	for _, record := range matching[filter.Offset:end] {
+		copied := *record
+		page = append(page, &copied)
+	}
+	return page, total, nil
+}
+
+// ListSince returns up to limit records calculated after the watermark, oldest first
+func (s *MemoryHistoryStore) ListSince(ctx context.Context, since export.Watermark, limit int) ([]*PricingRecord, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var matching []*PricingRecord
+	for _, record := range s.records {
+		if since.Before(record.CalculatedAt, record.ID) {
+			copied := *record
+			matching = append(matching, &copied)
+		}
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		at := export.Watermark{UpdatedAt: matching[i].CalculatedAt, ID: matching[i].ID}
+		return at.Before(matching[j].CalculatedAt, matching[j].ID)
+	})
+	if len(matching) > limit {
+		matching = matching[:limit]
+	}
+	return matching, nil
+}
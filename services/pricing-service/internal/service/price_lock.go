@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/rideshare-platform/shared/city"
+)
+
+// defaultQuoteValidity is how long a quote's surge is locked for when no
+// price lock store sets it
+const defaultQuoteValidity = 10 * time.Minute
+
+var (
+	// ErrPriceLockNotFound is returned for a quote ID with no lock on record
+	ErrPriceLockNotFound = errors.New("price lock not found")
+	// ErrPriceLockExpired is returned when re-quoting after a lock's window has passed
+	ErrPriceLockExpired = errors.New("price lock has expired")
+	// ErrPriceLockMismatch is returned when a quote ID is used for another
+	// rider, city, area or vehicle type than it was quoted for
+	ErrPriceLockMismatch = errors.New("price lock does not match the request")
+)
+
+// PriceLock guarantees a rider the surge they were quoted. Re-quotes within
+// ValidUntil are priced at SurgeMultiplier however the area's surge moves,
+// and so is the final fare of a trip booked with the quote.
+type PriceLock struct {
+	QuoteID         string    `json:"quote_id"`
+	RiderID         string    `json:"rider_id"`
+	City            string    `json:"city,omitempty"`
+	PickupArea      string    `json:"pickup_area,omitempty"`
+	VehicleType     string    `json:"vehicle_type"`
+	SurgeMultiplier float64   `json:"surge_multiplier"`
+	ValidUntil      time.Time `json:"valid_until"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// matches reports whether a request is for what the lock was quoted for
+func (l *PriceLock) matches(request *PricingRequest) bool {
+	return l.RiderID == request.RiderID &&
+		l.City == city.Normalize(request.City) &&
+		l.PickupArea == request.PickupArea &&
+		l.VehicleType == request.VehicleType
+}
+
+// PriceLockStore keeps price locks by quote ID. Locks outlive their window
+// so trips booked with them are charged the locked surge when they complete.
+type PriceLockStore interface {
+	// Get returns the lock of a quote, or nil if there is none
+	Get(ctx context.Context, quoteID string) (*PriceLock, error)
+	Save(ctx context.Context, lock *PriceLock) error
+}
+
+// SetPriceLocks records a lock for every quote, each guaranteeing its surge
+// for validity
+func (s *AdvancedPricingService) SetPriceLocks(store PriceLockStore, validity time.Duration) {
+	s.priceLocks = store
+	s.quoteValidity = validity
+}
+
+// validity returns how long quotes are valid for
+func (s *AdvancedPricingService) validity() time.Duration {
+	if s.quoteValidity > 0 {
+		return s.quoteValidity
+	}
+	return defaultQuoteValidity
+}
+
+// lockQuote records the surge a new quote was priced at under a new quote
+// ID. Without a store, or if the lock cannot be saved, the quote is returned
+// unlocked and carries no quote ID.
+func (s *AdvancedPricingService) lockQuote(ctx context.Context, request *PricingRequest, response *PricingResponse) {
+	if s.priceLocks == nil {
+		return
+	}
+
+	now := time.Now()
+	lock := &PriceLock{
+		QuoteID:         fmt.Sprintf("quote_%d", now.UnixNano()),
+		RiderID:         request.RiderID,
+		City:            city.Normalize(request.City),
+		PickupArea:      request.PickupArea,
+		VehicleType:     request.VehicleType,
+		SurgeMultiplier: response.SurgeMultiplier,
+		ValidUntil:      response.ValidUntil,
+		CreatedAt:       now,
+	}
+	if err := s.priceLocks.Save(ctx, lock); err != nil {
+		return
+	}
+	response.QuoteID = lock.QuoteID
+}
+
+// lockedSurge returns the lock of the request's quote. Re-quotes must fall
+// within the lock's window; final fares are charged at it whenever the trip
+// completes.
+func (s *AdvancedPricingService) lockedSurge(ctx context.Context, request *PricingRequest, requote bool) (*PriceLock, error) {
+	if s.priceLocks == nil {
+		return nil, ErrPriceLockNotFound
+	}
+
+	lock, err := s.priceLocks.Get(ctx, request.QuoteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read price lock: %w", err)
+	}
+	if lock == nil {
+		return nil, ErrPriceLockNotFound
+	}
+	if !lock.matches(request) {
+		return nil, ErrPriceLockMismatch
+	}
+	if requote && time.Now().After(lock.ValidUntil) {
+		return nil, ErrPriceLockExpired
+	}
+	return lock, nil
+}
+
+// RedisPriceLockStore keeps price locks in Redis, shared by every replica
+type RedisPriceLockStore struct {
+	client    redis.UniversalClient
+	retention time.Duration
+}
+
+// NewRedisPriceLockStore creates a store keeping locks for retention past
+// their window
+func NewRedisPriceLockStore(client redis.UniversalClient, retention time.Duration) *RedisPriceLockStore {
+	return &RedisPriceLockStore{client: client, retention: retention}
+}
+
+// Get returns the lock of a quote, or nil if there is none
+func (s *RedisPriceLockStore) Get(ctx context.Context, quoteID string) (*PriceLock, error) {
+	data, err := s.client.Get(ctx, "price_lock:"+quoteID).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var lock PriceLock
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("failed to decode price lock: %w", err)
+	}
+	return &lock, nil
+}
+
+// Save records a lock until retention past its window
+func (s *RedisPriceLockStore) Save(ctx context.Context, lock *PriceLock) error {
+	data, err := json.Marshal(lock)
+	if err != nil {
+		return err
+	}
+	ttl := time.Until(lock.ValidUntil) + s.retention
+	if err := s.client.SetEx(ctx, "price_lock:"+lock.QuoteID, data, ttl).Err(); err != nil {
+		return fmt.Errorf("failed to save price lock: %w", err)
+	}
+	return nil
+}
+
+// MemoryPriceLockStore keeps price locks in memory
+type MemoryPriceLockStore struct {
+	retention time.Duration
+	locks     map[string]*PriceLock
+	mutex     sync.Mutex
+}
+
+// NewMemoryPriceLockStore creates an in-memory store keeping locks for
+// retention past their window
+func NewMemoryPriceLockStore(retention time.Duration) *MemoryPriceLockStore {
+	return &MemoryPriceLockStore{retention: retention, locks: make(map[string]*PriceLock)}
+}
+
+// Get returns the lock of a quote, or nil if there is none
+func (s *MemoryPriceLockStore) Get(ctx context.Context, quoteID string) (*PriceLock, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	lock, exists := s.locks[quoteID]
+	if !exists {
+		return nil, nil
+	}
+	if time.Now().After(lock.ValidUntil.Add(s.retention)) {
+		delete(s.locks, quoteID)
+		return nil, nil
+	}
+	copied := *lock
+	return &copied, nil
+}
+
+// Save records a lock
+func (s *MemoryPriceLockStore) Save(ctx context.Context, lock *PriceLock) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	copied := *lock
+	s.locks[lock.QuoteID] = &copied
+	return nil
+}
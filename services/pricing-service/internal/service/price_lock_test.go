@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateQuote_LocksSurgeForQuoteWindow(t *testing.T) {
+	ctx := context.Background()
+	locks := NewMemoryPriceLockStore(time.Hour)
+	pricing := NewAdvancedPricingService(nil)
+	pricing.SetPriceLocks(locks, 5*time.Minute)
+
+	quote, err := pricing.EstimateQuote(ctx, newFinalFareTestRequest())
+	require.NoError(t, err)
+	require.NotEmpty(t, quote.QuoteID)
+	assert.WithinDuration(t, time.Now().Add(5*time.Minute), quote.ValidUntil, time.Second)
+
+	// The area surges after the quote; the lock keeps the quoted surge
+	lock, err := locks.Get(ctx, quote.QuoteID)
+	require.NoError(t, err)
+	assert.Equal(t, quote.SurgeMultiplier, lock.SurgeMultiplier)
+	lock.SurgeMultiplier = 1.8
+	require.NoError(t, locks.Save(ctx, lock))
+
+	request := newFinalFareTestRequest()
+	request.QuoteID = quote.QuoteID
+	requote, err := pricing.EstimateQuote(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, 1.8, requote.SurgeMultiplier)
+	assert.True(t, requote.FareBreakdown.SurgeLocked)
+	assert.Equal(t, quote.QuoteID, requote.QuoteID)
+	assert.Equal(t, lock.ValidUntil, requote.ValidUntil, "re-quoting does not extend the lock")
+
+	other := newFinalFareTestRequest()
+	other.RiderID = "rider-2"
+	other.QuoteID = quote.QuoteID
+	_, err = pricing.EstimateQuote(ctx, other)
+	assert.ErrorIs(t, err, ErrPriceLockMismatch)
+
+	unknown := newFinalFareTestRequest()
+	unknown.QuoteID = "quote_unknown"
+	_, err = pricing.EstimateQuote(ctx, unknown)
+	assert.ErrorIs(t, err, ErrPriceLockNotFound)
+}
+
+func TestCalculatePrice_ChargesExpiredLockCapped(t *testing.T) {
+	ctx := context.Background()
+	locks := NewMemoryPriceLockStore(time.Hour)
+	pricing := NewAdvancedPricingService(nil)
+	pricing.SetPriceLocks(locks, 5*time.Minute)
+	pricing.SetSurgeLimits(SurgeLimits{MaxMultiplier: 2})
+
+	request := newFinalFareTestRequest()
+	require.NoError(t, locks.Save(ctx, &PriceLock{
+		QuoteID:         "quote_1",
+		RiderID:         request.RiderID,
+		VehicleType:     request.VehicleType,
+		SurgeMultiplier: 1.6,
+		ValidUntil:      time.Now().Add(-time.Minute),
+	}))
+	request.QuoteID = "quote_1"
+
+	_, err := pricing.EstimateQuote(ctx, request)
+	assert.ErrorIs(t, err, ErrPriceLockExpired, "the window to re-quote has passed")
+
+	final, err := pricing.CalculatePrice(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, 1.6, final.SurgeMultiplier, "a trip booked in the window is still charged the lock")
+
+	request = newFinalFareTestRequest()
+	request.LockedSurgeMultiplier = 3.5
+	capped, err := pricing.CalculatePrice(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, 2.0, capped.SurgeMultiplier, "locked surges are held to the cap")
+}
+
+func TestSmoothSurge_RisesGradually(t *testing.T) {
+	assert.Equal(t, 1.5, smoothSurge(1.0, 3.0, 0.5))
+	assert.Equal(t, 1.2, smoothSurge(1.0, 1.2, 0.5))
+	assert.Equal(t, 1.1, smoothSurge(2.5, 1.1, 0.5), "surge falls at once")
+	assert.Equal(t, 3.0, smoothSurge(1.0, 3.0, 0), "no step lets surge jump")
+}
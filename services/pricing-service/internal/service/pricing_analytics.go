@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rideshare-platform/shared/analytics"
+	"github.com/rideshare-platform/shared/currency"
+	"github.com/rideshare-platform/shared/experiments"
+)
+
+// AnalyticsSource is the name pricing rollups are recorded under
+const AnalyticsSource = "pricing"
+
+// Pricing rollup counters. Per-currency and per-vehicle counters are named
+// with the currency or vehicle type after the prefix.
+const (
+	counterTrips           = "trips"
+	counterSurgedTrips     = "surged_trips"
+	counterSurgeMultiplier = "surge_multiplier"
+	counterDiscountedTrips = "discounted_trips"
+	counterRevenuePrefix   = "revenue."
+	counterTripsPrefix     = "currency_trips."
+	counterDiscountPrefix  = "discounts."
+	counterVehiclePrefix   = "vehicle."
+)
+
+// peakHourCount is how many of the busiest hours analytics report
+const peakHourCount = 5
+
+// CurrencyRevenue is the fares charged in one currency
+type CurrencyRevenue struct {
+	Currency    string  `json:"currency"`
+	Trips       int     `json:"trips"`
+	Revenue     float64 `json:"revenue"`
+	AverageFare float64 `json:"average_fare"`
+	Discounts   float64 `json:"discounts"`
+}
+
+// SetAnalytics replaces the recorder final fares are counted with
+func (s *AdvancedPricingService) SetAnalytics(recorder *analytics.Recorder) {
+	s.analytics = recorder
+}
+
+// recordFinalFare counts a final fare into the hour it was calculated in,
+// for the experiment variants the request is in as well
+func (s *AdvancedPricingService) recordFinalFare(ctx context.Context, request *PricingRequest, response *PricingResponse, at time.Time) {
+	if s.analytics == nil {
+		return
+	}
+
+	counters := analytics.Counters{
+		counterTrips:                             1,
+		counterRevenuePrefix + response.Currency: response.TotalFare,
+		counterTripsPrefix + response.Currency:   1,
+	}
+	if request.VehicleType != "" {
+		counters.Add(counterVehiclePrefix+request.VehicleType, 1)
+	}
+	if response.SurgeMultiplier > 1.0 {
+		counters.Add(counterSurgedTrips, 1)
+		counters.Add(counterSurgeMultiplier, response.SurgeMultiplier)
+	}
+	if response.DiscountAmount > 0 {
+		counters.Add(counterDiscountedTrips, 1)
+		counters.Add(counterDiscountPrefix+response.Currency, response.DiscountAmount)
+	}
+	s.analytics.Record(at, experiments.Tag(ctx, counters))
+}
+
+// GetPricingAnalytics summarises the final fares calculated in the range.
+// Revenue is reported per currency, fares in different currencies are never
+// added together.
+func (s *AdvancedPricingService) GetPricingAnalytics(ctx context.Context, tr analytics.TimeRange) (*PricingAnalytics, error) {
+	if s.analytics == nil {
+		return nil, errors.New("pricing analytics are not configured")
+	}
+	summary, err := s.analytics.Summarize(ctx, tr)
+	if err != nil {
+		return nil, err
+	}
+
+	totals := summary.Totals
+	result := &PricingAnalytics{
+		From:                summary.From,
+		To:                  summary.To,
+		TotalTrips:          int(totals[counterTrips]),
+		Revenue:             []*CurrencyRevenue{},
+		SurgePercentage:     totals.Ratio(counterSurgedTrips, counterTrips) * 100,
+		AverageSurge:        totals.Ratio(counterSurgeMultiplier, counterSurgedTrips),
+		DiscountPercentage:  totals.Ratio(counterDiscountedTrips, counterTrips) * 100,
+		PeakHours:           summary.BusiestHours(counterTrips, peakHourCount),
+		PopularVehicleTypes: make(map[string]int),
+	}
+	if result.PeakHours == nil {
+		result.PeakHours = []int{}
+	}
+
+	result.Revenue = revenueLines(totals)
+
+	for vehicleType, count := range summary.WithPrefix(counterVehiclePrefix) {
+		result.PopularVehicleTypes[vehicleType] = int(count)
+	}
+	return result, nil
+}
+
+// revenueLines reports the revenue counted in counters per currency, ordered
+// by currency code
+func revenueLines(counters analytics.Counters) []*CurrencyRevenue {
+	lines := []*CurrencyRevenue{}
+	for name, revenue := range counters {
+		code, found := strings.CutPrefix(name, counterRevenuePrefix)
+		if !found || code == "" {
+			continue
+		}
+		line := &CurrencyRevenue{
+			Currency:  code,
+			Trips:     int(counters[counterTripsPrefix+code]),
+			Revenue:   currency.Round(revenue, code),
+			Discounts: currency.Round(counters[counterDiscountPrefix+code], code),
+		}
+		if line.Trips > 0 {
+			line.AverageFare = currency.Round(revenue/float64(line.Trips), code)
+		}
+		lines = append(lines, line)
+	}
+	sort.Slice(lines, func(i, j int) bool {
+		return lines[i].Currency < lines[j].Currency
+	})
+	return lines
+}
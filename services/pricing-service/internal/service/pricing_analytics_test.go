@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rideshare-platform/shared/analytics"
+)
+
+func TestGetPricingAnalytics_SummarisesFinalFares(t *testing.T) {
+	ctx := context.Background()
+	pricing := NewAdvancedPricingService(nil)
+
+	standard := newFinalFareTestRequest()
+	standardFare, err := pricing.CalculatePrice(ctx, standard)
+	assert.NoError(t, err)
+	assert.NoError(t, pricing.RecordPricing(ctx, standard, standardFare))
+
+	surged := newFinalFareTestRequest()
+	surged.VehicleType = "premium"
+	surged.LockedSurgeMultiplier = 1.5
+	surgedFare, err := pricing.CalculatePrice(ctx, surged)
+	assert.NoError(t, err)
+	assert.NoError(t, pricing.RecordPricing(ctx, surged, surgedFare))
+
+	now := time.Now()
+	result, err := pricing.GetPricingAnalytics(ctx, analytics.TimeRange{From: now.Add(-time.Hour), To: now.Add(time.Minute)})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.TotalTrips)
+	assert.Equal(t, 50.0, result.SurgePercentage)
+	assert.Equal(t, 1.5, result.AverageSurge)
+	assert.Equal(t, map[string]int{"standard": 1, "premium": 1}, result.PopularVehicleTypes)
+	assert.Equal(t, []int{now.UTC().Hour()}, result.PeakHours)
+	if assert.Len(t, result.Revenue, 1) {
+		assert.Equal(t, standardFare.Currency, result.Revenue[0].Currency)
+		assert.Equal(t, 2, result.Revenue[0].Trips)
+		assert.InDelta(t, standardFare.TotalFare+surgedFare.TotalFare, result.Revenue[0].Revenue, 0.01)
+	}
+
+	// Fares calculated outside the range are left out
+	earlier, err := pricing.GetPricingAnalytics(ctx, analytics.TimeRange{From: now.Add(-48 * time.Hour), To: now.Add(-24 * time.Hour)})
+	assert.NoError(t, err)
+	assert.Zero(t, earlier.TotalTrips)
+	assert.Empty(t, earlier.Revenue)
+	assert.Empty(t, earlier.PeakHours)
+}
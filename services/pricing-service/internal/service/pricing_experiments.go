@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rideshare-platform/shared/analytics"
+	"github.com/rideshare-platform/shared/experiments"
+)
+
+// SurgeExperiment tests how much of the surge riders are charged. Its
+// variants may set surge_scale, the share of the surge above 1x applied:
+// 0.5 turns a 2x surge into 1.5x. Surge locked at request time is charged
+// as quoted.
+const SurgeExperiment = "pricing_surge"
+
+// VariantFares compares the final fares of one experiment variant
+type VariantFares struct {
+	Variant         string             `json:"variant"`
+	Trips           int                `json:"trips"`
+	SurgePercentage float64            `json:"surge_percentage"`
+	AverageSurge    float64            `json:"average_surge"`
+	Revenue         []*CurrencyRevenue `json:"revenue"`
+}
+
+// ExperimentFares compares an experiment's variants over a time range
+type ExperimentFares struct {
+	Experiment string          `json:"experiment"`
+	From       time.Time       `json:"from"`
+	To         time.Time       `json:"to"`
+	Variants   []*VariantFares `json:"variants"`
+}
+
+// SetExperiments attaches the experiments riders are assigned to. Requests
+// keep the assignments they arrive with.
+func (s *AdvancedPricingService) SetExperiments(registry *experiments.Registry) {
+	s.experiments = registry
+}
+
+// assignExperiments places the request in its experiment variants
+func (s *AdvancedPricingService) assignExperiments(ctx context.Context, request *PricingRequest) context.Context {
+	return s.experiments.Assign(ctx, experiments.Units{RiderID: request.RiderID, TripID: request.TripID})
+}
+
+// scaleSurge applies the surge experiment variant the request is in
+func (s *AdvancedPricingService) scaleSurge(ctx context.Context, multiplier float64) float64 {
+	if multiplier <= 1.0 {
+		return multiplier
+	}
+	scale := s.experiments.Param(ctx, SurgeExperiment, "surge_scale", 1.0)
+	return 1.0 + (multiplier-1.0)*scale
+}
+
+// GetExperimentFares compares the final fares of an experiment's variants
+// calculated in the range. As in the overall analytics, revenue is reported
+// per currency.
+func (s *AdvancedPricingService) GetExperimentFares(ctx context.Context, experiment string, tr analytics.TimeRange) (*ExperimentFares, error) {
+	if s.analytics == nil {
+		return nil, errors.New("pricing analytics are not configured")
+	}
+	if _, exists := s.experiments.Get(experiment); !exists {
+		return nil, fmt.Errorf("%w: %s", experiments.ErrUnknownExperiment, experiment)
+	}
+	summary, err := s.analytics.Summarize(ctx, tr)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ExperimentFares{
+		Experiment: experiment,
+		From:       summary.From,
+		To:         summary.To,
+		Variants:   []*VariantFares{},
+	}
+	for _, variant := range experiments.Compare(summary, experiment) {
+		counters := variant.Counters
+		fares := &VariantFares{
+			Variant:         variant.Variant,
+			Trips:           int(counters[counterTrips]),
+			SurgePercentage: counters.Ratio(counterSurgedTrips, counterTrips) * 100,
+			AverageSurge:    counters.Ratio(counterSurgeMultiplier, counterSurgedTrips),
+			Revenue:         revenueLines(counters),
+		}
+		result.Variants = append(result.Variants, fares)
+	}
+	return result, nil
+}
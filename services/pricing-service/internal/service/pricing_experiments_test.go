@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rideshare-platform/shared/analytics"
+	"github.com/rideshare-platform/shared/experiments"
+	"github.com/rideshare-platform/shared/models"
+)
+
+func newSurgeExperiment(t *testing.T, unit experiments.Unit) *experiments.Registry {
+	t.Helper()
+	registry, err := experiments.NewRegistry(&experiments.Config{Experiments: map[string]*experiments.Experiment{
+		SurgeExperiment: {
+			Unit: unit,
+			Variants: []*experiments.Variant{
+				{Name: "control", Weight: 1},
+				{Name: "half_surge", Weight: 1, Params: map[string]float64{"surge_scale": 0.5}},
+			},
+		},
+	}})
+	require.NoError(t, err)
+	return registry
+}
+
+func TestScaleSurge_FollowsVariant(t *testing.T) {
+	pricing := NewAdvancedPricingService(nil)
+	pricing.SetExperiments(newSurgeExperiment(t, experiments.UnitRider))
+
+	control := experiments.WithAssignments(context.Background(), experiments.Assignments{SurgeExperiment: "control"})
+	half := experiments.WithAssignments(context.Background(), experiments.Assignments{SurgeExperiment: "half_surge"})
+	assert.Equal(t, 2.0, pricing.scaleSurge(control, 2.0))
+	assert.Equal(t, 1.5, pricing.scaleSurge(half, 2.0))
+	assert.Equal(t, 1.0, pricing.scaleSurge(half, 1.0))
+
+	// Quotes of different variants are cached apart
+	pricing.SetQuoteCache(NewMemoryQuoteCache(time.Minute), 7)
+	request := newQuoteTestRequest("rider-1", &models.Location{Latitude: 40.6413, Longitude: -73.7781})
+	assert.NotEqual(t, pricing.quoteKey(control, request), pricing.quoteKey(half, request))
+}
+
+func TestGetExperimentFares_ComparesVariants(t *testing.T) {
+	ctx := context.Background()
+	pricing := NewAdvancedPricingService(nil)
+	pricing.SetExperiments(newSurgeExperiment(t, experiments.UnitTrip))
+
+	for _, tripID := range []string{"trip-1", "trip-2", "trip-3", "trip-4"} {
+		request := newFinalFareTestRequest()
+		request.TripID = tripID
+		fare, err := pricing.CalculatePrice(ctx, request)
+		require.NoError(t, err)
+		require.NoError(t, pricing.RecordPricing(ctx, request, fare))
+	}
+
+	now := time.Now()
+	tr := analytics.TimeRange{From: now.Add(-time.Hour), To: now.Add(time.Minute)}
+	_, err := pricing.GetExperimentFares(ctx, "unknown", tr)
+	assert.ErrorIs(t, err, experiments.ErrUnknownExperiment)
+
+	result, err := pricing.GetExperimentFares(ctx, SurgeExperiment, tr)
+	require.NoError(t, err)
+	trips := 0
+	for _, variant := range result.Variants {
+		trips += variant.Trips
+		if assert.Len(t, variant.Revenue, 1) {
+			assert.Equal(t, variant.Trips, variant.Revenue[0].Trips)
+		}
+	}
+	assert.Equal(t, 4, trips)
+
+	// Tagged counters do not change the overall analytics
+	overall, err := pricing.GetPricingAnalytics(ctx, tr)
+	require.NoError(t, err)
+	assert.Equal(t, 4, overall.TotalTrips)
+	assert.Len(t, overall.Revenue, 1)
+}
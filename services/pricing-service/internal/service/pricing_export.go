@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+
+	"github.com/rideshare-platform/shared/export"
+)
+
+// PricingExportDataset is the name the pricing history is exported to the
+// warehouse under
+const PricingExportDataset = "pricing_history"
+
+var pricingExportColumns = []string{
+	"id", "trip_id", "rider_id", "pickup_area", "vehicle_type", "total_fare", "currency",
+	"surge_multiplier", "pricing_version", "calculated_at",
+}
+
+// NewPricingExport creates the warehouse dataset of final fare calculations.
+// The full request and result are left out; they stay in the history API.
+func NewPricingExport(store HistoryStore) export.Dataset {
+	return export.NewDataset(PricingExportDataset, pricingExportColumns, func(ctx context.Context, since export.Watermark, limit int) ([]export.Record, error) {
+		history, err := store.ListSince(ctx, since, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		records := make([]export.Record, 0, len(history))
+		for _, record := range history {
+			records = append(records, export.Record{
+				ID:        record.ID,
+				UpdatedAt: record.CalculatedAt,
+				Values: []string{
+					record.ID, record.TripID, record.RiderID, record.PickupArea, record.VehicleType,
+					export.Float(record.TotalFare), record.Currency, export.Float(record.SurgeMultiplier),
+					record.PricingVersion, export.Time(record.CalculatedAt),
+				},
+			})
+		}
+		return records, nil
+	})
+}
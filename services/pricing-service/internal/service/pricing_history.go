@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rideshare-platform/shared/export"
+)
+
+const (
+	// DefaultHistoryLimit is the page size used when a history query sets none
+	DefaultHistoryLimit = 20
+	// MaxHistoryLimit is the largest page a history query may ask for
+	MaxHistoryLimit = 100
+)
+
+// ErrInvalidHistoryQuery is returned for history queries with a bad page or date range
+var ErrInvalidHistoryQuery = errors.New("invalid pricing history query")
+
+// PricingRecord is a final pricing calculation kept for audit and support
+type PricingRecord struct {
+	ID              string           `json:"id"`
+	TripID          string           `json:"trip_id"`
+	RiderID         string           `json:"rider_id,omitempty"`
+	PickupArea      string           `json:"pickup_area,omitempty"`
+	VehicleType     string           `json:"vehicle_type"`
+	TotalFare       float64          `json:"total_fare"`
+	Currency        string           `json:"currency"`
+	SurgeMultiplier float64          `json:"surge_multiplier"`
+	PricingVersion  string           `json:"pricing_version"`
+	Request         *PricingRequest  `json:"request"`
+	Result          *PricingResponse `json:"result"`
+	CalculatedAt    time.Time        `json:"calculated_at"`
+}
+
+// HistoryFilter selects pricing records. Empty fields match everything, and
+// From and To bound the calculation time inclusively.
+type HistoryFilter struct {
+	TripID  string
+	RiderID string
+	Area    string
+	From    time.Time
+	To      time.Time
+	Limit   int
+	Offset  int
+}
+
+// HistoryPage is one page of pricing records, newest first
+type HistoryPage struct {
+	Records []*PricingRecord `json:"records"`
+	Total   int              `json:"total"`
+	Limit   int              `json:"limit"`
+	Offset  int              `json:"offset"`
+}
+
+// HistoryStore persists pricing records
+type HistoryStore interface {
+	Save(ctx context.Context, record *PricingRecord) error
+	// List returns the page of records matching the filter, newest first, and
+	// how many records match in total
+	List(ctx context.Context, filter HistoryFilter) ([]*PricingRecord, int, error)
+	// ListSince returns up to limit records calculated after the watermark,
+	// oldest first, for the warehouse export
+	ListSince(ctx context.Context, since export.Watermark, limit int) ([]*PricingRecord, error)
+}
+
+// SetHistoryStore sets where final pricing calculations are recorded
+func (s *AdvancedPricingService) SetHistoryStore(store HistoryStore) {
+	s.history = store
+}
+
+// RecordPricing keeps a final pricing calculation in the pricing history and
+// counts it in the pricing analytics. The history is skipped when no history
+// store is configured.
+func (s *AdvancedPricingService) RecordPricing(ctx context.Context, request *PricingRequest, response *PricingResponse) error {
+	now := time.Now().UTC()
+	s.recordFinalFare(s.assignExperiments(ctx, request), request, response, now)
+	if s.history == nil {
+		return nil
+	}
+
+	record := &PricingRecord{
+		TripID:          response.TripID,
+		RiderID:         request.RiderID,
+		PickupArea:      request.PickupArea,
+		VehicleType:     request.VehicleType,
+		TotalFare:       response.TotalFare,
+		Currency:        response.Currency,
+		SurgeMultiplier: response.SurgeMultiplier,
+		PricingVersion:  response.PricingVersion,
+		Request:         request,
+		Result:          response,
+		CalculatedAt:    now,
+	}
+	if err := s.history.Save(ctx, record); err != nil {
+		return fmt.Errorf("failed to record pricing for trip %s: %w", response.TripID, err)
+	}
+	return nil
+}
+
+// GetPricingHistory returns a page of recorded pricing calculations
+func (s *AdvancedPricingService) GetPricingHistory(ctx context.Context, filter HistoryFilter) (*HistoryPage, error) {
+	if filter.Limit == 0 {
+		filter.Limit = DefaultHistoryLimit
+	}
+	if filter.Limit < 0 || filter.Limit > MaxHistoryLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidHistoryQuery, MaxHistoryLimit)
+	}
+	if filter.Offset < 0 {
+		return nil, fmt.Errorf("%w: offset must not be negative", ErrInvalidHistoryQuery)
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && filter.From.After(filter.To) {
+		return nil, fmt.Errorf("%w: from must not be after to", ErrInvalidHistoryQuery)
+	}
+
+	page := &HistoryPage{Records: []*PricingRecord{}, Limit: filter.Limit, Offset: filter.Offset}
+	if s.history == nil {
+		return page, nil
+	}
+
+	records, total, err := s.history.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pricing history: %w", err)
+	}
+	if records != nil {
+		page.Records = records
+	}
+	page.Total = total
+	return page, nil
+}
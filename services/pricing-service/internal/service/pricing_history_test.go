@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordPricing_KeepsFinalFares(t *testing.T) {
+	ctx := context.Background()
+	pricing := NewAdvancedPricingService(nil)
+	pricing.SetHistoryStore(NewMemoryHistoryStore())
+
+	for _, waitingTime := range []int{0, 300} {
+		request := newFinalFareTestRequest()
+		request.WaitingTime = waitingTime
+		response, err := pricing.CalculatePrice(ctx, request)
+		assert.NoError(t, err)
+		assert.NoError(t, pricing.RecordPricing(ctx, request, response))
+	}
+
+	page, err := pricing.GetPricingHistory(ctx, HistoryFilter{TripID: "trip-final"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, page.Total)
+	assert.Equal(t, DefaultHistoryLimit, page.Limit)
+	assert.Len(t, page.Records, 2)
+	for _, record := range page.Records {
+		assert.NotEmpty(t, record.ID)
+		assert.Equal(t, "rider-1", record.RiderID)
+		assert.Equal(t, "v1.0", record.PricingVersion)
+		assert.Equal(t, record.Result.TotalFare, record.TotalFare)
+		assert.NotNil(t, record.Result.FareBreakdown)
+	}
+
+	empty, err := pricing.GetPricingHistory(ctx, HistoryFilter{TripID: "trip-unknown"})
+	assert.NoError(t, err)
+	assert.Zero(t, empty.Total)
+	assert.NotNil(t, empty.Records)
+}
+
+func TestGetPricingHistory_FiltersAndPages(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryHistoryStore()
+	pricing := NewAdvancedPricingService(nil)
+	pricing.SetHistoryStore(store)
+
+	day := time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)
+	records := []*PricingRecord{
+		{TripID: "trip-1", RiderID: "rider-1", PickupArea: "downtown", CalculatedAt: day.Add(9 * time.Hour)},
+		{TripID: "trip-2", RiderID: "rider-1", PickupArea: "airport", CalculatedAt: day.Add(10 * time.Hour)},
+		{TripID: "trip-3", RiderID: "rider-2", PickupArea: "downtown", CalculatedAt: day.Add(11 * time.Hour)},
+		{TripID: "trip-4", RiderID: "rider-1", PickupArea: "downtown", CalculatedAt: day.Add(36 * time.Hour)},
+	}
+	for _, record := range records {
+		assert.NoError(t, store.Save(ctx, record))
+	}
+
+	byRider, err := pricing.GetPricingHistory(ctx, HistoryFilter{RiderID: "rider-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, byRider.Total)
+	assert.Equal(t, "trip-4", byRider.Records[0].TripID, "newest first")
+
+	byArea, err := pricing.GetPricingHistory(ctx, HistoryFilter{RiderID: "rider-1", Area: "downtown"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, byArea.Total)
+
+	sameDay, err := pricing.GetPricingHistory(ctx, HistoryFilter{From: day, To: day.Add(24*time.Hour - time.Nanosecond)})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, sameDay.Total)
+
+	secondPage, err := pricing.GetPricingHistory(ctx, HistoryFilter{Limit: 2, Offset: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, secondPage.Total)
+	assert.Len(t, secondPage.Records, 2)
+	assert.Equal(t, "trip-2", secondPage.Records[0].TripID)
+	assert.Equal(t, "trip-1", secondPage.Records[1].TripID)
+}
+
+func TestGetPricingHistory_Validation(t *testing.T) {
+	ctx := context.Background()
+	pricing := NewAdvancedPricingService(nil)
+	pricing.SetHistoryStore(NewMemoryHistoryStore())
+	now := time.Now()
+
+	tests := []HistoryFilter{
+		{Limit: MaxHistoryLimit + 1},
+		{Limit: -1},
+		{Offset: -1},
+		{From: now, To: now.Add(-time.Hour)},
+	}
+	for _, filter := range tests {
+		_, err := pricing.GetPricingHistory(ctx, filter)
+		assert.True(t, errors.Is(err, ErrInvalidHistoryQuery), "filter %+v", filter)
+	}
+}
@@ -3,11 +3,20 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/redis/go-redis/v9"
+
+	"github.com/rideshare-platform/shared/analytics"
+	"github.com/rideshare-platform/shared/city"
+	"github.com/rideshare-platform/shared/currency"
+	"github.com/rideshare-platform/shared/experiments"
+	"github.com/rideshare-platform/shared/models"
 )
 
 // PricingRequest represents a pricing calculation request
@@ -21,6 +30,21 @@ type PricingRequest struct {
 	RequestTime     int64   `json:"request_time"`     // unix timestamp
 	RiderID         string  `json:"rider_id"`
 	PriorityLevel   int     `json:"priority_level"` // 0=economy, 1=standard, 2=premium
+	City            string  `json:"city,omitempty"` // sets the currency the fare is charged in
+	// Currency the trip was quoted in. The fare is rejected if the city charges
+	// in a different one.
+	Currency string `json:"currency,omitempty"`
+	// PickupLocation is checked against geo-service zones for surcharges
+	PickupLocation *models.Location `json:"pickup_location,omitempty"`
+	// DestinationLocation, with the pickup location, keys cached quotes
+	DestinationLocation *models.Location `json:"destination_location,omitempty"`
+	// LockedSurgeMultiplier is the surge quoted when the trip was requested.
+	// Completed trips are charged at it rather than the current surge.
+	LockedSurgeMultiplier float64 `json:"locked_surge_multiplier,omitempty"`
+	// QuoteID names a price lock. Re-quotes within its window and the final
+	// fare of a trip booked with it are priced at the surge it locked.
+	QuoteID     string `json:"quote_id,omitempty"`
+	WaitingTime int    `json:"waiting_time,omitempty"` // seconds the driver waited at pickup
 }
 
 // PricingResponse represents the pricing calculation result
@@ -29,14 +53,19 @@ type PricingResponse struct {
 	BaseFare         float64         `json:"base_fare"`
 	DistanceFare     float64         `json:"distance_fare"`
 	TimeFare         float64         `json:"time_fare"`
+	WaitingFare      float64         `json:"waiting_fare"`
 	SurgeFare        float64         `json:"surge_fare"`
+	ZoneSurcharge    float64         `json:"zone_surcharge"`
+	SurchargeZone    string          `json:"surcharge_zone,omitempty"`
 	DiscountAmount   float64         `json:"discount_amount"`
+	TaxAmount        float64         `json:"tax_amount"`
 	TotalFare        float64         `json:"total_fare"`
 	Currency         string          `json:"currency"`
 	SurgeMultiplier  float64         `json:"surge_multiplier"`
 	AppliedDiscounts []*DiscountInfo `json:"applied_discounts,omitempty"`
 	FareBreakdown    *FareBreakdown  `json:"fare_breakdown"`
 	ValidUntil       time.Time       `json:"valid_until"`
+	QuoteID          string          `json:"quote_id,omitempty"` // price lock guaranteeing the surge until ValidUntil
 	PricingVersion   string          `json:"pricing_version"`
 }
 
@@ -48,7 +77,12 @@ type FareBreakdown struct {
 	MinimumFare  float64 `json:"minimum_fare"`
 	MaximumFare  float64 `json:"maximum_fare"`
 	SurgeActive  bool    `json:"surge_active"`
+	SurgeLocked  bool    `json:"surge_locked"` // surge was quoted at request time
 	DemandLevel  string  `json:"demand_level"` // low, medium, high, extreme
+	WaitRate     float64 `json:"wait_rate"`    // per minute beyond the free waiting time
+
+	// TaxLines itemize the taxes included in the total
+	TaxLines []*TaxLine `json:"tax_lines,omitempty"`
 }
 
 // DiscountInfo represents applied discount information
@@ -62,6 +96,7 @@ type DiscountInfo struct {
 // SurgeInfo represents surge pricing information for an area
 type SurgeInfo struct {
 	Area             string    `json:"area"`
+	CityID           string    `json:"city_id,omitempty"`
 	Multiplier       float64   `json:"multiplier"`
 	DemandLevel      string    `json:"demand_level"`
 	ActiveRequests   int       `json:"active_requests"`
@@ -70,70 +105,108 @@ type SurgeInfo struct {
 	ExpiresAt        time.Time `json:"expires_at"`
 }
 
-// PricingAnalytics represents pricing analytics data
+// PricingAnalytics summarises the final fares calculated over a time range
 type PricingAnalytics struct {
-	TotalTrips          int            `json:"total_trips"`
-	AverageFare         float64        `json:"average_fare"`
-	TotalRevenue        float64        `json:"total_revenue"`
+	From       time.Time          `json:"from"`
+	To         time.Time          `json:"to"`
+	TotalTrips int                `json:"total_trips"`
+	Revenue    []*CurrencyRevenue `json:"revenue"`
+	// SurgePercentage and DiscountPercentage are the shares of trips charged
+	// a surge or given a discount, AverageSurge the mean multiplier of the
+	// surged trips
 	SurgePercentage     float64        `json:"surge_percentage"`
+	AverageSurge        float64        `json:"average_surge"`
 	DiscountPercentage  float64        `json:"discount_percentage"`
-	PeakHours           []int          `json:"peak_hours"`
+	PeakHours           []int          `json:"peak_hours"` // UTC hours, busiest first
 	PopularVehicleTypes map[string]int `json:"popular_vehicle_types"`
 }
 
 // AdvancedPricingService implements sophisticated pricing algorithms
 type AdvancedPricingService struct {
-	redis           *redis.Client
+	redis           redis.UniversalClient
 	vehicleRates    map[string]*VehicleRates
 	areaMultipliers map[string]float64
+	zones           ZoneLookup
+	history         HistoryStore
+	currencies      *currency.Cities
+	taxes           *TaxEngine
+	analytics       *analytics.Recorder
+	cities          *city.Registry
+	rateCards       RateCards
+	quotes          QuoteCache
+	quotePrecision  int
+	experiments     *experiments.Registry
+	priceLocks      PriceLockStore
+	quoteValidity   time.Duration
+	surgeLimits     SurgeLimits
+	pickupETAs      PickupETAEstimator
+	comparisons     ComparisonCache
+	// comparisonPrecision is the geohash precision comparisons are cached at
+	comparisonPrecision int
 }
 
 // VehicleRates defines pricing rates for different vehicle types
 type VehicleRates struct {
-	BaseFare     float64 `json:"base_fare"`
-	DistanceRate float64 `json:"distance_rate"` // per km
-	TimeRate     float64 `json:"time_rate"`     // per minute
-	MinimumFare  float64 `json:"minimum_fare"`
-	MaximumFare  float64 `json:"maximum_fare"`
+	BaseFare     float64 `json:"base_fare" yaml:"base_fare"`
+	DistanceRate float64 `json:"distance_rate" yaml:"distance_rate"` // per km
+	TimeRate     float64 `json:"time_rate" yaml:"time_rate"`         // per minute
+	MinimumFare  float64 `json:"minimum_fare" yaml:"minimum_fare"`
+	MaximumFare  float64 `json:"maximum_fare" yaml:"maximum_fare"`
+	// Waiting at pickup is free for FreeWaitMinutes, then charged at WaitRate per minute
+	FreeWaitMinutes float64 `json:"free_wait_minutes" yaml:"free_wait_minutes"`
+	WaitRate        float64 `json:"wait_rate" yaml:"wait_rate"`
 }
 
-// NewAdvancedPricingService creates a new advanced pricing service
-func NewAdvancedPricingService() *AdvancedPricingService {
-	// Initialize Redis client (with fallback handling)
-	rdb := redis.NewClient(&redis.Options{
-		Addr: "localhost:6379",
-		DB:   0,
-	})
-
+// NewAdvancedPricingService creates a new advanced pricing service. rdb may
+// be nil, surge and pricing data are then not cached.
+func NewAdvancedPricingService(rdb redis.UniversalClient) *AdvancedPricingService {
 	// Initialize vehicle rates
 	vehicleRates := map[string]*VehicleRates{
 		"economy": {
-			BaseFare:     2.50,
-			DistanceRate: 1.20,
-			TimeRate:     0.15,
-			MinimumFare:  5.00,
-			MaximumFare:  150.00,
+			BaseFare:        2.50,
+			DistanceRate:    1.20,
+			TimeRate:        0.15,
+			MinimumFare:     5.00,
+			MaximumFare:     150.00,
+			FreeWaitMinutes: 2,
+			WaitRate:        0.30,
 		},
 		"standard": {
-			BaseFare:     3.50,
-			DistanceRate: 1.50,
-			TimeRate:     0.20,
-			MinimumFare:  7.00,
-			MaximumFare:  200.00,
+			BaseFare:        3.50,
+			DistanceRate:    1.50,
+			TimeRate:        0.20,
+			MinimumFare:     7.00,
+			MaximumFare:     200.00,
+			FreeWaitMinutes: 2,
+			WaitRate:        0.40,
 		},
 		"premium": {
-			BaseFare:     5.00,
-			DistanceRate: 2.00,
-			TimeRate:     0.30,
-			MinimumFare:  10.00,
-			MaximumFare:  300.00,
+			BaseFare:        5.00,
+			DistanceRate:    2.00,
+			TimeRate:        0.30,
+			MinimumFare:     10.00,
+			MaximumFare:     300.00,
+			FreeWaitMinutes: 3,
+			WaitRate:        0.60,
 		},
 		"luxury": {
-			BaseFare:     8.00,
-			DistanceRate: 3.00,
-			TimeRate:     0.50,
-			MinimumFare:  15.00,
-			MaximumFare:  500.00,
+			BaseFare:        8.00,
+			DistanceRate:    3.00,
+			TimeRate:        0.50,
+			MinimumFare:     15.00,
+			MaximumFare:     500.00,
+			FreeWaitMinutes: 5,
+			WaitRate:        1.00,
+		},
+		// Wheelchair-accessible rides, with longer free waiting for boarding
+		models.RideTypeWAV: {
+			BaseFare:        3.50,
+			DistanceRate:    1.50,
+			TimeRate:        0.20,
+			MinimumFare:     7.00,
+			MaximumFare:     200.00,
+			FreeWaitMinutes: 6,
+			WaitRate:        0.40,
 		},
 	}
 
@@ -150,15 +223,47 @@ func NewAdvancedPricingService() *AdvancedPricingService {
 		redis:           rdb,
 		vehicleRates:    vehicleRates,
 		areaMultipliers: areaMultipliers,
+		analytics:       analytics.NewRecorder(analytics.NewMemoryStore(), AnalyticsSource, nil),
 	}
 }
 
 // CalculatePrice calculates the fare for a trip with advanced algorithms
 func (s *AdvancedPricingService) CalculatePrice(ctx context.Context, request *PricingRequest) (*PricingResponse, error) {
-	// Get vehicle rates
-	rates, exists := s.vehicleRates[request.VehicleType]
+	fareCurrency, err := s.fareCurrency(request)
+	if err != nil {
+		return nil, err
+	}
+	if request.QuoteID != "" {
+		lock, err := s.lockedSurge(ctx, request, false)
+		switch {
+		case err == nil:
+			request.LockedSurgeMultiplier = lock.SurgeMultiplier
+		case errors.Is(err, ErrPriceLockNotFound) && request.LockedSurgeMultiplier > 0:
+			// The lock is gone, the surge the trip recorded still stands
+		default:
+			return nil, err
+		}
+	}
+	ctx = s.assignExperiments(ctx, request)
+	return s.priceFare(ctx, request, s.calculateFare(ctx, request), fareCurrency), nil
+}
+
+// calculateFare works out the fare before discounts and taxes, the part of
+// a price that does not depend on the rider
+func (s *AdvancedPricingService) calculateFare(ctx context.Context, request *PricingRequest) *QuotedFare {
+	// Use the surge quoted at request time, or the current one
+	surgeMultiplier, surgeLocked := s.surgeMultiplier(ctx, request)
+	// Zone surcharges such as airport fees are added on top and never discounted
+	return s.fareAt(request, surgeMultiplier, surgeLocked, s.zoneSurcharge(ctx, request))
+}
+
+// fareAt works out the fare at a surge and zone surcharge already looked up,
+// so quotes for several vehicle types can share the lookups
+func (s *AdvancedPricingService) fareAt(request *PricingRequest, surgeMultiplier float64, surgeLocked bool, zoneSurcharge *ZoneSurcharge) *QuotedFare {
+	// Get the city's vehicle rates
+	rates, exists := s.vehicleRatesFor(request.City, request.VehicleType)
 	if !exists {
-		rates = s.vehicleRates["economy"] // Default to economy
+		rates, _ = s.vehicleRatesFor(request.City, "economy") // Default to economy
 	}
 
 	// Calculate base components
@@ -166,12 +271,6 @@ func (s *AdvancedPricingService) CalculatePrice(ctx context.Context, request *Pr
 	distanceFare := request.Distance * rates.DistanceRate
 	timeFare := float64(request.EstimatedTime) / 60.0 * rates.TimeRate
 
-	// Get current surge multiplier
-	surgeMultiplier, err := s.GetSurgeMultiplier(ctx, request.PickupArea)
-	if err != nil {
-		surgeMultiplier = 1.0 // Default if surge data unavailable
-	}
-
 	// Apply surge pricing
 	preSurgeFare := baseFare + distanceFare + timeFare
 	surgeFare := 0.0
@@ -185,8 +284,11 @@ func (s *AdvancedPricingService) CalculatePrice(ctx context.Context, request *Pr
 		areaMultiplier = 1.0
 	}
 
+	// Waiting at pickup is charged on top, without surge
+	waitingFare := waitingFare(rates, request.WaitingTime)
+
 	// Calculate total before discounts
-	totalBeforeDiscount := (preSurgeFare + surgeFare) * areaMultiplier
+	totalBeforeDiscount := (preSurgeFare+surgeFare)*areaMultiplier + waitingFare
 
 	// Apply minimum/maximum fare constraints
 	if totalBeforeDiscount < rates.MinimumFare {
@@ -196,15 +298,40 @@ func (s *AdvancedPricingService) CalculatePrice(ctx context.Context, request *Pr
 		totalBeforeDiscount = rates.MaximumFare
 	}
 
+	return &QuotedFare{
+		Rates:           rates,
+		BaseFare:        baseFare,
+		DistanceFare:    distanceFare,
+		TimeFare:        timeFare,
+		WaitingFare:     waitingFare,
+		SurgeFare:       surgeFare,
+		SurgeMultiplier: surgeMultiplier,
+		SurgeLocked:     surgeLocked,
+		Subtotal:        totalBeforeDiscount,
+		ZoneSurcharge:   zoneSurcharge,
+	}
+}
+
+// priceFare applies the rider's discounts and the taxes to a fare and caches
+// the price for validation
+func (s *AdvancedPricingService) priceFare(ctx context.Context, request *PricingRequest, fare *QuotedFare, fareCurrency string) *PricingResponse {
+	rates := fare.Rates
+	zoneSurcharge := fare.ZoneSurcharge
+
 	// Calculate discounts
-	discountAmount, appliedDiscounts, err := s.calculateDiscounts(ctx, request, totalBeforeDiscount)
+	discountAmount, appliedDiscounts, err := s.calculateDiscounts(ctx, request, fare.Subtotal)
 	if err != nil {
 		discountAmount = 0.0 // Fail gracefully
 		appliedDiscounts = []*DiscountInfo{}
 	}
 
-	// Final total
-	totalFare := math.Max(0, totalBeforeDiscount-discountAmount)
+	// Taxes are charged on top of the fare, surcharges included
+	fareBeforeTax := math.Max(0, fare.Subtotal-discountAmount) + zoneSurcharge.Amount
+	taxLines := s.taxLines(request, fareBeforeTax, zoneSurcharge, fareCurrency)
+	taxAmount := totalTax(taxLines)
+
+	// Final total, in whole minor units of the currency charged
+	totalFare := currency.Round(fareBeforeTax+taxAmount, fareCurrency)
 
 	// Create fare breakdown
 	fareBreakdown := &FareBreakdown{
@@ -213,39 +340,47 @@ func (s *AdvancedPricingService) CalculatePrice(ctx context.Context, request *Pr
 		TimeRate:     rates.TimeRate,
 		MinimumFare:  rates.MinimumFare,
 		MaximumFare:  rates.MaximumFare,
-		SurgeActive:  surgeMultiplier > 1.0,
-		DemandLevel:  s.getDemandLevel(surgeMultiplier),
+		SurgeActive:  fare.SurgeMultiplier > 1.0,
+		SurgeLocked:  fare.SurgeLocked,
+		DemandLevel:  s.getDemandLevel(fare.SurgeMultiplier),
+		WaitRate:     rates.WaitRate,
+		TaxLines:     taxLines,
 	}
 
 	response := &PricingResponse{
 		TripID:           request.TripID,
-		BaseFare:         baseFare,
-		DistanceFare:     distanceFare,
-		TimeFare:         timeFare,
-		SurgeFare:        surgeFare,
+		BaseFare:         fare.BaseFare,
+		DistanceFare:     fare.DistanceFare,
+		TimeFare:         fare.TimeFare,
+		WaitingFare:      fare.WaitingFare,
+		SurgeFare:        fare.SurgeFare,
+		ZoneSurcharge:    zoneSurcharge.Amount,
+		SurchargeZone:    zoneSurcharge.ZoneName,
 		DiscountAmount:   discountAmount,
+		TaxAmount:        taxAmount,
 		TotalFare:        totalFare,
-		Currency:         "USD",
-		SurgeMultiplier:  surgeMultiplier,
+		Currency:         fareCurrency,
+		SurgeMultiplier:  fare.SurgeMultiplier,
 		AppliedDiscounts: appliedDiscounts,
 		FareBreakdown:    fareBreakdown,
-		ValidUntil:       time.Now().Add(10 * time.Minute), // Price valid for 10 minutes
+		ValidUntil:       time.Now().Add(s.validity()),
 		PricingVersion:   "v1.0",
 	}
 
 	// Cache the pricing calculation
 	s.cachePricingResult(ctx, response)
 
-	return response, nil
+	return response
 }
 
-// GetSurgeMultiplier gets the current surge multiplier for an area
-func (s *AdvancedPricingService) GetSurgeMultiplier(ctx context.Context, area string) (float64, error) {
+// GetSurgeMultiplier gets the current surge multiplier for an area of a
+// city, capped at the city's maximum surge
+func (s *AdvancedPricingService) GetSurgeMultiplier(ctx context.Context, cityID, area string) (float64, error) {
 	if s.redis == nil {
 		return 1.0, nil // Default if Redis unavailable
 	}
 
-	key := fmt.Sprintf("surge:%s", area)
+	key := surgeKey(cityID, area)
 	val, err := s.redis.Get(ctx, key).Result()
 	if err == redis.Nil {
 		return 1.0, nil // No surge if key doesn't exist
@@ -264,17 +399,101 @@ func (s *AdvancedPricingService) GetSurgeMultiplier(ctx context.Context, area st
 		return 1.0, nil
 	}
 
-	return surgeInfo.Multiplier, nil
+	return s.capSurge(cityID, surgeInfo.Multiplier), nil
 }
 
-// UpdateSurgeMultiplier updates the surge multiplier for an area
-func (s *AdvancedPricingService) UpdateSurgeMultiplier(ctx context.Context, area string, multiplier float64, activeRequests, availableDrivers int) error {
+// ListSurgeAreas returns the unexpired surge of every area in a city, or in
+// every city when cityID is empty, highest multiplier first
+func (s *AdvancedPricingService) ListSurgeAreas(ctx context.Context, cityID string) ([]*SurgeInfo, error) {
+	if s.redis == nil {
+		return nil, nil // No surge is recorded without Redis
+	}
+
+	pattern := "surge:*"
+	if cityID != "" {
+		pattern = surgeKey(cityID, "*")
+	}
+
+	var keys []string
+	var mutex sync.Mutex
+	scan := func(ctx context.Context, client redis.UniversalClient) error {
+		iter := client.Scan(ctx, 0, pattern, 100).Iterator()
+		for iter.Next(ctx) {
+			mutex.Lock()
+			keys = append(keys, iter.Val())
+			mutex.Unlock()
+		}
+		return iter.Err()
+	}
+	// A cluster client only sees one node per scan, so every master is scanned
+	var err error
+	if cluster, ok := s.redis.(*redis.ClusterClient); ok {
+		err = cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+			return scan(ctx, node)
+		})
+	} else {
+		err = scan(ctx, s.redis)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan surge areas: %w", err)
+	}
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	// Areas hash to different cluster slots, so they are read in a pipeline
+	// rather than with one MGET
+	values := make([]*redis.StringCmd, len(keys))
+	_, err = s.redis.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			values[i] = pipe.Get(ctx, key)
+		}
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("failed to read surge areas: %w", err)
+	}
+
+	now := time.Now()
+	areas := make([]*SurgeInfo, 0, len(values))
+	for _, value := range values {
+		// Keys expiring between the scan and the read come back nil
+		raw, err := value.Result()
+		if err != nil {
+			continue
+		}
+		var surgeInfo SurgeInfo
+		if err := json.Unmarshal([]byte(raw), &surgeInfo); err != nil {
+			continue
+		}
+		if now.After(surgeInfo.ExpiresAt) {
+			continue
+		}
+		surgeInfo.Multiplier = s.capSurge(surgeInfo.CityID, surgeInfo.Multiplier)
+		areas = append(areas, &surgeInfo)
+	}
+
+	sort.Slice(areas, func(i, j int) bool {
+		if areas[i].Multiplier != areas[j].Multiplier {
+			return areas[i].Multiplier > areas[j].Multiplier
+		}
+		return areas[i].Area < areas[j].Area
+	})
+	return areas, nil
+}
+
+// UpdateSurgeMultiplier updates the surge multiplier for an area of a city,
+// rising by at most the city's surge step and capped at its maximum surge
+func (s *AdvancedPricingService) UpdateSurgeMultiplier(ctx context.Context, cityID, area string, multiplier float64, activeRequests, availableDrivers int) error {
 	if s.redis == nil {
 		return nil // Skip if Redis unavailable
 	}
 
+	cityID = city.Normalize(cityID)
+	multiplier = s.capSurge(cityID, s.smoothedSurge(ctx, cityID, area, multiplier))
 	surgeInfo := SurgeInfo{
 		Area:             area,
+		CityID:           cityID,
 		Multiplier:       multiplier,
 		DemandLevel:      s.getDemandLevel(multiplier),
 		ActiveRequests:   activeRequests,
@@ -288,8 +507,12 @@ func (s *AdvancedPricingService) UpdateSurgeMultiplier(ctx context.Context, area
 		return err
 	}
 
-	key := fmt.Sprintf("surge:%s", area)
-	return s.redis.SetEx(ctx, key, data, 15*time.Minute).Err()
+	if err := s.redis.SetEx(ctx, surgeKey(cityID, area), data, 15*time.Minute).Err(); err != nil {
+		return err
+	}
+	// Quotes priced at the old surge must not be served any more
+	s.invalidateQuotes(ctx, cityID, area)
+	return nil
 }
 
 // calculateDiscounts calculates applicable discounts for a trip
@@ -376,7 +599,7 @@ func (s *AdvancedPricingService) isOffPeakHour(timestamp int64) bool {
 }
 
 func (s *AdvancedPricingService) cachePricingResult(ctx context.Context, response *PricingResponse) {
-	if s.redis == nil {
+	if s.redis == nil || response.TripID == "" {
 		return
 	}
 
@@ -421,26 +644,6 @@ func (s *AdvancedPricingService) ValidatePrice(ctx context.Context, tripID strin
 	return isValid, &cachedResponse, nil
 }
 
-// GetPricingAnalytics returns comprehensive pricing analytics
-func (s *AdvancedPricingService) GetPricingAnalytics(ctx context.Context) (*PricingAnalytics, error) {
-	// In a real implementation, this would query the database
-	// For now, return mock analytics data
-	return &PricingAnalytics{
-		TotalTrips:         15420,
-		AverageFare:        18.75,
-		TotalRevenue:       289125.00,
-		SurgePercentage:    15.2,
-		DiscountPercentage: 8.5,
-		PeakHours:          []int{8, 9, 17, 18, 19},
-		PopularVehicleTypes: map[string]int{
-			"economy":  8950,
-			"standard": 4200,
-			"premium":  1850,
-			"luxury":   420,
-		},
-	}, nil
-}
-
 // CalculateFare calculates fare for a trip request
 func (s *AdvancedPricingService) CalculateFare(ctx context.Context, request *PricingRequest) (*PricingResponse, error) {
 	return s.CalculatePrice(ctx, request)
@@ -569,7 +772,7 @@ func (s *AdvancedPricingService) ValidateRequest(request *PricingRequest) error
 	}
 
 	// Validate vehicle type
-	if _, exists := s.vehicleRates[request.VehicleType]; !exists {
+	if _, exists := s.vehicleRatesFor(request.City, request.VehicleType); !exists {
 		return fmt.Errorf("invalid vehicle type: %s", request.VehicleType)
 	}
 
@@ -582,7 +785,29 @@ func (s *AdvancedPricingService) EstimateQuote(ctx context.Context, request *Pri
 		return nil, err
 	}
 
-	return s.CalculatePrice(ctx, request)
+	fareCurrency, err := s.fareCurrency(request)
+	if err != nil {
+		return nil, err
+	}
+
+	// A re-quote within a lock's window keeps the surge it locked
+	var lock *PriceLock
+	if request.QuoteID != "" {
+		if lock, err = s.lockedSurge(ctx, request, true); err != nil {
+			return nil, err
+		}
+		request.LockedSurgeMultiplier = lock.SurgeMultiplier
+	}
+
+	ctx = s.assignExperiments(ctx, request)
+	response := s.priceFare(ctx, request, s.quotedFare(ctx, request), fareCurrency)
+	if lock != nil {
+		response.QuoteID = lock.QuoteID
+		response.ValidUntil = lock.ValidUntil
+	} else {
+		s.lockQuote(ctx, request, response)
+	}
+	return response, nil
 }
 
 // GetVehicleRates returns pricing rates for a vehicle type
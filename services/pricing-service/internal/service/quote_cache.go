@@ -0,0 +1,241 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"pricing-service/internal/metrics"
+
+	"github.com/rideshare-platform/shared/experiments"
+)
+
+// QuotedFare is a fare before the rider's discounts and taxes. Quotes for the
+// same route, vehicle type and surge share it, which is what the quote cache
+// keeps.
+type QuotedFare struct {
+	Rates           *VehicleRates  `json:"rates"`
+	BaseFare        float64        `json:"base_fare"`
+	DistanceFare    float64        `json:"distance_fare"`
+	TimeFare        float64        `json:"time_fare"`
+	WaitingFare     float64        `json:"waiting_fare"`
+	SurgeFare       float64        `json:"surge_fare"`
+	SurgeMultiplier float64        `json:"surge_multiplier"`
+	SurgeLocked     bool           `json:"surge_locked"`
+	Subtotal        float64        `json:"subtotal"` // within the minimum and maximum fare
+	ZoneSurcharge   *ZoneSurcharge `json:"zone_surcharge"`
+}
+
+// QuoteCache keeps quoted fares for a short time, grouped by the surge area
+// they were priced in so a surge change can drop them
+type QuoteCache interface {
+	// Get returns the fare cached under key, or nil if there is none
+	Get(ctx context.Context, key string) (*QuotedFare, error)
+	Set(ctx context.Context, key, area string, fare *QuotedFare) error
+	// InvalidateArea drops the fares priced in a surge area and returns how
+	// many were dropped
+	InvalidateArea(ctx context.Context, area string) (int, error)
+}
+
+// SetQuoteCache caches quotes by the geohash cells of their pickup and
+// destination, at the given precision
+func (s *AdvancedPricingService) SetQuoteCache(cache QuoteCache, precision int) {
+	s.quotes = cache
+	s.quotePrecision = precision
+}
+
+// quotedFare returns the request's fare from the quote cache, calculating and
+// caching it on a miss. Cache failures fall back to calculating the fare.
+func (s *AdvancedPricingService) quotedFare(ctx context.Context, request *PricingRequest) *QuotedFare {
+	key := s.quoteKey(ctx, request)
+	if key == "" {
+		return s.calculateFare(ctx, request)
+	}
+
+	if fare, err := s.quotes.Get(ctx, key); err == nil && fare != nil {
+		metrics.RecordQuoteCacheHit()
+		return fare
+	}
+	metrics.RecordQuoteCacheMiss()
+
+	fare := s.calculateFare(ctx, request)
+	_ = s.quotes.Set(ctx, key, quoteArea(request.City, request.PickupArea), fare)
+	return fare
+}
+
+// quoteKey keys a quote by its surge area, vehicle type, the cells of its
+// pickup and destination and the surge experiment variant it is priced for.
+// Requests without both locations, or priced at a locked surge or with
+// waiting time, are not cached and get no key.
+func (s *AdvancedPricingService) quoteKey(ctx context.Context, request *PricingRequest) string {
+	if s.quotes == nil || request.PickupLocation == nil || request.DestinationLocation == nil {
+		return ""
+	}
+	if request.LockedSurgeMultiplier > 0 || request.WaitingTime > 0 {
+		return ""
+	}
+
+	pickupCell := request.PickupLocation.Geohash(s.quotePrecision)
+	destinationCell := request.DestinationLocation.Geohash(s.quotePrecision)
+	if pickupCell == "" || destinationCell == "" {
+		return ""
+	}
+	key := fmt.Sprintf("%s:%s:%s:%s", quoteArea(request.City, request.PickupArea), request.VehicleType, pickupCell, destinationCell)
+	if variant := experiments.VariantOf(ctx, SurgeExperiment); variant != "" {
+		key += ":" + variant
+	}
+	return key
+}
+
+// invalidateQuotes drops the cached quotes of a surge area
+func (s *AdvancedPricingService) invalidateQuotes(ctx context.Context, cityID, area string) {
+	if s.quotes == nil {
+		return
+	}
+	if dropped, err := s.quotes.InvalidateArea(ctx, quoteArea(cityID, area)); err == nil {
+		metrics.RecordQuotesInvalidated(dropped)
+	}
+}
+
+// quoteArea names a surge area the way its surge key does, scoped to its city
+func quoteArea(cityID, area string) string {
+	return strings.TrimPrefix(surgeKey(cityID, area), "surge:")
+}
+
+// RedisQuoteCache keeps quoted fares in Redis, shared by every replica. Each
+// surge area has a set of the keys cached in it.
+type RedisQuoteCache struct {
+	client redis.UniversalClient
+	ttl    time.Duration
+}
+
+// NewRedisQuoteCache creates a cache whose fares expire after ttl
+func NewRedisQuoteCache(client redis.UniversalClient, ttl time.Duration) *RedisQuoteCache {
+	return &RedisQuoteCache{client: client, ttl: ttl}
+}
+
+// Get returns the fare cached under key, or nil if there is none
+func (c *RedisQuoteCache) Get(ctx context.Context, key string) (*QuotedFare, error) {
+	data, err := c.client.Get(ctx, "quote_cache:"+key).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cached quote: %w", err)
+	}
+
+	var fare QuotedFare
+	if err := json.Unmarshal(data, &fare); err != nil {
+		return nil, fmt.Errorf("failed to decode cached quote: %w", err)
+	}
+	return &fare, nil
+}
+
+// Set caches a fare and adds its key to the area's set. The set expires with
+// the last fare added to it.
+func (c *RedisQuoteCache) Set(ctx context.Context, key, area string, fare *QuotedFare) error {
+	data, err := json.Marshal(fare)
+	if err != nil {
+		return err
+	}
+
+	areaKey := "quote_cache_area:" + area
+	pipe := c.client.Pipeline()
+	pipe.SetEx(ctx, "quote_cache:"+key, data, c.ttl)
+	pipe.SAdd(ctx, areaKey, key)
+	pipe.Expire(ctx, areaKey, c.ttl)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to cache quote: %w", err)
+	}
+	return nil
+}
+
+// InvalidateArea drops the fares cached in an area. Keys are removed from the
+// area's set one by one, so fares cached meanwhile stay indexed.
+func (c *RedisQuoteCache) InvalidateArea(ctx context.Context, area string) (int, error) {
+	areaKey := "quote_cache_area:" + area
+	keys, err := c.client.SMembers(ctx, areaKey).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read cached quotes: %w", err)
+	}
+	if len(keys) == 0 {
+		return 0, nil
+	}
+
+	members := make([]interface{}, len(keys))
+	cacheKeys := make([]string, len(keys))
+	for i, key := range keys {
+		members[i] = key
+		cacheKeys[i] = "quote_cache:" + key
+	}
+
+	pipe := c.client.Pipeline()
+	dropped := pipe.Del(ctx, cacheKeys...)
+	pipe.SRem(ctx, areaKey, members...)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("failed to drop cached quotes: %w", err)
+	}
+	return int(dropped.Val()), nil
+}
+
+// MemoryQuoteCache keeps quoted fares in memory
+type MemoryQuoteCache struct {
+	ttl     time.Duration
+	entries map[string]cachedQuote
+	mutex   sync.Mutex
+}
+
+type cachedQuote struct {
+	fare      *QuotedFare
+	area      string
+	expiresAt time.Time
+}
+
+// NewMemoryQuoteCache creates an in-memory cache whose fares expire after ttl
+func NewMemoryQuoteCache(ttl time.Duration) *MemoryQuoteCache {
+	return &MemoryQuoteCache{ttl: ttl, entries: make(map[string]cachedQuote)}
+}
+
+// Get returns the fare cached under key, or nil if there is none
+func (c *MemoryQuoteCache) Get(ctx context.Context, key string) (*QuotedFare, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, exists := c.entries[key]
+	if !exists {
+		return nil, nil
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		return nil, nil
+	}
+	return entry.fare, nil
+}
+
+// Set caches a fare
+func (c *MemoryQuoteCache) Set(ctx context.Context, key, area string, fare *QuotedFare) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.entries[key] = cachedQuote{fare: fare, area: area, expiresAt: time.Now().Add(c.ttl)}
+	return nil
+}
+
+// InvalidateArea drops the fares cached in an area
+func (c *MemoryQuoteCache) InvalidateArea(ctx context.Context, area string) (int, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	dropped := 0
+	for key, entry := range c.entries {
+		if entry.area == area {
+			delete(c.entries, key)
+			dropped++
+		}
+	}
+	return dropped, nil
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rideshare-platform/shared/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingZoneLookup counts the zone lookups a price needs
+type countingZoneLookup struct {
+	calls int
+}
+
+func (c *countingZoneLookup) ZoneSurcharge(ctx context.Context, location *models.Location) (*ZoneSurcharge, error) {
+	c.calls++
+	return &ZoneSurcharge{ZoneName: "JFK", ZoneType: "airport", Amount: 5}, nil
+}
+
+func newQuoteTestRequest(riderID string, pickup *models.Location) *PricingRequest {
+	return &PricingRequest{
+		TripID:              "quote-" + riderID,
+		Distance:            20,
+		EstimatedTime:       1800,
+		VehicleType:         "standard",
+		PickupArea:          "airport",
+		RequestTime:         time.Date(2024, 3, 4, 8, 0, 0, 0, time.Local).Unix(),
+		RiderID:             riderID,
+		City:                "nyc",
+		PickupLocation:      pickup,
+		DestinationLocation: &models.Location{Latitude: 40.7580, Longitude: -73.9855},
+	}
+}
+
+func TestQuoteCache_ReusesFareForSameCells(t *testing.T) {
+	ctx := context.Background()
+	zones := &countingZoneLookup{}
+	service := NewAdvancedPricingService(nil)
+	service.SetZoneLookup(zones)
+	service.SetQuoteCache(NewMemoryQuoteCache(time.Minute), 7)
+
+	first, err := service.EstimateQuote(ctx, newQuoteTestRequest("rider-1", &models.Location{Latitude: 40.6413, Longitude: -73.7781}))
+	assert.NoError(t, err)
+
+	// A few meters away is the same cell, and another rider's quote
+	second, err := service.EstimateQuote(ctx, newQuoteTestRequest("rider-2", &models.Location{Latitude: 40.64131, Longitude: -73.77811}))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, zones.calls)
+	assert.Equal(t, first.TotalFare, second.TotalFare)
+	assert.Equal(t, "quote-rider-2", second.TripID)
+
+	// Another pickup cell is priced afresh
+	_, err = service.EstimateQuote(ctx, newQuoteTestRequest("rider-1", &models.Location{Latitude: 40.6500, Longitude: -73.7900}))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, zones.calls)
+}
+
+func TestQuoteCache_SurgeChangeInvalidatesArea(t *testing.T) {
+	ctx := context.Background()
+	zones := &countingZoneLookup{}
+	service := NewAdvancedPricingService(nil)
+	service.SetZoneLookup(zones)
+	service.SetQuoteCache(NewMemoryQuoteCache(time.Minute), 7)
+
+	jfk := &models.Location{Latitude: 40.6413, Longitude: -73.7781}
+	_, err := service.EstimateQuote(ctx, newQuoteTestRequest("rider-1", jfk))
+	assert.NoError(t, err)
+
+	// Surge changing elsewhere leaves the quote cached
+	service.invalidateQuotes(ctx, "nyc", "downtown")
+	_, err = service.EstimateQuote(ctx, newQuoteTestRequest("rider-1", jfk))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, zones.calls)
+
+	service.invalidateQuotes(ctx, "NYC", "airport")
+	_, err = service.EstimateQuote(ctx, newQuoteTestRequest("rider-1", jfk))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, zones.calls)
+}
+
+func TestQuoteCache_SkipsRequestsWithoutDestination(t *testing.T) {
+	ctx := context.Background()
+	zones := &countingZoneLookup{}
+	service := NewAdvancedPricingService(nil)
+	service.SetZoneLookup(zones)
+	service.SetQuoteCache(NewMemoryQuoteCache(time.Minute), 7)
+
+	for i := 0; i < 2; i++ {
+		request := newQuoteTestRequest("rider-1", &models.Location{Latitude: 40.6413, Longitude: -73.7781})
+		request.DestinationLocation = nil
+		_, err := service.EstimateQuote(ctx, request)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 2, zones.calls)
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/rideshare-platform/shared/currency"
+)
+
+// SharedRideDiscount is the minimum discount a rider gets for sharing a ride
+const SharedRideDiscount = 0.25
+
+// SharedFareRider is one rider's direct (unshared) leg of a shared ride
+type SharedFareRider struct {
+	RiderID         string  `json:"rider_id"`
+	TripID          string  `json:"trip_id"`
+	DistanceKm      float64 `json:"distance_km" binding:"required"`
+	DurationMinutes int     `json:"duration_minutes"`
+}
+
+// SharedFareRequest asks for a shared route's fare to be split between its riders
+type SharedFareRequest struct {
+	VehicleType          string             `json:"vehicle_type"`
+	PickupArea           string             `json:"pickup_area"`
+	RouteDistanceKm      float64            `json:"route_distance_km"`
+	RouteDurationMinutes int                `json:"route_duration_minutes"`
+	City                 string             `json:"city,omitempty"` // sets the currency the fares are charged in
+	Riders               []*SharedFareRider `json:"riders" binding:"required,dive"`
+}
+
+// SharedFareShare is what one rider pays for a shared ride
+type SharedFareShare struct {
+	RiderID    string  `json:"rider_id"`
+	TripID     string  `json:"trip_id"`
+	SoloFare   float64 `json:"solo_fare"`
+	SharedFare float64 `json:"shared_fare"`
+	Savings    float64 `json:"savings"`
+}
+
+// SharedFareResponse is the result of splitting a shared route's fare
+type SharedFareResponse struct {
+	Shares          []*SharedFareShare `json:"shares"`
+	RouteFare       float64            `json:"route_fare"`
+	TotalFare       float64            `json:"total_fare"`
+	SurgeMultiplier float64            `json:"surge_multiplier"`
+	Currency        string             `json:"currency"`
+}
+
+// SplitSharedFare prices the shared route as a single ride and splits it between
+// riders in proportion to their direct distance. No rider pays more than their
+// solo fare less the shared ride discount.
+func (s *AdvancedPricingService) SplitSharedFare(ctx context.Context, request *SharedFareRequest) (*SharedFareResponse, error) {
+	if len(request.Riders) == 0 {
+		return nil, fmt.Errorf("at least one rider is required")
+	}
+
+	rates, exists := s.vehicleRatesFor(request.City, request.VehicleType)
+	if !exists {
+		rates, _ = s.vehicleRatesFor(request.City, "economy")
+	}
+
+	surgeMultiplier, err := s.GetSurgeMultiplier(ctx, request.City, request.PickupArea)
+	if err != nil {
+		surgeMultiplier = 1.0
+	}
+
+	totalDistance := 0.0
+	for _, rider := range request.Riders {
+		if rider.DistanceKm <= 0 {
+			return nil, fmt.Errorf("distance for rider %s must be greater than 0", rider.RiderID)
+		}
+		totalDistance += rider.DistanceKm
+	}
+
+	routeDistance := request.RouteDistanceKm
+	routeDuration := request.RouteDurationMinutes
+	if routeDistance <= 0 {
+		routeDistance = totalDistance
+	}
+	fareCurrency := s.cityCurrency(request.City)
+	routeFare := s.rideFare(rates, routeDistance, routeDuration, surgeMultiplier, fareCurrency)
+
+	response := &SharedFareResponse{
+		RouteFare:       routeFare,
+		SurgeMultiplier: surgeMultiplier,
+		Currency:        fareCurrency,
+	}
+
+	for _, rider := range request.Riders {
+		soloFare := s.rideFare(rates, rider.DistanceKm, rider.DurationMinutes, surgeMultiplier, fareCurrency)
+		sharedFare := routeFare * rider.DistanceKm / totalDistance
+		sharedFare = math.Min(sharedFare, soloFare*(1-SharedRideDiscount))
+		sharedFare = currency.Round(math.Max(sharedFare, rates.BaseFare), fareCurrency)
+
+		response.Shares = append(response.Shares, &SharedFareShare{
+			RiderID:    rider.RiderID,
+			TripID:     rider.TripID,
+			SoloFare:   soloFare,
+			SharedFare: sharedFare,
+			Savings:    currency.Round(soloFare-sharedFare, fareCurrency),
+		})
+		response.TotalFare += sharedFare
+	}
+	response.TotalFare = currency.Round(response.TotalFare, fareCurrency)
+
+	return response, nil
+}
+
+// rideFare prices a single ride with surge, clamped to the vehicle's fare
+// limits and rounded to the currency's minor unit
+func (s *AdvancedPricingService) rideFare(rates *VehicleRates, distanceKm float64, durationMinutes int, surgeMultiplier float64, fareCurrency string) float64 {
+	fare := rates.BaseFare + distanceKm*rates.DistanceRate + float64(durationMinutes)*rates.TimeRate
+	if surgeMultiplier > 1.0 {
+		fare *= surgeMultiplier
+	}
+	fare = math.Max(fare, rates.MinimumFare)
+	fare = math.Min(fare, rates.MaximumFare)
+	return currency.Round(fare, fareCurrency)
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newSharedFareTestService() *AdvancedPricingService {
+	return NewAdvancedPricingService(nil)
+}
+
+func TestSplitSharedFare_DiscountsEachRider(t *testing.T) {
+	service := newSharedFareTestService()
+
+	response, err := service.SplitSharedFare(context.Background(), &SharedFareRequest{
+		VehicleType:          "standard",
+		RouteDistanceKm:      14,
+		RouteDurationMinutes: 30,
+		Riders: []*SharedFareRider{
+			{RiderID: "rider-a", TripID: "trip-a", DistanceKm: 10, DurationMinutes: 20},
+			{RiderID: "rider-b", TripID: "trip-b", DistanceKm: 6, DurationMinutes: 12},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, response.Shares, 2)
+	assert.Equal(t, 30.5, response.RouteFare)
+
+	for _, share := range response.Shares {
+		assert.LessOrEqual(t, share.SharedFare, share.SoloFare*(1-SharedRideDiscount)+0.01)
+		assert.InDelta(t, share.SoloFare-share.SharedFare, share.Savings, 0.01)
+	}
+
+	// The longer leg pays the larger share
+	assert.Greater(t, response.Shares[0].SharedFare, response.Shares[1].SharedFare)
+	assert.InDelta(t, response.Shares[0].SharedFare+response.Shares[1].SharedFare, response.TotalFare, 0.01)
+}
+
+func TestSplitSharedFare_ProportionalWhenRouteIsCheap(t *testing.T) {
+	service := newSharedFareTestService()
+
+	// Two riders with identical legs along the same road share the route fare evenly
+	response, err := service.SplitSharedFare(context.Background(), &SharedFareRequest{
+		VehicleType:          "economy",
+		RouteDistanceKm:      10,
+		RouteDurationMinutes: 20,
+		Riders: []*SharedFareRider{
+			{RiderID: "rider-a", DistanceKm: 10, DurationMinutes: 20},
+			{RiderID: "rider-b", DistanceKm: 10, DurationMinutes: 20},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, response.Shares[0].SharedFare, response.Shares[1].SharedFare)
+	assert.InDelta(t, response.RouteFare/2, response.Shares[0].SharedFare, 0.01)
+}
+
+func TestSplitSharedFare_Validation(t *testing.T) {
+	service := newSharedFareTestService()
+	ctx := context.Background()
+
+	_, err := service.SplitSharedFare(ctx, &SharedFareRequest{VehicleType: "economy"})
+	assert.Error(t, err)
+
+	_, err = service.SplitSharedFare(ctx, &SharedFareRequest{
+		VehicleType: "economy",
+		Riders:      []*SharedFareRider{{RiderID: "rider-a", DistanceKm: 0}},
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"math"
+)
+
+// SurgeLimits keep surge fair to riders. Cities' own policies override them.
+type SurgeLimits struct {
+	// MaxMultiplier caps every surge, locked surges included; 0 leaves
+	// surge uncapped
+	MaxMultiplier float64
+	// MaxStep is the most an area's surge can rise by in one update, so it
+	// climbs gradually instead of spiking; 0 lets it jump. Surge falls
+	// without limit.
+	MaxStep float64
+}
+
+// SetSurgeLimits sets the surge cap and smoothing of cities without their own
+func (s *AdvancedPricingService) SetSurgeLimits(limits SurgeLimits) {
+	s.surgeLimits = limits
+}
+
+// maxSurgeStep returns how far surge may rise per update in a city
+func (s *AdvancedPricingService) maxSurgeStep(cityID string) float64 {
+	if step := s.cities.Policies(cityID).MaxSurgeStep; step > 0 {
+		return step
+	}
+	return s.surgeLimits.MaxStep
+}
+
+// smoothSurge moves an area's surge from previous towards target, rising by
+// at most step
+func smoothSurge(previous, target, step float64) float64 {
+	if step <= 0 || target <= previous {
+		return target
+	}
+	return math.Min(target, previous+step)
+}
+
+// smoothedSurge limits how far an area's surge rises from its current value.
+// Areas without a current surge start from no surge.
+func (s *AdvancedPricingService) smoothedSurge(ctx context.Context, cityID, area string, multiplier float64) float64 {
+	step := s.maxSurgeStep(cityID)
+	if step <= 0 {
+		return multiplier
+	}
+	previous, err := s.GetSurgeMultiplier(ctx, cityID, area)
+	if err != nil {
+		previous = 1.0
+	}
+	return smoothSurge(math.Max(previous, 1.0), multiplier, step)
+}
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/rideshare-platform/shared/currency"
+)
+
+// Tax rule types
+const (
+	TaxTypePercentage = "percentage" // share of the fare, such as VAT
+	TaxTypeFixed      = "fixed"      // flat fee per trip
+	TaxTypeZoneLevy   = "zone_levy"  // flat fee for pickups in a zone type, such as an airport levy
+)
+
+// DefaultTaxRegion holds the rules for cities without rules of their own
+const DefaultTaxRegion = "default"
+
+// TaxRule is a tax or levy charged in a region while it is in effect
+type TaxRule struct {
+	Name string `yaml:"name" json:"name"`
+	Type string `yaml:"type" json:"type"` // percentage, fixed, zone_levy
+	// Rate of percentage rules, 0.18 for 18%
+	Rate float64 `yaml:"rate,omitempty" json:"rate,omitempty"`
+	// Amount of fixed and zone levy rules, in the region's currency
+	Amount float64 `yaml:"amount,omitempty" json:"amount,omitempty"`
+	// ZoneType of zone levy rules, matched against geo-service zone types
+	ZoneType string `yaml:"zone_type,omitempty" json:"zone_type,omitempty"`
+	// The rule applies to trips from EffectiveFrom up to but excluding
+	// EffectiveTo. Zero values leave that end open.
+	EffectiveFrom time.Time `yaml:"effective_from,omitempty" json:"effective_from,omitempty"`
+	EffectiveTo   time.Time `yaml:"effective_to,omitempty" json:"effective_to,omitempty"`
+}
+
+// TaxConfig holds the tax rules of each region, keyed by city. Cities without
+// rules use the DefaultTaxRegion rules, if any.
+type TaxConfig struct {
+	Regions map[string][]*TaxRule `yaml:"regions" json:"regions"`
+}
+
+// TaxLine is one tax charged on a fare
+type TaxLine struct {
+	Name   string  `json:"name"`
+	Type   string  `json:"type"`
+	Rate   float64 `json:"rate,omitempty"`
+	Amount float64 `json:"amount"`
+}
+
+// TaxEngine applies the tax rules of the region a trip is in
+type TaxEngine struct {
+	regions map[string][]*TaxRule
+}
+
+// NewTaxEngine creates a tax engine from validated rules
+func NewTaxEngine(config *TaxConfig) (*TaxEngine, error) {
+	engine := &TaxEngine{regions: make(map[string][]*TaxRule)}
+	if config == nil {
+		return engine, nil
+	}
+
+	for region, rules := range config.Regions {
+		for i, rule := range rules {
+			if err := rule.validate(); err != nil {
+				return nil, fmt.Errorf("invalid tax rule %d for %s: %w", i, region, err)
+			}
+		}
+		engine.regions[taxRegionKey(region)] = rules
+	}
+	return engine, nil
+}
+
+// LoadTaxEngine creates a tax engine from a YAML (or JSON) file of tax rules
+func LoadTaxEngine(path string) (*TaxEngine, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tax config: %w", err)
+	}
+
+	var config TaxConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse tax config %s: %w", path, err)
+	}
+	return NewTaxEngine(&config)
+}
+
+func (r *TaxRule) validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	switch r.Type {
+	case TaxTypePercentage:
+		if r.Rate <= 0 || r.Rate >= 1 {
+			return fmt.Errorf("rate of %s must be between 0 and 1", r.Name)
+		}
+	case TaxTypeFixed, TaxTypeZoneLevy:
+		if r.Amount <= 0 {
+			return fmt.Errorf("amount of %s must be positive", r.Name)
+		}
+		if r.Type == TaxTypeZoneLevy && r.ZoneType == "" {
+			return fmt.Errorf("zone levy %s needs a zone_type", r.Name)
+		}
+	default:
+		return fmt.Errorf("unknown tax type %q", r.Type)
+	}
+	if !r.EffectiveTo.IsZero() && !r.EffectiveTo.After(r.EffectiveFrom) {
+		return fmt.Errorf("%s ends before it takes effect", r.Name)
+	}
+	return nil
+}
+
+// inEffect reports whether the rule applies to a trip at the given time
+func (r *TaxRule) inEffect(at time.Time) bool {
+	if !r.EffectiveFrom.IsZero() && at.Before(r.EffectiveFrom) {
+		return false
+	}
+	return r.EffectiveTo.IsZero() || at.Before(r.EffectiveTo)
+}
+
+// Calculate returns the taxes on a fare of taxable in a city at the given time.
+// Percentage taxes are charged on the fare only, never on other taxes. Zone
+// levies apply when the pickup is in a zone of their type. Amounts are rounded
+// to the currency's minor unit.
+func (e *TaxEngine) Calculate(city string, at time.Time, taxable float64, pickupZoneTypes []string, fareCurrency string) []*TaxLine {
+	rules, exists := e.regions[taxRegionKey(city)]
+	if !exists {
+		rules = e.regions[DefaultTaxRegion]
+	}
+
+	var lines []*TaxLine
+	for _, rule := range rules {
+		if !rule.inEffect(at) {
+			continue
+		}
+
+		line := &TaxLine{Name: rule.Name, Type: rule.Type}
+		switch rule.Type {
+		case TaxTypePercentage:
+			line.Rate = rule.Rate
+			line.Amount = math.Max(0, taxable) * rule.Rate
+		case TaxTypeFixed:
+			line.Amount = rule.Amount
+		case TaxTypeZoneLevy:
+			if !containsZoneType(pickupZoneTypes, rule.ZoneType) {
+				continue
+			}
+			line.Amount = rule.Amount
+		}
+		line.Amount = currency.Round(line.Amount, fareCurrency)
+		if line.Amount > 0 {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// SetTaxEngine enables taxes on fares
+func (s *AdvancedPricingService) SetTaxEngine(engine *TaxEngine) {
+	s.taxes = engine
+}
+
+// taxLines returns the taxes on a fare, none when no tax engine is set
+func (s *AdvancedPricingService) taxLines(request *PricingRequest, taxable float64, zone *ZoneSurcharge, fareCurrency string) []*TaxLine {
+	if s.taxes == nil {
+		return nil
+	}
+
+	at := time.Now()
+	if request.RequestTime > 0 {
+		at = time.Unix(request.RequestTime, 0)
+	}
+	return s.taxes.Calculate(request.City, at, taxable, zone.zoneTypes(), fareCurrency)
+}
+
+// totalTax sums the amounts of tax lines
+func totalTax(lines []*TaxLine) float64 {
+	var total float64
+	for _, line := range lines {
+		total += line.Amount
+	}
+	return total
+}
+
+func containsZoneType(zoneTypes []string, zoneType string) bool {
+	for _, candidate := range zoneTypes {
+		if strings.EqualFold(candidate, zoneType) {
+			return true
+		}
+	}
+	return false
+}
+
+func taxRegionKey(region string) string {
+	return strings.ToLower(strings.TrimSpace(region))
+}
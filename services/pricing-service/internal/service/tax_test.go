@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rideshare-platform/shared/models"
+	"github.com/stretchr/testify/assert"
+)
+
+const testTaxConfig = `
+regions:
+  istanbul:
+    - name: KDV
+      type: percentage
+      rate: 0.20
+      effective_from: 2023-07-10T00:00:00Z
+    - name: KDV
+      type: percentage
+      rate: 0.18
+      effective_to: 2023-07-10T00:00:00Z
+  default:
+    - name: Sales tax
+      type: percentage
+      rate: 0.08
+    - name: Congestion fee
+      type: fixed
+      amount: 2.75
+      effective_from: 2024-01-01T00:00:00Z
+    - name: Airport levy
+      type: zone_levy
+      zone_type: airport
+      amount: 4
+`
+
+func newTaxTestService(t *testing.T) *AdvancedPricingService {
+	path := filepath.Join(t.TempDir(), "taxes.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(testTaxConfig), 0o600))
+	taxes, err := LoadTaxEngine(path)
+	assert.NoError(t, err)
+
+	pricing := NewAdvancedPricingService(nil)
+	pricing.SetTaxEngine(taxes)
+	return pricing
+}
+
+func TestCalculatePrice_AddsRegionalTaxes(t *testing.T) {
+	ctx := context.Background()
+	untaxed, err := NewAdvancedPricingService(nil).CalculatePrice(ctx, newZoneTestRequest(nil))
+	assert.NoError(t, err)
+	assert.Zero(t, untaxed.TaxAmount)
+
+	pricing := newTaxTestService(t)
+	response, err := pricing.CalculatePrice(ctx, newZoneTestRequest(nil))
+	assert.NoError(t, err)
+	lines := response.FareBreakdown.TaxLines
+	assert.Len(t, lines, 2, "no airport levy without a pickup zone")
+	assert.Equal(t, "Sales tax", lines[0].Name)
+	assert.InDelta(t, untaxed.TotalFare*0.08, lines[0].Amount, 0.006)
+	assert.Equal(t, 2.75, lines[1].Amount)
+	assert.InDelta(t, untaxed.TotalFare+response.TaxAmount, response.TotalFare, 0.006)
+
+	// Pickups in an airport zone pay the levy, even without a surcharge
+	pricing.SetZoneLookup(&fakeZoneLookup{surcharge: &ZoneSurcharge{ZoneTypes: []string{"airport"}}})
+	jfk := &models.Location{Latitude: 40.6413, Longitude: -73.7781}
+	response, err = pricing.CalculatePrice(ctx, newZoneTestRequest(jfk))
+	assert.NoError(t, err)
+	assert.Len(t, response.FareBreakdown.TaxLines, 3)
+	assert.Equal(t, "zone_levy", response.FareBreakdown.TaxLines[2].Type)
+}
+
+func TestCalculatePrice_TaxRulesFollowEffectiveDates(t *testing.T) {
+	ctx := context.Background()
+	pricing := newTaxTestService(t)
+
+	request := newZoneTestRequest(nil)
+	request.City = "Istanbul"
+	request.RequestTime = time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC).Unix()
+	before, err := pricing.CalculatePrice(ctx, request)
+	assert.NoError(t, err)
+	assert.Len(t, before.FareBreakdown.TaxLines, 1)
+	assert.Equal(t, 0.18, before.FareBreakdown.TaxLines[0].Rate)
+
+	request.RequestTime = time.Date(2023, 7, 10, 0, 0, 0, 0, time.UTC).Unix()
+	after, err := pricing.CalculatePrice(ctx, request)
+	assert.NoError(t, err)
+	assert.Len(t, after.FareBreakdown.TaxLines, 1)
+	assert.Equal(t, 0.20, after.FareBreakdown.TaxLines[0].Rate)
+
+	// The congestion fee only applies from 2024 in the default region
+	request.City = ""
+	request.RequestTime = time.Date(2023, 12, 31, 12, 0, 0, 0, time.UTC).Unix()
+	response, err := pricing.CalculatePrice(ctx, request)
+	assert.NoError(t, err)
+	assert.Len(t, response.FareBreakdown.TaxLines, 1)
+}
+
+func TestNewTaxEngine_RejectsInvalidRules(t *testing.T) {
+	effective := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []*TaxRule{
+		{Type: TaxTypeFixed, Amount: 1},
+		{Name: "VAT", Type: TaxTypePercentage, Rate: 20},
+		{Name: "Fee", Type: TaxTypeFixed},
+		{Name: "Levy", Type: TaxTypeZoneLevy, Amount: 3},
+		{Name: "Toll", Type: "toll", Amount: 1},
+		{Name: "Fee", Type: TaxTypeFixed, Amount: 1, EffectiveFrom: effective, EffectiveTo: effective},
+	}
+	for _, rule := range tests {
+		_, err := NewTaxEngine(&TaxConfig{Regions: map[string][]*TaxRule{"default": {rule}}})
+		assert.Error(t, err, "rule %+v", rule)
+	}
+}
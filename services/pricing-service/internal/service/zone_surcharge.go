@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+
+	"github.com/rideshare-platform/shared/models"
+)
+
+// ZoneSurcharge is the flat fee for a pickup inside a geo-service zone
+type ZoneSurcharge struct {
+	ZoneID   string  `json:"zone_id"`
+	ZoneName string  `json:"zone_name"`
+	ZoneType string  `json:"zone_type"` // airport, event_venue, restricted
+	Amount   float64 `json:"amount"`
+
+	// ZoneTypes are the types of every zone containing the location, including
+	// zones without a surcharge, for zone levies
+	ZoneTypes []string `json:"zone_types,omitempty"`
+}
+
+// ZoneLookup finds the surcharge for pickups at a location
+type ZoneLookup interface {
+	// ZoneSurcharge returns the highest surcharge of the zones containing the
+	// location along with the types of all of them, or nil if the location is
+	// in no zone
+	ZoneSurcharge(ctx context.Context, location *models.Location) (*ZoneSurcharge, error)
+}
+
+// SetZoneLookup sets the geo-service client used to add zone surcharges
+func (s *AdvancedPricingService) SetZoneLookup(lookup ZoneLookup) {
+	s.zones = lookup
+}
+
+// zoneSurcharge returns the request's pickup zone surcharge. Requests without
+// a pickup location, or whose zones cannot be looked up, are not surcharged.
+func (s *AdvancedPricingService) zoneSurcharge(ctx context.Context, request *PricingRequest) *ZoneSurcharge {
+	if s.zones == nil || request.PickupLocation == nil {
+		return &ZoneSurcharge{}
+	}
+
+	surcharge, err := s.zones.ZoneSurcharge(ctx, request.PickupLocation)
+	if err != nil || surcharge == nil {
+		return &ZoneSurcharge{}
+	}
+	return surcharge
+}
+
+// zoneTypes returns the types of the zones the pickup is in
+func (z *ZoneSurcharge) zoneTypes() []string {
+	if len(z.ZoneTypes) == 0 && z.ZoneType != "" {
+		return []string{z.ZoneType}
+	}
+	return z.ZoneTypes
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rideshare-platform/shared/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeZoneLookup returns a fixed surcharge or an error
+type fakeZoneLookup struct {
+	surcharge *ZoneSurcharge
+	err       error
+}
+
+func (f *fakeZoneLookup) ZoneSurcharge(ctx context.Context, location *models.Location) (*ZoneSurcharge, error) {
+	return f.surcharge, f.err
+}
+
+func newZoneTestRequest(pickup *models.Location) *PricingRequest {
+	return &PricingRequest{
+		TripID:         "trip-zone",
+		Distance:       20,
+		EstimatedTime:  1800,
+		VehicleType:    "standard",
+		RequestTime:    time.Date(2024, 3, 4, 8, 0, 0, 0, time.Local).Unix(),
+		RiderID:        "rider-1",
+		PickupLocation: pickup,
+	}
+}
+
+func TestZoneSurcharge_AddedOnTopOfFare(t *testing.T) {
+	ctx := context.Background()
+	jfk := &models.Location{Latitude: 40.6413, Longitude: -73.7781}
+
+	base, err := NewAdvancedPricingService(nil).CalculatePrice(ctx, newZoneTestRequest(jfk))
+	assert.NoError(t, err)
+	assert.Zero(t, base.ZoneSurcharge)
+
+	service := NewAdvancedPricingService(nil)
+	service.SetZoneLookup(&fakeZoneLookup{surcharge: &ZoneSurcharge{ZoneID: "zone-jfk", ZoneName: "JFK", ZoneType: "airport", Amount: 5}})
+
+	surcharged, err := service.CalculatePrice(ctx, newZoneTestRequest(jfk))
+	assert.NoError(t, err)
+	assert.Equal(t, 5.0, surcharged.ZoneSurcharge)
+	assert.Equal(t, "JFK", surcharged.SurchargeZone)
+	assert.InDelta(t, base.TotalFare+5, surcharged.TotalFare, 0.001)
+
+	// Without a pickup location there is nothing to look up
+	unlocated, err := service.CalculatePrice(ctx, newZoneTestRequest(nil))
+	assert.NoError(t, err)
+	assert.Zero(t, unlocated.ZoneSurcharge)
+}
+
+func TestZoneSurcharge_LookupFailureSkipsSurcharge(t *testing.T) {
+	service := NewAdvancedPricingService(nil)
+	service.SetZoneLookup(&fakeZoneLookup{err: errors.New("geo-service unavailable")})
+
+	response, err := service.CalculatePrice(context.Background(), newZoneTestRequest(&models.Location{Latitude: 40.6413, Longitude: -73.7781}))
+	assert.NoError(t, err)
+	assert.Zero(t, response.ZoneSurcharge)
+	assert.Greater(t, response.TotalFare, 0.0)
+}
@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 	"log"
 	"net"
 	"net/http"
@@ -10,43 +12,233 @@ import (
 	"syscall"
 	"time"
 
+	"pricing-service/internal/client"
 	"pricing-service/internal/config"
 	"pricing-service/internal/handler"
 	"pricing-service/internal/service"
+	"pricing-service/migrations"
 
 	"github.com/gin-gonic/gin"
+	_ "github.com/lib/pq"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
 
+	"github.com/rideshare-platform/shared/analytics"
+	"github.com/rideshare-platform/shared/city"
+	sharedconfig "github.com/rideshare-platform/shared/config"
+	"github.com/rideshare-platform/shared/currency"
+	"github.com/rideshare-platform/shared/database"
+	"github.com/rideshare-platform/shared/experiments"
+	"github.com/rideshare-platform/shared/export"
+	sharedgrpc "github.com/rideshare-platform/shared/grpc"
+	"github.com/rideshare-platform/shared/grpc/interceptor"
+	sharedhealth "github.com/rideshare-platform/shared/health"
 	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/middleware"
+	"github.com/rideshare-platform/shared/monitoring"
 	pricingpb "github.com/rideshare-platform/shared/proto/pricing"
+	sharedtls "github.com/rideshare-platform/shared/tls"
 )
 
 func main() {
 	// Load configuration
-	cfg := config.Load()
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	// Initialize logger, following log_level changes in the config file
+	appLogger := logger.NewLogger(cfg.Dynamic.LogLevel, cfg.Environment)
+	dynamicConfig, err := sharedconfig.NewReloadable(sharedconfig.NewLoader())
+	if err != nil {
+		log.Fatalf("Failed to load dynamic configuration: %v", err)
+	}
+
+	tlsReloader, err := sharedtls.Load(cfg.TLS, appLogger)
+	if err != nil {
+		log.Fatalf("Failed to load TLS certificates: %v", err)
+	}
+	go tlsReloader.Watch(context.Background())
+	dynamicConfig.BindLogLevel(appLogger)
+	watchCtx, stopWatching := context.WithCancel(context.Background())
+	defer stopWatching()
+	go dynamicConfig.StartWatching(watchCtx, 30*time.Second, appLogger)
 
 	// Initialize services
-	pricingService := service.NewAdvancedPricingService()
+	redisDB, err := database.NewRedisDB(cfg.Redis(), appLogger)
+	if err != nil {
+		log.Fatalf("Failed to connect to Redis: %v", err)
+	}
+	defer redisDB.Close()
+	redisClient := redisDB.Client
+	pricingService := service.NewAdvancedPricingService(redisClient)
+
+	// Fares are charged in the currency of the city the trip starts in
+	cityCurrencies, err := currency.ParseCities(cfg.DefaultCurrency, cfg.CityCurrencies)
+	if err != nil {
+		log.Fatalf("Invalid currency configuration: %v", err)
+	}
 
-	// Initialize logger
-	appLogger := logger.NewLogger("info", "development")
+	// Each city has its own currency, surge cap and rate cards
+	if cfg.CitiesFile != "" {
+		cities, err := city.Load(cfg.CitiesFile)
+		if err != nil {
+			log.Fatalf("Failed to load cities: %v", err)
+		}
+		rateCards, err := service.LoadRateCards(cfg.CitiesFile, cities)
+		if err != nil {
+			log.Fatalf("Invalid rate cards: %v", err)
+		}
+		if cityCurrencies, err = cities.Currencies(cfg.DefaultCurrency); err != nil {
+			log.Fatalf("Invalid currency configuration: %v", err)
+		}
+		pricingService.SetCities(cities)
+		pricingService.SetRateCards(rateCards)
+	}
+	pricingService.SetCurrencies(cityCurrencies)
+
+	// A/B experiments branch the surge charged per rider or trip
+	if cfg.ExperimentsFile != "" {
+		registry, err := experiments.Load(cfg.ExperimentsFile)
+		if err != nil {
+			log.Fatalf("Failed to load experiments: %v", err)
+		}
+		pricingService.SetExperiments(registry)
+	}
+
+	// Bursts of identical quotes are answered from Redis until the surge changes
+	if cfg.QuoteCacheTTL > 0 {
+		quoteCache := service.NewRedisQuoteCache(redisClient, time.Duration(cfg.QuoteCacheTTL)*time.Second)
+		pricingService.SetQuoteCache(quoteCache, cfg.QuoteCachePrecision)
+	}
+	// Riders comparing vehicle types are answered from Redis for a short while
+	if cfg.FareComparisonCacheTTL > 0 {
+		comparisonCache := service.NewRedisComparisonCache(redisClient, time.Duration(cfg.FareComparisonCacheTTL)*time.Second)
+		pricingService.SetComparisonCache(comparisonCache, cfg.QuoteCachePrecision)
+	}
+
+	// Quotes lock their surge so riders are charged what they were shown, and
+	// surge is capped and rises gradually in cities without their own policy
+	pricingService.SetPriceLocks(service.NewRedisPriceLockStore(redisClient, cfg.PriceLockRetention), cfg.QuoteValidity)
+	pricingService.SetSurgeLimits(service.SurgeLimits{
+		MaxMultiplier: cfg.MaxSurgeMultiplier,
+		MaxStep:       cfg.MaxSurgeStep,
+	})
+
+	// Taxes and levies are configured per region with effective dates
+	if cfg.TaxConfigFile != "" {
+		taxes, err := service.LoadTaxEngine(cfg.TaxConfigFile)
+		if err != nil {
+			log.Fatalf("Invalid tax configuration: %v", err)
+		}
+		pricingService.SetTaxEngine(taxes)
+	}
+	healthChecker := sharedhealth.NewChecker("pricing-service", "1.0.0")
+	healthChecker.SetConfig(cfg)
+	healthChecker.AddCheck("redis", redisDB.Health)
+
+	// Final fares are recorded in the pricing history
+	analyticsStore := analytics.Store(analytics.NewMemoryStore())
+	var historyStore service.HistoryStore
+	if cfg.DatabaseURL != "" {
+		db, err := sql.Open("postgres", cfg.DatabaseURL)
+		if err != nil {
+			log.Fatalf("Failed to connect to database: %v", err)
+		}
+		defer db.Close()
+		if err := db.Ping(); err != nil {
+			log.Fatalf("Failed to ping database: %v", err)
+		}
+
+		if cfg.MigrateOnStartup {
+			schema, err := migrations.Load()
+			if err != nil {
+				log.Fatalf("Failed to load migrations: %v", err)
+			}
+			if _, err := database.NewMigrator(db, "pricing-service", schema, appLogger).Up(context.Background()); err != nil {
+				log.Fatalf("Failed to migrate database: %v", err)
+			}
+			if err := analytics.Migrate(context.Background(), db, appLogger); err != nil {
+				log.Fatalf("Failed to migrate analytics tables: %v", err)
+			}
+		}
+
+		historyStore = service.NewPostgresHistoryStore(db)
+		analyticsStore = analytics.NewPostgresStore(db)
+		healthChecker.AddCheck("postgres", db.PingContext)
+	} else {
+		log.Printf("DATABASE_URL not set, pricing history and analytics are kept in memory")
+		historyStore = service.NewMemoryHistoryStore()
+	}
+	pricingService.SetHistoryStore(historyStore)
+
+	// Final fares are also rolled up hourly for the analytics endpoint
+	analyticsRecorder := analytics.NewRecorder(analyticsStore, service.AnalyticsSource, appLogger)
+	pricingService.SetAnalytics(analyticsRecorder)
+	analyticsCtx, stopAnalytics := context.WithCancel(context.Background())
+	analyticsDone := make(chan struct{})
+	go func() {
+		defer close(analyticsDone)
+		analyticsRecorder.Start(analyticsCtx, analytics.DefaultFlushInterval)
+	}()
+
+	// The pricing history is exported to the data warehouse in incremental batches
+	var exporter *export.Exporter
+	exportCtx, stopExport := context.WithCancel(context.Background())
+	defer stopExport()
+	if cfg.Export.Enabled {
+		exporter, err = export.Open(context.Background(), cfg.Export, appLogger)
+		if err != nil {
+			log.Fatalf("Failed to set up warehouse export: %v", err)
+		}
+		defer exporter.Close()
+		exporter.Register(service.NewPricingExport(historyStore))
+		go exporter.Start(exportCtx, cfg.Export.Interval)
+	}
+
+	// Pickups inside geo-service zones such as airports carry a surcharge, and
+	// fare comparisons show each vehicle type's pickup ETA.
+	// geo-service is optional, the health report is degraded while it is down.
+	dialOptions := append(sharedgrpc.ClientOptions(), tlsReloader.DialOption())
+	if conn, err := grpc.NewClient(cfg.GeoServiceAddr, dialOptions...); err != nil {
+		log.Printf("Failed to create geo-service client, zone surcharges disabled: %v", err)
+	} else {
+		defer conn.Close()
+		pricingService.SetZoneLookup(client.NewGRPCZoneClient(conn))
+		pricingService.SetPickupETAs(client.NewGRPCPickupETAClient(conn))
+		healthChecker.AddOptionalCheck("geo-service", sharedhealth.GRPCProbe(conn))
+	}
+
+	// Request counts and latencies for HTTP and gRPC, scraped from /metrics
+	metricsCollector := monitoring.NewMetricsCollector(nil, appLogger)
 
 	// Initialize handlers
 	pricingHandler := handler.NewPricingHandler(pricingService)
 	grpcPricingHandler := handler.NewGRPCPricingHandler(pricingService, appLogger)
 
 	// Setup gRPC server
-	lis, err := net.Listen("tcp", ":50053") // Different port for pricing service
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.GRPCPort))
 	if err != nil {
 		log.Fatalf("Failed to listen on gRPC port: %v", err)
 	}
 
-	grpcServer := grpc.NewServer()
+	serverOptions := interceptor.ServerOptions(interceptor.Config{
+		Service:  "pricing-service",
+		Logger:   appLogger,
+		Metrics:  metricsCollector,
+		Auth:     interceptor.JWTAuth(cfg.JWTSecret, cfg.GRPCAuthRequired),
+		Deadline: interceptor.DefaultDeadlineConfig(),
+	})
+	grpcServer := grpc.NewServer(append(serverOptions, tlsReloader.ServerOptions()...)...)
 	pricingpb.RegisterPricingServiceServer(grpcServer, grpcPricingHandler)
+	healthServer := health.NewServer()
+	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
+	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
 
 	// Start gRPC server in a goroutine
 	go func() {
-		log.Printf("Pricing gRPC service starting on port 50053")
+		log.Printf("Pricing gRPC service starting on port %d", cfg.GRPCPort)
 		if err := grpcServer.Serve(lis); err != nil {
 			log.Fatalf("Failed to serve gRPC: %v", err)
 		}
@@ -54,38 +246,46 @@ func main() {
 
 	// Setup router
 	router := gin.Default()
+	router.Use(middleware.NewLoggingMiddleware(appLogger).RequestLogger())
+	router.Use(metricsCollector.GinMiddleware("pricing-service"))
+	router.GET("/metrics", gin.WrapH(monitoring.Handler()))
 
 	// Health check endpoint
-	router.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
-			"status":    "healthy",
-			"service":   "pricing-service",
-			"timestamp": time.Now().Format(time.RFC3339),
-			"version":   "1.0.0",
-		})
-	})
+	router.GET("/health", gin.WrapH(healthChecker.Handler()))
+	router.GET("/info", gin.WrapH(healthChecker.InfoHandler()))
 
 	// Pricing endpoints
 	v1 := router.Group("/api/v1")
 	{
 		v1.POST("/pricing/calculate", pricingHandler.CalculatePrice)
+		v1.POST("/pricing/compare", pricingHandler.CompareFares)
 		v1.GET("/pricing/surge/:area", pricingHandler.GetSurgeMultiplier)
 		v1.POST("/pricing/surge/update", pricingHandler.UpdateSurgeMultiplier)
 		v1.POST("/pricing/discount/apply", pricingHandler.ApplyDiscount)
+		v1.GET("/pricing/history", pricingHandler.ListPricingHistory)
 		v1.GET("/pricing/history/:trip_id", pricingHandler.GetPricingHistory)
 		v1.GET("/pricing/analytics", pricingHandler.GetPricingAnalytics)
+		v1.GET("/pricing/analytics/experiments/:name", pricingHandler.GetExperimentFares)
 		v1.POST("/pricing/validate", pricingHandler.ValidatePrice)
+		v1.POST("/pricing/shared/split", pricingHandler.SplitSharedFare)
+	}
+
+	// Export manifest, for admin tokens only
+	if exporter != nil {
+		exportMux := http.NewServeMux()
+		export.NewHandler(exporter, middleware.NewAuthMiddleware(cfg.JWTSecret, appLogger).RequireAdmin).RegisterRoutes(exportMux)
+		router.Any("/api/v1/exports/*path", gin.WrapH(exportMux))
 	}
 
 	// Setup HTTP server
 	server := &http.Server{
-		Addr:    cfg.Port,
+		Addr:    fmt.Sprintf(":%d", cfg.HTTPPort),
 		Handler: router,
 	}
 
 	// Start server in a goroutine
 	go func() {
-		log.Printf("Pricing service starting on port %s", cfg.Port)
+		log.Printf("Pricing service starting on port %d", cfg.HTTPPort)
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("Failed to start server: %v", err)
 		}
@@ -97,6 +297,7 @@ func main() {
 	<-quit
 
 	log.Println("Shutting down pricing service...")
+	stopExport()
 
 	// Create a deadline for shutdown
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
@@ -110,5 +311,9 @@ func main() {
 		log.Fatalf("Server forced to shutdown: %v", err)
 	}
 
+	// Write the rollups counted since the last flush
+	stopAnalytics()
+	<-analyticsDone
+
 	log.Println("Pricing service shut down successfully")
 }
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS pricing_history;
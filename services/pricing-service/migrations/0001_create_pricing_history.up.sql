@@ -0,0 +1,22 @@
+-- Every final fare calculated for a trip. trip_id and rider_id refer to
+-- records owned by trip-service and user-service, so there are no foreign keys.
+CREATE TABLE IF NOT EXISTS pricing_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    trip_id VARCHAR(64) NOT NULL,
+    rider_id VARCHAR(64) NOT NULL DEFAULT '',
+    pickup_area VARCHAR(100) NOT NULL DEFAULT '',
+    vehicle_type VARCHAR(20) NOT NULL,
+    total_fare NUMERIC(10, 2) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    surge_multiplier NUMERIC(5, 2) NOT NULL,
+    pricing_version VARCHAR(20) NOT NULL,
+    request JSONB NOT NULL,
+    result JSONB NOT NULL,
+    applied_discounts JSONB NOT NULL DEFAULT '[]',
+    calculated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_pricing_history_trip_id ON pricing_history(trip_id, calculated_at DESC);
+CREATE INDEX IF NOT EXISTS idx_pricing_history_rider_id ON pricing_history(rider_id, calculated_at DESC);
+CREATE INDEX IF NOT EXISTS idx_pricing_history_pickup_area ON pricing_history(pickup_area, calculated_at DESC);
+CREATE INDEX IF NOT EXISTS idx_pricing_history_calculated_at ON pricing_history(calculated_at DESC);
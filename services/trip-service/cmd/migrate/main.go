@@ -0,0 +1,45 @@
+// Command migrate applies and rolls back the trip-service event store, read
+// model and driver reliability schema.
+//
+//	migrate up | down [N] | version | status
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+
+	_ "github.com/lib/pq"
+	"github.com/rideshare-platform/services/trip-service/internal/config"
+	"github.com/rideshare-platform/services/trip-service/migrations"
+	"github.com/rideshare-platform/shared/database"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	db, err := sql.Open("postgres", cfg.PostgresDSN())
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	schema, err := migrations.Load()
+	if err != nil {
+		return err
+	}
+	migrator := database.NewMigrator(db, "trip-service", schema, logger.NewLogger(cfg.Dynamic.LogLevel, cfg.Environment))
+	return database.RunMigrateCommand(context.Background(), migrator, args, os.Stdout)
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/rideshare-platform/services/trip-service/internal/service"
+	"github.com/rideshare-platform/shared/models"
+	geopb "github.com/rideshare-platform/shared/proto/geo"
+)
+
+// GRPCRouteClient fetches recorded trip routes from geo-service's gRPC API
+type GRPCRouteClient struct {
+	client geopb.GeospatialServiceClient
+}
+
+// NewGRPCRouteClient creates a new route client
+func NewGRPCRouteClient(conn grpc.ClientConnInterface) *GRPCRouteClient {
+	return &GRPCRouteClient{client: geopb.NewGeospatialServiceClient(conn)}
+}
+
+// GetTripRoute returns the trip's map-matched route, or nil if none was recorded
+func (c *GRPCRouteClient) GetTripRoute(ctx context.Context, tripID string) (*service.TripRoute, error) {
+	resp, err := c.client.GetTripRoute(ctx, &geopb.GetTripRouteRequest{TripId: tripID, MapMatch: true})
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	route := &service.TripRoute{
+		Points:          make([]models.Location, 0, len(resp.Points)),
+		DistanceKm:      resp.DistanceKm,
+		DurationSeconds: int(resp.DurationSeconds),
+	}
+	for _, point := range resp.Points {
+		location := models.Location{
+			Latitude:  point.Latitude,
+			Longitude: point.Longitude,
+			Accuracy:  point.Accuracy,
+		}
+		if point.Timestamp != nil {
+			location.Timestamp = point.Timestamp.AsTime()
+		}
+		route.Points = append(route.Points, location)
+	}
+	return route, nil
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/rideshare-platform/services/trip-service/internal/service"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/models"
+	matchingpb "github.com/rideshare-platform/shared/proto/matching"
+)
+
+// GRPCMatchingClient pre-dispatches scheduled rides through matching-service's gRPC API
+type GRPCMatchingClient struct {
+	client matchingpb.MatchingServiceClient
+}
+
+// NewGRPCMatchingClient creates a new matching client
+func NewGRPCMatchingClient(conn grpc.ClientConnInterface) *GRPCMatchingClient {
+	return &GRPCMatchingClient{client: matchingpb.NewMatchingServiceClient(conn)}
+}
+
+// DispatchScheduledRide asks matching-service to find a driver for the ride's pickup time
+func (c *GRPCMatchingClient) DispatchScheduledRide(ctx context.Context, ride *types.ScheduledRide) (*service.DispatchResult, error) {
+	resp, err := c.client.MatchDriver(ctx, &matchingpb.MatchDriverRequest{
+		RideRequest: &matchingpb.RideRequest{
+			Id:             ride.ID,
+			RiderId:        ride.RiderID,
+			PickupLocation: locationToProto(ride.PickupLocation),
+			Destination:    locationToProto(ride.Destination),
+			VehicleType:    ride.VehicleType,
+			PassengerCount: int32(ride.PassengerCount),
+			RequestedAt:    timestamppb.New(ride.CreatedAt),
+			ScheduledFor:   timestamppb.New(ride.ScheduledFor),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Success {
+		return nil, fmt.Errorf("matching rejected scheduled ride: %s", resp.Message)
+	}
+
+	result := &service.DispatchResult{Message: resp.Message}
+	if resp.Result != nil && resp.Result.BestMatch != nil {
+		result.DriverID = resp.Result.BestMatch.Id
+	}
+	result.Queued = result.DriverID == ""
+	return result, nil
+}
+
+func locationToProto(location *models.Location) *matchingpb.Location {
+	if location == nil {
+		return nil
+	}
+	return &matchingpb.Location{
+		Latitude:  location.Latitude,
+		Longitude: location.Longitude,
+	}
+}
@@ -0,0 +1,257 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/currency"
+	"github.com/rideshare-platform/shared/events"
+	paymentpb "github.com/rideshare-platform/shared/proto/payment"
+)
+
+// GRPCPaymentClient looks up trip payments through payment-service's gRPC API
+type GRPCPaymentClient struct {
+	client paymentpb.PaymentServiceClient
+}
+
+// NewGRPCPaymentClient creates a new payment client
+func NewGRPCPaymentClient(conn grpc.ClientConnInterface) *GRPCPaymentClient {
+	return &GRPCPaymentClient{client: paymentpb.NewPaymentServiceClient(conn)}
+}
+
+// GetTripPayment returns the most recent charge made for a trip, or nil if
+// the rider has not been charged yet
+func (c *GRPCPaymentClient) GetTripPayment(ctx context.Context, tripID string) (*types.ReceiptPayment, error) {
+	resp, err := c.client.GetTripPayments(ctx, &paymentpb.GetTripPaymentsRequest{TripId: tripID})
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *paymentpb.Payment
+	for _, payment := range resp.Payments {
+		if payment.TransactionType != paymentpb.TransactionType_PAYMENT {
+			continue
+		}
+		if latest == nil || payment.CreatedAt.AsTime().After(latest.CreatedAt.AsTime()) {
+			latest = payment
+		}
+	}
+	if latest == nil {
+		return nil, nil
+	}
+
+	result := &types.ReceiptPayment{
+		PaymentID: latest.Id,
+		Status:    paymentStatus(latest.Status),
+		Method:    strings.ToLower(latest.PaymentMethod.String()),
+		Amount:    latest.Amount,
+		Currency:  latest.Currency,
+	}
+	if latest.ProcessedAt != nil {
+		processedAt := latest.ProcessedAt.AsTime()
+		result.ProcessedAt = &processedAt
+	}
+	return result, nil
+}
+
+// GetTripCharges returns every charge made for a trip, for reconciliation
+func (c *GRPCPaymentClient) GetTripCharges(ctx context.Context, tripID string) ([]*types.ReconciliationPayment, error) {
+	resp, err := c.client.GetTripPayments(ctx, &paymentpb.GetTripPaymentsRequest{TripId: tripID})
+	if err != nil {
+		return nil, err
+	}
+	return reconciliationPayments(resp.Payments), nil
+}
+
+// ListCharges returns every charge created in [from, to), paging through
+// payment-service's ListPayments
+func (c *GRPCPaymentClient) ListCharges(ctx context.Context, from, to time.Time) ([]*types.ReconciliationPayment, error) {
+	const pageSize = 500
+
+	var (
+		charges []*types.ReconciliationPayment
+		cursor  string
+	)
+	for {
+		resp, err := c.client.ListPayments(ctx, &paymentpb.ListPaymentsRequest{
+			CreatedAfter:  timestamppb.New(from),
+			CreatedBefore: timestamppb.New(to),
+			Limit:         pageSize,
+			Cursor:        cursor,
+		})
+		if err != nil {
+			return nil, err
+		}
+		charges = append(charges, reconciliationPayments(resp.Payments)...)
+		if resp.NextCursor == "" {
+			return charges, nil
+		}
+		cursor = resp.NextCursor
+	}
+}
+
+// RefundTrip refunds amount of the trip's most recent completed charge in
+// the given currency that has that much left to refund
+func (c *GRPCPaymentClient) RefundTrip(ctx context.Context, tripID string, amount float64, currencyCode, reason, requestedBy string) (string, error) {
+	resp, err := c.client.GetTripPayments(ctx, &paymentpb.GetTripPaymentsRequest{TripId: tripID})
+	if err != nil {
+		return "", err
+	}
+
+	var charge *paymentpb.Payment
+	for _, payment := range resp.Payments {
+		if payment.TransactionType != paymentpb.TransactionType_PAYMENT || payment.Status != paymentpb.PaymentStatus_COMPLETED {
+			continue
+		}
+		if currencyCode != "" && !strings.EqualFold(payment.Currency, currencyCode) {
+			continue
+		}
+		refundable := currency.ToMinor(payment.Amount-payment.RefundedAmount, payment.Currency)
+		if refundable < currency.ToMinor(amount, payment.Currency) {
+			continue
+		}
+		if charge == nil || payment.CreatedAt.AsTime().After(charge.CreatedAt.AsTime()) {
+			charge = payment
+		}
+	}
+	if charge == nil {
+		return "", fmt.Errorf("trip %s has no completed charge with %s left to refund", tripID, currency.FormatAmount(amount, currencyCode))
+	}
+
+	refund, err := c.client.ProcessRefund(ctx, &paymentpb.ProcessRefundRequest{
+		PaymentId:   charge.Id,
+		Amount:      amount,
+		Reason:      reason,
+		RequestedBy: requestedBy,
+	})
+	if err != nil {
+		return "", err
+	}
+	if !refund.Success {
+		return "", fmt.Errorf("refund declined: %s", refund.Message)
+	}
+	return refund.RefundId, nil
+}
+
+// GrantWalletCredit credits amount to the user's wallet and returns the
+// credit's transaction ID
+func (c *GRPCPaymentClient) GrantWalletCredit(ctx context.Context, userID string, amount float64, currencyCode, creditType, reference, description string) (string, error) {
+	resp, err := c.client.GrantWalletCredit(ctx, &paymentpb.GrantWalletCreditRequest{
+		UserId:      userID,
+		Amount:      amount,
+		Currency:    currencyCode,
+		Type:        creditType,
+		Reference:   reference,
+		Description: description,
+	})
+	if err != nil {
+		return "", err
+	}
+	if !resp.Success {
+		return "", fmt.Errorf("credit declined: %s", resp.Message)
+	}
+	return resp.TransactionId, nil
+}
+
+// ReportTripEvent reports a no-show or cancellation to payment-service's
+// refund policy
+func (c *GRPCPaymentClient) ReportTripEvent(ctx context.Context, event *events.Event) error {
+	tripID, _ := event.Data["trip_id"].(string)
+	if tripID == "" {
+		tripID = event.AggregateID
+	}
+	cancelledBy, _ := event.Data["cancelled_by"].(string)
+	reason, _ := event.Data["reason"].(string)
+	_, err := c.client.ReportTripEvent(ctx, &paymentpb.ReportTripEventRequest{
+		EventId:     event.ID,
+		EventType:   string(event.Type),
+		TripId:      tripID,
+		CancelledBy: cancelledBy,
+		Reason:      reason,
+		Source:      event.Source,
+	})
+	return err
+}
+
+// HoldFare holds a started trip's estimated fare, plus payment-service's
+// buffer, on the rider's default payment method
+func (c *GRPCPaymentClient) HoldFare(ctx context.Context, tripID, riderID, driverID string, estimatedFare float64, currencyCode string) error {
+	resp, err := c.client.AuthorizeTripPayment(ctx, &paymentpb.AuthorizeTripPaymentRequest{
+		TripId:        tripID,
+		UserId:        riderID,
+		DriverId:      driverID,
+		EstimatedFare: estimatedFare,
+		Currency:      currencyCode,
+	})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return fmt.Errorf("hold declined: %s", resp.Message)
+	}
+	return nil
+}
+
+// CaptureFare charges a completed trip's fare, against its hold when it has one
+func (c *GRPCPaymentClient) CaptureFare(ctx context.Context, tripID, riderID, driverID string, fare float64, currencyCode string) error {
+	resp, err := c.client.CaptureTripPayment(ctx, &paymentpb.CaptureTripPaymentRequest{
+		TripId:   tripID,
+		UserId:   riderID,
+		DriverId: driverID,
+		Amount:   fare,
+		Currency: currencyCode,
+	})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return fmt.Errorf("charge declined: %s", resp.Message)
+	}
+	return nil
+}
+
+// ReleaseFare releases a cancelled trip's hold
+func (c *GRPCPaymentClient) ReleaseFare(ctx context.Context, tripID string) error {
+	resp, err := c.client.ReleaseTripPayment(ctx, &paymentpb.ReleaseTripPaymentRequest{TripId: tripID})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return fmt.Errorf("release failed: %s", resp.Message)
+	}
+	return nil
+}
+
+// reconciliationPayments keeps the payments that are charges, skipping
+// authorizations and refund transactions
+func reconciliationPayments(payments []*paymentpb.Payment) []*types.ReconciliationPayment {
+	var charges []*types.ReconciliationPayment
+	for _, payment := range payments {
+		if payment.TransactionType != paymentpb.TransactionType_PAYMENT {
+			continue
+		}
+		charges = append(charges, &types.ReconciliationPayment{
+			PaymentID:      payment.Id,
+			TripID:         payment.TripId,
+			Status:         paymentStatus(payment.Status),
+			Amount:         payment.Amount,
+			RefundedAmount: payment.RefundedAmount,
+			Currency:       payment.Currency,
+			CreatedAt:      payment.CreatedAt.AsTime(),
+		})
+	}
+	return charges
+}
+
+func paymentStatus(status paymentpb.PaymentStatus) string {
+	if status == paymentpb.PaymentStatus_UNKNOWN_PAYMENT_STATUS {
+		return "unknown"
+	}
+	return strings.ToLower(status.String())
+}
@@ -0,0 +1,160 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/rideshare-platform/services/trip-service/internal/service"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/currency"
+	"github.com/rideshare-platform/shared/models"
+	pricingpb "github.com/rideshare-platform/shared/proto/pricing"
+)
+
+// GRPCPricingClient fetches final trip fares from pricing-service's gRPC API
+type GRPCPricingClient struct {
+	client pricingpb.PricingServiceClient
+}
+
+// NewGRPCPricingClient creates a new pricing client
+func NewGRPCPricingClient(conn grpc.ClientConnInterface) *GRPCPricingClient {
+	return &GRPCPricingClient{client: pricingpb.NewPricingServiceClient(conn)}
+}
+
+// CalculateFinalFare asks pricing-service for the fare of the receipt's completed trip
+func (c *GRPCPricingClient) CalculateFinalFare(ctx context.Context, receipt *types.Receipt) (*types.ReceiptFare, error) {
+	route := receipt.Route
+	resp, err := c.finalFare(ctx, &service.FinalFareRequest{
+		TripID:          receipt.TripID,
+		RiderID:         receipt.RiderID,
+		VehicleType:     receipt.Vehicle.VehicleType,
+		PickupArea:      route.PickupArea,
+		PickupLocation:  route.PickupLocation,
+		Destination:     route.Destination,
+		DistanceKm:      route.DistanceKm,
+		DurationSeconds: route.DurationMinutes * 60,
+		WaitingSeconds:  route.WaitingSeconds,
+		SurgeMultiplier: receipt.LockedSurgeMultiplier,
+		StartedAt:       route.StartedAt,
+		CompletedAt:     route.CompletedAt,
+		City:            route.City,
+		Currency:        receipt.Currency,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	estimate := resp.FinalFare
+	fare := &types.ReceiptFare{
+		BaseFare:        estimate.BaseFare,
+		DistanceFare:    estimate.DistanceFare,
+		TimeFare:        estimate.TimeFare,
+		SurgeMultiplier: estimate.SurgeMultiplier,
+		SurgeAmount:     estimate.SurgeAmount,
+		DiscountAmount:  estimate.DiscountAmount,
+		Total:           estimate.TotalAmount,
+		Currency:        estimate.Currency,
+	}
+	if breakdown := estimate.Breakdown; breakdown != nil {
+		fare.WaitingFare = breakdown.WaitingFare
+		fare.BookingFee = breakdown.BookingFee
+		fare.ServiceFee = breakdown.ServiceFee
+		fare.Taxes = breakdown.Taxes
+		fare.Tolls = breakdown.Tolls
+		for _, line := range breakdown.TaxLines {
+			fare.TaxLines = append(fare.TaxLines, &types.ReceiptTaxLine{
+				Name:   line.Name,
+				Type:   line.Type,
+				Rate:   line.Rate,
+				Amount: line.Amount,
+			})
+		}
+	}
+	return fare, nil
+}
+
+// PriceCompletedTrip asks pricing-service for the itemized fare of a completed trip
+func (c *GRPCPricingClient) PriceCompletedTrip(ctx context.Context, req *service.FinalFareRequest) (*models.FareBreakdown, error) {
+	resp, err := c.finalFare(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	estimate := resp.FinalFare
+	money := func(amount float64) models.Money {
+		return models.NewMoney(currency.ToMinor(amount, estimate.Currency), estimate.Currency)
+	}
+	fare := &models.FareBreakdown{
+		BaseFare:        money(estimate.BaseFare),
+		DistanceFare:    money(estimate.DistanceFare),
+		TimeFare:        money(estimate.TimeFare),
+		SurgeAmount:     money(estimate.SurgeAmount),
+		Discount:        money(estimate.DiscountAmount),
+		Total:           money(estimate.TotalAmount),
+		SurgeMultiplier: estimate.SurgeMultiplier,
+		WaitingSeconds:  req.WaitingSeconds,
+	}
+	if breakdown := estimate.Breakdown; breakdown != nil {
+		fare.WaitingFare = money(breakdown.WaitingFare)
+		fare.BookingFee = money(breakdown.BookingFee)
+		fare.ServiceFee = money(breakdown.ServiceFee)
+		fare.Taxes = money(breakdown.Taxes)
+	}
+	var surcharges float64
+	for _, adjustment := range resp.Adjustments {
+		if adjustment.Type == "zone_surcharge" {
+			surcharges += adjustment.Amount
+		}
+	}
+	fare.Surcharges = money(surcharges)
+	return fare, nil
+}
+
+func (c *GRPCPricingClient) finalFare(ctx context.Context, req *service.FinalFareRequest) (*pricingpb.CalculateFinalFareResponse, error) {
+	completedAt := req.CompletedAt
+	if completedAt.IsZero() {
+		completedAt = time.Now()
+	}
+	pbReq := &pricingpb.CalculateFinalFareRequest{
+		TripId:                req.TripID,
+		RiderId:               req.RiderID,
+		ActualPickup:          pricingLocationToProto(req.PickupLocation),
+		ActualDestination:     pricingLocationToProto(req.Destination),
+		ActualDistanceKm:      req.DistanceKm,
+		ActualDurationMinutes: int32(math.Ceil(float64(req.DurationSeconds) / 60)),
+		VehicleType:           req.VehicleType,
+		PickupArea:            req.PickupArea,
+		TripEndTime:           timestamppb.New(completedAt),
+		LockedSurgeMultiplier: req.SurgeMultiplier,
+		WaitingTimeSeconds:    int32(req.WaitingSeconds),
+		City:                  req.City,
+		Currency:              req.Currency,
+	}
+	if req.StartedAt != nil {
+		pbReq.TripStartTime = timestamppb.New(*req.StartedAt)
+	}
+
+	resp, err := c.client.CalculateFinalFare(ctx, pbReq)
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Success || resp.FinalFare == nil {
+		return nil, fmt.Errorf("pricing rejected final fare: %s", resp.Message)
+	}
+	return resp, nil
+}
+
+func pricingLocationToProto(location *models.Location) *pricingpb.Location {
+	if location == nil {
+		return nil
+	}
+	return &pricingpb.Location{
+		Latitude:  location.Latitude,
+		Longitude: location.Longitude,
+	}
+}
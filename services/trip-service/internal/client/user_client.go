@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+
+	userpb "github.com/rideshare-platform/shared/proto/user"
+)
+
+// GRPCUserClient looks up riders' and drivers' phone numbers and locales
+// through user-service's gRPC API
+type GRPCUserClient struct {
+	client userpb.UserServiceClient
+}
+
+// NewGRPCUserClient creates a new user client
+func NewGRPCUserClient(conn grpc.ClientConnInterface) *GRPCUserClient {
+	return &GRPCUserClient{client: userpb.NewUserServiceClient(conn)}
+}
+
+// GetPhone returns a user's phone number, empty if they have none on file
+func (c *GRPCUserClient) GetPhone(ctx context.Context, userID string) (string, error) {
+	resp, err := c.client.GetUser(ctx, &userpb.GetUserRequest{Id: userID})
+	if err != nil {
+		return "", err
+	}
+	if !resp.Found || resp.User == nil {
+		return "", fmt.Errorf("user %s not found", userID)
+	}
+	return resp.User.Phone, nil
+}
+
+// GetLocale returns the locale a user chose in their profile, empty if they
+// chose none
+func (c *GRPCUserClient) GetLocale(ctx context.Context, userID string) (string, error) {
+	resp, err := c.client.GetUser(ctx, &userpb.GetUserRequest{Id: userID})
+	if err != nil {
+		return "", err
+	}
+	if !resp.Found || resp.User == nil {
+		return "", fmt.Errorf("user %s not found", userID)
+	}
+	return resp.User.GetProfile().GetPreferredLanguage(), nil
+}
@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	vehiclepb "github.com/rideshare-platform/shared/proto/vehicle"
+)
+
+// GRPCVehicleClient fetches vehicle details from vehicle-service's gRPC API
+type GRPCVehicleClient struct {
+	client vehiclepb.VehicleServiceClient
+}
+
+// NewGRPCVehicleClient creates a new vehicle client
+func NewGRPCVehicleClient(conn grpc.ClientConnInterface) *GRPCVehicleClient {
+	return &GRPCVehicleClient{client: vehiclepb.NewVehicleServiceClient(conn)}
+}
+
+// GetVehicle returns the details of a vehicle printed on trip receipts
+func (c *GRPCVehicleClient) GetVehicle(ctx context.Context, vehicleID string) (*types.ReceiptVehicle, error) {
+	resp, err := c.client.GetVehicle(ctx, &vehiclepb.GetVehicleRequest{VehicleId: vehicleID})
+	if err != nil {
+		return nil, err
+	}
+
+	vehicle := resp.Vehicle
+	return &types.ReceiptVehicle{
+		ID:           vehicle.Id,
+		VehicleType:  vehicle.VehicleType,
+		Make:         vehicle.Make,
+		Model:        vehicle.Model,
+		Year:         int(vehicle.Year),
+		Color:        vehicle.Color,
+		LicensePlate: vehicle.LicensePlate,
+	}, nil
+}
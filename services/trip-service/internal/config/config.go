@@ -1,94 +1,267 @@
 package config
 
 import (
-	"os"
-	"strconv"
+	"fmt"
+	"time"
+
+	"github.com/rideshare-platform/shared/archive"
+	sharedconfig "github.com/rideshare-platform/shared/config"
+	"github.com/rideshare-platform/shared/export"
+	sharedtls "github.com/rideshare-platform/shared/tls"
 )
 
 // Config holds all configuration for the trip service
 type Config struct {
-	HTTPPort    string
-	Environment string
-	LogLevel    string
+	HTTPPort    int    `yaml:"http_port" env:"HTTP_PORT" default:"8085"`
+	GRPCPort    int    `yaml:"grpc_port" env:"GRPC_PORT" default:"50053"`
+	Environment string `yaml:"environment" env:"ENVIRONMENT" default:"development"`
+
+	// Bearer tokens on gRPC calls are validated against JWTSecret when it is
+	// set. GRPCAuthRequired also rejects calls without one.
+	JWTSecret        string `yaml:"jwt_secret" env:"JWT_SECRET"`
+	GRPCAuthRequired bool   `yaml:"grpc_auth_required" env:"GRPC_AUTH_REQUIRED" default:"false"`
+
+	// Mutual TLS for gRPC traffic between services
+	TLS sharedtls.Config `yaml:"tls"`
+
+	// How long in-flight requests may take to drain on SIGTERM
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT" default:"30s"`
 
 	// Database config
-	DatabaseHost     string
-	DatabasePort     int
-	DatabaseName     string
-	DatabaseUser     string
-	DatabasePassword string
+	DatabaseHost     string `yaml:"db_host" env:"DB_HOST" default:"localhost"`
+	DatabasePort     int    `yaml:"db_port" env:"DB_PORT" default:"5432"`
+	DatabaseName     string `yaml:"db_name" env:"DB_NAME" default:"rideshare"`
+	DatabaseUser     string `yaml:"db_user" env:"DB_USER" default:"rideshare_user"`
+	DatabasePassword string `yaml:"db_password" env:"DB_PASSWORD" default:"rideshare_password"`
+
+	// Hourly trip and rating rollups are kept in PostgreSQL when set, in memory otherwise
+	AnalyticsDatabaseURL string `yaml:"analytics_database_url" env:"ANALYTICS_DATABASE_URL"`
+	MigrateOnStartup     bool   `yaml:"migrate_on_startup" env:"MIGRATE_ON_STARTUP" default:"false"`
 
 	// MongoDB config
-	MongoURI      string
-	MongoDatabase string
+	MongoURI      string `yaml:"mongo_uri" env:"MONGO_URI" default:"mongodb://localhost:27017"`
+	MongoDatabase string `yaml:"mongo_db" env:"MONGO_DB" default:"rideshare"`
 
 	// Redis config
-	RedisHost     string
-	RedisPort     int
-	RedisPassword string
-	RedisDatabase int
+	RedisHost     string `yaml:"redis_host" env:"REDIS_HOST" default:"localhost"`
+	RedisPort     int    `yaml:"redis_port" env:"REDIS_PORT" default:"6379"`
+	RedisPassword string `yaml:"redis_password" env:"REDIS_PASSWORD"`
+	RedisDatabase int    `yaml:"redis_db" env:"REDIS_DB" default:"0"`
+
+	// Sentinel or Cluster instead of a single Redis host
+	RedisTopology sharedconfig.RedisTopology `yaml:"redis_topology"`
 
 	// Trip service parameters
-	MaxActiveTripDuration int    // hours
-	TripTimeoutMinutes    int    // minutes
-	CancellationWindow    int    // minutes after booking
-	MaxPassengerCount     int    // maximum passengers per trip
-	DefaultCurrency       string // default currency code
+	MaxActiveTripDuration int    `yaml:"max_active_trip_duration" env:"MAX_ACTIVE_TRIP_DURATION" default:"24"` // hours
+	TripTimeoutMinutes    int    `yaml:"trip_timeout_minutes" env:"TRIP_TIMEOUT_MINUTES" default:"30"`         // minutes
+	CancellationWindow    int    `yaml:"cancellation_window" env:"CANCELLATION_WINDOW" default:"5"`            // minutes after booking
+	MaxPassengerCount     int    `yaml:"max_passenger_count" env:"MAX_PASSENGER_COUNT" default:"4"`            // maximum passengers per trip
+	DefaultCurrency       string `yaml:"default_currency" env:"DEFAULT_CURRENCY" default:"USD"`                // default currency code
+
+	// Cities file scoping trips to the cities the platform operates in; trips
+	// are unpartitioned without one
+	CitiesFile string `yaml:"cities_file" env:"CITIES_FILE"`
+
+	// A/B experiments shared with matching and pricing. Trip events record
+	// the variants each trip was in; without it they record only variants
+	// assigned upstream.
+	ExperimentsFile string `yaml:"experiments_file" env:"EXPERIMENTS_FILE"`
+
+	// Trip share links let riders' contacts follow a trip without an account.
+	// They are signed with TripShareSecret; sharing is disabled without one.
+	TripShareSecret string        `yaml:"trip_share_secret" env:"TRIP_SHARE_SECRET"`
+	TripShareTTL    time.Duration `yaml:"trip_share_ttl" env:"TRIP_SHARE_TTL" default:"4h"` // default share link lifetime
+
+	// How long the Idempotency-Key of a trip request is remembered, so a
+	// retry returns the trip it created instead of requesting another
+	IdempotencyKeyTTL time.Duration `yaml:"idempotency_key_ttl" env:"IDEMPOTENCY_KEY_TTL" default:"24h"`
+	// Where idempotency keys are kept: "memory" for a single instance, or
+	// "redis" for REDIS_HOST, shared by every replica and surviving restarts.
+	// With "redis" the service does not start until Redis is reachable.
+	IdempotencyStore string `yaml:"idempotency_store" env:"IDEMPOTENCY_STORE" default:"memory"`
+
+	// Where in-trip messages are kept until delivered and for support review:
+	// "memory", or "mongo" for MONGO_URI so they survive restarts
+	ChatStore string `yaml:"chat_store" env:"CHAT_STORE" default:"memory"`
+
+	// Rider, driver and status trip lists are served from a read model the
+	// trip event log is projected into: "memory", or "mongo" for MONGO_URI.
+	// TripProjectionMaxLag is how far behind the log it may fall before the
+	// service reports degraded.
+	TripListingStore       string        `yaml:"trip_listing_store" env:"TRIP_LISTING_STORE" default:"memory"`
+	TripProjectionInterval time.Duration `yaml:"trip_projection_interval" env:"TRIP_PROJECTION_INTERVAL" default:"1s"`
+	TripProjectionMaxLag   time.Duration `yaml:"trip_projection_max_lag" env:"TRIP_PROJECTION_MAX_LAG" default:"1m"`
+
+	// Masked calling between a trip's rider and driver. Masked numbers are
+	// handed out from ContactProxyNumbers; without any, or for a party with
+	// no phone on file, calls fall back to in-app VoIP.
+	ContactProxyNumbers  []string      `yaml:"contact_proxy_numbers" env:"CONTACT_PROXY_NUMBERS"`
+	ContactSessionTTL    time.Duration `yaml:"contact_session_ttl" env:"CONTACT_SESSION_TTL" default:"6h"`       // longest a session lives if its trip's end is missed
+	ContactSweepInterval time.Duration `yaml:"contact_sweep_interval" env:"CONTACT_SWEEP_INTERVAL" default:"1m"` // how often expired sessions are released
+
+	// On-time pickup guarantees. Riders whose driver arrives more than the
+	// grace period after the ETA they were matched with are credited
+	// PickupGuaranteeCredit in their trip's currency; 0 only records misses.
+	PickupGuaranteeGracePeriod time.Duration `yaml:"pickup_guarantee_grace_period" env:"PICKUP_GUARANTEE_GRACE_PERIOD" default:"5m"`
+	PickupGuaranteeCredit      float64       `yaml:"pickup_guarantee_credit" env:"PICKUP_GUARANTEE_CREDIT" default:"5"`
+
+	// DriverReliabilityHalfLife is how long it takes a trip to count half as
+	// much towards the cancellation rate and completion streak drivers are
+	// matched on
+	DriverReliabilityHalfLife time.Duration `yaml:"driver_reliability_half_life" env:"DRIVER_RELIABILITY_HALF_LIFE" default:"336h"`
+	// Where drivers' reliability is kept: "memory", or "postgres" for the
+	// DB_* database so it survives restarts and is shared by every replica
+	DriverReliabilityStore string `yaml:"driver_reliability_store" env:"DRIVER_RELIABILITY_STORE" default:"memory"`
+
+	// Scheduled ride parameters
+	ScheduledRideLeadMinutes      int `yaml:"scheduled_ride_lead_minutes" env:"SCHEDULED_RIDE_LEAD_MINUTES" default:"15"`             // dispatch to matching this long before pickup
+	ScheduledRideMinNoticeMinutes int `yaml:"scheduled_ride_min_notice_minutes" env:"SCHEDULED_RIDE_MIN_NOTICE_MINUTES" default:"30"` // minimum booking notice
+	ScheduledRideMaxAdvanceDays   int `yaml:"scheduled_ride_max_advance_days" env:"SCHEDULED_RIDE_MAX_ADVANCE_DAYS" default:"30"`     // how far ahead rides can be booked
+	ScheduledRideSweepSeconds     int `yaml:"scheduled_ride_sweep_seconds" env:"SCHEDULED_RIDE_SWEEP_SECONDS" default:"30"`           // scheduler polling interval
+
+	// Nightly reconciliation of completed trips against payment-service's charges
+	ReconciliationRunAt          time.Duration `yaml:"reconciliation_run_at" env:"RECONCILIATION_RUN_AT" default:"2h"`                  // time after midnight UTC the previous day is reconciled
+	ReconciliationToleranceCents int64         `yaml:"reconciliation_tolerance_cents" env:"RECONCILIATION_TOLERANCE_CENTS" default:"1"` // charge drift ignored, in minor units
+
+	// How often request, error, payment and latency metrics are evaluated
+	// against the alert rules. Rules need their condition to hold for
+	// minutes, so this should be well under a minute.
+	MetricsIngestInterval time.Duration `yaml:"metrics_ingest_interval" env:"METRICS_INGEST_INTERVAL" default:"30s"`
+
+	// Trip data reports for cities' regulators, from the templates in
+	// CitiesFile. Due reports are checked for every ComplianceReportInterval;
+	// trip and driver IDs are reported hashed with ComplianceHashSecret.
+	ComplianceReportInterval time.Duration `yaml:"compliance_report_interval" env:"COMPLIANCE_REPORT_INTERVAL" default:"1h"`
+	ComplianceHashSecret     string        `yaml:"compliance_hash_secret" env:"COMPLIANCE_HASH_SECRET"`
+
+	// Incremental trip exports to the data warehouse
+	Export export.Config `yaml:"export"`
+
+	// Archival of ended trips past their retention period
+	Archive archive.Config `yaml:"archive"`
+
+	// Downstream services
+	MatchingServiceAddr string `yaml:"matching_service_addr" env:"MATCHING_SERVICE_ADDR" default:"matching-service:8054"`
+	PricingServiceAddr  string `yaml:"pricing_service_addr" env:"PRICING_SERVICE_ADDR" default:"pricing-service:50053"`
+	PaymentServiceAddr  string `yaml:"payment_service_addr" env:"PAYMENT_SERVICE_ADDR" default:"payment-service:8055"`
+	VehicleServiceAddr  string `yaml:"vehicle_service_addr" env:"VEHICLE_SERVICE_ADDR" default:"vehicle-service:50052"`
+	UserServiceAddr     string `yaml:"user_service_addr" env:"USER_SERVICE_ADDR" default:"user-service:50051"`
+	GeoServiceAddr      string `yaml:"geo_service_addr" env:"GEO_SERVICE_ADDR" default:"geo-service:50053"`
+
+	// Log level and feature flags, reloadable at runtime
+	Dynamic sharedconfig.DynamicConfig `yaml:"dynamic"`
 }
 
-// Load loads configuration from environment variables
+// Load loads configuration from defaults, the CONFIG_FILE YAML file and environment variables
 func Load() (*Config, error) {
-	return &Config{
-		HTTPPort:    getEnv("HTTP_PORT", "8085"),
-		Environment: getEnv("ENVIRONMENT", "development"),
-		LogLevel:    getEnv("LOG_LEVEL", "info"),
-
-		// Database config
-		DatabaseHost:     getEnv("DB_HOST", "localhost"),
-		DatabasePort:     getEnvInt("DB_PORT", 5432),
-		DatabaseName:     getEnv("DB_NAME", "rideshare"),
-		DatabaseUser:     getEnv("DB_USER", "rideshare_user"),
-		DatabasePassword: getEnv("DB_PASSWORD", "rideshare_password"),
-
-		// MongoDB config
-		MongoURI:      getEnv("MONGO_URI", "mongodb://localhost:27017"),
-		MongoDatabase: getEnv("MONGO_DB", "rideshare"),
-
-		// Redis config
-		RedisHost:     getEnv("REDIS_HOST", "localhost"),
-		RedisPort:     getEnvInt("REDIS_PORT", 6379),
-		RedisPassword: getEnv("REDIS_PASSWORD", ""),
-		RedisDatabase: getEnvInt("REDIS_DB", 0),
-
-		// Trip parameters
-		MaxActiveTripDuration: getEnvInt("MAX_ACTIVE_TRIP_DURATION", 24),
-		TripTimeoutMinutes:    getEnvInt("TRIP_TIMEOUT_MINUTES", 30),
-		CancellationWindow:    getEnvInt("CANCELLATION_WINDOW", 5),
-		MaxPassengerCount:     getEnvInt("MAX_PASSENGER_COUNT", 4),
-		DefaultCurrency:       getEnv("DEFAULT_CURRENCY", "USD"),
-	}, nil
+	cfg := &Config{}
+	if err := sharedconfig.NewLoader().Load(cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
 }
 
-// Validate validates the configuration
-func (c *Config) Validate() error {
-	// Add validation logic here if needed
-	return nil
+// Redis returns the connection settings for the Redis idempotency keys are kept in
+func (c *Config) Redis() *sharedconfig.RedisConfig {
+	return &sharedconfig.RedisConfig{
+		Host:         c.RedisHost,
+		Port:         c.RedisPort,
+		Password:     c.RedisPassword,
+		Database:     c.RedisDatabase,
+		PoolSize:     20,
+		MinIdleConns: 2,
+		DialTimeout:  5 * time.Second,
+		ReadTimeout:  3 * time.Second,
+		WriteTimeout: 3 * time.Second,
+		IdleTimeout:  5 * time.Minute,
+		Topology:     c.RedisTopology,
+	}
 }
 
-// getEnv gets an environment variable with a default value
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
+// PostgresDSN returns the connection string of the DB_* database
+func (c *Config) PostgresDSN() string {
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=disable",
+		c.DatabaseHost, c.DatabasePort, c.DatabaseUser, c.DatabasePassword, c.DatabaseName)
 }
 
-// getEnvInt gets an environment variable as int with a default value
-func getEnvInt(key string, defaultValue int) int {
-	if value := os.Getenv(key); value != "" {
-		if parsed, err := strconv.Atoi(value); err == nil {
-			return parsed
+// Validate validates the configuration
+func (c *Config) Validate() error {
+	if err := c.TLS.Validate(); err != nil {
+		return err
+	}
+	if err := c.Export.Validate(); err != nil {
+		return err
+	}
+	if err := c.Archive.Validate(); err != nil {
+		return err
+	}
+	for name, port := range map[string]int{"HTTP_PORT": c.HTTPPort, "GRPC_PORT": c.GRPCPort, "DB_PORT": c.DatabasePort, "REDIS_PORT": c.RedisPort} {
+		if err := sharedconfig.ValidatePort(name, port); err != nil {
+			return err
 		}
 	}
-	return defaultValue
+	if c.HTTPPort == c.GRPCPort {
+		return fmt.Errorf("HTTP_PORT and GRPC_PORT must differ, both are %d", c.HTTPPort)
+	}
+	if c.ShutdownTimeout <= 0 {
+		return fmt.Errorf("SHUTDOWN_TIMEOUT must be positive, got %s", c.ShutdownTimeout)
+	}
+	if c.MaxPassengerCount < 1 {
+		return fmt.Errorf("MAX_PASSENGER_COUNT must be at least 1, got %d", c.MaxPassengerCount)
+	}
+	if c.TripShareTTL <= 0 || c.TripShareTTL > 24*time.Hour {
+		return fmt.Errorf("TRIP_SHARE_TTL must be positive and at most 24h, got %s", c.TripShareTTL)
+	}
+	if c.IdempotencyKeyTTL <= 0 {
+		return fmt.Errorf("IDEMPOTENCY_KEY_TTL must be positive, got %s", c.IdempotencyKeyTTL)
+	}
+	if c.IdempotencyStore != "memory" && c.IdempotencyStore != "redis" {
+		return fmt.Errorf("IDEMPOTENCY_STORE must be memory or redis, got %q", c.IdempotencyStore)
+	}
+	if err := c.RedisTopology.Validate(); err != nil {
+		return err
+	}
+	if c.ChatStore != "memory" && c.ChatStore != "mongo" {
+		return fmt.Errorf("CHAT_STORE must be memory or mongo, got %q", c.ChatStore)
+	}
+	if c.TripListingStore != "memory" && c.TripListingStore != "mongo" {
+		return fmt.Errorf("TRIP_LISTING_STORE must be memory or mongo, got %q", c.TripListingStore)
+	}
+	if c.DriverReliabilityStore != "memory" && c.DriverReliabilityStore != "postgres" {
+		return fmt.Errorf("DRIVER_RELIABILITY_STORE must be memory or postgres, got %q", c.DriverReliabilityStore)
+	}
+	if c.TripProjectionInterval <= 0 {
+		return fmt.Errorf("TRIP_PROJECTION_INTERVAL must be positive, got %s", c.TripProjectionInterval)
+	}
+	if c.TripProjectionMaxLag <= 0 {
+		return fmt.Errorf("TRIP_PROJECTION_MAX_LAG must be positive, got %s", c.TripProjectionMaxLag)
+	}
+	if c.ContactSessionTTL <= 0 {
+		return fmt.Errorf("CONTACT_SESSION_TTL must be positive, got %s", c.ContactSessionTTL)
+	}
+	if c.ContactSweepInterval <= 0 {
+		return fmt.Errorf("CONTACT_SWEEP_INTERVAL must be positive, got %s", c.ContactSweepInterval)
+	}
+	if c.PickupGuaranteeGracePeriod < 0 {
+		return fmt.Errorf("PICKUP_GUARANTEE_GRACE_PERIOD must not be negative, got %s", c.PickupGuaranteeGracePeriod)
+	}
+	if c.PickupGuaranteeCredit < 0 {
+		return fmt.Errorf("PICKUP_GUARANTEE_CREDIT must not be negative, got %v", c.PickupGuaranteeCredit)
+	}
+	if c.DriverReliabilityHalfLife <= 0 {
+		return fmt.Errorf("DRIVER_RELIABILITY_HALF_LIFE must be positive, got %s", c.DriverReliabilityHalfLife)
+	}
+	if c.ScheduledRideSweepSeconds < 1 {
+		return fmt.Errorf("SCHEDULED_RIDE_SWEEP_SECONDS must be at least 1, got %d", c.ScheduledRideSweepSeconds)
+	}
+	if c.ReconciliationRunAt < 0 || c.ReconciliationRunAt >= 24*time.Hour {
+		return fmt.Errorf("RECONCILIATION_RUN_AT must be within a day, got %s", c.ReconciliationRunAt)
+	}
+	if c.MetricsIngestInterval <= 0 {
+		return fmt.Errorf("METRICS_INGEST_INTERVAL must be positive, got %s", c.MetricsIngestInterval)
+	}
+	if c.ReconciliationToleranceCents < 0 {
+		return fmt.Errorf("RECONCILIATION_TOLERANCE_CENTS must not be negative, got %d", c.ReconciliationToleranceCents)
+	}
+	return nil
 }
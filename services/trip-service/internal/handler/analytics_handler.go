@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/rideshare-platform/shared/analytics"
+	"github.com/rideshare-platform/shared/monitoring"
+)
+
+// BusinessMetricsProvider returns the business KPIs for a time range
+type BusinessMetricsProvider interface {
+	GetBusinessMetrics(ctx context.Context, from, to time.Time) (*monitoring.BusinessMetrics, error)
+}
+
+// AnalyticsHandler serves business metrics computed from trip rollups
+type AnalyticsHandler struct {
+	metrics BusinessMetricsProvider
+}
+
+// NewAnalyticsHandler creates a new analytics handler
+func NewAnalyticsHandler(metrics BusinessMetricsProvider) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		metrics: metrics,
+	}
+}
+
+// RegisterRoutes registers the analytics routes
+func (h *AnalyticsHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/v1/analytics/business", h.GetBusinessMetrics)
+}
+
+// GetBusinessMetrics returns trip, revenue and rating KPIs for the range given
+// by the from and to query parameters, by default the last 24 hours
+func (h *AnalyticsHandler) GetBusinessMetrics(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	tr, err := analytics.ParseTimeRange(query.Get("from"), query.Get("to"), time.Now())
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+
+	metrics, err := h.metrics.GetBusinessMetrics(r.Context(), tr.From, tr.To)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "internal_error", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, metrics)
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rideshare-platform/services/trip-service/internal/service"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+)
+
+// DisputeHandler serves the endpoint riders dispute trip charges with.
+// Support staff review and resolve disputes through the gateway's admin API.
+type DisputeHandler struct {
+	disputes *service.DisputeService
+}
+
+// NewDisputeHandler creates a new dispute handler
+func NewDisputeHandler(disputes *service.DisputeService) *DisputeHandler {
+	return &DisputeHandler{
+		disputes: disputes,
+	}
+}
+
+// RegisterRoutes registers the dispute routes
+func (h *DisputeHandler) RegisterRoutes(router gin.IRouter) {
+	router.POST("/api/v1/trips/:id/disputes", h.OpenDispute)
+}
+
+// OpenDispute contests a trip's charge
+func (h *DisputeHandler) OpenDispute(c *gin.Context) {
+	var req service.OpenDisputeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeGinError(c, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+	req.TripID = c.Param("id")
+
+	dispute, err := h.disputes.OpenDispute(c.Request.Context(), &req)
+	if err != nil {
+		writeDisputeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, dispute)
+}
+
+func writeDisputeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, types.ErrTripNotFound):
+		writeGinError(c, http.StatusNotFound, "not_found", err)
+	case errors.Is(err, service.ErrNotTripRider):
+		writeGinError(c, http.StatusForbidden, "forbidden", err)
+	case errors.Is(err, service.ErrDisputeExists):
+		writeGinError(c, http.StatusConflict, "dispute_conflict", err)
+	case errors.Is(err, service.ErrTripNotDisputable), errors.Is(err, service.ErrDisputeWindowClosed):
+		writeGinError(c, http.StatusUnprocessableEntity, "not_disputable", err)
+	default:
+		writeGinError(c, http.StatusBadRequest, "invalid_request", err)
+	}
+}
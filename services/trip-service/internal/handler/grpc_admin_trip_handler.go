@@ -0,0 +1,231 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/rideshare-platform/services/trip-service/internal/service"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/currency"
+	"github.com/rideshare-platform/shared/events"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+	"github.com/rideshare-platform/shared/pagination"
+	trippb "github.com/rideshare-platform/shared/proto/trip"
+)
+
+// SetTrips attaches the trip store backed service used by the operator RPCs
+func (h *GRPCTripHandler) SetTrips(trips *service.TripService) {
+	h.trips = trips
+}
+
+// GetActiveTrips lists the trips in progress for the operations dashboard
+func (h *GRPCTripHandler) GetActiveTrips(ctx context.Context, req *trippb.GetActiveTripsRequest) (*trippb.GetActiveTripsResponse, error) {
+	if h.trips == nil {
+		return nil, status.Error(codes.Unimplemented, "active trip listing is not configured")
+	}
+
+	page, err := pagination.NewRequest(int(req.Limit), req.Cursor, req.Sort, service.ActiveTripListOptions)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	filter := service.ActiveTripFilter{
+		RiderID:  req.RiderId,
+		DriverID: req.DriverId,
+		CityID:   req.CityId,
+		Page:     page,
+	}
+	if req.Status != trippb.TripStatus_UNKNOWN_STATUS {
+		filter.Status = tripStatusFromProto(req.Status)
+		if filter.Status == "" {
+			return nil, status.Errorf(codes.InvalidArgument, "%s is not an active trip status", req.Status)
+		}
+	}
+
+	trips, err := h.trips.ListActiveTrips(ctx, filter)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	resp := &trippb.GetActiveTripsResponse{
+		Count:      int32(trips.TotalEstimate),
+		NextCursor: trips.NextCursor,
+	}
+	for _, trip := range trips.Items {
+		resp.Trips = append(resp.Trips, tripToProto(trip))
+	}
+	return resp, nil
+}
+
+// ForceCancelTrip cancels an active trip on behalf of the platform. The
+// cancellation is announced so the rider is refunded.
+func (h *GRPCTripHandler) ForceCancelTrip(ctx context.Context, req *trippb.ForceCancelTripRequest) (*trippb.ForceCancelTripResponse, error) {
+	if h.trips == nil {
+		return nil, status.Error(codes.Unimplemented, "trip cancellation is not configured")
+	}
+	if req.TripId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Trip ID is required")
+	}
+
+	previous, err := h.trips.GetTrip(ctx, req.TripId)
+	if err != nil {
+		return nil, forceCancelError(err)
+	}
+	oldStatus := tripStatusToProto(previous)
+
+	trip, err := h.trips.ForceCancelTrip(ctx, req.TripId, req.Reason, req.ActorId)
+	if err != nil {
+		return nil, forceCancelError(err)
+	}
+	protoTrip := tripToProto(trip)
+
+	h.NotifyTripUpdate(trip.ID, oldStatus, protoTrip.Status, map[string]string{
+		"previous_status": oldStatus.String(),
+		"reason":          req.Reason,
+		"updated_by":      req.ActorId,
+		"event_type":      "force_cancelled",
+	})
+	h.publishCancelledEvent(ctx, trip)
+
+	return &trippb.ForceCancelTripResponse{Trip: protoTrip}, nil
+}
+
+// publishCancelledEvent announces a platform cancellation to payments and
+// notifications
+func (h *GRPCTripHandler) publishCancelledEvent(ctx context.Context, trip *models.Trip) {
+	if h.events == nil {
+		return
+	}
+
+	data := map[string]interface{}{
+		"trip_id":  trip.ID,
+		"rider_id": trip.RiderID,
+	}
+	if trip.DriverID != nil {
+		data["driver_id"] = *trip.DriverID
+	}
+	if trip.CancelledBy != nil {
+		data["cancelled_by"] = *trip.CancelledBy
+	}
+	if trip.CancellationReason != nil {
+		data["reason"] = *trip.CancellationReason
+	}
+
+	event := events.NewEvent(events.TripCancelledEvent, trip.ID, 1, data, "trip-service")
+	h.tagExperiments(ctx, event, trip.ID, trip.RiderID)
+	if err := h.events.PublishEvent(ctx, event); err != nil {
+		h.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+			"trip_id": trip.ID,
+		}).Warn("Failed to publish trip cancelled event")
+	}
+}
+
+// forceCancelError maps trip cancellation errors to gRPC status codes
+func forceCancelError(err error) error {
+	switch {
+	case errors.Is(err, types.ErrTripNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrInvalidTripTransition):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+}
+
+// tripStatusFromProto returns the stored status an active proto status
+// filters by, or "" for statuses that are not active
+func tripStatusFromProto(s trippb.TripStatus) models.TripStatus {
+	switch s {
+	case trippb.TripStatus_REQUESTED:
+		return models.TripStatusRequested
+	case trippb.TripStatus_MATCHED:
+		return models.TripStatusMatched
+	case trippb.TripStatus_DRIVER_EN_ROUTE:
+		return models.TripStatusDriverArriving
+	case trippb.TripStatus_DRIVER_ARRIVED:
+		return models.TripStatusDriverArrived
+	case trippb.TripStatus_TRIP_STARTED:
+		return models.TripStatusTripStarted
+	case trippb.TripStatus_IN_PROGRESS:
+		return models.TripStatusInProgress
+	default:
+		return ""
+	}
+}
+
+func tripStatusToProto(trip *models.Trip) trippb.TripStatus {
+	switch trip.Status {
+	case models.TripStatusRequested:
+		return trippb.TripStatus_REQUESTED
+	case models.TripStatusMatched, models.TripStatusDriverAssigned:
+		return trippb.TripStatus_MATCHED
+	case models.TripStatusDriverArriving:
+		return trippb.TripStatus_DRIVER_EN_ROUTE
+	case models.TripStatusDriverArrived:
+		return trippb.TripStatus_DRIVER_ARRIVED
+	case models.TripStatusTripStarted:
+		return trippb.TripStatus_TRIP_STARTED
+	case models.TripStatusInProgress:
+		return trippb.TripStatus_IN_PROGRESS
+	case models.TripStatusCompleted:
+		return trippb.TripStatus_COMPLETED
+	case models.TripStatusCancelled:
+		if trip.CancelledBy != nil && *trip.CancelledBy == "driver" {
+			return trippb.TripStatus_CANCELLED_BY_DRIVER
+		}
+		return trippb.TripStatus_CANCELLED_BY_RIDER
+	case models.TripStatusFailed:
+		return trippb.TripStatus_FAILED
+	default:
+		return trippb.TripStatus_UNKNOWN_STATUS
+	}
+}
+
+func tripToProto(trip *models.Trip) *trippb.Trip {
+	protoTrip := &trippb.Trip{
+		Id:                 trip.ID,
+		RiderId:            trip.RiderID,
+		Status:             tripStatusToProto(trip),
+		PickupLocation:     locationToProto(&trip.PickupLocation),
+		Destination:        locationToProto(&trip.Destination),
+		RequestedAt:        timestamppb.New(trip.RequestedAt),
+		Metadata:           &trippb.TripMetadata{},
+		CityId:             trip.CityID,
+		AccessibilityNeeds: trip.AccessibilityNeeds,
+	}
+	if trip.DriverID != nil {
+		protoTrip.DriverId = *trip.DriverID
+	}
+	if trip.EstimatedFareCents != nil {
+		protoTrip.EstimatedFare = currency.FromMinor(*trip.EstimatedFareCents, trip.Currency)
+	}
+	if trip.ActualFareCents != nil {
+		protoTrip.ActualFare = currency.FromMinor(*trip.ActualFareCents, trip.Currency)
+	}
+	if trip.DriverAssignedAt != nil {
+		protoTrip.AcceptedAt = timestamppb.New(*trip.DriverAssignedAt)
+	}
+	if trip.StartedAt != nil {
+		protoTrip.StartedAt = timestamppb.New(*trip.StartedAt)
+	}
+	if trip.CompletedAt != nil {
+		protoTrip.CompletedAt = timestamppb.New(*trip.CompletedAt)
+	}
+	if trip.ScheduledFor != nil {
+		protoTrip.ScheduledFor = timestamppb.New(*trip.ScheduledFor)
+	}
+	if trip.EstimatedDistanceKm != nil {
+		protoTrip.Metadata.DistanceKm = *trip.EstimatedDistanceKm
+	}
+	if trip.SurgeMultiplier != nil {
+		protoTrip.Metadata.SurgeMultiplier = *trip.SurgeMultiplier
+	}
+	if trip.CancellationReason != nil {
+		protoTrip.Metadata.CancellationReason = *trip.CancellationReason
+	}
+	return protoTrip
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/rideshare-platform/services/trip-service/internal/service"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	trippb "github.com/rideshare-platform/shared/proto/trip"
+)
+
+// SetCompliance attaches the compliance report service
+func (h *GRPCTripHandler) SetCompliance(compliance *service.ComplianceService) {
+	h.compliance = compliance
+}
+
+// ListComplianceReports returns the most recent compliance reports without
+// their content
+func (h *GRPCTripHandler) ListComplianceReports(ctx context.Context, req *trippb.ListComplianceReportsRequest) (*trippb.ListComplianceReportsResponse, error) {
+	if h.compliance == nil {
+		return nil, status.Error(codes.Unimplemented, "compliance reports are not configured")
+	}
+	limit := int(req.Limit)
+	if limit <= 0 || limit > 100 {
+		limit = 30
+	}
+
+	reports, err := h.compliance.ListReports(ctx, types.ComplianceReportFilter{
+		CityID:     req.CityId,
+		TemplateID: req.TemplateId,
+		Limit:      limit,
+	})
+	if err != nil {
+		return nil, complianceError(err)
+	}
+
+	resp := &trippb.ListComplianceReportsResponse{Reports: make([]*trippb.ComplianceReport, 0, len(reports))}
+	for _, report := range reports {
+		resp.Reports = append(resp.Reports, complianceReportToProto(report))
+	}
+	return resp, nil
+}
+
+// GetComplianceReport returns a compliance report with its content
+func (h *GRPCTripHandler) GetComplianceReport(ctx context.Context, req *trippb.GetComplianceReportRequest) (*trippb.ComplianceReport, error) {
+	if h.compliance == nil {
+		return nil, status.Error(codes.Unimplemented, "compliance reports are not configured")
+	}
+
+	report, err := h.compliance.GetReport(ctx, req.ReportId)
+	if err != nil {
+		return nil, complianceError(err)
+	}
+	return complianceReportToProto(report), nil
+}
+
+// GenerateComplianceReport generates a template's report for the period
+// containing the requested date
+func (h *GRPCTripHandler) GenerateComplianceReport(ctx context.Context, req *trippb.GenerateComplianceReportRequest) (*trippb.ComplianceReport, error) {
+	if h.compliance == nil {
+		return nil, status.Error(codes.Unimplemented, "compliance reports are not configured")
+	}
+
+	report, err := h.compliance.GenerateReport(ctx, req.TemplateId, req.Date, req.GeneratedBy)
+	if err != nil {
+		return nil, complianceError(err)
+	}
+	return complianceReportToProto(report), nil
+}
+
+// complianceError maps compliance report errors to gRPC status codes
+func complianceError(err error) error {
+	switch {
+	case errors.Is(err, types.ErrComplianceReportNotFound), errors.Is(err, service.ErrComplianceTemplateNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrInvalidComplianceRequest):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func complianceReportToProto(report *types.ComplianceReport) *trippb.ComplianceReport {
+	return &trippb.ComplianceReport{
+		Id:                    report.ID,
+		TemplateId:            report.TemplateID,
+		TemplateName:          report.TemplateName,
+		CityId:                report.CityID,
+		Schedule:              report.Schedule,
+		PeriodStart:           timestamppb.New(report.PeriodStart),
+		PeriodEnd:             timestamppb.New(report.PeriodEnd),
+		Timezone:              report.Timezone,
+		TripCount:             int64(report.TripCount),
+		AccessibleTripCount:   int64(report.AccessibleTripCount),
+		SuppressedZoneCount:   int64(report.SuppressedZoneCount),
+		ContentType:           report.ContentType,
+		Content:               report.Content,
+		GeneratedBy:           report.GeneratedBy,
+		GeneratedAt:           timestamppb.New(report.GeneratedAt),
+		WavRequestCount:       int64(report.WAVRequestCount),
+		WavFulfilledCount:     int64(report.WAVFulfilledCount),
+		WavFulfillmentRate:    report.WAVFulfillmentRate,
+		WavAverageWaitSeconds: report.WAVAverageWaitSeconds,
+	}
+}
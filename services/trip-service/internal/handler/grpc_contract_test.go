@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/rideshare-platform/services/trip-service/internal/repository"
+	"github.com/rideshare-platform/services/trip-service/internal/service"
+	"github.com/rideshare-platform/shared/clients/contract"
+	"github.com/rideshare-platform/shared/clients/tripclient"
+	"github.com/rideshare-platform/shared/contactproxy"
+	"github.com/rideshare-platform/shared/events"
+	"github.com/rideshare-platform/shared/grpc/interceptor"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/middleware"
+	trippb "github.com/rideshare-platform/shared/proto/trip"
+)
+
+func TestGRPCTripHandler_ServesContract(t *testing.T) {
+	log := logger.NewLogger("error", "test")
+	tripStore := repository.NewMemoryTripStore()
+	trips := service.NewTripService(tripStore, log)
+	trips.SetEventLog(repository.NewMemoryTripEventLog())
+	trips.SetShareLinks([]byte("contract-test-secret"), time.Hour)
+
+	ratings := service.NewRatingService(repository.NewMemoryRatingStore(), service.DefaultRatingConfig(), log)
+	receipts := service.NewReceiptService(repository.NewMemoryReceiptStore(), log)
+	receipts.SetRatingService(ratings)
+	grpcHandler := NewGRPCTripHandler(
+		service.NewBasicTripService(log),
+		service.NewSharedTripService(repository.NewMemorySharedTripStore(), log),
+		service.NewScheduledRideService(repository.NewMemoryScheduledRideStore(), nil, service.DefaultScheduledRideConfig(), log),
+		ratings,
+		receipts,
+		log,
+	)
+	grpcHandler.SetTrips(trips)
+	grpcHandler.SetEmergencies(service.NewEmergencyService(tripStore, repository.NewMemoryIncidentStore(), log))
+	grpcHandler.SetDisputes(service.NewDisputeService(tripStore, repository.NewMemoryDisputeStore(), service.DefaultDisputeConfig(), log))
+	grpcHandler.SetLostItems(service.NewLostItemService(tripStore, repository.NewMemoryLostItemStore(), service.DefaultLostItemConfig(), log))
+	grpcHandler.SetContacts(service.NewTripContactService(tripStore, contactproxy.NewManager(contactproxy.NewLocalProvider(nil, log), contactproxy.NewMemoryStore(), contactproxy.DefaultConfig(), log), log))
+	grpcHandler.SetPickupGuarantees(service.NewPickupGuaranteeService(tripStore, repository.NewMemoryPickupGuaranteeStore(), service.DefaultPickupGuaranteeConfig(), log))
+	grpcHandler.SetChat(service.NewChatService(tripStore, repository.NewMemoryTripMessageStore(), log))
+	grpcHandler.SetCompliance(service.NewComplianceService(tripStore, nil, service.ComplianceTemplates{}, repository.NewMemoryComplianceStore(), log))
+	grpcHandler.SetDeadLetters(events.NewDeadLetterQueue(events.NewInMemoryDeadLetterStore(), log))
+	grpcHandler.SetProjector(service.NewTripProjector(repository.NewMemoryTripEventLog(), tripStore, repository.NewMemoryTripListingStore(), service.DefaultProjectionConfig(), log))
+	grpcHandler.SetDriverEvents(service.NewDriverEventHub(log))
+	grpcHandler.SetDriverReliability(service.NewDriverReliabilityService(repository.NewMemoryDriverReliabilityStore(0)))
+
+	conn := contract.Serve(t, func(server *grpc.Server) {
+		trippb.RegisterTripServiceServer(server, grpcHandler)
+	})
+	contract.Check(t, tripclient.ContractCalls(conn))
+}
+
+func TestGRPCTripHandler_DeadLettersRequireAdmin(t *testing.T) {
+	const secret = "dead-letter-test-secret"
+	log := logger.NewLogger("error", "test")
+	grpcHandler := NewGRPCTripHandler(nil, nil, nil, nil, nil, log)
+	grpcHandler.SetDeadLetters(events.NewDeadLetterQueue(events.NewInMemoryDeadLetterStore(), log))
+
+	auth := interceptor.JWTAuth(secret, false)
+	conn := contract.Serve(t, func(server *grpc.Server) {
+		trippb.RegisterTripServiceServer(server, grpcHandler)
+	}, grpc.ChainUnaryInterceptor(interceptor.AuthUnaryServerInterceptor(*auth)))
+	client := trippb.NewTripServiceClient(conn)
+
+	tokens := middleware.NewAuthMiddleware(secret, nil)
+	withToken := func(userType string) context.Context {
+		token, err := tokens.GenerateToken("user-1", userType, "user@example.com", 1)
+		if err != nil {
+			t.Fatalf("failed to sign token: %v", err)
+		}
+		return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want codes.Code
+	}{
+		{"no token", context.Background(), codes.PermissionDenied},
+		{"rider token", withToken("rider"), codes.PermissionDenied},
+		{"admin token", withToken("admin"), codes.NotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.ReplayDeadLetter(tt.ctx, &trippb.ReplayDeadLetterRequest{DeadLetterId: "missing"})
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("ReplayDeadLetter returned %v, want %v", got, tt.want)
+			}
+			_, err = client.DiscardDeadLetter(tt.ctx, &trippb.DiscardDeadLetterRequest{DeadLetterId: "missing"})
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("DiscardDeadLetter returned %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/rideshare-platform/shared/events"
+	"github.com/rideshare-platform/shared/grpc/interceptor"
+	trippb "github.com/rideshare-platform/shared/proto/trip"
+)
+
+// SetDeadLetters attaches the queue of events trip-service's consumers
+// failed to handle, which operators inspect, replay and discard through the
+// gateway's admin API. Every dead letter call requires an operator token.
+func (h *GRPCTripHandler) SetDeadLetters(queue *events.DeadLetterQueue) {
+	h.deadLetters = queue
+}
+
+// ListDeadLetters returns dead letters, most recently failed first
+func (h *GRPCTripHandler) ListDeadLetters(ctx context.Context, req *trippb.ListDeadLettersRequest) (*trippb.ListDeadLettersResponse, error) {
+	if err := interceptor.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if h.deadLetters == nil {
+		return nil, status.Error(codes.Unimplemented, "dead letters are not configured")
+	}
+	filter := events.DeadLetterFilter{
+		Consumer:  req.Consumer,
+		EventType: events.EventType(req.EventType),
+		Limit:     int(req.Limit),
+	}
+	if filter.Limit <= 0 || filter.Limit > 1000 {
+		filter.Limit = 100
+	}
+	if req.Poison != "" {
+		poison, err := strconv.ParseBool(req.Poison)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "poison must be true or false")
+		}
+		filter.Poison = &poison
+	}
+
+	letters, err := h.deadLetters.List(ctx, filter)
+	if err != nil {
+		return nil, deadLetterError(err)
+	}
+	resp := &trippb.ListDeadLettersResponse{DeadLetters: make([]*trippb.DeadLetter, 0, len(letters))}
+	for _, letter := range letters {
+		resp.DeadLetters = append(resp.DeadLetters, deadLetterToProto(letter))
+	}
+	return resp, nil
+}
+
+// GetDeadLetter returns a dead letter with its event
+func (h *GRPCTripHandler) GetDeadLetter(ctx context.Context, req *trippb.GetDeadLetterRequest) (*trippb.DeadLetter, error) {
+	if err := interceptor.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if h.deadLetters == nil {
+		return nil, status.Error(codes.Unimplemented, "dead letters are not configured")
+	}
+
+	letter, err := h.deadLetters.Get(ctx, req.DeadLetterId)
+	if err != nil {
+		return nil, deadLetterError(err)
+	}
+	return deadLetterToProto(letter), nil
+}
+
+// ReplayDeadLetter hands a dead letter's event to the handler that failed it
+// again. Events that fail again stay dead-lettered.
+func (h *GRPCTripHandler) ReplayDeadLetter(ctx context.Context, req *trippb.ReplayDeadLetterRequest) (*trippb.ReplayDeadLetterResponse, error) {
+	if err := interceptor.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if h.deadLetters == nil {
+		return nil, status.Error(codes.Unimplemented, "dead letters are not configured")
+	}
+
+	if err := h.deadLetters.Replay(ctx, req.DeadLetterId); err != nil {
+		if errors.Is(err, events.ErrDeadLetterNotFound) || errors.Is(err, events.ErrUnknownConsumer) {
+			return nil, deadLetterError(err)
+		}
+		return nil, status.Errorf(codes.Aborted, "replay failed: %v", err)
+	}
+	return &trippb.ReplayDeadLetterResponse{}, nil
+}
+
+// DiscardDeadLetter drops a dead letter without handling its event
+func (h *GRPCTripHandler) DiscardDeadLetter(ctx context.Context, req *trippb.DiscardDeadLetterRequest) (*trippb.DiscardDeadLetterResponse, error) {
+	if err := interceptor.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if h.deadLetters == nil {
+		return nil, status.Error(codes.Unimplemented, "dead letters are not configured")
+	}
+
+	if err := h.deadLetters.Discard(ctx, req.DeadLetterId); err != nil {
+		return nil, deadLetterError(err)
+	}
+	return &trippb.DiscardDeadLetterResponse{}, nil
+}
+
+// deadLetterError maps dead letter errors to gRPC status codes
+func deadLetterError(err error) error {
+	switch {
+	case errors.Is(err, events.ErrDeadLetterNotFound), errors.Is(err, events.ErrUnknownConsumer):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func deadLetterToProto(letter *events.DeadLetter) *trippb.DeadLetter {
+	// Events are decoded from JSON, so they always encode again
+	event, _ := json.Marshal(letter.Event)
+	return &trippb.DeadLetter{
+		Id:            letter.ID,
+		Consumer:      letter.Consumer,
+		Handler:       letter.Handler,
+		EventId:       letter.Event.ID,
+		EventType:     string(letter.Event.Type),
+		EventJson:     string(event),
+		Error:         letter.Error,
+		Poison:        letter.Poison,
+		Attempts:      int32(letter.Attempts),
+		Replays:       int32(letter.Replays),
+		FirstFailedAt: timestamppb.New(letter.FirstFailedAt),
+		LastFailedAt:  timestamppb.New(letter.LastFailedAt),
+	}
+}
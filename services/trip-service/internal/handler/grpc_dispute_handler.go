@@ -0,0 +1,226 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/rideshare-platform/services/trip-service/internal/service"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/models"
+	trippb "github.com/rideshare-platform/shared/proto/trip"
+)
+
+// SetDisputes attaches the dispute service behind the fare dispute RPCs
+func (h *GRPCTripHandler) SetDisputes(disputes *service.DisputeService) {
+	h.disputes = disputes
+}
+
+// OpenDispute records a rider contesting a trip's charge
+func (h *GRPCTripHandler) OpenDispute(ctx context.Context, req *trippb.OpenDisputeRequest) (*trippb.Dispute, error) {
+	if h.disputes == nil {
+		return nil, status.Error(codes.Unimplemented, "fare disputes are not configured")
+	}
+
+	open := &service.OpenDisputeRequest{
+		TripID:      req.TripId,
+		RiderID:     req.RiderId,
+		Reason:      types.DisputeReason(req.Reason),
+		Description: req.Description,
+	}
+	for _, evidence := range req.Evidence {
+		open.Evidence = append(open.Evidence, types.DisputeEvidence{Description: evidence.Description, URL: evidence.Url})
+	}
+
+	dispute, err := h.disputes.OpenDispute(ctx, open)
+	if err != nil {
+		return nil, disputeError(err)
+	}
+	return disputeToProto(dispute), nil
+}
+
+// GetDisputeReview returns a dispute with the trip's route for support staff
+func (h *GRPCTripHandler) GetDisputeReview(ctx context.Context, req *trippb.GetDisputeRequest) (*trippb.DisputeReview, error) {
+	if h.disputes == nil {
+		return nil, status.Error(codes.Unimplemented, "fare disputes are not configured")
+	}
+
+	review, err := h.disputes.ReviewDispute(ctx, req.DisputeId)
+	if err != nil {
+		return nil, disputeError(err)
+	}
+
+	resp := &trippb.DisputeReview{
+		Dispute:            disputeToProto(review.Dispute),
+		RouteRecorded:      review.RouteRecorded,
+		RouteDistanceKm:    review.RouteDistanceKm,
+		ReportedDistanceKm: review.ReportedDistanceKm,
+		DurationSeconds:    int32(review.DurationSeconds),
+	}
+	for i := range review.Route {
+		resp.Route = append(resp.Route, locationToProto(&review.Route[i]))
+	}
+	return resp, nil
+}
+
+// ListDisputes lists disputes for the support queue, oldest first
+func (h *GRPCTripHandler) ListDisputes(ctx context.Context, req *trippb.ListDisputesRequest) (*trippb.ListDisputesResponse, error) {
+	if h.disputes == nil {
+		return nil, status.Error(codes.Unimplemented, "fare disputes are not configured")
+	}
+
+	disputes, err := h.disputes.ListDisputes(ctx, types.DisputeStatus(req.Status), int(req.Limit))
+	if err != nil {
+		return nil, disputeError(err)
+	}
+
+	resp := &trippb.ListDisputesResponse{}
+	for _, dispute := range disputes {
+		resp.Disputes = append(resp.Disputes, disputeToProto(dispute))
+	}
+	return resp, nil
+}
+
+// StartDisputeReview assigns a dispute to a member of staff
+func (h *GRPCTripHandler) StartDisputeReview(ctx context.Context, req *trippb.StartDisputeReviewRequest) (*trippb.Dispute, error) {
+	if h.disputes == nil {
+		return nil, status.Error(codes.Unimplemented, "fare disputes are not configured")
+	}
+
+	dispute, err := h.disputes.StartReview(ctx, req.DisputeId, req.StaffId)
+	if err != nil {
+		return nil, disputeError(err)
+	}
+	return disputeToProto(dispute), nil
+}
+
+// AddDisputeNote adds a note to a dispute's timeline
+func (h *GRPCTripHandler) AddDisputeNote(ctx context.Context, req *trippb.AddDisputeNoteRequest) (*trippb.Dispute, error) {
+	if h.disputes == nil {
+		return nil, status.Error(codes.Unimplemented, "fare disputes are not configured")
+	}
+
+	dispute, err := h.disputes.AddNote(ctx, req.DisputeId, req.AuthorId, req.Text)
+	if err != nil {
+		return nil, disputeError(err)
+	}
+	return disputeToProto(dispute), nil
+}
+
+// ResolveDispute closes a dispute, refunding the rider when a refund amount is set
+func (h *GRPCTripHandler) ResolveDispute(ctx context.Context, req *trippb.ResolveDisputeRequest) (*trippb.Dispute, error) {
+	if h.disputes == nil {
+		return nil, status.Error(codes.Unimplemented, "fare disputes are not configured")
+	}
+
+	dispute, err := h.disputes.ResolveDispute(ctx, req.DisputeId, &service.ResolveDisputeRequest{
+		StaffID:      req.StaffId,
+		Resolution:   req.Resolution,
+		RefundAmount: req.RefundAmount,
+	})
+	if err != nil {
+		return nil, disputeError(err)
+	}
+	return disputeToProto(dispute), nil
+}
+
+// RejectDispute closes a dispute without a refund
+func (h *GRPCTripHandler) RejectDispute(ctx context.Context, req *trippb.RejectDisputeRequest) (*trippb.Dispute, error) {
+	if h.disputes == nil {
+		return nil, status.Error(codes.Unimplemented, "fare disputes are not configured")
+	}
+
+	dispute, err := h.disputes.RejectDispute(ctx, req.DisputeId, req.StaffId, req.Resolution)
+	if err != nil {
+		return nil, disputeError(err)
+	}
+	return disputeToProto(dispute), nil
+}
+
+// disputeError maps fare dispute errors to gRPC status codes
+func disputeError(err error) error {
+	switch {
+	case errors.Is(err, types.ErrTripNotFound), errors.Is(err, types.ErrDisputeNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrNotTripRider):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrDisputeExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, service.ErrDisputeClosed), errors.Is(err, service.ErrTripNotDisputable),
+		errors.Is(err, service.ErrDisputeWindowClosed), errors.Is(err, service.ErrRefundExceedsCharge):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrRefundFailed), errors.Is(err, service.ErrRefundsUnavailable):
+		return status.Error(codes.Unavailable, err.Error())
+	default:
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+}
+
+func disputeToProto(dispute *types.Dispute) *trippb.Dispute {
+	resp := &trippb.Dispute{
+		Id:            dispute.ID,
+		TripId:        dispute.TripID,
+		RiderId:       dispute.RiderID,
+		DriverId:      dispute.DriverID,
+		Reason:        string(dispute.Reason),
+		Description:   dispute.Description,
+		Status:        string(dispute.Status),
+		ChargedAmount: dispute.ChargedAmount,
+		Currency:      dispute.Currency,
+		FareBreakdown: fareLinesToProto(dispute.Fare),
+		AssignedTo:    dispute.AssignedTo,
+		Resolution:    dispute.Resolution,
+		RefundAmount:  dispute.RefundAmount,
+		RefundId:      dispute.RefundID,
+		ResolvedBy:    dispute.ResolvedBy,
+		ResolvedAt:    optionalTimestamp(dispute.ResolvedAt),
+		CreatedAt:     timestamppb.New(dispute.CreatedAt),
+		UpdatedAt:     timestamppb.New(dispute.UpdatedAt),
+	}
+	for _, evidence := range dispute.Evidence {
+		resp.Evidence = append(resp.Evidence, &trippb.DisputeEvidence{Description: evidence.Description, Url: evidence.URL})
+	}
+	for _, note := range dispute.Notes {
+		resp.Notes = append(resp.Notes, &trippb.DisputeNote{
+			AuthorId:  note.AuthorID,
+			Text:      note.Text,
+			CreatedAt: timestamppb.New(note.CreatedAt),
+		})
+	}
+	return resp
+}
+
+// fareLinesToProto lists the fare's charged components in receipt order,
+// leaving out those that are zero. The total is the dispute's charged amount.
+func fareLinesToProto(fare *models.FareBreakdown) []*trippb.FareLine {
+	if fare == nil {
+		return nil
+	}
+	components := []struct {
+		name   string
+		amount models.Money
+	}{
+		{"base_fare", fare.BaseFare},
+		{"distance_fare", fare.DistanceFare},
+		{"time_fare", fare.TimeFare},
+		{"waiting_fare", fare.WaitingFare},
+		{"surge_amount", fare.SurgeAmount},
+		{"booking_fee", fare.BookingFee},
+		{"service_fee", fare.ServiceFee},
+		{"surcharges", fare.Surcharges},
+		{"taxes", fare.Taxes},
+		{"discount", fare.Discount},
+	}
+
+	var lines []*trippb.FareLine
+	for _, component := range components {
+		if component.amount.IsZero() {
+			continue
+		}
+		lines = append(lines, &trippb.FareLine{Name: component.name, Amount: component.amount.ToFloat64()})
+	}
+	return lines
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/rideshare-platform/services/trip-service/internal/service"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/events"
+	"github.com/rideshare-platform/shared/logger"
+	trippb "github.com/rideshare-platform/shared/proto/trip"
+)
+
+// SetDriverEvents attaches the hub driver event streams are served from
+func (h *GRPCTripHandler) SetDriverEvents(hub *service.DriverEventHub) {
+	h.driverEvents = hub
+}
+
+// SubscribeDriverEvents streams a driver's trip assignments, cancellations
+// and their riders' locations until the driver disconnects
+func (h *GRPCTripHandler) SubscribeDriverEvents(req *trippb.SubscribeDriverEventsRequest, stream trippb.TripService_SubscribeDriverEventsServer) error {
+	if h.driverEvents == nil {
+		return status.Error(codes.Unimplemented, "driver events are not configured")
+	}
+
+	subscription, err := h.driverEvents.Subscribe(req.DriverId)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	defer subscription.Close()
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event := <-subscription.Events:
+			if err := stream.Send(driverEventToProto(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// UpdateRiderLocation shares a waiting rider's location with the driver on
+// the way to pick them up
+func (h *GRPCTripHandler) UpdateRiderLocation(ctx context.Context, req *trippb.UpdateRiderLocationRequest) (*trippb.UpdateRiderLocationResponse, error) {
+	if req.Location == nil {
+		return nil, status.Error(codes.InvalidArgument, "location is required")
+	}
+	if h.trips == nil || h.events == nil {
+		return nil, status.Error(codes.Unimplemented, "rider location sharing is not configured")
+	}
+
+	driverID, err := h.trips.RiderLocationRecipient(ctx, req.TripId, req.RiderId)
+	if err != nil {
+		return nil, riderLocationError(err)
+	}
+
+	event := events.NewEvent(events.TripRiderLocationUpdatedEvent, req.TripId, 1, map[string]interface{}{
+		"trip_id":   req.TripId,
+		"rider_id":  req.RiderId,
+		"driver_id": driverID,
+		"latitude":  req.Location.Latitude,
+		"longitude": req.Location.Longitude,
+	}, "trip-service")
+	if err := h.events.PublishEvent(ctx, event); err != nil {
+		h.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+			"trip_id": req.TripId,
+		}).Warn("Failed to publish rider location")
+		return nil, status.Error(codes.Unavailable, "failed to share rider location")
+	}
+	return &trippb.UpdateRiderLocationResponse{}, nil
+}
+
+// riderLocationError maps rider location errors to gRPC status codes
+func riderLocationError(err error) error {
+	switch {
+	case errors.Is(err, types.ErrTripNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrTripNotOwned):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrRiderLocationNotShared):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+}
+
+var driverEventTypeToProto = map[string]trippb.DriverEventType{
+	service.DriverEventTripAssigned:  trippb.DriverEventType_DRIVER_EVENT_TRIP_ASSIGNED,
+	service.DriverEventTripCancelled: trippb.DriverEventType_DRIVER_EVENT_TRIP_CANCELLED,
+	service.DriverEventRiderLocation: trippb.DriverEventType_DRIVER_EVENT_RIDER_LOCATION,
+	service.DriverEventLostItem:      trippb.DriverEventType_DRIVER_EVENT_LOST_ITEM_REPORTED,
+}
+
+func driverEventToProto(event *service.DriverEvent) *trippb.DriverEvent {
+	return &trippb.DriverEvent{
+		EventId:       event.ID,
+		Type:          driverEventTypeToProto[event.Type],
+		TripId:        event.TripID,
+		RiderId:       event.RiderID,
+		RiderLocation: locationToProto(event.RiderLocation),
+		CancelledBy:   event.CancelledBy,
+		Reason:        event.Reason,
+		OccurredAt:    timestamppb.New(event.OccurredAt),
+		LostItemId:    event.LostItemID,
+		Description:   event.Description,
+	}
+}
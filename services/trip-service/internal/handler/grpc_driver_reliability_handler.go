@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/rideshare-platform/services/trip-service/internal/service"
+	trippb "github.com/rideshare-platform/shared/proto/trip"
+)
+
+// SetDriverReliability attaches the service tracking how reliably drivers finish their trips
+func (h *GRPCTripHandler) SetDriverReliability(reliability *service.DriverReliabilityService) {
+	h.reliability = reliability
+}
+
+// GetDriverReliability returns the cancellation rates and completion streaks
+// of a batch of drivers for matching
+func (h *GRPCTripHandler) GetDriverReliability(ctx context.Context, req *trippb.GetDriverReliabilityRequest) (*trippb.GetDriverReliabilityResponse, error) {
+	if h.reliability == nil {
+		return nil, status.Error(codes.Unimplemented, "driver reliability is not configured")
+	}
+
+	reliabilities, err := h.reliability.GetDriverReliabilities(ctx, req.DriverIds)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &trippb.GetDriverReliabilityResponse{Drivers: make(map[string]*trippb.DriverReliability, len(reliabilities))}
+	for driverID, reliability := range reliabilities {
+		resp.Drivers[driverID] = &trippb.DriverReliability{
+			DriverId:         driverID,
+			Trips:            reliability.Trips,
+			CancellationRate: reliability.CancellationRate(),
+			CompletionStreak: reliability.CompletionStreak,
+		}
+	}
+	return resp, nil
+}
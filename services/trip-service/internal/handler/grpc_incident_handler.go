@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/rideshare-platform/services/trip-service/internal/service"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/models"
+	trippb "github.com/rideshare-platform/shared/proto/trip"
+)
+
+// SetEmergencies attaches the emergency service behind the SOS and incident RPCs
+func (h *GRPCTripHandler) SetEmergencies(emergencies *service.EmergencyService) {
+	h.emergencies = emergencies
+}
+
+// ReportSOS raises an emergency for a trip and alerts the operations team
+func (h *GRPCTripHandler) ReportSOS(ctx context.Context, req *trippb.ReportSOSRequest) (*trippb.Incident, error) {
+	if h.emergencies == nil {
+		return nil, status.Error(codes.Unimplemented, "emergency incidents are not configured")
+	}
+
+	incident, err := h.emergencies.ReportSOS(ctx, &service.SOSRequest{
+		TripID:     req.TripId,
+		ReporterID: req.ReporterId,
+		Location:   locationFromProto(req.Location),
+		Message:    req.Message,
+	})
+	if err != nil {
+		return nil, incidentError(err)
+	}
+	return incidentToProto(incident), nil
+}
+
+// GetIncident returns an incident
+func (h *GRPCTripHandler) GetIncident(ctx context.Context, req *trippb.GetIncidentRequest) (*trippb.Incident, error) {
+	if h.emergencies == nil {
+		return nil, status.Error(codes.Unimplemented, "emergency incidents are not configured")
+	}
+
+	incident, err := h.emergencies.GetIncident(ctx, req.IncidentId)
+	if err != nil {
+		return nil, incidentError(err)
+	}
+	return incidentToProto(incident), nil
+}
+
+// ListIncidents lists incidents for the support queue, newest first
+func (h *GRPCTripHandler) ListIncidents(ctx context.Context, req *trippb.ListIncidentsRequest) (*trippb.ListIncidentsResponse, error) {
+	if h.emergencies == nil {
+		return nil, status.Error(codes.Unimplemented, "emergency incidents are not configured")
+	}
+
+	incidents, err := h.emergencies.ListIncidents(ctx, types.IncidentStatus(req.Status), int(req.Limit))
+	if err != nil {
+		return nil, incidentError(err)
+	}
+
+	resp := &trippb.ListIncidentsResponse{}
+	for _, incident := range incidents {
+		resp.Incidents = append(resp.Incidents, incidentToProto(incident))
+	}
+	return resp, nil
+}
+
+// AcknowledgeIncident records that a member of staff has taken an incident on
+func (h *GRPCTripHandler) AcknowledgeIncident(ctx context.Context, req *trippb.AcknowledgeIncidentRequest) (*trippb.Incident, error) {
+	if h.emergencies == nil {
+		return nil, status.Error(codes.Unimplemented, "emergency incidents are not configured")
+	}
+
+	incident, err := h.emergencies.AcknowledgeIncident(ctx, req.IncidentId, req.StaffId)
+	if err != nil {
+		return nil, incidentError(err)
+	}
+	return incidentToProto(incident), nil
+}
+
+// AddIncidentNote adds a note to an incident's timeline
+func (h *GRPCTripHandler) AddIncidentNote(ctx context.Context, req *trippb.AddIncidentNoteRequest) (*trippb.Incident, error) {
+	if h.emergencies == nil {
+		return nil, status.Error(codes.Unimplemented, "emergency incidents are not configured")
+	}
+
+	incident, err := h.emergencies.AddIncidentNote(ctx, req.IncidentId, req.AuthorId, req.Text)
+	if err != nil {
+		return nil, incidentError(err)
+	}
+	return incidentToProto(incident), nil
+}
+
+// ResolveIncident closes an incident
+func (h *GRPCTripHandler) ResolveIncident(ctx context.Context, req *trippb.ResolveIncidentRequest) (*trippb.Incident, error) {
+	if h.emergencies == nil {
+		return nil, status.Error(codes.Unimplemented, "emergency incidents are not configured")
+	}
+
+	incident, err := h.emergencies.ResolveIncident(ctx, req.IncidentId, req.StaffId, req.Resolution)
+	if err != nil {
+		return nil, incidentError(err)
+	}
+	return incidentToProto(incident), nil
+}
+
+// incidentError maps emergency incident errors to gRPC status codes
+func incidentError(err error) error {
+	switch {
+	case errors.Is(err, types.ErrTripNotFound), errors.Is(err, types.ErrIncidentNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrNotTripMember):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrIncidentResolved):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+}
+
+func incidentToProto(incident *types.Incident) *trippb.Incident {
+	resp := &trippb.Incident{
+		Id:             incident.ID,
+		TripId:         incident.TripID,
+		RiderId:        incident.RiderID,
+		DriverId:       incident.DriverID,
+		ReporterId:     incident.ReporterID,
+		ReporterRole:   incident.ReporterRole,
+		Status:         string(incident.Status),
+		TripStatus:     tripStatusToProto(&models.Trip{Status: incident.TripStatus}),
+		Location:       locationToProto(incident.Location),
+		AlertId:        incident.AlertID,
+		AcknowledgedBy: incident.AcknowledgedBy,
+		AcknowledgedAt: optionalTimestamp(incident.AcknowledgedAt),
+		ResolvedBy:     incident.ResolvedBy,
+		ResolvedAt:     optionalTimestamp(incident.ResolvedAt),
+		Resolution:     incident.Resolution,
+		CreatedAt:      timestamppb.New(incident.CreatedAt),
+		UpdatedAt:      timestamppb.New(incident.UpdatedAt),
+	}
+	for i := range incident.LocationTrail {
+		resp.LocationTrail = append(resp.LocationTrail, locationToProto(&incident.LocationTrail[i]))
+	}
+	for _, note := range incident.Notes {
+		resp.Notes = append(resp.Notes, &trippb.IncidentNote{
+			AuthorId:  note.AuthorID,
+			Text:      note.Text,
+			CreatedAt: timestamppb.New(note.CreatedAt),
+		})
+	}
+	return resp
+}
+
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
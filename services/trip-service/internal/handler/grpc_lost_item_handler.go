@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/rideshare-platform/services/trip-service/internal/service"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	trippb "github.com/rideshare-platform/shared/proto/trip"
+)
+
+// SetLostItems attaches the lost item service behind the lost and found RPCs
+func (h *GRPCTripHandler) SetLostItems(lostItems *service.LostItemService) {
+	h.lostItems = lostItems
+}
+
+// ReportLostItem records an item a rider left behind and notifies the driver
+func (h *GRPCTripHandler) ReportLostItem(ctx context.Context, req *trippb.ReportLostItemRequest) (*trippb.LostItemReport, error) {
+	if h.lostItems == nil {
+		return nil, status.Error(codes.Unimplemented, "lost and found is not configured")
+	}
+
+	report, err := h.lostItems.ReportLostItem(ctx, &service.ReportLostItemRequest{
+		TripID:      req.TripId,
+		RiderID:     req.RiderId,
+		Description: req.Description,
+	})
+	if err != nil {
+		return nil, lostItemError(err)
+	}
+	return lostItemToProto(report), nil
+}
+
+// GetLostItem returns a lost item report
+func (h *GRPCTripHandler) GetLostItem(ctx context.Context, req *trippb.GetLostItemRequest) (*trippb.LostItemReport, error) {
+	if h.lostItems == nil {
+		return nil, status.Error(codes.Unimplemented, "lost and found is not configured")
+	}
+
+	report, err := h.lostItems.GetLostItem(ctx, req.ReportId)
+	if err != nil {
+		return nil, lostItemError(err)
+	}
+	return lostItemToProto(report), nil
+}
+
+// ListLostItems lists lost item reports across trips, newest first
+func (h *GRPCTripHandler) ListLostItems(ctx context.Context, req *trippb.ListLostItemsRequest) (*trippb.ListLostItemsResponse, error) {
+	if h.lostItems == nil {
+		return nil, status.Error(codes.Unimplemented, "lost and found is not configured")
+	}
+
+	reports, err := h.lostItems.ListLostItems(ctx, types.LostItemFilter{
+		Status:   types.LostItemStatus(req.Status),
+		RiderID:  req.RiderId,
+		DriverID: req.DriverId,
+		TripID:   req.TripId,
+	}, int(req.Limit))
+	if err != nil {
+		return nil, lostItemError(err)
+	}
+
+	resp := &trippb.ListLostItemsResponse{}
+	for _, report := range reports {
+		resp.Reports = append(resp.Reports, lostItemToProto(report))
+	}
+	return resp, nil
+}
+
+// UpdateLostItem moves a lost item report on or adds a note to it
+func (h *GRPCTripHandler) UpdateLostItem(ctx context.Context, req *trippb.UpdateLostItemRequest) (*trippb.LostItemReport, error) {
+	if h.lostItems == nil {
+		return nil, status.Error(codes.Unimplemented, "lost and found is not configured")
+	}
+
+	report, err := h.lostItems.UpdateLostItem(ctx, req.ReportId, &service.UpdateLostItemRequest{
+		AuthorID: req.AuthorId,
+		Status:   types.LostItemStatus(req.Status),
+		Note:     req.Note,
+	})
+	if err != nil {
+		return nil, lostItemError(err)
+	}
+	return lostItemToProto(report), nil
+}
+
+// lostItemError maps lost and found errors to gRPC status codes
+func lostItemError(err error) error {
+	switch {
+	case errors.Is(err, types.ErrTripNotFound), errors.Is(err, types.ErrLostItemNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrTripNotOwned):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrLostItemReportExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, service.ErrLostItemTripNotEligible), errors.Is(err, service.ErrLostItemWindowClosed),
+		errors.Is(err, service.ErrLostItemClosed):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+}
+
+func lostItemToProto(report *types.LostItemReport) *trippb.LostItemReport {
+	resp := &trippb.LostItemReport{
+		Id:               report.ID,
+		TripId:           report.TripID,
+		RiderId:          report.RiderID,
+		DriverId:         report.DriverID,
+		Description:      report.Description,
+		Status:           string(report.Status),
+		DriverNotifiedAt: optionalTimestamp(report.DriverNotifiedAt),
+		ContactUntil:     timestamppb.New(report.ContactUntil),
+		ContactOpen:      report.ContactOpen(time.Now()),
+		CreatedAt:        timestamppb.New(report.CreatedAt),
+		UpdatedAt:        timestamppb.New(report.UpdatedAt),
+	}
+	for _, note := range report.Notes {
+		resp.Notes = append(resp.Notes, &trippb.LostItemNote{
+			AuthorId:  note.AuthorID,
+			Status:    string(note.Status),
+			Text:      note.Text,
+			CreatedAt: timestamppb.New(note.CreatedAt),
+		})
+	}
+	return resp
+}
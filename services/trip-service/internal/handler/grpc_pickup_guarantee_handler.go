@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/rideshare-platform/services/trip-service/internal/service"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/analytics"
+	trippb "github.com/rideshare-platform/shared/proto/trip"
+)
+
+// SetPickupGuarantees attaches the on-time pickup guarantee service
+func (h *GRPCTripHandler) SetPickupGuarantees(guarantees *service.PickupGuaranteeService) {
+	h.pickupGuarantees = guarantees
+}
+
+// GetPickupGuarantee returns the pickup time a trip's rider was promised and
+// whether it was kept
+func (h *GRPCTripHandler) GetPickupGuarantee(ctx context.Context, req *trippb.GetPickupGuaranteeRequest) (*trippb.PickupGuarantee, error) {
+	if h.pickupGuarantees == nil {
+		return nil, status.Error(codes.Unimplemented, "pickup guarantees are not configured")
+	}
+
+	guarantee, err := h.pickupGuarantees.GetGuarantee(ctx, req.TripId)
+	if err != nil {
+		return nil, pickupGuaranteeError(err)
+	}
+	return pickupGuaranteeToProto(guarantee), nil
+}
+
+// GetPickupSLAReport reports kept and missed guarantees by driver and area
+func (h *GRPCTripHandler) GetPickupSLAReport(ctx context.Context, req *trippb.GetPickupSLAReportRequest) (*trippb.PickupSLAReport, error) {
+	if h.pickupGuarantees == nil {
+		return nil, status.Error(codes.Unimplemented, "pickup guarantees are not configured")
+	}
+	if req.From == nil || req.To == nil {
+		return nil, status.Error(codes.InvalidArgument, "from and to are required")
+	}
+
+	report, err := h.pickupGuarantees.Report(ctx, req.From.AsTime(), req.To.AsTime(), req.CityId)
+	if err != nil {
+		return nil, pickupGuaranteeError(err)
+	}
+	return pickupSLAReportToProto(report), nil
+}
+
+// pickupGuaranteeError maps pickup guarantee errors to gRPC status codes
+func pickupGuaranteeError(err error) error {
+	switch {
+	case errors.Is(err, types.ErrPickupGuaranteeNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, analytics.ErrInvalidTimeRange):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func pickupGuaranteeToProto(guarantee *types.PickupGuarantee) *trippb.PickupGuarantee {
+	pb := &trippb.PickupGuarantee{
+		TripId:        guarantee.TripID,
+		RiderId:       guarantee.RiderID,
+		DriverId:      guarantee.DriverID,
+		CityId:        guarantee.CityID,
+		Area:          guarantee.Area,
+		Status:        string(guarantee.Status),
+		MatchedAt:     timestamppb.New(guarantee.MatchedAt),
+		PromisedAt:    timestamppb.New(guarantee.PromisedAt),
+		LateBySeconds: guarantee.LateBySeconds,
+		CreditAmount:  guarantee.CreditAmount,
+		Currency:      guarantee.Currency,
+		CreditId:      guarantee.CreditID,
+		CreditError:   guarantee.CreditError,
+	}
+	if guarantee.PickedUpAt != nil {
+		pb.PickedUpAt = timestamppb.New(*guarantee.PickedUpAt)
+	}
+	return pb
+}
+
+func pickupSLAReportToProto(report *types.PickupSLAReport) *trippb.PickupSLAReport {
+	pb := &trippb.PickupSLAReport{
+		From:              timestamppb.New(report.From),
+		To:                timestamppb.New(report.To),
+		CityId:            report.CityID,
+		Judged:            report.Judged,
+		Missed:            report.Missed,
+		MissRate:          report.MissRate,
+		CreditsByCurrency: report.CreditsByCurrency,
+		ByDriver:          make([]*trippb.PickupSLAGroup, 0, len(report.ByDriver)),
+		ByArea:            make([]*trippb.PickupSLAGroup, 0, len(report.ByArea)),
+	}
+	for _, group := range report.ByDriver {
+		pb.ByDriver = append(pb.ByDriver, pickupSLAGroupToProto(group))
+	}
+	for _, group := range report.ByArea {
+		pb.ByArea = append(pb.ByArea, pickupSLAGroupToProto(group))
+	}
+	return pb
+}
+
+func pickupSLAGroupToProto(group *types.PickupSLAGroup) *trippb.PickupSLAGroup {
+	return &trippb.PickupSLAGroup{
+		Key:                group.Key,
+		Judged:             group.Judged,
+		Missed:             group.Missed,
+		MissRate:           group.MissRate,
+		AverageLateSeconds: group.AverageLateSeconds,
+	}
+}
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/rideshare-platform/services/trip-service/internal/service"
+	"github.com/rideshare-platform/shared/grpc/interceptor"
+	trippb "github.com/rideshare-platform/shared/proto/trip"
+)
+
+// SetProjector attaches the projector of the trip listing read model, which
+// operators follow and rebuild through the gateway's admin API. Both calls
+// require an operator token.
+func (h *GRPCTripHandler) SetProjector(projector *service.TripProjector) {
+	h.projector = projector
+}
+
+// GetTripListingsLag returns how far the trip listings are behind the trip
+// event log
+func (h *GRPCTripHandler) GetTripListingsLag(ctx context.Context, req *trippb.GetTripListingsLagRequest) (*trippb.TripListingsLag, error) {
+	if err := interceptor.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if h.projector == nil {
+		return nil, status.Error(codes.Unimplemented, "trip listings are not configured")
+	}
+
+	lag, err := h.projector.Lag(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &trippb.TripListingsLag{
+		Checkpoint:     lag.Checkpoint,
+		LatestPosition: lag.LatestPosition,
+		EventsBehind:   lag.EventsBehind,
+		LagSeconds:     lag.LagSeconds,
+		ProjectedAt:    optionalTimestamp(lag.ProjectedAt),
+		RebuiltAt:      optionalTimestamp(lag.RebuiltAt),
+	}, nil
+}
+
+// RebuildTripListings drops the trip listings and projects the whole event
+// log again
+func (h *GRPCTripHandler) RebuildTripListings(ctx context.Context, req *trippb.RebuildTripListingsRequest) (*trippb.RebuildTripListingsResponse, error) {
+	if err := interceptor.RequireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if h.projector == nil {
+		return nil, status.Error(codes.Unimplemented, "trip listings are not configured")
+	}
+
+	projected, err := h.projector.Rebuild(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &trippb.RebuildTripListingsResponse{Projected: int32(projected)}, nil
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/rideshare-platform/services/trip-service/internal/service"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	trippb "github.com/rideshare-platform/shared/proto/trip"
+)
+
+// SubmitRating saves a rider's or driver's review of a completed trip
+func (h *GRPCTripHandler) SubmitRating(ctx context.Context, req *trippb.SubmitRatingRequest) (*trippb.SubmitRatingResponse, error) {
+	rating, summary, err := h.ratings.SubmitRating(ctx, &service.SubmitRatingRequest{
+		TripID:  req.TripId,
+		RaterID: req.RaterId,
+		Score:   int(req.Score),
+		Comment: req.Comment,
+		Tags:    req.Tags,
+	})
+	if err != nil {
+		return nil, ratingError(err)
+	}
+
+	return &trippb.SubmitRatingResponse{
+		Rating:       ratingToProto(rating),
+		RateeSummary: ratingSummaryToProto(summary),
+	}, nil
+}
+
+// GetRatingSummary returns a user's rolling average rating in a role
+func (h *GRPCTripHandler) GetRatingSummary(ctx context.Context, req *trippb.GetRatingSummaryRequest) (*trippb.RatingSummary, error) {
+	summary, err := h.ratings.GetRatingSummary(ctx, req.UserId, types.RaterRole(req.Role))
+	if err != nil {
+		return nil, ratingError(err)
+	}
+	return ratingSummaryToProto(summary), nil
+}
+
+// ListReviews returns the most recent reviews a user received, newest first
+func (h *GRPCTripHandler) ListReviews(ctx context.Context, req *trippb.ListReviewsRequest) (*trippb.ListReviewsResponse, error) {
+	reviews, err := h.ratings.ListReviews(ctx, req.UserId, types.RaterRole(req.Role), int(req.Limit))
+	if err != nil {
+		return nil, ratingError(err)
+	}
+
+	resp := &trippb.ListReviewsResponse{}
+	for _, review := range reviews {
+		resp.Reviews = append(resp.Reviews, ratingToProto(review))
+	}
+	return resp, nil
+}
+
+// GetDriverRatings returns the rating summaries of a batch of drivers for matching
+func (h *GRPCTripHandler) GetDriverRatings(ctx context.Context, req *trippb.GetDriverRatingsRequest) (*trippb.GetDriverRatingsResponse, error) {
+	summaries, err := h.ratings.GetDriverRatings(ctx, req.DriverIds)
+	if err != nil {
+		return nil, ratingError(err)
+	}
+
+	resp := &trippb.GetDriverRatingsResponse{Ratings: make(map[string]*trippb.RatingSummary, len(summaries))}
+	for driverID, summary := range summaries {
+		resp.Ratings[driverID] = ratingSummaryToProto(summary)
+	}
+	return resp, nil
+}
+
+func ratingError(err error) error {
+	switch {
+	case errors.Is(err, types.ErrRatingSummaryNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrNotTripParticipant):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, types.ErrDuplicateRating):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, service.ErrTripNotRatable), errors.Is(err, service.ErrRatingWindowClosed):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+}
+
+func ratingToProto(rating *types.Rating) *trippb.Rating {
+	return &trippb.Rating{
+		Id:        rating.ID,
+		TripId:    rating.TripID,
+		RaterId:   rating.RaterID,
+		RateeId:   rating.RateeID,
+		RaterRole: string(rating.RaterRole),
+		Score:     int32(rating.Score),
+		Comment:   rating.Comment,
+		Tags:      rating.Tags,
+		CreatedAt: timestamppb.New(rating.CreatedAt),
+	}
+}
+
+func ratingSummaryToProto(summary *types.RatingSummary) *trippb.RatingSummary {
+	return &trippb.RatingSummary{
+		UserId:        summary.UserID,
+		Role:          string(summary.Role),
+		AverageRating: summary.Average,
+		RatingCount:   int32(summary.RatingCount),
+		UpdatedAt:     timestamppb.New(summary.UpdatedAt),
+	}
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/rideshare-platform/services/trip-service/internal/service"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	trippb "github.com/rideshare-platform/shared/proto/trip"
+)
+
+// CreateScheduledRide books a ride for a future pickup time
+func (h *GRPCTripHandler) CreateScheduledRide(ctx context.Context, req *trippb.CreateScheduledRideRequest) (*trippb.ScheduledRideResponse, error) {
+	if req.ScheduledFor == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Scheduled time is required")
+	}
+
+	ride, err := h.scheduledRides.CreateScheduledRide(ctx, &service.CreateScheduledRideRequest{
+		RiderID:        req.RiderId,
+		PickupLocation: locationFromProto(req.PickupLocation),
+		Destination:    locationFromProto(req.Destination),
+		VehicleType:    req.VehicleType,
+		PaymentMethod:  req.PaymentMethodId,
+		PassengerCount: int(req.PassengerCount),
+		ScheduledFor:   req.ScheduledFor.AsTime(),
+	})
+	if err != nil {
+		return nil, scheduledRideError(err)
+	}
+
+	return &trippb.ScheduledRideResponse{
+		ScheduledRide: scheduledRideToProto(ride),
+		Success:       true,
+		Message:       "Ride scheduled",
+	}, nil
+}
+
+// ListScheduledRides returns a rider's scheduled rides ordered by pickup time
+func (h *GRPCTripHandler) ListScheduledRides(ctx context.Context, req *trippb.ListScheduledRidesRequest) (*trippb.ListScheduledRidesResponse, error) {
+	rides, err := h.scheduledRides.ListScheduledRides(ctx, req.RiderId, req.IncludeClosed)
+	if err != nil {
+		return nil, scheduledRideError(err)
+	}
+
+	resp := &trippb.ListScheduledRidesResponse{}
+	for _, ride := range rides {
+		resp.ScheduledRides = append(resp.ScheduledRides, scheduledRideToProto(ride))
+	}
+	return resp, nil
+}
+
+// CancelScheduledRide cancels a scheduled ride before it is dispatched
+func (h *GRPCTripHandler) CancelScheduledRide(ctx context.Context, req *trippb.CancelScheduledRideRequest) (*trippb.ScheduledRideResponse, error) {
+	if req.ScheduledRideId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Scheduled ride ID is required")
+	}
+
+	ride, err := h.scheduledRides.CancelScheduledRide(ctx, req.ScheduledRideId, req.RiderId, req.Reason)
+	if err != nil {
+		return nil, scheduledRideError(err)
+	}
+
+	return &trippb.ScheduledRideResponse{
+		ScheduledRide: scheduledRideToProto(ride),
+		Success:       true,
+		Message:       "Scheduled ride cancelled",
+	}, nil
+}
+
+// scheduledRideError maps scheduled ride errors to gRPC status codes
+func scheduledRideError(err error) error {
+	switch {
+	case errors.Is(err, types.ErrScheduledRideNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrScheduledRideNotOwned):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrScheduledRideNotCancellable):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+}
+
+func scheduledRideToProto(ride *types.ScheduledRide) *trippb.ScheduledRide {
+	return &trippb.ScheduledRide{
+		Id:                 ride.ID,
+		RiderId:            ride.RiderID,
+		PickupLocation:     locationToProto(ride.PickupLocation),
+		Destination:        locationToProto(ride.Destination),
+		VehicleType:        ride.VehicleType,
+		PaymentMethodId:    ride.PaymentMethod,
+		PassengerCount:     int32(ride.PassengerCount),
+		Status:             string(ride.Status),
+		ScheduledFor:       timestamppb.New(ride.ScheduledFor),
+		DispatchAt:         timestamppb.New(ride.DispatchAt),
+		DispatchAttempts:   int32(ride.DispatchAttempts),
+		DriverId:           ride.DriverID,
+		LastError:          ride.LastError,
+		CancellationReason: ride.CancellationReason,
+		CreatedAt:          timestamppb.New(ride.CreatedAt),
+		UpdatedAt:          timestamppb.New(ride.UpdatedAt),
+	}
+}
@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/rideshare-platform/services/trip-service/internal/service"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+	trippb "github.com/rideshare-platform/shared/proto/trip"
+)
+
+// OpenSharedTrip starts a shared trip around a driver's first rider
+func (h *GRPCTripHandler) OpenSharedTrip(ctx context.Context, req *trippb.OpenSharedTripRequest) (*trippb.SharedTripResponse, error) {
+	trip, err := h.sharedTrips.OpenSharedTrip(ctx, &service.OpenSharedTripRequest{
+		DriverID:        req.DriverId,
+		VehicleType:     req.VehicleType,
+		SeatCapacity:    int(req.SeatCapacity),
+		Rider:           sharedRiderFromProto(req.Rider),
+		CurrentLocation: locationFromProto(req.CurrentLocation),
+	})
+	if err != nil {
+		return nil, sharedTripError(err)
+	}
+
+	return &trippb.SharedTripResponse{
+		SharedTrip: sharedTripToProto(trip),
+		Success:    true,
+		Message:    "Shared trip opened",
+	}, nil
+}
+
+// AddSharedRider inserts a rider's stops into a shared trip
+func (h *GRPCTripHandler) AddSharedRider(ctx context.Context, req *trippb.AddSharedRiderRequest) (*trippb.SharedTripResponse, error) {
+	if req.SharedTripId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Shared trip ID is required")
+	}
+
+	trip, err := h.sharedTrips.AddRider(ctx, req.SharedTripId, int(req.ExpectedVersion), sharedRiderFromProto(req.Rider), int(req.PickupIndex), int(req.DropoffIndex))
+	if err != nil {
+		return nil, sharedTripError(err)
+	}
+
+	return &trippb.SharedTripResponse{
+		SharedTrip: sharedTripToProto(trip),
+		Success:    true,
+		Message:    "Rider added to shared trip",
+	}, nil
+}
+
+// CompleteTripStop marks the next stop of a shared trip as visited
+func (h *GRPCTripHandler) CompleteTripStop(ctx context.Context, req *trippb.CompleteTripStopRequest) (*trippb.SharedTripResponse, error) {
+	if req.SharedTripId == "" || req.StopId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Shared trip ID and stop ID are required")
+	}
+
+	trip, err := h.sharedTrips.CompleteStop(ctx, req.SharedTripId, req.StopId)
+	if err != nil {
+		return nil, sharedTripError(err)
+	}
+	h.openDroppedOffRiderForRating(ctx, trip, req.StopId)
+
+	return &trippb.SharedTripResponse{
+		SharedTrip: sharedTripToProto(trip),
+		Success:    true,
+		Message:    "Stop completed",
+	}, nil
+}
+
+// GetSharedTrip returns a shared trip with its stops and riders
+func (h *GRPCTripHandler) GetSharedTrip(ctx context.Context, req *trippb.GetSharedTripRequest) (*trippb.SharedTripResponse, error) {
+	trip, err := h.sharedTrips.GetSharedTrip(ctx, req.SharedTripId)
+	if err != nil {
+		return nil, sharedTripError(err)
+	}
+
+	return &trippb.SharedTripResponse{
+		SharedTrip: sharedTripToProto(trip),
+		Success:    true,
+	}, nil
+}
+
+// ListOpenSharedTrips returns active shared trips that can take more riders
+func (h *GRPCTripHandler) ListOpenSharedTrips(ctx context.Context, req *trippb.ListOpenSharedTripsRequest) (*trippb.ListOpenSharedTripsResponse, error) {
+	trips, err := h.sharedTrips.ListOpenSharedTrips(ctx, req.VehicleType, int(req.SeatsNeeded))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to list shared trips: %v", err)
+	}
+
+	response := &trippb.ListOpenSharedTripsResponse{}
+	for _, trip := range trips {
+		response.SharedTrips = append(response.SharedTrips, sharedTripToProto(trip))
+	}
+	return response, nil
+}
+
+// sharedTripError maps shared trip errors to gRPC status codes
+func sharedTripError(err error) error {
+	switch {
+	case errors.Is(err, types.ErrSharedTripNotFound), errors.Is(err, service.ErrStopNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrSharedTripVersionConflict):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, service.ErrSharedTripClosed), errors.Is(err, service.ErrSharedTripCapacity),
+		errors.Is(err, service.ErrStopOutOfOrder):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+}
+
+func sharedRiderFromProto(rider *trippb.SharedRider) *types.SharedRider {
+	if rider == nil {
+		return nil
+	}
+	return &types.SharedRider{
+		TripID:           rider.TripId,
+		RiderID:          rider.RiderId,
+		PickupLocation:   locationFromProto(rider.PickupLocation),
+		Destination:      locationFromProto(rider.Destination),
+		PassengerCount:   int(rider.PassengerCount),
+		MaxDetourMinutes: int(rider.MaxDetourMinutes),
+		Fare:             rider.Fare,
+	}
+}
+
+func sharedTripToProto(trip *types.SharedTrip) *trippb.SharedTrip {
+	protoTrip := &trippb.SharedTrip{
+		Id:              trip.ID,
+		DriverId:        trip.DriverID,
+		VehicleType:     trip.VehicleType,
+		SeatCapacity:    int32(trip.SeatCapacity),
+		SeatsOccupied:   int32(trip.SeatsOccupied),
+		Status:          string(trip.Status),
+		CurrentLocation: locationToProto(trip.CurrentLocation),
+		Version:         int32(trip.Version),
+		CreatedAt:       timestamppb.New(trip.CreatedAt),
+		UpdatedAt:       timestamppb.New(trip.UpdatedAt),
+	}
+
+	for _, stop := range trip.Stops {
+		protoStop := &trippb.TripStop{
+			Id:       stop.ID,
+			TripId:   stop.TripID,
+			RiderId:  stop.RiderID,
+			Type:     string(stop.Type),
+			Location: locationToProto(stop.Location),
+			Status:   string(stop.Status),
+		}
+		if stop.CompletedAt != nil {
+			protoStop.CompletedAt = timestamppb.New(*stop.CompletedAt)
+		}
+		protoTrip.Stops = append(protoTrip.Stops, protoStop)
+	}
+
+	for _, rider := range trip.Riders {
+		protoTrip.Riders = append(protoTrip.Riders, &trippb.SharedRider{
+			TripId:           rider.TripID,
+			RiderId:          rider.RiderID,
+			PickupLocation:   locationToProto(rider.PickupLocation),
+			Destination:      locationToProto(rider.Destination),
+			PassengerCount:   int32(rider.PassengerCount),
+			MaxDetourMinutes: int32(rider.MaxDetourMinutes),
+			Fare:             rider.Fare,
+		})
+	}
+
+	return protoTrip
+}
+
+func locationFromProto(location *trippb.Location) *models.Location {
+	if location == nil {
+		return nil
+	}
+	return &models.Location{
+		Latitude:  location.Latitude,
+		Longitude: location.Longitude,
+	}
+}
+
+func locationToProto(location *models.Location) *trippb.Location {
+	if location == nil {
+		return nil
+	}
+	return &trippb.Location{
+		Latitude:  location.Latitude,
+		Longitude: location.Longitude,
+	}
+}
+
+// openDroppedOffRiderForRating lets a pooled rider and the driver rate each
+// other once the rider has been dropped off
+func (h *GRPCTripHandler) openDroppedOffRiderForRating(ctx context.Context, trip *types.SharedTrip, stopID string) {
+	for _, stop := range trip.Stops {
+		if stop.ID != stopID || stop.Type != types.StopTypeDropoff || stop.CompletedAt == nil {
+			continue
+		}
+		if err := h.ratings.RecordCompletedTrip(ctx, stop.TripID, stop.RiderID, trip.DriverID, *stop.CompletedAt); err != nil {
+			h.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+				"trip_id": stop.TripID,
+			}).Warn("Failed to open trip for rating")
+		}
+		return
+	}
+}
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/rideshare-platform/services/trip-service/internal/service"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	trippb "github.com/rideshare-platform/shared/proto/trip"
+)
+
+// SetChat attaches the chat service behind the in-trip messaging RPCs
+func (h *GRPCTripHandler) SetChat(chat *service.ChatService) {
+	h.chat = chat
+}
+
+// SendTripMessage sends a message to the other participant of an active trip
+func (h *GRPCTripHandler) SendTripMessage(ctx context.Context, req *trippb.SendTripMessageRequest) (*trippb.TripMessage, error) {
+	if h.chat == nil {
+		return nil, status.Error(codes.Unimplemented, "in-trip messaging is not configured")
+	}
+
+	message, err := h.chat.SendMessage(ctx, &service.SendTripMessageRequest{
+		TripID:       req.TripId,
+		SenderID:     req.SenderId,
+		Text:         req.Text,
+		QuickReplyID: req.QuickReplyId,
+	})
+	if err != nil {
+		return nil, tripChatError(err)
+	}
+	return tripMessageToProto(message), nil
+}
+
+// ListTripMessages returns a trip's conversation, oldest first
+func (h *GRPCTripHandler) ListTripMessages(ctx context.Context, req *trippb.ListTripMessagesRequest) (*trippb.ListTripMessagesResponse, error) {
+	if h.chat == nil {
+		return nil, status.Error(codes.Unimplemented, "in-trip messaging is not configured")
+	}
+
+	messages, err := h.chat.ListMessages(ctx, req.TripId, req.UserId)
+	if err != nil {
+		return nil, tripChatError(err)
+	}
+
+	resp := &trippb.ListTripMessagesResponse{}
+	for _, message := range messages {
+		resp.Messages = append(resp.Messages, tripMessageToProto(message))
+	}
+	return resp, nil
+}
+
+// StreamTripMessages streams the messages sent to a participant, starting
+// with those sent while they were not connected
+func (h *GRPCTripHandler) StreamTripMessages(req *trippb.StreamTripMessagesRequest, stream trippb.TripService_StreamTripMessagesServer) error {
+	if h.chat == nil {
+		return status.Error(codes.Unimplemented, "in-trip messaging is not configured")
+	}
+
+	ctx := stream.Context()
+	subscription, err := h.chat.Subscribe(ctx, req.TripId, req.UserId)
+	if err != nil {
+		return tripChatError(err)
+	}
+	defer subscription.Close()
+
+	for _, message := range subscription.Pending {
+		if err := stream.Send(tripMessageToProto(message)); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case message := <-subscription.Messages:
+			if err := stream.Send(tripMessageToProto(message)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// ListQuickReplies returns the canned messages a rider or driver can send
+func (h *GRPCTripHandler) ListQuickReplies(ctx context.Context, req *trippb.ListQuickRepliesRequest) (*trippb.ListQuickRepliesResponse, error) {
+	if req.Role != "rider" && req.Role != "driver" {
+		return nil, status.Error(codes.InvalidArgument, "role must be rider or driver")
+	}
+
+	resp := &trippb.ListQuickRepliesResponse{}
+	for _, reply := range service.QuickReplies(req.Role) {
+		resp.QuickReplies = append(resp.QuickReplies, &trippb.QuickReply{Id: reply.ID, Text: reply.Text})
+	}
+	return resp, nil
+}
+
+func tripChatError(err error) error {
+	switch {
+	case errors.Is(err, types.ErrTripNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrNotChatParticipant):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrChatClosed):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+}
+
+func tripMessageToProto(message *types.TripMessage) *trippb.TripMessage {
+	return &trippb.TripMessage{
+		Id:           message.ID,
+		TripId:       message.TripID,
+		SenderId:     message.SenderID,
+		SenderRole:   message.SenderRole,
+		RecipientId:  message.RecipientID,
+		Text:         message.Text,
+		QuickReplyId: message.QuickReplyID,
+		CreatedAt:    timestamppb.New(message.CreatedAt),
+		DeliveredAt:  optionalTimestamp(message.DeliveredAt),
+	}
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/rideshare-platform/services/trip-service/internal/service"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/contactproxy"
+	trippb "github.com/rideshare-platform/shared/proto/trip"
+)
+
+// SetContacts attaches the trip contact service behind masked calling
+func (h *GRPCTripHandler) SetContacts(contacts *service.TripContactService) {
+	h.contacts = contacts
+}
+
+// GetTripContact returns how the caller reaches the other party of their trip
+func (h *GRPCTripHandler) GetTripContact(ctx context.Context, req *trippb.GetTripContactRequest) (*trippb.TripContact, error) {
+	if h.contacts == nil {
+		return nil, status.Error(codes.Unimplemented, "masked calling is not configured")
+	}
+
+	contact, err := h.contacts.ContactHandle(ctx, req.TripId, req.UserId)
+	if err != nil {
+		return nil, tripContactError(err)
+	}
+	return tripContactToProto(contact), nil
+}
+
+// tripContactError maps masked calling errors to gRPC status codes
+func tripContactError(err error) error {
+	switch {
+	case errors.Is(err, types.ErrTripNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrNotTripMember):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrContactClosed):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, contactproxy.ErrNumbersExhausted), errors.Is(err, contactproxy.ErrModeUnsupported):
+		return status.Error(codes.Unavailable, err.Error())
+	default:
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+}
+
+func tripContactToProto(contact *service.TripContact) *trippb.TripContact {
+	return &trippb.TripContact{
+		TripId:       contact.TripID,
+		Mode:         string(contact.Mode),
+		MaskedNumber: contact.Handle.MaskedNumber,
+		VoipToken:    contact.Handle.VoIPToken,
+		ExpiresAt:    timestamppb.New(contact.ExpiresAt),
+	}
+}
@@ -2,35 +2,88 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"sync"
 	"time"
 
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/rideshare-platform/services/trip-service/internal/service"
+	"github.com/rideshare-platform/shared/events"
+	"github.com/rideshare-platform/shared/experiments"
 	"github.com/rideshare-platform/shared/logger"
 	trippb "github.com/rideshare-platform/shared/proto/trip"
 )
 
+// Metadata keys of idempotent trip requests, the gRPC counterparts of the
+// Idempotency-Key and Idempotent-Replayed headers
+const (
+	idempotencyKeyMetadata     = "idempotency-key"
+	idempotentReplayedMetadata = "idempotent-replayed"
+)
+
 // GRPCTripHandler handles gRPC requests for trip service
 type GRPCTripHandler struct {
 	trippb.UnimplementedTripServiceServer
-	tripService service.BasicTripService
-	logger      *logger.Logger
+	tripService      service.BasicTripService
+	sharedTrips      *service.SharedTripService
+	scheduledRides   *service.ScheduledRideService
+	ratings          *service.RatingService
+	receipts         *service.ReceiptService
+	trips            *service.TripService
+	emergencies      *service.EmergencyService
+	disputes         *service.DisputeService
+	lostItems        *service.LostItemService
+	contacts         *service.TripContactService
+	pickupGuarantees *service.PickupGuaranteeService
+	reliability      *service.DriverReliabilityService
+	compliance       *service.ComplianceService
+	deadLetters      *events.DeadLetterQueue
+	projector        *service.TripProjector
+	chat             *service.ChatService
+	driverEvents     *service.DriverEventHub
+	events           *events.EventPublisher
+	experiments      *experiments.Registry
+	logger           *logger.Logger
 
 	// Subscription management
 	subscriptions map[string][]chan *trippb.TripUpdateEvent
 	subMutex      sync.RWMutex
 }
 
-func NewGRPCTripHandler(tripService service.BasicTripService, logger *logger.Logger) *GRPCTripHandler {
+func NewGRPCTripHandler(tripService service.BasicTripService, sharedTrips *service.SharedTripService, scheduledRides *service.ScheduledRideService, ratings *service.RatingService, receipts *service.ReceiptService, logger *logger.Logger) *GRPCTripHandler {
 	return &GRPCTripHandler{
-		tripService:   tripService,
-		logger:        logger,
-		subscriptions: make(map[string][]chan *trippb.TripUpdateEvent),
+		tripService:    tripService,
+		sharedTrips:    sharedTrips,
+		scheduledRides: scheduledRides,
+		ratings:        ratings,
+		receipts:       receipts,
+		logger:         logger,
+		subscriptions:  make(map[string][]chan *trippb.TripUpdateEvent),
+	}
+}
+
+// SetEventPublisher attaches the publisher used to announce trip status changes
+func (h *GRPCTripHandler) SetEventPublisher(publisher *events.EventPublisher) {
+	h.events = publisher
+}
+
+// SetExperiments attaches the experiments trip events record the variants of
+func (h *GRPCTripHandler) SetExperiments(registry *experiments.Registry) {
+	h.experiments = registry
+}
+
+// tagExperiments records the experiment variants the trip is in on its event,
+// so the events can be compared by variant
+func (h *GRPCTripHandler) tagExperiments(ctx context.Context, event *events.Event, tripID, riderID string) {
+	assignments := h.experiments.Assignments(ctx, experiments.Units{RiderID: riderID, TripID: tripID})
+	if len(assignments) > 0 {
+		event.AddMetadata(events.ExperimentsMetadataKey, assignments)
 	}
 }
 
@@ -171,6 +224,57 @@ func (h *GRPCTripHandler) NotifyTripUpdate(tripID string, oldStatus, newStatus t
 	}
 }
 
+// CreateTrip requests a trip for a rider. Requests retried with the same
+// idempotency-key metadata return the trip the first one created, with the
+// idempotent-replayed header set.
+func (h *GRPCTripHandler) CreateTrip(ctx context.Context, req *trippb.CreateTripRequest) (*trippb.CreateTripResponse, error) {
+	if h.trips == nil {
+		return nil, status.Error(codes.Unimplemented, "trip creation is not configured")
+	}
+	if req.RiderId == "" || req.PickupLocation == nil || req.Destination == nil {
+		return nil, status.Error(codes.InvalidArgument, "rider ID, pickup location and destination are required")
+	}
+
+	create := &service.CreateTripRequest{
+		RiderID:             req.RiderId,
+		PickupLocation:      *locationFromProto(req.PickupLocation),
+		DestinationLocation: *locationFromProto(req.Destination),
+		RideType:            req.VehicleType,
+		CityID:              req.CityId,
+		AccessibilityNeeds:  req.AccessibilityNeeds,
+	}
+	if req.Metadata != nil {
+		create.SurgeMultiplier = req.Metadata.SurgeMultiplier
+	}
+	if req.ScheduledFor != nil {
+		scheduledFor := req.ScheduledFor.AsTime()
+		create.ScheduledFor = &scheduledFor
+	}
+
+	var key string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(idempotencyKeyMetadata); len(values) > 0 {
+			key = values[0]
+		}
+	}
+	trip, replayed, err := h.trips.CreateTripIdempotent(ctx, key, create)
+	switch {
+	case errors.Is(err, service.ErrIdempotencyKeyReused):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, service.ErrIdempotentRequestInProgress):
+		return nil, status.Error(codes.Aborted, err.Error())
+	case err != nil:
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if replayed {
+		grpc.SetHeader(ctx, metadata.Pairs(idempotentReplayedMetadata, "true"))
+	}
+	return &trippb.CreateTripResponse{
+		Trip:    tripToProto(trip),
+		Success: true,
+	}, nil
+}
+
 // GetTrip implements gRPC method for getting trip details
 func (h *GRPCTripHandler) GetTrip(ctx context.Context, req *trippb.GetTripRequest) (*trippb.GetTripResponse, error) {
 	trip, err := h.tripService.GetTrip(ctx, req.TripId)
@@ -217,6 +321,26 @@ func (h *GRPCTripHandler) UpdateTripStatus(ctx context.Context, req *trippb.Upda
 	}
 
 	h.NotifyTripUpdate(req.TripId, oldStatus, newStatus, metadata)
+	h.publishStatusEvent(ctx, trip, req)
+
+	// Completed trips are opened for rating by both sides and get a receipt
+	if newStatus == trippb.TripStatus_COMPLETED {
+		driverID := trip.DriverID
+		if driverID == "" {
+			driverID = req.DriverId
+		}
+		completedAt := time.Now()
+		if err := h.ratings.RecordCompletedTrip(ctx, trip.ID, trip.RiderID, driverID, completedAt); err != nil {
+			h.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+				"trip_id": trip.ID,
+			}).Warn("Failed to open trip for rating")
+		}
+		if _, err := h.receipts.GenerateReceipt(ctx, completedTripDetails(trip, driverID, req.Completion, completedAt)); err != nil {
+			h.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+				"trip_id": trip.ID,
+			}).Warn("Failed to generate trip receipt")
+		}
+	}
 
 	// Update the trip (this would typically call a proper update method)
 	// For now, we'll just return success
@@ -229,6 +353,58 @@ func (h *GRPCTripHandler) UpdateTripStatus(ctx context.Context, req *trippb.Upda
 	}, nil
 }
 
+// publishStatusEvent announces status changes other services and notifications react to
+func (h *GRPCTripHandler) publishStatusEvent(ctx context.Context, trip *service.BasicTrip, req *trippb.UpdateTripStatusRequest) {
+	if h.events == nil {
+		return
+	}
+
+	var eventType events.EventType
+	switch req.Status {
+	case trippb.TripStatus_MATCHED:
+		eventType = events.TripMatchedEvent
+	case trippb.TripStatus_DRIVER_ARRIVED:
+		eventType = events.TripDriverArrivedEvent
+	case trippb.TripStatus_TRIP_STARTED:
+		eventType = events.TripStartedEvent
+	case trippb.TripStatus_COMPLETED:
+		eventType = events.TripCompletedEvent
+	case trippb.TripStatus_CANCELLED_BY_RIDER, trippb.TripStatus_CANCELLED_BY_DRIVER:
+		eventType = events.TripCancelledEvent
+	default:
+		return
+	}
+
+	driverID := trip.DriverID
+	if driverID == "" {
+		driverID = req.DriverId
+	}
+	data := map[string]interface{}{
+		"trip_id":   trip.ID,
+		"rider_id":  trip.RiderID,
+		"driver_id": driverID,
+	}
+	if eventType == events.TripCancelledEvent {
+		data["cancelled_by"] = "rider"
+		if req.Status == trippb.TripStatus_CANCELLED_BY_DRIVER {
+			data["cancelled_by"] = "driver"
+		}
+		data["reason"] = req.Reason
+	}
+	if eventType == events.TripMatchedEvent && req.PickupEtaSeconds > 0 {
+		// Numbers read back from the bus as float64 wherever it is JSON-backed
+		data["pickup_eta_seconds"] = float64(req.PickupEtaSeconds)
+	}
+	event := events.NewEvent(eventType, trip.ID, 1, data, "trip-service")
+	h.tagExperiments(ctx, event, trip.ID, trip.RiderID)
+	if err := h.events.PublishEvent(ctx, event); err != nil {
+		h.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+			"trip_id":    trip.ID,
+			"event_type": eventType,
+		}).Warn("Failed to publish trip status event")
+	}
+}
+
 // GetSubscriptionStats returns statistics about active subscriptions
 func (h *GRPCTripHandler) GetSubscriptionStats() map[string]int {
 	h.subMutex.RLock()
@@ -267,6 +443,40 @@ func convertToProtoStatus(status string) trippb.TripStatus {
 	}
 }
 
+// completedTripDetails combines the stored trip with the metrics the driver's
+// app reported on completion
+func completedTripDetails(trip *service.BasicTrip, driverID string, completion *trippb.TripCompletion, completedAt time.Time) *service.CompletedTripDetails {
+	details := &service.CompletedTripDetails{
+		TripID:      trip.ID,
+		RiderID:     trip.RiderID,
+		DriverID:    driverID,
+		VehicleType: trip.RideType,
+		CompletedAt: completedAt,
+	}
+	if completion == nil {
+		return details
+	}
+
+	details.VehicleID = completion.VehicleId
+	if completion.VehicleType != "" {
+		details.VehicleType = completion.VehicleType
+	}
+	details.PickupArea = completion.PickupArea
+	details.PickupLocation = locationFromProto(completion.PickupLocation)
+	details.Destination = locationFromProto(completion.Destination)
+	details.DistanceKm = completion.DistanceKm
+	details.DurationMinutes = int(completion.DurationMinutes)
+	details.WaitingSeconds = int(completion.WaitingTimeSeconds)
+	details.SurgeMultiplier = completion.SurgeMultiplier
+	details.City = completion.City
+	details.Currency = completion.Currency
+	if completion.StartedAt != nil {
+		startedAt := completion.StartedAt.AsTime()
+		details.StartedAt = &startedAt
+	}
+	return details
+}
+
 // Helper function to convert internal trip to proto trip
 func convertToProtoTrip(trip *service.BasicTrip) *trippb.Trip {
 	return &trippb.Trip{
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/rideshare-platform/services/trip-service/internal/service"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/models"
+	trippb "github.com/rideshare-platform/shared/proto/trip"
+)
+
+// shareWatchInterval is how often a watched shared trip is checked for changes
+const shareWatchInterval = 2 * time.Second
+
+// CreateTripShareLink signs a link riders hand out so others can follow
+// their trip without an account
+func (h *GRPCTripHandler) CreateTripShareLink(ctx context.Context, req *trippb.CreateTripShareLinkRequest) (*trippb.CreateTripShareLinkResponse, error) {
+	if h.trips == nil {
+		return nil, status.Error(codes.Unimplemented, "trip sharing is not configured")
+	}
+
+	link, err := h.trips.CreateShareLink(ctx, req.TripId, req.RiderId, time.Duration(req.TtlMinutes)*time.Minute)
+	if err != nil {
+		return nil, tripShareError(err)
+	}
+	return &trippb.CreateTripShareLinkResponse{
+		Token:     link.Token,
+		ExpiresAt: timestamppb.New(link.ExpiresAt),
+	}, nil
+}
+
+// GetTripByShareToken returns what a share link reveals of its trip
+func (h *GRPCTripHandler) GetTripByShareToken(ctx context.Context, req *trippb.GetTripByShareTokenRequest) (*trippb.SharedTripStatus, error) {
+	if h.trips == nil {
+		return nil, status.Error(codes.Unimplemented, "trip sharing is not configured")
+	}
+
+	view, err := h.trips.GetSharedTrip(ctx, req.Token)
+	if err != nil {
+		return nil, tripShareError(err)
+	}
+	return sharedTripStatusToProto(view), nil
+}
+
+// WatchTripByShareToken streams a shared trip's status whenever it changes,
+// until the trip ends or the link expires
+func (h *GRPCTripHandler) WatchTripByShareToken(req *trippb.GetTripByShareTokenRequest, stream trippb.TripService_WatchTripByShareTokenServer) error {
+	if h.trips == nil {
+		return status.Error(codes.Unimplemented, "trip sharing is not configured")
+	}
+
+	ctx := stream.Context()
+	view, err := h.trips.GetSharedTrip(ctx, req.Token)
+	if err != nil {
+		return tripShareError(err)
+	}
+
+	ticker := time.NewTicker(shareWatchInterval)
+	defer ticker.Stop()
+
+	var lastUpdate time.Time
+	for {
+		if !view.UpdatedAt.Equal(lastUpdate) {
+			if err := stream.Send(sharedTripStatusToProto(view)); err != nil {
+				return err
+			}
+			lastUpdate = view.UpdatedAt
+		}
+		if view.Ended {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		view, err = h.trips.GetSharedTrip(ctx, req.Token)
+		if errors.Is(err, service.ErrInvalidShareToken) {
+			// The link expired while it was being watched
+			return nil
+		}
+		if err != nil {
+			return tripShareError(err)
+		}
+	}
+}
+
+// tripShareError maps trip share link errors to gRPC status codes
+func tripShareError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrTripSharingDisabled):
+		return status.Error(codes.Unimplemented, err.Error())
+	case errors.Is(err, types.ErrTripNotFound), errors.Is(err, service.ErrInvalidShareToken):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrTripNotOwned):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrTripNotShareable):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+}
+
+func sharedTripStatusToProto(view *service.SharedTripView) *trippb.SharedTripStatus {
+	return &trippb.SharedTripStatus{
+		TripId:         view.TripID,
+		Status:         tripStatusToProto(&models.Trip{Status: view.Status, CancelledBy: view.CancelledBy}),
+		PickupLocation: locationToProto(&view.PickupLocation),
+		Destination:    locationToProto(&view.Destination),
+		DriverLocation: locationToProto(view.DriverLocation),
+		UpdatedAt:      timestamppb.New(view.UpdatedAt),
+		ExpiresAt:      timestamppb.New(view.ExpiresAt),
+		Ended:          view.Ended,
+	}
+}
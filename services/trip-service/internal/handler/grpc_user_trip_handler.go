@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/rideshare-platform/services/trip-service/internal/service"
+	"github.com/rideshare-platform/shared/models"
+	"github.com/rideshare-platform/shared/pagination"
+	trippb "github.com/rideshare-platform/shared/proto/trip"
+)
+
+// GetUserTrips lists the trips a user rode or drove, most recent first
+func (h *GRPCTripHandler) GetUserTrips(ctx context.Context, req *trippb.GetUserTripsRequest) (*trippb.GetUserTripsResponse, error) {
+	if h.trips == nil {
+		return nil, status.Error(codes.Unimplemented, "user trip listing is not configured")
+	}
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	page, err := pagination.NewRequest(int(req.Limit), req.Cursor, req.Sort, service.TripListOptions)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	filter := service.UserTripFilter{
+		Role: req.Role,
+		Page: page,
+	}
+	if req.Status != trippb.TripStatus_UNKNOWN_STATUS {
+		filter.Status = tripStatusFilterFromProto(req.Status)
+	}
+
+	trips, err := h.trips.ListUserTrips(ctx, req.UserId, filter)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	resp := &trippb.GetUserTripsResponse{
+		TotalCount: int32(trips.TotalEstimate),
+		HasMore:    trips.NextCursor != "",
+		NextCursor: trips.NextCursor,
+	}
+	for _, trip := range trips.Items {
+		resp.Trips = append(resp.Trips, tripToProto(trip))
+	}
+	return resp, nil
+}
+
+// AnonymizeUserTrips scrubs a user's personal data from their trips for a
+// data subject erasure request
+func (h *GRPCTripHandler) AnonymizeUserTrips(ctx context.Context, req *trippb.AnonymizeUserTripsRequest) (*trippb.AnonymizeUserTripsResponse, error) {
+	if h.trips == nil {
+		return nil, status.Error(codes.Unimplemented, "trip anonymization is not configured")
+	}
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	tripIDs, err := h.trips.AnonymizeUserTrips(ctx, req.UserId)
+	if errors.Is(err, service.ErrUserHasActiveTrip) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &trippb.AnonymizeUserTripsResponse{
+		AnonymizedCount: int32(len(tripIDs)),
+		TripIds:         tripIDs,
+	}, nil
+}
+
+// tripStatusFilterFromProto returns the stored status a proto status filters
+// by. Trips cancelled by riders and by drivers share one stored status, so
+// both cancellation statuses match either.
+func tripStatusFilterFromProto(s trippb.TripStatus) models.TripStatus {
+	switch s {
+	case trippb.TripStatus_COMPLETED:
+		return models.TripStatusCompleted
+	case trippb.TripStatus_CANCELLED_BY_RIDER, trippb.TripStatus_CANCELLED_BY_DRIVER:
+		return models.TripStatusCancelled
+	case trippb.TripStatus_FAILED:
+		return models.TripStatusFailed
+	default:
+		return tripStatusFromProto(s)
+	}
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rideshare-platform/services/trip-service/internal/service"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+)
+
+// IncidentHandler serves the trip SOS endpoint. Support staff work the
+// emergency queue through the gateway's admin API.
+type IncidentHandler struct {
+	emergencies *service.EmergencyService
+}
+
+// NewIncidentHandler creates a new incident handler
+func NewIncidentHandler(emergencies *service.EmergencyService) *IncidentHandler {
+	return &IncidentHandler{
+		emergencies: emergencies,
+	}
+}
+
+// RegisterRoutes registers the SOS route
+func (h *IncidentHandler) RegisterRoutes(router gin.IRouter) {
+	router.POST("/api/v1/trips/:id/sos", h.ReportSOS)
+}
+
+// ReportSOS raises an emergency for a trip
+func (h *IncidentHandler) ReportSOS(c *gin.Context) {
+	var req service.SOSRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeGinError(c, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+	req.TripID = c.Param("id")
+
+	incident, err := h.emergencies.ReportSOS(c.Request.Context(), &req)
+	if err != nil {
+		writeIncidentError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, incident)
+}
+
+func writeIncidentError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, types.ErrTripNotFound):
+		writeGinError(c, http.StatusNotFound, "not_found", err)
+	case errors.Is(err, service.ErrNotTripMember):
+		writeGinError(c, http.StatusForbidden, "forbidden", err)
+	default:
+		writeGinError(c, http.StatusBadRequest, "invalid_request", err)
+	}
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rideshare-platform/services/trip-service/internal/service"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+)
+
+// LostItemHandler serves the endpoint riders report lost items with. Support
+// staff track reports through the gateway's admin API.
+type LostItemHandler struct {
+	lostItems *service.LostItemService
+}
+
+// NewLostItemHandler creates a new lost item handler
+func NewLostItemHandler(lostItems *service.LostItemService) *LostItemHandler {
+	return &LostItemHandler{
+		lostItems: lostItems,
+	}
+}
+
+// RegisterRoutes registers the lost item report route
+func (h *LostItemHandler) RegisterRoutes(router gin.IRouter) {
+	router.POST("/api/v1/trips/:id/lost-items", h.ReportLostItem)
+}
+
+// ReportLostItem records an item left behind on a trip
+func (h *LostItemHandler) ReportLostItem(c *gin.Context) {
+	var req service.ReportLostItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeGinError(c, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+	req.TripID = c.Param("id")
+
+	report, err := h.lostItems.ReportLostItem(c.Request.Context(), &req)
+	if err != nil {
+		writeLostItemError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, report)
+}
+
+func writeLostItemError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, types.ErrTripNotFound):
+		writeGinError(c, http.StatusNotFound, "not_found", err)
+	case errors.Is(err, service.ErrTripNotOwned):
+		writeGinError(c, http.StatusForbidden, "forbidden", err)
+	case errors.Is(err, service.ErrLostItemReportExists):
+		writeGinError(c, http.StatusConflict, "lost_item_conflict", err)
+	case errors.Is(err, service.ErrLostItemTripNotEligible), errors.Is(err, service.ErrLostItemWindowClosed):
+		writeGinError(c, http.StatusUnprocessableEntity, "not_reportable", err)
+	default:
+		writeGinError(c, http.StatusBadRequest, "invalid_request", err)
+	}
+}
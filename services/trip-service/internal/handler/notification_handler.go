@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/rideshare-platform/shared/notifications"
+)
+
+// NotificationHandler serves users' notification preferences and delivery history
+type NotificationHandler struct {
+	dispatcher *notifications.Dispatcher
+}
+
+// NewNotificationHandler creates a new notification handler
+func NewNotificationHandler(dispatcher *notifications.Dispatcher) *NotificationHandler {
+	return &NotificationHandler{
+		dispatcher: dispatcher,
+	}
+}
+
+// RegisterRoutes registers the notification routes
+func (h *NotificationHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/v1/users/{id}/notifications/preferences", h.GetPreferences)
+	mux.HandleFunc("PUT /api/v1/users/{id}/notifications/preferences", h.UpdatePreferences)
+	mux.HandleFunc("GET /api/v1/users/{id}/notifications", h.ListDeliveries)
+}
+
+// GetPreferences returns a user's notification preferences
+func (h *NotificationHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	prefs, err := h.dispatcher.GetPreferences(r.Context(), r.PathValue("id"))
+	if errors.Is(err, notifications.ErrPreferencesNotFound) {
+		writeJSONError(w, http.StatusNotFound, "not_found", err)
+		return
+	}
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "internal_error", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, prefs)
+}
+
+// UpdatePreferences replaces a user's contact details and enabled channels
+func (h *NotificationHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	var prefs notifications.Preferences
+	if err := json.NewDecoder(r.Body).Decode(&prefs); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+	prefs.UserID = r.PathValue("id")
+
+	saved, err := h.dispatcher.UpdatePreferences(r.Context(), &prefs)
+	if errors.Is(err, notifications.ErrInvalidPreferences) {
+		writeJSONError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "internal_error", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, saved)
+}
+
+// ListDeliveries returns the notifications most recently sent to a user
+func (h *NotificationHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit <= 0 {
+		limit = 50
+	}
+
+	deliveries, err := h.dispatcher.GetDeliveries(r.Context(), r.PathValue("id"), limit)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "internal_error", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"notifications": deliveries,
+		"count":         len(deliveries),
+	})
+}
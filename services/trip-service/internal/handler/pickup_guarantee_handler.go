@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rideshare-platform/services/trip-service/internal/service"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+)
+
+// PickupGuaranteeHandler serves trips' on-time pickup guarantees. The SLA
+// report is served to operations by the gateway's admin API over gRPC.
+type PickupGuaranteeHandler struct {
+	guarantees *service.PickupGuaranteeService
+}
+
+// NewPickupGuaranteeHandler creates a new pickup guarantee handler
+func NewPickupGuaranteeHandler(guarantees *service.PickupGuaranteeService) *PickupGuaranteeHandler {
+	return &PickupGuaranteeHandler{
+		guarantees: guarantees,
+	}
+}
+
+// RegisterRoutes registers the pickup guarantee routes
+func (h *PickupGuaranteeHandler) RegisterRoutes(router gin.IRouter) {
+	router.GET("/api/v1/trips/:id/pickup-guarantee", h.GetPickupGuarantee)
+}
+
+// GetPickupGuarantee returns the pickup time the trip's rider was promised
+// and whether it was kept
+func (h *PickupGuaranteeHandler) GetPickupGuarantee(c *gin.Context) {
+	guarantee, err := h.guarantees.GetGuarantee(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		writePickupGuaranteeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, guarantee)
+}
+
+func writePickupGuaranteeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, types.ErrPickupGuaranteeNotFound):
+		writeGinError(c, http.StatusNotFound, "not_found", err)
+	default:
+		writeGinError(c, http.StatusInternalServerError, "internal_error", err)
+	}
+}
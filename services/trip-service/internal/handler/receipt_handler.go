@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/rideshare-platform/services/trip-service/internal/service"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/i18n"
+)
+
+// ReceiptHandler serves trip receipts as JSON or PDF
+type ReceiptHandler struct {
+	receipts *service.ReceiptService
+}
+
+// NewReceiptHandler creates a new receipt handler
+func NewReceiptHandler(receipts *service.ReceiptService) *ReceiptHandler {
+	return &ReceiptHandler{
+		receipts: receipts,
+	}
+}
+
+// RegisterRoutes registers the receipt routes
+func (h *ReceiptHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/v1/trips/{id}/receipt", h.GetReceipt)
+}
+
+// GetReceipt returns a completed trip's receipt. PDF is returned for
+// ?format=pdf or an Accept header asking for application/pdf, written in the
+// locale of ?locale= or else the one negotiated from Accept-Language.
+func (h *ReceiptHandler) GetReceipt(w http.ResponseWriter, r *http.Request) {
+	receipt, err := h.receipts.GetReceipt(r.Context(), r.PathValue("id"))
+	if errors.Is(err, types.ErrReceiptNotFound) {
+		writeJSONError(w, http.StatusNotFound, "not_found", err)
+		return
+	}
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "internal_error", err)
+		return
+	}
+
+	if !wantsPDF(r) {
+		writeJSON(w, http.StatusOK, receipt)
+		return
+	}
+
+	locale := receiptLocale(r)
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", "receipt-"+receipt.TripID+".pdf"))
+	w.Header().Set("Content-Language", locale)
+	w.WriteHeader(http.StatusOK)
+	w.Write(service.RenderReceiptPDF(receipt, locale))
+}
+
+func receiptLocale(r *http.Request) string {
+	if locale := r.URL.Query().Get("locale"); locale != "" {
+		return i18n.Resolve(locale)
+	}
+	return i18n.Negotiate(r.Header.Get("Accept-Language"))
+}
+
+func wantsPDF(r *http.Request) bool {
+	if format := r.URL.Query().Get("format"); format != "" {
+		return strings.EqualFold(format, "pdf")
+	}
+	return strings.Contains(r.Header.Get("Accept"), "application/pdf")
+}
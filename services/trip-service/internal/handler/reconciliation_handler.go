@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rideshare-platform/services/trip-service/internal/service"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+)
+
+// ReconciliationHandler serves trip and payment reconciliation reports
+type ReconciliationHandler struct {
+	reconciliation *service.ReconciliationService
+	requireAdmin   func(http.Handler) http.Handler
+}
+
+// NewReconciliationHandler creates a new reconciliation handler. Runs and
+// reports are for finance operators, so every route is wrapped with
+// requireAdmin.
+func NewReconciliationHandler(reconciliation *service.ReconciliationService, requireAdmin func(http.Handler) http.Handler) *ReconciliationHandler {
+	return &ReconciliationHandler{
+		reconciliation: reconciliation,
+		requireAdmin:   requireAdmin,
+	}
+}
+
+// RegisterRoutes registers the reconciliation routes
+func (h *ReconciliationHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.Handle("POST /api/v1/reconciliation/runs", h.requireAdmin(http.HandlerFunc(h.RunReconciliation)))
+	mux.Handle("GET /api/v1/reconciliation/reports", h.requireAdmin(http.HandlerFunc(h.ListReports)))
+	mux.Handle("GET /api/v1/reconciliation/reports/{id}", h.requireAdmin(http.HandlerFunc(h.GetReport)))
+}
+
+// RunReconciliation reconciles one UTC day now, by default yesterday. The
+// day is given as {"date": "2006-01-02"}.
+func (h *ReconciliationHandler) RunReconciliation(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Date string `json:"date"`
+	}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeJSONError(w, http.StatusBadRequest, "invalid_request", err)
+			return
+		}
+	}
+
+	day := time.Now().UTC().Add(-24 * time.Hour)
+	if req.Date != "" {
+		parsed, err := time.Parse(time.DateOnly, req.Date)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, "invalid_request", fmt.Errorf("date must be YYYY-MM-DD: %w", err))
+			return
+		}
+		day = parsed
+	}
+
+	report, err := h.reconciliation.ReconcileDay(r.Context(), day)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "internal_error", err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, report)
+}
+
+// ListReports returns the most recent reconciliation reports
+func (h *ReconciliationHandler) ListReports(w http.ResponseWriter, r *http.Request) {
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 || limit > 100 {
+		limit = 30
+	}
+
+	reports, err := h.reconciliation.ListReports(r.Context(), limit)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "internal_error", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"reports": reports,
+		"count":   len(reports),
+	})
+}
+
+// GetReport returns a reconciliation report with its mismatches
+func (h *ReconciliationHandler) GetReport(w http.ResponseWriter, r *http.Request) {
+	report, err := h.reconciliation.GetReport(r.Context(), r.PathValue("id"))
+	if errors.Is(err, types.ErrReconciliationReportNotFound) {
+		writeJSONError(w, http.StatusNotFound, "not_found", err)
+		return
+	}
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "internal_error", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, report)
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/rideshare-platform/services/trip-service/internal/service"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+)
+
+// ScheduledRideHandler serves the scheduled ride REST API
+type ScheduledRideHandler struct {
+	scheduledRides *service.ScheduledRideService
+}
+
+// NewScheduledRideHandler creates a new scheduled ride handler
+func NewScheduledRideHandler(scheduledRides *service.ScheduledRideService) *ScheduledRideHandler {
+	return &ScheduledRideHandler{
+		scheduledRides: scheduledRides,
+	}
+}
+
+// RegisterRoutes registers the scheduled ride routes
+func (h *ScheduledRideHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /api/v1/scheduled-rides", h.CreateScheduledRide)
+	mux.HandleFunc("GET /api/v1/scheduled-rides", h.ListScheduledRides)
+	mux.HandleFunc("GET /api/v1/scheduled-rides/{id}", h.GetScheduledRide)
+	mux.HandleFunc("POST /api/v1/scheduled-rides/{id}/cancel", h.CancelScheduledRide)
+}
+
+// CreateScheduledRide books a ride for a future pickup time
+func (h *ScheduledRideHandler) CreateScheduledRide(w http.ResponseWriter, r *http.Request) {
+	var req service.CreateScheduledRideRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+
+	ride, err := h.scheduledRides.CreateScheduledRide(r.Context(), &req)
+	if err != nil {
+		writeScheduledRideError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, ride)
+}
+
+// ListScheduledRides lists a rider's scheduled rides
+func (h *ScheduledRideHandler) ListScheduledRides(w http.ResponseWriter, r *http.Request) {
+	includeClosed, _ := strconv.ParseBool(r.URL.Query().Get("include_closed"))
+
+	rides, err := h.scheduledRides.ListScheduledRides(r.Context(), r.URL.Query().Get("rider_id"), includeClosed)
+	if err != nil {
+		writeScheduledRideError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"scheduled_rides": rides,
+		"count":           len(rides),
+	})
+}
+
+// GetScheduledRide returns a single scheduled ride
+func (h *ScheduledRideHandler) GetScheduledRide(w http.ResponseWriter, r *http.Request) {
+	ride, err := h.scheduledRides.GetScheduledRide(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeScheduledRideError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ride)
+}
+
+// CancelScheduledRide cancels a ride that has not been dispatched yet
+func (h *ScheduledRideHandler) CancelScheduledRide(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		RiderID string `json:"rider_id"`
+		Reason  string `json:"reason"`
+	}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeJSONError(w, http.StatusBadRequest, "invalid_request", err)
+			return
+		}
+	}
+
+	ride, err := h.scheduledRides.CancelScheduledRide(r.Context(), r.PathValue("id"), req.RiderID, req.Reason)
+	if err != nil {
+		writeScheduledRideError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ride)
+}
+
+func writeScheduledRideError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, types.ErrScheduledRideNotFound):
+		writeJSONError(w, http.StatusNotFound, "not_found", err)
+	case errors.Is(err, service.ErrScheduledRideNotOwned):
+		writeJSONError(w, http.StatusForbidden, "forbidden", err)
+	case errors.Is(err, service.ErrScheduledRideNotCancellable):
+		writeJSONError(w, http.StatusConflict, "not_cancellable", err)
+	default:
+		writeJSONError(w, http.StatusBadRequest, "invalid_request", err)
+	}
+}
+
+func writeJSONError(w http.ResponseWriter, status int, code string, err error) {
+	writeJSON(w, status, map[string]string{
+		"error":   code,
+		"message": err.Error(),
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
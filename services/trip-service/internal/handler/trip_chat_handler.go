@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rideshare-platform/services/trip-service/internal/service"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/middleware"
+)
+
+// TripChatHandler serves in-trip messaging over REST: sending messages, the
+// quick reply catalogue and a participant's conversation history. Support
+// staff read conversations through the gateway's admin API instead.
+type TripChatHandler struct {
+	chat *service.ChatService
+	auth *middleware.AuthMiddleware
+}
+
+// NewTripChatHandler creates a new trip chat handler
+func NewTripChatHandler(chat *service.ChatService, auth *middleware.AuthMiddleware) *TripChatHandler {
+	return &TripChatHandler{
+		chat: chat,
+		auth: auth,
+	}
+}
+
+// RegisterRoutes registers the trip messaging routes. Messages are sent
+// and read as the rider or driver the bearer token was issued to, who must
+// be on the trip.
+func (h *TripChatHandler) RegisterRoutes(router gin.IRouter) {
+	messages := router.Group("/api/v1/trips/:id/messages", h.auth.JWTAuth())
+	{
+		messages.GET("", h.ListMessages)
+		messages.POST("", h.SendMessage)
+	}
+	router.GET("/api/v1/quick-replies", h.ListQuickReplies)
+}
+
+// SendMessage sends a message from the caller to the other participant of
+// the trip
+func (h *TripChatHandler) SendMessage(c *gin.Context) {
+	var req service.SendTripMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeGinError(c, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+	req.TripID = c.Param("id")
+	req.SenderID, _ = middleware.GetUserID(c)
+
+	message, err := h.chat.SendMessage(c.Request.Context(), &req)
+	if err != nil {
+		writeTripChatError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, message)
+}
+
+// ListMessages returns the trip's conversation, oldest first, to one of its
+// participants
+func (h *TripChatHandler) ListMessages(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok || userID == "" {
+		writeGinError(c, http.StatusUnauthorized, "unauthorized", errors.New("user is not authenticated"))
+		return
+	}
+
+	messages, err := h.chat.ListMessages(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		writeTripChatError(c, err)
+		return
+	}
+	if messages == nil {
+		messages = []*types.TripMessage{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"messages": messages})
+}
+
+// ListQuickReplies returns the canned messages for ?role=rider or ?role=driver
+func (h *TripChatHandler) ListQuickReplies(c *gin.Context) {
+	role := c.Query("role")
+	if role != "rider" && role != "driver" {
+		writeGinError(c, http.StatusBadRequest, "invalid_request", errors.New("role must be rider or driver"))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"quick_replies": service.QuickReplies(role)})
+}
+
+func writeTripChatError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, types.ErrTripNotFound):
+		writeGinError(c, http.StatusNotFound, "not_found", err)
+	case errors.Is(err, service.ErrNotChatParticipant):
+		writeGinError(c, http.StatusForbidden, "forbidden", err)
+	case errors.Is(err, service.ErrChatClosed):
+		writeGinError(c, http.StatusConflict, "chat_closed", err)
+	default:
+		writeGinError(c, http.StatusBadRequest, "invalid_request", err)
+	}
+}
@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rideshare-platform/services/trip-service/internal/repository"
+	"github.com/rideshare-platform/services/trip-service/internal/service"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/middleware"
+	"github.com/rideshare-platform/shared/models"
+)
+
+func TestTripChatHandler_ParticipantComesFromTheToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+	log := logger.NewLogger("test", "info")
+	store := repository.NewMemoryTripStore()
+	trips := service.NewTripService(store, log)
+	chat := service.NewChatService(store, repository.NewMemoryTripMessageStore(), log)
+
+	trip, err := trips.CreateTrip(ctx, &service.CreateTripRequest{
+		RiderID:             "rider-1",
+		PickupLocation:      models.Location{Latitude: 41.008237, Longitude: 28.978359},
+		DestinationLocation: models.Location{Latitude: 41.042211, Longitude: 29.008311},
+		RideType:            "standard",
+		EstimatedFare:       20,
+	})
+	require.NoError(t, err)
+	_, err = trips.AcceptTrip(ctx, trip.ID, "driver-1")
+	require.NoError(t, err)
+
+	auth := middleware.NewAuthMiddleware("chat-test-secret", log)
+	router := gin.New()
+	NewTripChatHandler(chat, auth).RegisterRoutes(router)
+	path := "/api/v1/trips/" + trip.ID + "/messages"
+
+	serve := func(method, userID string, body interface{}) *httptest.ResponseRecorder {
+		var payload bytes.Buffer
+		if body != nil {
+			json.NewEncoder(&payload).Encode(body)
+		}
+		req := httptest.NewRequest(method, path, &payload)
+		req.Header.Set("Content-Type", "application/json")
+		if userID != "" {
+			token, err := auth.GenerateToken(userID, "rider", userID+"@example.com", 1)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, serve(http.MethodGet, "", nil).Code, "the history is not served without a token")
+	assert.Equal(t, http.StatusForbidden, serve(http.MethodGet, "stranger", nil).Code)
+
+	// The sender is the token's user, whatever the body claims
+	sent := serve(http.MethodPost, "rider-1", map[string]string{"sender_id": "driver-1", "text": "I'm outside"})
+	require.Equal(t, http.StatusCreated, sent.Code, sent.Body.String())
+	var message types.TripMessage
+	require.NoError(t, json.Unmarshal(sent.Body.Bytes(), &message))
+	assert.Equal(t, "rider-1", message.SenderID)
+	assert.Equal(t, "driver-1", message.RecipientID)
+
+	assert.Equal(t, http.StatusForbidden, serve(http.MethodPost, "stranger", map[string]string{"text": "hello"}).Code)
+
+	listed := serve(http.MethodGet, "driver-1", nil)
+	require.Equal(t, http.StatusOK, listed.Code)
+	var body struct {
+		Messages []*types.TripMessage `json:"messages"`
+	}
+	require.NoError(t, json.Unmarshal(listed.Body.Bytes(), &body))
+	assert.Len(t, body.Messages, 1)
+}
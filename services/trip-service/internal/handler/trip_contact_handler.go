@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rideshare-platform/services/trip-service/internal/service"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/contactproxy"
+	"github.com/rideshare-platform/shared/middleware"
+)
+
+// TripContactHandler serves the masked calling endpoint riders and drivers
+// reach each other through
+type TripContactHandler struct {
+	contacts *service.TripContactService
+	auth     *middleware.AuthMiddleware
+}
+
+// NewTripContactHandler creates a new trip contact handler
+func NewTripContactHandler(contacts *service.TripContactService, auth *middleware.AuthMiddleware) *TripContactHandler {
+	return &TripContactHandler{
+		contacts: contacts,
+		auth:     auth,
+	}
+}
+
+// RegisterRoutes registers the masked calling routes. Handles are only
+// handed to the rider or driver the bearer token was issued to.
+func (h *TripContactHandler) RegisterRoutes(router gin.IRouter) {
+	router.GET("/api/v1/trips/:id/contact", h.auth.JWTAuth(), h.GetTripContact)
+}
+
+// GetTripContact returns how the caller reaches the other party of the trip
+func (h *TripContactHandler) GetTripContact(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok || userID == "" {
+		writeGinError(c, http.StatusUnauthorized, "unauthorized", errors.New("user is not authenticated"))
+		return
+	}
+
+	contact, err := h.contacts.ContactHandle(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		writeTripContactError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, contact)
+}
+
+func writeTripContactError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, types.ErrTripNotFound):
+		writeGinError(c, http.StatusNotFound, "not_found", err)
+	case errors.Is(err, service.ErrNotTripMember):
+		writeGinError(c, http.StatusForbidden, "forbidden", err)
+	case errors.Is(err, service.ErrContactClosed):
+		writeGinError(c, http.StatusConflict, "contact_closed", err)
+	case errors.Is(err, contactproxy.ErrNumbersExhausted), errors.Is(err, contactproxy.ErrModeUnsupported):
+		writeGinError(c, http.StatusServiceUnavailable, "contact_unavailable", err)
+	default:
+		writeGinError(c, http.StatusBadRequest, "invalid_request", err)
+	}
+}
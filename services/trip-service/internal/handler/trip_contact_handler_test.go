@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rideshare-platform/services/trip-service/internal/repository"
+	"github.com/rideshare-platform/services/trip-service/internal/service"
+	"github.com/rideshare-platform/shared/contactproxy"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/middleware"
+	"github.com/rideshare-platform/shared/models"
+)
+
+func TestTripContactHandler_ParticipantComesFromTheToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctx := context.Background()
+	log := logger.NewLogger("test", "info")
+	store := repository.NewMemoryTripStore()
+	trips := service.NewTripService(store, log)
+	contacts := service.NewTripContactService(store, contactproxy.NewManager(
+		contactproxy.NewLocalProvider([]string{"+15550100"}, log),
+		contactproxy.NewMemoryStore(),
+		contactproxy.Config{Mode: contactproxy.ModeMaskedNumber, TTL: time.Hour},
+		log,
+	), log)
+
+	trip, err := trips.CreateTrip(ctx, &service.CreateTripRequest{
+		RiderID:             "rider-1",
+		PickupLocation:      models.Location{Latitude: 41.008237, Longitude: 28.978359},
+		DestinationLocation: models.Location{Latitude: 41.042211, Longitude: 29.008311},
+		RideType:            "standard",
+		EstimatedFare:       20,
+	})
+	require.NoError(t, err)
+	_, err = trips.AcceptTrip(ctx, trip.ID, "driver-1")
+	require.NoError(t, err)
+
+	auth := middleware.NewAuthMiddleware("contact-test-secret", log)
+	router := gin.New()
+	NewTripContactHandler(contacts, auth).RegisterRoutes(router)
+
+	serve := func(userID, query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/trips/"+trip.ID+"/contact"+query, nil)
+		if userID != "" {
+			token, err := auth.GenerateToken(userID, "rider", userID+"@example.com", 1)
+			require.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, serve("", "?user_id=rider-1").Code, "a handle is not served without a token")
+	assert.Equal(t, http.StatusForbidden, serve("stranger", "?user_id=rider-1").Code, "the query cannot name another participant")
+
+	// The handle is the token's user's, whatever the query claims
+	recorder := serve("driver-1", "?user_id=rider-1")
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	var contact service.TripContact
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &contact))
+	assert.Equal(t, trip.ID, contact.TripID)
+	assert.Equal(t, "driver-1", contact.Handle.UserID)
+	assert.Equal(t, "driver", contact.Handle.Role)
+}
@@ -0,0 +1,239 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/rideshare-platform/services/trip-service/internal/service"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/models"
+	"github.com/rideshare-platform/shared/pagination"
+)
+
+const (
+	// IdempotencyKeyHeader carries the key that makes retried trip requests
+	// return the trip the first request created
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set on responses to retried requests
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// TripHandler serves the trip lifecycle REST API
+type TripHandler struct {
+	trips *service.TripService
+}
+
+// NewTripHandler creates a new trip handler
+func NewTripHandler(trips *service.TripService) *TripHandler {
+	return &TripHandler{
+		trips: trips,
+	}
+}
+
+// RegisterRoutes registers the trip routes
+func (h *TripHandler) RegisterRoutes(router gin.IRouter) {
+	trips := router.Group("/api/v1/trips")
+	{
+		trips.POST("", h.CreateTrip)
+		trips.GET("", h.ListTrips)
+		trips.GET("/:id", h.GetTrip)
+		trips.GET("/:id/events", h.ListTripEvents)
+		trips.POST("/:id/accept", h.AcceptTrip)
+		trips.POST("/:id/start", h.StartTrip)
+		trips.POST("/:id/complete", h.CompleteTrip)
+		trips.POST("/:id/cancel", h.CancelTrip)
+		trips.POST("/:id/location", h.UpdateTripLocation)
+	}
+}
+
+// CreateTrip requests a new trip. Requests retried with the same
+// Idempotency-Key header are answered with the trip the first one created.
+func (h *TripHandler) CreateTrip(c *gin.Context) {
+	var req service.CreateTripRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeGinError(c, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+
+	trip, replayed, err := h.trips.CreateTripIdempotent(c.Request.Context(), c.GetHeader(IdempotencyKeyHeader), &req)
+	if err != nil {
+		writeTripError(c, err)
+		return
+	}
+
+	if replayed {
+		c.Header(IdempotentReplayedHeader, "true")
+		c.JSON(http.StatusOK, trip)
+		return
+	}
+	c.JSON(http.StatusCreated, trip)
+}
+
+// GetTrip returns a single trip
+func (h *TripHandler) GetTrip(c *gin.Context) {
+	trip, err := h.trips.GetTrip(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		writeTripError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, trip)
+}
+
+// ListTrips lists the trips of a rider (?rider_id=), a driver (?driver_id=)
+// or in a status (?status=). Pages are read with the limit, sort and cursor
+// query parameters.
+func (h *TripHandler) ListTrips(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	page, err := pagination.FromQuery(c.Request.URL.Query(), service.TripListOptions)
+	if err != nil {
+		writeGinError(c, http.StatusBadRequest, "invalid_pagination", err)
+		return
+	}
+
+	var trips []*models.Trip
+	switch {
+	case c.Query("rider_id") != "":
+		trips, err = h.trips.GetRiderTrips(ctx, c.Query("rider_id"))
+	case c.Query("driver_id") != "":
+		trips, err = h.trips.GetDriverTrips(ctx, c.Query("driver_id"))
+	case c.Query("status") != "":
+		trips, err = h.trips.GetTripsByStatus(ctx, c.Query("status"))
+	default:
+		writeGinError(c, http.StatusBadRequest, "invalid_request", errors.New("one of rider_id, driver_id or status is required"))
+		return
+	}
+	if err != nil {
+		writeTripError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pagination.Paginate(trips, page, service.TripSortKey(page.Sort)))
+}
+
+// ListTripEvents returns a trip's lifecycle events
+func (h *TripHandler) ListTripEvents(c *gin.Context) {
+	events, err := h.trips.GetTripEvents(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		writeTripError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"events": events,
+		"count":  len(events),
+	})
+}
+
+// AcceptTrip assigns the trip to the driver accepting it
+func (h *TripHandler) AcceptTrip(c *gin.Context) {
+	var req struct {
+		DriverID string `json:"driver_id"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeGinError(c, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+
+	trip, err := h.trips.AcceptTrip(c.Request.Context(), c.Param("id"), req.DriverID)
+	if err != nil {
+		writeTripError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, trip)
+}
+
+// StartTrip marks the rider as picked up
+func (h *TripHandler) StartTrip(c *gin.Context) {
+	trip, err := h.trips.StartTrip(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		writeTripError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, trip)
+}
+
+// CompleteTrip ends the trip and charges its final fare. The body is
+// optional; final_fare is only charged if pricing-service cannot price the trip.
+func (h *TripHandler) CompleteTrip(c *gin.Context) {
+	var req struct {
+		FinalFare float64 `json:"final_fare"`
+	}
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			writeGinError(c, http.StatusBadRequest, "invalid_request", err)
+			return
+		}
+	}
+
+	trip, err := h.trips.CompleteTrip(c.Request.Context(), c.Param("id"), req.FinalFare)
+	if err != nil {
+		writeTripError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, trip)
+}
+
+// CancelTrip cancels a trip that has not finished
+func (h *TripHandler) CancelTrip(c *gin.Context) {
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeGinError(c, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+
+	trip, err := h.trips.CancelTrip(c.Request.Context(), c.Param("id"), req.Reason)
+	if err != nil {
+		writeTripError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, trip)
+}
+
+// UpdateTripLocation records the driver's current location
+func (h *TripHandler) UpdateTripLocation(c *gin.Context) {
+	var location models.Location
+	if err := c.ShouldBindJSON(&location); err != nil {
+		writeGinError(c, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+
+	trip, err := h.trips.UpdateTripLocation(c.Request.Context(), c.Param("id"), location)
+	if err != nil {
+		writeTripError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, trip)
+}
+
+func writeTripError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, types.ErrTripNotFound):
+		writeGinError(c, http.StatusNotFound, "not_found", err)
+	case errors.Is(err, service.ErrInvalidTripTransition):
+		writeGinError(c, http.StatusConflict, "invalid_transition", err)
+	case errors.Is(err, service.ErrIdempotencyKeyReused):
+		writeGinError(c, http.StatusUnprocessableEntity, "idempotency_key_reused", err)
+	case errors.Is(err, service.ErrIdempotentRequestInProgress):
+		writeGinError(c, http.StatusConflict, "request_in_progress", err)
+	default:
+		writeGinError(c, http.StatusBadRequest, "invalid_request", err)
+	}
+}
+
+func writeGinError(c *gin.Context, status int, code string, err error) {
+	c.JSON(status, gin.H{
+		"error":   code,
+		"message": err.Error(),
+	})
+}
@@ -1,7 +1,190 @@
 package handler
 
-import "testing"
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rideshare-platform/services/trip-service/internal/repository"
+	"github.com/rideshare-platform/services/trip-service/internal/service"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+	"github.com/rideshare-platform/shared/pagination"
+)
 
 func TestPlaceholder(t *testing.T) {
 	// Placeholder test
 }
+
+func newTripTestRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	trips := service.NewTripService(repository.NewMemoryTripStore(), logger.NewLogger("test", "info"))
+	trips.SetEventLog(repository.NewMemoryTripEventLog())
+
+	router := gin.New()
+	NewTripHandler(trips).RegisterRoutes(router)
+	return router
+}
+
+func serveTripRequest(router http.Handler, method, path string, body interface{}) *httptest.ResponseRecorder {
+	var payload bytes.Buffer
+	if body != nil {
+		json.NewEncoder(&payload).Encode(body)
+	}
+	req := httptest.NewRequest(method, path, &payload)
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestTripHandler_Lifecycle(t *testing.T) {
+	router := newTripTestRouter()
+
+	created := serveTripRequest(router, http.MethodPost, "/api/v1/trips", map[string]interface{}{
+		"rider_id":             "rider-1",
+		"pickup_location":      map[string]float64{"latitude": 40.71, "longitude": -74.00},
+		"destination_location": map[string]float64{"latitude": 40.76, "longitude": -73.98},
+		"ride_type":            "standard",
+		"estimated_fare":       18.5,
+	})
+	assert.Equal(t, http.StatusCreated, created.Code)
+	var trip models.Trip
+	assert.NoError(t, json.Unmarshal(created.Body.Bytes(), &trip))
+	base := "/api/v1/trips/" + trip.ID
+
+	assert.Equal(t, http.StatusOK, serveTripRequest(router, http.MethodPost, base+"/accept", map[string]string{"driver_id": "driver-1"}).Code)
+	assert.Equal(t, http.StatusOK, serveTripRequest(router, http.MethodPost, base+"/start", nil).Code)
+	assert.Equal(t, http.StatusOK, serveTripRequest(router, http.MethodPost, base+"/location", map[string]float64{"latitude": 40.73, "longitude": -73.99}).Code)
+
+	completed := serveTripRequest(router, http.MethodPost, base+"/complete", nil)
+	assert.Equal(t, http.StatusOK, completed.Code)
+	assert.NoError(t, json.Unmarshal(completed.Body.Bytes(), &trip))
+	assert.Equal(t, models.TripStatusCompleted, trip.Status)
+	assert.Equal(t, int64(1850), *trip.ActualFareCents, "estimate charged without pricing-service")
+	if assert.NotNil(t, trip.ActualRoute) {
+		assert.Len(t, *trip.ActualRoute, 1)
+	}
+
+	// Completed trips cannot change status again
+	cancelled := serveTripRequest(router, http.MethodPost, base+"/cancel", map[string]string{"reason": "changed mind"})
+	assert.Equal(t, http.StatusConflict, cancelled.Code)
+
+	var events struct {
+		Events []*models.TripEvent `json:"events"`
+		Count  int                 `json:"count"`
+	}
+	listed := serveTripRequest(router, http.MethodGet, base+"/events", nil)
+	assert.Equal(t, http.StatusOK, listed.Code)
+	assert.NoError(t, json.Unmarshal(listed.Body.Bytes(), &events))
+	assert.Equal(t, 5, events.Count)
+	assert.Equal(t, "trip_requested", events.Events[0].EventType)
+	assert.Equal(t, "trip_completed", events.Events[4].EventType)
+
+	var byDriver pagination.Page[*models.Trip]
+	listed = serveTripRequest(router, http.MethodGet, "/api/v1/trips?driver_id=driver-1", nil)
+	assert.NoError(t, json.Unmarshal(listed.Body.Bytes(), &byDriver))
+	assert.Equal(t, int64(1), byDriver.TotalEstimate)
+	assert.Len(t, byDriver.Items, 1)
+
+	listed = serveTripRequest(router, http.MethodGet, "/api/v1/trips?status=completed&sort=-fare", nil)
+	assert.NoError(t, json.Unmarshal(listed.Body.Bytes(), &byDriver))
+	assert.Equal(t, int64(1), byDriver.TotalEstimate)
+
+	listed = serveTripRequest(router, http.MethodGet, "/api/v1/trips?status=completed&cursor=bogus", nil)
+	assert.Equal(t, http.StatusBadRequest, listed.Code)
+}
+
+func TestIncidentHandler_ReportSOS(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	log := logger.NewLogger("test", "info")
+	store := repository.NewMemoryTripStore()
+	trips := service.NewTripService(store, log)
+
+	router := gin.New()
+	NewTripHandler(trips).RegisterRoutes(router)
+	NewIncidentHandler(service.NewEmergencyService(store, repository.NewMemoryIncidentStore(), log)).RegisterRoutes(router)
+
+	created := serveTripRequest(router, http.MethodPost, "/api/v1/trips", map[string]interface{}{
+		"rider_id":             "rider-1",
+		"pickup_location":      map[string]float64{"latitude": 40.71, "longitude": -74.00},
+		"destination_location": map[string]float64{"latitude": 40.76, "longitude": -73.98},
+		"ride_type":            "standard",
+		"estimated_fare":       18.5,
+	})
+	assert.Equal(t, http.StatusCreated, created.Code)
+	var trip models.Trip
+	assert.NoError(t, json.Unmarshal(created.Body.Bytes(), &trip))
+
+	sos := "/api/v1/trips/" + trip.ID + "/sos"
+	assert.Equal(t, http.StatusForbidden, serveTripRequest(router, http.MethodPost, sos, map[string]string{"reporter_id": "rider-2"}).Code)
+	reported := serveTripRequest(router, http.MethodPost, sos, map[string]string{"reporter_id": "rider-1"})
+	assert.Equal(t, http.StatusCreated, reported.Code)
+
+	// Staff work incidents through the gateway's admin API only
+	var incident map[string]interface{}
+	assert.NoError(t, json.Unmarshal(reported.Body.Bytes(), &incident))
+	incidentPath := "/api/v1/incidents/" + incident["id"].(string)
+	assert.Equal(t, http.StatusNotFound, serveTripRequest(router, http.MethodPost, incidentPath+"/acknowledge", map[string]string{"staff_id": "support-1"}).Code)
+	assert.Equal(t, http.StatusNotFound, serveTripRequest(router, http.MethodGet, "/api/v1/incidents", nil).Code)
+}
+
+func TestTripHandler_Errors(t *testing.T) {
+	router := newTripTestRouter()
+
+	assert.Equal(t, http.StatusNotFound, serveTripRequest(router, http.MethodGet, "/api/v1/trips/missing", nil).Code)
+	assert.Equal(t, http.StatusNotFound, serveTripRequest(router, http.MethodPost, "/api/v1/trips/missing/start", nil).Code)
+	assert.Equal(t, http.StatusBadRequest, serveTripRequest(router, http.MethodGet, "/api/v1/trips", nil).Code)
+	assert.Equal(t, http.StatusBadRequest, serveTripRequest(router, http.MethodGet, "/api/v1/trips?status=unknown", nil).Code)
+	assert.Equal(t, http.StatusBadRequest, serveTripRequest(router, http.MethodPost, "/api/v1/trips", map[string]string{"rider_id": "rider-1"}).Code)
+}
+
+func TestTripHandler_CreateTripIdempotencyKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	trips := service.NewTripService(repository.NewMemoryTripStore(), logger.NewLogger("test", "info"))
+	trips.SetIdempotency(repository.NewMemoryTripIdempotencyStore(), 0)
+	router := gin.New()
+	NewTripHandler(trips).RegisterRoutes(router)
+
+	create := func(fare float64) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]interface{}{
+			"rider_id":             "rider-1",
+			"pickup_location":      map[string]float64{"latitude": 40.71, "longitude": -74.00},
+			"destination_location": map[string]float64{"latitude": 40.76, "longitude": -73.98},
+			"ride_type":            "standard",
+			"estimated_fare":       fare,
+		})
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/trips", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(IdempotencyKeyHeader, "tap-1")
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	first := create(18.5)
+	assert.Equal(t, http.StatusCreated, first.Code)
+	replay := create(18.5)
+	assert.Equal(t, http.StatusOK, replay.Code)
+	assert.Equal(t, "true", replay.Header().Get(IdempotentReplayedHeader))
+	assert.JSONEq(t, first.Body.String(), replay.Body.String())
+	assert.Equal(t, http.StatusUnprocessableEntity, create(20).Code)
+}
+
+func TestTripHandler_UnknownRoutesFallThroughToMux(t *testing.T) {
+	router := newTripTestRouter()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/trips/{id}/receipt", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"trip_id": r.PathValue("id")})
+	})
+	router.NoRoute(gin.WrapH(mux))
+
+	response := serveTripRequest(router, http.MethodGet, "/api/v1/trips/trip-1/receipt", nil)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Contains(t, response.Body.String(), "trip-1")
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+)
+
+// storedComplianceReport keeps a report's content next to its JSON, which
+// leaves the content out
+type storedComplianceReport struct {
+	data    []byte
+	content []byte
+}
+
+// MemoryComplianceStore implements ComplianceReportStore in memory
+type MemoryComplianceStore struct {
+	reports map[string]*storedComplianceReport
+	order   []string
+	mutex   sync.RWMutex
+}
+
+// NewMemoryComplianceStore creates a new in-memory compliance report store
+func NewMemoryComplianceStore() *MemoryComplianceStore {
+	return &MemoryComplianceStore{
+		reports: make(map[string]*storedComplianceReport),
+	}
+}
+
+// SaveReport saves a copy of a compliance report and its content
+func (m *MemoryComplianceStore) SaveReport(ctx context.Context, report *types.ComplianceReport) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to marshal compliance report: %w", err)
+	}
+	content := append([]byte(nil), report.Content...)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if _, exists := m.reports[report.ID]; !exists {
+		m.order = append(m.order, report.ID)
+	}
+	m.reports[report.ID] = &storedComplianceReport{data: data, content: content}
+	return nil
+}
+
+// GetReport retrieves a copy of a compliance report with its content
+func (m *MemoryComplianceStore) GetReport(ctx context.Context, reportID string) (*types.ComplianceReport, error) {
+	m.mutex.RLock()
+	stored, exists := m.reports[reportID]
+	m.mutex.RUnlock()
+
+	if !exists {
+		return nil, types.ErrComplianceReportNotFound
+	}
+	report, err := unmarshalComplianceReport(stored.data)
+	if err != nil {
+		return nil, err
+	}
+	report.Content = append([]byte(nil), stored.content...)
+	return report, nil
+}
+
+// FindReport returns a copy of the latest report a template generated for
+// the period starting at periodStart
+func (m *MemoryComplianceStore) FindReport(ctx context.Context, templateID string, periodStart time.Time) (*types.ComplianceReport, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	for i := len(m.order) - 1; i >= 0; i-- {
+		report, err := unmarshalComplianceReport(m.reports[m.order[i]].data)
+		if err != nil {
+			return nil, err
+		}
+		if report.TemplateID == templateID && report.PeriodStart.Equal(periodStart) {
+			return report, nil
+		}
+	}
+	return nil, types.ErrComplianceReportNotFound
+}
+
+// ListReports returns copies of the most recently saved reports matching the
+// filter, newest first
+func (m *MemoryComplianceStore) ListReports(ctx context.Context, filter types.ComplianceReportFilter) ([]*types.ComplianceReport, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	reports := make([]*types.ComplianceReport, 0, min(filter.Limit, len(m.order)))
+	for i := len(m.order) - 1; i >= 0 && len(reports) < filter.Limit; i-- {
+		report, err := unmarshalComplianceReport(m.reports[m.order[i]].data)
+		if err != nil {
+			return nil, err
+		}
+		if filter.CityID != "" && report.CityID != filter.CityID {
+			continue
+		}
+		if filter.TemplateID != "" && report.TemplateID != filter.TemplateID {
+			continue
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+func unmarshalComplianceReport(data []byte) (*types.ComplianceReport, error) {
+	var report types.ComplianceReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal compliance report: %w", err)
+	}
+	return &report, nil
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+)
+
+// MemoryDisputeStore implements DisputeStore in memory, storing copies so
+// callers cannot mutate saved disputes
+type MemoryDisputeStore struct {
+	disputes map[string][]byte
+	mutex    sync.RWMutex
+}
+
+// NewMemoryDisputeStore creates a new in-memory dispute store
+func NewMemoryDisputeStore() *MemoryDisputeStore {
+	return &MemoryDisputeStore{
+		disputes: make(map[string][]byte),
+	}
+}
+
+// SaveDispute saves a copy of the dispute
+func (m *MemoryDisputeStore) SaveDispute(ctx context.Context, dispute *types.Dispute) error {
+	data, err := json.Marshal(dispute)
+	if err != nil {
+		return fmt.Errorf("failed to marshal dispute: %w", err)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.disputes[dispute.ID] = data
+	return nil
+}
+
+// GetDispute retrieves a copy of a dispute by ID
+func (m *MemoryDisputeStore) GetDispute(ctx context.Context, disputeID string) (*types.Dispute, error) {
+	m.mutex.RLock()
+	data, exists := m.disputes[disputeID]
+	m.mutex.RUnlock()
+
+	if !exists {
+		return nil, types.ErrDisputeNotFound
+	}
+	return decodeDispute(data)
+}
+
+// ListDisputes retrieves the disputes in a status, or all of them, oldest first
+func (m *MemoryDisputeStore) ListDisputes(ctx context.Context, status types.DisputeStatus) ([]*types.Dispute, error) {
+	return m.filter(func(dispute *types.Dispute) bool {
+		return status == "" || dispute.Status == status
+	})
+}
+
+// GetDisputesByTrip retrieves the disputes opened for a trip, oldest first
+func (m *MemoryDisputeStore) GetDisputesByTrip(ctx context.Context, tripID string) ([]*types.Dispute, error) {
+	return m.filter(func(dispute *types.Dispute) bool {
+		return dispute.TripID == tripID
+	})
+}
+
+func (m *MemoryDisputeStore) filter(match func(*types.Dispute) bool) ([]*types.Dispute, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var disputes []*types.Dispute
+	for _, data := range m.disputes {
+		dispute, err := decodeDispute(data)
+		if err != nil {
+			return nil, err
+		}
+		if match(dispute) {
+			disputes = append(disputes, dispute)
+		}
+	}
+
+	sort.Slice(disputes, func(i, j int) bool {
+		return disputes[i].CreatedAt.Before(disputes[j].CreatedAt)
+	})
+	return disputes, nil
+}
+
+func decodeDispute(data []byte) (*types.Dispute, error) {
+	var dispute types.Dispute
+	if err := json.Unmarshal(data, &dispute); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal dispute: %w", err)
+	}
+	return &dispute, nil
+}
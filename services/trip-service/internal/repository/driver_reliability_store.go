@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+)
+
+// DefaultReliabilityHalfLife is how long it takes a trip to count half as
+// much towards a driver's reliability
+const DefaultReliabilityHalfLife = 14 * 24 * time.Hour
+
+// decayedReliability returns a copy of a driver's counts aged from when they
+// were last updated to now, halving every half-life
+func decayedReliability(reliability types.DriverReliability, now time.Time, halfLife time.Duration) *types.DriverReliability {
+	if elapsed := now.Sub(reliability.UpdatedAt); elapsed > 0 {
+		factor := math.Pow(0.5, float64(elapsed)/float64(halfLife))
+		reliability.Trips *= factor
+		reliability.Cancellations *= factor
+		reliability.CompletionStreak *= factor
+		reliability.UpdatedAt = now
+	}
+	return &reliability
+}
+
+// PostgresDriverReliabilityStore keeps decayed trip counts in the
+// driver_reliability table and the trips counted in driver_reliability_trips
+type PostgresDriverReliabilityStore struct {
+	db       *sql.DB
+	halfLife time.Duration
+}
+
+// NewPostgresDriverReliabilityStore creates a driver reliability store backed
+// by PostgreSQL. A half-life of 0 uses DefaultReliabilityHalfLife.
+func NewPostgresDriverReliabilityStore(db *sql.DB, halfLife time.Duration) *PostgresDriverReliabilityStore {
+	if halfLife <= 0 {
+		halfLife = DefaultReliabilityHalfLife
+	}
+	return &PostgresDriverReliabilityStore{db: db, halfLife: halfLife}
+}
+
+// RecordTrip counts a driver's trip unless its key is already in
+// driver_reliability_trips, decaying the stored counts in the same statement
+// so concurrent trips are not lost
+func (s *PostgresDriverReliabilityStore) RecordTrip(ctx context.Context, driverID, tripID string, cancelled bool, at time.Time) (*types.DriverReliability, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO driver_reliability_trips (driver_id, trip_id, cancelled, recorded_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (driver_id, trip_id) DO NOTHING`,
+		driverID, tripID, cancelled, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record driver trip: %w", err)
+	}
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("failed to record driver trip: %w", err)
+	}
+	if inserted == 0 {
+		tx.Rollback()
+		reliabilities, err := s.GetReliabilities(ctx, []string{driverID}, at)
+		if err != nil {
+			return nil, err
+		}
+		if reliability, exists := reliabilities[driverID]; exists {
+			return reliability, nil
+		}
+		return &types.DriverReliability{DriverID: driverID, UpdatedAt: at}, nil
+	}
+
+	cancellations, streak := 0.0, 1.0
+	if cancelled {
+		cancellations, streak = 1, 0
+	}
+	query := `
+		INSERT INTO driver_reliability (driver_id, trips, cancellations, completion_streak, updated_at)
+		VALUES ($1, 1, $2, $3, $4)
+		ON CONFLICT (driver_id) DO UPDATE SET
+			trips = driver_reliability.trips * POWER(0.5, GREATEST(EXTRACT(EPOCH FROM (EXCLUDED.updated_at - driver_reliability.updated_at)), 0) / $5) + 1,
+			cancellations = driver_reliability.cancellations * POWER(0.5, GREATEST(EXTRACT(EPOCH FROM (EXCLUDED.updated_at - driver_reliability.updated_at)), 0) / $5) + EXCLUDED.cancellations,
+			completion_streak = CASE WHEN EXCLUDED.cancellations > 0 THEN 0
+				ELSE driver_reliability.completion_streak * POWER(0.5, GREATEST(EXTRACT(EPOCH FROM (EXCLUDED.updated_at - driver_reliability.updated_at)), 0) / $5) + 1 END,
+			updated_at = GREATEST(driver_reliability.updated_at, EXCLUDED.updated_at)
+		RETURNING driver_id, trips, cancellations, completion_streak, updated_at`
+
+	var reliability types.DriverReliability
+	err = tx.QueryRowContext(ctx, query, driverID, cancellations, streak, at, s.halfLife.Seconds()).Scan(
+		&reliability.DriverID, &reliability.Trips, &reliability.Cancellations, &reliability.CompletionStreak, &reliability.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update driver reliability: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit driver reliability: %w", err)
+	}
+	return &reliability, nil
+}
+
+// GetReliabilities returns the drivers' counts as of now
+func (s *PostgresDriverReliabilityStore) GetReliabilities(ctx context.Context, driverIDs []string, now time.Time) (map[string]*types.DriverReliability, error) {
+	query := `
+		SELECT driver_id, trips, cancellations, completion_streak, updated_at
+		FROM driver_reliability
+		WHERE driver_id = ANY($1)`
+
+	rows, err := s.db.QueryContext(ctx, query, pq.Array(driverIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get driver reliability: %w", err)
+	}
+	defer rows.Close()
+
+	reliabilities := make(map[string]*types.DriverReliability, len(driverIDs))
+	for rows.Next() {
+		var reliability types.DriverReliability
+		if err := rows.Scan(&reliability.DriverID, &reliability.Trips, &reliability.Cancellations, &reliability.CompletionStreak, &reliability.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to read driver reliability: %w", err)
+		}
+		reliabilities[reliability.DriverID] = decayedReliability(reliability, now, s.halfLife)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get driver reliability: %w", err)
+	}
+	return reliabilities, nil
+}
+
+// MemoryDriverReliabilityStore implements DriverReliabilityStore in memory,
+// storing copies so callers cannot mutate saved records
+type MemoryDriverReliabilityStore struct {
+	reliabilities map[string]types.DriverReliability
+	counted       map[string]map[string]bool // trip IDs counted, by driver
+	halfLife      time.Duration
+	mutex         sync.RWMutex
+}
+
+// NewMemoryDriverReliabilityStore creates a new in-memory driver reliability
+// store. A half-life of 0 uses DefaultReliabilityHalfLife.
+func NewMemoryDriverReliabilityStore(halfLife time.Duration) *MemoryDriverReliabilityStore {
+	if halfLife <= 0 {
+		halfLife = DefaultReliabilityHalfLife
+	}
+	return &MemoryDriverReliabilityStore{
+		reliabilities: make(map[string]types.DriverReliability),
+		counted:       make(map[string]map[string]bool),
+		halfLife:      halfLife,
+	}
+}
+
+// RecordTrip counts a driver's trip unless it was counted already
+func (m *MemoryDriverReliabilityStore) RecordTrip(ctx context.Context, driverID, tripID string, cancelled bool, at time.Time) (*types.DriverReliability, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	reliability := types.DriverReliability{DriverID: driverID, UpdatedAt: at}
+	if existing, exists := m.reliabilities[driverID]; exists {
+		reliability = *decayedReliability(existing, at, m.halfLife)
+	}
+	if m.counted[driverID][tripID] {
+		return &reliability, nil
+	}
+
+	reliability.Trips++
+	if cancelled {
+		reliability.Cancellations++
+		reliability.CompletionStreak = 0
+	} else {
+		reliability.CompletionStreak++
+	}
+	if m.counted[driverID] == nil {
+		m.counted[driverID] = make(map[string]bool)
+	}
+	m.counted[driverID][tripID] = true
+	m.reliabilities[driverID] = reliability
+	return &reliability, nil
+}
+
+// GetReliabilities returns copies of the drivers' counts as of now
+func (m *MemoryDriverReliabilityStore) GetReliabilities(ctx context.Context, driverIDs []string, now time.Time) (map[string]*types.DriverReliability, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	reliabilities := make(map[string]*types.DriverReliability, len(driverIDs))
+	for _, driverID := range driverIDs {
+		if reliability, exists := m.reliabilities[driverID]; exists {
+			reliabilities[driverID] = decayedReliability(reliability, now, m.halfLife)
+		}
+	}
+	return reliabilities, nil
+}
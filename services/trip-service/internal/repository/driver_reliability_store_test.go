@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresDriverReliabilityStore_RecordTripDecaysInTheUpsert(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	store := NewPostgresDriverReliabilityStore(db, time.Hour)
+	at := time.Now()
+
+	// The trip is claimed first, then the counts are decayed and bumped in
+	// one statement so concurrent trips of a driver are not lost
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO driver_reliability_trips .* ON CONFLICT \(driver_id, trip_id\) DO NOTHING`).
+		WithArgs("driver-1", "trip-1", true, at).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`INSERT INTO driver_reliability .* POWER\(0.5, .*\) / \$5`).
+		WithArgs("driver-1", 1.0, 0.0, at, 3600.0).
+		WillReturnRows(sqlmock.NewRows([]string{"driver_id", "trips", "cancellations", "completion_streak", "updated_at"}).
+			AddRow("driver-1", 1.5, 1.0, 0.0, at))
+	mock.ExpectCommit()
+
+	reliability, err := store.RecordTrip(ctx, "driver-1", "trip-1", true, at)
+	require.NoError(t, err)
+	assert.InDelta(t, 1.5, reliability.Trips, 0.001)
+	assert.InDelta(t, 1.0/1.5, reliability.CancellationRate(), 0.001)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostgresDriverReliabilityStore_RecordTripCountsATripOnce(t *testing.T) {
+	ctx := context.Background()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	store := NewPostgresDriverReliabilityStore(db, time.Hour)
+	at := time.Now()
+
+	// A trip already claimed leaves the counts as they are
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO driver_reliability_trips`).
+		WithArgs("driver-1", "trip-1", false, at).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+	mock.ExpectQuery(`SELECT driver_id, trips, cancellations, completion_streak, updated_at FROM driver_reliability`).
+		WillReturnRows(sqlmock.NewRows([]string{"driver_id", "trips", "cancellations", "completion_streak", "updated_at"}).
+			AddRow("driver-1", 2.0, 0.0, 2.0, at.Add(-time.Hour)))
+
+	reliability, err := store.RecordTrip(ctx, "driver-1", "trip-1", false, at)
+	require.NoError(t, err)
+	assert.InDelta(t, 1.0, reliability.Trips, 0.001)
+	assert.InDelta(t, 1.0, reliability.CompletionStreak, 0.001)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMemoryDriverReliabilityStore_CountsATripOncePerDriver(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryDriverReliabilityStore(time.Hour)
+	at := time.Now()
+
+	_, err := store.RecordTrip(ctx, "driver-1", "trip-1", true, at)
+	require.NoError(t, err)
+	repeated, err := store.RecordTrip(ctx, "driver-1", "trip-1", true, at)
+	require.NoError(t, err)
+	assert.InDelta(t, 1, repeated.Trips, 0.001, "a repeated trip is counted once")
+
+	// A trip the first driver cancelled counts for the driver it was
+	// reassigned to
+	reassigned, err := store.RecordTrip(ctx, "driver-2", "trip-1", false, at)
+	require.NoError(t, err)
+	assert.InDelta(t, 1, reassigned.Trips, 0.001)
+	assert.InDelta(t, 1, reassigned.CompletionStreak, 0.001)
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/database"
 	"github.com/rideshare-platform/shared/logger"
 )
 
@@ -172,20 +173,139 @@ func (s *PostgreSQLEventStore) GetEventsAfterVersion(ctx context.Context, tripID
 	return events, nil
 }
 
+// GetTripVersionsSince returns the latest event version of each trip with
+// events recorded at or after since
+func (s *PostgreSQLEventStore) GetTripVersionsSince(ctx context.Context, since time.Time) (map[string]int, error) {
+	query := `
+		SELECT trip_id, MAX(version)
+		FROM trip_events
+		WHERE trip_id IN (SELECT DISTINCT trip_id FROM trip_events WHERE timestamp >= $1)
+		GROUP BY trip_id
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query trip versions: %w", err)
+	}
+	defer rows.Close()
+
+	versions := make(map[string]int)
+	for rows.Next() {
+		var tripID string
+		var version int
+		if err := rows.Scan(&tripID, &version); err != nil {
+			return nil, fmt.Errorf("failed to scan trip version: %w", err)
+		}
+		versions[tripID] = version
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating trip versions: %w", err)
+	}
+	return versions, nil
+}
+
+// PostgreSQLSnapshotStore implements TripSnapshotStore using PostgreSQL
+type PostgreSQLSnapshotStore struct {
+	db *sql.DB
+}
+
+// NewPostgreSQLSnapshotStore creates a new PostgreSQL snapshot store
+func NewPostgreSQLSnapshotStore(db *sql.DB) *PostgreSQLSnapshotStore {
+	return &PostgreSQLSnapshotStore{db: db}
+}
+
+// SaveSnapshot saves a trip snapshot, replacing one of the same version
+func (s *PostgreSQLSnapshotStore) SaveSnapshot(ctx context.Context, snapshot *types.TripSnapshot) error {
+	query := `
+		INSERT INTO trip_snapshots (trip_id, version, state, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (trip_id, version) DO UPDATE SET
+			state = EXCLUDED.state,
+			created_at = EXCLUDED.created_at
+	`
+
+	state, err := json.Marshal(snapshot.State)
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot state: %w", err)
+	}
+	if _, err := s.db.ExecContext(ctx, query, snapshot.TripID, snapshot.Version, state, snapshot.CreatedAt); err != nil {
+		return fmt.Errorf("failed to save snapshot: %w", err)
+	}
+	return nil
+}
+
+// GetLatestSnapshot retrieves the trip's snapshot with the highest version
+func (s *PostgreSQLSnapshotStore) GetLatestSnapshot(ctx context.Context, tripID string) (*types.TripSnapshot, error) {
+	query := `
+		SELECT trip_id, version, state, created_at
+		FROM trip_snapshots
+		WHERE trip_id = $1
+		ORDER BY version DESC
+		LIMIT 1
+	`
+
+	var snapshot types.TripSnapshot
+	var state []byte
+	err := s.db.QueryRowContext(ctx, query, tripID).Scan(&snapshot.TripID, &snapshot.Version, &state, &snapshot.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, types.ErrSnapshotNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get snapshot: %w", err)
+	}
+	if err := json.Unmarshal(state, &snapshot.State); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal snapshot state: %w", err)
+	}
+	return &snapshot, nil
+}
+
+// PruneSnapshots removes all but the trip's keep latest snapshots
+func (s *PostgreSQLSnapshotStore) PruneSnapshots(ctx context.Context, tripID string, keep int) (int, error) {
+	query := `
+		DELETE FROM trip_snapshots
+		WHERE trip_id = $1 AND version NOT IN (
+			SELECT version FROM trip_snapshots WHERE trip_id = $1 ORDER BY version DESC LIMIT $2
+		)
+	`
+
+	result, err := s.db.ExecContext(ctx, query, tripID, keep)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune snapshots: %w", err)
+	}
+	pruned, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count pruned snapshots: %w", err)
+	}
+	return int(pruned), nil
+}
+
 // PostgreSQLTripReadModel implements TripReadModel using PostgreSQL
 type PostgreSQLTripReadModel struct {
 	db     *sql.DB
+	reads  tripHistoryReader
 	logger logger.Logger
 }
 
+// tripHistoryReader runs the rider and driver history queries, which tolerate
+// replication lag. *sql.DB and database.ReplicaSet both satisfy it.
+type tripHistoryReader interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
 // NewPostgreSQLTripReadModel creates a new PostgreSQL read model
 func NewPostgreSQLTripReadModel(db *sql.DB, logger logger.Logger) *PostgreSQLTripReadModel {
 	return &PostgreSQLTripReadModel{
 		db:     db,
+		reads:  db,
 		logger: logger,
 	}
 }
 
+// SetReplicas routes trip history queries to read replicas
+func (r *PostgreSQLTripReadModel) SetReplicas(replicas *database.ReplicaSet) {
+	r.reads = replicas
+}
+
 // SaveTrip saves a trip aggregate to the read model
 func (r *PostgreSQLTripReadModel) SaveTrip(ctx context.Context, trip *types.TripAggregate) error {
 	query := `
@@ -299,7 +419,7 @@ func (r *PostgreSQLTripReadModel) GetTripsByRider(ctx context.Context, riderID s
 		LIMIT $2 OFFSET $3
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, riderID, limit, offset)
+	rows, err := r.reads.QueryContext(ctx, query, riderID, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query trips by rider: %w", err)
 	}
@@ -321,7 +441,7 @@ func (r *PostgreSQLTripReadModel) GetTripsByDriver(ctx context.Context, driverID
 		LIMIT $2 OFFSET $3
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, driverID, limit, offset)
+	rows, err := r.reads.QueryContext(ctx, query, driverID, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query trips by driver: %w", err)
 	}
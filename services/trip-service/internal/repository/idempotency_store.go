@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+)
+
+// idempotencySweepInterval is how often reserving a key also drops the
+// expired ones
+const idempotencySweepInterval = time.Minute
+
+// MemoryTripIdempotencyStore implements TripIdempotencyStore in memory.
+// Expired keys are treated as unsaved when they are looked up and replaced
+// when the rider reserves them again. Keys no rider sends again are dropped
+// by a sweep that runs while keys are reserved.
+type MemoryTripIdempotencyStore struct {
+	keys      map[string]types.TripIdempotencyKey
+	mutex     sync.Mutex
+	now       func() time.Time
+	lastSweep time.Time
+}
+
+// NewMemoryTripIdempotencyStore creates a new in-memory idempotency key store
+func NewMemoryTripIdempotencyStore() *MemoryTripIdempotencyStore {
+	return &MemoryTripIdempotencyStore{
+		keys: make(map[string]types.TripIdempotencyKey),
+		now:  time.Now,
+	}
+}
+
+func idempotencyStoreKey(riderID, key string) string {
+	return riderID + "/" + key
+}
+
+// ReserveIdempotencyKey saves key unless the rider's key is already saved
+// and has not expired
+func (m *MemoryTripIdempotencyStore) ReserveIdempotencyKey(ctx context.Context, key *types.TripIdempotencyKey) (*types.TripIdempotencyKey, bool, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.sweep()
+
+	id := idempotencyStoreKey(key.RiderID, key.Key)
+	if saved, exists := m.keys[id]; exists && m.now().Before(saved.ExpiresAt) {
+		return &saved, false, nil
+	}
+	m.keys[id] = *key
+	reserved := *key
+	return &reserved, true, nil
+}
+
+// sweep drops expired keys, at most once per idempotencySweepInterval. The
+// caller holds the mutex.
+func (m *MemoryTripIdempotencyStore) sweep() {
+	now := m.now()
+	if now.Sub(m.lastSweep) < idempotencySweepInterval {
+		return
+	}
+	m.lastSweep = now
+
+	for id, saved := range m.keys {
+		if !now.Before(saved.ExpiresAt) {
+			delete(m.keys, id)
+		}
+	}
+}
+
+// CompleteIdempotencyKey records the trip a reserved key created
+func (m *MemoryTripIdempotencyStore) CompleteIdempotencyKey(ctx context.Context, riderID, key, tripID string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	id := idempotencyStoreKey(riderID, key)
+	saved, exists := m.keys[id]
+	if !exists || !m.now().Before(saved.ExpiresAt) {
+		return fmt.Errorf("idempotency key %s is not reserved", key)
+	}
+	saved.TripID = tripID
+	m.keys[id] = saved
+	return nil
+}
+
+// ReleaseIdempotencyKey forgets a reserved key
+func (m *MemoryTripIdempotencyStore) ReleaseIdempotencyKey(ctx context.Context, riderID, key string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	delete(m.keys, idempotencyStoreKey(riderID, key))
+	return nil
+}
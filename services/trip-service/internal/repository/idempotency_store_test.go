@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+)
+
+func TestMemoryTripIdempotencyStore_ExpiresKeysOnLookup(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	store := NewMemoryTripIdempotencyStore()
+	store.now = func() time.Time { return now }
+
+	newKey := func(hash string) *types.TripIdempotencyKey {
+		return &types.TripIdempotencyKey{
+			Key:         "tap-1",
+			RiderID:     "rider123",
+			RequestHash: hash,
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(time.Hour),
+		}
+	}
+
+	_, reserved, err := store.ReserveIdempotencyKey(ctx, newKey("first"))
+	require.NoError(t, err)
+	assert.True(t, reserved)
+	require.NoError(t, store.CompleteIdempotencyKey(ctx, "rider123", "tap-1", "trip-1"))
+
+	saved, reserved, err := store.ReserveIdempotencyKey(ctx, newKey("second"))
+	require.NoError(t, err)
+	assert.False(t, reserved)
+	assert.Equal(t, "trip-1", saved.TripID)
+
+	// Once expired the key can no longer be completed and is reserved afresh
+	now = now.Add(time.Hour)
+	assert.Error(t, store.CompleteIdempotencyKey(ctx, "rider123", "tap-1", "trip-2"))
+	saved, reserved, err = store.ReserveIdempotencyKey(ctx, newKey("second"))
+	require.NoError(t, err)
+	assert.True(t, reserved)
+	assert.Equal(t, "second", saved.RequestHash)
+	assert.Empty(t, saved.TripID)
+}
+
+func TestMemoryTripIdempotencyStore_SweepsExpiredKeys(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	store := NewMemoryTripIdempotencyStore()
+	store.now = func() time.Time { return now }
+
+	reserve := func(key string, ttl time.Duration) {
+		_, reserved, err := store.ReserveIdempotencyKey(ctx, &types.TripIdempotencyKey{
+			Key:       key,
+			RiderID:   "rider123",
+			CreatedAt: now,
+			ExpiresAt: now.Add(ttl),
+		})
+		require.NoError(t, err)
+		require.True(t, reserved)
+	}
+
+	reserve("short", time.Minute)
+	reserve("long", time.Hour)
+
+	// Keys are not swept more than once a minute
+	now = now.Add(30 * time.Second)
+	reserve("later", time.Hour)
+	assert.Len(t, store.keys, 3)
+
+	now = now.Add(time.Minute)
+	reserve("latest", time.Hour)
+	assert.Len(t, store.keys, 3)
+	assert.NotContains(t, store.keys, idempotencyStoreKey("rider123", "short"), "the expired key is dropped without being sent again")
+}
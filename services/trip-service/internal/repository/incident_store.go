@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+)
+
+// MemoryIncidentStore implements IncidentStore in memory, storing copies so
+// callers cannot mutate saved incidents
+type MemoryIncidentStore struct {
+	incidents map[string][]byte
+	mutex     sync.RWMutex
+}
+
+// NewMemoryIncidentStore creates a new in-memory incident store
+func NewMemoryIncidentStore() *MemoryIncidentStore {
+	return &MemoryIncidentStore{
+		incidents: make(map[string][]byte),
+	}
+}
+
+// SaveIncident saves a copy of the incident
+func (m *MemoryIncidentStore) SaveIncident(ctx context.Context, incident *types.Incident) error {
+	data, err := json.Marshal(incident)
+	if err != nil {
+		return fmt.Errorf("failed to marshal incident: %w", err)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.incidents[incident.ID] = data
+	return nil
+}
+
+// GetIncident retrieves a copy of an incident by ID
+func (m *MemoryIncidentStore) GetIncident(ctx context.Context, incidentID string) (*types.Incident, error) {
+	m.mutex.RLock()
+	data, exists := m.incidents[incidentID]
+	m.mutex.RUnlock()
+
+	if !exists {
+		return nil, types.ErrIncidentNotFound
+	}
+	return decodeIncident(data)
+}
+
+// ListIncidents retrieves the incidents in a status, or all of them, newest first
+func (m *MemoryIncidentStore) ListIncidents(ctx context.Context, status types.IncidentStatus) ([]*types.Incident, error) {
+	return m.filter(func(incident *types.Incident) bool {
+		return status == "" || incident.Status == status
+	})
+}
+
+// GetIncidentsByTrip retrieves the incidents raised during a trip, newest first
+func (m *MemoryIncidentStore) GetIncidentsByTrip(ctx context.Context, tripID string) ([]*types.Incident, error) {
+	return m.filter(func(incident *types.Incident) bool {
+		return incident.TripID == tripID
+	})
+}
+
+func (m *MemoryIncidentStore) filter(match func(*types.Incident) bool) ([]*types.Incident, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var incidents []*types.Incident
+	for _, data := range m.incidents {
+		incident, err := decodeIncident(data)
+		if err != nil {
+			return nil, err
+		}
+		if match(incident) {
+			incidents = append(incidents, incident)
+		}
+	}
+
+	sort.Slice(incidents, func(i, j int) bool {
+		return incidents[i].CreatedAt.After(incidents[j].CreatedAt)
+	})
+	return incidents, nil
+}
+
+func decodeIncident(data []byte) (*types.Incident, error) {
+	var incident types.Incident
+	if err := json.Unmarshal(data, &incident); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal incident: %w", err)
+	}
+	return &incident, nil
+}
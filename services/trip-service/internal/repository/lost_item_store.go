@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+)
+
+// MemoryLostItemStore implements LostItemStore in memory, storing copies so
+// callers cannot mutate saved reports
+type MemoryLostItemStore struct {
+	reports map[string][]byte
+	mutex   sync.RWMutex
+}
+
+// NewMemoryLostItemStore creates a new in-memory lost item store
+func NewMemoryLostItemStore() *MemoryLostItemStore {
+	return &MemoryLostItemStore{
+		reports: make(map[string][]byte),
+	}
+}
+
+// SaveLostItem saves a copy of the report
+func (m *MemoryLostItemStore) SaveLostItem(ctx context.Context, report *types.LostItemReport) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to marshal lost item report: %w", err)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.reports[report.ID] = data
+	return nil
+}
+
+// GetLostItem retrieves a copy of a report by ID
+func (m *MemoryLostItemStore) GetLostItem(ctx context.Context, reportID string) (*types.LostItemReport, error) {
+	m.mutex.RLock()
+	data, exists := m.reports[reportID]
+	m.mutex.RUnlock()
+
+	if !exists {
+		return nil, types.ErrLostItemNotFound
+	}
+	return decodeLostItem(data)
+}
+
+// ListLostItems retrieves the reports matching filter, newest first
+func (m *MemoryLostItemStore) ListLostItems(ctx context.Context, filter types.LostItemFilter) ([]*types.LostItemReport, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var reports []*types.LostItemReport
+	for _, data := range m.reports {
+		report, err := decodeLostItem(data)
+		if err != nil {
+			return nil, err
+		}
+		if (filter.Status == "" || report.Status == filter.Status) &&
+			(filter.RiderID == "" || report.RiderID == filter.RiderID) &&
+			(filter.DriverID == "" || report.DriverID == filter.DriverID) &&
+			(filter.TripID == "" || report.TripID == filter.TripID) {
+			reports = append(reports, report)
+		}
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].CreatedAt.After(reports[j].CreatedAt)
+	})
+	return reports, nil
+}
+
+func decodeLostItem(data []byte) (*types.LostItemReport, error) {
+	var report types.LostItemReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal lost item report: %w", err)
+	}
+	return &report, nil
+}
@@ -3,6 +3,8 @@ package repository
 import (
 	"context"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/rideshare-platform/services/trip-service/internal/types"
 )
@@ -53,6 +55,76 @@ func (m *MockEventStore) GetEventsAfterVersion(ctx context.Context, tripID strin
 	return filteredEvents, nil
 }
 
+// GetTripVersionsSince returns the latest event version of each trip with
+// events at or after since
+func (m *MockEventStore) GetTripVersionsSince(ctx context.Context, since time.Time) (map[string]int, error) {
+	versions := make(map[string]int)
+	for tripID, events := range m.events {
+		recent := false
+		latest := 0
+		for _, event := range events {
+			if !event.Timestamp.Before(since) {
+				recent = true
+			}
+			if event.Version > latest {
+				latest = event.Version
+			}
+		}
+		if recent {
+			versions[tripID] = latest
+		}
+	}
+	return versions, nil
+}
+
+// MockSnapshotStore implements TripSnapshotStore for testing
+type MockSnapshotStore struct {
+	snapshots map[string][]*types.TripSnapshot
+}
+
+// NewMockSnapshotStore creates a new mock snapshot store
+func NewMockSnapshotStore() *MockSnapshotStore {
+	return &MockSnapshotStore{
+		snapshots: make(map[string][]*types.TripSnapshot),
+	}
+}
+
+// SaveSnapshot saves a snapshot to memory, keeping each trip's snapshots in
+// version order
+func (m *MockSnapshotStore) SaveSnapshot(ctx context.Context, snapshot *types.TripSnapshot) error {
+	snapshots := m.snapshots[snapshot.TripID]
+	for i, saved := range snapshots {
+		if saved.Version == snapshot.Version {
+			snapshots[i] = snapshot
+			return nil
+		}
+	}
+	snapshots = append(snapshots, snapshot)
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].Version < snapshots[j].Version })
+	m.snapshots[snapshot.TripID] = snapshots
+	return nil
+}
+
+// GetLatestSnapshot returns the trip's snapshot with the highest version
+func (m *MockSnapshotStore) GetLatestSnapshot(ctx context.Context, tripID string) (*types.TripSnapshot, error) {
+	snapshots := m.snapshots[tripID]
+	if len(snapshots) == 0 {
+		return nil, types.ErrSnapshotNotFound
+	}
+	return snapshots[len(snapshots)-1], nil
+}
+
+// PruneSnapshots removes all but the trip's keep latest snapshots
+func (m *MockSnapshotStore) PruneSnapshots(ctx context.Context, tripID string, keep int) (int, error) {
+	snapshots := m.snapshots[tripID]
+	if len(snapshots) <= keep {
+		return 0, nil
+	}
+	pruned := len(snapshots) - keep
+	m.snapshots[tripID] = snapshots[pruned:]
+	return pruned, nil
+}
+
 // MockReadModel implements TripReadModel for testing
 type MockReadModel struct {
 	trips map[string]*types.TripAggregate
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+)
+
+// tripListingCheckpointID identifies the trip listing projection's
+// checkpoint document
+const tripListingCheckpointID = "trip_listings"
+
+// MongoTripListingStore implements TripListingStore using MongoDB, so trip
+// lists are read from a collection indexed for them instead of the trip store
+type MongoTripListingStore struct {
+	listings    *mongo.Collection
+	checkpoints *mongo.Collection
+}
+
+// NewMongoTripListingStore creates a trip listing store on the database's
+// trip_listings and projection_checkpoints collections
+func NewMongoTripListingStore(db *mongo.Database) *MongoTripListingStore {
+	return &MongoTripListingStore{
+		listings:    db.Collection("trip_listings"),
+		checkpoints: db.Collection("projection_checkpoints"),
+	}
+}
+
+// EnsureIndexes creates the indexes rider, driver and status lists use
+func (s *MongoTripListingStore) EnsureIndexes(ctx context.Context) error {
+	_, err := s.listings.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "rider_id", Value: 1}, {Key: "requested_at", Value: -1}}},
+		{Keys: bson.D{{Key: "driver_id", Value: 1}, {Key: "requested_at", Value: -1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "requested_at", Value: -1}}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create trip listing indexes: %w", err)
+	}
+	return nil
+}
+
+// SaveListing inserts or replaces the trip's listing
+func (s *MongoTripListingStore) SaveListing(ctx context.Context, listing *types.TripListing) error {
+	_, err := s.listings.ReplaceOne(ctx, bson.M{"_id": listing.TripID}, listing, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to save trip listing: %w", err)
+	}
+	return nil
+}
+
+// DeleteListing removes a trip's listing. Trips without one are skipped.
+func (s *MongoTripListingStore) DeleteListing(ctx context.Context, tripID string) error {
+	if _, err := s.listings.DeleteOne(ctx, bson.M{"_id": tripID}); err != nil {
+		return fmt.Errorf("failed to delete trip listing: %w", err)
+	}
+	return nil
+}
+
+// ListListings retrieves the listings matching filter, most recently
+// requested first
+func (s *MongoTripListingStore) ListListings(ctx context.Context, filter types.TripListingFilter) ([]*types.TripListing, error) {
+	query := bson.M{}
+	if filter.RiderID != "" {
+		query["rider_id"] = filter.RiderID
+	}
+	if filter.DriverID != "" {
+		query["driver_id"] = filter.DriverID
+	}
+	if filter.Status != "" {
+		query["status"] = filter.Status
+	}
+
+	cursor, err := s.listings.Find(ctx, query, options.Find().SetSort(bson.D{{Key: "requested_at", Value: -1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find trip listings: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	listings := []*types.TripListing{}
+	if err := cursor.All(ctx, &listings); err != nil {
+		return nil, fmt.Errorf("failed to decode trip listings: %w", err)
+	}
+	return listings, nil
+}
+
+// GetCheckpoint returns the log position of the last event projected
+func (s *MongoTripListingStore) GetCheckpoint(ctx context.Context) (int64, error) {
+	var checkpoint struct {
+		Position int64 `bson:"position"`
+	}
+	err := s.checkpoints.FindOne(ctx, bson.M{"_id": tripListingCheckpointID}).Decode(&checkpoint)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get trip listing checkpoint: %w", err)
+	}
+	return checkpoint.Position, nil
+}
+
+// SaveCheckpoint records the log position of the last event projected
+func (s *MongoTripListingStore) SaveCheckpoint(ctx context.Context, position int64) error {
+	_, err := s.checkpoints.UpdateOne(ctx,
+		bson.M{"_id": tripListingCheckpointID},
+		bson.M{"$set": bson.M{"position": position}},
+		options.Update().SetUpsert(true),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save trip listing checkpoint: %w", err)
+	}
+	return nil
+}
+
+// Reset removes every listing and the checkpoint
+func (s *MongoTripListingStore) Reset(ctx context.Context) error {
+	if _, err := s.listings.DeleteMany(ctx, bson.M{}); err != nil {
+		return fmt.Errorf("failed to delete trip listings: %w", err)
+	}
+	if _, err := s.checkpoints.DeleteOne(ctx, bson.M{"_id": tripListingCheckpointID}); err != nil {
+		return fmt.Errorf("failed to delete trip listing checkpoint: %w", err)
+	}
+	return nil
+}
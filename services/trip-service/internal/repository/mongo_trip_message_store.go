@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+)
+
+// MongoTripMessageStore implements TripMessageStore using MongoDB, so
+// undelivered messages survive restarts and are shared between instances
+type MongoTripMessageStore struct {
+	messages *mongo.Collection
+}
+
+// NewMongoTripMessageStore creates a trip message store on the database's
+// trip_messages collection
+func NewMongoTripMessageStore(db *mongo.Database) *MongoTripMessageStore {
+	return &MongoTripMessageStore{
+		messages: db.Collection("trip_messages"),
+	}
+}
+
+// EnsureIndexes creates the indexes trip and recipient lookups use
+func (s *MongoTripMessageStore) EnsureIndexes(ctx context.Context) error {
+	_, err := s.messages.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "trip_id", Value: 1}, {Key: "created_at", Value: 1}}},
+		{Keys: bson.D{{Key: "trip_id", Value: 1}, {Key: "recipient_id", Value: 1}, {Key: "delivered_at", Value: 1}}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create trip message indexes: %w", err)
+	}
+	return nil
+}
+
+// SaveMessage inserts or replaces the message
+func (s *MongoTripMessageStore) SaveMessage(ctx context.Context, message *types.TripMessage) error {
+	_, err := s.messages.ReplaceOne(ctx, bson.M{"_id": message.ID}, message, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to save trip message: %w", err)
+	}
+	return nil
+}
+
+// ListTripMessages retrieves a trip's messages, oldest first
+func (s *MongoTripMessageStore) ListTripMessages(ctx context.Context, tripID string) ([]*types.TripMessage, error) {
+	return s.find(ctx, bson.M{"trip_id": tripID})
+}
+
+// UndeliveredMessages retrieves the trip's undelivered messages to a recipient, oldest first
+func (s *MongoTripMessageStore) UndeliveredMessages(ctx context.Context, tripID, recipientID string) ([]*types.TripMessage, error) {
+	return s.find(ctx, bson.M{
+		"trip_id":      tripID,
+		"recipient_id": recipientID,
+		"delivered_at": bson.M{"$exists": false},
+	})
+}
+
+// MarkDelivered records when messages were delivered
+func (s *MongoTripMessageStore) MarkDelivered(ctx context.Context, messageIDs []string, deliveredAt time.Time) error {
+	if len(messageIDs) == 0 {
+		return nil
+	}
+	_, err := s.messages.UpdateMany(ctx,
+		bson.M{"_id": bson.M{"$in": messageIDs}},
+		bson.M{"$set": bson.M{"delivered_at": deliveredAt}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to mark trip messages delivered: %w", err)
+	}
+	return nil
+}
+
+// DeleteTripMessages removes every message of the trips
+func (s *MongoTripMessageStore) DeleteTripMessages(ctx context.Context, tripIDs []string) error {
+	if len(tripIDs) == 0 {
+		return nil
+	}
+	if _, err := s.messages.DeleteMany(ctx, bson.M{"trip_id": bson.M{"$in": tripIDs}}); err != nil {
+		return fmt.Errorf("failed to delete trip messages: %w", err)
+	}
+	return nil
+}
+
+func (s *MongoTripMessageStore) find(ctx context.Context, filter bson.M) ([]*types.TripMessage, error) {
+	cursor, err := s.messages.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find trip messages: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var messages []*types.TripMessage
+	if err := cursor.All(ctx, &messages); err != nil {
+		return nil, fmt.Errorf("failed to decode trip messages: %w", err)
+	}
+	return messages, nil
+}
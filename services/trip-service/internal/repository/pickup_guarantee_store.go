@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+)
+
+// MemoryPickupGuaranteeStore implements PickupGuaranteeStore in memory,
+// storing copies so callers cannot mutate saved guarantees
+type MemoryPickupGuaranteeStore struct {
+	guarantees map[string][]byte
+	mutex      sync.RWMutex
+}
+
+// NewMemoryPickupGuaranteeStore creates a new in-memory pickup guarantee store
+func NewMemoryPickupGuaranteeStore() *MemoryPickupGuaranteeStore {
+	return &MemoryPickupGuaranteeStore{
+		guarantees: make(map[string][]byte),
+	}
+}
+
+// SaveGuarantee saves a copy of a trip's guarantee
+func (m *MemoryPickupGuaranteeStore) SaveGuarantee(ctx context.Context, guarantee *types.PickupGuarantee) error {
+	data, err := json.Marshal(guarantee)
+	if err != nil {
+		return fmt.Errorf("failed to marshal pickup guarantee: %w", err)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.guarantees[guarantee.TripID] = data
+	return nil
+}
+
+// GetGuarantee retrieves a copy of a trip's guarantee
+func (m *MemoryPickupGuaranteeStore) GetGuarantee(ctx context.Context, tripID string) (*types.PickupGuarantee, error) {
+	m.mutex.RLock()
+	data, exists := m.guarantees[tripID]
+	m.mutex.RUnlock()
+
+	if !exists {
+		return nil, types.ErrPickupGuaranteeNotFound
+	}
+	return decodeGuarantee(data)
+}
+
+// ListGuarantees retrieves the guarantees matching filter, oldest first
+func (m *MemoryPickupGuaranteeStore) ListGuarantees(ctx context.Context, filter types.PickupGuaranteeFilter) ([]*types.PickupGuarantee, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var guarantees []*types.PickupGuarantee
+	for _, data := range m.guarantees {
+		guarantee, err := decodeGuarantee(data)
+		if err != nil {
+			return nil, err
+		}
+		if (filter.Status == "" || guarantee.Status == filter.Status) &&
+			(filter.DriverID == "" || guarantee.DriverID == filter.DriverID) &&
+			(filter.CityID == "" || guarantee.CityID == filter.CityID) &&
+			(filter.From.IsZero() || !guarantee.MatchedAt.Before(filter.From)) &&
+			(filter.To.IsZero() || guarantee.MatchedAt.Before(filter.To)) {
+			guarantees = append(guarantees, guarantee)
+		}
+	}
+
+	sort.Slice(guarantees, func(i, j int) bool {
+		return guarantees[i].MatchedAt.Before(guarantees[j].MatchedAt)
+	})
+	return guarantees, nil
+}
+
+func decodeGuarantee(data []byte) (*types.PickupGuarantee, error) {
+	var guarantee types.PickupGuarantee
+	if err := json.Unmarshal(data, &guarantee); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal pickup guarantee: %w", err)
+	}
+	return &guarantee, nil
+}
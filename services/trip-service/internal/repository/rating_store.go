@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+)
+
+// MemoryRatingStore implements RatingStore in memory, storing copies so
+// callers cannot mutate saved records
+type MemoryRatingStore struct {
+	trips     map[string][]byte
+	ratings   map[string][]byte
+	summaries map[string][]byte
+	mutex     sync.RWMutex
+}
+
+// NewMemoryRatingStore creates a new in-memory rating store
+func NewMemoryRatingStore() *MemoryRatingStore {
+	return &MemoryRatingStore{
+		trips:     make(map[string][]byte),
+		ratings:   make(map[string][]byte),
+		summaries: make(map[string][]byte),
+	}
+}
+
+// SaveCompletedTrip saves a copy of a completed trip
+func (m *MemoryRatingStore) SaveCompletedTrip(ctx context.Context, trip *types.CompletedTrip) error {
+	data, err := json.Marshal(trip)
+	if err != nil {
+		return fmt.Errorf("failed to marshal completed trip: %w", err)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.trips[trip.TripID] = data
+	return nil
+}
+
+// GetCompletedTrip retrieves a copy of a completed trip
+func (m *MemoryRatingStore) GetCompletedTrip(ctx context.Context, tripID string) (*types.CompletedTrip, error) {
+	m.mutex.RLock()
+	data, exists := m.trips[tripID]
+	m.mutex.RUnlock()
+
+	if !exists {
+		return nil, types.ErrCompletedTripNotFound
+	}
+
+	var trip types.CompletedTrip
+	if err := json.Unmarshal(data, &trip); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal completed trip: %w", err)
+	}
+	return &trip, nil
+}
+
+// SaveRating saves a copy of a rating. Each trip can be rated once from each side.
+func (m *MemoryRatingStore) SaveRating(ctx context.Context, rating *types.Rating) error {
+	data, err := json.Marshal(rating)
+	if err != nil {
+		return fmt.Errorf("failed to marshal rating: %w", err)
+	}
+
+	key := ratingKey(rating.TripID, rating.RaterRole)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if _, exists := m.ratings[key]; exists {
+		return types.ErrDuplicateRating
+	}
+	m.ratings[key] = data
+	return nil
+}
+
+// GetRatings retrieves the most recent ratings received by a user in a role,
+// newest first. A limit of zero or less returns every rating.
+func (m *MemoryRatingStore) GetRatings(ctx context.Context, rateeID string, role types.RaterRole, limit int) ([]*types.Rating, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var ratings []*types.Rating
+	for _, data := range m.ratings {
+		var rating types.Rating
+		if err := json.Unmarshal(data, &rating); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal rating: %w", err)
+		}
+		if rating.RateeID == rateeID && rating.RaterRole.Ratee() == role {
+			ratings = append(ratings, &rating)
+		}
+	}
+
+	sort.Slice(ratings, func(i, j int) bool {
+		return ratings[i].CreatedAt.After(ratings[j].CreatedAt)
+	})
+	if limit > 0 && len(ratings) > limit {
+		ratings = ratings[:limit]
+	}
+	return ratings, nil
+}
+
+// SaveRatingSummary saves a copy of a user's rating summary
+func (m *MemoryRatingStore) SaveRatingSummary(ctx context.Context, summary *types.RatingSummary) error {
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return fmt.Errorf("failed to marshal rating summary: %w", err)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.summaries[summaryKey(summary.UserID, summary.Role)] = data
+	return nil
+}
+
+// GetRatingSummary retrieves a copy of a user's rating summary
+func (m *MemoryRatingStore) GetRatingSummary(ctx context.Context, userID string, role types.RaterRole) (*types.RatingSummary, error) {
+	m.mutex.RLock()
+	data, exists := m.summaries[summaryKey(userID, role)]
+	m.mutex.RUnlock()
+
+	if !exists {
+		return nil, types.ErrRatingSummaryNotFound
+	}
+
+	var summary types.RatingSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal rating summary: %w", err)
+	}
+	return &summary, nil
+}
+
+func ratingKey(tripID string, role types.RaterRole) string {
+	return tripID + ":" + string(role)
+}
+
+func summaryKey(userID string, role types.RaterRole) string {
+	return string(role) + ":" + userID
+}
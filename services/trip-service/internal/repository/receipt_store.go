@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+)
+
+// MemoryReceiptStore implements ReceiptStore in memory, keyed by trip ID
+type MemoryReceiptStore struct {
+	receipts map[string][]byte
+	mutex    sync.RWMutex
+}
+
+// NewMemoryReceiptStore creates a new in-memory receipt store
+func NewMemoryReceiptStore() *MemoryReceiptStore {
+	return &MemoryReceiptStore{
+		receipts: make(map[string][]byte),
+	}
+}
+
+// SaveReceipt saves a copy of a trip receipt
+func (m *MemoryReceiptStore) SaveReceipt(ctx context.Context, receipt *types.Receipt) error {
+	data, err := json.Marshal(receipt)
+	if err != nil {
+		return fmt.Errorf("failed to marshal receipt: %w", err)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.receipts[receipt.TripID] = data
+	return nil
+}
+
+// GetReceipt retrieves a copy of a trip's receipt
+func (m *MemoryReceiptStore) GetReceipt(ctx context.Context, tripID string) (*types.Receipt, error) {
+	m.mutex.RLock()
+	data, exists := m.receipts[tripID]
+	m.mutex.RUnlock()
+
+	if !exists {
+		return nil, types.ErrReceiptNotFound
+	}
+
+	var receipt types.Receipt
+	if err := json.Unmarshal(data, &receipt); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal receipt: %w", err)
+	}
+	return &receipt, nil
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+)
+
+// MemoryReconciliationStore implements ReconciliationReportStore in memory
+type MemoryReconciliationStore struct {
+	reports map[string][]byte
+	order   []string
+	mutex   sync.RWMutex
+}
+
+// NewMemoryReconciliationStore creates a new in-memory reconciliation report store
+func NewMemoryReconciliationStore() *MemoryReconciliationStore {
+	return &MemoryReconciliationStore{
+		reports: make(map[string][]byte),
+	}
+}
+
+// SaveReport saves a copy of a reconciliation report
+func (m *MemoryReconciliationStore) SaveReport(ctx context.Context, report *types.ReconciliationReport) error {
+	data, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to marshal reconciliation report: %w", err)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if _, exists := m.reports[report.ID]; !exists {
+		m.order = append(m.order, report.ID)
+	}
+	m.reports[report.ID] = data
+	return nil
+}
+
+// GetReport retrieves a copy of a reconciliation report
+func (m *MemoryReconciliationStore) GetReport(ctx context.Context, reportID string) (*types.ReconciliationReport, error) {
+	m.mutex.RLock()
+	data, exists := m.reports[reportID]
+	m.mutex.RUnlock()
+
+	if !exists {
+		return nil, types.ErrReconciliationReportNotFound
+	}
+	return unmarshalReport(data)
+}
+
+// ListReports returns copies of the most recently saved reports, newest first
+func (m *MemoryReconciliationStore) ListReports(ctx context.Context, limit int) ([]*types.ReconciliationReport, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	reports := make([]*types.ReconciliationReport, 0, min(limit, len(m.order)))
+	for i := len(m.order) - 1; i >= 0 && len(reports) < limit; i-- {
+		report, err := unmarshalReport(m.reports[m.order[i]])
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+func unmarshalReport(data []byte) (*types.ReconciliationReport, error) {
+	var report types.ReconciliationReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal reconciliation report: %w", err)
+	}
+	return &report, nil
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+)
+
+// RedisTripIdempotencyStore implements TripIdempotencyStore in Redis, so
+// retries that reach another replica or arrive after a restart still find
+// the trip they created. Each key is saved with SET NX and expires with the
+// key's ExpiresAt, so Redis forgets it without a sweep.
+type RedisTripIdempotencyStore struct {
+	client redis.UniversalClient
+	now    func() time.Time
+}
+
+// NewRedisTripIdempotencyStore creates a new Redis-backed idempotency key store
+func NewRedisTripIdempotencyStore(client redis.UniversalClient) *RedisTripIdempotencyStore {
+	return &RedisTripIdempotencyStore{client: client, now: time.Now}
+}
+
+func redisIdempotencyKey(riderID, key string) string {
+	return "trip_idempotency:" + idempotencyStoreKey(riderID, key)
+}
+
+// ReserveIdempotencyKey saves key with SET NX unless the rider's key is
+// already saved, in which case the saved key is returned
+func (r *RedisTripIdempotencyStore) ReserveIdempotencyKey(ctx context.Context, key *types.TripIdempotencyKey) (*types.TripIdempotencyKey, bool, error) {
+	data, err := json.Marshal(key)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to marshal idempotency key: %w", err)
+	}
+	ttl := key.ExpiresAt.Sub(r.now())
+	if ttl <= 0 {
+		return nil, false, fmt.Errorf("idempotency key %s has already expired", key.Key)
+	}
+	id := redisIdempotencyKey(key.RiderID, key.Key)
+
+	// The saved key can expire between SET NX and GET, so a miss is retried once
+	for attempt := 0; attempt < 2; attempt++ {
+		reserved, err := r.client.SetNX(ctx, id, data, ttl).Result()
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+		}
+		if reserved {
+			saved := *key
+			return &saved, true, nil
+		}
+
+		saved, err := r.get(ctx, id)
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		return saved, false, nil
+	}
+	return nil, false, fmt.Errorf("idempotency key %s expired while it was being reserved", key.Key)
+}
+
+// CompleteIdempotencyKey records the trip a reserved key created, keeping
+// the key's expiry
+func (r *RedisTripIdempotencyStore) CompleteIdempotencyKey(ctx context.Context, riderID, key, tripID string) error {
+	id := redisIdempotencyKey(riderID, key)
+	saved, err := r.get(ctx, id)
+	if errors.Is(err, redis.Nil) {
+		return fmt.Errorf("idempotency key %s is not reserved", key)
+	}
+	if err != nil {
+		return err
+	}
+
+	saved.TripID = tripID
+	data, err := json.Marshal(saved)
+	if err != nil {
+		return fmt.Errorf("failed to marshal idempotency key: %w", err)
+	}
+	updated, err := r.client.SetXX(ctx, id, data, redis.KeepTTL).Result()
+	if err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+	if !updated {
+		return fmt.Errorf("idempotency key %s is not reserved", key)
+	}
+	return nil
+}
+
+// ReleaseIdempotencyKey forgets a reserved key
+func (r *RedisTripIdempotencyStore) ReleaseIdempotencyKey(ctx context.Context, riderID, key string) error {
+	if err := r.client.Del(ctx, redisIdempotencyKey(riderID, key)).Err(); err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// get returns the key saved under id, or redis.Nil when there is none
+func (r *RedisTripIdempotencyStore) get(ctx context.Context, id string) (*types.TripIdempotencyKey, error) {
+	data, err := r.client.Get(ctx, id).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+	var saved types.TripIdempotencyKey
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal idempotency key: %w", err)
+	}
+	return &saved, nil
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+)
+
+// MemoryScheduledRideStore implements ScheduledRideStore in memory, storing
+// copies so callers cannot mutate saved rides
+type MemoryScheduledRideStore struct {
+	rides map[string][]byte
+	mutex sync.RWMutex
+}
+
+// NewMemoryScheduledRideStore creates a new in-memory scheduled ride store
+func NewMemoryScheduledRideStore() *MemoryScheduledRideStore {
+	return &MemoryScheduledRideStore{
+		rides: make(map[string][]byte),
+	}
+}
+
+// SaveScheduledRide saves a copy of the scheduled ride
+func (m *MemoryScheduledRideStore) SaveScheduledRide(ctx context.Context, ride *types.ScheduledRide) error {
+	data, err := json.Marshal(ride)
+	if err != nil {
+		return fmt.Errorf("failed to marshal scheduled ride: %w", err)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.rides[ride.ID] = data
+	return nil
+}
+
+// GetScheduledRide retrieves a copy of a scheduled ride by ID
+func (m *MemoryScheduledRideStore) GetScheduledRide(ctx context.Context, rideID string) (*types.ScheduledRide, error) {
+	m.mutex.RLock()
+	data, exists := m.rides[rideID]
+	m.mutex.RUnlock()
+
+	if !exists {
+		return nil, types.ErrScheduledRideNotFound
+	}
+	return decodeScheduledRide(data)
+}
+
+// GetScheduledRidesByRider retrieves a rider's scheduled rides ordered by pickup time
+func (m *MemoryScheduledRideStore) GetScheduledRidesByRider(ctx context.Context, riderID string) ([]*types.ScheduledRide, error) {
+	return m.filter(func(ride *types.ScheduledRide) bool {
+		return ride.RiderID == riderID
+	})
+}
+
+// GetDueScheduledRides retrieves rides still waiting to be dispatched whose
+// dispatch time has been reached, ordered by pickup time
+func (m *MemoryScheduledRideStore) GetDueScheduledRides(ctx context.Context, now time.Time) ([]*types.ScheduledRide, error) {
+	return m.filter(func(ride *types.ScheduledRide) bool {
+		return ride.Status == types.ScheduledRideStatusScheduled && !ride.DispatchAt.After(now)
+	})
+}
+
+func (m *MemoryScheduledRideStore) filter(match func(*types.ScheduledRide) bool) ([]*types.ScheduledRide, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var rides []*types.ScheduledRide
+	for _, data := range m.rides {
+		ride, err := decodeScheduledRide(data)
+		if err != nil {
+			return nil, err
+		}
+		if match(ride) {
+			rides = append(rides, ride)
+		}
+	}
+
+	sort.Slice(rides, func(i, j int) bool {
+		return rides[i].ScheduledFor.Before(rides[j].ScheduledFor)
+	})
+	return rides, nil
+}
+
+func decodeScheduledRide(data []byte) (*types.ScheduledRide, error) {
+	var ride types.ScheduledRide
+	if err := json.Unmarshal(data, &ride); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal scheduled ride: %w", err)
+	}
+	return &ride, nil
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+)
+
+// MemorySharedTripStore implements SharedTripStore in memory, storing copies so
+// callers cannot mutate saved trips
+type MemorySharedTripStore struct {
+	trips map[string][]byte
+	mutex sync.RWMutex
+}
+
+// NewMemorySharedTripStore creates a new in-memory shared trip store
+func NewMemorySharedTripStore() *MemorySharedTripStore {
+	return &MemorySharedTripStore{
+		trips: make(map[string][]byte),
+	}
+}
+
+// SaveSharedTrip saves a copy of the shared trip
+func (m *MemorySharedTripStore) SaveSharedTrip(ctx context.Context, trip *types.SharedTrip) error {
+	data, err := json.Marshal(trip)
+	if err != nil {
+		return fmt.Errorf("failed to marshal shared trip: %w", err)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.trips[trip.ID] = data
+	return nil
+}
+
+// GetSharedTrip retrieves a copy of a shared trip by ID
+func (m *MemorySharedTripStore) GetSharedTrip(ctx context.Context, sharedTripID string) (*types.SharedTrip, error) {
+	m.mutex.RLock()
+	data, exists := m.trips[sharedTripID]
+	m.mutex.RUnlock()
+
+	if !exists {
+		return nil, types.ErrSharedTripNotFound
+	}
+	return decodeSharedTrip(data)
+}
+
+// GetActiveSharedTrips retrieves all shared trips that still have stops to serve
+func (m *MemorySharedTripStore) GetActiveSharedTrips(ctx context.Context) ([]*types.SharedTrip, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var active []*types.SharedTrip
+	for _, data := range m.trips {
+		trip, err := decodeSharedTrip(data)
+		if err != nil {
+			return nil, err
+		}
+		if trip.Status == types.SharedTripStatusActive {
+			active = append(active, trip)
+		}
+	}
+	return active, nil
+}
+
+func decodeSharedTrip(data []byte) (*types.SharedTrip, error) {
+	var trip types.SharedTrip
+	if err := json.Unmarshal(data, &trip); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal shared trip: %w", err)
+	}
+	return &trip, nil
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+)
+
+// MemoryTripListingStore implements TripListingStore in memory, storing
+// copies so callers cannot mutate saved listings
+type MemoryTripListingStore struct {
+	listings   map[string][]byte
+	checkpoint int64
+	mutex      sync.RWMutex
+}
+
+// NewMemoryTripListingStore creates a new in-memory trip listing store
+func NewMemoryTripListingStore() *MemoryTripListingStore {
+	return &MemoryTripListingStore{
+		listings: make(map[string][]byte),
+	}
+}
+
+// SaveListing saves a copy of the listing
+func (m *MemoryTripListingStore) SaveListing(ctx context.Context, listing *types.TripListing) error {
+	data, err := json.Marshal(listing)
+	if err != nil {
+		return fmt.Errorf("failed to marshal trip listing: %w", err)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.listings[listing.TripID] = data
+	return nil
+}
+
+// DeleteListing removes a trip's listing. Trips without one are skipped.
+func (m *MemoryTripListingStore) DeleteListing(ctx context.Context, tripID string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	delete(m.listings, tripID)
+	return nil
+}
+
+// ListListings retrieves the listings matching filter, most recently
+// requested first
+func (m *MemoryTripListingStore) ListListings(ctx context.Context, filter types.TripListingFilter) ([]*types.TripListing, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	listings := []*types.TripListing{}
+	for _, data := range m.listings {
+		var listing types.TripListing
+		if err := json.Unmarshal(data, &listing); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal trip listing: %w", err)
+		}
+		if (filter.RiderID == "" || listing.RiderID == filter.RiderID) &&
+			(filter.DriverID == "" || listing.DriverID == filter.DriverID) &&
+			(filter.Status == "" || listing.Status == filter.Status) {
+			listings = append(listings, &listing)
+		}
+	}
+
+	sort.Slice(listings, func(i, j int) bool {
+		return listings[i].RequestedAt.After(listings[j].RequestedAt)
+	})
+	return listings, nil
+}
+
+// GetCheckpoint returns the log position of the last event projected
+func (m *MemoryTripListingStore) GetCheckpoint(ctx context.Context) (int64, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.checkpoint, nil
+}
+
+// SaveCheckpoint records the log position of the last event projected
+func (m *MemoryTripListingStore) SaveCheckpoint(ctx context.Context, position int64) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.checkpoint = position
+	return nil
+}
+
+// Reset removes every listing and the checkpoint
+func (m *MemoryTripListingStore) Reset(ctx context.Context) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.listings = make(map[string][]byte)
+	m.checkpoint = 0
+	return nil
+}
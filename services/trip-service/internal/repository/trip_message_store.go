@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+)
+
+// MemoryTripMessageStore implements TripMessageStore in memory, storing
+// copies so callers cannot mutate saved messages
+type MemoryTripMessageStore struct {
+	messages map[string][]byte
+	mutex    sync.RWMutex
+}
+
+// NewMemoryTripMessageStore creates a new in-memory trip message store
+func NewMemoryTripMessageStore() *MemoryTripMessageStore {
+	return &MemoryTripMessageStore{
+		messages: make(map[string][]byte),
+	}
+}
+
+// SaveMessage saves a copy of the message
+func (m *MemoryTripMessageStore) SaveMessage(ctx context.Context, message *types.TripMessage) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal trip message: %w", err)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.messages[message.ID] = data
+	return nil
+}
+
+// ListTripMessages retrieves a trip's messages, oldest first
+func (m *MemoryTripMessageStore) ListTripMessages(ctx context.Context, tripID string) ([]*types.TripMessage, error) {
+	return m.filter(func(message *types.TripMessage) bool {
+		return message.TripID == tripID
+	})
+}
+
+// UndeliveredMessages retrieves the trip's undelivered messages to a recipient, oldest first
+func (m *MemoryTripMessageStore) UndeliveredMessages(ctx context.Context, tripID, recipientID string) ([]*types.TripMessage, error) {
+	return m.filter(func(message *types.TripMessage) bool {
+		return message.TripID == tripID && message.RecipientID == recipientID && message.DeliveredAt == nil
+	})
+}
+
+// MarkDelivered records when messages were delivered
+func (m *MemoryTripMessageStore) MarkDelivered(ctx context.Context, messageIDs []string, deliveredAt time.Time) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, id := range messageIDs {
+		data, exists := m.messages[id]
+		if !exists {
+			continue
+		}
+		message, err := decodeTripMessage(data)
+		if err != nil {
+			return err
+		}
+		message.DeliveredAt = &deliveredAt
+		if data, err = json.Marshal(message); err != nil {
+			return fmt.Errorf("failed to marshal trip message: %w", err)
+		}
+		m.messages[id] = data
+	}
+	return nil
+}
+
+// DeleteTripMessages removes every message of the trips
+func (m *MemoryTripMessageStore) DeleteTripMessages(ctx context.Context, tripIDs []string) error {
+	trips := make(map[string]bool, len(tripIDs))
+	for _, id := range tripIDs {
+		trips[id] = true
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for id, data := range m.messages {
+		message, err := decodeTripMessage(data)
+		if err != nil {
+			return err
+		}
+		if trips[message.TripID] {
+			delete(m.messages, id)
+		}
+	}
+	return nil
+}
+
+func (m *MemoryTripMessageStore) filter(match func(*types.TripMessage) bool) ([]*types.TripMessage, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var messages []*types.TripMessage
+	for _, data := range m.messages {
+		message, err := decodeTripMessage(data)
+		if err != nil {
+			return nil, err
+		}
+		if match(message) {
+			messages = append(messages, message)
+		}
+	}
+
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].CreatedAt.Before(messages[j].CreatedAt)
+	})
+	return messages, nil
+}
+
+func decodeTripMessage(data []byte) (*types.TripMessage, error) {
+	var message types.TripMessage
+	if err := json.Unmarshal(data, &message); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal trip message: %w", err)
+	}
+	return &message, nil
+}
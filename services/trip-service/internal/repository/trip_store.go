@@ -0,0 +1,243 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/export"
+	"github.com/rideshare-platform/shared/models"
+)
+
+// MemoryTripStore keeps trips in memory, storing copies so callers cannot
+// mutate saved trips
+type MemoryTripStore struct {
+	trips map[string][]byte
+	mutex sync.RWMutex
+}
+
+// NewMemoryTripStore creates a new in-memory trip store
+func NewMemoryTripStore() *MemoryTripStore {
+	return &MemoryTripStore{
+		trips: make(map[string][]byte),
+	}
+}
+
+// Create saves a new trip
+func (m *MemoryTripStore) Create(ctx context.Context, trip *models.Trip) error {
+	data, err := json.Marshal(trip)
+	if err != nil {
+		return fmt.Errorf("failed to marshal trip: %w", err)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if _, exists := m.trips[trip.ID]; exists {
+		return fmt.Errorf("trip %s already exists", trip.ID)
+	}
+	m.trips[trip.ID] = data
+	return nil
+}
+
+// GetByID retrieves a copy of a trip
+func (m *MemoryTripStore) GetByID(ctx context.Context, id string) (*models.Trip, error) {
+	m.mutex.RLock()
+	data, exists := m.trips[id]
+	m.mutex.RUnlock()
+
+	if !exists {
+		return nil, types.ErrTripNotFound
+	}
+	return decodeTrip(data)
+}
+
+// Update replaces a saved trip
+func (m *MemoryTripStore) Update(ctx context.Context, trip *models.Trip) error {
+	data, err := json.Marshal(trip)
+	if err != nil {
+		return fmt.Errorf("failed to marshal trip: %w", err)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if _, exists := m.trips[trip.ID]; !exists {
+		return types.ErrTripNotFound
+	}
+	m.trips[trip.ID] = data
+	return nil
+}
+
+// GetByRiderID retrieves a rider's trips, most recent first
+func (m *MemoryTripStore) GetByRiderID(ctx context.Context, riderID string) ([]*models.Trip, error) {
+	return m.filter(func(trip *models.Trip) bool {
+		return trip.RiderID == riderID
+	})
+}
+
+// GetByDriverID retrieves a driver's trips, most recent first
+func (m *MemoryTripStore) GetByDriverID(ctx context.Context, driverID string) ([]*models.Trip, error) {
+	return m.filter(func(trip *models.Trip) bool {
+		return trip.DriverID != nil && *trip.DriverID == driverID
+	})
+}
+
+// GetByStatus retrieves the trips in a status, most recent first
+func (m *MemoryTripStore) GetByStatus(ctx context.Context, status models.TripStatus) ([]*models.Trip, error) {
+	return m.filter(func(trip *models.Trip) bool {
+		return trip.Status == status
+	})
+}
+
+// ChangedSince returns up to limit trips updated after the watermark, in
+// export order
+func (m *MemoryTripStore) ChangedSince(ctx context.Context, since export.Watermark, limit int) ([]*models.Trip, error) {
+	trips, err := m.filter(func(trip *models.Trip) bool {
+		return since.Before(trip.UpdatedAt, trip.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(trips, func(i, j int) bool {
+		at := export.Watermark{UpdatedAt: trips[i].UpdatedAt, ID: trips[i].ID}
+		return at.Before(trips[j].UpdatedAt, trips[j].ID)
+	})
+	if len(trips) > limit {
+		trips = trips[:limit]
+	}
+	return trips, nil
+}
+
+// EndedBefore returns up to limit trips that ended, were last updated before
+// cutoff and have IDs after afterID, in ID order
+func (m *MemoryTripStore) EndedBefore(ctx context.Context, cutoff time.Time, afterID string, limit int) ([]*models.Trip, error) {
+	trips, err := m.filter(func(trip *models.Trip) bool {
+		return !trip.IsActive() && trip.UpdatedAt.Before(cutoff) && trip.ID > afterID
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(trips, func(i, j int) bool {
+		return trips[i].ID < trips[j].ID
+	})
+	if len(trips) > limit {
+		trips = trips[:limit]
+	}
+	return trips, nil
+}
+
+// Delete removes trips. Trips that do not exist are skipped.
+func (m *MemoryTripStore) Delete(ctx context.Context, ids []string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	for _, id := range ids {
+		delete(m.trips, id)
+	}
+	return nil
+}
+
+// Restore saves a trip brought back from the archive. A trip that is
+// already in the store, rehydrated earlier, is kept as it is.
+func (m *MemoryTripStore) Restore(ctx context.Context, trip *models.Trip) error {
+	data, err := json.Marshal(trip)
+	if err != nil {
+		return fmt.Errorf("failed to marshal trip: %w", err)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if _, exists := m.trips[trip.ID]; !exists {
+		m.trips[trip.ID] = data
+	}
+	return nil
+}
+
+func (m *MemoryTripStore) filter(match func(*models.Trip) bool) ([]*models.Trip, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	trips := []*models.Trip{}
+	for _, data := range m.trips {
+		trip, err := decodeTrip(data)
+		if err != nil {
+			return nil, err
+		}
+		if match(trip) {
+			trips = append(trips, trip)
+		}
+	}
+
+	sort.Slice(trips, func(i, j int) bool {
+		return trips[i].RequestedAt.After(trips[j].RequestedAt)
+	})
+	return trips, nil
+}
+
+func decodeTrip(data []byte) (*models.Trip, error) {
+	var trip models.Trip
+	if err := json.Unmarshal(data, &trip); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal trip: %w", err)
+	}
+	return &trip, nil
+}
+
+// MemoryTripEventLog keeps each trip's lifecycle events in memory
+type MemoryTripEventLog struct {
+	events map[string][]*models.TripEvent
+	// log holds every trip's events in the order they were recorded; an
+	// event's position is its index plus one
+	log   []*models.TripEvent
+	mutex sync.RWMutex
+}
+
+// NewMemoryTripEventLog creates a new in-memory trip event log
+func NewMemoryTripEventLog() *MemoryTripEventLog {
+	return &MemoryTripEventLog{
+		events: make(map[string][]*models.TripEvent),
+	}
+}
+
+// SaveEvent appends an event to its trip's log
+func (m *MemoryTripEventLog) SaveEvent(ctx context.Context, event *models.TripEvent) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.events[event.TripID] = append(m.events[event.TripID], event)
+	m.log = append(m.log, event)
+	return nil
+}
+
+// GetTripEvents returns a trip's events in the order they happened
+func (m *MemoryTripEventLog) GetTripEvents(ctx context.Context, tripID string) ([]*models.TripEvent, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return append([]*models.TripEvent{}, m.events[tripID]...), nil
+}
+
+// EventsAfter returns up to limit events recorded after position, in the
+// order they were recorded
+func (m *MemoryTripEventLog) EventsAfter(ctx context.Context, position int64, limit int) ([]types.LoggedTripEvent, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	if position < 0 {
+		position = 0
+	}
+	events := []types.LoggedTripEvent{}
+	for index := position; index < int64(len(m.log)) && len(events) < limit; index++ {
+		events = append(events, types.LoggedTripEvent{Position: index + 1, Event: m.log[index]})
+	}
+	return events, nil
+}
+
+// LatestPosition returns the position of the last event recorded, 0 before
+// any were
+func (m *MemoryTripEventLog) LatestPosition(ctx context.Context) (int64, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return int64(len(m.log)), nil
+}
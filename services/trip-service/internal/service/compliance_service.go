@@ -0,0 +1,417 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/city"
+	"github.com/rideshare-platform/shared/currency"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+)
+
+var (
+	// ErrComplianceTemplateNotFound is returned for a compliance report
+	// template that is not configured
+	ErrComplianceTemplateNotFound = errors.New("compliance report template not found")
+	// ErrInvalidComplianceRequest is returned for compliance reports that
+	// cannot be generated, such as ones for periods that have not ended
+	ErrInvalidComplianceRequest = errors.New("invalid compliance report request")
+)
+
+const (
+	// complianceReportContentType is the format compliance reports are generated in
+	complianceReportContentType = "text/csv"
+	// suppressedComplianceZone replaces zones with too few trips to report
+	suppressedComplianceZone = "suppressed"
+	// complianceRefLength is how many hex characters of a hashed ID are reported
+	complianceRefLength = 16
+)
+
+// ComplianceService generates the trip data reports cities require from the
+// platform, one per template and reporting period, and keeps them so
+// historical reports can be downloaded again. Reports only cover completed
+// trips and are anonymized by the template's rules before they are saved.
+type ComplianceService struct {
+	trips     TripRepositoryInterface
+	cities    *city.Registry
+	templates ComplianceTemplates
+	store     types.ComplianceReportStore
+	logger    *logger.Logger
+
+	// hashSecret salts the trip and driver IDs reported
+	hashSecret []byte
+
+	// mutex allows one report to be generated at a time
+	mutex sync.Mutex
+}
+
+// NewComplianceService creates a new compliance report service. IDs are
+// hashed with a random secret until SetHashSecret is called, so they are
+// only consistent across reports generated by the same process.
+func NewComplianceService(trips TripRepositoryInterface, cities *city.Registry, templates ComplianceTemplates, store types.ComplianceReportStore, logger *logger.Logger) *ComplianceService {
+	secret := make([]byte, 32)
+	rand.Read(secret)
+
+	return &ComplianceService{
+		trips:      trips,
+		cities:     cities,
+		templates:  templates,
+		store:      store,
+		logger:     logger,
+		hashSecret: secret,
+	}
+}
+
+// SetHashSecret sets the secret trip and driver IDs are hashed with, which
+// keeps a trip's reference the same across reports and restarts
+func (s *ComplianceService) SetHashSecret(secret string) {
+	if secret != "" {
+		s.hashSecret = []byte(secret)
+	}
+}
+
+// Templates returns the configured templates ordered by city and ID
+func (s *ComplianceService) Templates() []*ComplianceTemplate {
+	templates := make([]*ComplianceTemplate, 0, len(s.templates))
+	for _, template := range s.templates {
+		templates = append(templates, template)
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		if templates[i].CityID != templates[j].CityID {
+			return templates[i].CityID < templates[j].CityID
+		}
+		return templates[i].ID < templates[j].ID
+	})
+	return templates
+}
+
+// GenerateReport generates a template's report for the period containing
+// date (YYYY-MM-DD in the city's timezone), which must have ended. A period
+// that was already reported gets a new report; the earlier one is kept.
+func (s *ComplianceService) GenerateReport(ctx context.Context, templateID, date, generatedBy string) (*types.ComplianceReport, error) {
+	template, exists := s.templates[templateID]
+	if !exists {
+		return nil, ErrComplianceTemplateNotFound
+	}
+	location := s.location(template)
+
+	day, err := time.ParseInLocation(time.DateOnly, date, location)
+	if err != nil {
+		return nil, fmt.Errorf("%w: date must be YYYY-MM-DD", ErrInvalidComplianceRequest)
+	}
+	start, end := template.period(day)
+	if end.After(time.Now()) {
+		return nil, fmt.Errorf("%w: the %s period starting %s has not ended", ErrInvalidComplianceRequest, template.Schedule, start.Format(time.DateOnly))
+	}
+
+	if generatedBy == "" {
+		generatedBy = "system"
+	}
+	return s.generate(ctx, template, start, end, generatedBy)
+}
+
+// GenerateDue generates each template's report for its last complete
+// period unless it was already generated, and returns how many it generated
+func (s *ComplianceService) GenerateDue(ctx context.Context, now time.Time) (int, error) {
+	generated := 0
+	for _, template := range s.Templates() {
+		start, end := template.lastCompletePeriod(now.In(s.location(template)))
+		_, err := s.store.FindReport(ctx, template.ID, start)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, types.ErrComplianceReportNotFound) {
+			return generated, fmt.Errorf("failed to look up %s report: %w", template.ID, err)
+		}
+
+		if _, err := s.generate(ctx, template, start, end, "system"); err != nil {
+			return generated, fmt.Errorf("failed to generate %s report: %w", template.ID, err)
+		}
+		generated++
+	}
+	return generated, nil
+}
+
+// Start generates the reports that have come due every interval until the
+// context is cancelled
+func (s *ComplianceService) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			generated, err := s.GenerateDue(ctx, time.Now())
+			if err != nil && ctx.Err() == nil {
+				s.logger.WithContext(ctx).WithError(err).Warn("Scheduled compliance reporting failed")
+			}
+			if generated > 0 {
+				s.logger.WithContext(ctx).WithFields(logger.Fields{"generated": generated}).Info("Compliance reports generated")
+			}
+		}
+	}
+}
+
+// GetReport returns a compliance report with its content
+func (s *ComplianceService) GetReport(ctx context.Context, reportID string) (*types.ComplianceReport, error) {
+	return s.store.GetReport(ctx, reportID)
+}
+
+// ListReports returns the most recent compliance reports, newest first
+func (s *ComplianceService) ListReports(ctx context.Context, filter types.ComplianceReportFilter) ([]*types.ComplianceReport, error) {
+	if filter.CityID != "" {
+		filter.CityID = city.Normalize(filter.CityID)
+	}
+	return s.store.ListReports(ctx, filter)
+}
+
+// generate reports the trips completed in the template's city in
+// [start, end) and saves the report
+func (s *ComplianceService) generate(ctx context.Context, template *ComplianceTemplate, start, end time.Time, generatedBy string) (*types.ComplianceReport, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	completed, err := s.trips.GetByStatus(ctx, models.TripStatusCompleted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list completed trips: %w", err)
+	}
+
+	var trips []*models.Trip
+	for _, trip := range completed {
+		if trip.CityID == template.CityID && trip.CompletedAt != nil && !trip.CompletedAt.Before(start) && trip.CompletedAt.Before(end) {
+			trips = append(trips, trip)
+		}
+	}
+	sort.Slice(trips, func(i, j int) bool {
+		if !trips[i].CompletedAt.Equal(*trips[j].CompletedAt) {
+			return trips[i].CompletedAt.Before(*trips[j].CompletedAt)
+		}
+		return trips[i].ID < trips[j].ID
+	})
+
+	report := &types.ComplianceReport{
+		ID:           generateComplianceReportID(),
+		TemplateID:   template.ID,
+		TemplateName: template.Name,
+		CityID:       template.CityID,
+		Schedule:     template.Schedule,
+		PeriodStart:  start,
+		PeriodEnd:    end,
+		Timezone:     start.Location().String(),
+		TripCount:    len(trips),
+		ContentType:  complianceReportContentType,
+		GeneratedBy:  generatedBy,
+		GeneratedAt:  time.Now(),
+	}
+	report.Content, report.SuppressedZoneCount, err = s.render(template, trips, start.Location())
+	if err != nil {
+		return nil, err
+	}
+	for _, trip := range trips {
+		if len(trip.AccessibilityNeeds) > 0 {
+			report.AccessibleTripCount++
+		}
+	}
+	if err := s.countWAV(ctx, report, completed, start, end); err != nil {
+		return nil, err
+	}
+
+	if err := s.store.SaveReport(ctx, report); err != nil {
+		return nil, fmt.Errorf("failed to save compliance report: %w", err)
+	}
+
+	s.logger.WithContext(ctx).WithFields(logger.Fields{
+		"report_id":    report.ID,
+		"template_id":  template.ID,
+		"city_id":      template.CityID,
+		"period_start": start.Format(time.DateOnly),
+		"trips":        report.TripCount,
+	}).Info("Compliance report generated")
+	return report, nil
+}
+
+// countWAV reports how the wheelchair-accessible trips requested in the
+// report's city in [start, end) were fulfilled. Trips still under way are
+// left out until they complete, are cancelled or fail.
+func (s *ComplianceService) countWAV(ctx context.Context, report *types.ComplianceReport, completed []*models.Trip, start, end time.Time) error {
+	requested := func(trip *models.Trip) bool {
+		return trip.CityID == report.CityID && trip.IsWAV() && !trip.RequestedAt.Before(start) && trip.RequestedAt.Before(end)
+	}
+
+	var waited time.Duration
+	for _, trip := range completed {
+		if !requested(trip) {
+			continue
+		}
+		report.WAVRequestCount++
+		report.WAVFulfilledCount++
+		arrivedAt := trip.DriverArrivedAt
+		if arrivedAt == nil {
+			arrivedAt = trip.StartedAt
+		}
+		if arrivedAt != nil {
+			waited += arrivedAt.Sub(trip.RequestedAt)
+		}
+	}
+	for _, status := range []models.TripStatus{models.TripStatusCancelled, models.TripStatusFailed} {
+		unfulfilled, err := s.trips.GetByStatus(ctx, status)
+		if err != nil {
+			return fmt.Errorf("failed to list %s trips: %w", status, err)
+		}
+		for _, trip := range unfulfilled {
+			if requested(trip) {
+				report.WAVRequestCount++
+			}
+		}
+	}
+
+	if report.WAVRequestCount > 0 {
+		report.WAVFulfillmentRate = float64(report.WAVFulfilledCount) / float64(report.WAVRequestCount)
+	}
+	if report.WAVFulfilledCount > 0 {
+		report.WAVAverageWaitSeconds = math.Round(waited.Seconds() / float64(report.WAVFulfilledCount))
+	}
+	return nil
+}
+
+// render writes the trips as CSV in the template's columns, suppressing
+// zones with too few trips, and returns how many zones it suppressed
+func (s *ComplianceService) render(template *ComplianceTemplate, trips []*models.Trip, location *time.Location) ([]byte, int, error) {
+	type tripZones struct{ pickup, dropoff string }
+	zones := make([]tripZones, len(trips))
+	zoneTrips := make(map[string]int)
+	for i, trip := range trips {
+		zones[i] = tripZones{
+			pickup:  trip.PickupLocation.Geohash(template.ZonePrecision),
+			dropoff: trip.Destination.Geohash(template.ZonePrecision),
+		}
+		zoneTrips[zones[i].pickup]++
+		if zones[i].dropoff != zones[i].pickup {
+			zoneTrips[zones[i].dropoff]++
+		}
+	}
+	suppressed := 0
+	for _, count := range zoneTrips {
+		if count < template.MinZoneTrips {
+			suppressed++
+		}
+	}
+	zone := func(geohash string) string {
+		if zoneTrips[geohash] < template.MinZoneTrips {
+			return suppressedComplianceZone
+		}
+		return geohash
+	}
+	timestamp := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.In(location).Truncate(template.TimeResolution).Format(time.RFC3339)
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	header := make([]string, len(template.Columns))
+	for i, column := range template.Columns {
+		header[i] = column.Header
+	}
+	if err := w.Write(header); err != nil {
+		return nil, 0, fmt.Errorf("failed to write compliance report: %w", err)
+	}
+
+	for i, trip := range trips {
+		row := make([]string, len(template.Columns))
+		for j, column := range template.Columns {
+			switch column.Field {
+			case "trip_ref":
+				row[j] = s.ref(trip.ID)
+			case "driver_ref":
+				if trip.DriverID != nil {
+					row[j] = s.ref(*trip.DriverID)
+				}
+			case "pickup_zone":
+				row[j] = zone(zones[i].pickup)
+			case "dropoff_zone":
+				row[j] = zone(zones[i].dropoff)
+			case "requested_at":
+				row[j] = timestamp(&trip.RequestedAt)
+			case "pickup_at":
+				row[j] = timestamp(trip.StartedAt)
+			case "dropoff_at":
+				row[j] = timestamp(trip.CompletedAt)
+			case "distance_km":
+				if trip.ActualDistanceKm != nil {
+					row[j] = strconv.FormatFloat(*trip.ActualDistanceKm, 'f', 1, 64)
+				}
+			case "duration_minutes":
+				if trip.ActualDurationSeconds != nil {
+					row[j] = strconv.Itoa(int(math.Round(float64(*trip.ActualDurationSeconds) / 60)))
+				}
+			case "fare":
+				if trip.ActualFareCents != nil {
+					decimals := 2
+					if c, err := currency.Lookup(trip.Currency); err == nil {
+						decimals = c.MinorUnits
+					}
+					row[j] = strconv.FormatFloat(currency.FromMinor(*trip.ActualFareCents, trip.Currency), 'f', decimals, 64)
+				}
+			case "currency":
+				row[j] = trip.Currency
+			case "accessible":
+				row[j] = strconv.FormatBool(len(trip.AccessibilityNeeds) > 0)
+			case "accessibility_needs":
+				row[j] = strings.Join(trip.AccessibilityNeeds, ";")
+			case "wav":
+				row[j] = strconv.FormatBool(trip.IsWAV())
+			}
+		}
+		if err := w.Write(row); err != nil {
+			return nil, 0, fmt.Errorf("failed to write compliance report: %w", err)
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, 0, fmt.Errorf("failed to write compliance report: %w", err)
+	}
+	return buf.Bytes(), suppressed, nil
+}
+
+// ref hashes an ID with the service's secret so reports can refer to the
+// same trip or driver without revealing who it is
+func (s *ComplianceService) ref(id string) string {
+	mac := hmac.New(sha256.New, s.hashSecret)
+	mac.Write([]byte(id))
+	return hex.EncodeToString(mac.Sum(nil))[:complianceRefLength]
+}
+
+// location returns the timezone of the template's city, UTC if it is unknown
+func (s *ComplianceService) location(template *ComplianceTemplate) *time.Location {
+	if s.cities != nil {
+		if c, err := s.cities.Get(template.CityID); err == nil {
+			return c.Location()
+		}
+	}
+	return time.UTC
+}
+
+func generateComplianceReportID() string {
+	return fmt.Sprintf("compliance_%d", time.Now().UnixNano())
+}
@@ -0,0 +1,244 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rideshare-platform/services/trip-service/internal/repository"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/city"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+)
+
+const testComplianceCitiesFile = `
+cities:
+  istanbul:
+    name: Istanbul
+    currency: TRY
+    timezone: Europe/Istanbul
+    center: {latitude: 41.0082, longitude: 28.9784}
+    radius_km: 60
+  london:
+    name: London
+    currency: GBP
+    timezone: Europe/London
+    center: {latitude: 51.5074, longitude: -0.1278}
+    radius_km: 50
+compliance_reports:
+  istanbul:
+    - id: ibb-daily-trips
+      name: IBB daily trip records
+      schedule: daily
+      min_zone_trips: 2
+      columns:
+        - {field: trip_ref, header: trip_id}
+        - {field: driver_ref}
+        - {field: pickup_zone}
+        - {field: dropoff_zone}
+        - {field: dropoff_at, header: end_time}
+        - {field: fare}
+        - {field: accessible, header: wheelchair}
+`
+
+func loadComplianceTestTemplates(t *testing.T, contents string) (*city.Registry, ComplianceTemplates, error) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "cities.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0o600))
+
+	cities, err := city.Load(path)
+	require.NoError(t, err)
+	templates, err := LoadComplianceTemplates(path, cities)
+	return cities, templates, err
+}
+
+func saveComplianceTrip(t *testing.T, store *repository.MemoryTripStore, id, cityID string, pickup, dropoff models.Location, completedAt time.Time, needs ...string) {
+	trip := models.NewTrip("rider-secret", pickup, dropoff, 1)
+	trip.ID = id
+	trip.CityID = cityID
+	trip.Status = models.TripStatusCompleted
+	trip.Currency = "TRY"
+	trip.CompletedAt = &completedAt
+	fare := int64(24550)
+	trip.ActualFareCents = &fare
+	driverID := "driver-secret"
+	trip.DriverID = &driverID
+	trip.AccessibilityNeeds = needs
+	require.NoError(t, store.Create(context.Background(), trip))
+}
+
+func TestLoadComplianceTemplates(t *testing.T) {
+	_, templates, err := loadComplianceTestTemplates(t, testComplianceCitiesFile)
+	require.NoError(t, err)
+
+	template := templates["ibb-daily-trips"]
+	require.NotNil(t, template)
+	assert.Equal(t, "istanbul", template.CityID)
+	assert.Equal(t, defaultComplianceZonePrecision, template.ZonePrecision, "unset rules take the defaults")
+	assert.Equal(t, defaultComplianceTimeResolution, template.TimeResolution)
+	assert.Equal(t, 2, template.MinZoneTrips)
+	assert.Equal(t, "driver_ref", template.Columns[1].Header, "columns without a header are headed by their field")
+
+	_, _, err = loadComplianceTestTemplates(t, testComplianceCitiesFile+"        - {field: rider_id}\n")
+	assert.ErrorContains(t, err, `unknown field "rider_id"`, "riders are never reported")
+
+	_, _, err = loadComplianceTestTemplates(t, `
+cities:
+  istanbul: {name: Istanbul, currency: TRY, center: {latitude: 41, longitude: 29}, radius_km: 60}
+compliance_reports:
+  paris:
+    - {id: paris-trips, schedule: daily, columns: [{field: trip_ref}]}
+`)
+	assert.ErrorIs(t, err, city.ErrUnknownCity)
+}
+
+func TestComplianceService_GenerateReportAnonymizesTrips(t *testing.T) {
+	ctx := context.Background()
+	cities, templates, err := loadComplianceTestTemplates(t, testComplianceCitiesFile)
+	require.NoError(t, err)
+	istanbul, err := time.LoadLocation("Europe/Istanbul")
+	require.NoError(t, err)
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, 10, day, hour, minute, 0, 0, istanbul)
+	}
+
+	sultanahmet := models.Location{Latitude: 41.0054, Longitude: 28.9768}
+	taksim := models.Location{Latitude: 41.0370, Longitude: 28.9850}
+	trips := repository.NewMemoryTripStore()
+	saveComplianceTrip(t, trips, "trip-1", "istanbul", sultanahmet, taksim, at(14, 10, 7), "wheelchair_accessible")
+	saveComplianceTrip(t, trips, "trip-2", "istanbul", sultanahmet, taksim, at(14, 10, 40))
+	saveComplianceTrip(t, trips, "trip-3", "istanbul", models.Location{Latitude: 41.1, Longitude: 29.05}, models.Location{Latitude: 41.2, Longitude: 29.1}, at(14, 23, 59))
+	saveComplianceTrip(t, trips, "trip-next-day", "istanbul", sultanahmet, taksim, at(15, 0, 0))
+	saveComplianceTrip(t, trips, "trip-london", "london", sultanahmet, taksim, at(14, 12, 0))
+
+	compliance := NewComplianceService(trips, cities, templates, repository.NewMemoryComplianceStore(), logger.NewLogger("error", "test"))
+	compliance.SetHashSecret("compliance-test-secret")
+
+	report, err := compliance.GenerateReport(ctx, "ibb-daily-trips", "2026-10-14", "ops-1")
+	require.NoError(t, err)
+	assert.Equal(t, at(14, 0, 0), report.PeriodStart, "periods follow the city's timezone")
+	assert.Equal(t, 3, report.TripCount)
+	assert.Equal(t, 1, report.AccessibleTripCount)
+	assert.Equal(t, 2, report.SuppressedZoneCount, "the zones only trip-3 visited are suppressed")
+	assert.Equal(t, "ops-1", report.GeneratedBy)
+
+	stored, err := compliance.GetReport(ctx, report.ID)
+	require.NoError(t, err)
+	assert.False(t, bytes.Contains(stored.Content, []byte("secret")), "rider and driver IDs are not reported")
+	assert.False(t, bytes.Contains(stored.Content, []byte("trip-1")), "trip IDs are reported hashed")
+
+	rows, err := csv.NewReader(bytes.NewReader(stored.Content)).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+	assert.Equal(t, []string{"trip_id", "driver_ref", "pickup_zone", "dropoff_zone", "end_time", "fare", "wheelchair"}, rows[0])
+	assert.Equal(t, []string{
+		compliance.ref("trip-1"), compliance.ref("driver-secret"),
+		sultanahmet.Geohash(5), taksim.Geohash(5),
+		"2026-10-14T10:00:00+03:00", "245.50", "true",
+	}, rows[1])
+	assert.Equal(t, "false", rows[2][6])
+	assert.Equal(t, suppressedComplianceZone, rows[3][2])
+	assert.Equal(t, suppressedComplianceZone, rows[3][3])
+
+	_, err = compliance.GenerateReport(ctx, "ibb-daily-trips", time.Now().In(istanbul).Format(time.DateOnly), "ops-1")
+	assert.ErrorIs(t, err, ErrInvalidComplianceRequest, "periods that have not ended cannot be reported")
+	_, err = compliance.GenerateReport(ctx, "unknown", "2026-10-14", "ops-1")
+	assert.ErrorIs(t, err, ErrComplianceTemplateNotFound)
+}
+
+func TestComplianceService_GenerateReportCountsWAVTrips(t *testing.T) {
+	ctx := context.Background()
+	cities, templates, err := loadComplianceTestTemplates(t, testComplianceCitiesFile)
+	require.NoError(t, err)
+	istanbul, err := time.LoadLocation("Europe/Istanbul")
+	require.NoError(t, err)
+	at := func(hour, minute int) time.Time {
+		return time.Date(2026, 10, 14, hour, minute, 0, 0, istanbul)
+	}
+
+	sultanahmet := models.Location{Latitude: 41.0054, Longitude: 28.9768}
+	taksim := models.Location{Latitude: 41.0370, Longitude: 28.9850}
+	trips := repository.NewMemoryTripStore()
+	for i, waited := range []int{6, 14} {
+		id := fmt.Sprintf("trip-wav-%d", i)
+		saveComplianceTrip(t, trips, id, "istanbul", sultanahmet, taksim, at(11, 0), "wheelchair_accessible")
+		trip, err := trips.GetByID(ctx, id)
+		require.NoError(t, err)
+		trip.RequestedAt = at(10, 0)
+		arrivedAt := at(10, waited)
+		trip.DriverArrivedAt = &arrivedAt
+		require.NoError(t, trips.Update(ctx, trip))
+	}
+	saveComplianceTrip(t, trips, "trip-standard", "istanbul", sultanahmet, taksim, at(11, 0))
+
+	cancelled := models.NewTrip("rider-secret", sultanahmet, taksim, 1)
+	cancelled.ID = "trip-wav-cancelled"
+	cancelled.CityID = "istanbul"
+	cancelled.Status = models.TripStatusCancelled
+	cancelled.RequestedAt = at(12, 0)
+	cancelled.AccessibilityNeeds = []string{"wheelchair_accessible"}
+	require.NoError(t, trips.Create(ctx, cancelled))
+
+	compliance := NewComplianceService(trips, cities, templates, repository.NewMemoryComplianceStore(), logger.NewLogger("error", "test"))
+	report, err := compliance.GenerateReport(ctx, "ibb-daily-trips", "2026-10-14", "ops-1")
+	require.NoError(t, err)
+	assert.Equal(t, 3, report.WAVRequestCount)
+	assert.Equal(t, 2, report.WAVFulfilledCount, "cancelled WAV requests go unfulfilled")
+	assert.InDelta(t, 2.0/3, report.WAVFulfillmentRate, 0.001)
+	assert.Equal(t, float64(600), report.WAVAverageWaitSeconds)
+}
+
+func TestComplianceService_GenerateDueOncePerPeriod(t *testing.T) {
+	ctx := context.Background()
+	cities, templates, err := loadComplianceTestTemplates(t, testComplianceCitiesFile)
+	require.NoError(t, err)
+	compliance := NewComplianceService(repository.NewMemoryTripStore(), cities, templates, repository.NewMemoryComplianceStore(), logger.NewLogger("error", "test"))
+
+	// 22:30 UTC is already the next day in Istanbul
+	now := time.Date(2026, 10, 14, 22, 30, 0, 0, time.UTC)
+	generated, err := compliance.GenerateDue(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, generated)
+
+	generated, err = compliance.GenerateDue(ctx, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, generated, "a period is reported once")
+
+	reports, err := compliance.ListReports(ctx, types.ComplianceReportFilter{CityID: "Istanbul", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.Equal(t, "2026-10-14", reports[0].PeriodStart.Format(time.DateOnly))
+	assert.Equal(t, "system", reports[0].GeneratedBy)
+	assert.Empty(t, reports[0].Content, "listed reports leave their content out")
+}
+
+func TestComplianceTemplate_Periods(t *testing.T) {
+	// Thursday 15 October 2026
+	at := time.Date(2026, 10, 15, 13, 0, 0, 0, time.UTC)
+	tests := []struct {
+		schedule   string
+		start, end string
+		lastStart  string
+	}{
+		{ComplianceScheduleDaily, "2026-10-15", "2026-10-16", "2026-10-14"},
+		{ComplianceScheduleWeekly, "2026-10-12", "2026-10-19", "2026-10-05"},
+		{ComplianceScheduleMonthly, "2026-10-01", "2026-11-01", "2026-09-01"},
+	}
+	for _, tt := range tests {
+		template := &ComplianceTemplate{Schedule: tt.schedule}
+		start, end := template.period(at)
+		assert.Equal(t, tt.start, start.Format(time.DateOnly), tt.schedule)
+		assert.Equal(t, tt.end, end.Format(time.DateOnly), tt.schedule)
+		last, _ := template.lastCompletePeriod(at)
+		assert.Equal(t, tt.lastStart, last.Format(time.DateOnly), tt.schedule)
+	}
+}
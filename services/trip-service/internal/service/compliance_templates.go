@@ -0,0 +1,199 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/rideshare-platform/shared/city"
+)
+
+// How often a jurisdiction wants its trip data reported
+const (
+	ComplianceScheduleDaily   = "daily"
+	ComplianceScheduleWeekly  = "weekly" // Monday to Sunday
+	ComplianceScheduleMonthly = "monthly"
+)
+
+// Anonymization applied when a template leaves a rule unset
+const (
+	defaultComplianceZonePrecision  = 5 // geohash cells of about 5km
+	defaultComplianceTimeResolution = 15 * time.Minute
+	defaultComplianceMinZoneTrips   = 5
+	maxComplianceZonePrecision      = 6
+)
+
+// complianceFields are the trip fields a template may report. Trip and
+// driver IDs are only reported as salted hashes and locations only as zones;
+// riders are never reported.
+var complianceFields = map[string]bool{
+	"trip_ref":            true, // salted hash of the trip ID
+	"driver_ref":          true, // salted hash of the driver ID
+	"pickup_zone":         true,
+	"dropoff_zone":        true,
+	"requested_at":        true,
+	"pickup_at":           true,
+	"dropoff_at":          true,
+	"distance_km":         true,
+	"duration_minutes":    true,
+	"fare":                true,
+	"currency":            true,
+	"accessible":          true, // whether the rider had accessibility needs
+	"accessibility_needs": true,
+	"wav":                 true, // whether the trip needed a wheelchair-accessible vehicle
+}
+
+// ComplianceColumn is one column of a compliance report: a trip field and
+// the header the jurisdiction calls it, the field name by default
+type ComplianceColumn struct {
+	Field  string `yaml:"field" json:"field"`
+	Header string `yaml:"header" json:"header"`
+}
+
+// ComplianceTemplate is the trip data report a jurisdiction requires from
+// the trips completed in one of the platform's cities, and the anonymization
+// rules applied to it. Pickups and dropoffs are reported as geohash zones of
+// ZonePrecision characters, timestamps are rounded down to TimeResolution
+// and zones with fewer than MinZoneTrips trips in a report are suppressed.
+type ComplianceTemplate struct {
+	ID             string             `yaml:"id" json:"id"`
+	Name           string             `yaml:"name" json:"name"`
+	CityID         string             `yaml:"-" json:"city_id"`
+	Schedule       string             `yaml:"schedule" json:"schedule"`
+	ZonePrecision  int                `yaml:"zone_precision" json:"zone_precision"`
+	TimeResolution time.Duration      `yaml:"time_resolution" json:"time_resolution"`
+	MinZoneTrips   int                `yaml:"min_zone_trips" json:"min_zone_trips"`
+	Columns        []ComplianceColumn `yaml:"columns" json:"columns"`
+}
+
+// ComplianceTemplates are the compliance report templates, by ID
+type ComplianceTemplates map[string]*ComplianceTemplate
+
+// complianceReportsFile is the compliance_reports section of the cities
+// file, listing each city's templates:
+//
+//	compliance_reports:
+//	  istanbul:
+//	    - id: ibb-monthly-trips
+//	      name: IBB monthly trip records
+//	      schedule: monthly
+//	      zone_precision: 5
+//	      time_resolution: 15m
+//	      min_zone_trips: 5
+//	      columns:
+//	        - {field: trip_ref, header: trip_id}
+//	        - {field: pickup_zone}
+//	        - {field: dropoff_at, header: end_time}
+//	        - {field: accessible, header: wheelchair}
+type complianceReportsFile struct {
+	ComplianceReports map[string][]*ComplianceTemplate `yaml:"compliance_reports"`
+}
+
+// LoadComplianceTemplates reads the compliance report templates from the
+// cities file at path. Every template must belong to one of the cities.
+func LoadComplianceTemplates(path string, cities *city.Registry) (ComplianceTemplates, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cities file: %w", err)
+	}
+
+	var file complianceReportsFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse compliance reports in %s: %w", path, err)
+	}
+
+	templates := make(ComplianceTemplates)
+	for id, cityTemplates := range file.ComplianceReports {
+		cityID := city.Normalize(id)
+		if _, err := cities.Get(cityID); err != nil {
+			return nil, fmt.Errorf("compliance report: %w", err)
+		}
+		for _, template := range cityTemplates {
+			if template == nil {
+				continue
+			}
+			template.CityID = cityID
+			if err := template.normalize(); err != nil {
+				return nil, err
+			}
+			if _, exists := templates[template.ID]; exists {
+				return nil, fmt.Errorf("compliance report %s is defined more than once", template.ID)
+			}
+			templates[template.ID] = template
+		}
+	}
+	return templates, nil
+}
+
+// normalize checks the template and fills in its defaults
+func (t *ComplianceTemplate) normalize() error {
+	if t.ID == "" {
+		return fmt.Errorf("compliance report in %s has no id", t.CityID)
+	}
+	if t.Name == "" {
+		t.Name = t.ID
+	}
+	switch t.Schedule {
+	case ComplianceScheduleDaily, ComplianceScheduleWeekly, ComplianceScheduleMonthly:
+	default:
+		return fmt.Errorf("compliance report %s: schedule must be daily, weekly or monthly", t.ID)
+	}
+
+	if t.ZonePrecision == 0 {
+		t.ZonePrecision = defaultComplianceZonePrecision
+	}
+	if t.ZonePrecision < 1 || t.ZonePrecision > maxComplianceZonePrecision {
+		return fmt.Errorf("compliance report %s: zone_precision must be between 1 and %d", t.ID, maxComplianceZonePrecision)
+	}
+	if t.TimeResolution == 0 {
+		t.TimeResolution = defaultComplianceTimeResolution
+	}
+	if t.TimeResolution < time.Minute {
+		return fmt.Errorf("compliance report %s: time_resolution must be at least a minute", t.ID)
+	}
+	if t.MinZoneTrips == 0 {
+		t.MinZoneTrips = defaultComplianceMinZoneTrips
+	}
+	if t.MinZoneTrips < 0 {
+		return fmt.Errorf("compliance report %s: min_zone_trips must not be negative", t.ID)
+	}
+
+	if len(t.Columns) == 0 {
+		return fmt.Errorf("compliance report %s has no columns", t.ID)
+	}
+	for i := range t.Columns {
+		column := &t.Columns[i]
+		if !complianceFields[column.Field] {
+			return fmt.Errorf("compliance report %s: unknown field %q", t.ID, column.Field)
+		}
+		if strings.TrimSpace(column.Header) == "" {
+			column.Header = column.Field
+		}
+	}
+	return nil
+}
+
+// period returns the start and end of the reporting period containing t,
+// in t's location
+func (t *ComplianceTemplate) period(at time.Time) (time.Time, time.Time) {
+	day := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, at.Location())
+	switch t.Schedule {
+	case ComplianceScheduleWeekly:
+		start := day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+		return start, start.AddDate(0, 0, 7)
+	case ComplianceScheduleMonthly:
+		start := day.AddDate(0, 0, 1-day.Day())
+		return start, start.AddDate(0, 1, 0)
+	default:
+		return day, day.AddDate(0, 0, 1)
+	}
+}
+
+// lastCompletePeriod returns the most recent period that ended by now
+func (t *ComplianceTemplate) lastCompletePeriod(now time.Time) (time.Time, time.Time) {
+	current, _ := t.period(now)
+	return t.period(current.AddDate(0, 0, -1))
+}
@@ -0,0 +1,459 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/analytics"
+	"github.com/rideshare-platform/shared/currency"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+)
+
+var (
+	// ErrNotTripRider is returned when someone other than the trip's rider
+	// disputes its fare
+	ErrNotTripRider = errors.New("only the trip's rider can dispute its fare")
+	// ErrTripNotDisputable is returned when disputing a trip that has not ended
+	ErrTripNotDisputable = errors.New("only completed or cancelled trips can be disputed")
+	// ErrDisputeWindowClosed is returned when a dispute arrives after the dispute window
+	ErrDisputeWindowClosed = errors.New("dispute window has closed")
+	// ErrDisputeExists is returned when the trip already has a dispute in progress
+	ErrDisputeExists = errors.New("trip already has a dispute in progress")
+	// ErrDisputeClosed is returned when acting on a dispute that has been resolved or rejected
+	ErrDisputeClosed = errors.New("dispute has already been closed")
+	// ErrRefundExceedsCharge is returned when a resolution refunds more than the rider was charged
+	ErrRefundExceedsCharge = errors.New("refund exceeds what the rider was charged")
+	// ErrRefundFailed is returned when payment-service did not pay a
+	// resolution's refund; the dispute stays open
+	ErrRefundFailed = errors.New("refund failed")
+	// ErrRefundsUnavailable is returned when a resolution refunds the rider
+	// but no refunder is configured
+	ErrRefundsUnavailable = errors.New("refunds are not configured")
+)
+
+// DisputeConfig controls when trips can be disputed and what riders can send
+type DisputeConfig struct {
+	Window      time.Duration // how long after a trip ends its fare can be disputed
+	MaxEvidence int           // most pieces of evidence a dispute can carry
+}
+
+// DefaultDisputeConfig returns the default dispute settings
+func DefaultDisputeConfig() DisputeConfig {
+	return DisputeConfig{
+		Window:      30 * 24 * time.Hour,
+		MaxEvidence: 10,
+	}
+}
+
+const maxDisputeDescriptionLength = 2000
+
+// DisputeRefunder refunds part of what a rider was charged for a trip,
+// normally through payment-service
+type DisputeRefunder interface {
+	// RefundTrip refunds amount of the trip's charge and returns the refund's ID
+	RefundTrip(ctx context.Context, tripID string, amount float64, currencyCode, reason, requestedBy string) (string, error)
+}
+
+// OpenDisputeRequest is a rider contesting what they were charged for a trip
+type OpenDisputeRequest struct {
+	TripID      string                  `json:"-"`
+	RiderID     string                  `json:"rider_id" binding:"required"`
+	Reason      types.DisputeReason     `json:"reason" binding:"required"`
+	Description string                  `json:"description"`
+	Evidence    []types.DisputeEvidence `json:"evidence,omitempty"`
+}
+
+// ResolveDisputeRequest closes a dispute. A positive RefundAmount, in the
+// dispute's currency, refunds that much of the charge; zero upholds the fare.
+type ResolveDisputeRequest struct {
+	StaffID      string  `json:"staff_id" binding:"required"`
+	Resolution   string  `json:"resolution"`
+	RefundAmount float64 `json:"refund_amount"`
+}
+
+// DisputeReview is a dispute with the trip's route, for support staff
+// deciding it. The route is the one geo-service recorded when there is one,
+// otherwise the one the driver's app reported.
+type DisputeReview struct {
+	Dispute            *types.Dispute    `json:"dispute"`
+	Route              []models.Location `json:"route"`
+	RouteRecorded      bool              `json:"route_recorded"`
+	RouteDistanceKm    float64           `json:"route_distance_km,omitempty"`
+	ReportedDistanceKm float64           `json:"reported_distance_km,omitempty"`
+	DurationSeconds    int               `json:"duration_seconds,omitempty"`
+}
+
+// DisputeService lets riders contest trip charges and support staff review
+// and resolve them, refunding part or all of the fare through payment-service
+type DisputeService struct {
+	trips     TripRepositoryInterface
+	store     types.DisputeStore
+	config    DisputeConfig
+	routes    RouteLookup
+	refunds   DisputeRefunder
+	analytics *analytics.Recorder
+	logger    *logger.Logger
+
+	// mutex serialises dispute updates, which read, change and save
+	mutex sync.Mutex
+}
+
+// NewDisputeService creates a new dispute service
+func NewDisputeService(trips TripRepositoryInterface, store types.DisputeStore, config DisputeConfig, logger *logger.Logger) *DisputeService {
+	return &DisputeService{
+		trips:  trips,
+		store:  store,
+		config: config,
+		logger: logger,
+	}
+}
+
+// SetRouteLookup attaches the geo-service client reviews fetch the trip's
+// recorded route with. Without one reviews show the route the app reported.
+func (s *DisputeService) SetRouteLookup(routes RouteLookup) {
+	s.routes = routes
+}
+
+// SetRefunder attaches the payment-service client refunds are paid through.
+// Without one disputes can only be resolved without a refund.
+func (s *DisputeService) SetRefunder(refunds DisputeRefunder) {
+	s.refunds = refunds
+}
+
+// SetAnalytics sets the recorder opened and closed disputes are counted with
+func (s *DisputeService) SetAnalytics(recorder *analytics.Recorder) {
+	s.analytics = recorder
+}
+
+// OpenDispute records a rider contesting a trip's charge. The charge and its
+// fare breakdown are frozen into the dispute.
+func (s *DisputeService) OpenDispute(ctx context.Context, req *OpenDisputeRequest) (*types.Dispute, error) {
+	if req.TripID == "" || req.RiderID == "" {
+		return nil, fmt.Errorf("trip ID and rider ID are required")
+	}
+	switch req.Reason {
+	case types.DisputeReasonOvercharged, types.DisputeReasonWrongRoute, types.DisputeReasonTripNotTaken,
+		types.DisputeReasonCancellationFee, types.DisputeReasonOther:
+	default:
+		return nil, fmt.Errorf("unknown dispute reason %q", req.Reason)
+	}
+	description := strings.TrimSpace(req.Description)
+	if req.Reason == types.DisputeReasonOther && description == "" {
+		return nil, fmt.Errorf("a description is required for other disputes")
+	}
+	if len(description) > maxDisputeDescriptionLength {
+		return nil, fmt.Errorf("description must be at most %d characters", maxDisputeDescriptionLength)
+	}
+	evidence, err := s.evidence(req.Evidence)
+	if err != nil {
+		return nil, err
+	}
+
+	trip, err := s.trips.GetByID(ctx, req.TripID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trip: %w", err)
+	}
+	if trip.RiderID != req.RiderID {
+		return nil, ErrNotTripRider
+	}
+	endedAt, err := tripEndedAt(trip)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if now.After(endedAt.Add(s.config.Window)) {
+		return nil, ErrDisputeWindowClosed
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	existing, err := s.store.GetDisputesByTrip(ctx, trip.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, dispute := range existing {
+		if !dispute.Status.Closed() {
+			return nil, ErrDisputeExists
+		}
+	}
+
+	dispute := &types.Dispute{
+		ID:          generateDisputeID(),
+		TripID:      trip.ID,
+		RiderID:     trip.RiderID,
+		Reason:      req.Reason,
+		Description: description,
+		Evidence:    evidence,
+		Status:      types.DisputeStatusOpen,
+		Currency:    trip.Currency,
+		Fare:        trip.FareBreakdown,
+		Notes:       []*types.DisputeNote{},
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if trip.DriverID != nil {
+		dispute.DriverID = *trip.DriverID
+	}
+	switch {
+	case trip.FareBreakdown != nil:
+		dispute.ChargedAmount = trip.FareBreakdown.Total.ToFloat64()
+		dispute.Currency = trip.FareBreakdown.Total.Currency
+	case trip.ActualFareCents != nil:
+		dispute.ChargedAmount = currency.FromMinor(*trip.ActualFareCents, trip.Currency)
+	}
+
+	if err := s.store.SaveDispute(ctx, dispute); err != nil {
+		return nil, fmt.Errorf("failed to save dispute: %w", err)
+	}
+	s.recordOpened(dispute)
+
+	s.logger.WithContext(ctx).WithFields(logger.Fields{
+		"dispute_id": dispute.ID,
+		"trip_id":    dispute.TripID,
+		"reason":     dispute.Reason,
+	}).Info("Fare dispute opened")
+	return dispute, nil
+}
+
+// GetDispute returns a dispute
+func (s *DisputeService) GetDispute(ctx context.Context, disputeID string) (*types.Dispute, error) {
+	return s.store.GetDispute(ctx, disputeID)
+}
+
+// ReviewDispute returns a dispute with the trip's route for support staff
+func (s *DisputeService) ReviewDispute(ctx context.Context, disputeID string) (*DisputeReview, error) {
+	dispute, err := s.store.GetDispute(ctx, disputeID)
+	if err != nil {
+		return nil, err
+	}
+	trip, err := s.trips.GetByID(ctx, dispute.TripID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trip: %w", err)
+	}
+
+	review := &DisputeReview{Dispute: dispute, Route: []models.Location{}}
+	if trip.ActualRoute != nil {
+		review.Route = append(review.Route, *trip.ActualRoute...)
+	}
+	if trip.ActualDistanceKm != nil {
+		review.ReportedDistanceKm = *trip.ActualDistanceKm
+	}
+	if trip.ActualDurationSeconds != nil {
+		review.DurationSeconds = *trip.ActualDurationSeconds
+	}
+
+	if s.routes != nil {
+		recorded, err := s.routes.GetTripRoute(ctx, trip.ID)
+		if err != nil {
+			// The review is still useful with the reported route
+			s.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+				"dispute_id": dispute.ID,
+				"trip_id":    trip.ID,
+			}).Warn("Failed to get recorded route for dispute review")
+		} else if recorded != nil && len(recorded.Points) > 0 {
+			review.Route = recorded.Points
+			review.RouteRecorded = true
+			review.RouteDistanceKm = recorded.DistanceKm
+			if recorded.DurationSeconds > 0 {
+				review.DurationSeconds = recorded.DurationSeconds
+			}
+		}
+	}
+	return review, nil
+}
+
+// ListDisputes returns up to limit disputes in a status, or in any status
+// when it is empty, oldest first
+func (s *DisputeService) ListDisputes(ctx context.Context, status types.DisputeStatus, limit int) ([]*types.Dispute, error) {
+	switch status {
+	case "", types.DisputeStatusOpen, types.DisputeStatusInReview, types.DisputeStatusResolved, types.DisputeStatusRejected:
+	default:
+		return nil, fmt.Errorf("unknown dispute status %q", status)
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+
+	disputes, err := s.store.ListDisputes(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+	if len(disputes) > limit {
+		disputes = disputes[:limit]
+	}
+	return disputes, nil
+}
+
+// StartReview assigns a dispute to a member of staff. Another member of
+// staff starting a review takes the dispute over.
+func (s *DisputeService) StartReview(ctx context.Context, disputeID, staffID string) (*types.Dispute, error) {
+	if staffID == "" {
+		return nil, fmt.Errorf("staff ID is required")
+	}
+	return s.update(ctx, disputeID, func(dispute *types.Dispute, now time.Time) error {
+		if dispute.Status.Closed() {
+			return ErrDisputeClosed
+		}
+		dispute.Status = types.DisputeStatusInReview
+		dispute.AssignedTo = staffID
+		return nil
+	})
+}
+
+// AddNote adds a note to a dispute's timeline. Notes can be added after the
+// dispute is closed for follow-ups.
+func (s *DisputeService) AddNote(ctx context.Context, disputeID, authorID, text string) (*types.Dispute, error) {
+	text = strings.TrimSpace(text)
+	if authorID == "" || text == "" {
+		return nil, fmt.Errorf("author ID and note text are required")
+	}
+	return s.update(ctx, disputeID, func(dispute *types.Dispute, now time.Time) error {
+		dispute.Notes = append(dispute.Notes, &types.DisputeNote{
+			AuthorID:  authorID,
+			Text:      text,
+			CreatedAt: now,
+		})
+		return nil
+	})
+}
+
+// ResolveDispute closes a dispute in the rider's favour or upholding the
+// fare. A refund is paid before the dispute is closed, so a failed refund
+// leaves the dispute open to be retried.
+func (s *DisputeService) ResolveDispute(ctx context.Context, disputeID string, req *ResolveDisputeRequest) (*types.Dispute, error) {
+	resolution := strings.TrimSpace(req.Resolution)
+	if req.StaffID == "" || resolution == "" {
+		return nil, fmt.Errorf("staff ID and resolution are required")
+	}
+	if req.RefundAmount < 0 {
+		return nil, fmt.Errorf("refund amount cannot be negative")
+	}
+	if req.RefundAmount > 0 && s.refunds == nil {
+		return nil, ErrRefundsUnavailable
+	}
+
+	return s.close(ctx, disputeID, func(dispute *types.Dispute, now time.Time) error {
+		dispute.Status = types.DisputeStatusResolved
+		if req.RefundAmount == 0 {
+			return nil
+		}
+
+		amount := currency.Round(req.RefundAmount, dispute.Currency)
+		if dispute.ChargedAmount > 0 && amount > dispute.ChargedAmount {
+			return fmt.Errorf("%w: %s charged", ErrRefundExceedsCharge, currency.FormatAmount(dispute.ChargedAmount, dispute.Currency))
+		}
+		refundID, err := s.refunds.RefundTrip(ctx, dispute.TripID, amount, dispute.Currency,
+			fmt.Sprintf("fare dispute %s: %s", dispute.ID, resolution), req.StaffID)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrRefundFailed, err)
+		}
+		dispute.RefundAmount = amount
+		dispute.RefundID = refundID
+		return nil
+	}, req.StaffID, resolution)
+}
+
+// RejectDispute closes a dispute without a refund
+func (s *DisputeService) RejectDispute(ctx context.Context, disputeID, staffID, resolution string) (*types.Dispute, error) {
+	resolution = strings.TrimSpace(resolution)
+	if staffID == "" || resolution == "" {
+		return nil, fmt.Errorf("staff ID and resolution are required")
+	}
+	return s.close(ctx, disputeID, func(dispute *types.Dispute, now time.Time) error {
+		dispute.Status = types.DisputeStatusRejected
+		return nil
+	}, staffID, resolution)
+}
+
+// close applies an outcome to an open dispute and records who closed it
+func (s *DisputeService) close(ctx context.Context, disputeID string, outcome func(*types.Dispute, time.Time) error, staffID, resolution string) (*types.Dispute, error) {
+	dispute, err := s.update(ctx, disputeID, func(dispute *types.Dispute, now time.Time) error {
+		if dispute.Status.Closed() {
+			return ErrDisputeClosed
+		}
+		if err := outcome(dispute, now); err != nil {
+			return err
+		}
+		if dispute.AssignedTo == "" {
+			dispute.AssignedTo = staffID
+		}
+		dispute.Resolution = resolution
+		dispute.ResolvedBy = staffID
+		dispute.ResolvedAt = &now
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.recordClosed(dispute)
+	return dispute, nil
+}
+
+func (s *DisputeService) update(ctx context.Context, disputeID string, change func(*types.Dispute, time.Time) error) (*types.Dispute, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	dispute, err := s.store.GetDispute(ctx, disputeID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if err := change(dispute, now); err != nil {
+		return nil, err
+	}
+	dispute.UpdatedAt = now
+	if err := s.store.SaveDispute(ctx, dispute); err != nil {
+		return nil, fmt.Errorf("failed to save dispute: %w", err)
+	}
+
+	s.logger.WithContext(ctx).WithFields(logger.Fields{
+		"dispute_id": dispute.ID,
+		"status":     dispute.Status,
+	}).Info("Dispute updated")
+	return dispute, nil
+}
+
+// evidence drops empty pieces of evidence and checks the rest
+func (s *DisputeService) evidence(pieces []types.DisputeEvidence) ([]types.DisputeEvidence, error) {
+	evidence := []types.DisputeEvidence{}
+	for _, piece := range pieces {
+		piece.Description = strings.TrimSpace(piece.Description)
+		piece.URL = strings.TrimSpace(piece.URL)
+		if piece.Description == "" && piece.URL == "" {
+			continue
+		}
+		if piece.URL != "" && !strings.HasPrefix(piece.URL, "https://") {
+			return nil, fmt.Errorf("evidence links must be https URLs")
+		}
+		evidence = append(evidence, piece)
+	}
+	if len(evidence) > s.config.MaxEvidence {
+		return nil, fmt.Errorf("a dispute can carry at most %d pieces of evidence", s.config.MaxEvidence)
+	}
+	return evidence, nil
+}
+
+// tripEndedAt returns when a trip was completed or cancelled
+func tripEndedAt(trip *models.Trip) (time.Time, error) {
+	switch trip.Status {
+	case models.TripStatusCompleted:
+		if trip.CompletedAt != nil {
+			return *trip.CompletedAt, nil
+		}
+	case models.TripStatusCancelled:
+	default:
+		return time.Time{}, ErrTripNotDisputable
+	}
+	return trip.UpdatedAt, nil
+}
+
+func generateDisputeID() string {
+	return fmt.Sprintf("dispute_%d", time.Now().UnixNano())
+}
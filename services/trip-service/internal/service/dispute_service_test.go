@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rideshare-platform/services/trip-service/internal/repository"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+)
+
+type recordingRefunder struct {
+	amounts []float64
+	err     error
+}
+
+func (r *recordingRefunder) RefundTrip(ctx context.Context, tripID string, amount float64, currencyCode, reason, requestedBy string) (string, error) {
+	if r.err != nil {
+		return "", r.err
+	}
+	r.amounts = append(r.amounts, amount)
+	return "refund-1", nil
+}
+
+// newDisputeTestTrip creates a trip for rider-1 and completes it for 25
+func newDisputeTestTrip(t *testing.T, trips *TripService) *models.Trip {
+	ctx := context.Background()
+	trip, err := trips.CreateTrip(ctx, &CreateTripRequest{
+		RiderID:             "rider-1",
+		PickupLocation:      models.Location{Latitude: 41.008237, Longitude: 28.978359},
+		DestinationLocation: models.Location{Latitude: 41.042211, Longitude: 29.008311},
+		RideType:            "standard",
+		EstimatedFare:       20,
+	})
+	require.NoError(t, err)
+	_, err = trips.AcceptTrip(ctx, trip.ID, "driver-1")
+	require.NoError(t, err)
+	_, err = trips.StartTrip(ctx, trip.ID)
+	require.NoError(t, err)
+	trip, err = trips.CompleteTrip(ctx, trip.ID, 25)
+	require.NoError(t, err)
+	return trip
+}
+
+func TestDisputeService_ResolveWithPartialRefund(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("test", "info")
+	store := repository.NewMemoryTripStore()
+	trips := NewTripService(store, log)
+	disputes := NewDisputeService(store, repository.NewMemoryDisputeStore(), DefaultDisputeConfig(), log)
+	trip := newDisputeTestTrip(t, trips)
+
+	_, err := disputes.OpenDispute(ctx, &OpenDisputeRequest{TripID: trip.ID, RiderID: "stranger", Reason: types.DisputeReasonOvercharged})
+	assert.ErrorIs(t, err, ErrNotTripRider)
+	_, err = disputes.OpenDispute(ctx, &OpenDisputeRequest{TripID: trip.ID, RiderID: "rider-1", Reason: types.DisputeReasonOvercharged,
+		Evidence: []types.DisputeEvidence{{URL: "http://example.com/receipt.png"}}})
+	assert.Error(t, err)
+
+	dispute, err := disputes.OpenDispute(ctx, &OpenDisputeRequest{
+		TripID:      trip.ID,
+		RiderID:     "rider-1",
+		Reason:      types.DisputeReasonWrongRoute,
+		Description: "the driver took a detour",
+		Evidence:    []types.DisputeEvidence{{Description: "screenshot", URL: "https://example.com/map.png"}, {}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, types.DisputeStatusOpen, dispute.Status)
+	assert.Equal(t, "driver-1", dispute.DriverID)
+	assert.Equal(t, 25.0, dispute.ChargedAmount)
+	assert.Len(t, dispute.Evidence, 1)
+	require.NotNil(t, dispute.Fare)
+
+	// One dispute per trip at a time
+	_, err = disputes.OpenDispute(ctx, &OpenDisputeRequest{TripID: trip.ID, RiderID: "rider-1", Reason: types.DisputeReasonOvercharged})
+	assert.ErrorIs(t, err, ErrDisputeExists)
+
+	review, err := disputes.ReviewDispute(ctx, dispute.ID)
+	require.NoError(t, err)
+	assert.False(t, review.RouteRecorded)
+
+	_, err = disputes.StartReview(ctx, dispute.ID, "support-1")
+	require.NoError(t, err)
+	_, err = disputes.AddNote(ctx, dispute.ID, "support-1", "route is 3km longer than expected")
+	require.NoError(t, err)
+
+	// Refunds need payment-service
+	_, err = disputes.ResolveDispute(ctx, dispute.ID, &ResolveDisputeRequest{StaffID: "support-1", Resolution: "detour", RefundAmount: 5})
+	assert.ErrorIs(t, err, ErrRefundsUnavailable)
+
+	refunder := &recordingRefunder{err: errors.New("declined")}
+	disputes.SetRefunder(refunder)
+	_, err = disputes.ResolveDispute(ctx, dispute.ID, &ResolveDisputeRequest{StaffID: "support-1", Resolution: "detour", RefundAmount: 30})
+	assert.ErrorIs(t, err, ErrRefundExceedsCharge)
+	_, err = disputes.ResolveDispute(ctx, dispute.ID, &ResolveDisputeRequest{StaffID: "support-1", Resolution: "detour", RefundAmount: 5})
+	assert.ErrorIs(t, err, ErrRefundFailed)
+
+	// A failed refund leaves the dispute open to retry
+	stored, err := disputes.GetDispute(ctx, dispute.ID)
+	require.NoError(t, err)
+	assert.Equal(t, types.DisputeStatusInReview, stored.Status)
+
+	refunder.err = nil
+	resolved, err := disputes.ResolveDispute(ctx, dispute.ID, &ResolveDisputeRequest{StaffID: "support-1", Resolution: "detour", RefundAmount: 5.004})
+	require.NoError(t, err)
+	assert.Equal(t, types.DisputeStatusResolved, resolved.Status)
+	assert.Equal(t, []float64{5}, refunder.amounts)
+	assert.Equal(t, 5.0, resolved.RefundAmount)
+	assert.Equal(t, "refund-1", resolved.RefundID)
+	assert.Equal(t, "support-1", resolved.ResolvedBy)
+	require.NotNil(t, resolved.ResolvedAt)
+	assert.Len(t, resolved.Notes, 1)
+
+	_, err = disputes.RejectDispute(ctx, dispute.ID, "support-1", "changed my mind")
+	assert.ErrorIs(t, err, ErrDisputeClosed)
+
+	// A closed dispute no longer blocks a new one
+	_, err = disputes.OpenDispute(ctx, &OpenDisputeRequest{TripID: trip.ID, RiderID: "rider-1", Reason: types.DisputeReasonOvercharged})
+	require.NoError(t, err)
+}
+
+func TestDisputeService_Reject(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("test", "info")
+	store := repository.NewMemoryTripStore()
+	trips := NewTripService(store, log)
+	disputes := NewDisputeService(store, repository.NewMemoryDisputeStore(), DefaultDisputeConfig(), log)
+
+	active, err := trips.CreateTrip(ctx, &CreateTripRequest{
+		RiderID:             "rider-1",
+		PickupLocation:      models.Location{Latitude: 41.008237, Longitude: 28.978359},
+		DestinationLocation: models.Location{Latitude: 41.042211, Longitude: 29.008311},
+		RideType:            "standard",
+		EstimatedFare:       20,
+	})
+	require.NoError(t, err)
+	_, err = disputes.OpenDispute(ctx, &OpenDisputeRequest{TripID: active.ID, RiderID: "rider-1", Reason: types.DisputeReasonOvercharged})
+	assert.ErrorIs(t, err, ErrTripNotDisputable)
+
+	trip := newDisputeTestTrip(t, trips)
+	dispute, err := disputes.OpenDispute(ctx, &OpenDisputeRequest{TripID: trip.ID, RiderID: "rider-1", Reason: types.DisputeReasonOvercharged})
+	require.NoError(t, err)
+
+	rejected, err := disputes.RejectDispute(ctx, dispute.ID, "support-2", "fare matches the route")
+	require.NoError(t, err)
+	assert.Equal(t, types.DisputeStatusRejected, rejected.Status)
+	assert.Equal(t, "support-2", rejected.AssignedTo)
+	assert.Zero(t, rejected.RefundAmount)
+
+	open, err := disputes.ListDisputes(ctx, types.DisputeStatusOpen, 0)
+	require.NoError(t, err)
+	assert.Empty(t, open)
+	all, err := disputes.ListDisputes(ctx, "", 0)
+	require.NoError(t, err)
+	assert.Len(t, all, 1)
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/shared/events"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+)
+
+// ErrRiderLocationNotShared is returned for rider locations sent when no
+// driver is on the way to pick the rider up
+var ErrRiderLocationNotShared = errors.New("rider locations are only shared while a driver is on the way to the pickup")
+
+// driverEventBuffer is how many events a driver can fall behind by before
+// further events are dropped for them
+const driverEventBuffer = 32
+
+// Driver event types
+const (
+	DriverEventTripAssigned  = "trip_assigned"
+	DriverEventTripCancelled = "trip_cancelled"
+	DriverEventRiderLocation = "rider_location"
+	DriverEventLostItem      = "lost_item_reported"
+)
+
+// DriverEvent is a real-time event for a driver about one of their trips
+type DriverEvent struct {
+	ID            string           `json:"id"`
+	Type          string           `json:"type"`
+	DriverID      string           `json:"driver_id"`
+	TripID        string           `json:"trip_id"`
+	RiderID       string           `json:"rider_id"`
+	RiderLocation *models.Location `json:"rider_location,omitempty"`
+	CancelledBy   string           `json:"cancelled_by,omitempty"`
+	Reason        string           `json:"reason,omitempty"`
+	LostItemID    string           `json:"lost_item_id,omitempty"`
+	Description   string           `json:"description,omitempty"`
+	OccurredAt    time.Time        `json:"occurred_at"`
+}
+
+// driverEventTypes maps the bus events drivers follow to their driver event type
+var driverEventTypes = map[events.EventType]string{
+	events.TripMatchedEvent:              DriverEventTripAssigned,
+	events.TripCancelledEvent:            DriverEventTripCancelled,
+	events.TripRiderLocationUpdatedEvent: DriverEventRiderLocation,
+	events.TripLostItemReportedEvent:     DriverEventLostItem,
+}
+
+// DriverEventSubscription receives the events of one connected driver
+type DriverEventSubscription struct {
+	Events <-chan *DriverEvent
+
+	hub      *DriverEventHub
+	driverID string
+	events   chan *DriverEvent
+}
+
+// Close stops the subscription
+func (s *DriverEventSubscription) Close() {
+	s.hub.unsubscribe(s)
+}
+
+// DriverEventHub turns trip events published on the event bus into events
+// for the drivers they concern and hands them to the drivers connected to
+// this instance. Events for drivers that are not connected are dropped; the
+// driver app reads the trip's state when it reconnects.
+type DriverEventHub struct {
+	logger *logger.Logger
+
+	mutex       sync.Mutex
+	subscribers map[string]map[*DriverEventSubscription]bool
+}
+
+// NewDriverEventHub creates a new driver event hub
+func NewDriverEventHub(logger *logger.Logger) *DriverEventHub {
+	return &DriverEventHub{
+		logger:      logger,
+		subscribers: make(map[string]map[*DriverEventSubscription]bool),
+	}
+}
+
+// SubscribeEvents follows the trip events drivers are told about
+func (h *DriverEventHub) SubscribeEvents(publisher *events.EventPublisher) error {
+	for eventType := range driverEventTypes {
+		if err := publisher.Subscribe(eventType, h.handle); err != nil {
+			return fmt.Errorf("failed to subscribe to %s events: %w", eventType, err)
+		}
+	}
+	return nil
+}
+
+// Subscribe connects a driver to their events
+func (h *DriverEventHub) Subscribe(driverID string) (*DriverEventSubscription, error) {
+	if driverID == "" {
+		return nil, fmt.Errorf("driver ID is required")
+	}
+
+	driverEvents := make(chan *DriverEvent, driverEventBuffer)
+	subscription := &DriverEventSubscription{
+		Events:   driverEvents,
+		hub:      h,
+		driverID: driverID,
+		events:   driverEvents,
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	if h.subscribers[driverID] == nil {
+		h.subscribers[driverID] = make(map[*DriverEventSubscription]bool)
+	}
+	h.subscribers[driverID][subscription] = true
+	return subscription, nil
+}
+
+func (h *DriverEventHub) unsubscribe(subscription *DriverEventSubscription) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	delete(h.subscribers[subscription.driverID], subscription)
+	if len(h.subscribers[subscription.driverID]) == 0 {
+		delete(h.subscribers, subscription.driverID)
+	}
+}
+
+// handle delivers a bus event to the connected driver it concerns
+func (h *DriverEventHub) handle(ctx context.Context, event *events.Event) error {
+	driverEvent := driverEventFromBus(event)
+	if driverEvent == nil {
+		return nil
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	for subscription := range h.subscribers[driverEvent.DriverID] {
+		select {
+		case subscription.events <- driverEvent:
+		default:
+			h.logger.WithContext(ctx).WithFields(logger.Fields{
+				"driver_id": driverEvent.DriverID,
+				"event_id":  driverEvent.ID,
+			}).Warn("Driver event subscriber is full, dropping event")
+		}
+	}
+	return nil
+}
+
+// driverEventFromBus returns the driver event for a bus event, or nil when
+// the event names no driver
+func driverEventFromBus(event *events.Event) *DriverEvent {
+	eventType, ok := driverEventTypes[event.Type]
+	if !ok {
+		return nil
+	}
+	driverID, _ := event.Data["driver_id"].(string)
+	if driverID == "" {
+		return nil
+	}
+
+	driverEvent := &DriverEvent{
+		ID:         event.ID,
+		Type:       eventType,
+		DriverID:   driverID,
+		TripID:     event.AggregateID,
+		OccurredAt: event.Timestamp,
+	}
+	driverEvent.RiderID, _ = event.Data["rider_id"].(string)
+	driverEvent.CancelledBy, _ = event.Data["cancelled_by"].(string)
+	driverEvent.Reason, _ = event.Data["reason"].(string)
+	driverEvent.LostItemID, _ = event.Data["lost_item_id"].(string)
+	driverEvent.Description, _ = event.Data["description"].(string)
+
+	latitude, hasLatitude := event.Data["latitude"].(float64)
+	longitude, hasLongitude := event.Data["longitude"].(float64)
+	if hasLatitude && hasLongitude {
+		driverEvent.RiderLocation = &models.Location{Latitude: latitude, Longitude: longitude}
+	}
+	return driverEvent
+}
+
+// RiderLocationRecipient returns the driver a rider's location is shared
+// with: the driver of their trip, until the rider is picked up
+func (s *TripService) RiderLocationRecipient(ctx context.Context, tripID, riderID string) (string, error) {
+	if tripID == "" || riderID == "" {
+		return "", fmt.Errorf("trip ID and rider ID are required")
+	}
+
+	trip, err := s.tripRepo.GetByID(ctx, tripID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get trip: %w", err)
+	}
+	if trip.RiderID != riderID {
+		return "", ErrTripNotOwned
+	}
+	if !trip.HasDriver() {
+		return "", ErrRiderLocationNotShared
+	}
+	switch trip.Status {
+	case models.TripStatusMatched, models.TripStatusDriverAssigned, models.TripStatusDriverArriving, models.TripStatusDriverArrived:
+		return *trip.DriverID, nil
+	default:
+		return "", ErrRiderLocationNotShared
+	}
+}
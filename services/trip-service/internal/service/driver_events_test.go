@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rideshare-platform/services/trip-service/internal/repository"
+	"github.com/rideshare-platform/shared/events"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func receiveDriverEvent(t *testing.T, subscription *DriverEventSubscription) *DriverEvent {
+	t.Helper()
+	select {
+	case event := <-subscription.Events:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("no driver event received")
+		return nil
+	}
+}
+
+func TestDriverEventHub_DeliversDriversTheirEvents(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("test", "info")
+	publisher := events.NewEventPublisher(events.NewInMemoryEventBus(log), events.NewInMemoryEventStore(log), log)
+	hub := NewDriverEventHub(log)
+	require.NoError(t, hub.SubscribeEvents(publisher))
+
+	subscription, err := hub.Subscribe("driver-1")
+	require.NoError(t, err)
+	defer subscription.Close()
+
+	// Another driver's assignment is not delivered
+	require.NoError(t, publisher.PublishEvent(ctx, events.NewEvent(events.TripMatchedEvent, "trip-2", 1, map[string]interface{}{
+		"trip_id": "trip-2", "rider_id": "rider-2", "driver_id": "driver-2",
+	}, "test")))
+	require.NoError(t, publisher.PublishEvent(ctx, events.NewEvent(events.TripMatchedEvent, "trip-1", 1, map[string]interface{}{
+		"trip_id": "trip-1", "rider_id": "rider-1", "driver_id": "driver-1",
+	}, "test")))
+	assigned := receiveDriverEvent(t, subscription)
+	assert.Equal(t, DriverEventTripAssigned, assigned.Type)
+	assert.Equal(t, "trip-1", assigned.TripID)
+	assert.Equal(t, "rider-1", assigned.RiderID)
+
+	require.NoError(t, publisher.PublishEvent(ctx, events.NewEvent(events.TripRiderLocationUpdatedEvent, "trip-1", 1, map[string]interface{}{
+		"trip_id": "trip-1", "rider_id": "rider-1", "driver_id": "driver-1", "latitude": 41.01, "longitude": 28.98,
+	}, "test")))
+	location := receiveDriverEvent(t, subscription)
+	assert.Equal(t, DriverEventRiderLocation, location.Type)
+	require.NotNil(t, location.RiderLocation)
+	assert.Equal(t, 41.01, location.RiderLocation.Latitude)
+
+	require.NoError(t, publisher.PublishEvent(ctx, events.NewEvent(events.TripCancelledEvent, "trip-1", 1, map[string]interface{}{
+		"trip_id": "trip-1", "rider_id": "rider-1", "driver_id": "driver-1", "cancelled_by": "rider", "reason": "changed plans",
+	}, "test")))
+	cancelled := receiveDriverEvent(t, subscription)
+	assert.Equal(t, DriverEventTripCancelled, cancelled.Type)
+	assert.Equal(t, "rider", cancelled.CancelledBy)
+	assert.Equal(t, "changed plans", cancelled.Reason)
+
+	select {
+	case event := <-subscription.Events:
+		t.Fatalf("unexpected driver event %+v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestDriverEventHub_Unsubscribe(t *testing.T) {
+	hub := NewDriverEventHub(logger.NewLogger("test", "info"))
+
+	_, err := hub.Subscribe("")
+	assert.Error(t, err)
+
+	subscription, err := hub.Subscribe("driver-1")
+	require.NoError(t, err)
+	subscription.Close()
+	assert.Empty(t, hub.subscribers)
+}
+
+func TestTripService_RiderLocationRecipient(t *testing.T) {
+	ctx := context.Background()
+	trips := NewTripService(repository.NewMemoryTripStore(), logger.NewLogger("test", "info"))
+
+	trip, err := trips.CreateTrip(ctx, &CreateTripRequest{
+		RiderID:             "rider-1",
+		PickupLocation:      models.Location{Latitude: 41.008237, Longitude: 28.978359},
+		DestinationLocation: models.Location{Latitude: 41.042211, Longitude: 29.008311},
+		RideType:            "standard",
+		EstimatedFare:       20,
+		Currency:            "EUR",
+	})
+	require.NoError(t, err)
+
+	_, err = trips.RiderLocationRecipient(ctx, trip.ID, "rider-1")
+	assert.ErrorIs(t, err, ErrRiderLocationNotShared, "no driver is on the way yet")
+
+	_, err = trips.AcceptTrip(ctx, trip.ID, "driver-1")
+	require.NoError(t, err)
+
+	_, err = trips.RiderLocationRecipient(ctx, trip.ID, "rider-2")
+	assert.ErrorIs(t, err, ErrTripNotOwned)
+
+	driverID, err := trips.RiderLocationRecipient(ctx, trip.ID, "rider-1")
+	require.NoError(t, err)
+	assert.Equal(t, "driver-1", driverID)
+
+	// Once picked up the driver no longer needs to find the rider
+	_, err = trips.StartTrip(ctx, trip.ID)
+	require.NoError(t, err)
+	_, err = trips.RiderLocationRecipient(ctx, trip.ID, "rider-1")
+	assert.ErrorIs(t, err, ErrRiderLocationNotShared)
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/events"
+)
+
+// DriverReliabilityService tracks how often drivers cancel the trips they
+// take on and how many they have completed in a row, from trip events.
+// matching-service scores drivers on it. Older trips count less, halving
+// every half-life of the store, so drivers recover from a bad stretch.
+type DriverReliabilityService struct {
+	store types.DriverReliabilityStore
+}
+
+// NewDriverReliabilityService creates a new driver reliability service
+func NewDriverReliabilityService(store types.DriverReliabilityStore) *DriverReliabilityService {
+	return &DriverReliabilityService{store: store}
+}
+
+// SubscribeEvents counts completed trips and trips cancelled by their driver.
+// Trips cancelled by riders or operators do not count against the driver.
+func (s *DriverReliabilityService) SubscribeEvents(publisher events.Subscriber) error {
+	handlers := map[events.EventType]events.EventHandler{
+		events.TripCompletedEvent: s.handleCompleted,
+		events.TripCancelledEvent: s.handleCancelled,
+	}
+	for eventType, handler := range handlers {
+		if err := publisher.Subscribe(eventType, handler); err != nil {
+			return fmt.Errorf("failed to subscribe to %s events: %w", eventType, err)
+		}
+	}
+	return nil
+}
+
+// RecordTrip counts a driver's completed or cancelled trip at the given
+// time. A trip counted already is ignored.
+func (s *DriverReliabilityService) RecordTrip(ctx context.Context, driverID, tripID string, cancelled bool, at time.Time) (*types.DriverReliability, error) {
+	if driverID == "" || tripID == "" {
+		return nil, fmt.Errorf("driver ID and trip ID are required")
+	}
+
+	reliability, err := s.store.RecordTrip(ctx, driverID, tripID, cancelled, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record driver trip: %w", err)
+	}
+	return reliability, nil
+}
+
+// GetDriverReliabilities returns the reliability of the given drivers as of
+// now, leaving out drivers without trips
+func (s *DriverReliabilityService) GetDriverReliabilities(ctx context.Context, driverIDs []string) (map[string]*types.DriverReliability, error) {
+	reliabilities, err := s.store.GetReliabilities(ctx, driverIDs, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get driver reliabilities: %w", err)
+	}
+	return reliabilities, nil
+}
+
+func (s *DriverReliabilityService) handleCompleted(ctx context.Context, event *events.Event) error {
+	driverID, _ := event.Data["driver_id"].(string)
+	if driverID == "" {
+		return nil
+	}
+	_, err := s.RecordTrip(ctx, driverID, event.AggregateID, false, event.Timestamp)
+	return err
+}
+
+func (s *DriverReliabilityService) handleCancelled(ctx context.Context, event *events.Event) error {
+	driverID, _ := event.Data["driver_id"].(string)
+	cancelledBy, _ := event.Data["cancelled_by"].(string)
+	if driverID == "" || cancelledBy != "driver" {
+		return nil
+	}
+	_, err := s.RecordTrip(ctx, driverID, event.AggregateID, true, event.Timestamp)
+	return err
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rideshare-platform/services/trip-service/internal/repository"
+	"github.com/rideshare-platform/shared/events"
+)
+
+func TestDriverReliabilityService_CountsDriverCancellations(t *testing.T) {
+	ctx := context.Background()
+	reliability := NewDriverReliabilityService(repository.NewMemoryDriverReliabilityStore(time.Hour))
+	now := time.Now()
+
+	completed := func(tripID string) *events.Event {
+		event := events.NewEvent(events.TripCompletedEvent, tripID, 1, map[string]interface{}{"driver_id": "driver-1"}, "trip-service")
+		event.Timestamp = now
+		return event
+	}
+	cancelled := func(tripID, by string) *events.Event {
+		event := events.NewEvent(events.TripCancelledEvent, tripID, 1, map[string]interface{}{"driver_id": "driver-1", "cancelled_by": by}, "trip-service")
+		event.Timestamp = now
+		return event
+	}
+
+	require.NoError(t, reliability.handleCompleted(ctx, completed("trip-1")))
+	require.NoError(t, reliability.handleCompleted(ctx, completed("trip-1")), "a repeated event is counted once")
+	require.NoError(t, reliability.handleCompleted(ctx, completed("trip-2")))
+	require.NoError(t, reliability.handleCancelled(ctx, cancelled("trip-3", "rider")), "rider cancellations do not count")
+	require.NoError(t, reliability.handleCompleted(ctx, completed("trip-4")))
+
+	drivers, err := reliability.GetDriverReliabilities(ctx, []string{"driver-1", "driver-2"})
+	require.NoError(t, err)
+	require.Len(t, drivers, 1)
+	assert.InDelta(t, 3, drivers["driver-1"].Trips, 0.01)
+	assert.InDelta(t, 3, drivers["driver-1"].CompletionStreak, 0.01)
+	assert.Zero(t, drivers["driver-1"].CancellationRate())
+
+	require.NoError(t, reliability.handleCancelled(ctx, cancelled("trip-5", "driver")))
+	drivers, err = reliability.GetDriverReliabilities(ctx, []string{"driver-1"})
+	require.NoError(t, err)
+	assert.InDelta(t, 0.25, drivers["driver-1"].CancellationRate(), 0.01)
+	assert.Zero(t, drivers["driver-1"].CompletionStreak, "a cancellation ends the streak")
+}
+
+func TestDriverReliabilityService_OldTripsDecay(t *testing.T) {
+	ctx := context.Background()
+	reliability := NewDriverReliabilityService(repository.NewMemoryDriverReliabilityStore(time.Hour))
+	now := time.Now()
+
+	_, err := reliability.RecordTrip(ctx, "driver-1", "trip-1", true, now.Add(-3*time.Hour))
+	require.NoError(t, err)
+	record, err := reliability.RecordTrip(ctx, "driver-1", "trip-2", false, now.Add(-time.Hour))
+	require.NoError(t, err)
+
+	// The cancellation three hours ago counts a quarter as much as the trip
+	// completed an hour ago
+	assert.InDelta(t, 1.25, record.Trips, 0.001)
+	assert.InDelta(t, 0.2, record.CancellationRate(), 0.001)
+
+	drivers, err := reliability.GetDriverReliabilities(ctx, []string{"driver-1"})
+	require.NoError(t, err)
+	assert.InDelta(t, 0.625, drivers["driver-1"].Trips, 0.01)
+	assert.InDelta(t, 0.5, drivers["driver-1"].CompletionStreak, 0.01)
+	assert.InDelta(t, 0.2, drivers["driver-1"].CancellationRate(), 0.001, "decay keeps the rate")
+}
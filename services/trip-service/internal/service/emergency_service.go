@@ -0,0 +1,390 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/alerting"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+)
+
+var (
+	// ErrNotTripMember is returned when someone other than the trip's rider
+	// or driver raises an emergency for it
+	ErrNotTripMember = errors.New("only the trip's rider or driver can raise an emergency")
+	// ErrIncidentResolved is returned when acting on an incident that has been resolved
+	ErrIncidentResolved = errors.New("incident has already been resolved")
+)
+
+// EmergencyAlertRuleID identifies emergency alerts in the alert store
+const EmergencyAlertRuleID = "trip_sos"
+
+// IncidentAlerter raises alerts that no metric rule watches for, and
+// resolves them once handled, normally shared/alerting's AlertManager
+type IncidentAlerter interface {
+	RaiseAlert(ctx context.Context, alert *alerting.Alert, actions []alerting.AlertAction) error
+	ResolveRaisedAlert(ctx context.Context, alert *alerting.Alert) error
+}
+
+// EmergencyAlertActions returns where emergency alerts are sent. Being
+// critical, they are also paged to on-call when an escalation channel is set.
+func EmergencyAlertActions() []alerting.AlertAction {
+	return []alerting.AlertAction{
+		{Type: "slack", Target: "#safety-incidents", Enabled: true},
+		{Type: "email", Target: "safety@rideshare.com", Enabled: true},
+	}
+}
+
+// SOSRequest is an emergency raised by a trip's rider or driver
+type SOSRequest struct {
+	TripID     string           `json:"-"`
+	ReporterID string           `json:"reporter_id" binding:"required"`
+	Location   *models.Location `json:"location,omitempty"`
+	Message    string           `json:"message,omitempty"`
+}
+
+// EmergencyService records emergencies raised during trips, alerts the
+// operations team and tracks support staff's handling of them
+type EmergencyService struct {
+	trips  TripRepositoryInterface
+	store  types.IncidentStore
+	alerts IncidentAlerter
+	logger *logger.Logger
+
+	// mutex serialises incident updates, which read, change and save
+	mutex sync.Mutex
+}
+
+// NewEmergencyService creates a new emergency service
+func NewEmergencyService(trips TripRepositoryInterface, store types.IncidentStore, logger *logger.Logger) *EmergencyService {
+	return &EmergencyService{
+		trips:  trips,
+		store:  store,
+		logger: logger,
+	}
+}
+
+// SetAlerter attaches the alerting used to notify the operations team.
+// Without one incidents are only recorded and logged.
+func (s *EmergencyService) SetAlerter(alerts IncidentAlerter) {
+	s.alerts = alerts
+}
+
+// ReportSOS records an emergency for a trip and alerts the operations team
+// at critical severity. The trip's location trail is frozen into the
+// incident. Raising it again while the trip's incident is unresolved adds to
+// that incident instead of alerting again.
+func (s *EmergencyService) ReportSOS(ctx context.Context, req *SOSRequest) (*types.Incident, error) {
+	if req.TripID == "" || req.ReporterID == "" {
+		return nil, fmt.Errorf("trip ID and reporter ID are required")
+	}
+	if req.Location != nil && !req.Location.IsValid() {
+		return nil, fmt.Errorf("invalid location coordinates")
+	}
+
+	trip, err := s.trips.GetByID(ctx, req.TripID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trip: %w", err)
+	}
+	role, err := tripMemberRole(trip, req.ReporterID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	if incident, err := s.openIncidentForTrip(ctx, trip.ID); err != nil {
+		return nil, err
+	} else if incident != nil {
+		if req.Location != nil {
+			incident.LocationTrail = append(incident.LocationTrail, *req.Location)
+		}
+		incident.Notes = append(incident.Notes, &types.IncidentNote{
+			AuthorID:  req.ReporterID,
+			Text:      sosNote(role, req.Message),
+			CreatedAt: now,
+		})
+		incident.UpdatedAt = now
+		if err := s.store.SaveIncident(ctx, incident); err != nil {
+			return nil, fmt.Errorf("failed to save incident: %w", err)
+		}
+		return incident, nil
+	}
+
+	incident := &types.Incident{
+		ID:            generateIncidentID(),
+		TripID:        trip.ID,
+		RiderID:       trip.RiderID,
+		ReporterID:    req.ReporterID,
+		ReporterRole:  role,
+		Status:        types.IncidentStatusOpen,
+		TripStatus:    trip.Status,
+		Location:      req.Location,
+		LocationTrail: freezeLocationTrail(trip, req.Location),
+		Notes: []*types.IncidentNote{{
+			AuthorID:  req.ReporterID,
+			Text:      sosNote(role, req.Message),
+			CreatedAt: now,
+		}},
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if trip.DriverID != nil {
+		incident.DriverID = *trip.DriverID
+	}
+
+	// The incident is recorded even if nobody could be alerted, so support
+	// staff still find it in the queue
+	incident.AlertID = s.raiseAlert(ctx, incident)
+	if err := s.store.SaveIncident(ctx, incident); err != nil {
+		return nil, fmt.Errorf("failed to save incident: %w", err)
+	}
+
+	s.logger.WithContext(ctx).WithFields(logger.Fields{
+		"incident_id":   incident.ID,
+		"trip_id":       incident.TripID,
+		"reporter_role": role,
+	}).Error("Trip SOS raised")
+
+	return incident, nil
+}
+
+// GetIncident returns an incident
+func (s *EmergencyService) GetIncident(ctx context.Context, incidentID string) (*types.Incident, error) {
+	return s.store.GetIncident(ctx, incidentID)
+}
+
+// ListIncidents returns up to limit incidents in a status, or in any status
+// when it is empty, newest first
+func (s *EmergencyService) ListIncidents(ctx context.Context, status types.IncidentStatus, limit int) ([]*types.Incident, error) {
+	switch status {
+	case "", types.IncidentStatusOpen, types.IncidentStatusAcknowledged, types.IncidentStatusResolved:
+	default:
+		return nil, fmt.Errorf("unknown incident status %q", status)
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+
+	incidents, err := s.store.ListIncidents(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+	if len(incidents) > limit {
+		incidents = incidents[:limit]
+	}
+	return incidents, nil
+}
+
+// AcknowledgeIncident records that a member of staff has taken an incident
+// on. Acknowledging it again keeps the first acknowledgement.
+func (s *EmergencyService) AcknowledgeIncident(ctx context.Context, incidentID, staffID string) (*types.Incident, error) {
+	if staffID == "" {
+		return nil, fmt.Errorf("staff ID is required")
+	}
+	return s.update(ctx, incidentID, func(incident *types.Incident, now time.Time) error {
+		switch incident.Status {
+		case types.IncidentStatusResolved:
+			return ErrIncidentResolved
+		case types.IncidentStatusOpen:
+			incident.Status = types.IncidentStatusAcknowledged
+			incident.AcknowledgedBy = staffID
+			incident.AcknowledgedAt = &now
+		}
+		return nil
+	})
+}
+
+// AddIncidentNote adds a note to an incident's timeline. Notes can be added
+// after resolution for follow-ups.
+func (s *EmergencyService) AddIncidentNote(ctx context.Context, incidentID, authorID, text string) (*types.Incident, error) {
+	text = strings.TrimSpace(text)
+	if authorID == "" || text == "" {
+		return nil, fmt.Errorf("author ID and note text are required")
+	}
+	return s.update(ctx, incidentID, func(incident *types.Incident, now time.Time) error {
+		incident.Notes = append(incident.Notes, &types.IncidentNote{
+			AuthorID:  authorID,
+			Text:      text,
+			CreatedAt: now,
+		})
+		return nil
+	})
+}
+
+// ResolveIncident closes an incident with how it was resolved, and resolves
+// its alert so on-call is no longer paged for it
+func (s *EmergencyService) ResolveIncident(ctx context.Context, incidentID, staffID, resolution string) (*types.Incident, error) {
+	resolution = strings.TrimSpace(resolution)
+	if staffID == "" || resolution == "" {
+		return nil, fmt.Errorf("staff ID and resolution are required")
+	}
+	incident, err := s.update(ctx, incidentID, func(incident *types.Incident, now time.Time) error {
+		if incident.Status == types.IncidentStatusResolved {
+			return ErrIncidentResolved
+		}
+		if incident.AcknowledgedAt == nil {
+			incident.AcknowledgedBy = staffID
+			incident.AcknowledgedAt = &now
+		}
+		incident.Status = types.IncidentStatusResolved
+		incident.ResolvedBy = staffID
+		incident.ResolvedAt = &now
+		incident.Resolution = resolution
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.resolveAlert(ctx, incident)
+	return incident, nil
+}
+
+func (s *EmergencyService) update(ctx context.Context, incidentID string, change func(*types.Incident, time.Time) error) (*types.Incident, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	incident, err := s.store.GetIncident(ctx, incidentID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if err := change(incident, now); err != nil {
+		return nil, err
+	}
+	incident.UpdatedAt = now
+	if err := s.store.SaveIncident(ctx, incident); err != nil {
+		return nil, fmt.Errorf("failed to save incident: %w", err)
+	}
+
+	s.logger.WithContext(ctx).WithFields(logger.Fields{
+		"incident_id": incident.ID,
+		"status":      incident.Status,
+	}).Info("Incident updated")
+	return incident, nil
+}
+
+// openIncidentForTrip returns the trip's unresolved incident, if it has one
+func (s *EmergencyService) openIncidentForTrip(ctx context.Context, tripID string) (*types.Incident, error) {
+	incidents, err := s.store.GetIncidentsByTrip(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+	for _, incident := range incidents {
+		if incident.Status != types.IncidentStatusResolved {
+			return incident, nil
+		}
+	}
+	return nil, nil
+}
+
+// raiseAlert notifies the operations team and returns the alert's ID, or ""
+// if it could not be raised
+func (s *EmergencyService) raiseAlert(ctx context.Context, incident *types.Incident) string {
+	if s.alerts == nil {
+		return ""
+	}
+
+	alert := emergencyAlert(incident)
+	if err := s.alerts.RaiseAlert(ctx, alert, EmergencyAlertActions()); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+			"incident_id": incident.ID,
+		}).Error("Failed to alert operations about trip SOS")
+		return ""
+	}
+	return alert.ID
+}
+
+// resolveAlert resolves the alert raised for an incident. A failure is
+// logged; the incident stays resolved.
+func (s *EmergencyService) resolveAlert(ctx context.Context, incident *types.Incident) {
+	if s.alerts == nil || incident.AlertID == "" {
+		return
+	}
+
+	if err := s.alerts.ResolveRaisedAlert(ctx, emergencyAlert(incident)); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+			"incident_id": incident.ID,
+			"alert_id":    incident.AlertID,
+		}).Error("Failed to resolve trip SOS alert")
+	}
+}
+
+// emergencyAlert returns the critical alert for an incident. Each incident
+// has its own incident key, so concurrent SOSes page separately and
+// resolving one does not close the others.
+func emergencyAlert(incident *types.Incident) *alerting.Alert {
+	metadata := map[string]interface{}{
+		"incident_id":   incident.ID,
+		"trip_id":       incident.TripID,
+		"reporter_id":   incident.ReporterID,
+		"reporter_role": incident.ReporterRole,
+		"trip_status":   incident.TripStatus,
+	}
+	if last := len(incident.LocationTrail); last > 0 {
+		metadata["latitude"] = incident.LocationTrail[last-1].Latitude
+		metadata["longitude"] = incident.LocationTrail[last-1].Longitude
+	}
+	return &alerting.Alert{
+		ID:          "sos_" + incident.ID,
+		RuleID:      EmergencyAlertRuleID,
+		IncidentKey: incident.ID,
+		Severity:    alerting.SeverityCritical,
+		Title:       "Trip SOS raised",
+		Description: fmt.Sprintf("The %s of trip %s raised an emergency", incident.ReporterRole, incident.TripID),
+		Service:     "trip-service",
+		Metadata:    metadata,
+		CreatedAt:   incident.CreatedAt,
+	}
+}
+
+// tripMemberRole returns whether userID rides or drives the trip
+func tripMemberRole(trip *models.Trip, userID string) (string, error) {
+	switch {
+	case trip.RiderID == userID:
+		return "rider", nil
+	case trip.DriverID != nil && *trip.DriverID == userID:
+		return "driver", nil
+	default:
+		return "", ErrNotTripMember
+	}
+}
+
+// freezeLocationTrail copies the route the trip has taken so far, then the
+// driver's last known position and where the reporter is, if they differ
+func freezeLocationTrail(trip *models.Trip, reported *models.Location) []models.Location {
+	trail := []models.Location{}
+	if trip.ActualRoute != nil {
+		trail = append(trail, *trip.ActualRoute...)
+	}
+	for _, location := range []*models.Location{trip.DriverLocation, reported} {
+		if location == nil {
+			continue
+		}
+		if last := len(trail); last > 0 && trail[last-1] == *location {
+			continue
+		}
+		trail = append(trail, *location)
+	}
+	return trail
+}
+
+func sosNote(role, message string) string {
+	if message = strings.TrimSpace(message); message != "" {
+		return fmt.Sprintf("SOS raised by the %s: %s", role, message)
+	}
+	return fmt.Sprintf("SOS raised by the %s", role)
+}
+
+func generateIncidentID() string {
+	return fmt.Sprintf("incident_%d", time.Now().UnixNano())
+}
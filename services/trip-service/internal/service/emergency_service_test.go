@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rideshare-platform/services/trip-service/internal/repository"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/alerting"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+)
+
+type recordingIncidentAlerter struct {
+	alerts   []*alerting.Alert
+	resolved []*alerting.Alert
+}
+
+func (r *recordingIncidentAlerter) RaiseAlert(ctx context.Context, alert *alerting.Alert, actions []alerting.AlertAction) error {
+	r.alerts = append(r.alerts, alert)
+	return nil
+}
+
+func (r *recordingIncidentAlerter) ResolveRaisedAlert(ctx context.Context, alert *alerting.Alert) error {
+	r.resolved = append(r.resolved, alert)
+	return nil
+}
+
+func TestEmergencyService_SOSLifecycle(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("test", "info")
+	store := repository.NewMemoryTripStore()
+	trips := NewTripService(store, log)
+	emergencies := NewEmergencyService(store, repository.NewMemoryIncidentStore(), log)
+	alerter := &recordingIncidentAlerter{}
+	emergencies.SetAlerter(alerter)
+
+	trip, err := trips.CreateTrip(ctx, &CreateTripRequest{
+		RiderID:             "rider-1",
+		PickupLocation:      models.Location{Latitude: 41.008237, Longitude: 28.978359},
+		DestinationLocation: models.Location{Latitude: 41.042211, Longitude: 29.008311},
+		RideType:            "standard",
+		EstimatedFare:       20,
+	})
+	require.NoError(t, err)
+	_, err = trips.AcceptTrip(ctx, trip.ID, "driver-1")
+	require.NoError(t, err)
+	_, err = trips.StartTrip(ctx, trip.ID)
+	require.NoError(t, err)
+	_, err = trips.UpdateTripLocation(ctx, trip.ID, models.Location{Latitude: 41.01, Longitude: 28.98})
+	require.NoError(t, err)
+
+	_, err = emergencies.ReportSOS(ctx, &SOSRequest{TripID: trip.ID, ReporterID: "stranger"})
+	assert.ErrorIs(t, err, ErrNotTripMember)
+
+	reported := &models.Location{Latitude: 41.02, Longitude: 28.99}
+	incident, err := emergencies.ReportSOS(ctx, &SOSRequest{TripID: trip.ID, ReporterID: "rider-1", Location: reported, Message: "help"})
+	require.NoError(t, err)
+	assert.Equal(t, types.IncidentStatusOpen, incident.Status)
+	assert.Equal(t, "rider", incident.ReporterRole)
+	assert.Equal(t, "driver-1", incident.DriverID)
+	require.Len(t, alerter.alerts, 1)
+	assert.Equal(t, alerting.SeverityCritical, alerter.alerts[0].Severity)
+	assert.Equal(t, alerter.alerts[0].ID, incident.AlertID)
+	assert.Equal(t, "rideshare/"+EmergencyAlertRuleID+"/"+incident.ID, alerting.DedupKey(alerter.alerts[0]),
+		"each incident pages on its own")
+
+	trailLength := len(incident.LocationTrail)
+	require.GreaterOrEqual(t, trailLength, 2)
+	assert.Equal(t, *reported, incident.LocationTrail[trailLength-1])
+
+	// The frozen trail does not follow the trip
+	_, err = trips.UpdateTripLocation(ctx, trip.ID, models.Location{Latitude: 41.03, Longitude: 29.00})
+	require.NoError(t, err)
+	stored, err := emergencies.GetIncident(ctx, incident.ID)
+	require.NoError(t, err)
+	assert.Len(t, stored.LocationTrail, trailLength)
+
+	// A second SOS joins the open incident without alerting again
+	again, err := emergencies.ReportSOS(ctx, &SOSRequest{TripID: trip.ID, ReporterID: "driver-1"})
+	require.NoError(t, err)
+	assert.Equal(t, incident.ID, again.ID)
+	assert.Len(t, again.Notes, 2)
+	assert.Len(t, alerter.alerts, 1)
+
+	acknowledged, err := emergencies.AcknowledgeIncident(ctx, incident.ID, "support-1")
+	require.NoError(t, err)
+	assert.Equal(t, types.IncidentStatusAcknowledged, acknowledged.Status)
+	assert.Equal(t, "support-1", acknowledged.AcknowledgedBy)
+
+	_, err = emergencies.AddIncidentNote(ctx, incident.ID, "support-1", "called the rider, they are safe")
+	require.NoError(t, err)
+
+	_, err = emergencies.ResolveIncident(ctx, incident.ID, "support-1", "")
+	assert.Error(t, err, "a resolution is required")
+	resolved, err := emergencies.ResolveIncident(ctx, incident.ID, "support-1", "rider confirmed safe")
+	require.NoError(t, err)
+	assert.Equal(t, types.IncidentStatusResolved, resolved.Status)
+	assert.Len(t, resolved.Notes, 3)
+	require.Len(t, alerter.resolved, 1)
+	assert.Equal(t, incident.AlertID, alerter.resolved[0].ID)
+	assert.Equal(t, alerting.DedupKey(alerter.alerts[0]), alerting.DedupKey(alerter.resolved[0]))
+
+	_, err = emergencies.ResolveIncident(ctx, incident.ID, "support-2", "duplicate")
+	assert.ErrorIs(t, err, ErrIncidentResolved)
+	_, err = emergencies.AcknowledgeIncident(ctx, incident.ID, "support-2")
+	assert.ErrorIs(t, err, ErrIncidentResolved)
+	assert.Len(t, alerter.resolved, 1)
+
+	open, err := emergencies.ListIncidents(ctx, types.IncidentStatusOpen, 0)
+	require.NoError(t, err)
+	assert.Empty(t, open)
+	_, err = emergencies.ListIncidents(ctx, "closed", 0)
+	assert.Error(t, err)
+}
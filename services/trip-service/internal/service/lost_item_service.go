@@ -0,0 +1,299 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/events"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+)
+
+var (
+	// ErrLostItemTripNotEligible is returned when reporting a lost item on a
+	// trip that did not complete with a driver
+	ErrLostItemTripNotEligible = errors.New("lost items can only be reported for completed trips")
+	// ErrLostItemWindowClosed is returned when a report arrives after the reporting window
+	ErrLostItemWindowClosed = errors.New("lost item reporting window has closed")
+	// ErrLostItemReportExists is returned when the trip already has an open report
+	ErrLostItemReportExists = errors.New("trip already has an open lost item report")
+	// ErrLostItemClosed is returned when changing a report that has been closed
+	ErrLostItemClosed = errors.New("lost item report has already been closed")
+)
+
+// maxLostItemDescriptionLength is the longest description of a lost item, in characters
+const maxLostItemDescriptionLength = 500
+
+// LostItemConfig controls when lost items can be reported and how long the
+// rider and driver can message each other about them
+type LostItemConfig struct {
+	ReportWindow  time.Duration // how long after a trip completes items can be reported
+	ContactWindow time.Duration // how long the trip chat reopens for after a report or a find
+}
+
+// DefaultLostItemConfig returns the default lost and found settings
+func DefaultLostItemConfig() LostItemConfig {
+	return LostItemConfig{
+		ReportWindow:  14 * 24 * time.Hour,
+		ContactWindow: 72 * time.Hour,
+	}
+}
+
+// ReportLostItemRequest is a rider reporting something left behind on a trip
+type ReportLostItemRequest struct {
+	TripID      string `json:"-"`
+	RiderID     string `json:"rider_id" binding:"required"`
+	Description string `json:"description" binding:"required"`
+}
+
+// UpdateLostItemRequest moves a report to Status, adding Note to its
+// timeline. An empty Status only adds the note.
+type UpdateLostItemRequest struct {
+	AuthorID string               `json:"author_id" binding:"required"`
+	Status   types.LostItemStatus `json:"status,omitempty"`
+	Note     string               `json:"note,omitempty"`
+}
+
+// lostItemTransitions lists the statuses each open status can move to
+var lostItemTransitions = map[types.LostItemStatus][]types.LostItemStatus{
+	types.LostItemStatusReported: {types.LostItemStatusFound, types.LostItemStatusReturned, types.LostItemStatusNotFound},
+	types.LostItemStatusFound:    {types.LostItemStatusReturned, types.LostItemStatusNotFound},
+}
+
+// LostItemService lets riders report items left behind on completed trips.
+// The driver is notified through the event bus and the trip chat reopens so
+// the rider and driver can arrange a return without sharing contact details.
+// Support staff track reports across trips until they are closed.
+type LostItemService struct {
+	trips  TripRepositoryInterface
+	store  types.LostItemStore
+	config LostItemConfig
+	events *events.EventPublisher
+	logger *logger.Logger
+
+	// mutex serialises report changes, which read, change and save
+	mutex sync.Mutex
+}
+
+// NewLostItemService creates a new lost item service
+func NewLostItemService(trips TripRepositoryInterface, store types.LostItemStore, config LostItemConfig, logger *logger.Logger) *LostItemService {
+	return &LostItemService{
+		trips:  trips,
+		store:  store,
+		config: config,
+		logger: logger,
+	}
+}
+
+// SetEventPublisher attaches the publisher drivers are told about reports through
+func (s *LostItemService) SetEventPublisher(publisher *events.EventPublisher) {
+	s.events = publisher
+}
+
+// ReportLostItem records a rider's lost item, notifies the trip's driver and
+// opens the trip chat between them for the contact window
+func (s *LostItemService) ReportLostItem(ctx context.Context, req *ReportLostItemRequest) (*types.LostItemReport, error) {
+	if req.TripID == "" || req.RiderID == "" {
+		return nil, fmt.Errorf("trip ID and rider ID are required")
+	}
+	description := strings.TrimSpace(req.Description)
+	if description == "" {
+		return nil, fmt.Errorf("a description of the item is required")
+	}
+	if utf8.RuneCountInString(description) > maxLostItemDescriptionLength {
+		return nil, fmt.Errorf("description must be at most %d characters", maxLostItemDescriptionLength)
+	}
+
+	trip, err := s.trips.GetByID(ctx, req.TripID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trip: %w", err)
+	}
+	if trip.RiderID != req.RiderID {
+		return nil, ErrTripNotOwned
+	}
+	if trip.Status != models.TripStatusCompleted || trip.DriverID == nil {
+		return nil, ErrLostItemTripNotEligible
+	}
+	now := time.Now()
+	if trip.CompletedAt != nil && now.After(trip.CompletedAt.Add(s.config.ReportWindow)) {
+		return nil, ErrLostItemWindowClosed
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	existing, err := s.store.ListLostItems(ctx, types.LostItemFilter{TripID: trip.ID})
+	if err != nil {
+		return nil, err
+	}
+	for _, report := range existing {
+		if !report.Status.Closed() {
+			return nil, ErrLostItemReportExists
+		}
+	}
+
+	report := &types.LostItemReport{
+		ID:           generateLostItemID(),
+		TripID:       trip.ID,
+		RiderID:      trip.RiderID,
+		DriverID:     *trip.DriverID,
+		Description:  description,
+		Status:       types.LostItemStatusReported,
+		ContactUntil: now.Add(s.config.ContactWindow),
+		Notes:        []*types.LostItemNote{},
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if s.publishReported(ctx, report) {
+		report.DriverNotifiedAt = &now
+	}
+
+	if err := s.store.SaveLostItem(ctx, report); err != nil {
+		return nil, fmt.Errorf("failed to save lost item report: %w", err)
+	}
+
+	s.logger.WithContext(ctx).WithFields(logger.Fields{
+		"lost_item_id":    report.ID,
+		"trip_id":         report.TripID,
+		"driver_notified": report.DriverNotifiedAt != nil,
+	}).Info("Lost item reported")
+	return report, nil
+}
+
+// GetLostItem returns a lost item report
+func (s *LostItemService) GetLostItem(ctx context.Context, reportID string) (*types.LostItemReport, error) {
+	return s.store.GetLostItem(ctx, reportID)
+}
+
+// ListLostItems returns up to limit reports matching filter, newest first
+func (s *LostItemService) ListLostItems(ctx context.Context, filter types.LostItemFilter, limit int) ([]*types.LostItemReport, error) {
+	switch filter.Status {
+	case "", types.LostItemStatusReported, types.LostItemStatusFound, types.LostItemStatusReturned, types.LostItemStatusNotFound:
+	default:
+		return nil, fmt.Errorf("unknown lost item status %q", filter.Status)
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+
+	reports, err := s.store.ListLostItems(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(reports) > limit {
+		reports = reports[:limit]
+	}
+	return reports, nil
+}
+
+// UpdateLostItem moves a report on and notes why. Marking an item found
+// keeps the trip chat open for another contact window so the return can be
+// arranged; closing the report closes the chat.
+func (s *LostItemService) UpdateLostItem(ctx context.Context, reportID string, req *UpdateLostItemRequest) (*types.LostItemReport, error) {
+	note := strings.TrimSpace(req.Note)
+	if req.AuthorID == "" {
+		return nil, fmt.Errorf("author ID is required")
+	}
+	if req.Status == "" && note == "" {
+		return nil, fmt.Errorf("a status or a note is required")
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	report, err := s.store.GetLostItem(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+
+	if req.Status != "" {
+		if report.Status.Closed() {
+			return nil, ErrLostItemClosed
+		}
+		if !lostItemTransitionAllowed(report.Status, req.Status) {
+			return nil, fmt.Errorf("lost item report cannot move from %s to %s", report.Status, req.Status)
+		}
+		report.Status = req.Status
+		if req.Status == types.LostItemStatusFound {
+			report.ContactUntil = now.Add(s.config.ContactWindow)
+		}
+	}
+	report.Notes = append(report.Notes, &types.LostItemNote{
+		AuthorID:  req.AuthorID,
+		Status:    req.Status,
+		Text:      note,
+		CreatedAt: now,
+	})
+	report.UpdatedAt = now
+
+	if err := s.store.SaveLostItem(ctx, report); err != nil {
+		return nil, fmt.Errorf("failed to save lost item report: %w", err)
+	}
+
+	s.logger.WithContext(ctx).WithFields(logger.Fields{
+		"lost_item_id": report.ID,
+		"status":       report.Status,
+	}).Info("Lost item report updated")
+	return report, nil
+}
+
+// ContactOpen reports whether a finished trip's rider and driver can message
+// each other about an open lost item report
+func (s *LostItemService) ContactOpen(ctx context.Context, tripID string) (bool, error) {
+	reports, err := s.store.ListLostItems(ctx, types.LostItemFilter{TripID: tripID})
+	if err != nil {
+		return false, err
+	}
+	now := time.Now()
+	for _, report := range reports {
+		if report.ContactOpen(now) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// publishReported tells the trip's driver about the report and reports
+// whether the event was published
+func (s *LostItemService) publishReported(ctx context.Context, report *types.LostItemReport) bool {
+	if s.events == nil {
+		return false
+	}
+
+	event := events.NewEvent(events.TripLostItemReportedEvent, report.TripID, 1, map[string]interface{}{
+		"lost_item_id":  report.ID,
+		"trip_id":       report.TripID,
+		"rider_id":      report.RiderID,
+		"driver_id":     report.DriverID,
+		"description":   report.Description,
+		"contact_until": report.ContactUntil.UTC().Format(time.RFC3339),
+	}, "trip-service")
+	if err := s.events.PublishEvent(ctx, event); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+			"lost_item_id": report.ID,
+			"trip_id":      report.TripID,
+		}).Warn("Failed to publish lost item reported event")
+		return false
+	}
+	return true
+}
+
+func lostItemTransitionAllowed(from, to types.LostItemStatus) bool {
+	for _, allowed := range lostItemTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+func generateLostItemID() string {
+	return fmt.Sprintf("lost_%d", time.Now().UnixNano())
+}
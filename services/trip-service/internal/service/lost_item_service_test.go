@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rideshare-platform/services/trip-service/internal/repository"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/events"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+func TestLostItemService_ReportReopensChatAndNotifiesDriver(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("test", "info")
+	store := repository.NewMemoryTripStore()
+	trips := NewTripService(store, log)
+	chat := NewChatService(store, repository.NewMemoryTripMessageStore(), log)
+	lostItems := NewLostItemService(store, repository.NewMemoryLostItemStore(), DefaultLostItemConfig(), log)
+	chat.SetContactWindows(lostItems)
+
+	publisher := events.NewEventPublisher(events.NewInMemoryEventBus(log), events.NewInMemoryEventStore(log), log)
+	lostItems.SetEventPublisher(publisher)
+	hub := NewDriverEventHub(log)
+	require.NoError(t, hub.SubscribeEvents(publisher))
+	driverEvents, err := hub.Subscribe("driver-1")
+	require.NoError(t, err)
+	defer driverEvents.Close()
+
+	trip := newDisputeTestTrip(t, trips)
+	_, err = chat.SendMessage(ctx, &SendTripMessageRequest{TripID: trip.ID, SenderID: "rider-1", Text: "hello?"})
+	assert.ErrorIs(t, err, ErrChatClosed, "messaging closes with the trip")
+
+	_, err = lostItems.ReportLostItem(ctx, &ReportLostItemRequest{TripID: trip.ID, RiderID: "rider-2", Description: "umbrella"})
+	assert.ErrorIs(t, err, ErrTripNotOwned)
+
+	report, err := lostItems.ReportLostItem(ctx, &ReportLostItemRequest{TripID: trip.ID, RiderID: "rider-1", Description: " black umbrella "})
+	require.NoError(t, err)
+	assert.Equal(t, types.LostItemStatusReported, report.Status)
+	assert.Equal(t, "driver-1", report.DriverID)
+	assert.Equal(t, "black umbrella", report.Description)
+	require.NotNil(t, report.DriverNotifiedAt)
+
+	event := receiveDriverEvent(t, driverEvents)
+	assert.Equal(t, DriverEventLostItem, event.Type)
+	assert.Equal(t, report.ID, event.LostItemID)
+	assert.Equal(t, "black umbrella", event.Description)
+
+	_, err = lostItems.ReportLostItem(ctx, &ReportLostItemRequest{TripID: trip.ID, RiderID: "rider-1", Description: "scarf"})
+	assert.ErrorIs(t, err, ErrLostItemReportExists)
+
+	// The rider and driver can message each other again
+	_, err = chat.SendMessage(ctx, &SendTripMessageRequest{TripID: trip.ID, SenderID: "rider-1", Text: "did you find it?"})
+	require.NoError(t, err)
+	_, err = chat.SendMessage(ctx, &SendTripMessageRequest{TripID: trip.ID, SenderID: "driver-1", Text: "yes, it's here"})
+	require.NoError(t, err)
+
+	_, err = lostItems.UpdateLostItem(ctx, report.ID, &UpdateLostItemRequest{AuthorID: "support-1", Status: types.LostItemStatusReported})
+	assert.Error(t, err)
+
+	found, err := lostItems.UpdateLostItem(ctx, report.ID, &UpdateLostItemRequest{AuthorID: "support-1", Status: types.LostItemStatusFound, Note: "driver has it"})
+	require.NoError(t, err)
+	assert.Equal(t, types.LostItemStatusFound, found.Status)
+	assert.True(t, found.ContactUntil.After(report.ContactUntil))
+	require.Len(t, found.Notes, 1)
+
+	returned, err := lostItems.UpdateLostItem(ctx, report.ID, &UpdateLostItemRequest{AuthorID: "support-1", Status: types.LostItemStatusReturned})
+	require.NoError(t, err)
+	assert.False(t, returned.ContactOpen(time.Now()))
+
+	// Closing the report closes the chat again
+	_, err = chat.SendMessage(ctx, &SendTripMessageRequest{TripID: trip.ID, SenderID: "rider-1", Text: "thanks!"})
+	assert.ErrorIs(t, err, ErrChatClosed)
+	_, err = lostItems.UpdateLostItem(ctx, report.ID, &UpdateLostItemRequest{AuthorID: "support-1", Status: types.LostItemStatusNotFound})
+	assert.ErrorIs(t, err, ErrLostItemClosed)
+
+	// Notes can still be added for the record
+	_, err = lostItems.UpdateLostItem(ctx, report.ID, &UpdateLostItemRequest{AuthorID: "support-1", Note: "rider confirmed"})
+	require.NoError(t, err)
+
+	reports, err := lostItems.ListLostItems(ctx, types.LostItemFilter{DriverID: "driver-1"}, 0)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.Len(t, reports[0].Notes, 3)
+}
+
+func TestLostItemService_OnlyCompletedTrips(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("test", "info")
+	store := repository.NewMemoryTripStore()
+	trips := NewTripService(store, log)
+	lostItems := NewLostItemService(store, repository.NewMemoryLostItemStore(), DefaultLostItemConfig(), log)
+
+	trip := newDisputeTestTrip(t, trips)
+	active, err := trips.CreateTrip(ctx, &CreateTripRequest{
+		RiderID:             "rider-1",
+		PickupLocation:      trip.PickupLocation,
+		DestinationLocation: trip.Destination,
+		RideType:            "standard",
+		EstimatedFare:       20,
+	})
+	require.NoError(t, err)
+
+	_, err = lostItems.ReportLostItem(ctx, &ReportLostItemRequest{TripID: active.ID, RiderID: "rider-1", Description: "phone"})
+	assert.ErrorIs(t, err, ErrLostItemTripNotEligible)
+
+	// Without a publisher the report is kept but the driver is not notified
+	report, err := lostItems.ReportLostItem(ctx, &ReportLostItemRequest{TripID: trip.ID, RiderID: "rider-1", Description: "phone"})
+	require.NoError(t, err)
+	assert.Nil(t, report.DriverNotifiedAt)
+
+	expired := NewLostItemService(store, repository.NewMemoryLostItemStore(), LostItemConfig{ReportWindow: time.Nanosecond, ContactWindow: time.Hour}, log)
+	time.Sleep(time.Millisecond)
+	_, err = expired.ReportLostItem(ctx, &ReportLostItemRequest{TripID: trip.ID, RiderID: "rider-1", Description: "phone"})
+	assert.ErrorIs(t, err, ErrLostItemWindowClosed)
+}
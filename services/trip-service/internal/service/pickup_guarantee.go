@@ -0,0 +1,421 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/analytics"
+	"github.com/rideshare-platform/shared/currency"
+	"github.com/rideshare-platform/shared/events"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// Pickup guarantee rollup counters. Credits are counted per currency, named
+// with the currency after the prefix.
+const (
+	counterPickupsJudged      = "pickup_guarantees_judged"
+	counterPickupsMissed      = "pickup_guarantees_missed"
+	counterPickupLateSeconds  = "pickup_late_seconds"
+	counterPickupCreditPrefix = "pickup_guarantee_credits."
+)
+
+// pickupGuaranteeCreditType is the wallet credit type missed guarantees are
+// paid as; credits reference the trip so support can trace them back
+const pickupGuaranteeCreditType = "guarantee_credit"
+
+// PickupGuaranteeConfig controls when a late pickup misses its guarantee and
+// what the rider is credited for it
+type PickupGuaranteeConfig struct {
+	// GracePeriod is how late past the promised time a driver may arrive
+	// and still keep the guarantee
+	GracePeriod time.Duration
+	// CreditAmount is what riders are credited for a missed guarantee, in
+	// their trip's currency; 0 records misses without crediting
+	CreditAmount float64
+	// AreaPrecision is the geohash precision pickups are grouped into areas by
+	AreaPrecision int
+}
+
+// DefaultPickupGuaranteeConfig returns the default pickup guarantee settings
+func DefaultPickupGuaranteeConfig() PickupGuaranteeConfig {
+	return PickupGuaranteeConfig{
+		GracePeriod:   5 * time.Minute,
+		CreditAmount:  5,
+		AreaPrecision: 5,
+	}
+}
+
+// GuaranteeCrediter gives riders ride credits in their wallet, normally
+// through payment-service
+type GuaranteeCrediter interface {
+	// GrantWalletCredit credits amount to the user's wallet and returns the
+	// credit's transaction ID
+	GrantWalletCredit(ctx context.Context, userID string, amount float64, currencyCode, creditType, reference, description string) (string, error)
+}
+
+// PickupPromise is the pickup time a rider is promised when their trip is
+// matched: the driver's ETA to the pickup from MatchedAt
+type PickupPromise struct {
+	TripID    string
+	RiderID   string
+	DriverID  string
+	ETA       time.Duration
+	MatchedAt time.Time
+}
+
+// PickupGuaranteeService holds drivers to the pickup ETA riders are shown
+// when their trip is matched. Riders whose driver arrives more than the
+// grace period late are credited automatically, and every miss is kept for
+// per-driver and per-area SLA reporting.
+type PickupGuaranteeService struct {
+	trips      TripRepositoryInterface
+	guarantees types.PickupGuaranteeStore
+	credits    GuaranteeCrediter
+	analytics  *analytics.Recorder
+	config     PickupGuaranteeConfig
+	logger     *logger.Logger
+
+	// Arrival and the trip starting may both report the pickup; a trip's
+	// guarantee is judged once
+	mutex sync.Mutex
+}
+
+// NewPickupGuaranteeService creates a new pickup guarantee service. Trips
+// are looked up in trips for the city, pickup area and currency of their
+// guarantee; trips not found there are recorded without them.
+func NewPickupGuaranteeService(trips TripRepositoryInterface, guarantees types.PickupGuaranteeStore, config PickupGuaranteeConfig, logger *logger.Logger) *PickupGuaranteeService {
+	return &PickupGuaranteeService{
+		trips:      trips,
+		guarantees: guarantees,
+		config:     config,
+		logger:     logger,
+	}
+}
+
+// SetCrediter attaches the payment-service client missed guarantees are
+// credited through. Without one misses are recorded but not credited.
+func (s *PickupGuaranteeService) SetCrediter(credits GuaranteeCrediter) {
+	s.credits = credits
+}
+
+// SetAnalytics sets the recorder judged and missed guarantees are counted with
+func (s *PickupGuaranteeService) SetAnalytics(recorder *analytics.Recorder) {
+	s.analytics = recorder
+}
+
+// SubscribeEvents promises a pickup time when a trip is matched with an ETA,
+// judges it when the driver arrives or the trip starts, and voids it when
+// the trip is cancelled first
+func (s *PickupGuaranteeService) SubscribeEvents(publisher events.Subscriber) error {
+	handlers := map[events.EventType]events.EventHandler{
+		events.TripMatchedEvent:       s.handleMatched,
+		events.TripDriverArrivedEvent: s.handlePickup,
+		events.TripStartedEvent:       s.handlePickup,
+		events.TripCancelledEvent:     s.handleCancelled,
+	}
+	for eventType, handler := range handlers {
+		if err := publisher.Subscribe(eventType, handler); err != nil {
+			return fmt.Errorf("failed to subscribe to %s events: %w", eventType, err)
+		}
+	}
+	return nil
+}
+
+// Promise records the pickup time a rider was promised. Promising again
+// before the pickup replaces the promise, as when a trip is rematched.
+func (s *PickupGuaranteeService) Promise(ctx context.Context, promise PickupPromise) (*types.PickupGuarantee, error) {
+	if promise.TripID == "" || promise.RiderID == "" || promise.DriverID == "" {
+		return nil, fmt.Errorf("trip ID, rider ID and driver ID are required")
+	}
+	if promise.ETA <= 0 {
+		return nil, fmt.Errorf("pickup ETA must be positive")
+	}
+	if promise.MatchedAt.IsZero() {
+		promise.MatchedAt = time.Now()
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	existing, err := s.guarantees.GetGuarantee(ctx, promise.TripID)
+	if err != nil && !errors.Is(err, types.ErrPickupGuaranteeNotFound) {
+		return nil, fmt.Errorf("failed to get pickup guarantee: %w", err)
+	}
+	if existing != nil && existing.Status != types.PickupGuaranteePending {
+		return existing, nil
+	}
+
+	now := time.Now()
+	guarantee := &types.PickupGuarantee{
+		TripID:     promise.TripID,
+		RiderID:    promise.RiderID,
+		DriverID:   promise.DriverID,
+		Status:     types.PickupGuaranteePending,
+		MatchedAt:  promise.MatchedAt,
+		PromisedAt: promise.MatchedAt.Add(promise.ETA),
+		Currency:   currency.Default,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if trip, err := s.trips.GetByID(ctx, promise.TripID); err == nil {
+		guarantee.CityID = trip.CityID
+		guarantee.Area = trip.PickupLocation.Geohash(s.config.AreaPrecision)
+		if trip.Currency != "" {
+			guarantee.Currency = trip.Currency
+		}
+	}
+
+	if err := s.guarantees.SaveGuarantee(ctx, guarantee); err != nil {
+		return nil, fmt.Errorf("failed to save pickup guarantee: %w", err)
+	}
+	return guarantee, nil
+}
+
+// RecordPickup judges a trip's guarantee by when its driver reached the
+// pickup, crediting the rider if they were late. Guarantees already judged
+// are returned as they are, so a rider is credited at most once.
+func (s *PickupGuaranteeService) RecordPickup(ctx context.Context, tripID string, at time.Time) (*types.PickupGuarantee, error) {
+	if tripID == "" {
+		return nil, fmt.Errorf("trip ID is required")
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	guarantee, err := s.guarantees.GetGuarantee(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+	if guarantee.Status != types.PickupGuaranteePending {
+		return guarantee, nil
+	}
+
+	guarantee.PickedUpAt = &at
+	guarantee.UpdatedAt = time.Now()
+	guarantee.Status = types.PickupGuaranteeKept
+	if late := at.Sub(guarantee.PromisedAt); late > s.config.GracePeriod {
+		guarantee.Status = types.PickupGuaranteeMissed
+		guarantee.LateBySeconds = int64(late.Seconds())
+		s.credit(ctx, guarantee)
+	}
+
+	if err := s.guarantees.SaveGuarantee(ctx, guarantee); err != nil {
+		return nil, fmt.Errorf("failed to save pickup guarantee: %w", err)
+	}
+	s.record(guarantee)
+
+	if guarantee.Status == types.PickupGuaranteeMissed {
+		s.logger.WithContext(ctx).WithFields(logger.Fields{
+			"trip_id":   guarantee.TripID,
+			"driver_id": guarantee.DriverID,
+			"area":      guarantee.Area,
+			"late_by":   guarantee.LateBySeconds,
+		}).Info("Pickup guarantee missed")
+	}
+	return guarantee, nil
+}
+
+// Void drops the guarantee of a trip cancelled before its pickup
+func (s *PickupGuaranteeService) Void(ctx context.Context, tripID string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	guarantee, err := s.guarantees.GetGuarantee(ctx, tripID)
+	if errors.Is(err, types.ErrPickupGuaranteeNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if guarantee.Status != types.PickupGuaranteePending {
+		return nil
+	}
+
+	guarantee.Status = types.PickupGuaranteeVoid
+	guarantee.UpdatedAt = time.Now()
+	return s.guarantees.SaveGuarantee(ctx, guarantee)
+}
+
+// GetGuarantee returns a trip's pickup guarantee
+func (s *PickupGuaranteeService) GetGuarantee(ctx context.Context, tripID string) (*types.PickupGuarantee, error) {
+	if tripID == "" {
+		return nil, fmt.Errorf("trip ID is required")
+	}
+	return s.guarantees.GetGuarantee(ctx, tripID)
+}
+
+// Report summarises how drivers and areas kept the guarantees of trips
+// matched in [from, to), in one city or all of them when cityID is empty
+func (s *PickupGuaranteeService) Report(ctx context.Context, from, to time.Time, cityID string) (*types.PickupSLAReport, error) {
+	if from.IsZero() || to.IsZero() || !from.Before(to) {
+		return nil, fmt.Errorf("%w: from must be before to", analytics.ErrInvalidTimeRange)
+	}
+
+	guarantees, err := s.guarantees.ListGuarantees(ctx, types.PickupGuaranteeFilter{CityID: cityID, From: from, To: to})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pickup guarantees: %w", err)
+	}
+
+	report := &types.PickupSLAReport{
+		From:              from,
+		To:                to,
+		CityID:            cityID,
+		CreditsByCurrency: make(map[string]float64),
+	}
+	drivers := make(map[string]*pickupSLATally)
+	areas := make(map[string]*pickupSLATally)
+	overall := &pickupSLATally{}
+	for _, guarantee := range guarantees {
+		if guarantee.Status != types.PickupGuaranteeKept && guarantee.Status != types.PickupGuaranteeMissed {
+			continue
+		}
+		overall.add(guarantee)
+		tallyFor(drivers, guarantee.DriverID).add(guarantee)
+		tallyFor(areas, guarantee.Area).add(guarantee)
+		if guarantee.CreditID != "" {
+			report.CreditsByCurrency[guarantee.Currency] += guarantee.CreditAmount
+		}
+	}
+	for code, credited := range report.CreditsByCurrency {
+		report.CreditsByCurrency[code] = currency.Round(credited, code)
+	}
+
+	totals := overall.group("")
+	report.Judged, report.Missed, report.MissRate = totals.Judged, totals.Missed, totals.MissRate
+	report.ByDriver = slaGroups(drivers)
+	report.ByArea = slaGroups(areas)
+	return report, nil
+}
+
+// credit grants the rider of a missed guarantee their credit. A credit that
+// cannot be granted is noted on the guarantee rather than failing the pickup.
+func (s *PickupGuaranteeService) credit(ctx context.Context, guarantee *types.PickupGuarantee) {
+	if s.config.CreditAmount <= 0 {
+		return
+	}
+	if s.credits == nil {
+		guarantee.CreditError = "wallet credits are not configured"
+		return
+	}
+
+	amount := currency.Round(s.config.CreditAmount, guarantee.Currency)
+	description := fmt.Sprintf("Driver arrived %d min later than promised", guarantee.LateBySeconds/60)
+	creditID, err := s.credits.GrantWalletCredit(ctx, guarantee.RiderID, amount, guarantee.Currency,
+		pickupGuaranteeCreditType, "pickup_guarantee:"+guarantee.TripID, description)
+	if err != nil {
+		guarantee.CreditError = err.Error()
+		s.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+			"trip_id":  guarantee.TripID,
+			"rider_id": guarantee.RiderID,
+		}).Warn("Failed to credit rider for missed pickup guarantee")
+		return
+	}
+	guarantee.CreditID = creditID
+	guarantee.CreditAmount = amount
+}
+
+// record counts a judged guarantee into the hour of its pickup
+func (s *PickupGuaranteeService) record(guarantee *types.PickupGuarantee) {
+	if s.analytics == nil || guarantee.PickedUpAt == nil {
+		return
+	}
+
+	counters := analytics.Counters{counterPickupsJudged: 1}
+	if guarantee.Status == types.PickupGuaranteeMissed {
+		counters.Add(counterPickupsMissed, 1)
+		counters.Add(counterPickupLateSeconds, float64(guarantee.LateBySeconds))
+	}
+	if guarantee.CreditID != "" {
+		counters.Add(counterPickupCreditPrefix+guarantee.Currency, guarantee.CreditAmount)
+	}
+	s.analytics.Record(*guarantee.PickedUpAt, counters)
+}
+
+func (s *PickupGuaranteeService) handleMatched(ctx context.Context, event *events.Event) error {
+	etaSeconds, _ := event.Data["pickup_eta_seconds"].(float64)
+	if etaSeconds <= 0 {
+		// Trips matched without an ETA carry no guarantee
+		return nil
+	}
+
+	promise := PickupPromise{
+		TripID:    event.AggregateID,
+		ETA:       time.Duration(etaSeconds) * time.Second,
+		MatchedAt: event.Timestamp,
+	}
+	promise.RiderID, _ = event.Data["rider_id"].(string)
+	promise.DriverID, _ = event.Data["driver_id"].(string)
+	_, err := s.Promise(ctx, promise)
+	return err
+}
+
+func (s *PickupGuaranteeService) handlePickup(ctx context.Context, event *events.Event) error {
+	_, err := s.RecordPickup(ctx, event.AggregateID, event.Timestamp)
+	if errors.Is(err, types.ErrPickupGuaranteeNotFound) {
+		return nil
+	}
+	return err
+}
+
+func (s *PickupGuaranteeService) handleCancelled(ctx context.Context, event *events.Event) error {
+	return s.Void(ctx, event.AggregateID)
+}
+
+// pickupSLATally counts the judged guarantees of one driver or area
+type pickupSLATally struct {
+	judged      int64
+	missed      int64
+	lateSeconds int64
+}
+
+func tallyFor(tallies map[string]*pickupSLATally, key string) *pickupSLATally {
+	tally, exists := tallies[key]
+	if !exists {
+		tally = &pickupSLATally{}
+		tallies[key] = tally
+	}
+	return tally
+}
+
+func (t *pickupSLATally) add(guarantee *types.PickupGuarantee) {
+	t.judged++
+	if guarantee.Status == types.PickupGuaranteeMissed {
+		t.missed++
+		t.lateSeconds += guarantee.LateBySeconds
+	}
+}
+
+func (t *pickupSLATally) group(key string) *types.PickupSLAGroup {
+	group := &types.PickupSLAGroup{Key: key, Judged: t.judged, Missed: t.missed}
+	if t.judged > 0 {
+		group.MissRate = float64(t.missed) / float64(t.judged)
+	}
+	if t.missed > 0 {
+		group.AverageLateSeconds = float64(t.lateSeconds) / float64(t.missed)
+	}
+	return group
+}
+
+// slaGroups lists tallies with the most misses first, then the highest miss
+// rate, then by key
+func slaGroups(tallies map[string]*pickupSLATally) []*types.PickupSLAGroup {
+	groups := make([]*types.PickupSLAGroup, 0, len(tallies))
+	for key, tally := range tallies {
+		groups = append(groups, tally.group(key))
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Missed != groups[j].Missed {
+			return groups[i].Missed > groups[j].Missed
+		}
+		if groups[i].MissRate != groups[j].MissRate {
+			return groups[i].MissRate > groups[j].MissRate
+		}
+		return groups[i].Key < groups[j].Key
+	})
+	return groups
+}
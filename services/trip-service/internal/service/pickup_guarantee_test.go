@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rideshare-platform/services/trip-service/internal/repository"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/analytics"
+	"github.com/rideshare-platform/shared/events"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+)
+
+// walletCredits records the wallet credits granted, failing while err is set
+type walletCredits struct {
+	mutex   sync.Mutex
+	err     error
+	granted []string
+}
+
+func (w *walletCredits) GrantWalletCredit(ctx context.Context, userID string, amount float64, currencyCode, creditType, reference, description string) (string, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.err != nil {
+		return "", w.err
+	}
+	w.granted = append(w.granted, userID+" "+currencyCode+" "+creditType+" "+reference)
+	return "txn-" + reference, nil
+}
+
+func TestPickupGuaranteeService_CreditsLatePickupsOnce(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("test", "info")
+	store := repository.NewMemoryTripStore()
+	trips := NewTripService(store, log)
+	credits := &walletCredits{}
+	guarantees := NewPickupGuaranteeService(store, repository.NewMemoryPickupGuaranteeStore(), DefaultPickupGuaranteeConfig(), log)
+	guarantees.SetCrediter(credits)
+
+	trip, err := trips.CreateTrip(ctx, &CreateTripRequest{
+		RiderID:             "rider-1",
+		PickupLocation:      models.Location{Latitude: 41.008237, Longitude: 28.978359},
+		DestinationLocation: models.Location{Latitude: 41.042211, Longitude: 29.008311},
+		RideType:            "standard",
+		EstimatedFare:       20,
+		Currency:            "EUR",
+	})
+	require.NoError(t, err)
+
+	matchedAt := time.Now().Add(-20 * time.Minute)
+	guarantee, err := guarantees.Promise(ctx, PickupPromise{TripID: trip.ID, RiderID: "rider-1", DriverID: "driver-1", ETA: 6 * time.Minute, MatchedAt: matchedAt})
+	require.NoError(t, err)
+	assert.Equal(t, types.PickupGuaranteePending, guarantee.Status)
+	assert.Equal(t, matchedAt.Add(6*time.Minute), guarantee.PromisedAt)
+	assert.Equal(t, "EUR", guarantee.Currency)
+	assert.Equal(t, "sxk97", guarantee.Area, "pickups are grouped by geohash")
+
+	// Eight minutes past the promised time is beyond the five minute grace period
+	guarantee, err = guarantees.RecordPickup(ctx, trip.ID, matchedAt.Add(14*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, types.PickupGuaranteeMissed, guarantee.Status)
+	assert.Equal(t, int64(480), guarantee.LateBySeconds)
+	assert.Equal(t, 5.0, guarantee.CreditAmount)
+	assert.Equal(t, "txn-pickup_guarantee:"+trip.ID, guarantee.CreditID)
+	assert.Equal(t, []string{"rider-1 EUR guarantee_credit pickup_guarantee:" + trip.ID}, credits.granted)
+
+	// The trip starting reports the pickup again
+	_, err = guarantees.RecordPickup(ctx, trip.ID, matchedAt.Add(16*time.Minute))
+	require.NoError(t, err)
+	assert.Len(t, credits.granted, 1, "a rider is credited once per trip")
+
+	_, err = guarantees.Promise(ctx, PickupPromise{TripID: trip.ID, RiderID: "rider-1", DriverID: "driver-1", ETA: time.Hour})
+	require.NoError(t, err)
+	stored, err := guarantees.GetGuarantee(ctx, trip.ID)
+	require.NoError(t, err)
+	assert.Equal(t, types.PickupGuaranteeMissed, stored.Status, "a judged guarantee is not promised again")
+}
+
+func TestPickupGuaranteeService_KeptWithinGracePeriod(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("test", "info")
+	credits := &walletCredits{}
+	guarantees := NewPickupGuaranteeService(repository.NewMemoryTripStore(), repository.NewMemoryPickupGuaranteeStore(), DefaultPickupGuaranteeConfig(), log)
+	guarantees.SetCrediter(credits)
+
+	matchedAt := time.Now().Add(-time.Hour)
+	_, err := guarantees.Promise(ctx, PickupPromise{TripID: "trip-1", RiderID: "rider-1", DriverID: "driver-1", ETA: 5 * time.Minute, MatchedAt: matchedAt})
+	require.NoError(t, err)
+
+	guarantee, err := guarantees.RecordPickup(ctx, "trip-1", matchedAt.Add(9*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, types.PickupGuaranteeKept, guarantee.Status)
+	assert.Zero(t, guarantee.LateBySeconds)
+	assert.Empty(t, credits.granted)
+	assert.Equal(t, "USD", guarantee.Currency, "trips that cannot be looked up use the default currency")
+
+	_, err = guarantees.RecordPickup(ctx, "trip-unknown", time.Now())
+	assert.ErrorIs(t, err, types.ErrPickupGuaranteeNotFound)
+}
+
+func TestPickupGuaranteeService_FailedCreditIsNoted(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("test", "info")
+	credits := &walletCredits{err: errors.New("payment-service unavailable")}
+	guarantees := NewPickupGuaranteeService(repository.NewMemoryTripStore(), repository.NewMemoryPickupGuaranteeStore(), DefaultPickupGuaranteeConfig(), log)
+	guarantees.SetCrediter(credits)
+
+	matchedAt := time.Now().Add(-time.Hour)
+	_, err := guarantees.Promise(ctx, PickupPromise{TripID: "trip-1", RiderID: "rider-1", DriverID: "driver-1", ETA: time.Minute, MatchedAt: matchedAt})
+	require.NoError(t, err)
+
+	guarantee, err := guarantees.RecordPickup(ctx, "trip-1", matchedAt.Add(30*time.Minute))
+	require.NoError(t, err, "the miss is still recorded")
+	assert.Equal(t, types.PickupGuaranteeMissed, guarantee.Status)
+	assert.Empty(t, guarantee.CreditID)
+	assert.Zero(t, guarantee.CreditAmount)
+	assert.Equal(t, "payment-service unavailable", guarantee.CreditError)
+}
+
+func TestPickupGuaranteeService_FollowsTripEvents(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("test", "info")
+	credits := &walletCredits{}
+	guarantees := NewPickupGuaranteeService(repository.NewMemoryTripStore(), repository.NewMemoryPickupGuaranteeStore(), DefaultPickupGuaranteeConfig(), log)
+	guarantees.SetCrediter(credits)
+
+	publisher := events.NewEventPublisher(events.NewInMemoryEventBus(log), events.NewInMemoryEventStore(log), log)
+	require.NoError(t, guarantees.SubscribeEvents(publisher))
+
+	publish := func(eventType events.EventType, tripID string, at time.Time, data map[string]interface{}) {
+		event := events.NewEvent(eventType, tripID, 1, data, "trip-service")
+		event.Timestamp = at
+		require.NoError(t, publisher.PublishEvent(ctx, event))
+	}
+	matchedAt := time.Now().Add(-time.Hour)
+	parties := map[string]interface{}{"rider_id": "rider-1", "driver_id": "driver-1", "pickup_eta_seconds": float64(240)}
+
+	publish(events.TripMatchedEvent, "trip-late", matchedAt, parties)
+	publish(events.TripMatchedEvent, "trip-cancelled", matchedAt, parties)
+	publish(events.TripMatchedEvent, "trip-no-eta", matchedAt, map[string]interface{}{"rider_id": "rider-1", "driver_id": "driver-1"})
+	assert.Eventually(t, func() bool {
+		_, lateErr := guarantees.GetGuarantee(ctx, "trip-late")
+		_, cancelledErr := guarantees.GetGuarantee(ctx, "trip-cancelled")
+		return lateErr == nil && cancelledErr == nil
+	}, time.Second, 10*time.Millisecond)
+
+	publish(events.TripDriverArrivedEvent, "trip-late", matchedAt.Add(20*time.Minute), parties)
+	publish(events.TripCancelledEvent, "trip-cancelled", matchedAt.Add(2*time.Minute), parties)
+	assert.Eventually(t, func() bool {
+		late, lateErr := guarantees.GetGuarantee(ctx, "trip-late")
+		cancelled, cancelledErr := guarantees.GetGuarantee(ctx, "trip-cancelled")
+		return lateErr == nil && late.Status == types.PickupGuaranteeMissed &&
+			cancelledErr == nil && cancelled.Status == types.PickupGuaranteeVoid
+	}, time.Second, 10*time.Millisecond)
+
+	_, err := guarantees.GetGuarantee(ctx, "trip-no-eta")
+	assert.ErrorIs(t, err, types.ErrPickupGuaranteeNotFound, "trips matched without an ETA carry no guarantee")
+}
+
+func TestPickupGuaranteeService_Report(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("test", "info")
+	recorder := analytics.NewRecorder(analytics.NewMemoryStore(), AnalyticsSource, log)
+	guarantees := NewPickupGuaranteeService(repository.NewMemoryTripStore(), repository.NewMemoryPickupGuaranteeStore(), DefaultPickupGuaranteeConfig(), log)
+	guarantees.SetCrediter(&walletCredits{})
+	guarantees.SetAnalytics(recorder)
+
+	matchedAt := time.Now().Add(-2 * time.Hour)
+	pickups := []struct {
+		tripID   string
+		driverID string
+		late     time.Duration
+	}{
+		{"trip-1", "driver-1", 10 * time.Minute},
+		{"trip-2", "driver-1", 20 * time.Minute},
+		{"trip-3", "driver-1", 0},
+		{"trip-4", "driver-2", time.Minute},
+	}
+	for _, pickup := range pickups {
+		_, err := guarantees.Promise(ctx, PickupPromise{TripID: pickup.tripID, RiderID: "rider-1", DriverID: pickup.driverID, ETA: 5 * time.Minute, MatchedAt: matchedAt})
+		require.NoError(t, err)
+		_, err = guarantees.RecordPickup(ctx, pickup.tripID, matchedAt.Add(5*time.Minute+pickup.late))
+		require.NoError(t, err)
+	}
+	_, err := guarantees.Promise(ctx, PickupPromise{TripID: "trip-pending", RiderID: "rider-1", DriverID: "driver-2", ETA: 5 * time.Minute, MatchedAt: matchedAt})
+	require.NoError(t, err)
+
+	report, err := guarantees.Report(ctx, matchedAt.Add(-time.Minute), time.Now(), "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), report.Judged, "pending guarantees are not judged")
+	assert.Equal(t, int64(2), report.Missed)
+	assert.Equal(t, 0.5, report.MissRate)
+	assert.Equal(t, map[string]float64{"USD": 10}, report.CreditsByCurrency)
+
+	require.Len(t, report.ByDriver, 2)
+	assert.Equal(t, &types.PickupSLAGroup{Key: "driver-1", Judged: 3, Missed: 2, MissRate: 2.0 / 3, AverageLateSeconds: 900}, report.ByDriver[0])
+	assert.Equal(t, &types.PickupSLAGroup{Key: "driver-2", Judged: 1}, report.ByDriver[1])
+	require.Len(t, report.ByArea, 1)
+	assert.Equal(t, int64(2), report.ByArea[0].Missed)
+
+	_, err = guarantees.Report(ctx, time.Now(), time.Now().Add(-time.Hour), "")
+	assert.ErrorIs(t, err, analytics.ErrInvalidTimeRange)
+
+	metrics, err := NewTripAnalytics(recorder, nil, "USD").BusinessMetrics(ctx, matchedAt.Add(-time.Hour), time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), metrics.PickupGuaranteesJudged)
+	assert.Equal(t, int64(2), metrics.PickupGuaranteesMissed)
+	assert.Equal(t, map[string]float64{"USD": 10}, metrics.PickupGuaranteeCreditsByCurrency)
+}
@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/analytics"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+var (
+	// ErrTripNotRatable is returned when rating a trip that has not been completed
+	ErrTripNotRatable = errors.New("trip has not been completed")
+	// ErrNotTripParticipant is returned when someone who was not on the trip tries to rate it
+	ErrNotTripParticipant = errors.New("rater did not take part in the trip")
+	// ErrRatingWindowClosed is returned when a rating arrives after the rating window
+	ErrRatingWindowClosed = errors.New("rating window has closed")
+	// ErrInvalidScore is returned for scores outside 1-5
+	ErrInvalidScore = errors.New("score must be between 1 and 5")
+)
+
+const (
+	minRatingScore   = 1
+	maxRatingScore   = 5
+	maxCommentLength = 500
+)
+
+// RatingConfig controls when trips can be rated and how averages are kept
+type RatingConfig struct {
+	Window        time.Duration // how long after completion a trip can be rated
+	RollingWindow int           // number of most recent ratings in the average
+}
+
+// DefaultRatingConfig returns the default rating settings
+func DefaultRatingConfig() RatingConfig {
+	return RatingConfig{
+		Window:        7 * 24 * time.Hour,
+		RollingWindow: 100,
+	}
+}
+
+// RatingService lets riders and drivers rate each other after a trip and
+// keeps rolling average ratings for both sides
+type RatingService struct {
+	store     types.RatingStore
+	config    RatingConfig
+	analytics *analytics.Recorder
+	logger    *logger.Logger
+	mutex     sync.Mutex
+}
+
+// NewRatingService creates a new rating service
+func NewRatingService(store types.RatingStore, config RatingConfig, logger *logger.Logger) *RatingService {
+	return &RatingService{
+		store:  store,
+		config: config,
+		logger: logger,
+	}
+}
+
+// SubmitRatingRequest is a rider's or driver's review of a completed trip
+type SubmitRatingRequest struct {
+	TripID  string   `json:"trip_id"`
+	RaterID string   `json:"rater_id"`
+	Score   int      `json:"score"`
+	Comment string   `json:"comment,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+}
+
+// RecordCompletedTrip opens a completed trip for rating by its rider and driver
+func (s *RatingService) RecordCompletedTrip(ctx context.Context, tripID, riderID, driverID string, completedAt time.Time) error {
+	if tripID == "" || riderID == "" || driverID == "" {
+		return fmt.Errorf("trip, rider and driver IDs are required")
+	}
+
+	trip := &types.CompletedTrip{
+		TripID:      tripID,
+		RiderID:     riderID,
+		DriverID:    driverID,
+		CompletedAt: completedAt,
+	}
+	if err := s.store.SaveCompletedTrip(ctx, trip); err != nil {
+		return fmt.Errorf("failed to record completed trip: %w", err)
+	}
+	return nil
+}
+
+// SubmitRating saves a review of the other side of a completed trip and
+// updates the ratee's rolling average. The rater's role is taken from the trip.
+func (s *RatingService) SubmitRating(ctx context.Context, req *SubmitRatingRequest) (*types.Rating, *types.RatingSummary, error) {
+	if req.TripID == "" || req.RaterID == "" {
+		return nil, nil, fmt.Errorf("trip ID and rater ID are required")
+	}
+	if req.Score < minRatingScore || req.Score > maxRatingScore {
+		return nil, nil, ErrInvalidScore
+	}
+	if len(req.Comment) > maxCommentLength {
+		return nil, nil, fmt.Errorf("comment must be at most %d characters", maxCommentLength)
+	}
+
+	trip, err := s.store.GetCompletedTrip(ctx, req.TripID)
+	if errors.Is(err, types.ErrCompletedTripNotFound) {
+		return nil, nil, ErrTripNotRatable
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rating := &types.Rating{
+		ID:        generateRatingID(),
+		TripID:    trip.TripID,
+		RaterID:   req.RaterID,
+		Score:     req.Score,
+		Comment:   strings.TrimSpace(req.Comment),
+		Tags:      req.Tags,
+		CreatedAt: time.Now(),
+	}
+	switch req.RaterID {
+	case trip.RiderID:
+		rating.RaterRole = types.RaterRoleRider
+		rating.RateeID = trip.DriverID
+	case trip.DriverID:
+		rating.RaterRole = types.RaterRoleDriver
+		rating.RateeID = trip.RiderID
+	default:
+		return nil, nil, ErrNotTripParticipant
+	}
+
+	if rating.CreatedAt.After(trip.CompletedAt.Add(s.config.Window)) {
+		return nil, nil, ErrRatingWindowClosed
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := s.store.SaveRating(ctx, rating); err != nil {
+		return nil, nil, err
+	}
+
+	summary, err := s.updateSummary(ctx, rating)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+			"trip_id":  rating.TripID,
+			"ratee_id": rating.RateeID,
+		}).Error("Failed to update rating summary")
+		return nil, nil, fmt.Errorf("failed to update rating summary: %w", err)
+	}
+	s.recordRating(rating)
+
+	s.logger.WithContext(ctx).WithFields(logger.Fields{
+		"trip_id":    rating.TripID,
+		"rater_role": rating.RaterRole,
+		"ratee_id":   rating.RateeID,
+		"score":      rating.Score,
+		"average":    summary.Average,
+	}).Info("Trip rated")
+
+	return rating, summary, nil
+}
+
+// GetRatingSummary returns a user's rolling average in a role
+func (s *RatingService) GetRatingSummary(ctx context.Context, userID string, role types.RaterRole) (*types.RatingSummary, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user ID is required")
+	}
+	if err := validateRole(role); err != nil {
+		return nil, err
+	}
+	return s.store.GetRatingSummary(ctx, userID, role)
+}
+
+// ListReviews returns the most recent reviews a user received in a role, newest first
+func (s *RatingService) ListReviews(ctx context.Context, userID string, role types.RaterRole, limit int) ([]*types.Rating, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user ID is required")
+	}
+	if err := validateRole(role); err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > s.config.RollingWindow {
+		limit = s.config.RollingWindow
+	}
+	return s.store.GetRatings(ctx, userID, role, limit)
+}
+
+// GetDriverRatings returns the rating summaries of the given drivers. Drivers
+// who have not been rated yet are left out.
+func (s *RatingService) GetDriverRatings(ctx context.Context, driverIDs []string) (map[string]*types.RatingSummary, error) {
+	summaries := make(map[string]*types.RatingSummary, len(driverIDs))
+	for _, driverID := range driverIDs {
+		summary, err := s.store.GetRatingSummary(ctx, driverID, types.RaterRoleDriver)
+		if errors.Is(err, types.ErrRatingSummaryNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		summaries[driverID] = summary
+	}
+	return summaries, nil
+}
+
+// updateSummary adds a rating to the ratee's rolling average
+func (s *RatingService) updateSummary(ctx context.Context, rating *types.Rating) (*types.RatingSummary, error) {
+	role := rating.RaterRole.Ratee()
+	summary, err := s.store.GetRatingSummary(ctx, rating.RateeID, role)
+	if errors.Is(err, types.ErrRatingSummaryNotFound) {
+		summary = &types.RatingSummary{UserID: rating.RateeID, Role: role}
+	} else if err != nil {
+		return nil, err
+	}
+
+	summary.RecentScores = append(summary.RecentScores, rating.Score)
+	if len(summary.RecentScores) > s.config.RollingWindow {
+		summary.RecentScores = summary.RecentScores[len(summary.RecentScores)-s.config.RollingWindow:]
+	}
+
+	total := 0
+	for _, score := range summary.RecentScores {
+		total += score
+	}
+	summary.Average = float64(total) / float64(len(summary.RecentScores))
+	summary.RatingCount++
+	summary.UpdatedAt = rating.CreatedAt
+
+	if err := s.store.SaveRatingSummary(ctx, summary); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+func validateRole(role types.RaterRole) error {
+	if role != types.RaterRoleRider && role != types.RaterRoleDriver {
+		return fmt.Errorf("role must be %q or %q", types.RaterRoleRider, types.RaterRoleDriver)
+	}
+	return nil
+}
+
+func generateRatingID() string {
+	return fmt.Sprintf("rating_%d", time.Now().UnixNano())
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rideshare-platform/services/trip-service/internal/repository"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRatingTestService(config RatingConfig) *RatingService {
+	return NewRatingService(repository.NewMemoryRatingStore(), config, logger.NewLogger("test", "info"))
+}
+
+func TestRatingService_BothSidesRateCompletedTrip(t *testing.T) {
+	service := newRatingTestService(DefaultRatingConfig())
+	ctx := context.Background()
+
+	_, _, err := service.SubmitRating(ctx, &SubmitRatingRequest{TripID: "trip-1", RaterID: "rider-1", Score: 5})
+	assert.ErrorIs(t, err, ErrTripNotRatable)
+
+	assert.NoError(t, service.RecordCompletedTrip(ctx, "trip-1", "rider-1", "driver-1", time.Now()))
+
+	rating, summary, err := service.SubmitRating(ctx, &SubmitRatingRequest{TripID: "trip-1", RaterID: "rider-1", Score: 4, Comment: " Smooth ride "})
+	assert.NoError(t, err)
+	assert.Equal(t, types.RaterRoleRider, rating.RaterRole)
+	assert.Equal(t, "driver-1", rating.RateeID)
+	assert.Equal(t, "Smooth ride", rating.Comment)
+	assert.Equal(t, types.RaterRoleDriver, summary.Role)
+	assert.Equal(t, 4.0, summary.Average)
+
+	_, _, err = service.SubmitRating(ctx, &SubmitRatingRequest{TripID: "trip-1", RaterID: "rider-1", Score: 1})
+	assert.ErrorIs(t, err, types.ErrDuplicateRating)
+
+	_, _, err = service.SubmitRating(ctx, &SubmitRatingRequest{TripID: "trip-1", RaterID: "someone-else", Score: 1})
+	assert.ErrorIs(t, err, ErrNotTripParticipant)
+
+	_, _, err = service.SubmitRating(ctx, &SubmitRatingRequest{TripID: "trip-1", RaterID: "driver-1", Score: 6})
+	assert.ErrorIs(t, err, ErrInvalidScore)
+
+	rating, summary, err = service.SubmitRating(ctx, &SubmitRatingRequest{TripID: "trip-1", RaterID: "driver-1", Score: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, types.RaterRoleDriver, rating.RaterRole)
+	assert.Equal(t, "rider-1", summary.UserID)
+	assert.Equal(t, types.RaterRoleRider, summary.Role)
+
+	reviews, err := service.ListReviews(ctx, "driver-1", types.RaterRoleDriver, 10)
+	assert.NoError(t, err)
+	assert.Len(t, reviews, 1)
+	assert.Equal(t, "rider-1", reviews[0].RaterID)
+}
+
+func TestRatingService_RatingWindowCloses(t *testing.T) {
+	service := newRatingTestService(DefaultRatingConfig())
+	ctx := context.Background()
+
+	assert.NoError(t, service.RecordCompletedTrip(ctx, "trip-1", "rider-1", "driver-1", time.Now().Add(-8*24*time.Hour)))
+
+	_, _, err := service.SubmitRating(ctx, &SubmitRatingRequest{TripID: "trip-1", RaterID: "rider-1", Score: 5})
+	assert.ErrorIs(t, err, ErrRatingWindowClosed)
+}
+
+func TestRatingService_RollingAverage(t *testing.T) {
+	service := newRatingTestService(RatingConfig{Window: time.Hour, RollingWindow: 3})
+	ctx := context.Background()
+
+	for i, score := range []int{1, 5, 4, 3} {
+		tripID := "trip-" + string(rune('a'+i))
+		assert.NoError(t, service.RecordCompletedTrip(ctx, tripID, "rider-1", "driver-1", time.Now()))
+		_, _, err := service.SubmitRating(ctx, &SubmitRatingRequest{TripID: tripID, RaterID: "rider-1", Score: score})
+		assert.NoError(t, err)
+	}
+
+	// Only the three most recent scores count towards the average
+	summary, err := service.GetRatingSummary(ctx, "driver-1", types.RaterRoleDriver)
+	assert.NoError(t, err)
+	assert.Equal(t, 4.0, summary.Average)
+	assert.Equal(t, 4, summary.RatingCount)
+	assert.Equal(t, []int{5, 4, 3}, summary.RecentScores)
+
+	ratings, err := service.GetDriverRatings(ctx, []string{"driver-1", "driver-2"})
+	assert.NoError(t, err)
+	assert.Len(t, ratings, 1)
+	assert.Equal(t, 4.0, ratings["driver-1"].Average)
+}
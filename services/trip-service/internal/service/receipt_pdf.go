@@ -0,0 +1,216 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/i18n"
+	"github.com/rideshare-platform/shared/models"
+)
+
+const (
+	pdfPageWidth   = 595 // A4 in points
+	pdfPageHeight  = 842
+	pdfMargin      = 50
+	pdfFontSize    = 11
+	pdfLineSpacing = 16
+)
+
+// RenderReceiptPDF renders a receipt as a single-page PDF document, in the
+// locale's language and with its amounts and dates written the locale's way
+func RenderReceiptPDF(receipt *types.Receipt, locale string) []byte {
+	return buildPDF(receiptLines(receipt, locale))
+}
+
+// receiptLines lays out the receipt as the lines of text printed on the page
+func receiptLines(receipt *types.Receipt, locale string) []string {
+	f := i18n.NewFormatter(locale)
+	t := func(key string, args ...interface{}) string {
+		return i18n.T(locale, key, args...)
+	}
+	fareLine := func(label string, amount float64, code string) string {
+		return "  " + t("receipt.line", label, f.Amount(amount, code))
+	}
+
+	lines := []string{
+		t("receipt.title"),
+		"",
+		t("receipt.id", receipt.ID),
+		t("receipt.trip", receipt.TripID),
+		t("receipt.issued", f.DateTime(receipt.IssuedAt.UTC())),
+		"",
+		t("receipt.route"),
+	}
+
+	route := receipt.Route
+	if route.PickupLocation != nil {
+		lines = append(lines, "  "+t("receipt.from", formatReceiptLocation(route.PickupLocation)))
+	}
+	if route.Destination != nil {
+		lines = append(lines, "  "+t("receipt.to", formatReceiptLocation(route.Destination)))
+	}
+	lines = append(lines,
+		"  "+t("receipt.distance", f.Number(route.DistanceKm, 2)),
+		"  "+t("receipt.duration", route.DurationMinutes),
+		"  "+t("receipt.completed", f.DateTime(route.CompletedAt.UTC())),
+		"",
+		t("receipt.driver_and_vehicle"),
+		"  "+t("receipt.driver", receipt.Driver.ID),
+	)
+	if receipt.Driver.RatingCount > 0 {
+		lines = append(lines, "  "+t("receipt.rating", f.Number(receipt.Driver.Rating, 2), receipt.Driver.RatingCount))
+	}
+
+	vehicle := receipt.Vehicle
+	description := strings.TrimSpace(fmt.Sprintf("%s %s %s", vehicle.Color, vehicle.Make, vehicle.Model))
+	if description == "" {
+		description = vehicle.VehicleType
+	}
+	lines = append(lines, "  "+t("receipt.vehicle", description))
+	if vehicle.LicensePlate != "" {
+		lines = append(lines, "  "+t("receipt.plate", vehicle.LicensePlate))
+	}
+
+	lines = append(lines, "", t("receipt.fare"))
+	if fare := receipt.Fare; fare != nil {
+		lines = append(lines,
+			fareLine(t("receipt.base_fare"), fare.BaseFare, fare.Currency),
+			fareLine(t("receipt.distance_fare"), fare.DistanceFare, fare.Currency),
+			fareLine(t("receipt.time_fare"), fare.TimeFare, fare.Currency),
+		)
+		if fare.WaitingFare > 0 {
+			lines = append(lines, fareLine(t("receipt.waiting_fare"), fare.WaitingFare, fare.Currency))
+		}
+		if fare.SurgeAmount > 0 {
+			lines = append(lines, fareLine(t("receipt.surge", f.Number(fare.SurgeMultiplier, 1)), fare.SurgeAmount, fare.Currency))
+		}
+		for _, item := range []struct {
+			label  string
+			amount float64
+		}{
+			{t("receipt.booking_fee"), fare.BookingFee},
+			{t("receipt.service_fee"), fare.ServiceFee},
+			{t("receipt.tolls"), fare.Tolls},
+		} {
+			if item.amount > 0 {
+				lines = append(lines, fareLine(item.label, item.amount, fare.Currency))
+			}
+		}
+		lines = append(lines, taxLines(fare, locale)...)
+		if fare.DiscountAmount > 0 {
+			lines = append(lines, fareLine(t("receipt.discount"), -fare.DiscountAmount, fare.Currency))
+		}
+		lines = append(lines, fareLine(t("receipt.total"), fare.Total, fare.Currency))
+	} else {
+		lines = append(lines, "  "+t("receipt.fare_pending"))
+	}
+
+	lines = append(lines, "", t("receipt.payment"))
+	if payment := receipt.Payment; payment != nil {
+		lines = append(lines,
+			"  "+t("receipt.payment_status", payment.Status),
+			"  "+t("receipt.payment_method", payment.Method),
+			fareLine(t("receipt.charged"), payment.Amount, payment.Currency),
+		)
+	} else {
+		lines = append(lines, "  "+t("receipt.payment_pending"))
+	}
+
+	return lines
+}
+
+// taxLines lists each tax on the fare, or the tax total when pricing did not
+// itemize it
+func taxLines(fare *types.ReceiptFare, locale string) []string {
+	f := i18n.NewFormatter(locale)
+	if len(fare.TaxLines) == 0 {
+		if fare.Taxes > 0 {
+			return []string{"  " + i18n.T(locale, "receipt.line", i18n.T(locale, "receipt.taxes"), f.Amount(fare.Taxes, fare.Currency))}
+		}
+		return nil
+	}
+
+	lines := make([]string, 0, len(fare.TaxLines))
+	for _, tax := range fare.TaxLines {
+		label := tax.Name
+		if tax.Rate > 0 {
+			label = fmt.Sprintf("%s (%s)", tax.Name, f.Percent(tax.Rate))
+		}
+		lines = append(lines, "  "+i18n.T(locale, "receipt.line", label, f.Amount(tax.Amount, fare.Currency)))
+	}
+	return lines
+}
+
+func formatReceiptLocation(location *models.Location) string {
+	return fmt.Sprintf("%.5f, %.5f", location.Latitude, location.Longitude)
+}
+
+// buildPDF writes a minimal PDF with one page of Helvetica text
+func buildPDF(lines []string) []byte {
+	var content bytes.Buffer
+	fmt.Fprintf(&content, "BT\n/F1 %d Tf\n%d TL\n%d %d Td\n", pdfFontSize, pdfLineSpacing, pdfMargin, pdfPageHeight-pdfMargin)
+	for _, line := range lines {
+		fmt.Fprintf(&content, "(%s) Tj T*\n", escapePDFText(line))
+	}
+	content.WriteString("ET\n")
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>", pdfPageWidth, pdfPageHeight),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()),
+	}
+
+	var doc bytes.Buffer
+	doc.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, object := range objects {
+		offsets[i] = doc.Len()
+		fmt.Fprintf(&doc, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+
+	xref := doc.Len()
+	fmt.Fprintf(&doc, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&doc, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&doc, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return doc.Bytes()
+}
+
+// winAnsiExtras are the WinAnsiEncoding codes of the characters it places
+// outside Latin-1
+var winAnsiExtras = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '‘': 0x91, '’': 0x92,
+	'“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97,
+}
+
+// escapePDFText escapes a line for a PDF string literal. Accented letters and
+// symbols are written as their WinAnsiEncoding codes, narrow spaces as plain
+// spaces and characters the standard Helvetica encoding cannot show as '?'.
+func escapePDFText(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		if code, ok := winAnsiExtras[r]; ok {
+			fmt.Fprintf(&b, "\\%03o", code)
+			continue
+		}
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteRune('\\')
+			b.WriteRune(r)
+		case r == '\u202f' || r == '\u2009':
+			b.WriteByte(' ')
+		case r >= 160 && r <= 255:
+			fmt.Fprintf(&b, "\\%03o", r)
+		case r < 32 || r > 126:
+			b.WriteRune('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
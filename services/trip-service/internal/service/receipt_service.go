@@ -0,0 +1,325 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/currency"
+	"github.com/rideshare-platform/shared/events"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+)
+
+// CompletedTripDetails is what the trip knows about itself when it completes
+type CompletedTripDetails struct {
+	TripID          string
+	RiderID         string
+	DriverID        string
+	VehicleID       string
+	VehicleType     string
+	PickupArea      string
+	PickupLocation  *models.Location
+	Destination     *models.Location
+	DistanceKm      float64
+	DurationMinutes int
+	StartedAt       *time.Time
+	CompletedAt     time.Time
+	WaitingSeconds  int
+	// SurgeMultiplier was locked when the trip was requested. Zero prices the
+	// trip at the current surge.
+	SurgeMultiplier float64
+	City            string
+	Currency        string // currency the trip was quoted in
+}
+
+// FareCalculator asks pricing-service for the final fare of a completed trip
+type FareCalculator interface {
+	CalculateFinalFare(ctx context.Context, receipt *types.Receipt) (*types.ReceiptFare, error)
+}
+
+// PaymentLookup finds the payment charged for a trip. It returns nil when no
+// payment has been recorded yet.
+type PaymentLookup interface {
+	GetTripPayment(ctx context.Context, tripID string) (*types.ReceiptPayment, error)
+}
+
+// VehicleLookup fetches the details of the vehicle a trip was taken in
+type VehicleLookup interface {
+	GetVehicle(ctx context.Context, vehicleID string) (*types.ReceiptVehicle, error)
+}
+
+// ReceiptService composes receipts for completed trips from the trip's route,
+// pricing-service's fare, payment-service's payment and the driver's vehicle
+type ReceiptService struct {
+	store    types.ReceiptStore
+	fares    FareCalculator
+	payments PaymentLookup
+	vehicles VehicleLookup
+	routes   RouteLookup
+	ratings  *RatingService
+	events   *events.EventPublisher
+	logger   *logger.Logger
+	mutex    sync.Mutex
+}
+
+// NewReceiptService creates a new receipt service
+func NewReceiptService(store types.ReceiptStore, logger *logger.Logger) *ReceiptService {
+	return &ReceiptService{
+		store:  store,
+		logger: logger,
+	}
+}
+
+// SetFareCalculator attaches the pricing-service client used for final fares
+func (s *ReceiptService) SetFareCalculator(fares FareCalculator) {
+	s.fares = fares
+}
+
+// SetPaymentLookup attaches the payment-service client used for payment status
+func (s *ReceiptService) SetPaymentLookup(payments PaymentLookup) {
+	s.payments = payments
+}
+
+// SetVehicleLookup attaches the vehicle-service client used for vehicle details
+func (s *ReceiptService) SetVehicleLookup(vehicles VehicleLookup) {
+	s.vehicles = vehicles
+}
+
+// SetRouteLookup attaches the geo-service client used to measure the distance
+// actually travelled from the trip's recorded route
+func (s *ReceiptService) SetRouteLookup(routes RouteLookup) {
+	s.routes = routes
+}
+
+// SetRatingService attaches the rating service used to print the driver's rating
+func (s *ReceiptService) SetRatingService(ratings *RatingService) {
+	s.ratings = ratings
+}
+
+// SetEventPublisher attaches the publisher used to announce issued receipts
+func (s *ReceiptService) SetEventPublisher(publisher *events.EventPublisher) {
+	s.events = publisher
+}
+
+// GenerateReceipt builds and saves the receipt for a completed trip. Details
+// that downstream services cannot provide yet are filled in when the receipt
+// is next read.
+func (s *ReceiptService) GenerateReceipt(ctx context.Context, trip *CompletedTripDetails) (*types.Receipt, error) {
+	if trip.TripID == "" || trip.RiderID == "" || trip.DriverID == "" {
+		return nil, fmt.Errorf("trip, rider and driver IDs are required")
+	}
+
+	completedAt := trip.CompletedAt
+	if completedAt.IsZero() {
+		completedAt = time.Now()
+	}
+
+	receipt := &types.Receipt{
+		ID:      generateReceiptID(),
+		TripID:  trip.TripID,
+		RiderID: trip.RiderID,
+		Route: types.ReceiptRoute{
+			PickupLocation:  trip.PickupLocation,
+			Destination:     trip.Destination,
+			PickupArea:      trip.PickupArea,
+			City:            trip.City,
+			DistanceKm:      trip.DistanceKm,
+			DurationMinutes: trip.DurationMinutes,
+			StartedAt:       trip.StartedAt,
+			CompletedAt:     completedAt,
+			WaitingSeconds:  trip.WaitingSeconds,
+		},
+		LockedSurgeMultiplier: trip.SurgeMultiplier,
+		Currency:              trip.Currency,
+		Driver:                types.ReceiptDriver{ID: trip.DriverID},
+		Vehicle:               types.ReceiptVehicle{ID: trip.VehicleID, VehicleType: trip.VehicleType},
+		IssuedAt:              time.Now(),
+		UpdatedAt:             time.Now(),
+	}
+
+	s.fillRoute(ctx, receipt)
+	s.fillDriver(ctx, receipt)
+	s.fillVehicle(ctx, receipt)
+	s.fillFare(ctx, receipt)
+	s.fillPayment(ctx, receipt)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if err := s.store.SaveReceipt(ctx, receipt); err != nil {
+		return nil, fmt.Errorf("failed to save receipt: %w", err)
+	}
+
+	s.publishReceiptIssued(ctx, receipt)
+	return receipt, nil
+}
+
+// GetReceipt returns a trip's receipt, retrying a missing fare and refreshing
+// the payment until it has settled
+func (s *ReceiptService) GetReceipt(ctx context.Context, tripID string) (*types.Receipt, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	receipt, err := s.store.GetReceipt(ctx, tripID)
+	if err != nil {
+		return nil, err
+	}
+
+	if receipt.Fare != nil && receipt.Payment != nil && receipt.Payment.Settled() {
+		return receipt, nil
+	}
+
+	fare, payment := receipt.Fare, receipt.Payment
+	s.fillFare(ctx, receipt)
+	s.fillPayment(ctx, receipt)
+	if receipt.Fare == fare && receipt.Payment == payment {
+		return receipt, nil
+	}
+
+	receipt.UpdatedAt = time.Now()
+	if err := s.store.SaveReceipt(ctx, receipt); err != nil {
+		return nil, fmt.Errorf("failed to save receipt: %w", err)
+	}
+	return receipt, nil
+}
+
+// fillRoute replaces the reported distance with the distance of the recorded
+// route, so the fare is based on where the trip actually went
+func (s *ReceiptService) fillRoute(ctx context.Context, receipt *types.Receipt) {
+	if s.routes == nil {
+		return
+	}
+
+	recorded, err := s.routes.GetTripRoute(ctx, receipt.TripID)
+	if err != nil {
+		s.warn(ctx, err, receipt.TripID, "Failed to get recorded route for receipt")
+		return
+	}
+	if !usableRoute(recorded) {
+		return
+	}
+
+	route := &receipt.Route
+	route.DistanceKm = recorded.DistanceKm
+	route.Recorded = true
+	if route.PickupLocation == nil {
+		pickup := recorded.Points[0]
+		route.PickupLocation = &pickup
+	}
+	if route.Destination == nil {
+		destination := recorded.Points[len(recorded.Points)-1]
+		route.Destination = &destination
+	}
+}
+
+func (s *ReceiptService) fillFare(ctx context.Context, receipt *types.Receipt) {
+	if s.fares == nil || receipt.Fare != nil {
+		return
+	}
+
+	fare, err := s.fares.CalculateFinalFare(ctx, receipt)
+	if err != nil {
+		s.warn(ctx, err, receipt.TripID, "Failed to get final fare for receipt")
+		return
+	}
+	if receipt.Currency == "" {
+		receipt.Currency = fare.Currency
+	} else if err := currency.Match(receipt.Currency, fare.Currency); err != nil {
+		s.warn(ctx, err, receipt.TripID, "Final fare is not in the currency the trip was quoted in")
+		return
+	}
+	receipt.Fare = fare
+}
+
+func (s *ReceiptService) fillPayment(ctx context.Context, receipt *types.Receipt) {
+	if s.payments == nil || (receipt.Payment != nil && receipt.Payment.Settled()) {
+		return
+	}
+
+	payment, err := s.payments.GetTripPayment(ctx, receipt.TripID)
+	if err != nil {
+		s.warn(ctx, err, receipt.TripID, "Failed to get payment for receipt")
+		return
+	}
+	if payment == nil {
+		return
+	}
+	if receipt.Currency != "" {
+		if err := currency.Match(receipt.Currency, payment.Currency); err != nil {
+			s.warn(ctx, err, receipt.TripID, "Payment is not in the currency the trip was quoted in")
+			return
+		}
+	}
+	receipt.Payment = payment
+}
+
+func (s *ReceiptService) fillVehicle(ctx context.Context, receipt *types.Receipt) {
+	if s.vehicles == nil || receipt.Vehicle.ID == "" {
+		return
+	}
+
+	vehicle, err := s.vehicles.GetVehicle(ctx, receipt.Vehicle.ID)
+	if err != nil {
+		s.warn(ctx, err, receipt.TripID, "Failed to get vehicle for receipt")
+		return
+	}
+	if vehicle.VehicleType == "" {
+		vehicle.VehicleType = receipt.Vehicle.VehicleType
+	}
+	receipt.Vehicle = *vehicle
+}
+
+func (s *ReceiptService) fillDriver(ctx context.Context, receipt *types.Receipt) {
+	if s.ratings == nil {
+		return
+	}
+
+	summary, err := s.ratings.GetRatingSummary(ctx, receipt.Driver.ID, types.RaterRoleDriver)
+	if errors.Is(err, types.ErrRatingSummaryNotFound) {
+		return
+	}
+	if err != nil {
+		s.warn(ctx, err, receipt.TripID, "Failed to get driver rating for receipt")
+		return
+	}
+	receipt.Driver.Rating = summary.Average
+	receipt.Driver.RatingCount = summary.RatingCount
+}
+
+func (s *ReceiptService) publishReceiptIssued(ctx context.Context, receipt *types.Receipt) {
+	if s.events == nil {
+		return
+	}
+
+	data := map[string]interface{}{
+		"receipt_id": receipt.ID,
+		"trip_id":    receipt.TripID,
+		"rider_id":   receipt.RiderID,
+		"driver_id":  receipt.Driver.ID,
+	}
+	if receipt.Fare != nil {
+		data["total"] = receipt.Fare.Total
+		data["currency"] = receipt.Fare.Currency
+	}
+
+	event := events.NewEvent(events.TripReceiptIssuedEvent, receipt.TripID, 1, data, "trip-service")
+	if err := s.events.PublishEvent(ctx, event); err != nil {
+		s.warn(ctx, err, receipt.TripID, "Failed to publish receipt issued event")
+	}
+}
+
+func (s *ReceiptService) warn(ctx context.Context, err error, tripID, message string) {
+	if s.logger == nil {
+		return
+	}
+	s.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+		"trip_id": tripID,
+	}).Warn(message)
+}
+
+func generateReceiptID() string {
+	return fmt.Sprintf("receipt_%d", time.Now().UnixNano())
+}
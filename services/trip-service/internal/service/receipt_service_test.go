@@ -0,0 +1,350 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rideshare-platform/services/trip-service/internal/repository"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/events"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+	"github.com/rideshare-platform/shared/notifications"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeFareCalculator returns a fixed fare once pricing is available
+type fakeFareCalculator struct {
+	err   error
+	calls int
+}
+
+func (f *fakeFareCalculator) CalculateFinalFare(ctx context.Context, receipt *types.Receipt) (*types.ReceiptFare, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &types.ReceiptFare{
+		BaseFare:     2.50,
+		DistanceFare: receipt.Route.DistanceKm * 1.2,
+		TimeFare:     float64(receipt.Route.DurationMinutes) * 0.25,
+		Total:        2.50 + receipt.Route.DistanceKm*1.2 + float64(receipt.Route.DurationMinutes)*0.25,
+		Currency:     "USD",
+	}, nil
+}
+
+// fakePaymentLookup returns whatever payment is currently recorded for the trip
+type fakePaymentLookup struct {
+	payment *types.ReceiptPayment
+}
+
+func (f *fakePaymentLookup) GetTripPayment(ctx context.Context, tripID string) (*types.ReceiptPayment, error) {
+	if f.payment == nil {
+		return nil, nil
+	}
+	payment := *f.payment
+	return &payment, nil
+}
+
+// fakeVehicleLookup returns a fixed vehicle
+type fakeVehicleLookup struct{}
+
+func (f *fakeVehicleLookup) GetVehicle(ctx context.Context, vehicleID string) (*types.ReceiptVehicle, error) {
+	return &types.ReceiptVehicle{
+		ID:           vehicleID,
+		Make:         "Toyota",
+		Model:        "Prius",
+		Color:        "White",
+		LicensePlate: "ABC-123",
+	}, nil
+}
+
+// fakeRouteLookup returns a fixed recorded route
+type fakeRouteLookup struct {
+	route *TripRoute
+	err   error
+}
+
+func (f *fakeRouteLookup) GetTripRoute(ctx context.Context, tripID string) (*TripRoute, error) {
+	return f.route, f.err
+}
+
+func newReceiptTestService(fares FareCalculator, payments PaymentLookup) *ReceiptService {
+	s := NewReceiptService(repository.NewMemoryReceiptStore(), logger.NewLogger("test", "info"))
+	s.SetFareCalculator(fares)
+	s.SetPaymentLookup(payments)
+	s.SetVehicleLookup(&fakeVehicleLookup{})
+	return s
+}
+
+func newCompletedTripDetails() *CompletedTripDetails {
+	return &CompletedTripDetails{
+		TripID:          "trip-1",
+		RiderID:         "rider-1",
+		DriverID:        "driver-1",
+		VehicleID:       "vehicle-1",
+		VehicleType:     "sedan",
+		PickupLocation:  &models.Location{Latitude: 37.77, Longitude: -122.41},
+		Destination:     &models.Location{Latitude: 37.80, Longitude: -122.40},
+		DistanceKm:      5,
+		DurationMinutes: 12,
+		CompletedAt:     time.Now(),
+	}
+}
+
+func TestReceiptService_GenerateReceipt(t *testing.T) {
+	payments := &fakePaymentLookup{payment: &types.ReceiptPayment{PaymentID: "payment-1", Status: "completed", Method: "credit_card", Amount: 11.50, Currency: "USD"}}
+	s := newReceiptTestService(&fakeFareCalculator{}, payments)
+	ctx := context.Background()
+
+	ratings := NewRatingService(repository.NewMemoryRatingStore(), DefaultRatingConfig(), logger.NewLogger("test", "info"))
+	assert.NoError(t, ratings.RecordCompletedTrip(ctx, "trip-0", "rider-0", "driver-1", time.Now()))
+	_, _, err := ratings.SubmitRating(ctx, &SubmitRatingRequest{TripID: "trip-0", RaterID: "rider-0", Score: 4})
+	assert.NoError(t, err)
+	s.SetRatingService(ratings)
+
+	details := newCompletedTripDetails()
+	details.SurgeMultiplier = 1.4
+	details.WaitingSeconds = 240
+	receipt, err := s.GenerateReceipt(ctx, details)
+	assert.NoError(t, err)
+	assert.Equal(t, 1.4, receipt.LockedSurgeMultiplier)
+	assert.Equal(t, 240, receipt.Route.WaitingSeconds)
+	assert.Equal(t, 11.50, receipt.Fare.Total)
+	assert.Equal(t, "completed", receipt.Payment.Status)
+	assert.Equal(t, "ABC-123", receipt.Vehicle.LicensePlate)
+	assert.Equal(t, "sedan", receipt.Vehicle.VehicleType)
+	assert.Equal(t, 4.0, receipt.Driver.Rating)
+	assert.Equal(t, 5.0, receipt.Route.DistanceKm)
+
+	stored, err := s.GetReceipt(ctx, "trip-1")
+	assert.NoError(t, err)
+	assert.Equal(t, receipt.ID, stored.ID)
+
+	_, err = s.GetReceipt(ctx, "missing")
+	assert.ErrorIs(t, err, types.ErrReceiptNotFound)
+
+	_, err = s.GenerateReceipt(ctx, &CompletedTripDetails{TripID: "trip-2"})
+	assert.Error(t, err)
+}
+
+func TestReceiptService_UsesRecordedRouteDistance(t *testing.T) {
+	s := newReceiptTestService(&fakeFareCalculator{}, &fakePaymentLookup{})
+	s.SetRouteLookup(&fakeRouteLookup{route: &TripRoute{
+		Points: []models.Location{
+			{Latitude: 37.77, Longitude: -122.41},
+			{Latitude: 37.79, Longitude: -122.40},
+			{Latitude: 37.80, Longitude: -122.40},
+		},
+		DistanceKm:      6.5,
+		DurationSeconds: 780,
+	}})
+
+	receipt, err := s.GenerateReceipt(context.Background(), newCompletedTripDetails())
+	assert.NoError(t, err)
+	assert.True(t, receipt.Route.Recorded)
+	assert.Equal(t, 6.5, receipt.Route.DistanceKm)
+	assert.InDelta(t, 2.50+6.5*1.2+12*0.25, receipt.Fare.Total, 0.001, "fare is priced on the recorded distance")
+
+	// Without a usable recorded route the reported distance is kept
+	s.SetRouteLookup(&fakeRouteLookup{err: errors.New("geo-service unavailable")})
+	details := newCompletedTripDetails()
+	details.TripID = "trip-2"
+	receipt, err = s.GenerateReceipt(context.Background(), details)
+	assert.NoError(t, err)
+	assert.False(t, receipt.Route.Recorded)
+	assert.Equal(t, 5.0, receipt.Route.DistanceKm)
+}
+
+func TestReceiptService_GetReceiptFillsInPendingDetails(t *testing.T) {
+	fares := &fakeFareCalculator{err: errors.New("pricing unavailable")}
+	payments := &fakePaymentLookup{}
+	s := newReceiptTestService(fares, payments)
+	ctx := context.Background()
+
+	receipt, err := s.GenerateReceipt(ctx, newCompletedTripDetails())
+	assert.NoError(t, err)
+	assert.Nil(t, receipt.Fare)
+	assert.Nil(t, receipt.Payment)
+
+	// Pricing recovers and the charge is still processing
+	fares.err = nil
+	payments.payment = &types.ReceiptPayment{PaymentID: "payment-1", Status: "processing", Amount: 11.50, Currency: "USD"}
+	receipt, err = s.GetReceipt(ctx, "trip-1")
+	assert.NoError(t, err)
+	assert.NotNil(t, receipt.Fare)
+	assert.Equal(t, "processing", receipt.Payment.Status)
+
+	// The settled payment replaces the processing one and the fare is not recalculated
+	payments.payment.Status = "completed"
+	receipt, err = s.GetReceipt(ctx, "trip-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "completed", receipt.Payment.Status)
+	assert.Equal(t, 2, fares.calls)
+
+	payments.payment.Status = "refunded"
+	receipt, err = s.GetReceipt(ctx, "trip-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "completed", receipt.Payment.Status, "settled payments are not refreshed")
+}
+
+func TestReceiptService_SkipsDetailsInAnotherCurrency(t *testing.T) {
+	payments := &fakePaymentLookup{payment: &types.ReceiptPayment{PaymentID: "payment-1", Status: "completed", Amount: 11.50, Currency: "USD"}}
+	s := newReceiptTestService(&fakeFareCalculator{}, payments)
+	ctx := context.Background()
+
+	details := newCompletedTripDetails()
+	details.Currency = "EUR"
+	receipt, err := s.GenerateReceipt(ctx, details)
+	assert.NoError(t, err)
+	assert.Equal(t, "EUR", receipt.Currency)
+	assert.Nil(t, receipt.Fare, "fare priced in USD is not shown on a EUR receipt")
+	assert.Nil(t, receipt.Payment, "payment charged in USD is not shown on a EUR receipt")
+
+	payments.payment.Currency = "eur"
+	receipt, err = s.GetReceipt(ctx, "trip-1")
+	assert.NoError(t, err)
+	assert.Equal(t, "completed", receipt.Payment.Status)
+}
+
+func TestReceiptService_NotifiesRiderWhenReceiptIssued(t *testing.T) {
+	log := logger.NewLogger("test", "info")
+	publisher := events.NewEventPublisher(events.NewInMemoryEventBus(log), events.NewInMemoryEventStore(log), log)
+	notifier := notifications.NewDispatcher(notifications.NewMemoryStore(), log)
+	notifier.RegisterProvider(notifications.NewLogProvider(notifications.ChannelPush, log))
+	assert.NoError(t, notifier.SubscribeEvents(publisher, notifications.DefaultEventRoutes()))
+
+	s := newReceiptTestService(&fakeFareCalculator{}, &fakePaymentLookup{})
+	s.SetEventPublisher(publisher)
+	_, err := s.GenerateReceipt(context.Background(), newCompletedTripDetails())
+	assert.NoError(t, err)
+
+	var deliveries []*notifications.Delivery
+	assert.Eventually(t, func() bool {
+		deliveries, _ = notifier.GetDeliveries(context.Background(), "rider-1", 0)
+		return len(deliveries) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	statuses := map[notifications.Channel]notifications.DeliveryStatus{}
+	for _, delivery := range deliveries {
+		assert.Equal(t, notifications.TemplateReceiptReady, delivery.Template)
+		statuses[delivery.Channel] = delivery.Status
+	}
+	assert.Equal(t, notifications.DeliveryStatusSent, statuses[notifications.ChannelPush])
+	assert.Equal(t, notifications.DeliveryStatusSkipped, statuses[notifications.ChannelEmail], "no email provider is registered")
+}
+
+// fakeLocaleLookup returns each user's profile locale
+type fakeLocaleLookup map[string]string
+
+func (f fakeLocaleLookup) GetLocale(ctx context.Context, userID string) (string, error) {
+	return f[userID], nil
+}
+
+// recordingProvider keeps the messages it is asked to send
+type recordingProvider struct {
+	messages chan *notifications.Message
+}
+
+func (p *recordingProvider) Channel() notifications.Channel {
+	return notifications.ChannelPush
+}
+
+func (p *recordingProvider) Send(ctx context.Context, msg *notifications.Message) error {
+	p.messages <- msg
+	return nil
+}
+
+func TestReceiptService_NotifiesRiderInTheirLocale(t *testing.T) {
+	log := logger.NewLogger("test", "info")
+	publisher := events.NewEventPublisher(events.NewInMemoryEventBus(log), events.NewInMemoryEventStore(log), log)
+	notifier := notifications.NewDispatcher(notifications.NewMemoryStore(), log)
+	provider := &recordingProvider{messages: make(chan *notifications.Message, 1)}
+	notifier.RegisterProvider(provider)
+	notifier.SetLocaleLookup(fakeLocaleLookup{"rider-1": "de-DE"})
+	assert.NoError(t, notifier.SubscribeEvents(publisher, notifications.DefaultEventRoutes()))
+
+	s := newReceiptTestService(&fakeFareCalculator{}, &fakePaymentLookup{})
+	s.SetEventPublisher(publisher)
+	_, err := s.GenerateReceipt(context.Background(), newCompletedTripDetails())
+	assert.NoError(t, err)
+
+	select {
+	case msg := <-provider.messages:
+		assert.Equal(t, "de-DE", msg.Locale)
+		assert.Equal(t, "Ihr Fahrtbeleg", msg.Subject)
+		assert.Equal(t, "Danke, dass Sie mit uns gefahren sind. Ihr Beleg für die Fahrt trip-1 ist bereit: 11,50 $.", msg.Body)
+	case <-time.After(time.Second):
+		t.Fatal("rider was not notified")
+	}
+}
+
+func TestRenderReceiptPDF(t *testing.T) {
+	s := newReceiptTestService(&fakeFareCalculator{}, &fakePaymentLookup{})
+	details := newCompletedTripDetails()
+	details.DriverID = "driver (1)"
+
+	receipt, err := s.GenerateReceipt(context.Background(), details)
+	assert.NoError(t, err)
+
+	pdf := RenderReceiptPDF(receipt, "en")
+	assert.True(t, bytes.HasPrefix(pdf, []byte("%PDF-1.4")))
+	assert.True(t, bytes.HasSuffix(pdf, []byte("%%EOF\n")))
+	assert.Contains(t, string(pdf), `(  Driver: driver \(1\)) Tj`)
+	assert.Contains(t, string(pdf), "Payment pending")
+}
+
+func TestReceiptLines_ItemizeTaxes(t *testing.T) {
+	receipt := &types.Receipt{
+		Fare: &types.ReceiptFare{
+			Total:    26.75,
+			Taxes:    6.75,
+			Currency: "USD",
+			TaxLines: []*types.ReceiptTaxLine{
+				{Name: "Sales tax", Type: "percentage", Rate: 0.2, Amount: 4},
+				{Name: "Airport levy", Type: "zone_levy", Amount: 2.75},
+			},
+		},
+	}
+
+	pdf := string(RenderReceiptPDF(receipt, "en"))
+	assert.Contains(t, pdf, "Sales tax \\(20%\\): $4.00")
+	assert.Contains(t, pdf, "Airport levy: $2.75")
+	assert.NotContains(t, pdf, "Taxes:")
+}
+
+func TestReceiptLines_Localized(t *testing.T) {
+	completedAt := time.Date(2026, 3, 4, 17, 5, 0, 0, time.UTC)
+	receipt := &types.Receipt{
+		TripID: "trip-1",
+		Route:  types.ReceiptRoute{DistanceKm: 12.5, DurationMinutes: 25, CompletedAt: completedAt},
+		Fare: &types.ReceiptFare{
+			BaseFare: 1234.5,
+			Total:    1240.1,
+			Currency: "EUR",
+			TaxLines: []*types.ReceiptTaxLine{
+				{Name: "TVA", Type: "percentage", Rate: 0.055, Amount: 5.6},
+			},
+		},
+		IssuedAt: completedAt,
+	}
+
+	lines := receiptLines(receipt, "fr")
+	assert.Equal(t, "Reçu de course", lines[0])
+	assert.Contains(t, lines, "  Distance : 12,50 km")
+	assert.Contains(t, lines, "  Terminée le : 04/03/2026 17:05 UTC")
+	assert.Contains(t, lines, "  Prise en charge : 1\u00a0234,50 €")
+	assert.Contains(t, lines, "  TVA (5,5\u00a0%) : 5,60 €")
+	assert.Contains(t, lines, "  Paiement en attente")
+
+	lines = receiptLines(receipt, "en-US")
+	assert.Contains(t, lines, "  Completed: 03/04/2026 5:05 PM UTC")
+	assert.Contains(t, lines, "  Base fare: €1,234.50")
+
+	pdf := string(RenderReceiptPDF(receipt, "fr"))
+	assert.Contains(t, pdf, "(Re\\347u de course) Tj")
+	assert.Contains(t, pdf, "1\\240234,50 \\200")
+}
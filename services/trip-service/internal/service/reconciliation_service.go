@@ -0,0 +1,409 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/alerting"
+	"github.com/rideshare-platform/shared/currency"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+)
+
+// ErrInvalidReconciliationPeriod is returned when a reconciliation period ends before it starts
+var ErrInvalidReconciliationPeriod = errors.New("reconciliation period must end after it starts")
+
+// Metrics reported to the alert evaluator after every reconciliation run
+const (
+	MetricReconciliationMissingPayments = "reconciliation_missing_payments"
+	MetricReconciliationAmountDrifts    = "reconciliation_amount_drifts"
+	MetricReconciliationOrphanPayments  = "reconciliation_orphan_payments"
+	MetricReconciliationRunFailed       = "reconciliation_run_failed"
+)
+
+// ChargeSource lists the charges payment-service has recorded
+type ChargeSource interface {
+	GetTripCharges(ctx context.Context, tripID string) ([]*types.ReconciliationPayment, error)
+	ListCharges(ctx context.Context, from, to time.Time) ([]*types.ReconciliationPayment, error)
+}
+
+// AlertEvaluator raises alerts from metrics, normally shared/alerting's AlertManager
+type AlertEvaluator interface {
+	EvaluateMetrics(ctx context.Context, metrics []*alerting.MetricValue) error
+}
+
+// ReconciliationConfig controls what counts as a mismatch and when the nightly run starts
+type ReconciliationConfig struct {
+	ToleranceCents int64         // charges this close to the fare are not drift
+	RunAt          time.Duration // time after midnight UTC the previous day is reconciled
+}
+
+// DefaultReconciliationConfig returns the default reconciliation settings
+func DefaultReconciliationConfig() ReconciliationConfig {
+	return ReconciliationConfig{
+		ToleranceCents: 1,
+		RunAt:          2 * time.Hour,
+	}
+}
+
+// ReconciliationAlertRules returns the alert rules for reconciliation
+// metrics, to be added to the AlertManager evaluating them
+func ReconciliationAlertRules() []*alerting.AlertRule {
+	rule := func(id, name, description, metric string, severity alerting.AlertSeverity) *alerting.AlertRule {
+		return &alerting.AlertRule{
+			ID:          id,
+			Name:        name,
+			Description: description,
+			Conditions: []alerting.AlertCondition{
+				{Metric: metric, Operator: "gt", Threshold: 0},
+			},
+			Severity: severity,
+			Actions: []alerting.AlertAction{
+				{Type: "email", Target: "payments@rideshare.com", Enabled: true},
+				{Type: "slack", Target: "#payments-reconciliation", Enabled: true},
+			},
+			Cooldown: 12 * time.Hour,
+			Enabled:  true,
+		}
+	}
+
+	return []*alerting.AlertRule{
+		rule("reconciliation_missing_payments", "Completed Trips Without Payment",
+			"Reconciliation found completed trips with no successful charge",
+			MetricReconciliationMissingPayments, alerting.SeverityCritical),
+		rule("reconciliation_amount_drift", "Trip Charge Amount Drift",
+			"Reconciliation found trips charged a different amount than their fare",
+			MetricReconciliationAmountDrifts, alerting.SeverityWarning),
+		rule("reconciliation_orphan_payments", "Orphan Payments",
+			"Reconciliation found charges for trips that do not exist or did not complete",
+			MetricReconciliationOrphanPayments, alerting.SeverityWarning),
+		rule("reconciliation_run_failed", "Reconciliation Run Failed",
+			"The trip and payment reconciliation could not finish",
+			MetricReconciliationRunFailed, alerting.SeverityWarning),
+	}
+}
+
+// ReconciliationService cross-checks completed trips against the charges
+// payment-service recorded and reports where they disagree
+type ReconciliationService struct {
+	trips   TripRepositoryInterface
+	charges ChargeSource
+	store   types.ReconciliationReportStore
+	alerts  AlertEvaluator
+	config  ReconciliationConfig
+	logger  *logger.Logger
+
+	// mutex allows one run at a time
+	mutex sync.Mutex
+}
+
+// NewReconciliationService creates a new reconciliation service
+func NewReconciliationService(trips TripRepositoryInterface, charges ChargeSource, store types.ReconciliationReportStore, config ReconciliationConfig, logger *logger.Logger) *ReconciliationService {
+	return &ReconciliationService{
+		trips:   trips,
+		charges: charges,
+		store:   store,
+		config:  config,
+		logger:  logger,
+	}
+}
+
+// SetAlertEvaluator attaches the alert manager mismatches are reported to
+func (s *ReconciliationService) SetAlertEvaluator(alerts AlertEvaluator) {
+	s.alerts = alerts
+}
+
+// ReconcileDay reconciles the UTC day containing day
+func (s *ReconciliationService) ReconcileDay(ctx context.Context, day time.Time) (*types.ReconciliationReport, error) {
+	start := day.UTC().Truncate(24 * time.Hour)
+	return s.Reconcile(ctx, start, start.Add(24*time.Hour))
+}
+
+// Reconcile checks the trips completed and the charges created in [from, to)
+// and saves the report. A run that cannot finish is still saved, with the
+// failure in the report's Error.
+func (s *ReconciliationService) Reconcile(ctx context.Context, from, to time.Time) (*types.ReconciliationReport, error) {
+	if !to.After(from) {
+		return nil, ErrInvalidReconciliationPeriod
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	report := &types.ReconciliationReport{
+		ID:          generateReconciliationReportID(),
+		PeriodStart: from.UTC(),
+		PeriodEnd:   to.UTC(),
+		StartedAt:   time.Now().UTC(),
+		Mismatches:  []*types.ReconciliationMismatch{},
+	}
+
+	err := s.checkTrips(ctx, report)
+	if err == nil {
+		err = s.checkCharges(ctx, report)
+	}
+	if err != nil {
+		report.Error = err.Error()
+	}
+	report.FinishedAt = time.Now().UTC()
+
+	if err := s.store.SaveReport(ctx, report); err != nil {
+		return nil, fmt.Errorf("failed to save reconciliation report: %w", err)
+	}
+	s.raiseAlerts(ctx, report)
+
+	entry := s.logger.WithContext(ctx).WithFields(logger.Fields{
+		"report_id":        report.ID,
+		"period_start":     report.PeriodStart,
+		"trips_checked":    report.TripsChecked,
+		"payments_checked": report.PaymentsChecked,
+		"missing_payments": report.MissingPayments,
+		"amount_drifts":    report.AmountDrifts,
+		"orphan_payments":  report.OrphanPayments,
+	})
+	switch {
+	case report.Error != "":
+		entry.WithError(err).Warn("Reconciliation run failed")
+	case len(report.Mismatches) > 0:
+		entry.Warn("Reconciliation found mismatches")
+	default:
+		entry.Info("Reconciliation found no mismatches")
+	}
+	return report, nil
+}
+
+// GetReport returns a saved reconciliation report
+func (s *ReconciliationService) GetReport(ctx context.Context, reportID string) (*types.ReconciliationReport, error) {
+	return s.store.GetReport(ctx, reportID)
+}
+
+// ListReports returns the most recent reconciliation reports, newest first
+func (s *ReconciliationService) ListReports(ctx context.Context, limit int) ([]*types.ReconciliationReport, error) {
+	return s.store.ListReports(ctx, limit)
+}
+
+// StartNightly reconciles the previous UTC day every night at the configured
+// time until the context is cancelled
+func (s *ReconciliationService) StartNightly(ctx context.Context) {
+	for {
+		now := time.Now()
+		next := nextReconciliationRun(now, s.config.RunAt)
+		timer := time.NewTimer(next.Sub(now))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			if _, err := s.ReconcileDay(ctx, next.Add(-24*time.Hour)); err != nil {
+				s.logger.WithContext(ctx).WithError(err).Warn("Nightly reconciliation failed")
+			}
+		}
+	}
+}
+
+// checkTrips compares each trip completed in the period with its charges
+func (s *ReconciliationService) checkTrips(ctx context.Context, report *types.ReconciliationReport) error {
+	completed, err := s.trips.GetByStatus(ctx, models.TripStatusCompleted)
+	if err != nil {
+		return fmt.Errorf("failed to list completed trips: %w", err)
+	}
+
+	var trips []*models.Trip
+	for _, trip := range completed {
+		if trip.CompletedAt != nil && !trip.CompletedAt.Before(report.PeriodStart) && trip.CompletedAt.Before(report.PeriodEnd) {
+			trips = append(trips, trip)
+		}
+	}
+	sort.Slice(trips, func(i, j int) bool {
+		return trips[i].CompletedAt.Before(*trips[j].CompletedAt)
+	})
+
+	for _, trip := range trips {
+		charges, err := s.charges.GetTripCharges(ctx, trip.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get charges for trip %s: %w", trip.ID, err)
+		}
+		report.TripsChecked++
+		if mismatch := s.checkTrip(trip, charges); mismatch != nil {
+			addMismatch(report, mismatch)
+		}
+	}
+	return nil
+}
+
+// checkTrip compares a completed trip's fare with what was charged for it
+// net of refunds, or returns nil when they agree
+func (s *ReconciliationService) checkTrip(trip *models.Trip, charges []*types.ReconciliationPayment) *types.ReconciliationMismatch {
+	var expected int64
+	if trip.ActualFareCents != nil {
+		expected = *trip.ActualFareCents
+	}
+
+	mismatch := &types.ReconciliationMismatch{
+		TripID:        trip.ID,
+		TripStatus:    string(trip.Status),
+		ExpectedCents: expected,
+		Currency:      trip.Currency,
+	}
+
+	settled := settledCharges(charges)
+	if len(settled) == 0 {
+		if expected == 0 {
+			return nil
+		}
+		mismatch.Type = types.MismatchMissingPayment
+		mismatch.Details = fmt.Sprintf("fare of %s was never charged", formatCents(expected, trip.Currency))
+		if len(charges) > 0 {
+			mismatch.Details += fmt.Sprintf(", %d charge attempts did not complete", len(charges))
+		}
+		return mismatch
+	}
+
+	for _, charge := range settled {
+		mismatch.PaymentIDs = append(mismatch.PaymentIDs, charge.PaymentID)
+		mismatch.ChargedCents += currency.ToMinor(charge.Amount-charge.RefundedAmount, charge.Currency)
+		if trip.Currency != "" && !strings.EqualFold(charge.Currency, trip.Currency) {
+			mismatch.ChargeCurrency = strings.ToUpper(charge.Currency)
+		}
+	}
+
+	mismatch.Type = types.MismatchAmountDrift
+	if mismatch.ChargeCurrency != "" {
+		mismatch.Details = fmt.Sprintf("charged in %s, fare is in %s", mismatch.ChargeCurrency, trip.Currency)
+		return mismatch
+	}
+
+	drift := mismatch.ChargedCents - expected
+	if drift < 0 {
+		drift = -drift
+	}
+	if drift <= s.config.ToleranceCents {
+		return nil
+	}
+	mismatch.Details = fmt.Sprintf("charged %s, fare is %s",
+		formatCents(mismatch.ChargedCents, trip.Currency), formatCents(expected, trip.Currency))
+	return mismatch
+}
+
+// checkCharges looks for charges created in the period for trips that do not
+// exist or did not complete. Charges fully refunded, e.g. for a cancelled
+// trip, are not orphans.
+func (s *ReconciliationService) checkCharges(ctx context.Context, report *types.ReconciliationReport) error {
+	charges, err := s.charges.ListCharges(ctx, report.PeriodStart, report.PeriodEnd)
+	if err != nil {
+		return fmt.Errorf("failed to list charges: %w", err)
+	}
+	report.PaymentsChecked = len(charges)
+
+	// Wallet top-ups and other charges without a trip are not reconciled here
+	byTrip := make(map[string][]*types.ReconciliationPayment)
+	var tripIDs []string
+	for _, charge := range settledCharges(charges) {
+		if charge.TripID == "" || currency.ToMinor(charge.Amount-charge.RefundedAmount, charge.Currency) <= 0 {
+			continue
+		}
+		if _, seen := byTrip[charge.TripID]; !seen {
+			tripIDs = append(tripIDs, charge.TripID)
+		}
+		byTrip[charge.TripID] = append(byTrip[charge.TripID], charge)
+	}
+
+	for _, tripID := range tripIDs {
+		mismatch := &types.ReconciliationMismatch{
+			Type:     types.MismatchOrphanPayment,
+			TripID:   tripID,
+			Currency: strings.ToUpper(byTrip[tripID][0].Currency),
+		}
+		for _, charge := range byTrip[tripID] {
+			mismatch.PaymentIDs = append(mismatch.PaymentIDs, charge.PaymentID)
+			mismatch.ChargedCents += currency.ToMinor(charge.Amount-charge.RefundedAmount, charge.Currency)
+		}
+
+		trip, err := s.trips.GetByID(ctx, tripID)
+		switch {
+		case errors.Is(err, types.ErrTripNotFound):
+			mismatch.Details = "charged for a trip that does not exist"
+		case err != nil:
+			return fmt.Errorf("failed to get trip %s: %w", tripID, err)
+		case trip.Status == models.TripStatusCompleted:
+			continue
+		default:
+			mismatch.TripStatus = string(trip.Status)
+			mismatch.Details = fmt.Sprintf("charged for a trip that is %s", trip.Status)
+		}
+		addMismatch(report, mismatch)
+	}
+	return nil
+}
+
+// raiseAlerts reports the run's mismatch counts to the alert evaluator
+func (s *ReconciliationService) raiseAlerts(ctx context.Context, report *types.ReconciliationReport) {
+	if s.alerts == nil {
+		return
+	}
+
+	failed := 0
+	if report.Error != "" {
+		failed = 1
+	}
+	labels := map[string]string{"report_id": report.ID}
+	metrics := []*alerting.MetricValue{
+		{Name: MetricReconciliationMissingPayments, Value: report.MissingPayments, Timestamp: report.FinishedAt, Labels: labels},
+		{Name: MetricReconciliationAmountDrifts, Value: report.AmountDrifts, Timestamp: report.FinishedAt, Labels: labels},
+		{Name: MetricReconciliationOrphanPayments, Value: report.OrphanPayments, Timestamp: report.FinishedAt, Labels: labels},
+		{Name: MetricReconciliationRunFailed, Value: failed, Timestamp: report.FinishedAt, Labels: labels},
+	}
+	if err := s.alerts.EvaluateMetrics(ctx, metrics); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithField("report_id", report.ID).Warn("Failed to raise reconciliation alerts")
+	}
+}
+
+// settledCharges keeps the charges that took money from the rider. Charges
+// under chargeback still count until the chargeback is lost, when they are
+// refunded.
+func settledCharges(charges []*types.ReconciliationPayment) []*types.ReconciliationPayment {
+	var settled []*types.ReconciliationPayment
+	for _, charge := range charges {
+		if charge.Status == "completed" || charge.Status == "refunded" || charge.Status == "chargeback" {
+			settled = append(settled, charge)
+		}
+	}
+	return settled
+}
+
+func formatCents(minor int64, code string) string {
+	return currency.FormatAmount(currency.FromMinor(minor, code), code)
+}
+
+func addMismatch(report *types.ReconciliationReport, mismatch *types.ReconciliationMismatch) {
+	switch mismatch.Type {
+	case types.MismatchMissingPayment:
+		report.MissingPayments++
+	case types.MismatchAmountDrift:
+		report.AmountDrifts++
+	case types.MismatchOrphanPayment:
+		report.OrphanPayments++
+	}
+	report.Mismatches = append(report.Mismatches, mismatch)
+}
+
+// nextReconciliationRun returns the first run time after now, runAt past a
+// UTC midnight
+func nextReconciliationRun(now time.Time, runAt time.Duration) time.Time {
+	next := now.UTC().Truncate(24 * time.Hour).Add(runAt)
+	if !next.After(now) {
+		next = next.Add(24 * time.Hour)
+	}
+	return next
+}
+
+func generateReconciliationReportID() string {
+	return fmt.Sprintf("reconciliation_%d", time.Now().UnixNano())
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rideshare-platform/services/trip-service/internal/repository"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/alerting"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeChargeSource serves a fixed list of charges
+type fakeChargeSource struct {
+	charges []*types.ReconciliationPayment
+}
+
+func (f *fakeChargeSource) GetTripCharges(ctx context.Context, tripID string) ([]*types.ReconciliationPayment, error) {
+	var charges []*types.ReconciliationPayment
+	for _, charge := range f.charges {
+		if charge.TripID == tripID {
+			charges = append(charges, charge)
+		}
+	}
+	return charges, nil
+}
+
+func (f *fakeChargeSource) ListCharges(ctx context.Context, from, to time.Time) ([]*types.ReconciliationPayment, error) {
+	var charges []*types.ReconciliationPayment
+	for _, charge := range f.charges {
+		if !charge.CreatedAt.Before(from) && charge.CreatedAt.Before(to) {
+			charges = append(charges, charge)
+		}
+	}
+	return charges, nil
+}
+
+// recordingAlertEvaluator keeps the metrics it was asked to evaluate
+type recordingAlertEvaluator struct {
+	metrics map[string]interface{}
+}
+
+func (r *recordingAlertEvaluator) EvaluateMetrics(ctx context.Context, metrics []*alerting.MetricValue) error {
+	r.metrics = make(map[string]interface{})
+	for _, metric := range metrics {
+		r.metrics[metric.Name] = metric.Value
+	}
+	return nil
+}
+
+func saveReconciliationTrip(t *testing.T, store *repository.MemoryTripStore, id string, status models.TripStatus, completedAt time.Time, fareCents int64) {
+	trip := models.NewTrip("rider-1", models.Location{}, models.Location{}, 1)
+	trip.ID = id
+	trip.Status = status
+	trip.Currency = "USD"
+	if status == models.TripStatusCompleted {
+		trip.CompletedAt = &completedAt
+		trip.ActualFareCents = &fareCents
+	}
+	assert.NoError(t, store.Create(context.Background(), trip))
+}
+
+func TestReconciliationService_FlagsMismatches(t *testing.T) {
+	ctx := context.Background()
+	day := time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)
+	at := func(hour int) time.Time { return day.Add(time.Duration(hour) * time.Hour) }
+
+	trips := repository.NewMemoryTripStore()
+	saveReconciliationTrip(t, trips, "trip-ok", models.TripStatusCompleted, at(9), 2500)
+	saveReconciliationTrip(t, trips, "trip-refunded", models.TripStatusCompleted, at(10), 1800)
+	saveReconciliationTrip(t, trips, "trip-unpaid", models.TripStatusCompleted, at(11), 1200)
+	saveReconciliationTrip(t, trips, "trip-drift", models.TripStatusCompleted, at(12), 2000)
+	saveReconciliationTrip(t, trips, "trip-euros", models.TripStatusCompleted, at(13), 900)
+	saveReconciliationTrip(t, trips, "trip-yesterday", models.TripStatusCompleted, at(-2), 1500)
+	saveReconciliationTrip(t, trips, "trip-cancelled", models.TripStatusCancelled, time.Time{}, 0)
+	saveReconciliationTrip(t, trips, "trip-in-progress", models.TripStatusInProgress, time.Time{}, 0)
+
+	charges := &fakeChargeSource{charges: []*types.ReconciliationPayment{
+		{PaymentID: "pay-ok", TripID: "trip-ok", Status: "completed", Amount: 25.005, Currency: "USD", CreatedAt: at(9)},
+		{PaymentID: "pay-refunded", TripID: "trip-refunded", Status: "completed", Amount: 30, RefundedAmount: 12, Currency: "USD", CreatedAt: at(10)},
+		{PaymentID: "pay-unpaid", TripID: "trip-unpaid", Status: "failed", Amount: 12, Currency: "USD", CreatedAt: at(11)},
+		{PaymentID: "pay-drift", TripID: "trip-drift", Status: "completed", Amount: 22, Currency: "USD", CreatedAt: at(12)},
+		{PaymentID: "pay-euros", TripID: "trip-euros", Status: "completed", Amount: 9, Currency: "EUR", CreatedAt: at(13)},
+		// Charged just after midnight for a trip completed the day before
+		{PaymentID: "pay-yesterday", TripID: "trip-yesterday", Status: "completed", Amount: 15, Currency: "USD", CreatedAt: at(0)},
+		{PaymentID: "pay-cancelled", TripID: "trip-cancelled", Status: "completed", Amount: 8, RefundedAmount: 8, Currency: "USD", CreatedAt: at(14)},
+		{PaymentID: "pay-in-progress", TripID: "trip-in-progress", Status: "completed", Amount: 8, Currency: "USD", CreatedAt: at(15)},
+		{PaymentID: "pay-ghost", TripID: "trip-ghost", Status: "completed", Amount: 5, Currency: "USD", CreatedAt: at(16)},
+		{PaymentID: "pay-top-up", Status: "completed", Amount: 50, Currency: "USD", CreatedAt: at(17)},
+	}}
+
+	alerts := &recordingAlertEvaluator{}
+	reconciliation := NewReconciliationService(trips, charges, repository.NewMemoryReconciliationStore(), DefaultReconciliationConfig(), logger.NewLogger("error", "test"))
+	reconciliation.SetAlertEvaluator(alerts)
+
+	report, err := reconciliation.ReconcileDay(ctx, at(20))
+	assert.NoError(t, err)
+	assert.Empty(t, report.Error)
+	assert.Equal(t, day, report.PeriodStart)
+	assert.Equal(t, 5, report.TripsChecked)
+	assert.Equal(t, 10, report.PaymentsChecked)
+	assert.Equal(t, 1, report.MissingPayments)
+	assert.Equal(t, 2, report.AmountDrifts)
+	assert.Equal(t, 2, report.OrphanPayments)
+
+	byTrip := make(map[string]*types.ReconciliationMismatch)
+	for _, mismatch := range report.Mismatches {
+		byTrip[mismatch.TripID] = mismatch
+	}
+	assert.Len(t, byTrip, 5)
+	assert.Equal(t, types.MismatchMissingPayment, byTrip["trip-unpaid"].Type)
+	assert.Equal(t, int64(1200), byTrip["trip-unpaid"].ExpectedCents)
+	assert.Equal(t, types.MismatchAmountDrift, byTrip["trip-drift"].Type)
+	assert.Equal(t, int64(2200), byTrip["trip-drift"].ChargedCents)
+	assert.Equal(t, "charged $22.00, fare is $20.00", byTrip["trip-drift"].Details)
+	assert.Equal(t, "EUR", byTrip["trip-euros"].ChargeCurrency)
+	assert.Equal(t, types.MismatchOrphanPayment, byTrip["trip-in-progress"].Type)
+	assert.Equal(t, string(models.TripStatusInProgress), byTrip["trip-in-progress"].TripStatus)
+	assert.Equal(t, []string{"pay-ghost"}, byTrip["trip-ghost"].PaymentIDs)
+
+	assert.Equal(t, 1, alerts.metrics[MetricReconciliationMissingPayments])
+	assert.Equal(t, 2, alerts.metrics[MetricReconciliationAmountDrifts])
+	assert.Equal(t, 2, alerts.metrics[MetricReconciliationOrphanPayments])
+	assert.Equal(t, 0, alerts.metrics[MetricReconciliationRunFailed])
+
+	saved, err := reconciliation.ListReports(ctx, 10)
+	assert.NoError(t, err)
+	assert.Len(t, saved, 1)
+	assert.Equal(t, report.ID, saved[0].ID)
+	assert.Len(t, saved[0].Mismatches, 5)
+}
+
+func TestNextReconciliationRun(t *testing.T) {
+	runAt := 2 * time.Hour
+	assert.Equal(t, time.Date(2026, 3, 14, 2, 0, 0, 0, time.UTC), nextReconciliationRun(time.Date(2026, 3, 14, 1, 30, 0, 0, time.UTC), runAt))
+	assert.Equal(t, time.Date(2026, 3, 15, 2, 0, 0, 0, time.UTC), nextReconciliationRun(time.Date(2026, 3, 14, 2, 0, 0, 0, time.UTC), runAt))
+	// 23:00 EST is already 04:00 UTC the next day
+	assert.Equal(t, time.Date(2026, 3, 16, 2, 0, 0, 0, time.UTC), nextReconciliationRun(time.Date(2026, 3, 14, 23, 0, 0, 0, time.FixedZone("EST", -5*3600)), runAt))
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rideshare-platform/shared/events"
+)
+
+// TripEventReporter reports trip events to payment-service's refund policy
+type TripEventReporter interface {
+	ReportTripEvent(ctx context.Context, event *events.Event) error
+}
+
+// RefundTriggerService reports driver no-shows and cancellations to
+// payment-service, whose refund policy decides which of them are refunded.
+// Trip events are only accepted from trip-service, so this is the one way
+// they reach the policy.
+type RefundTriggerService struct {
+	reporter TripEventReporter
+}
+
+// NewRefundTriggerService creates a new refund trigger service
+func NewRefundTriggerService(reporter TripEventReporter) *RefundTriggerService {
+	return &RefundTriggerService{reporter: reporter}
+}
+
+// SubscribeEvents reports no-shows and cancellations. Failed reports are
+// returned, so the consumer retries them; the policy refunds a trip once
+// however often it is reported.
+func (s *RefundTriggerService) SubscribeEvents(publisher events.Subscriber) error {
+	for _, eventType := range []events.EventType{events.TripDriverNoShowEvent, events.TripCancelledEvent} {
+		if err := publisher.Subscribe(eventType, s.report); err != nil {
+			return fmt.Errorf("failed to subscribe to %s events: %w", eventType, err)
+		}
+	}
+	return nil
+}
+
+func (s *RefundTriggerService) report(ctx context.Context, event *events.Event) error {
+	if err := s.reporter.ReportTripEvent(ctx, event); err != nil {
+		return fmt.Errorf("failed to report %s event %s: %w", event.Type, event.ID, err)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rideshare-platform/shared/events"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// tripEventReports records the trip events reported to payment-service
+type tripEventReports struct {
+	mutex  sync.Mutex
+	events []*events.Event
+}
+
+func (r *tripEventReports) ReportTripEvent(ctx context.Context, event *events.Event) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *tripEventReports) reported() []events.EventType {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	var reported []events.EventType
+	for _, event := range r.events {
+		reported = append(reported, event.Type)
+	}
+	return reported
+}
+
+func TestRefundTriggerService_ReportsNoShowsAndCancellations(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("test", "info")
+	reports := &tripEventReports{}
+	publisher := events.NewEventPublisher(events.NewInMemoryEventBus(log), events.NewInMemoryEventStore(log), log)
+	require.NoError(t, NewRefundTriggerService(reports).SubscribeEvents(publisher))
+
+	for _, eventType := range []events.EventType{events.TripCompletedEvent, events.TripDriverNoShowEvent, events.TripCancelledEvent} {
+		event := events.NewEvent(eventType, "trip-1", 1, map[string]interface{}{"trip_id": "trip-1", "cancelled_by": "platform"}, "trip-service")
+		require.NoError(t, publisher.PublishEvent(ctx, event))
+	}
+
+	assert.Eventually(t, func() bool { return len(reports.reported()) == 2 }, time.Second, 10*time.Millisecond)
+	assert.ElementsMatch(t, []events.EventType{events.TripDriverNoShowEvent, events.TripCancelledEvent}, reports.reported())
+}
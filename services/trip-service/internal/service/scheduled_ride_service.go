@@ -0,0 +1,322 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+)
+
+var (
+	// ErrScheduledTimeTooSoon is returned when a ride is booked closer to pickup than the minimum notice
+	ErrScheduledTimeTooSoon = errors.New("scheduled time is too soon")
+	// ErrScheduledTimeTooFar is returned when a ride is booked further ahead than allowed
+	ErrScheduledTimeTooFar = errors.New("scheduled time is too far in the future")
+	// ErrScheduledRideNotOwned is returned when a rider acts on another rider's scheduled ride
+	ErrScheduledRideNotOwned = errors.New("scheduled ride belongs to another rider")
+	// ErrScheduledRideNotCancellable is returned when cancelling a ride that has already been dispatched or closed
+	ErrScheduledRideNotCancellable = errors.New("scheduled ride can no longer be cancelled")
+)
+
+// MatchingDispatcher hands a scheduled ride to matching-service
+type MatchingDispatcher interface {
+	DispatchScheduledRide(ctx context.Context, ride *types.ScheduledRide) (*DispatchResult, error)
+}
+
+// DispatchResult is matching-service's answer to a pre-dispatched ride
+type DispatchResult struct {
+	DriverID string `json:"driver_id,omitempty"`
+	Queued   bool   `json:"queued"`
+	Message  string `json:"message,omitempty"`
+}
+
+// ScheduledRideConfig controls when scheduled rides may be booked and dispatched
+type ScheduledRideConfig struct {
+	LeadTime   time.Duration // how long before pickup matching starts
+	MinNotice  time.Duration // earliest a ride can be booked before pickup
+	MaxAdvance time.Duration // latest a ride can be booked before pickup
+	RetryDelay time.Duration // wait between failed dispatch attempts
+}
+
+// DefaultScheduledRideConfig returns the default scheduled ride settings
+func DefaultScheduledRideConfig() ScheduledRideConfig {
+	return ScheduledRideConfig{
+		LeadTime:   15 * time.Minute,
+		MinNotice:  30 * time.Minute,
+		MaxAdvance: 30 * 24 * time.Hour,
+		RetryDelay: 30 * time.Second,
+	}
+}
+
+// ScheduledRideService books rides for later and dispatches them to matching
+// ahead of their pickup time
+type ScheduledRideService struct {
+	store      types.ScheduledRideStore
+	dispatcher MatchingDispatcher
+	config     ScheduledRideConfig
+	logger     *logger.Logger
+	mutex      sync.Mutex
+}
+
+// NewScheduledRideService creates a new scheduled ride service
+func NewScheduledRideService(store types.ScheduledRideStore, dispatcher MatchingDispatcher, config ScheduledRideConfig, logger *logger.Logger) *ScheduledRideService {
+	return &ScheduledRideService{
+		store:      store,
+		dispatcher: dispatcher,
+		config:     config,
+		logger:     logger,
+	}
+}
+
+// CreateScheduledRideRequest books a ride for a future pickup time
+type CreateScheduledRideRequest struct {
+	RiderID        string           `json:"rider_id"`
+	PickupLocation *models.Location `json:"pickup_location"`
+	Destination    *models.Location `json:"destination"`
+	VehicleType    string           `json:"vehicle_type"`
+	PaymentMethod  string           `json:"payment_method"`
+	PassengerCount int              `json:"passenger_count"`
+	ScheduledFor   time.Time        `json:"scheduled_for"`
+}
+
+// CreateScheduledRide books a ride and schedules its dispatch LeadTime before pickup
+func (s *ScheduledRideService) CreateScheduledRide(ctx context.Context, req *CreateScheduledRideRequest) (*types.ScheduledRide, error) {
+	now := time.Now()
+	if err := s.validateCreateRequest(req, now); err != nil {
+		return nil, err
+	}
+
+	passengers := req.PassengerCount
+	if passengers <= 0 {
+		passengers = 1
+	}
+
+	ride := &types.ScheduledRide{
+		ID:             generateScheduledRideID(),
+		RiderID:        req.RiderID,
+		PickupLocation: req.PickupLocation,
+		Destination:    req.Destination,
+		VehicleType:    req.VehicleType,
+		PaymentMethod:  req.PaymentMethod,
+		PassengerCount: passengers,
+		Status:         types.ScheduledRideStatusScheduled,
+		ScheduledFor:   req.ScheduledFor,
+		DispatchAt:     req.ScheduledFor.Add(-s.config.LeadTime),
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	if err := s.store.SaveScheduledRide(ctx, ride); err != nil {
+		s.logger.WithContext(ctx).WithError(err).Error("Failed to save scheduled ride")
+		return nil, fmt.Errorf("failed to save scheduled ride: %w", err)
+	}
+
+	s.logger.WithContext(ctx).WithFields(logger.Fields{
+		"scheduled_ride_id": ride.ID,
+		"rider_id":          ride.RiderID,
+		"scheduled_for":     ride.ScheduledFor,
+		"dispatch_at":       ride.DispatchAt,
+	}).Info("Ride scheduled")
+
+	return ride, nil
+}
+
+// GetScheduledRide retrieves a scheduled ride by ID
+func (s *ScheduledRideService) GetScheduledRide(ctx context.Context, rideID string) (*types.ScheduledRide, error) {
+	return s.store.GetScheduledRide(ctx, rideID)
+}
+
+// ListScheduledRides returns a rider's scheduled rides ordered by pickup time.
+// Cancelled and failed rides are only included when includeClosed is set.
+func (s *ScheduledRideService) ListScheduledRides(ctx context.Context, riderID string, includeClosed bool) ([]*types.ScheduledRide, error) {
+	if riderID == "" {
+		return nil, fmt.Errorf("rider ID is required")
+	}
+
+	rides, err := s.store.GetScheduledRidesByRider(ctx, riderID)
+	if err != nil {
+		return nil, err
+	}
+	if includeClosed {
+		return rides, nil
+	}
+
+	open := make([]*types.ScheduledRide, 0, len(rides))
+	for _, ride := range rides {
+		if ride.Status == types.ScheduledRideStatusScheduled || ride.Status == types.ScheduledRideStatusDispatched {
+			open = append(open, ride)
+		}
+	}
+	return open, nil
+}
+
+// CancelScheduledRide cancels a ride that has not been dispatched yet. Once
+// dispatched the ride is cancelled like any other trip in matching.
+func (s *ScheduledRideService) CancelScheduledRide(ctx context.Context, rideID, riderID, reason string) (*types.ScheduledRide, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	ride, err := s.store.GetScheduledRide(ctx, rideID)
+	if err != nil {
+		return nil, err
+	}
+	if riderID != "" && ride.RiderID != riderID {
+		return nil, ErrScheduledRideNotOwned
+	}
+	if ride.Status != types.ScheduledRideStatusScheduled {
+		return nil, ErrScheduledRideNotCancellable
+	}
+
+	now := time.Now()
+	ride.Status = types.ScheduledRideStatusCancelled
+	ride.CancellationReason = reason
+	ride.CancelledAt = &now
+	ride.UpdatedAt = now
+
+	if err := s.store.SaveScheduledRide(ctx, ride); err != nil {
+		return nil, fmt.Errorf("failed to cancel scheduled ride: %w", err)
+	}
+
+	s.logger.WithContext(ctx).WithFields(logger.Fields{
+		"scheduled_ride_id": ride.ID,
+		"rider_id":          ride.RiderID,
+		"reason":            reason,
+	}).Info("Scheduled ride cancelled")
+
+	return ride, nil
+}
+
+// ActivateDueRides dispatches every scheduled ride whose dispatch time has
+// been reached and returns how many were handed to matching
+func (s *ScheduledRideService) ActivateDueRides(ctx context.Context, now time.Time) (int, error) {
+	due, err := s.store.GetDueScheduledRides(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	dispatched := 0
+	for _, ride := range due {
+		if s.activateRide(ctx, ride.ID, now) {
+			dispatched++
+		}
+	}
+	return dispatched, nil
+}
+
+// StartScheduler periodically activates due rides until the context is cancelled
+func (s *ScheduledRideService) StartScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if _, err := s.ActivateDueRides(ctx, now); err != nil {
+				s.logger.WithContext(ctx).WithError(err).Warn("Scheduled ride sweep failed")
+			}
+		}
+	}
+}
+
+// activateRide dispatches one ride, rescheduling it after a failure until its
+// pickup time has passed
+func (s *ScheduledRideService) activateRide(ctx context.Context, rideID string, now time.Time) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// Re-read the ride so a cancellation since the sweep started wins
+	ride, err := s.store.GetScheduledRide(ctx, rideID)
+	if err != nil || ride.Status != types.ScheduledRideStatusScheduled || ride.DispatchAt.After(now) {
+		return false
+	}
+
+	ride.DispatchAttempts++
+	ride.UpdatedAt = now
+
+	result, err := s.dispatch(ctx, ride)
+	if err != nil {
+		ride.LastError = err.Error()
+		retryAt := now.Add(s.config.RetryDelay)
+		if retryAt.After(ride.ScheduledFor) {
+			ride.Status = types.ScheduledRideStatusFailed
+		} else {
+			ride.DispatchAt = retryAt
+		}
+		if saveErr := s.store.SaveScheduledRide(ctx, ride); saveErr != nil {
+			s.logger.WithContext(ctx).WithError(saveErr).Error("Failed to save scheduled ride")
+		}
+
+		s.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+			"scheduled_ride_id": ride.ID,
+			"attempts":          ride.DispatchAttempts,
+			"status":            ride.Status,
+		}).Warn("Failed to dispatch scheduled ride")
+		return false
+	}
+
+	ride.Status = types.ScheduledRideStatusDispatched
+	ride.DriverID = result.DriverID
+	ride.LastError = ""
+	ride.DispatchedAt = &now
+	if err := s.store.SaveScheduledRide(ctx, ride); err != nil {
+		s.logger.WithContext(ctx).WithError(err).Error("Failed to save scheduled ride")
+		return false
+	}
+
+	s.logger.WithContext(ctx).WithFields(logger.Fields{
+		"scheduled_ride_id": ride.ID,
+		"rider_id":          ride.RiderID,
+		"driver_id":         result.DriverID,
+		"queued":            result.Queued,
+	}).Info("Scheduled ride dispatched to matching")
+
+	return true
+}
+
+func (s *ScheduledRideService) dispatch(ctx context.Context, ride *types.ScheduledRide) (*DispatchResult, error) {
+	if s.dispatcher == nil {
+		return nil, fmt.Errorf("matching dispatcher not configured")
+	}
+
+	result, err := s.dispatcher.DispatchScheduledRide(ctx, ride)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		result = &DispatchResult{}
+	}
+	return result, nil
+}
+
+func (s *ScheduledRideService) validateCreateRequest(req *CreateScheduledRideRequest, now time.Time) error {
+	if req.RiderID == "" {
+		return fmt.Errorf("rider ID is required")
+	}
+	if req.PickupLocation == nil || req.Destination == nil {
+		return fmt.Errorf("pickup location and destination are required")
+	}
+	if req.VehicleType == "" {
+		return fmt.Errorf("vehicle type is required")
+	}
+	if req.ScheduledFor.IsZero() {
+		return fmt.Errorf("scheduled time is required")
+	}
+	if req.ScheduledFor.Before(now.Add(s.config.MinNotice)) {
+		return ErrScheduledTimeTooSoon
+	}
+	if s.config.MaxAdvance > 0 && req.ScheduledFor.After(now.Add(s.config.MaxAdvance)) {
+		return ErrScheduledTimeTooFar
+	}
+	return nil
+}
+
+func generateScheduledRideID() string {
+	return fmt.Sprintf("scheduled_%d", time.Now().UnixNano())
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rideshare-platform/services/trip-service/internal/repository"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeDispatcher records dispatched rides and can be told to fail
+type fakeDispatcher struct {
+	dispatched []string
+	err        error
+}
+
+func (f *fakeDispatcher) DispatchScheduledRide(ctx context.Context, ride *types.ScheduledRide) (*DispatchResult, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.dispatched = append(f.dispatched, ride.ID)
+	return &DispatchResult{DriverID: "driver-1"}, nil
+}
+
+func newScheduledTestService(dispatcher MatchingDispatcher) *ScheduledRideService {
+	return NewScheduledRideService(repository.NewMemoryScheduledRideStore(), dispatcher, DefaultScheduledRideConfig(), logger.NewLogger("test", "info"))
+}
+
+func newScheduledTestRequest(riderID string, scheduledFor time.Time) *CreateScheduledRideRequest {
+	return &CreateScheduledRideRequest{
+		RiderID:        riderID,
+		PickupLocation: &models.Location{Latitude: 37.77, Longitude: -122.41},
+		Destination:    &models.Location{Latitude: 37.80, Longitude: -122.40},
+		VehicleType:    "sedan",
+		PaymentMethod:  "card",
+		ScheduledFor:   scheduledFor,
+	}
+}
+
+func TestScheduledRideService_CreateValidatesPickupTime(t *testing.T) {
+	service := newScheduledTestService(&fakeDispatcher{})
+	ctx := context.Background()
+
+	_, err := service.CreateScheduledRide(ctx, newScheduledTestRequest("rider-1", time.Now().Add(10*time.Minute)))
+	assert.ErrorIs(t, err, ErrScheduledTimeTooSoon)
+
+	_, err = service.CreateScheduledRide(ctx, newScheduledTestRequest("rider-1", time.Now().Add(60*24*time.Hour)))
+	assert.ErrorIs(t, err, ErrScheduledTimeTooFar)
+
+	pickup := time.Now().Add(2 * time.Hour)
+	ride, err := service.CreateScheduledRide(ctx, newScheduledTestRequest("rider-1", pickup))
+	assert.NoError(t, err)
+	assert.Equal(t, types.ScheduledRideStatusScheduled, ride.Status)
+	assert.Equal(t, 1, ride.PassengerCount)
+	assert.WithinDuration(t, pickup.Add(-15*time.Minute), ride.DispatchAt, 0)
+}
+
+func TestScheduledRideService_ActivatesRidesBeforePickup(t *testing.T) {
+	dispatcher := &fakeDispatcher{}
+	service := newScheduledTestService(dispatcher)
+	ctx := context.Background()
+
+	pickup := time.Now().Add(time.Hour)
+	ride, err := service.CreateScheduledRide(ctx, newScheduledTestRequest("rider-1", pickup))
+	assert.NoError(t, err)
+
+	// Not yet within the lead time
+	dispatched, err := service.ActivateDueRides(ctx, pickup.Add(-20*time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, dispatched)
+
+	dispatched, err = service.ActivateDueRides(ctx, pickup.Add(-15*time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, dispatched)
+	assert.Equal(t, []string{ride.ID}, dispatcher.dispatched)
+
+	ride, err = service.GetScheduledRide(ctx, ride.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, types.ScheduledRideStatusDispatched, ride.Status)
+	assert.Equal(t, "driver-1", ride.DriverID)
+
+	// Dispatched rides are not sent twice or cancellable here
+	dispatched, err = service.ActivateDueRides(ctx, pickup)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, dispatched)
+
+	_, err = service.CancelScheduledRide(ctx, ride.ID, "rider-1", "changed plans")
+	assert.ErrorIs(t, err, ErrScheduledRideNotCancellable)
+}
+
+func TestScheduledRideService_RetriesFailedDispatchUntilPickup(t *testing.T) {
+	dispatcher := &fakeDispatcher{err: errors.New("matching unavailable")}
+	service := newScheduledTestService(dispatcher)
+	ctx := context.Background()
+
+	pickup := time.Now().Add(time.Hour)
+	ride, err := service.CreateScheduledRide(ctx, newScheduledTestRequest("rider-1", pickup))
+	assert.NoError(t, err)
+
+	now := pickup.Add(-15 * time.Minute)
+	dispatched, err := service.ActivateDueRides(ctx, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, dispatched)
+
+	ride, err = service.GetScheduledRide(ctx, ride.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, types.ScheduledRideStatusScheduled, ride.Status)
+	assert.Equal(t, 1, ride.DispatchAttempts)
+	assert.Equal(t, "matching unavailable", ride.LastError)
+	assert.WithinDuration(t, now.Add(30*time.Second), ride.DispatchAt, 0)
+
+	// A failure with no time left before pickup gives up
+	_, err = service.ActivateDueRides(ctx, pickup.Add(-10*time.Second))
+	assert.NoError(t, err)
+
+	ride, err = service.GetScheduledRide(ctx, ride.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, types.ScheduledRideStatusFailed, ride.Status)
+	assert.Equal(t, 2, ride.DispatchAttempts)
+}
+
+func TestScheduledRideService_CancelAndList(t *testing.T) {
+	dispatcher := &fakeDispatcher{}
+	service := newScheduledTestService(dispatcher)
+	ctx := context.Background()
+
+	later, err := service.CreateScheduledRide(ctx, newScheduledTestRequest("rider-1", time.Now().Add(3*time.Hour)))
+	assert.NoError(t, err)
+	sooner, err := service.CreateScheduledRide(ctx, newScheduledTestRequest("rider-1", time.Now().Add(time.Hour)))
+	assert.NoError(t, err)
+	_, err = service.CreateScheduledRide(ctx, newScheduledTestRequest("rider-2", time.Now().Add(time.Hour)))
+	assert.NoError(t, err)
+
+	_, err = service.CancelScheduledRide(ctx, later.ID, "rider-2", "")
+	assert.ErrorIs(t, err, ErrScheduledRideNotOwned)
+
+	cancelled, err := service.CancelScheduledRide(ctx, later.ID, "rider-1", "changed plans")
+	assert.NoError(t, err)
+	assert.Equal(t, types.ScheduledRideStatusCancelled, cancelled.Status)
+	assert.Equal(t, "changed plans", cancelled.CancellationReason)
+
+	rides, err := service.ListScheduledRides(ctx, "rider-1", false)
+	assert.NoError(t, err)
+	assert.Len(t, rides, 1)
+	assert.Equal(t, sooner.ID, rides[0].ID)
+
+	rides, err = service.ListScheduledRides(ctx, "rider-1", true)
+	assert.NoError(t, err)
+	assert.Len(t, rides, 2)
+	assert.Equal(t, later.ID, rides[1].ID)
+
+	// Cancelled rides are never dispatched
+	_, err = service.ActivateDueRides(ctx, time.Now().Add(4*time.Hour))
+	assert.NoError(t, err)
+	assert.NotContains(t, dispatcher.dispatched, later.ID)
+}
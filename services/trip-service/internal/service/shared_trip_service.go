@@ -0,0 +1,336 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+)
+
+var (
+	// ErrSharedTripClosed is returned when changing a shared trip that has finished
+	ErrSharedTripClosed = errors.New("shared trip is no longer active")
+	// ErrSharedTripVersionConflict is returned when a rider is inserted into a route that has changed since it was planned
+	ErrSharedTripVersionConflict = errors.New("shared trip has changed since the insertion was planned")
+	// ErrSharedTripCapacity is returned when an insertion would exceed the vehicle's seats
+	ErrSharedTripCapacity = errors.New("shared trip does not have enough free seats")
+	// ErrInvalidStopInsertion is returned when pickup and dropoff positions are out of range or reversed
+	ErrInvalidStopInsertion = errors.New("invalid stop insertion")
+	// ErrStopNotFound is returned when a stop is not pending on the shared trip
+	ErrStopNotFound = errors.New("stop not found")
+	// ErrStopOutOfOrder is returned when a stop is completed before the stops ahead of it
+	ErrStopOutOfOrder = errors.New("stops must be completed in route order")
+)
+
+// SharedTripService manages the multi-stop state of shared (pooled) trips
+type SharedTripService struct {
+	store  types.SharedTripStore
+	logger *logger.Logger
+	mutex  sync.Mutex
+}
+
+// NewSharedTripService creates a new shared trip service
+func NewSharedTripService(store types.SharedTripStore, logger *logger.Logger) *SharedTripService {
+	return &SharedTripService{
+		store:  store,
+		logger: logger,
+	}
+}
+
+// OpenSharedTripRequest opens a shared trip around a driver's first rider
+type OpenSharedTripRequest struct {
+	DriverID        string             `json:"driver_id"`
+	VehicleType     string             `json:"vehicle_type"`
+	SeatCapacity    int                `json:"seat_capacity"`
+	Rider           *types.SharedRider `json:"rider"`
+	CurrentLocation *models.Location   `json:"current_location,omitempty"`
+}
+
+// OpenSharedTrip starts a shared trip with the first rider's pickup and dropoff
+func (s *SharedTripService) OpenSharedTrip(ctx context.Context, req *OpenSharedTripRequest) (*types.SharedTrip, error) {
+	if req.DriverID == "" {
+		return nil, fmt.Errorf("driver ID is required")
+	}
+	if req.SeatCapacity <= 0 {
+		return nil, fmt.Errorf("seat capacity must be greater than 0")
+	}
+	if err := validateSharedRider(req.Rider); err != nil {
+		return nil, err
+	}
+	if req.Rider.PassengerCount > req.SeatCapacity {
+		return nil, ErrSharedTripCapacity
+	}
+
+	now := time.Now()
+	trip := &types.SharedTrip{
+		ID:              generateSharedTripID(),
+		DriverID:        req.DriverID,
+		VehicleType:     req.VehicleType,
+		SeatCapacity:    req.SeatCapacity,
+		Status:          types.SharedTripStatusActive,
+		Stops:           riderStops(req.Rider),
+		Riders:          []*types.SharedRider{req.Rider},
+		CurrentLocation: req.CurrentLocation,
+		Version:         1,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+
+	if err := s.store.SaveSharedTrip(ctx, trip); err != nil {
+		s.logger.WithContext(ctx).WithError(err).Error("Failed to open shared trip")
+		return nil, fmt.Errorf("failed to open shared trip: %w", err)
+	}
+
+	s.logger.WithContext(ctx).WithFields(logger.Fields{
+		"shared_trip_id": trip.ID,
+		"driver_id":      trip.DriverID,
+		"trip_id":        req.Rider.TripID,
+	}).Info("Shared trip opened")
+
+	return trip, nil
+}
+
+// AddRider inserts a rider's pickup and dropoff into the pending stops of a
+// shared trip. The indexes are positions in the pending stop list after
+// insertion, so the dropoff index must be greater than the pickup index.
+func (s *SharedTripService) AddRider(ctx context.Context, sharedTripID string, expectedVersion int, rider *types.SharedRider, pickupIndex, dropoffIndex int) (*types.SharedTrip, error) {
+	if err := validateSharedRider(rider); err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	trip, err := s.store.GetSharedTrip(ctx, sharedTripID)
+	if err != nil {
+		return nil, err
+	}
+	if trip.Status != types.SharedTripStatusActive {
+		return nil, ErrSharedTripClosed
+	}
+	if expectedVersion > 0 && expectedVersion != trip.Version {
+		return nil, ErrSharedTripVersionConflict
+	}
+	for _, existing := range trip.Riders {
+		if existing.TripID == rider.TripID {
+			return nil, fmt.Errorf("trip %s is already on shared trip %s", rider.TripID, sharedTripID)
+		}
+	}
+
+	completed, pending := splitStops(trip.Stops)
+	if pickupIndex < 0 || pickupIndex > len(pending) || dropoffIndex <= pickupIndex || dropoffIndex > len(pending)+1 {
+		return nil, ErrInvalidStopInsertion
+	}
+
+	stops := riderStops(rider)
+	pending = insertStop(pending, pickupIndex, stops[0])
+	pending = insertStop(pending, dropoffIndex, stops[1])
+
+	trip.Riders = append(trip.Riders, rider)
+	if peakOccupancy(trip, pending) > trip.SeatCapacity {
+		return nil, ErrSharedTripCapacity
+	}
+
+	trip.Stops = append(completed, pending...)
+	trip.Version++
+	trip.UpdatedAt = time.Now()
+
+	if err := s.store.SaveSharedTrip(ctx, trip); err != nil {
+		s.logger.WithContext(ctx).WithError(err).Error("Failed to add rider to shared trip")
+		return nil, fmt.Errorf("failed to add rider to shared trip: %w", err)
+	}
+
+	s.logger.WithContext(ctx).WithFields(logger.Fields{
+		"shared_trip_id": trip.ID,
+		"trip_id":        rider.TripID,
+		"rider_count":    len(trip.Riders),
+	}).Info("Rider added to shared trip")
+
+	return trip, nil
+}
+
+// CompleteStop marks the next stop on the route as visited, updating the seats
+// in use and finishing the shared trip after its last dropoff
+func (s *SharedTripService) CompleteStop(ctx context.Context, sharedTripID, stopID string) (*types.SharedTrip, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	trip, err := s.store.GetSharedTrip(ctx, sharedTripID)
+	if err != nil {
+		return nil, err
+	}
+	if trip.Status != types.SharedTripStatusActive {
+		return nil, ErrSharedTripClosed
+	}
+
+	_, pending := splitStops(trip.Stops)
+	if len(pending) == 0 {
+		return nil, ErrStopNotFound
+	}
+
+	stop := pending[0]
+	if stop.ID != stopID {
+		for _, later := range pending[1:] {
+			if later.ID == stopID {
+				return nil, ErrStopOutOfOrder
+			}
+		}
+		return nil, ErrStopNotFound
+	}
+
+	now := time.Now()
+	stop.Status = types.StopStatusCompleted
+	stop.CompletedAt = &now
+	trip.CurrentLocation = stop.Location
+
+	passengers := riderPassengers(trip, stop.TripID)
+	if stop.Type == types.StopTypePickup {
+		trip.SeatsOccupied += passengers
+	} else {
+		trip.SeatsOccupied -= passengers
+	}
+
+	if len(pending) == 1 {
+		trip.Status = types.SharedTripStatusCompleted
+		trip.SeatsOccupied = 0
+	}
+	trip.Version++
+	trip.UpdatedAt = now
+
+	if err := s.store.SaveSharedTrip(ctx, trip); err != nil {
+		s.logger.WithContext(ctx).WithError(err).Error("Failed to complete shared trip stop")
+		return nil, fmt.Errorf("failed to complete stop: %w", err)
+	}
+
+	s.logger.WithContext(ctx).WithFields(logger.Fields{
+		"shared_trip_id": trip.ID,
+		"stop_id":        stop.ID,
+		"stop_type":      stop.Type,
+		"status":         trip.Status,
+	}).Info("Shared trip stop completed")
+
+	return trip, nil
+}
+
+// GetSharedTrip retrieves a shared trip by ID
+func (s *SharedTripService) GetSharedTrip(ctx context.Context, sharedTripID string) (*types.SharedTrip, error) {
+	if sharedTripID == "" {
+		return nil, fmt.Errorf("shared trip ID is required")
+	}
+	return s.store.GetSharedTrip(ctx, sharedTripID)
+}
+
+// ListOpenSharedTrips returns active shared trips of the vehicle type with at
+// least the requested number of seats currently free
+func (s *SharedTripService) ListOpenSharedTrips(ctx context.Context, vehicleType string, seatsNeeded int) ([]*types.SharedTrip, error) {
+	trips, err := s.store.GetActiveSharedTrips(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list shared trips: %w", err)
+	}
+
+	var open []*types.SharedTrip
+	for _, trip := range trips {
+		if vehicleType != "" && trip.VehicleType != vehicleType {
+			continue
+		}
+		if trip.SeatCapacity-trip.SeatsOccupied < seatsNeeded {
+			continue
+		}
+		open = append(open, trip)
+	}
+	return open, nil
+}
+
+// validateSharedRider validates a rider joining a shared trip
+func validateSharedRider(rider *types.SharedRider) error {
+	if rider == nil || rider.TripID == "" {
+		return fmt.Errorf("rider trip ID is required")
+	}
+	if rider.PickupLocation == nil || rider.Destination == nil {
+		return fmt.Errorf("rider pickup and destination are required")
+	}
+	if rider.PassengerCount <= 0 {
+		rider.PassengerCount = 1
+	}
+	return nil
+}
+
+// riderStops returns the pending pickup and dropoff for a rider
+func riderStops(rider *types.SharedRider) []*types.TripStop {
+	return []*types.TripStop{
+		{
+			ID:       fmt.Sprintf("%s_pickup", rider.TripID),
+			TripID:   rider.TripID,
+			RiderID:  rider.RiderID,
+			Type:     types.StopTypePickup,
+			Location: rider.PickupLocation,
+			Status:   types.StopStatusPending,
+		},
+		{
+			ID:       fmt.Sprintf("%s_dropoff", rider.TripID),
+			TripID:   rider.TripID,
+			RiderID:  rider.RiderID,
+			Type:     types.StopTypeDropoff,
+			Location: rider.Destination,
+			Status:   types.StopStatusPending,
+		},
+	}
+}
+
+// splitStops separates visited stops from the ones still ahead
+func splitStops(stops []*types.TripStop) ([]*types.TripStop, []*types.TripStop) {
+	var completed, pending []*types.TripStop
+	for _, stop := range stops {
+		if stop.Status == types.StopStatusCompleted {
+			completed = append(completed, stop)
+		} else {
+			pending = append(pending, stop)
+		}
+	}
+	return completed, pending
+}
+
+// insertStop inserts a stop at the given position
+func insertStop(stops []*types.TripStop, index int, stop *types.TripStop) []*types.TripStop {
+	stops = append(stops, nil)
+	copy(stops[index+1:], stops[index:])
+	stops[index] = stop
+	return stops
+}
+
+// peakOccupancy returns the most seats in use at any point along the pending stops
+func peakOccupancy(trip *types.SharedTrip, pending []*types.TripStop) int {
+	occupied := trip.SeatsOccupied
+	peak := occupied
+	for _, stop := range pending {
+		if stop.Type == types.StopTypePickup {
+			occupied += riderPassengers(trip, stop.TripID)
+		} else {
+			occupied -= riderPassengers(trip, stop.TripID)
+		}
+		if occupied > peak {
+			peak = occupied
+		}
+	}
+	return peak
+}
+
+// riderPassengers returns how many seats a rider's trip takes
+func riderPassengers(trip *types.SharedTrip, tripID string) int {
+	for _, rider := range trip.Riders {
+		if rider.TripID == tripID {
+			return rider.PassengerCount
+		}
+	}
+	return 1
+}
+
+// generateSharedTripID generates a unique shared trip ID
+func generateSharedTripID() string {
+	return fmt.Sprintf("shared_%d", time.Now().UnixNano())
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rideshare-platform/services/trip-service/internal/repository"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func newSharedTestRider(tripID string, passengers int) *types.SharedRider {
+	return &types.SharedRider{
+		TripID:         tripID,
+		RiderID:        "rider-" + tripID,
+		PickupLocation: &models.Location{Latitude: 37.77, Longitude: -122.41},
+		Destination:    &models.Location{Latitude: 37.80, Longitude: -122.40},
+		PassengerCount: passengers,
+	}
+}
+
+func newSharedTestService() *SharedTripService {
+	return NewSharedTripService(repository.NewMemorySharedTripStore(), logger.NewLogger("test", "info"))
+}
+
+func stopIDs(trip *types.SharedTrip) []string {
+	ids := make([]string, 0, len(trip.Stops))
+	for _, stop := range trip.Stops {
+		ids = append(ids, stop.ID)
+	}
+	return ids
+}
+
+func TestSharedTripService_AddRiderInsertsStops(t *testing.T) {
+	service := newSharedTestService()
+	ctx := context.Background()
+
+	trip, err := service.OpenSharedTrip(ctx, &OpenSharedTripRequest{
+		DriverID:     "driver-1",
+		VehicleType:  "sedan",
+		SeatCapacity: 4,
+		Rider:        newSharedTestRider("trip-a", 1),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, trip.Version)
+	assert.Equal(t, []string{"trip-a_pickup", "trip-a_dropoff"}, stopIDs(trip))
+
+	trip, err = service.AddRider(ctx, trip.ID, 1, newSharedTestRider("trip-b", 2), 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, trip.Version)
+	assert.Len(t, trip.Riders, 2)
+	assert.Equal(t, []string{"trip-a_pickup", "trip-b_pickup", "trip-b_dropoff", "trip-a_dropoff"}, stopIDs(trip))
+
+	_, err = service.AddRider(ctx, trip.ID, 1, newSharedTestRider("trip-c", 1), 0, 1)
+	assert.ErrorIs(t, err, ErrSharedTripVersionConflict)
+
+	_, err = service.AddRider(ctx, trip.ID, 2, newSharedTestRider("trip-c", 1), 2, 2)
+	assert.ErrorIs(t, err, ErrInvalidStopInsertion)
+}
+
+func TestSharedTripService_AddRiderRespectsCapacity(t *testing.T) {
+	service := newSharedTestService()
+	ctx := context.Background()
+
+	trip, err := service.OpenSharedTrip(ctx, &OpenSharedTripRequest{
+		DriverID:     "driver-1",
+		SeatCapacity: 3,
+		Rider:        newSharedTestRider("trip-a", 2),
+	})
+	assert.NoError(t, err)
+
+	// Riding at the same time would need four seats
+	_, err = service.AddRider(ctx, trip.ID, 0, newSharedTestRider("trip-b", 2), 1, 2)
+	assert.ErrorIs(t, err, ErrSharedTripCapacity)
+
+	// Picking up after the first dropoff fits
+	trip, err = service.AddRider(ctx, trip.ID, 0, newSharedTestRider("trip-b", 2), 2, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"trip-a_pickup", "trip-a_dropoff", "trip-b_pickup", "trip-b_dropoff"}, stopIDs(trip))
+}
+
+func TestSharedTripService_CompleteStopsInOrder(t *testing.T) {
+	service := newSharedTestService()
+	ctx := context.Background()
+
+	trip, err := service.OpenSharedTrip(ctx, &OpenSharedTripRequest{
+		DriverID:     "driver-1",
+		VehicleType:  "sedan",
+		SeatCapacity: 4,
+		Rider:        newSharedTestRider("trip-a", 1),
+	})
+	assert.NoError(t, err)
+
+	_, err = service.CompleteStop(ctx, trip.ID, "trip-a_dropoff")
+	assert.ErrorIs(t, err, ErrStopOutOfOrder)
+
+	trip, err = service.CompleteStop(ctx, trip.ID, "trip-a_pickup")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, trip.SeatsOccupied)
+	assert.Equal(t, types.StopStatusCompleted, trip.Stops[0].Status)
+
+	// Stops inserted after a pickup only go into the pending part of the route
+	trip, err = service.AddRider(ctx, trip.ID, trip.Version, newSharedTestRider("trip-b", 1), 0, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"trip-a_pickup", "trip-b_pickup", "trip-a_dropoff", "trip-b_dropoff"}, stopIDs(trip))
+
+	open, err := service.ListOpenSharedTrips(ctx, "sedan", 3)
+	assert.NoError(t, err)
+	assert.Len(t, open, 1)
+
+	for _, stopID := range []string{"trip-b_pickup", "trip-a_dropoff", "trip-b_dropoff"} {
+		trip, err = service.CompleteStop(ctx, trip.ID, stopID)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, types.SharedTripStatusCompleted, trip.Status)
+	assert.Equal(t, 0, trip.SeatsOccupied)
+
+	open, err = service.ListOpenSharedTrips(ctx, "sedan", 1)
+	assert.NoError(t, err)
+	assert.Empty(t, open)
+
+	_, err = service.AddRider(ctx, trip.ID, 0, newSharedTestRider("trip-c", 1), 0, 1)
+	assert.ErrorIs(t, err, ErrSharedTripClosed)
+}
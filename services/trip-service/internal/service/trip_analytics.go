@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/analytics"
+	"github.com/rideshare-platform/shared/currency"
+	"github.com/rideshare-platform/shared/models"
+	"github.com/rideshare-platform/shared/monitoring"
+	"github.com/rideshare-platform/shared/pagination"
+)
+
+// AnalyticsSource is the name trip and rating rollups are recorded under
+const AnalyticsSource = "trips"
+
+// Trip rollup counters. Revenue is counted per currency, named with the
+// currency after the prefix.
+const (
+	counterTripsRequested   = "trips_requested"
+	counterTripsCompleted   = "trips_completed"
+	counterTripsCancelled   = "trips_cancelled"
+	counterTripSeconds      = "trip_duration_seconds"
+	counterTimedTrips       = "timed_trips"
+	counterRevenuePrefix    = "revenue."
+	counterRatings          = "ratings"
+	counterRatingSum        = "rating_sum"
+	counterRiderRatings     = "rider_ratings"
+	counterSatisfiedRatings = "satisfied_ratings"
+	counterDisputesOpened   = "disputes_opened"
+	counterDisputesResolved = "disputes_resolved"
+	counterDisputesRejected = "disputes_rejected"
+	counterDisputesRefunded = "disputes_refunded"
+	counterDisputeSeconds   = "dispute_resolution_seconds"
+	counterRefundPrefix     = "dispute_refunds."
+)
+
+// satisfiedScore is the lowest rider score counted as a satisfied customer
+const satisfiedScore = 4
+
+// SetAnalytics sets the recorder trip requests, completions and
+// cancellations are counted with
+func (s *TripService) SetAnalytics(recorder *analytics.Recorder) {
+	s.analytics = recorder
+}
+
+// recordTrip counts a trip lifecycle event into the hour it happened in
+func (s *TripService) recordTrip(trip *models.Trip, at time.Time) {
+	if s.analytics == nil {
+		return
+	}
+
+	counters := make(analytics.Counters)
+	switch trip.Status {
+	case models.TripStatusRequested:
+		counters.Add(counterTripsRequested, 1)
+	case models.TripStatusCompleted:
+		counters.Add(counterTripsCompleted, 1)
+		if duration := trip.ActualDurationSeconds; duration != nil {
+			counters.Add(counterTimedTrips, 1)
+			counters.Add(counterTripSeconds, float64(*duration))
+		}
+		if trip.FareBreakdown != nil {
+			total := trip.FareBreakdown.Total
+			counters.Add(counterRevenuePrefix+total.Currency, total.ToFloat64())
+		}
+	case models.TripStatusCancelled:
+		counters.Add(counterTripsCancelled, 1)
+	}
+	s.analytics.Record(at, counters)
+}
+
+// SetAnalytics sets the recorder submitted ratings are counted with
+func (s *RatingService) SetAnalytics(recorder *analytics.Recorder) {
+	s.analytics = recorder
+}
+
+// recordRating counts a submitted rating. Riders' ratings of their driver
+// are also counted towards customer satisfaction.
+func (s *RatingService) recordRating(rating *types.Rating) {
+	if s.analytics == nil {
+		return
+	}
+
+	counters := analytics.Counters{
+		counterRatings:   1,
+		counterRatingSum: float64(rating.Score),
+	}
+	if rating.RaterRole == types.RaterRoleRider {
+		counters.Add(counterRiderRatings, 1)
+		if rating.Score >= satisfiedScore {
+			counters.Add(counterSatisfiedRatings, 1)
+		}
+	}
+	s.analytics.Record(rating.CreatedAt, counters)
+}
+
+// recordOpened counts an opened dispute, by reason as well
+func (s *DisputeService) recordOpened(dispute *types.Dispute) {
+	if s.analytics == nil {
+		return
+	}
+	s.analytics.Record(dispute.CreatedAt, analytics.Counters{
+		counterDisputesOpened:                                1,
+		counterDisputesOpened + "." + string(dispute.Reason): 1,
+	})
+}
+
+// recordClosed counts a closed dispute into the hour it was closed in, with
+// how long it took and what was refunded
+func (s *DisputeService) recordClosed(dispute *types.Dispute) {
+	if s.analytics == nil || dispute.ResolvedAt == nil {
+		return
+	}
+
+	counters := analytics.Counters{
+		counterDisputeSeconds: dispute.ResolvedAt.Sub(dispute.CreatedAt).Seconds(),
+	}
+	if dispute.Status == types.DisputeStatusRejected {
+		counters.Add(counterDisputesRejected, 1)
+	} else {
+		counters.Add(counterDisputesResolved, 1)
+	}
+	if dispute.RefundAmount > 0 {
+		counters.Add(counterDisputesRefunded, 1)
+		counters.Add(counterRefundPrefix+dispute.Currency, dispute.RefundAmount)
+	}
+	s.analytics.Record(*dispute.ResolvedAt, counters)
+}
+
+// TripAnalytics computes business KPIs from the trip and rating rollups
+type TripAnalytics struct {
+	recorder *analytics.Recorder
+	trips    *TripService
+	currency string
+}
+
+// NewTripAnalytics creates a business metrics source. Total revenue is
+// reported in reportCurrency, other currencies only in the per-currency
+// breakdown. Driver utilization is not known to trip-service and is left 0.
+func NewTripAnalytics(recorder *analytics.Recorder, trips *TripService, reportCurrency string) *TripAnalytics {
+	if reportCurrency == "" {
+		reportCurrency = currency.Default
+	}
+	return &TripAnalytics{
+		recorder: recorder,
+		trips:    trips,
+		currency: reportCurrency,
+	}
+}
+
+// BusinessMetrics summarises the trips and ratings recorded in [from, to)
+func (a *TripAnalytics) BusinessMetrics(ctx context.Context, from, to time.Time) (*monitoring.BusinessMetrics, error) {
+	if a.recorder == nil {
+		return nil, errors.New("trip analytics are not configured")
+	}
+	summary, err := a.recorder.Summarize(ctx, analytics.TimeRange{From: from, To: to})
+	if err != nil {
+		return nil, err
+	}
+
+	totals := summary.Totals
+	metrics := &monitoring.BusinessMetrics{
+		TotalTrips:                       int64(totals[counterTripsRequested]),
+		CompletedTrips:                   int64(totals[counterTripsCompleted]),
+		CancelledTrips:                   int64(totals[counterTripsCancelled]),
+		Currency:                         a.currency,
+		RevenueByCurrency:                make(map[string]float64),
+		DisputeRefundsByCurrency:         make(map[string]float64),
+		AverageRating:                    totals.Ratio(counterRatingSum, counterRatings),
+		AverageTripDuration:              totals.Ratio(counterTripSeconds, counterTimedTrips) / 60,
+		CustomerSatisfaction:             totals.Ratio(counterSatisfiedRatings, counterRiderRatings),
+		DisputesOpened:                   int64(totals[counterDisputesOpened]),
+		DisputesClosed:                   int64(totals[counterDisputesResolved] + totals[counterDisputesRejected]),
+		PickupGuaranteesJudged:           int64(totals[counterPickupsJudged]),
+		PickupGuaranteesMissed:           int64(totals[counterPickupsMissed]),
+		PickupGuaranteeCreditsByCurrency: make(map[string]float64),
+	}
+	for code, revenue := range summary.WithPrefix(counterRevenuePrefix) {
+		metrics.RevenueByCurrency[code] = currency.Round(revenue, code)
+	}
+	metrics.TotalRevenue = metrics.RevenueByCurrency[a.currency]
+	for code, refunded := range summary.WithPrefix(counterRefundPrefix) {
+		metrics.DisputeRefundsByCurrency[code] = currency.Round(refunded, code)
+	}
+	for code, credited := range summary.WithPrefix(counterPickupCreditPrefix) {
+		metrics.PickupGuaranteeCreditsByCurrency[code] = currency.Round(credited, code)
+	}
+
+	if a.trips != nil {
+		active, err := a.trips.ListActiveTrips(ctx, ActiveTripFilter{Page: pagination.Request{Limit: 1}})
+		if err != nil {
+			return nil, err
+		}
+		metrics.ActiveTrips = active.TotalEstimate
+	}
+	return metrics, nil
+}
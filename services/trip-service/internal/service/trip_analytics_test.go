@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rideshare-platform/services/trip-service/internal/repository"
+	"github.com/rideshare-platform/shared/analytics"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTripAnalytics_BusinessMetrics(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("test", "info")
+	recorder := analytics.NewRecorder(analytics.NewMemoryStore(), AnalyticsSource, log)
+
+	trips := NewTripService(repository.NewMemoryTripStore(), log)
+	trips.SetAnalytics(recorder)
+	ratings := newRatingTestService(DefaultRatingConfig())
+	ratings.SetAnalytics(recorder)
+
+	newRequest := func(quoted string) *CreateTripRequest {
+		return &CreateTripRequest{
+			RiderID:             "rider-1",
+			PickupLocation:      models.Location{Latitude: 41.0082, Longitude: 28.9784},
+			DestinationLocation: models.Location{Latitude: 41.0422, Longitude: 29.0083},
+			RideType:            "standard",
+			EstimatedFare:       20,
+			Currency:            quoted,
+		}
+	}
+
+	completed := make([]*models.Trip, 0, 2)
+	for _, quoted := range []string{"USD", "EUR"} {
+		trip, err := trips.CreateTrip(ctx, newRequest(quoted))
+		require.NoError(t, err)
+		_, err = trips.AcceptTrip(ctx, trip.ID, "driver-1")
+		require.NoError(t, err)
+		_, err = trips.StartTrip(ctx, trip.ID)
+		require.NoError(t, err)
+		trip, err = trips.CompleteTrip(ctx, trip.ID, 12.5)
+		require.NoError(t, err)
+		completed = append(completed, trip)
+	}
+
+	cancelled, err := trips.CreateTrip(ctx, newRequest("USD"))
+	require.NoError(t, err)
+	_, err = trips.CancelTrip(ctx, cancelled.ID, "changed plans")
+	require.NoError(t, err)
+
+	_, err = trips.CreateTrip(ctx, newRequest("USD"))
+	require.NoError(t, err)
+
+	for i, trip := range completed {
+		require.NoError(t, ratings.RecordCompletedTrip(ctx, trip.ID, "rider-1", "driver-1", time.Now()))
+		_, _, err := ratings.SubmitRating(ctx, &SubmitRatingRequest{TripID: trip.ID, RaterID: "rider-1", Score: 5 - 2*i})
+		require.NoError(t, err)
+		_, _, err = ratings.SubmitRating(ctx, &SubmitRatingRequest{TripID: trip.ID, RaterID: "driver-1", Score: 4})
+		require.NoError(t, err)
+	}
+
+	source := NewTripAnalytics(recorder, trips, "USD")
+	now := time.Now()
+	metrics, err := source.BusinessMetrics(ctx, now.Add(-time.Hour), now.Add(time.Minute))
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(4), metrics.TotalTrips)
+	assert.Equal(t, int64(2), metrics.CompletedTrips)
+	assert.Equal(t, int64(1), metrics.CancelledTrips)
+	assert.Equal(t, int64(1), metrics.ActiveTrips)
+	assert.Equal(t, "USD", metrics.Currency)
+	assert.Equal(t, 12.5, metrics.TotalRevenue, "revenue in other currencies is not added in")
+	assert.Equal(t, map[string]float64{"USD": 12.5, "EUR": 12.5}, metrics.RevenueByCurrency)
+	assert.Equal(t, 4.0, metrics.AverageRating)
+	assert.Equal(t, 0.5, metrics.CustomerSatisfaction, "only the rider's 5 star rating is satisfied")
+
+	// Nothing was recorded in an earlier range
+	metrics, err = source.BusinessMetrics(ctx, now.Add(-72*time.Hour), now.Add(-48*time.Hour))
+	require.NoError(t, err)
+	assert.Zero(t, metrics.TotalTrips)
+	assert.Zero(t, metrics.TotalRevenue)
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/rideshare-platform/shared/archive"
+	"github.com/rideshare-platform/shared/models"
+)
+
+// TripArchiveDataset is the name trips are archived under
+const TripArchiveDataset = "trips"
+
+// TripArchiveStore is the hot trip store as the archiver sees it
+type TripArchiveStore interface {
+	// EndedBefore returns up to limit trips that ended and were last
+	// updated before cutoff, with IDs after afterID, in ID order
+	EndedBefore(ctx context.Context, cutoff time.Time, afterID string, limit int) ([]*models.Trip, error)
+	// Delete removes archived trips
+	Delete(ctx context.Context, ids []string) error
+	// Restore saves a rehydrated trip unless it is already in the store
+	Restore(ctx context.Context, trip *models.Trip) error
+}
+
+// NewTripArchive creates the archive dataset of trips. Completed, cancelled
+// and failed trips are archived once their last update is older than the
+// retention period. Their summary keeps the fares, distances and timings
+// analytics report on, with the pickup rounded as for erased trips.
+func NewTripArchive(store TripArchiveStore) archive.Dataset {
+	expired := func(ctx context.Context, cutoff time.Time, afterID string, limit int) ([]archive.Record, error) {
+		trips, err := store.EndedBefore(ctx, cutoff, afterID, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		records := make([]archive.Record, 0, len(trips))
+		for _, trip := range trips {
+			data, err := json.Marshal(trip)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode trip %s: %w", trip.ID, err)
+			}
+			records = append(records, archive.Record{
+				ID:      trip.ID,
+				EndedAt: trip.UpdatedAt,
+				Data:    data,
+				Summary: tripSummary(trip),
+			})
+		}
+		return records, nil
+	}
+
+	restore := func(ctx context.Context, data json.RawMessage) error {
+		var trip models.Trip
+		if err := json.Unmarshal(data, &trip); err != nil {
+			return fmt.Errorf("failed to decode archived trip: %w", err)
+		}
+		return store.Restore(ctx, &trip)
+	}
+
+	return archive.NewDataset(TripArchiveDataset, expired, store.Delete, restore)
+}
+
+func tripSummary(trip *models.Trip) map[string]interface{} {
+	pickup := anonymizeLocation(trip.PickupLocation)
+	summary := map[string]interface{}{
+		"rider_id":         trip.RiderID,
+		"status":           string(trip.Status),
+		"currency":         trip.Currency,
+		"passenger_count":  trip.PassengerCount,
+		"pickup_latitude":  pickup.Latitude,
+		"pickup_longitude": pickup.Longitude,
+		"requested_at":     trip.RequestedAt,
+	}
+	if trip.DriverID != nil {
+		summary["driver_id"] = *trip.DriverID
+	}
+	if trip.CityID != "" {
+		summary["city_id"] = trip.CityID
+	}
+	if trip.ActualFareCents != nil {
+		summary["final_fare_minor"] = *trip.ActualFareCents
+	}
+	if trip.EstimatedFareCents != nil {
+		summary["estimated_fare_minor"] = *trip.EstimatedFareCents
+	}
+	if trip.ActualDistanceKm != nil {
+		summary["distance_km"] = *trip.ActualDistanceKm
+	}
+	if trip.ActualDurationSeconds != nil {
+		summary["duration_seconds"] = *trip.ActualDurationSeconds
+	}
+	if trip.SurgeMultiplier != nil {
+		summary["surge_multiplier"] = *trip.SurgeMultiplier
+	}
+	if trip.CompletedAt != nil {
+		summary["completed_at"] = *trip.CompletedAt
+	}
+	return summary
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rideshare-platform/services/trip-service/internal/repository"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/archive"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTripArchive_ArchivesEndedTripsAndRehydrates(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("test", "info")
+	store := repository.NewMemoryTripStore()
+	trips := NewTripService(store, log)
+
+	archiver := archive.NewArchiver(archive.NewMemoryStore(), time.Hour, log)
+	archiver.SetBatchSize(1)
+	archiver.Register(NewTripArchive(store))
+
+	request := &CreateTripRequest{
+		RiderID:             "rider-1",
+		PickupLocation:      models.Location{Latitude: 41.0082, Longitude: 28.9784},
+		DestinationLocation: models.Location{Latitude: 41.0422, Longitude: 29.0083},
+		RideType:            "standard",
+		EstimatedFare:       20,
+		Currency:            "EUR",
+	}
+	var created []*models.Trip
+	for i := 0; i < 4; i++ {
+		trip, err := trips.CreateTrip(ctx, request)
+		require.NoError(t, err)
+		created = append(created, trip)
+	}
+	for _, trip := range created[:3] {
+		_, err := trips.CancelTrip(ctx, trip.ID, "changed plans")
+		require.NoError(t, err)
+	}
+
+	// The first two cancelled trips and the active one are past retention
+	for _, trip := range []*models.Trip{created[0], created[1], created[3]} {
+		stored, err := store.GetByID(ctx, trip.ID)
+		require.NoError(t, err)
+		stored.UpdatedAt = time.Now().Add(-2 * time.Hour)
+		require.NoError(t, store.Update(ctx, stored))
+	}
+
+	archived, err := archiver.Run(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{TripArchiveDataset: 2}, archived)
+
+	for _, trip := range created[:2] {
+		_, err := store.GetByID(ctx, trip.ID)
+		assert.ErrorIs(t, err, types.ErrTripNotFound, "archived trips leave the hot store")
+	}
+	_, err = store.GetByID(ctx, created[2].ID)
+	assert.NoError(t, err, "recently cancelled trips are kept")
+	_, err = store.GetByID(ctx, created[3].ID)
+	assert.NoError(t, err, "active trips are never archived")
+
+	summaries, err := archiver.ListSummaries(ctx, archive.SummaryFilter{Dataset: TripArchiveDataset})
+	require.NoError(t, err)
+	require.Len(t, summaries, 2)
+	assert.Equal(t, "EUR", summaries[0].Summary["currency"])
+	assert.Equal(t, 41.01, summaries[0].Summary["pickup_latitude"], "pickup is rounded")
+	assert.Empty(t, summaries[0].Data, "summaries are listed without the full trip")
+
+	rehydrated, err := archiver.Rehydrate(ctx, TripArchiveDataset, created[0].ID)
+	require.NoError(t, err)
+	require.NotNil(t, rehydrated.RehydratedUntil)
+	restored, err := store.GetByID(ctx, created[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.TripStatusCancelled, restored.Status)
+
+	// The rehydrated trip is held in the hot store until its TTL passes
+	count, err := archiver.RunDataset(ctx, TripArchiveDataset)
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+	_, err = store.GetByID(ctx, created[0].ID)
+	assert.NoError(t, err)
+
+	_, err = archiver.Rehydrate(ctx, TripArchiveDataset, created[3].ID)
+	assert.ErrorIs(t, err, archive.ErrNotArchived)
+	_, err = archiver.Get(ctx, "payments", created[1].ID)
+	assert.ErrorIs(t, err, archive.ErrUnknownDataset)
+}
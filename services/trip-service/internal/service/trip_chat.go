@@ -0,0 +1,358 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+)
+
+var (
+	// ErrNotChatParticipant is returned when someone other than the trip's
+	// rider or driver sends or reads its messages
+	ErrNotChatParticipant = errors.New("only the trip's rider and driver can message each other")
+	// ErrChatClosed is returned when messaging a trip that has no driver yet
+	// or has ended with no contact window open
+	ErrChatClosed = errors.New("messaging is only open while a driver is on the trip")
+	// ErrUnknownQuickReply is returned when a quick reply does not exist for the sender's role
+	ErrUnknownQuickReply = errors.New("unknown quick reply")
+)
+
+// MaxTripMessageLength is the longest message text, in characters
+const MaxTripMessageLength = 500
+
+// chatSubscriptionBuffer is how many messages a subscriber can fall behind
+// by before further messages wait for them to reconnect
+const chatSubscriptionBuffer = 32
+
+// QuickReply is a canned message riders or drivers can send with one tap
+type QuickReply struct {
+	ID   string `json:"id"`
+	Role string `json:"-"`
+	Text string `json:"text"`
+}
+
+var quickReplies = []QuickReply{
+	{ID: "driver_on_my_way", Role: "driver", Text: "I'm on my way"},
+	{ID: "driver_arrived", Role: "driver", Text: "I've arrived at the pickup point"},
+	{ID: "driver_running_late", Role: "driver", Text: "Running a few minutes late, sorry"},
+	{ID: "driver_cant_find_you", Role: "driver", Text: "I can't find you, where are you waiting?"},
+	{ID: "rider_coming_out", Role: "rider", Text: "Coming out now"},
+	{ID: "rider_few_minutes", Role: "rider", Text: "I'll be there in a couple of minutes"},
+	{ID: "rider_at_pickup", Role: "rider", Text: "I'm at the pickup point"},
+	{ID: "rider_please_wait", Role: "rider", Text: "Please wait for me, I'm on my way"},
+}
+
+// QuickReplies returns the canned messages a rider or driver can send
+func QuickReplies(role string) []QuickReply {
+	var replies []QuickReply
+	for _, reply := range quickReplies {
+		if reply.Role == role {
+			replies = append(replies, reply)
+		}
+	}
+	return replies
+}
+
+// SendTripMessageRequest is a message from a trip's rider or driver to the
+// other. QuickReplyID sends that quick reply's text instead of Text.
+type SendTripMessageRequest struct {
+	TripID       string `json:"-"`
+	SenderID     string `json:"-"`
+	Text         string `json:"text,omitempty"`
+	QuickReplyID string `json:"quick_reply_id,omitempty"`
+}
+
+// ContactWindows reports whether a finished trip's rider and driver can
+// still message each other, such as while a lost item is being returned
+type ContactWindows interface {
+	ContactOpen(ctx context.Context, tripID string) (bool, error)
+}
+
+// ChatSubscription receives a trip's messages to one participant. Pending
+// holds what was sent while they were not connected.
+type ChatSubscription struct {
+	Pending  []*types.TripMessage
+	Messages <-chan *types.TripMessage
+
+	service  *ChatService
+	tripID   string
+	userID   string
+	messages chan *types.TripMessage
+}
+
+// Close stops the subscription
+func (s *ChatSubscription) Close() {
+	s.service.unsubscribe(s)
+}
+
+// ChatService carries messages between a trip's rider and driver while the
+// driver is on the trip. Messages are delivered to connected recipients
+// straight away and stored for the others until they next connect.
+type ChatService struct {
+	trips    TripRepositoryInterface
+	store    types.TripMessageStore
+	contacts ContactWindows
+	logger   *logger.Logger
+
+	// mutex orders deliveries against subscriptions so a message is
+	// neither lost nor delivered twice when its recipient connects
+	mutex       sync.Mutex
+	subscribers map[string]map[*ChatSubscription]bool
+}
+
+// NewChatService creates a new chat service
+func NewChatService(trips TripRepositoryInterface, store types.TripMessageStore, logger *logger.Logger) *ChatService {
+	return &ChatService{
+		trips:       trips,
+		store:       store,
+		logger:      logger,
+		subscribers: make(map[string]map[*ChatSubscription]bool),
+	}
+}
+
+// SetContactWindows attaches what reopens messaging after a trip has ended.
+// Without it messaging closes when the trip ends.
+func (s *ChatService) SetContactWindows(contacts ContactWindows) {
+	s.contacts = contacts
+}
+
+// SendMessage sends a message to the other participant of an active trip,
+// or of a finished trip with a contact window open
+func (s *ChatService) SendMessage(ctx context.Context, req *SendTripMessageRequest) (*types.TripMessage, error) {
+	if req.TripID == "" || req.SenderID == "" {
+		return nil, fmt.Errorf("trip ID and sender ID are required")
+	}
+
+	trip, err := s.trips.GetByID(ctx, req.TripID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trip: %w", err)
+	}
+	role, err := chatRole(trip, req.SenderID)
+	if err != nil {
+		return nil, err
+	}
+	if trip.DriverID == nil {
+		return nil, ErrChatClosed
+	}
+	if !trip.IsActive() {
+		open, err := s.contactOpen(ctx, trip.ID)
+		if err != nil {
+			return nil, err
+		}
+		if !open {
+			return nil, ErrChatClosed
+		}
+	}
+
+	text := strings.TrimSpace(req.Text)
+	if req.QuickReplyID != "" {
+		reply, ok := findQuickReply(req.QuickReplyID, role)
+		if !ok {
+			return nil, ErrUnknownQuickReply
+		}
+		text = reply.Text
+	}
+	if text == "" {
+		return nil, fmt.Errorf("message text or a quick reply is required")
+	}
+	if utf8.RuneCountInString(text) > MaxTripMessageLength {
+		return nil, fmt.Errorf("messages are limited to %d characters", MaxTripMessageLength)
+	}
+
+	message := &types.TripMessage{
+		ID:           fmt.Sprintf("msg_%d", time.Now().UnixNano()),
+		TripID:       trip.ID,
+		SenderID:     req.SenderID,
+		SenderRole:   role,
+		RecipientID:  trip.RiderID,
+		Text:         text,
+		QuickReplyID: req.QuickReplyID,
+		CreatedAt:    time.Now(),
+	}
+	if role == "rider" {
+		message.RecipientID = *trip.DriverID
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := s.store.SaveMessage(ctx, message); err != nil {
+		return nil, fmt.Errorf("failed to save trip message: %w", err)
+	}
+	s.deliver(ctx, message)
+
+	s.logger.WithContext(ctx).WithFields(logger.Fields{
+		"trip_id":     message.TripID,
+		"message_id":  message.ID,
+		"sender_role": role,
+		"delivered":   message.DeliveredAt != nil,
+	}).Debug("Trip message sent")
+
+	return message, nil
+}
+
+// ListMessages returns a trip's messages, oldest first. Participants pass
+// their user ID; support staff reviewing the conversation pass none.
+func (s *ChatService) ListMessages(ctx context.Context, tripID, userID string) ([]*types.TripMessage, error) {
+	if tripID == "" {
+		return nil, fmt.Errorf("trip ID is required")
+	}
+	if userID != "" {
+		trip, err := s.trips.GetByID(ctx, tripID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get trip: %w", err)
+		}
+		if _, err := chatRole(trip, userID); err != nil {
+			return nil, err
+		}
+	}
+	return s.store.ListTripMessages(ctx, tripID)
+}
+
+// Subscribe connects a participant to a trip's messages. Messages sent to
+// them while they were not connected are returned as pending and count as
+// delivered.
+func (s *ChatService) Subscribe(ctx context.Context, tripID, userID string) (*ChatSubscription, error) {
+	if tripID == "" || userID == "" {
+		return nil, fmt.Errorf("trip ID and user ID are required")
+	}
+	trip, err := s.trips.GetByID(ctx, tripID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trip: %w", err)
+	}
+	if _, err := chatRole(trip, userID); err != nil {
+		return nil, err
+	}
+
+	messages := make(chan *types.TripMessage, chatSubscriptionBuffer)
+	subscription := &ChatSubscription{
+		Messages: messages,
+		service:  s,
+		tripID:   tripID,
+		userID:   userID,
+		messages: messages,
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	pending, err := s.store.UndeliveredMessages(ctx, tripID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get undelivered trip messages: %w", err)
+	}
+	if len(pending) > 0 {
+		now := time.Now()
+		ids := make([]string, len(pending))
+		for i, message := range pending {
+			message.DeliveredAt = &now
+			ids[i] = message.ID
+		}
+		if err := s.store.MarkDelivered(ctx, ids, now); err != nil {
+			return nil, fmt.Errorf("failed to mark trip messages delivered: %w", err)
+		}
+	}
+	subscription.Pending = pending
+
+	if s.subscribers[tripID] == nil {
+		s.subscribers[tripID] = make(map[*ChatSubscription]bool)
+	}
+	s.subscribers[tripID][subscription] = true
+	return subscription, nil
+}
+
+// ArchiveStore wraps the trip archive store so a trip's messages are
+// deleted when the trip is archived
+func (s *ChatService) ArchiveStore(trips TripArchiveStore) TripArchiveStore {
+	return &chatArchiveStore{TripArchiveStore: trips, messages: s.store}
+}
+
+// deliver hands the message to its recipient's subscriptions, marking it
+// delivered if any took it. Called with the mutex held.
+func (s *ChatService) deliver(ctx context.Context, message *types.TripMessage) {
+	delivered := false
+	for subscription := range s.subscribers[message.TripID] {
+		if subscription.userID != message.RecipientID {
+			continue
+		}
+		select {
+		case subscription.messages <- message:
+			delivered = true
+		default:
+			// A subscriber this far behind picks the message up on reconnect
+		}
+	}
+	if !delivered {
+		return
+	}
+
+	now := time.Now()
+	if err := s.store.MarkDelivered(ctx, []string{message.ID}, now); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+			"message_id": message.ID,
+		}).Error("Failed to mark trip message delivered")
+		return
+	}
+	message.DeliveredAt = &now
+}
+
+func (s *ChatService) unsubscribe(subscription *ChatSubscription) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	subscribers := s.subscribers[subscription.tripID]
+	delete(subscribers, subscription)
+	if len(subscribers) == 0 {
+		delete(s.subscribers, subscription.tripID)
+	}
+}
+
+// contactOpen reports whether a finished trip has a contact window open
+func (s *ChatService) contactOpen(ctx context.Context, tripID string) (bool, error) {
+	if s.contacts == nil {
+		return false, nil
+	}
+	open, err := s.contacts.ContactOpen(ctx, tripID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check trip contact window: %w", err)
+	}
+	return open, nil
+}
+
+// chatArchiveStore deletes archived trips' messages along with the trips
+type chatArchiveStore struct {
+	TripArchiveStore
+	messages types.TripMessageStore
+}
+
+func (s *chatArchiveStore) Delete(ctx context.Context, ids []string) error {
+	if err := s.messages.DeleteTripMessages(ctx, ids); err != nil {
+		return err
+	}
+	return s.TripArchiveStore.Delete(ctx, ids)
+}
+
+// chatRole returns whether userID rides or drives the trip
+func chatRole(trip *models.Trip, userID string) (string, error) {
+	role, err := tripMemberRole(trip, userID)
+	if errors.Is(err, ErrNotTripMember) {
+		return "", ErrNotChatParticipant
+	}
+	return role, err
+}
+
+func findQuickReply(id, role string) (QuickReply, bool) {
+	for _, reply := range quickReplies {
+		if reply.ID == id && reply.Role == role {
+			return reply, true
+		}
+	}
+	return QuickReply{}, false
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rideshare-platform/services/trip-service/internal/repository"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+)
+
+func TestChatService_Messaging(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("test", "info")
+	store := repository.NewMemoryTripStore()
+	trips := NewTripService(store, log)
+	messages := repository.NewMemoryTripMessageStore()
+	chat := NewChatService(store, messages, log)
+
+	trip, err := trips.CreateTrip(ctx, &CreateTripRequest{
+		RiderID:             "rider-1",
+		PickupLocation:      models.Location{Latitude: 41.008237, Longitude: 28.978359},
+		DestinationLocation: models.Location{Latitude: 41.042211, Longitude: 29.008311},
+		RideType:            "standard",
+		EstimatedFare:       20,
+	})
+	require.NoError(t, err)
+
+	_, err = chat.SendMessage(ctx, &SendTripMessageRequest{TripID: trip.ID, SenderID: "rider-1", Text: "hello"})
+	assert.ErrorIs(t, err, ErrChatClosed, "there is nobody to message before a driver accepts")
+
+	_, err = trips.AcceptTrip(ctx, trip.ID, "driver-1")
+	require.NoError(t, err)
+
+	_, err = chat.SendMessage(ctx, &SendTripMessageRequest{TripID: trip.ID, SenderID: "stranger", Text: "hello"})
+	assert.ErrorIs(t, err, ErrNotChatParticipant)
+	_, err = chat.SendMessage(ctx, &SendTripMessageRequest{TripID: trip.ID, SenderID: "driver-1", QuickReplyID: "rider_coming_out"})
+	assert.ErrorIs(t, err, ErrUnknownQuickReply, "quick replies are per role")
+
+	// The rider is offline, so the driver's message waits for them
+	sent, err := chat.SendMessage(ctx, &SendTripMessageRequest{TripID: trip.ID, SenderID: "driver-1", QuickReplyID: "driver_on_my_way"})
+	require.NoError(t, err)
+	assert.Equal(t, "I'm on my way", sent.Text)
+	assert.Equal(t, "rider-1", sent.RecipientID)
+	assert.Nil(t, sent.DeliveredAt)
+
+	rider, err := chat.Subscribe(ctx, trip.ID, "rider-1")
+	require.NoError(t, err)
+	defer rider.Close()
+	require.Len(t, rider.Pending, 1)
+	assert.Equal(t, sent.ID, rider.Pending[0].ID)
+
+	// Once connected, messages are delivered straight away
+	reply, err := chat.SendMessage(ctx, &SendTripMessageRequest{TripID: trip.ID, SenderID: "driver-1", Text: "  blue car  "})
+	require.NoError(t, err)
+	assert.NotNil(t, reply.DeliveredAt)
+	select {
+	case received := <-rider.Messages:
+		assert.Equal(t, "blue car", received.Text)
+	case <-time.After(time.Second):
+		t.Fatal("message was not delivered to the connected rider")
+	}
+
+	again, err := chat.Subscribe(ctx, trip.ID, "rider-1")
+	require.NoError(t, err)
+	again.Close()
+	assert.Empty(t, again.Pending, "delivered messages are not replayed")
+
+	_, err = chat.ListMessages(ctx, trip.ID, "stranger")
+	assert.ErrorIs(t, err, ErrNotChatParticipant)
+	history, err := chat.ListMessages(ctx, trip.ID, "")
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, sent.ID, history[0].ID)
+
+	// Messaging closes with the trip, but support can still review it
+	_, err = trips.CancelTrip(ctx, trip.ID, "changed plans")
+	require.NoError(t, err)
+	_, err = chat.SendMessage(ctx, &SendTripMessageRequest{TripID: trip.ID, SenderID: "rider-1", Text: "thanks"})
+	assert.ErrorIs(t, err, ErrChatClosed)
+
+	// Archiving the trip removes its messages
+	require.NoError(t, chat.ArchiveStore(store).Delete(ctx, []string{trip.ID}))
+	history, err = chat.ListMessages(ctx, trip.ID, "")
+	require.NoError(t, err)
+	assert.Empty(t, history)
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rideshare-platform/shared/contactproxy"
+	"github.com/rideshare-platform/shared/events"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+)
+
+// ErrContactClosed is returned when asking for a contact handle on a trip
+// that has no driver yet or has ended
+var ErrContactClosed = errors.New("calling is only open while a driver is on the trip")
+
+// PhoneLookup returns the phone numbers masked numbers forward to
+type PhoneLookup interface {
+	GetPhone(ctx context.Context, userID string) (string, error)
+}
+
+// TripContact is how a trip's rider or driver reaches the other party
+type TripContact struct {
+	TripID    string              `json:"trip_id"`
+	Mode      contactproxy.Mode   `json:"mode"`
+	Handle    contactproxy.Handle `json:"handle"`
+	ExpiresAt time.Time           `json:"expires_at"`
+}
+
+// TripContactService lets a trip's rider and driver call each other through
+// a contact proxy while the driver is on the trip. Neither sees the other's
+// phone number; sessions are released when the trip completes or is
+// cancelled, or when their lifetime runs out.
+type TripContactService struct {
+	trips   TripRepositoryInterface
+	manager *contactproxy.Manager
+	phones  PhoneLookup
+	logger  *logger.Logger
+}
+
+// NewTripContactService creates a new trip contact service
+func NewTripContactService(trips TripRepositoryInterface, manager *contactproxy.Manager, logger *logger.Logger) *TripContactService {
+	return &TripContactService{
+		trips:   trips,
+		manager: manager,
+		logger:  logger,
+	}
+}
+
+// SetPhoneLookup attaches the lookup for riders' and drivers' phone numbers.
+// Without one every session falls back to in-app calls.
+func (s *TripContactService) SetPhoneLookup(phones PhoneLookup) {
+	s.phones = phones
+}
+
+// SubscribeEvents releases a trip's contact session when it completes or is cancelled
+func (s *TripContactService) SubscribeEvents(publisher events.Subscriber) error {
+	for _, eventType := range []events.EventType{events.TripCompletedEvent, events.TripCancelledEvent} {
+		if err := publisher.Subscribe(eventType, s.handleTripEnded); err != nil {
+			return fmt.Errorf("failed to subscribe to %s events: %w", eventType, err)
+		}
+	}
+	return nil
+}
+
+// ContactHandle returns the caller's handle for reaching the other party,
+// opening the trip's contact session on first use
+func (s *TripContactService) ContactHandle(ctx context.Context, tripID, userID string) (*TripContact, error) {
+	if tripID == "" || userID == "" {
+		return nil, fmt.Errorf("trip ID and user ID are required")
+	}
+
+	trip, err := s.trips.GetByID(ctx, tripID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trip: %w", err)
+	}
+	if _, err := tripMemberRole(trip, userID); err != nil {
+		return nil, err
+	}
+	if trip.DriverID == nil {
+		return nil, ErrContactClosed
+	}
+	if !trip.IsActive() {
+		// The end of the trip may not have been announced on this instance
+		if err := s.manager.Close(ctx, trip.ID); err != nil {
+			s.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+				"trip_id": trip.ID,
+			}).Warn("Failed to release contact session of ended trip")
+		}
+		return nil, ErrContactClosed
+	}
+
+	session, err := s.manager.Open(ctx, trip.ID, s.parties(ctx, trip))
+	if err != nil {
+		return nil, err
+	}
+	handle, ok := session.Handle(userID)
+	if !ok {
+		return nil, ErrNotTripMember
+	}
+	return &TripContact{
+		TripID:    trip.ID,
+		Mode:      session.Mode,
+		Handle:    handle,
+		ExpiresAt: session.ExpiresAt,
+	}, nil
+}
+
+// StartSweeper releases expired contact sessions every interval until ctx is done
+func (s *TripContactService) StartSweeper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if _, err := s.manager.ReleaseExpired(ctx, now); err != nil {
+				s.logger.WithContext(ctx).WithError(err).Warn("Contact session sweep failed")
+			}
+		}
+	}
+}
+
+func (s *TripContactService) handleTripEnded(ctx context.Context, event *events.Event) error {
+	return s.manager.Close(ctx, event.AggregateID)
+}
+
+// parties returns the trip's rider and driver with their phone numbers. A
+// number that cannot be looked up is left empty, so the session falls back
+// to an in-app call rather than failing.
+func (s *TripContactService) parties(ctx context.Context, trip *models.Trip) []contactproxy.Party {
+	parties := []contactproxy.Party{
+		{UserID: trip.RiderID, Role: "rider"},
+		{UserID: *trip.DriverID, Role: "driver"},
+	}
+	if s.phones == nil {
+		return parties
+	}
+	for i := range parties {
+		phone, err := s.phones.GetPhone(ctx, parties[i].UserID)
+		if err != nil {
+			s.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+				"trip_id": trip.ID,
+				"role":    parties[i].Role,
+			}).Warn("Failed to look up phone number for contact session")
+			continue
+		}
+		parties[i].Phone = phone
+	}
+	return parties
+}
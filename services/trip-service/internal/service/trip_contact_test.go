@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rideshare-platform/services/trip-service/internal/repository"
+	"github.com/rideshare-platform/shared/contactproxy"
+	"github.com/rideshare-platform/shared/events"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+)
+
+// phoneBook is a PhoneLookup over a fixed set of numbers
+type phoneBook map[string]string
+
+func (p phoneBook) GetPhone(ctx context.Context, userID string) (string, error) {
+	return p[userID], nil
+}
+
+func newContactTestTrip(t *testing.T, trips *TripService, riderID, driverID string) *models.Trip {
+	ctx := context.Background()
+	trip, err := trips.CreateTrip(ctx, &CreateTripRequest{
+		RiderID:             riderID,
+		PickupLocation:      models.Location{Latitude: 41.008237, Longitude: 28.978359},
+		DestinationLocation: models.Location{Latitude: 41.042211, Longitude: 29.008311},
+		RideType:            "standard",
+		EstimatedFare:       20,
+	})
+	require.NoError(t, err)
+	trip, err = trips.AcceptTrip(ctx, trip.ID, driverID)
+	require.NoError(t, err)
+	return trip
+}
+
+func TestTripContactService_MasksNumbersUntilTripEnds(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("test", "info")
+	store := repository.NewMemoryTripStore()
+	trips := NewTripService(store, log)
+	manager := contactproxy.NewManager(contactproxy.NewLocalProvider([]string{"+15550100", "+15550101"}, log), contactproxy.NewMemoryStore(), contactproxy.DefaultConfig(), log)
+	contacts := NewTripContactService(store, manager, log)
+	contacts.SetPhoneLookup(phoneBook{"rider-1": "+905551112233", "driver-1": "+905554445566", "rider-2": "+905557778899"})
+
+	publisher := events.NewEventPublisher(events.NewInMemoryEventBus(log), events.NewInMemoryEventStore(log), log)
+	require.NoError(t, contacts.SubscribeEvents(publisher))
+
+	trip := newContactTestTrip(t, trips, "rider-1", "driver-1")
+
+	_, err := contacts.ContactHandle(ctx, trip.ID, "stranger")
+	assert.ErrorIs(t, err, ErrNotTripMember)
+
+	rider, err := contacts.ContactHandle(ctx, trip.ID, "rider-1")
+	require.NoError(t, err)
+	assert.Equal(t, contactproxy.ModeMaskedNumber, rider.Mode)
+	driver, err := contacts.ContactHandle(ctx, trip.ID, "driver-1")
+	require.NoError(t, err)
+	assert.NotEmpty(t, rider.Handle.MaskedNumber)
+	assert.NotEmpty(t, driver.Handle.MaskedNumber)
+	assert.NotEqual(t, rider.Handle.MaskedNumber, driver.Handle.MaskedNumber)
+	assert.NotContains(t, []string{"+905551112233", "+905554445566"}, rider.Handle.MaskedNumber, "real numbers are never handed out")
+
+	again, err := contacts.ContactHandle(ctx, trip.ID, "rider-1")
+	require.NoError(t, err)
+	assert.Equal(t, rider.Handle, again.Handle, "a trip keeps its session")
+
+	// Both pooled numbers are in use
+	other := newContactTestTrip(t, trips, "rider-2", "driver-1")
+	_, err = contacts.ContactHandle(ctx, other.ID, "rider-2")
+	assert.ErrorIs(t, err, contactproxy.ErrNumbersExhausted)
+
+	// Completing the trip tears its session down and frees its numbers
+	require.NoError(t, publisher.PublishEvent(ctx, events.NewEvent(events.TripCompletedEvent, trip.ID, 1, map[string]interface{}{}, "trip-service")))
+	assert.Eventually(t, func() bool {
+		_, err := manager.Get(ctx, trip.ID)
+		return err == contactproxy.ErrSessionNotFound
+	}, time.Second, 10*time.Millisecond)
+
+	reused, err := contacts.ContactHandle(ctx, other.ID, "rider-2")
+	require.NoError(t, err)
+	assert.Contains(t, []string{rider.Handle.MaskedNumber, driver.Handle.MaskedNumber}, reused.Handle.MaskedNumber)
+
+	_, err = trips.StartTrip(ctx, trip.ID)
+	require.NoError(t, err)
+	_, err = trips.CompleteTrip(ctx, trip.ID, 25)
+	require.NoError(t, err)
+	_, err = contacts.ContactHandle(ctx, trip.ID, "rider-1")
+	assert.ErrorIs(t, err, ErrContactClosed)
+}
+
+func TestTripContactService_FallsBackToVoIPAndExpires(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("test", "info")
+	store := repository.NewMemoryTripStore()
+	trips := NewTripService(store, log)
+	manager := contactproxy.NewManager(contactproxy.NewLocalProvider([]string{"+15550100", "+15550101"}, log), contactproxy.NewMemoryStore(), contactproxy.DefaultConfig(), log)
+	contacts := NewTripContactService(store, manager, log)
+	contacts.SetPhoneLookup(phoneBook{"rider-1": "+905551112233"})
+
+	trip := newContactTestTrip(t, trips, "rider-1", "driver-1")
+	contact, err := contacts.ContactHandle(ctx, trip.ID, "rider-1")
+	require.NoError(t, err)
+	assert.Equal(t, contactproxy.ModeVoIP, contact.Mode, "the driver has no phone on file")
+	assert.NotEmpty(t, contact.Handle.VoIPToken)
+	assert.Empty(t, contact.Handle.MaskedNumber)
+
+	released, err := manager.ReleaseExpired(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Zero(t, released)
+	released, err = manager.ReleaseExpired(ctx, contact.ExpiresAt)
+	require.NoError(t, err)
+	assert.Equal(t, 1, released)
+	_, err = manager.Get(ctx, trip.ID)
+	assert.ErrorIs(t, err, contactproxy.ErrSessionNotFound)
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+)
+
+// TripEventLog stores each trip's lifecycle events
+type TripEventLog interface {
+	SaveEvent(ctx context.Context, event *models.TripEvent) error
+	GetTripEvents(ctx context.Context, tripID string) ([]*models.TripEvent, error)
+}
+
+// SetEventLog attaches the log trip lifecycle events are recorded in
+func (s *TripService) SetEventLog(events TripEventLog) {
+	s.events = events
+}
+
+// GetTripEvents returns a trip's lifecycle events in the order they happened
+func (s *TripService) GetTripEvents(ctx context.Context, tripID string) ([]*models.TripEvent, error) {
+	if _, err := s.GetTrip(ctx, tripID); err != nil {
+		return nil, err
+	}
+	if s.events == nil {
+		return []*models.TripEvent{}, nil
+	}
+
+	events, err := s.events.GetTripEvents(ctx, tripID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trip events: %w", err)
+	}
+	return events, nil
+}
+
+// recordEvent logs a lifecycle event for the trip. The change it describes has
+// already been saved, so a failure is only logged.
+func (s *TripService) recordEvent(ctx context.Context, trip *models.Trip, eventType types.TripEventType, data map[string]interface{}) {
+	if s.events == nil {
+		return
+	}
+
+	if data == nil {
+		data = make(map[string]interface{})
+	}
+	data["status"] = trip.Status
+	event := models.NewTripEvent(trip.ID, string(eventType), data, nil)
+	if err := s.events.SaveEvent(ctx, event); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+			"trip_id":    trip.ID,
+			"event_type": eventType,
+		}).Warn("Failed to record trip event")
+	}
+}
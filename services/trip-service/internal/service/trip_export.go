@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+
+	"github.com/rideshare-platform/shared/export"
+	"github.com/rideshare-platform/shared/models"
+)
+
+// TripExportDataset is the name trips are exported to the warehouse under
+const TripExportDataset = "trips"
+
+// TripChangeSource reads trips in export order for the warehouse export
+type TripChangeSource interface {
+	// ChangedSince returns up to limit trips updated after the watermark
+	ChangedSince(ctx context.Context, since export.Watermark, limit int) ([]*models.Trip, error)
+}
+
+var tripExportColumns = []string{
+	"id", "rider_id", "driver_id", "vehicle_id", "status", "currency",
+	"estimated_fare_minor", "final_fare_minor", "surge_multiplier",
+	"estimated_distance_km", "actual_distance_km", "actual_duration_seconds",
+	"pickup_latitude", "pickup_longitude", "destination_latitude", "destination_longitude",
+	"passenger_count", "cancelled_by", "cancellation_reason",
+	"requested_at", "scheduled_for", "started_at", "completed_at", "created_at", "updated_at",
+	"city_id",
+}
+
+// NewTripExport creates the warehouse dataset of trips. Fares are exported
+// in minor units of the trip's currency.
+func NewTripExport(source TripChangeSource) export.Dataset {
+	return export.NewDataset(TripExportDataset, tripExportColumns, func(ctx context.Context, since export.Watermark, limit int) ([]export.Record, error) {
+		trips, err := source.ChangedSince(ctx, since, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		records := make([]export.Record, 0, len(trips))
+		for _, trip := range trips {
+			records = append(records, export.Record{
+				ID:        trip.ID,
+				UpdatedAt: trip.UpdatedAt,
+				Values: []string{
+					trip.ID, trip.RiderID, export.StringPtr(trip.DriverID), export.StringPtr(trip.VehicleID),
+					string(trip.Status), trip.Currency,
+					export.IntPtr(trip.EstimatedFareCents), export.IntPtr(trip.ActualFareCents), export.FloatPtr(trip.SurgeMultiplier),
+					export.FloatPtr(trip.EstimatedDistanceKm), export.FloatPtr(trip.ActualDistanceKm), export.IntPtr(trip.ActualDurationSeconds),
+					export.Float(trip.PickupLocation.Latitude), export.Float(trip.PickupLocation.Longitude),
+					export.Float(trip.Destination.Latitude), export.Float(trip.Destination.Longitude),
+					export.Int(int64(trip.PassengerCount)), export.StringPtr(trip.CancelledBy), export.StringPtr(trip.CancellationReason),
+					export.Time(trip.RequestedAt), export.TimePtr(trip.ScheduledFor), export.TimePtr(trip.StartedAt),
+					export.TimePtr(trip.CompletedAt), export.Time(trip.CreatedAt), export.Time(trip.UpdatedAt),
+					trip.CityID,
+				},
+			})
+		}
+		return records, nil
+	})
+}
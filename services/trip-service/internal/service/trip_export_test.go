@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rideshare-platform/services/trip-service/internal/repository"
+	"github.com/rideshare-platform/shared/export"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTripExport_IncrementalBatches(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("test", "info")
+	store := repository.NewMemoryTripStore()
+	trips := NewTripService(store, log)
+
+	dir := t.TempDir()
+	exporter := export.NewExporter(export.NewFileSink(dir), export.NewMemoryManifestStore(), log)
+	exporter.SetFormat(export.FormatCSV)
+	exporter.SetBatchSize(2)
+	exporter.Register(NewTripExport(store))
+
+	request := &CreateTripRequest{
+		RiderID:             "rider-1",
+		PickupLocation:      models.Location{Latitude: 41.0082, Longitude: 28.9784},
+		DestinationLocation: models.Location{Latitude: 41.0422, Longitude: 29.0083},
+		RideType:            "standard",
+		EstimatedFare:       20,
+		Currency:            "EUR",
+	}
+	var created []*models.Trip
+	for i := 0; i < 3; i++ {
+		trip, err := trips.CreateTrip(ctx, request)
+		require.NoError(t, err)
+		created = append(created, trip)
+	}
+
+	batches, err := exporter.Run(ctx)
+	require.NoError(t, err)
+	require.Len(t, batches, 2, "three trips in batches of two")
+	assert.Equal(t, 2, batches[0].Rows)
+	assert.Equal(t, 1, batches[1].Rows)
+	assert.Equal(t, batches[0].To, batches[1].From)
+	assert.True(t, strings.HasPrefix(batches[0].Key, "trips/dt="))
+
+	rows := readExportedCSV(t, filepath.Join(dir, filepath.FromSlash(batches[0].Key)))
+	require.Len(t, rows, 3)
+	assert.Equal(t, tripExportColumns, rows[0])
+	assert.Equal(t, "EUR", rows[1][5])
+	assert.Equal(t, "2000", rows[1][6], "fares are exported in minor units")
+
+	// Nothing changed, nothing is exported
+	batches, err = exporter.Run(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, batches)
+
+	// Only the cancelled trip is exported again
+	_, err = trips.CancelTrip(ctx, created[1].ID, "changed plans")
+	require.NoError(t, err)
+	batches, err = exporter.RunDataset(ctx, TripExportDataset)
+	require.NoError(t, err)
+	require.Len(t, batches, 1)
+	rows = readExportedCSV(t, filepath.Join(dir, filepath.FromSlash(batches[0].Key)))
+	require.Len(t, rows, 2)
+	assert.Equal(t, created[1].ID, rows[1][0])
+	assert.Equal(t, string(models.TripStatusCancelled), rows[1][4])
+
+	listed, err := exporter.ListBatches(ctx, export.BatchFilter{Dataset: TripExportDataset})
+	require.NoError(t, err)
+	assert.Len(t, listed, 3)
+	assert.Equal(t, batches[0].ID, listed[0].ID, "most recent batch first")
+}
+
+func readExportedCSV(t *testing.T, path string) [][]string {
+	t.Helper()
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	rows, err := csv.NewReader(file).ReadAll()
+	require.NoError(t, err)
+	return rows
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/rideshare-platform/shared/currency"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+)
+
+// FinalFareRequest describes a completed trip as it actually happened, for
+// pricing-service to charge
+type FinalFareRequest struct {
+	TripID          string
+	RiderID         string
+	VehicleType     string
+	PickupArea      string
+	PickupLocation  *models.Location
+	Destination     *models.Location
+	DistanceKm      float64
+	DurationSeconds int
+	WaitingSeconds  int
+	// SurgeMultiplier was locked when the trip was requested. Zero prices the
+	// trip at the current surge.
+	SurgeMultiplier float64
+	StartedAt       *time.Time
+	CompletedAt     time.Time
+	City            string
+	// Currency the trip was quoted in. pricing-service rejects the fare if it
+	// would charge in another one.
+	Currency string
+}
+
+// TripFarePricer asks pricing-service for the itemized fare of a completed trip
+type TripFarePricer interface {
+	PriceCompletedTrip(ctx context.Context, req *FinalFareRequest) (*models.FareBreakdown, error)
+}
+
+// SetFarePricer attaches the pricing-service client used to charge completed
+// trips for their actual distance, duration and waiting time
+func (s *TripService) SetFarePricer(fares TripFarePricer) {
+	s.fares = fares
+}
+
+// SetDefaultCurrency sets the currency trips are charged in when their quote
+// names none
+func (s *TripService) SetDefaultCurrency(code string) error {
+	normalized, err := currency.Normalize(code)
+	if err != nil {
+		return err
+	}
+	s.defaultCurrency = normalized
+	return nil
+}
+
+// tripCurrency returns the currency a new trip is charged in: the one it was
+// quoted in, or when the quote names none, the currency of its city or the
+// default
+func (s *TripService) tripCurrency(quoted, cityID string) (string, error) {
+	if quoted != "" {
+		return currency.Normalize(quoted)
+	}
+	if tripCity, err := s.cities.Get(cityID); err == nil {
+		return tripCity.Currency, nil
+	}
+	if s.defaultCurrency == "" {
+		return currency.Default, nil
+	}
+	return s.defaultCurrency, nil
+}
+
+// calculateActualFare prices the completed trip from its actual distance,
+// duration and waiting time at the surge locked when it was requested. When
+// pricing-service is unavailable, or prices the trip in a different currency
+// than it was quoted in, the fallback fare is charged, or the estimate if
+// there is none.
+func (s *TripService) calculateActualFare(ctx context.Context, trip *models.Trip, fallbackFare float64) *models.FareBreakdown {
+	req := finalFareRequest(trip)
+
+	if s.fares != nil {
+		breakdown, err := s.fares.PriceCompletedTrip(ctx, req)
+		if err == nil {
+			err = currency.Match(trip.Currency, breakdown.Total.Currency)
+		}
+		if err == nil {
+			return breakdown
+		}
+		s.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+			"trip_id": trip.ID,
+		}).Warn("Failed to price completed trip, charging the estimate")
+	}
+
+	fareCents := currency.ToMinor(fallbackFare, trip.Currency)
+	if fallbackFare <= 0 && trip.EstimatedFareCents != nil {
+		fareCents = *trip.EstimatedFareCents
+	}
+	return &models.FareBreakdown{
+		Total:           models.NewMoney(fareCents, trip.Currency),
+		SurgeMultiplier: req.SurgeMultiplier,
+		WaitingSeconds:  req.WaitingSeconds,
+		Estimated:       true,
+	}
+}
+
+func finalFareRequest(trip *models.Trip) *FinalFareRequest {
+	pickup, destination := trip.PickupLocation, trip.Destination
+	req := &FinalFareRequest{
+		TripID:         trip.ID,
+		RiderID:        trip.RiderID,
+		PickupLocation: &pickup,
+		Destination:    &destination,
+		WaitingSeconds: trip.WaitingSeconds(),
+		StartedAt:      trip.StartedAt,
+		CompletedAt:    time.Now(),
+		City:           trip.CityID,
+		Currency:       trip.Currency,
+	}
+	if trip.CompletedAt != nil {
+		req.CompletedAt = *trip.CompletedAt
+	}
+	switch {
+	case trip.ActualDistanceKm != nil:
+		req.DistanceKm = *trip.ActualDistanceKm
+	case trip.EstimatedDistanceKm != nil:
+		req.DistanceKm = *trip.EstimatedDistanceKm
+	}
+	switch {
+	case trip.ActualDurationSeconds != nil:
+		req.DurationSeconds = *trip.ActualDurationSeconds
+	case trip.EstimatedDurationSeconds != nil:
+		req.DurationSeconds = *trip.EstimatedDurationSeconds
+	}
+	if trip.SurgeMultiplier != nil {
+		req.SurgeMultiplier = *trip.SurgeMultiplier
+	}
+	return req
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+)
+
+var (
+	// ErrIdempotencyKeyReused is returned when an idempotency key is sent
+	// again with a different trip request
+	ErrIdempotencyKeyReused = errors.New("idempotency key was already used for a different trip request")
+	// ErrIdempotentRequestInProgress is returned when a request is retried
+	// while the first request with its idempotency key is still being processed
+	ErrIdempotentRequestInProgress = errors.New("a trip request with this idempotency key is still being processed")
+)
+
+const (
+	// DefaultIdempotencyTTL is how long idempotency keys are remembered when
+	// no lifetime is configured
+	DefaultIdempotencyTTL = 24 * time.Hour
+	// MaxIdempotencyKeyLength bounds the idempotency keys clients can send
+	MaxIdempotencyKeyLength = 255
+	// idempotencyCompleteAttempts is how often recording the trip a key
+	// created is tried before the key is given up
+	idempotencyCompleteAttempts = 3
+)
+
+// idempotencyRetryDelay is the pause before the first retry of recording a
+// key's trip, doubled before each further retry
+var idempotencyRetryDelay = 100 * time.Millisecond
+
+// SetIdempotency remembers the idempotency keys trips are requested with in
+// store for ttl, so retried requests return the trip they created
+func (s *TripService) SetIdempotency(store types.TripIdempotencyStore, ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyTTL
+	}
+	s.idempotency = store
+	s.idempotencyTTL = ttl
+}
+
+// CreateTripIdempotent creates a trip the first time a rider sends key and
+// returns that trip, reporting it as replayed, when the request is retried
+// with the same key. Retries must repeat the request's payload. Without a
+// key, or when no idempotency store is configured, every request creates a
+// trip.
+func (s *TripService) CreateTripIdempotent(ctx context.Context, key string, req *CreateTripRequest) (*models.Trip, bool, error) {
+	if key == "" || s.idempotency == nil {
+		trip, err := s.CreateTrip(ctx, req)
+		return trip, false, err
+	}
+	if len(key) > MaxIdempotencyKeyLength {
+		return nil, false, fmt.Errorf("invalid request: idempotency key must be at most %d characters", MaxIdempotencyKeyLength)
+	}
+	if req.RiderID == "" {
+		return nil, false, fmt.Errorf("invalid request: rider ID is required")
+	}
+
+	requestHash, err := hashCreateTripRequest(req)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid request: %w", err)
+	}
+	now := time.Now()
+	saved, reserved, err := s.idempotency.ReserveIdempotencyKey(ctx, &types.TripIdempotencyKey{
+		Key:         key,
+		RiderID:     req.RiderID,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.idempotencyTTL),
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+
+	if !reserved {
+		switch {
+		case saved.RequestHash != requestHash:
+			return nil, false, ErrIdempotencyKeyReused
+		case saved.TripID == "":
+			return nil, false, ErrIdempotentRequestInProgress
+		}
+		trip, err := s.tripRepo.GetByID(ctx, saved.TripID)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to get trip: %w", err)
+		}
+		s.logger.WithContext(ctx).WithFields(logger.Fields{
+			"trip_id":  trip.ID,
+			"rider_id": trip.RiderID,
+		}).Info("Replayed trip request")
+		return trip, true, nil
+	}
+
+	trip, err := s.CreateTrip(ctx, req)
+	if err != nil {
+		// The request can be retried with the same key once it is fixed
+		if releaseErr := s.idempotency.ReleaseIdempotencyKey(ctx, req.RiderID, key); releaseErr != nil {
+			s.logger.WithContext(ctx).WithError(releaseErr).Warn("Failed to release idempotency key")
+		}
+		return nil, false, err
+	}
+	s.completeIdempotencyKey(ctx, req.RiderID, key, trip.ID)
+	return trip, false, nil
+}
+
+// completeIdempotencyKey records the trip a key created, retrying failures.
+// A key that cannot be recorded is released: otherwise every retry would be
+// refused as in progress until the key expires, while a released key only
+// lets a retry request another trip.
+func (s *TripService) completeIdempotencyKey(ctx context.Context, riderID, key, tripID string) {
+	// The trip exists, so the key is recorded even if the request is cancelled
+	ctx = context.WithoutCancel(ctx)
+
+	var err error
+	delay := idempotencyRetryDelay
+	for attempt := 1; attempt <= idempotencyCompleteAttempts; attempt++ {
+		if err = s.idempotency.CompleteIdempotencyKey(ctx, riderID, key, tripID); err == nil {
+			return
+		}
+		if attempt < idempotencyCompleteAttempts {
+			time.Sleep(delay)
+			delay *= 2
+		}
+	}
+
+	fields := logger.Fields{"trip_id": tripID, "rider_id": riderID}
+	s.logger.WithContext(ctx).WithError(err).WithFields(fields).Error("Failed to record idempotency key, releasing it")
+	if releaseErr := s.idempotency.ReleaseIdempotencyKey(ctx, riderID, key); releaseErr != nil {
+		s.logger.WithContext(ctx).WithError(releaseErr).WithFields(fields).Error("Failed to release idempotency key")
+	}
+}
+
+// hashCreateTripRequest identifies a trip request's payload
+func hashCreateTripRequest(req *CreateTripRequest) (string, error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
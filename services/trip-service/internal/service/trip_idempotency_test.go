@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rideshare-platform/services/trip-service/internal/repository"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTripService_CreateTripIdempotent(t *testing.T) {
+	ctx := context.Background()
+	store := repository.NewMemoryTripStore()
+	service := NewTripService(store, logger.NewLogger("test", "info"))
+	service.SetIdempotency(repository.NewMemoryTripIdempotencyStore(), 0)
+
+	newRequest := func(riderID string, fare float64) *CreateTripRequest {
+		return &CreateTripRequest{
+			RiderID:             riderID,
+			PickupLocation:      models.Location{Latitude: 41.0082, Longitude: 28.9784},
+			DestinationLocation: models.Location{Latitude: 41.0422, Longitude: 29.0083},
+			RideType:            "standard",
+			EstimatedFare:       fare,
+		}
+	}
+
+	trip, replayed, err := service.CreateTripIdempotent(ctx, "tap-1", newRequest("rider123", 25))
+	require.NoError(t, err)
+	assert.False(t, replayed)
+
+	replay, replayed, err := service.CreateTripIdempotent(ctx, "tap-1", newRequest("rider123", 25))
+	require.NoError(t, err)
+	assert.True(t, replayed)
+	assert.Equal(t, trip.ID, replay.ID)
+
+	trips, err := store.GetByRiderID(ctx, "rider123")
+	require.NoError(t, err)
+	assert.Len(t, trips, 1, "a replay must not create another trip")
+
+	_, _, err = service.CreateTripIdempotent(ctx, "tap-1", newRequest("rider123", 30))
+	assert.True(t, errors.Is(err, ErrIdempotencyKeyReused))
+
+	// Keys are scoped to the rider sending them
+	other, replayed, err := service.CreateTripIdempotent(ctx, "tap-1", newRequest("rider456", 25))
+	require.NoError(t, err)
+	assert.False(t, replayed)
+	assert.NotEqual(t, trip.ID, other.ID)
+
+	// A failed request releases its key so the fixed request can be retried
+	invalid := newRequest("rider123", 25)
+	invalid.PickupLocation = models.Location{Latitude: 100}
+	_, _, err = service.CreateTripIdempotent(ctx, "tap-2", invalid)
+	assert.Error(t, err)
+	_, replayed, err = service.CreateTripIdempotent(ctx, "tap-2", newRequest("rider123", 25))
+	require.NoError(t, err)
+	assert.False(t, replayed)
+}
+
+// flakyIdempotencyStore fails the first failures attempts to record a trip
+type flakyIdempotencyStore struct {
+	*repository.MemoryTripIdempotencyStore
+	failures  int
+	completes int
+}
+
+func (f *flakyIdempotencyStore) CompleteIdempotencyKey(ctx context.Context, riderID, key, tripID string) error {
+	f.completes++
+	if f.completes <= f.failures {
+		return errors.New("store unavailable")
+	}
+	return f.MemoryTripIdempotencyStore.CompleteIdempotencyKey(ctx, riderID, key, tripID)
+}
+
+func TestTripService_CreateTripIdempotentRetriesRecordingTheTrip(t *testing.T) {
+	ctx := context.Background()
+	defer func(delay time.Duration) { idempotencyRetryDelay = delay }(idempotencyRetryDelay)
+	idempotencyRetryDelay = time.Millisecond
+
+	request := &CreateTripRequest{
+		RiderID:             "rider123",
+		PickupLocation:      models.Location{Latitude: 41.0082, Longitude: 28.9784},
+		DestinationLocation: models.Location{Latitude: 41.0422, Longitude: 29.0083},
+		RideType:            "standard",
+		EstimatedFare:       25,
+	}
+	newService := func(failures int) (*TripService, *flakyIdempotencyStore) {
+		store := &flakyIdempotencyStore{MemoryTripIdempotencyStore: repository.NewMemoryTripIdempotencyStore(), failures: failures}
+		service := NewTripService(repository.NewMemoryTripStore(), logger.NewLogger("test", "info"))
+		service.SetIdempotency(store, 0)
+		return service, store
+	}
+
+	// A passing failure is retried and the retry replays the trip
+	service, store := newService(2)
+	trip, _, err := service.CreateTripIdempotent(ctx, "tap-1", request)
+	require.NoError(t, err)
+	assert.Equal(t, 3, store.completes)
+	replay, replayed, err := service.CreateTripIdempotent(ctx, "tap-1", request)
+	require.NoError(t, err)
+	assert.True(t, replayed)
+	assert.Equal(t, trip.ID, replay.ID)
+
+	// A key that cannot be recorded is released rather than refusing every
+	// retry as in progress until it expires
+	service, store = newService(idempotencyCompleteAttempts)
+	_, _, err = service.CreateTripIdempotent(ctx, "tap-1", request)
+	require.NoError(t, err)
+	assert.Equal(t, idempotencyCompleteAttempts, store.completes)
+	_, reserved, err := store.ReserveIdempotencyKey(ctx, &types.TripIdempotencyKey{
+		Key:       "tap-1",
+		RiderID:   "rider123",
+		ExpiresAt: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+	assert.True(t, reserved)
+}
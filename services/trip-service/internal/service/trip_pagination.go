@@ -0,0 +1,56 @@
+package service
+
+import (
+	"github.com/rideshare-platform/shared/models"
+	"github.com/rideshare-platform/shared/pagination"
+)
+
+var (
+	tripSortRequestedAt = pagination.Field{Name: "requested_at", Kind: pagination.KindTime}
+	tripSortUpdatedAt   = pagination.Field{Name: "updated_at", Kind: pagination.KindTime}
+	tripSortFare        = pagination.Field{Name: "fare", Kind: pagination.KindNumber}
+
+	tripSortFields = []pagination.Field{tripSortRequestedAt, tripSortUpdatedAt, tripSortFare}
+)
+
+// TripListOptions are the sorts trip histories offer, most recent request
+// first by default
+var TripListOptions = pagination.Options{
+	Fields:       tripSortFields,
+	Default:      pagination.Sort{Field: tripSortRequestedAt, Descending: true},
+	DefaultLimit: 50,
+	MaxLimit:     200,
+}
+
+// ActiveTripListOptions are the sorts the operators' active trip list
+// offers, oldest request first by default so the longest waits lead
+var ActiveTripListOptions = pagination.Options{
+	Fields:       tripSortFields,
+	Default:      pagination.Sort{Field: tripSortRequestedAt},
+	DefaultLimit: 50,
+	MaxLimit:     200,
+}
+
+// TripSortKey returns the position of trips in a list sorted by sort. Trips
+// sort on their actual fare once known and their estimate before.
+func TripSortKey(sort pagination.Sort) func(*models.Trip) pagination.Key {
+	switch sort.Field.Name {
+	case tripSortUpdatedAt.Name:
+		return func(t *models.Trip) pagination.Key { return pagination.TimeKey(t.UpdatedAt, t.ID) }
+	case tripSortFare.Name:
+		return func(t *models.Trip) pagination.Key { return pagination.NumberKey(float64(tripFareCents(t)), t.ID) }
+	default:
+		return func(t *models.Trip) pagination.Key { return pagination.TimeKey(t.RequestedAt, t.ID) }
+	}
+}
+
+func tripFareCents(trip *models.Trip) int64 {
+	switch {
+	case trip.ActualFareCents != nil:
+		return *trip.ActualFareCents
+	case trip.EstimatedFareCents != nil:
+		return *trip.EstimatedFareCents
+	default:
+		return 0
+	}
+}
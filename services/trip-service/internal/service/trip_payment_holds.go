@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+
+	"github.com/rideshare-platform/shared/currency"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+)
+
+// TripFareHolds holds a trip's fare on the rider's payment method through
+// payment-service: the estimate plus a buffer when the trip starts, charging
+// the actual fare when it completes and releasing the hold when it is
+// cancelled
+type TripFareHolds interface {
+	HoldFare(ctx context.Context, tripID, riderID, driverID string, estimatedFare float64, currencyCode string) error
+	CaptureFare(ctx context.Context, tripID, riderID, driverID string, fare float64, currencyCode string) error
+	ReleaseFare(ctx context.Context, tripID string) error
+}
+
+// SetFareHolds attaches the payment-service client trip fares are held and
+// charged through
+func (s *TripService) SetFareHolds(holds TripFareHolds) {
+	s.holds = holds
+}
+
+// holdFare holds a started trip's estimated fare. Trips are not held up by
+// payments: a trip whose fare could not be held is charged when it completes.
+func (s *TripService) holdFare(ctx context.Context, trip *models.Trip) {
+	if s.holds == nil || trip.EstimatedFareCents == nil {
+		return
+	}
+
+	estimate := currency.FromMinor(*trip.EstimatedFareCents, trip.Currency)
+	if err := s.holds.HoldFare(ctx, trip.ID, trip.RiderID, tripDriverID(trip), estimate, trip.Currency); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+			"trip_id": trip.ID,
+		}).Warn("Failed to hold trip fare, it will be charged on completion")
+	}
+}
+
+// captureFare charges a completed trip's final fare against its hold
+func (s *TripService) captureFare(ctx context.Context, trip *models.Trip) {
+	if s.holds == nil || trip.FareBreakdown == nil {
+		return
+	}
+
+	total := trip.FareBreakdown.Total
+	fare := currency.FromMinor(total.Amount, total.Currency)
+	if err := s.holds.CaptureFare(ctx, trip.ID, trip.RiderID, tripDriverID(trip), fare, total.Currency); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+			"trip_id": trip.ID,
+			"fare":    fare,
+		}).Error("Failed to charge trip fare")
+	}
+}
+
+// releaseFare releases the hold of a trip cancelled after it started
+func (s *TripService) releaseFare(ctx context.Context, trip *models.Trip) {
+	if s.holds == nil {
+		return
+	}
+
+	if err := s.holds.ReleaseFare(ctx, trip.ID); err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+			"trip_id": trip.ID,
+		}).Warn("Failed to release trip fare hold, it will lapse on its own")
+	}
+}
+
+func tripDriverID(trip *models.Trip) string {
+	if trip.DriverID == nil {
+		return ""
+	}
+	return *trip.DriverID
+}
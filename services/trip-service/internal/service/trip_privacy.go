@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+	"github.com/rideshare-platform/shared/pagination"
+)
+
+// ErrUserHasActiveTrip is returned when erasing the data of a user who is
+// riding or driving a trip that has not ended
+var ErrUserHasActiveTrip = errors.New("user has an active trip")
+
+// anonymizedCoordinatePrecision is the decimal places erased trips keep of
+// their pickup and destination, about a kilometre. Regional reporting still
+// works on the rounded locations.
+const anonymizedCoordinatePrecision = 2
+
+// UserTripFilter narrows the trips listed for a user. Role is "rider",
+// "driver" or empty for both.
+type UserTripFilter struct {
+	Role   string
+	Status models.TripStatus
+	Page   pagination.Request
+}
+
+// ListUserTrips returns a page of the trips a user rode or drove, most recent
+// request first unless the page asks for another sort
+func (s *TripService) ListUserTrips(ctx context.Context, userID string, filter UserTripFilter) (*pagination.Page[*models.Trip], error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user ID is required")
+	}
+	if filter.Role != "" && filter.Role != "rider" && filter.Role != "driver" {
+		return nil, fmt.Errorf("role must be rider or driver, got %q", filter.Role)
+	}
+	filter.Page = TripListOptions.WithDefaults(filter.Page)
+
+	trips, err := s.userTrips(ctx, userID, filter.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	matched := trips[:0]
+	for _, trip := range trips {
+		if filter.Status == "" || trip.Status == filter.Status {
+			matched = append(matched, trip)
+		}
+	}
+	page := pagination.Paginate(matched, filter.Page, TripSortKey(filter.Page.Sort))
+	return &page, nil
+}
+
+// AnonymizeUserTrips scrubs a user's personal data from every trip they rode
+// or drove and returns the trips' IDs. Pickup and destination are rounded,
+// the recorded route and free text are dropped, and the fares, distances and
+// timestamps are kept for the financial records. Users on an active trip are
+// refused, so the trip can finish and be charged first.
+func (s *TripService) AnonymizeUserTrips(ctx context.Context, userID string) ([]string, error) {
+	trips, err := s.userTrips(ctx, userID, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, trip := range trips {
+		if trip.IsActive() {
+			return nil, fmt.Errorf("%w: %s", ErrUserHasActiveTrip, trip.ID)
+		}
+	}
+
+	now := time.Now()
+	tripIDs := make([]string, 0, len(trips))
+	for _, trip := range trips {
+		anonymizeTrip(trip)
+		trip.UpdatedAt = now
+		if err := s.tripRepo.Update(ctx, trip); err != nil {
+			s.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+				"trip_id": trip.ID,
+			}).Error("Failed to anonymize trip")
+			return nil, fmt.Errorf("failed to anonymize trip %s: %w", trip.ID, err)
+		}
+		tripIDs = append(tripIDs, trip.ID)
+	}
+
+	s.logger.WithContext(ctx).WithFields(logger.Fields{
+		"user_id":    userID,
+		"trip_count": len(tripIDs),
+	}).Info("Anonymized user trips")
+	return tripIDs, nil
+}
+
+// userTrips returns the trips a user rode, drove or both, each once
+func (s *TripService) userTrips(ctx context.Context, userID, role string) ([]*models.Trip, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user ID is required")
+	}
+
+	var trips []*models.Trip
+	seen := make(map[string]bool)
+	add := func(found []*models.Trip) {
+		for _, trip := range found {
+			if !seen[trip.ID] {
+				seen[trip.ID] = true
+				trips = append(trips, trip)
+			}
+		}
+	}
+
+	if role != "driver" {
+		ridden, err := s.tripRepo.GetByRiderID(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get rider trips: %w", err)
+		}
+		add(ridden)
+	}
+	if role != "rider" {
+		driven, err := s.tripRepo.GetByDriverID(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get driver trips: %w", err)
+		}
+		add(driven)
+	}
+	return trips, nil
+}
+
+func anonymizeTrip(trip *models.Trip) {
+	trip.PickupLocation = anonymizeLocation(trip.PickupLocation)
+	trip.Destination = anonymizeLocation(trip.Destination)
+	trip.ActualRoute = nil
+	trip.DriverLocation = nil
+	trip.SpecialRequests = nil
+	trip.CancellationReason = nil
+	trip.PromoCode = nil
+}
+
+func anonymizeLocation(location models.Location) models.Location {
+	scale := math.Pow(10, anonymizedCoordinatePrecision)
+	return models.Location{
+		Latitude:  math.Round(location.Latitude*scale) / scale,
+		Longitude: math.Round(location.Longitude*scale) / scale,
+		Timestamp: location.Timestamp,
+	}
+}
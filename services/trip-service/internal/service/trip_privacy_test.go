@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rideshare-platform/services/trip-service/internal/repository"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTripService_AnonymizeUserTrips(t *testing.T) {
+	ctx := context.Background()
+	trips := NewTripService(repository.NewMemoryTripStore(), logger.NewLogger("test", "info"))
+
+	request := &CreateTripRequest{
+		RiderID:             "rider-1",
+		PickupLocation:      models.Location{Latitude: 41.008237, Longitude: 28.978359},
+		DestinationLocation: models.Location{Latitude: 41.042211, Longitude: 29.008311},
+		RideType:            "standard",
+		EstimatedFare:       20,
+		Currency:            "EUR",
+	}
+	completed, err := trips.CreateTrip(ctx, request)
+	require.NoError(t, err)
+	_, err = trips.AcceptTrip(ctx, completed.ID, "driver-1")
+	require.NoError(t, err)
+	_, err = trips.StartTrip(ctx, completed.ID)
+	require.NoError(t, err)
+	_, err = trips.CompleteTrip(ctx, completed.ID, 25)
+	require.NoError(t, err)
+
+	active, err := trips.CreateTrip(ctx, request)
+	require.NoError(t, err)
+
+	// The rider is refused while a trip of theirs is still active
+	_, err = trips.AnonymizeUserTrips(ctx, "rider-1")
+	assert.ErrorIs(t, err, ErrUserHasActiveTrip)
+
+	_, err = trips.CancelTrip(ctx, active.ID, "call me on +44 7700 900123")
+	require.NoError(t, err)
+
+	tripIDs, err := trips.AnonymizeUserTrips(ctx, "rider-1")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{completed.ID, active.ID}, tripIDs)
+
+	anonymized, err := trips.GetTrip(ctx, completed.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 41.01, anonymized.PickupLocation.Latitude)
+	assert.Equal(t, 28.98, anonymized.PickupLocation.Longitude)
+	assert.Equal(t, 41.04, anonymized.Destination.Latitude)
+	require.NotNil(t, anonymized.ActualFareCents, "fares are kept for the financial records")
+	assert.Equal(t, int64(2500), *anonymized.ActualFareCents)
+	assert.Equal(t, "driver-1", *anonymized.DriverID)
+
+	cancelled, err := trips.GetTrip(ctx, active.ID)
+	require.NoError(t, err)
+	assert.Nil(t, cancelled.CancellationReason)
+
+	// The driver's trips are listed through the same lookup
+	driven, err := trips.ListUserTrips(ctx, "driver-1", UserTripFilter{Role: "driver"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), driven.TotalEstimate)
+	assert.Equal(t, completed.ID, driven.Items[0].ID)
+}
@@ -0,0 +1,284 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+)
+
+// TripEventFeed reads every trip's lifecycle events in the order they were
+// recorded
+type TripEventFeed interface {
+	// EventsAfter returns up to limit events recorded after position
+	EventsAfter(ctx context.Context, position int64, limit int) ([]types.LoggedTripEvent, error)
+	// LatestPosition returns the position of the last event recorded
+	LatestPosition(ctx context.Context) (int64, error)
+}
+
+// ProjectionConfig controls how the trip listing projection catches up
+type ProjectionConfig struct {
+	BatchSize int           // events read from the log at a time
+	MaxLag    time.Duration // oldest unprojected event age the projection is healthy within
+}
+
+// DefaultProjectionConfig returns the default projection settings
+func DefaultProjectionConfig() ProjectionConfig {
+	return ProjectionConfig{
+		BatchSize: 500,
+		MaxLag:    time.Minute,
+	}
+}
+
+// ProjectionLag is how far the trip listing projection is behind the log
+type ProjectionLag struct {
+	Checkpoint     int64 `json:"checkpoint"`
+	LatestPosition int64 `json:"latest_position"`
+	EventsBehind   int64 `json:"events_behind"`
+	// LagSeconds is how long ago the oldest unprojected event was recorded
+	LagSeconds  float64    `json:"lag_seconds"`
+	ProjectedAt *time.Time `json:"projected_at,omitempty"`
+	RebuiltAt   *time.Time `json:"rebuilt_at,omitempty"`
+}
+
+// TripProjector projects trip lifecycle events into the listing read model
+// rider, driver and status trip lists are served from, so lists are not
+// scanned out of the trip store. Each event refreshes its trip's listing
+// from the trip as saved, so events can be replayed any number of times and
+// the read model can be rebuilt from the log.
+type TripProjector struct {
+	feed     TripEventFeed
+	trips    TripRepositoryInterface
+	listings types.TripListingStore
+	config   ProjectionConfig
+	logger   *logger.Logger
+
+	// mutex serializes projecting and rebuilding
+	mutex       sync.Mutex
+	projectedAt time.Time
+	rebuiltAt   time.Time
+}
+
+// NewTripProjector creates a trip listing projector
+func NewTripProjector(feed TripEventFeed, trips TripRepositoryInterface, listings types.TripListingStore, config ProjectionConfig, logger *logger.Logger) *TripProjector {
+	if config.BatchSize < 1 {
+		config.BatchSize = DefaultProjectionConfig().BatchSize
+	}
+	return &TripProjector{
+		feed:     feed,
+		trips:    trips,
+		listings: listings,
+		config:   config,
+		logger:   logger,
+	}
+}
+
+// Project projects the events recorded since the checkpoint and returns how
+// many were projected
+func (p *TripProjector) Project(ctx context.Context) (int, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.project(ctx)
+}
+
+func (p *TripProjector) project(ctx context.Context) (int, error) {
+	checkpoint, err := p.listings.GetCheckpoint(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get projection checkpoint: %w", err)
+	}
+
+	projected := 0
+	for {
+		events, err := p.feed.EventsAfter(ctx, checkpoint, p.config.BatchSize)
+		if err != nil {
+			return projected, fmt.Errorf("failed to read trip events: %w", err)
+		}
+		if len(events) == 0 {
+			p.projectedAt = time.Now()
+			return projected, nil
+		}
+
+		// A trip's listing is refreshed once per batch, with the position of
+		// its last event in it
+		positions := make(map[string]int64)
+		var order []string
+		for _, logged := range events {
+			if _, seen := positions[logged.Event.TripID]; !seen {
+				order = append(order, logged.Event.TripID)
+			}
+			positions[logged.Event.TripID] = logged.Position
+		}
+		for _, tripID := range order {
+			if err := p.refresh(ctx, tripID, positions[tripID]); err != nil {
+				return projected, err
+			}
+		}
+
+		checkpoint = events[len(events)-1].Position
+		if err := p.listings.SaveCheckpoint(ctx, checkpoint); err != nil {
+			return projected, fmt.Errorf("failed to save projection checkpoint: %w", err)
+		}
+		projected += len(events)
+	}
+}
+
+// refresh saves a trip's listing as the trip is saved now, or removes it
+// for trips no longer in the store
+func (p *TripProjector) refresh(ctx context.Context, tripID string, position int64) error {
+	trip, err := p.trips.GetByID(ctx, tripID)
+	if errors.Is(err, types.ErrTripNotFound) {
+		if err := p.listings.DeleteListing(ctx, tripID); err != nil {
+			return fmt.Errorf("failed to remove listing of trip %s: %w", tripID, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get trip %s: %w", tripID, err)
+	}
+
+	if err := p.listings.SaveListing(ctx, tripListing(trip, position)); err != nil {
+		return fmt.Errorf("failed to save listing of trip %s: %w", tripID, err)
+	}
+	return nil
+}
+
+// Rebuild drops the read model and projects every event in the log again.
+// Trip lists are served from the read model as it fills.
+func (p *TripProjector) Rebuild(ctx context.Context) (int, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if err := p.listings.Reset(ctx); err != nil {
+		return 0, fmt.Errorf("failed to reset trip listings: %w", err)
+	}
+	projected, err := p.project(ctx)
+	if err != nil {
+		return projected, err
+	}
+
+	p.rebuiltAt = time.Now()
+	p.logger.WithContext(ctx).WithFields(logger.Fields{"projected": projected}).Info("Trip listings rebuilt")
+	return projected, nil
+}
+
+// Lag reports how far the projection is behind the log
+func (p *TripProjector) Lag(ctx context.Context) (*ProjectionLag, error) {
+	checkpoint, err := p.listings.GetCheckpoint(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projection checkpoint: %w", err)
+	}
+	latest, err := p.feed.LatestPosition(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest trip event: %w", err)
+	}
+
+	lag := &ProjectionLag{
+		Checkpoint:     checkpoint,
+		LatestPosition: latest,
+	}
+	if latest > checkpoint {
+		lag.EventsBehind = latest - checkpoint
+		oldest, err := p.feed.EventsAfter(ctx, checkpoint, 1)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read trip events: %w", err)
+		}
+		if len(oldest) > 0 {
+			lag.LagSeconds = time.Since(oldest[0].Event.Timestamp).Seconds()
+		}
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if !p.projectedAt.IsZero() {
+		projectedAt := p.projectedAt
+		lag.ProjectedAt = &projectedAt
+	}
+	if !p.rebuiltAt.IsZero() {
+		rebuiltAt := p.rebuiltAt
+		lag.RebuiltAt = &rebuiltAt
+	}
+	return lag, nil
+}
+
+// Probe fails while the oldest unprojected event is older than MaxLag, for
+// the service's health check
+func (p *TripProjector) Probe(ctx context.Context) error {
+	lag, err := p.Lag(ctx)
+	if err != nil {
+		return err
+	}
+	if p.config.MaxLag > 0 && lag.LagSeconds > p.config.MaxLag.Seconds() {
+		return fmt.Errorf("trip listings are %d events and %.0fs behind", lag.EventsBehind, lag.LagSeconds)
+	}
+	return nil
+}
+
+// Start projects new events every interval until ctx is cancelled
+func (p *TripProjector) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := p.Project(ctx); err != nil && ctx.Err() == nil {
+				p.logger.WithContext(ctx).WithError(err).Warn("Trip listing projection failed")
+			}
+		}
+	}
+}
+
+// ArchiveStore wraps the trip archive store so archived trips leave the
+// trip lists and rehydrated trips return to them
+func (p *TripProjector) ArchiveStore(trips TripArchiveStore) TripArchiveStore {
+	return &listingArchiveStore{TripArchiveStore: trips, listings: p.listings}
+}
+
+// listingArchiveStore keeps the trip listings in step with the archive
+type listingArchiveStore struct {
+	TripArchiveStore
+	listings types.TripListingStore
+}
+
+func (s *listingArchiveStore) Delete(ctx context.Context, ids []string) error {
+	if err := s.TripArchiveStore.Delete(ctx, ids); err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := s.listings.DeleteListing(ctx, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *listingArchiveStore) Restore(ctx context.Context, trip *models.Trip) error {
+	if err := s.TripArchiveStore.Restore(ctx, trip); err != nil {
+		return err
+	}
+	return s.listings.SaveListing(ctx, tripListing(trip, 0))
+}
+
+// tripListing denormalizes a trip into its listing
+func tripListing(trip *models.Trip, position int64) *types.TripListing {
+	listing := &types.TripListing{
+		TripID:      trip.ID,
+		RiderID:     trip.RiderID,
+		Status:      trip.Status,
+		CityID:      trip.CityID,
+		RequestedAt: trip.RequestedAt,
+		Trip:        trip,
+		Position:    position,
+	}
+	if trip.DriverID != nil {
+		listing.DriverID = *trip.DriverID
+	}
+	return listing
+}
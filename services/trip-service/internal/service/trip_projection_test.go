@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rideshare-platform/services/trip-service/internal/repository"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+)
+
+func TestTripProjector_ServesTripLists(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("test", "info")
+	store := repository.NewMemoryTripStore()
+	tripEvents := repository.NewMemoryTripEventLog()
+	listings := repository.NewMemoryTripListingStore()
+	trips := NewTripService(store, log)
+	trips.SetEventLog(tripEvents)
+	trips.SetReadModel(listings)
+	projector := NewTripProjector(tripEvents, store, listings, ProjectionConfig{BatchSize: 2, MaxLag: time.Hour}, log)
+
+	var requested []*models.Trip
+	for i := 0; i < 3; i++ {
+		trip, err := trips.CreateTrip(ctx, &CreateTripRequest{
+			RiderID:             "rider-1",
+			PickupLocation:      models.Location{Latitude: 41.008237, Longitude: 28.978359},
+			DestinationLocation: models.Location{Latitude: 41.042211, Longitude: 29.008311},
+			RideType:            "standard",
+			EstimatedFare:       20,
+			RequestedAt:         time.Now().Add(time.Duration(i) * time.Minute),
+		})
+		require.NoError(t, err)
+		requested = append(requested, trip)
+	}
+
+	// Lists trail the trip store until the events are projected
+	listed, err := trips.GetRiderTrips(ctx, "rider-1")
+	require.NoError(t, err)
+	assert.Empty(t, listed)
+	lag, err := projector.Lag(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), lag.EventsBehind)
+	assert.NoError(t, projector.Probe(ctx))
+
+	projected, err := projector.Project(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, projected)
+	listed, err = trips.GetRiderTrips(ctx, "rider-1")
+	require.NoError(t, err)
+	require.Len(t, listed, 3)
+	assert.Equal(t, requested[2].ID, listed[0].ID, "most recently requested first")
+
+	_, err = trips.AcceptTrip(ctx, requested[0].ID, "driver-1")
+	require.NoError(t, err)
+	_, err = projector.Project(ctx)
+	require.NoError(t, err)
+
+	listed, err = trips.GetDriverTrips(ctx, "driver-1")
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, requested[0].ID, listed[0].ID)
+	listed, err = trips.GetTripsByStatus(ctx, string(models.TripStatusRequested))
+	require.NoError(t, err)
+	assert.Len(t, listed, 2)
+
+	lag, err = projector.Lag(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), lag.EventsBehind)
+	assert.Equal(t, int64(4), lag.Checkpoint)
+
+	// Rebuilding projects the whole log again
+	projected, err = projector.Rebuild(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 4, projected)
+	listed, err = trips.GetRiderTrips(ctx, "rider-1")
+	require.NoError(t, err)
+	assert.Len(t, listed, 3)
+
+	// Archived trips leave the lists
+	require.NoError(t, projector.ArchiveStore(store).Delete(ctx, []string{requested[1].ID}))
+	listed, err = trips.GetRiderTrips(ctx, "rider-1")
+	require.NoError(t, err)
+	assert.Len(t, listed, 2)
+}
+
+func TestTripProjector_ProbeFailsWhenBehind(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewLogger("test", "info")
+	store := repository.NewMemoryTripStore()
+	tripEvents := repository.NewMemoryTripEventLog()
+	projector := NewTripProjector(tripEvents, store, repository.NewMemoryTripListingStore(), ProjectionConfig{MaxLag: time.Minute}, log)
+
+	event := models.NewTripEvent("trip-1", "trip_requested", nil, nil)
+	event.Timestamp = time.Now().Add(-5 * time.Minute)
+	require.NoError(t, tripEvents.SaveEvent(ctx, event))
+
+	lag, err := projector.Lag(ctx)
+	require.NoError(t, err)
+	assert.InDelta(t, 300, lag.LagSeconds, 5)
+	assert.Error(t, projector.Probe(ctx))
+
+	// Events of trips no longer in the store are skipped
+	_, err = projector.Project(ctx)
+	require.NoError(t, err)
+	assert.NoError(t, projector.Probe(ctx))
+}
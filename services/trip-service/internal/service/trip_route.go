@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+
+	"github.com/rideshare-platform/shared/models"
+)
+
+// TripRoute is the route geo-service recorded from the driver's locations during a trip
+type TripRoute struct {
+	Points          []models.Location
+	DistanceKm      float64
+	DurationSeconds int
+}
+
+// RouteLookup fetches a trip's recorded route. It returns nil when no route
+// was recorded, for example when the driver's app sent no locations.
+type RouteLookup interface {
+	GetTripRoute(ctx context.Context, tripID string) (*TripRoute, error)
+}
+
+// usableRoute reports whether a recorded route covers enough ground to
+// replace the distance reported by the driver's app
+func usableRoute(route *TripRoute) bool {
+	return route != nil && len(route.Points) >= 2 && route.DistanceKm > 0
+}
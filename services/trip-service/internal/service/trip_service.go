@@ -2,13 +2,23 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/analytics"
+	"github.com/rideshare-platform/shared/city"
+	"github.com/rideshare-platform/shared/currency"
 	"github.com/rideshare-platform/shared/logger"
 	"github.com/rideshare-platform/shared/models"
+	"github.com/rideshare-platform/shared/pagination"
 )
 
+// ErrInvalidTripTransition is returned when a trip's current status does not
+// allow the requested change
+var ErrInvalidTripTransition = errors.New("invalid trip status transition")
+
 // TripRepositoryInterface defines the repository interface for trips
 type TripRepositoryInterface interface {
 	Create(ctx context.Context, trip *models.Trip) error
@@ -16,12 +26,34 @@ type TripRepositoryInterface interface {
 	Update(ctx context.Context, trip *models.Trip) error
 	GetByRiderID(ctx context.Context, riderID string) ([]*models.Trip, error)
 	GetByDriverID(ctx context.Context, driverID string) ([]*models.Trip, error)
+	GetByStatus(ctx context.Context, status models.TripStatus) ([]*models.Trip, error)
 }
 
 // TripService handles trip business logic
 type TripService struct {
-	tripRepo TripRepositoryInterface
-	logger   *logger.Logger
+	tripRepo  TripRepositoryInterface
+	routes    RouteLookup
+	fares     TripFarePricer
+	events    TripEventLog
+	holds     TripFareHolds
+	analytics *analytics.Recorder
+	cities    *city.Registry
+	logger    *logger.Logger
+
+	// defaultCurrency is charged when a trip's quote names no currency
+	defaultCurrency string
+
+	// Share links are signed with shareSecret and live for shareTTL by default
+	shareSecret []byte
+	shareTTL    time.Duration
+
+	// Idempotency keys of trip requests are remembered for idempotencyTTL
+	idempotency    types.TripIdempotencyStore
+	idempotencyTTL time.Duration
+
+	// listings is the read model rider, driver and status lists are served
+	// from; without it they are read from the trip store
+	listings types.TripListingStore
 }
 
 // NewTripService creates a new trip service
@@ -32,6 +64,18 @@ func NewTripService(tripRepo TripRepositoryInterface, logger *logger.Logger) *Tr
 	}
 }
 
+// SetRouteLookup attaches the geo-service client used to record the route a
+// trip actually took and the distance measured along it
+func (s *TripService) SetRouteLookup(routes RouteLookup) {
+	s.routes = routes
+}
+
+// SetCities scopes new trips to the configured cities. Without them trips
+// carry only the city they were requested with.
+func (s *TripService) SetCities(cities *city.Registry) {
+	s.cities = cities
+}
+
 // CreateTripRequest represents a trip creation request
 type CreateTripRequest struct {
 	RiderID             string          `json:"rider_id"`
@@ -39,7 +83,12 @@ type CreateTripRequest struct {
 	DestinationLocation models.Location `json:"destination_location"`
 	RideType            string          `json:"ride_type"`
 	EstimatedFare       float64         `json:"estimated_fare"`
+	Currency            string          `json:"currency"`         // currency the estimate was quoted in
+	SurgeMultiplier     float64         `json:"surge_multiplier"` // quoted with the estimate, locked for the final fare
 	RequestedAt         time.Time       `json:"requested_at"`
+	ScheduledFor        *time.Time      `json:"scheduled_for,omitempty"`
+	CityID              string          `json:"city_id,omitempty"` // resolved from the pickup location when empty
+	AccessibilityNeeds  []string        `json:"accessibility_needs,omitempty"`
 }
 
 // Location represents a geographic location with address
@@ -56,6 +105,21 @@ func (s *TripService) CreateTrip(ctx context.Context, req *CreateTripRequest) (*
 		return nil, fmt.Errorf("invalid request: %w", err)
 	}
 
+	cityID, err := s.cities.Resolve(req.CityID, req.PickupLocation)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	tripCurrency, err := s.tripCurrency(req.Currency, cityID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	requestedAt := req.RequestedAt
+	if requestedAt.IsZero() {
+		requestedAt = time.Now()
+	}
+
 	// Create trip
 	trip := &models.Trip{
 		ID:      generateTripID(),
@@ -72,14 +136,21 @@ func (s *TripService) CreateTrip(ctx context.Context, req *CreateTripRequest) (*
 			Timestamp: time.Now(),
 		},
 		EstimatedFareCents: func() *int64 {
-			cents := int64(req.EstimatedFare * 100)
+			cents := currency.ToMinor(req.EstimatedFare, tripCurrency)
 			return &cents
 		}(),
-		Currency:       "USD",
-		PassengerCount: 1,
-		RequestedAt:    req.RequestedAt,
-		CreatedAt:      time.Now(),
-		UpdatedAt:      time.Now(),
+		Currency:           tripCurrency,
+		CityID:             cityID,
+		AccessibilityNeeds: accessibilityNeeds(req),
+		PassengerCount:     1,
+		RequestedAt:        requestedAt,
+		ScheduledFor:       req.ScheduledFor,
+		CreatedAt:          time.Now(),
+		UpdatedAt:          time.Now(),
+	}
+
+	if req.SurgeMultiplier > 0 {
+		trip.LockSurgeMultiplier(req.SurgeMultiplier)
 	}
 
 	// Save to database
@@ -88,9 +159,17 @@ func (s *TripService) CreateTrip(ctx context.Context, req *CreateTripRequest) (*
 		return nil, fmt.Errorf("failed to create trip: %w", err)
 	}
 
+	s.recordEvent(ctx, trip, types.EventTripRequested, map[string]interface{}{
+		"pickup_location":      trip.PickupLocation,
+		"destination_location": trip.Destination,
+		"ride_type":            req.RideType,
+	})
+	s.recordTrip(trip, trip.CreatedAt)
+
 	s.logger.WithContext(ctx).WithFields(logger.Fields{
 		"trip_id":  trip.ID,
 		"rider_id": trip.RiderID,
+		"city_id":  trip.CityID,
 	}).Info("Trip created successfully")
 
 	return trip, nil
@@ -130,7 +209,7 @@ func (s *TripService) AcceptTrip(ctx context.Context, tripID, driverID string) (
 
 	// Validate trip can be accepted
 	if trip.Status != models.TripStatusRequested {
-		return nil, fmt.Errorf("trip cannot be accepted, current status: %s", trip.Status)
+		return nil, fmt.Errorf("%w: trip cannot be accepted, current status: %s", ErrInvalidTripTransition, trip.Status)
 	}
 
 	// Update trip
@@ -145,6 +224,10 @@ func (s *TripService) AcceptTrip(ctx context.Context, tripID, driverID string) (
 		return nil, fmt.Errorf("failed to accept trip: %w", err)
 	}
 
+	s.recordEvent(ctx, trip, types.EventDriverMatched, map[string]interface{}{
+		"driver_id": driverID,
+	})
+
 	s.logger.WithContext(ctx).WithFields(logger.Fields{
 		"trip_id":   trip.ID,
 		"driver_id": driverID,
@@ -165,7 +248,7 @@ func (s *TripService) StartTrip(ctx context.Context, tripID string) (*models.Tri
 	}
 
 	if trip.Status != models.TripStatusMatched {
-		return nil, fmt.Errorf("trip cannot be started, current status: %s", trip.Status)
+		return nil, fmt.Errorf("%w: trip cannot be started, current status: %s", ErrInvalidTripTransition, trip.Status)
 	}
 
 	trip.Status = models.TripStatusTripStarted
@@ -178,6 +261,9 @@ func (s *TripService) StartTrip(ctx context.Context, tripID string) (*models.Tri
 		return nil, fmt.Errorf("failed to start trip: %w", err)
 	}
 
+	s.recordEvent(ctx, trip, types.EventTripStarted, nil)
+	s.holdFare(ctx, trip)
+
 	s.logger.WithContext(ctx).WithFields(logger.Fields{
 		"trip_id": trip.ID,
 	}).Info("Trip started successfully")
@@ -185,7 +271,9 @@ func (s *TripService) StartTrip(ctx context.Context, tripID string) (*models.Tri
 	return trip, nil
 }
 
-// CompleteTrip marks a trip as completed
+// CompleteTrip marks a trip as completed and charges it for its actual
+// distance, duration and waiting time. finalFare is charged instead if
+// pricing-service cannot price the trip; zero charges the estimate.
 func (s *TripService) CompleteTrip(ctx context.Context, tripID string, finalFare float64) (*models.Trip, error) {
 	if tripID == "" {
 		return nil, fmt.Errorf("trip ID is required")
@@ -200,31 +288,77 @@ func (s *TripService) CompleteTrip(ctx context.Context, tripID string, finalFare
 	}
 
 	if trip.Status != models.TripStatusTripStarted {
-		return nil, fmt.Errorf("trip cannot be completed, current status: %s", trip.Status)
+		return nil, fmt.Errorf("%w: trip cannot be completed, current status: %s", ErrInvalidTripTransition, trip.Status)
 	}
 
 	trip.Status = models.TripStatusCompleted
-	finalFareCents := int64(finalFare * 100)
-	trip.ActualFareCents = &finalFareCents
 	now := time.Now()
 	trip.CompletedAt = &now
 	trip.UpdatedAt = now
+	if trip.StartedAt != nil {
+		trip.SetActualDuration(int(now.Sub(*trip.StartedAt).Seconds()))
+	}
+	s.applyRecordedRoute(ctx, trip)
+	trip.SetFareBreakdown(s.calculateActualFare(ctx, trip, finalFare))
 
 	if err := s.tripRepo.Update(ctx, trip); err != nil {
 		s.logger.WithContext(ctx).WithError(err).Error("Failed to complete trip")
 		return nil, fmt.Errorf("failed to complete trip: %w", err)
 	}
 
+	s.recordEvent(ctx, trip, types.EventTripCompleted, map[string]interface{}{
+		"final_fare_cents": trip.FareBreakdown.Total.Amount,
+	})
+	s.recordTrip(trip, now)
+	s.captureFare(ctx, trip)
+
 	s.logger.WithContext(ctx).WithFields(logger.Fields{
 		"trip_id":    trip.ID,
-		"final_fare": finalFare,
+		"final_fare": trip.FareBreakdown.Total.ToFloat64(),
 	}).Info("Trip completed successfully")
 
 	return trip, nil
 }
 
+// applyRecordedRoute stores the route recorded during the trip and the
+// distance measured along it. Trips without a usable route are left as they are.
+func (s *TripService) applyRecordedRoute(ctx context.Context, trip *models.Trip) {
+	if s.routes == nil {
+		return
+	}
+
+	recorded, err := s.routes.GetTripRoute(ctx, trip.ID)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+			"trip_id": trip.ID,
+		}).Warn("Failed to get recorded route")
+		return
+	}
+	if !usableRoute(recorded) {
+		return
+	}
+
+	points := append([]models.Location(nil), recorded.Points...)
+	trip.ActualRoute = &points
+	trip.SetActualDistance(recorded.DistanceKm)
+}
+
 // CancelTrip cancels a trip
 func (s *TripService) CancelTrip(ctx context.Context, tripID, reason string) (*models.Trip, error) {
+	return s.cancelTrip(ctx, tripID, reason, "", "")
+}
+
+// ForceCancelTrip cancels an active trip on behalf of the platform, as an
+// operator does for a stuck or fraudulent trip. actorID names the operator
+// in the trip's event log.
+func (s *TripService) ForceCancelTrip(ctx context.Context, tripID, reason, actorID string) (*models.Trip, error) {
+	if actorID == "" {
+		return nil, fmt.Errorf("actor ID is required")
+	}
+	return s.cancelTrip(ctx, tripID, reason, "platform", actorID)
+}
+
+func (s *TripService) cancelTrip(ctx context.Context, tripID, reason, cancelledBy, actorID string) (*models.Trip, error) {
 	if tripID == "" {
 		return nil, fmt.Errorf("trip ID is required")
 	}
@@ -238,11 +372,15 @@ func (s *TripService) CancelTrip(ctx context.Context, tripID, reason string) (*m
 	}
 
 	if trip.Status == models.TripStatusCompleted || trip.Status == models.TripStatusCancelled {
-		return nil, fmt.Errorf("trip cannot be cancelled, current status: %s", trip.Status)
+		return nil, fmt.Errorf("%w: trip cannot be cancelled, current status: %s", ErrInvalidTripTransition, trip.Status)
 	}
 
+	started := trip.StartedAt != nil
 	trip.Status = models.TripStatusCancelled
 	trip.CancellationReason = &reason
+	if cancelledBy != "" {
+		trip.CancelledBy = &cancelledBy
+	}
 	trip.UpdatedAt = time.Now()
 
 	if err := s.tripRepo.Update(ctx, trip); err != nil {
@@ -250,20 +388,80 @@ func (s *TripService) CancelTrip(ctx context.Context, tripID, reason string) (*m
 		return nil, fmt.Errorf("failed to cancel trip: %w", err)
 	}
 
+	data := map[string]interface{}{
+		"reason": reason,
+	}
+	if cancelledBy != "" {
+		data["cancelled_by"] = cancelledBy
+		data["actor_id"] = actorID
+	}
+	s.recordEvent(ctx, trip, types.EventTripCancelled, data)
+	s.recordTrip(trip, trip.UpdatedAt)
+	if started {
+		s.releaseFare(ctx, trip)
+	}
+
 	s.logger.WithContext(ctx).WithFields(logger.Fields{
-		"trip_id": trip.ID,
-		"reason":  reason,
+		"trip_id":      trip.ID,
+		"reason":       reason,
+		"cancelled_by": cancelledBy,
+		"actor_id":     actorID,
 	}).Info("Trip cancelled successfully")
 
 	return trip, nil
 }
 
+// UpdateTripLocation records the driver's location during an active trip.
+// Once the trip has started, locations are added to its route.
+func (s *TripService) UpdateTripLocation(ctx context.Context, tripID string, location models.Location) (*models.Trip, error) {
+	if tripID == "" {
+		return nil, fmt.Errorf("trip ID is required")
+	}
+	if !location.IsValid() {
+		return nil, fmt.Errorf("invalid location coordinates")
+	}
+
+	trip, err := s.tripRepo.GetByID(ctx, tripID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trip: %w", err)
+	}
+
+	if !trip.IsActive() || trip.Status == models.TripStatusRequested {
+		return nil, fmt.Errorf("%w: trip location cannot be updated, current status: %s", ErrInvalidTripTransition, trip.Status)
+	}
+
+	if location.Timestamp.IsZero() {
+		location.Timestamp = time.Now()
+	}
+	trip.DriverLocation = &location
+	if trip.Status == models.TripStatusTripStarted || trip.Status == models.TripStatusInProgress {
+		trip.AddRoutePoint(location)
+	} else {
+		trip.UpdatedAt = time.Now()
+	}
+
+	if err := s.tripRepo.Update(ctx, trip); err != nil {
+		s.logger.WithContext(ctx).WithError(err).Error("Failed to update trip location")
+		return nil, fmt.Errorf("failed to update trip location: %w", err)
+	}
+
+	s.recordEvent(ctx, trip, types.EventLocationUpdate, map[string]interface{}{
+		"location": location,
+	})
+
+	return trip, nil
+}
+
 // GetRiderTrips retrieves all trips for a rider
 func (s *TripService) GetRiderTrips(ctx context.Context, riderID string) ([]*models.Trip, error) {
 	if riderID == "" {
 		return nil, fmt.Errorf("rider ID is required")
 	}
 
+	if s.listings != nil {
+		return s.listTrips(ctx, types.TripListingFilter{RiderID: riderID})
+	}
+
 	trips, err := s.tripRepo.GetByRiderID(ctx, riderID)
 	if err != nil {
 		s.logger.WithContext(ctx).WithError(err).Error("Failed to get rider trips")
@@ -279,6 +477,10 @@ func (s *TripService) GetDriverTrips(ctx context.Context, driverID string) ([]*m
 		return nil, fmt.Errorf("driver ID is required")
 	}
 
+	if s.listings != nil {
+		return s.listTrips(ctx, types.TripListingFilter{DriverID: driverID})
+	}
+
 	trips, err := s.tripRepo.GetByDriverID(ctx, driverID)
 	if err != nil {
 		s.logger.WithContext(ctx).WithError(err).Error("Failed to get driver trips")
@@ -288,6 +490,105 @@ func (s *TripService) GetDriverTrips(ctx context.Context, driverID string) ([]*m
 	return trips, nil
 }
 
+// GetTripsByStatus retrieves all trips in a status
+func (s *TripService) GetTripsByStatus(ctx context.Context, status string) ([]*models.Trip, error) {
+	if !models.IsValidTripStatus(status) {
+		return nil, fmt.Errorf("invalid trip status: %s", status)
+	}
+
+	if s.listings != nil {
+		return s.listTrips(ctx, types.TripListingFilter{Status: models.TripStatus(status)})
+	}
+
+	trips, err := s.tripRepo.GetByStatus(ctx, models.TripStatus(status))
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Error("Failed to get trips by status")
+		return nil, fmt.Errorf("failed to get trips by status: %w", err)
+	}
+
+	return trips, nil
+}
+
+// SetReadModel serves rider, driver and status trip lists from the listing
+// read model a TripProjector keeps, which trails the trip store by the
+// projection's lag
+func (s *TripService) SetReadModel(listings types.TripListingStore) {
+	s.listings = listings
+}
+
+// listTrips returns the trips of the listings matching filter
+func (s *TripService) listTrips(ctx context.Context, filter types.TripListingFilter) ([]*models.Trip, error) {
+	listings, err := s.listings.ListListings(ctx, filter)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Error("Failed to list trips")
+		return nil, fmt.Errorf("failed to list trips: %w", err)
+	}
+
+	trips := make([]*models.Trip, 0, len(listings))
+	for _, listing := range listings {
+		trips = append(trips, listing.Trip)
+	}
+	return trips, nil
+}
+
+// ActiveTripFilter narrows the active trips listed for operators. Empty
+// fields match every trip.
+type ActiveTripFilter struct {
+	Status   models.TripStatus
+	RiderID  string
+	DriverID string
+	CityID   string
+	Page     pagination.Request
+}
+
+// activeTripStatuses are the statuses of trips that have not yet ended
+var activeTripStatuses = []models.TripStatus{
+	models.TripStatusRequested,
+	models.TripStatusMatched,
+	models.TripStatusDriverAssigned,
+	models.TripStatusDriverArriving,
+	models.TripStatusDriverArrived,
+	models.TripStatusTripStarted,
+	models.TripStatusInProgress,
+}
+
+// ListActiveTrips returns a page of the trips in progress that match filter,
+// oldest request first unless the page asks for another sort
+func (s *TripService) ListActiveTrips(ctx context.Context, filter ActiveTripFilter) (*pagination.Page[*models.Trip], error) {
+	statuses := activeTripStatuses
+	if filter.Status != "" {
+		if !(&models.Trip{Status: filter.Status}).IsActive() {
+			return nil, fmt.Errorf("%s is not an active trip status", filter.Status)
+		}
+		statuses = []models.TripStatus{filter.Status}
+	}
+	filter.Page = ActiveTripListOptions.WithDefaults(filter.Page)
+
+	var matched []*models.Trip
+	for _, status := range statuses {
+		trips, err := s.tripRepo.GetByStatus(ctx, status)
+		if err != nil {
+			s.logger.WithContext(ctx).WithError(err).Error("Failed to list active trips")
+			return nil, fmt.Errorf("failed to list active trips: %w", err)
+		}
+		for _, trip := range trips {
+			if filter.RiderID != "" && trip.RiderID != filter.RiderID {
+				continue
+			}
+			if filter.DriverID != "" && (trip.DriverID == nil || *trip.DriverID != filter.DriverID) {
+				continue
+			}
+			if filter.CityID != "" && trip.CityID != city.Normalize(filter.CityID) {
+				continue
+			}
+			matched = append(matched, trip)
+		}
+	}
+
+	page := pagination.Paginate(matched, filter.Page, TripSortKey(filter.Page.Sort))
+	return &page, nil
+}
+
 // CalculateTripDuration calculates the duration of a completed trip
 func (s *TripService) CalculateTripDuration(trip *models.Trip) (time.Duration, error) {
 	if trip.Status != models.TripStatusCompleted {
@@ -332,6 +633,9 @@ func (s *TripService) validateCreateTripRequest(req *CreateTripRequest) error {
 		"premium":  true,
 		"xl":       true,
 		"pool":     true,
+		// Wheelchair-accessible rides are matched on the vehicle's
+		// feature rather than its type
+		models.RideTypeWAV: true,
 	}
 
 	if !validRideTypes[req.RideType] {
@@ -342,9 +646,28 @@ func (s *TripService) validateCreateTripRequest(req *CreateTripRequest) error {
 		return fmt.Errorf("estimated fare must be non-negative")
 	}
 
+	if req.SurgeMultiplier != 0 && req.SurgeMultiplier < 1 {
+		return fmt.Errorf("surge multiplier must be at least 1")
+	}
+
 	return nil
 }
 
+// accessibilityNeeds returns the rider's accessibility needs, which for a
+// WAV ride include a wheelchair-accessible vehicle
+func accessibilityNeeds(req *CreateTripRequest) []string {
+	needs := req.AccessibilityNeeds
+	if req.RideType != models.RideTypeWAV {
+		return needs
+	}
+	for _, need := range needs {
+		if need == string(models.VehicleFeatureWheelchairAccessible) {
+			return needs
+		}
+	}
+	return append(append([]string{}, needs...), string(models.VehicleFeatureWheelchairAccessible))
+}
+
 // generateTripID generates a unique trip ID
 func generateTripID() string {
 	return fmt.Sprintf("trip_%d", time.Now().UnixNano())
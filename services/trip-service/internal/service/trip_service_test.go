@@ -2,11 +2,17 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/rideshare-platform/services/trip-service/internal/repository"
+	"github.com/rideshare-platform/shared/city"
+	"github.com/rideshare-platform/shared/currency"
 	"github.com/rideshare-platform/shared/logger"
 	"github.com/rideshare-platform/shared/models"
+	"github.com/rideshare-platform/shared/pagination"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -50,6 +56,14 @@ func (m *MockTripRepository) GetByDriverID(ctx context.Context, driverID string)
 	return args.Get(0).([]*models.Trip), args.Error(1)
 }
 
+func (m *MockTripRepository) GetByStatus(ctx context.Context, status models.TripStatus) ([]*models.Trip, error) {
+	args := m.Called(ctx, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Trip), args.Error(1)
+}
+
 func TestTripService_CreateTrip(t *testing.T) {
 	mockRepo := new(MockTripRepository)
 	logger := logger.NewLogger("test", "info")
@@ -282,6 +296,319 @@ func TestTripService_AcceptTrip(t *testing.T) {
 	}
 }
 
+func TestTripService_ListActiveTrips(t *testing.T) {
+	mockRepo := new(MockTripRepository)
+	service := NewTripService(mockRepo, logger.NewLogger("test", "info"))
+	ctx := context.Background()
+
+	driverID := "driver1"
+	now := time.Now()
+	requested := []*models.Trip{
+		{ID: "trip2", RiderID: "rider1", Status: models.TripStatusRequested, RequestedAt: now},
+		{ID: "trip3", RiderID: "rider2", Status: models.TripStatusRequested, RequestedAt: now.Add(time.Minute), CityID: "istanbul"},
+	}
+	started := []*models.Trip{
+		{ID: "trip1", RiderID: "rider1", DriverID: &driverID, Status: models.TripStatusTripStarted, RequestedAt: now.Add(-time.Minute)},
+	}
+	for _, status := range activeTripStatuses {
+		switch status {
+		case models.TripStatusRequested:
+			mockRepo.On("GetByStatus", ctx, status).Return(requested, nil)
+		case models.TripStatusTripStarted:
+			mockRepo.On("GetByStatus", ctx, status).Return(started, nil)
+		default:
+			mockRepo.On("GetByStatus", ctx, status).Return([]*models.Trip{}, nil)
+		}
+	}
+
+	trips, err := service.ListActiveTrips(ctx, ActiveTripFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), trips.TotalEstimate)
+	assert.Empty(t, trips.NextCursor)
+	if assert.Len(t, trips.Items, 3) {
+		assert.Equal(t, "trip1", trips.Items[0].ID)
+		assert.Equal(t, "trip3", trips.Items[2].ID)
+	}
+
+	first, err := service.ListActiveTrips(ctx, ActiveTripFilter{RiderID: "rider1", Page: pagination.Request{Limit: 1}})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), first.TotalEstimate)
+	if assert.Len(t, first.Items, 1) {
+		assert.Equal(t, "trip1", first.Items[0].ID)
+	}
+	page, err := pagination.NewRequest(1, first.NextCursor, "", ActiveTripListOptions)
+	assert.NoError(t, err)
+	trips, err = service.ListActiveTrips(ctx, ActiveTripFilter{RiderID: "rider1", Page: page})
+	assert.NoError(t, err)
+	if assert.Len(t, trips.Items, 1) {
+		assert.Equal(t, "trip2", trips.Items[0].ID)
+	}
+	assert.Empty(t, trips.NextCursor)
+
+	page, err = pagination.NewRequest(0, "", "-requested_at", ActiveTripListOptions)
+	assert.NoError(t, err)
+	trips, err = service.ListActiveTrips(ctx, ActiveTripFilter{Page: page})
+	assert.NoError(t, err)
+	if assert.Len(t, trips.Items, 3) {
+		assert.Equal(t, "trip3", trips.Items[0].ID)
+	}
+
+	trips, err = service.ListActiveTrips(ctx, ActiveTripFilter{DriverID: driverID})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), trips.TotalEstimate)
+	assert.Equal(t, "trip1", trips.Items[0].ID)
+
+	trips, err = service.ListActiveTrips(ctx, ActiveTripFilter{CityID: "Istanbul"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), trips.TotalEstimate)
+	assert.Equal(t, "trip3", trips.Items[0].ID)
+
+	_, err = service.ListActiveTrips(ctx, ActiveTripFilter{Status: models.TripStatusCompleted})
+	assert.Error(t, err)
+}
+
+func TestTripService_ForceCancelTrip(t *testing.T) {
+	mockRepo := new(MockTripRepository)
+	service := NewTripService(mockRepo, logger.NewLogger("test", "info"))
+	ctx := context.Background()
+
+	trip := &models.Trip{ID: "trip123", RiderID: "rider123", Status: models.TripStatusInProgress}
+	completed := &models.Trip{ID: "trip456", RiderID: "rider123", Status: models.TripStatusCompleted}
+	mockRepo.On("GetByID", ctx, "trip123").Return(trip, nil)
+	mockRepo.On("GetByID", ctx, "trip456").Return(completed, nil)
+	mockRepo.On("Update", ctx, mock.AnythingOfType("*models.Trip")).Return(nil)
+
+	_, err := service.ForceCancelTrip(ctx, "trip123", "fraud suspected", "")
+	assert.Error(t, err)
+
+	result, err := service.ForceCancelTrip(ctx, "trip123", "fraud suspected", "ops1")
+	assert.NoError(t, err)
+	assert.Equal(t, models.TripStatusCancelled, result.Status)
+	if assert.NotNil(t, result.CancelledBy) {
+		assert.Equal(t, "platform", *result.CancelledBy)
+	}
+
+	_, err = service.ForceCancelTrip(ctx, "trip456", "fraud suspected", "ops1")
+	assert.True(t, errors.Is(err, ErrInvalidTripTransition))
+}
+
+func TestTripService_CompleteTripUsesRecordedRoute(t *testing.T) {
+	mockRepo := new(MockTripRepository)
+	service := NewTripService(mockRepo, logger.NewLogger("test", "info"))
+	service.SetRouteLookup(&fakeRouteLookup{route: &TripRoute{
+		Points: []models.Location{
+			{Latitude: 40.700, Longitude: -74.000},
+			{Latitude: 40.710, Longitude: -74.000},
+		},
+		DistanceKm:      1.11,
+		DurationSeconds: 300,
+	}})
+	ctx := context.Background()
+
+	startedAt := time.Now().Add(-5 * time.Minute)
+	trip := &models.Trip{
+		ID:        "trip123",
+		RiderID:   "rider123",
+		Status:    models.TripStatusTripStarted,
+		StartedAt: &startedAt,
+	}
+	mockRepo.On("GetByID", ctx, "trip123").Return(trip, nil)
+	mockRepo.On("Update", ctx, mock.AnythingOfType("*models.Trip")).Return(nil)
+
+	result, err := service.CompleteTrip(ctx, "trip123", 12.5)
+	assert.NoError(t, err)
+	assert.Equal(t, models.TripStatusCompleted, result.Status)
+	if assert.NotNil(t, result.ActualRoute) {
+		assert.Len(t, *result.ActualRoute, 2)
+	}
+	if assert.NotNil(t, result.ActualDistanceKm) {
+		assert.Equal(t, 1.11, *result.ActualDistanceKm)
+	}
+	if assert.NotNil(t, result.ActualDurationSeconds) {
+		assert.InDelta(t, 300, *result.ActualDurationSeconds, 2)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+// fakeFarePricer records the completed trip it was asked to price
+type fakeFarePricer struct {
+	request *FinalFareRequest
+	err     error
+}
+
+func (f *fakeFarePricer) PriceCompletedTrip(ctx context.Context, req *FinalFareRequest) (*models.FareBreakdown, error) {
+	f.request = req
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &models.FareBreakdown{
+		BaseFare:        models.NewMoney(350, "USD"),
+		DistanceFare:    models.NewMoney(int64(req.DistanceKm*150), "USD"),
+		WaitingFare:     models.NewMoney(120, "USD"),
+		Total:           models.NewMoney(1845, "USD"),
+		SurgeMultiplier: req.SurgeMultiplier,
+		WaitingSeconds:  req.WaitingSeconds,
+	}, nil
+}
+
+func TestTripService_CompleteTripPricesActualFare(t *testing.T) {
+	ctx := context.Background()
+	newStartedTrip := func() *models.Trip {
+		arrivedAt := time.Now().Add(-25 * time.Minute)
+		startedAt := arrivedAt.Add(5 * time.Minute)
+		estimatedFare := int64(1500)
+		trip := &models.Trip{
+			ID:                 "trip123",
+			RiderID:            "rider123",
+			Status:             models.TripStatusTripStarted,
+			EstimatedFareCents: &estimatedFare,
+			Currency:           "USD",
+			DriverArrivedAt:    &arrivedAt,
+			StartedAt:          &startedAt,
+		}
+		trip.SetEstimatedDistance(8)
+		trip.LockSurgeMultiplier(1.5)
+		return trip
+	}
+
+	t.Run("priced_from_actual_trip", func(t *testing.T) {
+		mockRepo := new(MockTripRepository)
+		service := NewTripService(mockRepo, logger.NewLogger("test", "info"))
+		pricer := &fakeFarePricer{}
+		service.SetFarePricer(pricer)
+		service.SetRouteLookup(&fakeRouteLookup{route: &TripRoute{
+			Points:     []models.Location{{Latitude: 40.70, Longitude: -74.00}, {Latitude: 40.79, Longitude: -74.00}},
+			DistanceKm: 10.2,
+		}})
+		mockRepo.On("GetByID", ctx, "trip123").Return(newStartedTrip(), nil)
+		mockRepo.On("Update", ctx, mock.AnythingOfType("*models.Trip")).Return(nil)
+
+		result, err := service.CompleteTrip(ctx, "trip123", 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 10.2, pricer.request.DistanceKm, "priced on the recorded distance")
+		assert.Equal(t, 1.5, pricer.request.SurgeMultiplier, "priced at the surge locked at request time")
+		assert.Equal(t, 300, pricer.request.WaitingSeconds)
+		assert.InDelta(t, 1200, pricer.request.DurationSeconds, 2)
+
+		if assert.NotNil(t, result.FareBreakdown) {
+			assert.False(t, result.FareBreakdown.Estimated)
+			assert.Equal(t, int64(120), result.FareBreakdown.WaitingFare.Amount)
+		}
+		assert.Equal(t, int64(1845), *result.ActualFareCents)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("falls_back_when_pricing_unavailable", func(t *testing.T) {
+		mockRepo := new(MockTripRepository)
+		service := NewTripService(mockRepo, logger.NewLogger("test", "info"))
+		service.SetFarePricer(&fakeFarePricer{err: errors.New("pricing unavailable")})
+		mockRepo.On("GetByID", ctx, "trip123").Return(newStartedTrip(), nil)
+		mockRepo.On("Update", ctx, mock.AnythingOfType("*models.Trip")).Return(nil)
+
+		result, err := service.CompleteTrip(ctx, "trip123", 0)
+		assert.NoError(t, err)
+		assert.True(t, result.FareBreakdown.Estimated)
+		assert.Equal(t, int64(1500), *result.ActualFareCents, "the estimate is charged")
+
+		mockRepo.ExpectedCalls = nil
+		mockRepo.On("GetByID", ctx, "trip123").Return(newStartedTrip(), nil)
+		mockRepo.On("Update", ctx, mock.AnythingOfType("*models.Trip")).Return(nil)
+		result, err = service.CompleteTrip(ctx, "trip123", 17.25)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1725), *result.ActualFareCents, "the fare given by the caller is charged")
+	})
+
+	t.Run("falls_back_when_priced_in_another_currency", func(t *testing.T) {
+		mockRepo := new(MockTripRepository)
+		service := NewTripService(mockRepo, logger.NewLogger("test", "info"))
+		service.SetFarePricer(&fakeFarePricer{})
+		trip := newStartedTrip()
+		trip.Currency = "EUR"
+		mockRepo.On("GetByID", ctx, "trip123").Return(trip, nil)
+		mockRepo.On("Update", ctx, mock.AnythingOfType("*models.Trip")).Return(nil)
+
+		result, err := service.CompleteTrip(ctx, "trip123", 0)
+		assert.NoError(t, err)
+		assert.True(t, result.FareBreakdown.Estimated)
+		assert.Equal(t, "EUR", result.FareBreakdown.Total.Currency)
+		assert.Equal(t, int64(1500), *result.ActualFareCents, "the estimate is charged")
+	})
+}
+
+func TestTripService_CreateTripInQuotedCurrency(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockTripRepository)
+	mockRepo.On("Create", ctx, mock.AnythingOfType("*models.Trip")).Return(nil)
+	service := NewTripService(mockRepo, logger.NewLogger("test", "info"))
+	assert.NoError(t, service.SetDefaultCurrency("GBP"))
+
+	newRequest := func(quoted string) *CreateTripRequest {
+		return &CreateTripRequest{
+			RiderID:             "rider123",
+			PickupLocation:      models.Location{Latitude: 41.0082, Longitude: 28.9784},
+			DestinationLocation: models.Location{Latitude: 41.0422, Longitude: 29.0083},
+			RideType:            "standard",
+			EstimatedFare:       1250,
+			Currency:            quoted,
+		}
+	}
+
+	trip, err := service.CreateTrip(ctx, newRequest("jpy"))
+	assert.NoError(t, err)
+	assert.Equal(t, "JPY", trip.Currency)
+	assert.Equal(t, int64(1250), *trip.EstimatedFareCents, "yen have no minor unit")
+
+	trip, err = service.CreateTrip(ctx, newRequest(""))
+	assert.NoError(t, err)
+	assert.Equal(t, "GBP", trip.Currency)
+	assert.Equal(t, int64(125000), *trip.EstimatedFareCents)
+
+	_, err = service.CreateTrip(ctx, newRequest("XYZ"))
+	assert.True(t, errors.Is(err, currency.ErrUnsupported))
+}
+
+func TestTripService_CreateTripInCity(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockTripRepository)
+	mockRepo.On("Create", ctx, mock.AnythingOfType("*models.Trip")).Return(nil)
+	service := NewTripService(mockRepo, logger.NewLogger("test", "info"))
+	cities, err := city.NewRegistry(&city.Config{Cities: map[string]*city.City{
+		"istanbul": {Name: "Istanbul", Currency: "TRY", Center: models.Location{Latitude: 41.0082, Longitude: 28.9784}, RadiusKm: 60},
+		"london":   {Name: "London", Currency: "GBP", Center: models.Location{Latitude: 51.5074, Longitude: -0.1278}, RadiusKm: 50},
+	}})
+	assert.NoError(t, err)
+	service.SetCities(cities)
+
+	newRequest := func(cityID string, pickup models.Location) *CreateTripRequest {
+		return &CreateTripRequest{
+			RiderID:             "rider123",
+			PickupLocation:      pickup,
+			DestinationLocation: models.Location{Latitude: 41.0422, Longitude: 29.0083},
+			RideType:            "standard",
+			EstimatedFare:       120,
+			CityID:              cityID,
+		}
+	}
+
+	trip, err := service.CreateTrip(ctx, newRequest("", models.Location{Latitude: 41.0422, Longitude: 29.0083}))
+	assert.NoError(t, err)
+	assert.Equal(t, "istanbul", trip.CityID)
+	assert.Equal(t, "TRY", trip.Currency, "trips are charged in their city's currency")
+
+	trip, err = service.CreateTrip(ctx, newRequest("London", models.Location{Latitude: 51.5, Longitude: -0.12}))
+	assert.NoError(t, err)
+	assert.Equal(t, "london", trip.CityID)
+	assert.Equal(t, "GBP", trip.Currency)
+
+	trip, err = service.CreateTrip(ctx, newRequest("", models.Location{Latitude: 40.7128, Longitude: -74.0060}))
+	assert.NoError(t, err)
+	assert.Empty(t, trip.CityID)
+	assert.Equal(t, currency.Default, trip.Currency)
+
+	_, err = service.CreateTrip(ctx, newRequest("paris", models.Location{Latitude: 48.8566, Longitude: 2.3522}))
+	assert.True(t, errors.Is(err, city.ErrUnknownCity))
+}
+
 func TestTripService_CalculateTripDuration(t *testing.T) {
 	logger := logger.NewLogger("test", "info")
 	service := NewTripService(nil, logger)
@@ -380,3 +707,75 @@ func TestTripService_EstimateTripTime(t *testing.T) {
 		})
 	}
 }
+
+// fakeFareHolds records the fare holds, charges and releases asked for
+type fakeFareHolds struct {
+	calls []string
+	err   error
+}
+
+func (f *fakeFareHolds) HoldFare(ctx context.Context, tripID, riderID, driverID string, estimatedFare float64, currencyCode string) error {
+	f.calls = append(f.calls, fmt.Sprintf("hold %s %s %s %.2f %s", tripID, riderID, driverID, estimatedFare, currencyCode))
+	return f.err
+}
+
+func (f *fakeFareHolds) CaptureFare(ctx context.Context, tripID, riderID, driverID string, fare float64, currencyCode string) error {
+	f.calls = append(f.calls, fmt.Sprintf("capture %s %s %s %.2f %s", tripID, riderID, driverID, fare, currencyCode))
+	return f.err
+}
+
+func (f *fakeFareHolds) ReleaseFare(ctx context.Context, tripID string) error {
+	f.calls = append(f.calls, "release "+tripID)
+	return f.err
+}
+
+func TestTripService_HoldsFareWhileTripRuns(t *testing.T) {
+	ctx := context.Background()
+	store := repository.NewMemoryTripStore()
+	service := NewTripService(store, logger.NewLogger("test", "info"))
+	holds := &fakeFareHolds{}
+	service.SetFareHolds(holds)
+
+	newTrip := func() *models.Trip {
+		trip, err := service.CreateTrip(ctx, &CreateTripRequest{
+			RiderID:             "rider-1",
+			PickupLocation:      models.Location{Latitude: 41.008237, Longitude: 28.978359},
+			DestinationLocation: models.Location{Latitude: 41.042211, Longitude: 29.008311},
+			RideType:            "standard",
+			EstimatedFare:       20,
+			Currency:            "EUR",
+		})
+		assert.NoError(t, err)
+		_, err = service.AcceptTrip(ctx, trip.ID, "driver-1")
+		assert.NoError(t, err)
+		return trip
+	}
+
+	completed := newTrip()
+	_, err := service.StartTrip(ctx, completed.ID)
+	assert.NoError(t, err)
+	_, err = service.CompleteTrip(ctx, completed.ID, 23.4)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"hold " + completed.ID + " rider-1 driver-1 20.00 EUR",
+		"capture " + completed.ID + " rider-1 driver-1 23.40 EUR",
+	}, holds.calls)
+
+	holds.calls = nil
+	cancelled := newTrip()
+	_, err = service.CancelTrip(ctx, cancelled.ID, "rider changed plans")
+	assert.NoError(t, err)
+	assert.Empty(t, holds.calls, "trips cancelled before they start hold nothing")
+
+	// Trips go ahead when payment-service cannot hold their fare
+	holds.err = errors.New("payment-service unavailable")
+	started := newTrip()
+	_, err = service.StartTrip(ctx, started.ID)
+	assert.NoError(t, err)
+	_, err = service.CancelTrip(ctx, started.ID, "rider no longer needs the ride")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"hold " + started.ID + " rider-1 driver-1 20.00 EUR",
+		"release " + started.ID,
+	}, holds.calls)
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+)
+
+var (
+	// ErrTripSharingDisabled is returned when no share link secret is configured
+	ErrTripSharingDisabled = errors.New("trip sharing is not configured")
+	// ErrInvalidShareToken is returned for share tokens that are malformed,
+	// signed with another secret or expired
+	ErrInvalidShareToken = errors.New("share link is invalid or has expired")
+	// ErrTripNotOwned is returned when a rider shares another rider's trip
+	ErrTripNotOwned = errors.New("trip belongs to another rider")
+	// ErrTripNotShareable is returned when sharing a trip that has ended
+	ErrTripNotShareable = errors.New("only active trips can be shared")
+)
+
+const (
+	// DefaultShareLinkTTL is how long a share link lives when none is asked for
+	DefaultShareLinkTTL = 4 * time.Hour
+	// MaxShareLinkTTL bounds how long a share link can live
+	MaxShareLinkTTL = 24 * time.Hour
+)
+
+// TripShareLink is a signed, expiring token a rider hands to the people
+// following their trip
+type TripShareLink struct {
+	Token     string    `json:"token"`
+	TripID    string    `json:"trip_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// SharedTripView is what a share link reveals of a trip: its progress and
+// where the driver is, never the rider, fare or payment
+type SharedTripView struct {
+	TripID         string            `json:"trip_id"`
+	Status         models.TripStatus `json:"status"`
+	CancelledBy    *string           `json:"cancelled_by,omitempty"`
+	PickupLocation models.Location   `json:"pickup_location"`
+	Destination    models.Location   `json:"destination"`
+	DriverLocation *models.Location  `json:"driver_location,omitempty"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+	ExpiresAt      time.Time         `json:"expires_at"`
+	Ended          bool              `json:"ended"`
+}
+
+// SetShareLinks enables trip share links signed with secret. Links live for
+// defaultTTL unless the rider asks for another lifetime.
+func (s *TripService) SetShareLinks(secret []byte, defaultTTL time.Duration) {
+	if defaultTTL <= 0 || defaultTTL > MaxShareLinkTTL {
+		defaultTTL = DefaultShareLinkTTL
+	}
+	s.shareSecret = secret
+	s.shareTTL = defaultTTL
+}
+
+// CreateShareLink signs a link to an active trip of the rider's that expires
+// after ttl, or the default lifetime when ttl is zero
+func (s *TripService) CreateShareLink(ctx context.Context, tripID, riderID string, ttl time.Duration) (*TripShareLink, error) {
+	if len(s.shareSecret) == 0 {
+		return nil, ErrTripSharingDisabled
+	}
+	if tripID == "" || riderID == "" {
+		return nil, fmt.Errorf("trip ID and rider ID are required")
+	}
+	if ttl < 0 || ttl > MaxShareLinkTTL {
+		return nil, fmt.Errorf("share link lifetime must be at most %s", MaxShareLinkTTL)
+	}
+	if ttl == 0 {
+		ttl = s.shareTTL
+	}
+
+	trip, err := s.tripRepo.GetByID(ctx, tripID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trip: %w", err)
+	}
+	if trip.RiderID != riderID {
+		return nil, ErrTripNotOwned
+	}
+	if !trip.IsActive() {
+		return nil, ErrTripNotShareable
+	}
+
+	expiresAt := time.Now().Add(ttl).Truncate(time.Second)
+	s.logger.WithContext(ctx).WithFields(logger.Fields{
+		"trip_id":    tripID,
+		"expires_at": expiresAt,
+	}).Info("Trip share link created")
+
+	return &TripShareLink{
+		Token:     s.signShareToken(tripID, expiresAt),
+		TripID:    tripID,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// GetSharedTrip returns the view of the trip a share link points to. Links
+// keep working until they expire, showing how the trip ended.
+func (s *TripService) GetSharedTrip(ctx context.Context, token string) (*SharedTripView, error) {
+	tripID, expiresAt, err := s.verifyShareToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	trip, err := s.tripRepo.GetByID(ctx, tripID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trip: %w", err)
+	}
+
+	view := &SharedTripView{
+		TripID:         trip.ID,
+		Status:         trip.Status,
+		CancelledBy:    trip.CancelledBy,
+		PickupLocation: trip.PickupLocation,
+		Destination:    trip.Destination,
+		UpdatedAt:      trip.UpdatedAt,
+		ExpiresAt:      expiresAt,
+		Ended:          !trip.IsActive(),
+	}
+	if !view.Ended && trip.DriverLocation != nil {
+		location := *trip.DriverLocation
+		view.DriverLocation = &location
+	}
+	return view, nil
+}
+
+// signShareToken encodes the trip and expiry, then their signature:
+// base64url(trip_id.expires_unix).base64url(hmac_sha256)
+func (s *TripService) signShareToken(tripID string, expiresAt time.Time) string {
+	payload := tripID + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." +
+		base64.RawURLEncoding.EncodeToString(s.shareSignature(payload))
+}
+
+func (s *TripService) verifyShareToken(token string) (string, time.Time, error) {
+	if len(s.shareSecret) == 0 {
+		return "", time.Time{}, ErrTripSharingDisabled
+	}
+
+	encodedPayload, encodedSignature, found := strings.Cut(token, ".")
+	if !found {
+		return "", time.Time{}, ErrInvalidShareToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return "", time.Time{}, ErrInvalidShareToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, s.shareSignature(string(payload))) {
+		return "", time.Time{}, ErrInvalidShareToken
+	}
+
+	separator := strings.LastIndexByte(string(payload), '.')
+	if separator <= 0 {
+		return "", time.Time{}, ErrInvalidShareToken
+	}
+	tripID := string(payload[:separator])
+	expiresUnix, err := strconv.ParseInt(string(payload[separator+1:]), 10, 64)
+	if err != nil {
+		return "", time.Time{}, ErrInvalidShareToken
+	}
+	expiresAt := time.Unix(expiresUnix, 0)
+	if !time.Now().Before(expiresAt) {
+		return "", time.Time{}, ErrInvalidShareToken
+	}
+	return tripID, expiresAt, nil
+}
+
+func (s *TripService) shareSignature(payload string) []byte {
+	mac := hmac.New(sha256.New, s.shareSecret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
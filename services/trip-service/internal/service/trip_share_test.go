@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rideshare-platform/services/trip-service/internal/repository"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTripService_ShareLink(t *testing.T) {
+	ctx := context.Background()
+	trips := NewTripService(repository.NewMemoryTripStore(), logger.NewLogger("test", "info"))
+
+	trip, err := trips.CreateTrip(ctx, &CreateTripRequest{
+		RiderID:             "rider-1",
+		PickupLocation:      models.Location{Latitude: 41.008237, Longitude: 28.978359},
+		DestinationLocation: models.Location{Latitude: 41.042211, Longitude: 29.008311},
+		RideType:            "standard",
+		EstimatedFare:       20,
+		Currency:            "EUR",
+	})
+	require.NoError(t, err)
+
+	_, err = trips.CreateShareLink(ctx, trip.ID, "rider-1", 0)
+	assert.ErrorIs(t, err, ErrTripSharingDisabled)
+
+	trips.SetShareLinks([]byte("share-secret"), time.Hour)
+	_, err = trips.CreateShareLink(ctx, trip.ID, "rider-2", 0)
+	assert.ErrorIs(t, err, ErrTripNotOwned)
+
+	link, err := trips.CreateShareLink(ctx, trip.ID, "rider-1", 0)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), link.ExpiresAt, 2*time.Second)
+
+	_, err = trips.AcceptTrip(ctx, trip.ID, "driver-1")
+	require.NoError(t, err)
+	_, err = trips.UpdateTripLocation(ctx, trip.ID, models.Location{Latitude: 41.01, Longitude: 28.98})
+	require.NoError(t, err)
+
+	view, err := trips.GetSharedTrip(ctx, link.Token)
+	require.NoError(t, err)
+	assert.Equal(t, trip.ID, view.TripID)
+	assert.False(t, view.Ended)
+	require.NotNil(t, view.DriverLocation)
+	assert.Equal(t, 41.01, view.DriverLocation.Latitude)
+
+	// Once the trip ends the link still shows how, but not where the driver is
+	_, err = trips.CancelTrip(ctx, trip.ID, "changed plans")
+	require.NoError(t, err)
+	view, err = trips.GetSharedTrip(ctx, link.Token)
+	require.NoError(t, err)
+	assert.True(t, view.Ended)
+	assert.Nil(t, view.DriverLocation)
+
+	_, err = trips.CreateShareLink(ctx, trip.ID, "rider-1", 0)
+	assert.ErrorIs(t, err, ErrTripNotShareable)
+}
+
+func TestTripService_ShareTokenVerification(t *testing.T) {
+	trips := NewTripService(repository.NewMemoryTripStore(), logger.NewLogger("test", "info"))
+	trips.SetShareLinks([]byte("share-secret"), time.Hour)
+
+	token := trips.signShareToken("trip-1", time.Now().Add(time.Minute))
+	tripID, _, err := trips.verifyShareToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, "trip-1", tripID)
+
+	expired := trips.signShareToken("trip-1", time.Now().Add(-time.Second))
+	_, _, err = trips.verifyShareToken(expired)
+	assert.ErrorIs(t, err, ErrInvalidShareToken)
+
+	other := NewTripService(repository.NewMemoryTripStore(), logger.NewLogger("test", "info"))
+	other.SetShareLinks([]byte("another-secret"), time.Hour)
+	_, _, err = other.verifyShareToken(token)
+	assert.ErrorIs(t, err, ErrInvalidShareToken, "tokens signed with another secret are rejected")
+
+	for _, malformed := range []string{"", "no-signature", token + "x", "." + token} {
+		_, _, err = trips.verifyShareToken(malformed)
+		assert.ErrorIs(t, err, ErrInvalidShareToken, malformed)
+	}
+}
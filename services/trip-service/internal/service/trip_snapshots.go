@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+)
+
+// SnapshotConfig controls how often trip state is snapshotted
+type SnapshotConfig struct {
+	Frequency int // events recorded after a trip's latest snapshot before another is taken
+	Retain    int // snapshots kept per trip by compaction
+}
+
+// DefaultSnapshotConfig returns the default snapshot settings
+func DefaultSnapshotConfig() SnapshotConfig {
+	return SnapshotConfig{
+		Frequency: 50,
+		Retain:    2,
+	}
+}
+
+// TripSnapshotter rebuilds event-sourced trips from their latest snapshot
+// and the events recorded after it, instead of replaying every event of
+// long-lived trips. A snapshot is taken whenever a trip is read Frequency
+// events after its latest one, and by compaction for trips not read.
+type TripSnapshotter struct {
+	events    types.TripEventStore
+	snapshots types.TripSnapshotStore
+	config    SnapshotConfig
+	logger    *logger.Logger
+
+	// compactedAt is when the last compaction started; the next one looks
+	// only at trips with events since
+	mutex       sync.Mutex
+	compactedAt time.Time
+}
+
+// NewTripSnapshotter creates a trip snapshotter
+func NewTripSnapshotter(events types.TripEventStore, snapshots types.TripSnapshotStore, config SnapshotConfig, logger *logger.Logger) *TripSnapshotter {
+	if config.Frequency < 1 {
+		config.Frequency = DefaultSnapshotConfig().Frequency
+	}
+	if config.Retain < 1 {
+		config.Retain = 1
+	}
+	return &TripSnapshotter{
+		events:    events,
+		snapshots: snapshots,
+		config:    config,
+		logger:    logger,
+	}
+}
+
+// LoadTrip returns a trip's current state from its latest snapshot and the
+// events recorded after it, taking a new snapshot when Frequency or more
+// events were replayed
+func (s *TripSnapshotter) LoadTrip(ctx context.Context, tripID string) (*types.TripAggregate, error) {
+	if tripID == "" {
+		return nil, fmt.Errorf("trip ID is required")
+	}
+
+	trip := &types.TripAggregate{ID: tripID}
+	snapshot, err := s.snapshots.GetLatestSnapshot(ctx, tripID)
+	switch {
+	case err == nil:
+		// Events are applied to a copy, leaving the snapshot as it was saved
+		data, err := json.Marshal(snapshot.State)
+		if err != nil {
+			return nil, fmt.Errorf("failed to copy trip snapshot: %w", err)
+		}
+		trip = &types.TripAggregate{}
+		if err := json.Unmarshal(data, trip); err != nil {
+			return nil, fmt.Errorf("failed to copy trip snapshot: %w", err)
+		}
+	case !errors.Is(err, types.ErrSnapshotNotFound):
+		return nil, fmt.Errorf("failed to get trip snapshot: %w", err)
+	}
+
+	events, err := s.events.GetEventsAfterVersion(ctx, tripID, trip.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trip events: %w", err)
+	}
+	if snapshot == nil && len(events) == 0 {
+		return nil, types.ErrTripNotFound
+	}
+	for _, event := range events {
+		applyTripEvent(trip, event)
+	}
+
+	if len(events) >= s.config.Frequency {
+		if err := s.snapshot(ctx, trip); err != nil {
+			s.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+				"trip_id": tripID,
+				"version": trip.Version,
+			}).Warn("Failed to snapshot trip")
+		}
+	}
+	return trip, nil
+}
+
+// snapshot saves the trip's state as of its version
+func (s *TripSnapshotter) snapshot(ctx context.Context, trip *types.TripAggregate) error {
+	return s.snapshots.SaveSnapshot(ctx, &types.TripSnapshot{
+		TripID:    trip.ID,
+		Version:   trip.Version,
+		State:     trip,
+		CreatedAt: time.Now(),
+	})
+}
+
+// Compact snapshots the trips with events since the last compaction that
+// are Frequency or more events past their latest snapshot, and prunes their
+// snapshots down to the Retain latest. Events are kept as the record of
+// what happened. It returns how many trips were snapshotted.
+func (s *TripSnapshotter) Compact(ctx context.Context) (int, error) {
+	s.mutex.Lock()
+	since := s.compactedAt
+	s.mutex.Unlock()
+	startedAt := time.Now()
+
+	versions, err := s.events.GetTripVersionsSince(ctx, since)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list updated trips: %w", err)
+	}
+
+	snapshotted := 0
+	for tripID, version := range versions {
+		latest := 0
+		snapshot, err := s.snapshots.GetLatestSnapshot(ctx, tripID)
+		switch {
+		case err == nil:
+			latest = snapshot.Version
+		case !errors.Is(err, types.ErrSnapshotNotFound):
+			return snapshotted, fmt.Errorf("failed to get snapshot of trip %s: %w", tripID, err)
+		}
+		if version-latest < s.config.Frequency {
+			continue
+		}
+
+		// Loading the trip replays its events since the snapshot and takes
+		// the new one
+		if _, err := s.LoadTrip(ctx, tripID); err != nil {
+			return snapshotted, fmt.Errorf("failed to snapshot trip %s: %w", tripID, err)
+		}
+		snapshotted++
+		if _, err := s.snapshots.PruneSnapshots(ctx, tripID, s.config.Retain); err != nil {
+			return snapshotted, fmt.Errorf("failed to prune snapshots of trip %s: %w", tripID, err)
+		}
+	}
+
+	s.mutex.Lock()
+	s.compactedAt = startedAt
+	s.mutex.Unlock()
+	return snapshotted, nil
+}
+
+// Start compacts every interval until ctx is cancelled
+func (s *TripSnapshotter) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			snapshotted, err := s.Compact(ctx)
+			if err != nil && ctx.Err() == nil {
+				s.logger.WithContext(ctx).WithError(err).Warn("Trip snapshot compaction failed")
+			}
+			if snapshotted > 0 {
+				s.logger.WithContext(ctx).WithFields(logger.Fields{"snapshotted": snapshotted}).Info("Trip snapshots compacted")
+			}
+		}
+	}
+}
+
+// applyTripEvent moves a trip aggregate forward by one event
+func applyTripEvent(trip *types.TripAggregate, event *types.TripEvent) {
+	at := event.Timestamp
+	switch event.Type {
+	case types.EventTripRequested:
+		trip.State = types.TripStateRequested
+		trip.RequestedAt = at
+		trip.RiderID = eventString(event, "rider_id", trip.RiderID)
+		trip.VehicleType = eventString(event, "vehicle_type", trip.VehicleType)
+		trip.PaymentMethod = eventString(event, "payment_method", trip.PaymentMethod)
+		trip.PickupLocation = eventLocation(event, "pickup_location", trip.PickupLocation)
+		trip.DestinationLocation = eventLocation(event, "destination_location", trip.DestinationLocation)
+		trip.EstimatedFare = eventFloat(event, "estimated_fare", trip.EstimatedFare)
+	case types.EventMatchingStarted:
+		trip.State = types.TripStateMatching
+	case types.EventDriverMatched:
+		trip.State = types.TripStateMatched
+		trip.MatchedAt = &at
+		trip.DriverID = eventString(event, "driver_id", trip.DriverID)
+		trip.VehicleID = eventString(event, "vehicle_id", trip.VehicleID)
+		trip.EstimatedFare = eventFloat(event, "fare", trip.EstimatedFare)
+	case types.EventDriverEnRoute:
+		trip.State = types.TripStateDriverEn
+	case types.EventDriverArrived:
+		trip.State = types.TripStateArrived
+	case types.EventTripStarted:
+		trip.State = types.TripStateInProgress
+		trip.StartedAt = &at
+	case types.EventTripCompleted:
+		trip.State = types.TripStateCompleted
+		trip.CompletedAt = &at
+		trip.ActualFare = eventFloat(event, "actual_fare", trip.ActualFare)
+		trip.Distance = eventFloat(event, "distance", trip.Distance)
+		if trip.StartedAt != nil {
+			duration := at.Sub(*trip.StartedAt)
+			trip.Duration = &duration
+		}
+	case types.EventTripCancelled:
+		trip.State = types.TripStateCancelled
+		trip.CancelledAt = &at
+		setTripMetadata(trip, "cancellation_reason", event.Data["reason"])
+	case types.EventPaymentProcessed:
+		setTripMetadata(trip, "payment_id", event.Data["payment_id"])
+	case types.EventTripRated:
+		trip.Rating = eventFloat(event, "rating", trip.Rating)
+	case types.EventTripDisputed:
+		trip.State = types.TripStateDisputed
+	case types.EventLocationUpdate:
+		trip.CurrentLocation = eventLocation(event, "location", trip.CurrentLocation)
+	case types.EventETAUpdate:
+		setTripMetadata(trip, "eta", event.Data["eta"])
+	}
+
+	if event.Version > trip.Version {
+		trip.Version = event.Version
+	}
+	trip.LastUpdated = at
+}
+
+func eventString(event *types.TripEvent, key, fallback string) string {
+	if value, ok := event.Data[key].(string); ok && value != "" {
+		return value
+	}
+	return fallback
+}
+
+func eventFloat(event *types.TripEvent, key string, fallback *float64) *float64 {
+	if value, ok := event.Data[key].(float64); ok {
+		return &value
+	}
+	return fallback
+}
+
+// eventLocation reads a location from event data, which is a decoded JSON
+// object for events read back from the store
+func eventLocation(event *types.TripEvent, key string, fallback *models.Location) *models.Location {
+	value, ok := event.Data[key]
+	if !ok || value == nil {
+		return fallback
+	}
+	if location, ok := value.(*models.Location); ok {
+		return location
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fallback
+	}
+	var location models.Location
+	if err := json.Unmarshal(data, &location); err != nil {
+		return fallback
+	}
+	return &location
+}
+
+func setTripMetadata(trip *types.TripAggregate, key string, value interface{}) {
+	if value == nil {
+		return
+	}
+	if trip.Metadata == nil {
+		trip.Metadata = make(map[string]interface{})
+	}
+	trip.Metadata[key] = value
+}
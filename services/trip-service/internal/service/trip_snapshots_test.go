@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rideshare-platform/services/trip-service/internal/repository"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordTripEvents saves a requested trip followed by location updates up to
+// version count
+func recordTripEvents(t *testing.T, events *repository.MockEventStore, tripID string, from, count int, at time.Time) {
+	t.Helper()
+	for version := from; version <= count; version++ {
+		event := &types.TripEvent{
+			ID:        tripID + "-" + time.Duration(version).String(),
+			TripID:    tripID,
+			Type:      types.EventLocationUpdate,
+			Data:      map[string]interface{}{"location": map[string]interface{}{"latitude": 40.7 + float64(version)/1000, "longitude": -74.0}},
+			Timestamp: at.Add(time.Duration(version) * time.Second),
+			Version:   version,
+		}
+		if version == 1 {
+			event.Type = types.EventTripRequested
+			event.Data = map[string]interface{}{
+				"rider_id":        "rider-1",
+				"vehicle_type":    "standard",
+				"pickup_location": map[string]interface{}{"latitude": 40.7, "longitude": -74.0},
+				"estimated_fare":  18.5,
+			}
+		}
+		require.NoError(t, events.SaveEvent(context.Background(), event))
+	}
+}
+
+func TestTripSnapshotter_LoadTripFromSnapshot(t *testing.T) {
+	ctx := context.Background()
+	events := repository.NewMockEventStore()
+	snapshots := repository.NewMockSnapshotStore()
+	snapshotter := NewTripSnapshotter(events, snapshots, SnapshotConfig{Frequency: 5, Retain: 1}, logger.NewLogger("test", "info"))
+
+	_, err := snapshotter.LoadTrip(ctx, "missing")
+	assert.ErrorIs(t, err, types.ErrTripNotFound)
+
+	start := time.Now().Add(-time.Hour)
+	recordTripEvents(t, events, "trip-1", 1, 3, start)
+	trip, err := snapshotter.LoadTrip(ctx, "trip-1")
+	require.NoError(t, err)
+	assert.Equal(t, 3, trip.Version)
+	assert.Equal(t, "rider-1", trip.RiderID)
+	_, err = snapshots.GetLatestSnapshot(ctx, "trip-1")
+	assert.ErrorIs(t, err, types.ErrSnapshotNotFound, "fewer events than the frequency are not snapshotted")
+
+	recordTripEvents(t, events, "trip-1", 4, 8, start)
+	trip, err = snapshotter.LoadTrip(ctx, "trip-1")
+	require.NoError(t, err)
+	snapshot, err := snapshots.GetLatestSnapshot(ctx, "trip-1")
+	require.NoError(t, err)
+	assert.Equal(t, 8, snapshot.Version)
+
+	// Later reads replay only the events after the snapshot
+	recordTripEvents(t, events, "trip-1", 9, 10, start)
+	loaded, err := snapshotter.LoadTrip(ctx, "trip-1")
+	require.NoError(t, err)
+	assert.Equal(t, 10, loaded.Version)
+	assert.Equal(t, trip.RiderID, loaded.RiderID)
+	assert.InDelta(t, 40.71, loaded.CurrentLocation.Latitude, 1e-9)
+	assert.Equal(t, types.TripStateRequested, loaded.State)
+	snapshot, _ = snapshots.GetLatestSnapshot(ctx, "trip-1")
+	assert.Equal(t, 8, snapshot.Version, "the snapshot is left as it was saved")
+}
+
+func TestTripSnapshotter_Compact(t *testing.T) {
+	ctx := context.Background()
+	events := repository.NewMockEventStore()
+	snapshots := repository.NewMockSnapshotStore()
+	snapshotter := NewTripSnapshotter(events, snapshots, SnapshotConfig{Frequency: 5, Retain: 1}, logger.NewLogger("test", "info"))
+
+	start := time.Now().Add(-time.Hour)
+	recordTripEvents(t, events, "busy", 1, 12, start)
+	recordTripEvents(t, events, "quiet", 1, 2, start)
+
+	snapshotted, err := snapshotter.Compact(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, snapshotted)
+	snapshot, err := snapshots.GetLatestSnapshot(ctx, "busy")
+	require.NoError(t, err)
+	assert.Equal(t, 12, snapshot.Version)
+	_, err = snapshots.GetLatestSnapshot(ctx, "quiet")
+	assert.ErrorIs(t, err, types.ErrSnapshotNotFound)
+
+	// Only trips with new events are looked at again, and older snapshots
+	// are pruned
+	recordTripEvents(t, events, "busy", 13, 20, time.Now().Add(-time.Minute))
+	snapshotted, err = snapshotter.Compact(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, snapshotted, "events older than the last compaction are not looked at")
+
+	recordTripEvents(t, events, "busy", 21, 26, time.Now())
+	snapshotted, err = snapshotter.Compact(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, snapshotted)
+	snapshot, _ = snapshots.GetLatestSnapshot(ctx, "busy")
+	assert.Equal(t, 26, snapshot.Version)
+	pruned, err := snapshots.PruneSnapshots(ctx, "busy", 1)
+	require.NoError(t, err)
+	assert.Equal(t, 0, pruned, "compaction keeps only the latest snapshot")
+}
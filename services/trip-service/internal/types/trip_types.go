@@ -2,6 +2,7 @@ package types
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/rideshare-platform/shared/models"
@@ -111,11 +112,39 @@ type TripLocationUpdate struct {
 	Timestamp time.Time        `json:"timestamp"`
 }
 
+// ErrTripNotFound is returned when a trip does not exist
+var ErrTripNotFound = errors.New("trip not found")
+
 // TripEventStore interface for event storage
 type TripEventStore interface {
 	SaveEvent(ctx context.Context, event *TripEvent) error
 	GetEvents(ctx context.Context, tripID string) ([]*TripEvent, error)
 	GetEventsAfterVersion(ctx context.Context, tripID string, version int) ([]*TripEvent, error)
+	// GetTripVersionsSince returns the latest event version of each trip with
+	// events recorded at or after since
+	GetTripVersionsSince(ctx context.Context, since time.Time) (map[string]int, error)
+}
+
+// TripSnapshot is a trip's state as of an event version, so reading the trip
+// replays only the events recorded after it
+type TripSnapshot struct {
+	TripID    string         `json:"trip_id"`
+	Version   int            `json:"version"`
+	State     *TripAggregate `json:"state"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// ErrSnapshotNotFound is returned when a trip has no snapshot
+var ErrSnapshotNotFound = errors.New("trip snapshot not found")
+
+// TripSnapshotStore interface for trip snapshot storage
+type TripSnapshotStore interface {
+	SaveSnapshot(ctx context.Context, snapshot *TripSnapshot) error
+	// GetLatestSnapshot returns the trip's snapshot with the highest version
+	GetLatestSnapshot(ctx context.Context, tripID string) (*TripSnapshot, error)
+	// PruneSnapshots removes all but the trip's keep latest snapshots and
+	// returns how many were removed
+	PruneSnapshots(ctx context.Context, tripID string, keep int) (int, error)
 }
 
 // TripReadModel interface for read-side projections
@@ -126,3 +155,828 @@ type TripReadModel interface {
 	GetTripsByDriver(ctx context.Context, driverID string, limit, offset int) ([]*TripAggregate, error)
 	GetActiveTrips(ctx context.Context) ([]*TripAggregate, error)
 }
+
+// LoggedTripEvent is a trip event with its position in the log of every
+// trip's events, which projections track how far they have read by
+type LoggedTripEvent struct {
+	Position int64             `json:"position"`
+	Event    *models.TripEvent `json:"event"`
+}
+
+// TripListing is a trip as kept in the read model trip lists are served
+// from, with the fields lists are filtered by alongside the trip
+type TripListing struct {
+	TripID      string            `json:"trip_id" bson:"_id"`
+	RiderID     string            `json:"rider_id" bson:"rider_id"`
+	DriverID    string            `json:"driver_id,omitempty" bson:"driver_id,omitempty"`
+	Status      models.TripStatus `json:"status" bson:"status"`
+	CityID      string            `json:"city_id,omitempty" bson:"city_id,omitempty"`
+	RequestedAt time.Time         `json:"requested_at" bson:"requested_at"`
+	Trip        *models.Trip      `json:"trip" bson:"trip"`
+	// Position is the log position of the last event projected
+	Position int64 `json:"position" bson:"position"`
+}
+
+// TripListingFilter selects trip listings. Empty fields match every listing.
+type TripListingFilter struct {
+	RiderID  string
+	DriverID string
+	Status   models.TripStatus
+}
+
+// TripListingStore interface for the trip list read model
+type TripListingStore interface {
+	SaveListing(ctx context.Context, listing *TripListing) error
+	DeleteListing(ctx context.Context, tripID string) error
+	// ListListings returns the listings matching filter, most recently
+	// requested first
+	ListListings(ctx context.Context, filter TripListingFilter) ([]*TripListing, error)
+	// GetCheckpoint returns the log position of the last event projected,
+	// 0 before any were
+	GetCheckpoint(ctx context.Context) (int64, error)
+	SaveCheckpoint(ctx context.Context, position int64) error
+	// Reset removes every listing and the checkpoint
+	Reset(ctx context.Context) error
+}
+
+// SharedTripStatus represents the state of a shared (pooled) trip
+type SharedTripStatus string
+
+const (
+	SharedTripStatusActive    SharedTripStatus = "active"
+	SharedTripStatusCompleted SharedTripStatus = "completed"
+)
+
+// StopType distinguishes pickups from dropoffs on a shared trip
+type StopType string
+
+const (
+	StopTypePickup  StopType = "pickup"
+	StopTypeDropoff StopType = "dropoff"
+)
+
+// StopStatus represents whether the driver has reached a stop
+type StopStatus string
+
+const (
+	StopStatusPending   StopStatus = "pending"
+	StopStatusCompleted StopStatus = "completed"
+)
+
+// TripStop is a pickup or dropoff on a shared trip's route
+type TripStop struct {
+	ID          string           `json:"id"`
+	TripID      string           `json:"trip_id"`
+	RiderID     string           `json:"rider_id"`
+	Type        StopType         `json:"type"`
+	Location    *models.Location `json:"location"`
+	Status      StopStatus       `json:"status"`
+	CompletedAt *time.Time       `json:"completed_at,omitempty"`
+}
+
+// SharedRider is a rider's trip on a shared route
+type SharedRider struct {
+	TripID           string           `json:"trip_id"`
+	RiderID          string           `json:"rider_id"`
+	PickupLocation   *models.Location `json:"pickup_location"`
+	Destination      *models.Location `json:"destination"`
+	PassengerCount   int              `json:"passenger_count"`
+	MaxDetourMinutes int              `json:"max_detour_minutes"`
+	Fare             float64          `json:"fare"`
+}
+
+// SharedTrip is a driver's route serving several riders' trips. Stops are kept
+// in the order the driver will visit them.
+type SharedTrip struct {
+	ID              string           `json:"id"`
+	DriverID        string           `json:"driver_id"`
+	VehicleType     string           `json:"vehicle_type"`
+	SeatCapacity    int              `json:"seat_capacity"`
+	SeatsOccupied   int              `json:"seats_occupied"`
+	Status          SharedTripStatus `json:"status"`
+	Stops           []*TripStop      `json:"stops"`
+	Riders          []*SharedRider   `json:"riders"`
+	CurrentLocation *models.Location `json:"current_location,omitempty"`
+	Version         int              `json:"version"`
+	CreatedAt       time.Time        `json:"created_at"`
+	UpdatedAt       time.Time        `json:"updated_at"`
+}
+
+// ErrSharedTripNotFound is returned when a shared trip does not exist
+var ErrSharedTripNotFound = errors.New("shared trip not found")
+
+// SharedTripStore interface for shared trip storage
+type SharedTripStore interface {
+	SaveSharedTrip(ctx context.Context, trip *SharedTrip) error
+	GetSharedTrip(ctx context.Context, sharedTripID string) (*SharedTrip, error)
+	GetActiveSharedTrips(ctx context.Context) ([]*SharedTrip, error)
+}
+
+// ScheduledRideStatus represents the state of a ride booked for later
+type ScheduledRideStatus string
+
+const (
+	ScheduledRideStatusScheduled  ScheduledRideStatus = "scheduled"
+	ScheduledRideStatusDispatched ScheduledRideStatus = "dispatched"
+	ScheduledRideStatusCancelled  ScheduledRideStatus = "cancelled"
+	ScheduledRideStatusFailed     ScheduledRideStatus = "failed"
+)
+
+// ScheduledRide is a trip requested for a future pickup time. It is handed to
+// matching-service once DispatchAt is reached.
+type ScheduledRide struct {
+	ID                 string              `json:"id"`
+	RiderID            string              `json:"rider_id"`
+	PickupLocation     *models.Location    `json:"pickup_location"`
+	Destination        *models.Location    `json:"destination"`
+	VehicleType        string              `json:"vehicle_type"`
+	PaymentMethod      string              `json:"payment_method"`
+	PassengerCount     int                 `json:"passenger_count"`
+	Status             ScheduledRideStatus `json:"status"`
+	ScheduledFor       time.Time           `json:"scheduled_for"`
+	DispatchAt         time.Time           `json:"dispatch_at"`
+	DispatchAttempts   int                 `json:"dispatch_attempts"`
+	DriverID           string              `json:"driver_id,omitempty"`
+	LastError          string              `json:"last_error,omitempty"`
+	CancellationReason string              `json:"cancellation_reason,omitempty"`
+	CancelledAt        *time.Time          `json:"cancelled_at,omitempty"`
+	DispatchedAt       *time.Time          `json:"dispatched_at,omitempty"`
+	CreatedAt          time.Time           `json:"created_at"`
+	UpdatedAt          time.Time           `json:"updated_at"`
+}
+
+// ErrScheduledRideNotFound is returned when a scheduled ride does not exist
+var ErrScheduledRideNotFound = errors.New("scheduled ride not found")
+
+// ScheduledRideStore interface for scheduled ride storage
+type ScheduledRideStore interface {
+	SaveScheduledRide(ctx context.Context, ride *ScheduledRide) error
+	GetScheduledRide(ctx context.Context, rideID string) (*ScheduledRide, error)
+	GetScheduledRidesByRider(ctx context.Context, riderID string) ([]*ScheduledRide, error)
+	GetDueScheduledRides(ctx context.Context, now time.Time) ([]*ScheduledRide, error)
+}
+
+// RaterRole is the side of a trip a rating was given from
+type RaterRole string
+
+const (
+	RaterRoleRider  RaterRole = "rider"
+	RaterRoleDriver RaterRole = "driver"
+)
+
+// Ratee returns the role of the person being rated
+func (r RaterRole) Ratee() RaterRole {
+	if r == RaterRoleRider {
+		return RaterRoleDriver
+	}
+	return RaterRoleRider
+}
+
+// Rating is one participant's review of the other after a completed trip
+type Rating struct {
+	ID        string    `json:"id"`
+	TripID    string    `json:"trip_id"`
+	RaterID   string    `json:"rater_id"`
+	RateeID   string    `json:"ratee_id"`
+	RaterRole RaterRole `json:"rater_role"`
+	Score     int       `json:"score"`
+	Comment   string    `json:"comment,omitempty"`
+	Tags      []string  `json:"tags,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// RatingSummary is a user's rolling average over their most recent ratings in one role
+type RatingSummary struct {
+	UserID       string    `json:"user_id"`
+	Role         RaterRole `json:"role"`
+	Average      float64   `json:"average_rating"`
+	RatingCount  int       `json:"rating_count"`
+	RecentScores []int     `json:"recent_scores"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// CompletedTrip records who took part in a finished trip so both sides can rate each other
+type CompletedTrip struct {
+	TripID      string    `json:"trip_id"`
+	RiderID     string    `json:"rider_id"`
+	DriverID    string    `json:"driver_id"`
+	CompletedAt time.Time `json:"completed_at"`
+}
+
+var (
+	// ErrCompletedTripNotFound is returned when a trip has not been recorded as completed
+	ErrCompletedTripNotFound = errors.New("completed trip not found")
+	// ErrRatingSummaryNotFound is returned when a user has not been rated yet
+	ErrRatingSummaryNotFound = errors.New("rating summary not found")
+	// ErrDuplicateRating is returned when a trip already has a rating from the same side
+	ErrDuplicateRating = errors.New("trip already rated")
+)
+
+// RatingStore interface for rating and review storage
+type RatingStore interface {
+	SaveCompletedTrip(ctx context.Context, trip *CompletedTrip) error
+	GetCompletedTrip(ctx context.Context, tripID string) (*CompletedTrip, error)
+	// SaveRating returns ErrDuplicateRating if the trip is already rated from the same side
+	SaveRating(ctx context.Context, rating *Rating) error
+	GetRatings(ctx context.Context, rateeID string, role RaterRole, limit int) ([]*Rating, error)
+	SaveRatingSummary(ctx context.Context, summary *RatingSummary) error
+	GetRatingSummary(ctx context.Context, userID string, role RaterRole) (*RatingSummary, error)
+}
+
+// ReceiptFare is the final fare breakdown printed on a receipt
+type ReceiptFare struct {
+	BaseFare        float64 `json:"base_fare"`
+	DistanceFare    float64 `json:"distance_fare"`
+	TimeFare        float64 `json:"time_fare"`
+	WaitingFare     float64 `json:"waiting_fare"`
+	SurgeMultiplier float64 `json:"surge_multiplier"`
+	SurgeAmount     float64 `json:"surge_amount"`
+	BookingFee      float64 `json:"booking_fee"`
+	ServiceFee      float64 `json:"service_fee"`
+	Taxes           float64 `json:"taxes"`
+	Tolls           float64 `json:"tolls"`
+	DiscountAmount  float64 `json:"discount_amount"`
+	Total           float64 `json:"total"`
+	Currency        string  `json:"currency"`
+
+	// TaxLines itemize Taxes by jurisdiction rule
+	TaxLines []*ReceiptTaxLine `json:"tax_lines,omitempty"`
+}
+
+// ReceiptTaxLine is one tax or levy included in a receipt's fare
+type ReceiptTaxLine struct {
+	Name   string  `json:"name"`
+	Type   string  `json:"type"`
+	Rate   float64 `json:"rate,omitempty"`
+	Amount float64 `json:"amount"`
+}
+
+// ReceiptPayment is the state of the payment charged for a trip
+type ReceiptPayment struct {
+	PaymentID   string     `json:"payment_id"`
+	Status      string     `json:"status"`
+	Method      string     `json:"method"`
+	Amount      float64    `json:"amount"`
+	Currency    string     `json:"currency"`
+	ProcessedAt *time.Time `json:"processed_at,omitempty"`
+}
+
+// Settled reports whether the payment has reached a status that will not change
+func (p *ReceiptPayment) Settled() bool {
+	switch p.Status {
+	case "pending", "processing", "unknown", "":
+		return false
+	default:
+		return true
+	}
+}
+
+// ReceiptRoute summarises where and how long a trip went
+type ReceiptRoute struct {
+	PickupLocation  *models.Location `json:"pickup_location,omitempty"`
+	Destination     *models.Location `json:"destination,omitempty"`
+	PickupArea      string           `json:"pickup_area,omitempty"`
+	City            string           `json:"city,omitempty"`
+	DistanceKm      float64          `json:"distance_km"`
+	DurationMinutes int              `json:"duration_minutes"`
+	StartedAt       *time.Time       `json:"started_at,omitempty"`
+	CompletedAt     time.Time        `json:"completed_at"`
+	WaitingSeconds  int              `json:"waiting_seconds,omitempty"` // driver waited at pickup
+	// Recorded is set when the distance was measured from the route recorded
+	// during the trip rather than reported by the driver's app
+	Recorded bool `json:"recorded,omitempty"`
+}
+
+// ReceiptDriver identifies the driver of a trip and their rating when the receipt was issued
+type ReceiptDriver struct {
+	ID          string  `json:"id"`
+	Rating      float64 `json:"rating,omitempty"`
+	RatingCount int     `json:"rating_count,omitempty"`
+}
+
+// ReceiptVehicle identifies the vehicle a trip was taken in
+type ReceiptVehicle struct {
+	ID           string `json:"id"`
+	VehicleType  string `json:"vehicle_type"`
+	Make         string `json:"make,omitempty"`
+	Model        string `json:"model,omitempty"`
+	Year         int    `json:"year,omitempty"`
+	Color        string `json:"color,omitempty"`
+	LicensePlate string `json:"license_plate,omitempty"`
+}
+
+// Receipt is the persisted summary of a completed trip. Fare and Payment are
+// nil until pricing-service and payment-service have answered.
+type Receipt struct {
+	ID        string          `json:"id"`
+	TripID    string          `json:"trip_id"`
+	RiderID   string          `json:"rider_id"`
+	Fare      *ReceiptFare    `json:"fare,omitempty"`
+	Payment   *ReceiptPayment `json:"payment,omitempty"`
+	Route     ReceiptRoute    `json:"route"`
+	Driver    ReceiptDriver   `json:"driver"`
+	Vehicle   ReceiptVehicle  `json:"vehicle"`
+	IssuedAt  time.Time       `json:"issued_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+	// LockedSurgeMultiplier is the surge quoted when the trip was requested,
+	// which the final fare is charged at
+	LockedSurgeMultiplier float64 `json:"locked_surge_multiplier,omitempty"`
+	// Currency the trip was quoted in. Fares and payments in any other
+	// currency are not put on the receipt.
+	Currency string `json:"currency,omitempty"`
+}
+
+// ErrReceiptNotFound is returned when a trip has no receipt
+var ErrReceiptNotFound = errors.New("receipt not found")
+
+// ReceiptStore interface for trip receipt storage
+type ReceiptStore interface {
+	SaveReceipt(ctx context.Context, receipt *Receipt) error
+	GetReceipt(ctx context.Context, tripID string) (*Receipt, error)
+}
+
+// ReconciliationMismatchType names how a trip and its payments disagree
+type ReconciliationMismatchType string
+
+const (
+	// MismatchMissingPayment is a completed trip with a fare but no successful charge
+	MismatchMissingPayment ReconciliationMismatchType = "missing_payment"
+	// MismatchAmountDrift is a completed trip charged a different amount or currency than its fare
+	MismatchAmountDrift ReconciliationMismatchType = "amount_drift"
+	// MismatchOrphanPayment is a charge for a trip that does not exist or did not complete
+	MismatchOrphanPayment ReconciliationMismatchType = "orphan_payment"
+)
+
+// ReconciliationPayment is a charge as payment-service reports it
+type ReconciliationPayment struct {
+	PaymentID      string    `json:"payment_id"`
+	TripID         string    `json:"trip_id"`
+	Status         string    `json:"status"`
+	Amount         float64   `json:"amount"`
+	RefundedAmount float64   `json:"refunded_amount"`
+	Currency       string    `json:"currency"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// ReconciliationMismatch is one finding of a reconciliation run. Amounts are
+// in minor units of Currency.
+type ReconciliationMismatch struct {
+	Type           ReconciliationMismatchType `json:"type"`
+	TripID         string                     `json:"trip_id"`
+	TripStatus     string                     `json:"trip_status,omitempty"`
+	PaymentIDs     []string                   `json:"payment_ids,omitempty"`
+	ExpectedCents  int64                      `json:"expected_cents"`
+	ChargedCents   int64                      `json:"charged_cents"`
+	Currency       string                     `json:"currency"`
+	ChargeCurrency string                     `json:"charge_currency,omitempty"` // set when the trip was charged in another currency
+	Details        string                     `json:"details"`
+}
+
+// ReconciliationReport is the outcome of cross-checking the trips completed
+// and the payments created in [PeriodStart, PeriodEnd)
+type ReconciliationReport struct {
+	ID              string                    `json:"id"`
+	PeriodStart     time.Time                 `json:"period_start"`
+	PeriodEnd       time.Time                 `json:"period_end"`
+	StartedAt       time.Time                 `json:"started_at"`
+	FinishedAt      time.Time                 `json:"finished_at"`
+	TripsChecked    int                       `json:"trips_checked"`
+	PaymentsChecked int                       `json:"payments_checked"`
+	MissingPayments int                       `json:"missing_payments"`
+	AmountDrifts    int                       `json:"amount_drifts"`
+	OrphanPayments  int                       `json:"orphan_payments"`
+	Mismatches      []*ReconciliationMismatch `json:"mismatches"`
+	// Error is set when the run could not finish; the counts cover what was checked
+	Error string `json:"error,omitempty"`
+}
+
+// ErrReconciliationReportNotFound is returned when a reconciliation report does not exist
+var ErrReconciliationReportNotFound = errors.New("reconciliation report not found")
+
+// ReconciliationReportStore interface for reconciliation report storage
+type ReconciliationReportStore interface {
+	SaveReport(ctx context.Context, report *ReconciliationReport) error
+	GetReport(ctx context.Context, reportID string) (*ReconciliationReport, error)
+	// ListReports returns the most recent reports, newest first
+	ListReports(ctx context.Context, limit int) ([]*ReconciliationReport, error)
+}
+
+// ComplianceReport is one period of trip data reported to a city's
+// regulator, generated from the jurisdiction's template. Content is the
+// anonymized report itself and is only loaded when the report is fetched.
+type ComplianceReport struct {
+	ID                  string    `json:"id"`
+	TemplateID          string    `json:"template_id"`
+	TemplateName        string    `json:"template_name"`
+	CityID              string    `json:"city_id"`
+	Schedule            string    `json:"schedule"`
+	PeriodStart         time.Time `json:"period_start"`
+	PeriodEnd           time.Time `json:"period_end"`
+	Timezone            string    `json:"timezone"`
+	TripCount           int       `json:"trip_count"`
+	AccessibleTripCount int       `json:"accessible_trip_count"`
+	// SuppressedZoneCount is how many zones had too few trips to report
+	SuppressedZoneCount int       `json:"suppressed_zone_count"`
+	ContentType         string    `json:"content_type"`
+	Content             []byte    `json:"-"`
+	GeneratedBy         string    `json:"generated_by"`
+	GeneratedAt         time.Time `json:"generated_at"`
+
+	// WAV requests are the wheelchair-accessible trips requested in the
+	// period that have since completed, been cancelled or failed. The
+	// fulfillment rate is the share that completed, and the average wait
+	// how long those waited from request to the driver's arrival.
+	WAVRequestCount       int     `json:"wav_request_count"`
+	WAVFulfilledCount     int     `json:"wav_fulfilled_count"`
+	WAVFulfillmentRate    float64 `json:"wav_fulfillment_rate"`
+	WAVAverageWaitSeconds float64 `json:"wav_average_wait_seconds"`
+}
+
+// ComplianceReportFilter narrows the compliance reports listed
+type ComplianceReportFilter struct {
+	CityID     string
+	TemplateID string
+	Limit      int
+}
+
+// ErrComplianceReportNotFound is returned when a compliance report does not exist
+var ErrComplianceReportNotFound = errors.New("compliance report not found")
+
+// ComplianceReportStore interface for compliance report storage
+type ComplianceReportStore interface {
+	SaveReport(ctx context.Context, report *ComplianceReport) error
+	// GetReport returns a report with its content
+	GetReport(ctx context.Context, reportID string) (*ComplianceReport, error)
+	// FindReport returns the latest report a template generated for the
+	// period starting at periodStart, without its content
+	FindReport(ctx context.Context, templateID string, periodStart time.Time) (*ComplianceReport, error)
+	// ListReports returns the most recent reports, newest first, without
+	// their content
+	ListReports(ctx context.Context, filter ComplianceReportFilter) ([]*ComplianceReport, error)
+}
+
+// IncidentStatus is how far support staff have got with an emergency incident
+type IncidentStatus string
+
+const (
+	IncidentStatusOpen         IncidentStatus = "open"
+	IncidentStatusAcknowledged IncidentStatus = "acknowledged"
+	IncidentStatusResolved     IncidentStatus = "resolved"
+)
+
+// IncidentNote is a remark added to an incident's timeline
+type IncidentNote struct {
+	AuthorID  string    `json:"author_id"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Incident is an emergency a rider or driver raised during a trip. The
+// trip's location trail is frozen into the incident when it is raised, so
+// later trip updates cannot change what support staff see.
+type Incident struct {
+	ID             string            `json:"id"`
+	TripID         string            `json:"trip_id"`
+	RiderID        string            `json:"rider_id"`
+	DriverID       string            `json:"driver_id,omitempty"`
+	ReporterID     string            `json:"reporter_id"`
+	ReporterRole   string            `json:"reporter_role"` // "rider" or "driver"
+	Status         IncidentStatus    `json:"status"`
+	TripStatus     models.TripStatus `json:"trip_status"`
+	Location       *models.Location  `json:"location,omitempty"` // where the reporter was, if they sent it
+	LocationTrail  []models.Location `json:"location_trail"`
+	AlertID        string            `json:"alert_id,omitempty"`
+	Notes          []*IncidentNote   `json:"notes"`
+	AcknowledgedBy string            `json:"acknowledged_by,omitempty"`
+	AcknowledgedAt *time.Time        `json:"acknowledged_at,omitempty"`
+	ResolvedBy     string            `json:"resolved_by,omitempty"`
+	ResolvedAt     *time.Time        `json:"resolved_at,omitempty"`
+	Resolution     string            `json:"resolution,omitempty"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+}
+
+// ErrIncidentNotFound is returned when an incident does not exist
+var ErrIncidentNotFound = errors.New("incident not found")
+
+// IncidentStore interface for emergency incident storage
+type IncidentStore interface {
+	SaveIncident(ctx context.Context, incident *Incident) error
+	GetIncident(ctx context.Context, incidentID string) (*Incident, error)
+	// ListIncidents returns the incidents in a status, or all of them when
+	// status is empty, newest first
+	ListIncidents(ctx context.Context, status IncidentStatus) ([]*Incident, error)
+	GetIncidentsByTrip(ctx context.Context, tripID string) ([]*Incident, error)
+}
+
+// DisputeStatus is how far support staff have got with a fare dispute
+type DisputeStatus string
+
+const (
+	DisputeStatusOpen     DisputeStatus = "open"
+	DisputeStatusInReview DisputeStatus = "in_review"
+	DisputeStatusResolved DisputeStatus = "resolved"
+	DisputeStatusRejected DisputeStatus = "rejected"
+)
+
+// Closed reports whether support staff have finished with the dispute
+func (s DisputeStatus) Closed() bool {
+	return s == DisputeStatusResolved || s == DisputeStatusRejected
+}
+
+// DisputeReason is why a rider contests a charge
+type DisputeReason string
+
+const (
+	DisputeReasonOvercharged     DisputeReason = "overcharged"
+	DisputeReasonWrongRoute      DisputeReason = "wrong_route"
+	DisputeReasonTripNotTaken    DisputeReason = "trip_not_taken"
+	DisputeReasonCancellationFee DisputeReason = "cancellation_fee"
+	DisputeReasonOther           DisputeReason = "other"
+)
+
+// DisputeEvidence is something the rider sent to back their dispute, such as
+// a description of what happened or a link to a screenshot
+type DisputeEvidence struct {
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url,omitempty"`
+}
+
+// DisputeNote is a remark added to a dispute's timeline
+type DisputeNote struct {
+	AuthorID  string    `json:"author_id"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Dispute is a rider contesting what they were charged for a trip. The
+// charge and its fare breakdown are frozen when the dispute is opened.
+// RefundAmount is what was refunded when it was resolved, in Currency.
+type Dispute struct {
+	ID            string                `json:"id"`
+	TripID        string                `json:"trip_id"`
+	RiderID       string                `json:"rider_id"`
+	DriverID      string                `json:"driver_id,omitempty"`
+	Reason        DisputeReason         `json:"reason"`
+	Description   string                `json:"description"`
+	Evidence      []DisputeEvidence     `json:"evidence"`
+	Status        DisputeStatus         `json:"status"`
+	ChargedAmount float64               `json:"charged_amount"`
+	Currency      string                `json:"currency"`
+	Fare          *models.FareBreakdown `json:"fare,omitempty"`
+	AssignedTo    string                `json:"assigned_to,omitempty"`
+	Notes         []*DisputeNote        `json:"notes"`
+	Resolution    string                `json:"resolution,omitempty"`
+	RefundAmount  float64               `json:"refund_amount,omitempty"`
+	RefundID      string                `json:"refund_id,omitempty"`
+	ResolvedBy    string                `json:"resolved_by,omitempty"`
+	ResolvedAt    *time.Time            `json:"resolved_at,omitempty"`
+	CreatedAt     time.Time             `json:"created_at"`
+	UpdatedAt     time.Time             `json:"updated_at"`
+}
+
+// ErrDisputeNotFound is returned when a dispute does not exist
+var ErrDisputeNotFound = errors.New("dispute not found")
+
+// DisputeStore interface for fare dispute storage
+type DisputeStore interface {
+	SaveDispute(ctx context.Context, dispute *Dispute) error
+	GetDispute(ctx context.Context, disputeID string) (*Dispute, error)
+	// ListDisputes returns the disputes in a status, or all of them when
+	// status is empty, oldest first so the longest waiting are worked first
+	ListDisputes(ctx context.Context, status DisputeStatus) ([]*Dispute, error)
+	GetDisputesByTrip(ctx context.Context, tripID string) ([]*Dispute, error)
+}
+
+// LostItemStatus is where a lost item report has got to
+type LostItemStatus string
+
+const (
+	LostItemStatusReported LostItemStatus = "reported"
+	LostItemStatusFound    LostItemStatus = "found"
+	LostItemStatusReturned LostItemStatus = "returned"
+	LostItemStatusNotFound LostItemStatus = "not_found"
+)
+
+// Closed reports whether the report needs no more work
+func (s LostItemStatus) Closed() bool {
+	return s == LostItemStatusReturned || s == LostItemStatusNotFound
+}
+
+// LostItemNote is a status change or remark on a lost item report's timeline
+type LostItemNote struct {
+	AuthorID  string         `json:"author_id"`
+	Status    LostItemStatus `json:"status,omitempty"`
+	Text      string         `json:"text,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// LostItemReport is a rider reporting something left behind on a completed
+// trip. The trip's rider and driver can message each other through the trip
+// chat until ContactUntil, or until the report is closed.
+type LostItemReport struct {
+	ID               string          `json:"id"`
+	TripID           string          `json:"trip_id"`
+	RiderID          string          `json:"rider_id"`
+	DriverID         string          `json:"driver_id"`
+	Description      string          `json:"description"`
+	Status           LostItemStatus  `json:"status"`
+	DriverNotifiedAt *time.Time      `json:"driver_notified_at,omitempty"`
+	ContactUntil     time.Time       `json:"contact_until"`
+	Notes            []*LostItemNote `json:"notes"`
+	CreatedAt        time.Time       `json:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at"`
+}
+
+// ContactOpen reports whether the rider and driver can still message each other about the report
+func (r *LostItemReport) ContactOpen(at time.Time) bool {
+	return !r.Status.Closed() && at.Before(r.ContactUntil)
+}
+
+// LostItemFilter selects lost item reports. Empty fields match every report.
+type LostItemFilter struct {
+	Status   LostItemStatus
+	RiderID  string
+	DriverID string
+	TripID   string
+}
+
+// ErrLostItemNotFound is returned when a lost item report does not exist
+var ErrLostItemNotFound = errors.New("lost item report not found")
+
+// LostItemStore interface for lost item report storage
+type LostItemStore interface {
+	SaveLostItem(ctx context.Context, report *LostItemReport) error
+	GetLostItem(ctx context.Context, reportID string) (*LostItemReport, error)
+	// ListLostItems returns the reports matching filter, newest first
+	ListLostItems(ctx context.Context, filter LostItemFilter) ([]*LostItemReport, error)
+}
+
+// PickupGuaranteeStatus is whether a trip's driver reached the pickup in the
+// time the rider was promised
+type PickupGuaranteeStatus string
+
+const (
+	PickupGuaranteePending PickupGuaranteeStatus = "pending"
+	PickupGuaranteeKept    PickupGuaranteeStatus = "kept"
+	PickupGuaranteeMissed  PickupGuaranteeStatus = "missed"
+	// PickupGuaranteeVoid is a trip cancelled before its pickup, which
+	// counts neither way
+	PickupGuaranteeVoid PickupGuaranteeStatus = "void"
+)
+
+// PickupGuarantee is the pickup time a rider was promised when their trip
+// was matched. A driver who arrives more than the grace period after
+// PromisedAt misses it, and the rider is credited CreditAmount.
+type PickupGuarantee struct {
+	TripID        string                `json:"trip_id"`
+	RiderID       string                `json:"rider_id"`
+	DriverID      string                `json:"driver_id"`
+	CityID        string                `json:"city_id,omitempty"`
+	Area          string                `json:"area,omitempty"` // geohash of the pickup
+	Status        PickupGuaranteeStatus `json:"status"`
+	MatchedAt     time.Time             `json:"matched_at"`
+	PromisedAt    time.Time             `json:"promised_at"`
+	PickedUpAt    *time.Time            `json:"picked_up_at,omitempty"`
+	LateBySeconds int64                 `json:"late_by_seconds,omitempty"`
+	CreditAmount  float64               `json:"credit_amount,omitempty"`
+	Currency      string                `json:"currency,omitempty"`
+	CreditID      string                `json:"credit_id,omitempty"`
+	// CreditError is why the credit of a missed guarantee was not granted
+	CreditError string    `json:"credit_error,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// PickupGuaranteeFilter selects pickup guarantees by when their trip was
+// matched, in [From, To). Empty fields match every guarantee.
+type PickupGuaranteeFilter struct {
+	Status   PickupGuaranteeStatus
+	DriverID string
+	CityID   string
+	From     time.Time
+	To       time.Time
+}
+
+// PickupSLAGroup is how one driver or area kept the pickup guarantees they
+// were judged on
+type PickupSLAGroup struct {
+	Key                string  `json:"key"` // driver ID or area geohash
+	Judged             int64   `json:"judged"`
+	Missed             int64   `json:"missed"`
+	MissRate           float64 `json:"miss_rate"`
+	AverageLateSeconds float64 `json:"average_late_seconds"` // of missed pickups
+}
+
+// PickupSLAReport summarises the pickup guarantees of trips matched in
+// [From, To). Guarantees still pending or voided are not judged. Drivers and
+// areas are listed with the most misses first.
+type PickupSLAReport struct {
+	From              time.Time          `json:"from"`
+	To                time.Time          `json:"to"`
+	CityID            string             `json:"city_id,omitempty"`
+	Judged            int64              `json:"judged"`
+	Missed            int64              `json:"missed"`
+	MissRate          float64            `json:"miss_rate"`
+	CreditsByCurrency map[string]float64 `json:"credits_by_currency"`
+	ByDriver          []*PickupSLAGroup  `json:"by_driver"`
+	ByArea            []*PickupSLAGroup  `json:"by_area"`
+}
+
+// ErrPickupGuaranteeNotFound is returned when a trip has no pickup guarantee
+var ErrPickupGuaranteeNotFound = errors.New("pickup guarantee not found")
+
+// PickupGuaranteeStore interface for pickup guarantee storage, keyed by trip
+type PickupGuaranteeStore interface {
+	SaveGuarantee(ctx context.Context, guarantee *PickupGuarantee) error
+	GetGuarantee(ctx context.Context, tripID string) (*PickupGuarantee, error)
+	// ListGuarantees returns the guarantees matching filter, oldest first
+	ListGuarantees(ctx context.Context, filter PickupGuaranteeFilter) ([]*PickupGuarantee, error)
+}
+
+// DriverReliability is how reliably a driver finishes the trips they take
+// on. Counts decay with age, so Trips and Cancellations are weighted sums
+// in which recent trips count most.
+type DriverReliability struct {
+	DriverID      string  `json:"driver_id"`
+	Trips         float64 `json:"trips"`         // trips completed or cancelled by the driver
+	Cancellations float64 `json:"cancellations"` // trips cancelled by the driver
+	// CompletionStreak counts the trips completed since the driver last
+	// cancelled one, decaying like the other counts
+	CompletionStreak float64   `json:"completion_streak"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// CancellationRate is the share of the driver's trips they cancelled, 0
+// for drivers without trips
+func (r *DriverReliability) CancellationRate() float64 {
+	if r.Trips <= 0 {
+		return 0
+	}
+	return r.Cancellations / r.Trips
+}
+
+// DriverReliabilityStore interface for driver reliability storage, keyed by driver
+type DriverReliabilityStore interface {
+	// RecordTrip counts a driver's completed or cancelled trip, decaying the
+	// trips counted before, and returns the driver's reliability after it. A
+	// trip is counted once per driver however often it is recorded.
+	RecordTrip(ctx context.Context, driverID, tripID string, cancelled bool, at time.Time) (*DriverReliability, error)
+	// GetReliabilities returns the reliability of the given drivers as of
+	// now, leaving out drivers with none
+	GetReliabilities(ctx context.Context, driverIDs []string, now time.Time) (map[string]*DriverReliability, error)
+}
+
+// TripMessage is a message between a trip's rider and driver. Messages are
+// kept until they are delivered, and for support review until the trip is
+// archived.
+type TripMessage struct {
+	ID           string     `json:"id" bson:"_id"`
+	TripID       string     `json:"trip_id" bson:"trip_id"`
+	SenderID     string     `json:"sender_id" bson:"sender_id"`
+	SenderRole   string     `json:"sender_role" bson:"sender_role"` // "rider" or "driver"
+	RecipientID  string     `json:"recipient_id" bson:"recipient_id"`
+	Text         string     `json:"text" bson:"text"`
+	QuickReplyID string     `json:"quick_reply_id,omitempty" bson:"quick_reply_id,omitempty"`
+	CreatedAt    time.Time  `json:"created_at" bson:"created_at"`
+	DeliveredAt  *time.Time `json:"delivered_at,omitempty" bson:"delivered_at,omitempty"`
+}
+
+// TripMessageStore interface for in-trip message storage
+type TripMessageStore interface {
+	SaveMessage(ctx context.Context, message *TripMessage) error
+	// ListTripMessages returns a trip's messages, oldest first
+	ListTripMessages(ctx context.Context, tripID string) ([]*TripMessage, error)
+	// UndeliveredMessages returns the trip's messages to recipientID that
+	// have not been delivered, oldest first
+	UndeliveredMessages(ctx context.Context, tripID, recipientID string) ([]*TripMessage, error)
+	MarkDelivered(ctx context.Context, messageIDs []string, deliveredAt time.Time) error
+	// DeleteTripMessages removes every message of the trips
+	DeleteTripMessages(ctx context.Context, tripIDs []string) error
+}
+
+// TripIdempotencyKey maps the idempotency key a rider created a trip with to
+// that trip, so retries of the request return it instead of creating
+// another. RequestHash identifies the request's payload, which retries must
+// repeat. TripID is empty while the first request is being processed.
+type TripIdempotencyKey struct {
+	Key         string    `json:"key"`
+	RiderID     string    `json:"rider_id"`
+	RequestHash string    `json:"request_hash"`
+	TripID      string    `json:"trip_id,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// TripIdempotencyStore interface for idempotency key storage, keyed by rider
+// and key. Keys are forgotten once they expire.
+type TripIdempotencyStore interface {
+	// ReserveIdempotencyKey saves key unless the rider's key is already saved,
+	// and returns the saved key and whether it is the one just reserved
+	ReserveIdempotencyKey(ctx context.Context, key *TripIdempotencyKey) (*TripIdempotencyKey, bool, error)
+	// CompleteIdempotencyKey records the trip a reserved key created
+	CompleteIdempotencyKey(ctx context.Context, riderID, key, tripID string) error
+	// ReleaseIdempotencyKey forgets a reserved key whose request failed, so
+	// it can be retried
+	ReleaseIdempotencyKey(ctx context.Context, riderID, key string) error
+}
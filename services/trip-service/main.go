@@ -1,61 +1,619 @@
 package main
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
 	"log"
 	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"google.golang.org/grpc/health"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
+	"github.com/gin-gonic/gin"
+	_ "github.com/lib/pq"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"google.golang.org/grpc"
 
+	"github.com/rideshare-platform/services/trip-service/internal/client"
+	"github.com/rideshare-platform/services/trip-service/internal/config"
 	"github.com/rideshare-platform/services/trip-service/internal/handler"
+	"github.com/rideshare-platform/services/trip-service/internal/repository"
 	"github.com/rideshare-platform/services/trip-service/internal/service"
+	"github.com/rideshare-platform/services/trip-service/internal/types"
+	"github.com/rideshare-platform/services/trip-service/migrations"
+	"github.com/rideshare-platform/shared/alerting"
+	"github.com/rideshare-platform/shared/analytics"
+	"github.com/rideshare-platform/shared/archive"
+	"github.com/rideshare-platform/shared/city"
+	sharedconfig "github.com/rideshare-platform/shared/config"
+	"github.com/rideshare-platform/shared/contactproxy"
+	"github.com/rideshare-platform/shared/database"
+	"github.com/rideshare-platform/shared/events"
+	"github.com/rideshare-platform/shared/experiments"
+	"github.com/rideshare-platform/shared/export"
+	sharedgrpc "github.com/rideshare-platform/shared/grpc"
+	"github.com/rideshare-platform/shared/grpc/interceptor"
+	sharedhealth "github.com/rideshare-platform/shared/health"
 	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/middleware"
+	"github.com/rideshare-platform/shared/monitoring"
+	"github.com/rideshare-platform/shared/notifications"
 	trippb "github.com/rideshare-platform/shared/proto/trip"
+	sharedtls "github.com/rideshare-platform/shared/tls"
 )
 
 func main() {
-	// Create logger
-	logr := logger.NewLogger("info", "development")
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Create logger, following log_level changes in the config file
+	logr := logger.NewLogger(cfg.Dynamic.LogLevel, cfg.Environment)
 	logr.Info("Starting Trip Service...")
+	dynamicConfig, err := sharedconfig.NewReloadable(sharedconfig.NewLoader())
+	if err != nil {
+		log.Fatalf("Failed to load dynamic config: %v", err)
+	}
+	dynamicConfig.BindLogLevel(logr)
+	watchCtx, stopWatching := context.WithCancel(context.Background())
+	defer stopWatching()
+	go dynamicConfig.StartWatching(watchCtx, 30*time.Second, logr)
+
+	tlsReloader, err := sharedtls.Load(cfg.TLS, logr)
+	if err != nil {
+		log.Fatalf("Failed to load TLS certificates: %v", err)
+	}
+	go tlsReloader.Watch(watchCtx)
+
+	// Outgoing calls carry the request's correlation ID to downstream services
+	dialOptions := append(sharedgrpc.ClientOptions(), tlsReloader.DialOption())
+
+	// Downstream services are optional, the health report is degraded while one is down
+	healthChecker := sharedhealth.NewChecker("trip-service", "1.0.0")
+	healthChecker.SetConfig(cfg)
 
 	// Create service
 	tripService := service.NewBasicTripService(logr)
 
+	// MongoDB keeps in-trip messages and trip listings when they are
+	// configured to survive restarts
+	var mongoDB *mongo.Database
+	if cfg.ChatStore == "mongo" || cfg.TripListingStore == "mongo" {
+		mongoClient, err := mongo.Connect(context.Background(), options.Client().ApplyURI(cfg.MongoURI))
+		if err != nil {
+			log.Fatalf("Failed to connect to MongoDB: %v", err)
+		}
+		defer mongoClient.Disconnect(context.Background())
+		if err := mongoClient.Ping(context.Background(), nil); err != nil {
+			log.Fatalf("Failed to ping MongoDB: %v", err)
+		}
+		mongoDB = mongoClient.Database(cfg.MongoDatabase)
+		healthChecker.AddCheck("mongodb", func(ctx context.Context) error {
+			return mongoClient.Ping(ctx, nil)
+		})
+	}
+
+	// Trip lifecycle behind the REST API, charged at completion for the route actually driven
+	tripStore := repository.NewMemoryTripStore()
+	trips := service.NewTripService(tripStore, logr)
+	if err := trips.SetDefaultCurrency(cfg.DefaultCurrency); err != nil {
+		log.Fatalf("Invalid DEFAULT_CURRENCY: %v", err)
+	}
+	tripEvents := repository.NewMemoryTripEventLog()
+	trips.SetEventLog(tripEvents)
+
+	// Rider, driver and status trip lists are served from listings the trip
+	// event log is projected into, rather than scanned out of the trip store
+	var listings types.TripListingStore = repository.NewMemoryTripListingStore()
+	if cfg.TripListingStore == "mongo" {
+		mongoListings := repository.NewMongoTripListingStore(mongoDB)
+		if err := mongoListings.EnsureIndexes(context.Background()); err != nil {
+			log.Fatalf("Failed to prepare trip listing store: %v", err)
+		}
+		listings = mongoListings
+	}
+	projectionConfig := service.DefaultProjectionConfig()
+	projectionConfig.MaxLag = cfg.TripProjectionMaxLag
+	projector := service.NewTripProjector(tripEvents, tripStore, listings, projectionConfig, logr)
+	if _, err := projector.Project(context.Background()); err != nil {
+		log.Fatalf("Failed to project trip listings: %v", err)
+	}
+	trips.SetReadModel(listings)
+	healthChecker.AddOptionalCheck("trip-listings", projector.Probe)
+	projectionCtx, stopProjection := context.WithCancel(context.Background())
+	defer stopProjection()
+	go projector.Start(projectionCtx, cfg.TripProjectionInterval)
+
+	// Idempotency keys are kept in Redis when IDEMPOTENCY_STORE=redis so a
+	// retried trip request finds the trip it created whichever replica it
+	// reaches
+	var idempotencyStore types.TripIdempotencyStore = repository.NewMemoryTripIdempotencyStore()
+	if cfg.IdempotencyStore == "redis" {
+		redisDB, err := database.NewRedisDB(cfg.Redis(), logr)
+		if err != nil {
+			log.Fatalf("Failed to connect to Redis: %v", err)
+		}
+		defer redisDB.Close()
+		healthChecker.AddCheck("redis", redisDB.Health)
+		idempotencyStore = repository.NewRedisTripIdempotencyStore(redisDB.Client)
+	}
+	trips.SetIdempotency(idempotencyStore, cfg.IdempotencyKeyTTL)
+
+	// Cities with compliance report templates get their trip data reported
+	// to their regulators on the templates' schedules
+	var compliance *service.ComplianceService
+	if cfg.CitiesFile != "" {
+		cities, err := city.Load(cfg.CitiesFile)
+		if err != nil {
+			log.Fatalf("Failed to load cities: %v", err)
+		}
+		trips.SetCities(cities)
+
+		templates, err := service.LoadComplianceTemplates(cfg.CitiesFile, cities)
+		if err != nil {
+			log.Fatalf("Failed to load compliance report templates: %v", err)
+		}
+		compliance = service.NewComplianceService(tripStore, cities, templates, repository.NewMemoryComplianceStore(), logr)
+		compliance.SetHashSecret(cfg.ComplianceHashSecret)
+	}
+	if cfg.TripShareSecret != "" {
+		trips.SetShareLinks([]byte(cfg.TripShareSecret), cfg.TripShareTTL)
+	}
+	sharedTripService := service.NewSharedTripService(repository.NewMemorySharedTripStore(), logr)
+
+	// Scheduled rides are pre-dispatched to matching-service ahead of pickup
+	var dispatcher service.MatchingDispatcher
+	if conn, err := grpc.NewClient(cfg.MatchingServiceAddr, dialOptions...); err != nil {
+		log.Printf("Failed to create matching-service client, scheduled rides will not be dispatched: %v", err)
+	} else {
+		defer conn.Close()
+		dispatcher = client.NewGRPCMatchingClient(conn)
+		healthChecker.AddOptionalCheck("matching-service", sharedhealth.GRPCProbe(conn))
+	}
+	scheduledRideService := service.NewScheduledRideService(repository.NewMemoryScheduledRideStore(), dispatcher, service.ScheduledRideConfig{
+		LeadTime:   time.Duration(cfg.ScheduledRideLeadMinutes) * time.Minute,
+		MinNotice:  time.Duration(cfg.ScheduledRideMinNoticeMinutes) * time.Minute,
+		MaxAdvance: time.Duration(cfg.ScheduledRideMaxAdvanceDays) * 24 * time.Hour,
+		RetryDelay: service.DefaultScheduledRideConfig().RetryDelay,
+	}, logr)
+
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	defer stopScheduler()
+	schedulerDone := make(chan struct{})
+	go func() {
+		defer close(schedulerDone)
+		scheduledRideService.StartScheduler(schedulerCtx, time.Duration(cfg.ScheduledRideSweepSeconds)*time.Second)
+	}()
+
+	// Riders and drivers rate each other once a trip completes
+	ratingService := service.NewRatingService(repository.NewMemoryRatingStore(), service.DefaultRatingConfig(), logr)
+
+	// Trip status changes and receipts are announced as events riders and drivers are notified about
+	eventBus := events.NewInMemoryEventBus(logr)
+	eventPublisher := events.NewEventPublisher(eventBus, events.NewInMemoryEventStore(logr), logr)
+	defer eventPublisher.Close()
+
+	// Consumers of trip events retry failures with backoff and dead-letter
+	// the events that still fail, for operators to replay or discard through
+	// the gateway's admin API
+	deadLetters := events.NewDeadLetterQueue(events.NewInMemoryDeadLetterStore(), logr)
+
+	notifier := notifications.NewDispatcher(notifications.NewMemoryStore(), logr)
+	for _, channel := range []notifications.Channel{notifications.ChannelPush, notifications.ChannelSMS, notifications.ChannelEmail} {
+		notifier.RegisterProvider(notifications.NewLogProvider(channel, logr))
+	}
+	if err := notifier.SubscribeEvents(deadLetters.Consumer("notifications", eventPublisher, events.DefaultRetryPolicy()), notifications.DefaultEventRoutes()); err != nil {
+		log.Fatalf("Failed to subscribe notifications to trip events: %v", err)
+	}
+
+	// Drivers connected to this instance follow their trip assignments,
+	// cancellations and waiting riders' locations as they are published
+	driverEvents := service.NewDriverEventHub(logr)
+	if err := driverEvents.SubscribeEvents(eventPublisher); err != nil {
+		log.Fatalf("Failed to subscribe driver events to trip events: %v", err)
+	}
+
+	// Completed trips get a receipt built from pricing, payment and vehicle details
+	receiptService := service.NewReceiptService(repository.NewMemoryReceiptStore(), logr)
+	receiptService.SetRatingService(ratingService)
+	receiptService.SetEventPublisher(eventPublisher)
+	if conn, err := grpc.NewClient(cfg.PricingServiceAddr, dialOptions...); err != nil {
+		log.Printf("Failed to create pricing-service client, receipts will not include fares: %v", err)
+	} else {
+		defer conn.Close()
+		pricingClient := client.NewGRPCPricingClient(conn)
+		receiptService.SetFareCalculator(pricingClient)
+		trips.SetFarePricer(pricingClient)
+		healthChecker.AddOptionalCheck("pricing-service", sharedhealth.GRPCProbe(conn))
+	}
+	// Alerts for error rates and latencies, reconciliation mismatches and
+	// trip emergencies go out over the channels configured in the environment
+	alertManager := alerting.NewAlertManager(nil, logr)
+
+	// Riders and drivers can raise an SOS during a trip; incidents are worked
+	// by support staff
+	emergencies := service.NewEmergencyService(tripStore, repository.NewMemoryIncidentStore(), logr)
+	emergencies.SetAlerter(alertManager)
+
+	// Riders dispute what they were charged; support staff review disputes
+	// against the trip's route and fare and can refund part of the charge
+	disputes := service.NewDisputeService(tripStore, repository.NewMemoryDisputeStore(), service.DefaultDisputeConfig(), logr)
+
+	// Riders and drivers message each other while the driver is on the trip.
+	// Messages for a recipient who is not connected wait in the chat store.
+	var messageStore types.TripMessageStore = repository.NewMemoryTripMessageStore()
+	if cfg.ChatStore == "mongo" {
+		mongoMessages := repository.NewMongoTripMessageStore(mongoDB)
+		if err := mongoMessages.EnsureIndexes(context.Background()); err != nil {
+			log.Fatalf("Failed to prepare trip message store: %v", err)
+		}
+		messageStore = mongoMessages
+	}
+	chat := service.NewChatService(tripStore, messageStore, logr)
+
+	// Riders report items left behind on completed trips; the driver is
+	// notified and the trip chat reopens so they can arrange a return
+	lostItems := service.NewLostItemService(tripStore, repository.NewMemoryLostItemStore(), service.DefaultLostItemConfig(), logr)
+	lostItems.SetEventPublisher(eventPublisher)
+	chat.SetContactWindows(lostItems)
+
+	// Riders and drivers call each other through masked numbers or in-app
+	// calls while the driver is on the trip; sessions end with the trip
+	contacts := service.NewTripContactService(tripStore, contactproxy.NewManager(
+		contactproxy.NewLocalProvider(cfg.ContactProxyNumbers, logr),
+		contactproxy.NewMemoryStore(),
+		contactproxy.Config{Mode: contactproxy.ModeMaskedNumber, TTL: cfg.ContactSessionTTL},
+		logr,
+	), logr)
+	if err := contacts.SubscribeEvents(deadLetters.Consumer("trip-contacts", eventPublisher, events.DefaultRetryPolicy())); err != nil {
+		log.Fatalf("Failed to subscribe contact sessions to trip events: %v", err)
+	}
+	if conn, err := grpc.NewClient(cfg.UserServiceAddr, dialOptions...); err != nil {
+		log.Printf("Failed to create user-service client, calls will use in-app VoIP only and notifications the default locale: %v", err)
+	} else {
+		defer conn.Close()
+		userClient := client.NewGRPCUserClient(conn)
+		contacts.SetPhoneLookup(userClient)
+		notifier.SetLocaleLookup(userClient)
+		healthChecker.AddOptionalCheck("user-service", sharedhealth.GRPCProbe(conn))
+	}
+	contactCtx, stopContacts := context.WithCancel(context.Background())
+	defer stopContacts()
+	go contacts.StartSweeper(contactCtx, cfg.ContactSweepInterval)
+
+	// Riders are promised a pickup within the ETA they are matched with and
+	// credited when the driver arrives too late
+	guaranteeConfig := service.DefaultPickupGuaranteeConfig()
+	guaranteeConfig.GracePeriod = cfg.PickupGuaranteeGracePeriod
+	guaranteeConfig.CreditAmount = cfg.PickupGuaranteeCredit
+	pickupGuarantees := service.NewPickupGuaranteeService(tripStore, repository.NewMemoryPickupGuaranteeStore(), guaranteeConfig, logr)
+	if err := pickupGuarantees.SubscribeEvents(deadLetters.Consumer("pickup-guarantees", eventPublisher, events.DefaultRetryPolicy())); err != nil {
+		log.Fatalf("Failed to subscribe pickup guarantees to trip events: %v", err)
+	}
+
+	// Drivers are matched on how often they cancel and complete their trips
+	var reliabilityStore types.DriverReliabilityStore = repository.NewMemoryDriverReliabilityStore(cfg.DriverReliabilityHalfLife)
+	if cfg.DriverReliabilityStore == "postgres" {
+		db, err := sql.Open("postgres", cfg.PostgresDSN())
+		if err != nil {
+			log.Fatalf("Failed to connect to database: %v", err)
+		}
+		defer db.Close()
+		if err := db.Ping(); err != nil {
+			log.Fatalf("Failed to ping database: %v", err)
+		}
+		if cfg.MigrateOnStartup {
+			schema, err := migrations.Load()
+			if err != nil {
+				log.Fatalf("Failed to load migrations: %v", err)
+			}
+			if _, err := database.NewMigrator(db, "trip-service", schema, logr).Up(context.Background()); err != nil {
+				log.Fatalf("Failed to migrate database: %v", err)
+			}
+		}
+		reliabilityStore = repository.NewPostgresDriverReliabilityStore(db, cfg.DriverReliabilityHalfLife)
+		healthChecker.AddCheck("postgres-driver-reliability", db.PingContext)
+	}
+	driverReliability := service.NewDriverReliabilityService(reliabilityStore)
+	if err := driverReliability.SubscribeEvents(deadLetters.Consumer("driver-reliability", eventPublisher, events.DefaultRetryPolicy())); err != nil {
+		log.Fatalf("Failed to subscribe driver reliability to trip events: %v", err)
+	}
+
+	var reconciliation *service.ReconciliationService
+	if conn, err := grpc.NewClient(cfg.PaymentServiceAddr, dialOptions...); err != nil {
+		log.Printf("Failed to create payment-service client, receipts will not include payments, trips will not be reconciled, disputes cannot be refunded, late pickups will not be credited, cancellations will not be refunded and fares will not be held or charged: %v", err)
+	} else {
+		defer conn.Close()
+		paymentClient := client.NewGRPCPaymentClient(conn)
+		receiptService.SetPaymentLookup(paymentClient)
+		disputes.SetRefunder(paymentClient)
+		pickupGuarantees.SetCrediter(paymentClient)
+		// No-shows and cancellations by the platform are refunded under
+		// payment-service's refund policy
+		if err := service.NewRefundTriggerService(paymentClient).SubscribeEvents(deadLetters.Consumer("refund-triggers", eventPublisher, events.DefaultRetryPolicy())); err != nil {
+			log.Fatalf("Failed to subscribe refund triggers to trip events: %v", err)
+		}
+		trips.SetFareHolds(paymentClient)
+		healthChecker.AddOptionalCheck("payment-service", sharedhealth.GRPCProbe(conn))
+
+		// Completed trips are reconciled against their charges every night,
+		// mismatches raise alerts
+		for _, rule := range service.ReconciliationAlertRules() {
+			alertManager.AddRule(rule)
+		}
+		reconciliation = service.NewReconciliationService(tripStore, paymentClient, repository.NewMemoryReconciliationStore(), service.ReconciliationConfig{
+			ToleranceCents: cfg.ReconciliationToleranceCents,
+			RunAt:          cfg.ReconciliationRunAt,
+		}, logr)
+		reconciliation.SetAlertEvaluator(alertManager)
+	}
+	if conn, err := grpc.NewClient(cfg.VehicleServiceAddr, dialOptions...); err != nil {
+		log.Printf("Failed to create vehicle-service client, receipts will not include vehicle details: %v", err)
+	} else {
+		defer conn.Close()
+		receiptService.SetVehicleLookup(client.NewGRPCVehicleClient(conn))
+		healthChecker.AddOptionalCheck("vehicle-service", sharedhealth.GRPCProbe(conn))
+	}
+
+	if conn, err := grpc.NewClient(cfg.GeoServiceAddr, dialOptions...); err != nil {
+		log.Printf("Failed to create geo-service client, receipts will use reported trip distances: %v", err)
+	} else {
+		defer conn.Close()
+		routeClient := client.NewGRPCRouteClient(conn)
+		receiptService.SetRouteLookup(routeClient)
+		trips.SetRouteLookup(routeClient)
+		disputes.SetRouteLookup(routeClient)
+		healthChecker.AddOptionalCheck("geo-service", sharedhealth.GRPCProbe(conn))
+	}
+
+	reconciliationCtx, stopReconciliation := context.WithCancel(context.Background())
+	defer stopReconciliation()
+	if reconciliation != nil {
+		go reconciliation.StartNightly(reconciliationCtx)
+	}
+
+	complianceCtx, stopCompliance := context.WithCancel(context.Background())
+	defer stopCompliance()
+	if compliance != nil {
+		go compliance.Start(complianceCtx, cfg.ComplianceReportInterval)
+	}
+
+	// Trip requests, completions, cancellations and ratings are rolled up
+	// hourly for the business metrics endpoint
+	analyticsStore := analytics.Store(analytics.NewMemoryStore())
+	if cfg.AnalyticsDatabaseURL != "" {
+		db, err := sql.Open("postgres", cfg.AnalyticsDatabaseURL)
+		if err != nil {
+			log.Fatalf("Failed to connect to analytics database: %v", err)
+		}
+		defer db.Close()
+		if err := db.Ping(); err != nil {
+			log.Fatalf("Failed to ping analytics database: %v", err)
+		}
+		if cfg.MigrateOnStartup {
+			if err := analytics.Migrate(context.Background(), db, logr); err != nil {
+				log.Fatalf("Failed to migrate analytics database: %v", err)
+			}
+		}
+		analyticsStore = analytics.NewPostgresStore(db)
+		healthChecker.AddCheck("postgres", db.PingContext)
+	} else {
+		log.Printf("ANALYTICS_DATABASE_URL not set, business metrics are kept in memory")
+	}
+	analyticsRecorder := analytics.NewRecorder(analyticsStore, service.AnalyticsSource, logr)
+	trips.SetAnalytics(analyticsRecorder)
+	ratingService.SetAnalytics(analyticsRecorder)
+	disputes.SetAnalytics(analyticsRecorder)
+	pickupGuarantees.SetAnalytics(analyticsRecorder)
+	analyticsCtx, stopAnalytics := context.WithCancel(context.Background())
+	defer stopAnalytics()
+	analyticsDone := make(chan struct{})
+	go func() {
+		defer close(analyticsDone)
+		analyticsRecorder.Start(analyticsCtx, analytics.DefaultFlushInterval)
+	}()
+
+	// Trips are exported to the data warehouse in incremental batches
+	var exporter *export.Exporter
+	exportCtx, stopExport := context.WithCancel(context.Background())
+	defer stopExport()
+	if cfg.Export.Enabled {
+		exporter, err = export.Open(context.Background(), cfg.Export, logr)
+		if err != nil {
+			log.Fatalf("Failed to set up warehouse export: %v", err)
+		}
+		defer exporter.Close()
+		exporter.Register(service.NewTripExport(tripStore))
+		go exporter.Start(exportCtx, cfg.Export.Interval)
+	}
+
+	// Ended trips past their retention period move to the archive, and their
+	// messages are deleted with them
+	var archiver *archive.Archiver
+	archiveCtx, stopArchive := context.WithCancel(context.Background())
+	defer stopArchive()
+	if cfg.Archive.Enabled {
+		archiver, err = archive.Open(context.Background(), cfg.Archive, logr)
+		if err != nil {
+			log.Fatalf("Failed to set up trip archival: %v", err)
+		}
+		defer archiver.Close()
+		archiver.Register(service.NewTripArchive(projector.ArchiveStore(chat.ArchiveStore(tripStore))))
+		go archiver.Start(archiveCtx, cfg.Archive.Interval)
+	}
+
 	// Create gRPC handler
-	grpcHandler := handler.NewGRPCTripHandler(tripService, logr)
+	grpcHandler := handler.NewGRPCTripHandler(tripService, sharedTripService, scheduledRideService, ratingService, receiptService, logr)
+	grpcHandler.SetEventPublisher(eventPublisher)
+	if cfg.ExperimentsFile != "" {
+		registry, err := experiments.Load(cfg.ExperimentsFile)
+		if err != nil {
+			log.Fatalf("Failed to load experiments: %v", err)
+		}
+		grpcHandler.SetExperiments(registry)
+	}
+	grpcHandler.SetTrips(trips)
+	grpcHandler.SetEmergencies(emergencies)
+	grpcHandler.SetDisputes(disputes)
+	grpcHandler.SetLostItems(lostItems)
+	grpcHandler.SetContacts(contacts)
+	grpcHandler.SetPickupGuarantees(pickupGuarantees)
+	grpcHandler.SetDriverReliability(driverReliability)
+	if compliance != nil {
+		grpcHandler.SetCompliance(compliance)
+	}
+	grpcHandler.SetChat(chat)
+	grpcHandler.SetDeadLetters(deadLetters)
+	grpcHandler.SetProjector(projector)
+	grpcHandler.SetDriverEvents(driverEvents)
+
+	// Request counts and latencies for HTTP and gRPC, scraped from /metrics
+	metricsCollector := monitoring.NewMetricsCollector(nil, logr)
+	metricsCollector.SetBusinessMetricsSource(service.NewTripAnalytics(analyticsRecorder, trips, cfg.DefaultCurrency))
 
-	// Create gRPC server
-	grpcServer := grpc.NewServer()
+	// Error rates, availability and latencies are evaluated against the
+	// alert rules on every ingestion
+	ingestionCtx, stopIngestion := context.WithCancel(context.Background())
+	defer stopIngestion()
+	go monitoring.NewMetricsIngester(metricsCollector, alertManager, logr).StartIngestion(ingestionCtx, cfg.MetricsIngestInterval)
+
+	// Create gRPC server. Share tokens are their own credential, so the
+	// public trip tracking calls carry no bearer token.
+	auth := interceptor.JWTAuth(cfg.JWTSecret, cfg.GRPCAuthRequired)
+	if auth != nil {
+		auth.PublicMethods = []string{
+			trippb.TripService_GetTripByShareToken_FullMethodName,
+			trippb.TripService_WatchTripByShareToken_FullMethodName,
+		}
+	}
+	serverOptions := interceptor.ServerOptions(interceptor.Config{
+		Service:  "trip-service",
+		Logger:   logr,
+		Metrics:  metricsCollector,
+		Auth:     auth,
+		Deadline: interceptor.DefaultDeadlineConfig(),
+	})
+	grpcServer := grpc.NewServer(append(serverOptions, tlsReloader.ServerOptions()...)...)
 	trippb.RegisterTripServiceServer(grpcServer, grpcHandler)
 	// Register gRPC health service
 	healthServer := health.NewServer()
 	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
 	healthpb.RegisterHealthServer(grpcServer, healthServer)
 
-	// Start gRPC server
-	listener, err := net.Listen("tcp", ":50053")
-	if err != nil {
-		log.Fatalf("Failed to listen on port 50053: %v", err)
+	// HTTP health endpoint, scheduled ride API, receipts, notification
+	// preferences, business metrics, reconciliation, the export manifest and
+	// archived trips. The operations endpoints take admin tokens only.
+	httpAuth := middleware.NewAuthMiddleware(cfg.JWTSecret, logr)
+	requireAdmin := httpAuth.RequireAdmin
+	mux := http.NewServeMux()
+	mux.Handle("/health", healthChecker.Handler())
+	mux.Handle("/info", healthChecker.InfoHandler())
+	mux.Handle("/metrics", monitoring.Handler())
+	handler.NewScheduledRideHandler(scheduledRideService).RegisterRoutes(mux)
+	handler.NewReceiptHandler(receiptService).RegisterRoutes(mux)
+	handler.NewNotificationHandler(notifier).RegisterRoutes(mux)
+	handler.NewAnalyticsHandler(metricsCollector).RegisterRoutes(mux)
+	if reconciliation != nil {
+		handler.NewReconciliationHandler(reconciliation, requireAdmin).RegisterRoutes(mux)
+	}
+	if exporter != nil {
+		export.NewHandler(exporter, requireAdmin).RegisterRoutes(mux)
+	}
+	if archiver != nil {
+		archive.NewHandler(archiver, requireAdmin).RegisterRoutes(mux)
 	}
 
-	logr.Info("Trip Service gRPC server listening on port 50053")
+	// The trip REST API is served by Gin, everything else falls through to the mux
+	gin.SetMode(gin.ReleaseMode)
+	router := gin.New()
+	router.Use(gin.Recovery())
+	handler.NewTripHandler(trips).RegisterRoutes(router)
+	handler.NewIncidentHandler(emergencies).RegisterRoutes(router)
+	handler.NewDisputeHandler(disputes).RegisterRoutes(router)
+	handler.NewLostItemHandler(lostItems).RegisterRoutes(router)
+	handler.NewTripContactHandler(contacts, httpAuth).RegisterRoutes(router)
+	handler.NewPickupGuaranteeHandler(pickupGuarantees).RegisterRoutes(router)
+	handler.NewTripChatHandler(chat, httpAuth).RegisterRoutes(router)
+	router.NoRoute(gin.WrapH(mux))
 
-	if err := grpcServer.Serve(listener); err != nil {
-		log.Fatalf("Failed to serve gRPC server: %v", err)
+	requestLogger := middleware.NewLoggingMiddleware(logr)
+	httpServer := &http.Server{
+		Addr:    fmt.Sprintf(":%d", cfg.HTTPPort),
+		Handler: requestLogger.HTTPRequestLogger(metricsCollector.HTTPMiddleware("trip-service", router)),
 	}
-	// Minimal HTTP health endpoint
+
+	// Bind the gRPC port before serving anything so a port clash fails startup outright
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.GRPCPort))
+	if err != nil {
+		log.Fatalf("Failed to listen on port %d: %v", cfg.GRPCPort, err)
+	}
+
+	// Both servers run concurrently; the first to fail triggers shutdown
+	serverErrors := make(chan error, 2)
 	go func() {
-		http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusOK)
-			w.Write([]byte(`{"status": "healthy", "service": "trip-service"}`))
-		})
-		if err := http.ListenAndServe(":8085", nil); err != nil {
-			log.Fatalf("Failed to start HTTP health server: %v", err)
+		logr.WithField("port", cfg.GRPCPort).Info("Trip Service gRPC server listening")
+		if err := grpcServer.Serve(listener); err != nil {
+			serverErrors <- fmt.Errorf("gRPC server: %w", err)
+		}
+	}()
+	go func() {
+		logr.WithField("port", cfg.HTTPPort).Info("Trip Service HTTP server listening")
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			serverErrors <- fmt.Errorf("HTTP server: %w", err)
 		}
 	}()
 
+	// Wait for interrupt signal to gracefully shutdown the servers
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	select {
+	case sig := <-quit:
+		logr.WithField("signal", sig.String()).Info("Shutting down Trip Service...")
+	case err := <-serverErrors:
+		logr.WithError(err).Error("Server failed, shutting down Trip Service...")
+	}
+
+	// Stop advertising readiness, then let the current scheduler sweep finish
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	stopScheduler()
+	stopReconciliation()
+	stopIngestion()
+	stopCompliance()
+	stopExport()
+	stopArchive()
+	stopProjection()
+	<-schedulerDone
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	// In-flight HTTP requests and trip RPCs drain before the servers stop
+	if err := httpServer.Shutdown(ctx); err != nil {
+		logr.WithError(err).Error("HTTP server forced to shutdown")
+	}
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		logr.Warn("gRPC server did not drain in time, forcing stop")
+		grpcServer.Stop()
+	}
+
+	// Rollups recorded by the drained requests are flushed last
+	stopAnalytics()
+	<-analyticsDone
+
+	logr.Info("Trip Service stopped")
 }
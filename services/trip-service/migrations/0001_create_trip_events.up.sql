@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS trip_events (
+    id VARCHAR(64) PRIMARY KEY,
+    trip_id VARCHAR(64) NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    event_data JSONB NOT NULL,
+    timestamp TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    version INTEGER NOT NULL,
+    user_id VARCHAR(64),
+    UNIQUE (trip_id, version)
+);
@@ -0,0 +1,31 @@
+-- Read model projected from trip_events
+CREATE TABLE IF NOT EXISTS trips (
+    id VARCHAR(64) PRIMARY KEY,
+    rider_id VARCHAR(64) NOT NULL,
+    driver_id VARCHAR(64),
+    vehicle_id VARCHAR(64),
+    state VARCHAR(30) NOT NULL,
+    pickup_location JSONB NOT NULL,
+    destination_location JSONB NOT NULL,
+    current_location JSONB,
+    requested_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    matched_at TIMESTAMP WITH TIME ZONE,
+    started_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    cancelled_at TIMESTAMP WITH TIME ZONE,
+    estimated_fare DOUBLE PRECISION,
+    actual_fare DOUBLE PRECISION,
+    distance DOUBLE PRECISION,
+    duration DOUBLE PRECISION, -- seconds
+    rating DOUBLE PRECISION,
+    vehicle_type VARCHAR(20),
+    payment_method VARCHAR(30),
+    metadata JSONB,
+    version INTEGER NOT NULL DEFAULT 0,
+    last_updated TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_trips_rider_id ON trips(rider_id);
+CREATE INDEX IF NOT EXISTS idx_trips_driver_id ON trips(driver_id);
+CREATE INDEX IF NOT EXISTS idx_trips_state ON trips(state);
+CREATE INDEX IF NOT EXISTS idx_trips_requested_at ON trips(requested_at);
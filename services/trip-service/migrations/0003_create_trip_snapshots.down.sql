@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_trip_events_timestamp;
+DROP TABLE IF EXISTS trip_snapshots;
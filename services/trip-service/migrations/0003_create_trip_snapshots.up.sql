@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS trip_snapshots (
+    trip_id VARCHAR(64) NOT NULL,
+    version INTEGER NOT NULL,
+    state JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (trip_id, version)
+);
+
+CREATE INDEX IF NOT EXISTS idx_trip_events_timestamp ON trip_events(timestamp);
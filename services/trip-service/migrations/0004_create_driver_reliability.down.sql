@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS driver_reliability_trips;
+DROP TABLE IF EXISTS driver_reliability;
@@ -0,0 +1,19 @@
+-- Decayed counts of the trips each driver completed or cancelled, halving
+-- with age so drivers' recent trips weigh most in their cancellation rate
+CREATE TABLE IF NOT EXISTS driver_reliability (
+    driver_id VARCHAR(64) PRIMARY KEY,
+    trips DOUBLE PRECISION NOT NULL DEFAULT 0,
+    cancellations DOUBLE PRECISION NOT NULL DEFAULT 0,
+    completion_streak DOUBLE PRECISION NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Trips counted towards each driver's reliability, so a trip's repeated
+-- events are counted once
+CREATE TABLE IF NOT EXISTS driver_reliability_trips (
+    driver_id VARCHAR(64) NOT NULL,
+    trip_id VARCHAR(64) NOT NULL,
+    cancelled BOOLEAN NOT NULL,
+    recorded_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (driver_id, trip_id)
+);
@@ -0,0 +1,46 @@
+// Command migrate applies and rolls back the user-service database schema.
+//
+//	migrate up | down [N] | version | status
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+
+	_ "github.com/lib/pq"
+	"github.com/rideshare-platform/services/user-service/internal/config"
+	"github.com/rideshare-platform/services/user-service/migrations"
+	"github.com/rideshare-platform/shared/database"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	db, err := sql.Open("postgres", fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
+		cfg.DatabaseHost, cfg.DatabasePort, cfg.DatabaseUser,
+		cfg.DatabasePassword, cfg.DatabaseName, cfg.DatabaseSSLMode))
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	schema, err := migrations.Load()
+	if err != nil {
+		return err
+	}
+	migrator := database.NewMigrator(db, "user-service", schema, logger.NewLogger(cfg.LogLevel, cfg.Environment))
+	return database.RunMigrateCommand(context.Background(), migrator, args, os.Stdout)
+}
@@ -0,0 +1,48 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	geopb "github.com/rideshare-platform/shared/proto/geo"
+)
+
+// GRPCGeoClient reads and deletes trip routes through geo-service's gRPC API
+type GRPCGeoClient struct {
+	client geopb.GeospatialServiceClient
+}
+
+// NewGRPCGeoClient creates a new geo client
+func NewGRPCGeoClient(conn grpc.ClientConnInterface) *GRPCGeoClient {
+	return &GRPCGeoClient{client: geopb.NewGeospatialServiceClient(conn)}
+}
+
+// TripRoute returns the route recorded during a trip, or nil if none was
+func (c *GRPCGeoClient) TripRoute(ctx context.Context, tripID string) (json.RawMessage, error) {
+	resp, err := c.client.GetTripRoute(ctx, &geopb.GetTripRouteRequest{TripId: tripID})
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return protojson.Marshal(resp)
+}
+
+// DeleteTripRoutes deletes the routes recorded during the trips and returns
+// the number of points deleted
+func (c *GRPCGeoClient) DeleteTripRoutes(ctx context.Context, tripIDs []string) (int64, error) {
+	if len(tripIDs) == 0 {
+		return 0, nil
+	}
+	resp, err := c.client.DeleteTripRoutes(ctx, &geopb.DeleteTripRoutesRequest{TripIds: tripIDs})
+	if err != nil {
+		return 0, err
+	}
+	return resp.DeletedPoints, nil
+}
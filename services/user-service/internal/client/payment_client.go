@@ -0,0 +1,83 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	paymentpb "github.com/rideshare-platform/shared/proto/payment"
+)
+
+// GRPCPaymentClient reads a user's payments and removes their saved payment
+// methods through payment-service's gRPC API
+type GRPCPaymentClient struct {
+	client paymentpb.PaymentServiceClient
+}
+
+// NewGRPCPaymentClient creates a new payment client
+func NewGRPCPaymentClient(conn grpc.ClientConnInterface) *GRPCPaymentClient {
+	return &GRPCPaymentClient{client: paymentpb.NewPaymentServiceClient(conn)}
+}
+
+// UserPayments returns every payment the user made, paging through
+// payment-service's GetUserPayments
+func (c *GRPCPaymentClient) UserPayments(ctx context.Context, userID string) ([]json.RawMessage, error) {
+	var (
+		payments []json.RawMessage
+		cursor   string
+	)
+	for {
+		resp, err := c.client.GetUserPayments(ctx, &paymentpb.GetUserPaymentsRequest{
+			UserId: userID,
+			Limit:  pageSize,
+			Cursor: cursor,
+		})
+		if err != nil {
+			return nil, err
+		}
+		page, err := encodeAll(resp.Payments)
+		if err != nil {
+			return nil, err
+		}
+		payments = append(payments, page...)
+		if resp.NextCursor == "" || len(resp.Payments) == 0 {
+			return payments, nil
+		}
+		cursor = resp.NextCursor
+	}
+}
+
+// UserPaymentMethods returns the user's saved payment methods, masked as
+// payment-service returns them
+func (c *GRPCPaymentClient) UserPaymentMethods(ctx context.Context, userID string) ([]json.RawMessage, error) {
+	resp, err := c.client.GetUserPaymentMethods(ctx, &paymentpb.GetUserPaymentMethodsRequest{UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	return encodeAll(resp.PaymentMethods)
+}
+
+// RemoveUserPaymentMethods deletes the user's saved payment methods
+func (c *GRPCPaymentClient) RemoveUserPaymentMethods(ctx context.Context, userID string) (int64, error) {
+	resp, err := c.client.RemoveUserPaymentMethods(ctx, &paymentpb.RemoveUserPaymentMethodsRequest{UserId: userID})
+	if err != nil {
+		return 0, err
+	}
+	return int64(resp.RemovedCount), nil
+}
+
+// encodeAll encodes proto messages as JSON
+func encodeAll[M proto.Message](messages []M) ([]json.RawMessage, error) {
+	encoded := make([]json.RawMessage, 0, len(messages))
+	for _, message := range messages {
+		data, err := protojson.Marshal(message)
+		if err != nil {
+			return nil, err
+		}
+		encoded = append(encoded, data)
+	}
+	return encoded, nil
+}
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/rideshare-platform/services/user-service/internal/service"
+	trippb "github.com/rideshare-platform/shared/proto/trip"
+)
+
+// pageSize is the number of records fetched per call when paging through a
+// user's data
+const pageSize = 100
+
+// GRPCTripClient reads and anonymizes a user's trips through trip-service's gRPC API
+type GRPCTripClient struct {
+	client trippb.TripServiceClient
+}
+
+// NewGRPCTripClient creates a new trip client
+func NewGRPCTripClient(conn grpc.ClientConnInterface) *GRPCTripClient {
+	return &GRPCTripClient{client: trippb.NewTripServiceClient(conn)}
+}
+
+// UserTrips returns every trip the user rode or drove, paging through
+// trip-service's GetUserTrips, and the trips' IDs
+func (c *GRPCTripClient) UserTrips(ctx context.Context, userID string) ([]json.RawMessage, []string, error) {
+	var trips []json.RawMessage
+	var tripIDs []string
+	var cursor string
+	for {
+		resp, err := c.client.GetUserTrips(ctx, &trippb.GetUserTripsRequest{
+			UserId: userID,
+			Limit:  pageSize,
+			Cursor: cursor,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, trip := range resp.Trips {
+			encoded, err := protojson.Marshal(trip)
+			if err != nil {
+				return nil, nil, err
+			}
+			trips = append(trips, encoded)
+			tripIDs = append(tripIDs, trip.Id)
+		}
+		if resp.NextCursor == "" || len(resp.Trips) == 0 {
+			return trips, tripIDs, nil
+		}
+		cursor = resp.NextCursor
+	}
+}
+
+// AnonymizeUserTrips scrubs the user's personal data from their trips
+func (c *GRPCTripClient) AnonymizeUserTrips(ctx context.Context, userID string) ([]string, error) {
+	resp, err := c.client.AnonymizeUserTrips(ctx, &trippb.AnonymizeUserTripsRequest{UserId: userID})
+	if status.Code(err) == codes.FailedPrecondition {
+		return nil, service.ErrUserHasActiveTrip
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp.TripIds, nil
+}
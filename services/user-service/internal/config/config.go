@@ -3,6 +3,10 @@ package config
 import (
 	"os"
 	"strconv"
+
+	sharedcrypto "github.com/rideshare-platform/shared/crypto"
+	sharedtls "github.com/rideshare-platform/shared/tls"
+	"github.com/rideshare-platform/shared/uploads"
 )
 
 // Config holds all configuration for the user service
@@ -10,6 +14,16 @@ type Config struct {
 	HTTPPort    string
 	Environment string
 	LogLevel    string
+	JWTSecret   string
+
+	// Mutual TLS for gRPC traffic between services
+	TLS sharedtls.Config
+
+	// Encryption of emails and phone numbers at rest
+	Encryption sharedcrypto.Config
+
+	// Onboarding document uploads through pre-signed object store URLs
+	Uploads uploads.Config
 
 	// Database configuration
 	DatabaseHost     string
@@ -18,14 +32,46 @@ type Config struct {
 	DatabasePassword string
 	DatabaseName     string
 	DatabaseSSLMode  string
+
+	// Apply pending schema migrations before serving
+	MigrateOnStartup bool
+
+	// Services holding the rest of a user's personal data, for privacy
+	// exports and erasure
+	TripServiceAddr    string
+	PaymentServiceAddr string
+	GeoServiceAddr     string
 }
 
 // Load loads configuration from environment variables
 func Load() (*Config, error) {
+	tlsConfig, err := sharedtls.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	encryptionConfig, err := sharedcrypto.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	if err := encryptionConfig.Validate(); err != nil {
+		return nil, err
+	}
+	uploadsConfig, err := uploads.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	if err := uploadsConfig.Validate(); err != nil {
+		return nil, err
+	}
+
 	return &Config{
 		HTTPPort:    getEnv("HTTP_PORT", "8081"),
 		Environment: getEnv("ENVIRONMENT", "development"),
 		LogLevel:    getEnv("LOG_LEVEL", "info"),
+		JWTSecret:   getEnv("JWT_SECRET", "your-secret-key-change-in-production"),
+		TLS:         tlsConfig,
+		Encryption:  encryptionConfig,
+		Uploads:     uploadsConfig,
 
 		// Database configuration
 		DatabaseHost:     getEnv("DATABASE_HOST", "localhost"),
@@ -34,6 +80,12 @@ func Load() (*Config, error) {
 		DatabasePassword: getEnv("DATABASE_PASSWORD", "rideshare_password"),
 		DatabaseName:     getEnv("DATABASE_NAME", "rideshare"),
 		DatabaseSSLMode:  getEnv("DATABASE_SSL_MODE", "disable"),
+
+		MigrateOnStartup: getEnvAsBool("MIGRATE_ON_STARTUP", false),
+
+		TripServiceAddr:    getEnv("TRIP_SERVICE_ADDR", "trip-service:50053"),
+		PaymentServiceAddr: getEnv("PAYMENT_SERVICE_ADDR", "payment-service:8055"),
+		GeoServiceAddr:     getEnv("GEO_SERVICE_ADDR", "geo-service:50053"),
 	}, nil
 }
 
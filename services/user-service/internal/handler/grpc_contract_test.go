@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"testing"
+
+	"google.golang.org/grpc"
+
+	"github.com/rideshare-platform/services/user-service/internal/service"
+	"github.com/rideshare-platform/shared/clients/contract"
+	"github.com/rideshare-platform/shared/clients/userclient"
+	userpb "github.com/rideshare-platform/shared/proto/user"
+)
+
+func TestGRPCUserHandler_ServesContract(t *testing.T) {
+	// Empty requests are rejected before any repository is used
+	grpcHandler := NewGRPCUserHandler(
+		service.NewUserService(nil),
+		service.NewOnboardingService(nil, nil),
+		service.NewDriverProfileService(nil),
+	)
+
+	conn := contract.Serve(t, func(server *grpc.Server) {
+		userpb.RegisterUserServiceServer(server, grpcHandler)
+	})
+	contract.Check(t, userclient.ContractCalls(conn),
+		// Accounts are managed over HTTP; driver locations are kept by geo-service
+		"CreateUser",
+		"UpdateUser",
+		"ListUsers",
+		"GetDriver",
+		"UpdateDriverLocation",
+	)
+}
@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/rideshare-platform/services/user-service/internal/service"
+	"github.com/rideshare-platform/shared/models"
+	userpb "github.com/rideshare-platform/shared/proto/user"
+)
+
+// GRPCUserHandler handles gRPC requests for user service
+type GRPCUserHandler struct {
+	userpb.UnimplementedUserServiceServer
+	userService       *service.UserService
+	onboardingService *service.OnboardingService
+	driverProfiles    *service.DriverProfileService
+}
+
+// NewGRPCUserHandler creates a new gRPC user handler
+func NewGRPCUserHandler(userService *service.UserService, onboardingService *service.OnboardingService, driverProfiles *service.DriverProfileService) *GRPCUserHandler {
+	return &GRPCUserHandler{
+		userService:       userService,
+		onboardingService: onboardingService,
+		driverProfiles:    driverProfiles,
+	}
+}
+
+var userStatusFromProto = map[userpb.UserStatus]models.UserStatus{
+	userpb.UserStatus_ACTIVE:    models.UserStatusActive,
+	userpb.UserStatus_INACTIVE:  models.UserStatusInactive,
+	userpb.UserStatus_SUSPENDED: models.UserStatusSuspended,
+	userpb.UserStatus_BANNED:    models.UserStatusBanned,
+}
+
+var userStatusToProto = map[models.UserStatus]userpb.UserStatus{
+	models.UserStatusActive:    userpb.UserStatus_ACTIVE,
+	models.UserStatusInactive:  userpb.UserStatus_INACTIVE,
+	models.UserStatusSuspended: userpb.UserStatus_SUSPENDED,
+	models.UserStatusBanned:    userpb.UserStatus_BANNED,
+}
+
+var userRoleToProto = map[models.UserType]userpb.UserRole{
+	models.UserTypeRider:  userpb.UserRole_RIDER,
+	models.UserTypeDriver: userpb.UserRole_DRIVER,
+	models.UserTypeAdmin:  userpb.UserRole_ADMIN,
+}
+
+// GetUser returns a user's account and profile, for services that contact
+// users or write to them in their locale
+func (h *GRPCUserHandler) GetUser(ctx context.Context, req *userpb.GetUserRequest) (*userpb.GetUserResponse, error) {
+	if req.Id == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "User ID is required")
+	}
+
+	user, err := h.userService.GetUser(ctx, req.Id)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if user == nil {
+		return &userpb.GetUserResponse{Found: false}, nil
+	}
+
+	return &userpb.GetUserResponse{
+		Found: true,
+		User: &userpb.User{
+			Id:        user.ID,
+			Email:     user.Email,
+			Phone:     user.Phone,
+			FirstName: user.FirstName,
+			LastName:  user.LastName,
+			Role:      userRoleToProto[user.UserType],
+			Status:    userStatusToProto[user.Status],
+			CreatedAt: timestamppb.New(user.CreatedAt),
+			UpdatedAt: timestamppb.New(user.UpdatedAt),
+			Profile: &userpb.UserProfile{
+				AvatarUrl:         user.ProfileImageURL,
+				PreferredLanguage: user.Locale,
+				EmailVerified:     user.EmailVerified,
+				PhoneVerified:     user.PhoneVerified,
+			},
+		},
+	}, nil
+}
+
+// UpdateUserStatus changes an account's status, as operators do to ban a user
+func (h *GRPCUserHandler) UpdateUserStatus(ctx context.Context, req *userpb.UpdateUserStatusRequest) (*userpb.UpdateUserStatusResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "User ID is required")
+	}
+	userStatus, ok := userStatusFromProto[req.Status]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown user status %s", req.Status)
+	}
+
+	user, err := h.userService.SetUserStatus(ctx, req.UserId, userStatus)
+	switch {
+	case errors.Is(err, service.ErrUserNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrInvalidUserStatus):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case err != nil:
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &userpb.UpdateUserStatusResponse{
+		UserId: user.ID,
+		Status: req.Status,
+	}, nil
+}
+
+// GetDriverOnboardingStatus reports whether a driver has been approved to go online
+func (h *GRPCUserHandler) GetDriverOnboardingStatus(ctx context.Context, req *userpb.GetDriverOnboardingStatusRequest) (*userpb.GetDriverOnboardingStatusResponse, error) {
+	if req.DriverId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Driver ID is required")
+	}
+
+	onboarding, err := h.onboardingService.GetOnboarding(ctx, req.DriverId)
+	if errors.Is(err, service.ErrOnboardingNotFound) {
+		return &userpb.GetDriverOnboardingStatusResponse{DriverId: req.DriverId}, nil
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &userpb.GetDriverOnboardingStatusResponse{
+		DriverId:              onboarding.DriverID,
+		Status:                string(onboarding.Status),
+		BackgroundCheckStatus: string(onboarding.BackgroundCheckStatus),
+		Approved:              onboarding.IsApproved(),
+	}, nil
+}
+
+// GetDriverProfiles returns the public profiles of several drivers at once
+func (h *GRPCUserHandler) GetDriverProfiles(ctx context.Context, req *userpb.GetDriverProfilesRequest) (*userpb.GetDriverProfilesResponse, error) {
+	profiles, err := h.driverProfiles.GetDriverProfiles(ctx, req.DriverIds)
+	if errors.Is(err, service.ErrInvalidProfileRequest) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &userpb.GetDriverProfilesResponse{Profiles: make(map[string]*userpb.DriverProfile, len(profiles))}
+	for driverID, profile := range profiles {
+		resp.Profiles[driverID] = &userpb.DriverProfile{
+			DriverId:   profile.DriverID,
+			FirstName:  profile.FirstName,
+			LastName:   profile.LastName,
+			PhotoUrl:   profile.PhotoURL,
+			Rating:     profile.Rating,
+			TotalTrips: int32(profile.TotalTrips),
+		}
+	}
+	return resp, nil
+}
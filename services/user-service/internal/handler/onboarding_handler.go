@@ -0,0 +1,341 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rideshare-platform/services/user-service/internal/service"
+	"github.com/rideshare-platform/shared/middleware"
+	"github.com/rideshare-platform/shared/uploads"
+)
+
+// OnboardingHandler handles HTTP requests for driver onboarding
+type OnboardingHandler struct {
+	onboardingService *service.OnboardingService
+	auth              *middleware.AuthMiddleware
+	uploader          *uploads.Uploader
+}
+
+// NewOnboardingHandler creates a new onboarding handler
+func NewOnboardingHandler(onboardingService *service.OnboardingService, auth *middleware.AuthMiddleware) *OnboardingHandler {
+	return &OnboardingHandler{
+		onboardingService: onboardingService,
+		auth:              auth,
+	}
+}
+
+// SetUploader lets drivers upload their documents straight to the object
+// store instead of passing a file URL they host themselves
+func (h *OnboardingHandler) SetUploader(uploader *uploads.Uploader) {
+	h.uploader = uploader
+}
+
+// RegisterRoutes registers the driver and admin onboarding routes
+func (h *OnboardingHandler) RegisterRoutes(router *gin.Engine) {
+	drivers := router.Group("/api/v1/drivers/:id/onboarding", h.auth.JWTAuth(), h.auth.RequireUserType("driver"), requireSelf)
+	{
+		drivers.POST("", h.StartOnboarding)
+		drivers.GET("", h.GetOnboarding)
+		drivers.POST("/documents", h.UploadDocument)
+		if h.uploader != nil {
+			drivers.POST("/documents/uploads", h.RequestDocumentUpload)
+			drivers.POST("/documents/uploads/:document_id/complete", h.CompleteDocumentUpload)
+		}
+		drivers.POST("/submit", h.SubmitForReview)
+	}
+
+	admin := router.Group("/api/v1/admin/driver-onboarding", h.auth.JWTAuth(), h.auth.RequireUserType("admin"))
+	{
+		admin.GET("", h.ListOnboardings)
+		admin.GET("/:id", h.GetOnboarding)
+		admin.POST("/:id/background-check", h.UpdateBackgroundCheck)
+		admin.POST("/:id/approve", h.ApproveDriver)
+		admin.POST("/:id/reject", h.RejectDriver)
+	}
+}
+
+// UploadDocumentRequest represents the metadata of an uploaded onboarding document
+type UploadDocumentRequest struct {
+	Type           service.DocumentType `json:"type" binding:"required"`
+	FileURL        string               `json:"file_url" binding:"required"`
+	DocumentNumber string               `json:"document_number"`
+	ExpiresAt      *time.Time           `json:"expires_at"`
+}
+
+// DocumentUploadRequest asks for a URL to upload an onboarding document to
+type DocumentUploadRequest struct {
+	Type           service.DocumentType `json:"type" binding:"required"`
+	ContentType    string               `json:"content_type" binding:"required"`
+	DocumentNumber string               `json:"document_number"`
+	ExpiresAt      *time.Time           `json:"expires_at"`
+}
+
+// BackgroundCheckRequest represents a background check result
+type BackgroundCheckRequest struct {
+	Status service.BackgroundCheckStatus `json:"status" binding:"required"`
+}
+
+// RejectDriverRequest represents the request to reject a driver application
+type RejectDriverRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// StartOnboarding opens an onboarding application for the driver
+func (h *OnboardingHandler) StartOnboarding(c *gin.Context) {
+	onboarding, err := h.onboardingService.StartOnboarding(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		onboardingError(c, "Failed to start onboarding", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, onboarding)
+}
+
+// GetOnboarding returns a driver's onboarding application
+func (h *OnboardingHandler) GetOnboarding(c *gin.Context) {
+	onboarding, err := h.onboardingService.GetOnboarding(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		onboardingError(c, "Failed to get onboarding", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, onboarding)
+}
+
+// UploadDocument records the metadata of an uploaded document
+func (h *OnboardingHandler) UploadDocument(c *gin.Context) {
+	var req UploadDocumentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	onboarding, err := h.onboardingService.UploadDocument(c.Request.Context(), c.Param("id"), &service.DriverDocument{
+		Type:           req.Type,
+		FileURL:        req.FileURL,
+		DocumentNumber: req.DocumentNumber,
+		ExpiresAt:      req.ExpiresAt,
+	})
+	if err != nil {
+		onboardingError(c, "Failed to upload document", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, onboarding)
+}
+
+// RequestDocumentUpload returns a pre-signed URL the driver uploads a
+// document's file to. The document is added to the application once the
+// upload is completed and passes its checks.
+func (h *OnboardingHandler) RequestDocumentUpload(c *gin.Context) {
+	var req DocumentUploadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	upload, err := h.uploader.RequestUpload(c.Request.Context(), &uploads.UploadRequest{
+		OwnerType:      uploads.OwnerDriver,
+		OwnerID:        c.Param("id"),
+		DocumentType:   string(req.Type),
+		DocumentNumber: req.DocumentNumber,
+		ContentType:    req.ContentType,
+		ExpiresAt:      req.ExpiresAt,
+	})
+	if err != nil {
+		uploadError(c, "Failed to request document upload", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, upload)
+}
+
+// CompleteDocumentUpload checks and scans an uploaded file and adds the
+// accepted document to the driver's application
+func (h *OnboardingHandler) CompleteDocumentUpload(c *gin.Context) {
+	doc, err := h.uploader.Get(c.Request.Context(), c.Param("document_id"))
+	if err == nil && (doc.OwnerType != uploads.OwnerDriver || doc.OwnerID != c.Param("id")) {
+		err = uploads.ErrDocumentNotFound
+	}
+	if err != nil {
+		uploadError(c, "Failed to complete document upload", err)
+		return
+	}
+
+	if _, err := h.uploader.CompleteUpload(c.Request.Context(), doc.ID); err != nil {
+		uploadError(c, "Failed to complete document upload", err)
+		return
+	}
+
+	onboarding, err := h.onboardingService.GetOnboarding(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		onboardingError(c, "Failed to get onboarding", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, onboarding)
+}
+
+// SubmitForReview sends the driver's documents for admin review
+func (h *OnboardingHandler) SubmitForReview(c *gin.Context) {
+	onboarding, err := h.onboardingService.SubmitForReview(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		onboardingError(c, "Failed to submit for review", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, onboarding)
+}
+
+// ListOnboardings lists applications in a status, defaulting to those awaiting review
+func (h *OnboardingHandler) ListOnboardings(c *gin.Context) {
+	status := service.OnboardingStatus(c.DefaultQuery("status", string(service.OnboardingStatusDocumentsSubmitted)))
+
+	limit := 10
+	offset := 0
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			offset = o
+		}
+	}
+
+	onboardings, err := h.onboardingService.ListOnboardings(c.Request.Context(), status, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to list onboarding applications",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"applications": onboardings,
+		"count":        len(onboardings),
+	})
+}
+
+// UpdateBackgroundCheck records a driver's background check result
+func (h *OnboardingHandler) UpdateBackgroundCheck(c *gin.Context) {
+	var req BackgroundCheckRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	onboarding, err := h.onboardingService.UpdateBackgroundCheck(c.Request.Context(), c.Param("id"), req.Status)
+	if err != nil {
+		onboardingError(c, "Failed to update background check", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, onboarding)
+}
+
+// ApproveDriver approves a driver's application so they can go online
+func (h *OnboardingHandler) ApproveDriver(c *gin.Context) {
+	reviewerID, _ := middleware.GetUserID(c)
+
+	onboarding, err := h.onboardingService.ApproveDriver(c.Request.Context(), c.Param("id"), reviewerID)
+	if err != nil {
+		onboardingError(c, "Failed to approve driver", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, onboarding)
+}
+
+// RejectDriver rejects a driver's application with a reason
+func (h *OnboardingHandler) RejectDriver(c *gin.Context) {
+	var req RejectDriverRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	reviewerID, _ := middleware.GetUserID(c)
+
+	onboarding, err := h.onboardingService.RejectDriver(c.Request.Context(), c.Param("id"), reviewerID, req.Reason)
+	if err != nil {
+		onboardingError(c, "Failed to reject driver", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, onboarding)
+}
+
+// requireSelf only lets drivers act on their own onboarding application
+func requireSelf(c *gin.Context) {
+	if userID, ok := middleware.GetUserID(c); !ok || userID != c.Param("id") {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "Drivers can only manage their own onboarding",
+		})
+		c.Abort()
+		return
+	}
+	c.Next()
+}
+
+func uploadError(c *gin.Context, message string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, uploads.ErrDocumentNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, uploads.ErrInvalidUpload):
+		status = http.StatusBadRequest
+	case errors.Is(err, uploads.ErrNotUploaded):
+		status = http.StatusConflict
+	case errors.Is(err, uploads.ErrDocumentRejected):
+		status = http.StatusUnprocessableEntity
+	case errors.Is(err, service.ErrOnboardingNotFound),
+		errors.Is(err, service.ErrInvalidOnboardingTransition),
+		errors.Is(err, service.ErrInvalidDocument):
+		onboardingError(c, message, err)
+		return
+	}
+
+	c.JSON(status, gin.H{
+		"error":   message,
+		"details": err.Error(),
+	})
+}
+
+func onboardingError(c *gin.Context, message string, err error) {
+	status := http.StatusBadRequest
+	switch {
+	case errors.Is(err, service.ErrOnboardingNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, service.ErrNotADriver):
+		status = http.StatusForbidden
+	case errors.Is(err, service.ErrInvalidOnboardingTransition),
+		errors.Is(err, service.ErrMissingDocuments),
+		errors.Is(err, service.ErrBackgroundCheckNotClear):
+		status = http.StatusConflict
+	}
+
+	c.JSON(status, gin.H{
+		"error":   message,
+		"details": err.Error(),
+	})
+}
@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rideshare-platform/services/user-service/internal/service"
+	"github.com/rideshare-platform/shared/middleware"
+)
+
+// PrivacyHandler handles HTTP requests for data subject requests: exports
+// of a user's personal data and its erasure
+type PrivacyHandler struct {
+	privacyService *service.PrivacyService
+	auth           *middleware.AuthMiddleware
+}
+
+// NewPrivacyHandler creates a new privacy handler
+func NewPrivacyHandler(privacyService *service.PrivacyService, auth *middleware.AuthMiddleware) *PrivacyHandler {
+	return &PrivacyHandler{
+		privacyService: privacyService,
+		auth:           auth,
+	}
+}
+
+// RegisterRoutes registers the user and admin privacy routes
+func (h *PrivacyHandler) RegisterRoutes(router *gin.Engine) {
+	users := router.Group("/api/v1/users/:id/privacy", h.auth.JWTAuth(), requireSelfOrAdmin)
+	{
+		users.GET("/export", h.ExportUserData)
+		users.POST("/erasure", h.EraseUserData)
+		users.GET("/requests", h.ListUserRequests)
+	}
+
+	admin := router.Group("/api/v1/admin/privacy-requests", h.auth.JWTAuth(), h.auth.RequireUserType("admin"))
+	{
+		admin.GET("", h.ListRequests)
+		admin.GET("/:id", h.GetRequest)
+	}
+}
+
+// ExportUserData downloads a zip archive of the user's personal data
+func (h *PrivacyHandler) ExportUserData(c *gin.Context) {
+	requestedBy, _ := middleware.GetUserID(c)
+
+	request, archive, err := h.privacyService.ExportUserData(c.Request.Context(), c.Param("id"), requestedBy)
+	if err != nil {
+		privacyError(c, "Failed to export user data", request, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="user-data-%s.zip"`, request.UserID))
+	c.Header("X-Privacy-Request-ID", request.ID)
+	c.Data(http.StatusOK, "application/zip", archive)
+}
+
+// EraseUserData anonymizes the user's personal data across services,
+// keeping their financial records
+func (h *PrivacyHandler) EraseUserData(c *gin.Context) {
+	requestedBy, _ := middleware.GetUserID(c)
+
+	request, err := h.privacyService.EraseUserData(c.Request.Context(), c.Param("id"), requestedBy)
+	if err != nil {
+		privacyError(c, "Failed to erase user data", request, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+// ListUserRequests lists the privacy requests made for a user
+func (h *PrivacyHandler) ListUserRequests(c *gin.Context) {
+	h.listRequests(c, c.Param("id"))
+}
+
+// ListRequests lists the privacy requests of every user, most recent first
+func (h *PrivacyHandler) ListRequests(c *gin.Context) {
+	h.listRequests(c, c.Query("user_id"))
+}
+
+// GetRequest returns one logged privacy request
+func (h *PrivacyHandler) GetRequest(c *gin.Context) {
+	request, err := h.privacyService.GetRequest(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		privacyError(c, "Failed to get privacy request", nil, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+func (h *PrivacyHandler) listRequests(c *gin.Context, userID string) {
+	limit := 20
+	offset := 0
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = l
+		}
+	}
+
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			offset = o
+		}
+	}
+
+	requests, err := h.privacyService.ListRequests(c.Request.Context(), userID, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   "Failed to list privacy requests",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"requests": requests,
+		"count":    len(requests),
+	})
+}
+
+// requireSelfOrAdmin lets users act on their own data and admins on anyone's
+func requireSelfOrAdmin(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if ok && (userID == c.Param("id") || c.GetString("user_type") == "admin") {
+		c.Next()
+		return
+	}
+	c.JSON(http.StatusForbidden, gin.H{
+		"error": "Users can only manage their own data",
+	})
+	c.Abort()
+}
+
+// privacyError writes an error response, including the logged request when
+// there is one so the failed step can be looked up
+func privacyError(c *gin.Context, message string, request *service.PrivacyRequest, err error) {
+	status := http.StatusBadRequest
+	switch {
+	case errors.Is(err, service.ErrUserNotFound), errors.Is(err, service.ErrPrivacyRequestNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, service.ErrUserHasActiveTrip):
+		status = http.StatusConflict
+	case errors.Is(err, service.ErrPrivacyRequestFailed):
+		status = http.StatusBadGateway
+	}
+
+	body := gin.H{
+		"error":   message,
+		"details": err.Error(),
+	}
+	if request != nil {
+		body["request"] = request
+	}
+	c.JSON(status, body)
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rideshare-platform/services/user-service/internal/service"
+	"github.com/rideshare-platform/shared/middleware"
+	"github.com/rideshare-platform/shared/uploads"
+)
+
+// ProfilePhotoHandler handles HTTP requests for drivers' profile photos
+type ProfilePhotoHandler struct {
+	uploader *uploads.Uploader
+	auth     *middleware.AuthMiddleware
+}
+
+// NewProfilePhotoHandler creates a new profile photo handler
+func NewProfilePhotoHandler(uploader *uploads.Uploader, auth *middleware.AuthMiddleware) *ProfilePhotoHandler {
+	return &ProfilePhotoHandler{
+		uploader: uploader,
+		auth:     auth,
+	}
+}
+
+// PhotoUploadRequest asks for a URL to upload a profile photo to
+type PhotoUploadRequest struct {
+	ContentType string `json:"content_type" binding:"required"`
+}
+
+// RegisterRoutes registers the driver profile photo routes
+func (h *ProfilePhotoHandler) RegisterRoutes(router *gin.Engine) {
+	drivers := router.Group("/api/v1/drivers/:id/photo", h.auth.JWTAuth(), h.auth.RequireUserType("driver"), requireSelf)
+	{
+		drivers.POST("/uploads", h.RequestPhotoUpload)
+		drivers.POST("/uploads/:document_id/complete", h.CompletePhotoUpload)
+	}
+}
+
+// RequestPhotoUpload returns a pre-signed URL the driver uploads a profile
+// photo to. It becomes their photo once the upload is completed and passes
+// its checks.
+func (h *ProfilePhotoHandler) RequestPhotoUpload(c *gin.Context) {
+	var req PhotoUploadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	upload, err := h.uploader.RequestUpload(c.Request.Context(), &uploads.UploadRequest{
+		OwnerType:    uploads.OwnerDriver,
+		OwnerID:      c.Param("id"),
+		DocumentType: service.ProfilePhotoDocumentType,
+		ContentType:  req.ContentType,
+	})
+	if err != nil {
+		uploadError(c, "Failed to request photo upload", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, upload)
+}
+
+// CompletePhotoUpload checks and scans an uploaded photo, makes it the
+// driver's profile photo and returns the URLs it is served at in each size
+func (h *ProfilePhotoHandler) CompletePhotoUpload(c *gin.Context) {
+	doc, err := h.uploader.Get(c.Request.Context(), c.Param("document_id"))
+	if err == nil && (doc.OwnerType != uploads.OwnerDriver || doc.OwnerID != c.Param("id") || doc.DocumentType != service.ProfilePhotoDocumentType) {
+		err = uploads.ErrDocumentNotFound
+	}
+	if err == nil {
+		_, err = h.uploader.CompleteUpload(c.Request.Context(), doc.ID)
+	}
+	if err != nil {
+		uploadError(c, "Failed to complete photo upload", err)
+		return
+	}
+
+	urls, err := h.uploader.PhotoURLs(c.Request.Context(), doc.ID)
+	if err != nil {
+		uploadError(c, "Failed to sign photo URLs", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"document_id": doc.ID,
+		"variants":    urls,
+	})
+}
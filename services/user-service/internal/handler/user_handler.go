@@ -1,32 +1,41 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/rideshare-platform/services/user-service/internal/metrics"
 	"github.com/rideshare-platform/services/user-service/internal/service"
+	"github.com/rideshare-platform/shared/health"
 	"github.com/rideshare-platform/shared/models"
 )
 
 // UserHandler handles HTTP requests for user operations
 type UserHandler struct {
 	userService *service.UserService
+	health      *health.Checker
 }
 
 // NewUserHandler creates a new user handler
 func NewUserHandler(userService *service.UserService) *UserHandler {
 	return &UserHandler{
 		userService: userService,
+		health:      health.NewChecker("user-service", "1.0.0"),
 	}
 }
 
+// SetHealthChecker attaches the checker that probes the service's dependencies
+func (h *UserHandler) SetHealthChecker(checker *health.Checker) {
+	h.health = checker
+}
+
 // RegisterRoutes registers user routes
 func (h *UserHandler) RegisterRoutes(router *gin.Engine) {
 	// Health check endpoint
 	router.GET("/health", h.healthCheck)
+	router.GET("/info", h.info)
 
 	users := router.Group("/api/v1/users")
 	{
@@ -47,6 +56,8 @@ type CreateUserRequest struct {
 	LastName  string          `json:"last_name" binding:"required"`
 	UserType  models.UserType `json:"user_type" binding:"required"`
 	Password  string          `json:"password" binding:"required"`
+	// Locale is the BCP 47 locale the user is notified in, e.g. pt-BR
+	Locale string `json:"locale"`
 }
 
 // UpdateUserRequest represents the request to update a user
@@ -57,6 +68,7 @@ type UpdateUserRequest struct {
 	LastName  string            `json:"last_name"`
 	UserType  models.UserType   `json:"user_type"`
 	Status    models.UserStatus `json:"status"`
+	Locale    string            `json:"locale"`
 }
 
 // AuthRequest represents the authentication request
@@ -78,6 +90,7 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 
 	// Create user model
 	user := models.NewUser(req.Email, req.Phone, req.FirstName, req.LastName, req.UserType)
+	user.Locale = req.Locale
 
 	// Create user
 	createdUser, err := h.userService.CreateUser(c.Request.Context(), user)
@@ -145,6 +158,7 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 		LastName:  req.LastName,
 		UserType:  req.UserType,
 		Status:    req.Status,
+		Locale:    req.Locale,
 	}
 
 	updatedUser, err := h.userService.UpdateUser(c.Request.Context(), user)
@@ -228,6 +242,13 @@ func (h *UserHandler) AuthenticateUser(c *gin.Context) {
 	}
 
 	user, err := h.userService.AuthenticateUser(c.Request.Context(), req.Email, req.Password)
+	if errors.Is(err, service.ErrAccountBlocked) {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error":   "Authentication failed",
+			"details": err.Error(),
+		})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusUnauthorized, gin.H{
 			"error":   "Authentication failed",
@@ -244,12 +265,10 @@ func (h *UserHandler) AuthenticateUser(c *gin.Context) {
 
 // healthCheck returns the health status of the service
 func (h *UserHandler) healthCheck(c *gin.Context) {
-	response := map[string]interface{}{
-		"status":    "healthy",
-		"service":   "user-service",
-		"timestamp": time.Now().UTC().Format(time.RFC3339),
-		"version":   "1.0.0",
-	}
+	h.health.Handler().ServeHTTP(c.Writer, c.Request)
+}
 
-	c.JSON(http.StatusOK, response)
+// info returns the running build's metadata
+func (h *UserHandler) info(c *gin.Context) {
+	h.health.InfoHandler().ServeHTTP(c.Writer, c.Request)
 }
@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/rideshare-platform/services/user-service/internal/service"
+)
+
+type OnboardingRepository struct {
+	db *sql.DB
+}
+
+func NewOnboardingRepository(db *sql.DB) *OnboardingRepository {
+	return &OnboardingRepository{
+		db: db,
+	}
+}
+
+func (r *OnboardingRepository) CreateOnboarding(ctx context.Context, onboarding *service.DriverOnboarding) (*service.DriverOnboarding, error) {
+	query := `
+		INSERT INTO driver_onboarding (driver_id, status, background_check_status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	_, err := r.db.ExecContext(ctx, query,
+		onboarding.DriverID, onboarding.Status, onboarding.BackgroundCheckStatus,
+		onboarding.CreatedAt, onboarding.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create driver onboarding: %w", err)
+	}
+
+	return onboarding, nil
+}
+
+func (r *OnboardingRepository) GetOnboarding(ctx context.Context, driverID string) (*service.DriverOnboarding, error) {
+	query := `
+		SELECT driver_id, status, background_check_status, rejection_reason, reviewed_by,
+		       submitted_at, reviewed_at, created_at, updated_at
+		FROM driver_onboarding WHERE driver_id = $1`
+
+	onboarding, err := scanOnboarding(r.db.QueryRowContext(ctx, query, driverID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get driver onboarding: %w", err)
+	}
+
+	onboarding.Documents, err = r.getDocuments(ctx, driverID)
+	if err != nil {
+		return nil, err
+	}
+
+	return onboarding, nil
+}
+
+func (r *OnboardingRepository) UpdateOnboarding(ctx context.Context, onboarding *service.DriverOnboarding) error {
+	query := `
+		UPDATE driver_onboarding SET
+		    status = $2, background_check_status = $3, rejection_reason = $4, reviewed_by = $5,
+		    submitted_at = $6, reviewed_at = $7, updated_at = $8
+		WHERE driver_id = $1`
+
+	result, err := r.db.ExecContext(ctx, query,
+		onboarding.DriverID, onboarding.Status, onboarding.BackgroundCheckStatus,
+		nullString(onboarding.RejectionReason), nullString(onboarding.ReviewedBy),
+		onboarding.SubmittedAt, onboarding.ReviewedAt, onboarding.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update driver onboarding: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return service.ErrOnboardingNotFound
+	}
+
+	return nil
+}
+
+func (r *OnboardingRepository) SaveDocument(ctx context.Context, doc *service.DriverDocument) error {
+	query := `
+		INSERT INTO driver_documents (id, driver_id, document_type, file_url, document_number, expires_at, uploaded_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (driver_id, document_type) DO UPDATE SET
+		    id = EXCLUDED.id, file_url = EXCLUDED.file_url, document_number = EXCLUDED.document_number,
+		    expires_at = EXCLUDED.expires_at, uploaded_at = EXCLUDED.uploaded_at`
+
+	_, err := r.db.ExecContext(ctx, query,
+		doc.ID, doc.DriverID, doc.Type, doc.FileURL,
+		nullString(doc.DocumentNumber), doc.ExpiresAt, doc.UploadedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save driver document: %w", err)
+	}
+
+	return nil
+}
+
+func (r *OnboardingRepository) ListOnboardings(ctx context.Context, status service.OnboardingStatus, limit, offset int) ([]*service.DriverOnboarding, error) {
+	query := `
+		SELECT driver_id, status, background_check_status, rejection_reason, reviewed_by,
+		       submitted_at, reviewed_at, created_at, updated_at
+		FROM driver_onboarding WHERE status = $1
+		ORDER BY COALESCE(submitted_at, created_at) ASC LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.QueryContext(ctx, query, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list driver onboarding: %w", err)
+	}
+	defer rows.Close()
+
+	var onboardings []*service.DriverOnboarding
+	for rows.Next() {
+		onboarding, err := scanOnboarding(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan driver onboarding: %w", err)
+		}
+		onboardings = append(onboardings, onboarding)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list driver onboarding: %w", err)
+	}
+
+	for _, onboarding := range onboardings {
+		onboarding.Documents, err = r.getDocuments(ctx, onboarding.DriverID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return onboardings, nil
+}
+
+func (r *OnboardingRepository) getDocuments(ctx context.Context, driverID string) ([]*service.DriverDocument, error) {
+	query := `
+		SELECT id, driver_id, document_type, file_url, document_number, expires_at, uploaded_at
+		FROM driver_documents WHERE driver_id = $1 ORDER BY uploaded_at ASC`
+
+	rows, err := r.db.QueryContext(ctx, query, driverID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get driver documents: %w", err)
+	}
+	defer rows.Close()
+
+	var docs []*service.DriverDocument
+	for rows.Next() {
+		doc := &service.DriverDocument{}
+		var documentNumber sql.NullString
+		var expiresAt sql.NullTime
+		err := rows.Scan(
+			&doc.ID, &doc.DriverID, &doc.Type, &doc.FileURL,
+			&documentNumber, &expiresAt, &doc.UploadedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan driver document: %w", err)
+		}
+		doc.DocumentNumber = documentNumber.String
+		if expiresAt.Valid {
+			doc.ExpiresAt = &expiresAt.Time
+		}
+		docs = append(docs, doc)
+	}
+
+	return docs, rows.Err()
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanOnboarding(row rowScanner) (*service.DriverOnboarding, error) {
+	onboarding := &service.DriverOnboarding{}
+	var rejectionReason, reviewedBy sql.NullString
+	var submittedAt, reviewedAt sql.NullTime
+
+	err := row.Scan(
+		&onboarding.DriverID, &onboarding.Status, &onboarding.BackgroundCheckStatus,
+		&rejectionReason, &reviewedBy, &submittedAt, &reviewedAt,
+		&onboarding.CreatedAt, &onboarding.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	onboarding.RejectionReason = rejectionReason.String
+	onboarding.ReviewedBy = reviewedBy.String
+	if submittedAt.Valid {
+		onboarding.SubmittedAt = &submittedAt.Time
+	}
+	if reviewedAt.Valid {
+		onboarding.ReviewedAt = &reviewedAt.Time
+	}
+
+	return onboarding, nil
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/rideshare-platform/services/user-service/internal/service"
+)
+
+// PrivacyRepository keeps the audit log of data subject requests
+type PrivacyRepository struct {
+	db *sql.DB
+}
+
+func NewPrivacyRepository(db *sql.DB) *PrivacyRepository {
+	return &PrivacyRepository{
+		db: db,
+	}
+}
+
+func (r *PrivacyRepository) CreatePrivacyRequest(ctx context.Context, request *service.PrivacyRequest) error {
+	steps, err := json.Marshal(request.Steps)
+	if err != nil {
+		return fmt.Errorf("failed to encode privacy request steps: %w", err)
+	}
+
+	query := `
+		INSERT INTO privacy_requests (id, user_id, request_type, status, requested_by, steps, error, created_at, completed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err = r.db.ExecContext(ctx, query,
+		request.ID, request.UserID, request.Type, request.Status, request.RequestedBy,
+		steps, nullString(request.Error), request.CreatedAt, request.CompletedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create privacy request: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PrivacyRepository) UpdatePrivacyRequest(ctx context.Context, request *service.PrivacyRequest) error {
+	steps, err := json.Marshal(request.Steps)
+	if err != nil {
+		return fmt.Errorf("failed to encode privacy request steps: %w", err)
+	}
+
+	query := `
+		UPDATE privacy_requests SET status = $2, steps = $3, error = $4, completed_at = $5
+		WHERE id = $1`
+
+	_, err = r.db.ExecContext(ctx, query,
+		request.ID, request.Status, steps, nullString(request.Error), request.CompletedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update privacy request: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PrivacyRepository) GetPrivacyRequest(ctx context.Context, requestID string) (*service.PrivacyRequest, error) {
+	query := `
+		SELECT id, user_id, request_type, status, requested_by, steps, error, created_at, completed_at
+		FROM privacy_requests WHERE id = $1`
+
+	request, err := scanPrivacyRequest(r.db.QueryRowContext(ctx, query, requestID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get privacy request: %w", err)
+	}
+
+	return request, nil
+}
+
+func (r *PrivacyRepository) ListPrivacyRequests(ctx context.Context, userID string, limit, offset int) ([]*service.PrivacyRequest, error) {
+	query := `
+		SELECT id, user_id, request_type, status, requested_by, steps, error, created_at, completed_at
+		FROM privacy_requests
+		WHERE $1 = '' OR user_id::text = $1
+		ORDER BY created_at DESC LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list privacy requests: %w", err)
+	}
+	defer rows.Close()
+
+	var requests []*service.PrivacyRequest
+	for rows.Next() {
+		request, err := scanPrivacyRequest(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan privacy request: %w", err)
+		}
+		requests = append(requests, request)
+	}
+
+	return requests, rows.Err()
+}
+
+func scanPrivacyRequest(row rowScanner) (*service.PrivacyRequest, error) {
+	request := &service.PrivacyRequest{}
+	var steps []byte
+	var requestError sql.NullString
+	var completedAt sql.NullTime
+
+	err := row.Scan(
+		&request.ID, &request.UserID, &request.Type, &request.Status, &request.RequestedBy,
+		&steps, &requestError, &request.CreatedAt, &completedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(steps, &request.Steps); err != nil {
+		return nil, fmt.Errorf("failed to decode privacy request steps: %w", err)
+	}
+	request.Error = requestError.String
+	if completedAt.Valid {
+		request.CompletedAt = &completedAt.Time
+	}
+
+	return request, nil
+}
@@ -7,11 +7,15 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/rideshare-platform/services/user-service/internal/service"
+	sharedcrypto "github.com/rideshare-platform/shared/crypto"
 	"github.com/rideshare-platform/shared/models"
 )
 
 type UserRepository struct {
-	db *sql.DB
+	db     *sql.DB
+	cipher *sharedcrypto.Cipher
 }
 
 func NewUserRepository(db *sql.DB) *UserRepository {
@@ -20,21 +24,34 @@ func NewUserRepository(db *sql.DB) *UserRepository {
 	}
 }
 
+// SetCipher encrypts emails and phone numbers at rest, with blind indexes
+// to look users up by them. Without a cipher they are stored in plaintext.
+func (r *UserRepository) SetCipher(cipher *sharedcrypto.Cipher) {
+	r.cipher = cipher
+}
+
 func (r *UserRepository) CreateUser(ctx context.Context, user *models.User) (*models.User, error) {
 	// Generate UUID if not provided
 	if user.ID == "" {
 		user.ID = uuid.New().String()
 	}
 
+	sealed, err := r.seal(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
-		INSERT INTO users (id, email, phone, password_hash, first_name, last_name, user_type, status, profile_image_url, email_verified, phone_verified)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		INSERT INTO users (id, email, phone, password_hash, first_name, last_name, user_type, status, profile_image_url, email_verified, phone_verified, email_index, phone_index, locale)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 		RETURNING created_at, updated_at`
 
-	err := r.db.QueryRowContext(ctx, query,
-		user.ID, user.Email, user.Phone, user.PasswordHash,
+	err = r.db.QueryRowContext(ctx, query,
+		user.ID, sealed.Email, sealed.Phone, user.PasswordHash,
 		user.FirstName, user.LastName, user.UserType, user.Status,
 		user.ProfileImageURL, user.EmailVerified, user.PhoneVerified,
+		nullString(r.cipher.BlindIndex(user.Email)), nullString(r.cipher.BlindIndex(user.Phone)),
+		nullString(user.Locale),
 	).Scan(&user.CreatedAt, &user.UpdatedAt)
 
 	if err != nil {
@@ -45,19 +62,12 @@ func (r *UserRepository) CreateUser(ctx context.Context, user *models.User) (*mo
 }
 
 func (r *UserRepository) GetUser(ctx context.Context, id string) (*models.User, error) {
-	user := &models.User{}
-
 	query := `
 		SELECT id, email, phone, password_hash, first_name, last_name, user_type, status, 
-		       profile_image_url, email_verified, phone_verified, created_at, updated_at
+		       profile_image_url, email_verified, phone_verified, COALESCE(locale, ''), created_at, updated_at
 		FROM users WHERE id = $1`
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&user.ID, &user.Email, &user.Phone, &user.PasswordHash,
-		&user.FirstName, &user.LastName, &user.UserType, &user.Status,
-		&user.ProfileImageURL, &user.EmailVerified, &user.PhoneVerified,
-		&user.CreatedAt, &user.UpdatedAt,
-	)
+	user, err := r.scanUser(ctx, r.db.QueryRowContext(ctx, query, id))
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -69,20 +79,15 @@ func (r *UserRepository) GetUser(ctx context.Context, id string) (*models.User,
 	return user, nil
 }
 
+// GetUserByEmail finds a user by the blind index of their email, or by the
+// email itself for users stored before encryption was enabled
 func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
-	user := &models.User{}
-
 	query := `
 		SELECT id, email, phone, password_hash, first_name, last_name, user_type, status, 
-		       profile_image_url, email_verified, phone_verified, created_at, updated_at
-		FROM users WHERE email = $1`
+		       profile_image_url, email_verified, phone_verified, COALESCE(locale, ''), created_at, updated_at
+		FROM users WHERE email_index = $1 OR (email_index IS NULL AND email = $2)`
 
-	err := r.db.QueryRowContext(ctx, query, email).Scan(
-		&user.ID, &user.Email, &user.Phone, &user.PasswordHash,
-		&user.FirstName, &user.LastName, &user.UserType, &user.Status,
-		&user.ProfileImageURL, &user.EmailVerified, &user.PhoneVerified,
-		&user.CreatedAt, &user.UpdatedAt,
-	)
+	user, err := r.scanUser(ctx, r.db.QueryRowContext(ctx, query, nullString(r.cipher.BlindIndex(email)), email))
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -97,7 +102,7 @@ func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (*mod
 func (r *UserRepository) ListUsers(ctx context.Context, limit, offset int) ([]*models.User, error) {
 	query := `
 		SELECT id, email, phone, password_hash, first_name, last_name, user_type, status, 
-		       profile_image_url, email_verified, phone_verified, created_at, updated_at
+		       profile_image_url, email_verified, phone_verified, COALESCE(locale, ''), created_at, updated_at
 		FROM users ORDER BY created_at DESC LIMIT $1 OFFSET $2`
 
 	rows, err := r.db.QueryContext(ctx, query, limit, offset)
@@ -108,13 +113,7 @@ func (r *UserRepository) ListUsers(ctx context.Context, limit, offset int) ([]*m
 
 	var users []*models.User
 	for rows.Next() {
-		user := &models.User{}
-		err := rows.Scan(
-			&user.ID, &user.Email, &user.Phone, &user.PasswordHash,
-			&user.FirstName, &user.LastName, &user.UserType, &user.Status,
-			&user.ProfileImageURL, &user.EmailVerified, &user.PhoneVerified,
-			&user.CreatedAt, &user.UpdatedAt,
-		)
+		user, err := r.scanUser(ctx, rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
 		}
@@ -124,22 +123,82 @@ func (r *UserRepository) ListUsers(ctx context.Context, limit, offset int) ([]*m
 	return users, nil
 }
 
+// GetDriverProfiles reads the public profiles of the given drivers
+func (r *UserRepository) GetDriverProfiles(ctx context.Context, driverIDs []string) ([]*service.DriverProfile, error) {
+	query := `
+		SELECT u.id, u.first_name, u.last_name, COALESCE(u.profile_image_url, ''),
+		       COALESCE(u.profile_photo_key, ''), COALESCE(d.rating, 0), COALESCE(d.total_trips, 0)
+		FROM users u JOIN drivers d ON d.user_id = u.id
+		WHERE u.id::text = ANY($1)`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(driverIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get driver profiles: %w", err)
+	}
+	defer rows.Close()
+
+	var profiles []*service.DriverProfile
+	for rows.Next() {
+		profile := &service.DriverProfile{}
+		if err := rows.Scan(
+			&profile.DriverID, &profile.FirstName, &profile.LastName, &profile.PhotoURL,
+			&profile.PhotoKey, &profile.Rating, &profile.TotalTrips,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan driver profile: %w", err)
+		}
+		profiles = append(profiles, profile)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read driver profiles: %w", err)
+	}
+
+	return profiles, nil
+}
+
+// SetProfilePhoto records the object key of a driver's accepted profile photo
+func (r *UserRepository) SetProfilePhoto(ctx context.Context, driverID, key string) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE users SET profile_photo_key = $2, updated_at = NOW() WHERE id = $1`,
+		driverID, key)
+	if err != nil {
+		return fmt.Errorf("failed to set profile photo: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("user not found")
+	}
+
+	return nil
+}
+
 func (r *UserRepository) UpdateUser(ctx context.Context, user *models.User) (*models.User, error) {
 	user.UpdatedAt = time.Now()
 
+	sealed, err := r.seal(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
 		UPDATE users SET 
 		    email = $2, phone = $3, password_hash = $4, first_name = $5, last_name = $6,
 		    user_type = $7, status = $8, profile_image_url = $9, email_verified = $10,
-		    phone_verified = $11, updated_at = $12
+		    phone_verified = $11, updated_at = $12, email_index = $13, phone_index = $14,
+		    locale = $15
 		WHERE id = $1
 		RETURNING updated_at`
 
-	err := r.db.QueryRowContext(ctx, query,
-		user.ID, user.Email, user.Phone, user.PasswordHash,
+	err = r.db.QueryRowContext(ctx, query,
+		user.ID, sealed.Email, sealed.Phone, user.PasswordHash,
 		user.FirstName, user.LastName, user.UserType, user.Status,
 		user.ProfileImageURL, user.EmailVerified, user.PhoneVerified,
 		user.UpdatedAt,
+		nullString(r.cipher.BlindIndex(user.Email)), nullString(r.cipher.BlindIndex(user.Phone)),
+		nullString(user.Locale),
 	).Scan(&user.UpdatedAt)
 
 	if err != nil {
@@ -168,3 +227,147 @@ func (r *UserRepository) DeleteUser(ctx context.Context, id string) error {
 
 	return nil
 }
+
+// AnonymizeUser scrubs a user's personal data in one transaction. Email and
+// phone get unique placeholders, since both columns are unique, and the
+// password hash is cleared so the account can no longer sign in.
+func (r *UserRepository) AnonymizeUser(ctx context.Context, id string, anonymizedAt time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE users SET
+		    email = 'deleted-' || id::text || '@anonymized.invalid',
+		    phone = 'del-' || substr(md5(id::text), 1, 16),
+		    email_index = NULL, phone_index = NULL,
+		    password_hash = '', first_name = 'Deleted', last_name = 'User',
+		    profile_image_url = NULL, profile_photo_key = NULL,
+		    email_verified = FALSE, phone_verified = FALSE,
+		    locale = NULL, status = 'inactive', anonymized_at = $2, updated_at = $2
+		WHERE id = $1`, id, anonymizedAt)
+	if err != nil {
+		return fmt.Errorf("failed to anonymize user: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("user not found")
+	}
+
+	statements := []string{
+		`UPDATE drivers SET
+		    license_number = 'deleted-' || user_id::text,
+		    current_latitude = NULL, current_longitude = NULL, current_location_accuracy = NULL,
+		    last_location_update = NULL, updated_at = $2
+		WHERE user_id = $1`,
+		`DELETE FROM driver_documents WHERE driver_id = $1`,
+		`UPDATE driver_onboarding SET rejection_reason = NULL, updated_at = $2 WHERE driver_id = $1`,
+	}
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement, id, anonymizedAt); err != nil {
+			return fmt.Errorf("failed to anonymize driver: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit anonymization: %w", err)
+	}
+	return nil
+}
+
+// RotateBatch re-encrypts the emails and phone numbers of up to limit users
+// after cursor under the active master key. Users stored before encryption
+// was enabled are encrypted and get their blind indexes.
+func (r *UserRepository) RotateBatch(ctx context.Context, cipher *sharedcrypto.Cipher, cursor string, limit int) (string, int, error) {
+	query := `
+		SELECT id, email, phone, email_index, phone_index
+		FROM users WHERE $1::uuid IS NULL OR id > $1::uuid
+		ORDER BY id LIMIT $2`
+
+	rows, err := r.db.QueryContext(ctx, query, nullString(cursor), limit)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to list users for rotation: %w", err)
+	}
+	type storedUser struct {
+		id, email, phone       string
+		emailIndex, phoneIndex sql.NullString
+	}
+	var users []storedUser
+	for rows.Next() {
+		var user storedUser
+		if err := rows.Scan(&user.id, &user.email, &user.phone, &user.emailIndex, &user.phoneIndex); err != nil {
+			rows.Close()
+			return "", 0, fmt.Errorf("failed to scan user for rotation: %w", err)
+		}
+		users = append(users, user)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return "", 0, fmt.Errorf("failed to read users for rotation: %w", err)
+	}
+	if len(users) == 0 {
+		return "", 0, nil
+	}
+
+	rotated := 0
+	for _, user := range users {
+		plain := &models.User{Email: user.email, Phone: user.phone}
+		if err := cipher.DecryptFields(ctx, plain); err != nil {
+			return "", rotated, fmt.Errorf("failed to decrypt user %s: %w", user.id, err)
+		}
+		sealed := &models.User{Email: user.email, Phone: user.phone}
+		changed, err := cipher.RotateFields(ctx, sealed)
+		if err != nil {
+			return "", rotated, fmt.Errorf("failed to re-encrypt user %s: %w", user.id, err)
+		}
+		emailIndex, phoneIndex := cipher.BlindIndex(plain.Email), cipher.BlindIndex(plain.Phone)
+		if !changed && user.emailIndex.String == emailIndex && user.phoneIndex.String == phoneIndex {
+			continue
+		}
+
+		// Users updated since they were read are left for the next run
+		_, err = r.db.ExecContext(ctx, `
+			UPDATE users SET email = $2, phone = $3, email_index = $4, phone_index = $5
+			WHERE id = $1 AND email = $6 AND phone = $7`,
+			user.id, sealed.Email, sealed.Phone, nullString(emailIndex), nullString(phoneIndex),
+			user.email, user.phone,
+		)
+		if err != nil {
+			return "", rotated, fmt.Errorf("failed to rotate user %s: %w", user.id, err)
+		}
+		rotated++
+	}
+
+	return users[len(users)-1].id, rotated, nil
+}
+
+// seal returns a copy of the user with its encrypted fields sealed
+func (r *UserRepository) seal(ctx context.Context, user *models.User) (*models.User, error) {
+	sealed := *user
+	if err := r.cipher.EncryptFields(ctx, &sealed); err != nil {
+		return nil, fmt.Errorf("failed to encrypt user: %w", err)
+	}
+	return &sealed, nil
+}
+
+func (r *UserRepository) scanUser(ctx context.Context, row rowScanner) (*models.User, error) {
+	user := &models.User{}
+	err := row.Scan(
+		&user.ID, &user.Email, &user.Phone, &user.PasswordHash,
+		&user.FirstName, &user.LastName, &user.UserType, &user.Status,
+		&user.ProfileImageURL, &user.EmailVerified, &user.PhoneVerified, &user.Locale,
+		&user.CreatedAt, &user.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.cipher.DecryptFields(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to decrypt user: %w", err)
+	}
+	return user, nil
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/rideshare-platform/shared/crypto/cryptotest"
+)
+
+func TestUserRepository_RotateBatch(t *testing.T) {
+	ctx := context.Background()
+	oldKey, newKey, indexKey := cryptotest.NewKey(t), cryptotest.NewKey(t), cryptotest.NewKey(t)
+	before := cryptotest.NewCipher(t, map[string][]byte{"2024-01": oldKey}, "2024-01", indexKey)
+	cipher := cryptotest.NewCipher(t, map[string][]byte{"2024-01": oldKey, "2024-07": newKey}, "2024-07", indexKey)
+
+	oldEmail, oldPhone := cryptotest.Seal(t, before, "old@example.com"), cryptotest.Seal(t, before, "+905551110000")
+	currentEmail, currentPhone := cryptotest.Seal(t, cipher, "current@example.com"), cryptotest.Seal(t, cipher, "+905552220000")
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	repo := NewUserRepository(db)
+
+	const selectUsers = `SELECT id, email, phone, email_index, phone_index\s+FROM users`
+	const updateUser = `UPDATE users SET email = \$2, phone = \$3, email_index = \$4, phone_index = \$5`
+
+	mock.ExpectQuery(selectUsers).WithArgs(nil, 3).WillReturnRows(
+		sqlmock.NewRows([]string{"id", "email", "phone", "email_index", "phone_index"}).
+			// Stored before encryption was enabled
+			AddRow("user-1", "plain@example.com", "+905550000000", nil, nil).
+			// Sealed under the retired key
+			AddRow("user-2", oldEmail, oldPhone, cipher.BlindIndex("old@example.com"), cipher.BlindIndex("+905551110000")).
+			// Up to date
+			AddRow("user-3", currentEmail, currentPhone, cipher.BlindIndex("current@example.com"), cipher.BlindIndex("+905552220000")),
+	)
+	mock.ExpectExec(updateUser).WithArgs(
+		"user-1",
+		cryptotest.SealedUnder{Cipher: cipher, Plaintext: "plain@example.com"}, cryptotest.SealedUnder{Cipher: cipher, Plaintext: "+905550000000"},
+		cipher.BlindIndex("plain@example.com"), cipher.BlindIndex("+905550000000"),
+		"plain@example.com", "+905550000000",
+	).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(updateUser).WithArgs(
+		"user-2",
+		cryptotest.SealedUnder{Cipher: cipher, Plaintext: "old@example.com"}, cryptotest.SealedUnder{Cipher: cipher, Plaintext: "+905551110000"},
+		cipher.BlindIndex("old@example.com"), cipher.BlindIndex("+905551110000"),
+		oldEmail, oldPhone,
+	).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	next, rotated, err := repo.RotateBatch(ctx, cipher, "", 3)
+	if err != nil {
+		t.Fatalf("RotateBatch failed: %v", err)
+	}
+	if next != "user-3" || rotated != 2 {
+		t.Errorf("expected to continue after user-3 with 2 rotated, got %q and %d", next, rotated)
+	}
+
+	// The next batch continues from the cursor and finds nothing left
+	mock.ExpectQuery(selectUsers).WithArgs("user-3", 3).WillReturnRows(
+		sqlmock.NewRows([]string{"id", "email", "phone", "email_index", "phone_index"}),
+	)
+	next, rotated, err = repo.RotateBatch(ctx, cipher, "user-3", 3)
+	if err != nil {
+		t.Fatalf("RotateBatch failed: %v", err)
+	}
+	if next != "" || rotated != 0 {
+		t.Errorf("expected the walk to end, got %q and %d", next, rotated)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUserRepository_RotateBatchBackfillsMissingIndexes(t *testing.T) {
+	ctx := context.Background()
+	cipher := cryptotest.NewCipher(t, map[string][]byte{"k1": cryptotest.NewKey(t)}, "k1", cryptotest.NewKey(t))
+	email, err := cipher.Encrypt(ctx, "rider@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	repo := NewUserRepository(db)
+
+	// Sealed under the active key but without a blind index, the phone empty
+	mock.ExpectQuery(`SELECT id, email, phone`).WillReturnRows(
+		sqlmock.NewRows([]string{"id", "email", "phone", "email_index", "phone_index"}).
+			AddRow("user-1", email, "", nil, nil),
+	)
+	mock.ExpectExec(`UPDATE users`).WithArgs(
+		"user-1", email, "", cipher.BlindIndex("rider@example.com"), nil, email, "",
+	).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	_, rotated, err := repo.RotateBatch(ctx, cipher, "", 10)
+	if err != nil {
+		t.Fatalf("RotateBatch failed: %v", err)
+	}
+	if rotated != 1 {
+		t.Errorf("expected the missing index to be written, got %d rotated", rotated)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rideshare-platform/shared/uploads"
+)
+
+// MaxDriverProfileBatch is the most profiles one lookup may ask for
+const MaxDriverProfileBatch = 100
+
+// ProfilePhotoDocumentType is the upload type of the photo riders see of the
+// driver picking them up
+const ProfilePhotoDocumentType = "profile_photo"
+
+// ErrInvalidProfileRequest is returned for empty or oversized profile lookups
+var ErrInvalidProfileRequest = errors.New("invalid driver profile request")
+
+// ProfilePhotoTypes are the photos drivers upload through pre-signed object
+// store URLs
+func ProfilePhotoTypes() []uploads.DocumentType {
+	return []uploads.DocumentType{
+		{Name: ProfilePhotoDocumentType, Owner: uploads.OwnerDriver, Photo: true},
+	}
+}
+
+// DriverProfile is the part of a driver's account shown to riders they are matched with
+type DriverProfile struct {
+	DriverID   string  `json:"driver_id"`
+	FirstName  string  `json:"first_name"`
+	LastName   string  `json:"last_name"`
+	PhotoURL   string  `json:"photo_url,omitempty"`
+	Rating     float64 `json:"rating"`
+	TotalTrips int     `json:"total_trips"`
+
+	// PhotoKey is the object key of an uploaded photo, which is signed into
+	// PhotoURL in place of a stored profile image URL
+	PhotoKey string `json:"-"`
+}
+
+// DriverProfileService looks up public driver profiles for other services
+type DriverProfileService struct {
+	repo  DriverProfileRepositoryInterface
+	media *uploads.Media
+}
+
+// NewDriverProfileService creates a new driver profile service
+func NewDriverProfileService(repo DriverProfileRepositoryInterface) *DriverProfileService {
+	return &DriverProfileService{repo: repo}
+}
+
+// GetDriverProfiles returns the profiles of the given drivers keyed by driver
+// ID. Duplicate and empty IDs are ignored, unknown drivers are left out.
+func (s *DriverProfileService) GetDriverProfiles(ctx context.Context, driverIDs []string) (map[string]*DriverProfile, error) {
+	seen := make(map[string]bool, len(driverIDs))
+	unique := make([]string, 0, len(driverIDs))
+	for _, driverID := range driverIDs {
+		if driverID != "" && !seen[driverID] {
+			seen[driverID] = true
+			unique = append(unique, driverID)
+		}
+	}
+	if len(unique) == 0 {
+		return nil, fmt.Errorf("%w: at least one driver ID is required", ErrInvalidProfileRequest)
+	}
+	if len(unique) > MaxDriverProfileBatch {
+		return nil, fmt.Errorf("%w: at most %d drivers per lookup", ErrInvalidProfileRequest, MaxDriverProfileBatch)
+	}
+
+	found, err := s.repo.GetDriverProfiles(ctx, unique)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := make(map[string]*DriverProfile, len(found))
+	for _, profile := range found {
+		if profile.PhotoKey != "" && s.media != nil {
+			url, err := s.media.URL(profile.PhotoKey, uploads.VariantMedium)
+			if err != nil {
+				return nil, err
+			}
+			profile.PhotoURL = url
+		}
+		profiles[profile.DriverID] = profile
+	}
+	return profiles, nil
+}
+
+// SetMedia sets the signer uploaded profile photos are served through.
+// Without one profiles only carry stored profile image URLs.
+func (s *DriverProfileService) SetMedia(media *uploads.Media) {
+	s.media = media
+}
+
+// ApplyProfilePhoto makes an accepted profile photo the driver's photo. It is
+// registered as an uploads hook.
+func (s *DriverProfileService) ApplyProfilePhoto(ctx context.Context, doc *uploads.Document) error {
+	if doc.OwnerType != uploads.OwnerDriver || doc.DocumentType != ProfilePhotoDocumentType {
+		return nil
+	}
+	if err := s.repo.SetProfilePhoto(ctx, doc.OwnerID, doc.Key); err != nil {
+		return fmt.Errorf("failed to apply profile photo to driver %s: %w", doc.OwnerID, err)
+	}
+	return nil
+}
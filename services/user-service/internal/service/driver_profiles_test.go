@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rideshare-platform/shared/uploads"
+)
+
+// fakeDriverProfileRepository returns the stored profiles of the requested drivers
+type fakeDriverProfileRepository struct {
+	profiles map[string]*DriverProfile
+	lookups  [][]string
+}
+
+func (f *fakeDriverProfileRepository) GetDriverProfiles(ctx context.Context, driverIDs []string) ([]*DriverProfile, error) {
+	f.lookups = append(f.lookups, driverIDs)
+	var found []*DriverProfile
+	for _, driverID := range driverIDs {
+		if profile, ok := f.profiles[driverID]; ok {
+			found = append(found, profile)
+		}
+	}
+	return found, nil
+}
+
+func (f *fakeDriverProfileRepository) SetProfilePhoto(ctx context.Context, driverID, key string) error {
+	profile, ok := f.profiles[driverID]
+	if !ok {
+		return errors.New("user not found")
+	}
+	profile.PhotoKey = key
+	return nil
+}
+
+func TestDriverProfileService_GetDriverProfiles(t *testing.T) {
+	repo := &fakeDriverProfileRepository{profiles: map[string]*DriverProfile{
+		"driver-1": {DriverID: "driver-1", FirstName: "Ada", LastName: "Byron", Rating: 4.9, TotalTrips: 120},
+		"driver-2": {DriverID: "driver-2", FirstName: "Alan", LastName: "Kay", Rating: 4.7, TotalTrips: 40},
+	}}
+	s := NewDriverProfileService(repo)
+	ctx := context.Background()
+
+	profiles, err := s.GetDriverProfiles(ctx, []string{"driver-1", "driver-2", "driver-1", "", "unknown"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(profiles) != 2 || profiles["driver-1"].FirstName != "Ada" || profiles["driver-2"].TotalTrips != 40 {
+		t.Errorf("Expected profiles of driver-1 and driver-2, got %+v", profiles)
+	}
+	if len(repo.lookups) != 1 || len(repo.lookups[0]) != 3 {
+		t.Errorf("Expected one lookup of the three distinct IDs, got %v", repo.lookups)
+	}
+
+	if _, err := s.GetDriverProfiles(ctx, []string{""}); !errors.Is(err, ErrInvalidProfileRequest) {
+		t.Errorf("Expected ErrInvalidProfileRequest for an empty lookup, got %v", err)
+	}
+
+	tooMany := make([]string, MaxDriverProfileBatch+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("driver-%d", i)
+	}
+	if _, err := s.GetDriverProfiles(ctx, tooMany); !errors.Is(err, ErrInvalidProfileRequest) {
+		t.Errorf("Expected ErrInvalidProfileRequest for an oversized lookup, got %v", err)
+	}
+}
+
+func TestDriverProfileService_ProfilePhotos(t *testing.T) {
+	repo := &fakeDriverProfileRepository{profiles: map[string]*DriverProfile{
+		"driver-1": {DriverID: "driver-1", FirstName: "Ada", PhotoURL: "https://example.com/ada-old.jpg"},
+		"driver-2": {DriverID: "driver-2", FirstName: "Alan", PhotoURL: "https://example.com/alan.jpg"},
+	}}
+	s := NewDriverProfileService(repo)
+	s.SetMedia(uploads.NewMedia(uploads.MediaConfig{BaseURL: "https://media.example.com/", SigningKey: "secret", URLTTL: time.Hour}, nil))
+	ctx := context.Background()
+
+	// Onboarding documents are left to the onboarding hook
+	if err := s.ApplyProfilePhoto(ctx, &uploads.Document{OwnerType: uploads.OwnerDriver, OwnerID: "driver-1", DocumentType: "driver_license", Key: "documents/driver/driver-1/doc_1.pdf"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if repo.profiles["driver-1"].PhotoKey != "" {
+		t.Errorf("Expected a licence not to become the profile photo")
+	}
+
+	photo := &uploads.Document{OwnerType: uploads.OwnerDriver, OwnerID: "driver-1", DocumentType: ProfilePhotoDocumentType, Key: "documents/driver/driver-1/doc_2.jpg"}
+	if err := s.ApplyProfilePhoto(ctx, photo); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	profiles, err := s.GetDriverProfiles(ctx, []string{"driver-1", "driver-2"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if url := profiles["driver-1"].PhotoURL; !strings.HasPrefix(url, "https://media.example.com/640x640/documents/driver/driver-1/doc_2.jpg?expires=") || !strings.Contains(url, "&signature=") {
+		t.Errorf("Expected a signed medium-size URL of the uploaded photo, got %q", url)
+	}
+	if url := profiles["driver-2"].PhotoURL; url != "https://example.com/alan.jpg" {
+		t.Errorf("Expected drivers without an uploaded photo to keep their profile image, got %q", url)
+	}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/rideshare-platform/shared/models"
 )
@@ -15,3 +16,42 @@ type UserRepositoryInterface interface {
 	DeleteUser(ctx context.Context, userID string) error
 	ListUsers(ctx context.Context, limit, offset int) ([]*models.User, error)
 }
+
+// OnboardingRepositoryInterface defines the interface for driver onboarding storage
+type OnboardingRepositoryInterface interface {
+	CreateOnboarding(ctx context.Context, onboarding *DriverOnboarding) (*DriverOnboarding, error)
+	// GetOnboarding returns nil when the driver has not started onboarding
+	GetOnboarding(ctx context.Context, driverID string) (*DriverOnboarding, error)
+	UpdateOnboarding(ctx context.Context, onboarding *DriverOnboarding) error
+	// SaveDocument replaces any earlier document of the same type
+	SaveDocument(ctx context.Context, doc *DriverDocument) error
+	ListOnboardings(ctx context.Context, status OnboardingStatus, limit, offset int) ([]*DriverOnboarding, error)
+}
+
+// DriverProfileRepositoryInterface defines the interface for reading driver
+// profiles in bulk and keeping their photos
+type DriverProfileRepositoryInterface interface {
+	// GetDriverProfiles leaves out IDs that are not registered drivers
+	GetDriverProfiles(ctx context.Context, driverIDs []string) ([]*DriverProfile, error)
+	// SetProfilePhoto records the object key of a driver's profile photo
+	SetProfilePhoto(ctx context.Context, driverID, key string) error
+}
+
+// UserAnonymizerInterface defines the interface for scrubbing a user's personal data
+type UserAnonymizerInterface interface {
+	// AnonymizeUser replaces the user's profile with placeholders, deactivates
+	// the account and deletes their driver documents. The user ID is kept so
+	// financial records still resolve to an account.
+	AnonymizeUser(ctx context.Context, userID string, anonymizedAt time.Time) error
+}
+
+// PrivacyRequestRepositoryInterface defines the interface for the privacy request audit log
+type PrivacyRequestRepositoryInterface interface {
+	CreatePrivacyRequest(ctx context.Context, request *PrivacyRequest) error
+	UpdatePrivacyRequest(ctx context.Context, request *PrivacyRequest) error
+	// GetPrivacyRequest returns nil when no request has the ID
+	GetPrivacyRequest(ctx context.Context, requestID string) (*PrivacyRequest, error)
+	// ListPrivacyRequests lists one user's requests, or every user's when
+	// userID is empty, most recent first
+	ListPrivacyRequests(ctx context.Context, userID string, limit, offset int) ([]*PrivacyRequest, error)
+}
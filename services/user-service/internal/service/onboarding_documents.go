@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+
+	"github.com/rideshare-platform/shared/uploads"
+)
+
+// OnboardingDocumentTypes are the onboarding documents drivers upload through
+// pre-signed object store URLs
+func OnboardingDocumentTypes() []uploads.DocumentType {
+	return []uploads.DocumentType{
+		{Name: string(DocumentTypeDriverLicense), Owner: uploads.OwnerDriver, RequiresExpiry: true, RequiresNumber: true},
+		{Name: string(DocumentTypeInsurance), Owner: uploads.OwnerDriver, RequiresExpiry: true, RequiresNumber: true},
+		{Name: string(DocumentTypeVehicleRegistration), Owner: uploads.OwnerDriver, RequiresExpiry: true},
+	}
+}
+
+// ApplyDocument records an accepted upload on the driver's onboarding
+// application, replacing any earlier document of its type. It is registered
+// as an uploads hook.
+func (s *OnboardingService) ApplyDocument(ctx context.Context, doc *uploads.Document) error {
+	if doc.OwnerType != uploads.OwnerDriver || doc.DocumentType == ProfilePhotoDocumentType {
+		return nil
+	}
+
+	_, err := s.UploadDocument(ctx, doc.OwnerID, &DriverDocument{
+		ID:             doc.ID,
+		Type:           DocumentType(doc.DocumentType),
+		FileURL:        doc.URL,
+		DocumentNumber: doc.DocumentNumber,
+		ExpiresAt:      doc.ExpiresAt,
+	})
+	return err
+}
@@ -0,0 +1,373 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rideshare-platform/shared/models"
+)
+
+// OnboardingStatus represents where a driver is in the onboarding workflow
+type OnboardingStatus string
+
+const (
+	OnboardingStatusPending            OnboardingStatus = "pending"
+	OnboardingStatusDocumentsSubmitted OnboardingStatus = "documents_submitted"
+	OnboardingStatusApproved           OnboardingStatus = "approved"
+	OnboardingStatusRejected           OnboardingStatus = "rejected"
+)
+
+// DocumentType identifies an onboarding document
+type DocumentType string
+
+const (
+	DocumentTypeDriverLicense       DocumentType = "driver_license"
+	DocumentTypeInsurance           DocumentType = "insurance"
+	DocumentTypeVehicleRegistration DocumentType = "vehicle_registration"
+)
+
+// requiredDocuments must all be uploaded before a driver can submit for review
+var requiredDocuments = []DocumentType{
+	DocumentTypeDriverLicense,
+	DocumentTypeInsurance,
+	DocumentTypeVehicleRegistration,
+}
+
+// BackgroundCheckStatus is the result of a driver's background check
+type BackgroundCheckStatus string
+
+const (
+	BackgroundCheckPending  BackgroundCheckStatus = "pending"
+	BackgroundCheckClear    BackgroundCheckStatus = "clear"
+	BackgroundCheckConsider BackgroundCheckStatus = "consider"
+	BackgroundCheckFailed   BackgroundCheckStatus = "failed"
+)
+
+var (
+	// ErrOnboardingNotFound is returned when a driver has not started onboarding
+	ErrOnboardingNotFound = errors.New("driver onboarding not found")
+	// ErrNotADriver is returned when a non-driver account tries to onboard
+	ErrNotADriver = errors.New("user is not a driver")
+	// ErrInvalidOnboardingTransition is returned when an action is not allowed in the current onboarding status
+	ErrInvalidOnboardingTransition = errors.New("invalid onboarding transition")
+	// ErrMissingDocuments is returned when submitting for review without every required document
+	ErrMissingDocuments = errors.New("required documents are missing")
+	// ErrInvalidDocument is returned for documents with an unknown type, no file or a past expiry
+	ErrInvalidDocument = errors.New("invalid document")
+	// ErrBackgroundCheckNotClear is returned when approving a driver whose background check has not cleared
+	ErrBackgroundCheckNotClear = errors.New("background check has not cleared")
+)
+
+// onboardingTransitions lists the statuses each action may be applied from, and the status it leads to
+var onboardingTransitions = map[string]struct {
+	from []OnboardingStatus
+	to   OnboardingStatus
+}{
+	"upload_document": {from: []OnboardingStatus{OnboardingStatusPending, OnboardingStatusRejected}, to: OnboardingStatusPending},
+	"submit":          {from: []OnboardingStatus{OnboardingStatusPending}, to: OnboardingStatusDocumentsSubmitted},
+	"approve":         {from: []OnboardingStatus{OnboardingStatusDocumentsSubmitted}, to: OnboardingStatusApproved},
+	"reject":          {from: []OnboardingStatus{OnboardingStatusDocumentsSubmitted}, to: OnboardingStatusRejected},
+}
+
+// DriverDocument is the metadata of an uploaded onboarding document. The file
+// itself lives in object storage.
+type DriverDocument struct {
+	ID             string       `json:"id"`
+	DriverID       string       `json:"driver_id"`
+	Type           DocumentType `json:"type"`
+	FileURL        string       `json:"file_url"`
+	DocumentNumber string       `json:"document_number,omitempty"`
+	ExpiresAt      *time.Time   `json:"expires_at,omitempty"`
+	UploadedAt     time.Time    `json:"uploaded_at"`
+}
+
+// DriverOnboarding tracks a driver's documents, background check and approval
+type DriverOnboarding struct {
+	DriverID              string                `json:"driver_id"`
+	Status                OnboardingStatus      `json:"status"`
+	BackgroundCheckStatus BackgroundCheckStatus `json:"background_check_status"`
+	Documents             []*DriverDocument     `json:"documents"`
+	RejectionReason       string                `json:"rejection_reason,omitempty"`
+	ReviewedBy            string                `json:"reviewed_by,omitempty"`
+	SubmittedAt           *time.Time            `json:"submitted_at,omitempty"`
+	ReviewedAt            *time.Time            `json:"reviewed_at,omitempty"`
+	CreatedAt             time.Time             `json:"created_at"`
+	UpdatedAt             time.Time             `json:"updated_at"`
+}
+
+// Document returns the driver's document of the given type, if uploaded
+func (o *DriverOnboarding) Document(docType DocumentType) *DriverDocument {
+	for _, doc := range o.Documents {
+		if doc.Type == docType {
+			return doc
+		}
+	}
+	return nil
+}
+
+// IsApproved reports whether the driver may go online
+func (o *DriverOnboarding) IsApproved() bool {
+	return o.Status == OnboardingStatusApproved
+}
+
+// OnboardingService runs the driver onboarding workflow
+type OnboardingService struct {
+	repo  OnboardingRepositoryInterface
+	users UserRepositoryInterface
+}
+
+// NewOnboardingService creates a new onboarding service
+func NewOnboardingService(repo OnboardingRepositoryInterface, users UserRepositoryInterface) *OnboardingService {
+	return &OnboardingService{
+		repo:  repo,
+		users: users,
+	}
+}
+
+// StartOnboarding opens an onboarding application for a driver account.
+// Starting again returns the existing application.
+func (s *OnboardingService) StartOnboarding(ctx context.Context, driverID string) (*DriverOnboarding, error) {
+	if driverID == "" {
+		return nil, errors.New("driver ID is required")
+	}
+
+	existing, err := s.repo.GetOnboarding(ctx, driverID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, nil
+	}
+
+	user, err := s.users.GetUser(ctx, driverID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, errors.New("user not found")
+	}
+	if user.UserType != models.UserTypeDriver {
+		return nil, ErrNotADriver
+	}
+
+	now := time.Now()
+	onboarding := &DriverOnboarding{
+		DriverID:              driverID,
+		Status:                OnboardingStatusPending,
+		BackgroundCheckStatus: BackgroundCheckPending,
+		CreatedAt:             now,
+		UpdatedAt:             now,
+	}
+	return s.repo.CreateOnboarding(ctx, onboarding)
+}
+
+// GetOnboarding returns a driver's onboarding application
+func (s *OnboardingService) GetOnboarding(ctx context.Context, driverID string) (*DriverOnboarding, error) {
+	if driverID == "" {
+		return nil, errors.New("driver ID is required")
+	}
+
+	onboarding, err := s.repo.GetOnboarding(ctx, driverID)
+	if err != nil {
+		return nil, err
+	}
+	if onboarding == nil {
+		return nil, ErrOnboardingNotFound
+	}
+	return onboarding, nil
+}
+
+// UploadDocument records an uploaded document, replacing any earlier document
+// of the same type. Uploading after a rejection reopens the application.
+func (s *OnboardingService) UploadDocument(ctx context.Context, driverID string, doc *DriverDocument) (*DriverOnboarding, error) {
+	if err := validateDocument(doc, time.Now()); err != nil {
+		return nil, err
+	}
+
+	onboarding, err := s.GetOnboarding(ctx, driverID)
+	if err != nil {
+		return nil, err
+	}
+	if err := transitionOnboarding(onboarding, "upload_document"); err != nil {
+		return nil, err
+	}
+
+	doc.DriverID = driverID
+	doc.UploadedAt = time.Now()
+	if doc.ID == "" {
+		doc.ID = fmt.Sprintf("doc_%d", doc.UploadedAt.UnixNano())
+	}
+	if err := s.repo.SaveDocument(ctx, doc); err != nil {
+		return nil, err
+	}
+
+	onboarding.RejectionReason = ""
+	onboarding.UpdatedAt = doc.UploadedAt
+	if err := s.repo.UpdateOnboarding(ctx, onboarding); err != nil {
+		return nil, err
+	}
+	return s.GetOnboarding(ctx, driverID)
+}
+
+// SubmitForReview sends a driver's documents to an admin once every required document is uploaded
+func (s *OnboardingService) SubmitForReview(ctx context.Context, driverID string) (*DriverOnboarding, error) {
+	onboarding, err := s.GetOnboarding(ctx, driverID)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, docType := range requiredDocuments {
+		if onboarding.Document(docType) == nil {
+			missing = append(missing, string(docType))
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrMissingDocuments, strings.Join(missing, ", "))
+	}
+
+	if err := transitionOnboarding(onboarding, "submit"); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	onboarding.SubmittedAt = &now
+	onboarding.UpdatedAt = now
+	if err := s.repo.UpdateOnboarding(ctx, onboarding); err != nil {
+		return nil, err
+	}
+	return onboarding, nil
+}
+
+// UpdateBackgroundCheck records the result of a driver's background check
+func (s *OnboardingService) UpdateBackgroundCheck(ctx context.Context, driverID string, status BackgroundCheckStatus) (*DriverOnboarding, error) {
+	switch status {
+	case BackgroundCheckPending, BackgroundCheckClear, BackgroundCheckConsider, BackgroundCheckFailed:
+	default:
+		return nil, fmt.Errorf("invalid background check status: %s", status)
+	}
+
+	onboarding, err := s.GetOnboarding(ctx, driverID)
+	if err != nil {
+		return nil, err
+	}
+	if onboarding.Status == OnboardingStatusApproved {
+		return nil, fmt.Errorf("%w: driver is already approved", ErrInvalidOnboardingTransition)
+	}
+
+	onboarding.BackgroundCheckStatus = status
+	onboarding.UpdatedAt = time.Now()
+	if err := s.repo.UpdateOnboarding(ctx, onboarding); err != nil {
+		return nil, err
+	}
+	return onboarding, nil
+}
+
+// ApproveDriver approves a submitted application whose background check has cleared
+func (s *OnboardingService) ApproveDriver(ctx context.Context, driverID, reviewerID string) (*DriverOnboarding, error) {
+	onboarding, err := s.GetOnboarding(ctx, driverID)
+	if err != nil {
+		return nil, err
+	}
+	if onboarding.BackgroundCheckStatus != BackgroundCheckClear {
+		return nil, ErrBackgroundCheckNotClear
+	}
+	if license := onboarding.Document(DocumentTypeDriverLicense); license != nil && license.ExpiresAt != nil && license.ExpiresAt.Before(time.Now()) {
+		return nil, fmt.Errorf("%w: driver license has expired", ErrInvalidDocument)
+	}
+
+	return s.review(ctx, onboarding, "approve", reviewerID, "")
+}
+
+// RejectDriver rejects a submitted application. The driver can upload new documents and resubmit.
+func (s *OnboardingService) RejectDriver(ctx context.Context, driverID, reviewerID, reason string) (*DriverOnboarding, error) {
+	if strings.TrimSpace(reason) == "" {
+		return nil, errors.New("rejection reason is required")
+	}
+
+	onboarding, err := s.GetOnboarding(ctx, driverID)
+	if err != nil {
+		return nil, err
+	}
+	return s.review(ctx, onboarding, "reject", reviewerID, reason)
+}
+
+// ListOnboardings lists applications in a status, oldest first, for the admin review queue
+func (s *OnboardingService) ListOnboardings(ctx context.Context, status OnboardingStatus, limit, offset int) ([]*DriverOnboarding, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return s.repo.ListOnboardings(ctx, status, limit, offset)
+}
+
+// IsDriverApproved reports whether a driver has completed onboarding and may go online
+func (s *OnboardingService) IsDriverApproved(ctx context.Context, driverID string) (bool, error) {
+	onboarding, err := s.GetOnboarding(ctx, driverID)
+	if errors.Is(err, ErrOnboardingNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return onboarding.IsApproved(), nil
+}
+
+func (s *OnboardingService) review(ctx context.Context, onboarding *DriverOnboarding, action, reviewerID, reason string) (*DriverOnboarding, error) {
+	if reviewerID == "" {
+		return nil, errors.New("reviewer ID is required")
+	}
+	if err := transitionOnboarding(onboarding, action); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	onboarding.ReviewedBy = reviewerID
+	onboarding.ReviewedAt = &now
+	onboarding.RejectionReason = reason
+	onboarding.UpdatedAt = now
+	if err := s.repo.UpdateOnboarding(ctx, onboarding); err != nil {
+		return nil, err
+	}
+	return onboarding, nil
+}
+
+// transitionOnboarding moves an application to the status an action leads to
+func transitionOnboarding(onboarding *DriverOnboarding, action string) error {
+	transition := onboardingTransitions[action]
+	for _, from := range transition.from {
+		if from == onboarding.Status {
+			onboarding.Status = transition.to
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: cannot %s while %s", ErrInvalidOnboardingTransition, action, onboarding.Status)
+}
+
+func validateDocument(doc *DriverDocument, now time.Time) error {
+	if doc == nil {
+		return fmt.Errorf("%w: document is required", ErrInvalidDocument)
+	}
+
+	known := false
+	for _, docType := range requiredDocuments {
+		if doc.Type == docType {
+			known = true
+		}
+	}
+	if !known {
+		return fmt.Errorf("%w: unknown document type %q", ErrInvalidDocument, doc.Type)
+	}
+	if doc.FileURL == "" {
+		return fmt.Errorf("%w: file URL is required", ErrInvalidDocument)
+	}
+	if doc.ExpiresAt != nil && !doc.ExpiresAt.After(now) {
+		return fmt.Errorf("%w: %s has expired", ErrInvalidDocument, doc.Type)
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rideshare-platform/shared/models"
+)
+
+// MockOnboardingRepository implements the OnboardingRepositoryInterface for testing
+type MockOnboardingRepository struct {
+	onboardings map[string]*DriverOnboarding
+}
+
+func NewMockOnboardingRepository() *MockOnboardingRepository {
+	return &MockOnboardingRepository{
+		onboardings: make(map[string]*DriverOnboarding),
+	}
+}
+
+func (m *MockOnboardingRepository) CreateOnboarding(ctx context.Context, onboarding *DriverOnboarding) (*DriverOnboarding, error) {
+	m.onboardings[onboarding.DriverID] = onboarding
+	return onboarding, nil
+}
+
+func (m *MockOnboardingRepository) GetOnboarding(ctx context.Context, driverID string) (*DriverOnboarding, error) {
+	onboarding, exists := m.onboardings[driverID]
+	if !exists {
+		return nil, nil
+	}
+	copied := *onboarding
+	copied.Documents = append([]*DriverDocument(nil), onboarding.Documents...)
+	return &copied, nil
+}
+
+func (m *MockOnboardingRepository) UpdateOnboarding(ctx context.Context, onboarding *DriverOnboarding) error {
+	existing, exists := m.onboardings[onboarding.DriverID]
+	if !exists {
+		return ErrOnboardingNotFound
+	}
+	documents := existing.Documents
+	copied := *onboarding
+	copied.Documents = documents
+	m.onboardings[onboarding.DriverID] = &copied
+	return nil
+}
+
+func (m *MockOnboardingRepository) SaveDocument(ctx context.Context, doc *DriverDocument) error {
+	onboarding := m.onboardings[doc.DriverID]
+	for i, existing := range onboarding.Documents {
+		if existing.Type == doc.Type {
+			onboarding.Documents[i] = doc
+			return nil
+		}
+	}
+	onboarding.Documents = append(onboarding.Documents, doc)
+	return nil
+}
+
+func (m *MockOnboardingRepository) ListOnboardings(ctx context.Context, status OnboardingStatus, limit, offset int) ([]*DriverOnboarding, error) {
+	var onboardings []*DriverOnboarding
+	for _, onboarding := range m.onboardings {
+		if onboarding.Status == status {
+			onboardings = append(onboardings, onboarding)
+		}
+	}
+	return onboardings, nil
+}
+
+func newOnboardingTestService() *OnboardingService {
+	users := NewMockUserRepository()
+	users.users["driver-1"] = &models.User{ID: "driver-1", UserType: models.UserTypeDriver}
+	users.users["rider-1"] = &models.User{ID: "rider-1", UserType: models.UserTypeRider}
+	return NewOnboardingService(NewMockOnboardingRepository(), users)
+}
+
+func uploadAllDocuments(t *testing.T, s *OnboardingService, driverID string) {
+	expiry := time.Now().AddDate(1, 0, 0)
+	for _, docType := range requiredDocuments {
+		_, err := s.UploadDocument(context.Background(), driverID, &DriverDocument{
+			Type:      docType,
+			FileURL:   "https://files.example.com/" + string(docType),
+			ExpiresAt: &expiry,
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error uploading %s: %v", docType, err)
+		}
+	}
+}
+
+func TestOnboardingService_ApprovalWorkflow(t *testing.T) {
+	s := newOnboardingTestService()
+	ctx := context.Background()
+
+	if _, err := s.StartOnboarding(ctx, "rider-1"); !errors.Is(err, ErrNotADriver) {
+		t.Errorf("Expected ErrNotADriver, got %v", err)
+	}
+
+	onboarding, err := s.StartOnboarding(ctx, "driver-1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if onboarding.Status != OnboardingStatusPending {
+		t.Errorf("Expected status pending, got %s", onboarding.Status)
+	}
+
+	if _, err := s.SubmitForReview(ctx, "driver-1"); !errors.Is(err, ErrMissingDocuments) {
+		t.Errorf("Expected ErrMissingDocuments, got %v", err)
+	}
+
+	uploadAllDocuments(t, s, "driver-1")
+
+	onboarding, err = s.SubmitForReview(ctx, "driver-1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if onboarding.Status != OnboardingStatusDocumentsSubmitted || onboarding.SubmittedAt == nil {
+		t.Errorf("Expected submitted application, got %+v", onboarding)
+	}
+
+	// Documents cannot change while an admin is reviewing them
+	_, err = s.UploadDocument(ctx, "driver-1", &DriverDocument{Type: DocumentTypeInsurance, FileURL: "https://files.example.com/new"})
+	if !errors.Is(err, ErrInvalidOnboardingTransition) {
+		t.Errorf("Expected ErrInvalidOnboardingTransition, got %v", err)
+	}
+
+	if _, err := s.ApproveDriver(ctx, "driver-1", "admin-1"); !errors.Is(err, ErrBackgroundCheckNotClear) {
+		t.Errorf("Expected ErrBackgroundCheckNotClear, got %v", err)
+	}
+
+	if _, err := s.UpdateBackgroundCheck(ctx, "driver-1", BackgroundCheckClear); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	onboarding, err = s.ApproveDriver(ctx, "driver-1", "admin-1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if onboarding.Status != OnboardingStatusApproved || onboarding.ReviewedBy != "admin-1" {
+		t.Errorf("Expected approval by admin-1, got %+v", onboarding)
+	}
+
+	approved, err := s.IsDriverApproved(ctx, "driver-1")
+	if err != nil || !approved {
+		t.Errorf("Expected driver to be approved, got %v (err %v)", approved, err)
+	}
+}
+
+func TestOnboardingService_RejectAndResubmit(t *testing.T) {
+	s := newOnboardingTestService()
+	ctx := context.Background()
+
+	if _, err := s.StartOnboarding(ctx, "driver-1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	uploadAllDocuments(t, s, "driver-1")
+	if _, err := s.SubmitForReview(ctx, "driver-1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := s.RejectDriver(ctx, "driver-1", "admin-1", ""); err == nil {
+		t.Errorf("Expected error for missing rejection reason")
+	}
+
+	onboarding, err := s.RejectDriver(ctx, "driver-1", "admin-1", "Insurance document is unreadable")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if onboarding.Status != OnboardingStatusRejected {
+		t.Errorf("Expected status rejected, got %s", onboarding.Status)
+	}
+
+	approved, _ := s.IsDriverApproved(ctx, "driver-1")
+	if approved {
+		t.Errorf("Expected rejected driver not to be approved")
+	}
+
+	// A new upload reopens the application
+	onboarding, err = s.UploadDocument(ctx, "driver-1", &DriverDocument{Type: DocumentTypeInsurance, FileURL: "https://files.example.com/insurance-v2"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if onboarding.Status != OnboardingStatusPending || onboarding.RejectionReason != "" {
+		t.Errorf("Expected reopened application, got %+v", onboarding)
+	}
+	if len(onboarding.Documents) != len(requiredDocuments) {
+		t.Errorf("Expected the insurance document to be replaced, got %d documents", len(onboarding.Documents))
+	}
+}
+
+func TestOnboardingService_UploadDocumentValidation(t *testing.T) {
+	s := newOnboardingTestService()
+	ctx := context.Background()
+	expired := time.Now().Add(-time.Hour)
+
+	if _, err := s.StartOnboarding(ctx, "driver-1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		doc  *DriverDocument
+	}{
+		{name: "unknown_type", doc: &DriverDocument{Type: "passport", FileURL: "https://files.example.com/p"}},
+		{name: "missing_file", doc: &DriverDocument{Type: DocumentTypeDriverLicense}},
+		{name: "expired", doc: &DriverDocument{Type: DocumentTypeDriverLicense, FileURL: "https://files.example.com/l", ExpiresAt: &expired}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.UploadDocument(ctx, "driver-1", tt.doc); !errors.Is(err, ErrInvalidDocument) {
+				t.Errorf("Expected ErrInvalidDocument, got %v", err)
+			}
+		})
+	}
+
+	approved, err := s.IsDriverApproved(ctx, "unknown-driver")
+	if err != nil || approved {
+		t.Errorf("Expected drivers without an application not to be approved, got %v (err %v)", approved, err)
+	}
+}
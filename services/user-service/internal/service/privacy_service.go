@@ -0,0 +1,457 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rideshare-platform/shared/models"
+)
+
+var (
+	// ErrPrivacyRequestNotFound is returned when no privacy request has the given ID
+	ErrPrivacyRequestNotFound = errors.New("privacy request not found")
+	// ErrUserHasActiveTrip is returned when erasing a user who is riding or
+	// driving a trip that has not ended
+	ErrUserHasActiveTrip = errors.New("user has an active trip")
+	// ErrPrivacyRequestFailed is returned when a service could not export or
+	// erase its part of a user's data. The request log names the failed step.
+	ErrPrivacyRequestFailed = errors.New("privacy request failed")
+)
+
+// PrivacyRequestType is the kind of data subject request
+type PrivacyRequestType string
+
+const (
+	// PrivacyRequestExport gathers a copy of the user's personal data
+	PrivacyRequestExport PrivacyRequestType = "export"
+	// PrivacyRequestErasure scrubs the user's personal data
+	PrivacyRequestErasure PrivacyRequestType = "erasure"
+)
+
+// PrivacyRequestStatus is the outcome of a data subject request
+type PrivacyRequestStatus string
+
+const (
+	PrivacyRequestProcessing PrivacyRequestStatus = "processing"
+	PrivacyRequestCompleted  PrivacyRequestStatus = "completed"
+	PrivacyRequestFailed     PrivacyRequestStatus = "failed"
+)
+
+// PrivacyStepStatus is the outcome of one part of a data subject request
+type PrivacyStepStatus string
+
+const (
+	PrivacyStepCompleted PrivacyStepStatus = "completed"
+	PrivacyStepFailed    PrivacyStepStatus = "failed"
+	PrivacyStepSkipped   PrivacyStepStatus = "skipped" // the owning service is not configured
+)
+
+// PrivacyStep records the part of a request one service carried out, e.g.
+// the trips anonymized by trip-service
+type PrivacyStep struct {
+	Name    string            `json:"name"`
+	Status  PrivacyStepStatus `json:"status"`
+	Records int64             `json:"records"`
+	Error   string            `json:"error,omitempty"`
+}
+
+// PrivacyRequest is an entry in the audit log of data subject requests
+type PrivacyRequest struct {
+	ID          string               `json:"id"`
+	UserID      string               `json:"user_id"`
+	Type        PrivacyRequestType   `json:"type"`
+	Status      PrivacyRequestStatus `json:"status"`
+	RequestedBy string               `json:"requested_by"`
+	Steps       []PrivacyStep        `json:"steps"`
+	Error       string               `json:"error,omitempty"`
+	CreatedAt   time.Time            `json:"created_at"`
+	CompletedAt *time.Time           `json:"completed_at,omitempty"`
+}
+
+// TripDataClient reads and anonymizes a user's trips in trip-service
+type TripDataClient interface {
+	// UserTrips returns every trip the user rode or drove, encoded as JSON,
+	// and the trips' IDs
+	UserTrips(ctx context.Context, userID string) ([]json.RawMessage, []string, error)
+	// AnonymizeUserTrips returns the IDs of the trips it anonymized, and
+	// ErrUserHasActiveTrip while a trip of the user has not ended
+	AnonymizeUserTrips(ctx context.Context, userID string) ([]string, error)
+}
+
+// PaymentDataClient reads a user's payments and removes their saved payment
+// methods in payment-service
+type PaymentDataClient interface {
+	UserPayments(ctx context.Context, userID string) ([]json.RawMessage, error)
+	UserPaymentMethods(ctx context.Context, userID string) ([]json.RawMessage, error)
+	RemoveUserPaymentMethods(ctx context.Context, userID string) (int64, error)
+}
+
+// LocationDataClient reads and deletes the routes geo-service recorded during trips
+type LocationDataClient interface {
+	// TripRoute returns nil when no route was recorded for the trip
+	TripRoute(ctx context.Context, tripID string) (json.RawMessage, error)
+	DeleteTripRoutes(ctx context.Context, tripIDs []string) (int64, error)
+}
+
+// The files of an export archive, and the names of the request steps
+// producing them
+const (
+	privacyStepProfile        = "profile"
+	privacyStepOnboarding     = "driver_onboarding"
+	privacyStepTrips          = "trips"
+	privacyStepLocations      = "locations"
+	privacyStepPayments       = "payments"
+	privacyStepPaymentMethods = "payment_methods"
+)
+
+// PrivacyService handles data subject requests: exporting a user's personal
+// data from every service into an archive, and erasing it. Erasure keeps
+// financial records, payments and trip fares, and scrubs what identifies the
+// user. Every request is recorded in an audit log.
+type PrivacyService struct {
+	users      UserRepositoryInterface
+	anonymizer UserAnonymizerInterface
+	onboarding OnboardingRepositoryInterface
+	requests   PrivacyRequestRepositoryInterface
+	trips      TripDataClient
+	payments   PaymentDataClient
+	locations  LocationDataClient
+	now        func() time.Time
+}
+
+// NewPrivacyService creates a new privacy service. Without the clients of
+// the other services only the data user-service keeps is covered.
+func NewPrivacyService(users UserRepositoryInterface, anonymizer UserAnonymizerInterface, onboarding OnboardingRepositoryInterface, requests PrivacyRequestRepositoryInterface) *PrivacyService {
+	return &PrivacyService{
+		users:      users,
+		anonymizer: anonymizer,
+		onboarding: onboarding,
+		requests:   requests,
+		now:        time.Now,
+	}
+}
+
+// SetTripClient sets the trip-service client
+func (s *PrivacyService) SetTripClient(trips TripDataClient) {
+	s.trips = trips
+}
+
+// SetPaymentClient sets the payment-service client
+func (s *PrivacyService) SetPaymentClient(payments PaymentDataClient) {
+	s.payments = payments
+}
+
+// SetLocationClient sets the geo-service client
+func (s *PrivacyService) SetLocationClient(locations LocationDataClient) {
+	s.locations = locations
+}
+
+// GetRequest returns a logged privacy request
+func (s *PrivacyService) GetRequest(ctx context.Context, requestID string) (*PrivacyRequest, error) {
+	request, err := s.requests.GetPrivacyRequest(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if request == nil {
+		return nil, ErrPrivacyRequestNotFound
+	}
+	return request, nil
+}
+
+// ListRequests returns logged privacy requests, most recent first, of one
+// user or of every user when userID is empty
+func (s *PrivacyService) ListRequests(ctx context.Context, userID string, limit, offset int) ([]*PrivacyRequest, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return s.requests.ListPrivacyRequests(ctx, userID, limit, offset)
+}
+
+// ExportUserData gathers a user's personal data from every service into a
+// zip archive of JSON files. The fan-out runs in parallel; if any service
+// fails the request is logged as failed and no archive is returned, so a
+// user is never handed an incomplete copy.
+func (s *PrivacyService) ExportUserData(ctx context.Context, userID, requestedBy string) (*PrivacyRequest, []byte, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	request, err := s.startRequest(ctx, userID, PrivacyRequestExport, requestedBy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	files := make(map[string]interface{})
+	var mutex sync.Mutex
+	collect := func(name string, data interface{}, records int) PrivacyStep {
+		mutex.Lock()
+		files[name] = data
+		mutex.Unlock()
+		return PrivacyStep{Name: name, Status: PrivacyStepCompleted, Records: int64(records)}
+	}
+	request.Steps = append(request.Steps, collect(privacyStepProfile, user, 1))
+
+	onboarding, err := s.onboarding.GetOnboarding(ctx, userID)
+	if err != nil {
+		request.Steps = append(request.Steps, failedStep(privacyStepOnboarding, err))
+		return request, nil, s.fail(ctx, request)
+	}
+	if onboarding != nil {
+		request.Steps = append(request.Steps, collect(privacyStepOnboarding, onboarding, 1))
+	}
+
+	// Locations are looked up by trip, so they follow the trips
+	tasks := []func() []PrivacyStep{
+		func() []PrivacyStep {
+			if s.trips == nil {
+				return []PrivacyStep{skippedStep(privacyStepTrips), skippedStep(privacyStepLocations)}
+			}
+			trips, tripIDs, err := s.trips.UserTrips(ctx, userID)
+			if err != nil {
+				return []PrivacyStep{failedStep(privacyStepTrips, err)}
+			}
+			steps := []PrivacyStep{collect(privacyStepTrips, trips, len(trips))}
+			if s.locations == nil {
+				return append(steps, skippedStep(privacyStepLocations))
+			}
+			routes := make(map[string]json.RawMessage)
+			for _, tripID := range tripIDs {
+				route, err := s.locations.TripRoute(ctx, tripID)
+				if err != nil {
+					return append(steps, failedStep(privacyStepLocations, err))
+				}
+				if route != nil {
+					routes[tripID] = route
+				}
+			}
+			return append(steps, collect(privacyStepLocations, routes, len(routes)))
+		},
+		func() []PrivacyStep {
+			if s.payments == nil {
+				return []PrivacyStep{skippedStep(privacyStepPayments)}
+			}
+			payments, err := s.payments.UserPayments(ctx, userID)
+			if err != nil {
+				return []PrivacyStep{failedStep(privacyStepPayments, err)}
+			}
+			return []PrivacyStep{collect(privacyStepPayments, payments, len(payments))}
+		},
+		func() []PrivacyStep {
+			if s.payments == nil {
+				return []PrivacyStep{skippedStep(privacyStepPaymentMethods)}
+			}
+			methods, err := s.payments.UserPaymentMethods(ctx, userID)
+			if err != nil {
+				return []PrivacyStep{failedStep(privacyStepPaymentMethods, err)}
+			}
+			return []PrivacyStep{collect(privacyStepPaymentMethods, methods, len(methods))}
+		},
+	}
+	results := make([][]PrivacyStep, len(tasks))
+	var wg sync.WaitGroup
+	for i, task := range tasks {
+		wg.Add(1)
+		go func(i int, task func() []PrivacyStep) {
+			defer wg.Done()
+			results[i] = task()
+		}(i, task)
+	}
+	wg.Wait()
+	for _, steps := range results {
+		request.Steps = append(request.Steps, steps...)
+	}
+	if failed(request.Steps) {
+		return request, nil, s.fail(ctx, request)
+	}
+
+	archive, err := s.buildArchive(request, files)
+	if err != nil {
+		request.Error = err.Error()
+		return request, nil, s.fail(ctx, request)
+	}
+	if err := s.complete(ctx, request); err != nil {
+		return nil, nil, err
+	}
+	return request, archive, nil
+}
+
+// EraseUserData scrubs a user's personal data from every service. Trips are
+// anonymized first, since users on an active trip are refused, then their
+// routes and saved payment methods are deleted and finally the profile is
+// anonymized. The steps stop at the first failure, and as each is safe to
+// repeat a failed request can simply be made again.
+func (s *PrivacyService) EraseUserData(ctx context.Context, userID, requestedBy string) (*PrivacyRequest, error) {
+	if _, err := s.getUser(ctx, userID); err != nil {
+		return nil, err
+	}
+	request, err := s.startRequest(ctx, userID, PrivacyRequestErasure, requestedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	// Erasure is refused when a service is missing, its data would be left behind
+	if s.trips == nil || s.payments == nil || s.locations == nil {
+		request.Error = "trip, payment and location clients are required for erasure"
+		return request, s.fail(ctx, request)
+	}
+
+	tripIDs, err := s.trips.AnonymizeUserTrips(ctx, userID)
+	if err != nil {
+		request.Steps = append(request.Steps, failedStep(privacyStepTrips, err))
+		failErr := s.fail(ctx, request)
+		if errors.Is(err, ErrUserHasActiveTrip) {
+			return request, err
+		}
+		return request, failErr
+	}
+	request.Steps = append(request.Steps, PrivacyStep{Name: privacyStepTrips, Status: PrivacyStepCompleted, Records: int64(len(tripIDs))})
+
+	steps := []struct {
+		name  string
+		erase func() (int64, error)
+	}{
+		{privacyStepLocations, func() (int64, error) { return s.locations.DeleteTripRoutes(ctx, tripIDs) }},
+		{privacyStepPaymentMethods, func() (int64, error) { return s.payments.RemoveUserPaymentMethods(ctx, userID) }},
+		{privacyStepProfile, func() (int64, error) { return 1, s.anonymizer.AnonymizeUser(ctx, userID, s.now()) }},
+	}
+	for _, step := range steps {
+		records, err := step.erase()
+		if err != nil {
+			request.Steps = append(request.Steps, failedStep(step.name, err))
+			return request, s.fail(ctx, request)
+		}
+		request.Steps = append(request.Steps, PrivacyStep{Name: step.name, Status: PrivacyStepCompleted, Records: records})
+	}
+
+	if err := s.complete(ctx, request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+func (s *PrivacyService) getUser(ctx context.Context, userID string) (*models.User, error) {
+	if userID == "" {
+		return nil, errors.New("user ID is required")
+	}
+	user, err := s.users.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (s *PrivacyService) startRequest(ctx context.Context, userID string, requestType PrivacyRequestType, requestedBy string) (*PrivacyRequest, error) {
+	request := &PrivacyRequest{
+		ID:          uuid.NewString(),
+		UserID:      userID,
+		Type:        requestType,
+		Status:      PrivacyRequestProcessing,
+		RequestedBy: requestedBy,
+		Steps:       []PrivacyStep{},
+		CreatedAt:   s.now(),
+	}
+	if err := s.requests.CreatePrivacyRequest(ctx, request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+func (s *PrivacyService) complete(ctx context.Context, request *PrivacyRequest) error {
+	return s.finish(ctx, request, PrivacyRequestCompleted)
+}
+
+// fail logs the request as failed and returns ErrPrivacyRequestFailed
+// naming the failed step
+func (s *PrivacyService) fail(ctx context.Context, request *PrivacyRequest) error {
+	if request.Error == "" {
+		for _, step := range request.Steps {
+			if step.Status == PrivacyStepFailed {
+				request.Error = fmt.Sprintf("%s: %s", step.Name, step.Error)
+				break
+			}
+		}
+	}
+	if err := s.finish(ctx, request, PrivacyRequestFailed); err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: %s", ErrPrivacyRequestFailed, request.Error)
+}
+
+func (s *PrivacyService) finish(ctx context.Context, request *PrivacyRequest, status PrivacyRequestStatus) error {
+	now := s.now()
+	request.Status = status
+	request.CompletedAt = &now
+	// The outcome is logged even if the caller has given up on the request
+	return s.requests.UpdatePrivacyRequest(context.WithoutCancel(ctx), request)
+}
+
+// buildArchive writes each gathered file as indented JSON, plus an
+// export.json listing what the archive covers
+func (s *PrivacyService) buildArchive(request *PrivacyRequest, files map[string]interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	write := func(name string, data interface{}) error {
+		body, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", name, err)
+		}
+		file, err := archive.CreateHeader(&zip.FileHeader{Name: name + ".json", Method: zip.Deflate, Modified: request.CreatedAt})
+		if err != nil {
+			return err
+		}
+		_, err = file.Write(body)
+		return err
+	}
+
+	if err := write("export", map[string]interface{}{
+		"request_id":   request.ID,
+		"user_id":      request.UserID,
+		"generated_at": s.now(),
+		"files":        request.Steps,
+	}); err != nil {
+		return nil, err
+	}
+	for _, name := range []string{privacyStepProfile, privacyStepOnboarding, privacyStepTrips, privacyStepLocations, privacyStepPayments, privacyStepPaymentMethods} {
+		data, exists := files[name]
+		if !exists {
+			continue
+		}
+		if err := write(name, data); err != nil {
+			return nil, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func failed(steps []PrivacyStep) bool {
+	for _, step := range steps {
+		if step.Status == PrivacyStepFailed {
+			return true
+		}
+	}
+	return false
+}
+
+func failedStep(name string, err error) PrivacyStep {
+	return PrivacyStep{Name: name, Status: PrivacyStepFailed, Error: err.Error()}
+}
+
+func skippedStep(name string) PrivacyStep {
+	return PrivacyStep{Name: name, Status: PrivacyStepSkipped}
+}
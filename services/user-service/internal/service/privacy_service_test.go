@@ -0,0 +1,206 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rideshare-platform/shared/models"
+)
+
+// MockPrivacyRequestRepository implements the PrivacyRequestRepositoryInterface for testing
+type MockPrivacyRequestRepository struct {
+	requests map[string]*PrivacyRequest
+}
+
+func (m *MockPrivacyRequestRepository) CreatePrivacyRequest(ctx context.Context, request *PrivacyRequest) error {
+	copied := *request
+	m.requests[request.ID] = &copied
+	return nil
+}
+
+func (m *MockPrivacyRequestRepository) UpdatePrivacyRequest(ctx context.Context, request *PrivacyRequest) error {
+	copied := *request
+	m.requests[request.ID] = &copied
+	return nil
+}
+
+func (m *MockPrivacyRequestRepository) GetPrivacyRequest(ctx context.Context, requestID string) (*PrivacyRequest, error) {
+	return m.requests[requestID], nil
+}
+
+func (m *MockPrivacyRequestRepository) ListPrivacyRequests(ctx context.Context, userID string, limit, offset int) ([]*PrivacyRequest, error) {
+	var requests []*PrivacyRequest
+	for _, request := range m.requests {
+		if userID == "" || request.UserID == userID {
+			requests = append(requests, request)
+		}
+	}
+	return requests, nil
+}
+
+// mockAnonymizer records the users it anonymized
+type mockAnonymizer struct {
+	anonymized []string
+}
+
+func (m *mockAnonymizer) AnonymizeUser(ctx context.Context, userID string, anonymizedAt time.Time) error {
+	m.anonymized = append(m.anonymized, userID)
+	return nil
+}
+
+// mockUserData stands in for trip-, payment- and geo-service
+type mockUserData struct {
+	activeTrip      bool
+	paymentsErr     error
+	anonymizedTrips []string
+	deletedRoutes   []string
+	removedMethods  bool
+}
+
+func (m *mockUserData) UserTrips(ctx context.Context, userID string) ([]json.RawMessage, []string, error) {
+	return []json.RawMessage{json.RawMessage(`{"id":"trip-1"}`), json.RawMessage(`{"id":"trip-2"}`)}, []string{"trip-1", "trip-2"}, nil
+}
+
+func (m *mockUserData) AnonymizeUserTrips(ctx context.Context, userID string) ([]string, error) {
+	if m.activeTrip {
+		return nil, ErrUserHasActiveTrip
+	}
+	m.anonymizedTrips = []string{"trip-1", "trip-2"}
+	return m.anonymizedTrips, nil
+}
+
+func (m *mockUserData) UserPayments(ctx context.Context, userID string) ([]json.RawMessage, error) {
+	if m.paymentsErr != nil {
+		return nil, m.paymentsErr
+	}
+	return []json.RawMessage{json.RawMessage(`{"id":"payment-1"}`)}, nil
+}
+
+func (m *mockUserData) UserPaymentMethods(ctx context.Context, userID string) ([]json.RawMessage, error) {
+	return []json.RawMessage{json.RawMessage(`{"last_four":"4242"}`)}, nil
+}
+
+func (m *mockUserData) RemoveUserPaymentMethods(ctx context.Context, userID string) (int64, error) {
+	m.removedMethods = true
+	return 1, nil
+}
+
+func (m *mockUserData) TripRoute(ctx context.Context, tripID string) (json.RawMessage, error) {
+	if tripID != "trip-1" {
+		return nil, nil
+	}
+	return json.RawMessage(`{"trip_id":"trip-1","points":[]}`), nil
+}
+
+func (m *mockUserData) DeleteTripRoutes(ctx context.Context, tripIDs []string) (int64, error) {
+	m.deletedRoutes = tripIDs
+	return 12, nil
+}
+
+func newPrivacyTestService() (*PrivacyService, *mockUserData, *mockAnonymizer, *MockPrivacyRequestRepository) {
+	users := NewMockUserRepository()
+	users.users["rider-1"] = &models.User{ID: "rider-1", Email: "rider@example.com", UserType: models.UserTypeRider}
+	anonymizer := &mockAnonymizer{}
+	requests := &MockPrivacyRequestRepository{requests: make(map[string]*PrivacyRequest)}
+	data := &mockUserData{}
+
+	s := NewPrivacyService(users, anonymizer, NewMockOnboardingRepository(), requests)
+	s.SetTripClient(data)
+	s.SetPaymentClient(data)
+	s.SetLocationClient(data)
+	return s, data, anonymizer, requests
+}
+
+func TestPrivacyService_ExportUserData(t *testing.T) {
+	s, _, _, requests := newPrivacyTestService()
+
+	request, archive, err := s.ExportUserData(context.Background(), "rider-1", "rider-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if request.Status != PrivacyRequestCompleted {
+		t.Errorf("Expected completed request, got %s", request.Status)
+	}
+	if logged := requests.requests[request.ID]; logged == nil || logged.Status != PrivacyRequestCompleted {
+		t.Errorf("Expected the completed request to be logged, got %+v", logged)
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		t.Fatalf("Expected a zip archive, got %v", err)
+	}
+	files := make(map[string]bool)
+	for _, file := range reader.File {
+		files[file.Name] = true
+	}
+	for _, name := range []string{"export.json", "profile.json", "trips.json", "locations.json", "payments.json", "payment_methods.json"} {
+		if !files[name] {
+			t.Errorf("Expected %s in the archive, got %v", name, files)
+		}
+	}
+
+	for _, step := range request.Steps {
+		if step.Name == privacyStepLocations && step.Records != 1 {
+			t.Errorf("Expected the one recorded route to be exported, got %d", step.Records)
+		}
+	}
+}
+
+func TestPrivacyService_ExportUserData_FailsWithoutPartialArchive(t *testing.T) {
+	s, data, _, requests := newPrivacyTestService()
+	data.paymentsErr = errors.New("payment-service unavailable")
+
+	request, archive, err := s.ExportUserData(context.Background(), "rider-1", "rider-1")
+	if !errors.Is(err, ErrPrivacyRequestFailed) {
+		t.Fatalf("Expected ErrPrivacyRequestFailed, got %v", err)
+	}
+	if archive != nil {
+		t.Error("Expected no archive for a failed export")
+	}
+	logged := requests.requests[request.ID]
+	if logged.Status != PrivacyRequestFailed || logged.Error != "payments: payment-service unavailable" {
+		t.Errorf("Expected the failed step to be logged, got %s %q", logged.Status, logged.Error)
+	}
+}
+
+func TestPrivacyService_EraseUserData(t *testing.T) {
+	s, data, anonymizer, _ := newPrivacyTestService()
+
+	request, err := s.EraseUserData(context.Background(), "rider-1", "admin-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if request.Status != PrivacyRequestCompleted || request.RequestedBy != "admin-1" {
+		t.Errorf("Expected completed request by admin-1, got %s by %s", request.Status, request.RequestedBy)
+	}
+	if len(data.deletedRoutes) != 2 {
+		t.Errorf("Expected the routes of the anonymized trips to be deleted, got %v", data.deletedRoutes)
+	}
+	if !data.removedMethods {
+		t.Error("Expected payment methods to be removed")
+	}
+	if len(anonymizer.anonymized) != 1 || anonymizer.anonymized[0] != "rider-1" {
+		t.Errorf("Expected the profile to be anonymized, got %v", anonymizer.anonymized)
+	}
+}
+
+func TestPrivacyService_EraseUserData_RefusesActiveTrip(t *testing.T) {
+	s, data, anonymizer, requests := newPrivacyTestService()
+	data.activeTrip = true
+
+	request, err := s.EraseUserData(context.Background(), "rider-1", "rider-1")
+	if !errors.Is(err, ErrUserHasActiveTrip) {
+		t.Fatalf("Expected ErrUserHasActiveTrip, got %v", err)
+	}
+	if requests.requests[request.ID].Status != PrivacyRequestFailed {
+		t.Error("Expected the refused request to be logged as failed")
+	}
+	if data.removedMethods || len(anonymizer.anonymized) != 0 {
+		t.Error("Expected nothing to be erased while a trip is active")
+	}
+}
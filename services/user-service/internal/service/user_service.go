@@ -3,10 +3,21 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 
+	"github.com/rideshare-platform/shared/i18n"
 	"github.com/rideshare-platform/shared/models"
 )
 
+var (
+	// ErrUserNotFound is returned when no user has the given ID
+	ErrUserNotFound = errors.New("user not found")
+	// ErrInvalidUserStatus is returned for a status accounts cannot be set to
+	ErrInvalidUserStatus = errors.New("invalid user status")
+	// ErrAccountBlocked is returned when a suspended or banned user signs in
+	ErrAccountBlocked = errors.New("account is suspended or banned")
+)
+
 // UserService handles user business logic
 type UserService struct {
 	repo UserRepositoryInterface
@@ -33,6 +44,14 @@ func (s *UserService) CreateUser(ctx context.Context, user *models.User) (*model
 		return nil, errors.New("user last name is required")
 	}
 
+	if user.Locale != "" {
+		locale, err := i18n.Normalize(user.Locale)
+		if err != nil {
+			return nil, err
+		}
+		user.Locale = locale
+	}
+
 	// Check if user already exists by email
 	existingUser, err := s.repo.GetUserByEmail(ctx, user.Email)
 	if err != nil {
@@ -105,10 +124,41 @@ func (s *UserService) UpdateUser(ctx context.Context, user *models.User) (*model
 	if user.ProfileImageURL != "" {
 		existingUser.ProfileImageURL = user.ProfileImageURL
 	}
+	if user.Locale != "" {
+		locale, err := i18n.Normalize(user.Locale)
+		if err != nil {
+			return nil, err
+		}
+		existingUser.Locale = locale
+	}
 
 	return s.repo.UpdateUser(ctx, existingUser)
 }
 
+// SetUserStatus changes a user's account status, e.g. to ban them. Suspended
+// and banned users can no longer sign in.
+func (s *UserService) SetUserStatus(ctx context.Context, userID string, status models.UserStatus) (*models.User, error) {
+	if userID == "" {
+		return nil, errors.New("user ID is required")
+	}
+	switch status {
+	case models.UserStatusActive, models.UserStatusInactive, models.UserStatusSuspended, models.UserStatusBanned:
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidUserStatus, status)
+	}
+
+	user, err := s.repo.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	user.Status = status
+	return s.repo.UpdateUser(ctx, user)
+}
+
 // DeleteUser deletes a user
 func (s *UserService) DeleteUser(ctx context.Context, userID string) error {
 	if userID == "" {
@@ -146,6 +196,9 @@ func (s *UserService) AuthenticateUser(ctx context.Context, email, password stri
 	if user == nil {
 		return nil, errors.New("invalid credentials")
 	}
+	if user.Status == models.UserStatusSuspended || user.Status == models.UserStatusBanned {
+		return nil, ErrAccountBlocked
+	}
 
 	// TODO: Implement proper password hashing verification
 	// For now, just return the user (this is not secure!)
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/rideshare-platform/shared/i18n"
 	"github.com/rideshare-platform/shared/models"
 )
 
@@ -268,3 +269,56 @@ func TestUserService_GetUser(t *testing.T) {
 		})
 	}
 }
+
+func TestUserService_SetUserStatus(t *testing.T) {
+	mockRepo := NewMockUserRepository()
+	mockRepo.users["test-123"] = &models.User{
+		ID:     "test-123",
+		Email:  "test@example.com",
+		Status: models.UserStatusActive,
+	}
+	mockRepo.emailIndex["test@example.com"] = mockRepo.users["test-123"]
+	service := NewUserService(mockRepo)
+	ctx := context.Background()
+
+	if _, err := service.SetUserStatus(ctx, "missing", models.UserStatusBanned); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+	if _, err := service.SetUserStatus(ctx, "test-123", "deleted"); !errors.Is(err, ErrInvalidUserStatus) {
+		t.Errorf("Expected ErrInvalidUserStatus, got %v", err)
+	}
+
+	user, err := service.SetUserStatus(ctx, "test-123", models.UserStatusBanned)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if user.Status != models.UserStatusBanned {
+		t.Errorf("Expected status banned, got %s", user.Status)
+	}
+
+	if _, err := service.AuthenticateUser(ctx, "test@example.com", "secret"); !errors.Is(err, ErrAccountBlocked) {
+		t.Errorf("Expected banned user to be refused, got %v", err)
+	}
+}
+
+func TestUserService_UpdateUserLocale(t *testing.T) {
+	mockRepo := NewMockUserRepository()
+	mockRepo.users["test-123"] = &models.User{ID: "test-123", Email: "test@example.com"}
+	service := NewUserService(mockRepo)
+	ctx := context.Background()
+
+	user, err := service.UpdateUser(ctx, &models.User{ID: "test-123", Locale: "pt_br"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if user.Locale != "pt-BR" {
+		t.Errorf("Expected locale pt-BR, got %s", user.Locale)
+	}
+
+	if _, err := service.UpdateUser(ctx, &models.User{ID: "test-123", Locale: "zh"}); !errors.Is(err, i18n.ErrUnsupportedLocale) {
+		t.Errorf("Expected ErrUnsupportedLocale, got %v", err)
+	}
+	if mockRepo.users["test-123"].Locale != "pt-BR" {
+		t.Errorf("Expected unsupported locale to be refused, got %s", mockRepo.users["test-123"].Locale)
+	}
+}
@@ -15,12 +15,22 @@ import (
 
 	"github.com/gin-gonic/gin"
 	_ "github.com/lib/pq"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rideshare-platform/services/user-service/internal/client"
 	"github.com/rideshare-platform/services/user-service/internal/config"
 	"github.com/rideshare-platform/services/user-service/internal/handler"
-	"github.com/rideshare-platform/services/user-service/internal/metrics"
 	"github.com/rideshare-platform/services/user-service/internal/repository"
 	"github.com/rideshare-platform/services/user-service/internal/service"
+	"github.com/rideshare-platform/services/user-service/migrations"
+	sharedcrypto "github.com/rideshare-platform/shared/crypto"
+	"github.com/rideshare-platform/shared/database"
+	sharedgrpc "github.com/rideshare-platform/shared/grpc"
+	sharedhealth "github.com/rideshare-platform/shared/health"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/middleware"
+	"github.com/rideshare-platform/shared/monitoring"
+	userpb "github.com/rideshare-platform/shared/proto/user"
+	sharedtls "github.com/rideshare-platform/shared/tls"
+	"github.com/rideshare-platform/shared/uploads"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
@@ -28,21 +38,6 @@ import (
 
 func main() {
 	// Load configuration
-	// Start gRPC health server
-	grpcServer := grpc.NewServer()
-	healthServer := health.NewServer()
-	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
-	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
-	go func() {
-		lis, err := net.Listen("tcp", ":50051")
-		if err != nil {
-			log.Fatalf("Failed to listen on gRPC port: %v", err)
-		}
-		log.Printf("gRPC server listening on port %s", "50051")
-		if err := grpcServer.Serve(lis); err != nil {
-			log.Fatalf("Failed to start gRPC server: %v", err)
-		}
-	}()
 	cfg, err := config.Load()
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v", err)
@@ -71,25 +66,140 @@ func main() {
 	// Initialize repository and service
 	userRepo := repository.NewUserRepository(db)
 	userService := service.NewUserService(userRepo)
+	onboardingService := service.NewOnboardingService(repository.NewOnboardingRepository(db), userRepo)
+
+	appLogger := logger.NewLogger(cfg.LogLevel, cfg.Environment)
+
+	tlsReloader, err := sharedtls.Load(cfg.TLS, appLogger)
+	if err != nil {
+		log.Fatalf("Failed to load TLS certificates: %v", err)
+	}
+	go tlsReloader.Watch(context.Background())
+
+	if cfg.MigrateOnStartup {
+		schema, err := migrations.Load()
+		if err != nil {
+			log.Fatalf("Failed to load migrations: %v", err)
+		}
+		if _, err := database.NewMigrator(db, "user-service", schema, appLogger).Up(context.Background()); err != nil {
+			log.Fatalf("Failed to migrate database: %v", err)
+		}
+	}
+
+	// Privacy exports and erasure reach the services holding the rest of a
+	// user's personal data
+	privacyService := service.NewPrivacyService(userRepo, userRepo, repository.NewOnboardingRepository(db), repository.NewPrivacyRepository(db))
+	dialOptions := append(sharedgrpc.ClientOptions(), tlsReloader.DialOption())
+	if conn, err := grpc.NewClient(cfg.TripServiceAddr, dialOptions...); err != nil {
+		log.Printf("Failed to create trip-service client, privacy requests will not cover trips: %v", err)
+	} else {
+		defer conn.Close()
+		privacyService.SetTripClient(client.NewGRPCTripClient(conn))
+	}
+	if conn, err := grpc.NewClient(cfg.PaymentServiceAddr, dialOptions...); err != nil {
+		log.Printf("Failed to create payment-service client, privacy requests will not cover payments: %v", err)
+	} else {
+		defer conn.Close()
+		privacyService.SetPaymentClient(client.NewGRPCPaymentClient(conn))
+	}
+	if conn, err := grpc.NewClient(cfg.GeoServiceAddr, dialOptions...); err != nil {
+		log.Printf("Failed to create geo-service client, privacy requests will not cover locations: %v", err)
+	} else {
+		defer conn.Close()
+		privacyService.SetLocationClient(client.NewGRPCGeoClient(conn))
+	}
 
-	// Initialize HTTP handler
+	// Emails and phone numbers are encrypted at rest when
+	// PII_ENCRYPTION_ENABLED is set. The rotator re-encrypts them after the
+	// active master key changes and encrypts users stored before.
+	rotationCtx, stopRotation := context.WithCancel(context.Background())
+	defer stopRotation()
+	if cfg.Encryption.Enabled {
+		cipher, err := sharedcrypto.Open(cfg.Encryption)
+		if err != nil {
+			log.Fatalf("Failed to set up field encryption: %v", err)
+		}
+		userRepo.SetCipher(cipher)
+
+		rotator := sharedcrypto.NewRotator(cipher, appLogger)
+		rotator.SetBatchSize(cfg.Encryption.RotationBatchSize)
+		rotator.Register("users", userRepo)
+		go rotator.Start(rotationCtx, cfg.Encryption.RotationInterval)
+	}
+
+	// Drivers upload onboarding documents and profile photos straight to the
+	// object store. Accepted documents are added to their application and
+	// accepted photos are served resized through the media CDN.
+	driverProfiles := service.NewDriverProfileService(userRepo)
+	var uploader *uploads.Uploader
+	if cfg.Uploads.Enabled {
+		uploader, err = uploads.Open(context.Background(), cfg.Uploads, appLogger)
+		if err != nil {
+			log.Fatalf("Failed to set up document uploads: %v", err)
+		}
+		defer uploader.Close()
+		uploader.RegisterTypes(service.OnboardingDocumentTypes()...)
+		uploader.RegisterTypes(service.ProfilePhotoTypes()...)
+		uploader.OnAccepted(onboardingService.ApplyDocument, driverProfiles.ApplyProfilePhoto)
+		driverProfiles.SetMedia(uploader.Media())
+	}
+
+	// Request counts and latencies for HTTP and gRPC, scraped from /api/v1/metrics
+	metricsCollector := monitoring.NewMetricsCollector(nil, appLogger)
+
+	// Start gRPC server with health, account status, driver onboarding status and driver profiles
+	serverOptions := append(sharedgrpc.ServerOptions(), metricsCollector.ServerOptions("user-service")...)
+	grpcServer := grpc.NewServer(append(serverOptions, tlsReloader.ServerOptions()...)...)
+	userpb.RegisterUserServiceServer(grpcServer, handler.NewGRPCUserHandler(userService, onboardingService, driverProfiles))
+	healthServer := health.NewServer()
+	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
+	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
+	go func() {
+		lis, err := net.Listen("tcp", ":50051")
+		if err != nil {
+			log.Fatalf("Failed to listen on gRPC port: %v", err)
+		}
+		log.Printf("gRPC server listening on port %s", "50051")
+		if err := grpcServer.Serve(lis); err != nil {
+			log.Fatalf("Failed to start gRPC server: %v", err)
+		}
+	}()
+
+	// Initialize HTTP handlers
 	userHandler := handler.NewUserHandler(userService)
+	healthChecker := sharedhealth.NewChecker("user-service", "1.0.0")
+	healthChecker.SetConfig(cfg)
+	healthChecker.AddCheck("postgres", db.PingContext)
+	userHandler.SetHealthChecker(healthChecker)
+	authMiddleware := middleware.NewAuthMiddleware(cfg.JWTSecret, appLogger)
+	onboardingHandler := handler.NewOnboardingHandler(onboardingService, authMiddleware)
+	privacyHandler := handler.NewPrivacyHandler(privacyService, authMiddleware)
+
+	if uploader != nil {
+		onboardingHandler.SetUploader(uploader)
+	}
 
 	// Setup HTTP server
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.New()
 	router.Use(gin.Recovery())
-	router.Use(metrics.PrometheusMiddleware())
+	router.Use(middleware.NewLoggingMiddleware(appLogger).RequestLogger())
+	router.Use(metricsCollector.GinMiddleware("user-service"))
 
 	// Register routes
 	userHandler.RegisterRoutes(router)
+	onboardingHandler.RegisterRoutes(router)
+	if uploader != nil {
+		handler.NewProfilePhotoHandler(uploader, authMiddleware).RegisterRoutes(router)
+	}
+	privacyHandler.RegisterRoutes(router)
 
 	router.GET("/ready", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"status": "ready"})
 	})
 
 	// Prometheus metrics endpoint
-	router.GET("/api/v1/metrics", gin.WrapH(promhttp.Handler()))
+	router.GET("/api/v1/metrics", gin.WrapH(monitoring.Handler()))
 
 	server := &http.Server{
 		Addr:    ":" + cfg.HTTPPort,
@@ -118,6 +228,7 @@ func main() {
 	if err := server.Shutdown(ctx); err != nil {
 		log.Fatal("Server forced to shutdown:", err)
 	}
+	grpcServer.GracefulStop()
 
 	log.Println("Server exiting")
 }
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS drivers (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    license_number VARCHAR(50) UNIQUE NOT NULL,
+    license_expiry DATE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'offline' CHECK (status IN ('offline', 'online', 'busy', 'break')),
+    rating DECIMAL(3,2) DEFAULT 5.00 CHECK (rating >= 0 AND rating <= 5),
+    total_trips INTEGER DEFAULT 0,
+    total_earnings_cents BIGINT DEFAULT 0,
+    current_latitude DECIMAL(10,8),
+    current_longitude DECIMAL(11,8),
+    current_location_accuracy DECIMAL(8,2),
+    last_location_update TIMESTAMP WITH TIME ZONE,
+    background_check_status VARCHAR(20) DEFAULT 'pending',
+    background_check_date DATE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_drivers_status ON drivers(status);
+CREATE INDEX IF NOT EXISTS idx_drivers_rating ON drivers(rating);
+CREATE INDEX IF NOT EXISTS idx_drivers_location ON drivers(current_latitude, current_longitude);
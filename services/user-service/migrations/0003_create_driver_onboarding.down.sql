@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS driver_documents;
+DROP TABLE IF EXISTS driver_onboarding;
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS driver_onboarding (
+    driver_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(30) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'documents_submitted', 'approved', 'rejected')),
+    background_check_status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (background_check_status IN ('pending', 'clear', 'consider', 'failed')),
+    rejection_reason TEXT,
+    reviewed_by VARCHAR(100),
+    submitted_at TIMESTAMP WITH TIME ZONE,
+    reviewed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS driver_documents (
+    id VARCHAR(50) PRIMARY KEY,
+    driver_id UUID NOT NULL REFERENCES driver_onboarding(driver_id) ON DELETE CASCADE,
+    document_type VARCHAR(30) NOT NULL CHECK (document_type IN ('driver_license', 'insurance', 'vehicle_registration')),
+    file_url TEXT NOT NULL,
+    document_number VARCHAR(100),
+    expires_at TIMESTAMP WITH TIME ZONE,
+    uploaded_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (driver_id, document_type)
+);
+
+CREATE INDEX IF NOT EXISTS idx_driver_onboarding_status ON driver_onboarding(status, submitted_at);
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS privacy_requests (
+    id VARCHAR(50) PRIMARY KEY,
+    user_id UUID NOT NULL,
+    request_type VARCHAR(20) NOT NULL CHECK (request_type IN ('export', 'erasure')),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('processing', 'completed', 'failed')),
+    requested_by VARCHAR(100) NOT NULL,
+    steps JSONB NOT NULL DEFAULT '[]',
+    error TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_privacy_requests_user ON privacy_requests(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_privacy_requests_created ON privacy_requests(created_at DESC);
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS anonymized_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,7 @@
+-- Encrypted values do not fit the original column sizes, so emails and phone
+-- numbers must be stored in plaintext again before rolling back
+DROP INDEX IF EXISTS idx_users_phone_index;
+DROP INDEX IF EXISTS idx_users_email_index;
+ALTER TABLE users DROP COLUMN IF EXISTS phone_index;
+ALTER TABLE users DROP COLUMN IF EXISTS email_index;
+ALTER TABLE users ALTER COLUMN email TYPE VARCHAR(255), ALTER COLUMN phone TYPE VARCHAR(20);
@@ -0,0 +1,8 @@
+-- Encrypted emails and phone numbers outgrow the original column sizes.
+-- Lookups and uniqueness move to keyed hashes of the plaintext values.
+ALTER TABLE users ALTER COLUMN email TYPE TEXT, ALTER COLUMN phone TYPE TEXT;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_index VARCHAR(64);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS phone_index VARCHAR(64);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email_index ON users(email_index);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_phone_index ON users(phone_index);
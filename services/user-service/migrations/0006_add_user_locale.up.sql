@@ -0,0 +1,2 @@
+-- The BCP 47 locale users are notified in; NULL until they choose one
+ALTER TABLE users ADD COLUMN IF NOT EXISTS locale VARCHAR(35);
@@ -0,0 +1,2 @@
+-- Object key of the driver's accepted profile photo, signed into URLs when read
+ALTER TABLE users ADD COLUMN IF NOT EXISTS profile_photo_key TEXT;
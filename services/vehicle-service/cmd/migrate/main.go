@@ -0,0 +1,44 @@
+// Command migrate applies and rolls back the vehicle-service database schema.
+//
+//	migrate up | down [N] | version | status
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	_ "github.com/lib/pq"
+	"github.com/rideshare-platform/services/vehicle-service/internal/config"
+	"github.com/rideshare-platform/services/vehicle-service/migrations"
+	"github.com/rideshare-platform/shared/database"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+	appLogger := logger.NewLogger(cfg.Dynamic.LogLevel, cfg.Environment)
+
+	postgresDB, err := database.NewPostgresDB(&cfg.Database, appLogger)
+	if err != nil {
+		return err
+	}
+	defer postgresDB.Close()
+
+	schema, err := migrations.Load()
+	if err != nil {
+		return err
+	}
+	migrator := database.NewMigrator(postgresDB.DB, "vehicle-service", schema, appLogger)
+	return database.RunMigrateCommand(context.Background(), migrator, args, os.Stdout)
+}
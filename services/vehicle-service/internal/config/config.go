@@ -1,37 +1,65 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/rideshare-platform/shared/config"
+	sharedtls "github.com/rideshare-platform/shared/tls"
+	"github.com/rideshare-platform/shared/uploads"
 )
 
 // Config holds the vehicle service configuration
 type Config struct {
 	// Service configuration
-	Environment string
-	LogLevel    string
-	HTTPPort    int
-	GRPCPort    int
-	JWTSecret   string
+	Environment string `yaml:"environment" env:"ENVIRONMENT" default:"development"`
+	HTTPPort    int    `yaml:"http_port" env:"HTTP_PORT" default:"8082"`
+	GRPCPort    int    `yaml:"grpc_port" env:"GRPC_PORT" default:"50052"`
+	JWTSecret   string `yaml:"jwt_secret" env:"JWT_SECRET" default:"your-secret-key-change-in-production"`
+
+	// Reject gRPC calls without a bearer token. Tokens that are sent are
+	// always validated against JWTSecret.
+	GRPCAuthRequired bool `yaml:"grpc_auth_required" env:"GRPC_AUTH_REQUIRED" default:"false"`
+
+	// Mutual TLS for gRPC traffic between services
+	TLS sharedtls.Config `yaml:"tls"`
+
+	// Log level and feature flags, reloadable at runtime
+	Dynamic config.DynamicConfig `yaml:"dynamic"`
 
 	// Database configuration
-	Database config.DatabaseConfig
+	Database config.DatabaseConfig `yaml:"-"`
+
+	// Apply pending schema migrations before serving
+	MigrateOnStartup bool `yaml:"migrate_on_startup" env:"MIGRATE_ON_STARTUP" default:"false"`
 
 	// Redis configuration
-	Redis *config.RedisConfig
+	Redis *config.RedisConfig `yaml:"-"`
+
+	// Document expiry job configuration
+	DocumentExpiry DocumentExpiryConfig `yaml:"-"`
+
+	// Vehicle import jobs that may wait to be processed
+	ImportQueueSize int `yaml:"import_queue_size" env:"VEHICLE_IMPORT_QUEUE_SIZE" default:"16"`
+
+	// Insurance and registration uploads through pre-signed object store URLs
+	Uploads uploads.Config `yaml:"uploads"`
+}
+
+// DocumentExpiryConfig controls the vehicle document expiry job
+type DocumentExpiryConfig struct {
+	CheckInterval time.Duration
+	WarningWindow time.Duration
 }
 
 // Load loads configuration from environment variables
 func Load() (*Config, error) {
-	cfg := &Config{
-		Environment: getEnv("ENVIRONMENT", "development"),
-		LogLevel:    getEnv("LOG_LEVEL", "info"),
-		HTTPPort:    getEnvAsInt("HTTP_PORT", 8082),
-		GRPCPort:    getEnvAsInt("GRPC_PORT", 50052),
-		JWTSecret:   getEnv("JWT_SECRET", "your-secret-key-change-in-production"),
+	cfg := &Config{}
+	if err := config.NewLoader().Load(cfg); err != nil {
+		return nil, err
 	}
 
 	// Database configuration
@@ -46,6 +74,10 @@ func Load() (*Config, error) {
 		MaxIdleConns:    getEnvAsInt("DB_MAX_IDLE_CONNS", 5),
 		ConnMaxLifetime: time.Duration(getEnvAsInt("DB_CONN_MAX_LIFETIME", 300)) * time.Second,
 		ConnMaxIdleTime: time.Duration(getEnvAsInt("DB_CONN_MAX_IDLE_TIME", 60)) * time.Second,
+
+		SlowQueryThreshold: time.Duration(getEnvAsInt("DB_SLOW_QUERY_THRESHOLD_MS", 200)) * time.Millisecond,
+		ReplicaDSNs:        getEnvAsSlice("DB_REPLICA_DSNS"),
+		MaxReplicaLag:      time.Duration(getEnvAsInt("DB_MAX_REPLICA_LAG_SECONDS", 5)) * time.Second,
 	}
 
 	// Redis configuration
@@ -61,10 +93,42 @@ func Load() (*Config, error) {
 		WriteTimeout: 3 * time.Second,
 		IdleTimeout:  5 * time.Minute,
 	}
+	if err := config.LoadRedisTopology(cfg.Redis); err != nil {
+		return nil, err
+	}
+
+	// Document expiry job configuration
+	cfg.DocumentExpiry = DocumentExpiryConfig{
+		CheckInterval: time.Duration(getEnvAsInt("DOCUMENT_EXPIRY_CHECK_INTERVAL_HOURS", 24)) * time.Hour,
+		WarningWindow: time.Duration(getEnvAsInt("DOCUMENT_EXPIRY_WARNING_DAYS", 30)) * 24 * time.Hour,
+	}
 
 	return cfg, nil
 }
 
+// Validate validates the configuration
+func (c *Config) Validate() error {
+	if err := c.TLS.Validate(); err != nil {
+		return err
+	}
+	if err := c.Uploads.Validate(); err != nil {
+		return err
+	}
+	if err := config.ValidatePort("HTTP_PORT", c.HTTPPort); err != nil {
+		return err
+	}
+	if err := config.ValidatePort("GRPC_PORT", c.GRPCPort); err != nil {
+		return err
+	}
+	if c.HTTPPort == c.GRPCPort {
+		return fmt.Errorf("HTTP_PORT and GRPC_PORT must differ, both are %d", c.HTTPPort)
+	}
+	if c.Environment == "production" && c.JWTSecret == "your-secret-key-change-in-production" {
+		return fmt.Errorf("JWT_SECRET must be set in production")
+	}
+	return nil
+}
+
 // getEnv gets an environment variable with a default value
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -82,3 +146,14 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+// getEnvAsSlice gets a comma separated environment variable as a slice
+func getEnvAsSlice(key string) []string {
+	var items []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
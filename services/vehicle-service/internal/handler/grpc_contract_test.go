@@ -0,0 +1,20 @@
+package handler
+
+import (
+	"testing"
+
+	"google.golang.org/grpc"
+
+	"github.com/rideshare-platform/shared/clients/contract"
+	"github.com/rideshare-platform/shared/clients/vehicleclient"
+	vehiclepb "github.com/rideshare-platform/shared/proto/vehicle"
+)
+
+func TestGRPCVehicleHandler_ServesContract(t *testing.T) {
+	grpcHandler := newGRPCTestHandler()
+
+	conn := contract.Serve(t, func(server *grpc.Server) {
+		vehiclepb.RegisterVehicleServiceServer(server, grpcHandler)
+	})
+	contract.Check(t, vehicleclient.ContractCalls(conn))
+}
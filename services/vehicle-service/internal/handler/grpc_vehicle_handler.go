@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/rideshare-platform/services/vehicle-service/internal/repository"
+	"github.com/rideshare-platform/services/vehicle-service/internal/service"
+	"github.com/rideshare-platform/shared/models"
+	"github.com/rideshare-platform/shared/pagination"
+	vehiclepb "github.com/rideshare-platform/shared/proto/vehicle"
+	"github.com/rideshare-platform/shared/uploads"
+)
+
+// GRPCVehicleHandler handles gRPC requests for the vehicle service
+type GRPCVehicleHandler struct {
+	vehiclepb.UnimplementedVehicleServiceServer
+	vehicleService *service.VehicleService
+}
+
+// NewGRPCVehicleHandler creates a new gRPC vehicle handler
+func NewGRPCVehicleHandler(vehicleService *service.VehicleService) *GRPCVehicleHandler {
+	return &GRPCVehicleHandler{
+		vehicleService: vehicleService,
+	}
+}
+
+// CreateVehicle registers a new vehicle for a driver
+func (h *GRPCVehicleHandler) CreateVehicle(ctx context.Context, req *vehiclepb.CreateVehicleRequest) (*vehiclepb.VehicleResponse, error) {
+	vehicle, err := h.vehicleService.CreateVehicle(ctx, &service.CreateVehicleRequest{
+		DriverID:              req.DriverId,
+		Make:                  req.Make,
+		Model:                 req.Model,
+		Year:                  int(req.Year),
+		Color:                 req.Color,
+		LicensePlate:          req.LicensePlate,
+		VehicleType:           req.VehicleType,
+		Capacity:              int(req.Capacity),
+		InsurancePolicyNumber: req.InsurancePolicyNumber,
+		InsuranceExpiry:       timeFromProto(req.InsuranceExpiry),
+		RegistrationExpiry:    timeFromProto(req.RegistrationExpiry),
+	})
+	if err != nil {
+		return nil, vehicleError(err)
+	}
+
+	return &vehiclepb.VehicleResponse{Vehicle: vehicleToProto(vehicle)}, nil
+}
+
+// GetVehicle gets a vehicle by ID
+func (h *GRPCVehicleHandler) GetVehicle(ctx context.Context, req *vehiclepb.GetVehicleRequest) (*vehiclepb.VehicleResponse, error) {
+	if req.VehicleId == "" {
+		return nil, status.Error(codes.InvalidArgument, "Vehicle ID is required")
+	}
+
+	vehicle, err := h.vehicleService.GetVehicle(ctx, req.VehicleId)
+	if err != nil {
+		return nil, vehicleError(err)
+	}
+
+	return &vehiclepb.VehicleResponse{Vehicle: vehicleToProto(vehicle)}, nil
+}
+
+// ListVehicles lists a page of vehicles matching the filters
+func (h *GRPCVehicleHandler) ListVehicles(ctx context.Context, req *vehiclepb.ListVehiclesRequest) (*vehiclepb.ListVehiclesResponse, error) {
+	page, err := pagination.NewRequest(int(req.Limit), req.Cursor, req.Sort, service.VehicleListOptions)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	resp, err := h.vehicleService.ListVehicles(ctx, &service.ListVehiclesRequest{
+		Page:        page,
+		Status:      req.Status,
+		VehicleType: req.VehicleType,
+	})
+	if err != nil {
+		return nil, vehicleError(err)
+	}
+
+	return &vehiclepb.ListVehiclesResponse{
+		Vehicles:   vehiclesToProto(resp.Items),
+		Total:      resp.TotalEstimate,
+		Limit:      int32(page.Limit),
+		NextCursor: resp.NextCursor,
+	}, nil
+}
+
+// UpdateStatus changes a vehicle's status
+func (h *GRPCVehicleHandler) UpdateStatus(ctx context.Context, req *vehiclepb.UpdateVehicleStatusRequest) (*vehiclepb.VehicleResponse, error) {
+	if req.VehicleId == "" {
+		return nil, status.Error(codes.InvalidArgument, "Vehicle ID is required")
+	}
+
+	if err := h.vehicleService.UpdateVehicleStatus(ctx, req.VehicleId, models.VehicleStatus(req.Status)); err != nil {
+		return nil, vehicleError(err)
+	}
+
+	vehicle, err := h.vehicleService.GetVehicle(ctx, req.VehicleId)
+	if err != nil {
+		return nil, vehicleError(err)
+	}
+
+	return &vehiclepb.VehicleResponse{Vehicle: vehicleToProto(vehicle)}, nil
+}
+
+// GetVehiclesByDriver gets the vehicles registered to a driver
+func (h *GRPCVehicleHandler) GetVehiclesByDriver(ctx context.Context, req *vehiclepb.GetVehiclesByDriverRequest) (*vehiclepb.GetVehiclesByDriverResponse, error) {
+	if req.DriverId == "" {
+		return nil, status.Error(codes.InvalidArgument, "Driver ID is required")
+	}
+
+	var vehicles []*models.Vehicle
+	var err error
+	if req.AvailableOnly {
+		vehicles, err = h.vehicleService.GetAvailableVehicles(ctx, req.DriverId)
+	} else {
+		vehicles, err = h.vehicleService.GetVehiclesByDriver(ctx, req.DriverId)
+	}
+	if err != nil {
+		return nil, vehicleError(err)
+	}
+
+	return &vehiclepb.GetVehiclesByDriverResponse{Vehicles: vehiclesToProto(vehicles)}, nil
+}
+
+// GetAvailableVehiclesByDrivers gets the active vehicles of several drivers at once
+func (h *GRPCVehicleHandler) GetAvailableVehiclesByDrivers(ctx context.Context, req *vehiclepb.GetAvailableVehiclesByDriversRequest) (*vehiclepb.GetAvailableVehiclesByDriversResponse, error) {
+	vehicles, err := h.vehicleService.GetAvailableVehiclesByDrivers(ctx, req.DriverIds)
+	if err != nil {
+		return nil, vehicleError(err)
+	}
+
+	return &vehiclepb.GetAvailableVehiclesByDriversResponse{Vehicles: vehiclesToProto(vehicles)}, nil
+}
+
+func vehicleError(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrVehicleNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrLicensePlateExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, service.ErrInvalidVehicleRequest):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func vehiclesToProto(vehicles []*models.Vehicle) []*vehiclepb.Vehicle {
+	result := make([]*vehiclepb.Vehicle, 0, len(vehicles))
+	for _, vehicle := range vehicles {
+		result = append(result, vehicleToProto(vehicle))
+	}
+	return result
+}
+
+func vehicleToProto(vehicle *models.Vehicle) *vehiclepb.Vehicle {
+	pb := &vehiclepb.Vehicle{
+		Id:                    vehicle.ID,
+		DriverId:              vehicle.DriverID,
+		Make:                  vehicle.Make,
+		Model:                 vehicle.Model,
+		Year:                  int32(vehicle.Year),
+		Color:                 vehicle.Color,
+		LicensePlate:          vehicle.LicensePlate,
+		VehicleType:           string(vehicle.VehicleType),
+		Status:                string(vehicle.Status),
+		Capacity:              int32(vehicle.Capacity),
+		InsurancePolicyNumber: vehicle.InsurancePolicyNumber,
+		PhotoUrl:              vehicle.PhotoURLs[uploads.VariantMedium.Name],
+		CreatedAt:             timestamppb.New(vehicle.CreatedAt),
+		UpdatedAt:             timestamppb.New(vehicle.UpdatedAt),
+	}
+	if vehicle.InsuranceExpiry != nil {
+		pb.InsuranceExpiry = timestamppb.New(*vehicle.InsuranceExpiry)
+	}
+	if vehicle.RegistrationExpiry != nil {
+		pb.RegistrationExpiry = timestamppb.New(*vehicle.RegistrationExpiry)
+	}
+	for _, feature := range vehicle.Features {
+		pb.Features = append(pb.Features, string(feature))
+	}
+	return pb
+}
+
+func timeFromProto(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
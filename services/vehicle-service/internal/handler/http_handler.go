@@ -1,16 +1,20 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/rideshare-platform/services/vehicle-service/internal/repository"
 	"github.com/rideshare-platform/services/vehicle-service/internal/service"
+	"github.com/rideshare-platform/shared/models"
+	"github.com/rideshare-platform/shared/pagination"
 )
 
 // VehicleHandler handles HTTP requests for vehicle operations
 type VehicleHandler struct {
 	vehicleService *service.VehicleService
+	importer       *service.VehicleImporter
 }
 
 // NewVehicleHandler creates a new vehicle handler
@@ -28,6 +32,7 @@ func (h *VehicleHandler) RegisterRoutes(router *gin.Engine) {
 		vehicles.GET("/:id", h.GetVehicle)
 		vehicles.PUT("/:id", h.UpdateVehicle)
 		vehicles.DELETE("/:id", h.DeleteVehicle)
+		vehicles.PATCH("/:id/status", h.UpdateVehicleStatus)
 		vehicles.GET("/driver/:driver_id", h.GetVehiclesByDriver)
 		vehicles.GET("/", h.ListVehicles)
 	}
@@ -48,10 +53,7 @@ func (h *VehicleHandler) CreateVehicle(c *gin.Context) {
 	}
 	createdVehicle, err := h.vehicleService.CreateVehicle(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Failed to create vehicle",
-			"details": err.Error(),
-		})
+		vehicleHTTPError(c, "Failed to create vehicle", err)
 		return
 	}
 	c.JSON(http.StatusCreated, createdVehicle)
@@ -69,10 +71,7 @@ func (h *VehicleHandler) GetVehicle(c *gin.Context) {
 
 	vehicle, err := h.vehicleService.GetVehicle(c.Request.Context(), vehicleID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"error":   "Vehicle not found",
-			"details": err.Error(),
-		})
+		vehicleHTTPError(c, "Failed to get vehicle", err)
 		return
 	}
 
@@ -103,10 +102,7 @@ func (h *VehicleHandler) UpdateVehicle(c *gin.Context) {
 
 	updatedVehicle, err := h.vehicleService.UpdateVehicle(c.Request.Context(), &req)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{
-			"error":   "Failed to update vehicle",
-			"details": err.Error(),
-		})
+		vehicleHTTPError(c, "Failed to update vehicle", err)
 		return
 	}
 	c.JSON(http.StatusOK, updatedVehicle)
@@ -124,10 +120,7 @@ func (h *VehicleHandler) DeleteVehicle(c *gin.Context) {
 
 	err := h.vehicleService.DeleteVehicle(c.Request.Context(), vehicleID)
 	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{
-			"error":   "Failed to delete vehicle",
-			"details": err.Error(),
-		})
+		vehicleHTTPError(c, "Failed to delete vehicle", err)
 		return
 	}
 
@@ -161,39 +154,91 @@ func (h *VehicleHandler) GetVehiclesByDriver(c *gin.Context) {
 	})
 }
 
-// ListVehicles returns a list of vehicles
+// ListVehicles returns a page of vehicles. Pages are read with the limit,
+// sort and cursor query parameters; each page carries the cursor of the next.
 func (h *VehicleHandler) ListVehicles(c *gin.Context) {
-	// Parse query params for pagination and filtering
-	limit := 20
-	offset := 0
-	if l := c.Query("limit"); l != "" {
-		if v, err := strconv.Atoi(l); err == nil {
-			limit = v
-		}
-	}
-	if o := c.Query("offset"); o != "" {
-		if v, err := strconv.Atoi(o); err == nil {
-			offset = v
-		}
-	}
-	status := c.Query("status")
-	vehicleType := c.Query("vehicle_type")
+	page, err := pagination.FromQuery(c.Request.URL.Query(), service.VehicleListOptions)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid pagination",
+			"details": err.Error(),
+		})
+		return
+	}
 
 	req := &service.ListVehiclesRequest{
-		Limit:       limit,
-		Offset:      offset,
-		Status:      status,
-		VehicleType: vehicleType,
+		Page:        page,
+		Status:      c.Query("status"),
+		VehicleType: c.Query("vehicle_type"),
 	}
 
 	resp, err := h.vehicleService.ListVehicles(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		vehicleHTTPError(c, "Failed to list vehicles", err)
 		return
 	}
 	c.JSON(http.StatusOK, resp)
 }
 
+// UpdateVehicleStatusRequest represents a vehicle status change
+type UpdateVehicleStatusRequest struct {
+	Status models.VehicleStatus `json:"status" binding:"required"`
+}
+
+// UpdateVehicleStatus changes the status of a vehicle
+func (h *VehicleHandler) UpdateVehicleStatus(c *gin.Context) {
+	vehicleID := c.Param("id")
+
+	var req UpdateVehicleStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := h.vehicleService.UpdateVehicleStatus(c.Request.Context(), vehicleID, req.Status); err != nil {
+		vehicleHTTPError(c, "Failed to update vehicle status", err)
+		return
+	}
+
+	vehicle, err := h.vehicleService.GetVehicle(c.Request.Context(), vehicleID)
+	if err != nil {
+		vehicleHTTPError(c, "Failed to get vehicle", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, vehicle)
+}
+
+// GetAvailableVehicles retrieves a driver's vehicles that can take trips
+func (h *VehicleHandler) GetAvailableVehicles(c *gin.Context) {
+	driverID := c.Param("driver_id")
+
+	vehicles, err := h.vehicleService.GetAvailableVehicles(c.Request.Context(), driverID)
+	if err != nil {
+		vehicleHTTPError(c, "Failed to get available vehicles", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"vehicles": vehicles,
+		"count":    len(vehicles),
+	})
+}
+
+// GetVehicleStats returns fleet-wide vehicle statistics
+func (h *VehicleHandler) GetVehicleStats(c *gin.Context) {
+	stats, err := h.vehicleService.GetVehicleStats(c.Request.Context())
+	if err != nil {
+		vehicleHTTPError(c, "Failed to get vehicle stats", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
 // HealthCheck returns the health status of the service
 func (h *VehicleHandler) HealthCheck(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{
@@ -201,3 +246,22 @@ func (h *VehicleHandler) HealthCheck(c *gin.Context) {
 		"service": "vehicle-service",
 	})
 }
+
+func vehicleHTTPError(c *gin.Context, message string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, repository.ErrVehicleNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, service.ErrLicensePlateExists):
+		status = http.StatusConflict
+	case errors.Is(err, service.ErrInvalidVehicleRequest):
+		status = http.StatusBadRequest
+	case errors.Is(err, service.ErrFeaturesUnavailable):
+		status = http.StatusServiceUnavailable
+	}
+
+	c.JSON(status, gin.H{
+		"error":   message,
+		"details": err.Error(),
+	})
+}
@@ -8,7 +8,10 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/rideshare-platform/services/vehicle-service/internal/middleware"
+	"github.com/rideshare-platform/shared/health"
 	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/monitoring"
+	"github.com/rideshare-platform/shared/uploads"
 )
 
 // HTTPServer provides HTTP endpoints for the vehicle service
@@ -17,6 +20,9 @@ type HTTPServer struct {
 	server            *http.Server
 	authMiddleware    *middleware.AuthMiddleware
 	metricsMiddleware *middleware.MetricsMiddleware
+	vehicles          *VehicleHandler
+	documents         http.Handler
+	health            *health.Checker
 	logger            *logger.Logger
 }
 
@@ -25,16 +31,32 @@ func NewHTTPServer(
 	port int,
 	authMiddleware *middleware.AuthMiddleware,
 	metricsMiddleware *middleware.MetricsMiddleware,
+	vehicles *VehicleHandler,
 	logger *logger.Logger,
 ) *HTTPServer {
 	return &HTTPServer{
 		port:              port,
 		authMiddleware:    authMiddleware,
 		metricsMiddleware: metricsMiddleware,
+		vehicles:          vehicles,
+		health:            health.NewChecker("vehicle-service", "1.0.0"),
 		logger:            logger,
 	}
 }
 
+// SetHealthChecker attaches the checker that probes the service's dependencies
+func (s *HTTPServer) SetHealthChecker(checker *health.Checker) {
+	s.health = checker
+}
+
+// SetDocuments attaches the document upload routes, served under
+// /api/v1/documents behind authentication
+func (s *HTTPServer) SetDocuments(documents *uploads.Handler) {
+	mux := http.NewServeMux()
+	documents.RegisterRoutes(mux)
+	s.documents = mux
+}
+
 // Start starts the HTTP server
 func (s *HTTPServer) Start() error {
 	// Set Gin mode based on environment
@@ -52,30 +74,43 @@ func (s *HTTPServer) Start() error {
 	router.Use(s.metricsMiddleware.PrometheusMetrics("vehicle-service"))
 
 	// Health check endpoint
-	router.GET("/health", s.healthCheck)
+	router.GET("/health", gin.WrapH(s.health.Handler()))
+	router.GET("/info", gin.WrapH(s.health.InfoHandler()))
 	router.GET("/ready", s.readinessCheck)
 
 	// Metrics endpoint
-	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	router.GET("/metrics", gin.WrapH(monitoring.Handler()))
 
 	// API routes
 	v1 := router.Group("/api/v1")
 	{
 		// Public endpoints (no auth required)
-		v1.GET("/vehicles/stats", s.getVehicleStats)
+		v1.GET("/vehicles/stats", s.vehicles.GetVehicleStats)
 
 		// Protected endpoints (auth required)
 		protected := v1.Group("")
 		protected.Use(s.authMiddleware.JWTAuth())
 		{
-			protected.POST("/vehicles", s.createVehicle)
-			protected.GET("/vehicles/:id", s.getVehicle)
-			protected.PUT("/vehicles/:id", s.updateVehicle)
-			protected.DELETE("/vehicles/:id", s.deleteVehicle)
-			protected.PATCH("/vehicles/:id/status", s.updateVehicleStatus)
-			protected.GET("/vehicles", s.listVehicles)
-			protected.GET("/drivers/:driver_id/vehicles", s.getVehiclesByDriver)
-			protected.GET("/drivers/:driver_id/vehicles/available", s.getAvailableVehicles)
+			protected.POST("/vehicles", s.vehicles.CreateVehicle)
+			if s.vehicles.importer != nil {
+				protected.POST("/vehicles/import", s.vehicles.ImportVehicles)
+				protected.GET("/vehicles/import/:job_id", s.vehicles.GetImportJob)
+				protected.GET("/vehicles/import/:job_id/errors", s.vehicles.GetImportErrorReport)
+			}
+			protected.GET("/vehicles/:id", s.vehicles.GetVehicle)
+			protected.PUT("/vehicles/:id", s.vehicles.UpdateVehicle)
+			protected.DELETE("/vehicles/:id", s.vehicles.DeleteVehicle)
+			protected.PATCH("/vehicles/:id/status", s.vehicles.UpdateVehicleStatus)
+			protected.GET("/vehicles/:id/features", s.vehicles.GetVehicleFeatures)
+			protected.PUT("/vehicles/:id/features", s.vehicles.SetVehicleFeatures)
+			protected.POST("/vehicles/:id/features", s.vehicles.AddVehicleFeature)
+			protected.DELETE("/vehicles/:id/features/:feature", s.vehicles.RemoveVehicleFeature)
+			protected.GET("/vehicles", s.vehicles.ListVehicles)
+			protected.GET("/drivers/:driver_id/vehicles", s.vehicles.GetVehiclesByDriver)
+			protected.GET("/drivers/:driver_id/vehicles/available", s.vehicles.GetAvailableVehicles)
+			if s.documents != nil {
+				protected.Any("/documents/*path", gin.WrapH(s.documents))
+			}
 		}
 	}
 
@@ -101,90 +136,22 @@ func (s *HTTPServer) Shutdown(ctx context.Context) error {
 	return s.server.Shutdown(ctx)
 }
 
-// Health check endpoint
-func (s *HTTPServer) healthCheck(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
-		"status":    "healthy",
-		"service":   "vehicle-service",
-		"timestamp": time.Now().UTC(),
-		"version":   "1.0.0",
-	})
-}
-
 // Readiness check endpoint
 func (s *HTTPServer) readinessCheck(c *gin.Context) {
-	// In a real implementation, you would check database connectivity, etc.
+	// Only ready once the required dependencies answer
+	report := s.health.Check(c.Request.Context())
+	if report.Status == health.StatusUnhealthy {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"status":       "not_ready",
+			"service":      "vehicle-service",
+			"timestamp":    report.Timestamp,
+			"dependencies": report.Dependencies,
+		})
+		return
+	}
 	c.JSON(http.StatusOK, gin.H{
 		"status":    "ready",
 		"service":   "vehicle-service",
-		"timestamp": time.Now().UTC(),
-	})
-}
-
-// HTTP handler methods (these would integrate with the vehicle service)
-
-func (s *HTTPServer) createVehicle(c *gin.Context) {
-	c.JSON(http.StatusNotImplemented, gin.H{
-		"error": "HTTP endpoints not implemented - use gRPC",
-	})
-}
-
-func (s *HTTPServer) getVehicle(c *gin.Context) {
-	c.JSON(http.StatusNotImplemented, gin.H{
-		"error": "HTTP endpoints not implemented - use gRPC",
-	})
-}
-
-func (s *HTTPServer) updateVehicle(c *gin.Context) {
-	c.JSON(http.StatusNotImplemented, gin.H{
-		"error": "HTTP endpoints not implemented - use gRPC",
+		"timestamp": report.Timestamp,
 	})
 }
-
-func (s *HTTPServer) deleteVehicle(c *gin.Context) {
-	c.JSON(http.StatusNotImplemented, gin.H{
-		"error": "HTTP endpoints not implemented - use gRPC",
-	})
-}
-
-func (s *HTTPServer) updateVehicleStatus(c *gin.Context) {
-	c.JSON(http.StatusNotImplemented, gin.H{
-		"error": "HTTP endpoints not implemented - use gRPC",
-	})
-}
-
-func (s *HTTPServer) listVehicles(c *gin.Context) {
-	c.JSON(http.StatusNotImplemented, gin.H{
-		"error": "HTTP endpoints not implemented - use gRPC",
-	})
-}
-
-func (s *HTTPServer) getVehiclesByDriver(c *gin.Context) {
-	c.JSON(http.StatusNotImplemented, gin.H{
-		"error": "HTTP endpoints not implemented - use gRPC",
-	})
-}
-
-func (s *HTTPServer) getAvailableVehicles(c *gin.Context) {
-	c.JSON(http.StatusNotImplemented, gin.H{
-		"error": "HTTP endpoints not implemented - use gRPC",
-	})
-}
-
-func (s *HTTPServer) getVehicleStats(c *gin.Context) {
-	c.JSON(http.StatusNotImplemented, gin.H{
-		"error": "HTTP endpoints not implemented - use gRPC",
-	})
-}
-
-// Placeholder for promhttp (would be imported from Prometheus)
-var promhttp = struct {
-	Handler func() http.Handler
-}{
-	Handler: func() http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			w.WriteHeader(http.StatusOK)
-			w.Write([]byte("# Prometheus metrics would be here\n"))
-		})
-	},
-}
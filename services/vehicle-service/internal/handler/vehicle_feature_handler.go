@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetVehicleFeaturesRequest replaces the features a vehicle offers
+type SetVehicleFeaturesRequest struct {
+	Features []string `json:"features"`
+}
+
+// AddVehicleFeatureRequest adds one feature to a vehicle
+type AddVehicleFeatureRequest struct {
+	Feature string `json:"feature" binding:"required"`
+}
+
+// GetVehicleFeatures lists the features a vehicle offers
+func (h *VehicleHandler) GetVehicleFeatures(c *gin.Context) {
+	features, err := h.vehicleService.GetVehicleFeatures(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		vehicleHTTPError(c, "Failed to get vehicle features", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"vehicle_id": c.Param("id"),
+		"features":   features,
+	})
+}
+
+// SetVehicleFeatures replaces the features a vehicle offers
+func (h *VehicleHandler) SetVehicleFeatures(c *gin.Context) {
+	var req SetVehicleFeaturesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	features, err := h.vehicleService.SetVehicleFeatures(c.Request.Context(), c.Param("id"), req.Features)
+	if err != nil {
+		vehicleHTTPError(c, "Failed to set vehicle features", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"vehicle_id": c.Param("id"),
+		"features":   features,
+	})
+}
+
+// AddVehicleFeature adds a feature to a vehicle
+func (h *VehicleHandler) AddVehicleFeature(c *gin.Context) {
+	var req AddVehicleFeatureRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "Invalid request",
+			"details": err.Error(),
+		})
+		return
+	}
+
+	if err := h.vehicleService.AddVehicleFeature(c.Request.Context(), c.Param("id"), req.Feature); err != nil {
+		vehicleHTTPError(c, "Failed to add vehicle feature", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RemoveVehicleFeature removes a feature from a vehicle
+func (h *VehicleHandler) RemoveVehicleFeature(c *gin.Context) {
+	if err := h.vehicleService.RemoveVehicleFeature(c.Request.Context(), c.Param("id"), c.Param("feature")); err != nil {
+		vehicleHTTPError(c, "Failed to remove vehicle feature", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rideshare-platform/services/vehicle-service/internal/service"
+	sharedmiddleware "github.com/rideshare-platform/shared/middleware"
+)
+
+// maxImportBytes caps the size of an uploaded import batch
+const maxImportBytes = 10 << 20
+
+// SetImporter enables the batch vehicle import endpoints
+func (h *VehicleHandler) SetImporter(importer *service.VehicleImporter) {
+	h.importer = importer
+}
+
+// ImportVehicles queues a CSV or JSON batch of vehicles for registration.
+// The format is taken from ?format= or else the Content-Type. Rows that
+// fail validation are reported on the returned job straight away.
+func (h *VehicleHandler) ImportVehicles(c *gin.Context) {
+	format := service.ImportFormat(c.Query("format"))
+	if format == "" {
+		mediaType, _, _ := mime.ParseMediaType(c.GetHeader("Content-Type"))
+		switch mediaType {
+		case "text/csv", "application/csv":
+			format = service.ImportFormatCSV
+		default:
+			format = service.ImportFormatJSON
+		}
+	}
+
+	rows, rowErrors, err := service.ParseVehicleImport(format, http.MaxBytesReader(c.Writer, c.Request.Body, maxImportBytes))
+	if err != nil {
+		importHTTPError(c, "Invalid vehicle import", err)
+		return
+	}
+
+	submittedBy, _ := sharedmiddleware.GetUserID(c)
+	job, err := h.importer.Submit(c.Request.Context(), submittedBy, rows, rowErrors)
+	if err != nil {
+		importHTTPError(c, "Failed to queue vehicle import", err)
+		return
+	}
+
+	c.Header("Location", "/api/v1/vehicles/import/"+job.ID)
+	c.JSON(http.StatusAccepted, job)
+}
+
+// GetImportJob returns an import job's progress and row errors
+func (h *VehicleHandler) GetImportJob(c *gin.Context) {
+	job, err := h.importer.Get(c.Request.Context(), c.Param("job_id"))
+	if err != nil {
+		importHTTPError(c, "Failed to get import job", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// GetImportErrorReport downloads an import job's row errors as CSV
+func (h *VehicleHandler) GetImportErrorReport(c *gin.Context) {
+	job, err := h.importer.Get(c.Request.Context(), c.Param("job_id"))
+	if err != nil {
+		importHTTPError(c, "Failed to get import job", err)
+		return
+	}
+
+	c.Header("Content-Type", "text/csv")
+	c.Header("Content-Disposition", `attachment; filename="`+job.ID+`-errors.csv"`)
+	c.Status(http.StatusOK)
+	if err := service.WriteImportErrorReport(c.Writer, job); err != nil {
+		c.Error(err)
+	}
+}
+
+func importHTTPError(c *gin.Context, message string, err error) {
+	status := http.StatusInternalServerError
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		status = http.StatusRequestEntityTooLarge
+	case errors.Is(err, service.ErrInvalidImport):
+		status = http.StatusBadRequest
+	case errors.Is(err, service.ErrImportJobNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, service.ErrImportQueueFull):
+		status = http.StatusServiceUnavailable
+	}
+
+	c.JSON(status, gin.H{
+		"error":   message,
+		"details": err.Error(),
+	})
+}
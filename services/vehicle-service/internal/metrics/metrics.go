@@ -1,32 +1,11 @@
 package metrics
 
 import (
-	"time"
-
-	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
 
 var (
-	// HTTP metrics
-	httpRequestsTotal = promauto.NewCounterVec(
-		prometheus.CounterOpts{
-			Name: "vehicle_service_http_requests_total",
-			Help: "Total number of HTTP requests processed",
-		},
-		[]string{"method", "endpoint", "status"},
-	)
-
-	httpRequestDuration = promauto.NewHistogramVec(
-		prometheus.HistogramOpts{
-			Name:    "vehicle_service_http_request_duration_seconds",
-			Help:    "Duration of HTTP requests in seconds",
-			Buckets: prometheus.DefBuckets,
-		},
-		[]string{"method", "endpoint"},
-	)
-
 	// Business metrics
 	vehiclesRegisteredTotal = promauto.NewCounter(
 		prometheus.CounterOpts{
@@ -50,31 +29,6 @@ var (
 	)
 )
 
-// PrometheusMiddleware creates a Gin middleware for Prometheus metrics
-func PrometheusMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		start := time.Now()
-
-		// Process request
-		c.Next()
-
-		// Record metrics
-		duration := time.Since(start).Seconds()
-		status := string(rune(c.Writer.Status()))
-
-		httpRequestsTotal.WithLabelValues(
-			c.Request.Method,
-			c.FullPath(),
-			status,
-		).Inc()
-
-		httpRequestDuration.WithLabelValues(
-			c.Request.Method,
-			c.FullPath(),
-		).Observe(duration)
-	}
-}
-
 // RecordVehicleRegistered increments the vehicles registered counter
 func RecordVehicleRegistered() {
 	vehiclesRegisteredTotal.Inc()
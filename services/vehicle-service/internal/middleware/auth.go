@@ -1,14 +1,24 @@
 package middleware
 
-import "github.com/gin-gonic/gin"
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rideshare-platform/shared/logger"
+	sharedmiddleware "github.com/rideshare-platform/shared/middleware"
+)
 
 // AuthMiddleware provides JWT authentication middleware.
-type AuthMiddleware struct{}
+type AuthMiddleware struct {
+	auth *sharedmiddleware.AuthMiddleware
+}
+
+// NewAuthMiddleware creates an auth middleware validating tokens signed with jwtSecret.
+func NewAuthMiddleware(jwtSecret string, log *logger.Logger) *AuthMiddleware {
+	return &AuthMiddleware{
+		auth: sharedmiddleware.NewAuthMiddleware(jwtSecret, log),
+	}
+}
 
 // JWTAuth returns a Gin middleware handler for JWT authentication.
 func (a *AuthMiddleware) JWTAuth() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		// Placeholder: In production, validate JWT here
-		c.Next()
-	}
+	return a.auth.JWTAuth()
 }
@@ -1,14 +1,21 @@
 package middleware
 
-import "github.com/gin-gonic/gin"
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/rideshare-platform/shared/monitoring"
+)
 
 // MetricsMiddleware provides metrics functionality for vehicle service.
-type MetricsMiddleware struct{}
+type MetricsMiddleware struct {
+	collector *monitoring.MetricsCollector
+}
+
+// NewMetricsMiddleware creates a metrics middleware recording into the shared collector.
+func NewMetricsMiddleware(collector *monitoring.MetricsCollector) *MetricsMiddleware {
+	return &MetricsMiddleware{collector: collector}
+}
 
 // PrometheusMetrics returns a Gin middleware handler for Prometheus metrics.
 func (m *MetricsMiddleware) PrometheusMetrics(serviceName string) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		// Placeholder: In production, integrate with Prometheus client
-		c.String(200, "# Prometheus metrics for %s\n", serviceName)
-	}
+	return m.collector.GinMiddleware(serviceName)
 }
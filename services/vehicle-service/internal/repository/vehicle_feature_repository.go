@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/rideshare-platform/shared/database"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+)
+
+// VehicleFeatureRepository keeps the features vehicles offer in the
+// vehicle_features table
+type VehicleFeatureRepository struct {
+	db     *database.PostgresDB
+	logger *logger.Logger
+}
+
+// NewVehicleFeatureRepository creates a new vehicle feature repository
+func NewVehicleFeatureRepository(db *database.PostgresDB, log *logger.Logger) *VehicleFeatureRepository {
+	return &VehicleFeatureRepository{
+		db:     db,
+		logger: log,
+	}
+}
+
+// GetFeatures returns the features of each vehicle keyed by vehicle ID,
+// leaving out vehicles without any
+func (r *VehicleFeatureRepository) GetFeatures(ctx context.Context, vehicleIDs []string) (map[string][]models.VehicleFeature, error) {
+	features := make(map[string][]models.VehicleFeature)
+	if len(vehicleIDs) == 0 {
+		return features, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT vehicle_id, feature
+		FROM vehicle_features
+		WHERE vehicle_id::text = ANY($1)
+		ORDER BY vehicle_id, feature
+	`, pq.Array(vehicleIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vehicle features: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var vehicleID string
+		var feature models.VehicleFeature
+		if err := rows.Scan(&vehicleID, &feature); err != nil {
+			return nil, fmt.Errorf("failed to scan vehicle feature: %w", err)
+		}
+		features[vehicleID] = append(features[vehicleID], feature)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating vehicle features: %w", err)
+	}
+	return features, nil
+}
+
+// SetFeatures replaces a vehicle's features
+func (r *VehicleFeatureRepository) SetFeatures(ctx context.Context, vehicleID string, features []models.VehicleFeature) error {
+	err := r.db.WithTransaction(ctx, nil, func(tx *database.Transaction) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM vehicle_features WHERE vehicle_id = $1`, vehicleID); err != nil {
+			return err
+		}
+		for _, feature := range features {
+			if _, err := tx.ExecContext(ctx, `
+				INSERT INTO vehicle_features (vehicle_id, feature)
+				VALUES ($1, $2)
+				ON CONFLICT DO NOTHING
+			`, vehicleID, feature); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+			"vehicle_id": vehicleID,
+		}).Error("Failed to set vehicle features")
+		return fmt.Errorf("failed to set vehicle features: %w", err)
+	}
+	return nil
+}
+
+// AddFeature adds a feature to a vehicle. Adding a feature it has succeeds.
+func (r *VehicleFeatureRepository) AddFeature(ctx context.Context, vehicleID string, feature models.VehicleFeature) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO vehicle_features (vehicle_id, feature)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`, vehicleID, feature)
+	if err != nil {
+		return fmt.Errorf("failed to add vehicle feature: %w", err)
+	}
+	return nil
+}
+
+// RemoveFeature removes a feature from a vehicle. Removing a feature it does
+// not have succeeds.
+func (r *VehicleFeatureRepository) RemoveFeature(ctx context.Context, vehicleID string, feature models.VehicleFeature) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM vehicle_features WHERE vehicle_id = $1 AND feature = $2`, vehicleID, feature)
+	if err != nil {
+		return fmt.Errorf("failed to remove vehicle feature: %w", err)
+	}
+	return nil
+}
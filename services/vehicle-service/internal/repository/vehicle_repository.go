@@ -3,15 +3,23 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/rideshare-platform/shared/database"
 	"github.com/rideshare-platform/shared/logger"
 	"github.com/rideshare-platform/shared/models"
+	"github.com/rideshare-platform/shared/pagination"
 )
 
-// VehicleRepository handles vehicle data persistence
+// ErrVehicleNotFound is returned when no vehicle matches the lookup
+var ErrVehicleNotFound = errors.New("vehicle not found")
+
+// VehicleRepository handles vehicle data persistence. Listing, counting and
+// the expiry scans read from replicas when configured; lookups that follow a
+// write, such as GetByID and license plate checks, stay on the primary.
 type VehicleRepository struct {
 	db     *database.PostgresDB
 	logger *logger.Logger
@@ -30,15 +38,15 @@ func (r *VehicleRepository) Create(ctx context.Context, vehicle *models.Vehicle)
 	query := `
 		INSERT INTO vehicles (id, driver_id, make, model, year, color, license_plate,
 			vehicle_type, status, capacity, insurance_policy_number,
-			insurance_expiry, registration_expiry, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+			insurance_expiry, registration_expiry, photo_key, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
 	`
 
 	_, err := r.db.ExecContext(ctx, query,
 		vehicle.ID, vehicle.DriverID, vehicle.Make, vehicle.Model, vehicle.Year,
 		vehicle.Color, vehicle.LicensePlate, vehicle.VehicleType, vehicle.Status,
 		vehicle.Capacity, vehicle.InsurancePolicyNumber,
-		vehicle.InsuranceExpiry, vehicle.RegistrationExpiry,
+		vehicle.InsuranceExpiry, vehicle.RegistrationExpiry, vehicle.PhotoKey,
 		vehicle.CreatedAt, vehicle.UpdatedAt,
 	)
 
@@ -67,7 +75,7 @@ func (r *VehicleRepository) GetByID(ctx context.Context, id string) (*models.Veh
 	query := `
 		SELECT id, driver_id, make, model, year, color, license_plate,
 			vehicle_type, status, capacity, insurance_policy_number,
-			insurance_expiry, registration_expiry, created_at, updated_at
+			insurance_expiry, registration_expiry, photo_key, created_at, updated_at
 		FROM vehicles
 		WHERE id = $1
 	`
@@ -78,13 +86,13 @@ func (r *VehicleRepository) GetByID(ctx context.Context, id string) (*models.Veh
 		&vehicle.ID, &vehicle.DriverID, &vehicle.Make, &vehicle.Model, &vehicle.Year,
 		&vehicle.Color, &vehicle.LicensePlate, &vehicle.VehicleType, &vehicle.Status,
 		&vehicle.Capacity, &vehicle.InsurancePolicyNumber,
-		&vehicle.InsuranceExpiry, &vehicle.RegistrationExpiry,
+		&vehicle.InsuranceExpiry, &vehicle.RegistrationExpiry, &vehicle.PhotoKey,
 		&vehicle.CreatedAt, &vehicle.UpdatedAt,
 	)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("vehicle not found: %s", id)
+			return nil, fmt.Errorf("%w: %s", ErrVehicleNotFound, id)
 		}
 		r.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
 			"vehicle_id": id,
@@ -100,7 +108,7 @@ func (r *VehicleRepository) GetByDriverID(ctx context.Context, driverID string)
 	query := `
 		SELECT id, driver_id, make, model, year, color, license_plate,
 			vehicle_type, status, capacity, insurance_policy_number,
-			insurance_expiry, registration_expiry, created_at, updated_at
+			insurance_expiry, registration_expiry, photo_key, created_at, updated_at
 		FROM vehicles
 		WHERE driver_id = $1
 		ORDER BY created_at DESC
@@ -123,7 +131,7 @@ func (r *VehicleRepository) GetByDriverID(ctx context.Context, driverID string)
 			&vehicle.ID, &vehicle.DriverID, &vehicle.Make, &vehicle.Model, &vehicle.Year,
 			&vehicle.Color, &vehicle.LicensePlate, &vehicle.VehicleType, &vehicle.Status,
 			&vehicle.Capacity, &vehicle.InsurancePolicyNumber,
-			&vehicle.InsuranceExpiry, &vehicle.RegistrationExpiry,
+			&vehicle.InsuranceExpiry, &vehicle.RegistrationExpiry, &vehicle.PhotoKey,
 			&vehicle.CreatedAt, &vehicle.UpdatedAt,
 		)
 		if err != nil {
@@ -146,7 +154,7 @@ func (r *VehicleRepository) GetByLicensePlate(ctx context.Context, licensePlate
 	query := `
 		SELECT id, driver_id, make, model, year, color, license_plate,
 			vehicle_type, status, capacity, insurance_policy_number,
-			insurance_expiry, registration_expiry, created_at, updated_at
+			insurance_expiry, registration_expiry, photo_key, created_at, updated_at
 		FROM vehicles
 		WHERE license_plate = $1
 	`
@@ -157,13 +165,13 @@ func (r *VehicleRepository) GetByLicensePlate(ctx context.Context, licensePlate
 		&vehicle.ID, &vehicle.DriverID, &vehicle.Make, &vehicle.Model, &vehicle.Year,
 		&vehicle.Color, &vehicle.LicensePlate, &vehicle.VehicleType, &vehicle.Status,
 		&vehicle.Capacity, &vehicle.InsurancePolicyNumber,
-		&vehicle.InsuranceExpiry, &vehicle.RegistrationExpiry,
+		&vehicle.InsuranceExpiry, &vehicle.RegistrationExpiry, &vehicle.PhotoKey,
 		&vehicle.CreatedAt, &vehicle.UpdatedAt,
 	)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("vehicle not found: %s", licensePlate)
+			return nil, fmt.Errorf("%w: %s", ErrVehicleNotFound, licensePlate)
 		}
 		r.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
 			"license_plate": licensePlate,
@@ -181,7 +189,7 @@ func (r *VehicleRepository) Update(ctx context.Context, vehicle *models.Vehicle)
 		SET driver_id = $2, make = $3, model = $4, year = $5, color = $6,
 			license_plate = $7, vehicle_type = $8, status = $9, capacity = $10,
 			insurance_policy_number = $11, insurance_expiry = $12,
-			registration_expiry = $13, updated_at = $14
+			registration_expiry = $13, photo_key = $14, updated_at = $15
 		WHERE id = $1
 	`
 
@@ -189,7 +197,7 @@ func (r *VehicleRepository) Update(ctx context.Context, vehicle *models.Vehicle)
 		vehicle.ID, vehicle.DriverID, vehicle.Make, vehicle.Model, vehicle.Year,
 		vehicle.Color, vehicle.LicensePlate, vehicle.VehicleType, vehicle.Status,
 		vehicle.Capacity, vehicle.InsurancePolicyNumber,
-		vehicle.InsuranceExpiry, vehicle.RegistrationExpiry, vehicle.UpdatedAt,
+		vehicle.InsuranceExpiry, vehicle.RegistrationExpiry, vehicle.PhotoKey, vehicle.UpdatedAt,
 	)
 
 	if err != nil {
@@ -205,7 +213,7 @@ func (r *VehicleRepository) Update(ctx context.Context, vehicle *models.Vehicle)
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("vehicle not found: %s", vehicle.ID)
+		return fmt.Errorf("%w: %s", ErrVehicleNotFound, vehicle.ID)
 	}
 
 	r.logger.WithContext(ctx).WithFields(logger.Fields{
@@ -238,7 +246,7 @@ func (r *VehicleRepository) UpdateStatus(ctx context.Context, id string, status
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("vehicle not found: %s", id)
+		return fmt.Errorf("%w: %s", ErrVehicleNotFound, id)
 	}
 
 	r.logger.WithContext(ctx).WithFields(logger.Fields{
@@ -271,7 +279,7 @@ func (r *VehicleRepository) Delete(ctx context.Context, id string) error {
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("vehicle not found: %s", id)
+		return fmt.Errorf("%w: %s", ErrVehicleNotFound, id)
 	}
 
 	r.logger.WithContext(ctx).WithFields(logger.Fields{
@@ -281,16 +289,29 @@ func (r *VehicleRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-// List retrieves vehicles with pagination and filtering
-func (r *VehicleRepository) List(ctx context.Context, limit, offset int, status string, vehicleType string) ([]*models.Vehicle, error) {
+// vehicleSortColumns maps the fields vehicle lists sort on to their columns
+var vehicleSortColumns = map[string]string{
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+	"year":       "year",
+}
+
+// List retrieves a page of vehicles matching the filters, reading one past
+// the page so the caller can tell whether another follows
+func (r *VehicleRepository) List(ctx context.Context, page pagination.Request, filters map[string]interface{}) ([]*models.Vehicle, error) {
 	var query string
 	var args []interface{}
 	argIndex := 1
+	status, vehicleType := listFilters(filters)
+	column, ok := vehicleSortColumns[page.Sort.Field.Name]
+	if !ok {
+		return nil, fmt.Errorf("%w: cannot sort vehicles on %q", pagination.ErrInvalidSort, page.Sort.Field.Name)
+	}
 
 	baseQuery := `
 		SELECT id, driver_id, make, model, year, color, license_plate,
 			vehicle_type, status, capacity, insurance_policy_number,
-			insurance_expiry, registration_expiry, created_at, updated_at
+			insurance_expiry, registration_expiry, photo_key, created_at, updated_at
 		FROM vehicles
 		WHERE 1=1
 	`
@@ -308,10 +329,16 @@ func (r *VehicleRepository) List(ctx context.Context, limit, offset int, status
 		argIndex++
 	}
 
-	query = baseQuery + conditions + fmt.Sprintf(" ORDER BY created_at DESC LIMIT $%d OFFSET $%d", argIndex, argIndex+1)
-	args = append(args, limit, offset)
+	if keyset, keysetArgs := page.Keyset(column, "id", argIndex); keyset != "" {
+		conditions += " AND " + keyset
+		args = append(args, keysetArgs...)
+		argIndex += len(keysetArgs)
+	}
+
+	query = baseQuery + conditions + fmt.Sprintf(" ORDER BY %s LIMIT $%d", page.OrderBy(column, "id"), argIndex)
+	args = append(args, page.FetchLimit())
 
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	rows, err := r.db.ReadQueryContext(ctx, query, args...)
 	if err != nil {
 		r.logger.WithContext(ctx).WithError(err).Error("Failed to list vehicles")
 		return nil, fmt.Errorf("failed to list vehicles: %w", err)
@@ -326,7 +353,7 @@ func (r *VehicleRepository) List(ctx context.Context, limit, offset int, status
 			&vehicle.ID, &vehicle.DriverID, &vehicle.Make, &vehicle.Model, &vehicle.Year,
 			&vehicle.Color, &vehicle.LicensePlate, &vehicle.VehicleType, &vehicle.Status,
 			&vehicle.Capacity, &vehicle.InsurancePolicyNumber,
-			&vehicle.InsuranceExpiry, &vehicle.RegistrationExpiry,
+			&vehicle.InsuranceExpiry, &vehicle.RegistrationExpiry, &vehicle.PhotoKey,
 			&vehicle.CreatedAt, &vehicle.UpdatedAt,
 		)
 		if err != nil {
@@ -345,10 +372,11 @@ func (r *VehicleRepository) List(ctx context.Context, limit, offset int, status
 }
 
 // Count counts total vehicles with filtering
-func (r *VehicleRepository) Count(ctx context.Context, status string, vehicleType string) (int64, error) {
+func (r *VehicleRepository) Count(ctx context.Context, filters map[string]interface{}) (int64, error) {
 	var query string
 	var args []interface{}
 	argIndex := 1
+	status, vehicleType := listFilters(filters)
 
 	baseQuery := "SELECT COUNT(*) FROM vehicles WHERE 1=1"
 	conditions := ""
@@ -368,7 +396,7 @@ func (r *VehicleRepository) Count(ctx context.Context, status string, vehicleTyp
 	query = baseQuery + conditions
 
 	var count int64
-	err := r.db.QueryRowContext(ctx, query, args...).Scan(&count)
+	err := r.db.ReadQueryRowContext(ctx, query, args...).Scan(&count)
 	if err != nil {
 		r.logger.WithContext(ctx).WithError(err).Error("Failed to count vehicles")
 		return 0, fmt.Errorf("failed to count vehicles: %w", err)
@@ -382,13 +410,13 @@ func (r *VehicleRepository) GetAvailableVehicles(ctx context.Context, driverID s
 	query := `
 		SELECT id, driver_id, make, model, year, color, license_plate,
 			vehicle_type, status, capacity, insurance_policy_number,
-			insurance_expiry, registration_expiry, created_at, updated_at
+			insurance_expiry, registration_expiry, photo_key, created_at, updated_at
 		FROM vehicles
 		WHERE driver_id = $1 AND status = 'active'
 		ORDER BY created_at DESC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, driverID)
+	rows, err := r.db.ReadQueryContext(ctx, query, driverID)
 	if err != nil {
 		r.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
 			"driver_id": driverID,
@@ -405,7 +433,54 @@ func (r *VehicleRepository) GetAvailableVehicles(ctx context.Context, driverID s
 			&vehicle.ID, &vehicle.DriverID, &vehicle.Make, &vehicle.Model, &vehicle.Year,
 			&vehicle.Color, &vehicle.LicensePlate, &vehicle.VehicleType, &vehicle.Status,
 			&vehicle.Capacity, &vehicle.InsurancePolicyNumber,
-			&vehicle.InsuranceExpiry, &vehicle.RegistrationExpiry,
+			&vehicle.InsuranceExpiry, &vehicle.RegistrationExpiry, &vehicle.PhotoKey,
+			&vehicle.CreatedAt, &vehicle.UpdatedAt,
+		)
+		if err != nil {
+			r.logger.WithContext(ctx).WithError(err).Error("Failed to scan available vehicle row")
+			return nil, fmt.Errorf("failed to scan available vehicle: %w", err)
+		}
+
+		vehicles = append(vehicles, vehicle)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating available vehicles: %w", err)
+	}
+
+	return vehicles, nil
+}
+
+// GetAvailableByDriverIDs retrieves the available vehicles of several drivers,
+// newest first for each driver
+func (r *VehicleRepository) GetAvailableByDriverIDs(ctx context.Context, driverIDs []string) ([]*models.Vehicle, error) {
+	query := `
+		SELECT id, driver_id, make, model, year, color, license_plate,
+			vehicle_type, status, capacity, insurance_policy_number,
+			insurance_expiry, registration_expiry, photo_key, created_at, updated_at
+		FROM vehicles
+		WHERE driver_id::text = ANY($1) AND status = 'active'
+		ORDER BY driver_id, created_at DESC
+	`
+
+	rows, err := r.db.ReadQueryContext(ctx, query, pq.Array(driverIDs))
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+			"driver_count": len(driverIDs),
+		}).Error("Failed to get available vehicles by driver IDs")
+		return nil, fmt.Errorf("failed to get available vehicles by driver IDs: %w", err)
+	}
+	defer rows.Close()
+
+	var vehicles []*models.Vehicle
+	for rows.Next() {
+		vehicle := &models.Vehicle{}
+
+		err := rows.Scan(
+			&vehicle.ID, &vehicle.DriverID, &vehicle.Make, &vehicle.Model, &vehicle.Year,
+			&vehicle.Color, &vehicle.LicensePlate, &vehicle.VehicleType, &vehicle.Status,
+			&vehicle.Capacity, &vehicle.InsurancePolicyNumber,
+			&vehicle.InsuranceExpiry, &vehicle.RegistrationExpiry, &vehicle.PhotoKey,
 			&vehicle.CreatedAt, &vehicle.UpdatedAt,
 		)
 		if err != nil {
@@ -428,7 +503,7 @@ func (r *VehicleRepository) GetVehiclesWithExpiredInsurance(ctx context.Context)
 	query := `
 		SELECT id, driver_id, make, model, year, color, license_plate,
 			vehicle_type, status, capacity, insurance_policy_number,
-			insurance_expiry, registration_expiry, created_at, updated_at
+			insurance_expiry, registration_expiry, photo_key, created_at, updated_at
 		FROM vehicles
 		WHERE insurance_expiry IS NOT NULL 
 			AND insurance_expiry <= $1
@@ -436,7 +511,7 @@ func (r *VehicleRepository) GetVehiclesWithExpiredInsurance(ctx context.Context)
 		ORDER BY insurance_expiry ASC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, time.Now())
+	rows, err := r.db.ReadQueryContext(ctx, query, time.Now())
 	if err != nil {
 		r.logger.WithContext(ctx).WithError(err).Error("Failed to get vehicles with expired insurance")
 		return nil, fmt.Errorf("failed to get vehicles with expired insurance: %w", err)
@@ -451,7 +526,7 @@ func (r *VehicleRepository) GetVehiclesWithExpiredInsurance(ctx context.Context)
 			&vehicle.ID, &vehicle.DriverID, &vehicle.Make, &vehicle.Model, &vehicle.Year,
 			&vehicle.Color, &vehicle.LicensePlate, &vehicle.VehicleType, &vehicle.Status,
 			&vehicle.Capacity, &vehicle.InsurancePolicyNumber,
-			&vehicle.InsuranceExpiry, &vehicle.RegistrationExpiry,
+			&vehicle.InsuranceExpiry, &vehicle.RegistrationExpiry, &vehicle.PhotoKey,
 			&vehicle.CreatedAt, &vehicle.UpdatedAt,
 		)
 		if err != nil {
@@ -474,7 +549,7 @@ func (r *VehicleRepository) GetVehiclesWithExpiredRegistration(ctx context.Conte
 	query := `
 		SELECT id, driver_id, make, model, year, color, license_plate,
 			vehicle_type, status, capacity, insurance_policy_number,
-			insurance_expiry, registration_expiry, created_at, updated_at
+			insurance_expiry, registration_expiry, photo_key, created_at, updated_at
 		FROM vehicles
 		WHERE registration_expiry IS NOT NULL 
 			AND registration_expiry <= $1
@@ -482,7 +557,7 @@ func (r *VehicleRepository) GetVehiclesWithExpiredRegistration(ctx context.Conte
 		ORDER BY registration_expiry ASC
 	`
 
-	rows, err := r.db.QueryContext(ctx, query, time.Now())
+	rows, err := r.db.ReadQueryContext(ctx, query, time.Now())
 	if err != nil {
 		r.logger.WithContext(ctx).WithError(err).Error("Failed to get vehicles with expired registration")
 		return nil, fmt.Errorf("failed to get vehicles with expired registration: %w", err)
@@ -497,7 +572,7 @@ func (r *VehicleRepository) GetVehiclesWithExpiredRegistration(ctx context.Conte
 			&vehicle.ID, &vehicle.DriverID, &vehicle.Make, &vehicle.Model, &vehicle.Year,
 			&vehicle.Color, &vehicle.LicensePlate, &vehicle.VehicleType, &vehicle.Status,
 			&vehicle.Capacity, &vehicle.InsurancePolicyNumber,
-			&vehicle.InsuranceExpiry, &vehicle.RegistrationExpiry,
+			&vehicle.InsuranceExpiry, &vehicle.RegistrationExpiry, &vehicle.PhotoKey,
 			&vehicle.CreatedAt, &vehicle.UpdatedAt,
 		)
 		if err != nil {
@@ -515,6 +590,53 @@ func (r *VehicleRepository) GetVehiclesWithExpiredRegistration(ctx context.Conte
 	return vehicles, nil
 }
 
+// GetVehiclesWithDocumentsExpiringBefore retrieves in-service vehicles whose
+// insurance or registration expires on or before the cutoff
+func (r *VehicleRepository) GetVehiclesWithDocumentsExpiringBefore(ctx context.Context, cutoff time.Time) ([]*models.Vehicle, error) {
+	query := `
+		SELECT id, driver_id, make, model, year, color, license_plate,
+			vehicle_type, status, capacity, insurance_policy_number,
+			insurance_expiry, registration_expiry, photo_key, created_at, updated_at
+		FROM vehicles
+		WHERE status NOT IN ('inactive', 'retired')
+			AND ((insurance_expiry IS NOT NULL AND insurance_expiry <= $1)
+				OR (registration_expiry IS NOT NULL AND registration_expiry <= $1))
+		ORDER BY LEAST(COALESCE(insurance_expiry, $1), COALESCE(registration_expiry, $1)) ASC
+	`
+
+	rows, err := r.db.ReadQueryContext(ctx, query, cutoff)
+	if err != nil {
+		r.logger.WithContext(ctx).WithError(err).Error("Failed to get vehicles with expiring documents")
+		return nil, fmt.Errorf("failed to get vehicles with expiring documents: %w", err)
+	}
+	defer rows.Close()
+
+	var vehicles []*models.Vehicle
+	for rows.Next() {
+		vehicle := &models.Vehicle{}
+
+		err := rows.Scan(
+			&vehicle.ID, &vehicle.DriverID, &vehicle.Make, &vehicle.Model, &vehicle.Year,
+			&vehicle.Color, &vehicle.LicensePlate, &vehicle.VehicleType, &vehicle.Status,
+			&vehicle.Capacity, &vehicle.InsurancePolicyNumber,
+			&vehicle.InsuranceExpiry, &vehicle.RegistrationExpiry, &vehicle.PhotoKey,
+			&vehicle.CreatedAt, &vehicle.UpdatedAt,
+		)
+		if err != nil {
+			r.logger.WithContext(ctx).WithError(err).Error("Failed to scan expiring documents vehicle row")
+			return nil, fmt.Errorf("failed to scan expiring documents vehicle: %w", err)
+		}
+
+		vehicles = append(vehicles, vehicle)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating expiring documents vehicles: %w", err)
+	}
+
+	return vehicles, nil
+}
+
 // LicensePlateExists checks if a license plate already exists
 func (r *VehicleRepository) LicensePlateExists(ctx context.Context, licensePlate string) (bool, error) {
 	query := "SELECT EXISTS(SELECT 1 FROM vehicles WHERE license_plate = $1)"
@@ -553,7 +675,7 @@ func (r *VehicleRepository) UpdateInsuranceInfo(ctx context.Context, id, policyN
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("vehicle not found: %s", id)
+		return fmt.Errorf("%w: %s", ErrVehicleNotFound, id)
 	}
 
 	r.logger.WithContext(ctx).WithFields(logger.Fields{
@@ -587,7 +709,7 @@ func (r *VehicleRepository) UpdateRegistrationExpiry(ctx context.Context, id str
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("vehicle not found: %s", id)
+		return fmt.Errorf("%w: %s", ErrVehicleNotFound, id)
 	}
 
 	r.logger.WithContext(ctx).WithFields(logger.Fields{
@@ -597,3 +719,14 @@ func (r *VehicleRepository) UpdateRegistrationExpiry(ctx context.Context, id str
 
 	return nil
 }
+
+// listFilters extracts the supported status and vehicle_type filters
+func listFilters(filters map[string]interface{}) (status, vehicleType string) {
+	if value, ok := filters["status"]; ok {
+		status = fmt.Sprint(value)
+	}
+	if value, ok := filters["vehicle_type"]; ok {
+		vehicleType = fmt.Sprint(value)
+	}
+	return status, vehicleType
+}
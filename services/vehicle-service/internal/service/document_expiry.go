@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/rideshare-platform/shared/events"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+)
+
+// VehicleDocument identifies a vehicle document with an expiry date
+type VehicleDocument string
+
+const (
+	VehicleDocumentInsurance    VehicleDocument = "insurance"
+	VehicleDocumentRegistration VehicleDocument = "registration"
+)
+
+// DocumentExpiryNotice tells a driver that one of their vehicle documents is
+// about to expire or has lapsed
+type DocumentExpiryNotice struct {
+	VehicleID     string          `json:"vehicle_id"`
+	DriverID      string          `json:"driver_id"`
+	LicensePlate  string          `json:"license_plate"`
+	Document      VehicleDocument `json:"document"`
+	ExpiresAt     time.Time       `json:"expires_at"`
+	DaysRemaining int             `json:"days_remaining"`
+	// Lapsed is set when the document has expired and the vehicle was deactivated
+	Lapsed bool `json:"lapsed"`
+}
+
+// DriverNotifier delivers document expiry notices to drivers
+type DriverNotifier interface {
+	NotifyDocumentExpiry(ctx context.Context, notice *DocumentExpiryNotice) error
+}
+
+// DocumentExpiryResult summarises one run of the document expiry check
+type DocumentExpiryResult struct {
+	Expiring    int `json:"expiring"`
+	Deactivated int `json:"deactivated"`
+}
+
+// SetDriverNotifier attaches the notifier used to tell drivers about expiring documents
+func (s *VehicleService) SetDriverNotifier(notifier DriverNotifier) {
+	s.driverNotifier = notifier
+}
+
+// CheckDocumentExpiry warns drivers about documents expiring within the warning
+// window and deactivates vehicles whose documents have already lapsed
+func (s *VehicleService) CheckDocumentExpiry(ctx context.Context, now time.Time, warningWindow time.Duration) (*DocumentExpiryResult, error) {
+	vehicles, err := s.vehicleRepo.GetVehiclesWithDocumentsExpiringBefore(ctx, now.Add(warningWindow))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vehicles with expiring documents: %w", err)
+	}
+
+	result := &DocumentExpiryResult{}
+	for _, vehicle := range vehicles {
+		notices := expiryNotices(vehicle, now, warningWindow)
+		if len(notices) == 0 {
+			continue
+		}
+
+		lapsed := false
+		for _, notice := range notices {
+			lapsed = lapsed || notice.Lapsed
+		}
+
+		if lapsed {
+			if err := s.deactivateForLapsedDocuments(ctx, vehicle, notices); err != nil {
+				if s.logger != nil {
+					s.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+						"vehicle_id": vehicle.ID,
+					}).Error("Failed to deactivate vehicle with lapsed documents")
+				}
+				continue
+			}
+			result.Deactivated++
+		} else {
+			result.Expiring++
+		}
+
+		for _, notice := range notices {
+			if !notice.Lapsed {
+				s.publishDocumentExpiring(ctx, notice)
+			}
+			s.notifyDriver(ctx, notice)
+		}
+	}
+
+	if s.logger != nil && (result.Expiring > 0 || result.Deactivated > 0) {
+		s.logger.WithContext(ctx).WithFields(logger.Fields{
+			"expiring":    result.Expiring,
+			"deactivated": result.Deactivated,
+		}).Info("Vehicle document expiry check completed")
+	}
+
+	return result, nil
+}
+
+// StartDocumentExpiryJob runs the document expiry check on start and then every
+// interval until the context is cancelled
+func (s *VehicleService) StartDocumentExpiryJob(ctx context.Context, interval, warningWindow time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	run := func(now time.Time) {
+		if _, err := s.CheckDocumentExpiry(ctx, now, warningWindow); err != nil && s.logger != nil {
+			s.logger.WithContext(ctx).WithError(err).Warn("Vehicle document expiry check failed")
+		}
+	}
+
+	run(time.Now())
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			run(now)
+		}
+	}
+}
+
+func (s *VehicleService) deactivateForLapsedDocuments(ctx context.Context, vehicle *models.Vehicle, notices []*DocumentExpiryNotice) error {
+	if err := s.UpdateVehicleStatus(ctx, vehicle.ID, models.VehicleStatusInactive); err != nil {
+		return err
+	}
+
+	var documents []string
+	for _, notice := range notices {
+		if notice.Lapsed {
+			documents = append(documents, string(notice.Document))
+		}
+	}
+
+	if s.eventPublisher != nil {
+		event := events.NewEvent(
+			events.VehicleDeactivatedEvent,
+			vehicle.ID,
+			1,
+			map[string]interface{}{
+				"vehicle_id":        vehicle.ID,
+				"driver_id":         vehicle.DriverID,
+				"license_plate":     vehicle.LicensePlate,
+				"reason":            "document_expired",
+				"expired_documents": documents,
+			},
+			"vehicle-service",
+		)
+
+		if err := s.eventPublisher.PublishEvent(ctx, event); err != nil && s.logger != nil {
+			s.logger.WithContext(ctx).WithError(err).Warn("Failed to publish vehicle deactivated event")
+		}
+	}
+
+	return nil
+}
+
+func (s *VehicleService) publishDocumentExpiring(ctx context.Context, notice *DocumentExpiryNotice) {
+	if s.eventPublisher == nil {
+		return
+	}
+
+	event := events.NewEvent(
+		events.VehicleDocumentExpiringEvent,
+		notice.VehicleID,
+		1,
+		map[string]interface{}{
+			"vehicle_id":     notice.VehicleID,
+			"driver_id":      notice.DriverID,
+			"license_plate":  notice.LicensePlate,
+			"document":       string(notice.Document),
+			"expires_at":     notice.ExpiresAt,
+			"days_remaining": notice.DaysRemaining,
+		},
+		"vehicle-service",
+	)
+
+	if err := s.eventPublisher.PublishEvent(ctx, event); err != nil && s.logger != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("Failed to publish vehicle document expiring event")
+	}
+}
+
+func (s *VehicleService) notifyDriver(ctx context.Context, notice *DocumentExpiryNotice) {
+	if s.driverNotifier == nil {
+		return
+	}
+
+	if err := s.driverNotifier.NotifyDocumentExpiry(ctx, notice); err != nil && s.logger != nil {
+		s.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+			"vehicle_id": notice.VehicleID,
+			"driver_id":  notice.DriverID,
+			"document":   notice.Document,
+		}).Warn("Failed to notify driver about document expiry")
+	}
+}
+
+// expiryNotices lists the vehicle's documents that expire within the warning window
+func expiryNotices(vehicle *models.Vehicle, now time.Time, warningWindow time.Duration) []*DocumentExpiryNotice {
+	documents := []struct {
+		document VehicleDocument
+		expiry   *time.Time
+	}{
+		{VehicleDocumentInsurance, vehicle.InsuranceExpiry},
+		{VehicleDocumentRegistration, vehicle.RegistrationExpiry},
+	}
+
+	cutoff := now.Add(warningWindow)
+	var notices []*DocumentExpiryNotice
+	for _, doc := range documents {
+		if doc.expiry == nil || doc.expiry.After(cutoff) {
+			continue
+		}
+		notices = append(notices, &DocumentExpiryNotice{
+			VehicleID:     vehicle.ID,
+			DriverID:      vehicle.DriverID,
+			LicensePlate:  vehicle.LicensePlate,
+			Document:      doc.document,
+			ExpiresAt:     *doc.expiry,
+			DaysRemaining: int(math.Ceil(doc.expiry.Sub(now).Hours() / 24)),
+			Lapsed:        !doc.expiry.After(now),
+		})
+	}
+	return notices
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rideshare-platform/shared/models"
+)
+
+// recordingNotifier captures the notices sent to drivers
+type recordingNotifier struct {
+	notices []*DocumentExpiryNotice
+}
+
+func (n *recordingNotifier) NotifyDocumentExpiry(ctx context.Context, notice *DocumentExpiryNotice) error {
+	n.notices = append(n.notices, notice)
+	return nil
+}
+
+func TestVehicleService_CheckDocumentExpiry(t *testing.T) {
+	repo := NewMockVehicleRepository()
+	service := NewVehicleService(repo, nil, nil, nil)
+	notifier := &recordingNotifier{}
+	service.SetDriverNotifier(notifier)
+
+	now := time.Now()
+	expiringSoon := now.Add(10 * 24 * time.Hour)
+	lapsed := now.Add(-24 * time.Hour)
+	farAway := now.Add(365 * 24 * time.Hour)
+
+	vehicles := []*models.Vehicle{
+		{ID: "expiring", DriverID: "driver-1", Status: models.VehicleStatusActive, InsuranceExpiry: &expiringSoon, RegistrationExpiry: &farAway},
+		{ID: "lapsed", DriverID: "driver-2", Status: models.VehicleStatusActive, InsuranceExpiry: &farAway, RegistrationExpiry: &lapsed},
+		{ID: "valid", DriverID: "driver-3", Status: models.VehicleStatusActive, InsuranceExpiry: &farAway, RegistrationExpiry: &farAway},
+		{ID: "already-inactive", DriverID: "driver-4", Status: models.VehicleStatusInactive, InsuranceExpiry: &lapsed},
+	}
+	for _, vehicle := range vehicles {
+		if err := repo.Create(context.Background(), vehicle); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	result, err := service.CheckDocumentExpiry(context.Background(), now, 30*24*time.Hour)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Expiring != 1 || result.Deactivated != 1 {
+		t.Errorf("Expected 1 expiring and 1 deactivated vehicle, got %+v", result)
+	}
+
+	if repo.vehicles["lapsed"].Status != models.VehicleStatusInactive {
+		t.Errorf("Expected vehicle with lapsed registration to be deactivated, got %s", repo.vehicles["lapsed"].Status)
+	}
+	if repo.vehicles["expiring"].Status != models.VehicleStatusActive {
+		t.Errorf("Expected vehicle with expiring insurance to stay active, got %s", repo.vehicles["expiring"].Status)
+	}
+
+	if len(notifier.notices) != 2 {
+		t.Fatalf("Expected 2 driver notices, got %d", len(notifier.notices))
+	}
+	for _, notice := range notifier.notices {
+		switch notice.VehicleID {
+		case "expiring":
+			if notice.Document != VehicleDocumentInsurance || notice.Lapsed || notice.DaysRemaining != 10 {
+				t.Errorf("Unexpected notice for expiring vehicle: %+v", notice)
+			}
+		case "lapsed":
+			if notice.Document != VehicleDocumentRegistration || !notice.Lapsed {
+				t.Errorf("Unexpected notice for lapsed vehicle: %+v", notice)
+			}
+		default:
+			t.Errorf("Unexpected notice for vehicle %s", notice.VehicleID)
+		}
+	}
+
+	// Deactivated vehicles are not picked up again on the next run
+	result, err = service.CheckDocumentExpiry(context.Background(), now, 30*24*time.Hour)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Deactivated != 0 {
+		t.Errorf("Expected no further deactivations, got %d", result.Deactivated)
+	}
+}
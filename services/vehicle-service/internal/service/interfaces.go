@@ -2,8 +2,10 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/rideshare-platform/shared/models"
+	"github.com/rideshare-platform/shared/pagination"
 )
 
 // VehicleRepositoryInterface defines the interface for vehicle repository operations
@@ -14,12 +16,24 @@ type VehicleRepositoryInterface interface {
 	Update(ctx context.Context, vehicle *models.Vehicle) error
 	Delete(ctx context.Context, vehicleID string) error
 	LicensePlateExists(ctx context.Context, licensePlate string) (bool, error)
-	GetAvailableVehicles(ctx context.Context, vehicleType string, lat, lng float64, radius float64) ([]*models.Vehicle, error)
+	GetAvailableVehicles(ctx context.Context, driverID string) ([]*models.Vehicle, error)
+	GetAvailableByDriverIDs(ctx context.Context, driverIDs []string) ([]*models.Vehicle, error)
 
 	// Additional methods needed by the service
 	UpdateStatus(ctx context.Context, vehicleID string, status models.VehicleStatus) error
-	List(ctx context.Context, limit, offset int, filters map[string]interface{}) ([]*models.Vehicle, error)
+	// List returns the vehicles matching the filters on the requested page,
+	// reading page.FetchLimit() of them
+	List(ctx context.Context, page pagination.Request, filters map[string]interface{}) ([]*models.Vehicle, error)
 	Count(ctx context.Context, filters map[string]interface{}) (int64, error)
 	GetVehiclesWithExpiredInsurance(ctx context.Context) ([]*models.Vehicle, error)
 	GetVehiclesWithExpiredRegistration(ctx context.Context) ([]*models.Vehicle, error)
+	GetVehiclesWithDocumentsExpiringBefore(ctx context.Context, cutoff time.Time) ([]*models.Vehicle, error)
+}
+
+// VehicleFeatureRepositoryInterface defines the storage of vehicle features
+type VehicleFeatureRepositoryInterface interface {
+	GetFeatures(ctx context.Context, vehicleIDs []string) (map[string][]models.VehicleFeature, error)
+	SetFeatures(ctx context.Context, vehicleID string, features []models.VehicleFeature) error
+	AddFeature(ctx context.Context, vehicleID string, feature models.VehicleFeature) error
+	RemoveFeature(ctx context.Context, vehicleID string, feature models.VehicleFeature) error
 }
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rideshare-platform/shared/uploads"
+)
+
+// VehiclePhotoDocumentType is the upload type of the photo riders see of the
+// vehicle picking them up
+const VehiclePhotoDocumentType = "photo"
+
+// VehicleDocumentTypes are the documents drivers upload for their vehicles
+func VehicleDocumentTypes() []uploads.DocumentType {
+	return []uploads.DocumentType{
+		{Name: string(VehicleDocumentInsurance), Owner: uploads.OwnerVehicle, RequiresExpiry: true, RequiresNumber: true},
+		{Name: string(VehicleDocumentRegistration), Owner: uploads.OwnerVehicle, RequiresExpiry: true},
+		{Name: VehiclePhotoDocumentType, Owner: uploads.OwnerVehicle, Photo: true},
+	}
+}
+
+// ApplyDocument records an accepted insurance certificate or registration on
+// its vehicle, so the document expiry job and the expired document queries
+// follow the uploaded expiry date, and makes an accepted photo the vehicle's
+// photo. It is registered as an uploads hook.
+func (s *VehicleService) ApplyDocument(ctx context.Context, doc *uploads.Document) error {
+	if doc.OwnerType != uploads.OwnerVehicle {
+		return nil
+	}
+
+	req := &UpdateVehicleRequest{ID: doc.OwnerID}
+	switch {
+	case doc.DocumentType == VehiclePhotoDocumentType:
+		req.PhotoKey = doc.Key
+	case doc.ExpiresAt == nil:
+		return nil
+	case VehicleDocument(doc.DocumentType) == VehicleDocumentInsurance:
+		req.InsurancePolicyNumber = doc.DocumentNumber
+		req.InsuranceExpiry = doc.ExpiresAt
+	case VehicleDocument(doc.DocumentType) == VehicleDocumentRegistration:
+		req.RegistrationExpiry = doc.ExpiresAt
+	default:
+		return nil
+	}
+
+	if _, err := s.UpdateVehicle(ctx, req); err != nil {
+		return fmt.Errorf("failed to apply %s document to vehicle %s: %w", doc.DocumentType, doc.OwnerID, err)
+	}
+	return nil
+}
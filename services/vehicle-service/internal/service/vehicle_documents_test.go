@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+	"github.com/rideshare-platform/shared/uploads"
+)
+
+// fakeObjectStore pretends every signed upload was made
+type fakeObjectStore struct {
+	uploaded map[string]int64
+	deleted  []string
+}
+
+func (f *fakeObjectStore) PresignPut(key, contentType string, ttl time.Duration) (*uploads.PresignedURL, error) {
+	return &uploads.PresignedURL{Method: "PUT", URL: f.URL(key) + "?signed", ExpiresAt: time.Now().Add(ttl)}, nil
+}
+
+func (f *fakeObjectStore) PresignGet(key string, ttl time.Duration) (*uploads.PresignedURL, error) {
+	return &uploads.PresignedURL{Method: "GET", URL: f.URL(key) + "?signed", ExpiresAt: time.Now().Add(ttl)}, nil
+}
+
+func (f *fakeObjectStore) URL(key string) string {
+	return "https://bucket.test/" + key
+}
+
+func (f *fakeObjectStore) Stat(ctx context.Context, key string) (*uploads.ObjectInfo, error) {
+	size, exists := f.uploaded[key]
+	if !exists {
+		return nil, uploads.ErrObjectNotFound
+	}
+	return &uploads.ObjectInfo{SizeBytes: size}, nil
+}
+
+func (f *fakeObjectStore) Delete(ctx context.Context, key string) error {
+	f.deleted = append(f.deleted, key)
+	return nil
+}
+
+func TestVehicleService_ApplyDocument(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockVehicleRepository()
+	service := NewVehicleService(repo, nil, nil, nil)
+	if err := repo.Create(ctx, &models.Vehicle{ID: "vehicle-1", DriverID: "driver-1", Status: models.VehicleStatusActive}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	objects := &fakeObjectStore{uploaded: make(map[string]int64)}
+	uploader := uploads.NewUploader(uploads.NewMemoryStore(), objects, logger.NewLogger("error", "test"))
+	uploader.RegisterTypes(VehicleDocumentTypes()...)
+	uploader.OnAccepted(service.ApplyDocument)
+
+	expiry := time.Now().Add(20 * 24 * time.Hour).UTC().Truncate(time.Second)
+	_, err := uploader.RequestUpload(ctx, &uploads.UploadRequest{
+		OwnerType: uploads.OwnerVehicle, OwnerID: "vehicle-1", DocumentType: "insurance",
+		ContentType: "application/pdf", ExpiresAt: &expiry,
+	})
+	if !errors.Is(err, uploads.ErrInvalidUpload) {
+		t.Errorf("Expected insurance without a policy number to be invalid, got %v", err)
+	}
+
+	upload, err := uploader.RequestUpload(ctx, &uploads.UploadRequest{
+		OwnerType: uploads.OwnerVehicle, OwnerID: "vehicle-1", DocumentType: "insurance",
+		DocumentNumber: "POL-123", ContentType: "application/pdf", ExpiresAt: &expiry,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := uploader.CompleteUpload(ctx, upload.Document.ID); !errors.Is(err, uploads.ErrNotUploaded) {
+		t.Errorf("Expected completing before the file is uploaded to fail, got %v", err)
+	}
+
+	// The file is uploaded and passes its scan
+	objects.uploaded[upload.Document.Key] = 2048
+	uploader.SetScanner(uploads.ScannerFunc(func(ctx context.Context, doc *uploads.Document, downloadURL string) (uploads.Verdict, error) {
+		return uploads.Verdict{Clean: true}, nil
+	}))
+	doc, err := uploader.CompleteUpload(ctx, upload.Document.ID)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if doc.Status != uploads.StatusAccepted || doc.ScannedAt == nil {
+		t.Errorf("Expected a scanned, accepted document, got %+v", doc)
+	}
+
+	vehicle := repo.vehicles["vehicle-1"]
+	if vehicle.InsurancePolicyNumber != "POL-123" || vehicle.InsuranceExpiry == nil || !vehicle.InsuranceExpiry.Equal(expiry) {
+		t.Errorf("Expected the vehicle to carry the uploaded insurance, got %q expiring %v", vehicle.InsurancePolicyNumber, vehicle.InsuranceExpiry)
+	}
+
+	// The uploaded expiry feeds the document expiry check
+	notifier := &recordingNotifier{}
+	service.SetDriverNotifier(notifier)
+	result, err := service.CheckDocumentExpiry(ctx, time.Now(), 30*24*time.Hour)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Expiring != 1 || len(notifier.notices) != 1 || notifier.notices[0].Document != VehicleDocumentInsurance {
+		t.Errorf("Expected one expiring insurance notice, got %+v and %d notices", result, len(notifier.notices))
+	}
+
+	// An infected registration is rejected and leaves the vehicle alone
+	upload, err = uploader.RequestUpload(ctx, &uploads.UploadRequest{
+		OwnerType: uploads.OwnerVehicle, OwnerID: "vehicle-1", DocumentType: "registration",
+		ContentType: "image/png", ExpiresAt: &expiry,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	objects.uploaded[upload.Document.Key] = 1024
+	uploader.SetScanner(uploads.ScannerFunc(func(ctx context.Context, doc *uploads.Document, downloadURL string) (uploads.Verdict, error) {
+		return uploads.Verdict{Reason: "Eicar-Test-Signature"}, nil
+	}))
+	if _, err := uploader.CompleteUpload(ctx, upload.Document.ID); !errors.Is(err, uploads.ErrDocumentRejected) {
+		t.Errorf("Expected the infected document to be rejected, got %v", err)
+	}
+	if len(objects.deleted) != 1 || objects.deleted[0] != upload.Document.Key {
+		t.Errorf("Expected the infected file to be deleted, got %v", objects.deleted)
+	}
+	if vehicle.RegistrationExpiry != nil {
+		t.Errorf("Expected a rejected registration not to be applied, got %v", vehicle.RegistrationExpiry)
+	}
+}
+
+func TestVehicleService_ApplyPhoto(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockVehicleRepository()
+	service := NewVehicleService(repo, nil, nil, nil)
+	if err := repo.Create(ctx, &models.Vehicle{ID: "vehicle-1", DriverID: "driver-1", Status: models.VehicleStatusActive}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	objects := &fakeObjectStore{uploaded: make(map[string]int64)}
+	uploader := uploads.NewUploader(uploads.NewMemoryStore(), objects, logger.NewLogger("error", "test"))
+	uploader.RegisterTypes(VehicleDocumentTypes()...)
+	uploader.OnAccepted(service.ApplyDocument)
+	service.SetMedia(uploader.Media())
+
+	_, err := uploader.RequestUpload(ctx, &uploads.UploadRequest{
+		OwnerType: uploads.OwnerVehicle, OwnerID: "vehicle-1", DocumentType: VehiclePhotoDocumentType,
+		ContentType: "application/pdf",
+	})
+	if !errors.Is(err, uploads.ErrInvalidUpload) {
+		t.Errorf("Expected a PDF photo to be invalid, got %v", err)
+	}
+
+	upload, err := uploader.RequestUpload(ctx, &uploads.UploadRequest{
+		OwnerType: uploads.OwnerVehicle, OwnerID: "vehicle-1", DocumentType: VehiclePhotoDocumentType,
+		ContentType: "image/jpeg",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	objects.uploaded[upload.Document.Key] = 4096
+	if _, err := uploader.CompleteUpload(ctx, upload.Document.ID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	vehicle, err := service.GetVehicle(ctx, "vehicle-1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if vehicle.PhotoKey != upload.Document.Key {
+		t.Errorf("Expected the photo to be recorded on the vehicle, got %q", vehicle.PhotoKey)
+	}
+	// Without a media CDN every size is the original through a signed URL
+	if len(vehicle.PhotoURLs) != len(uploads.Variants) || vehicle.PhotoURLs["medium"] != objects.URL(upload.Document.Key)+"?signed" {
+		t.Errorf("Expected signed URLs for every size, got %v", vehicle.PhotoURLs)
+	}
+
+	urls, err := uploader.PhotoURLs(ctx, upload.Document.ID)
+	if err != nil || urls["thumbnail"] == "" {
+		t.Errorf("Expected the photo's URLs, got %v, %v", urls, err)
+	}
+}
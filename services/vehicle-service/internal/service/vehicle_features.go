@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/rideshare-platform/shared/models"
+)
+
+// ErrFeaturesUnavailable is returned when no feature repository is attached
+var ErrFeaturesUnavailable = errors.New("vehicle features are not available")
+
+// SetFeatureRepository attaches the repository holding vehicle features.
+// Without one vehicles are returned without features.
+func (s *VehicleService) SetFeatureRepository(repo VehicleFeatureRepositoryInterface) {
+	s.features = repo
+}
+
+// GetVehicleFeatures returns the features a vehicle offers
+func (s *VehicleService) GetVehicleFeatures(ctx context.Context, vehicleID string) ([]models.VehicleFeature, error) {
+	if s.features == nil {
+		return nil, ErrFeaturesUnavailable
+	}
+	vehicle, err := s.GetVehicle(ctx, vehicleID)
+	if err != nil {
+		return nil, err
+	}
+	features, err := s.features.GetFeatures(ctx, []string{vehicle.ID})
+	if err != nil {
+		return nil, err
+	}
+	return features[vehicle.ID], nil
+}
+
+// SetVehicleFeatures replaces the features a vehicle offers
+func (s *VehicleService) SetVehicleFeatures(ctx context.Context, vehicleID string, features []string) ([]models.VehicleFeature, error) {
+	if s.features == nil {
+		return nil, ErrFeaturesUnavailable
+	}
+	parsed, err := parseVehicleFeatures(features)
+	if err != nil {
+		return nil, err
+	}
+	vehicle, err := s.GetVehicle(ctx, vehicleID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.features.SetFeatures(ctx, vehicle.ID, parsed); err != nil {
+		return nil, err
+	}
+	s.invalidateVehicleCaches(ctx, vehicle)
+	return parsed, nil
+}
+
+// AddVehicleFeature adds a feature to a vehicle
+func (s *VehicleService) AddVehicleFeature(ctx context.Context, vehicleID, feature string) error {
+	if s.features == nil {
+		return ErrFeaturesUnavailable
+	}
+	return s.changeVehicleFeature(ctx, vehicleID, feature, s.features.AddFeature)
+}
+
+// RemoveVehicleFeature removes a feature from a vehicle
+func (s *VehicleService) RemoveVehicleFeature(ctx context.Context, vehicleID, feature string) error {
+	if s.features == nil {
+		return ErrFeaturesUnavailable
+	}
+	return s.changeVehicleFeature(ctx, vehicleID, feature, s.features.RemoveFeature)
+}
+
+func (s *VehicleService) changeVehicleFeature(
+	ctx context.Context,
+	vehicleID, feature string,
+	change func(context.Context, string, models.VehicleFeature) error,
+) error {
+	if !models.IsValidVehicleFeature(feature) {
+		return fmt.Errorf("%w: unknown vehicle feature %q", ErrInvalidVehicleRequest, feature)
+	}
+	vehicle, err := s.GetVehicle(ctx, vehicleID)
+	if err != nil {
+		return err
+	}
+	if err := change(ctx, vehicle.ID, models.VehicleFeature(feature)); err != nil {
+		return err
+	}
+	s.invalidateVehicleCaches(ctx, vehicle)
+	return nil
+}
+
+// parseVehicleFeatures validates features and drops repeats, keeping them in
+// a stable order
+func parseVehicleFeatures(features []string) ([]models.VehicleFeature, error) {
+	seen := make(map[string]bool, len(features))
+	parsed := make([]models.VehicleFeature, 0, len(features))
+	for _, feature := range features {
+		if !models.IsValidVehicleFeature(feature) {
+			return nil, fmt.Errorf("%w: unknown vehicle feature %q", ErrInvalidVehicleRequest, feature)
+		}
+		if seen[feature] {
+			continue
+		}
+		seen[feature] = true
+		parsed = append(parsed, models.VehicleFeature(feature))
+	}
+	sort.Slice(parsed, func(i, j int) bool { return parsed[i] < parsed[j] })
+	return parsed, nil
+}
+
+// attachFeatures fills in the features of vehicles. Lookup failures are
+// logged and leave the features out, so vehicles stay readable.
+func (s *VehicleService) attachFeatures(ctx context.Context, vehicles ...*models.Vehicle) {
+	if s.features == nil || len(vehicles) == 0 {
+		return
+	}
+	ids := make([]string, 0, len(vehicles))
+	for _, vehicle := range vehicles {
+		if vehicle != nil {
+			ids = append(ids, vehicle.ID)
+		}
+	}
+	features, err := s.features.GetFeatures(ctx, ids)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.WithContext(ctx).WithError(err).Warn("Failed to get vehicle features")
+		}
+		return
+	}
+	for _, vehicle := range vehicles {
+		if vehicle != nil {
+			vehicle.Features = features[vehicle.ID]
+		}
+	}
+}
+
+// invalidateVehicleCaches drops the cached copies of a vehicle after its
+// features change
+func (s *VehicleService) invalidateVehicleCaches(ctx context.Context, vehicle *models.Vehicle) {
+	if s.cacheRepo == nil {
+		return
+	}
+	if err := s.cacheRepo.InvalidateVehicle(ctx, vehicle.ID); err != nil && s.logger != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("Failed to invalidate vehicle cache")
+	}
+	if err := s.cacheRepo.InvalidateDriverVehicles(ctx, vehicle.DriverID); err != nil && s.logger != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("Failed to invalidate driver vehicles cache")
+	}
+	if err := s.cacheRepo.InvalidateAvailableVehicles(ctx, vehicle.DriverID); err != nil && s.logger != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("Failed to invalidate available vehicles cache")
+	}
+}
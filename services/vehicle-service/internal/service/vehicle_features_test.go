@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rideshare-platform/shared/models"
+)
+
+type fakeFeatureRepository struct {
+	features map[string][]models.VehicleFeature
+}
+
+func (r *fakeFeatureRepository) GetFeatures(ctx context.Context, vehicleIDs []string) (map[string][]models.VehicleFeature, error) {
+	found := make(map[string][]models.VehicleFeature)
+	for _, id := range vehicleIDs {
+		if features, ok := r.features[id]; ok {
+			found[id] = features
+		}
+	}
+	return found, nil
+}
+
+func (r *fakeFeatureRepository) SetFeatures(ctx context.Context, vehicleID string, features []models.VehicleFeature) error {
+	r.features[vehicleID] = features
+	return nil
+}
+
+func (r *fakeFeatureRepository) AddFeature(ctx context.Context, vehicleID string, feature models.VehicleFeature) error {
+	for _, f := range r.features[vehicleID] {
+		if f == feature {
+			return nil
+		}
+	}
+	r.features[vehicleID] = append(r.features[vehicleID], feature)
+	return nil
+}
+
+func (r *fakeFeatureRepository) RemoveFeature(ctx context.Context, vehicleID string, feature models.VehicleFeature) error {
+	kept := r.features[vehicleID][:0]
+	for _, f := range r.features[vehicleID] {
+		if f != feature {
+			kept = append(kept, f)
+		}
+	}
+	r.features[vehicleID] = kept
+	return nil
+}
+
+func TestVehicleService_Features(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockVehicleRepository()
+	service := NewVehicleService(repo, nil, nil, nil)
+	if err := repo.Create(ctx, &models.Vehicle{ID: "vehicle-1", DriverID: "driver-1", Status: models.VehicleStatusActive}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := service.GetVehicleFeatures(ctx, "vehicle-1"); !errors.Is(err, ErrFeaturesUnavailable) {
+		t.Errorf("Expected features to be unavailable without a repository, got %v", err)
+	}
+
+	service.SetFeatureRepository(&fakeFeatureRepository{features: map[string][]models.VehicleFeature{}})
+
+	features, err := service.SetVehicleFeatures(ctx, "vehicle-1", []string{"wifi", "child_seat", "wifi"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(features) != 2 || features[0] != models.VehicleFeatureChildSeat || features[1] != models.VehicleFeatureWifi {
+		t.Errorf("Expected child_seat and wifi, got %v", features)
+	}
+	if _, err := service.SetVehicleFeatures(ctx, "vehicle-1", []string{"jacuzzi"}); !errors.Is(err, ErrInvalidVehicleRequest) {
+		t.Errorf("Expected an unknown feature to be invalid, got %v", err)
+	}
+
+	if err := service.AddVehicleFeature(ctx, "vehicle-1", "wheelchair_accessible"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := service.RemoveVehicleFeature(ctx, "vehicle-1", "wifi"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := service.AddVehicleFeature(ctx, "missing", "wifi"); err == nil {
+		t.Error("Expected adding a feature to a missing vehicle to fail")
+	}
+
+	vehicle, err := service.GetVehicle(ctx, "vehicle-1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !vehicle.HasFeature(models.VehicleFeatureWheelchairAccessible) || vehicle.HasFeature(models.VehicleFeatureWifi) {
+		t.Errorf("Expected the vehicle to carry its features, got %v", vehicle.Features)
+	}
+
+	available, err := service.GetAvailableVehiclesByDrivers(ctx, []string{"driver-1"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(available) != 1 || len(available[0].Features) != 2 {
+		t.Errorf("Expected available vehicles to carry their features, got %+v", available)
+	}
+}
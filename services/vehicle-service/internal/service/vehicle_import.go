@@ -0,0 +1,420 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// MaxImportRows is the most vehicles one import may carry
+const MaxImportRows = 5000
+
+// ImportFormat is the encoding of a vehicle import batch
+type ImportFormat string
+
+const (
+	ImportFormatCSV  ImportFormat = "csv"
+	ImportFormatJSON ImportFormat = "json"
+)
+
+// ImportJobStatus is where an import job is in its processing
+type ImportJobStatus string
+
+const (
+	ImportJobQueued    ImportJobStatus = "queued"
+	ImportJobRunning   ImportJobStatus = "running"
+	ImportJobCompleted ImportJobStatus = "completed"
+)
+
+var (
+	// ErrInvalidImport is returned for batches that cannot be read at all
+	ErrInvalidImport = errors.New("invalid vehicle import")
+	// ErrImportJobNotFound is returned when an import job does not exist
+	ErrImportJobNotFound = errors.New("import job not found")
+	// ErrImportQueueFull is returned when too many imports are waiting
+	ErrImportQueueFull = errors.New("too many vehicle imports are queued")
+)
+
+// ImportRow is one vehicle of an import batch. Row numbers start at 1 for
+// the first vehicle, after any CSV header.
+type ImportRow struct {
+	Row     int
+	Vehicle *CreateVehicleRequest
+}
+
+// ImportRowError explains why a row of an import was not registered
+type ImportRowError struct {
+	Row          int    `json:"row"`
+	LicensePlate string `json:"license_plate,omitempty"`
+	Error        string `json:"error"`
+}
+
+// ImportJob tracks an asynchronous vehicle import
+type ImportJob struct {
+	ID          string           `json:"id"`
+	Status      ImportJobStatus  `json:"status"`
+	SubmittedBy string           `json:"submitted_by,omitempty"`
+	TotalRows   int              `json:"total_rows"`
+	Processed   int              `json:"processed"`
+	Imported    int              `json:"imported"`
+	Failed      int              `json:"failed"`
+	VehicleIDs  []string         `json:"vehicle_ids"`
+	Errors      []ImportRowError `json:"errors"`
+	CreatedAt   time.Time        `json:"created_at"`
+	StartedAt   *time.Time       `json:"started_at,omitempty"`
+	CompletedAt *time.Time       `json:"completed_at,omitempty"`
+
+	rows []ImportRow
+}
+
+// ParseVehicleImport reads an import batch. CSV batches start with a header
+// naming their columns after the JSON fields of CreateVehicleRequest, with
+// dates as RFC3339 times or YYYY-MM-DD; JSON batches are an array of
+// vehicles or an object with a "vehicles" array. Rows whose values cannot
+// be read are returned as row errors rather than failing the batch.
+func ParseVehicleImport(format ImportFormat, r io.Reader) ([]ImportRow, []ImportRowError, error) {
+	switch format {
+	case ImportFormatCSV:
+		return parseCSVImport(r)
+	case ImportFormatJSON:
+		return parseJSONImport(r)
+	default:
+		return nil, nil, fmt.Errorf("%w: unsupported format %q", ErrInvalidImport, format)
+	}
+}
+
+func parseCSVImport(r io.Reader) ([]ImportRow, []ImportRowError, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil, fmt.Errorf("%w: the batch is empty", ErrInvalidImport)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"driver_id", "license_plate"} {
+		if _, ok := columns[required]; !ok {
+			return nil, nil, fmt.Errorf("%w: the header has no %s column", ErrInvalidImport, required)
+		}
+	}
+
+	var rows []ImportRow
+	var rowErrors []ImportRowError
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				rowErrors = append(rowErrors, ImportRowError{Row: row, Error: parseErr.Err.Error()})
+				continue
+			}
+			return nil, nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+		}
+		if row > MaxImportRows {
+			return nil, nil, fmt.Errorf("%w: at most %d vehicles can be imported at once", ErrInvalidImport, MaxImportRows)
+		}
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		vehicle, err := csvVehicle(field)
+		if err != nil {
+			rowErrors = append(rowErrors, ImportRowError{Row: row, LicensePlate: field("license_plate"), Error: err.Error()})
+			continue
+		}
+		rows = append(rows, ImportRow{Row: row, Vehicle: vehicle})
+	}
+	if len(rows) == 0 && len(rowErrors) == 0 {
+		return nil, nil, fmt.Errorf("%w: the batch has no vehicles", ErrInvalidImport)
+	}
+	return rows, rowErrors, nil
+}
+
+func csvVehicle(field func(string) string) (*CreateVehicleRequest, error) {
+	vehicle := &CreateVehicleRequest{
+		DriverID:              field("driver_id"),
+		Make:                  field("make"),
+		Model:                 field("model"),
+		Color:                 field("color"),
+		LicensePlate:          field("license_plate"),
+		VehicleType:           field("vehicle_type"),
+		InsurancePolicyNumber: field("insurance_policy_number"),
+	}
+
+	for _, number := range []struct {
+		name   string
+		target *int
+	}{
+		{"year", &vehicle.Year},
+		{"capacity", &vehicle.Capacity},
+	} {
+		if value := field(number.name); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s must be a whole number, got %q", number.name, value)
+			}
+			*number.target = parsed
+		}
+	}
+
+	for _, date := range []struct {
+		name   string
+		target **time.Time
+	}{
+		{"insurance_expiry", &vehicle.InsuranceExpiry},
+		{"registration_expiry", &vehicle.RegistrationExpiry},
+	} {
+		if value := field(date.name); value != "" {
+			parsed, err := parseImportDate(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s must be an RFC3339 time or YYYY-MM-DD date, got %q", date.name, value)
+			}
+			*date.target = &parsed
+		}
+	}
+	return vehicle, nil
+}
+
+func parseImportDate(value string) (time.Time, error) {
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+func parseJSONImport(r io.Reader) ([]ImportRow, []ImportRowError, error) {
+	var raw json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+
+	var items []json.RawMessage
+	if err := json.Unmarshal(raw, &items); err != nil {
+		var wrapped struct {
+			Vehicles []json.RawMessage `json:"vehicles"`
+		}
+		if err := json.Unmarshal(raw, &wrapped); err != nil || wrapped.Vehicles == nil {
+			return nil, nil, fmt.Errorf("%w: expected an array of vehicles or an object with a vehicles array", ErrInvalidImport)
+		}
+		items = wrapped.Vehicles
+	}
+	if len(items) == 0 {
+		return nil, nil, fmt.Errorf("%w: the batch is empty", ErrInvalidImport)
+	}
+	if len(items) > MaxImportRows {
+		return nil, nil, fmt.Errorf("%w: at most %d vehicles can be imported at once", ErrInvalidImport, MaxImportRows)
+	}
+
+	var rows []ImportRow
+	var rowErrors []ImportRowError
+	for i, item := range items {
+		var vehicle CreateVehicleRequest
+		if err := json.Unmarshal(item, &vehicle); err != nil {
+			rowErrors = append(rowErrors, ImportRowError{Row: i + 1, Error: err.Error()})
+			continue
+		}
+		rows = append(rows, ImportRow{Row: i + 1, Vehicle: &vehicle})
+	}
+	return rows, rowErrors, nil
+}
+
+// VehicleImporter registers vehicles from fleet partners' batches in the
+// background. Jobs are kept in memory, so a restart loses their status.
+type VehicleImporter struct {
+	vehicles *VehicleService
+	queue    chan *ImportJob
+	jobs     map[string]*ImportJob
+	mutex    sync.RWMutex
+	logger   *logger.Logger
+}
+
+// NewVehicleImporter creates an importer holding up to queueSize waiting jobs
+func NewVehicleImporter(vehicles *VehicleService, queueSize int, logger *logger.Logger) *VehicleImporter {
+	if queueSize <= 0 {
+		queueSize = 16
+	}
+	return &VehicleImporter{
+		vehicles: vehicles,
+		queue:    make(chan *ImportJob, queueSize),
+		jobs:     make(map[string]*ImportJob),
+		logger:   logger,
+	}
+}
+
+// Submit validates a parsed batch and queues its valid rows for import.
+// Rows that fail validation, including license plates repeated within the
+// batch, are recorded as errors on the job straight away.
+func (i *VehicleImporter) Submit(ctx context.Context, submittedBy string, rows []ImportRow, rowErrors []ImportRowError) (*ImportJob, error) {
+	now := time.Now()
+	job := &ImportJob{
+		ID:          fmt.Sprintf("import_%d", now.UnixNano()),
+		Status:      ImportJobQueued,
+		SubmittedBy: submittedBy,
+		TotalRows:   len(rows) + len(rowErrors),
+		VehicleIDs:  []string{},
+		Errors:      append([]ImportRowError{}, rowErrors...),
+		CreatedAt:   now,
+	}
+
+	firstRow := make(map[string]int)
+	for _, row := range rows {
+		if err := i.vehicles.validateCreateVehicleRequest(row.Vehicle); err != nil {
+			job.Errors = append(job.Errors, ImportRowError{Row: row.Row, LicensePlate: row.Vehicle.LicensePlate, Error: err.Error()})
+			continue
+		}
+		plate := normalizeLicensePlate(row.Vehicle.LicensePlate)
+		if first, seen := firstRow[plate]; seen {
+			job.Errors = append(job.Errors, ImportRowError{
+				Row:          row.Row,
+				LicensePlate: row.Vehicle.LicensePlate,
+				Error:        fmt.Sprintf("license plate is repeated from row %d", first),
+			})
+			continue
+		}
+		firstRow[plate] = row.Row
+		job.rows = append(job.rows, row)
+	}
+	job.Failed = len(job.Errors)
+	job.Processed = job.Failed
+	sortRowErrors(job.Errors)
+
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	select {
+	case i.queue <- job:
+	default:
+		return nil, ErrImportQueueFull
+	}
+	i.jobs[job.ID] = job
+
+	i.logger.WithContext(ctx).WithFields(logger.Fields{
+		"job_id":       job.ID,
+		"submitted_by": submittedBy,
+		"rows":         job.TotalRows,
+		"invalid_rows": job.Failed,
+	}).Info("Vehicle import queued")
+	return copyImportJob(job), nil
+}
+
+// Get returns an import job's progress
+func (i *VehicleImporter) Get(ctx context.Context, id string) (*ImportJob, error) {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	job, exists := i.jobs[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrImportJobNotFound, id)
+	}
+	return copyImportJob(job), nil
+}
+
+// Start imports queued jobs one at a time until ctx is cancelled
+func (i *VehicleImporter) Start(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-i.queue:
+			i.run(ctx, job)
+		}
+	}
+}
+
+// run registers each of a job's valid rows through CreateVehicle, so
+// imported vehicles are cached and announced like any other
+func (i *VehicleImporter) run(ctx context.Context, job *ImportJob) {
+	started := time.Now()
+	i.mutex.Lock()
+	job.Status = ImportJobRunning
+	job.StartedAt = &started
+	i.mutex.Unlock()
+
+	for _, row := range job.rows {
+		if ctx.Err() != nil {
+			return
+		}
+		vehicle, err := i.vehicles.CreateVehicle(ctx, row.Vehicle)
+
+		i.mutex.Lock()
+		job.Processed++
+		if err != nil {
+			job.Failed++
+			job.Errors = append(job.Errors, ImportRowError{Row: row.Row, LicensePlate: row.Vehicle.LicensePlate, Error: err.Error()})
+		} else {
+			job.Imported++
+			job.VehicleIDs = append(job.VehicleIDs, vehicle.ID)
+		}
+		i.mutex.Unlock()
+	}
+
+	completed := time.Now()
+	i.mutex.Lock()
+	job.Status = ImportJobCompleted
+	job.CompletedAt = &completed
+	job.rows = nil
+	sortRowErrors(job.Errors)
+	i.mutex.Unlock()
+
+	i.logger.WithContext(ctx).WithFields(logger.Fields{
+		"job_id":   job.ID,
+		"imported": job.Imported,
+		"failed":   job.Failed,
+		"duration": completed.Sub(started).String(),
+	}).Info("Vehicle import completed")
+}
+
+// WriteImportErrorReport writes a job's row errors as CSV
+func WriteImportErrorReport(w io.Writer, job *ImportJob) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"row", "license_plate", "error"}); err != nil {
+		return err
+	}
+	for _, rowError := range job.Errors {
+		if err := writer.Write([]string{strconv.Itoa(rowError.Row), rowError.LicensePlate, rowError.Error}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func normalizeLicensePlate(plate string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(plate), ""))
+}
+
+func sortRowErrors(rowErrors []ImportRowError) {
+	sort.SliceStable(rowErrors, func(a, b int) bool {
+		return rowErrors[a].Row < rowErrors[b].Row
+	})
+}
+
+func copyImportJob(job *ImportJob) *ImportJob {
+	copied := *job
+	copied.VehicleIDs = append([]string{}, job.VehicleIDs...)
+	copied.Errors = append([]ImportRowError{}, job.Errors...)
+	copied.rows = nil
+	return &copied
+}
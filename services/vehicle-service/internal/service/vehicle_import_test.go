@@ -0,0 +1,120 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/models"
+)
+
+func TestVehicleImporter_CSV(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockVehicleRepository()
+	vehicles := NewVehicleService(repo, nil, nil, logger.NewLogger("error", "test"))
+	if err := repo.Create(ctx, &models.Vehicle{ID: "existing", DriverID: "driver-0", LicensePlate: "34ABC001"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	batch := `driver_id,make,model,year,color,license_plate,vehicle_type,capacity,registration_expiry
+driver-1,Toyota,Corolla,2021,white,34XYZ100,sedan,4,2030-01-31
+driver-2,Honda,Civic,not-a-year,black,34XYZ200,sedan,4,
+driver-3,Ford,Focus,2020,blue,34 xyz 100,sedan,4,
+driver-4,Tesla,Model 3,2023,red,34ABC001,sedan,4,
+driver-5,,Golf,2019,grey,34XYZ500,sedan,4,
+driver-6,Kia,Ceed,2022,green,34XYZ600,sedan,4,
+`
+	rows, rowErrors, err := ParseVehicleImport(ImportFormatCSV, strings.NewReader(batch))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(rows) != 5 || len(rowErrors) != 1 || rowErrors[0].Row != 2 {
+		t.Fatalf("Expected 5 rows and a year error on row 2, got %d rows and %+v", len(rows), rowErrors)
+	}
+	if rows[0].Vehicle.RegistrationExpiry == nil || rows[0].Vehicle.Year != 2021 {
+		t.Errorf("Expected the first row's year and registration expiry to be read, got %+v", rows[0].Vehicle)
+	}
+
+	importer := NewVehicleImporter(vehicles, 1, logger.NewLogger("error", "test"))
+	job, err := importer.Submit(ctx, "fleet-1", rows, rowErrors)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if job.Status != ImportJobQueued || job.TotalRows != 6 || job.Failed != 3 {
+		t.Errorf("Expected a queued job of 6 rows with 3 failing validation, got %+v", job)
+	}
+	if _, err := importer.Submit(ctx, "fleet-1", rows, nil); !errors.Is(err, ErrImportQueueFull) {
+		t.Errorf("Expected a full queue to refuse the import, got %v", err)
+	}
+
+	workerCtx, stop := context.WithCancel(ctx)
+	defer stop()
+	go importer.Start(workerCtx)
+	deadline := time.Now().Add(2 * time.Second)
+	for job.Status != ImportJobCompleted {
+		if time.Now().After(deadline) {
+			t.Fatalf("Import did not complete, last status %+v", job)
+		}
+		time.Sleep(5 * time.Millisecond)
+		if job, err = importer.Get(ctx, job.ID); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if job.Imported != 2 || job.Failed != 4 || job.Processed != 6 || len(job.VehicleIDs) != 2 {
+		t.Errorf("Expected 2 imported and 4 failed rows, got %+v", job)
+	}
+	wantErrors := map[int]string{
+		2: "year must be a whole number",
+		3: "license plate is repeated from row 1",
+		4: "license plate already exists",
+		5: "make is required",
+	}
+	if len(job.Errors) != len(wantErrors) {
+		t.Fatalf("Expected %d row errors, got %+v", len(wantErrors), job.Errors)
+	}
+	for i, rowError := range job.Errors {
+		if i > 0 && job.Errors[i-1].Row > rowError.Row {
+			t.Errorf("Expected row errors in row order, got %+v", job.Errors)
+		}
+		if want := wantErrors[rowError.Row]; want == "" || !strings.Contains(rowError.Error, want) {
+			t.Errorf("Row %d: expected an error containing %q, got %q", rowError.Row, want, rowError.Error)
+		}
+	}
+
+	var report bytes.Buffer
+	if err := WriteImportErrorReport(&report, job); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(report.String()), "\n")
+	if len(lines) != 5 || lines[0] != "row,license_plate,error" || !strings.HasPrefix(lines[2], "3,34 xyz 100,") {
+		t.Errorf("Unexpected error report:\n%s", report.String())
+	}
+}
+
+func TestParseVehicleImport_JSON(t *testing.T) {
+	rows, rowErrors, err := ParseVehicleImport(ImportFormatJSON, strings.NewReader(`{"vehicles": [
+		{"driver_id": "driver-1", "make": "Toyota", "model": "Corolla", "year": 2021, "license_plate": "34XYZ100", "vehicle_type": "sedan", "capacity": 4},
+		{"driver_id": "driver-2", "year": "2020"}
+	]}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(rows) != 1 || rows[0].Vehicle.LicensePlate != "34XYZ100" {
+		t.Errorf("Expected the first vehicle to be read, got %+v", rows)
+	}
+	if len(rowErrors) != 1 || rowErrors[0].Row != 2 {
+		t.Errorf("Expected a type error on row 2, got %+v", rowErrors)
+	}
+
+	if _, _, err := ParseVehicleImport(ImportFormatJSON, strings.NewReader(`[]`)); !errors.Is(err, ErrInvalidImport) {
+		t.Errorf("Expected an empty batch to be invalid, got %v", err)
+	}
+	if _, _, err := ParseVehicleImport("xml", strings.NewReader(`<vehicles/>`)); !errors.Is(err, ErrInvalidImport) {
+		t.Errorf("Expected an unknown format to be invalid, got %v", err)
+	}
+}
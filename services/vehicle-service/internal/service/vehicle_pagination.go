@@ -0,0 +1,30 @@
+package service
+
+import (
+	"github.com/rideshare-platform/shared/models"
+	"github.com/rideshare-platform/shared/pagination"
+)
+
+var (
+	vehicleSortCreatedAt = pagination.Field{Name: "created_at", Kind: pagination.KindTime}
+	vehicleSortUpdatedAt = pagination.Field{Name: "updated_at", Kind: pagination.KindTime}
+	vehicleSortYear      = pagination.Field{Name: "year", Kind: pagination.KindNumber}
+)
+
+// VehicleListOptions are the sorts vehicle lists offer, newest first by default
+var VehicleListOptions = pagination.Options{
+	Fields:  []pagination.Field{vehicleSortCreatedAt, vehicleSortUpdatedAt, vehicleSortYear},
+	Default: pagination.Sort{Field: vehicleSortCreatedAt, Descending: true},
+}
+
+// VehicleSortKey returns the position of vehicles in a list sorted by sort
+func VehicleSortKey(sort pagination.Sort) func(*models.Vehicle) pagination.Key {
+	switch sort.Field.Name {
+	case vehicleSortUpdatedAt.Name:
+		return func(v *models.Vehicle) pagination.Key { return pagination.TimeKey(v.UpdatedAt, v.ID) }
+	case vehicleSortYear.Name:
+		return func(v *models.Vehicle) pagination.Key { return pagination.NumberKey(float64(v.Year), v.ID) }
+	default:
+		return func(v *models.Vehicle) pagination.Key { return pagination.TimeKey(v.CreatedAt, v.ID) }
+	}
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+
+	"github.com/rideshare-platform/shared/models"
+	"github.com/rideshare-platform/shared/uploads"
+)
+
+// SetMedia sets the signer vehicle photos are served through. Without one
+// vehicles are returned without photo URLs.
+func (s *VehicleService) SetMedia(media *uploads.Media) {
+	s.media = media
+}
+
+// attachPhotos fills in the signed URLs of vehicles' photos. Signing failures
+// are logged and leave the URLs out, so vehicles stay readable.
+func (s *VehicleService) attachPhotos(ctx context.Context, vehicles ...*models.Vehicle) {
+	if s.media == nil {
+		return
+	}
+	for _, vehicle := range vehicles {
+		if vehicle == nil || vehicle.PhotoKey == "" {
+			continue
+		}
+		urls, err := s.media.URLs(vehicle.PhotoKey)
+		if err != nil {
+			if s.logger != nil {
+				s.logger.WithContext(ctx).WithError(err).Warn("Failed to sign vehicle photo URLs")
+			}
+			continue
+		}
+		vehicle.PhotoURLs = urls
+	}
+}
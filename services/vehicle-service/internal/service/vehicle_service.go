@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -10,6 +11,15 @@ import (
 	"github.com/rideshare-platform/shared/events"
 	"github.com/rideshare-platform/shared/logger"
 	"github.com/rideshare-platform/shared/models"
+	"github.com/rideshare-platform/shared/pagination"
+	"github.com/rideshare-platform/shared/uploads"
+)
+
+var (
+	// ErrInvalidVehicleRequest is returned when a request fails validation
+	ErrInvalidVehicleRequest = errors.New("invalid request")
+	// ErrLicensePlateExists is returned when a license plate is already registered
+	ErrLicensePlateExists = errors.New("license plate already exists")
 )
 
 // VehicleService handles vehicle business logic
@@ -17,6 +27,9 @@ type VehicleService struct {
 	vehicleRepo    VehicleRepositoryInterface
 	cacheRepo      *repository.CacheRepository
 	eventPublisher *events.EventPublisher
+	driverNotifier DriverNotifier
+	features       VehicleFeatureRepositoryInterface
+	media          *uploads.Media
 	logger         *logger.Logger
 }
 
@@ -39,7 +52,7 @@ func NewVehicleService(
 func (s *VehicleService) CreateVehicle(ctx context.Context, req *CreateVehicleRequest) (*models.Vehicle, error) {
 	// Validate request
 	if err := s.validateCreateVehicleRequest(req); err != nil {
-		return nil, fmt.Errorf("invalid request: %w", err)
+		return nil, fmt.Errorf("%w: %v", ErrInvalidVehicleRequest, err)
 	}
 
 	// Check if license plate already exists
@@ -50,7 +63,7 @@ func (s *VehicleService) CreateVehicle(ctx context.Context, req *CreateVehicleRe
 	}
 
 	if exists {
-		return nil, fmt.Errorf("license plate already exists: %s", req.LicensePlate)
+		return nil, fmt.Errorf("%w: %s", ErrLicensePlateExists, req.LicensePlate)
 	}
 
 	// Create vehicle
@@ -147,6 +160,8 @@ func (s *VehicleService) GetVehicle(ctx context.Context, id string) (*models.Veh
 		}
 
 		if vehicle != nil {
+			s.attachFeatures(ctx, vehicle)
+			s.attachPhotos(ctx, vehicle)
 			return vehicle, nil
 		}
 	}
@@ -164,6 +179,8 @@ func (s *VehicleService) GetVehicle(ctx context.Context, id string) (*models.Veh
 		}
 	}
 
+	s.attachFeatures(ctx, vehicle)
+	s.attachPhotos(ctx, vehicle)
 	return vehicle, nil
 }
 
@@ -183,6 +200,8 @@ func (s *VehicleService) GetVehiclesByDriver(ctx context.Context, driverID strin
 		}
 
 		if vehicles != nil {
+			s.attachFeatures(ctx, vehicles...)
+			s.attachPhotos(ctx, vehicles...)
 			return vehicles, nil
 		}
 	}
@@ -200,6 +219,8 @@ func (s *VehicleService) GetVehiclesByDriver(ctx context.Context, driverID strin
 		}
 	}
 
+	s.attachFeatures(ctx, vehicles...)
+	s.attachPhotos(ctx, vehicles...)
 	return vehicles, nil
 }
 
@@ -219,6 +240,8 @@ func (s *VehicleService) GetAvailableVehicles(ctx context.Context, driverID stri
 		}
 
 		if vehicles != nil {
+			s.attachFeatures(ctx, vehicles...)
+			s.attachPhotos(ctx, vehicles...)
 			return vehicles, nil
 		}
 	}
@@ -244,14 +267,46 @@ func (s *VehicleService) GetAvailableVehicles(ctx context.Context, driverID stri
 		}
 	}
 
+	s.attachFeatures(ctx, availableVehicles...)
+	s.attachPhotos(ctx, availableVehicles...)
 	return availableVehicles, nil
 }
 
+// maxDriverVehicleBatch is the most drivers one batched vehicle lookup may ask for
+const maxDriverVehicleBatch = 100
+
+// GetAvailableVehiclesByDrivers retrieves the available vehicles of several
+// drivers at once, newest first for each driver
+func (s *VehicleService) GetAvailableVehiclesByDrivers(ctx context.Context, driverIDs []string) ([]*models.Vehicle, error) {
+	seen := make(map[string]bool, len(driverIDs))
+	unique := make([]string, 0, len(driverIDs))
+	for _, driverID := range driverIDs {
+		if driverID != "" && !seen[driverID] {
+			seen[driverID] = true
+			unique = append(unique, driverID)
+		}
+	}
+	if len(unique) == 0 {
+		return nil, fmt.Errorf("%w: at least one driver ID is required", ErrInvalidVehicleRequest)
+	}
+	if len(unique) > maxDriverVehicleBatch {
+		return nil, fmt.Errorf("%w: at most %d drivers per lookup", ErrInvalidVehicleRequest, maxDriverVehicleBatch)
+	}
+
+	vehicles, err := s.vehicleRepo.GetAvailableByDriverIDs(ctx, unique)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get available vehicles: %w", err)
+	}
+	s.attachFeatures(ctx, vehicles...)
+	s.attachPhotos(ctx, vehicles...)
+	return vehicles, nil
+}
+
 // UpdateVehicle updates a vehicle
 func (s *VehicleService) UpdateVehicle(ctx context.Context, req *UpdateVehicleRequest) (*models.Vehicle, error) {
 	// Validate request
 	if err := s.validateUpdateVehicleRequest(req); err != nil {
-		return nil, fmt.Errorf("invalid request: %w", err)
+		return nil, fmt.Errorf("%w: %v", ErrInvalidVehicleRequest, err)
 	}
 
 	// Get existing vehicle
@@ -267,7 +322,7 @@ func (s *VehicleService) UpdateVehicle(ctx context.Context, req *UpdateVehicleRe
 			return nil, fmt.Errorf("failed to check license plate: %w", err)
 		}
 		if exists {
-			return nil, fmt.Errorf("license plate already exists: %s", req.LicensePlate)
+			return nil, fmt.Errorf("%w: %s", ErrLicensePlateExists, req.LicensePlate)
 		}
 	}
 
@@ -304,6 +359,10 @@ func (s *VehicleService) UpdateVehicle(ctx context.Context, req *UpdateVehicleRe
 		vehicle.SetRegistrationExpiry(*req.RegistrationExpiry)
 	}
 
+	if req.PhotoKey != "" {
+		vehicle.PhotoKey = req.PhotoKey
+	}
+
 	vehicle.UpdatedAt = time.Now()
 
 	// Save to database
@@ -353,6 +412,7 @@ func (s *VehicleService) UpdateVehicle(ctx context.Context, req *UpdateVehicleRe
 		}).Info("Vehicle updated successfully")
 	}
 
+	s.attachPhotos(ctx, vehicle)
 	return vehicle, nil
 }
 
@@ -361,6 +421,9 @@ func (s *VehicleService) UpdateVehicleStatus(ctx context.Context, id string, sta
 	if id == "" {
 		return fmt.Errorf("vehicle ID is required")
 	}
+	if !models.IsValidVehicleStatus(string(status)) {
+		return fmt.Errorf("%w: invalid vehicle status: %s", ErrInvalidVehicleRequest, status)
+	}
 
 	// Get vehicle to get driver ID for cache invalidation
 	vehicle, err := s.GetVehicle(ctx, id)
@@ -459,11 +522,11 @@ func (s *VehicleService) DeleteVehicle(ctx context.Context, id string) error {
 	return nil
 }
 
-// ListVehicles retrieves vehicles with pagination and filtering
+// ListVehicles retrieves a page of vehicles matching the filters
 func (s *VehicleService) ListVehicles(ctx context.Context, req *ListVehiclesRequest) (*ListVehiclesResponse, error) {
 	// Validate request
 	if err := s.validateListVehiclesRequest(req); err != nil {
-		return nil, fmt.Errorf("invalid request: %w", err)
+		return nil, fmt.Errorf("%w: %v", ErrInvalidVehicleRequest, err)
 	}
 
 	// Build filters map
@@ -476,7 +539,7 @@ func (s *VehicleService) ListVehicles(ctx context.Context, req *ListVehiclesRequ
 	}
 
 	// Get vehicles from database
-	vehicles, err := s.vehicleRepo.List(ctx, req.Limit, req.Offset, filters)
+	vehicles, err := s.vehicleRepo.List(ctx, req.Page, filters)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list vehicles: %w", err)
 	}
@@ -487,12 +550,10 @@ func (s *VehicleService) ListVehicles(ctx context.Context, req *ListVehiclesRequ
 		return nil, fmt.Errorf("failed to count vehicles: %w", err)
 	}
 
-	return &ListVehiclesResponse{
-		Vehicles: vehicles,
-		Total:    total,
-		Limit:    req.Limit,
-		Offset:   req.Offset,
-	}, nil
+	page := pagination.NewPage(vehicles, req.Page, VehicleSortKey(req.Page.Sort), total)
+	s.attachFeatures(ctx, page.Items...)
+	s.attachPhotos(ctx, page.Items...)
+	return &page, nil
 }
 
 // GetVehicleStats retrieves vehicle statistics
@@ -624,15 +685,7 @@ func (s *VehicleService) validateUpdateVehicleRequest(req *UpdateVehicleRequest)
 }
 
 func (s *VehicleService) validateListVehiclesRequest(req *ListVehiclesRequest) error {
-	if req.Limit <= 0 {
-		req.Limit = 20
-	}
-	if req.Limit > 100 {
-		req.Limit = 100
-	}
-	if req.Offset < 0 {
-		req.Offset = 0
-	}
+	req.Page = VehicleListOptions.WithDefaults(req.Page)
 	return nil
 }
 
@@ -664,21 +717,17 @@ type UpdateVehicleRequest struct {
 	InsurancePolicyNumber string     `json:"insurance_policy_number,omitempty"`
 	InsuranceExpiry       *time.Time `json:"insurance_expiry,omitempty"`
 	RegistrationExpiry    *time.Time `json:"registration_expiry,omitempty"`
+	// PhotoKey is only set from accepted photo uploads
+	PhotoKey string `json:"-"`
 }
 
 type ListVehiclesRequest struct {
-	Limit       int    `json:"limit"`
-	Offset      int    `json:"offset"`
-	Status      string `json:"status,omitempty"`
-	VehicleType string `json:"vehicle_type,omitempty"`
+	Page        pagination.Request `json:"-"`
+	Status      string             `json:"status,omitempty"`
+	VehicleType string             `json:"vehicle_type,omitempty"`
 }
 
-type ListVehiclesResponse struct {
-	Vehicles []*models.Vehicle `json:"vehicles"`
-	Total    int64             `json:"total"`
-	Limit    int               `json:"limit"`
-	Offset   int               `json:"offset"`
-}
+type ListVehiclesResponse = pagination.Page[*models.Vehicle]
 
 type VehicleStatsResponse struct {
 	TotalVehicles    int64                  `json:"total_vehicles"`
@@ -3,15 +3,16 @@ package service
 import (
 	"context"
 	"errors"
+	"sort"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/rideshare-platform/services/vehicle-service/internal/repository"
 	"github.com/rideshare-platform/shared/models"
+	"github.com/rideshare-platform/shared/pagination"
 )
 
-var ErrVehicleNotFound = errors.New("vehicle not found")
-
 // MockVehicleRepository provides a complete test implementation
 type MockVehicleRepository struct {
 	vehicles map[string]*models.Vehicle
@@ -34,7 +35,7 @@ func (m *MockVehicleRepository) Create(ctx context.Context, vehicle *models.Vehi
 func (m *MockVehicleRepository) GetByID(ctx context.Context, id string) (*models.Vehicle, error) {
 	vehicle, exists := m.vehicles[id]
 	if !exists {
-		return nil, ErrVehicleNotFound
+		return nil, repository.ErrVehicleNotFound
 	}
 	return vehicle, nil
 }
@@ -48,7 +49,7 @@ func (m *MockVehicleRepository) UpdateStatus(ctx context.Context, id string, sta
 		vehicle.Status = status
 		return nil
 	}
-	return ErrVehicleNotFound
+	return repository.ErrVehicleNotFound
 }
 
 func (m *MockVehicleRepository) Update(ctx context.Context, vehicle *models.Vehicle) error {
@@ -61,10 +62,19 @@ func (m *MockVehicleRepository) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-func (m *MockVehicleRepository) List(ctx context.Context, limit, offset int, filters map[string]interface{}) ([]*models.Vehicle, error) {
+func (m *MockVehicleRepository) List(ctx context.Context, page pagination.Request, filters map[string]interface{}) ([]*models.Vehicle, error) {
+	key := VehicleSortKey(page.Sort)
 	var result []*models.Vehicle
 	for _, vehicle := range m.vehicles {
-		result = append(result, vehicle)
+		if page.After == nil || page.Compare(key(vehicle), *page.After) > 0 {
+			result = append(result, vehicle)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return page.Compare(key(result[i]), key(result[j])) < 0
+	})
+	if len(result) > page.FetchLimit() {
+		result = result[:page.FetchLimit()]
 	}
 	return result, nil
 }
@@ -82,18 +92,25 @@ func (m *MockVehicleRepository) LicensePlateExists(ctx context.Context, licenseP
 	return false, nil
 }
 
-func (m *MockVehicleRepository) GetAvailableVehicles(ctx context.Context, vehicleType string, lat, lng float64, radius float64) ([]*models.Vehicle, error) {
+func (m *MockVehicleRepository) GetAvailableVehicles(ctx context.Context, driverID string) ([]*models.Vehicle, error) {
 	var result []*models.Vehicle
-	for _, vehicle := range m.vehicles {
+	for _, vehicle := range m.drivers[driverID] {
 		if vehicle.Status == models.VehicleStatusActive {
-			if vehicleType == "" || string(vehicle.VehicleType) == vehicleType {
-				result = append(result, vehicle)
-			}
+			result = append(result, vehicle)
 		}
 	}
 	return result, nil
 }
 
+func (m *MockVehicleRepository) GetAvailableByDriverIDs(ctx context.Context, driverIDs []string) ([]*models.Vehicle, error) {
+	var result []*models.Vehicle
+	for _, driverID := range driverIDs {
+		available, _ := m.GetAvailableVehicles(ctx, driverID)
+		result = append(result, available...)
+	}
+	return result, nil
+}
+
 func (m *MockVehicleRepository) GetVehiclesWithExpiredInsurance(ctx context.Context) ([]*models.Vehicle, error) {
 	var result []*models.Vehicle
 	now := time.Now()
@@ -116,6 +133,20 @@ func (m *MockVehicleRepository) GetVehiclesWithExpiredRegistration(ctx context.C
 	return result, nil
 }
 
+func (m *MockVehicleRepository) GetVehiclesWithDocumentsExpiringBefore(ctx context.Context, cutoff time.Time) ([]*models.Vehicle, error) {
+	var result []*models.Vehicle
+	for _, vehicle := range m.vehicles {
+		if vehicle.Status == models.VehicleStatusInactive || vehicle.Status == models.VehicleStatusRetired {
+			continue
+		}
+		if (vehicle.InsuranceExpiry != nil && !vehicle.InsuranceExpiry.After(cutoff)) ||
+			(vehicle.RegistrationExpiry != nil && !vehicle.RegistrationExpiry.After(cutoff)) {
+			result = append(result, vehicle)
+		}
+	}
+	return result, nil
+}
+
 // Test functions with comprehensive coverage
 func TestVehicleService_CreateVehicle(t *testing.T) {
 	repo := NewMockVehicleRepository()
@@ -346,6 +377,43 @@ func TestVehicleService_GetAvailableVehicles(t *testing.T) {
 	}
 }
 
+func TestVehicleService_GetAvailableVehiclesByDrivers(t *testing.T) {
+	repo := NewMockVehicleRepository()
+	service := &VehicleService{vehicleRepo: repo}
+
+	active1 := models.NewVehicle("driver-1", "Toyota", "Prius", 2022, "White", "ABC123", models.VehicleTypeSedan, 4)
+	inactive1 := models.NewVehicle("driver-1", "Honda", "Civic", 2021, "Blue", "DEF456", models.VehicleTypeSedan, 4)
+	inactive1.Status = models.VehicleStatusMaintenance
+	active2 := models.NewVehicle("driver-2", "Ford", "Explorer", 2023, "Black", "GHI789", models.VehicleTypeSUV, 6)
+	for _, vehicle := range []*models.Vehicle{active1, inactive1, active2} {
+		repo.Create(context.Background(), vehicle)
+	}
+
+	tests := []struct {
+		name      string
+		driverIDs []string
+		wantErr   bool
+		wantLen   int
+	}{
+		{name: "active vehicles of each driver", driverIDs: []string{"driver-1", "driver-2", "driver-3"}, wantLen: 2},
+		{name: "duplicate IDs looked up once", driverIDs: []string{"driver-2", "driver-2"}, wantLen: 1},
+		{name: "no driver IDs", driverIDs: []string{""}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vehicles, err := service.GetAvailableVehiclesByDrivers(context.Background(), tt.driverIDs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetAvailableVehiclesByDrivers() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(vehicles) != tt.wantLen {
+				t.Errorf("GetAvailableVehiclesByDrivers() len = %v, want %v", len(vehicles), tt.wantLen)
+			}
+		})
+	}
+}
+
 func TestVehicleService_UpdateVehicleStatus(t *testing.T) {
 	repo := NewMockVehicleRepository()
 	service := &VehicleService{
@@ -709,76 +777,61 @@ func TestVehicleService_ValidateUpdateVehicleRequest(t *testing.T) {
 	}
 }
 
-func TestVehicleService_ValidateListVehiclesRequest(t *testing.T) {
+func TestVehicleService_ListVehiclesPages(t *testing.T) {
+	ctx := context.Background()
 	repo := NewMockVehicleRepository()
-	service := &VehicleService{
-		vehicleRepo: repo,
+	service := NewVehicleService(repo, nil, nil, nil)
+	created := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i, id := range []string{"vehicle-a", "vehicle-b", "vehicle-c", "vehicle-d", "vehicle-e"} {
+		// The last two vehicles share a creation time, their IDs break the tie
+		at := created.Add(time.Duration(min(i, 3)) * time.Hour)
+		if err := repo.Create(ctx, &models.Vehicle{ID: id, DriverID: "driver-1", Year: 2015 + i, CreatedAt: at}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
 	}
 
-	tests := []struct {
-		name        string
-		req         *ListVehiclesRequest
-		expectedReq *ListVehiclesRequest
-	}{
-		{
-			name: "Valid Request",
-			req: &ListVehiclesRequest{
-				Limit:  10,
-				Offset: 0,
-			},
-			expectedReq: &ListVehiclesRequest{
-				Limit:  10,
-				Offset: 0,
-			},
-		},
-		{
-			name: "Limit Too High - Should Cap at 100",
-			req: &ListVehiclesRequest{
-				Limit:  200,
-				Offset: 0,
-			},
-			expectedReq: &ListVehiclesRequest{
-				Limit:  100,
-				Offset: 0,
-			},
-		},
-		{
-			name: "Negative Offset - Should Reset to 0",
-			req: &ListVehiclesRequest{
-				Limit:  10,
-				Offset: -5,
-			},
-			expectedReq: &ListVehiclesRequest{
-				Limit:  10,
-				Offset: 0,
-			},
-		},
-		{
-			name: "Zero Limit - Should Set Default",
-			req: &ListVehiclesRequest{
-				Limit:  0,
-				Offset: 10,
-			},
-			expectedReq: &ListVehiclesRequest{
-				Limit:  20,
-				Offset: 10,
-			},
-		},
+	var seen []string
+	cursor := ""
+	for pages := 0; ; pages++ {
+		if pages > 3 {
+			t.Fatalf("Expected three pages, still paging after %v", seen)
+		}
+		page, err := pagination.NewRequest(2, cursor, "", VehicleListOptions)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		resp, err := service.ListVehicles(ctx, &ListVehiclesRequest{Page: page})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if resp.TotalEstimate != 5 {
+			t.Errorf("Expected a total estimate of 5, got %d", resp.TotalEstimate)
+		}
+		for _, vehicle := range resp.Items {
+			seen = append(seen, vehicle.ID)
+		}
+		if resp.NextCursor == "" {
+			break
+		}
+		cursor = resp.NextCursor
+	}
+	want := []string{"vehicle-e", "vehicle-d", "vehicle-c", "vehicle-b", "vehicle-a"}
+	if strings.Join(seen, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected newest first %v, got %v", want, seen)
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			err := service.validateListVehiclesRequest(tt.req)
-			if err != nil {
-				t.Errorf("validateListVehiclesRequest() unexpected error = %v", err)
-				return
-			}
-			if tt.req.Limit != tt.expectedReq.Limit {
-				t.Errorf("validateListVehiclesRequest() limit = %v, want %v", tt.req.Limit, tt.expectedReq.Limit)
-			}
-			if tt.req.Offset != tt.expectedReq.Offset {
-				t.Errorf("validateListVehiclesRequest() offset = %v, want %v", tt.req.Offset, tt.expectedReq.Offset)
-			}
-		})
+	if _, err := pagination.NewRequest(2, cursor, "year", VehicleListOptions); !errors.Is(err, pagination.ErrInvalidCursor) {
+		t.Errorf("Expected a cursor issued for another sort to be refused, got %v", err)
+	}
+	if _, err := pagination.NewRequest(2, "", "-license_plate", VehicleListOptions); !errors.Is(err, pagination.ErrInvalidSort) {
+		t.Errorf("Expected an unknown sort field to be refused, got %v", err)
+	}
+
+	resp, err := service.ListVehicles(ctx, &ListVehiclesRequest{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(resp.Items) != 5 || resp.NextCursor != "" {
+		t.Errorf("Expected the default page to hold every vehicle, got %+v", resp)
 	}
 }
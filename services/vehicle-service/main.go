@@ -1,61 +1,208 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
-	"net/http"
-
 	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
-	"github.com/gin-gonic/gin"
+	_ "github.com/lib/pq"
+	"github.com/rideshare-platform/services/vehicle-service/internal/config"
+	"github.com/rideshare-platform/services/vehicle-service/internal/handler"
+	"github.com/rideshare-platform/services/vehicle-service/internal/middleware"
+	"github.com/rideshare-platform/services/vehicle-service/internal/repository"
+	"github.com/rideshare-platform/services/vehicle-service/internal/service"
+	"github.com/rideshare-platform/services/vehicle-service/migrations"
+	sharedconfig "github.com/rideshare-platform/shared/config"
+	"github.com/rideshare-platform/shared/database"
+	"github.com/rideshare-platform/shared/events"
+	"github.com/rideshare-platform/shared/grpc/interceptor"
+	sharedhealth "github.com/rideshare-platform/shared/health"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/monitoring"
+	"github.com/rideshare-platform/shared/notifications"
+	vehiclepb "github.com/rideshare-platform/shared/proto/vehicle"
+	sharedtls "github.com/rideshare-platform/shared/tls"
+	"github.com/rideshare-platform/shared/uploads"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
 )
 
 func main() {
-	// Create Gin router
-	r := gin.Default()
-
-	// Basic health check endpoint
-	r.GET("/health", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
-			"status":  "healthy",
-			"service": "vehicle-service",
-		})
-	})
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
 
-	// Basic vehicles endpoint
-	r.GET("/vehicles", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{
-			"vehicles": []gin.H{},
-			"message":  "Vehicle service is running",
-		})
-	})
+	appLogger := logger.NewLogger(cfg.Dynamic.LogLevel, cfg.Environment)
+	appLogger.WithFields(logger.Fields{
+		"http_port": cfg.HTTPPort,
+		"grpc_port": cfg.GRPCPort,
+	}).Info("Starting Vehicle Service")
 
-	// Start HTTP server
-	port := ":8080"
-	go func() {
-		log.Printf("Vehicle service starting on port %s", port)
-		if err := r.Run(port); err != nil {
-			log.Fatalf("Failed to start server: %v", err)
+	tlsReloader, err := sharedtls.Load(cfg.TLS, appLogger)
+	if err != nil {
+		appLogger.WithError(err).Fatal("Failed to load TLS certificates")
+	}
+	go tlsReloader.Watch(context.Background())
+
+	// Log level and feature flags follow the config file while running
+	dynamicConfig, err := sharedconfig.NewReloadable(sharedconfig.NewLoader())
+	if err != nil {
+		appLogger.WithError(err).Fatal("Failed to load dynamic config")
+	}
+	dynamicConfig.BindLogLevel(appLogger)
+	watchCtx, stopWatching := context.WithCancel(context.Background())
+	defer stopWatching()
+	go dynamicConfig.StartWatching(watchCtx, 30*time.Second, appLogger)
+
+	// Connect to PostgreSQL and Redis
+	postgresDB, err := database.NewPostgresDB(&cfg.Database, appLogger)
+	if err != nil {
+		appLogger.WithError(err).Fatal("Failed to connect to PostgreSQL")
+	}
+	defer postgresDB.Close()
+
+	if cfg.MigrateOnStartup {
+		schema, err := migrations.Load()
+		if err != nil {
+			appLogger.WithError(err).Fatal("Failed to load migrations")
 		}
-	}()
+		if _, err := database.NewMigrator(postgresDB.DB, "vehicle-service", schema, appLogger).Up(context.Background()); err != nil {
+			appLogger.WithError(err).Fatal("Failed to migrate database")
+		}
+	}
+
+	redisDB, err := database.NewRedisDB(cfg.Redis, appLogger)
+	if err != nil {
+		appLogger.WithError(err).Fatal("Failed to connect to Redis")
+	}
+	defer redisDB.Close()
+
+	eventBus := events.NewInMemoryEventBus(appLogger)
+	eventPublisher := events.NewEventPublisher(eventBus, events.NewInMemoryEventStore(appLogger), appLogger)
+	defer eventPublisher.Close()
+
+	// Drivers are notified when their vehicle documents expire or a vehicle is deactivated
+	notifier := notifications.NewDispatcher(notifications.NewMemoryStore(), appLogger)
+	for _, channel := range []notifications.Channel{notifications.ChannelPush, notifications.ChannelSMS, notifications.ChannelEmail} {
+		notifier.RegisterProvider(notifications.NewLogProvider(channel, appLogger))
+	}
+	if err := notifier.SubscribeEvents(eventPublisher, notifications.DefaultEventRoutes()); err != nil {
+		appLogger.WithError(err).Fatal("Failed to subscribe notifications to vehicle events")
+	}
+
+	// Initialize repositories and service
+	vehicleRepo := repository.NewVehicleRepository(postgresDB, appLogger)
+	cacheRepo := repository.NewCacheRepository(redisDB, appLogger)
+	vehicleService := service.NewVehicleService(vehicleRepo, cacheRepo, eventPublisher, appLogger)
+	vehicleService.SetFeatureRepository(repository.NewVehicleFeatureRepository(postgresDB, appLogger))
+
+	// Insurance certificates, registrations and photos are uploaded straight
+	// to the object store. Accepted documents update the vehicle's expiry
+	// dates and accepted photos are served resized through the media CDN.
+	var uploader *uploads.Uploader
+	if cfg.Uploads.Enabled {
+		var err error
+		uploader, err = uploads.Open(context.Background(), cfg.Uploads, appLogger)
+		if err != nil {
+			appLogger.WithError(err).Fatal("Failed to set up document uploads")
+		}
+		defer uploader.Close()
+		uploader.RegisterTypes(service.VehicleDocumentTypes()...)
+		uploader.OnAccepted(vehicleService.ApplyDocument)
+		vehicleService.SetMedia(uploader.Media())
+	}
+
+	// Warn drivers about expiring documents and take lapsed vehicles out of service
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	go vehicleService.StartDocumentExpiryJob(workerCtx, cfg.DocumentExpiry.CheckInterval, cfg.DocumentExpiry.WarningWindow)
 
-	// Start gRPC health server
-	grpcServer := grpc.NewServer()
+	// Request counts and latencies for HTTP, gRPC and SQL queries, scraped from /metrics
+	metricsCollector := monitoring.NewMetricsCollector(nil, appLogger)
+	postgresDB.SetMetrics(metricsCollector, "vehicle-service")
+
+	// Start gRPC server with the vehicle API and health
+	serverOptions := interceptor.ServerOptions(interceptor.Config{
+		Service:  "vehicle-service",
+		Logger:   appLogger,
+		Metrics:  metricsCollector,
+		Auth:     interceptor.JWTAuth(cfg.JWTSecret, cfg.GRPCAuthRequired),
+		Deadline: interceptor.DefaultDeadlineConfig(),
+	})
+	grpcServer := grpc.NewServer(append(serverOptions, tlsReloader.ServerOptions()...)...)
+	vehiclepb.RegisterVehicleServiceServer(grpcServer, handler.NewGRPCVehicleHandler(vehicleService))
 	healthServer := health.NewServer()
 	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
 	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
+
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.GRPCPort))
+	if err != nil {
+		appLogger.WithError(err).Fatal("Failed to listen on gRPC port")
+	}
 	go func() {
-		lis, err := net.Listen("tcp", ":50052")
-		if err != nil {
-			log.Fatalf("Failed to listen on gRPC port: %v", err)
-		}
-		log.Printf("gRPC server listening on port %s", "50052")
+		appLogger.WithFields(logger.Fields{"port": cfg.GRPCPort}).Info("gRPC server listening")
 		if err := grpcServer.Serve(lis); err != nil {
-			log.Fatalf("Failed to start gRPC server: %v", err)
+			appLogger.WithError(err).Fatal("Failed to start gRPC server")
 		}
 	}()
 
-	select {} // Block forever
+	// Fleet partners register vehicles in batches, imported in the background
+	vehicleImporter := service.NewVehicleImporter(vehicleService, cfg.ImportQueueSize, appLogger)
+	go vehicleImporter.Start(workerCtx)
+
+	// Start HTTP server with the REST API
+	vehicleHandler := handler.NewVehicleHandler(vehicleService)
+	vehicleHandler.SetImporter(vehicleImporter)
+	httpServer := handler.NewHTTPServer(
+		cfg.HTTPPort,
+		middleware.NewAuthMiddleware(cfg.JWTSecret, appLogger),
+		middleware.NewMetricsMiddleware(metricsCollector),
+		vehicleHandler,
+		appLogger,
+	)
+	if uploader != nil {
+		httpServer.SetDocuments(uploads.NewHandler(uploader))
+	}
+	healthChecker := sharedhealth.NewChecker("vehicle-service", "1.0.0")
+	healthChecker.SetConfig(cfg)
+	healthChecker.AddCheck("postgres", postgresDB.Health)
+	healthChecker.AddCheck("redis", redisDB.Health)
+	if postgresDB.Replicas().Len() > 0 {
+		healthChecker.AddOptionalCheck("postgres-replicas", postgresDB.ReplicaHealth)
+		go postgresDB.Replicas().StartMonitoring(workerCtx, 15*time.Second)
+	}
+	httpServer.SetHealthChecker(healthChecker)
+	go func() {
+		if err := httpServer.Start(); err != nil && err != http.ErrServerClosed {
+			appLogger.WithError(err).Fatal("Failed to start HTTP server")
+		}
+	}()
+
+	// Wait for interrupt signal to gracefully shutdown the servers
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+	appLogger.Logger.Info("Shutting down Vehicle Service...")
+
+	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	stopWorkers()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := httpServer.Shutdown(ctx); err != nil {
+		appLogger.WithError(err).Error("HTTP server forced to shutdown")
+	}
+	grpcServer.GracefulStop()
+
+	appLogger.Logger.Info("Vehicle Service stopped")
 }
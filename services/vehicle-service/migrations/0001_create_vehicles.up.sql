@@ -0,0 +1,24 @@
+-- driver_id refers to drivers owned by user-service. There is no foreign key
+-- so the two services can migrate independently.
+CREATE TABLE IF NOT EXISTS vehicles (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    driver_id UUID NOT NULL,
+    make VARCHAR(50) NOT NULL,
+    model VARCHAR(50) NOT NULL,
+    year INTEGER NOT NULL CHECK (year >= 1990),
+    color VARCHAR(30) NOT NULL,
+    license_plate VARCHAR(20) UNIQUE NOT NULL,
+    vehicle_type VARCHAR(20) NOT NULL CHECK (vehicle_type IN ('sedan', 'suv', 'hatchback', 'luxury', 'van')),
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('inactive', 'active', 'maintenance', 'retired')),
+    capacity INTEGER NOT NULL DEFAULT 4 CHECK (capacity >= 1 AND capacity <= 8),
+    insurance_policy_number VARCHAR(100),
+    insurance_expiry DATE,
+    registration_expiry DATE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_vehicles_driver_id ON vehicles(driver_id);
+CREATE INDEX IF NOT EXISTS idx_vehicles_type ON vehicles(vehicle_type);
+CREATE INDEX IF NOT EXISTS idx_vehicles_status ON vehicles(status);
+CREATE INDEX IF NOT EXISTS idx_vehicles_license_plate ON vehicles(license_plate);
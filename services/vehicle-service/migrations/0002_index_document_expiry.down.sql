@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_vehicles_registration_expiry;
+DROP INDEX IF EXISTS idx_vehicles_insurance_expiry;
@@ -0,0 +1,3 @@
+-- The document expiry job scans for lapsed insurance and registration
+CREATE INDEX IF NOT EXISTS idx_vehicles_insurance_expiry ON vehicles(insurance_expiry) WHERE insurance_expiry IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_vehicles_registration_expiry ON vehicles(registration_expiry) WHERE registration_expiry IS NOT NULL;
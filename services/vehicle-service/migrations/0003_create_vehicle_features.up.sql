@@ -0,0 +1,9 @@
+-- Amenities and accessibility features riders can ask for when matching
+CREATE TABLE IF NOT EXISTS vehicle_features (
+    vehicle_id UUID NOT NULL REFERENCES vehicles(id) ON DELETE CASCADE,
+    feature VARCHAR(40) NOT NULL CHECK (feature IN ('child_seat', 'wheelchair_accessible', 'wifi', 'pet_friendly')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (vehicle_id, feature)
+);
+
+CREATE INDEX IF NOT EXISTS idx_vehicle_features_feature ON vehicle_features(feature);
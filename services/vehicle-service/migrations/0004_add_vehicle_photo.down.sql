@@ -0,0 +1 @@
+ALTER TABLE vehicles DROP COLUMN IF EXISTS photo_key;
@@ -0,0 +1,2 @@
+-- Object key of the vehicle's accepted photo, signed into URLs when read
+ALTER TABLE vehicles ADD COLUMN IF NOT EXISTS photo_key TEXT NOT NULL DEFAULT '';
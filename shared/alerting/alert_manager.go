@@ -4,19 +4,29 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"strings"
+	"sync"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 	"github.com/rideshare-platform/shared/logger"
 )
 
+// AlertEventsChannel is the Redis channel every fired, acknowledged and
+// resolved alert is published on, as JSON
+const AlertEventsChannel = "alert_events"
+
 // AlertManager manages platform alerts and notifications
 type AlertManager struct {
-	redis    *redis.Client
-	logger   *logger.Logger
-	channels map[string]NotificationChannel
-	rules    []*AlertRule
+	redis      redis.UniversalClient
+	logger     *logger.Logger
+	channels   map[string]NotificationChannel
+	escalation EscalationChannel
+	rules      []*AlertRule
+
+	// pendingSince records when each rule condition started holding, so
+	// conditions only count once they have persisted for their Duration
+	pendingSince map[string]time.Time
+	mutex        sync.Mutex
 }
 
 // AlertRule defines conditions that trigger alerts
@@ -52,6 +62,7 @@ type AlertAction struct {
 type Alert struct {
 	ID          string                 `json:"id"`
 	RuleID      string                 `json:"rule_id"`
+	IncidentKey string                 `json:"incident_key,omitempty"`
 	Severity    AlertSeverity          `json:"severity"`
 	Title       string                 `json:"title"`
 	Description string                 `json:"description"`
@@ -63,6 +74,10 @@ type Alert struct {
 	ResolvedAt  *time.Time             `json:"resolved_at,omitempty"`
 	AckedAt     *time.Time             `json:"acked_at,omitempty"`
 	AckedBy     string                 `json:"acked_by,omitempty"`
+
+	// EscalationTarget is the target the alert was paged to, so it is
+	// resolved in the same place
+	EscalationTarget string `json:"escalation_target,omitempty"`
 }
 
 // AlertSeverity defines alert severity levels
@@ -83,9 +98,11 @@ const (
 	StatusResolved AlertStatus = "resolved"
 )
 
-// NotificationChannel interface for different notification methods
+// NotificationChannel interface for different notification methods. target is
+// the action's destination (address, channel or URL) and may be empty to use
+// the channel's default.
 type NotificationChannel interface {
-	Send(ctx context.Context, alert *Alert) error
+	Send(ctx context.Context, alert *Alert, target string) error
 	GetType() string
 }
 
@@ -98,12 +115,13 @@ type MetricValue struct {
 }
 
 // NewAlertManager creates a new alert manager
-func NewAlertManager(redis *redis.Client, logger *logger.Logger) *AlertManager {
+func NewAlertManager(redis redis.UniversalClient, logger *logger.Logger) *AlertManager {
 	am := &AlertManager{
-		redis:    redis,
-		logger:   logger,
-		channels: make(map[string]NotificationChannel),
-		rules:    []*AlertRule{},
+		redis:        redis,
+		logger:       logger,
+		channels:     make(map[string]NotificationChannel),
+		rules:        []*AlertRule{},
+		pendingSince: make(map[string]time.Time),
 	}
 
 	// Initialize default alert rules
@@ -243,28 +261,55 @@ func (am *AlertManager) initializeDefaultRules() {
 	am.rules = defaultRules
 }
 
-// initializeChannels sets up notification channels
+// initializeChannels registers the channels configured in the environment
 func (am *AlertManager) initializeChannels() {
-	// Initialize email channel
-	am.channels["email"] = &EmailChannel{
-		SMTPHost: "smtp.rideshare.com",
-		SMTPPort: 587,
-		Username: "alerts@rideshare.com",
-		Password: "smtp_password", // In production, use secure config
-		From:     "RideShare Alerts <alerts@rideshare.com>",
+	config := LoadChannelConfig()
+
+	if config.Email.SMTPHost != "" {
+		am.channels["email"] = NewEmailChannel(config.Email)
+	}
+	if config.Slack.WebhookURL != "" {
+		am.channels["slack"] = NewSlackChannel(config.Slack)
+	}
+	if config.Webhook.DefaultURL != "" {
+		am.channels["webhook"] = NewWebhookChannel(config.Webhook, am.redis)
 	}
+	if config.PagerDuty.RoutingKey != "" {
+		am.SetEscalationChannel(NewPagerDutyChannel(config.PagerDuty))
+	}
+}
+
+// SetEscalationChannel sets the on-call channel paged for every critical
+// alert. It is also registered under its type so rules can page it explicitly.
+func (am *AlertManager) SetEscalationChannel(channel EscalationChannel) {
+	am.escalation = channel
+	am.channels[channel.GetType()] = channel
+}
+
+// SetChannel registers or replaces the channel used for an action type
+func (am *AlertManager) SetChannel(actionType string, channel NotificationChannel) {
+	am.channels[actionType] = channel
+}
 
-	// Initialize Slack channel
-	am.channels["slack"] = &SlackChannel{
-		WebhookURL:     "https://hooks.slack.com/services/YOUR/SLACK/WEBHOOK",
-		DefaultChannel: "#alerts",
+// GetDeadLetters returns the most recent webhook deliveries that failed after every retry
+func (am *AlertManager) GetDeadLetters(ctx context.Context, limit int64) ([]*DeadLetter, error) {
+	if am.redis == nil {
+		return nil, fmt.Errorf("redis client not available")
+	}
+
+	results, err := am.redis.LRange(ctx, deadLetterKey, 0, limit-1).Result()
+	if err != nil {
+		return nil, err
 	}
 
-	// Initialize webhook channel
-	am.channels["webhook"] = &WebhookChannel{
-		DefaultURL: "https://api.rideshare.com/webhooks/alerts",
-		Timeout:    10 * time.Second,
+	letters := make([]*DeadLetter, 0, len(results))
+	for _, data := range results {
+		var letter DeadLetter
+		if err := json.Unmarshal([]byte(data), &letter); err == nil {
+			letters = append(letters, &letter)
+		}
 	}
+	return letters, nil
 }
 
 // EvaluateMetrics evaluates incoming metrics against alert rules
@@ -305,14 +350,57 @@ func (am *AlertManager) EvaluateMetrics(ctx context.Context, metrics []*MetricVa
 	return nil
 }
 
+// RaiseAlert fires an alert that no metric rule watches for, such as an
+// emergency reported by a user, and sends it to actions. Critical alerts are
+// also escalated to on-call. Missing IDs, statuses and times are filled in.
+func (am *AlertManager) RaiseAlert(ctx context.Context, alert *Alert, actions []AlertAction) error {
+	now := time.Now()
+	if alert.ID == "" {
+		alert.ID = fmt.Sprintf("%s_%d", alert.RuleID, now.UnixNano())
+	}
+	if alert.Status == "" {
+		alert.Status = StatusActive
+	}
+	if alert.CreatedAt.IsZero() {
+		alert.CreatedAt = now
+	}
+	alert.UpdatedAt = now
+
+	return am.fireAlert(ctx, alert, actions)
+}
+
 // evaluateRuleConditions checks if all conditions for a rule are met
 func (am *AlertManager) evaluateRuleConditions(ctx context.Context, rule *AlertRule, metrics []*MetricValue) bool {
-	for _, condition := range rule.Conditions {
-		if !am.evaluateCondition(ctx, condition, metrics) {
-			return false
+	now := time.Now()
+	allMet := true
+
+	// Every condition is evaluated so each one's pending time stays current
+	for i, condition := range rule.Conditions {
+		met := am.evaluateCondition(ctx, condition, metrics)
+		if !am.conditionPersisted(fmt.Sprintf("%s:%d", rule.ID, i), met, condition.Duration, now) {
+			allMet = false
 		}
 	}
-	return true
+	return allMet
+}
+
+// conditionPersisted tracks how long a condition has held continuously and
+// reports whether that covers its required duration. A condition that stops
+// holding starts over.
+func (am *AlertManager) conditionPersisted(key string, met bool, duration time.Duration, now time.Time) bool {
+	am.mutex.Lock()
+	defer am.mutex.Unlock()
+
+	if !met {
+		delete(am.pendingSince, key)
+		return false
+	}
+	since, pending := am.pendingSince[key]
+	if !pending {
+		since = now
+		am.pendingSince[key] = now
+	}
+	return now.Sub(since) >= duration
 }
 
 // evaluateCondition evaluates a single condition against metrics
@@ -373,6 +461,14 @@ func (am *AlertManager) isInCooldown(ctx context.Context, ruleID string) bool {
 
 // fireAlert creates and sends an alert
 func (am *AlertManager) fireAlert(ctx context.Context, alert *Alert, actions []AlertAction) error {
+	if am.escalation != nil {
+		for _, action := range actions {
+			if action.Enabled && action.Type == am.escalation.GetType() {
+				alert.EscalationTarget = action.Target
+			}
+		}
+	}
+
 	// Store alert in Redis
 	if am.redis != nil {
 		alertData, _ := json.Marshal(alert)
@@ -391,6 +487,8 @@ func (am *AlertManager) fireAlert(ctx context.Context, alert *Alert, actions []A
 		if rule != nil {
 			am.redis.SetEx(ctx, cooldownKey, "1", rule.Cooldown)
 		}
+
+		am.redis.Publish(ctx, AlertEventsChannel, alertData)
 	}
 
 	// Send notifications
@@ -399,17 +497,36 @@ func (am *AlertManager) fireAlert(ctx context.Context, alert *Alert, actions []A
 			continue
 		}
 
-		if channel, exists := am.channels[action.Type]; exists {
-			if err := channel.Send(ctx, alert); err != nil {
-				am.logger.WithError(err).Error("Failed to send alert notification",
-					"alert_id", alert.ID, "channel", action.Type)
-			} else {
-				am.logger.WithFields(logger.Fields{
-					"alert_id": alert.ID,
-					"channel":  action.Type,
-					"target":   action.Target,
-				}).Info("Alert notification sent")
-			}
+		channel, exists := am.channels[action.Type]
+		if !exists {
+			am.logger.WithFields(logger.Fields{
+				"alert_id": alert.ID,
+				"channel":  action.Type,
+			}).Warn("No notification channel configured")
+			continue
+		}
+		if err := channel.Send(ctx, alert, action.Target); err != nil {
+			am.logger.WithError(err).Error("Failed to send alert notification",
+				"alert_id", alert.ID, "channel", action.Type)
+		} else {
+			am.logger.WithFields(logger.Fields{
+				"alert_id": alert.ID,
+				"channel":  action.Type,
+				"target":   action.Target,
+			}).Info("Alert notification sent")
+		}
+	}
+
+	if alert.Severity == SeverityCritical && am.escalation != nil && !hasAction(actions, am.escalation.GetType()) {
+		if err := am.escalation.Send(ctx, alert, ""); err != nil {
+			am.logger.WithError(err).Error("Failed to escalate alert",
+				"alert_id", alert.ID, "channel", am.escalation.GetType())
+		} else {
+			am.logger.WithFields(logger.Fields{
+				"alert_id":  alert.ID,
+				"channel":   am.escalation.GetType(),
+				"dedup_key": DedupKey(alert),
+			}).Info("Alert escalated")
 		}
 	}
 
@@ -441,29 +558,59 @@ func (am *AlertManager) ResolveAlert(ctx context.Context, alertID string) error
 		return fmt.Errorf("failed to unmarshal alert: %w", err)
 	}
 
+	return am.ResolveRaisedAlert(ctx, &alert)
+}
+
+// ResolveRaisedAlert resolves an alert raised with RaiseAlert, given the
+// alert as it was raised. Unlike ResolveAlert it does not need the alert
+// store, so the on-call incident is closed even without Redis.
+func (am *AlertManager) ResolveRaisedAlert(ctx context.Context, alert *Alert) error {
 	// Update alert status
 	now := time.Now()
 	alert.Status = StatusResolved
 	alert.ResolvedAt = &now
 	alert.UpdatedAt = now
 
-	// Save updated alert
-	updatedData, _ := json.Marshal(alert)
-	am.redis.SetEx(ctx, alertKey, updatedData, 24*time.Hour)
+	if am.redis != nil {
+		// Save updated alert
+		updatedData, _ := json.Marshal(alert)
+		am.redis.SetEx(ctx, fmt.Sprintf("alert:%s", alert.ID), updatedData, 24*time.Hour)
+
+		// Remove from active alerts
+		am.redis.ZRem(ctx, "active_alerts", alert.ID)
 
-	// Remove from active alerts
-	am.redis.ZRem(ctx, "active_alerts", alertID)
+		// Add to resolved alerts
+		am.redis.ZAdd(ctx, "resolved_alerts", redis.Z{
+			Score:  float64(now.Unix()),
+			Member: alert.ID,
+		})
+		am.redis.Publish(ctx, AlertEventsChannel, updatedData)
+	}
 
-	// Add to resolved alerts
-	am.redis.ZAdd(ctx, "resolved_alerts", redis.Z{
-		Score:  float64(now.Unix()),
-		Member: alertID,
-	})
+	am.resolveEscalation(ctx, alert)
 
-	am.logger.WithField("alert_id", alertID).Info("Alert resolved")
+	am.logger.WithField("alert_id", alert.ID).Info("Alert resolved")
 	return nil
 }
 
+// resolveEscalation closes the on-call incident for an alert that was escalated
+func (am *AlertManager) resolveEscalation(ctx context.Context, alert *Alert) {
+	if am.escalation == nil {
+		return
+	}
+	if alert.Severity != SeverityCritical {
+		rule := am.findRule(alert.RuleID)
+		if rule == nil || !hasAction(rule.Actions, am.escalation.GetType()) {
+			return
+		}
+	}
+
+	if err := am.escalation.Resolve(ctx, alert); err != nil {
+		am.logger.WithError(err).Error("Failed to resolve escalated alert",
+			"alert_id", alert.ID, "dedup_key", DedupKey(alert))
+	}
+}
+
 // AcknowledgeAlert marks an alert as acknowledged
 func (am *AlertManager) AcknowledgeAlert(ctx context.Context, alertID, ackedBy string) error {
 	if am.redis == nil {
@@ -492,6 +639,7 @@ func (am *AlertManager) AcknowledgeAlert(ctx context.Context, alertID, ackedBy s
 	// Save updated alert
 	updatedData, _ := json.Marshal(alert)
 	am.redis.SetEx(ctx, alertKey, updatedData, 24*time.Hour)
+	am.redis.Publish(ctx, AlertEventsChannel, updatedData)
 
 	am.logger.WithFields(logger.Fields{
 		"alert_id": alertID,
@@ -592,6 +740,16 @@ func (am *AlertManager) findRule(ruleID string) *AlertRule {
 	return nil
 }
 
+// hasAction reports whether an enabled action of the given type is present
+func hasAction(actions []AlertAction, actionType string) bool {
+	for _, action := range actions {
+		if action.Enabled && action.Type == actionType {
+			return true
+		}
+	}
+	return false
+}
+
 // Helper function to convert interface{} to float64
 func toFloat64(value interface{}) (float64, bool) {
 	switch v := value.(type) {
@@ -609,65 +767,3 @@ func toFloat64(value interface{}) (float64, bool) {
 		return 0, false
 	}
 }
-
-// Notification channel implementations
-
-// EmailChannel sends alerts via email
-type EmailChannel struct {
-	SMTPHost string
-	SMTPPort int
-	Username string
-	Password string
-	From     string
-}
-
-func (ec *EmailChannel) Send(ctx context.Context, alert *Alert) error {
-	// Mock email sending implementation
-	subject := fmt.Sprintf("[%s] %s", strings.ToUpper(string(alert.Severity)), alert.Title)
-	body := fmt.Sprintf("Alert: %s\n\nDescription: %s\n\nService: %s\n\nCreated: %s",
-		alert.Title, alert.Description, alert.Service, alert.CreatedAt.Format(time.RFC3339))
-
-	fmt.Printf("EMAIL ALERT:\nTo: ops@rideshare.com\nSubject: %s\nBody: %s\n", subject, body)
-	return nil
-}
-
-func (ec *EmailChannel) GetType() string {
-	return "email"
-}
-
-// SlackChannel sends alerts to Slack
-type SlackChannel struct {
-	WebhookURL     string
-	DefaultChannel string
-}
-
-func (sc *SlackChannel) Send(ctx context.Context, alert *Alert) error {
-	// Mock Slack notification implementation
-	message := fmt.Sprintf("🚨 *%s Alert*: %s\n_%s_\nService: %s\nTime: %s",
-		strings.ToUpper(string(alert.Severity)), alert.Title, alert.Description,
-		alert.Service, alert.CreatedAt.Format(time.RFC3339))
-
-	fmt.Printf("SLACK ALERT:\nChannel: %s\nMessage: %s\n", sc.DefaultChannel, message)
-	return nil
-}
-
-func (sc *SlackChannel) GetType() string {
-	return "slack"
-}
-
-// WebhookChannel sends alerts via HTTP webhook
-type WebhookChannel struct {
-	DefaultURL string
-	Timeout    time.Duration
-}
-
-func (wc *WebhookChannel) Send(ctx context.Context, alert *Alert) error {
-	// Mock webhook implementation
-	alertJSON, _ := json.Marshal(alert)
-	fmt.Printf("WEBHOOK ALERT:\nURL: %s\nPayload: %s\n", wc.DefaultURL, string(alertJSON))
-	return nil
-}
-
-func (wc *WebhookChannel) GetType() string {
-	return "webhook"
-}
@@ -0,0 +1,557 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// deadLetterKey is the Redis list holding webhook deliveries that exhausted their retries
+const deadLetterKey = "alert_webhook_dead_letters"
+
+// maxDeadLetters caps the dead-letter list so a broken endpoint cannot grow it without bound
+const maxDeadLetters = 1000
+
+// maxWebhookDeliveries caps the webhook deliveries in flight so a slow
+// endpoint cannot pile up retrying goroutines
+const maxWebhookDeliveries = 64
+
+// ChannelConfig holds the settings for the built-in notification channels.
+// A channel is only registered when its destination is configured.
+type ChannelConfig struct {
+	Email     EmailConfig
+	Slack     SlackConfig
+	Webhook   WebhookConfig
+	PagerDuty PagerDutyConfig
+}
+
+// EmailConfig configures SMTP delivery
+type EmailConfig struct {
+	SMTPHost      string
+	SMTPPort      int
+	Username      string
+	Password      string
+	From          string
+	DefaultTo     []string
+	Timeout       time.Duration
+	SkipTLSVerify bool
+}
+
+// SlackConfig configures Slack incoming webhook delivery
+type SlackConfig struct {
+	WebhookURL     string
+	DefaultChannel string
+	Timeout        time.Duration
+}
+
+// WebhookConfig configures signed HTTP webhook delivery
+type WebhookConfig struct {
+	DefaultURL  string
+	Secret      string
+	Timeout     time.Duration
+	MaxRetries  int
+	BaseBackoff time.Duration
+}
+
+// LoadChannelConfig reads the channel settings from the environment
+func LoadChannelConfig() ChannelConfig {
+	return ChannelConfig{
+		Email: EmailConfig{
+			SMTPHost:      os.Getenv("ALERT_SMTP_HOST"),
+			SMTPPort:      getEnvAsInt("ALERT_SMTP_PORT", 587),
+			Username:      os.Getenv("ALERT_SMTP_USERNAME"),
+			Password:      os.Getenv("ALERT_SMTP_PASSWORD"),
+			From:          getEnv("ALERT_EMAIL_FROM", "RideShare Alerts <alerts@rideshare.com>"),
+			DefaultTo:     getEnvAsList("ALERT_EMAIL_TO"),
+			Timeout:       getEnvAsDuration("ALERT_SMTP_TIMEOUT", 10*time.Second),
+			SkipTLSVerify: getEnvAsBool("ALERT_SMTP_SKIP_TLS_VERIFY", false),
+		},
+		Slack: SlackConfig{
+			WebhookURL:     os.Getenv("ALERT_SLACK_WEBHOOK_URL"),
+			DefaultChannel: getEnv("ALERT_SLACK_CHANNEL", "#alerts"),
+			Timeout:        getEnvAsDuration("ALERT_SLACK_TIMEOUT", 10*time.Second),
+		},
+		Webhook: WebhookConfig{
+			DefaultURL:  os.Getenv("ALERT_WEBHOOK_URL"),
+			Secret:      os.Getenv("ALERT_WEBHOOK_SECRET"),
+			Timeout:     getEnvAsDuration("ALERT_WEBHOOK_TIMEOUT", 10*time.Second),
+			MaxRetries:  getEnvAsInt("ALERT_WEBHOOK_MAX_RETRIES", 3),
+			BaseBackoff: getEnvAsDuration("ALERT_WEBHOOK_BACKOFF", time.Second),
+		},
+		PagerDuty: PagerDutyConfig{
+			RoutingKey: os.Getenv("ALERT_PAGERDUTY_ROUTING_KEY"),
+			EventsURL:  getEnv("ALERT_PAGERDUTY_EVENTS_URL", pagerDutyEventsURL),
+			Source:     getEnv("ALERT_PAGERDUTY_SOURCE", "rideshare-platform"),
+			Timeout:    getEnvAsDuration("ALERT_PAGERDUTY_TIMEOUT", 10*time.Second),
+		},
+	}
+}
+
+// EmailChannel sends alerts via SMTP
+type EmailChannel struct {
+	config EmailConfig
+}
+
+// NewEmailChannel creates an email channel
+func NewEmailChannel(config EmailConfig) *EmailChannel {
+	if config.SMTPPort == 0 {
+		config.SMTPPort = 587
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 10 * time.Second
+	}
+	return &EmailChannel{config: config}
+}
+
+// Send emails the alert to the target address, or to the default recipients
+// when the action has no target. STARTTLS is used whenever the server offers it.
+func (ec *EmailChannel) Send(ctx context.Context, alert *Alert, target string) error {
+	recipients := ec.config.DefaultTo
+	if target != "" {
+		recipients = splitList(target)
+	}
+	if len(recipients) == 0 {
+		return fmt.Errorf("no email recipients for alert %s", alert.ID)
+	}
+
+	from, err := mail.ParseAddress(ec.config.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender address: %w", err)
+	}
+
+	deadline := time.Now().Add(ec.config.Timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	addr := net.JoinHostPort(ec.config.SMTPHost, strconv.Itoa(ec.config.SMTPPort))
+	dialer := &net.Dialer{Deadline: deadline}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
+	conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, ec.config.SMTPHost)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start SMTP session: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(ec.tlsConfig()); err != nil {
+			return fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+	if ec.config.Username != "" {
+		auth := smtp.PlainAuth("", ec.config.Username, ec.config.Password, ec.config.SMTPHost)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("SMTP authentication failed: %w", err)
+		}
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return err
+	}
+	for _, rcpt := range recipients {
+		if err := client.Rcpt(rcpt); err != nil {
+			return fmt.Errorf("recipient %s rejected: %w", rcpt, err)
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(ec.buildMessage(alert, recipients)); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+func (ec *EmailChannel) tlsConfig() *tls.Config {
+	return &tls.Config{
+		ServerName:         ec.config.SMTPHost,
+		InsecureSkipVerify: ec.config.SkipTLSVerify,
+	}
+}
+
+func (ec *EmailChannel) buildMessage(alert *Alert, recipients []string) []byte {
+	subject := fmt.Sprintf("[%s] %s", strings.ToUpper(string(alert.Severity)), alert.Title)
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", ec.config.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(recipients, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", headerValue(subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&msg, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	fmt.Fprintf(&msg, "Alert: %s\r\n\r\nDescription: %s\r\n\r\nService: %s\r\nSeverity: %s\r\nRule: %s\r\nCreated: %s\r\n",
+		alert.Title, alert.Description, alert.Service, alert.Severity, alert.RuleID,
+		alert.CreatedAt.Format(time.RFC3339))
+	return msg.Bytes()
+}
+
+func (ec *EmailChannel) GetType() string {
+	return "email"
+}
+
+// headerValue makes text safe to use as a mail header value. Line breaks,
+// which would start a new header, are folded into spaces and non-ASCII text
+// is encoded as an RFC 2047 encoded-word.
+func headerValue(text string) string {
+	return mime.QEncoding.Encode("UTF-8", strings.Join(strings.Fields(text), " "))
+}
+
+// SlackChannel posts alerts to a Slack incoming webhook
+type SlackChannel struct {
+	config SlackConfig
+	client *http.Client
+}
+
+// NewSlackChannel creates a Slack channel
+func NewSlackChannel(config SlackConfig) *SlackChannel {
+	if config.Timeout == 0 {
+		config.Timeout = 10 * time.Second
+	}
+	return &SlackChannel{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+// slackMessage is the incoming webhook payload using Block Kit
+type slackMessage struct {
+	Channel string       `json:"channel,omitempty"`
+	Text    string       `json:"text"`
+	Blocks  []slackBlock `json:"blocks"`
+}
+
+type slackBlock struct {
+	Type     string      `json:"type"`
+	Text     *slackText  `json:"text,omitempty"`
+	Fields   []slackText `json:"fields,omitempty"`
+	Elements []slackText `json:"elements,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// Send posts the alert to the target channel, or the default channel when the action has no target
+func (sc *SlackChannel) Send(ctx context.Context, alert *Alert, target string) error {
+	channel := sc.config.DefaultChannel
+	if target != "" {
+		channel = target
+	}
+
+	payload, err := json.Marshal(sc.buildMessage(alert, channel))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sc.config.WebhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := sc.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("slack webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("slack webhook returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+func (sc *SlackChannel) buildMessage(alert *Alert, channel string) *slackMessage {
+	severity := strings.ToUpper(string(alert.Severity))
+	text := fmt.Sprintf("%s %s alert: %s", severityEmoji(alert.Severity), severity, alert.Title)
+
+	return &slackMessage{
+		Channel: channel,
+		Text:    text,
+		Blocks: []slackBlock{
+			{Type: "header", Text: &slackText{Type: "plain_text", Text: text}},
+			{Type: "section", Text: &slackText{Type: "mrkdwn", Text: alert.Description}},
+			{Type: "section", Fields: []slackText{
+				{Type: "mrkdwn", Text: fmt.Sprintf("*Severity:*\n%s", severity)},
+				{Type: "mrkdwn", Text: fmt.Sprintf("*Service:*\n%s", orDefault(alert.Service, "platform"))},
+				{Type: "mrkdwn", Text: fmt.Sprintf("*Rule:*\n%s", alert.RuleID)},
+				{Type: "mrkdwn", Text: fmt.Sprintf("*Status:*\n%s", alert.Status)},
+			}},
+			{Type: "context", Elements: []slackText{
+				{Type: "mrkdwn", Text: fmt.Sprintf("Alert %s fired at %s", alert.ID, alert.CreatedAt.Format(time.RFC3339))},
+			}},
+		},
+	}
+}
+
+func (sc *SlackChannel) GetType() string {
+	return "slack"
+}
+
+func severityEmoji(severity AlertSeverity) string {
+	switch severity {
+	case SeverityCritical:
+		return ":rotating_light:"
+	case SeverityWarning:
+		return ":warning:"
+	default:
+		return ":information_source:"
+	}
+}
+
+// WebhookChannel delivers alerts as signed JSON to an HTTP endpoint
+type WebhookChannel struct {
+	config   WebhookConfig
+	client   *http.Client
+	redis    redis.UniversalClient
+	inFlight chan struct{}
+	wg       sync.WaitGroup
+}
+
+// DeadLetter is a webhook delivery that failed after every retry
+type DeadLetter struct {
+	AlertID  string          `json:"alert_id"`
+	URL      string          `json:"url"`
+	Payload  json.RawMessage `json:"payload"`
+	Attempts int             `json:"attempts"`
+	Error    string          `json:"error"`
+	FailedAt time.Time       `json:"failed_at"`
+}
+
+// NewWebhookChannel creates a webhook channel. Deliveries that exhaust their
+// retries are dead-lettered to Redis when a client is given.
+func NewWebhookChannel(config WebhookConfig, redis redis.UniversalClient) *WebhookChannel {
+	if config.Timeout == 0 {
+		config.Timeout = 10 * time.Second
+	}
+	if config.BaseBackoff == 0 {
+		config.BaseBackoff = time.Second
+	}
+	return &WebhookChannel{
+		config:   config,
+		client:   &http.Client{Timeout: config.Timeout},
+		redis:    redis,
+		inFlight: make(chan struct{}, maxWebhookDeliveries),
+	}
+}
+
+// Send posts the alert to the target URL, or the default URL when the action
+// has no target. The body is signed with HMAC-SHA256 in the X-Alert-Signature
+// header. Delivery happens in the background so its retries do not hold up
+// alert evaluation: 5xx, 429 and network errors are retried with exponential
+// backoff, and deliveries that still fail are dead-lettered, as are alerts
+// sent while maxWebhookDeliveries are already in flight.
+func (wc *WebhookChannel) Send(ctx context.Context, alert *Alert, target string) error {
+	url := wc.config.DefaultURL
+	if target != "" {
+		url = target
+	}
+
+	payload, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case wc.inFlight <- struct{}{}:
+	default:
+		err := fmt.Errorf("%d webhook deliveries already in flight", maxWebhookDeliveries)
+		wc.deadLetter(ctx, &DeadLetter{
+			AlertID:  alert.ID,
+			URL:      url,
+			Payload:  payload,
+			Error:    err.Error(),
+			FailedAt: time.Now(),
+		})
+		return err
+	}
+
+	wc.wg.Add(1)
+	go func() {
+		defer func() {
+			<-wc.inFlight
+			wc.wg.Done()
+		}()
+		// The delivery outlives the evaluation that raised the alert
+		wc.deliverWithRetries(context.WithoutCancel(ctx), url, alert.ID, payload)
+	}()
+	return nil
+}
+
+// Wait blocks until the deliveries in flight have finished
+func (wc *WebhookChannel) Wait() {
+	wc.wg.Wait()
+}
+
+// deliverWithRetries delivers a payload, retrying failures worth retrying,
+// and dead-letters it when every attempt fails
+func (wc *WebhookChannel) deliverWithRetries(ctx context.Context, url, alertID string, payload []byte) error {
+	attempts := 0
+	for {
+		attempts++
+		retryable, err := wc.deliver(ctx, url, alertID, payload)
+		if err == nil {
+			return nil
+		}
+		if !retryable || attempts > wc.config.MaxRetries {
+			wc.deadLetter(ctx, &DeadLetter{
+				AlertID:  alertID,
+				URL:      url,
+				Payload:  payload,
+				Attempts: attempts,
+				Error:    err.Error(),
+				FailedAt: time.Now(),
+			})
+			return fmt.Errorf("webhook delivery failed after %d attempts: %w", attempts, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wc.config.BaseBackoff * time.Duration(1<<(attempts-1))):
+		}
+	}
+}
+
+// deliver makes one delivery attempt, reporting whether a failure is worth retrying
+func (wc *WebhookChannel) deliver(ctx context.Context, url, alertID string, payload []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return false, err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Alert-ID", alertID)
+	req.Header.Set("X-Alert-Timestamp", timestamp)
+	if wc.config.Secret != "" {
+		req.Header.Set("X-Alert-Signature", "sha256="+SignPayload(wc.config.Secret, timestamp, payload))
+	}
+
+	resp, err := wc.client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retryable := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+	return retryable, fmt.Errorf("webhook returned %d", resp.StatusCode)
+}
+
+func (wc *WebhookChannel) deadLetter(ctx context.Context, letter *DeadLetter) {
+	if wc.redis == nil {
+		return
+	}
+	data, err := json.Marshal(letter)
+	if err != nil {
+		return
+	}
+	// The delivery context may already be cancelled, dead-lettering must still happen
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	pipe := wc.redis.TxPipeline()
+	pipe.LPush(ctx, deadLetterKey, data)
+	pipe.LTrim(ctx, deadLetterKey, 0, maxDeadLetters-1)
+	pipe.Exec(ctx)
+}
+
+func (wc *WebhookChannel) GetType() string {
+	return "webhook"
+}
+
+// SignPayload computes the hex HMAC-SHA256 of "timestamp.payload". Receivers
+// recompute it with the shared secret to verify X-Alert-Signature.
+func SignPayload(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Helper functions for environment variable parsing
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvAsInt(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if intValue, err := strconv.Atoi(value); err == nil {
+			return intValue
+		}
+	}
+	return defaultValue
+}
+
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
+func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if duration, err := time.ParseDuration(value); err == nil {
+			return duration
+		}
+	}
+	return defaultValue
+}
+
+func getEnvAsList(key string) []string {
+	return splitList(os.Getenv(key))
+}
+
+func splitList(value string) []string {
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func orDefault(value, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
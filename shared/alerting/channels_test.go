@@ -0,0 +1,325 @@
+package alerting
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAlert() *Alert {
+	return &Alert{
+		ID:          "high_error_rate_1",
+		RuleID:      "high_error_rate",
+		Severity:    SeverityCritical,
+		Title:       "High Error Rate",
+		Description: "Error rate exceeds acceptable threshold",
+		Service:     "trip-service",
+		Status:      StatusActive,
+		CreatedAt:   time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestEmailChannel_BuildMessage(t *testing.T) {
+	channel := NewEmailChannel(EmailConfig{From: "RideShare Alerts <alerts@rideshare.com>"})
+	alert := testAlert()
+
+	msg, err := mail.ReadMessage(strings.NewReader(string(channel.buildMessage(alert, []string{"oncall@rideshare.com", "ops@rideshare.com"}))))
+	require.NoError(t, err)
+	assert.Equal(t, "RideShare Alerts <alerts@rideshare.com>", msg.Header.Get("From"))
+	assert.Equal(t, "oncall@rideshare.com, ops@rideshare.com", msg.Header.Get("To"))
+	assert.Equal(t, "[CRITICAL] High Error Rate", msg.Header.Get("Subject"))
+	assert.Equal(t, "text/plain; charset=UTF-8", msg.Header.Get("Content-Type"))
+
+	body, err := io.ReadAll(msg.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "Description: Error rate exceeds acceptable threshold")
+	assert.Contains(t, string(body), "Rule: high_error_rate")
+	assert.Contains(t, string(body), "Created: 2024-05-01T12:00:00Z")
+}
+
+func TestEmailChannel_BuildMessageSanitisesTheSubject(t *testing.T) {
+	channel := NewEmailChannel(EmailConfig{From: "alerts@rideshare.com"})
+	decoder := new(mime.WordDecoder)
+
+	tests := []struct {
+		title   string
+		subject string
+	}{
+		// A title cannot smuggle in headers of its own
+		{"Outage\r\nBcc: attacker@example.com", "[CRITICAL] Outage Bcc: attacker@example.com"},
+		{"Outage\nX-Priority: 1", "[CRITICAL] Outage X-Priority: 1"},
+		// Non-ASCII titles are encoded
+		{"Sürücü eşleşmesi durdu", "[CRITICAL] Sürücü eşleşmesi durdu"},
+	}
+	for _, tt := range tests {
+		alert := testAlert()
+		alert.Title = tt.title
+
+		msg, err := mail.ReadMessage(strings.NewReader(string(channel.buildMessage(alert, []string{"oncall@rideshare.com"}))))
+		require.NoError(t, err, tt.title)
+		assert.Empty(t, msg.Header.Get("Bcc"), tt.title)
+		assert.Empty(t, msg.Header.Get("X-Priority"), tt.title)
+
+		raw := msg.Header.Get("Subject")
+		for _, r := range raw {
+			assert.Less(t, r, rune(128), "the raw header is ASCII: %q", raw)
+		}
+		subject, err := decoder.DecodeHeader(raw)
+		require.NoError(t, err)
+		assert.Equal(t, tt.subject, subject)
+	}
+}
+
+func TestSlackChannel_PostsBlockKit(t *testing.T) {
+	var received slackMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	channel := NewSlackChannel(SlackConfig{WebhookURL: server.URL, DefaultChannel: "#alerts"})
+	require.NoError(t, channel.Send(context.Background(), testAlert(), "#payments"))
+
+	assert.Equal(t, "#payments", received.Channel)
+	assert.Equal(t, ":rotating_light: CRITICAL alert: High Error Rate", received.Text)
+	require.Len(t, received.Blocks, 4)
+	assert.Equal(t, "header", received.Blocks[0].Type)
+	assert.Equal(t, "plain_text", received.Blocks[0].Text.Type)
+	assert.Equal(t, received.Text, received.Blocks[0].Text.Text)
+	assert.Equal(t, "Error rate exceeds acceptable threshold", received.Blocks[1].Text.Text)
+	assert.Equal(t, []slackText{
+		{Type: "mrkdwn", Text: "*Severity:*\nCRITICAL"},
+		{Type: "mrkdwn", Text: "*Service:*\ntrip-service"},
+		{Type: "mrkdwn", Text: "*Rule:*\nhigh_error_rate"},
+		{Type: "mrkdwn", Text: "*Status:*\nactive"},
+	}, received.Blocks[2].Fields)
+	assert.Equal(t, "context", received.Blocks[3].Type)
+	assert.Equal(t, "Alert high_error_rate_1 fired at 2024-05-01T12:00:00Z", received.Blocks[3].Elements[0].Text)
+
+	// Without a target the default channel is used
+	require.NoError(t, channel.Send(context.Background(), testAlert(), ""))
+	assert.Equal(t, "#alerts", received.Channel)
+}
+
+func TestSlackChannel_ReportsRejectedPosts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("channel_not_found"))
+	}))
+	defer server.Close()
+
+	err := NewSlackChannel(SlackConfig{WebhookURL: server.URL}).Send(context.Background(), testAlert(), "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "404")
+	assert.Contains(t, err.Error(), "channel_not_found")
+}
+
+func TestSignPayload(t *testing.T) {
+	payload := []byte(`{"id":"a1"}`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte(`1700000000.{"id":"a1"}`))
+	assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), SignPayload("secret", "1700000000", payload))
+
+	assert.NotEqual(t, SignPayload("secret", "1700000000", payload), SignPayload("secret", "1700000001", payload),
+		"the timestamp is signed so deliveries cannot be replayed later")
+	assert.NotEqual(t, SignPayload("secret", "1700000000", payload), SignPayload("other", "1700000000", payload))
+}
+
+// deadLetterRecorder stands in for Redis, keeping the dead letters a webhook
+// channel pushes
+type deadLetterRecorder struct {
+	mu      sync.Mutex
+	letters []*DeadLetter
+}
+
+func (d *deadLetterRecorder) DialHook(next redis.DialHook) redis.DialHook { return next }
+
+func (d *deadLetterRecorder) ProcessHook(next redis.ProcessHook) redis.ProcessHook { return next }
+
+func (d *deadLetterRecorder) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		for _, cmd := range cmds {
+			args := cmd.Args()
+			if cmd.Name() != "lpush" || args[1] != deadLetterKey {
+				continue
+			}
+			var letter DeadLetter
+			if err := json.Unmarshal(args[2].([]byte), &letter); err == nil {
+				d.letters = append(d.letters, &letter)
+			}
+		}
+		return nil
+	}
+}
+
+func (d *deadLetterRecorder) received() []*DeadLetter {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]*DeadLetter(nil), d.letters...)
+}
+
+func newTestWebhookChannel(t *testing.T, url string) (*WebhookChannel, *deadLetterRecorder) {
+	recorder := &deadLetterRecorder{}
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:0"})
+	client.AddHook(recorder)
+	t.Cleanup(func() { client.Close() })
+
+	return NewWebhookChannel(WebhookConfig{
+		DefaultURL:  url,
+		Secret:      "webhook-secret",
+		MaxRetries:  3,
+		BaseBackoff: time.Millisecond,
+	}, client), recorder
+}
+
+func TestWebhookChannel_SignsDeliveries(t *testing.T) {
+	var delivered atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "high_error_rate_1", r.Header.Get("X-Alert-ID"))
+		signature := "sha256=" + SignPayload("webhook-secret", r.Header.Get("X-Alert-Timestamp"), body)
+		assert.Equal(t, signature, r.Header.Get("X-Alert-Signature"))
+
+		var alert Alert
+		assert.NoError(t, json.Unmarshal(body, &alert))
+		assert.Equal(t, "High Error Rate", alert.Title)
+		delivered.Store(true)
+	}))
+	defer server.Close()
+
+	channel, deadLetters := newTestWebhookChannel(t, server.URL)
+	require.NoError(t, channel.Send(context.Background(), testAlert(), ""))
+	channel.Wait()
+	assert.True(t, delivered.Load())
+	assert.Empty(t, deadLetters.received())
+}
+
+func TestWebhookChannel_SendDoesNotWaitForDelivery(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+
+	channel, _ := newTestWebhookChannel(t, server.URL)
+	ctx, cancel := context.WithCancel(context.Background())
+	sent := make(chan error, 1)
+	go func() { sent <- channel.Send(ctx, testAlert(), "") }()
+
+	select {
+	case err := <-sent:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Send waited for the endpoint to answer")
+	}
+
+	// Cancelling the evaluation that raised the alert does not abandon it
+	cancel()
+	close(release)
+	channel.Wait()
+}
+
+func TestWebhookChannel_RetriesWithBackoff(t *testing.T) {
+	var mu sync.Mutex
+	var arrivals []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		arrivals = append(arrivals, time.Now())
+		switch len(arrivals) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer server.Close()
+
+	channel, deadLetters := newTestWebhookChannel(t, server.URL)
+	channel.config.BaseBackoff = 20 * time.Millisecond
+	require.NoError(t, channel.Send(context.Background(), testAlert(), ""))
+	channel.Wait()
+
+	require.Len(t, arrivals, 3)
+	assert.GreaterOrEqual(t, arrivals[1].Sub(arrivals[0]), 20*time.Millisecond)
+	assert.GreaterOrEqual(t, arrivals[2].Sub(arrivals[1]), 40*time.Millisecond, "the backoff doubles")
+	assert.Empty(t, deadLetters.received())
+}
+
+func TestWebhookChannel_DeadLettersFailedDeliveries(t *testing.T) {
+	var attempts atomic.Int32
+	status := http.StatusBadGateway
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	channel, deadLetters := newTestWebhookChannel(t, server.URL)
+
+	// Retryable failures are dead-lettered once the retries run out
+	require.NoError(t, channel.Send(context.Background(), testAlert(), ""))
+	channel.Wait()
+	assert.Equal(t, int32(4), attempts.Load())
+	letters := deadLetters.received()
+	require.Len(t, letters, 1)
+	assert.Equal(t, "high_error_rate_1", letters[0].AlertID)
+	assert.Equal(t, server.URL, letters[0].URL)
+	assert.Equal(t, 4, letters[0].Attempts)
+	assert.Equal(t, "webhook returned 502", letters[0].Error)
+	var payload Alert
+	require.NoError(t, json.Unmarshal(letters[0].Payload, &payload))
+	assert.Equal(t, "high_error_rate_1", payload.ID)
+
+	// Client errors are not retried
+	status = http.StatusBadRequest
+	attempts.Store(0)
+	require.NoError(t, channel.Send(context.Background(), testAlert(), server.URL+"/other"))
+	channel.Wait()
+	assert.Equal(t, int32(1), attempts.Load())
+	letters = deadLetters.received()
+	require.Len(t, letters, 2)
+	assert.Equal(t, server.URL+"/other", letters[1].URL)
+	assert.Equal(t, 1, letters[1].Attempts)
+}
+
+func TestWebhookChannel_DeadLettersWhenTooManyAreInFlight(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+
+	channel, deadLetters := newTestWebhookChannel(t, server.URL)
+	for i := 0; i < maxWebhookDeliveries; i++ {
+		require.NoError(t, channel.Send(context.Background(), testAlert(), ""))
+	}
+
+	assert.Error(t, channel.Send(context.Background(), testAlert(), ""))
+	letters := deadLetters.received()
+	require.Len(t, letters, 1)
+	assert.Zero(t, letters[0].Attempts)
+
+	close(release)
+	channel.Wait()
+}
@@ -0,0 +1,172 @@
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// pagerDutyEventsURL is the PagerDuty Events API v2 endpoint
+const pagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// EscalationChannel is a notification channel that pages on-call staff. It is
+// used automatically for critical alerts and is told when they resolve so the
+// incident closes without manual intervention.
+type EscalationChannel interface {
+	NotificationChannel
+	Resolve(ctx context.Context, alert *Alert) error
+}
+
+// PagerDutyConfig configures PagerDuty Events API v2 delivery
+type PagerDutyConfig struct {
+	RoutingKey string
+	EventsURL  string
+	Source     string
+	Timeout    time.Duration
+}
+
+// DedupKey returns the incident deduplication key for an alert. It is derived
+// from the rule so repeated firings of one rule update a single incident.
+// Alerts that each stand for a separate incident, such as one SOS per trip,
+// set IncidentKey so they page and resolve on their own.
+func DedupKey(alert *Alert) string {
+	if alert.IncidentKey != "" {
+		return "rideshare/" + alert.RuleID + "/" + alert.IncidentKey
+	}
+	return "rideshare/" + alert.RuleID
+}
+
+// PagerDutyChannel triggers and resolves PagerDuty incidents
+type PagerDutyChannel struct {
+	config PagerDutyConfig
+	client *http.Client
+}
+
+// NewPagerDutyChannel creates a PagerDuty channel
+func NewPagerDutyChannel(config PagerDutyConfig) *PagerDutyChannel {
+	if config.EventsURL == "" {
+		config.EventsURL = pagerDutyEventsURL
+	}
+	if config.Source == "" {
+		config.Source = "rideshare-platform"
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 10 * time.Second
+	}
+	return &PagerDutyChannel{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout},
+	}
+}
+
+// pagerDutyEvent is an Events API v2 request body
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"` // trigger, resolve
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string                 `json:"summary"`
+	Source        string                 `json:"source"`
+	Severity      string                 `json:"severity"` // critical, error, warning, info
+	Timestamp     string                 `json:"timestamp"`
+	Component     string                 `json:"component,omitempty"`
+	Class         string                 `json:"class,omitempty"`
+	CustomDetails map[string]interface{} `json:"custom_details,omitempty"`
+}
+
+// Send triggers an incident for the alert. target overrides the routing key,
+// so individual rules can page a different service.
+func (pc *PagerDutyChannel) Send(ctx context.Context, alert *Alert, target string) error {
+	details := map[string]interface{}{
+		"alert_id":    alert.ID,
+		"description": alert.Description,
+	}
+	for k, v := range alert.Metadata {
+		details[k] = v
+	}
+
+	return pc.post(ctx, &pagerDutyEvent{
+		RoutingKey:  pc.routingKey(target),
+		EventAction: "trigger",
+		DedupKey:    DedupKey(alert),
+		Payload: &pagerDutyPayload{
+			Summary:       fmt.Sprintf("[%s] %s", strings.ToUpper(string(alert.Severity)), alert.Title),
+			Source:        orDefault(alert.Service, pc.config.Source),
+			Severity:      pagerDutySeverity(alert.Severity),
+			Timestamp:     alert.CreatedAt.UTC().Format(time.RFC3339),
+			Component:     alert.Service,
+			Class:         alert.RuleID,
+			CustomDetails: details,
+		},
+	})
+}
+
+// Resolve closes the incident opened for the alert. It is sent with the
+// routing key the alert was triggered with, since PagerDuty only matches the
+// dedup key within one integration.
+func (pc *PagerDutyChannel) Resolve(ctx context.Context, alert *Alert) error {
+	return pc.post(ctx, &pagerDutyEvent{
+		RoutingKey:  pc.routingKey(alert.EscalationTarget),
+		EventAction: "resolve",
+		DedupKey:    DedupKey(alert),
+	})
+}
+
+func (pc *PagerDutyChannel) routingKey(target string) string {
+	if target != "" {
+		return target
+	}
+	return pc.config.RoutingKey
+}
+
+func (pc *PagerDutyChannel) post(ctx context.Context, event *pagerDutyEvent) error {
+	if event.RoutingKey == "" {
+		return fmt.Errorf("pagerduty routing key not configured")
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, pc.config.EventsURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := pc.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("pagerduty request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// The Events API answers 202 Accepted for queued events
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("pagerduty returned %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+func (pc *PagerDutyChannel) GetType() string {
+	return "pagerduty"
+}
+
+func pagerDutySeverity(severity AlertSeverity) string {
+	switch severity {
+	case SeverityCritical:
+		return "critical"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "info"
+	}
+}
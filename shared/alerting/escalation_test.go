@@ -0,0 +1,155 @@
+package alerting
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// pagerDutyRecorder is a stand-in for the Events API that keeps every event
+// it receives
+type pagerDutyRecorder struct {
+	mu     sync.Mutex
+	events []pagerDutyEvent
+	status int
+}
+
+func newPagerDutyServer(t *testing.T, status int) (*httptest.Server, *pagerDutyRecorder) {
+	recorder := &pagerDutyRecorder{status: status}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var event pagerDutyEvent
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&event))
+		recorder.mu.Lock()
+		recorder.events = append(recorder.events, event)
+		recorder.mu.Unlock()
+
+		w.WriteHeader(recorder.status)
+		w.Write([]byte(`{"status":"invalid event"}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, recorder
+}
+
+func (r *pagerDutyRecorder) received() []pagerDutyEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]pagerDutyEvent(nil), r.events...)
+}
+
+func TestPagerDutyChannel_TriggerAndResolve(t *testing.T) {
+	server, recorder := newPagerDutyServer(t, http.StatusAccepted)
+	channel := NewPagerDutyChannel(PagerDutyConfig{RoutingKey: "default-key", EventsURL: server.URL})
+	ctx := context.Background()
+
+	alert := &Alert{
+		ID:          "high_error_rate_1",
+		RuleID:      "high_error_rate",
+		Severity:    SeverityCritical,
+		Title:       "High Error Rate",
+		Description: "Error rate exceeds acceptable threshold",
+		Service:     "trip-service",
+		Metadata:    map[string]interface{}{"error_rate": 0.2},
+		CreatedAt:   time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, channel.Send(ctx, alert, ""))
+	require.NoError(t, channel.Resolve(ctx, alert))
+
+	events := recorder.received()
+	require.Len(t, events, 2)
+
+	trigger := events[0]
+	assert.Equal(t, "default-key", trigger.RoutingKey)
+	assert.Equal(t, "trigger", trigger.EventAction)
+	assert.Equal(t, "rideshare/high_error_rate", trigger.DedupKey)
+	require.NotNil(t, trigger.Payload)
+	assert.Equal(t, "[CRITICAL] High Error Rate", trigger.Payload.Summary)
+	assert.Equal(t, "trip-service", trigger.Payload.Source)
+	assert.Equal(t, "critical", trigger.Payload.Severity)
+	assert.Equal(t, "2024-05-01T12:00:00Z", trigger.Payload.Timestamp)
+	assert.Equal(t, "high_error_rate", trigger.Payload.Class)
+	assert.Equal(t, "high_error_rate_1", trigger.Payload.CustomDetails["alert_id"])
+	assert.Equal(t, 0.2, trigger.Payload.CustomDetails["error_rate"])
+
+	resolve := events[1]
+	assert.Equal(t, "default-key", resolve.RoutingKey)
+	assert.Equal(t, "resolve", resolve.EventAction)
+	assert.Equal(t, trigger.DedupKey, resolve.DedupKey)
+	assert.Nil(t, resolve.Payload)
+}
+
+func TestPagerDutyChannel_IncidentKeyPagesSeparately(t *testing.T) {
+	server, recorder := newPagerDutyServer(t, http.StatusAccepted)
+	channel := NewPagerDutyChannel(PagerDutyConfig{RoutingKey: "default-key", EventsURL: server.URL})
+	ctx := context.Background()
+
+	first := &Alert{ID: "sos_1", RuleID: "trip_sos", IncidentKey: "incident-1", Severity: SeverityCritical}
+	second := &Alert{ID: "sos_2", RuleID: "trip_sos", IncidentKey: "incident-2", Severity: SeverityCritical}
+	require.NoError(t, channel.Send(ctx, first, ""))
+	require.NoError(t, channel.Send(ctx, second, ""))
+	require.NoError(t, channel.Resolve(ctx, first))
+
+	events := recorder.received()
+	require.Len(t, events, 3)
+	assert.Equal(t, "rideshare/trip_sos/incident-1", events[0].DedupKey)
+	assert.Equal(t, "rideshare/trip_sos/incident-2", events[1].DedupKey)
+	assert.Equal(t, "rideshare/trip_sos/incident-1", events[2].DedupKey, "only the first incident is resolved")
+}
+
+func TestPagerDutyChannel_Errors(t *testing.T) {
+	server, recorder := newPagerDutyServer(t, http.StatusBadRequest)
+	ctx := context.Background()
+	alert := &Alert{ID: "a1", RuleID: "high_error_rate", Severity: SeverityCritical}
+
+	err := NewPagerDutyChannel(PagerDutyConfig{RoutingKey: "default-key", EventsURL: server.URL}).Send(ctx, alert, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "400")
+	assert.Contains(t, err.Error(), "invalid event")
+
+	// Without a routing key nothing is sent
+	unconfigured := NewPagerDutyChannel(PagerDutyConfig{EventsURL: server.URL})
+	assert.Error(t, unconfigured.Send(ctx, alert, ""))
+	assert.Error(t, unconfigured.Resolve(ctx, alert))
+	assert.Len(t, recorder.received(), 1)
+}
+
+func TestAlertManager_ResolvesWithTheRoutingKeyItPagedWith(t *testing.T) {
+	server, recorder := newPagerDutyServer(t, http.StatusAccepted)
+	manager := NewAlertManager(nil, logger.NewLogger("error", "test"))
+	manager.SetEscalationChannel(NewPagerDutyChannel(PagerDutyConfig{RoutingKey: "default-key", EventsURL: server.URL}))
+	ctx := context.Background()
+
+	// A rule that pages a different service than the default
+	paged := &Alert{RuleID: "payment_outage", Severity: SeverityCritical, Title: "Payments down"}
+	require.NoError(t, manager.RaiseAlert(ctx, paged, []AlertAction{
+		{Type: "pagerduty", Target: "payments-key", Enabled: true},
+	}))
+	assert.Equal(t, "payments-key", paged.EscalationTarget)
+	require.NoError(t, manager.ResolveRaisedAlert(ctx, paged))
+
+	// A critical alert without a paging action is escalated to the default
+	escalated := &Alert{RuleID: "trip_sos", IncidentKey: "incident-1", Severity: SeverityCritical, Title: "SOS"}
+	require.NoError(t, manager.RaiseAlert(ctx, escalated, nil))
+	assert.Empty(t, escalated.EscalationTarget)
+	require.NoError(t, manager.ResolveRaisedAlert(ctx, escalated))
+
+	events := recorder.received()
+	require.Len(t, events, 4)
+	assert.Equal(t, []string{"trigger", "resolve", "trigger", "resolve"},
+		[]string{events[0].EventAction, events[1].EventAction, events[2].EventAction, events[3].EventAction})
+	assert.Equal(t, "payments-key", events[0].RoutingKey)
+	assert.Equal(t, "payments-key", events[1].RoutingKey, "the incident is resolved on the service it was opened on")
+	assert.Equal(t, "default-key", events[2].RoutingKey)
+	assert.Equal(t, "default-key", events[3].RoutingKey)
+}
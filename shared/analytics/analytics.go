@@ -0,0 +1,181 @@
+// Package analytics keeps hourly rollups of business counters, such as trips
+// completed or matches made, and summarises them over a time range
+package analytics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultRange is the window a summary covers when no range is given
+	DefaultRange = 24 * time.Hour
+	// MaxRange is the longest window a summary may cover
+	MaxRange = 92 * 24 * time.Hour
+)
+
+// ErrInvalidTimeRange is returned for ranges that are malformed, empty or too long
+var ErrInvalidTimeRange = errors.New("invalid time range")
+
+// Counters are named values added up within an hour, and across hours when
+// summarising
+type Counters map[string]float64
+
+// Add adds value to the named counter
+func (c Counters) Add(name string, value float64) {
+	c[name] += value
+}
+
+// Merge adds every counter of other
+func (c Counters) Merge(other Counters) {
+	for name, value := range other {
+		c[name] += value
+	}
+}
+
+// Ratio divides two counters, returning 0 when the denominator is 0
+func (c Counters) Ratio(numerator, denominator string) float64 {
+	if c[denominator] == 0 {
+		return 0
+	}
+	return c[numerator] / c[denominator]
+}
+
+// Rollup is one source's counters for the hour starting at Hour (UTC)
+type Rollup struct {
+	Source   string    `json:"source"`
+	Hour     time.Time `json:"hour"`
+	Counters Counters  `json:"counters"`
+}
+
+// Store persists hourly rollups
+type Store interface {
+	// Add merges counters into the source's rollup for the hour
+	Add(ctx context.Context, source string, hour time.Time, counters Counters) error
+	// Range returns the source's rollups with from <= hour < to, oldest first
+	Range(ctx context.Context, source string, from, to time.Time) ([]*Rollup, error)
+}
+
+// HourOf returns the start of the UTC hour t falls in
+func HourOf(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Hour)
+}
+
+// TimeRange is the half-open interval [From, To)
+type TimeRange struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// ParseTimeRange parses from and to query parameters, given as RFC3339
+// timestamps or YYYY-MM-DD dates; a plain to date covers the whole day. A
+// missing to means now and a missing from means DefaultRange before to.
+func ParseTimeRange(from, to string, now time.Time) (TimeRange, error) {
+	r := TimeRange{To: now.UTC()}
+	if to != "" {
+		parsed, dateOnly, err := parseTime(to)
+		if err != nil {
+			return TimeRange{}, fmt.Errorf("%w: to %v", ErrInvalidTimeRange, err)
+		}
+		r.To = parsed
+		if dateOnly {
+			r.To = parsed.AddDate(0, 0, 1)
+		}
+	}
+	r.From = r.To.Add(-DefaultRange)
+	if from != "" {
+		parsed, _, err := parseTime(from)
+		if err != nil {
+			return TimeRange{}, fmt.Errorf("%w: from %v", ErrInvalidTimeRange, err)
+		}
+		r.From = parsed
+	}
+
+	if !r.From.Before(r.To) {
+		return TimeRange{}, fmt.Errorf("%w: from must be before to", ErrInvalidTimeRange)
+	}
+	if r.To.Sub(r.From) > MaxRange {
+		return TimeRange{}, fmt.Errorf("%w: ranges are limited to %d days", ErrInvalidTimeRange, int(MaxRange.Hours()/24))
+	}
+	return r, nil
+}
+
+// parseTime reads an RFC3339 timestamp or a YYYY-MM-DD date, reporting which
+func parseTime(value string) (time.Time, bool, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), false, nil
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("must be an RFC3339 timestamp or YYYY-MM-DD date")
+	}
+	return t, true, nil
+}
+
+// Summary adds up a source's rollups over a time range. Rollups are hourly, so
+// the hours containing From and To are counted whole.
+type Summary struct {
+	Source string    `json:"source"`
+	From   time.Time `json:"from"`
+	To     time.Time `json:"to"`
+	Totals Counters  `json:"totals"`
+	Hours  []*Rollup `json:"hours"`
+}
+
+// Summarize reads the source's rollups for the range and adds them up
+func Summarize(ctx context.Context, store Store, source string, r TimeRange) (*Summary, error) {
+	rollups, err := store.Range(ctx, source, HourOf(r.From), r.To)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s rollups: %w", source, err)
+	}
+
+	summary := &Summary{
+		Source: source,
+		From:   r.From,
+		To:     r.To,
+		Totals: make(Counters),
+		Hours:  rollups,
+	}
+	for _, rollup := range rollups {
+		summary.Totals.Merge(rollup.Counters)
+	}
+	return summary, nil
+}
+
+// BusiestHours returns the UTC hours of the day with the highest total of
+// the named counter across the summary, busiest first
+func (s *Summary) BusiestHours(counter string, limit int) []int {
+	var byHour [24]float64
+	for _, rollup := range s.Hours {
+		byHour[rollup.Hour.UTC().Hour()] += rollup.Counters[counter]
+	}
+
+	var hours []int
+	for hour, total := range byHour {
+		if total > 0 {
+			hours = append(hours, hour)
+		}
+	}
+	sort.SliceStable(hours, func(i, j int) bool {
+		return byHour[hours[i]] > byHour[hours[j]]
+	})
+	if limit > 0 && len(hours) > limit {
+		hours = hours[:limit]
+	}
+	return hours
+}
+
+// WithPrefix returns the totals of counters named prefix+key, keyed by key
+func (s *Summary) WithPrefix(prefix string) map[string]float64 {
+	values := make(map[string]float64)
+	for name, value := range s.Totals {
+		if key, found := strings.CutPrefix(name, prefix); found && key != "" {
+			values[key] = value
+		}
+	}
+	return values
+}
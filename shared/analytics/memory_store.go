@@ -0,0 +1,57 @@
+package analytics
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps rollups in memory, for tests and local development
+type MemoryStore struct {
+	rollups map[string]map[time.Time]Counters
+	mutex   sync.RWMutex
+}
+
+// NewMemoryStore creates an empty in-memory rollup store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{rollups: make(map[string]map[time.Time]Counters)}
+}
+
+// Add merges counters into the source's rollup for the hour
+func (s *MemoryStore) Add(ctx context.Context, source string, hour time.Time, counters Counters) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	hours, exists := s.rollups[source]
+	if !exists {
+		hours = make(map[time.Time]Counters)
+		s.rollups[source] = hours
+	}
+	hour = HourOf(hour)
+	if hours[hour] == nil {
+		hours[hour] = make(Counters)
+	}
+	hours[hour].Merge(counters)
+	return nil
+}
+
+// Range returns the source's rollups with from <= hour < to, oldest first
+func (s *MemoryStore) Range(ctx context.Context, source string, from, to time.Time) ([]*Rollup, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var rollups []*Rollup
+	for hour, counters := range s.rollups[source] {
+		if hour.Before(from) || !hour.Before(to) {
+			continue
+		}
+		copied := make(Counters, len(counters))
+		copied.Merge(counters)
+		rollups = append(rollups, &Rollup{Source: source, Hour: hour, Counters: copied})
+	}
+	sort.Slice(rollups, func(i, j int) bool {
+		return rollups[i].Hour.Before(rollups[j].Hour)
+	})
+	return rollups, nil
+}
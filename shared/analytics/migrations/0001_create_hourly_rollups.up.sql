@@ -0,0 +1,12 @@
+-- Hourly business counters per source service. Each counter of an hour is
+-- one row so concurrent flushes can add to it in place.
+CREATE TABLE IF NOT EXISTS analytics_hourly_rollups (
+    source VARCHAR(50) NOT NULL,
+    hour TIMESTAMP WITH TIME ZONE NOT NULL,
+    metric VARCHAR(100) NOT NULL,
+    value DOUBLE PRECISION NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (source, hour, metric)
+);
+
+CREATE INDEX IF NOT EXISTS idx_analytics_hourly_rollups_hour ON analytics_hourly_rollups(hour);
@@ -0,0 +1,104 @@
+package analytics
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/rideshare-platform/shared/analytics/migrations"
+	"github.com/rideshare-platform/shared/database"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// MigrationService is the name the rollup schema's migrations are recorded
+// under. Every service writing rollups shares the one table.
+const MigrationService = "analytics"
+
+// Migrate creates or upgrades the rollup table in db
+func Migrate(ctx context.Context, db *sql.DB, log *logger.Logger) error {
+	schema, err := migrations.Load()
+	if err != nil {
+		return err
+	}
+	_, err = database.NewMigrator(db, MigrationService, schema, log).Up(ctx)
+	return err
+}
+
+// PostgresStore keeps rollups in the analytics_hourly_rollups table
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// NewPostgresStore creates a rollup store backed by PostgreSQL
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+// Add merges counters into the source's rollup for the hour
+func (s *PostgresStore) Add(ctx context.Context, source string, hour time.Time, counters Counters) error {
+	if len(counters) == 0 {
+		return nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin rollup transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO analytics_hourly_rollups (source, hour, metric, value, updated_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (source, hour, metric)
+		DO UPDATE SET value = analytics_hourly_rollups.value + EXCLUDED.value, updated_at = NOW()`
+
+	// Metrics are written in a fixed order so concurrent flushes lock rows alike
+	metrics := make([]string, 0, len(counters))
+	for metric := range counters {
+		metrics = append(metrics, metric)
+	}
+	sort.Strings(metrics)
+
+	hour = HourOf(hour)
+	for _, metric := range metrics {
+		if _, err := tx.ExecContext(ctx, query, source, hour, metric, counters[metric]); err != nil {
+			return fmt.Errorf("failed to add %s rollup: %w", metric, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit rollup: %w", err)
+	}
+	return nil
+}
+
+// Range returns the source's rollups with from <= hour < to, oldest first
+func (s *PostgresStore) Range(ctx context.Context, source string, from, to time.Time) ([]*Rollup, error) {
+	query := `
+		SELECT hour, metric, value FROM analytics_hourly_rollups
+		WHERE source = $1 AND hour >= $2 AND hour < $3
+		ORDER BY hour`
+
+	rows, err := s.db.QueryContext(ctx, query, source, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query rollups: %w", err)
+	}
+	defer rows.Close()
+
+	var rollups []*Rollup
+	for rows.Next() {
+		var hour time.Time
+		var metric string
+		var value float64
+		if err := rows.Scan(&hour, &metric, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan rollup: %w", err)
+		}
+		hour = hour.UTC()
+		if len(rollups) == 0 || !rollups[len(rollups)-1].Hour.Equal(hour) {
+			rollups = append(rollups, &Rollup{Source: source, Hour: hour, Counters: make(Counters)})
+		}
+		rollups[len(rollups)-1].Counters[metric] = value
+	}
+	return rollups, rows.Err()
+}
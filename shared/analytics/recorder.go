@@ -0,0 +1,110 @@
+package analytics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// DefaultFlushInterval is how often a started recorder writes to its store
+const DefaultFlushInterval = 30 * time.Second
+
+// Recorder counts one source's events into hourly buckets in memory and
+// flushes them to a store, keeping store writes off the request path
+type Recorder struct {
+	store   Store
+	source  string
+	logger  *logger.Logger
+	pending map[time.Time]Counters
+	mutex   sync.Mutex
+}
+
+// NewRecorder creates a recorder for the named source
+func NewRecorder(store Store, source string, logger *logger.Logger) *Recorder {
+	return &Recorder{
+		store:   store,
+		source:  source,
+		logger:  logger,
+		pending: make(map[time.Time]Counters),
+	}
+}
+
+// Source returns the name rollups are recorded under
+func (r *Recorder) Source() string {
+	return r.source
+}
+
+// Record adds counters to the hour at falls in
+func (r *Recorder) Record(at time.Time, counters Counters) {
+	if len(counters) == 0 {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	hour := HourOf(at)
+	if r.pending[hour] == nil {
+		r.pending[hour] = make(Counters)
+	}
+	r.pending[hour].Merge(counters)
+}
+
+// Flush writes the buffered counters to the store. Hours that fail to write
+// are kept and retried on the next flush.
+func (r *Recorder) Flush(ctx context.Context) error {
+	r.mutex.Lock()
+	pending := r.pending
+	r.pending = make(map[time.Time]Counters)
+	r.mutex.Unlock()
+
+	var firstErr error
+	for hour, counters := range pending {
+		if err := r.store.Add(ctx, r.source, hour, counters); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			r.Record(hour, counters)
+		}
+	}
+	return firstErr
+}
+
+// Start flushes every interval until ctx is cancelled, then flushes once more
+func (r *Recorder) Start(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultFlushInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			// The service is stopping, give the last flush its own deadline
+			flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			r.flushLogged(flushCtx)
+			cancel()
+			return
+		case <-ticker.C:
+			r.flushLogged(ctx)
+		}
+	}
+}
+
+func (r *Recorder) flushLogged(ctx context.Context) {
+	if err := r.Flush(ctx); err != nil && r.logger != nil {
+		r.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+			"source": r.source,
+		}).Warn("Failed to flush analytics rollups")
+	}
+}
+
+// Summarize flushes what has been recorded so far and summarises the range.
+// A failed flush is logged and leaves the newest counters out of the summary.
+func (r *Recorder) Summarize(ctx context.Context, tr TimeRange) (*Summary, error) {
+	r.flushLogged(ctx)
+	return Summarize(ctx, r.store, r.source, tr)
+}
@@ -0,0 +1,119 @@
+// Package archive moves records that ended longer ago than a retention
+// period out of a service's hot tables. The full record is kept in archive
+// storage, where support can look it up and rehydrate it into the hot table
+// for a while, and a summary row stays queryable for analytics.
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrNotArchived is returned when the dataset has no archived record with the ID
+	ErrNotArchived = errors.New("record is not archived")
+	// ErrUnknownDataset is returned for a dataset that is not registered
+	ErrUnknownDataset = errors.New("unknown archive dataset")
+)
+
+// Record is a record leaving the hot table
+type Record struct {
+	ID string
+	// EndedAt is when the record stopped changing; retention counts from it
+	EndedAt time.Time
+	// Data is the full record, handed back to the dataset on rehydration
+	Data json.RawMessage
+	// Summary holds the fields kept queryable for analytics
+	Summary map[string]interface{}
+}
+
+// Dataset is a hot table whose ended records are archived
+type Dataset interface {
+	// Name names the dataset in archive storage and routes
+	Name() string
+	// Expired returns up to limit records that ended before cutoff with IDs
+	// after afterID, in ID order. Records still in progress are never expired.
+	Expired(ctx context.Context, cutoff time.Time, afterID string, limit int) ([]Record, error)
+	// Purge removes archived records from the hot table
+	Purge(ctx context.Context, ids []string) error
+	// Restore puts an archived record's data back into the hot table
+	Restore(ctx context.Context, data json.RawMessage) error
+}
+
+// ExpiredFunc reads the records that ended before cutoff, in ID order
+type ExpiredFunc func(ctx context.Context, cutoff time.Time, afterID string, limit int) ([]Record, error)
+
+// PurgeFunc removes archived records from the hot table
+type PurgeFunc func(ctx context.Context, ids []string) error
+
+// RestoreFunc puts an archived record's data back into the hot table
+type RestoreFunc func(ctx context.Context, data json.RawMessage) error
+
+type dataset struct {
+	name    string
+	expired ExpiredFunc
+	purge   PurgeFunc
+	restore RestoreFunc
+}
+
+// NewDataset creates a dataset from its functions
+func NewDataset(name string, expired ExpiredFunc, purge PurgeFunc, restore RestoreFunc) Dataset {
+	return &dataset{name: name, expired: expired, purge: purge, restore: restore}
+}
+
+func (d *dataset) Name() string {
+	return d.name
+}
+
+func (d *dataset) Expired(ctx context.Context, cutoff time.Time, afterID string, limit int) ([]Record, error) {
+	return d.expired(ctx, cutoff, afterID, limit)
+}
+
+func (d *dataset) Purge(ctx context.Context, ids []string) error {
+	return d.purge(ctx, ids)
+}
+
+func (d *dataset) Restore(ctx context.Context, data json.RawMessage) error {
+	return d.restore(ctx, data)
+}
+
+// Archived is a record in archive storage
+type Archived struct {
+	Dataset    string                 `json:"dataset"`
+	ID         string                 `json:"id"`
+	EndedAt    time.Time              `json:"ended_at"`
+	ArchivedAt time.Time              `json:"archived_at"`
+	Summary    map[string]interface{} `json:"summary"`
+	// Data is the full record, only read when a single record is looked up
+	Data json.RawMessage `json:"data,omitempty"`
+	// RehydratedUntil is set while a rehydrated copy is back in the hot
+	// table. Runs leave the copy there until the time has passed.
+	RehydratedUntil *time.Time `json:"rehydrated_until,omitempty"`
+}
+
+// SummaryFilter selects summary rows
+type SummaryFilter struct {
+	Dataset string
+	From    time.Time // records that ended at or after From
+	To      time.Time // records that ended before To, when set
+	Limit   int
+	Offset  int
+}
+
+// Store keeps archived records and their summaries
+type Store interface {
+	// Save adds or replaces archived records, lifting any rehydration hold
+	Save(ctx context.Context, dataset string, records []Record, archivedAt time.Time) error
+	// Get returns an archived record with its data
+	Get(ctx context.Context, dataset, id string) (*Archived, error)
+	// Held returns which of the IDs have a rehydrated copy held in the hot
+	// table at now
+	Held(ctx context.Context, dataset string, ids []string, now time.Time) (map[string]bool, error)
+	// Hold marks an archived record as rehydrated until the given time
+	Hold(ctx context.Context, dataset, id string, until time.Time) error
+	// ListSummaries returns matching records without their data, most
+	// recently ended first
+	ListSummaries(ctx context.Context, filter SummaryFilter) ([]*Archived, error)
+}
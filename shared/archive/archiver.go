@@ -0,0 +1,354 @@
+package archive
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	sharedconfig "github.com/rideshare-platform/shared/config"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+const (
+	// DefaultRetention is how long ended records stay in the hot tables
+	DefaultRetention = 90 * 24 * time.Hour
+	// DefaultInterval is how often a started archiver runs
+	DefaultInterval = 24 * time.Hour
+	// DefaultBatchSize is the most records archived per query
+	DefaultBatchSize = 500
+	// DefaultRehydrationTTL is how long a rehydrated record stays in the hot table
+	DefaultRehydrationTTL = 7 * 24 * time.Hour
+)
+
+// Config holds a service's archival settings
+type Config struct {
+	Enabled bool `yaml:"enabled" env:"ARCHIVE_ENABLED" default:"false"`
+	// Retention is how long after it ended a record is archived
+	Retention time.Duration `yaml:"retention" env:"ARCHIVE_RETENTION" default:"2160h"`
+	Interval  time.Duration `yaml:"interval" env:"ARCHIVE_INTERVAL" default:"24h"`
+	BatchSize int           `yaml:"batch_size" env:"ARCHIVE_BATCH_SIZE" default:"500"`
+	// RehydrationTTL is how long a rehydrated record stays in the hot table
+	// before the next run archives it again
+	RehydrationTTL time.Duration `yaml:"rehydration_ttl" env:"ARCHIVE_REHYDRATION_TTL" default:"168h"`
+	// DatabaseURL keeps archived records in PostgreSQL. Without it they are
+	// kept in memory and lost on restart.
+	DatabaseURL string `yaml:"database_url" env:"ARCHIVE_DATABASE_URL"`
+}
+
+// LoadConfig reads the archival settings from the ARCHIVE_* environment
+// variables, for services that do not load their config through the shared loader
+func LoadConfig() (Config, error) {
+	var config Config
+	if err := sharedconfig.NewLoader().WithFile("").Load(&config); err != nil {
+		return Config{}, err
+	}
+	return config, nil
+}
+
+// Validate checks that an enabled config has usable periods
+func (c *Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Retention <= 0 {
+		return fmt.Errorf("ARCHIVE_RETENTION must be positive, got %s", c.Retention)
+	}
+	if c.Interval <= 0 {
+		return fmt.Errorf("ARCHIVE_INTERVAL must be positive, got %s", c.Interval)
+	}
+	if c.BatchSize < 1 {
+		return fmt.Errorf("ARCHIVE_BATCH_SIZE must be at least 1, got %d", c.BatchSize)
+	}
+	if c.RehydrationTTL <= 0 {
+		return fmt.Errorf("ARCHIVE_REHYDRATION_TTL must be positive, got %s", c.RehydrationTTL)
+	}
+	return nil
+}
+
+// Archiver moves the expired records of registered datasets into a store.
+// Runs are serialised, so a slow run is never overlapped by the next one.
+type Archiver struct {
+	store          Store
+	retention      time.Duration
+	rehydrationTTL time.Duration
+	batchSize      int
+	logger         *logger.Logger
+	now            func() time.Time
+	db             *sql.DB
+
+	datasets map[string]Dataset
+	mutex    sync.RWMutex
+	running  sync.Mutex
+}
+
+// NewArchiver creates an archiver moving records that ended more than
+// retention ago, DefaultBatchSize at a time
+func NewArchiver(store Store, retention time.Duration, logger *logger.Logger) *Archiver {
+	if retention <= 0 {
+		retention = DefaultRetention
+	}
+	return &Archiver{
+		store:          store,
+		retention:      retention,
+		rehydrationTTL: DefaultRehydrationTTL,
+		batchSize:      DefaultBatchSize,
+		logger:         logger,
+		now:            time.Now,
+		datasets:       make(map[string]Dataset),
+	}
+}
+
+// Open creates an archiver from config. A PostgreSQL store needs the
+// postgres driver registered by the caller and is migrated on open.
+func Open(ctx context.Context, config Config, log *logger.Logger) (*Archiver, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	var store Store = NewMemoryStore()
+	var db *sql.DB
+	if config.DatabaseURL != "" {
+		var err error
+		db, err = sql.Open("postgres", config.DatabaseURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to archive database: %w", err)
+		}
+		if err := db.PingContext(ctx); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to ping archive database: %w", err)
+		}
+		if err := Migrate(ctx, db, log); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to migrate archive database: %w", err)
+		}
+		store = NewPostgresStore(db)
+	}
+
+	archiver := NewArchiver(store, config.Retention, log)
+	archiver.SetBatchSize(config.BatchSize)
+	archiver.SetRehydrationTTL(config.RehydrationTTL)
+	archiver.db = db
+	return archiver, nil
+}
+
+// Close releases the archive database opened by Open
+func (a *Archiver) Close() error {
+	if a.db == nil {
+		return nil
+	}
+	return a.db.Close()
+}
+
+// SetBatchSize sets the most records archived per query
+func (a *Archiver) SetBatchSize(size int) {
+	if size > 0 {
+		a.batchSize = size
+	}
+}
+
+// SetRehydrationTTL sets how long a rehydrated record stays in the hot table
+func (a *Archiver) SetRehydrationTTL(ttl time.Duration) {
+	if ttl > 0 {
+		a.rehydrationTTL = ttl
+	}
+}
+
+// Register adds datasets to every run
+func (a *Archiver) Register(datasets ...Dataset) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	for _, dataset := range datasets {
+		a.datasets[dataset.Name()] = dataset
+	}
+}
+
+func (a *Archiver) registered() []Dataset {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	datasets := make([]Dataset, 0, len(a.datasets))
+	for _, dataset := range a.datasets {
+		datasets = append(datasets, dataset)
+	}
+	sort.Slice(datasets, func(i, j int) bool {
+		return datasets[i].Name() < datasets[j].Name()
+	})
+	return datasets
+}
+
+func (a *Archiver) dataset(name string) (Dataset, error) {
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	dataset, exists := a.datasets[name]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownDataset, name)
+	}
+	return dataset, nil
+}
+
+// Run archives the expired records of every registered dataset and returns
+// the records moved per dataset. A dataset that fails does not stop the
+// others; its error is returned with the counts of the rest.
+func (a *Archiver) Run(ctx context.Context) (map[string]int, error) {
+	a.running.Lock()
+	defer a.running.Unlock()
+
+	archived := make(map[string]int)
+	var errs []error
+	for _, dataset := range a.registered() {
+		count, err := a.archive(ctx, dataset)
+		archived[dataset.Name()] = count
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", dataset.Name(), err))
+		}
+	}
+	return archived, errors.Join(errs...)
+}
+
+// RunDataset archives the expired records of one dataset
+func (a *Archiver) RunDataset(ctx context.Context, name string) (int, error) {
+	dataset, err := a.dataset(name)
+	if err != nil {
+		return 0, err
+	}
+
+	a.running.Lock()
+	defer a.running.Unlock()
+	return a.archive(ctx, dataset)
+}
+
+// Start runs the archiver every interval until ctx is cancelled
+func (a *Archiver) Start(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := a.Run(ctx); err != nil && ctx.Err() == nil {
+				a.logger.WithContext(ctx).WithError(err).Error("Archival run failed")
+			}
+		}
+	}
+}
+
+// Get returns an archived record with its data, without rehydrating it
+func (a *Archiver) Get(ctx context.Context, dataset, id string) (*Archived, error) {
+	if _, err := a.dataset(dataset); err != nil {
+		return nil, err
+	}
+	return a.store.Get(ctx, dataset, id)
+}
+
+// Rehydrate restores an archived record into the hot table, where it stays
+// until the rehydration TTL has passed. The archived copy is kept, so
+// rehydrating a record again only extends its stay.
+func (a *Archiver) Rehydrate(ctx context.Context, dataset, id string) (*Archived, error) {
+	target, err := a.dataset(dataset)
+	if err != nil {
+		return nil, err
+	}
+	archived, err := a.store.Get(ctx, dataset, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Held first, so a run starting meanwhile cannot purge the restored copy
+	until := a.now().Add(a.rehydrationTTL).UTC()
+	if err := a.store.Hold(ctx, dataset, id, until); err != nil {
+		return nil, err
+	}
+	if err := target.Restore(ctx, archived.Data); err != nil {
+		return nil, fmt.Errorf("failed to restore %s %s: %w", dataset, id, err)
+	}
+	archived.RehydratedUntil = &until
+
+	a.logger.WithContext(ctx).WithFields(logger.Fields{
+		"dataset":          dataset,
+		"record_id":        id,
+		"rehydrated_until": until,
+	}).Info("Rehydrated archived record")
+	return archived, nil
+}
+
+// ListSummaries returns the summary rows of archived records, most recently
+// ended first
+func (a *Archiver) ListSummaries(ctx context.Context, filter SummaryFilter) ([]*Archived, error) {
+	return a.store.ListSummaries(ctx, filter)
+}
+
+// archive moves the dataset's expired records into the store a batch at a
+// time. Records are saved before they are purged, so a failure in between
+// only leaves a record in both places until the next run.
+func (a *Archiver) archive(ctx context.Context, dataset Dataset) (int, error) {
+	now := a.now().UTC()
+	cutoff := now.Add(-a.retention)
+
+	total := 0
+	afterID := ""
+	for {
+		records, err := dataset.Expired(ctx, cutoff, afterID, a.batchSize)
+		if err != nil {
+			return total, fmt.Errorf("failed to read expired records: %w", err)
+		}
+		if len(records) == 0 {
+			break
+		}
+		last := records[len(records)-1].ID
+		if last <= afterID {
+			return total, fmt.Errorf("expired records did not advance past %q", afterID)
+		}
+		afterID = last
+
+		ids := make([]string, len(records))
+		for i, record := range records {
+			ids[i] = record.ID
+		}
+		held, err := a.store.Held(ctx, dataset.Name(), ids, now)
+		if err != nil {
+			return total, err
+		}
+
+		expired := make([]Record, 0, len(records))
+		ids = ids[:0]
+		for _, record := range records {
+			if held[record.ID] {
+				continue
+			}
+			expired = append(expired, record)
+			ids = append(ids, record.ID)
+		}
+		if len(expired) > 0 {
+			if err := a.store.Save(ctx, dataset.Name(), expired, now); err != nil {
+				return total, err
+			}
+			if err := dataset.Purge(ctx, ids); err != nil {
+				return total, fmt.Errorf("failed to purge archived records: %w", err)
+			}
+			total += len(expired)
+		}
+
+		if len(records) < a.batchSize {
+			break
+		}
+	}
+
+	if total > 0 {
+		a.logger.WithContext(ctx).WithFields(logger.Fields{
+			"dataset":  dataset.Name(),
+			"archived": total,
+			"cutoff":   cutoff,
+		}).Info("Archived expired records")
+	}
+	return total, nil
+}
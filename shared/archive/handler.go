@@ -0,0 +1,145 @@
+package archive
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Handler serves archived records: summaries for analytics, lookups and
+// rehydration for support, and on-demand runs
+type Handler struct {
+	archiver     *Archiver
+	requireAdmin func(http.Handler) http.Handler
+}
+
+// NewHandler creates a new archive handler. Every route is an operator
+// action, so each one is wrapped with requireAdmin.
+func NewHandler(archiver *Archiver, requireAdmin func(http.Handler) http.Handler) *Handler {
+	return &Handler{archiver: archiver, requireAdmin: requireAdmin}
+}
+
+// RegisterRoutes registers the archive routes
+func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
+	mux.Handle("GET /api/v1/archive/summaries", h.requireAdmin(http.HandlerFunc(h.ListSummaries)))
+	mux.Handle("POST /api/v1/archive/runs", h.requireAdmin(http.HandlerFunc(h.Run)))
+	mux.Handle("GET /api/v1/archive/{dataset}/{id}", h.requireAdmin(http.HandlerFunc(h.Get)))
+	mux.Handle("POST /api/v1/archive/{dataset}/{id}/rehydrate", h.requireAdmin(http.HandlerFunc(h.Rehydrate)))
+}
+
+// ListSummaries returns the summary rows of archived records, most recently
+// ended first. They can be narrowed to a dataset and to records that ended
+// in an RFC3339 [from, to) range.
+func (h *Handler) ListSummaries(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := SummaryFilter{Dataset: query.Get("dataset")}
+	for name, target := range map[string]*time.Time{"from": &filter.From, "to": &filter.To} {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_request", fmt.Errorf("%s must be an RFC3339 timestamp: %w", name, err))
+			return
+		}
+		*target = parsed
+	}
+	limit, err := strconv.Atoi(query.Get("limit"))
+	if err != nil || limit <= 0 || limit > 1000 {
+		limit = defaultSummaryLimit
+	}
+	filter.Limit = limit
+	if offset, err := strconv.Atoi(query.Get("offset")); err == nil && offset > 0 {
+		filter.Offset = offset
+	}
+
+	summaries, err := h.archiver.ListSummaries(r.Context(), filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal_error", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"summaries": summaries,
+		"count":     len(summaries),
+	})
+}
+
+// Get returns an archived record with its data, leaving it archived
+func (h *Handler) Get(w http.ResponseWriter, r *http.Request) {
+	archived, err := h.archiver.Get(r.Context(), r.PathValue("dataset"), r.PathValue("id"))
+	if err != nil {
+		writeArchiveError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, archived)
+}
+
+// Rehydrate restores an archived record into the hot table for support
+// lookups
+func (h *Handler) Rehydrate(w http.ResponseWriter, r *http.Request) {
+	archived, err := h.archiver.Rehydrate(r.Context(), r.PathValue("dataset"), r.PathValue("id"))
+	if err != nil {
+		writeArchiveError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, archived)
+}
+
+// Run archives now instead of waiting for the next scheduled run, every
+// dataset or the one given as {"dataset": "trips"}
+func (h *Handler) Run(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Dataset string `json:"dataset"`
+	}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_request", err)
+			return
+		}
+	}
+
+	archived := make(map[string]int)
+	var err error
+	if req.Dataset != "" {
+		archived[req.Dataset], err = h.archiver.RunDataset(r.Context(), req.Dataset)
+	} else {
+		archived, err = h.archiver.Run(r.Context())
+	}
+	if errors.Is(err, ErrUnknownDataset) {
+		writeError(w, http.StatusNotFound, "not_found", err)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "archive_failed", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"archived": archived,
+	})
+}
+
+func writeArchiveError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrUnknownDataset), errors.Is(err, ErrNotArchived):
+		writeError(w, http.StatusNotFound, "not_found", err)
+	default:
+		writeError(w, http.StatusInternalServerError, "internal_error", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, code string, err error) {
+	writeJSON(w, status, map[string]string{
+		"error":   code,
+		"message": err.Error(),
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
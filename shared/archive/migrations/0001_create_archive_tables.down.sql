@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS archive_summaries;
+DROP TABLE IF EXISTS archived_records;
@@ -0,0 +1,24 @@
+-- Full archived records, gzip-compressed JSON, read only for support
+-- lookups and rehydration
+CREATE TABLE IF NOT EXISTS archived_records (
+    dataset VARCHAR(100) NOT NULL,
+    id VARCHAR(255) NOT NULL,
+    data BYTEA NOT NULL,
+    ended_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    archived_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    -- Set while a rehydrated copy is back in the hot table
+    rehydrated_until TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (dataset, id)
+);
+
+-- One summary row per archived record, kept for analytics
+CREATE TABLE IF NOT EXISTS archive_summaries (
+    dataset VARCHAR(100) NOT NULL,
+    id VARCHAR(255) NOT NULL,
+    summary JSONB NOT NULL DEFAULT '{}',
+    ended_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    archived_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (dataset, id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_archive_summaries_dataset_ended_at ON archive_summaries(dataset, ended_at DESC);
@@ -0,0 +1,391 @@
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/shared/archive/migrations"
+	"github.com/rideshare-platform/shared/database"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// MigrationService is the name archive storage's migrations are recorded under
+const MigrationService = "archive"
+
+// defaultSummaryLimit caps summary listings that name no limit
+const defaultSummaryLimit = 100
+
+// MemoryStore keeps archived records in memory. They are lost on restart,
+// so it only suits development and tests.
+type MemoryStore struct {
+	records map[string]*Archived
+	mutex   sync.RWMutex
+}
+
+// NewMemoryStore creates an empty in-memory archive
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{records: make(map[string]*Archived)}
+}
+
+func memoryKey(dataset, id string) string {
+	return dataset + "/" + id
+}
+
+// Save adds or replaces archived records, lifting any rehydration hold
+func (s *MemoryStore) Save(ctx context.Context, dataset string, records []Record, archivedAt time.Time) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, record := range records {
+		s.records[memoryKey(dataset, record.ID)] = &Archived{
+			Dataset:    dataset,
+			ID:         record.ID,
+			EndedAt:    record.EndedAt.UTC(),
+			ArchivedAt: archivedAt.UTC(),
+			Summary:    copySummary(record.Summary),
+			Data:       append(json.RawMessage(nil), record.Data...),
+		}
+	}
+	return nil
+}
+
+// Get returns a copy of an archived record with its data
+func (s *MemoryStore) Get(ctx context.Context, dataset, id string) (*Archived, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	archived, exists := s.records[memoryKey(dataset, id)]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s %s", ErrNotArchived, dataset, id)
+	}
+	copied := copyArchived(archived)
+	copied.Data = append(json.RawMessage(nil), archived.Data...)
+	return copied, nil
+}
+
+// Held returns which of the IDs are held in the hot table at now
+func (s *MemoryStore) Held(ctx context.Context, dataset string, ids []string, now time.Time) (map[string]bool, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	held := make(map[string]bool)
+	for _, id := range ids {
+		archived, exists := s.records[memoryKey(dataset, id)]
+		if exists && archived.RehydratedUntil != nil && archived.RehydratedUntil.After(now) {
+			held[id] = true
+		}
+	}
+	return held, nil
+}
+
+// Hold marks an archived record as rehydrated until the given time
+func (s *MemoryStore) Hold(ctx context.Context, dataset, id string, until time.Time) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	archived, exists := s.records[memoryKey(dataset, id)]
+	if !exists {
+		return fmt.Errorf("%w: %s %s", ErrNotArchived, dataset, id)
+	}
+	until = until.UTC()
+	archived.RehydratedUntil = &until
+	return nil
+}
+
+// ListSummaries returns matching records without their data, most recently
+// ended first
+func (s *MemoryStore) ListSummaries(ctx context.Context, filter SummaryFilter) ([]*Archived, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	matched := []*Archived{}
+	for _, archived := range s.records {
+		if filter.Dataset != "" && archived.Dataset != filter.Dataset {
+			continue
+		}
+		if archived.EndedAt.Before(filter.From) {
+			continue
+		}
+		if !filter.To.IsZero() && !archived.EndedAt.Before(filter.To) {
+			continue
+		}
+		matched = append(matched, copyArchived(archived))
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if !matched[i].EndedAt.Equal(matched[j].EndedAt) {
+			return matched[i].EndedAt.After(matched[j].EndedAt)
+		}
+		return matched[i].ID > matched[j].ID
+	})
+
+	if filter.Offset >= len(matched) {
+		return []*Archived{}, nil
+	}
+	matched = matched[filter.Offset:]
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultSummaryLimit
+	}
+	if len(matched) > limit {
+		matched = matched[:limit]
+	}
+	return matched, nil
+}
+
+// copyArchived copies an archived record without its data
+func copyArchived(archived *Archived) *Archived {
+	copied := *archived
+	copied.Data = nil
+	copied.Summary = copySummary(archived.Summary)
+	if archived.RehydratedUntil != nil {
+		until := *archived.RehydratedUntil
+		copied.RehydratedUntil = &until
+	}
+	return &copied
+}
+
+func copySummary(summary map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(summary))
+	for key, value := range summary {
+		copied[key] = value
+	}
+	return copied
+}
+
+// Migrate creates or upgrades the archive tables in db
+func Migrate(ctx context.Context, db *sql.DB, log *logger.Logger) error {
+	schema, err := migrations.Load()
+	if err != nil {
+		return err
+	}
+	_, err = database.NewMigrator(db, MigrationService, schema, log).Up(ctx)
+	return err
+}
+
+// PostgresStore keeps archived records in the archived_records table, with
+// their data gzip-compressed, and their summaries in archive_summaries
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// NewPostgresStore creates an archive backed by PostgreSQL
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+// Save adds or replaces archived records in one transaction, lifting any
+// rehydration hold
+func (s *PostgresStore) Save(ctx context.Context, dataset string, records []Record, archivedAt time.Time) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to save archived records: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, record := range records {
+		data, err := compress(record.Data)
+		if err != nil {
+			return fmt.Errorf("failed to compress %s %s: %w", dataset, record.ID, err)
+		}
+		summary, err := json.Marshal(record.Summary)
+		if err != nil {
+			return fmt.Errorf("failed to encode summary of %s %s: %w", dataset, record.ID, err)
+		}
+
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO archived_records (dataset, id, data, ended_at, archived_at, rehydrated_until)
+			VALUES ($1, $2, $3, $4, $5, NULL)
+			ON CONFLICT (dataset, id) DO UPDATE
+			SET data = EXCLUDED.data, ended_at = EXCLUDED.ended_at,
+				archived_at = EXCLUDED.archived_at, rehydrated_until = NULL`,
+			dataset, record.ID, data, record.EndedAt, archivedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save archived %s %s: %w", dataset, record.ID, err)
+		}
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO archive_summaries (dataset, id, summary, ended_at, archived_at)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (dataset, id) DO UPDATE
+			SET summary = EXCLUDED.summary, ended_at = EXCLUDED.ended_at, archived_at = EXCLUDED.archived_at`,
+			dataset, record.ID, summary, record.EndedAt, archivedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to save summary of %s %s: %w", dataset, record.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to save archived records: %w", err)
+	}
+	return nil
+}
+
+// Get returns an archived record with its data
+func (s *PostgresStore) Get(ctx context.Context, dataset, id string) (*Archived, error) {
+	query := `
+		SELECT r.dataset, r.id, r.ended_at, r.archived_at, r.rehydrated_until, COALESCE(s.summary, '{}'), r.data
+		FROM archived_records r
+		LEFT JOIN archive_summaries s ON s.dataset = r.dataset AND s.id = r.id
+		WHERE r.dataset = $1 AND r.id = $2`
+
+	var data []byte
+	archived, err := scanArchived(s.db.QueryRowContext(ctx, query, dataset, id), &data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s %s", ErrNotArchived, dataset, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get archived record: %w", err)
+	}
+	if archived.Data, err = decompress(data); err != nil {
+		return nil, fmt.Errorf("failed to decompress %s %s: %w", dataset, id, err)
+	}
+	return archived, nil
+}
+
+// Held returns which of the IDs are held in the hot table at now
+func (s *PostgresStore) Held(ctx context.Context, dataset string, ids []string, now time.Time) (map[string]bool, error) {
+	held := make(map[string]bool)
+	if len(ids) == 0 {
+		return held, nil
+	}
+
+	args := []interface{}{dataset, now}
+	placeholders := make([]string, len(ids))
+	for i, id := range ids {
+		args = append(args, id)
+		placeholders[i] = "$" + strconv.Itoa(i+3)
+	}
+	query := `SELECT id FROM archived_records
+		WHERE dataset = $1 AND rehydrated_until > $2 AND id IN (` + strings.Join(placeholders, ", ") + `)`
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rehydration holds: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to read rehydration holds: %w", err)
+		}
+		held[id] = true
+	}
+	return held, rows.Err()
+}
+
+// Hold marks an archived record as rehydrated until the given time
+func (s *PostgresStore) Hold(ctx context.Context, dataset, id string, until time.Time) error {
+	result, err := s.db.ExecContext(ctx,
+		`UPDATE archived_records SET rehydrated_until = $3 WHERE dataset = $1 AND id = $2`,
+		dataset, id, until,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to hold rehydrated record: %w", err)
+	}
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return fmt.Errorf("%w: %s %s", ErrNotArchived, dataset, id)
+	}
+	return nil
+}
+
+// ListSummaries returns matching records without their data, most recently
+// ended first
+func (s *PostgresStore) ListSummaries(ctx context.Context, filter SummaryFilter) ([]*Archived, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultSummaryLimit
+	}
+	var to *time.Time
+	if !filter.To.IsZero() {
+		to = &filter.To
+	}
+	query := `
+		SELECT s.dataset, s.id, s.ended_at, s.archived_at, r.rehydrated_until, s.summary
+		FROM archive_summaries s
+		LEFT JOIN archived_records r ON r.dataset = s.dataset AND r.id = s.id
+		WHERE ($1 = '' OR s.dataset = $1) AND s.ended_at >= $2
+			AND ($3::timestamptz IS NULL OR s.ended_at < $3)
+		ORDER BY s.ended_at DESC, s.id DESC LIMIT $4 OFFSET $5`
+
+	rows, err := s.db.QueryContext(ctx, query, filter.Dataset, filter.From, to, limit, filter.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list archive summaries: %w", err)
+	}
+	defer rows.Close()
+
+	summaries := []*Archived{}
+	for rows.Next() {
+		archived, err := scanArchived(rows, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan archive summary: %w", err)
+		}
+		summaries = append(summaries, archived)
+	}
+	return summaries, rows.Err()
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanArchived scans an archived record, and its compressed data into data
+// when it is not nil
+func scanArchived(row rowScanner, data *[]byte) (*Archived, error) {
+	var archived Archived
+	var rehydratedUntil sql.NullTime
+	var summary []byte
+	dest := []interface{}{
+		&archived.Dataset, &archived.ID, &archived.EndedAt, &archived.ArchivedAt, &rehydratedUntil, &summary,
+	}
+	if data != nil {
+		dest = append(dest, data)
+	}
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	archived.EndedAt = archived.EndedAt.UTC()
+	archived.ArchivedAt = archived.ArchivedAt.UTC()
+	if rehydratedUntil.Valid {
+		until := rehydratedUntil.Time.UTC()
+		archived.RehydratedUntil = &until
+	}
+	if err := json.Unmarshal(summary, &archived.Summary); err != nil {
+		return nil, fmt.Errorf("failed to decode summary: %w", err)
+	}
+	return &archived, nil
+}
+
+func compress(data []byte) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func decompress(data []byte) (json.RawMessage, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
@@ -0,0 +1,172 @@
+// Package awssig signs requests to S3-compatible object stores with AWS
+// Signature Version 4, either in the Authorization header or in a presigned
+// URL's query string.
+package awssig
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	algorithm = "AWS4-HMAC-SHA256"
+	// UnsignedPayload stands in for the payload hash of presigned requests,
+	// whose body is not known when they are signed
+	UnsignedPayload = "UNSIGNED-PAYLOAD"
+)
+
+// Signer signs requests for one service in one region
+type Signer struct {
+	accessKeyID     string
+	secretAccessKey string
+	region          string
+	service         string
+}
+
+// NewS3Signer creates a signer for S3 requests with the given keys
+func NewS3Signer(accessKeyID, secretAccessKey, region string) *Signer {
+	return &Signer{accessKeyID: accessKeyID, secretAccessKey: secretAccessKey, region: region, service: "s3"}
+}
+
+// SignRequest adds the date, payload hash and Authorization headers to req,
+// signing its host, content type and body
+func (s *Signer) SignRequest(req *http.Request, body []byte, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	payloadHash := HashHex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{
+		"host":                 req.URL.Host,
+		"x-amz-content-sha256": payloadHash,
+		"x-amz-date":           amzDate,
+	}
+	if contentType := req.Header.Get("Content-Type"); contentType != "" {
+		headers["content-type"] = contentType
+	}
+	canonicalHeaders, signedHeaders := canonicalizeHeaders(headers)
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.Query().Encode(),
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := s.scope(now)
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		algorithm, s.accessKeyID, scope, signedHeaders, s.signature(now, canonicalRequest)))
+}
+
+// Presign returns target signed in its query string for method, so the
+// request can be made without credentials until ttl has passed. headers are
+// signed and must be sent with the request.
+func (s *Signer) Presign(method string, target *url.URL, headers map[string]string, ttl time.Duration, now time.Time) *url.URL {
+	now = now.UTC()
+
+	signed := map[string]string{"host": target.Host}
+	for name, value := range headers {
+		signed[strings.ToLower(name)] = value
+	}
+	canonicalHeaders, signedHeaders := canonicalizeHeaders(signed)
+
+	query := url.Values{}
+	query.Set("X-Amz-Algorithm", algorithm)
+	query.Set("X-Amz-Credential", s.accessKeyID+"/"+s.scope(now))
+	query.Set("X-Amz-Date", now.Format("20060102T150405Z"))
+	query.Set("X-Amz-Expires", strconv.Itoa(int(ttl.Seconds())))
+	query.Set("X-Amz-SignedHeaders", signedHeaders)
+	// Signature Version 4 encodes spaces as %20, not +
+	canonicalQuery := strings.ReplaceAll(query.Encode(), "+", "%20")
+
+	canonicalRequest := strings.Join([]string{
+		method,
+		target.EscapedPath(),
+		canonicalQuery,
+		canonicalHeaders,
+		signedHeaders,
+		UnsignedPayload,
+	}, "\n")
+
+	presigned := *target
+	presigned.RawQuery = canonicalQuery + "&X-Amz-Signature=" + s.signature(now, canonicalRequest)
+	return &presigned
+}
+
+// scope is the date, region and service the signature is valid for
+func (s *Signer) scope(now time.Time) string {
+	return now.Format("20060102") + "/" + s.region + "/" + s.service + "/aws4_request"
+}
+
+// signature signs the canonical request with a key derived from the secret
+// for the request's date, region and service
+func (s *Signer) signature(now time.Time, canonicalRequest string) string {
+	stringToSign := strings.Join([]string{
+		algorithm,
+		now.Format("20060102T150405Z"),
+		s.scope(now),
+		HashHex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.secretAccessKey), now.Format("20060102"))
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, s.service)
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+// canonicalizeHeaders returns the lowercase headers sorted by name, one
+// name:value line each, and their names joined by semicolons
+func canonicalizeHeaders(headers map[string]string) (string, string) {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonical strings.Builder
+	for _, name := range names {
+		canonical.WriteString(name + ":" + strings.TrimSpace(headers[name]) + "\n")
+	}
+	return canonical.String(), strings.Join(names, ";")
+}
+
+// EscapePath URI-encodes an object path as Signature Version 4 expects:
+// every byte but unreserved characters and the slashes between segments
+func EscapePath(path string) string {
+	var escaped strings.Builder
+	for i := 0; i < len(path); i++ {
+		c := path[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~', c == '/':
+			escaped.WriteByte(c)
+		default:
+			fmt.Fprintf(&escaped, "%%%02X", c)
+		}
+	}
+	return escaped.String()
+}
+
+// HashHex returns the hex-encoded SHA-256 of data
+func HashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -6,7 +6,7 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/go-redis/redis/v8"
+	"github.com/redis/go-redis/v9"
 )
 
 // Cache interface defines caching operations
@@ -20,12 +20,12 @@ type Cache interface {
 
 // RedisCache implements Cache interface using Redis
 type RedisCache struct {
-	client *redis.Client
+	client redis.UniversalClient
 	prefix string
 }
 
 // NewRedisCache creates a new Redis cache instance
-func NewRedisCache(client *redis.Client, prefix string) *RedisCache {
+func NewRedisCache(client redis.UniversalClient, prefix string) *RedisCache {
 	return &RedisCache{
 		client: client,
 		prefix: prefix,
@@ -94,13 +94,34 @@ func (c *RedisCache) Exists(ctx context.Context, key string) (bool, error) {
 func (c *RedisCache) InvalidatePattern(ctx context.Context, pattern string) error {
 	fullPattern := c.getFullKey(pattern)
 
-	keys, err := c.client.Keys(ctx, fullPattern).Result()
+	// A cluster client only sees one node per call, so every master is scanned
+	// and each node deletes its own matches
+	if cluster, ok := c.client.(*redis.ClusterClient); ok {
+		return cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+			return invalidateMatching(ctx, node, fullPattern, func(keys []string) error {
+				_, err := node.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+					for _, key := range keys {
+						pipe.Del(ctx, key)
+					}
+					return nil
+				})
+				return err
+			})
+		})
+	}
+	return invalidateMatching(ctx, c.client, fullPattern, func(keys []string) error {
+		return c.client.Del(ctx, keys...).Err()
+	})
+}
+
+func invalidateMatching(ctx context.Context, client redis.UniversalClient, pattern string, del func([]string) error) error {
+	keys, err := client.Keys(ctx, pattern).Result()
 	if err != nil {
 		return fmt.Errorf("cache keys error: %w", err)
 	}
 
 	if len(keys) > 0 {
-		if err := c.client.Del(ctx, keys...).Err(); err != nil {
+		if err := del(keys); err != nil {
 			return fmt.Errorf("cache invalidate error: %w", err)
 		}
 	}
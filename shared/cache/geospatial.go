@@ -5,17 +5,17 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/go-redis/redis/v8"
+	"github.com/redis/go-redis/v9"
 )
 
 // GeospatialCache handles location-based caching for rideshare platform
 type GeospatialCache struct {
-	client *redis.Client
+	client redis.UniversalClient
 	prefix string
 }
 
 // NewGeospatialCache creates a new geospatial cache
-func NewGeospatialCache(client *redis.Client, prefix string) *GeospatialCache {
+func NewGeospatialCache(client redis.UniversalClient, prefix string) *GeospatialCache {
 	return &GeospatialCache{
 		client: client,
 		prefix: prefix,
@@ -167,12 +167,14 @@ func (c *GeospatialCache) ClearArea(ctx context.Context, key string, lat, lon, r
 	return nil
 }
 
+// getGeoKey and getMetaKey share the {prefix:key} hash tag, so in Redis Cluster
+// an index and the metadata of its members live in the same slot
 func (c *GeospatialCache) getGeoKey(key string) string {
-	return fmt.Sprintf("%s:geo:%s", c.prefix, key)
+	return fmt.Sprintf("{%s:%s}:geo", c.prefix, key)
 }
 
 func (c *GeospatialCache) getMetaKey(key, locationID string) string {
-	return fmt.Sprintf("%s:meta:%s:%s", c.prefix, key, locationID)
+	return fmt.Sprintf("{%s:%s}:meta:%s", c.prefix, key, locationID)
 }
 
 // Cache invalidation patterns for rideshare platform
@@ -0,0 +1,28 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rideshare-platform/shared/database"
+)
+
+func TestGeospatialCacheKeys_ClusterSlots(t *testing.T) {
+	c := NewGeospatialCache(nil, "rideshare")
+
+	// An index and its members' metadata are written together, so they share
+	// a slot
+	for _, index := range []string{"drivers:istanbul", "drivers:ankara"} {
+		slot := database.ClusterSlot(c.getGeoKey(index))
+		for _, locationID := range []string{"driver-1", "driver-2"} {
+			assert.Equal(t, slot, database.ClusterSlot(c.getMetaKey(index, locationID)), index+" "+locationID)
+		}
+	}
+
+	// Separate indexes are tagged separately so they can live on different
+	// nodes
+	assert.NotEqual(t,
+		database.ClusterSlot(c.getGeoKey("drivers:istanbul")),
+		database.ClusterSlot(c.getGeoKey("drivers:ankara")))
+}
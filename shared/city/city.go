@@ -0,0 +1,265 @@
+// Package city partitions the platform by the cities it operates in. Trips,
+// driver locations, surge areas and rate cards are scoped to a city ID, and
+// each city carries its own currency, timezone and operating policies.
+//
+// Cities are configured in a YAML file shared by the services, keyed by ID:
+//
+//	cities:
+//	  istanbul:
+//	    name: Istanbul
+//	    country: TR
+//	    currency: TRY
+//	    timezone: Europe/Istanbul
+//	    center: {latitude: 41.0082, longitude: 28.9784}
+//	    radius_km: 60
+//	    policies:
+//	      max_search_radius_km: 10
+//	      max_surge_multiplier: 3
+//	      max_location_age_seconds: 30
+//	      max_online_hours: 12
+//	      fatigue_window_hours: 24
+//
+// Services read the sections they own from the same file, such as the rate
+// cards pricing-service reads and the compliance report templates
+// trip-service reads.
+package city
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/rideshare-platform/shared/currency"
+	"github.com/rideshare-platform/shared/models"
+)
+
+// ErrUnknownCity is returned for a city ID that is not configured
+var ErrUnknownCity = errors.New("unknown city")
+
+// City is a city the platform operates in
+type City struct {
+	ID       string `yaml:"-" json:"id"`
+	Name     string `yaml:"name" json:"name"`
+	Country  string `yaml:"country" json:"country"`
+	Currency string `yaml:"currency" json:"currency"`
+	Timezone string `yaml:"timezone" json:"timezone"`
+	// Locations within RadiusKm of Center belong to the city. Where cities
+	// overlap, a location belongs to the one with the nearest center.
+	Center   models.Location `yaml:"center" json:"center"`
+	RadiusKm float64         `yaml:"radius_km" json:"radius_km"`
+	Policies Policies        `yaml:"policies" json:"policies"`
+}
+
+// Policies are the operating rules of a city. Zero values leave the
+// service's own default in place.
+type Policies struct {
+	// MaxSearchRadiusKm caps how far from a pickup drivers are searched for
+	MaxSearchRadiusKm float64 `yaml:"max_search_radius_km" json:"max_search_radius_km,omitempty"`
+	// MaxSurgeMultiplier caps the surge fares in the city are charged at
+	MaxSurgeMultiplier float64 `yaml:"max_surge_multiplier" json:"max_surge_multiplier,omitempty"`
+	// MaxSurgeStep is the most an area's surge can rise by in one update
+	MaxSurgeStep float64 `yaml:"max_surge_step" json:"max_surge_step,omitempty"`
+	// MaxLocationAgeSeconds is how old a driver's last location can be for
+	// them to still be found by searches
+	MaxLocationAgeSeconds int `yaml:"max_location_age_seconds" json:"max_location_age_seconds,omitempty"`
+	// MaxOnlineHours caps the hours a driver can be online, trips included
+	// and breaks not, within any FatigueWindowHours
+	MaxOnlineHours float64 `yaml:"max_online_hours" json:"max_online_hours,omitempty"`
+	// MaxDrivingHours caps the hours a driver can spend on trips within any
+	// FatigueWindowHours
+	MaxDrivingHours float64 `yaml:"max_driving_hours" json:"max_driving_hours,omitempty"`
+	// FatigueWindowHours is the rolling window driving-hour limits apply to
+	FatigueWindowHours float64 `yaml:"fatigue_window_hours" json:"fatigue_window_hours,omitempty"`
+}
+
+// Location returns the city's timezone, UTC when it has none
+func (c *City) Location() *time.Location {
+	if c.Timezone == "" {
+		return time.UTC
+	}
+	location, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// Contains reports whether a location is within the city's radius
+func (c *City) Contains(location models.Location) bool {
+	return c.RadiusKm > 0 && location.IsValid() && c.Center.DistanceTo(&location) <= c.RadiusKm
+}
+
+func (c *City) validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("city %s: name is required", c.ID)
+	}
+	code, err := currency.Normalize(c.Currency)
+	if err != nil {
+		return fmt.Errorf("city %s: %w", c.ID, err)
+	}
+	c.Currency = code
+	if c.Timezone != "" {
+		if _, err := time.LoadLocation(c.Timezone); err != nil {
+			return fmt.Errorf("city %s: invalid timezone %q: %w", c.ID, c.Timezone, err)
+		}
+	}
+	if !c.Center.IsValid() {
+		return fmt.Errorf("city %s: center has invalid coordinates", c.ID)
+	}
+	if c.RadiusKm < 0 || c.Policies.MaxSearchRadiusKm < 0 {
+		return fmt.Errorf("city %s: radii must not be negative", c.ID)
+	}
+	if c.Policies.MaxLocationAgeSeconds < 0 {
+		return fmt.Errorf("city %s: max_location_age_seconds must not be negative", c.ID)
+	}
+	if c.Policies.MaxSurgeMultiplier != 0 && c.Policies.MaxSurgeMultiplier < 1 {
+		return fmt.Errorf("city %s: max_surge_multiplier must be at least 1", c.ID)
+	}
+	if c.Policies.MaxSurgeStep < 0 {
+		return fmt.Errorf("city %s: max_surge_step must not be negative", c.ID)
+	}
+	if c.Policies.MaxOnlineHours < 0 || c.Policies.MaxDrivingHours < 0 || c.Policies.FatigueWindowHours < 0 {
+		return fmt.Errorf("city %s: driving-hour limits must not be negative", c.ID)
+	}
+	return nil
+}
+
+// Normalize returns the canonical form of a city ID, which is matched
+// ignoring case and surrounding space
+func Normalize(id string) string {
+	return strings.ToLower(strings.TrimSpace(id))
+}
+
+// Registry holds the configured cities. A nil Registry has no cities:
+// explicit city IDs are accepted as they are and locations belong to none,
+// so services work unpartitioned without a cities file.
+type Registry struct {
+	cities map[string]*City
+}
+
+// Config is the layout of the cities file
+type Config struct {
+	Cities map[string]*City `yaml:"cities"`
+}
+
+// NewRegistry creates a registry of validated cities keyed by ID
+func NewRegistry(config *Config) (*Registry, error) {
+	registry := &Registry{cities: make(map[string]*City, len(config.Cities))}
+	for id, city := range config.Cities {
+		city.ID = Normalize(id)
+		if city.ID == "" {
+			return nil, errors.New("city ID is required")
+		}
+		if _, exists := registry.cities[city.ID]; exists {
+			return nil, fmt.Errorf("city %s is configured twice", city.ID)
+		}
+		if err := city.validate(); err != nil {
+			return nil, err
+		}
+		registry.cities[city.ID] = city
+	}
+	return registry, nil
+}
+
+// Load reads the cities file at path
+func Load(path string) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cities file: %w", err)
+	}
+
+	var config Config
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse cities file %s: %w", path, err)
+	}
+	return NewRegistry(&config)
+}
+
+// Get returns the city with the ID
+func (r *Registry) Get(id string) (*City, error) {
+	if r != nil {
+		if city, exists := r.cities[Normalize(id)]; exists {
+			return city, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownCity, id)
+}
+
+// List returns every city, by ID
+func (r *Registry) List() []*City {
+	if r == nil {
+		return []*City{}
+	}
+	cities := make([]*City, 0, len(r.cities))
+	for _, city := range r.cities {
+		cities = append(cities, city)
+	}
+	sort.Slice(cities, func(i, j int) bool {
+		return cities[i].ID < cities[j].ID
+	})
+	return cities
+}
+
+// Locate returns the city a location belongs to
+func (r *Registry) Locate(location models.Location) (*City, bool) {
+	if r == nil {
+		return nil, false
+	}
+	var nearest *City
+	nearestKm := 0.0
+	for _, city := range r.cities {
+		if !city.Contains(location) {
+			continue
+		}
+		distance := city.Center.DistanceTo(&location)
+		if nearest == nil || distance < nearestKm || (distance == nearestKm && city.ID < nearest.ID) {
+			nearest, nearestKm = city, distance
+		}
+	}
+	return nearest, nearest != nil
+}
+
+// Resolve returns the city a record at location belongs to: the given ID,
+// which must be configured, or the city the location is in. It is empty for
+// locations outside every city.
+func (r *Registry) Resolve(id string, location models.Location) (string, error) {
+	if id = Normalize(id); id != "" {
+		if r == nil {
+			return id, nil
+		}
+		if _, err := r.Get(id); err != nil {
+			return "", err
+		}
+		return id, nil
+	}
+	if city, ok := r.Locate(location); ok {
+		return city.ID, nil
+	}
+	return "", nil
+}
+
+// Policies returns the policies of the city with the ID, none for cities
+// that are not configured
+func (r *Registry) Policies(id string) Policies {
+	city, err := r.Get(id)
+	if err != nil {
+		return Policies{}
+	}
+	return city.Policies
+}
+
+// Currencies returns the currency each city charges in, matched by ID or
+// name, with fallback charged elsewhere
+func (r *Registry) Currencies(fallback string) (*currency.Cities, error) {
+	byCity := make(map[string]string)
+	for _, city := range r.List() {
+		byCity[city.Name] = city.Currency
+		byCity[city.ID] = city.Currency
+	}
+	return currency.NewCities(fallback, byCity)
+}
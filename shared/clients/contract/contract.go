@@ -0,0 +1,131 @@
+// Package contract checks that a service serves the proto definition its
+// callers are generated from. A contract test runs the service's real gRPC
+// handler in memory and makes every call of the generated client against it:
+//
+//	conn := contract.Serve(t, func(server *grpc.Server) {
+//		trippb.RegisterTripServiceServer(server, handler)
+//	})
+//	contract.Check(t, tripclient.ContractCalls(conn))
+//
+// Calls are made with empty requests. A handler may reject them with any
+// error, but must not leave the method unimplemented or panic, so methods
+// added to a proto without a server implementation, or handlers trusting
+// their input, fail the build. Methods known to be unimplemented are listed
+// to Check and must keep returning Unimplemented.
+package contract
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	// callTimeout bounds each call. Streams that stay open until it passes,
+	// such as subscriptions, are served.
+	callTimeout = time.Second
+	// panicMessage marks the errors of handlers that panicked
+	panicMessage = "contract: handler panicked"
+)
+
+// Serve starts a gRPC server in memory with the services register adds and
+// returns a connection to it. Both are closed when the test ends.
+func Serve(t testing.TB, register func(*grpc.Server), opts ...grpc.ServerOption) *grpc.ClientConn {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	opts = append([]grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(recoverUnary),
+		grpc.ChainStreamInterceptor(recoverStream),
+	}, opts...)
+	server := grpc.NewServer(opts...)
+	register(server)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///contract",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to connect to the contract server: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// Check makes every call and fails the test for methods that are not served
+// or whose handler panicked. unimplemented lists the methods the service is
+// known not to serve yet.
+func Check(t *testing.T, calls map[string]func(context.Context) error, unimplemented ...string) {
+	t.Helper()
+
+	expectUnimplemented := make(map[string]bool, len(unimplemented))
+	for _, name := range unimplemented {
+		if _, exists := calls[name]; !exists {
+			t.Errorf("%s is listed as unimplemented but is not a method of the service", name)
+		}
+		expectUnimplemented[name] = true
+	}
+
+	names := make([]string, 0, len(calls))
+	for name := range calls {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		call := calls[name]
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithTimeout(context.Background(), callTimeout)
+			defer cancel()
+
+			err := call(ctx)
+			code := status.Code(err)
+			switch {
+			case expectUnimplemented[name] && code != codes.Unimplemented:
+				t.Errorf("%s is served now, remove it from the unimplemented methods (got %v)", name, err)
+			case expectUnimplemented[name]:
+			case code == codes.Unimplemented:
+				t.Errorf("%s is in the proto but the server does not implement it: %v", name, err)
+			case code == codes.Internal && strings.HasPrefix(status.Convert(err).Message(), panicMessage):
+				t.Errorf("%s panicked on an empty request: %v", name, err)
+			}
+		})
+	}
+}
+
+func recoverUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = panicError(recovered)
+		}
+	}()
+	return handler(ctx, req)
+}
+
+func recoverStream(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = panicError(recovered)
+		}
+	}()
+	return handler(srv, stream)
+}
+
+func panicError(recovered interface{}) error {
+	return status.Error(codes.Internal, fmt.Sprintf("%s: %v", panicMessage, recovered))
+}
@@ -0,0 +1,13 @@
+// Package clients holds the gRPC clients of the platform's services, one
+// package per service, generated from the proto definitions in shared/proto.
+// Each package's Dial connects with the shared client options so calls carry
+// the request context downstream, and its ContractCalls exercise every method
+// of the service for the contract tests services run against their servers
+// (see package contract).
+//
+// The packages are regenerated whenever the protos change:
+//
+//	buf generate && (cd shared && go generate ./clients)
+package clients
+
+//go:generate go run ./gen
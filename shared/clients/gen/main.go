@@ -0,0 +1,290 @@
+// Command gen writes the service clients in shared/clients from the proto
+// definitions compiled into shared/proto. Run it through go generate in
+// shared/clients after regenerating the protos:
+//
+//	buf generate && (cd shared && go generate ./clients)
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	// Every proto package with a service registers its descriptors here
+	_ "github.com/rideshare-platform/shared/proto/geo"
+	_ "github.com/rideshare-platform/shared/proto/matching"
+	_ "github.com/rideshare-platform/shared/proto/payment"
+	_ "github.com/rideshare-platform/shared/proto/pricing"
+	_ "github.com/rideshare-platform/shared/proto/trip"
+	_ "github.com/rideshare-platform/shared/proto/user"
+	_ "github.com/rideshare-platform/shared/proto/vehicle"
+)
+
+// protoRoot is where the platform's proto files live, relative to the repository
+const protoRoot = "shared/proto/"
+
+// service is one proto service to write a client for
+type service struct {
+	Source      string // proto file the service is defined in
+	FullName    string // e.g. trip.TripService
+	Name        string // e.g. TripService
+	Package     string // client package, e.g. tripclient
+	PBImport    string // Go import path of the generated protos
+	PBAlias     string // e.g. trippb
+	Methods     []method
+	HasStreams  bool
+	ServiceRole string // the service serving it, e.g. trip-service
+}
+
+// method is one RPC of a service
+type method struct {
+	Name         string
+	Input        string // Go type of the request
+	ClientStream bool
+	ServerStream bool
+}
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	services, err := collectServices()
+	if err != nil {
+		return err
+	}
+	for _, svc := range services {
+		if err := write(svc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collectServices returns the services of every registered platform proto file
+func collectServices() ([]service, error) {
+	var services []service
+	var rangeErr error
+	protoregistry.GlobalFiles.RangeFiles(func(file protoreflect.FileDescriptor) bool {
+		if !strings.HasPrefix(file.Path(), protoRoot) {
+			return true
+		}
+		for i := 0; i < file.Services().Len(); i++ {
+			svc, err := describe(file, file.Services().Get(i))
+			if err != nil {
+				rangeErr = err
+				return false
+			}
+			services = append(services, svc)
+		}
+		return true
+	})
+	if rangeErr != nil {
+		return nil, rangeErr
+	}
+	sort.Slice(services, func(i, j int) bool { return services[i].FullName < services[j].FullName })
+	return services, nil
+}
+
+func describe(file protoreflect.FileDescriptor, sd protoreflect.ServiceDescriptor) (service, error) {
+	options, _ := file.Options().(*descriptorpb.FileOptions)
+	importPath, _, _ := strings.Cut(options.GetGoPackage(), ";")
+	if importPath == "" {
+		return service{}, fmt.Errorf("%s has no go_package option", file.Path())
+	}
+	dir := path.Base(importPath)
+
+	svc := service{
+		Source:      file.Path(),
+		FullName:    string(sd.FullName()),
+		Name:        string(sd.Name()),
+		Package:     dir + "client",
+		PBImport:    importPath,
+		PBAlias:     dir + "pb",
+		ServiceRole: dir + "-service",
+	}
+	for i := 0; i < sd.Methods().Len(); i++ {
+		md := sd.Methods().Get(i)
+		if md.Input().ParentFile().Path() != file.Path() {
+			return service{}, fmt.Errorf("%s: request %s of %s is defined in another file", file.Path(), md.Input().FullName(), md.Name())
+		}
+		m := method{
+			Name:         string(md.Name()),
+			Input:        goMessageName(md.Input()),
+			ClientStream: md.IsStreamingClient(),
+			ServerStream: md.IsStreamingServer(),
+		}
+		svc.HasStreams = svc.HasStreams || m.ClientStream || m.ServerStream
+		svc.Methods = append(svc.Methods, m)
+	}
+	return svc, nil
+}
+
+// goMessageName returns the name protoc-gen-go gives a message, which joins
+// nested message names with underscores
+func goMessageName(md protoreflect.MessageDescriptor) string {
+	name := string(md.Name())
+	for parent, ok := md.Parent().(protoreflect.MessageDescriptor); ok; parent, ok = parent.Parent().(protoreflect.MessageDescriptor) {
+		name = string(parent.Name()) + "_" + name
+	}
+	return name
+}
+
+// write renders the client package of svc into the working directory
+func write(svc service) error {
+	if err := os.MkdirAll(svc.Package, 0o755); err != nil {
+		return err
+	}
+	for name, tmpl := range map[string]*template.Template{
+		"client.go":   clientTemplate,
+		"contract.go": contractTemplate,
+	} {
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, svc); err != nil {
+			return fmt.Errorf("failed to render %s for %s: %w", name, svc.FullName, err)
+		}
+		source, err := format.Source(buf.Bytes())
+		if err != nil {
+			return fmt.Errorf("failed to format %s for %s: %w", name, svc.FullName, err)
+		}
+		if err := os.WriteFile(filepath.Join(svc.Package, name), source, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var clientTemplate = template.Must(template.New("client").Parse(`// Code generated by shared/clients/gen. DO NOT EDIT.
+// source: {{.Source}}
+
+// Package {{.Package}} calls {{.FullName}}, served by {{.ServiceRole}}.
+package {{.Package}}
+
+import (
+	"google.golang.org/grpc"
+
+	sharedgrpc "github.com/rideshare-platform/shared/grpc"
+	{{.PBAlias}} "{{.PBImport}}"
+)
+
+// ServiceName is the full name of the service the client calls
+const ServiceName = "{{.FullName}}"
+
+// Client calls {{.FullName}}
+type Client struct {
+	{{.PBAlias}}.{{.Name}}Client
+	conn *grpc.ClientConn
+}
+
+// New returns a client making its calls over conn
+func New(conn grpc.ClientConnInterface) *Client {
+	return &Client{ {{- .Name}}Client: {{.PBAlias}}.New{{.Name}}Client(conn)}
+}
+
+// Dial returns a client connected to addr. Its calls carry the request
+// context the services propagate; opts add the transport credentials.
+func Dial(addr string, opts ...grpc.DialOption) (*Client, error) {
+	conn, err := grpc.NewClient(addr, append(sharedgrpc.ClientOptions(), opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	client := New(conn)
+	client.conn = conn
+	return client, nil
+}
+
+// Conn returns the connection Dial opened, nil for clients made with New
+func (c *Client) Conn() *grpc.ClientConn {
+	return c.conn
+}
+
+// Close closes the connection Dial opened. It is safe to call on a nil client.
+func (c *Client) Close() error {
+	if c == nil || c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
+}
+`))
+
+var contractTemplate = template.Must(template.New("contract").Parse(`// Code generated by shared/clients/gen. DO NOT EDIT.
+// source: {{.Source}}
+
+package {{.Package}}
+
+import (
+	"context"
+	{{- if .HasStreams}}
+	"errors"
+	"io"
+	{{- end}}
+
+	"google.golang.org/grpc"
+
+	{{.PBAlias}} "{{.PBImport}}"
+)
+
+// ContractCalls returns a call of every method of {{.FullName}} with an
+// empty request, keyed by method name. Contract tests make them against
+// {{.ServiceRole}}'s server to catch methods it no longer serves.
+func ContractCalls(conn grpc.ClientConnInterface) map[string]func(context.Context) error {
+	client := New(conn)
+	return map[string]func(context.Context) error{
+	{{- range .Methods}}
+		"{{.Name}}": func(ctx context.Context) error {
+		{{- if and .ClientStream .ServerStream}}
+			stream, err := client.{{.Name}}(ctx)
+			if err != nil {
+				return err
+			}
+			if err := stream.CloseSend(); err != nil {
+				return err
+			}
+			_, err = stream.Recv()
+			return endOfStream(err)
+		{{- else if .ClientStream}}
+			stream, err := client.{{.Name}}(ctx)
+			if err != nil {
+				return err
+			}
+			_, err = stream.CloseAndRecv()
+			return endOfStream(err)
+		{{- else if .ServerStream}}
+			stream, err := client.{{.Name}}(ctx, &{{$.PBAlias}}.{{.Input}}{})
+			if err != nil {
+				return err
+			}
+			_, err = stream.Recv()
+			return endOfStream(err)
+		{{- else}}
+			_, err := client.{{.Name}}(ctx, &{{$.PBAlias}}.{{.Input}}{})
+			return err
+		{{- end}}
+		},
+	{{- end}}
+	}
+}
+{{- if .HasStreams}}
+
+// endOfStream treats a stream the server closed cleanly as a successful call
+func endOfStream(err error) error {
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	return err
+}
+{{- end}}
+`))
@@ -0,0 +1,103 @@
+// Code generated by shared/clients/gen. DO NOT EDIT.
+// source: shared/proto/geo/geo.proto
+
+package geoclient
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"google.golang.org/grpc"
+
+	geopb "github.com/rideshare-platform/shared/proto/geo"
+)
+
+// ContractCalls returns a call of every method of geo.GeospatialService with an
+// empty request, keyed by method name. Contract tests make them against
+// geo-service's server to catch methods it no longer serves.
+func ContractCalls(conn grpc.ClientConnInterface) map[string]func(context.Context) error {
+	client := New(conn)
+	return map[string]func(context.Context) error{
+		"CalculateDistance": func(ctx context.Context) error {
+			_, err := client.CalculateDistance(ctx, &geopb.DistanceRequest{})
+			return err
+		},
+		"CalculateETA": func(ctx context.Context) error {
+			_, err := client.CalculateETA(ctx, &geopb.ETARequest{})
+			return err
+		},
+		"FindNearbyDrivers": func(ctx context.Context) error {
+			_, err := client.FindNearbyDrivers(ctx, &geopb.NearbyDriversRequest{})
+			return err
+		},
+		"UpdateDriverLocation": func(ctx context.Context) error {
+			_, err := client.UpdateDriverLocation(ctx, &geopb.UpdateDriverLocationRequest{})
+			return err
+		},
+		"GenerateGeohash": func(ctx context.Context) error {
+			_, err := client.GenerateGeohash(ctx, &geopb.GeohashRequest{})
+			return err
+		},
+		"OptimizeRoute": func(ctx context.Context) error {
+			_, err := client.OptimizeRoute(ctx, &geopb.RouteOptimizationRequest{})
+			return err
+		},
+		"SubscribeToDriverLocations": func(ctx context.Context) error {
+			stream, err := client.SubscribeToDriverLocations(ctx, &geopb.SubscribeToDriverLocationRequest{})
+			if err != nil {
+				return err
+			}
+			_, err = stream.Recv()
+			return endOfStream(err)
+		},
+		"StartLocationTracking": func(ctx context.Context) error {
+			_, err := client.StartLocationTracking(ctx, &geopb.StartLocationTrackingRequest{})
+			return err
+		},
+		"FindZones": func(ctx context.Context) error {
+			_, err := client.FindZones(ctx, &geopb.FindZonesRequest{})
+			return err
+		},
+		"GetTripRoute": func(ctx context.Context) error {
+			_, err := client.GetTripRoute(ctx, &geopb.GetTripRouteRequest{})
+			return err
+		},
+		"DeleteTripRoutes": func(ctx context.Context) error {
+			_, err := client.DeleteTripRoutes(ctx, &geopb.DeleteTripRoutesRequest{})
+			return err
+		},
+		"IngestDriverLocations": func(ctx context.Context) error {
+			_, err := client.IngestDriverLocations(ctx, &geopb.IngestDriverLocationsRequest{})
+			return err
+		},
+		"StreamPickupETA": func(ctx context.Context) error {
+			stream, err := client.StreamPickupETA(ctx, &geopb.StreamPickupETARequest{})
+			if err != nil {
+				return err
+			}
+			_, err = stream.Recv()
+			return endOfStream(err)
+		},
+		"ListDriverShifts": func(ctx context.Context) error {
+			_, err := client.ListDriverShifts(ctx, &geopb.ListDriverShiftsRequest{})
+			return err
+		},
+		"GetDriverOnlineHours": func(ctx context.Context) error {
+			_, err := client.GetDriverOnlineHours(ctx, &geopb.GetDriverOnlineHoursRequest{})
+			return err
+		},
+		"GetZoneQueue": func(ctx context.Context) error {
+			_, err := client.GetZoneQueue(ctx, &geopb.GetZoneQueueRequest{})
+			return err
+		},
+	}
+}
+
+// endOfStream treats a stream the server closed cleanly as a successful call
+func endOfStream(err error) error {
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,118 @@
+// Code generated by shared/clients/gen. DO NOT EDIT.
+// source: shared/proto/matching/matching.proto
+
+package matchingclient
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"google.golang.org/grpc"
+
+	matchingpb "github.com/rideshare-platform/shared/proto/matching"
+)
+
+// ContractCalls returns a call of every method of matching.MatchingService with an
+// empty request, keyed by method name. Contract tests make them against
+// matching-service's server to catch methods it no longer serves.
+func ContractCalls(conn grpc.ClientConnInterface) map[string]func(context.Context) error {
+	client := New(conn)
+	return map[string]func(context.Context) error{
+		"FindNearbyDrivers": func(ctx context.Context) error {
+			_, err := client.FindNearbyDrivers(ctx, &matchingpb.FindNearbyDriversRequest{})
+			return err
+		},
+		"MatchDriver": func(ctx context.Context) error {
+			_, err := client.MatchDriver(ctx, &matchingpb.MatchDriverRequest{})
+			return err
+		},
+		"UpdateDriverLocation": func(ctx context.Context) error {
+			_, err := client.UpdateDriverLocation(ctx, &matchingpb.UpdateDriverLocationRequest{})
+			return err
+		},
+		"GetDriver": func(ctx context.Context) error {
+			_, err := client.GetDriver(ctx, &matchingpb.GetDriverRequest{})
+			return err
+		},
+		"GetActiveDrivers": func(ctx context.Context) error {
+			_, err := client.GetActiveDrivers(ctx, &matchingpb.GetActiveDriversRequest{})
+			return err
+		},
+		"BatchUpdateDrivers": func(ctx context.Context) error {
+			_, err := client.BatchUpdateDrivers(ctx, &matchingpb.BatchUpdateDriversRequest{})
+			return err
+		},
+		"GetMatchingStats": func(ctx context.Context) error {
+			_, err := client.GetMatchingStats(ctx, &matchingpb.GetMatchingStatsRequest{})
+			return err
+		},
+		"AcceptOffer": func(ctx context.Context) error {
+			_, err := client.AcceptOffer(ctx, &matchingpb.AcceptOfferRequest{})
+			return err
+		},
+		"DeclineOffer": func(ctx context.Context) error {
+			_, err := client.DeclineOffer(ctx, &matchingpb.DeclineOfferRequest{})
+			return err
+		},
+		"ReleaseDriverReservation": func(ctx context.Context) error {
+			_, err := client.ReleaseDriverReservation(ctx, &matchingpb.ReleaseDriverReservationRequest{})
+			return err
+		},
+		"GetMatchingAttemptLog": func(ctx context.Context) error {
+			_, err := client.GetMatchingAttemptLog(ctx, &matchingpb.GetMatchingAttemptLogRequest{})
+			return err
+		},
+		"ListMatchingConfigs": func(ctx context.Context) error {
+			_, err := client.ListMatchingConfigs(ctx, &matchingpb.ListMatchingConfigsRequest{})
+			return err
+		},
+		"GetMatchingConfig": func(ctx context.Context) error {
+			_, err := client.GetMatchingConfig(ctx, &matchingpb.GetMatchingConfigRequest{})
+			return err
+		},
+		"SaveMatchingConfig": func(ctx context.Context) error {
+			_, err := client.SaveMatchingConfig(ctx, &matchingpb.SaveMatchingConfigRequest{})
+			return err
+		},
+		"DeleteMatchingConfig": func(ctx context.Context) error {
+			_, err := client.DeleteMatchingConfig(ctx, &matchingpb.DeleteMatchingConfigRequest{})
+			return err
+		},
+		"StreamDriverUpdates": func(ctx context.Context) error {
+			stream, err := client.StreamDriverUpdates(ctx)
+			if err != nil {
+				return err
+			}
+			if err := stream.CloseSend(); err != nil {
+				return err
+			}
+			_, err = stream.Recv()
+			return endOfStream(err)
+		},
+		"StreamDriverOffers": func(ctx context.Context) error {
+			stream, err := client.StreamDriverOffers(ctx, &matchingpb.StreamDriverOffersRequest{})
+			if err != nil {
+				return err
+			}
+			_, err = stream.Recv()
+			return endOfStream(err)
+		},
+		"StreamMatchingProgress": func(ctx context.Context) error {
+			stream, err := client.StreamMatchingProgress(ctx, &matchingpb.StreamMatchingProgressRequest{})
+			if err != nil {
+				return err
+			}
+			_, err = stream.Recv()
+			return endOfStream(err)
+		},
+	}
+}
+
+// endOfStream treats a stream the server closed cleanly as a successful call
+func endOfStream(err error) error {
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,141 @@
+// Code generated by shared/clients/gen. DO NOT EDIT.
+// source: shared/proto/payment/payment.proto
+
+package paymentclient
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	paymentpb "github.com/rideshare-platform/shared/proto/payment"
+)
+
+// ContractCalls returns a call of every method of payment.PaymentService with an
+// empty request, keyed by method name. Contract tests make them against
+// payment-service's server to catch methods it no longer serves.
+func ContractCalls(conn grpc.ClientConnInterface) map[string]func(context.Context) error {
+	client := New(conn)
+	return map[string]func(context.Context) error{
+		"ProcessPayment": func(ctx context.Context) error {
+			_, err := client.ProcessPayment(ctx, &paymentpb.ProcessPaymentRequest{})
+			return err
+		},
+		"ProcessRefund": func(ctx context.Context) error {
+			_, err := client.ProcessRefund(ctx, &paymentpb.ProcessRefundRequest{})
+			return err
+		},
+		"AddPaymentMethod": func(ctx context.Context) error {
+			_, err := client.AddPaymentMethod(ctx, &paymentpb.AddPaymentMethodRequest{})
+			return err
+		},
+		"GetPayment": func(ctx context.Context) error {
+			_, err := client.GetPayment(ctx, &paymentpb.GetPaymentRequest{})
+			return err
+		},
+		"GetUserPaymentMethods": func(ctx context.Context) error {
+			_, err := client.GetUserPaymentMethods(ctx, &paymentpb.GetUserPaymentMethodsRequest{})
+			return err
+		},
+		"GetUserPayments": func(ctx context.Context) error {
+			_, err := client.GetUserPayments(ctx, &paymentpb.GetUserPaymentsRequest{})
+			return err
+		},
+		"GetTripPayments": func(ctx context.Context) error {
+			_, err := client.GetTripPayments(ctx, &paymentpb.GetTripPaymentsRequest{})
+			return err
+		},
+		"ListPayments": func(ctx context.Context) error {
+			_, err := client.ListPayments(ctx, &paymentpb.ListPaymentsRequest{})
+			return err
+		},
+		"ListPaymentsByStatus": func(ctx context.Context) error {
+			_, err := client.ListPaymentsByStatus(ctx, &paymentpb.ListPaymentsByStatusRequest{})
+			return err
+		},
+		"RemoveUserPaymentMethods": func(ctx context.Context) error {
+			_, err := client.RemoveUserPaymentMethods(ctx, &paymentpb.RemoveUserPaymentMethodsRequest{})
+			return err
+		},
+		"GrantWalletCredit": func(ctx context.Context) error {
+			_, err := client.GrantWalletCredit(ctx, &paymentpb.GrantWalletCreditRequest{})
+			return err
+		},
+		"AuthorizeTripPayment": func(ctx context.Context) error {
+			_, err := client.AuthorizeTripPayment(ctx, &paymentpb.AuthorizeTripPaymentRequest{})
+			return err
+		},
+		"CaptureTripPayment": func(ctx context.Context) error {
+			_, err := client.CaptureTripPayment(ctx, &paymentpb.CaptureTripPaymentRequest{})
+			return err
+		},
+		"ReleaseTripPayment": func(ctx context.Context) error {
+			_, err := client.ReleaseTripPayment(ctx, &paymentpb.ReleaseTripPaymentRequest{})
+			return err
+		},
+		"ListChargebacks": func(ctx context.Context) error {
+			_, err := client.ListChargebacks(ctx, &paymentpb.ListChargebacksRequest{})
+			return err
+		},
+		"GetChargeback": func(ctx context.Context) error {
+			_, err := client.GetChargeback(ctx, &paymentpb.GetChargebackRequest{})
+			return err
+		},
+		"SubmitChargebackEvidence": func(ctx context.Context) error {
+			_, err := client.SubmitChargebackEvidence(ctx, &paymentpb.SubmitChargebackEvidenceRequest{})
+			return err
+		},
+		"RecordChargebackOutcome": func(ctx context.Context) error {
+			_, err := client.RecordChargebackOutcome(ctx, &paymentpb.RecordChargebackOutcomeRequest{})
+			return err
+		},
+		"RecordDriverPayout": func(ctx context.Context) error {
+			_, err := client.RecordDriverPayout(ctx, &paymentpb.RecordDriverPayoutRequest{})
+			return err
+		},
+		"RecordDriverEarning": func(ctx context.Context) error {
+			_, err := client.RecordDriverEarning(ctx, &paymentpb.RecordDriverEarningRequest{})
+			return err
+		},
+		"ReportTripEvent": func(ctx context.Context) error {
+			_, err := client.ReportTripEvent(ctx, &paymentpb.ReportTripEventRequest{})
+			return err
+		},
+		"ListRefundCases": func(ctx context.Context) error {
+			_, err := client.ListRefundCases(ctx, &paymentpb.ListRefundCasesRequest{})
+			return err
+		},
+		"GetRefundCase": func(ctx context.Context) error {
+			_, err := client.GetRefundCase(ctx, &paymentpb.GetRefundCaseRequest{})
+			return err
+		},
+		"ResolveRefundCase": func(ctx context.Context) error {
+			_, err := client.ResolveRefundCase(ctx, &paymentpb.ResolveRefundCaseRequest{})
+			return err
+		},
+		"ListFraudRules": func(ctx context.Context) error {
+			_, err := client.ListFraudRules(ctx, &paymentpb.ListFraudRulesRequest{})
+			return err
+		},
+		"SaveFraudRule": func(ctx context.Context) error {
+			_, err := client.SaveFraudRule(ctx, &paymentpb.SaveFraudRuleRequest{})
+			return err
+		},
+		"SetFraudRuleEnabled": func(ctx context.Context) error {
+			_, err := client.SetFraudRuleEnabled(ctx, &paymentpb.SetFraudRuleEnabledRequest{})
+			return err
+		},
+		"DeleteFraudRule": func(ctx context.Context) error {
+			_, err := client.DeleteFraudRule(ctx, &paymentpb.DeleteFraudRuleRequest{})
+			return err
+		},
+		"ListFraudReviews": func(ctx context.Context) error {
+			_, err := client.ListFraudReviews(ctx, &paymentpb.ListFraudReviewsRequest{})
+			return err
+		},
+		"ResolveFraudReview": func(ctx context.Context) error {
+			_, err := client.ResolveFraudReview(ctx, &paymentpb.ResolveFraudReviewRequest{})
+			return err
+		},
+	}
+}
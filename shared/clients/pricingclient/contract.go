@@ -0,0 +1,75 @@
+// Code generated by shared/clients/gen. DO NOT EDIT.
+// source: shared/proto/pricing/pricing.proto
+
+package pricingclient
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"google.golang.org/grpc"
+
+	pricingpb "github.com/rideshare-platform/shared/proto/pricing"
+)
+
+// ContractCalls returns a call of every method of pricing.PricingService with an
+// empty request, keyed by method name. Contract tests make them against
+// pricing-service's server to catch methods it no longer serves.
+func ContractCalls(conn grpc.ClientConnInterface) map[string]func(context.Context) error {
+	client := New(conn)
+	return map[string]func(context.Context) error{
+		"GetPriceEstimate": func(ctx context.Context) error {
+			_, err := client.GetPriceEstimate(ctx, &pricingpb.GetPriceEstimateRequest{})
+			return err
+		},
+		"GetMultipleEstimates": func(ctx context.Context) error {
+			_, err := client.GetMultipleEstimates(ctx, &pricingpb.GetMultipleEstimatesRequest{})
+			return err
+		},
+		"CalculateFinalFare": func(ctx context.Context) error {
+			_, err := client.CalculateFinalFare(ctx, &pricingpb.CalculateFinalFareRequest{})
+			return err
+		},
+		"GetSurgePricing": func(ctx context.Context) error {
+			_, err := client.GetSurgePricing(ctx, &pricingpb.GetSurgePricingRequest{})
+			return err
+		},
+		"GetVehicleTypes": func(ctx context.Context) error {
+			_, err := client.GetVehicleTypes(ctx, &pricingpb.GetVehicleTypesRequest{})
+			return err
+		},
+		"UpdateSurgePricing": func(ctx context.Context) error {
+			_, err := client.UpdateSurgePricing(ctx, &pricingpb.UpdateSurgePricingRequest{})
+			return err
+		},
+		"ListSurgeAreas": func(ctx context.Context) error {
+			_, err := client.ListSurgeAreas(ctx, &pricingpb.ListSurgeAreasRequest{})
+			return err
+		},
+		"GetPricingStats": func(ctx context.Context) error {
+			_, err := client.GetPricingStats(ctx, &pricingpb.GetPricingStatsRequest{})
+			return err
+		},
+		"SplitSharedFare": func(ctx context.Context) error {
+			_, err := client.SplitSharedFare(ctx, &pricingpb.SplitSharedFareRequest{})
+			return err
+		},
+		"SubscribeToPricingUpdates": func(ctx context.Context) error {
+			stream, err := client.SubscribeToPricingUpdates(ctx, &pricingpb.SubscribeToPricingUpdatesRequest{})
+			if err != nil {
+				return err
+			}
+			_, err = stream.Recv()
+			return endOfStream(err)
+		},
+	}
+}
+
+// endOfStream treats a stream the server closed cleanly as a successful call
+func endOfStream(err error) error {
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	return err
+}
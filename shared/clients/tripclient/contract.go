@@ -0,0 +1,283 @@
+// Code generated by shared/clients/gen. DO NOT EDIT.
+// source: shared/proto/trip/trip.proto
+
+package tripclient
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"google.golang.org/grpc"
+
+	trippb "github.com/rideshare-platform/shared/proto/trip"
+)
+
+// ContractCalls returns a call of every method of trip.TripService with an
+// empty request, keyed by method name. Contract tests make them against
+// trip-service's server to catch methods it no longer serves.
+func ContractCalls(conn grpc.ClientConnInterface) map[string]func(context.Context) error {
+	client := New(conn)
+	return map[string]func(context.Context) error{
+		"CreateTrip": func(ctx context.Context) error {
+			_, err := client.CreateTrip(ctx, &trippb.CreateTripRequest{})
+			return err
+		},
+		"GetTrip": func(ctx context.Context) error {
+			_, err := client.GetTrip(ctx, &trippb.GetTripRequest{})
+			return err
+		},
+		"UpdateTripStatus": func(ctx context.Context) error {
+			_, err := client.UpdateTripStatus(ctx, &trippb.UpdateTripStatusRequest{})
+			return err
+		},
+		"GetUserTrips": func(ctx context.Context) error {
+			_, err := client.GetUserTrips(ctx, &trippb.GetUserTripsRequest{})
+			return err
+		},
+		"GetActiveTrips": func(ctx context.Context) error {
+			_, err := client.GetActiveTrips(ctx, &trippb.GetActiveTripsRequest{})
+			return err
+		},
+		"ForceCancelTrip": func(ctx context.Context) error {
+			_, err := client.ForceCancelTrip(ctx, &trippb.ForceCancelTripRequest{})
+			return err
+		},
+		"AnonymizeUserTrips": func(ctx context.Context) error {
+			_, err := client.AnonymizeUserTrips(ctx, &trippb.AnonymizeUserTripsRequest{})
+			return err
+		},
+		"OpenSharedTrip": func(ctx context.Context) error {
+			_, err := client.OpenSharedTrip(ctx, &trippb.OpenSharedTripRequest{})
+			return err
+		},
+		"AddSharedRider": func(ctx context.Context) error {
+			_, err := client.AddSharedRider(ctx, &trippb.AddSharedRiderRequest{})
+			return err
+		},
+		"CompleteTripStop": func(ctx context.Context) error {
+			_, err := client.CompleteTripStop(ctx, &trippb.CompleteTripStopRequest{})
+			return err
+		},
+		"GetSharedTrip": func(ctx context.Context) error {
+			_, err := client.GetSharedTrip(ctx, &trippb.GetSharedTripRequest{})
+			return err
+		},
+		"ListOpenSharedTrips": func(ctx context.Context) error {
+			_, err := client.ListOpenSharedTrips(ctx, &trippb.ListOpenSharedTripsRequest{})
+			return err
+		},
+		"CreateScheduledRide": func(ctx context.Context) error {
+			_, err := client.CreateScheduledRide(ctx, &trippb.CreateScheduledRideRequest{})
+			return err
+		},
+		"ListScheduledRides": func(ctx context.Context) error {
+			_, err := client.ListScheduledRides(ctx, &trippb.ListScheduledRidesRequest{})
+			return err
+		},
+		"CancelScheduledRide": func(ctx context.Context) error {
+			_, err := client.CancelScheduledRide(ctx, &trippb.CancelScheduledRideRequest{})
+			return err
+		},
+		"SubmitRating": func(ctx context.Context) error {
+			_, err := client.SubmitRating(ctx, &trippb.SubmitRatingRequest{})
+			return err
+		},
+		"GetRatingSummary": func(ctx context.Context) error {
+			_, err := client.GetRatingSummary(ctx, &trippb.GetRatingSummaryRequest{})
+			return err
+		},
+		"ListReviews": func(ctx context.Context) error {
+			_, err := client.ListReviews(ctx, &trippb.ListReviewsRequest{})
+			return err
+		},
+		"GetDriverRatings": func(ctx context.Context) error {
+			_, err := client.GetDriverRatings(ctx, &trippb.GetDriverRatingsRequest{})
+			return err
+		},
+		"GetDriverReliability": func(ctx context.Context) error {
+			_, err := client.GetDriverReliability(ctx, &trippb.GetDriverReliabilityRequest{})
+			return err
+		},
+		"CreateTripShareLink": func(ctx context.Context) error {
+			_, err := client.CreateTripShareLink(ctx, &trippb.CreateTripShareLinkRequest{})
+			return err
+		},
+		"GetTripByShareToken": func(ctx context.Context) error {
+			_, err := client.GetTripByShareToken(ctx, &trippb.GetTripByShareTokenRequest{})
+			return err
+		},
+		"WatchTripByShareToken": func(ctx context.Context) error {
+			stream, err := client.WatchTripByShareToken(ctx, &trippb.GetTripByShareTokenRequest{})
+			if err != nil {
+				return err
+			}
+			_, err = stream.Recv()
+			return endOfStream(err)
+		},
+		"ReportSOS": func(ctx context.Context) error {
+			_, err := client.ReportSOS(ctx, &trippb.ReportSOSRequest{})
+			return err
+		},
+		"GetIncident": func(ctx context.Context) error {
+			_, err := client.GetIncident(ctx, &trippb.GetIncidentRequest{})
+			return err
+		},
+		"ListIncidents": func(ctx context.Context) error {
+			_, err := client.ListIncidents(ctx, &trippb.ListIncidentsRequest{})
+			return err
+		},
+		"AcknowledgeIncident": func(ctx context.Context) error {
+			_, err := client.AcknowledgeIncident(ctx, &trippb.AcknowledgeIncidentRequest{})
+			return err
+		},
+		"AddIncidentNote": func(ctx context.Context) error {
+			_, err := client.AddIncidentNote(ctx, &trippb.AddIncidentNoteRequest{})
+			return err
+		},
+		"ResolveIncident": func(ctx context.Context) error {
+			_, err := client.ResolveIncident(ctx, &trippb.ResolveIncidentRequest{})
+			return err
+		},
+		"OpenDispute": func(ctx context.Context) error {
+			_, err := client.OpenDispute(ctx, &trippb.OpenDisputeRequest{})
+			return err
+		},
+		"GetDisputeReview": func(ctx context.Context) error {
+			_, err := client.GetDisputeReview(ctx, &trippb.GetDisputeRequest{})
+			return err
+		},
+		"ListDisputes": func(ctx context.Context) error {
+			_, err := client.ListDisputes(ctx, &trippb.ListDisputesRequest{})
+			return err
+		},
+		"StartDisputeReview": func(ctx context.Context) error {
+			_, err := client.StartDisputeReview(ctx, &trippb.StartDisputeReviewRequest{})
+			return err
+		},
+		"AddDisputeNote": func(ctx context.Context) error {
+			_, err := client.AddDisputeNote(ctx, &trippb.AddDisputeNoteRequest{})
+			return err
+		},
+		"ResolveDispute": func(ctx context.Context) error {
+			_, err := client.ResolveDispute(ctx, &trippb.ResolveDisputeRequest{})
+			return err
+		},
+		"RejectDispute": func(ctx context.Context) error {
+			_, err := client.RejectDispute(ctx, &trippb.RejectDisputeRequest{})
+			return err
+		},
+		"ReportLostItem": func(ctx context.Context) error {
+			_, err := client.ReportLostItem(ctx, &trippb.ReportLostItemRequest{})
+			return err
+		},
+		"GetLostItem": func(ctx context.Context) error {
+			_, err := client.GetLostItem(ctx, &trippb.GetLostItemRequest{})
+			return err
+		},
+		"ListLostItems": func(ctx context.Context) error {
+			_, err := client.ListLostItems(ctx, &trippb.ListLostItemsRequest{})
+			return err
+		},
+		"UpdateLostItem": func(ctx context.Context) error {
+			_, err := client.UpdateLostItem(ctx, &trippb.UpdateLostItemRequest{})
+			return err
+		},
+		"GetTripContact": func(ctx context.Context) error {
+			_, err := client.GetTripContact(ctx, &trippb.GetTripContactRequest{})
+			return err
+		},
+		"GetPickupGuarantee": func(ctx context.Context) error {
+			_, err := client.GetPickupGuarantee(ctx, &trippb.GetPickupGuaranteeRequest{})
+			return err
+		},
+		"GetPickupSLAReport": func(ctx context.Context) error {
+			_, err := client.GetPickupSLAReport(ctx, &trippb.GetPickupSLAReportRequest{})
+			return err
+		},
+		"ListComplianceReports": func(ctx context.Context) error {
+			_, err := client.ListComplianceReports(ctx, &trippb.ListComplianceReportsRequest{})
+			return err
+		},
+		"GetComplianceReport": func(ctx context.Context) error {
+			_, err := client.GetComplianceReport(ctx, &trippb.GetComplianceReportRequest{})
+			return err
+		},
+		"GenerateComplianceReport": func(ctx context.Context) error {
+			_, err := client.GenerateComplianceReport(ctx, &trippb.GenerateComplianceReportRequest{})
+			return err
+		},
+		"ListDeadLetters": func(ctx context.Context) error {
+			_, err := client.ListDeadLetters(ctx, &trippb.ListDeadLettersRequest{})
+			return err
+		},
+		"GetDeadLetter": func(ctx context.Context) error {
+			_, err := client.GetDeadLetter(ctx, &trippb.GetDeadLetterRequest{})
+			return err
+		},
+		"ReplayDeadLetter": func(ctx context.Context) error {
+			_, err := client.ReplayDeadLetter(ctx, &trippb.ReplayDeadLetterRequest{})
+			return err
+		},
+		"DiscardDeadLetter": func(ctx context.Context) error {
+			_, err := client.DiscardDeadLetter(ctx, &trippb.DiscardDeadLetterRequest{})
+			return err
+		},
+		"GetTripListingsLag": func(ctx context.Context) error {
+			_, err := client.GetTripListingsLag(ctx, &trippb.GetTripListingsLagRequest{})
+			return err
+		},
+		"RebuildTripListings": func(ctx context.Context) error {
+			_, err := client.RebuildTripListings(ctx, &trippb.RebuildTripListingsRequest{})
+			return err
+		},
+		"SendTripMessage": func(ctx context.Context) error {
+			_, err := client.SendTripMessage(ctx, &trippb.SendTripMessageRequest{})
+			return err
+		},
+		"ListTripMessages": func(ctx context.Context) error {
+			_, err := client.ListTripMessages(ctx, &trippb.ListTripMessagesRequest{})
+			return err
+		},
+		"StreamTripMessages": func(ctx context.Context) error {
+			stream, err := client.StreamTripMessages(ctx, &trippb.StreamTripMessagesRequest{})
+			if err != nil {
+				return err
+			}
+			_, err = stream.Recv()
+			return endOfStream(err)
+		},
+		"ListQuickReplies": func(ctx context.Context) error {
+			_, err := client.ListQuickReplies(ctx, &trippb.ListQuickRepliesRequest{})
+			return err
+		},
+		"SubscribeDriverEvents": func(ctx context.Context) error {
+			stream, err := client.SubscribeDriverEvents(ctx, &trippb.SubscribeDriverEventsRequest{})
+			if err != nil {
+				return err
+			}
+			_, err = stream.Recv()
+			return endOfStream(err)
+		},
+		"UpdateRiderLocation": func(ctx context.Context) error {
+			_, err := client.UpdateRiderLocation(ctx, &trippb.UpdateRiderLocationRequest{})
+			return err
+		},
+		"SubscribeToTripUpdates": func(ctx context.Context) error {
+			stream, err := client.SubscribeToTripUpdates(ctx, &trippb.SubscribeToTripUpdatesRequest{})
+			if err != nil {
+				return err
+			}
+			_, err = stream.Recv()
+			return endOfStream(err)
+		},
+	}
+}
+
+// endOfStream treats a stream the server closed cleanly as a successful call
+func endOfStream(err error) error {
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	return err
+}
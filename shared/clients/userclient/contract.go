@@ -0,0 +1,57 @@
+// Code generated by shared/clients/gen. DO NOT EDIT.
+// source: shared/proto/user/user.proto
+
+package userclient
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	userpb "github.com/rideshare-platform/shared/proto/user"
+)
+
+// ContractCalls returns a call of every method of user.UserService with an
+// empty request, keyed by method name. Contract tests make them against
+// user-service's server to catch methods it no longer serves.
+func ContractCalls(conn grpc.ClientConnInterface) map[string]func(context.Context) error {
+	client := New(conn)
+	return map[string]func(context.Context) error{
+		"CreateUser": func(ctx context.Context) error {
+			_, err := client.CreateUser(ctx, &userpb.CreateUserRequest{})
+			return err
+		},
+		"GetUser": func(ctx context.Context) error {
+			_, err := client.GetUser(ctx, &userpb.GetUserRequest{})
+			return err
+		},
+		"UpdateUser": func(ctx context.Context) error {
+			_, err := client.UpdateUser(ctx, &userpb.UpdateUserRequest{})
+			return err
+		},
+		"UpdateUserStatus": func(ctx context.Context) error {
+			_, err := client.UpdateUserStatus(ctx, &userpb.UpdateUserStatusRequest{})
+			return err
+		},
+		"ListUsers": func(ctx context.Context) error {
+			_, err := client.ListUsers(ctx, &userpb.ListUsersRequest{})
+			return err
+		},
+		"UpdateDriverLocation": func(ctx context.Context) error {
+			_, err := client.UpdateDriverLocation(ctx, &userpb.UpdateDriverLocationRequest{})
+			return err
+		},
+		"GetDriver": func(ctx context.Context) error {
+			_, err := client.GetDriver(ctx, &userpb.GetDriverRequest{})
+			return err
+		},
+		"GetDriverOnboardingStatus": func(ctx context.Context) error {
+			_, err := client.GetDriverOnboardingStatus(ctx, &userpb.GetDriverOnboardingStatusRequest{})
+			return err
+		},
+		"GetDriverProfiles": func(ctx context.Context) error {
+			_, err := client.GetDriverProfiles(ctx, &userpb.GetDriverProfilesRequest{})
+			return err
+		},
+	}
+}
@@ -0,0 +1,45 @@
+// Code generated by shared/clients/gen. DO NOT EDIT.
+// source: shared/proto/vehicle/vehicle.proto
+
+package vehicleclient
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	vehiclepb "github.com/rideshare-platform/shared/proto/vehicle"
+)
+
+// ContractCalls returns a call of every method of vehicle.VehicleService with an
+// empty request, keyed by method name. Contract tests make them against
+// vehicle-service's server to catch methods it no longer serves.
+func ContractCalls(conn grpc.ClientConnInterface) map[string]func(context.Context) error {
+	client := New(conn)
+	return map[string]func(context.Context) error{
+		"CreateVehicle": func(ctx context.Context) error {
+			_, err := client.CreateVehicle(ctx, &vehiclepb.CreateVehicleRequest{})
+			return err
+		},
+		"GetVehicle": func(ctx context.Context) error {
+			_, err := client.GetVehicle(ctx, &vehiclepb.GetVehicleRequest{})
+			return err
+		},
+		"ListVehicles": func(ctx context.Context) error {
+			_, err := client.ListVehicles(ctx, &vehiclepb.ListVehiclesRequest{})
+			return err
+		},
+		"UpdateStatus": func(ctx context.Context) error {
+			_, err := client.UpdateStatus(ctx, &vehiclepb.UpdateVehicleStatusRequest{})
+			return err
+		},
+		"GetVehiclesByDriver": func(ctx context.Context) error {
+			_, err := client.GetVehiclesByDriver(ctx, &vehiclepb.GetVehiclesByDriverRequest{})
+			return err
+		},
+		"GetAvailableVehiclesByDrivers": func(ctx context.Context) error {
+			_, err := client.GetAvailableVehiclesByDrivers(ctx, &vehiclepb.GetAvailableVehiclesByDriversRequest{})
+			return err
+		},
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -50,6 +51,14 @@ type DatabaseConfig struct {
 	MaxIdleConns    int           `json:"max_idle_conns"`
 	ConnMaxLifetime time.Duration `json:"conn_max_lifetime"`
 	ConnMaxIdleTime time.Duration `json:"conn_max_idle_time"`
+
+	// Queries at or above this duration are logged as slow
+	SlowQueryThreshold time.Duration `json:"slow_query_threshold"`
+
+	// Read replicas for read-only queries, as lib/pq connection strings, and
+	// the replication lag beyond which a replica is skipped
+	ReplicaDSNs   []string      `json:"replica_dsns"`
+	MaxReplicaLag time.Duration `json:"max_replica_lag"`
 }
 
 // MongoConfig represents MongoDB configuration
@@ -76,6 +85,51 @@ type RedisConfig struct {
 	ReadTimeout  time.Duration `json:"read_timeout"`
 	WriteTimeout time.Duration `json:"write_timeout"`
 	IdleTimeout  time.Duration `json:"idle_timeout"`
+
+	// Topology selects a standalone server, Sentinel or Cluster
+	Topology RedisTopology `json:"topology"`
+}
+
+// Redis topologies
+const (
+	RedisModeStandalone = "standalone"
+	RedisModeSentinel   = "sentinel"
+	RedisModeCluster    = "cluster"
+)
+
+// RedisTopology describes how to reach Redis beyond a single host and port.
+// Sentinel mode uses Addrs as the sentinels and MasterName as the monitored
+// master; cluster mode uses Addrs as seed nodes.
+type RedisTopology struct {
+	Mode             string   `json:"mode" yaml:"redis_mode" env:"REDIS_MODE" default:"standalone"`
+	Addrs            []string `json:"addrs" yaml:"redis_addrs" env:"REDIS_ADDRS"`
+	MasterName       string   `json:"master_name" yaml:"redis_master_name" env:"REDIS_MASTER_NAME"`
+	SentinelPassword string   `json:"sentinel_password" yaml:"redis_sentinel_password" env:"REDIS_SENTINEL_PASSWORD"`
+}
+
+// Validate checks that the topology has the addresses its mode needs
+func (t *RedisTopology) Validate() error {
+	switch t.Mode {
+	case RedisModeStandalone:
+		return nil
+	case RedisModeSentinel:
+		if len(t.Addrs) == 0 || t.MasterName == "" {
+			return fmt.Errorf("redis sentinel mode requires REDIS_ADDRS and REDIS_MASTER_NAME")
+		}
+	case RedisModeCluster:
+		if len(t.Addrs) == 0 {
+			return fmt.Errorf("redis cluster mode requires REDIS_ADDRS")
+		}
+	default:
+		return fmt.Errorf("unknown REDIS_MODE %q, expected standalone, sentinel or cluster", t.Mode)
+	}
+	return nil
+}
+
+// LoadRedisTopology fills the topology of cfg from the config file and
+// environment, for services that build their RedisConfig by hand
+func LoadRedisTopology(cfg *RedisConfig) error {
+	return NewLoader().Load(&cfg.Topology)
 }
 
 // JWTConfig represents JWT configuration
@@ -121,6 +175,10 @@ func LoadConfig() (*Config, error) {
 			MaxIdleConns:    getEnvAsInt("DB_MAX_IDLE_CONNS", 25),
 			ConnMaxLifetime: getEnvAsDuration("DB_CONN_MAX_LIFETIME", time.Hour),
 			ConnMaxIdleTime: getEnvAsDuration("DB_CONN_MAX_IDLE_TIME", 15*time.Minute),
+
+			SlowQueryThreshold: getEnvAsDuration("DB_SLOW_QUERY_THRESHOLD", 200*time.Millisecond),
+			ReplicaDSNs:        getEnvAsSlice("DB_REPLICA_DSNS", nil),
+			MaxReplicaLag:      getEnvAsDuration("DB_MAX_REPLICA_LAG", 5*time.Second),
 		},
 		MongoDB: MongoConfig{
 			URI:                    getEnv("MONGO_URI", "mongodb://mongodb:27017"),
@@ -164,6 +222,10 @@ func LoadConfig() (*Config, error) {
 		LogLevel:    getEnv("LOG_LEVEL", "info"),
 	}
 
+	if err := LoadRedisTopology(&config.Redis); err != nil {
+		return nil, err
+	}
+
 	return config, nil
 }
 
@@ -234,11 +296,9 @@ func getEnvAsDuration(key string, defaultValue time.Duration) time.Duration {
 
 func getEnvAsSlice(key string, defaultValue []string) []string {
 	if value := os.Getenv(key); value != "" {
-		// Simple comma-separated parsing
-		// For more complex parsing, consider using a proper CSV parser
 		result := []string{}
-		for _, item := range []string{value} {
-			if item != "" {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
 				result = append(result, item)
 			}
 		}
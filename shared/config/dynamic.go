@@ -0,0 +1,133 @@
+package config
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/sirupsen/logrus"
+)
+
+// DynamicConfig holds the settings a service picks up without a restart.
+// Service configs embed it under the `dynamic` key of their YAML file.
+type DynamicConfig struct {
+	LogLevel     string          `yaml:"log_level" env:"LOG_LEVEL" default:"info"`
+	FeatureFlags map[string]bool `yaml:"feature_flags" env:"FEATURE_FLAGS"`
+}
+
+// FeatureEnabled reports whether a feature flag is on. Unknown flags are off.
+func (d DynamicConfig) FeatureEnabled(name string) bool {
+	return d.FeatureFlags[name]
+}
+
+// dynamicFile is the part of a service's config file that can be reloaded
+type dynamicFile struct {
+	Dynamic DynamicConfig `yaml:"dynamic"`
+}
+
+// Reloadable holds the current DynamicConfig and reloads it when the config
+// file changes
+type Reloadable struct {
+	loader    *Loader
+	mutex     sync.RWMutex
+	current   DynamicConfig
+	modTime   time.Time
+	listeners []func(DynamicConfig)
+}
+
+// NewReloadable loads the dynamic section through the loader
+func NewReloadable(loader *Loader) (*Reloadable, error) {
+	r := &Reloadable{loader: loader}
+	if _, err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Current returns the active dynamic settings
+func (r *Reloadable) Current() DynamicConfig {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.current
+}
+
+// FeatureEnabled reports whether a feature flag is currently on
+func (r *Reloadable) FeatureEnabled(name string) bool {
+	return r.Current().FeatureEnabled(name)
+}
+
+// OnChange registers a function called with the new settings after every
+// reload that changes them
+func (r *Reloadable) OnChange(fn func(DynamicConfig)) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.listeners = append(r.listeners, fn)
+}
+
+// BindLogLevel keeps a logger's level in step with the dynamic log_level
+func (r *Reloadable) BindLogLevel(log *logger.Logger) {
+	apply := func(d DynamicConfig) {
+		if level, err := logrus.ParseLevel(d.LogLevel); err == nil {
+			log.SetLevel(level)
+		}
+	}
+	apply(r.Current())
+	r.OnChange(apply)
+}
+
+// Reload re-reads the dynamic section and notifies listeners if it changed.
+// A file that fails to parse leaves the current settings in place.
+func (r *Reloadable) Reload() (bool, error) {
+	var file dynamicFile
+	if err := r.loader.Load(&file); err != nil {
+		return false, err
+	}
+
+	r.mutex.Lock()
+	changed := !reflect.DeepEqual(r.current, file.Dynamic)
+	r.current = file.Dynamic
+	listeners := append([]func(DynamicConfig){}, r.listeners...)
+	r.mutex.Unlock()
+
+	if changed {
+		for _, listener := range listeners {
+			listener(file.Dynamic)
+		}
+	}
+	return changed, nil
+}
+
+// StartWatching reloads the dynamic settings whenever the config file's
+// modification time changes, checking every interval until ctx is cancelled
+func (r *Reloadable) StartWatching(ctx context.Context, interval time.Duration, log *logger.Logger) {
+	if r.loader.File() == "" {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			info, err := os.Stat(r.loader.File())
+			if err != nil || !info.ModTime().After(r.modTime) {
+				continue
+			}
+			r.modTime = info.ModTime()
+
+			changed, err := r.Reload()
+			if err != nil {
+				log.WithError(err).Error("Failed to reload configuration")
+				continue
+			}
+			if changed {
+				log.WithField("file", r.loader.File()).Info("Configuration reloaded")
+			}
+		}
+	}
+}
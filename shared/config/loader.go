@@ -0,0 +1,263 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileEnv names the environment variable pointing at a service's YAML config file
+const ConfigFileEnv = "CONFIG_FILE"
+
+// Validator is implemented by config structs that check their own values
+// once every source has been applied
+type Validator interface {
+	Validate() error
+}
+
+// Loader populates typed config structs from, in increasing precedence,
+// `default` struct tags, a YAML file and `env` struct tags.
+//
+//	type Config struct {
+//		HTTPPort int    `yaml:"http_port" env:"HTTP_PORT" default:"8080"`
+//		RedisURL string `yaml:"redis_url" env:"REDIS_URL" required:"true"`
+//	}
+//
+// Nested structs are loaded recursively; their env tags are used as is,
+// without a prefix.
+type Loader struct {
+	file string
+}
+
+// NewLoader creates a loader reading the YAML file named by CONFIG_FILE, if set
+func NewLoader() *Loader {
+	return &Loader{file: os.Getenv(ConfigFileEnv)}
+}
+
+// WithFile sets the YAML file to read. A missing file is not an error, so
+// services run on defaults and environment alone.
+func (l *Loader) WithFile(path string) *Loader {
+	l.file = path
+	return l
+}
+
+// File returns the YAML file the loader reads
+func (l *Loader) File() string {
+	return l.file
+}
+
+// Load fills dst, which must be a pointer to a struct, and validates it
+func (l *Loader) Load(dst interface{}) error {
+	value := reflect.ValueOf(dst)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("config destination must be a pointer to a struct, got %T", dst)
+	}
+
+	if err := applyDefaults(value.Elem()); err != nil {
+		return err
+	}
+	if err := l.applyFile(dst); err != nil {
+		return err
+	}
+	if err := applyEnv(value.Elem()); err != nil {
+		return err
+	}
+	if err := checkRequired(value.Elem()); err != nil {
+		return err
+	}
+	if validator, ok := dst.(Validator); ok {
+		if err := validator.Validate(); err != nil {
+			return fmt.Errorf("invalid configuration: %w", err)
+		}
+	}
+	return nil
+}
+
+func (l *Loader) applyFile(dst interface{}) error {
+	if l.file == "" {
+		return nil
+	}
+	data, err := os.ReadFile(l.file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", l.file, err)
+	}
+	if err := yaml.Unmarshal(data, dst); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", l.file, err)
+	}
+	return nil
+}
+
+func applyDefaults(value reflect.Value) error {
+	return walk(value, func(field reflect.StructField, target reflect.Value) error {
+		if def, ok := field.Tag.Lookup("default"); ok {
+			if err := setValue(target, def); err != nil {
+				return fmt.Errorf("invalid default for %s: %w", field.Name, err)
+			}
+		}
+		return nil
+	})
+}
+
+func applyEnv(value reflect.Value) error {
+	return walk(value, func(field reflect.StructField, target reflect.Value) error {
+		key := field.Tag.Get("env")
+		if key == "" {
+			return nil
+		}
+		raw, ok := os.LookupEnv(key)
+		if !ok || raw == "" {
+			return nil
+		}
+		if err := setValue(target, raw); err != nil {
+			return fmt.Errorf("invalid value for %s: %w", key, err)
+		}
+		return nil
+	})
+}
+
+func checkRequired(value reflect.Value) error {
+	var missing []string
+	walk(value, func(field reflect.StructField, target reflect.Value) error {
+		if field.Tag.Get("required") == "true" && target.IsZero() {
+			name := field.Tag.Get("env")
+			if name == "" {
+				name = field.Name
+			}
+			missing = append(missing, name)
+		}
+		return nil
+	})
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required configuration: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// walk calls fn for every leaf field of a struct, descending into nested
+// structs and allocating nil struct pointers on the way
+func walk(value reflect.Value, fn func(reflect.StructField, reflect.Value) error) error {
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		target := value.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		if target.Kind() == reflect.Ptr && target.Type().Elem().Kind() == reflect.Struct {
+			if target.IsNil() {
+				target.Set(reflect.New(target.Type().Elem()))
+			}
+			target = target.Elem()
+		}
+		if target.Kind() == reflect.Struct && target.Type() != reflect.TypeOf(time.Time{}) {
+			if err := walk(target, fn); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := fn(field, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setValue parses raw into a field. Durations use time.ParseDuration syntax,
+// slices are comma separated and maps are comma separated key=value pairs.
+func setValue(target reflect.Value, raw string) error {
+	if target.Type() == reflect.TypeOf(time.Duration(0)) {
+		duration, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		target.SetInt(int64(duration))
+		return nil
+	}
+
+	switch target.Kind() {
+	case reflect.String:
+		target.SetString(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(raw, 10, target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(raw, 10, target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(raw, target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetFloat(parsed)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		target.SetBool(parsed)
+	case reflect.Slice:
+		items := splitList(raw)
+		slice := reflect.MakeSlice(target.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := setValue(slice.Index(i), item); err != nil {
+				return err
+			}
+		}
+		target.Set(slice)
+	case reflect.Map:
+		mapValue := reflect.MakeMap(target.Type())
+		for _, pair := range splitList(raw) {
+			key, val, ok := strings.Cut(pair, "=")
+			if !ok {
+				return fmt.Errorf("expected key=value, got %q", pair)
+			}
+			k := reflect.New(target.Type().Key()).Elem()
+			if err := setValue(k, strings.TrimSpace(key)); err != nil {
+				return err
+			}
+			v := reflect.New(target.Type().Elem()).Elem()
+			if err := setValue(v, strings.TrimSpace(val)); err != nil {
+				return err
+			}
+			mapValue.SetMapIndex(k, v)
+		}
+		target.Set(mapValue)
+	default:
+		return fmt.Errorf("unsupported config field type %s", target.Type())
+	}
+	return nil
+}
+
+func splitList(raw string) []string {
+	var items []string
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// ValidatePort checks that a TCP port is in range
+func ValidatePort(name string, port int) error {
+	if port <= 0 || port > 65535 {
+		return fmt.Errorf("%s must be between 1 and 65535, got %d", name, port)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+// Package contactproxy lets a trip's rider and driver call each other
+// without learning each other's phone numbers. A session per trip hands each
+// party a handle: a temporary masked number a telephony provider forwards to
+// the other party, or a token for an in-app VoIP call. Sessions are torn down
+// when the trip ends or their lifetime runs out.
+package contactproxy
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Mode is how the parties of a session reach each other
+type Mode string
+
+const (
+	// ModeMaskedNumber gives each party a temporary number that forwards to the other
+	ModeMaskedNumber Mode = "masked_number"
+	// ModeVoIP gives each party a token for an in-app call
+	ModeVoIP Mode = "voip"
+)
+
+// SessionStatus is whether a session's handles still connect
+type SessionStatus string
+
+const (
+	SessionStatusActive   SessionStatus = "active"
+	SessionStatusReleased SessionStatus = "released"
+)
+
+var (
+	// ErrSessionNotFound is returned when a trip has no active session
+	ErrSessionNotFound = errors.New("contact session not found")
+	// ErrModeUnsupported is returned when the provider cannot allocate a mode
+	ErrModeUnsupported = errors.New("contact mode is not supported by the provider")
+	// ErrNumbersExhausted is returned when no masked numbers are free
+	ErrNumbersExhausted = errors.New("no masked numbers are available")
+)
+
+// Party is one side of a proxied conversation. Phone is the party's real
+// number, which masked numbers forward to; it is never handed to the other
+// party.
+type Party struct {
+	UserID string `json:"user_id"`
+	Role   string `json:"role"` // "rider" or "driver"
+	Phone  string `json:"-"`
+}
+
+// Handle is how one party reaches the other: the masked number to dial, or
+// the token to start an in-app call with
+type Handle struct {
+	UserID       string `json:"user_id"`
+	Role         string `json:"role"`
+	MaskedNumber string `json:"masked_number,omitempty"`
+	VoIPToken    string `json:"voip_token,omitempty"`
+}
+
+// Session connects a trip's rider and driver through a provider
+type Session struct {
+	ID          string        `json:"id"`
+	TripID      string        `json:"trip_id"`
+	Mode        Mode          `json:"mode"`
+	Provider    string        `json:"provider"`
+	ProviderRef string        `json:"provider_ref"`
+	Handles     []Handle      `json:"handles"`
+	Status      SessionStatus `json:"status"`
+	ExpiresAt   time.Time     `json:"expires_at"`
+	CreatedAt   time.Time     `json:"created_at"`
+	ReleasedAt  *time.Time    `json:"released_at,omitempty"`
+}
+
+// Handle returns the handle of one of the session's parties
+func (s *Session) Handle(userID string) (Handle, bool) {
+	for _, handle := range s.Handles {
+		if handle.UserID == userID {
+			return handle, true
+		}
+	}
+	return Handle{}, false
+}
+
+// Allocation is what a provider set up for a session
+type Allocation struct {
+	// Ref identifies the allocation to the provider when it is released
+	Ref     string
+	Handles []Handle
+}
+
+// Provider allocates masked numbers or VoIP sessions from a telephony
+// provider, such as a programmable voice API
+type Provider interface {
+	Name() string
+	Supports(mode Mode) bool
+	// Allocate connects the parties until expiresAt, returning a handle per party
+	Allocate(ctx context.Context, tripID string, mode Mode, parties []Party, expiresAt time.Time) (*Allocation, error)
+	// Release disconnects an allocation's handles
+	Release(ctx context.Context, ref string) error
+}
+
+// Store keeps contact sessions
+type Store interface {
+	SaveSession(ctx context.Context, session *Session) error
+	// GetActiveSession returns the trip's active session, or ErrSessionNotFound
+	GetActiveSession(ctx context.Context, tripID string) (*Session, error)
+	ListActiveSessions(ctx context.Context) ([]*Session, error)
+}
@@ -0,0 +1,195 @@
+package contactproxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// Config controls the sessions a manager opens
+type Config struct {
+	// Mode is the preferred mode. Masked numbers fall back to VoIP when a
+	// party has no phone number and the provider supports VoIP.
+	Mode Mode
+	// TTL bounds how long a session lives if its trip's end is missed
+	TTL time.Duration
+}
+
+// DefaultConfig returns the default contact proxy settings
+func DefaultConfig() Config {
+	return Config{
+		Mode: ModeMaskedNumber,
+		TTL:  6 * time.Hour,
+	}
+}
+
+// Manager opens one contact session per trip through a provider and
+// releases it when the trip ends
+type Manager struct {
+	provider Provider
+	store    Store
+	config   Config
+	logger   *logger.Logger
+
+	// mutex keeps two requests from allocating a session for the same trip
+	mutex sync.Mutex
+}
+
+// NewManager creates a new contact session manager
+func NewManager(provider Provider, store Store, config Config, logger *logger.Logger) *Manager {
+	return &Manager{
+		provider: provider,
+		store:    store,
+		config:   config,
+		logger:   logger,
+	}
+}
+
+// Open returns the trip's active session, allocating one from the provider
+// when the trip has none
+func (m *Manager) Open(ctx context.Context, tripID string, parties []Party) (*Session, error) {
+	if tripID == "" || len(parties) < 2 {
+		return nil, fmt.Errorf("trip ID and two parties are required")
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	session, err := m.store.GetActiveSession(ctx, tripID)
+	if err == nil {
+		return session, nil
+	}
+	if !errors.Is(err, ErrSessionNotFound) {
+		return nil, err
+	}
+
+	mode, err := m.mode(parties)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	expiresAt := now.Add(m.config.TTL)
+	allocation, err := m.provider.Allocate(ctx, tripID, mode, parties, expiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to allocate %s contact session: %w", mode, err)
+	}
+
+	session = &Session{
+		ID:          fmt.Sprintf("contact_%d", now.UnixNano()),
+		TripID:      tripID,
+		Mode:        mode,
+		Provider:    m.provider.Name(),
+		ProviderRef: allocation.Ref,
+		Handles:     allocation.Handles,
+		Status:      SessionStatusActive,
+		ExpiresAt:   expiresAt,
+		CreatedAt:   now,
+	}
+	if err := m.store.SaveSession(ctx, session); err != nil {
+		// Don't leave numbers allocated for a session nobody can find
+		m.release(ctx, allocation.Ref)
+		return nil, fmt.Errorf("failed to save contact session: %w", err)
+	}
+
+	m.logger.WithContext(ctx).WithFields(logger.Fields{
+		"session_id": session.ID,
+		"trip_id":    tripID,
+		"mode":       mode,
+		"provider":   session.Provider,
+	}).Info("Contact session opened")
+	return session, nil
+}
+
+// Get returns the trip's active session
+func (m *Manager) Get(ctx context.Context, tripID string) (*Session, error) {
+	return m.store.GetActiveSession(ctx, tripID)
+}
+
+// Close releases the trip's active session. Trips without one are ignored.
+func (m *Manager) Close(ctx context.Context, tripID string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	session, err := m.store.GetActiveSession(ctx, tripID)
+	if errors.Is(err, ErrSessionNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return m.close(ctx, session, time.Now())
+}
+
+// ReleaseExpired releases the sessions whose lifetime has run out and
+// returns how many were released
+func (m *Manager) ReleaseExpired(ctx context.Context, now time.Time) (int, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	sessions, err := m.store.ListActiveSessions(ctx)
+	if err != nil {
+		return 0, err
+	}
+	released := 0
+	for _, session := range sessions {
+		if now.Before(session.ExpiresAt) {
+			continue
+		}
+		if err := m.close(ctx, session, now); err != nil {
+			return released, err
+		}
+		released++
+	}
+	return released, nil
+}
+
+// close releases a session's handles and marks it released. Called with the
+// mutex held.
+func (m *Manager) close(ctx context.Context, session *Session, now time.Time) error {
+	m.release(ctx, session.ProviderRef)
+
+	session.Status = SessionStatusReleased
+	session.ReleasedAt = &now
+	if err := m.store.SaveSession(ctx, session); err != nil {
+		return fmt.Errorf("failed to save contact session: %w", err)
+	}
+
+	m.logger.WithContext(ctx).WithFields(logger.Fields{
+		"session_id": session.ID,
+		"trip_id":    session.TripID,
+	}).Info("Contact session released")
+	return nil
+}
+
+// release asks the provider to disconnect an allocation. A failure is logged
+// rather than returned: the provider's own expiry disconnects the handles,
+// and the session must not stay active for a trip that has ended.
+func (m *Manager) release(ctx context.Context, ref string) {
+	if err := m.provider.Release(ctx, ref); err != nil {
+		m.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+			"provider":     m.provider.Name(),
+			"provider_ref": ref,
+		}).Warn("Failed to release contact session from provider")
+	}
+}
+
+// mode picks the mode for a session between the parties
+func (m *Manager) mode(parties []Party) (Mode, error) {
+	mode := m.config.Mode
+	if mode == ModeMaskedNumber {
+		for _, party := range parties {
+			if party.Phone == "" {
+				mode = ModeVoIP
+				break
+			}
+		}
+	}
+	if !m.provider.Supports(mode) {
+		return "", fmt.Errorf("%w: %s", ErrModeUnsupported, mode)
+	}
+	return mode, nil
+}
@@ -0,0 +1,78 @@
+package contactproxy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// MemoryStore implements Store in memory, storing copies so callers cannot
+// mutate saved sessions. Released sessions are dropped.
+type MemoryStore struct {
+	sessions map[string][]byte // active sessions by trip ID
+	mutex    sync.RWMutex
+}
+
+// NewMemoryStore creates a new in-memory contact session store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		sessions: make(map[string][]byte),
+	}
+}
+
+// SaveSession saves a copy of an active session, or forgets a released one
+func (m *MemoryStore) SaveSession(ctx context.Context, session *Session) error {
+	if session.Status != SessionStatusActive {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+		delete(m.sessions, session.TripID)
+		return nil
+	}
+
+	data, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("failed to marshal contact session: %w", err)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.sessions[session.TripID] = data
+	return nil
+}
+
+// GetActiveSession retrieves a copy of the trip's active session
+func (m *MemoryStore) GetActiveSession(ctx context.Context, tripID string) (*Session, error) {
+	m.mutex.RLock()
+	data, exists := m.sessions[tripID]
+	m.mutex.RUnlock()
+
+	if !exists {
+		return nil, ErrSessionNotFound
+	}
+	return decodeSession(data)
+}
+
+// ListActiveSessions retrieves copies of all active sessions
+func (m *MemoryStore) ListActiveSessions(ctx context.Context) ([]*Session, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	sessions := make([]*Session, 0, len(m.sessions))
+	for _, data := range m.sessions {
+		session, err := decodeSession(data)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+func decodeSession(data []byte) (*Session, error) {
+	var session Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal contact session: %w", err)
+	}
+	return &session, nil
+}
@@ -0,0 +1,113 @@
+package contactproxy
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// LocalProvider hands out masked numbers from a fixed pool and random VoIP
+// tokens, logging the calls it would bridge instead of placing them. It
+// stands in for a real telephony provider in development. Without numbers
+// it only supports VoIP.
+type LocalProvider struct {
+	logger *logger.Logger
+
+	mutex sync.Mutex
+	free  []string
+	// allocations maps an allocation's ref to the numbers it holds
+	allocations map[string][]string
+}
+
+// NewLocalProvider creates a provider allocating masked numbers from numbers
+func NewLocalProvider(numbers []string, logger *logger.Logger) *LocalProvider {
+	return &LocalProvider{
+		logger:      logger,
+		free:        append([]string(nil), numbers...),
+		allocations: make(map[string][]string),
+	}
+}
+
+// Name returns the provider's name
+func (p *LocalProvider) Name() string {
+	return "local"
+}
+
+// Supports reports whether the provider can allocate a mode
+func (p *LocalProvider) Supports(mode Mode) bool {
+	switch mode {
+	case ModeVoIP:
+		return true
+	case ModeMaskedNumber:
+		p.mutex.Lock()
+		defer p.mutex.Unlock()
+		return len(p.free)+len(p.allocations) > 0
+	default:
+		return false
+	}
+}
+
+// Allocate gives each party a masked number or a VoIP token
+func (p *LocalProvider) Allocate(ctx context.Context, tripID string, mode Mode, parties []Party, expiresAt time.Time) (*Allocation, error) {
+	ref, err := randomToken(8)
+	if err != nil {
+		return nil, err
+	}
+	allocation := &Allocation{Ref: ref, Handles: make([]Handle, 0, len(parties))}
+
+	switch mode {
+	case ModeVoIP:
+		for _, party := range parties {
+			token, err := randomToken(16)
+			if err != nil {
+				return nil, err
+			}
+			allocation.Handles = append(allocation.Handles, Handle{UserID: party.UserID, Role: party.Role, VoIPToken: token})
+		}
+	case ModeMaskedNumber:
+		p.mutex.Lock()
+		defer p.mutex.Unlock()
+		if len(p.free) < len(parties) {
+			return nil, ErrNumbersExhausted
+		}
+		numbers := p.free[:len(parties)]
+		p.free = p.free[len(parties):]
+		p.allocations[ref] = numbers
+		for i, party := range parties {
+			allocation.Handles = append(allocation.Handles, Handle{UserID: party.UserID, Role: party.Role, MaskedNumber: numbers[i]})
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrModeUnsupported, mode)
+	}
+
+	p.logger.WithContext(ctx).WithFields(logger.Fields{
+		"trip_id":      tripID,
+		"mode":         mode,
+		"provider_ref": ref,
+		"expires_at":   expiresAt,
+	}).Info("Contact proxy allocated")
+	return allocation, nil
+}
+
+// Release returns an allocation's numbers to the pool
+func (p *LocalProvider) Release(ctx context.Context, ref string) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.free = append(p.free, p.allocations[ref]...)
+	delete(p.allocations, ref)
+	return nil
+}
+
+func randomToken(bytes int) (string, error) {
+	buf := make([]byte, bytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate contact token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,233 @@
+package crypto
+
+import (
+	"context"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// ciphertextPrefix marks encrypted values, which read
+	// enc:v1:<master key ID>:<wrapped data key>:<nonce and ciphertext>
+	ciphertextPrefix = "enc:v1:"
+
+	// A data key seals at most dataKeyMaxUses values, well below the limit
+	// for random GCM nonces, and is replaced after dataKeyLifetime
+	dataKeyMaxUses  = 1 << 20
+	dataKeyLifetime = 24 * time.Hour
+
+	// maxCachedDataKeys bounds the unwrapped data keys kept for decryption
+	maxCachedDataKeys = 1024
+)
+
+// Cipher seals values with envelope encryption. Data keys are generated
+// locally and reused for many values, so the key manager is only called
+// when a data key is created or first seen.
+//
+// A nil Cipher leaves values in plaintext, so repositories work the same
+// with encryption disabled.
+type Cipher struct {
+	keys     KeyManager
+	indexKey []byte
+	now      func() time.Time
+
+	mutex     sync.Mutex
+	current   *dataKey
+	unwrapped map[string]cipher.AEAD
+}
+
+type dataKey struct {
+	keyID     string
+	wrapped   string
+	aead      cipher.AEAD
+	uses      int
+	createdAt time.Time
+}
+
+// NewCipher creates a cipher wrapping its data keys with keys, and
+// computing blind indexes with indexKey
+func NewCipher(keys KeyManager, indexKey []byte) *Cipher {
+	return &Cipher{
+		keys:      keys,
+		indexKey:  indexKey,
+		now:       time.Now,
+		unwrapped: make(map[string]cipher.AEAD),
+	}
+}
+
+// IsEncrypted reports whether a stored value was sealed by a Cipher
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, ciphertextPrefix)
+}
+
+// Encrypt seals a value. Empty values are kept empty.
+func (c *Cipher) Encrypt(ctx context.Context, plaintext string) (string, error) {
+	if c == nil || plaintext == "" {
+		return plaintext, nil
+	}
+
+	key, err := c.dataKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := seal(key.aead, []byte(plaintext), nil)
+	if err != nil {
+		return "", err
+	}
+	return ciphertextPrefix + key.keyID + ":" + key.wrapped + ":" + base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt opens a sealed value. Values stored before encryption was enabled
+// are returned as they are.
+func (c *Cipher) Decrypt(ctx context.Context, value string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	if c == nil {
+		return "", ErrNoCipher
+	}
+
+	keyID, wrapped, sealed, err := parseCiphertext(value)
+	if err != nil {
+		return "", err
+	}
+	aead, err := c.unwrap(ctx, keyID, wrapped)
+	if err != nil {
+		return "", err
+	}
+	data, err := base64.RawURLEncoding.DecodeString(sealed)
+	if err != nil {
+		return "", ErrMalformedCiphertext
+	}
+	plaintext, err := open(aead, data, nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// NeedsRotation reports whether a stored value should be re-encrypted: it is
+// in plaintext, or sealed under a master key that is no longer active
+func (c *Cipher) NeedsRotation(value string) bool {
+	if c == nil || value == "" {
+		return false
+	}
+	if !IsEncrypted(value) {
+		return true
+	}
+	keyID, _, _, err := parseCiphertext(value)
+	return err == nil && keyID != c.keys.ActiveKeyID()
+}
+
+// Reencrypt seals a stored value again under the active master key
+func (c *Cipher) Reencrypt(ctx context.Context, value string) (string, error) {
+	plaintext, err := c.Decrypt(ctx, value)
+	if err != nil {
+		return "", err
+	}
+	return c.Encrypt(ctx, plaintext)
+}
+
+// BlindIndex returns a keyed hash of a value, stored next to its ciphertext
+// so the column can still be searched by exact value. It is empty for a nil
+// Cipher or an empty value.
+func (c *Cipher) BlindIndex(value string) string {
+	if c == nil || value == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, c.indexKey)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// dataKey returns the data key to seal the next value with, replacing it
+// when it is used up, too old or the active master key has changed
+func (c *Cipher) dataKey(ctx context.Context) (*dataKey, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	current := c.current
+	if current != nil && current.uses < dataKeyMaxUses &&
+		c.now().Sub(current.createdAt) < dataKeyLifetime &&
+		current.keyID == c.keys.ActiveKeyID() {
+		current.uses++
+		return current, nil
+	}
+
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+	keyID, wrapped, err := c.keys.WrapKey(ctx, raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap data key: %w", err)
+	}
+	aead, err := newAEAD(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	c.current = &dataKey{
+		keyID:     keyID,
+		wrapped:   base64.RawURLEncoding.EncodeToString(wrapped),
+		aead:      aead,
+		uses:      1,
+		createdAt: c.now(),
+	}
+	c.cache(keyID, c.current.wrapped, aead)
+	return c.current, nil
+}
+
+// unwrap returns the data key a value was sealed with, asking the key
+// manager only the first time the key is seen
+func (c *Cipher) unwrap(ctx context.Context, keyID, wrapped string) (cipher.AEAD, error) {
+	c.mutex.Lock()
+	aead, cached := c.unwrapped[keyID+":"+wrapped]
+	c.mutex.Unlock()
+	if cached {
+		return aead, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(wrapped)
+	if err != nil {
+		return nil, ErrMalformedCiphertext
+	}
+	raw, err := c.keys.UnwrapKey(ctx, keyID, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	aead, err = newAEAD(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	c.cache(keyID, wrapped, aead)
+	c.mutex.Unlock()
+	return aead, nil
+}
+
+// cache keeps an unwrapped data key, starting over when the cache is full.
+// The caller holds the mutex.
+func (c *Cipher) cache(keyID, wrapped string, aead cipher.AEAD) {
+	if len(c.unwrapped) >= maxCachedDataKeys {
+		c.unwrapped = make(map[string]cipher.AEAD)
+	}
+	c.unwrapped[keyID+":"+wrapped] = aead
+}
+
+func parseCiphertext(value string) (keyID, wrapped, sealed string, err error) {
+	parts := strings.Split(strings.TrimPrefix(value, ciphertextPrefix), ":")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", ErrMalformedCiphertext
+	}
+	return parts[0], parts[1], parts[2], nil
+}
@@ -0,0 +1,257 @@
+package crypto_test
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rideshare-platform/shared/crypto"
+	"github.com/rideshare-platform/shared/crypto/cryptotest"
+)
+
+// sealedParts splits a ciphertext into its key ID, wrapped data key and
+// decoded nonce and ciphertext
+func sealedParts(t *testing.T, value string) (string, string, []byte) {
+	t.Helper()
+	keyID, wrapped, sealed, err := crypto.ParseCiphertext(value)
+	require.NoError(t, err)
+	data, err := base64.RawURLEncoding.DecodeString(sealed)
+	require.NoError(t, err)
+	return keyID, wrapped, data
+}
+
+func joinSealed(keyID, wrapped string, data []byte) string {
+	return crypto.CiphertextPrefix + keyID + ":" + wrapped + ":" + base64.RawURLEncoding.EncodeToString(data)
+}
+
+func TestCipher_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	cipher := cryptotest.NewCipher(t, map[string][]byte{"k1": cryptotest.NewKey(t)}, "k1", cryptotest.NewKey(t))
+
+	tests := []struct {
+		name      string
+		plaintext string
+	}{
+		{"email", "rider@example.com"},
+		{"phone", "+90 555 123 45 67"},
+		{"unicode", "Çağla Öztürk"},
+		{"separators", "enc:v1:a:b:c"},
+		{"long", strings.Repeat("x", 4096)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sealed, err := cipher.Encrypt(ctx, tt.plaintext)
+			require.NoError(t, err)
+			assert.True(t, crypto.IsEncrypted(sealed))
+			assert.NotContains(t, sealed, tt.plaintext)
+
+			opened, err := cipher.Decrypt(ctx, sealed)
+			require.NoError(t, err)
+			assert.Equal(t, tt.plaintext, opened)
+
+			again, err := cipher.Encrypt(ctx, tt.plaintext)
+			require.NoError(t, err)
+			assert.NotEqual(t, sealed, again, "every value gets a fresh nonce")
+		})
+	}
+
+	empty, err := cipher.Encrypt(ctx, "")
+	require.NoError(t, err)
+	assert.Empty(t, empty, "empty values stay empty")
+
+	legacy, err := cipher.Decrypt(ctx, "stored before encryption")
+	require.NoError(t, err)
+	assert.Equal(t, "stored before encryption", legacy)
+}
+
+func TestCipher_NilLeavesPlaintext(t *testing.T) {
+	ctx := context.Background()
+	var cipher *crypto.Cipher
+
+	value, err := cipher.Encrypt(ctx, "rider@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "rider@example.com", value)
+	assert.Empty(t, cipher.BlindIndex("rider@example.com"))
+	assert.False(t, cipher.NeedsRotation("rider@example.com"))
+
+	sealed, err := cryptotest.NewCipher(t, map[string][]byte{"k1": cryptotest.NewKey(t)}, "k1", cryptotest.NewKey(t)).Encrypt(ctx, "rider@example.com")
+	require.NoError(t, err)
+	_, err = cipher.Decrypt(ctx, sealed)
+	assert.ErrorIs(t, err, crypto.ErrNoCipher)
+}
+
+func TestCipher_RejectsTampering(t *testing.T) {
+	ctx := context.Background()
+	keys := map[string][]byte{"k1": cryptotest.NewKey(t), "k2": cryptotest.NewKey(t)}
+	cipher := cryptotest.NewCipher(t, keys, "k1", cryptotest.NewKey(t))
+
+	sealed, err := cipher.Encrypt(ctx, "rider@example.com")
+	require.NoError(t, err)
+	keyID, wrapped, data := sealedParts(t, sealed)
+	other, err := cipher.Encrypt(ctx, "driver@example.com")
+	require.NoError(t, err)
+	_, _, otherData := sealedParts(t, other)
+
+	flip := func(data []byte, i int) []byte {
+		tampered := append([]byte(nil), data...)
+		tampered[i] ^= 0x01
+		return tampered
+	}
+	wrappedBytes, err := base64.RawURLEncoding.DecodeString(wrapped)
+	require.NoError(t, err)
+	nonceSize := 12
+
+	tests := []struct {
+		name    string
+		value   string
+		wantErr error
+	}{
+		{"flipped ciphertext byte", joinSealed(keyID, wrapped, flip(data, nonceSize+1)), crypto.ErrMalformedCiphertext},
+		{"flipped tag byte", joinSealed(keyID, wrapped, flip(data, len(data)-1)), crypto.ErrMalformedCiphertext},
+		{"flipped nonce byte", joinSealed(keyID, wrapped, flip(data, 0)), crypto.ErrMalformedCiphertext},
+		{"nonce of another value", joinSealed(keyID, wrapped, append(append([]byte(nil), otherData[:nonceSize]...), data[nonceSize:]...)), crypto.ErrMalformedCiphertext},
+		{"truncated below the nonce", joinSealed(keyID, wrapped, data[:nonceSize-1]), crypto.ErrMalformedCiphertext},
+		{"key ID of another master key", joinSealed("k2", wrapped, data), crypto.ErrMalformedCiphertext},
+		{"unknown key ID", joinSealed("k9", wrapped, data), crypto.ErrUnknownKey},
+		{"flipped wrapped data key", joinSealed(keyID, base64.RawURLEncoding.EncodeToString(flip(wrappedBytes, 20)), data), crypto.ErrMalformedCiphertext},
+		{"wrapped data key not base64", joinSealed(keyID, "!!!", data), crypto.ErrMalformedCiphertext},
+		{"ciphertext not base64", crypto.CiphertextPrefix + keyID + ":" + wrapped + ":!!!", crypto.ErrMalformedCiphertext},
+		{"missing part", crypto.CiphertextPrefix + keyID + ":" + wrapped, crypto.ErrMalformedCiphertext},
+		{"empty key ID", joinSealed("", wrapped, data), crypto.ErrMalformedCiphertext},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := cipher.Decrypt(ctx, tt.value)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+
+	opened, err := cipher.Decrypt(ctx, sealed)
+	require.NoError(t, err)
+	assert.Equal(t, "rider@example.com", opened, "the untouched value still opens")
+}
+
+func TestCipher_DecryptsUnderRetiredKey(t *testing.T) {
+	ctx := context.Background()
+	oldKey, newKey, indexKey := cryptotest.NewKey(t), cryptotest.NewKey(t), cryptotest.NewKey(t)
+
+	before := cryptotest.NewCipher(t, map[string][]byte{"2024-01": oldKey}, "2024-01", indexKey)
+	sealed, err := before.Encrypt(ctx, "rider@example.com")
+	require.NoError(t, err)
+
+	// The new key is made active while the old one stays listed
+	after := cryptotest.NewCipher(t, map[string][]byte{"2024-01": oldKey, "2024-07": newKey}, "2024-07", indexKey)
+	opened, err := after.Decrypt(ctx, sealed)
+	require.NoError(t, err)
+	assert.Equal(t, "rider@example.com", opened)
+	assert.True(t, after.NeedsRotation(sealed))
+	assert.True(t, after.NeedsRotation("stored before encryption"))
+	assert.False(t, after.NeedsRotation(""))
+
+	rotated, err := after.Reencrypt(ctx, sealed)
+	require.NoError(t, err)
+	keyID, _, _ := sealedParts(t, rotated)
+	assert.Equal(t, "2024-07", keyID)
+	assert.False(t, after.NeedsRotation(rotated))
+
+	// Once rotation has finished the old key can be dropped
+	retired := cryptotest.NewCipher(t, map[string][]byte{"2024-07": newKey}, "2024-07", indexKey)
+	opened, err = retired.Decrypt(ctx, rotated)
+	require.NoError(t, err)
+	assert.Equal(t, "rider@example.com", opened)
+	_, err = retired.Decrypt(ctx, sealed)
+	assert.ErrorIs(t, err, crypto.ErrUnknownKey)
+}
+
+func TestCipher_BlindIndex(t *testing.T) {
+	oldKey, newKey, indexKey := cryptotest.NewKey(t), cryptotest.NewKey(t), cryptotest.NewKey(t)
+	cipher := cryptotest.NewCipher(t, map[string][]byte{"k1": oldKey}, "k1", indexKey)
+	rotated := cryptotest.NewCipher(t, map[string][]byte{"k1": oldKey, "k2": newKey}, "k2", indexKey)
+	otherIndex := cryptotest.NewCipher(t, map[string][]byte{"k1": oldKey}, "k1", cryptotest.NewKey(t))
+
+	index := cipher.BlindIndex("rider@example.com")
+	assert.Len(t, index, 64)
+	assert.Equal(t, index, cipher.BlindIndex("rider@example.com"), "stable, so it can be looked up")
+	assert.Equal(t, index, rotated.BlindIndex("rider@example.com"), "unaffected by master key rotation")
+	assert.NotEqual(t, index, cipher.BlindIndex("driver@example.com"))
+	assert.NotEqual(t, index, otherIndex.BlindIndex("rider@example.com"), "keyed by the index key")
+	assert.NotContains(t, index, "rider")
+	assert.Empty(t, cipher.BlindIndex(""))
+}
+
+func TestCipher_ReplacesDataKeyWhenActiveKeyChanges(t *testing.T) {
+	ctx := context.Background()
+	manager, err := crypto.NewLocalKeyManager(map[string][]byte{"k1": cryptotest.NewKey(t), "k2": cryptotest.NewKey(t)}, "k1")
+	require.NoError(t, err)
+	cipher := crypto.NewCipher(manager, cryptotest.NewKey(t))
+
+	first, err := cipher.Encrypt(ctx, "a")
+	require.NoError(t, err)
+	second, err := cipher.Encrypt(ctx, "b")
+	require.NoError(t, err)
+	_, firstWrapped, _ := sealedParts(t, first)
+	_, secondWrapped, _ := sealedParts(t, second)
+	assert.Equal(t, firstWrapped, secondWrapped, "a data key seals many values")
+
+	manager.SetActiveKeyID("k2")
+	third, err := cipher.Encrypt(ctx, "c")
+	require.NoError(t, err)
+	keyID, _, _ := sealedParts(t, third)
+	assert.Equal(t, "k2", keyID)
+}
+
+func TestConfig_Validate(t *testing.T) {
+	encode := func(key []byte) string { return base64.StdEncoding.EncodeToString(key) }
+	valid := func() crypto.Config {
+		return crypto.Config{
+			Enabled:           true,
+			MasterKeys:        map[string]string{"2024-01": encode(cryptotest.NewKey(t))},
+			ActiveKeyID:       "2024-01",
+			IndexKey:          encode(cryptotest.NewKey(t)),
+			RotationInterval:  crypto.DefaultRotationInterval,
+			RotationBatchSize: crypto.DefaultRotationBatchSize,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(*crypto.Config)
+		wantErr bool
+	}{
+		{"valid", func(c *crypto.Config) {}, false},
+		{"disabled without keys", func(c *crypto.Config) { *c = crypto.Config{} }, false},
+		{"no master keys", func(c *crypto.Config) { c.MasterKeys = nil }, true},
+		{"active key not listed", func(c *crypto.Config) { c.ActiveKeyID = "2024-07" }, true},
+		{"key ID with a colon", func(c *crypto.Config) {
+			c.MasterKeys = map[string]string{"2024:01": c.MasterKeys["2024-01"]}
+			c.ActiveKeyID = "2024:01"
+		}, true},
+		{"short master key", func(c *crypto.Config) { c.MasterKeys["2024-01"] = encode(make([]byte, 16)) }, true},
+		{"master key not base64", func(c *crypto.Config) { c.MasterKeys["2024-01"] = "not base64!" }, true},
+		{"no index key", func(c *crypto.Config) { c.IndexKey = "" }, true},
+		{"no rotation interval", func(c *crypto.Config) { c.RotationInterval = 0 }, true},
+		{"no rotation batch size", func(c *crypto.Config) { c.RotationBatchSize = 0 }, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := valid()
+			tt.modify(&config)
+			err := config.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	cipher, err := crypto.Open(valid())
+	require.NoError(t, err)
+	assert.NotNil(t, cipher)
+	_, err = crypto.Open(crypto.Config{})
+	assert.Error(t, err, "a disabled config opens no cipher")
+}
@@ -0,0 +1,146 @@
+// Package crypto encrypts personal data before it is stored. Values are
+// sealed with envelope encryption: each is encrypted with AES-256-GCM under a
+// data key, and the data key is stored next to it wrapped by a master key the
+// key manager never hands out, the way a cloud KMS works. Struct fields
+// tagged `pii:"encrypt"` are encrypted and decrypted by repositories, and
+// blind indexes keep encrypted columns searchable by exact value.
+//
+// Master keys are rotated by adding a new key, making it active and letting
+// the Rotator re-encrypt every value sealed under the old ones. The old key
+// can be dropped once a rotation run reports nothing left to rotate. The
+// rotation also encrypts values stored before encryption was enabled.
+package crypto
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	sharedconfig "github.com/rideshare-platform/shared/config"
+)
+
+var (
+	// ErrUnknownKey is returned when a value was sealed under a master key
+	// the key manager does not hold
+	ErrUnknownKey = errors.New("unknown master key")
+	// ErrMalformedCiphertext is returned when an encrypted value cannot be parsed
+	ErrMalformedCiphertext = errors.New("malformed ciphertext")
+	// ErrNoCipher is returned when decrypting an encrypted value without a cipher
+	ErrNoCipher = errors.New("encrypted value found but encryption is not configured")
+)
+
+const (
+	// DefaultRotationInterval is how often a started rotator runs
+	DefaultRotationInterval = time.Hour
+	// DefaultRotationBatchSize is the most records a rotator re-encrypts per query
+	DefaultRotationBatchSize = 500
+)
+
+// Config holds a service's field encryption settings
+type Config struct {
+	Enabled bool `yaml:"enabled" env:"PII_ENCRYPTION_ENABLED" default:"false"`
+	// MasterKeys maps key IDs to base64 encoded 256-bit keys, e.g.
+	// "2024-01=...,2024-07=...". Keys no longer active stay listed until
+	// rotation has moved every value off them.
+	MasterKeys  map[string]string `yaml:"master_keys" env:"PII_MASTER_KEYS"`
+	ActiveKeyID string            `yaml:"active_key_id" env:"PII_ACTIVE_KEY_ID"`
+	// IndexKey is the base64 encoded key blind indexes are computed with. It
+	// is never rotated, as every index would have to be recomputed.
+	IndexKey          string        `yaml:"index_key" env:"PII_INDEX_KEY"`
+	RotationInterval  time.Duration `yaml:"rotation_interval" env:"PII_ROTATION_INTERVAL" default:"1h"`
+	RotationBatchSize int           `yaml:"rotation_batch_size" env:"PII_ROTATION_BATCH_SIZE" default:"500"`
+}
+
+// LoadConfig reads the encryption settings from the PII_* environment
+// variables, for services that do not load their config through the shared loader
+func LoadConfig() (Config, error) {
+	var config Config
+	if err := sharedconfig.NewLoader().WithFile("").Load(&config); err != nil {
+		return Config{}, err
+	}
+	return config, nil
+}
+
+// Validate checks that an enabled config holds usable keys
+func (c *Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if _, err := c.masterKeys(); err != nil {
+		return err
+	}
+	if _, err := decodeKey("PII_INDEX_KEY", c.IndexKey); err != nil {
+		return err
+	}
+	if c.RotationInterval <= 0 {
+		return fmt.Errorf("PII_ROTATION_INTERVAL must be positive, got %s", c.RotationInterval)
+	}
+	if c.RotationBatchSize < 1 {
+		return fmt.Errorf("PII_ROTATION_BATCH_SIZE must be at least 1, got %d", c.RotationBatchSize)
+	}
+	return nil
+}
+
+// Open creates a cipher from an enabled config, with its master keys held
+// by a LocalKeyManager
+func Open(config Config) (*Cipher, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if !config.Enabled {
+		return nil, errors.New("field encryption is not enabled")
+	}
+
+	keys, err := config.masterKeys()
+	if err != nil {
+		return nil, err
+	}
+	keyManager, err := NewLocalKeyManager(keys, config.ActiveKeyID)
+	if err != nil {
+		return nil, err
+	}
+	indexKey, err := decodeKey("PII_INDEX_KEY", config.IndexKey)
+	if err != nil {
+		return nil, err
+	}
+	return NewCipher(keyManager, indexKey), nil
+}
+
+func (c *Config) masterKeys() (map[string][]byte, error) {
+	if len(c.MasterKeys) == 0 {
+		return nil, errors.New("PII_MASTER_KEYS is required when encryption is enabled")
+	}
+	if _, exists := c.MasterKeys[c.ActiveKeyID]; !exists {
+		return nil, fmt.Errorf("PII_ACTIVE_KEY_ID %q is not one of PII_MASTER_KEYS", c.ActiveKeyID)
+	}
+
+	keys := make(map[string][]byte, len(c.MasterKeys))
+	for id, encoded := range c.MasterKeys {
+		if id == "" || strings.Contains(id, ":") {
+			return nil, fmt.Errorf("master key ID %q must be non-empty and must not contain ':'", id)
+		}
+		key, err := decodeKey("master key "+id, encoded)
+		if err != nil {
+			return nil, err
+		}
+		keys[id] = key
+	}
+	return keys, nil
+}
+
+// decodeKey decodes a base64 encoded 256-bit key
+func decodeKey(name, encoded string) ([]byte, error) {
+	if encoded == "" {
+		return nil, fmt.Errorf("%s is required when encryption is enabled", name)
+	}
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not valid base64: %w", name, err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("%s must be 32 bytes, got %d", name, len(key))
+	}
+	return key, nil
+}
@@ -0,0 +1,61 @@
+// Package cryptotest provides keys, ciphers and sqlmock argument matchers for
+// tests of code that encrypts fields with the crypto package.
+package cryptotest
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/rideshare-platform/shared/crypto"
+)
+
+// NewKey returns a random 32-byte key, usable as a master or index key
+func NewKey(t testing.TB) []byte {
+	t.Helper()
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	return key
+}
+
+// NewCipher returns a cipher on local master keys with activeID sealing new
+// values. A nil index key is replaced by a random one.
+func NewCipher(t testing.TB, keys map[string][]byte, activeID string, indexKey []byte) *crypto.Cipher {
+	t.Helper()
+	manager, err := crypto.NewLocalKeyManager(keys, activeID)
+	require.NoError(t, err)
+	if indexKey == nil {
+		indexKey = NewKey(t)
+	}
+	return crypto.NewCipher(manager, indexKey)
+}
+
+// Seal encrypts plaintext with cipher
+func Seal(t testing.TB, cipher *crypto.Cipher, plaintext string) string {
+	t.Helper()
+	sealed, err := cipher.Encrypt(context.Background(), plaintext)
+	require.NoError(t, err)
+	return sealed
+}
+
+// SealedUnder is a sqlmock argument matching a value sealed under the
+// cipher's active key that opens to Plaintext
+type SealedUnder struct {
+	Cipher    *crypto.Cipher
+	Plaintext string
+}
+
+// Match reports whether value is sealed under the active key and opens to
+// the plaintext
+func (s SealedUnder) Match(value driver.Value) bool {
+	sealed, ok := value.(string)
+	if !ok || !crypto.IsEncrypted(sealed) || s.Cipher.NeedsRotation(sealed) {
+		return false
+	}
+	opened, err := s.Cipher.Decrypt(context.Background(), sealed)
+	return err == nil && opened == s.Plaintext
+}
@@ -0,0 +1,13 @@
+package crypto
+
+// Exported for the tests in crypto_test, which use cryptotest and so cannot
+// be in this package
+
+const CiphertextPrefix = ciphertextPrefix
+
+var ParseCiphertext = parseCiphertext
+
+// SetActiveKeyID makes another listed key the one new values are sealed under
+func (m *LocalKeyManager) SetActiveKeyID(id string) {
+	m.activeID = id
+}
@@ -0,0 +1,87 @@
+package crypto
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// fieldTag marks the struct fields repositories encrypt, e.g.
+//
+//	Email string `json:"email" pii:"encrypt"`
+//
+// Tagged fields must be strings or string pointers.
+const fieldTag = "pii"
+
+// EncryptFields seals the tagged fields of the struct v points to, in place.
+// Fields that are already sealed are left alone, so encrypting twice is safe.
+func (c *Cipher) EncryptFields(ctx context.Context, v interface{}) error {
+	return c.transformFields(v, func(value string) (string, error) {
+		if IsEncrypted(value) {
+			return value, nil
+		}
+		return c.Encrypt(ctx, value)
+	})
+}
+
+// DecryptFields opens the tagged fields of the struct v points to, in place
+func (c *Cipher) DecryptFields(ctx context.Context, v interface{}) error {
+	return c.transformFields(v, func(value string) (string, error) {
+		return c.Decrypt(ctx, value)
+	})
+}
+
+// RotateFields re-encrypts the tagged fields of the struct v points to that
+// need rotation, and reports whether any did
+func (c *Cipher) RotateFields(ctx context.Context, v interface{}) (bool, error) {
+	rotated := false
+	err := c.transformFields(v, func(value string) (string, error) {
+		if !c.NeedsRotation(value) {
+			return value, nil
+		}
+		rotated = true
+		return c.Reencrypt(ctx, value)
+	})
+	return rotated, err
+}
+
+func (c *Cipher) transformFields(v interface{}, transform func(string) (string, error)) error {
+	target := reflect.ValueOf(v)
+	if target.Kind() != reflect.Pointer || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("crypto: expected a pointer to a struct, got %T", v)
+	}
+	target = target.Elem()
+
+	for i := 0; i < target.NumField(); i++ {
+		field := target.Type().Field(i)
+		if field.Tag.Get(fieldTag) != "encrypt" {
+			continue
+		}
+
+		value := target.Field(i)
+		pointer := value.Kind() == reflect.Pointer
+		if pointer {
+			if value.IsNil() {
+				continue
+			}
+			value = value.Elem()
+		}
+		if value.Kind() != reflect.String {
+			return fmt.Errorf("crypto: field %s tagged %s:\"encrypt\" must be a string, got %s", field.Name, fieldTag, field.Type)
+		}
+
+		transformed, err := transform(value.String())
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		if pointer {
+			// A fresh string, as the pointer may be shared with a copy of the struct
+			fresh := reflect.New(value.Type())
+			fresh.Elem().SetString(transformed)
+			target.Field(i).Set(fresh)
+			continue
+		}
+		value.SetString(transformed)
+	}
+	return nil
+}
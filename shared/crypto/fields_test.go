@@ -0,0 +1,124 @@
+package crypto_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rideshare-platform/shared/crypto"
+	"github.com/rideshare-platform/shared/crypto/cryptotest"
+)
+
+type testProfile struct {
+	Name     string
+	Email    string  `pii:"encrypt"`
+	Phone    *string `pii:"encrypt"`
+	Address  *string `pii:"encrypt"`
+	Nickname string  `pii:"plain"`
+}
+
+func TestCipher_EncryptAndDecryptFields(t *testing.T) {
+	ctx := context.Background()
+	cipher := cryptotest.NewCipher(t, map[string][]byte{"k1": cryptotest.NewKey(t)}, "k1", cryptotest.NewKey(t))
+
+	phone := "+90 555 123 45 67"
+	tests := []struct {
+		name    string
+		profile testProfile
+	}{
+		{"string and pointer fields", testProfile{Name: "Ada", Email: "ada@example.com", Phone: &phone, Nickname: "ada"}},
+		{"nil pointer field", testProfile{Name: "Ada", Email: "ada@example.com"}},
+		{"empty fields", testProfile{Name: "Ada", Phone: new(string)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := tt.profile
+			profile := tt.profile
+			require.NoError(t, cipher.EncryptFields(ctx, &profile))
+
+			assert.Equal(t, original.Name, profile.Name, "untagged fields are left alone")
+			assert.Equal(t, original.Nickname, profile.Nickname, "other pii tags are left alone")
+			assert.Equal(t, original.Email != "", crypto.IsEncrypted(profile.Email))
+			assert.Nil(t, profile.Address, "nil pointers stay nil")
+			if original.Phone != nil {
+				require.NotNil(t, profile.Phone)
+				assert.Equal(t, *original.Phone != "", crypto.IsEncrypted(*profile.Phone))
+				assert.Equal(t, phoneOf(tt.profile), *original.Phone, "the caller's string is not overwritten")
+			}
+
+			encrypted := profile
+			require.NoError(t, cipher.EncryptFields(ctx, &profile))
+			assert.Equal(t, encrypted.Email, profile.Email, "encrypting twice leaves sealed fields alone")
+
+			require.NoError(t, cipher.DecryptFields(ctx, &profile))
+			assert.Equal(t, original.Email, profile.Email)
+			assert.Equal(t, phoneOf(original), phoneOf(profile))
+			assert.Nil(t, profile.Address)
+		})
+	}
+}
+
+func phoneOf(profile testProfile) string {
+	if profile.Phone == nil {
+		return "<nil>"
+	}
+	return *profile.Phone
+}
+
+func TestCipher_FieldsRejectUnsupportedTargets(t *testing.T) {
+	ctx := context.Background()
+	cipher := cryptotest.NewCipher(t, map[string][]byte{"k1": cryptotest.NewKey(t)}, "k1", cryptotest.NewKey(t))
+
+	var nilProfile *testProfile
+	badField := struct {
+		Age int `pii:"encrypt"`
+	}{Age: 30}
+	tests := []struct {
+		name   string
+		target interface{}
+	}{
+		{"struct value", testProfile{}},
+		{"nil pointer", nilProfile},
+		{"pointer to a non-struct", new(string)},
+		{"tagged non-string field", &badField},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, cipher.EncryptFields(ctx, tt.target))
+			assert.Error(t, cipher.DecryptFields(ctx, tt.target))
+		})
+	}
+}
+
+func TestCipher_RotateFields(t *testing.T) {
+	ctx := context.Background()
+	oldKey, newKey, indexKey := cryptotest.NewKey(t), cryptotest.NewKey(t), cryptotest.NewKey(t)
+	before := cryptotest.NewCipher(t, map[string][]byte{"k1": oldKey}, "k1", indexKey)
+	after := cryptotest.NewCipher(t, map[string][]byte{"k1": oldKey, "k2": newKey}, "k2", indexKey)
+
+	phone := "+90 555 123 45 67"
+	profile := testProfile{Email: "ada@example.com", Phone: &phone}
+	require.NoError(t, before.EncryptFields(ctx, &profile))
+
+	rotated, err := after.RotateFields(ctx, &profile)
+	require.NoError(t, err)
+	assert.True(t, rotated)
+	assert.False(t, after.NeedsRotation(profile.Email))
+	assert.False(t, after.NeedsRotation(*profile.Phone))
+
+	rotated, err = after.RotateFields(ctx, &profile)
+	require.NoError(t, err)
+	assert.False(t, rotated, "nothing left to rotate")
+
+	legacy := testProfile{Email: "stored@example.com"}
+	rotated, err = after.RotateFields(ctx, &legacy)
+	require.NoError(t, err)
+	assert.True(t, rotated, "plaintext values are encrypted")
+	assert.True(t, crypto.IsEncrypted(legacy.Email))
+
+	require.NoError(t, after.DecryptFields(ctx, &profile))
+	assert.Equal(t, "ada@example.com", profile.Email)
+	assert.Equal(t, phone, *profile.Phone)
+}
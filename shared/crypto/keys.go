@@ -0,0 +1,100 @@
+package crypto
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+)
+
+// KeyManager wraps data keys under master keys it never hands out. A cloud
+// KMS can stand behind it; LocalKeyManager holds the keys in process.
+type KeyManager interface {
+	// ActiveKeyID names the master key new data keys are wrapped under
+	ActiveKeyID() string
+	// WrapKey encrypts a data key under the active master key and returns
+	// the key's ID with the wrapped data key
+	WrapKey(ctx context.Context, dataKey []byte) (keyID string, wrapped []byte, err error)
+	// UnwrapKey decrypts a data key wrapped under the named master key
+	UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
+
+// LocalKeyManager wraps data keys with AES-256-GCM under master keys read
+// from configuration
+type LocalKeyManager struct {
+	keys     map[string]cipher.AEAD
+	activeID string
+}
+
+// NewLocalKeyManager creates a key manager holding the given 256-bit master
+// keys by ID
+func NewLocalKeyManager(keys map[string][]byte, activeID string) (*LocalKeyManager, error) {
+	manager := &LocalKeyManager{
+		keys:     make(map[string]cipher.AEAD, len(keys)),
+		activeID: activeID,
+	}
+	for id, key := range keys {
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, fmt.Errorf("master key %s: %w", id, err)
+		}
+		manager.keys[id] = aead
+	}
+	if _, exists := manager.keys[activeID]; !exists {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, activeID)
+	}
+	return manager, nil
+}
+
+// ActiveKeyID names the master key new data keys are wrapped under
+func (m *LocalKeyManager) ActiveKeyID() string {
+	return m.activeID
+}
+
+// WrapKey encrypts a data key under the active master key
+func (m *LocalKeyManager) WrapKey(ctx context.Context, dataKey []byte) (string, []byte, error) {
+	wrapped, err := seal(m.keys[m.activeID], dataKey, []byte(m.activeID))
+	if err != nil {
+		return "", nil, err
+	}
+	return m.activeID, wrapped, nil
+}
+
+// UnwrapKey decrypts a data key wrapped under the named master key
+func (m *LocalKeyManager) UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	aead, exists := m.keys[keyID]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKey, keyID)
+	}
+	return open(aead, wrapped, []byte(keyID))
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts plaintext under a random nonce, which is prepended to the result
+func seal(aead cipher.AEAD, plaintext, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func open(aead cipher.AEAD, sealed, additionalData []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, ErrMalformedCiphertext
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, additionalData)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrMalformedCiphertext, err)
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,133 @@
+package crypto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// RotationSource is a store of encrypted records the Rotator walks
+type RotationSource interface {
+	// RotateBatch visits up to limit records after cursor, in a stable order,
+	// re-encrypts the values needing rotation and returns the number of
+	// records rewritten. next is the cursor to continue from, empty once
+	// every record has been visited.
+	RotateBatch(ctx context.Context, cipher *Cipher, cursor string, limit int) (next string, rotated int, err error)
+}
+
+// Rotator re-encrypts every registered store's values under the active
+// master key, a batch at a time. Runs are serialised, so a slow run is never
+// overlapped by the next one.
+type Rotator struct {
+	cipher    *Cipher
+	batchSize int
+	logger    *logger.Logger
+
+	sources map[string]RotationSource
+	mutex   sync.RWMutex
+	running sync.Mutex
+}
+
+// NewRotator creates a rotator re-encrypting DefaultRotationBatchSize
+// records per query
+func NewRotator(cipher *Cipher, logger *logger.Logger) *Rotator {
+	return &Rotator{
+		cipher:    cipher,
+		batchSize: DefaultRotationBatchSize,
+		logger:    logger,
+		sources:   make(map[string]RotationSource),
+	}
+}
+
+// SetBatchSize sets the most records visited per query
+func (r *Rotator) SetBatchSize(size int) {
+	if size > 0 {
+		r.batchSize = size
+	}
+}
+
+// Register adds a store to rotate under a name used in logs and results
+func (r *Rotator) Register(name string, source RotationSource) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.sources[name] = source
+}
+
+// Run walks every registered store once and returns the records rewritten
+// per store. A store that fails does not stop the others; its error is
+// returned with the counts of the rest.
+func (r *Rotator) Run(ctx context.Context) (map[string]int, error) {
+	r.running.Lock()
+	defer r.running.Unlock()
+
+	r.mutex.RLock()
+	names := make([]string, 0, len(r.sources))
+	for name := range r.sources {
+		names = append(names, name)
+	}
+	r.mutex.RUnlock()
+	sort.Strings(names)
+
+	rotated := make(map[string]int, len(names))
+	var errs []error
+	for _, name := range names {
+		r.mutex.RLock()
+		source := r.sources[name]
+		r.mutex.RUnlock()
+
+		count, err := r.rotate(ctx, source)
+		rotated[name] = count
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+		if count > 0 {
+			r.logger.WithContext(ctx).WithFields(logger.Fields{
+				"store":   name,
+				"rotated": count,
+				"key_id":  r.cipher.keys.ActiveKeyID(),
+			}).Info("Re-encrypted stored personal data")
+		}
+	}
+	return rotated, errors.Join(errs...)
+}
+
+// Start runs the rotator every interval until ctx is cancelled
+func (r *Rotator) Start(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultRotationInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := r.Run(ctx); err != nil && ctx.Err() == nil {
+				r.logger.WithContext(ctx).WithError(err).Error("Key rotation failed")
+			}
+		}
+	}
+}
+
+func (r *Rotator) rotate(ctx context.Context, source RotationSource) (int, error) {
+	total := 0
+	cursor := ""
+	for {
+		next, rotated, err := source.RotateBatch(ctx, r.cipher, cursor, r.batchSize)
+		total += rotated
+		if err != nil {
+			return total, err
+		}
+		if next == "" || next == cursor {
+			return total, nil
+		}
+		cursor = next
+	}
+}
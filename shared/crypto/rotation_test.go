@@ -0,0 +1,108 @@
+package crypto_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rideshare-platform/shared/crypto"
+	"github.com/rideshare-platform/shared/crypto/cryptotest"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// fakeRotationSource keeps values by ID, in ID order, and rotates them the
+// way the repositories do
+type fakeRotationSource struct {
+	ids     []string
+	values  map[string]string
+	cursors []string
+	err     error
+}
+
+func (f *fakeRotationSource) RotateBatch(ctx context.Context, cipher *crypto.Cipher, cursor string, limit int) (string, int, error) {
+	f.cursors = append(f.cursors, cursor)
+	if f.err != nil {
+		return "", 0, f.err
+	}
+
+	var batch []string
+	for _, id := range f.ids {
+		if id > cursor && len(batch) < limit {
+			batch = append(batch, id)
+		}
+	}
+	if len(batch) == 0 {
+		return "", 0, nil
+	}
+
+	rotated := 0
+	for _, id := range batch {
+		if !cipher.NeedsRotation(f.values[id]) {
+			continue
+		}
+		value, err := cipher.Reencrypt(ctx, f.values[id])
+		if err != nil {
+			return "", rotated, err
+		}
+		f.values[id] = value
+		rotated++
+	}
+	return batch[len(batch)-1], rotated, nil
+}
+
+func TestRotator_RotatesEveryBatch(t *testing.T) {
+	ctx := context.Background()
+	oldKey, newKey, indexKey := cryptotest.NewKey(t), cryptotest.NewKey(t), cryptotest.NewKey(t)
+	before := cryptotest.NewCipher(t, map[string][]byte{"k1": oldKey}, "k1", indexKey)
+	after := cryptotest.NewCipher(t, map[string][]byte{"k1": oldKey, "k2": newKey}, "k2", indexKey)
+
+	source := &fakeRotationSource{values: make(map[string]string)}
+	for _, id := range []string{"a", "b", "c", "d", "e"} {
+		sealed, err := before.Encrypt(ctx, "value-"+id)
+		require.NoError(t, err)
+		source.ids = append(source.ids, id)
+		source.values[id] = sealed
+	}
+	source.values["c"] = "stored before encryption"
+	source.values["d"] = ""
+
+	rotator := crypto.NewRotator(after, logger.NewLogger("error", "test"))
+	rotator.SetBatchSize(2)
+	rotator.Register("users", source)
+
+	rotated, err := rotator.Run(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"users": 4}, rotated, "every value but the empty one")
+	assert.Equal(t, []string{"", "b", "d", "e"}, source.cursors, "each batch continues from the last")
+	for id, value := range source.values {
+		assert.False(t, after.NeedsRotation(value), id)
+	}
+	opened, err := after.Decrypt(ctx, source.values["a"])
+	require.NoError(t, err)
+	assert.Equal(t, "value-a", opened)
+
+	source.cursors = nil
+	rotated, err = rotator.Run(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"users": 0}, rotated, "a second run finds nothing left")
+}
+
+func TestRotator_FailingStoreDoesNotStopOthers(t *testing.T) {
+	ctx := context.Background()
+	cipher := cryptotest.NewCipher(t, map[string][]byte{"k1": cryptotest.NewKey(t)}, "k1", cryptotest.NewKey(t))
+
+	healthy := &fakeRotationSource{ids: []string{"a"}, values: map[string]string{"a": "plaintext"}}
+	failing := &fakeRotationSource{err: errors.New("database unavailable")}
+
+	rotator := crypto.NewRotator(cipher, logger.NewLogger("error", "test"))
+	rotator.Register("payment_methods", failing)
+	rotator.Register("users", healthy)
+
+	rotated, err := rotator.Run(ctx)
+	assert.ErrorContains(t, err, "payment_methods: database unavailable")
+	assert.Equal(t, map[string]int{"payment_methods": 0, "users": 1}, rotated)
+	assert.True(t, crypto.IsEncrypted(healthy.values["a"]))
+}
@@ -0,0 +1,66 @@
+package currency
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Cities maps the cities the platform operates in to the currency fares there
+// are charged in
+type Cities struct {
+	fallback string
+	byCity   map[string]string
+}
+
+// NewCities creates a city currency mapping. Cities are matched ignoring case,
+// and cities not in byCity are charged in fallback.
+func NewCities(fallback string, byCity map[string]string) (*Cities, error) {
+	code, err := Normalize(fallback)
+	if err != nil {
+		return nil, fmt.Errorf("invalid default currency: %w", err)
+	}
+
+	cities := &Cities{fallback: code, byCity: make(map[string]string, len(byCity))}
+	for city, cityCode := range byCity {
+		code, err := Normalize(cityCode)
+		if err != nil {
+			return nil, fmt.Errorf("invalid currency for %s: %w", city, err)
+		}
+		cities.byCity[cityKey(city)] = code
+	}
+	return cities, nil
+}
+
+// ParseCities creates a city currency mapping from a comma separated list of
+// city=CODE pairs, for example "istanbul=TRY,london=GBP"
+func ParseCities(fallback, spec string) (*Cities, error) {
+	byCity := make(map[string]string)
+	for _, pair := range strings.Split(spec, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		city, code, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(city) == "" {
+			return nil, fmt.Errorf("invalid city currency %q, expected city=CODE", pair)
+		}
+		byCity[city] = code
+	}
+	return NewCities(fallback, byCity)
+}
+
+// ForCity returns the currency fares in the city are charged in
+func (c *Cities) ForCity(city string) string {
+	if code, ok := c.byCity[cityKey(city)]; ok {
+		return code
+	}
+	return c.fallback
+}
+
+// Default returns the currency of cities without their own
+func (c *Cities) Default() string {
+	return c.fallback
+}
+
+func cityKey(city string) string {
+	return strings.ToLower(strings.TrimSpace(city))
+}
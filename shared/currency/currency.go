@@ -0,0 +1,155 @@
+// Package currency describes the currencies fares are charged in: their ISO
+// 4217 codes, how many minor units (cents) they have, how amounts are
+// displayed, which currency each city charges in and how amounts are converted
+// for reporting.
+package currency
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Default is the currency used when neither a city nor a request names one
+const Default = "USD"
+
+var (
+	// ErrUnsupported is returned for currency codes the platform cannot charge in
+	ErrUnsupported = errors.New("unsupported currency")
+	// ErrMismatch is returned when an amount is in a different currency than expected
+	ErrMismatch = errors.New("currency mismatch")
+)
+
+// Currency is a currency fares can be charged in
+type Currency struct {
+	Code   string `json:"code"`   // ISO 4217 code
+	Symbol string `json:"symbol"` // display symbol
+	// MinorUnits is the number of decimal places, 2 for cents, 0 for currencies
+	// without a minor unit such as JPY
+	MinorUnits int `json:"minor_units"`
+}
+
+var supported = map[string]Currency{
+	"USD": {Code: "USD", Symbol: "$", MinorUnits: 2},
+	"EUR": {Code: "EUR", Symbol: "€", MinorUnits: 2},
+	"GBP": {Code: "GBP", Symbol: "£", MinorUnits: 2},
+	"TRY": {Code: "TRY", Symbol: "₺", MinorUnits: 2},
+	"INR": {Code: "INR", Symbol: "₹", MinorUnits: 2},
+	"CAD": {Code: "CAD", Symbol: "CA$", MinorUnits: 2},
+	"AUD": {Code: "AUD", Symbol: "A$", MinorUnits: 2},
+	"BRL": {Code: "BRL", Symbol: "R$", MinorUnits: 2},
+	"MXN": {Code: "MXN", Symbol: "MX$", MinorUnits: 2},
+	"AED": {Code: "AED", Symbol: "AED ", MinorUnits: 2},
+	"JPY": {Code: "JPY", Symbol: "¥", MinorUnits: 0},
+	"KRW": {Code: "KRW", Symbol: "₩", MinorUnits: 0},
+	"KWD": {Code: "KWD", Symbol: "KD ", MinorUnits: 3},
+}
+
+// Lookup returns the supported currency with the given code, ignoring case
+func Lookup(code string) (Currency, error) {
+	c, ok := supported[strings.ToUpper(strings.TrimSpace(code))]
+	if !ok {
+		return Currency{}, fmt.Errorf("%w: %q", ErrUnsupported, code)
+	}
+	return c, nil
+}
+
+// Normalize returns the upper case code of a supported currency
+func Normalize(code string) (string, error) {
+	c, err := Lookup(code)
+	if err != nil {
+		return "", err
+	}
+	return c.Code, nil
+}
+
+// Supported returns the codes of all supported currencies in alphabetical order
+func Supported() []string {
+	codes := make([]string, 0, len(supported))
+	for code := range supported {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// Match checks that actual is the expected currency
+func Match(expected, actual string) error {
+	if !strings.EqualFold(strings.TrimSpace(expected), strings.TrimSpace(actual)) {
+		return fmt.Errorf("%w: expected %s, got %s", ErrMismatch, expected, actual)
+	}
+	return nil
+}
+
+// ToMinor converts an amount to minor units, rounding half away from zero
+func (c Currency) ToMinor(amount float64) int64 {
+	return int64(math.Round(amount * math.Pow10(c.MinorUnits)))
+}
+
+// FromMinor converts minor units back to an amount
+func (c Currency) FromMinor(minor int64) float64 {
+	return float64(minor) / math.Pow10(c.MinorUnits)
+}
+
+// Round rounds an amount to the currency's minor unit
+func (c Currency) Round(amount float64) float64 {
+	return c.FromMinor(c.ToMinor(amount))
+}
+
+// Format displays an amount in minor units with the currency's symbol and
+// thousands separators, for example $2,847,392.50 or ¥1,200
+func (c Currency) Format(minor int64) string {
+	sign := ""
+	if minor < 0 {
+		sign = "-"
+		minor = -minor
+	}
+	scale := int64(math.Pow10(c.MinorUnits))
+	whole := strconv.FormatInt(minor/scale, 10)
+
+	var grouped strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			grouped.WriteByte(',')
+		}
+		grouped.WriteRune(digit)
+	}
+	if c.MinorUnits > 0 {
+		fmt.Fprintf(&grouped, ".%0*d", c.MinorUnits, minor%scale)
+	}
+	return sign + c.Symbol + grouped.String()
+}
+
+// lookupOrDefault returns the currency for code, or a two decimal currency
+// displayed by its code when it is not supported
+func lookupOrDefault(code string) Currency {
+	if c, err := Lookup(code); err == nil {
+		return c
+	}
+	return Currency{Code: code, Symbol: code + " ", MinorUnits: 2}
+}
+
+// ToMinor converts an amount in the given currency to minor units. Unsupported
+// currencies are treated as having two decimal places.
+func ToMinor(amount float64, code string) int64 {
+	return lookupOrDefault(code).ToMinor(amount)
+}
+
+// FromMinor converts minor units of the given currency back to an amount
+func FromMinor(minor int64, code string) float64 {
+	return lookupOrDefault(code).FromMinor(minor)
+}
+
+// Round rounds an amount to the given currency's minor unit
+func Round(amount float64, code string) float64 {
+	return lookupOrDefault(code).Round(amount)
+}
+
+// FormatAmount displays an amount in the given currency
+func FormatAmount(amount float64, code string) string {
+	c := lookupOrDefault(code)
+	return c.Format(c.ToMinor(amount))
+}
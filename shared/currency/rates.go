@@ -0,0 +1,92 @@
+package currency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrNoRate is returned when no exchange rate is known between two currencies
+var ErrNoRate = errors.New("no exchange rate")
+
+// RateProvider supplies exchange rates. Rates are for reporting, for example
+// summing revenue across cities. Riders are always charged in the currency
+// they were quoted in and never converted.
+type RateProvider interface {
+	// Rate returns how many units of to one unit of from is worth
+	Rate(ctx context.Context, from, to string) (float64, error)
+}
+
+// StaticRates is a fixed table of rates against a base currency
+type StaticRates struct {
+	base  string
+	rates map[string]float64
+}
+
+// NewStaticRates creates a rate table. rates gives how many units of each
+// currency one unit of base is worth.
+func NewStaticRates(base string, rates map[string]float64) (*StaticRates, error) {
+	code, err := Normalize(base)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base currency: %w", err)
+	}
+
+	table := &StaticRates{base: code, rates: map[string]float64{code: 1}}
+	for currencyCode, rate := range rates {
+		code, err := Normalize(currencyCode)
+		if err != nil {
+			return nil, err
+		}
+		if rate <= 0 {
+			return nil, fmt.Errorf("rate for %s must be positive", code)
+		}
+		table.rates[code] = rate
+	}
+	return table, nil
+}
+
+// ParseStaticRates creates a rate table from a comma separated list of
+// CODE=rate pairs, for example "EUR=0.92,TRY=32.5"
+func ParseStaticRates(base, spec string) (*StaticRates, error) {
+	rates := make(map[string]float64)
+	for _, pair := range strings.Split(spec, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		code, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid exchange rate %q, expected CODE=rate", pair)
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exchange rate for %s: %w", code, err)
+		}
+		rates[code] = rate
+	}
+	return NewStaticRates(base, rates)
+}
+
+// Rate returns how many units of to one unit of from is worth
+func (r *StaticRates) Rate(ctx context.Context, from, to string) (float64, error) {
+	fromRate, fromOK := r.rates[strings.ToUpper(from)]
+	toRate, toOK := r.rates[strings.ToUpper(to)]
+	if !fromOK || !toOK {
+		return 0, fmt.Errorf("%w from %s to %s", ErrNoRate, from, to)
+	}
+	return toRate / fromRate, nil
+}
+
+// Convert converts an amount in minor units of one currency to minor units of
+// another at the provider's rate
+func Convert(ctx context.Context, rates RateProvider, minor int64, from, to string) (int64, error) {
+	if strings.EqualFold(from, to) {
+		return minor, nil
+	}
+	rate, err := rates.Rate(ctx, from, to)
+	if err != nil {
+		return 0, err
+	}
+	return ToMinor(FromMinor(minor, from)*rate, to), nil
+}
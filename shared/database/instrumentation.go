@@ -0,0 +1,87 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// DefaultSlowQueryThreshold applies when the config leaves SlowQueryThreshold unset
+const DefaultSlowQueryThreshold = 200 * time.Millisecond
+
+// QueryRecorder receives per-query measurements, normally the shared
+// monitoring MetricsCollector
+type QueryRecorder interface {
+	RecordDatabaseQuery(service, operation, table string, duration float64)
+	RecordDatabaseError(service, operation, table string)
+}
+
+// queryInstrumentation times queries, reports them to a QueryRecorder and
+// logs the ones slower than the threshold
+type queryInstrumentation struct {
+	logger        *logger.Logger
+	recorder      QueryRecorder
+	service       string
+	slowThreshold time.Duration
+}
+
+// observe records one finished query. sql.ErrNoRows is an expected outcome,
+// not a failure.
+func (qi *queryInstrumentation) observe(ctx context.Context, query string, start time.Time, err error) {
+	duration := time.Since(start)
+	if err != nil && errors.Is(err, sql.ErrNoRows) {
+		err = nil
+	}
+
+	if qi.recorder != nil {
+		operation, table := describeQuery(query)
+		qi.recorder.RecordDatabaseQuery(qi.service, operation, table, duration.Seconds())
+		if err != nil {
+			qi.recorder.RecordDatabaseError(qi.service, operation, table)
+		}
+	}
+
+	if err == nil && qi.slowThreshold > 0 && duration >= qi.slowThreshold {
+		qi.logger.WithContext(ctx).WithFields(logger.Fields{
+			"query":        compactQuery(query),
+			"duration_ms":  duration.Milliseconds(),
+			"threshold_ms": qi.slowThreshold.Milliseconds(),
+			"type":         "slow_query",
+		}).Warn("Slow database query")
+		return
+	}
+	qi.logger.LogDatabaseQuery(ctx, query, duration, err)
+}
+
+var (
+	whitespace   = regexp.MustCompile(`\s+`)
+	tablePattern = regexp.MustCompile(`(?i)\b(?:from|into|update|join)\s+"?([a-zA-Z_][a-zA-Z0-9_.]*)`)
+)
+
+// describeQuery derives low-cardinality metric labels from a statement: the
+// leading keyword as the operation and the first table it names
+func describeQuery(query string) (string, string) {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return "unknown", "unknown"
+	}
+	operation := strings.ToLower(fields[0])
+	if operation == "with" {
+		operation = "cte"
+	}
+
+	table := "unknown"
+	if match := tablePattern.FindStringSubmatch(query); match != nil {
+		table = strings.ToLower(match[1])
+	}
+	return operation, table
+}
+
+func compactQuery(query string) string {
+	return strings.TrimSpace(whitespace.ReplaceAllString(query, " "))
+}
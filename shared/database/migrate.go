@@ -0,0 +1,352 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// ErrMigrationChanged is returned when an applied migration's up file no
+// longer matches the checksum recorded when it was applied
+var ErrMigrationChanged = errors.New("applied migration was changed")
+
+// Migration is one versioned schema change. Files are named
+// NNNN_description.up.sql and NNNN_description.down.sql.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Checksum identifies the migration's up file. It is recorded when the
+// migration is applied so later edits to the file are noticed.
+func (m Migration) Checksum() string {
+	sum := sha256.Sum256([]byte(m.Up))
+	return hex.EncodeToString(sum[:])
+}
+
+// MigrationStatus reports whether a migration has been applied
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+// SchemaMigrator applies and rolls back a service's migrations
+type SchemaMigrator interface {
+	Up(ctx context.Context) (int, error)
+	Down(ctx context.Context, steps int) (int, error)
+	Version(ctx context.Context) (int, error)
+	Status(ctx context.Context) ([]MigrationStatus, error)
+}
+
+// LoadMigrations reads the .up.sql and .down.sql files in the root of fsys,
+// typically an embed.FS, ordered by version
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+		version, name, direction, err := parseMigrationName(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		body, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: name}
+			byVersion[version] = migration
+		} else if migration.Name != name {
+			return nil, fmt.Errorf("migration version %d used by both %s and %s", version, migration.Name, name)
+		}
+		if direction == "up" {
+			migration.Up = string(body)
+		} else {
+			migration.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+func parseMigrationName(filename string) (int, string, string, error) {
+	base := strings.TrimSuffix(filename, ".sql")
+	direction := path.Ext(base)
+	if direction != ".up" && direction != ".down" {
+		return 0, "", "", fmt.Errorf("migration %s must end in .up.sql or .down.sql", filename)
+	}
+	versionPart, name, ok := strings.Cut(strings.TrimSuffix(base, direction), "_")
+	if !ok {
+		return 0, "", "", fmt.Errorf("migration %s must be named NNNN_description", filename)
+	}
+	version, err := strconv.Atoi(versionPart)
+	if err != nil || version <= 0 {
+		return 0, "", "", fmt.Errorf("migration %s has invalid version %q", filename, versionPart)
+	}
+	return version, name, strings.TrimPrefix(direction, "."), nil
+}
+
+// Migrator applies SQL migrations to PostgreSQL. Services sharing a database
+// record their history in one schema_migrations table keyed by service, and
+// an advisory lock per service keeps concurrent replicas from racing.
+type Migrator struct {
+	db         *sql.DB
+	service    string
+	migrations []Migration
+	logger     *logger.Logger
+}
+
+// NewMigrator creates a migrator for one service's migrations
+func NewMigrator(db *sql.DB, service string, migrations []Migration, log *logger.Logger) *Migrator {
+	return &Migrator{
+		db:         db,
+		service:    service,
+		migrations: migrations,
+		logger:     log,
+	}
+}
+
+const createMigrationsTable = `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		service VARCHAR(100) NOT NULL,
+		version INTEGER NOT NULL,
+		name VARCHAR(255) NOT NULL,
+		applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+		checksum VARCHAR(64) NOT NULL DEFAULT '',
+		PRIMARY KEY (service, version)
+	)`
+
+// addMigrationChecksums upgrades tables created before checksums were
+// recorded. Their rows keep an empty checksum, which is not verified.
+const addMigrationChecksums = `
+	ALTER TABLE schema_migrations ADD COLUMN IF NOT EXISTS checksum VARCHAR(64) NOT NULL DEFAULT ''`
+
+// appliedMigration is a migration's row in schema_migrations
+type appliedMigration struct {
+	appliedAt time.Time
+	checksum  string
+}
+
+// Up applies every pending migration in order and returns how many ran.
+// Nothing is applied if a migration that already ran has since been edited.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	applied := 0
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := m.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			record, ok := done[migration.Version]
+			if ok && record.checksum != "" && record.checksum != migration.Checksum() {
+				return fmt.Errorf("%w: %d_%s", ErrMigrationChanged, migration.Version, migration.Name)
+			}
+		}
+		for _, migration := range m.migrations {
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+			if err := m.apply(ctx, conn, migration, true); err != nil {
+				return err
+			}
+			applied++
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down rolls back the most recent steps migrations and returns how many ran
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	rolledBack := 0
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		done, err := m.appliedVersions(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for i := len(m.migrations) - 1; i >= 0 && rolledBack < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := done[migration.Version]; !ok {
+				continue
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("migration %d_%s cannot be rolled back", migration.Version, migration.Name)
+			}
+			if err := m.apply(ctx, conn, migration, false); err != nil {
+				return err
+			}
+			rolledBack++
+		}
+		return nil
+	})
+	return rolledBack, err
+}
+
+// Version returns the highest applied migration version, 0 if none
+func (m *Migrator) Version(ctx context.Context) (int, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	if err := createMigrationsTableOn(ctx, conn); err != nil {
+		return 0, err
+	}
+	var version int
+	err = conn.QueryRowContext(ctx,
+		`SELECT COALESCE(MAX(version), 0) FROM schema_migrations WHERE service = $1`, m.service,
+	).Scan(&version)
+	return version, err
+}
+
+// Status lists every known migration and whether it has been applied
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err := createMigrationsTableOn(ctx, conn); err != nil {
+		return nil, err
+	}
+	done, err := m.appliedVersions(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if record, ok := done[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = &record.appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// withLock runs fn on a dedicated connection holding the service's advisory lock
+func (m *Migrator) withLock(ctx context.Context, fn func(*sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire connection: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock(hashtext($1))`, "schema_migrations:"+m.service); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock(hashtext($1))`, "schema_migrations:"+m.service)
+
+	if err := createMigrationsTableOn(ctx, conn); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+// createMigrationsTableOn creates schema_migrations, or adds the columns an
+// older table lacks
+func createMigrationsTableOn(ctx context.Context, conn *sql.Conn) error {
+	for _, statement := range []string{createMigrationsTable, addMigrationChecksums} {
+		if _, err := conn.ExecContext(ctx, statement); err != nil {
+			return fmt.Errorf("failed to create schema_migrations: %w", err)
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) appliedVersions(ctx context.Context, conn *sql.Conn) (map[int]appliedMigration, error) {
+	rows, err := conn.QueryContext(ctx,
+		`SELECT version, applied_at, checksum FROM schema_migrations WHERE service = $1`, m.service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]appliedMigration)
+	for rows.Next() {
+		var version int
+		var record appliedMigration
+		if err := rows.Scan(&version, &record.appliedAt, &record.checksum); err != nil {
+			return nil, err
+		}
+		applied[version] = record
+	}
+	return applied, rows.Err()
+}
+
+// apply runs one migration and records it in the same transaction, so a
+// failing migration leaves neither schema changes nor a history row behind
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, migration Migration, up bool) error {
+	direction, body := "up", migration.Up
+	if !up {
+		direction, body = "down", migration.Down
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, body); err != nil {
+		return fmt.Errorf("migration %d_%s %s failed: %w", migration.Version, migration.Name, direction, err)
+	}
+	if up {
+		_, err = tx.ExecContext(ctx,
+			`INSERT INTO schema_migrations (service, version, name, checksum) VALUES ($1, $2, $3, $4)`,
+			m.service, migration.Version, migration.Name, migration.Checksum())
+	} else {
+		_, err = tx.ExecContext(ctx,
+			`DELETE FROM schema_migrations WHERE service = $1 AND version = $2`,
+			m.service, migration.Version)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	m.logger.WithFields(logger.Fields{
+		"service":   m.service,
+		"version":   migration.Version,
+		"name":      migration.Name,
+		"direction": direction,
+	}).Info("Applied database migration")
+	return nil
+}
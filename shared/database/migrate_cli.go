@@ -0,0 +1,69 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// MigrateUsage describes the commands RunMigrateCommand accepts
+const MigrateUsage = `usage: migrate <command>
+
+commands:
+  up          apply all pending migrations
+  down [N]    roll back the last N migrations (default 1)
+  version     print the current schema version
+  status      list migrations and whether they are applied`
+
+// RunMigrateCommand runs a migrate CLI command against migrator, writing
+// results to out. It backs each service's cmd/migrate binary.
+func RunMigrateCommand(ctx context.Context, migrator SchemaMigrator, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing command\n%s", MigrateUsage)
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "applied %d migration(s)\n", applied)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				return fmt.Errorf("invalid step count %q", args[1])
+			}
+			steps = n
+		}
+		rolledBack, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "rolled back %d migration(s)\n", rolledBack)
+	case "version":
+		version, err := migrator.Version(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, version)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			state := "pending"
+			if status.Applied {
+				state = "applied " + status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(out, "%04d  %-40s %s\n", status.Version, status.Name, state)
+		}
+	default:
+		return fmt.Errorf("unknown command %q\n%s", args[0], MigrateUsage)
+	}
+	return nil
+}
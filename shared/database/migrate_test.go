@@ -0,0 +1,244 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rideshare-platform/shared/logger"
+)
+
+func TestLoadMigrations_OrdersByVersion(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"0010_add_trip_index.up.sql":        "CREATE INDEX idx_trips_rider ON trips (rider_id);",
+		"0010_add_trip_index.down.sql":      "DROP INDEX idx_trips_rider;",
+		"0002_create_trips.up.sql":          "CREATE TABLE trips (id UUID PRIMARY KEY);",
+		"0002_create_trips.down.sql":        "DROP TABLE trips;",
+		"0001_create_users.up.sql":          "CREATE TABLE users (id UUID PRIMARY KEY);",
+		"0003_backfill_without_down.up.sql": "UPDATE trips SET id = id;",
+		"README.md":                         "not a migration",
+	}
+	for name, body := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(body), 0o644))
+	}
+
+	migrations, err := LoadMigrations(os.DirFS(dir))
+	require.NoError(t, err)
+
+	var versions []int
+	for _, migration := range migrations {
+		versions = append(versions, migration.Version)
+	}
+	assert.Equal(t, []int{1, 2, 3, 10}, versions, "versions are ordered numerically, not by file name")
+	assert.Equal(t, "create_trips", migrations[1].Name)
+	assert.Equal(t, "CREATE TABLE trips (id UUID PRIMARY KEY);", migrations[1].Up)
+	assert.Equal(t, "DROP TABLE trips;", migrations[1].Down)
+	assert.Empty(t, migrations[2].Down)
+}
+
+func TestLoadMigrations_Invalid(t *testing.T) {
+	tests := map[string]fstest.MapFS{
+		"no up file": {
+			"0001_create_users.down.sql": {Data: []byte("DROP TABLE users;")},
+		},
+		"version used twice": {
+			"0001_create_users.up.sql": {Data: []byte("CREATE TABLE users ();")},
+			"0001_create_trips.up.sql": {Data: []byte("CREATE TABLE trips ();")},
+		},
+		"no direction": {"0001_create_users.sql": {Data: []byte("")}},
+		"no name":      {"0001.up.sql": {Data: []byte("")}},
+		"bad version":  {"v1_create_users.up.sql": {Data: []byte("")}},
+		"zero version": {"0000_create_users.up.sql": {Data: []byte("")}},
+	}
+	for name, fsys := range tests {
+		_, err := LoadMigrations(fsys)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestMigration_Checksum(t *testing.T) {
+	migration := Migration{Version: 1, Name: "create_users", Up: "CREATE TABLE users ();", Down: "DROP TABLE users;"}
+	assert.Len(t, migration.Checksum(), 64)
+
+	// Only the up file is covered; it is what was applied
+	reverted := migration
+	reverted.Down = "DROP TABLE IF EXISTS users;"
+	assert.Equal(t, migration.Checksum(), reverted.Checksum())
+
+	edited := migration
+	edited.Up = "CREATE TABLE users (id UUID);"
+	assert.NotEqual(t, migration.Checksum(), edited.Checksum())
+}
+
+var testMigrations = []Migration{
+	{Version: 1, Name: "create_users", Up: "CREATE TABLE users ();", Down: "DROP TABLE users;"},
+	{Version: 2, Name: "create_trips", Up: "CREATE TABLE trips ();", Down: "DROP TABLE trips;"},
+	{Version: 3, Name: "create_payments", Up: "CREATE TABLE payments ();", Down: "DROP TABLE payments;"},
+}
+
+func newTestMigrator(t *testing.T, migrations []Migration) (*Migrator, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return NewMigrator(db, "trip-service", migrations, logger.NewLogger("error", "test")), mock
+}
+
+// expectLocked expects the migrator to take the service's lock, make sure
+// schema_migrations exists and read the versions applied
+func expectLocked(mock sqlmock.Sqlmock, applied *sqlmock.Rows) {
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_lock(hashtext($1))`)).
+		WithArgs("schema_migrations:trip-service").WillReturnResult(sqlmock.NewResult(0, 0))
+	expectMigrationsTable(mock)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT version, applied_at, checksum FROM schema_migrations WHERE service = $1`)).
+		WithArgs("trip-service").WillReturnRows(applied)
+}
+
+func expectMigrationsTable(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(`CREATE TABLE IF NOT EXISTS schema_migrations`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`ALTER TABLE schema_migrations ADD COLUMN IF NOT EXISTS checksum`).WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func expectUnlocked(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_unlock(hashtext($1))`)).
+		WithArgs("schema_migrations:trip-service").WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func appliedRows(migrations ...Migration) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"version", "applied_at", "checksum"})
+	for _, migration := range migrations {
+		rows.AddRow(migration.Version, time.Now(), migration.Checksum())
+	}
+	return rows
+}
+
+func TestMigrator_UpAppliesPendingMigrationsInOrder(t *testing.T) {
+	migrator, mock := newTestMigrator(t, testMigrations)
+
+	expectLocked(mock, appliedRows(testMigrations[0]))
+	for _, migration := range testMigrations[1:] {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(migration.Up)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO schema_migrations (service, version, name, checksum) VALUES ($1, $2, $3, $4)`)).
+			WithArgs("trip-service", migration.Version, migration.Name, migration.Checksum()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+	}
+	expectUnlocked(mock)
+
+	applied, err := migrator.Up(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, applied)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_UpRefusesEditedMigrations(t *testing.T) {
+	migrator, mock := newTestMigrator(t, testMigrations)
+
+	applied := appliedRows(testMigrations[0]).
+		AddRow(2, time.Now(), Migration{Up: "CREATE TABLE trips (id UUID);"}.Checksum())
+	expectLocked(mock, applied)
+	expectUnlocked(mock)
+
+	n, err := migrator.Up(context.Background())
+	assert.True(t, errors.Is(err, ErrMigrationChanged), "got %v", err)
+	assert.Contains(t, err.Error(), "2_create_trips")
+	assert.Zero(t, n, "nothing is applied on top of an edited migration")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_UpAcceptsMigrationsAppliedBeforeChecksums(t *testing.T) {
+	migrator, mock := newTestMigrator(t, testMigrations[:1])
+
+	expectLocked(mock, sqlmock.NewRows([]string{"version", "applied_at", "checksum"}).AddRow(1, time.Now(), ""))
+	expectUnlocked(mock)
+
+	n, err := migrator.Up(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_FailedMigrationIsRolledBack(t *testing.T) {
+	migrator, mock := newTestMigrator(t, testMigrations)
+
+	expectLocked(mock, appliedRows(testMigrations[0]))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(testMigrations[1].Up)).WillReturnError(errors.New(`relation "trips" already exists`))
+	mock.ExpectRollback()
+	expectUnlocked(mock)
+
+	n, err := migrator.Up(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "migration 2_create_trips up failed")
+	assert.Zero(t, n, "later migrations do not run after a failure")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_DownRollsBackNewestFirst(t *testing.T) {
+	migrator, mock := newTestMigrator(t, testMigrations)
+
+	expectLocked(mock, appliedRows(testMigrations...))
+	for _, migration := range []Migration{testMigrations[2], testMigrations[1]} {
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(migration.Down)).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM schema_migrations WHERE service = $1 AND version = $2`)).
+			WithArgs("trip-service", migration.Version).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+	}
+	expectUnlocked(mock)
+
+	n, err := migrator.Down(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_DownNeedsADownFile(t *testing.T) {
+	migrations := []Migration{{Version: 1, Name: "backfill", Up: "UPDATE trips SET id = id;"}}
+	migrator, mock := newTestMigrator(t, migrations)
+
+	expectLocked(mock, appliedRows(migrations...))
+	expectUnlocked(mock)
+
+	_, err := migrator.Down(context.Background(), 1)
+	assert.EqualError(t, err, "migration 1_backfill cannot be rolled back")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMigrator_VersionAndStatus(t *testing.T) {
+	migrator, mock := newTestMigrator(t, testMigrations)
+	appliedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	expectMigrationsTable(mock)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations WHERE service = $1`)).
+		WithArgs("trip-service").WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(2))
+	version, err := migrator.Version(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, version)
+
+	expectMigrationsTable(mock)
+	mock.ExpectQuery(`SELECT version, applied_at, checksum FROM schema_migrations`).
+		WithArgs("trip-service").
+		WillReturnRows(sqlmock.NewRows([]string{"version", "applied_at", "checksum"}).
+			AddRow(1, appliedAt, testMigrations[0].Checksum()).
+			AddRow(2, appliedAt, testMigrations[1].Checksum()))
+	statuses, err := migrator.Status(context.Background())
+	require.NoError(t, err)
+	require.Len(t, statuses, 3)
+	assert.True(t, statuses[0].Applied)
+	assert.Equal(t, appliedAt, *statuses[1].AppliedAt)
+	assert.False(t, statuses[2].Applied)
+	assert.Nil(t, statuses[2].AppliedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,177 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rideshare-platform/shared/logger"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// MongoMigration is one versioned change to a MongoDB database, such as
+// creating collections or indexes
+type MongoMigration struct {
+	Version int
+	Name    string
+	Up      func(ctx context.Context, db *mongo.Database) error
+	Down    func(ctx context.Context, db *mongo.Database) error
+}
+
+// MongoMigrator applies MongoDB migrations, recording them in the
+// schema_migrations collection
+type MongoMigrator struct {
+	db         *mongo.Database
+	service    string
+	migrations []MongoMigration
+	logger     *logger.Logger
+}
+
+// NewMongoMigrator creates a migrator for one service's MongoDB migrations
+func NewMongoMigrator(db *mongo.Database, service string, migrations []MongoMigration, log *logger.Logger) *MongoMigrator {
+	return &MongoMigrator{
+		db:         db,
+		service:    service,
+		migrations: migrations,
+		logger:     log,
+	}
+}
+
+type mongoMigrationRecord struct {
+	Service   string    `bson:"service"`
+	Version   int       `bson:"version"`
+	Name      string    `bson:"name"`
+	AppliedAt time.Time `bson:"applied_at"`
+}
+
+func (m *MongoMigrator) history() *mongo.Collection {
+	return m.db.Collection("schema_migrations")
+}
+
+// Up applies every pending migration in order and returns how many ran
+func (m *MongoMigrator) Up(ctx context.Context) (int, error) {
+	done, err := m.applied(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	applied := 0
+	for _, migration := range m.migrations {
+		if _, ok := done[migration.Version]; ok {
+			continue
+		}
+		if err := migration.Up(ctx, m.db); err != nil {
+			return applied, fmt.Errorf("migration %d_%s up failed: %w", migration.Version, migration.Name, err)
+		}
+		// The unique history index turns a concurrent replica's duplicate run into an error here
+		if _, err := m.history().InsertOne(ctx, mongoMigrationRecord{
+			Service:   m.service,
+			Version:   migration.Version,
+			Name:      migration.Name,
+			AppliedAt: time.Now(),
+		}); err != nil {
+			return applied, fmt.Errorf("failed to record migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		m.logged(migration, "up")
+		applied++
+	}
+	return applied, nil
+}
+
+// Down rolls back the most recent steps migrations and returns how many ran
+func (m *MongoMigrator) Down(ctx context.Context, steps int) (int, error) {
+	done, err := m.applied(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	rolledBack := 0
+	for i := len(m.migrations) - 1; i >= 0 && rolledBack < steps; i-- {
+		migration := m.migrations[i]
+		if _, ok := done[migration.Version]; !ok {
+			continue
+		}
+		if migration.Down == nil {
+			return rolledBack, fmt.Errorf("migration %d_%s cannot be rolled back", migration.Version, migration.Name)
+		}
+		if err := migration.Down(ctx, m.db); err != nil {
+			return rolledBack, fmt.Errorf("migration %d_%s down failed: %w", migration.Version, migration.Name, err)
+		}
+		if _, err := m.history().DeleteOne(ctx, bson.M{"service": m.service, "version": migration.Version}); err != nil {
+			return rolledBack, fmt.Errorf("failed to record rollback of %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		m.logged(migration, "down")
+		rolledBack++
+	}
+	return rolledBack, nil
+}
+
+// Version returns the highest applied migration version, 0 if none
+func (m *MongoMigrator) Version(ctx context.Context) (int, error) {
+	done, err := m.applied(ctx)
+	if err != nil {
+		return 0, err
+	}
+	version := 0
+	for v := range done {
+		if v > version {
+			version = v
+		}
+	}
+	return version, nil
+}
+
+// Status lists every known migration and whether it has been applied
+func (m *MongoMigrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	done, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if appliedAt, ok := done[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func (m *MongoMigrator) applied(ctx context.Context) (map[int]time.Time, error) {
+	if _, err := m.history().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "service", Value: 1}, {Key: "version", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	}); err != nil {
+		return nil, fmt.Errorf("failed to index schema_migrations: %w", err)
+	}
+
+	cursor, err := m.history().Find(ctx, bson.M{"service": m.service})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	applied := make(map[int]time.Time)
+	for cursor.Next(ctx) {
+		var record mongoMigrationRecord
+		if err := cursor.Decode(&record); err != nil {
+			return nil, err
+		}
+		applied[record.Version] = record.AppliedAt
+	}
+	return applied, cursor.Err()
+}
+
+func (m *MongoMigrator) logged(migration MongoMigration, direction string) {
+	m.logger.WithFields(logger.Fields{
+		"service":   m.service,
+		"version":   migration.Version,
+		"name":      migration.Name,
+		"direction": direction,
+	}).Info("Applied database migration")
+}
@@ -38,7 +38,7 @@ func NewMongoDB(cfg *config.DatabaseConfig, log *logger.Logger) (*MongoDB, error
 	// Configure connection pool
 	clientOptions.SetMaxPoolSize(uint64(cfg.MaxOpenConns))
 	clientOptions.SetMinPoolSize(uint64(cfg.MaxIdleConns))
-	clientOptions.SetMaxConnIdleTime(time.Duration(cfg.ConnMaxIdleTime) * time.Second)
+	clientOptions.SetMaxConnIdleTime(cfg.ConnMaxIdleTime)
 	clientOptions.SetConnectTimeout(10 * time.Second)
 	clientOptions.SetServerSelectionTimeout(5 * time.Second)
 
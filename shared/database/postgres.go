@@ -15,8 +15,19 @@ type PostgresDB struct {
 	DB     *sql.DB
 	config *config.DatabaseConfig
 	logger *logger.Logger
+
+	instrumentation *queryInstrumentation
+	replicas        *ReplicaSet
 }
 
+// Pool defaults for settings the config leaves at zero
+const (
+	defaultMaxOpenConns    = 25
+	defaultMaxIdleConns    = 5
+	defaultConnMaxLifetime = 5 * time.Minute
+	defaultConnMaxIdleTime = time.Minute
+)
+
 // NewPostgresDB creates a new PostgreSQL database connection
 func NewPostgresDB(cfg *config.DatabaseConfig, log *logger.Logger) (*PostgresDB, error) {
 	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
@@ -28,10 +39,10 @@ func NewPostgresDB(cfg *config.DatabaseConfig, log *logger.Logger) (*PostgresDB,
 	}
 
 	// Configure connection pool
-	db.SetMaxOpenConns(cfg.MaxOpenConns)
-	db.SetMaxIdleConns(cfg.MaxIdleConns)
-	db.SetConnMaxLifetime(time.Duration(cfg.ConnMaxLifetime) * time.Second)
-	db.SetConnMaxIdleTime(time.Duration(cfg.ConnMaxIdleTime) * time.Second)
+	db.SetMaxOpenConns(orDefaultInt(cfg.MaxOpenConns, defaultMaxOpenConns))
+	db.SetMaxIdleConns(orDefaultInt(cfg.MaxIdleConns, defaultMaxIdleConns))
+	db.SetConnMaxLifetime(orDefaultDuration(cfg.ConnMaxLifetime, defaultConnMaxLifetime))
+	db.SetConnMaxIdleTime(orDefaultDuration(cfg.ConnMaxIdleTime, defaultConnMaxIdleTime))
 
 	// Test the connection
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -48,17 +59,44 @@ func NewPostgresDB(cfg *config.DatabaseConfig, log *logger.Logger) (*PostgresDB,
 		"database": cfg.Database,
 	}).Info("Connected to PostgreSQL database")
 
+	replicas, err := NewReplicaSet(db, cfg.ReplicaDSNs, cfg.MaxReplicaLag, log)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	if replicas.Len() > 0 {
+		log.WithField("replicas", replicas.Len()).Info("Routing read-only queries to PostgreSQL replicas")
+	}
+
+	slowThreshold := cfg.SlowQueryThreshold
+	if slowThreshold == 0 {
+		slowThreshold = DefaultSlowQueryThreshold
+	}
+
 	return &PostgresDB{
 		DB:     db,
 		config: cfg,
 		logger: log,
+		instrumentation: &queryInstrumentation{
+			logger:        log,
+			slowThreshold: slowThreshold,
+		},
+		replicas: replicas,
 	}, nil
 }
 
+// SetMetrics reports every query's latency and failures to recorder, labelled
+// with service
+func (p *PostgresDB) SetMetrics(recorder QueryRecorder, service string) {
+	p.instrumentation.recorder = recorder
+	p.instrumentation.service = service
+}
+
 // Close closes the database connection
 func (p *PostgresDB) Close() error {
 	if p.DB != nil {
 		p.logger.Logger.Info("Closing PostgreSQL database connection")
+		p.replicas.Close()
 		return p.DB.Close()
 	}
 	return nil
@@ -69,6 +107,33 @@ func (p *PostgresDB) Health(ctx context.Context) error {
 	return p.DB.PingContext(ctx)
 }
 
+// Replicas returns the read replicas, which route to the primary when none are configured
+func (p *PostgresDB) Replicas() *ReplicaSet {
+	return p.replicas
+}
+
+// ReplicaHealth reports replicas that are down or lagging
+func (p *PostgresDB) ReplicaHealth(ctx context.Context) error {
+	return p.replicas.Health(ctx)
+}
+
+// ReadQueryContext executes a read-only query on a replica, falling back to
+// the primary. Use it only where slightly stale results are acceptable.
+func (p *PostgresDB) ReadQueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := p.replicas.QueryContext(ctx, query, args...)
+	p.instrumentation.observe(ctx, query, start, err)
+	return rows, err
+}
+
+// ReadQueryRowContext is ReadQueryContext for queries returning at most one row
+func (p *PostgresDB) ReadQueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	start := time.Now()
+	row := p.replicas.QueryRowContext(ctx, query, args...)
+	p.instrumentation.observe(ctx, query, start, row.Err())
+	return row
+}
+
 // BeginTx starts a new transaction
 func (p *PostgresDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
 	return p.DB.BeginTx(ctx, opts)
@@ -78,9 +143,7 @@ func (p *PostgresDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx,
 func (p *PostgresDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
 	start := time.Now()
 	result, err := p.DB.ExecContext(ctx, query, args...)
-	duration := time.Since(start)
-
-	p.logger.LogDatabaseQuery(ctx, query, duration, err)
+	p.instrumentation.observe(ctx, query, start, err)
 	return result, err
 }
 
@@ -88,9 +151,7 @@ func (p *PostgresDB) ExecContext(ctx context.Context, query string, args ...inte
 func (p *PostgresDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
 	start := time.Now()
 	rows, err := p.DB.QueryContext(ctx, query, args...)
-	duration := time.Since(start)
-
-	p.logger.LogDatabaseQuery(ctx, query, duration, err)
+	p.instrumentation.observe(ctx, query, start, err)
 	return rows, err
 }
 
@@ -98,9 +159,7 @@ func (p *PostgresDB) QueryContext(ctx context.Context, query string, args ...int
 func (p *PostgresDB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
 	start := time.Now()
 	row := p.DB.QueryRowContext(ctx, query, args...)
-	duration := time.Since(start)
-
-	p.logger.LogDatabaseQuery(ctx, query, duration, nil)
+	p.instrumentation.observe(ctx, query, start, row.Err())
 	return row
 }
 
@@ -135,6 +194,8 @@ type Transaction struct {
 	tx     *sql.Tx
 	logger *logger.Logger
 	ctx    context.Context
+
+	instrumentation *queryInstrumentation
 }
 
 // NewTransaction creates a new transaction wrapper
@@ -147,9 +208,10 @@ func (p *PostgresDB) NewTransaction(ctx context.Context, opts *sql.TxOptions) (*
 	p.logger.WithContext(ctx).Debug("Database transaction started")
 
 	return &Transaction{
-		tx:     tx,
-		logger: p.logger,
-		ctx:    ctx,
+		tx:              tx,
+		logger:          p.logger,
+		ctx:             ctx,
+		instrumentation: p.instrumentation,
 	}, nil
 }
 
@@ -179,9 +241,7 @@ func (t *Transaction) Rollback() error {
 func (t *Transaction) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
 	start := time.Now()
 	result, err := t.tx.ExecContext(ctx, query, args...)
-	duration := time.Since(start)
-
-	t.logger.LogDatabaseQuery(ctx, query, duration, err)
+	t.instrumentation.observe(ctx, query, start, err)
 	return result, err
 }
 
@@ -189,9 +249,7 @@ func (t *Transaction) ExecContext(ctx context.Context, query string, args ...int
 func (t *Transaction) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
 	start := time.Now()
 	rows, err := t.tx.QueryContext(ctx, query, args...)
-	duration := time.Since(start)
-
-	t.logger.LogDatabaseQuery(ctx, query, duration, err)
+	t.instrumentation.observe(ctx, query, start, err)
 	return rows, err
 }
 
@@ -199,9 +257,7 @@ func (t *Transaction) QueryContext(ctx context.Context, query string, args ...in
 func (t *Transaction) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
 	start := time.Now()
 	row := t.tx.QueryRowContext(ctx, query, args...)
-	duration := time.Since(start)
-
-	t.logger.LogDatabaseQuery(ctx, query, duration, nil)
+	t.instrumentation.observe(ctx, query, start, row.Err())
 	return row
 }
 
@@ -289,3 +345,17 @@ func (r *Repository) GetLastInsertID(ctx context.Context, table, idColumn string
 
 	return id, nil
 }
+
+func orDefaultInt(value, fallback int) int {
+	if value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+func orDefaultDuration(value, fallback time.Duration) time.Duration {
+	if value <= 0 {
+		return fallback
+	}
+	return value
+}
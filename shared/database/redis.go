@@ -3,38 +3,29 @@ package database
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
-	"github.com/go-redis/redis/v8"
+	"github.com/redis/go-redis/v9"
 	"github.com/rideshare-platform/shared/config"
 	"github.com/rideshare-platform/shared/logger"
 )
 
-// RedisDB represents a Redis database connection
+// RedisDB represents a Redis database connection. Client is a single-node,
+// Sentinel failover or Cluster client depending on the configured topology.
 type RedisDB struct {
-	Client *redis.Client
+	Client redis.UniversalClient
 	config *config.RedisConfig
 	logger *logger.Logger
 }
 
 // NewRedisDB creates a new Redis database connection
 func NewRedisDB(cfg *config.RedisConfig, log *logger.Logger) (*RedisDB, error) {
-	// Create Redis client options
-	opts := &redis.Options{
-		Addr:         fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
-		Password:     cfg.Password,
-		DB:           cfg.Database,
-		PoolSize:     cfg.PoolSize,
-		MinIdleConns: cfg.MinIdleConns,
-		DialTimeout:  cfg.DialTimeout,
-		ReadTimeout:  cfg.ReadTimeout,
-		WriteTimeout: cfg.WriteTimeout,
-		IdleTimeout:  cfg.IdleTimeout,
+	client, err := newRedisClient(cfg)
+	if err != nil {
+		return nil, err
 	}
 
-	// Create Redis client
-	client := redis.NewClient(opts)
-
 	// Test the connection
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -44,11 +35,13 @@ func NewRedisDB(cfg *config.RedisConfig, log *logger.Logger) (*RedisDB, error) {
 		return nil, fmt.Errorf("failed to ping Redis: %w", err)
 	}
 
-	log.WithFields(logger.Fields{
-		"host": cfg.Host,
-		"port": cfg.Port,
-		"db":   0,
-	}).Info("Connected to Redis database")
+	fields := logger.Fields{"mode": redisMode(cfg)}
+	if fields["mode"] == config.RedisModeStandalone {
+		fields["host"], fields["port"], fields["db"] = cfg.Host, cfg.Port, cfg.Database
+	} else {
+		fields["addrs"] = strings.Join(cfg.Topology.Addrs, ",")
+	}
+	log.WithFields(fields).Info("Connected to Redis database")
 
 	return &RedisDB{
 		Client: client,
@@ -57,6 +50,95 @@ func NewRedisDB(cfg *config.RedisConfig, log *logger.Logger) (*RedisDB, error) {
 	}, nil
 }
 
+func newRedisClient(cfg *config.RedisConfig) (redis.UniversalClient, error) {
+	switch redisMode(cfg) {
+	case config.RedisModeStandalone:
+		return redis.NewClient(&redis.Options{
+			Addr:            fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
+			Password:        cfg.Password,
+			DB:              cfg.Database,
+			PoolSize:        cfg.PoolSize,
+			MinIdleConns:    cfg.MinIdleConns,
+			DialTimeout:     cfg.DialTimeout,
+			ReadTimeout:     cfg.ReadTimeout,
+			WriteTimeout:    cfg.WriteTimeout,
+			ConnMaxIdleTime: cfg.IdleTimeout,
+		}), nil
+	case config.RedisModeSentinel:
+		if err := cfg.Topology.Validate(); err != nil {
+			return nil, err
+		}
+		return redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       cfg.Topology.MasterName,
+			SentinelAddrs:    cfg.Topology.Addrs,
+			SentinelPassword: cfg.Topology.SentinelPassword,
+			Password:         cfg.Password,
+			DB:               cfg.Database,
+			PoolSize:         cfg.PoolSize,
+			MinIdleConns:     cfg.MinIdleConns,
+			DialTimeout:      cfg.DialTimeout,
+			ReadTimeout:      cfg.ReadTimeout,
+			WriteTimeout:     cfg.WriteTimeout,
+			ConnMaxIdleTime:  cfg.IdleTimeout,
+		}), nil
+	case config.RedisModeCluster:
+		if err := cfg.Topology.Validate(); err != nil {
+			return nil, err
+		}
+		// Cluster mode has no numbered databases, so cfg.Database is ignored
+		return redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:           cfg.Topology.Addrs,
+			Password:        cfg.Password,
+			PoolSize:        cfg.PoolSize,
+			MinIdleConns:    cfg.MinIdleConns,
+			DialTimeout:     cfg.DialTimeout,
+			ReadTimeout:     cfg.ReadTimeout,
+			WriteTimeout:    cfg.WriteTimeout,
+			ConnMaxIdleTime: cfg.IdleTimeout,
+		}), nil
+	default:
+		return nil, cfg.Topology.Validate()
+	}
+}
+
+func redisMode(cfg *config.RedisConfig) string {
+	if cfg.Topology.Mode == "" {
+		return config.RedisModeStandalone
+	}
+	return cfg.Topology.Mode
+}
+
+// redisClusterSlots is the number of hash slots a Redis Cluster shards keys over
+const redisClusterSlots = 16384
+
+// ClusterSlot returns the Redis Cluster hash slot of key. When the key has a
+// non-empty {hash tag} only the tag is hashed, which is how keys that must be
+// used together in a transaction or script are placed in one slot.
+func ClusterSlot(key string) int {
+	if start := strings.IndexByte(key, '{'); start >= 0 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			key = key[start+1 : start+1+end]
+		}
+	}
+	return int(crc16(key)) % redisClusterSlots
+}
+
+// crc16 is the CRC-16/XMODEM checksum Redis Cluster hashes keys with
+func crc16(key string) uint16 {
+	var crc uint16
+	for i := 0; i < len(key); i++ {
+		crc ^= uint16(key[i]) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
 // Close closes the Redis connection
 func (r *RedisDB) Close() error {
 	if r.Client != nil {
@@ -91,7 +173,7 @@ func (r *RedisDB) LogStats(ctx context.Context) {
 
 // RedisCache provides caching operations with logging
 type RedisCache struct {
-	client *redis.Client
+	client redis.UniversalClient
 	logger *logger.Logger
 	prefix string
 }
@@ -161,7 +243,7 @@ func (c *RedisCache) Del(ctx context.Context, keys ...string) error {
 	}
 
 	start := time.Now()
-	err := c.client.Del(ctx, prefixedKeys...).Err()
+	err := c.deleteKeys(ctx, prefixedKeys)
 	duration := time.Since(start)
 
 	c.logger.LogCacheOperation(ctx, "DEL", fmt.Sprintf("%v", keys), false, duration)
@@ -176,13 +258,50 @@ func (c *RedisCache) Exists(ctx context.Context, keys ...string) (int64, error)
 	}
 
 	start := time.Now()
-	count, err := c.client.Exists(ctx, prefixedKeys...).Result()
+	count, err := c.countExisting(ctx, prefixedKeys)
 	duration := time.Since(start)
 
 	c.logger.LogCacheOperation(ctx, "EXISTS", fmt.Sprintf("%v", keys), count > 0, duration)
 	return count, err
 }
 
+// deleteKeys and countExisting send one command per key when the client is a
+// cluster client, since multi-key commands fail with CROSSSLOT when the keys
+// hash to different slots. The cluster client routes each pipelined command
+// to its node.
+func (c *RedisCache) deleteKeys(ctx context.Context, keys []string) error {
+	if _, ok := c.client.(*redis.ClusterClient); !ok {
+		return c.client.Del(ctx, keys...).Err()
+	}
+	_, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range keys {
+			pipe.Del(ctx, key)
+		}
+		return nil
+	})
+	return err
+}
+
+func (c *RedisCache) countExisting(ctx context.Context, keys []string) (int64, error) {
+	if _, ok := c.client.(*redis.ClusterClient); !ok {
+		return c.client.Exists(ctx, keys...).Result()
+	}
+	cmds, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range keys {
+			pipe.Exists(ctx, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	for _, cmd := range cmds {
+		count += cmd.(*redis.IntCmd).Val()
+	}
+	return count, nil
+}
+
 // Expire sets expiration for a key
 func (c *RedisCache) Expire(ctx context.Context, key string, expiration time.Duration) error {
 	start := time.Now()
@@ -391,7 +510,7 @@ func (c *RedisCache) SIsMember(ctx context.Context, key string, member interface
 }
 
 // ZAdd adds members to a sorted set
-func (c *RedisCache) ZAdd(ctx context.Context, key string, members ...*redis.Z) error {
+func (c *RedisCache) ZAdd(ctx context.Context, key string, members ...redis.Z) error {
 	start := time.Now()
 	err := c.client.ZAdd(ctx, c.key(key), members...).Err()
 	duration := time.Since(start)
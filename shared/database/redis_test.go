@@ -0,0 +1,30 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusterSlot(t *testing.T) {
+	// Slots as reported by CLUSTER KEYSLOT
+	tests := []struct {
+		key  string
+		slot int
+	}{
+		{"foo", 12182},
+		{"bar", 5061},
+		{"123456789", 12739},
+		{"{user1000}.following", 3443},
+		{"{user1000}.followers", 3443},
+		{"user1000", 3443},
+		// Only the first tag counts, and an empty one hashes the whole key
+		{"foo{user1000}{bar}", 3443},
+		{"{}", 15257},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.slot, ClusterSlot(tt.key), tt.key)
+	}
+
+	assert.NotEqual(t, ClusterSlot("foo{}{user1000}"), ClusterSlot("user1000"), "an empty tag is not a tag")
+}
@@ -0,0 +1,220 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// DefaultMaxReplicaLag applies when the config leaves MaxReplicaLag unset
+const DefaultMaxReplicaLag = 5 * time.Second
+
+// replicationLagQuery reports how far a replica trails the primary in
+// seconds. A replica that has replayed everything it received is current even
+// if the primary has been idle, so it reports zero.
+const replicationLagQuery = `
+	SELECT CASE
+		WHEN NOT pg_is_in_recovery() OR pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+		ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+	END`
+
+// replica is one read replica and its last observed state
+type replica struct {
+	db   *sql.DB
+	name string
+
+	mutex   sync.RWMutex
+	healthy bool
+	lag     time.Duration
+	lastErr error
+}
+
+func (r *replica) usable(maxLag time.Duration) bool {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.healthy && r.lag <= maxLag
+}
+
+func (r *replica) update(lag time.Duration, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.healthy = err == nil
+	r.lastErr = err
+	if err == nil {
+		r.lag = lag
+	}
+}
+
+// ReplicaSet routes read-only queries across read replicas in turn, skipping
+// replicas that are down or lag more than maxLag and falling back to the
+// primary when none is usable
+type ReplicaSet struct {
+	primary  *sql.DB
+	replicas []*replica
+	maxLag   time.Duration
+	logger   *logger.Logger
+	next     atomic.Uint64
+}
+
+// NewReplicaSet opens a connection pool per replica DSN, sized like the primary's
+func NewReplicaSet(primary *sql.DB, dsns []string, maxLag time.Duration, log *logger.Logger) (*ReplicaSet, error) {
+	if maxLag <= 0 {
+		maxLag = DefaultMaxReplicaLag
+	}
+	rs := &ReplicaSet{
+		primary: primary,
+		maxLag:  maxLag,
+		logger:  log,
+	}
+
+	for i, dsn := range dsns {
+		db, err := sql.Open("postgres", dsn)
+		if err != nil {
+			rs.Close()
+			return nil, fmt.Errorf("failed to open replica %d: %w", i, err)
+		}
+		stats := primary.Stats()
+		db.SetMaxOpenConns(stats.MaxOpenConnections)
+		rs.replicas = append(rs.replicas, &replica{
+			db:      db,
+			name:    fmt.Sprintf("replica-%d", i),
+			healthy: true,
+		})
+	}
+	return rs, nil
+}
+
+// Len returns the number of configured replicas
+func (rs *ReplicaSet) Len() int {
+	return len(rs.replicas)
+}
+
+// pick returns the next usable replica, or nil to use the primary
+func (rs *ReplicaSet) pick() *replica {
+	n := len(rs.replicas)
+	if n == 0 {
+		return nil
+	}
+	start := rs.next.Add(1)
+	for i := 0; i < n; i++ {
+		candidate := rs.replicas[(start+uint64(i))%uint64(n)]
+		if candidate.usable(rs.maxLag) {
+			return candidate
+		}
+	}
+	return nil
+}
+
+// QueryContext runs a read-only query on a replica, retrying on the primary
+// if the replica fails
+func (rs *ReplicaSet) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if r := rs.pick(); r != nil {
+		rows, err := r.db.QueryContext(ctx, query, args...)
+		if err == nil {
+			return rows, nil
+		}
+		rs.fallback(ctx, r, err)
+	}
+	return rs.primary.QueryContext(ctx, query, args...)
+}
+
+// QueryRowContext runs a single-row read-only query on a replica, retrying on
+// the primary if the replica fails. sql.ErrNoRows is a result, not a failure.
+func (rs *ReplicaSet) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if r := rs.pick(); r != nil {
+		row := r.db.QueryRowContext(ctx, query, args...)
+		err := row.Err()
+		if err == nil || errors.Is(err, sql.ErrNoRows) {
+			return row
+		}
+		rs.fallback(ctx, r, err)
+	}
+	return rs.primary.QueryRowContext(ctx, query, args...)
+}
+
+func (rs *ReplicaSet) fallback(ctx context.Context, r *replica, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+	r.update(0, err)
+	rs.logger.WithContext(ctx).WithError(err).WithField("replica", r.name).
+		Warn("Replica query failed, falling back to primary")
+}
+
+// CheckReplicas measures every replica's lag, taking failing replicas out of
+// rotation and returning recovered ones to it
+func (rs *ReplicaSet) CheckReplicas(ctx context.Context) {
+	for _, r := range rs.replicas {
+		var seconds float64
+		err := r.db.QueryRowContext(ctx, replicationLagQuery).Scan(&seconds)
+		lag := time.Duration(seconds * float64(time.Second))
+		r.update(lag, err)
+
+		if err != nil {
+			rs.logger.WithError(err).WithField("replica", r.name).Warn("Replica health check failed")
+		} else if lag > rs.maxLag {
+			rs.logger.WithFields(logger.Fields{
+				"replica": r.name,
+				"lag_ms":  lag.Milliseconds(),
+				"max_ms":  rs.maxLag.Milliseconds(),
+			}).Warn("Replica lag exceeds limit, routing reads to other replicas")
+		}
+	}
+}
+
+// StartMonitoring checks replica lag every interval until ctx is cancelled
+func (rs *ReplicaSet) StartMonitoring(ctx context.Context, interval time.Duration) {
+	if len(rs.replicas) == 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	rs.CheckReplicas(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			rs.CheckReplicas(ctx)
+		}
+	}
+}
+
+// Health reports replicas that are down or lagging as of the last check.
+// Reads still succeed through the primary, so it suits an optional check.
+func (rs *ReplicaSet) Health(ctx context.Context) error {
+	var problems []string
+	for _, r := range rs.replicas {
+		r.mutex.RLock()
+		switch {
+		case !r.healthy:
+			problems = append(problems, fmt.Sprintf("%s unreachable: %v", r.name, r.lastErr))
+		case r.lag > rs.maxLag:
+			problems = append(problems, fmt.Sprintf("%s lagging %s", r.name, r.lag.Round(time.Millisecond)))
+		}
+		r.mutex.RUnlock()
+	}
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// Close closes every replica connection pool
+func (rs *ReplicaSet) Close() error {
+	var errs []error
+	for _, r := range rs.replicas {
+		if err := r.db.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,143 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rideshare-platform/shared/logger"
+)
+
+const testReadQuery = `SELECT status FROM trips WHERE id = \$1`
+
+// newTestReplicaSet returns a replica set over mocked databases, the primary's
+// mock and one mock per replica
+func newTestReplicaSet(t *testing.T, replicas int) (*ReplicaSet, sqlmock.Sqlmock, []sqlmock.Sqlmock) {
+	primary, primaryMock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { primary.Close() })
+
+	rs := &ReplicaSet{primary: primary, maxLag: DefaultMaxReplicaLag, logger: logger.NewLogger("error", "test")}
+	var mocks []sqlmock.Sqlmock
+	for i := 0; i < replicas; i++ {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		t.Cleanup(func() { db.Close() })
+		rs.replicas = append(rs.replicas, &replica{db: db, name: "replica", healthy: true})
+		mocks = append(mocks, mock)
+	}
+	return rs, primaryMock, mocks
+}
+
+// expectLag makes a replica's next health check read seconds of lag, or
+// fail when seconds is negative
+func expectLag(mock sqlmock.Sqlmock, seconds float64) {
+	query := mock.ExpectQuery(`pg_last_xact_replay_timestamp`)
+	if seconds < 0 {
+		query.WillReturnError(errors.New("connection refused"))
+		return
+	}
+	query.WillReturnRows(sqlmock.NewRows([]string{"lag"}).AddRow(seconds))
+}
+
+func expectRead(mock sqlmock.Sqlmock) {
+	mock.ExpectQuery(testReadQuery).WithArgs("trip-1").
+		WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("in_progress"))
+}
+
+func TestReplicaSet_RoutesReadsByLag(t *testing.T) {
+	const primary = -1
+	const down = -1.0
+
+	tests := []struct {
+		name   string
+		lags   []float64 // seconds each replica trails the primary, down if unreachable
+		served int       // index of the replica serving the read, or primary
+	}{
+		{"current replica", []float64{0}, 0},
+		{"lag within the limit", []float64{4.9}, 0},
+		{"lag at the limit", []float64{5}, 0},
+		{"lag over the limit", []float64{5.5}, primary},
+		{"far behind", []float64{300}, primary},
+		{"unreachable replica", []float64{down}, primary},
+		{"lagging replica is skipped for a current one", []float64{30, 1}, 1},
+		{"unreachable replica is skipped for a current one", []float64{down, 0.2}, 1},
+		{"every replica lagging", []float64{30, 10}, primary},
+		{"every replica unreachable or lagging", []float64{down, 6}, primary},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs, primaryMock, replicaMocks := newTestReplicaSet(t, len(tt.lags))
+			for i, lag := range tt.lags {
+				expectLag(replicaMocks[i], lag)
+			}
+			rs.CheckReplicas(context.Background())
+
+			if tt.served == primary {
+				expectRead(primaryMock)
+			} else {
+				expectRead(replicaMocks[tt.served])
+			}
+			var status string
+			require.NoError(t, rs.QueryRowContext(context.Background(), "SELECT status FROM trips WHERE id = $1", "trip-1").Scan(&status))
+			assert.Equal(t, "in_progress", status)
+
+			assert.NoError(t, primaryMock.ExpectationsWereMet())
+			for _, mock := range replicaMocks {
+				assert.NoError(t, mock.ExpectationsWereMet())
+			}
+		})
+	}
+}
+
+func TestReplicaSet_LaggingReplicaReturnsOnceCaughtUp(t *testing.T) {
+	rs, primaryMock, replicaMocks := newTestReplicaSet(t, 1)
+	ctx := context.Background()
+
+	expectLag(replicaMocks[0], 12)
+	rs.CheckReplicas(ctx)
+	assert.EqualError(t, rs.Health(ctx), "replica lagging 12s")
+
+	expectRead(primaryMock)
+	rows, err := rs.QueryContext(ctx, "SELECT status FROM trips WHERE id = $1", "trip-1")
+	require.NoError(t, err)
+	rows.Close()
+
+	expectLag(replicaMocks[0], 0.5)
+	rs.CheckReplicas(ctx)
+	assert.NoError(t, rs.Health(ctx))
+
+	expectRead(replicaMocks[0])
+	rows, err = rs.QueryContext(ctx, "SELECT status FROM trips WHERE id = $1", "trip-1")
+	require.NoError(t, err)
+	rows.Close()
+
+	assert.NoError(t, primaryMock.ExpectationsWereMet())
+	assert.NoError(t, replicaMocks[0].ExpectationsWereMet())
+}
+
+func TestReplicaSet_FailedReplicaQueryFallsBackToThePrimary(t *testing.T) {
+	rs, primaryMock, replicaMocks := newTestReplicaSet(t, 1)
+	ctx := context.Background()
+
+	replicaMocks[0].ExpectQuery(testReadQuery).WillReturnError(errors.New("connection reset"))
+	expectRead(primaryMock)
+	rows, err := rs.QueryContext(ctx, "SELECT status FROM trips WHERE id = $1", "trip-1")
+	require.NoError(t, err)
+	rows.Close()
+
+	// The replica stays out of rotation until a health check passes
+	assert.EqualError(t, rs.Health(ctx), "replica unreachable: connection reset")
+	expectRead(primaryMock)
+	rows, err = rs.QueryContext(ctx, "SELECT status FROM trips WHERE id = $1", "trip-1")
+	require.NoError(t, err)
+	rows.Close()
+
+	assert.NoError(t, primaryMock.ExpectationsWereMet())
+	assert.NoError(t, replicaMocks[0].ExpectationsWereMet())
+}
@@ -0,0 +1,194 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// ErrPoison marks a handler error as permanent: the event can never be
+// handled, such as one missing data the handler needs, so it is dead-lettered
+// without being retried. Wrap errors with Poison.
+var ErrPoison = errors.New("poison event")
+
+// Poison marks err as permanent, so the event is dead-lettered at once
+func Poison(err error) error {
+	return fmt.Errorf("%w: %w", ErrPoison, err)
+}
+
+var (
+	handlerRetries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rideshare_event_handler_retries_total",
+		Help: "Event handler attempts retried after a failure, by consumer and event type",
+	}, []string{"consumer", "event_type"})
+	eventsDeadLettered = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rideshare_events_dead_lettered_total",
+		Help: "Events dead-lettered by consumer, event type and whether they were poison",
+	}, []string{"consumer", "event_type", "poison"})
+)
+
+// Subscriber is anything events can be subscribed to, such as an EventBus,
+// an EventPublisher or a Consumer
+type Subscriber interface {
+	Subscribe(eventType EventType, handler EventHandler) error
+}
+
+// RetryPolicy is how often and how patiently a consumer retries an event
+// before dead-lettering it
+type RetryPolicy struct {
+	// MaxAttempts bounds how often a handler is run for an event, including
+	// the first attempt
+	MaxAttempts int
+	// InitialBackoff is the wait before the first retry, multiplied by
+	// Multiplier for each further retry up to MaxBackoff
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+}
+
+// DefaultRetryPolicy returns a policy retrying an event four times over
+// about three seconds
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    5,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+	}
+}
+
+// backoff returns the wait before the given retry, counted from one
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	wait := time.Duration(float64(p.InitialBackoff) * math.Pow(multiplier, float64(retry-1)))
+	if p.MaxBackoff > 0 && (wait > p.MaxBackoff || wait < 0) {
+		wait = p.MaxBackoff
+	}
+	return wait
+}
+
+// Consumer subscribes a named consumer's handlers, such as notifications or
+// a saga step, so that failures are retried with backoff and events that
+// still fail, or are poison, are dead-lettered for an operator to inspect
+// and replay. Handlers are run detached from the publisher's context, since
+// the publisher does not wait for them.
+type Consumer struct {
+	name       string
+	subscriber Subscriber
+	policy     RetryPolicy
+	queue      *DeadLetterQueue
+	logger     *logger.Logger
+
+	mutex    sync.Mutex
+	handlers map[string]EventHandler
+	counts   map[EventType]int
+}
+
+// Consumer creates a consumer named name subscribing to subscriber, whose
+// failed events are dead-lettered in the queue
+func (q *DeadLetterQueue) Consumer(name string, subscriber Subscriber, policy RetryPolicy) *Consumer {
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+	consumer := &Consumer{
+		name:       name,
+		subscriber: subscriber,
+		policy:     policy,
+		queue:      q,
+		logger:     q.logger,
+		handlers:   make(map[string]EventHandler),
+		counts:     make(map[EventType]int),
+	}
+
+	q.mutex.Lock()
+	q.consumers[name] = consumer
+	q.mutex.Unlock()
+	return consumer
+}
+
+// Name returns the consumer's name
+func (c *Consumer) Name() string {
+	return c.name
+}
+
+// Subscribe subscribes handler to eventType with the consumer's retries and
+// dead-lettering
+func (c *Consumer) Subscribe(eventType EventType, handler EventHandler) error {
+	c.mutex.Lock()
+	c.counts[eventType]++
+	// Handlers are identified by the order they subscribed to the event
+	// type, so dead letters can be replayed to the handler that failed
+	handlerID := fmt.Sprintf("%s#%d", eventType, c.counts[eventType])
+	c.handlers[handlerID] = handler
+	c.mutex.Unlock()
+
+	return c.subscriber.Subscribe(eventType, func(ctx context.Context, event *Event) error {
+		ctx = context.WithoutCancel(ctx)
+		attempts, poison, err := c.handle(ctx, handler, event)
+		if err == nil {
+			return nil
+		}
+		c.queue.deadLetter(ctx, c.name, handlerID, event, attempts, poison, err)
+		return err
+	})
+}
+
+// handle runs handler until it succeeds, fails with a poison error or runs
+// out of attempts, and returns how often it ran
+func (c *Consumer) handle(ctx context.Context, handler EventHandler, event *Event) (int, bool, error) {
+	var err error
+	for attempt := 1; attempt <= c.policy.MaxAttempts; attempt++ {
+		if attempt > 1 {
+			handlerRetries.WithLabelValues(c.name, string(event.Type)).Inc()
+			select {
+			case <-time.After(c.policy.backoff(attempt - 1)):
+			case <-ctx.Done():
+				return attempt - 1, false, err
+			}
+		}
+
+		err = c.run(ctx, handler, event)
+		if err == nil {
+			return attempt, false, nil
+		}
+		if errors.Is(err, ErrPoison) {
+			return attempt, true, err
+		}
+		c.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+			"consumer":   c.name,
+			"event_type": event.Type,
+			"event_id":   event.ID,
+			"attempt":    attempt,
+		}).Warn("Event handler failed")
+	}
+	return c.policy.MaxAttempts, false, err
+}
+
+// run runs handler once, treating a panic as a poison event
+func (c *Consumer) run(ctx context.Context, handler EventHandler, event *Event) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = Poison(fmt.Errorf("handler panicked: %v", recovered))
+		}
+	}()
+	return handler(ctx, event)
+}
+
+// handler returns the subscribed handler a dead letter was recorded for
+func (c *Consumer) handler(handlerID string) (EventHandler, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	handler, ok := c.handlers[handlerID]
+	return handler, ok
+}
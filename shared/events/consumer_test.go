@@ -0,0 +1,171 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// syncSubscriber runs handlers as events are published, one after another,
+// so tests can check what a publish left behind
+type syncSubscriber struct {
+	handlers map[EventType][]EventHandler
+}
+
+func newSyncSubscriber() *syncSubscriber {
+	return &syncSubscriber{handlers: make(map[EventType][]EventHandler)}
+}
+
+func (s *syncSubscriber) Subscribe(eventType EventType, handler EventHandler) error {
+	s.handlers[eventType] = append(s.handlers[eventType], handler)
+	return nil
+}
+
+func (s *syncSubscriber) publish(ctx context.Context, event *Event) error {
+	var errs []error
+	for _, handler := range s.handlers[event.Type] {
+		errs = append(errs, handler(ctx, event))
+	}
+	return errors.Join(errs...)
+}
+
+// testRetryPolicy retries quickly so tests do not wait on backoff
+func testRetryPolicy(maxAttempts int) RetryPolicy {
+	return RetryPolicy{MaxAttempts: maxAttempts, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond, Multiplier: 2}
+}
+
+func newTestQueue() *DeadLetterQueue {
+	return NewDeadLetterQueue(NewInMemoryDeadLetterStore(), logger.NewLogger("error", "test"))
+}
+
+func newTestEvent(id string) *Event {
+	event := NewEvent(TripCompletedEvent, "trip-1", 1, map[string]interface{}{"fare": 12.5}, "trip-service")
+	event.ID = id
+	return event
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	assert.Equal(t, 200*time.Millisecond, policy.backoff(1))
+	assert.Equal(t, 400*time.Millisecond, policy.backoff(2))
+	assert.Equal(t, 3200*time.Millisecond, policy.backoff(5))
+	assert.Equal(t, 5*time.Second, policy.backoff(6), "capped at MaxBackoff")
+	assert.Equal(t, 5*time.Second, policy.backoff(200), "overflowing waits are capped too")
+
+	// Multipliers below one keep the wait constant
+	policy.Multiplier = 0
+	assert.Equal(t, 200*time.Millisecond, policy.backoff(4))
+}
+
+func TestConsumer_RetriesUntilHandled(t *testing.T) {
+	ctx := context.Background()
+	queue := newTestQueue()
+	subscriber := newSyncSubscriber()
+	consumer := queue.Consumer("notifications", subscriber, testRetryPolicy(5))
+
+	calls := 0
+	require.NoError(t, consumer.Subscribe(TripCompletedEvent, func(ctx context.Context, event *Event) error {
+		calls++
+		if calls < 3 {
+			return errors.New("provider unavailable")
+		}
+		return nil
+	}))
+
+	require.NoError(t, subscriber.publish(ctx, newTestEvent("evt-1")))
+	assert.Equal(t, 3, calls)
+	letters, err := queue.List(ctx, DeadLetterFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, letters)
+}
+
+func TestConsumer_DeadLettersAfterMaxAttempts(t *testing.T) {
+	ctx := context.Background()
+	queue := newTestQueue()
+	subscriber := newSyncSubscriber()
+	consumer := queue.Consumer("notifications", subscriber, testRetryPolicy(3))
+
+	calls := 0
+	require.NoError(t, consumer.Subscribe(TripCompletedEvent, func(ctx context.Context, event *Event) error {
+		calls++
+		return errors.New("provider unavailable")
+	}))
+
+	assert.Error(t, subscriber.publish(ctx, newTestEvent("evt-1")))
+	assert.Equal(t, 3, calls, "the handler runs MaxAttempts times")
+
+	letter, err := queue.Get(ctx, deadLetterID("notifications", "trip.completed#1", "evt-1"))
+	require.NoError(t, err)
+	assert.Equal(t, "notifications", letter.Consumer)
+	assert.Equal(t, "trip.completed#1", letter.Handler)
+	assert.Equal(t, "evt-1", letter.Event.ID)
+	assert.Equal(t, 12.5, letter.Event.Data["fare"])
+	assert.Equal(t, "provider unavailable", letter.Error)
+	assert.Equal(t, 3, letter.Attempts)
+	assert.False(t, letter.Poison)
+
+	// The same event failing again updates its dead letter
+	firstFailedAt := letter.FirstFailedAt
+	assert.Error(t, subscriber.publish(ctx, newTestEvent("evt-1")))
+	letters, err := queue.List(ctx, DeadLetterFilter{})
+	require.NoError(t, err)
+	require.Len(t, letters, 1)
+	assert.Equal(t, 6, letters[0].Attempts)
+	assert.Equal(t, firstFailedAt, letters[0].FirstFailedAt)
+}
+
+func TestConsumer_DeadLettersPoisonEventsAtOnce(t *testing.T) {
+	ctx := context.Background()
+	queue := newTestQueue()
+	subscriber := newSyncSubscriber()
+	consumer := queue.Consumer("notifications", subscriber, testRetryPolicy(5))
+
+	calls := 0
+	require.NoError(t, consumer.Subscribe(TripCompletedEvent, func(ctx context.Context, event *Event) error {
+		calls++
+		return Poison(errors.New("event has no rider"))
+	}))
+	require.NoError(t, consumer.Subscribe(TripCompletedEvent, func(ctx context.Context, event *Event) error {
+		panic("unexpected event shape")
+	}))
+
+	assert.ErrorIs(t, subscriber.publish(ctx, newTestEvent("evt-1")), ErrPoison)
+	assert.Equal(t, 1, calls, "poison events are not retried")
+
+	poison := true
+	letters, err := queue.List(ctx, DeadLetterFilter{Poison: &poison})
+	require.NoError(t, err)
+	require.Len(t, letters, 2, "a panicking handler is poison too")
+	for _, letter := range letters {
+		assert.Equal(t, 1, letter.Attempts)
+		assert.True(t, letter.Poison)
+	}
+	panicked, err := queue.Get(ctx, deadLetterID("notifications", "trip.completed#2", "evt-1"))
+	require.NoError(t, err)
+	assert.Contains(t, panicked.Error, "handler panicked")
+}
+
+func TestConsumer_HandlesEventsDetachedFromThePublisher(t *testing.T) {
+	queue := newTestQueue()
+	subscriber := newSyncSubscriber()
+	consumer := queue.Consumer("notifications", subscriber, RetryPolicy{})
+
+	// The publisher has moved on, but the handler still runs, once
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls := 0
+	require.NoError(t, consumer.Subscribe(TripCompletedEvent, func(ctx context.Context, event *Event) error {
+		calls++
+		return ctx.Err()
+	}))
+
+	require.NoError(t, subscriber.publish(ctx, newTestEvent("evt-1")))
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, "notifications", consumer.Name())
+}
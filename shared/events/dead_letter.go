@@ -0,0 +1,264 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/shared/logger"
+)
+
+var (
+	// ErrDeadLetterNotFound is returned for dead letters that were replayed,
+	// discarded or never existed
+	ErrDeadLetterNotFound = errors.New("dead letter not found")
+	// ErrUnknownConsumer is returned when replaying a dead letter of a
+	// consumer or handler this service does not run
+	ErrUnknownConsumer = errors.New("consumer not found")
+)
+
+// DeadLetter is an event a consumer failed to handle, kept with why and how
+// often it failed until it is replayed or discarded
+type DeadLetter struct {
+	ID       string `json:"id"`
+	Consumer string `json:"consumer"`
+	// Handler identifies which of the consumer's handlers failed
+	Handler  string `json:"handler"`
+	Event    *Event `json:"event"`
+	Error    string `json:"error"`
+	Poison   bool   `json:"poison"`
+	Attempts int    `json:"attempts"`
+	// Replays counts the replays that failed again
+	Replays       int       `json:"replays"`
+	FirstFailedAt time.Time `json:"first_failed_at"`
+	LastFailedAt  time.Time `json:"last_failed_at"`
+}
+
+// DeadLetterFilter selects dead letters. Empty fields match every dead letter.
+type DeadLetterFilter struct {
+	Consumer  string
+	EventType EventType
+	Poison    *bool
+	Limit     int
+}
+
+// matches reports whether a dead letter is selected by the filter
+func (f DeadLetterFilter) matches(letter *DeadLetter) bool {
+	return (f.Consumer == "" || letter.Consumer == f.Consumer) &&
+		(f.EventType == "" || letter.Event.Type == f.EventType) &&
+		(f.Poison == nil || letter.Poison == *f.Poison)
+}
+
+// DeadLetterStore persists dead letters
+type DeadLetterStore interface {
+	SaveDeadLetter(ctx context.Context, letter *DeadLetter) error
+	GetDeadLetter(ctx context.Context, id string) (*DeadLetter, error)
+	// ListDeadLetters returns the dead letters matching filter, most
+	// recently failed first
+	ListDeadLetters(ctx context.Context, filter DeadLetterFilter) ([]*DeadLetter, error)
+	DeleteDeadLetter(ctx context.Context, id string) error
+}
+
+// InMemoryDeadLetterStore keeps dead letters in memory, storing copies so
+// callers cannot mutate saved dead letters
+type InMemoryDeadLetterStore struct {
+	letters map[string][]byte
+	mutex   sync.RWMutex
+}
+
+// NewInMemoryDeadLetterStore creates a new in-memory dead letter store
+func NewInMemoryDeadLetterStore() *InMemoryDeadLetterStore {
+	return &InMemoryDeadLetterStore{
+		letters: make(map[string][]byte),
+	}
+}
+
+// SaveDeadLetter saves a copy of the dead letter
+func (s *InMemoryDeadLetterStore) SaveDeadLetter(ctx context.Context, letter *DeadLetter) error {
+	data, err := json.Marshal(letter)
+	if err != nil {
+		return fmt.Errorf("failed to marshal dead letter: %w", err)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.letters[letter.ID] = data
+	return nil
+}
+
+// GetDeadLetter retrieves a copy of a dead letter
+func (s *InMemoryDeadLetterStore) GetDeadLetter(ctx context.Context, id string) (*DeadLetter, error) {
+	s.mutex.RLock()
+	data, exists := s.letters[id]
+	s.mutex.RUnlock()
+
+	if !exists {
+		return nil, ErrDeadLetterNotFound
+	}
+	return decodeDeadLetter(data)
+}
+
+// ListDeadLetters retrieves the dead letters matching filter, most recently
+// failed first
+func (s *InMemoryDeadLetterStore) ListDeadLetters(ctx context.Context, filter DeadLetterFilter) ([]*DeadLetter, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var letters []*DeadLetter
+	for _, data := range s.letters {
+		letter, err := decodeDeadLetter(data)
+		if err != nil {
+			return nil, err
+		}
+		if filter.matches(letter) {
+			letters = append(letters, letter)
+		}
+	}
+	sort.Slice(letters, func(i, j int) bool {
+		return letters[i].LastFailedAt.After(letters[j].LastFailedAt)
+	})
+	if filter.Limit > 0 && len(letters) > filter.Limit {
+		letters = letters[:filter.Limit]
+	}
+	return letters, nil
+}
+
+// DeleteDeadLetter removes a dead letter
+func (s *InMemoryDeadLetterStore) DeleteDeadLetter(ctx context.Context, id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, exists := s.letters[id]; !exists {
+		return ErrDeadLetterNotFound
+	}
+	delete(s.letters, id)
+	return nil
+}
+
+func decodeDeadLetter(data []byte) (*DeadLetter, error) {
+	var letter DeadLetter
+	if err := json.Unmarshal(data, &letter); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal dead letter: %w", err)
+	}
+	return &letter, nil
+}
+
+// DeadLetterQueue collects the events a service's consumers failed to
+// handle and replays them to the handler that failed once the cause is fixed
+type DeadLetterQueue struct {
+	store  DeadLetterStore
+	logger *logger.Logger
+
+	mutex     sync.Mutex
+	consumers map[string]*Consumer
+}
+
+// NewDeadLetterQueue creates a dead letter queue kept in store
+func NewDeadLetterQueue(store DeadLetterStore, log *logger.Logger) *DeadLetterQueue {
+	return &DeadLetterQueue{
+		store:     store,
+		logger:    log,
+		consumers: make(map[string]*Consumer),
+	}
+}
+
+// deadLetterID identifies a consumer's handler's dead letter of an event, so
+// an event failing the same handler again updates its dead letter
+func deadLetterID(consumer, handlerID, eventID string) string {
+	return consumer + ":" + handlerID + ":" + eventID
+}
+
+// deadLetter records an event a consumer's handler failed to handle
+func (q *DeadLetterQueue) deadLetter(ctx context.Context, consumer, handlerID string, event *Event, attempts int, poison bool, cause error) {
+	eventsDeadLettered.WithLabelValues(consumer, string(event.Type), strconv.FormatBool(poison)).Inc()
+	now := time.Now().UTC()
+	letter := &DeadLetter{
+		ID:            deadLetterID(consumer, handlerID, event.ID),
+		Consumer:      consumer,
+		Handler:       handlerID,
+		Event:         event,
+		Error:         cause.Error(),
+		Poison:        poison,
+		Attempts:      attempts,
+		FirstFailedAt: now,
+		LastFailedAt:  now,
+	}
+	if previous, err := q.store.GetDeadLetter(ctx, letter.ID); err == nil {
+		letter.Attempts += previous.Attempts
+		letter.Replays = previous.Replays
+		letter.FirstFailedAt = previous.FirstFailedAt
+	}
+
+	fields := logger.Fields{
+		"consumer":   consumer,
+		"event_type": event.Type,
+		"event_id":   event.ID,
+		"attempts":   attempts,
+		"poison":     poison,
+	}
+	if err := q.store.SaveDeadLetter(ctx, letter); err != nil {
+		q.logger.WithContext(ctx).WithError(err).WithFields(fields).Error("Failed to dead-letter event")
+		return
+	}
+	q.logger.WithContext(ctx).WithError(cause).WithFields(fields).Error("Event dead-lettered")
+}
+
+// List returns the dead letters matching filter, most recently failed first
+func (q *DeadLetterQueue) List(ctx context.Context, filter DeadLetterFilter) ([]*DeadLetter, error) {
+	return q.store.ListDeadLetters(ctx, filter)
+}
+
+// Get returns a dead letter
+func (q *DeadLetterQueue) Get(ctx context.Context, id string) (*DeadLetter, error) {
+	return q.store.GetDeadLetter(ctx, id)
+}
+
+// Discard drops a dead letter without handling its event
+func (q *DeadLetterQueue) Discard(ctx context.Context, id string) error {
+	return q.store.DeleteDeadLetter(ctx, id)
+}
+
+// Replay hands a dead letter's event to the handler that failed it again,
+// with the consumer's retries. The dead letter is removed once the event is
+// handled; otherwise it is updated with the new failure, which is returned.
+func (q *DeadLetterQueue) Replay(ctx context.Context, id string) error {
+	letter, err := q.store.GetDeadLetter(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	q.mutex.Lock()
+	consumer := q.consumers[letter.Consumer]
+	q.mutex.Unlock()
+	if consumer == nil {
+		return fmt.Errorf("%w: %s", ErrUnknownConsumer, letter.Consumer)
+	}
+	handler, ok := consumer.handler(letter.Handler)
+	if !ok {
+		return fmt.Errorf("%w: %s has no handler %s", ErrUnknownConsumer, letter.Consumer, letter.Handler)
+	}
+
+	attempts, poison, handleErr := consumer.handle(ctx, handler, letter.Event)
+	if handleErr == nil {
+		q.logger.WithContext(ctx).WithFields(logger.Fields{
+			"consumer":   letter.Consumer,
+			"event_type": letter.Event.Type,
+			"event_id":   letter.Event.ID,
+		}).Info("Dead-lettered event replayed")
+		return q.store.DeleteDeadLetter(ctx, id)
+	}
+
+	letter.Error = handleErr.Error()
+	letter.Poison = poison
+	letter.Attempts += attempts
+	letter.Replays++
+	letter.LastFailedAt = time.Now().UTC()
+	if err := q.store.SaveDeadLetter(ctx, letter); err != nil {
+		return fmt.Errorf("failed to update dead letter: %w", err)
+	}
+	return handleErr
+}
@@ -0,0 +1,124 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+// defaultDeadLetterLimit is how many dead letters are listed when no limit
+// is asked for
+const defaultDeadLetterLimit = 100
+
+// DeadLetterHandler serves the admin API operators inspect, replay and
+// discard dead-lettered events with. It does not authenticate requests, so it
+// is only mounted behind operator authentication, such as the gateway's
+// admin router, never on a service's public listener.
+type DeadLetterHandler struct {
+	queue *DeadLetterQueue
+}
+
+// NewDeadLetterHandler creates a new dead letter handler
+func NewDeadLetterHandler(queue *DeadLetterQueue) *DeadLetterHandler {
+	return &DeadLetterHandler{queue: queue}
+}
+
+// RegisterRoutes registers the dead letter routes
+func (h *DeadLetterHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /api/v1/admin/dead-letters", h.List)
+	mux.HandleFunc("GET /api/v1/admin/dead-letters/{id}", h.Get)
+	mux.HandleFunc("POST /api/v1/admin/dead-letters/{id}/replay", h.Replay)
+	mux.HandleFunc("DELETE /api/v1/admin/dead-letters/{id}", h.Discard)
+}
+
+// List returns dead letters, most recently failed first. They can be
+// narrowed with the consumer, event_type and poison query parameters.
+func (h *DeadLetterHandler) List(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := DeadLetterFilter{
+		Consumer:  query.Get("consumer"),
+		EventType: EventType(query.Get("event_type")),
+		Limit:     defaultDeadLetterLimit,
+	}
+	if value := query.Get("poison"); value != "" {
+		poison, err := strconv.ParseBool(value)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_request", errors.New("poison must be true or false"))
+			return
+		}
+		filter.Poison = &poison
+	}
+	if limit, err := strconv.Atoi(query.Get("limit")); err == nil && limit > 0 && limit <= 1000 {
+		filter.Limit = limit
+	}
+
+	letters, err := h.queue.List(r.Context(), filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal_error", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"dead_letters": letters,
+		"count":        len(letters),
+	})
+}
+
+// Get returns a dead letter with its event
+func (h *DeadLetterHandler) Get(w http.ResponseWriter, r *http.Request) {
+	letter, err := h.queue.Get(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeDeadLetterError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, letter)
+}
+
+// Replay hands a dead letter's event to the handler that failed it again.
+// Events that fail again stay dead-lettered and are answered with 422.
+func (h *DeadLetterHandler) Replay(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if err := h.queue.Replay(r.Context(), id); err != nil {
+		if errors.Is(err, ErrDeadLetterNotFound) || errors.Is(err, ErrUnknownConsumer) {
+			writeDeadLetterError(w, err)
+			return
+		}
+		writeError(w, http.StatusUnprocessableEntity, "replay_failed", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"id":       id,
+		"replayed": true,
+	})
+}
+
+// Discard drops a dead letter without handling its event
+func (h *DeadLetterHandler) Discard(w http.ResponseWriter, r *http.Request) {
+	if err := h.queue.Discard(r.Context(), r.PathValue("id")); err != nil {
+		writeDeadLetterError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeDeadLetterError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrDeadLetterNotFound), errors.Is(err, ErrUnknownConsumer):
+		writeError(w, http.StatusNotFound, "not_found", err)
+	default:
+		writeError(w, http.StatusInternalServerError, "internal_error", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, code string, err error) {
+	writeJSON(w, status, map[string]string{
+		"error":   code,
+		"message": err.Error(),
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,78 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeadLetterHandler(t *testing.T) {
+	ctx := context.Background()
+	queue := newTestQueue()
+	subscriber := newSyncSubscriber()
+	consumer := queue.Consumer("notifications", subscriber, testRetryPolicy(1))
+
+	providerDown := true
+	require.NoError(t, consumer.Subscribe(TripCompletedEvent, func(ctx context.Context, event *Event) error {
+		if event.Data["rider_id"] == nil {
+			return Poison(errors.New("event has no rider"))
+		}
+		if providerDown {
+			return errors.New("provider unavailable")
+		}
+		return nil
+	}))
+	failing := newTestEvent("evt-1")
+	failing.Data["rider_id"] = "rider-1"
+	assert.Error(t, subscriber.publish(ctx, failing))
+	assert.Error(t, subscriber.publish(ctx, newTestEvent("evt-2")))
+	failingID := url.PathEscape(deadLetterID("notifications", "trip.completed#1", "evt-1"))
+	poisonID := url.PathEscape(deadLetterID("notifications", "trip.completed#1", "evt-2"))
+
+	mux := http.NewServeMux()
+	NewDeadLetterHandler(queue).RegisterRoutes(mux)
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(method, path, nil))
+		return recorder
+	}
+
+	recorder := serve("GET", "/api/v1/admin/dead-letters?poison=true")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var listed struct {
+		DeadLetters []*DeadLetter `json:"dead_letters"`
+		Count       int           `json:"count"`
+	}
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&listed))
+	require.Equal(t, 1, listed.Count)
+	assert.Equal(t, "evt-2", listed.DeadLetters[0].Event.ID)
+
+	assert.Equal(t, http.StatusBadRequest, serve("GET", "/api/v1/admin/dead-letters?poison=maybe").Code)
+	assert.Equal(t, http.StatusNotFound, serve("GET", "/api/v1/admin/dead-letters/missing").Code)
+
+	recorder = serve("GET", "/api/v1/admin/dead-letters/"+failingID)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var letter DeadLetter
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&letter))
+	assert.Equal(t, "provider unavailable", letter.Error)
+
+	// Events that fail again stay dead-lettered
+	assert.Equal(t, http.StatusUnprocessableEntity, serve("POST", "/api/v1/admin/dead-letters/"+failingID+"/replay").Code)
+	providerDown = false
+	assert.Equal(t, http.StatusOK, serve("POST", "/api/v1/admin/dead-letters/"+failingID+"/replay").Code)
+	assert.Equal(t, http.StatusNotFound, serve("POST", "/api/v1/admin/dead-letters/"+failingID+"/replay").Code)
+
+	assert.Equal(t, http.StatusNoContent, serve("DELETE", "/api/v1/admin/dead-letters/"+poisonID).Code)
+	assert.Equal(t, http.StatusNotFound, serve("DELETE", "/api/v1/admin/dead-letters/"+poisonID).Code)
+
+	letters, err := queue.List(ctx, DeadLetterFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, letters)
+}
@@ -0,0 +1,137 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemoryDeadLetterStore_FiltersAndCopies(t *testing.T) {
+	ctx := context.Background()
+	store := NewInMemoryDeadLetterStore()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	letters := []*DeadLetter{
+		{ID: "a", Consumer: "notifications", Event: newTestEvent("evt-a"), LastFailedAt: now},
+		{ID: "b", Consumer: "notifications", Event: newTestEvent("evt-b"), Poison: true, LastFailedAt: now.Add(time.Minute)},
+		{ID: "c", Consumer: "trip-contacts", Event: newTestEvent("evt-c"), LastFailedAt: now.Add(2 * time.Minute)},
+	}
+	for _, letter := range letters {
+		require.NoError(t, store.SaveDeadLetter(ctx, letter))
+	}
+
+	listed, err := store.ListDeadLetters(ctx, DeadLetterFilter{})
+	require.NoError(t, err)
+	require.Len(t, listed, 3)
+	assert.Equal(t, []string{"c", "b", "a"}, []string{listed[0].ID, listed[1].ID, listed[2].ID}, "most recently failed first")
+
+	poison := false
+	listed, err = store.ListDeadLetters(ctx, DeadLetterFilter{Consumer: "notifications", Poison: &poison})
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, "a", listed[0].ID)
+	listed, err = store.ListDeadLetters(ctx, DeadLetterFilter{EventType: TripRequestedEvent})
+	require.NoError(t, err)
+	assert.Empty(t, listed)
+	listed, err = store.ListDeadLetters(ctx, DeadLetterFilter{Limit: 2})
+	require.NoError(t, err)
+	assert.Len(t, listed, 2)
+
+	// Changing a dead letter after saving or loading it leaves the store alone
+	letters[0].Error = "changed"
+	loaded, err := store.GetDeadLetter(ctx, "a")
+	require.NoError(t, err)
+	assert.Empty(t, loaded.Error)
+	loaded.Event.ID = "changed"
+	loaded, err = store.GetDeadLetter(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, "evt-a", loaded.Event.ID)
+
+	require.NoError(t, store.DeleteDeadLetter(ctx, "a"))
+	_, err = store.GetDeadLetter(ctx, "a")
+	assert.ErrorIs(t, err, ErrDeadLetterNotFound)
+	assert.ErrorIs(t, store.DeleteDeadLetter(ctx, "a"), ErrDeadLetterNotFound)
+}
+
+func TestDeadLetterQueue_ReplaysToTheHandlerThatFailed(t *testing.T) {
+	ctx := context.Background()
+	queue := newTestQueue()
+	subscriber := newSyncSubscriber()
+	consumer := queue.Consumer("notifications", subscriber, testRetryPolicy(2))
+
+	var firstCalls, secondCalls int
+	providerDown := true
+	require.NoError(t, consumer.Subscribe(TripCompletedEvent, func(ctx context.Context, event *Event) error {
+		firstCalls++
+		return nil
+	}))
+	require.NoError(t, consumer.Subscribe(TripCompletedEvent, func(ctx context.Context, event *Event) error {
+		secondCalls++
+		if providerDown {
+			return errors.New("provider unavailable")
+		}
+		return nil
+	}))
+
+	assert.Error(t, subscriber.publish(ctx, newTestEvent("evt-1")))
+	id := deadLetterID("notifications", "trip.completed#2", "evt-1")
+
+	// A replay that fails again keeps the dead letter with the new failure
+	err := queue.Replay(ctx, id)
+	assert.EqualError(t, err, "provider unavailable")
+	letter, err := queue.Get(ctx, id)
+	require.NoError(t, err)
+	assert.Equal(t, 4, letter.Attempts)
+	assert.Equal(t, 1, letter.Replays)
+	assert.False(t, letter.LastFailedAt.Before(letter.FirstFailedAt))
+
+	// Once the cause is fixed the replay handles the event and drops it
+	providerDown = false
+	require.NoError(t, queue.Replay(ctx, id))
+	_, err = queue.Get(ctx, id)
+	assert.ErrorIs(t, err, ErrDeadLetterNotFound)
+	assert.Equal(t, 1, firstCalls, "handlers that succeeded are not run again")
+	assert.Equal(t, 5, secondCalls)
+
+	assert.ErrorIs(t, queue.Replay(ctx, id), ErrDeadLetterNotFound)
+}
+
+func TestDeadLetterQueue_ReplayOfUnknownConsumer(t *testing.T) {
+	ctx := context.Background()
+	store := NewInMemoryDeadLetterStore()
+	queue := NewDeadLetterQueue(store, newTestQueue().logger)
+	queue.Consumer("notifications", newSyncSubscriber(), testRetryPolicy(1))
+
+	// Left by a consumer this service no longer runs, or a handler it dropped
+	require.NoError(t, store.SaveDeadLetter(ctx, &DeadLetter{ID: "gone", Consumer: "legacy-billing", Handler: "trip.completed#1", Event: newTestEvent("evt-1")}))
+	require.NoError(t, store.SaveDeadLetter(ctx, &DeadLetter{ID: "dropped", Consumer: "notifications", Handler: "trip.completed#3", Event: newTestEvent("evt-2")}))
+
+	assert.ErrorIs(t, queue.Replay(ctx, "gone"), ErrUnknownConsumer)
+	assert.ErrorIs(t, queue.Replay(ctx, "dropped"), ErrUnknownConsumer)
+	_, err := queue.Get(ctx, "gone")
+	assert.NoError(t, err, "dead letters that cannot be replayed are kept")
+}
+
+func TestDeadLetterQueue_Discard(t *testing.T) {
+	ctx := context.Background()
+	queue := newTestQueue()
+	subscriber := newSyncSubscriber()
+	consumer := queue.Consumer("notifications", subscriber, testRetryPolicy(1))
+
+	calls := 0
+	require.NoError(t, consumer.Subscribe(TripCompletedEvent, func(ctx context.Context, event *Event) error {
+		calls++
+		return Poison(errors.New("event has no rider"))
+	}))
+	assert.Error(t, subscriber.publish(ctx, newTestEvent("evt-1")))
+	id := deadLetterID("notifications", "trip.completed#1", "evt-1")
+
+	require.NoError(t, queue.Discard(ctx, id))
+	assert.Equal(t, 1, calls, "discarding does not handle the event")
+	_, err := queue.Get(ctx, id)
+	assert.ErrorIs(t, err, ErrDeadLetterNotFound)
+	assert.ErrorIs(t, queue.Discard(ctx, id), ErrDeadLetterNotFound)
+}
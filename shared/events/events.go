@@ -19,16 +19,23 @@ const (
 	UserDeactivatedEvent EventType = "user.deactivated"
 
 	// Driver events
-	DriverOnlineEvent     EventType = "driver.online"
-	DriverOfflineEvent    EventType = "driver.offline"
-	DriverLocationUpdated EventType = "driver.location_updated"
+	DriverOnlineEvent         EventType = "driver.online"
+	DriverOfflineEvent        EventType = "driver.offline"
+	DriverLocationUpdated     EventType = "driver.location_updated"
+	DriverStateChangedEvent   EventType = "driver.state_changed"
+	DriverFatigueWarningEvent EventType = "driver.fatigue_warning"
 
 	// Trip events
-	TripRequestedEvent EventType = "trip.requested"
-	TripMatchedEvent   EventType = "trip.matched"
-	TripStartedEvent   EventType = "trip.started"
-	TripCompletedEvent EventType = "trip.completed"
-	TripCancelledEvent EventType = "trip.cancelled"
+	TripRequestedEvent            EventType = "trip.requested"
+	TripMatchedEvent              EventType = "trip.matched"
+	TripDriverArrivedEvent        EventType = "trip.driver_arrived"
+	TripStartedEvent              EventType = "trip.started"
+	TripCompletedEvent            EventType = "trip.completed"
+	TripCancelledEvent            EventType = "trip.cancelled"
+	TripDriverNoShowEvent         EventType = "trip.driver_no_show"
+	TripReceiptIssuedEvent        EventType = "trip.receipt_issued"
+	TripRiderLocationUpdatedEvent EventType = "trip.rider_location_updated"
+	TripLostItemReportedEvent     EventType = "trip.lost_item_reported"
 
 	// Payment events
 	PaymentProcessedEvent EventType = "payment.processed"
@@ -36,11 +43,16 @@ const (
 	PaymentRefundedEvent  EventType = "payment.refunded"
 
 	// Vehicle events
-	VehicleRegisteredEvent  EventType = "vehicle.registered"
-	VehicleUpdatedEvent     EventType = "vehicle.updated"
-	VehicleDeactivatedEvent EventType = "vehicle.deactivated"
+	VehicleRegisteredEvent       EventType = "vehicle.registered"
+	VehicleUpdatedEvent          EventType = "vehicle.updated"
+	VehicleDeactivatedEvent      EventType = "vehicle.deactivated"
+	VehicleDocumentExpiringEvent EventType = "vehicle.document_expiring"
 )
 
+// ExperimentsMetadataKey is the event metadata holding the experiment
+// variants the trip was in, keyed by experiment name
+const ExperimentsMetadataKey = "experiments"
+
 // Event represents a domain event
 type Event struct {
 	ID          string                 `json:"id"`
@@ -0,0 +1,63 @@
+package experiments
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/rideshare-platform/shared/analytics"
+)
+
+// counterPrefix starts the names of counters recorded for a variant:
+// experiment.<experiment>.<variant>.<counter>
+const counterPrefix = "experiment."
+
+// Tag adds a copy of every counter for each variant the context is assigned
+// to, so a rollup holds both the overall and the per-variant counts
+func Tag(ctx context.Context, counters analytics.Counters) analytics.Counters {
+	assignments := FromContext(ctx)
+	if len(assignments) == 0 || len(counters) == 0 {
+		return counters
+	}
+
+	tagged := make(analytics.Counters, len(counters)*(len(assignments)+1))
+	tagged.Merge(counters)
+	for experiment, variant := range assignments {
+		prefix := counterPrefix + experiment + "." + variant + "."
+		for name, value := range counters {
+			tagged.Add(prefix+name, value)
+		}
+	}
+	return tagged
+}
+
+// VariantCounters are the counters recorded for one variant
+type VariantCounters struct {
+	Variant  string
+	Counters analytics.Counters
+}
+
+// Compare splits the experiment's counters in a summary by variant, ordered
+// by variant name
+func Compare(summary *analytics.Summary, experiment string) []*VariantCounters {
+	byVariant := make(map[string]analytics.Counters)
+	for key, value := range summary.WithPrefix(counterPrefix + experiment + ".") {
+		variant, name, found := strings.Cut(key, ".")
+		if !found || name == "" {
+			continue
+		}
+		if byVariant[variant] == nil {
+			byVariant[variant] = make(analytics.Counters)
+		}
+		byVariant[variant].Add(name, value)
+	}
+
+	variants := make([]*VariantCounters, 0, len(byVariant))
+	for variant, counters := range byVariant {
+		variants = append(variants, &VariantCounters{Variant: variant, Counters: counters})
+	}
+	sort.Slice(variants, func(i, j int) bool {
+		return variants[i].Variant < variants[j].Variant
+	})
+	return variants
+}
@@ -0,0 +1,103 @@
+package experiments
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// Assignments maps experiment names to the variant a request is in
+type Assignments map[string]string
+
+// String encodes the assignments as sorted experiment=variant pairs
+// separated by commas, the form they take in gRPC metadata
+func (a Assignments) String() string {
+	pairs := make([]string, 0, len(a))
+	for experiment, variant := range a {
+		pairs = append(pairs, experiment+"="+variant)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// ParseAssignments decodes assignments encoded by String. Malformed pairs
+// are skipped.
+func ParseAssignments(value string) Assignments {
+	assignments := make(Assignments)
+	for _, pair := range strings.Split(value, ",") {
+		experiment, variant, found := strings.Cut(strings.TrimSpace(pair), "=")
+		if found && namePattern.MatchString(experiment) && namePattern.MatchString(variant) {
+			assignments[experiment] = variant
+		}
+	}
+	return assignments
+}
+
+// assignmentsKey stores a request's assignments in its context
+type assignmentsKey struct{}
+
+// WithAssignments returns a context carrying the assignments
+func WithAssignments(ctx context.Context, assignments Assignments) context.Context {
+	if len(assignments) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, assignmentsKey{}, assignments)
+}
+
+// FromContext returns the assignments the context carries, or nil
+func FromContext(ctx context.Context) Assignments {
+	assignments, _ := ctx.Value(assignmentsKey{}).(Assignments)
+	return assignments
+}
+
+// VariantOf returns the variant of the experiment the context is assigned
+// to, or "" when it is not in the experiment
+func VariantOf(ctx context.Context, experiment string) string {
+	return FromContext(ctx)[experiment]
+}
+
+// Assignments places a request in a variant of every experiment it has the
+// unit ID for. Assignments already in the context, made by the service the
+// request came through, are kept.
+func (r *Registry) Assignments(ctx context.Context, units Units) Assignments {
+	assignments := make(Assignments)
+	for experiment, variant := range FromContext(ctx) {
+		assignments[experiment] = variant
+	}
+	if r == nil {
+		return assignments
+	}
+
+	for name, experiment := range r.experiments {
+		if _, assigned := assignments[name]; assigned {
+			continue
+		}
+		if id := units.id(experiment.Unit); id != "" {
+			assignments[name] = experiment.Assign(id).Name
+		}
+	}
+	return assignments
+}
+
+// Assign returns a context carrying the request's assignments
+func (r *Registry) Assign(ctx context.Context, units Units) context.Context {
+	return WithAssignments(ctx, r.Assignments(ctx, units))
+}
+
+// Param returns a parameter of the variant the context is assigned to, or
+// fallback when the request is not in the experiment or its variant does not
+// set the parameter
+func (r *Registry) Param(ctx context.Context, experiment, param string, fallback float64) float64 {
+	exp, exists := r.Get(experiment)
+	if !exists {
+		return fallback
+	}
+	variant, exists := exp.Variant(VariantOf(ctx, experiment))
+	if !exists {
+		return fallback
+	}
+	if value, set := variant.Params[param]; set {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,221 @@
+// Package experiments runs A/B tests of the matching and pricing algorithms.
+// Riders or trips are split between an experiment's variants by hashing their
+// ID, so every service places the same rider or trip in the same variant
+// without coordinating. Assignments travel with the request context and gRPC
+// metadata, services branch on the variant they find there and tag their
+// analytics counters with it so variants can be compared.
+//
+// Experiments are configured in a YAML file shared by the services, keyed by
+// name:
+//
+//	experiments:
+//	  matching_scoring:
+//	    description: Weigh pickup ETA above distance
+//	    unit: rider
+//	    variants:
+//	      - name: control
+//	        weight: 50
+//	      - name: eta_first
+//	        weight: 50
+//	        params: {distance_weight: 20, eta_weight: 50}
+//
+// A variant's params override the defaults of the algorithm it tests; the
+// control variant usually sets none.
+package experiments
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"regexp"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// ErrInvalidExperiment is returned for experiments that cannot be run
+	ErrInvalidExperiment = errors.New("invalid experiment")
+	// ErrUnknownExperiment is returned for experiments that are not configured
+	ErrUnknownExperiment = errors.New("unknown experiment")
+)
+
+// namePattern is what experiment and variant names look like. Names become
+// part of analytics counter names, so they stay short and free of dots.
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+
+// Unit is what an experiment splits between its variants
+type Unit string
+
+const (
+	// UnitRider keeps a rider in the same variant across trips
+	UnitRider Unit = "rider"
+	// UnitTrip places each trip independently
+	UnitTrip Unit = "trip"
+)
+
+// Config is the contents of the experiments file
+type Config struct {
+	Experiments map[string]*Experiment `yaml:"experiments"`
+}
+
+// Experiment splits riders or trips between variants of an algorithm
+type Experiment struct {
+	Name        string     `yaml:"-" json:"name"`
+	Description string     `yaml:"description" json:"description,omitempty"`
+	Unit        Unit       `yaml:"unit" json:"unit"`
+	Variants    []*Variant `yaml:"variants" json:"variants"`
+}
+
+// Variant is one arm of an experiment. Weights are relative to the other
+// variants of the experiment.
+type Variant struct {
+	Name   string             `yaml:"name" json:"name"`
+	Weight int                `yaml:"weight" json:"weight"`
+	Params map[string]float64 `yaml:"params" json:"params,omitempty"`
+}
+
+// Units are the IDs a request can be assigned by
+type Units struct {
+	RiderID string
+	TripID  string
+}
+
+// id returns the ID of the unit the experiment splits by
+func (u Units) id(unit Unit) string {
+	if unit == UnitTrip {
+		return u.TripID
+	}
+	return u.RiderID
+}
+
+func (e *Experiment) validate() error {
+	if !namePattern.MatchString(e.Name) {
+		return fmt.Errorf("%w: name %q must be lowercase letters, digits, '_' or '-'", ErrInvalidExperiment, e.Name)
+	}
+	switch e.Unit {
+	case "":
+		e.Unit = UnitRider
+	case UnitRider, UnitTrip:
+	default:
+		return fmt.Errorf("%w: %s splits by unknown unit %q", ErrInvalidExperiment, e.Name, e.Unit)
+	}
+	if len(e.Variants) < 2 {
+		return fmt.Errorf("%w: %s needs at least two variants", ErrInvalidExperiment, e.Name)
+	}
+
+	seen := make(map[string]bool, len(e.Variants))
+	for _, variant := range e.Variants {
+		if !namePattern.MatchString(variant.Name) {
+			return fmt.Errorf("%w: %s has a variant named %q", ErrInvalidExperiment, e.Name, variant.Name)
+		}
+		if seen[variant.Name] {
+			return fmt.Errorf("%w: %s has two variants named %s", ErrInvalidExperiment, e.Name, variant.Name)
+		}
+		if variant.Weight < 0 {
+			return fmt.Errorf("%w: variant %s of %s has a negative weight", ErrInvalidExperiment, variant.Name, e.Name)
+		}
+		seen[variant.Name] = true
+	}
+	if e.totalWeight() == 0 {
+		return fmt.Errorf("%w: %s has no weighted variants", ErrInvalidExperiment, e.Name)
+	}
+	return nil
+}
+
+func (e *Experiment) totalWeight() int {
+	total := 0
+	for _, variant := range e.Variants {
+		total += variant.Weight
+	}
+	return total
+}
+
+// Assign returns the variant an ID falls in. The experiment name is part of
+// the hash so each experiment splits the IDs differently.
+func (e *Experiment) Assign(id string) *Variant {
+	hash := fnv.New64a()
+	hash.Write([]byte(e.Name + ":" + id))
+	point := int(hash.Sum64() % uint64(e.totalWeight()))
+
+	for _, variant := range e.Variants {
+		if point < variant.Weight {
+			return variant
+		}
+		point -= variant.Weight
+	}
+	return e.Variants[len(e.Variants)-1]
+}
+
+// Variant returns the named variant
+func (e *Experiment) Variant(name string) (*Variant, bool) {
+	for _, variant := range e.Variants {
+		if variant.Name == name {
+			return variant, true
+		}
+	}
+	return nil, false
+}
+
+// Registry holds the configured experiments. A nil Registry runs none.
+type Registry struct {
+	experiments map[string]*Experiment
+}
+
+// NewRegistry validates the configured experiments
+func NewRegistry(config *Config) (*Registry, error) {
+	registry := &Registry{experiments: make(map[string]*Experiment)}
+	if config == nil {
+		return registry, nil
+	}
+	for name, experiment := range config.Experiments {
+		if experiment == nil {
+			return nil, fmt.Errorf("%w: %s is empty", ErrInvalidExperiment, name)
+		}
+		experiment.Name = name
+		if err := experiment.validate(); err != nil {
+			return nil, err
+		}
+		registry.experiments[name] = experiment
+	}
+	return registry, nil
+}
+
+// Load reads the experiments file at path
+func Load(path string) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read experiments file: %w", err)
+	}
+
+	var config Config
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse experiments file %s: %w", path, err)
+	}
+	return NewRegistry(&config)
+}
+
+// Get returns the named experiment
+func (r *Registry) Get(name string) (*Experiment, bool) {
+	if r == nil {
+		return nil, false
+	}
+	experiment, exists := r.experiments[name]
+	return experiment, exists
+}
+
+// List returns every experiment, by name
+func (r *Registry) List() []*Experiment {
+	if r == nil {
+		return []*Experiment{}
+	}
+	experiments := make([]*Experiment, 0, len(r.experiments))
+	for _, experiment := range r.experiments {
+		experiments = append(experiments, experiment)
+	}
+	sort.Slice(experiments, func(i, j int) bool {
+		return experiments[i].Name < experiments[j].Name
+	})
+	return experiments
+}
@@ -0,0 +1,131 @@
+// Package export snapshots service data into partitioned files on object
+// storage for the data warehouse. Exports are incremental: each run picks up
+// the records updated since the previous batch's watermark, so a record that
+// changes again is exported again and consumers keep its latest version.
+package export
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+)
+
+var (
+	// ErrBatchNotFound is returned when no batch has the requested ID
+	ErrBatchNotFound = errors.New("export batch not found")
+	// ErrUnknownDataset is returned when running a dataset that is not registered
+	ErrUnknownDataset = errors.New("unknown export dataset")
+)
+
+// Watermark is the position of the last record a batch exported. Records are
+// exported in (UpdatedAt, ID) order so records updated in the same instant
+// are neither skipped nor exported twice.
+type Watermark struct {
+	UpdatedAt time.Time `json:"updated_at"`
+	ID        string    `json:"id"`
+}
+
+// IsZero reports whether the watermark is before every record
+func (w Watermark) IsZero() bool {
+	return w.UpdatedAt.IsZero() && w.ID == ""
+}
+
+// Before reports whether a record updated at updatedAt with the given ID
+// comes after the watermark. Times are compared to the microsecond, the
+// precision PostgreSQL keeps watermarks at.
+func (w Watermark) Before(updatedAt time.Time, id string) bool {
+	mark, at := w.UpdatedAt.Truncate(time.Microsecond), updatedAt.Truncate(time.Microsecond)
+	if !mark.Equal(at) {
+		return mark.Before(at)
+	}
+	return w.ID < id
+}
+
+// SortRecords sorts records into export order, the order Watermark.Before
+// compares them in
+func SortRecords(records []Record) {
+	sort.Slice(records, func(i, j int) bool {
+		at := Watermark{UpdatedAt: records[i].UpdatedAt, ID: records[i].ID}
+		return at.Before(records[j].UpdatedAt, records[j].ID)
+	})
+}
+
+// Record is one exported row. Values line up with the dataset's columns.
+type Record struct {
+	ID        string
+	UpdatedAt time.Time
+	Values    []string
+}
+
+// Dataset is a table of records that can be read incrementally
+type Dataset interface {
+	// Name names the dataset in file paths and the manifest
+	Name() string
+	// Columns returns the column names of every record
+	Columns() []string
+	// Changes returns up to limit records after the watermark, in
+	// (UpdatedAt, ID) order
+	Changes(ctx context.Context, since Watermark, limit int) ([]Record, error)
+}
+
+// ChangesFunc reads the records after a watermark, in (UpdatedAt, ID) order
+type ChangesFunc func(ctx context.Context, since Watermark, limit int) ([]Record, error)
+
+type dataset struct {
+	name    string
+	columns []string
+	changes ChangesFunc
+}
+
+// NewDataset creates a dataset read by changes
+func NewDataset(name string, columns []string, changes ChangesFunc) Dataset {
+	return &dataset{name: name, columns: columns, changes: changes}
+}
+
+func (d *dataset) Name() string {
+	return d.name
+}
+
+func (d *dataset) Columns() []string {
+	return d.columns
+}
+
+func (d *dataset) Changes(ctx context.Context, since Watermark, limit int) ([]Record, error) {
+	return d.changes(ctx, since, limit)
+}
+
+// Batch is one exported file, as listed in the manifest
+type Batch struct {
+	ID        string    `json:"id"`
+	Dataset   string    `json:"dataset"`
+	Format    Format    `json:"format"`
+	Partition string    `json:"partition"`
+	Key       string    `json:"key"`
+	URL       string    `json:"url"`
+	Rows      int       `json:"rows"`
+	Bytes     int       `json:"bytes"`
+	From      Watermark `json:"from"` // exclusive
+	To        Watermark `json:"to"`   // inclusive, where the next batch starts
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BatchFilter selects batches from the manifest
+type BatchFilter struct {
+	Dataset string
+	Since   time.Time // batches created at or after Since
+	Limit   int
+}
+
+// ManifestStore records the batches that have been exported
+type ManifestStore interface {
+	// SaveBatch adds a batch to the manifest
+	SaveBatch(ctx context.Context, batch *Batch) error
+	// GetBatch returns a batch by ID
+	GetBatch(ctx context.Context, id string) (*Batch, error)
+	// ListBatches returns matching batches, most recent first
+	ListBatches(ctx context.Context, filter BatchFilter) ([]*Batch, error)
+	// LatestWatermark returns where the dataset's next batch starts, the zero
+	// watermark before its first batch
+	LatestWatermark(ctx context.Context, dataset string) (Watermark, error)
+}
@@ -0,0 +1,360 @@
+package export
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	sharedconfig "github.com/rideshare-platform/shared/config"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+const (
+	// DefaultBatchSize is the most records written to one file
+	DefaultBatchSize = 10000
+	// DefaultInterval is how often a started exporter runs
+	DefaultInterval = time.Hour
+)
+
+// Config holds a service's warehouse export settings
+type Config struct {
+	Enabled   bool          `yaml:"enabled" env:"EXPORT_ENABLED" default:"false"`
+	Interval  time.Duration `yaml:"interval" env:"EXPORT_INTERVAL" default:"1h"`
+	Format    string        `yaml:"format" env:"EXPORT_FORMAT" default:"csv.gz"`        // csv, csv.gz or parquet
+	BatchSize int           `yaml:"batch_size" env:"EXPORT_BATCH_SIZE" default:"10000"` // most records per file
+	// Sink is "file" to write under Dir or "s3" to upload to the S3 bucket.
+	// Prefix is prepended to every file's key.
+	Sink   string   `yaml:"sink" env:"EXPORT_SINK" default:"file"`
+	Dir    string   `yaml:"dir" env:"EXPORT_DIR" default:"exports"`
+	Prefix string   `yaml:"prefix" env:"EXPORT_PREFIX"`
+	S3     S3Config `yaml:"s3"`
+	// DatabaseURL keeps the manifest in PostgreSQL. Without it the manifest
+	// is kept in memory and every dataset is exported in full after a restart.
+	DatabaseURL string `yaml:"database_url" env:"EXPORT_DATABASE_URL"`
+}
+
+// LoadConfig reads the export settings from the EXPORT_* environment
+// variables, for services that do not load their config through the shared loader
+func LoadConfig() (Config, error) {
+	var config Config
+	if err := sharedconfig.NewLoader().WithFile("").Load(&config); err != nil {
+		return Config{}, err
+	}
+	return config, nil
+}
+
+// Validate checks that an enabled config names a usable format and sink
+func (c *Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if _, err := ParseFormat(c.Format); err != nil {
+		return err
+	}
+	if c.Interval <= 0 {
+		return fmt.Errorf("EXPORT_INTERVAL must be positive, got %s", c.Interval)
+	}
+	if c.BatchSize < 1 {
+		return fmt.Errorf("EXPORT_BATCH_SIZE must be at least 1, got %d", c.BatchSize)
+	}
+	switch c.Sink {
+	case "file":
+		if c.Dir == "" {
+			return errors.New("EXPORT_DIR is required for the file sink")
+		}
+	case "s3":
+		if c.S3.Bucket == "" || c.S3.AccessKeyID == "" || c.S3.SecretAccessKey == "" {
+			return errors.New("EXPORT_S3_BUCKET, EXPORT_S3_ACCESS_KEY_ID and EXPORT_S3_SECRET_ACCESS_KEY are required for the s3 sink")
+		}
+	default:
+		return fmt.Errorf("unknown EXPORT_SINK %q, use file or s3", c.Sink)
+	}
+	return nil
+}
+
+// Exporter writes registered datasets to a sink in batches and records each
+// batch in the manifest. Runs are serialised, so a slow run is never
+// overlapped by the next one.
+type Exporter struct {
+	sink      Sink
+	manifest  ManifestStore
+	format    Format
+	batchSize int
+	prefix    string
+	logger    *logger.Logger
+	now       func() time.Time
+	db        *sql.DB
+
+	datasets map[string]Dataset
+	mutex    sync.RWMutex
+	running  sync.Mutex
+}
+
+// NewExporter creates an exporter writing gzip-compressed CSV batches of
+// DefaultBatchSize records
+func NewExporter(sink Sink, manifest ManifestStore, logger *logger.Logger) *Exporter {
+	return &Exporter{
+		sink:      sink,
+		manifest:  manifest,
+		format:    FormatCSVGzip,
+		batchSize: DefaultBatchSize,
+		logger:    logger,
+		now:       time.Now,
+		datasets:  make(map[string]Dataset),
+	}
+}
+
+// Open creates an exporter from config. A PostgreSQL manifest needs the
+// postgres driver registered by the caller and is migrated on open.
+func Open(ctx context.Context, config Config, log *logger.Logger) (*Exporter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	format, err := ParseFormat(config.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	var sink Sink = NewFileSink(config.Dir)
+	if config.Sink == "s3" {
+		sink = NewS3Sink(config.S3)
+	}
+
+	var manifest ManifestStore = NewMemoryManifestStore()
+	var db *sql.DB
+	if config.DatabaseURL != "" {
+		db, err = sql.Open("postgres", config.DatabaseURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to export database: %w", err)
+		}
+		if err := db.PingContext(ctx); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to ping export database: %w", err)
+		}
+		if err := MigrateManifest(ctx, db, log); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to migrate export database: %w", err)
+		}
+		manifest = NewPostgresManifestStore(db)
+	}
+
+	exporter := NewExporter(sink, manifest, log)
+	exporter.SetFormat(format)
+	exporter.SetBatchSize(config.BatchSize)
+	exporter.SetPrefix(config.Prefix)
+	exporter.db = db
+	return exporter, nil
+}
+
+// Close releases the manifest database opened by Open
+func (e *Exporter) Close() error {
+	if e.db == nil {
+		return nil
+	}
+	return e.db.Close()
+}
+
+// SetFormat sets the format new batches are written in
+func (e *Exporter) SetFormat(format Format) {
+	e.format = format
+}
+
+// SetBatchSize sets the most records written to one file
+func (e *Exporter) SetBatchSize(size int) {
+	if size > 0 {
+		e.batchSize = size
+	}
+}
+
+// SetPrefix sets the key prefix every file is written under
+func (e *Exporter) SetPrefix(prefix string) {
+	e.prefix = prefix
+}
+
+// Register adds datasets to every run
+func (e *Exporter) Register(datasets ...Dataset) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	for _, dataset := range datasets {
+		e.datasets[dataset.Name()] = dataset
+	}
+}
+
+// DatasetInfo describes a registered dataset and how far it has been exported
+type DatasetInfo struct {
+	Name      string    `json:"name"`
+	Columns   []string  `json:"columns"`
+	Watermark Watermark `json:"watermark"`
+}
+
+// Datasets lists the registered datasets by name
+func (e *Exporter) Datasets(ctx context.Context) ([]*DatasetInfo, error) {
+	var infos []*DatasetInfo
+	for _, dataset := range e.registered() {
+		watermark, err := e.manifest.LatestWatermark(ctx, dataset.Name())
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, &DatasetInfo{Name: dataset.Name(), Columns: dataset.Columns(), Watermark: watermark})
+	}
+	return infos, nil
+}
+
+func (e *Exporter) registered() []Dataset {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	datasets := make([]Dataset, 0, len(e.datasets))
+	for _, dataset := range e.datasets {
+		datasets = append(datasets, dataset)
+	}
+	sort.Slice(datasets, func(i, j int) bool {
+		return datasets[i].Name() < datasets[j].Name()
+	})
+	return datasets
+}
+
+// Run exports the changes to every registered dataset. A dataset that fails
+// does not stop the others; its error is returned with the batches written.
+func (e *Exporter) Run(ctx context.Context) ([]*Batch, error) {
+	e.running.Lock()
+	defer e.running.Unlock()
+
+	var batches []*Batch
+	var errs []error
+	for _, dataset := range e.registered() {
+		written, err := e.export(ctx, dataset)
+		batches = append(batches, written...)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", dataset.Name(), err))
+		}
+	}
+	return batches, errors.Join(errs...)
+}
+
+// RunDataset exports the changes to one dataset
+func (e *Exporter) RunDataset(ctx context.Context, name string) ([]*Batch, error) {
+	e.mutex.RLock()
+	dataset, exists := e.datasets[name]
+	e.mutex.RUnlock()
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownDataset, name)
+	}
+
+	e.running.Lock()
+	defer e.running.Unlock()
+	return e.export(ctx, dataset)
+}
+
+// Start runs the exporter every interval until ctx is cancelled
+func (e *Exporter) Start(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := e.Run(ctx); err != nil && ctx.Err() == nil {
+				e.logger.WithContext(ctx).WithError(err).Error("Warehouse export failed")
+			}
+		}
+	}
+}
+
+// ListBatches returns exported batches from the manifest, most recent first
+func (e *Exporter) ListBatches(ctx context.Context, filter BatchFilter) ([]*Batch, error) {
+	return e.manifest.ListBatches(ctx, filter)
+}
+
+// GetBatch returns an exported batch from the manifest
+func (e *Exporter) GetBatch(ctx context.Context, id string) (*Batch, error) {
+	return e.manifest.GetBatch(ctx, id)
+}
+
+// export writes the dataset's changes since its watermark, one file per
+// batchSize records
+func (e *Exporter) export(ctx context.Context, dataset Dataset) ([]*Batch, error) {
+	since, err := e.manifest.LatestWatermark(ctx, dataset.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	var batches []*Batch
+	for {
+		records, err := dataset.Changes(ctx, since, e.batchSize)
+		if err != nil {
+			return batches, fmt.Errorf("failed to read changes: %w", err)
+		}
+		if len(records) == 0 {
+			return batches, nil
+		}
+		last := records[len(records)-1]
+		if !since.Before(last.UpdatedAt, last.ID) {
+			return batches, fmt.Errorf("changes did not advance past watermark %s/%s", since.UpdatedAt.Format(time.RFC3339Nano), since.ID)
+		}
+
+		batch, err := e.writeBatch(ctx, dataset, since, records)
+		if err != nil {
+			return batches, err
+		}
+		batches = append(batches, batch)
+		since = batch.To
+
+		if len(records) < e.batchSize {
+			return batches, nil
+		}
+	}
+}
+
+// writeBatch uploads one file of records and adds it to the manifest. Files
+// are partitioned by the UTC day they were exported on.
+func (e *Exporter) writeBatch(ctx context.Context, dataset Dataset, since Watermark, records []Record) (*Batch, error) {
+	body, err := e.format.Encode(dataset.Columns(), records)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode batch: %w", err)
+	}
+
+	now := e.now().UTC()
+	last := records[len(records)-1]
+	batch := &Batch{
+		ID:        now.Format("20060102T150405Z") + "-" + uuid.NewString()[:8],
+		Dataset:   dataset.Name(),
+		Format:    e.format,
+		Partition: "dt=" + now.Format(time.DateOnly),
+		Rows:      len(records),
+		Bytes:     len(body),
+		From:      since,
+		To:        Watermark{UpdatedAt: last.UpdatedAt.UTC(), ID: last.ID},
+		CreatedAt: now,
+	}
+	batch.Key = path.Join(e.prefix, batch.Dataset, batch.Partition, batch.Dataset+"-"+batch.ID+e.format.Extension())
+	batch.URL = e.sink.URL(batch.Key)
+
+	if err := e.sink.Put(ctx, batch.Key, e.format.ContentType(), body); err != nil {
+		return nil, err
+	}
+	if err := e.manifest.SaveBatch(ctx, batch); err != nil {
+		// The file stays behind and its records are exported again next run
+		return nil, err
+	}
+
+	e.logger.WithContext(ctx).WithFields(logger.Fields{
+		"dataset":  batch.Dataset,
+		"batch_id": batch.ID,
+		"rows":     batch.Rows,
+		"key":      batch.Key,
+	}).Info("Exported warehouse batch")
+	return batch, nil
+}
@@ -0,0 +1,136 @@
+package export
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/csv"
+	"fmt"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// Format is the file format batches are written in
+type Format string
+
+const (
+	// FormatCSV writes RFC 4180 CSV with a header row
+	FormatCSV Format = "csv"
+	// FormatCSVGzip writes gzip-compressed CSV with a header row
+	FormatCSVGzip Format = "csv.gz"
+	// FormatParquet writes Snappy-compressed Parquet with an optional string
+	// column per dataset column. Empty values are written as nulls.
+	FormatParquet Format = "parquet"
+)
+
+// ParseFormat validates a format name
+func ParseFormat(name string) (Format, error) {
+	switch format := Format(name); format {
+	case FormatCSV, FormatCSVGzip, FormatParquet:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unsupported export format %q, use %s, %s or %s", name, FormatCSV, FormatCSVGzip, FormatParquet)
+	}
+}
+
+// Extension returns the file name extension for the format
+func (f Format) Extension() string {
+	return "." + string(f)
+}
+
+// ContentType returns the MIME type files of the format are uploaded with
+func (f Format) ContentType() string {
+	switch f {
+	case FormatCSVGzip:
+		return "application/gzip"
+	case FormatParquet:
+		return "application/vnd.apache.parquet"
+	default:
+		return "text/csv"
+	}
+}
+
+// Encode writes records in the format. CSV files start with a header row of
+// columns.
+func (f Format) Encode(columns []string, records []Record) ([]byte, error) {
+	if f == FormatParquet {
+		return encodeParquet(columns, records)
+	}
+
+	var buf bytes.Buffer
+	var compressed *gzip.Writer
+	writer := csv.NewWriter(&buf)
+	if f == FormatCSVGzip {
+		compressed = gzip.NewWriter(&buf)
+		writer = csv.NewWriter(compressed)
+	}
+
+	if err := writer.Write(columns); err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		if len(record.Values) != len(columns) {
+			return nil, fmt.Errorf("record %s has %d values for %d columns", record.ID, len(record.Values), len(columns))
+		}
+		if err := writer.Write(record.Values); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	if compressed != nil {
+		if err := compressed.Close(); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// encodeParquet writes records as a Parquet file with an optional string
+// column per column. Parquet orders the columns by name.
+func encodeParquet(columns []string, records []Record) ([]byte, error) {
+	group := make(parquet.Group, len(columns))
+	for _, column := range columns {
+		if _, exists := group[column]; exists {
+			return nil, fmt.Errorf("duplicate column %s", column)
+		}
+		group[column] = parquet.Optional(parquet.String())
+	}
+	schema := parquet.NewSchema("record", group)
+
+	// Where each of the columns' values goes in the schema's column order
+	leafIndex := make(map[string]int, len(columns))
+	for i, path := range schema.Columns() {
+		leafIndex[path[0]] = i
+	}
+	order := make([]int, len(columns))
+	for i, column := range columns {
+		order[i] = leafIndex[column]
+	}
+
+	var buf bytes.Buffer
+	writer := parquet.NewWriter(&buf, schema, parquet.Compression(&parquet.Snappy))
+	rows := make([]parquet.Row, 0, len(records))
+	for _, record := range records {
+		if len(record.Values) != len(columns) {
+			return nil, fmt.Errorf("record %s has %d values for %d columns", record.ID, len(record.Values), len(columns))
+		}
+		row := make(parquet.Row, len(columns))
+		for i, value := range record.Values {
+			if value == "" {
+				row[order[i]] = parquet.NullValue().Level(0, 0, order[i])
+			} else {
+				row[order[i]] = parquet.ValueOf(value).Level(0, 1, order[i])
+			}
+		}
+		rows = append(rows, row)
+	}
+	if _, err := writer.WriteRows(rows); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
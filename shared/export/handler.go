@@ -0,0 +1,141 @@
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Handler serves the export manifest: the datasets a service exports, the
+// batches written so far and on-demand runs
+type Handler struct {
+	exporter     *Exporter
+	requireAdmin func(http.Handler) http.Handler
+}
+
+// NewHandler creates a new manifest handler. The manifest is for operators,
+// so every route is wrapped with requireAdmin.
+func NewHandler(exporter *Exporter, requireAdmin func(http.Handler) http.Handler) *Handler {
+	return &Handler{exporter: exporter, requireAdmin: requireAdmin}
+}
+
+// RegisterRoutes registers the manifest routes
+func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
+	mux.Handle("GET /api/v1/exports/datasets", h.requireAdmin(http.HandlerFunc(h.ListDatasets)))
+	mux.Handle("GET /api/v1/exports/batches", h.requireAdmin(http.HandlerFunc(h.ListBatches)))
+	mux.Handle("GET /api/v1/exports/batches/{id}", h.requireAdmin(http.HandlerFunc(h.GetBatch)))
+	mux.Handle("POST /api/v1/exports/runs", h.requireAdmin(http.HandlerFunc(h.Run)))
+}
+
+// ListDatasets returns the exported datasets with their columns and watermarks
+func (h *Handler) ListDatasets(w http.ResponseWriter, r *http.Request) {
+	datasets, err := h.exporter.Datasets(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal_error", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"datasets": datasets,
+		"count":    len(datasets),
+	})
+}
+
+// ListBatches returns exported batches, most recent first. They can be
+// narrowed to a dataset and to batches created since an RFC3339 time.
+func (h *Handler) ListBatches(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := BatchFilter{Dataset: query.Get("dataset")}
+	if since := query.Get("since"); since != "" {
+		parsed, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_request", fmt.Errorf("since must be an RFC3339 timestamp: %w", err))
+			return
+		}
+		filter.Since = parsed
+	}
+	limit, err := strconv.Atoi(query.Get("limit"))
+	if err != nil || limit <= 0 || limit > 1000 {
+		limit = defaultBatchLimit
+	}
+	filter.Limit = limit
+
+	batches, err := h.exporter.ListBatches(r.Context(), filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal_error", err)
+		return
+	}
+	if batches == nil {
+		batches = []*Batch{}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"batches": batches,
+		"count":   len(batches),
+	})
+}
+
+// GetBatch returns one exported batch
+func (h *Handler) GetBatch(w http.ResponseWriter, r *http.Request) {
+	batch, err := h.exporter.GetBatch(r.Context(), r.PathValue("id"))
+	if errors.Is(err, ErrBatchNotFound) {
+		writeError(w, http.StatusNotFound, "not_found", err)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal_error", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, batch)
+}
+
+// Run exports now instead of waiting for the next scheduled run, every
+// dataset or the one given as {"dataset": "trips"}
+func (h *Handler) Run(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Dataset string `json:"dataset"`
+	}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_request", err)
+			return
+		}
+	}
+
+	var batches []*Batch
+	var err error
+	if req.Dataset != "" {
+		batches, err = h.exporter.RunDataset(r.Context(), req.Dataset)
+	} else {
+		batches, err = h.exporter.Run(r.Context())
+	}
+	if errors.Is(err, ErrUnknownDataset) {
+		writeError(w, http.StatusNotFound, "not_found", err)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "export_failed", err)
+		return
+	}
+	if batches == nil {
+		batches = []*Batch{}
+	}
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"batches": batches,
+		"count":   len(batches),
+	})
+}
+
+func writeError(w http.ResponseWriter, status int, code string, err error) {
+	writeJSON(w, status, map[string]string{
+		"error":   code,
+		"message": err.Error(),
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
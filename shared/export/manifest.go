@@ -0,0 +1,225 @@
+package export
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/shared/database"
+	"github.com/rideshare-platform/shared/export/migrations"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// MigrationService is the name the manifest schema's migrations are recorded under
+const MigrationService = "export"
+
+// defaultBatchLimit caps batch listings that name no limit
+const defaultBatchLimit = 100
+
+// MemoryManifestStore keeps the manifest in memory. Watermarks are lost on
+// restart, so the next run exports every dataset in full again.
+type MemoryManifestStore struct {
+	batches map[string]*Batch
+	mutex   sync.RWMutex
+}
+
+// NewMemoryManifestStore creates an empty in-memory manifest
+func NewMemoryManifestStore() *MemoryManifestStore {
+	return &MemoryManifestStore{batches: make(map[string]*Batch)}
+}
+
+// SaveBatch adds a copy of batch to the manifest
+func (s *MemoryManifestStore) SaveBatch(ctx context.Context, batch *Batch) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	copied := *batch
+	s.batches[batch.ID] = &copied
+	return nil
+}
+
+// GetBatch returns a copy of the batch with the ID
+func (s *MemoryManifestStore) GetBatch(ctx context.Context, id string) (*Batch, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	batch, exists := s.batches[id]
+	if !exists {
+		return nil, ErrBatchNotFound
+	}
+	copied := *batch
+	return &copied, nil
+}
+
+// ListBatches returns matching batches, most recent first
+func (s *MemoryManifestStore) ListBatches(ctx context.Context, filter BatchFilter) ([]*Batch, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var batches []*Batch
+	for _, batch := range s.batches {
+		if filter.Dataset != "" && batch.Dataset != filter.Dataset {
+			continue
+		}
+		if batch.CreatedAt.Before(filter.Since) {
+			continue
+		}
+		copied := *batch
+		batches = append(batches, &copied)
+	}
+	sort.Slice(batches, func(i, j int) bool {
+		if !batches[i].CreatedAt.Equal(batches[j].CreatedAt) {
+			return batches[i].CreatedAt.After(batches[j].CreatedAt)
+		}
+		return batches[i].ID > batches[j].ID
+	})
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultBatchLimit
+	}
+	if len(batches) > limit {
+		batches = batches[:limit]
+	}
+	return batches, nil
+}
+
+// LatestWatermark returns the furthest watermark the dataset has been exported to
+func (s *MemoryManifestStore) LatestWatermark(ctx context.Context, dataset string) (Watermark, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var latest Watermark
+	for _, batch := range s.batches {
+		if batch.Dataset == dataset && latest.Before(batch.To.UpdatedAt, batch.To.ID) {
+			latest = batch.To
+		}
+	}
+	return latest, nil
+}
+
+// MigrateManifest creates or upgrades the export_batches table in db
+func MigrateManifest(ctx context.Context, db *sql.DB, log *logger.Logger) error {
+	schema, err := migrations.Load()
+	if err != nil {
+		return err
+	}
+	_, err = database.NewMigrator(db, MigrationService, schema, log).Up(ctx)
+	return err
+}
+
+// PostgresManifestStore keeps the manifest in the export_batches table
+type PostgresManifestStore struct {
+	db *sql.DB
+}
+
+// NewPostgresManifestStore creates a manifest backed by PostgreSQL
+func NewPostgresManifestStore(db *sql.DB) *PostgresManifestStore {
+	return &PostgresManifestStore{db: db}
+}
+
+const batchColumns = `id, dataset, format, partition_path, object_key, url, row_count, byte_count,
+	from_updated_at, from_id, to_updated_at, to_id, created_at`
+
+// SaveBatch adds a batch to the manifest
+func (s *PostgresManifestStore) SaveBatch(ctx context.Context, batch *Batch) error {
+	query := `INSERT INTO export_batches (` + batchColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`
+
+	var fromUpdatedAt *time.Time
+	if !batch.From.UpdatedAt.IsZero() {
+		fromUpdatedAt = &batch.From.UpdatedAt
+	}
+	_, err := s.db.ExecContext(ctx, query,
+		batch.ID, batch.Dataset, string(batch.Format), batch.Partition, batch.Key, batch.URL,
+		batch.Rows, batch.Bytes, fromUpdatedAt, batch.From.ID, batch.To.UpdatedAt, batch.To.ID, batch.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save export batch: %w", err)
+	}
+	return nil
+}
+
+// GetBatch returns the batch with the ID
+func (s *PostgresManifestStore) GetBatch(ctx context.Context, id string) (*Batch, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+batchColumns+` FROM export_batches WHERE id = $1`, id)
+	batch, err := scanBatch(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrBatchNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get export batch: %w", err)
+	}
+	return batch, nil
+}
+
+// ListBatches returns matching batches, most recent first
+func (s *PostgresManifestStore) ListBatches(ctx context.Context, filter BatchFilter) ([]*Batch, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultBatchLimit
+	}
+	query := `SELECT ` + batchColumns + ` FROM export_batches
+		WHERE ($1 = '' OR dataset = $1) AND created_at >= $2
+		ORDER BY created_at DESC, id DESC LIMIT $3`
+
+	rows, err := s.db.QueryContext(ctx, query, filter.Dataset, filter.Since, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list export batches: %w", err)
+	}
+	defer rows.Close()
+
+	var batches []*Batch
+	for rows.Next() {
+		batch, err := scanBatch(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan export batch: %w", err)
+		}
+		batches = append(batches, batch)
+	}
+	return batches, rows.Err()
+}
+
+// LatestWatermark returns the furthest watermark the dataset has been exported to
+func (s *PostgresManifestStore) LatestWatermark(ctx context.Context, dataset string) (Watermark, error) {
+	query := `SELECT to_updated_at, to_id FROM export_batches
+		WHERE dataset = $1 ORDER BY to_updated_at DESC, to_id DESC LIMIT 1`
+
+	var watermark Watermark
+	err := s.db.QueryRowContext(ctx, query, dataset).Scan(&watermark.UpdatedAt, &watermark.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Watermark{}, nil
+	}
+	if err != nil {
+		return Watermark{}, fmt.Errorf("failed to get export watermark: %w", err)
+	}
+	watermark.UpdatedAt = watermark.UpdatedAt.UTC()
+	return watermark, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanBatch(row rowScanner) (*Batch, error) {
+	var batch Batch
+	var format string
+	var fromUpdatedAt sql.NullTime
+	err := row.Scan(
+		&batch.ID, &batch.Dataset, &format, &batch.Partition, &batch.Key, &batch.URL,
+		&batch.Rows, &batch.Bytes, &fromUpdatedAt, &batch.From.ID, &batch.To.UpdatedAt, &batch.To.ID, &batch.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	batch.Format = Format(format)
+	if fromUpdatedAt.Valid {
+		batch.From.UpdatedAt = fromUpdatedAt.Time.UTC()
+	}
+	batch.To.UpdatedAt = batch.To.UpdatedAt.UTC()
+	return &batch, nil
+}
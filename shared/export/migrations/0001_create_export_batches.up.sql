@@ -0,0 +1,19 @@
+-- One row per exported file. A dataset's next batch starts after the
+-- watermark of its most recent batch.
+CREATE TABLE IF NOT EXISTS export_batches (
+    id VARCHAR(64) PRIMARY KEY,
+    dataset VARCHAR(100) NOT NULL,
+    format VARCHAR(20) NOT NULL,
+    partition_path VARCHAR(100) NOT NULL,
+    object_key TEXT NOT NULL,
+    url TEXT NOT NULL,
+    row_count INTEGER NOT NULL,
+    byte_count BIGINT NOT NULL,
+    from_updated_at TIMESTAMP WITH TIME ZONE,
+    from_id VARCHAR(255) NOT NULL DEFAULT '',
+    to_updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    to_id VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_export_batches_dataset_created_at ON export_batches(dataset, created_at DESC);
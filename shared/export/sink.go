@@ -0,0 +1,118 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rideshare-platform/shared/awssig"
+)
+
+// Sink stores exported files
+type Sink interface {
+	// Put writes body to key, replacing any file already there
+	Put(ctx context.Context, key, contentType string, body []byte) error
+	// URL returns where the file at key can be read from
+	URL(key string) string
+}
+
+// FileSink writes exported files under a local directory, for development
+// and for warehouses that load from a mounted volume
+type FileSink struct {
+	dir string
+}
+
+// NewFileSink creates a sink writing under dir
+func NewFileSink(dir string) *FileSink {
+	return &FileSink{dir: dir}
+}
+
+// Put writes body to the file at key, through a temporary file so readers
+// never see a partial file
+func (s *FileSink) Put(ctx context.Context, key, contentType string, body []byte) error {
+	path := filepath.Join(s.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create export directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, body, 0o644); err != nil {
+		return fmt.Errorf("failed to write export file: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write export file: %w", err)
+	}
+	return nil
+}
+
+// URL returns the file URL of key
+func (s *FileSink) URL(key string) string {
+	path, err := filepath.Abs(filepath.Join(s.dir, filepath.FromSlash(key)))
+	if err != nil {
+		path = filepath.Join(s.dir, filepath.FromSlash(key))
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
+
+// S3Config configures an S3-compatible bucket. Google Cloud Storage is
+// written through its interoperability endpoint with HMAC keys.
+type S3Config struct {
+	Endpoint        string `yaml:"endpoint" env:"EXPORT_S3_ENDPOINT" default:"https://s3.amazonaws.com"`
+	Region          string `yaml:"region" env:"EXPORT_S3_REGION" default:"us-east-1"`
+	Bucket          string `yaml:"bucket" env:"EXPORT_S3_BUCKET"`
+	AccessKeyID     string `yaml:"access_key_id" env:"EXPORT_S3_ACCESS_KEY_ID"`
+	SecretAccessKey string `yaml:"secret_access_key" env:"EXPORT_S3_SECRET_ACCESS_KEY"`
+}
+
+// S3Sink uploads exported files to an S3-compatible bucket, addressing
+// objects path-style and signing requests with AWS Signature Version 4
+type S3Sink struct {
+	config S3Config
+	signer *awssig.Signer
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewS3Sink creates a sink uploading to the configured bucket
+func NewS3Sink(config S3Config) *S3Sink {
+	config.Endpoint = strings.TrimRight(config.Endpoint, "/")
+	return &S3Sink{
+		config: config,
+		signer: awssig.NewS3Signer(config.AccessKeyID, config.SecretAccessKey, config.Region),
+		client: &http.Client{Timeout: 5 * time.Minute},
+		now:    time.Now,
+	}
+}
+
+// Put uploads body as the object at key
+func (s *S3Sink) Put(ctx context.Context, key, contentType string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.URL(key), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create upload request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	s.signer.SignRequest(req, body, s.now())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("failed to upload %s: %s: %s", key, resp.Status, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+// URL returns the path-style URL of the object at key
+func (s *S3Sink) URL(key string) string {
+	return s.config.Endpoint + "/" + awssig.EscapePath(s.config.Bucket+"/"+key)
+}
@@ -0,0 +1,59 @@
+package export
+
+import (
+	"strconv"
+	"time"
+)
+
+// Helpers formatting record values. Missing values are written as empty
+// fields, times as RFC3339 in UTC.
+
+// Time formats a time, empty when zero
+func Time(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// TimePtr formats an optional time
+func TimePtr(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return Time(*t)
+}
+
+// StringPtr formats an optional string
+func StringPtr(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// Float formats a number without trailing zeros
+func Float(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// FloatPtr formats an optional number
+func FloatPtr(f *float64) string {
+	if f == nil {
+		return ""
+	}
+	return Float(*f)
+}
+
+// Int formats an integer
+func Int(i int64) string {
+	return strconv.FormatInt(i, 10)
+}
+
+// IntPtr formats an optional integer
+func IntPtr[T int | int64](i *T) string {
+	if i == nil {
+		return ""
+	}
+	return Int(int64(*i))
+}
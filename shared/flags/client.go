@@ -0,0 +1,120 @@
+package flags
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// Client evaluates flags from an in-memory copy of the store, so checking a
+// flag never waits on Redis. The copy is reloaded when the store announces a
+// change and every poll interval in case an announcement was missed.
+type Client struct {
+	store     Store
+	logger    *logger.Logger
+	mutex     sync.RWMutex
+	flags     map[string]*Flag
+	listeners []func()
+}
+
+// NewClient creates a client on the store. It knows no flags until the
+// first Refresh.
+func NewClient(store Store, log *logger.Logger) *Client {
+	return &Client{store: store, logger: log, flags: make(map[string]*Flag)}
+}
+
+// Enabled reports whether a flag is on for the subject. Unknown flags are off.
+func (c *Client) Enabled(name string, subject Subject) bool {
+	return c.EnabledOr(name, subject, false)
+}
+
+// EnabledOr reports whether a flag is on for the subject, or fallback when
+// the flag does not exist
+func (c *Client) EnabledOr(name string, subject Subject, fallback bool) bool {
+	c.mutex.RLock()
+	flag, ok := c.flags[name]
+	c.mutex.RUnlock()
+	if !ok {
+		return fallback
+	}
+	return flag.EnabledFor(subject)
+}
+
+// OnChange registers a function called after a refresh that changed the flags
+func (c *Client) OnChange(fn func()) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.listeners = append(c.listeners, fn)
+}
+
+// Refresh reloads the flags from the store and reports whether they changed.
+// On error the current flags are kept.
+func (c *Client) Refresh(ctx context.Context) (bool, error) {
+	list, err := c.store.List(ctx)
+	if err != nil {
+		return false, err
+	}
+	flags := make(map[string]*Flag, len(list))
+	for _, flag := range list {
+		flags[flag.Name] = flag
+	}
+
+	c.mutex.Lock()
+	changed := !reflect.DeepEqual(c.flags, flags)
+	c.flags = flags
+	listeners := append([]func(){}, c.listeners...)
+	c.mutex.Unlock()
+
+	if changed {
+		for _, listener := range listeners {
+			listener()
+		}
+	}
+	return changed, nil
+}
+
+// Start keeps the flags up to date until ctx is cancelled, reloading them on
+// every change the store announces and every interval
+func (c *Client) Start(ctx context.Context, interval time.Duration) {
+	var changes <-chan struct{}
+	if notifier, ok := c.store.(Notifier); ok {
+		var err error
+		if changes, err = notifier.Changes(ctx); err != nil && c.logger != nil {
+			c.logger.WithError(err).Warn("Feature flag changes are not announced, polling only")
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	c.refresh(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case _, ok := <-changes:
+			if !ok {
+				changes = nil
+				continue
+			}
+			c.refresh(ctx)
+		case <-ticker.C:
+			c.refresh(ctx)
+		}
+	}
+}
+
+func (c *Client) refresh(ctx context.Context) {
+	changed, err := c.Refresh(ctx)
+	if c.logger == nil {
+		return
+	}
+	if err != nil {
+		c.logger.WithError(err).Warn("Failed to reload feature flags")
+	} else if changed {
+		c.logger.Info("Feature flags reloaded")
+	}
+}
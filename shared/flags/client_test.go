@@ -0,0 +1,65 @@
+package flags
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Refresh(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore()
+	client := NewClient(store, nil)
+	subject := Subject{ID: "rider-1", City: "istanbul"}
+
+	assert.False(t, client.Enabled("batch_matching", subject), "unknown flags are off")
+	assert.True(t, client.EnabledOr("batch_matching", subject, true))
+
+	var changes int
+	client.OnChange(func() { changes++ })
+
+	require.NoError(t, store.Save(ctx, &Flag{Name: "batch_matching", Enabled: true, Rollout: 100}))
+	assert.False(t, client.Enabled("batch_matching", subject), "flags are read from the last refresh")
+	changed, err := client.Refresh(ctx)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.True(t, client.Enabled("batch_matching", subject))
+	assert.True(t, client.EnabledOr("batch_matching", subject, false))
+
+	changed, err = client.Refresh(ctx)
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, 1, changes, "listeners only hear about changes")
+
+	require.NoError(t, store.Delete(ctx, "batch_matching"))
+	changed, err = client.Refresh(ctx)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.False(t, client.Enabled("batch_matching", subject))
+	assert.Equal(t, 2, changes)
+}
+
+func TestClient_StartReloadsOnChange(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewMemoryStore()
+	client := NewClient(store, nil)
+
+	done := make(chan struct{})
+	go func() {
+		client.Start(ctx, time.Hour)
+		close(done)
+	}()
+
+	// The hour-long poll never fires, so the flag arrives by announcement
+	require.NoError(t, store.Save(ctx, &Flag{Name: "surge_v2", Enabled: true, Rollout: 100}))
+	assert.Eventually(t, func() bool {
+		return client.Enabled("surge_v2", Subject{ID: "driver-42"})
+	}, time.Second, 5*time.Millisecond)
+
+	cancel()
+	<-done
+}
@@ -0,0 +1,117 @@
+// Package flags rolls features out gradually across services. A flag is
+// switched on for a percentage of riders or drivers, picked by hashing their
+// ID so each keeps the same answer, and can be overridden per city. Flags are
+// kept in Redis, edited through the admin API and cached by every service,
+// which reloads them as soon as one changes.
+//
+// Unlike the feature_flags of a service's dynamic config, which are plain
+// switches read from its own config file, these flags are shared by all
+// services and target individual riders and drivers.
+package flags
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"time"
+
+	"github.com/rideshare-platform/shared/city"
+)
+
+var (
+	// ErrFlagNotFound is returned for flags that do not exist
+	ErrFlagNotFound = errors.New("feature flag not found")
+	// ErrInvalidFlag is returned for flags that cannot be saved
+	ErrInvalidFlag = errors.New("invalid feature flag")
+)
+
+// namePattern is what flag names look like, such as "batch_matching"
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]{0,63}$`)
+
+// Flag is a feature rolled out to a share of riders or drivers
+type Flag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Enabled switches the flag off everywhere when false, whatever the
+	// rollout and city overrides say
+	Enabled bool `json:"enabled"`
+	// Rollout is the percentage of subjects the flag is on for, 0 to 100
+	Rollout float64 `json:"rollout"`
+	// Cities override the rollout in the cities listed, keyed by city ID
+	Cities    map[string]CityOverride `json:"cities,omitempty"`
+	UpdatedBy string                  `json:"updated_by,omitempty"`
+	UpdatedAt time.Time               `json:"updated_at"`
+}
+
+// CityOverride replaces a flag's rollout in one city
+type CityOverride struct {
+	// Enabled switches the flag off in the city when false
+	Enabled bool    `json:"enabled"`
+	Rollout float64 `json:"rollout"`
+}
+
+// Subject is who a flag is evaluated for: a rider or driver ID and the city
+// they are in
+type Subject struct {
+	ID   string
+	City string
+}
+
+// Validate checks the flag's name and rollouts, and normalizes its city IDs
+func (f *Flag) Validate() error {
+	if !namePattern.MatchString(f.Name) {
+		return fmt.Errorf("%w: name %q must be lowercase letters, digits, '_', '.' or '-'", ErrInvalidFlag, f.Name)
+	}
+	if f.Rollout < 0 || f.Rollout > 100 {
+		return fmt.Errorf("%w: rollout must be between 0 and 100, got %g", ErrInvalidFlag, f.Rollout)
+	}
+
+	cities := make(map[string]CityOverride, len(f.Cities))
+	for id, override := range f.Cities {
+		cityID := city.Normalize(id)
+		if cityID == "" {
+			return fmt.Errorf("%w: city override without a city ID", ErrInvalidFlag)
+		}
+		if override.Rollout < 0 || override.Rollout > 100 {
+			return fmt.Errorf("%w: rollout in %s must be between 0 and 100, got %g", ErrInvalidFlag, cityID, override.Rollout)
+		}
+		cities[cityID] = override
+	}
+	if len(cities) > 0 {
+		f.Cities = cities
+	}
+	return nil
+}
+
+// EnabledFor reports whether the flag is on for the subject. Partial
+// rollouts need a subject ID to place it in or out.
+func (f *Flag) EnabledFor(subject Subject) bool {
+	if !f.Enabled {
+		return false
+	}
+
+	rollout := f.Rollout
+	if override, ok := f.Cities[city.Normalize(subject.City)]; ok {
+		if !override.Enabled {
+			return false
+		}
+		rollout = override.Rollout
+	}
+
+	switch {
+	case rollout >= 100:
+		return true
+	case rollout <= 0 || subject.ID == "":
+		return false
+	}
+	return Bucket(f.Name, subject.ID) < rollout
+}
+
+// Bucket places an ID between 0 and 100 for a flag. The flag name is part of
+// the hash so each flag's rollout reaches a different set of IDs.
+func Bucket(flag, id string) float64 {
+	hash := fnv.New32a()
+	hash.Write([]byte(flag + ":" + id))
+	return float64(hash.Sum32()%10000) / 100
+}
@@ -0,0 +1,121 @@
+package flags
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBucket_IsStable(t *testing.T) {
+	// Changing the hash would move riders and drivers in and out of every
+	// rollout, so the buckets are pinned
+	tests := []struct {
+		flag string
+		id   string
+		want float64
+	}{
+		{"batch_matching", "rider-1", 74.95},
+		{"batch_matching", "rider-2", 51.14},
+		{"surge_v2", "driver-42", 35.19},
+		{"surge_v2", "rider-1", 98.98},
+	}
+	for _, tt := range tests {
+		t.Run(tt.flag+"/"+tt.id, func(t *testing.T) {
+			assert.Equal(t, tt.want, Bucket(tt.flag, tt.id))
+			assert.Equal(t, Bucket(tt.flag, tt.id), Bucket(tt.flag, tt.id))
+		})
+	}
+}
+
+func TestBucket_SpreadsIDs(t *testing.T) {
+	inRollout := 0
+	for i := 0; i < 10000; i++ {
+		bucket := Bucket("batch_matching", fmt.Sprintf("rider-%d", i))
+		require.True(t, bucket >= 0 && bucket < 100, "bucket %g is out of range", bucket)
+		if bucket < 25 {
+			inRollout++
+		}
+	}
+	assert.InDelta(t, 2500, inRollout, 200, "a 25%% rollout reaches about a quarter of riders")
+}
+
+func TestFlag_EnabledFor(t *testing.T) {
+	rider := Subject{ID: "rider-1", City: "Istanbul"} // bucket 74.95 for batch_matching
+
+	tests := []struct {
+		name    string
+		flag    Flag
+		subject Subject
+		want    bool
+	}{
+		{"full rollout", Flag{Enabled: true, Rollout: 100}, rider, true},
+		{"full rollout without a subject ID", Flag{Enabled: true, Rollout: 100}, Subject{}, true},
+		{"zero rollout", Flag{Enabled: true, Rollout: 0}, rider, false},
+		{"bucket inside the rollout", Flag{Enabled: true, Rollout: 75}, rider, true},
+		{"bucket on the rollout edge", Flag{Enabled: true, Rollout: 74.95}, rider, false},
+		{"partial rollout without a subject ID", Flag{Enabled: true, Rollout: 99.99}, Subject{City: "istanbul"}, false},
+		{"kill switch beats full rollout", Flag{Enabled: false, Rollout: 100}, rider, false},
+		{
+			name:    "city override raises the rollout",
+			flag:    Flag{Enabled: true, Rollout: 0, Cities: map[string]CityOverride{"istanbul": {Enabled: true, Rollout: 100}}},
+			subject: rider,
+			want:    true,
+		},
+		{
+			name:    "city override lowers the rollout",
+			flag:    Flag{Enabled: true, Rollout: 100, Cities: map[string]CityOverride{"istanbul": {Enabled: true, Rollout: 50}}},
+			subject: rider,
+			want:    false,
+		},
+		{
+			name:    "city switched off beats the default",
+			flag:    Flag{Enabled: true, Rollout: 100, Cities: map[string]CityOverride{"istanbul": {Enabled: false, Rollout: 100}}},
+			subject: rider,
+			want:    false,
+		},
+		{
+			name:    "kill switch beats the city override",
+			flag:    Flag{Enabled: false, Rollout: 0, Cities: map[string]CityOverride{"istanbul": {Enabled: true, Rollout: 100}}},
+			subject: rider,
+			want:    false,
+		},
+		{
+			name:    "other cities use the default",
+			flag:    Flag{Enabled: true, Rollout: 100, Cities: map[string]CityOverride{"ankara": {Enabled: false}}},
+			subject: rider,
+			want:    true,
+		},
+		{
+			name:    "no city uses the default",
+			flag:    Flag{Enabled: true, Rollout: 0, Cities: map[string]CityOverride{"istanbul": {Enabled: true, Rollout: 100}}},
+			subject: Subject{ID: "rider-1"},
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.flag.Name = "batch_matching"
+			assert.Equal(t, tt.want, tt.flag.EnabledFor(tt.subject))
+		})
+	}
+}
+
+func TestFlag_Validate(t *testing.T) {
+	flag := &Flag{Name: "batch_matching", Rollout: 10, Cities: map[string]CityOverride{" Istanbul ": {Enabled: true, Rollout: 50}}}
+	require.NoError(t, flag.Validate())
+	assert.Equal(t, map[string]CityOverride{"istanbul": {Enabled: true, Rollout: 50}}, flag.Cities)
+
+	invalid := []*Flag{
+		{Name: "Batch Matching"},
+		{Name: ""},
+		{Name: "batch_matching", Rollout: -1},
+		{Name: "batch_matching", Rollout: 100.5},
+		{Name: "batch_matching", Cities: map[string]CityOverride{" ": {Enabled: true}}},
+		{Name: "batch_matching", Cities: map[string]CityOverride{"istanbul": {Rollout: 101}}},
+	}
+	for _, flag := range invalid {
+		assert.ErrorIs(t, flag.Validate(), ErrInvalidFlag, "%+v", flag)
+	}
+}
@@ -0,0 +1,240 @@
+package flags
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// redisKey is the hash holding every flag, keyed by name
+	redisKey = "feature_flags"
+	// redisChannel is published to whenever a flag changes
+	redisChannel = "feature_flags:changed"
+)
+
+// Store keeps the flags
+type Store interface {
+	// List returns every flag, ordered by name
+	List(ctx context.Context) ([]*Flag, error)
+	// Get returns a flag, or ErrFlagNotFound
+	Get(ctx context.Context, name string) (*Flag, error)
+	// Save creates or replaces a flag
+	Save(ctx context.Context, flag *Flag) error
+	// Delete removes a flag, or returns ErrFlagNotFound
+	Delete(ctx context.Context, name string) error
+}
+
+// Notifier is a store that announces changes, so clients reload at once
+// rather than at their next poll
+type Notifier interface {
+	// Changes receives a value whenever a flag is saved or deleted, until
+	// ctx is cancelled
+	Changes(ctx context.Context) (<-chan struct{}, error)
+}
+
+// RedisStore keeps the flags in a Redis hash and publishes every change
+type RedisStore struct {
+	client redis.UniversalClient
+}
+
+// NewRedisStore creates a store on the given Redis
+func NewRedisStore(client redis.UniversalClient) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+// List returns every flag, ordered by name
+func (s *RedisStore) List(ctx context.Context) ([]*Flag, error) {
+	values, err := s.client.HGetAll(ctx, redisKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feature flags: %w", err)
+	}
+
+	flags := make([]*Flag, 0, len(values))
+	for name, value := range values {
+		var flag Flag
+		if err := json.Unmarshal([]byte(value), &flag); err != nil {
+			return nil, fmt.Errorf("failed to decode feature flag %s: %w", name, err)
+		}
+		flags = append(flags, &flag)
+	}
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Name < flags[j].Name })
+	return flags, nil
+}
+
+// Get returns a flag
+func (s *RedisStore) Get(ctx context.Context, name string) (*Flag, error) {
+	value, err := s.client.HGet(ctx, redisKey, name).Bytes()
+	if err == redis.Nil {
+		return nil, ErrFlagNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feature flag: %w", err)
+	}
+
+	var flag Flag
+	if err := json.Unmarshal(value, &flag); err != nil {
+		return nil, fmt.Errorf("failed to decode feature flag %s: %w", name, err)
+	}
+	return &flag, nil
+}
+
+// Save creates or replaces a flag and announces the change
+func (s *RedisStore) Save(ctx context.Context, flag *Flag) error {
+	data, err := json.Marshal(flag)
+	if err != nil {
+		return err
+	}
+
+	pipe := s.client.TxPipeline()
+	pipe.HSet(ctx, redisKey, flag.Name, data)
+	pipe.Publish(ctx, redisChannel, flag.Name)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to save feature flag: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a flag and announces the change
+func (s *RedisStore) Delete(ctx context.Context, name string) error {
+	pipe := s.client.TxPipeline()
+	deleted := pipe.HDel(ctx, redisKey, name)
+	pipe.Publish(ctx, redisChannel, name)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete feature flag: %w", err)
+	}
+	if deleted.Val() == 0 {
+		return ErrFlagNotFound
+	}
+	return nil
+}
+
+// Changes subscribes to the change channel
+func (s *RedisStore) Changes(ctx context.Context) (<-chan struct{}, error) {
+	pubsub := s.client.Subscribe(ctx, redisChannel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, fmt.Errorf("failed to subscribe to feature flag changes: %w", err)
+	}
+
+	changes := make(chan struct{}, 1)
+	go func() {
+		defer pubsub.Close()
+		defer close(changes)
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-messages:
+				if !ok {
+					return
+				}
+				notify(changes)
+			}
+		}
+	}()
+	return changes, nil
+}
+
+// MemoryStore keeps flags in memory, for tests and single-instance setups
+type MemoryStore struct {
+	mutex       sync.RWMutex
+	flags       map[string]*Flag
+	subscribers []chan struct{}
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{flags: make(map[string]*Flag)}
+}
+
+// List returns every flag, ordered by name
+func (s *MemoryStore) List(ctx context.Context) ([]*Flag, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	flags := make([]*Flag, 0, len(s.flags))
+	for _, flag := range s.flags {
+		copied := *flag
+		flags = append(flags, &copied)
+	}
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Name < flags[j].Name })
+	return flags, nil
+}
+
+// Get returns a copy of a flag
+func (s *MemoryStore) Get(ctx context.Context, name string) (*Flag, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	flag, ok := s.flags[name]
+	if !ok {
+		return nil, ErrFlagNotFound
+	}
+	copied := *flag
+	return &copied, nil
+}
+
+// Save stores a copy of a flag
+func (s *MemoryStore) Save(ctx context.Context, flag *Flag) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	copied := *flag
+	s.flags[flag.Name] = &copied
+	s.notifyLocked()
+	return nil
+}
+
+// Delete removes a flag
+func (s *MemoryStore) Delete(ctx context.Context, name string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.flags[name]; !ok {
+		return ErrFlagNotFound
+	}
+	delete(s.flags, name)
+	s.notifyLocked()
+	return nil
+}
+
+// Changes receives a value after every save and delete
+func (s *MemoryStore) Changes(ctx context.Context) (<-chan struct{}, error) {
+	changes := make(chan struct{}, 1)
+	s.mutex.Lock()
+	s.subscribers = append(s.subscribers, changes)
+	s.mutex.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		for i, subscriber := range s.subscribers {
+			if subscriber == changes {
+				s.subscribers = append(s.subscribers[:i], s.subscribers[i+1:]...)
+				break
+			}
+		}
+	}()
+	return changes, nil
+}
+
+func (s *MemoryStore) notifyLocked() {
+	for _, subscriber := range s.subscribers {
+		notify(subscriber)
+	}
+}
+
+// notify signals a change without blocking; a pending signal already covers it
+func notify(changes chan struct{}) {
+	select {
+	case changes <- struct{}{}:
+	default:
+	}
+}
@@ -121,6 +121,7 @@ func (c *Client) Health(ctx context.Context) error {
 func unaryClientInterceptor(log *logger.Logger) grpc.UnaryClientInterceptor {
 	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
 		start := time.Now()
+		ctx = OutgoingContext(ctx)
 
 		// Call the method
 		err := invoker(ctx, method, req, reply, cc, opts...)
@@ -139,6 +140,7 @@ func unaryClientInterceptor(log *logger.Logger) grpc.UnaryClientInterceptor {
 func streamClientInterceptor(log *logger.Logger) grpc.StreamClientInterceptor {
 	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
 		start := time.Now()
+		ctx = OutgoingContext(ctx)
 
 		// Call the method
 		stream, err := streamer(ctx, desc, cc, method, opts...)
@@ -0,0 +1,131 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/rideshare-platform/shared/experiments"
+	"github.com/rideshare-platform/shared/i18n"
+	"github.com/rideshare-platform/shared/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Metadata keys used to carry request context between services
+const (
+	RequestIDMetadataKey     = "x-request-id"
+	CorrelationIDMetadataKey = "x-correlation-id"
+	UserIDMetadataKey        = "x-user-id"
+	// ExperimentsMetadataKey carries the request's experiment assignments so
+	// every service branches on the same variants
+	ExperimentsMetadataKey = "x-experiments"
+	// LocaleMetadataKey carries the locale the caller answers its user in
+	LocaleMetadataKey = "x-locale"
+)
+
+// correlationKeys maps the logger's context keys to their metadata keys
+var correlationKeys = []struct {
+	contextKey  logger.ContextKey
+	metadataKey string
+}{
+	{logger.RequestIDKey, RequestIDMetadataKey},
+	{logger.CorrelationIDKey, CorrelationIDMetadataKey},
+	{logger.UserIDKey, UserIDMetadataKey},
+}
+
+// OutgoingContext copies the request, correlation and user IDs, the
+// experiment assignments and the locale from the context into outgoing gRPC
+// metadata
+func OutgoingContext(ctx context.Context) context.Context {
+	var pairs []string
+	for _, key := range correlationKeys {
+		if value, ok := ctx.Value(key.contextKey).(string); ok && value != "" {
+			pairs = append(pairs, key.metadataKey, value)
+		}
+	}
+	if assignments := experiments.FromContext(ctx); len(assignments) > 0 {
+		pairs = append(pairs, ExperimentsMetadataKey, assignments.String())
+	}
+	if locale := i18n.FromContext(ctx); locale != "" {
+		pairs = append(pairs, LocaleMetadataKey, locale)
+	}
+	if len(pairs) == 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, pairs...)
+}
+
+// IncomingContext copies the request, correlation and user IDs sent by the
+// caller into the context so the handler's log lines carry them, and restores
+// the caller's experiment assignments and locale
+func IncomingContext(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	for _, key := range correlationKeys {
+		if values := md.Get(key.metadataKey); len(values) > 0 && values[0] != "" {
+			ctx = context.WithValue(ctx, key.contextKey, values[0])
+		}
+	}
+	if values := md.Get(ExperimentsMetadataKey); len(values) > 0 {
+		ctx = experiments.WithAssignments(ctx, experiments.ParseAssignments(values[0]))
+	}
+	if values := md.Get(LocaleMetadataKey); len(values) > 0 && values[0] != "" {
+		ctx = i18n.WithLocale(ctx, i18n.Resolve(values[0]))
+	}
+	return ctx
+}
+
+// CorrelationUnaryClientInterceptor propagates request context on unary calls
+func CorrelationUnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(OutgoingContext(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// CorrelationStreamClientInterceptor propagates request context on streams
+func CorrelationStreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(OutgoingContext(ctx), desc, cc, method, opts...)
+	}
+}
+
+// CorrelationUnaryServerInterceptor restores the caller's request context on unary calls
+func CorrelationUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(IncomingContext(ctx), req)
+	}
+}
+
+// CorrelationStreamServerInterceptor restores the caller's request context on streams
+func CorrelationStreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &correlatedStream{ServerStream: stream, ctx: IncomingContext(stream.Context())})
+	}
+}
+
+// correlatedStream overrides a server stream's context
+type correlatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *correlatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// ClientOptions returns the dial options that propagate request context
+func ClientOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(CorrelationUnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(CorrelationStreamClientInterceptor()),
+	}
+}
+
+// ServerOptions returns the server options that restore request context
+func ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(CorrelationUnaryServerInterceptor()),
+		grpc.ChainStreamInterceptor(CorrelationStreamServerInterceptor()),
+	}
+}
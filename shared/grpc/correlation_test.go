@@ -0,0 +1,141 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/rideshare-platform/shared/experiments"
+	"github.com/rideshare-platform/shared/i18n"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// requestContext returns a context carrying the IDs an HTTP request was
+// assigned by the request logger
+func requestContext() context.Context {
+	ctx := context.WithValue(context.Background(), logger.RequestIDKey, "req-1")
+	ctx = context.WithValue(ctx, logger.CorrelationIDKey, "corr-1")
+	ctx = context.WithValue(ctx, logger.UserIDKey, "rider-1")
+	ctx = experiments.WithAssignments(ctx, experiments.Assignments{"surge_cap": "treatment"})
+	return i18n.WithLocale(ctx, "fr")
+}
+
+// serverContext hands what a client sent to the server as incoming metadata
+func serverContext(t *testing.T, clientCtx context.Context) context.Context {
+	md, ok := metadata.FromOutgoingContext(clientCtx)
+	require.True(t, ok, "no outgoing metadata")
+	return metadata.NewIncomingContext(context.Background(), md)
+}
+
+func TestOutgoingContext(t *testing.T) {
+	md, ok := metadata.FromOutgoingContext(OutgoingContext(requestContext()))
+	require.True(t, ok)
+	assert.Equal(t, []string{"req-1"}, md.Get(RequestIDMetadataKey))
+	assert.Equal(t, []string{"corr-1"}, md.Get(CorrelationIDMetadataKey))
+	assert.Equal(t, []string{"rider-1"}, md.Get(UserIDMetadataKey))
+	assert.Equal(t, []string{"surge_cap=treatment"}, md.Get(ExperimentsMetadataKey))
+	assert.Equal(t, []string{"fr"}, md.Get(LocaleMetadataKey))
+
+	// Nothing is sent for a context without request values
+	ctx := context.Background()
+	assert.Equal(t, ctx, OutgoingContext(ctx))
+}
+
+func TestIncomingContext(t *testing.T) {
+	ctx := IncomingContext(serverContext(t, OutgoingContext(requestContext())))
+	assert.Equal(t, "req-1", ctx.Value(logger.RequestIDKey))
+	assert.Equal(t, "corr-1", ctx.Value(logger.CorrelationIDKey))
+	assert.Equal(t, "rider-1", ctx.Value(logger.UserIDKey))
+	assert.Equal(t, "treatment", experiments.VariantOf(ctx, "surge_cap"))
+	assert.Equal(t, "fr", i18n.FromContext(ctx))
+
+	// Calls made without metadata, such as health checks, are left alone
+	bare := context.Background()
+	assert.Equal(t, bare, IncomingContext(bare))
+	assert.Nil(t, IncomingContext(metadata.NewIncomingContext(bare, metadata.Pairs(CorrelationIDMetadataKey, ""))).Value(logger.CorrelationIDKey))
+}
+
+func TestCorrelationUnaryClientInterceptor(t *testing.T) {
+	var sent metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		sent, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+
+	err := CorrelationUnaryClientInterceptor()(requestContext(), "/trip.TripService/GetTrip", nil, nil, nil, invoker)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"req-1"}, sent.Get(RequestIDMetadataKey))
+	assert.Equal(t, []string{"corr-1"}, sent.Get(CorrelationIDMetadataKey))
+}
+
+func TestCorrelationStreamClientInterceptor(t *testing.T) {
+	var sent metadata.MD
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		sent, _ = metadata.FromOutgoingContext(ctx)
+		return nil, nil
+	}
+
+	_, err := CorrelationStreamClientInterceptor()(requestContext(), &grpc.StreamDesc{ServerStreams: true}, nil, "/geo.GeoService/WatchDrivers", streamer)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"corr-1"}, sent.Get(CorrelationIDMetadataKey))
+}
+
+func TestCorrelationUnaryServerInterceptor_LogsWithTheCallersIDs(t *testing.T) {
+	var buf bytes.Buffer
+	log := logger.NewLogger("info", "test")
+	log.SetOutput(&buf)
+	log.SetFormatter(&logrus.JSONFormatter{})
+
+	// The client's metadata reaches the server as incoming metadata
+	var clientCtx context.Context
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		clientCtx = ctx
+		return nil
+	}
+	require.NoError(t, CorrelationUnaryClientInterceptor()(requestContext(), "/trip.TripService/GetTrip", nil, nil, nil, invoker))
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		log.WithContext(ctx).Info("loading trip")
+		return "trip-1", nil
+	}
+	resp, err := CorrelationUnaryServerInterceptor()(serverContext(t, clientCtx), nil, &grpc.UnaryServerInfo{FullMethod: "/trip.TripService/GetTrip"}, handler)
+	require.NoError(t, err)
+	assert.Equal(t, "trip-1", resp)
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry), "log output: %s", buf.String())
+	assert.Equal(t, "req-1", entry["request_id"])
+	assert.Equal(t, "corr-1", entry["correlation_id"])
+	assert.Equal(t, "rider-1", entry["user_id"])
+}
+
+// testServerStream is a server stream with only a context
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestCorrelationStreamServerInterceptor(t *testing.T) {
+	stream := &testServerStream{ctx: serverContext(t, OutgoingContext(requestContext()))}
+
+	var handled context.Context
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		handled = stream.Context()
+		return nil
+	}
+	require.NoError(t, CorrelationStreamServerInterceptor()(nil, stream, &grpc.StreamServerInfo{FullMethod: "/geo.GeoService/WatchDrivers"}, handler))
+	assert.Equal(t, "req-1", handled.Value(logger.RequestIDKey))
+	assert.Equal(t, "corr-1", handled.Value(logger.CorrelationIDKey))
+	assert.Equal(t, "rider-1", handled.Value(logger.UserIDKey))
+}
@@ -0,0 +1,147 @@
+package interceptor
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/rideshare-platform/shared/middleware"
+)
+
+// AuthorizationMetadataKey carries the caller's bearer token
+const AuthorizationMetadataKey = "authorization"
+
+// AdminUserType is the user type carried by operator tokens
+const AdminUserType = "admin"
+
+// TokenValidator checks a bearer token and returns its claims.
+// middleware.AuthMiddleware validates the platform's JWTs.
+type TokenValidator interface {
+	ParseToken(token string) (*middleware.AuthClaims, error)
+}
+
+// AuthConfig controls bearer token validation
+type AuthConfig struct {
+	Validator TokenValidator
+	// Required rejects calls without a token. Otherwise tokens are only
+	// checked when the caller sends one, so internal calls keep working.
+	Required bool
+	// PublicMethods are full method names, or service prefixes ending in
+	// "/", that are never checked. Health and reflection are always public.
+	PublicMethods []string
+}
+
+// JWTAuth returns the auth config validating the platform's JWTs signed with
+// secret, or nil when no secret is configured
+func JWTAuth(secret string, required bool) *AuthConfig {
+	if secret == "" {
+		return nil
+	}
+	return &AuthConfig{
+		Validator: middleware.NewAuthMiddleware(secret, nil),
+		Required:  required,
+	}
+}
+
+// claimsKey stores the validated claims in the call's context
+type claimsKey struct{}
+
+// ClaimsFromContext returns the claims of the token the call was made with,
+// if it carried a valid one
+func ClaimsFromContext(ctx context.Context) (*middleware.AuthClaims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*middleware.AuthClaims)
+	return claims, ok
+}
+
+// RequireAdmin rejects calls that did not carry a valid operator token with
+// PermissionDenied. Handlers of operations-only methods call it first, since
+// tokens are optional unless GRPC_AUTH_REQUIRED is set.
+func RequireAdmin(ctx context.Context) error {
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok || claims.UserType != AdminUserType {
+		return status.Error(codes.PermissionDenied, "admin token required")
+	}
+	return nil
+}
+
+// AuthUnaryServerInterceptor validates the caller's bearer token
+func AuthUnaryServerInterceptor(config AuthConfig) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticate(ctx, config, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// AuthStreamServerInterceptor validates the caller's bearer token when the stream opens
+func AuthStreamServerInterceptor(config AuthConfig) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(stream.Context(), config, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// authenticate validates the token in the call's metadata and records who
+// made the call in the context
+func authenticate(ctx context.Context, config AuthConfig, fullMethod string) (context.Context, error) {
+	if isInfrastructureMethod(fullMethod) || isPublicMethod(config.PublicMethods, fullMethod) {
+		return ctx, nil
+	}
+
+	token, present := bearerToken(ctx)
+	if !present {
+		if config.Required {
+			return nil, status.Error(codes.Unauthenticated, "authorization token required")
+		}
+		return ctx, nil
+	}
+	if token == "" {
+		return nil, status.Error(codes.Unauthenticated, "invalid authorization metadata format")
+	}
+
+	claims, err := config.Validator.ParseToken(token)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "invalid authorization token: %v", err)
+	}
+
+	ctx = middleware.SetPrincipal(ctx, claims.UserID)
+	return context.WithValue(ctx, claimsKey{}, claims), nil
+}
+
+// bearerToken returns the token from "authorization: Bearer <token>"
+// metadata. present is true whenever authorization metadata was sent; the
+// token is empty if it is not a bearer token.
+func bearerToken(ctx context.Context) (token string, present bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+	values := md.Get(AuthorizationMetadataKey)
+	if len(values) == 0 {
+		return "", false
+	}
+
+	scheme, token, found := strings.Cut(values[0], " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", true
+	}
+	return strings.TrimSpace(token), true
+}
+
+func isPublicMethod(public []string, fullMethod string) bool {
+	for _, method := range public {
+		if method == fullMethod || (strings.HasSuffix(method, "/") && strings.HasPrefix(fullMethod, method)) {
+			return true
+		}
+	}
+	return false
+}
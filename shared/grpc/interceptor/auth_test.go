@@ -0,0 +1,136 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/rideshare-platform/shared/middleware"
+)
+
+const testSecret = "test-secret"
+
+func newToken(t *testing.T, secret, userID, userType string) string {
+	t.Helper()
+	token, err := middleware.NewAuthMiddleware(secret, nil).GenerateToken(userID, userType, userID+"@example.com", 1)
+	require.NoError(t, err)
+	return token
+}
+
+// withAuthorization returns an incoming call context carrying the
+// authorization metadata, or none when it is empty
+func withAuthorization(authorization string) context.Context {
+	if authorization == "" {
+		return context.Background()
+	}
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(AuthorizationMetadataKey, authorization))
+}
+
+// fakeStream is a server stream that only has a context
+type fakeStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestAuthUnaryServerInterceptor(t *testing.T) {
+	riderToken := newToken(t, testSecret, "rider-1", "rider")
+
+	tests := []struct {
+		name          string
+		required      bool
+		method        string
+		authorization string
+		wantCode      codes.Code
+		wantUserID    string
+	}{
+		{"valid token", true, "/trip.TripService/GetTrip", "Bearer " + riderToken, codes.OK, "rider-1"},
+		{"scheme is case insensitive", true, "/trip.TripService/GetTrip", "bearer " + riderToken, codes.OK, "rider-1"},
+		{"required token missing", true, "/trip.TripService/GetTrip", "", codes.Unauthenticated, ""},
+		{"optional token missing", false, "/trip.TripService/GetTrip", "", codes.OK, ""},
+		{"optional token still checked", false, "/trip.TripService/GetTrip", "Bearer not-a-token", codes.Unauthenticated, ""},
+		{"bad scheme", true, "/trip.TripService/GetTrip", "Basic " + riderToken, codes.Unauthenticated, ""},
+		{"no scheme", true, "/trip.TripService/GetTrip", riderToken, codes.Unauthenticated, ""},
+		{"token signed with another secret", true, "/trip.TripService/GetTrip", "Bearer " + newToken(t, "other-secret", "rider-1", "rider"), codes.Unauthenticated, ""},
+		{"public method", true, "/trip.TripService/GetTripByShareToken", "", codes.OK, ""},
+		{"public service prefix", true, "/trip.PublicService/Anything", "Bearer not-a-token", codes.OK, ""},
+		{"health is always public", true, "/grpc.health.v1.Health/Check", "", codes.OK, ""},
+		{"reflection is always public", true, "/grpc.reflection.v1.ServerReflection/ServerReflectionInfo", "", codes.OK, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := JWTAuth(testSecret, tt.required)
+			config.PublicMethods = []string{"/trip.TripService/GetTripByShareToken", "/trip.PublicService/"}
+
+			var userID string
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				if claims, ok := ClaimsFromContext(ctx); ok {
+					userID = claims.UserID
+				}
+				return "ok", nil
+			}
+			_, err := AuthUnaryServerInterceptor(*config)(withAuthorization(tt.authorization), nil,
+				&grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.wantUserID, userID)
+		})
+	}
+}
+
+func TestAuthStreamServerInterceptor(t *testing.T) {
+	interceptor := AuthStreamServerInterceptor(*JWTAuth(testSecret, true))
+	info := &grpc.StreamServerInfo{FullMethod: "/trip.TripService/WatchTrip"}
+
+	var userID string
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		claims, ok := ClaimsFromContext(stream.Context())
+		require.True(t, ok)
+		userID = claims.UserID
+		return nil
+	}
+	stream := &fakeStream{ctx: withAuthorization("Bearer " + newToken(t, testSecret, "driver-1", "driver"))}
+	require.NoError(t, interceptor(nil, stream, info, handler))
+	assert.Equal(t, "driver-1", userID)
+
+	err := interceptor(nil, &fakeStream{ctx: context.Background()}, info, handler)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestJWTAuth(t *testing.T) {
+	assert.Nil(t, JWTAuth("", true), "no secret disables auth")
+	config := JWTAuth(testSecret, true)
+	require.NotNil(t, config)
+	assert.True(t, config.Required)
+}
+
+func TestRequireAdmin(t *testing.T) {
+	tests := []struct {
+		name          string
+		authorization string
+		wantCode      codes.Code
+	}{
+		{"admin token", "Bearer " + newToken(t, testSecret, "ops-1", AdminUserType), codes.OK},
+		{"rider token", "Bearer " + newToken(t, testSecret, "rider-1", "rider"), codes.PermissionDenied},
+		{"no token", "", codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, RequireAdmin(ctx)
+			}
+			_, err := AuthUnaryServerInterceptor(*JWTAuth(testSecret, false))(withAuthorization(tt.authorization), nil,
+				&grpc.UnaryServerInfo{FullMethod: "/trip.TripService/ReplayDeadLetter"}, handler)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+		})
+	}
+}
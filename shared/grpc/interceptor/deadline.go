@@ -0,0 +1,80 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DeadlineConfig bounds how long a call may run
+type DeadlineConfig struct {
+	// Default is applied to unary calls that arrive without a deadline. Zero
+	// leaves them without one.
+	Default time.Duration
+	// Max shortens longer deadlines set by callers. Zero allows any deadline.
+	Max time.Duration
+}
+
+// DefaultDeadlineConfig returns the default deadline settings
+func DefaultDeadlineConfig() DeadlineConfig {
+	return DeadlineConfig{
+		Default: 10 * time.Second,
+		Max:     60 * time.Second,
+	}
+}
+
+// DeadlineUnaryServerInterceptor gives every unary call a bounded deadline
+// and rejects calls whose deadline has already passed
+func DeadlineUnaryServerInterceptor(config DeadlineConfig) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, cancel, err := boundDeadline(ctx, config.Default, config.Max)
+		if err != nil {
+			return nil, err
+		}
+		defer cancel()
+		return handler(ctx, req)
+	}
+}
+
+// DeadlineStreamServerInterceptor shortens stream deadlines beyond the
+// maximum. Streams without a deadline are long-lived subscriptions and are
+// left open.
+func DeadlineStreamServerInterceptor(config DeadlineConfig) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if _, ok := stream.Context().Deadline(); !ok {
+			return handler(srv, stream)
+		}
+
+		ctx, cancel, err := boundDeadline(stream.Context(), 0, config.Max)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+		return handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// boundDeadline applies the default deadline when the caller set none and
+// caps the deadline at maxTimeout from now
+func boundDeadline(ctx context.Context, defaultTimeout, maxTimeout time.Duration) (context.Context, context.CancelFunc, error) {
+	now := time.Now()
+	deadline, ok := ctx.Deadline()
+	if ok && !now.Before(deadline) {
+		return nil, nil, status.Error(codes.DeadlineExceeded, "deadline exceeded before the call started")
+	}
+	if !ok {
+		if defaultTimeout <= 0 {
+			return ctx, func() {}, nil
+		}
+		deadline = now.Add(defaultTimeout)
+	}
+	if limit := now.Add(maxTimeout); maxTimeout > 0 && deadline.After(limit) {
+		deadline = limit
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	return ctx, cancel, nil
+}
@@ -0,0 +1,81 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDeadlineUnaryServerInterceptor(t *testing.T) {
+	config := DeadlineConfig{Default: 10 * time.Second, Max: time.Minute}
+
+	tests := []struct {
+		name         string
+		callerBudget time.Duration // 0 sends no deadline
+		config       DeadlineConfig
+		wantCode     codes.Code
+		wantBudget   time.Duration // 0 expects no deadline
+	}{
+		{"no deadline gets the default", 0, config, codes.OK, 10 * time.Second},
+		{"short deadline is kept", 2 * time.Second, config, codes.OK, 2 * time.Second},
+		{"long deadline is capped", time.Hour, config, codes.OK, time.Minute},
+		{"expired deadline is rejected", -time.Second, config, codes.DeadlineExceeded, 0},
+		{"no default leaves no deadline", 0, DeadlineConfig{Max: time.Minute}, codes.OK, 0},
+		{"no max allows any deadline", time.Hour, DeadlineConfig{Default: time.Second}, codes.OK, time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.callerBudget != 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.callerBudget)
+				defer cancel()
+			}
+
+			called := false
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				deadline, ok := ctx.Deadline()
+				if tt.wantBudget == 0 {
+					assert.False(t, ok)
+				} else if assert.True(t, ok) {
+					assert.WithinDuration(t, time.Now().Add(tt.wantBudget), deadline, time.Second)
+				}
+				return nil, nil
+			}
+			_, err := DeadlineUnaryServerInterceptor(tt.config)(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/trip.TripService/GetTrip"}, handler)
+
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.wantCode == codes.OK, called)
+		})
+	}
+}
+
+func TestDeadlineStreamServerInterceptor(t *testing.T) {
+	interceptor := DeadlineStreamServerInterceptor(DeadlineConfig{Default: 10 * time.Second, Max: time.Minute})
+	info := &grpc.StreamServerInfo{FullMethod: "/trip.TripService/WatchTrip"}
+
+	// Subscriptions without a deadline stay open
+	err := interceptor(nil, &fakeStream{ctx: context.Background()}, info, func(srv interface{}, stream grpc.ServerStream) error {
+		_, ok := stream.Context().Deadline()
+		assert.False(t, ok)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	err = interceptor(nil, &fakeStream{ctx: ctx}, info, func(srv interface{}, stream grpc.ServerStream) error {
+		deadline, ok := stream.Context().Deadline()
+		if assert.True(t, ok) {
+			assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,82 @@
+// Package interceptor provides the unary and stream server interceptors every
+// gRPC service installs: correlation IDs, request logging, metrics, panic
+// recovery, deadline enforcement and auth token validation.
+package interceptor
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+
+	sharedgrpc "github.com/rideshare-platform/shared/grpc"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/monitoring"
+)
+
+// Config selects the interceptors a server installs
+type Config struct {
+	Service string
+	Logger  *logger.Logger
+	// Metrics records request counts and latencies when set
+	Metrics *monitoring.MetricsCollector
+	// Auth validates bearer tokens when set
+	Auth     *AuthConfig
+	Deadline DeadlineConfig
+}
+
+// ServerOptions returns the server options installing the interceptor suite.
+// Calls pass through correlation, logging, metrics, recovery, deadline and
+// auth in that order, so a recovered panic or a rejected token is still
+// logged and counted.
+func ServerOptions(config Config) []grpc.ServerOption {
+	unary := []grpc.UnaryServerInterceptor{
+		sharedgrpc.CorrelationUnaryServerInterceptor(),
+		LoggingUnaryServerInterceptor(config.Logger),
+	}
+	stream := []grpc.StreamServerInterceptor{
+		sharedgrpc.CorrelationStreamServerInterceptor(),
+		LoggingStreamServerInterceptor(config.Logger),
+	}
+
+	if config.Metrics != nil {
+		unary = append(unary, config.Metrics.UnaryServerInterceptor(config.Service))
+		stream = append(stream, config.Metrics.StreamServerInterceptor(config.Service))
+	}
+
+	unary = append(unary,
+		RecoveryUnaryServerInterceptor(config.Logger),
+		DeadlineUnaryServerInterceptor(config.Deadline),
+	)
+	stream = append(stream,
+		RecoveryStreamServerInterceptor(config.Logger),
+		DeadlineStreamServerInterceptor(config.Deadline),
+	)
+
+	if config.Auth != nil {
+		unary = append(unary, AuthUnaryServerInterceptor(*config.Auth))
+		stream = append(stream, AuthStreamServerInterceptor(*config.Auth))
+	}
+
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	}
+}
+
+// isInfrastructureMethod reports whether a method belongs to the health or
+// reflection services, which are probed constantly and never authenticated
+func isInfrastructureMethod(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, "/grpc.health.v1.Health/") ||
+		strings.HasPrefix(fullMethod, "/grpc.reflection.")
+}
+
+// contextStream overrides a server stream's context
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,39 @@
+package interceptor
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// LoggingUnaryServerInterceptor logs every call with its duration, error and
+// the caller's correlation IDs. Health and reflection calls are not logged.
+func LoggingUnaryServerInterceptor(log *logger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if isInfrastructureMethod(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		log.LogGRPCRequest(ctx, info.FullMethod, time.Since(start), err)
+		return resp, err
+	}
+}
+
+// LoggingStreamServerInterceptor logs every stream once it ends
+func LoggingStreamServerInterceptor(log *logger.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if isInfrastructureMethod(info.FullMethod) {
+			return handler(srv, stream)
+		}
+
+		start := time.Now()
+		err := handler(srv, stream)
+		log.LogGRPCRequest(stream.Context(), info.FullMethod, time.Since(start), err)
+		return err
+	}
+}
@@ -0,0 +1,48 @@
+package interceptor
+
+import (
+	"context"
+	"runtime/debug"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// RecoveryUnaryServerInterceptor turns a panicking handler into an Internal
+// error instead of crashing the server
+func RecoveryUnaryServerInterceptor(log *logger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = recoveredError(ctx, log, info.FullMethod, recovered)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// RecoveryStreamServerInterceptor turns a panicking stream handler into an
+// Internal error instead of crashing the server
+func RecoveryStreamServerInterceptor(log *logger.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = recoveredError(stream.Context(), log, info.FullMethod, recovered)
+			}
+		}()
+		return handler(srv, stream)
+	}
+}
+
+func recoveredError(ctx context.Context, log *logger.Logger, method string, recovered interface{}) error {
+	log.WithContext(ctx).WithFields(logger.Fields{
+		"method":      method,
+		"panic_value": recovered,
+		"stack":       string(debug.Stack()),
+	}).Error("Panic recovered")
+
+	return status.Error(codes.Internal, "internal server error")
+}
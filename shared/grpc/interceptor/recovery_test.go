@@ -0,0 +1,52 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/rideshare-platform/shared/logger"
+)
+
+func TestRecoveryUnaryServerInterceptor(t *testing.T) {
+	interceptor := RecoveryUnaryServerInterceptor(logger.NewLogger("error", "test"))
+	info := &grpc.UnaryServerInfo{FullMethod: "/trip.TripService/GetTrip"}
+
+	tests := []struct {
+		name     string
+		handler  grpc.UnaryHandler
+		wantCode codes.Code
+		wantResp interface{}
+	}{
+		{"result passes through", func(ctx context.Context, req interface{}) (interface{}, error) { return "trip", nil }, codes.OK, "trip"},
+		{"error passes through", func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, status.Error(codes.NotFound, "trip not found")
+		}, codes.NotFound, nil},
+		{"panic becomes internal", func(ctx context.Context, req interface{}) (interface{}, error) { panic("nil map") }, codes.Internal, nil},
+		{"panic with an error becomes internal", func(ctx context.Context, req interface{}) (interface{}, error) {
+			panic(errors.New("boom"))
+		}, codes.Internal, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := interceptor(context.Background(), nil, info, tt.handler)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.wantResp, resp)
+			if tt.wantCode == codes.Internal {
+				assert.Equal(t, "internal server error", status.Convert(err).Message(), "the panic value is not leaked")
+			}
+		})
+	}
+}
+
+func TestRecoveryStreamServerInterceptor(t *testing.T) {
+	interceptor := RecoveryStreamServerInterceptor(logger.NewLogger("error", "test"))
+	err := interceptor(nil, &fakeStream{ctx: context.Background()}, &grpc.StreamServerInfo{FullMethod: "/trip.TripService/WatchTrip"},
+		func(srv interface{}, stream grpc.ServerStream) error { panic("nil map") })
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
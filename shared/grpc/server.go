@@ -122,6 +122,7 @@ func (s *Server) ForceStop() {
 func unaryServerInterceptor(log *logger.Logger) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 		start := time.Now()
+		ctx = IncomingContext(ctx)
 
 		// Call the handler
 		resp, err := handler(ctx, req)
@@ -140,6 +141,7 @@ func unaryServerInterceptor(log *logger.Logger) grpc.UnaryServerInterceptor {
 func streamServerInterceptor(log *logger.Logger) grpc.StreamServerInterceptor {
 	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
 		start := time.Now()
+		stream = &correlatedStream{ServerStream: stream, ctx: IncomingContext(stream.Context())}
 
 		// Call the handler
 		err := handler(srv, stream)
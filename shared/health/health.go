@@ -0,0 +1,190 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// DefaultProbeTimeout bounds how long a single dependency probe may take
+const DefaultProbeTimeout = 2 * time.Second
+
+// Status is the health of a service or one of its dependencies
+type Status string
+
+const (
+	// StatusHealthy means the service and all its dependencies are working
+	StatusHealthy Status = "healthy"
+	// StatusDegraded means an optional dependency is failing
+	StatusDegraded Status = "degraded"
+	// StatusUnhealthy means a required dependency is failing
+	StatusUnhealthy Status = "unhealthy"
+)
+
+// Probe checks a single dependency, returning an error when it is unusable
+type Probe func(ctx context.Context) error
+
+// DependencyStatus is the outcome of probing one dependency
+type DependencyStatus struct {
+	Status    Status `json:"status"`
+	Required  bool   `json:"required"`
+	LatencyMs int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Report is the health document a service returns from its health endpoint
+type Report struct {
+	Service      string                      `json:"service"`
+	Status       Status                      `json:"status"`
+	Version      string                      `json:"version,omitempty"`
+	Timestamp    time.Time                   `json:"timestamp"`
+	Dependencies map[string]DependencyStatus `json:"dependencies"`
+	Info         *Info                       `json:"info,omitempty"`
+}
+
+// dependency is a registered probe
+type dependency struct {
+	name     string
+	required bool
+	probe    Probe
+}
+
+// Checker probes a service's dependencies and reports its overall health
+type Checker struct {
+	service           string
+	version           string
+	configFingerprint string
+	timeout           time.Duration
+	dependencies      []dependency
+	mutex             sync.RWMutex
+}
+
+// NewChecker creates a health checker for a service
+func NewChecker(service, version string) *Checker {
+	return &Checker{
+		service: service,
+		version: version,
+		timeout: DefaultProbeTimeout,
+	}
+}
+
+// SetTimeout changes how long each probe may take
+func (c *Checker) SetTimeout(timeout time.Duration) {
+	if timeout > 0 {
+		c.timeout = timeout
+	}
+}
+
+// AddCheck registers a required dependency. The service is unhealthy while it fails.
+func (c *Checker) AddCheck(name string, probe Probe) {
+	c.add(dependency{name: name, required: true, probe: probe})
+}
+
+// AddOptionalCheck registers a dependency the service can run without. The
+// service is degraded while it fails.
+func (c *Checker) AddOptionalCheck(name string, probe Probe) {
+	c.add(dependency{name: name, required: false, probe: probe})
+}
+
+func (c *Checker) add(dep dependency) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.dependencies = append(c.dependencies, dep)
+}
+
+// Check probes every dependency concurrently and builds the health report
+func (c *Checker) Check(ctx context.Context) *Report {
+	c.mutex.RLock()
+	dependencies := append([]dependency(nil), c.dependencies...)
+	c.mutex.RUnlock()
+
+	info := c.Info()
+	report := &Report{
+		Service:      c.service,
+		Status:       StatusHealthy,
+		Version:      info.Version,
+		Timestamp:    time.Now().UTC(),
+		Dependencies: make(map[string]DependencyStatus, len(dependencies)),
+		Info:         info,
+	}
+
+	results := make([]DependencyStatus, len(dependencies))
+	var wg sync.WaitGroup
+	for i, dep := range dependencies {
+		wg.Add(1)
+		go func(i int, dep dependency) {
+			defer wg.Done()
+			results[i] = c.probe(ctx, dep)
+		}(i, dep)
+	}
+	wg.Wait()
+
+	for i, dep := range dependencies {
+		result := results[i]
+		report.Dependencies[dep.name] = result
+		if result.Status == StatusHealthy {
+			continue
+		}
+		if dep.required {
+			report.Status = StatusUnhealthy
+		} else if report.Status == StatusHealthy {
+			report.Status = StatusDegraded
+		}
+	}
+	return report
+}
+
+func (c *Checker) probe(ctx context.Context, dep dependency) DependencyStatus {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := dep.probe(ctx)
+	result := DependencyStatus{
+		Status:    StatusHealthy,
+		Required:  dep.required,
+		LatencyMs: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		result.Status = StatusUnhealthy
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// Handler serves the health report as JSON. Unhealthy services answer 503 so
+// load balancers and orchestrators stop routing to them.
+func (c *Checker) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := c.Check(r.Context())
+
+		status := http.StatusOK
+		if report.Status == StatusUnhealthy {
+			status = http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(report)
+	})
+}
+
+// GRPCProbe checks a downstream service through the standard gRPC health service
+func GRPCProbe(conn grpc.ClientConnInterface) Probe {
+	client := healthpb.NewHealthClient(conn)
+	return func(ctx context.Context) error {
+		resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{})
+		if err != nil {
+			return err
+		}
+		if resp.Status != healthpb.HealthCheckResponse_SERVING {
+			return fmt.Errorf("service is %s", resp.Status)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,116 @@
+package health
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"time"
+)
+
+// Build metadata stamped in at link time, for example
+//
+//	go build -ldflags "-X github.com/rideshare-platform/shared/health.Version=1.4.0 \
+//	  -X github.com/rideshare-platform/shared/health.GitCommit=$(git rev-parse HEAD) \
+//	  -X github.com/rideshare-platform/shared/health.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// Binaries built from a git checkout without them report the commit and
+// time recorded by the Go toolchain. Version defaults to the version the
+// service passes its checker.
+var (
+	Version   string
+	GitCommit string
+	BuildTime string
+)
+
+// startedAt is when the service process started
+var startedAt = time.Now().UTC()
+
+// Info describes the build and configuration a service instance runs, so
+// instances left on an old build or configuration stand out
+type Info struct {
+	Service           string    `json:"service"`
+	Version           string    `json:"version"`
+	GitCommit         string    `json:"git_commit"`
+	Modified          bool      `json:"modified,omitempty"`
+	BuildTime         string    `json:"build_time,omitempty"`
+	GoVersion         string    `json:"go_version"`
+	StartedAt         time.Time `json:"started_at"`
+	UptimeSeconds     int64     `json:"uptime_seconds"`
+	ConfigFingerprint string    `json:"config_fingerprint,omitempty"`
+}
+
+// NewInfo returns the running build's metadata for a service
+func NewInfo(service, version, configFingerprint string) *Info {
+	info := &Info{
+		Service:           service,
+		Version:           version,
+		GitCommit:         GitCommit,
+		BuildTime:         BuildTime,
+		GoVersion:         runtime.Version(),
+		StartedAt:         startedAt,
+		UptimeSeconds:     int64(time.Since(startedAt).Seconds()),
+		ConfigFingerprint: configFingerprint,
+	}
+	if Version != "" {
+		info.Version = Version
+	}
+
+	if build, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range build.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if info.GitCommit == "" {
+					info.GitCommit = setting.Value
+				}
+			case "vcs.time":
+				if info.BuildTime == "" {
+					info.BuildTime = setting.Value
+				}
+			case "vcs.modified":
+				info.Modified = setting.Value == "true"
+			}
+		}
+	}
+	if info.GitCommit == "" {
+		info.GitCommit = "unknown"
+	}
+	return info
+}
+
+// Fingerprint returns a short hash of a service's configuration, so
+// instances running different configurations can be told apart without
+// exposing the configuration
+func Fingerprint(config interface{}) string {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// SetConfig records the fingerprint of the configuration the service was
+// started with
+func (c *Checker) SetConfig(config interface{}) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.configFingerprint = Fingerprint(config)
+}
+
+// Info returns the service's build metadata
+func (c *Checker) Info() *Info {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return NewInfo(c.service, c.version, c.configFingerprint)
+}
+
+// InfoHandler serves the service's build metadata as JSON
+func (c *Checker) InfoHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(c.Info())
+	})
+}
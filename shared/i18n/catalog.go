@@ -0,0 +1,79 @@
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Catalogs are JSON objects of message keys to messages, one per language,
+// named after the language: locales/fr.json holds French. Messages are
+// fmt formats unless the key's consumer says otherwise; notification
+// templates, for one, are text/template strings.
+//
+//go:embed locales/*.json
+var catalogFiles embed.FS
+
+// catalogs holds every language's messages, by language
+var catalogs = loadCatalogs()
+
+func loadCatalogs() map[string]map[string]string {
+	entries, err := catalogFiles.ReadDir("locales")
+	if err != nil {
+		panic(fmt.Sprintf("failed to read message catalogs: %v", err))
+	}
+
+	loaded := make(map[string]map[string]string, len(entries))
+	for _, entry := range entries {
+		data, err := catalogFiles.ReadFile(path.Join("locales", entry.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("failed to read message catalog %s: %v", entry.Name(), err))
+		}
+		messages := make(map[string]string)
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Sprintf("failed to decode message catalog %s: %v", entry.Name(), err))
+		}
+		loaded[strings.TrimSuffix(entry.Name(), ".json")] = messages
+	}
+	if _, ok := loaded[DefaultLocale]; !ok {
+		panic("no message catalog for the default locale")
+	}
+	return loaded
+}
+
+// Supported returns the languages with a catalog, in alphabetical order
+func Supported() []string {
+	languages := make([]string, 0, len(catalogs))
+	for language := range catalogs {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// Lookup returns the message for key in the locale's own language, without
+// falling back to the default locale's
+func Lookup(locale, key string) (string, bool) {
+	message, ok := catalogs[Language(locale)][key]
+	return message, ok
+}
+
+// T returns the message for key in the locale's language, formatted with
+// args. Keys missing from the language's catalog fall back to the default
+// locale's message, and then to the key itself.
+func T(locale, key string, args ...interface{}) string {
+	message, ok := Lookup(locale, key)
+	if !ok {
+		message, ok = catalogs[DefaultLocale][key]
+	}
+	if !ok {
+		message = key
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
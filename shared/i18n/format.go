@@ -0,0 +1,120 @@
+package i18n
+
+import (
+	"strings"
+	"time"
+
+	"github.com/rideshare-platform/shared/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// dateLayouts are the date orders of each language, overridden by region.
+// Other locales get ISO 8601 dates.
+var dateLayouts = map[string]string{
+	"en": "02/01/2006", "en-US": "01/02/2006", "en-CA": "2006-01-02",
+	"es": "02/01/2006", "fr": "02/01/2006", "fr-CA": "2006-01-02",
+	"de": "02.01.2006", "pt": "02/01/2006",
+}
+
+// timeLayouts are the clock formats of locales not using a 24-hour clock
+var timeLayouts = map[string]string{
+	"en-US": "3:04 PM", "en-CA": "3:04 PM",
+}
+
+// symbolAfter lists the locales writing currency symbols after the amount,
+// by language and then by region
+var symbolAfter = map[string]bool{
+	"es": true, "es-MX": false, "fr": true, "de": true, "pt": true, "pt-BR": false,
+}
+
+// Formatter writes numbers, amounts and dates the way a locale does
+type Formatter struct {
+	locale     string
+	printer    *message.Printer
+	dateLayout string
+	timeLayout string
+	symbolLast bool
+}
+
+// NewFormatter creates a formatter for locale, falling back to the default
+// locale's conventions for locales that cannot be parsed
+func NewFormatter(locale string) *Formatter {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		tag = language.MustParse(DefaultLocale)
+	}
+	base := baseOf(tag)
+	regional := base
+	if region, confidence := tag.Region(); confidence == language.Exact {
+		regional = base + "-" + region.String()
+	}
+
+	return &Formatter{
+		locale:     tag.String(),
+		printer:    message.NewPrinter(tag),
+		dateLayout: byLocale(dateLayouts, regional, base, "2006-01-02"),
+		timeLayout: byLocale(timeLayouts, regional, base, "15:04"),
+		symbolLast: byLocale(symbolAfter, regional, base, false),
+	}
+}
+
+// byLocale returns the regional value, else the language's, else fallback
+func byLocale[T any](values map[string]T, regional, base string, fallback T) T {
+	if value, ok := values[regional]; ok {
+		return value
+	}
+	if value, ok := values[base]; ok {
+		return value
+	}
+	return fallback
+}
+
+// Locale returns the locale the formatter writes for
+func (f *Formatter) Locale() string {
+	return f.locale
+}
+
+// Number writes a number with the locale's separators and the given number
+// of decimals
+func (f *Formatter) Number(value float64, decimals int) string {
+	return f.printer.Sprint(number.Decimal(value, number.Scale(decimals)))
+}
+
+// Percent writes a rate such as 0.2 as a percentage, with up to two decimals
+func (f *Formatter) Percent(rate float64) string {
+	return f.printer.Sprint(number.Percent(rate, number.MaxFractionDigits(2)))
+}
+
+// Amount writes an amount with its currency's decimals and symbol, e.g.
+// $1,234.50 in English and 1.234,50 € in German. Unsupported currencies are
+// written with two decimals and their code.
+func (f *Formatter) Amount(amount float64, code string) string {
+	if code == "" {
+		return f.Number(amount, 2)
+	}
+	symbol, decimals := strings.ToUpper(code)+" ", 2
+	if c, err := currency.Lookup(code); err == nil {
+		symbol, decimals = c.Symbol, c.MinorUnits
+	}
+
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	if f.symbolLast {
+		return sign + f.Number(amount, decimals) + " " + strings.TrimSpace(symbol)
+	}
+	return sign + symbol + f.Number(amount, decimals)
+}
+
+// Date writes the day of t
+func (f *Formatter) Date(t time.Time) string {
+	return t.Format(f.dateLayout)
+}
+
+// DateTime writes the day and time of t with its time zone
+func (f *Formatter) DateTime(t time.Time) string {
+	return t.Format(f.dateLayout + " " + f.timeLayout + " MST")
+}
@@ -0,0 +1,89 @@
+// Package i18n localizes the strings users read: it negotiates a locale from
+// a request's Accept-Language header or a user's profile, looks messages up
+// in per-language catalogs and formats amounts and dates the way the locale
+// writes them.
+//
+// Locales are BCP 47 tags such as "fr" or "pt-BR". Messages are translated
+// by language; the region only changes how numbers and dates are written.
+package i18n
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"golang.org/x/text/language"
+)
+
+// DefaultLocale is used for users and requests that name no supported locale
+const DefaultLocale = "en"
+
+// ErrUnsupportedLocale is returned for locales in a language without a catalog
+var ErrUnsupportedLocale = errors.New("unsupported locale")
+
+// Normalize returns the canonical form of a locale in a supported language,
+// e.g. "pt-BR" for "pt_br"
+func Normalize(locale string) (string, error) {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedLocale, locale)
+	}
+	if _, ok := catalogs[baseOf(tag)]; !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedLocale, locale)
+	}
+	return tag.String(), nil
+}
+
+// Negotiate picks the locale to answer a request in from its Accept-Language
+// header: the most preferred locale in a supported language, or the default
+func Negotiate(acceptLanguage string) string {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil {
+		return DefaultLocale
+	}
+	for _, tag := range tags {
+		if _, ok := catalogs[baseOf(tag)]; ok {
+			return tag.String()
+		}
+	}
+	return DefaultLocale
+}
+
+// Resolve returns the supported form of locale, or the default when it is
+// empty or in an unsupported language
+func Resolve(locale string) string {
+	if normalized, err := Normalize(locale); err == nil {
+		return normalized
+	}
+	return DefaultLocale
+}
+
+// baseOf returns the language of a tag, e.g. "pt" for "pt-BR"
+func baseOf(tag language.Tag) string {
+	base, _ := tag.Base()
+	return base.String()
+}
+
+// Language returns the language of a locale, e.g. "pt" for "pt-BR", and the
+// default locale's for locales that cannot be parsed
+func Language(locale string) string {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return DefaultLocale
+	}
+	return baseOf(tag)
+}
+
+// localeKey stores the request's locale in its context
+type localeKey struct{}
+
+// WithLocale returns a context carrying the locale a request is answered in
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// FromContext returns the locale of the request, empty if none was negotiated
+func FromContext(ctx context.Context) string {
+	locale, _ := ctx.Value(localeKey{}).(string)
+	return locale
+}
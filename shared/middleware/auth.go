@@ -1,7 +1,8 @@
 package middleware
 
 import (
-	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -12,11 +13,22 @@ import (
 	"github.com/rideshare-platform/shared/logger"
 )
 
+var (
+	// ErrInvalidToken is returned for tokens that are malformed or wrongly signed
+	ErrInvalidToken = errors.New("invalid token")
+	// ErrInvalidTokenClaims is returned for signed tokens whose claims are not valid
+	ErrInvalidTokenClaims = errors.New("invalid token claims")
+	// ErrTokenExpired is returned for tokens past their expiry
+	ErrTokenExpired = errors.New("token expired")
+)
+
 // AuthClaims represents JWT claims
 type AuthClaims struct {
 	UserID   string `json:"user_id"`
-	UserType string `json:"user_type"` // "rider" or "driver"
+	UserType string `json:"user_type"` // "rider", "driver" or "admin"
 	Email    string `json:"email"`
+	// Roles grant admin users their permissions in the operations API
+	Roles []string `json:"roles,omitempty"`
 	jwt.StandardClaims
 }
 
@@ -34,9 +46,16 @@ func NewAuthMiddleware(jwtSecret string, log *logger.Logger) *AuthMiddleware {
 	}
 }
 
-// JWTAuth validates JWT tokens
+// JWTAuth validates JWT tokens. Without a JWT secret no token can be
+// trusted, so every request is refused.
 func (a *AuthMiddleware) JWTAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if len(a.jwtSecret) == 0 {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Authentication is not configured"})
+			c.Abort()
+			return
+		}
+
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
 			a.logger.WithContext(c.Request.Context()).Warn("Missing authorization header")
@@ -54,41 +73,26 @@ func (a *AuthMiddleware) JWTAuth() gin.HandlerFunc {
 			return
 		}
 
-		tokenString := tokenParts[1]
-
-		// Parse and validate token
-		token, err := jwt.ParseWithClaims(tokenString, &AuthClaims{}, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-			}
-			return a.jwtSecret, nil
-		})
-
+		claims, err := a.ParseToken(tokenParts[1])
 		if err != nil {
-			a.logger.WithContext(c.Request.Context()).WithError(err).Warn("Invalid JWT token")
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
-			c.Abort()
-			return
-		}
-
-		claims, ok := token.Claims.(*AuthClaims)
-		if !ok || !token.Valid {
-			a.logger.WithContext(c.Request.Context()).Warn("Invalid JWT claims")
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
-			c.Abort()
-			return
-		}
-
-		// Check token expiration
-		if claims.ExpiresAt < time.Now().Unix() {
-			a.logger.WithContext(c.Request.Context()).Warn("JWT token expired")
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Token expired"})
+			entry := a.logger.WithContext(c.Request.Context())
+			switch {
+			case errors.Is(err, ErrTokenExpired):
+				entry.Warn("JWT token expired")
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Token expired"})
+			case errors.Is(err, ErrInvalidTokenClaims):
+				entry.Warn("Invalid JWT claims")
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
+			default:
+				entry.WithError(err).Warn("Invalid JWT token")
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+			}
 			c.Abort()
 			return
 		}
 
 		// Add user info to context
-		ctx := context.WithValue(c.Request.Context(), logger.UserIDKey, claims.UserID)
+		ctx := SetPrincipal(c.Request.Context(), claims.UserID)
 		c.Request = c.Request.WithContext(ctx)
 
 		// Set user info in Gin context
@@ -146,35 +150,14 @@ func (a *AuthMiddleware) OptionalAuth() gin.HandlerFunc {
 			return
 		}
 
-		tokenString := tokenParts[1]
-
-		// Parse and validate token
-		token, err := jwt.ParseWithClaims(tokenString, &AuthClaims{}, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-			}
-			return a.jwtSecret, nil
-		})
-
+		claims, err := a.ParseToken(tokenParts[1])
 		if err != nil {
 			c.Next()
 			return
 		}
 
-		claims, ok := token.Claims.(*AuthClaims)
-		if !ok || !token.Valid {
-			c.Next()
-			return
-		}
-
-		// Check token expiration
-		if claims.ExpiresAt < time.Now().Unix() {
-			c.Next()
-			return
-		}
-
 		// Add user info to context
-		ctx := context.WithValue(c.Request.Context(), logger.UserIDKey, claims.UserID)
+		ctx := SetPrincipal(c.Request.Context(), claims.UserID)
 		c.Request = c.Request.WithContext(ctx)
 
 		// Set user info in Gin context
@@ -186,12 +169,77 @@ func (a *AuthMiddleware) OptionalAuth() gin.HandlerFunc {
 	}
 }
 
+// RequireAdmin serves next only to requests carrying a valid bearer token of
+// an admin user. Operations endpoints mounted on a service's own listener
+// are wrapped with it. Without a JWT secret no token can be trusted, so
+// every request is refused.
+func (a *AuthMiddleware) RequireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(a.jwtSecret) == 0 {
+			writeAuthError(w, http.StatusServiceUnavailable, "Admin authentication is not configured")
+			return
+		}
+
+		scheme, token, found := strings.Cut(r.Header.Get("Authorization"), " ")
+		if !found || scheme != "Bearer" {
+			writeAuthError(w, http.StatusUnauthorized, "Authorization header required")
+			return
+		}
+		claims, err := a.ParseToken(token)
+		if err != nil {
+			writeAuthError(w, http.StatusUnauthorized, "Invalid token")
+			return
+		}
+		if claims.UserType != "admin" {
+			writeAuthError(w, http.StatusForbidden, "Insufficient permissions")
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(SetPrincipal(r.Context(), claims.UserID)))
+	})
+}
+
+func writeAuthError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
+
+// ParseToken validates a signed token and returns its claims. Tokens that are
+// expired or carry no expiry are rejected with ErrTokenExpired.
+func (a *AuthMiddleware) ParseToken(tokenString string) (*AuthClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &AuthClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return a.jwtSecret, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	claims, ok := token.Claims.(*AuthClaims)
+	if !ok || !token.Valid {
+		return nil, ErrInvalidTokenClaims
+	}
+	if claims.ExpiresAt < time.Now().Unix() {
+		return nil, ErrTokenExpired
+	}
+	return claims, nil
+}
+
 // GenerateToken generates a JWT token for a user
 func (a *AuthMiddleware) GenerateToken(userID, userType, email string, expirationHours int) (string, error) {
+	return a.GenerateTokenWithRoles(userID, userType, email, nil, expirationHours)
+}
+
+// GenerateTokenWithRoles generates a JWT token for a user holding roles
+func (a *AuthMiddleware) GenerateTokenWithRoles(userID, userType, email string, roles []string, expirationHours int) (string, error) {
 	claims := &AuthClaims{
 		UserID:   userID,
 		UserType: userType,
 		Email:    email,
+		Roles:    roles,
 		StandardClaims: jwt.StandardClaims{
 			ExpiresAt: time.Now().Add(time.Hour * time.Duration(expirationHours)).Unix(),
 			IssuedAt:  time.Now().Unix(),
@@ -242,7 +290,7 @@ func (a *AuthMiddleware) RefreshToken(tokenString string, expirationHours int) (
 	}
 
 	// Generate new token with extended expiration
-	return a.GenerateToken(claims.UserID, claims.UserType, claims.Email, expirationHours)
+	return a.GenerateTokenWithRoles(claims.UserID, claims.UserType, claims.Email, claims.Roles, expirationHours)
 }
 
 // GetUserFromContext extracts user information from Gin context
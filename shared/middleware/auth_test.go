@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthMiddleware_RequireAdmin(t *testing.T) {
+	issuer := NewAuthMiddleware("test-secret", nil)
+	adminToken, err := issuer.GenerateToken("admin-1", "admin", "ops@example.com", 1)
+	require.NoError(t, err)
+	riderToken, err := issuer.GenerateToken("rider-1", "rider", "rider@example.com", 1)
+	require.NoError(t, err)
+	forgedToken, err := NewAuthMiddleware("other-secret", nil).GenerateToken("admin-1", "admin", "ops@example.com", 1)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		secret        string
+		authorization string
+		wantStatus    int
+	}{
+		{"admin token", "test-secret", "Bearer " + adminToken, http.StatusOK},
+		{"no token", "test-secret", "", http.StatusUnauthorized},
+		{"wrong scheme", "test-secret", "Basic " + adminToken, http.StatusUnauthorized},
+		{"forged token", "test-secret", "Bearer " + forgedToken, http.StatusUnauthorized},
+		{"rider token", "test-secret", "Bearer " + riderToken, http.StatusForbidden},
+		{"no secret configured", "", "Bearer " + adminToken, http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var principal string
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				principal, _ = r.Context().Value(logger.UserIDKey).(string)
+				w.WriteHeader(http.StatusOK)
+			})
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/archive/runs", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+
+			NewAuthMiddleware(tt.secret, nil).RequireAdmin(next).ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, "admin-1", principal)
+			} else {
+				assert.Empty(t, principal)
+			}
+		})
+	}
+}
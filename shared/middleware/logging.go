@@ -15,6 +15,8 @@ import (
 // LoggingMiddleware provides request logging middleware
 type LoggingMiddleware struct {
 	logger *logger.Logger
+	// redactedPaths are routes whose request bodies are never logged
+	redactedPaths map[string]bool
 }
 
 // NewLoggingMiddleware creates a new logging middleware
@@ -24,18 +26,35 @@ func NewLoggingMiddleware(log *logger.Logger) *LoggingMiddleware {
 	}
 }
 
-// RequestLogger logs HTTP requests and responses
+// RedactBodies stops request bodies of the given routes, such as those taking
+// card data, from being logged with failed requests. Paths are gin route
+// patterns, for example /api/v1/payment-methods.
+func (l *LoggingMiddleware) RedactBodies(paths ...string) *LoggingMiddleware {
+	if l.redactedPaths == nil {
+		l.redactedPaths = make(map[string]bool)
+	}
+	for _, path := range paths {
+		l.redactedPaths[path] = true
+	}
+	return l
+}
+
+// RequestLogger logs HTTP requests and responses. It assigns the request and
+// correlation IDs, stores them in the request context and logs method, path,
+// status, latency and principal.
 func (l *LoggingMiddleware) RequestLogger() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Generate request ID
-		requestID := uuid.New().String()
+		// Reuse the caller's IDs or generate new ones
+		requestID, correlationID := requestIDs(c.Request.Header)
 
-		// Add request ID to context
-		ctx := context.WithValue(c.Request.Context(), logger.RequestIDKey, requestID)
+		// Add request IDs to context
+		ctx := WithRequestIDs(c.Request.Context(), requestID, correlationID)
+		ctx = context.WithValue(ctx, principalKey{}, &principal{})
 		c.Request = c.Request.WithContext(ctx)
 
-		// Set request ID in response header
-		c.Header("X-Request-ID", requestID)
+		// Set request IDs in response header
+		c.Header(RequestIDHeader, requestID)
+		c.Header(CorrelationIDHeader, correlationID)
 
 		// Record start time
 		start := time.Now()
@@ -61,16 +80,20 @@ func (l *LoggingMiddleware) RequestLogger() gin.HandlerFunc {
 		duration := time.Since(start)
 
 		// Log request details
-		l.logger.LogRequest(
+		l.logRequest(
 			c.Request.Context(),
 			c.Request.Method,
 			c.Request.URL.Path,
 			c.Writer.Status(),
 			duration,
+			ginPrincipal(c),
 		)
 
 		// Log additional details for errors
 		if c.Writer.Status() >= 400 {
+			if l.redactedPaths[c.FullPath()] {
+				requestBody = []byte("[redacted]")
+			}
 			l.logger.WithContext(c.Request.Context()).WithFields(logger.Fields{
 				"method":        c.Request.Method,
 				"path":          c.Request.URL.Path,
@@ -89,8 +112,11 @@ func (l *LoggingMiddleware) RequestLogger() gin.HandlerFunc {
 // CorrelationID adds correlation ID to requests
 func (l *LoggingMiddleware) CorrelationID() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Check if correlation ID exists in header
-		correlationID := c.GetHeader("X-Correlation-ID")
+		// Reuse the ID assigned by RequestLogger or sent by the caller
+		correlationID, _ := c.Request.Context().Value(logger.CorrelationIDKey).(string)
+		if correlationID == "" {
+			correlationID = c.GetHeader(CorrelationIDHeader)
+		}
 		if correlationID == "" {
 			correlationID = uuid.New().String()
 		}
@@ -100,7 +126,7 @@ func (l *LoggingMiddleware) CorrelationID() gin.HandlerFunc {
 		c.Request = c.Request.WithContext(ctx)
 
 		// Set correlation ID in response header
-		c.Header("X-Correlation-ID", correlationID)
+		c.Header(CorrelationIDHeader, correlationID)
 
 		c.Next()
 	}
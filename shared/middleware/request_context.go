@@ -0,0 +1,153 @@
+package middleware
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+const (
+	// RequestIDHeader carries the ID of a single HTTP request
+	RequestIDHeader = "X-Request-ID"
+	// CorrelationIDHeader carries the ID shared by every request made on behalf
+	// of one client call, across services
+	CorrelationIDHeader = "X-Correlation-ID"
+)
+
+// principalKey stores the principal holder in the request context
+type principalKey struct{}
+
+// principal is filled in by auth handlers that run after the request logger,
+// so the logger can still report who made the request
+type principal struct {
+	userID string
+	mutex  sync.Mutex
+}
+
+// WithRequestIDs returns a context carrying the request and correlation IDs so
+// log lines and outgoing gRPC calls can be correlated
+func WithRequestIDs(ctx context.Context, requestID, correlationID string) context.Context {
+	ctx = context.WithValue(ctx, logger.RequestIDKey, requestID)
+	return context.WithValue(ctx, logger.CorrelationIDKey, correlationID)
+}
+
+// SetPrincipal records the authenticated user for the current request. It is
+// added to the context for later log lines and reported by the request logger.
+func SetPrincipal(ctx context.Context, userID string) context.Context {
+	if p, ok := ctx.Value(principalKey{}).(*principal); ok {
+		p.mutex.Lock()
+		p.userID = userID
+		p.mutex.Unlock()
+	}
+	return context.WithValue(ctx, logger.UserIDKey, userID)
+}
+
+// requestIDs reuses the caller's request and correlation IDs when present. A
+// new correlation ID starts at the first service a request reaches.
+func requestIDs(header http.Header) (requestID, correlationID string) {
+	requestID = header.Get(RequestIDHeader)
+	if requestID == "" {
+		requestID = uuid.New().String()
+	}
+	correlationID = header.Get(CorrelationIDHeader)
+	if correlationID == "" {
+		correlationID = requestID
+	}
+	return requestID, correlationID
+}
+
+// logRequest logs the outcome of a request with its principal
+func (l *LoggingMiddleware) logRequest(ctx context.Context, method, path string, status int, duration time.Duration, userID string) {
+	if userID != "" {
+		ctx = context.WithValue(ctx, logger.UserIDKey, userID)
+	}
+	l.logger.LogRequest(ctx, method, path, status, duration)
+}
+
+// HTTPRequestLogger is the net/http and gorilla/mux counterpart of
+// RequestLogger. It assigns the request and correlation IDs, stores them in
+// the request context and logs method, path, status, latency and principal.
+func (l *LoggingMiddleware) HTTPRequestLogger(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		requestID, correlationID := requestIDs(r.Header)
+		w.Header().Set(RequestIDHeader, requestID)
+		w.Header().Set(CorrelationIDHeader, correlationID)
+
+		p := &principal{}
+		ctx := WithRequestIDs(r.Context(), requestID, correlationID)
+		ctx = context.WithValue(ctx, principalKey{}, p)
+
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r.WithContext(ctx))
+
+		p.mutex.Lock()
+		userID := p.userID
+		p.mutex.Unlock()
+		l.logRequest(ctx, r.Method, r.URL.Path, recorder.status, time.Since(start), userID)
+	})
+}
+
+// statusRecorder captures the status code written by a net/http handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (w *statusRecorder) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusRecorder) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush lets streaming handlers flush through the recorder
+func (w *statusRecorder) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack lets websocket upgrades take over the connection
+func (w *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking")
+	}
+	w.status = http.StatusSwitchingProtocols
+	w.wroteHeader = true
+	return hijacker.Hijack()
+}
+
+// Unwrap exposes the underlying writer to http.ResponseController
+func (w *statusRecorder) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// ginPrincipal returns the user set on the gin context by the auth middleware
+func ginPrincipal(c *gin.Context) string {
+	if userID := c.GetString("user_id"); userID != "" {
+		return userID
+	}
+	if p, ok := c.Request.Context().Value(principalKey{}).(*principal); ok {
+		p.mutex.Lock()
+		defer p.mutex.Unlock()
+		return p.userID
+	}
+	return ""
+}
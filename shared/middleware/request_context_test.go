@@ -0,0 +1,142 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// newCapturingLogger returns a logger writing JSON lines into the buffer
+func newCapturingLogger() (*logger.Logger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	log := logger.NewLogger("info", "test")
+	log.SetOutput(&buf)
+	log.SetFormatter(&logrus.JSONFormatter{})
+	return log, &buf
+}
+
+// loggedRequest decodes the request log line written by the middleware
+func loggedRequest(t *testing.T, buf *bytes.Buffer) map[string]interface{} {
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry), "log output: %s", buf.String())
+	return entry
+}
+
+func TestHTTPRequestLogger_CorrelationIDs(t *testing.T) {
+	tests := []struct {
+		name              string
+		requestID         string
+		correlationID     string
+		wantCorrelationID string // empty when it should match the request ID
+	}{
+		{"generates a request ID", "", "", ""},
+		{"keeps the caller's request ID", "req-1", "", "req-1"},
+		{"keeps the caller's correlation ID", "req-2", "corr-1", "corr-1"},
+		{"keeps a correlation ID sent without a request ID", "", "corr-2", "corr-2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log, buf := newCapturingLogger()
+			var ctxRequestID, ctxCorrelationID string
+			handler := NewLoggingMiddleware(log).HTTPRequestLogger(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ctxRequestID, _ = r.Context().Value(logger.RequestIDKey).(string)
+				ctxCorrelationID, _ = r.Context().Value(logger.CorrelationIDKey).(string)
+				w.WriteHeader(http.StatusCreated)
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/drivers", nil)
+			if tt.requestID != "" {
+				req.Header.Set(RequestIDHeader, tt.requestID)
+			}
+			if tt.correlationID != "" {
+				req.Header.Set(CorrelationIDHeader, tt.correlationID)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			requestID := rec.Header().Get(RequestIDHeader)
+			if tt.requestID != "" {
+				assert.Equal(t, tt.requestID, requestID)
+			} else {
+				_, err := uuid.Parse(requestID)
+				assert.NoError(t, err, "a generated request ID is a UUID")
+			}
+			wantCorrelationID := tt.wantCorrelationID
+			if wantCorrelationID == "" {
+				wantCorrelationID = requestID
+			}
+			assert.Equal(t, wantCorrelationID, rec.Header().Get(CorrelationIDHeader))
+
+			// The handler and the log line see the IDs returned to the caller
+			assert.Equal(t, requestID, ctxRequestID)
+			assert.Equal(t, wantCorrelationID, ctxCorrelationID)
+			entry := loggedRequest(t, buf)
+			assert.Equal(t, requestID, entry["request_id"])
+			assert.Equal(t, wantCorrelationID, entry["correlation_id"])
+			assert.Equal(t, float64(http.StatusCreated), entry["status_code"])
+		})
+	}
+}
+
+func TestHTTPRequestLogger_GeneratesAnIDPerRequest(t *testing.T) {
+	log, _ := newCapturingLogger()
+	handler := NewLoggingMiddleware(log).HTTPRequestLogger(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	first, second := httptest.NewRecorder(), httptest.NewRecorder()
+	handler.ServeHTTP(first, httptest.NewRequest(http.MethodGet, "/health", nil))
+	handler.ServeHTTP(second, httptest.NewRequest(http.MethodGet, "/health", nil))
+	assert.NotEqual(t, first.Header().Get(RequestIDHeader), second.Header().Get(RequestIDHeader))
+}
+
+func TestHTTPRequestLogger_LogsThePrincipal(t *testing.T) {
+	log, buf := newCapturingLogger()
+	handler := NewLoggingMiddleware(log).HTTPRequestLogger(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Auth runs inside the logger and records the user on the way through
+		SetPrincipal(r.Context(), "admin-1")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/trips", nil)
+	req.Header.Set(CorrelationIDHeader, "corr-1")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	entry := loggedRequest(t, buf)
+	assert.Equal(t, "corr-1", entry["correlation_id"])
+	assert.Equal(t, "admin-1", entry["user_id"])
+}
+
+func TestRequestLogger_CorrelationIDs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	log, buf := newCapturingLogger()
+	router := gin.New()
+	router.Use(NewLoggingMiddleware(log).RequestLogger())
+
+	var ctxCorrelationID string
+	router.GET("/trips/:id", func(c *gin.Context) {
+		ctxCorrelationID, _ = c.Request.Context().Value(logger.CorrelationIDKey).(string)
+		c.Set("user_id", "rider-1")
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/trips/trip-1", nil)
+	req.Header.Set(RequestIDHeader, "req-1")
+	req.Header.Set(CorrelationIDHeader, "corr-1")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, "req-1", rec.Header().Get(RequestIDHeader))
+	assert.Equal(t, "corr-1", rec.Header().Get(CorrelationIDHeader))
+	assert.Equal(t, "corr-1", ctxCorrelationID)
+	entry := loggedRequest(t, buf)
+	assert.Equal(t, "corr-1", entry["correlation_id"])
+	assert.Equal(t, "rider-1", entry["user_id"])
+}
@@ -3,11 +3,13 @@ package models
 import (
 	"fmt"
 	"time"
+
+	"github.com/rideshare-platform/shared/currency"
 )
 
 // Money represents a monetary value
 type Money struct {
-	Amount   int64  `json:"amount" db:"amount"`     // amount in cents
+	Amount   int64  `json:"amount" db:"amount"`     // amount in minor units, e.g. cents
 	Currency string `json:"currency" db:"currency"` // ISO 4217 currency code
 }
 
@@ -26,11 +28,20 @@ type FareBreakdown struct {
 	BaseFare     Money `json:"base_fare" db:"base_fare"`
 	DistanceFare Money `json:"distance_fare" db:"distance_fare"`
 	TimeFare     Money `json:"time_fare" db:"time_fare"`
+	WaitingFare  Money `json:"waiting_fare" db:"waiting_fare"`
 	SurgeAmount  Money `json:"surge_amount" db:"surge_amount"`
 	BookingFee   Money `json:"booking_fee" db:"booking_fee"`
 	ServiceFee   Money `json:"service_fee" db:"service_fee"`
+	Surcharges   Money `json:"surcharges" db:"surcharges"` // zone surcharges such as airport fees
+	Taxes        Money `json:"taxes" db:"taxes"`           // taxes and levies, included in Total
 	Discount     Money `json:"discount" db:"discount"`
 	Total        Money `json:"total" db:"total"`
+
+	SurgeMultiplier float64 `json:"surge_multiplier" db:"surge_multiplier"`
+	WaitingSeconds  int     `json:"waiting_seconds" db:"waiting_seconds"`
+	// Estimated is set when the fare could not be priced from the trip's actual
+	// distance and duration, and a fallback amount was charged instead
+	Estimated bool `json:"estimated,omitempty" db:"estimated"`
 }
 
 // PricingRule represents a pricing rule for a specific vehicle type and location
@@ -153,7 +164,7 @@ func NewPromoCode(code, discountType string, discountValue float64) *PromoCode {
 
 // ToFloat64 converts Money to float64 (in major currency units)
 func (m Money) ToFloat64() float64 {
-	return float64(m.Amount) / 100.0
+	return currency.FromMinor(m.Amount, m.Currency)
 }
 
 // Add adds another Money amount (must be same currency)
@@ -203,16 +214,10 @@ func (m Money) IsNegative() bool {
 
 // Formatted returns a formatted string representation
 func (m Money) Formatted() string {
-	switch m.Currency {
-	case "USD":
-		return fmt.Sprintf("$%.2f", m.ToFloat64())
-	case "EUR":
-		return fmt.Sprintf("€%.2f", m.ToFloat64())
-	case "GBP":
-		return fmt.Sprintf("£%.2f", m.ToFloat64())
-	default:
-		return fmt.Sprintf("%.2f %s", m.ToFloat64(), m.Currency)
+	if c, err := currency.Lookup(m.Currency); err == nil {
+		return c.Format(m.Amount)
 	}
+	return fmt.Sprintf("%.2f %s", m.ToFloat64(), m.Currency)
 }
 
 // IsCurrentlyActive returns true if the pricing rule is currently active
@@ -50,6 +50,7 @@ type Trip struct {
 	EstimatedDurationSeconds *int        `json:"estimated_duration_seconds" db:"estimated_duration_seconds"`
 	ActualDurationSeconds    *int        `json:"actual_duration_seconds" db:"actual_duration_seconds"`
 	RequestedAt              time.Time   `json:"requested_at" db:"requested_at"`
+	ScheduledFor             *time.Time  `json:"scheduled_for,omitempty" db:"scheduled_for"`
 	MatchedAt                *time.Time  `json:"matched_at" db:"matched_at"`
 	DriverAssignedAt         *time.Time  `json:"driver_assigned_at" db:"driver_assigned_at"`
 	DriverArrivedAt          *time.Time  `json:"driver_arrived_at" db:"driver_arrived_at"`
@@ -62,6 +63,23 @@ type Trip struct {
 	PromoCode                *string     `json:"promo_code" db:"promo_code"`
 	CreatedAt                time.Time   `json:"created_at" db:"created_at"`
 	UpdatedAt                time.Time   `json:"updated_at" db:"updated_at"`
+
+	// SurgeMultiplier is locked when the trip is requested and the final fare
+	// is charged at it. FareBreakdown itemizes the final fare.
+	SurgeMultiplier *float64       `json:"surge_multiplier,omitempty" db:"surge_multiplier"`
+	FareBreakdown   *FareBreakdown `json:"fare_breakdown,omitempty" db:"fare_breakdown"`
+
+	// CityID is the city the trip starts in, which scopes its pricing,
+	// matching and reporting. It is empty outside every configured city.
+	CityID string `json:"city_id,omitempty" db:"city_id"`
+
+	// AccessibilityNeeds are the rider's needs the vehicle had to meet, such
+	// as wheelchair_accessible. Cities report accessible trips separately.
+	AccessibilityNeeds []string `json:"accessibility_needs,omitempty" db:"accessibility_needs"`
+
+	// DriverLocation is the driver's last reported position while the trip
+	// is active, which trip share links follow
+	DriverLocation *Location `json:"driver_location,omitempty" db:"driver_location"`
 }
 
 // TripEvent represents an event in the trip lifecycle for event sourcing
@@ -145,6 +163,16 @@ func (t *Trip) HasVehicle() bool {
 	return t.VehicleID != nil
 }
 
+// IsWAV returns true if the trip needs a wheelchair-accessible vehicle
+func (t *Trip) IsWAV() bool {
+	for _, need := range t.AccessibilityNeeds {
+		if need == string(VehicleFeatureWheelchairAccessible) {
+			return true
+		}
+	}
+	return false
+}
+
 // UpdateStatus updates the trip status with state machine validation
 func (t *Trip) UpdateStatus(status TripStatus, userID *string) (*TripEvent, error) {
 	// Validate state transition
@@ -211,6 +239,28 @@ func (t *Trip) SetActualFare(fareCents int64) {
 	t.UpdatedAt = time.Now()
 }
 
+// LockSurgeMultiplier records the surge quoted when the trip was requested,
+// which the final fare is charged at
+func (t *Trip) LockSurgeMultiplier(multiplier float64) {
+	t.SurgeMultiplier = &multiplier
+	t.UpdatedAt = time.Now()
+}
+
+// SetFareBreakdown records the final fare and sets the actual fare to its total
+func (t *Trip) SetFareBreakdown(breakdown *FareBreakdown) {
+	t.FareBreakdown = breakdown
+	t.SetActualFare(breakdown.Total.Amount)
+}
+
+// WaitingSeconds returns how long the driver waited at pickup before the trip
+// started, or zero if either time is unknown
+func (t *Trip) WaitingSeconds() int {
+	if t.DriverArrivedAt == nil || t.StartedAt == nil || !t.StartedAt.After(*t.DriverArrivedAt) {
+		return 0
+	}
+	return int(t.StartedAt.Sub(*t.DriverArrivedAt).Seconds())
+}
+
 // SetEstimatedDistance sets the estimated distance for the trip
 func (t *Trip) SetEstimatedDistance(distanceKm float64) {
 	t.EstimatedDistanceKm = &distanceKm
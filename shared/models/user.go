@@ -45,8 +45,8 @@ const (
 // User represents a user in the rideshare platform
 type User struct {
 	ID              string     `json:"id" db:"id"`
-	Email           string     `json:"email" db:"email"`
-	Phone           string     `json:"phone" db:"phone"`
+	Email           string     `json:"email" db:"email" pii:"encrypt"`
+	Phone           string     `json:"phone" db:"phone" pii:"encrypt"`
 	PasswordHash    string     `json:"-" db:"password_hash"`
 	FirstName       string     `json:"first_name" db:"first_name"`
 	LastName        string     `json:"last_name" db:"last_name"`
@@ -55,6 +55,7 @@ type User struct {
 	ProfileImageURL string     `json:"profile_image_url" db:"profile_image_url"`
 	EmailVerified   bool       `json:"email_verified" db:"email_verified"`
 	PhoneVerified   bool       `json:"phone_verified" db:"phone_verified"`
+	Locale          string     `json:"locale,omitempty" db:"locale"` // BCP 47 locale notifications are sent in
 	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
 }
@@ -25,6 +25,20 @@ const (
 	VehicleStatusRetired     VehicleStatus = "retired"
 )
 
+// VehicleFeature is an amenity or accessibility feature a vehicle offers
+type VehicleFeature string
+
+const (
+	VehicleFeatureChildSeat            VehicleFeature = "child_seat"
+	VehicleFeatureWheelchairAccessible VehicleFeature = "wheelchair_accessible"
+	VehicleFeatureWifi                 VehicleFeature = "wifi"
+	VehicleFeaturePetFriendly          VehicleFeature = "pet_friendly"
+)
+
+// RideTypeWAV is the wheelchair-accessible ride type. Vehicles of any type
+// with the wheelchair_accessible feature serve it, and no others do.
+const RideTypeWAV = "wav"
+
 // Vehicle represents a vehicle in the rideshare platform
 type Vehicle struct {
 	ID                    string        `json:"id" db:"id"`
@@ -40,8 +54,14 @@ type Vehicle struct {
 	InsurancePolicyNumber string        `json:"insurance_policy_number" db:"insurance_policy_number"`
 	InsuranceExpiry       *time.Time    `json:"insurance_expiry" db:"insurance_expiry"`
 	RegistrationExpiry    *time.Time    `json:"registration_expiry" db:"registration_expiry"`
-	CreatedAt             time.Time     `json:"created_at" db:"created_at"`
-	UpdatedAt             time.Time     `json:"updated_at" db:"updated_at"`
+	// PhotoKey is the object key of the vehicle's accepted photo, and
+	// PhotoURLs the signed URLs it is served at by size
+	PhotoKey  string            `json:"photo_key,omitempty" db:"photo_key"`
+	PhotoURLs map[string]string `json:"photo_urls,omitempty" db:"-"`
+	// Features are stored apart from the vehicle row
+	Features  []VehicleFeature `json:"features,omitempty" db:"-"`
+	CreatedAt time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at" db:"updated_at"`
 }
 
 // NewVehicle creates a new vehicle with default values
@@ -112,6 +132,21 @@ func (v *Vehicle) IsValidForService() bool {
 	return v.IsActive() && v.IsInsuranceValid() && v.IsRegistrationValid()
 }
 
+// HasFeature reports whether the vehicle offers a feature
+func (v *Vehicle) HasFeature(feature VehicleFeature) bool {
+	for _, f := range v.Features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
+
+// IsWAV reports whether the vehicle can serve wheelchair-accessible rides
+func (v *Vehicle) IsWAV() bool {
+	return v.HasFeature(VehicleFeatureWheelchairAccessible)
+}
+
 // GetDisplayName returns a display name for the vehicle
 func (v *Vehicle) GetDisplayName() string {
 	return v.Color + " " + v.Make + " " + v.Model
@@ -145,6 +180,26 @@ func IsValidVehicleType(vehicleType string) bool {
 	}
 }
 
+// IsValidVehicleFeature checks if a vehicle feature is known
+func IsValidVehicleFeature(feature string) bool {
+	switch VehicleFeature(feature) {
+	case VehicleFeatureChildSeat, VehicleFeatureWheelchairAccessible, VehicleFeatureWifi, VehicleFeaturePetFriendly:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsValidVehicleStatus checks if a vehicle status is valid
+func IsValidVehicleStatus(status string) bool {
+	switch VehicleStatus(status) {
+	case VehicleStatusInactive, VehicleStatusActive, VehicleStatusMaintenance, VehicleStatusRetired:
+		return true
+	default:
+		return false
+	}
+}
+
 // GetVehicleTypes returns all valid vehicle types
 func GetVehicleTypes() []VehicleType {
 	return []VehicleType{
@@ -155,3 +210,13 @@ func GetVehicleTypes() []VehicleType {
 		VehicleTypeVan,
 	}
 }
+
+// GetVehicleFeatures returns all known vehicle features
+func GetVehicleFeatures() []VehicleFeature {
+	return []VehicleFeature{
+		VehicleFeatureChildSeat,
+		VehicleFeatureWheelchairAccessible,
+		VehicleFeatureWifi,
+		VehicleFeaturePetFriendly,
+	}
+}
@@ -0,0 +1,226 @@
+package monitoring
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/rideshare-platform/shared/alerting"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// availableDriversKey is the Redis set of drivers currently accepting trips
+const availableDriversKey = "available_drivers"
+
+// MetricsEvaluator consumes ingested metrics, normally the AlertManager
+type MetricsEvaluator interface {
+	EvaluateMetrics(ctx context.Context, metrics []*alerting.MetricValue) error
+}
+
+// MetricsIngester periodically derives alerting metrics from the collector's
+// Prometheus metrics and Redis and feeds them to an evaluator. Rates and
+// percentiles cover the interval since the previous ingestion, not process lifetime.
+type MetricsIngester struct {
+	collector *MetricsCollector
+	evaluator MetricsEvaluator
+	logger    *logger.Logger
+
+	previous   map[string]float64
+	histograms map[string]map[float64]uint64
+}
+
+// NewMetricsIngester creates an ingester reading from collector and feeding evaluator
+func NewMetricsIngester(collector *MetricsCollector, evaluator MetricsEvaluator, logger *logger.Logger) *MetricsIngester {
+	return &MetricsIngester{
+		collector:  collector,
+		evaluator:  evaluator,
+		logger:     logger,
+		previous:   make(map[string]float64),
+		histograms: make(map[string]map[float64]uint64),
+	}
+}
+
+// StartIngestion collects and evaluates metrics every interval until ctx is cancelled
+func (mi *MetricsIngester) StartIngestion(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			mi.logger.Info("Stopping metrics ingestion")
+			return
+		case <-ticker.C:
+			metrics := mi.Collect(ctx)
+			if err := mi.evaluator.EvaluateMetrics(ctx, metrics); err != nil {
+				mi.logger.WithError(err).Error("Failed to evaluate metrics")
+			}
+		}
+	}
+}
+
+// Collect derives the current alerting metrics. Metrics without data for the
+// interval (for example error_rate with no traffic) are omitted rather than
+// reported as zero, so they cannot satisfy a condition by accident.
+func (mi *MetricsIngester) Collect(ctx context.Context) []*alerting.MetricValue {
+	now := time.Now()
+	var metrics []*alerting.MetricValue
+	add := func(name string, value float64) {
+		metrics = append(metrics, &alerting.MetricValue{Name: name, Value: value, Timestamp: now})
+	}
+
+	system := mi.collector.systemMetrics
+	requests, serverErrors := mi.requestCounts(system.APIRequests)
+	errors := mi.delta("errors", sumCounters(system.ErrorsTotal))
+	if requests > 0 {
+		add("error_rate", math.Min(errors/requests, 1))
+		add("availability", 1-serverErrors/requests)
+	}
+
+	payment := mi.collector.paymentMetrics
+	payments := mi.delta("payments", sumCounters(payment.PaymentsTotal))
+	failures := mi.delta("payment_failures", sumCounters(payment.PaymentFailures))
+	if payments > 0 {
+		add("payment_failure_rate", failures/payments)
+	}
+
+	if p95, ok := mi.percentile("api_latency", system.APILatency, 0.95); ok {
+		add("response_time_p95", p95*1000)
+	}
+	if p95, ok := mi.percentile("db_latency", system.DatabaseLatency, 0.95); ok {
+		add("db_query_latency_p95", p95*1000)
+	}
+
+	if drivers, ok := mi.availableDrivers(ctx); ok {
+		add("available_drivers", drivers)
+	}
+
+	return metrics
+}
+
+// requestCounts returns the requests and server errors recorded since the last ingestion
+func (mi *MetricsIngester) requestCounts(requests *prometheus.CounterVec) (float64, float64) {
+	var total, serverErrors float64
+	for _, metric := range collect(requests) {
+		value := metric.GetCounter().GetValue()
+		total += value
+		if isServerError(label(metric, "status")) {
+			serverErrors += value
+		}
+	}
+	return mi.delta("requests", total), mi.delta("server_errors", serverErrors)
+}
+
+// availableDrivers prefers the live Redis set and falls back to the gauge
+// updated through UpdateDriverCounts
+func (mi *MetricsIngester) availableDrivers(ctx context.Context) (float64, bool) {
+	if redis := mi.collector.redis; redis != nil {
+		exists, err := redis.Exists(ctx, availableDriversKey).Result()
+		if err == nil && exists > 0 {
+			if count, err := redis.SCard(ctx, availableDriversKey).Result(); err == nil {
+				return float64(count), true
+			}
+		}
+	}
+	if !mi.collector.driverCountsReported() {
+		return 0, false
+	}
+	return sumCounters(mi.collector.driverMetrics.DriversAvailable), true
+}
+
+// delta returns how much a cumulative value grew since the last call. A
+// counter reset (process restart) is treated as growth from zero.
+func (mi *MetricsIngester) delta(key string, current float64) float64 {
+	previous := mi.previous[key]
+	mi.previous[key] = current
+	if current < previous {
+		return current
+	}
+	return current - previous
+}
+
+// percentile estimates a quantile over the observations recorded since the
+// last call, interpolating linearly within the matching bucket
+func (mi *MetricsIngester) percentile(key string, histogram prometheus.Collector, q float64) (float64, bool) {
+	current := make(map[float64]uint64)
+	for _, metric := range collect(histogram) {
+		for _, bucket := range metric.GetHistogram().GetBucket() {
+			current[bucket.GetUpperBound()] += bucket.GetCumulativeCount()
+		}
+	}
+	previous := mi.histograms[key]
+	mi.histograms[key] = current
+
+	bounds := make([]float64, 0, len(current))
+	for bound := range current {
+		bounds = append(bounds, bound)
+	}
+	sort.Float64s(bounds)
+
+	counts := make([]float64, len(bounds))
+	for i, bound := range bounds {
+		count := current[bound]
+		if prev, ok := previous[bound]; ok && prev <= count {
+			count -= prev
+		}
+		counts[i] = float64(count)
+	}
+
+	// Observations above the last bound only show up in the +Inf bucket, which
+	// the cumulative counts omit, so the estimate caps at the largest bound
+	if len(counts) == 0 || counts[len(counts)-1] == 0 {
+		return 0, false
+	}
+	rank := q * counts[len(counts)-1]
+	lower, below := 0.0, 0.0
+	for i, bound := range bounds {
+		if counts[i] >= rank {
+			inBucket := counts[i] - below
+			if inBucket == 0 {
+				return bound, true
+			}
+			return lower + (bound-lower)*(rank-below)/inBucket, true
+		}
+		lower, below = bound, counts[i]
+	}
+	return bounds[len(bounds)-1], true
+}
+
+// collect reads the current samples of a metric or metric vector
+func collect(collector prometheus.Collector) []*dto.Metric {
+	ch := make(chan prometheus.Metric)
+	go func() {
+		collector.Collect(ch)
+		close(ch)
+	}()
+
+	var metrics []*dto.Metric
+	for metric := range ch {
+		var m dto.Metric
+		if err := metric.Write(&m); err == nil {
+			metrics = append(metrics, &m)
+		}
+	}
+	return metrics
+}
+
+// sumCounters adds up every counter or gauge sample of a metric
+func sumCounters(collector prometheus.Collector) float64 {
+	var total float64
+	for _, metric := range collect(collector) {
+		total += metric.GetCounter().GetValue() + metric.GetGauge().GetValue()
+	}
+	return total
+}
+
+func label(metric *dto.Metric, name string) string {
+	for _, pair := range metric.GetLabel() {
+		if pair.GetName() == name {
+			return pair.GetValue()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,167 @@
+package monitoring
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rideshare-platform/shared/alerting"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+var (
+	testCollectorOnce sync.Once
+	testCollector     *MetricsCollector
+)
+
+// sharedCollector returns the collector all tests record into. Its metrics
+// are registered with the default Prometheus registry, which only takes
+// them once per process; each test starts its own ingester instead, whose
+// first Collect sets the baseline.
+func sharedCollector() *MetricsCollector {
+	testCollectorOnce.Do(func() {
+		testCollector = NewMetricsCollector(nil, logger.NewLogger("error", "test"))
+	})
+	return testCollector
+}
+
+func newTestIngester(evaluator MetricsEvaluator) (*MetricsIngester, *MetricsCollector) {
+	collector := sharedCollector()
+	ingester := NewMetricsIngester(collector, evaluator, logger.NewLogger("error", "test"))
+	ingester.Collect(context.Background())
+	return ingester, collector
+}
+
+func metricValues(metrics []*alerting.MetricValue) map[string]float64 {
+	values := make(map[string]float64, len(metrics))
+	for _, metric := range metrics {
+		values[metric.Name] = metric.Value.(float64)
+	}
+	return values
+}
+
+func TestMetricsIngester_Delta(t *testing.T) {
+	ingester := NewMetricsIngester(nil, nil, logger.NewLogger("error", "test"))
+
+	tests := []struct {
+		current float64
+		want    float64
+	}{
+		{10, 10},
+		{15, 5},
+		{15, 0},
+		{3, 3}, // the counter was reset by a restart
+		{7, 4},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, ingester.delta("requests", tt.current), "delta to %v", tt.current)
+	}
+}
+
+func TestMetricsIngester_RatesCoverTheInterval(t *testing.T) {
+	ingester, collector := newTestIngester(nil)
+	ctx := context.Background()
+
+	for i := 0; i < 8; i++ {
+		collector.RecordAPIRequest("trip-service", "GET", "/trips", "200", 0.01)
+	}
+	collector.RecordAPIRequest("trip-service", "GET", "/trips", "500", 0.01)
+	collector.RecordAPIRequest("trip-service", "GET", "/trips", "503", 0.01)
+	collector.RecordDatabaseError("trip-service", "select", "trips")
+	collector.RecordPayment("card", "completed", 1500)
+	collector.RecordPayment("card", "completed", 900)
+	collector.RecordPayment("card", "completed", 1200)
+	collector.RecordPayment("card", "failed", 700)
+
+	values := metricValues(ingester.Collect(ctx))
+	assert.InDelta(t, 0.1, values["error_rate"], 1e-9)
+	assert.InDelta(t, 0.8, values["availability"], 1e-9)
+	assert.InDelta(t, 0.25, values["payment_failure_rate"], 1e-9)
+
+	// Without traffic in the next interval the rates are left out rather
+	// than reported as zero
+	values = metricValues(ingester.Collect(ctx))
+	assert.NotContains(t, values, "error_rate")
+	assert.NotContains(t, values, "availability")
+	assert.NotContains(t, values, "payment_failure_rate")
+
+	// Earlier failures do not count against the next interval
+	collector.RecordAPIRequest("trip-service", "GET", "/trips", "200", 0.01)
+	values = metricValues(ingester.Collect(ctx))
+	assert.Equal(t, 1.0, values["availability"])
+	assert.Equal(t, 0.0, values["error_rate"])
+}
+
+func TestMetricsIngester_LatencyPercentiles(t *testing.T) {
+	ingester, collector := newTestIngester(nil)
+	ctx := context.Background()
+
+	// All observations fall in the 10ms-25ms bucket; the 95th percentile is
+	// interpolated 95% of the way through it
+	for i := 0; i < 100; i++ {
+		collector.RecordAPIRequest("trip-service", "GET", "/trips", "200", 0.02)
+		collector.RecordDatabaseQuery("trip-service", "select", "trips", 0.004)
+	}
+	values := metricValues(ingester.Collect(ctx))
+	assert.InDelta(t, 24.25, values["response_time_p95"], 1e-6, "latencies are reported in milliseconds")
+	assert.InDelta(t, 4.75, values["db_query_latency_p95"], 1e-6)
+
+	// The next interval's percentile only covers the next interval's requests
+	for i := 0; i < 20; i++ {
+		collector.RecordAPIRequest("trip-service", "GET", "/trips", "200", 0.4)
+	}
+	values = metricValues(ingester.Collect(ctx))
+	assert.InDelta(t, 487.5, values["response_time_p95"], 1e-6)
+	assert.NotContains(t, values, "db_query_latency_p95", "no queries ran in the interval")
+
+	// Slow outliers lift the percentile once they pass 5% of requests
+	for i := 0; i < 90; i++ {
+		collector.RecordAPIRequest("trip-service", "GET", "/trips", "200", 0.02)
+	}
+	for i := 0; i < 10; i++ {
+		collector.RecordAPIRequest("trip-service", "GET", "/trips", "200", 2)
+	}
+	values = metricValues(ingester.Collect(ctx))
+	assert.InDelta(t, 1750, values["response_time_p95"], 1e-6)
+}
+
+// recordingEvaluator hands every batch of metrics it evaluates to a channel
+type recordingEvaluator struct {
+	batches chan []*alerting.MetricValue
+}
+
+func (r *recordingEvaluator) EvaluateMetrics(ctx context.Context, metrics []*alerting.MetricValue) error {
+	r.batches <- metrics
+	return nil
+}
+
+func TestMetricsIngester_StartIngestionFeedsTheEvaluator(t *testing.T) {
+	evaluator := &recordingEvaluator{batches: make(chan []*alerting.MetricValue, 10)}
+	ingester, collector := newTestIngester(evaluator)
+	collector.RecordAPIRequest("trip-service", "GET", "/trips", "500", 0.01)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		ingester.StartIngestion(ctx, 10*time.Millisecond)
+		close(stopped)
+	}()
+
+	select {
+	case batch := <-evaluator.batches:
+		assert.Equal(t, 0.0, metricValues(batch)["availability"])
+	case <-time.After(time.Second):
+		t.Fatal("no metrics were evaluated")
+	}
+
+	cancel()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		require.Fail(t, "ingestion did not stop when its context was cancelled")
+	}
+}
@@ -3,7 +3,9 @@ package monitoring
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -14,7 +16,7 @@ import (
 
 // MetricsCollector collects and exposes metrics for the rideshare platform
 type MetricsCollector struct {
-	redis  *redis.Client
+	redis  redis.UniversalClient
 	logger *logger.Logger
 
 	// Prometheus metrics
@@ -23,6 +25,12 @@ type MetricsCollector struct {
 	matchingMetrics *MatchingMetrics
 	paymentMetrics  *PaymentMetrics
 	systemMetrics   *SystemMetrics
+
+	// driverCountsSet records whether the driver gauges hold real data yet
+	driverCountsSet atomic.Bool
+
+	// businessSource computes the business KPIs served by GetBusinessMetrics
+	businessSource BusinessMetricsSource
 }
 
 // TripMetrics contains trip-related Prometheus metrics
@@ -78,20 +86,47 @@ type SystemMetrics struct {
 	WebSocketConnections prometheus.Gauge
 }
 
-// BusinessMetrics represents business KPIs
+// BusinessMetrics represents business KPIs over the range [From, To).
+// ActiveTrips is the number of trips in progress when they were computed.
 type BusinessMetrics struct {
-	TotalTrips           int64     `json:"total_trips"`
-	ActiveTrips          int64     `json:"active_trips"`
-	CompletedTrips       int64     `json:"completed_trips"`
-	CancelledTrips       int64     `json:"cancelled_trips"`
-	TotalRevenue         float64   `json:"total_revenue"`
-	AverageRating        float64   `json:"average_rating"`
-	AverageTripDuration  float64   `json:"average_trip_duration"`
-	DriverUtilization    float64   `json:"driver_utilization"`
-	CustomerSatisfaction float64   `json:"customer_satisfaction"`
-	Timestamp            time.Time `json:"timestamp"`
+	From           time.Time `json:"from"`
+	To             time.Time `json:"to"`
+	TotalTrips     int64     `json:"total_trips"`
+	ActiveTrips    int64     `json:"active_trips"`
+	CompletedTrips int64     `json:"completed_trips"`
+	CancelledTrips int64     `json:"cancelled_trips"`
+	// TotalRevenue is the revenue in Currency; revenue in every currency is
+	// listed in RevenueByCurrency
+	TotalRevenue        float64            `json:"total_revenue"`
+	Currency            string             `json:"currency,omitempty"`
+	RevenueByCurrency   map[string]float64 `json:"revenue_by_currency,omitempty"`
+	AverageRating       float64            `json:"average_rating"`
+	AverageTripDuration float64            `json:"average_trip_duration"` // minutes
+	DriverUtilization   float64            `json:"driver_utilization"`
+	// CustomerSatisfaction is the share of riders' ratings of 4 or 5 stars
+	CustomerSatisfaction float64 `json:"customer_satisfaction"`
+	// Fare disputes opened and closed in the range, and what was refunded
+	// to riders for them in each currency
+	DisputesOpened           int64              `json:"disputes_opened"`
+	DisputesClosed           int64              `json:"disputes_closed"`
+	DisputeRefundsByCurrency map[string]float64 `json:"dispute_refunds_by_currency,omitempty"`
+	// Pickups judged against the ETA riders were promised, those that came
+	// too late, and what riders were credited for them in each currency
+	PickupGuaranteesJudged           int64              `json:"pickup_guarantees_judged"`
+	PickupGuaranteesMissed           int64              `json:"pickup_guarantees_missed"`
+	PickupGuaranteeCreditsByCurrency map[string]float64 `json:"pickup_guarantee_credits_by_currency,omitempty"`
+	Timestamp                        time.Time          `json:"timestamp"`
+}
+
+// BusinessMetricsSource computes business KPIs from a service's own data
+type BusinessMetricsSource interface {
+	BusinessMetrics(ctx context.Context, from, to time.Time) (*BusinessMetrics, error)
 }
 
+// ErrNoBusinessMetricsSource is returned by GetBusinessMetrics when no source
+// has been set
+var ErrNoBusinessMetricsSource = errors.New("no business metrics source configured")
+
 // SystemHealth represents overall system health
 type SystemHealth struct {
 	Status       string                   `json:"status"` // healthy, degraded, unhealthy
@@ -125,7 +160,7 @@ type Alert struct {
 }
 
 // NewMetricsCollector creates a new metrics collector
-func NewMetricsCollector(redis *redis.Client, logger *logger.Logger) *MetricsCollector {
+func NewMetricsCollector(redis redis.UniversalClient, logger *logger.Logger) *MetricsCollector {
 	collector := &MetricsCollector{
 		redis:  redis,
 		logger: logger,
@@ -371,11 +406,22 @@ func (mc *MetricsCollector) RecordDatabaseQuery(service, operation, table string
 	mc.systemMetrics.DatabaseLatency.WithLabelValues(service, operation, table).Observe(duration)
 }
 
+// RecordDatabaseError records a failed database query
+func (mc *MetricsCollector) RecordDatabaseError(service, operation, table string) {
+	mc.systemMetrics.ErrorsTotal.WithLabelValues(service, "database_"+operation).Inc()
+}
+
 // UpdateDriverCounts updates driver status counts
 func (mc *MetricsCollector) UpdateDriverCounts(online, available, busy int) {
 	mc.driverMetrics.DriversOnline.Set(float64(online))
 	mc.driverMetrics.DriversAvailable.Set(float64(available))
 	mc.driverMetrics.DriversBusy.Set(float64(busy))
+	mc.driverCountsSet.Store(true)
+}
+
+// driverCountsReported reports whether UpdateDriverCounts has been called
+func (mc *MetricsCollector) driverCountsReported() bool {
+	return mc.driverCountsSet.Load()
 }
 
 // RecordDriverUtilization records driver utilization
@@ -383,42 +429,44 @@ func (mc *MetricsCollector) RecordDriverUtilization(utilizationRatio float64) {
 	mc.driverMetrics.DriverUtilization.Observe(utilizationRatio)
 }
 
-// GetBusinessMetrics collects and returns current business metrics
-func (mc *MetricsCollector) GetBusinessMetrics(ctx context.Context) (*BusinessMetrics, error) {
-	// In a real implementation, this would query the database
-	// For now, return computed metrics
+// SetBusinessMetricsSource sets where GetBusinessMetrics reads KPIs from
+func (mc *MetricsCollector) SetBusinessMetricsSource(source BusinessMetricsSource) {
+	mc.businessSource = source
+}
 
-	metrics := &BusinessMetrics{
-		Timestamp: time.Now(),
+// GetBusinessMetrics returns the business KPIs for [from, to). Ranges that
+// ended before the current hour no longer change and are cached in Redis
+// when it is available.
+func (mc *MetricsCollector) GetBusinessMetrics(ctx context.Context, from, to time.Time) (*BusinessMetrics, error) {
+	if mc.businessSource == nil {
+		return nil, ErrNoBusinessMetricsSource
 	}
 
-	// If Redis is available, try to get cached metrics
-	if mc.redis != nil {
-		data, err := mc.redis.Get(ctx, "business_metrics:current").Result()
+	cacheable := mc.redis != nil && !to.After(time.Now().Truncate(time.Hour))
+	cacheKey := fmt.Sprintf("business_metrics:%d:%d", from.Unix(), to.Unix())
+	if cacheable {
+		data, err := mc.redis.Get(ctx, cacheKey).Result()
 		if err == nil {
-			if err := json.Unmarshal([]byte(data), metrics); err == nil {
-				return metrics, nil
+			var metrics BusinessMetrics
+			if err := json.Unmarshal([]byte(data), &metrics); err == nil {
+				return &metrics, nil
 			}
 		}
 	}
 
-	// Fallback to mock data
-	metrics.TotalTrips = 15420
-	metrics.ActiveTrips = 234
-	metrics.CompletedTrips = 14890
-	metrics.CancelledTrips = 530
-	metrics.TotalRevenue = 289125.50
-	metrics.AverageRating = 4.72
-	metrics.AverageTripDuration = 18.5  // minutes
-	metrics.DriverUtilization = 0.68    // 68%
-	metrics.CustomerSatisfaction = 0.89 // 89%
-
-	// Cache the metrics if Redis is available
-	if mc.redis != nil {
-		data, _ := json.Marshal(metrics)
-		mc.redis.SetEx(ctx, "business_metrics:current", data, 5*time.Minute)
+	metrics, err := mc.businessSource.BusinessMetrics(ctx, from, to)
+	if err != nil {
+		return nil, err
 	}
+	metrics.From = from
+	metrics.To = to
+	metrics.Timestamp = time.Now()
 
+	if cacheable {
+		if data, err := json.Marshal(metrics); err == nil {
+			mc.redis.SetEx(ctx, cacheKey, data, 5*time.Minute)
+		}
+	}
 	return metrics, nil
 }
 
@@ -547,7 +595,7 @@ func (mc *MetricsCollector) collectPlatformMetrics(ctx context.Context) {
 		}
 
 		// Count WebSocket connections
-		connections, err := mc.redis.SCard(ctx, "websocket_connections").Result()
+		connections, err := mc.redis.SCard(ctx, "{websocket}:connections").Result()
 		if err == nil {
 			mc.systemMetrics.WebSocketConnections.Set(float64(connections))
 		}
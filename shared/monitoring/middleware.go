@@ -0,0 +1,152 @@
+package monitoring
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Handler serves the Prometheus metrics endpoint
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// GinMiddleware records every request in the API request and latency metrics.
+// Requests are labelled by route template rather than raw path to keep
+// cardinality bounded.
+func (mc *MetricsCollector) GinMiddleware(service string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		endpoint := c.FullPath()
+		if endpoint == "" {
+			endpoint = "unmatched"
+		}
+		mc.recordHTTP(service, c.Request.Method, endpoint, c.Writer.Status(), time.Since(start))
+	}
+}
+
+// HTTPMiddleware is GinMiddleware for net/http handlers. Without a router to
+// name the route, requests are labelled by path.
+func (mc *MetricsCollector) HTTPMiddleware(service string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		mc.recordHTTP(service, r.Method, r.URL.Path, recorder.status, time.Since(start))
+	})
+}
+
+func (mc *MetricsCollector) recordHTTP(service, method, endpoint string, code int, duration time.Duration) {
+	mc.RecordAPIRequest(service, method, endpoint, strconv.Itoa(code), duration.Seconds())
+	if code >= http.StatusInternalServerError {
+		mc.systemMetrics.ErrorsTotal.WithLabelValues(service, "http_"+strconv.Itoa(code)).Inc()
+	}
+}
+
+// UnaryServerInterceptor records unary gRPC calls in the API request and
+// latency metrics, labelled with method "grpc" and the gRPC status code
+func (mc *MetricsCollector) UnaryServerInterceptor(service string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		mc.recordGRPC(service, info.FullMethod, err, time.Since(start))
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor records streaming gRPC calls once the stream ends
+func (mc *MetricsCollector) StreamServerInterceptor(service string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		mc.recordGRPC(service, info.FullMethod, err, time.Since(start))
+		return err
+	}
+}
+
+// ServerOptions returns the options that install the gRPC interceptors
+func (mc *MetricsCollector) ServerOptions(service string) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(mc.UnaryServerInterceptor(service)),
+		grpc.ChainStreamInterceptor(mc.StreamServerInterceptor(service)),
+	}
+}
+
+func (mc *MetricsCollector) recordGRPC(service, method string, err error, duration time.Duration) {
+	code := status.Code(err)
+	mc.RecordAPIRequest(service, "grpc", method, code.String(), duration.Seconds())
+	if isServerCode(code) {
+		mc.systemMetrics.ErrorsTotal.WithLabelValues(service, "grpc_"+code.String()).Inc()
+	}
+}
+
+// isServerError reports whether a recorded request status is a server-side
+// failure: an HTTP 5xx or a gRPC code the server is responsible for
+func isServerError(statusLabel string) bool {
+	if code, err := strconv.Atoi(statusLabel); err == nil {
+		return code >= http.StatusInternalServerError
+	}
+	for _, code := range serverCodes {
+		if code.String() == statusLabel {
+			return true
+		}
+	}
+	return false
+}
+
+// serverCodes are the gRPC codes that indicate a server-side failure
+var serverCodes = []codes.Code{
+	codes.Unknown, codes.DeadlineExceeded, codes.Unimplemented,
+	codes.Internal, codes.Unavailable, codes.DataLoss,
+}
+
+func isServerCode(code codes.Code) bool {
+	for _, c := range serverCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+// statusWriter captures the response status for HTTPMiddleware
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(code int) {
+	w.status = code
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *statusWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *statusWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking")
+	}
+	return hijacker.Hijack()
+}
+
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,261 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/shared/i18n"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+const defaultMaxAttempts = 3
+
+// Request asks for a templated notification to be sent to a user
+type Request struct {
+	UserID   string
+	Template string
+	Data     map[string]interface{}
+	// EventID links the deliveries to the platform event that caused them
+	EventID string
+}
+
+// LocaleLookup finds the locale a user chose in their profile. It returns an
+// empty locale for users who chose none.
+type LocaleLookup interface {
+	GetLocale(ctx context.Context, userID string) (string, error)
+}
+
+// Dispatcher renders templates and sends them to users on the channels they
+// have enabled, recording every delivery
+type Dispatcher struct {
+	store       Store
+	providers   map[Channel]Provider
+	templates   map[string]*compiledTemplate
+	maxAttempts int
+	locales     LocaleLookup
+	logger      *logger.Logger
+	mutex       sync.RWMutex
+}
+
+// NewDispatcher creates a dispatcher with the default templates registered
+func NewDispatcher(store Store, logger *logger.Logger) *Dispatcher {
+	d := &Dispatcher{
+		store:       store,
+		providers:   make(map[Channel]Provider),
+		templates:   make(map[string]*compiledTemplate),
+		maxAttempts: defaultMaxAttempts,
+		logger:      logger,
+	}
+	for _, t := range DefaultTemplates() {
+		if err := d.RegisterTemplate(t); err != nil {
+			panic(err)
+		}
+	}
+	return d
+}
+
+// SetMaxAttempts sets how many times a failed send is tried before the delivery is marked failed
+func (d *Dispatcher) SetMaxAttempts(attempts int) {
+	if attempts < 1 {
+		attempts = 1
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.maxAttempts = attempts
+}
+
+// SetLocaleLookup attaches the user-service client used to find the locale
+// each user is notified in. Without it, users are notified in the default
+// locale.
+func (d *Dispatcher) SetLocaleLookup(locales LocaleLookup) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.locales = locales
+}
+
+// RegisterProvider attaches the provider for its channel, replacing any existing one
+func (d *Dispatcher) RegisterProvider(provider Provider) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.providers[provider.Channel()] = provider
+}
+
+// RegisterTemplate adds or replaces a notification template
+func (d *Dispatcher) RegisterTemplate(t *Template) error {
+	compiled, err := compileTemplate(t)
+	if err != nil {
+		return err
+	}
+	for _, channel := range t.Channels {
+		if !IsValidChannel(channel) {
+			return fmt.Errorf("template %s uses unknown channel %s", t.Name, channel)
+		}
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.templates[t.Name] = compiled
+	return nil
+}
+
+// UpdatePreferences saves a user's contact details and enabled channels
+func (d *Dispatcher) UpdatePreferences(ctx context.Context, prefs *Preferences) (*Preferences, error) {
+	if prefs.UserID == "" {
+		return nil, fmt.Errorf("%w: user ID is required", ErrInvalidPreferences)
+	}
+	for _, channel := range prefs.Channels {
+		if !IsValidChannel(channel) {
+			return nil, fmt.Errorf("%w: unknown channel %s", ErrInvalidPreferences, channel)
+		}
+	}
+
+	prefs.UpdatedAt = time.Now()
+	if err := d.store.SavePreferences(ctx, prefs); err != nil {
+		return nil, fmt.Errorf("failed to save notification preferences: %w", err)
+	}
+	return prefs, nil
+}
+
+// GetPreferences returns a user's notification preferences
+func (d *Dispatcher) GetPreferences(ctx context.Context, userID string) (*Preferences, error) {
+	return d.store.GetPreferences(ctx, userID)
+}
+
+// GetDeliveries returns a user's most recent notification deliveries, newest first
+func (d *Dispatcher) GetDeliveries(ctx context.Context, userID string, limit int) ([]*Delivery, error) {
+	return d.store.GetDeliveries(ctx, userID, limit)
+}
+
+// Notify renders the template in the user's locale and sends it on every
+// channel the user allows. Users without saved preferences get the template's
+// default channels. One delivery is recorded per channel, including channels
+// that were skipped.
+func (d *Dispatcher) Notify(ctx context.Context, req *Request) ([]*Delivery, error) {
+	if req.UserID == "" {
+		return nil, fmt.Errorf("user ID is required")
+	}
+
+	d.mutex.RLock()
+	tmpl, exists := d.templates[req.Template]
+	d.mutex.RUnlock()
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrTemplateNotFound, req.Template)
+	}
+
+	prefs, err := d.store.GetPreferences(ctx, req.UserID)
+	if errors.Is(err, ErrPreferencesNotFound) {
+		prefs = &Preferences{UserID: req.UserID, Channels: tmpl.Channels}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+
+	locale := d.locale(ctx, req.UserID)
+	subject, body, err := tmpl.render(locale, req.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	var deliveries []*Delivery
+	for _, channel := range tmpl.Channels {
+		if !prefs.Allows(channel, tmpl.Name) {
+			continue
+		}
+
+		delivery := &Delivery{
+			ID:        generateDeliveryID(),
+			UserID:    req.UserID,
+			Template:  tmpl.Name,
+			Channel:   channel,
+			EventID:   req.EventID,
+			CreatedAt: time.Now(),
+		}
+		d.deliver(ctx, delivery, &Message{
+			UserID:   req.UserID,
+			Channel:  channel,
+			To:       prefs.Address(channel),
+			Template: tmpl.Name,
+			Locale:   locale,
+			Subject:  subject,
+			Body:     body,
+		})
+
+		if err := d.store.SaveDelivery(ctx, delivery); err != nil {
+			return deliveries, fmt.Errorf("failed to save delivery: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, nil
+}
+
+// locale returns the locale of the user's profile. Users whose locale cannot
+// be looked up are notified in the default locale.
+func (d *Dispatcher) locale(ctx context.Context, userID string) string {
+	d.mutex.RLock()
+	locales := d.locales
+	d.mutex.RUnlock()
+	if locales == nil {
+		return i18n.DefaultLocale
+	}
+
+	locale, err := locales.GetLocale(ctx, userID)
+	if err != nil {
+		if d.logger != nil {
+			d.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+				"user_id": userID,
+			}).Warn("Failed to get user locale, notifying in the default locale")
+		}
+		return i18n.DefaultLocale
+	}
+	return i18n.Resolve(locale)
+}
+
+// deliver sends the message, retrying failures, and records the outcome on the delivery
+func (d *Dispatcher) deliver(ctx context.Context, delivery *Delivery, msg *Message) {
+	d.mutex.RLock()
+	provider, exists := d.providers[msg.Channel]
+	maxAttempts := d.maxAttempts
+	d.mutex.RUnlock()
+
+	switch {
+	case !exists:
+		delivery.Status = DeliveryStatusSkipped
+		delivery.Error = fmt.Sprintf("no provider for channel %s", msg.Channel)
+		return
+	case msg.To == "":
+		delivery.Status = DeliveryStatusSkipped
+		delivery.Error = fmt.Sprintf("no %s address for user", msg.Channel)
+		return
+	}
+
+	var err error
+	for delivery.Attempts < maxAttempts {
+		delivery.Attempts++
+		if err = provider.Send(ctx, msg); err == nil {
+			deliveredAt := time.Now()
+			delivery.Status = DeliveryStatusSent
+			delivery.DeliveredAt = &deliveredAt
+			return
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	delivery.Status = DeliveryStatusFailed
+	delivery.Error = err.Error()
+	if d.logger != nil {
+		d.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+			"user_id":  delivery.UserID,
+			"template": delivery.Template,
+			"channel":  delivery.Channel,
+			"attempts": delivery.Attempts,
+		}).Warn("Failed to deliver notification")
+	}
+}
+
+func generateDeliveryID() string {
+	return fmt.Sprintf("notification_%d", time.Now().UnixNano())
+}
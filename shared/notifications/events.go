@@ -0,0 +1,59 @@
+package notifications
+
+import (
+	"context"
+
+	"github.com/rideshare-platform/shared/events"
+)
+
+// EventSubscriber is anything platform events can be subscribed to, such as
+// an events.EventBus or events.EventPublisher
+type EventSubscriber interface {
+	Subscribe(eventType events.EventType, handler events.EventHandler) error
+}
+
+// EventRoute sends a template to the user named by a field of an event's data
+type EventRoute struct {
+	Event          events.EventType
+	RecipientField string
+	Template       string
+}
+
+// DefaultEventRoutes returns the routes from platform events to the default templates
+func DefaultEventRoutes() []EventRoute {
+	return []EventRoute{
+		{Event: events.TripMatchedEvent, RecipientField: "rider_id", Template: TemplateTripMatched},
+		{Event: events.TripMatchedEvent, RecipientField: "driver_id", Template: TemplateTripAssigned},
+		{Event: events.TripDriverArrivedEvent, RecipientField: "rider_id", Template: TemplateDriverArrived},
+		{Event: events.TripReceiptIssuedEvent, RecipientField: "rider_id", Template: TemplateReceiptReady},
+		{Event: events.PaymentFailedEvent, RecipientField: "user_id", Template: TemplatePaymentFailed},
+		{Event: events.VehicleDocumentExpiringEvent, RecipientField: "driver_id", Template: TemplateVehicleDocumentExpiring},
+		{Event: events.VehicleDeactivatedEvent, RecipientField: "driver_id", Template: TemplateVehicleDeactivated},
+		{Event: events.TripLostItemReportedEvent, RecipientField: "driver_id", Template: TemplateLostItemReported},
+	}
+}
+
+// SubscribeEvents notifies users as the routed events are published. Events
+// without a recipient are ignored.
+func (d *Dispatcher) SubscribeEvents(subscriber EventSubscriber, routes []EventRoute) error {
+	for _, route := range routes {
+		route := route
+		err := subscriber.Subscribe(route.Event, func(ctx context.Context, event *events.Event) error {
+			userID, _ := event.Data[route.RecipientField].(string)
+			if userID == "" {
+				return nil
+			}
+			_, err := d.Notify(ctx, &Request{
+				UserID:   userID,
+				Template: route.Template,
+				Data:     event.Data,
+				EventID:  event.ID,
+			})
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
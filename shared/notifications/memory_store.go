@@ -0,0 +1,91 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// MemoryStore implements Store in memory, storing copies so callers cannot
+// mutate saved records
+type MemoryStore struct {
+	preferences map[string][]byte
+	deliveries  map[string][][]byte
+	mutex       sync.RWMutex
+}
+
+// NewMemoryStore creates a new in-memory notification store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		preferences: make(map[string][]byte),
+		deliveries:  make(map[string][][]byte),
+	}
+}
+
+// SavePreferences saves a copy of a user's preferences
+func (m *MemoryStore) SavePreferences(ctx context.Context, prefs *Preferences) error {
+	data, err := json.Marshal(prefs)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification preferences: %w", err)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.preferences[prefs.UserID] = data
+	return nil
+}
+
+// GetPreferences retrieves a copy of a user's preferences
+func (m *MemoryStore) GetPreferences(ctx context.Context, userID string) (*Preferences, error) {
+	m.mutex.RLock()
+	data, exists := m.preferences[userID]
+	m.mutex.RUnlock()
+
+	if !exists {
+		return nil, ErrPreferencesNotFound
+	}
+
+	var prefs Preferences
+	if err := json.Unmarshal(data, &prefs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal notification preferences: %w", err)
+	}
+	return &prefs, nil
+}
+
+// SaveDelivery saves a copy of a delivery record
+func (m *MemoryStore) SaveDelivery(ctx context.Context, delivery *Delivery) error {
+	data, err := json.Marshal(delivery)
+	if err != nil {
+		return fmt.Errorf("failed to marshal delivery: %w", err)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.deliveries[delivery.UserID] = append(m.deliveries[delivery.UserID], data)
+	return nil
+}
+
+// GetDeliveries retrieves copies of a user's most recent deliveries, newest first
+func (m *MemoryStore) GetDeliveries(ctx context.Context, userID string, limit int) ([]*Delivery, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var deliveries []*Delivery
+	for _, data := range m.deliveries[userID] {
+		var delivery Delivery
+		if err := json.Unmarshal(data, &delivery); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal delivery: %w", err)
+		}
+		deliveries = append(deliveries, &delivery)
+	}
+
+	sort.SliceStable(deliveries, func(i, j int) bool {
+		return deliveries[i].CreatedAt.After(deliveries[j].CreatedAt)
+	})
+	if limit > 0 && len(deliveries) > limit {
+		deliveries = deliveries[:limit]
+	}
+	return deliveries, nil
+}
@@ -0,0 +1,132 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Channel is a medium notifications are delivered over
+type Channel string
+
+const (
+	ChannelPush  Channel = "push"
+	ChannelSMS   Channel = "sms"
+	ChannelEmail Channel = "email"
+)
+
+// IsValidChannel reports whether a channel is supported
+func IsValidChannel(channel Channel) bool {
+	switch channel {
+	case ChannelPush, ChannelSMS, ChannelEmail:
+		return true
+	default:
+		return false
+	}
+}
+
+// DeliveryStatus is the outcome of sending a notification on one channel
+type DeliveryStatus string
+
+const (
+	DeliveryStatusSent    DeliveryStatus = "sent"
+	DeliveryStatusFailed  DeliveryStatus = "failed"
+	DeliveryStatusSkipped DeliveryStatus = "skipped"
+)
+
+var (
+	// ErrTemplateNotFound is returned when notifying with an unregistered template
+	ErrTemplateNotFound = errors.New("notification template not found")
+	// ErrPreferencesNotFound is returned when a user has not saved notification preferences
+	ErrPreferencesNotFound = errors.New("notification preferences not found")
+	// ErrInvalidPreferences is returned when preferences name an unknown channel or lack a user
+	ErrInvalidPreferences = errors.New("invalid notification preferences")
+)
+
+// Preferences are a user's contact details and the channels they want to be
+// notified on. Templates listed in OptedOut are never sent to the user.
+type Preferences struct {
+	UserID    string    `json:"user_id"`
+	Email     string    `json:"email,omitempty"`
+	Phone     string    `json:"phone,omitempty"`
+	PushToken string    `json:"push_token,omitempty"`
+	Channels  []Channel `json:"channels"`
+	OptedOut  []string  `json:"opted_out,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Allows reports whether the user wants the template delivered on the channel
+func (p *Preferences) Allows(channel Channel, template string) bool {
+	for _, name := range p.OptedOut {
+		if name == template {
+			return false
+		}
+	}
+	for _, enabled := range p.Channels {
+		if enabled == channel {
+			return true
+		}
+	}
+	return false
+}
+
+// Address returns where the user receives messages on a channel. Push
+// notifications fall back to the user ID, which push providers resolve to
+// the user's devices.
+func (p *Preferences) Address(channel Channel) string {
+	switch channel {
+	case ChannelEmail:
+		return p.Email
+	case ChannelSMS:
+		return p.Phone
+	case ChannelPush:
+		if p.PushToken != "" {
+			return p.PushToken
+		}
+		return p.UserID
+	default:
+		return ""
+	}
+}
+
+// Message is a rendered notification ready to hand to a provider
+type Message struct {
+	UserID   string  `json:"user_id"`
+	Channel  Channel `json:"channel"`
+	To       string  `json:"to"`
+	Template string  `json:"template"`
+	Subject  string  `json:"subject,omitempty"`
+	Body     string  `json:"body"`
+	// Locale is the locale the message was written in
+	Locale string `json:"locale,omitempty"`
+}
+
+// Provider sends messages on one channel, e.g. an SMS gateway or push service
+type Provider interface {
+	Channel() Channel
+	Send(ctx context.Context, msg *Message) error
+}
+
+// Delivery records one attempt to notify a user on one channel
+type Delivery struct {
+	ID          string         `json:"id"`
+	UserID      string         `json:"user_id"`
+	Template    string         `json:"template"`
+	Channel     Channel        `json:"channel"`
+	Status      DeliveryStatus `json:"status"`
+	Attempts    int            `json:"attempts"`
+	Error       string         `json:"error,omitempty"`
+	EventID     string         `json:"event_id,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+	DeliveredAt *time.Time     `json:"delivered_at,omitempty"`
+}
+
+// Store persists notification preferences and delivery records
+type Store interface {
+	SavePreferences(ctx context.Context, prefs *Preferences) error
+	GetPreferences(ctx context.Context, userID string) (*Preferences, error)
+	SaveDelivery(ctx context.Context, delivery *Delivery) error
+	// GetDeliveries returns a user's most recent deliveries, newest first.
+	// A limit of zero or less returns every delivery.
+	GetDeliveries(ctx context.Context, userID string, limit int) ([]*Delivery, error)
+}
@@ -0,0 +1,39 @@
+package notifications
+
+import (
+	"context"
+
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// LogProvider writes messages to the log instead of sending them. It stands
+// in for real push, SMS and email providers in development.
+type LogProvider struct {
+	channel Channel
+	logger  *logger.Logger
+}
+
+// NewLogProvider creates a provider that logs messages sent on a channel
+func NewLogProvider(channel Channel, logger *logger.Logger) *LogProvider {
+	return &LogProvider{
+		channel: channel,
+		logger:  logger,
+	}
+}
+
+// Channel returns the channel the provider handles
+func (p *LogProvider) Channel() Channel {
+	return p.channel
+}
+
+// Send logs the message
+func (p *LogProvider) Send(ctx context.Context, msg *Message) error {
+	p.logger.WithContext(ctx).WithFields(logger.Fields{
+		"channel":  msg.Channel,
+		"user_id":  msg.UserID,
+		"template": msg.Template,
+		"subject":  msg.Subject,
+		"body":     msg.Body,
+	}).Info("Notification sent")
+	return nil
+}
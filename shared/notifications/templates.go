@@ -0,0 +1,193 @@
+package notifications
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/rideshare-platform/shared/i18n"
+)
+
+// Template is a notification that can be sent on one or more channels. Subject
+// and Body are text/template strings rendered with the notification data, in
+// English. Translations are taken from the i18n catalogs, under
+// notification.<name>.subject and notification.<name>.body.
+//
+// Templates can write amounts and times the way the recipient's locale does
+// with {{money .amount .currency}} and {{datetime .time}}; times are
+// time.Time values or RFC 3339 strings.
+type Template struct {
+	Name     string
+	Channels []Channel // channels used when the user has no preferences
+	Subject  string    // email subject and push title
+	Body     string
+}
+
+// Template names used by the default event routes
+const (
+	TemplateTripMatched             = "trip_matched"
+	TemplateTripAssigned            = "trip_assigned"
+	TemplateDriverArrived           = "driver_arrived"
+	TemplateReceiptReady            = "receipt_ready"
+	TemplatePaymentFailed           = "payment_failed"
+	TemplateVehicleDocumentExpiring = "vehicle_document_expiring"
+	TemplateVehicleDeactivated      = "vehicle_deactivated"
+	TemplateLostItemReported        = "lost_item_reported"
+)
+
+// DefaultTemplates returns the platform's built-in notification templates
+func DefaultTemplates() []*Template {
+	return []*Template{
+		{
+			Name:     TemplateTripMatched,
+			Channels: []Channel{ChannelPush, ChannelSMS},
+			Subject:  "Your driver is on the way",
+			Body:     "A driver has accepted your trip.{{with .eta_minutes}} They will arrive in about {{.}} minutes.{{end}}",
+		},
+		{
+			Name:     TemplateTripAssigned,
+			Channels: []Channel{ChannelPush},
+			Subject:  "New trip assigned",
+			Body:     "You have been matched with a rider. Head to the pickup location for trip {{.trip_id}}.",
+		},
+		{
+			Name:     TemplateDriverArrived,
+			Channels: []Channel{ChannelPush, ChannelSMS},
+			Subject:  "Your driver has arrived",
+			Body:     "Your driver is waiting at the pickup location.",
+		},
+		{
+			Name:     TemplateReceiptReady,
+			Channels: []Channel{ChannelPush, ChannelEmail},
+			Subject:  "Your trip receipt",
+			Body:     "Thanks for riding with us. Your receipt for trip {{.trip_id}} is ready{{with .total}}: {{money . $.currency}}{{end}}.",
+		},
+		{
+			Name:     TemplatePaymentFailed,
+			Channels: []Channel{ChannelPush, ChannelEmail},
+			Subject:  "Payment failed",
+			Body:     "We could not charge your payment method for trip {{.trip_id}}. Please update your payment details.",
+		},
+		{
+			Name:     TemplateVehicleDocumentExpiring,
+			Channels: []Channel{ChannelPush, ChannelEmail},
+			Subject:  "Vehicle document expiring",
+			Body:     "The {{.document}} for vehicle {{.license_plate}} expires in {{.days_remaining}} days. Upload a renewed document to keep driving.",
+		},
+		{
+			Name:     TemplateVehicleDeactivated,
+			Channels: []Channel{ChannelPush, ChannelEmail, ChannelSMS},
+			Subject:  "Vehicle deactivated",
+			Body:     "Vehicle {{.license_plate}} has been deactivated{{if eq (print .reason) \"document_expired\"}} because its documents have expired{{end}}. Contact support to reactivate it.",
+		},
+		{
+			Name:     TemplateLostItemReported,
+			Channels: []Channel{ChannelPush, ChannelSMS},
+			Subject:  "A rider left something behind",
+			Body:     "The rider from trip {{.trip_id}} thinks they left {{.description}} in your vehicle. Please check and reply to them in the trip chat{{with .contact_until}} before {{datetime .}}{{end}}.",
+		},
+	}
+}
+
+// compiledTemplate is a template parsed in every supported language
+type compiledTemplate struct {
+	*Template
+	locales map[string]*localizedTemplate
+}
+
+// localizedTemplate is a template's subject and body in one language
+type localizedTemplate struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+func compileTemplate(t *Template) (*compiledTemplate, error) {
+	if t.Name == "" {
+		return nil, fmt.Errorf("template name is required")
+	}
+
+	compiled := &compiledTemplate{Template: t, locales: make(map[string]*localizedTemplate)}
+	for _, locale := range i18n.Supported() {
+		subjectText, ok := i18n.Lookup(locale, "notification."+t.Name+".subject")
+		if !ok {
+			subjectText = t.Subject
+		}
+		bodyText, ok := i18n.Lookup(locale, "notification."+t.Name+".body")
+		if !ok {
+			bodyText = t.Body
+		}
+
+		funcs := templateFuncs(i18n.NewFormatter(locale))
+		subject, err := template.New(t.Name + ".subject").Funcs(funcs).Parse(subjectText)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s subject of template %s: %w", locale, t.Name, err)
+		}
+		body, err := template.New(t.Name + ".body").Funcs(funcs).Parse(bodyText)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s body of template %s: %w", locale, t.Name, err)
+		}
+		compiled.locales[locale] = &localizedTemplate{subject: subject, body: body}
+	}
+	return compiled, nil
+}
+
+// render fills in the template's subject and body in the locale's language,
+// writing amounts and times as the locale does
+func (t *compiledTemplate) render(locale string, data map[string]interface{}) (string, string, error) {
+	localized, exists := t.locales[i18n.Language(locale)]
+	if !exists {
+		localized = t.locales[i18n.DefaultLocale]
+	}
+	funcs := templateFuncs(i18n.NewFormatter(locale))
+
+	var subject, body bytes.Buffer
+	if err := template.Must(localized.subject.Clone()).Funcs(funcs).Execute(&subject, data); err != nil {
+		return "", "", fmt.Errorf("failed to render subject of template %s: %w", t.Name, err)
+	}
+	if err := template.Must(localized.body.Clone()).Funcs(funcs).Execute(&body, data); err != nil {
+		return "", "", fmt.Errorf("failed to render body of template %s: %w", t.Name, err)
+	}
+	return subject.String(), body.String(), nil
+}
+
+// templateFuncs writes amounts and times in templates the formatter's way
+func templateFuncs(formatter *i18n.Formatter) template.FuncMap {
+	return template.FuncMap{
+		"money": func(amount interface{}, code interface{}) string {
+			value, _ := toFloat(amount)
+			currency, _ := code.(string)
+			return formatter.Amount(value, currency)
+		},
+		"datetime": func(value interface{}) string {
+			switch t := value.(type) {
+			case time.Time:
+				return formatter.DateTime(t)
+			case string:
+				if parsed, err := time.Parse(time.RFC3339, t); err == nil {
+					return formatter.DateTime(parsed)
+				}
+				return t
+			default:
+				return fmt.Sprint(value)
+			}
+		},
+	}
+}
+
+// toFloat reads a number from notification data, which may have been
+// decoded from JSON
+func toFloat(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,82 @@
+package pagination
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Page is one page of a list, in the envelope every list API returns
+type Page[T any] struct {
+	Items []T `json:"items"`
+	// NextCursor fetches the following page, empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+	// TotalEstimate is about how many items the whole list holds. Items
+	// added or removed while paging make it drift.
+	TotalEstimate int64 `json:"total_estimate"`
+}
+
+// NewPage builds a page from items read with FetchLimit, so one item more
+// than the page holds tells whether another page follows
+func NewPage[T any](items []T, req Request, key func(T) Key, totalEstimate int64) Page[T] {
+	page := Page[T]{Items: items, TotalEstimate: totalEstimate}
+	if len(items) > req.Limit {
+		page.Items = items[:req.Limit]
+		page.NextCursor = req.Cursor(key(page.Items[req.Limit-1]))
+	}
+	if page.Items == nil {
+		page.Items = []T{}
+	}
+	return page
+}
+
+// Paginate pages through a list held in memory. The items are sorted in
+// place as the request asks.
+func Paginate[T any](items []T, req Request, key func(T) Key) Page[T] {
+	sort.SliceStable(items, func(i, j int) bool {
+		return req.Compare(key(items[i]), key(items[j])) < 0
+	})
+
+	start := 0
+	if req.After != nil {
+		start = sort.Search(len(items), func(i int) bool {
+			return req.Compare(key(items[i]), *req.After) > 0
+		})
+	}
+	end := min(start+req.FetchLimit(), len(items))
+	return NewPage(items[start:end], req, key, int64(len(items)))
+}
+
+// FetchLimit is the number of items to read for a page, one more than it holds
+func (r Request) FetchLimit() int {
+	return r.Limit + 1
+}
+
+// Keyset returns the SQL condition selecting the items after the request's
+// cursor, such as "(created_at, id) < ($3, $4)", and its arguments. The
+// condition is empty on the first page. Column names are not escaped and
+// must come from the service, never from the request.
+func (r Request) Keyset(column, idColumn string, argIndex int) (string, []interface{}) {
+	if r.After == nil {
+		return "", nil
+	}
+	op := ">"
+	if r.Sort.Descending {
+		op = "<"
+	}
+	var value interface{} = r.After.Time
+	if r.Sort.Field.Kind == KindNumber {
+		value = r.After.Number
+	}
+	return fmt.Sprintf("(%s, %s) %s ($%d, $%d)", column, idColumn, op, argIndex, argIndex+1),
+		[]interface{}{value, r.After.ID}
+}
+
+// OrderBy returns the SQL ordering of the request's sort, such as
+// "created_at DESC, id DESC"
+func (r Request) OrderBy(column, idColumn string) string {
+	direction := "ASC"
+	if r.Sort.Descending {
+		direction = "DESC"
+	}
+	return fmt.Sprintf("%s %s, %s %s", column, direction, idColumn, direction)
+}
@@ -0,0 +1,97 @@
+package pagination
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testItem struct {
+	ID        string
+	CreatedAt time.Time
+}
+
+func testItemKey(item testItem) Key {
+	return TimeKey(item.CreatedAt, item.ID)
+}
+
+func TestPaginate_WalksEveryPage(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	items := []testItem{
+		{"trip-1", start},
+		{"trip-2", start.Add(time.Minute)},
+		{"trip-3", start.Add(time.Minute)},
+		{"trip-4", start.Add(2 * time.Minute)},
+		{"trip-5", start.Add(3 * time.Minute)},
+	}
+
+	var seen []string
+	cursor := ""
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 3, "five items take three pages of two")
+		req, err := NewRequest(2, cursor, "-created_at", testOptions)
+		require.NoError(t, err)
+		page := Paginate(items, req, testItemKey)
+		assert.Equal(t, int64(5), page.TotalEstimate)
+		for _, item := range page.Items {
+			seen = append(seen, item.ID)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+	assert.Equal(t, []string{"trip-5", "trip-4", "trip-3", "trip-2", "trip-1"}, seen)
+}
+
+func TestPaginate_EmptyList(t *testing.T) {
+	req, err := NewRequest(0, "", "", testOptions)
+	require.NoError(t, err)
+	page := Paginate([]testItem(nil), req, testItemKey)
+	assert.NotNil(t, page.Items, "an empty page encodes as []")
+	assert.Empty(t, page.Items)
+	assert.Empty(t, page.NextCursor)
+}
+
+func TestRequest_Keyset(t *testing.T) {
+	at := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		req       Request
+		wantWhere string
+		wantArgs  []interface{}
+		wantOrder string
+	}{
+		{
+			name:      "first page",
+			req:       Request{Limit: 10, Sort: Sort{Field: createdAt, Descending: true}},
+			wantOrder: "created_at DESC, id DESC",
+		},
+		{
+			name:      "descending time",
+			req:       Request{Limit: 10, Sort: Sort{Field: createdAt, Descending: true}, After: &Key{Time: at, ID: "trip-1"}},
+			wantWhere: "(created_at, id) < ($3, $4)",
+			wantArgs:  []interface{}{at, "trip-1"},
+			wantOrder: "created_at DESC, id DESC",
+		},
+		{
+			name:      "ascending number",
+			req:       Request{Limit: 10, Sort: Sort{Field: amount}, After: &Key{Number: 12.5, ID: "trip-1"}},
+			wantWhere: "(created_at, id) > ($3, $4)",
+			wantArgs:  []interface{}{12.5, "trip-1"},
+			wantOrder: "created_at ASC, id ASC",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			where, args := tt.req.Keyset("created_at", "id", 3)
+			assert.Equal(t, tt.wantWhere, where)
+			assert.Equal(t, tt.wantArgs, args)
+			assert.Equal(t, tt.wantOrder, tt.req.OrderBy("created_at", "id"))
+			assert.Equal(t, 11, tt.req.FetchLimit())
+		})
+	}
+}
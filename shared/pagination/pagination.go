@@ -0,0 +1,259 @@
+// Package pagination pages through lists with opaque keyset cursors. A cursor
+// holds the sort value and ID of the last item on a page, so the next page
+// starts right after it however many items were added or removed before it,
+// and reading deep pages costs the same as reading the first.
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultLimit is the page size when a request does not ask for one
+	DefaultLimit = 20
+	// MaxLimit caps the page size a request can ask for
+	MaxLimit = 100
+)
+
+var (
+	// ErrInvalidCursor is returned for a cursor that is malformed or was
+	// issued for a different sort
+	ErrInvalidCursor = errors.New("invalid cursor")
+	// ErrInvalidSort is returned when sorting on a field the list does not offer
+	ErrInvalidSort = errors.New("invalid sort")
+	// ErrInvalidLimit is returned for a page size that is not a positive number
+	ErrInvalidLimit = errors.New("invalid limit")
+)
+
+// Kind is the type of the values a sort field holds
+type Kind int
+
+const (
+	// KindTime fields hold timestamps
+	KindTime Kind = iota
+	// KindNumber fields hold numbers
+	KindNumber
+)
+
+// Field is a field a list can be sorted on
+type Field struct {
+	Name string
+	Kind Kind
+}
+
+// Sort orders a list by a field. Ties are broken by ID in the same direction.
+type Sort struct {
+	Field      Field
+	Descending bool
+}
+
+// String returns the sort as it is written in requests, the field name with
+// a leading "-" when descending
+func (s Sort) String() string {
+	if s.Descending {
+		return "-" + s.Field.Name
+	}
+	return s.Field.Name
+}
+
+// Options describe how a list can be paged
+type Options struct {
+	// Fields are the fields the list can be sorted on
+	Fields []Field
+	// Default is the sort used when a request does not ask for one
+	Default Sort
+	// DefaultLimit is the page size when a request does not ask for one,
+	// DefaultLimit when zero
+	DefaultLimit int
+	// MaxLimit caps the page size, MaxLimit when zero
+	MaxLimit int
+}
+
+// Key is the position of an item in a sorted list: the value of the sort
+// field and the item's ID
+type Key struct {
+	Time   time.Time
+	Number float64
+	ID     string
+}
+
+// TimeKey returns the key of an item sorted on a timestamp
+func TimeKey(t time.Time, id string) Key {
+	return Key{Time: t, ID: id}
+}
+
+// NumberKey returns the key of an item sorted on a number
+func NumberKey(n float64, id string) Key {
+	return Key{Number: n, ID: id}
+}
+
+// Request asks for one page of a list
+type Request struct {
+	Limit int
+	Sort  Sort
+	// After is the key of the last item of the previous page, nil for the first page
+	After *Key
+}
+
+// NewRequest builds a request from a page size, a cursor from a previous
+// page and a sort such as "-created_at". Empty values take the defaults.
+func NewRequest(limit int, cursor, sort string, options Options) (Request, error) {
+	if limit < 0 {
+		return Request{}, fmt.Errorf("%w: %d", ErrInvalidLimit, limit)
+	}
+
+	req := options.WithDefaults(Request{Limit: limit})
+	if sort != "" {
+		parsed, err := parseSort(sort, options.Fields)
+		if err != nil {
+			return Request{}, err
+		}
+		req.Sort = parsed
+	}
+	if cursor != "" {
+		after, err := decodeCursor(cursor, req.Sort)
+		if err != nil {
+			return Request{}, err
+		}
+		req.After = after
+	}
+	return req, nil
+}
+
+// WithDefaults fills in the page size and sort a request built in code
+// leaves out, and caps its page size
+func (o Options) WithDefaults(r Request) Request {
+	maxLimit := o.MaxLimit
+	if maxLimit <= 0 {
+		maxLimit = MaxLimit
+	}
+	if r.Limit <= 0 {
+		r.Limit = o.DefaultLimit
+		if r.Limit <= 0 {
+			r.Limit = DefaultLimit
+		}
+	}
+	r.Limit = min(r.Limit, maxLimit)
+	if r.Sort.Field.Name == "" {
+		r.Sort = o.Default
+	}
+	return r
+}
+
+// FromQuery builds a request from the limit, cursor and sort query parameters
+func FromQuery(query url.Values, options Options) (Request, error) {
+	limit := 0
+	if value := query.Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return Request{}, fmt.Errorf("%w: %q", ErrInvalidLimit, value)
+		}
+		limit = parsed
+	}
+	return NewRequest(limit, query.Get("cursor"), query.Get("sort"), options)
+}
+
+// IsInvalid reports whether err is a malformed pagination request
+func IsInvalid(err error) bool {
+	return errors.Is(err, ErrInvalidCursor) || errors.Is(err, ErrInvalidSort) || errors.Is(err, ErrInvalidLimit)
+}
+
+// Compare orders two keys as the request sorts them, returning a negative
+// number when a comes first. Times are compared to the microsecond, the
+// precision PostgreSQL stores them at.
+func (r Request) Compare(a, b Key) int {
+	c := 0
+	switch r.Sort.Field.Kind {
+	case KindTime:
+		at, bt := a.Time.Truncate(time.Microsecond), b.Time.Truncate(time.Microsecond)
+		c = at.Compare(bt)
+	case KindNumber:
+		switch {
+		case a.Number < b.Number:
+			c = -1
+		case a.Number > b.Number:
+			c = 1
+		}
+	}
+	if c == 0 {
+		c = strings.Compare(a.ID, b.ID)
+	}
+	if r.Sort.Descending {
+		return -c
+	}
+	return c
+}
+
+// Cursor returns the opaque cursor of the page following the item with key
+func (r Request) Cursor(key Key) string {
+	payload := cursorPayload{Sort: r.Sort.String(), ID: key.ID}
+	switch r.Sort.Field.Kind {
+	case KindTime:
+		at := key.Time.UTC()
+		payload.Time = &at
+	case KindNumber:
+		number := key.Number
+		payload.Number = &number
+	}
+	data, _ := json.Marshal(payload)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// cursorPayload is what a cursor encodes. The sort is kept so a cursor
+// cannot be replayed against a differently ordered list.
+type cursorPayload struct {
+	Sort   string     `json:"s"`
+	Time   *time.Time `json:"t,omitempty"`
+	Number *float64   `json:"n,omitempty"`
+	ID     string     `json:"id"`
+}
+
+func decodeCursor(cursor string, sort Sort) (*Key, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var payload cursorPayload
+	if err := json.Unmarshal(data, &payload); err != nil || payload.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+	if payload.Sort != sort.String() {
+		return nil, fmt.Errorf("%w: issued for sort %s, not %s", ErrInvalidCursor, payload.Sort, sort)
+	}
+
+	key := &Key{ID: payload.ID}
+	switch sort.Field.Kind {
+	case KindTime:
+		if payload.Time == nil {
+			return nil, ErrInvalidCursor
+		}
+		key.Time = *payload.Time
+	case KindNumber:
+		if payload.Number == nil {
+			return nil, ErrInvalidCursor
+		}
+		key.Number = *payload.Number
+	}
+	return key, nil
+}
+
+func parseSort(value string, fields []Field) (Sort, error) {
+	name, descending := strings.CutPrefix(value, "-")
+	for _, field := range fields {
+		if field.Name == name {
+			return Sort{Field: field, Descending: descending}, nil
+		}
+	}
+	names := make([]string, 0, len(fields))
+	for _, field := range fields {
+		names = append(names, field.Name)
+	}
+	return Sort{}, fmt.Errorf("%w: cannot sort on %q, sort on one of %s", ErrInvalidSort, name, strings.Join(names, ", "))
+}
@@ -0,0 +1,142 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	createdAt = Field{Name: "created_at", Kind: KindTime}
+	amount    = Field{Name: "amount", Kind: KindNumber}
+
+	testOptions = Options{
+		Fields:  []Field{createdAt, amount},
+		Default: Sort{Field: createdAt, Descending: true},
+	}
+)
+
+func TestNewRequest_Defaults(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     int
+		sort      string
+		options   Options
+		wantLimit int
+		wantSort  string
+		wantErr   error
+	}{
+		{"defaults", 0, "", testOptions, DefaultLimit, "-created_at", nil},
+		{"list default limit", 0, "", Options{Default: testOptions.Default, DefaultLimit: 50}, 50, "-created_at", nil},
+		{"limit is capped", 500, "", testOptions, MaxLimit, "-created_at", nil},
+		{"list max limit", 30, "", Options{Default: testOptions.Default, MaxLimit: 25}, 25, "-created_at", nil},
+		{"ascending sort", 10, "amount", testOptions, 10, "amount", nil},
+		{"descending sort", 10, "-amount", testOptions, 10, "-amount", nil},
+		{"unknown sort field", 10, "rider_id", testOptions, 0, "", ErrInvalidSort},
+		{"negative limit", -1, "", testOptions, 0, "", ErrInvalidLimit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := NewRequest(tt.limit, "", tt.sort, tt.options)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.True(t, IsInvalid(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantLimit, req.Limit)
+			assert.Equal(t, tt.wantSort, req.Sort.String())
+			assert.Nil(t, req.After)
+		})
+	}
+}
+
+func TestCursor_RoundTrip(t *testing.T) {
+	at := time.Date(2024, 3, 1, 12, 30, 0, 123456789, time.FixedZone("TRT", 3*60*60))
+
+	tests := []struct {
+		name string
+		sort string
+		key  Key
+		want Key
+	}{
+		{"time", "-created_at", TimeKey(at, "trip-1"), TimeKey(at.UTC(), "trip-1")},
+		{"ascending time", "created_at", TimeKey(at, "trip-2"), TimeKey(at.UTC(), "trip-2")},
+		{"number", "-amount", NumberKey(42.5, "trip-3"), NumberKey(42.5, "trip-3")},
+		{"zero number", "amount", NumberKey(0, "trip-4"), NumberKey(0, "trip-4")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, err := NewRequest(10, "", tt.sort, testOptions)
+			require.NoError(t, err)
+			cursor := first.Cursor(tt.key)
+
+			next, err := NewRequest(10, cursor, tt.sort, testOptions)
+			require.NoError(t, err)
+			require.NotNil(t, next.After)
+			assert.True(t, tt.want.Time.Equal(next.After.Time))
+			assert.Equal(t, tt.want.Number, next.After.Number)
+			assert.Equal(t, tt.want.ID, next.After.ID)
+		})
+	}
+}
+
+func TestCursor_Rejected(t *testing.T) {
+	byTime, err := NewRequest(10, "", "-created_at", testOptions)
+	require.NoError(t, err)
+	cursor := byTime.Cursor(TimeKey(time.Now(), "trip-1"))
+	encode := func(payload string) string {
+		return base64.RawURLEncoding.EncodeToString([]byte(payload))
+	}
+
+	tests := []struct {
+		name   string
+		cursor string
+		sort   string
+	}{
+		{"not base64", "!!not-a-cursor!!", "-created_at"},
+		{"not JSON", encode("trip-1"), "-created_at"},
+		{"tampered", cursor[:len(cursor)-4] + "AAAA", "-created_at"},
+		{"no ID", encode(`{"s":"-created_at","t":"2024-03-01T12:00:00Z"}`), "-created_at"},
+		{"no sort value", encode(`{"s":"-created_at","id":"trip-1"}`), "-created_at"},
+		{"number for a time sort", encode(`{"s":"-created_at","n":5,"id":"trip-1"}`), "-created_at"},
+		{"other direction", cursor, "created_at"},
+		{"other field", cursor, "-amount"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRequest(10, tt.cursor, tt.sort, testOptions)
+			assert.ErrorIs(t, err, ErrInvalidCursor)
+			assert.True(t, IsInvalid(err))
+		})
+	}
+}
+
+func TestFromQuery(t *testing.T) {
+	req, err := FromQuery(url.Values{"limit": {"5"}, "sort": {"amount"}}, testOptions)
+	require.NoError(t, err)
+	assert.Equal(t, 5, req.Limit)
+	assert.Equal(t, "amount", req.Sort.String())
+
+	_, err = FromQuery(url.Values{"limit": {"ten"}}, testOptions)
+	assert.ErrorIs(t, err, ErrInvalidLimit)
+}
+
+func TestRequest_Compare(t *testing.T) {
+	at := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	ascending := Request{Sort: Sort{Field: createdAt}}
+	descending := Request{Sort: Sort{Field: createdAt, Descending: true}}
+
+	assert.Negative(t, ascending.Compare(TimeKey(at, "b"), TimeKey(at.Add(time.Second), "a")))
+	assert.Positive(t, descending.Compare(TimeKey(at, "b"), TimeKey(at.Add(time.Second), "a")))
+	assert.Negative(t, ascending.Compare(TimeKey(at, "a"), TimeKey(at, "b")), "ties are broken by ID")
+	assert.Positive(t, descending.Compare(TimeKey(at, "a"), TimeKey(at, "b")), "ties are broken in the sort's direction")
+	assert.Zero(t, ascending.Compare(TimeKey(at, "a"), TimeKey(at.Add(time.Nanosecond), "a")), "times compare to the microsecond")
+
+	byAmount := Request{Sort: Sort{Field: amount}}
+	assert.Negative(t, byAmount.Compare(NumberKey(1, "b"), NumberKey(2, "a")))
+}
@@ -391,6 +391,8 @@ type NearbyDriversRequest struct {
 	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
 	VehicleTypes  []string               `protobuf:"bytes,4,rep,name=vehicle_types,json=vehicleTypes,proto3" json:"vehicle_types,omitempty"`
 	OnlyAvailable bool                   `protobuf:"varint,5,opt,name=only_available,json=onlyAvailable,proto3" json:"only_available,omitempty"`
+	// Only drivers in this city are returned; resolved from the center when unset
+	CityId        string `protobuf:"bytes,6,opt,name=city_id,json=cityId,proto3" json:"city_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -460,6 +462,13 @@ func (x *NearbyDriversRequest) GetOnlyAvailable() bool {
 	return false
 }
 
+func (x *NearbyDriversRequest) GetCityId() string {
+	if x != nil {
+		return x.CityId
+	}
+	return ""
+}
+
 // Driver location information
 type DriverLocation struct {
 	state              protoimpl.MessageState `protogen:"open.v1"`
@@ -470,8 +479,13 @@ type DriverLocation struct {
 	Status             string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"` // "online", "busy", "offline"
 	VehicleType        string                 `protobuf:"bytes,6,opt,name=vehicle_type,json=vehicleType,proto3" json:"vehicle_type,omitempty"`
 	Rating             float64                `protobuf:"fixed64,7,opt,name=rating,proto3" json:"rating,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+	CityId             string                 `protobuf:"bytes,8,opt,name=city_id,json=cityId,proto3" json:"city_id,omitempty"`
+	// Features of the vehicle the driver is on, as last reported
+	VehicleFeatures []string `protobuf:"bytes,9,rep,name=vehicle_features,json=vehicleFeatures,proto3" json:"vehicle_features,omitempty"`
+	// When the driver was last known to be at the location
+	LastSeenAt    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DriverLocation) Reset() {
@@ -553,6 +567,27 @@ func (x *DriverLocation) GetRating() float64 {
 	return 0
 }
 
+func (x *DriverLocation) GetCityId() string {
+	if x != nil {
+		return x.CityId
+	}
+	return ""
+}
+
+func (x *DriverLocation) GetVehicleFeatures() []string {
+	if x != nil {
+		return x.VehicleFeatures
+	}
+	return nil
+}
+
+func (x *DriverLocation) GetLastSeenAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeenAt
+	}
+	return nil
+}
+
 // Nearby drivers response
 type NearbyDriversResponse struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
@@ -616,13 +651,17 @@ func (x *NearbyDriversResponse) GetSearchRadiusKm() float64 {
 
 // Update driver location request
 type UpdateDriverLocationRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	DriverId      string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
-	Location      *Location              `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
-	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
-	VehicleId     string                 `protobuf:"bytes,4,opt,name=vehicle_id,json=vehicleId,proto3" json:"vehicle_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	DriverId  string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	Location  *Location              `protobuf:"bytes,2,opt,name=location,proto3" json:"location,omitempty"`
+	Status    string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	VehicleId string                 `protobuf:"bytes,4,opt,name=vehicle_id,json=vehicleId,proto3" json:"vehicle_id,omitempty"`
+	// Optional; resolved from the location when unset
+	CityId string `protobuf:"bytes,5,opt,name=city_id,json=cityId,proto3" json:"city_id,omitempty"`
+	// Features of the vehicle, e.g. "wheelchair_accessible"
+	VehicleFeatures []string `protobuf:"bytes,6,rep,name=vehicle_features,json=vehicleFeatures,proto3" json:"vehicle_features,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *UpdateDriverLocationRequest) Reset() {
@@ -683,6 +722,20 @@ func (x *UpdateDriverLocationRequest) GetVehicleId() string {
 	return ""
 }
 
+func (x *UpdateDriverLocationRequest) GetCityId() string {
+	if x != nil {
+		return x.CityId
+	}
+	return ""
+}
+
+func (x *UpdateDriverLocationRequest) GetVehicleFeatures() []string {
+	if x != nil {
+		return x.VehicleFeatures
+	}
+	return nil
+}
+
 // Update driver location response
 type UpdateDriverLocationResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1288,116 +1341,1765 @@ func (x *StartLocationTrackingResponse) GetMessage() string {
 	return ""
 }
 
-var File_shared_proto_geo_geo_proto protoreflect.FileDescriptor
+// Zone is a polygonal area such as an airport, event venue or restricted area
+type Zone struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name             string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type             string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"` // "airport", "event_venue", "restricted"
+	Surcharge        float64                `protobuf:"fixed64,4,opt,name=surcharge,proto3" json:"surcharge,omitempty"`
+	PickupRestricted bool                   `protobuf:"varint,5,opt,name=pickup_restricted,json=pickupRestricted,proto3" json:"pickup_restricted,omitempty"`
+	DispatchQueue    bool                   `protobuf:"varint,6,opt,name=dispatch_queue,json=dispatchQueue,proto3" json:"dispatch_queue,omitempty"` // Pickups inside go to the drivers queued in the zone in turn
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
 
-const file_shared_proto_geo_geo_proto_rawDesc = "" +
-	"\n" +
-	"\x1ashared/proto/geo/geo.proto\x12\x03geo\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb4\x01\n" +
-	"\bLocation\x12\x1a\n" +
-	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
-	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\x12\x1a\n" +
-	"\baccuracy\x18\x03 \x01(\x01R\baccuracy\x128\n" +
-	"\ttimestamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x18\n" +
-	"\aaddress\x18\x05 \x01(\tR\aaddress\"\x98\x01\n" +
-	"\x0fDistanceRequest\x12%\n" +
-	"\x06origin\x18\x01 \x01(\v2\r.geo.LocationR\x06origin\x12/\n" +
-	"\vdestination\x18\x02 \x01(\v2\r.geo.LocationR\vdestination\x12-\n" +
-	"\x12calculation_method\x18\x03 \x01(\tR\x11calculationMethod\"\xb4\x01\n" +
-	"\x10DistanceResponse\x12'\n" +
-	"\x0fdistance_meters\x18\x01 \x01(\x01R\x0edistanceMeters\x12\x1f\n" +
-	"\vdistance_km\x18\x02 \x01(\x01R\n" +
-	"distanceKm\x12'\n" +
-	"\x0fbearing_degrees\x18\x03 \x01(\x01R\x0ebearingDegrees\x12-\n" +
-	"\x12calculation_method\x18\x04 \x01(\tR\x11calculationMethod\"\xf3\x01\n" +
-	"\n" +
-	"ETARequest\x12%\n" +
-	"\x06origin\x18\x01 \x01(\v2\r.geo.LocationR\x06origin\x12/\n" +
-	"\vdestination\x18\x02 \x01(\v2\r.geo.LocationR\vdestination\x12!\n" +
-	"\fvehicle_type\x18\x03 \x01(\tR\vvehicleType\x12A\n" +
-	"\x0edeparture_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\rdepartureTime\x12'\n" +
-	"\x0finclude_traffic\x18\x05 \x01(\bR\x0eincludeTraffic\"\xfc\x01\n" +
-	"\vETAResponse\x12)\n" +
-	"\x10duration_seconds\x18\x01 \x01(\x05R\x0fdurationSeconds\x12'\n" +
-	"\x0fdistance_meters\x18\x02 \x01(\x01R\x0edistanceMeters\x12#\n" +
-	"\rroute_summary\x18\x03 \x01(\tR\frouteSummary\x12+\n" +
-	"\twaypoints\x18\x04 \x03(\v2\r.geo.LocationR\twaypoints\x12G\n" +
-	"\x11estimated_arrival\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x10estimatedArrival\"\xbc\x01\n" +
-	"\x14NearbyDriversRequest\x12%\n" +
-	"\x06center\x18\x01 \x01(\v2\r.geo.LocationR\x06center\x12\x1b\n" +
-	"\tradius_km\x18\x02 \x01(\x01R\bradiusKm\x12\x14\n" +
-	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12#\n" +
-	"\rvehicle_types\x18\x04 \x03(\tR\fvehicleTypes\x12%\n" +
-	"\x0eonly_available\x18\x05 \x01(\bR\ronlyAvailable\"\xfc\x01\n" +
-	"\x0eDriverLocation\x12\x1b\n" +
-	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12\x1d\n" +
-	"\n" +
-	"vehicle_id\x18\x02 \x01(\tR\tvehicleId\x12)\n" +
-	"\blocation\x18\x03 \x01(\v2\r.geo.LocationR\blocation\x120\n" +
-	"\x14distance_from_center\x18\x04 \x01(\x01R\x12distanceFromCenter\x12\x16\n" +
-	"\x06status\x18\x05 \x01(\tR\x06status\x12!\n" +
-	"\fvehicle_type\x18\x06 \x01(\tR\vvehicleType\x12\x16\n" +
-	"\x06rating\x18\a \x01(\x01R\x06rating\"\x91\x01\n" +
-	"\x15NearbyDriversResponse\x12-\n" +
-	"\adrivers\x18\x01 \x03(\v2\x13.geo.DriverLocationR\adrivers\x12\x1f\n" +
-	"\vtotal_count\x18\x02 \x01(\x05R\n" +
-	"totalCount\x12(\n" +
-	"\x10search_radius_km\x18\x03 \x01(\x01R\x0esearchRadiusKm\"\x9c\x01\n" +
-	"\x1bUpdateDriverLocationRequest\x12\x1b\n" +
-	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12)\n" +
-	"\blocation\x18\x02 \x01(\v2\r.geo.LocationR\blocation\x12\x16\n" +
-	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1d\n" +
-	"\n" +
-	"vehicle_id\x18\x04 \x01(\tR\tvehicleId\"\x8d\x01\n" +
-	"\x1cUpdateDriverLocationResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\x129\n" +
-	"\n" +
-	"updated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"Y\n" +
-	"\x0eGeohashRequest\x12)\n" +
-	"\blocation\x18\x01 \x01(\v2\r.geo.LocationR\blocation\x12\x1c\n" +
-	"\tprecision\x18\x02 \x01(\x05R\tprecision\"\x9a\x01\n" +
-	"\x0fGeohashResponse\x12\x18\n" +
-	"\ageohash\x18\x01 \x01(\tR\ageohash\x12%\n" +
-	"\x06center\x18\x02 \x01(\v2\r.geo.LocationR\x06center\x12!\n" +
-	"\fwidth_meters\x18\x03 \x01(\x01R\vwidthMeters\x12#\n" +
-	"\rheight_meters\x18\x04 \x01(\x01R\fheightMeters\"\xdd\x01\n" +
-	"\x18RouteOptimizationRequest\x12#\n" +
-	"\x05start\x18\x01 \x01(\v2\r.geo.LocationR\x05start\x12+\n" +
-	"\twaypoints\x18\x02 \x03(\v2\r.geo.LocationR\twaypoints\x12\x1f\n" +
-	"\x03end\x18\x03 \x01(\v2\r.geo.LocationR\x03end\x12+\n" +
-	"\x11optimization_type\x18\x04 \x01(\tR\x10optimizationType\x12!\n" +
-	"\fvehicle_type\x18\x05 \x01(\tR\vvehicleType\"\xf4\x01\n" +
-	"\x19RouteOptimizationResponse\x126\n" +
-	"\x0foptimized_route\x18\x01 \x03(\v2\r.geo.LocationR\x0eoptimizedRoute\x12*\n" +
-	"\x11total_distance_km\x18\x02 \x01(\x01R\x0ftotalDistanceKm\x12<\n" +
-	"\x1aestimated_duration_seconds\x18\x03 \x01(\x05R\x18estimatedDurationSeconds\x125\n" +
-	"\x16optimization_algorithm\x18\x04 \x01(\tR\x15optimizationAlgorithm\"w\n" +
-	" SubscribeToDriverLocationRequest\x12\x17\n" +
-	"\aarea_id\x18\x01 \x01(\tR\x06areaId\x12\x1b\n" +
-	"\tradius_km\x18\x02 \x01(\x01R\bradiusKm\x12\x1d\n" +
-	"\n" +
-	"driver_ids\x18\x03 \x03(\tR\tdriverIds\"\x86\x03\n" +
-	"\x13DriverLocationEvent\x12\x1b\n" +
-	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12)\n" +
-	"\blocation\x18\x02 \x01(\v2\r.geo.LocationR\blocation\x12\x16\n" +
-	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1d\n" +
-	"\n" +
-	"vehicle_id\x18\x04 \x01(\tR\tvehicleId\x12\x1b\n" +
-	"\tspeed_kmh\x18\x05 \x01(\x01R\bspeedKmh\x12\x18\n" +
-	"\aheading\x18\x06 \x01(\x01R\aheading\x128\n" +
-	"\ttimestamp\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12B\n" +
-	"\bmetadata\x18\b \x03(\v2&.geo.DriverLocationEvent.MetadataEntryR\bmetadata\x1a;\n" +
-	"\rMetadataEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"s\n" +
-	"\x1cStartLocationTrackingRequest\x12\x1b\n" +
-	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x126\n" +
-	"\x17update_interval_seconds\x18\x02 \x01(\x05R\x15updateIntervalSeconds\"r\n" +
-	"\x1dStartLocationTrackingResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1d\n" +
-	"\n" +
-	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage2\x80\x05\n" +
+func (x *Zone) Reset() {
+	*x = Zone{}
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Zone) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Zone) ProtoMessage() {}
+
+func (x *Zone) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Zone.ProtoReflect.Descriptor instead.
+func (*Zone) Descriptor() ([]byte, []int) {
+	return file_shared_proto_geo_geo_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Zone) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Zone) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Zone) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Zone) GetSurcharge() float64 {
+	if x != nil {
+		return x.Surcharge
+	}
+	return 0
+}
+
+func (x *Zone) GetPickupRestricted() bool {
+	if x != nil {
+		return x.PickupRestricted
+	}
+	return false
+}
+
+func (x *Zone) GetDispatchQueue() bool {
+	if x != nil {
+		return x.DispatchQueue
+	}
+	return false
+}
+
+// Point-in-zone lookup request
+type FindZonesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Location      *Location              `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FindZonesRequest) Reset() {
+	*x = FindZonesRequest{}
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindZonesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindZonesRequest) ProtoMessage() {}
+
+func (x *FindZonesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindZonesRequest.ProtoReflect.Descriptor instead.
+func (*FindZonesRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_geo_geo_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *FindZonesRequest) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+// Point-in-zone lookup response
+type FindZonesResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Zones            []*Zone                `protobuf:"bytes,1,rep,name=zones,proto3" json:"zones,omitempty"`
+	Surcharge        float64                `protobuf:"fixed64,2,opt,name=surcharge,proto3" json:"surcharge,omitempty"` // Highest surcharge of the zones, overlapping zones do not stack
+	PickupRestricted bool                   `protobuf:"varint,3,opt,name=pickup_restricted,json=pickupRestricted,proto3" json:"pickup_restricted,omitempty"`
+	QueueZoneId      string                 `protobuf:"bytes,4,opt,name=queue_zone_id,json=queueZoneId,proto3" json:"queue_zone_id,omitempty"` // Dispatch zone pickups at the location are served from
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *FindZonesResponse) Reset() {
+	*x = FindZonesResponse{}
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FindZonesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FindZonesResponse) ProtoMessage() {}
+
+func (x *FindZonesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FindZonesResponse.ProtoReflect.Descriptor instead.
+func (*FindZonesResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_geo_geo_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *FindZonesResponse) GetZones() []*Zone {
+	if x != nil {
+		return x.Zones
+	}
+	return nil
+}
+
+func (x *FindZonesResponse) GetSurcharge() float64 {
+	if x != nil {
+		return x.Surcharge
+	}
+	return 0
+}
+
+func (x *FindZonesResponse) GetPickupRestricted() bool {
+	if x != nil {
+		return x.PickupRestricted
+	}
+	return false
+}
+
+func (x *FindZonesResponse) GetQueueZoneId() string {
+	if x != nil {
+		return x.QueueZoneId
+	}
+	return ""
+}
+
+// Dispatch zone queue request
+type GetZoneQueueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ZoneId        string                 `protobuf:"bytes,1,opt,name=zone_id,json=zoneId,proto3" json:"zone_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // Drivers read from the head of the queue, up to 100
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetZoneQueueRequest) Reset() {
+	*x = GetZoneQueueRequest{}
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetZoneQueueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetZoneQueueRequest) ProtoMessage() {}
+
+func (x *GetZoneQueueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetZoneQueueRequest.ProtoReflect.Descriptor instead.
+func (*GetZoneQueueRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_geo_geo_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetZoneQueueRequest) GetZoneId() string {
+	if x != nil {
+		return x.ZoneId
+	}
+	return ""
+}
+
+func (x *GetZoneQueueRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// QueuedDriver is a driver's place in a dispatch zone's queue
+type QueuedDriver struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverId      string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	Position      int32                  `protobuf:"varint,2,opt,name=position,proto3" json:"position,omitempty"` // Counts from 1 at the head of the queue
+	JoinedAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=joined_at,json=joinedAt,proto3" json:"joined_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueuedDriver) Reset() {
+	*x = QueuedDriver{}
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueuedDriver) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueuedDriver) ProtoMessage() {}
+
+func (x *QueuedDriver) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueuedDriver.ProtoReflect.Descriptor instead.
+func (*QueuedDriver) Descriptor() ([]byte, []int) {
+	return file_shared_proto_geo_geo_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *QueuedDriver) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *QueuedDriver) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *QueuedDriver) GetJoinedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.JoinedAt
+	}
+	return nil
+}
+
+// Dispatch zone queue response, head of the queue first
+type GetZoneQueueResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ZoneId        string                 `protobuf:"bytes,1,opt,name=zone_id,json=zoneId,proto3" json:"zone_id,omitempty"`
+	Drivers       []*QueuedDriver        `protobuf:"bytes,2,rep,name=drivers,proto3" json:"drivers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetZoneQueueResponse) Reset() {
+	*x = GetZoneQueueResponse{}
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetZoneQueueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetZoneQueueResponse) ProtoMessage() {}
+
+func (x *GetZoneQueueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetZoneQueueResponse.ProtoReflect.Descriptor instead.
+func (*GetZoneQueueResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_geo_geo_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetZoneQueueResponse) GetZoneId() string {
+	if x != nil {
+		return x.ZoneId
+	}
+	return ""
+}
+
+func (x *GetZoneQueueResponse) GetDrivers() []*QueuedDriver {
+	if x != nil {
+		return x.Drivers
+	}
+	return nil
+}
+
+// Recorded trip route request
+type GetTripRouteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TripId        string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	MapMatch      bool                   `protobuf:"varint,2,opt,name=map_match,json=mapMatch,proto3" json:"map_match,omitempty"` // Clean up GPS noise before measuring the distance
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTripRouteRequest) Reset() {
+	*x = GetTripRouteRequest{}
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTripRouteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTripRouteRequest) ProtoMessage() {}
+
+func (x *GetTripRouteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTripRouteRequest.ProtoReflect.Descriptor instead.
+func (*GetTripRouteRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_geo_geo_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetTripRouteRequest) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *GetTripRouteRequest) GetMapMatch() bool {
+	if x != nil {
+		return x.MapMatch
+	}
+	return false
+}
+
+// Recorded trip route response
+type GetTripRouteResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TripId          string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	Points          []*Location            `protobuf:"bytes,2,rep,name=points,proto3" json:"points,omitempty"`
+	DistanceKm      float64                `protobuf:"fixed64,3,opt,name=distance_km,json=distanceKm,proto3" json:"distance_km,omitempty"`
+	DurationSeconds int32                  `protobuf:"varint,4,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+	RawPointCount   int32                  `protobuf:"varint,5,opt,name=raw_point_count,json=rawPointCount,proto3" json:"raw_point_count,omitempty"` // Points recorded before map matching dropped any
+	Matched         bool                   `protobuf:"varint,6,opt,name=matched,proto3" json:"matched,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetTripRouteResponse) Reset() {
+	*x = GetTripRouteResponse{}
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTripRouteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTripRouteResponse) ProtoMessage() {}
+
+func (x *GetTripRouteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTripRouteResponse.ProtoReflect.Descriptor instead.
+func (*GetTripRouteResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_geo_geo_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetTripRouteResponse) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *GetTripRouteResponse) GetPoints() []*Location {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+func (x *GetTripRouteResponse) GetDistanceKm() float64 {
+	if x != nil {
+		return x.DistanceKm
+	}
+	return 0
+}
+
+func (x *GetTripRouteResponse) GetDurationSeconds() int32 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *GetTripRouteResponse) GetRawPointCount() int32 {
+	if x != nil {
+		return x.RawPointCount
+	}
+	return 0
+}
+
+func (x *GetTripRouteResponse) GetMatched() bool {
+	if x != nil {
+		return x.Matched
+	}
+	return false
+}
+
+// Erase recorded trip routes request, for data subject erasure requests
+type DeleteTripRoutesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TripIds       []string               `protobuf:"bytes,1,rep,name=trip_ids,json=tripIds,proto3" json:"trip_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTripRoutesRequest) Reset() {
+	*x = DeleteTripRoutesRequest{}
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTripRoutesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTripRoutesRequest) ProtoMessage() {}
+
+func (x *DeleteTripRoutesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTripRoutesRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTripRoutesRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_geo_geo_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *DeleteTripRoutesRequest) GetTripIds() []string {
+	if x != nil {
+		return x.TripIds
+	}
+	return nil
+}
+
+// Erase recorded trip routes response
+type DeleteTripRoutesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeletedPoints int64                  `protobuf:"varint,1,opt,name=deleted_points,json=deletedPoints,proto3" json:"deleted_points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTripRoutesResponse) Reset() {
+	*x = DeleteTripRoutesResponse{}
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTripRoutesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTripRoutesResponse) ProtoMessage() {}
+
+func (x *DeleteTripRoutesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTripRoutesResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTripRoutesResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_geo_geo_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DeleteTripRoutesResponse) GetDeletedPoints() int64 {
+	if x != nil {
+		return x.DeletedPoints
+	}
+	return 0
+}
+
+// One driver's buffered locations, oldest first
+type DriverLocationBatch struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	DriverId string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	// Every location must carry the time it was recorded
+	Locations []*Location `protobuf:"bytes,2,rep,name=locations,proto3" json:"locations,omitempty"`
+	Status    string      `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	VehicleId string      `protobuf:"bytes,4,opt,name=vehicle_id,json=vehicleId,proto3" json:"vehicle_id,omitempty"`
+	// Optional; resolved from the latest location when unset
+	CityId          string   `protobuf:"bytes,5,opt,name=city_id,json=cityId,proto3" json:"city_id,omitempty"`
+	VehicleFeatures []string `protobuf:"bytes,6,rep,name=vehicle_features,json=vehicleFeatures,proto3" json:"vehicle_features,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DriverLocationBatch) Reset() {
+	*x = DriverLocationBatch{}
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriverLocationBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriverLocationBatch) ProtoMessage() {}
+
+func (x *DriverLocationBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriverLocationBatch.ProtoReflect.Descriptor instead.
+func (*DriverLocationBatch) Descriptor() ([]byte, []int) {
+	return file_shared_proto_geo_geo_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DriverLocationBatch) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *DriverLocationBatch) GetLocations() []*Location {
+	if x != nil {
+		return x.Locations
+	}
+	return nil
+}
+
+func (x *DriverLocationBatch) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *DriverLocationBatch) GetVehicleId() string {
+	if x != nil {
+		return x.VehicleId
+	}
+	return ""
+}
+
+func (x *DriverLocationBatch) GetCityId() string {
+	if x != nil {
+		return x.CityId
+	}
+	return ""
+}
+
+func (x *DriverLocationBatch) GetVehicleFeatures() []string {
+	if x != nil {
+		return x.VehicleFeatures
+	}
+	return nil
+}
+
+// Batch driver location upload request
+type IngestDriverLocationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// At most one batch per driver
+	Batches       []*DriverLocationBatch `protobuf:"bytes,1,rep,name=batches,proto3" json:"batches,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngestDriverLocationsRequest) Reset() {
+	*x = IngestDriverLocationsRequest{}
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestDriverLocationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestDriverLocationsRequest) ProtoMessage() {}
+
+func (x *IngestDriverLocationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestDriverLocationsRequest.ProtoReflect.Descriptor instead.
+func (*IngestDriverLocationsRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_geo_geo_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *IngestDriverLocationsRequest) GetBatches() []*DriverLocationBatch {
+	if x != nil {
+		return x.Batches
+	}
+	return nil
+}
+
+// A location that was not ingested
+type RejectedLocation struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Index of the location in its batch
+	Index int32 `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	// missing_timestamp, invalid_location, future_timestamp or out_of_order
+	Reason        string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectedLocation) Reset() {
+	*x = RejectedLocation{}
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectedLocation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectedLocation) ProtoMessage() {}
+
+func (x *RejectedLocation) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectedLocation.ProtoReflect.Descriptor instead.
+func (*RejectedLocation) Descriptor() ([]byte, []int) {
+	return file_shared_proto_geo_geo_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *RejectedLocation) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *RejectedLocation) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// What became of one driver's batch
+type DriverIngestResult struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	DriverId string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	Accepted int32                  `protobuf:"varint,2,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	// Locations already ingested, by an earlier upload or earlier in the batch
+	Duplicates int32               `protobuf:"varint,3,opt,name=duplicates,proto3" json:"duplicates,omitempty"`
+	Rejected   []*RejectedLocation `protobuf:"bytes,4,rep,name=rejected,proto3" json:"rejected,omitempty"`
+	// Set when none of the batch could be ingested
+	Error         string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DriverIngestResult) Reset() {
+	*x = DriverIngestResult{}
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriverIngestResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriverIngestResult) ProtoMessage() {}
+
+func (x *DriverIngestResult) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriverIngestResult.ProtoReflect.Descriptor instead.
+func (*DriverIngestResult) Descriptor() ([]byte, []int) {
+	return file_shared_proto_geo_geo_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *DriverIngestResult) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *DriverIngestResult) GetAccepted() int32 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+func (x *DriverIngestResult) GetDuplicates() int32 {
+	if x != nil {
+		return x.Duplicates
+	}
+	return 0
+}
+
+func (x *DriverIngestResult) GetRejected() []*RejectedLocation {
+	if x != nil {
+		return x.Rejected
+	}
+	return nil
+}
+
+func (x *DriverIngestResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// Batch driver location upload response
+type IngestDriverLocationsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One result per batch, in request order
+	Results       []*DriverIngestResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Accepted      int32                 `protobuf:"varint,2,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Duplicates    int32                 `protobuf:"varint,3,opt,name=duplicates,proto3" json:"duplicates,omitempty"`
+	Rejected      int32                 `protobuf:"varint,4,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IngestDriverLocationsResponse) Reset() {
+	*x = IngestDriverLocationsResponse{}
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IngestDriverLocationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestDriverLocationsResponse) ProtoMessage() {}
+
+func (x *IngestDriverLocationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestDriverLocationsResponse.ProtoReflect.Descriptor instead.
+func (*IngestDriverLocationsResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_geo_geo_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *IngestDriverLocationsResponse) GetResults() []*DriverIngestResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *IngestDriverLocationsResponse) GetAccepted() int32 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+func (x *IngestDriverLocationsResponse) GetDuplicates() int32 {
+	if x != nil {
+		return x.Duplicates
+	}
+	return 0
+}
+
+func (x *IngestDriverLocationsResponse) GetRejected() int32 {
+	if x != nil {
+		return x.Rejected
+	}
+	return 0
+}
+
+// Pickup ETA stream request
+type StreamPickupETARequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TripId   string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	DriverId string                 `protobuf:"bytes,2,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	Pickup   *Location              `protobuf:"bytes,3,opt,name=pickup,proto3" json:"pickup,omitempty"`
+	// Defaults to "car"
+	VehicleType   string `protobuf:"bytes,4,opt,name=vehicle_type,json=vehicleType,proto3" json:"vehicle_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamPickupETARequest) Reset() {
+	*x = StreamPickupETARequest{}
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamPickupETARequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamPickupETARequest) ProtoMessage() {}
+
+func (x *StreamPickupETARequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamPickupETARequest.ProtoReflect.Descriptor instead.
+func (*StreamPickupETARequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_geo_geo_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *StreamPickupETARequest) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *StreamPickupETARequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *StreamPickupETARequest) GetPickup() *Location {
+	if x != nil {
+		return x.Pickup
+	}
+	return nil
+}
+
+func (x *StreamPickupETARequest) GetVehicleType() string {
+	if x != nil {
+		return x.VehicleType
+	}
+	return ""
+}
+
+// Driver's ETA to the pickup, sent whenever it changes
+type PickupETAUpdate struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	TripId           string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	DriverId         string                 `protobuf:"bytes,2,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	DriverLocation   *Location              `protobuf:"bytes,3,opt,name=driver_location,json=driverLocation,proto3" json:"driver_location,omitempty"`
+	EtaSeconds       int32                  `protobuf:"varint,4,opt,name=eta_seconds,json=etaSeconds,proto3" json:"eta_seconds,omitempty"`
+	DistanceMeters   float64                `protobuf:"fixed64,5,opt,name=distance_meters,json=distanceMeters,proto3" json:"distance_meters,omitempty"`
+	EstimatedArrival *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=estimated_arrival,json=estimatedArrival,proto3" json:"estimated_arrival,omitempty"`
+	Model            string                 `protobuf:"bytes,7,opt,name=model,proto3" json:"model,omitempty"`
+	// Set on the last update, once the driver reached the pickup
+	Arrived       bool                   `protobuf:"varint,8,opt,name=arrived,proto3" json:"arrived,omitempty"`
+	ComputedAt    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=computed_at,json=computedAt,proto3" json:"computed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PickupETAUpdate) Reset() {
+	*x = PickupETAUpdate{}
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PickupETAUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PickupETAUpdate) ProtoMessage() {}
+
+func (x *PickupETAUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PickupETAUpdate.ProtoReflect.Descriptor instead.
+func (*PickupETAUpdate) Descriptor() ([]byte, []int) {
+	return file_shared_proto_geo_geo_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *PickupETAUpdate) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *PickupETAUpdate) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *PickupETAUpdate) GetDriverLocation() *Location {
+	if x != nil {
+		return x.DriverLocation
+	}
+	return nil
+}
+
+func (x *PickupETAUpdate) GetEtaSeconds() int32 {
+	if x != nil {
+		return x.EtaSeconds
+	}
+	return 0
+}
+
+func (x *PickupETAUpdate) GetDistanceMeters() float64 {
+	if x != nil {
+		return x.DistanceMeters
+	}
+	return 0
+}
+
+func (x *PickupETAUpdate) GetEstimatedArrival() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EstimatedArrival
+	}
+	return nil
+}
+
+func (x *PickupETAUpdate) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *PickupETAUpdate) GetArrived() bool {
+	if x != nil {
+		return x.Arrived
+	}
+	return false
+}
+
+func (x *PickupETAUpdate) GetComputedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ComputedAt
+	}
+	return nil
+}
+
+// Driver shift history request
+type ListDriverShiftsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	DriverId string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	// Shifts overlapping [from, to) are returned
+	From          *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDriverShiftsRequest) Reset() {
+	*x = ListDriverShiftsRequest{}
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDriverShiftsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDriverShiftsRequest) ProtoMessage() {}
+
+func (x *ListDriverShiftsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDriverShiftsRequest.ProtoReflect.Descriptor instead.
+func (*ListDriverShiftsRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_geo_geo_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListDriverShiftsRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *ListDriverShiftsRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ListDriverShiftsRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+// A shift a driver worked, from going online to going offline
+type DriverShift struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	StartedAt *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	// Now for the shift in progress
+	EndedAt    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=ended_at,json=endedAt,proto3" json:"ended_at,omitempty"`
+	InProgress bool                   `protobuf:"varint,3,opt,name=in_progress,json=inProgress,proto3" json:"in_progress,omitempty"`
+	// How the shift ended, e.g. go_offline or heartbeat_expired
+	EndReason     string `protobuf:"bytes,4,opt,name=end_reason,json=endReason,proto3" json:"end_reason,omitempty"`
+	OnTripSeconds int64  `protobuf:"varint,5,opt,name=on_trip_seconds,json=onTripSeconds,proto3" json:"on_trip_seconds,omitempty"`
+	BreakSeconds  int64  `protobuf:"varint,6,opt,name=break_seconds,json=breakSeconds,proto3" json:"break_seconds,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DriverShift) Reset() {
+	*x = DriverShift{}
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriverShift) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriverShift) ProtoMessage() {}
+
+func (x *DriverShift) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriverShift.ProtoReflect.Descriptor instead.
+func (*DriverShift) Descriptor() ([]byte, []int) {
+	return file_shared_proto_geo_geo_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *DriverShift) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *DriverShift) GetEndedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndedAt
+	}
+	return nil
+}
+
+func (x *DriverShift) GetInProgress() bool {
+	if x != nil {
+		return x.InProgress
+	}
+	return false
+}
+
+func (x *DriverShift) GetEndReason() string {
+	if x != nil {
+		return x.EndReason
+	}
+	return ""
+}
+
+func (x *DriverShift) GetOnTripSeconds() int64 {
+	if x != nil {
+		return x.OnTripSeconds
+	}
+	return 0
+}
+
+func (x *DriverShift) GetBreakSeconds() int64 {
+	if x != nil {
+		return x.BreakSeconds
+	}
+	return 0
+}
+
+// Driver shift history response
+type ListDriverShiftsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shifts        []*DriverShift         `protobuf:"bytes,1,rep,name=shifts,proto3" json:"shifts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDriverShiftsResponse) Reset() {
+	*x = ListDriverShiftsResponse{}
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDriverShiftsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDriverShiftsResponse) ProtoMessage() {}
+
+func (x *ListDriverShiftsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDriverShiftsResponse.ProtoReflect.Descriptor instead.
+func (*ListDriverShiftsResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_geo_geo_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListDriverShiftsResponse) GetShifts() []*DriverShift {
+	if x != nil {
+		return x.Shifts
+	}
+	return nil
+}
+
+// Driver online hours request
+type GetDriverOnlineHoursRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	DriverId string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	// UTC days from from to to, both included, as YYYY-MM-DD
+	From          string `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            string `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDriverOnlineHoursRequest) Reset() {
+	*x = GetDriverOnlineHoursRequest{}
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDriverOnlineHoursRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDriverOnlineHoursRequest) ProtoMessage() {}
+
+func (x *GetDriverOnlineHoursRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDriverOnlineHoursRequest.ProtoReflect.Descriptor instead.
+func (*GetDriverOnlineHoursRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_geo_geo_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetDriverOnlineHoursRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *GetDriverOnlineHoursRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *GetDriverOnlineHoursRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+// How a driver spent one UTC day on shift
+type DriverDayActivity struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Date  string                 `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	// On shift and not on a break, trips included
+	OnlineSeconds int64 `protobuf:"varint,2,opt,name=online_seconds,json=onlineSeconds,proto3" json:"online_seconds,omitempty"`
+	OnTripSeconds int64 `protobuf:"varint,3,opt,name=on_trip_seconds,json=onTripSeconds,proto3" json:"on_trip_seconds,omitempty"`
+	BreakSeconds  int64 `protobuf:"varint,4,opt,name=break_seconds,json=breakSeconds,proto3" json:"break_seconds,omitempty"`
+	// Shifts started that day
+	Shifts        int32 `protobuf:"varint,5,opt,name=shifts,proto3" json:"shifts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DriverDayActivity) Reset() {
+	*x = DriverDayActivity{}
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriverDayActivity) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriverDayActivity) ProtoMessage() {}
+
+func (x *DriverDayActivity) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriverDayActivity.ProtoReflect.Descriptor instead.
+func (*DriverDayActivity) Descriptor() ([]byte, []int) {
+	return file_shared_proto_geo_geo_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *DriverDayActivity) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *DriverDayActivity) GetOnlineSeconds() int64 {
+	if x != nil {
+		return x.OnlineSeconds
+	}
+	return 0
+}
+
+func (x *DriverDayActivity) GetOnTripSeconds() int64 {
+	if x != nil {
+		return x.OnTripSeconds
+	}
+	return 0
+}
+
+func (x *DriverDayActivity) GetBreakSeconds() int64 {
+	if x != nil {
+		return x.BreakSeconds
+	}
+	return 0
+}
+
+func (x *DriverDayActivity) GetShifts() int32 {
+	if x != nil {
+		return x.Shifts
+	}
+	return 0
+}
+
+// Driver online hours response
+type GetDriverOnlineHoursResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Days without activity are left out
+	Days          []*DriverDayActivity `protobuf:"bytes,1,rep,name=days,proto3" json:"days,omitempty"`
+	OnlineSeconds int64                `protobuf:"varint,2,opt,name=online_seconds,json=onlineSeconds,proto3" json:"online_seconds,omitempty"`
+	OnTripSeconds int64                `protobuf:"varint,3,opt,name=on_trip_seconds,json=onTripSeconds,proto3" json:"on_trip_seconds,omitempty"`
+	OnlineHours   float64              `protobuf:"fixed64,4,opt,name=online_hours,json=onlineHours,proto3" json:"online_hours,omitempty"`
+	// Share of online time spent on trips
+	Utilization   float64 `protobuf:"fixed64,5,opt,name=utilization,proto3" json:"utilization,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDriverOnlineHoursResponse) Reset() {
+	*x = GetDriverOnlineHoursResponse{}
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDriverOnlineHoursResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDriverOnlineHoursResponse) ProtoMessage() {}
+
+func (x *GetDriverOnlineHoursResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_geo_geo_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDriverOnlineHoursResponse.ProtoReflect.Descriptor instead.
+func (*GetDriverOnlineHoursResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_geo_geo_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetDriverOnlineHoursResponse) GetDays() []*DriverDayActivity {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+func (x *GetDriverOnlineHoursResponse) GetOnlineSeconds() int64 {
+	if x != nil {
+		return x.OnlineSeconds
+	}
+	return 0
+}
+
+func (x *GetDriverOnlineHoursResponse) GetOnTripSeconds() int64 {
+	if x != nil {
+		return x.OnTripSeconds
+	}
+	return 0
+}
+
+func (x *GetDriverOnlineHoursResponse) GetOnlineHours() float64 {
+	if x != nil {
+		return x.OnlineHours
+	}
+	return 0
+}
+
+func (x *GetDriverOnlineHoursResponse) GetUtilization() float64 {
+	if x != nil {
+		return x.Utilization
+	}
+	return 0
+}
+
+var File_shared_proto_geo_geo_proto protoreflect.FileDescriptor
+
+const file_shared_proto_geo_geo_proto_rawDesc = "" +
+	"\n" +
+	"\x1ashared/proto/geo/geo.proto\x12\x03geo\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb4\x01\n" +
+	"\bLocation\x12\x1a\n" +
+	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\x12\x1a\n" +
+	"\baccuracy\x18\x03 \x01(\x01R\baccuracy\x128\n" +
+	"\ttimestamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12\x18\n" +
+	"\aaddress\x18\x05 \x01(\tR\aaddress\"\x98\x01\n" +
+	"\x0fDistanceRequest\x12%\n" +
+	"\x06origin\x18\x01 \x01(\v2\r.geo.LocationR\x06origin\x12/\n" +
+	"\vdestination\x18\x02 \x01(\v2\r.geo.LocationR\vdestination\x12-\n" +
+	"\x12calculation_method\x18\x03 \x01(\tR\x11calculationMethod\"\xb4\x01\n" +
+	"\x10DistanceResponse\x12'\n" +
+	"\x0fdistance_meters\x18\x01 \x01(\x01R\x0edistanceMeters\x12\x1f\n" +
+	"\vdistance_km\x18\x02 \x01(\x01R\n" +
+	"distanceKm\x12'\n" +
+	"\x0fbearing_degrees\x18\x03 \x01(\x01R\x0ebearingDegrees\x12-\n" +
+	"\x12calculation_method\x18\x04 \x01(\tR\x11calculationMethod\"\xf3\x01\n" +
+	"\n" +
+	"ETARequest\x12%\n" +
+	"\x06origin\x18\x01 \x01(\v2\r.geo.LocationR\x06origin\x12/\n" +
+	"\vdestination\x18\x02 \x01(\v2\r.geo.LocationR\vdestination\x12!\n" +
+	"\fvehicle_type\x18\x03 \x01(\tR\vvehicleType\x12A\n" +
+	"\x0edeparture_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\rdepartureTime\x12'\n" +
+	"\x0finclude_traffic\x18\x05 \x01(\bR\x0eincludeTraffic\"\xfc\x01\n" +
+	"\vETAResponse\x12)\n" +
+	"\x10duration_seconds\x18\x01 \x01(\x05R\x0fdurationSeconds\x12'\n" +
+	"\x0fdistance_meters\x18\x02 \x01(\x01R\x0edistanceMeters\x12#\n" +
+	"\rroute_summary\x18\x03 \x01(\tR\frouteSummary\x12+\n" +
+	"\twaypoints\x18\x04 \x03(\v2\r.geo.LocationR\twaypoints\x12G\n" +
+	"\x11estimated_arrival\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x10estimatedArrival\"\xd5\x01\n" +
+	"\x14NearbyDriversRequest\x12%\n" +
+	"\x06center\x18\x01 \x01(\v2\r.geo.LocationR\x06center\x12\x1b\n" +
+	"\tradius_km\x18\x02 \x01(\x01R\bradiusKm\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12#\n" +
+	"\rvehicle_types\x18\x04 \x03(\tR\fvehicleTypes\x12%\n" +
+	"\x0eonly_available\x18\x05 \x01(\bR\ronlyAvailable\x12\x17\n" +
+	"\acity_id\x18\x06 \x01(\tR\x06cityId\"\xfe\x02\n" +
+	"\x0eDriverLocation\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12\x1d\n" +
+	"\n" +
+	"vehicle_id\x18\x02 \x01(\tR\tvehicleId\x12)\n" +
+	"\blocation\x18\x03 \x01(\v2\r.geo.LocationR\blocation\x120\n" +
+	"\x14distance_from_center\x18\x04 \x01(\x01R\x12distanceFromCenter\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12!\n" +
+	"\fvehicle_type\x18\x06 \x01(\tR\vvehicleType\x12\x16\n" +
+	"\x06rating\x18\a \x01(\x01R\x06rating\x12\x17\n" +
+	"\acity_id\x18\b \x01(\tR\x06cityId\x12)\n" +
+	"\x10vehicle_features\x18\t \x03(\tR\x0fvehicleFeatures\x12<\n" +
+	"\flast_seen_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastSeenAt\"\x91\x01\n" +
+	"\x15NearbyDriversResponse\x12-\n" +
+	"\adrivers\x18\x01 \x03(\v2\x13.geo.DriverLocationR\adrivers\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x05R\n" +
+	"totalCount\x12(\n" +
+	"\x10search_radius_km\x18\x03 \x01(\x01R\x0esearchRadiusKm\"\xe0\x01\n" +
+	"\x1bUpdateDriverLocationRequest\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12)\n" +
+	"\blocation\x18\x02 \x01(\v2\r.geo.LocationR\blocation\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"vehicle_id\x18\x04 \x01(\tR\tvehicleId\x12\x17\n" +
+	"\acity_id\x18\x05 \x01(\tR\x06cityId\x12)\n" +
+	"\x10vehicle_features\x18\x06 \x03(\tR\x0fvehicleFeatures\"\x8d\x01\n" +
+	"\x1cUpdateDriverLocationResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x129\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"Y\n" +
+	"\x0eGeohashRequest\x12)\n" +
+	"\blocation\x18\x01 \x01(\v2\r.geo.LocationR\blocation\x12\x1c\n" +
+	"\tprecision\x18\x02 \x01(\x05R\tprecision\"\x9a\x01\n" +
+	"\x0fGeohashResponse\x12\x18\n" +
+	"\ageohash\x18\x01 \x01(\tR\ageohash\x12%\n" +
+	"\x06center\x18\x02 \x01(\v2\r.geo.LocationR\x06center\x12!\n" +
+	"\fwidth_meters\x18\x03 \x01(\x01R\vwidthMeters\x12#\n" +
+	"\rheight_meters\x18\x04 \x01(\x01R\fheightMeters\"\xdd\x01\n" +
+	"\x18RouteOptimizationRequest\x12#\n" +
+	"\x05start\x18\x01 \x01(\v2\r.geo.LocationR\x05start\x12+\n" +
+	"\twaypoints\x18\x02 \x03(\v2\r.geo.LocationR\twaypoints\x12\x1f\n" +
+	"\x03end\x18\x03 \x01(\v2\r.geo.LocationR\x03end\x12+\n" +
+	"\x11optimization_type\x18\x04 \x01(\tR\x10optimizationType\x12!\n" +
+	"\fvehicle_type\x18\x05 \x01(\tR\vvehicleType\"\xf4\x01\n" +
+	"\x19RouteOptimizationResponse\x126\n" +
+	"\x0foptimized_route\x18\x01 \x03(\v2\r.geo.LocationR\x0eoptimizedRoute\x12*\n" +
+	"\x11total_distance_km\x18\x02 \x01(\x01R\x0ftotalDistanceKm\x12<\n" +
+	"\x1aestimated_duration_seconds\x18\x03 \x01(\x05R\x18estimatedDurationSeconds\x125\n" +
+	"\x16optimization_algorithm\x18\x04 \x01(\tR\x15optimizationAlgorithm\"w\n" +
+	" SubscribeToDriverLocationRequest\x12\x17\n" +
+	"\aarea_id\x18\x01 \x01(\tR\x06areaId\x12\x1b\n" +
+	"\tradius_km\x18\x02 \x01(\x01R\bradiusKm\x12\x1d\n" +
+	"\n" +
+	"driver_ids\x18\x03 \x03(\tR\tdriverIds\"\x86\x03\n" +
+	"\x13DriverLocationEvent\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12)\n" +
+	"\blocation\x18\x02 \x01(\v2\r.geo.LocationR\blocation\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"vehicle_id\x18\x04 \x01(\tR\tvehicleId\x12\x1b\n" +
+	"\tspeed_kmh\x18\x05 \x01(\x01R\bspeedKmh\x12\x18\n" +
+	"\aheading\x18\x06 \x01(\x01R\aheading\x128\n" +
+	"\ttimestamp\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12B\n" +
+	"\bmetadata\x18\b \x03(\v2&.geo.DriverLocationEvent.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"s\n" +
+	"\x1cStartLocationTrackingRequest\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x126\n" +
+	"\x17update_interval_seconds\x18\x02 \x01(\x05R\x15updateIntervalSeconds\"r\n" +
+	"\x1dStartLocationTrackingResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\xb0\x01\n" +
+	"\x04Zone\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x1c\n" +
+	"\tsurcharge\x18\x04 \x01(\x01R\tsurcharge\x12+\n" +
+	"\x11pickup_restricted\x18\x05 \x01(\bR\x10pickupRestricted\x12%\n" +
+	"\x0edispatch_queue\x18\x06 \x01(\bR\rdispatchQueue\"=\n" +
+	"\x10FindZonesRequest\x12)\n" +
+	"\blocation\x18\x01 \x01(\v2\r.geo.LocationR\blocation\"\xa3\x01\n" +
+	"\x11FindZonesResponse\x12\x1f\n" +
+	"\x05zones\x18\x01 \x03(\v2\t.geo.ZoneR\x05zones\x12\x1c\n" +
+	"\tsurcharge\x18\x02 \x01(\x01R\tsurcharge\x12+\n" +
+	"\x11pickup_restricted\x18\x03 \x01(\bR\x10pickupRestricted\x12\"\n" +
+	"\rqueue_zone_id\x18\x04 \x01(\tR\vqueueZoneId\"D\n" +
+	"\x13GetZoneQueueRequest\x12\x17\n" +
+	"\azone_id\x18\x01 \x01(\tR\x06zoneId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\x80\x01\n" +
+	"\fQueuedDriver\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12\x1a\n" +
+	"\bposition\x18\x02 \x01(\x05R\bposition\x127\n" +
+	"\tjoined_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bjoinedAt\"\\\n" +
+	"\x14GetZoneQueueResponse\x12\x17\n" +
+	"\azone_id\x18\x01 \x01(\tR\x06zoneId\x12+\n" +
+	"\adrivers\x18\x02 \x03(\v2\x11.geo.QueuedDriverR\adrivers\"K\n" +
+	"\x13GetTripRouteRequest\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\x12\x1b\n" +
+	"\tmap_match\x18\x02 \x01(\bR\bmapMatch\"\xe4\x01\n" +
+	"\x14GetTripRouteResponse\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\x12%\n" +
+	"\x06points\x18\x02 \x03(\v2\r.geo.LocationR\x06points\x12\x1f\n" +
+	"\vdistance_km\x18\x03 \x01(\x01R\n" +
+	"distanceKm\x12)\n" +
+	"\x10duration_seconds\x18\x04 \x01(\x05R\x0fdurationSeconds\x12&\n" +
+	"\x0fraw_point_count\x18\x05 \x01(\x05R\rrawPointCount\x12\x18\n" +
+	"\amatched\x18\x06 \x01(\bR\amatched\"4\n" +
+	"\x17DeleteTripRoutesRequest\x12\x19\n" +
+	"\btrip_ids\x18\x01 \x03(\tR\atripIds\"A\n" +
+	"\x18DeleteTripRoutesResponse\x12%\n" +
+	"\x0edeleted_points\x18\x01 \x01(\x03R\rdeletedPoints\"\xda\x01\n" +
+	"\x13DriverLocationBatch\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12+\n" +
+	"\tlocations\x18\x02 \x03(\v2\r.geo.LocationR\tlocations\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"vehicle_id\x18\x04 \x01(\tR\tvehicleId\x12\x17\n" +
+	"\acity_id\x18\x05 \x01(\tR\x06cityId\x12)\n" +
+	"\x10vehicle_features\x18\x06 \x03(\tR\x0fvehicleFeatures\"R\n" +
+	"\x1cIngestDriverLocationsRequest\x122\n" +
+	"\abatches\x18\x01 \x03(\v2\x18.geo.DriverLocationBatchR\abatches\"@\n" +
+	"\x10RejectedLocation\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\xb6\x01\n" +
+	"\x12DriverIngestResult\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12\x1a\n" +
+	"\baccepted\x18\x02 \x01(\x05R\baccepted\x12\x1e\n" +
+	"\n" +
+	"duplicates\x18\x03 \x01(\x05R\n" +
+	"duplicates\x121\n" +
+	"\brejected\x18\x04 \x03(\v2\x15.geo.RejectedLocationR\brejected\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\"\xaa\x01\n" +
+	"\x1dIngestDriverLocationsResponse\x121\n" +
+	"\aresults\x18\x01 \x03(\v2\x17.geo.DriverIngestResultR\aresults\x12\x1a\n" +
+	"\baccepted\x18\x02 \x01(\x05R\baccepted\x12\x1e\n" +
+	"\n" +
+	"duplicates\x18\x03 \x01(\x05R\n" +
+	"duplicates\x12\x1a\n" +
+	"\brejected\x18\x04 \x01(\x05R\brejected\"\x98\x01\n" +
+	"\x16StreamPickupETARequest\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\x12\x1b\n" +
+	"\tdriver_id\x18\x02 \x01(\tR\bdriverId\x12%\n" +
+	"\x06pickup\x18\x03 \x01(\v2\r.geo.LocationR\x06pickup\x12!\n" +
+	"\fvehicle_type\x18\x04 \x01(\tR\vvehicleType\"\xff\x02\n" +
+	"\x0fPickupETAUpdate\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\x12\x1b\n" +
+	"\tdriver_id\x18\x02 \x01(\tR\bdriverId\x126\n" +
+	"\x0fdriver_location\x18\x03 \x01(\v2\r.geo.LocationR\x0edriverLocation\x12\x1f\n" +
+	"\veta_seconds\x18\x04 \x01(\x05R\n" +
+	"etaSeconds\x12'\n" +
+	"\x0fdistance_meters\x18\x05 \x01(\x01R\x0edistanceMeters\x12G\n" +
+	"\x11estimated_arrival\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x10estimatedArrival\x12\x14\n" +
+	"\x05model\x18\a \x01(\tR\x05model\x12\x18\n" +
+	"\aarrived\x18\b \x01(\bR\aarrived\x12;\n" +
+	"\vcomputed_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"computedAt\"\x92\x01\n" +
+	"\x17ListDriverShiftsRequest\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12.\n" +
+	"\x04from\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\"\x8c\x02\n" +
+	"\vDriverShift\x129\n" +
+	"\n" +
+	"started_at\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x125\n" +
+	"\bended_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendedAt\x12\x1f\n" +
+	"\vin_progress\x18\x03 \x01(\bR\n" +
+	"inProgress\x12\x1d\n" +
+	"\n" +
+	"end_reason\x18\x04 \x01(\tR\tendReason\x12&\n" +
+	"\x0fon_trip_seconds\x18\x05 \x01(\x03R\ronTripSeconds\x12#\n" +
+	"\rbreak_seconds\x18\x06 \x01(\x03R\fbreakSeconds\"D\n" +
+	"\x18ListDriverShiftsResponse\x12(\n" +
+	"\x06shifts\x18\x01 \x03(\v2\x10.geo.DriverShiftR\x06shifts\"^\n" +
+	"\x1bGetDriverOnlineHoursRequest\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\"\xb3\x01\n" +
+	"\x11DriverDayActivity\x12\x12\n" +
+	"\x04date\x18\x01 \x01(\tR\x04date\x12%\n" +
+	"\x0eonline_seconds\x18\x02 \x01(\x03R\ronlineSeconds\x12&\n" +
+	"\x0fon_trip_seconds\x18\x03 \x01(\x03R\ronTripSeconds\x12#\n" +
+	"\rbreak_seconds\x18\x04 \x01(\x03R\fbreakSeconds\x12\x16\n" +
+	"\x06shifts\x18\x05 \x01(\x05R\x06shifts\"\xde\x01\n" +
+	"\x1cGetDriverOnlineHoursResponse\x12*\n" +
+	"\x04days\x18\x01 \x03(\v2\x16.geo.DriverDayActivityR\x04days\x12%\n" +
+	"\x0eonline_seconds\x18\x02 \x01(\x03R\ronlineSeconds\x12&\n" +
+	"\x0fon_trip_seconds\x18\x03 \x01(\x03R\ronTripSeconds\x12!\n" +
+	"\fonline_hours\x18\x04 \x01(\x01R\vonlineHours\x12 \n" +
+	"\vutilization\x18\x05 \x01(\x01R\vutilization2\xed\t\n" +
 	"\x11GeospatialService\x12@\n" +
 	"\x11CalculateDistance\x12\x14.geo.DistanceRequest\x1a\x15.geo.DistanceResponse\x121\n" +
 	"\fCalculateETA\x12\x0f.geo.ETARequest\x1a\x10.geo.ETAResponse\x12J\n" +
@@ -1406,7 +3108,15 @@ const file_shared_proto_geo_geo_proto_rawDesc = "" +
 	"\x0fGenerateGeohash\x12\x13.geo.GeohashRequest\x1a\x14.geo.GeohashResponse\x12N\n" +
 	"\rOptimizeRoute\x12\x1d.geo.RouteOptimizationRequest\x1a\x1e.geo.RouteOptimizationResponse\x12_\n" +
 	"\x1aSubscribeToDriverLocations\x12%.geo.SubscribeToDriverLocationRequest\x1a\x18.geo.DriverLocationEvent0\x01\x12^\n" +
-	"\x15StartLocationTracking\x12!.geo.StartLocationTrackingRequest\x1a\".geo.StartLocationTrackingResponseB6Z4github.com/rideshare-platform/shared/proto/geo;geopbb\x06proto3"
+	"\x15StartLocationTracking\x12!.geo.StartLocationTrackingRequest\x1a\".geo.StartLocationTrackingResponse\x12:\n" +
+	"\tFindZones\x12\x15.geo.FindZonesRequest\x1a\x16.geo.FindZonesResponse\x12C\n" +
+	"\fGetTripRoute\x12\x18.geo.GetTripRouteRequest\x1a\x19.geo.GetTripRouteResponse\x12O\n" +
+	"\x10DeleteTripRoutes\x12\x1c.geo.DeleteTripRoutesRequest\x1a\x1d.geo.DeleteTripRoutesResponse\x12^\n" +
+	"\x15IngestDriverLocations\x12!.geo.IngestDriverLocationsRequest\x1a\".geo.IngestDriverLocationsResponse\x12F\n" +
+	"\x0fStreamPickupETA\x12\x1b.geo.StreamPickupETARequest\x1a\x14.geo.PickupETAUpdate0\x01\x12O\n" +
+	"\x10ListDriverShifts\x12\x1c.geo.ListDriverShiftsRequest\x1a\x1d.geo.ListDriverShiftsResponse\x12[\n" +
+	"\x14GetDriverOnlineHours\x12 .geo.GetDriverOnlineHoursRequest\x1a!.geo.GetDriverOnlineHoursResponse\x12C\n" +
+	"\fGetZoneQueue\x12\x18.geo.GetZoneQueueRequest\x1a\x19.geo.GetZoneQueueResponseB6Z4github.com/rideshare-platform/shared/proto/geo;geopbb\x06proto3"
 
 var (
 	file_shared_proto_geo_geo_proto_rawDescOnce sync.Once
@@ -1420,7 +3130,7 @@ func file_shared_proto_geo_geo_proto_rawDescGZIP() []byte {
 	return file_shared_proto_geo_geo_proto_rawDescData
 }
 
-var file_shared_proto_geo_geo_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_shared_proto_geo_geo_proto_msgTypes = make([]protoimpl.MessageInfo, 42)
 var file_shared_proto_geo_geo_proto_goTypes = []any{
 	(*Location)(nil),                         // 0: geo.Location
 	(*DistanceRequest)(nil),                  // 1: geo.DistanceRequest
@@ -1440,53 +3150,112 @@ var file_shared_proto_geo_geo_proto_goTypes = []any{
 	(*DriverLocationEvent)(nil),              // 15: geo.DriverLocationEvent
 	(*StartLocationTrackingRequest)(nil),     // 16: geo.StartLocationTrackingRequest
 	(*StartLocationTrackingResponse)(nil),    // 17: geo.StartLocationTrackingResponse
-	nil,                                      // 18: geo.DriverLocationEvent.MetadataEntry
-	(*timestamppb.Timestamp)(nil),            // 19: google.protobuf.Timestamp
+	(*Zone)(nil),                             // 18: geo.Zone
+	(*FindZonesRequest)(nil),                 // 19: geo.FindZonesRequest
+	(*FindZonesResponse)(nil),                // 20: geo.FindZonesResponse
+	(*GetZoneQueueRequest)(nil),              // 21: geo.GetZoneQueueRequest
+	(*QueuedDriver)(nil),                     // 22: geo.QueuedDriver
+	(*GetZoneQueueResponse)(nil),             // 23: geo.GetZoneQueueResponse
+	(*GetTripRouteRequest)(nil),              // 24: geo.GetTripRouteRequest
+	(*GetTripRouteResponse)(nil),             // 25: geo.GetTripRouteResponse
+	(*DeleteTripRoutesRequest)(nil),          // 26: geo.DeleteTripRoutesRequest
+	(*DeleteTripRoutesResponse)(nil),         // 27: geo.DeleteTripRoutesResponse
+	(*DriverLocationBatch)(nil),              // 28: geo.DriverLocationBatch
+	(*IngestDriverLocationsRequest)(nil),     // 29: geo.IngestDriverLocationsRequest
+	(*RejectedLocation)(nil),                 // 30: geo.RejectedLocation
+	(*DriverIngestResult)(nil),               // 31: geo.DriverIngestResult
+	(*IngestDriverLocationsResponse)(nil),    // 32: geo.IngestDriverLocationsResponse
+	(*StreamPickupETARequest)(nil),           // 33: geo.StreamPickupETARequest
+	(*PickupETAUpdate)(nil),                  // 34: geo.PickupETAUpdate
+	(*ListDriverShiftsRequest)(nil),          // 35: geo.ListDriverShiftsRequest
+	(*DriverShift)(nil),                      // 36: geo.DriverShift
+	(*ListDriverShiftsResponse)(nil),         // 37: geo.ListDriverShiftsResponse
+	(*GetDriverOnlineHoursRequest)(nil),      // 38: geo.GetDriverOnlineHoursRequest
+	(*DriverDayActivity)(nil),                // 39: geo.DriverDayActivity
+	(*GetDriverOnlineHoursResponse)(nil),     // 40: geo.GetDriverOnlineHoursResponse
+	nil,                                      // 41: geo.DriverLocationEvent.MetadataEntry
+	(*timestamppb.Timestamp)(nil),            // 42: google.protobuf.Timestamp
 }
 var file_shared_proto_geo_geo_proto_depIdxs = []int32{
-	19, // 0: geo.Location.timestamp:type_name -> google.protobuf.Timestamp
+	42, // 0: geo.Location.timestamp:type_name -> google.protobuf.Timestamp
 	0,  // 1: geo.DistanceRequest.origin:type_name -> geo.Location
 	0,  // 2: geo.DistanceRequest.destination:type_name -> geo.Location
 	0,  // 3: geo.ETARequest.origin:type_name -> geo.Location
 	0,  // 4: geo.ETARequest.destination:type_name -> geo.Location
-	19, // 5: geo.ETARequest.departure_time:type_name -> google.protobuf.Timestamp
+	42, // 5: geo.ETARequest.departure_time:type_name -> google.protobuf.Timestamp
 	0,  // 6: geo.ETAResponse.waypoints:type_name -> geo.Location
-	19, // 7: geo.ETAResponse.estimated_arrival:type_name -> google.protobuf.Timestamp
+	42, // 7: geo.ETAResponse.estimated_arrival:type_name -> google.protobuf.Timestamp
 	0,  // 8: geo.NearbyDriversRequest.center:type_name -> geo.Location
 	0,  // 9: geo.DriverLocation.location:type_name -> geo.Location
-	6,  // 10: geo.NearbyDriversResponse.drivers:type_name -> geo.DriverLocation
-	0,  // 11: geo.UpdateDriverLocationRequest.location:type_name -> geo.Location
-	19, // 12: geo.UpdateDriverLocationResponse.updated_at:type_name -> google.protobuf.Timestamp
-	0,  // 13: geo.GeohashRequest.location:type_name -> geo.Location
-	0,  // 14: geo.GeohashResponse.center:type_name -> geo.Location
-	0,  // 15: geo.RouteOptimizationRequest.start:type_name -> geo.Location
-	0,  // 16: geo.RouteOptimizationRequest.waypoints:type_name -> geo.Location
-	0,  // 17: geo.RouteOptimizationRequest.end:type_name -> geo.Location
-	0,  // 18: geo.RouteOptimizationResponse.optimized_route:type_name -> geo.Location
-	0,  // 19: geo.DriverLocationEvent.location:type_name -> geo.Location
-	19, // 20: geo.DriverLocationEvent.timestamp:type_name -> google.protobuf.Timestamp
-	18, // 21: geo.DriverLocationEvent.metadata:type_name -> geo.DriverLocationEvent.MetadataEntry
-	1,  // 22: geo.GeospatialService.CalculateDistance:input_type -> geo.DistanceRequest
-	3,  // 23: geo.GeospatialService.CalculateETA:input_type -> geo.ETARequest
-	5,  // 24: geo.GeospatialService.FindNearbyDrivers:input_type -> geo.NearbyDriversRequest
-	8,  // 25: geo.GeospatialService.UpdateDriverLocation:input_type -> geo.UpdateDriverLocationRequest
-	10, // 26: geo.GeospatialService.GenerateGeohash:input_type -> geo.GeohashRequest
-	12, // 27: geo.GeospatialService.OptimizeRoute:input_type -> geo.RouteOptimizationRequest
-	14, // 28: geo.GeospatialService.SubscribeToDriverLocations:input_type -> geo.SubscribeToDriverLocationRequest
-	16, // 29: geo.GeospatialService.StartLocationTracking:input_type -> geo.StartLocationTrackingRequest
-	2,  // 30: geo.GeospatialService.CalculateDistance:output_type -> geo.DistanceResponse
-	4,  // 31: geo.GeospatialService.CalculateETA:output_type -> geo.ETAResponse
-	7,  // 32: geo.GeospatialService.FindNearbyDrivers:output_type -> geo.NearbyDriversResponse
-	9,  // 33: geo.GeospatialService.UpdateDriverLocation:output_type -> geo.UpdateDriverLocationResponse
-	11, // 34: geo.GeospatialService.GenerateGeohash:output_type -> geo.GeohashResponse
-	13, // 35: geo.GeospatialService.OptimizeRoute:output_type -> geo.RouteOptimizationResponse
-	15, // 36: geo.GeospatialService.SubscribeToDriverLocations:output_type -> geo.DriverLocationEvent
-	17, // 37: geo.GeospatialService.StartLocationTracking:output_type -> geo.StartLocationTrackingResponse
-	30, // [30:38] is the sub-list for method output_type
-	22, // [22:30] is the sub-list for method input_type
-	22, // [22:22] is the sub-list for extension type_name
-	22, // [22:22] is the sub-list for extension extendee
-	0,  // [0:22] is the sub-list for field type_name
+	42, // 10: geo.DriverLocation.last_seen_at:type_name -> google.protobuf.Timestamp
+	6,  // 11: geo.NearbyDriversResponse.drivers:type_name -> geo.DriverLocation
+	0,  // 12: geo.UpdateDriverLocationRequest.location:type_name -> geo.Location
+	42, // 13: geo.UpdateDriverLocationResponse.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 14: geo.GeohashRequest.location:type_name -> geo.Location
+	0,  // 15: geo.GeohashResponse.center:type_name -> geo.Location
+	0,  // 16: geo.RouteOptimizationRequest.start:type_name -> geo.Location
+	0,  // 17: geo.RouteOptimizationRequest.waypoints:type_name -> geo.Location
+	0,  // 18: geo.RouteOptimizationRequest.end:type_name -> geo.Location
+	0,  // 19: geo.RouteOptimizationResponse.optimized_route:type_name -> geo.Location
+	0,  // 20: geo.DriverLocationEvent.location:type_name -> geo.Location
+	42, // 21: geo.DriverLocationEvent.timestamp:type_name -> google.protobuf.Timestamp
+	41, // 22: geo.DriverLocationEvent.metadata:type_name -> geo.DriverLocationEvent.MetadataEntry
+	0,  // 23: geo.FindZonesRequest.location:type_name -> geo.Location
+	18, // 24: geo.FindZonesResponse.zones:type_name -> geo.Zone
+	42, // 25: geo.QueuedDriver.joined_at:type_name -> google.protobuf.Timestamp
+	22, // 26: geo.GetZoneQueueResponse.drivers:type_name -> geo.QueuedDriver
+	0,  // 27: geo.GetTripRouteResponse.points:type_name -> geo.Location
+	0,  // 28: geo.DriverLocationBatch.locations:type_name -> geo.Location
+	28, // 29: geo.IngestDriverLocationsRequest.batches:type_name -> geo.DriverLocationBatch
+	30, // 30: geo.DriverIngestResult.rejected:type_name -> geo.RejectedLocation
+	31, // 31: geo.IngestDriverLocationsResponse.results:type_name -> geo.DriverIngestResult
+	0,  // 32: geo.StreamPickupETARequest.pickup:type_name -> geo.Location
+	0,  // 33: geo.PickupETAUpdate.driver_location:type_name -> geo.Location
+	42, // 34: geo.PickupETAUpdate.estimated_arrival:type_name -> google.protobuf.Timestamp
+	42, // 35: geo.PickupETAUpdate.computed_at:type_name -> google.protobuf.Timestamp
+	42, // 36: geo.ListDriverShiftsRequest.from:type_name -> google.protobuf.Timestamp
+	42, // 37: geo.ListDriverShiftsRequest.to:type_name -> google.protobuf.Timestamp
+	42, // 38: geo.DriverShift.started_at:type_name -> google.protobuf.Timestamp
+	42, // 39: geo.DriverShift.ended_at:type_name -> google.protobuf.Timestamp
+	36, // 40: geo.ListDriverShiftsResponse.shifts:type_name -> geo.DriverShift
+	39, // 41: geo.GetDriverOnlineHoursResponse.days:type_name -> geo.DriverDayActivity
+	1,  // 42: geo.GeospatialService.CalculateDistance:input_type -> geo.DistanceRequest
+	3,  // 43: geo.GeospatialService.CalculateETA:input_type -> geo.ETARequest
+	5,  // 44: geo.GeospatialService.FindNearbyDrivers:input_type -> geo.NearbyDriversRequest
+	8,  // 45: geo.GeospatialService.UpdateDriverLocation:input_type -> geo.UpdateDriverLocationRequest
+	10, // 46: geo.GeospatialService.GenerateGeohash:input_type -> geo.GeohashRequest
+	12, // 47: geo.GeospatialService.OptimizeRoute:input_type -> geo.RouteOptimizationRequest
+	14, // 48: geo.GeospatialService.SubscribeToDriverLocations:input_type -> geo.SubscribeToDriverLocationRequest
+	16, // 49: geo.GeospatialService.StartLocationTracking:input_type -> geo.StartLocationTrackingRequest
+	19, // 50: geo.GeospatialService.FindZones:input_type -> geo.FindZonesRequest
+	24, // 51: geo.GeospatialService.GetTripRoute:input_type -> geo.GetTripRouteRequest
+	26, // 52: geo.GeospatialService.DeleteTripRoutes:input_type -> geo.DeleteTripRoutesRequest
+	29, // 53: geo.GeospatialService.IngestDriverLocations:input_type -> geo.IngestDriverLocationsRequest
+	33, // 54: geo.GeospatialService.StreamPickupETA:input_type -> geo.StreamPickupETARequest
+	35, // 55: geo.GeospatialService.ListDriverShifts:input_type -> geo.ListDriverShiftsRequest
+	38, // 56: geo.GeospatialService.GetDriverOnlineHours:input_type -> geo.GetDriverOnlineHoursRequest
+	21, // 57: geo.GeospatialService.GetZoneQueue:input_type -> geo.GetZoneQueueRequest
+	2,  // 58: geo.GeospatialService.CalculateDistance:output_type -> geo.DistanceResponse
+	4,  // 59: geo.GeospatialService.CalculateETA:output_type -> geo.ETAResponse
+	7,  // 60: geo.GeospatialService.FindNearbyDrivers:output_type -> geo.NearbyDriversResponse
+	9,  // 61: geo.GeospatialService.UpdateDriverLocation:output_type -> geo.UpdateDriverLocationResponse
+	11, // 62: geo.GeospatialService.GenerateGeohash:output_type -> geo.GeohashResponse
+	13, // 63: geo.GeospatialService.OptimizeRoute:output_type -> geo.RouteOptimizationResponse
+	15, // 64: geo.GeospatialService.SubscribeToDriverLocations:output_type -> geo.DriverLocationEvent
+	17, // 65: geo.GeospatialService.StartLocationTracking:output_type -> geo.StartLocationTrackingResponse
+	20, // 66: geo.GeospatialService.FindZones:output_type -> geo.FindZonesResponse
+	25, // 67: geo.GeospatialService.GetTripRoute:output_type -> geo.GetTripRouteResponse
+	27, // 68: geo.GeospatialService.DeleteTripRoutes:output_type -> geo.DeleteTripRoutesResponse
+	32, // 69: geo.GeospatialService.IngestDriverLocations:output_type -> geo.IngestDriverLocationsResponse
+	34, // 70: geo.GeospatialService.StreamPickupETA:output_type -> geo.PickupETAUpdate
+	37, // 71: geo.GeospatialService.ListDriverShifts:output_type -> geo.ListDriverShiftsResponse
+	40, // 72: geo.GeospatialService.GetDriverOnlineHours:output_type -> geo.GetDriverOnlineHoursResponse
+	23, // 73: geo.GeospatialService.GetZoneQueue:output_type -> geo.GetZoneQueueResponse
+	58, // [58:74] is the sub-list for method output_type
+	42, // [42:58] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
 }
 
 func init() { file_shared_proto_geo_geo_proto_init() }
@@ -1500,7 +3269,7 @@ func file_shared_proto_geo_geo_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_shared_proto_geo_geo_proto_rawDesc), len(file_shared_proto_geo_geo_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   19,
+			NumMessages:   42,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -27,6 +27,14 @@ const (
 	GeospatialService_OptimizeRoute_FullMethodName              = "/geo.GeospatialService/OptimizeRoute"
 	GeospatialService_SubscribeToDriverLocations_FullMethodName = "/geo.GeospatialService/SubscribeToDriverLocations"
 	GeospatialService_StartLocationTracking_FullMethodName      = "/geo.GeospatialService/StartLocationTracking"
+	GeospatialService_FindZones_FullMethodName                  = "/geo.GeospatialService/FindZones"
+	GeospatialService_GetTripRoute_FullMethodName               = "/geo.GeospatialService/GetTripRoute"
+	GeospatialService_DeleteTripRoutes_FullMethodName           = "/geo.GeospatialService/DeleteTripRoutes"
+	GeospatialService_IngestDriverLocations_FullMethodName      = "/geo.GeospatialService/IngestDriverLocations"
+	GeospatialService_StreamPickupETA_FullMethodName            = "/geo.GeospatialService/StreamPickupETA"
+	GeospatialService_ListDriverShifts_FullMethodName           = "/geo.GeospatialService/ListDriverShifts"
+	GeospatialService_GetDriverOnlineHours_FullMethodName       = "/geo.GeospatialService/GetDriverOnlineHours"
+	GeospatialService_GetZoneQueue_FullMethodName               = "/geo.GeospatialService/GetZoneQueue"
 )
 
 // GeospatialServiceClient is the client API for GeospatialService service.
@@ -51,6 +59,22 @@ type GeospatialServiceClient interface {
 	SubscribeToDriverLocations(ctx context.Context, in *SubscribeToDriverLocationRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DriverLocationEvent], error)
 	// Start location tracking session for a driver
 	StartLocationTracking(ctx context.Context, in *StartLocationTrackingRequest, opts ...grpc.CallOption) (*StartLocationTrackingResponse, error)
+	// Find the active zones containing a location
+	FindZones(ctx context.Context, in *FindZonesRequest, opts ...grpc.CallOption) (*FindZonesResponse, error)
+	// Get the route recorded from driver locations during a trip
+	GetTripRoute(ctx context.Context, in *GetTripRouteRequest, opts ...grpc.CallOption) (*GetTripRouteResponse, error)
+	// Erase the routes recorded during trips
+	DeleteTripRoutes(ctx context.Context, in *DeleteTripRoutesRequest, opts ...grpc.CallOption) (*DeleteTripRoutesResponse, error)
+	// Upload locations drivers buffered, in batches per driver
+	IngestDriverLocations(ctx context.Context, in *IngestDriverLocationsRequest, opts ...grpc.CallOption) (*IngestDriverLocationsResponse, error)
+	// Stream the driver's ETA to the pickup until they arrive
+	StreamPickupETA(ctx context.Context, in *StreamPickupETARequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PickupETAUpdate], error)
+	// List the shifts a driver worked in a time range
+	ListDriverShifts(ctx context.Context, in *ListDriverShiftsRequest, opts ...grpc.CallOption) (*ListDriverShiftsResponse, error)
+	// Get a driver's online, on-trip and break time per day
+	GetDriverOnlineHours(ctx context.Context, in *GetDriverOnlineHoursRequest, opts ...grpc.CallOption) (*GetDriverOnlineHoursResponse, error)
+	// List the drivers waiting in a dispatch zone's queue, head first
+	GetZoneQueue(ctx context.Context, in *GetZoneQueueRequest, opts ...grpc.CallOption) (*GetZoneQueueResponse, error)
 }
 
 type geospatialServiceClient struct {
@@ -150,6 +174,95 @@ func (c *geospatialServiceClient) StartLocationTracking(ctx context.Context, in
 	return out, nil
 }
 
+func (c *geospatialServiceClient) FindZones(ctx context.Context, in *FindZonesRequest, opts ...grpc.CallOption) (*FindZonesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindZonesResponse)
+	err := c.cc.Invoke(ctx, GeospatialService_FindZones_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geospatialServiceClient) GetTripRoute(ctx context.Context, in *GetTripRouteRequest, opts ...grpc.CallOption) (*GetTripRouteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTripRouteResponse)
+	err := c.cc.Invoke(ctx, GeospatialService_GetTripRoute_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geospatialServiceClient) DeleteTripRoutes(ctx context.Context, in *DeleteTripRoutesRequest, opts ...grpc.CallOption) (*DeleteTripRoutesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteTripRoutesResponse)
+	err := c.cc.Invoke(ctx, GeospatialService_DeleteTripRoutes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geospatialServiceClient) IngestDriverLocations(ctx context.Context, in *IngestDriverLocationsRequest, opts ...grpc.CallOption) (*IngestDriverLocationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IngestDriverLocationsResponse)
+	err := c.cc.Invoke(ctx, GeospatialService_IngestDriverLocations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geospatialServiceClient) StreamPickupETA(ctx context.Context, in *StreamPickupETARequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PickupETAUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GeospatialService_ServiceDesc.Streams[1], GeospatialService_StreamPickupETA_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamPickupETARequest, PickupETAUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GeospatialService_StreamPickupETAClient = grpc.ServerStreamingClient[PickupETAUpdate]
+
+func (c *geospatialServiceClient) ListDriverShifts(ctx context.Context, in *ListDriverShiftsRequest, opts ...grpc.CallOption) (*ListDriverShiftsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDriverShiftsResponse)
+	err := c.cc.Invoke(ctx, GeospatialService_ListDriverShifts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geospatialServiceClient) GetDriverOnlineHours(ctx context.Context, in *GetDriverOnlineHoursRequest, opts ...grpc.CallOption) (*GetDriverOnlineHoursResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDriverOnlineHoursResponse)
+	err := c.cc.Invoke(ctx, GeospatialService_GetDriverOnlineHours_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *geospatialServiceClient) GetZoneQueue(ctx context.Context, in *GetZoneQueueRequest, opts ...grpc.CallOption) (*GetZoneQueueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetZoneQueueResponse)
+	err := c.cc.Invoke(ctx, GeospatialService_GetZoneQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GeospatialServiceServer is the server API for GeospatialService service.
 // All implementations must embed UnimplementedGeospatialServiceServer
 // for forward compatibility.
@@ -172,6 +285,22 @@ type GeospatialServiceServer interface {
 	SubscribeToDriverLocations(*SubscribeToDriverLocationRequest, grpc.ServerStreamingServer[DriverLocationEvent]) error
 	// Start location tracking session for a driver
 	StartLocationTracking(context.Context, *StartLocationTrackingRequest) (*StartLocationTrackingResponse, error)
+	// Find the active zones containing a location
+	FindZones(context.Context, *FindZonesRequest) (*FindZonesResponse, error)
+	// Get the route recorded from driver locations during a trip
+	GetTripRoute(context.Context, *GetTripRouteRequest) (*GetTripRouteResponse, error)
+	// Erase the routes recorded during trips
+	DeleteTripRoutes(context.Context, *DeleteTripRoutesRequest) (*DeleteTripRoutesResponse, error)
+	// Upload locations drivers buffered, in batches per driver
+	IngestDriverLocations(context.Context, *IngestDriverLocationsRequest) (*IngestDriverLocationsResponse, error)
+	// Stream the driver's ETA to the pickup until they arrive
+	StreamPickupETA(*StreamPickupETARequest, grpc.ServerStreamingServer[PickupETAUpdate]) error
+	// List the shifts a driver worked in a time range
+	ListDriverShifts(context.Context, *ListDriverShiftsRequest) (*ListDriverShiftsResponse, error)
+	// Get a driver's online, on-trip and break time per day
+	GetDriverOnlineHours(context.Context, *GetDriverOnlineHoursRequest) (*GetDriverOnlineHoursResponse, error)
+	// List the drivers waiting in a dispatch zone's queue, head first
+	GetZoneQueue(context.Context, *GetZoneQueueRequest) (*GetZoneQueueResponse, error)
 	mustEmbedUnimplementedGeospatialServiceServer()
 }
 
@@ -206,6 +335,30 @@ func (UnimplementedGeospatialServiceServer) SubscribeToDriverLocations(*Subscrib
 func (UnimplementedGeospatialServiceServer) StartLocationTracking(context.Context, *StartLocationTrackingRequest) (*StartLocationTrackingResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StartLocationTracking not implemented")
 }
+func (UnimplementedGeospatialServiceServer) FindZones(context.Context, *FindZonesRequest) (*FindZonesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindZones not implemented")
+}
+func (UnimplementedGeospatialServiceServer) GetTripRoute(context.Context, *GetTripRouteRequest) (*GetTripRouteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTripRoute not implemented")
+}
+func (UnimplementedGeospatialServiceServer) DeleteTripRoutes(context.Context, *DeleteTripRoutesRequest) (*DeleteTripRoutesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTripRoutes not implemented")
+}
+func (UnimplementedGeospatialServiceServer) IngestDriverLocations(context.Context, *IngestDriverLocationsRequest) (*IngestDriverLocationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IngestDriverLocations not implemented")
+}
+func (UnimplementedGeospatialServiceServer) StreamPickupETA(*StreamPickupETARequest, grpc.ServerStreamingServer[PickupETAUpdate]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamPickupETA not implemented")
+}
+func (UnimplementedGeospatialServiceServer) ListDriverShifts(context.Context, *ListDriverShiftsRequest) (*ListDriverShiftsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDriverShifts not implemented")
+}
+func (UnimplementedGeospatialServiceServer) GetDriverOnlineHours(context.Context, *GetDriverOnlineHoursRequest) (*GetDriverOnlineHoursResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDriverOnlineHours not implemented")
+}
+func (UnimplementedGeospatialServiceServer) GetZoneQueue(context.Context, *GetZoneQueueRequest) (*GetZoneQueueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetZoneQueue not implemented")
+}
 func (UnimplementedGeospatialServiceServer) mustEmbedUnimplementedGeospatialServiceServer() {}
 func (UnimplementedGeospatialServiceServer) testEmbeddedByValue()                           {}
 
@@ -364,6 +517,143 @@ func _GeospatialService_StartLocationTracking_Handler(srv interface{}, ctx conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GeospatialService_FindZones_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindZonesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeospatialServiceServer).FindZones(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeospatialService_FindZones_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeospatialServiceServer).FindZones(ctx, req.(*FindZonesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeospatialService_GetTripRoute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTripRouteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeospatialServiceServer).GetTripRoute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeospatialService_GetTripRoute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeospatialServiceServer).GetTripRoute(ctx, req.(*GetTripRouteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeospatialService_DeleteTripRoutes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTripRoutesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeospatialServiceServer).DeleteTripRoutes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeospatialService_DeleteTripRoutes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeospatialServiceServer).DeleteTripRoutes(ctx, req.(*DeleteTripRoutesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeospatialService_IngestDriverLocations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IngestDriverLocationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeospatialServiceServer).IngestDriverLocations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeospatialService_IngestDriverLocations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeospatialServiceServer).IngestDriverLocations(ctx, req.(*IngestDriverLocationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeospatialService_StreamPickupETA_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamPickupETARequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GeospatialServiceServer).StreamPickupETA(m, &grpc.GenericServerStream[StreamPickupETARequest, PickupETAUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GeospatialService_StreamPickupETAServer = grpc.ServerStreamingServer[PickupETAUpdate]
+
+func _GeospatialService_ListDriverShifts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDriverShiftsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeospatialServiceServer).ListDriverShifts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeospatialService_ListDriverShifts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeospatialServiceServer).ListDriverShifts(ctx, req.(*ListDriverShiftsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeospatialService_GetDriverOnlineHours_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDriverOnlineHoursRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeospatialServiceServer).GetDriverOnlineHours(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeospatialService_GetDriverOnlineHours_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeospatialServiceServer).GetDriverOnlineHours(ctx, req.(*GetDriverOnlineHoursRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GeospatialService_GetZoneQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetZoneQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GeospatialServiceServer).GetZoneQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GeospatialService_GetZoneQueue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GeospatialServiceServer).GetZoneQueue(ctx, req.(*GetZoneQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // GeospatialService_ServiceDesc is the grpc.ServiceDesc for GeospatialService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -399,6 +689,34 @@ var GeospatialService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "StartLocationTracking",
 			Handler:    _GeospatialService_StartLocationTracking_Handler,
 		},
+		{
+			MethodName: "FindZones",
+			Handler:    _GeospatialService_FindZones_Handler,
+		},
+		{
+			MethodName: "GetTripRoute",
+			Handler:    _GeospatialService_GetTripRoute_Handler,
+		},
+		{
+			MethodName: "DeleteTripRoutes",
+			Handler:    _GeospatialService_DeleteTripRoutes_Handler,
+		},
+		{
+			MethodName: "IngestDriverLocations",
+			Handler:    _GeospatialService_IngestDriverLocations_Handler,
+		},
+		{
+			MethodName: "ListDriverShifts",
+			Handler:    _GeospatialService_ListDriverShifts_Handler,
+		},
+		{
+			MethodName: "GetDriverOnlineHours",
+			Handler:    _GeospatialService_GetDriverOnlineHours_Handler,
+		},
+		{
+			MethodName: "GetZoneQueue",
+			Handler:    _GeospatialService_GetZoneQueue_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -406,6 +724,11 @@ var GeospatialService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _GeospatialService_SubscribeToDriverLocations_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "StreamPickupETA",
+			Handler:       _GeospatialService_StreamPickupETA_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "shared/proto/geo/geo.proto",
 }
@@ -97,6 +97,9 @@ type Driver struct {
 	DistanceKm      float64                `protobuf:"fixed64,9,opt,name=distance_km,json=distanceKm,proto3" json:"distance_km,omitempty"`
 	EtaMinutes      int32                  `protobuf:"varint,10,opt,name=eta_minutes,json=etaMinutes,proto3" json:"eta_minutes,omitempty"`
 	Score           *MatchingScore         `protobuf:"bytes,11,opt,name=score,proto3" json:"score,omitempty"`
+	Name            string                 `protobuf:"bytes,12,opt,name=name,proto3" json:"name,omitempty"`
+	PhotoUrl        string                 `protobuf:"bytes,13,opt,name=photo_url,json=photoUrl,proto3" json:"photo_url,omitempty"`
+	Vehicle         *Vehicle               `protobuf:"bytes,14,opt,name=vehicle,proto3" json:"vehicle,omitempty"`
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -208,6 +211,128 @@ func (x *Driver) GetScore() *MatchingScore {
 	return nil
 }
 
+func (x *Driver) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Driver) GetPhotoUrl() string {
+	if x != nil {
+		return x.PhotoUrl
+	}
+	return ""
+}
+
+func (x *Driver) GetVehicle() *Vehicle {
+	if x != nil {
+		return x.Vehicle
+	}
+	return nil
+}
+
+// Vehicle the matched driver is coming in
+type Vehicle struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Make          string                 `protobuf:"bytes,1,opt,name=make,proto3" json:"make,omitempty"`
+	Model         string                 `protobuf:"bytes,2,opt,name=model,proto3" json:"model,omitempty"`
+	Year          int32                  `protobuf:"varint,3,opt,name=year,proto3" json:"year,omitempty"`
+	Color         string                 `protobuf:"bytes,4,opt,name=color,proto3" json:"color,omitempty"`
+	LicensePlate  string                 `protobuf:"bytes,5,opt,name=license_plate,json=licensePlate,proto3" json:"license_plate,omitempty"`
+	Capacity      int32                  `protobuf:"varint,6,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	Features      []string               `protobuf:"bytes,7,rep,name=features,proto3" json:"features,omitempty"`
+	PhotoUrl      string                 `protobuf:"bytes,8,opt,name=photo_url,json=photoUrl,proto3" json:"photo_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Vehicle) Reset() {
+	*x = Vehicle{}
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Vehicle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Vehicle) ProtoMessage() {}
+
+func (x *Vehicle) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Vehicle.ProtoReflect.Descriptor instead.
+func (*Vehicle) Descriptor() ([]byte, []int) {
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Vehicle) GetMake() string {
+	if x != nil {
+		return x.Make
+	}
+	return ""
+}
+
+func (x *Vehicle) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *Vehicle) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *Vehicle) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *Vehicle) GetLicensePlate() string {
+	if x != nil {
+		return x.LicensePlate
+	}
+	return ""
+}
+
+func (x *Vehicle) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *Vehicle) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *Vehicle) GetPhotoUrl() string {
+	if x != nil {
+		return x.PhotoUrl
+	}
+	return ""
+}
+
 // Matching score breakdown
 type MatchingScore struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
@@ -223,7 +348,7 @@ type MatchingScore struct {
 
 func (x *MatchingScore) Reset() {
 	*x = MatchingScore{}
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[2]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -235,7 +360,7 @@ func (x *MatchingScore) String() string {
 func (*MatchingScore) ProtoMessage() {}
 
 func (x *MatchingScore) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[2]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -248,7 +373,7 @@ func (x *MatchingScore) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MatchingScore.ProtoReflect.Descriptor instead.
 func (*MatchingScore) Descriptor() ([]byte, []int) {
-	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{2}
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *MatchingScore) GetTotalScore() float64 {
@@ -304,13 +429,16 @@ type RideRequest struct {
 	PassengerCount int32                  `protobuf:"varint,6,opt,name=passenger_count,json=passengerCount,proto3" json:"passenger_count,omitempty"`
 	RequestedAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"`
 	Preferences    map[string]string      `protobuf:"bytes,8,rep,name=preferences,proto3" json:"preferences,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ScheduledFor   *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=scheduled_for,json=scheduledFor,proto3" json:"scheduled_for,omitempty"`      // pickup time of a pre-dispatched scheduled ride
+	City           string                 `protobuf:"bytes,10,opt,name=city,proto3" json:"city,omitempty"`                                         // city the ride starts in, which sets the currency it is quoted in
+	PriorityLevel  int32                  `protobuf:"varint,11,opt,name=priority_level,json=priorityLevel,proto3" json:"priority_level,omitempty"` // 1=normal, 2=premium, 3=emergency; orders requests when matching is overloaded
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
 func (x *RideRequest) Reset() {
 	*x = RideRequest{}
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[3]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -322,7 +450,7 @@ func (x *RideRequest) String() string {
 func (*RideRequest) ProtoMessage() {}
 
 func (x *RideRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[3]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -335,7 +463,7 @@ func (x *RideRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RideRequest.ProtoReflect.Descriptor instead.
 func (*RideRequest) Descriptor() ([]byte, []int) {
-	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{3}
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *RideRequest) GetId() string {
@@ -394,6 +522,27 @@ func (x *RideRequest) GetPreferences() map[string]string {
 	return nil
 }
 
+func (x *RideRequest) GetScheduledFor() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ScheduledFor
+	}
+	return nil
+}
+
+func (x *RideRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *RideRequest) GetPriorityLevel() int32 {
+	if x != nil {
+		return x.PriorityLevel
+	}
+	return 0
+}
+
 // Matching result
 type MatchResult struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
@@ -409,7 +558,7 @@ type MatchResult struct {
 
 func (x *MatchResult) Reset() {
 	*x = MatchResult{}
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[4]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -421,7 +570,7 @@ func (x *MatchResult) String() string {
 func (*MatchResult) ProtoMessage() {}
 
 func (x *MatchResult) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[4]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -434,7 +583,7 @@ func (x *MatchResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MatchResult.ProtoReflect.Descriptor instead.
 func (*MatchResult) Descriptor() ([]byte, []int) {
-	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{4}
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *MatchResult) GetRequestId() string {
@@ -494,7 +643,7 @@ type MatchingMetadata struct {
 
 func (x *MatchingMetadata) Reset() {
 	*x = MatchingMetadata{}
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[5]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -506,7 +655,7 @@ func (x *MatchingMetadata) String() string {
 func (*MatchingMetadata) ProtoMessage() {}
 
 func (x *MatchingMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[5]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -519,7 +668,7 @@ func (x *MatchingMetadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MatchingMetadata.ProtoReflect.Descriptor instead.
 func (*MatchingMetadata) Descriptor() ([]byte, []int) {
-	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{5}
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *MatchingMetadata) GetTotalDriversConsidered() int32 {
@@ -578,7 +727,7 @@ type DriverLocationUpdate struct {
 
 func (x *DriverLocationUpdate) Reset() {
 	*x = DriverLocationUpdate{}
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[6]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -590,7 +739,7 @@ func (x *DriverLocationUpdate) String() string {
 func (*DriverLocationUpdate) ProtoMessage() {}
 
 func (x *DriverLocationUpdate) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[6]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -603,7 +752,7 @@ func (x *DriverLocationUpdate) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DriverLocationUpdate.ProtoReflect.Descriptor instead.
 func (*DriverLocationUpdate) Descriptor() ([]byte, []int) {
-	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{6}
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *DriverLocationUpdate) GetDriverId() string {
@@ -655,7 +804,7 @@ type FindNearbyDriversRequest struct {
 
 func (x *FindNearbyDriversRequest) Reset() {
 	*x = FindNearbyDriversRequest{}
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[7]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -667,7 +816,7 @@ func (x *FindNearbyDriversRequest) String() string {
 func (*FindNearbyDriversRequest) ProtoMessage() {}
 
 func (x *FindNearbyDriversRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[7]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -680,7 +829,7 @@ func (x *FindNearbyDriversRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FindNearbyDriversRequest.ProtoReflect.Descriptor instead.
 func (*FindNearbyDriversRequest) Descriptor() ([]byte, []int) {
-	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{7}
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *FindNearbyDriversRequest) GetPickupLocation() *Location {
@@ -729,7 +878,7 @@ type FindNearbyDriversResponse struct {
 
 func (x *FindNearbyDriversResponse) Reset() {
 	*x = FindNearbyDriversResponse{}
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[8]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -741,7 +890,7 @@ func (x *FindNearbyDriversResponse) String() string {
 func (*FindNearbyDriversResponse) ProtoMessage() {}
 
 func (x *FindNearbyDriversResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[8]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -754,7 +903,7 @@ func (x *FindNearbyDriversResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FindNearbyDriversResponse.ProtoReflect.Descriptor instead.
 func (*FindNearbyDriversResponse) Descriptor() ([]byte, []int) {
-	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{8}
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *FindNearbyDriversResponse) GetDrivers() []*Driver {
@@ -788,7 +937,7 @@ type MatchDriverRequest struct {
 
 func (x *MatchDriverRequest) Reset() {
 	*x = MatchDriverRequest{}
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[9]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -800,7 +949,7 @@ func (x *MatchDriverRequest) String() string {
 func (*MatchDriverRequest) ProtoMessage() {}
 
 func (x *MatchDriverRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[9]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -813,7 +962,7 @@ func (x *MatchDriverRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MatchDriverRequest.ProtoReflect.Descriptor instead.
 func (*MatchDriverRequest) Descriptor() ([]byte, []int) {
-	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{9}
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *MatchDriverRequest) GetRideRequest() *RideRequest {
@@ -837,13 +986,15 @@ type MatchingPreferences struct {
 	PreferExperiencedDrivers bool                   `protobuf:"varint,3,opt,name=prefer_experienced_drivers,json=preferExperiencedDrivers,proto3" json:"prefer_experienced_drivers,omitempty"`
 	AllowPoolMatching        bool                   `protobuf:"varint,4,opt,name=allow_pool_matching,json=allowPoolMatching,proto3" json:"allow_pool_matching,omitempty"`
 	CustomPreferences        map[string]string      `protobuf:"bytes,5,rep,name=custom_preferences,json=customPreferences,proto3" json:"custom_preferences,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields            protoimpl.UnknownFields
-	sizeCache                protoimpl.SizeCache
+	// Vehicle features the rider needs, e.g. "wheelchair_accessible"
+	AccessibilityNeeds []string `protobuf:"bytes,6,rep,name=accessibility_needs,json=accessibilityNeeds,proto3" json:"accessibility_needs,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *MatchingPreferences) Reset() {
 	*x = MatchingPreferences{}
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[10]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -855,7 +1006,7 @@ func (x *MatchingPreferences) String() string {
 func (*MatchingPreferences) ProtoMessage() {}
 
 func (x *MatchingPreferences) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[10]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -868,7 +1019,7 @@ func (x *MatchingPreferences) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MatchingPreferences.ProtoReflect.Descriptor instead.
 func (*MatchingPreferences) Descriptor() ([]byte, []int) {
-	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{10}
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *MatchingPreferences) GetMaxPickupDistanceKm() float64 {
@@ -906,6 +1057,13 @@ func (x *MatchingPreferences) GetCustomPreferences() map[string]string {
 	return nil
 }
 
+func (x *MatchingPreferences) GetAccessibilityNeeds() []string {
+	if x != nil {
+		return x.AccessibilityNeeds
+	}
+	return nil
+}
+
 type MatchDriverResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Result        *MatchResult           `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
@@ -918,7 +1076,7 @@ type MatchDriverResponse struct {
 
 func (x *MatchDriverResponse) Reset() {
 	*x = MatchDriverResponse{}
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[11]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -930,7 +1088,7 @@ func (x *MatchDriverResponse) String() string {
 func (*MatchDriverResponse) ProtoMessage() {}
 
 func (x *MatchDriverResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[11]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -943,7 +1101,7 @@ func (x *MatchDriverResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MatchDriverResponse.ProtoReflect.Descriptor instead.
 func (*MatchDriverResponse) Descriptor() ([]byte, []int) {
-	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{11}
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *MatchDriverResponse) GetResult() *MatchResult {
@@ -986,7 +1144,7 @@ type UpdateDriverLocationRequest struct {
 
 func (x *UpdateDriverLocationRequest) Reset() {
 	*x = UpdateDriverLocationRequest{}
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[12]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -998,7 +1156,7 @@ func (x *UpdateDriverLocationRequest) String() string {
 func (*UpdateDriverLocationRequest) ProtoMessage() {}
 
 func (x *UpdateDriverLocationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[12]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1011,7 +1169,7 @@ func (x *UpdateDriverLocationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateDriverLocationRequest.ProtoReflect.Descriptor instead.
 func (*UpdateDriverLocationRequest) Descriptor() ([]byte, []int) {
-	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{12}
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *UpdateDriverLocationRequest) GetDriverId() string {
@@ -1052,7 +1210,7 @@ type UpdateDriverLocationResponse struct {
 
 func (x *UpdateDriverLocationResponse) Reset() {
 	*x = UpdateDriverLocationResponse{}
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[13]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1064,7 +1222,7 @@ func (x *UpdateDriverLocationResponse) String() string {
 func (*UpdateDriverLocationResponse) ProtoMessage() {}
 
 func (x *UpdateDriverLocationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[13]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1077,7 +1235,7 @@ func (x *UpdateDriverLocationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateDriverLocationResponse.ProtoReflect.Descriptor instead.
 func (*UpdateDriverLocationResponse) Descriptor() ([]byte, []int) {
-	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{13}
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *UpdateDriverLocationResponse) GetSuccess() bool {
@@ -1103,7 +1261,7 @@ type GetDriverRequest struct {
 
 func (x *GetDriverRequest) Reset() {
 	*x = GetDriverRequest{}
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[14]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1115,7 +1273,7 @@ func (x *GetDriverRequest) String() string {
 func (*GetDriverRequest) ProtoMessage() {}
 
 func (x *GetDriverRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[14]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1128,7 +1286,7 @@ func (x *GetDriverRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDriverRequest.ProtoReflect.Descriptor instead.
 func (*GetDriverRequest) Descriptor() ([]byte, []int) {
-	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{14}
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *GetDriverRequest) GetDriverId() string {
@@ -1148,7 +1306,7 @@ type GetDriverResponse struct {
 
 func (x *GetDriverResponse) Reset() {
 	*x = GetDriverResponse{}
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[15]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1160,7 +1318,7 @@ func (x *GetDriverResponse) String() string {
 func (*GetDriverResponse) ProtoMessage() {}
 
 func (x *GetDriverResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[15]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1173,7 +1331,7 @@ func (x *GetDriverResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDriverResponse.ProtoReflect.Descriptor instead.
 func (*GetDriverResponse) Descriptor() ([]byte, []int) {
-	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{15}
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *GetDriverResponse) GetDriver() *Driver {
@@ -1202,7 +1360,7 @@ type GetActiveDriversRequest struct {
 
 func (x *GetActiveDriversRequest) Reset() {
 	*x = GetActiveDriversRequest{}
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[16]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1214,7 +1372,7 @@ func (x *GetActiveDriversRequest) String() string {
 func (*GetActiveDriversRequest) ProtoMessage() {}
 
 func (x *GetActiveDriversRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[16]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1227,7 +1385,7 @@ func (x *GetActiveDriversRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetActiveDriversRequest.ProtoReflect.Descriptor instead.
 func (*GetActiveDriversRequest) Descriptor() ([]byte, []int) {
-	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{16}
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *GetActiveDriversRequest) GetCenter() *Location {
@@ -1269,7 +1427,7 @@ type GetActiveDriversResponse struct {
 
 func (x *GetActiveDriversResponse) Reset() {
 	*x = GetActiveDriversResponse{}
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[17]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1281,7 +1439,7 @@ func (x *GetActiveDriversResponse) String() string {
 func (*GetActiveDriversResponse) ProtoMessage() {}
 
 func (x *GetActiveDriversResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[17]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1294,7 +1452,7 @@ func (x *GetActiveDriversResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetActiveDriversResponse.ProtoReflect.Descriptor instead.
 func (*GetActiveDriversResponse) Descriptor() ([]byte, []int) {
-	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{17}
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *GetActiveDriversResponse) GetDrivers() []*Driver {
@@ -1328,7 +1486,7 @@ type BatchUpdateDriversRequest struct {
 
 func (x *BatchUpdateDriversRequest) Reset() {
 	*x = BatchUpdateDriversRequest{}
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[18]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1340,7 +1498,7 @@ func (x *BatchUpdateDriversRequest) String() string {
 func (*BatchUpdateDriversRequest) ProtoMessage() {}
 
 func (x *BatchUpdateDriversRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[18]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1353,7 +1511,7 @@ func (x *BatchUpdateDriversRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BatchUpdateDriversRequest.ProtoReflect.Descriptor instead.
 func (*BatchUpdateDriversRequest) Descriptor() ([]byte, []int) {
-	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{18}
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *BatchUpdateDriversRequest) GetUpdates() []*DriverLocationUpdate {
@@ -1374,7 +1532,7 @@ type BatchUpdateDriversResponse struct {
 
 func (x *BatchUpdateDriversResponse) Reset() {
 	*x = BatchUpdateDriversResponse{}
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[19]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1386,7 +1544,7 @@ func (x *BatchUpdateDriversResponse) String() string {
 func (*BatchUpdateDriversResponse) ProtoMessage() {}
 
 func (x *BatchUpdateDriversResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[19]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1399,7 +1557,7 @@ func (x *BatchUpdateDriversResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BatchUpdateDriversResponse.ProtoReflect.Descriptor instead.
 func (*BatchUpdateDriversResponse) Descriptor() ([]byte, []int) {
-	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{19}
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *BatchUpdateDriversResponse) GetSuccessfulUpdates() int32 {
@@ -1434,7 +1592,7 @@ type GetMatchingStatsRequest struct {
 
 func (x *GetMatchingStatsRequest) Reset() {
 	*x = GetMatchingStatsRequest{}
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[20]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1446,7 +1604,7 @@ func (x *GetMatchingStatsRequest) String() string {
 func (*GetMatchingStatsRequest) ProtoMessage() {}
 
 func (x *GetMatchingStatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[20]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1459,7 +1617,7 @@ func (x *GetMatchingStatsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMatchingStatsRequest.ProtoReflect.Descriptor instead.
 func (*GetMatchingStatsRequest) Descriptor() ([]byte, []int) {
-	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{20}
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *GetMatchingStatsRequest) GetFromTime() *timestamppb.Timestamp {
@@ -1491,7 +1649,7 @@ type MatchingStats struct {
 
 func (x *MatchingStats) Reset() {
 	*x = MatchingStats{}
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[21]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1503,7 +1661,7 @@ func (x *MatchingStats) String() string {
 func (*MatchingStats) ProtoMessage() {}
 
 func (x *MatchingStats) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[21]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1516,7 +1674,7 @@ func (x *MatchingStats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MatchingStats.ProtoReflect.Descriptor instead.
 func (*MatchingStats) Descriptor() ([]byte, []int) {
-	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{21}
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *MatchingStats) GetTotalRequests() int32 {
@@ -1578,7 +1736,7 @@ type GetMatchingStatsResponse struct {
 
 func (x *GetMatchingStatsResponse) Reset() {
 	*x = GetMatchingStatsResponse{}
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[22]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1590,7 +1748,7 @@ func (x *GetMatchingStatsResponse) String() string {
 func (*GetMatchingStatsResponse) ProtoMessage() {}
 
 func (x *GetMatchingStatsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_matching_matching_proto_msgTypes[22]
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1603,7 +1761,7 @@ func (x *GetMatchingStatsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMatchingStatsResponse.ProtoReflect.Descriptor instead.
 func (*GetMatchingStatsResponse) Descriptor() ([]byte, []int) {
-	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{22}
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *GetMatchingStatsResponse) GetStats() *MatchingStats {
@@ -1620,40 +1778,1352 @@ func (x *GetMatchingStatsResponse) GetSuccess() bool {
 	return false
 }
 
-var File_shared_proto_matching_matching_proto protoreflect.FileDescriptor
+// Driver offers
+type DriverOffer struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	OfferId        string                 `protobuf:"bytes,1,opt,name=offer_id,json=offerId,proto3" json:"offer_id,omitempty"`
+	TripId         string                 `protobuf:"bytes,2,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	RiderId        string                 `protobuf:"bytes,3,opt,name=rider_id,json=riderId,proto3" json:"rider_id,omitempty"`
+	DriverId       string                 `protobuf:"bytes,4,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	PickupLocation *Location              `protobuf:"bytes,5,opt,name=pickup_location,json=pickupLocation,proto3" json:"pickup_location,omitempty"`
+	Destination    *Location              `protobuf:"bytes,6,opt,name=destination,proto3" json:"destination,omitempty"`
+	Status         string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	Attempt        int32                  `protobuf:"varint,8,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	EtaSeconds     int32                  `protobuf:"varint,9,opt,name=eta_seconds,json=etaSeconds,proto3" json:"eta_seconds,omitempty"`
+	DistanceKm     float64                `protobuf:"fixed64,10,opt,name=distance_km,json=distanceKm,proto3" json:"distance_km,omitempty"`
+	EstimatedFare  float64                `protobuf:"fixed64,11,opt,name=estimated_fare,json=estimatedFare,proto3" json:"estimated_fare,omitempty"`
+	OfferedAt      *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=offered_at,json=offeredAt,proto3" json:"offered_at,omitempty"`
+	ExpiresAt      *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
 
-const file_shared_proto_matching_matching_proto_rawDesc = "" +
-	"\n" +
-	"$shared/proto/matching/matching.proto\x12\bmatching\x1a\x1fgoogle/protobuf/timestamp.proto\"^\n" +
-	"\bLocation\x12\x1a\n" +
-	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
-	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\x12\x18\n" +
-	"\aaddress\x18\x03 \x01(\tR\aaddress\"\xff\x02\n" +
-	"\x06Driver\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\x12=\n" +
-	"\x10current_location\x18\x03 \x01(\v2\x12.matching.LocationR\x0fcurrentLocation\x12\x1d\n" +
-	"\n" +
-	"vehicle_id\x18\x04 \x01(\tR\tvehicleId\x12!\n" +
-	"\fvehicle_type\x18\x05 \x01(\tR\vvehicleType\x12\x16\n" +
-	"\x06rating\x18\x06 \x01(\x01R\x06rating\x12\x1f\n" +
-	"\vtotal_trips\x18\a \x01(\x05R\n" +
-	"totalTrips\x12!\n" +
-	"\fis_available\x18\b \x01(\bR\visAvailable\x12\x1f\n" +
-	"\vdistance_km\x18\t \x01(\x01R\n" +
-	"distanceKm\x12\x1f\n" +
-	"\veta_minutes\x18\n" +
-	" \x01(\x05R\n" +
-	"etaMinutes\x12-\n" +
-	"\x05score\x18\v \x01(\v2\x17.matching.MatchingScoreR\x05score\"\xf7\x01\n" +
-	"\rMatchingScore\x12\x1f\n" +
-	"\vtotal_score\x18\x01 \x01(\x01R\n" +
-	"totalScore\x12%\n" +
+func (x *DriverOffer) Reset() {
+	*x = DriverOffer{}
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriverOffer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriverOffer) ProtoMessage() {}
+
+func (x *DriverOffer) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriverOffer.ProtoReflect.Descriptor instead.
+func (*DriverOffer) Descriptor() ([]byte, []int) {
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *DriverOffer) GetOfferId() string {
+	if x != nil {
+		return x.OfferId
+	}
+	return ""
+}
+
+func (x *DriverOffer) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *DriverOffer) GetRiderId() string {
+	if x != nil {
+		return x.RiderId
+	}
+	return ""
+}
+
+func (x *DriverOffer) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *DriverOffer) GetPickupLocation() *Location {
+	if x != nil {
+		return x.PickupLocation
+	}
+	return nil
+}
+
+func (x *DriverOffer) GetDestination() *Location {
+	if x != nil {
+		return x.Destination
+	}
+	return nil
+}
+
+func (x *DriverOffer) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *DriverOffer) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+func (x *DriverOffer) GetEtaSeconds() int32 {
+	if x != nil {
+		return x.EtaSeconds
+	}
+	return 0
+}
+
+func (x *DriverOffer) GetDistanceKm() float64 {
+	if x != nil {
+		return x.DistanceKm
+	}
+	return 0
+}
+
+func (x *DriverOffer) GetEstimatedFare() float64 {
+	if x != nil {
+		return x.EstimatedFare
+	}
+	return 0
+}
+
+func (x *DriverOffer) GetOfferedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OfferedAt
+	}
+	return nil
+}
+
+func (x *DriverOffer) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type AcceptOfferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TripId        string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	DriverId      string                 `protobuf:"bytes,2,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptOfferRequest) Reset() {
+	*x = AcceptOfferRequest{}
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptOfferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptOfferRequest) ProtoMessage() {}
+
+func (x *AcceptOfferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptOfferRequest.ProtoReflect.Descriptor instead.
+func (*AcceptOfferRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *AcceptOfferRequest) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *AcceptOfferRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+type AcceptOfferResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Offer         *DriverOffer           `protobuf:"bytes,1,opt,name=offer,proto3" json:"offer,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptOfferResponse) Reset() {
+	*x = AcceptOfferResponse{}
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptOfferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptOfferResponse) ProtoMessage() {}
+
+func (x *AcceptOfferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptOfferResponse.ProtoReflect.Descriptor instead.
+func (*AcceptOfferResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *AcceptOfferResponse) GetOffer() *DriverOffer {
+	if x != nil {
+		return x.Offer
+	}
+	return nil
+}
+
+func (x *AcceptOfferResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *AcceptOfferResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type DeclineOfferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TripId        string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	DriverId      string                 `protobuf:"bytes,2,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeclineOfferRequest) Reset() {
+	*x = DeclineOfferRequest{}
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeclineOfferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeclineOfferRequest) ProtoMessage() {}
+
+func (x *DeclineOfferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeclineOfferRequest.ProtoReflect.Descriptor instead.
+func (*DeclineOfferRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DeclineOfferRequest) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *DeclineOfferRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *DeclineOfferRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type DeclineOfferResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NextOffer     *DriverOffer           `protobuf:"bytes,1,opt,name=next_offer,json=nextOffer,proto3" json:"next_offer,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeclineOfferResponse) Reset() {
+	*x = DeclineOfferResponse{}
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeclineOfferResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeclineOfferResponse) ProtoMessage() {}
+
+func (x *DeclineOfferResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeclineOfferResponse.ProtoReflect.Descriptor instead.
+func (*DeclineOfferResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DeclineOfferResponse) GetNextOffer() *DriverOffer {
+	if x != nil {
+		return x.NextOffer
+	}
+	return nil
+}
+
+func (x *DeclineOfferResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DeclineOfferResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Frees a driver held for a trip; a pending offer to the driver is declined
+type ReleaseDriverReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverId      string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseDriverReservationRequest) Reset() {
+	*x = ReleaseDriverReservationRequest{}
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseDriverReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseDriverReservationRequest) ProtoMessage() {}
+
+func (x *ReleaseDriverReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseDriverReservationRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseDriverReservationRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ReleaseDriverReservationRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+type ReleaseDriverReservationResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	DriverId string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	// The trip the driver was held for
+	TripId        string `protobuf:"bytes,2,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseDriverReservationResponse) Reset() {
+	*x = ReleaseDriverReservationResponse{}
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseDriverReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseDriverReservationResponse) ProtoMessage() {}
+
+func (x *ReleaseDriverReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseDriverReservationResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseDriverReservationResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ReleaseDriverReservationResponse) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *ReleaseDriverReservationResponse) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+type StreamDriverOffersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverId      string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamDriverOffersRequest) Reset() {
+	*x = StreamDriverOffersRequest{}
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamDriverOffersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamDriverOffersRequest) ProtoMessage() {}
+
+func (x *StreamDriverOffersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamDriverOffersRequest.ProtoReflect.Descriptor instead.
+func (*StreamDriverOffersRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *StreamDriverOffersRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+// A step of matching a trip, pushed to the rider as it happens
+type MatchingProgress struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TripId         string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	RiderId        string                 `protobuf:"bytes,2,opt,name=rider_id,json=riderId,proto3" json:"rider_id,omitempty"`
+	Status         string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Retries        int32                  `protobuf:"varint,4,opt,name=retries,proto3" json:"retries,omitempty"`
+	SearchRadiusKm float64                `protobuf:"fixed64,5,opt,name=search_radius_km,json=searchRadiusKm,proto3" json:"search_radius_km,omitempty"`
+	Message        string                 `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	DriverId       string                 `protobuf:"bytes,7,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	OfferId        string                 `protobuf:"bytes,8,opt,name=offer_id,json=offerId,proto3" json:"offer_id,omitempty"`
+	NextAttemptAt  *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=next_attempt_at,json=nextAttemptAt,proto3" json:"next_attempt_at,omitempty"`
+	Deadline       *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// The step: searching, queued, radius_expanded, driver_offered,
+	// driver_accepted, driver_declined, offer_expired, no_drivers, timed_out
+	// or cancelled
+	Event string `protobuf:"bytes,12,opt,name=event,proto3" json:"event,omitempty"`
+	// Which offer of the trip the step is about, counting from 1
+	Attempt       int32 `protobuf:"varint,13,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchingProgress) Reset() {
+	*x = MatchingProgress{}
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchingProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchingProgress) ProtoMessage() {}
+
+func (x *MatchingProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchingProgress.ProtoReflect.Descriptor instead.
+func (*MatchingProgress) Descriptor() ([]byte, []int) {
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *MatchingProgress) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *MatchingProgress) GetRiderId() string {
+	if x != nil {
+		return x.RiderId
+	}
+	return ""
+}
+
+func (x *MatchingProgress) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *MatchingProgress) GetRetries() int32 {
+	if x != nil {
+		return x.Retries
+	}
+	return 0
+}
+
+func (x *MatchingProgress) GetSearchRadiusKm() float64 {
+	if x != nil {
+		return x.SearchRadiusKm
+	}
+	return 0
+}
+
+func (x *MatchingProgress) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *MatchingProgress) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *MatchingProgress) GetOfferId() string {
+	if x != nil {
+		return x.OfferId
+	}
+	return ""
+}
+
+func (x *MatchingProgress) GetNextAttemptAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextAttemptAt
+	}
+	return nil
+}
+
+func (x *MatchingProgress) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
+func (x *MatchingProgress) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *MatchingProgress) GetEvent() string {
+	if x != nil {
+		return x.Event
+	}
+	return ""
+}
+
+func (x *MatchingProgress) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+type StreamMatchingProgressRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TripId        string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamMatchingProgressRequest) Reset() {
+	*x = StreamMatchingProgressRequest{}
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamMatchingProgressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamMatchingProgressRequest) ProtoMessage() {}
+
+func (x *StreamMatchingProgressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamMatchingProgressRequest.ProtoReflect.Descriptor instead.
+func (*StreamMatchingProgressRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *StreamMatchingProgressRequest) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+type GetMatchingAttemptLogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TripId        string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMatchingAttemptLogRequest) Reset() {
+	*x = GetMatchingAttemptLogRequest{}
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMatchingAttemptLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMatchingAttemptLogRequest) ProtoMessage() {}
+
+func (x *GetMatchingAttemptLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMatchingAttemptLogRequest.ProtoReflect.Descriptor instead.
+func (*GetMatchingAttemptLogRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetMatchingAttemptLogRequest) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+// Every step taken to match a trip, kept for support review
+type GetMatchingAttemptLogResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	TripId  string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	RiderId string                 `protobuf:"bytes,2,opt,name=rider_id,json=riderId,proto3" json:"rider_id,omitempty"`
+	// The step that ended matching, empty while the trip is still matching
+	Outcome       string                 `protobuf:"bytes,3,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	Events        []*MatchingProgress    `protobuf:"bytes,4,rep,name=events,proto3" json:"events,omitempty"`
+	StartedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMatchingAttemptLogResponse) Reset() {
+	*x = GetMatchingAttemptLogResponse{}
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMatchingAttemptLogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMatchingAttemptLogResponse) ProtoMessage() {}
+
+func (x *GetMatchingAttemptLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMatchingAttemptLogResponse.ProtoReflect.Descriptor instead.
+func (*GetMatchingAttemptLogResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetMatchingAttemptLogResponse) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *GetMatchingAttemptLogResponse) GetRiderId() string {
+	if x != nil {
+		return x.RiderId
+	}
+	return ""
+}
+
+func (x *GetMatchingAttemptLogResponse) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *GetMatchingAttemptLogResponse) GetEvents() []*MatchingProgress {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *GetMatchingAttemptLogResponse) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *GetMatchingAttemptLogResponse) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+// How drivers are searched for and scored in a city. Each saved change is a
+// new version of the city's config.
+type MatchingConfig struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	City  string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	// 0 for the built-in defaults
+	Version int64 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// Points out of 100 a driver's distance, pickup ETA, rating and
+	// availability are worth
+	DistanceWeight     float64 `protobuf:"fixed64,3,opt,name=distance_weight,json=distanceWeight,proto3" json:"distance_weight,omitempty"`
+	EtaWeight          float64 `protobuf:"fixed64,4,opt,name=eta_weight,json=etaWeight,proto3" json:"eta_weight,omitempty"`
+	RatingWeight       float64 `protobuf:"fixed64,5,opt,name=rating_weight,json=ratingWeight,proto3" json:"rating_weight,omitempty"`
+	AvailabilityWeight float64 `protobuf:"fixed64,6,opt,name=availability_weight,json=availabilityWeight,proto3" json:"availability_weight,omitempty"`
+	// Distance and pickup ETA at which a driver scores nothing for them
+	ScoreDistanceCapKm float64 `protobuf:"fixed64,7,opt,name=score_distance_cap_km,json=scoreDistanceCapKm,proto3" json:"score_distance_cap_km,omitempty"`
+	ScoreEtaCapSeconds int32   `protobuf:"varint,8,opt,name=score_eta_cap_seconds,json=scoreEtaCapSeconds,proto3" json:"score_eta_cap_seconds,omitempty"`
+	// The search widens from the initial radius by the step until
+	// min_candidates drivers are found or the max radius is reached
+	InitialRadiusKm     float64                `protobuf:"fixed64,9,opt,name=initial_radius_km,json=initialRadiusKm,proto3" json:"initial_radius_km,omitempty"`
+	RadiusStepKm        float64                `protobuf:"fixed64,10,opt,name=radius_step_km,json=radiusStepKm,proto3" json:"radius_step_km,omitempty"`
+	MaxRadiusKm         float64                `protobuf:"fixed64,11,opt,name=max_radius_km,json=maxRadiusKm,proto3" json:"max_radius_km,omitempty"`
+	MaxPickupDistanceKm float64                `protobuf:"fixed64,12,opt,name=max_pickup_distance_km,json=maxPickupDistanceKm,proto3" json:"max_pickup_distance_km,omitempty"`
+	MinCandidates       int32                  `protobuf:"varint,13,opt,name=min_candidates,json=minCandidates,proto3" json:"min_candidates,omitempty"`
+	UpdatedBy           string                 `protobuf:"bytes,14,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	UpdatedAt           *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Points out of 100 a driver's offer acceptance rate, trips not cancelled
+	// and completion streak are worth, counting towards the same 100
+	AcceptanceWeight   float64 `protobuf:"fixed64,16,opt,name=acceptance_weight,json=acceptanceWeight,proto3" json:"acceptance_weight,omitempty"`
+	CancellationWeight float64 `protobuf:"fixed64,17,opt,name=cancellation_weight,json=cancellationWeight,proto3" json:"cancellation_weight,omitempty"`
+	StreakWeight       float64 `protobuf:"fixed64,18,opt,name=streak_weight,json=streakWeight,proto3" json:"streak_weight,omitempty"`
+	// Trips completed in a row at which a driver scores the full streak weight
+	StreakTarget  int32 `protobuf:"varint,19,opt,name=streak_target,json=streakTarget,proto3" json:"streak_target,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchingConfig) Reset() {
+	*x = MatchingConfig{}
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchingConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchingConfig) ProtoMessage() {}
+
+func (x *MatchingConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchingConfig.ProtoReflect.Descriptor instead.
+func (*MatchingConfig) Descriptor() ([]byte, []int) {
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *MatchingConfig) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *MatchingConfig) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *MatchingConfig) GetDistanceWeight() float64 {
+	if x != nil {
+		return x.DistanceWeight
+	}
+	return 0
+}
+
+func (x *MatchingConfig) GetEtaWeight() float64 {
+	if x != nil {
+		return x.EtaWeight
+	}
+	return 0
+}
+
+func (x *MatchingConfig) GetRatingWeight() float64 {
+	if x != nil {
+		return x.RatingWeight
+	}
+	return 0
+}
+
+func (x *MatchingConfig) GetAvailabilityWeight() float64 {
+	if x != nil {
+		return x.AvailabilityWeight
+	}
+	return 0
+}
+
+func (x *MatchingConfig) GetScoreDistanceCapKm() float64 {
+	if x != nil {
+		return x.ScoreDistanceCapKm
+	}
+	return 0
+}
+
+func (x *MatchingConfig) GetScoreEtaCapSeconds() int32 {
+	if x != nil {
+		return x.ScoreEtaCapSeconds
+	}
+	return 0
+}
+
+func (x *MatchingConfig) GetInitialRadiusKm() float64 {
+	if x != nil {
+		return x.InitialRadiusKm
+	}
+	return 0
+}
+
+func (x *MatchingConfig) GetRadiusStepKm() float64 {
+	if x != nil {
+		return x.RadiusStepKm
+	}
+	return 0
+}
+
+func (x *MatchingConfig) GetMaxRadiusKm() float64 {
+	if x != nil {
+		return x.MaxRadiusKm
+	}
+	return 0
+}
+
+func (x *MatchingConfig) GetMaxPickupDistanceKm() float64 {
+	if x != nil {
+		return x.MaxPickupDistanceKm
+	}
+	return 0
+}
+
+func (x *MatchingConfig) GetMinCandidates() int32 {
+	if x != nil {
+		return x.MinCandidates
+	}
+	return 0
+}
+
+func (x *MatchingConfig) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *MatchingConfig) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *MatchingConfig) GetAcceptanceWeight() float64 {
+	if x != nil {
+		return x.AcceptanceWeight
+	}
+	return 0
+}
+
+func (x *MatchingConfig) GetCancellationWeight() float64 {
+	if x != nil {
+		return x.CancellationWeight
+	}
+	return 0
+}
+
+func (x *MatchingConfig) GetStreakWeight() float64 {
+	if x != nil {
+		return x.StreakWeight
+	}
+	return 0
+}
+
+func (x *MatchingConfig) GetStreakTarget() int32 {
+	if x != nil {
+		return x.StreakTarget
+	}
+	return 0
+}
+
+type ListMatchingConfigsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMatchingConfigsRequest) Reset() {
+	*x = ListMatchingConfigsRequest{}
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMatchingConfigsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMatchingConfigsRequest) ProtoMessage() {}
+
+func (x *ListMatchingConfigsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMatchingConfigsRequest.ProtoReflect.Descriptor instead.
+func (*ListMatchingConfigsRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{37}
+}
+
+type ListMatchingConfigsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Configs       []*MatchingConfig      `protobuf:"bytes,1,rep,name=configs,proto3" json:"configs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMatchingConfigsResponse) Reset() {
+	*x = ListMatchingConfigsResponse{}
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMatchingConfigsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMatchingConfigsResponse) ProtoMessage() {}
+
+func (x *ListMatchingConfigsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMatchingConfigsResponse.ProtoReflect.Descriptor instead.
+func (*ListMatchingConfigsResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListMatchingConfigsResponse) GetConfigs() []*MatchingConfig {
+	if x != nil {
+		return x.Configs
+	}
+	return nil
+}
+
+type GetMatchingConfigRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	City  string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	// A saved version of the city's config; 0 for the config the city is
+	// currently matched with, falling back to the default
+	Version       int64 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMatchingConfigRequest) Reset() {
+	*x = GetMatchingConfigRequest{}
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMatchingConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMatchingConfigRequest) ProtoMessage() {}
+
+func (x *GetMatchingConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMatchingConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetMatchingConfigRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetMatchingConfigRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *GetMatchingConfigRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type SaveMatchingConfigRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Saved as the city's next version; its version is ignored
+	Config        *MatchingConfig `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveMatchingConfigRequest) Reset() {
+	*x = SaveMatchingConfigRequest{}
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveMatchingConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveMatchingConfigRequest) ProtoMessage() {}
+
+func (x *SaveMatchingConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveMatchingConfigRequest.ProtoReflect.Descriptor instead.
+func (*SaveMatchingConfigRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *SaveMatchingConfigRequest) GetConfig() *MatchingConfig {
+	if x != nil {
+		return x.Config
+	}
+	return nil
+}
+
+type DeleteMatchingConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	City          string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMatchingConfigRequest) Reset() {
+	*x = DeleteMatchingConfigRequest{}
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMatchingConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMatchingConfigRequest) ProtoMessage() {}
+
+func (x *DeleteMatchingConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMatchingConfigRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMatchingConfigRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *DeleteMatchingConfigRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+type DeleteMatchingConfigResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMatchingConfigResponse) Reset() {
+	*x = DeleteMatchingConfigResponse{}
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMatchingConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMatchingConfigResponse) ProtoMessage() {}
+
+func (x *DeleteMatchingConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_matching_matching_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMatchingConfigResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMatchingConfigResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_matching_matching_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *DeleteMatchingConfigResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+var File_shared_proto_matching_matching_proto protoreflect.FileDescriptor
+
+const file_shared_proto_matching_matching_proto_rawDesc = "" +
+	"\n" +
+	"$shared/proto/matching/matching.proto\x12\bmatching\x1a\x1fgoogle/protobuf/timestamp.proto\"^\n" +
+	"\bLocation\x12\x1a\n" +
+	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\x12\x18\n" +
+	"\aaddress\x18\x03 \x01(\tR\aaddress\"\xdd\x03\n" +
+	"\x06Driver\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12=\n" +
+	"\x10current_location\x18\x03 \x01(\v2\x12.matching.LocationR\x0fcurrentLocation\x12\x1d\n" +
+	"\n" +
+	"vehicle_id\x18\x04 \x01(\tR\tvehicleId\x12!\n" +
+	"\fvehicle_type\x18\x05 \x01(\tR\vvehicleType\x12\x16\n" +
+	"\x06rating\x18\x06 \x01(\x01R\x06rating\x12\x1f\n" +
+	"\vtotal_trips\x18\a \x01(\x05R\n" +
+	"totalTrips\x12!\n" +
+	"\fis_available\x18\b \x01(\bR\visAvailable\x12\x1f\n" +
+	"\vdistance_km\x18\t \x01(\x01R\n" +
+	"distanceKm\x12\x1f\n" +
+	"\veta_minutes\x18\n" +
+	" \x01(\x05R\n" +
+	"etaMinutes\x12-\n" +
+	"\x05score\x18\v \x01(\v2\x17.matching.MatchingScoreR\x05score\x12\x12\n" +
+	"\x04name\x18\f \x01(\tR\x04name\x12\x1b\n" +
+	"\tphoto_url\x18\r \x01(\tR\bphotoUrl\x12+\n" +
+	"\avehicle\x18\x0e \x01(\v2\x11.matching.VehicleR\avehicle\"\xd7\x01\n" +
+	"\aVehicle\x12\x12\n" +
+	"\x04make\x18\x01 \x01(\tR\x04make\x12\x14\n" +
+	"\x05model\x18\x02 \x01(\tR\x05model\x12\x12\n" +
+	"\x04year\x18\x03 \x01(\x05R\x04year\x12\x14\n" +
+	"\x05color\x18\x04 \x01(\tR\x05color\x12#\n" +
+	"\rlicense_plate\x18\x05 \x01(\tR\flicensePlate\x12\x1a\n" +
+	"\bcapacity\x18\x06 \x01(\x05R\bcapacity\x12\x1a\n" +
+	"\bfeatures\x18\a \x03(\tR\bfeatures\x12\x1b\n" +
+	"\tphoto_url\x18\b \x01(\tR\bphotoUrl\"\xf7\x01\n" +
+	"\rMatchingScore\x12\x1f\n" +
+	"\vtotal_score\x18\x01 \x01(\x01R\n" +
+	"totalScore\x12%\n" +
 	"\x0edistance_score\x18\x02 \x01(\x01R\rdistanceScore\x12!\n" +
 	"\frating_score\x18\x03 \x01(\x01R\vratingScore\x12-\n" +
 	"\x12availability_score\x18\x04 \x01(\x01R\x11availabilityScore\x12!\n" +
 	"\fdemand_score\x18\x05 \x01(\x01R\vdemandScore\x12)\n" +
-	"\x10historical_score\x18\x06 \x01(\x01R\x0fhistoricalScore\"\xc0\x03\n" +
+	"\x10historical_score\x18\x06 \x01(\x01R\x0fhistoricalScore\"\xbc\x04\n" +
 	"\vRideRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
 	"\brider_id\x18\x02 \x01(\tR\ariderId\x12;\n" +
@@ -1662,7 +3132,11 @@ const file_shared_proto_matching_matching_proto_rawDesc = "" +
 	"\fvehicle_type\x18\x05 \x01(\tR\vvehicleType\x12'\n" +
 	"\x0fpassenger_count\x18\x06 \x01(\x05R\x0epassengerCount\x12=\n" +
 	"\frequested_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\vrequestedAt\x12H\n" +
-	"\vpreferences\x18\b \x03(\v2&.matching.RideRequest.PreferencesEntryR\vpreferences\x1a>\n" +
+	"\vpreferences\x18\b \x03(\v2&.matching.RideRequest.PreferencesEntryR\vpreferences\x12?\n" +
+	"\rscheduled_for\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\fscheduledFor\x12\x12\n" +
+	"\x04city\x18\n" +
+	" \x01(\tR\x04city\x12%\n" +
+	"\x0epriority_level\x18\v \x01(\x05R\rpriorityLevel\x1a>\n" +
 	"\x10PreferencesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x84\x02\n" +
@@ -1708,13 +3182,14 @@ const file_shared_proto_matching_matching_proto_rawDesc = "" +
 	"\bmetadata\x18\x03 \x01(\v2\x1a.matching.MatchingMetadataR\bmetadata\"\x8f\x01\n" +
 	"\x12MatchDriverRequest\x128\n" +
 	"\fride_request\x18\x01 \x01(\v2\x15.matching.RideRequestR\vrideRequest\x12?\n" +
-	"\vpreferences\x18\x02 \x01(\v2\x1d.matching.MatchingPreferencesR\vpreferences\"\x8f\x03\n" +
+	"\vpreferences\x18\x02 \x01(\v2\x1d.matching.MatchingPreferencesR\vpreferences\"\xc0\x03\n" +
 	"\x13MatchingPreferences\x123\n" +
 	"\x16max_pickup_distance_km\x18\x01 \x01(\x01R\x13maxPickupDistanceKm\x12*\n" +
 	"\x11min_driver_rating\x18\x02 \x01(\x01R\x0fminDriverRating\x12<\n" +
 	"\x1aprefer_experienced_drivers\x18\x03 \x01(\bR\x18preferExperiencedDrivers\x12.\n" +
 	"\x13allow_pool_matching\x18\x04 \x01(\bR\x11allowPoolMatching\x12c\n" +
-	"\x12custom_preferences\x18\x05 \x03(\v24.matching.MatchingPreferences.CustomPreferencesEntryR\x11customPreferences\x1aD\n" +
+	"\x12custom_preferences\x18\x05 \x03(\v24.matching.MatchingPreferences.CustomPreferencesEntryR\x11customPreferences\x12/\n" +
+	"\x13accessibility_needs\x18\x06 \x03(\tR\x12accessibilityNeeds\x1aD\n" +
 	"\x16CustomPreferencesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x90\x01\n" +
@@ -1768,7 +3243,114 @@ const file_shared_proto_matching_matching_proto_rawDesc = "" +
 	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"c\n" +
 	"\x18GetMatchingStatsResponse\x12-\n" +
 	"\x05stats\x18\x01 \x01(\v2\x17.matching.MatchingStatsR\x05stats\x12\x18\n" +
-	"\asuccess\x18\x02 \x01(\bR\asuccess2\xe2\x05\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\"\xfd\x03\n" +
+	"\vDriverOffer\x12\x19\n" +
+	"\boffer_id\x18\x01 \x01(\tR\aofferId\x12\x17\n" +
+	"\atrip_id\x18\x02 \x01(\tR\x06tripId\x12\x19\n" +
+	"\brider_id\x18\x03 \x01(\tR\ariderId\x12\x1b\n" +
+	"\tdriver_id\x18\x04 \x01(\tR\bdriverId\x12;\n" +
+	"\x0fpickup_location\x18\x05 \x01(\v2\x12.matching.LocationR\x0epickupLocation\x124\n" +
+	"\vdestination\x18\x06 \x01(\v2\x12.matching.LocationR\vdestination\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x12\x18\n" +
+	"\aattempt\x18\b \x01(\x05R\aattempt\x12\x1f\n" +
+	"\veta_seconds\x18\t \x01(\x05R\n" +
+	"etaSeconds\x12\x1f\n" +
+	"\vdistance_km\x18\n" +
+	" \x01(\x01R\n" +
+	"distanceKm\x12%\n" +
+	"\x0eestimated_fare\x18\v \x01(\x01R\restimatedFare\x129\n" +
+	"\n" +
+	"offered_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tofferedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"J\n" +
+	"\x12AcceptOfferRequest\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\x12\x1b\n" +
+	"\tdriver_id\x18\x02 \x01(\tR\bdriverId\"v\n" +
+	"\x13AcceptOfferResponse\x12+\n" +
+	"\x05offer\x18\x01 \x01(\v2\x15.matching.DriverOfferR\x05offer\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"c\n" +
+	"\x13DeclineOfferRequest\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\x12\x1b\n" +
+	"\tdriver_id\x18\x02 \x01(\tR\bdriverId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\x80\x01\n" +
+	"\x14DeclineOfferResponse\x124\n" +
+	"\n" +
+	"next_offer\x18\x01 \x01(\v2\x15.matching.DriverOfferR\tnextOffer\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\">\n" +
+	"\x1fReleaseDriverReservationRequest\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\"X\n" +
+	" ReleaseDriverReservationResponse\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12\x17\n" +
+	"\atrip_id\x18\x02 \x01(\tR\x06tripId\"8\n" +
+	"\x19StreamDriverOffersRequest\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\"\xdb\x03\n" +
+	"\x10MatchingProgress\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\x12\x19\n" +
+	"\brider_id\x18\x02 \x01(\tR\ariderId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x18\n" +
+	"\aretries\x18\x04 \x01(\x05R\aretries\x12(\n" +
+	"\x10search_radius_km\x18\x05 \x01(\x01R\x0esearchRadiusKm\x12\x18\n" +
+	"\amessage\x18\x06 \x01(\tR\amessage\x12\x1b\n" +
+	"\tdriver_id\x18\a \x01(\tR\bdriverId\x12\x19\n" +
+	"\boffer_id\x18\b \x01(\tR\aofferId\x12B\n" +
+	"\x0fnext_attempt_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\rnextAttemptAt\x126\n" +
+	"\bdeadline\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\bdeadline\x129\n" +
+	"\n" +
+	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x14\n" +
+	"\x05event\x18\f \x01(\tR\x05event\x12\x18\n" +
+	"\aattempt\x18\r \x01(\x05R\aattempt\"8\n" +
+	"\x1dStreamMatchingProgressRequest\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\"7\n" +
+	"\x1cGetMatchingAttemptLogRequest\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\"\x99\x02\n" +
+	"\x1dGetMatchingAttemptLogResponse\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\x12\x19\n" +
+	"\brider_id\x18\x02 \x01(\tR\ariderId\x12\x18\n" +
+	"\aoutcome\x18\x03 \x01(\tR\aoutcome\x122\n" +
+	"\x06events\x18\x04 \x03(\v2\x1a.matching.MatchingProgressR\x06events\x129\n" +
+	"\n" +
+	"started_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12;\n" +
+	"\vfinished_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\"\x96\x06\n" +
+	"\x0eMatchingConfig\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\x12'\n" +
+	"\x0fdistance_weight\x18\x03 \x01(\x01R\x0edistanceWeight\x12\x1d\n" +
+	"\n" +
+	"eta_weight\x18\x04 \x01(\x01R\tetaWeight\x12#\n" +
+	"\rrating_weight\x18\x05 \x01(\x01R\fratingWeight\x12/\n" +
+	"\x13availability_weight\x18\x06 \x01(\x01R\x12availabilityWeight\x121\n" +
+	"\x15score_distance_cap_km\x18\a \x01(\x01R\x12scoreDistanceCapKm\x121\n" +
+	"\x15score_eta_cap_seconds\x18\b \x01(\x05R\x12scoreEtaCapSeconds\x12*\n" +
+	"\x11initial_radius_km\x18\t \x01(\x01R\x0finitialRadiusKm\x12$\n" +
+	"\x0eradius_step_km\x18\n" +
+	" \x01(\x01R\fradiusStepKm\x12\"\n" +
+	"\rmax_radius_km\x18\v \x01(\x01R\vmaxRadiusKm\x123\n" +
+	"\x16max_pickup_distance_km\x18\f \x01(\x01R\x13maxPickupDistanceKm\x12%\n" +
+	"\x0emin_candidates\x18\r \x01(\x05R\rminCandidates\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\x0e \x01(\tR\tupdatedBy\x129\n" +
+	"\n" +
+	"updated_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12+\n" +
+	"\x11acceptance_weight\x18\x10 \x01(\x01R\x10acceptanceWeight\x12/\n" +
+	"\x13cancellation_weight\x18\x11 \x01(\x01R\x12cancellationWeight\x12#\n" +
+	"\rstreak_weight\x18\x12 \x01(\x01R\fstreakWeight\x12#\n" +
+	"\rstreak_target\x18\x13 \x01(\x05R\fstreakTarget\"\x1c\n" +
+	"\x1aListMatchingConfigsRequest\"Q\n" +
+	"\x1bListMatchingConfigsResponse\x122\n" +
+	"\aconfigs\x18\x01 \x03(\v2\x18.matching.MatchingConfigR\aconfigs\"H\n" +
+	"\x18GetMatchingConfigRequest\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\"M\n" +
+	"\x19SaveMatchingConfigRequest\x120\n" +
+	"\x06config\x18\x01 \x01(\v2\x18.matching.MatchingConfigR\x06config\"1\n" +
+	"\x1bDeleteMatchingConfigRequest\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\"8\n" +
+	"\x1cDeleteMatchingConfigResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess2\x82\r\n" +
 	"\x0fMatchingService\x12\\\n" +
 	"\x11FindNearbyDrivers\x12\".matching.FindNearbyDriversRequest\x1a#.matching.FindNearbyDriversResponse\x12J\n" +
 	"\vMatchDriver\x12\x1c.matching.MatchDriverRequest\x1a\x1d.matching.MatchDriverResponse\x12e\n" +
@@ -1776,8 +3358,18 @@ const file_shared_proto_matching_matching_proto_rawDesc = "" +
 	"\tGetDriver\x12\x1a.matching.GetDriverRequest\x1a\x1b.matching.GetDriverResponse\x12Y\n" +
 	"\x10GetActiveDrivers\x12!.matching.GetActiveDriversRequest\x1a\".matching.GetActiveDriversResponse\x12_\n" +
 	"\x12BatchUpdateDrivers\x12#.matching.BatchUpdateDriversRequest\x1a$.matching.BatchUpdateDriversResponse\x12Y\n" +
-	"\x10GetMatchingStats\x12!.matching.GetMatchingStatsRequest\x1a\".matching.GetMatchingStatsResponse\x12a\n" +
-	"\x13StreamDriverUpdates\x12\x1e.matching.DriverLocationUpdate\x1a&.matching.UpdateDriverLocationResponse(\x010\x01B5Z3github.com/rideshare-platform/shared/proto/matchingb\x06proto3"
+	"\x10GetMatchingStats\x12!.matching.GetMatchingStatsRequest\x1a\".matching.GetMatchingStatsResponse\x12J\n" +
+	"\vAcceptOffer\x12\x1c.matching.AcceptOfferRequest\x1a\x1d.matching.AcceptOfferResponse\x12M\n" +
+	"\fDeclineOffer\x12\x1d.matching.DeclineOfferRequest\x1a\x1e.matching.DeclineOfferResponse\x12q\n" +
+	"\x18ReleaseDriverReservation\x12).matching.ReleaseDriverReservationRequest\x1a*.matching.ReleaseDriverReservationResponse\x12h\n" +
+	"\x15GetMatchingAttemptLog\x12&.matching.GetMatchingAttemptLogRequest\x1a'.matching.GetMatchingAttemptLogResponse\x12b\n" +
+	"\x13ListMatchingConfigs\x12$.matching.ListMatchingConfigsRequest\x1a%.matching.ListMatchingConfigsResponse\x12Q\n" +
+	"\x11GetMatchingConfig\x12\".matching.GetMatchingConfigRequest\x1a\x18.matching.MatchingConfig\x12S\n" +
+	"\x12SaveMatchingConfig\x12#.matching.SaveMatchingConfigRequest\x1a\x18.matching.MatchingConfig\x12e\n" +
+	"\x14DeleteMatchingConfig\x12%.matching.DeleteMatchingConfigRequest\x1a&.matching.DeleteMatchingConfigResponse\x12a\n" +
+	"\x13StreamDriverUpdates\x12\x1e.matching.DriverLocationUpdate\x1a&.matching.UpdateDriverLocationResponse(\x010\x01\x12R\n" +
+	"\x12StreamDriverOffers\x12#.matching.StreamDriverOffersRequest\x1a\x15.matching.DriverOffer0\x01\x12_\n" +
+	"\x16StreamMatchingProgress\x12'.matching.StreamMatchingProgressRequest\x1a\x1a.matching.MatchingProgress0\x01B5Z3github.com/rideshare-platform/shared/proto/matchingb\x06proto3"
 
 var (
 	file_shared_proto_matching_matching_proto_rawDescOnce sync.Once
@@ -1791,90 +3383,147 @@ func file_shared_proto_matching_matching_proto_rawDescGZIP() []byte {
 	return file_shared_proto_matching_matching_proto_rawDescData
 }
 
-var file_shared_proto_matching_matching_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
+var file_shared_proto_matching_matching_proto_msgTypes = make([]protoimpl.MessageInfo, 48)
 var file_shared_proto_matching_matching_proto_goTypes = []any{
-	(*Location)(nil),                     // 0: matching.Location
-	(*Driver)(nil),                       // 1: matching.Driver
-	(*MatchingScore)(nil),                // 2: matching.MatchingScore
-	(*RideRequest)(nil),                  // 3: matching.RideRequest
-	(*MatchResult)(nil),                  // 4: matching.MatchResult
-	(*MatchingMetadata)(nil),             // 5: matching.MatchingMetadata
-	(*DriverLocationUpdate)(nil),         // 6: matching.DriverLocationUpdate
-	(*FindNearbyDriversRequest)(nil),     // 7: matching.FindNearbyDriversRequest
-	(*FindNearbyDriversResponse)(nil),    // 8: matching.FindNearbyDriversResponse
-	(*MatchDriverRequest)(nil),           // 9: matching.MatchDriverRequest
-	(*MatchingPreferences)(nil),          // 10: matching.MatchingPreferences
-	(*MatchDriverResponse)(nil),          // 11: matching.MatchDriverResponse
-	(*UpdateDriverLocationRequest)(nil),  // 12: matching.UpdateDriverLocationRequest
-	(*UpdateDriverLocationResponse)(nil), // 13: matching.UpdateDriverLocationResponse
-	(*GetDriverRequest)(nil),             // 14: matching.GetDriverRequest
-	(*GetDriverResponse)(nil),            // 15: matching.GetDriverResponse
-	(*GetActiveDriversRequest)(nil),      // 16: matching.GetActiveDriversRequest
-	(*GetActiveDriversResponse)(nil),     // 17: matching.GetActiveDriversResponse
-	(*BatchUpdateDriversRequest)(nil),    // 18: matching.BatchUpdateDriversRequest
-	(*BatchUpdateDriversResponse)(nil),   // 19: matching.BatchUpdateDriversResponse
-	(*GetMatchingStatsRequest)(nil),      // 20: matching.GetMatchingStatsRequest
-	(*MatchingStats)(nil),                // 21: matching.MatchingStats
-	(*GetMatchingStatsResponse)(nil),     // 22: matching.GetMatchingStatsResponse
-	nil,                                  // 23: matching.RideRequest.PreferencesEntry
-	nil,                                  // 24: matching.MatchingMetadata.AlgorithmWeightsEntry
-	nil,                                  // 25: matching.FindNearbyDriversRequest.FiltersEntry
-	nil,                                  // 26: matching.MatchingPreferences.CustomPreferencesEntry
-	nil,                                  // 27: matching.MatchingStats.VehicleTypeDistributionEntry
-	(*timestamppb.Timestamp)(nil),        // 28: google.protobuf.Timestamp
+	(*Location)(nil),                         // 0: matching.Location
+	(*Driver)(nil),                           // 1: matching.Driver
+	(*Vehicle)(nil),                          // 2: matching.Vehicle
+	(*MatchingScore)(nil),                    // 3: matching.MatchingScore
+	(*RideRequest)(nil),                      // 4: matching.RideRequest
+	(*MatchResult)(nil),                      // 5: matching.MatchResult
+	(*MatchingMetadata)(nil),                 // 6: matching.MatchingMetadata
+	(*DriverLocationUpdate)(nil),             // 7: matching.DriverLocationUpdate
+	(*FindNearbyDriversRequest)(nil),         // 8: matching.FindNearbyDriversRequest
+	(*FindNearbyDriversResponse)(nil),        // 9: matching.FindNearbyDriversResponse
+	(*MatchDriverRequest)(nil),               // 10: matching.MatchDriverRequest
+	(*MatchingPreferences)(nil),              // 11: matching.MatchingPreferences
+	(*MatchDriverResponse)(nil),              // 12: matching.MatchDriverResponse
+	(*UpdateDriverLocationRequest)(nil),      // 13: matching.UpdateDriverLocationRequest
+	(*UpdateDriverLocationResponse)(nil),     // 14: matching.UpdateDriverLocationResponse
+	(*GetDriverRequest)(nil),                 // 15: matching.GetDriverRequest
+	(*GetDriverResponse)(nil),                // 16: matching.GetDriverResponse
+	(*GetActiveDriversRequest)(nil),          // 17: matching.GetActiveDriversRequest
+	(*GetActiveDriversResponse)(nil),         // 18: matching.GetActiveDriversResponse
+	(*BatchUpdateDriversRequest)(nil),        // 19: matching.BatchUpdateDriversRequest
+	(*BatchUpdateDriversResponse)(nil),       // 20: matching.BatchUpdateDriversResponse
+	(*GetMatchingStatsRequest)(nil),          // 21: matching.GetMatchingStatsRequest
+	(*MatchingStats)(nil),                    // 22: matching.MatchingStats
+	(*GetMatchingStatsResponse)(nil),         // 23: matching.GetMatchingStatsResponse
+	(*DriverOffer)(nil),                      // 24: matching.DriverOffer
+	(*AcceptOfferRequest)(nil),               // 25: matching.AcceptOfferRequest
+	(*AcceptOfferResponse)(nil),              // 26: matching.AcceptOfferResponse
+	(*DeclineOfferRequest)(nil),              // 27: matching.DeclineOfferRequest
+	(*DeclineOfferResponse)(nil),             // 28: matching.DeclineOfferResponse
+	(*ReleaseDriverReservationRequest)(nil),  // 29: matching.ReleaseDriverReservationRequest
+	(*ReleaseDriverReservationResponse)(nil), // 30: matching.ReleaseDriverReservationResponse
+	(*StreamDriverOffersRequest)(nil),        // 31: matching.StreamDriverOffersRequest
+	(*MatchingProgress)(nil),                 // 32: matching.MatchingProgress
+	(*StreamMatchingProgressRequest)(nil),    // 33: matching.StreamMatchingProgressRequest
+	(*GetMatchingAttemptLogRequest)(nil),     // 34: matching.GetMatchingAttemptLogRequest
+	(*GetMatchingAttemptLogResponse)(nil),    // 35: matching.GetMatchingAttemptLogResponse
+	(*MatchingConfig)(nil),                   // 36: matching.MatchingConfig
+	(*ListMatchingConfigsRequest)(nil),       // 37: matching.ListMatchingConfigsRequest
+	(*ListMatchingConfigsResponse)(nil),      // 38: matching.ListMatchingConfigsResponse
+	(*GetMatchingConfigRequest)(nil),         // 39: matching.GetMatchingConfigRequest
+	(*SaveMatchingConfigRequest)(nil),        // 40: matching.SaveMatchingConfigRequest
+	(*DeleteMatchingConfigRequest)(nil),      // 41: matching.DeleteMatchingConfigRequest
+	(*DeleteMatchingConfigResponse)(nil),     // 42: matching.DeleteMatchingConfigResponse
+	nil,                                      // 43: matching.RideRequest.PreferencesEntry
+	nil,                                      // 44: matching.MatchingMetadata.AlgorithmWeightsEntry
+	nil,                                      // 45: matching.FindNearbyDriversRequest.FiltersEntry
+	nil,                                      // 46: matching.MatchingPreferences.CustomPreferencesEntry
+	nil,                                      // 47: matching.MatchingStats.VehicleTypeDistributionEntry
+	(*timestamppb.Timestamp)(nil),            // 48: google.protobuf.Timestamp
 }
 var file_shared_proto_matching_matching_proto_depIdxs = []int32{
 	0,  // 0: matching.Driver.current_location:type_name -> matching.Location
-	2,  // 1: matching.Driver.score:type_name -> matching.MatchingScore
-	0,  // 2: matching.RideRequest.pickup_location:type_name -> matching.Location
-	0,  // 3: matching.RideRequest.destination:type_name -> matching.Location
-	28, // 4: matching.RideRequest.requested_at:type_name -> google.protobuf.Timestamp
-	23, // 5: matching.RideRequest.preferences:type_name -> matching.RideRequest.PreferencesEntry
-	1,  // 6: matching.MatchResult.matched_drivers:type_name -> matching.Driver
-	1,  // 7: matching.MatchResult.best_match:type_name -> matching.Driver
-	5,  // 8: matching.MatchResult.metadata:type_name -> matching.MatchingMetadata
-	24, // 9: matching.MatchingMetadata.algorithm_weights:type_name -> matching.MatchingMetadata.AlgorithmWeightsEntry
-	0,  // 10: matching.DriverLocationUpdate.location:type_name -> matching.Location
-	28, // 11: matching.DriverLocationUpdate.timestamp:type_name -> google.protobuf.Timestamp
-	0,  // 12: matching.FindNearbyDriversRequest.pickup_location:type_name -> matching.Location
-	25, // 13: matching.FindNearbyDriversRequest.filters:type_name -> matching.FindNearbyDriversRequest.FiltersEntry
-	1,  // 14: matching.FindNearbyDriversResponse.drivers:type_name -> matching.Driver
-	5,  // 15: matching.FindNearbyDriversResponse.metadata:type_name -> matching.MatchingMetadata
-	3,  // 16: matching.MatchDriverRequest.ride_request:type_name -> matching.RideRequest
-	10, // 17: matching.MatchDriverRequest.preferences:type_name -> matching.MatchingPreferences
-	26, // 18: matching.MatchingPreferences.custom_preferences:type_name -> matching.MatchingPreferences.CustomPreferencesEntry
-	4,  // 19: matching.MatchDriverResponse.result:type_name -> matching.MatchResult
-	0,  // 20: matching.UpdateDriverLocationRequest.location:type_name -> matching.Location
-	1,  // 21: matching.GetDriverResponse.driver:type_name -> matching.Driver
-	0,  // 22: matching.GetActiveDriversRequest.center:type_name -> matching.Location
-	1,  // 23: matching.GetActiveDriversResponse.drivers:type_name -> matching.Driver
-	5,  // 24: matching.GetActiveDriversResponse.metadata:type_name -> matching.MatchingMetadata
-	6,  // 25: matching.BatchUpdateDriversRequest.updates:type_name -> matching.DriverLocationUpdate
-	28, // 26: matching.GetMatchingStatsRequest.from_time:type_name -> google.protobuf.Timestamp
-	28, // 27: matching.GetMatchingStatsRequest.to_time:type_name -> google.protobuf.Timestamp
-	27, // 28: matching.MatchingStats.vehicle_type_distribution:type_name -> matching.MatchingStats.VehicleTypeDistributionEntry
-	21, // 29: matching.GetMatchingStatsResponse.stats:type_name -> matching.MatchingStats
-	7,  // 30: matching.MatchingService.FindNearbyDrivers:input_type -> matching.FindNearbyDriversRequest
-	9,  // 31: matching.MatchingService.MatchDriver:input_type -> matching.MatchDriverRequest
-	12, // 32: matching.MatchingService.UpdateDriverLocation:input_type -> matching.UpdateDriverLocationRequest
-	14, // 33: matching.MatchingService.GetDriver:input_type -> matching.GetDriverRequest
-	16, // 34: matching.MatchingService.GetActiveDrivers:input_type -> matching.GetActiveDriversRequest
-	18, // 35: matching.MatchingService.BatchUpdateDrivers:input_type -> matching.BatchUpdateDriversRequest
-	20, // 36: matching.MatchingService.GetMatchingStats:input_type -> matching.GetMatchingStatsRequest
-	6,  // 37: matching.MatchingService.StreamDriverUpdates:input_type -> matching.DriverLocationUpdate
-	8,  // 38: matching.MatchingService.FindNearbyDrivers:output_type -> matching.FindNearbyDriversResponse
-	11, // 39: matching.MatchingService.MatchDriver:output_type -> matching.MatchDriverResponse
-	13, // 40: matching.MatchingService.UpdateDriverLocation:output_type -> matching.UpdateDriverLocationResponse
-	15, // 41: matching.MatchingService.GetDriver:output_type -> matching.GetDriverResponse
-	17, // 42: matching.MatchingService.GetActiveDrivers:output_type -> matching.GetActiveDriversResponse
-	19, // 43: matching.MatchingService.BatchUpdateDrivers:output_type -> matching.BatchUpdateDriversResponse
-	22, // 44: matching.MatchingService.GetMatchingStats:output_type -> matching.GetMatchingStatsResponse
-	13, // 45: matching.MatchingService.StreamDriverUpdates:output_type -> matching.UpdateDriverLocationResponse
-	38, // [38:46] is the sub-list for method output_type
-	30, // [30:38] is the sub-list for method input_type
-	30, // [30:30] is the sub-list for extension type_name
-	30, // [30:30] is the sub-list for extension extendee
-	0,  // [0:30] is the sub-list for field type_name
+	3,  // 1: matching.Driver.score:type_name -> matching.MatchingScore
+	2,  // 2: matching.Driver.vehicle:type_name -> matching.Vehicle
+	0,  // 3: matching.RideRequest.pickup_location:type_name -> matching.Location
+	0,  // 4: matching.RideRequest.destination:type_name -> matching.Location
+	48, // 5: matching.RideRequest.requested_at:type_name -> google.protobuf.Timestamp
+	43, // 6: matching.RideRequest.preferences:type_name -> matching.RideRequest.PreferencesEntry
+	48, // 7: matching.RideRequest.scheduled_for:type_name -> google.protobuf.Timestamp
+	1,  // 8: matching.MatchResult.matched_drivers:type_name -> matching.Driver
+	1,  // 9: matching.MatchResult.best_match:type_name -> matching.Driver
+	6,  // 10: matching.MatchResult.metadata:type_name -> matching.MatchingMetadata
+	44, // 11: matching.MatchingMetadata.algorithm_weights:type_name -> matching.MatchingMetadata.AlgorithmWeightsEntry
+	0,  // 12: matching.DriverLocationUpdate.location:type_name -> matching.Location
+	48, // 13: matching.DriverLocationUpdate.timestamp:type_name -> google.protobuf.Timestamp
+	0,  // 14: matching.FindNearbyDriversRequest.pickup_location:type_name -> matching.Location
+	45, // 15: matching.FindNearbyDriversRequest.filters:type_name -> matching.FindNearbyDriversRequest.FiltersEntry
+	1,  // 16: matching.FindNearbyDriversResponse.drivers:type_name -> matching.Driver
+	6,  // 17: matching.FindNearbyDriversResponse.metadata:type_name -> matching.MatchingMetadata
+	4,  // 18: matching.MatchDriverRequest.ride_request:type_name -> matching.RideRequest
+	11, // 19: matching.MatchDriverRequest.preferences:type_name -> matching.MatchingPreferences
+	46, // 20: matching.MatchingPreferences.custom_preferences:type_name -> matching.MatchingPreferences.CustomPreferencesEntry
+	5,  // 21: matching.MatchDriverResponse.result:type_name -> matching.MatchResult
+	0,  // 22: matching.UpdateDriverLocationRequest.location:type_name -> matching.Location
+	1,  // 23: matching.GetDriverResponse.driver:type_name -> matching.Driver
+	0,  // 24: matching.GetActiveDriversRequest.center:type_name -> matching.Location
+	1,  // 25: matching.GetActiveDriversResponse.drivers:type_name -> matching.Driver
+	6,  // 26: matching.GetActiveDriversResponse.metadata:type_name -> matching.MatchingMetadata
+	7,  // 27: matching.BatchUpdateDriversRequest.updates:type_name -> matching.DriverLocationUpdate
+	48, // 28: matching.GetMatchingStatsRequest.from_time:type_name -> google.protobuf.Timestamp
+	48, // 29: matching.GetMatchingStatsRequest.to_time:type_name -> google.protobuf.Timestamp
+	47, // 30: matching.MatchingStats.vehicle_type_distribution:type_name -> matching.MatchingStats.VehicleTypeDistributionEntry
+	22, // 31: matching.GetMatchingStatsResponse.stats:type_name -> matching.MatchingStats
+	0,  // 32: matching.DriverOffer.pickup_location:type_name -> matching.Location
+	0,  // 33: matching.DriverOffer.destination:type_name -> matching.Location
+	48, // 34: matching.DriverOffer.offered_at:type_name -> google.protobuf.Timestamp
+	48, // 35: matching.DriverOffer.expires_at:type_name -> google.protobuf.Timestamp
+	24, // 36: matching.AcceptOfferResponse.offer:type_name -> matching.DriverOffer
+	24, // 37: matching.DeclineOfferResponse.next_offer:type_name -> matching.DriverOffer
+	48, // 38: matching.MatchingProgress.next_attempt_at:type_name -> google.protobuf.Timestamp
+	48, // 39: matching.MatchingProgress.deadline:type_name -> google.protobuf.Timestamp
+	48, // 40: matching.MatchingProgress.updated_at:type_name -> google.protobuf.Timestamp
+	32, // 41: matching.GetMatchingAttemptLogResponse.events:type_name -> matching.MatchingProgress
+	48, // 42: matching.GetMatchingAttemptLogResponse.started_at:type_name -> google.protobuf.Timestamp
+	48, // 43: matching.GetMatchingAttemptLogResponse.finished_at:type_name -> google.protobuf.Timestamp
+	48, // 44: matching.MatchingConfig.updated_at:type_name -> google.protobuf.Timestamp
+	36, // 45: matching.ListMatchingConfigsResponse.configs:type_name -> matching.MatchingConfig
+	36, // 46: matching.SaveMatchingConfigRequest.config:type_name -> matching.MatchingConfig
+	8,  // 47: matching.MatchingService.FindNearbyDrivers:input_type -> matching.FindNearbyDriversRequest
+	10, // 48: matching.MatchingService.MatchDriver:input_type -> matching.MatchDriverRequest
+	13, // 49: matching.MatchingService.UpdateDriverLocation:input_type -> matching.UpdateDriverLocationRequest
+	15, // 50: matching.MatchingService.GetDriver:input_type -> matching.GetDriverRequest
+	17, // 51: matching.MatchingService.GetActiveDrivers:input_type -> matching.GetActiveDriversRequest
+	19, // 52: matching.MatchingService.BatchUpdateDrivers:input_type -> matching.BatchUpdateDriversRequest
+	21, // 53: matching.MatchingService.GetMatchingStats:input_type -> matching.GetMatchingStatsRequest
+	25, // 54: matching.MatchingService.AcceptOffer:input_type -> matching.AcceptOfferRequest
+	27, // 55: matching.MatchingService.DeclineOffer:input_type -> matching.DeclineOfferRequest
+	29, // 56: matching.MatchingService.ReleaseDriverReservation:input_type -> matching.ReleaseDriverReservationRequest
+	34, // 57: matching.MatchingService.GetMatchingAttemptLog:input_type -> matching.GetMatchingAttemptLogRequest
+	37, // 58: matching.MatchingService.ListMatchingConfigs:input_type -> matching.ListMatchingConfigsRequest
+	39, // 59: matching.MatchingService.GetMatchingConfig:input_type -> matching.GetMatchingConfigRequest
+	40, // 60: matching.MatchingService.SaveMatchingConfig:input_type -> matching.SaveMatchingConfigRequest
+	41, // 61: matching.MatchingService.DeleteMatchingConfig:input_type -> matching.DeleteMatchingConfigRequest
+	7,  // 62: matching.MatchingService.StreamDriverUpdates:input_type -> matching.DriverLocationUpdate
+	31, // 63: matching.MatchingService.StreamDriverOffers:input_type -> matching.StreamDriverOffersRequest
+	33, // 64: matching.MatchingService.StreamMatchingProgress:input_type -> matching.StreamMatchingProgressRequest
+	9,  // 65: matching.MatchingService.FindNearbyDrivers:output_type -> matching.FindNearbyDriversResponse
+	12, // 66: matching.MatchingService.MatchDriver:output_type -> matching.MatchDriverResponse
+	14, // 67: matching.MatchingService.UpdateDriverLocation:output_type -> matching.UpdateDriverLocationResponse
+	16, // 68: matching.MatchingService.GetDriver:output_type -> matching.GetDriverResponse
+	18, // 69: matching.MatchingService.GetActiveDrivers:output_type -> matching.GetActiveDriversResponse
+	20, // 70: matching.MatchingService.BatchUpdateDrivers:output_type -> matching.BatchUpdateDriversResponse
+	23, // 71: matching.MatchingService.GetMatchingStats:output_type -> matching.GetMatchingStatsResponse
+	26, // 72: matching.MatchingService.AcceptOffer:output_type -> matching.AcceptOfferResponse
+	28, // 73: matching.MatchingService.DeclineOffer:output_type -> matching.DeclineOfferResponse
+	30, // 74: matching.MatchingService.ReleaseDriverReservation:output_type -> matching.ReleaseDriverReservationResponse
+	35, // 75: matching.MatchingService.GetMatchingAttemptLog:output_type -> matching.GetMatchingAttemptLogResponse
+	38, // 76: matching.MatchingService.ListMatchingConfigs:output_type -> matching.ListMatchingConfigsResponse
+	36, // 77: matching.MatchingService.GetMatchingConfig:output_type -> matching.MatchingConfig
+	36, // 78: matching.MatchingService.SaveMatchingConfig:output_type -> matching.MatchingConfig
+	42, // 79: matching.MatchingService.DeleteMatchingConfig:output_type -> matching.DeleteMatchingConfigResponse
+	14, // 80: matching.MatchingService.StreamDriverUpdates:output_type -> matching.UpdateDriverLocationResponse
+	24, // 81: matching.MatchingService.StreamDriverOffers:output_type -> matching.DriverOffer
+	32, // 82: matching.MatchingService.StreamMatchingProgress:output_type -> matching.MatchingProgress
+	65, // [65:83] is the sub-list for method output_type
+	47, // [47:65] is the sub-list for method input_type
+	47, // [47:47] is the sub-list for extension type_name
+	47, // [47:47] is the sub-list for extension extendee
+	0,  // [0:47] is the sub-list for field type_name
 }
 
 func init() { file_shared_proto_matching_matching_proto_init() }
@@ -1888,7 +3537,7 @@ func file_shared_proto_matching_matching_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_shared_proto_matching_matching_proto_rawDesc), len(file_shared_proto_matching_matching_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   28,
+			NumMessages:   48,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
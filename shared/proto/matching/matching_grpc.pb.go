@@ -19,14 +19,24 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	MatchingService_FindNearbyDrivers_FullMethodName    = "/matching.MatchingService/FindNearbyDrivers"
-	MatchingService_MatchDriver_FullMethodName          = "/matching.MatchingService/MatchDriver"
-	MatchingService_UpdateDriverLocation_FullMethodName = "/matching.MatchingService/UpdateDriverLocation"
-	MatchingService_GetDriver_FullMethodName            = "/matching.MatchingService/GetDriver"
-	MatchingService_GetActiveDrivers_FullMethodName     = "/matching.MatchingService/GetActiveDrivers"
-	MatchingService_BatchUpdateDrivers_FullMethodName   = "/matching.MatchingService/BatchUpdateDrivers"
-	MatchingService_GetMatchingStats_FullMethodName     = "/matching.MatchingService/GetMatchingStats"
-	MatchingService_StreamDriverUpdates_FullMethodName  = "/matching.MatchingService/StreamDriverUpdates"
+	MatchingService_FindNearbyDrivers_FullMethodName        = "/matching.MatchingService/FindNearbyDrivers"
+	MatchingService_MatchDriver_FullMethodName              = "/matching.MatchingService/MatchDriver"
+	MatchingService_UpdateDriverLocation_FullMethodName     = "/matching.MatchingService/UpdateDriverLocation"
+	MatchingService_GetDriver_FullMethodName                = "/matching.MatchingService/GetDriver"
+	MatchingService_GetActiveDrivers_FullMethodName         = "/matching.MatchingService/GetActiveDrivers"
+	MatchingService_BatchUpdateDrivers_FullMethodName       = "/matching.MatchingService/BatchUpdateDrivers"
+	MatchingService_GetMatchingStats_FullMethodName         = "/matching.MatchingService/GetMatchingStats"
+	MatchingService_AcceptOffer_FullMethodName              = "/matching.MatchingService/AcceptOffer"
+	MatchingService_DeclineOffer_FullMethodName             = "/matching.MatchingService/DeclineOffer"
+	MatchingService_ReleaseDriverReservation_FullMethodName = "/matching.MatchingService/ReleaseDriverReservation"
+	MatchingService_GetMatchingAttemptLog_FullMethodName    = "/matching.MatchingService/GetMatchingAttemptLog"
+	MatchingService_ListMatchingConfigs_FullMethodName      = "/matching.MatchingService/ListMatchingConfigs"
+	MatchingService_GetMatchingConfig_FullMethodName        = "/matching.MatchingService/GetMatchingConfig"
+	MatchingService_SaveMatchingConfig_FullMethodName       = "/matching.MatchingService/SaveMatchingConfig"
+	MatchingService_DeleteMatchingConfig_FullMethodName     = "/matching.MatchingService/DeleteMatchingConfig"
+	MatchingService_StreamDriverUpdates_FullMethodName      = "/matching.MatchingService/StreamDriverUpdates"
+	MatchingService_StreamDriverOffers_FullMethodName       = "/matching.MatchingService/StreamDriverOffers"
+	MatchingService_StreamMatchingProgress_FullMethodName   = "/matching.MatchingService/StreamMatchingProgress"
 )
 
 // MatchingServiceClient is the client API for MatchingService service.
@@ -42,8 +52,20 @@ type MatchingServiceClient interface {
 	GetActiveDrivers(ctx context.Context, in *GetActiveDriversRequest, opts ...grpc.CallOption) (*GetActiveDriversResponse, error)
 	BatchUpdateDrivers(ctx context.Context, in *BatchUpdateDriversRequest, opts ...grpc.CallOption) (*BatchUpdateDriversResponse, error)
 	GetMatchingStats(ctx context.Context, in *GetMatchingStatsRequest, opts ...grpc.CallOption) (*GetMatchingStatsResponse, error)
+	// Driver offers
+	AcceptOffer(ctx context.Context, in *AcceptOfferRequest, opts ...grpc.CallOption) (*AcceptOfferResponse, error)
+	DeclineOffer(ctx context.Context, in *DeclineOfferRequest, opts ...grpc.CallOption) (*DeclineOfferResponse, error)
+	ReleaseDriverReservation(ctx context.Context, in *ReleaseDriverReservationRequest, opts ...grpc.CallOption) (*ReleaseDriverReservationResponse, error)
+	GetMatchingAttemptLog(ctx context.Context, in *GetMatchingAttemptLogRequest, opts ...grpc.CallOption) (*GetMatchingAttemptLogResponse, error)
+	// Per-city matching configs
+	ListMatchingConfigs(ctx context.Context, in *ListMatchingConfigsRequest, opts ...grpc.CallOption) (*ListMatchingConfigsResponse, error)
+	GetMatchingConfig(ctx context.Context, in *GetMatchingConfigRequest, opts ...grpc.CallOption) (*MatchingConfig, error)
+	SaveMatchingConfig(ctx context.Context, in *SaveMatchingConfigRequest, opts ...grpc.CallOption) (*MatchingConfig, error)
+	DeleteMatchingConfig(ctx context.Context, in *DeleteMatchingConfigRequest, opts ...grpc.CallOption) (*DeleteMatchingConfigResponse, error)
 	// Real-time streaming
 	StreamDriverUpdates(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[DriverLocationUpdate, UpdateDriverLocationResponse], error)
+	StreamDriverOffers(ctx context.Context, in *StreamDriverOffersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DriverOffer], error)
+	StreamMatchingProgress(ctx context.Context, in *StreamMatchingProgressRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MatchingProgress], error)
 }
 
 type matchingServiceClient struct {
@@ -124,6 +146,86 @@ func (c *matchingServiceClient) GetMatchingStats(ctx context.Context, in *GetMat
 	return out, nil
 }
 
+func (c *matchingServiceClient) AcceptOffer(ctx context.Context, in *AcceptOfferRequest, opts ...grpc.CallOption) (*AcceptOfferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AcceptOfferResponse)
+	err := c.cc.Invoke(ctx, MatchingService_AcceptOffer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchingServiceClient) DeclineOffer(ctx context.Context, in *DeclineOfferRequest, opts ...grpc.CallOption) (*DeclineOfferResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeclineOfferResponse)
+	err := c.cc.Invoke(ctx, MatchingService_DeclineOffer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchingServiceClient) ReleaseDriverReservation(ctx context.Context, in *ReleaseDriverReservationRequest, opts ...grpc.CallOption) (*ReleaseDriverReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseDriverReservationResponse)
+	err := c.cc.Invoke(ctx, MatchingService_ReleaseDriverReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchingServiceClient) GetMatchingAttemptLog(ctx context.Context, in *GetMatchingAttemptLogRequest, opts ...grpc.CallOption) (*GetMatchingAttemptLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMatchingAttemptLogResponse)
+	err := c.cc.Invoke(ctx, MatchingService_GetMatchingAttemptLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchingServiceClient) ListMatchingConfigs(ctx context.Context, in *ListMatchingConfigsRequest, opts ...grpc.CallOption) (*ListMatchingConfigsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMatchingConfigsResponse)
+	err := c.cc.Invoke(ctx, MatchingService_ListMatchingConfigs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchingServiceClient) GetMatchingConfig(ctx context.Context, in *GetMatchingConfigRequest, opts ...grpc.CallOption) (*MatchingConfig, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MatchingConfig)
+	err := c.cc.Invoke(ctx, MatchingService_GetMatchingConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchingServiceClient) SaveMatchingConfig(ctx context.Context, in *SaveMatchingConfigRequest, opts ...grpc.CallOption) (*MatchingConfig, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MatchingConfig)
+	err := c.cc.Invoke(ctx, MatchingService_SaveMatchingConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchingServiceClient) DeleteMatchingConfig(ctx context.Context, in *DeleteMatchingConfigRequest, opts ...grpc.CallOption) (*DeleteMatchingConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteMatchingConfigResponse)
+	err := c.cc.Invoke(ctx, MatchingService_DeleteMatchingConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *matchingServiceClient) StreamDriverUpdates(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[DriverLocationUpdate, UpdateDriverLocationResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &MatchingService_ServiceDesc.Streams[0], MatchingService_StreamDriverUpdates_FullMethodName, cOpts...)
@@ -137,6 +239,44 @@ func (c *matchingServiceClient) StreamDriverUpdates(ctx context.Context, opts ..
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type MatchingService_StreamDriverUpdatesClient = grpc.BidiStreamingClient[DriverLocationUpdate, UpdateDriverLocationResponse]
 
+func (c *matchingServiceClient) StreamDriverOffers(ctx context.Context, in *StreamDriverOffersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DriverOffer], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MatchingService_ServiceDesc.Streams[1], MatchingService_StreamDriverOffers_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamDriverOffersRequest, DriverOffer]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MatchingService_StreamDriverOffersClient = grpc.ServerStreamingClient[DriverOffer]
+
+func (c *matchingServiceClient) StreamMatchingProgress(ctx context.Context, in *StreamMatchingProgressRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MatchingProgress], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MatchingService_ServiceDesc.Streams[2], MatchingService_StreamMatchingProgress_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamMatchingProgressRequest, MatchingProgress]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MatchingService_StreamMatchingProgressClient = grpc.ServerStreamingClient[MatchingProgress]
+
 // MatchingServiceServer is the server API for MatchingService service.
 // All implementations must embed UnimplementedMatchingServiceServer
 // for forward compatibility.
@@ -150,8 +290,20 @@ type MatchingServiceServer interface {
 	GetActiveDrivers(context.Context, *GetActiveDriversRequest) (*GetActiveDriversResponse, error)
 	BatchUpdateDrivers(context.Context, *BatchUpdateDriversRequest) (*BatchUpdateDriversResponse, error)
 	GetMatchingStats(context.Context, *GetMatchingStatsRequest) (*GetMatchingStatsResponse, error)
+	// Driver offers
+	AcceptOffer(context.Context, *AcceptOfferRequest) (*AcceptOfferResponse, error)
+	DeclineOffer(context.Context, *DeclineOfferRequest) (*DeclineOfferResponse, error)
+	ReleaseDriverReservation(context.Context, *ReleaseDriverReservationRequest) (*ReleaseDriverReservationResponse, error)
+	GetMatchingAttemptLog(context.Context, *GetMatchingAttemptLogRequest) (*GetMatchingAttemptLogResponse, error)
+	// Per-city matching configs
+	ListMatchingConfigs(context.Context, *ListMatchingConfigsRequest) (*ListMatchingConfigsResponse, error)
+	GetMatchingConfig(context.Context, *GetMatchingConfigRequest) (*MatchingConfig, error)
+	SaveMatchingConfig(context.Context, *SaveMatchingConfigRequest) (*MatchingConfig, error)
+	DeleteMatchingConfig(context.Context, *DeleteMatchingConfigRequest) (*DeleteMatchingConfigResponse, error)
 	// Real-time streaming
 	StreamDriverUpdates(grpc.BidiStreamingServer[DriverLocationUpdate, UpdateDriverLocationResponse]) error
+	StreamDriverOffers(*StreamDriverOffersRequest, grpc.ServerStreamingServer[DriverOffer]) error
+	StreamMatchingProgress(*StreamMatchingProgressRequest, grpc.ServerStreamingServer[MatchingProgress]) error
 	mustEmbedUnimplementedMatchingServiceServer()
 }
 
@@ -183,9 +335,39 @@ func (UnimplementedMatchingServiceServer) BatchUpdateDrivers(context.Context, *B
 func (UnimplementedMatchingServiceServer) GetMatchingStats(context.Context, *GetMatchingStatsRequest) (*GetMatchingStatsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMatchingStats not implemented")
 }
+func (UnimplementedMatchingServiceServer) AcceptOffer(context.Context, *AcceptOfferRequest) (*AcceptOfferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptOffer not implemented")
+}
+func (UnimplementedMatchingServiceServer) DeclineOffer(context.Context, *DeclineOfferRequest) (*DeclineOfferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeclineOffer not implemented")
+}
+func (UnimplementedMatchingServiceServer) ReleaseDriverReservation(context.Context, *ReleaseDriverReservationRequest) (*ReleaseDriverReservationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseDriverReservation not implemented")
+}
+func (UnimplementedMatchingServiceServer) GetMatchingAttemptLog(context.Context, *GetMatchingAttemptLogRequest) (*GetMatchingAttemptLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMatchingAttemptLog not implemented")
+}
+func (UnimplementedMatchingServiceServer) ListMatchingConfigs(context.Context, *ListMatchingConfigsRequest) (*ListMatchingConfigsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMatchingConfigs not implemented")
+}
+func (UnimplementedMatchingServiceServer) GetMatchingConfig(context.Context, *GetMatchingConfigRequest) (*MatchingConfig, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMatchingConfig not implemented")
+}
+func (UnimplementedMatchingServiceServer) SaveMatchingConfig(context.Context, *SaveMatchingConfigRequest) (*MatchingConfig, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveMatchingConfig not implemented")
+}
+func (UnimplementedMatchingServiceServer) DeleteMatchingConfig(context.Context, *DeleteMatchingConfigRequest) (*DeleteMatchingConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMatchingConfig not implemented")
+}
 func (UnimplementedMatchingServiceServer) StreamDriverUpdates(grpc.BidiStreamingServer[DriverLocationUpdate, UpdateDriverLocationResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method StreamDriverUpdates not implemented")
 }
+func (UnimplementedMatchingServiceServer) StreamDriverOffers(*StreamDriverOffersRequest, grpc.ServerStreamingServer[DriverOffer]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamDriverOffers not implemented")
+}
+func (UnimplementedMatchingServiceServer) StreamMatchingProgress(*StreamMatchingProgressRequest, grpc.ServerStreamingServer[MatchingProgress]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamMatchingProgress not implemented")
+}
 func (UnimplementedMatchingServiceServer) mustEmbedUnimplementedMatchingServiceServer() {}
 func (UnimplementedMatchingServiceServer) testEmbeddedByValue()                         {}
 
@@ -333,6 +515,150 @@ func _MatchingService_GetMatchingStats_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MatchingService_AcceptOffer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptOfferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingServiceServer).AcceptOffer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingService_AcceptOffer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingServiceServer).AcceptOffer(ctx, req.(*AcceptOfferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchingService_DeclineOffer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeclineOfferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingServiceServer).DeclineOffer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingService_DeclineOffer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingServiceServer).DeclineOffer(ctx, req.(*DeclineOfferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchingService_ReleaseDriverReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseDriverReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingServiceServer).ReleaseDriverReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingService_ReleaseDriverReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingServiceServer).ReleaseDriverReservation(ctx, req.(*ReleaseDriverReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchingService_GetMatchingAttemptLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMatchingAttemptLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingServiceServer).GetMatchingAttemptLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingService_GetMatchingAttemptLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingServiceServer).GetMatchingAttemptLog(ctx, req.(*GetMatchingAttemptLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchingService_ListMatchingConfigs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMatchingConfigsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingServiceServer).ListMatchingConfigs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingService_ListMatchingConfigs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingServiceServer).ListMatchingConfigs(ctx, req.(*ListMatchingConfigsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchingService_GetMatchingConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMatchingConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingServiceServer).GetMatchingConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingService_GetMatchingConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingServiceServer).GetMatchingConfig(ctx, req.(*GetMatchingConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchingService_SaveMatchingConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveMatchingConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingServiceServer).SaveMatchingConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingService_SaveMatchingConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingServiceServer).SaveMatchingConfig(ctx, req.(*SaveMatchingConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MatchingService_DeleteMatchingConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMatchingConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchingServiceServer).DeleteMatchingConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MatchingService_DeleteMatchingConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchingServiceServer).DeleteMatchingConfig(ctx, req.(*DeleteMatchingConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MatchingService_StreamDriverUpdates_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(MatchingServiceServer).StreamDriverUpdates(&grpc.GenericServerStream[DriverLocationUpdate, UpdateDriverLocationResponse]{ServerStream: stream})
 }
@@ -340,6 +666,28 @@ func _MatchingService_StreamDriverUpdates_Handler(srv interface{}, stream grpc.S
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type MatchingService_StreamDriverUpdatesServer = grpc.BidiStreamingServer[DriverLocationUpdate, UpdateDriverLocationResponse]
 
+func _MatchingService_StreamDriverOffers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamDriverOffersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MatchingServiceServer).StreamDriverOffers(m, &grpc.GenericServerStream[StreamDriverOffersRequest, DriverOffer]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MatchingService_StreamDriverOffersServer = grpc.ServerStreamingServer[DriverOffer]
+
+func _MatchingService_StreamMatchingProgress_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamMatchingProgressRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MatchingServiceServer).StreamMatchingProgress(m, &grpc.GenericServerStream[StreamMatchingProgressRequest, MatchingProgress]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MatchingService_StreamMatchingProgressServer = grpc.ServerStreamingServer[MatchingProgress]
+
 // MatchingService_ServiceDesc is the grpc.ServiceDesc for MatchingService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -375,6 +723,38 @@ var MatchingService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetMatchingStats",
 			Handler:    _MatchingService_GetMatchingStats_Handler,
 		},
+		{
+			MethodName: "AcceptOffer",
+			Handler:    _MatchingService_AcceptOffer_Handler,
+		},
+		{
+			MethodName: "DeclineOffer",
+			Handler:    _MatchingService_DeclineOffer_Handler,
+		},
+		{
+			MethodName: "ReleaseDriverReservation",
+			Handler:    _MatchingService_ReleaseDriverReservation_Handler,
+		},
+		{
+			MethodName: "GetMatchingAttemptLog",
+			Handler:    _MatchingService_GetMatchingAttemptLog_Handler,
+		},
+		{
+			MethodName: "ListMatchingConfigs",
+			Handler:    _MatchingService_ListMatchingConfigs_Handler,
+		},
+		{
+			MethodName: "GetMatchingConfig",
+			Handler:    _MatchingService_GetMatchingConfig_Handler,
+		},
+		{
+			MethodName: "SaveMatchingConfig",
+			Handler:    _MatchingService_SaveMatchingConfig_Handler,
+		},
+		{
+			MethodName: "DeleteMatchingConfig",
+			Handler:    _MatchingService_DeleteMatchingConfig_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -383,6 +763,16 @@ var MatchingService_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "StreamDriverOffers",
+			Handler:       _MatchingService_StreamDriverOffers_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamMatchingProgress",
+			Handler:       _MatchingService_StreamMatchingProgress_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "shared/proto/matching/matching.proto",
 }
@@ -32,6 +32,7 @@ const (
 	PaymentMethod_DIGITAL_WALLET         PaymentMethod = 3
 	PaymentMethod_BANK_TRANSFER          PaymentMethod = 4
 	PaymentMethod_CASH                   PaymentMethod = 5
+	PaymentMethod_WALLET_BALANCE         PaymentMethod = 6
 )
 
 // Enum value maps for PaymentMethod.
@@ -43,6 +44,7 @@ var (
 		3: "DIGITAL_WALLET",
 		4: "BANK_TRANSFER",
 		5: "CASH",
+		6: "WALLET_BALANCE",
 	}
 	PaymentMethod_value = map[string]int32{
 		"UNKNOWN_PAYMENT_METHOD": 0,
@@ -51,6 +53,7 @@ var (
 		"DIGITAL_WALLET":         3,
 		"BANK_TRANSFER":          4,
 		"CASH":                   5,
+		"WALLET_BALANCE":         6,
 	}
 )
 
@@ -278,6 +281,7 @@ type Payment struct {
 	ProcessedAt       *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=processed_at,json=processedAt,proto3" json:"processed_at,omitempty"`
 	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt         *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	RefundedAmount    float64                `protobuf:"fixed64,18,opt,name=refunded_amount,json=refundedAmount,proto3" json:"refunded_amount,omitempty"` // sum of refunds that have not failed
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -431,6 +435,13 @@ func (x *Payment) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Payment) GetRefundedAmount() float64 {
+	if x != nil {
+		return x.RefundedAmount
+	}
+	return 0
+}
+
 // Payment method details
 type PaymentMethodDetails struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
@@ -1282,11 +1293,15 @@ func (x *GetUserPaymentMethodsResponse) GetCount() int32 {
 	return 0
 }
 
+// Payment lists are read a page at a time. The first page is read without a
+// cursor, the following ones with the next_cursor of the page before.
 type GetUserPaymentsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Limit  int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor string                 `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// Field to sort on, "-" prefixed for descending; newest first by default
+	Sort          string `protobuf:"bytes,5,opt,name=sort,proto3" json:"sort,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1335,18 +1350,27 @@ func (x *GetUserPaymentsRequest) GetLimit() int32 {
 	return 0
 }
 
-func (x *GetUserPaymentsRequest) GetOffset() int32 {
+func (x *GetUserPaymentsRequest) GetCursor() string {
 	if x != nil {
-		return x.Offset
+		return x.Cursor
 	}
-	return 0
+	return ""
+}
+
+func (x *GetUserPaymentsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
 }
 
 type GetUserPaymentsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Payments      []*Payment             `protobuf:"bytes,1,rep,name=payments,proto3" json:"payments,omitempty"`
-	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
-	HasMore       bool                   `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Payments []*Payment             `protobuf:"bytes,1,rep,name=payments,proto3" json:"payments,omitempty"`
+	// About how many payments the user has across all pages
+	TotalCount    int32  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	HasMore       bool   `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	NextCursor    string `protobuf:"bytes,4,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1402,6 +1426,13 @@ func (x *GetUserPaymentsResponse) GetHasMore() bool {
 	return false
 }
 
+func (x *GetUserPaymentsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
 type GetTripPaymentsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TripId        string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
@@ -1498,139 +1529,3661 @@ func (x *GetTripPaymentsResponse) GetCount() int32 {
 	return 0
 }
 
-var File_shared_proto_payment_payment_proto protoreflect.FileDescriptor
+// ListPaymentsRequest pages through payments created in [created_after, created_before)
+// Lists payments created in a time window, oldest first
+type ListPaymentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CreatedAfter  *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string                 `protobuf:"bytes,5,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_shared_proto_payment_payment_proto_rawDesc = "" +
-	"\n" +
-	"\"shared/proto/payment/payment.proto\x12\apayment\x1a\x1fgoogle/protobuf/timestamp.proto\"\x92\a\n" +
-	"\aPayment\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
-	"\atrip_id\x18\x02 \x01(\tR\x06tripId\x12\x17\n" +
-	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x1b\n" +
-	"\tdriver_id\x18\x04 \x01(\tR\bdriverId\x12\x16\n" +
-	"\x06amount\x18\x05 \x01(\x01R\x06amount\x12\x1a\n" +
-	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x12=\n" +
-	"\x0epayment_method\x18\a \x01(\x0e2\x16.payment.PaymentMethodR\rpaymentMethod\x12.\n" +
-	"\x06status\x18\b \x01(\x0e2\x16.payment.PaymentStatusR\x06status\x12C\n" +
-	"\x10transaction_type\x18\t \x01(\x0e2\x18.payment.TransactionTypeR\x0ftransactionType\x12-\n" +
-	"\x12processor_response\x18\n" +
-	" \x01(\tR\x11processorResponse\x126\n" +
-	"\n" +
-	"fraud_risk\x18\v \x01(\x0e2\x17.payment.FraudRiskLevelR\tfraudRisk\x12D\n" +
-	"\ffraud_scores\x18\f \x03(\v2!.payment.Payment.FraudScoresEntryR\vfraudScores\x12:\n" +
-	"\bmetadata\x18\r \x03(\v2\x1e.payment.Payment.MetadataEntryR\bmetadata\x12%\n" +
-	"\x0efailure_reason\x18\x0e \x01(\tR\rfailureReason\x12=\n" +
-	"\fprocessed_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\vprocessedAt\x129\n" +
-	"\n" +
-	"created_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
-	"\n" +
-	"updated_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x1a>\n" +
-	"\x10FraudScoresEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\x1a;\n" +
-	"\rMetadataEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd1\x04\n" +
-	"\x14PaymentMethodDetails\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\x12*\n" +
-	"\x04type\x18\x03 \x01(\x0e2\x16.payment.PaymentMethodR\x04type\x12\x1d\n" +
-	"\n" +
-	"is_default\x18\x04 \x01(\bR\tisDefault\x12 \n" +
-	"\vfingerprint\x18\x05 \x01(\tR\vfingerprint\x12;\n" +
-	"\vexpiry_date\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
-	"expiryDate\x12(\n" +
-	"\x10last_four_digits\x18\a \x01(\tR\x0elastFourDigits\x12\x1b\n" +
-	"\tbank_name\x18\b \x01(\tR\bbankName\x12'\n" +
-	"\x0fwallet_provider\x18\t \x01(\tR\x0ewalletProvider\x12D\n" +
-	"\adetails\x18\n" +
-	" \x03(\v2*.payment.PaymentMethodDetails.DetailsEntryR\adetails\x129\n" +
-	"\n" +
-	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
-	"\n" +
-	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x1a:\n" +
-	"\fDetailsEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd5\x02\n" +
-	"\x14FraudDetectionResult\x12%\n" +
-	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x126\n" +
-	"\n" +
-	"risk_level\x18\x02 \x01(\x0e2\x17.payment.FraudRiskLevelR\triskLevel\x12\x1d\n" +
-	"\n" +
-	"risk_score\x18\x03 \x01(\x01R\triskScore\x12\x18\n" +
-	"\areasons\x18\x04 \x03(\tR\areasons\x12A\n" +
-	"\x06scores\x18\x05 \x03(\v2).payment.FraudDetectionResult.ScoresEntryR\x06scores\x12'\n" +
-	"\x0frequires_review\x18\x06 \x01(\bR\x0erequiresReview\x1a9\n" +
-	"\vScoresEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"\xef\x02\n" +
-	"\x15ProcessPaymentRequest\x12\x17\n" +
-	"\atrip_id\x18\x01 \x01(\tR\x06tripId\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1b\n" +
-	"\tdriver_id\x18\x03 \x01(\tR\bdriverId\x12\x16\n" +
-	"\x06amount\x18\x04 \x01(\x01R\x06amount\x12\x1a\n" +
-	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12*\n" +
-	"\x11payment_method_id\x18\x06 \x01(\tR\x0fpaymentMethodId\x12 \n" +
-	"\vdescription\x18\a \x01(\tR\vdescription\x12H\n" +
-	"\bmetadata\x18\b \x03(\v2,.payment.ProcessPaymentRequest.MetadataEntryR\bmetadata\x1a;\n" +
-	"\rMetadataEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x90\x01\n" +
-	"\x16ProcessPaymentResponse\x12*\n" +
-	"\apayment\x18\x01 \x01(\v2\x10.payment.PaymentR\apayment\x12\x18\n" +
-	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage\x12\x16\n" +
-	"\x06errors\x18\x04 \x03(\tR\x06errors\"\x88\x01\n" +
-	"\x14ProcessRefundRequest\x12\x1d\n" +
-	"\n" +
-	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x16\n" +
-	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12\x16\n" +
-	"\x06reason\x18\x03 \x01(\tR\x06reason\x12!\n" +
-	"\frequested_by\x18\x04 \x01(\tR\vrequestedBy\"\x80\x01\n" +
-	"\x15ProcessRefundResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1b\n" +
-	"\trefund_id\x18\x03 \x01(\tR\brefundId\x12\x16\n" +
-	"\x06errors\x18\x04 \x03(\tR\x06errors\"\x82\x02\n" +
-	"\x17AddPaymentMethodRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12*\n" +
-	"\x04type\x18\x02 \x01(\x0e2\x16.payment.PaymentMethodR\x04type\x12G\n" +
-	"\adetails\x18\x03 \x03(\v2-.payment.AddPaymentMethodRequest.DetailsEntryR\adetails\x12\x1d\n" +
-	"\n" +
-	"is_default\x18\x04 \x01(\bR\tisDefault\x1a:\n" +
-	"\fDetailsEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xac\x01\n" +
-	"\x18AddPaymentMethodResponse\x12D\n" +
-	"\x0epayment_method\x18\x01 \x01(\v2\x1d.payment.PaymentMethodDetailsR\rpaymentMethod\x12\x18\n" +
-	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage\x12\x16\n" +
-	"\x06errors\x18\x04 \x03(\tR\x06errors\"2\n" +
-	"\x11GetPaymentRequest\x12\x1d\n" +
-	"\n" +
-	"payment_id\x18\x01 \x01(\tR\tpaymentId\"V\n" +
-	"\x12GetPaymentResponse\x12*\n" +
-	"\apayment\x18\x01 \x01(\v2\x10.payment.PaymentR\apayment\x12\x14\n" +
-	"\x05found\x18\x02 \x01(\bR\x05found\"7\n" +
-	"\x1cGetUserPaymentMethodsRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"}\n" +
-	"\x1dGetUserPaymentMethodsResponse\x12F\n" +
-	"\x0fpayment_methods\x18\x01 \x03(\v2\x1d.payment.PaymentMethodDetailsR\x0epaymentMethods\x12\x14\n" +
-	"\x05count\x18\x02 \x01(\x05R\x05count\"_\n" +
-	"\x16GetUserPaymentsRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x03 \x01(\x05R\x06offset\"\x83\x01\n" +
-	"\x17GetUserPaymentsResponse\x12,\n" +
-	"\bpayments\x18\x01 \x03(\v2\x10.payment.PaymentR\bpayments\x12\x1f\n" +
-	"\vtotal_count\x18\x02 \x01(\x05R\n" +
-	"totalCount\x12\x19\n" +
-	"\bhas_more\x18\x03 \x01(\bR\ahasMore\"1\n" +
-	"\x16GetTripPaymentsRequest\x12\x17\n" +
-	"\atrip_id\x18\x01 \x01(\tR\x06tripId\"]\n" +
-	"\x17GetTripPaymentsResponse\x12,\n" +
-	"\bpayments\x18\x01 \x03(\v2\x10.payment.PaymentR\bpayments\x12\x14\n" +
-	"\x05count\x18\x02 \x01(\x05R\x05count*}\n" +
+func (x *ListPaymentsRequest) Reset() {
+	*x = ListPaymentsRequest{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPaymentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPaymentsRequest) ProtoMessage() {}
+
+func (x *ListPaymentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPaymentsRequest.ProtoReflect.Descriptor instead.
+func (*ListPaymentsRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListPaymentsRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *ListPaymentsRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *ListPaymentsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListPaymentsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ListPaymentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payments      []*Payment             `protobuf:"bytes,1,rep,name=payments,proto3" json:"payments,omitempty"`
+	HasMore       bool                   `protobuf:"varint,2,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPaymentsResponse) Reset() {
+	*x = ListPaymentsResponse{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPaymentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPaymentsResponse) ProtoMessage() {}
+
+func (x *ListPaymentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPaymentsResponse.ProtoReflect.Descriptor instead.
+func (*ListPaymentsResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListPaymentsResponse) GetPayments() []*Payment {
+	if x != nil {
+		return x.Payments
+	}
+	return nil
+}
+
+func (x *ListPaymentsResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+func (x *ListPaymentsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+// Lists payments in one status, newest first, e.g. the failed payments
+// operators follow up on
+type ListPaymentsByStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        PaymentStatus          `protobuf:"varint,1,opt,name=status,proto3,enum=payment.PaymentStatus" json:"status,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string                 `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPaymentsByStatusRequest) Reset() {
+	*x = ListPaymentsByStatusRequest{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPaymentsByStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPaymentsByStatusRequest) ProtoMessage() {}
+
+func (x *ListPaymentsByStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPaymentsByStatusRequest.ProtoReflect.Descriptor instead.
+func (*ListPaymentsByStatusRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListPaymentsByStatusRequest) GetStatus() PaymentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PaymentStatus_UNKNOWN_PAYMENT_STATUS
+}
+
+func (x *ListPaymentsByStatusRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListPaymentsByStatusRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+// Removes a user's saved payment methods for a data subject erasure request.
+// Payments are financial records and are kept.
+type RemoveUserPaymentMethodsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveUserPaymentMethodsRequest) Reset() {
+	*x = RemoveUserPaymentMethodsRequest{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveUserPaymentMethodsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveUserPaymentMethodsRequest) ProtoMessage() {}
+
+func (x *RemoveUserPaymentMethodsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveUserPaymentMethodsRequest.ProtoReflect.Descriptor instead.
+func (*RemoveUserPaymentMethodsRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RemoveUserPaymentMethodsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type RemoveUserPaymentMethodsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RemovedCount  int32                  `protobuf:"varint,1,opt,name=removed_count,json=removedCount,proto3" json:"removed_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveUserPaymentMethodsResponse) Reset() {
+	*x = RemoveUserPaymentMethodsResponse{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveUserPaymentMethodsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveUserPaymentMethodsResponse) ProtoMessage() {}
+
+func (x *RemoveUserPaymentMethodsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveUserPaymentMethodsResponse.ProtoReflect.Descriptor instead.
+func (*RemoveUserPaymentMethodsResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RemoveUserPaymentMethodsResponse) GetRemovedCount() int32 {
+	if x != nil {
+		return x.RemovedCount
+	}
+	return 0
+}
+
+// Gives a rider ride credits in their wallet. type is promotion_credit,
+// refund_credit or guarantee_credit.
+type GrantWalletCreditRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	Type          string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Reference     string                 `protobuf:"bytes,5,opt,name=reference,proto3" json:"reference,omitempty"`
+	Description   string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GrantWalletCreditRequest) Reset() {
+	*x = GrantWalletCreditRequest{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantWalletCreditRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantWalletCreditRequest) ProtoMessage() {}
+
+func (x *GrantWalletCreditRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantWalletCreditRequest.ProtoReflect.Descriptor instead.
+func (*GrantWalletCreditRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GrantWalletCreditRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GrantWalletCreditRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *GrantWalletCreditRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *GrantWalletCreditRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *GrantWalletCreditRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *GrantWalletCreditRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type GrantWalletCreditResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	TransactionId string                 `protobuf:"bytes,3,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Errors        []string               `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GrantWalletCreditResponse) Reset() {
+	*x = GrantWalletCreditResponse{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GrantWalletCreditResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GrantWalletCreditResponse) ProtoMessage() {}
+
+func (x *GrantWalletCreditResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GrantWalletCreditResponse.ProtoReflect.Descriptor instead.
+func (*GrantWalletCreditResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GrantWalletCreditResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GrantWalletCreditResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *GrantWalletCreditResponse) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *GrantWalletCreditResponse) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+// A hold is placed on the rider's payment method when their trip starts,
+// captured for the actual fare when it completes and released when it is
+// cancelled. status is active, captured, released, expired or failed.
+type PaymentHold struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TripId          string                 `protobuf:"bytes,2,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	UserId          string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	PaymentMethodId string                 `protobuf:"bytes,4,opt,name=payment_method_id,json=paymentMethodId,proto3" json:"payment_method_id,omitempty"`
+	EstimatedFare   float64                `protobuf:"fixed64,5,opt,name=estimated_fare,json=estimatedFare,proto3" json:"estimated_fare,omitempty"`
+	Amount          float64                `protobuf:"fixed64,6,opt,name=amount,proto3" json:"amount,omitempty"` // the estimated fare plus a buffer
+	Currency        string                 `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"`
+	Status          string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"`
+	CapturedAmount  float64                `protobuf:"fixed64,9,opt,name=captured_amount,json=capturedAmount,proto3" json:"captured_amount,omitempty"`
+	ReleasedAmount  float64                `protobuf:"fixed64,10,opt,name=released_amount,json=releasedAmount,proto3" json:"released_amount,omitempty"`
+	PaymentId       string                 `protobuf:"bytes,11,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	Renewals        int32                  `protobuf:"varint,12,opt,name=renewals,proto3" json:"renewals,omitempty"`
+	FailureReason   string                 `protobuf:"bytes,13,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	ExpiresAt       *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PaymentHold) Reset() {
+	*x = PaymentHold{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentHold) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentHold) ProtoMessage() {}
+
+func (x *PaymentHold) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentHold.ProtoReflect.Descriptor instead.
+func (*PaymentHold) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *PaymentHold) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PaymentHold) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *PaymentHold) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PaymentHold) GetPaymentMethodId() string {
+	if x != nil {
+		return x.PaymentMethodId
+	}
+	return ""
+}
+
+func (x *PaymentHold) GetEstimatedFare() float64 {
+	if x != nil {
+		return x.EstimatedFare
+	}
+	return 0
+}
+
+func (x *PaymentHold) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *PaymentHold) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *PaymentHold) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PaymentHold) GetCapturedAmount() float64 {
+	if x != nil {
+		return x.CapturedAmount
+	}
+	return 0
+}
+
+func (x *PaymentHold) GetReleasedAmount() float64 {
+	if x != nil {
+		return x.ReleasedAmount
+	}
+	return 0
+}
+
+func (x *PaymentHold) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *PaymentHold) GetRenewals() int32 {
+	if x != nil {
+		return x.Renewals
+	}
+	return 0
+}
+
+func (x *PaymentHold) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+func (x *PaymentHold) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *PaymentHold) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Holds the estimated fare plus a buffer on the rider's payment method, their
+// default one when payment_method_id is empty
+type AuthorizeTripPaymentRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TripId          string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	UserId          string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DriverId        string                 `protobuf:"bytes,3,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	PaymentMethodId string                 `protobuf:"bytes,4,opt,name=payment_method_id,json=paymentMethodId,proto3" json:"payment_method_id,omitempty"`
+	EstimatedFare   float64                `protobuf:"fixed64,5,opt,name=estimated_fare,json=estimatedFare,proto3" json:"estimated_fare,omitempty"`
+	Currency        string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AuthorizeTripPaymentRequest) Reset() {
+	*x = AuthorizeTripPaymentRequest{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuthorizeTripPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuthorizeTripPaymentRequest) ProtoMessage() {}
+
+func (x *AuthorizeTripPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuthorizeTripPaymentRequest.ProtoReflect.Descriptor instead.
+func (*AuthorizeTripPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *AuthorizeTripPaymentRequest) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *AuthorizeTripPaymentRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AuthorizeTripPaymentRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *AuthorizeTripPaymentRequest) GetPaymentMethodId() string {
+	if x != nil {
+		return x.PaymentMethodId
+	}
+	return ""
+}
+
+func (x *AuthorizeTripPaymentRequest) GetEstimatedFare() float64 {
+	if x != nil {
+		return x.EstimatedFare
+	}
+	return 0
+}
+
+func (x *AuthorizeTripPaymentRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+// Charges the actual fare against the trip's hold and releases the rest.
+// Trips without a usable hold are charged directly.
+type CaptureTripPaymentRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TripId          string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	UserId          string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DriverId        string                 `protobuf:"bytes,3,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	PaymentMethodId string                 `protobuf:"bytes,4,opt,name=payment_method_id,json=paymentMethodId,proto3" json:"payment_method_id,omitempty"`
+	Amount          float64                `protobuf:"fixed64,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency        string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CaptureTripPaymentRequest) Reset() {
+	*x = CaptureTripPaymentRequest{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaptureTripPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaptureTripPaymentRequest) ProtoMessage() {}
+
+func (x *CaptureTripPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaptureTripPaymentRequest.ProtoReflect.Descriptor instead.
+func (*CaptureTripPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CaptureTripPaymentRequest) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *CaptureTripPaymentRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CaptureTripPaymentRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *CaptureTripPaymentRequest) GetPaymentMethodId() string {
+	if x != nil {
+		return x.PaymentMethodId
+	}
+	return ""
+}
+
+func (x *CaptureTripPaymentRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CaptureTripPaymentRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type ReleaseTripPaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TripId        string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseTripPaymentRequest) Reset() {
+	*x = ReleaseTripPaymentRequest{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseTripPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseTripPaymentRequest) ProtoMessage() {}
+
+func (x *ReleaseTripPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseTripPaymentRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseTripPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ReleaseTripPaymentRequest) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+type TripPaymentHoldResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Hold          *PaymentHold           `protobuf:"bytes,3,opt,name=hold,proto3" json:"hold,omitempty"`
+	PaymentIds    []string               `protobuf:"bytes,4,rep,name=payment_ids,json=paymentIds,proto3" json:"payment_ids,omitempty"` // charges made by a capture
+	Errors        []string               `protobuf:"bytes,5,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TripPaymentHoldResponse) Reset() {
+	*x = TripPaymentHoldResponse{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TripPaymentHoldResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TripPaymentHoldResponse) ProtoMessage() {}
+
+func (x *TripPaymentHoldResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TripPaymentHoldResponse.ProtoReflect.Descriptor instead.
+func (*TripPaymentHoldResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *TripPaymentHoldResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *TripPaymentHoldResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *TripPaymentHoldResponse) GetHold() *PaymentHold {
+	if x != nil {
+		return x.Hold
+	}
+	return nil
+}
+
+func (x *TripPaymentHoldResponse) GetPaymentIds() []string {
+	if x != nil {
+		return x.PaymentIds
+	}
+	return nil
+}
+
+func (x *TripPaymentHoldResponse) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type ChargebackEvidence struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"` // receipt, trip_route, rider_communication or other
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	SubmittedBy   string                 `protobuf:"bytes,4,opt,name=submitted_by,json=submittedBy,proto3" json:"submitted_by,omitempty"`
+	SubmittedAt   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=submitted_at,json=submittedAt,proto3" json:"submitted_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChargebackEvidence) Reset() {
+	*x = ChargebackEvidence{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChargebackEvidence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChargebackEvidence) ProtoMessage() {}
+
+func (x *ChargebackEvidence) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChargebackEvidence.ProtoReflect.Descriptor instead.
+func (*ChargebackEvidence) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ChargebackEvidence) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ChargebackEvidence) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ChargebackEvidence) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ChargebackEvidence) GetSubmittedBy() string {
+	if x != nil {
+		return x.SubmittedBy
+	}
+	return ""
+}
+
+func (x *ChargebackEvidence) GetSubmittedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SubmittedAt
+	}
+	return nil
+}
+
+// A payment disputed by the rider with their bank. The driver's fare is frozen
+// while the chargeback is open.
+type Chargeback struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProviderDisputeId string                 `protobuf:"bytes,2,opt,name=provider_dispute_id,json=providerDisputeId,proto3" json:"provider_dispute_id,omitempty"`
+	PaymentId         string                 `protobuf:"bytes,3,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	TripId            string                 `protobuf:"bytes,4,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	UserId            string                 `protobuf:"bytes,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DriverId          string                 `protobuf:"bytes,6,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	Amount            float64                `protobuf:"fixed64,7,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency          string                 `protobuf:"bytes,8,opt,name=currency,proto3" json:"currency,omitempty"`
+	Reason            string                 `protobuf:"bytes,9,opt,name=reason,proto3" json:"reason,omitempty"`
+	Status            string                 `protobuf:"bytes,10,opt,name=status,proto3" json:"status,omitempty"` // needs_response, under_review, won, lost or accepted
+	EvidenceDueBy     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=evidence_due_by,json=evidenceDueBy,proto3" json:"evidence_due_by,omitempty"`
+	Notes             string                 `protobuf:"bytes,12,opt,name=notes,proto3" json:"notes,omitempty"`
+	ResolvedBy        string                 `protobuf:"bytes,13,opt,name=resolved_by,json=resolvedBy,proto3" json:"resolved_by,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ResolvedAt        *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=resolved_at,json=resolvedAt,proto3" json:"resolved_at,omitempty"`
+	Evidence          []*ChargebackEvidence  `protobuf:"bytes,16,rep,name=evidence,proto3" json:"evidence,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Chargeback) Reset() {
+	*x = Chargeback{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Chargeback) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Chargeback) ProtoMessage() {}
+
+func (x *Chargeback) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Chargeback.ProtoReflect.Descriptor instead.
+func (*Chargeback) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *Chargeback) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Chargeback) GetProviderDisputeId() string {
+	if x != nil {
+		return x.ProviderDisputeId
+	}
+	return ""
+}
+
+func (x *Chargeback) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *Chargeback) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *Chargeback) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Chargeback) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *Chargeback) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Chargeback) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Chargeback) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Chargeback) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Chargeback) GetEvidenceDueBy() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EvidenceDueBy
+	}
+	return nil
+}
+
+func (x *Chargeback) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *Chargeback) GetResolvedBy() string {
+	if x != nil {
+		return x.ResolvedBy
+	}
+	return ""
+}
+
+func (x *Chargeback) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Chargeback) GetResolvedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ResolvedAt
+	}
+	return nil
+}
+
+func (x *Chargeback) GetEvidence() []*ChargebackEvidence {
+	if x != nil {
+		return x.Evidence
+	}
+	return nil
+}
+
+// Lists chargebacks in status, all of them when status is empty, oldest first
+type ListChargebacksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string                 `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChargebacksRequest) Reset() {
+	*x = ListChargebacksRequest{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChargebacksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChargebacksRequest) ProtoMessage() {}
+
+func (x *ListChargebacksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChargebacksRequest.ProtoReflect.Descriptor instead.
+func (*ListChargebacksRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListChargebacksRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListChargebacksRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListChargebacksRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ListChargebacksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chargebacks   []*Chargeback          `protobuf:"bytes,1,rep,name=chargebacks,proto3" json:"chargebacks,omitempty"`
+	HasMore       bool                   `protobuf:"varint,2,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChargebacksResponse) Reset() {
+	*x = ListChargebacksResponse{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChargebacksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChargebacksResponse) ProtoMessage() {}
+
+func (x *ListChargebacksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChargebacksResponse.ProtoReflect.Descriptor instead.
+func (*ListChargebacksResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListChargebacksResponse) GetChargebacks() []*Chargeback {
+	if x != nil {
+		return x.Chargebacks
+	}
+	return nil
+}
+
+func (x *ListChargebacksResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+func (x *ListChargebacksResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type GetChargebackRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChargebackId  string                 `protobuf:"bytes,1,opt,name=chargeback_id,json=chargebackId,proto3" json:"chargeback_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetChargebackRequest) Reset() {
+	*x = GetChargebackRequest{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChargebackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChargebackRequest) ProtoMessage() {}
+
+func (x *GetChargebackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChargebackRequest.ProtoReflect.Descriptor instead.
+func (*GetChargebackRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetChargebackRequest) GetChargebackId() string {
+	if x != nil {
+		return x.ChargebackId
+	}
+	return ""
+}
+
+type SubmitChargebackEvidenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChargebackId  string                 `protobuf:"bytes,1,opt,name=chargeback_id,json=chargebackId,proto3" json:"chargeback_id,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	SubmittedBy   string                 `protobuf:"bytes,4,opt,name=submitted_by,json=submittedBy,proto3" json:"submitted_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitChargebackEvidenceRequest) Reset() {
+	*x = SubmitChargebackEvidenceRequest{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitChargebackEvidenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitChargebackEvidenceRequest) ProtoMessage() {}
+
+func (x *SubmitChargebackEvidenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitChargebackEvidenceRequest.ProtoReflect.Descriptor instead.
+func (*SubmitChargebackEvidenceRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SubmitChargebackEvidenceRequest) GetChargebackId() string {
+	if x != nil {
+		return x.ChargebackId
+	}
+	return ""
+}
+
+func (x *SubmitChargebackEvidenceRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SubmitChargebackEvidenceRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *SubmitChargebackEvidenceRequest) GetSubmittedBy() string {
+	if x != nil {
+		return x.SubmittedBy
+	}
+	return ""
+}
+
+// Decides an open chargeback; outcome is won, lost or accepted
+type RecordChargebackOutcomeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChargebackId  string                 `protobuf:"bytes,1,opt,name=chargeback_id,json=chargebackId,proto3" json:"chargeback_id,omitempty"`
+	Outcome       string                 `protobuf:"bytes,2,opt,name=outcome,proto3" json:"outcome,omitempty"`
+	Notes         string                 `protobuf:"bytes,3,opt,name=notes,proto3" json:"notes,omitempty"`
+	ResolvedBy    string                 `protobuf:"bytes,4,opt,name=resolved_by,json=resolvedBy,proto3" json:"resolved_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordChargebackOutcomeRequest) Reset() {
+	*x = RecordChargebackOutcomeRequest{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordChargebackOutcomeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordChargebackOutcomeRequest) ProtoMessage() {}
+
+func (x *RecordChargebackOutcomeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordChargebackOutcomeRequest.ProtoReflect.Descriptor instead.
+func (*RecordChargebackOutcomeRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *RecordChargebackOutcomeRequest) GetChargebackId() string {
+	if x != nil {
+		return x.ChargebackId
+	}
+	return ""
+}
+
+func (x *RecordChargebackOutcomeRequest) GetOutcome() string {
+	if x != nil {
+		return x.Outcome
+	}
+	return ""
+}
+
+func (x *RecordChargebackOutcomeRequest) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *RecordChargebackOutcomeRequest) GetResolvedBy() string {
+	if x != nil {
+		return x.ResolvedBy
+	}
+	return ""
+}
+
+type ChargebackResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chargeback    *Chargeback            `protobuf:"bytes,1,opt,name=chargeback,proto3" json:"chargeback,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChargebackResponse) Reset() {
+	*x = ChargebackResponse{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChargebackResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChargebackResponse) ProtoMessage() {}
+
+func (x *ChargebackResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChargebackResponse.ProtoReflect.Descriptor instead.
+func (*ChargebackResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ChargebackResponse) GetChargeback() *Chargeback {
+	if x != nil {
+		return x.Chargeback
+	}
+	return nil
+}
+
+// Records a transfer to a driver's bank account against what the platform
+// owes them. Transfers are keyed by reference, so recording one twice fails
+// with ALREADY_EXISTS, and payouts above what the driver is owed fail with
+// FAILED_PRECONDITION.
+type RecordDriverPayoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverId      string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	Reference     string                 `protobuf:"bytes,4,opt,name=reference,proto3" json:"reference,omitempty"` // the bank transfer's reference
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordDriverPayoutRequest) Reset() {
+	*x = RecordDriverPayoutRequest{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordDriverPayoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordDriverPayoutRequest) ProtoMessage() {}
+
+func (x *RecordDriverPayoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordDriverPayoutRequest.ProtoReflect.Descriptor instead.
+func (*RecordDriverPayoutRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *RecordDriverPayoutRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *RecordDriverPayoutRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *RecordDriverPayoutRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *RecordDriverPayoutRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+type RecordDriverPayoutResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	JournalEntryId string                 `protobuf:"bytes,1,opt,name=journal_entry_id,json=journalEntryId,proto3" json:"journal_entry_id,omitempty"`
+	DriverId       string                 `protobuf:"bytes,2,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	Amount         float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency       string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	Reference      string                 `protobuf:"bytes,5,opt,name=reference,proto3" json:"reference,omitempty"`
+	PostedAt       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=posted_at,json=postedAt,proto3" json:"posted_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RecordDriverPayoutResponse) Reset() {
+	*x = RecordDriverPayoutResponse{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordDriverPayoutResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordDriverPayoutResponse) ProtoMessage() {}
+
+func (x *RecordDriverPayoutResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordDriverPayoutResponse.ProtoReflect.Descriptor instead.
+func (*RecordDriverPayoutResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *RecordDriverPayoutResponse) GetJournalEntryId() string {
+	if x != nil {
+		return x.JournalEntryId
+	}
+	return ""
+}
+
+func (x *RecordDriverPayoutResponse) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *RecordDriverPayoutResponse) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *RecordDriverPayoutResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *RecordDriverPayoutResponse) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *RecordDriverPayoutResponse) GetPostedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PostedAt
+	}
+	return nil
+}
+
+// A trip event reported by trip-service for the refund policy. Driver
+// no-shows and trips cancelled by the platform raise refunds; other events
+// are ignored. Redelivered events do not refund a trip twice.
+type ReportTripEventRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	EventType     string                 `protobuf:"bytes,2,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"` // trip.driver_no_show or trip.cancelled
+	TripId        string                 `protobuf:"bytes,3,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	CancelledBy   string                 `protobuf:"bytes,4,opt,name=cancelled_by,json=cancelledBy,proto3" json:"cancelled_by,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	Source        string                 `protobuf:"bytes,6,opt,name=source,proto3" json:"source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportTripEventRequest) Reset() {
+	*x = ReportTripEventRequest{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportTripEventRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportTripEventRequest) ProtoMessage() {}
+
+func (x *ReportTripEventRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportTripEventRequest.ProtoReflect.Descriptor instead.
+func (*ReportTripEventRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ReportTripEventRequest) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *ReportTripEventRequest) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *ReportTripEventRequest) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *ReportTripEventRequest) GetCancelledBy() string {
+	if x != nil {
+		return x.CancelledBy
+	}
+	return ""
+}
+
+func (x *ReportTripEventRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ReportTripEventRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+type ReportTripEventResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportTripEventResponse) Reset() {
+	*x = ReportTripEventResponse{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportTripEventResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportTripEventResponse) ProtoMessage() {}
+
+func (x *ReportTripEventResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportTripEventResponse.ProtoReflect.Descriptor instead.
+func (*ReportTripEventResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{40}
+}
+
+type RefundCaseAuditEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Action        string                 `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
+	Actor         string                 `protobuf:"bytes,2,opt,name=actor,proto3" json:"actor,omitempty"`
+	Details       string                 `protobuf:"bytes,3,opt,name=details,proto3" json:"details,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundCaseAuditEntry) Reset() {
+	*x = RefundCaseAuditEntry{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundCaseAuditEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundCaseAuditEntry) ProtoMessage() {}
+
+func (x *RefundCaseAuditEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundCaseAuditEntry.ProtoReflect.Descriptor instead.
+func (*RefundCaseAuditEntry) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *RefundCaseAuditEntry) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *RefundCaseAuditEntry) GetActor() string {
+	if x != nil {
+		return x.Actor
+	}
+	return ""
+}
+
+func (x *RefundCaseAuditEntry) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+func (x *RefundCaseAuditEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// A refund the refund policy raised for one payment of a trip. Refunds above
+// the policy's auto-approval limit wait in pending_review for an operator.
+type RefundCase struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Id            string                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TripId        string                  `protobuf:"bytes,2,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	PaymentId     string                  `protobuf:"bytes,3,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	UserId        string                  `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason        string                  `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"` // driver_no_show, platform_cancellation or overcharge
+	Amount        float64                 `protobuf:"fixed64,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                  `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"`
+	Status        string                  `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"` // pending_review, refunded, rejected or failed
+	AutoApproved  bool                    `protobuf:"varint,9,opt,name=auto_approved,json=autoApproved,proto3" json:"auto_approved,omitempty"`
+	Source        string                  `protobuf:"bytes,10,opt,name=source,proto3" json:"source,omitempty"`
+	Details       string                  `protobuf:"bytes,11,opt,name=details,proto3" json:"details,omitempty"`
+	ReviewedBy    string                  `protobuf:"bytes,12,opt,name=reviewed_by,json=reviewedBy,proto3" json:"reviewed_by,omitempty"`
+	Notes         string                  `protobuf:"bytes,13,opt,name=notes,proto3" json:"notes,omitempty"`
+	FailureReason string                  `protobuf:"bytes,14,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	CreatedAt     *timestamppb.Timestamp  `protobuf:"bytes,15,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ResolvedAt    *timestamppb.Timestamp  `protobuf:"bytes,16,opt,name=resolved_at,json=resolvedAt,proto3" json:"resolved_at,omitempty"`
+	Audit         []*RefundCaseAuditEntry `protobuf:"bytes,17,rep,name=audit,proto3" json:"audit,omitempty"` // only set by GetRefundCase
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundCase) Reset() {
+	*x = RefundCase{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundCase) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundCase) ProtoMessage() {}
+
+func (x *RefundCase) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundCase.ProtoReflect.Descriptor instead.
+func (*RefundCase) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *RefundCase) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RefundCase) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *RefundCase) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *RefundCase) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RefundCase) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RefundCase) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *RefundCase) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *RefundCase) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *RefundCase) GetAutoApproved() bool {
+	if x != nil {
+		return x.AutoApproved
+	}
+	return false
+}
+
+func (x *RefundCase) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *RefundCase) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+func (x *RefundCase) GetReviewedBy() string {
+	if x != nil {
+		return x.ReviewedBy
+	}
+	return ""
+}
+
+func (x *RefundCase) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *RefundCase) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+func (x *RefundCase) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *RefundCase) GetResolvedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ResolvedAt
+	}
+	return nil
+}
+
+func (x *RefundCase) GetAudit() []*RefundCaseAuditEntry {
+	if x != nil {
+		return x.Audit
+	}
+	return nil
+}
+
+// Lists refund cases in status, pending_review when empty, oldest first
+type ListRefundCasesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRefundCasesRequest) Reset() {
+	*x = ListRefundCasesRequest{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRefundCasesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRefundCasesRequest) ProtoMessage() {}
+
+func (x *ListRefundCasesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRefundCasesRequest.ProtoReflect.Descriptor instead.
+func (*ListRefundCasesRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListRefundCasesRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListRefundCasesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListRefundCasesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cases         []*RefundCase          `protobuf:"bytes,1,rep,name=cases,proto3" json:"cases,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRefundCasesResponse) Reset() {
+	*x = ListRefundCasesResponse{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRefundCasesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRefundCasesResponse) ProtoMessage() {}
+
+func (x *ListRefundCasesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRefundCasesResponse.ProtoReflect.Descriptor instead.
+func (*ListRefundCasesResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ListRefundCasesResponse) GetCases() []*RefundCase {
+	if x != nil {
+		return x.Cases
+	}
+	return nil
+}
+
+type GetRefundCaseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CaseId        string                 `protobuf:"bytes,1,opt,name=case_id,json=caseId,proto3" json:"case_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRefundCaseRequest) Reset() {
+	*x = GetRefundCaseRequest{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRefundCaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRefundCaseRequest) ProtoMessage() {}
+
+func (x *GetRefundCaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRefundCaseRequest.ProtoReflect.Descriptor instead.
+func (*GetRefundCaseRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GetRefundCaseRequest) GetCaseId() string {
+	if x != nil {
+		return x.CaseId
+	}
+	return ""
+}
+
+// Approves or rejects a refund case waiting for review. Approved refunds are
+// paid at once. The reviewer is the operator the call's token was issued
+// to; cases already resolved fail with FAILED_PRECONDITION.
+type ResolveRefundCaseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CaseId        string                 `protobuf:"bytes,1,opt,name=case_id,json=caseId,proto3" json:"case_id,omitempty"`
+	Approve       bool                   `protobuf:"varint,2,opt,name=approve,proto3" json:"approve,omitempty"`
+	Notes         string                 `protobuf:"bytes,3,opt,name=notes,proto3" json:"notes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveRefundCaseRequest) Reset() {
+	*x = ResolveRefundCaseRequest{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveRefundCaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveRefundCaseRequest) ProtoMessage() {}
+
+func (x *ResolveRefundCaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveRefundCaseRequest.ProtoReflect.Descriptor instead.
+func (*ResolveRefundCaseRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ResolveRefundCaseRequest) GetCaseId() string {
+	if x != nil {
+		return x.CaseId
+	}
+	return ""
+}
+
+func (x *ResolveRefundCaseRequest) GetApprove() bool {
+	if x != nil {
+		return x.Approve
+	}
+	return false
+}
+
+func (x *ResolveRefundCaseRequest) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+type RefundCaseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Case          *RefundCase            `protobuf:"bytes,1,opt,name=case,proto3" json:"case,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundCaseResponse) Reset() {
+	*x = RefundCaseResponse{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundCaseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundCaseResponse) ProtoMessage() {}
+
+func (x *RefundCaseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundCaseResponse.ProtoReflect.Descriptor instead.
+func (*RefundCaseResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *RefundCaseResponse) GetCase() *RefundCase {
+	if x != nil {
+		return x.Case
+	}
+	return nil
+}
+
+// Records a tip or incentive paid to a driver; type is tip or incentive and
+// tips name their trip. Earnings are dated when they are recorded. Only
+// services record earnings, so calls made with rider or driver tokens fail
+// with PERMISSION_DENIED.
+type RecordDriverEarningRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverId      string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	TripId        string                 `protobuf:"bytes,2,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Amount        float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	Description   string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordDriverEarningRequest) Reset() {
+	*x = RecordDriverEarningRequest{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordDriverEarningRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordDriverEarningRequest) ProtoMessage() {}
+
+func (x *RecordDriverEarningRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordDriverEarningRequest.ProtoReflect.Descriptor instead.
+func (*RecordDriverEarningRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *RecordDriverEarningRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *RecordDriverEarningRequest) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *RecordDriverEarningRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *RecordDriverEarningRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *RecordDriverEarningRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *RecordDriverEarningRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type RecordDriverEarningResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EarningId     string                 `protobuf:"bytes,1,opt,name=earning_id,json=earningId,proto3" json:"earning_id,omitempty"`
+	DriverId      string                 `protobuf:"bytes,2,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	TripId        string                 `protobuf:"bytes,3,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	Type          string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	Amount        float64                `protobuf:"fixed64,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	EarnedAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=earned_at,json=earnedAt,proto3" json:"earned_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordDriverEarningResponse) Reset() {
+	*x = RecordDriverEarningResponse{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordDriverEarningResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordDriverEarningResponse) ProtoMessage() {}
+
+func (x *RecordDriverEarningResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordDriverEarningResponse.ProtoReflect.Descriptor instead.
+func (*RecordDriverEarningResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *RecordDriverEarningResponse) GetEarningId() string {
+	if x != nil {
+		return x.EarningId
+	}
+	return ""
+}
+
+func (x *RecordDriverEarningResponse) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *RecordDriverEarningResponse) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *RecordDriverEarningResponse) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *RecordDriverEarningResponse) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *RecordDriverEarningResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *RecordDriverEarningResponse) GetEarnedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EarnedAt
+	}
+	return nil
+}
+
+// A fraud rule in the rule DSL, e.g. `velocity_1h >= 5 && amount > 200`.
+// Matching payments score score and are flagged, queued for review or
+// blocked according to action.
+type FraudRule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Expression    string                 `protobuf:"bytes,4,opt,name=expression,proto3" json:"expression,omitempty"`
+	Score         float64                `protobuf:"fixed64,5,opt,name=score,proto3" json:"score,omitempty"`
+	Action        string                 `protobuf:"bytes,6,opt,name=action,proto3" json:"action,omitempty"` // flag, review or block
+	Priority      int32                  `protobuf:"varint,7,opt,name=priority,proto3" json:"priority,omitempty"`
+	Enabled       bool                   `protobuf:"varint,8,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FraudRule) Reset() {
+	*x = FraudRule{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FraudRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FraudRule) ProtoMessage() {}
+
+func (x *FraudRule) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FraudRule.ProtoReflect.Descriptor instead.
+func (*FraudRule) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *FraudRule) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FraudRule) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FraudRule) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *FraudRule) GetExpression() string {
+	if x != nil {
+		return x.Expression
+	}
+	return ""
+}
+
+func (x *FraudRule) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *FraudRule) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *FraudRule) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *FraudRule) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *FraudRule) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *FraudRule) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Lists the enabled fraud rules, highest priority first
+type ListFraudRulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFraudRulesRequest) Reset() {
+	*x = ListFraudRulesRequest{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFraudRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFraudRulesRequest) ProtoMessage() {}
+
+func (x *ListFraudRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFraudRulesRequest.ProtoReflect.Descriptor instead.
+func (*ListFraudRulesRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{51}
+}
+
+type ListFraudRulesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rules         []*FraudRule           `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFraudRulesResponse) Reset() {
+	*x = ListFraudRulesResponse{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFraudRulesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFraudRulesResponse) ProtoMessage() {}
+
+func (x *ListFraudRulesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFraudRulesResponse.ProtoReflect.Descriptor instead.
+func (*ListFraudRulesResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ListFraudRulesResponse) GetRules() []*FraudRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+// Creates a fraud rule, or replaces the one with the rule's ID. Rules that
+// do not compile fail with INVALID_ARGUMENT.
+type SaveFraudRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          *FraudRule             `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveFraudRuleRequest) Reset() {
+	*x = SaveFraudRuleRequest{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveFraudRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveFraudRuleRequest) ProtoMessage() {}
+
+func (x *SaveFraudRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveFraudRuleRequest.ProtoReflect.Descriptor instead.
+func (*SaveFraudRuleRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *SaveFraudRuleRequest) GetRule() *FraudRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+type FraudRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rule          *FraudRule             `protobuf:"bytes,1,opt,name=rule,proto3" json:"rule,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FraudRuleResponse) Reset() {
+	*x = FraudRuleResponse{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FraudRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FraudRuleResponse) ProtoMessage() {}
+
+func (x *FraudRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FraudRuleResponse.ProtoReflect.Descriptor instead.
+func (*FraudRuleResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *FraudRuleResponse) GetRule() *FraudRule {
+	if x != nil {
+		return x.Rule
+	}
+	return nil
+}
+
+type SetFraudRuleEnabledRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RuleId        string                 `protobuf:"bytes,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	Enabled       bool                   `protobuf:"varint,2,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetFraudRuleEnabledRequest) Reset() {
+	*x = SetFraudRuleEnabledRequest{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetFraudRuleEnabledRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFraudRuleEnabledRequest) ProtoMessage() {}
+
+func (x *SetFraudRuleEnabledRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFraudRuleEnabledRequest.ProtoReflect.Descriptor instead.
+func (*SetFraudRuleEnabledRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *SetFraudRuleEnabledRequest) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+func (x *SetFraudRuleEnabledRequest) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type SetFraudRuleEnabledResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetFraudRuleEnabledResponse) Reset() {
+	*x = SetFraudRuleEnabledResponse{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetFraudRuleEnabledResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetFraudRuleEnabledResponse) ProtoMessage() {}
+
+func (x *SetFraudRuleEnabledResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetFraudRuleEnabledResponse.ProtoReflect.Descriptor instead.
+func (*SetFraudRuleEnabledResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{56}
+}
+
+type DeleteFraudRuleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RuleId        string                 `protobuf:"bytes,1,opt,name=rule_id,json=ruleId,proto3" json:"rule_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFraudRuleRequest) Reset() {
+	*x = DeleteFraudRuleRequest{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFraudRuleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFraudRuleRequest) ProtoMessage() {}
+
+func (x *DeleteFraudRuleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFraudRuleRequest.ProtoReflect.Descriptor instead.
+func (*DeleteFraudRuleRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *DeleteFraudRuleRequest) GetRuleId() string {
+	if x != nil {
+		return x.RuleId
+	}
+	return ""
+}
+
+type DeleteFraudRuleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFraudRuleResponse) Reset() {
+	*x = DeleteFraudRuleResponse{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFraudRuleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFraudRuleResponse) ProtoMessage() {}
+
+func (x *DeleteFraudRuleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFraudRuleResponse.ProtoReflect.Descriptor instead.
+func (*DeleteFraudRuleResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{58}
+}
+
+// A payment the fraud engine flagged for manual review
+type FraudReview struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PaymentId     string                 `protobuf:"bytes,2,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Amount        float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	RiskLevel     string                 `protobuf:"bytes,6,opt,name=risk_level,json=riskLevel,proto3" json:"risk_level,omitempty"`
+	RiskScore     float64                `protobuf:"fixed64,7,opt,name=risk_score,json=riskScore,proto3" json:"risk_score,omitempty"`
+	Reasons       []string               `protobuf:"bytes,8,rep,name=reasons,proto3" json:"reasons,omitempty"`
+	MatchedRules  []string               `protobuf:"bytes,9,rep,name=matched_rules,json=matchedRules,proto3" json:"matched_rules,omitempty"`
+	Scores        map[string]float64     `protobuf:"bytes,10,rep,name=scores,proto3" json:"scores,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	Status        string                 `protobuf:"bytes,11,opt,name=status,proto3" json:"status,omitempty"` // pending, approved or rejected
+	ReviewedBy    string                 `protobuf:"bytes,12,opt,name=reviewed_by,json=reviewedBy,proto3" json:"reviewed_by,omitempty"`
+	Notes         string                 `protobuf:"bytes,13,opt,name=notes,proto3" json:"notes,omitempty"`
+	ReviewedAt    *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=reviewed_at,json=reviewedAt,proto3" json:"reviewed_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FraudReview) Reset() {
+	*x = FraudReview{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FraudReview) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FraudReview) ProtoMessage() {}
+
+func (x *FraudReview) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FraudReview.ProtoReflect.Descriptor instead.
+func (*FraudReview) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *FraudReview) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FraudReview) GetPaymentId() string {
+	if x != nil {
+		return x.PaymentId
+	}
+	return ""
+}
+
+func (x *FraudReview) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *FraudReview) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *FraudReview) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *FraudReview) GetRiskLevel() string {
+	if x != nil {
+		return x.RiskLevel
+	}
+	return ""
+}
+
+func (x *FraudReview) GetRiskScore() float64 {
+	if x != nil {
+		return x.RiskScore
+	}
+	return 0
+}
+
+func (x *FraudReview) GetReasons() []string {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+func (x *FraudReview) GetMatchedRules() []string {
+	if x != nil {
+		return x.MatchedRules
+	}
+	return nil
+}
+
+func (x *FraudReview) GetScores() map[string]float64 {
+	if x != nil {
+		return x.Scores
+	}
+	return nil
+}
+
+func (x *FraudReview) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *FraudReview) GetReviewedBy() string {
+	if x != nil {
+		return x.ReviewedBy
+	}
+	return ""
+}
+
+func (x *FraudReview) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *FraudReview) GetReviewedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReviewedAt
+	}
+	return nil
+}
+
+func (x *FraudReview) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Lists fraud reviews in status, pending when empty, riskiest first
+type ListFraudReviewsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFraudReviewsRequest) Reset() {
+	*x = ListFraudReviewsRequest{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFraudReviewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFraudReviewsRequest) ProtoMessage() {}
+
+func (x *ListFraudReviewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFraudReviewsRequest.ProtoReflect.Descriptor instead.
+func (*ListFraudReviewsRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ListFraudReviewsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListFraudReviewsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListFraudReviewsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reviews       []*FraudReview         `protobuf:"bytes,1,rep,name=reviews,proto3" json:"reviews,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFraudReviewsResponse) Reset() {
+	*x = ListFraudReviewsResponse{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFraudReviewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFraudReviewsResponse) ProtoMessage() {}
+
+func (x *ListFraudReviewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFraudReviewsResponse.ProtoReflect.Descriptor instead.
+func (*ListFraudReviewsResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ListFraudReviewsResponse) GetReviews() []*FraudReview {
+	if x != nil {
+		return x.Reviews
+	}
+	return nil
+}
+
+// Approves a flagged payment as legitimate or rejects it as fraudulent. The
+// reviewer is the operator the call's token was issued to; reviews already
+// decided fail with FAILED_PRECONDITION.
+type ResolveFraudReviewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReviewId      string                 `protobuf:"bytes,1,opt,name=review_id,json=reviewId,proto3" json:"review_id,omitempty"`
+	Approve       bool                   `protobuf:"varint,2,opt,name=approve,proto3" json:"approve,omitempty"`
+	Notes         string                 `protobuf:"bytes,3,opt,name=notes,proto3" json:"notes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveFraudReviewRequest) Reset() {
+	*x = ResolveFraudReviewRequest{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveFraudReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveFraudReviewRequest) ProtoMessage() {}
+
+func (x *ResolveFraudReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveFraudReviewRequest.ProtoReflect.Descriptor instead.
+func (*ResolveFraudReviewRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ResolveFraudReviewRequest) GetReviewId() string {
+	if x != nil {
+		return x.ReviewId
+	}
+	return ""
+}
+
+func (x *ResolveFraudReviewRequest) GetApprove() bool {
+	if x != nil {
+		return x.Approve
+	}
+	return false
+}
+
+func (x *ResolveFraudReviewRequest) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+type FraudReviewResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Review        *FraudReview           `protobuf:"bytes,1,opt,name=review,proto3" json:"review,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FraudReviewResponse) Reset() {
+	*x = FraudReviewResponse{}
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FraudReviewResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FraudReviewResponse) ProtoMessage() {}
+
+func (x *FraudReviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_payment_payment_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FraudReviewResponse.ProtoReflect.Descriptor instead.
+func (*FraudReviewResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_payment_payment_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *FraudReviewResponse) GetReview() *FraudReview {
+	if x != nil {
+		return x.Review
+	}
+	return nil
+}
+
+var File_shared_proto_payment_payment_proto protoreflect.FileDescriptor
+
+const file_shared_proto_payment_payment_proto_rawDesc = "" +
+	"\n" +
+	"\"shared/proto/payment/payment.proto\x12\apayment\x1a\x1fgoogle/protobuf/timestamp.proto\"\xbb\a\n" +
+	"\aPayment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\atrip_id\x18\x02 \x01(\tR\x06tripId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tdriver_id\x18\x04 \x01(\tR\bdriverId\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x12=\n" +
+	"\x0epayment_method\x18\a \x01(\x0e2\x16.payment.PaymentMethodR\rpaymentMethod\x12.\n" +
+	"\x06status\x18\b \x01(\x0e2\x16.payment.PaymentStatusR\x06status\x12C\n" +
+	"\x10transaction_type\x18\t \x01(\x0e2\x18.payment.TransactionTypeR\x0ftransactionType\x12-\n" +
+	"\x12processor_response\x18\n" +
+	" \x01(\tR\x11processorResponse\x126\n" +
+	"\n" +
+	"fraud_risk\x18\v \x01(\x0e2\x17.payment.FraudRiskLevelR\tfraudRisk\x12D\n" +
+	"\ffraud_scores\x18\f \x03(\v2!.payment.Payment.FraudScoresEntryR\vfraudScores\x12:\n" +
+	"\bmetadata\x18\r \x03(\v2\x1e.payment.Payment.MetadataEntryR\bmetadata\x12%\n" +
+	"\x0efailure_reason\x18\x0e \x01(\tR\rfailureReason\x12=\n" +
+	"\fprocessed_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\vprocessedAt\x129\n" +
+	"\n" +
+	"created_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12'\n" +
+	"\x0frefunded_amount\x18\x12 \x01(\x01R\x0erefundedAmount\x1a>\n" +
+	"\x10FraudScoresEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd1\x04\n" +
+	"\x14PaymentMethodDetails\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12*\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x16.payment.PaymentMethodR\x04type\x12\x1d\n" +
+	"\n" +
+	"is_default\x18\x04 \x01(\bR\tisDefault\x12 \n" +
+	"\vfingerprint\x18\x05 \x01(\tR\vfingerprint\x12;\n" +
+	"\vexpiry_date\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"expiryDate\x12(\n" +
+	"\x10last_four_digits\x18\a \x01(\tR\x0elastFourDigits\x12\x1b\n" +
+	"\tbank_name\x18\b \x01(\tR\bbankName\x12'\n" +
+	"\x0fwallet_provider\x18\t \x01(\tR\x0ewalletProvider\x12D\n" +
+	"\adetails\x18\n" +
+	" \x03(\v2*.payment.PaymentMethodDetails.DetailsEntryR\adetails\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x1a:\n" +
+	"\fDetailsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd5\x02\n" +
+	"\x14FraudDetectionResult\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\x126\n" +
+	"\n" +
+	"risk_level\x18\x02 \x01(\x0e2\x17.payment.FraudRiskLevelR\triskLevel\x12\x1d\n" +
+	"\n" +
+	"risk_score\x18\x03 \x01(\x01R\triskScore\x12\x18\n" +
+	"\areasons\x18\x04 \x03(\tR\areasons\x12A\n" +
+	"\x06scores\x18\x05 \x03(\v2).payment.FraudDetectionResult.ScoresEntryR\x06scores\x12'\n" +
+	"\x0frequires_review\x18\x06 \x01(\bR\x0erequiresReview\x1a9\n" +
+	"\vScoresEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"\xef\x02\n" +
+	"\x15ProcessPaymentRequest\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tdriver_id\x18\x03 \x01(\tR\bdriverId\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12*\n" +
+	"\x11payment_method_id\x18\x06 \x01(\tR\x0fpaymentMethodId\x12 \n" +
+	"\vdescription\x18\a \x01(\tR\vdescription\x12H\n" +
+	"\bmetadata\x18\b \x03(\v2,.payment.ProcessPaymentRequest.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x90\x01\n" +
+	"\x16ProcessPaymentResponse\x12*\n" +
+	"\apayment\x18\x01 \x01(\v2\x10.payment.PaymentR\apayment\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x16\n" +
+	"\x06errors\x18\x04 \x03(\tR\x06errors\"\x88\x01\n" +
+	"\x14ProcessRefundRequest\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12!\n" +
+	"\frequested_by\x18\x04 \x01(\tR\vrequestedBy\"\x80\x01\n" +
+	"\x15ProcessRefundResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1b\n" +
+	"\trefund_id\x18\x03 \x01(\tR\brefundId\x12\x16\n" +
+	"\x06errors\x18\x04 \x03(\tR\x06errors\"\x82\x02\n" +
+	"\x17AddPaymentMethodRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12*\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x16.payment.PaymentMethodR\x04type\x12G\n" +
+	"\adetails\x18\x03 \x03(\v2-.payment.AddPaymentMethodRequest.DetailsEntryR\adetails\x12\x1d\n" +
+	"\n" +
+	"is_default\x18\x04 \x01(\bR\tisDefault\x1a:\n" +
+	"\fDetailsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xac\x01\n" +
+	"\x18AddPaymentMethodResponse\x12D\n" +
+	"\x0epayment_method\x18\x01 \x01(\v2\x1d.payment.PaymentMethodDetailsR\rpaymentMethod\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x16\n" +
+	"\x06errors\x18\x04 \x03(\tR\x06errors\"2\n" +
+	"\x11GetPaymentRequest\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\tR\tpaymentId\"V\n" +
+	"\x12GetPaymentResponse\x12*\n" +
+	"\apayment\x18\x01 \x01(\v2\x10.payment.PaymentR\apayment\x12\x14\n" +
+	"\x05found\x18\x02 \x01(\bR\x05found\"7\n" +
+	"\x1cGetUserPaymentMethodsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"}\n" +
+	"\x1dGetUserPaymentMethodsResponse\x12F\n" +
+	"\x0fpayment_methods\x18\x01 \x03(\v2\x1d.payment.PaymentMethodDetailsR\x0epaymentMethods\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"\x81\x01\n" +
+	"\x16GetUserPaymentsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursor\x12\x12\n" +
+	"\x04sort\x18\x05 \x01(\tR\x04sortJ\x04\b\x03\x10\x04R\x06offset\"\xa4\x01\n" +
+	"\x17GetUserPaymentsResponse\x12,\n" +
+	"\bpayments\x18\x01 \x03(\v2\x10.payment.PaymentR\bpayments\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x05R\n" +
+	"totalCount\x12\x19\n" +
+	"\bhas_more\x18\x03 \x01(\bR\ahasMore\x12\x1f\n" +
+	"\vnext_cursor\x18\x04 \x01(\tR\n" +
+	"nextCursor\"1\n" +
+	"\x16GetTripPaymentsRequest\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\"]\n" +
+	"\x17GetTripPaymentsResponse\x12,\n" +
+	"\bpayments\x18\x01 \x03(\v2\x10.payment.PaymentR\bpayments\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"\xd5\x01\n" +
+	"\x13ListPaymentsRequest\x12?\n" +
+	"\rcreated_after\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x05 \x01(\tR\x06cursorJ\x04\b\x04\x10\x05R\x06offset\"\x80\x01\n" +
+	"\x14ListPaymentsResponse\x12,\n" +
+	"\bpayments\x18\x01 \x03(\v2\x10.payment.PaymentR\bpayments\x12\x19\n" +
+	"\bhas_more\x18\x02 \x01(\bR\ahasMore\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\"\x89\x01\n" +
+	"\x1bListPaymentsByStatusRequest\x12.\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x16.payment.PaymentStatusR\x06status\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursorJ\x04\b\x03\x10\x04R\x06offset\":\n" +
+	"\x1fRemoveUserPaymentMethodsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"G\n" +
+	" RemoveUserPaymentMethodsResponse\x12#\n" +
+	"\rremoved_count\x18\x01 \x01(\x05R\fremovedCount\"\xbb\x01\n" +
+	"\x18GrantWalletCreditRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x1c\n" +
+	"\treference\x18\x05 \x01(\tR\treference\x12 \n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\"\x8e\x01\n" +
+	"\x19GrantWalletCreditResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12%\n" +
+	"\x0etransaction_id\x18\x03 \x01(\tR\rtransactionId\x12\x16\n" +
+	"\x06errors\x18\x04 \x03(\tR\x06errors\"\x98\x04\n" +
+	"\vPaymentHold\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\atrip_id\x18\x02 \x01(\tR\x06tripId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12*\n" +
+	"\x11payment_method_id\x18\x04 \x01(\tR\x0fpaymentMethodId\x12%\n" +
+	"\x0eestimated_fare\x18\x05 \x01(\x01R\restimatedFare\x12\x16\n" +
+	"\x06amount\x18\x06 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\a \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12'\n" +
+	"\x0fcaptured_amount\x18\t \x01(\x01R\x0ecapturedAmount\x12'\n" +
+	"\x0freleased_amount\x18\n" +
+	" \x01(\x01R\x0ereleasedAmount\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\v \x01(\tR\tpaymentId\x12\x1a\n" +
+	"\brenewals\x18\f \x01(\x05R\brenewals\x12%\n" +
+	"\x0efailure_reason\x18\r \x01(\tR\rfailureReason\x129\n" +
+	"\n" +
+	"expires_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x129\n" +
+	"\n" +
+	"created_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xdb\x01\n" +
+	"\x1bAuthorizeTripPaymentRequest\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tdriver_id\x18\x03 \x01(\tR\bdriverId\x12*\n" +
+	"\x11payment_method_id\x18\x04 \x01(\tR\x0fpaymentMethodId\x12%\n" +
+	"\x0eestimated_fare\x18\x05 \x01(\x01R\restimatedFare\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\"\xca\x01\n" +
+	"\x19CaptureTripPaymentRequest\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tdriver_id\x18\x03 \x01(\tR\bdriverId\x12*\n" +
+	"\x11payment_method_id\x18\x04 \x01(\tR\x0fpaymentMethodId\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\"4\n" +
+	"\x19ReleaseTripPaymentRequest\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\"\xb0\x01\n" +
+	"\x17TripPaymentHoldResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12(\n" +
+	"\x04hold\x18\x03 \x01(\v2\x14.payment.PaymentHoldR\x04hold\x12\x1f\n" +
+	"\vpayment_ids\x18\x04 \x03(\tR\n" +
+	"paymentIds\x12\x16\n" +
+	"\x06errors\x18\x05 \x03(\tR\x06errors\"\xbc\x01\n" +
+	"\x12ChargebackEvidence\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12!\n" +
+	"\fsubmitted_by\x18\x04 \x01(\tR\vsubmittedBy\x12=\n" +
+	"\fsubmitted_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vsubmittedAt\"\xca\x04\n" +
+	"\n" +
+	"Chargeback\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12.\n" +
+	"\x13provider_dispute_id\x18\x02 \x01(\tR\x11providerDisputeId\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x03 \x01(\tR\tpaymentId\x12\x17\n" +
+	"\atrip_id\x18\x04 \x01(\tR\x06tripId\x12\x17\n" +
+	"\auser_id\x18\x05 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tdriver_id\x18\x06 \x01(\tR\bdriverId\x12\x16\n" +
+	"\x06amount\x18\a \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\b \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06reason\x18\t \x01(\tR\x06reason\x12\x16\n" +
+	"\x06status\x18\n" +
+	" \x01(\tR\x06status\x12B\n" +
+	"\x0fevidence_due_by\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\revidenceDueBy\x12\x14\n" +
+	"\x05notes\x18\f \x01(\tR\x05notes\x12\x1f\n" +
+	"\vresolved_by\x18\r \x01(\tR\n" +
+	"resolvedBy\x129\n" +
+	"\n" +
+	"created_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12;\n" +
+	"\vresolved_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"resolvedAt\x127\n" +
+	"\bevidence\x18\x10 \x03(\v2\x1b.payment.ChargebackEvidenceR\bevidence\"l\n" +
+	"\x16ListChargebacksRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x04 \x01(\tR\x06cursorJ\x04\b\x03\x10\x04R\x06offset\"\x8c\x01\n" +
+	"\x17ListChargebacksResponse\x125\n" +
+	"\vchargebacks\x18\x01 \x03(\v2\x13.payment.ChargebackR\vchargebacks\x12\x19\n" +
+	"\bhas_more\x18\x02 \x01(\bR\ahasMore\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\";\n" +
+	"\x14GetChargebackRequest\x12#\n" +
+	"\rchargeback_id\x18\x01 \x01(\tR\fchargebackId\"\x9f\x01\n" +
+	"\x1fSubmitChargebackEvidenceRequest\x12#\n" +
+	"\rchargeback_id\x18\x01 \x01(\tR\fchargebackId\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12!\n" +
+	"\fsubmitted_by\x18\x04 \x01(\tR\vsubmittedBy\"\x96\x01\n" +
+	"\x1eRecordChargebackOutcomeRequest\x12#\n" +
+	"\rchargeback_id\x18\x01 \x01(\tR\fchargebackId\x12\x18\n" +
+	"\aoutcome\x18\x02 \x01(\tR\aoutcome\x12\x14\n" +
+	"\x05notes\x18\x03 \x01(\tR\x05notes\x12\x1f\n" +
+	"\vresolved_by\x18\x04 \x01(\tR\n" +
+	"resolvedBy\"I\n" +
+	"\x12ChargebackResponse\x123\n" +
+	"\n" +
+	"chargeback\x18\x01 \x01(\v2\x13.payment.ChargebackR\n" +
+	"chargeback\"\x8a\x01\n" +
+	"\x19RecordDriverPayoutRequest\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x03 \x01(\tR\bcurrency\x12\x1c\n" +
+	"\treference\x18\x04 \x01(\tR\treference\"\xee\x01\n" +
+	"\x1aRecordDriverPayoutResponse\x12(\n" +
+	"\x10journal_entry_id\x18\x01 \x01(\tR\x0ejournalEntryId\x12\x1b\n" +
+	"\tdriver_id\x18\x02 \x01(\tR\bdriverId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12\x1c\n" +
+	"\treference\x18\x05 \x01(\tR\treference\x127\n" +
+	"\tposted_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\bpostedAt\"\xbe\x01\n" +
+	"\x16ReportTripEventRequest\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x02 \x01(\tR\teventType\x12\x17\n" +
+	"\atrip_id\x18\x03 \x01(\tR\x06tripId\x12!\n" +
+	"\fcancelled_by\x18\x04 \x01(\tR\vcancelledBy\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12\x16\n" +
+	"\x06source\x18\x06 \x01(\tR\x06source\"\x19\n" +
+	"\x17ReportTripEventResponse\"\x99\x01\n" +
+	"\x14RefundCaseAuditEntry\x12\x16\n" +
+	"\x06action\x18\x01 \x01(\tR\x06action\x12\x14\n" +
+	"\x05actor\x18\x02 \x01(\tR\x05actor\x12\x18\n" +
+	"\adetails\x18\x03 \x01(\tR\adetails\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xb3\x04\n" +
+	"\n" +
+	"RefundCase\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\atrip_id\x18\x02 \x01(\tR\x06tripId\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x03 \x01(\tR\tpaymentId\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12\x16\n" +
+	"\x06amount\x18\x06 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\a \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12#\n" +
+	"\rauto_approved\x18\t \x01(\bR\fautoApproved\x12\x16\n" +
+	"\x06source\x18\n" +
+	" \x01(\tR\x06source\x12\x18\n" +
+	"\adetails\x18\v \x01(\tR\adetails\x12\x1f\n" +
+	"\vreviewed_by\x18\f \x01(\tR\n" +
+	"reviewedBy\x12\x14\n" +
+	"\x05notes\x18\r \x01(\tR\x05notes\x12%\n" +
+	"\x0efailure_reason\x18\x0e \x01(\tR\rfailureReason\x129\n" +
+	"\n" +
+	"created_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12;\n" +
+	"\vresolved_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"resolvedAt\x123\n" +
+	"\x05audit\x18\x11 \x03(\v2\x1d.payment.RefundCaseAuditEntryR\x05audit\"F\n" +
+	"\x16ListRefundCasesRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"D\n" +
+	"\x17ListRefundCasesResponse\x12)\n" +
+	"\x05cases\x18\x01 \x03(\v2\x13.payment.RefundCaseR\x05cases\"/\n" +
+	"\x14GetRefundCaseRequest\x12\x17\n" +
+	"\acase_id\x18\x01 \x01(\tR\x06caseId\"c\n" +
+	"\x18ResolveRefundCaseRequest\x12\x17\n" +
+	"\acase_id\x18\x01 \x01(\tR\x06caseId\x12\x18\n" +
+	"\aapprove\x18\x02 \x01(\bR\aapprove\x12\x14\n" +
+	"\x05notes\x18\x03 \x01(\tR\x05notes\"=\n" +
+	"\x12RefundCaseResponse\x12'\n" +
+	"\x04case\x18\x01 \x01(\v2\x13.payment.RefundCaseR\x04case\"\xbc\x01\n" +
+	"\x1aRecordDriverEarningRequest\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12\x17\n" +
+	"\atrip_id\x18\x02 \x01(\tR\x06tripId\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12 \n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\"\xf3\x01\n" +
+	"\x1bRecordDriverEarningResponse\x12\x1d\n" +
+	"\n" +
+	"earning_id\x18\x01 \x01(\tR\tearningId\x12\x1b\n" +
+	"\tdriver_id\x18\x02 \x01(\tR\bdriverId\x12\x17\n" +
+	"\atrip_id\x18\x03 \x01(\tR\x06tripId\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x127\n" +
+	"\tearned_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\bearnedAt\"\xcb\x02\n" +
+	"\tFraudRule\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x1e\n" +
+	"\n" +
+	"expression\x18\x04 \x01(\tR\n" +
+	"expression\x12\x14\n" +
+	"\x05score\x18\x05 \x01(\x01R\x05score\x12\x16\n" +
+	"\x06action\x18\x06 \x01(\tR\x06action\x12\x1a\n" +
+	"\bpriority\x18\a \x01(\x05R\bpriority\x12\x18\n" +
+	"\aenabled\x18\b \x01(\bR\aenabled\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x17\n" +
+	"\x15ListFraudRulesRequest\"B\n" +
+	"\x16ListFraudRulesResponse\x12(\n" +
+	"\x05rules\x18\x01 \x03(\v2\x12.payment.FraudRuleR\x05rules\">\n" +
+	"\x14SaveFraudRuleRequest\x12&\n" +
+	"\x04rule\x18\x01 \x01(\v2\x12.payment.FraudRuleR\x04rule\";\n" +
+	"\x11FraudRuleResponse\x12&\n" +
+	"\x04rule\x18\x01 \x01(\v2\x12.payment.FraudRuleR\x04rule\"O\n" +
+	"\x1aSetFraudRuleEnabledRequest\x12\x17\n" +
+	"\arule_id\x18\x01 \x01(\tR\x06ruleId\x12\x18\n" +
+	"\aenabled\x18\x02 \x01(\bR\aenabled\"\x1d\n" +
+	"\x1bSetFraudRuleEnabledResponse\"1\n" +
+	"\x16DeleteFraudRuleRequest\x12\x17\n" +
+	"\arule_id\x18\x01 \x01(\tR\x06ruleId\"\x19\n" +
+	"\x17DeleteFraudRuleResponse\"\xc2\x04\n" +
+	"\vFraudReview\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x02 \x01(\tR\tpaymentId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12\x1d\n" +
+	"\n" +
+	"risk_level\x18\x06 \x01(\tR\triskLevel\x12\x1d\n" +
+	"\n" +
+	"risk_score\x18\a \x01(\x01R\triskScore\x12\x18\n" +
+	"\areasons\x18\b \x03(\tR\areasons\x12#\n" +
+	"\rmatched_rules\x18\t \x03(\tR\fmatchedRules\x128\n" +
+	"\x06scores\x18\n" +
+	" \x03(\v2 .payment.FraudReview.ScoresEntryR\x06scores\x12\x16\n" +
+	"\x06status\x18\v \x01(\tR\x06status\x12\x1f\n" +
+	"\vreviewed_by\x18\f \x01(\tR\n" +
+	"reviewedBy\x12\x14\n" +
+	"\x05notes\x18\r \x01(\tR\x05notes\x12;\n" +
+	"\vreviewed_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"reviewedAt\x129\n" +
+	"\n" +
+	"created_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x1a9\n" +
+	"\vScoresEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"G\n" +
+	"\x17ListFraudReviewsRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"J\n" +
+	"\x18ListFraudReviewsResponse\x12.\n" +
+	"\areviews\x18\x01 \x03(\v2\x14.payment.FraudReviewR\areviews\"h\n" +
+	"\x19ResolveFraudReviewRequest\x12\x1b\n" +
+	"\treview_id\x18\x01 \x01(\tR\breviewId\x12\x18\n" +
+	"\aapprove\x18\x02 \x01(\bR\aapprove\x12\x14\n" +
+	"\x05notes\x18\x03 \x01(\tR\x05notes\"C\n" +
+	"\x13FraudReviewResponse\x12,\n" +
+	"\x06review\x18\x01 \x01(\v2\x14.payment.FraudReviewR\x06review*\x91\x01\n" +
 	"\rPaymentMethod\x12\x1a\n" +
 	"\x16UNKNOWN_PAYMENT_METHOD\x10\x00\x12\x0f\n" +
 	"\vCREDIT_CARD\x10\x01\x12\x0e\n" +
@@ -1638,7 +5191,8 @@ const file_shared_proto_payment_payment_proto_rawDesc = "" +
 	"DEBIT_CARD\x10\x02\x12\x12\n" +
 	"\x0eDIGITAL_WALLET\x10\x03\x12\x11\n" +
 	"\rBANK_TRANSFER\x10\x04\x12\b\n" +
-	"\x04CASH\x10\x05*\x90\x01\n" +
+	"\x04CASH\x10\x05\x12\x12\n" +
+	"\x0eWALLET_BALANCE\x10\x06*\x90\x01\n" +
 	"\rPaymentStatus\x12\x1a\n" +
 	"\x16UNKNOWN_PAYMENT_STATUS\x10\x00\x12\v\n" +
 	"\aPENDING\x10\x01\x12\x0e\n" +
@@ -1664,7 +5218,7 @@ const file_shared_proto_payment_payment_proto_rawDesc = "" +
 	"\x03LOW\x10\x01\x12\n" +
 	"\n" +
 	"\x06MEDIUM\x10\x02\x12\b\n" +
-	"\x04HIGH\x10\x032\xe7\x04\n" +
+	"\x04HIGH\x10\x032\xf4\x14\n" +
 	"\x0ePaymentService\x12Q\n" +
 	"\x0eProcessPayment\x12\x1e.payment.ProcessPaymentRequest\x1a\x1f.payment.ProcessPaymentResponse\x12N\n" +
 	"\rProcessRefund\x12\x1d.payment.ProcessRefundRequest\x1a\x1e.payment.ProcessRefundResponse\x12W\n" +
@@ -1673,7 +5227,30 @@ const file_shared_proto_payment_payment_proto_rawDesc = "" +
 	"GetPayment\x12\x1a.payment.GetPaymentRequest\x1a\x1b.payment.GetPaymentResponse\x12f\n" +
 	"\x15GetUserPaymentMethods\x12%.payment.GetUserPaymentMethodsRequest\x1a&.payment.GetUserPaymentMethodsResponse\x12T\n" +
 	"\x0fGetUserPayments\x12\x1f.payment.GetUserPaymentsRequest\x1a .payment.GetUserPaymentsResponse\x12T\n" +
-	"\x0fGetTripPayments\x12\x1f.payment.GetTripPaymentsRequest\x1a .payment.GetTripPaymentsResponseB4Z2github.com/rideshare-platform/shared/proto/paymentb\x06proto3"
+	"\x0fGetTripPayments\x12\x1f.payment.GetTripPaymentsRequest\x1a .payment.GetTripPaymentsResponse\x12K\n" +
+	"\fListPayments\x12\x1c.payment.ListPaymentsRequest\x1a\x1d.payment.ListPaymentsResponse\x12[\n" +
+	"\x14ListPaymentsByStatus\x12$.payment.ListPaymentsByStatusRequest\x1a\x1d.payment.ListPaymentsResponse\x12o\n" +
+	"\x18RemoveUserPaymentMethods\x12(.payment.RemoveUserPaymentMethodsRequest\x1a).payment.RemoveUserPaymentMethodsResponse\x12Z\n" +
+	"\x11GrantWalletCredit\x12!.payment.GrantWalletCreditRequest\x1a\".payment.GrantWalletCreditResponse\x12^\n" +
+	"\x14AuthorizeTripPayment\x12$.payment.AuthorizeTripPaymentRequest\x1a .payment.TripPaymentHoldResponse\x12Z\n" +
+	"\x12CaptureTripPayment\x12\".payment.CaptureTripPaymentRequest\x1a .payment.TripPaymentHoldResponse\x12Z\n" +
+	"\x12ReleaseTripPayment\x12\".payment.ReleaseTripPaymentRequest\x1a .payment.TripPaymentHoldResponse\x12T\n" +
+	"\x0fListChargebacks\x12\x1f.payment.ListChargebacksRequest\x1a .payment.ListChargebacksResponse\x12K\n" +
+	"\rGetChargeback\x12\x1d.payment.GetChargebackRequest\x1a\x1b.payment.ChargebackResponse\x12a\n" +
+	"\x18SubmitChargebackEvidence\x12(.payment.SubmitChargebackEvidenceRequest\x1a\x1b.payment.ChargebackResponse\x12_\n" +
+	"\x17RecordChargebackOutcome\x12'.payment.RecordChargebackOutcomeRequest\x1a\x1b.payment.ChargebackResponse\x12]\n" +
+	"\x12RecordDriverPayout\x12\".payment.RecordDriverPayoutRequest\x1a#.payment.RecordDriverPayoutResponse\x12`\n" +
+	"\x13RecordDriverEarning\x12#.payment.RecordDriverEarningRequest\x1a$.payment.RecordDriverEarningResponse\x12T\n" +
+	"\x0fReportTripEvent\x12\x1f.payment.ReportTripEventRequest\x1a .payment.ReportTripEventResponse\x12T\n" +
+	"\x0fListRefundCases\x12\x1f.payment.ListRefundCasesRequest\x1a .payment.ListRefundCasesResponse\x12K\n" +
+	"\rGetRefundCase\x12\x1d.payment.GetRefundCaseRequest\x1a\x1b.payment.RefundCaseResponse\x12S\n" +
+	"\x11ResolveRefundCase\x12!.payment.ResolveRefundCaseRequest\x1a\x1b.payment.RefundCaseResponse\x12Q\n" +
+	"\x0eListFraudRules\x12\x1e.payment.ListFraudRulesRequest\x1a\x1f.payment.ListFraudRulesResponse\x12J\n" +
+	"\rSaveFraudRule\x12\x1d.payment.SaveFraudRuleRequest\x1a\x1a.payment.FraudRuleResponse\x12`\n" +
+	"\x13SetFraudRuleEnabled\x12#.payment.SetFraudRuleEnabledRequest\x1a$.payment.SetFraudRuleEnabledResponse\x12T\n" +
+	"\x0fDeleteFraudRule\x12\x1f.payment.DeleteFraudRuleRequest\x1a .payment.DeleteFraudRuleResponse\x12W\n" +
+	"\x10ListFraudReviews\x12 .payment.ListFraudReviewsRequest\x1a!.payment.ListFraudReviewsResponse\x12V\n" +
+	"\x12ResolveFraudReview\x12\".payment.ResolveFraudReviewRequest\x1a\x1c.payment.FraudReviewResponseB4Z2github.com/rideshare-platform/shared/proto/paymentb\x06proto3"
 
 var (
 	file_shared_proto_payment_payment_proto_rawDescOnce sync.Once
@@ -1688,82 +5265,208 @@ func file_shared_proto_payment_payment_proto_rawDescGZIP() []byte {
 }
 
 var file_shared_proto_payment_payment_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_shared_proto_payment_payment_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_shared_proto_payment_payment_proto_msgTypes = make([]protoimpl.MessageInfo, 71)
 var file_shared_proto_payment_payment_proto_goTypes = []any{
-	(PaymentMethod)(0),                    // 0: payment.PaymentMethod
-	(PaymentStatus)(0),                    // 1: payment.PaymentStatus
-	(TransactionType)(0),                  // 2: payment.TransactionType
-	(FraudRiskLevel)(0),                   // 3: payment.FraudRiskLevel
-	(*Payment)(nil),                       // 4: payment.Payment
-	(*PaymentMethodDetails)(nil),          // 5: payment.PaymentMethodDetails
-	(*FraudDetectionResult)(nil),          // 6: payment.FraudDetectionResult
-	(*ProcessPaymentRequest)(nil),         // 7: payment.ProcessPaymentRequest
-	(*ProcessPaymentResponse)(nil),        // 8: payment.ProcessPaymentResponse
-	(*ProcessRefundRequest)(nil),          // 9: payment.ProcessRefundRequest
-	(*ProcessRefundResponse)(nil),         // 10: payment.ProcessRefundResponse
-	(*AddPaymentMethodRequest)(nil),       // 11: payment.AddPaymentMethodRequest
-	(*AddPaymentMethodResponse)(nil),      // 12: payment.AddPaymentMethodResponse
-	(*GetPaymentRequest)(nil),             // 13: payment.GetPaymentRequest
-	(*GetPaymentResponse)(nil),            // 14: payment.GetPaymentResponse
-	(*GetUserPaymentMethodsRequest)(nil),  // 15: payment.GetUserPaymentMethodsRequest
-	(*GetUserPaymentMethodsResponse)(nil), // 16: payment.GetUserPaymentMethodsResponse
-	(*GetUserPaymentsRequest)(nil),        // 17: payment.GetUserPaymentsRequest
-	(*GetUserPaymentsResponse)(nil),       // 18: payment.GetUserPaymentsResponse
-	(*GetTripPaymentsRequest)(nil),        // 19: payment.GetTripPaymentsRequest
-	(*GetTripPaymentsResponse)(nil),       // 20: payment.GetTripPaymentsResponse
-	nil,                                   // 21: payment.Payment.FraudScoresEntry
-	nil,                                   // 22: payment.Payment.MetadataEntry
-	nil,                                   // 23: payment.PaymentMethodDetails.DetailsEntry
-	nil,                                   // 24: payment.FraudDetectionResult.ScoresEntry
-	nil,                                   // 25: payment.ProcessPaymentRequest.MetadataEntry
-	nil,                                   // 26: payment.AddPaymentMethodRequest.DetailsEntry
-	(*timestamppb.Timestamp)(nil),         // 27: google.protobuf.Timestamp
+	(PaymentMethod)(0),                       // 0: payment.PaymentMethod
+	(PaymentStatus)(0),                       // 1: payment.PaymentStatus
+	(TransactionType)(0),                     // 2: payment.TransactionType
+	(FraudRiskLevel)(0),                      // 3: payment.FraudRiskLevel
+	(*Payment)(nil),                          // 4: payment.Payment
+	(*PaymentMethodDetails)(nil),             // 5: payment.PaymentMethodDetails
+	(*FraudDetectionResult)(nil),             // 6: payment.FraudDetectionResult
+	(*ProcessPaymentRequest)(nil),            // 7: payment.ProcessPaymentRequest
+	(*ProcessPaymentResponse)(nil),           // 8: payment.ProcessPaymentResponse
+	(*ProcessRefundRequest)(nil),             // 9: payment.ProcessRefundRequest
+	(*ProcessRefundResponse)(nil),            // 10: payment.ProcessRefundResponse
+	(*AddPaymentMethodRequest)(nil),          // 11: payment.AddPaymentMethodRequest
+	(*AddPaymentMethodResponse)(nil),         // 12: payment.AddPaymentMethodResponse
+	(*GetPaymentRequest)(nil),                // 13: payment.GetPaymentRequest
+	(*GetPaymentResponse)(nil),               // 14: payment.GetPaymentResponse
+	(*GetUserPaymentMethodsRequest)(nil),     // 15: payment.GetUserPaymentMethodsRequest
+	(*GetUserPaymentMethodsResponse)(nil),    // 16: payment.GetUserPaymentMethodsResponse
+	(*GetUserPaymentsRequest)(nil),           // 17: payment.GetUserPaymentsRequest
+	(*GetUserPaymentsResponse)(nil),          // 18: payment.GetUserPaymentsResponse
+	(*GetTripPaymentsRequest)(nil),           // 19: payment.GetTripPaymentsRequest
+	(*GetTripPaymentsResponse)(nil),          // 20: payment.GetTripPaymentsResponse
+	(*ListPaymentsRequest)(nil),              // 21: payment.ListPaymentsRequest
+	(*ListPaymentsResponse)(nil),             // 22: payment.ListPaymentsResponse
+	(*ListPaymentsByStatusRequest)(nil),      // 23: payment.ListPaymentsByStatusRequest
+	(*RemoveUserPaymentMethodsRequest)(nil),  // 24: payment.RemoveUserPaymentMethodsRequest
+	(*RemoveUserPaymentMethodsResponse)(nil), // 25: payment.RemoveUserPaymentMethodsResponse
+	(*GrantWalletCreditRequest)(nil),         // 26: payment.GrantWalletCreditRequest
+	(*GrantWalletCreditResponse)(nil),        // 27: payment.GrantWalletCreditResponse
+	(*PaymentHold)(nil),                      // 28: payment.PaymentHold
+	(*AuthorizeTripPaymentRequest)(nil),      // 29: payment.AuthorizeTripPaymentRequest
+	(*CaptureTripPaymentRequest)(nil),        // 30: payment.CaptureTripPaymentRequest
+	(*ReleaseTripPaymentRequest)(nil),        // 31: payment.ReleaseTripPaymentRequest
+	(*TripPaymentHoldResponse)(nil),          // 32: payment.TripPaymentHoldResponse
+	(*ChargebackEvidence)(nil),               // 33: payment.ChargebackEvidence
+	(*Chargeback)(nil),                       // 34: payment.Chargeback
+	(*ListChargebacksRequest)(nil),           // 35: payment.ListChargebacksRequest
+	(*ListChargebacksResponse)(nil),          // 36: payment.ListChargebacksResponse
+	(*GetChargebackRequest)(nil),             // 37: payment.GetChargebackRequest
+	(*SubmitChargebackEvidenceRequest)(nil),  // 38: payment.SubmitChargebackEvidenceRequest
+	(*RecordChargebackOutcomeRequest)(nil),   // 39: payment.RecordChargebackOutcomeRequest
+	(*ChargebackResponse)(nil),               // 40: payment.ChargebackResponse
+	(*RecordDriverPayoutRequest)(nil),        // 41: payment.RecordDriverPayoutRequest
+	(*RecordDriverPayoutResponse)(nil),       // 42: payment.RecordDriverPayoutResponse
+	(*ReportTripEventRequest)(nil),           // 43: payment.ReportTripEventRequest
+	(*ReportTripEventResponse)(nil),          // 44: payment.ReportTripEventResponse
+	(*RefundCaseAuditEntry)(nil),             // 45: payment.RefundCaseAuditEntry
+	(*RefundCase)(nil),                       // 46: payment.RefundCase
+	(*ListRefundCasesRequest)(nil),           // 47: payment.ListRefundCasesRequest
+	(*ListRefundCasesResponse)(nil),          // 48: payment.ListRefundCasesResponse
+	(*GetRefundCaseRequest)(nil),             // 49: payment.GetRefundCaseRequest
+	(*ResolveRefundCaseRequest)(nil),         // 50: payment.ResolveRefundCaseRequest
+	(*RefundCaseResponse)(nil),               // 51: payment.RefundCaseResponse
+	(*RecordDriverEarningRequest)(nil),       // 52: payment.RecordDriverEarningRequest
+	(*RecordDriverEarningResponse)(nil),      // 53: payment.RecordDriverEarningResponse
+	(*FraudRule)(nil),                        // 54: payment.FraudRule
+	(*ListFraudRulesRequest)(nil),            // 55: payment.ListFraudRulesRequest
+	(*ListFraudRulesResponse)(nil),           // 56: payment.ListFraudRulesResponse
+	(*SaveFraudRuleRequest)(nil),             // 57: payment.SaveFraudRuleRequest
+	(*FraudRuleResponse)(nil),                // 58: payment.FraudRuleResponse
+	(*SetFraudRuleEnabledRequest)(nil),       // 59: payment.SetFraudRuleEnabledRequest
+	(*SetFraudRuleEnabledResponse)(nil),      // 60: payment.SetFraudRuleEnabledResponse
+	(*DeleteFraudRuleRequest)(nil),           // 61: payment.DeleteFraudRuleRequest
+	(*DeleteFraudRuleResponse)(nil),          // 62: payment.DeleteFraudRuleResponse
+	(*FraudReview)(nil),                      // 63: payment.FraudReview
+	(*ListFraudReviewsRequest)(nil),          // 64: payment.ListFraudReviewsRequest
+	(*ListFraudReviewsResponse)(nil),         // 65: payment.ListFraudReviewsResponse
+	(*ResolveFraudReviewRequest)(nil),        // 66: payment.ResolveFraudReviewRequest
+	(*FraudReviewResponse)(nil),              // 67: payment.FraudReviewResponse
+	nil,                                      // 68: payment.Payment.FraudScoresEntry
+	nil,                                      // 69: payment.Payment.MetadataEntry
+	nil,                                      // 70: payment.PaymentMethodDetails.DetailsEntry
+	nil,                                      // 71: payment.FraudDetectionResult.ScoresEntry
+	nil,                                      // 72: payment.ProcessPaymentRequest.MetadataEntry
+	nil,                                      // 73: payment.AddPaymentMethodRequest.DetailsEntry
+	nil,                                      // 74: payment.FraudReview.ScoresEntry
+	(*timestamppb.Timestamp)(nil),            // 75: google.protobuf.Timestamp
 }
 var file_shared_proto_payment_payment_proto_depIdxs = []int32{
 	0,  // 0: payment.Payment.payment_method:type_name -> payment.PaymentMethod
 	1,  // 1: payment.Payment.status:type_name -> payment.PaymentStatus
 	2,  // 2: payment.Payment.transaction_type:type_name -> payment.TransactionType
 	3,  // 3: payment.Payment.fraud_risk:type_name -> payment.FraudRiskLevel
-	21, // 4: payment.Payment.fraud_scores:type_name -> payment.Payment.FraudScoresEntry
-	22, // 5: payment.Payment.metadata:type_name -> payment.Payment.MetadataEntry
-	27, // 6: payment.Payment.processed_at:type_name -> google.protobuf.Timestamp
-	27, // 7: payment.Payment.created_at:type_name -> google.protobuf.Timestamp
-	27, // 8: payment.Payment.updated_at:type_name -> google.protobuf.Timestamp
+	68, // 4: payment.Payment.fraud_scores:type_name -> payment.Payment.FraudScoresEntry
+	69, // 5: payment.Payment.metadata:type_name -> payment.Payment.MetadataEntry
+	75, // 6: payment.Payment.processed_at:type_name -> google.protobuf.Timestamp
+	75, // 7: payment.Payment.created_at:type_name -> google.protobuf.Timestamp
+	75, // 8: payment.Payment.updated_at:type_name -> google.protobuf.Timestamp
 	0,  // 9: payment.PaymentMethodDetails.type:type_name -> payment.PaymentMethod
-	27, // 10: payment.PaymentMethodDetails.expiry_date:type_name -> google.protobuf.Timestamp
-	23, // 11: payment.PaymentMethodDetails.details:type_name -> payment.PaymentMethodDetails.DetailsEntry
-	27, // 12: payment.PaymentMethodDetails.created_at:type_name -> google.protobuf.Timestamp
-	27, // 13: payment.PaymentMethodDetails.updated_at:type_name -> google.protobuf.Timestamp
+	75, // 10: payment.PaymentMethodDetails.expiry_date:type_name -> google.protobuf.Timestamp
+	70, // 11: payment.PaymentMethodDetails.details:type_name -> payment.PaymentMethodDetails.DetailsEntry
+	75, // 12: payment.PaymentMethodDetails.created_at:type_name -> google.protobuf.Timestamp
+	75, // 13: payment.PaymentMethodDetails.updated_at:type_name -> google.protobuf.Timestamp
 	3,  // 14: payment.FraudDetectionResult.risk_level:type_name -> payment.FraudRiskLevel
-	24, // 15: payment.FraudDetectionResult.scores:type_name -> payment.FraudDetectionResult.ScoresEntry
-	25, // 16: payment.ProcessPaymentRequest.metadata:type_name -> payment.ProcessPaymentRequest.MetadataEntry
+	71, // 15: payment.FraudDetectionResult.scores:type_name -> payment.FraudDetectionResult.ScoresEntry
+	72, // 16: payment.ProcessPaymentRequest.metadata:type_name -> payment.ProcessPaymentRequest.MetadataEntry
 	4,  // 17: payment.ProcessPaymentResponse.payment:type_name -> payment.Payment
 	0,  // 18: payment.AddPaymentMethodRequest.type:type_name -> payment.PaymentMethod
-	26, // 19: payment.AddPaymentMethodRequest.details:type_name -> payment.AddPaymentMethodRequest.DetailsEntry
+	73, // 19: payment.AddPaymentMethodRequest.details:type_name -> payment.AddPaymentMethodRequest.DetailsEntry
 	5,  // 20: payment.AddPaymentMethodResponse.payment_method:type_name -> payment.PaymentMethodDetails
 	4,  // 21: payment.GetPaymentResponse.payment:type_name -> payment.Payment
 	5,  // 22: payment.GetUserPaymentMethodsResponse.payment_methods:type_name -> payment.PaymentMethodDetails
 	4,  // 23: payment.GetUserPaymentsResponse.payments:type_name -> payment.Payment
 	4,  // 24: payment.GetTripPaymentsResponse.payments:type_name -> payment.Payment
-	7,  // 25: payment.PaymentService.ProcessPayment:input_type -> payment.ProcessPaymentRequest
-	9,  // 26: payment.PaymentService.ProcessRefund:input_type -> payment.ProcessRefundRequest
-	11, // 27: payment.PaymentService.AddPaymentMethod:input_type -> payment.AddPaymentMethodRequest
-	13, // 28: payment.PaymentService.GetPayment:input_type -> payment.GetPaymentRequest
-	15, // 29: payment.PaymentService.GetUserPaymentMethods:input_type -> payment.GetUserPaymentMethodsRequest
-	17, // 30: payment.PaymentService.GetUserPayments:input_type -> payment.GetUserPaymentsRequest
-	19, // 31: payment.PaymentService.GetTripPayments:input_type -> payment.GetTripPaymentsRequest
-	8,  // 32: payment.PaymentService.ProcessPayment:output_type -> payment.ProcessPaymentResponse
-	10, // 33: payment.PaymentService.ProcessRefund:output_type -> payment.ProcessRefundResponse
-	12, // 34: payment.PaymentService.AddPaymentMethod:output_type -> payment.AddPaymentMethodResponse
-	14, // 35: payment.PaymentService.GetPayment:output_type -> payment.GetPaymentResponse
-	16, // 36: payment.PaymentService.GetUserPaymentMethods:output_type -> payment.GetUserPaymentMethodsResponse
-	18, // 37: payment.PaymentService.GetUserPayments:output_type -> payment.GetUserPaymentsResponse
-	20, // 38: payment.PaymentService.GetTripPayments:output_type -> payment.GetTripPaymentsResponse
-	32, // [32:39] is the sub-list for method output_type
-	25, // [25:32] is the sub-list for method input_type
-	25, // [25:25] is the sub-list for extension type_name
-	25, // [25:25] is the sub-list for extension extendee
-	0,  // [0:25] is the sub-list for field type_name
+	75, // 25: payment.ListPaymentsRequest.created_after:type_name -> google.protobuf.Timestamp
+	75, // 26: payment.ListPaymentsRequest.created_before:type_name -> google.protobuf.Timestamp
+	4,  // 27: payment.ListPaymentsResponse.payments:type_name -> payment.Payment
+	1,  // 28: payment.ListPaymentsByStatusRequest.status:type_name -> payment.PaymentStatus
+	75, // 29: payment.PaymentHold.expires_at:type_name -> google.protobuf.Timestamp
+	75, // 30: payment.PaymentHold.created_at:type_name -> google.protobuf.Timestamp
+	28, // 31: payment.TripPaymentHoldResponse.hold:type_name -> payment.PaymentHold
+	75, // 32: payment.ChargebackEvidence.submitted_at:type_name -> google.protobuf.Timestamp
+	75, // 33: payment.Chargeback.evidence_due_by:type_name -> google.protobuf.Timestamp
+	75, // 34: payment.Chargeback.created_at:type_name -> google.protobuf.Timestamp
+	75, // 35: payment.Chargeback.resolved_at:type_name -> google.protobuf.Timestamp
+	33, // 36: payment.Chargeback.evidence:type_name -> payment.ChargebackEvidence
+	34, // 37: payment.ListChargebacksResponse.chargebacks:type_name -> payment.Chargeback
+	34, // 38: payment.ChargebackResponse.chargeback:type_name -> payment.Chargeback
+	75, // 39: payment.RecordDriverPayoutResponse.posted_at:type_name -> google.protobuf.Timestamp
+	75, // 40: payment.RefundCaseAuditEntry.created_at:type_name -> google.protobuf.Timestamp
+	75, // 41: payment.RefundCase.created_at:type_name -> google.protobuf.Timestamp
+	75, // 42: payment.RefundCase.resolved_at:type_name -> google.protobuf.Timestamp
+	45, // 43: payment.RefundCase.audit:type_name -> payment.RefundCaseAuditEntry
+	46, // 44: payment.ListRefundCasesResponse.cases:type_name -> payment.RefundCase
+	46, // 45: payment.RefundCaseResponse.case:type_name -> payment.RefundCase
+	75, // 46: payment.RecordDriverEarningResponse.earned_at:type_name -> google.protobuf.Timestamp
+	75, // 47: payment.FraudRule.created_at:type_name -> google.protobuf.Timestamp
+	75, // 48: payment.FraudRule.updated_at:type_name -> google.protobuf.Timestamp
+	54, // 49: payment.ListFraudRulesResponse.rules:type_name -> payment.FraudRule
+	54, // 50: payment.SaveFraudRuleRequest.rule:type_name -> payment.FraudRule
+	54, // 51: payment.FraudRuleResponse.rule:type_name -> payment.FraudRule
+	74, // 52: payment.FraudReview.scores:type_name -> payment.FraudReview.ScoresEntry
+	75, // 53: payment.FraudReview.reviewed_at:type_name -> google.protobuf.Timestamp
+	75, // 54: payment.FraudReview.created_at:type_name -> google.protobuf.Timestamp
+	63, // 55: payment.ListFraudReviewsResponse.reviews:type_name -> payment.FraudReview
+	63, // 56: payment.FraudReviewResponse.review:type_name -> payment.FraudReview
+	7,  // 57: payment.PaymentService.ProcessPayment:input_type -> payment.ProcessPaymentRequest
+	9,  // 58: payment.PaymentService.ProcessRefund:input_type -> payment.ProcessRefundRequest
+	11, // 59: payment.PaymentService.AddPaymentMethod:input_type -> payment.AddPaymentMethodRequest
+	13, // 60: payment.PaymentService.GetPayment:input_type -> payment.GetPaymentRequest
+	15, // 61: payment.PaymentService.GetUserPaymentMethods:input_type -> payment.GetUserPaymentMethodsRequest
+	17, // 62: payment.PaymentService.GetUserPayments:input_type -> payment.GetUserPaymentsRequest
+	19, // 63: payment.PaymentService.GetTripPayments:input_type -> payment.GetTripPaymentsRequest
+	21, // 64: payment.PaymentService.ListPayments:input_type -> payment.ListPaymentsRequest
+	23, // 65: payment.PaymentService.ListPaymentsByStatus:input_type -> payment.ListPaymentsByStatusRequest
+	24, // 66: payment.PaymentService.RemoveUserPaymentMethods:input_type -> payment.RemoveUserPaymentMethodsRequest
+	26, // 67: payment.PaymentService.GrantWalletCredit:input_type -> payment.GrantWalletCreditRequest
+	29, // 68: payment.PaymentService.AuthorizeTripPayment:input_type -> payment.AuthorizeTripPaymentRequest
+	30, // 69: payment.PaymentService.CaptureTripPayment:input_type -> payment.CaptureTripPaymentRequest
+	31, // 70: payment.PaymentService.ReleaseTripPayment:input_type -> payment.ReleaseTripPaymentRequest
+	35, // 71: payment.PaymentService.ListChargebacks:input_type -> payment.ListChargebacksRequest
+	37, // 72: payment.PaymentService.GetChargeback:input_type -> payment.GetChargebackRequest
+	38, // 73: payment.PaymentService.SubmitChargebackEvidence:input_type -> payment.SubmitChargebackEvidenceRequest
+	39, // 74: payment.PaymentService.RecordChargebackOutcome:input_type -> payment.RecordChargebackOutcomeRequest
+	41, // 75: payment.PaymentService.RecordDriverPayout:input_type -> payment.RecordDriverPayoutRequest
+	52, // 76: payment.PaymentService.RecordDriverEarning:input_type -> payment.RecordDriverEarningRequest
+	43, // 77: payment.PaymentService.ReportTripEvent:input_type -> payment.ReportTripEventRequest
+	47, // 78: payment.PaymentService.ListRefundCases:input_type -> payment.ListRefundCasesRequest
+	49, // 79: payment.PaymentService.GetRefundCase:input_type -> payment.GetRefundCaseRequest
+	50, // 80: payment.PaymentService.ResolveRefundCase:input_type -> payment.ResolveRefundCaseRequest
+	55, // 81: payment.PaymentService.ListFraudRules:input_type -> payment.ListFraudRulesRequest
+	57, // 82: payment.PaymentService.SaveFraudRule:input_type -> payment.SaveFraudRuleRequest
+	59, // 83: payment.PaymentService.SetFraudRuleEnabled:input_type -> payment.SetFraudRuleEnabledRequest
+	61, // 84: payment.PaymentService.DeleteFraudRule:input_type -> payment.DeleteFraudRuleRequest
+	64, // 85: payment.PaymentService.ListFraudReviews:input_type -> payment.ListFraudReviewsRequest
+	66, // 86: payment.PaymentService.ResolveFraudReview:input_type -> payment.ResolveFraudReviewRequest
+	8,  // 87: payment.PaymentService.ProcessPayment:output_type -> payment.ProcessPaymentResponse
+	10, // 88: payment.PaymentService.ProcessRefund:output_type -> payment.ProcessRefundResponse
+	12, // 89: payment.PaymentService.AddPaymentMethod:output_type -> payment.AddPaymentMethodResponse
+	14, // 90: payment.PaymentService.GetPayment:output_type -> payment.GetPaymentResponse
+	16, // 91: payment.PaymentService.GetUserPaymentMethods:output_type -> payment.GetUserPaymentMethodsResponse
+	18, // 92: payment.PaymentService.GetUserPayments:output_type -> payment.GetUserPaymentsResponse
+	20, // 93: payment.PaymentService.GetTripPayments:output_type -> payment.GetTripPaymentsResponse
+	22, // 94: payment.PaymentService.ListPayments:output_type -> payment.ListPaymentsResponse
+	22, // 95: payment.PaymentService.ListPaymentsByStatus:output_type -> payment.ListPaymentsResponse
+	25, // 96: payment.PaymentService.RemoveUserPaymentMethods:output_type -> payment.RemoveUserPaymentMethodsResponse
+	27, // 97: payment.PaymentService.GrantWalletCredit:output_type -> payment.GrantWalletCreditResponse
+	32, // 98: payment.PaymentService.AuthorizeTripPayment:output_type -> payment.TripPaymentHoldResponse
+	32, // 99: payment.PaymentService.CaptureTripPayment:output_type -> payment.TripPaymentHoldResponse
+	32, // 100: payment.PaymentService.ReleaseTripPayment:output_type -> payment.TripPaymentHoldResponse
+	36, // 101: payment.PaymentService.ListChargebacks:output_type -> payment.ListChargebacksResponse
+	40, // 102: payment.PaymentService.GetChargeback:output_type -> payment.ChargebackResponse
+	40, // 103: payment.PaymentService.SubmitChargebackEvidence:output_type -> payment.ChargebackResponse
+	40, // 104: payment.PaymentService.RecordChargebackOutcome:output_type -> payment.ChargebackResponse
+	42, // 105: payment.PaymentService.RecordDriverPayout:output_type -> payment.RecordDriverPayoutResponse
+	53, // 106: payment.PaymentService.RecordDriverEarning:output_type -> payment.RecordDriverEarningResponse
+	44, // 107: payment.PaymentService.ReportTripEvent:output_type -> payment.ReportTripEventResponse
+	48, // 108: payment.PaymentService.ListRefundCases:output_type -> payment.ListRefundCasesResponse
+	51, // 109: payment.PaymentService.GetRefundCase:output_type -> payment.RefundCaseResponse
+	51, // 110: payment.PaymentService.ResolveRefundCase:output_type -> payment.RefundCaseResponse
+	56, // 111: payment.PaymentService.ListFraudRules:output_type -> payment.ListFraudRulesResponse
+	58, // 112: payment.PaymentService.SaveFraudRule:output_type -> payment.FraudRuleResponse
+	60, // 113: payment.PaymentService.SetFraudRuleEnabled:output_type -> payment.SetFraudRuleEnabledResponse
+	62, // 114: payment.PaymentService.DeleteFraudRule:output_type -> payment.DeleteFraudRuleResponse
+	65, // 115: payment.PaymentService.ListFraudReviews:output_type -> payment.ListFraudReviewsResponse
+	67, // 116: payment.PaymentService.ResolveFraudReview:output_type -> payment.FraudReviewResponse
+	87, // [87:117] is the sub-list for method output_type
+	57, // [57:87] is the sub-list for method input_type
+	57, // [57:57] is the sub-list for extension type_name
+	57, // [57:57] is the sub-list for extension extendee
+	0,  // [0:57] is the sub-list for field type_name
 }
 
 func init() { file_shared_proto_payment_payment_proto_init() }
@@ -1777,7 +5480,7 @@ func file_shared_proto_payment_payment_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_shared_proto_payment_payment_proto_rawDesc), len(file_shared_proto_payment_payment_proto_rawDesc)),
 			NumEnums:      4,
-			NumMessages:   23,
+			NumMessages:   71,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,13 +19,36 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	PaymentService_ProcessPayment_FullMethodName        = "/payment.PaymentService/ProcessPayment"
-	PaymentService_ProcessRefund_FullMethodName         = "/payment.PaymentService/ProcessRefund"
-	PaymentService_AddPaymentMethod_FullMethodName      = "/payment.PaymentService/AddPaymentMethod"
-	PaymentService_GetPayment_FullMethodName            = "/payment.PaymentService/GetPayment"
-	PaymentService_GetUserPaymentMethods_FullMethodName = "/payment.PaymentService/GetUserPaymentMethods"
-	PaymentService_GetUserPayments_FullMethodName       = "/payment.PaymentService/GetUserPayments"
-	PaymentService_GetTripPayments_FullMethodName       = "/payment.PaymentService/GetTripPayments"
+	PaymentService_ProcessPayment_FullMethodName           = "/payment.PaymentService/ProcessPayment"
+	PaymentService_ProcessRefund_FullMethodName            = "/payment.PaymentService/ProcessRefund"
+	PaymentService_AddPaymentMethod_FullMethodName         = "/payment.PaymentService/AddPaymentMethod"
+	PaymentService_GetPayment_FullMethodName               = "/payment.PaymentService/GetPayment"
+	PaymentService_GetUserPaymentMethods_FullMethodName    = "/payment.PaymentService/GetUserPaymentMethods"
+	PaymentService_GetUserPayments_FullMethodName          = "/payment.PaymentService/GetUserPayments"
+	PaymentService_GetTripPayments_FullMethodName          = "/payment.PaymentService/GetTripPayments"
+	PaymentService_ListPayments_FullMethodName             = "/payment.PaymentService/ListPayments"
+	PaymentService_ListPaymentsByStatus_FullMethodName     = "/payment.PaymentService/ListPaymentsByStatus"
+	PaymentService_RemoveUserPaymentMethods_FullMethodName = "/payment.PaymentService/RemoveUserPaymentMethods"
+	PaymentService_GrantWalletCredit_FullMethodName        = "/payment.PaymentService/GrantWalletCredit"
+	PaymentService_AuthorizeTripPayment_FullMethodName     = "/payment.PaymentService/AuthorizeTripPayment"
+	PaymentService_CaptureTripPayment_FullMethodName       = "/payment.PaymentService/CaptureTripPayment"
+	PaymentService_ReleaseTripPayment_FullMethodName       = "/payment.PaymentService/ReleaseTripPayment"
+	PaymentService_ListChargebacks_FullMethodName          = "/payment.PaymentService/ListChargebacks"
+	PaymentService_GetChargeback_FullMethodName            = "/payment.PaymentService/GetChargeback"
+	PaymentService_SubmitChargebackEvidence_FullMethodName = "/payment.PaymentService/SubmitChargebackEvidence"
+	PaymentService_RecordChargebackOutcome_FullMethodName  = "/payment.PaymentService/RecordChargebackOutcome"
+	PaymentService_RecordDriverPayout_FullMethodName       = "/payment.PaymentService/RecordDriverPayout"
+	PaymentService_RecordDriverEarning_FullMethodName      = "/payment.PaymentService/RecordDriverEarning"
+	PaymentService_ReportTripEvent_FullMethodName          = "/payment.PaymentService/ReportTripEvent"
+	PaymentService_ListRefundCases_FullMethodName          = "/payment.PaymentService/ListRefundCases"
+	PaymentService_GetRefundCase_FullMethodName            = "/payment.PaymentService/GetRefundCase"
+	PaymentService_ResolveRefundCase_FullMethodName        = "/payment.PaymentService/ResolveRefundCase"
+	PaymentService_ListFraudRules_FullMethodName           = "/payment.PaymentService/ListFraudRules"
+	PaymentService_SaveFraudRule_FullMethodName            = "/payment.PaymentService/SaveFraudRule"
+	PaymentService_SetFraudRuleEnabled_FullMethodName      = "/payment.PaymentService/SetFraudRuleEnabled"
+	PaymentService_DeleteFraudRule_FullMethodName          = "/payment.PaymentService/DeleteFraudRule"
+	PaymentService_ListFraudReviews_FullMethodName         = "/payment.PaymentService/ListFraudReviews"
+	PaymentService_ResolveFraudReview_FullMethodName       = "/payment.PaymentService/ResolveFraudReview"
 )
 
 // PaymentServiceClient is the client API for PaymentService service.
@@ -41,6 +64,36 @@ type PaymentServiceClient interface {
 	GetUserPaymentMethods(ctx context.Context, in *GetUserPaymentMethodsRequest, opts ...grpc.CallOption) (*GetUserPaymentMethodsResponse, error)
 	GetUserPayments(ctx context.Context, in *GetUserPaymentsRequest, opts ...grpc.CallOption) (*GetUserPaymentsResponse, error)
 	GetTripPayments(ctx context.Context, in *GetTripPaymentsRequest, opts ...grpc.CallOption) (*GetTripPaymentsResponse, error)
+	ListPayments(ctx context.Context, in *ListPaymentsRequest, opts ...grpc.CallOption) (*ListPaymentsResponse, error)
+	ListPaymentsByStatus(ctx context.Context, in *ListPaymentsByStatusRequest, opts ...grpc.CallOption) (*ListPaymentsResponse, error)
+	RemoveUserPaymentMethods(ctx context.Context, in *RemoveUserPaymentMethodsRequest, opts ...grpc.CallOption) (*RemoveUserPaymentMethodsResponse, error)
+	GrantWalletCredit(ctx context.Context, in *GrantWalletCreditRequest, opts ...grpc.CallOption) (*GrantWalletCreditResponse, error)
+	// Pre-authorization holds on trip fares
+	AuthorizeTripPayment(ctx context.Context, in *AuthorizeTripPaymentRequest, opts ...grpc.CallOption) (*TripPaymentHoldResponse, error)
+	CaptureTripPayment(ctx context.Context, in *CaptureTripPaymentRequest, opts ...grpc.CallOption) (*TripPaymentHoldResponse, error)
+	ReleaseTripPayment(ctx context.Context, in *ReleaseTripPaymentRequest, opts ...grpc.CallOption) (*TripPaymentHoldResponse, error)
+	// Chargebacks reported by the payment provider
+	ListChargebacks(ctx context.Context, in *ListChargebacksRequest, opts ...grpc.CallOption) (*ListChargebacksResponse, error)
+	GetChargeback(ctx context.Context, in *GetChargebackRequest, opts ...grpc.CallOption) (*ChargebackResponse, error)
+	SubmitChargebackEvidence(ctx context.Context, in *SubmitChargebackEvidenceRequest, opts ...grpc.CallOption) (*ChargebackResponse, error)
+	RecordChargebackOutcome(ctx context.Context, in *RecordChargebackOutcomeRequest, opts ...grpc.CallOption) (*ChargebackResponse, error)
+	// Driver payouts posted to the general ledger, and tips and incentives
+	// recorded by other services
+	RecordDriverPayout(ctx context.Context, in *RecordDriverPayoutRequest, opts ...grpc.CallOption) (*RecordDriverPayoutResponse, error)
+	RecordDriverEarning(ctx context.Context, in *RecordDriverEarningRequest, opts ...grpc.CallOption) (*RecordDriverEarningResponse, error)
+	// Refunds raised by the refund policy, and the review of larger ones
+	ReportTripEvent(ctx context.Context, in *ReportTripEventRequest, opts ...grpc.CallOption) (*ReportTripEventResponse, error)
+	ListRefundCases(ctx context.Context, in *ListRefundCasesRequest, opts ...grpc.CallOption) (*ListRefundCasesResponse, error)
+	GetRefundCase(ctx context.Context, in *GetRefundCaseRequest, opts ...grpc.CallOption) (*RefundCaseResponse, error)
+	ResolveRefundCase(ctx context.Context, in *ResolveRefundCaseRequest, opts ...grpc.CallOption) (*RefundCaseResponse, error)
+	// Fraud rules and the review queue of flagged payments. Only operators
+	// may call these.
+	ListFraudRules(ctx context.Context, in *ListFraudRulesRequest, opts ...grpc.CallOption) (*ListFraudRulesResponse, error)
+	SaveFraudRule(ctx context.Context, in *SaveFraudRuleRequest, opts ...grpc.CallOption) (*FraudRuleResponse, error)
+	SetFraudRuleEnabled(ctx context.Context, in *SetFraudRuleEnabledRequest, opts ...grpc.CallOption) (*SetFraudRuleEnabledResponse, error)
+	DeleteFraudRule(ctx context.Context, in *DeleteFraudRuleRequest, opts ...grpc.CallOption) (*DeleteFraudRuleResponse, error)
+	ListFraudReviews(ctx context.Context, in *ListFraudReviewsRequest, opts ...grpc.CallOption) (*ListFraudReviewsResponse, error)
+	ResolveFraudReview(ctx context.Context, in *ResolveFraudReviewRequest, opts ...grpc.CallOption) (*FraudReviewResponse, error)
 }
 
 type paymentServiceClient struct {
@@ -121,6 +174,236 @@ func (c *paymentServiceClient) GetTripPayments(ctx context.Context, in *GetTripP
 	return out, nil
 }
 
+func (c *paymentServiceClient) ListPayments(ctx context.Context, in *ListPaymentsRequest, opts ...grpc.CallOption) (*ListPaymentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPaymentsResponse)
+	err := c.cc.Invoke(ctx, PaymentService_ListPayments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ListPaymentsByStatus(ctx context.Context, in *ListPaymentsByStatusRequest, opts ...grpc.CallOption) (*ListPaymentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPaymentsResponse)
+	err := c.cc.Invoke(ctx, PaymentService_ListPaymentsByStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) RemoveUserPaymentMethods(ctx context.Context, in *RemoveUserPaymentMethodsRequest, opts ...grpc.CallOption) (*RemoveUserPaymentMethodsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveUserPaymentMethodsResponse)
+	err := c.cc.Invoke(ctx, PaymentService_RemoveUserPaymentMethods_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) GrantWalletCredit(ctx context.Context, in *GrantWalletCreditRequest, opts ...grpc.CallOption) (*GrantWalletCreditResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GrantWalletCreditResponse)
+	err := c.cc.Invoke(ctx, PaymentService_GrantWalletCredit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) AuthorizeTripPayment(ctx context.Context, in *AuthorizeTripPaymentRequest, opts ...grpc.CallOption) (*TripPaymentHoldResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TripPaymentHoldResponse)
+	err := c.cc.Invoke(ctx, PaymentService_AuthorizeTripPayment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) CaptureTripPayment(ctx context.Context, in *CaptureTripPaymentRequest, opts ...grpc.CallOption) (*TripPaymentHoldResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TripPaymentHoldResponse)
+	err := c.cc.Invoke(ctx, PaymentService_CaptureTripPayment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ReleaseTripPayment(ctx context.Context, in *ReleaseTripPaymentRequest, opts ...grpc.CallOption) (*TripPaymentHoldResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TripPaymentHoldResponse)
+	err := c.cc.Invoke(ctx, PaymentService_ReleaseTripPayment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ListChargebacks(ctx context.Context, in *ListChargebacksRequest, opts ...grpc.CallOption) (*ListChargebacksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListChargebacksResponse)
+	err := c.cc.Invoke(ctx, PaymentService_ListChargebacks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) GetChargeback(ctx context.Context, in *GetChargebackRequest, opts ...grpc.CallOption) (*ChargebackResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChargebackResponse)
+	err := c.cc.Invoke(ctx, PaymentService_GetChargeback_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) SubmitChargebackEvidence(ctx context.Context, in *SubmitChargebackEvidenceRequest, opts ...grpc.CallOption) (*ChargebackResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChargebackResponse)
+	err := c.cc.Invoke(ctx, PaymentService_SubmitChargebackEvidence_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) RecordChargebackOutcome(ctx context.Context, in *RecordChargebackOutcomeRequest, opts ...grpc.CallOption) (*ChargebackResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChargebackResponse)
+	err := c.cc.Invoke(ctx, PaymentService_RecordChargebackOutcome_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) RecordDriverPayout(ctx context.Context, in *RecordDriverPayoutRequest, opts ...grpc.CallOption) (*RecordDriverPayoutResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordDriverPayoutResponse)
+	err := c.cc.Invoke(ctx, PaymentService_RecordDriverPayout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) RecordDriverEarning(ctx context.Context, in *RecordDriverEarningRequest, opts ...grpc.CallOption) (*RecordDriverEarningResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordDriverEarningResponse)
+	err := c.cc.Invoke(ctx, PaymentService_RecordDriverEarning_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ReportTripEvent(ctx context.Context, in *ReportTripEventRequest, opts ...grpc.CallOption) (*ReportTripEventResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReportTripEventResponse)
+	err := c.cc.Invoke(ctx, PaymentService_ReportTripEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ListRefundCases(ctx context.Context, in *ListRefundCasesRequest, opts ...grpc.CallOption) (*ListRefundCasesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRefundCasesResponse)
+	err := c.cc.Invoke(ctx, PaymentService_ListRefundCases_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) GetRefundCase(ctx context.Context, in *GetRefundCaseRequest, opts ...grpc.CallOption) (*RefundCaseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefundCaseResponse)
+	err := c.cc.Invoke(ctx, PaymentService_GetRefundCase_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ResolveRefundCase(ctx context.Context, in *ResolveRefundCaseRequest, opts ...grpc.CallOption) (*RefundCaseResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefundCaseResponse)
+	err := c.cc.Invoke(ctx, PaymentService_ResolveRefundCase_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ListFraudRules(ctx context.Context, in *ListFraudRulesRequest, opts ...grpc.CallOption) (*ListFraudRulesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFraudRulesResponse)
+	err := c.cc.Invoke(ctx, PaymentService_ListFraudRules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) SaveFraudRule(ctx context.Context, in *SaveFraudRuleRequest, opts ...grpc.CallOption) (*FraudRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FraudRuleResponse)
+	err := c.cc.Invoke(ctx, PaymentService_SaveFraudRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) SetFraudRuleEnabled(ctx context.Context, in *SetFraudRuleEnabledRequest, opts ...grpc.CallOption) (*SetFraudRuleEnabledResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetFraudRuleEnabledResponse)
+	err := c.cc.Invoke(ctx, PaymentService_SetFraudRuleEnabled_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) DeleteFraudRule(ctx context.Context, in *DeleteFraudRuleRequest, opts ...grpc.CallOption) (*DeleteFraudRuleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteFraudRuleResponse)
+	err := c.cc.Invoke(ctx, PaymentService_DeleteFraudRule_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ListFraudReviews(ctx context.Context, in *ListFraudReviewsRequest, opts ...grpc.CallOption) (*ListFraudReviewsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFraudReviewsResponse)
+	err := c.cc.Invoke(ctx, PaymentService_ListFraudReviews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) ResolveFraudReview(ctx context.Context, in *ResolveFraudReviewRequest, opts ...grpc.CallOption) (*FraudReviewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FraudReviewResponse)
+	err := c.cc.Invoke(ctx, PaymentService_ResolveFraudReview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PaymentServiceServer is the server API for PaymentService service.
 // All implementations must embed UnimplementedPaymentServiceServer
 // for forward compatibility.
@@ -134,6 +417,36 @@ type PaymentServiceServer interface {
 	GetUserPaymentMethods(context.Context, *GetUserPaymentMethodsRequest) (*GetUserPaymentMethodsResponse, error)
 	GetUserPayments(context.Context, *GetUserPaymentsRequest) (*GetUserPaymentsResponse, error)
 	GetTripPayments(context.Context, *GetTripPaymentsRequest) (*GetTripPaymentsResponse, error)
+	ListPayments(context.Context, *ListPaymentsRequest) (*ListPaymentsResponse, error)
+	ListPaymentsByStatus(context.Context, *ListPaymentsByStatusRequest) (*ListPaymentsResponse, error)
+	RemoveUserPaymentMethods(context.Context, *RemoveUserPaymentMethodsRequest) (*RemoveUserPaymentMethodsResponse, error)
+	GrantWalletCredit(context.Context, *GrantWalletCreditRequest) (*GrantWalletCreditResponse, error)
+	// Pre-authorization holds on trip fares
+	AuthorizeTripPayment(context.Context, *AuthorizeTripPaymentRequest) (*TripPaymentHoldResponse, error)
+	CaptureTripPayment(context.Context, *CaptureTripPaymentRequest) (*TripPaymentHoldResponse, error)
+	ReleaseTripPayment(context.Context, *ReleaseTripPaymentRequest) (*TripPaymentHoldResponse, error)
+	// Chargebacks reported by the payment provider
+	ListChargebacks(context.Context, *ListChargebacksRequest) (*ListChargebacksResponse, error)
+	GetChargeback(context.Context, *GetChargebackRequest) (*ChargebackResponse, error)
+	SubmitChargebackEvidence(context.Context, *SubmitChargebackEvidenceRequest) (*ChargebackResponse, error)
+	RecordChargebackOutcome(context.Context, *RecordChargebackOutcomeRequest) (*ChargebackResponse, error)
+	// Driver payouts posted to the general ledger, and tips and incentives
+	// recorded by other services
+	RecordDriverPayout(context.Context, *RecordDriverPayoutRequest) (*RecordDriverPayoutResponse, error)
+	RecordDriverEarning(context.Context, *RecordDriverEarningRequest) (*RecordDriverEarningResponse, error)
+	// Refunds raised by the refund policy, and the review of larger ones
+	ReportTripEvent(context.Context, *ReportTripEventRequest) (*ReportTripEventResponse, error)
+	ListRefundCases(context.Context, *ListRefundCasesRequest) (*ListRefundCasesResponse, error)
+	GetRefundCase(context.Context, *GetRefundCaseRequest) (*RefundCaseResponse, error)
+	ResolveRefundCase(context.Context, *ResolveRefundCaseRequest) (*RefundCaseResponse, error)
+	// Fraud rules and the review queue of flagged payments. Only operators
+	// may call these.
+	ListFraudRules(context.Context, *ListFraudRulesRequest) (*ListFraudRulesResponse, error)
+	SaveFraudRule(context.Context, *SaveFraudRuleRequest) (*FraudRuleResponse, error)
+	SetFraudRuleEnabled(context.Context, *SetFraudRuleEnabledRequest) (*SetFraudRuleEnabledResponse, error)
+	DeleteFraudRule(context.Context, *DeleteFraudRuleRequest) (*DeleteFraudRuleResponse, error)
+	ListFraudReviews(context.Context, *ListFraudReviewsRequest) (*ListFraudReviewsResponse, error)
+	ResolveFraudReview(context.Context, *ResolveFraudReviewRequest) (*FraudReviewResponse, error)
 	mustEmbedUnimplementedPaymentServiceServer()
 }
 
@@ -165,6 +478,75 @@ func (UnimplementedPaymentServiceServer) GetUserPayments(context.Context, *GetUs
 func (UnimplementedPaymentServiceServer) GetTripPayments(context.Context, *GetTripPaymentsRequest) (*GetTripPaymentsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTripPayments not implemented")
 }
+func (UnimplementedPaymentServiceServer) ListPayments(context.Context, *ListPaymentsRequest) (*ListPaymentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPayments not implemented")
+}
+func (UnimplementedPaymentServiceServer) ListPaymentsByStatus(context.Context, *ListPaymentsByStatusRequest) (*ListPaymentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPaymentsByStatus not implemented")
+}
+func (UnimplementedPaymentServiceServer) RemoveUserPaymentMethods(context.Context, *RemoveUserPaymentMethodsRequest) (*RemoveUserPaymentMethodsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveUserPaymentMethods not implemented")
+}
+func (UnimplementedPaymentServiceServer) GrantWalletCredit(context.Context, *GrantWalletCreditRequest) (*GrantWalletCreditResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GrantWalletCredit not implemented")
+}
+func (UnimplementedPaymentServiceServer) AuthorizeTripPayment(context.Context, *AuthorizeTripPaymentRequest) (*TripPaymentHoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuthorizeTripPayment not implemented")
+}
+func (UnimplementedPaymentServiceServer) CaptureTripPayment(context.Context, *CaptureTripPaymentRequest) (*TripPaymentHoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CaptureTripPayment not implemented")
+}
+func (UnimplementedPaymentServiceServer) ReleaseTripPayment(context.Context, *ReleaseTripPaymentRequest) (*TripPaymentHoldResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseTripPayment not implemented")
+}
+func (UnimplementedPaymentServiceServer) ListChargebacks(context.Context, *ListChargebacksRequest) (*ListChargebacksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListChargebacks not implemented")
+}
+func (UnimplementedPaymentServiceServer) GetChargeback(context.Context, *GetChargebackRequest) (*ChargebackResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChargeback not implemented")
+}
+func (UnimplementedPaymentServiceServer) SubmitChargebackEvidence(context.Context, *SubmitChargebackEvidenceRequest) (*ChargebackResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitChargebackEvidence not implemented")
+}
+func (UnimplementedPaymentServiceServer) RecordChargebackOutcome(context.Context, *RecordChargebackOutcomeRequest) (*ChargebackResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordChargebackOutcome not implemented")
+}
+func (UnimplementedPaymentServiceServer) RecordDriverPayout(context.Context, *RecordDriverPayoutRequest) (*RecordDriverPayoutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordDriverPayout not implemented")
+}
+func (UnimplementedPaymentServiceServer) RecordDriverEarning(context.Context, *RecordDriverEarningRequest) (*RecordDriverEarningResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordDriverEarning not implemented")
+}
+func (UnimplementedPaymentServiceServer) ReportTripEvent(context.Context, *ReportTripEventRequest) (*ReportTripEventResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportTripEvent not implemented")
+}
+func (UnimplementedPaymentServiceServer) ListRefundCases(context.Context, *ListRefundCasesRequest) (*ListRefundCasesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRefundCases not implemented")
+}
+func (UnimplementedPaymentServiceServer) GetRefundCase(context.Context, *GetRefundCaseRequest) (*RefundCaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRefundCase not implemented")
+}
+func (UnimplementedPaymentServiceServer) ResolveRefundCase(context.Context, *ResolveRefundCaseRequest) (*RefundCaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveRefundCase not implemented")
+}
+func (UnimplementedPaymentServiceServer) ListFraudRules(context.Context, *ListFraudRulesRequest) (*ListFraudRulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFraudRules not implemented")
+}
+func (UnimplementedPaymentServiceServer) SaveFraudRule(context.Context, *SaveFraudRuleRequest) (*FraudRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveFraudRule not implemented")
+}
+func (UnimplementedPaymentServiceServer) SetFraudRuleEnabled(context.Context, *SetFraudRuleEnabledRequest) (*SetFraudRuleEnabledResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetFraudRuleEnabled not implemented")
+}
+func (UnimplementedPaymentServiceServer) DeleteFraudRule(context.Context, *DeleteFraudRuleRequest) (*DeleteFraudRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFraudRule not implemented")
+}
+func (UnimplementedPaymentServiceServer) ListFraudReviews(context.Context, *ListFraudReviewsRequest) (*ListFraudReviewsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFraudReviews not implemented")
+}
+func (UnimplementedPaymentServiceServer) ResolveFraudReview(context.Context, *ResolveFraudReviewRequest) (*FraudReviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveFraudReview not implemented")
+}
 func (UnimplementedPaymentServiceServer) mustEmbedUnimplementedPaymentServiceServer() {}
 func (UnimplementedPaymentServiceServer) testEmbeddedByValue()                        {}
 
@@ -312,40 +694,546 @@ func _PaymentService_GetTripPayments_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
-// PaymentService_ServiceDesc is the grpc.ServiceDesc for PaymentService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var PaymentService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "payment.PaymentService",
-	HandlerType: (*PaymentServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "ProcessPayment",
-			Handler:    _PaymentService_ProcessPayment_Handler,
-		},
-		{
-			MethodName: "ProcessRefund",
-			Handler:    _PaymentService_ProcessRefund_Handler,
-		},
-		{
-			MethodName: "AddPaymentMethod",
-			Handler:    _PaymentService_AddPaymentMethod_Handler,
-		},
-		{
-			MethodName: "GetPayment",
-			Handler:    _PaymentService_GetPayment_Handler,
-		},
-		{
-			MethodName: "GetUserPaymentMethods",
-			Handler:    _PaymentService_GetUserPaymentMethods_Handler,
-		},
-		{
-			MethodName: "GetUserPayments",
-			Handler:    _PaymentService_GetUserPayments_Handler,
-		},
-		{
-			MethodName: "GetTripPayments",
-			Handler:    _PaymentService_GetTripPayments_Handler,
+func _PaymentService_ListPayments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPaymentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ListPayments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_ListPayments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ListPayments(ctx, req.(*ListPaymentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_ListPaymentsByStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPaymentsByStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ListPaymentsByStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_ListPaymentsByStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ListPaymentsByStatus(ctx, req.(*ListPaymentsByStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_RemoveUserPaymentMethods_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveUserPaymentMethodsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).RemoveUserPaymentMethods(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_RemoveUserPaymentMethods_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).RemoveUserPaymentMethods(ctx, req.(*RemoveUserPaymentMethodsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_GrantWalletCredit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GrantWalletCreditRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).GrantWalletCredit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_GrantWalletCredit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).GrantWalletCredit(ctx, req.(*GrantWalletCreditRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_AuthorizeTripPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthorizeTripPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).AuthorizeTripPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_AuthorizeTripPayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).AuthorizeTripPayment(ctx, req.(*AuthorizeTripPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_CaptureTripPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CaptureTripPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).CaptureTripPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_CaptureTripPayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).CaptureTripPayment(ctx, req.(*CaptureTripPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_ReleaseTripPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseTripPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ReleaseTripPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_ReleaseTripPayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ReleaseTripPayment(ctx, req.(*ReleaseTripPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_ListChargebacks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListChargebacksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ListChargebacks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_ListChargebacks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ListChargebacks(ctx, req.(*ListChargebacksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_GetChargeback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChargebackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).GetChargeback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_GetChargeback_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).GetChargeback(ctx, req.(*GetChargebackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_SubmitChargebackEvidence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitChargebackEvidenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).SubmitChargebackEvidence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_SubmitChargebackEvidence_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).SubmitChargebackEvidence(ctx, req.(*SubmitChargebackEvidenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_RecordChargebackOutcome_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordChargebackOutcomeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).RecordChargebackOutcome(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_RecordChargebackOutcome_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).RecordChargebackOutcome(ctx, req.(*RecordChargebackOutcomeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_RecordDriverPayout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordDriverPayoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).RecordDriverPayout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_RecordDriverPayout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).RecordDriverPayout(ctx, req.(*RecordDriverPayoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_RecordDriverEarning_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordDriverEarningRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).RecordDriverEarning(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_RecordDriverEarning_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).RecordDriverEarning(ctx, req.(*RecordDriverEarningRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_ReportTripEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportTripEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ReportTripEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_ReportTripEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ReportTripEvent(ctx, req.(*ReportTripEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_ListRefundCases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRefundCasesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ListRefundCases(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_ListRefundCases_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ListRefundCases(ctx, req.(*ListRefundCasesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_GetRefundCase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRefundCaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).GetRefundCase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_GetRefundCase_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).GetRefundCase(ctx, req.(*GetRefundCaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_ResolveRefundCase_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveRefundCaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ResolveRefundCase(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_ResolveRefundCase_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ResolveRefundCase(ctx, req.(*ResolveRefundCaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_ListFraudRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFraudRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ListFraudRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_ListFraudRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ListFraudRules(ctx, req.(*ListFraudRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_SaveFraudRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveFraudRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).SaveFraudRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_SaveFraudRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).SaveFraudRule(ctx, req.(*SaveFraudRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_SetFraudRuleEnabled_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetFraudRuleEnabledRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).SetFraudRuleEnabled(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_SetFraudRuleEnabled_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).SetFraudRuleEnabled(ctx, req.(*SetFraudRuleEnabledRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_DeleteFraudRule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFraudRuleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).DeleteFraudRule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_DeleteFraudRule_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).DeleteFraudRule(ctx, req.(*DeleteFraudRuleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_ListFraudReviews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListFraudReviewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ListFraudReviews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_ListFraudReviews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ListFraudReviews(ctx, req.(*ListFraudReviewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_ResolveFraudReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveFraudReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).ResolveFraudReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_ResolveFraudReview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).ResolveFraudReview(ctx, req.(*ResolveFraudReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PaymentService_ServiceDesc is the grpc.ServiceDesc for PaymentService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PaymentService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "payment.PaymentService",
+	HandlerType: (*PaymentServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ProcessPayment",
+			Handler:    _PaymentService_ProcessPayment_Handler,
+		},
+		{
+			MethodName: "ProcessRefund",
+			Handler:    _PaymentService_ProcessRefund_Handler,
+		},
+		{
+			MethodName: "AddPaymentMethod",
+			Handler:    _PaymentService_AddPaymentMethod_Handler,
+		},
+		{
+			MethodName: "GetPayment",
+			Handler:    _PaymentService_GetPayment_Handler,
+		},
+		{
+			MethodName: "GetUserPaymentMethods",
+			Handler:    _PaymentService_GetUserPaymentMethods_Handler,
+		},
+		{
+			MethodName: "GetUserPayments",
+			Handler:    _PaymentService_GetUserPayments_Handler,
+		},
+		{
+			MethodName: "GetTripPayments",
+			Handler:    _PaymentService_GetTripPayments_Handler,
+		},
+		{
+			MethodName: "ListPayments",
+			Handler:    _PaymentService_ListPayments_Handler,
+		},
+		{
+			MethodName: "ListPaymentsByStatus",
+			Handler:    _PaymentService_ListPaymentsByStatus_Handler,
+		},
+		{
+			MethodName: "RemoveUserPaymentMethods",
+			Handler:    _PaymentService_RemoveUserPaymentMethods_Handler,
+		},
+		{
+			MethodName: "GrantWalletCredit",
+			Handler:    _PaymentService_GrantWalletCredit_Handler,
+		},
+		{
+			MethodName: "AuthorizeTripPayment",
+			Handler:    _PaymentService_AuthorizeTripPayment_Handler,
+		},
+		{
+			MethodName: "CaptureTripPayment",
+			Handler:    _PaymentService_CaptureTripPayment_Handler,
+		},
+		{
+			MethodName: "ReleaseTripPayment",
+			Handler:    _PaymentService_ReleaseTripPayment_Handler,
+		},
+		{
+			MethodName: "ListChargebacks",
+			Handler:    _PaymentService_ListChargebacks_Handler,
+		},
+		{
+			MethodName: "GetChargeback",
+			Handler:    _PaymentService_GetChargeback_Handler,
+		},
+		{
+			MethodName: "SubmitChargebackEvidence",
+			Handler:    _PaymentService_SubmitChargebackEvidence_Handler,
+		},
+		{
+			MethodName: "RecordChargebackOutcome",
+			Handler:    _PaymentService_RecordChargebackOutcome_Handler,
+		},
+		{
+			MethodName: "RecordDriverPayout",
+			Handler:    _PaymentService_RecordDriverPayout_Handler,
+		},
+		{
+			MethodName: "RecordDriverEarning",
+			Handler:    _PaymentService_RecordDriverEarning_Handler,
+		},
+		{
+			MethodName: "ReportTripEvent",
+			Handler:    _PaymentService_ReportTripEvent_Handler,
+		},
+		{
+			MethodName: "ListRefundCases",
+			Handler:    _PaymentService_ListRefundCases_Handler,
+		},
+		{
+			MethodName: "GetRefundCase",
+			Handler:    _PaymentService_GetRefundCase_Handler,
+		},
+		{
+			MethodName: "ResolveRefundCase",
+			Handler:    _PaymentService_ResolveRefundCase_Handler,
+		},
+		{
+			MethodName: "ListFraudRules",
+			Handler:    _PaymentService_ListFraudRules_Handler,
+		},
+		{
+			MethodName: "SaveFraudRule",
+			Handler:    _PaymentService_SaveFraudRule_Handler,
+		},
+		{
+			MethodName: "SetFraudRuleEnabled",
+			Handler:    _PaymentService_SetFraudRuleEnabled_Handler,
+		},
+		{
+			MethodName: "DeleteFraudRule",
+			Handler:    _PaymentService_DeleteFraudRule_Handler,
+		},
+		{
+			MethodName: "ListFraudReviews",
+			Handler:    _PaymentService_ListFraudReviews_Handler,
+		},
+		{
+			MethodName: "ResolveFraudReview",
+			Handler:    _PaymentService_ResolveFraudReview_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
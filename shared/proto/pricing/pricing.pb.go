@@ -97,8 +97,11 @@ type PriceEstimate struct {
 	Currency        string                 `protobuf:"bytes,9,opt,name=currency,proto3" json:"currency,omitempty"`
 	Breakdown       *PricingBreakdown      `protobuf:"bytes,10,opt,name=breakdown,proto3" json:"breakdown,omitempty"`
 	ValidUntil      *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=valid_until,json=validUntil,proto3" json:"valid_until,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// Price lock guaranteeing surge_multiplier until valid_until. Pass it back
+	// to re-quote, and on the final fare of a trip booked with the quote.
+	QuoteId       string `protobuf:"bytes,12,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PriceEstimate) Reset() {
@@ -208,22 +211,32 @@ func (x *PriceEstimate) GetValidUntil() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *PriceEstimate) GetQuoteId() string {
+	if x != nil {
+		return x.QuoteId
+	}
+	return ""
+}
+
 // Detailed pricing breakdown
 type PricingBreakdown struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	BaseRate        float64                `protobuf:"fixed64,1,opt,name=base_rate,json=baseRate,proto3" json:"base_rate,omitempty"`
-	PerKmRate       float64                `protobuf:"fixed64,2,opt,name=per_km_rate,json=perKmRate,proto3" json:"per_km_rate,omitempty"`
-	PerMinuteRate   float64                `protobuf:"fixed64,3,opt,name=per_minute_rate,json=perMinuteRate,proto3" json:"per_minute_rate,omitempty"`
-	DistanceKm      float64                `protobuf:"fixed64,4,opt,name=distance_km,json=distanceKm,proto3" json:"distance_km,omitempty"`
-	DurationMinutes int32                  `protobuf:"varint,5,opt,name=duration_minutes,json=durationMinutes,proto3" json:"duration_minutes,omitempty"`
-	BookingFee      float64                `protobuf:"fixed64,6,opt,name=booking_fee,json=bookingFee,proto3" json:"booking_fee,omitempty"`
-	ServiceFee      float64                `protobuf:"fixed64,7,opt,name=service_fee,json=serviceFee,proto3" json:"service_fee,omitempty"`
-	Taxes           float64                `protobuf:"fixed64,8,opt,name=taxes,proto3" json:"taxes,omitempty"`
-	Tolls           float64                `protobuf:"fixed64,9,opt,name=tolls,proto3" json:"tolls,omitempty"`
-	Discounts       []*AppliedDiscount     `protobuf:"bytes,10,rep,name=discounts,proto3" json:"discounts,omitempty"`
-	SurgeInfo       *SurgeInfo             `protobuf:"bytes,11,opt,name=surge_info,json=surgeInfo,proto3" json:"surge_info,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	BaseRate           float64                `protobuf:"fixed64,1,opt,name=base_rate,json=baseRate,proto3" json:"base_rate,omitempty"`
+	PerKmRate          float64                `protobuf:"fixed64,2,opt,name=per_km_rate,json=perKmRate,proto3" json:"per_km_rate,omitempty"`
+	PerMinuteRate      float64                `protobuf:"fixed64,3,opt,name=per_minute_rate,json=perMinuteRate,proto3" json:"per_minute_rate,omitempty"`
+	DistanceKm         float64                `protobuf:"fixed64,4,opt,name=distance_km,json=distanceKm,proto3" json:"distance_km,omitempty"`
+	DurationMinutes    int32                  `protobuf:"varint,5,opt,name=duration_minutes,json=durationMinutes,proto3" json:"duration_minutes,omitempty"`
+	BookingFee         float64                `protobuf:"fixed64,6,opt,name=booking_fee,json=bookingFee,proto3" json:"booking_fee,omitempty"`
+	ServiceFee         float64                `protobuf:"fixed64,7,opt,name=service_fee,json=serviceFee,proto3" json:"service_fee,omitempty"`
+	Taxes              float64                `protobuf:"fixed64,8,opt,name=taxes,proto3" json:"taxes,omitempty"`
+	Tolls              float64                `protobuf:"fixed64,9,opt,name=tolls,proto3" json:"tolls,omitempty"`
+	Discounts          []*AppliedDiscount     `protobuf:"bytes,10,rep,name=discounts,proto3" json:"discounts,omitempty"`
+	SurgeInfo          *SurgeInfo             `protobuf:"bytes,11,opt,name=surge_info,json=surgeInfo,proto3" json:"surge_info,omitempty"`
+	WaitingFare        float64                `protobuf:"fixed64,12,opt,name=waiting_fare,json=waitingFare,proto3" json:"waiting_fare,omitempty"`
+	WaitingTimeSeconds int32                  `protobuf:"varint,13,opt,name=waiting_time_seconds,json=waitingTimeSeconds,proto3" json:"waiting_time_seconds,omitempty"`
+	TaxLines           []*TaxLine             `protobuf:"bytes,14,rep,name=tax_lines,json=taxLines,proto3" json:"tax_lines,omitempty"` // itemizes taxes
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *PricingBreakdown) Reset() {
@@ -333,6 +346,96 @@ func (x *PricingBreakdown) GetSurgeInfo() *SurgeInfo {
 	return nil
 }
 
+func (x *PricingBreakdown) GetWaitingFare() float64 {
+	if x != nil {
+		return x.WaitingFare
+	}
+	return 0
+}
+
+func (x *PricingBreakdown) GetWaitingTimeSeconds() int32 {
+	if x != nil {
+		return x.WaitingTimeSeconds
+	}
+	return 0
+}
+
+func (x *PricingBreakdown) GetTaxLines() []*TaxLine {
+	if x != nil {
+		return x.TaxLines
+	}
+	return nil
+}
+
+// A tax or levy charged on a fare
+type TaxLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`   // "percentage", "fixed", "zone_levy"
+	Rate          float64                `protobuf:"fixed64,3,opt,name=rate,proto3" json:"rate,omitempty"` // percentage taxes, 0.18 for 18%
+	Amount        float64                `protobuf:"fixed64,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaxLine) Reset() {
+	*x = TaxLine{}
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TaxLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaxLine) ProtoMessage() {}
+
+func (x *TaxLine) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaxLine.ProtoReflect.Descriptor instead.
+func (*TaxLine) Descriptor() ([]byte, []int) {
+	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TaxLine) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TaxLine) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TaxLine) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *TaxLine) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
 // Applied discount information
 type AppliedDiscount struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -348,7 +451,7 @@ type AppliedDiscount struct {
 
 func (x *AppliedDiscount) Reset() {
 	*x = AppliedDiscount{}
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[3]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -360,7 +463,7 @@ func (x *AppliedDiscount) String() string {
 func (*AppliedDiscount) ProtoMessage() {}
 
 func (x *AppliedDiscount) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[3]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -373,7 +476,7 @@ func (x *AppliedDiscount) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AppliedDiscount.ProtoReflect.Descriptor instead.
 func (*AppliedDiscount) Descriptor() ([]byte, []int) {
-	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{3}
+	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *AppliedDiscount) GetId() string {
@@ -433,7 +536,7 @@ type SurgeInfo struct {
 
 func (x *SurgeInfo) Reset() {
 	*x = SurgeInfo{}
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[4]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -445,7 +548,7 @@ func (x *SurgeInfo) String() string {
 func (*SurgeInfo) ProtoMessage() {}
 
 func (x *SurgeInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[4]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -458,7 +561,7 @@ func (x *SurgeInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SurgeInfo.ProtoReflect.Descriptor instead.
 func (*SurgeInfo) Descriptor() ([]byte, []int) {
-	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{4}
+	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *SurgeInfo) GetIsActive() bool {
@@ -518,7 +621,7 @@ type PricingFactors struct {
 
 func (x *PricingFactors) Reset() {
 	*x = PricingFactors{}
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[5]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -530,7 +633,7 @@ func (x *PricingFactors) String() string {
 func (*PricingFactors) ProtoMessage() {}
 
 func (x *PricingFactors) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[5]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -543,7 +646,7 @@ func (x *PricingFactors) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PricingFactors.ProtoReflect.Descriptor instead.
 func (*PricingFactors) Descriptor() ([]byte, []int) {
-	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{5}
+	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *PricingFactors) GetDemandMultiplier() float64 {
@@ -603,7 +706,7 @@ type VehicleType struct {
 
 func (x *VehicleType) Reset() {
 	*x = VehicleType{}
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[6]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -615,7 +718,7 @@ func (x *VehicleType) String() string {
 func (*VehicleType) ProtoMessage() {}
 
 func (x *VehicleType) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[6]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -628,7 +731,7 @@ func (x *VehicleType) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VehicleType.ProtoReflect.Descriptor instead.
 func (*VehicleType) Descriptor() ([]byte, []int) {
-	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{6}
+	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *VehicleType) GetId() string {
@@ -689,7 +792,7 @@ type PricingRates struct {
 
 func (x *PricingRates) Reset() {
 	*x = PricingRates{}
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[7]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -701,7 +804,7 @@ func (x *PricingRates) String() string {
 func (*PricingRates) ProtoMessage() {}
 
 func (x *PricingRates) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[7]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -714,7 +817,7 @@ func (x *PricingRates) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PricingRates.ProtoReflect.Descriptor instead.
 func (*PricingRates) Descriptor() ([]byte, []int) {
-	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{7}
+	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *PricingRates) GetBaseFare() float64 {
@@ -775,13 +878,21 @@ type GetPriceEstimateRequest struct {
 	DepartureTime  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=departure_time,json=departureTime,proto3" json:"departure_time,omitempty"`
 	RiderId        string                 `protobuf:"bytes,5,opt,name=rider_id,json=riderId,proto3" json:"rider_id,omitempty"`
 	Options        map[string]string      `protobuf:"bytes,6,rep,name=options,proto3" json:"options,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// Estimated route, usually from geo-service. Without a distance the
+	// straight line between pickup and destination is used.
+	DistanceKm      float64 `protobuf:"fixed64,7,opt,name=distance_km,json=distanceKm,proto3" json:"distance_km,omitempty"`
+	DurationSeconds int32   `protobuf:"varint,8,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+	TripId          string  `protobuf:"bytes,9,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	PickupArea      string  `protobuf:"bytes,10,opt,name=pickup_area,json=pickupArea,proto3" json:"pickup_area,omitempty"` // area the current surge is looked up for
+	City            string  `protobuf:"bytes,11,opt,name=city,proto3" json:"city,omitempty"`                               // city the trip starts in, which sets the currency quoted in
+	QuoteId         string  `protobuf:"bytes,12,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"`          // re-quotes at the surge this quote locked, while it is valid
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *GetPriceEstimateRequest) Reset() {
 	*x = GetPriceEstimateRequest{}
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[8]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -793,7 +904,7 @@ func (x *GetPriceEstimateRequest) String() string {
 func (*GetPriceEstimateRequest) ProtoMessage() {}
 
 func (x *GetPriceEstimateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[8]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -806,7 +917,7 @@ func (x *GetPriceEstimateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPriceEstimateRequest.ProtoReflect.Descriptor instead.
 func (*GetPriceEstimateRequest) Descriptor() ([]byte, []int) {
-	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{8}
+	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *GetPriceEstimateRequest) GetPickupLocation() *Location {
@@ -851,6 +962,48 @@ func (x *GetPriceEstimateRequest) GetOptions() map[string]string {
 	return nil
 }
 
+func (x *GetPriceEstimateRequest) GetDistanceKm() float64 {
+	if x != nil {
+		return x.DistanceKm
+	}
+	return 0
+}
+
+func (x *GetPriceEstimateRequest) GetDurationSeconds() int32 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *GetPriceEstimateRequest) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *GetPriceEstimateRequest) GetPickupArea() string {
+	if x != nil {
+		return x.PickupArea
+	}
+	return ""
+}
+
+func (x *GetPriceEstimateRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *GetPriceEstimateRequest) GetQuoteId() string {
+	if x != nil {
+		return x.QuoteId
+	}
+	return ""
+}
+
 type GetPriceEstimateResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Estimate      *PriceEstimate         `protobuf:"bytes,1,opt,name=estimate,proto3" json:"estimate,omitempty"`
@@ -863,7 +1016,7 @@ type GetPriceEstimateResponse struct {
 
 func (x *GetPriceEstimateResponse) Reset() {
 	*x = GetPriceEstimateResponse{}
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[9]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -875,7 +1028,7 @@ func (x *GetPriceEstimateResponse) String() string {
 func (*GetPriceEstimateResponse) ProtoMessage() {}
 
 func (x *GetPriceEstimateResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[9]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -888,7 +1041,7 @@ func (x *GetPriceEstimateResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPriceEstimateResponse.ProtoReflect.Descriptor instead.
 func (*GetPriceEstimateResponse) Descriptor() ([]byte, []int) {
-	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{9}
+	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *GetPriceEstimateResponse) GetEstimate() *PriceEstimate {
@@ -932,7 +1085,7 @@ type GetMultipleEstimatesRequest struct {
 
 func (x *GetMultipleEstimatesRequest) Reset() {
 	*x = GetMultipleEstimatesRequest{}
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[10]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -944,7 +1097,7 @@ func (x *GetMultipleEstimatesRequest) String() string {
 func (*GetMultipleEstimatesRequest) ProtoMessage() {}
 
 func (x *GetMultipleEstimatesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[10]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -957,7 +1110,7 @@ func (x *GetMultipleEstimatesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMultipleEstimatesRequest.ProtoReflect.Descriptor instead.
 func (*GetMultipleEstimatesRequest) Descriptor() ([]byte, []int) {
-	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{10}
+	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GetMultipleEstimatesRequest) GetPickupLocation() *Location {
@@ -1006,7 +1159,7 @@ type GetMultipleEstimatesResponse struct {
 
 func (x *GetMultipleEstimatesResponse) Reset() {
 	*x = GetMultipleEstimatesResponse{}
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[11]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1018,7 +1171,7 @@ func (x *GetMultipleEstimatesResponse) String() string {
 func (*GetMultipleEstimatesResponse) ProtoMessage() {}
 
 func (x *GetMultipleEstimatesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[11]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1031,7 +1184,7 @@ func (x *GetMultipleEstimatesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMultipleEstimatesResponse.ProtoReflect.Descriptor instead.
 func (*GetMultipleEstimatesResponse) Descriptor() ([]byte, []int) {
-	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{11}
+	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GetMultipleEstimatesResponse) GetEstimates() []*PriceEstimate {
@@ -1066,13 +1219,25 @@ type CalculateFinalFareRequest struct {
 	TripStartTime         *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=trip_start_time,json=tripStartTime,proto3" json:"trip_start_time,omitempty"`
 	TripEndTime           *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=trip_end_time,json=tripEndTime,proto3" json:"trip_end_time,omitempty"`
 	Adjustments           map[string]string      `protobuf:"bytes,9,rep,name=adjustments,proto3" json:"adjustments,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields         protoimpl.UnknownFields
-	sizeCache             protoimpl.SizeCache
+	RiderId               string                 `protobuf:"bytes,10,opt,name=rider_id,json=riderId,proto3" json:"rider_id,omitempty"`
+	PickupArea            string                 `protobuf:"bytes,11,opt,name=pickup_area,json=pickupArea,proto3" json:"pickup_area,omitempty"`
+	// Surge quoted when the trip was requested. Zero prices at the current surge.
+	LockedSurgeMultiplier float64 `protobuf:"fixed64,12,opt,name=locked_surge_multiplier,json=lockedSurgeMultiplier,proto3" json:"locked_surge_multiplier,omitempty"`
+	// Time the driver waited at pickup, charged beyond the free waiting period
+	WaitingTimeSeconds int32  `protobuf:"varint,13,opt,name=waiting_time_seconds,json=waitingTimeSeconds,proto3" json:"waiting_time_seconds,omitempty"`
+	City               string `protobuf:"bytes,14,opt,name=city,proto3" json:"city,omitempty"`
+	// Currency the trip was quoted in. A fare in any other currency is rejected.
+	Currency string `protobuf:"bytes,15,opt,name=currency,proto3" json:"currency,omitempty"`
+	// Quote the trip was booked with; its locked surge takes precedence over
+	// locked_surge_multiplier
+	QuoteId       string `protobuf:"bytes,16,opt,name=quote_id,json=quoteId,proto3" json:"quote_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CalculateFinalFareRequest) Reset() {
 	*x = CalculateFinalFareRequest{}
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[12]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1084,7 +1249,7 @@ func (x *CalculateFinalFareRequest) String() string {
 func (*CalculateFinalFareRequest) ProtoMessage() {}
 
 func (x *CalculateFinalFareRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[12]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1097,7 +1262,7 @@ func (x *CalculateFinalFareRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CalculateFinalFareRequest.ProtoReflect.Descriptor instead.
 func (*CalculateFinalFareRequest) Descriptor() ([]byte, []int) {
-	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{12}
+	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *CalculateFinalFareRequest) GetTripId() string {
@@ -1163,6 +1328,55 @@ func (x *CalculateFinalFareRequest) GetAdjustments() map[string]string {
 	return nil
 }
 
+func (x *CalculateFinalFareRequest) GetRiderId() string {
+	if x != nil {
+		return x.RiderId
+	}
+	return ""
+}
+
+func (x *CalculateFinalFareRequest) GetPickupArea() string {
+	if x != nil {
+		return x.PickupArea
+	}
+	return ""
+}
+
+func (x *CalculateFinalFareRequest) GetLockedSurgeMultiplier() float64 {
+	if x != nil {
+		return x.LockedSurgeMultiplier
+	}
+	return 0
+}
+
+func (x *CalculateFinalFareRequest) GetWaitingTimeSeconds() int32 {
+	if x != nil {
+		return x.WaitingTimeSeconds
+	}
+	return 0
+}
+
+func (x *CalculateFinalFareRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *CalculateFinalFareRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *CalculateFinalFareRequest) GetQuoteId() string {
+	if x != nil {
+		return x.QuoteId
+	}
+	return ""
+}
+
 type CalculateFinalFareResponse struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	FinalFare        *PriceEstimate         `protobuf:"bytes,1,opt,name=final_fare,json=finalFare,proto3" json:"final_fare,omitempty"`
@@ -1176,7 +1390,7 @@ type CalculateFinalFareResponse struct {
 
 func (x *CalculateFinalFareResponse) Reset() {
 	*x = CalculateFinalFareResponse{}
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[13]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1188,7 +1402,7 @@ func (x *CalculateFinalFareResponse) String() string {
 func (*CalculateFinalFareResponse) ProtoMessage() {}
 
 func (x *CalculateFinalFareResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[13]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1201,7 +1415,7 @@ func (x *CalculateFinalFareResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CalculateFinalFareResponse.ProtoReflect.Descriptor instead.
 func (*CalculateFinalFareResponse) Descriptor() ([]byte, []int) {
-	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{13}
+	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *CalculateFinalFareResponse) GetFinalFare() *PriceEstimate {
@@ -1251,7 +1465,7 @@ type FareAdjustment struct {
 
 func (x *FareAdjustment) Reset() {
 	*x = FareAdjustment{}
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[14]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1263,7 +1477,7 @@ func (x *FareAdjustment) String() string {
 func (*FareAdjustment) ProtoMessage() {}
 
 func (x *FareAdjustment) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[14]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1276,7 +1490,7 @@ func (x *FareAdjustment) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FareAdjustment.ProtoReflect.Descriptor instead.
 func (*FareAdjustment) Descriptor() ([]byte, []int) {
-	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{14}
+	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *FareAdjustment) GetType() string {
@@ -1317,7 +1531,7 @@ type GetSurgePricingRequest struct {
 
 func (x *GetSurgePricingRequest) Reset() {
 	*x = GetSurgePricingRequest{}
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[15]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1329,7 +1543,7 @@ func (x *GetSurgePricingRequest) String() string {
 func (*GetSurgePricingRequest) ProtoMessage() {}
 
 func (x *GetSurgePricingRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[15]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1342,7 +1556,7 @@ func (x *GetSurgePricingRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSurgePricingRequest.ProtoReflect.Descriptor instead.
 func (*GetSurgePricingRequest) Descriptor() ([]byte, []int) {
-	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{15}
+	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *GetSurgePricingRequest) GetLocation() *Location {
@@ -1370,7 +1584,7 @@ type GetSurgePricingResponse struct {
 
 func (x *GetSurgePricingResponse) Reset() {
 	*x = GetSurgePricingResponse{}
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[16]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1382,7 +1596,7 @@ func (x *GetSurgePricingResponse) String() string {
 func (*GetSurgePricingResponse) ProtoMessage() {}
 
 func (x *GetSurgePricingResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[16]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1395,7 +1609,7 @@ func (x *GetSurgePricingResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSurgePricingResponse.ProtoReflect.Descriptor instead.
 func (*GetSurgePricingResponse) Descriptor() ([]byte, []int) {
-	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{16}
+	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *GetSurgePricingResponse) GetSurgeInfo() *SurgeInfo {
@@ -1419,28 +1633,228 @@ func (x *GetSurgePricingResponse) GetIsActive() bool {
 	return false
 }
 
-type GetVehicleTypesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Location      *Location              `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// Current surge in one area, as recorded by the demand monitor
+type AreaSurge struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Area             string                 `protobuf:"bytes,1,opt,name=area,proto3" json:"area,omitempty"`
+	Multiplier       float64                `protobuf:"fixed64,2,opt,name=multiplier,proto3" json:"multiplier,omitempty"`
+	DemandLevel      string                 `protobuf:"bytes,3,opt,name=demand_level,json=demandLevel,proto3" json:"demand_level,omitempty"`
+	ActiveRequests   int32                  `protobuf:"varint,4,opt,name=active_requests,json=activeRequests,proto3" json:"active_requests,omitempty"`
+	AvailableDrivers int32                  `protobuf:"varint,5,opt,name=available_drivers,json=availableDrivers,proto3" json:"available_drivers,omitempty"`
+	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ExpiresAt        *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	CityId           string                 `protobuf:"bytes,8,opt,name=city_id,json=cityId,proto3" json:"city_id,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
-func (x *GetVehicleTypesRequest) Reset() {
-	*x = GetVehicleTypesRequest{}
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[17]
+func (x *AreaSurge) Reset() {
+	*x = AreaSurge{}
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetVehicleTypesRequest) String() string {
+func (x *AreaSurge) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetVehicleTypesRequest) ProtoMessage() {}
+func (*AreaSurge) ProtoMessage() {}
 
-func (x *GetVehicleTypesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[17]
+func (x *AreaSurge) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AreaSurge.ProtoReflect.Descriptor instead.
+func (*AreaSurge) Descriptor() ([]byte, []int) {
+	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AreaSurge) GetArea() string {
+	if x != nil {
+		return x.Area
+	}
+	return ""
+}
+
+func (x *AreaSurge) GetMultiplier() float64 {
+	if x != nil {
+		return x.Multiplier
+	}
+	return 0
+}
+
+func (x *AreaSurge) GetDemandLevel() string {
+	if x != nil {
+		return x.DemandLevel
+	}
+	return ""
+}
+
+func (x *AreaSurge) GetActiveRequests() int32 {
+	if x != nil {
+		return x.ActiveRequests
+	}
+	return 0
+}
+
+func (x *AreaSurge) GetAvailableDrivers() int32 {
+	if x != nil {
+		return x.AvailableDrivers
+	}
+	return 0
+}
+
+func (x *AreaSurge) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *AreaSurge) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *AreaSurge) GetCityId() string {
+	if x != nil {
+		return x.CityId
+	}
+	return ""
+}
+
+type ListSurgeAreasRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only areas surging at or above this multiplier are listed; 0 lists all
+	MinMultiplier float64 `protobuf:"fixed64,1,opt,name=min_multiplier,json=minMultiplier,proto3" json:"min_multiplier,omitempty"`
+	// Only areas in this city are listed; empty lists every city
+	CityId        string `protobuf:"bytes,2,opt,name=city_id,json=cityId,proto3" json:"city_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSurgeAreasRequest) Reset() {
+	*x = ListSurgeAreasRequest{}
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSurgeAreasRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSurgeAreasRequest) ProtoMessage() {}
+
+func (x *ListSurgeAreasRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSurgeAreasRequest.ProtoReflect.Descriptor instead.
+func (*ListSurgeAreasRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListSurgeAreasRequest) GetMinMultiplier() float64 {
+	if x != nil {
+		return x.MinMultiplier
+	}
+	return 0
+}
+
+func (x *ListSurgeAreasRequest) GetCityId() string {
+	if x != nil {
+		return x.CityId
+	}
+	return ""
+}
+
+type ListSurgeAreasResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Highest multiplier first
+	Areas         []*AreaSurge `protobuf:"bytes,1,rep,name=areas,proto3" json:"areas,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSurgeAreasResponse) Reset() {
+	*x = ListSurgeAreasResponse{}
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSurgeAreasResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSurgeAreasResponse) ProtoMessage() {}
+
+func (x *ListSurgeAreasResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSurgeAreasResponse.ProtoReflect.Descriptor instead.
+func (*ListSurgeAreasResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListSurgeAreasResponse) GetAreas() []*AreaSurge {
+	if x != nil {
+		return x.Areas
+	}
+	return nil
+}
+
+type GetVehicleTypesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Location      *Location              `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVehicleTypesRequest) Reset() {
+	*x = GetVehicleTypesRequest{}
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVehicleTypesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVehicleTypesRequest) ProtoMessage() {}
+
+func (x *GetVehicleTypesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1453,7 +1867,7 @@ func (x *GetVehicleTypesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetVehicleTypesRequest.ProtoReflect.Descriptor instead.
 func (*GetVehicleTypesRequest) Descriptor() ([]byte, []int) {
-	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{17}
+	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *GetVehicleTypesRequest) GetLocation() *Location {
@@ -1473,7 +1887,7 @@ type GetVehicleTypesResponse struct {
 
 func (x *GetVehicleTypesResponse) Reset() {
 	*x = GetVehicleTypesResponse{}
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[18]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1485,7 +1899,7 @@ func (x *GetVehicleTypesResponse) String() string {
 func (*GetVehicleTypesResponse) ProtoMessage() {}
 
 func (x *GetVehicleTypesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[18]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1498,7 +1912,7 @@ func (x *GetVehicleTypesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetVehicleTypesResponse.ProtoReflect.Descriptor instead.
 func (*GetVehicleTypesResponse) Descriptor() ([]byte, []int) {
-	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{18}
+	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *GetVehicleTypesResponse) GetVehicleTypes() []*VehicleType {
@@ -1527,7 +1941,7 @@ type UpdateSurgePricingRequest struct {
 
 func (x *UpdateSurgePricingRequest) Reset() {
 	*x = UpdateSurgePricingRequest{}
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[19]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1539,7 +1953,7 @@ func (x *UpdateSurgePricingRequest) String() string {
 func (*UpdateSurgePricingRequest) ProtoMessage() {}
 
 func (x *UpdateSurgePricingRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[19]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1552,7 +1966,7 @@ func (x *UpdateSurgePricingRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateSurgePricingRequest.ProtoReflect.Descriptor instead.
 func (*UpdateSurgePricingRequest) Descriptor() ([]byte, []int) {
-	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{19}
+	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *UpdateSurgePricingRequest) GetZoneId() string {
@@ -1594,7 +2008,7 @@ type UpdateSurgePricingResponse struct {
 
 func (x *UpdateSurgePricingResponse) Reset() {
 	*x = UpdateSurgePricingResponse{}
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[20]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1606,7 +2020,7 @@ func (x *UpdateSurgePricingResponse) String() string {
 func (*UpdateSurgePricingResponse) ProtoMessage() {}
 
 func (x *UpdateSurgePricingResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[20]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1619,7 +2033,7 @@ func (x *UpdateSurgePricingResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateSurgePricingResponse.ProtoReflect.Descriptor instead.
 func (*UpdateSurgePricingResponse) Descriptor() ([]byte, []int) {
-	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{20}
+	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *UpdateSurgePricingResponse) GetSuccess() bool {
@@ -1654,7 +2068,7 @@ type GetPricingStatsRequest struct {
 
 func (x *GetPricingStatsRequest) Reset() {
 	*x = GetPricingStatsRequest{}
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[21]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1666,7 +2080,7 @@ func (x *GetPricingStatsRequest) String() string {
 func (*GetPricingStatsRequest) ProtoMessage() {}
 
 func (x *GetPricingStatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[21]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1679,7 +2093,7 @@ func (x *GetPricingStatsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPricingStatsRequest.ProtoReflect.Descriptor instead.
 func (*GetPricingStatsRequest) Descriptor() ([]byte, []int) {
-	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{21}
+	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *GetPricingStatsRequest) GetFromTime() *timestamppb.Timestamp {
@@ -1718,7 +2132,7 @@ type PricingStats struct {
 
 func (x *PricingStats) Reset() {
 	*x = PricingStats{}
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[22]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1730,7 +2144,7 @@ func (x *PricingStats) String() string {
 func (*PricingStats) ProtoMessage() {}
 
 func (x *PricingStats) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[22]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1743,7 +2157,7 @@ func (x *PricingStats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PricingStats.ProtoReflect.Descriptor instead.
 func (*PricingStats) Descriptor() ([]byte, []int) {
-	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{22}
+	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *PricingStats) GetAverageFare() float64 {
@@ -1805,7 +2219,7 @@ type GetPricingStatsResponse struct {
 
 func (x *GetPricingStatsResponse) Reset() {
 	*x = GetPricingStatsResponse{}
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[23]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1817,7 +2231,7 @@ func (x *GetPricingStatsResponse) String() string {
 func (*GetPricingStatsResponse) ProtoMessage() {}
 
 func (x *GetPricingStatsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[23]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1830,7 +2244,7 @@ func (x *GetPricingStatsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPricingStatsResponse.ProtoReflect.Descriptor instead.
 func (*GetPricingStatsResponse) Descriptor() ([]byte, []int) {
-	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{23}
+	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *GetPricingStatsResponse) GetStats() *PricingStats {
@@ -1862,7 +2276,7 @@ type PricingUpdateEvent struct {
 
 func (x *PricingUpdateEvent) Reset() {
 	*x = PricingUpdateEvent{}
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[24]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1874,7 +2288,7 @@ func (x *PricingUpdateEvent) String() string {
 func (*PricingUpdateEvent) ProtoMessage() {}
 
 func (x *PricingUpdateEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[24]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1887,7 +2301,7 @@ func (x *PricingUpdateEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PricingUpdateEvent.ProtoReflect.Descriptor instead.
 func (*PricingUpdateEvent) Descriptor() ([]byte, []int) {
-	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{24}
+	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *PricingUpdateEvent) GetZoneId() string {
@@ -1942,7 +2356,7 @@ type SubscribeToPricingUpdatesRequest struct {
 
 func (x *SubscribeToPricingUpdatesRequest) Reset() {
 	*x = SubscribeToPricingUpdatesRequest{}
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[25]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1954,7 +2368,7 @@ func (x *SubscribeToPricingUpdatesRequest) String() string {
 func (*SubscribeToPricingUpdatesRequest) ProtoMessage() {}
 
 func (x *SubscribeToPricingUpdatesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[25]
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1967,7 +2381,7 @@ func (x *SubscribeToPricingUpdatesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubscribeToPricingUpdatesRequest.ProtoReflect.Descriptor instead.
 func (*SubscribeToPricingUpdatesRequest) Descriptor() ([]byte, []int) {
-	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{25}
+	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *SubscribeToPricingUpdatesRequest) GetZoneIds() []string {
@@ -1984,6 +2398,319 @@ func (x *SubscribeToPricingUpdatesRequest) GetVehicleTypes() []string {
 	return nil
 }
 
+// Shared ride fare splitting
+type SharedFareRider struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	RiderId         string                 `protobuf:"bytes,1,opt,name=rider_id,json=riderId,proto3" json:"rider_id,omitempty"`
+	TripId          string                 `protobuf:"bytes,2,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	DistanceKm      float64                `protobuf:"fixed64,3,opt,name=distance_km,json=distanceKm,proto3" json:"distance_km,omitempty"`               // direct pickup-to-destination distance
+	DurationMinutes int32                  `protobuf:"varint,4,opt,name=duration_minutes,json=durationMinutes,proto3" json:"duration_minutes,omitempty"` // direct travel time
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SharedFareRider) Reset() {
+	*x = SharedFareRider{}
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SharedFareRider) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedFareRider) ProtoMessage() {}
+
+func (x *SharedFareRider) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedFareRider.ProtoReflect.Descriptor instead.
+func (*SharedFareRider) Descriptor() ([]byte, []int) {
+	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *SharedFareRider) GetRiderId() string {
+	if x != nil {
+		return x.RiderId
+	}
+	return ""
+}
+
+func (x *SharedFareRider) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *SharedFareRider) GetDistanceKm() float64 {
+	if x != nil {
+		return x.DistanceKm
+	}
+	return 0
+}
+
+func (x *SharedFareRider) GetDurationMinutes() int32 {
+	if x != nil {
+		return x.DurationMinutes
+	}
+	return 0
+}
+
+type SharedFareShare struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RiderId       string                 `protobuf:"bytes,1,opt,name=rider_id,json=riderId,proto3" json:"rider_id,omitempty"`
+	TripId        string                 `protobuf:"bytes,2,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	SoloFare      float64                `protobuf:"fixed64,3,opt,name=solo_fare,json=soloFare,proto3" json:"solo_fare,omitempty"`
+	SharedFare    float64                `protobuf:"fixed64,4,opt,name=shared_fare,json=sharedFare,proto3" json:"shared_fare,omitempty"`
+	Savings       float64                `protobuf:"fixed64,5,opt,name=savings,proto3" json:"savings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SharedFareShare) Reset() {
+	*x = SharedFareShare{}
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SharedFareShare) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedFareShare) ProtoMessage() {}
+
+func (x *SharedFareShare) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedFareShare.ProtoReflect.Descriptor instead.
+func (*SharedFareShare) Descriptor() ([]byte, []int) {
+	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *SharedFareShare) GetRiderId() string {
+	if x != nil {
+		return x.RiderId
+	}
+	return ""
+}
+
+func (x *SharedFareShare) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *SharedFareShare) GetSoloFare() float64 {
+	if x != nil {
+		return x.SoloFare
+	}
+	return 0
+}
+
+func (x *SharedFareShare) GetSharedFare() float64 {
+	if x != nil {
+		return x.SharedFare
+	}
+	return 0
+}
+
+func (x *SharedFareShare) GetSavings() float64 {
+	if x != nil {
+		return x.Savings
+	}
+	return 0
+}
+
+type SplitSharedFareRequest struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	VehicleType          string                 `protobuf:"bytes,1,opt,name=vehicle_type,json=vehicleType,proto3" json:"vehicle_type,omitempty"`
+	PickupArea           string                 `protobuf:"bytes,2,opt,name=pickup_area,json=pickupArea,proto3" json:"pickup_area,omitempty"`
+	RouteDistanceKm      float64                `protobuf:"fixed64,3,opt,name=route_distance_km,json=routeDistanceKm,proto3" json:"route_distance_km,omitempty"` // distance driven for the whole shared route
+	RouteDurationMinutes int32                  `protobuf:"varint,4,opt,name=route_duration_minutes,json=routeDurationMinutes,proto3" json:"route_duration_minutes,omitempty"`
+	Riders               []*SharedFareRider     `protobuf:"bytes,5,rep,name=riders,proto3" json:"riders,omitempty"`
+	City                 string                 `protobuf:"bytes,6,opt,name=city,proto3" json:"city,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *SplitSharedFareRequest) Reset() {
+	*x = SplitSharedFareRequest{}
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SplitSharedFareRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SplitSharedFareRequest) ProtoMessage() {}
+
+func (x *SplitSharedFareRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SplitSharedFareRequest.ProtoReflect.Descriptor instead.
+func (*SplitSharedFareRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SplitSharedFareRequest) GetVehicleType() string {
+	if x != nil {
+		return x.VehicleType
+	}
+	return ""
+}
+
+func (x *SplitSharedFareRequest) GetPickupArea() string {
+	if x != nil {
+		return x.PickupArea
+	}
+	return ""
+}
+
+func (x *SplitSharedFareRequest) GetRouteDistanceKm() float64 {
+	if x != nil {
+		return x.RouteDistanceKm
+	}
+	return 0
+}
+
+func (x *SplitSharedFareRequest) GetRouteDurationMinutes() int32 {
+	if x != nil {
+		return x.RouteDurationMinutes
+	}
+	return 0
+}
+
+func (x *SplitSharedFareRequest) GetRiders() []*SharedFareRider {
+	if x != nil {
+		return x.Riders
+	}
+	return nil
+}
+
+func (x *SplitSharedFareRequest) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+type SplitSharedFareResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Shares        []*SharedFareShare     `protobuf:"bytes,1,rep,name=shares,proto3" json:"shares,omitempty"`
+	RouteFare     float64                `protobuf:"fixed64,2,opt,name=route_fare,json=routeFare,proto3" json:"route_fare,omitempty"`
+	TotalFare     float64                `protobuf:"fixed64,3,opt,name=total_fare,json=totalFare,proto3" json:"total_fare,omitempty"`
+	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	Success       bool                   `protobuf:"varint,5,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SplitSharedFareResponse) Reset() {
+	*x = SplitSharedFareResponse{}
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SplitSharedFareResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SplitSharedFareResponse) ProtoMessage() {}
+
+func (x *SplitSharedFareResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_pricing_pricing_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SplitSharedFareResponse.ProtoReflect.Descriptor instead.
+func (*SplitSharedFareResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_pricing_pricing_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SplitSharedFareResponse) GetShares() []*SharedFareShare {
+	if x != nil {
+		return x.Shares
+	}
+	return nil
+}
+
+func (x *SplitSharedFareResponse) GetRouteFare() float64 {
+	if x != nil {
+		return x.RouteFare
+	}
+	return 0
+}
+
+func (x *SplitSharedFareResponse) GetTotalFare() float64 {
+	if x != nil {
+		return x.TotalFare
+	}
+	return 0
+}
+
+func (x *SplitSharedFareResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *SplitSharedFareResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SplitSharedFareResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 var File_shared_proto_pricing_pricing_proto protoreflect.FileDescriptor
 
 const file_shared_proto_pricing_pricing_proto_rawDesc = "" +
@@ -1992,7 +2719,7 @@ const file_shared_proto_pricing_pricing_proto_rawDesc = "" +
 	"\bLocation\x12\x1a\n" +
 	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
 	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\x12\x18\n" +
-	"\aaddress\x18\x03 \x01(\tR\aaddress\"\xaa\x03\n" +
+	"\aaddress\x18\x03 \x01(\tR\aaddress\"\xc5\x03\n" +
 	"\rPriceEstimate\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
 	"\tbase_fare\x18\x02 \x01(\x01R\bbaseFare\x12#\n" +
@@ -2006,7 +2733,8 @@ const file_shared_proto_pricing_pricing_proto_rawDesc = "" +
 	"\tbreakdown\x18\n" +
 	" \x01(\v2\x19.pricing.PricingBreakdownR\tbreakdown\x12;\n" +
 	"\vvalid_until\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\n" +
-	"validUntil\"\x9c\x03\n" +
+	"validUntil\x12\x19\n" +
+	"\bquote_id\x18\f \x01(\tR\aquoteId\"\xa0\x04\n" +
 	"\x10PricingBreakdown\x12\x1b\n" +
 	"\tbase_rate\x18\x01 \x01(\x01R\bbaseRate\x12\x1e\n" +
 	"\vper_km_rate\x18\x02 \x01(\x01R\tperKmRate\x12&\n" +
@@ -2023,7 +2751,15 @@ const file_shared_proto_pricing_pricing_proto_rawDesc = "" +
 	"\tdiscounts\x18\n" +
 	" \x03(\v2\x18.pricing.AppliedDiscountR\tdiscounts\x121\n" +
 	"\n" +
-	"surge_info\x18\v \x01(\v2\x12.pricing.SurgeInfoR\tsurgeInfo\"\xa4\x01\n" +
+	"surge_info\x18\v \x01(\v2\x12.pricing.SurgeInfoR\tsurgeInfo\x12!\n" +
+	"\fwaiting_fare\x18\f \x01(\x01R\vwaitingFare\x120\n" +
+	"\x14waiting_time_seconds\x18\r \x01(\x05R\x12waitingTimeSeconds\x12-\n" +
+	"\ttax_lines\x18\x0e \x03(\v2\x10.pricing.TaxLineR\btaxLines\"]\n" +
+	"\aTaxLine\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x12\n" +
+	"\x04rate\x18\x03 \x01(\x01R\x04rate\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x01R\x06amount\"\xa4\x01\n" +
 	"\x0fAppliedDiscount\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
@@ -2066,14 +2802,23 @@ const file_shared_proto_pricing_pricing_proto_rawDesc = "" +
 	"\fmaximum_fare\x18\x05 \x01(\x01R\vmaximumFare\x12\x1f\n" +
 	"\vbooking_fee\x18\x06 \x01(\x01R\n" +
 	"bookingFee\x12)\n" +
-	"\x10cancellation_fee\x18\a \x01(\x01R\x0fcancellationFee\"\x90\x03\n" +
+	"\x10cancellation_fee\x18\a \x01(\x01R\x0fcancellationFee\"\xc5\x04\n" +
 	"\x17GetPriceEstimateRequest\x12:\n" +
 	"\x0fpickup_location\x18\x01 \x01(\v2\x11.pricing.LocationR\x0epickupLocation\x123\n" +
 	"\vdestination\x18\x02 \x01(\v2\x11.pricing.LocationR\vdestination\x12!\n" +
 	"\fvehicle_type\x18\x03 \x01(\tR\vvehicleType\x12A\n" +
 	"\x0edeparture_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\rdepartureTime\x12\x19\n" +
 	"\brider_id\x18\x05 \x01(\tR\ariderId\x12G\n" +
-	"\aoptions\x18\x06 \x03(\v2-.pricing.GetPriceEstimateRequest.OptionsEntryR\aoptions\x1a:\n" +
+	"\aoptions\x18\x06 \x03(\v2-.pricing.GetPriceEstimateRequest.OptionsEntryR\aoptions\x12\x1f\n" +
+	"\vdistance_km\x18\a \x01(\x01R\n" +
+	"distanceKm\x12)\n" +
+	"\x10duration_seconds\x18\b \x01(\x05R\x0fdurationSeconds\x12\x17\n" +
+	"\atrip_id\x18\t \x01(\tR\x06tripId\x12\x1f\n" +
+	"\vpickup_area\x18\n" +
+	" \x01(\tR\n" +
+	"pickupArea\x12\x12\n" +
+	"\x04city\x18\v \x01(\tR\x04city\x12\x19\n" +
+	"\bquote_id\x18\f \x01(\tR\aquoteId\x1a:\n" +
 	"\fOptionsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x9a\x01\n" +
@@ -2091,7 +2836,7 @@ const file_shared_proto_pricing_pricing_proto_rawDesc = "" +
 	"\x1cGetMultipleEstimatesResponse\x124\n" +
 	"\testimates\x18\x01 \x03(\v2\x16.pricing.PriceEstimateR\testimates\x12\x18\n" +
 	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage\"\xd2\x04\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\xc3\x06\n" +
 	"\x19CalculateFinalFareRequest\x12\x17\n" +
 	"\atrip_id\x18\x01 \x01(\tR\x06tripId\x126\n" +
 	"\ractual_pickup\x18\x02 \x01(\v2\x11.pricing.LocationR\factualPickup\x12@\n" +
@@ -2101,7 +2846,16 @@ const file_shared_proto_pricing_pricing_proto_rawDesc = "" +
 	"\fvehicle_type\x18\x06 \x01(\tR\vvehicleType\x12B\n" +
 	"\x0ftrip_start_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\rtripStartTime\x12>\n" +
 	"\rtrip_end_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vtripEndTime\x12U\n" +
-	"\vadjustments\x18\t \x03(\v23.pricing.CalculateFinalFareRequest.AdjustmentsEntryR\vadjustments\x1a>\n" +
+	"\vadjustments\x18\t \x03(\v23.pricing.CalculateFinalFareRequest.AdjustmentsEntryR\vadjustments\x12\x19\n" +
+	"\brider_id\x18\n" +
+	" \x01(\tR\ariderId\x12\x1f\n" +
+	"\vpickup_area\x18\v \x01(\tR\n" +
+	"pickupArea\x126\n" +
+	"\x17locked_surge_multiplier\x18\f \x01(\x01R\x15lockedSurgeMultiplier\x120\n" +
+	"\x14waiting_time_seconds\x18\r \x01(\x05R\x12waitingTimeSeconds\x12\x12\n" +
+	"\x04city\x18\x0e \x01(\tR\x04city\x12\x1a\n" +
+	"\bcurrency\x18\x0f \x01(\tR\bcurrency\x12\x19\n" +
+	"\bquote_id\x18\x10 \x01(\tR\aquoteId\x1a>\n" +
 	"\x10AdjustmentsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x87\x02\n" +
@@ -2124,7 +2878,25 @@ const file_shared_proto_pricing_pricing_proto_rawDesc = "" +
 	"\n" +
 	"surge_info\x18\x01 \x01(\v2\x12.pricing.SurgeInfoR\tsurgeInfo\x12-\n" +
 	"\x12current_multiplier\x18\x02 \x01(\x01R\x11currentMultiplier\x12\x1b\n" +
-	"\tis_active\x18\x03 \x01(\bR\bisActive\"G\n" +
+	"\tis_active\x18\x03 \x01(\bR\bisActive\"\xc7\x02\n" +
+	"\tAreaSurge\x12\x12\n" +
+	"\x04area\x18\x01 \x01(\tR\x04area\x12\x1e\n" +
+	"\n" +
+	"multiplier\x18\x02 \x01(\x01R\n" +
+	"multiplier\x12!\n" +
+	"\fdemand_level\x18\x03 \x01(\tR\vdemandLevel\x12'\n" +
+	"\x0factive_requests\x18\x04 \x01(\x05R\x0eactiveRequests\x12+\n" +
+	"\x11available_drivers\x18\x05 \x01(\x05R\x10availableDrivers\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x17\n" +
+	"\acity_id\x18\b \x01(\tR\x06cityId\"W\n" +
+	"\x15ListSurgeAreasRequest\x12%\n" +
+	"\x0emin_multiplier\x18\x01 \x01(\x01R\rminMultiplier\x12\x17\n" +
+	"\acity_id\x18\x02 \x01(\tR\x06cityId\"B\n" +
+	"\x16ListSurgeAreasResponse\x12(\n" +
+	"\x05areas\x18\x01 \x03(\v2\x12.pricing.AreaSurgeR\x05areas\"G\n" +
 	"\x16GetVehicleTypesRequest\x12-\n" +
 	"\blocation\x18\x01 \x01(\v2\x11.pricing.LocationR\blocation\"j\n" +
 	"\x17GetVehicleTypesResponse\x129\n" +
@@ -2172,15 +2944,47 @@ const file_shared_proto_pricing_pricing_proto_rawDesc = "" +
 	"\x06reason\x18\x06 \x01(\tR\x06reason\"b\n" +
 	" SubscribeToPricingUpdatesRequest\x12\x19\n" +
 	"\bzone_ids\x18\x01 \x03(\tR\azoneIds\x12#\n" +
-	"\rvehicle_types\x18\x02 \x03(\tR\fvehicleTypes2\xf5\x05\n" +
+	"\rvehicle_types\x18\x02 \x03(\tR\fvehicleTypes\"\x91\x01\n" +
+	"\x0fSharedFareRider\x12\x19\n" +
+	"\brider_id\x18\x01 \x01(\tR\ariderId\x12\x17\n" +
+	"\atrip_id\x18\x02 \x01(\tR\x06tripId\x12\x1f\n" +
+	"\vdistance_km\x18\x03 \x01(\x01R\n" +
+	"distanceKm\x12)\n" +
+	"\x10duration_minutes\x18\x04 \x01(\x05R\x0fdurationMinutes\"\x9d\x01\n" +
+	"\x0fSharedFareShare\x12\x19\n" +
+	"\brider_id\x18\x01 \x01(\tR\ariderId\x12\x17\n" +
+	"\atrip_id\x18\x02 \x01(\tR\x06tripId\x12\x1b\n" +
+	"\tsolo_fare\x18\x03 \x01(\x01R\bsoloFare\x12\x1f\n" +
+	"\vshared_fare\x18\x04 \x01(\x01R\n" +
+	"sharedFare\x12\x18\n" +
+	"\asavings\x18\x05 \x01(\x01R\asavings\"\x84\x02\n" +
+	"\x16SplitSharedFareRequest\x12!\n" +
+	"\fvehicle_type\x18\x01 \x01(\tR\vvehicleType\x12\x1f\n" +
+	"\vpickup_area\x18\x02 \x01(\tR\n" +
+	"pickupArea\x12*\n" +
+	"\x11route_distance_km\x18\x03 \x01(\x01R\x0frouteDistanceKm\x124\n" +
+	"\x16route_duration_minutes\x18\x04 \x01(\x05R\x14routeDurationMinutes\x120\n" +
+	"\x06riders\x18\x05 \x03(\v2\x18.pricing.SharedFareRiderR\x06riders\x12\x12\n" +
+	"\x04city\x18\x06 \x01(\tR\x04city\"\xd9\x01\n" +
+	"\x17SplitSharedFareResponse\x120\n" +
+	"\x06shares\x18\x01 \x03(\v2\x18.pricing.SharedFareShareR\x06shares\x12\x1d\n" +
+	"\n" +
+	"route_fare\x18\x02 \x01(\x01R\trouteFare\x12\x1d\n" +
+	"\n" +
+	"total_fare\x18\x03 \x01(\x01R\ttotalFare\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12\x18\n" +
+	"\asuccess\x18\x05 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x06 \x01(\tR\amessage2\x9e\a\n" +
 	"\x0ePricingService\x12W\n" +
 	"\x10GetPriceEstimate\x12 .pricing.GetPriceEstimateRequest\x1a!.pricing.GetPriceEstimateResponse\x12c\n" +
 	"\x14GetMultipleEstimates\x12$.pricing.GetMultipleEstimatesRequest\x1a%.pricing.GetMultipleEstimatesResponse\x12]\n" +
 	"\x12CalculateFinalFare\x12\".pricing.CalculateFinalFareRequest\x1a#.pricing.CalculateFinalFareResponse\x12T\n" +
 	"\x0fGetSurgePricing\x12\x1f.pricing.GetSurgePricingRequest\x1a .pricing.GetSurgePricingResponse\x12T\n" +
 	"\x0fGetVehicleTypes\x12\x1f.pricing.GetVehicleTypesRequest\x1a .pricing.GetVehicleTypesResponse\x12]\n" +
-	"\x12UpdateSurgePricing\x12\".pricing.UpdateSurgePricingRequest\x1a#.pricing.UpdateSurgePricingResponse\x12T\n" +
-	"\x0fGetPricingStats\x12\x1f.pricing.GetPricingStatsRequest\x1a .pricing.GetPricingStatsResponse\x12e\n" +
+	"\x12UpdateSurgePricing\x12\".pricing.UpdateSurgePricingRequest\x1a#.pricing.UpdateSurgePricingResponse\x12Q\n" +
+	"\x0eListSurgeAreas\x12\x1e.pricing.ListSurgeAreasRequest\x1a\x1f.pricing.ListSurgeAreasResponse\x12T\n" +
+	"\x0fGetPricingStats\x12\x1f.pricing.GetPricingStatsRequest\x1a .pricing.GetPricingStatsResponse\x12T\n" +
+	"\x0fSplitSharedFare\x12\x1f.pricing.SplitSharedFareRequest\x1a .pricing.SplitSharedFareResponse\x12e\n" +
 	"\x19SubscribeToPricingUpdates\x12).pricing.SubscribeToPricingUpdatesRequest\x1a\x1b.pricing.PricingUpdateEvent0\x01B4Z2github.com/rideshare-platform/shared/proto/pricingb\x06proto3"
 
 var (
@@ -2195,99 +2999,117 @@ func file_shared_proto_pricing_pricing_proto_rawDescGZIP() []byte {
 	return file_shared_proto_pricing_pricing_proto_rawDescData
 }
 
-var file_shared_proto_pricing_pricing_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
+var file_shared_proto_pricing_pricing_proto_msgTypes = make([]protoimpl.MessageInfo, 39)
 var file_shared_proto_pricing_pricing_proto_goTypes = []any{
 	(*Location)(nil),                         // 0: pricing.Location
 	(*PriceEstimate)(nil),                    // 1: pricing.PriceEstimate
 	(*PricingBreakdown)(nil),                 // 2: pricing.PricingBreakdown
-	(*AppliedDiscount)(nil),                  // 3: pricing.AppliedDiscount
-	(*SurgeInfo)(nil),                        // 4: pricing.SurgeInfo
-	(*PricingFactors)(nil),                   // 5: pricing.PricingFactors
-	(*VehicleType)(nil),                      // 6: pricing.VehicleType
-	(*PricingRates)(nil),                     // 7: pricing.PricingRates
-	(*GetPriceEstimateRequest)(nil),          // 8: pricing.GetPriceEstimateRequest
-	(*GetPriceEstimateResponse)(nil),         // 9: pricing.GetPriceEstimateResponse
-	(*GetMultipleEstimatesRequest)(nil),      // 10: pricing.GetMultipleEstimatesRequest
-	(*GetMultipleEstimatesResponse)(nil),     // 11: pricing.GetMultipleEstimatesResponse
-	(*CalculateFinalFareRequest)(nil),        // 12: pricing.CalculateFinalFareRequest
-	(*CalculateFinalFareResponse)(nil),       // 13: pricing.CalculateFinalFareResponse
-	(*FareAdjustment)(nil),                   // 14: pricing.FareAdjustment
-	(*GetSurgePricingRequest)(nil),           // 15: pricing.GetSurgePricingRequest
-	(*GetSurgePricingResponse)(nil),          // 16: pricing.GetSurgePricingResponse
-	(*GetVehicleTypesRequest)(nil),           // 17: pricing.GetVehicleTypesRequest
-	(*GetVehicleTypesResponse)(nil),          // 18: pricing.GetVehicleTypesResponse
-	(*UpdateSurgePricingRequest)(nil),        // 19: pricing.UpdateSurgePricingRequest
-	(*UpdateSurgePricingResponse)(nil),       // 20: pricing.UpdateSurgePricingResponse
-	(*GetPricingStatsRequest)(nil),           // 21: pricing.GetPricingStatsRequest
-	(*PricingStats)(nil),                     // 22: pricing.PricingStats
-	(*GetPricingStatsResponse)(nil),          // 23: pricing.GetPricingStatsResponse
-	(*PricingUpdateEvent)(nil),               // 24: pricing.PricingUpdateEvent
-	(*SubscribeToPricingUpdatesRequest)(nil), // 25: pricing.SubscribeToPricingUpdatesRequest
-	nil,                                      // 26: pricing.PricingFactors.CustomFactorsEntry
-	nil,                                      // 27: pricing.GetPriceEstimateRequest.OptionsEntry
-	nil,                                      // 28: pricing.CalculateFinalFareRequest.AdjustmentsEntry
-	nil,                                      // 29: pricing.PricingStats.VehicleTypeAveragesEntry
-	nil,                                      // 30: pricing.PricingStats.DiscountUsageEntry
-	(*timestamppb.Timestamp)(nil),            // 31: google.protobuf.Timestamp
+	(*TaxLine)(nil),                          // 3: pricing.TaxLine
+	(*AppliedDiscount)(nil),                  // 4: pricing.AppliedDiscount
+	(*SurgeInfo)(nil),                        // 5: pricing.SurgeInfo
+	(*PricingFactors)(nil),                   // 6: pricing.PricingFactors
+	(*VehicleType)(nil),                      // 7: pricing.VehicleType
+	(*PricingRates)(nil),                     // 8: pricing.PricingRates
+	(*GetPriceEstimateRequest)(nil),          // 9: pricing.GetPriceEstimateRequest
+	(*GetPriceEstimateResponse)(nil),         // 10: pricing.GetPriceEstimateResponse
+	(*GetMultipleEstimatesRequest)(nil),      // 11: pricing.GetMultipleEstimatesRequest
+	(*GetMultipleEstimatesResponse)(nil),     // 12: pricing.GetMultipleEstimatesResponse
+	(*CalculateFinalFareRequest)(nil),        // 13: pricing.CalculateFinalFareRequest
+	(*CalculateFinalFareResponse)(nil),       // 14: pricing.CalculateFinalFareResponse
+	(*FareAdjustment)(nil),                   // 15: pricing.FareAdjustment
+	(*GetSurgePricingRequest)(nil),           // 16: pricing.GetSurgePricingRequest
+	(*GetSurgePricingResponse)(nil),          // 17: pricing.GetSurgePricingResponse
+	(*AreaSurge)(nil),                        // 18: pricing.AreaSurge
+	(*ListSurgeAreasRequest)(nil),            // 19: pricing.ListSurgeAreasRequest
+	(*ListSurgeAreasResponse)(nil),           // 20: pricing.ListSurgeAreasResponse
+	(*GetVehicleTypesRequest)(nil),           // 21: pricing.GetVehicleTypesRequest
+	(*GetVehicleTypesResponse)(nil),          // 22: pricing.GetVehicleTypesResponse
+	(*UpdateSurgePricingRequest)(nil),        // 23: pricing.UpdateSurgePricingRequest
+	(*UpdateSurgePricingResponse)(nil),       // 24: pricing.UpdateSurgePricingResponse
+	(*GetPricingStatsRequest)(nil),           // 25: pricing.GetPricingStatsRequest
+	(*PricingStats)(nil),                     // 26: pricing.PricingStats
+	(*GetPricingStatsResponse)(nil),          // 27: pricing.GetPricingStatsResponse
+	(*PricingUpdateEvent)(nil),               // 28: pricing.PricingUpdateEvent
+	(*SubscribeToPricingUpdatesRequest)(nil), // 29: pricing.SubscribeToPricingUpdatesRequest
+	(*SharedFareRider)(nil),                  // 30: pricing.SharedFareRider
+	(*SharedFareShare)(nil),                  // 31: pricing.SharedFareShare
+	(*SplitSharedFareRequest)(nil),           // 32: pricing.SplitSharedFareRequest
+	(*SplitSharedFareResponse)(nil),          // 33: pricing.SplitSharedFareResponse
+	nil,                                      // 34: pricing.PricingFactors.CustomFactorsEntry
+	nil,                                      // 35: pricing.GetPriceEstimateRequest.OptionsEntry
+	nil,                                      // 36: pricing.CalculateFinalFareRequest.AdjustmentsEntry
+	nil,                                      // 37: pricing.PricingStats.VehicleTypeAveragesEntry
+	nil,                                      // 38: pricing.PricingStats.DiscountUsageEntry
+	(*timestamppb.Timestamp)(nil),            // 39: google.protobuf.Timestamp
 }
 var file_shared_proto_pricing_pricing_proto_depIdxs = []int32{
 	2,  // 0: pricing.PriceEstimate.breakdown:type_name -> pricing.PricingBreakdown
-	31, // 1: pricing.PriceEstimate.valid_until:type_name -> google.protobuf.Timestamp
-	3,  // 2: pricing.PricingBreakdown.discounts:type_name -> pricing.AppliedDiscount
-	4,  // 3: pricing.PricingBreakdown.surge_info:type_name -> pricing.SurgeInfo
-	31, // 4: pricing.SurgeInfo.started_at:type_name -> google.protobuf.Timestamp
-	31, // 5: pricing.SurgeInfo.ends_at:type_name -> google.protobuf.Timestamp
-	26, // 6: pricing.PricingFactors.custom_factors:type_name -> pricing.PricingFactors.CustomFactorsEntry
-	7,  // 7: pricing.VehicleType.rates:type_name -> pricing.PricingRates
-	0,  // 8: pricing.GetPriceEstimateRequest.pickup_location:type_name -> pricing.Location
-	0,  // 9: pricing.GetPriceEstimateRequest.destination:type_name -> pricing.Location
-	31, // 10: pricing.GetPriceEstimateRequest.departure_time:type_name -> google.protobuf.Timestamp
-	27, // 11: pricing.GetPriceEstimateRequest.options:type_name -> pricing.GetPriceEstimateRequest.OptionsEntry
-	1,  // 12: pricing.GetPriceEstimateResponse.estimate:type_name -> pricing.PriceEstimate
-	0,  // 13: pricing.GetMultipleEstimatesRequest.pickup_location:type_name -> pricing.Location
-	0,  // 14: pricing.GetMultipleEstimatesRequest.destination:type_name -> pricing.Location
-	31, // 15: pricing.GetMultipleEstimatesRequest.departure_time:type_name -> google.protobuf.Timestamp
-	1,  // 16: pricing.GetMultipleEstimatesResponse.estimates:type_name -> pricing.PriceEstimate
-	0,  // 17: pricing.CalculateFinalFareRequest.actual_pickup:type_name -> pricing.Location
-	0,  // 18: pricing.CalculateFinalFareRequest.actual_destination:type_name -> pricing.Location
-	31, // 19: pricing.CalculateFinalFareRequest.trip_start_time:type_name -> google.protobuf.Timestamp
-	31, // 20: pricing.CalculateFinalFareRequest.trip_end_time:type_name -> google.protobuf.Timestamp
-	28, // 21: pricing.CalculateFinalFareRequest.adjustments:type_name -> pricing.CalculateFinalFareRequest.AdjustmentsEntry
-	1,  // 22: pricing.CalculateFinalFareResponse.final_fare:type_name -> pricing.PriceEstimate
-	1,  // 23: pricing.CalculateFinalFareResponse.original_estimate:type_name -> pricing.PriceEstimate
-	14, // 24: pricing.CalculateFinalFareResponse.adjustments:type_name -> pricing.FareAdjustment
-	0,  // 25: pricing.GetSurgePricingRequest.location:type_name -> pricing.Location
-	4,  // 26: pricing.GetSurgePricingResponse.surge_info:type_name -> pricing.SurgeInfo
-	0,  // 27: pricing.GetVehicleTypesRequest.location:type_name -> pricing.Location
-	6,  // 28: pricing.GetVehicleTypesResponse.vehicle_types:type_name -> pricing.VehicleType
-	4,  // 29: pricing.UpdateSurgePricingResponse.updated_surge:type_name -> pricing.SurgeInfo
-	31, // 30: pricing.GetPricingStatsRequest.from_time:type_name -> google.protobuf.Timestamp
-	31, // 31: pricing.GetPricingStatsRequest.to_time:type_name -> google.protobuf.Timestamp
-	29, // 32: pricing.PricingStats.vehicle_type_averages:type_name -> pricing.PricingStats.VehicleTypeAveragesEntry
-	30, // 33: pricing.PricingStats.discount_usage:type_name -> pricing.PricingStats.DiscountUsageEntry
-	22, // 34: pricing.GetPricingStatsResponse.stats:type_name -> pricing.PricingStats
-	31, // 35: pricing.PricingUpdateEvent.timestamp:type_name -> google.protobuf.Timestamp
-	8,  // 36: pricing.PricingService.GetPriceEstimate:input_type -> pricing.GetPriceEstimateRequest
-	10, // 37: pricing.PricingService.GetMultipleEstimates:input_type -> pricing.GetMultipleEstimatesRequest
-	12, // 38: pricing.PricingService.CalculateFinalFare:input_type -> pricing.CalculateFinalFareRequest
-	15, // 39: pricing.PricingService.GetSurgePricing:input_type -> pricing.GetSurgePricingRequest
-	17, // 40: pricing.PricingService.GetVehicleTypes:input_type -> pricing.GetVehicleTypesRequest
-	19, // 41: pricing.PricingService.UpdateSurgePricing:input_type -> pricing.UpdateSurgePricingRequest
-	21, // 42: pricing.PricingService.GetPricingStats:input_type -> pricing.GetPricingStatsRequest
-	25, // 43: pricing.PricingService.SubscribeToPricingUpdates:input_type -> pricing.SubscribeToPricingUpdatesRequest
-	9,  // 44: pricing.PricingService.GetPriceEstimate:output_type -> pricing.GetPriceEstimateResponse
-	11, // 45: pricing.PricingService.GetMultipleEstimates:output_type -> pricing.GetMultipleEstimatesResponse
-	13, // 46: pricing.PricingService.CalculateFinalFare:output_type -> pricing.CalculateFinalFareResponse
-	16, // 47: pricing.PricingService.GetSurgePricing:output_type -> pricing.GetSurgePricingResponse
-	18, // 48: pricing.PricingService.GetVehicleTypes:output_type -> pricing.GetVehicleTypesResponse
-	20, // 49: pricing.PricingService.UpdateSurgePricing:output_type -> pricing.UpdateSurgePricingResponse
-	23, // 50: pricing.PricingService.GetPricingStats:output_type -> pricing.GetPricingStatsResponse
-	24, // 51: pricing.PricingService.SubscribeToPricingUpdates:output_type -> pricing.PricingUpdateEvent
-	44, // [44:52] is the sub-list for method output_type
-	36, // [36:44] is the sub-list for method input_type
-	36, // [36:36] is the sub-list for extension type_name
-	36, // [36:36] is the sub-list for extension extendee
-	0,  // [0:36] is the sub-list for field type_name
+	39, // 1: pricing.PriceEstimate.valid_until:type_name -> google.protobuf.Timestamp
+	4,  // 2: pricing.PricingBreakdown.discounts:type_name -> pricing.AppliedDiscount
+	5,  // 3: pricing.PricingBreakdown.surge_info:type_name -> pricing.SurgeInfo
+	3,  // 4: pricing.PricingBreakdown.tax_lines:type_name -> pricing.TaxLine
+	39, // 5: pricing.SurgeInfo.started_at:type_name -> google.protobuf.Timestamp
+	39, // 6: pricing.SurgeInfo.ends_at:type_name -> google.protobuf.Timestamp
+	34, // 7: pricing.PricingFactors.custom_factors:type_name -> pricing.PricingFactors.CustomFactorsEntry
+	8,  // 8: pricing.VehicleType.rates:type_name -> pricing.PricingRates
+	0,  // 9: pricing.GetPriceEstimateRequest.pickup_location:type_name -> pricing.Location
+	0,  // 10: pricing.GetPriceEstimateRequest.destination:type_name -> pricing.Location
+	39, // 11: pricing.GetPriceEstimateRequest.departure_time:type_name -> google.protobuf.Timestamp
+	35, // 12: pricing.GetPriceEstimateRequest.options:type_name -> pricing.GetPriceEstimateRequest.OptionsEntry
+	1,  // 13: pricing.GetPriceEstimateResponse.estimate:type_name -> pricing.PriceEstimate
+	0,  // 14: pricing.GetMultipleEstimatesRequest.pickup_location:type_name -> pricing.Location
+	0,  // 15: pricing.GetMultipleEstimatesRequest.destination:type_name -> pricing.Location
+	39, // 16: pricing.GetMultipleEstimatesRequest.departure_time:type_name -> google.protobuf.Timestamp
+	1,  // 17: pricing.GetMultipleEstimatesResponse.estimates:type_name -> pricing.PriceEstimate
+	0,  // 18: pricing.CalculateFinalFareRequest.actual_pickup:type_name -> pricing.Location
+	0,  // 19: pricing.CalculateFinalFareRequest.actual_destination:type_name -> pricing.Location
+	39, // 20: pricing.CalculateFinalFareRequest.trip_start_time:type_name -> google.protobuf.Timestamp
+	39, // 21: pricing.CalculateFinalFareRequest.trip_end_time:type_name -> google.protobuf.Timestamp
+	36, // 22: pricing.CalculateFinalFareRequest.adjustments:type_name -> pricing.CalculateFinalFareRequest.AdjustmentsEntry
+	1,  // 23: pricing.CalculateFinalFareResponse.final_fare:type_name -> pricing.PriceEstimate
+	1,  // 24: pricing.CalculateFinalFareResponse.original_estimate:type_name -> pricing.PriceEstimate
+	15, // 25: pricing.CalculateFinalFareResponse.adjustments:type_name -> pricing.FareAdjustment
+	0,  // 26: pricing.GetSurgePricingRequest.location:type_name -> pricing.Location
+	5,  // 27: pricing.GetSurgePricingResponse.surge_info:type_name -> pricing.SurgeInfo
+	39, // 28: pricing.AreaSurge.updated_at:type_name -> google.protobuf.Timestamp
+	39, // 29: pricing.AreaSurge.expires_at:type_name -> google.protobuf.Timestamp
+	18, // 30: pricing.ListSurgeAreasResponse.areas:type_name -> pricing.AreaSurge
+	0,  // 31: pricing.GetVehicleTypesRequest.location:type_name -> pricing.Location
+	7,  // 32: pricing.GetVehicleTypesResponse.vehicle_types:type_name -> pricing.VehicleType
+	5,  // 33: pricing.UpdateSurgePricingResponse.updated_surge:type_name -> pricing.SurgeInfo
+	39, // 34: pricing.GetPricingStatsRequest.from_time:type_name -> google.protobuf.Timestamp
+	39, // 35: pricing.GetPricingStatsRequest.to_time:type_name -> google.protobuf.Timestamp
+	37, // 36: pricing.PricingStats.vehicle_type_averages:type_name -> pricing.PricingStats.VehicleTypeAveragesEntry
+	38, // 37: pricing.PricingStats.discount_usage:type_name -> pricing.PricingStats.DiscountUsageEntry
+	26, // 38: pricing.GetPricingStatsResponse.stats:type_name -> pricing.PricingStats
+	39, // 39: pricing.PricingUpdateEvent.timestamp:type_name -> google.protobuf.Timestamp
+	30, // 40: pricing.SplitSharedFareRequest.riders:type_name -> pricing.SharedFareRider
+	31, // 41: pricing.SplitSharedFareResponse.shares:type_name -> pricing.SharedFareShare
+	9,  // 42: pricing.PricingService.GetPriceEstimate:input_type -> pricing.GetPriceEstimateRequest
+	11, // 43: pricing.PricingService.GetMultipleEstimates:input_type -> pricing.GetMultipleEstimatesRequest
+	13, // 44: pricing.PricingService.CalculateFinalFare:input_type -> pricing.CalculateFinalFareRequest
+	16, // 45: pricing.PricingService.GetSurgePricing:input_type -> pricing.GetSurgePricingRequest
+	21, // 46: pricing.PricingService.GetVehicleTypes:input_type -> pricing.GetVehicleTypesRequest
+	23, // 47: pricing.PricingService.UpdateSurgePricing:input_type -> pricing.UpdateSurgePricingRequest
+	19, // 48: pricing.PricingService.ListSurgeAreas:input_type -> pricing.ListSurgeAreasRequest
+	25, // 49: pricing.PricingService.GetPricingStats:input_type -> pricing.GetPricingStatsRequest
+	32, // 50: pricing.PricingService.SplitSharedFare:input_type -> pricing.SplitSharedFareRequest
+	29, // 51: pricing.PricingService.SubscribeToPricingUpdates:input_type -> pricing.SubscribeToPricingUpdatesRequest
+	10, // 52: pricing.PricingService.GetPriceEstimate:output_type -> pricing.GetPriceEstimateResponse
+	12, // 53: pricing.PricingService.GetMultipleEstimates:output_type -> pricing.GetMultipleEstimatesResponse
+	14, // 54: pricing.PricingService.CalculateFinalFare:output_type -> pricing.CalculateFinalFareResponse
+	17, // 55: pricing.PricingService.GetSurgePricing:output_type -> pricing.GetSurgePricingResponse
+	22, // 56: pricing.PricingService.GetVehicleTypes:output_type -> pricing.GetVehicleTypesResponse
+	24, // 57: pricing.PricingService.UpdateSurgePricing:output_type -> pricing.UpdateSurgePricingResponse
+	20, // 58: pricing.PricingService.ListSurgeAreas:output_type -> pricing.ListSurgeAreasResponse
+	27, // 59: pricing.PricingService.GetPricingStats:output_type -> pricing.GetPricingStatsResponse
+	33, // 60: pricing.PricingService.SplitSharedFare:output_type -> pricing.SplitSharedFareResponse
+	28, // 61: pricing.PricingService.SubscribeToPricingUpdates:output_type -> pricing.PricingUpdateEvent
+	52, // [52:62] is the sub-list for method output_type
+	42, // [42:52] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
 }
 
 func init() { file_shared_proto_pricing_pricing_proto_init() }
@@ -2301,7 +3123,7 @@ func file_shared_proto_pricing_pricing_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_shared_proto_pricing_pricing_proto_rawDesc), len(file_shared_proto_pricing_pricing_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   31,
+			NumMessages:   39,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
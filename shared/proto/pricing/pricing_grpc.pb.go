@@ -25,7 +25,9 @@ const (
 	PricingService_GetSurgePricing_FullMethodName           = "/pricing.PricingService/GetSurgePricing"
 	PricingService_GetVehicleTypes_FullMethodName           = "/pricing.PricingService/GetVehicleTypes"
 	PricingService_UpdateSurgePricing_FullMethodName        = "/pricing.PricingService/UpdateSurgePricing"
+	PricingService_ListSurgeAreas_FullMethodName            = "/pricing.PricingService/ListSurgeAreas"
 	PricingService_GetPricingStats_FullMethodName           = "/pricing.PricingService/GetPricingStats"
+	PricingService_SplitSharedFare_FullMethodName           = "/pricing.PricingService/SplitSharedFare"
 	PricingService_SubscribeToPricingUpdates_FullMethodName = "/pricing.PricingService/SubscribeToPricingUpdates"
 )
 
@@ -41,7 +43,9 @@ type PricingServiceClient interface {
 	GetSurgePricing(ctx context.Context, in *GetSurgePricingRequest, opts ...grpc.CallOption) (*GetSurgePricingResponse, error)
 	GetVehicleTypes(ctx context.Context, in *GetVehicleTypesRequest, opts ...grpc.CallOption) (*GetVehicleTypesResponse, error)
 	UpdateSurgePricing(ctx context.Context, in *UpdateSurgePricingRequest, opts ...grpc.CallOption) (*UpdateSurgePricingResponse, error)
+	ListSurgeAreas(ctx context.Context, in *ListSurgeAreasRequest, opts ...grpc.CallOption) (*ListSurgeAreasResponse, error)
 	GetPricingStats(ctx context.Context, in *GetPricingStatsRequest, opts ...grpc.CallOption) (*GetPricingStatsResponse, error)
+	SplitSharedFare(ctx context.Context, in *SplitSharedFareRequest, opts ...grpc.CallOption) (*SplitSharedFareResponse, error)
 	// Real-time features
 	SubscribeToPricingUpdates(ctx context.Context, in *SubscribeToPricingUpdatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PricingUpdateEvent], error)
 }
@@ -114,6 +118,16 @@ func (c *pricingServiceClient) UpdateSurgePricing(ctx context.Context, in *Updat
 	return out, nil
 }
 
+func (c *pricingServiceClient) ListSurgeAreas(ctx context.Context, in *ListSurgeAreasRequest, opts ...grpc.CallOption) (*ListSurgeAreasResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSurgeAreasResponse)
+	err := c.cc.Invoke(ctx, PricingService_ListSurgeAreas_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *pricingServiceClient) GetPricingStats(ctx context.Context, in *GetPricingStatsRequest, opts ...grpc.CallOption) (*GetPricingStatsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetPricingStatsResponse)
@@ -124,6 +138,16 @@ func (c *pricingServiceClient) GetPricingStats(ctx context.Context, in *GetPrici
 	return out, nil
 }
 
+func (c *pricingServiceClient) SplitSharedFare(ctx context.Context, in *SplitSharedFareRequest, opts ...grpc.CallOption) (*SplitSharedFareResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SplitSharedFareResponse)
+	err := c.cc.Invoke(ctx, PricingService_SplitSharedFare_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *pricingServiceClient) SubscribeToPricingUpdates(ctx context.Context, in *SubscribeToPricingUpdatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PricingUpdateEvent], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &PricingService_ServiceDesc.Streams[0], PricingService_SubscribeToPricingUpdates_FullMethodName, cOpts...)
@@ -155,7 +179,9 @@ type PricingServiceServer interface {
 	GetSurgePricing(context.Context, *GetSurgePricingRequest) (*GetSurgePricingResponse, error)
 	GetVehicleTypes(context.Context, *GetVehicleTypesRequest) (*GetVehicleTypesResponse, error)
 	UpdateSurgePricing(context.Context, *UpdateSurgePricingRequest) (*UpdateSurgePricingResponse, error)
+	ListSurgeAreas(context.Context, *ListSurgeAreasRequest) (*ListSurgeAreasResponse, error)
 	GetPricingStats(context.Context, *GetPricingStatsRequest) (*GetPricingStatsResponse, error)
+	SplitSharedFare(context.Context, *SplitSharedFareRequest) (*SplitSharedFareResponse, error)
 	// Real-time features
 	SubscribeToPricingUpdates(*SubscribeToPricingUpdatesRequest, grpc.ServerStreamingServer[PricingUpdateEvent]) error
 	mustEmbedUnimplementedPricingServiceServer()
@@ -186,9 +212,15 @@ func (UnimplementedPricingServiceServer) GetVehicleTypes(context.Context, *GetVe
 func (UnimplementedPricingServiceServer) UpdateSurgePricing(context.Context, *UpdateSurgePricingRequest) (*UpdateSurgePricingResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateSurgePricing not implemented")
 }
+func (UnimplementedPricingServiceServer) ListSurgeAreas(context.Context, *ListSurgeAreasRequest) (*ListSurgeAreasResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSurgeAreas not implemented")
+}
 func (UnimplementedPricingServiceServer) GetPricingStats(context.Context, *GetPricingStatsRequest) (*GetPricingStatsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPricingStats not implemented")
 }
+func (UnimplementedPricingServiceServer) SplitSharedFare(context.Context, *SplitSharedFareRequest) (*SplitSharedFareResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SplitSharedFare not implemented")
+}
 func (UnimplementedPricingServiceServer) SubscribeToPricingUpdates(*SubscribeToPricingUpdatesRequest, grpc.ServerStreamingServer[PricingUpdateEvent]) error {
 	return status.Errorf(codes.Unimplemented, "method SubscribeToPricingUpdates not implemented")
 }
@@ -321,6 +353,24 @@ func _PricingService_UpdateSurgePricing_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PricingService_ListSurgeAreas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSurgeAreasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PricingServiceServer).ListSurgeAreas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PricingService_ListSurgeAreas_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PricingServiceServer).ListSurgeAreas(ctx, req.(*ListSurgeAreasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PricingService_GetPricingStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetPricingStatsRequest)
 	if err := dec(in); err != nil {
@@ -339,6 +389,24 @@ func _PricingService_GetPricingStats_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PricingService_SplitSharedFare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SplitSharedFareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PricingServiceServer).SplitSharedFare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PricingService_SplitSharedFare_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PricingServiceServer).SplitSharedFare(ctx, req.(*SplitSharedFareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PricingService_SubscribeToPricingUpdates_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(SubscribeToPricingUpdatesRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -381,10 +449,18 @@ var PricingService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateSurgePricing",
 			Handler:    _PricingService_UpdateSurgePricing_Handler,
 		},
+		{
+			MethodName: "ListSurgeAreas",
+			Handler:    _PricingService_ListSurgeAreas_Handler,
+		},
 		{
 			MethodName: "GetPricingStats",
 			Handler:    _PricingService_GetPricingStats_Handler,
 		},
+		{
+			MethodName: "SplitSharedFare",
+			Handler:    _PricingService_SplitSharedFare_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -96,6 +96,63 @@ func (TripStatus) EnumDescriptor() ([]byte, []int) {
 	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{0}
 }
 
+// Real-time events for a driver about their trips, published on the event
+// bus and streamed to the driver app
+type DriverEventType int32
+
+const (
+	DriverEventType_DRIVER_EVENT_UNKNOWN            DriverEventType = 0
+	DriverEventType_DRIVER_EVENT_TRIP_ASSIGNED      DriverEventType = 1
+	DriverEventType_DRIVER_EVENT_TRIP_CANCELLED     DriverEventType = 2
+	DriverEventType_DRIVER_EVENT_RIDER_LOCATION     DriverEventType = 3
+	DriverEventType_DRIVER_EVENT_LOST_ITEM_REPORTED DriverEventType = 4
+)
+
+// Enum value maps for DriverEventType.
+var (
+	DriverEventType_name = map[int32]string{
+		0: "DRIVER_EVENT_UNKNOWN",
+		1: "DRIVER_EVENT_TRIP_ASSIGNED",
+		2: "DRIVER_EVENT_TRIP_CANCELLED",
+		3: "DRIVER_EVENT_RIDER_LOCATION",
+		4: "DRIVER_EVENT_LOST_ITEM_REPORTED",
+	}
+	DriverEventType_value = map[string]int32{
+		"DRIVER_EVENT_UNKNOWN":            0,
+		"DRIVER_EVENT_TRIP_ASSIGNED":      1,
+		"DRIVER_EVENT_TRIP_CANCELLED":     2,
+		"DRIVER_EVENT_RIDER_LOCATION":     3,
+		"DRIVER_EVENT_LOST_ITEM_REPORTED": 4,
+	}
+)
+
+func (x DriverEventType) Enum() *DriverEventType {
+	p := new(DriverEventType)
+	*p = x
+	return p
+}
+
+func (x DriverEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DriverEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_shared_proto_trip_trip_proto_enumTypes[1].Descriptor()
+}
+
+func (DriverEventType) Type() protoreflect.EnumType {
+	return &file_shared_proto_trip_trip_proto_enumTypes[1]
+}
+
+func (x DriverEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DriverEventType.Descriptor instead.
+func (DriverEventType) EnumDescriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{1}
+}
+
 // Location represents a geographical coordinate (simplified version)
 type Location struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -174,8 +231,13 @@ type Trip struct {
 	StartedAt       *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
 	CompletedAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
 	Metadata        *TripMetadata          `protobuf:"bytes,14,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	ScheduledFor    *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=scheduled_for,json=scheduledFor,proto3" json:"scheduled_for,omitempty"`
+	// City the trip starts in, empty outside every configured city
+	CityId string `protobuf:"bytes,16,opt,name=city_id,json=cityId,proto3" json:"city_id,omitempty"`
+	// Vehicle features the rider needed, such as wheelchair_accessible
+	AccessibilityNeeds []string `protobuf:"bytes,17,rep,name=accessibility_needs,json=accessibilityNeeds,proto3" json:"accessibility_needs,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *Trip) Reset() {
@@ -306,6 +368,27 @@ func (x *Trip) GetMetadata() *TripMetadata {
 	return nil
 }
 
+func (x *Trip) GetScheduledFor() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ScheduledFor
+	}
+	return nil
+}
+
+func (x *Trip) GetCityId() string {
+	if x != nil {
+		return x.CityId
+	}
+	return ""
+}
+
+func (x *Trip) GetAccessibilityNeeds() []string {
+	if x != nil {
+		return x.AccessibilityNeeds
+	}
+	return nil
+}
+
 // Additional trip metadata
 type TripMetadata struct {
 	state                    protoimpl.MessageState `protogen:"open.v1"`
@@ -416,8 +499,12 @@ type CreateTripRequest struct {
 	VehicleType     string                 `protobuf:"bytes,4,opt,name=vehicle_type,json=vehicleType,proto3" json:"vehicle_type,omitempty"`
 	PaymentMethodId string                 `protobuf:"bytes,5,opt,name=payment_method_id,json=paymentMethodId,proto3" json:"payment_method_id,omitempty"`
 	Metadata        *TripMetadata          `protobuf:"bytes,6,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	ScheduledFor    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=scheduled_for,json=scheduledFor,proto3" json:"scheduled_for,omitempty"`
+	// Optional; resolved from the pickup location when unset
+	CityId             string   `protobuf:"bytes,8,opt,name=city_id,json=cityId,proto3" json:"city_id,omitempty"`
+	AccessibilityNeeds []string `protobuf:"bytes,9,rep,name=accessibility_needs,json=accessibilityNeeds,proto3" json:"accessibility_needs,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *CreateTripRequest) Reset() {
@@ -492,6 +579,27 @@ func (x *CreateTripRequest) GetMetadata() *TripMetadata {
 	return nil
 }
 
+func (x *CreateTripRequest) GetScheduledFor() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ScheduledFor
+	}
+	return nil
+}
+
+func (x *CreateTripRequest) GetCityId() string {
+	if x != nil {
+		return x.CityId
+	}
+	return ""
+}
+
+func (x *CreateTripRequest) GetAccessibilityNeeds() []string {
+	if x != nil {
+		return x.AccessibilityNeeds
+	}
+	return nil
+}
+
 type CreateTripResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Trip          *Trip                  `protobuf:"bytes,1,opt,name=trip,proto3" json:"trip,omitempty"`
@@ -657,13 +765,18 @@ func (x *GetTripResponse) GetFound() bool {
 }
 
 type UpdateTripStatusRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TripId        string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
-	Status        TripStatus             `protobuf:"varint,2,opt,name=status,proto3,enum=trip.TripStatus" json:"status,omitempty"`
-	DriverId      string                 `protobuf:"bytes,3,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
-	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	TripId   string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	Status   TripStatus             `protobuf:"varint,2,opt,name=status,proto3,enum=trip.TripStatus" json:"status,omitempty"`
+	DriverId string                 `protobuf:"bytes,3,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	Reason   string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Actual trip metrics, reported with the COMPLETED status
+	Completion *TripCompletion `protobuf:"bytes,5,opt,name=completion,proto3" json:"completion,omitempty"`
+	// Driver's ETA to the pickup, reported with the MATCHED status. Riders
+	// are guaranteed a pickup within it.
+	PickupEtaSeconds int32 `protobuf:"varint,6,opt,name=pickup_eta_seconds,json=pickupEtaSeconds,proto3" json:"pickup_eta_seconds,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *UpdateTripStatusRequest) Reset() {
@@ -724,6 +837,156 @@ func (x *UpdateTripStatusRequest) GetReason() string {
 	return ""
 }
 
+func (x *UpdateTripStatusRequest) GetCompletion() *TripCompletion {
+	if x != nil {
+		return x.Completion
+	}
+	return nil
+}
+
+func (x *UpdateTripStatusRequest) GetPickupEtaSeconds() int32 {
+	if x != nil {
+		return x.PickupEtaSeconds
+	}
+	return 0
+}
+
+// TripCompletion carries the measured route of a finished trip
+type TripCompletion struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	VehicleId       string                 `protobuf:"bytes,1,opt,name=vehicle_id,json=vehicleId,proto3" json:"vehicle_id,omitempty"`
+	VehicleType     string                 `protobuf:"bytes,2,opt,name=vehicle_type,json=vehicleType,proto3" json:"vehicle_type,omitempty"`
+	PickupLocation  *Location              `protobuf:"bytes,3,opt,name=pickup_location,json=pickupLocation,proto3" json:"pickup_location,omitempty"`
+	Destination     *Location              `protobuf:"bytes,4,opt,name=destination,proto3" json:"destination,omitempty"`
+	DistanceKm      float64                `protobuf:"fixed64,5,opt,name=distance_km,json=distanceKm,proto3" json:"distance_km,omitempty"`
+	DurationMinutes int32                  `protobuf:"varint,6,opt,name=duration_minutes,json=durationMinutes,proto3" json:"duration_minutes,omitempty"`
+	StartedAt       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	PickupArea      string                 `protobuf:"bytes,8,opt,name=pickup_area,json=pickupArea,proto3" json:"pickup_area,omitempty"`
+	// Surge multiplier quoted to the rider when the trip was requested
+	SurgeMultiplier float64 `protobuf:"fixed64,9,opt,name=surge_multiplier,json=surgeMultiplier,proto3" json:"surge_multiplier,omitempty"`
+	// Time the driver waited at pickup for the rider
+	WaitingTimeSeconds int32  `protobuf:"varint,10,opt,name=waiting_time_seconds,json=waitingTimeSeconds,proto3" json:"waiting_time_seconds,omitempty"`
+	City               string `protobuf:"bytes,11,opt,name=city,proto3" json:"city,omitempty"`
+	// Currency the trip was quoted in, which the final fare must be charged in
+	Currency      string `protobuf:"bytes,12,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TripCompletion) Reset() {
+	*x = TripCompletion{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TripCompletion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TripCompletion) ProtoMessage() {}
+
+func (x *TripCompletion) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TripCompletion.ProtoReflect.Descriptor instead.
+func (*TripCompletion) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TripCompletion) GetVehicleId() string {
+	if x != nil {
+		return x.VehicleId
+	}
+	return ""
+}
+
+func (x *TripCompletion) GetVehicleType() string {
+	if x != nil {
+		return x.VehicleType
+	}
+	return ""
+}
+
+func (x *TripCompletion) GetPickupLocation() *Location {
+	if x != nil {
+		return x.PickupLocation
+	}
+	return nil
+}
+
+func (x *TripCompletion) GetDestination() *Location {
+	if x != nil {
+		return x.Destination
+	}
+	return nil
+}
+
+func (x *TripCompletion) GetDistanceKm() float64 {
+	if x != nil {
+		return x.DistanceKm
+	}
+	return 0
+}
+
+func (x *TripCompletion) GetDurationMinutes() int32 {
+	if x != nil {
+		return x.DurationMinutes
+	}
+	return 0
+}
+
+func (x *TripCompletion) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *TripCompletion) GetPickupArea() string {
+	if x != nil {
+		return x.PickupArea
+	}
+	return ""
+}
+
+func (x *TripCompletion) GetSurgeMultiplier() float64 {
+	if x != nil {
+		return x.SurgeMultiplier
+	}
+	return 0
+}
+
+func (x *TripCompletion) GetWaitingTimeSeconds() int32 {
+	if x != nil {
+		return x.WaitingTimeSeconds
+	}
+	return 0
+}
+
+func (x *TripCompletion) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *TripCompletion) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
 type UpdateTripStatusResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Trip          *Trip                  `protobuf:"bytes,1,opt,name=trip,proto3" json:"trip,omitempty"`
@@ -735,7 +998,7 @@ type UpdateTripStatusResponse struct {
 
 func (x *UpdateTripStatusResponse) Reset() {
 	*x = UpdateTripStatusResponse{}
-	mi := &file_shared_proto_trip_trip_proto_msgTypes[8]
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -747,7 +1010,7 @@ func (x *UpdateTripStatusResponse) String() string {
 func (*UpdateTripStatusResponse) ProtoMessage() {}
 
 func (x *UpdateTripStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_trip_trip_proto_msgTypes[8]
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -760,7 +1023,7 @@ func (x *UpdateTripStatusResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateTripStatusResponse.ProtoReflect.Descriptor instead.
 func (*UpdateTripStatusResponse) Descriptor() ([]byte, []int) {
-	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{8}
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *UpdateTripStatusResponse) GetTrip() *Trip {
@@ -784,20 +1047,24 @@ func (x *UpdateTripStatusResponse) GetMessage() string {
 	return ""
 }
 
+// Trip lists are read a page at a time. The first page is read without a
+// cursor, the following ones with the next_cursor of the page before.
 type GetUserTripsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"` // "rider" or "driver"
-	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
-	Status        TripStatus             `protobuf:"varint,5,opt,name=status,proto3,enum=trip.TripStatus" json:"status,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role   string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"` // "rider" or "driver"
+	Limit  int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Status TripStatus             `protobuf:"varint,5,opt,name=status,proto3,enum=trip.TripStatus" json:"status,omitempty"`
+	Cursor string                 `protobuf:"bytes,6,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// Field to sort on, "-" prefixed for descending; latest request first by default
+	Sort          string `protobuf:"bytes,7,opt,name=sort,proto3" json:"sort,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetUserTripsRequest) Reset() {
 	*x = GetUserTripsRequest{}
-	mi := &file_shared_proto_trip_trip_proto_msgTypes[9]
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -809,7 +1076,7 @@ func (x *GetUserTripsRequest) String() string {
 func (*GetUserTripsRequest) ProtoMessage() {}
 
 func (x *GetUserTripsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_trip_trip_proto_msgTypes[9]
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -822,7 +1089,7 @@ func (x *GetUserTripsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserTripsRequest.ProtoReflect.Descriptor instead.
 func (*GetUserTripsRequest) Descriptor() ([]byte, []int) {
-	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{9}
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *GetUserTripsRequest) GetUserId() string {
@@ -846,18 +1113,25 @@ func (x *GetUserTripsRequest) GetLimit() int32 {
 	return 0
 }
 
-func (x *GetUserTripsRequest) GetOffset() int32 {
+func (x *GetUserTripsRequest) GetStatus() TripStatus {
 	if x != nil {
-		return x.Offset
+		return x.Status
 	}
-	return 0
+	return TripStatus_UNKNOWN_STATUS
 }
 
-func (x *GetUserTripsRequest) GetStatus() TripStatus {
+func (x *GetUserTripsRequest) GetCursor() string {
 	if x != nil {
-		return x.Status
+		return x.Cursor
 	}
-	return TripStatus_UNKNOWN_STATUS
+	return ""
+}
+
+func (x *GetUserTripsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
 }
 
 type GetUserTripsResponse struct {
@@ -865,13 +1139,14 @@ type GetUserTripsResponse struct {
 	Trips         []*Trip                `protobuf:"bytes,1,rep,name=trips,proto3" json:"trips,omitempty"`
 	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
 	HasMore       bool                   `protobuf:"varint,3,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,4,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetUserTripsResponse) Reset() {
 	*x = GetUserTripsResponse{}
-	mi := &file_shared_proto_trip_trip_proto_msgTypes[10]
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -883,7 +1158,7 @@ func (x *GetUserTripsResponse) String() string {
 func (*GetUserTripsResponse) ProtoMessage() {}
 
 func (x *GetUserTripsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_trip_trip_proto_msgTypes[10]
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -896,7 +1171,7 @@ func (x *GetUserTripsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserTripsResponse.ProtoReflect.Descriptor instead.
 func (*GetUserTripsResponse) Descriptor() ([]byte, []int) {
-	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{10}
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *GetUserTripsResponse) GetTrips() []*Trip {
@@ -920,17 +1195,32 @@ func (x *GetUserTripsResponse) GetHasMore() bool {
 	return false
 }
 
+func (x *GetUserTripsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
 type GetActiveTripsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Region        string                 `protobuf:"bytes,1,opt,name=region,proto3" json:"region,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Region string                 `protobuf:"bytes,1,opt,name=region,proto3" json:"region,omitempty"`
+	Limit  int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Optional filters; an unset status matches every active status
+	Status   TripStatus `protobuf:"varint,3,opt,name=status,proto3,enum=trip.TripStatus" json:"status,omitempty"`
+	RiderId  string     `protobuf:"bytes,4,opt,name=rider_id,json=riderId,proto3" json:"rider_id,omitempty"`
+	DriverId string     `protobuf:"bytes,5,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	CityId   string     `protobuf:"bytes,7,opt,name=city_id,json=cityId,proto3" json:"city_id,omitempty"`
+	Cursor   string     `protobuf:"bytes,8,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// Field to sort on, "-" prefixed for descending; oldest request first by default
+	Sort          string `protobuf:"bytes,9,opt,name=sort,proto3" json:"sort,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetActiveTripsRequest) Reset() {
 	*x = GetActiveTripsRequest{}
-	mi := &file_shared_proto_trip_trip_proto_msgTypes[11]
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -942,7 +1232,7 @@ func (x *GetActiveTripsRequest) String() string {
 func (*GetActiveTripsRequest) ProtoMessage() {}
 
 func (x *GetActiveTripsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_trip_trip_proto_msgTypes[11]
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -955,7 +1245,7 @@ func (x *GetActiveTripsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetActiveTripsRequest.ProtoReflect.Descriptor instead.
 func (*GetActiveTripsRequest) Descriptor() ([]byte, []int) {
-	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{11}
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GetActiveTripsRequest) GetRegion() string {
@@ -972,17 +1262,61 @@ func (x *GetActiveTripsRequest) GetLimit() int32 {
 	return 0
 }
 
+func (x *GetActiveTripsRequest) GetStatus() TripStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TripStatus_UNKNOWN_STATUS
+}
+
+func (x *GetActiveTripsRequest) GetRiderId() string {
+	if x != nil {
+		return x.RiderId
+	}
+	return ""
+}
+
+func (x *GetActiveTripsRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *GetActiveTripsRequest) GetCityId() string {
+	if x != nil {
+		return x.CityId
+	}
+	return ""
+}
+
+func (x *GetActiveTripsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *GetActiveTripsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
 type GetActiveTripsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Trips         []*Trip                `protobuf:"bytes,1,rep,name=trips,proto3" json:"trips,omitempty"`
-	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Trips []*Trip                `protobuf:"bytes,1,rep,name=trips,proto3" json:"trips,omitempty"`
+	// About how many active trips match the filters, across all pages
+	Count         int32  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	NextCursor    string `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetActiveTripsResponse) Reset() {
 	*x = GetActiveTripsResponse{}
-	mi := &file_shared_proto_trip_trip_proto_msgTypes[12]
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -994,7 +1328,7 @@ func (x *GetActiveTripsResponse) String() string {
 func (*GetActiveTripsResponse) ProtoMessage() {}
 
 func (x *GetActiveTripsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_trip_trip_proto_msgTypes[12]
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1007,51 +1341,6662 @@ func (x *GetActiveTripsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetActiveTripsResponse.ProtoReflect.Descriptor instead.
 func (*GetActiveTripsResponse) Descriptor() ([]byte, []int) {
-	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{12}
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetActiveTripsResponse) GetTrips() []*Trip {
+	if x != nil {
+		return x.Trips
+	}
+	return nil
+}
+
+func (x *GetActiveTripsResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *GetActiveTripsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+// Cancels an active trip on behalf of the platform
+type ForceCancelTripRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	TripId string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	Reason string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	// The operator who cancelled the trip, for the audit trail
+	ActorId       string `protobuf:"bytes,3,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForceCancelTripRequest) Reset() {
+	*x = ForceCancelTripRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForceCancelTripRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForceCancelTripRequest) ProtoMessage() {}
+
+func (x *ForceCancelTripRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForceCancelTripRequest.ProtoReflect.Descriptor instead.
+func (*ForceCancelTripRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ForceCancelTripRequest) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *ForceCancelTripRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ForceCancelTripRequest) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+type ForceCancelTripResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Trip          *Trip                  `protobuf:"bytes,1,opt,name=trip,proto3" json:"trip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForceCancelTripResponse) Reset() {
+	*x = ForceCancelTripResponse{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForceCancelTripResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForceCancelTripResponse) ProtoMessage() {}
+
+func (x *ForceCancelTripResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForceCancelTripResponse.ProtoReflect.Descriptor instead.
+func (*ForceCancelTripResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ForceCancelTripResponse) GetTrip() *Trip {
+	if x != nil {
+		return x.Trip
+	}
+	return nil
+}
+
+// Scrubs a user's personal data from their trips for a data subject erasure
+// request. Fares and timestamps are kept for the financial records.
+type AnonymizeUserTripsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnonymizeUserTripsRequest) Reset() {
+	*x = AnonymizeUserTripsRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnonymizeUserTripsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnonymizeUserTripsRequest) ProtoMessage() {}
+
+func (x *AnonymizeUserTripsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnonymizeUserTripsRequest.ProtoReflect.Descriptor instead.
+func (*AnonymizeUserTripsRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *AnonymizeUserTripsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type AnonymizeUserTripsResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AnonymizedCount int32                  `protobuf:"varint,1,opt,name=anonymized_count,json=anonymizedCount,proto3" json:"anonymized_count,omitempty"`
+	// Every trip the user rode or drove, so their recorded routes can be erased
+	TripIds       []string `protobuf:"bytes,2,rep,name=trip_ids,json=tripIds,proto3" json:"trip_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnonymizeUserTripsResponse) Reset() {
+	*x = AnonymizeUserTripsResponse{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnonymizeUserTripsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnonymizeUserTripsResponse) ProtoMessage() {}
+
+func (x *AnonymizeUserTripsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnonymizeUserTripsResponse.ProtoReflect.Descriptor instead.
+func (*AnonymizeUserTripsResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *AnonymizeUserTripsResponse) GetAnonymizedCount() int32 {
+	if x != nil {
+		return x.AnonymizedCount
+	}
+	return 0
+}
+
+func (x *AnonymizeUserTripsResponse) GetTripIds() []string {
+	if x != nil {
+		return x.TripIds
+	}
+	return nil
+}
+
+// Real-time trip updates
+type TripUpdateEvent struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	TripId          string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	OldStatus       TripStatus             `protobuf:"varint,2,opt,name=old_status,json=oldStatus,proto3,enum=trip.TripStatus" json:"old_status,omitempty"`
+	NewStatus       TripStatus             `protobuf:"varint,3,opt,name=new_status,json=newStatus,proto3,enum=trip.TripStatus" json:"new_status,omitempty"`
+	CurrentLocation *Location              `protobuf:"bytes,4,opt,name=current_location,json=currentLocation,proto3" json:"current_location,omitempty"`
+	Timestamp       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Metadata        map[string]string      `protobuf:"bytes,6,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TripUpdateEvent) Reset() {
+	*x = TripUpdateEvent{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TripUpdateEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TripUpdateEvent) ProtoMessage() {}
+
+func (x *TripUpdateEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TripUpdateEvent.ProtoReflect.Descriptor instead.
+func (*TripUpdateEvent) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *TripUpdateEvent) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *TripUpdateEvent) GetOldStatus() TripStatus {
+	if x != nil {
+		return x.OldStatus
+	}
+	return TripStatus_UNKNOWN_STATUS
+}
+
+func (x *TripUpdateEvent) GetNewStatus() TripStatus {
+	if x != nil {
+		return x.NewStatus
+	}
+	return TripStatus_UNKNOWN_STATUS
+}
+
+func (x *TripUpdateEvent) GetCurrentLocation() *Location {
+	if x != nil {
+		return x.CurrentLocation
+	}
+	return nil
+}
+
+func (x *TripUpdateEvent) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *TripUpdateEvent) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+type SubscribeToTripUpdatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TripId        string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeToTripUpdatesRequest) Reset() {
+	*x = SubscribeToTripUpdatesRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeToTripUpdatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeToTripUpdatesRequest) ProtoMessage() {}
+
+func (x *SubscribeToTripUpdatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeToTripUpdatesRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeToTripUpdatesRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SubscribeToTripUpdatesRequest) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *SubscribeToTripUpdatesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// Shared (pooled) trips with multiple pickups and dropoffs
+type TripStop struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TripId        string                 `protobuf:"bytes,2,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	RiderId       string                 `protobuf:"bytes,3,opt,name=rider_id,json=riderId,proto3" json:"rider_id,omitempty"`
+	Type          string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"` // "pickup" or "dropoff"
+	Location      *Location              `protobuf:"bytes,5,opt,name=location,proto3" json:"location,omitempty"`
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"` // "pending" or "completed"
+	CompletedAt   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TripStop) Reset() {
+	*x = TripStop{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TripStop) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TripStop) ProtoMessage() {}
+
+func (x *TripStop) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TripStop.ProtoReflect.Descriptor instead.
+func (*TripStop) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *TripStop) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TripStop) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *TripStop) GetRiderId() string {
+	if x != nil {
+		return x.RiderId
+	}
+	return ""
+}
+
+func (x *TripStop) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TripStop) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *TripStop) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TripStop) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+type SharedRider struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	TripId           string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	RiderId          string                 `protobuf:"bytes,2,opt,name=rider_id,json=riderId,proto3" json:"rider_id,omitempty"`
+	PickupLocation   *Location              `protobuf:"bytes,3,opt,name=pickup_location,json=pickupLocation,proto3" json:"pickup_location,omitempty"`
+	Destination      *Location              `protobuf:"bytes,4,opt,name=destination,proto3" json:"destination,omitempty"`
+	PassengerCount   int32                  `protobuf:"varint,5,opt,name=passenger_count,json=passengerCount,proto3" json:"passenger_count,omitempty"`
+	MaxDetourMinutes int32                  `protobuf:"varint,6,opt,name=max_detour_minutes,json=maxDetourMinutes,proto3" json:"max_detour_minutes,omitempty"`
+	Fare             float64                `protobuf:"fixed64,7,opt,name=fare,proto3" json:"fare,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SharedRider) Reset() {
+	*x = SharedRider{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SharedRider) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedRider) ProtoMessage() {}
+
+func (x *SharedRider) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedRider.ProtoReflect.Descriptor instead.
+func (*SharedRider) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SharedRider) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *SharedRider) GetRiderId() string {
+	if x != nil {
+		return x.RiderId
+	}
+	return ""
+}
+
+func (x *SharedRider) GetPickupLocation() *Location {
+	if x != nil {
+		return x.PickupLocation
+	}
+	return nil
+}
+
+func (x *SharedRider) GetDestination() *Location {
+	if x != nil {
+		return x.Destination
+	}
+	return nil
+}
+
+func (x *SharedRider) GetPassengerCount() int32 {
+	if x != nil {
+		return x.PassengerCount
+	}
+	return 0
+}
+
+func (x *SharedRider) GetMaxDetourMinutes() int32 {
+	if x != nil {
+		return x.MaxDetourMinutes
+	}
+	return 0
+}
+
+func (x *SharedRider) GetFare() float64 {
+	if x != nil {
+		return x.Fare
+	}
+	return 0
+}
+
+type SharedTrip struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DriverId        string                 `protobuf:"bytes,2,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	VehicleType     string                 `protobuf:"bytes,3,opt,name=vehicle_type,json=vehicleType,proto3" json:"vehicle_type,omitempty"`
+	SeatCapacity    int32                  `protobuf:"varint,4,opt,name=seat_capacity,json=seatCapacity,proto3" json:"seat_capacity,omitempty"`
+	SeatsOccupied   int32                  `protobuf:"varint,5,opt,name=seats_occupied,json=seatsOccupied,proto3" json:"seats_occupied,omitempty"`
+	Status          string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"` // "active" or "completed"
+	Stops           []*TripStop            `protobuf:"bytes,7,rep,name=stops,proto3" json:"stops,omitempty"`
+	Riders          []*SharedRider         `protobuf:"bytes,8,rep,name=riders,proto3" json:"riders,omitempty"`
+	CurrentLocation *Location              `protobuf:"bytes,9,opt,name=current_location,json=currentLocation,proto3" json:"current_location,omitempty"`
+	Version         int32                  `protobuf:"varint,10,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *SharedTrip) Reset() {
+	*x = SharedTrip{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SharedTrip) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedTrip) ProtoMessage() {}
+
+func (x *SharedTrip) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedTrip.ProtoReflect.Descriptor instead.
+func (*SharedTrip) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SharedTrip) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SharedTrip) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *SharedTrip) GetVehicleType() string {
+	if x != nil {
+		return x.VehicleType
+	}
+	return ""
+}
+
+func (x *SharedTrip) GetSeatCapacity() int32 {
+	if x != nil {
+		return x.SeatCapacity
+	}
+	return 0
+}
+
+func (x *SharedTrip) GetSeatsOccupied() int32 {
+	if x != nil {
+		return x.SeatsOccupied
+	}
+	return 0
+}
+
+func (x *SharedTrip) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SharedTrip) GetStops() []*TripStop {
+	if x != nil {
+		return x.Stops
+	}
+	return nil
+}
+
+func (x *SharedTrip) GetRiders() []*SharedRider {
+	if x != nil {
+		return x.Riders
+	}
+	return nil
+}
+
+func (x *SharedTrip) GetCurrentLocation() *Location {
+	if x != nil {
+		return x.CurrentLocation
+	}
+	return nil
+}
+
+func (x *SharedTrip) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *SharedTrip) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *SharedTrip) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type OpenSharedTripRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	DriverId        string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	VehicleType     string                 `protobuf:"bytes,2,opt,name=vehicle_type,json=vehicleType,proto3" json:"vehicle_type,omitempty"`
+	SeatCapacity    int32                  `protobuf:"varint,3,opt,name=seat_capacity,json=seatCapacity,proto3" json:"seat_capacity,omitempty"`
+	Rider           *SharedRider           `protobuf:"bytes,4,opt,name=rider,proto3" json:"rider,omitempty"`
+	CurrentLocation *Location              `protobuf:"bytes,5,opt,name=current_location,json=currentLocation,proto3" json:"current_location,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *OpenSharedTripRequest) Reset() {
+	*x = OpenSharedTripRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenSharedTripRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenSharedTripRequest) ProtoMessage() {}
+
+func (x *OpenSharedTripRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenSharedTripRequest.ProtoReflect.Descriptor instead.
+func (*OpenSharedTripRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *OpenSharedTripRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *OpenSharedTripRequest) GetVehicleType() string {
+	if x != nil {
+		return x.VehicleType
+	}
+	return ""
+}
+
+func (x *OpenSharedTripRequest) GetSeatCapacity() int32 {
+	if x != nil {
+		return x.SeatCapacity
+	}
+	return 0
+}
+
+func (x *OpenSharedTripRequest) GetRider() *SharedRider {
+	if x != nil {
+		return x.Rider
+	}
+	return nil
+}
+
+func (x *OpenSharedTripRequest) GetCurrentLocation() *Location {
+	if x != nil {
+		return x.CurrentLocation
+	}
+	return nil
+}
+
+type AddSharedRiderRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	SharedTripId    string                 `protobuf:"bytes,1,opt,name=shared_trip_id,json=sharedTripId,proto3" json:"shared_trip_id,omitempty"`
+	ExpectedVersion int32                  `protobuf:"varint,2,opt,name=expected_version,json=expectedVersion,proto3" json:"expected_version,omitempty"`
+	Rider           *SharedRider           `protobuf:"bytes,3,opt,name=rider,proto3" json:"rider,omitempty"`
+	PickupIndex     int32                  `protobuf:"varint,4,opt,name=pickup_index,json=pickupIndex,proto3" json:"pickup_index,omitempty"` // position among the pending stops after insertion
+	DropoffIndex    int32                  `protobuf:"varint,5,opt,name=dropoff_index,json=dropoffIndex,proto3" json:"dropoff_index,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *AddSharedRiderRequest) Reset() {
+	*x = AddSharedRiderRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddSharedRiderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddSharedRiderRequest) ProtoMessage() {}
+
+func (x *AddSharedRiderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddSharedRiderRequest.ProtoReflect.Descriptor instead.
+func (*AddSharedRiderRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AddSharedRiderRequest) GetSharedTripId() string {
+	if x != nil {
+		return x.SharedTripId
+	}
+	return ""
+}
+
+func (x *AddSharedRiderRequest) GetExpectedVersion() int32 {
+	if x != nil {
+		return x.ExpectedVersion
+	}
+	return 0
+}
+
+func (x *AddSharedRiderRequest) GetRider() *SharedRider {
+	if x != nil {
+		return x.Rider
+	}
+	return nil
+}
+
+func (x *AddSharedRiderRequest) GetPickupIndex() int32 {
+	if x != nil {
+		return x.PickupIndex
+	}
+	return 0
+}
+
+func (x *AddSharedRiderRequest) GetDropoffIndex() int32 {
+	if x != nil {
+		return x.DropoffIndex
+	}
+	return 0
+}
+
+type CompleteTripStopRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SharedTripId  string                 `protobuf:"bytes,1,opt,name=shared_trip_id,json=sharedTripId,proto3" json:"shared_trip_id,omitempty"`
+	StopId        string                 `protobuf:"bytes,2,opt,name=stop_id,json=stopId,proto3" json:"stop_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteTripStopRequest) Reset() {
+	*x = CompleteTripStopRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteTripStopRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteTripStopRequest) ProtoMessage() {}
+
+func (x *CompleteTripStopRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteTripStopRequest.ProtoReflect.Descriptor instead.
+func (*CompleteTripStopRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CompleteTripStopRequest) GetSharedTripId() string {
+	if x != nil {
+		return x.SharedTripId
+	}
+	return ""
+}
+
+func (x *CompleteTripStopRequest) GetStopId() string {
+	if x != nil {
+		return x.StopId
+	}
+	return ""
+}
+
+type GetSharedTripRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SharedTripId  string                 `protobuf:"bytes,1,opt,name=shared_trip_id,json=sharedTripId,proto3" json:"shared_trip_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSharedTripRequest) Reset() {
+	*x = GetSharedTripRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSharedTripRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSharedTripRequest) ProtoMessage() {}
+
+func (x *GetSharedTripRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSharedTripRequest.ProtoReflect.Descriptor instead.
+func (*GetSharedTripRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetSharedTripRequest) GetSharedTripId() string {
+	if x != nil {
+		return x.SharedTripId
+	}
+	return ""
+}
+
+type SharedTripResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SharedTrip    *SharedTrip            `protobuf:"bytes,1,opt,name=shared_trip,json=sharedTrip,proto3" json:"shared_trip,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SharedTripResponse) Reset() {
+	*x = SharedTripResponse{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SharedTripResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedTripResponse) ProtoMessage() {}
+
+func (x *SharedTripResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedTripResponse.ProtoReflect.Descriptor instead.
+func (*SharedTripResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SharedTripResponse) GetSharedTrip() *SharedTrip {
+	if x != nil {
+		return x.SharedTrip
+	}
+	return nil
+}
+
+func (x *SharedTripResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SharedTripResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ListOpenSharedTripsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VehicleType   string                 `protobuf:"bytes,1,opt,name=vehicle_type,json=vehicleType,proto3" json:"vehicle_type,omitempty"`
+	SeatsNeeded   int32                  `protobuf:"varint,2,opt,name=seats_needed,json=seatsNeeded,proto3" json:"seats_needed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOpenSharedTripsRequest) Reset() {
+	*x = ListOpenSharedTripsRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOpenSharedTripsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOpenSharedTripsRequest) ProtoMessage() {}
+
+func (x *ListOpenSharedTripsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOpenSharedTripsRequest.ProtoReflect.Descriptor instead.
+func (*ListOpenSharedTripsRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListOpenSharedTripsRequest) GetVehicleType() string {
+	if x != nil {
+		return x.VehicleType
+	}
+	return ""
+}
+
+func (x *ListOpenSharedTripsRequest) GetSeatsNeeded() int32 {
+	if x != nil {
+		return x.SeatsNeeded
+	}
+	return 0
+}
+
+type ListOpenSharedTripsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SharedTrips   []*SharedTrip          `protobuf:"bytes,1,rep,name=shared_trips,json=sharedTrips,proto3" json:"shared_trips,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOpenSharedTripsResponse) Reset() {
+	*x = ListOpenSharedTripsResponse{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOpenSharedTripsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOpenSharedTripsResponse) ProtoMessage() {}
+
+func (x *ListOpenSharedTripsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOpenSharedTripsResponse.ProtoReflect.Descriptor instead.
+func (*ListOpenSharedTripsResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListOpenSharedTripsResponse) GetSharedTrips() []*SharedTrip {
+	if x != nil {
+		return x.SharedTrips
+	}
+	return nil
+}
+
+// Scheduled (book-for-later) rides
+type ScheduledRide struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RiderId            string                 `protobuf:"bytes,2,opt,name=rider_id,json=riderId,proto3" json:"rider_id,omitempty"`
+	PickupLocation     *Location              `protobuf:"bytes,3,opt,name=pickup_location,json=pickupLocation,proto3" json:"pickup_location,omitempty"`
+	Destination        *Location              `protobuf:"bytes,4,opt,name=destination,proto3" json:"destination,omitempty"`
+	VehicleType        string                 `protobuf:"bytes,5,opt,name=vehicle_type,json=vehicleType,proto3" json:"vehicle_type,omitempty"`
+	PaymentMethodId    string                 `protobuf:"bytes,6,opt,name=payment_method_id,json=paymentMethodId,proto3" json:"payment_method_id,omitempty"`
+	PassengerCount     int32                  `protobuf:"varint,7,opt,name=passenger_count,json=passengerCount,proto3" json:"passenger_count,omitempty"`
+	Status             string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"` // "scheduled", "dispatched", "cancelled" or "failed"
+	ScheduledFor       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=scheduled_for,json=scheduledFor,proto3" json:"scheduled_for,omitempty"`
+	DispatchAt         *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=dispatch_at,json=dispatchAt,proto3" json:"dispatch_at,omitempty"`
+	DispatchAttempts   int32                  `protobuf:"varint,11,opt,name=dispatch_attempts,json=dispatchAttempts,proto3" json:"dispatch_attempts,omitempty"`
+	DriverId           string                 `protobuf:"bytes,12,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	LastError          string                 `protobuf:"bytes,13,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	CancellationReason string                 `protobuf:"bytes,14,opt,name=cancellation_reason,json=cancellationReason,proto3" json:"cancellation_reason,omitempty"`
+	CreatedAt          *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt          *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ScheduledRide) Reset() {
+	*x = ScheduledRide{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduledRide) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledRide) ProtoMessage() {}
+
+func (x *ScheduledRide) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledRide.ProtoReflect.Descriptor instead.
+func (*ScheduledRide) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ScheduledRide) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ScheduledRide) GetRiderId() string {
+	if x != nil {
+		return x.RiderId
+	}
+	return ""
+}
+
+func (x *ScheduledRide) GetPickupLocation() *Location {
+	if x != nil {
+		return x.PickupLocation
+	}
+	return nil
+}
+
+func (x *ScheduledRide) GetDestination() *Location {
+	if x != nil {
+		return x.Destination
+	}
+	return nil
+}
+
+func (x *ScheduledRide) GetVehicleType() string {
+	if x != nil {
+		return x.VehicleType
+	}
+	return ""
+}
+
+func (x *ScheduledRide) GetPaymentMethodId() string {
+	if x != nil {
+		return x.PaymentMethodId
+	}
+	return ""
+}
+
+func (x *ScheduledRide) GetPassengerCount() int32 {
+	if x != nil {
+		return x.PassengerCount
+	}
+	return 0
+}
+
+func (x *ScheduledRide) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ScheduledRide) GetScheduledFor() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ScheduledFor
+	}
+	return nil
+}
+
+func (x *ScheduledRide) GetDispatchAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DispatchAt
+	}
+	return nil
+}
+
+func (x *ScheduledRide) GetDispatchAttempts() int32 {
+	if x != nil {
+		return x.DispatchAttempts
+	}
+	return 0
+}
+
+func (x *ScheduledRide) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *ScheduledRide) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *ScheduledRide) GetCancellationReason() string {
+	if x != nil {
+		return x.CancellationReason
+	}
+	return ""
+}
+
+func (x *ScheduledRide) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ScheduledRide) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreateScheduledRideRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	RiderId         string                 `protobuf:"bytes,1,opt,name=rider_id,json=riderId,proto3" json:"rider_id,omitempty"`
+	PickupLocation  *Location              `protobuf:"bytes,2,opt,name=pickup_location,json=pickupLocation,proto3" json:"pickup_location,omitempty"`
+	Destination     *Location              `protobuf:"bytes,3,opt,name=destination,proto3" json:"destination,omitempty"`
+	VehicleType     string                 `protobuf:"bytes,4,opt,name=vehicle_type,json=vehicleType,proto3" json:"vehicle_type,omitempty"`
+	PaymentMethodId string                 `protobuf:"bytes,5,opt,name=payment_method_id,json=paymentMethodId,proto3" json:"payment_method_id,omitempty"`
+	PassengerCount  int32                  `protobuf:"varint,6,opt,name=passenger_count,json=passengerCount,proto3" json:"passenger_count,omitempty"`
+	ScheduledFor    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=scheduled_for,json=scheduledFor,proto3" json:"scheduled_for,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CreateScheduledRideRequest) Reset() {
+	*x = CreateScheduledRideRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateScheduledRideRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateScheduledRideRequest) ProtoMessage() {}
+
+func (x *CreateScheduledRideRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateScheduledRideRequest.ProtoReflect.Descriptor instead.
+func (*CreateScheduledRideRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CreateScheduledRideRequest) GetRiderId() string {
+	if x != nil {
+		return x.RiderId
+	}
+	return ""
+}
+
+func (x *CreateScheduledRideRequest) GetPickupLocation() *Location {
+	if x != nil {
+		return x.PickupLocation
+	}
+	return nil
+}
+
+func (x *CreateScheduledRideRequest) GetDestination() *Location {
+	if x != nil {
+		return x.Destination
+	}
+	return nil
+}
+
+func (x *CreateScheduledRideRequest) GetVehicleType() string {
+	if x != nil {
+		return x.VehicleType
+	}
+	return ""
+}
+
+func (x *CreateScheduledRideRequest) GetPaymentMethodId() string {
+	if x != nil {
+		return x.PaymentMethodId
+	}
+	return ""
+}
+
+func (x *CreateScheduledRideRequest) GetPassengerCount() int32 {
+	if x != nil {
+		return x.PassengerCount
+	}
+	return 0
+}
+
+func (x *CreateScheduledRideRequest) GetScheduledFor() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ScheduledFor
+	}
+	return nil
+}
+
+type ScheduledRideResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScheduledRide *ScheduledRide         `protobuf:"bytes,1,opt,name=scheduled_ride,json=scheduledRide,proto3" json:"scheduled_ride,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScheduledRideResponse) Reset() {
+	*x = ScheduledRideResponse{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScheduledRideResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScheduledRideResponse) ProtoMessage() {}
+
+func (x *ScheduledRideResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScheduledRideResponse.ProtoReflect.Descriptor instead.
+func (*ScheduledRideResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ScheduledRideResponse) GetScheduledRide() *ScheduledRide {
+	if x != nil {
+		return x.ScheduledRide
+	}
+	return nil
+}
+
+func (x *ScheduledRideResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ScheduledRideResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ListScheduledRidesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RiderId       string                 `protobuf:"bytes,1,opt,name=rider_id,json=riderId,proto3" json:"rider_id,omitempty"`
+	IncludeClosed bool                   `protobuf:"varint,2,opt,name=include_closed,json=includeClosed,proto3" json:"include_closed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScheduledRidesRequest) Reset() {
+	*x = ListScheduledRidesRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduledRidesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledRidesRequest) ProtoMessage() {}
+
+func (x *ListScheduledRidesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledRidesRequest.ProtoReflect.Descriptor instead.
+func (*ListScheduledRidesRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListScheduledRidesRequest) GetRiderId() string {
+	if x != nil {
+		return x.RiderId
+	}
+	return ""
+}
+
+func (x *ListScheduledRidesRequest) GetIncludeClosed() bool {
+	if x != nil {
+		return x.IncludeClosed
+	}
+	return false
+}
+
+type ListScheduledRidesResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ScheduledRides []*ScheduledRide       `protobuf:"bytes,1,rep,name=scheduled_rides,json=scheduledRides,proto3" json:"scheduled_rides,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListScheduledRidesResponse) Reset() {
+	*x = ListScheduledRidesResponse{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduledRidesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledRidesResponse) ProtoMessage() {}
+
+func (x *ListScheduledRidesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledRidesResponse.ProtoReflect.Descriptor instead.
+func (*ListScheduledRidesResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListScheduledRidesResponse) GetScheduledRides() []*ScheduledRide {
+	if x != nil {
+		return x.ScheduledRides
+	}
+	return nil
+}
+
+type CancelScheduledRideRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ScheduledRideId string                 `protobuf:"bytes,1,opt,name=scheduled_ride_id,json=scheduledRideId,proto3" json:"scheduled_ride_id,omitempty"`
+	RiderId         string                 `protobuf:"bytes,2,opt,name=rider_id,json=riderId,proto3" json:"rider_id,omitempty"`
+	Reason          string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CancelScheduledRideRequest) Reset() {
+	*x = CancelScheduledRideRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelScheduledRideRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduledRideRequest) ProtoMessage() {}
+
+func (x *CancelScheduledRideRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduledRideRequest.ProtoReflect.Descriptor instead.
+func (*CancelScheduledRideRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *CancelScheduledRideRequest) GetScheduledRideId() string {
+	if x != nil {
+		return x.ScheduledRideId
+	}
+	return ""
+}
+
+func (x *CancelScheduledRideRequest) GetRiderId() string {
+	if x != nil {
+		return x.RiderId
+	}
+	return ""
+}
+
+func (x *CancelScheduledRideRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// Ratings and reviews
+type Rating struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TripId        string                 `protobuf:"bytes,2,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	RaterId       string                 `protobuf:"bytes,3,opt,name=rater_id,json=raterId,proto3" json:"rater_id,omitempty"`
+	RateeId       string                 `protobuf:"bytes,4,opt,name=ratee_id,json=rateeId,proto3" json:"ratee_id,omitempty"`
+	RaterRole     string                 `protobuf:"bytes,5,opt,name=rater_role,json=raterRole,proto3" json:"rater_role,omitempty"` // "rider" or "driver"
+	Score         int32                  `protobuf:"varint,6,opt,name=score,proto3" json:"score,omitempty"`
+	Comment       string                 `protobuf:"bytes,7,opt,name=comment,proto3" json:"comment,omitempty"`
+	Tags          []string               `protobuf:"bytes,8,rep,name=tags,proto3" json:"tags,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Rating) Reset() {
+	*x = Rating{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Rating) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rating) ProtoMessage() {}
+
+func (x *Rating) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rating.ProtoReflect.Descriptor instead.
+func (*Rating) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *Rating) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Rating) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *Rating) GetRaterId() string {
+	if x != nil {
+		return x.RaterId
+	}
+	return ""
+}
+
+func (x *Rating) GetRateeId() string {
+	if x != nil {
+		return x.RateeId
+	}
+	return ""
+}
+
+func (x *Rating) GetRaterRole() string {
+	if x != nil {
+		return x.RaterRole
+	}
+	return ""
+}
+
+func (x *Rating) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Rating) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *Rating) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Rating) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type RatingSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"` // role being rated: "rider" or "driver"
+	AverageRating float64                `protobuf:"fixed64,3,opt,name=average_rating,json=averageRating,proto3" json:"average_rating,omitempty"`
+	RatingCount   int32                  `protobuf:"varint,4,opt,name=rating_count,json=ratingCount,proto3" json:"rating_count,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RatingSummary) Reset() {
+	*x = RatingSummary{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RatingSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RatingSummary) ProtoMessage() {}
+
+func (x *RatingSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RatingSummary.ProtoReflect.Descriptor instead.
+func (*RatingSummary) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *RatingSummary) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RatingSummary) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *RatingSummary) GetAverageRating() float64 {
+	if x != nil {
+		return x.AverageRating
+	}
+	return 0
+}
+
+func (x *RatingSummary) GetRatingCount() int32 {
+	if x != nil {
+		return x.RatingCount
+	}
+	return 0
+}
+
+func (x *RatingSummary) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type SubmitRatingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TripId        string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	RaterId       string                 `protobuf:"bytes,2,opt,name=rater_id,json=raterId,proto3" json:"rater_id,omitempty"`
+	Score         int32                  `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	Comment       string                 `protobuf:"bytes,4,opt,name=comment,proto3" json:"comment,omitempty"`
+	Tags          []string               `protobuf:"bytes,5,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitRatingRequest) Reset() {
+	*x = SubmitRatingRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitRatingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitRatingRequest) ProtoMessage() {}
+
+func (x *SubmitRatingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitRatingRequest.ProtoReflect.Descriptor instead.
+func (*SubmitRatingRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *SubmitRatingRequest) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *SubmitRatingRequest) GetRaterId() string {
+	if x != nil {
+		return x.RaterId
+	}
+	return ""
+}
+
+func (x *SubmitRatingRequest) GetScore() int32 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SubmitRatingRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *SubmitRatingRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type SubmitRatingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rating        *Rating                `protobuf:"bytes,1,opt,name=rating,proto3" json:"rating,omitempty"`
+	RateeSummary  *RatingSummary         `protobuf:"bytes,2,opt,name=ratee_summary,json=rateeSummary,proto3" json:"ratee_summary,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubmitRatingResponse) Reset() {
+	*x = SubmitRatingResponse{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubmitRatingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitRatingResponse) ProtoMessage() {}
+
+func (x *SubmitRatingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitRatingResponse.ProtoReflect.Descriptor instead.
+func (*SubmitRatingResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *SubmitRatingResponse) GetRating() *Rating {
+	if x != nil {
+		return x.Rating
+	}
+	return nil
+}
+
+func (x *SubmitRatingResponse) GetRateeSummary() *RatingSummary {
+	if x != nil {
+		return x.RateeSummary
+	}
+	return nil
+}
+
+type GetRatingSummaryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRatingSummaryRequest) Reset() {
+	*x = GetRatingSummaryRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRatingSummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRatingSummaryRequest) ProtoMessage() {}
+
+func (x *GetRatingSummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRatingSummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetRatingSummaryRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetRatingSummaryRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetRatingSummaryRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type ListReviewsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReviewsRequest) Reset() {
+	*x = ListReviewsRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReviewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReviewsRequest) ProtoMessage() {}
+
+func (x *ListReviewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReviewsRequest.ProtoReflect.Descriptor instead.
+func (*ListReviewsRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListReviewsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ListReviewsRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *ListReviewsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListReviewsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reviews       []*Rating              `protobuf:"bytes,1,rep,name=reviews,proto3" json:"reviews,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReviewsResponse) Reset() {
+	*x = ListReviewsResponse{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReviewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReviewsResponse) ProtoMessage() {}
+
+func (x *ListReviewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReviewsResponse.ProtoReflect.Descriptor instead.
+func (*ListReviewsResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListReviewsResponse) GetReviews() []*Rating {
+	if x != nil {
+		return x.Reviews
+	}
+	return nil
+}
+
+type GetDriverRatingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverIds     []string               `protobuf:"bytes,1,rep,name=driver_ids,json=driverIds,proto3" json:"driver_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDriverRatingsRequest) Reset() {
+	*x = GetDriverRatingsRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDriverRatingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDriverRatingsRequest) ProtoMessage() {}
+
+func (x *GetDriverRatingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDriverRatingsRequest.ProtoReflect.Descriptor instead.
+func (*GetDriverRatingsRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetDriverRatingsRequest) GetDriverIds() []string {
+	if x != nil {
+		return x.DriverIds
+	}
+	return nil
+}
+
+type GetDriverRatingsResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Ratings       map[string]*RatingSummary `protobuf:"bytes,1,rep,name=ratings,proto3" json:"ratings,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDriverRatingsResponse) Reset() {
+	*x = GetDriverRatingsResponse{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDriverRatingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDriverRatingsResponse) ProtoMessage() {}
+
+func (x *GetDriverRatingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDriverRatingsResponse.ProtoReflect.Descriptor instead.
+func (*GetDriverRatingsResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetDriverRatingsResponse) GetRatings() map[string]*RatingSummary {
+	if x != nil {
+		return x.Ratings
+	}
+	return nil
+}
+
+// How reliably a driver finishes the trips they take on. Counts decay with
+// age, recent trips counting most.
+type DriverReliability struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	DriverId string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	// Trips completed or cancelled by the driver
+	Trips            float64 `protobuf:"fixed64,2,opt,name=trips,proto3" json:"trips,omitempty"`
+	CancellationRate float64 `protobuf:"fixed64,3,opt,name=cancellation_rate,json=cancellationRate,proto3" json:"cancellation_rate,omitempty"`
+	// Trips completed since the driver last cancelled one
+	CompletionStreak float64 `protobuf:"fixed64,4,opt,name=completion_streak,json=completionStreak,proto3" json:"completion_streak,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DriverReliability) Reset() {
+	*x = DriverReliability{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriverReliability) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriverReliability) ProtoMessage() {}
+
+func (x *DriverReliability) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriverReliability.ProtoReflect.Descriptor instead.
+func (*DriverReliability) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *DriverReliability) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *DriverReliability) GetTrips() float64 {
+	if x != nil {
+		return x.Trips
+	}
+	return 0
+}
+
+func (x *DriverReliability) GetCancellationRate() float64 {
+	if x != nil {
+		return x.CancellationRate
+	}
+	return 0
+}
+
+func (x *DriverReliability) GetCompletionStreak() float64 {
+	if x != nil {
+		return x.CompletionStreak
+	}
+	return 0
+}
+
+type GetDriverReliabilityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverIds     []string               `protobuf:"bytes,1,rep,name=driver_ids,json=driverIds,proto3" json:"driver_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDriverReliabilityRequest) Reset() {
+	*x = GetDriverReliabilityRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDriverReliabilityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDriverReliabilityRequest) ProtoMessage() {}
+
+func (x *GetDriverReliabilityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDriverReliabilityRequest.ProtoReflect.Descriptor instead.
+func (*GetDriverReliabilityRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *GetDriverReliabilityRequest) GetDriverIds() []string {
+	if x != nil {
+		return x.DriverIds
+	}
+	return nil
+}
+
+type GetDriverReliabilityResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Keyed by driver ID, leaving out drivers without trips
+	Drivers       map[string]*DriverReliability `protobuf:"bytes,1,rep,name=drivers,proto3" json:"drivers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDriverReliabilityResponse) Reset() {
+	*x = GetDriverReliabilityResponse{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDriverReliabilityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDriverReliabilityResponse) ProtoMessage() {}
+
+func (x *GetDriverReliabilityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDriverReliabilityResponse.ProtoReflect.Descriptor instead.
+func (*GetDriverReliabilityResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *GetDriverReliabilityResponse) GetDrivers() map[string]*DriverReliability {
+	if x != nil {
+		return x.Drivers
+	}
+	return nil
+}
+
+// Trip share links let a rider's contacts follow a trip without an account.
+// A link only reveals the trip's progress, never the rider, fare or payment.
+type CreateTripShareLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TripId        string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	RiderId       string                 `protobuf:"bytes,2,opt,name=rider_id,json=riderId,proto3" json:"rider_id,omitempty"`
+	TtlMinutes    int32                  `protobuf:"varint,3,opt,name=ttl_minutes,json=ttlMinutes,proto3" json:"ttl_minutes,omitempty"` // 0 uses the service default
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTripShareLinkRequest) Reset() {
+	*x = CreateTripShareLinkRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTripShareLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTripShareLinkRequest) ProtoMessage() {}
+
+func (x *CreateTripShareLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTripShareLinkRequest.ProtoReflect.Descriptor instead.
+func (*CreateTripShareLinkRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *CreateTripShareLinkRequest) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *CreateTripShareLinkRequest) GetRiderId() string {
+	if x != nil {
+		return x.RiderId
+	}
+	return ""
+}
+
+func (x *CreateTripShareLinkRequest) GetTtlMinutes() int32 {
+	if x != nil {
+		return x.TtlMinutes
+	}
+	return 0
+}
+
+type CreateTripShareLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTripShareLinkResponse) Reset() {
+	*x = CreateTripShareLinkResponse{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTripShareLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTripShareLinkResponse) ProtoMessage() {}
+
+func (x *CreateTripShareLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTripShareLinkResponse.ProtoReflect.Descriptor instead.
+func (*CreateTripShareLinkResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *CreateTripShareLinkResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *CreateTripShareLinkResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type GetTripByShareTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTripByShareTokenRequest) Reset() {
+	*x = GetTripByShareTokenRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTripByShareTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTripByShareTokenRequest) ProtoMessage() {}
+
+func (x *GetTripByShareTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTripByShareTokenRequest.ProtoReflect.Descriptor instead.
+func (*GetTripByShareTokenRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *GetTripByShareTokenRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type SharedTripStatus struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TripId         string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	Status         TripStatus             `protobuf:"varint,2,opt,name=status,proto3,enum=trip.TripStatus" json:"status,omitempty"`
+	PickupLocation *Location              `protobuf:"bytes,3,opt,name=pickup_location,json=pickupLocation,proto3" json:"pickup_location,omitempty"`
+	Destination    *Location              `protobuf:"bytes,4,opt,name=destination,proto3" json:"destination,omitempty"`
+	DriverLocation *Location              `protobuf:"bytes,5,opt,name=driver_location,json=driverLocation,proto3" json:"driver_location,omitempty"` // only while the trip is active
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ExpiresAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Ended          bool                   `protobuf:"varint,8,opt,name=ended,proto3" json:"ended,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SharedTripStatus) Reset() {
+	*x = SharedTripStatus{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SharedTripStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SharedTripStatus) ProtoMessage() {}
+
+func (x *SharedTripStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SharedTripStatus.ProtoReflect.Descriptor instead.
+func (*SharedTripStatus) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *SharedTripStatus) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *SharedTripStatus) GetStatus() TripStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TripStatus_UNKNOWN_STATUS
+}
+
+func (x *SharedTripStatus) GetPickupLocation() *Location {
+	if x != nil {
+		return x.PickupLocation
+	}
+	return nil
+}
+
+func (x *SharedTripStatus) GetDestination() *Location {
+	if x != nil {
+		return x.Destination
+	}
+	return nil
+}
+
+func (x *SharedTripStatus) GetDriverLocation() *Location {
+	if x != nil {
+		return x.DriverLocation
+	}
+	return nil
+}
+
+func (x *SharedTripStatus) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *SharedTripStatus) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *SharedTripStatus) GetEnded() bool {
+	if x != nil {
+		return x.Ended
+	}
+	return false
+}
+
+// Emergency incidents raised by a trip's rider or driver and worked by
+// support staff. The location trail is frozen when the SOS is raised.
+type IncidentNote struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AuthorId      string                 `protobuf:"bytes,1,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncidentNote) Reset() {
+	*x = IncidentNote{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncidentNote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncidentNote) ProtoMessage() {}
+
+func (x *IncidentNote) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncidentNote.ProtoReflect.Descriptor instead.
+func (*IncidentNote) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *IncidentNote) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *IncidentNote) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *IncidentNote) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type Incident struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TripId         string                 `protobuf:"bytes,2,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	RiderId        string                 `protobuf:"bytes,3,opt,name=rider_id,json=riderId,proto3" json:"rider_id,omitempty"`
+	DriverId       string                 `protobuf:"bytes,4,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	ReporterId     string                 `protobuf:"bytes,5,opt,name=reporter_id,json=reporterId,proto3" json:"reporter_id,omitempty"`
+	ReporterRole   string                 `protobuf:"bytes,6,opt,name=reporter_role,json=reporterRole,proto3" json:"reporter_role,omitempty"` // "rider" or "driver"
+	Status         string                 `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`                                 // "open", "acknowledged" or "resolved"
+	TripStatus     TripStatus             `protobuf:"varint,8,opt,name=trip_status,json=tripStatus,proto3,enum=trip.TripStatus" json:"trip_status,omitempty"`
+	Location       *Location              `protobuf:"bytes,9,opt,name=location,proto3" json:"location,omitempty"`
+	LocationTrail  []*Location            `protobuf:"bytes,10,rep,name=location_trail,json=locationTrail,proto3" json:"location_trail,omitempty"`
+	AlertId        string                 `protobuf:"bytes,11,opt,name=alert_id,json=alertId,proto3" json:"alert_id,omitempty"`
+	Notes          []*IncidentNote        `protobuf:"bytes,12,rep,name=notes,proto3" json:"notes,omitempty"`
+	AcknowledgedBy string                 `protobuf:"bytes,13,opt,name=acknowledged_by,json=acknowledgedBy,proto3" json:"acknowledged_by,omitempty"`
+	AcknowledgedAt *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=acknowledged_at,json=acknowledgedAt,proto3" json:"acknowledged_at,omitempty"`
+	ResolvedBy     string                 `protobuf:"bytes,15,opt,name=resolved_by,json=resolvedBy,proto3" json:"resolved_by,omitempty"`
+	ResolvedAt     *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=resolved_at,json=resolvedAt,proto3" json:"resolved_at,omitempty"`
+	Resolution     string                 `protobuf:"bytes,17,opt,name=resolution,proto3" json:"resolution,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Incident) Reset() {
+	*x = Incident{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Incident) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Incident) ProtoMessage() {}
+
+func (x *Incident) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Incident.ProtoReflect.Descriptor instead.
+func (*Incident) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *Incident) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Incident) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *Incident) GetRiderId() string {
+	if x != nil {
+		return x.RiderId
+	}
+	return ""
+}
+
+func (x *Incident) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *Incident) GetReporterId() string {
+	if x != nil {
+		return x.ReporterId
+	}
+	return ""
+}
+
+func (x *Incident) GetReporterRole() string {
+	if x != nil {
+		return x.ReporterRole
+	}
+	return ""
+}
+
+func (x *Incident) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Incident) GetTripStatus() TripStatus {
+	if x != nil {
+		return x.TripStatus
+	}
+	return TripStatus_UNKNOWN_STATUS
+}
+
+func (x *Incident) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *Incident) GetLocationTrail() []*Location {
+	if x != nil {
+		return x.LocationTrail
+	}
+	return nil
+}
+
+func (x *Incident) GetAlertId() string {
+	if x != nil {
+		return x.AlertId
+	}
+	return ""
+}
+
+func (x *Incident) GetNotes() []*IncidentNote {
+	if x != nil {
+		return x.Notes
+	}
+	return nil
+}
+
+func (x *Incident) GetAcknowledgedBy() string {
+	if x != nil {
+		return x.AcknowledgedBy
+	}
+	return ""
+}
+
+func (x *Incident) GetAcknowledgedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AcknowledgedAt
+	}
+	return nil
+}
+
+func (x *Incident) GetResolvedBy() string {
+	if x != nil {
+		return x.ResolvedBy
+	}
+	return ""
+}
+
+func (x *Incident) GetResolvedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ResolvedAt
+	}
+	return nil
+}
+
+func (x *Incident) GetResolution() string {
+	if x != nil {
+		return x.Resolution
+	}
+	return ""
+}
+
+func (x *Incident) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Incident) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ReportSOSRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TripId        string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	ReporterId    string                 `protobuf:"bytes,2,opt,name=reporter_id,json=reporterId,proto3" json:"reporter_id,omitempty"`
+	Location      *Location              `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportSOSRequest) Reset() {
+	*x = ReportSOSRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportSOSRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportSOSRequest) ProtoMessage() {}
+
+func (x *ReportSOSRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportSOSRequest.ProtoReflect.Descriptor instead.
+func (*ReportSOSRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ReportSOSRequest) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *ReportSOSRequest) GetReporterId() string {
+	if x != nil {
+		return x.ReporterId
+	}
+	return ""
+}
+
+func (x *ReportSOSRequest) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *ReportSOSRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type GetIncidentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IncidentId    string                 `protobuf:"bytes,1,opt,name=incident_id,json=incidentId,proto3" json:"incident_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetIncidentRequest) Reset() {
+	*x = GetIncidentRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetIncidentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIncidentRequest) ProtoMessage() {}
+
+func (x *GetIncidentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIncidentRequest.ProtoReflect.Descriptor instead.
+func (*GetIncidentRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *GetIncidentRequest) GetIncidentId() string {
+	if x != nil {
+		return x.IncidentId
+	}
+	return ""
+}
+
+type ListIncidentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"` // empty for every status
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIncidentsRequest) Reset() {
+	*x = ListIncidentsRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIncidentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIncidentsRequest) ProtoMessage() {}
+
+func (x *ListIncidentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIncidentsRequest.ProtoReflect.Descriptor instead.
+func (*ListIncidentsRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ListIncidentsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListIncidentsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListIncidentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Incidents     []*Incident            `protobuf:"bytes,1,rep,name=incidents,proto3" json:"incidents,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIncidentsResponse) Reset() {
+	*x = ListIncidentsResponse{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIncidentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIncidentsResponse) ProtoMessage() {}
+
+func (x *ListIncidentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIncidentsResponse.ProtoReflect.Descriptor instead.
+func (*ListIncidentsResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ListIncidentsResponse) GetIncidents() []*Incident {
+	if x != nil {
+		return x.Incidents
+	}
+	return nil
+}
+
+type AcknowledgeIncidentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IncidentId    string                 `protobuf:"bytes,1,opt,name=incident_id,json=incidentId,proto3" json:"incident_id,omitempty"`
+	StaffId       string                 `protobuf:"bytes,2,opt,name=staff_id,json=staffId,proto3" json:"staff_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcknowledgeIncidentRequest) Reset() {
+	*x = AcknowledgeIncidentRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcknowledgeIncidentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcknowledgeIncidentRequest) ProtoMessage() {}
+
+func (x *AcknowledgeIncidentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcknowledgeIncidentRequest.ProtoReflect.Descriptor instead.
+func (*AcknowledgeIncidentRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *AcknowledgeIncidentRequest) GetIncidentId() string {
+	if x != nil {
+		return x.IncidentId
+	}
+	return ""
+}
+
+func (x *AcknowledgeIncidentRequest) GetStaffId() string {
+	if x != nil {
+		return x.StaffId
+	}
+	return ""
+}
+
+type AddIncidentNoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IncidentId    string                 `protobuf:"bytes,1,opt,name=incident_id,json=incidentId,proto3" json:"incident_id,omitempty"`
+	AuthorId      string                 `protobuf:"bytes,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddIncidentNoteRequest) Reset() {
+	*x = AddIncidentNoteRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddIncidentNoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddIncidentNoteRequest) ProtoMessage() {}
+
+func (x *AddIncidentNoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddIncidentNoteRequest.ProtoReflect.Descriptor instead.
+func (*AddIncidentNoteRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *AddIncidentNoteRequest) GetIncidentId() string {
+	if x != nil {
+		return x.IncidentId
+	}
+	return ""
+}
+
+func (x *AddIncidentNoteRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *AddIncidentNoteRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type ResolveIncidentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IncidentId    string                 `protobuf:"bytes,1,opt,name=incident_id,json=incidentId,proto3" json:"incident_id,omitempty"`
+	StaffId       string                 `protobuf:"bytes,2,opt,name=staff_id,json=staffId,proto3" json:"staff_id,omitempty"`
+	Resolution    string                 `protobuf:"bytes,3,opt,name=resolution,proto3" json:"resolution,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveIncidentRequest) Reset() {
+	*x = ResolveIncidentRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveIncidentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveIncidentRequest) ProtoMessage() {}
+
+func (x *ResolveIncidentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveIncidentRequest.ProtoReflect.Descriptor instead.
+func (*ResolveIncidentRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ResolveIncidentRequest) GetIncidentId() string {
+	if x != nil {
+		return x.IncidentId
+	}
+	return ""
+}
+
+func (x *ResolveIncidentRequest) GetStaffId() string {
+	if x != nil {
+		return x.StaffId
+	}
+	return ""
+}
+
+func (x *ResolveIncidentRequest) GetResolution() string {
+	if x != nil {
+		return x.Resolution
+	}
+	return ""
+}
+
+// Fare disputes opened by riders and reviewed by support staff. The fare
+// charged is frozen when the dispute is opened; resolving it with a refund
+// refunds that much of the trip's charge through payment-service.
+type DisputeEvidence struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Description   string                 `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisputeEvidence) Reset() {
+	*x = DisputeEvidence{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisputeEvidence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisputeEvidence) ProtoMessage() {}
+
+func (x *DisputeEvidence) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisputeEvidence.ProtoReflect.Descriptor instead.
+func (*DisputeEvidence) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *DisputeEvidence) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *DisputeEvidence) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type DisputeNote struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AuthorId      string                 `protobuf:"bytes,1,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisputeNote) Reset() {
+	*x = DisputeNote{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisputeNote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisputeNote) ProtoMessage() {}
+
+func (x *DisputeNote) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisputeNote.ProtoReflect.Descriptor instead.
+func (*DisputeNote) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *DisputeNote) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *DisputeNote) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *DisputeNote) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type FareLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // "base_fare", "distance_fare", "surge_amount", ...
+	Amount        float64                `protobuf:"fixed64,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FareLine) Reset() {
+	*x = FareLine{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FareLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FareLine) ProtoMessage() {}
+
+func (x *FareLine) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FareLine.ProtoReflect.Descriptor instead.
+func (*FareLine) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *FareLine) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FareLine) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type Dispute struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TripId        string                 `protobuf:"bytes,2,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	RiderId       string                 `protobuf:"bytes,3,opt,name=rider_id,json=riderId,proto3" json:"rider_id,omitempty"`
+	DriverId      string                 `protobuf:"bytes,4,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"` // "overcharged", "wrong_route", "trip_not_taken", "cancellation_fee" or "other"
+	Description   string                 `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	Evidence      []*DisputeEvidence     `protobuf:"bytes,7,rep,name=evidence,proto3" json:"evidence,omitempty"`
+	Status        string                 `protobuf:"bytes,8,opt,name=status,proto3" json:"status,omitempty"` // "open", "in_review", "resolved" or "rejected"
+	ChargedAmount float64                `protobuf:"fixed64,9,opt,name=charged_amount,json=chargedAmount,proto3" json:"charged_amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,10,opt,name=currency,proto3" json:"currency,omitempty"`
+	FareBreakdown []*FareLine            `protobuf:"bytes,11,rep,name=fare_breakdown,json=fareBreakdown,proto3" json:"fare_breakdown,omitempty"`
+	AssignedTo    string                 `protobuf:"bytes,12,opt,name=assigned_to,json=assignedTo,proto3" json:"assigned_to,omitempty"`
+	Notes         []*DisputeNote         `protobuf:"bytes,13,rep,name=notes,proto3" json:"notes,omitempty"`
+	Resolution    string                 `protobuf:"bytes,14,opt,name=resolution,proto3" json:"resolution,omitempty"`
+	RefundAmount  float64                `protobuf:"fixed64,15,opt,name=refund_amount,json=refundAmount,proto3" json:"refund_amount,omitempty"`
+	RefundId      string                 `protobuf:"bytes,16,opt,name=refund_id,json=refundId,proto3" json:"refund_id,omitempty"`
+	ResolvedBy    string                 `protobuf:"bytes,17,opt,name=resolved_by,json=resolvedBy,proto3" json:"resolved_by,omitempty"`
+	ResolvedAt    *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=resolved_at,json=resolvedAt,proto3" json:"resolved_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,20,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Dispute) Reset() {
+	*x = Dispute{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Dispute) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Dispute) ProtoMessage() {}
+
+func (x *Dispute) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Dispute.ProtoReflect.Descriptor instead.
+func (*Dispute) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *Dispute) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Dispute) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *Dispute) GetRiderId() string {
+	if x != nil {
+		return x.RiderId
+	}
+	return ""
+}
+
+func (x *Dispute) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *Dispute) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *Dispute) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Dispute) GetEvidence() []*DisputeEvidence {
+	if x != nil {
+		return x.Evidence
+	}
+	return nil
+}
+
+func (x *Dispute) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Dispute) GetChargedAmount() float64 {
+	if x != nil {
+		return x.ChargedAmount
+	}
+	return 0
+}
+
+func (x *Dispute) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Dispute) GetFareBreakdown() []*FareLine {
+	if x != nil {
+		return x.FareBreakdown
+	}
+	return nil
+}
+
+func (x *Dispute) GetAssignedTo() string {
+	if x != nil {
+		return x.AssignedTo
+	}
+	return ""
+}
+
+func (x *Dispute) GetNotes() []*DisputeNote {
+	if x != nil {
+		return x.Notes
+	}
+	return nil
+}
+
+func (x *Dispute) GetResolution() string {
+	if x != nil {
+		return x.Resolution
+	}
+	return ""
+}
+
+func (x *Dispute) GetRefundAmount() float64 {
+	if x != nil {
+		return x.RefundAmount
+	}
+	return 0
+}
+
+func (x *Dispute) GetRefundId() string {
+	if x != nil {
+		return x.RefundId
+	}
+	return ""
+}
+
+func (x *Dispute) GetResolvedBy() string {
+	if x != nil {
+		return x.ResolvedBy
+	}
+	return ""
+}
+
+func (x *Dispute) GetResolvedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ResolvedAt
+	}
+	return nil
+}
+
+func (x *Dispute) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Dispute) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// DisputeReview is what support staff see when reviewing a dispute: the
+// dispute and the route geo-service recorded for the trip, falling back to
+// the route the driver's app reported
+type DisputeReview struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Dispute            *Dispute               `protobuf:"bytes,1,opt,name=dispute,proto3" json:"dispute,omitempty"`
+	Route              []*Location            `protobuf:"bytes,2,rep,name=route,proto3" json:"route,omitempty"`
+	RouteRecorded      bool                   `protobuf:"varint,3,opt,name=route_recorded,json=routeRecorded,proto3" json:"route_recorded,omitempty"` // false when the route is the one the app reported
+	RouteDistanceKm    float64                `protobuf:"fixed64,4,opt,name=route_distance_km,json=routeDistanceKm,proto3" json:"route_distance_km,omitempty"`
+	ReportedDistanceKm float64                `protobuf:"fixed64,5,opt,name=reported_distance_km,json=reportedDistanceKm,proto3" json:"reported_distance_km,omitempty"`
+	DurationSeconds    int32                  `protobuf:"varint,6,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *DisputeReview) Reset() {
+	*x = DisputeReview{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisputeReview) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisputeReview) ProtoMessage() {}
+
+func (x *DisputeReview) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisputeReview.ProtoReflect.Descriptor instead.
+func (*DisputeReview) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *DisputeReview) GetDispute() *Dispute {
+	if x != nil {
+		return x.Dispute
+	}
+	return nil
+}
+
+func (x *DisputeReview) GetRoute() []*Location {
+	if x != nil {
+		return x.Route
+	}
+	return nil
+}
+
+func (x *DisputeReview) GetRouteRecorded() bool {
+	if x != nil {
+		return x.RouteRecorded
+	}
+	return false
+}
+
+func (x *DisputeReview) GetRouteDistanceKm() float64 {
+	if x != nil {
+		return x.RouteDistanceKm
+	}
+	return 0
+}
+
+func (x *DisputeReview) GetReportedDistanceKm() float64 {
+	if x != nil {
+		return x.ReportedDistanceKm
+	}
+	return 0
+}
+
+func (x *DisputeReview) GetDurationSeconds() int32 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+type OpenDisputeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TripId        string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	RiderId       string                 `protobuf:"bytes,2,opt,name=rider_id,json=riderId,proto3" json:"rider_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Evidence      []*DisputeEvidence     `protobuf:"bytes,5,rep,name=evidence,proto3" json:"evidence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OpenDisputeRequest) Reset() {
+	*x = OpenDisputeRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OpenDisputeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OpenDisputeRequest) ProtoMessage() {}
+
+func (x *OpenDisputeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OpenDisputeRequest.ProtoReflect.Descriptor instead.
+func (*OpenDisputeRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *OpenDisputeRequest) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *OpenDisputeRequest) GetRiderId() string {
+	if x != nil {
+		return x.RiderId
+	}
+	return ""
+}
+
+func (x *OpenDisputeRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *OpenDisputeRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *OpenDisputeRequest) GetEvidence() []*DisputeEvidence {
+	if x != nil {
+		return x.Evidence
+	}
+	return nil
+}
+
+type GetDisputeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DisputeId     string                 `protobuf:"bytes,1,opt,name=dispute_id,json=disputeId,proto3" json:"dispute_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDisputeRequest) Reset() {
+	*x = GetDisputeRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDisputeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDisputeRequest) ProtoMessage() {}
+
+func (x *GetDisputeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDisputeRequest.ProtoReflect.Descriptor instead.
+func (*GetDisputeRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *GetDisputeRequest) GetDisputeId() string {
+	if x != nil {
+		return x.DisputeId
+	}
+	return ""
+}
+
+type ListDisputesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"` // empty for every status
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDisputesRequest) Reset() {
+	*x = ListDisputesRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDisputesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDisputesRequest) ProtoMessage() {}
+
+func (x *ListDisputesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDisputesRequest.ProtoReflect.Descriptor instead.
+func (*ListDisputesRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *ListDisputesRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListDisputesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListDisputesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Disputes      []*Dispute             `protobuf:"bytes,1,rep,name=disputes,proto3" json:"disputes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDisputesResponse) Reset() {
+	*x = ListDisputesResponse{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDisputesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDisputesResponse) ProtoMessage() {}
+
+func (x *ListDisputesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDisputesResponse.ProtoReflect.Descriptor instead.
+func (*ListDisputesResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *ListDisputesResponse) GetDisputes() []*Dispute {
+	if x != nil {
+		return x.Disputes
+	}
+	return nil
+}
+
+type StartDisputeReviewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DisputeId     string                 `protobuf:"bytes,1,opt,name=dispute_id,json=disputeId,proto3" json:"dispute_id,omitempty"`
+	StaffId       string                 `protobuf:"bytes,2,opt,name=staff_id,json=staffId,proto3" json:"staff_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartDisputeReviewRequest) Reset() {
+	*x = StartDisputeReviewRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartDisputeReviewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartDisputeReviewRequest) ProtoMessage() {}
+
+func (x *StartDisputeReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartDisputeReviewRequest.ProtoReflect.Descriptor instead.
+func (*StartDisputeReviewRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *StartDisputeReviewRequest) GetDisputeId() string {
+	if x != nil {
+		return x.DisputeId
+	}
+	return ""
+}
+
+func (x *StartDisputeReviewRequest) GetStaffId() string {
+	if x != nil {
+		return x.StaffId
+	}
+	return ""
+}
+
+type AddDisputeNoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DisputeId     string                 `protobuf:"bytes,1,opt,name=dispute_id,json=disputeId,proto3" json:"dispute_id,omitempty"`
+	AuthorId      string                 `protobuf:"bytes,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddDisputeNoteRequest) Reset() {
+	*x = AddDisputeNoteRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddDisputeNoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddDisputeNoteRequest) ProtoMessage() {}
+
+func (x *AddDisputeNoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddDisputeNoteRequest.ProtoReflect.Descriptor instead.
+func (*AddDisputeNoteRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *AddDisputeNoteRequest) GetDisputeId() string {
+	if x != nil {
+		return x.DisputeId
+	}
+	return ""
+}
+
+func (x *AddDisputeNoteRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *AddDisputeNoteRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type ResolveDisputeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DisputeId     string                 `protobuf:"bytes,1,opt,name=dispute_id,json=disputeId,proto3" json:"dispute_id,omitempty"`
+	StaffId       string                 `protobuf:"bytes,2,opt,name=staff_id,json=staffId,proto3" json:"staff_id,omitempty"`
+	Resolution    string                 `protobuf:"bytes,3,opt,name=resolution,proto3" json:"resolution,omitempty"`
+	RefundAmount  float64                `protobuf:"fixed64,4,opt,name=refund_amount,json=refundAmount,proto3" json:"refund_amount,omitempty"` // 0 upholds the fare without a refund
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveDisputeRequest) Reset() {
+	*x = ResolveDisputeRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveDisputeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveDisputeRequest) ProtoMessage() {}
+
+func (x *ResolveDisputeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveDisputeRequest.ProtoReflect.Descriptor instead.
+func (*ResolveDisputeRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *ResolveDisputeRequest) GetDisputeId() string {
+	if x != nil {
+		return x.DisputeId
+	}
+	return ""
+}
+
+func (x *ResolveDisputeRequest) GetStaffId() string {
+	if x != nil {
+		return x.StaffId
+	}
+	return ""
+}
+
+func (x *ResolveDisputeRequest) GetResolution() string {
+	if x != nil {
+		return x.Resolution
+	}
+	return ""
+}
+
+func (x *ResolveDisputeRequest) GetRefundAmount() float64 {
+	if x != nil {
+		return x.RefundAmount
+	}
+	return 0
+}
+
+type RejectDisputeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DisputeId     string                 `protobuf:"bytes,1,opt,name=dispute_id,json=disputeId,proto3" json:"dispute_id,omitempty"`
+	StaffId       string                 `protobuf:"bytes,2,opt,name=staff_id,json=staffId,proto3" json:"staff_id,omitempty"`
+	Resolution    string                 `protobuf:"bytes,3,opt,name=resolution,proto3" json:"resolution,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectDisputeRequest) Reset() {
+	*x = RejectDisputeRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectDisputeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectDisputeRequest) ProtoMessage() {}
+
+func (x *RejectDisputeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectDisputeRequest.ProtoReflect.Descriptor instead.
+func (*RejectDisputeRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *RejectDisputeRequest) GetDisputeId() string {
+	if x != nil {
+		return x.DisputeId
+	}
+	return ""
+}
+
+func (x *RejectDisputeRequest) GetStaffId() string {
+	if x != nil {
+		return x.StaffId
+	}
+	return ""
+}
+
+func (x *RejectDisputeRequest) GetResolution() string {
+	if x != nil {
+		return x.Resolution
+	}
+	return ""
+}
+
+// Lost items riders report after a completed trip. The trip chat reopens
+// for the rider and driver until contact_until or the report is closed.
+type LostItemNote struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AuthorId      string                 `protobuf:"bytes,1,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // set when the note records a status change
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LostItemNote) Reset() {
+	*x = LostItemNote{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LostItemNote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LostItemNote) ProtoMessage() {}
+
+func (x *LostItemNote) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LostItemNote.ProtoReflect.Descriptor instead.
+func (*LostItemNote) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *LostItemNote) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *LostItemNote) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *LostItemNote) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *LostItemNote) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type LostItemReport struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TripId           string                 `protobuf:"bytes,2,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	RiderId          string                 `protobuf:"bytes,3,opt,name=rider_id,json=riderId,proto3" json:"rider_id,omitempty"`
+	DriverId         string                 `protobuf:"bytes,4,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	Description      string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	Status           string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"` // reported, found, returned or not_found
+	DriverNotifiedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=driver_notified_at,json=driverNotifiedAt,proto3" json:"driver_notified_at,omitempty"`
+	ContactUntil     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=contact_until,json=contactUntil,proto3" json:"contact_until,omitempty"`
+	ContactOpen      bool                   `protobuf:"varint,9,opt,name=contact_open,json=contactOpen,proto3" json:"contact_open,omitempty"`
+	Notes            []*LostItemNote        `protobuf:"bytes,10,rep,name=notes,proto3" json:"notes,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *LostItemReport) Reset() {
+	*x = LostItemReport{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LostItemReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LostItemReport) ProtoMessage() {}
+
+func (x *LostItemReport) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LostItemReport.ProtoReflect.Descriptor instead.
+func (*LostItemReport) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *LostItemReport) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LostItemReport) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *LostItemReport) GetRiderId() string {
+	if x != nil {
+		return x.RiderId
+	}
+	return ""
+}
+
+func (x *LostItemReport) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *LostItemReport) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *LostItemReport) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *LostItemReport) GetDriverNotifiedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DriverNotifiedAt
+	}
+	return nil
+}
+
+func (x *LostItemReport) GetContactUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ContactUntil
+	}
+	return nil
+}
+
+func (x *LostItemReport) GetContactOpen() bool {
+	if x != nil {
+		return x.ContactOpen
+	}
+	return false
+}
+
+func (x *LostItemReport) GetNotes() []*LostItemNote {
+	if x != nil {
+		return x.Notes
+	}
+	return nil
+}
+
+func (x *LostItemReport) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *LostItemReport) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ReportLostItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TripId        string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	RiderId       string                 `protobuf:"bytes,2,opt,name=rider_id,json=riderId,proto3" json:"rider_id,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportLostItemRequest) Reset() {
+	*x = ReportLostItemRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportLostItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportLostItemRequest) ProtoMessage() {}
+
+func (x *ReportLostItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportLostItemRequest.ProtoReflect.Descriptor instead.
+func (*ReportLostItemRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *ReportLostItemRequest) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *ReportLostItemRequest) GetRiderId() string {
+	if x != nil {
+		return x.RiderId
+	}
+	return ""
+}
+
+func (x *ReportLostItemRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type GetLostItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReportId      string                 `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLostItemRequest) Reset() {
+	*x = GetLostItemRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLostItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLostItemRequest) ProtoMessage() {}
+
+func (x *GetLostItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLostItemRequest.ProtoReflect.Descriptor instead.
+func (*GetLostItemRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *GetLostItemRequest) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+type ListLostItemsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	RiderId       string                 `protobuf:"bytes,2,opt,name=rider_id,json=riderId,proto3" json:"rider_id,omitempty"`
+	DriverId      string                 `protobuf:"bytes,3,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	TripId        string                 `protobuf:"bytes,4,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLostItemsRequest) Reset() {
+	*x = ListLostItemsRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLostItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLostItemsRequest) ProtoMessage() {}
+
+func (x *ListLostItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLostItemsRequest.ProtoReflect.Descriptor instead.
+func (*ListLostItemsRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *ListLostItemsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListLostItemsRequest) GetRiderId() string {
+	if x != nil {
+		return x.RiderId
+	}
+	return ""
+}
+
+func (x *ListLostItemsRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *ListLostItemsRequest) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *ListLostItemsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListLostItemsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reports       []*LostItemReport      `protobuf:"bytes,1,rep,name=reports,proto3" json:"reports,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLostItemsResponse) Reset() {
+	*x = ListLostItemsResponse{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLostItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLostItemsResponse) ProtoMessage() {}
+
+func (x *ListLostItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLostItemsResponse.ProtoReflect.Descriptor instead.
+func (*ListLostItemsResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *ListLostItemsResponse) GetReports() []*LostItemReport {
+	if x != nil {
+		return x.Reports
+	}
+	return nil
+}
+
+type UpdateLostItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReportId      string                 `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	AuthorId      string                 `protobuf:"bytes,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"` // empty to only add a note
+	Note          string                 `protobuf:"bytes,4,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateLostItemRequest) Reset() {
+	*x = UpdateLostItemRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLostItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLostItemRequest) ProtoMessage() {}
+
+func (x *UpdateLostItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLostItemRequest.ProtoReflect.Descriptor instead.
+func (*UpdateLostItemRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *UpdateLostItemRequest) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+func (x *UpdateLostItemRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *UpdateLostItemRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UpdateLostItemRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+// Contact handles for calling the other party of an active trip without
+// seeing their phone number: a masked number to dial or an in-app call token
+type GetTripContactRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TripId        string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTripContactRequest) Reset() {
+	*x = GetTripContactRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTripContactRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTripContactRequest) ProtoMessage() {}
+
+func (x *GetTripContactRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTripContactRequest.ProtoReflect.Descriptor instead.
+func (*GetTripContactRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *GetTripContactRequest) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *GetTripContactRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type TripContact struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TripId        string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	Mode          string                 `protobuf:"bytes,2,opt,name=mode,proto3" json:"mode,omitempty"` // masked_number or voip
+	MaskedNumber  string                 `protobuf:"bytes,3,opt,name=masked_number,json=maskedNumber,proto3" json:"masked_number,omitempty"`
+	VoipToken     string                 `protobuf:"bytes,4,opt,name=voip_token,json=voipToken,proto3" json:"voip_token,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TripContact) Reset() {
+	*x = TripContact{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TripContact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TripContact) ProtoMessage() {}
+
+func (x *TripContact) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TripContact.ProtoReflect.Descriptor instead.
+func (*TripContact) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *TripContact) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *TripContact) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *TripContact) GetMaskedNumber() string {
+	if x != nil {
+		return x.MaskedNumber
+	}
+	return ""
+}
+
+func (x *TripContact) GetVoipToken() string {
+	if x != nil {
+		return x.VoipToken
+	}
+	return ""
+}
+
+func (x *TripContact) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+// On-time pickup guarantees: the pickup time a rider was promised when their
+// trip was matched, and whether the driver kept it
+type GetPickupGuaranteeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TripId        string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPickupGuaranteeRequest) Reset() {
+	*x = GetPickupGuaranteeRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPickupGuaranteeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPickupGuaranteeRequest) ProtoMessage() {}
+
+func (x *GetPickupGuaranteeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPickupGuaranteeRequest.ProtoReflect.Descriptor instead.
+func (*GetPickupGuaranteeRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *GetPickupGuaranteeRequest) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+type PickupGuarantee struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TripId        string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	RiderId       string                 `protobuf:"bytes,2,opt,name=rider_id,json=riderId,proto3" json:"rider_id,omitempty"`
+	DriverId      string                 `protobuf:"bytes,3,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	CityId        string                 `protobuf:"bytes,4,opt,name=city_id,json=cityId,proto3" json:"city_id,omitempty"`
+	Area          string                 `protobuf:"bytes,5,opt,name=area,proto3" json:"area,omitempty"`
+	Status        string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"` // pending, kept, missed or void
+	MatchedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=matched_at,json=matchedAt,proto3" json:"matched_at,omitempty"`
+	PromisedAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=promised_at,json=promisedAt,proto3" json:"promised_at,omitempty"`
+	PickedUpAt    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=picked_up_at,json=pickedUpAt,proto3" json:"picked_up_at,omitempty"`
+	LateBySeconds int64                  `protobuf:"varint,10,opt,name=late_by_seconds,json=lateBySeconds,proto3" json:"late_by_seconds,omitempty"`
+	CreditAmount  float64                `protobuf:"fixed64,11,opt,name=credit_amount,json=creditAmount,proto3" json:"credit_amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,12,opt,name=currency,proto3" json:"currency,omitempty"`
+	CreditId      string                 `protobuf:"bytes,13,opt,name=credit_id,json=creditId,proto3" json:"credit_id,omitempty"`
+	CreditError   string                 `protobuf:"bytes,14,opt,name=credit_error,json=creditError,proto3" json:"credit_error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PickupGuarantee) Reset() {
+	*x = PickupGuarantee{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PickupGuarantee) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PickupGuarantee) ProtoMessage() {}
+
+func (x *PickupGuarantee) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PickupGuarantee.ProtoReflect.Descriptor instead.
+func (*PickupGuarantee) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *PickupGuarantee) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *PickupGuarantee) GetRiderId() string {
+	if x != nil {
+		return x.RiderId
+	}
+	return ""
+}
+
+func (x *PickupGuarantee) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *PickupGuarantee) GetCityId() string {
+	if x != nil {
+		return x.CityId
+	}
+	return ""
+}
+
+func (x *PickupGuarantee) GetArea() string {
+	if x != nil {
+		return x.Area
+	}
+	return ""
+}
+
+func (x *PickupGuarantee) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PickupGuarantee) GetMatchedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MatchedAt
+	}
+	return nil
+}
+
+func (x *PickupGuarantee) GetPromisedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PromisedAt
+	}
+	return nil
+}
+
+func (x *PickupGuarantee) GetPickedUpAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PickedUpAt
+	}
+	return nil
+}
+
+func (x *PickupGuarantee) GetLateBySeconds() int64 {
+	if x != nil {
+		return x.LateBySeconds
+	}
+	return 0
+}
+
+func (x *PickupGuarantee) GetCreditAmount() float64 {
+	if x != nil {
+		return x.CreditAmount
+	}
+	return 0
+}
+
+func (x *PickupGuarantee) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *PickupGuarantee) GetCreditId() string {
+	if x != nil {
+		return x.CreditId
+	}
+	return ""
+}
+
+func (x *PickupGuarantee) GetCreditError() string {
+	if x != nil {
+		return x.CreditError
+	}
+	return ""
+}
+
+// How drivers and areas kept the pickup guarantees of trips matched in
+// [from, to), in one city or all of them when city_id is empty
+type GetPickupSLAReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	CityId        string                 `protobuf:"bytes,3,opt,name=city_id,json=cityId,proto3" json:"city_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPickupSLAReportRequest) Reset() {
+	*x = GetPickupSLAReportRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPickupSLAReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPickupSLAReportRequest) ProtoMessage() {}
+
+func (x *GetPickupSLAReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPickupSLAReportRequest.ProtoReflect.Descriptor instead.
+func (*GetPickupSLAReportRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *GetPickupSLAReportRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *GetPickupSLAReportRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *GetPickupSLAReportRequest) GetCityId() string {
+	if x != nil {
+		return x.CityId
+	}
+	return ""
+}
+
+type PickupSLAGroup struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Key                string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"` // driver ID or area geohash
+	Judged             int64                  `protobuf:"varint,2,opt,name=judged,proto3" json:"judged,omitempty"`
+	Missed             int64                  `protobuf:"varint,3,opt,name=missed,proto3" json:"missed,omitempty"`
+	MissRate           float64                `protobuf:"fixed64,4,opt,name=miss_rate,json=missRate,proto3" json:"miss_rate,omitempty"`
+	AverageLateSeconds float64                `protobuf:"fixed64,5,opt,name=average_late_seconds,json=averageLateSeconds,proto3" json:"average_late_seconds,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *PickupSLAGroup) Reset() {
+	*x = PickupSLAGroup{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PickupSLAGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PickupSLAGroup) ProtoMessage() {}
+
+func (x *PickupSLAGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PickupSLAGroup.ProtoReflect.Descriptor instead.
+func (*PickupSLAGroup) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *PickupSLAGroup) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *PickupSLAGroup) GetJudged() int64 {
+	if x != nil {
+		return x.Judged
+	}
+	return 0
+}
+
+func (x *PickupSLAGroup) GetMissed() int64 {
+	if x != nil {
+		return x.Missed
+	}
+	return 0
+}
+
+func (x *PickupSLAGroup) GetMissRate() float64 {
+	if x != nil {
+		return x.MissRate
+	}
+	return 0
+}
+
+func (x *PickupSLAGroup) GetAverageLateSeconds() float64 {
+	if x != nil {
+		return x.AverageLateSeconds
+	}
+	return 0
+}
+
+type PickupSLAReport struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	From              *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To                *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	CityId            string                 `protobuf:"bytes,3,opt,name=city_id,json=cityId,proto3" json:"city_id,omitempty"`
+	Judged            int64                  `protobuf:"varint,4,opt,name=judged,proto3" json:"judged,omitempty"`
+	Missed            int64                  `protobuf:"varint,5,opt,name=missed,proto3" json:"missed,omitempty"`
+	MissRate          float64                `protobuf:"fixed64,6,opt,name=miss_rate,json=missRate,proto3" json:"miss_rate,omitempty"`
+	CreditsByCurrency map[string]float64     `protobuf:"bytes,7,rep,name=credits_by_currency,json=creditsByCurrency,proto3" json:"credits_by_currency,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	ByDriver          []*PickupSLAGroup      `protobuf:"bytes,8,rep,name=by_driver,json=byDriver,proto3" json:"by_driver,omitempty"`
+	ByArea            []*PickupSLAGroup      `protobuf:"bytes,9,rep,name=by_area,json=byArea,proto3" json:"by_area,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *PickupSLAReport) Reset() {
+	*x = PickupSLAReport{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PickupSLAReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PickupSLAReport) ProtoMessage() {}
+
+func (x *PickupSLAReport) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PickupSLAReport.ProtoReflect.Descriptor instead.
+func (*PickupSLAReport) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *PickupSLAReport) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *PickupSLAReport) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *PickupSLAReport) GetCityId() string {
+	if x != nil {
+		return x.CityId
+	}
+	return ""
+}
+
+func (x *PickupSLAReport) GetJudged() int64 {
+	if x != nil {
+		return x.Judged
+	}
+	return 0
+}
+
+func (x *PickupSLAReport) GetMissed() int64 {
+	if x != nil {
+		return x.Missed
+	}
+	return 0
+}
+
+func (x *PickupSLAReport) GetMissRate() float64 {
+	if x != nil {
+		return x.MissRate
+	}
+	return 0
+}
+
+func (x *PickupSLAReport) GetCreditsByCurrency() map[string]float64 {
+	if x != nil {
+		return x.CreditsByCurrency
+	}
+	return nil
+}
+
+func (x *PickupSLAReport) GetByDriver() []*PickupSLAGroup {
+	if x != nil {
+		return x.ByDriver
+	}
+	return nil
+}
+
+func (x *PickupSLAReport) GetByArea() []*PickupSLAGroup {
+	if x != nil {
+		return x.ByArea
+	}
+	return nil
+}
+
+// Trip data reported to a city's regulator for one period, generated from a
+// jurisdiction's template with its anonymization rules applied. Content is
+// only returned by GetComplianceReport.
+type ComplianceReport struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TemplateId          string                 `protobuf:"bytes,2,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	TemplateName        string                 `protobuf:"bytes,3,opt,name=template_name,json=templateName,proto3" json:"template_name,omitempty"`
+	CityId              string                 `protobuf:"bytes,4,opt,name=city_id,json=cityId,proto3" json:"city_id,omitempty"`
+	Schedule            string                 `protobuf:"bytes,5,opt,name=schedule,proto3" json:"schedule,omitempty"` // daily, weekly or monthly
+	PeriodStart         *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=period_start,json=periodStart,proto3" json:"period_start,omitempty"`
+	PeriodEnd           *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=period_end,json=periodEnd,proto3" json:"period_end,omitempty"`
+	Timezone            string                 `protobuf:"bytes,8,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	TripCount           int64                  `protobuf:"varint,9,opt,name=trip_count,json=tripCount,proto3" json:"trip_count,omitempty"`
+	AccessibleTripCount int64                  `protobuf:"varint,10,opt,name=accessible_trip_count,json=accessibleTripCount,proto3" json:"accessible_trip_count,omitempty"`
+	SuppressedZoneCount int64                  `protobuf:"varint,11,opt,name=suppressed_zone_count,json=suppressedZoneCount,proto3" json:"suppressed_zone_count,omitempty"`
+	ContentType         string                 `protobuf:"bytes,12,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Content             []byte                 `protobuf:"bytes,13,opt,name=content,proto3" json:"content,omitempty"`
+	GeneratedBy         string                 `protobuf:"bytes,14,opt,name=generated_by,json=generatedBy,proto3" json:"generated_by,omitempty"`
+	GeneratedAt         *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=generated_at,json=generatedAt,proto3" json:"generated_at,omitempty"`
+	// Wheelchair-accessible trips requested in the period that have since
+	// completed, been cancelled or failed, and how many completed
+	WavRequestCount    int64   `protobuf:"varint,16,opt,name=wav_request_count,json=wavRequestCount,proto3" json:"wav_request_count,omitempty"`
+	WavFulfilledCount  int64   `protobuf:"varint,17,opt,name=wav_fulfilled_count,json=wavFulfilledCount,proto3" json:"wav_fulfilled_count,omitempty"`
+	WavFulfillmentRate float64 `protobuf:"fixed64,18,opt,name=wav_fulfillment_rate,json=wavFulfillmentRate,proto3" json:"wav_fulfillment_rate,omitempty"`
+	// Average wait of fulfilled WAV requests from request to driver arrival
+	WavAverageWaitSeconds float64 `protobuf:"fixed64,19,opt,name=wav_average_wait_seconds,json=wavAverageWaitSeconds,proto3" json:"wav_average_wait_seconds,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *ComplianceReport) Reset() {
+	*x = ComplianceReport{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComplianceReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComplianceReport) ProtoMessage() {}
+
+func (x *ComplianceReport) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComplianceReport.ProtoReflect.Descriptor instead.
+func (*ComplianceReport) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *ComplianceReport) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ComplianceReport) GetTemplateId() string {
+	if x != nil {
+		return x.TemplateId
+	}
+	return ""
+}
+
+func (x *ComplianceReport) GetTemplateName() string {
+	if x != nil {
+		return x.TemplateName
+	}
+	return ""
+}
+
+func (x *ComplianceReport) GetCityId() string {
+	if x != nil {
+		return x.CityId
+	}
+	return ""
+}
+
+func (x *ComplianceReport) GetSchedule() string {
+	if x != nil {
+		return x.Schedule
+	}
+	return ""
+}
+
+func (x *ComplianceReport) GetPeriodStart() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeriodStart
+	}
+	return nil
+}
+
+func (x *ComplianceReport) GetPeriodEnd() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PeriodEnd
+	}
+	return nil
+}
+
+func (x *ComplianceReport) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *ComplianceReport) GetTripCount() int64 {
+	if x != nil {
+		return x.TripCount
+	}
+	return 0
+}
+
+func (x *ComplianceReport) GetAccessibleTripCount() int64 {
+	if x != nil {
+		return x.AccessibleTripCount
+	}
+	return 0
+}
+
+func (x *ComplianceReport) GetSuppressedZoneCount() int64 {
+	if x != nil {
+		return x.SuppressedZoneCount
+	}
+	return 0
+}
+
+func (x *ComplianceReport) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ComplianceReport) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+func (x *ComplianceReport) GetGeneratedBy() string {
+	if x != nil {
+		return x.GeneratedBy
+	}
+	return ""
+}
+
+func (x *ComplianceReport) GetGeneratedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.GeneratedAt
+	}
+	return nil
+}
+
+func (x *ComplianceReport) GetWavRequestCount() int64 {
+	if x != nil {
+		return x.WavRequestCount
+	}
+	return 0
+}
+
+func (x *ComplianceReport) GetWavFulfilledCount() int64 {
+	if x != nil {
+		return x.WavFulfilledCount
+	}
+	return 0
+}
+
+func (x *ComplianceReport) GetWavFulfillmentRate() float64 {
+	if x != nil {
+		return x.WavFulfillmentRate
+	}
+	return 0
+}
+
+func (x *ComplianceReport) GetWavAverageWaitSeconds() float64 {
+	if x != nil {
+		return x.WavAverageWaitSeconds
+	}
+	return 0
+}
+
+type ListComplianceReportsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CityId        string                 `protobuf:"bytes,1,opt,name=city_id,json=cityId,proto3" json:"city_id,omitempty"`
+	TemplateId    string                 `protobuf:"bytes,2,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListComplianceReportsRequest) Reset() {
+	*x = ListComplianceReportsRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListComplianceReportsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListComplianceReportsRequest) ProtoMessage() {}
+
+func (x *ListComplianceReportsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListComplianceReportsRequest.ProtoReflect.Descriptor instead.
+func (*ListComplianceReportsRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *ListComplianceReportsRequest) GetCityId() string {
+	if x != nil {
+		return x.CityId
+	}
+	return ""
+}
+
+func (x *ListComplianceReportsRequest) GetTemplateId() string {
+	if x != nil {
+		return x.TemplateId
+	}
+	return ""
+}
+
+func (x *ListComplianceReportsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListComplianceReportsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reports       []*ComplianceReport    `protobuf:"bytes,1,rep,name=reports,proto3" json:"reports,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListComplianceReportsResponse) Reset() {
+	*x = ListComplianceReportsResponse{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListComplianceReportsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListComplianceReportsResponse) ProtoMessage() {}
+
+func (x *ListComplianceReportsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListComplianceReportsResponse.ProtoReflect.Descriptor instead.
+func (*ListComplianceReportsResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *ListComplianceReportsResponse) GetReports() []*ComplianceReport {
+	if x != nil {
+		return x.Reports
+	}
+	return nil
+}
+
+type GetComplianceReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReportId      string                 `protobuf:"bytes,1,opt,name=report_id,json=reportId,proto3" json:"report_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetComplianceReportRequest) Reset() {
+	*x = GetComplianceReportRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetComplianceReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetComplianceReportRequest) ProtoMessage() {}
+
+func (x *GetComplianceReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetComplianceReportRequest.ProtoReflect.Descriptor instead.
+func (*GetComplianceReportRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *GetComplianceReportRequest) GetReportId() string {
+	if x != nil {
+		return x.ReportId
+	}
+	return ""
+}
+
+// Generates a template's report for the period containing date (YYYY-MM-DD
+// in the city's timezone), which must have ended
+type GenerateComplianceReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TemplateId    string                 `protobuf:"bytes,1,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	Date          string                 `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	GeneratedBy   string                 `protobuf:"bytes,3,opt,name=generated_by,json=generatedBy,proto3" json:"generated_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateComplianceReportRequest) Reset() {
+	*x = GenerateComplianceReportRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateComplianceReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateComplianceReportRequest) ProtoMessage() {}
+
+func (x *GenerateComplianceReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateComplianceReportRequest.ProtoReflect.Descriptor instead.
+func (*GenerateComplianceReportRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *GenerateComplianceReportRequest) GetTemplateId() string {
+	if x != nil {
+		return x.TemplateId
+	}
+	return ""
+}
+
+func (x *GenerateComplianceReportRequest) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *GenerateComplianceReportRequest) GetGeneratedBy() string {
+	if x != nil {
+		return x.GeneratedBy
+	}
+	return ""
+}
+
+// In-trip messages between a trip's rider and driver. Messages the
+// recipient is not connected for are kept and replayed when they subscribe.
+type TripMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TripId        string                 `protobuf:"bytes,2,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	SenderId      string                 `protobuf:"bytes,3,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
+	SenderRole    string                 `protobuf:"bytes,4,opt,name=sender_role,json=senderRole,proto3" json:"sender_role,omitempty"` // "rider" or "driver"
+	RecipientId   string                 `protobuf:"bytes,5,opt,name=recipient_id,json=recipientId,proto3" json:"recipient_id,omitempty"`
+	Text          string                 `protobuf:"bytes,6,opt,name=text,proto3" json:"text,omitempty"`
+	QuickReplyId  string                 `protobuf:"bytes,7,opt,name=quick_reply_id,json=quickReplyId,proto3" json:"quick_reply_id,omitempty"` // set when a canned quick reply was sent
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	DeliveredAt   *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=delivered_at,json=deliveredAt,proto3" json:"delivered_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TripMessage) Reset() {
+	*x = TripMessage{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TripMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TripMessage) ProtoMessage() {}
+
+func (x *TripMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TripMessage.ProtoReflect.Descriptor instead.
+func (*TripMessage) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *TripMessage) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TripMessage) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *TripMessage) GetSenderId() string {
+	if x != nil {
+		return x.SenderId
+	}
+	return ""
+}
+
+func (x *TripMessage) GetSenderRole() string {
+	if x != nil {
+		return x.SenderRole
+	}
+	return ""
+}
+
+func (x *TripMessage) GetRecipientId() string {
+	if x != nil {
+		return x.RecipientId
+	}
+	return ""
+}
+
+func (x *TripMessage) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *TripMessage) GetQuickReplyId() string {
+	if x != nil {
+		return x.QuickReplyId
+	}
+	return ""
+}
+
+func (x *TripMessage) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *TripMessage) GetDeliveredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeliveredAt
+	}
+	return nil
+}
+
+type SendTripMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TripId        string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	SenderId      string                 `protobuf:"bytes,2,opt,name=sender_id,json=senderId,proto3" json:"sender_id,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	QuickReplyId  string                 `protobuf:"bytes,4,opt,name=quick_reply_id,json=quickReplyId,proto3" json:"quick_reply_id,omitempty"` // sends the quick reply's text instead
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendTripMessageRequest) Reset() {
+	*x = SendTripMessageRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendTripMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendTripMessageRequest) ProtoMessage() {}
+
+func (x *SendTripMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendTripMessageRequest.ProtoReflect.Descriptor instead.
+func (*SendTripMessageRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *SendTripMessageRequest) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *SendTripMessageRequest) GetSenderId() string {
+	if x != nil {
+		return x.SenderId
+	}
+	return ""
+}
+
+func (x *SendTripMessageRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *SendTripMessageRequest) GetQuickReplyId() string {
+	if x != nil {
+		return x.QuickReplyId
+	}
+	return ""
+}
+
+type ListTripMessagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TripId        string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // empty for support review
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTripMessagesRequest) Reset() {
+	*x = ListTripMessagesRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTripMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTripMessagesRequest) ProtoMessage() {}
+
+func (x *ListTripMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTripMessagesRequest.ProtoReflect.Descriptor instead.
+func (*ListTripMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *ListTripMessagesRequest) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *ListTripMessagesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ListTripMessagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Messages      []*TripMessage         `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTripMessagesResponse) Reset() {
+	*x = ListTripMessagesResponse{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTripMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTripMessagesResponse) ProtoMessage() {}
+
+func (x *ListTripMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTripMessagesResponse.ProtoReflect.Descriptor instead.
+func (*ListTripMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *ListTripMessagesResponse) GetMessages() []*TripMessage {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+type StreamTripMessagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TripId        string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamTripMessagesRequest) Reset() {
+	*x = StreamTripMessagesRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamTripMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamTripMessagesRequest) ProtoMessage() {}
+
+func (x *StreamTripMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamTripMessagesRequest.ProtoReflect.Descriptor instead.
+func (*StreamTripMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *StreamTripMessagesRequest) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *StreamTripMessagesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type QuickReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuickReply) Reset() {
+	*x = QuickReply{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuickReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuickReply) ProtoMessage() {}
+
+func (x *QuickReply) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuickReply.ProtoReflect.Descriptor instead.
+func (*QuickReply) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *QuickReply) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *QuickReply) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type ListQuickRepliesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Role          string                 `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"` // "rider" or "driver"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListQuickRepliesRequest) Reset() {
+	*x = ListQuickRepliesRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListQuickRepliesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListQuickRepliesRequest) ProtoMessage() {}
+
+func (x *ListQuickRepliesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListQuickRepliesRequest.ProtoReflect.Descriptor instead.
+func (*ListQuickRepliesRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *ListQuickRepliesRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type ListQuickRepliesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	QuickReplies  []*QuickReply          `protobuf:"bytes,1,rep,name=quick_replies,json=quickReplies,proto3" json:"quick_replies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListQuickRepliesResponse) Reset() {
+	*x = ListQuickRepliesResponse{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListQuickRepliesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListQuickRepliesResponse) ProtoMessage() {}
+
+func (x *ListQuickRepliesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListQuickRepliesResponse.ProtoReflect.Descriptor instead.
+func (*ListQuickRepliesResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *ListQuickRepliesResponse) GetQuickReplies() []*QuickReply {
+	if x != nil {
+		return x.QuickReplies
+	}
+	return nil
+}
+
+type DriverEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	Type          DriverEventType        `protobuf:"varint,2,opt,name=type,proto3,enum=trip.DriverEventType" json:"type,omitempty"`
+	TripId        string                 `protobuf:"bytes,3,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	RiderId       string                 `protobuf:"bytes,4,opt,name=rider_id,json=riderId,proto3" json:"rider_id,omitempty"`
+	RiderLocation *Location              `protobuf:"bytes,5,opt,name=rider_location,json=riderLocation,proto3" json:"rider_location,omitempty"` // set for rider locations
+	CancelledBy   string                 `protobuf:"bytes,6,opt,name=cancelled_by,json=cancelledBy,proto3" json:"cancelled_by,omitempty"`       // set for cancellations
+	Reason        string                 `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`                                    // set for cancellations
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	LostItemId    string                 `protobuf:"bytes,9,opt,name=lost_item_id,json=lostItemId,proto3" json:"lost_item_id,omitempty"` // set for lost item reports
+	Description   string                 `protobuf:"bytes,10,opt,name=description,proto3" json:"description,omitempty"`                  // set for lost item reports
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DriverEvent) Reset() {
+	*x = DriverEvent{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriverEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriverEvent) ProtoMessage() {}
+
+func (x *DriverEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriverEvent.ProtoReflect.Descriptor instead.
+func (*DriverEvent) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *DriverEvent) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *DriverEvent) GetType() DriverEventType {
+	if x != nil {
+		return x.Type
+	}
+	return DriverEventType_DRIVER_EVENT_UNKNOWN
+}
+
+func (x *DriverEvent) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *DriverEvent) GetRiderId() string {
+	if x != nil {
+		return x.RiderId
+	}
+	return ""
+}
+
+func (x *DriverEvent) GetRiderLocation() *Location {
+	if x != nil {
+		return x.RiderLocation
+	}
+	return nil
+}
+
+func (x *DriverEvent) GetCancelledBy() string {
+	if x != nil {
+		return x.CancelledBy
+	}
+	return ""
+}
+
+func (x *DriverEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *DriverEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *DriverEvent) GetLostItemId() string {
+	if x != nil {
+		return x.LostItemId
+	}
+	return ""
+}
+
+func (x *DriverEvent) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type SubscribeDriverEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverId      string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeDriverEventsRequest) Reset() {
+	*x = SubscribeDriverEventsRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeDriverEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeDriverEventsRequest) ProtoMessage() {}
+
+func (x *SubscribeDriverEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeDriverEventsRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeDriverEventsRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *SubscribeDriverEventsRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+type UpdateRiderLocationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TripId        string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
+	RiderId       string                 `protobuf:"bytes,2,opt,name=rider_id,json=riderId,proto3" json:"rider_id,omitempty"`
+	Location      *Location              `protobuf:"bytes,3,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRiderLocationRequest) Reset() {
+	*x = UpdateRiderLocationRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[103]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRiderLocationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRiderLocationRequest) ProtoMessage() {}
+
+func (x *UpdateRiderLocationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[103]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRiderLocationRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRiderLocationRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *UpdateRiderLocationRequest) GetTripId() string {
+	if x != nil {
+		return x.TripId
+	}
+	return ""
+}
+
+func (x *UpdateRiderLocationRequest) GetRiderId() string {
+	if x != nil {
+		return x.RiderId
+	}
+	return ""
+}
+
+func (x *UpdateRiderLocationRequest) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+type UpdateRiderLocationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRiderLocationResponse) Reset() {
+	*x = UpdateRiderLocationResponse{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRiderLocationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRiderLocationResponse) ProtoMessage() {}
+
+func (x *UpdateRiderLocationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[104]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRiderLocationResponse.ProtoReflect.Descriptor instead.
+func (*UpdateRiderLocationResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{104}
+}
+
+// An event one of trip-service's consumers failed to handle, kept until an
+// operator replays or discards it
+type DeadLetter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Consumer      string                 `protobuf:"bytes,2,opt,name=consumer,proto3" json:"consumer,omitempty"`
+	Handler       string                 `protobuf:"bytes,3,opt,name=handler,proto3" json:"handler,omitempty"` // which of the consumer's handlers failed
+	EventId       string                 `protobuf:"bytes,4,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	EventType     string                 `protobuf:"bytes,5,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	EventJson     string                 `protobuf:"bytes,6,opt,name=event_json,json=eventJson,proto3" json:"event_json,omitempty"` // the event as it was published
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	Poison        bool                   `protobuf:"varint,8,opt,name=poison,proto3" json:"poison,omitempty"`
+	Attempts      int32                  `protobuf:"varint,9,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	Replays       int32                  `protobuf:"varint,10,opt,name=replays,proto3" json:"replays,omitempty"` // replays that failed again
+	FirstFailedAt *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=first_failed_at,json=firstFailedAt,proto3" json:"first_failed_at,omitempty"`
+	LastFailedAt  *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=last_failed_at,json=lastFailedAt,proto3" json:"last_failed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeadLetter) Reset() {
+	*x = DeadLetter{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[105]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeadLetter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeadLetter) ProtoMessage() {}
+
+func (x *DeadLetter) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[105]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeadLetter.ProtoReflect.Descriptor instead.
+func (*DeadLetter) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{105}
+}
+
+func (x *DeadLetter) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetConsumer() string {
+	if x != nil {
+		return x.Consumer
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetHandler() string {
+	if x != nil {
+		return x.Handler
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetEventJson() string {
+	if x != nil {
+		return x.EventJson
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *DeadLetter) GetPoison() bool {
+	if x != nil {
+		return x.Poison
+	}
+	return false
+}
+
+func (x *DeadLetter) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *DeadLetter) GetReplays() int32 {
+	if x != nil {
+		return x.Replays
+	}
+	return 0
+}
+
+func (x *DeadLetter) GetFirstFailedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstFailedAt
+	}
+	return nil
+}
+
+func (x *DeadLetter) GetLastFailedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastFailedAt
+	}
+	return nil
+}
+
+type ListDeadLettersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Consumer      string                 `protobuf:"bytes,1,opt,name=consumer,proto3" json:"consumer,omitempty"`
+	EventType     string                 `protobuf:"bytes,2,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	Poison        string                 `protobuf:"bytes,3,opt,name=poison,proto3" json:"poison,omitempty"` // "true" or "false", empty for both
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeadLettersRequest) Reset() {
+	*x = ListDeadLettersRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[106]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeadLettersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeadLettersRequest) ProtoMessage() {}
+
+func (x *ListDeadLettersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[106]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeadLettersRequest.ProtoReflect.Descriptor instead.
+func (*ListDeadLettersRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{106}
+}
+
+func (x *ListDeadLettersRequest) GetConsumer() string {
+	if x != nil {
+		return x.Consumer
+	}
+	return ""
+}
+
+func (x *ListDeadLettersRequest) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *ListDeadLettersRequest) GetPoison() string {
+	if x != nil {
+		return x.Poison
+	}
+	return ""
+}
+
+func (x *ListDeadLettersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListDeadLettersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeadLetters   []*DeadLetter          `protobuf:"bytes,1,rep,name=dead_letters,json=deadLetters,proto3" json:"dead_letters,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeadLettersResponse) Reset() {
+	*x = ListDeadLettersResponse{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[107]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeadLettersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeadLettersResponse) ProtoMessage() {}
+
+func (x *ListDeadLettersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[107]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeadLettersResponse.ProtoReflect.Descriptor instead.
+func (*ListDeadLettersResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{107}
+}
+
+func (x *ListDeadLettersResponse) GetDeadLetters() []*DeadLetter {
+	if x != nil {
+		return x.DeadLetters
+	}
+	return nil
+}
+
+type GetDeadLetterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeadLetterId  string                 `protobuf:"bytes,1,opt,name=dead_letter_id,json=deadLetterId,proto3" json:"dead_letter_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDeadLetterRequest) Reset() {
+	*x = GetDeadLetterRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[108]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDeadLetterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDeadLetterRequest) ProtoMessage() {}
+
+func (x *GetDeadLetterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[108]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDeadLetterRequest.ProtoReflect.Descriptor instead.
+func (*GetDeadLetterRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{108}
+}
+
+func (x *GetDeadLetterRequest) GetDeadLetterId() string {
+	if x != nil {
+		return x.DeadLetterId
+	}
+	return ""
+}
+
+// Hands a dead letter's event to the handler that failed it again. Events
+// that fail again stay dead-lettered and are answered with ABORTED.
+type ReplayDeadLetterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeadLetterId  string                 `protobuf:"bytes,1,opt,name=dead_letter_id,json=deadLetterId,proto3" json:"dead_letter_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayDeadLetterRequest) Reset() {
+	*x = ReplayDeadLetterRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[109]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayDeadLetterRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayDeadLetterRequest) ProtoMessage() {}
+
+func (x *ReplayDeadLetterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[109]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayDeadLetterRequest.ProtoReflect.Descriptor instead.
+func (*ReplayDeadLetterRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{109}
 }
 
-func (x *GetActiveTripsResponse) GetTrips() []*Trip {
+func (x *ReplayDeadLetterRequest) GetDeadLetterId() string {
 	if x != nil {
-		return x.Trips
+		return x.DeadLetterId
 	}
-	return nil
+	return ""
 }
 
-func (x *GetActiveTripsResponse) GetCount() int32 {
+type ReplayDeadLetterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayDeadLetterResponse) Reset() {
+	*x = ReplayDeadLetterResponse{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[110]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayDeadLetterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayDeadLetterResponse) ProtoMessage() {}
+
+func (x *ReplayDeadLetterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[110]
 	if x != nil {
-		return x.Count
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-// Real-time trip updates
-type TripUpdateEvent struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	TripId          string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
-	OldStatus       TripStatus             `protobuf:"varint,2,opt,name=old_status,json=oldStatus,proto3,enum=trip.TripStatus" json:"old_status,omitempty"`
-	NewStatus       TripStatus             `protobuf:"varint,3,opt,name=new_status,json=newStatus,proto3,enum=trip.TripStatus" json:"new_status,omitempty"`
-	CurrentLocation *Location              `protobuf:"bytes,4,opt,name=current_location,json=currentLocation,proto3" json:"current_location,omitempty"`
-	Timestamp       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Metadata        map[string]string      `protobuf:"bytes,6,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+// Deprecated: Use ReplayDeadLetterResponse.ProtoReflect.Descriptor instead.
+func (*ReplayDeadLetterResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{110}
 }
 
-func (x *TripUpdateEvent) Reset() {
-	*x = TripUpdateEvent{}
-	mi := &file_shared_proto_trip_trip_proto_msgTypes[13]
+type DiscardDeadLetterRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeadLetterId  string                 `protobuf:"bytes,1,opt,name=dead_letter_id,json=deadLetterId,proto3" json:"dead_letter_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiscardDeadLetterRequest) Reset() {
+	*x = DiscardDeadLetterRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[111]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TripUpdateEvent) String() string {
+func (x *DiscardDeadLetterRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TripUpdateEvent) ProtoMessage() {}
+func (*DiscardDeadLetterRequest) ProtoMessage() {}
 
-func (x *TripUpdateEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_trip_trip_proto_msgTypes[13]
+func (x *DiscardDeadLetterRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[111]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1062,76 +8007,161 @@ func (x *TripUpdateEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TripUpdateEvent.ProtoReflect.Descriptor instead.
-func (*TripUpdateEvent) Descriptor() ([]byte, []int) {
-	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use DiscardDeadLetterRequest.ProtoReflect.Descriptor instead.
+func (*DiscardDeadLetterRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{111}
 }
 
-func (x *TripUpdateEvent) GetTripId() string {
+func (x *DiscardDeadLetterRequest) GetDeadLetterId() string {
 	if x != nil {
-		return x.TripId
+		return x.DeadLetterId
 	}
 	return ""
 }
 
-func (x *TripUpdateEvent) GetOldStatus() TripStatus {
+type DiscardDeadLetterResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiscardDeadLetterResponse) Reset() {
+	*x = DiscardDeadLetterResponse{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[112]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiscardDeadLetterResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiscardDeadLetterResponse) ProtoMessage() {}
+
+func (x *DiscardDeadLetterResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[112]
 	if x != nil {
-		return x.OldStatus
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return TripStatus_UNKNOWN_STATUS
+	return mi.MessageOf(x)
 }
 
-func (x *TripUpdateEvent) GetNewStatus() TripStatus {
+// Deprecated: Use DiscardDeadLetterResponse.ProtoReflect.Descriptor instead.
+func (*DiscardDeadLetterResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{112}
+}
+
+// How far the trip listing read model trip lists are served from is behind
+// the trip event log
+type TripListingsLag struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Checkpoint     int64                  `protobuf:"varint,1,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"`
+	LatestPosition int64                  `protobuf:"varint,2,opt,name=latest_position,json=latestPosition,proto3" json:"latest_position,omitempty"`
+	EventsBehind   int64                  `protobuf:"varint,3,opt,name=events_behind,json=eventsBehind,proto3" json:"events_behind,omitempty"`
+	LagSeconds     float64                `protobuf:"fixed64,4,opt,name=lag_seconds,json=lagSeconds,proto3" json:"lag_seconds,omitempty"` // how long ago the oldest unprojected event was recorded
+	ProjectedAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=projected_at,json=projectedAt,proto3" json:"projected_at,omitempty"`
+	RebuiltAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=rebuilt_at,json=rebuiltAt,proto3" json:"rebuilt_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TripListingsLag) Reset() {
+	*x = TripListingsLag{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[113]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TripListingsLag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TripListingsLag) ProtoMessage() {}
+
+func (x *TripListingsLag) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[113]
 	if x != nil {
-		return x.NewStatus
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return TripStatus_UNKNOWN_STATUS
+	return mi.MessageOf(x)
 }
 
-func (x *TripUpdateEvent) GetCurrentLocation() *Location {
+// Deprecated: Use TripListingsLag.ProtoReflect.Descriptor instead.
+func (*TripListingsLag) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{113}
+}
+
+func (x *TripListingsLag) GetCheckpoint() int64 {
 	if x != nil {
-		return x.CurrentLocation
+		return x.Checkpoint
 	}
-	return nil
+	return 0
 }
 
-func (x *TripUpdateEvent) GetTimestamp() *timestamppb.Timestamp {
+func (x *TripListingsLag) GetLatestPosition() int64 {
 	if x != nil {
-		return x.Timestamp
+		return x.LatestPosition
+	}
+	return 0
+}
+
+func (x *TripListingsLag) GetEventsBehind() int64 {
+	if x != nil {
+		return x.EventsBehind
+	}
+	return 0
+}
+
+func (x *TripListingsLag) GetLagSeconds() float64 {
+	if x != nil {
+		return x.LagSeconds
+	}
+	return 0
+}
+
+func (x *TripListingsLag) GetProjectedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ProjectedAt
 	}
 	return nil
 }
 
-func (x *TripUpdateEvent) GetMetadata() map[string]string {
+func (x *TripListingsLag) GetRebuiltAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Metadata
+		return x.RebuiltAt
 	}
 	return nil
 }
 
-type SubscribeToTripUpdatesRequest struct {
+type GetTripListingsLagRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TripId        string                 `protobuf:"bytes,1,opt,name=trip_id,json=tripId,proto3" json:"trip_id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SubscribeToTripUpdatesRequest) Reset() {
-	*x = SubscribeToTripUpdatesRequest{}
-	mi := &file_shared_proto_trip_trip_proto_msgTypes[14]
+func (x *GetTripListingsLagRequest) Reset() {
+	*x = GetTripListingsLagRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[114]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SubscribeToTripUpdatesRequest) String() string {
+func (x *GetTripListingsLagRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubscribeToTripUpdatesRequest) ProtoMessage() {}
+func (*GetTripListingsLagRequest) ProtoMessage() {}
 
-func (x *SubscribeToTripUpdatesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_trip_trip_proto_msgTypes[14]
+func (x *GetTripListingsLagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[114]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1142,23 +8172,90 @@ func (x *SubscribeToTripUpdatesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubscribeToTripUpdatesRequest.ProtoReflect.Descriptor instead.
-func (*SubscribeToTripUpdatesRequest) Descriptor() ([]byte, []int) {
-	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use GetTripListingsLagRequest.ProtoReflect.Descriptor instead.
+func (*GetTripListingsLagRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{114}
 }
 
-func (x *SubscribeToTripUpdatesRequest) GetTripId() string {
+// Drops the trip listings and projects the whole trip event log again
+type RebuildTripListingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RebuildTripListingsRequest) Reset() {
+	*x = RebuildTripListingsRequest{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[115]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RebuildTripListingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RebuildTripListingsRequest) ProtoMessage() {}
+
+func (x *RebuildTripListingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[115]
 	if x != nil {
-		return x.TripId
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *SubscribeToTripUpdatesRequest) GetUserId() string {
+// Deprecated: Use RebuildTripListingsRequest.ProtoReflect.Descriptor instead.
+func (*RebuildTripListingsRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{115}
+}
+
+type RebuildTripListingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Projected     int32                  `protobuf:"varint,1,opt,name=projected,proto3" json:"projected,omitempty"` // events projected into the rebuilt listings
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RebuildTripListingsResponse) Reset() {
+	*x = RebuildTripListingsResponse{}
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[116]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RebuildTripListingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RebuildTripListingsResponse) ProtoMessage() {}
+
+func (x *RebuildTripListingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_trip_trip_proto_msgTypes[116]
 	if x != nil {
-		return x.UserId
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RebuildTripListingsResponse.ProtoReflect.Descriptor instead.
+func (*RebuildTripListingsResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_trip_trip_proto_rawDescGZIP(), []int{116}
+}
+
+func (x *RebuildTripListingsResponse) GetProjected() int32 {
+	if x != nil {
+		return x.Projected
+	}
+	return 0
 }
 
 var File_shared_proto_trip_trip_proto protoreflect.FileDescriptor
@@ -1169,7 +8266,7 @@ const file_shared_proto_trip_trip_proto_rawDesc = "" +
 	"\bLocation\x12\x1a\n" +
 	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
 	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\x12\x18\n" +
-	"\aaddress\x18\x03 \x01(\tR\aaddress\"\xfd\x04\n" +
+	"\aaddress\x18\x03 \x01(\tR\aaddress\"\x88\x06\n" +
 	"\x04Trip\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
 	"\brider_id\x18\x02 \x01(\tR\ariderId\x12\x1b\n" +
@@ -1188,7 +8285,10 @@ const file_shared_proto_trip_trip_proto_rawDesc = "" +
 	"\n" +
 	"started_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12=\n" +
 	"\fcompleted_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\x12.\n" +
-	"\bmetadata\x18\x0e \x01(\v2\x12.trip.TripMetadataR\bmetadata\"\xec\x02\n" +
+	"\bmetadata\x18\x0e \x01(\v2\x12.trip.TripMetadataR\bmetadata\x12?\n" +
+	"\rscheduled_for\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\fscheduledFor\x12\x17\n" +
+	"\acity_id\x18\x10 \x01(\tR\x06cityId\x12/\n" +
+	"\x13accessibility_needs\x18\x11 \x03(\tR\x12accessibilityNeeds\"\xec\x02\n" +
 	"\fTripMetadata\x12!\n" +
 	"\fvehicle_type\x18\x01 \x01(\tR\vvehicleType\x12\x1f\n" +
 	"\vdistance_km\x18\x02 \x01(\x01R\n" +
@@ -1198,14 +8298,17 @@ const file_shared_proto_trip_trip_proto_rawDesc = "" +
 	"\x10surge_multiplier\x18\x05 \x01(\x01R\x0fsurgeMultiplier\x12/\n" +
 	"\x13cancellation_reason\x18\x06 \x01(\tR\x12cancellationReason\x12!\n" +
 	"\frider_rating\x18\a \x01(\x01R\vriderRating\x12#\n" +
-	"\rdriver_rating\x18\b \x01(\x01R\fdriverRating\"\x98\x02\n" +
+	"\rdriver_rating\x18\b \x01(\x01R\fdriverRating\"\xa3\x03\n" +
 	"\x11CreateTripRequest\x12\x19\n" +
 	"\brider_id\x18\x01 \x01(\tR\ariderId\x127\n" +
 	"\x0fpickup_location\x18\x02 \x01(\v2\x0e.trip.LocationR\x0epickupLocation\x120\n" +
 	"\vdestination\x18\x03 \x01(\v2\x0e.trip.LocationR\vdestination\x12!\n" +
 	"\fvehicle_type\x18\x04 \x01(\tR\vvehicleType\x12*\n" +
 	"\x11payment_method_id\x18\x05 \x01(\tR\x0fpaymentMethodId\x12.\n" +
-	"\bmetadata\x18\x06 \x01(\v2\x12.trip.TripMetadataR\bmetadata\"\x80\x01\n" +
+	"\bmetadata\x18\x06 \x01(\v2\x12.trip.TripMetadataR\bmetadata\x12?\n" +
+	"\rscheduled_for\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\fscheduledFor\x12\x17\n" +
+	"\acity_id\x18\b \x01(\tR\x06cityId\x12/\n" +
+	"\x13accessibility_needs\x18\t \x03(\tR\x12accessibilityNeeds\"\x80\x01\n" +
 	"\x12CreateTripResponse\x12\x1e\n" +
 	"\x04trip\x18\x01 \x01(\v2\n" +
 	".trip.TripR\x04trip\x12\x18\n" +
@@ -1217,36 +8320,81 @@ const file_shared_proto_trip_trip_proto_rawDesc = "" +
 	"\x0fGetTripResponse\x12\x1e\n" +
 	"\x04trip\x18\x01 \x01(\v2\n" +
 	".trip.TripR\x04trip\x12\x14\n" +
-	"\x05found\x18\x02 \x01(\bR\x05found\"\x91\x01\n" +
+	"\x05found\x18\x02 \x01(\bR\x05found\"\xf5\x01\n" +
 	"\x17UpdateTripStatusRequest\x12\x17\n" +
 	"\atrip_id\x18\x01 \x01(\tR\x06tripId\x12(\n" +
 	"\x06status\x18\x02 \x01(\x0e2\x10.trip.TripStatusR\x06status\x12\x1b\n" +
 	"\tdriver_id\x18\x03 \x01(\tR\bdriverId\x12\x16\n" +
-	"\x06reason\x18\x04 \x01(\tR\x06reason\"n\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x124\n" +
+	"\n" +
+	"completion\x18\x05 \x01(\v2\x14.trip.TripCompletionR\n" +
+	"completion\x12,\n" +
+	"\x12pickup_eta_seconds\x18\x06 \x01(\x05R\x10pickupEtaSeconds\"\xf2\x03\n" +
+	"\x0eTripCompletion\x12\x1d\n" +
+	"\n" +
+	"vehicle_id\x18\x01 \x01(\tR\tvehicleId\x12!\n" +
+	"\fvehicle_type\x18\x02 \x01(\tR\vvehicleType\x127\n" +
+	"\x0fpickup_location\x18\x03 \x01(\v2\x0e.trip.LocationR\x0epickupLocation\x120\n" +
+	"\vdestination\x18\x04 \x01(\v2\x0e.trip.LocationR\vdestination\x12\x1f\n" +
+	"\vdistance_km\x18\x05 \x01(\x01R\n" +
+	"distanceKm\x12)\n" +
+	"\x10duration_minutes\x18\x06 \x01(\x05R\x0fdurationMinutes\x129\n" +
+	"\n" +
+	"started_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12\x1f\n" +
+	"\vpickup_area\x18\b \x01(\tR\n" +
+	"pickupArea\x12)\n" +
+	"\x10surge_multiplier\x18\t \x01(\x01R\x0fsurgeMultiplier\x120\n" +
+	"\x14waiting_time_seconds\x18\n" +
+	" \x01(\x05R\x12waitingTimeSeconds\x12\x12\n" +
+	"\x04city\x18\v \x01(\tR\x04city\x12\x1a\n" +
+	"\bcurrency\x18\f \x01(\tR\bcurrency\"n\n" +
 	"\x18UpdateTripStatusResponse\x12\x1e\n" +
 	"\x04trip\x18\x01 \x01(\v2\n" +
 	".trip.TripR\x04trip\x12\x18\n" +
 	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage\"\x9a\x01\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\xbc\x01\n" +
 	"\x13GetUserTripsRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
 	"\x04role\x18\x02 \x01(\tR\x04role\x12\x14\n" +
-	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x04 \x01(\x05R\x06offset\x12(\n" +
-	"\x06status\x18\x05 \x01(\x0e2\x10.trip.TripStatusR\x06status\"t\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12(\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x10.trip.TripStatusR\x06status\x12\x16\n" +
+	"\x06cursor\x18\x06 \x01(\tR\x06cursor\x12\x12\n" +
+	"\x04sort\x18\a \x01(\tR\x04sortJ\x04\b\x04\x10\x05R\x06offset\"\x95\x01\n" +
 	"\x14GetUserTripsResponse\x12 \n" +
 	"\x05trips\x18\x01 \x03(\v2\n" +
 	".trip.TripR\x05trips\x12\x1f\n" +
 	"\vtotal_count\x18\x02 \x01(\x05R\n" +
 	"totalCount\x12\x19\n" +
-	"\bhas_more\x18\x03 \x01(\bR\ahasMore\"E\n" +
+	"\bhas_more\x18\x03 \x01(\bR\ahasMore\x12\x1f\n" +
+	"\vnext_cursor\x18\x04 \x01(\tR\n" +
+	"nextCursor\"\xfa\x01\n" +
 	"\x15GetActiveTripsRequest\x12\x16\n" +
 	"\x06region\x18\x01 \x01(\tR\x06region\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x05R\x05limit\"P\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12(\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x10.trip.TripStatusR\x06status\x12\x19\n" +
+	"\brider_id\x18\x04 \x01(\tR\ariderId\x12\x1b\n" +
+	"\tdriver_id\x18\x05 \x01(\tR\bdriverId\x12\x17\n" +
+	"\acity_id\x18\a \x01(\tR\x06cityId\x12\x16\n" +
+	"\x06cursor\x18\b \x01(\tR\x06cursor\x12\x12\n" +
+	"\x04sort\x18\t \x01(\tR\x04sortJ\x04\b\x06\x10\aR\x06offset\"q\n" +
 	"\x16GetActiveTripsResponse\x12 \n" +
 	"\x05trips\x18\x01 \x03(\v2\n" +
 	".trip.TripR\x05trips\x12\x14\n" +
-	"\x05count\x18\x02 \x01(\x05R\x05count\"\xff\x02\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\"d\n" +
+	"\x16ForceCancelTripRequest\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x19\n" +
+	"\bactor_id\x18\x03 \x01(\tR\aactorId\"9\n" +
+	"\x17ForceCancelTripResponse\x12\x1e\n" +
+	"\x04trip\x18\x01 \x01(\v2\n" +
+	".trip.TripR\x04trip\"4\n" +
+	"\x19AnonymizeUserTripsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"b\n" +
+	"\x1aAnonymizeUserTripsResponse\x12)\n" +
+	"\x10anonymized_count\x18\x01 \x01(\x05R\x0fanonymizedCount\x12\x19\n" +
+	"\btrip_ids\x18\x02 \x03(\tR\atripIds\"\xff\x02\n" +
 	"\x0fTripUpdateEvent\x12\x17\n" +
 	"\atrip_id\x18\x01 \x01(\tR\x06tripId\x12/\n" +
 	"\n" +
@@ -1261,7 +8409,575 @@ const file_shared_proto_trip_trip_proto_rawDesc = "" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"Q\n" +
 	"\x1dSubscribeToTripUpdatesRequest\x12\x17\n" +
 	"\atrip_id\x18\x01 \x01(\tR\x06tripId\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId*\xd4\x01\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\xe5\x01\n" +
+	"\bTripStop\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\atrip_id\x18\x02 \x01(\tR\x06tripId\x12\x19\n" +
+	"\brider_id\x18\x03 \x01(\tR\ariderId\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12*\n" +
+	"\blocation\x18\x05 \x01(\v2\x0e.trip.LocationR\blocation\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12=\n" +
+	"\fcompleted_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\"\x97\x02\n" +
+	"\vSharedRider\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\x12\x19\n" +
+	"\brider_id\x18\x02 \x01(\tR\ariderId\x127\n" +
+	"\x0fpickup_location\x18\x03 \x01(\v2\x0e.trip.LocationR\x0epickupLocation\x120\n" +
+	"\vdestination\x18\x04 \x01(\v2\x0e.trip.LocationR\vdestination\x12'\n" +
+	"\x0fpassenger_count\x18\x05 \x01(\x05R\x0epassengerCount\x12,\n" +
+	"\x12max_detour_minutes\x18\x06 \x01(\x05R\x10maxDetourMinutes\x12\x12\n" +
+	"\x04fare\x18\a \x01(\x01R\x04fare\"\xdc\x03\n" +
+	"\n" +
+	"SharedTrip\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tdriver_id\x18\x02 \x01(\tR\bdriverId\x12!\n" +
+	"\fvehicle_type\x18\x03 \x01(\tR\vvehicleType\x12#\n" +
+	"\rseat_capacity\x18\x04 \x01(\x05R\fseatCapacity\x12%\n" +
+	"\x0eseats_occupied\x18\x05 \x01(\x05R\rseatsOccupied\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12$\n" +
+	"\x05stops\x18\a \x03(\v2\x0e.trip.TripStopR\x05stops\x12)\n" +
+	"\x06riders\x18\b \x03(\v2\x11.trip.SharedRiderR\x06riders\x129\n" +
+	"\x10current_location\x18\t \x01(\v2\x0e.trip.LocationR\x0fcurrentLocation\x12\x18\n" +
+	"\aversion\x18\n" +
+	" \x01(\x05R\aversion\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xe0\x01\n" +
+	"\x15OpenSharedTripRequest\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12!\n" +
+	"\fvehicle_type\x18\x02 \x01(\tR\vvehicleType\x12#\n" +
+	"\rseat_capacity\x18\x03 \x01(\x05R\fseatCapacity\x12'\n" +
+	"\x05rider\x18\x04 \x01(\v2\x11.trip.SharedRiderR\x05rider\x129\n" +
+	"\x10current_location\x18\x05 \x01(\v2\x0e.trip.LocationR\x0fcurrentLocation\"\xd9\x01\n" +
+	"\x15AddSharedRiderRequest\x12$\n" +
+	"\x0eshared_trip_id\x18\x01 \x01(\tR\fsharedTripId\x12)\n" +
+	"\x10expected_version\x18\x02 \x01(\x05R\x0fexpectedVersion\x12'\n" +
+	"\x05rider\x18\x03 \x01(\v2\x11.trip.SharedRiderR\x05rider\x12!\n" +
+	"\fpickup_index\x18\x04 \x01(\x05R\vpickupIndex\x12#\n" +
+	"\rdropoff_index\x18\x05 \x01(\x05R\fdropoffIndex\"X\n" +
+	"\x17CompleteTripStopRequest\x12$\n" +
+	"\x0eshared_trip_id\x18\x01 \x01(\tR\fsharedTripId\x12\x17\n" +
+	"\astop_id\x18\x02 \x01(\tR\x06stopId\"<\n" +
+	"\x14GetSharedTripRequest\x12$\n" +
+	"\x0eshared_trip_id\x18\x01 \x01(\tR\fsharedTripId\"{\n" +
+	"\x12SharedTripResponse\x121\n" +
+	"\vshared_trip\x18\x01 \x01(\v2\x10.trip.SharedTripR\n" +
+	"sharedTrip\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"b\n" +
+	"\x1aListOpenSharedTripsRequest\x12!\n" +
+	"\fvehicle_type\x18\x01 \x01(\tR\vvehicleType\x12!\n" +
+	"\fseats_needed\x18\x02 \x01(\x05R\vseatsNeeded\"R\n" +
+	"\x1bListOpenSharedTripsResponse\x123\n" +
+	"\fshared_trips\x18\x01 \x03(\v2\x10.trip.SharedTripR\vsharedTrips\"\xc3\x05\n" +
+	"\rScheduledRide\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\brider_id\x18\x02 \x01(\tR\ariderId\x127\n" +
+	"\x0fpickup_location\x18\x03 \x01(\v2\x0e.trip.LocationR\x0epickupLocation\x120\n" +
+	"\vdestination\x18\x04 \x01(\v2\x0e.trip.LocationR\vdestination\x12!\n" +
+	"\fvehicle_type\x18\x05 \x01(\tR\vvehicleType\x12*\n" +
+	"\x11payment_method_id\x18\x06 \x01(\tR\x0fpaymentMethodId\x12'\n" +
+	"\x0fpassenger_count\x18\a \x01(\x05R\x0epassengerCount\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12?\n" +
+	"\rscheduled_for\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\fscheduledFor\x12;\n" +
+	"\vdispatch_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"dispatchAt\x12+\n" +
+	"\x11dispatch_attempts\x18\v \x01(\x05R\x10dispatchAttempts\x12\x1b\n" +
+	"\tdriver_id\x18\f \x01(\tR\bdriverId\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\r \x01(\tR\tlastError\x12/\n" +
+	"\x13cancellation_reason\x18\x0e \x01(\tR\x12cancellationReason\x129\n" +
+	"\n" +
+	"created_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xdb\x02\n" +
+	"\x1aCreateScheduledRideRequest\x12\x19\n" +
+	"\brider_id\x18\x01 \x01(\tR\ariderId\x127\n" +
+	"\x0fpickup_location\x18\x02 \x01(\v2\x0e.trip.LocationR\x0epickupLocation\x120\n" +
+	"\vdestination\x18\x03 \x01(\v2\x0e.trip.LocationR\vdestination\x12!\n" +
+	"\fvehicle_type\x18\x04 \x01(\tR\vvehicleType\x12*\n" +
+	"\x11payment_method_id\x18\x05 \x01(\tR\x0fpaymentMethodId\x12'\n" +
+	"\x0fpassenger_count\x18\x06 \x01(\x05R\x0epassengerCount\x12?\n" +
+	"\rscheduled_for\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\fscheduledFor\"\x87\x01\n" +
+	"\x15ScheduledRideResponse\x12:\n" +
+	"\x0escheduled_ride\x18\x01 \x01(\v2\x13.trip.ScheduledRideR\rscheduledRide\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"]\n" +
+	"\x19ListScheduledRidesRequest\x12\x19\n" +
+	"\brider_id\x18\x01 \x01(\tR\ariderId\x12%\n" +
+	"\x0einclude_closed\x18\x02 \x01(\bR\rincludeClosed\"Z\n" +
+	"\x1aListScheduledRidesResponse\x12<\n" +
+	"\x0fscheduled_rides\x18\x01 \x03(\v2\x13.trip.ScheduledRideR\x0escheduledRides\"{\n" +
+	"\x1aCancelScheduledRideRequest\x12*\n" +
+	"\x11scheduled_ride_id\x18\x01 \x01(\tR\x0fscheduledRideId\x12\x19\n" +
+	"\brider_id\x18\x02 \x01(\tR\ariderId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\x85\x02\n" +
+	"\x06Rating\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\atrip_id\x18\x02 \x01(\tR\x06tripId\x12\x19\n" +
+	"\brater_id\x18\x03 \x01(\tR\araterId\x12\x19\n" +
+	"\bratee_id\x18\x04 \x01(\tR\arateeId\x12\x1d\n" +
+	"\n" +
+	"rater_role\x18\x05 \x01(\tR\traterRole\x12\x14\n" +
+	"\x05score\x18\x06 \x01(\x05R\x05score\x12\x18\n" +
+	"\acomment\x18\a \x01(\tR\acomment\x12\x12\n" +
+	"\x04tags\x18\b \x03(\tR\x04tags\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xc1\x01\n" +
+	"\rRatingSummary\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\x12%\n" +
+	"\x0eaverage_rating\x18\x03 \x01(\x01R\raverageRating\x12!\n" +
+	"\frating_count\x18\x04 \x01(\x05R\vratingCount\x129\n" +
+	"\n" +
+	"updated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x8d\x01\n" +
+	"\x13SubmitRatingRequest\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\x12\x19\n" +
+	"\brater_id\x18\x02 \x01(\tR\araterId\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x05R\x05score\x12\x18\n" +
+	"\acomment\x18\x04 \x01(\tR\acomment\x12\x12\n" +
+	"\x04tags\x18\x05 \x03(\tR\x04tags\"v\n" +
+	"\x14SubmitRatingResponse\x12$\n" +
+	"\x06rating\x18\x01 \x01(\v2\f.trip.RatingR\x06rating\x128\n" +
+	"\rratee_summary\x18\x02 \x01(\v2\x13.trip.RatingSummaryR\frateeSummary\"F\n" +
+	"\x17GetRatingSummaryRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\"W\n" +
+	"\x12ListReviewsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"=\n" +
+	"\x13ListReviewsResponse\x12&\n" +
+	"\areviews\x18\x01 \x03(\v2\f.trip.RatingR\areviews\"8\n" +
+	"\x17GetDriverRatingsRequest\x12\x1d\n" +
+	"\n" +
+	"driver_ids\x18\x01 \x03(\tR\tdriverIds\"\xb2\x01\n" +
+	"\x18GetDriverRatingsResponse\x12E\n" +
+	"\aratings\x18\x01 \x03(\v2+.trip.GetDriverRatingsResponse.RatingsEntryR\aratings\x1aO\n" +
+	"\fRatingsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12)\n" +
+	"\x05value\x18\x02 \x01(\v2\x13.trip.RatingSummaryR\x05value:\x028\x01\"\xa0\x01\n" +
+	"\x11DriverReliability\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12\x14\n" +
+	"\x05trips\x18\x02 \x01(\x01R\x05trips\x12+\n" +
+	"\x11cancellation_rate\x18\x03 \x01(\x01R\x10cancellationRate\x12+\n" +
+	"\x11completion_streak\x18\x04 \x01(\x01R\x10completionStreak\"<\n" +
+	"\x1bGetDriverReliabilityRequest\x12\x1d\n" +
+	"\n" +
+	"driver_ids\x18\x01 \x03(\tR\tdriverIds\"\xbe\x01\n" +
+	"\x1cGetDriverReliabilityResponse\x12I\n" +
+	"\adrivers\x18\x01 \x03(\v2/.trip.GetDriverReliabilityResponse.DriversEntryR\adrivers\x1aS\n" +
+	"\fDriversEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12-\n" +
+	"\x05value\x18\x02 \x01(\v2\x17.trip.DriverReliabilityR\x05value:\x028\x01\"q\n" +
+	"\x1aCreateTripShareLinkRequest\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\x12\x19\n" +
+	"\brider_id\x18\x02 \x01(\tR\ariderId\x12\x1f\n" +
+	"\vttl_minutes\x18\x03 \x01(\x05R\n" +
+	"ttlMinutes\"n\n" +
+	"\x1bCreateTripShareLinkResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"2\n" +
+	"\x1aGetTripByShareTokenRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\x85\x03\n" +
+	"\x10SharedTripStatus\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\x12(\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x10.trip.TripStatusR\x06status\x127\n" +
+	"\x0fpickup_location\x18\x03 \x01(\v2\x0e.trip.LocationR\x0epickupLocation\x120\n" +
+	"\vdestination\x18\x04 \x01(\v2\x0e.trip.LocationR\vdestination\x127\n" +
+	"\x0fdriver_location\x18\x05 \x01(\v2\x0e.trip.LocationR\x0edriverLocation\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x14\n" +
+	"\x05ended\x18\b \x01(\bR\x05ended\"z\n" +
+	"\fIncidentNote\x12\x1b\n" +
+	"\tauthor_id\x18\x01 \x01(\tR\bauthorId\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x86\x06\n" +
+	"\bIncident\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\atrip_id\x18\x02 \x01(\tR\x06tripId\x12\x19\n" +
+	"\brider_id\x18\x03 \x01(\tR\ariderId\x12\x1b\n" +
+	"\tdriver_id\x18\x04 \x01(\tR\bdriverId\x12\x1f\n" +
+	"\vreporter_id\x18\x05 \x01(\tR\n" +
+	"reporterId\x12#\n" +
+	"\rreporter_role\x18\x06 \x01(\tR\freporterRole\x12\x16\n" +
+	"\x06status\x18\a \x01(\tR\x06status\x121\n" +
+	"\vtrip_status\x18\b \x01(\x0e2\x10.trip.TripStatusR\n" +
+	"tripStatus\x12*\n" +
+	"\blocation\x18\t \x01(\v2\x0e.trip.LocationR\blocation\x125\n" +
+	"\x0elocation_trail\x18\n" +
+	" \x03(\v2\x0e.trip.LocationR\rlocationTrail\x12\x19\n" +
+	"\balert_id\x18\v \x01(\tR\aalertId\x12(\n" +
+	"\x05notes\x18\f \x03(\v2\x12.trip.IncidentNoteR\x05notes\x12'\n" +
+	"\x0facknowledged_by\x18\r \x01(\tR\x0eacknowledgedBy\x12C\n" +
+	"\x0facknowledged_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\x0eacknowledgedAt\x12\x1f\n" +
+	"\vresolved_by\x18\x0f \x01(\tR\n" +
+	"resolvedBy\x12;\n" +
+	"\vresolved_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"resolvedAt\x12\x1e\n" +
+	"\n" +
+	"resolution\x18\x11 \x01(\tR\n" +
+	"resolution\x129\n" +
+	"\n" +
+	"created_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x13 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x92\x01\n" +
+	"\x10ReportSOSRequest\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\x12\x1f\n" +
+	"\vreporter_id\x18\x02 \x01(\tR\n" +
+	"reporterId\x12*\n" +
+	"\blocation\x18\x03 \x01(\v2\x0e.trip.LocationR\blocation\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"5\n" +
+	"\x12GetIncidentRequest\x12\x1f\n" +
+	"\vincident_id\x18\x01 \x01(\tR\n" +
+	"incidentId\"D\n" +
+	"\x14ListIncidentsRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"E\n" +
+	"\x15ListIncidentsResponse\x12,\n" +
+	"\tincidents\x18\x01 \x03(\v2\x0e.trip.IncidentR\tincidents\"X\n" +
+	"\x1aAcknowledgeIncidentRequest\x12\x1f\n" +
+	"\vincident_id\x18\x01 \x01(\tR\n" +
+	"incidentId\x12\x19\n" +
+	"\bstaff_id\x18\x02 \x01(\tR\astaffId\"j\n" +
+	"\x16AddIncidentNoteRequest\x12\x1f\n" +
+	"\vincident_id\x18\x01 \x01(\tR\n" +
+	"incidentId\x12\x1b\n" +
+	"\tauthor_id\x18\x02 \x01(\tR\bauthorId\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\"t\n" +
+	"\x16ResolveIncidentRequest\x12\x1f\n" +
+	"\vincident_id\x18\x01 \x01(\tR\n" +
+	"incidentId\x12\x19\n" +
+	"\bstaff_id\x18\x02 \x01(\tR\astaffId\x12\x1e\n" +
+	"\n" +
+	"resolution\x18\x03 \x01(\tR\n" +
+	"resolution\"E\n" +
+	"\x0fDisputeEvidence\x12 \n" +
+	"\vdescription\x18\x01 \x01(\tR\vdescription\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\"y\n" +
+	"\vDisputeNote\x12\x1b\n" +
+	"\tauthor_id\x18\x01 \x01(\tR\bauthorId\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"6\n" +
+	"\bFareLine\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x01R\x06amount\"\xe9\x05\n" +
+	"\aDispute\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\atrip_id\x18\x02 \x01(\tR\x06tripId\x12\x19\n" +
+	"\brider_id\x18\x03 \x01(\tR\ariderId\x12\x1b\n" +
+	"\tdriver_id\x18\x04 \x01(\tR\bdriverId\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12 \n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\x121\n" +
+	"\bevidence\x18\a \x03(\v2\x15.trip.DisputeEvidenceR\bevidence\x12\x16\n" +
+	"\x06status\x18\b \x01(\tR\x06status\x12%\n" +
+	"\x0echarged_amount\x18\t \x01(\x01R\rchargedAmount\x12\x1a\n" +
+	"\bcurrency\x18\n" +
+	" \x01(\tR\bcurrency\x125\n" +
+	"\x0efare_breakdown\x18\v \x03(\v2\x0e.trip.FareLineR\rfareBreakdown\x12\x1f\n" +
+	"\vassigned_to\x18\f \x01(\tR\n" +
+	"assignedTo\x12'\n" +
+	"\x05notes\x18\r \x03(\v2\x11.trip.DisputeNoteR\x05notes\x12\x1e\n" +
+	"\n" +
+	"resolution\x18\x0e \x01(\tR\n" +
+	"resolution\x12#\n" +
+	"\rrefund_amount\x18\x0f \x01(\x01R\frefundAmount\x12\x1b\n" +
+	"\trefund_id\x18\x10 \x01(\tR\brefundId\x12\x1f\n" +
+	"\vresolved_by\x18\x11 \x01(\tR\n" +
+	"resolvedBy\x12;\n" +
+	"\vresolved_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"resolvedAt\x129\n" +
+	"\n" +
+	"created_at\x18\x13 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x14 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x8e\x02\n" +
+	"\rDisputeReview\x12'\n" +
+	"\adispute\x18\x01 \x01(\v2\r.trip.DisputeR\adispute\x12$\n" +
+	"\x05route\x18\x02 \x03(\v2\x0e.trip.LocationR\x05route\x12%\n" +
+	"\x0eroute_recorded\x18\x03 \x01(\bR\rrouteRecorded\x12*\n" +
+	"\x11route_distance_km\x18\x04 \x01(\x01R\x0frouteDistanceKm\x120\n" +
+	"\x14reported_distance_km\x18\x05 \x01(\x01R\x12reportedDistanceKm\x12)\n" +
+	"\x10duration_seconds\x18\x06 \x01(\x05R\x0fdurationSeconds\"\xb5\x01\n" +
+	"\x12OpenDisputeRequest\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\x12\x19\n" +
+	"\brider_id\x18\x02 \x01(\tR\ariderId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x121\n" +
+	"\bevidence\x18\x05 \x03(\v2\x15.trip.DisputeEvidenceR\bevidence\"2\n" +
+	"\x11GetDisputeRequest\x12\x1d\n" +
+	"\n" +
+	"dispute_id\x18\x01 \x01(\tR\tdisputeId\"C\n" +
+	"\x13ListDisputesRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"A\n" +
+	"\x14ListDisputesResponse\x12)\n" +
+	"\bdisputes\x18\x01 \x03(\v2\r.trip.DisputeR\bdisputes\"U\n" +
+	"\x19StartDisputeReviewRequest\x12\x1d\n" +
+	"\n" +
+	"dispute_id\x18\x01 \x01(\tR\tdisputeId\x12\x19\n" +
+	"\bstaff_id\x18\x02 \x01(\tR\astaffId\"g\n" +
+	"\x15AddDisputeNoteRequest\x12\x1d\n" +
+	"\n" +
+	"dispute_id\x18\x01 \x01(\tR\tdisputeId\x12\x1b\n" +
+	"\tauthor_id\x18\x02 \x01(\tR\bauthorId\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\"\x96\x01\n" +
+	"\x15ResolveDisputeRequest\x12\x1d\n" +
+	"\n" +
+	"dispute_id\x18\x01 \x01(\tR\tdisputeId\x12\x19\n" +
+	"\bstaff_id\x18\x02 \x01(\tR\astaffId\x12\x1e\n" +
+	"\n" +
+	"resolution\x18\x03 \x01(\tR\n" +
+	"resolution\x12#\n" +
+	"\rrefund_amount\x18\x04 \x01(\x01R\frefundAmount\"p\n" +
+	"\x14RejectDisputeRequest\x12\x1d\n" +
+	"\n" +
+	"dispute_id\x18\x01 \x01(\tR\tdisputeId\x12\x19\n" +
+	"\bstaff_id\x18\x02 \x01(\tR\astaffId\x12\x1e\n" +
+	"\n" +
+	"resolution\x18\x03 \x01(\tR\n" +
+	"resolution\"\x92\x01\n" +
+	"\fLostItemNote\x12\x1b\n" +
+	"\tauthor_id\x18\x01 \x01(\tR\bauthorId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xf9\x03\n" +
+	"\x0eLostItemReport\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\atrip_id\x18\x02 \x01(\tR\x06tripId\x12\x19\n" +
+	"\brider_id\x18\x03 \x01(\tR\ariderId\x12\x1b\n" +
+	"\tdriver_id\x18\x04 \x01(\tR\bdriverId\x12 \n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12H\n" +
+	"\x12driver_notified_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x10driverNotifiedAt\x12?\n" +
+	"\rcontact_until\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\fcontactUntil\x12!\n" +
+	"\fcontact_open\x18\t \x01(\bR\vcontactOpen\x12(\n" +
+	"\x05notes\x18\n" +
+	" \x03(\v2\x12.trip.LostItemNoteR\x05notes\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"m\n" +
+	"\x15ReportLostItemRequest\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\x12\x19\n" +
+	"\brider_id\x18\x02 \x01(\tR\ariderId\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\"1\n" +
+	"\x12GetLostItemRequest\x12\x1b\n" +
+	"\treport_id\x18\x01 \x01(\tR\breportId\"\x95\x01\n" +
+	"\x14ListLostItemsRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x19\n" +
+	"\brider_id\x18\x02 \x01(\tR\ariderId\x12\x1b\n" +
+	"\tdriver_id\x18\x03 \x01(\tR\bdriverId\x12\x17\n" +
+	"\atrip_id\x18\x04 \x01(\tR\x06tripId\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\"G\n" +
+	"\x15ListLostItemsResponse\x12.\n" +
+	"\areports\x18\x01 \x03(\v2\x14.trip.LostItemReportR\areports\"}\n" +
+	"\x15UpdateLostItemRequest\x12\x1b\n" +
+	"\treport_id\x18\x01 \x01(\tR\breportId\x12\x1b\n" +
+	"\tauthor_id\x18\x02 \x01(\tR\bauthorId\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x12\n" +
+	"\x04note\x18\x04 \x01(\tR\x04note\"I\n" +
+	"\x15GetTripContactRequest\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\xb9\x01\n" +
+	"\vTripContact\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\x12\x12\n" +
+	"\x04mode\x18\x02 \x01(\tR\x04mode\x12#\n" +
+	"\rmasked_number\x18\x03 \x01(\tR\fmaskedNumber\x12\x1d\n" +
+	"\n" +
+	"voip_token\x18\x04 \x01(\tR\tvoipToken\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"4\n" +
+	"\x19GetPickupGuaranteeRequest\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\"\x86\x04\n" +
+	"\x0fPickupGuarantee\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\x12\x19\n" +
+	"\brider_id\x18\x02 \x01(\tR\ariderId\x12\x1b\n" +
+	"\tdriver_id\x18\x03 \x01(\tR\bdriverId\x12\x17\n" +
+	"\acity_id\x18\x04 \x01(\tR\x06cityId\x12\x12\n" +
+	"\x04area\x18\x05 \x01(\tR\x04area\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"matched_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tmatchedAt\x12;\n" +
+	"\vpromised_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"promisedAt\x12<\n" +
+	"\fpicked_up_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"pickedUpAt\x12&\n" +
+	"\x0flate_by_seconds\x18\n" +
+	" \x01(\x03R\rlateBySeconds\x12#\n" +
+	"\rcredit_amount\x18\v \x01(\x01R\fcreditAmount\x12\x1a\n" +
+	"\bcurrency\x18\f \x01(\tR\bcurrency\x12\x1b\n" +
+	"\tcredit_id\x18\r \x01(\tR\bcreditId\x12!\n" +
+	"\fcredit_error\x18\x0e \x01(\tR\vcreditError\"\x90\x01\n" +
+	"\x19GetPickupSLAReportRequest\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x17\n" +
+	"\acity_id\x18\x03 \x01(\tR\x06cityId\"\xa1\x01\n" +
+	"\x0ePickupSLAGroup\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x16\n" +
+	"\x06judged\x18\x02 \x01(\x03R\x06judged\x12\x16\n" +
+	"\x06missed\x18\x03 \x01(\x03R\x06missed\x12\x1b\n" +
+	"\tmiss_rate\x18\x04 \x01(\x01R\bmissRate\x120\n" +
+	"\x14average_late_seconds\x18\x05 \x01(\x01R\x12averageLateSeconds\"\xd9\x03\n" +
+	"\x0fPickupSLAReport\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x17\n" +
+	"\acity_id\x18\x03 \x01(\tR\x06cityId\x12\x16\n" +
+	"\x06judged\x18\x04 \x01(\x03R\x06judged\x12\x16\n" +
+	"\x06missed\x18\x05 \x01(\x03R\x06missed\x12\x1b\n" +
+	"\tmiss_rate\x18\x06 \x01(\x01R\bmissRate\x12\\\n" +
+	"\x13credits_by_currency\x18\a \x03(\v2,.trip.PickupSLAReport.CreditsByCurrencyEntryR\x11creditsByCurrency\x121\n" +
+	"\tby_driver\x18\b \x03(\v2\x14.trip.PickupSLAGroupR\bbyDriver\x12-\n" +
+	"\aby_area\x18\t \x03(\v2\x14.trip.PickupSLAGroupR\x06byArea\x1aD\n" +
+	"\x16CreditsByCurrencyEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"\xa0\x06\n" +
+	"\x10ComplianceReport\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vtemplate_id\x18\x02 \x01(\tR\n" +
+	"templateId\x12#\n" +
+	"\rtemplate_name\x18\x03 \x01(\tR\ftemplateName\x12\x17\n" +
+	"\acity_id\x18\x04 \x01(\tR\x06cityId\x12\x1a\n" +
+	"\bschedule\x18\x05 \x01(\tR\bschedule\x12=\n" +
+	"\fperiod_start\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vperiodStart\x129\n" +
+	"\n" +
+	"period_end\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tperiodEnd\x12\x1a\n" +
+	"\btimezone\x18\b \x01(\tR\btimezone\x12\x1d\n" +
+	"\n" +
+	"trip_count\x18\t \x01(\x03R\ttripCount\x122\n" +
+	"\x15accessible_trip_count\x18\n" +
+	" \x01(\x03R\x13accessibleTripCount\x122\n" +
+	"\x15suppressed_zone_count\x18\v \x01(\x03R\x13suppressedZoneCount\x12!\n" +
+	"\fcontent_type\x18\f \x01(\tR\vcontentType\x12\x18\n" +
+	"\acontent\x18\r \x01(\fR\acontent\x12!\n" +
+	"\fgenerated_by\x18\x0e \x01(\tR\vgeneratedBy\x12=\n" +
+	"\fgenerated_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\vgeneratedAt\x12*\n" +
+	"\x11wav_request_count\x18\x10 \x01(\x03R\x0fwavRequestCount\x12.\n" +
+	"\x13wav_fulfilled_count\x18\x11 \x01(\x03R\x11wavFulfilledCount\x120\n" +
+	"\x14wav_fulfillment_rate\x18\x12 \x01(\x01R\x12wavFulfillmentRate\x127\n" +
+	"\x18wav_average_wait_seconds\x18\x13 \x01(\x01R\x15wavAverageWaitSeconds\"n\n" +
+	"\x1cListComplianceReportsRequest\x12\x17\n" +
+	"\acity_id\x18\x01 \x01(\tR\x06cityId\x12\x1f\n" +
+	"\vtemplate_id\x18\x02 \x01(\tR\n" +
+	"templateId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"Q\n" +
+	"\x1dListComplianceReportsResponse\x120\n" +
+	"\areports\x18\x01 \x03(\v2\x16.trip.ComplianceReportR\areports\"9\n" +
+	"\x1aGetComplianceReportRequest\x12\x1b\n" +
+	"\treport_id\x18\x01 \x01(\tR\breportId\"y\n" +
+	"\x1fGenerateComplianceReportRequest\x12\x1f\n" +
+	"\vtemplate_id\x18\x01 \x01(\tR\n" +
+	"templateId\x12\x12\n" +
+	"\x04date\x18\x02 \x01(\tR\x04date\x12!\n" +
+	"\fgenerated_by\x18\x03 \x01(\tR\vgeneratedBy\"\xcb\x02\n" +
+	"\vTripMessage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\atrip_id\x18\x02 \x01(\tR\x06tripId\x12\x1b\n" +
+	"\tsender_id\x18\x03 \x01(\tR\bsenderId\x12\x1f\n" +
+	"\vsender_role\x18\x04 \x01(\tR\n" +
+	"senderRole\x12!\n" +
+	"\frecipient_id\x18\x05 \x01(\tR\vrecipientId\x12\x12\n" +
+	"\x04text\x18\x06 \x01(\tR\x04text\x12$\n" +
+	"\x0equick_reply_id\x18\a \x01(\tR\fquickReplyId\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12=\n" +
+	"\fdelivered_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\vdeliveredAt\"\x88\x01\n" +
+	"\x16SendTripMessageRequest\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\x12\x1b\n" +
+	"\tsender_id\x18\x02 \x01(\tR\bsenderId\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\x12$\n" +
+	"\x0equick_reply_id\x18\x04 \x01(\tR\fquickReplyId\"K\n" +
+	"\x17ListTripMessagesRequest\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"I\n" +
+	"\x18ListTripMessagesResponse\x12-\n" +
+	"\bmessages\x18\x01 \x03(\v2\x11.trip.TripMessageR\bmessages\"M\n" +
+	"\x19StreamTripMessagesRequest\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"0\n" +
+	"\n" +
+	"QuickReply\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\"-\n" +
+	"\x17ListQuickRepliesRequest\x12\x12\n" +
+	"\x04role\x18\x01 \x01(\tR\x04role\"Q\n" +
+	"\x18ListQuickRepliesResponse\x125\n" +
+	"\rquick_replies\x18\x01 \x03(\v2\x10.trip.QuickReplyR\fquickReplies\"\xfa\x02\n" +
+	"\vDriverEvent\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\x12)\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x15.trip.DriverEventTypeR\x04type\x12\x17\n" +
+	"\atrip_id\x18\x03 \x01(\tR\x06tripId\x12\x19\n" +
+	"\brider_id\x18\x04 \x01(\tR\ariderId\x125\n" +
+	"\x0erider_location\x18\x05 \x01(\v2\x0e.trip.LocationR\rriderLocation\x12!\n" +
+	"\fcancelled_by\x18\x06 \x01(\tR\vcancelledBy\x12\x16\n" +
+	"\x06reason\x18\a \x01(\tR\x06reason\x12;\n" +
+	"\voccurred_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12 \n" +
+	"\flost_item_id\x18\t \x01(\tR\n" +
+	"lostItemId\x12 \n" +
+	"\vdescription\x18\n" +
+	" \x01(\tR\vdescription\";\n" +
+	"\x1cSubscribeDriverEventsRequest\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\"|\n" +
+	"\x1aUpdateRiderLocationRequest\x12\x17\n" +
+	"\atrip_id\x18\x01 \x01(\tR\x06tripId\x12\x19\n" +
+	"\brider_id\x18\x02 \x01(\tR\ariderId\x12*\n" +
+	"\blocation\x18\x03 \x01(\v2\x0e.trip.LocationR\blocation\"\x1d\n" +
+	"\x1bUpdateRiderLocationResponse\"\x95\x03\n" +
+	"\n" +
+	"DeadLetter\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bconsumer\x18\x02 \x01(\tR\bconsumer\x12\x18\n" +
+	"\ahandler\x18\x03 \x01(\tR\ahandler\x12\x19\n" +
+	"\bevent_id\x18\x04 \x01(\tR\aeventId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x05 \x01(\tR\teventType\x12\x1d\n" +
+	"\n" +
+	"event_json\x18\x06 \x01(\tR\teventJson\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x12\x16\n" +
+	"\x06poison\x18\b \x01(\bR\x06poison\x12\x1a\n" +
+	"\battempts\x18\t \x01(\x05R\battempts\x12\x18\n" +
+	"\areplays\x18\n" +
+	" \x01(\x05R\areplays\x12B\n" +
+	"\x0ffirst_failed_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\rfirstFailedAt\x12@\n" +
+	"\x0elast_failed_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\flastFailedAt\"\x81\x01\n" +
+	"\x16ListDeadLettersRequest\x12\x1a\n" +
+	"\bconsumer\x18\x01 \x01(\tR\bconsumer\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x02 \x01(\tR\teventType\x12\x16\n" +
+	"\x06poison\x18\x03 \x01(\tR\x06poison\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"N\n" +
+	"\x17ListDeadLettersResponse\x123\n" +
+	"\fdead_letters\x18\x01 \x03(\v2\x10.trip.DeadLetterR\vdeadLetters\"<\n" +
+	"\x14GetDeadLetterRequest\x12$\n" +
+	"\x0edead_letter_id\x18\x01 \x01(\tR\fdeadLetterId\"?\n" +
+	"\x17ReplayDeadLetterRequest\x12$\n" +
+	"\x0edead_letter_id\x18\x01 \x01(\tR\fdeadLetterId\"\x1a\n" +
+	"\x18ReplayDeadLetterResponse\"@\n" +
+	"\x18DiscardDeadLetterRequest\x12$\n" +
+	"\x0edead_letter_id\x18\x01 \x01(\tR\fdeadLetterId\"\x1b\n" +
+	"\x19DiscardDeadLetterResponse\"\x9a\x02\n" +
+	"\x0fTripListingsLag\x12\x1e\n" +
+	"\n" +
+	"checkpoint\x18\x01 \x01(\x03R\n" +
+	"checkpoint\x12'\n" +
+	"\x0flatest_position\x18\x02 \x01(\x03R\x0elatestPosition\x12#\n" +
+	"\revents_behind\x18\x03 \x01(\x03R\feventsBehind\x12\x1f\n" +
+	"\vlag_seconds\x18\x04 \x01(\x01R\n" +
+	"lagSeconds\x12=\n" +
+	"\fprojected_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vprojectedAt\x129\n" +
+	"\n" +
+	"rebuilt_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\trebuiltAt\"\x1b\n" +
+	"\x19GetTripListingsLagRequest\"\x1c\n" +
+	"\x1aRebuildTripListingsRequest\";\n" +
+	"\x1bRebuildTripListingsResponse\x12\x1c\n" +
+	"\tprojected\x18\x01 \x01(\x05R\tprojected*\xd4\x01\n" +
 	"\n" +
 	"TripStatus\x12\x12\n" +
 	"\x0eUNKNOWN_STATUS\x10\x00\x12\r\n" +
@@ -1276,14 +8992,73 @@ const file_shared_proto_trip_trip_proto_rawDesc = "" +
 	"\x13CANCELLED_BY_DRIVER\x10\t\x12\n" +
 	"\n" +
 	"\x06FAILED\x10\n" +
-	"2\xc5\x03\n" +
+	"*\xb2\x01\n" +
+	"\x0fDriverEventType\x12\x18\n" +
+	"\x14DRIVER_EVENT_UNKNOWN\x10\x00\x12\x1e\n" +
+	"\x1aDRIVER_EVENT_TRIP_ASSIGNED\x10\x01\x12\x1f\n" +
+	"\x1bDRIVER_EVENT_TRIP_CANCELLED\x10\x02\x12\x1f\n" +
+	"\x1bDRIVER_EVENT_RIDER_LOCATION\x10\x03\x12#\n" +
+	"\x1fDRIVER_EVENT_LOST_ITEM_REPORTED\x10\x042\x83#\n" +
 	"\vTripService\x12?\n" +
 	"\n" +
 	"CreateTrip\x12\x17.trip.CreateTripRequest\x1a\x18.trip.CreateTripResponse\x126\n" +
 	"\aGetTrip\x12\x14.trip.GetTripRequest\x1a\x15.trip.GetTripResponse\x12Q\n" +
 	"\x10UpdateTripStatus\x12\x1d.trip.UpdateTripStatusRequest\x1a\x1e.trip.UpdateTripStatusResponse\x12E\n" +
 	"\fGetUserTrips\x12\x19.trip.GetUserTripsRequest\x1a\x1a.trip.GetUserTripsResponse\x12K\n" +
-	"\x0eGetActiveTrips\x12\x1b.trip.GetActiveTripsRequest\x1a\x1c.trip.GetActiveTripsResponse\x12V\n" +
+	"\x0eGetActiveTrips\x12\x1b.trip.GetActiveTripsRequest\x1a\x1c.trip.GetActiveTripsResponse\x12N\n" +
+	"\x0fForceCancelTrip\x12\x1c.trip.ForceCancelTripRequest\x1a\x1d.trip.ForceCancelTripResponse\x12W\n" +
+	"\x12AnonymizeUserTrips\x12\x1f.trip.AnonymizeUserTripsRequest\x1a .trip.AnonymizeUserTripsResponse\x12G\n" +
+	"\x0eOpenSharedTrip\x12\x1b.trip.OpenSharedTripRequest\x1a\x18.trip.SharedTripResponse\x12G\n" +
+	"\x0eAddSharedRider\x12\x1b.trip.AddSharedRiderRequest\x1a\x18.trip.SharedTripResponse\x12K\n" +
+	"\x10CompleteTripStop\x12\x1d.trip.CompleteTripStopRequest\x1a\x18.trip.SharedTripResponse\x12E\n" +
+	"\rGetSharedTrip\x12\x1a.trip.GetSharedTripRequest\x1a\x18.trip.SharedTripResponse\x12Z\n" +
+	"\x13ListOpenSharedTrips\x12 .trip.ListOpenSharedTripsRequest\x1a!.trip.ListOpenSharedTripsResponse\x12T\n" +
+	"\x13CreateScheduledRide\x12 .trip.CreateScheduledRideRequest\x1a\x1b.trip.ScheduledRideResponse\x12W\n" +
+	"\x12ListScheduledRides\x12\x1f.trip.ListScheduledRidesRequest\x1a .trip.ListScheduledRidesResponse\x12T\n" +
+	"\x13CancelScheduledRide\x12 .trip.CancelScheduledRideRequest\x1a\x1b.trip.ScheduledRideResponse\x12E\n" +
+	"\fSubmitRating\x12\x19.trip.SubmitRatingRequest\x1a\x1a.trip.SubmitRatingResponse\x12F\n" +
+	"\x10GetRatingSummary\x12\x1d.trip.GetRatingSummaryRequest\x1a\x13.trip.RatingSummary\x12B\n" +
+	"\vListReviews\x12\x18.trip.ListReviewsRequest\x1a\x19.trip.ListReviewsResponse\x12Q\n" +
+	"\x10GetDriverRatings\x12\x1d.trip.GetDriverRatingsRequest\x1a\x1e.trip.GetDriverRatingsResponse\x12]\n" +
+	"\x14GetDriverReliability\x12!.trip.GetDriverReliabilityRequest\x1a\".trip.GetDriverReliabilityResponse\x12Z\n" +
+	"\x13CreateTripShareLink\x12 .trip.CreateTripShareLinkRequest\x1a!.trip.CreateTripShareLinkResponse\x12O\n" +
+	"\x13GetTripByShareToken\x12 .trip.GetTripByShareTokenRequest\x1a\x16.trip.SharedTripStatus\x12S\n" +
+	"\x15WatchTripByShareToken\x12 .trip.GetTripByShareTokenRequest\x1a\x16.trip.SharedTripStatus0\x01\x123\n" +
+	"\tReportSOS\x12\x16.trip.ReportSOSRequest\x1a\x0e.trip.Incident\x127\n" +
+	"\vGetIncident\x12\x18.trip.GetIncidentRequest\x1a\x0e.trip.Incident\x12H\n" +
+	"\rListIncidents\x12\x1a.trip.ListIncidentsRequest\x1a\x1b.trip.ListIncidentsResponse\x12G\n" +
+	"\x13AcknowledgeIncident\x12 .trip.AcknowledgeIncidentRequest\x1a\x0e.trip.Incident\x12?\n" +
+	"\x0fAddIncidentNote\x12\x1c.trip.AddIncidentNoteRequest\x1a\x0e.trip.Incident\x12?\n" +
+	"\x0fResolveIncident\x12\x1c.trip.ResolveIncidentRequest\x1a\x0e.trip.Incident\x126\n" +
+	"\vOpenDispute\x12\x18.trip.OpenDisputeRequest\x1a\r.trip.Dispute\x12@\n" +
+	"\x10GetDisputeReview\x12\x17.trip.GetDisputeRequest\x1a\x13.trip.DisputeReview\x12E\n" +
+	"\fListDisputes\x12\x19.trip.ListDisputesRequest\x1a\x1a.trip.ListDisputesResponse\x12D\n" +
+	"\x12StartDisputeReview\x12\x1f.trip.StartDisputeReviewRequest\x1a\r.trip.Dispute\x12<\n" +
+	"\x0eAddDisputeNote\x12\x1b.trip.AddDisputeNoteRequest\x1a\r.trip.Dispute\x12<\n" +
+	"\x0eResolveDispute\x12\x1b.trip.ResolveDisputeRequest\x1a\r.trip.Dispute\x12:\n" +
+	"\rRejectDispute\x12\x1a.trip.RejectDisputeRequest\x1a\r.trip.Dispute\x12C\n" +
+	"\x0eReportLostItem\x12\x1b.trip.ReportLostItemRequest\x1a\x14.trip.LostItemReport\x12=\n" +
+	"\vGetLostItem\x12\x18.trip.GetLostItemRequest\x1a\x14.trip.LostItemReport\x12H\n" +
+	"\rListLostItems\x12\x1a.trip.ListLostItemsRequest\x1a\x1b.trip.ListLostItemsResponse\x12C\n" +
+	"\x0eUpdateLostItem\x12\x1b.trip.UpdateLostItemRequest\x1a\x14.trip.LostItemReport\x12@\n" +
+	"\x0eGetTripContact\x12\x1b.trip.GetTripContactRequest\x1a\x11.trip.TripContact\x12L\n" +
+	"\x12GetPickupGuarantee\x12\x1f.trip.GetPickupGuaranteeRequest\x1a\x15.trip.PickupGuarantee\x12L\n" +
+	"\x12GetPickupSLAReport\x12\x1f.trip.GetPickupSLAReportRequest\x1a\x15.trip.PickupSLAReport\x12`\n" +
+	"\x15ListComplianceReports\x12\".trip.ListComplianceReportsRequest\x1a#.trip.ListComplianceReportsResponse\x12O\n" +
+	"\x13GetComplianceReport\x12 .trip.GetComplianceReportRequest\x1a\x16.trip.ComplianceReport\x12Y\n" +
+	"\x18GenerateComplianceReport\x12%.trip.GenerateComplianceReportRequest\x1a\x16.trip.ComplianceReport\x12N\n" +
+	"\x0fListDeadLetters\x12\x1c.trip.ListDeadLettersRequest\x1a\x1d.trip.ListDeadLettersResponse\x12=\n" +
+	"\rGetDeadLetter\x12\x1a.trip.GetDeadLetterRequest\x1a\x10.trip.DeadLetter\x12Q\n" +
+	"\x10ReplayDeadLetter\x12\x1d.trip.ReplayDeadLetterRequest\x1a\x1e.trip.ReplayDeadLetterResponse\x12T\n" +
+	"\x11DiscardDeadLetter\x12\x1e.trip.DiscardDeadLetterRequest\x1a\x1f.trip.DiscardDeadLetterResponse\x12L\n" +
+	"\x12GetTripListingsLag\x12\x1f.trip.GetTripListingsLagRequest\x1a\x15.trip.TripListingsLag\x12Z\n" +
+	"\x13RebuildTripListings\x12 .trip.RebuildTripListingsRequest\x1a!.trip.RebuildTripListingsResponse\x12B\n" +
+	"\x0fSendTripMessage\x12\x1c.trip.SendTripMessageRequest\x1a\x11.trip.TripMessage\x12Q\n" +
+	"\x10ListTripMessages\x12\x1d.trip.ListTripMessagesRequest\x1a\x1e.trip.ListTripMessagesResponse\x12J\n" +
+	"\x12StreamTripMessages\x12\x1f.trip.StreamTripMessagesRequest\x1a\x11.trip.TripMessage0\x01\x12Q\n" +
+	"\x10ListQuickReplies\x12\x1d.trip.ListQuickRepliesRequest\x1a\x1e.trip.ListQuickRepliesResponse\x12P\n" +
+	"\x15SubscribeDriverEvents\x12\".trip.SubscribeDriverEventsRequest\x1a\x11.trip.DriverEvent0\x01\x12Z\n" +
+	"\x13UpdateRiderLocation\x12 .trip.UpdateRiderLocationRequest\x1a!.trip.UpdateRiderLocationResponse\x12V\n" +
 	"\x16SubscribeToTripUpdates\x12#.trip.SubscribeToTripUpdatesRequest\x1a\x15.trip.TripUpdateEvent0\x01B1Z/github.com/rideshare-platform/shared/proto/tripb\x06proto3"
 
 var (
@@ -1298,69 +9073,387 @@ func file_shared_proto_trip_trip_proto_rawDescGZIP() []byte {
 	return file_shared_proto_trip_trip_proto_rawDescData
 }
 
-var file_shared_proto_trip_trip_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_shared_proto_trip_trip_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_shared_proto_trip_trip_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_shared_proto_trip_trip_proto_msgTypes = make([]protoimpl.MessageInfo, 121)
 var file_shared_proto_trip_trip_proto_goTypes = []any{
-	(TripStatus)(0),                       // 0: trip.TripStatus
-	(*Location)(nil),                      // 1: trip.Location
-	(*Trip)(nil),                          // 2: trip.Trip
-	(*TripMetadata)(nil),                  // 3: trip.TripMetadata
-	(*CreateTripRequest)(nil),             // 4: trip.CreateTripRequest
-	(*CreateTripResponse)(nil),            // 5: trip.CreateTripResponse
-	(*GetTripRequest)(nil),                // 6: trip.GetTripRequest
-	(*GetTripResponse)(nil),               // 7: trip.GetTripResponse
-	(*UpdateTripStatusRequest)(nil),       // 8: trip.UpdateTripStatusRequest
-	(*UpdateTripStatusResponse)(nil),      // 9: trip.UpdateTripStatusResponse
-	(*GetUserTripsRequest)(nil),           // 10: trip.GetUserTripsRequest
-	(*GetUserTripsResponse)(nil),          // 11: trip.GetUserTripsResponse
-	(*GetActiveTripsRequest)(nil),         // 12: trip.GetActiveTripsRequest
-	(*GetActiveTripsResponse)(nil),        // 13: trip.GetActiveTripsResponse
-	(*TripUpdateEvent)(nil),               // 14: trip.TripUpdateEvent
-	(*SubscribeToTripUpdatesRequest)(nil), // 15: trip.SubscribeToTripUpdatesRequest
-	nil,                                   // 16: trip.TripUpdateEvent.MetadataEntry
-	(*timestamppb.Timestamp)(nil),         // 17: google.protobuf.Timestamp
+	(TripStatus)(0),                         // 0: trip.TripStatus
+	(DriverEventType)(0),                    // 1: trip.DriverEventType
+	(*Location)(nil),                        // 2: trip.Location
+	(*Trip)(nil),                            // 3: trip.Trip
+	(*TripMetadata)(nil),                    // 4: trip.TripMetadata
+	(*CreateTripRequest)(nil),               // 5: trip.CreateTripRequest
+	(*CreateTripResponse)(nil),              // 6: trip.CreateTripResponse
+	(*GetTripRequest)(nil),                  // 7: trip.GetTripRequest
+	(*GetTripResponse)(nil),                 // 8: trip.GetTripResponse
+	(*UpdateTripStatusRequest)(nil),         // 9: trip.UpdateTripStatusRequest
+	(*TripCompletion)(nil),                  // 10: trip.TripCompletion
+	(*UpdateTripStatusResponse)(nil),        // 11: trip.UpdateTripStatusResponse
+	(*GetUserTripsRequest)(nil),             // 12: trip.GetUserTripsRequest
+	(*GetUserTripsResponse)(nil),            // 13: trip.GetUserTripsResponse
+	(*GetActiveTripsRequest)(nil),           // 14: trip.GetActiveTripsRequest
+	(*GetActiveTripsResponse)(nil),          // 15: trip.GetActiveTripsResponse
+	(*ForceCancelTripRequest)(nil),          // 16: trip.ForceCancelTripRequest
+	(*ForceCancelTripResponse)(nil),         // 17: trip.ForceCancelTripResponse
+	(*AnonymizeUserTripsRequest)(nil),       // 18: trip.AnonymizeUserTripsRequest
+	(*AnonymizeUserTripsResponse)(nil),      // 19: trip.AnonymizeUserTripsResponse
+	(*TripUpdateEvent)(nil),                 // 20: trip.TripUpdateEvent
+	(*SubscribeToTripUpdatesRequest)(nil),   // 21: trip.SubscribeToTripUpdatesRequest
+	(*TripStop)(nil),                        // 22: trip.TripStop
+	(*SharedRider)(nil),                     // 23: trip.SharedRider
+	(*SharedTrip)(nil),                      // 24: trip.SharedTrip
+	(*OpenSharedTripRequest)(nil),           // 25: trip.OpenSharedTripRequest
+	(*AddSharedRiderRequest)(nil),           // 26: trip.AddSharedRiderRequest
+	(*CompleteTripStopRequest)(nil),         // 27: trip.CompleteTripStopRequest
+	(*GetSharedTripRequest)(nil),            // 28: trip.GetSharedTripRequest
+	(*SharedTripResponse)(nil),              // 29: trip.SharedTripResponse
+	(*ListOpenSharedTripsRequest)(nil),      // 30: trip.ListOpenSharedTripsRequest
+	(*ListOpenSharedTripsResponse)(nil),     // 31: trip.ListOpenSharedTripsResponse
+	(*ScheduledRide)(nil),                   // 32: trip.ScheduledRide
+	(*CreateScheduledRideRequest)(nil),      // 33: trip.CreateScheduledRideRequest
+	(*ScheduledRideResponse)(nil),           // 34: trip.ScheduledRideResponse
+	(*ListScheduledRidesRequest)(nil),       // 35: trip.ListScheduledRidesRequest
+	(*ListScheduledRidesResponse)(nil),      // 36: trip.ListScheduledRidesResponse
+	(*CancelScheduledRideRequest)(nil),      // 37: trip.CancelScheduledRideRequest
+	(*Rating)(nil),                          // 38: trip.Rating
+	(*RatingSummary)(nil),                   // 39: trip.RatingSummary
+	(*SubmitRatingRequest)(nil),             // 40: trip.SubmitRatingRequest
+	(*SubmitRatingResponse)(nil),            // 41: trip.SubmitRatingResponse
+	(*GetRatingSummaryRequest)(nil),         // 42: trip.GetRatingSummaryRequest
+	(*ListReviewsRequest)(nil),              // 43: trip.ListReviewsRequest
+	(*ListReviewsResponse)(nil),             // 44: trip.ListReviewsResponse
+	(*GetDriverRatingsRequest)(nil),         // 45: trip.GetDriverRatingsRequest
+	(*GetDriverRatingsResponse)(nil),        // 46: trip.GetDriverRatingsResponse
+	(*DriverReliability)(nil),               // 47: trip.DriverReliability
+	(*GetDriverReliabilityRequest)(nil),     // 48: trip.GetDriverReliabilityRequest
+	(*GetDriverReliabilityResponse)(nil),    // 49: trip.GetDriverReliabilityResponse
+	(*CreateTripShareLinkRequest)(nil),      // 50: trip.CreateTripShareLinkRequest
+	(*CreateTripShareLinkResponse)(nil),     // 51: trip.CreateTripShareLinkResponse
+	(*GetTripByShareTokenRequest)(nil),      // 52: trip.GetTripByShareTokenRequest
+	(*SharedTripStatus)(nil),                // 53: trip.SharedTripStatus
+	(*IncidentNote)(nil),                    // 54: trip.IncidentNote
+	(*Incident)(nil),                        // 55: trip.Incident
+	(*ReportSOSRequest)(nil),                // 56: trip.ReportSOSRequest
+	(*GetIncidentRequest)(nil),              // 57: trip.GetIncidentRequest
+	(*ListIncidentsRequest)(nil),            // 58: trip.ListIncidentsRequest
+	(*ListIncidentsResponse)(nil),           // 59: trip.ListIncidentsResponse
+	(*AcknowledgeIncidentRequest)(nil),      // 60: trip.AcknowledgeIncidentRequest
+	(*AddIncidentNoteRequest)(nil),          // 61: trip.AddIncidentNoteRequest
+	(*ResolveIncidentRequest)(nil),          // 62: trip.ResolveIncidentRequest
+	(*DisputeEvidence)(nil),                 // 63: trip.DisputeEvidence
+	(*DisputeNote)(nil),                     // 64: trip.DisputeNote
+	(*FareLine)(nil),                        // 65: trip.FareLine
+	(*Dispute)(nil),                         // 66: trip.Dispute
+	(*DisputeReview)(nil),                   // 67: trip.DisputeReview
+	(*OpenDisputeRequest)(nil),              // 68: trip.OpenDisputeRequest
+	(*GetDisputeRequest)(nil),               // 69: trip.GetDisputeRequest
+	(*ListDisputesRequest)(nil),             // 70: trip.ListDisputesRequest
+	(*ListDisputesResponse)(nil),            // 71: trip.ListDisputesResponse
+	(*StartDisputeReviewRequest)(nil),       // 72: trip.StartDisputeReviewRequest
+	(*AddDisputeNoteRequest)(nil),           // 73: trip.AddDisputeNoteRequest
+	(*ResolveDisputeRequest)(nil),           // 74: trip.ResolveDisputeRequest
+	(*RejectDisputeRequest)(nil),            // 75: trip.RejectDisputeRequest
+	(*LostItemNote)(nil),                    // 76: trip.LostItemNote
+	(*LostItemReport)(nil),                  // 77: trip.LostItemReport
+	(*ReportLostItemRequest)(nil),           // 78: trip.ReportLostItemRequest
+	(*GetLostItemRequest)(nil),              // 79: trip.GetLostItemRequest
+	(*ListLostItemsRequest)(nil),            // 80: trip.ListLostItemsRequest
+	(*ListLostItemsResponse)(nil),           // 81: trip.ListLostItemsResponse
+	(*UpdateLostItemRequest)(nil),           // 82: trip.UpdateLostItemRequest
+	(*GetTripContactRequest)(nil),           // 83: trip.GetTripContactRequest
+	(*TripContact)(nil),                     // 84: trip.TripContact
+	(*GetPickupGuaranteeRequest)(nil),       // 85: trip.GetPickupGuaranteeRequest
+	(*PickupGuarantee)(nil),                 // 86: trip.PickupGuarantee
+	(*GetPickupSLAReportRequest)(nil),       // 87: trip.GetPickupSLAReportRequest
+	(*PickupSLAGroup)(nil),                  // 88: trip.PickupSLAGroup
+	(*PickupSLAReport)(nil),                 // 89: trip.PickupSLAReport
+	(*ComplianceReport)(nil),                // 90: trip.ComplianceReport
+	(*ListComplianceReportsRequest)(nil),    // 91: trip.ListComplianceReportsRequest
+	(*ListComplianceReportsResponse)(nil),   // 92: trip.ListComplianceReportsResponse
+	(*GetComplianceReportRequest)(nil),      // 93: trip.GetComplianceReportRequest
+	(*GenerateComplianceReportRequest)(nil), // 94: trip.GenerateComplianceReportRequest
+	(*TripMessage)(nil),                     // 95: trip.TripMessage
+	(*SendTripMessageRequest)(nil),          // 96: trip.SendTripMessageRequest
+	(*ListTripMessagesRequest)(nil),         // 97: trip.ListTripMessagesRequest
+	(*ListTripMessagesResponse)(nil),        // 98: trip.ListTripMessagesResponse
+	(*StreamTripMessagesRequest)(nil),       // 99: trip.StreamTripMessagesRequest
+	(*QuickReply)(nil),                      // 100: trip.QuickReply
+	(*ListQuickRepliesRequest)(nil),         // 101: trip.ListQuickRepliesRequest
+	(*ListQuickRepliesResponse)(nil),        // 102: trip.ListQuickRepliesResponse
+	(*DriverEvent)(nil),                     // 103: trip.DriverEvent
+	(*SubscribeDriverEventsRequest)(nil),    // 104: trip.SubscribeDriverEventsRequest
+	(*UpdateRiderLocationRequest)(nil),      // 105: trip.UpdateRiderLocationRequest
+	(*UpdateRiderLocationResponse)(nil),     // 106: trip.UpdateRiderLocationResponse
+	(*DeadLetter)(nil),                      // 107: trip.DeadLetter
+	(*ListDeadLettersRequest)(nil),          // 108: trip.ListDeadLettersRequest
+	(*ListDeadLettersResponse)(nil),         // 109: trip.ListDeadLettersResponse
+	(*GetDeadLetterRequest)(nil),            // 110: trip.GetDeadLetterRequest
+	(*ReplayDeadLetterRequest)(nil),         // 111: trip.ReplayDeadLetterRequest
+	(*ReplayDeadLetterResponse)(nil),        // 112: trip.ReplayDeadLetterResponse
+	(*DiscardDeadLetterRequest)(nil),        // 113: trip.DiscardDeadLetterRequest
+	(*DiscardDeadLetterResponse)(nil),       // 114: trip.DiscardDeadLetterResponse
+	(*TripListingsLag)(nil),                 // 115: trip.TripListingsLag
+	(*GetTripListingsLagRequest)(nil),       // 116: trip.GetTripListingsLagRequest
+	(*RebuildTripListingsRequest)(nil),      // 117: trip.RebuildTripListingsRequest
+	(*RebuildTripListingsResponse)(nil),     // 118: trip.RebuildTripListingsResponse
+	nil,                                     // 119: trip.TripUpdateEvent.MetadataEntry
+	nil,                                     // 120: trip.GetDriverRatingsResponse.RatingsEntry
+	nil,                                     // 121: trip.GetDriverReliabilityResponse.DriversEntry
+	nil,                                     // 122: trip.PickupSLAReport.CreditsByCurrencyEntry
+	(*timestamppb.Timestamp)(nil),           // 123: google.protobuf.Timestamp
 }
 var file_shared_proto_trip_trip_proto_depIdxs = []int32{
-	0,  // 0: trip.Trip.status:type_name -> trip.TripStatus
-	1,  // 1: trip.Trip.pickup_location:type_name -> trip.Location
-	1,  // 2: trip.Trip.destination:type_name -> trip.Location
-	17, // 3: trip.Trip.requested_at:type_name -> google.protobuf.Timestamp
-	17, // 4: trip.Trip.accepted_at:type_name -> google.protobuf.Timestamp
-	17, // 5: trip.Trip.started_at:type_name -> google.protobuf.Timestamp
-	17, // 6: trip.Trip.completed_at:type_name -> google.protobuf.Timestamp
-	3,  // 7: trip.Trip.metadata:type_name -> trip.TripMetadata
-	1,  // 8: trip.CreateTripRequest.pickup_location:type_name -> trip.Location
-	1,  // 9: trip.CreateTripRequest.destination:type_name -> trip.Location
-	3,  // 10: trip.CreateTripRequest.metadata:type_name -> trip.TripMetadata
-	2,  // 11: trip.CreateTripResponse.trip:type_name -> trip.Trip
-	2,  // 12: trip.GetTripResponse.trip:type_name -> trip.Trip
-	0,  // 13: trip.UpdateTripStatusRequest.status:type_name -> trip.TripStatus
-	2,  // 14: trip.UpdateTripStatusResponse.trip:type_name -> trip.Trip
-	0,  // 15: trip.GetUserTripsRequest.status:type_name -> trip.TripStatus
-	2,  // 16: trip.GetUserTripsResponse.trips:type_name -> trip.Trip
-	2,  // 17: trip.GetActiveTripsResponse.trips:type_name -> trip.Trip
-	0,  // 18: trip.TripUpdateEvent.old_status:type_name -> trip.TripStatus
-	0,  // 19: trip.TripUpdateEvent.new_status:type_name -> trip.TripStatus
-	1,  // 20: trip.TripUpdateEvent.current_location:type_name -> trip.Location
-	17, // 21: trip.TripUpdateEvent.timestamp:type_name -> google.protobuf.Timestamp
-	16, // 22: trip.TripUpdateEvent.metadata:type_name -> trip.TripUpdateEvent.MetadataEntry
-	4,  // 23: trip.TripService.CreateTrip:input_type -> trip.CreateTripRequest
-	6,  // 24: trip.TripService.GetTrip:input_type -> trip.GetTripRequest
-	8,  // 25: trip.TripService.UpdateTripStatus:input_type -> trip.UpdateTripStatusRequest
-	10, // 26: trip.TripService.GetUserTrips:input_type -> trip.GetUserTripsRequest
-	12, // 27: trip.TripService.GetActiveTrips:input_type -> trip.GetActiveTripsRequest
-	15, // 28: trip.TripService.SubscribeToTripUpdates:input_type -> trip.SubscribeToTripUpdatesRequest
-	5,  // 29: trip.TripService.CreateTrip:output_type -> trip.CreateTripResponse
-	7,  // 30: trip.TripService.GetTrip:output_type -> trip.GetTripResponse
-	9,  // 31: trip.TripService.UpdateTripStatus:output_type -> trip.UpdateTripStatusResponse
-	11, // 32: trip.TripService.GetUserTrips:output_type -> trip.GetUserTripsResponse
-	13, // 33: trip.TripService.GetActiveTrips:output_type -> trip.GetActiveTripsResponse
-	14, // 34: trip.TripService.SubscribeToTripUpdates:output_type -> trip.TripUpdateEvent
-	29, // [29:35] is the sub-list for method output_type
-	23, // [23:29] is the sub-list for method input_type
-	23, // [23:23] is the sub-list for extension type_name
-	23, // [23:23] is the sub-list for extension extendee
-	0,  // [0:23] is the sub-list for field type_name
+	0,   // 0: trip.Trip.status:type_name -> trip.TripStatus
+	2,   // 1: trip.Trip.pickup_location:type_name -> trip.Location
+	2,   // 2: trip.Trip.destination:type_name -> trip.Location
+	123, // 3: trip.Trip.requested_at:type_name -> google.protobuf.Timestamp
+	123, // 4: trip.Trip.accepted_at:type_name -> google.protobuf.Timestamp
+	123, // 5: trip.Trip.started_at:type_name -> google.protobuf.Timestamp
+	123, // 6: trip.Trip.completed_at:type_name -> google.protobuf.Timestamp
+	4,   // 7: trip.Trip.metadata:type_name -> trip.TripMetadata
+	123, // 8: trip.Trip.scheduled_for:type_name -> google.protobuf.Timestamp
+	2,   // 9: trip.CreateTripRequest.pickup_location:type_name -> trip.Location
+	2,   // 10: trip.CreateTripRequest.destination:type_name -> trip.Location
+	4,   // 11: trip.CreateTripRequest.metadata:type_name -> trip.TripMetadata
+	123, // 12: trip.CreateTripRequest.scheduled_for:type_name -> google.protobuf.Timestamp
+	3,   // 13: trip.CreateTripResponse.trip:type_name -> trip.Trip
+	3,   // 14: trip.GetTripResponse.trip:type_name -> trip.Trip
+	0,   // 15: trip.UpdateTripStatusRequest.status:type_name -> trip.TripStatus
+	10,  // 16: trip.UpdateTripStatusRequest.completion:type_name -> trip.TripCompletion
+	2,   // 17: trip.TripCompletion.pickup_location:type_name -> trip.Location
+	2,   // 18: trip.TripCompletion.destination:type_name -> trip.Location
+	123, // 19: trip.TripCompletion.started_at:type_name -> google.protobuf.Timestamp
+	3,   // 20: trip.UpdateTripStatusResponse.trip:type_name -> trip.Trip
+	0,   // 21: trip.GetUserTripsRequest.status:type_name -> trip.TripStatus
+	3,   // 22: trip.GetUserTripsResponse.trips:type_name -> trip.Trip
+	0,   // 23: trip.GetActiveTripsRequest.status:type_name -> trip.TripStatus
+	3,   // 24: trip.GetActiveTripsResponse.trips:type_name -> trip.Trip
+	3,   // 25: trip.ForceCancelTripResponse.trip:type_name -> trip.Trip
+	0,   // 26: trip.TripUpdateEvent.old_status:type_name -> trip.TripStatus
+	0,   // 27: trip.TripUpdateEvent.new_status:type_name -> trip.TripStatus
+	2,   // 28: trip.TripUpdateEvent.current_location:type_name -> trip.Location
+	123, // 29: trip.TripUpdateEvent.timestamp:type_name -> google.protobuf.Timestamp
+	119, // 30: trip.TripUpdateEvent.metadata:type_name -> trip.TripUpdateEvent.MetadataEntry
+	2,   // 31: trip.TripStop.location:type_name -> trip.Location
+	123, // 32: trip.TripStop.completed_at:type_name -> google.protobuf.Timestamp
+	2,   // 33: trip.SharedRider.pickup_location:type_name -> trip.Location
+	2,   // 34: trip.SharedRider.destination:type_name -> trip.Location
+	22,  // 35: trip.SharedTrip.stops:type_name -> trip.TripStop
+	23,  // 36: trip.SharedTrip.riders:type_name -> trip.SharedRider
+	2,   // 37: trip.SharedTrip.current_location:type_name -> trip.Location
+	123, // 38: trip.SharedTrip.created_at:type_name -> google.protobuf.Timestamp
+	123, // 39: trip.SharedTrip.updated_at:type_name -> google.protobuf.Timestamp
+	23,  // 40: trip.OpenSharedTripRequest.rider:type_name -> trip.SharedRider
+	2,   // 41: trip.OpenSharedTripRequest.current_location:type_name -> trip.Location
+	23,  // 42: trip.AddSharedRiderRequest.rider:type_name -> trip.SharedRider
+	24,  // 43: trip.SharedTripResponse.shared_trip:type_name -> trip.SharedTrip
+	24,  // 44: trip.ListOpenSharedTripsResponse.shared_trips:type_name -> trip.SharedTrip
+	2,   // 45: trip.ScheduledRide.pickup_location:type_name -> trip.Location
+	2,   // 46: trip.ScheduledRide.destination:type_name -> trip.Location
+	123, // 47: trip.ScheduledRide.scheduled_for:type_name -> google.protobuf.Timestamp
+	123, // 48: trip.ScheduledRide.dispatch_at:type_name -> google.protobuf.Timestamp
+	123, // 49: trip.ScheduledRide.created_at:type_name -> google.protobuf.Timestamp
+	123, // 50: trip.ScheduledRide.updated_at:type_name -> google.protobuf.Timestamp
+	2,   // 51: trip.CreateScheduledRideRequest.pickup_location:type_name -> trip.Location
+	2,   // 52: trip.CreateScheduledRideRequest.destination:type_name -> trip.Location
+	123, // 53: trip.CreateScheduledRideRequest.scheduled_for:type_name -> google.protobuf.Timestamp
+	32,  // 54: trip.ScheduledRideResponse.scheduled_ride:type_name -> trip.ScheduledRide
+	32,  // 55: trip.ListScheduledRidesResponse.scheduled_rides:type_name -> trip.ScheduledRide
+	123, // 56: trip.Rating.created_at:type_name -> google.protobuf.Timestamp
+	123, // 57: trip.RatingSummary.updated_at:type_name -> google.protobuf.Timestamp
+	38,  // 58: trip.SubmitRatingResponse.rating:type_name -> trip.Rating
+	39,  // 59: trip.SubmitRatingResponse.ratee_summary:type_name -> trip.RatingSummary
+	38,  // 60: trip.ListReviewsResponse.reviews:type_name -> trip.Rating
+	120, // 61: trip.GetDriverRatingsResponse.ratings:type_name -> trip.GetDriverRatingsResponse.RatingsEntry
+	121, // 62: trip.GetDriverReliabilityResponse.drivers:type_name -> trip.GetDriverReliabilityResponse.DriversEntry
+	123, // 63: trip.CreateTripShareLinkResponse.expires_at:type_name -> google.protobuf.Timestamp
+	0,   // 64: trip.SharedTripStatus.status:type_name -> trip.TripStatus
+	2,   // 65: trip.SharedTripStatus.pickup_location:type_name -> trip.Location
+	2,   // 66: trip.SharedTripStatus.destination:type_name -> trip.Location
+	2,   // 67: trip.SharedTripStatus.driver_location:type_name -> trip.Location
+	123, // 68: trip.SharedTripStatus.updated_at:type_name -> google.protobuf.Timestamp
+	123, // 69: trip.SharedTripStatus.expires_at:type_name -> google.protobuf.Timestamp
+	123, // 70: trip.IncidentNote.created_at:type_name -> google.protobuf.Timestamp
+	0,   // 71: trip.Incident.trip_status:type_name -> trip.TripStatus
+	2,   // 72: trip.Incident.location:type_name -> trip.Location
+	2,   // 73: trip.Incident.location_trail:type_name -> trip.Location
+	54,  // 74: trip.Incident.notes:type_name -> trip.IncidentNote
+	123, // 75: trip.Incident.acknowledged_at:type_name -> google.protobuf.Timestamp
+	123, // 76: trip.Incident.resolved_at:type_name -> google.protobuf.Timestamp
+	123, // 77: trip.Incident.created_at:type_name -> google.protobuf.Timestamp
+	123, // 78: trip.Incident.updated_at:type_name -> google.protobuf.Timestamp
+	2,   // 79: trip.ReportSOSRequest.location:type_name -> trip.Location
+	55,  // 80: trip.ListIncidentsResponse.incidents:type_name -> trip.Incident
+	123, // 81: trip.DisputeNote.created_at:type_name -> google.protobuf.Timestamp
+	63,  // 82: trip.Dispute.evidence:type_name -> trip.DisputeEvidence
+	65,  // 83: trip.Dispute.fare_breakdown:type_name -> trip.FareLine
+	64,  // 84: trip.Dispute.notes:type_name -> trip.DisputeNote
+	123, // 85: trip.Dispute.resolved_at:type_name -> google.protobuf.Timestamp
+	123, // 86: trip.Dispute.created_at:type_name -> google.protobuf.Timestamp
+	123, // 87: trip.Dispute.updated_at:type_name -> google.protobuf.Timestamp
+	66,  // 88: trip.DisputeReview.dispute:type_name -> trip.Dispute
+	2,   // 89: trip.DisputeReview.route:type_name -> trip.Location
+	63,  // 90: trip.OpenDisputeRequest.evidence:type_name -> trip.DisputeEvidence
+	66,  // 91: trip.ListDisputesResponse.disputes:type_name -> trip.Dispute
+	123, // 92: trip.LostItemNote.created_at:type_name -> google.protobuf.Timestamp
+	123, // 93: trip.LostItemReport.driver_notified_at:type_name -> google.protobuf.Timestamp
+	123, // 94: trip.LostItemReport.contact_until:type_name -> google.protobuf.Timestamp
+	76,  // 95: trip.LostItemReport.notes:type_name -> trip.LostItemNote
+	123, // 96: trip.LostItemReport.created_at:type_name -> google.protobuf.Timestamp
+	123, // 97: trip.LostItemReport.updated_at:type_name -> google.protobuf.Timestamp
+	77,  // 98: trip.ListLostItemsResponse.reports:type_name -> trip.LostItemReport
+	123, // 99: trip.TripContact.expires_at:type_name -> google.protobuf.Timestamp
+	123, // 100: trip.PickupGuarantee.matched_at:type_name -> google.protobuf.Timestamp
+	123, // 101: trip.PickupGuarantee.promised_at:type_name -> google.protobuf.Timestamp
+	123, // 102: trip.PickupGuarantee.picked_up_at:type_name -> google.protobuf.Timestamp
+	123, // 103: trip.GetPickupSLAReportRequest.from:type_name -> google.protobuf.Timestamp
+	123, // 104: trip.GetPickupSLAReportRequest.to:type_name -> google.protobuf.Timestamp
+	123, // 105: trip.PickupSLAReport.from:type_name -> google.protobuf.Timestamp
+	123, // 106: trip.PickupSLAReport.to:type_name -> google.protobuf.Timestamp
+	122, // 107: trip.PickupSLAReport.credits_by_currency:type_name -> trip.PickupSLAReport.CreditsByCurrencyEntry
+	88,  // 108: trip.PickupSLAReport.by_driver:type_name -> trip.PickupSLAGroup
+	88,  // 109: trip.PickupSLAReport.by_area:type_name -> trip.PickupSLAGroup
+	123, // 110: trip.ComplianceReport.period_start:type_name -> google.protobuf.Timestamp
+	123, // 111: trip.ComplianceReport.period_end:type_name -> google.protobuf.Timestamp
+	123, // 112: trip.ComplianceReport.generated_at:type_name -> google.protobuf.Timestamp
+	90,  // 113: trip.ListComplianceReportsResponse.reports:type_name -> trip.ComplianceReport
+	123, // 114: trip.TripMessage.created_at:type_name -> google.protobuf.Timestamp
+	123, // 115: trip.TripMessage.delivered_at:type_name -> google.protobuf.Timestamp
+	95,  // 116: trip.ListTripMessagesResponse.messages:type_name -> trip.TripMessage
+	100, // 117: trip.ListQuickRepliesResponse.quick_replies:type_name -> trip.QuickReply
+	1,   // 118: trip.DriverEvent.type:type_name -> trip.DriverEventType
+	2,   // 119: trip.DriverEvent.rider_location:type_name -> trip.Location
+	123, // 120: trip.DriverEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	2,   // 121: trip.UpdateRiderLocationRequest.location:type_name -> trip.Location
+	123, // 122: trip.DeadLetter.first_failed_at:type_name -> google.protobuf.Timestamp
+	123, // 123: trip.DeadLetter.last_failed_at:type_name -> google.protobuf.Timestamp
+	107, // 124: trip.ListDeadLettersResponse.dead_letters:type_name -> trip.DeadLetter
+	123, // 125: trip.TripListingsLag.projected_at:type_name -> google.protobuf.Timestamp
+	123, // 126: trip.TripListingsLag.rebuilt_at:type_name -> google.protobuf.Timestamp
+	39,  // 127: trip.GetDriverRatingsResponse.RatingsEntry.value:type_name -> trip.RatingSummary
+	47,  // 128: trip.GetDriverReliabilityResponse.DriversEntry.value:type_name -> trip.DriverReliability
+	5,   // 129: trip.TripService.CreateTrip:input_type -> trip.CreateTripRequest
+	7,   // 130: trip.TripService.GetTrip:input_type -> trip.GetTripRequest
+	9,   // 131: trip.TripService.UpdateTripStatus:input_type -> trip.UpdateTripStatusRequest
+	12,  // 132: trip.TripService.GetUserTrips:input_type -> trip.GetUserTripsRequest
+	14,  // 133: trip.TripService.GetActiveTrips:input_type -> trip.GetActiveTripsRequest
+	16,  // 134: trip.TripService.ForceCancelTrip:input_type -> trip.ForceCancelTripRequest
+	18,  // 135: trip.TripService.AnonymizeUserTrips:input_type -> trip.AnonymizeUserTripsRequest
+	25,  // 136: trip.TripService.OpenSharedTrip:input_type -> trip.OpenSharedTripRequest
+	26,  // 137: trip.TripService.AddSharedRider:input_type -> trip.AddSharedRiderRequest
+	27,  // 138: trip.TripService.CompleteTripStop:input_type -> trip.CompleteTripStopRequest
+	28,  // 139: trip.TripService.GetSharedTrip:input_type -> trip.GetSharedTripRequest
+	30,  // 140: trip.TripService.ListOpenSharedTrips:input_type -> trip.ListOpenSharedTripsRequest
+	33,  // 141: trip.TripService.CreateScheduledRide:input_type -> trip.CreateScheduledRideRequest
+	35,  // 142: trip.TripService.ListScheduledRides:input_type -> trip.ListScheduledRidesRequest
+	37,  // 143: trip.TripService.CancelScheduledRide:input_type -> trip.CancelScheduledRideRequest
+	40,  // 144: trip.TripService.SubmitRating:input_type -> trip.SubmitRatingRequest
+	42,  // 145: trip.TripService.GetRatingSummary:input_type -> trip.GetRatingSummaryRequest
+	43,  // 146: trip.TripService.ListReviews:input_type -> trip.ListReviewsRequest
+	45,  // 147: trip.TripService.GetDriverRatings:input_type -> trip.GetDriverRatingsRequest
+	48,  // 148: trip.TripService.GetDriverReliability:input_type -> trip.GetDriverReliabilityRequest
+	50,  // 149: trip.TripService.CreateTripShareLink:input_type -> trip.CreateTripShareLinkRequest
+	52,  // 150: trip.TripService.GetTripByShareToken:input_type -> trip.GetTripByShareTokenRequest
+	52,  // 151: trip.TripService.WatchTripByShareToken:input_type -> trip.GetTripByShareTokenRequest
+	56,  // 152: trip.TripService.ReportSOS:input_type -> trip.ReportSOSRequest
+	57,  // 153: trip.TripService.GetIncident:input_type -> trip.GetIncidentRequest
+	58,  // 154: trip.TripService.ListIncidents:input_type -> trip.ListIncidentsRequest
+	60,  // 155: trip.TripService.AcknowledgeIncident:input_type -> trip.AcknowledgeIncidentRequest
+	61,  // 156: trip.TripService.AddIncidentNote:input_type -> trip.AddIncidentNoteRequest
+	62,  // 157: trip.TripService.ResolveIncident:input_type -> trip.ResolveIncidentRequest
+	68,  // 158: trip.TripService.OpenDispute:input_type -> trip.OpenDisputeRequest
+	69,  // 159: trip.TripService.GetDisputeReview:input_type -> trip.GetDisputeRequest
+	70,  // 160: trip.TripService.ListDisputes:input_type -> trip.ListDisputesRequest
+	72,  // 161: trip.TripService.StartDisputeReview:input_type -> trip.StartDisputeReviewRequest
+	73,  // 162: trip.TripService.AddDisputeNote:input_type -> trip.AddDisputeNoteRequest
+	74,  // 163: trip.TripService.ResolveDispute:input_type -> trip.ResolveDisputeRequest
+	75,  // 164: trip.TripService.RejectDispute:input_type -> trip.RejectDisputeRequest
+	78,  // 165: trip.TripService.ReportLostItem:input_type -> trip.ReportLostItemRequest
+	79,  // 166: trip.TripService.GetLostItem:input_type -> trip.GetLostItemRequest
+	80,  // 167: trip.TripService.ListLostItems:input_type -> trip.ListLostItemsRequest
+	82,  // 168: trip.TripService.UpdateLostItem:input_type -> trip.UpdateLostItemRequest
+	83,  // 169: trip.TripService.GetTripContact:input_type -> trip.GetTripContactRequest
+	85,  // 170: trip.TripService.GetPickupGuarantee:input_type -> trip.GetPickupGuaranteeRequest
+	87,  // 171: trip.TripService.GetPickupSLAReport:input_type -> trip.GetPickupSLAReportRequest
+	91,  // 172: trip.TripService.ListComplianceReports:input_type -> trip.ListComplianceReportsRequest
+	93,  // 173: trip.TripService.GetComplianceReport:input_type -> trip.GetComplianceReportRequest
+	94,  // 174: trip.TripService.GenerateComplianceReport:input_type -> trip.GenerateComplianceReportRequest
+	108, // 175: trip.TripService.ListDeadLetters:input_type -> trip.ListDeadLettersRequest
+	110, // 176: trip.TripService.GetDeadLetter:input_type -> trip.GetDeadLetterRequest
+	111, // 177: trip.TripService.ReplayDeadLetter:input_type -> trip.ReplayDeadLetterRequest
+	113, // 178: trip.TripService.DiscardDeadLetter:input_type -> trip.DiscardDeadLetterRequest
+	116, // 179: trip.TripService.GetTripListingsLag:input_type -> trip.GetTripListingsLagRequest
+	117, // 180: trip.TripService.RebuildTripListings:input_type -> trip.RebuildTripListingsRequest
+	96,  // 181: trip.TripService.SendTripMessage:input_type -> trip.SendTripMessageRequest
+	97,  // 182: trip.TripService.ListTripMessages:input_type -> trip.ListTripMessagesRequest
+	99,  // 183: trip.TripService.StreamTripMessages:input_type -> trip.StreamTripMessagesRequest
+	101, // 184: trip.TripService.ListQuickReplies:input_type -> trip.ListQuickRepliesRequest
+	104, // 185: trip.TripService.SubscribeDriverEvents:input_type -> trip.SubscribeDriverEventsRequest
+	105, // 186: trip.TripService.UpdateRiderLocation:input_type -> trip.UpdateRiderLocationRequest
+	21,  // 187: trip.TripService.SubscribeToTripUpdates:input_type -> trip.SubscribeToTripUpdatesRequest
+	6,   // 188: trip.TripService.CreateTrip:output_type -> trip.CreateTripResponse
+	8,   // 189: trip.TripService.GetTrip:output_type -> trip.GetTripResponse
+	11,  // 190: trip.TripService.UpdateTripStatus:output_type -> trip.UpdateTripStatusResponse
+	13,  // 191: trip.TripService.GetUserTrips:output_type -> trip.GetUserTripsResponse
+	15,  // 192: trip.TripService.GetActiveTrips:output_type -> trip.GetActiveTripsResponse
+	17,  // 193: trip.TripService.ForceCancelTrip:output_type -> trip.ForceCancelTripResponse
+	19,  // 194: trip.TripService.AnonymizeUserTrips:output_type -> trip.AnonymizeUserTripsResponse
+	29,  // 195: trip.TripService.OpenSharedTrip:output_type -> trip.SharedTripResponse
+	29,  // 196: trip.TripService.AddSharedRider:output_type -> trip.SharedTripResponse
+	29,  // 197: trip.TripService.CompleteTripStop:output_type -> trip.SharedTripResponse
+	29,  // 198: trip.TripService.GetSharedTrip:output_type -> trip.SharedTripResponse
+	31,  // 199: trip.TripService.ListOpenSharedTrips:output_type -> trip.ListOpenSharedTripsResponse
+	34,  // 200: trip.TripService.CreateScheduledRide:output_type -> trip.ScheduledRideResponse
+	36,  // 201: trip.TripService.ListScheduledRides:output_type -> trip.ListScheduledRidesResponse
+	34,  // 202: trip.TripService.CancelScheduledRide:output_type -> trip.ScheduledRideResponse
+	41,  // 203: trip.TripService.SubmitRating:output_type -> trip.SubmitRatingResponse
+	39,  // 204: trip.TripService.GetRatingSummary:output_type -> trip.RatingSummary
+	44,  // 205: trip.TripService.ListReviews:output_type -> trip.ListReviewsResponse
+	46,  // 206: trip.TripService.GetDriverRatings:output_type -> trip.GetDriverRatingsResponse
+	49,  // 207: trip.TripService.GetDriverReliability:output_type -> trip.GetDriverReliabilityResponse
+	51,  // 208: trip.TripService.CreateTripShareLink:output_type -> trip.CreateTripShareLinkResponse
+	53,  // 209: trip.TripService.GetTripByShareToken:output_type -> trip.SharedTripStatus
+	53,  // 210: trip.TripService.WatchTripByShareToken:output_type -> trip.SharedTripStatus
+	55,  // 211: trip.TripService.ReportSOS:output_type -> trip.Incident
+	55,  // 212: trip.TripService.GetIncident:output_type -> trip.Incident
+	59,  // 213: trip.TripService.ListIncidents:output_type -> trip.ListIncidentsResponse
+	55,  // 214: trip.TripService.AcknowledgeIncident:output_type -> trip.Incident
+	55,  // 215: trip.TripService.AddIncidentNote:output_type -> trip.Incident
+	55,  // 216: trip.TripService.ResolveIncident:output_type -> trip.Incident
+	66,  // 217: trip.TripService.OpenDispute:output_type -> trip.Dispute
+	67,  // 218: trip.TripService.GetDisputeReview:output_type -> trip.DisputeReview
+	71,  // 219: trip.TripService.ListDisputes:output_type -> trip.ListDisputesResponse
+	66,  // 220: trip.TripService.StartDisputeReview:output_type -> trip.Dispute
+	66,  // 221: trip.TripService.AddDisputeNote:output_type -> trip.Dispute
+	66,  // 222: trip.TripService.ResolveDispute:output_type -> trip.Dispute
+	66,  // 223: trip.TripService.RejectDispute:output_type -> trip.Dispute
+	77,  // 224: trip.TripService.ReportLostItem:output_type -> trip.LostItemReport
+	77,  // 225: trip.TripService.GetLostItem:output_type -> trip.LostItemReport
+	81,  // 226: trip.TripService.ListLostItems:output_type -> trip.ListLostItemsResponse
+	77,  // 227: trip.TripService.UpdateLostItem:output_type -> trip.LostItemReport
+	84,  // 228: trip.TripService.GetTripContact:output_type -> trip.TripContact
+	86,  // 229: trip.TripService.GetPickupGuarantee:output_type -> trip.PickupGuarantee
+	89,  // 230: trip.TripService.GetPickupSLAReport:output_type -> trip.PickupSLAReport
+	92,  // 231: trip.TripService.ListComplianceReports:output_type -> trip.ListComplianceReportsResponse
+	90,  // 232: trip.TripService.GetComplianceReport:output_type -> trip.ComplianceReport
+	90,  // 233: trip.TripService.GenerateComplianceReport:output_type -> trip.ComplianceReport
+	109, // 234: trip.TripService.ListDeadLetters:output_type -> trip.ListDeadLettersResponse
+	107, // 235: trip.TripService.GetDeadLetter:output_type -> trip.DeadLetter
+	112, // 236: trip.TripService.ReplayDeadLetter:output_type -> trip.ReplayDeadLetterResponse
+	114, // 237: trip.TripService.DiscardDeadLetter:output_type -> trip.DiscardDeadLetterResponse
+	115, // 238: trip.TripService.GetTripListingsLag:output_type -> trip.TripListingsLag
+	118, // 239: trip.TripService.RebuildTripListings:output_type -> trip.RebuildTripListingsResponse
+	95,  // 240: trip.TripService.SendTripMessage:output_type -> trip.TripMessage
+	98,  // 241: trip.TripService.ListTripMessages:output_type -> trip.ListTripMessagesResponse
+	95,  // 242: trip.TripService.StreamTripMessages:output_type -> trip.TripMessage
+	102, // 243: trip.TripService.ListQuickReplies:output_type -> trip.ListQuickRepliesResponse
+	103, // 244: trip.TripService.SubscribeDriverEvents:output_type -> trip.DriverEvent
+	106, // 245: trip.TripService.UpdateRiderLocation:output_type -> trip.UpdateRiderLocationResponse
+	20,  // 246: trip.TripService.SubscribeToTripUpdates:output_type -> trip.TripUpdateEvent
+	188, // [188:247] is the sub-list for method output_type
+	129, // [129:188] is the sub-list for method input_type
+	129, // [129:129] is the sub-list for extension type_name
+	129, // [129:129] is the sub-list for extension extendee
+	0,   // [0:129] is the sub-list for field type_name
 }
 
 func init() { file_shared_proto_trip_trip_proto_init() }
@@ -1373,8 +9466,8 @@ func file_shared_proto_trip_trip_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_shared_proto_trip_trip_proto_rawDesc), len(file_shared_proto_trip_trip_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   16,
+			NumEnums:      2,
+			NumMessages:   121,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,12 +19,65 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	TripService_CreateTrip_FullMethodName             = "/trip.TripService/CreateTrip"
-	TripService_GetTrip_FullMethodName                = "/trip.TripService/GetTrip"
-	TripService_UpdateTripStatus_FullMethodName       = "/trip.TripService/UpdateTripStatus"
-	TripService_GetUserTrips_FullMethodName           = "/trip.TripService/GetUserTrips"
-	TripService_GetActiveTrips_FullMethodName         = "/trip.TripService/GetActiveTrips"
-	TripService_SubscribeToTripUpdates_FullMethodName = "/trip.TripService/SubscribeToTripUpdates"
+	TripService_CreateTrip_FullMethodName               = "/trip.TripService/CreateTrip"
+	TripService_GetTrip_FullMethodName                  = "/trip.TripService/GetTrip"
+	TripService_UpdateTripStatus_FullMethodName         = "/trip.TripService/UpdateTripStatus"
+	TripService_GetUserTrips_FullMethodName             = "/trip.TripService/GetUserTrips"
+	TripService_GetActiveTrips_FullMethodName           = "/trip.TripService/GetActiveTrips"
+	TripService_ForceCancelTrip_FullMethodName          = "/trip.TripService/ForceCancelTrip"
+	TripService_AnonymizeUserTrips_FullMethodName       = "/trip.TripService/AnonymizeUserTrips"
+	TripService_OpenSharedTrip_FullMethodName           = "/trip.TripService/OpenSharedTrip"
+	TripService_AddSharedRider_FullMethodName           = "/trip.TripService/AddSharedRider"
+	TripService_CompleteTripStop_FullMethodName         = "/trip.TripService/CompleteTripStop"
+	TripService_GetSharedTrip_FullMethodName            = "/trip.TripService/GetSharedTrip"
+	TripService_ListOpenSharedTrips_FullMethodName      = "/trip.TripService/ListOpenSharedTrips"
+	TripService_CreateScheduledRide_FullMethodName      = "/trip.TripService/CreateScheduledRide"
+	TripService_ListScheduledRides_FullMethodName       = "/trip.TripService/ListScheduledRides"
+	TripService_CancelScheduledRide_FullMethodName      = "/trip.TripService/CancelScheduledRide"
+	TripService_SubmitRating_FullMethodName             = "/trip.TripService/SubmitRating"
+	TripService_GetRatingSummary_FullMethodName         = "/trip.TripService/GetRatingSummary"
+	TripService_ListReviews_FullMethodName              = "/trip.TripService/ListReviews"
+	TripService_GetDriverRatings_FullMethodName         = "/trip.TripService/GetDriverRatings"
+	TripService_GetDriverReliability_FullMethodName     = "/trip.TripService/GetDriverReliability"
+	TripService_CreateTripShareLink_FullMethodName      = "/trip.TripService/CreateTripShareLink"
+	TripService_GetTripByShareToken_FullMethodName      = "/trip.TripService/GetTripByShareToken"
+	TripService_WatchTripByShareToken_FullMethodName    = "/trip.TripService/WatchTripByShareToken"
+	TripService_ReportSOS_FullMethodName                = "/trip.TripService/ReportSOS"
+	TripService_GetIncident_FullMethodName              = "/trip.TripService/GetIncident"
+	TripService_ListIncidents_FullMethodName            = "/trip.TripService/ListIncidents"
+	TripService_AcknowledgeIncident_FullMethodName      = "/trip.TripService/AcknowledgeIncident"
+	TripService_AddIncidentNote_FullMethodName          = "/trip.TripService/AddIncidentNote"
+	TripService_ResolveIncident_FullMethodName          = "/trip.TripService/ResolveIncident"
+	TripService_OpenDispute_FullMethodName              = "/trip.TripService/OpenDispute"
+	TripService_GetDisputeReview_FullMethodName         = "/trip.TripService/GetDisputeReview"
+	TripService_ListDisputes_FullMethodName             = "/trip.TripService/ListDisputes"
+	TripService_StartDisputeReview_FullMethodName       = "/trip.TripService/StartDisputeReview"
+	TripService_AddDisputeNote_FullMethodName           = "/trip.TripService/AddDisputeNote"
+	TripService_ResolveDispute_FullMethodName           = "/trip.TripService/ResolveDispute"
+	TripService_RejectDispute_FullMethodName            = "/trip.TripService/RejectDispute"
+	TripService_ReportLostItem_FullMethodName           = "/trip.TripService/ReportLostItem"
+	TripService_GetLostItem_FullMethodName              = "/trip.TripService/GetLostItem"
+	TripService_ListLostItems_FullMethodName            = "/trip.TripService/ListLostItems"
+	TripService_UpdateLostItem_FullMethodName           = "/trip.TripService/UpdateLostItem"
+	TripService_GetTripContact_FullMethodName           = "/trip.TripService/GetTripContact"
+	TripService_GetPickupGuarantee_FullMethodName       = "/trip.TripService/GetPickupGuarantee"
+	TripService_GetPickupSLAReport_FullMethodName       = "/trip.TripService/GetPickupSLAReport"
+	TripService_ListComplianceReports_FullMethodName    = "/trip.TripService/ListComplianceReports"
+	TripService_GetComplianceReport_FullMethodName      = "/trip.TripService/GetComplianceReport"
+	TripService_GenerateComplianceReport_FullMethodName = "/trip.TripService/GenerateComplianceReport"
+	TripService_ListDeadLetters_FullMethodName          = "/trip.TripService/ListDeadLetters"
+	TripService_GetDeadLetter_FullMethodName            = "/trip.TripService/GetDeadLetter"
+	TripService_ReplayDeadLetter_FullMethodName         = "/trip.TripService/ReplayDeadLetter"
+	TripService_DiscardDeadLetter_FullMethodName        = "/trip.TripService/DiscardDeadLetter"
+	TripService_GetTripListingsLag_FullMethodName       = "/trip.TripService/GetTripListingsLag"
+	TripService_RebuildTripListings_FullMethodName      = "/trip.TripService/RebuildTripListings"
+	TripService_SendTripMessage_FullMethodName          = "/trip.TripService/SendTripMessage"
+	TripService_ListTripMessages_FullMethodName         = "/trip.TripService/ListTripMessages"
+	TripService_StreamTripMessages_FullMethodName       = "/trip.TripService/StreamTripMessages"
+	TripService_ListQuickReplies_FullMethodName         = "/trip.TripService/ListQuickReplies"
+	TripService_SubscribeDriverEvents_FullMethodName    = "/trip.TripService/SubscribeDriverEvents"
+	TripService_UpdateRiderLocation_FullMethodName      = "/trip.TripService/UpdateRiderLocation"
+	TripService_SubscribeToTripUpdates_FullMethodName   = "/trip.TripService/SubscribeToTripUpdates"
 )
 
 // TripServiceClient is the client API for TripService service.
@@ -38,6 +91,73 @@ type TripServiceClient interface {
 	UpdateTripStatus(ctx context.Context, in *UpdateTripStatusRequest, opts ...grpc.CallOption) (*UpdateTripStatusResponse, error)
 	GetUserTrips(ctx context.Context, in *GetUserTripsRequest, opts ...grpc.CallOption) (*GetUserTripsResponse, error)
 	GetActiveTrips(ctx context.Context, in *GetActiveTripsRequest, opts ...grpc.CallOption) (*GetActiveTripsResponse, error)
+	ForceCancelTrip(ctx context.Context, in *ForceCancelTripRequest, opts ...grpc.CallOption) (*ForceCancelTripResponse, error)
+	AnonymizeUserTrips(ctx context.Context, in *AnonymizeUserTripsRequest, opts ...grpc.CallOption) (*AnonymizeUserTripsResponse, error)
+	// Shared rides
+	OpenSharedTrip(ctx context.Context, in *OpenSharedTripRequest, opts ...grpc.CallOption) (*SharedTripResponse, error)
+	AddSharedRider(ctx context.Context, in *AddSharedRiderRequest, opts ...grpc.CallOption) (*SharedTripResponse, error)
+	CompleteTripStop(ctx context.Context, in *CompleteTripStopRequest, opts ...grpc.CallOption) (*SharedTripResponse, error)
+	GetSharedTrip(ctx context.Context, in *GetSharedTripRequest, opts ...grpc.CallOption) (*SharedTripResponse, error)
+	ListOpenSharedTrips(ctx context.Context, in *ListOpenSharedTripsRequest, opts ...grpc.CallOption) (*ListOpenSharedTripsResponse, error)
+	// Scheduled rides
+	CreateScheduledRide(ctx context.Context, in *CreateScheduledRideRequest, opts ...grpc.CallOption) (*ScheduledRideResponse, error)
+	ListScheduledRides(ctx context.Context, in *ListScheduledRidesRequest, opts ...grpc.CallOption) (*ListScheduledRidesResponse, error)
+	CancelScheduledRide(ctx context.Context, in *CancelScheduledRideRequest, opts ...grpc.CallOption) (*ScheduledRideResponse, error)
+	// Ratings and reviews
+	SubmitRating(ctx context.Context, in *SubmitRatingRequest, opts ...grpc.CallOption) (*SubmitRatingResponse, error)
+	GetRatingSummary(ctx context.Context, in *GetRatingSummaryRequest, opts ...grpc.CallOption) (*RatingSummary, error)
+	ListReviews(ctx context.Context, in *ListReviewsRequest, opts ...grpc.CallOption) (*ListReviewsResponse, error)
+	GetDriverRatings(ctx context.Context, in *GetDriverRatingsRequest, opts ...grpc.CallOption) (*GetDriverRatingsResponse, error)
+	GetDriverReliability(ctx context.Context, in *GetDriverReliabilityRequest, opts ...grpc.CallOption) (*GetDriverReliabilityResponse, error)
+	// Trip share links
+	CreateTripShareLink(ctx context.Context, in *CreateTripShareLinkRequest, opts ...grpc.CallOption) (*CreateTripShareLinkResponse, error)
+	GetTripByShareToken(ctx context.Context, in *GetTripByShareTokenRequest, opts ...grpc.CallOption) (*SharedTripStatus, error)
+	WatchTripByShareToken(ctx context.Context, in *GetTripByShareTokenRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SharedTripStatus], error)
+	// Emergency incidents
+	ReportSOS(ctx context.Context, in *ReportSOSRequest, opts ...grpc.CallOption) (*Incident, error)
+	GetIncident(ctx context.Context, in *GetIncidentRequest, opts ...grpc.CallOption) (*Incident, error)
+	ListIncidents(ctx context.Context, in *ListIncidentsRequest, opts ...grpc.CallOption) (*ListIncidentsResponse, error)
+	AcknowledgeIncident(ctx context.Context, in *AcknowledgeIncidentRequest, opts ...grpc.CallOption) (*Incident, error)
+	AddIncidentNote(ctx context.Context, in *AddIncidentNoteRequest, opts ...grpc.CallOption) (*Incident, error)
+	ResolveIncident(ctx context.Context, in *ResolveIncidentRequest, opts ...grpc.CallOption) (*Incident, error)
+	// Fare disputes
+	OpenDispute(ctx context.Context, in *OpenDisputeRequest, opts ...grpc.CallOption) (*Dispute, error)
+	GetDisputeReview(ctx context.Context, in *GetDisputeRequest, opts ...grpc.CallOption) (*DisputeReview, error)
+	ListDisputes(ctx context.Context, in *ListDisputesRequest, opts ...grpc.CallOption) (*ListDisputesResponse, error)
+	StartDisputeReview(ctx context.Context, in *StartDisputeReviewRequest, opts ...grpc.CallOption) (*Dispute, error)
+	AddDisputeNote(ctx context.Context, in *AddDisputeNoteRequest, opts ...grpc.CallOption) (*Dispute, error)
+	ResolveDispute(ctx context.Context, in *ResolveDisputeRequest, opts ...grpc.CallOption) (*Dispute, error)
+	RejectDispute(ctx context.Context, in *RejectDisputeRequest, opts ...grpc.CallOption) (*Dispute, error)
+	// Lost and found
+	ReportLostItem(ctx context.Context, in *ReportLostItemRequest, opts ...grpc.CallOption) (*LostItemReport, error)
+	GetLostItem(ctx context.Context, in *GetLostItemRequest, opts ...grpc.CallOption) (*LostItemReport, error)
+	ListLostItems(ctx context.Context, in *ListLostItemsRequest, opts ...grpc.CallOption) (*ListLostItemsResponse, error)
+	UpdateLostItem(ctx context.Context, in *UpdateLostItemRequest, opts ...grpc.CallOption) (*LostItemReport, error)
+	// Masked calling
+	GetTripContact(ctx context.Context, in *GetTripContactRequest, opts ...grpc.CallOption) (*TripContact, error)
+	// On-time pickup guarantees
+	GetPickupGuarantee(ctx context.Context, in *GetPickupGuaranteeRequest, opts ...grpc.CallOption) (*PickupGuarantee, error)
+	GetPickupSLAReport(ctx context.Context, in *GetPickupSLAReportRequest, opts ...grpc.CallOption) (*PickupSLAReport, error)
+	// Regulatory compliance reports
+	ListComplianceReports(ctx context.Context, in *ListComplianceReportsRequest, opts ...grpc.CallOption) (*ListComplianceReportsResponse, error)
+	GetComplianceReport(ctx context.Context, in *GetComplianceReportRequest, opts ...grpc.CallOption) (*ComplianceReport, error)
+	GenerateComplianceReport(ctx context.Context, in *GenerateComplianceReportRequest, opts ...grpc.CallOption) (*ComplianceReport, error)
+	// Dead-lettered events
+	ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...grpc.CallOption) (*ListDeadLettersResponse, error)
+	GetDeadLetter(ctx context.Context, in *GetDeadLetterRequest, opts ...grpc.CallOption) (*DeadLetter, error)
+	ReplayDeadLetter(ctx context.Context, in *ReplayDeadLetterRequest, opts ...grpc.CallOption) (*ReplayDeadLetterResponse, error)
+	DiscardDeadLetter(ctx context.Context, in *DiscardDeadLetterRequest, opts ...grpc.CallOption) (*DiscardDeadLetterResponse, error)
+	// Trip listing read model
+	GetTripListingsLag(ctx context.Context, in *GetTripListingsLagRequest, opts ...grpc.CallOption) (*TripListingsLag, error)
+	RebuildTripListings(ctx context.Context, in *RebuildTripListingsRequest, opts ...grpc.CallOption) (*RebuildTripListingsResponse, error)
+	// In-trip messaging
+	SendTripMessage(ctx context.Context, in *SendTripMessageRequest, opts ...grpc.CallOption) (*TripMessage, error)
+	ListTripMessages(ctx context.Context, in *ListTripMessagesRequest, opts ...grpc.CallOption) (*ListTripMessagesResponse, error)
+	StreamTripMessages(ctx context.Context, in *StreamTripMessagesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TripMessage], error)
+	ListQuickReplies(ctx context.Context, in *ListQuickRepliesRequest, opts ...grpc.CallOption) (*ListQuickRepliesResponse, error)
+	// Driver events
+	SubscribeDriverEvents(ctx context.Context, in *SubscribeDriverEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DriverEvent], error)
+	UpdateRiderLocation(ctx context.Context, in *UpdateRiderLocationRequest, opts ...grpc.CallOption) (*UpdateRiderLocationResponse, error)
 	// Real-time features
 	SubscribeToTripUpdates(ctx context.Context, in *SubscribeToTripUpdatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TripUpdateEvent], error)
 }
@@ -100,173 +220,1889 @@ func (c *tripServiceClient) GetActiveTrips(ctx context.Context, in *GetActiveTri
 	return out, nil
 }
 
-func (c *tripServiceClient) SubscribeToTripUpdates(ctx context.Context, in *SubscribeToTripUpdatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TripUpdateEvent], error) {
+func (c *tripServiceClient) ForceCancelTrip(ctx context.Context, in *ForceCancelTripRequest, opts ...grpc.CallOption) (*ForceCancelTripResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &TripService_ServiceDesc.Streams[0], TripService_SubscribeToTripUpdates_FullMethodName, cOpts...)
+	out := new(ForceCancelTripResponse)
+	err := c.cc.Invoke(ctx, TripService_ForceCancelTrip_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &grpc.GenericClientStream[SubscribeToTripUpdatesRequest, TripUpdateEvent]{ClientStream: stream}
+	return out, nil
+}
+
+func (c *tripServiceClient) AnonymizeUserTrips(ctx context.Context, in *AnonymizeUserTripsRequest, opts ...grpc.CallOption) (*AnonymizeUserTripsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AnonymizeUserTripsResponse)
+	err := c.cc.Invoke(ctx, TripService_AnonymizeUserTrips_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) OpenSharedTrip(ctx context.Context, in *OpenSharedTripRequest, opts ...grpc.CallOption) (*SharedTripResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SharedTripResponse)
+	err := c.cc.Invoke(ctx, TripService_OpenSharedTrip_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) AddSharedRider(ctx context.Context, in *AddSharedRiderRequest, opts ...grpc.CallOption) (*SharedTripResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SharedTripResponse)
+	err := c.cc.Invoke(ctx, TripService_AddSharedRider_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) CompleteTripStop(ctx context.Context, in *CompleteTripStopRequest, opts ...grpc.CallOption) (*SharedTripResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SharedTripResponse)
+	err := c.cc.Invoke(ctx, TripService_CompleteTripStop_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) GetSharedTrip(ctx context.Context, in *GetSharedTripRequest, opts ...grpc.CallOption) (*SharedTripResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SharedTripResponse)
+	err := c.cc.Invoke(ctx, TripService_GetSharedTrip_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) ListOpenSharedTrips(ctx context.Context, in *ListOpenSharedTripsRequest, opts ...grpc.CallOption) (*ListOpenSharedTripsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOpenSharedTripsResponse)
+	err := c.cc.Invoke(ctx, TripService_ListOpenSharedTrips_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) CreateScheduledRide(ctx context.Context, in *CreateScheduledRideRequest, opts ...grpc.CallOption) (*ScheduledRideResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduledRideResponse)
+	err := c.cc.Invoke(ctx, TripService_CreateScheduledRide_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) ListScheduledRides(ctx context.Context, in *ListScheduledRidesRequest, opts ...grpc.CallOption) (*ListScheduledRidesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListScheduledRidesResponse)
+	err := c.cc.Invoke(ctx, TripService_ListScheduledRides_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) CancelScheduledRide(ctx context.Context, in *CancelScheduledRideRequest, opts ...grpc.CallOption) (*ScheduledRideResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScheduledRideResponse)
+	err := c.cc.Invoke(ctx, TripService_CancelScheduledRide_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) SubmitRating(ctx context.Context, in *SubmitRatingRequest, opts ...grpc.CallOption) (*SubmitRatingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitRatingResponse)
+	err := c.cc.Invoke(ctx, TripService_SubmitRating_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) GetRatingSummary(ctx context.Context, in *GetRatingSummaryRequest, opts ...grpc.CallOption) (*RatingSummary, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RatingSummary)
+	err := c.cc.Invoke(ctx, TripService_GetRatingSummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) ListReviews(ctx context.Context, in *ListReviewsRequest, opts ...grpc.CallOption) (*ListReviewsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListReviewsResponse)
+	err := c.cc.Invoke(ctx, TripService_ListReviews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) GetDriverRatings(ctx context.Context, in *GetDriverRatingsRequest, opts ...grpc.CallOption) (*GetDriverRatingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDriverRatingsResponse)
+	err := c.cc.Invoke(ctx, TripService_GetDriverRatings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) GetDriverReliability(ctx context.Context, in *GetDriverReliabilityRequest, opts ...grpc.CallOption) (*GetDriverReliabilityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDriverReliabilityResponse)
+	err := c.cc.Invoke(ctx, TripService_GetDriverReliability_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) CreateTripShareLink(ctx context.Context, in *CreateTripShareLinkRequest, opts ...grpc.CallOption) (*CreateTripShareLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateTripShareLinkResponse)
+	err := c.cc.Invoke(ctx, TripService_CreateTripShareLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) GetTripByShareToken(ctx context.Context, in *GetTripByShareTokenRequest, opts ...grpc.CallOption) (*SharedTripStatus, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SharedTripStatus)
+	err := c.cc.Invoke(ctx, TripService_GetTripByShareToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) WatchTripByShareToken(ctx context.Context, in *GetTripByShareTokenRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SharedTripStatus], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TripService_ServiceDesc.Streams[0], TripService_WatchTripByShareToken_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[GetTripByShareTokenRequest, SharedTripStatus]{ClientStream: stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
 	if err := x.ClientStream.CloseSend(); err != nil {
 		return nil, err
 	}
-	return x, nil
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TripService_WatchTripByShareTokenClient = grpc.ServerStreamingClient[SharedTripStatus]
+
+func (c *tripServiceClient) ReportSOS(ctx context.Context, in *ReportSOSRequest, opts ...grpc.CallOption) (*Incident, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Incident)
+	err := c.cc.Invoke(ctx, TripService_ReportSOS_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) GetIncident(ctx context.Context, in *GetIncidentRequest, opts ...grpc.CallOption) (*Incident, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Incident)
+	err := c.cc.Invoke(ctx, TripService_GetIncident_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) ListIncidents(ctx context.Context, in *ListIncidentsRequest, opts ...grpc.CallOption) (*ListIncidentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListIncidentsResponse)
+	err := c.cc.Invoke(ctx, TripService_ListIncidents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) AcknowledgeIncident(ctx context.Context, in *AcknowledgeIncidentRequest, opts ...grpc.CallOption) (*Incident, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Incident)
+	err := c.cc.Invoke(ctx, TripService_AcknowledgeIncident_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) AddIncidentNote(ctx context.Context, in *AddIncidentNoteRequest, opts ...grpc.CallOption) (*Incident, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Incident)
+	err := c.cc.Invoke(ctx, TripService_AddIncidentNote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) ResolveIncident(ctx context.Context, in *ResolveIncidentRequest, opts ...grpc.CallOption) (*Incident, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Incident)
+	err := c.cc.Invoke(ctx, TripService_ResolveIncident_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) OpenDispute(ctx context.Context, in *OpenDisputeRequest, opts ...grpc.CallOption) (*Dispute, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Dispute)
+	err := c.cc.Invoke(ctx, TripService_OpenDispute_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) GetDisputeReview(ctx context.Context, in *GetDisputeRequest, opts ...grpc.CallOption) (*DisputeReview, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DisputeReview)
+	err := c.cc.Invoke(ctx, TripService_GetDisputeReview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) ListDisputes(ctx context.Context, in *ListDisputesRequest, opts ...grpc.CallOption) (*ListDisputesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDisputesResponse)
+	err := c.cc.Invoke(ctx, TripService_ListDisputes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) StartDisputeReview(ctx context.Context, in *StartDisputeReviewRequest, opts ...grpc.CallOption) (*Dispute, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Dispute)
+	err := c.cc.Invoke(ctx, TripService_StartDisputeReview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) AddDisputeNote(ctx context.Context, in *AddDisputeNoteRequest, opts ...grpc.CallOption) (*Dispute, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Dispute)
+	err := c.cc.Invoke(ctx, TripService_AddDisputeNote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) ResolveDispute(ctx context.Context, in *ResolveDisputeRequest, opts ...grpc.CallOption) (*Dispute, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Dispute)
+	err := c.cc.Invoke(ctx, TripService_ResolveDispute_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) RejectDispute(ctx context.Context, in *RejectDisputeRequest, opts ...grpc.CallOption) (*Dispute, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Dispute)
+	err := c.cc.Invoke(ctx, TripService_RejectDispute_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) ReportLostItem(ctx context.Context, in *ReportLostItemRequest, opts ...grpc.CallOption) (*LostItemReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LostItemReport)
+	err := c.cc.Invoke(ctx, TripService_ReportLostItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) GetLostItem(ctx context.Context, in *GetLostItemRequest, opts ...grpc.CallOption) (*LostItemReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LostItemReport)
+	err := c.cc.Invoke(ctx, TripService_GetLostItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) ListLostItems(ctx context.Context, in *ListLostItemsRequest, opts ...grpc.CallOption) (*ListLostItemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLostItemsResponse)
+	err := c.cc.Invoke(ctx, TripService_ListLostItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) UpdateLostItem(ctx context.Context, in *UpdateLostItemRequest, opts ...grpc.CallOption) (*LostItemReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LostItemReport)
+	err := c.cc.Invoke(ctx, TripService_UpdateLostItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) GetTripContact(ctx context.Context, in *GetTripContactRequest, opts ...grpc.CallOption) (*TripContact, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TripContact)
+	err := c.cc.Invoke(ctx, TripService_GetTripContact_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) GetPickupGuarantee(ctx context.Context, in *GetPickupGuaranteeRequest, opts ...grpc.CallOption) (*PickupGuarantee, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PickupGuarantee)
+	err := c.cc.Invoke(ctx, TripService_GetPickupGuarantee_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) GetPickupSLAReport(ctx context.Context, in *GetPickupSLAReportRequest, opts ...grpc.CallOption) (*PickupSLAReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PickupSLAReport)
+	err := c.cc.Invoke(ctx, TripService_GetPickupSLAReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) ListComplianceReports(ctx context.Context, in *ListComplianceReportsRequest, opts ...grpc.CallOption) (*ListComplianceReportsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListComplianceReportsResponse)
+	err := c.cc.Invoke(ctx, TripService_ListComplianceReports_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) GetComplianceReport(ctx context.Context, in *GetComplianceReportRequest, opts ...grpc.CallOption) (*ComplianceReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ComplianceReport)
+	err := c.cc.Invoke(ctx, TripService_GetComplianceReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) GenerateComplianceReport(ctx context.Context, in *GenerateComplianceReportRequest, opts ...grpc.CallOption) (*ComplianceReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ComplianceReport)
+	err := c.cc.Invoke(ctx, TripService_GenerateComplianceReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) ListDeadLetters(ctx context.Context, in *ListDeadLettersRequest, opts ...grpc.CallOption) (*ListDeadLettersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeadLettersResponse)
+	err := c.cc.Invoke(ctx, TripService_ListDeadLetters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) GetDeadLetter(ctx context.Context, in *GetDeadLetterRequest, opts ...grpc.CallOption) (*DeadLetter, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeadLetter)
+	err := c.cc.Invoke(ctx, TripService_GetDeadLetter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) ReplayDeadLetter(ctx context.Context, in *ReplayDeadLetterRequest, opts ...grpc.CallOption) (*ReplayDeadLetterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReplayDeadLetterResponse)
+	err := c.cc.Invoke(ctx, TripService_ReplayDeadLetter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) DiscardDeadLetter(ctx context.Context, in *DiscardDeadLetterRequest, opts ...grpc.CallOption) (*DiscardDeadLetterResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiscardDeadLetterResponse)
+	err := c.cc.Invoke(ctx, TripService_DiscardDeadLetter_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) GetTripListingsLag(ctx context.Context, in *GetTripListingsLagRequest, opts ...grpc.CallOption) (*TripListingsLag, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TripListingsLag)
+	err := c.cc.Invoke(ctx, TripService_GetTripListingsLag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) RebuildTripListings(ctx context.Context, in *RebuildTripListingsRequest, opts ...grpc.CallOption) (*RebuildTripListingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RebuildTripListingsResponse)
+	err := c.cc.Invoke(ctx, TripService_RebuildTripListings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) SendTripMessage(ctx context.Context, in *SendTripMessageRequest, opts ...grpc.CallOption) (*TripMessage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TripMessage)
+	err := c.cc.Invoke(ctx, TripService_SendTripMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) ListTripMessages(ctx context.Context, in *ListTripMessagesRequest, opts ...grpc.CallOption) (*ListTripMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTripMessagesResponse)
+	err := c.cc.Invoke(ctx, TripService_ListTripMessages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) StreamTripMessages(ctx context.Context, in *StreamTripMessagesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TripMessage], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TripService_ServiceDesc.Streams[1], TripService_StreamTripMessages_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamTripMessagesRequest, TripMessage]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TripService_StreamTripMessagesClient = grpc.ServerStreamingClient[TripMessage]
+
+func (c *tripServiceClient) ListQuickReplies(ctx context.Context, in *ListQuickRepliesRequest, opts ...grpc.CallOption) (*ListQuickRepliesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListQuickRepliesResponse)
+	err := c.cc.Invoke(ctx, TripService_ListQuickReplies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) SubscribeDriverEvents(ctx context.Context, in *SubscribeDriverEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DriverEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TripService_ServiceDesc.Streams[2], TripService_SubscribeDriverEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeDriverEventsRequest, DriverEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TripService_SubscribeDriverEventsClient = grpc.ServerStreamingClient[DriverEvent]
+
+func (c *tripServiceClient) UpdateRiderLocation(ctx context.Context, in *UpdateRiderLocationRequest, opts ...grpc.CallOption) (*UpdateRiderLocationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateRiderLocationResponse)
+	err := c.cc.Invoke(ctx, TripService_UpdateRiderLocation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tripServiceClient) SubscribeToTripUpdates(ctx context.Context, in *SubscribeToTripUpdatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TripUpdateEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TripService_ServiceDesc.Streams[3], TripService_SubscribeToTripUpdates_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeToTripUpdatesRequest, TripUpdateEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TripService_SubscribeToTripUpdatesClient = grpc.ServerStreamingClient[TripUpdateEvent]
+
+// TripServiceServer is the server API for TripService service.
+// All implementations must embed UnimplementedTripServiceServer
+// for forward compatibility.
+//
+// TripService defines the gRPC service for trip management
+type TripServiceServer interface {
+	CreateTrip(context.Context, *CreateTripRequest) (*CreateTripResponse, error)
+	GetTrip(context.Context, *GetTripRequest) (*GetTripResponse, error)
+	UpdateTripStatus(context.Context, *UpdateTripStatusRequest) (*UpdateTripStatusResponse, error)
+	GetUserTrips(context.Context, *GetUserTripsRequest) (*GetUserTripsResponse, error)
+	GetActiveTrips(context.Context, *GetActiveTripsRequest) (*GetActiveTripsResponse, error)
+	ForceCancelTrip(context.Context, *ForceCancelTripRequest) (*ForceCancelTripResponse, error)
+	AnonymizeUserTrips(context.Context, *AnonymizeUserTripsRequest) (*AnonymizeUserTripsResponse, error)
+	// Shared rides
+	OpenSharedTrip(context.Context, *OpenSharedTripRequest) (*SharedTripResponse, error)
+	AddSharedRider(context.Context, *AddSharedRiderRequest) (*SharedTripResponse, error)
+	CompleteTripStop(context.Context, *CompleteTripStopRequest) (*SharedTripResponse, error)
+	GetSharedTrip(context.Context, *GetSharedTripRequest) (*SharedTripResponse, error)
+	ListOpenSharedTrips(context.Context, *ListOpenSharedTripsRequest) (*ListOpenSharedTripsResponse, error)
+	// Scheduled rides
+	CreateScheduledRide(context.Context, *CreateScheduledRideRequest) (*ScheduledRideResponse, error)
+	ListScheduledRides(context.Context, *ListScheduledRidesRequest) (*ListScheduledRidesResponse, error)
+	CancelScheduledRide(context.Context, *CancelScheduledRideRequest) (*ScheduledRideResponse, error)
+	// Ratings and reviews
+	SubmitRating(context.Context, *SubmitRatingRequest) (*SubmitRatingResponse, error)
+	GetRatingSummary(context.Context, *GetRatingSummaryRequest) (*RatingSummary, error)
+	ListReviews(context.Context, *ListReviewsRequest) (*ListReviewsResponse, error)
+	GetDriverRatings(context.Context, *GetDriverRatingsRequest) (*GetDriverRatingsResponse, error)
+	GetDriverReliability(context.Context, *GetDriverReliabilityRequest) (*GetDriverReliabilityResponse, error)
+	// Trip share links
+	CreateTripShareLink(context.Context, *CreateTripShareLinkRequest) (*CreateTripShareLinkResponse, error)
+	GetTripByShareToken(context.Context, *GetTripByShareTokenRequest) (*SharedTripStatus, error)
+	WatchTripByShareToken(*GetTripByShareTokenRequest, grpc.ServerStreamingServer[SharedTripStatus]) error
+	// Emergency incidents
+	ReportSOS(context.Context, *ReportSOSRequest) (*Incident, error)
+	GetIncident(context.Context, *GetIncidentRequest) (*Incident, error)
+	ListIncidents(context.Context, *ListIncidentsRequest) (*ListIncidentsResponse, error)
+	AcknowledgeIncident(context.Context, *AcknowledgeIncidentRequest) (*Incident, error)
+	AddIncidentNote(context.Context, *AddIncidentNoteRequest) (*Incident, error)
+	ResolveIncident(context.Context, *ResolveIncidentRequest) (*Incident, error)
+	// Fare disputes
+	OpenDispute(context.Context, *OpenDisputeRequest) (*Dispute, error)
+	GetDisputeReview(context.Context, *GetDisputeRequest) (*DisputeReview, error)
+	ListDisputes(context.Context, *ListDisputesRequest) (*ListDisputesResponse, error)
+	StartDisputeReview(context.Context, *StartDisputeReviewRequest) (*Dispute, error)
+	AddDisputeNote(context.Context, *AddDisputeNoteRequest) (*Dispute, error)
+	ResolveDispute(context.Context, *ResolveDisputeRequest) (*Dispute, error)
+	RejectDispute(context.Context, *RejectDisputeRequest) (*Dispute, error)
+	// Lost and found
+	ReportLostItem(context.Context, *ReportLostItemRequest) (*LostItemReport, error)
+	GetLostItem(context.Context, *GetLostItemRequest) (*LostItemReport, error)
+	ListLostItems(context.Context, *ListLostItemsRequest) (*ListLostItemsResponse, error)
+	UpdateLostItem(context.Context, *UpdateLostItemRequest) (*LostItemReport, error)
+	// Masked calling
+	GetTripContact(context.Context, *GetTripContactRequest) (*TripContact, error)
+	// On-time pickup guarantees
+	GetPickupGuarantee(context.Context, *GetPickupGuaranteeRequest) (*PickupGuarantee, error)
+	GetPickupSLAReport(context.Context, *GetPickupSLAReportRequest) (*PickupSLAReport, error)
+	// Regulatory compliance reports
+	ListComplianceReports(context.Context, *ListComplianceReportsRequest) (*ListComplianceReportsResponse, error)
+	GetComplianceReport(context.Context, *GetComplianceReportRequest) (*ComplianceReport, error)
+	GenerateComplianceReport(context.Context, *GenerateComplianceReportRequest) (*ComplianceReport, error)
+	// Dead-lettered events
+	ListDeadLetters(context.Context, *ListDeadLettersRequest) (*ListDeadLettersResponse, error)
+	GetDeadLetter(context.Context, *GetDeadLetterRequest) (*DeadLetter, error)
+	ReplayDeadLetter(context.Context, *ReplayDeadLetterRequest) (*ReplayDeadLetterResponse, error)
+	DiscardDeadLetter(context.Context, *DiscardDeadLetterRequest) (*DiscardDeadLetterResponse, error)
+	// Trip listing read model
+	GetTripListingsLag(context.Context, *GetTripListingsLagRequest) (*TripListingsLag, error)
+	RebuildTripListings(context.Context, *RebuildTripListingsRequest) (*RebuildTripListingsResponse, error)
+	// In-trip messaging
+	SendTripMessage(context.Context, *SendTripMessageRequest) (*TripMessage, error)
+	ListTripMessages(context.Context, *ListTripMessagesRequest) (*ListTripMessagesResponse, error)
+	StreamTripMessages(*StreamTripMessagesRequest, grpc.ServerStreamingServer[TripMessage]) error
+	ListQuickReplies(context.Context, *ListQuickRepliesRequest) (*ListQuickRepliesResponse, error)
+	// Driver events
+	SubscribeDriverEvents(*SubscribeDriverEventsRequest, grpc.ServerStreamingServer[DriverEvent]) error
+	UpdateRiderLocation(context.Context, *UpdateRiderLocationRequest) (*UpdateRiderLocationResponse, error)
+	// Real-time features
+	SubscribeToTripUpdates(*SubscribeToTripUpdatesRequest, grpc.ServerStreamingServer[TripUpdateEvent]) error
+	mustEmbedUnimplementedTripServiceServer()
+}
+
+// UnimplementedTripServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTripServiceServer struct{}
+
+func (UnimplementedTripServiceServer) CreateTrip(context.Context, *CreateTripRequest) (*CreateTripResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTrip not implemented")
+}
+func (UnimplementedTripServiceServer) GetTrip(context.Context, *GetTripRequest) (*GetTripResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrip not implemented")
+}
+func (UnimplementedTripServiceServer) UpdateTripStatus(context.Context, *UpdateTripStatusRequest) (*UpdateTripStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTripStatus not implemented")
+}
+func (UnimplementedTripServiceServer) GetUserTrips(context.Context, *GetUserTripsRequest) (*GetUserTripsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserTrips not implemented")
+}
+func (UnimplementedTripServiceServer) GetActiveTrips(context.Context, *GetActiveTripsRequest) (*GetActiveTripsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetActiveTrips not implemented")
+}
+func (UnimplementedTripServiceServer) ForceCancelTrip(context.Context, *ForceCancelTripRequest) (*ForceCancelTripResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForceCancelTrip not implemented")
+}
+func (UnimplementedTripServiceServer) AnonymizeUserTrips(context.Context, *AnonymizeUserTripsRequest) (*AnonymizeUserTripsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnonymizeUserTrips not implemented")
+}
+func (UnimplementedTripServiceServer) OpenSharedTrip(context.Context, *OpenSharedTripRequest) (*SharedTripResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OpenSharedTrip not implemented")
+}
+func (UnimplementedTripServiceServer) AddSharedRider(context.Context, *AddSharedRiderRequest) (*SharedTripResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddSharedRider not implemented")
+}
+func (UnimplementedTripServiceServer) CompleteTripStop(context.Context, *CompleteTripStopRequest) (*SharedTripResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteTripStop not implemented")
+}
+func (UnimplementedTripServiceServer) GetSharedTrip(context.Context, *GetSharedTripRequest) (*SharedTripResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSharedTrip not implemented")
+}
+func (UnimplementedTripServiceServer) ListOpenSharedTrips(context.Context, *ListOpenSharedTripsRequest) (*ListOpenSharedTripsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOpenSharedTrips not implemented")
+}
+func (UnimplementedTripServiceServer) CreateScheduledRide(context.Context, *CreateScheduledRideRequest) (*ScheduledRideResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateScheduledRide not implemented")
+}
+func (UnimplementedTripServiceServer) ListScheduledRides(context.Context, *ListScheduledRidesRequest) (*ListScheduledRidesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListScheduledRides not implemented")
+}
+func (UnimplementedTripServiceServer) CancelScheduledRide(context.Context, *CancelScheduledRideRequest) (*ScheduledRideResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelScheduledRide not implemented")
+}
+func (UnimplementedTripServiceServer) SubmitRating(context.Context, *SubmitRatingRequest) (*SubmitRatingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitRating not implemented")
+}
+func (UnimplementedTripServiceServer) GetRatingSummary(context.Context, *GetRatingSummaryRequest) (*RatingSummary, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRatingSummary not implemented")
+}
+func (UnimplementedTripServiceServer) ListReviews(context.Context, *ListReviewsRequest) (*ListReviewsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReviews not implemented")
+}
+func (UnimplementedTripServiceServer) GetDriverRatings(context.Context, *GetDriverRatingsRequest) (*GetDriverRatingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDriverRatings not implemented")
+}
+func (UnimplementedTripServiceServer) GetDriverReliability(context.Context, *GetDriverReliabilityRequest) (*GetDriverReliabilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDriverReliability not implemented")
+}
+func (UnimplementedTripServiceServer) CreateTripShareLink(context.Context, *CreateTripShareLinkRequest) (*CreateTripShareLinkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTripShareLink not implemented")
+}
+func (UnimplementedTripServiceServer) GetTripByShareToken(context.Context, *GetTripByShareTokenRequest) (*SharedTripStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTripByShareToken not implemented")
+}
+func (UnimplementedTripServiceServer) WatchTripByShareToken(*GetTripByShareTokenRequest, grpc.ServerStreamingServer[SharedTripStatus]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchTripByShareToken not implemented")
+}
+func (UnimplementedTripServiceServer) ReportSOS(context.Context, *ReportSOSRequest) (*Incident, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportSOS not implemented")
+}
+func (UnimplementedTripServiceServer) GetIncident(context.Context, *GetIncidentRequest) (*Incident, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIncident not implemented")
+}
+func (UnimplementedTripServiceServer) ListIncidents(context.Context, *ListIncidentsRequest) (*ListIncidentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListIncidents not implemented")
+}
+func (UnimplementedTripServiceServer) AcknowledgeIncident(context.Context, *AcknowledgeIncidentRequest) (*Incident, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcknowledgeIncident not implemented")
+}
+func (UnimplementedTripServiceServer) AddIncidentNote(context.Context, *AddIncidentNoteRequest) (*Incident, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddIncidentNote not implemented")
+}
+func (UnimplementedTripServiceServer) ResolveIncident(context.Context, *ResolveIncidentRequest) (*Incident, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveIncident not implemented")
+}
+func (UnimplementedTripServiceServer) OpenDispute(context.Context, *OpenDisputeRequest) (*Dispute, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OpenDispute not implemented")
+}
+func (UnimplementedTripServiceServer) GetDisputeReview(context.Context, *GetDisputeRequest) (*DisputeReview, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDisputeReview not implemented")
+}
+func (UnimplementedTripServiceServer) ListDisputes(context.Context, *ListDisputesRequest) (*ListDisputesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDisputes not implemented")
+}
+func (UnimplementedTripServiceServer) StartDisputeReview(context.Context, *StartDisputeReviewRequest) (*Dispute, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartDisputeReview not implemented")
+}
+func (UnimplementedTripServiceServer) AddDisputeNote(context.Context, *AddDisputeNoteRequest) (*Dispute, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddDisputeNote not implemented")
+}
+func (UnimplementedTripServiceServer) ResolveDispute(context.Context, *ResolveDisputeRequest) (*Dispute, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveDispute not implemented")
+}
+func (UnimplementedTripServiceServer) RejectDispute(context.Context, *RejectDisputeRequest) (*Dispute, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectDispute not implemented")
+}
+func (UnimplementedTripServiceServer) ReportLostItem(context.Context, *ReportLostItemRequest) (*LostItemReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportLostItem not implemented")
+}
+func (UnimplementedTripServiceServer) GetLostItem(context.Context, *GetLostItemRequest) (*LostItemReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLostItem not implemented")
+}
+func (UnimplementedTripServiceServer) ListLostItems(context.Context, *ListLostItemsRequest) (*ListLostItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLostItems not implemented")
+}
+func (UnimplementedTripServiceServer) UpdateLostItem(context.Context, *UpdateLostItemRequest) (*LostItemReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLostItem not implemented")
+}
+func (UnimplementedTripServiceServer) GetTripContact(context.Context, *GetTripContactRequest) (*TripContact, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTripContact not implemented")
+}
+func (UnimplementedTripServiceServer) GetPickupGuarantee(context.Context, *GetPickupGuaranteeRequest) (*PickupGuarantee, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPickupGuarantee not implemented")
+}
+func (UnimplementedTripServiceServer) GetPickupSLAReport(context.Context, *GetPickupSLAReportRequest) (*PickupSLAReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPickupSLAReport not implemented")
+}
+func (UnimplementedTripServiceServer) ListComplianceReports(context.Context, *ListComplianceReportsRequest) (*ListComplianceReportsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListComplianceReports not implemented")
+}
+func (UnimplementedTripServiceServer) GetComplianceReport(context.Context, *GetComplianceReportRequest) (*ComplianceReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetComplianceReport not implemented")
+}
+func (UnimplementedTripServiceServer) GenerateComplianceReport(context.Context, *GenerateComplianceReportRequest) (*ComplianceReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateComplianceReport not implemented")
+}
+func (UnimplementedTripServiceServer) ListDeadLetters(context.Context, *ListDeadLettersRequest) (*ListDeadLettersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeadLetters not implemented")
+}
+func (UnimplementedTripServiceServer) GetDeadLetter(context.Context, *GetDeadLetterRequest) (*DeadLetter, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeadLetter not implemented")
+}
+func (UnimplementedTripServiceServer) ReplayDeadLetter(context.Context, *ReplayDeadLetterRequest) (*ReplayDeadLetterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplayDeadLetter not implemented")
+}
+func (UnimplementedTripServiceServer) DiscardDeadLetter(context.Context, *DiscardDeadLetterRequest) (*DiscardDeadLetterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiscardDeadLetter not implemented")
+}
+func (UnimplementedTripServiceServer) GetTripListingsLag(context.Context, *GetTripListingsLagRequest) (*TripListingsLag, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTripListingsLag not implemented")
+}
+func (UnimplementedTripServiceServer) RebuildTripListings(context.Context, *RebuildTripListingsRequest) (*RebuildTripListingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RebuildTripListings not implemented")
+}
+func (UnimplementedTripServiceServer) SendTripMessage(context.Context, *SendTripMessageRequest) (*TripMessage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendTripMessage not implemented")
+}
+func (UnimplementedTripServiceServer) ListTripMessages(context.Context, *ListTripMessagesRequest) (*ListTripMessagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTripMessages not implemented")
+}
+func (UnimplementedTripServiceServer) StreamTripMessages(*StreamTripMessagesRequest, grpc.ServerStreamingServer[TripMessage]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamTripMessages not implemented")
+}
+func (UnimplementedTripServiceServer) ListQuickReplies(context.Context, *ListQuickRepliesRequest) (*ListQuickRepliesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListQuickReplies not implemented")
+}
+func (UnimplementedTripServiceServer) SubscribeDriverEvents(*SubscribeDriverEventsRequest, grpc.ServerStreamingServer[DriverEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeDriverEvents not implemented")
+}
+func (UnimplementedTripServiceServer) UpdateRiderLocation(context.Context, *UpdateRiderLocationRequest) (*UpdateRiderLocationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRiderLocation not implemented")
+}
+func (UnimplementedTripServiceServer) SubscribeToTripUpdates(*SubscribeToTripUpdatesRequest, grpc.ServerStreamingServer[TripUpdateEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeToTripUpdates not implemented")
+}
+func (UnimplementedTripServiceServer) mustEmbedUnimplementedTripServiceServer() {}
+func (UnimplementedTripServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeTripServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TripServiceServer will
+// result in compilation errors.
+type UnsafeTripServiceServer interface {
+	mustEmbedUnimplementedTripServiceServer()
+}
+
+func RegisterTripServiceServer(s grpc.ServiceRegistrar, srv TripServiceServer) {
+	// If the following call pancis, it indicates UnimplementedTripServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&TripService_ServiceDesc, srv)
+}
+
+func _TripService_CreateTrip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTripRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).CreateTrip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_CreateTrip_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).CreateTrip(ctx, req.(*CreateTripRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_GetTrip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTripRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).GetTrip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_GetTrip_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).GetTrip(ctx, req.(*GetTripRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_UpdateTripStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTripStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).UpdateTripStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_UpdateTripStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).UpdateTripStatus(ctx, req.(*UpdateTripStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_GetUserTrips_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserTripsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).GetUserTrips(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_GetUserTrips_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).GetUserTrips(ctx, req.(*GetUserTripsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_GetActiveTrips_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetActiveTripsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).GetActiveTrips(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_GetActiveTrips_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).GetActiveTrips(ctx, req.(*GetActiveTripsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_ForceCancelTrip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForceCancelTripRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).ForceCancelTrip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_ForceCancelTrip_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).ForceCancelTrip(ctx, req.(*ForceCancelTripRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_AnonymizeUserTrips_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnonymizeUserTripsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).AnonymizeUserTrips(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_AnonymizeUserTrips_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).AnonymizeUserTrips(ctx, req.(*AnonymizeUserTripsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_OpenSharedTrip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenSharedTripRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).OpenSharedTrip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_OpenSharedTrip_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).OpenSharedTrip(ctx, req.(*OpenSharedTripRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_AddSharedRider_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddSharedRiderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).AddSharedRider(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_AddSharedRider_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).AddSharedRider(ctx, req.(*AddSharedRiderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_CompleteTripStop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteTripStopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).CompleteTripStop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_CompleteTripStop_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).CompleteTripStop(ctx, req.(*CompleteTripStopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_GetSharedTrip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSharedTripRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).GetSharedTrip(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_GetSharedTrip_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).GetSharedTrip(ctx, req.(*GetSharedTripRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_ListOpenSharedTrips_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOpenSharedTripsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).ListOpenSharedTrips(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_ListOpenSharedTrips_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).ListOpenSharedTrips(ctx, req.(*ListOpenSharedTripsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_CreateScheduledRide_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateScheduledRideRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).CreateScheduledRide(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_CreateScheduledRide_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).CreateScheduledRide(ctx, req.(*CreateScheduledRideRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_ListScheduledRides_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScheduledRidesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).ListScheduledRides(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_ListScheduledRides_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).ListScheduledRides(ctx, req.(*ListScheduledRidesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_CancelScheduledRide_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelScheduledRideRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).CancelScheduledRide(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_CancelScheduledRide_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).CancelScheduledRide(ctx, req.(*CancelScheduledRideRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_SubmitRating_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitRatingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).SubmitRating(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_SubmitRating_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).SubmitRating(ctx, req.(*SubmitRatingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_GetRatingSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRatingSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).GetRatingSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_GetRatingSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).GetRatingSummary(ctx, req.(*GetRatingSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_ListReviews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReviewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).ListReviews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_ListReviews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).ListReviews(ctx, req.(*ListReviewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_GetDriverRatings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDriverRatingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).GetDriverRatings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_GetDriverRatings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).GetDriverRatings(ctx, req.(*GetDriverRatingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_GetDriverReliability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDriverReliabilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).GetDriverReliability(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_GetDriverReliability_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).GetDriverReliability(ctx, req.(*GetDriverReliabilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_CreateTripShareLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTripShareLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).CreateTripShareLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_CreateTripShareLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).CreateTripShareLink(ctx, req.(*CreateTripShareLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_GetTripByShareToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTripByShareTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).GetTripByShareToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_GetTripByShareToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).GetTripByShareToken(ctx, req.(*GetTripByShareTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_WatchTripByShareToken_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetTripByShareTokenRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TripServiceServer).WatchTripByShareToken(m, &grpc.GenericServerStream[GetTripByShareTokenRequest, SharedTripStatus]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TripService_WatchTripByShareTokenServer = grpc.ServerStreamingServer[SharedTripStatus]
+
+func _TripService_ReportSOS_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportSOSRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).ReportSOS(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_ReportSOS_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).ReportSOS(ctx, req.(*ReportSOSRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_GetIncident_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetIncidentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).GetIncident(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_GetIncident_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).GetIncident(ctx, req.(*GetIncidentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_ListIncidents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListIncidentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).ListIncidents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_ListIncidents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).ListIncidents(ctx, req.(*ListIncidentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_AcknowledgeIncident_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcknowledgeIncidentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).AcknowledgeIncident(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_AcknowledgeIncident_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).AcknowledgeIncident(ctx, req.(*AcknowledgeIncidentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_AddIncidentNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddIncidentNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).AddIncidentNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_AddIncidentNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).AddIncidentNote(ctx, req.(*AddIncidentNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_ResolveIncident_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveIncidentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).ResolveIncident(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_ResolveIncident_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).ResolveIncident(ctx, req.(*ResolveIncidentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_OpenDispute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenDisputeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).OpenDispute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_OpenDispute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).OpenDispute(ctx, req.(*OpenDisputeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_GetDisputeReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDisputeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).GetDisputeReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_GetDisputeReview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).GetDisputeReview(ctx, req.(*GetDisputeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_ListDisputes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDisputesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).ListDisputes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_ListDisputes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).ListDisputes(ctx, req.(*ListDisputesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_StartDisputeReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartDisputeReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).StartDisputeReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_StartDisputeReview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).StartDisputeReview(ctx, req.(*StartDisputeReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_AddDisputeNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddDisputeNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).AddDisputeNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_AddDisputeNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).AddDisputeNote(ctx, req.(*AddDisputeNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_ResolveDispute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveDisputeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).ResolveDispute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_ResolveDispute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).ResolveDispute(ctx, req.(*ResolveDisputeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_RejectDispute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectDisputeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).RejectDispute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_RejectDispute_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).RejectDispute(ctx, req.(*RejectDisputeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_ReportLostItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportLostItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).ReportLostItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_ReportLostItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).ReportLostItem(ctx, req.(*ReportLostItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_GetLostItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLostItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).GetLostItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_GetLostItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).GetLostItem(ctx, req.(*GetLostItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_ListLostItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLostItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).ListLostItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_ListLostItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).ListLostItems(ctx, req.(*ListLostItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_UpdateLostItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLostItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).UpdateLostItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_UpdateLostItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).UpdateLostItem(ctx, req.(*UpdateLostItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_GetTripContact_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTripContactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).GetTripContact(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_GetTripContact_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).GetTripContact(ctx, req.(*GetTripContactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_GetPickupGuarantee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPickupGuaranteeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).GetPickupGuarantee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_GetPickupGuarantee_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).GetPickupGuarantee(ctx, req.(*GetPickupGuaranteeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_GetPickupSLAReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPickupSLAReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).GetPickupSLAReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_GetPickupSLAReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).GetPickupSLAReport(ctx, req.(*GetPickupSLAReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_ListComplianceReports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListComplianceReportsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).ListComplianceReports(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_ListComplianceReports_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).ListComplianceReports(ctx, req.(*ListComplianceReportsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_GetComplianceReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetComplianceReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).GetComplianceReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_GetComplianceReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).GetComplianceReport(ctx, req.(*GetComplianceReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_GenerateComplianceReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateComplianceReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).GenerateComplianceReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_GenerateComplianceReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).GenerateComplianceReport(ctx, req.(*GenerateComplianceReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TripService_ListDeadLetters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeadLettersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).ListDeadLetters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_ListDeadLetters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).ListDeadLetters(ctx, req.(*ListDeadLettersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type TripService_SubscribeToTripUpdatesClient = grpc.ServerStreamingClient[TripUpdateEvent]
-
-// TripServiceServer is the server API for TripService service.
-// All implementations must embed UnimplementedTripServiceServer
-// for forward compatibility.
-//
-// TripService defines the gRPC service for trip management
-type TripServiceServer interface {
-	CreateTrip(context.Context, *CreateTripRequest) (*CreateTripResponse, error)
-	GetTrip(context.Context, *GetTripRequest) (*GetTripResponse, error)
-	UpdateTripStatus(context.Context, *UpdateTripStatusRequest) (*UpdateTripStatusResponse, error)
-	GetUserTrips(context.Context, *GetUserTripsRequest) (*GetUserTripsResponse, error)
-	GetActiveTrips(context.Context, *GetActiveTripsRequest) (*GetActiveTripsResponse, error)
-	// Real-time features
-	SubscribeToTripUpdates(*SubscribeToTripUpdatesRequest, grpc.ServerStreamingServer[TripUpdateEvent]) error
-	mustEmbedUnimplementedTripServiceServer()
+func _TripService_GetDeadLetter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDeadLetterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).GetDeadLetter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_GetDeadLetter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).GetDeadLetter(ctx, req.(*GetDeadLetterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// UnimplementedTripServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedTripServiceServer struct{}
-
-func (UnimplementedTripServiceServer) CreateTrip(context.Context, *CreateTripRequest) (*CreateTripResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateTrip not implemented")
-}
-func (UnimplementedTripServiceServer) GetTrip(context.Context, *GetTripRequest) (*GetTripResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetTrip not implemented")
-}
-func (UnimplementedTripServiceServer) UpdateTripStatus(context.Context, *UpdateTripStatusRequest) (*UpdateTripStatusResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateTripStatus not implemented")
-}
-func (UnimplementedTripServiceServer) GetUserTrips(context.Context, *GetUserTripsRequest) (*GetUserTripsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetUserTrips not implemented")
-}
-func (UnimplementedTripServiceServer) GetActiveTrips(context.Context, *GetActiveTripsRequest) (*GetActiveTripsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetActiveTrips not implemented")
-}
-func (UnimplementedTripServiceServer) SubscribeToTripUpdates(*SubscribeToTripUpdatesRequest, grpc.ServerStreamingServer[TripUpdateEvent]) error {
-	return status.Errorf(codes.Unimplemented, "method SubscribeToTripUpdates not implemented")
+func _TripService_ReplayDeadLetter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayDeadLetterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).ReplayDeadLetter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_ReplayDeadLetter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).ReplayDeadLetter(ctx, req.(*ReplayDeadLetterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedTripServiceServer) mustEmbedUnimplementedTripServiceServer() {}
-func (UnimplementedTripServiceServer) testEmbeddedByValue()                     {}
 
-// UnsafeTripServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to TripServiceServer will
-// result in compilation errors.
-type UnsafeTripServiceServer interface {
-	mustEmbedUnimplementedTripServiceServer()
+func _TripService_DiscardDeadLetter_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiscardDeadLetterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TripServiceServer).DiscardDeadLetter(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_DiscardDeadLetter_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).DiscardDeadLetter(ctx, req.(*DiscardDeadLetterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterTripServiceServer(s grpc.ServiceRegistrar, srv TripServiceServer) {
-	// If the following call pancis, it indicates UnimplementedTripServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _TripService_GetTripListingsLag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTripListingsLagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&TripService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(TripServiceServer).GetTripListingsLag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TripService_GetTripListingsLag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TripServiceServer).GetTripListingsLag(ctx, req.(*GetTripListingsLagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _TripService_CreateTrip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateTripRequest)
+func _TripService_RebuildTripListings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RebuildTripListingsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TripServiceServer).CreateTrip(ctx, in)
+		return srv.(TripServiceServer).RebuildTripListings(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: TripService_CreateTrip_FullMethodName,
+		FullMethod: TripService_RebuildTripListings_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TripServiceServer).CreateTrip(ctx, req.(*CreateTripRequest))
+		return srv.(TripServiceServer).RebuildTripListings(ctx, req.(*RebuildTripListingsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TripService_GetTrip_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetTripRequest)
+func _TripService_SendTripMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendTripMessageRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TripServiceServer).GetTrip(ctx, in)
+		return srv.(TripServiceServer).SendTripMessage(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: TripService_GetTrip_FullMethodName,
+		FullMethod: TripService_SendTripMessage_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TripServiceServer).GetTrip(ctx, req.(*GetTripRequest))
+		return srv.(TripServiceServer).SendTripMessage(ctx, req.(*SendTripMessageRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TripService_UpdateTripStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateTripStatusRequest)
+func _TripService_ListTripMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTripMessagesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TripServiceServer).UpdateTripStatus(ctx, in)
+		return srv.(TripServiceServer).ListTripMessages(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: TripService_UpdateTripStatus_FullMethodName,
+		FullMethod: TripService_ListTripMessages_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TripServiceServer).UpdateTripStatus(ctx, req.(*UpdateTripStatusRequest))
+		return srv.(TripServiceServer).ListTripMessages(ctx, req.(*ListTripMessagesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TripService_GetUserTrips_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetUserTripsRequest)
+func _TripService_StreamTripMessages_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamTripMessagesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TripServiceServer).StreamTripMessages(m, &grpc.GenericServerStream[StreamTripMessagesRequest, TripMessage]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TripService_StreamTripMessagesServer = grpc.ServerStreamingServer[TripMessage]
+
+func _TripService_ListQuickReplies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListQuickRepliesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TripServiceServer).GetUserTrips(ctx, in)
+		return srv.(TripServiceServer).ListQuickReplies(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: TripService_GetUserTrips_FullMethodName,
+		FullMethod: TripService_ListQuickReplies_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TripServiceServer).GetUserTrips(ctx, req.(*GetUserTripsRequest))
+		return srv.(TripServiceServer).ListQuickReplies(ctx, req.(*ListQuickRepliesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _TripService_GetActiveTrips_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetActiveTripsRequest)
+func _TripService_SubscribeDriverEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeDriverEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TripServiceServer).SubscribeDriverEvents(m, &grpc.GenericServerStream[SubscribeDriverEventsRequest, DriverEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TripService_SubscribeDriverEventsServer = grpc.ServerStreamingServer[DriverEvent]
+
+func _TripService_UpdateRiderLocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRiderLocationRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(TripServiceServer).GetActiveTrips(ctx, in)
+		return srv.(TripServiceServer).UpdateRiderLocation(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: TripService_GetActiveTrips_FullMethodName,
+		FullMethod: TripService_UpdateRiderLocation_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(TripServiceServer).GetActiveTrips(ctx, req.(*GetActiveTripsRequest))
+		return srv.(TripServiceServer).UpdateRiderLocation(ctx, req.(*UpdateRiderLocationRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -309,8 +2145,223 @@ var TripService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetActiveTrips",
 			Handler:    _TripService_GetActiveTrips_Handler,
 		},
+		{
+			MethodName: "ForceCancelTrip",
+			Handler:    _TripService_ForceCancelTrip_Handler,
+		},
+		{
+			MethodName: "AnonymizeUserTrips",
+			Handler:    _TripService_AnonymizeUserTrips_Handler,
+		},
+		{
+			MethodName: "OpenSharedTrip",
+			Handler:    _TripService_OpenSharedTrip_Handler,
+		},
+		{
+			MethodName: "AddSharedRider",
+			Handler:    _TripService_AddSharedRider_Handler,
+		},
+		{
+			MethodName: "CompleteTripStop",
+			Handler:    _TripService_CompleteTripStop_Handler,
+		},
+		{
+			MethodName: "GetSharedTrip",
+			Handler:    _TripService_GetSharedTrip_Handler,
+		},
+		{
+			MethodName: "ListOpenSharedTrips",
+			Handler:    _TripService_ListOpenSharedTrips_Handler,
+		},
+		{
+			MethodName: "CreateScheduledRide",
+			Handler:    _TripService_CreateScheduledRide_Handler,
+		},
+		{
+			MethodName: "ListScheduledRides",
+			Handler:    _TripService_ListScheduledRides_Handler,
+		},
+		{
+			MethodName: "CancelScheduledRide",
+			Handler:    _TripService_CancelScheduledRide_Handler,
+		},
+		{
+			MethodName: "SubmitRating",
+			Handler:    _TripService_SubmitRating_Handler,
+		},
+		{
+			MethodName: "GetRatingSummary",
+			Handler:    _TripService_GetRatingSummary_Handler,
+		},
+		{
+			MethodName: "ListReviews",
+			Handler:    _TripService_ListReviews_Handler,
+		},
+		{
+			MethodName: "GetDriverRatings",
+			Handler:    _TripService_GetDriverRatings_Handler,
+		},
+		{
+			MethodName: "GetDriverReliability",
+			Handler:    _TripService_GetDriverReliability_Handler,
+		},
+		{
+			MethodName: "CreateTripShareLink",
+			Handler:    _TripService_CreateTripShareLink_Handler,
+		},
+		{
+			MethodName: "GetTripByShareToken",
+			Handler:    _TripService_GetTripByShareToken_Handler,
+		},
+		{
+			MethodName: "ReportSOS",
+			Handler:    _TripService_ReportSOS_Handler,
+		},
+		{
+			MethodName: "GetIncident",
+			Handler:    _TripService_GetIncident_Handler,
+		},
+		{
+			MethodName: "ListIncidents",
+			Handler:    _TripService_ListIncidents_Handler,
+		},
+		{
+			MethodName: "AcknowledgeIncident",
+			Handler:    _TripService_AcknowledgeIncident_Handler,
+		},
+		{
+			MethodName: "AddIncidentNote",
+			Handler:    _TripService_AddIncidentNote_Handler,
+		},
+		{
+			MethodName: "ResolveIncident",
+			Handler:    _TripService_ResolveIncident_Handler,
+		},
+		{
+			MethodName: "OpenDispute",
+			Handler:    _TripService_OpenDispute_Handler,
+		},
+		{
+			MethodName: "GetDisputeReview",
+			Handler:    _TripService_GetDisputeReview_Handler,
+		},
+		{
+			MethodName: "ListDisputes",
+			Handler:    _TripService_ListDisputes_Handler,
+		},
+		{
+			MethodName: "StartDisputeReview",
+			Handler:    _TripService_StartDisputeReview_Handler,
+		},
+		{
+			MethodName: "AddDisputeNote",
+			Handler:    _TripService_AddDisputeNote_Handler,
+		},
+		{
+			MethodName: "ResolveDispute",
+			Handler:    _TripService_ResolveDispute_Handler,
+		},
+		{
+			MethodName: "RejectDispute",
+			Handler:    _TripService_RejectDispute_Handler,
+		},
+		{
+			MethodName: "ReportLostItem",
+			Handler:    _TripService_ReportLostItem_Handler,
+		},
+		{
+			MethodName: "GetLostItem",
+			Handler:    _TripService_GetLostItem_Handler,
+		},
+		{
+			MethodName: "ListLostItems",
+			Handler:    _TripService_ListLostItems_Handler,
+		},
+		{
+			MethodName: "UpdateLostItem",
+			Handler:    _TripService_UpdateLostItem_Handler,
+		},
+		{
+			MethodName: "GetTripContact",
+			Handler:    _TripService_GetTripContact_Handler,
+		},
+		{
+			MethodName: "GetPickupGuarantee",
+			Handler:    _TripService_GetPickupGuarantee_Handler,
+		},
+		{
+			MethodName: "GetPickupSLAReport",
+			Handler:    _TripService_GetPickupSLAReport_Handler,
+		},
+		{
+			MethodName: "ListComplianceReports",
+			Handler:    _TripService_ListComplianceReports_Handler,
+		},
+		{
+			MethodName: "GetComplianceReport",
+			Handler:    _TripService_GetComplianceReport_Handler,
+		},
+		{
+			MethodName: "GenerateComplianceReport",
+			Handler:    _TripService_GenerateComplianceReport_Handler,
+		},
+		{
+			MethodName: "ListDeadLetters",
+			Handler:    _TripService_ListDeadLetters_Handler,
+		},
+		{
+			MethodName: "GetDeadLetter",
+			Handler:    _TripService_GetDeadLetter_Handler,
+		},
+		{
+			MethodName: "ReplayDeadLetter",
+			Handler:    _TripService_ReplayDeadLetter_Handler,
+		},
+		{
+			MethodName: "DiscardDeadLetter",
+			Handler:    _TripService_DiscardDeadLetter_Handler,
+		},
+		{
+			MethodName: "GetTripListingsLag",
+			Handler:    _TripService_GetTripListingsLag_Handler,
+		},
+		{
+			MethodName: "RebuildTripListings",
+			Handler:    _TripService_RebuildTripListings_Handler,
+		},
+		{
+			MethodName: "SendTripMessage",
+			Handler:    _TripService_SendTripMessage_Handler,
+		},
+		{
+			MethodName: "ListTripMessages",
+			Handler:    _TripService_ListTripMessages_Handler,
+		},
+		{
+			MethodName: "ListQuickReplies",
+			Handler:    _TripService_ListQuickReplies_Handler,
+		},
+		{
+			MethodName: "UpdateRiderLocation",
+			Handler:    _TripService_UpdateRiderLocation_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchTripByShareToken",
+			Handler:       _TripService_WatchTripByShareToken_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamTripMessages",
+			Handler:       _TripService_StreamTripMessages_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeDriverEvents",
+			Handler:       _TripService_SubscribeDriverEvents_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "SubscribeToTripUpdates",
 			Handler:       _TripService_SubscribeToTripUpdates_Handler,
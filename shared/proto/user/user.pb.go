@@ -935,6 +935,120 @@ func (x *UpdateUserResponse) GetMessage() string {
 	return ""
 }
 
+// Changes an account's status, e.g. to ban it. Suspended and banned users
+// can no longer sign in.
+type UpdateUserStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status        UserStatus             `protobuf:"varint,2,opt,name=status,proto3,enum=user.UserStatus" json:"status,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserStatusRequest) Reset() {
+	*x = UpdateUserStatusRequest{}
+	mi := &file_shared_proto_user_user_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserStatusRequest) ProtoMessage() {}
+
+func (x *UpdateUserStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_user_user_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateUserStatusRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_user_user_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateUserStatusRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateUserStatusRequest) GetStatus() UserStatus {
+	if x != nil {
+		return x.Status
+	}
+	return UserStatus_UNKNOWN_STATUS
+}
+
+func (x *UpdateUserStatusRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type UpdateUserStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status        UserStatus             `protobuf:"varint,2,opt,name=status,proto3,enum=user.UserStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserStatusResponse) Reset() {
+	*x = UpdateUserStatusResponse{}
+	mi := &file_shared_proto_user_user_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserStatusResponse) ProtoMessage() {}
+
+func (x *UpdateUserStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_user_user_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserStatusResponse.ProtoReflect.Descriptor instead.
+func (*UpdateUserStatusResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_user_user_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *UpdateUserStatusResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateUserStatusResponse) GetStatus() UserStatus {
+	if x != nil {
+		return x.Status
+	}
+	return UserStatus_UNKNOWN_STATUS
+}
+
 type ListUsersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Role          UserRole               `protobuf:"varint,1,opt,name=role,proto3,enum=user.UserRole" json:"role,omitempty"`
@@ -947,7 +1061,7 @@ type ListUsersRequest struct {
 
 func (x *ListUsersRequest) Reset() {
 	*x = ListUsersRequest{}
-	mi := &file_shared_proto_user_user_proto_msgTypes[10]
+	mi := &file_shared_proto_user_user_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -959,7 +1073,7 @@ func (x *ListUsersRequest) String() string {
 func (*ListUsersRequest) ProtoMessage() {}
 
 func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_user_user_proto_msgTypes[10]
+	mi := &file_shared_proto_user_user_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -972,7 +1086,7 @@ func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
 func (*ListUsersRequest) Descriptor() ([]byte, []int) {
-	return file_shared_proto_user_user_proto_rawDescGZIP(), []int{10}
+	return file_shared_proto_user_user_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ListUsersRequest) GetRole() UserRole {
@@ -1014,7 +1128,7 @@ type ListUsersResponse struct {
 
 func (x *ListUsersResponse) Reset() {
 	*x = ListUsersResponse{}
-	mi := &file_shared_proto_user_user_proto_msgTypes[11]
+	mi := &file_shared_proto_user_user_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1026,7 +1140,7 @@ func (x *ListUsersResponse) String() string {
 func (*ListUsersResponse) ProtoMessage() {}
 
 func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_user_user_proto_msgTypes[11]
+	mi := &file_shared_proto_user_user_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1039,7 +1153,7 @@ func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
 func (*ListUsersResponse) Descriptor() ([]byte, []int) {
-	return file_shared_proto_user_user_proto_rawDescGZIP(), []int{11}
+	return file_shared_proto_user_user_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ListUsersResponse) GetUsers() []*User {
@@ -1082,7 +1196,7 @@ type Driver struct {
 
 func (x *Driver) Reset() {
 	*x = Driver{}
-	mi := &file_shared_proto_user_user_proto_msgTypes[12]
+	mi := &file_shared_proto_user_user_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1094,7 +1208,7 @@ func (x *Driver) String() string {
 func (*Driver) ProtoMessage() {}
 
 func (x *Driver) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_user_user_proto_msgTypes[12]
+	mi := &file_shared_proto_user_user_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1107,7 +1221,7 @@ func (x *Driver) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Driver.ProtoReflect.Descriptor instead.
 func (*Driver) Descriptor() ([]byte, []int) {
-	return file_shared_proto_user_user_proto_rawDescGZIP(), []int{12}
+	return file_shared_proto_user_user_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *Driver) GetUserId() string {
@@ -1191,7 +1305,7 @@ type UpdateDriverLocationRequest struct {
 
 func (x *UpdateDriverLocationRequest) Reset() {
 	*x = UpdateDriverLocationRequest{}
-	mi := &file_shared_proto_user_user_proto_msgTypes[13]
+	mi := &file_shared_proto_user_user_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1203,7 +1317,7 @@ func (x *UpdateDriverLocationRequest) String() string {
 func (*UpdateDriverLocationRequest) ProtoMessage() {}
 
 func (x *UpdateDriverLocationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_user_user_proto_msgTypes[13]
+	mi := &file_shared_proto_user_user_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1216,7 +1330,7 @@ func (x *UpdateDriverLocationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateDriverLocationRequest.ProtoReflect.Descriptor instead.
 func (*UpdateDriverLocationRequest) Descriptor() ([]byte, []int) {
-	return file_shared_proto_user_user_proto_rawDescGZIP(), []int{13}
+	return file_shared_proto_user_user_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *UpdateDriverLocationRequest) GetDriverId() string {
@@ -1250,7 +1364,7 @@ type UpdateDriverLocationResponse struct {
 
 func (x *UpdateDriverLocationResponse) Reset() {
 	*x = UpdateDriverLocationResponse{}
-	mi := &file_shared_proto_user_user_proto_msgTypes[14]
+	mi := &file_shared_proto_user_user_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1262,7 +1376,7 @@ func (x *UpdateDriverLocationResponse) String() string {
 func (*UpdateDriverLocationResponse) ProtoMessage() {}
 
 func (x *UpdateDriverLocationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_user_user_proto_msgTypes[14]
+	mi := &file_shared_proto_user_user_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1275,7 +1389,7 @@ func (x *UpdateDriverLocationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateDriverLocationResponse.ProtoReflect.Descriptor instead.
 func (*UpdateDriverLocationResponse) Descriptor() ([]byte, []int) {
-	return file_shared_proto_user_user_proto_rawDescGZIP(), []int{14}
+	return file_shared_proto_user_user_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *UpdateDriverLocationResponse) GetSuccess() bool {
@@ -1301,7 +1415,7 @@ type GetDriverRequest struct {
 
 func (x *GetDriverRequest) Reset() {
 	*x = GetDriverRequest{}
-	mi := &file_shared_proto_user_user_proto_msgTypes[15]
+	mi := &file_shared_proto_user_user_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1313,7 +1427,7 @@ func (x *GetDriverRequest) String() string {
 func (*GetDriverRequest) ProtoMessage() {}
 
 func (x *GetDriverRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_user_user_proto_msgTypes[15]
+	mi := &file_shared_proto_user_user_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1326,7 +1440,7 @@ func (x *GetDriverRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDriverRequest.ProtoReflect.Descriptor instead.
 func (*GetDriverRequest) Descriptor() ([]byte, []int) {
-	return file_shared_proto_user_user_proto_rawDescGZIP(), []int{15}
+	return file_shared_proto_user_user_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *GetDriverRequest) GetDriverId() string {
@@ -1346,7 +1460,7 @@ type GetDriverResponse struct {
 
 func (x *GetDriverResponse) Reset() {
 	*x = GetDriverResponse{}
-	mi := &file_shared_proto_user_user_proto_msgTypes[16]
+	mi := &file_shared_proto_user_user_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1358,7 +1472,7 @@ func (x *GetDriverResponse) String() string {
 func (*GetDriverResponse) ProtoMessage() {}
 
 func (x *GetDriverResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_shared_proto_user_user_proto_msgTypes[16]
+	mi := &file_shared_proto_user_user_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1371,7 +1485,7 @@ func (x *GetDriverResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDriverResponse.ProtoReflect.Descriptor instead.
 func (*GetDriverResponse) Descriptor() ([]byte, []int) {
-	return file_shared_proto_user_user_proto_rawDescGZIP(), []int{16}
+	return file_shared_proto_user_user_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *GetDriverResponse) GetDriver() *Driver {
@@ -1388,6 +1502,293 @@ func (x *GetDriverResponse) GetFound() bool {
 	return false
 }
 
+// Driver onboarding messages
+type GetDriverOnboardingStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverId      string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDriverOnboardingStatusRequest) Reset() {
+	*x = GetDriverOnboardingStatusRequest{}
+	mi := &file_shared_proto_user_user_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDriverOnboardingStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDriverOnboardingStatusRequest) ProtoMessage() {}
+
+func (x *GetDriverOnboardingStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_user_user_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDriverOnboardingStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetDriverOnboardingStatusRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_user_user_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetDriverOnboardingStatusRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+type GetDriverOnboardingStatusResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	DriverId              string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	Status                string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // "pending", "documents_submitted", "approved" or "rejected"; empty if not started
+	BackgroundCheckStatus string                 `protobuf:"bytes,3,opt,name=background_check_status,json=backgroundCheckStatus,proto3" json:"background_check_status,omitempty"`
+	Approved              bool                   `protobuf:"varint,4,opt,name=approved,proto3" json:"approved,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *GetDriverOnboardingStatusResponse) Reset() {
+	*x = GetDriverOnboardingStatusResponse{}
+	mi := &file_shared_proto_user_user_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDriverOnboardingStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDriverOnboardingStatusResponse) ProtoMessage() {}
+
+func (x *GetDriverOnboardingStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_user_user_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDriverOnboardingStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetDriverOnboardingStatusResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_user_user_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetDriverOnboardingStatusResponse) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *GetDriverOnboardingStatusResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetDriverOnboardingStatusResponse) GetBackgroundCheckStatus() string {
+	if x != nil {
+		return x.BackgroundCheckStatus
+	}
+	return ""
+}
+
+func (x *GetDriverOnboardingStatusResponse) GetApproved() bool {
+	if x != nil {
+		return x.Approved
+	}
+	return false
+}
+
+// Public driver profile shown to riders, looked up in bulk during matching
+type DriverProfile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverId      string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	FirstName     string                 `protobuf:"bytes,2,opt,name=first_name,json=firstName,proto3" json:"first_name,omitempty"`
+	LastName      string                 `protobuf:"bytes,3,opt,name=last_name,json=lastName,proto3" json:"last_name,omitempty"`
+	PhotoUrl      string                 `protobuf:"bytes,4,opt,name=photo_url,json=photoUrl,proto3" json:"photo_url,omitempty"`
+	Rating        float64                `protobuf:"fixed64,5,opt,name=rating,proto3" json:"rating,omitempty"`
+	TotalTrips    int32                  `protobuf:"varint,6,opt,name=total_trips,json=totalTrips,proto3" json:"total_trips,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DriverProfile) Reset() {
+	*x = DriverProfile{}
+	mi := &file_shared_proto_user_user_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriverProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriverProfile) ProtoMessage() {}
+
+func (x *DriverProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_user_user_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriverProfile.ProtoReflect.Descriptor instead.
+func (*DriverProfile) Descriptor() ([]byte, []int) {
+	return file_shared_proto_user_user_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DriverProfile) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *DriverProfile) GetFirstName() string {
+	if x != nil {
+		return x.FirstName
+	}
+	return ""
+}
+
+func (x *DriverProfile) GetLastName() string {
+	if x != nil {
+		return x.LastName
+	}
+	return ""
+}
+
+func (x *DriverProfile) GetPhotoUrl() string {
+	if x != nil {
+		return x.PhotoUrl
+	}
+	return ""
+}
+
+func (x *DriverProfile) GetRating() float64 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *DriverProfile) GetTotalTrips() int32 {
+	if x != nil {
+		return x.TotalTrips
+	}
+	return 0
+}
+
+type GetDriverProfilesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverIds     []string               `protobuf:"bytes,1,rep,name=driver_ids,json=driverIds,proto3" json:"driver_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDriverProfilesRequest) Reset() {
+	*x = GetDriverProfilesRequest{}
+	mi := &file_shared_proto_user_user_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDriverProfilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDriverProfilesRequest) ProtoMessage() {}
+
+func (x *GetDriverProfilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_user_user_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDriverProfilesRequest.ProtoReflect.Descriptor instead.
+func (*GetDriverProfilesRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_user_user_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetDriverProfilesRequest) GetDriverIds() []string {
+	if x != nil {
+		return x.DriverIds
+	}
+	return nil
+}
+
+type GetDriverProfilesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Keyed by driver ID, drivers without a profile are left out
+	Profiles      map[string]*DriverProfile `protobuf:"bytes,1,rep,name=profiles,proto3" json:"profiles,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDriverProfilesResponse) Reset() {
+	*x = GetDriverProfilesResponse{}
+	mi := &file_shared_proto_user_user_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDriverProfilesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDriverProfilesResponse) ProtoMessage() {}
+
+func (x *GetDriverProfilesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_user_user_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDriverProfilesResponse.ProtoReflect.Descriptor instead.
+func (*GetDriverProfilesResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_user_user_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetDriverProfilesResponse) GetProfiles() map[string]*DriverProfile {
+	if x != nil {
+		return x.Profiles
+	}
+	return nil
+}
+
 var File_shared_proto_user_user_proto protoreflect.FileDescriptor
 
 const file_shared_proto_user_user_proto_rawDesc = "" +
@@ -1462,7 +1863,14 @@ const file_shared_proto_user_user_proto_rawDesc = "" +
 	"\x04user\x18\x01 \x01(\v2\n" +
 	".user.UserR\x04user\x12\x18\n" +
 	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage\"\x8e\x01\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"t\n" +
+	"\x17UpdateUserStatusRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12(\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x10.user.UserStatusR\x06status\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"]\n" +
+	"\x18UpdateUserStatusResponse\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12(\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x10.user.UserStatusR\x06status\"\x8e\x01\n" +
 	"\x10ListUsersRequest\x12\"\n" +
 	"\x04role\x18\x01 \x01(\x0e2\x0e.user.UserRoleR\x04role\x12(\n" +
 	"\x06status\x18\x02 \x01(\x0e2\x10.user.UserStatusR\x06status\x12\x14\n" +
@@ -1500,7 +1908,31 @@ const file_shared_proto_user_user_proto_rawDesc = "" +
 	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\"O\n" +
 	"\x11GetDriverResponse\x12$\n" +
 	"\x06driver\x18\x01 \x01(\v2\f.user.DriverR\x06driver\x12\x14\n" +
-	"\x05found\x18\x02 \x01(\bR\x05found*>\n" +
+	"\x05found\x18\x02 \x01(\bR\x05found\"?\n" +
+	" GetDriverOnboardingStatusRequest\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\"\xac\x01\n" +
+	"!GetDriverOnboardingStatusResponse\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x126\n" +
+	"\x17background_check_status\x18\x03 \x01(\tR\x15backgroundCheckStatus\x12\x1a\n" +
+	"\bapproved\x18\x04 \x01(\bR\bapproved\"\xbe\x01\n" +
+	"\rDriverProfile\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12\x1d\n" +
+	"\n" +
+	"first_name\x18\x02 \x01(\tR\tfirstName\x12\x1b\n" +
+	"\tlast_name\x18\x03 \x01(\tR\blastName\x12\x1b\n" +
+	"\tphoto_url\x18\x04 \x01(\tR\bphotoUrl\x12\x16\n" +
+	"\x06rating\x18\x05 \x01(\x01R\x06rating\x12\x1f\n" +
+	"\vtotal_trips\x18\x06 \x01(\x05R\n" +
+	"totalTrips\"9\n" +
+	"\x18GetDriverProfilesRequest\x12\x1d\n" +
+	"\n" +
+	"driver_ids\x18\x01 \x03(\tR\tdriverIds\"\xb8\x01\n" +
+	"\x19GetDriverProfilesResponse\x12I\n" +
+	"\bprofiles\x18\x01 \x03(\v2-.user.GetDriverProfilesResponse.ProfilesEntryR\bprofiles\x1aP\n" +
+	"\rProfilesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12)\n" +
+	"\x05value\x18\x02 \x01(\v2\x13.user.DriverProfileR\x05value:\x028\x01*>\n" +
 	"\bUserRole\x12\x10\n" +
 	"\fUNKNOWN_ROLE\x10\x00\x12\t\n" +
 	"\x05RIDER\x10\x01\x12\n" +
@@ -1522,16 +1954,19 @@ const file_shared_proto_user_user_proto_rawDesc = "" +
 	"\n" +
 	"\x06ONLINE\x10\x02\x12\v\n" +
 	"\aON_TRIP\x10\x03\x12\t\n" +
-	"\x05BREAK\x10\x042\xa2\x03\n" +
+	"\x05BREAK\x10\x042\xb9\x05\n" +
 	"\vUserService\x12?\n" +
 	"\n" +
 	"CreateUser\x12\x17.user.CreateUserRequest\x1a\x18.user.CreateUserResponse\x126\n" +
 	"\aGetUser\x12\x14.user.GetUserRequest\x1a\x15.user.GetUserResponse\x12?\n" +
 	"\n" +
-	"UpdateUser\x12\x17.user.UpdateUserRequest\x1a\x18.user.UpdateUserResponse\x12<\n" +
+	"UpdateUser\x12\x17.user.UpdateUserRequest\x1a\x18.user.UpdateUserResponse\x12Q\n" +
+	"\x10UpdateUserStatus\x12\x1d.user.UpdateUserStatusRequest\x1a\x1e.user.UpdateUserStatusResponse\x12<\n" +
 	"\tListUsers\x12\x16.user.ListUsersRequest\x1a\x17.user.ListUsersResponse\x12]\n" +
 	"\x14UpdateDriverLocation\x12!.user.UpdateDriverLocationRequest\x1a\".user.UpdateDriverLocationResponse\x12<\n" +
-	"\tGetDriver\x12\x16.user.GetDriverRequest\x1a\x17.user.GetDriverResponseB1Z/github.com/rideshare-platform/shared/proto/userb\x06proto3"
+	"\tGetDriver\x12\x16.user.GetDriverRequest\x1a\x17.user.GetDriverResponse\x12l\n" +
+	"\x19GetDriverOnboardingStatus\x12&.user.GetDriverOnboardingStatusRequest\x1a'.user.GetDriverOnboardingStatusResponse\x12T\n" +
+	"\x11GetDriverProfiles\x12\x1e.user.GetDriverProfilesRequest\x1a\x1f.user.GetDriverProfilesResponseB1Z/github.com/rideshare-platform/shared/proto/userb\x06proto3"
 
 var (
 	file_shared_proto_user_user_proto_rawDescOnce sync.Once
@@ -1546,36 +1981,44 @@ func file_shared_proto_user_user_proto_rawDescGZIP() []byte {
 }
 
 var file_shared_proto_user_user_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_shared_proto_user_user_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_shared_proto_user_user_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
 var file_shared_proto_user_user_proto_goTypes = []any{
-	(UserRole)(0),                        // 0: user.UserRole
-	(UserStatus)(0),                      // 1: user.UserStatus
-	(DriverStatus)(0),                    // 2: user.DriverStatus
-	(*Location)(nil),                     // 3: user.Location
-	(*User)(nil),                         // 4: user.User
-	(*UserProfile)(nil),                  // 5: user.UserProfile
-	(*UserPreferences)(nil),              // 6: user.UserPreferences
-	(*CreateUserRequest)(nil),            // 7: user.CreateUserRequest
-	(*CreateUserResponse)(nil),           // 8: user.CreateUserResponse
-	(*GetUserRequest)(nil),               // 9: user.GetUserRequest
-	(*GetUserResponse)(nil),              // 10: user.GetUserResponse
-	(*UpdateUserRequest)(nil),            // 11: user.UpdateUserRequest
-	(*UpdateUserResponse)(nil),           // 12: user.UpdateUserResponse
-	(*ListUsersRequest)(nil),             // 13: user.ListUsersRequest
-	(*ListUsersResponse)(nil),            // 14: user.ListUsersResponse
-	(*Driver)(nil),                       // 15: user.Driver
-	(*UpdateDriverLocationRequest)(nil),  // 16: user.UpdateDriverLocationRequest
-	(*UpdateDriverLocationResponse)(nil), // 17: user.UpdateDriverLocationResponse
-	(*GetDriverRequest)(nil),             // 18: user.GetDriverRequest
-	(*GetDriverResponse)(nil),            // 19: user.GetDriverResponse
-	(*timestamppb.Timestamp)(nil),        // 20: google.protobuf.Timestamp
+	(UserRole)(0),                             // 0: user.UserRole
+	(UserStatus)(0),                           // 1: user.UserStatus
+	(DriverStatus)(0),                         // 2: user.DriverStatus
+	(*Location)(nil),                          // 3: user.Location
+	(*User)(nil),                              // 4: user.User
+	(*UserProfile)(nil),                       // 5: user.UserProfile
+	(*UserPreferences)(nil),                   // 6: user.UserPreferences
+	(*CreateUserRequest)(nil),                 // 7: user.CreateUserRequest
+	(*CreateUserResponse)(nil),                // 8: user.CreateUserResponse
+	(*GetUserRequest)(nil),                    // 9: user.GetUserRequest
+	(*GetUserResponse)(nil),                   // 10: user.GetUserResponse
+	(*UpdateUserRequest)(nil),                 // 11: user.UpdateUserRequest
+	(*UpdateUserResponse)(nil),                // 12: user.UpdateUserResponse
+	(*UpdateUserStatusRequest)(nil),           // 13: user.UpdateUserStatusRequest
+	(*UpdateUserStatusResponse)(nil),          // 14: user.UpdateUserStatusResponse
+	(*ListUsersRequest)(nil),                  // 15: user.ListUsersRequest
+	(*ListUsersResponse)(nil),                 // 16: user.ListUsersResponse
+	(*Driver)(nil),                            // 17: user.Driver
+	(*UpdateDriverLocationRequest)(nil),       // 18: user.UpdateDriverLocationRequest
+	(*UpdateDriverLocationResponse)(nil),      // 19: user.UpdateDriverLocationResponse
+	(*GetDriverRequest)(nil),                  // 20: user.GetDriverRequest
+	(*GetDriverResponse)(nil),                 // 21: user.GetDriverResponse
+	(*GetDriverOnboardingStatusRequest)(nil),  // 22: user.GetDriverOnboardingStatusRequest
+	(*GetDriverOnboardingStatusResponse)(nil), // 23: user.GetDriverOnboardingStatusResponse
+	(*DriverProfile)(nil),                     // 24: user.DriverProfile
+	(*GetDriverProfilesRequest)(nil),          // 25: user.GetDriverProfilesRequest
+	(*GetDriverProfilesResponse)(nil),         // 26: user.GetDriverProfilesResponse
+	nil,                                       // 27: user.GetDriverProfilesResponse.ProfilesEntry
+	(*timestamppb.Timestamp)(nil),             // 28: google.protobuf.Timestamp
 }
 var file_shared_proto_user_user_proto_depIdxs = []int32{
-	20, // 0: user.Location.timestamp:type_name -> google.protobuf.Timestamp
+	28, // 0: user.Location.timestamp:type_name -> google.protobuf.Timestamp
 	0,  // 1: user.User.role:type_name -> user.UserRole
 	1,  // 2: user.User.status:type_name -> user.UserStatus
-	20, // 3: user.User.created_at:type_name -> google.protobuf.Timestamp
-	20, // 4: user.User.updated_at:type_name -> google.protobuf.Timestamp
+	28, // 3: user.User.created_at:type_name -> google.protobuf.Timestamp
+	28, // 4: user.User.updated_at:type_name -> google.protobuf.Timestamp
 	5,  // 5: user.User.profile:type_name -> user.UserProfile
 	6,  // 6: user.UserProfile.preferences:type_name -> user.UserPreferences
 	0,  // 7: user.CreateUserRequest.role:type_name -> user.UserRole
@@ -1583,33 +2026,43 @@ var file_shared_proto_user_user_proto_depIdxs = []int32{
 	4,  // 9: user.GetUserResponse.user:type_name -> user.User
 	4,  // 10: user.UpdateUserRequest.user:type_name -> user.User
 	4,  // 11: user.UpdateUserResponse.user:type_name -> user.User
-	0,  // 12: user.ListUsersRequest.role:type_name -> user.UserRole
-	1,  // 13: user.ListUsersRequest.status:type_name -> user.UserStatus
-	4,  // 14: user.ListUsersResponse.users:type_name -> user.User
-	20, // 15: user.Driver.license_expiry:type_name -> google.protobuf.Timestamp
-	2,  // 16: user.Driver.status:type_name -> user.DriverStatus
-	3,  // 17: user.Driver.current_location:type_name -> user.Location
-	20, // 18: user.Driver.last_active:type_name -> google.protobuf.Timestamp
-	3,  // 19: user.UpdateDriverLocationRequest.location:type_name -> user.Location
-	2,  // 20: user.UpdateDriverLocationRequest.status:type_name -> user.DriverStatus
-	15, // 21: user.GetDriverResponse.driver:type_name -> user.Driver
-	7,  // 22: user.UserService.CreateUser:input_type -> user.CreateUserRequest
-	9,  // 23: user.UserService.GetUser:input_type -> user.GetUserRequest
-	11, // 24: user.UserService.UpdateUser:input_type -> user.UpdateUserRequest
-	13, // 25: user.UserService.ListUsers:input_type -> user.ListUsersRequest
-	16, // 26: user.UserService.UpdateDriverLocation:input_type -> user.UpdateDriverLocationRequest
-	18, // 27: user.UserService.GetDriver:input_type -> user.GetDriverRequest
-	8,  // 28: user.UserService.CreateUser:output_type -> user.CreateUserResponse
-	10, // 29: user.UserService.GetUser:output_type -> user.GetUserResponse
-	12, // 30: user.UserService.UpdateUser:output_type -> user.UpdateUserResponse
-	14, // 31: user.UserService.ListUsers:output_type -> user.ListUsersResponse
-	17, // 32: user.UserService.UpdateDriverLocation:output_type -> user.UpdateDriverLocationResponse
-	19, // 33: user.UserService.GetDriver:output_type -> user.GetDriverResponse
-	28, // [28:34] is the sub-list for method output_type
-	22, // [22:28] is the sub-list for method input_type
-	22, // [22:22] is the sub-list for extension type_name
-	22, // [22:22] is the sub-list for extension extendee
-	0,  // [0:22] is the sub-list for field type_name
+	1,  // 12: user.UpdateUserStatusRequest.status:type_name -> user.UserStatus
+	1,  // 13: user.UpdateUserStatusResponse.status:type_name -> user.UserStatus
+	0,  // 14: user.ListUsersRequest.role:type_name -> user.UserRole
+	1,  // 15: user.ListUsersRequest.status:type_name -> user.UserStatus
+	4,  // 16: user.ListUsersResponse.users:type_name -> user.User
+	28, // 17: user.Driver.license_expiry:type_name -> google.protobuf.Timestamp
+	2,  // 18: user.Driver.status:type_name -> user.DriverStatus
+	3,  // 19: user.Driver.current_location:type_name -> user.Location
+	28, // 20: user.Driver.last_active:type_name -> google.protobuf.Timestamp
+	3,  // 21: user.UpdateDriverLocationRequest.location:type_name -> user.Location
+	2,  // 22: user.UpdateDriverLocationRequest.status:type_name -> user.DriverStatus
+	17, // 23: user.GetDriverResponse.driver:type_name -> user.Driver
+	27, // 24: user.GetDriverProfilesResponse.profiles:type_name -> user.GetDriverProfilesResponse.ProfilesEntry
+	24, // 25: user.GetDriverProfilesResponse.ProfilesEntry.value:type_name -> user.DriverProfile
+	7,  // 26: user.UserService.CreateUser:input_type -> user.CreateUserRequest
+	9,  // 27: user.UserService.GetUser:input_type -> user.GetUserRequest
+	11, // 28: user.UserService.UpdateUser:input_type -> user.UpdateUserRequest
+	13, // 29: user.UserService.UpdateUserStatus:input_type -> user.UpdateUserStatusRequest
+	15, // 30: user.UserService.ListUsers:input_type -> user.ListUsersRequest
+	18, // 31: user.UserService.UpdateDriverLocation:input_type -> user.UpdateDriverLocationRequest
+	20, // 32: user.UserService.GetDriver:input_type -> user.GetDriverRequest
+	22, // 33: user.UserService.GetDriverOnboardingStatus:input_type -> user.GetDriverOnboardingStatusRequest
+	25, // 34: user.UserService.GetDriverProfiles:input_type -> user.GetDriverProfilesRequest
+	8,  // 35: user.UserService.CreateUser:output_type -> user.CreateUserResponse
+	10, // 36: user.UserService.GetUser:output_type -> user.GetUserResponse
+	12, // 37: user.UserService.UpdateUser:output_type -> user.UpdateUserResponse
+	14, // 38: user.UserService.UpdateUserStatus:output_type -> user.UpdateUserStatusResponse
+	16, // 39: user.UserService.ListUsers:output_type -> user.ListUsersResponse
+	19, // 40: user.UserService.UpdateDriverLocation:output_type -> user.UpdateDriverLocationResponse
+	21, // 41: user.UserService.GetDriver:output_type -> user.GetDriverResponse
+	23, // 42: user.UserService.GetDriverOnboardingStatus:output_type -> user.GetDriverOnboardingStatusResponse
+	26, // 43: user.UserService.GetDriverProfiles:output_type -> user.GetDriverProfilesResponse
+	35, // [35:44] is the sub-list for method output_type
+	26, // [26:35] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
 }
 
 func init() { file_shared_proto_user_user_proto_init() }
@@ -1623,7 +2076,7 @@ func file_shared_proto_user_user_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_shared_proto_user_user_proto_rawDesc), len(file_shared_proto_user_user_proto_rawDesc)),
 			NumEnums:      3,
-			NumMessages:   17,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
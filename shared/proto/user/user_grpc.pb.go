@@ -19,12 +19,15 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	UserService_CreateUser_FullMethodName           = "/user.UserService/CreateUser"
-	UserService_GetUser_FullMethodName              = "/user.UserService/GetUser"
-	UserService_UpdateUser_FullMethodName           = "/user.UserService/UpdateUser"
-	UserService_ListUsers_FullMethodName            = "/user.UserService/ListUsers"
-	UserService_UpdateDriverLocation_FullMethodName = "/user.UserService/UpdateDriverLocation"
-	UserService_GetDriver_FullMethodName            = "/user.UserService/GetDriver"
+	UserService_CreateUser_FullMethodName                = "/user.UserService/CreateUser"
+	UserService_GetUser_FullMethodName                   = "/user.UserService/GetUser"
+	UserService_UpdateUser_FullMethodName                = "/user.UserService/UpdateUser"
+	UserService_UpdateUserStatus_FullMethodName          = "/user.UserService/UpdateUserStatus"
+	UserService_ListUsers_FullMethodName                 = "/user.UserService/ListUsers"
+	UserService_UpdateDriverLocation_FullMethodName      = "/user.UserService/UpdateDriverLocation"
+	UserService_GetDriver_FullMethodName                 = "/user.UserService/GetDriver"
+	UserService_GetDriverOnboardingStatus_FullMethodName = "/user.UserService/GetDriverOnboardingStatus"
+	UserService_GetDriverProfiles_FullMethodName         = "/user.UserService/GetDriverProfiles"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -36,10 +39,13 @@ type UserServiceClient interface {
 	CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*CreateUserResponse, error)
 	GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*GetUserResponse, error)
 	UpdateUser(ctx context.Context, in *UpdateUserRequest, opts ...grpc.CallOption) (*UpdateUserResponse, error)
+	UpdateUserStatus(ctx context.Context, in *UpdateUserStatusRequest, opts ...grpc.CallOption) (*UpdateUserStatusResponse, error)
 	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error)
 	// Driver-specific methods
 	UpdateDriverLocation(ctx context.Context, in *UpdateDriverLocationRequest, opts ...grpc.CallOption) (*UpdateDriverLocationResponse, error)
 	GetDriver(ctx context.Context, in *GetDriverRequest, opts ...grpc.CallOption) (*GetDriverResponse, error)
+	GetDriverOnboardingStatus(ctx context.Context, in *GetDriverOnboardingStatusRequest, opts ...grpc.CallOption) (*GetDriverOnboardingStatusResponse, error)
+	GetDriverProfiles(ctx context.Context, in *GetDriverProfilesRequest, opts ...grpc.CallOption) (*GetDriverProfilesResponse, error)
 }
 
 type userServiceClient struct {
@@ -80,6 +86,16 @@ func (c *userServiceClient) UpdateUser(ctx context.Context, in *UpdateUserReques
 	return out, nil
 }
 
+func (c *userServiceClient) UpdateUserStatus(ctx context.Context, in *UpdateUserStatusRequest, opts ...grpc.CallOption) (*UpdateUserStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateUserStatusResponse)
+	err := c.cc.Invoke(ctx, UserService_UpdateUserStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListUsersResponse)
@@ -110,6 +126,26 @@ func (c *userServiceClient) GetDriver(ctx context.Context, in *GetDriverRequest,
 	return out, nil
 }
 
+func (c *userServiceClient) GetDriverOnboardingStatus(ctx context.Context, in *GetDriverOnboardingStatusRequest, opts ...grpc.CallOption) (*GetDriverOnboardingStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDriverOnboardingStatusResponse)
+	err := c.cc.Invoke(ctx, UserService_GetDriverOnboardingStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) GetDriverProfiles(ctx context.Context, in *GetDriverProfilesRequest, opts ...grpc.CallOption) (*GetDriverProfilesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDriverProfilesResponse)
+	err := c.cc.Invoke(ctx, UserService_GetDriverProfiles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations must embed UnimplementedUserServiceServer
 // for forward compatibility.
@@ -119,10 +155,13 @@ type UserServiceServer interface {
 	CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error)
 	GetUser(context.Context, *GetUserRequest) (*GetUserResponse, error)
 	UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error)
+	UpdateUserStatus(context.Context, *UpdateUserStatusRequest) (*UpdateUserStatusResponse, error)
 	ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error)
 	// Driver-specific methods
 	UpdateDriverLocation(context.Context, *UpdateDriverLocationRequest) (*UpdateDriverLocationResponse, error)
 	GetDriver(context.Context, *GetDriverRequest) (*GetDriverResponse, error)
+	GetDriverOnboardingStatus(context.Context, *GetDriverOnboardingStatusRequest) (*GetDriverOnboardingStatusResponse, error)
+	GetDriverProfiles(context.Context, *GetDriverProfilesRequest) (*GetDriverProfilesResponse, error)
 	mustEmbedUnimplementedUserServiceServer()
 }
 
@@ -142,6 +181,9 @@ func (UnimplementedUserServiceServer) GetUser(context.Context, *GetUserRequest)
 func (UnimplementedUserServiceServer) UpdateUser(context.Context, *UpdateUserRequest) (*UpdateUserResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateUser not implemented")
 }
+func (UnimplementedUserServiceServer) UpdateUserStatus(context.Context, *UpdateUserStatusRequest) (*UpdateUserStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateUserStatus not implemented")
+}
 func (UnimplementedUserServiceServer) ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
 }
@@ -151,6 +193,12 @@ func (UnimplementedUserServiceServer) UpdateDriverLocation(context.Context, *Upd
 func (UnimplementedUserServiceServer) GetDriver(context.Context, *GetDriverRequest) (*GetDriverResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetDriver not implemented")
 }
+func (UnimplementedUserServiceServer) GetDriverOnboardingStatus(context.Context, *GetDriverOnboardingStatusRequest) (*GetDriverOnboardingStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDriverOnboardingStatus not implemented")
+}
+func (UnimplementedUserServiceServer) GetDriverProfiles(context.Context, *GetDriverProfilesRequest) (*GetDriverProfilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDriverProfiles not implemented")
+}
 func (UnimplementedUserServiceServer) mustEmbedUnimplementedUserServiceServer() {}
 func (UnimplementedUserServiceServer) testEmbeddedByValue()                     {}
 
@@ -226,6 +274,24 @@ func _UserService_UpdateUser_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_UpdateUserStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateUserStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UpdateUserStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_UpdateUserStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UpdateUserStatus(ctx, req.(*UpdateUserStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListUsersRequest)
 	if err := dec(in); err != nil {
@@ -280,6 +346,42 @@ func _UserService_GetDriver_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_GetDriverOnboardingStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDriverOnboardingStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetDriverOnboardingStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetDriverOnboardingStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetDriverOnboardingStatus(ctx, req.(*GetDriverOnboardingStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_GetDriverProfiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDriverProfilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetDriverProfiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetDriverProfiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetDriverProfiles(ctx, req.(*GetDriverProfilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -299,6 +401,10 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateUser",
 			Handler:    _UserService_UpdateUser_Handler,
 		},
+		{
+			MethodName: "UpdateUserStatus",
+			Handler:    _UserService_UpdateUserStatus_Handler,
+		},
 		{
 			MethodName: "ListUsers",
 			Handler:    _UserService_ListUsers_Handler,
@@ -311,6 +417,14 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetDriver",
 			Handler:    _UserService_GetDriver_Handler,
 		},
+		{
+			MethodName: "GetDriverOnboardingStatus",
+			Handler:    _UserService_GetDriverOnboardingStatus_Handler,
+		},
+		{
+			MethodName: "GetDriverProfiles",
+			Handler:    _UserService_GetDriverProfiles_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "shared/proto/user/user.proto",
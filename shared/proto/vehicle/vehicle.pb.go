@@ -0,0 +1,957 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.8
+// 	protoc        v3.21.12
+// source: shared/proto/vehicle/vehicle.proto
+
+package vehicle
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Vehicle represents a driver's registered vehicle
+type Vehicle struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Id                    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DriverId              string                 `protobuf:"bytes,2,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	Make                  string                 `protobuf:"bytes,3,opt,name=make,proto3" json:"make,omitempty"`
+	Model                 string                 `protobuf:"bytes,4,opt,name=model,proto3" json:"model,omitempty"`
+	Year                  int32                  `protobuf:"varint,5,opt,name=year,proto3" json:"year,omitempty"`
+	Color                 string                 `protobuf:"bytes,6,opt,name=color,proto3" json:"color,omitempty"`
+	LicensePlate          string                 `protobuf:"bytes,7,opt,name=license_plate,json=licensePlate,proto3" json:"license_plate,omitempty"`
+	VehicleType           string                 `protobuf:"bytes,8,opt,name=vehicle_type,json=vehicleType,proto3" json:"vehicle_type,omitempty"`
+	Status                string                 `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
+	Capacity              int32                  `protobuf:"varint,10,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	InsurancePolicyNumber string                 `protobuf:"bytes,11,opt,name=insurance_policy_number,json=insurancePolicyNumber,proto3" json:"insurance_policy_number,omitempty"`
+	InsuranceExpiry       *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=insurance_expiry,json=insuranceExpiry,proto3" json:"insurance_expiry,omitempty"`
+	RegistrationExpiry    *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=registration_expiry,json=registrationExpiry,proto3" json:"registration_expiry,omitempty"`
+	CreatedAt             *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt             *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Amenities and accessibility features, e.g. "wheelchair_accessible"
+	Features []string `protobuf:"bytes,16,rep,name=features,proto3" json:"features,omitempty"`
+	// Signed URL of the vehicle's photo at medium size, empty without a photo
+	PhotoUrl      string `protobuf:"bytes,17,opt,name=photo_url,json=photoUrl,proto3" json:"photo_url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Vehicle) Reset() {
+	*x = Vehicle{}
+	mi := &file_shared_proto_vehicle_vehicle_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Vehicle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Vehicle) ProtoMessage() {}
+
+func (x *Vehicle) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_vehicle_vehicle_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Vehicle.ProtoReflect.Descriptor instead.
+func (*Vehicle) Descriptor() ([]byte, []int) {
+	return file_shared_proto_vehicle_vehicle_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Vehicle) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Vehicle) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *Vehicle) GetMake() string {
+	if x != nil {
+		return x.Make
+	}
+	return ""
+}
+
+func (x *Vehicle) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *Vehicle) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *Vehicle) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *Vehicle) GetLicensePlate() string {
+	if x != nil {
+		return x.LicensePlate
+	}
+	return ""
+}
+
+func (x *Vehicle) GetVehicleType() string {
+	if x != nil {
+		return x.VehicleType
+	}
+	return ""
+}
+
+func (x *Vehicle) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Vehicle) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *Vehicle) GetInsurancePolicyNumber() string {
+	if x != nil {
+		return x.InsurancePolicyNumber
+	}
+	return ""
+}
+
+func (x *Vehicle) GetInsuranceExpiry() *timestamppb.Timestamp {
+	if x != nil {
+		return x.InsuranceExpiry
+	}
+	return nil
+}
+
+func (x *Vehicle) GetRegistrationExpiry() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RegistrationExpiry
+	}
+	return nil
+}
+
+func (x *Vehicle) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Vehicle) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Vehicle) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *Vehicle) GetPhotoUrl() string {
+	if x != nil {
+		return x.PhotoUrl
+	}
+	return ""
+}
+
+type CreateVehicleRequest struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	DriverId              string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	Make                  string                 `protobuf:"bytes,2,opt,name=make,proto3" json:"make,omitempty"`
+	Model                 string                 `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`
+	Year                  int32                  `protobuf:"varint,4,opt,name=year,proto3" json:"year,omitempty"`
+	Color                 string                 `protobuf:"bytes,5,opt,name=color,proto3" json:"color,omitempty"`
+	LicensePlate          string                 `protobuf:"bytes,6,opt,name=license_plate,json=licensePlate,proto3" json:"license_plate,omitempty"`
+	VehicleType           string                 `protobuf:"bytes,7,opt,name=vehicle_type,json=vehicleType,proto3" json:"vehicle_type,omitempty"`
+	Capacity              int32                  `protobuf:"varint,8,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	InsurancePolicyNumber string                 `protobuf:"bytes,9,opt,name=insurance_policy_number,json=insurancePolicyNumber,proto3" json:"insurance_policy_number,omitempty"`
+	InsuranceExpiry       *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=insurance_expiry,json=insuranceExpiry,proto3" json:"insurance_expiry,omitempty"`
+	RegistrationExpiry    *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=registration_expiry,json=registrationExpiry,proto3" json:"registration_expiry,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *CreateVehicleRequest) Reset() {
+	*x = CreateVehicleRequest{}
+	mi := &file_shared_proto_vehicle_vehicle_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateVehicleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateVehicleRequest) ProtoMessage() {}
+
+func (x *CreateVehicleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_vehicle_vehicle_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateVehicleRequest.ProtoReflect.Descriptor instead.
+func (*CreateVehicleRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_vehicle_vehicle_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateVehicleRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *CreateVehicleRequest) GetMake() string {
+	if x != nil {
+		return x.Make
+	}
+	return ""
+}
+
+func (x *CreateVehicleRequest) GetModel() string {
+	if x != nil {
+		return x.Model
+	}
+	return ""
+}
+
+func (x *CreateVehicleRequest) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *CreateVehicleRequest) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *CreateVehicleRequest) GetLicensePlate() string {
+	if x != nil {
+		return x.LicensePlate
+	}
+	return ""
+}
+
+func (x *CreateVehicleRequest) GetVehicleType() string {
+	if x != nil {
+		return x.VehicleType
+	}
+	return ""
+}
+
+func (x *CreateVehicleRequest) GetCapacity() int32 {
+	if x != nil {
+		return x.Capacity
+	}
+	return 0
+}
+
+func (x *CreateVehicleRequest) GetInsurancePolicyNumber() string {
+	if x != nil {
+		return x.InsurancePolicyNumber
+	}
+	return ""
+}
+
+func (x *CreateVehicleRequest) GetInsuranceExpiry() *timestamppb.Timestamp {
+	if x != nil {
+		return x.InsuranceExpiry
+	}
+	return nil
+}
+
+func (x *CreateVehicleRequest) GetRegistrationExpiry() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RegistrationExpiry
+	}
+	return nil
+}
+
+type GetVehicleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VehicleId     string                 `protobuf:"bytes,1,opt,name=vehicle_id,json=vehicleId,proto3" json:"vehicle_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVehicleRequest) Reset() {
+	*x = GetVehicleRequest{}
+	mi := &file_shared_proto_vehicle_vehicle_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVehicleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVehicleRequest) ProtoMessage() {}
+
+func (x *GetVehicleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_vehicle_vehicle_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVehicleRequest.ProtoReflect.Descriptor instead.
+func (*GetVehicleRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_vehicle_vehicle_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetVehicleRequest) GetVehicleId() string {
+	if x != nil {
+		return x.VehicleId
+	}
+	return ""
+}
+
+type VehicleResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vehicle       *Vehicle               `protobuf:"bytes,1,opt,name=vehicle,proto3" json:"vehicle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VehicleResponse) Reset() {
+	*x = VehicleResponse{}
+	mi := &file_shared_proto_vehicle_vehicle_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VehicleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VehicleResponse) ProtoMessage() {}
+
+func (x *VehicleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_vehicle_vehicle_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VehicleResponse.ProtoReflect.Descriptor instead.
+func (*VehicleResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_vehicle_vehicle_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *VehicleResponse) GetVehicle() *Vehicle {
+	if x != nil {
+		return x.Vehicle
+	}
+	return nil
+}
+
+// Lists vehicles a page at a time. The first page is read without a cursor,
+// the following ones with the next_cursor of the page before.
+type ListVehiclesRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Limit       int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Status      string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	VehicleType string                 `protobuf:"bytes,4,opt,name=vehicle_type,json=vehicleType,proto3" json:"vehicle_type,omitempty"`
+	Cursor      string                 `protobuf:"bytes,5,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// Field to sort on, "-" prefixed for descending; newest first by default
+	Sort          string `protobuf:"bytes,6,opt,name=sort,proto3" json:"sort,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVehiclesRequest) Reset() {
+	*x = ListVehiclesRequest{}
+	mi := &file_shared_proto_vehicle_vehicle_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVehiclesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVehiclesRequest) ProtoMessage() {}
+
+func (x *ListVehiclesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_vehicle_vehicle_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVehiclesRequest.ProtoReflect.Descriptor instead.
+func (*ListVehiclesRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_vehicle_vehicle_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListVehiclesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListVehiclesRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListVehiclesRequest) GetVehicleType() string {
+	if x != nil {
+		return x.VehicleType
+	}
+	return ""
+}
+
+func (x *ListVehiclesRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListVehiclesRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+type ListVehiclesResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Vehicles []*Vehicle             `protobuf:"bytes,1,rep,name=vehicles,proto3" json:"vehicles,omitempty"`
+	// About how many vehicles match across all pages
+	Total int64 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Limit int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Empty on the last page
+	NextCursor    string `protobuf:"bytes,5,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVehiclesResponse) Reset() {
+	*x = ListVehiclesResponse{}
+	mi := &file_shared_proto_vehicle_vehicle_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVehiclesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVehiclesResponse) ProtoMessage() {}
+
+func (x *ListVehiclesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_vehicle_vehicle_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVehiclesResponse.ProtoReflect.Descriptor instead.
+func (*ListVehiclesResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_vehicle_vehicle_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListVehiclesResponse) GetVehicles() []*Vehicle {
+	if x != nil {
+		return x.Vehicles
+	}
+	return nil
+}
+
+func (x *ListVehiclesResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListVehiclesResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListVehiclesResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type UpdateVehicleStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	VehicleId     string                 `protobuf:"bytes,1,opt,name=vehicle_id,json=vehicleId,proto3" json:"vehicle_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateVehicleStatusRequest) Reset() {
+	*x = UpdateVehicleStatusRequest{}
+	mi := &file_shared_proto_vehicle_vehicle_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateVehicleStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateVehicleStatusRequest) ProtoMessage() {}
+
+func (x *UpdateVehicleStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_vehicle_vehicle_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateVehicleStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateVehicleStatusRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_vehicle_vehicle_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateVehicleStatusRequest) GetVehicleId() string {
+	if x != nil {
+		return x.VehicleId
+	}
+	return ""
+}
+
+func (x *UpdateVehicleStatusRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type GetVehiclesByDriverRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	DriverId string                 `protobuf:"bytes,1,opt,name=driver_id,json=driverId,proto3" json:"driver_id,omitempty"`
+	// Only return vehicles that are active and can take trips
+	AvailableOnly bool `protobuf:"varint,2,opt,name=available_only,json=availableOnly,proto3" json:"available_only,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVehiclesByDriverRequest) Reset() {
+	*x = GetVehiclesByDriverRequest{}
+	mi := &file_shared_proto_vehicle_vehicle_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVehiclesByDriverRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVehiclesByDriverRequest) ProtoMessage() {}
+
+func (x *GetVehiclesByDriverRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_vehicle_vehicle_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVehiclesByDriverRequest.ProtoReflect.Descriptor instead.
+func (*GetVehiclesByDriverRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_vehicle_vehicle_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetVehiclesByDriverRequest) GetDriverId() string {
+	if x != nil {
+		return x.DriverId
+	}
+	return ""
+}
+
+func (x *GetVehiclesByDriverRequest) GetAvailableOnly() bool {
+	if x != nil {
+		return x.AvailableOnly
+	}
+	return false
+}
+
+type GetVehiclesByDriverResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vehicles      []*Vehicle             `protobuf:"bytes,1,rep,name=vehicles,proto3" json:"vehicles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVehiclesByDriverResponse) Reset() {
+	*x = GetVehiclesByDriverResponse{}
+	mi := &file_shared_proto_vehicle_vehicle_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVehiclesByDriverResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVehiclesByDriverResponse) ProtoMessage() {}
+
+func (x *GetVehiclesByDriverResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_vehicle_vehicle_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVehiclesByDriverResponse.ProtoReflect.Descriptor instead.
+func (*GetVehiclesByDriverResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_vehicle_vehicle_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetVehiclesByDriverResponse) GetVehicles() []*Vehicle {
+	if x != nil {
+		return x.Vehicles
+	}
+	return nil
+}
+
+type GetAvailableVehiclesByDriversRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DriverIds     []string               `protobuf:"bytes,1,rep,name=driver_ids,json=driverIds,proto3" json:"driver_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAvailableVehiclesByDriversRequest) Reset() {
+	*x = GetAvailableVehiclesByDriversRequest{}
+	mi := &file_shared_proto_vehicle_vehicle_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAvailableVehiclesByDriversRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAvailableVehiclesByDriversRequest) ProtoMessage() {}
+
+func (x *GetAvailableVehiclesByDriversRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_vehicle_vehicle_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAvailableVehiclesByDriversRequest.ProtoReflect.Descriptor instead.
+func (*GetAvailableVehiclesByDriversRequest) Descriptor() ([]byte, []int) {
+	return file_shared_proto_vehicle_vehicle_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetAvailableVehiclesByDriversRequest) GetDriverIds() []string {
+	if x != nil {
+		return x.DriverIds
+	}
+	return nil
+}
+
+type GetAvailableVehiclesByDriversResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Newest first for each driver
+	Vehicles      []*Vehicle `protobuf:"bytes,1,rep,name=vehicles,proto3" json:"vehicles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAvailableVehiclesByDriversResponse) Reset() {
+	*x = GetAvailableVehiclesByDriversResponse{}
+	mi := &file_shared_proto_vehicle_vehicle_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAvailableVehiclesByDriversResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAvailableVehiclesByDriversResponse) ProtoMessage() {}
+
+func (x *GetAvailableVehiclesByDriversResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_shared_proto_vehicle_vehicle_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAvailableVehiclesByDriversResponse.ProtoReflect.Descriptor instead.
+func (*GetAvailableVehiclesByDriversResponse) Descriptor() ([]byte, []int) {
+	return file_shared_proto_vehicle_vehicle_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetAvailableVehiclesByDriversResponse) GetVehicles() []*Vehicle {
+	if x != nil {
+		return x.Vehicles
+	}
+	return nil
+}
+
+var File_shared_proto_vehicle_vehicle_proto protoreflect.FileDescriptor
+
+const file_shared_proto_vehicle_vehicle_proto_rawDesc = "" +
+	"\n" +
+	"\"shared/proto/vehicle/vehicle.proto\x12\avehicle\x1a\x1fgoogle/protobuf/timestamp.proto\"\x81\x05\n" +
+	"\aVehicle\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tdriver_id\x18\x02 \x01(\tR\bdriverId\x12\x12\n" +
+	"\x04make\x18\x03 \x01(\tR\x04make\x12\x14\n" +
+	"\x05model\x18\x04 \x01(\tR\x05model\x12\x12\n" +
+	"\x04year\x18\x05 \x01(\x05R\x04year\x12\x14\n" +
+	"\x05color\x18\x06 \x01(\tR\x05color\x12#\n" +
+	"\rlicense_plate\x18\a \x01(\tR\flicensePlate\x12!\n" +
+	"\fvehicle_type\x18\b \x01(\tR\vvehicleType\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x12\x1a\n" +
+	"\bcapacity\x18\n" +
+	" \x01(\x05R\bcapacity\x126\n" +
+	"\x17insurance_policy_number\x18\v \x01(\tR\x15insurancePolicyNumber\x12E\n" +
+	"\x10insurance_expiry\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\x0finsuranceExpiry\x12K\n" +
+	"\x13registration_expiry\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\x12registrationExpiry\x129\n" +
+	"\n" +
+	"created_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1a\n" +
+	"\bfeatures\x18\x10 \x03(\tR\bfeatures\x12\x1b\n" +
+	"\tphoto_url\x18\x11 \x01(\tR\bphotoUrl\"\xb7\x03\n" +
+	"\x14CreateVehicleRequest\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12\x12\n" +
+	"\x04make\x18\x02 \x01(\tR\x04make\x12\x14\n" +
+	"\x05model\x18\x03 \x01(\tR\x05model\x12\x12\n" +
+	"\x04year\x18\x04 \x01(\x05R\x04year\x12\x14\n" +
+	"\x05color\x18\x05 \x01(\tR\x05color\x12#\n" +
+	"\rlicense_plate\x18\x06 \x01(\tR\flicensePlate\x12!\n" +
+	"\fvehicle_type\x18\a \x01(\tR\vvehicleType\x12\x1a\n" +
+	"\bcapacity\x18\b \x01(\x05R\bcapacity\x126\n" +
+	"\x17insurance_policy_number\x18\t \x01(\tR\x15insurancePolicyNumber\x12E\n" +
+	"\x10insurance_expiry\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\x0finsuranceExpiry\x12K\n" +
+	"\x13registration_expiry\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\x12registrationExpiry\"2\n" +
+	"\x11GetVehicleRequest\x12\x1d\n" +
+	"\n" +
+	"vehicle_id\x18\x01 \x01(\tR\tvehicleId\"=\n" +
+	"\x0fVehicleResponse\x12*\n" +
+	"\avehicle\x18\x01 \x01(\v2\x10.vehicle.VehicleR\avehicle\"\xa0\x01\n" +
+	"\x13ListVehiclesRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12!\n" +
+	"\fvehicle_type\x18\x04 \x01(\tR\vvehicleType\x12\x16\n" +
+	"\x06cursor\x18\x05 \x01(\tR\x06cursor\x12\x12\n" +
+	"\x04sort\x18\x06 \x01(\tR\x04sortJ\x04\b\x02\x10\x03R\x06offset\"\x9f\x01\n" +
+	"\x14ListVehiclesResponse\x12,\n" +
+	"\bvehicles\x18\x01 \x03(\v2\x10.vehicle.VehicleR\bvehicles\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x1f\n" +
+	"\vnext_cursor\x18\x05 \x01(\tR\n" +
+	"nextCursorJ\x04\b\x04\x10\x05R\x06offset\"S\n" +
+	"\x1aUpdateVehicleStatusRequest\x12\x1d\n" +
+	"\n" +
+	"vehicle_id\x18\x01 \x01(\tR\tvehicleId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\"`\n" +
+	"\x1aGetVehiclesByDriverRequest\x12\x1b\n" +
+	"\tdriver_id\x18\x01 \x01(\tR\bdriverId\x12%\n" +
+	"\x0eavailable_only\x18\x02 \x01(\bR\ravailableOnly\"K\n" +
+	"\x1bGetVehiclesByDriverResponse\x12,\n" +
+	"\bvehicles\x18\x01 \x03(\v2\x10.vehicle.VehicleR\bvehicles\"E\n" +
+	"$GetAvailableVehiclesByDriversRequest\x12\x1d\n" +
+	"\n" +
+	"driver_ids\x18\x01 \x03(\tR\tdriverIds\"U\n" +
+	"%GetAvailableVehiclesByDriversResponse\x12,\n" +
+	"\bvehicles\x18\x01 \x03(\v2\x10.vehicle.VehicleR\bvehicles2\x9c\x04\n" +
+	"\x0eVehicleService\x12H\n" +
+	"\rCreateVehicle\x12\x1d.vehicle.CreateVehicleRequest\x1a\x18.vehicle.VehicleResponse\x12B\n" +
+	"\n" +
+	"GetVehicle\x12\x1a.vehicle.GetVehicleRequest\x1a\x18.vehicle.VehicleResponse\x12K\n" +
+	"\fListVehicles\x12\x1c.vehicle.ListVehiclesRequest\x1a\x1d.vehicle.ListVehiclesResponse\x12M\n" +
+	"\fUpdateStatus\x12#.vehicle.UpdateVehicleStatusRequest\x1a\x18.vehicle.VehicleResponse\x12`\n" +
+	"\x13GetVehiclesByDriver\x12#.vehicle.GetVehiclesByDriverRequest\x1a$.vehicle.GetVehiclesByDriverResponse\x12~\n" +
+	"\x1dGetAvailableVehiclesByDrivers\x12-.vehicle.GetAvailableVehiclesByDriversRequest\x1a..vehicle.GetAvailableVehiclesByDriversResponseB4Z2github.com/rideshare-platform/shared/proto/vehicleb\x06proto3"
+
+var (
+	file_shared_proto_vehicle_vehicle_proto_rawDescOnce sync.Once
+	file_shared_proto_vehicle_vehicle_proto_rawDescData []byte
+)
+
+func file_shared_proto_vehicle_vehicle_proto_rawDescGZIP() []byte {
+	file_shared_proto_vehicle_vehicle_proto_rawDescOnce.Do(func() {
+		file_shared_proto_vehicle_vehicle_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_shared_proto_vehicle_vehicle_proto_rawDesc), len(file_shared_proto_vehicle_vehicle_proto_rawDesc)))
+	})
+	return file_shared_proto_vehicle_vehicle_proto_rawDescData
+}
+
+var file_shared_proto_vehicle_vehicle_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_shared_proto_vehicle_vehicle_proto_goTypes = []any{
+	(*Vehicle)(nil),                               // 0: vehicle.Vehicle
+	(*CreateVehicleRequest)(nil),                  // 1: vehicle.CreateVehicleRequest
+	(*GetVehicleRequest)(nil),                     // 2: vehicle.GetVehicleRequest
+	(*VehicleResponse)(nil),                       // 3: vehicle.VehicleResponse
+	(*ListVehiclesRequest)(nil),                   // 4: vehicle.ListVehiclesRequest
+	(*ListVehiclesResponse)(nil),                  // 5: vehicle.ListVehiclesResponse
+	(*UpdateVehicleStatusRequest)(nil),            // 6: vehicle.UpdateVehicleStatusRequest
+	(*GetVehiclesByDriverRequest)(nil),            // 7: vehicle.GetVehiclesByDriverRequest
+	(*GetVehiclesByDriverResponse)(nil),           // 8: vehicle.GetVehiclesByDriverResponse
+	(*GetAvailableVehiclesByDriversRequest)(nil),  // 9: vehicle.GetAvailableVehiclesByDriversRequest
+	(*GetAvailableVehiclesByDriversResponse)(nil), // 10: vehicle.GetAvailableVehiclesByDriversResponse
+	(*timestamppb.Timestamp)(nil),                 // 11: google.protobuf.Timestamp
+}
+var file_shared_proto_vehicle_vehicle_proto_depIdxs = []int32{
+	11, // 0: vehicle.Vehicle.insurance_expiry:type_name -> google.protobuf.Timestamp
+	11, // 1: vehicle.Vehicle.registration_expiry:type_name -> google.protobuf.Timestamp
+	11, // 2: vehicle.Vehicle.created_at:type_name -> google.protobuf.Timestamp
+	11, // 3: vehicle.Vehicle.updated_at:type_name -> google.protobuf.Timestamp
+	11, // 4: vehicle.CreateVehicleRequest.insurance_expiry:type_name -> google.protobuf.Timestamp
+	11, // 5: vehicle.CreateVehicleRequest.registration_expiry:type_name -> google.protobuf.Timestamp
+	0,  // 6: vehicle.VehicleResponse.vehicle:type_name -> vehicle.Vehicle
+	0,  // 7: vehicle.ListVehiclesResponse.vehicles:type_name -> vehicle.Vehicle
+	0,  // 8: vehicle.GetVehiclesByDriverResponse.vehicles:type_name -> vehicle.Vehicle
+	0,  // 9: vehicle.GetAvailableVehiclesByDriversResponse.vehicles:type_name -> vehicle.Vehicle
+	1,  // 10: vehicle.VehicleService.CreateVehicle:input_type -> vehicle.CreateVehicleRequest
+	2,  // 11: vehicle.VehicleService.GetVehicle:input_type -> vehicle.GetVehicleRequest
+	4,  // 12: vehicle.VehicleService.ListVehicles:input_type -> vehicle.ListVehiclesRequest
+	6,  // 13: vehicle.VehicleService.UpdateStatus:input_type -> vehicle.UpdateVehicleStatusRequest
+	7,  // 14: vehicle.VehicleService.GetVehiclesByDriver:input_type -> vehicle.GetVehiclesByDriverRequest
+	9,  // 15: vehicle.VehicleService.GetAvailableVehiclesByDrivers:input_type -> vehicle.GetAvailableVehiclesByDriversRequest
+	3,  // 16: vehicle.VehicleService.CreateVehicle:output_type -> vehicle.VehicleResponse
+	3,  // 17: vehicle.VehicleService.GetVehicle:output_type -> vehicle.VehicleResponse
+	5,  // 18: vehicle.VehicleService.ListVehicles:output_type -> vehicle.ListVehiclesResponse
+	3,  // 19: vehicle.VehicleService.UpdateStatus:output_type -> vehicle.VehicleResponse
+	8,  // 20: vehicle.VehicleService.GetVehiclesByDriver:output_type -> vehicle.GetVehiclesByDriverResponse
+	10, // 21: vehicle.VehicleService.GetAvailableVehiclesByDrivers:output_type -> vehicle.GetAvailableVehiclesByDriversResponse
+	16, // [16:22] is the sub-list for method output_type
+	10, // [10:16] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_shared_proto_vehicle_vehicle_proto_init() }
+func file_shared_proto_vehicle_vehicle_proto_init() {
+	if File_shared_proto_vehicle_vehicle_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_shared_proto_vehicle_vehicle_proto_rawDesc), len(file_shared_proto_vehicle_vehicle_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_shared_proto_vehicle_vehicle_proto_goTypes,
+		DependencyIndexes: file_shared_proto_vehicle_vehicle_proto_depIdxs,
+		MessageInfos:      file_shared_proto_vehicle_vehicle_proto_msgTypes,
+	}.Build()
+	File_shared_proto_vehicle_vehicle_proto = out.File
+	file_shared_proto_vehicle_vehicle_proto_goTypes = nil
+	file_shared_proto_vehicle_vehicle_proto_depIdxs = nil
+}
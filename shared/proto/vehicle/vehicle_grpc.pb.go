@@ -0,0 +1,327 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.21.12
+// source: shared/proto/vehicle/vehicle.proto
+
+package vehicle
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	VehicleService_CreateVehicle_FullMethodName                 = "/vehicle.VehicleService/CreateVehicle"
+	VehicleService_GetVehicle_FullMethodName                    = "/vehicle.VehicleService/GetVehicle"
+	VehicleService_ListVehicles_FullMethodName                  = "/vehicle.VehicleService/ListVehicles"
+	VehicleService_UpdateStatus_FullMethodName                  = "/vehicle.VehicleService/UpdateStatus"
+	VehicleService_GetVehiclesByDriver_FullMethodName           = "/vehicle.VehicleService/GetVehiclesByDriver"
+	VehicleService_GetAvailableVehiclesByDrivers_FullMethodName = "/vehicle.VehicleService/GetAvailableVehiclesByDrivers"
+)
+
+// VehicleServiceClient is the client API for VehicleService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Vehicle service definition
+type VehicleServiceClient interface {
+	// Registers a new vehicle for a driver
+	CreateVehicle(ctx context.Context, in *CreateVehicleRequest, opts ...grpc.CallOption) (*VehicleResponse, error)
+	// Gets a vehicle by ID
+	GetVehicle(ctx context.Context, in *GetVehicleRequest, opts ...grpc.CallOption) (*VehicleResponse, error)
+	// Lists vehicles with pagination and filtering
+	ListVehicles(ctx context.Context, in *ListVehiclesRequest, opts ...grpc.CallOption) (*ListVehiclesResponse, error)
+	// Changes a vehicle's status
+	UpdateStatus(ctx context.Context, in *UpdateVehicleStatusRequest, opts ...grpc.CallOption) (*VehicleResponse, error)
+	// Gets the vehicles registered to a driver
+	GetVehiclesByDriver(ctx context.Context, in *GetVehiclesByDriverRequest, opts ...grpc.CallOption) (*GetVehiclesByDriverResponse, error)
+	// Gets the active vehicles of several drivers at once
+	GetAvailableVehiclesByDrivers(ctx context.Context, in *GetAvailableVehiclesByDriversRequest, opts ...grpc.CallOption) (*GetAvailableVehiclesByDriversResponse, error)
+}
+
+type vehicleServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewVehicleServiceClient(cc grpc.ClientConnInterface) VehicleServiceClient {
+	return &vehicleServiceClient{cc}
+}
+
+func (c *vehicleServiceClient) CreateVehicle(ctx context.Context, in *CreateVehicleRequest, opts ...grpc.CallOption) (*VehicleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VehicleResponse)
+	err := c.cc.Invoke(ctx, VehicleService_CreateVehicle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleServiceClient) GetVehicle(ctx context.Context, in *GetVehicleRequest, opts ...grpc.CallOption) (*VehicleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VehicleResponse)
+	err := c.cc.Invoke(ctx, VehicleService_GetVehicle_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleServiceClient) ListVehicles(ctx context.Context, in *ListVehiclesRequest, opts ...grpc.CallOption) (*ListVehiclesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListVehiclesResponse)
+	err := c.cc.Invoke(ctx, VehicleService_ListVehicles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleServiceClient) UpdateStatus(ctx context.Context, in *UpdateVehicleStatusRequest, opts ...grpc.CallOption) (*VehicleResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VehicleResponse)
+	err := c.cc.Invoke(ctx, VehicleService_UpdateStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleServiceClient) GetVehiclesByDriver(ctx context.Context, in *GetVehiclesByDriverRequest, opts ...grpc.CallOption) (*GetVehiclesByDriverResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetVehiclesByDriverResponse)
+	err := c.cc.Invoke(ctx, VehicleService_GetVehiclesByDriver_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vehicleServiceClient) GetAvailableVehiclesByDrivers(ctx context.Context, in *GetAvailableVehiclesByDriversRequest, opts ...grpc.CallOption) (*GetAvailableVehiclesByDriversResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAvailableVehiclesByDriversResponse)
+	err := c.cc.Invoke(ctx, VehicleService_GetAvailableVehiclesByDrivers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// VehicleServiceServer is the server API for VehicleService service.
+// All implementations must embed UnimplementedVehicleServiceServer
+// for forward compatibility.
+//
+// Vehicle service definition
+type VehicleServiceServer interface {
+	// Registers a new vehicle for a driver
+	CreateVehicle(context.Context, *CreateVehicleRequest) (*VehicleResponse, error)
+	// Gets a vehicle by ID
+	GetVehicle(context.Context, *GetVehicleRequest) (*VehicleResponse, error)
+	// Lists vehicles with pagination and filtering
+	ListVehicles(context.Context, *ListVehiclesRequest) (*ListVehiclesResponse, error)
+	// Changes a vehicle's status
+	UpdateStatus(context.Context, *UpdateVehicleStatusRequest) (*VehicleResponse, error)
+	// Gets the vehicles registered to a driver
+	GetVehiclesByDriver(context.Context, *GetVehiclesByDriverRequest) (*GetVehiclesByDriverResponse, error)
+	// Gets the active vehicles of several drivers at once
+	GetAvailableVehiclesByDrivers(context.Context, *GetAvailableVehiclesByDriversRequest) (*GetAvailableVehiclesByDriversResponse, error)
+	mustEmbedUnimplementedVehicleServiceServer()
+}
+
+// UnimplementedVehicleServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedVehicleServiceServer struct{}
+
+func (UnimplementedVehicleServiceServer) CreateVehicle(context.Context, *CreateVehicleRequest) (*VehicleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateVehicle not implemented")
+}
+func (UnimplementedVehicleServiceServer) GetVehicle(context.Context, *GetVehicleRequest) (*VehicleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVehicle not implemented")
+}
+func (UnimplementedVehicleServiceServer) ListVehicles(context.Context, *ListVehiclesRequest) (*ListVehiclesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListVehicles not implemented")
+}
+func (UnimplementedVehicleServiceServer) UpdateStatus(context.Context, *UpdateVehicleStatusRequest) (*VehicleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateStatus not implemented")
+}
+func (UnimplementedVehicleServiceServer) GetVehiclesByDriver(context.Context, *GetVehiclesByDriverRequest) (*GetVehiclesByDriverResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVehiclesByDriver not implemented")
+}
+func (UnimplementedVehicleServiceServer) GetAvailableVehiclesByDrivers(context.Context, *GetAvailableVehiclesByDriversRequest) (*GetAvailableVehiclesByDriversResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAvailableVehiclesByDrivers not implemented")
+}
+func (UnimplementedVehicleServiceServer) mustEmbedUnimplementedVehicleServiceServer() {}
+func (UnimplementedVehicleServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeVehicleServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to VehicleServiceServer will
+// result in compilation errors.
+type UnsafeVehicleServiceServer interface {
+	mustEmbedUnimplementedVehicleServiceServer()
+}
+
+func RegisterVehicleServiceServer(s grpc.ServiceRegistrar, srv VehicleServiceServer) {
+	// If the following call pancis, it indicates UnimplementedVehicleServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&VehicleService_ServiceDesc, srv)
+}
+
+func _VehicleService_CreateVehicle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateVehicleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).CreateVehicle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_CreateVehicle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).CreateVehicle(ctx, req.(*CreateVehicleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VehicleService_GetVehicle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVehicleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).GetVehicle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_GetVehicle_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).GetVehicle(ctx, req.(*GetVehicleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VehicleService_ListVehicles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVehiclesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).ListVehicles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_ListVehicles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).ListVehicles(ctx, req.(*ListVehiclesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VehicleService_UpdateStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateVehicleStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).UpdateStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_UpdateStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).UpdateStatus(ctx, req.(*UpdateVehicleStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VehicleService_GetVehiclesByDriver_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVehiclesByDriverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).GetVehiclesByDriver(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_GetVehiclesByDriver_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).GetVehiclesByDriver(ctx, req.(*GetVehiclesByDriverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _VehicleService_GetAvailableVehiclesByDrivers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAvailableVehiclesByDriversRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VehicleServiceServer).GetAvailableVehiclesByDrivers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VehicleService_GetAvailableVehiclesByDrivers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VehicleServiceServer).GetAvailableVehiclesByDrivers(ctx, req.(*GetAvailableVehiclesByDriversRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// VehicleService_ServiceDesc is the grpc.ServiceDesc for VehicleService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var VehicleService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "vehicle.VehicleService",
+	HandlerType: (*VehicleServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateVehicle",
+			Handler:    _VehicleService_CreateVehicle_Handler,
+		},
+		{
+			MethodName: "GetVehicle",
+			Handler:    _VehicleService_GetVehicle_Handler,
+		},
+		{
+			MethodName: "ListVehicles",
+			Handler:    _VehicleService_ListVehicles_Handler,
+		},
+		{
+			MethodName: "UpdateStatus",
+			Handler:    _VehicleService_UpdateStatus_Handler,
+		},
+		{
+			MethodName: "GetVehiclesByDriver",
+			Handler:    _VehicleService_GetVehiclesByDriver_Handler,
+		},
+		{
+			MethodName: "GetAvailableVehiclesByDrivers",
+			Handler:    _VehicleService_GetAvailableVehiclesByDrivers_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "shared/proto/vehicle/vehicle.proto",
+}
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_vehicles_make_model_trgm;
+DROP INDEX IF EXISTS idx_vehicles_plate_trgm;
+DROP INDEX IF EXISTS idx_users_name_trgm;
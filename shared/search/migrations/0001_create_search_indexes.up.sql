@@ -0,0 +1,11 @@
+-- Trigram indexes for admin search. The users, vehicles and trips tables
+-- belong to their services; search only adds indexes over the columns it
+-- matches on. The expressions must stay identical to the ones in the queries.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_users_name_trgm
+    ON users USING GIN ((first_name || ' ' || last_name) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_vehicles_plate_trgm
+    ON vehicles USING GIN ((regexp_replace(upper(license_plate), '[^A-Z0-9]', '', 'g')) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_vehicles_make_model_trgm
+    ON vehicles USING GIN ((make || ' ' || model) gin_trgm_ops);
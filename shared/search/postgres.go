@@ -0,0 +1,273 @@
+package search
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/rideshare-platform/shared/database"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/search/migrations"
+)
+
+// MigrationService is the name admin search's migrations are recorded under
+const MigrationService = "search"
+
+// Expressions the trigram indexes are built over. They must stay identical
+// to the ones in the migrations for the indexes to be used.
+const (
+	userNameExpr     = `(%[1]s.first_name || ' ' || %[1]s.last_name)`
+	plateExpr        = `regexp_replace(upper(%s.license_plate), '[^A-Z0-9]', '', 'g')`
+	vehicleModelExpr = `(v.make || ' ' || v.model)`
+)
+
+// BlindIndexer computes the keyed hashes encrypted contact details are
+// looked up by. *crypto.Cipher is one.
+type BlindIndexer interface {
+	BlindIndex(value string) string
+}
+
+// PostgresIndex searches the users, vehicles and trips tables with trigram
+// similarity. Names, plates and vehicle models match approximately; emails,
+// phone numbers and IDs match exactly.
+type PostgresIndex struct {
+	db      *sql.DB
+	indexer BlindIndexer
+}
+
+// NewPostgresIndex creates an index searching the tables in db
+func NewPostgresIndex(db *sql.DB) *PostgresIndex {
+	return &PostgresIndex{db: db}
+}
+
+// SetBlindIndexer attaches the hasher encrypted emails and phone numbers
+// are indexed with. Without one only contact details stored before
+// encryption was enabled can be matched.
+func (s *PostgresIndex) SetBlindIndexer(indexer BlindIndexer) {
+	s.indexer = indexer
+}
+
+// Migrate creates or upgrades the search indexes in db. The users, vehicles
+// and trips tables must already exist, and the role must be allowed to
+// create the pg_trgm extension.
+func Migrate(ctx context.Context, db *sql.DB, log *logger.Logger) error {
+	schema, err := migrations.Load()
+	if err != nil {
+		return err
+	}
+	_, err = database.NewMigrator(db, MigrationService, schema, log).Up(ctx)
+	return err
+}
+
+// Open connects to the database at databaseURL, migrates its search indexes
+// and returns an index over it. The postgres driver must be registered by
+// the caller.
+func Open(ctx context.Context, databaseURL string, log *logger.Logger) (*PostgresIndex, error) {
+	db, err := sql.Open("postgres", databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to search database: %w", err)
+	}
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to ping search database: %w", err)
+	}
+	if err := Migrate(ctx, db, log); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to migrate search database: %w", err)
+	}
+	return NewPostgresIndex(db), nil
+}
+
+// Close releases the database
+func (s *PostgresIndex) Close() error {
+	return s.db.Close()
+}
+
+// Search returns the users, vehicles and trips matching query, best match first
+func (s *PostgresIndex) Search(ctx context.Context, query Query) ([]Result, error) {
+	query, err := query.Normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []Result
+	if query.Text != "" && query.Includes(KindUser) {
+		users, err := s.searchUsers(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, users...)
+	}
+	if query.Text != "" && query.Includes(KindVehicle) {
+		vehicles, err := s.searchVehicles(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, vehicles...)
+	}
+	if query.Includes(KindTrip) {
+		trips, err := s.searchTrips(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, trips...)
+	}
+	return Rank(results, query.Limit), nil
+}
+
+// contactMatch is the condition and score matching a user aliased alias by
+// email, phone number or ID. $1 is the text and $2 its blind index.
+func contactMatch(alias string) string {
+	return fmt.Sprintf(`(%[1]s.id::text = $1 OR %[1]s.email_index = $2 OR %[1]s.phone_index = $2
+		OR (%[1]s.email_index IS NULL AND lower(%[1]s.email) = lower($1)))`, alias)
+}
+
+func (s *PostgresIndex) searchUsers(ctx context.Context, query Query) ([]Result, error) {
+	name := fmt.Sprintf(userNameExpr, "u")
+	contact := contactMatch("u")
+	sqlQuery := fmt.Sprintf(`
+		SELECT u.id::text, u.first_name, u.last_name, u.user_type, u.status, u.created_at,
+			GREATEST(word_similarity($1, %[1]s), CASE WHEN %[2]s THEN 1 ELSE 0 END) AS score
+		FROM users u
+		WHERE $1 <%% %[1]s OR %[1]s ILIKE $3 OR %[2]s
+		ORDER BY score DESC, u.id LIMIT $4`, name, contact)
+
+	rows, err := s.db.QueryContext(ctx, sqlQuery, query.Text, s.blindIndex(query.Text), likePattern(query.Text), query.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search users: %w", err)
+	}
+	defer rows.Close()
+
+	var results []Result
+	for rows.Next() {
+		hit := &UserHit{}
+		result := Result{Kind: KindUser, User: hit}
+		if err := rows.Scan(&result.ID, &hit.FirstName, &hit.LastName, &hit.UserType, &hit.Status, &hit.CreatedAt, &result.Score); err != nil {
+			return nil, fmt.Errorf("failed to search users: %w", err)
+		}
+		result.Score = roundScore(result.Score)
+		results = append(results, result)
+	}
+	return results, rows.Err()
+}
+
+func (s *PostgresIndex) searchVehicles(ctx context.Context, query Query) ([]Result, error) {
+	plate := fmt.Sprintf(plateExpr, "v")
+	sqlQuery := fmt.Sprintf(`
+		SELECT v.id::text, v.driver_id::text, v.make, v.model, v.year, v.color, v.license_plate, v.status,
+			GREATEST(
+				CASE WHEN $2 = '' THEN 0 ELSE similarity(%[1]s, $2) END,
+				word_similarity($1, %[2]s),
+				CASE WHEN v.id::text = $1 THEN 1 ELSE 0 END
+			) AS score
+		FROM vehicles v
+		WHERE ($2 <> '' AND %[1]s LIKE '%%' || $2 || '%%') OR $1 <%% %[2]s OR v.id::text = $1
+		ORDER BY score DESC, v.id LIMIT $3`, plate, vehicleModelExpr)
+
+	rows, err := s.db.QueryContext(ctx, sqlQuery, query.Text, plateKey(query.Text), query.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search vehicles: %w", err)
+	}
+	defer rows.Close()
+
+	var results []Result
+	for rows.Next() {
+		hit := &VehicleHit{}
+		result := Result{Kind: KindVehicle, Vehicle: hit}
+		if err := rows.Scan(&result.ID, &hit.DriverID, &hit.Make, &hit.Model, &hit.Year, &hit.Color, &hit.LicensePlate, &hit.Status, &result.Score); err != nil {
+			return nil, fmt.Errorf("failed to search vehicles: %w", err)
+		}
+		result.Score = roundScore(result.Score)
+		results = append(results, result)
+	}
+	return results, rows.Err()
+}
+
+// searchTrips matches trips by ID and by their rider's and driver's names
+// and contact details and their vehicle's plate. With a time, trips are
+// narrowed to those requested around it and the score falls off with the
+// distance, to half at the edge of the window.
+func (s *PostgresIndex) searchTrips(ctx context.Context, query Query) ([]Result, error) {
+	riderName, driverName := fmt.Sprintf(userNameExpr, "r"), fmt.Sprintf(userNameExpr, "d")
+	plate := fmt.Sprintf(plateExpr, "v")
+	textScore := fmt.Sprintf(`CASE WHEN $1 = '' THEN 1 ELSE GREATEST(
+			CASE WHEN t.id = $1 OR %[1]s OR %[2]s THEN 1 ELSE 0 END,
+			COALESCE(word_similarity($1, %[3]s), 0),
+			COALESCE(word_similarity($1, %[4]s), 0),
+			CASE WHEN $3 = '' THEN 0 ELSE COALESCE(similarity(%[5]s, $3), 0) END
+		) END`, contactMatch("r"), contactMatch("d"), riderName, driverName, plate)
+	timeScore := `CASE WHEN $4::timestamptz IS NULL THEN 1
+		ELSE 1 - 0.5 * abs(extract(epoch FROM t.requested_at - $4::timestamptz)) / $5 END`
+
+	sqlQuery := fmt.Sprintf(`
+		SELECT t.id, t.rider_id, COALESCE(%[1]s, ''), COALESCE(t.driver_id, ''), COALESCE(%[2]s, ''),
+			COALESCE(t.vehicle_id, ''), COALESCE(v.license_plate, ''), t.state, t.requested_at,
+			(%[3]s) * (%[4]s) AS score
+		FROM trips t
+		LEFT JOIN users r ON r.id::text = t.rider_id
+		LEFT JOIN users d ON d.id::text = t.driver_id
+		LEFT JOIN vehicles v ON v.id::text = t.vehicle_id
+		WHERE ($4::timestamptz IS NULL
+				OR t.requested_at BETWEEN $4::timestamptz - make_interval(secs => $5) AND $4::timestamptz + make_interval(secs => $5))
+			AND ($1 = '' OR t.id = $1 OR %[5]s OR %[6]s
+				OR $1 <%% %[1]s OR $1 <%% %[2]s
+				OR ($3 <> '' AND %[7]s LIKE '%%' || $3 || '%%'))
+		ORDER BY score DESC, t.requested_at DESC, t.id LIMIT $6`,
+		riderName, driverName, textScore, timeScore, contactMatch("r"), contactMatch("d"), plate)
+
+	var around *time.Time
+	if !query.Around.IsZero() {
+		around = &query.Around
+	}
+	rows, err := s.db.QueryContext(ctx, sqlQuery,
+		query.Text, s.blindIndex(query.Text), plateKey(query.Text), around, query.Window.Seconds(), query.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search trips: %w", err)
+	}
+	defer rows.Close()
+
+	var results []Result
+	for rows.Next() {
+		hit := &TripHit{}
+		result := Result{Kind: KindTrip, Trip: hit}
+		if err := rows.Scan(&result.ID, &hit.RiderID, &hit.RiderName, &hit.DriverID, &hit.DriverName,
+			&hit.VehicleID, &hit.LicensePlate, &hit.State, &hit.RequestedAt, &result.Score); err != nil {
+			return nil, fmt.Errorf("failed to search trips: %w", err)
+		}
+		result.Score = roundScore(result.Score)
+		results = append(results, result)
+	}
+	return results, rows.Err()
+}
+
+// blindIndex returns the blind index of text, NULL without an indexer so it
+// matches nothing
+func (s *PostgresIndex) blindIndex(text string) interface{} {
+	if s.indexer == nil || text == "" {
+		return nil
+	}
+	return s.indexer.BlindIndex(text)
+}
+
+var nonPlateCharacters = regexp.MustCompile(`[^A-Z0-9]`)
+
+// plateKey returns text as license plates are compared: upper case with
+// spaces and dashes dropped
+func plateKey(text string) string {
+	return nonPlateCharacters.ReplaceAllString(strings.ToUpper(text), "")
+}
+
+// likePattern returns an ILIKE pattern matching text anywhere, with its
+// wildcards escaped
+func likePattern(text string) string {
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(text)
+	return "%" + escaped + "%"
+}
+
+func roundScore(score float64) float64 {
+	return math.Round(score*1000) / 1000
+}
@@ -0,0 +1,188 @@
+// Package search finds users, vehicles and trips for support staff from a
+// free-text query: a name, an email or phone number, a license plate or an
+// ID, optionally narrowed to trips requested around a time. Results of every
+// type are ranked together by how well they match.
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultLimit is the number of results when a query does not ask for one
+	DefaultLimit = 20
+	// MaxLimit caps the number of results a query can ask for
+	MaxLimit = 100
+	// DefaultWindow is how far from Around trips are matched when a query
+	// does not say
+	DefaultWindow = 30 * time.Minute
+	// MaxWindow caps how far from Around trips are matched
+	MaxWindow = 24 * time.Hour
+	// MinTextLength is the shortest text a query can search for
+	MinTextLength = 2
+)
+
+var (
+	// ErrEmptyQuery is returned for a query with neither text nor a time
+	ErrEmptyQuery = errors.New("search needs text or a time")
+	// ErrInvalidQuery is returned for a query with malformed parameters
+	ErrInvalidQuery = errors.New("invalid search query")
+)
+
+// Kind is the type of a search result
+type Kind string
+
+const (
+	KindUser    Kind = "user"
+	KindVehicle Kind = "vehicle"
+	KindTrip    Kind = "trip"
+)
+
+// Kinds are every kind of result, in the order ties are ranked
+var Kinds = []Kind{KindTrip, KindUser, KindVehicle}
+
+// ParseKind returns the kind named s
+func ParseKind(s string) (Kind, error) {
+	for _, kind := range Kinds {
+		if string(kind) == s {
+			return kind, nil
+		}
+	}
+	return "", fmt.Errorf("%w: unknown result type %q", ErrInvalidQuery, s)
+}
+
+// Query asks for the records matching some text or time
+type Query struct {
+	// Text is matched against names, emails, phone numbers, license plates,
+	// vehicle makes and models, and IDs
+	Text string
+	// Kinds narrows the results to some types, every type when empty
+	Kinds []Kind
+	// Around narrows trips to those requested within Window of it. Matching
+	// trips closer to it rank higher. Users and vehicles ignore it.
+	Around time.Time
+	Window time.Duration
+	Limit  int
+}
+
+// Normalize trims the query's text, fills in its defaults and checks it
+func (q Query) Normalize() (Query, error) {
+	q.Text = strings.TrimSpace(q.Text)
+	if q.Text == "" && q.Around.IsZero() {
+		return Query{}, ErrEmptyQuery
+	}
+	if q.Text != "" && len([]rune(q.Text)) < MinTextLength {
+		return Query{}, fmt.Errorf("%w: text must be at least %d characters", ErrInvalidQuery, MinTextLength)
+	}
+	if q.Text == "" && !q.Includes(KindTrip) {
+		return Query{}, fmt.Errorf("%w: searching by time alone only finds trips", ErrInvalidQuery)
+	}
+	switch {
+	case q.Window < 0 || q.Window > MaxWindow:
+		return Query{}, fmt.Errorf("%w: window must be between 0 and %s", ErrInvalidQuery, MaxWindow)
+	case q.Window == 0:
+		q.Window = DefaultWindow
+	}
+	switch {
+	case q.Limit < 0:
+		return Query{}, fmt.Errorf("%w: limit must not be negative", ErrInvalidQuery)
+	case q.Limit == 0:
+		q.Limit = DefaultLimit
+	case q.Limit > MaxLimit:
+		q.Limit = MaxLimit
+	}
+	return q, nil
+}
+
+// Includes reports whether the query asks for results of kind
+func (q Query) Includes(kind Kind) bool {
+	if len(q.Kinds) == 0 {
+		return true
+	}
+	for _, k := range q.Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Result is one record matching a query. Exactly one of User, Vehicle and
+// Trip is set, as Kind says.
+type Result struct {
+	Kind Kind   `json:"type"`
+	ID   string `json:"id"`
+	// Score ranks the result against the others, from 0 to 1 for an exact match
+	Score   float64     `json:"score"`
+	User    *UserHit    `json:"user,omitempty"`
+	Vehicle *VehicleHit `json:"vehicle,omitempty"`
+	Trip    *TripHit    `json:"trip,omitempty"`
+}
+
+// UserHit is a matching rider, driver or admin. Contact details are
+// encrypted at rest and left out; they can be matched but not read here.
+type UserHit struct {
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	UserType  string    `json:"user_type"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// VehicleHit is a matching vehicle
+type VehicleHit struct {
+	DriverID     string `json:"driver_id"`
+	Make         string `json:"make"`
+	Model        string `json:"model"`
+	Year         int    `json:"year"`
+	Color        string `json:"color"`
+	LicensePlate string `json:"license_plate"`
+	Status       string `json:"status"`
+}
+
+// TripHit is a matching trip with the names and plate it was matched on
+type TripHit struct {
+	RiderID      string    `json:"rider_id"`
+	RiderName    string    `json:"rider_name,omitempty"`
+	DriverID     string    `json:"driver_id,omitempty"`
+	DriverName   string    `json:"driver_name,omitempty"`
+	VehicleID    string    `json:"vehicle_id,omitempty"`
+	LicensePlate string    `json:"license_plate,omitempty"`
+	State        string    `json:"state"`
+	RequestedAt  time.Time `json:"requested_at"`
+}
+
+// Index finds the records matching a query. PostgresIndex searches the
+// service tables directly; a dedicated search engine can stand in behind
+// the same interface.
+type Index interface {
+	Search(ctx context.Context, query Query) ([]Result, error)
+}
+
+// Rank orders results best match first and keeps the first limit. Ties go
+// to trips, then users, then vehicles, then the lower ID.
+func Rank(results []Result, limit int) []Result {
+	order := make(map[Kind]int, len(Kinds))
+	for i, kind := range Kinds {
+		order[kind] = i
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Kind != b.Kind {
+			return order[a.Kind] < order[b.Kind]
+		}
+		return a.ID < b.ID
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
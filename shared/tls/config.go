@@ -0,0 +1,55 @@
+// Package tls loads the certificates services use for mutual TLS, keeps them
+// fresh as they are rotated on disk and builds the gRPC and HTTPS transport
+// settings from them. Services identify each other by the DNS or URI SANs of
+// their certificates.
+package tls
+
+import (
+	"errors"
+	"time"
+
+	sharedconfig "github.com/rideshare-platform/shared/config"
+)
+
+// Config holds a service's TLS settings
+type Config struct {
+	Enabled bool `yaml:"enabled" env:"TLS_ENABLED" default:"false"`
+	// CertFile and KeyFile hold the service's own certificate, whose SANs are
+	// its identity
+	CertFile string `yaml:"cert_file" env:"TLS_CERT_FILE"`
+	KeyFile  string `yaml:"key_file" env:"TLS_KEY_FILE"`
+	// CAFile holds the CA bundle peer certificates must chain to
+	CAFile string `yaml:"ca_file" env:"TLS_CA_FILE"`
+	// AllowedPeers lists the identities allowed to call this service. Empty
+	// accepts any certificate issued by the CA.
+	AllowedPeers []string `yaml:"allowed_peers" env:"TLS_ALLOWED_PEERS"`
+	// ReloadInterval is how often the files are checked for rotation
+	ReloadInterval time.Duration `yaml:"reload_interval" env:"TLS_RELOAD_INTERVAL" default:"1m"`
+}
+
+// LoadConfig reads the TLS settings from the TLS_* environment variables, for
+// services that do not load their config through the shared loader
+func LoadConfig() (Config, error) {
+	var config Config
+	if err := sharedconfig.NewLoader().WithFile("").Load(&config); err != nil {
+		return Config{}, err
+	}
+	return config, nil
+}
+
+// Validate checks that an enabled config names every file mutual TLS needs
+func (c *Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.CertFile == "" || c.KeyFile == "" {
+		return errors.New("TLS_CERT_FILE and TLS_KEY_FILE are required when TLS is enabled")
+	}
+	if c.CAFile == "" {
+		return errors.New("TLS_CA_FILE is required when TLS is enabled")
+	}
+	if c.ReloadInterval <= 0 {
+		return errors.New("TLS reload interval must be positive")
+	}
+	return nil
+}
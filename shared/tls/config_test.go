@@ -0,0 +1,53 @@
+package tls
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	valid := Config{Enabled: true, CertFile: "tls.crt", KeyFile: "tls.key", CAFile: "ca.crt", ReloadInterval: time.Minute}
+
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr string
+	}{
+		{"valid", func(*Config) {}, ""},
+		{"disabled needs no files", func(c *Config) { *c = Config{} }, ""},
+		{"missing certificate", func(c *Config) { c.CertFile = "" }, "TLS_CERT_FILE and TLS_KEY_FILE are required when TLS is enabled"},
+		{"missing key", func(c *Config) { c.KeyFile = "" }, "TLS_CERT_FILE and TLS_KEY_FILE are required when TLS is enabled"},
+		{"missing CA", func(c *Config) { c.CAFile = "" }, "TLS_CA_FILE is required when TLS is enabled"},
+		{"no reload interval", func(c *Config) { c.ReloadInterval = 0 }, "TLS reload interval must be positive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := valid
+			tt.modify(&config)
+			err := config.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Setenv("TLS_ENABLED", "true")
+	t.Setenv("TLS_CERT_FILE", "/etc/tls/tls.crt")
+	t.Setenv("TLS_KEY_FILE", "/etc/tls/tls.key")
+	t.Setenv("TLS_CA_FILE", "/etc/tls/ca.crt")
+	t.Setenv("TLS_ALLOWED_PEERS", "api-gateway,matching-service")
+
+	config, err := LoadConfig()
+	assert.NoError(t, err)
+	assert.True(t, config.Enabled)
+	assert.Equal(t, "/etc/tls/tls.crt", config.CertFile)
+	assert.Equal(t, []string{"api-gateway", "matching-service"}, config.AllowedPeers)
+	assert.Equal(t, time.Minute, config.ReloadInterval, "the default applies")
+	assert.NoError(t, config.Validate())
+}
@@ -0,0 +1,96 @@
+package tls
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+// Identities returns the DNS and URI SANs a certificate identifies its
+// holder by
+func Identities(cert *x509.Certificate) []string {
+	if cert == nil {
+		return nil
+	}
+	identities := make([]string, 0, len(cert.DNSNames)+len(cert.URIs))
+	identities = append(identities, cert.DNSNames...)
+	for _, uri := range cert.URIs {
+		identities = append(identities, uri.String())
+	}
+	return identities
+}
+
+// PeerIdentity returns the first SAN of the certificate the gRPC caller
+// presented, if the call arrived over mutual TLS
+func PeerIdentity(ctx context.Context) (string, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", false
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.PeerCertificates) == 0 {
+		return "", false
+	}
+	identities := Identities(info.State.PeerCertificates[0])
+	if len(identities) == 0 {
+		return "", false
+	}
+	return identities[0], true
+}
+
+// verifyClient checks that the client's certificate chains to the current
+// CA pool and carries an allowed identity
+func (r *Reloader) verifyClient(state tls.ConnectionState) error {
+	leaf, err := r.verifyChain(state, x509.ExtKeyUsageClientAuth, "")
+	if err != nil {
+		return err
+	}
+	if len(r.config.AllowedPeers) == 0 {
+		return nil
+	}
+	for _, identity := range Identities(leaf) {
+		for _, allowed := range r.config.AllowedPeers {
+			if identity == allowed {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("peer identity %v is not allowed", Identities(leaf))
+}
+
+// verifyServer checks that the server's certificate chains to the current CA
+// pool and is issued to the name that was dialled
+func (r *Reloader) verifyServer(state tls.ConnectionState) error {
+	_, err := r.verifyChain(state, x509.ExtKeyUsageServerAuth, state.ServerName)
+	return err
+}
+
+// verifyChain verifies the peer's chain against the CA pool loaded at the
+// time of the handshake, so a rotated CA applies to new connections
+// immediately
+func (r *Reloader) verifyChain(state tls.ConnectionState, usage x509.ExtKeyUsage, dnsName string) (*x509.Certificate, error) {
+	if len(state.PeerCertificates) == 0 {
+		return nil, errors.New("peer presented no certificate")
+	}
+	_, roots := r.current()
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	leaf := state.PeerCertificates[0]
+	if _, err := leaf.Verify(x509.VerifyOptions{
+		DNSName:       dnsName,
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{usage},
+	}); err != nil {
+		return nil, err
+	}
+	return leaf, nil
+}
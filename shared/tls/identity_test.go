@@ -0,0 +1,253 @@
+package tls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCA issues certificates for the handshake tests
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+var serialNumber int64
+
+func newTestCA(t *testing.T, name string) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	serialNumber++
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(serialNumber),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns a PEM certificate and key for the SANs, which are URIs when
+// they have a scheme and DNS names otherwise
+func (ca *testCA) issue(t *testing.T, usages []x509.ExtKeyUsage, sans ...string) ([]byte, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	serialNumber++
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serialNumber),
+		Subject:      pkix.Name{CommonName: sans[0]},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  usages,
+	}
+	for _, san := range sans {
+		if strings.Contains(san, "://") {
+			uri, err := url.Parse(san)
+			require.NoError(t, err)
+			template.URIs = append(template.URIs, uri)
+		} else {
+			template.DNSNames = append(template.DNSNames, san)
+		}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+var bothUsages = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}
+
+// writeIdentity writes a certificate issued by issuer for the SANs, with
+// trusted as the CA bundle, and returns the config loading them
+func writeIdentity(t *testing.T, dir string, issuer, trusted *testCA, usages []x509.ExtKeyUsage, sans ...string) Config {
+	t.Helper()
+	certPEM, keyPEM := issuer.issue(t, usages, sans...)
+	config := Config{
+		Enabled:        true,
+		CertFile:       filepath.Join(dir, "tls.crt"),
+		KeyFile:        filepath.Join(dir, "tls.key"),
+		CAFile:         filepath.Join(dir, "ca.crt"),
+		ReloadInterval: time.Minute,
+	}
+	require.NoError(t, os.WriteFile(config.CertFile, certPEM, 0o600))
+	require.NoError(t, os.WriteFile(config.KeyFile, keyPEM, 0o600))
+	require.NoError(t, os.WriteFile(config.CAFile, trusted.pem, 0o600))
+	return config
+}
+
+func newTestReloader(t *testing.T, issuer, trusted *testCA, usages []x509.ExtKeyUsage, sans ...string) *Reloader {
+	t.Helper()
+	reloader, err := NewReloader(writeIdentity(t, t.TempDir(), issuer, trusted, usages, sans...), nil)
+	require.NoError(t, err)
+	return reloader
+}
+
+// handshake connects client to server over loopback, dialling serverName,
+// and returns the error each side's handshake ended with
+func handshake(t *testing.T, server, client *Reloader, serverName string) (serverErr, clientErr error) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	serverDone := make(chan error, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			serverDone <- err
+			return
+		}
+		defer conn.Close()
+		serverConn := tls.Server(conn, server.ServerTLSConfig())
+		if err := serverConn.Handshake(); err != nil {
+			serverDone <- err
+			return
+		}
+		_, err = serverConn.Write([]byte{1})
+		serverDone <- err
+	}()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+	config := client.ClientTLSConfig()
+	config.ServerName = serverName
+	clientConn := tls.Client(conn, config)
+	clientErr = clientConn.Handshake()
+	if clientErr == nil {
+		// Under TLS 1.3 the server verifies the client after the client's
+		// handshake is done, so a rejection only shows on the first read
+		_, clientErr = clientConn.Read(make([]byte, 1))
+	}
+	clientConn.Close()
+	return <-serverDone, clientErr
+}
+
+func TestReloader_VerifiesPeers(t *testing.T) {
+	ca := newTestCA(t, "rideshare-ca")
+	otherCA := newTestCA(t, "other-ca")
+	server := newTestReloader(t, ca, ca, bothUsages, "trip-service", "spiffe://rideshare/trip-service")
+
+	tests := []struct {
+		name          string
+		server        *Reloader
+		client        *Reloader
+		serverName    string
+		allowedPeers  []string
+		wantServerErr string
+		wantClientErr string
+	}{
+		{
+			name:       "mutual TLS",
+			server:     server,
+			client:     newTestReloader(t, ca, ca, bothUsages, "api-gateway"),
+			serverName: "trip-service",
+		},
+		{
+			name:         "allowed DNS identity",
+			server:       server,
+			client:       newTestReloader(t, ca, ca, bothUsages, "api-gateway"),
+			serverName:   "trip-service",
+			allowedPeers: []string{"matching-service", "api-gateway"},
+		},
+		{
+			name:         "allowed URI identity",
+			server:       server,
+			client:       newTestReloader(t, ca, ca, bothUsages, "payment-service", "spiffe://rideshare/payment-service"),
+			serverName:   "trip-service",
+			allowedPeers: []string{"spiffe://rideshare/payment-service"},
+		},
+		{
+			name:          "identity not allowed",
+			server:        server,
+			client:        newTestReloader(t, ca, ca, bothUsages, "geo-service"),
+			serverName:    "trip-service",
+			allowedPeers:  []string{"api-gateway"},
+			wantServerErr: "peer identity [geo-service] is not allowed",
+		},
+		{
+			name:          "client certificate from another CA",
+			server:        server,
+			client:        newTestReloader(t, otherCA, ca, bothUsages, "api-gateway"),
+			serverName:    "trip-service",
+			wantServerErr: "certificate signed by unknown authority",
+		},
+		{
+			name:          "client certificate without client auth usage",
+			server:        server,
+			client:        newTestReloader(t, ca, ca, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}, "api-gateway"),
+			serverName:    "trip-service",
+			wantServerErr: "incompatible key usage",
+		},
+		{
+			name:          "server certificate from another CA",
+			server:        newTestReloader(t, otherCA, ca, bothUsages, "trip-service"),
+			client:        newTestReloader(t, ca, ca, bothUsages, "api-gateway"),
+			serverName:    "trip-service",
+			wantClientErr: "certificate signed by unknown authority",
+		},
+		{
+			name:          "server dialled by another name",
+			server:        server,
+			client:        newTestReloader(t, ca, ca, bothUsages, "api-gateway"),
+			serverName:    "payment-service",
+			wantClientErr: "not payment-service",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.server.config.AllowedPeers = tt.allowedPeers
+			defer func() { tt.server.config.AllowedPeers = nil }()
+
+			serverErr, clientErr := handshake(t, tt.server, tt.client, tt.serverName)
+			switch {
+			case tt.wantServerErr != "":
+				require.Error(t, serverErr)
+				assert.Contains(t, serverErr.Error(), tt.wantServerErr)
+				assert.Error(t, clientErr, "the client sees the rejection")
+			case tt.wantClientErr != "":
+				require.Error(t, clientErr)
+				assert.Contains(t, clientErr.Error(), tt.wantClientErr)
+			default:
+				assert.NoError(t, serverErr)
+				assert.NoError(t, clientErr)
+			}
+		})
+	}
+}
+
+func TestIdentities(t *testing.T) {
+	ca := newTestCA(t, "rideshare-ca")
+	reloader := newTestReloader(t, ca, ca, bothUsages, "trip-service", "spiffe://rideshare/trip-service", "trip-service.internal")
+
+	assert.Equal(t, []string{"trip-service", "trip-service.internal", "spiffe://rideshare/trip-service"}, reloader.Identities())
+	assert.Nil(t, Identities(nil))
+	assert.Nil(t, (*Reloader)(nil).Identities())
+}
@@ -0,0 +1,172 @@
+package tls
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/shared/logger"
+)
+
+// Reloader holds a service's certificate and CA pool and reloads them when
+// the files change, so certificates can be rotated without a restart.
+//
+// A nil *Reloader means TLS is disabled: it builds plaintext transport
+// settings and Watch returns immediately.
+type Reloader struct {
+	config Config
+	logger *logger.Logger
+
+	mutex       sync.RWMutex
+	certificate *tls.Certificate
+	roots       *x509.CertPool
+	modTimes    map[string]time.Time
+}
+
+// Load returns a reloader for config with its files loaded, or nil when TLS
+// is disabled. Every service calls it at startup: gRPC traffic between
+// services uses mutual TLS when TLS_ENABLED is set, picking up rotated
+// certificates from disk while Watch runs.
+func Load(config Config, log *logger.Logger) (*Reloader, error) {
+	if !config.Enabled {
+		return nil, nil
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return NewReloader(config, log)
+}
+
+// NewReloader loads the certificate in config and, when CAFile is set, the
+// CA pool. Without a CA, peers are verified against the system roots.
+func NewReloader(config Config, log *logger.Logger) (*Reloader, error) {
+	if config.CertFile == "" || config.KeyFile == "" {
+		return nil, errors.New("certificate and key files are required")
+	}
+	r := &Reloader{config: config, logger: log}
+	if _, err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Watch reloads the files whenever they change until ctx is cancelled. A
+// file that fails to load is logged and the previous certificate kept.
+func (r *Reloader) Watch(ctx context.Context) {
+	if r == nil {
+		return
+	}
+
+	interval := r.config.ReloadInterval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloaded, err := r.reload()
+			if err != nil {
+				r.logger.WithError(err).Warn("Failed to reload TLS certificates, keeping the current ones")
+				continue
+			}
+			if reloaded {
+				r.logger.WithFields(logger.Fields{
+					"cert_file": r.config.CertFile,
+					"ca_file":   r.config.CAFile,
+				}).Info("TLS certificates reloaded")
+			}
+		}
+	}
+}
+
+// reload reads the files again if any of them changed since the last load
+func (r *Reloader) reload() (bool, error) {
+	files := []string{r.config.CertFile, r.config.KeyFile}
+	if r.config.CAFile != "" {
+		files = append(files, r.config.CAFile)
+	}
+
+	modTimes := make(map[string]time.Time, len(files))
+	changed := false
+	r.mutex.RLock()
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			r.mutex.RUnlock()
+			return false, fmt.Errorf("failed to stat %s: %w", file, err)
+		}
+		modTimes[file] = info.ModTime()
+		if !info.ModTime().Equal(r.modTimes[file]) {
+			changed = true
+		}
+	}
+	r.mutex.RUnlock()
+	if !changed {
+		return false, nil
+	}
+
+	certificate, err := tls.LoadX509KeyPair(r.config.CertFile, r.config.KeyFile)
+	if err != nil {
+		return false, fmt.Errorf("failed to load certificate: %w", err)
+	}
+	if certificate.Leaf == nil && len(certificate.Certificate) > 0 {
+		if certificate.Leaf, err = x509.ParseCertificate(certificate.Certificate[0]); err != nil {
+			return false, fmt.Errorf("failed to parse certificate: %w", err)
+		}
+	}
+
+	var roots *x509.CertPool
+	if r.config.CAFile != "" {
+		pem, err := os.ReadFile(r.config.CAFile)
+		if err != nil {
+			return false, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		roots = x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(pem) {
+			return false, fmt.Errorf("no certificates found in %s", r.config.CAFile)
+		}
+	}
+
+	r.mutex.Lock()
+	r.certificate = &certificate
+	r.roots = roots
+	r.modTimes = modTimes
+	r.mutex.Unlock()
+	return true, nil
+}
+
+// Identities returns the SANs of the service's own certificate
+func (r *Reloader) Identities() []string {
+	if r == nil {
+		return nil
+	}
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return Identities(r.certificate.Leaf)
+}
+
+func (r *Reloader) current() (*tls.Certificate, *x509.CertPool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.certificate, r.roots
+}
+
+func (r *Reloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	certificate, _ := r.current()
+	return certificate, nil
+}
+
+func (r *Reloader) getClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	certificate, _ := r.current()
+	return certificate, nil
+}
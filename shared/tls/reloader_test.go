@@ -0,0 +1,108 @@
+package tls
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// touch moves a file's modification time forward so the reloader sees it
+// changed even within the file system's timestamp resolution
+func touch(t *testing.T, files ...string) {
+	t.Helper()
+	later := time.Now().Add(time.Minute)
+	for _, file := range files {
+		require.NoError(t, os.Chtimes(file, later, later))
+	}
+}
+
+func TestReloader_PicksUpRotatedCertificates(t *testing.T) {
+	oldCA := newTestCA(t, "rideshare-ca-1")
+	newCA := newTestCA(t, "rideshare-ca-2")
+	dir := t.TempDir()
+	server, err := NewReloader(writeIdentity(t, dir, oldCA, oldCA, bothUsages, "trip-service"), nil)
+	require.NoError(t, err)
+	oldClient := newTestReloader(t, oldCA, oldCA, bothUsages, "api-gateway")
+	newClient := newTestReloader(t, newCA, newCA, bothUsages, "api-gateway")
+
+	reloaded, err := server.reload()
+	require.NoError(t, err)
+	assert.False(t, reloaded, "unchanged files are not read again")
+
+	serverErr, clientErr := handshake(t, server, oldClient, "trip-service")
+	require.NoError(t, serverErr)
+	require.NoError(t, clientErr)
+	serverErr, _ = handshake(t, server, newClient, "trip-service")
+	require.Error(t, serverErr, "the new CA is not trusted before rotation")
+
+	// Rotate the certificate and CA bundle in place
+	config := writeIdentity(t, dir, newCA, newCA, bothUsages, "trip-service")
+	touch(t, config.CertFile, config.KeyFile, config.CAFile)
+	reloaded, err = server.reload()
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+
+	serverErr, clientErr = handshake(t, server, newClient, "trip-service")
+	assert.NoError(t, serverErr)
+	assert.NoError(t, clientErr)
+	serverErr, clientErr = handshake(t, server, oldClient, "trip-service")
+	assert.Error(t, serverErr, "new connections are verified against the rotated CA")
+	assert.Error(t, clientErr)
+}
+
+func TestReloader_KeepsCertificatesThatFailToReload(t *testing.T) {
+	ca := newTestCA(t, "rideshare-ca")
+	config := writeIdentity(t, t.TempDir(), ca, ca, bothUsages, "trip-service")
+	server, err := NewReloader(config, nil)
+	require.NoError(t, err)
+	before, _ := server.current()
+
+	tests := []struct {
+		name    string
+		corrupt func()
+	}{
+		{"unreadable key", func() { require.NoError(t, os.WriteFile(config.KeyFile, []byte("not a key"), 0o600)) }},
+		{"empty CA bundle", func() { require.NoError(t, os.WriteFile(config.CAFile, []byte("\n"), 0o600)) }},
+		{"missing certificate", func() { require.NoError(t, os.Remove(config.CertFile)) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := writeIdentity(t, filepath.Dir(config.CertFile), ca, ca, bothUsages, "trip-service")
+			tt.corrupt()
+			for _, file := range []string{config.CertFile, config.KeyFile, config.CAFile} {
+				if _, err := os.Stat(file); err == nil {
+					touch(t, file)
+				}
+			}
+
+			reloaded, err := server.reload()
+			assert.Error(t, err)
+			assert.False(t, reloaded)
+			after, _ := server.current()
+			assert.Same(t, before, after)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	reloader, err := Load(Config{}, nil)
+	require.NoError(t, err)
+	assert.Nil(t, reloader, "TLS is disabled")
+	assert.Nil(t, reloader.ServerOptions())
+	assert.NotNil(t, reloader.DialOption())
+	reloader.Watch(context.Background())
+
+	_, err = Load(Config{Enabled: true, CertFile: "tls.crt", KeyFile: "tls.key"}, nil)
+	assert.EqualError(t, err, "TLS_CA_FILE is required when TLS is enabled")
+
+	ca := newTestCA(t, "rideshare-ca")
+	reloader, err = Load(writeIdentity(t, t.TempDir(), ca, ca, bothUsages, "trip-service"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"trip-service"}, reloader.Identities())
+	assert.Len(t, reloader.ServerOptions(), 1)
+}
@@ -0,0 +1,37 @@
+package tls
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedirectHandler(t *testing.T) {
+	tests := []struct {
+		name      string
+		httpsAddr string
+		host      string
+		target    string
+		want      string
+	}{
+		{"custom port", ":8443", "rides.example.com:8080", "/api/v1/trips?status=active", "https://rides.example.com:8443/api/v1/trips?status=active"},
+		{"default port", ":443", "rides.example.com:8080", "/health", "https://rides.example.com/health"},
+		{"host without port", "0.0.0.0:8443", "rides.example.com", "/", "https://rides.example.com:8443/"},
+		{"IPv6 host", ":8443", "[::1]:8080", "/health", "https://[::1]:8443/health"},
+		{"no port", "", "rides.example.com:8080", "/health", "https://rides.example.com/health"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			req.Host = tt.host
+			rec := httptest.NewRecorder()
+
+			RedirectHandler(tt.httpsAddr).ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusPermanentRedirect, rec.Code)
+			assert.Equal(t, tt.want, rec.Header().Get("Location"))
+		})
+	}
+}
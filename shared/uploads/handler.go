@@ -0,0 +1,165 @@
+package uploads
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Handler serves document uploads: signing upload URLs, completing uploads
+// and looking documents up
+type Handler struct {
+	uploader *Uploader
+}
+
+// NewHandler creates a new document upload handler
+func NewHandler(uploader *Uploader) *Handler {
+	return &Handler{uploader: uploader}
+}
+
+// RegisterRoutes registers the document routes
+func (h *Handler) RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /api/v1/documents/uploads", h.RequestUpload)
+	mux.HandleFunc("POST /api/v1/documents/{id}/complete", h.CompleteUpload)
+	mux.HandleFunc("GET /api/v1/documents/{id}", h.GetDocument)
+	mux.HandleFunc("GET /api/v1/documents/{id}/download", h.DownloadURL)
+	mux.HandleFunc("GET /api/v1/documents/{id}/photo", h.PhotoURLs)
+	mux.HandleFunc("GET /api/v1/documents/expiring", h.ListExpiring)
+	mux.HandleFunc("GET /api/v1/documents/owners/{owner_type}/{owner_id}", h.ListByOwner)
+}
+
+// RequestUpload records a document and returns the URL to upload its file to
+func (h *Handler) RequestUpload(w http.ResponseWriter, r *http.Request) {
+	var req UploadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err)
+		return
+	}
+
+	upload, err := h.uploader.RequestUpload(r.Context(), &req)
+	if err != nil {
+		writeUploadError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, upload)
+}
+
+// CompleteUpload checks and scans a document once its file is uploaded
+func (h *Handler) CompleteUpload(w http.ResponseWriter, r *http.Request) {
+	doc, err := h.uploader.CompleteUpload(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeUploadError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, doc)
+}
+
+// GetDocument returns a document's metadata
+func (h *Handler) GetDocument(w http.ResponseWriter, r *http.Request) {
+	doc, err := h.uploader.Get(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeUploadError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, doc)
+}
+
+// DownloadURL returns a short-lived URL to download an accepted document from
+func (h *Handler) DownloadURL(w http.ResponseWriter, r *http.Request) {
+	download, err := h.uploader.DownloadURL(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeUploadError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, download)
+}
+
+// PhotoURLs returns the signed URLs an accepted photo is served at in each size
+func (h *Handler) PhotoURLs(w http.ResponseWriter, r *http.Request) {
+	urls, err := h.uploader.PhotoURLs(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeUploadError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"document_id": r.PathValue("id"),
+		"variants":    urls,
+	})
+}
+
+// ListExpiring returns accepted documents expiring before an RFC3339 time,
+// by default within the next 30 days
+func (h *Handler) ListExpiring(w http.ResponseWriter, r *http.Request) {
+	cutoff := time.Now().Add(30 * 24 * time.Hour)
+	if before := r.URL.Query().Get("before"); before != "" {
+		parsed, err := time.Parse(time.RFC3339, before)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid_request", fmt.Errorf("before must be an RFC3339 timestamp: %w", err))
+			return
+		}
+		cutoff = parsed
+	}
+
+	documents, err := h.uploader.ExpiringBefore(r.Context(), cutoff)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal_error", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"documents": documents,
+		"count":     len(documents),
+	})
+}
+
+// ListByOwner returns a driver's or vehicle's documents, newest first
+func (h *Handler) ListByOwner(w http.ResponseWriter, r *http.Request) {
+	ownerType := OwnerType(r.PathValue("owner_type"))
+	if ownerType != OwnerDriver && ownerType != OwnerVehicle {
+		writeError(w, http.StatusBadRequest, "invalid_request", fmt.Errorf("owner type must be %s or %s", OwnerDriver, OwnerVehicle))
+		return
+	}
+
+	documents, err := h.uploader.ListByOwner(r.Context(), ownerType, r.PathValue("owner_id"))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "internal_error", err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"documents": documents,
+		"count":     len(documents),
+	})
+}
+
+func writeUploadError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrDocumentNotFound):
+		writeError(w, http.StatusNotFound, "not_found", err)
+	case errors.Is(err, ErrInvalidUpload):
+		writeError(w, http.StatusBadRequest, "invalid_upload", err)
+	case errors.Is(err, ErrNotPhoto):
+		writeError(w, http.StatusBadRequest, "not_photo", err)
+	case errors.Is(err, ErrNotUploaded):
+		writeError(w, http.StatusConflict, "not_uploaded", err)
+	case errors.Is(err, ErrNotAccepted):
+		writeError(w, http.StatusConflict, "not_accepted", err)
+	case errors.Is(err, ErrDocumentRejected):
+		writeError(w, http.StatusUnprocessableEntity, "document_rejected", err)
+	default:
+		writeError(w, http.StatusInternalServerError, "internal_error", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, code string, err error) {
+	writeJSON(w, status, map[string]string{
+		"error":   code,
+		"message": err.Error(),
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,121 @@
+package uploads
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rideshare-platform/shared/awssig"
+)
+
+// DefaultMediaURLTTL is how long signed photo URLs are valid
+const DefaultMediaURLTTL = 24 * time.Hour
+
+// Variant is a size photos are served in. Photos are scaled down to fit
+// within Width by Height, keeping their aspect ratio.
+type Variant struct {
+	Name   string
+	Width  int
+	Height int
+}
+
+var (
+	VariantThumbnail = Variant{Name: "thumbnail", Width: 128, Height: 128}
+	VariantSmall     = Variant{Name: "small", Width: 320, Height: 320}
+	VariantMedium    = Variant{Name: "medium", Width: 640, Height: 640}
+	VariantLarge     = Variant{Name: "large", Width: 1280, Height: 1280}
+)
+
+// Variants are the sizes photos are served in, smallest first
+var Variants = []Variant{VariantThumbnail, VariantSmall, VariantMedium, VariantLarge}
+
+// MediaConfig configures the image CDN photos are resized and served by
+type MediaConfig struct {
+	// BaseURL is the CDN photos are served from. Without it photos are
+	// served at their original size through pre-signed object store URLs.
+	BaseURL string `yaml:"base_url" env:"UPLOADS_MEDIA_BASE_URL"`
+	// SigningKey is the secret the CDN checks URL signatures with
+	SigningKey string        `yaml:"signing_key" env:"UPLOADS_MEDIA_SIGNING_KEY"`
+	URLTTL     time.Duration `yaml:"url_ttl" env:"UPLOADS_MEDIA_URL_TTL" default:"24h"`
+}
+
+// Validate checks that a CDN has a key to sign URLs for it with
+func (c *MediaConfig) Validate() error {
+	if c.BaseURL != "" && c.SigningKey == "" {
+		return fmt.Errorf("UPLOADS_MEDIA_SIGNING_KEY is required with UPLOADS_MEDIA_BASE_URL")
+	}
+	if c.URLTTL <= 0 || c.URLTTL > 7*24*time.Hour {
+		return fmt.Errorf("UPLOADS_MEDIA_URL_TTL must be positive and at most 168h, got %s", c.URLTTL)
+	}
+	return nil
+}
+
+// Media signs the URLs accepted photos are served at. With a CDN each
+// variant is requested as
+//
+//	{base_url}/{width}x{height}/{key}?expires={unix}&signature={hex}
+//
+// where the signature is the HMAC-SHA256 of the path and expires query,
+// keyed with the signing key. The CDN checks the signature, fetches the
+// original from the bucket and resizes it.
+type Media struct {
+	config  MediaConfig
+	objects ObjectStore
+	now     func() time.Time
+}
+
+// NewMedia creates a signer for photos kept in objects
+func NewMedia(config MediaConfig, objects ObjectStore) *Media {
+	config.BaseURL = strings.TrimRight(config.BaseURL, "/")
+	if config.URLTTL <= 0 {
+		config.URLTTL = DefaultMediaURLTTL
+	}
+	return &Media{config: config, objects: objects, now: time.Now}
+}
+
+// URL signs the URL of the photo at key in the variant's size
+func (m *Media) URL(key string, variant Variant) (string, error) {
+	if m.config.BaseURL == "" {
+		signed, err := m.objects.PresignGet(key, m.config.URLTTL)
+		if err != nil {
+			return "", fmt.Errorf("failed to sign photo URL: %w", err)
+		}
+		return signed.URL, nil
+	}
+
+	// Expiry is rounded up to the hour, so a photo keeps one URL for an
+	// hour at a time and clients and the CDN can cache it
+	expires := m.now().Add(m.config.URLTTL).Truncate(time.Hour).Add(time.Hour).Unix()
+	path := "/" + strconv.Itoa(variant.Width) + "x" + strconv.Itoa(variant.Height) + "/" + awssig.EscapePath(key)
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	mac := hmac.New(sha256.New, []byte(m.config.SigningKey))
+	mac.Write([]byte(path + "?" + query.Encode()))
+	signature := hex.EncodeToString(mac.Sum(nil))
+	query.Set("signature", signature)
+	return m.config.BaseURL + path + "?" + query.Encode(), nil
+}
+
+// URLs signs the URLs of the photo at key in every variant, keyed by variant name
+func (m *Media) URLs(key string) (map[string]string, error) {
+	urls := make(map[string]string, len(Variants))
+	for _, variant := range Variants {
+		signed, err := m.URL(key, variant)
+		if err != nil {
+			return nil, err
+		}
+		urls[variant.Name] = signed
+	}
+	return urls, nil
+}
+
+// isImage reports whether a content type is accepted for photos
+func isImage(contentType string) bool {
+	_, allowed := ContentTypes[contentType]
+	return allowed && strings.HasPrefix(contentType, "image/")
+}
@@ -0,0 +1,22 @@
+-- Metadata of documents drivers upload; the files live in the object store
+CREATE TABLE IF NOT EXISTS uploaded_documents (
+    id VARCHAR(255) PRIMARY KEY,
+    owner_type VARCHAR(50) NOT NULL,
+    owner_id VARCHAR(255) NOT NULL,
+    document_type VARCHAR(100) NOT NULL,
+    document_number VARCHAR(255) NOT NULL DEFAULT '',
+    object_key TEXT NOT NULL,
+    url TEXT NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL DEFAULT 0,
+    status VARCHAR(50) NOT NULL,
+    rejection_reason TEXT NOT NULL DEFAULT '',
+    expires_at TIMESTAMP WITH TIME ZONE,
+    uploaded_at TIMESTAMP WITH TIME ZONE,
+    scanned_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_uploaded_documents_owner ON uploaded_documents(owner_type, owner_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_uploaded_documents_expiry ON uploaded_documents(expires_at) WHERE status = 'accepted';
@@ -0,0 +1,16 @@
+// Package migrations holds the PostgreSQL schema of document upload metadata
+package migrations
+
+import (
+	"embed"
+
+	"github.com/rideshare-platform/shared/database"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+// Load returns document upload storage's migrations in version order
+func Load() ([]database.Migration, error) {
+	return database.LoadMigrations(files)
+}
@@ -0,0 +1,154 @@
+package uploads
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/rideshare-platform/shared/awssig"
+)
+
+// PresignedURL is a request the client can make to the object store without
+// credentials until it expires. Headers must be sent as given.
+type PresignedURL struct {
+	Method    string            `json:"method"`
+	URL       string            `json:"url"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+// ObjectInfo describes an object in the store
+type ObjectInfo struct {
+	SizeBytes   int64
+	ContentType string
+}
+
+// ObjectStore signs requests against the bucket documents are kept in
+type ObjectStore interface {
+	// PresignPut signs an upload of the object at key with the content type
+	PresignPut(key, contentType string, ttl time.Duration) (*PresignedURL, error)
+	// PresignGet signs a download of the object at key
+	PresignGet(key string, ttl time.Duration) (*PresignedURL, error)
+	// URL returns the object's unsigned location
+	URL(key string) string
+	// Stat returns ErrObjectNotFound if nothing was uploaded at key
+	Stat(ctx context.Context, key string) (*ObjectInfo, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// S3Config configures an S3-compatible bucket. Google Cloud Storage is
+// used through its interoperability endpoint, https://storage.googleapis.com,
+// with HMAC keys.
+type S3Config struct {
+	Endpoint        string `yaml:"endpoint" env:"UPLOADS_S3_ENDPOINT" default:"https://s3.amazonaws.com"`
+	Region          string `yaml:"region" env:"UPLOADS_S3_REGION" default:"us-east-1"`
+	Bucket          string `yaml:"bucket" env:"UPLOADS_S3_BUCKET"`
+	AccessKeyID     string `yaml:"access_key_id" env:"UPLOADS_S3_ACCESS_KEY_ID"`
+	SecretAccessKey string `yaml:"secret_access_key" env:"UPLOADS_S3_SECRET_ACCESS_KEY"`
+}
+
+// S3Store signs requests against an S3-compatible bucket with AWS Signature
+// Version 4 query parameters, addressing objects path-style
+type S3Store struct {
+	config S3Config
+	signer *awssig.Signer
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewS3Store creates an object store for the configured bucket
+func NewS3Store(config S3Config) *S3Store {
+	config.Endpoint = strings.TrimRight(config.Endpoint, "/")
+	return &S3Store{
+		config: config,
+		signer: awssig.NewS3Signer(config.AccessKeyID, config.SecretAccessKey, config.Region),
+		client: &http.Client{Timeout: 30 * time.Second},
+		now:    time.Now,
+	}
+}
+
+// PresignPut signs an upload of the object at key. The content type is
+// signed, so the client must upload with the same Content-Type.
+func (s *S3Store) PresignPut(key, contentType string, ttl time.Duration) (*PresignedURL, error) {
+	return s.presign(http.MethodPut, key, map[string]string{"Content-Type": contentType}, ttl)
+}
+
+// PresignGet signs a download of the object at key
+func (s *S3Store) PresignGet(key string, ttl time.Duration) (*PresignedURL, error) {
+	return s.presign(http.MethodGet, key, nil, ttl)
+}
+
+// URL returns the path-style URL of the object at key
+func (s *S3Store) URL(key string) string {
+	return s.config.Endpoint + "/" + awssig.EscapePath(s.config.Bucket+"/"+key)
+}
+
+// Stat looks the object at key up with a HEAD request
+func (s *S3Store) Stat(ctx context.Context, key string) (*ObjectInfo, error) {
+	resp, err := s.do(ctx, http.MethodHead, key)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("%w: %s", ErrObjectNotFound, key)
+	case resp.StatusCode/100 != 2:
+		return nil, fmt.Errorf("failed to stat %s: %s", key, resp.Status)
+	}
+	return &ObjectInfo{
+		SizeBytes:   resp.ContentLength,
+		ContentType: resp.Header.Get("Content-Type"),
+	}, nil
+}
+
+// Delete removes the object at key. Deleting a missing object succeeds.
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("failed to delete %s: %s", key, resp.Status)
+	}
+	return nil
+}
+
+func (s *S3Store) do(ctx context.Context, method, key string) (*http.Response, error) {
+	signed, err := s.presign(method, key, nil, time.Minute)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, signed.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s request: %w", strings.ToLower(method), err)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to %s %s: %w", strings.ToLower(method), key, err)
+	}
+	return resp, nil
+}
+
+// presign signs a request for the object at key in its query string, so it
+// can be made without credentials. headers are signed and must be sent.
+func (s *S3Store) presign(method, key string, headers map[string]string, ttl time.Duration) (*PresignedURL, error) {
+	target, err := url.Parse(s.URL(key))
+	if err != nil {
+		return nil, fmt.Errorf("invalid object URL: %w", err)
+	}
+
+	now := s.now()
+	return &PresignedURL{
+		Method:    method,
+		URL:       s.signer.Presign(method, target, headers, ttl, now).String(),
+		Headers:   headers,
+		ExpiresAt: now.UTC().Add(ttl),
+	}, nil
+}
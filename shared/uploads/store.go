@@ -0,0 +1,216 @@
+package uploads
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rideshare-platform/shared/database"
+	"github.com/rideshare-platform/shared/logger"
+	"github.com/rideshare-platform/shared/uploads/migrations"
+)
+
+// MigrationService is the name document storage's migrations are recorded under
+const MigrationService = "uploads"
+
+// MemoryStore keeps document metadata in memory. It is lost on restart, so
+// it only suits development and tests.
+type MemoryStore struct {
+	documents map[string]*Document
+	mutex     sync.RWMutex
+}
+
+// NewMemoryStore creates an empty in-memory document store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{documents: make(map[string]*Document)}
+}
+
+// Save adds or replaces a copy of the document
+func (s *MemoryStore) Save(ctx context.Context, doc *Document) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.documents[doc.ID] = copyDocument(doc)
+	return nil
+}
+
+// Get returns a copy of a document
+func (s *MemoryStore) Get(ctx context.Context, id string) (*Document, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	doc, exists := s.documents[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrDocumentNotFound, id)
+	}
+	return copyDocument(doc), nil
+}
+
+// ListByOwner returns an owner's documents, newest first
+func (s *MemoryStore) ListByOwner(ctx context.Context, ownerType OwnerType, ownerID string) ([]*Document, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	documents := []*Document{}
+	for _, doc := range s.documents {
+		if doc.OwnerType == ownerType && doc.OwnerID == ownerID {
+			documents = append(documents, copyDocument(doc))
+		}
+	}
+	sort.Slice(documents, func(i, j int) bool {
+		return documents[i].CreatedAt.After(documents[j].CreatedAt)
+	})
+	return documents, nil
+}
+
+// ExpiringBefore returns accepted documents expiring before cutoff, soonest first
+func (s *MemoryStore) ExpiringBefore(ctx context.Context, cutoff time.Time) ([]*Document, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	documents := []*Document{}
+	for _, doc := range s.documents {
+		if doc.Status == StatusAccepted && doc.ExpiresAt != nil && doc.ExpiresAt.Before(cutoff) {
+			documents = append(documents, copyDocument(doc))
+		}
+	}
+	sort.Slice(documents, func(i, j int) bool {
+		return documents[i].ExpiresAt.Before(*documents[j].ExpiresAt)
+	})
+	return documents, nil
+}
+
+func copyDocument(doc *Document) *Document {
+	copied := *doc
+	for _, t := range []**time.Time{&copied.ExpiresAt, &copied.UploadedAt, &copied.ScannedAt} {
+		if *t != nil {
+			value := **t
+			*t = &value
+		}
+	}
+	return &copied
+}
+
+// Migrate creates or upgrades the document tables in db
+func Migrate(ctx context.Context, db *sql.DB, log *logger.Logger) error {
+	schema, err := migrations.Load()
+	if err != nil {
+		return err
+	}
+	_, err = database.NewMigrator(db, MigrationService, schema, log).Up(ctx)
+	return err
+}
+
+// PostgresStore keeps document metadata in the uploaded_documents table
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// NewPostgresStore creates a document store backed by PostgreSQL
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+const documentColumns = `id, owner_type, owner_id, document_type, document_number, object_key, url,
+	content_type, size_bytes, status, rejection_reason, expires_at, uploaded_at, scanned_at,
+	created_at, updated_at`
+
+// Save adds or replaces the document
+func (s *PostgresStore) Save(ctx context.Context, doc *Document) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO uploaded_documents (`+documentColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+		ON CONFLICT (id) DO UPDATE
+		SET document_number = EXCLUDED.document_number, content_type = EXCLUDED.content_type,
+			size_bytes = EXCLUDED.size_bytes, status = EXCLUDED.status,
+			rejection_reason = EXCLUDED.rejection_reason, expires_at = EXCLUDED.expires_at,
+			uploaded_at = EXCLUDED.uploaded_at, scanned_at = EXCLUDED.scanned_at,
+			updated_at = EXCLUDED.updated_at`,
+		doc.ID, doc.OwnerType, doc.OwnerID, doc.DocumentType, doc.DocumentNumber, doc.Key, doc.URL,
+		doc.ContentType, doc.SizeBytes, doc.Status, doc.RejectionReason, doc.ExpiresAt, doc.UploadedAt, doc.ScannedAt,
+		doc.CreatedAt, doc.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save document %s: %w", doc.ID, err)
+	}
+	return nil
+}
+
+// Get returns a document
+func (s *PostgresStore) Get(ctx context.Context, id string) (*Document, error) {
+	row := s.db.QueryRowContext(ctx, `SELECT `+documentColumns+` FROM uploaded_documents WHERE id = $1`, id)
+	doc, err := scanDocument(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrDocumentNotFound, id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document: %w", err)
+	}
+	return doc, nil
+}
+
+// ListByOwner returns an owner's documents, newest first
+func (s *PostgresStore) ListByOwner(ctx context.Context, ownerType OwnerType, ownerID string) ([]*Document, error) {
+	return s.query(ctx, `SELECT `+documentColumns+` FROM uploaded_documents
+		WHERE owner_type = $1 AND owner_id = $2 ORDER BY created_at DESC`, ownerType, ownerID)
+}
+
+// ExpiringBefore returns accepted documents expiring before cutoff, soonest first
+func (s *PostgresStore) ExpiringBefore(ctx context.Context, cutoff time.Time) ([]*Document, error) {
+	return s.query(ctx, `SELECT `+documentColumns+` FROM uploaded_documents
+		WHERE status = $1 AND expires_at < $2 ORDER BY expires_at`, StatusAccepted, cutoff)
+}
+
+func (s *PostgresStore) query(ctx context.Context, query string, args ...interface{}) ([]*Document, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list documents: %w", err)
+	}
+	defer rows.Close()
+
+	documents := []*Document{}
+	for rows.Next() {
+		doc, err := scanDocument(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan document: %w", err)
+		}
+		documents = append(documents, doc)
+	}
+	return documents, rows.Err()
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanDocument(row rowScanner) (*Document, error) {
+	var doc Document
+	var expiresAt, uploadedAt, scannedAt sql.NullTime
+	err := row.Scan(
+		&doc.ID, &doc.OwnerType, &doc.OwnerID, &doc.DocumentType, &doc.DocumentNumber, &doc.Key, &doc.URL,
+		&doc.ContentType, &doc.SizeBytes, &doc.Status, &doc.RejectionReason, &expiresAt, &uploadedAt, &scannedAt,
+		&doc.CreatedAt, &doc.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, field := range []struct {
+		value  sql.NullTime
+		target **time.Time
+	}{
+		{expiresAt, &doc.ExpiresAt},
+		{uploadedAt, &doc.UploadedAt},
+		{scannedAt, &doc.ScannedAt},
+	} {
+		if field.value.Valid {
+			t := field.value.Time.UTC()
+			*field.target = &t
+		}
+	}
+	doc.CreatedAt = doc.CreatedAt.UTC()
+	doc.UpdatedAt = doc.UpdatedAt.UTC()
+	return &doc, nil
+}
@@ -0,0 +1,426 @@
+package uploads
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	sharedconfig "github.com/rideshare-platform/shared/config"
+	"github.com/rideshare-platform/shared/logger"
+)
+
+const (
+	// DefaultURLTTL is how long pre-signed upload and download URLs are valid
+	DefaultURLTTL = 15 * time.Minute
+	// DefaultMaxSizeBytes is the largest file accepted
+	DefaultMaxSizeBytes = 10 << 20
+)
+
+// ContentTypes are the file types documents can be uploaded as, with the
+// extension their objects are stored under
+var ContentTypes = map[string]string{
+	"application/pdf": "pdf",
+	"image/jpeg":      "jpg",
+	"image/png":       "png",
+	"image/heic":      "heic",
+}
+
+// Config holds a service's document upload settings
+type Config struct {
+	Enabled      bool          `yaml:"enabled" env:"UPLOADS_ENABLED" default:"false"`
+	URLTTL       time.Duration `yaml:"url_ttl" env:"UPLOADS_URL_TTL" default:"15m"`
+	MaxSizeBytes int64         `yaml:"max_size_bytes" env:"UPLOADS_MAX_SIZE_BYTES" default:"10485760"`
+	// Prefix is prepended to every object's key
+	Prefix string   `yaml:"prefix" env:"UPLOADS_PREFIX" default:"documents"`
+	S3     S3Config `yaml:"s3"`
+	// DatabaseURL keeps document metadata in PostgreSQL. Without it metadata
+	// is kept in memory and lost on restart.
+	DatabaseURL string `yaml:"database_url" env:"UPLOADS_DATABASE_URL"`
+	// Media is the CDN accepted photos are served from in resized variants
+	Media MediaConfig `yaml:"media"`
+}
+
+// LoadConfig reads the upload settings from the UPLOADS_* environment
+// variables, for services that do not load their config through the shared loader
+func LoadConfig() (Config, error) {
+	var config Config
+	if err := sharedconfig.NewLoader().WithFile("").Load(&config); err != nil {
+		return Config{}, err
+	}
+	return config, nil
+}
+
+// Validate checks that an enabled config names a bucket it can sign for
+func (c *Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.S3.Bucket == "" || c.S3.AccessKeyID == "" || c.S3.SecretAccessKey == "" {
+		return errors.New("UPLOADS_S3_BUCKET, UPLOADS_S3_ACCESS_KEY_ID and UPLOADS_S3_SECRET_ACCESS_KEY are required for uploads")
+	}
+	// Signature Version 4 URLs are valid for at most a week
+	if c.URLTTL <= 0 || c.URLTTL > 7*24*time.Hour {
+		return fmt.Errorf("UPLOADS_URL_TTL must be positive and at most 168h, got %s", c.URLTTL)
+	}
+	if c.MaxSizeBytes <= 0 {
+		return fmt.Errorf("UPLOADS_MAX_SIZE_BYTES must be positive, got %d", c.MaxSizeBytes)
+	}
+	return c.Media.Validate()
+}
+
+// UploadRequest asks for a URL to upload a document to
+type UploadRequest struct {
+	OwnerType      OwnerType  `json:"owner_type"`
+	OwnerID        string     `json:"owner_id"`
+	DocumentType   string     `json:"document_type"`
+	DocumentNumber string     `json:"document_number,omitempty"`
+	ContentType    string     `json:"content_type"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+}
+
+// Upload is a recorded document and the URL its file is uploaded to
+type Upload struct {
+	Document *Document     `json:"document"`
+	Upload   *PresignedURL `json:"upload"`
+}
+
+// Uploader hands out upload URLs for registered document types, checks and
+// scans uploaded files, and tells the owning service about accepted ones
+type Uploader struct {
+	store    Store
+	objects  ObjectStore
+	media    *Media
+	scanner  Scanner
+	logger   *logger.Logger
+	urlTTL   time.Duration
+	maxSize  int64
+	prefix   string
+	now      func() time.Time
+	db       *sql.DB
+	hooks    []AcceptedHook
+	types    map[string]DocumentType
+	typesMux sync.RWMutex
+
+	// completing serialises upload completion so a document is scanned once
+	completing sync.Mutex
+}
+
+// NewUploader creates an uploader signing DefaultURLTTL URLs for files up
+// to DefaultMaxSizeBytes
+func NewUploader(store Store, objects ObjectStore, logger *logger.Logger) *Uploader {
+	return &Uploader{
+		store:   store,
+		objects: objects,
+		media:   NewMedia(MediaConfig{}, objects),
+		logger:  logger,
+		urlTTL:  DefaultURLTTL,
+		maxSize: DefaultMaxSizeBytes,
+		prefix:  "documents",
+		now:     time.Now,
+		types:   make(map[string]DocumentType),
+	}
+}
+
+// Open creates an uploader from config. A PostgreSQL store needs the
+// postgres driver registered by the caller and is migrated on open.
+func Open(ctx context.Context, config Config, log *logger.Logger) (*Uploader, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	var store Store = NewMemoryStore()
+	var db *sql.DB
+	if config.DatabaseURL != "" {
+		var err error
+		db, err = sql.Open("postgres", config.DatabaseURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to uploads database: %w", err)
+		}
+		if err := db.PingContext(ctx); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to ping uploads database: %w", err)
+		}
+		if err := Migrate(ctx, db, log); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to migrate uploads database: %w", err)
+		}
+		store = NewPostgresStore(db)
+	}
+
+	uploader := NewUploader(store, NewS3Store(config.S3), log)
+	uploader.SetURLTTL(config.URLTTL)
+	uploader.SetMaxSize(config.MaxSizeBytes)
+	uploader.prefix = strings.Trim(config.Prefix, "/")
+	uploader.media = NewMedia(config.Media, uploader.objects)
+	uploader.db = db
+	return uploader, nil
+}
+
+// Close releases the uploads database opened by Open
+func (u *Uploader) Close() error {
+	if u.db == nil {
+		return nil
+	}
+	return u.db.Close()
+}
+
+// SetURLTTL sets how long pre-signed URLs are valid
+func (u *Uploader) SetURLTTL(ttl time.Duration) {
+	if ttl > 0 {
+		u.urlTTL = ttl
+	}
+}
+
+// SetMaxSize sets the largest file accepted, in bytes
+func (u *Uploader) SetMaxSize(size int64) {
+	if size > 0 {
+		u.maxSize = size
+	}
+}
+
+// SetScanner attaches the virus scanner uploaded files are checked with.
+// Without one files are accepted unscanned.
+func (u *Uploader) SetScanner(scanner Scanner) {
+	u.scanner = scanner
+}
+
+// Media returns the signer of the URLs accepted photos are served at
+func (u *Uploader) Media() *Media {
+	return u.media
+}
+
+// RegisterTypes adds the document types that can be uploaded
+func (u *Uploader) RegisterTypes(types ...DocumentType) {
+	u.typesMux.Lock()
+	defer u.typesMux.Unlock()
+	for _, docType := range types {
+		u.types[string(docType.Owner)+"/"+docType.Name] = docType
+	}
+}
+
+// OnAccepted adds hooks called with every accepted document
+func (u *Uploader) OnAccepted(hooks ...AcceptedHook) {
+	u.hooks = append(u.hooks, hooks...)
+}
+
+// RequestUpload records a document and signs the URL its file is uploaded
+// to. The file must be uploaded with the requested content type.
+func (u *Uploader) RequestUpload(ctx context.Context, req *UploadRequest) (*Upload, error) {
+	if err := u.validate(req); err != nil {
+		return nil, err
+	}
+
+	now := u.now().UTC()
+	id := fmt.Sprintf("doc_%d", now.UnixNano())
+	key := path.Join(u.prefix, string(req.OwnerType), req.OwnerID, id+"."+ContentTypes[req.ContentType])
+	doc := &Document{
+		ID:             id,
+		OwnerType:      req.OwnerType,
+		OwnerID:        req.OwnerID,
+		DocumentType:   req.DocumentType,
+		DocumentNumber: strings.TrimSpace(req.DocumentNumber),
+		Key:            key,
+		URL:            u.objects.URL(key),
+		ContentType:    req.ContentType,
+		Status:         StatusPendingUpload,
+		ExpiresAt:      req.ExpiresAt,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	upload, err := u.objects.PresignPut(key, req.ContentType, u.urlTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign upload URL: %w", err)
+	}
+	if err := u.store.Save(ctx, doc); err != nil {
+		return nil, err
+	}
+	return &Upload{Document: doc, Upload: upload}, nil
+}
+
+// CompleteUpload checks a document's uploaded file, scans it and, if it
+// passes, accepts the document and runs the accepted hooks. Completing an
+// accepted document runs the hooks again, so a failed hook can be retried.
+func (u *Uploader) CompleteUpload(ctx context.Context, id string) (*Document, error) {
+	u.completing.Lock()
+	defer u.completing.Unlock()
+
+	doc, err := u.store.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch doc.Status {
+	case StatusRejected:
+		return nil, fmt.Errorf("%w: %s", ErrDocumentRejected, doc.RejectionReason)
+	case StatusPendingUpload:
+		info, err := u.objects.Stat(ctx, doc.Key)
+		if errors.Is(err, ErrObjectNotFound) {
+			return nil, ErrNotUploaded
+		}
+		if err != nil {
+			return nil, err
+		}
+		now := u.now().UTC()
+		doc.SizeBytes = info.SizeBytes
+		doc.UploadedAt = &now
+		if info.SizeBytes > u.maxSize {
+			return nil, u.reject(ctx, doc, fmt.Sprintf("file is %d bytes, the limit is %d", info.SizeBytes, u.maxSize))
+		}
+		doc.Status = StatusPendingScan
+		doc.UpdatedAt = now
+		if err := u.store.Save(ctx, doc); err != nil {
+			return nil, err
+		}
+		fallthrough
+	case StatusPendingScan:
+		if err := u.scan(ctx, doc); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, hook := range u.hooks {
+		if err := hook(ctx, doc); err != nil {
+			return nil, fmt.Errorf("failed to apply accepted document %s: %w", doc.ID, err)
+		}
+	}
+	return doc, nil
+}
+
+// Get returns a document
+func (u *Uploader) Get(ctx context.Context, id string) (*Document, error) {
+	return u.store.Get(ctx, id)
+}
+
+// ListByOwner returns an owner's documents, newest first
+func (u *Uploader) ListByOwner(ctx context.Context, ownerType OwnerType, ownerID string) ([]*Document, error) {
+	return u.store.ListByOwner(ctx, ownerType, ownerID)
+}
+
+// ExpiringBefore returns accepted documents expiring before cutoff, soonest first
+func (u *Uploader) ExpiringBefore(ctx context.Context, cutoff time.Time) ([]*Document, error) {
+	return u.store.ExpiringBefore(ctx, cutoff)
+}
+
+// DownloadURL signs a download of an accepted document's file
+func (u *Uploader) DownloadURL(ctx context.Context, id string) (*PresignedURL, error) {
+	doc, err := u.store.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if doc.Status != StatusAccepted {
+		return nil, ErrNotAccepted
+	}
+	return u.objects.PresignGet(doc.Key, u.urlTTL)
+}
+
+// PhotoURLs signs the URLs an accepted photo is served at in every variant,
+// keyed by variant name
+func (u *Uploader) PhotoURLs(ctx context.Context, id string) (map[string]string, error) {
+	doc, err := u.store.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	u.typesMux.RLock()
+	docType := u.types[string(doc.OwnerType)+"/"+doc.DocumentType]
+	u.typesMux.RUnlock()
+	if !docType.Photo {
+		return nil, ErrNotPhoto
+	}
+	if doc.Status != StatusAccepted {
+		return nil, ErrNotAccepted
+	}
+	return u.media.URLs(doc.Key)
+}
+
+// scan runs the virus scanner over an uploaded document and accepts or
+// rejects it. A scanner error leaves the document pending its scan.
+func (u *Uploader) scan(ctx context.Context, doc *Document) error {
+	now := u.now().UTC()
+	if u.scanner != nil {
+		download, err := u.objects.PresignGet(doc.Key, u.urlTTL)
+		if err != nil {
+			return fmt.Errorf("failed to sign scan URL: %w", err)
+		}
+		verdict, err := u.scanner.Scan(ctx, doc, download.URL)
+		if err != nil {
+			return fmt.Errorf("failed to scan document %s: %w", doc.ID, err)
+		}
+		doc.ScannedAt = &now
+		if !verdict.Clean {
+			u.logger.WithContext(ctx).WithFields(logger.Fields{
+				"document_id": doc.ID,
+				"owner_type":  doc.OwnerType,
+				"owner_id":    doc.OwnerID,
+				"reason":      verdict.Reason,
+			}).Warn("Uploaded document failed its virus scan")
+			return u.reject(ctx, doc, "virus scan: "+verdict.Reason)
+		}
+	}
+
+	doc.Status = StatusAccepted
+	doc.UpdatedAt = now
+	if err := u.store.Save(ctx, doc); err != nil {
+		return err
+	}
+
+	u.logger.WithContext(ctx).WithFields(logger.Fields{
+		"document_id":   doc.ID,
+		"owner_type":    doc.OwnerType,
+		"owner_id":      doc.OwnerID,
+		"document_type": doc.DocumentType,
+		"scanned":       doc.ScannedAt != nil,
+	}).Info("Uploaded document accepted")
+	return nil
+}
+
+// reject records why a document was rejected and deletes its file. It
+// returns ErrDocumentRejected with the reason.
+func (u *Uploader) reject(ctx context.Context, doc *Document, reason string) error {
+	doc.Status = StatusRejected
+	doc.RejectionReason = reason
+	doc.UpdatedAt = u.now().UTC()
+	if err := u.store.Save(ctx, doc); err != nil {
+		return err
+	}
+	if err := u.objects.Delete(ctx, doc.Key); err != nil {
+		u.logger.WithContext(ctx).WithError(err).WithFields(logger.Fields{
+			"document_id": doc.ID,
+		}).Error("Failed to delete rejected document file")
+	}
+	return fmt.Errorf("%w: %s", ErrDocumentRejected, reason)
+}
+
+func (u *Uploader) validate(req *UploadRequest) error {
+	if req.OwnerID == "" {
+		return fmt.Errorf("%w: owner ID is required", ErrInvalidUpload)
+	}
+
+	u.typesMux.RLock()
+	docType, known := u.types[string(req.OwnerType)+"/"+req.DocumentType]
+	u.typesMux.RUnlock()
+	if !known {
+		return fmt.Errorf("%w: unknown %s document type %q", ErrInvalidUpload, req.OwnerType, req.DocumentType)
+	}
+	if _, allowed := ContentTypes[req.ContentType]; !allowed {
+		return fmt.Errorf("%w: content type %q is not accepted", ErrInvalidUpload, req.ContentType)
+	}
+	if docType.Photo && !isImage(req.ContentType) {
+		return fmt.Errorf("%w: %s must be an image", ErrInvalidUpload, docType.Name)
+	}
+	if docType.RequiresNumber && strings.TrimSpace(req.DocumentNumber) == "" {
+		return fmt.Errorf("%w: %s requires a document number", ErrInvalidUpload, docType.Name)
+	}
+	if docType.RequiresExpiry && req.ExpiresAt == nil {
+		return fmt.Errorf("%w: %s requires an expiry date", ErrInvalidUpload, docType.Name)
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(u.now()) {
+		return fmt.Errorf("%w: document has already expired", ErrInvalidUpload)
+	}
+	return nil
+}
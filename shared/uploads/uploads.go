@@ -0,0 +1,130 @@
+// Package uploads handles the documents drivers upload, such as licences,
+// insurance certificates and registrations, and the photos of drivers and
+// vehicles. Files go straight from the client to the object store through
+// pre-signed URLs; this package records what each file is, who it belongs
+// to, whether it passed a virus scan and when it expires.
+package uploads
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// OwnerType is what a document belongs to
+type OwnerType string
+
+const (
+	OwnerDriver  OwnerType = "driver"
+	OwnerVehicle OwnerType = "vehicle"
+)
+
+// Status is how far a document has got from upload to acceptance
+type Status string
+
+const (
+	// StatusPendingUpload is a document whose upload URL has been handed out
+	StatusPendingUpload Status = "pending_upload"
+	// StatusPendingScan is an uploaded document waiting for its virus scan
+	StatusPendingScan Status = "pending_scan"
+	// StatusAccepted is a document that passed its checks and can be used
+	StatusAccepted Status = "accepted"
+	// StatusRejected is a document that failed its checks; its file is deleted
+	StatusRejected Status = "rejected"
+)
+
+// DocumentType describes a kind of document an owner can upload and what it
+// must carry
+type DocumentType struct {
+	Name           string
+	Owner          OwnerType
+	RequiresExpiry bool
+	RequiresNumber bool
+	// Photo types only accept images, and accepted ones are served resized
+	// through Media
+	Photo bool
+}
+
+// Document is the metadata of an uploaded file. The file itself lives in the
+// object store under Key.
+type Document struct {
+	ID              string     `json:"id"`
+	OwnerType       OwnerType  `json:"owner_type"`
+	OwnerID         string     `json:"owner_id"`
+	DocumentType    string     `json:"document_type"`
+	DocumentNumber  string     `json:"document_number,omitempty"`
+	Key             string     `json:"key"`
+	URL             string     `json:"url"`
+	ContentType     string     `json:"content_type"`
+	SizeBytes       int64      `json:"size_bytes,omitempty"`
+	Status          Status     `json:"status"`
+	RejectionReason string     `json:"rejection_reason,omitempty"`
+	ExpiresAt       *time.Time `json:"expires_at,omitempty"`
+	UploadedAt      *time.Time `json:"uploaded_at,omitempty"`
+	ScannedAt       *time.Time `json:"scanned_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// Expired reports whether the document's validity has lapsed at now
+func (d *Document) Expired(now time.Time) bool {
+	return d.ExpiresAt != nil && !d.ExpiresAt.After(now)
+}
+
+var (
+	// ErrDocumentNotFound is returned when a document does not exist
+	ErrDocumentNotFound = errors.New("document not found")
+	// ErrInvalidUpload is returned for upload requests with an unknown
+	// document type or content type, or missing details the type requires
+	ErrInvalidUpload = errors.New("invalid upload")
+	// ErrNotUploaded is returned when completing an upload whose file is not
+	// in the object store
+	ErrNotUploaded = errors.New("document file has not been uploaded")
+	// ErrDocumentRejected is returned when acting on a rejected document
+	ErrDocumentRejected = errors.New("document was rejected")
+	// ErrNotAccepted is returned when downloading a document that has not
+	// passed its checks
+	ErrNotAccepted = errors.New("document has not been accepted")
+	// ErrNotPhoto is returned when asking for the photo URLs of a document
+	// that is not a photo
+	ErrNotPhoto = errors.New("document is not a photo")
+	// ErrObjectNotFound is returned by object stores for missing objects
+	ErrObjectNotFound = errors.New("object not found")
+)
+
+// Store keeps document metadata
+type Store interface {
+	Save(ctx context.Context, doc *Document) error
+	Get(ctx context.Context, id string) (*Document, error)
+	// ListByOwner returns an owner's documents, newest first
+	ListByOwner(ctx context.Context, ownerType OwnerType, ownerID string) ([]*Document, error)
+	// ExpiringBefore returns accepted documents expiring before cutoff,
+	// soonest first
+	ExpiringBefore(ctx context.Context, cutoff time.Time) ([]*Document, error)
+}
+
+// Verdict is the outcome of a virus scan
+type Verdict struct {
+	Clean bool
+	// Reason says what was found in a file that is not clean
+	Reason string
+}
+
+// Scanner checks uploaded files for malware. It is given a short-lived URL
+// the file can be downloaded from.
+type Scanner interface {
+	Scan(ctx context.Context, doc *Document, downloadURL string) (Verdict, error)
+}
+
+// ScannerFunc adapts a function to the Scanner interface
+type ScannerFunc func(ctx context.Context, doc *Document, downloadURL string) (Verdict, error)
+
+// Scan calls f
+func (f ScannerFunc) Scan(ctx context.Context, doc *Document, downloadURL string) (Verdict, error) {
+	return f(ctx, doc, downloadURL)
+}
+
+// AcceptedHook is called with every document that passes its checks, so the
+// owning service can pick up its details such as the expiry date. Hooks must
+// be idempotent: they run again when an accepted upload is completed again.
+type AcceptedHook func(ctx context.Context, doc *Document) error